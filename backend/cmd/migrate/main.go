@@ -15,17 +15,26 @@ import (
 	"go.uber.org/zap"
 )
 
-const defaultMigrationsPath = "migrations"
+const (
+	defaultMigrationsPath = "migrations"
+	defaultSeedsPath      = "seeds"
+)
 
 func main() {
 	// Parse flags
 	var (
 		migrationsPath string
+		seedsPath      string
 		logLevel       string
+		dryRun         bool
+		forceChecksum  bool
 	)
 
 	flag.StringVar(&migrationsPath, "path", "", "Path to migrations directory (default: ./migrations)")
+	flag.StringVar(&seedsPath, "seeds-path", "", "Path to seeds directory (default: ./seeds)")
 	flag.StringVar(&logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the SQL that up/down/step/goto would run without applying it")
+	flag.BoolVar(&forceChecksum, "force-checksum", false, "Migrate even if an applied migration file has changed")
 	flag.Parse()
 
 	// Get command and arguments
@@ -36,11 +45,15 @@ func main() {
 	}
 	command := args[0]
 
-	// Initialize logger
+	// Initialize logger; plans print SQL to stdout, so they log to stderr
+	logOutput := "stdout"
+	if command == "plan" || dryRun {
+		logOutput = "stderr"
+	}
 	log, err := logger.New(&logger.Config{
 		Level:      logLevel,
 		Format:     "console",
-		Output:     "stdout",
+		Output:     logOutput,
 		TimeFormat: "2006-01-02 15:04:05",
 	})
 	if err != nil {
@@ -57,33 +70,15 @@ func main() {
 		log.Fatal("Failed to load configuration", zap.Error(err))
 	}
 
-	// Determine migrations path
-	if migrationsPath == "" {
-		// Try to find migrations directory relative to executable or current dir
-		if _, err := os.Stat(defaultMigrationsPath); err == nil {
-			migrationsPath = defaultMigrationsPath
-		} else {
-			// Try relative to executable
-			execPath, err := os.Executable()
-			if err == nil {
-				execDir := filepath.Dir(execPath)
-				candidatePath := filepath.Join(execDir, "..", "..", defaultMigrationsPath)
-				if _, err := os.Stat(candidatePath); err == nil {
-					migrationsPath = candidatePath
-				}
-			}
-		}
-		if migrationsPath == "" {
-			migrationsPath = defaultMigrationsPath
-		}
+	// Determine migrations and seeds paths
+	migrationsPath, err = resolveDir(migrationsPath, defaultMigrationsPath)
+	if err != nil {
+		log.Fatal("Failed to get absolute path", zap.Error(err))
 	}
-
-	// Convert to absolute path
-	absPath, err := filepath.Abs(migrationsPath)
+	seedsPath, err = resolveDir(seedsPath, defaultSeedsPath)
 	if err != nil {
 		log.Fatal("Failed to get absolute path", zap.Error(err))
 	}
-	migrationsPath = absPath
 
 	log.Info("Migration CLI started",
 		zap.String("command", command),
@@ -145,12 +140,69 @@ func main() {
 		log.Fatal("Failed to ping database", zap.Error(err))
 	}
 
+	// Handle plan and dry-run before creating the migrator, which would
+	// create the schema_migrations table if it is missing
+	if command == "plan" || dryRun {
+		if command == "plan" {
+			if len(args) < 2 {
+				log.Fatal("Plan command required. Usage: migrate plan <up [n]|down [n]|goto <version>>")
+			}
+			args = args[1:]
+		}
+
+		plan, err := buildPlan(migration.NewPlanner(db, migrationsPath), args)
+		if err != nil {
+			log.Fatal("Failed to plan migrations", zap.Error(err))
+		}
+		if err := plan.Write(os.Stdout); err != nil {
+			log.Fatal("Failed to write migration plan", zap.Error(err))
+		}
+		return
+	}
+
+	// Handle seed command (independent of schema migrations)
+	if command == "seed" {
+		results, err := migration.NewSeeder(db, seedsPath, log).Run(args[1:]...)
+		if err != nil {
+			log.Fatal("Seeding failed", zap.Error(err))
+		}
+		if len(results) == 0 {
+			log.Info("No seeds found", zap.String("seeds_path", seedsPath))
+			return
+		}
+		log.Info("Seeding completed", zap.Int("count", len(results)))
+		return
+	}
+
+	// Handle verify command (read-only)
+	if command == "verify" {
+		report, err := migration.VerifyChecksums(db, migrationsPath)
+		if err != nil {
+			log.Fatal("Failed to verify migrations", zap.Error(err))
+		}
+
+		if len(report.Results) == 0 {
+			log.Info("No applied migrations to verify")
+			return
+		}
+
+		if err := report.Write(os.Stdout); err != nil {
+			log.Fatal("Failed to write verification report", zap.Error(err))
+		}
+		if err := report.Err(); err != nil {
+			log.Fatal("Applied migrations have changed", zap.Error(err))
+		}
+		log.Info("Applied migrations match their files", zap.Int("count", len(report.Results)))
+		return
+	}
+
 	// Create migrator
 	m, err := migration.New(db, migrationsPath, log)
 	if err != nil {
 		log.Fatal("Failed to create migrator", zap.Error(err))
 	}
 	defer m.Close()
+	m.SetForceChecksum(forceChecksum)
 
 	// Execute command
 	switch command {
@@ -239,6 +291,81 @@ func main() {
 	}
 }
 
+// resolveDir returns the absolute path of a directory flag, falling back to
+// defaultDir in the current directory or relative to the executable
+func resolveDir(path, defaultDir string) (string, error) {
+	if path == "" {
+		// Try to find the directory relative to executable or current dir
+		if _, err := os.Stat(defaultDir); err == nil {
+			path = defaultDir
+		} else {
+			// Try relative to executable
+			execPath, err := os.Executable()
+			if err == nil {
+				execDir := filepath.Dir(execPath)
+				candidatePath := filepath.Join(execDir, "..", "..", defaultDir)
+				if _, err := os.Stat(candidatePath); err == nil {
+					path = candidatePath
+				}
+			}
+		}
+		if path == "" {
+			path = defaultDir
+		}
+	}
+
+	// Convert to absolute path
+	return filepath.Abs(path)
+}
+
+// buildPlan resolves the migrations that an up, down, step or goto command
+// would run. Unlike the down command, "down n" limits the rollback to n
+// migrations.
+func buildPlan(planner *migration.Planner, args []string) (*migration.Plan, error) {
+	command := args[0]
+	switch command {
+	case "up", "down":
+		limit := 0
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid migration count %q", args[1])
+			}
+			limit = n
+		}
+		if command == "up" {
+			return planner.Up(limit)
+		}
+		return planner.Down(limit)
+
+	case "step":
+		if len(args) < 2 {
+			return nil, fmt.Errorf("step count required. Usage: migrate step <n>")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n == 0 {
+			return nil, fmt.Errorf("invalid step count %q", args[1])
+		}
+		if n > 0 {
+			return planner.Up(n)
+		}
+		return planner.Down(-n)
+
+	case "goto":
+		if len(args) < 2 {
+			return nil, fmt.Errorf("version required. Usage: migrate goto <version>")
+		}
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version number %q", args[1])
+		}
+		return planner.GoTo(uint(version))
+
+	default:
+		return nil, fmt.Errorf("cannot plan command %q (supported: up, down, step, goto)", command)
+	}
+}
+
 func printUsage() {
 	fmt.Println(`ERP Database Migration Tool
 
@@ -250,15 +377,23 @@ Commands:
   down                  Roll back all migrations
   step <n>              Apply n migrations (positive=up, negative=down)
   goto <version>        Migrate to a specific version
+  plan up [n]           Print the SQL of the next n (default: all) pending migrations
+  plan down [n]         Print the rollback SQL of the last n (default: all) migrations
+  plan goto <version>   Print the SQL that migrating to a version would run
   version               Show current migration version
+  verify                Check applied migrations against their recorded checksums
   force <version>       Force set migration version (use with caution)
   drop -confirm         Drop all database objects (DANGEROUS)
   create <name> [desc]  Create a new migration file pair
   list                  List available migrations
+  seed [name...]        Apply idempotent seed scripts (all by default)
 
 Flags:
   -path string          Path to migrations directory (default: ./migrations)
+  -seeds-path string    Path to seeds directory (default: ./seeds)
   -log-level string     Log level: debug, info, warn, error (default: info)
+  -dry-run              Print the SQL for up/down/step/goto instead of applying it
+  -force-checksum       Migrate even if an applied migration file has changed
 
 Environment Variables:
   DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME, DB_SSL_MODE
@@ -270,6 +405,13 @@ Examples:
   # Roll back the last migration
   migrate step -1
 
+  # Review the SQL of pending migrations before applying them
+  migrate plan up
+  migrate -dry-run step -1
+
+  # Create default roles, customer levels and warehouses for all tenants
+  migrate seed
+
   # Create a new migration
   migrate create add_users_table "Create users table with basic fields"
 
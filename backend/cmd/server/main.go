@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,31 +16,40 @@ import (
 	featureflagapp "github.com/erp/backend/internal/application/featureflag"
 	financeapp "github.com/erp/backend/internal/application/finance"
 	identityapp "github.com/erp/backend/internal/application/identity"
+	integrationapp "github.com/erp/backend/internal/application/integration"
 	inventoryapp "github.com/erp/backend/internal/application/inventory"
 	partnerapp "github.com/erp/backend/internal/application/partner"
 	printingapp "github.com/erp/backend/internal/application/printing"
 	reportapp "github.com/erp/backend/internal/application/report"
 	tradeapp "github.com/erp/backend/internal/application/trade"
+	featureflagdomain "github.com/erp/backend/internal/domain/featureflag"
 	financedomain "github.com/erp/backend/internal/domain/finance"
+	integrationdomain "github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/domain/shared"
 	domainStrategy "github.com/erp/backend/internal/domain/shared/strategy"
 	"github.com/erp/backend/internal/infrastructure/auth"
 	"github.com/erp/backend/internal/infrastructure/billing"
 	"github.com/erp/backend/internal/infrastructure/cache"
 	"github.com/erp/backend/internal/infrastructure/config"
+	"github.com/erp/backend/internal/infrastructure/ecommerce"
 	"github.com/erp/backend/internal/infrastructure/event"
 	"github.com/erp/backend/internal/infrastructure/logger"
 	"github.com/erp/backend/internal/infrastructure/persistence"
+	"github.com/erp/backend/internal/infrastructure/persistence/tenant"
 	infraPlugin "github.com/erp/backend/internal/infrastructure/plugin"
 	infraPrinting "github.com/erp/backend/internal/infrastructure/printing"
 	"github.com/erp/backend/internal/infrastructure/printing/providers"
 	"github.com/erp/backend/internal/infrastructure/scheduler"
 	infraStorage "github.com/erp/backend/internal/infrastructure/storage"
 	infraStrategy "github.com/erp/backend/internal/infrastructure/strategy"
+	infraPricing "github.com/erp/backend/internal/infrastructure/strategy/pricing"
 	"github.com/erp/backend/internal/infrastructure/telemetry"
 	"github.com/erp/backend/internal/interfaces/http/handler"
 	"github.com/erp/backend/internal/interfaces/http/middleware"
 	"github.com/erp/backend/internal/interfaces/http/router"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
 	_ "github.com/erp/backend/docs" // OpenAPI 3.1 generated docs
@@ -112,6 +123,8 @@ func main() {
 			log.Error("Error shutting down tracer provider", zap.Error(err))
 		}
 	}()
+	// Repository spans follow tracing; traced repositories skip all tracing work while it is off
+	telemetry.SetRepositoryTracingEnabled(tracerProvider.IsEnabled())
 
 	// Initialize OpenTelemetry MeterProvider for metrics
 	meterProvider, err := telemetry.NewMeterProvider(context.Background(), telemetry.MetricsConfig{
@@ -130,6 +143,20 @@ func main() {
 		}
 	}()
 
+	// Business metrics (orders, payments, inventory adjustments) are recorded on the meter provider,
+	// which hands out no-op meters while telemetry is disabled
+	var businessMetrics *telemetry.BusinessMetrics
+	if cfg.Telemetry.BusinessMetricsEnabled {
+		businessMetrics, err = telemetry.NewBusinessMetrics(telemetry.BusinessMetricsConfig{
+			Meter:  meterProvider.Meter("erp.business"),
+			Logger: log,
+		})
+		if err != nil {
+			log.Fatal("Failed to initialize business metrics", zap.Error(err))
+		}
+		defer businessMetrics.Stop()
+	}
+
 	// Initialize OpenTelemetry LoggerProvider for logs bridge (Zap -> OTEL)
 	// This enables exporting Zap logs to OTEL Collector alongside traces and metrics
 	logsProvider, err := telemetry.NewLoggerProvider(context.Background(), telemetry.LogsConfig{
@@ -218,16 +245,19 @@ func main() {
 		log.Info("OpenTelemetry initialized",
 			zap.Bool("tracing", tracerProvider.IsEnabled()),
 			zap.Bool("metrics", meterProvider.IsEnabled()),
+			zap.Bool("business_metrics", businessMetrics != nil),
 			zap.Bool("logs", logsProvider.IsEnabled()),
 			zap.Bool("profiling", profiler.IsEnabled()),
 			zap.Bool("span_profiles", tracerProvider.IsSpanProfilesEnabled()),
+			zap.Bool("repository_spans", telemetry.RepositoryTracingEnabled()),
 			zap.String("collector", cfg.Telemetry.CollectorEndpoint),
 		)
 	}
 
 	// Create GORM logger backed by zap
+	// Its slow query warning is off: slow queries are logged by fingerprint below, without parameters
 	gormLogLevel := logger.MapGormLogLevel(cfg.Log.Level)
-	gormLog := logger.NewGormLogger(log, gormLogLevel)
+	gormLog := logger.NewGormLogger(log, gormLogLevel, logger.WithSlowThreshold(0))
 
 	// Initialize database connection with custom logger and tracing
 	db, err := persistence.NewDatabaseWithTracing(&cfg.Database, gormLog, &cfg.Telemetry, log)
@@ -256,52 +286,71 @@ func main() {
 		defer dbMetrics.Stop()
 	}
 
+	// Log slow queries by fingerprint, sharing the slow query metrics threshold
+	if err := telemetry.RegisterSlowQueryLog(db.DB, cfg.Telemetry.DBSlowQueryThresh, log); err != nil {
+		log.Error("Failed to register slow query logging", zap.Error(err))
+	}
+
+	// Scope tenant-owned tables to the request's tenant; strict mode also rejects statements without one
+	if cfg.Database.TenantGuard != "off" {
+		tenant.EnableAutoTenantFilter(db.DB, cfg.Database.TenantGuard == "strict")
+	}
+	// Background workers have no request tenant: they query across tenants and
+	// scope each tenant's work themselves (event delivery, scheduled jobs, queues)
+	workerCtx := tenant.WithCrossTenant(context.Background())
+
 	log.Info("Database connected successfully",
 		zap.Bool("tracing_enabled", cfg.Telemetry.DBTraceEnabled),
+		zap.String("tenant_guard", cfg.Database.TenantGuard),
 	)
 
 	// Initialize repositories
-	productRepo := persistence.NewGormProductRepository(db.DB)
-	productUnitRepo := persistence.NewGormProductUnitRepository(db.DB)
-	productAttachmentRepo := persistence.NewGormProductAttachmentRepository(db.DB)
-	categoryRepo := persistence.NewGormCategoryRepository(db.DB)
-	customerRepo := persistence.NewGormCustomerRepository(db.DB)
-	customerLevelRepo := persistence.NewGormCustomerLevelRepository(db.DB)
-	supplierRepo := persistence.NewGormSupplierRepository(db.DB)
-	warehouseRepo := persistence.NewGormWarehouseRepository(db.DB)
-	balanceTransactionRepo := persistence.NewGormBalanceTransactionRepository(db.DB)
-	inventoryItemRepo := persistence.NewGormInventoryItemRepository(db.DB)
-	stockBatchRepo := persistence.NewGormStockBatchRepository(db.DB)
-	stockLockRepo := persistence.NewGormStockLockRepository(db.DB)
-	inventoryTxRepo := persistence.NewGormInventoryTransactionRepository(db.DB)
-	salesOrderRepo := persistence.NewGormSalesOrderRepository(db.DB)
-	purchaseOrderRepo := persistence.NewGormPurchaseOrderRepository(db.DB)
-	salesReturnRepo := persistence.NewGormSalesReturnRepository(db.DB)
-	purchaseReturnRepo := persistence.NewGormPurchaseReturnRepository(db.DB)
-	stockTakingRepo := persistence.NewGormStockTakingRepository(db.DB)
-	userRepo := persistence.NewGormUserRepository(db.DB)
-	roleRepo := persistence.NewGormRoleRepository(db.DB)
-	tenantRepo := persistence.NewGormTenantRepository(db.DB)
-	planFeatureRepo := persistence.NewGormPlanFeatureRepository(db.DB)
-	salesReportRepo := persistence.NewGormSalesReportRepository(db.DB)
-	inventoryReportRepo := persistence.NewGormInventoryReportRepository(db.DB)
-	financeReportRepo := persistence.NewGormFinanceReportRepository(db.DB)
+	productRepo := persistence.NewTracedGormProductRepository(persistence.NewGormProductRepository(db.DB))
+	productUnitRepo := persistence.NewTracedGormProductUnitRepository(persistence.NewGormProductUnitRepository(db.DB))
+	productAttachmentRepo := persistence.NewTracedGormProductAttachmentRepository(persistence.NewGormProductAttachmentRepository(db.DB))
+	categoryRepo := persistence.NewTracedGormCategoryRepository(persistence.NewGormCategoryRepository(db.DB))
+	customerRepo := persistence.NewTracedGormCustomerRepository(persistence.NewGormCustomerRepository(db.DB))
+	customerLevelRepo := persistence.NewTracedGormCustomerLevelRepository(persistence.NewGormCustomerLevelRepository(db.DB))
+	supplierRepo := persistence.NewTracedGormSupplierRepository(persistence.NewGormSupplierRepository(db.DB))
+	partnerAddressRepo := persistence.NewTracedGormPartnerAddressRepository(persistence.NewGormPartnerAddressRepository(db.DB))
+	warehouseRepo := persistence.NewTracedGormWarehouseRepository(persistence.NewGormWarehouseRepository(db.DB))
+	balanceTransactionRepo := persistence.NewTracedGormBalanceTransactionRepository(persistence.NewGormBalanceTransactionRepository(db.DB))
+	inventoryItemRepo := persistence.NewTracedGormInventoryItemRepository(persistence.NewGormInventoryItemRepository(db.DB))
+	stockBatchRepo := persistence.NewTracedGormStockBatchRepository(persistence.NewGormStockBatchRepository(db.DB))
+	stockLockRepo := persistence.NewTracedGormStockLockRepository(persistence.NewGormStockLockRepository(db.DB))
+	inventoryTxRepo := persistence.NewTracedGormInventoryTransactionRepository(persistence.NewGormInventoryTransactionRepository(db.DB))
+	salesOrderRepo := persistence.NewTracedGormSalesOrderRepository(persistence.NewGormSalesOrderRepository(db.DB))
+	purchaseOrderRepo := persistence.NewTracedGormPurchaseOrderRepository(persistence.NewGormPurchaseOrderRepository(db.DB))
+	salesReturnRepo := persistence.NewTracedGormSalesReturnRepository(persistence.NewGormSalesReturnRepository(db.DB))
+	purchaseReturnRepo := persistence.NewTracedGormPurchaseReturnRepository(persistence.NewGormPurchaseReturnRepository(db.DB))
+	stockTakingRepo := persistence.NewTracedGormStockTakingRepository(persistence.NewGormStockTakingRepository(db.DB))
+	userRepo := persistence.NewTracedGormUserRepository(persistence.NewGormUserRepository(db.DB))
+	roleRepo := persistence.NewTracedGormRoleRepository(persistence.NewGormRoleRepository(db.DB))
+	tenantRepo := persistence.NewTracedGormTenantRepository(persistence.NewGormTenantRepository(db.DB))
+	planFeatureRepo := persistence.NewTracedGormPlanFeatureRepository(persistence.NewGormPlanFeatureRepository(db.DB))
+	tenantStrategyConfigRepo := persistence.NewTracedGormTenantStrategyConfigRepository(persistence.NewGormTenantStrategyConfigRepository(db.DB))
+	salesReportRepo := persistence.NewTracedGormSalesReportRepository(persistence.NewGormSalesReportRepository(db.DB))
+	inventoryReportRepo := persistence.NewTracedGormInventoryReportRepository(persistence.NewGormInventoryReportRepository(db.DB))
+	financeReportRepo := persistence.NewTracedGormFinanceReportRepository(persistence.NewGormFinanceReportRepository(db.DB))
+	returnReportRepo := persistence.NewTracedGormReturnReportRepository(persistence.NewGormReturnReportRepository(db.DB))
 	reportCacheRepo := reportapp.NewGormReportCacheRepository(db.DB)
-	receiptVoucherRepo := persistence.NewGormReceiptVoucherRepository(db.DB)
-	paymentVoucherRepo := persistence.NewGormPaymentVoucherRepository(db.DB)
-	accountReceivableRepo := persistence.NewGormAccountReceivableRepository(db.DB)
-	accountPayableRepo := persistence.NewGormAccountPayableRepository(db.DB)
-	expenseRecordRepo := persistence.NewGormExpenseRecordRepository(db.DB)
-	otherIncomeRecordRepo := persistence.NewGormOtherIncomeRecordRepository(db.DB)
+	dailySalesFactRepo := persistence.NewTracedGormDailySalesFactRepository(persistence.NewGormDailySalesFactRepository(db.DB))
+	receiptVoucherRepo := persistence.NewTracedGormReceiptVoucherRepository(persistence.NewGormReceiptVoucherRepository(db.DB))
+	paymentVoucherRepo := persistence.NewTracedGormPaymentVoucherRepository(persistence.NewGormPaymentVoucherRepository(db.DB))
+	voucherIdempotencyKeyRepo := persistence.NewTracedGormVoucherIdempotencyKeyRepository(persistence.NewGormVoucherIdempotencyKeyRepository(db.DB))
+	accountReceivableRepo := persistence.NewTracedGormAccountReceivableRepository(persistence.NewGormAccountReceivableRepository(db.DB))
+	accountPayableRepo := persistence.NewTracedGormAccountPayableRepository(persistence.NewGormAccountPayableRepository(db.DB))
+	expenseRecordRepo := persistence.NewTracedGormExpenseRecordRepository(persistence.NewGormExpenseRecordRepository(db.DB))
+	otherIncomeRecordRepo := persistence.NewTracedGormOtherIncomeRecordRepository(persistence.NewGormOtherIncomeRecordRepository(db.DB))
 	outboxRepo := event.NewGormOutboxRepository(db.DB)
 
 	// Feature flag repositories
-	featureFlagRepo := persistence.NewGormFeatureFlagRepository(db.DB)
-	flagOverrideRepo := persistence.NewGormFlagOverrideRepository(db.DB)
-	flagAuditLogRepo := persistence.NewGormFlagAuditLogRepository(db.DB)
+	featureFlagRepo := persistence.NewTracedGormFeatureFlagRepository(persistence.NewGormFeatureFlagRepository(db.DB))
+	flagOverrideRepo := persistence.NewTracedGormFlagOverrideRepository(persistence.NewGormFlagOverrideRepository(db.DB))
+	flagAuditLogRepo := persistence.NewTracedGormFlagAuditLogRepository(persistence.NewGormFlagAuditLogRepository(db.DB))
 
 	// Print job repository (templates are now static, no repository needed)
-	printJobRepo := persistence.NewGormPrintJobRepository(db.DB)
+	printJobRepo := persistence.NewTracedGormPrintJobRepository(persistence.NewGormPrintJobRepository(db.DB))
 
 	// Initialize event serializer and register all event types
 	eventSerializer := event.NewEventSerializer()
@@ -319,6 +368,12 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to initialize strategy registry", zap.Error(err))
 	}
+	// Tenants can choose their own strategies in place of the defaults
+	strategyRegistry.SetTenantConfigRepository(tenantStrategyConfigRepo)
+	// Tiered pricing uses the quantity breaks defined on each product
+	if err := strategyRegistry.SetPriceTierProvider(infraPricing.NewProductPriceTierProvider(productRepo)); err != nil {
+		log.Fatal("Failed to configure tiered pricing", zap.Error(err))
+	}
 	log.Info("Strategy registry initialized",
 		zap.Int("cost_strategies", strategyRegistry.Stats()[domainStrategy.StrategyTypeCost]),
 		zap.Int("allocation_strategies", strategyRegistry.Stats()[domainStrategy.StrategyTypeAllocation]),
@@ -342,6 +397,20 @@ func main() {
 		)
 	}
 
+	// Retail/F&B plugin provides shelf life, storage temperature and allergen validation
+	// and marks down stock approaching its expiry date
+	retailFnBPlugin := infraPlugin.NewRetailFnBPlugin()
+	retailFnBPlugin.SetExpiryProvider(infraPlugin.NewInventoryExpiryDateProvider(inventoryItemRepo, stockBatchRepo))
+	if err := pluginManager.Register(retailFnBPlugin); err != nil {
+		log.Error("Failed to register retail/F&B plugin", zap.Error(err))
+	} else {
+		log.Info("Industry plugin registered",
+			zap.String("plugin", retailFnBPlugin.Name()),
+			zap.String("display_name", retailFnBPlugin.DisplayName()),
+			zap.Int("required_attributes", len(retailFnBPlugin.GetRequiredProductAttributes())),
+		)
+	}
+
 	log.Info("Plugin manager initialized",
 		zap.Int("total_plugins", pluginManager.Count()),
 		zap.Strings("plugins", pluginManager.ListPlugins()),
@@ -352,6 +421,10 @@ func main() {
 	productService.SetSalesOrderRepo(salesOrderRepo)
 	productService.SetPurchaseOrderRepo(purchaseOrderRepo)
 	productService.SetInventoryRepo(inventoryItemRepo)
+	productService.SetPriceHistoryRepo(persistence.NewTracedGormProductPriceHistoryRepository(persistence.NewGormProductPriceHistoryRepository(db.DB)))
+	productService.SetTransactionScope(persistence.NewGormCatalogTransactionScope(db.DB))
+	// Enforce required product attributes of the industry plugin each tenant has enabled
+	productService.SetIndustryPlugins(pluginManager, tenantRepo)
 	productUnitService := catalogapp.NewProductUnitService(productRepo, productUnitRepo)
 	categoryService := catalogapp.NewCategoryService(categoryRepo, productRepo)
 
@@ -375,19 +448,43 @@ func main() {
 	customerService := partnerapp.NewCustomerService(customerRepo)
 	customerService.SetAccountReceivableRepo(accountReceivableRepo)
 	customerService.SetSalesOrderRepo(salesOrderRepo)
+	customerService.SetTransactionScope(persistence.NewGormPartnerTransactionScope(db.DB))
 	customerLevelService := partnerapp.NewCustomerLevelService(customerLevelRepo)
 	supplierService := partnerapp.NewSupplierService(supplierRepo)
 	supplierService.SetAccountPayableRepo(accountPayableRepo)
 	supplierService.SetPurchaseOrderRepo(purchaseOrderRepo)
+	addressService := partnerapp.NewAddressService(partnerAddressRepo, customerRepo, supplierRepo)
+	addressService.SetTransactionScope(persistence.NewGormPartnerTransactionScope(db.DB))
 	warehouseService := partnerapp.NewWarehouseService(warehouseRepo, inventoryItemRepo)
+	warehouseService.SetLoadReader(inventoryItemRepo)
 	balanceTransactionService := partnerapp.NewBalanceTransactionService(balanceTransactionRepo, customerRepo)
+	balanceTransactionService.SetTransactionScope(persistence.NewGormPartnerTransactionScope(db.DB))
 	inventoryService := inventoryapp.NewInventoryService(inventoryItemRepo, stockBatchRepo, stockLockRepo, inventoryTxRepo)
+	// Run stock operations in a database transaction, so an operation that touches several items
+	// (e.g. a transfer) is written completely or not at all
+	inventoryService.SetTransactionScope(persistence.NewGormTransactionScope(db.DB))
+	inventoryService.SetStrategyProvider(strategyRegistry)
+	inventoryService.SetTenantStrategyResolver(strategyRegistry)
+	inventoryService.SetReorderSources(purchaseOrderRepo, productUnitRepo)
+	inventoryService.SetWarehouseReader(warehouseRepo)
+	if cfg.WarehouseCapacity.AlertEnabled {
+		inventoryService.SetCapacityMonitor(inventoryItemRepo, cfg.WarehouseCapacity.NearCapacityThreshold)
+	}
+	inventoryService.SetSerialNumberTracking(productRepo, persistence.NewTracedGormSerialNumberRepository(persistence.NewGormSerialNumberRepository(db.DB)))
+	inventoryService.SetExpiryTenantReader(tenantRepo)
+	inventoryService.SetBusinessMetrics(businessMetrics)
 	stockLockExpirationService := inventoryapp.NewStockLockExpirationService(stockLockRepo, inventoryItemRepo, nil, log) // eventBus will be set later
 	salesOrderService := tradeapp.NewSalesOrderService(salesOrderRepo)
+	salesOrderService.SetQuoteValidity(cfg.SalesQuote.DefaultValidity)
+	salesOrderService.SetBusinessMetrics(businessMetrics)
+	salesOrderService.SetShippingAddressChecker(addressService)
+	salesQuoteExpirationService := tradeapp.NewSalesQuoteExpirationService(salesOrderRepo, log)
 	purchaseOrderService := tradeapp.NewPurchaseOrderService(purchaseOrderRepo)
+	purchaseOrderService.SetBusinessMetrics(businessMetrics)
 	salesReturnService := tradeapp.NewSalesReturnService(salesReturnRepo, salesOrderRepo)
 	purchaseReturnService := tradeapp.NewPurchaseReturnService(purchaseReturnRepo, purchaseOrderRepo)
 	stockTakingService := inventoryapp.NewStockTakingService(stockTakingRepo, nil) // eventBus will be set later
+	stockTakingService.SetInventoryAdjustment(inventoryService, persistence.NewGormTransactionScope(db.DB))
 
 	// Identity services (auth, user, role, tenant)
 	jwtService := auth.NewJWTService(cfg.JWT)
@@ -396,6 +493,7 @@ func main() {
 	// Initialize token blacklist for secure logout and session invalidation
 	// This uses Redis to store blacklisted token JTIs and user invalidation timestamps
 	var tokenBlacklist auth.TokenBlacklist
+	var sessionStore auth.SessionStore
 	tokenBlacklistCfg := auth.RedisTokenBlacklistConfig{
 		Host:     cfg.Redis.Host,
 		Port:     cfg.Redis.Port,
@@ -408,54 +506,110 @@ func main() {
 			zap.Error(err),
 			zap.String("note", "In-memory blacklist does not persist across restarts and does not work in multi-instance deployments"))
 		tokenBlacklist = auth.NewInMemoryTokenBlacklist()
+		sessionStore = auth.NewInMemorySessionStore()
 	} else {
 		tokenBlacklist = redisBlacklist
+		sessionStore = auth.NewRedisSessionStoreWithClient(redisBlacklist.GetClient())
 		log.Info("Token blacklist initialized with Redis",
 			zap.String("host", cfg.Redis.Host),
 			zap.Int("port", cfg.Redis.Port))
 	}
 
-	// Set token blacklist on auth service for logout and password change handling
+	// Set token blacklist and session store on auth service for logout, password change and session revocation
 	authService.SetTokenBlacklist(tokenBlacklist)
+	authService.SetSessionStore(sessionStore)
+
+	// Enforce tenant password policies on password changes and resets
+	passwordPolicyService := identityapp.NewPasswordPolicyService(tenantRepo, persistence.NewTracedGormPasswordHistoryRepository(persistence.NewGormPasswordHistoryRepository(db.DB)), log)
+	authService.SetPasswordPolicyService(passwordPolicyService)
 
 	userService := identityapp.NewUserService(userRepo, roleRepo, log)
+	userService.SetPasswordPolicyService(passwordPolicyService)
 	roleService := identityapp.NewRoleService(roleRepo, userRepo, log)
 	tenantService := identityapp.NewTenantService(tenantRepo, log)
+	tenantExportService := identityapp.NewTenantExportService(tenantRepo, userRepo, customerRepo, salesOrderRepo, accountReceivableRepo, log)
 
 	// Report services
-	reportService := reportapp.NewReportService(salesReportRepo, inventoryReportRepo, financeReportRepo)
+	reportService := reportapp.NewReportService(salesReportRepo, inventoryReportRepo, financeReportRepo, returnReportRepo)
 	reportAggregationService := reportapp.NewReportAggregationService(
 		salesReportRepo, inventoryReportRepo, financeReportRepo, reportCacheRepo, log,
 	)
+	dailySalesFactService := reportapp.NewDailySalesFactService(dailySalesFactRepo, log)
+	reportAggregationService.SetDailySalesFactService(dailySalesFactService)
 
 	// Payment callback service (for external payment gateway notifications)
-	// Note: Payment gateways (WeChat, Alipay) are registered at runtime via config
+	// Note: Payment gateways (WeChat, Alipay) are registered at runtime via config.
+	// Other gateways are registered by name and served from /payment/callback/:gateway.
+	paymentGatewayRegistry := financeapp.NewGatewayRegistry()
 	paymentCallbackService := financeapp.NewPaymentCallbackService(financeapp.PaymentCallbackServiceConfig{
 		Gateways:           nil, // Gateways will be registered via RegisterGateway()
+		Registry:           paymentGatewayRegistry,
 		ReceiptVoucherRepo: receiptVoucherRepo,
 		ReceivableRepo:     accountReceivableRepo,
 		EventPublisher:     nil, // Will be set after event bus init
+		BusinessMetrics:    businessMetrics,
 		Logger:             log,
 	})
 
+	// E-commerce platform order import
+	// Note: Platform adapters (Taobao, Douyin) are registered at runtime via config.
+	platformRegistry := ecommerce.NewPlatformRegistry()
+	productMappingRepo := persistence.NewTracedGormProductMappingRepository(persistence.NewGormProductMappingRepository(db.DB))
+	orderImportService := integrationapp.NewOrderImportService(integrationapp.OrderImportServiceConfig{
+		Platforms:   platformRegistry,
+		Mappings:    productMappingRepo,
+		SyncRecords: persistence.NewTracedGormOrderSyncRecordRepository(persistence.NewGormOrderSyncRecordRepository(db.DB)),
+		SyncConfigs: persistence.NewTracedGormOrderSyncConfigRepository(persistence.NewGormOrderSyncConfigRepository(db.DB)),
+		Products:    productRepo,
+		SalesOrders: salesOrderService,
+		Logger:      log,
+	})
+	mappingSuggestionService := integrationapp.NewMappingSuggestionService(platformRegistry, productMappingRepo, productRepo)
+	orderStatusPushService := integrationapp.NewOrderStatusPushService(integrationapp.OrderStatusPushServiceConfig{
+		Platforms:   platformRegistry,
+		SyncRecords: persistence.NewTracedGormOrderSyncRecordRepository(persistence.NewGormOrderSyncRecordRepository(db.DB)),
+		Pushes:      persistence.NewTracedGormOrderStatusPushRepository(persistence.NewGormOrderStatusPushRepository(db.DB)),
+		Logger:      log,
+		BaseBackoff: cfg.OrderStatusPush.BaseBackoff,
+		MaxBackoff:  cfg.OrderStatusPush.MaxBackoff,
+	})
+
 	// Expense and income service
 	expenseIncomeService := financeapp.NewExpenseIncomeService(expenseRecordRepo, otherIncomeRecordRepo, receiptVoucherRepo, paymentVoucherRepo)
+	expenseIncomeService.SetTenantReader(tenantRepo)
+	expenseAttachmentStorage, err := infraPrinting.NewFileSystemStorage(&infraPrinting.FileSystemStorageConfig{
+		BasePath: "./storage/expense-attachments",
+		BaseURL:  "/expense-attachments",
+		Logger:   log,
+	})
+	if err != nil {
+		log.Warn("Failed to initialize expense attachment storage, expense attachments will be unavailable", zap.Error(err))
+	} else {
+		expenseIncomeService.SetAttachmentStorage(expenseAttachmentStorage)
+	}
 
 	// Finance core service (receivables, payables, vouchers)
-	// Configure with FIFO as default reconciliation strategy (injected from strategy registry)
+	// Configure with FIFO as default reconciliation strategy, overridden by the tenant's choice in the strategy registry
 	financeService := financeapp.NewFinanceService(
 		accountReceivableRepo,
 		accountPayableRepo,
 		receiptVoucherRepo,
 		paymentVoucherRepo,
 		financeapp.WithReconciliationStrategy(financedomain.ReconciliationStrategyTypeFIFO),
+		financeapp.WithReconciliationStrategyOverride(func(ctx context.Context, tenantID uuid.UUID) financedomain.ReconciliationStrategyType {
+			return financedomain.ReconciliationStrategyType(strategyRegistry.TenantReconciliationStrategy(ctx, tenantID))
+		}),
+		financeapp.WithCustomerReader(customerRepo),
+		financeapp.WithPurchaseOrderReader(purchaseOrderRepo),
+		financeapp.WithTransactionScope(persistence.NewGormFinanceTransactionScope(db.DB)),
+		financeapp.WithIdempotencyKeyRepository(voucherIdempotencyKeyRepo),
 	)
+	financeService.SetBusinessMetrics(businessMetrics)
 	// Log strategy configuration
 	log.Info("Finance service configured",
 		zap.String("default_reconciliation_strategy", financeService.GetReconciliationService().GetDefaultStrategy().String()),
 	)
-	// Keep reference to strategy registry for potential future use (tenant-specific strategies)
-	_ = strategyRegistry
+	receivableReminderService := financeapp.NewReceivableReminderService(accountReceivableRepo, cfg.ReceivableReminder.LeadTime, log)
 
 	// Feature flag services
 	flagService := featureflagapp.NewFlagService(
@@ -464,11 +618,34 @@ func main() {
 		outboxRepo,
 		log,
 	)
-	evaluationService := featureflagapp.NewEvaluationService(
-		featureFlagRepo,
-		flagOverrideRepo,
-		log,
-	)
+	// Evaluations read flags and overrides through an in-process cache when enabled;
+	// changed entries are evicted by the cache invalidation event handler
+	var evaluationService *featureflagapp.EvaluationService
+	if cfg.FeatureFlags.CacheEnabled {
+		flagCacheConfig := featureflagdomain.DefaultCacheConfig()
+		flagCacheConfig.FlagTTL = cfg.FeatureFlags.CacheTTL
+		flagCacheConfig.OverrideTTL = cfg.FeatureFlags.CacheTTL
+		flagCache := cache.NewInMemoryFeatureFlagCache(
+			cache.WithInMemoryConfig(flagCacheConfig),
+			cache.WithInMemoryLogger(log),
+		)
+		defer flagCache.Close()
+		evaluationService = featureflagapp.NewCachedEvaluationServiceWithConfig(
+			featureFlagRepo,
+			flagOverrideRepo,
+			flagCache,
+			flagCacheConfig,
+			log,
+		)
+		log.Info("Feature flag evaluation cache enabled",
+			zap.Duration("ttl", cfg.FeatureFlags.CacheTTL))
+	} else {
+		evaluationService = featureflagapp.NewEvaluationService(
+			featureFlagRepo,
+			flagOverrideRepo,
+			log,
+		)
+	}
 	overrideService := featureflagapp.NewOverrideService(
 		featureFlagRepo,
 		flagOverrideRepo,
@@ -518,44 +695,130 @@ func main() {
 		dataProviderRegistry,
 		log,
 	)
+	// Print job queue renders PDFs queued via POST /print/jobs in the background
+	printJobQueue := printingapp.NewPrintJobQueue(printService, printingapp.PrintJobQueueConfig{Logger: log})
+	if err := printJobQueue.Start(workerCtx); err != nil {
+		log.Warn("Failed to recover pending print jobs", zap.Error(err))
+	}
+
+	// Initialize event bus and handlers. The in-memory bus only reaches handlers in this
+	// instance; with partitions configured, it delivers asynchronously in publish order
+	// per aggregate. The Redis bus shares events across all instances.
+	var eventBus shared.EventBus
+	switch cfg.Event.BusType {
+	case "redis":
+		eventBusRedisClient := redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		defer eventBusRedisClient.Close()
+		eventBus = event.NewRedisEventBus(eventBusRedisClient, eventSerializer, log,
+			event.WithRedisEventStream(cfg.Event.BusStream),
+			event.WithRedisEventConsumerGroup(cfg.Event.BusConsumerGroup),
+		)
+		log.Info("Using Redis event bus",
+			zap.String("stream", cfg.Event.BusStream),
+			zap.String("consumer_group", cfg.Event.BusConsumerGroup),
+		)
+	default:
+		eventBus = event.NewInMemoryEventBus(log, event.WithOrderedDelivery(cfg.Event.BusPartitions))
+	}
 
-	// Initialize event bus and handlers
-	eventBus := event.NewInMemoryEventBus(log)
+	// Handlers subscribe through a decorator that claims each event per handler before
+	// handling it, so an event redelivered by the outbox is processed at most once per
+	// handler. A failed event is released again so the redelivery retries it.
+	processedEventStore := event.NewGormProcessedEventStore(db.DB)
+	eventSubscriber := event.NewIdempotentSubscriber(eventBus, processedEventStore, log,
+		event.WithIdempotencyConfig(shared.IdempotencyConfig{Enabled: true, PerHandler: true, RetryOnFailure: true}),
+		event.WithIdempotencyMetrics(event.GlobalIdempotencyMetrics),
+	)
 
 	// Register event handlers for cross-context integration
 	// Purchase order receiving -> inventory increase
 	purchaseOrderReceivedHandler := tradeapp.NewPurchaseOrderReceivedHandler(inventoryService, log)
-	eventBus.Subscribe(purchaseOrderReceivedHandler)
+	eventSubscriber.Subscribe(purchaseOrderReceivedHandler)
 
 	// Sales order confirmed -> stock locking
 	salesOrderConfirmedHandler := tradeapp.NewSalesOrderConfirmedHandler(inventoryService, log)
-	eventBus.Subscribe(salesOrderConfirmedHandler)
+	eventSubscriber.Subscribe(salesOrderConfirmedHandler)
 
 	// Sales order shipped -> stock deduction
 	salesOrderShippedHandler := tradeapp.NewSalesOrderShippedHandler(inventoryService, log)
-	eventBus.Subscribe(salesOrderShippedHandler)
+	eventSubscriber.Subscribe(salesOrderShippedHandler)
 
 	// Sales order cancelled -> stock unlock
 	salesOrderCancelledHandler := tradeapp.NewSalesOrderCancelledHandler(inventoryService, log)
-	eventBus.Subscribe(salesOrderCancelledHandler)
+	eventSubscriber.Subscribe(salesOrderCancelledHandler)
 
 	// Sales return completed -> inventory restoration
 	salesReturnCompletedHandler := tradeapp.NewSalesReturnCompletedHandler(inventoryService, log)
-	eventBus.Subscribe(salesReturnCompletedHandler)
+	eventSubscriber.Subscribe(salesReturnCompletedHandler)
 
 	// Sales return cancelled -> inventory reversal (if goods were received)
 	salesReturnCancelledHandler := tradeapp.NewSalesReturnCancelledHandler(inventoryService, log)
-	eventBus.Subscribe(salesReturnCancelledHandler)
+	eventSubscriber.Subscribe(salesReturnCancelledHandler)
 
 	// Purchase return shipped -> inventory deduction
 	purchaseReturnShippedHandler := tradeapp.NewPurchaseReturnShippedHandler(inventoryService, log)
-	eventBus.Subscribe(purchaseReturnShippedHandler)
+	eventSubscriber.Subscribe(purchaseReturnShippedHandler)
+
+	// Stock lock expired -> warn about confirmed sales orders that lost their reservation
+	stockLockExpiredHandler := tradeapp.NewStockLockExpiredHandler(salesOrderRepo, log)
+	eventSubscriber.Subscribe(stockLockExpiredHandler)
 
 	// Stock below threshold -> notifications/alerts
 	stockBelowThresholdNotifier := inventoryapp.NewLoggingStockAlertNotifier(log)
 	stockBelowThresholdHandler := inventoryapp.NewStockBelowThresholdHandler(log).
 		WithNotifier(stockBelowThresholdNotifier)
-	eventBus.Subscribe(stockBelowThresholdHandler)
+	eventSubscriber.Subscribe(stockBelowThresholdHandler)
+
+	// Supplier changes -> refresh the supplier reference cache and supplier names on payables
+	supplierReferenceCache := cache.NewInMemorySupplierReferenceCache()
+	supplierReferenceHandler := financeapp.NewSupplierReferenceHandler(accountPayableRepo, supplierReferenceCache, log)
+	eventSubscriber.Subscribe(supplierReferenceHandler)
+
+	// Stock changes -> push available quantity to mapped e-commerce platform listings
+	stockPushPlatforms := make([]integrationdomain.PlatformCode, 0, len(cfg.StockPush.Platforms))
+	for _, code := range cfg.StockPush.Platforms {
+		stockPushPlatforms = append(stockPushPlatforms, integrationdomain.PlatformCode(strings.ToUpper(code)))
+	}
+	stockPushHandler := integrationapp.NewStockPushHandler(integrationapp.StockPushHandlerConfig{
+		Platforms:        platformRegistry,
+		Mappings:         productMappingRepo,
+		Stock:            inventoryItemRepo,
+		EnabledPlatforms: stockPushPlatforms,
+		Debounce:         cfg.StockPush.Debounce,
+		Logger:           log,
+	})
+	eventSubscriber.Subscribe(stockPushHandler)
+	defer func() {
+		if err := stockPushHandler.Stop(workerCtx); err != nil {
+			log.Error("Error stopping stock push handler", zap.Error(err))
+		}
+	}()
+
+	// Platform order shipped -> push shipment and tracking number back to the platform
+	platformShipmentHandler := integrationapp.NewSalesOrderShippedHandler(orderStatusPushService, log)
+	eventSubscriber.Subscribe(platformShipmentHandler)
+
+	// Feature flag and override changes -> evict them from the evaluation cache
+	flagCacheInvalidationHandler := featureflagapp.NewCacheInvalidationHandler(evaluationService, log)
+	eventSubscriber.Subscribe(flagCacheInvalidationHandler)
+
+	// Sales, stock and reconciliation changes -> mark the tenant's affected cached reports dirty
+	reportCacheInvalidationHandler := reportapp.NewCacheInvalidationHandler(reportAggregationService, log)
+	eventSubscriber.Subscribe(reportCacheInvalidationHandler)
+
+	// Sales order completed -> rebuild the tenant's daily sales facts for the completion day
+	dailySalesFactHandler := reportapp.NewDailySalesFactHandler(dailySalesFactService, log)
+	eventSubscriber.Subscribe(dailySalesFactHandler)
+
+	// Sales order completed / receivable paid -> promote or demote the customer by its spend
+	customerLevelUpgradeService := partnerapp.NewCustomerLevelUpgradeService(customerRepo, customerLevelRepo, salesOrderRepo)
+	customerLevelUpgradeService.SetEventPublisher(eventBus)
+	customerLevelUpgradeHandler := partnerapp.NewCustomerLevelUpgradeHandler(customerLevelUpgradeService, log)
+	eventSubscriber.Subscribe(customerLevelUpgradeHandler)
 
 	log.Info("Event handlers registered",
 		zap.Strings("purchase_order_received_events", purchaseOrderReceivedHandler.EventTypes()),
@@ -565,11 +828,20 @@ func main() {
 		zap.Strings("sales_return_completed_events", salesReturnCompletedHandler.EventTypes()),
 		zap.Strings("sales_return_cancelled_events", salesReturnCancelledHandler.EventTypes()),
 		zap.Strings("purchase_return_shipped_events", purchaseReturnShippedHandler.EventTypes()),
+		zap.Strings("stock_lock_expired_events", stockLockExpiredHandler.EventTypes()),
 		zap.Strings("stock_below_threshold_events", stockBelowThresholdHandler.EventTypes()),
+		zap.Strings("supplier_reference_events", supplierReferenceHandler.EventTypes()),
+		zap.Strings("stock_push_events", stockPushHandler.EventTypes()),
+		zap.Strings("stock_push_platforms", cfg.StockPush.Platforms),
+		zap.Strings("platform_shipment_events", platformShipmentHandler.EventTypes()),
+		zap.Strings("flag_cache_invalidation_events", flagCacheInvalidationHandler.EventTypes()),
+		zap.Strings("report_cache_invalidation_events", reportCacheInvalidationHandler.EventTypes()),
+		zap.Strings("daily_sales_fact_events", dailySalesFactHandler.EventTypes()),
+		zap.Strings("customer_level_upgrade_events", customerLevelUpgradeHandler.EventTypes()),
 	)
 
 	// Start event bus
-	if err := eventBus.Start(context.Background()); err != nil {
+	if err := eventBus.Start(workerCtx); err != nil {
 		log.Fatal("Failed to start event bus", zap.Error(err))
 	}
 	defer func() {
@@ -581,8 +853,11 @@ func main() {
 	// Initialize and start outbox processor for guaranteed event delivery
 	// The outbox processor reads events from the outbox_events table and publishes them to the event bus
 	outboxProcessorConfig := event.DefaultOutboxProcessorConfig()
+	outboxProcessorConfig.MaxRetries = cfg.Event.MaxRetries
+	outboxProcessorConfig.BaseBackoff = cfg.Event.RetryBaseBackoff
+	outboxProcessorConfig.MaxBackoff = cfg.Event.RetryMaxBackoff
 	outboxProcessor := event.NewOutboxProcessor(outboxRepo, eventBus, eventSerializer, outboxProcessorConfig, log)
-	if err := outboxProcessor.Start(context.Background()); err != nil {
+	if err := outboxProcessor.Start(workerCtx); err != nil {
 		log.Fatal("Failed to start outbox processor", zap.Error(err))
 	}
 	defer func() {
@@ -595,8 +870,27 @@ func main() {
 		zap.Duration("poll_interval", outboxProcessorConfig.PollInterval),
 	)
 
-	// Initialize outbox service for dead letter queue management
-	outboxService := eventapp.NewOutboxService(outboxRepo, log)
+	// Initialize outbox service for dead letter queue management and alerting
+	outboxOpts := []eventapp.OutboxServiceOption{
+		eventapp.WithDeadLetterThresholds(eventapp.DeadLetterThresholds{
+			MaxCount: cfg.Event.DeadLetterThreshold,
+			MaxAge:   cfg.Event.DeadLetterMaxAge,
+		}),
+		eventapp.WithDeadLetterMetrics(meterProvider.Meter("outbox.monitor")),
+	}
+	if cfg.Event.DeadLetterWebhookURL != "" {
+		outboxOpts = append(outboxOpts, eventapp.WithDeadLetterNotifier(event.NewWebhookDeadLetterNotifier(cfg.Event.DeadLetterWebhookURL)))
+	}
+	outboxService := eventapp.NewOutboxService(outboxRepo, log, outboxOpts...)
+
+	// Initialize event replay for rebuilding read models from delivered events.
+	// Replays only reach the read model handlers listed here; the versioned
+	// serializer upgrades payloads written with an older schema version.
+	versionedEventSerializer := event.NewVersionedSerializer(log)
+	event.RegisterAllEvents(versionedEventSerializer)
+	replayService := eventapp.NewReplayService(outboxRepo, versionedEventSerializer, log,
+		supplierReferenceHandler,
+	)
 
 	// Inject event bus into services that publish events
 	purchaseOrderService.SetEventPublisher(eventBus)
@@ -605,12 +899,20 @@ func main() {
 	purchaseReturnService.SetEventPublisher(eventBus)
 	stockLockExpirationService.SetEventBus(eventBus)
 	inventoryService.SetEventPublisher(eventBus)
+	financeService.SetEventPublisher(eventBus)
+	receivableReminderService.SetEventPublisher(eventBus)
+	supplierService.SetEventPublisher(eventBus)
+	customerService.SetEventPublisher(eventBus)
 
 	// Inject pricing strategy provider into sales order service
 	salesOrderService.SetPricingProvider(strategyRegistry)
 
-	// Initialize report cron scheduler (if enabled)
-	// This runs daily report aggregation at the configured cron time (default: 2 AM)
+	// Inject credit checker so shipments are blocked when customers exceed their credit limit
+	salesOrderService.SetCreditChecker(financeapp.NewCustomerCreditChecker(customerRepo, accountReceivableRepo, tenantRepo))
+	salesOrderService.SetBatchExpiryChecker(inventoryService)
+
+	// Initialize the recurring job scheduler and the report cron scheduler (if enabled)
+	// Daily report aggregation is a recurring job run at the configured cron time (default: 2 AM)
 	var reportCronScheduler *scheduler.ReportCronScheduler
 	if cfg.Scheduler.Enabled {
 		// Parse cron schedule
@@ -630,6 +932,12 @@ func main() {
 		// Create scheduler job repository for persistence
 		schedulerJobRepo := scheduler.NewSchedulerJobRepository(db.DB)
 
+		// Create the recurring job scheduler; its run history is persisted in the job repository
+		jobScheduler := scheduler.NewJobScheduler(scheduler.JobSchedulerConfig{
+			MaxConcurrentJobs: cfg.Scheduler.MaxConcurrentJobs,
+			JobTimeout:        cfg.Scheduler.JobTimeout,
+		}, schedulerJobRepo, log)
+
 		// Create cron scheduler
 		reportCronScheduler = scheduler.NewReportCronScheduler(
 			cronConfig,
@@ -638,8 +946,29 @@ func main() {
 			schedulerJobRepo,
 			log,
 		)
+		if err := reportCronScheduler.RegisterJob(jobScheduler); err != nil {
+			log.Fatal("Failed to register report aggregation job", zap.Error(err))
+		}
+
+		// Customers whose spend lapsed place no orders to trigger a level evaluation,
+		// so ended level periods are re-evaluated daily to apply their demotions
+		if err := jobScheduler.Register(scheduler.RecurringJob{
+			Name:           "customer_level_period_review",
+			CronExpression: "0 3 * * *",
+			Handler: func(ctx context.Context) error {
+				changed, err := customerLevelUpgradeService.EvaluateEndedPeriods(ctx)
+				if changed > 0 {
+					log.Info("Customer levels changed at the end of their level period", zap.Int("changed", changed))
+				}
+				return err
+			},
+			MaxRetries: cfg.Scheduler.RetryAttempts,
+			RetryDelay: cfg.Scheduler.RetryDelay,
+		}); err != nil {
+			log.Fatal("Failed to register customer level period review job", zap.Error(err))
+		}
 
-		if err := reportCronScheduler.Start(context.Background()); err != nil {
+		if err := reportCronScheduler.Start(workerCtx); err != nil {
 			log.Fatal("Failed to start report cron scheduler", zap.Error(err))
 		}
 		defer func() {
@@ -647,6 +976,15 @@ func main() {
 				log.Error("Error stopping report cron scheduler", zap.Error(err))
 			}
 		}()
+
+		if err := jobScheduler.Start(workerCtx); err != nil {
+			log.Fatal("Failed to start job scheduler", zap.Error(err))
+		}
+		defer func() {
+			if err := jobScheduler.Stop(context.Background()); err != nil {
+				log.Error("Error stopping job scheduler", zap.Error(err))
+			}
+		}()
 		log.Info("Report cron scheduler started",
 			zap.String("cron_schedule", cfg.Scheduler.DailyCronSchedule),
 			zap.Int("cron_hour", cronHour),
@@ -659,7 +997,7 @@ func main() {
 	// Initialize stock lock expiration job (if enabled)
 	var stopStockLockExpiration context.CancelFunc
 	if cfg.StockLock.AutoReleaseEnabled {
-		stockLockCtx, cancel := context.WithCancel(context.Background())
+		stockLockCtx, cancel := context.WithCancel(workerCtx)
 		stopStockLockExpiration = cancel
 		go func() {
 			ticker := time.NewTicker(cfg.StockLock.CheckInterval)
@@ -701,6 +1039,135 @@ func main() {
 		}()
 	}
 
+	// Initialize receivable due-date reminder job (if enabled)
+	var stopReceivableReminder context.CancelFunc
+	if cfg.ReceivableReminder.Enabled {
+		reminderCtx, cancel := context.WithCancel(workerCtx)
+		stopReceivableReminder = cancel
+		go func() {
+			ticker := time.NewTicker(cfg.ReceivableReminder.CheckInterval)
+			defer ticker.Stop()
+
+			log.Info("Receivable reminder job started",
+				zap.Duration("check_interval", cfg.ReceivableReminder.CheckInterval),
+				zap.Duration("lead_time", cfg.ReceivableReminder.LeadTime),
+			)
+
+			// Run once immediately at startup
+			if _, err := receivableReminderService.SendDueReminders(reminderCtx); err != nil {
+				log.Error("Failed to send receivable reminders on startup", zap.Error(err))
+			}
+
+			for {
+				select {
+				case <-reminderCtx.Done():
+					log.Info("Receivable reminder job stopped")
+					return
+				case <-ticker.C:
+					if _, err := receivableReminderService.SendDueReminders(reminderCtx); err != nil {
+						log.Error("Failed to send receivable reminders", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Initialize sales quote expiration job (if enabled)
+	var stopSalesQuoteExpiration context.CancelFunc
+	if cfg.SalesQuote.ExpirationEnabled {
+		quoteCtx, cancel := context.WithCancel(workerCtx)
+		stopSalesQuoteExpiration = cancel
+		go func() {
+			ticker := time.NewTicker(cfg.SalesQuote.CheckInterval)
+			defer ticker.Stop()
+
+			log.Info("Sales quote expiration job started",
+				zap.Duration("check_interval", cfg.SalesQuote.CheckInterval),
+				zap.Duration("default_validity", cfg.SalesQuote.DefaultValidity),
+			)
+
+			// Run once immediately at startup
+			if _, err := salesQuoteExpirationService.ExpireQuotes(quoteCtx); err != nil {
+				log.Error("Failed to expire sales quotes on startup", zap.Error(err))
+			}
+
+			for {
+				select {
+				case <-quoteCtx.Done():
+					log.Info("Sales quote expiration job stopped")
+					return
+				case <-ticker.C:
+					if _, err := salesQuoteExpirationService.ExpireQuotes(quoteCtx); err != nil {
+						log.Error("Failed to expire sales quotes", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Initialize platform order status push retry job
+	orderStatusPushCtx, stopOrderStatusPushRetry := context.WithCancel(workerCtx)
+	go func() {
+		ticker := time.NewTicker(cfg.OrderStatusPush.RetryInterval)
+		defer ticker.Stop()
+
+		log.Info("Order status push retry job started",
+			zap.Duration("retry_interval", cfg.OrderStatusPush.RetryInterval),
+		)
+
+		// Run once immediately at startup
+		if _, err := orderStatusPushService.RetryFailedPushes(orderStatusPushCtx); err != nil {
+			log.Error("Failed to retry order status pushes on startup", zap.Error(err))
+		}
+
+		for {
+			select {
+			case <-orderStatusPushCtx.Done():
+				log.Info("Order status push retry job stopped")
+				return
+			case <-ticker.C:
+				if _, err := orderStatusPushService.RetryFailedPushes(orderStatusPushCtx); err != nil {
+					log.Error("Failed to retry order status pushes", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	// Initialize dead letter queue monitor (if enabled)
+	var stopDeadLetterMonitor context.CancelFunc
+	if cfg.Event.DeadLetterMonitorEnabled {
+		monitorCtx, cancel := context.WithCancel(workerCtx)
+		stopDeadLetterMonitor = cancel
+		go func() {
+			ticker := time.NewTicker(cfg.Event.DeadLetterCheckInterval)
+			defer ticker.Stop()
+
+			log.Info("Dead letter monitor started",
+				zap.Duration("check_interval", cfg.Event.DeadLetterCheckInterval),
+				zap.Int64("threshold", cfg.Event.DeadLetterThreshold),
+				zap.Duration("max_age", cfg.Event.DeadLetterMaxAge),
+				zap.Bool("webhook", cfg.Event.DeadLetterWebhookURL != ""),
+			)
+
+			// Run once immediately at startup
+			if _, err := outboxService.CheckDeadLetters(monitorCtx); err != nil {
+				log.Error("Failed to check dead letter queue on startup", zap.Error(err))
+			}
+
+			for {
+				select {
+				case <-monitorCtx.Done():
+					log.Info("Dead letter monitor stopped")
+					return
+				case <-ticker.C:
+					if _, err := outboxService.CheckDeadLetters(monitorCtx); err != nil {
+						log.Error("Failed to check dead letter queue", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
 	// Initialize HTTP handlers
 	productHandler := handler.NewProductHandler(productService)
 	productUnitHandler := handler.NewProductUnitHandler(productUnitService)
@@ -709,9 +1176,14 @@ func main() {
 	customerHandler := handler.NewCustomerHandler(customerService)
 	customerLevelHandler := handler.NewCustomerLevelHandler(customerLevelService)
 	supplierHandler := handler.NewSupplierHandler(supplierService)
+	partnerAddressHandler := handler.NewPartnerAddressHandler(addressService)
 	warehouseHandler := handler.NewWarehouseHandler(warehouseService)
 	balanceTransactionHandler := handler.NewBalanceTransactionHandler(balanceTransactionService)
 	inventoryHandler := handler.NewInventoryHandler(inventoryService)
+	// Cost recalculations queued via POST /inventory/cost-recalculations run in the background
+	costRecalculationQueue := inventoryapp.NewCostRecalculationQueue(inventoryService, inventoryapp.CostRecalculationQueueConfig{Logger: log})
+	costRecalculationQueue.Start()
+	inventoryCostHandler := handler.NewInventoryCostHandler(inventoryService, costRecalculationQueue)
 	salesOrderHandler := handler.NewSalesOrderHandler(salesOrderService)
 	purchaseOrderHandler := handler.NewPurchaseOrderHandler(purchaseOrderService)
 	salesReturnHandler := handler.NewSalesReturnHandler(salesReturnService)
@@ -721,6 +1193,7 @@ func main() {
 	userHandler := handler.NewUserHandler(userService)
 	roleHandler := handler.NewRoleHandler(roleService)
 	tenantHandler := handler.NewTenantHandler(tenantService)
+	tenantExportHandler := handler.NewTenantExportHandler(tenantExportService)
 	reportHandler := handler.NewReportHandler(reportService)
 	reportHandler.SetAggregationService(reportAggregationService)
 	if reportCronScheduler != nil {
@@ -729,12 +1202,36 @@ func main() {
 	paymentCallbackHandler := handler.NewPaymentCallbackHandler(paymentCallbackService)
 	expenseIncomeHandler := handler.NewExpenseIncomeHandler(expenseIncomeService)
 	financeHandler := handler.NewFinanceHandler(financeService)
+	platformOrderSyncHandler := handler.NewPlatformOrderSyncHandler(orderImportService)
+	platformMappingSuggestionHandler := handler.NewPlatformMappingSuggestionHandler(mappingSuggestionService)
 	outboxHandler := handler.NewOutboxHandler(outboxService)
+	eventReplayHandler := handler.NewEventReplayHandler(replayService)
 	featureFlagHandler := handler.NewFeatureFlagHandler(flagService, evaluationService, overrideService)
-	printHandler := handler.NewPrintHandler(printService, pdfStorage)
+	printHandler := handler.NewPrintHandler(printService, printJobQueue, pdfStorage)
 	planFeatureHandler := handler.NewPlanFeatureHandler(tenantRepo, planFeatureRepo)
 	usageHandler := handler.NewUsageHandler(tenantRepo, userRepo, warehouseRepo, productRepo)
 	subscriptionHandler := handler.NewSubscriptionHandler(tenantRepo, planFeatureRepo, userRepo, warehouseRepo, productRepo)
+	usageRecordRepo := persistence.NewTracedUsageRecordRepository(persistence.NewUsageRecordRepository(db.DB))
+	usageAggregationService := billingapp.NewUsageAggregationService(usageRecordRepo, tenantRepo, log)
+	usageSeriesHandler := handler.NewUsageSeriesHandler(usageAggregationService)
+
+	// Initialize quota enforcement for metered endpoints
+	// Usage of successful metered requests is written asynchronously by the usage tracker
+	usageQuotaRepo := persistence.NewTracedUsageQuotaRepository(persistence.NewUsageQuotaRepository(db.DB))
+	quotaService := billingapp.NewQuotaService(usageQuotaRepo, usageRecordRepo, nil, tenantRepo, nil, log, billingapp.DefaultQuotaServiceConfig())
+	usageTrackerConfig := middleware.DefaultUsageTrackerConfig()
+	usageTrackerConfig.MeterProvider = meterProvider
+	usageTrackerConfig.Logger = log
+	usageTracker, err := middleware.NewUsageTracker(usageTrackerConfig, usageRecordRepo)
+	if err != nil {
+		log.Fatal("Failed to create usage tracker", zap.Error(err))
+	}
+	usageTracker.Start()
+	defer func() {
+		if err := usageTracker.Stop(context.Background()); err != nil {
+			log.Error("Error stopping usage tracker", zap.Error(err))
+		}
+	}()
 
 	// Initialize Stripe webhook handler (if Stripe is enabled)
 	var stripeWebhookHandler *handler.StripeWebhookHandler
@@ -751,10 +1248,11 @@ func main() {
 			BillingPortalReturnURL: cfg.Stripe.BillingPortalReturnURL,
 		}
 		stripeWebhookService := billingapp.NewStripeWebhookService(billingapp.StripeWebhookServiceConfig{
-			Config:     stripeConfig,
-			TenantRepo: tenantRepo,
-			EventBus:   nil, // Event bus can be set later if needed
-			Logger:     log,
+			Config:        stripeConfig,
+			TenantRepo:    tenantRepo,
+			TenantService: tenantService,
+			EventBus:      nil, // Event bus can be set later if needed
+			Logger:        log,
 		})
 		stripeWebhookHandler = handler.NewStripeWebhookHandler(stripeWebhookService)
 		log.Info("Stripe webhook handler initialized",
@@ -821,7 +1319,8 @@ func main() {
 	// 7. Security - Add security headers
 	// 8. CORS - Handle cross-origin requests
 	// 9. BodyLimit - Limit request body size
-	// 10. RateLimit - Apply rate limiting (if enabled)
+	// RateLimit is applied to the API routes after JWT authentication (see below),
+	// so limits can be resolved per role and tenant plan
 	engine.Use(middleware.TracingWithConfig(middleware.TracingConfig{
 		ServiceName: cfg.Telemetry.ServiceName,
 		Enabled:     cfg.Telemetry.Enabled,
@@ -847,14 +1346,24 @@ func main() {
 	// Body size limit
 	engine.Use(middleware.BodyLimit(cfg.HTTP.MaxBodySize))
 
-	// Rate limiting (if enabled)
-	if cfg.HTTP.RateLimitEnabled {
-		rateLimiter := middleware.NewRateLimiter(cfg.HTTP.RateLimitRequests, cfg.HTTP.RateLimitWindow)
-		engine.Use(middleware.RateLimit(rateLimiter))
-		log.Info("Rate limiting enabled",
-			zap.Int("requests", cfg.HTTP.RateLimitRequests),
-			zap.Duration("window", cfg.HTTP.RateLimitWindow),
-		)
+	// Read-only mode for database maintenance: API writes get 503 while reads keep working.
+	// Login, payment callbacks and the switch itself stay writable; /health is outside the API.
+	readOnlyMode := middleware.NewReadOnlyMode(cfg.HTTP.ReadOnly)
+	engine.Use(middleware.ReadOnly(middleware.ReadOnlyConfig{
+		Mode: readOnlyMode,
+		ExemptPaths: []string{
+			"/api/v1/auth/login",
+			"/api/v1/auth/refresh",
+			"/api/v1/system/read-only",
+		},
+		ExemptPathPrefixes: []string{
+			"/api/v1/payment/callback",
+			"/api/v1/webhooks",
+			"/api/v1/billing/stripe/webhook",
+		},
+	}))
+	if cfg.HTTP.ReadOnly {
+		log.Warn("Starting in read-only mode, API writes will be rejected")
 	}
 
 	// Health check endpoint (outside API versioning)
@@ -910,12 +1419,17 @@ func main() {
 	paymentCallbackGroup.POST("/wechat/refund", paymentCallbackHandler.HandleWechatRefundCallback)
 	paymentCallbackGroup.POST("/alipay", paymentCallbackHandler.HandleAlipayPaymentCallback)
 	paymentCallbackGroup.POST("/alipay/refund", paymentCallbackHandler.HandleAlipayRefundCallback)
+	paymentCallbackGroup.POST("/:gateway", paymentCallbackHandler.HandleGatewayCallback)
+	log.Info("Payment callback gateways registered",
+		zap.Strings("gateways", paymentGatewayRegistry.Names()))
 
 	// Stripe webhook endpoint (no authentication required, uses signature verification)
+	// /api/v1/webhooks/stripe is kept for existing Stripe endpoint configurations
 	if stripeWebhookHandler != nil {
+		engine.POST("/api/v1/billing/stripe/webhook", stripeWebhookHandler.HandleStripeWebhook)
 		webhookGroup := engine.Group("/api/v1/webhooks")
 		webhookGroup.POST("/stripe", stripeWebhookHandler.HandleStripeWebhook)
-		log.Info("Stripe webhook endpoint registered at /api/v1/webhooks/stripe")
+		log.Info("Stripe webhook endpoint registered at /api/v1/billing/stripe/webhook")
 	}
 
 	// Setup API routes using router
@@ -941,6 +1455,55 @@ func main() {
 	}
 	r.Use(middleware.JWTAuthMiddlewareWithConfig(jwtConfig))
 
+	// Rate limiting (if enabled)
+	// Authenticated users get the highest limit configured for their roles or tenant plan,
+	// shared per tenant and tier; everyone else gets the default limit per IP
+	if cfg.HTTP.RateLimitEnabled {
+		var rateLimiterOpts []middleware.RateLimiterOption
+		if len(cfg.HTTP.RateLimitRoleRequests) > 0 || len(cfg.HTTP.RateLimitPlanRequests) > 0 {
+			rateLimitTierService := identityapp.NewRateLimitTierService(tenantRepo, roleRepo, identityapp.RateLimitTierConfig{
+				RoleLimits: cfg.HTTP.RateLimitRoleRequests,
+				PlanLimits: cfg.HTTP.RateLimitPlanRequests,
+			}, log)
+			rateLimiterOpts = append(rateLimiterOpts, middleware.WithLimitResolver(
+				func(ctx context.Context, principal middleware.RateLimitPrincipal) (middleware.RateLimitTier, bool) {
+					tenantID, err := uuid.Parse(principal.TenantID)
+					if err != nil {
+						return middleware.RateLimitTier{}, false
+					}
+					roleIDs := make([]uuid.UUID, 0, len(principal.RoleIDs))
+					for _, id := range principal.RoleIDs {
+						if roleID, err := uuid.Parse(id); err == nil {
+							roleIDs = append(roleIDs, roleID)
+						}
+					}
+					tier, ok := rateLimitTierService.ResolveTier(ctx, tenantID, roleIDs)
+					return middleware.RateLimitTier{Bucket: tier.Bucket, Limit: tier.Limit}, ok
+				}))
+		}
+		rateLimiter := middleware.NewRateLimiter(cfg.HTTP.RateLimitRequests, cfg.HTTP.RateLimitWindow, rateLimiterOpts...)
+		r.Use(middleware.RateLimit(rateLimiter))
+		log.Info("Rate limiting enabled",
+			zap.Int("requests", cfg.HTTP.RateLimitRequests),
+			zap.Duration("window", cfg.HTTP.RateLimitWindow),
+			zap.Any("role_requests", cfg.HTTP.RateLimitRoleRequests),
+			zap.Any("plan_requests", cfg.HTTP.RateLimitPlanRequests),
+		)
+	}
+
+	// Per-tenant slice of concurrent heavy operations (reports, exports), applied on those routes
+	heavyOpsLimiter := middleware.NewTenantLimiter(int64(cfg.HTTP.TenantHeavyOpsLimit))
+	log.Info("Tenant heavy operation limit configured",
+		zap.Int("max_concurrent_per_tenant", cfg.HTTP.TenantHeavyOpsLimit),
+	)
+
+	r.Use(middleware.EnforceQuota(middleware.QuotaMiddlewareConfig{
+		Checker:          quotaService,
+		Recorder:         usageTracker,
+		MeteredEndpoints: middleware.DefaultMeteredEndpoints(),
+		Logger:           log,
+	}))
+
 	// Register domain route groups
 	// These will be populated as domain APIs are implemented
 
@@ -951,13 +1514,16 @@ func main() {
 	})
 	// Product routes
 	catalogRoutes.POST("/products", productHandler.Create)
+	catalogRoutes.POST("/products/import", productHandler.BulkImport)
 	catalogRoutes.GET("/products", productHandler.List)
 	catalogRoutes.GET("/products/stats/count", productHandler.CountByStatus)
 	catalogRoutes.GET("/products/:id", productHandler.GetByID)
 	catalogRoutes.GET("/products/code/:code", productHandler.GetByCode)
 	catalogRoutes.PUT("/products/:id", productHandler.Update)
 	catalogRoutes.PUT("/products/:id/code", productHandler.UpdateCode)
+	catalogRoutes.GET("/products/:id/price-history", productHandler.GetPriceHistory)
 	catalogRoutes.DELETE("/products/:id", productHandler.Delete)
+	catalogRoutes.POST("/products/:id/restore", middleware.RequirePermission("product:restore"), productHandler.Restore)
 	catalogRoutes.POST("/products/:id/activate", productHandler.Activate)
 	catalogRoutes.POST("/products/:id/deactivate", productHandler.Deactivate)
 	catalogRoutes.POST("/products/:id/discontinue", productHandler.Discontinue)
@@ -1012,12 +1578,19 @@ func main() {
 	partnerRoutes.PUT("/customers/:id", customerHandler.Update)
 	partnerRoutes.PUT("/customers/:id/code", customerHandler.UpdateCode)
 	partnerRoutes.DELETE("/customers/:id", customerHandler.Delete)
+	partnerRoutes.POST("/customers/:id/restore", middleware.RequirePermission("customer:restore"), customerHandler.Restore)
+	partnerRoutes.POST("/customers/:id/merge", middleware.RequirePermission("customer:merge"), customerHandler.Merge)
 	partnerRoutes.POST("/customers/:id/activate", customerHandler.Activate)
 	partnerRoutes.POST("/customers/:id/deactivate", customerHandler.Deactivate)
 	partnerRoutes.POST("/customers/:id/suspend", customerHandler.Suspend)
 	partnerRoutes.POST("/customers/:id/balance/add", customerHandler.AddBalance)
 	partnerRoutes.POST("/customers/:id/balance/deduct", customerHandler.DeductBalance)
 	partnerRoutes.PUT("/customers/:id/level", customerHandler.SetLevel)
+	partnerRoutes.GET("/customers/:id/addresses", partnerAddressHandler.ListCustomerAddresses)
+	partnerRoutes.POST("/customers/:id/addresses", partnerAddressHandler.CreateCustomerAddress)
+	partnerRoutes.GET("/customers/:id/addresses/:address_id", partnerAddressHandler.GetCustomerAddress)
+	partnerRoutes.PUT("/customers/:id/addresses/:address_id", partnerAddressHandler.UpdateCustomerAddress)
+	partnerRoutes.DELETE("/customers/:id/addresses/:address_id", partnerAddressHandler.DeleteCustomerAddress)
 
 	// Balance transaction routes (customer balance with transaction records)
 	partnerRoutes.POST("/customers/:id/balance/recharge", balanceTransactionHandler.Recharge)
@@ -1049,11 +1622,17 @@ func main() {
 	partnerRoutes.PUT("/suppliers/:id", supplierHandler.Update)
 	partnerRoutes.PUT("/suppliers/:id/code", supplierHandler.UpdateCode)
 	partnerRoutes.DELETE("/suppliers/:id", supplierHandler.Delete)
+	partnerRoutes.POST("/suppliers/:id/restore", middleware.RequirePermission("supplier:restore"), supplierHandler.Restore)
 	partnerRoutes.POST("/suppliers/:id/activate", supplierHandler.Activate)
 	partnerRoutes.POST("/suppliers/:id/deactivate", supplierHandler.Deactivate)
 	partnerRoutes.POST("/suppliers/:id/block", supplierHandler.Block)
 	partnerRoutes.PUT("/suppliers/:id/rating", supplierHandler.SetRating)
 	partnerRoutes.PUT("/suppliers/:id/payment-terms", supplierHandler.SetPaymentTerms)
+	partnerRoutes.GET("/suppliers/:id/addresses", partnerAddressHandler.ListSupplierAddresses)
+	partnerRoutes.POST("/suppliers/:id/addresses", partnerAddressHandler.CreateSupplierAddress)
+	partnerRoutes.GET("/suppliers/:id/addresses/:address_id", partnerAddressHandler.GetSupplierAddress)
+	partnerRoutes.PUT("/suppliers/:id/addresses/:address_id", partnerAddressHandler.UpdateSupplierAddress)
+	partnerRoutes.DELETE("/suppliers/:id/addresses/:address_id", partnerAddressHandler.DeleteSupplierAddress)
 
 	// Warehouse routes
 	partnerRoutes.POST("/warehouses", warehouseHandler.Create)
@@ -1068,6 +1647,8 @@ func main() {
 	partnerRoutes.POST("/warehouses/:id/enable", warehouseHandler.Enable)
 	partnerRoutes.POST("/warehouses/:id/disable", warehouseHandler.Disable)
 	partnerRoutes.POST("/warehouses/:id/set-default", warehouseHandler.SetDefault)
+	partnerRoutes.PUT("/warehouses/:id/negative-stock", warehouseHandler.SetNegativeStockPolicy)
+	partnerRoutes.GET("/warehouses/:id/utilization", warehouseHandler.GetUtilization)
 
 	// Inventory domain
 	inventoryRoutes := router.NewDomainGroup("inventory", "/inventory")
@@ -1079,6 +1660,10 @@ func main() {
 	inventoryRoutes.GET("/items", inventoryHandler.List)
 	inventoryRoutes.GET("/items/lookup", inventoryHandler.GetByWarehouseAndProduct)
 	inventoryRoutes.GET("/items/alerts/low-stock", inventoryHandler.ListBelowMinimum)
+	inventoryRoutes.GET("/reorder-suggestions", inventoryHandler.GetReorderSuggestions)
+	inventoryRoutes.GET("/snapshot", inventoryHandler.GetSnapshot)
+	inventoryRoutes.GET("/serials/lookup", inventoryHandler.LookupSerialNumber)
+	inventoryRoutes.GET("/serials/:id", inventoryHandler.GetSerialNumber)
 	inventoryRoutes.GET("/items/:id", inventoryHandler.GetByID)
 	inventoryRoutes.GET("/items/:id/transactions", inventoryHandler.ListTransactionsByItem)
 
@@ -1089,6 +1674,7 @@ func main() {
 	// Stock operations
 	inventoryRoutes.POST("/availability/check", inventoryHandler.CheckAvailability)
 	inventoryRoutes.POST("/stock/increase", inventoryHandler.IncreaseStock)
+	inventoryRoutes.POST("/stock/transfer", inventoryHandler.TransferStock)
 	inventoryRoutes.POST("/stock/lock", inventoryHandler.LockStock)
 	inventoryRoutes.POST("/stock/unlock", inventoryHandler.UnlockStock)
 	inventoryRoutes.POST("/stock/deduct", inventoryHandler.DeductStock)
@@ -1103,6 +1689,11 @@ func main() {
 	inventoryRoutes.GET("/transactions", inventoryHandler.ListTransactions)
 	inventoryRoutes.GET("/transactions/:id", inventoryHandler.GetTransactionByID)
 
+	// Cost corrections and recalculation
+	inventoryRoutes.POST("/transactions/:id/cost-correction", inventoryCostHandler.CorrectReceiptCost)
+	inventoryRoutes.POST("/cost-recalculations", inventoryCostHandler.StartCostRecalculation)
+	inventoryRoutes.GET("/cost-recalculations/:id", inventoryCostHandler.GetCostRecalculation)
+
 	// Stock Taking routes
 	inventoryRoutes.POST("/stock-takings", stockTakingHandler.Create)
 	inventoryRoutes.GET("/stock-takings", stockTakingHandler.List)
@@ -1140,8 +1731,10 @@ func main() {
 	tradeRoutes.POST("/sales-orders/:id/items", salesOrderHandler.AddItem)
 	tradeRoutes.PUT("/sales-orders/:id/items/:item_id", salesOrderHandler.UpdateItem)
 	tradeRoutes.DELETE("/sales-orders/:id/items/:item_id", salesOrderHandler.RemoveItem)
+	tradeRoutes.POST("/sales-orders/:id/convert-quote", salesOrderHandler.ConvertQuote)
 	tradeRoutes.POST("/sales-orders/:id/confirm", salesOrderHandler.Confirm)
 	tradeRoutes.POST("/sales-orders/:id/ship", salesOrderHandler.Ship)
+	tradeRoutes.POST("/sales-orders/:id/credit-override", middleware.RequirePermission("sales_order:approve_credit_override"), salesOrderHandler.ApproveCreditOverride)
 	tradeRoutes.POST("/sales-orders/:id/complete", salesOrderHandler.Complete)
 	tradeRoutes.POST("/sales-orders/:id/cancel", salesOrderHandler.Cancel)
 
@@ -1161,6 +1754,9 @@ func main() {
 	tradeRoutes.POST("/purchase-orders/:id/confirm", purchaseOrderHandler.Confirm)
 	tradeRoutes.POST("/purchase-orders/:id/receive", purchaseOrderHandler.Receive)
 	tradeRoutes.POST("/purchase-orders/:id/cancel", purchaseOrderHandler.Cancel)
+	tradeRoutes.POST("/purchase-orders/:id/amendment", purchaseOrderHandler.RequestAmendment)
+	tradeRoutes.POST("/purchase-orders/:id/amendment/approve", purchaseOrderHandler.ApproveAmendment)
+	tradeRoutes.POST("/purchase-orders/:id/amendment/reject", purchaseOrderHandler.RejectAmendment)
 
 	// Sales Return routes
 	tradeRoutes.POST("/sales-returns", salesReturnHandler.Create)
@@ -1216,6 +1812,8 @@ func main() {
 	financeRoutes.POST("/expenses/:id/reject", expenseIncomeHandler.RejectExpense)
 	financeRoutes.POST("/expenses/:id/cancel", expenseIncomeHandler.CancelExpense)
 	financeRoutes.POST("/expenses/:id/pay", expenseIncomeHandler.MarkExpensePaid)
+	financeRoutes.POST("/expenses/:id/attachments", expenseIncomeHandler.AddExpenseAttachment)
+	financeRoutes.DELETE("/expenses/:id/attachments/:attachmentId", expenseIncomeHandler.RemoveExpenseAttachment)
 
 	// Other income routes
 	financeRoutes.GET("/incomes", expenseIncomeHandler.ListIncomes)
@@ -1230,24 +1828,32 @@ func main() {
 
 	// Cash flow route
 	financeRoutes.GET("/cash-flow", expenseIncomeHandler.GetCashFlow)
+	financeRoutes.GET("/cash-flow/forecast", financeHandler.GetCashFlowForecast)
 
 	// Account Receivable routes
 	financeRoutes.GET("/receivables", financeHandler.ListReceivables)
 	financeRoutes.GET("/receivables/summary", financeHandler.GetReceivableSummary)
 	financeRoutes.GET("/receivables/:id", financeHandler.GetReceivableByID)
+	financeRoutes.GET("/receivables/:id/payments", financeHandler.ListReceivablePayments)
+	financeRoutes.POST("/receivables/:id/write-off", financeHandler.WriteOffReceivable)
 
 	// Account Payable routes
 	financeRoutes.GET("/payables", financeHandler.ListPayables)
 	financeRoutes.GET("/payables/summary", financeHandler.GetPayableSummary)
 	financeRoutes.GET("/payables/:id", financeHandler.GetPayableByID)
+	financeRoutes.POST("/payables/:id/invoice", financeHandler.RecordPayableInvoice)
+	financeRoutes.GET("/payables/:id/match", financeHandler.GetPayableMatch)
+	financeRoutes.POST("/payables/:id/match", financeHandler.MatchPayable)
 
 	// Receipt Voucher routes (收款单)
 	financeRoutes.GET("/receipts", financeHandler.ListReceiptVouchers)
 	financeRoutes.GET("/receipts/:id", financeHandler.GetReceiptVoucherByID)
 	financeRoutes.POST("/receipts", financeHandler.CreateReceiptVoucher)
+	financeRoutes.POST("/receipts/batch", financeHandler.BatchCreateReceiptVouchers)
 	financeRoutes.POST("/receipts/:id/confirm", financeHandler.ConfirmReceiptVoucher)
 	financeRoutes.POST("/receipts/:id/cancel", financeHandler.CancelReceiptVoucher)
 	financeRoutes.POST("/receipts/:id/reconcile", financeHandler.ReconcileReceiptVoucher)
+	financeRoutes.POST("/receipts/:id/unreconcile", financeHandler.UnreconcileReceiptVoucher)
 
 	// Payment Voucher routes (付款单)
 	financeRoutes.GET("/payments", financeHandler.ListPaymentVouchers)
@@ -1258,7 +1864,9 @@ func main() {
 	financeRoutes.POST("/payments/:id/reconcile", financeHandler.ReconcilePaymentVoucher)
 
 	// Report domain
+	// Reports are heavy queries; each tenant may only run a few at once so one tenant cannot starve the DB pool
 	reportRoutes := router.NewDomainGroup("report", "/reports")
+	reportRoutes.Use(middleware.TenantConcurrencyLimit(heavyOpsLimiter, 1))
 	reportRoutes.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "report service ready"})
 	})
@@ -1267,9 +1875,11 @@ func main() {
 	reportRoutes.GET("/sales/daily-trend", reportHandler.GetDailySalesTrend)
 	reportRoutes.GET("/sales/products/ranking", reportHandler.GetProductSalesRanking)
 	reportRoutes.GET("/sales/customers/ranking", reportHandler.GetCustomerSalesRanking)
+	reportRoutes.GET("/sales/fulfillment-sla", reportHandler.GetFulfillmentSLA)
 	// Inventory reports
 	reportRoutes.GET("/inventory/summary", reportHandler.GetInventorySummary)
 	reportRoutes.GET("/inventory/turnover", reportHandler.GetInventoryTurnover)
+	reportRoutes.GET("/inventory/turnover/products", reportHandler.GetProductTurnover)
 	reportRoutes.GET("/inventory/value-by-category", reportHandler.GetInventoryValueByCategory)
 	reportRoutes.GET("/inventory/value-by-warehouse", reportHandler.GetInventoryValueByWarehouse)
 	reportRoutes.GET("/inventory/slow-moving", reportHandler.GetSlowMovingProducts)
@@ -1279,11 +1889,14 @@ func main() {
 	reportRoutes.GET("/finance/profit-by-product", reportHandler.GetProfitByProduct)
 	reportRoutes.GET("/finance/cash-flow", reportHandler.GetCashFlowStatement)
 	reportRoutes.GET("/finance/cash-flow/items", reportHandler.GetCashFlowItems)
+	// Return reports
+	reportRoutes.GET("/returns/reasons", reportHandler.GetReturnReasons)
 	// Report aggregation/refresh endpoints
 	reportRoutes.POST("/refresh", reportHandler.RefreshReport)
 	reportRoutes.POST("/refresh/all", reportHandler.RefreshAllReports)
 	reportRoutes.GET("/scheduler/status", reportHandler.GetSchedulerStatus)
-	reportRoutes.POST("/scheduler/trigger", reportHandler.TriggerDailyAggregation)
+	reportRoutes.POST("/scheduler/trigger", middleware.RequirePermission("report:aggregate"), reportHandler.TriggerDailyAggregation)
+	reportRoutes.GET("/scheduler/runs/:id", reportHandler.GetSchedulerRun)
 
 	// Identity domain (authentication, users, roles) - public routes
 	authRoutes := router.NewDomainGroup("auth", "/auth")
@@ -1326,6 +1939,8 @@ func main() {
 	identityRoutes.POST("/users/:id/unlock", userHandler.Unlock)
 	identityRoutes.POST("/users/:id/reset-password", userHandler.ResetPassword)
 	identityRoutes.PUT("/users/:id/roles", userHandler.AssignRoles)
+	identityRoutes.GET("/users/:id/sessions", middleware.RequirePermission("user:read"), authHandler.ListUserSessions)
+	identityRoutes.DELETE("/users/:id/sessions/:jti", middleware.RequirePermission("user:force_logout"), authHandler.RevokeUserSession)
 
 	// Role management routes
 	identityRoutes.POST("/roles", roleHandler.Create)
@@ -1357,6 +1972,7 @@ func main() {
 	identityRoutes.POST("/tenants/:id/activate", tenantHandler.Activate)
 	identityRoutes.POST("/tenants/:id/deactivate", tenantHandler.Deactivate)
 	identityRoutes.POST("/tenants/:id/suspend", tenantHandler.Suspend)
+	identityRoutes.POST("/tenants/:id/export", middleware.RequirePermission("tenant:export"), middleware.TenantConcurrencyLimit(heavyOpsLimiter, 2), tenantExportHandler.Export)
 
 	// Current tenant feature routes (self-service)
 	identityRoutes.GET("/tenants/current/features", planFeatureHandler.GetCurrentTenantFeatures)
@@ -1394,6 +2010,9 @@ func main() {
 	// Register system routes with swagger-documented handlers
 	systemHandler := handler.NewSystemHandler()
 	strategyHandler := handler.NewStrategyHandler(strategyRegistry)
+	tenantStrategyHandler := handler.NewTenantStrategyHandler(
+		identityapp.NewTenantStrategyService(tenantStrategyConfigRepo, strategyRegistry, financeService.GetReconciliationService()),
+	)
 	systemRoutes := router.NewDomainGroup("system", "/system")
 	systemRoutes.GET("/info", systemHandler.GetSystemInfo)
 	systemRoutes.GET("/ping", systemHandler.Ping)
@@ -1404,14 +2023,25 @@ func main() {
 	systemRoutes.GET("/strategies/cost", strategyHandler.GetCostStrategies)
 	systemRoutes.GET("/strategies/pricing", strategyHandler.GetPricingStrategies)
 	systemRoutes.GET("/strategies/allocation", strategyHandler.GetAllocationStrategies)
+	systemRoutes.GET("/strategies/tenant", tenantStrategyHandler.GetTenantStrategies)
+	systemRoutes.PUT("/strategies/tenant", middleware.RequirePermission("tenant:update"), tenantStrategyHandler.SetTenantStrategies)
 
 	// Outbox management routes (for operators)
 	systemRoutes.GET("/outbox/stats", outboxHandler.GetStats)
+	systemRoutes.GET("/outbox/health", outboxHandler.GetHealth)
 	systemRoutes.GET("/outbox/dead", outboxHandler.GetDeadLetterEntries)
 	systemRoutes.GET("/outbox/:id", outboxHandler.GetEntry)
 	systemRoutes.POST("/outbox/:id/retry", outboxHandler.RetryDeadEntry)
 	systemRoutes.POST("/outbox/dead/retry-all", outboxHandler.RetryAllDeadEntries)
 
+	// Event replay for rebuilding read models (for operators)
+	systemRoutes.POST("/events/replay", middleware.RequirePermission("event:replay"), eventReplayHandler.ReplayEvents)
+
+	// Read-only mode switch for database maintenance (for operators)
+	readOnlyModeHandler := handler.NewReadOnlyModeHandler(readOnlyMode, log)
+	systemRoutes.GET("/read-only", readOnlyModeHandler.GetReadOnlyMode)
+	systemRoutes.PUT("/read-only", middleware.RequirePermission("system:maintenance"), readOnlyModeHandler.SetReadOnlyMode)
+
 	r.Register(systemRoutes)
 
 	// Feature Flag domain - global resources for controlling application behavior
@@ -1462,8 +2092,26 @@ func main() {
 	// Current subscription routes (self-service, requires authentication)
 	billingRoutes.GET("/subscription/current", subscriptionHandler.GetCurrentSubscription)
 
+	// Metered usage series for the current tenant, bucketed in the tenant's time zone
+	billingRoutes.GET("/usage", usageSeriesHandler.GetUsageSeries)
+
 	r.Register(billingRoutes)
 
+	// Integration domain - e-commerce platform synchronization
+	integrationRoutes := router.NewDomainGroup("integration", "/integration")
+	integrationRoutes.GET("/ping", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "integration service ready"})
+	})
+
+	// Import new platform orders as draft sales orders
+	integrationRoutes.POST("/platforms/:platform/sync-orders", platformOrderSyncHandler.SyncPlatformOrders)
+
+	// Product mapping suggestions; only confirmed suggestions are persisted
+	integrationRoutes.GET("/platforms/:platform/mapping-suggestions", platformMappingSuggestionHandler.GetMappingSuggestions)
+	integrationRoutes.POST("/platforms/:platform/mapping-suggestions/confirm", platformMappingSuggestionHandler.ConfirmMappingSuggestions)
+
+	r.Register(integrationRoutes)
+
 	// Print domain - document printing and template management
 	// Create JWT middleware for print routes authentication
 	printJWTMiddleware := middleware.JWTAuthMiddlewareWithConfig(middleware.JWTMiddlewareConfig{
@@ -1516,6 +2164,24 @@ func main() {
 		stopStockLockExpiration()
 	}
 
+	// Stop receivable reminder job
+	if stopReceivableReminder != nil {
+		stopReceivableReminder()
+	}
+
+	// Stop sales quote expiration job
+	if stopSalesQuoteExpiration != nil {
+		stopSalesQuoteExpiration()
+	}
+
+	// Stop order status push retry job
+	stopOrderStatusPushRetry()
+
+	// Stop dead letter monitor
+	if stopDeadLetterMonitor != nil {
+		stopDeadLetterMonitor()
+	}
+
 	// Stop Feature Flag SSE handler
 	if featureFlagSSEHandler != nil {
 		featureFlagSSEHandler.Stop()
@@ -1535,6 +2201,16 @@ func main() {
 		log.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Stop print job workers, letting the jobs being rendered finish
+	if err := printJobQueue.Stop(ctx); err != nil {
+		log.Warn("Print job queue did not stop in time", zap.Error(err))
+	}
+
+	// Stop cost recalculation workers, letting the running recalculations finish
+	if err := costRecalculationQueue.Stop(ctx); err != nil {
+		log.Warn("Cost recalculation queue did not stop in time", zap.Error(err))
+	}
+
 	log.Info("Server exited gracefully")
 }
 
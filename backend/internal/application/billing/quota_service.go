@@ -72,6 +72,7 @@ type QuotaCheckResult struct {
 	Percentage   float64               // Usage percentage (0-100+)
 	Status       billing.QuotaStatus   // OK, WARNING, EXCEEDED, INACTIVE
 	Policy       billing.OveragePolicy // What happens when exceeded
+	ResetAt      time.Time             // When the quota period resets (zero if it never resets)
 	Warning      *QuotaWarning         // Warning if approaching limit
 	Error        *QuotaExceededError   // Error if exceeded and blocked
 }
@@ -202,7 +203,8 @@ func (s *QuotaService) CheckQuota(ctx context.Context, input QuotaCheckInput) (*
 	}
 
 	// Get current usage for the billing period
-	currentUsage, err := s.getCurrentUsage(ctx, input.TenantID, input.UsageType, quota.ResetPeriod)
+	periodStart, periodEnd := s.calculatePeriodBoundaries(quota.ResetPeriod)
+	currentUsage, err := s.getCurrentUsageForPeriod(ctx, input.TenantID, input.UsageType, periodStart, periodEnd)
 	if err != nil {
 		s.logger.Error("Failed to get current usage", zap.Error(err))
 		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to get current usage")
@@ -211,6 +213,9 @@ func (s *QuotaService) CheckQuota(ctx context.Context, input QuotaCheckInput) (*
 	// Check quota with the amount to be consumed
 	projectedUsage := currentUsage + input.Amount
 	checkResult := quota.CheckUsage(projectedUsage)
+	if quota.ResetPeriod != billing.ResetPeriodNever {
+		checkResult.ResetAt = periodEnd.Add(time.Nanosecond)
+	}
 
 	result := &QuotaCheckResult{
 		Allowed:      checkResult.IsAllowed(),
@@ -222,6 +227,7 @@ func (s *QuotaService) CheckQuota(ctx context.Context, input QuotaCheckInput) (*
 		Percentage:   checkResult.UsagePercent,
 		Status:       checkResult.Status,
 		Policy:       quota.OveragePolicy,
+		ResetAt:      checkResult.ResetAt,
 	}
 
 	// Handle warnings (soft limit reached)
@@ -403,6 +409,33 @@ func (s *QuotaService) CheckQuotaForResourceCreation(ctx context.Context, tenant
 	return nil
 }
 
+// CheckUsageQuota checks if a tenant can consume amount more of a metered usage type,
+// returning the domain check result with the current usage and the period reset time.
+// The result is not allowed only when the quota is exceeded under the BLOCK policy.
+func (s *QuotaService) CheckUsageQuota(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, amount int64) (*billing.QuotaCheckResult, error) {
+	result, err := s.CheckQuota(ctx, QuotaCheckInput{
+		TenantID:  tenantID,
+		UsageType: usageType,
+		Amount:    amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &billing.QuotaCheckResult{
+		UsageType:     result.UsageType,
+		Status:        result.Status,
+		CurrentUsage:  result.CurrentUsage,
+		Limit:         result.Limit,
+		SoftLimit:     result.SoftLimit,
+		Remaining:     result.Remaining,
+		UsagePercent:  result.Percentage,
+		OveragePolicy: result.Policy,
+		IsUnlimited:   result.Limit < 0,
+		ResetAt:       result.ResetAt,
+	}, nil
+}
+
 // CheckProductQuota checks if a tenant can create a new product
 func (s *QuotaService) CheckProductQuota(ctx context.Context, tenantID uuid.UUID) error {
 	return s.CheckQuotaForResourceCreation(ctx, tenantID, billing.UsageTypeProductsSKU)
@@ -458,12 +491,6 @@ func (s *QuotaService) GetQuotaStatus(ctx context.Context, tenantID uuid.UUID) (
 	return results, nil
 }
 
-// getCurrentUsage gets the current usage for a tenant and usage type based on reset period
-func (s *QuotaService) getCurrentUsage(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, resetPeriod billing.ResetPeriod) (int64, error) {
-	periodStart, periodEnd := s.calculatePeriodBoundaries(resetPeriod)
-	return s.getCurrentUsageForPeriod(ctx, tenantID, usageType, periodStart, periodEnd)
-}
-
 // getCurrentUsageForPeriod gets the current usage for a specific time period
 func (s *QuotaService) getCurrentUsageForPeriod(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, periodStart, periodEnd time.Time) (int64, error) {
 	// For countable resources (users, products, warehouses), we need current count
@@ -790,6 +790,78 @@ func TestCheckOrderQuota(t *testing.T) {
 	})
 }
 
+func TestCheckUsageQuota(t *testing.T) {
+	tests := []struct {
+		name        string
+		used        int64
+		wantAllowed bool
+		wantStatus  billing.QuotaStatus
+	}{
+		{name: "allows tenant at 99% of quota", used: 99, wantAllowed: true, wantStatus: billing.QuotaStatusOK},
+		{name: "blocks tenant at 100% of quota", used: 100, wantAllowed: false, wantStatus: billing.QuotaStatusExceeded},
+		{name: "blocks tenant over quota", used: 130, wantAllowed: false, wantStatus: billing.QuotaStatusExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotaRepo := new(mockUsageQuotaRepository)
+			usageRepo := new(mockUsageRecordRepository)
+			tenantRepo := new(mockTenantRepository)
+			eventPublisher := new(mockUsageEventPublisher)
+
+			tenantID := uuid.New()
+			tenant := createTestTenant(identity.TenantPlanBasic)
+			tenant.ID = tenantID
+
+			quota := createTestQuota(billing.UsageTypeOrdersCreated, 100, billing.OveragePolicyBlock)
+
+			tenantRepo.On("FindByID", mock.Anything, tenantID).Return(tenant, nil)
+			quotaRepo.On("FindEffectiveQuota", mock.Anything, tenantID, "basic", billing.UsageTypeOrdersCreated).Return(quota, nil)
+			usageRepo.On("SumByTenantAndType", mock.Anything, tenantID, billing.UsageTypeOrdersCreated, mock.Anything, mock.Anything).Return(tt.used, nil)
+			eventPublisher.On("PublishQuotaWarning", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			eventPublisher.On("PublishQuotaExceeded", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			service := newTestQuotaService(quotaRepo, usageRepo, tenantRepo, eventPublisher)
+
+			result, err := service.CheckUsageQuota(context.Background(), tenantID, billing.UsageTypeOrdersCreated, 1)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.IsAllowed())
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.used, result.CurrentUsage)
+			assert.Equal(t, int64(100), result.Limit)
+
+			// Monthly quotas reset at the start of next month
+			now := time.Now()
+			nextMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, 1, 0)
+			assert.True(t, nextMonth.Equal(result.ResetAt))
+		})
+	}
+
+	t.Run("reports unlimited when no quota is defined", func(t *testing.T) {
+		quotaRepo := new(mockUsageQuotaRepository)
+		usageRepo := new(mockUsageRecordRepository)
+		tenantRepo := new(mockTenantRepository)
+		eventPublisher := new(mockUsageEventPublisher)
+
+		tenantID := uuid.New()
+		tenant := createTestTenant(identity.TenantPlanBasic)
+		tenant.ID = tenantID
+
+		tenantRepo.On("FindByID", mock.Anything, tenantID).Return(tenant, nil)
+		quotaRepo.On("FindEffectiveQuota", mock.Anything, tenantID, "basic", billing.UsageTypeAPICalls).Return(nil, shared.ErrNotFound)
+
+		service := newTestQuotaService(quotaRepo, usageRepo, tenantRepo, eventPublisher)
+
+		result, err := service.CheckUsageQuota(context.Background(), tenantID, billing.UsageTypeAPICalls, 1)
+
+		require.NoError(t, err)
+		assert.True(t, result.IsAllowed())
+		assert.True(t, result.IsUnlimited)
+		assert.True(t, result.ResetAt.IsZero())
+	})
+}
+
 func TestCheckReportQuota(t *testing.T) {
 	t.Run("returns nil when within quota", func(t *testing.T) {
 		quotaRepo := new(mockUsageQuotaRepository)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	identityapp "github.com/erp/backend/internal/application/identity"
 	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/infrastructure/billing"
@@ -17,27 +18,30 @@ import (
 
 // StripeWebhookService handles Stripe webhook events
 type StripeWebhookService struct {
-	config     *billing.StripeConfig
-	tenantRepo identity.TenantRepository
-	eventBus   shared.EventBus
-	logger     *zap.Logger
+	config        *billing.StripeConfig
+	tenantRepo    identity.TenantRepository
+	tenantService *identityapp.TenantService
+	eventBus      shared.EventBus
+	logger        *zap.Logger
 }
 
 // StripeWebhookServiceConfig contains configuration for StripeWebhookService
 type StripeWebhookServiceConfig struct {
-	Config     *billing.StripeConfig
-	TenantRepo identity.TenantRepository
-	EventBus   shared.EventBus
-	Logger     *zap.Logger
+	Config        *billing.StripeConfig
+	TenantRepo    identity.TenantRepository
+	TenantService *identityapp.TenantService // Plan changes go through TenantService.SetPlan
+	EventBus      shared.EventBus
+	Logger        *zap.Logger
 }
 
 // NewStripeWebhookService creates a new StripeWebhookService
 func NewStripeWebhookService(cfg StripeWebhookServiceConfig) *StripeWebhookService {
 	return &StripeWebhookService{
-		config:     cfg.Config,
-		tenantRepo: cfg.TenantRepo,
-		eventBus:   cfg.EventBus,
-		logger:     cfg.Logger,
+		config:        cfg.Config,
+		tenantRepo:    cfg.TenantRepo,
+		tenantService: cfg.TenantService,
+		eventBus:      cfg.EventBus,
+		logger:        cfg.Logger,
 	}
 }
 
@@ -140,16 +144,6 @@ func (s *StripeWebhookService) handleSubscriptionCreated(ctx context.Context, ev
 	// Update tenant with subscription ID
 	tenant.SetStripeSubscriptionID(subscription.ID)
 
-	// Update plan based on subscription metadata
-	if planID, ok := subscription.Metadata["plan_id"]; ok {
-		plan := identity.TenantPlan(planID)
-		if err := tenant.SetPlan(plan); err != nil {
-			s.logger.Warn("Failed to set tenant plan",
-				zap.String("plan_id", planID),
-				zap.Error(err))
-		}
-	}
-
 	// Update expiration based on subscription period
 	if subscription.CurrentPeriodEnd > 0 {
 		expiresAt := time.Unix(subscription.CurrentPeriodEnd, 0)
@@ -171,6 +165,13 @@ func (s *StripeWebhookService) handleSubscriptionCreated(ctx context.Context, ev
 		return fmt.Errorf("failed to save tenant: %w", err)
 	}
 
+	// Update plan based on subscription metadata
+	if planID, ok := subscription.Metadata["plan_id"]; ok {
+		if err := s.setTenantPlan(ctx, tenant, identity.TenantPlan(planID)); err != nil {
+			return err
+		}
+	}
+
 	// Publish domain event
 	s.publishSubscriptionEvent(ctx, tenant.ID, "subscription_created", subscription.ID)
 
@@ -225,18 +226,6 @@ func (s *StripeWebhookService) handleSubscriptionUpdated(ctx context.Context, ev
 		tenant.SetStripeSubscriptionID(subscription.ID)
 	}
 
-	// Update plan if changed
-	if planID, ok := subscription.Metadata["plan_id"]; ok {
-		plan := identity.TenantPlan(planID)
-		if string(tenant.Plan) != planID {
-			if err := tenant.SetPlan(plan); err != nil {
-				s.logger.Warn("Failed to set tenant plan",
-					zap.String("plan_id", planID),
-					zap.Error(err))
-			}
-		}
-	}
-
 	// Update expiration
 	if subscription.CurrentPeriodEnd > 0 {
 		expiresAt := time.Unix(subscription.CurrentPeriodEnd, 0)
@@ -267,6 +256,13 @@ func (s *StripeWebhookService) handleSubscriptionUpdated(ctx context.Context, ev
 		return fmt.Errorf("failed to save tenant: %w", err)
 	}
 
+	// Update plan if changed
+	if planID, ok := subscription.Metadata["plan_id"]; ok {
+		if err := s.setTenantPlan(ctx, tenant, identity.TenantPlan(planID)); err != nil {
+			return err
+		}
+	}
+
 	// Publish domain event
 	s.publishSubscriptionEvent(ctx, tenant.ID, "subscription_updated", subscription.ID)
 
@@ -301,11 +297,6 @@ func (s *StripeWebhookService) handleSubscriptionDeleted(ctx context.Context, ev
 	// Clear subscription ID
 	tenant.ClearStripeSubscription()
 
-	// Downgrade to free plan
-	if err := tenant.SetPlan(identity.TenantPlanFree); err != nil {
-		s.logger.Warn("Failed to set tenant to free plan", zap.Error(err))
-	}
-
 	// Clear expiration for free plan
 	tenant.ClearExpiration()
 
@@ -314,6 +305,11 @@ func (s *StripeWebhookService) handleSubscriptionDeleted(ctx context.Context, ev
 		return fmt.Errorf("failed to save tenant: %w", err)
 	}
 
+	// Downgrade to free plan
+	if err := s.setTenantPlan(ctx, tenant, identity.TenantPlanFree); err != nil {
+		return err
+	}
+
 	// Publish domain event
 	s.publishSubscriptionEvent(ctx, tenant.ID, "subscription_deleted", subscription.ID)
 
@@ -452,6 +448,19 @@ func (s *StripeWebhookService) handleInvoicePaymentFailed(ctx context.Context, e
 	return nil
 }
 
+// setTenantPlan moves a tenant to plan through TenantService.SetPlan.
+// It must be called after the tenant's other changes are saved, since SetPlan reloads the tenant.
+func (s *StripeWebhookService) setTenantPlan(ctx context.Context, tenant *identity.Tenant, plan identity.TenantPlan) error {
+	if tenant.Plan == plan {
+		return nil
+	}
+
+	if _, err := s.tenantService.SetPlan(ctx, tenant.ID, string(plan)); err != nil {
+		return fmt.Errorf("failed to set tenant plan %s: %w", plan, err)
+	}
+	return nil
+}
+
 // publishSubscriptionEvent publishes a subscription-related domain event
 func (s *StripeWebhookService) publishSubscriptionEvent(ctx context.Context, tenantID uuid.UUID, eventType, subscriptionID string) {
 	if s.eventBus == nil {
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	identityapp "github.com/erp/backend/internal/application/identity"
 	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/infrastructure/billing"
@@ -158,10 +159,11 @@ func createWebhookTestService(t *testing.T, mockRepo *MockTenantRepository) *Str
 	}
 
 	return NewStripeWebhookService(StripeWebhookServiceConfig{
-		Config:     config,
-		TenantRepo: mockRepo,
-		EventBus:   nil,
-		Logger:     logger,
+		Config:        config,
+		TenantRepo:    mockRepo,
+		TenantService: identityapp.NewTenantService(mockRepo, logger),
+		EventBus:      nil,
+		Logger:        logger,
 	})
 }
 
@@ -212,12 +214,14 @@ func TestStripeWebhookService_handleSubscriptionCreated(t *testing.T) {
 
 	// Setup expectations
 	mockRepo.On("FindByStripeCustomerID", ctx, "cus_test123").Return(tenant, nil)
+	mockRepo.On("FindByID", ctx, tenant.ID).Return(tenant, nil)
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*identity.Tenant")).Return(nil)
 
 	// Call the handler directly
 	err := service.handleSubscriptionCreated(ctx, event)
 
 	assert.NoError(t, err)
+	assert.Equal(t, identity.TenantPlanPro, tenant.Plan)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -284,11 +288,13 @@ func TestStripeWebhookService_handleSubscriptionUpdated(t *testing.T) {
 
 	// Setup expectations
 	mockRepo.On("FindByStripeSubscriptionID", ctx, "sub_test123").Return(tenant, nil)
+	mockRepo.On("FindByID", ctx, tenant.ID).Return(tenant, nil)
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*identity.Tenant")).Return(nil)
 
 	err := service.handleSubscriptionUpdated(ctx, event)
 
 	assert.NoError(t, err)
+	assert.Equal(t, identity.TenantPlanEnterprise, tenant.Plan)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -320,11 +326,14 @@ func TestStripeWebhookService_handleSubscriptionDeleted(t *testing.T) {
 
 	// Setup expectations
 	mockRepo.On("FindByStripeSubscriptionID", ctx, "sub_test123").Return(tenant, nil)
+	mockRepo.On("FindByID", ctx, tenant.ID).Return(tenant, nil)
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*identity.Tenant")).Return(nil)
 
 	err := service.handleSubscriptionDeleted(ctx, event)
 
 	assert.NoError(t, err)
+	assert.Equal(t, identity.TenantPlanFree, tenant.Plan)
+	assert.Empty(t, tenant.StripeSubscriptionID)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -741,11 +750,13 @@ func TestStripeWebhookService_handleSubscriptionUpdated_WithPlanChange(t *testin
 	}
 
 	mockRepo.On("FindByStripeSubscriptionID", ctx, "sub_test123").Return(tenant, nil)
+	mockRepo.On("FindByID", ctx, tenant.ID).Return(tenant, nil)
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*identity.Tenant")).Return(nil)
 
 	err := service.handleSubscriptionUpdated(ctx, event)
 
 	assert.NoError(t, err)
+	assert.Equal(t, identity.TenantPlanPro, tenant.Plan)
 	mockRepo.AssertExpectations(t)
 }
 
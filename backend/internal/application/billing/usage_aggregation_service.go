@@ -0,0 +1,83 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/billing"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UsageAggregationService builds time-bucketed usage series from usage records
+type UsageAggregationService struct {
+	usageRepo  billing.UsageRecordRepository
+	tenantRepo identity.TenantRepository
+	logger     *zap.Logger
+}
+
+// NewUsageAggregationService creates a new UsageAggregationService
+func NewUsageAggregationService(
+	usageRepo billing.UsageRecordRepository,
+	tenantRepo identity.TenantRepository,
+	logger *zap.Logger,
+) *UsageAggregationService {
+	return &UsageAggregationService{
+		usageRepo:  usageRepo,
+		tenantRepo: tenantRepo,
+		logger:     logger,
+	}
+}
+
+// TenantLocation returns the tenant's configured time zone, falling back to UTC
+// when none is set or it is not a known zone
+func (s *UsageAggregationService) TenantLocation(ctx context.Context, tenantID uuid.UUID) (*time.Location, error) {
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Config.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(tenant.Config.Timezone)
+	if err != nil {
+		s.logger.Warn("Unknown tenant timezone, using UTC",
+			zap.String("tenant_id", tenantID.String()),
+			zap.String("timezone", tenant.Config.Timezone),
+			zap.Error(err))
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// AggregateUsage sums a tenant's usage of one type per hour, day or month over
+// [from, to). Buckets follow the tenant's time zone, and every bucket in the
+// range is returned, with zero usage where there were no records.
+func (s *UsageAggregationService) AggregateUsage(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	usageType billing.UsageType,
+	from, to time.Time,
+	granularity billing.AggregationPeriod,
+) ([]*billing.UsageMeter, error) {
+	loc, err := s.TenantLocation(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the request and fix the bucket range before querying
+	buckets, err := billing.NewUsageSeries(tenantID, usageType, from, to, granularity, loc, nil)
+	if err != nil {
+		return nil, err
+	}
+	start := buckets[0].PeriodStart
+	end := buckets[len(buckets)-1].PeriodEnd.Add(-time.Nanosecond)
+
+	aggregations, err := s.usageRepo.GetAggregatedUsage(ctx, tenantID, usageType, start, end, granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	return billing.NewUsageSeries(tenantID, usageType, from, to, granularity, loc, aggregations)
+}
@@ -0,0 +1,74 @@
+package billing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/billing"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUsageAggregationService_AggregateUsage(t *testing.T) {
+	ctx := context.Background()
+	tenant, err := identity.NewTenant("TEST", "Test Tenant")
+	require.NoError(t, err)
+	tenant.Config.Timezone = "Asia/Shanghai"
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+
+	t.Run("buckets by the tenant's days and fills empty days", func(t *testing.T) {
+		tenantRepo := new(mockTenantRepository)
+		usageRepo := new(mockUsageRecordRepository)
+		tenantRepo.On("FindByID", ctx, tenant.ID).Return(tenant, nil)
+
+		// Three Shanghai days, queried over whole buckets in Shanghai time
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, shanghai)
+		to := time.Date(2026, 3, 4, 0, 0, 0, 0, shanghai)
+		usageRepo.On("GetAggregatedUsage", ctx, tenant.ID, billing.UsageTypeAPICalls,
+			from, to.Add(-time.Nanosecond), billing.AggregationPeriodDay).
+			Return([]billing.UsageAggregation{
+				{PeriodStart: time.Date(2026, 3, 3, 0, 0, 0, 0, shanghai), TotalUsage: 12, RecordCount: 3},
+			}, nil)
+
+		service := NewUsageAggregationService(usageRepo, tenantRepo, zap.NewNop())
+		series, err := service.AggregateUsage(ctx, tenant.ID, billing.UsageTypeAPICalls, from.UTC(), to.UTC(), billing.AggregationPeriodDay)
+		require.NoError(t, err)
+
+		require.Len(t, series, 3)
+		assert.Zero(t, series[0].TotalUsage)
+		assert.Zero(t, series[1].TotalUsage)
+		assert.Equal(t, int64(12), series[2].TotalUsage)
+		assert.Equal(t, shanghai, series[0].PeriodStart.Location())
+		usageRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown tenant timezone falls back to UTC", func(t *testing.T) {
+		other, err := identity.NewTenant("OTHER", "Other Tenant")
+		require.NoError(t, err)
+		other.Config.Timezone = "Mars/Olympus_Mons"
+		tenantRepo := new(mockTenantRepository)
+		tenantRepo.On("FindByID", ctx, other.ID).Return(other, nil)
+
+		service := NewUsageAggregationService(new(mockUsageRecordRepository), tenantRepo, zap.NewNop())
+		loc, err := service.TenantLocation(ctx, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, time.UTC, loc)
+	})
+
+	t.Run("invalid granularity does not query usage", func(t *testing.T) {
+		tenantRepo := new(mockTenantRepository)
+		usageRepo := new(mockUsageRecordRepository)
+		tenantRepo.On("FindByID", ctx, tenant.ID).Return(tenant, nil)
+
+		service := NewUsageAggregationService(usageRepo, tenantRepo, zap.NewNop())
+		now := time.Now()
+		_, err := service.AggregateUsage(ctx, tenant.ID, billing.UsageTypeAPICalls, now.AddDate(0, 0, -7), now, billing.AggregationPeriodWeek)
+		require.Error(t, err)
+		usageRepo.AssertNotCalled(t, "GetAggregatedUsage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -254,6 +254,158 @@ func (s *UsageReportingService) ReportUsageForAllTenants(ctx context.Context) er
 	return nil
 }
 
+// ReportPeriodEndUsage reports the usage of every metered subscription whose billing
+// period has ended by now. Each tenant's usage records are rolled up over the closed
+// period and reported once per usage type; periods already reported are skipped.
+func (s *UsageReportingService) ReportPeriodEndUsage(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscriptions, err := s.subscriptionRepo.FindAllActiveWithMeteredBilling(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find active subscriptions: %w", err)
+	}
+
+	var failed int
+	for _, sub := range subscriptions {
+		if sub.CurrentPeriodEnd.IsZero() || sub.CurrentPeriodEnd.After(now) {
+			continue
+		}
+		if err := s.ReportPeriodUsage(ctx, sub, sub.CurrentPeriodStart, sub.CurrentPeriodEnd); err != nil {
+			s.logger.Error("Failed to report period usage for tenant",
+				zap.String("tenant_id", sub.TenantID.String()),
+				zap.Error(err))
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to report period usage for %d tenants", failed)
+	}
+	return nil
+}
+
+// ReportPeriodUsage rolls up a tenant's usage over the billing period [periodStart, periodEnd)
+// and reports it to Stripe, once per configured usage type. A usage report log keyed by
+// tenant, subscription item, usage type and period start marks what has been reported, so
+// reporting the same period again is a no-op.
+func (s *UsageReportingService) ReportPeriodUsage(
+	ctx context.Context,
+	subscription *TenantSubscription,
+	periodStart, periodEnd time.Time,
+) error {
+	var failed int
+	for _, usageType := range s.config.UsageTypes {
+		if err := s.reportPeriodUsageType(ctx, subscription, usageType, periodStart, periodEnd); err != nil {
+			s.logger.Error("Failed to report period usage",
+				zap.String("tenant_id", subscription.TenantID.String()),
+				zap.String("usage_type", usageType.String()),
+				zap.Time("period_start", periodStart),
+				zap.Error(err))
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to report %d usage types", failed)
+	}
+	return nil
+}
+
+// reportPeriodUsageType reports one usage type for a closed billing period
+func (s *UsageReportingService) reportPeriodUsageType(
+	ctx context.Context,
+	subscription *TenantSubscription,
+	usageType domainBilling.UsageType,
+	periodStart, periodEnd time.Time,
+) error {
+	tenantID := subscription.TenantID
+	idempotencyKey := infraBilling.GenerateIdempotencyKey(
+		tenantID,
+		subscription.SubscriptionItemID,
+		usageType,
+		periodStart,
+	)
+
+	reportLog, err := s.reportLogRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		return fmt.Errorf("failed to check usage report marker: %w", err)
+	}
+	if reportLog != nil && reportLog.Status == infraBilling.UsageReportStatusSuccess {
+		s.logger.Debug("Period usage already reported",
+			zap.String("tenant_id", tenantID.String()),
+			zap.String("usage_type", usageType.String()),
+			zap.String("idempotency_key", idempotencyKey))
+		return nil
+	}
+
+	// Records are summed with an inclusive end, so stop just short of the next period
+	totalUsage, err := s.usageRecordRepo.SumByTenantAndType(ctx, tenantID, usageType, periodStart, periodEnd.Add(-time.Nanosecond))
+	if err != nil {
+		return fmt.Errorf("failed to get usage sum: %w", err)
+	}
+	if totalUsage == 0 {
+		return nil
+	}
+
+	// Usage must be timestamped within the period it is billed in
+	timestamp := periodEnd.Add(-time.Second)
+
+	// Save the marker before reporting; a concurrent run hits the unique key and stops here
+	if reportLog == nil {
+		reportLog = infraBilling.NewUsageReportLog(
+			tenantID,
+			subscription.SubscriptionItemID,
+			usageType,
+			totalUsage,
+			timestamp,
+		)
+		reportLog.IdempotencyKey = idempotencyKey
+		if err := s.reportLogRepo.Save(ctx, reportLog); err != nil {
+			return fmt.Errorf("failed to save usage report log: %w", err)
+		}
+	} else if reportLog.Quantity != totalUsage {
+		reportLog.Quantity = totalUsage
+		reportLog.UpdatedAt = time.Now()
+		if err := s.reportLogRepo.Update(ctx, reportLog); err != nil {
+			return fmt.Errorf("failed to update usage report log: %w", err)
+		}
+	}
+
+	output, err := s.stripeAdapter.ReportUsage(ctx, infraBilling.UsageReportInput{
+		TenantID:           tenantID,
+		SubscriptionItemID: subscription.SubscriptionItemID,
+		Quantity:           totalUsage,
+		Timestamp:          timestamp,
+		Action:             "set", // Absolute usage for the period
+		IdempotencyKey:     idempotencyKey,
+	})
+	if err != nil {
+		if markErr := s.reportLogRepo.MarkAsFailed(ctx, reportLog.ID, err.Error()); markErr != nil {
+			s.logger.Error("Failed to mark report as failed",
+				zap.String("report_id", reportLog.ID.String()),
+				zap.Error(markErr))
+		}
+		return fmt.Errorf("failed to report usage to Stripe: %w", err)
+	}
+
+	if err := s.reportLogRepo.MarkAsSuccess(ctx, reportLog.ID, output.UsageRecordID); err != nil {
+		// Stripe deduplicates on the idempotency key if the next run reports again
+		s.logger.Error("Failed to mark report as successful",
+			zap.String("report_id", reportLog.ID.String()),
+			zap.Error(err))
+	}
+
+	s.logger.Info("Reported period usage to Stripe",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("usage_type", usageType.String()),
+		zap.Time("period_start", periodStart),
+		zap.Int64("quantity", totalUsage),
+		zap.String("stripe_record_id", output.UsageRecordID))
+
+	return nil
+}
+
 // RetryFailedReports retries failed usage reports
 func (s *UsageReportingService) RetryFailedReports(ctx context.Context) error {
 	s.logger.Info("Starting retry of failed usage reports")
@@ -0,0 +1,244 @@
+package billing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	domainBilling "github.com/erp/backend/internal/domain/billing"
+	infraBilling "github.com/erp/backend/internal/infrastructure/billing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/form"
+	"go.uber.org/zap"
+)
+
+// usageRecordEndpoint fakes Stripe's subscription item usage-record endpoint
+type usageRecordEndpoint struct {
+	mu    sync.Mutex
+	calls []*stripe.UsageRecordParams
+	err   error
+}
+
+func (b *usageRecordEndpoint) Call(method, path, key string, params stripe.ParamsContainer, v stripe.LastResponseSetter) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usageParams, ok := params.(*stripe.UsageRecordParams)
+	if !ok || method != "POST" || path != fmt.Sprintf("/v1/subscription_items/%s/usage_records", *usageParams.SubscriptionItem) {
+		return fmt.Errorf("unexpected call: %s %s", method, path)
+	}
+	if b.err != nil {
+		return b.err
+	}
+	b.calls = append(b.calls, usageParams)
+	data, err := json.Marshal(&stripe.UsageRecord{
+		ID:               fmt.Sprintf("mbur_%d", len(b.calls)),
+		SubscriptionItem: *usageParams.SubscriptionItem,
+		Quantity:         *usageParams.Quantity,
+		Timestamp:        *usageParams.Timestamp,
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (b *usageRecordEndpoint) CallStreaming(method, path, key string, params stripe.ParamsContainer, v stripe.StreamingLastResponseSetter) error {
+	return nil
+}
+
+func (b *usageRecordEndpoint) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v stripe.LastResponseSetter) error {
+	return nil
+}
+
+func (b *usageRecordEndpoint) CallMultipart(method, path, key, boundary string, body *bytes.Buffer, params *stripe.Params, v stripe.LastResponseSetter) error {
+	return nil
+}
+
+func (b *usageRecordEndpoint) SetMaxNetworkRetries(maxNetworkRetries int64) {}
+
+// memoryUsageReportLogRepository keeps usage report logs in memory
+type memoryUsageReportLogRepository struct {
+	infraBilling.UsageReportLogRepository
+	logs map[uuid.UUID]*infraBilling.UsageReportLog
+}
+
+func newMemoryUsageReportLogRepository() *memoryUsageReportLogRepository {
+	return &memoryUsageReportLogRepository{logs: make(map[uuid.UUID]*infraBilling.UsageReportLog)}
+}
+
+func (r *memoryUsageReportLogRepository) Save(ctx context.Context, log *infraBilling.UsageReportLog) error {
+	for _, existing := range r.logs {
+		if log.IdempotencyKey != "" && existing.IdempotencyKey == log.IdempotencyKey {
+			return fmt.Errorf("duplicate idempotency key %s", log.IdempotencyKey)
+		}
+	}
+	r.logs[log.ID] = log
+	return nil
+}
+
+func (r *memoryUsageReportLogRepository) Update(ctx context.Context, log *infraBilling.UsageReportLog) error {
+	r.logs[log.ID] = log
+	return nil
+}
+
+func (r *memoryUsageReportLogRepository) FindByIdempotencyKey(ctx context.Context, key string) (*infraBilling.UsageReportLog, error) {
+	for _, log := range r.logs {
+		if log.IdempotencyKey == key {
+			return log, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUsageReportLogRepository) MarkAsSuccess(ctx context.Context, id uuid.UUID, stripeRecordID string) error {
+	r.logs[id].Status = infraBilling.UsageReportStatusSuccess
+	r.logs[id].StripeRecordID = stripeRecordID
+	return nil
+}
+
+func (r *memoryUsageReportLogRepository) MarkAsFailed(ctx context.Context, id uuid.UUID, errorMessage string) error {
+	r.logs[id].Status = infraBilling.UsageReportStatusFailed
+	r.logs[id].ErrorMessage = errorMessage
+	return nil
+}
+
+// staticSubscriptionRepository serves a fixed set of metered subscriptions
+type staticSubscriptionRepository struct {
+	subscriptions []*TenantSubscription
+}
+
+func (r *staticSubscriptionRepository) FindActiveByTenant(ctx context.Context, tenantID uuid.UUID) (*TenantSubscription, error) {
+	for _, sub := range r.subscriptions {
+		if sub.TenantID == tenantID {
+			return sub, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *staticSubscriptionRepository) FindAllActiveWithMeteredBilling(ctx context.Context) ([]*TenantSubscription, error) {
+	return r.subscriptions, nil
+}
+
+func setupUsageReportingTest(t *testing.T, subscriptions ...*TenantSubscription) (*UsageReportingService, *usageRecordEndpoint, *mockUsageRecordRepository, *memoryUsageReportLogRepository) {
+	t.Helper()
+
+	endpoint := &usageRecordEndpoint{}
+	stripe.SetBackend(stripe.APIBackend, endpoint)
+	t.Cleanup(func() { stripe.SetBackend(stripe.APIBackend, nil) })
+
+	adapter, err := infraBilling.NewStripeAdapter(&infraBilling.StripeConfig{
+		SecretKey:       "sk_test_123456789",
+		PublishableKey:  "pk_test_123456789",
+		WebhookSecret:   "whsec_test_123456789",
+		IsTestMode:      true,
+		DefaultCurrency: "usd",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	usageRepo := new(mockUsageRecordRepository)
+	logRepo := newMemoryUsageReportLogRepository()
+	config := DefaultUsageReportingConfig()
+	config.UsageTypes = []domainBilling.UsageType{domainBilling.UsageTypeOrdersCreated}
+
+	service := NewUsageReportingService(adapter, usageRepo, logRepo,
+		&staticSubscriptionRepository{subscriptions: subscriptions}, zap.NewNop(), config)
+	return service, endpoint, usageRepo, logRepo
+}
+
+func TestUsageReportingService_ReportPeriodEndUsage(t *testing.T) {
+	ctx := context.Background()
+	periodStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("reports the usage rolled up over the closed period", func(t *testing.T) {
+		sub := &TenantSubscription{
+			TenantID:           uuid.New(),
+			SubscriptionItemID: "si_enterprise",
+			CurrentPeriodStart: periodStart,
+			CurrentPeriodEnd:   periodEnd,
+		}
+		service, endpoint, usageRepo, logRepo := setupUsageReportingTest(t, sub)
+		usageRepo.On("SumByTenantAndType", ctx, sub.TenantID, domainBilling.UsageTypeOrdersCreated,
+			periodStart, periodEnd.Add(-time.Nanosecond)).Return(int64(1842), nil)
+
+		require.NoError(t, service.ReportPeriodEndUsage(ctx, periodEnd.Add(time.Minute)))
+
+		require.Len(t, endpoint.calls, 1)
+		call := endpoint.calls[0]
+		assert.Equal(t, int64(1842), *call.Quantity)
+		assert.Equal(t, "set", *call.Action)
+		assert.Equal(t, periodEnd.Add(-time.Second).Unix(), *call.Timestamp)
+		expectedKey := infraBilling.GenerateIdempotencyKey(sub.TenantID, "si_enterprise", domainBilling.UsageTypeOrdersCreated, periodStart)
+		assert.Equal(t, expectedKey, *call.IdempotencyKey)
+
+		marker, err := logRepo.FindByIdempotencyKey(ctx, expectedKey)
+		require.NoError(t, err)
+		require.NotNil(t, marker)
+		assert.Equal(t, infraBilling.UsageReportStatusSuccess, marker.Status)
+		assert.Equal(t, int64(1842), marker.Quantity)
+		usageRepo.AssertExpectations(t)
+	})
+
+	t.Run("does not report the same period twice", func(t *testing.T) {
+		sub := &TenantSubscription{
+			TenantID:           uuid.New(),
+			SubscriptionItemID: "si_enterprise",
+			CurrentPeriodStart: periodStart,
+			CurrentPeriodEnd:   periodEnd,
+		}
+		service, endpoint, usageRepo, _ := setupUsageReportingTest(t, sub)
+		usageRepo.On("SumByTenantAndType", ctx, sub.TenantID, domainBilling.UsageTypeOrdersCreated,
+			periodStart, periodEnd.Add(-time.Nanosecond)).Return(int64(75), nil).Once()
+
+		require.NoError(t, service.ReportPeriodEndUsage(ctx, periodEnd.Add(time.Minute)))
+		require.NoError(t, service.ReportPeriodEndUsage(ctx, periodEnd.Add(time.Hour)))
+
+		assert.Len(t, endpoint.calls, 1)
+		usageRepo.AssertExpectations(t)
+	})
+
+	t.Run("retries a period whose report failed", func(t *testing.T) {
+		sub := &TenantSubscription{
+			TenantID:           uuid.New(),
+			SubscriptionItemID: "si_enterprise",
+			CurrentPeriodStart: periodStart,
+			CurrentPeriodEnd:   periodEnd,
+		}
+		service, endpoint, usageRepo, logRepo := setupUsageReportingTest(t, sub)
+		usageRepo.On("SumByTenantAndType", ctx, sub.TenantID, domainBilling.UsageTypeOrdersCreated,
+			periodStart, periodEnd.Add(-time.Nanosecond)).Return(int64(75), nil)
+
+		endpoint.err = &stripe.Error{HTTPStatusCode: 500, Msg: "stripe unavailable"}
+		require.Error(t, service.ReportPeriodEndUsage(ctx, periodEnd.Add(time.Minute)))
+		assert.Empty(t, endpoint.calls)
+
+		endpoint.err = nil
+		require.NoError(t, service.ReportPeriodEndUsage(ctx, periodEnd.Add(time.Hour)))
+		require.Len(t, endpoint.calls, 1)
+		assert.Equal(t, int64(75), *endpoint.calls[0].Quantity)
+		assert.Len(t, logRepo.logs, 1)
+	})
+
+	t.Run("skips periods that have not ended", func(t *testing.T) {
+		sub := &TenantSubscription{
+			TenantID:           uuid.New(),
+			SubscriptionItemID: "si_enterprise",
+			CurrentPeriodStart: periodStart,
+			CurrentPeriodEnd:   periodEnd,
+		}
+		service, endpoint, usageRepo, _ := setupUsageReportingTest(t, sub)
+
+		require.NoError(t, service.ReportPeriodEndUsage(ctx, periodEnd.Add(-time.Hour)))
+
+		assert.Empty(t, endpoint.calls)
+		usageRepo.AssertNotCalled(t, "SumByTenantAndType")
+	})
+}
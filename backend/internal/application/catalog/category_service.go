@@ -237,6 +237,11 @@ func (s *CategoryService) Move(ctx context.Context, tenantID, id uuid.UUID, req
 	var newLevel int
 
 	if req.ParentID != nil {
+		// Prevent moving a category under itself
+		if *req.ParentID == category.ID {
+			return nil, catalog.ErrCategoryCycle
+		}
+
 		// Moving to a new parent
 		newParent, err := s.categoryRepo.FindByIDForTenant(ctx, tenantID, *req.ParentID)
 		if err != nil {
@@ -246,9 +251,9 @@ func (s *CategoryService) Move(ctx context.Context, tenantID, id uuid.UUID, req
 			return nil, err
 		}
 
-		// Prevent circular reference
-		if category.IsAncestorOf(newParent) {
-			return nil, shared.NewDomainError("CIRCULAR_REFERENCE", "Cannot move category to its own descendant")
+		// Prevent moving a category under its own descendant
+		if err := s.checkMoveCycle(ctx, tenantID, category, newParent); err != nil {
+			return nil, err
 		}
 
 		// Check depth limit
@@ -268,7 +273,7 @@ func (s *CategoryService) Move(ctx context.Context, tenantID, id uuid.UUID, req
 	levelDelta := newLevel - category.Level
 
 	// Update the category and its descendants
-	if err := s.categoryRepo.UpdatePath(ctx, tenantID, id, newPath, levelDelta); err != nil {
+	if err := s.categoryRepo.UpdatePath(ctx, tenantID, id, req.ParentID, newPath, levelDelta); err != nil {
 		return nil, err
 	}
 
@@ -281,6 +286,38 @@ func (s *CategoryService) Move(ctx context.Context, tenantID, id uuid.UUID, req
 	return ToCategoryResponse(category), nil
 }
 
+// checkMoveCycle walks the ancestor chain of the new parent and returns ErrCategoryCycle
+// if the category being moved appears in it.
+// The chain is followed through parent IDs rather than the materialized path,
+// so a stale path cannot hide a cycle.
+func (s *CategoryService) checkMoveCycle(ctx context.Context, tenantID uuid.UUID, category, newParent *catalog.Category) error {
+	if category.IsAncestorOf(newParent) {
+		return catalog.ErrCategoryCycle
+	}
+
+	visited := map[uuid.UUID]bool{}
+	current := newParent
+	for current != nil {
+		if current.ID == category.ID || visited[current.ID] {
+			return catalog.ErrCategoryCycle
+		}
+		visited[current.ID] = true
+
+		if current.ParentID == nil {
+			return nil
+		}
+		parent, err := s.categoryRepo.FindByIDForTenant(ctx, tenantID, *current.ParentID)
+		if err != nil {
+			if errors.Is(err, shared.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		current = parent
+	}
+	return nil
+}
+
 // Activate activates a category
 func (s *CategoryService) Activate(ctx context.Context, tenantID, id uuid.UUID) (*CategoryResponse, error) {
 	category, err := s.categoryRepo.FindByIDForTenant(ctx, tenantID, id)
@@ -390,7 +427,8 @@ func updateTreeReferences(nodes []CategoryTreeNode, nodeMap map[uuid.UUID]*Categ
 	result := make([]CategoryTreeNode, len(nodes))
 	for i, node := range nodes {
 		result[i] = node
-		if len(node.Children) > 0 {
+		// Roots were copied before their children were attached, so read children from the map
+		if len(nodeMap[node.ID].Children) > 0 {
 			children := make([]CategoryTreeNode, 0)
 			for _, child := range nodeMap[node.ID].Children {
 				childNode := *nodeMap[child.ID]
@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testCategoryTree is a three-level category tree: root > child > grandchild, plus a sibling of child
+type testCategoryTree struct {
+	root       *catalog.Category
+	child      *catalog.Category
+	sibling    *catalog.Category
+	grandchild *catalog.Category
+}
+
+func (tree testCategoryTree) all() []*catalog.Category {
+	return []*catalog.Category{tree.root, tree.child, tree.sibling, tree.grandchild}
+}
+
+func newTestCategoryTree(t *testing.T, tenantID uuid.UUID) testCategoryTree {
+	root, err := catalog.NewCategory(tenantID, "ROOT", "Root")
+	require.NoError(t, err)
+	child, err := catalog.NewChildCategory(tenantID, "CHILD", "Child", root)
+	require.NoError(t, err)
+	sibling, err := catalog.NewChildCategory(tenantID, "SIBLING", "Sibling", root)
+	require.NoError(t, err)
+	grandchild, err := catalog.NewChildCategory(tenantID, "GRANDCHILD", "Grandchild", child)
+	require.NoError(t, err)
+	return testCategoryTree{root: root, child: child, sibling: sibling, grandchild: grandchild}
+}
+
+func newTestCategoryService(ctx context.Context, tenantID uuid.UUID, tree testCategoryTree) (*CategoryService, *MockCategoryRepository) {
+	mockCategoryRepo := new(MockCategoryRepository)
+	for _, category := range tree.all() {
+		mockCategoryRepo.On("FindByIDForTenant", ctx, tenantID, category.ID).Return(category, nil)
+	}
+	return NewCategoryService(mockCategoryRepo, new(MockProductRepository)), mockCategoryRepo
+}
+
+func TestCategoryService_Move_RejectsCycles(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	tree := newTestCategoryTree(t, tenantID)
+
+	tests := []struct {
+		name     string
+		category *catalog.Category
+		parent   *catalog.Category
+	}{
+		{"category under itself", tree.child, tree.child},
+		{"root under its child", tree.root, tree.child},
+		{"root under its grandchild", tree.root, tree.grandchild},
+		{"child under its own child", tree.child, tree.grandchild},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockCategoryRepo := newTestCategoryService(ctx, tenantID, tree)
+
+			_, err := service.Move(ctx, tenantID, tt.category.ID, MoveCategoryRequest{ParentID: &tt.parent.ID})
+
+			assert.ErrorIs(t, err, catalog.ErrCategoryCycle)
+			mockCategoryRepo.AssertNotCalled(t, "UpdatePath", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("detects a cycle hidden by a stale path", func(t *testing.T) {
+		service, mockCategoryRepo := newTestCategoryService(ctx, tenantID, tree)
+		staleGrandchild := *tree.grandchild
+		staleGrandchild.Path = tree.grandchild.ID.String()
+		mockCategoryRepo.ExpectedCalls = nil
+		for _, category := range []*catalog.Category{tree.root, tree.child, tree.sibling, &staleGrandchild} {
+			mockCategoryRepo.On("FindByIDForTenant", ctx, tenantID, category.ID).Return(category, nil)
+		}
+
+		_, err := service.Move(ctx, tenantID, tree.root.ID, MoveCategoryRequest{ParentID: &tree.grandchild.ID})
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, catalog.ErrCategoryCycle.Code, domainErr.Code)
+	})
+}
+
+func TestCategoryService_Move_SiblingKeepsTreeAcyclic(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	tree := newTestCategoryTree(t, tenantID)
+	service, mockCategoryRepo := newTestCategoryService(ctx, tenantID, tree)
+
+	newPath := tree.sibling.Path + "/" + tree.grandchild.ID.String()
+	mockCategoryRepo.On("UpdatePath", ctx, tenantID, tree.grandchild.ID, &tree.sibling.ID, newPath, 0).
+		Run(func(args mock.Arguments) {
+			tree.grandchild.ParentID = args.Get(3).(*uuid.UUID)
+			tree.grandchild.Path = args.String(4)
+		}).
+		Return(nil)
+
+	result, err := service.Move(ctx, tenantID, tree.grandchild.ID, MoveCategoryRequest{ParentID: &tree.sibling.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, &tree.sibling.ID, result.ParentID)
+	assert.Equal(t, newPath, result.Path)
+
+	categories := make([]catalog.Category, 0, len(tree.all()))
+	for _, category := range tree.all() {
+		categories = append(categories, *category)
+	}
+	mockCategoryRepo.On("FindAllForTenant", ctx, tenantID, mock.Anything).Return(categories, nil)
+
+	roots, err := service.GetTree(ctx, tenantID)
+
+	require.NoError(t, err)
+	require.Len(t, roots, 1)
+	seen := map[uuid.UUID]int{}
+	var walk func(nodes []CategoryTreeNode)
+	walk = func(nodes []CategoryTreeNode) {
+		for _, node := range nodes {
+			seen[node.ID]++
+			walk(node.Children)
+		}
+	}
+	walk(roots)
+	assert.Len(t, seen, len(categories))
+	for id, count := range seen {
+		assert.Equal(t, 1, count, "category %s appears more than once in the tree", id)
+	}
+
+	var siblingNode CategoryTreeNode
+	for _, node := range roots[0].Children {
+		if node.ID == tree.sibling.ID {
+			siblingNode = node
+		}
+	}
+	require.Len(t, siblingNode.Children, 1)
+	assert.Equal(t, tree.grandchild.ID, siblingNode.Children[0].ID)
+}
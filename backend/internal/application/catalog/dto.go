@@ -10,31 +10,41 @@ import (
 
 // CreateProductRequest represents a request to create a new product
 type CreateProductRequest struct {
-	Code          string           `json:"code" binding:"required,min=1,max=50"`
-	Name          string           `json:"name" binding:"required,min=1,max=200"`
-	Description   string           `json:"description" binding:"max=2000"`
-	Barcode       string           `json:"barcode" binding:"max=50"`
-	CategoryID    *uuid.UUID       `json:"category_id"`
-	Unit          string           `json:"unit" binding:"required,min=1,max=20"`
-	PurchasePrice *decimal.Decimal `json:"purchase_price"`
-	SellingPrice  *decimal.Decimal `json:"selling_price"`
-	MinStock      *decimal.Decimal `json:"min_stock"`
-	SortOrder     *int             `json:"sort_order"`
-	Attributes    string           `json:"attributes"`
-	CreatedBy     *uuid.UUID       `json:"-"` // Set from JWT context, not from request body
+	Code          string             `json:"code" binding:"required,min=1,max=50"`
+	Name          string             `json:"name" binding:"required,min=1,max=200"`
+	Description   string             `json:"description" binding:"max=2000"`
+	Barcode       string             `json:"barcode" binding:"max=50"`
+	CategoryID    *uuid.UUID         `json:"category_id"`
+	Unit          string             `json:"unit" binding:"required,min=1,max=20"`
+	PurchasePrice *decimal.Decimal   `json:"purchase_price"`
+	SellingPrice  *decimal.Decimal   `json:"selling_price"`
+	MinStock      *decimal.Decimal   `json:"min_stock"`
+	SortOrder     *int               `json:"sort_order"`
+	Attributes    string             `json:"attributes"`
+	IsSerialized  bool               `json:"is_serialized"`
+	Weight        *decimal.Decimal   `json:"weight"`
+	Volume        *decimal.Decimal   `json:"volume"`
+	PriceTiers    []ProductPriceTier `json:"price_tiers"` // Quantity breaks by ascending minimum quantity
+	CreatedBy     *uuid.UUID         `json:"-"`           // Set from JWT context, not from request body
 }
 
 // UpdateProductRequest represents a request to update a product
 type UpdateProductRequest struct {
-	Name          *string          `json:"name" binding:"omitempty,min=1,max=200"`
-	Description   *string          `json:"description" binding:"omitempty,max=2000"`
-	Barcode       *string          `json:"barcode" binding:"omitempty,max=50"`
-	CategoryID    *uuid.UUID       `json:"category_id"`
-	PurchasePrice *decimal.Decimal `json:"purchase_price"`
-	SellingPrice  *decimal.Decimal `json:"selling_price"`
-	MinStock      *decimal.Decimal `json:"min_stock"`
-	SortOrder     *int             `json:"sort_order"`
-	Attributes    *string          `json:"attributes"`
+	Name          *string             `json:"name" binding:"omitempty,min=1,max=200"`
+	Description   *string             `json:"description" binding:"omitempty,max=2000"`
+	Barcode       *string             `json:"barcode" binding:"omitempty,max=50"`
+	CategoryID    *uuid.UUID          `json:"category_id"`
+	PurchasePrice *decimal.Decimal    `json:"purchase_price"`
+	SellingPrice  *decimal.Decimal    `json:"selling_price"`
+	MinStock      *decimal.Decimal    `json:"min_stock"`
+	SortOrder     *int                `json:"sort_order"`
+	Attributes    *string             `json:"attributes"`
+	IsSerialized  *bool               `json:"is_serialized"`
+	Weight        *decimal.Decimal    `json:"weight"`
+	Volume        *decimal.Decimal    `json:"volume"`
+	PriceTiers    *[]ProductPriceTier `json:"price_tiers"` // Replaces the quantity breaks; an empty list removes them
+	Version       *int                `json:"version"`     // Version the client loaded; the update fails with a conflict if the product changed since
+	UpdatedBy     *uuid.UUID          `json:"-"`           // Set from JWT context, not from request body
 }
 
 // UpdateProductCodeRequest represents a request to update a product's code
@@ -44,24 +54,35 @@ type UpdateProductCodeRequest struct {
 
 // ProductResponse represents a product in API responses
 type ProductResponse struct {
-	ID            uuid.UUID       `json:"id"`
-	TenantID      uuid.UUID       `json:"tenant_id"`
-	Code          string          `json:"code"`
-	Name          string          `json:"name"`
-	Description   string          `json:"description"`
-	Barcode       string          `json:"barcode"`
-	CategoryID    *uuid.UUID      `json:"category_id"`
-	Unit          string          `json:"unit"`
-	PurchasePrice decimal.Decimal `json:"purchase_price"`
-	SellingPrice  decimal.Decimal `json:"selling_price"`
-	MinStock      decimal.Decimal `json:"min_stock"`
-	Status        string          `json:"status"`
-	SortOrder     int             `json:"sort_order"`
-	Attributes    string          `json:"attributes"`
-	ProfitMargin  decimal.Decimal `json:"profit_margin"`
-	CreatedAt     time.Time       `json:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at"`
-	Version       int             `json:"version"`
+	ID            uuid.UUID          `json:"id"`
+	TenantID      uuid.UUID          `json:"tenant_id"`
+	Code          string             `json:"code"`
+	Name          string             `json:"name"`
+	Description   string             `json:"description"`
+	Barcode       string             `json:"barcode"`
+	CategoryID    *uuid.UUID         `json:"category_id"`
+	Unit          string             `json:"unit"`
+	PurchasePrice decimal.Decimal    `json:"purchase_price"`
+	SellingPrice  decimal.Decimal    `json:"selling_price"`
+	MinStock      decimal.Decimal    `json:"min_stock"`
+	Status        string             `json:"status"`
+	SortOrder     int                `json:"sort_order"`
+	Attributes    string             `json:"attributes"`
+	IsSerialized  bool               `json:"is_serialized"`
+	Weight        decimal.Decimal    `json:"weight"`
+	Volume        decimal.Decimal    `json:"volume"`
+	PriceTiers    []ProductPriceTier `json:"price_tiers"`
+	ProfitMargin  decimal.Decimal    `json:"profit_margin"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	DeletedAt     *time.Time         `json:"deleted_at,omitempty"`
+	Version       int                `json:"version"`
+}
+
+// ProductPriceTier is a quantity break of a product: ordering at least MinQuantity sells at UnitPrice
+type ProductPriceTier struct {
+	MinQuantity decimal.Decimal `json:"min_quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
 }
 
 // ProductListResponse represents a list item for products
@@ -77,21 +98,23 @@ type ProductListResponse struct {
 	Status        string          `json:"status"`
 	SortOrder     int             `json:"sort_order"`
 	CreatedAt     time.Time       `json:"created_at"`
+	DeletedAt     *time.Time      `json:"deleted_at,omitempty"`
 }
 
 // ProductListFilter represents filter options for product list
 type ProductListFilter struct {
-	Search     string     `form:"search"`
-	Status     string     `form:"status" binding:"omitempty,oneof=active inactive discontinued"`
-	CategoryID *uuid.UUID `form:"category_id"`
-	Unit       string     `form:"unit"`
-	MinPrice   *float64   `form:"min_price"`
-	MaxPrice   *float64   `form:"max_price"`
-	HasBarcode *bool      `form:"has_barcode"`
-	Page       int        `form:"page" binding:"omitempty,min=1"`
-	PageSize   int        `form:"page_size" binding:"omitempty,min=1,max=100"`
-	OrderBy    string     `form:"order_by"`
-	OrderDir   string     `form:"order_dir" binding:"omitempty,oneof=asc desc"`
+	Search         string     `form:"search"`
+	Status         string     `form:"status" binding:"omitempty,oneof=active inactive discontinued"`
+	CategoryID     *uuid.UUID `form:"category_id"`
+	Unit           string     `form:"unit"`
+	MinPrice       *float64   `form:"min_price"`
+	MaxPrice       *float64   `form:"max_price"`
+	HasBarcode     *bool      `form:"has_barcode"`
+	Page           int        `form:"page" binding:"omitempty,min=1"`
+	PageSize       int        `form:"page_size" binding:"omitempty,min=1,max=100"`
+	OrderBy        string     `form:"order_by"`
+	OrderDir       string     `form:"order_dir" binding:"omitempty,oneof=asc desc"`
+	IncludeDeleted bool       `form:"include_deleted"` // Also list soft-deleted products
 }
 
 // ToProductResponse converts a domain Product to ProductResponse
@@ -111,13 +134,36 @@ func ToProductResponse(p *catalog.Product) ProductResponse {
 		Status:        string(p.Status),
 		SortOrder:     p.SortOrder,
 		Attributes:    p.Attributes,
+		IsSerialized:  p.IsSerialized,
+		Weight:        p.Weight,
+		Volume:        p.Volume,
+		PriceTiers:    toProductPriceTiers(p.PriceTiers),
 		ProfitMargin:  p.GetProfitMargin(),
 		CreatedAt:     p.CreatedAt,
 		UpdatedAt:     p.UpdatedAt,
+		DeletedAt:     p.DeletedAt,
 		Version:       p.Version,
 	}
 }
 
+// toProductPriceTiers converts the price tiers of a product to DTOs
+func toProductPriceTiers(tiers []catalog.PriceTier) []ProductPriceTier {
+	result := make([]ProductPriceTier, len(tiers))
+	for i, tier := range tiers {
+		result[i] = ProductPriceTier{MinQuantity: tier.MinQuantity, UnitPrice: tier.UnitPrice}
+	}
+	return result
+}
+
+// toDomainPriceTiers converts price tier DTOs to the price tiers of a product
+func toDomainPriceTiers(tiers []ProductPriceTier) []catalog.PriceTier {
+	result := make([]catalog.PriceTier, len(tiers))
+	for i, tier := range tiers {
+		result[i] = catalog.PriceTier{MinQuantity: tier.MinQuantity, UnitPrice: tier.UnitPrice}
+	}
+	return result
+}
+
 // ToProductListResponse converts a domain Product to ProductListResponse
 func ToProductListResponse(p *catalog.Product) ProductListResponse {
 	return ProductListResponse{
@@ -132,6 +178,7 @@ func ToProductListResponse(p *catalog.Product) ProductListResponse {
 		Status:        string(p.Status),
 		SortOrder:     p.SortOrder,
 		CreatedAt:     p.CreatedAt,
+		DeletedAt:     p.DeletedAt,
 	}
 }
 
@@ -144,6 +191,44 @@ func ToProductListResponses(products []catalog.Product) []ProductListResponse {
 	return responses
 }
 
+// ProductPriceHistoryResponse represents a product price change in API responses
+type ProductPriceHistoryResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	PriceType   string          `json:"price_type"`
+	OldPrice    decimal.Decimal `json:"old_price"`
+	NewPrice    decimal.Decimal `json:"new_price"`
+	ChangedBy   *uuid.UUID      `json:"changed_by,omitempty"`
+	EffectiveAt time.Time       `json:"effective_at"`
+}
+
+// ProductPriceHistoryFilter represents filter options for product price history
+type ProductPriceHistoryFilter struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// ProductPriceAtResponse represents the prices of a product at a point in time
+type ProductPriceAtResponse struct {
+	ProductID     uuid.UUID       `json:"product_id"`
+	AsOf          time.Time       `json:"as_of"`
+	PurchasePrice decimal.Decimal `json:"purchase_price"`
+	SellingPrice  decimal.Decimal `json:"selling_price"`
+}
+
+// ToProductPriceHistoryResponse converts a domain ProductPriceHistory to ProductPriceHistoryResponse
+func ToProductPriceHistoryResponse(h *catalog.ProductPriceHistory) ProductPriceHistoryResponse {
+	return ProductPriceHistoryResponse{
+		ID:          h.ID,
+		ProductID:   h.ProductID,
+		PriceType:   string(h.PriceType),
+		OldPrice:    h.OldPrice,
+		NewPrice:    h.NewPrice,
+		ChangedBy:   h.ChangedBy,
+		EffectiveAt: h.EffectiveAt,
+	}
+}
+
 // ============================================================================
 // Category DTOs
 // ============================================================================
@@ -266,6 +351,7 @@ type CreateProductUnitRequest struct {
 	UnitCode              string           `json:"unit_code" binding:"required,min=1,max=20"`
 	UnitName              string           `json:"unit_name" binding:"required,min=1,max=50"`
 	ConversionRate        decimal.Decimal  `json:"conversion_rate" binding:"required,gt=0"`
+	ReferenceUnitCode     string           `json:"reference_unit_code" binding:"omitempty,max=20"`
 	DefaultPurchasePrice  *decimal.Decimal `json:"default_purchase_price"`
 	DefaultSellingPrice   *decimal.Decimal `json:"default_selling_price"`
 	IsDefaultPurchaseUnit bool             `json:"is_default_purchase_unit"`
@@ -277,6 +363,7 @@ type CreateProductUnitRequest struct {
 type UpdateProductUnitRequest struct {
 	UnitName              *string          `json:"unit_name" binding:"omitempty,min=1,max=50"`
 	ConversionRate        *decimal.Decimal `json:"conversion_rate" binding:"omitempty,gt=0"`
+	ReferenceUnitCode     *string          `json:"reference_unit_code" binding:"omitempty,max=20"`
 	DefaultPurchasePrice  *decimal.Decimal `json:"default_purchase_price"`
 	DefaultSellingPrice   *decimal.Decimal `json:"default_selling_price"`
 	IsDefaultPurchaseUnit *bool            `json:"is_default_purchase_unit"`
@@ -291,6 +378,7 @@ type ProductUnitResponse struct {
 	UnitCode              string          `json:"unit_code"`
 	UnitName              string          `json:"unit_name"`
 	ConversionRate        decimal.Decimal `json:"conversion_rate"`
+	ReferenceUnitCode     string          `json:"reference_unit_code,omitempty"`
 	DefaultPurchasePrice  decimal.Decimal `json:"default_purchase_price"`
 	DefaultSellingPrice   decimal.Decimal `json:"default_selling_price"`
 	IsDefaultPurchaseUnit bool            `json:"is_default_purchase_unit"`
@@ -323,6 +411,7 @@ func ToProductUnitResponse(u *catalog.ProductUnit) ProductUnitResponse {
 		UnitCode:              u.UnitCode,
 		UnitName:              u.UnitName,
 		ConversionRate:        u.ConversionRate,
+		ReferenceUnitCode:     u.ReferenceUnitCode,
 		DefaultPurchasePrice:  u.DefaultPurchasePrice,
 		DefaultSellingPrice:   u.DefaultSellingPrice,
 		IsDefaultPurchaseUnit: u.IsDefaultPurchaseUnit,
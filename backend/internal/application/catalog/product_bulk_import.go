@@ -0,0 +1,225 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxBulkImportProducts is the maximum number of products accepted by a single bulk import
+	MaxBulkImportProducts = 10000
+	// bulkImportBatchSize is the number of products inserted per database round trip
+	bulkImportBatchSize = 200
+)
+
+// BulkImportProductRow is a single product definition in a bulk import
+type BulkImportProductRow struct {
+	Row        int                  // 1-based position of the product in the import payload
+	Product    CreateProductRequest // Product definition
+	ParseError string               // Set when the row could not be read from the payload
+}
+
+// BulkImportRowError describes why a row of a bulk import was not inserted
+type BulkImportRowError struct {
+	Row   int    `json:"row"`
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+// BulkImportProductsResult reports the outcome of a bulk product import
+type BulkImportProductsResult struct {
+	Total    int                  `json:"total"`
+	Inserted int                  `json:"inserted"`
+	Skipped  int                  `json:"skipped"`
+	Errors   []BulkImportRowError `json:"errors"`
+}
+
+// BulkImport validates each product against the same rules as Create and inserts the valid ones in batches.
+// Rows that fail validation are skipped and reported; they do not prevent other rows from being inserted.
+// Codes or barcodes that appear more than once in the payload are reported on every row that uses them,
+// since there is no way to tell which of the rows was intended.
+func (s *ProductService) BulkImport(ctx context.Context, tenantID uuid.UUID, rows []BulkImportProductRow) (*BulkImportProductsResult, error) {
+	if len(rows) == 0 {
+		return nil, shared.NewDomainError("INVALID_INPUT", "Import contains no products")
+	}
+	if len(rows) > MaxBulkImportProducts {
+		return nil, shared.NewDomainError("INVALID_INPUT",
+			fmt.Sprintf("Import contains %d products, the maximum is %d", len(rows), MaxBulkImportProducts))
+	}
+
+	result := &BulkImportProductsResult{
+		Total:  len(rows),
+		Errors: []BulkImportRowError{},
+	}
+	rowErrors := make(map[int]string)
+	reject := func(row BulkImportProductRow, message string) {
+		if _, ok := rowErrors[row.Row]; !ok {
+			rowErrors[row.Row] = message
+		}
+	}
+
+	for _, row := range rows {
+		if row.ParseError != "" {
+			reject(row, row.ParseError)
+		}
+	}
+
+	// Duplicates within the payload
+	codeRows := make(map[string][]int)
+	barcodeRows := make(map[string][]int)
+	for _, row := range rows {
+		if code := normalizeProductCode(row.Product.Code); code != "" {
+			codeRows[code] = append(codeRows[code], row.Row)
+		}
+		if barcode := strings.TrimSpace(row.Product.Barcode); barcode != "" {
+			barcodeRows[barcode] = append(barcodeRows[barcode], row.Row)
+		}
+	}
+	for _, row := range rows {
+		if others := codeRows[normalizeProductCode(row.Product.Code)]; len(others) > 1 {
+			reject(row, fmt.Sprintf("Duplicate code in import (rows %s)", joinRowNumbers(others)))
+		}
+		if others := barcodeRows[strings.TrimSpace(row.Product.Barcode)]; len(others) > 1 {
+			reject(row, fmt.Sprintf("Duplicate barcode in import (rows %s)", joinRowNumbers(others)))
+		}
+	}
+
+	// Codes and barcodes that already exist in the tenant
+	existingCodes, err := s.findExistingCodes(ctx, tenantID, codeRows)
+	if err != nil {
+		return nil, err
+	}
+	existingBarcodes, err := s.findExistingBarcodes(ctx, tenantID, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	type pendingProduct struct {
+		row     BulkImportProductRow
+		product *catalog.Product
+	}
+	var pending []pendingProduct
+	for _, row := range rows {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		if _, rejected := rowErrors[row.Row]; rejected {
+			continue
+		}
+
+		if existingCodes[normalizeProductCode(row.Product.Code)] {
+			reject(row, "Product with this code already exists")
+			continue
+		}
+		if existingBarcodes[row.Product.Barcode] {
+			reject(row, "Product with this barcode already exists")
+			continue
+		}
+
+		product, err := s.newProduct(ctx, tenantID, row.Product)
+		if err != nil {
+			reject(row, err.Error())
+			continue
+		}
+		pending = append(pending, pendingProduct{row: row, product: product})
+	}
+
+	// Insert valid products in batches
+	for start := 0; start < len(pending); start += bulkImportBatchSize {
+		end := min(start+bulkImportBatchSize, len(pending))
+		batch := make([]*catalog.Product, 0, end-start)
+		for _, p := range pending[start:end] {
+			batch = append(batch, p.product)
+		}
+		if err := s.productRepo.SaveBatch(ctx, batch); err != nil {
+			for _, p := range pending[start:end] {
+				reject(p.row, "Failed to save product: "+err.Error())
+			}
+			continue
+		}
+		result.Inserted += len(batch)
+	}
+
+	for _, row := range rows {
+		if message, rejected := rowErrors[row.Row]; rejected {
+			result.Errors = append(result.Errors, BulkImportRowError{
+				Row:   row.Row,
+				Code:  row.Product.Code,
+				Error: message,
+			})
+		}
+	}
+	result.Skipped = len(result.Errors)
+
+	return result, nil
+}
+
+// findExistingCodes returns which of the given product codes already exist in the tenant
+func (s *ProductService) findExistingCodes(ctx context.Context, tenantID uuid.UUID, codeRows map[string][]int) (map[string]bool, error) {
+	codes := make([]string, 0, len(codeRows))
+	for code := range codeRows {
+		codes = append(codes, code)
+	}
+
+	existing := make(map[string]bool)
+	for start := 0; start < len(codes); start += bulkImportBatchSize {
+		end := min(start+bulkImportBatchSize, len(codes))
+		products, err := s.productRepo.FindByCodes(ctx, tenantID, codes[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range products {
+			existing[normalizeProductCode(p.Code)] = true
+		}
+	}
+	return existing, nil
+}
+
+// findExistingBarcodes returns which of the barcodes of the given rows already exist in the tenant
+func (s *ProductService) findExistingBarcodes(ctx context.Context, tenantID uuid.UUID, rows []BulkImportProductRow) (map[string]bool, error) {
+	seen := make(map[string]bool)
+	var barcodes []string
+	for _, row := range rows {
+		if barcode := row.Product.Barcode; barcode != "" && !seen[barcode] {
+			seen[barcode] = true
+			barcodes = append(barcodes, barcode)
+		}
+	}
+
+	existing := make(map[string]bool)
+	for start := 0; start < len(barcodes); start += bulkImportBatchSize {
+		end := min(start+bulkImportBatchSize, len(barcodes))
+		products, err := s.productRepo.FindByBarcodes(ctx, tenantID, barcodes[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range products {
+			existing[p.Barcode] = true
+		}
+	}
+	return existing, nil
+}
+
+// normalizeProductCode returns a product code in the form it is stored in
+func normalizeProductCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// joinRowNumbers formats row numbers as a comma separated list
+func joinRowNumbers(rows []int) string {
+	sorted := append([]int(nil), rows...)
+	sort.Ints(sorted)
+	parts := make([]string, len(sorted))
+	for i, row := range sorted {
+		parts[i] = fmt.Sprint(row)
+	}
+	return strings.Join(parts, ", ")
+}
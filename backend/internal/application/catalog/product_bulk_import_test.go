@@ -0,0 +1,138 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newBulkImportRow(row int, code string) BulkImportProductRow {
+	return BulkImportProductRow{
+		Row: row,
+		Product: CreateProductRequest{
+			Code: code,
+			Name: "Product " + code,
+			Unit: "pcs",
+		},
+	}
+}
+
+func TestProductService_BulkImport(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+
+	t.Run("inserts valid rows and reports invalid ones", func(t *testing.T) {
+		service, mockProductRepo, _, _ := newTestProductService()
+
+		existing := createTestProduct(tenantID) // TEST-001
+		mockProductRepo.On("FindByCodes", ctx, tenantID, mock.Anything).Return([]catalog.Product{*existing}, nil)
+		mockProductRepo.On("SaveBatch", ctx, mock.MatchedBy(func(products []*catalog.Product) bool {
+			return len(products) == 2 && products[0].Code == "NEW-001" && products[1].Code == "NEW-002"
+		})).Return(nil)
+
+		parseErrorRow := newBulkImportRow(4, "BAD-001")
+		parseErrorRow.ParseError = "Invalid number in column selling_price: abc"
+
+		result, err := service.BulkImport(ctx, tenantID, []BulkImportProductRow{
+			newBulkImportRow(1, "NEW-001"),
+			newBulkImportRow(2, "test-001"),
+			newBulkImportRow(3, "NEW-002"),
+			parseErrorRow,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, 2, result.Inserted)
+		assert.Equal(t, 2, result.Skipped)
+		require.Len(t, result.Errors, 2)
+		assert.Equal(t, BulkImportRowError{Row: 2, Code: "test-001", Error: "Product with this code already exists"}, result.Errors[0])
+		assert.Equal(t, 4, result.Errors[1].Row)
+		assert.Equal(t, parseErrorRow.ParseError, result.Errors[1].Error)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects every row sharing a duplicate code", func(t *testing.T) {
+		service, mockProductRepo, _, _ := newTestProductService()
+
+		mockProductRepo.On("FindByCodes", ctx, tenantID, mock.Anything).Return([]catalog.Product{}, nil)
+		mockProductRepo.On("SaveBatch", ctx, mock.MatchedBy(func(products []*catalog.Product) bool {
+			return len(products) == 1 && products[0].Code == "NEW-002"
+		})).Return(nil)
+
+		result, err := service.BulkImport(ctx, tenantID, []BulkImportProductRow{
+			newBulkImportRow(1, "DUP-001"),
+			newBulkImportRow(2, "NEW-002"),
+			newBulkImportRow(3, "dup-001"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Inserted)
+		assert.Equal(t, 2, result.Skipped)
+		require.Len(t, result.Errors, 2)
+		assert.Equal(t, 1, result.Errors[0].Row)
+		assert.Equal(t, 3, result.Errors[1].Row)
+		assert.Equal(t, "Duplicate code in import (rows 1, 3)", result.Errors[0].Error)
+	})
+
+	t.Run("looks up existing barcodes in one batch", func(t *testing.T) {
+		service, mockProductRepo, _, _ := newTestProductService()
+
+		existing := createTestProduct(tenantID)
+		existing.Barcode = "6901234567890"
+		mockProductRepo.On("FindByCodes", ctx, tenantID, mock.Anything).Return([]catalog.Product{}, nil)
+		mockProductRepo.On("FindByBarcodes", ctx, tenantID, []string{"6901234567890", "6909876543210"}).
+			Return([]catalog.Product{*existing}, nil).Once()
+		mockProductRepo.On("SaveBatch", ctx, mock.MatchedBy(func(products []*catalog.Product) bool {
+			return len(products) == 2 && products[0].Code == "NEW-001" && products[1].Code == "NEW-003"
+		})).Return(nil)
+
+		rows := []BulkImportProductRow{
+			newBulkImportRow(1, "NEW-001"),
+			newBulkImportRow(2, "NEW-002"),
+			newBulkImportRow(3, "NEW-003"),
+		}
+		rows[1].Product.Barcode = "6901234567890"
+		rows[2].Product.Barcode = "6909876543210"
+
+		result, err := service.BulkImport(ctx, tenantID, rows)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Inserted)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, BulkImportRowError{Row: 2, Code: "NEW-002", Error: "Product with this barcode already exists"}, result.Errors[0])
+		mockProductRepo.AssertExpectations(t)
+		mockProductRepo.AssertNotCalled(t, "ExistsByBarcode", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports every row of a batch that fails to save", func(t *testing.T) {
+		service, mockProductRepo, _, _ := newTestProductService()
+
+		mockProductRepo.On("FindByCodes", ctx, tenantID, mock.Anything).Return([]catalog.Product{}, nil)
+		mockProductRepo.On("SaveBatch", ctx, mock.Anything).Return(errors.New("insert failed"))
+
+		result, err := service.BulkImport(ctx, tenantID, []BulkImportProductRow{
+			newBulkImportRow(1, "NEW-001"),
+			newBulkImportRow(2, "NEW-002"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Inserted)
+		assert.Equal(t, 2, result.Skipped)
+	})
+
+	t.Run("rejects an empty import", func(t *testing.T) {
+		service, _, _, _ := newTestProductService()
+
+		_, err := service.BulkImport(ctx, tenantID, nil)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_INPUT", domainErr.Code)
+	})
+}
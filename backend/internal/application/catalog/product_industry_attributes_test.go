@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/plugin"
+	infraPlugin "github.com/erp/backend/internal/infrastructure/plugin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIndustryPlugins is an IndustryPluginGetter backed by a fixed set of plugins
+type stubIndustryPlugins map[string]plugin.IndustryPlugin
+
+func (p stubIndustryPlugins) GetPlugin(name string) (plugin.IndustryPlugin, bool) {
+	industryPlugin, ok := p[name]
+	return industryPlugin, ok
+}
+
+// stubIndustryTenantReader returns a tenant with the configured industry plugin
+type stubIndustryTenantReader struct {
+	industryPlugin string
+}
+
+func (r stubIndustryTenantReader) FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error) {
+	tenant, err := identity.NewTenant("TENANT", "Tenant")
+	if err != nil {
+		return nil, err
+	}
+	tenant.ID = id
+	tenant.Config.IndustryPlugin = r.industryPlugin
+	return tenant, nil
+}
+
+func TestProductService_Create_IndustryAttributes(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	agricultural := infraPlugin.NewAgriculturalPlugin()
+
+	newService := func(industryPlugin string, plugins stubIndustryPlugins) (*ProductService, *MockProductRepository) {
+		service, mockProductRepo, _, _ := newTestProductService()
+		service.SetIndustryPlugins(plugins, stubIndustryTenantReader{industryPlugin: industryPlugin})
+		mockProductRepo.On("ExistsByCode", ctx, tenantID, mock.Anything).Return(false, nil)
+		mockProductRepo.On("Save", ctx, mock.AnythingOfType("*catalog.Product")).Return(nil)
+		return service, mockProductRepo
+	}
+
+	t.Run("rejects a product missing a required attribute", func(t *testing.T) {
+		service, mockProductRepo := newService(agricultural.Name(), stubIndustryPlugins{agricultural.Name(): agricultural})
+
+		_, err := service.Create(ctx, tenantID, CreateProductRequest{
+			Code:       "AGRI-001",
+			Name:       "Compound Fertilizer",
+			Unit:       "bag",
+			Attributes: `{"origin": "Shandong"}`,
+		})
+
+		var attrErr *catalog.MissingRequiredAttributesError
+		require.ErrorAs(t, err, &attrErr)
+		assert.ErrorIs(t, err, catalog.ErrMissingRequiredAttributes)
+		assert.Equal(t, "agricultural", attrErr.Plugin)
+		require.Len(t, attrErr.Violations, 1)
+		assert.Equal(t, "manufacturer", attrErr.Violations[0].Key)
+		assert.Equal(t, catalog.AttributeViolationMissing, attrErr.Violations[0].Reason)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "MISSING_REQUIRED_ATTRIBUTES", domainErr.Code)
+		mockProductRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepts a product with the required attributes", func(t *testing.T) {
+		service, _ := newService(agricultural.Name(), stubIndustryPlugins{agricultural.Name(): agricultural})
+
+		result, err := service.Create(ctx, tenantID, CreateProductRequest{
+			Code:       "AGRI-002",
+			Name:       "Compound Fertilizer",
+			Unit:       "bag",
+			Attributes: `{"manufacturer": "Green Fields Co."}`,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "AGRI-002", result.Code)
+	})
+
+	t.Run("does not check tenants without an industry plugin", func(t *testing.T) {
+		service, _ := newService("", stubIndustryPlugins{agricultural.Name(): agricultural})
+
+		_, err := service.Create(ctx, tenantID, CreateProductRequest{Code: "GEN-001", Name: "Widget", Unit: "pcs"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("does not block creation when the tenant's plugin is not enabled", func(t *testing.T) {
+		service, _ := newService(agricultural.Name(), stubIndustryPlugins{})
+
+		_, err := service.Create(ctx, tenantID, CreateProductRequest{Code: "GEN-002", Name: "Widget", Unit: "pcs"})
+
+		require.NoError(t, err)
+	})
+}
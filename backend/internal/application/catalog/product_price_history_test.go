@@ -0,0 +1,169 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProductPriceHistoryRepository is a mock implementation of ProductPriceHistoryRepository
+type MockProductPriceHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductPriceHistoryRepository) FindByProduct(ctx context.Context, tenantID, productID uuid.UUID, filter shared.Filter) ([]catalog.ProductPriceHistory, error) {
+	args := m.Called(ctx, tenantID, productID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]catalog.ProductPriceHistory), args.Error(1)
+}
+
+func (m *MockProductPriceHistoryRepository) CountByProduct(ctx context.Context, tenantID, productID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, tenantID, productID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductPriceHistoryRepository) FindLatestAt(ctx context.Context, tenantID, productID uuid.UUID, priceType catalog.PriceType, at time.Time) (*catalog.ProductPriceHistory, error) {
+	args := m.Called(ctx, tenantID, productID, priceType, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*catalog.ProductPriceHistory), args.Error(1)
+}
+
+func (m *MockProductPriceHistoryRepository) FindEarliestAfter(ctx context.Context, tenantID, productID uuid.UUID, priceType catalog.PriceType, at time.Time) (*catalog.ProductPriceHistory, error) {
+	args := m.Called(ctx, tenantID, productID, priceType, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*catalog.ProductPriceHistory), args.Error(1)
+}
+
+func (m *MockProductPriceHistoryRepository) CreateBatch(ctx context.Context, entries []*catalog.ProductPriceHistory) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+var _ catalog.ProductPriceHistoryRepository = (*MockProductPriceHistoryRepository)(nil)
+
+func TestProductService_Update_RecordsPriceHistory(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	productID := newTestProductID()
+	userID := uuid.New()
+
+	t.Run("records only the changed price", func(t *testing.T) {
+		service, mockProductRepo, _, _ := newTestProductService()
+		historyRepo := new(MockProductPriceHistoryRepository)
+		service.SetPriceHistoryRepo(historyRepo)
+
+		product := createTestProduct(tenantID)
+		mockProductRepo.On("FindByIDForTenant", ctx, tenantID, productID).Return(product, nil)
+		mockProductRepo.On("SaveWithLock", ctx, product, product.Version).Return(nil)
+		historyRepo.On("CreateBatch", ctx, mock.MatchedBy(func(entries []*catalog.ProductPriceHistory) bool {
+			return len(entries) == 1 &&
+				entries[0].PriceType == catalog.PriceTypeSelling &&
+				entries[0].OldPrice.IsZero() &&
+				entries[0].NewPrice.Equal(decimal.NewFromInt(120)) &&
+				*entries[0].ChangedBy == userID
+		})).Return(nil)
+
+		sellingPrice := decimal.NewFromInt(120)
+		_, err := service.Update(ctx, tenantID, productID, UpdateProductRequest{
+			SellingPrice: &sellingPrice,
+			UpdatedBy:    &userID,
+		})
+
+		require.NoError(t, err)
+		historyRepo.AssertExpectations(t)
+	})
+
+	t.Run("does not record history when prices are unchanged", func(t *testing.T) {
+		service, mockProductRepo, _, _ := newTestProductService()
+		historyRepo := new(MockProductPriceHistoryRepository)
+		service.SetPriceHistoryRepo(historyRepo)
+
+		product := createTestProduct(tenantID)
+		mockProductRepo.On("FindByIDForTenant", ctx, tenantID, productID).Return(product, nil)
+		mockProductRepo.On("SaveWithLock", ctx, product, product.Version).Return(nil)
+
+		newName := "Renamed"
+		_, err := service.Update(ctx, tenantID, productID, UpdateProductRequest{Name: &newName})
+
+		require.NoError(t, err)
+		historyRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails the update when history cannot be written", func(t *testing.T) {
+		service, mockProductRepo, _, _ := newTestProductService()
+		historyRepo := new(MockProductPriceHistoryRepository)
+		service.SetPriceHistoryRepo(historyRepo)
+		service.SetTransactionScope(NewNoOpTransactionScope(mockProductRepo, historyRepo))
+
+		product := createTestProduct(tenantID)
+		mockProductRepo.On("FindByIDForTenant", ctx, tenantID, productID).Return(product, nil)
+		mockProductRepo.On("SaveWithLock", ctx, product, product.Version).Return(nil)
+		historyRepo.On("CreateBatch", ctx, mock.Anything).Return(errors.New("insert failed"))
+
+		purchasePrice := decimal.NewFromInt(80)
+		_, err := service.Update(ctx, tenantID, productID, UpdateProductRequest{PurchasePrice: &purchasePrice})
+
+		require.Error(t, err)
+	})
+}
+
+func TestProductService_GetPriceAt(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	productID := newTestProductID()
+
+	newEntry := func(t *testing.T, priceType catalog.PriceType, oldPrice, newPrice int64) *catalog.ProductPriceHistory {
+		entry, err := catalog.NewProductPriceHistory(tenantID, productID, priceType,
+			decimal.NewFromInt(oldPrice), decimal.NewFromInt(newPrice), nil, time.Now())
+		require.NoError(t, err)
+		return entry
+	}
+
+	service, mockProductRepo, _, _ := newTestProductService()
+	historyRepo := new(MockProductPriceHistoryRepository)
+	service.SetPriceHistoryRepo(historyRepo)
+
+	product := createTestProduct(tenantID)
+	product.PurchasePrice = decimal.NewFromInt(90)
+	product.SellingPrice = decimal.NewFromInt(150)
+	at := product.CreatedAt.Add(time.Hour)
+	mockProductRepo.On("FindByIDForTenant", ctx, tenantID, productID).Return(product, nil)
+
+	// Purchase price changed before the requested time
+	historyRepo.On("FindLatestAt", ctx, tenantID, productID, catalog.PriceTypePurchase, at).
+		Return(newEntry(t, catalog.PriceTypePurchase, 50, 70), nil)
+	// Selling price only changed after the requested time
+	historyRepo.On("FindLatestAt", ctx, tenantID, productID, catalog.PriceTypeSelling, at).
+		Return(nil, shared.ErrNotFound)
+	historyRepo.On("FindEarliestAfter", ctx, tenantID, productID, catalog.PriceTypeSelling, at).
+		Return(newEntry(t, catalog.PriceTypeSelling, 100, 150), nil)
+
+	result, err := service.GetPriceAt(ctx, tenantID, productID, at)
+
+	require.NoError(t, err)
+	assert.True(t, result.PurchasePrice.Equal(decimal.NewFromInt(70)))
+	assert.True(t, result.SellingPrice.Equal(decimal.NewFromInt(100)))
+
+	t.Run("rejects a time before the product existed", func(t *testing.T) {
+		_, err := service.GetPriceAt(ctx, tenantID, productID, product.CreatedAt.Add(-time.Hour))
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Code)
+	})
+}
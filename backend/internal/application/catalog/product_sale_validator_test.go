@@ -62,6 +62,10 @@ func (m *mockProductReader) FindByID(ctx context.Context, id uuid.UUID) (*catalo
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockProductReader) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockProductReader) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Product, error) {
 	return nil, errors.New("not implemented")
 }
@@ -70,6 +74,10 @@ func (m *mockProductReader) FindByBarcode(ctx context.Context, tenantID uuid.UUI
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockProductReader) FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]catalog.Product, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockProductReader) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]catalog.Product, error) {
 	return nil, errors.New("not implemented")
 }
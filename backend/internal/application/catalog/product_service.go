@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/inventory"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/plugin"
 	"github.com/erp/backend/internal/domain/shared/strategy"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/erp/backend/internal/domain/trade"
@@ -23,14 +26,28 @@ type ValidationStrategyGetter interface {
 	GetValidationStrategyOrDefault(name string) strategy.ProductValidationStrategy
 }
 
+// IndustryPluginGetter provides access to the registered industry plugins
+type IndustryPluginGetter interface {
+	GetPlugin(name string) (plugin.IndustryPlugin, bool)
+}
+
+// IndustryTenantReader provides the tenant configuration that selects an industry plugin
+type IndustryTenantReader interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error)
+}
+
 // ProductService handles product-related business operations
 type ProductService struct {
 	productRepo       catalog.ProductRepository
 	categoryRepo      catalog.CategoryRepository
 	strategyRegistry  ValidationStrategyGetter
-	salesOrderRepo    trade.SalesOrderRepository        // Optional: for delete validation
-	purchaseOrderRepo trade.PurchaseOrderRepository     // Optional: for delete validation
-	inventoryRepo     inventory.InventoryItemRepository // Optional: for delete validation
+	salesOrderRepo    trade.SalesOrderRepository            // Optional: for delete validation
+	purchaseOrderRepo trade.PurchaseOrderRepository         // Optional: for delete validation
+	inventoryRepo     inventory.InventoryItemRepository     // Optional: for delete validation
+	priceHistoryRepo  catalog.ProductPriceHistoryRepository // Optional: for price history tracking
+	txScope           TransactionScope                      // Optional: for atomic product and price history writes
+	industryPlugins   IndustryPluginGetter                  // Optional: for industry attribute validation
+	tenantReader      IndustryTenantReader                  // Optional: for industry attribute validation
 }
 
 // NewProductService creates a new ProductService
@@ -61,6 +78,25 @@ func (s *ProductService) SetInventoryRepo(repo inventory.InventoryItemRepository
 	s.inventoryRepo = repo
 }
 
+// SetIndustryPlugins enables validation of product attributes against industry plugins.
+// Each tenant opts in by naming a plugin in its configuration; tenants without one,
+// or naming a plugin that is not registered, are not checked.
+func (s *ProductService) SetIndustryPlugins(plugins IndustryPluginGetter, tenantReader IndustryTenantReader) {
+	s.industryPlugins = plugins
+	s.tenantReader = tenantReader
+}
+
+// SetPriceHistoryRepo sets the price history repository.
+// When set, every price change made through Update is recorded.
+func (s *ProductService) SetPriceHistoryRepo(repo catalog.ProductPriceHistoryRepository) {
+	s.priceHistoryRepo = repo
+}
+
+// SetTransactionScope sets the transaction scope used to save a product together with its price history
+func (s *ProductService) SetTransactionScope(scope TransactionScope) {
+	s.txScope = scope
+}
+
 // Create creates a new product
 func (s *ProductService) Create(ctx context.Context, tenantID uuid.UUID, req CreateProductRequest) (*ProductResponse, error) {
 	// Check if code already exists
@@ -83,9 +119,26 @@ func (s *ProductService) Create(ctx context.Context, tenantID uuid.UUID, req Cre
 		}
 	}
 
+	product, err := s.newProduct(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the product
+	if err := s.productRepo.Save(ctx, product); err != nil {
+		return nil, err
+	}
+
+	response := ToProductResponse(product)
+	return &response, nil
+}
+
+// newProduct builds and validates a new product from a create request.
+// Code and barcode uniqueness are checked by the caller.
+func (s *ProductService) newProduct(ctx context.Context, tenantID uuid.UUID, req CreateProductRequest) (*catalog.Product, error) {
 	// Validate category exists (if provided)
 	if req.CategoryID != nil {
-		_, err = s.categoryRepo.FindByIDForTenant(ctx, tenantID, *req.CategoryID)
+		_, err := s.categoryRepo.FindByIDForTenant(ctx, tenantID, *req.CategoryID)
 		if err != nil {
 			if errors.Is(err, shared.ErrNotFound) {
 				return nil, shared.NewDomainError("INVALID_CATEGORY", "Category not found")
@@ -151,6 +204,25 @@ func (s *ProductService) Create(ctx context.Context, tenantID uuid.UUID, req Cre
 		product.SetSortOrder(*req.SortOrder)
 	}
 
+	// Set serial number tracking
+	if req.IsSerialized {
+		product.SetSerialized(true)
+	}
+
+	// Set weight and volume
+	if req.Weight != nil || req.Volume != nil {
+		if err := product.SetDimensions(decimalOr(req.Weight, product.Weight), decimalOr(req.Volume, product.Volume)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Set quantity breaks
+	if len(req.PriceTiers) > 0 {
+		if err := product.SetPriceTiers(toDomainPriceTiers(req.PriceTiers)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set attributes
 	if req.Attributes != "" {
 		if err := product.SetAttributes(req.Attributes); err != nil {
@@ -164,13 +236,12 @@ func (s *ProductService) Create(ctx context.Context, tenantID uuid.UUID, req Cre
 		return nil, err
 	}
 
-	// Save the product
-	if err := s.productRepo.Save(ctx, product); err != nil {
+	// Validate attributes required by the tenant's industry plugin
+	if err := s.validateIndustryAttributes(ctx, product, categoryCode); err != nil {
 		return nil, err
 	}
 
-	response := ToProductResponse(product)
-	return &response, nil
+	return product, nil
 }
 
 // GetByID retrieves a product by ID
@@ -240,6 +311,9 @@ func (s *ProductService) List(ctx context.Context, tenantID uuid.UUID, filter Pr
 	if filter.HasBarcode != nil {
 		domainFilter.Filters["has_barcode"] = *filter.HasBarcode
 	}
+	if filter.IncludeDeleted {
+		domainFilter.Filters["include_deleted"] = true
+	}
 
 	// Get products
 	products, err := s.productRepo.FindAllForTenant(ctx, tenantID, domainFilter)
@@ -263,6 +337,10 @@ func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UU
 	if err != nil {
 		return nil, err
 	}
+	if err := shared.CheckVersion("product", req.Version, product.Version); err != nil {
+		return nil, err
+	}
+	loadedVersion := product.Version
 
 	// Update name and description
 	if req.Name != nil {
@@ -310,6 +388,8 @@ func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UU
 	}
 
 	// Update prices
+	oldPurchasePrice := product.PurchasePrice
+	oldSellingPrice := product.SellingPrice
 	if req.PurchasePrice != nil || req.SellingPrice != nil {
 		purchasePrice := product.PurchasePrice
 		sellingPrice := product.SellingPrice
@@ -338,6 +418,25 @@ func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UU
 		product.SetSortOrder(*req.SortOrder)
 	}
 
+	// Update serial number tracking
+	if req.IsSerialized != nil {
+		product.SetSerialized(*req.IsSerialized)
+	}
+
+	// Update weight and volume
+	if req.Weight != nil || req.Volume != nil {
+		if err := product.SetDimensions(decimalOr(req.Weight, product.Weight), decimalOr(req.Volume, product.Volume)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update quantity breaks
+	if req.PriceTiers != nil {
+		if err := product.SetPriceTiers(toDomainPriceTiers(*req.PriceTiers)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update attributes
 	if req.Attributes != nil {
 		if err := product.SetAttributes(*req.Attributes); err != nil {
@@ -356,8 +455,12 @@ func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UU
 		return nil, err
 	}
 
-	// Save the product
-	if err := s.productRepo.Save(ctx, product); err != nil {
+	// Save the product together with its price changes, failing if it changed since it was loaded
+	priceChanges, err := catalog.NewPriceHistoryEntries(product, oldPurchasePrice, oldSellingPrice, req.UpdatedBy, product.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveWithPriceHistory(ctx, product, loadedVersion, priceChanges); err != nil {
 		return nil, err
 	}
 
@@ -365,6 +468,111 @@ func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UU
 	return &response, nil
 }
 
+// saveWithPriceHistory saves the product with a version check and records its price changes in the same transaction
+func (s *ProductService) saveWithPriceHistory(ctx context.Context, product *catalog.Product, expectedVersion int, priceChanges []*catalog.ProductPriceHistory) error {
+	if len(priceChanges) == 0 || s.priceHistoryRepo == nil {
+		return s.productRepo.SaveWithLock(ctx, product, expectedVersion)
+	}
+
+	scope := s.txScope
+	if scope == nil {
+		scope = NewNoOpTransactionScope(s.productRepo, s.priceHistoryRepo)
+	}
+	return scope.Execute(ctx, func(repos TransactionalRepositories) error {
+		if err := repos.ProductRepo().SaveWithLock(ctx, product, expectedVersion); err != nil {
+			return err
+		}
+		return repos.PriceHistoryRepo().CreateBatch(ctx, priceChanges)
+	})
+}
+
+// GetPriceHistory retrieves the price changes of a product, most recent first
+func (s *ProductService) GetPriceHistory(ctx context.Context, tenantID, productID uuid.UUID, filter ProductPriceHistoryFilter) ([]ProductPriceHistoryResponse, int64, error) {
+	if s.priceHistoryRepo == nil {
+		return nil, 0, shared.NewDomainError("PRICE_HISTORY_UNAVAILABLE", "Product price history is not configured")
+	}
+	if _, err := s.productRepo.FindByIDForTenant(ctx, tenantID, productID); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+
+	entries, err := s.priceHistoryRepo.FindByProduct(ctx, tenantID, productID, shared.Filter{
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.priceHistoryRepo.CountByProduct(ctx, tenantID, productID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]ProductPriceHistoryResponse, len(entries))
+	for i := range entries {
+		responses[i] = ToProductPriceHistoryResponse(&entries[i])
+	}
+	return responses, total, nil
+}
+
+// GetPriceAt retrieves the purchase and selling price of a product as they were at the given time
+func (s *ProductService) GetPriceAt(ctx context.Context, tenantID, productID uuid.UUID, at time.Time) (*ProductPriceAtResponse, error) {
+	if s.priceHistoryRepo == nil {
+		return nil, shared.NewDomainError("PRICE_HISTORY_UNAVAILABLE", "Product price history is not configured")
+	}
+	product, err := s.productRepo.FindByIDForTenant(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if at.Before(product.CreatedAt) {
+		return nil, shared.NewDomainError("NOT_FOUND", "Product did not exist at the requested time")
+	}
+
+	purchasePrice, err := s.priceAt(ctx, tenantID, productID, catalog.PriceTypePurchase, at, product.PurchasePrice)
+	if err != nil {
+		return nil, err
+	}
+	sellingPrice, err := s.priceAt(ctx, tenantID, productID, catalog.PriceTypeSelling, at, product.SellingPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProductPriceAtResponse{
+		ProductID:     productID,
+		AsOf:          at,
+		PurchasePrice: purchasePrice,
+		SellingPrice:  sellingPrice,
+	}, nil
+}
+
+// priceAt resolves a price at the given time from the price history:
+// the new price of the last change before that time, otherwise the old price of the first change after it,
+// otherwise the current price if the price never changed.
+func (s *ProductService) priceAt(ctx context.Context, tenantID, productID uuid.UUID, priceType catalog.PriceType, at time.Time, current decimal.Decimal) (decimal.Decimal, error) {
+	latest, err := s.priceHistoryRepo.FindLatestAt(ctx, tenantID, productID, priceType, at)
+	if err == nil {
+		return latest.NewPrice, nil
+	}
+	if !errors.Is(err, shared.ErrNotFound) {
+		return decimal.Zero, err
+	}
+
+	next, err := s.priceHistoryRepo.FindEarliestAfter(ctx, tenantID, productID, priceType, at)
+	if err == nil {
+		return next.OldPrice, nil
+	}
+	if !errors.Is(err, shared.ErrNotFound) {
+		return decimal.Zero, err
+	}
+	return current, nil
+}
+
 // UpdateCode updates a product's code
 func (s *ProductService) UpdateCode(ctx context.Context, tenantID, productID uuid.UUID, newCode string) (*ProductResponse, error) {
 	// Get existing product
@@ -456,6 +664,32 @@ func (s *ProductService) Delete(ctx context.Context, tenantID, productID uuid.UU
 	return s.productRepo.DeleteForTenant(ctx, tenantID, productID)
 }
 
+// Restore restores a soft-deleted product.
+// It fails if another product has taken the code since the product was deleted.
+func (s *ProductService) Restore(ctx context.Context, tenantID, productID uuid.UUID) (*ProductResponse, error) {
+	product, err := s.productRepo.FindDeletedByIDForTenant(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.productRepo.ExistsByCode(ctx, tenantID, product.Code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, shared.NewDomainError("ALREADY_EXISTS",
+			fmt.Sprintf("Cannot restore product: code %s is now used by another product", product.Code))
+	}
+
+	if err := s.productRepo.RestoreForTenant(ctx, tenantID, productID); err != nil {
+		return nil, err
+	}
+	product.DeletedAt = nil
+
+	response := ToProductResponse(product)
+	return &response, nil
+}
+
 // Activate activates a product
 func (s *ProductService) Activate(ctx context.Context, tenantID, productID uuid.UUID) (*ProductResponse, error) {
 	product, err := s.productRepo.FindByIDForTenant(ctx, tenantID, productID)
@@ -593,6 +827,32 @@ func (s *ProductService) validateProduct(
 	return nil
 }
 
+// validateIndustryAttributes checks the product's attributes against the attribute definitions
+// of the industry plugin enabled for the tenant.
+// Returns a *catalog.MissingRequiredAttributesError if any attribute is missing or invalid.
+func (s *ProductService) validateIndustryAttributes(ctx context.Context, product *catalog.Product, categoryCode string) error {
+	if s.industryPlugins == nil || s.tenantReader == nil {
+		return nil
+	}
+
+	tenant, err := s.tenantReader.FindByID(ctx, product.TenantID)
+	if err != nil {
+		return err
+	}
+	pluginName := tenant.Config.IndustryPlugin
+	if pluginName == "" {
+		return nil
+	}
+
+	industryPlugin, ok := s.industryPlugins.GetPlugin(pluginName)
+	if !ok {
+		// The plugin is not registered, so its requirements are not enforced
+		return nil
+	}
+
+	return catalog.ValidateIndustryAttributes(pluginName, product, categoryCode, industryPlugin.GetRequiredProductAttributes())
+}
+
 // getValidationStrategyName determines which validation strategy to use
 // based on the category code
 func (s *ProductService) getValidationStrategyName(categoryCode string) string {
@@ -687,3 +947,11 @@ func (s *ProductService) getCategoryCode(ctx context.Context, tenantID uuid.UUID
 
 	return category.Code
 }
+
+// decimalOr returns the requested value, or current when the request leaves it unset
+func decimalOr(requested *decimal.Decimal, current decimal.Decimal) decimal.Decimal {
+	if requested != nil {
+		return *requested
+	}
+	return current
+}
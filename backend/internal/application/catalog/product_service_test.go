@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/erp/backend/internal/domain/catalog"
 	"github.com/erp/backend/internal/domain/inventory"
@@ -50,6 +51,14 @@ func (m *MockProductRepository) FindByIDForTenant(ctx context.Context, tenantID,
 	return args.Get(0).(*catalog.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*catalog.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Product, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -101,6 +110,11 @@ func (m *MockProductRepository) FindByIDs(ctx context.Context, tenantID uuid.UUI
 	return args.Get(0).([]catalog.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]catalog.Product, error) {
+	args := m.Called(ctx, tenantID, barcodes)
+	return args.Get(0).([]catalog.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]catalog.Product, error) {
 	args := m.Called(ctx, tenantID, codes)
 	return args.Get(0).([]catalog.Product), args.Error(1)
@@ -111,6 +125,11 @@ func (m *MockProductRepository) Save(ctx context.Context, product *catalog.Produ
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) SaveWithLock(ctx context.Context, product *catalog.Product, expectedVersion int) error {
+	args := m.Called(ctx, product, expectedVersion)
+	return args.Error(0)
+}
+
 func (m *MockProductRepository) SaveBatch(ctx context.Context, products []*catalog.Product) error {
 	args := m.Called(ctx, products)
 	return args.Error(0)
@@ -126,6 +145,11 @@ func (m *MockProductRepository) DeleteForTenant(ctx context.Context, tenantID, i
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 func (m *MockProductRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
@@ -250,8 +274,8 @@ func (m *MockCategoryRepository) HasProducts(ctx context.Context, tenantID, cate
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, newPath string, levelDelta int) error {
-	args := m.Called(ctx, tenantID, categoryID, newPath, levelDelta)
+func (m *MockCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, parentID *uuid.UUID, newPath string, levelDelta int) error {
+	args := m.Called(ctx, tenantID, categoryID, parentID, newPath, levelDelta)
 	return args.Error(0)
 }
 
@@ -384,6 +408,14 @@ func (m *MockSalesOrderRepositoryForProduct) ExistsByProduct(ctx context.Context
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockSalesOrderRepositoryForProduct) FindExpiredQuotes(ctx context.Context, expiresBefore time.Time) ([]trade.SalesOrder, error) {
+	args := m.Called(ctx, expiresBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]trade.SalesOrder), args.Error(1)
+}
+
 var _ trade.SalesOrderRepository = (*MockSalesOrderRepositoryForProduct)(nil)
 
 // MockPurchaseOrderRepositoryForProduct is a mock for PurchaseOrderRepository used in product delete validation
@@ -954,7 +986,7 @@ func TestProductService_Update_Success(t *testing.T) {
 	}
 
 	mockProductRepo.On("FindByIDForTenant", ctx, tenantID, productID).Return(product, nil)
-	mockProductRepo.On("Save", ctx, mock.AnythingOfType("*catalog.Product")).Return(nil)
+	mockProductRepo.On("SaveWithLock", ctx, mock.AnythingOfType("*catalog.Product"), 1).Return(nil)
 
 	result, err := service.Update(ctx, tenantID, productID, req)
 
@@ -965,6 +997,33 @@ func TestProductService_Update_Success(t *testing.T) {
 	mockProductRepo.AssertExpectations(t)
 }
 
+func TestProductService_Update_StaleVersion(t *testing.T) {
+	service, mockProductRepo, _, _ := newTestProductService()
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	productID := newTestProductID()
+	product := createTestProduct(tenantID)
+	loadedVersion := product.Version
+
+	mockProductRepo.On("FindByIDForTenant", ctx, tenantID, productID).Return(product, nil)
+	mockProductRepo.On("SaveWithLock", ctx, product, loadedVersion).Return(nil).Once()
+
+	firstName := "First Edit"
+	_, err := service.Update(ctx, tenantID, productID, UpdateProductRequest{Name: &firstName, Version: &loadedVersion})
+	assert.NoError(t, err)
+
+	secondName := "Second Edit"
+	result, err := service.Update(ctx, tenantID, productID, UpdateProductRequest{Name: &secondName, Version: &loadedVersion})
+
+	assert.Nil(t, result)
+	var conflictErr *shared.VersionConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, product.Version, conflictErr.CurrentVersion)
+	assert.Equal(t, "First Edit", product.Name)
+	mockProductRepo.AssertExpectations(t)
+}
+
 func TestProductService_Update_NotFound(t *testing.T) {
 	service, mockProductRepo, _, _ := newTestProductService()
 
@@ -1065,6 +1124,49 @@ func TestProductService_Delete_NotFound(t *testing.T) {
 	mockProductRepo.AssertExpectations(t)
 }
 
+// Tests for ProductService.Restore
+func TestProductService_Restore_Success(t *testing.T) {
+	service, mockProductRepo, _, _ := newTestProductService()
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	productID := newTestProductID()
+	product := createTestProduct(tenantID)
+	deletedAt := time.Now()
+	product.DeletedAt = &deletedAt
+
+	mockProductRepo.On("FindDeletedByIDForTenant", ctx, tenantID, productID).Return(product, nil)
+	mockProductRepo.On("ExistsByCode", ctx, tenantID, product.Code).Return(false, nil)
+	mockProductRepo.On("RestoreForTenant", ctx, tenantID, productID).Return(nil)
+
+	result, err := service.Restore(ctx, tenantID, productID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, product.Code, result.Code)
+	assert.Nil(t, result.DeletedAt)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestProductService_Restore_CodeReused(t *testing.T) {
+	service, mockProductRepo, _, _ := newTestProductService()
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	productID := newTestProductID()
+	product := createTestProduct(tenantID)
+
+	mockProductRepo.On("FindDeletedByIDForTenant", ctx, tenantID, productID).Return(product, nil)
+	mockProductRepo.On("ExistsByCode", ctx, tenantID, product.Code).Return(true, nil)
+
+	result, err := service.Restore(ctx, tenantID, productID)
+
+	assert.Nil(t, result)
+	var domainErr *shared.DomainError
+	assert.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ALREADY_EXISTS", domainErr.Code)
+	mockProductRepo.AssertNotCalled(t, "RestoreForTenant", ctx, tenantID, productID)
+}
+
 // Tests for ProductService.Activate
 func TestProductService_Activate_Success(t *testing.T) {
 	service, mockProductRepo, _, _ := newTestProductService()
@@ -1370,7 +1472,7 @@ func TestProductService_Update_WithValidationStrategy_Success(t *testing.T) {
 	}
 
 	mockProductRepo.On("FindByIDForTenant", ctx, tenantID, productID).Return(product, nil)
-	mockProductRepo.On("Save", ctx, mock.AnythingOfType("*catalog.Product")).Return(nil)
+	mockProductRepo.On("SaveWithLock", ctx, mock.AnythingOfType("*catalog.Product"), 1).Return(nil)
 
 	result, err := service.Update(ctx, tenantID, productID, req)
 
@@ -60,6 +60,15 @@ func (s *ProductUnitService) Create(ctx context.Context, tenantID, productID uui
 		return nil, err
 	}
 
+	// Set the unit the conversion rate is relative to
+	referenceUnitCode, err := s.resolveReferenceUnit(ctx, tenantID, product, req.ReferenceUnitCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := unit.SetReferenceUnit(referenceUnitCode); err != nil {
+		return nil, err
+	}
+
 	// Set prices if provided
 	purchasePrice := decimal.Zero
 	sellingPrice := decimal.Zero
@@ -159,6 +168,21 @@ func (s *ProductUnitService) Update(ctx context.Context, tenantID, id uuid.UUID,
 		}
 	}
 
+	// Update the reference unit if provided
+	if req.ReferenceUnitCode != nil {
+		product, err := s.productReader.FindByIDForTenant(ctx, tenantID, unit.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		referenceUnitCode, err := s.resolveReferenceUnit(ctx, tenantID, product, *req.ReferenceUnitCode)
+		if err != nil {
+			return nil, err
+		}
+		if err := unit.SetReferenceUnit(referenceUnitCode); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update prices if provided
 	if req.DefaultPurchasePrice != nil || req.DefaultSellingPrice != nil {
 		purchasePrice := unit.DefaultPurchasePrice
@@ -232,41 +256,25 @@ func (s *ProductUnitService) ConvertQuantity(ctx context.Context, tenantID, prod
 		return nil, err
 	}
 
-	var fromRate, toRate decimal.Decimal
-
-	// Get conversion rate for from unit
-	if req.FromUnitCode == product.Unit {
-		fromRate = decimal.NewFromInt(1)
-	} else {
-		fromUnit, err := s.productUnitRepo.FindByProductIDAndCode(ctx, tenantID, productID, req.FromUnitCode)
-		if err != nil {
-			if errors.Is(err, shared.ErrNotFound) {
-				return nil, shared.NewDomainError("UNIT_NOT_FOUND", "From unit not found for this product")
-			}
-			return nil, err
-		}
-		fromRate = fromUnit.ConversionRate
+	units, err := s.productUnitRepo.FindByProductID(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get conversion rate for to unit
-	if req.ToUnitCode == product.Unit {
-		toRate = decimal.NewFromInt(1)
-	} else {
-		toUnit, err := s.productUnitRepo.FindByProductIDAndCode(ctx, tenantID, productID, req.ToUnitCode)
-		if err != nil {
-			if errors.Is(err, shared.ErrNotFound) {
-				return nil, shared.NewDomainError("UNIT_NOT_FOUND", "To unit not found for this product")
-			}
-			return nil, err
-		}
-		toRate = toUnit.ConversionRate
+	graph := catalog.NewUnitConversionGraph(product.Unit, units)
+	if !graph.HasUnit(req.FromUnitCode) {
+		return nil, shared.NewDomainError("UNIT_NOT_FOUND", "From unit not found for this product")
+	}
+	if !graph.HasUnit(req.ToUnitCode) {
+		return nil, shared.NewDomainError("UNIT_NOT_FOUND", "To unit not found for this product")
 	}
 
-	// Convert: first to base unit, then to target unit
-	// baseQty = fromQty * fromRate
-	// toQty = baseQty / toRate
-	baseQty := req.Quantity.Mul(fromRate)
-	toQty := baseQty.Div(toRate).Round(4)
+	// Convert through any intermediate units (e.g., box -> case -> pallet)
+	toQty, err := graph.Convert(req.Quantity, req.FromUnitCode, req.ToUnitCode)
+	if err != nil {
+		return nil, err
+	}
+	toQty = toQty.Round(4)
 
 	return &ConvertUnitResponse{
 		FromQuantity: req.Quantity,
@@ -276,6 +284,23 @@ func (s *ProductUnitService) ConvertQuantity(ctx context.Context, tenantID, prod
 	}, nil
 }
 
+// resolveReferenceUnit checks that a reference unit exists for the product.
+// The base unit is stored as an empty code so that rates relative to it follow base unit renames.
+func (s *ProductUnitService) resolveReferenceUnit(ctx context.Context, tenantID uuid.UUID, product *catalog.Product, unitCode string) (string, error) {
+	if unitCode == "" || unitCode == product.Unit {
+		return "", nil
+	}
+
+	exists, err := s.productUnitRepo.ExistsByProductIDAndCode(ctx, tenantID, product.ID, unitCode)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", shared.NewDomainError("INVALID_REFERENCE_UNIT", "Reference unit not found for this product")
+	}
+	return unitCode, nil
+}
+
 // GetDefaultPurchaseUnit gets the default purchase unit for a product
 func (s *ProductUnitService) GetDefaultPurchaseUnit(ctx context.Context, tenantID, productID uuid.UUID) (*ProductUnitResponse, error) {
 	unit, err := s.productUnitRepo.FindDefaultPurchaseUnit(ctx, tenantID, productID)
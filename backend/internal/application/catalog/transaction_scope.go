@@ -0,0 +1,63 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/catalog"
+)
+
+// TransactionScope provides transactional access to catalog repositories.
+// When a function is executed within a transaction scope, all repository operations
+// will be part of the same database transaction and will be committed or rolled back atomically.
+type TransactionScope interface {
+	// Execute runs the given function within a database transaction.
+	// If the function returns an error, the transaction is rolled back.
+	// If the function succeeds, the transaction is committed.
+	Execute(ctx context.Context, fn func(repos TransactionalRepositories) error) error
+}
+
+// TransactionalRepositories provides access to catalog repositories within a transaction.
+// All repositories returned share the same underlying database transaction.
+type TransactionalRepositories interface {
+	// ProductRepo returns the product repository scoped to the current transaction
+	ProductRepo() catalog.ProductRepository
+	// PriceHistoryRepo returns the product price history repository scoped to the current transaction
+	PriceHistoryRepo() catalog.ProductPriceHistoryRepository
+}
+
+// NoOpTransactionScope is a transaction scope that doesn't actually use transactions.
+// This is useful for testing or when transaction support is not required.
+type NoOpTransactionScope struct {
+	productRepo      catalog.ProductRepository
+	priceHistoryRepo catalog.ProductPriceHistoryRepository
+}
+
+// NewNoOpTransactionScope creates a NoOpTransactionScope with the given repositories.
+func NewNoOpTransactionScope(
+	productRepo catalog.ProductRepository,
+	priceHistoryRepo catalog.ProductPriceHistoryRepository,
+) *NoOpTransactionScope {
+	return &NoOpTransactionScope{
+		productRepo:      productRepo,
+		priceHistoryRepo: priceHistoryRepo,
+	}
+}
+
+// Execute runs the function without a real transaction (for testing/compatibility).
+func (s *NoOpTransactionScope) Execute(_ context.Context, fn func(repos TransactionalRepositories) error) error {
+	return fn(s)
+}
+
+// ProductRepo returns the product repository.
+func (s *NoOpTransactionScope) ProductRepo() catalog.ProductRepository {
+	return s.productRepo
+}
+
+// PriceHistoryRepo returns the product price history repository.
+func (s *NoOpTransactionScope) PriceHistoryRepo() catalog.ProductPriceHistoryRepository {
+	return s.priceHistoryRepo
+}
+
+// Ensure NoOpTransactionScope implements both interfaces
+var _ TransactionScope = (*NoOpTransactionScope)(nil)
+var _ TransactionalRepositories = (*NoOpTransactionScope)(nil)
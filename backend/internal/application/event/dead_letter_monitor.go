@@ -0,0 +1,264 @@
+package event
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/telemetry"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// OutboxHealthStatus summarizes the state of the dead letter queue for dashboards
+type OutboxHealthStatus string
+
+const (
+	OutboxHealthGreen OutboxHealthStatus = "green" // No dead letters
+	OutboxHealthAmber OutboxHealthStatus = "amber" // Dead letters within the thresholds
+	OutboxHealthRed   OutboxHealthStatus = "red"   // Too many dead letters, or one is too old
+)
+
+const (
+	// deadLetterSampleSize is how many of the oldest dead letters are inspected for sample event types
+	deadLetterSampleSize = 20
+	// maxSampleEventTypes caps the distinct event types reported
+	maxSampleEventTypes = 5
+)
+
+// DeadLetterThresholds defines when the dead letter queue turns red
+type DeadLetterThresholds struct {
+	MaxCount int64         // Red when more dead letters than this; 0 disables the count check
+	MaxAge   time.Duration // Red when the oldest dead letter is older than this; 0 disables the age check
+}
+
+// DefaultDeadLetterThresholds returns the thresholds used when none are configured
+func DefaultDeadLetterThresholds() DeadLetterThresholds {
+	return DeadLetterThresholds{
+		MaxCount: 10,
+		MaxAge:   24 * time.Hour,
+	}
+}
+
+// DeadLetterAlert is the payload sent when the dead letter queue turns red
+type DeadLetterAlert struct {
+	Status           string    `json:"status"`
+	DeadCount        int64     `json:"dead_count"`
+	Threshold        int64     `json:"threshold"`
+	OldestAgeSeconds int64     `json:"oldest_age_seconds"`
+	MaxAgeSeconds    int64     `json:"max_age_seconds"`
+	SampleEventTypes []string  `json:"sample_event_types"`
+	CheckedAt        time.Time `json:"checked_at"`
+}
+
+// DeadLetterNotifier sends dead letter alerts
+// Implementations can support different channels (webhook, email, etc.)
+type DeadLetterNotifier interface {
+	// NotifyDeadLetters sends an alert about the dead letter queue
+	NotifyDeadLetters(ctx context.Context, alert DeadLetterAlert) error
+}
+
+// OutboxHealthDTO represents the health of the dead letter queue
+type OutboxHealthDTO struct {
+	Status           string     `json:"status"`
+	DeadCount        int64      `json:"dead_count"`
+	OldestDeadAt     *time.Time `json:"oldest_dead_at,omitempty"`
+	OldestAgeSeconds int64      `json:"oldest_age_seconds"`
+	SampleEventTypes []string   `json:"sample_event_types"`
+	Threshold        int64      `json:"threshold"`
+	MaxAgeSeconds    int64      `json:"max_age_seconds"`
+	CheckedAt        time.Time  `json:"checked_at"`
+}
+
+// deadLetterMonitor holds the dead letter alerting state of an OutboxService
+type deadLetterMonitor struct {
+	thresholds DeadLetterThresholds
+	notifier   DeadLetterNotifier
+	metrics    *deadLetterMetrics
+
+	mu         sync.Mutex
+	lastStatus OutboxHealthStatus
+}
+
+// deadLetterMetrics holds OpenTelemetry metrics for the dead letter queue
+type deadLetterMetrics struct {
+	count     *telemetry.Gauge
+	oldestAge *telemetry.Gauge
+	alerts    *telemetry.Counter
+}
+
+func newDeadLetterMonitor(thresholds DeadLetterThresholds) *deadLetterMonitor {
+	return &deadLetterMonitor{
+		thresholds: thresholds,
+		lastStatus: OutboxHealthGreen,
+	}
+}
+
+// WithDeadLetterThresholds sets when the dead letter queue turns red.
+// Without it DefaultDeadLetterThresholds is used.
+func WithDeadLetterThresholds(thresholds DeadLetterThresholds) OutboxServiceOption {
+	return func(s *OutboxService) {
+		s.monitor.thresholds = thresholds
+	}
+}
+
+// WithDeadLetterNotifier sets the notifier alerted when the dead letter queue turns red
+func WithDeadLetterNotifier(notifier DeadLetterNotifier) OutboxServiceOption {
+	return func(s *OutboxService) {
+		s.monitor.notifier = notifier
+	}
+}
+
+// WithDeadLetterMetrics records the dead letter count, oldest age and alerts on each check
+func WithDeadLetterMetrics(meter metric.Meter) OutboxServiceOption {
+	return func(s *OutboxService) {
+		metrics, err := newDeadLetterMetrics(meter)
+		if err != nil {
+			s.logger.Warn("Failed to create dead letter metrics, continuing without metrics", zap.Error(err))
+			return
+		}
+		s.monitor.metrics = metrics
+	}
+}
+
+func newDeadLetterMetrics(meter metric.Meter) (*deadLetterMetrics, error) {
+	count, err := telemetry.NewGauge(meter,
+		"outbox_dead_letter_count",
+		"Current number of outbox entries in the dead letter queue",
+		"{entry}",
+	)
+	if err != nil {
+		return nil, err
+	}
+	oldestAge, err := telemetry.NewGauge(meter,
+		"outbox_dead_letter_oldest_age",
+		"Age of the oldest outbox entry in the dead letter queue",
+		"s",
+	)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := telemetry.NewCounter(meter,
+		"outbox_dead_letter_alerts_total",
+		"Total number of dead letter alerts raised",
+		"{alert}",
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetterMetrics{count: count, oldestAge: oldestAge, alerts: alerts}, nil
+}
+
+// GetHealth returns green without dead letters, red when the dead letter count or the age
+// of the oldest dead letter exceeds its threshold, and amber otherwise
+func (s *OutboxService) GetHealth(ctx context.Context) (*OutboxHealthDTO, error) {
+	counts, err := s.repo.CountByStatus(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count outbox entries", zap.Error(err))
+		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to get outbox health")
+	}
+
+	thresholds := s.monitor.thresholds
+	health := &OutboxHealthDTO{
+		Status:           string(OutboxHealthGreen),
+		DeadCount:        counts[shared.OutboxStatusDead],
+		SampleEventTypes: make([]string, 0),
+		Threshold:        thresholds.MaxCount,
+		MaxAgeSeconds:    int64(thresholds.MaxAge.Seconds()),
+		CheckedAt:        time.Now(),
+	}
+	if health.DeadCount == 0 {
+		return health, nil
+	}
+
+	oldest, err := s.repo.FindOldestDead(ctx, deadLetterSampleSize)
+	if err != nil {
+		s.logger.Error("Failed to find oldest dead letter entries", zap.Error(err))
+		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to get outbox health")
+	}
+	var oldestAge time.Duration
+	if len(oldest) > 0 {
+		health.OldestDeadAt = &oldest[0].CreatedAt
+		oldestAge = health.CheckedAt.Sub(oldest[0].CreatedAt)
+		health.OldestAgeSeconds = int64(oldestAge.Seconds())
+	}
+	for _, entry := range oldest {
+		if len(health.SampleEventTypes) == maxSampleEventTypes {
+			break
+		}
+		if !slices.Contains(health.SampleEventTypes, entry.EventType) {
+			health.SampleEventTypes = append(health.SampleEventTypes, entry.EventType)
+		}
+	}
+
+	health.Status = string(OutboxHealthAmber)
+	if (thresholds.MaxCount > 0 && health.DeadCount > thresholds.MaxCount) ||
+		(thresholds.MaxAge > 0 && oldestAge > thresholds.MaxAge) {
+		health.Status = string(OutboxHealthRed)
+	}
+	return health, nil
+}
+
+// CheckDeadLetters records the dead letter metrics and alerts the notifier when the
+// dead letter queue turns red. While it stays red no further alerts are sent; a failed
+// alert is retried on the next check.
+func (s *OutboxService) CheckDeadLetters(ctx context.Context) (*OutboxHealthDTO, error) {
+	health, err := s.GetHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if metrics := s.monitor.metrics; metrics != nil {
+		metrics.count.Record(ctx, health.DeadCount)
+		metrics.oldestAge.Record(ctx, health.OldestAgeSeconds)
+	}
+
+	s.monitor.mu.Lock()
+	defer s.monitor.mu.Unlock()
+
+	status, previous := OutboxHealthStatus(health.Status), s.monitor.lastStatus
+	if status != OutboxHealthRed {
+		if previous == OutboxHealthRed {
+			s.logger.Info("Dead letter queue recovered",
+				zap.String("status", health.Status),
+				zap.Int64("dead_count", health.DeadCount),
+			)
+		}
+		s.monitor.lastStatus = status
+		return health, nil
+	}
+	if previous == OutboxHealthRed {
+		return health, nil
+	}
+
+	s.logger.Warn("Dead letter queue exceeded its thresholds",
+		zap.Int64("dead_count", health.DeadCount),
+		zap.Int64("threshold", health.Threshold),
+		zap.Int64("oldest_age_seconds", health.OldestAgeSeconds),
+		zap.Int64("max_age_seconds", health.MaxAgeSeconds),
+		zap.Strings("sample_event_types", health.SampleEventTypes),
+	)
+	if metrics := s.monitor.metrics; metrics != nil {
+		metrics.alerts.Inc(ctx)
+	}
+
+	if s.monitor.notifier != nil {
+		alert := DeadLetterAlert{
+			Status:           health.Status,
+			DeadCount:        health.DeadCount,
+			Threshold:        health.Threshold,
+			OldestAgeSeconds: health.OldestAgeSeconds,
+			MaxAgeSeconds:    health.MaxAgeSeconds,
+			SampleEventTypes: health.SampleEventTypes,
+			CheckedAt:        health.CheckedAt,
+		}
+		if err := s.monitor.notifier.NotifyDeadLetters(ctx, alert); err != nil {
+			s.logger.Error("Failed to send dead letter alert", zap.Error(err))
+			return health, nil
+		}
+	}
+	s.monitor.lastStatus = status
+	return health, nil
+}
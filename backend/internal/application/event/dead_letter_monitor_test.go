@@ -0,0 +1,155 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingNotifier records the dead letter alerts it receives
+type recordingNotifier struct {
+	alerts []DeadLetterAlert
+	err    error
+}
+
+func (n *recordingNotifier) NotifyDeadLetters(ctx context.Context, alert DeadLetterAlert) error {
+	n.alerts = append(n.alerts, alert)
+	return n.err
+}
+
+// addDeadEntry adds a dead letter entry of the event type created age ago
+func addDeadEntry(repo *mockOutboxRepoForService, eventType string, age time.Duration) {
+	entry := &shared.OutboxEntry{
+		ID:        uuid.New(),
+		EventID:   uuid.New(),
+		EventType: eventType,
+		Status:    shared.OutboxStatusDead,
+		CreatedAt: time.Now().Add(-age),
+	}
+	repo.entries[entry.ID] = entry
+}
+
+func TestOutboxService_GetHealth(t *testing.T) {
+	ctx := context.Background()
+	thresholds := WithDeadLetterThresholds(DeadLetterThresholds{MaxCount: 3, MaxAge: time.Hour})
+
+	t.Run("green without dead letters", func(t *testing.T) {
+		repo := newMockOutboxRepoForService()
+		service := NewOutboxService(repo, zap.NewNop(), thresholds)
+
+		health, err := service.GetHealth(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "green", health.Status)
+		assert.Zero(t, health.DeadCount)
+		assert.Nil(t, health.OldestDeadAt)
+		assert.Equal(t, int64(3), health.Threshold)
+		assert.Equal(t, int64(3600), health.MaxAgeSeconds)
+	})
+
+	t.Run("amber within thresholds", func(t *testing.T) {
+		repo := newMockOutboxRepoForService()
+		addDeadEntry(repo, "SalesOrderShipped", 10*time.Minute)
+		addDeadEntry(repo, "PurchaseOrderReceived", 30*time.Minute)
+		addDeadEntry(repo, "SalesOrderShipped", 20*time.Minute)
+		service := NewOutboxService(repo, zap.NewNop(), thresholds)
+
+		health, err := service.GetHealth(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "amber", health.Status)
+		assert.Equal(t, int64(3), health.DeadCount)
+		assert.InDelta(t, 1800, health.OldestAgeSeconds, 5)
+		// Distinct event types, oldest first
+		assert.Equal(t, []string{"PurchaseOrderReceived", "SalesOrderShipped"}, health.SampleEventTypes)
+	})
+
+	t.Run("red over the count threshold", func(t *testing.T) {
+		repo := newMockOutboxRepoForService()
+		for i := 0; i < 4; i++ {
+			addDeadEntry(repo, "SalesOrderShipped", time.Minute)
+		}
+		service := NewOutboxService(repo, zap.NewNop(), thresholds)
+
+		health, err := service.GetHealth(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "red", health.Status)
+	})
+
+	t.Run("red over the max age", func(t *testing.T) {
+		repo := newMockOutboxRepoForService()
+		addDeadEntry(repo, "SalesOrderShipped", 2*time.Hour)
+		service := NewOutboxService(repo, zap.NewNop(), thresholds)
+
+		health, err := service.GetHealth(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "red", health.Status)
+	})
+}
+
+func TestOutboxService_CheckDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	thresholds := WithDeadLetterThresholds(DeadLetterThresholds{MaxCount: 1, MaxAge: time.Hour})
+
+	t.Run("alerts once when the queue turns red", func(t *testing.T) {
+		repo := newMockOutboxRepoForService()
+		notifier := &recordingNotifier{}
+		service := NewOutboxService(repo, zap.NewNop(), thresholds, WithDeadLetterNotifier(notifier))
+
+		addDeadEntry(repo, "SalesOrderShipped", time.Minute)
+		health, err := service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "amber", health.Status)
+		assert.Empty(t, notifier.alerts)
+
+		addDeadEntry(repo, "SupplierUpdated", 2*time.Minute)
+		_, err = service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+		require.Len(t, notifier.alerts, 1)
+		alert := notifier.alerts[0]
+		assert.Equal(t, "red", alert.Status)
+		assert.Equal(t, int64(2), alert.DeadCount)
+		assert.Equal(t, int64(1), alert.Threshold)
+		assert.Equal(t, []string{"SupplierUpdated", "SalesOrderShipped"}, alert.SampleEventTypes)
+
+		// Still red: no repeated alert
+		_, err = service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+		assert.Len(t, notifier.alerts, 1)
+
+		// Recovered, then red again: alerts again
+		for id := range repo.entries {
+			delete(repo.entries, id)
+		}
+		_, err = service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+		addDeadEntry(repo, "SalesOrderShipped", 2*time.Hour)
+		_, err = service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+		assert.Len(t, notifier.alerts, 2)
+	})
+
+	t.Run("failed alert is retried on the next check", func(t *testing.T) {
+		repo := newMockOutboxRepoForService()
+		notifier := &recordingNotifier{err: errors.New("webhook unavailable")}
+		service := NewOutboxService(repo, zap.NewNop(), thresholds, WithDeadLetterNotifier(notifier))
+		addDeadEntry(repo, "SalesOrderShipped", 2*time.Hour)
+
+		_, err := service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+		notifier.err = nil
+		_, err = service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+		_, err = service.CheckDeadLetters(ctx)
+		require.NoError(t, err)
+
+		assert.Len(t, notifier.alerts, 2)
+	})
+}
@@ -11,19 +11,29 @@ import (
 
 // OutboxService handles outbox event management operations
 type OutboxService struct {
-	repo   shared.OutboxRepository
-	logger *zap.Logger
+	repo    shared.OutboxRepository
+	logger  *zap.Logger
+	monitor *deadLetterMonitor
 }
 
+// OutboxServiceOption configures optional OutboxService behavior
+type OutboxServiceOption func(*OutboxService)
+
 // NewOutboxService creates a new outbox service
 func NewOutboxService(
 	repo shared.OutboxRepository,
 	logger *zap.Logger,
+	opts ...OutboxServiceOption,
 ) *OutboxService {
-	return &OutboxService{
-		repo:   repo,
-		logger: logger,
+	s := &OutboxService{
+		repo:    repo,
+		logger:  logger,
+		monitor: newDeadLetterMonitor(DefaultDeadLetterThresholds()),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // OutboxEntryDTO represents an outbox entry data transfer object
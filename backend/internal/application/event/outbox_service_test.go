@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -88,6 +89,45 @@ func (r *mockOutboxRepoForService) DeleteOlderThan(ctx context.Context, before t
 	return 0, nil
 }
 
+func (r *mockOutboxRepoForService) FindOldestDead(ctx context.Context, limit int) ([]*shared.OutboxEntry, error) {
+	var result []*shared.OutboxEntry
+	for _, e := range r.entries {
+		if e.Status == shared.OutboxStatusDead {
+			result = append(result, e)
+		}
+	}
+	slices.SortFunc(result, func(a, b *shared.OutboxEntry) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *mockOutboxRepoForService) FindForReplay(ctx context.Context, filter shared.OutboxReplayFilter) ([]*shared.OutboxEntry, error) {
+	var result []*shared.OutboxEntry
+	for _, e := range r.entries {
+		if e.Status != shared.OutboxStatusSent || e.CreatedAt.Before(filter.From) || !e.CreatedAt.Before(filter.To) {
+			continue
+		}
+		if len(filter.EventTypes) > 0 && !slices.Contains(filter.EventTypes, e.EventType) {
+			continue
+		}
+		if filter.AggregateType != "" && e.AggregateType != filter.AggregateType {
+			continue
+		}
+		result = append(result, e)
+	}
+	slices.SortFunc(result, func(a, b *shared.OutboxEntry) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
 func (r *mockOutboxRepoForService) CountByStatus(ctx context.Context) (map[shared.OutboxStatus]int64, error) {
 	counts := make(map[shared.OutboxStatus]int64)
 	for _, e := range r.entries {
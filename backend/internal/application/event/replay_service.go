@@ -0,0 +1,192 @@
+package event
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxReplayEvents is the most events a single replay may re-dispatch
+const MaxReplayEvents = 10000
+
+// maxReplayErrors caps the failures listed in a replay result
+const maxReplayErrors = 50
+
+// EventDeserializer turns stored event payloads back into domain events,
+// upgrading payloads written with an older schema version
+type EventDeserializer interface {
+	Deserialize(eventType string, data []byte) (shared.DomainEvent, error)
+}
+
+// ReplayService re-dispatches delivered outbox events to a set of handlers,
+// e.g. to rebuild a read model after it was added or corrupted
+type ReplayService struct {
+	repo         shared.OutboxRepository
+	deserializer EventDeserializer
+	handlers     []shared.EventHandler
+	logger       *zap.Logger
+}
+
+// NewReplayService creates a new replay service dispatching to the given handlers.
+// Only pass handlers that rebuild read models; replays bypass idempotency checks.
+func NewReplayService(
+	repo shared.OutboxRepository,
+	deserializer EventDeserializer,
+	logger *zap.Logger,
+	handlers ...shared.EventHandler,
+) *ReplayService {
+	return &ReplayService{
+		repo:         repo,
+		deserializer: deserializer,
+		handlers:     handlers,
+		logger:       logger,
+	}
+}
+
+// ReplayFilter selects the events to replay
+type ReplayFilter struct {
+	TenantID      *uuid.UUID `json:"tenant_id,omitempty"`
+	EventTypes    []string   `json:"event_types,omitempty"`
+	AggregateType string     `json:"aggregate_type,omitempty"`
+	From          time.Time  `json:"from"`
+	To            time.Time  `json:"to"`
+	DryRun        bool       `json:"dry_run"` // Count the matching events without dispatching them
+}
+
+// ReplayErrorDTO describes an event that failed to replay
+type ReplayErrorDTO struct {
+	EventID   uuid.UUID `json:"event_id"`
+	EventType string    `json:"event_type"`
+	Error     string    `json:"error"`
+}
+
+// ReplayResult summarizes a replay
+type ReplayResult struct {
+	Matched  int              `json:"matched"`  // Events selected by the filter
+	Replayed int              `json:"replayed"` // Events dispatched to every interested handler without error
+	Skipped  int              `json:"skipped"`  // Events no handler is interested in
+	Failed   int              `json:"failed"`   // Events that could not be deserialized or whose handlers failed
+	Errors   []ReplayErrorDTO `json:"errors"`   // First failures, capped at 50
+	DryRun   bool             `json:"dry_run"`
+}
+
+// ReplayEvents reads the sent outbox events matching the filter, oldest first, and
+// re-dispatches them to the replay handlers with a context marked by shared.WithEventReplay.
+// A failing event does not stop the replay; it is counted and listed in the result.
+func (s *ReplayService) ReplayEvents(ctx context.Context, filter ReplayFilter) (*ReplayResult, error) {
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, shared.NewDomainError("INVALID_TIME_WINDOW", "Both from and to are required")
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, shared.NewDomainError("INVALID_TIME_WINDOW", "from must be before to")
+	}
+
+	entries, err := s.repo.FindForReplay(ctx, shared.OutboxReplayFilter{
+		TenantID:      filter.TenantID,
+		EventTypes:    filter.EventTypes,
+		AggregateType: filter.AggregateType,
+		From:          filter.From,
+		To:            filter.To,
+		Limit:         MaxReplayEvents + 1,
+	})
+	if err != nil {
+		s.logger.Error("Failed to find events for replay", zap.Error(err))
+		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to retrieve events for replay")
+	}
+	if len(entries) > MaxReplayEvents {
+		return nil, shared.NewDomainError("REPLAY_TOO_LARGE", "More than 10000 events match; narrow the filter")
+	}
+
+	result := &ReplayResult{
+		Matched: len(entries),
+		Errors:  make([]ReplayErrorDTO, 0),
+		DryRun:  filter.DryRun,
+	}
+	if filter.DryRun {
+		return result, nil
+	}
+
+	replayCtx := shared.WithEventReplay(ctx)
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		handlers := s.handlersFor(entry.EventType)
+		if len(handlers) == 0 {
+			result.Skipped++
+			continue
+		}
+
+		if err := s.replayEntry(replayCtx, entry, handlers); err != nil {
+			result.Failed++
+			if len(result.Errors) < maxReplayErrors {
+				result.Errors = append(result.Errors, ReplayErrorDTO{
+					EventID:   entry.EventID,
+					EventType: entry.EventType,
+					Error:     err.Error(),
+				})
+			}
+			continue
+		}
+		result.Replayed++
+	}
+
+	s.logger.Info("Replayed events",
+		zap.Strings("event_types", filter.EventTypes),
+		zap.String("aggregate_type", filter.AggregateType),
+		zap.Time("from", filter.From),
+		zap.Time("to", filter.To),
+		zap.Int("matched", result.Matched),
+		zap.Int("replayed", result.Replayed),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("failed", result.Failed),
+	)
+
+	return result, nil
+}
+
+// replayEntry deserializes an outbox entry and dispatches it to the handlers,
+// returning the first error after every handler has run
+func (s *ReplayService) replayEntry(ctx context.Context, entry *shared.OutboxEntry, handlers []shared.EventHandler) error {
+	event, err := s.deserializer.Deserialize(entry.EventType, entry.Payload)
+	if err != nil {
+		s.logger.Error("Failed to deserialize event for replay",
+			zap.Error(err),
+			zap.String("event_id", entry.EventID.String()),
+			zap.String("event_type", entry.EventType),
+		)
+		return err
+	}
+
+	var firstErr error
+	for _, handler := range handlers {
+		if err := handler.Handle(ctx, event); err != nil {
+			s.logger.Error("Failed to replay event",
+				zap.Error(err),
+				zap.String("event_id", entry.EventID.String()),
+				zap.String("event_type", entry.EventType),
+			)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// handlersFor returns the replay handlers interested in the event type
+func (s *ReplayService) handlersFor(eventType string) []shared.EventHandler {
+	handlers := make([]shared.EventHandler, 0, len(s.handlers))
+	for _, handler := range s.handlers {
+		types := handler.EventTypes()
+		if len(types) == 0 || slices.Contains(types, eventType) {
+			handlers = append(handlers, handler)
+		}
+	}
+	return handlers
+}
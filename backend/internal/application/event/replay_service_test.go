@@ -0,0 +1,155 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// replayTestEvent is a simple event stored in the outbox for replay tests
+type replayTestEvent struct {
+	shared.BaseDomainEvent
+	Amount int `json:"amount"`
+}
+
+// stubDeserializer decodes replayTestEvent payloads of known event types
+type stubDeserializer struct{}
+
+func (stubDeserializer) Deserialize(eventType string, data []byte) (shared.DomainEvent, error) {
+	if eventType == "Unknown" {
+		return nil, fmt.Errorf("unknown event type: %s", eventType)
+	}
+	var event replayTestEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// recordingHandler records the events it handles and whether they were replayed
+type recordingHandler struct {
+	eventTypes []string
+	handled    []shared.DomainEvent
+	replayed   []bool
+	err        error
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	h.handled = append(h.handled, event)
+	h.replayed = append(h.replayed, shared.IsEventReplay(ctx))
+	return h.err
+}
+
+func (h *recordingHandler) EventTypes() []string {
+	return h.eventTypes
+}
+
+func TestReplayService_ReplayEvents(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	newRepo := func(t *testing.T) *mockOutboxRepoForService {
+		repo := newMockOutboxRepoForService()
+		add := func(eventType, aggregateType string, status shared.OutboxStatus, createdAt time.Time) {
+			event := &replayTestEvent{
+				BaseDomainEvent: shared.NewBaseDomainEvent(eventType, aggregateType, uuid.New(), uuid.New()),
+				Amount:          100,
+			}
+			payload, err := json.Marshal(event)
+			require.NoError(t, err)
+			entry := shared.NewOutboxEntry(event.TenantID(), event, payload)
+			entry.Status = status
+			entry.CreatedAt = createdAt
+			repo.entries[entry.ID] = entry
+		}
+		add("OrderShipped", "Order", shared.OutboxStatusSent, start.Add(2*time.Hour))
+		add("OrderCreated", "Order", shared.OutboxStatusSent, start.Add(time.Hour))
+		add("SupplierUpdated", "Supplier", shared.OutboxStatusSent, start.Add(3*time.Hour))
+		// Outside the window, or not delivered yet
+		add("OrderCreated", "Order", shared.OutboxStatusSent, start.AddDate(0, 0, -1))
+		add("OrderCreated", "Order", shared.OutboxStatusPending, start.Add(time.Hour))
+		return repo
+	}
+	window := ReplayFilter{From: start, To: start.AddDate(0, 0, 1)}
+
+	t.Run("dispatches matching events in order with the replay flag", func(t *testing.T) {
+		handler := &recordingHandler{eventTypes: []string{"OrderCreated", "OrderShipped"}}
+		service := NewReplayService(newRepo(t), stubDeserializer{}, zap.NewNop(), handler)
+
+		result, err := service.ReplayEvents(ctx, window)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, result.Matched)
+		assert.Equal(t, 2, result.Replayed)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Zero(t, result.Failed)
+		require.Len(t, handler.handled, 2)
+		assert.Equal(t, "OrderCreated", handler.handled[0].EventType())
+		assert.Equal(t, "OrderShipped", handler.handled[1].EventType())
+		assert.Equal(t, []bool{true, true}, handler.replayed)
+	})
+
+	t.Run("filters by event and aggregate type", func(t *testing.T) {
+		handler := &recordingHandler{}
+		service := NewReplayService(newRepo(t), stubDeserializer{}, zap.NewNop(), handler)
+
+		filter := window
+		filter.EventTypes = []string{"OrderShipped", "SupplierUpdated"}
+		filter.AggregateType = "Supplier"
+		result, err := service.ReplayEvents(ctx, filter)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Matched)
+		assert.Equal(t, 1, result.Replayed)
+		require.Len(t, handler.handled, 1)
+		assert.Equal(t, "SupplierUpdated", handler.handled[0].EventType())
+	})
+
+	t.Run("dry run only counts", func(t *testing.T) {
+		handler := &recordingHandler{}
+		service := NewReplayService(newRepo(t), stubDeserializer{}, zap.NewNop(), handler)
+
+		filter := window
+		filter.DryRun = true
+		result, err := service.ReplayEvents(ctx, filter)
+		require.NoError(t, err)
+
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 3, result.Matched)
+		assert.Zero(t, result.Replayed)
+		assert.Empty(t, handler.handled)
+	})
+
+	t.Run("failures are reported without stopping the replay", func(t *testing.T) {
+		handler := &recordingHandler{err: errors.New("projection unavailable")}
+		service := NewReplayService(newRepo(t), stubDeserializer{}, zap.NewNop(), handler)
+
+		result, err := service.ReplayEvents(ctx, window)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, result.Failed)
+		assert.Zero(t, result.Replayed)
+		require.Len(t, result.Errors, 3)
+		assert.Equal(t, "projection unavailable", result.Errors[0].Error)
+		assert.Len(t, handler.handled, 3)
+	})
+
+	t.Run("invalid time window", func(t *testing.T) {
+		service := NewReplayService(newRepo(t), stubDeserializer{}, zap.NewNop())
+
+		_, err := service.ReplayEvents(ctx, ReplayFilter{From: start, To: start})
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_TIME_WINDOW", domainErr.Code)
+	})
+}
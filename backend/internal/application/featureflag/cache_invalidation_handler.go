@@ -0,0 +1,111 @@
+package featureflag
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/featureflag"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FlagCacheInvalidator evicts flags and overrides from the evaluation cache.
+// It is implemented by EvaluationService.
+type FlagCacheInvalidator interface {
+	InvalidateFlag(ctx context.Context, key string) error
+	InvalidateOverride(ctx context.Context, flagKey string, targetType featureflag.OverrideTargetType, targetID uuid.UUID) error
+}
+
+// CacheInvalidationHandler evicts cached flags and overrides when they change,
+// so evaluations don't keep using them until their TTL runs out.
+// It receives the events FlagService and OverrideService publish through the outbox.
+//
+// Events are delivered to one instance per consumer group, so on other instances
+// the cache TTL bounds how long a changed flag is still evaluated from the old copy.
+type CacheInvalidationHandler struct {
+	cache  FlagCacheInvalidator
+	logger *zap.Logger
+}
+
+// NewCacheInvalidationHandler creates a new handler evicting changed flags from the cache
+func NewCacheInvalidationHandler(cache FlagCacheInvalidator, logger *zap.Logger) *CacheInvalidationHandler {
+	return &CacheInvalidationHandler{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *CacheInvalidationHandler) EventTypes() []string {
+	return []string{
+		featureflag.EventTypeFlagCreated,
+		featureflag.EventTypeFlagUpdated,
+		featureflag.EventTypeFlagEnabled,
+		featureflag.EventTypeFlagDisabled,
+		featureflag.EventTypeFlagArchived,
+		featureflag.EventTypeOverrideCreated,
+		featureflag.EventTypeOverrideUpdated,
+		featureflag.EventTypeOverrideRemoved,
+	}
+}
+
+// Handle evicts the flag or override the event is about
+func (h *CacheInvalidationHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	switch e := event.(type) {
+	case *featureflag.FlagCreatedEvent:
+		return h.invalidateFlag(ctx, e.Key)
+	case *featureflag.FlagUpdatedEvent:
+		return h.invalidateFlag(ctx, e.Key)
+	case *featureflag.FlagUpdatedEventWithDetails:
+		return h.invalidateFlag(ctx, e.Key)
+	case *featureflag.FlagEnabledEvent:
+		return h.invalidateFlag(ctx, e.Key)
+	case *featureflag.FlagDisabledEvent:
+		return h.invalidateFlag(ctx, e.Key)
+	case *featureflag.FlagArchivedEvent:
+		return h.invalidateFlag(ctx, e.Key)
+	case *featureflag.OverrideCreatedEvent:
+		return h.invalidateOverride(ctx, e.FlagKey, e.TargetType, e.TargetID)
+	case *featureflag.OverrideUpdatedEvent:
+		return h.invalidateOverride(ctx, e.FlagKey, e.TargetType, e.TargetID)
+	case *featureflag.OverrideRemovedEvent:
+		return h.invalidateOverride(ctx, e.FlagKey, e.TargetType, e.TargetID)
+	default:
+		h.logger.Warn("unexpected event type for flag cache invalidation",
+			zap.String("event_type", event.EventType()))
+		return nil
+	}
+}
+
+// invalidateFlag evicts a flag from the cache
+func (h *CacheInvalidationHandler) invalidateFlag(ctx context.Context, key string) error {
+	if err := h.cache.InvalidateFlag(ctx, key); err != nil {
+		h.logger.Error("Failed to invalidate cached flag",
+			zap.String("key", key),
+			zap.Error(err))
+		return err
+	}
+	h.logger.Debug("Invalidated cached flag", zap.String("key", key))
+	return nil
+}
+
+// invalidateOverride evicts an override from the cache
+func (h *CacheInvalidationHandler) invalidateOverride(ctx context.Context, flagKey string, targetType featureflag.OverrideTargetType, targetID uuid.UUID) error {
+	if err := h.cache.InvalidateOverride(ctx, flagKey, targetType, targetID); err != nil {
+		h.logger.Error("Failed to invalidate cached override",
+			zap.String("flag_key", flagKey),
+			zap.String("target_type", string(targetType)),
+			zap.Error(err))
+		return err
+	}
+	h.logger.Debug("Invalidated cached override",
+		zap.String("flag_key", flagKey),
+		zap.String("target_type", string(targetType)))
+	return nil
+}
+
+// Ensure CacheInvalidationHandler implements shared.EventHandler
+var _ shared.EventHandler = (*CacheInvalidationHandler)(nil)
+
+// Ensure EvaluationService implements FlagCacheInvalidator
+var _ FlagCacheInvalidator = (*EvaluationService)(nil)
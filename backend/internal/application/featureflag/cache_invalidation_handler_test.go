@@ -0,0 +1,150 @@
+package featureflag
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/application/featureflag/dto"
+	"github.com/erp/backend/internal/domain/featureflag"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/cache"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFlagCacheInvalidator is a mock implementation of FlagCacheInvalidator
+type MockFlagCacheInvalidator struct {
+	mock.Mock
+}
+
+func (m *MockFlagCacheInvalidator) InvalidateFlag(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockFlagCacheInvalidator) InvalidateOverride(ctx context.Context, flagKey string, targetType featureflag.OverrideTargetType, targetID uuid.UUID) error {
+	args := m.Called(ctx, flagKey, targetType, targetID)
+	return args.Error(0)
+}
+
+// newCachedTestService creates an evaluation service caching flags in memory for ttl
+func newCachedTestService(t *testing.T, flagRepo *MockFeatureFlagRepository, ttl time.Duration) *EvaluationService {
+	config := featureflag.DefaultCacheConfig()
+	config.FlagTTL = ttl
+	config.OverrideTTL = ttl
+	flagCache := cache.NewInMemoryFeatureFlagCache(cache.WithInMemoryConfig(config))
+	t.Cleanup(func() { _ = flagCache.Close() })
+
+	return NewCachedEvaluationServiceWithConfig(flagRepo, nil, flagCache, config, newTestLogger())
+}
+
+func TestCacheInvalidationHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	flag := createTestFlag("new-checkout", "New Checkout")
+	override, err := featureflag.NewFlagOverride("new-checkout", featureflag.OverrideTargetTypeTenant, uuid.New(),
+		featureflag.NewBooleanFlagValue(true), "pilot tenant", nil, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		event shared.DomainEvent
+	}{
+		{"flag updated", featureflag.NewFlagUpdatedEvent(flag)},
+		{"flag enabled", featureflag.NewFlagEnabledEvent(flag, featureflag.FlagStatusDisabled)},
+		{"flag archived", featureflag.NewFlagArchivedEvent(flag, featureflag.FlagStatusEnabled)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalidator := new(MockFlagCacheInvalidator)
+			invalidator.On("InvalidateFlag", ctx, "new-checkout").Return(nil)
+
+			err := NewCacheInvalidationHandler(invalidator, newTestLogger()).Handle(ctx, tt.event)
+
+			assert.NoError(t, err)
+			invalidator.AssertExpectations(t)
+		})
+	}
+
+	t.Run("override updated", func(t *testing.T) {
+		invalidator := new(MockFlagCacheInvalidator)
+		invalidator.On("InvalidateOverride", ctx, "new-checkout", featureflag.OverrideTargetTypeTenant, override.TargetID).Return(nil)
+		event := featureflag.NewOverrideUpdatedEvent(override, flag.ID, featureflag.NewBooleanFlagValue(false), nil)
+
+		err := NewCacheInvalidationHandler(invalidator, newTestLogger()).Handle(ctx, event)
+
+		assert.NoError(t, err)
+		invalidator.AssertExpectations(t)
+	})
+}
+
+func TestEvaluationService_BatchEvaluate_Cached(t *testing.T) {
+	ctx := context.Background()
+	keys := []string{"flag-1", "flag-2"}
+
+	newFlag := func(key string, enabled bool) *featureflag.FeatureFlag {
+		flag := createTestFlag(key, key)
+		_ = flag.SetDefault(featureflag.NewBooleanFlagValue(true), nil)
+		if enabled {
+			_ = flag.Enable(nil)
+		}
+		return flag
+	}
+
+	t.Run("evaluates from the cache within the TTL", func(t *testing.T) {
+		mockFlagRepo := new(MockFeatureFlagRepository)
+		mockFlagRepo.On("FindByKey", ctx, "flag-1").Return(newFlag("flag-1", true), nil)
+		mockFlagRepo.On("FindByKey", ctx, "flag-2").Return(newFlag("flag-2", true), nil)
+		service := newCachedTestService(t, mockFlagRepo, time.Minute)
+
+		first, err := service.BatchEvaluate(ctx, keys, dto.EvaluationContextDTO{})
+		require.NoError(t, err)
+		second, err := service.BatchEvaluate(ctx, keys, dto.EvaluationContextDTO{})
+		require.NoError(t, err)
+
+		assert.True(t, second.Results["flag-1"].Enabled)
+		assert.Equal(t, first.Results["flag-2"].Enabled, second.Results["flag-2"].Enabled)
+		mockFlagRepo.AssertNumberOfCalls(t, "FindByKey", 2)
+	})
+
+	t.Run("reads the repository again after the TTL", func(t *testing.T) {
+		mockFlagRepo := new(MockFeatureFlagRepository)
+		mockFlagRepo.On("FindByKey", ctx, "flag-1").Return(newFlag("flag-1", true), nil)
+		service := newCachedTestService(t, mockFlagRepo, 20*time.Millisecond)
+
+		_, err := service.BatchEvaluate(ctx, []string{"flag-1"}, dto.EvaluationContextDTO{})
+		require.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+		_, err = service.BatchEvaluate(ctx, []string{"flag-1"}, dto.EvaluationContextDTO{})
+		require.NoError(t, err)
+
+		mockFlagRepo.AssertNumberOfCalls(t, "FindByKey", 2)
+	})
+
+	t.Run("evaluates the updated flag after its update event", func(t *testing.T) {
+		mockFlagRepo := new(MockFeatureFlagRepository)
+		mockFlagRepo.On("FindByKey", ctx, "flag-1").Return(newFlag("flag-1", true), nil).Once()
+		mockFlagRepo.On("FindByKey", ctx, "flag-2").Return(newFlag("flag-2", true), nil).Once()
+		service := newCachedTestService(t, mockFlagRepo, time.Minute)
+		handler := NewCacheInvalidationHandler(service, newTestLogger())
+
+		before, err := service.BatchEvaluate(ctx, keys, dto.EvaluationContextDTO{})
+		require.NoError(t, err)
+		require.True(t, before.Results["flag-1"].Enabled)
+
+		disabled := newFlag("flag-1", false)
+		mockFlagRepo.On("FindByKey", ctx, "flag-1").Return(disabled, nil).Once()
+		require.NoError(t, handler.Handle(ctx, featureflag.NewFlagDisabledEvent(disabled, featureflag.FlagStatusEnabled)))
+
+		after, err := service.BatchEvaluate(ctx, keys, dto.EvaluationContextDTO{})
+		require.NoError(t, err)
+
+		assert.False(t, after.Results["flag-1"].Enabled)
+		assert.Equal(t, "disabled", after.Results["flag-1"].Reason)
+		assert.True(t, after.Results["flag-2"].Enabled, "other flags stay cached")
+		mockFlagRepo.AssertExpectations(t)
+		mockFlagRepo.AssertNumberOfCalls(t, "FindByKey", 3)
+	})
+}
@@ -45,6 +45,14 @@ type AuditLogListFilter struct {
 	Page     int     `form:"page,default=1" binding:"min=1"`
 	PageSize int     `form:"page_size,default=20" binding:"min=1,max=100"`
 	Action   *string `form:"action,omitempty"`
+	// Pagination is "offset" (default) or "cursor"; Cursor is the next_cursor of the previous page
+	Pagination string `form:"pagination" binding:"omitempty,oneof=offset cursor"`
+	Cursor     string `form:"cursor"`
+}
+
+// UsesCursor reports whether the request asked for cursor pagination
+func (f AuditLogListFilter) UsesCursor() bool {
+	return f.Pagination == "cursor" || f.Cursor != ""
 }
 
 // AuditLogListResponse represents a paginated list of audit logs
@@ -55,6 +63,8 @@ type AuditLogListResponse struct {
 	Page       int                `json:"page"`
 	PageSize   int                `json:"page_size"`
 	TotalPages int                `json:"total_pages"`
+	// NextCursor is set in cursor mode when another page follows; Total and TotalPages are not computed then
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ToAuditLogListResponse creates an AuditLogListResponse from domain audit logs
@@ -97,6 +97,22 @@ func TestTargetingRuleDTO_ToDomain(t *testing.T) {
 	assert.Equal(t, 1, len(domain.GetConditions()))
 }
 
+func TestTargetingRuleDTO_ToDomain_RolloutBy(t *testing.T) {
+	dto := TargetingRuleDTO{
+		RuleID:     "rollout",
+		Priority:   1,
+		Value:      FlagValueDTO{Enabled: true},
+		Percentage: 10,
+		RolloutBy:  "tenant",
+	}
+
+	domain, err := dto.ToDomain()
+
+	assert.NoError(t, err)
+	assert.Equal(t, featureflag.RolloutByTenant, domain.GetRolloutBy())
+	assert.Equal(t, "tenant", ToTargetingRuleDTO(domain).RolloutBy)
+}
+
 func TestToTargetingRuleDTO(t *testing.T) {
 	condition, _ := featureflag.NewCondition("role", featureflag.ConditionOperatorEquals, []string{"admin"})
 	rule, _ := featureflag.NewTargetingRuleWithPercentage(
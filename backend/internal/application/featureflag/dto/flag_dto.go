@@ -56,6 +56,7 @@ type TargetingRuleDTO struct {
 	Conditions []ConditionDTO `json:"conditions"`
 	Value      FlagValueDTO   `json:"value"`
 	Percentage int            `json:"percentage" binding:"min=0,max=100"`
+	RolloutBy  string         `json:"rollout_by,omitempty" binding:"omitempty,oneof=user tenant"`
 }
 
 // ToTargetingRuleDTO converts domain TargetingRule to DTO
@@ -70,6 +71,7 @@ func ToTargetingRuleDTO(r featureflag.TargetingRule) TargetingRuleDTO {
 		Conditions: conditions,
 		Value:      ToFlagValueDTO(r.GetValue()),
 		Percentage: r.GetPercentage(),
+		RolloutBy:  string(r.GetRolloutBy()),
 	}
 }
 
@@ -84,52 +86,91 @@ func (d TargetingRuleDTO) ToDomain() (featureflag.TargetingRule, error) {
 		conditions[i] = condition
 	}
 
-	return featureflag.NewTargetingRuleWithPercentage(
+	rule, err := featureflag.NewTargetingRuleWithPercentage(
 		d.RuleID,
 		d.Priority,
 		conditions,
 		d.Value.ToDomain(),
 		d.Percentage,
 	)
+	if err != nil || d.RolloutBy == "" {
+		return rule, err
+	}
+	return rule.WithRolloutBy(featureflag.RolloutBy(d.RolloutBy))
+}
+
+// PrerequisiteDTO represents a prerequisite flag in API requests/responses
+type PrerequisiteDTO struct {
+	FlagKey string `json:"flag_key" binding:"required"`
+	Variant string `json:"variant,omitempty"`
+}
+
+// ToPrerequisiteDTO converts domain Prerequisite to DTO
+func ToPrerequisiteDTO(p featureflag.Prerequisite) PrerequisiteDTO {
+	return PrerequisiteDTO{
+		FlagKey: p.FlagKey,
+		Variant: p.Variant,
+	}
+}
+
+// ToDomain converts PrerequisiteDTO to domain Prerequisite
+func (d PrerequisiteDTO) ToDomain() (featureflag.Prerequisite, error) {
+	return featureflag.NewPrerequisite(d.FlagKey, d.Variant)
+}
+
+// ToPrerequisites converts prerequisite DTOs to domain Prerequisites
+func ToPrerequisites(dtos []PrerequisiteDTO) ([]featureflag.Prerequisite, error) {
+	prerequisites := make([]featureflag.Prerequisite, len(dtos))
+	for i, d := range dtos {
+		prerequisite, err := d.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		prerequisites[i] = prerequisite
+	}
+	return prerequisites, nil
 }
 
 // CreateFlagRequest represents the request to create a new feature flag
 type CreateFlagRequest struct {
-	Key          string             `json:"key" binding:"required,min=1,max=100"`
-	Name         string             `json:"name" binding:"required,min=1,max=200"`
-	Description  string             `json:"description,omitempty"`
-	Type         string             `json:"type" binding:"required,oneof=boolean percentage variant user_segment"`
-	DefaultValue FlagValueDTO       `json:"default_value"`
-	Rules        []TargetingRuleDTO `json:"rules,omitempty"`
-	Tags         []string           `json:"tags,omitempty"`
+	Key           string             `json:"key" binding:"required,min=1,max=100"`
+	Name          string             `json:"name" binding:"required,min=1,max=200"`
+	Description   string             `json:"description,omitempty"`
+	Type          string             `json:"type" binding:"required,oneof=boolean percentage variant user_segment"`
+	DefaultValue  FlagValueDTO       `json:"default_value"`
+	Rules         []TargetingRuleDTO `json:"rules,omitempty"`
+	Tags          []string           `json:"tags,omitempty"`
+	Prerequisites []PrerequisiteDTO  `json:"prerequisites,omitempty" binding:"omitempty,dive"`
 }
 
 // UpdateFlagRequest represents the request to update a feature flag
 type UpdateFlagRequest struct {
-	Name         *string             `json:"name,omitempty"`
-	Description  *string             `json:"description,omitempty"`
-	DefaultValue *FlagValueDTO       `json:"default_value,omitempty"`
-	Rules        *[]TargetingRuleDTO `json:"rules,omitempty"`
-	Tags         *[]string           `json:"tags,omitempty"`
-	Version      *int                `json:"version,omitempty"` // For optimistic locking
+	Name          *string             `json:"name,omitempty"`
+	Description   *string             `json:"description,omitempty"`
+	DefaultValue  *FlagValueDTO       `json:"default_value,omitempty"`
+	Rules         *[]TargetingRuleDTO `json:"rules,omitempty"`
+	Tags          *[]string           `json:"tags,omitempty"`
+	Prerequisites *[]PrerequisiteDTO  `json:"prerequisites,omitempty" binding:"omitempty,dive"`
+	Version       *int                `json:"version,omitempty"` // For optimistic locking
 }
 
 // FlagResponse represents a feature flag in API responses
 type FlagResponse struct {
-	ID           uuid.UUID          `json:"id"`
-	Key          string             `json:"key"`
-	Name         string             `json:"name"`
-	Description  string             `json:"description,omitempty"`
-	Type         string             `json:"type"`
-	Status       string             `json:"status"`
-	DefaultValue FlagValueDTO       `json:"default_value"`
-	Rules        []TargetingRuleDTO `json:"rules,omitempty"`
-	Tags         []string           `json:"tags,omitempty"`
-	Version      int                `json:"version"`
-	CreatedBy    *uuid.UUID         `json:"created_by,omitempty"`
-	UpdatedBy    *uuid.UUID         `json:"updated_by,omitempty"`
-	CreatedAt    time.Time          `json:"created_at"`
-	UpdatedAt    time.Time          `json:"updated_at"`
+	ID            uuid.UUID          `json:"id"`
+	Key           string             `json:"key"`
+	Name          string             `json:"name"`
+	Description   string             `json:"description,omitempty"`
+	Type          string             `json:"type"`
+	Status        string             `json:"status"`
+	DefaultValue  FlagValueDTO       `json:"default_value"`
+	Rules         []TargetingRuleDTO `json:"rules,omitempty"`
+	Tags          []string           `json:"tags,omitempty"`
+	Prerequisites []PrerequisiteDTO  `json:"prerequisites,omitempty"`
+	Version       int                `json:"version"`
+	CreatedBy     *uuid.UUID         `json:"created_by,omitempty"`
+	UpdatedBy     *uuid.UUID         `json:"updated_by,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
 }
 
 // ToFlagResponse converts domain FeatureFlag to FlagResponse
@@ -143,21 +184,27 @@ func ToFlagResponse(flag *featureflag.FeatureFlag) *FlagResponse {
 		rules[i] = ToTargetingRuleDTO(r)
 	}
 
+	prerequisites := make([]PrerequisiteDTO, len(flag.GetPrerequisites()))
+	for i, p := range flag.GetPrerequisites() {
+		prerequisites[i] = ToPrerequisiteDTO(p)
+	}
+
 	return &FlagResponse{
-		ID:           flag.ID,
-		Key:          flag.GetKey(),
-		Name:         flag.GetName(),
-		Description:  flag.GetDescription(),
-		Type:         string(flag.GetType()),
-		Status:       string(flag.GetStatus()),
-		DefaultValue: ToFlagValueDTO(flag.GetDefaultValue()),
-		Rules:        rules,
-		Tags:         flag.GetTags(),
-		Version:      flag.GetVersion(),
-		CreatedBy:    flag.GetCreatedBy(),
-		UpdatedBy:    flag.GetUpdatedBy(),
-		CreatedAt:    flag.CreatedAt,
-		UpdatedAt:    flag.UpdatedAt,
+		ID:            flag.ID,
+		Key:           flag.GetKey(),
+		Name:          flag.GetName(),
+		Description:   flag.GetDescription(),
+		Type:          string(flag.GetType()),
+		Status:        string(flag.GetStatus()),
+		DefaultValue:  ToFlagValueDTO(flag.GetDefaultValue()),
+		Rules:         rules,
+		Tags:          flag.GetTags(),
+		Prerequisites: prerequisites,
+		Version:       flag.GetVersion(),
+		CreatedBy:     flag.GetCreatedBy(),
+		UpdatedBy:     flag.GetUpdatedBy(),
+		CreatedAt:     flag.CreatedAt,
+		UpdatedAt:     flag.UpdatedAt,
 	}
 }
 
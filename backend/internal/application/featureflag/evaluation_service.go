@@ -6,6 +6,7 @@ import (
 	"github.com/erp/backend/internal/application/featureflag/dto"
 	"github.com/erp/backend/internal/domain/featureflag"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -222,6 +223,15 @@ func (s *EvaluationService) InvalidateFlag(ctx context.Context, key string) erro
 	return nil
 }
 
+// InvalidateOverride invalidates a specific override in the cache
+// Returns nil if the evaluator doesn't support caching
+func (s *EvaluationService) InvalidateOverride(ctx context.Context, flagKey string, targetType featureflag.OverrideTargetType, targetID uuid.UUID) error {
+	if cachedEval, ok := s.evaluator.(*featureflag.CachedEvaluator); ok {
+		return cachedEval.InvalidateOverride(ctx, flagKey, targetType, targetID)
+	}
+	return nil
+}
+
 // GetCacheStats returns cache statistics if available
 // Returns nil if the evaluator doesn't support caching
 func (s *EvaluationService) GetCacheStats(ctx context.Context) *featureflag.CacheStats {
@@ -516,3 +516,45 @@ func TestEvaluationService_Evaluate_WithOverride(t *testing.T) {
 	assert.True(t, result.Enabled)
 	assert.Equal(t, "override_user", result.Reason)
 }
+
+func TestEvaluationService_Evaluate_Prerequisites(t *testing.T) {
+	ctx := context.Background()
+
+	newFlags := func(prerequisiteEnabled bool) *MockFeatureFlagRepository {
+		flag := createTestFlag("new-checkout", "New Checkout")
+		_ = flag.Enable(nil)
+		_ = flag.SetDefault(featureflag.NewBooleanFlagValue(true), nil)
+		_ = flag.SetPrerequisites([]featureflag.Prerequisite{{FlagKey: "new-cart"}}, nil)
+
+		prerequisite := createTestFlag("new-cart", "New Cart")
+		if prerequisiteEnabled {
+			_ = prerequisite.Enable(nil)
+			_ = prerequisite.SetDefault(featureflag.NewBooleanFlagValue(true), nil)
+		}
+
+		mockFlagRepo := new(MockFeatureFlagRepository)
+		mockFlagRepo.On("FindByKey", ctx, "new-checkout").Return(flag, nil)
+		mockFlagRepo.On("FindByKey", ctx, "new-cart").Return(prerequisite, nil)
+		return mockFlagRepo
+	}
+
+	t.Run("prerequisite met", func(t *testing.T) {
+		service := NewEvaluationService(newFlags(true), nil, newTestLogger())
+
+		result, err := service.Evaluate(ctx, "new-checkout", dto.EvaluationContextDTO{})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Enabled)
+		assert.Equal(t, "default", result.Reason)
+	})
+
+	t.Run("prerequisite not met", func(t *testing.T) {
+		service := NewEvaluationService(newFlags(false), nil, newTestLogger())
+
+		result, err := service.Evaluate(ctx, "new-checkout", dto.EvaluationContextDTO{})
+
+		assert.NoError(t, err)
+		assert.False(t, result.Enabled)
+		assert.Equal(t, "prerequisite_not_met", result.Reason)
+	})
+}
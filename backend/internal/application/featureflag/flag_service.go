@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/erp/backend/internal/application/featureflag/dto"
 	"github.com/erp/backend/internal/domain/featureflag"
@@ -96,6 +97,13 @@ func (s *FlagService) CreateFlag(ctx context.Context, req dto.CreateFlagRequest,
 		}
 	}
 
+	// Set prerequisites
+	if len(req.Prerequisites) > 0 {
+		if err := s.setPrerequisites(ctx, flag, req.Prerequisites, auditCtx.UserID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Persist the flag
 	if err := s.flagRepo.Create(ctx, flag); err != nil {
 		s.logger.Error("Failed to create flag", zap.Error(err))
@@ -193,6 +201,13 @@ func (s *FlagService) UpdateFlag(ctx context.Context, key string, req dto.Update
 		}
 	}
 
+	// Update prerequisites if provided
+	if req.Prerequisites != nil {
+		if err := s.setPrerequisites(ctx, flag, *req.Prerequisites, auditCtx.UserID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Reset version to exactly one more than original for consistent optimistic locking
 	// This handles the case where multiple domain operations each increment the version
 	flag.Version = originalVersion + 1
@@ -405,6 +420,43 @@ func (s *FlagService) ArchiveFlag(ctx context.Context, key string, auditCtx Audi
 	return nil
 }
 
+// setPrerequisites sets the prerequisites of a flag, checking that the prerequisite
+// flags exist and that the flag doesn't come to depend on itself through them
+func (s *FlagService) setPrerequisites(ctx context.Context, flag *featureflag.FeatureFlag, prerequisiteDTOs []dto.PrerequisiteDTO, updatedBy *uuid.UUID) error {
+	prerequisites, err := dto.ToPrerequisites(prerequisiteDTOs)
+	if err != nil {
+		return err
+	}
+	if err := flag.SetPrerequisites(prerequisites, updatedBy); err != nil {
+		return err
+	}
+
+	for _, prerequisite := range flag.GetPrerequisites() {
+		if _, err := s.flagRepo.FindByKey(ctx, prerequisite.FlagKey); err != nil {
+			if errors.Is(err, shared.ErrNotFound) {
+				return shared.NewDomainError("PREREQUISITE_NOT_FOUND", "Prerequisite flag not found: "+prerequisite.FlagKey)
+			}
+			s.logger.Error("Failed to find prerequisite flag", zap.Error(err))
+			return shared.NewDomainError("INTERNAL_ERROR", "Failed to find prerequisite flag")
+		}
+	}
+
+	return featureflag.CheckPrerequisiteCycle(ctx, flag.Key, flag.GetPrerequisites(), s.findPrerequisites)
+}
+
+// findPrerequisites returns the prerequisites of the flag with the given key
+func (s *FlagService) findPrerequisites(ctx context.Context, key string) ([]featureflag.Prerequisite, error) {
+	flag, err := s.flagRepo.FindByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, nil
+		}
+		s.logger.Error("Failed to find flag", zap.Error(err))
+		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to check flag prerequisites")
+	}
+	return flag.GetPrerequisites(), nil
+}
+
 // createAuditLog creates an audit log entry
 func (s *FlagService) createAuditLog(ctx context.Context, flagKey string, action featureflag.AuditAction, oldValue, newValue map[string]any, auditCtx AuditContext) error {
 	auditLog, err := featureflag.NewFlagAuditLog(
@@ -474,6 +526,10 @@ func (s *FlagService) GetAuditLogs(ctx context.Context, flagKey string, filter d
 		OrderDir: "desc",
 	}
 
+	if filter.UsesCursor() {
+		return s.getAuditLogsByCursor(ctx, flagKey, filter, domainFilter)
+	}
+
 	// Get audit logs
 	logs, err := s.auditLogRepo.FindByFlagKey(ctx, flagKey, domainFilter)
 	if err != nil {
@@ -491,6 +547,34 @@ func (s *FlagService) GetAuditLogs(ctx context.Context, flagKey string, filter d
 	return dto.ToAuditLogListResponse(logs, total, filter.Page, filter.PageSize), nil
 }
 
+// getAuditLogsByCursor returns one keyset page of audit logs, newest first, without counting the total
+func (s *FlagService) getAuditLogsByCursor(ctx context.Context, flagKey string, filter dto.AuditLogListFilter, domainFilter shared.Filter) (*dto.AuditLogListResponse, error) {
+	domainFilter.Keyset = true
+	if filter.Cursor != "" {
+		after, err := shared.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		domainFilter.After = after
+	}
+
+	logs, err := s.auditLogRepo.FindByFlagKey(ctx, flagKey, domainFilter)
+	if err != nil {
+		if errors.Is(err, shared.ErrInvalidCursor) {
+			return nil, err
+		}
+		s.logger.Error("Failed to get audit logs", zap.Error(err))
+		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to get audit logs")
+	}
+
+	logs, nextCursor := shared.NextCursor(logs, filter.PageSize, domainFilter.OrderDir, func(log featureflag.FlagAuditLog) (time.Time, uuid.UUID) {
+		return log.CreatedAt, log.ID
+	})
+	result := dto.ToAuditLogListResponse(logs, 0, 0, filter.PageSize)
+	result.NextCursor = nextCursor
+	return result, nil
+}
+
 // flagToMap converts a flag to a map for audit logging
 func flagToMap(flag *featureflag.FeatureFlag) map[string]any {
 	return map[string]any{
@@ -501,6 +585,7 @@ func flagToMap(flag *featureflag.FeatureFlag) map[string]any {
 		"type":          string(flag.Type),
 		"status":        string(flag.Status),
 		"default_value": flag.DefaultValue,
+		"prerequisites": flag.Prerequisites,
 		"version":       flag.Version,
 	}
 }
@@ -184,6 +184,22 @@ func (m *MockOutboxRepository) DeleteOlderThan(ctx context.Context, before time.
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockOutboxRepository) FindOldestDead(ctx context.Context, limit int) ([]*shared.OutboxEntry, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shared.OutboxEntry), args.Error(1)
+}
+
+func (m *MockOutboxRepository) FindForReplay(ctx context.Context, filter shared.OutboxReplayFilter) ([]*shared.OutboxEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shared.OutboxEntry), args.Error(1)
+}
+
 func (m *MockOutboxRepository) CountByStatus(ctx context.Context) (map[shared.OutboxStatus]int64, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(map[shared.OutboxStatus]int64), args.Error(1)
@@ -1327,3 +1343,94 @@ func TestFlagService_GetAuditLogs_CountError(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "INTERNAL_ERROR", domainErr.Code)
 }
+
+func TestFlagService_CreateFlag_WithPrerequisites(t *testing.T) {
+	mockFlagRepo := new(MockFeatureFlagRepository)
+	mockAuditRepo := new(MockFlagAuditLogRepository)
+	mockOutboxRepo := new(MockOutboxRepository)
+	logger := newTestLogger()
+
+	service := NewFlagService(mockFlagRepo, mockAuditRepo, mockOutboxRepo, logger)
+
+	ctx := context.Background()
+	req := dto.CreateFlagRequest{
+		Key:           "new-checkout",
+		Name:          "New Checkout",
+		Type:          "boolean",
+		Prerequisites: []dto.PrerequisiteDTO{{FlagKey: "new-cart"}},
+	}
+
+	mockFlagRepo.On("ExistsByKey", ctx, "new-checkout").Return(false, nil)
+	mockFlagRepo.On("FindByKey", ctx, "new-cart").Return(createTestFlag("new-cart", "New Cart"), nil)
+	mockFlagRepo.On("Create", ctx, mock.AnythingOfType("*featureflag.FeatureFlag")).Return(nil)
+	mockAuditRepo.On("Create", ctx, mock.AnythingOfType("*featureflag.FlagAuditLog")).Return(nil)
+	mockOutboxRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+	result, err := service.CreateFlag(ctx, req, AuditContext{UserID: newTestUserID()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.PrerequisiteDTO{{FlagKey: "new-cart"}}, result.Prerequisites)
+	mockFlagRepo.AssertExpectations(t)
+}
+
+func TestFlagService_CreateFlag_PrerequisiteNotFound(t *testing.T) {
+	mockFlagRepo := new(MockFeatureFlagRepository)
+	mockAuditRepo := new(MockFlagAuditLogRepository)
+	mockOutboxRepo := new(MockOutboxRepository)
+	logger := newTestLogger()
+
+	service := NewFlagService(mockFlagRepo, mockAuditRepo, mockOutboxRepo, logger)
+
+	ctx := context.Background()
+	req := dto.CreateFlagRequest{
+		Key:           "new-checkout",
+		Name:          "New Checkout",
+		Type:          "boolean",
+		Prerequisites: []dto.PrerequisiteDTO{{FlagKey: "new-cart"}},
+	}
+
+	mockFlagRepo.On("ExistsByKey", ctx, "new-checkout").Return(false, nil)
+	mockFlagRepo.On("FindByKey", ctx, "new-cart").Return(nil, shared.ErrNotFound)
+
+	result, err := service.CreateFlag(ctx, req, AuditContext{UserID: newTestUserID()})
+
+	assert.Nil(t, result)
+	domainErr, ok := err.(*shared.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "PREREQUISITE_NOT_FOUND", domainErr.Code)
+	mockFlagRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestFlagService_UpdateFlag_PrerequisiteCycle(t *testing.T) {
+	mockFlagRepo := new(MockFeatureFlagRepository)
+	mockAuditRepo := new(MockFlagAuditLogRepository)
+	mockOutboxRepo := new(MockOutboxRepository)
+	logger := newTestLogger()
+
+	service := NewFlagService(mockFlagRepo, mockAuditRepo, mockOutboxRepo, logger)
+
+	ctx := context.Background()
+	// flag-b already depends on flag-c, which depends on flag-a
+	flagA := createTestFlag("flag-a", "Flag A")
+	flagB := createTestFlag("flag-b", "Flag B")
+	_ = flagB.SetPrerequisites([]featureflag.Prerequisite{{FlagKey: "flag-c"}}, nil)
+	flagC := createTestFlag("flag-c", "Flag C")
+	_ = flagC.SetPrerequisites([]featureflag.Prerequisite{{FlagKey: "flag-a"}}, nil)
+
+	req := dto.UpdateFlagRequest{
+		Prerequisites: &[]dto.PrerequisiteDTO{{FlagKey: "flag-b"}},
+	}
+
+	mockFlagRepo.On("FindByKey", ctx, "flag-a").Return(flagA, nil)
+	mockFlagRepo.On("FindByKey", ctx, "flag-b").Return(flagB, nil)
+	mockFlagRepo.On("FindByKey", ctx, "flag-c").Return(flagC, nil)
+
+	result, err := service.UpdateFlag(ctx, "flag-a", req, AuditContext{UserID: newTestUserID()})
+
+	assert.Nil(t, result)
+	domainErr, ok := err.(*shared.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "PREREQUISITE_CYCLE", domainErr.Code)
+	assert.Contains(t, domainErr.Message, "flag-a -> flag-b -> flag-c -> flag-a")
+	mockFlagRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -94,13 +94,9 @@ func (s *BalancePaymentService) ProcessBalancePayment(
 			return
 		}
 
-		// Check sufficient balance
-		if customer.Balance.LessThan(req.Amount) {
-			err := shared.NewDomainError(
-				"INSUFFICIENT_BALANCE",
-				fmt.Sprintf("Insufficient balance: available %.2f, required %.2f",
-					customer.Balance.InexactFloat64(), req.Amount.InexactFloat64()),
-			)
+		// Check sufficient balance, including the customer's unused overdraft
+		if spendable := customer.SpendableBalance(); spendable.LessThan(req.Amount) {
+			err := shared.NewInsufficientBalanceError(spendable, req.Amount)
 			telemetry.RecordError(span, err)
 			operationErr = err
 			return
@@ -198,12 +194,8 @@ func (s *BalancePaymentService) ValidateBalancePayment(
 		return shared.NewDomainError("CUSTOMER_NOT_FOUND", "Customer not found")
 	}
 
-	if customer.Balance.LessThan(amount) {
-		return shared.NewDomainError(
-			"INSUFFICIENT_BALANCE",
-			fmt.Sprintf("Insufficient balance: available %.2f, required %.2f",
-				customer.Balance.InexactFloat64(), amount.InexactFloat64()),
-		)
+	if spendable := customer.SpendableBalance(); spendable.LessThan(amount) {
+		return shared.NewInsufficientBalanceError(spendable, amount)
 	}
 
 	return nil
@@ -225,17 +217,20 @@ func (s *BalancePaymentService) GetCustomerBalance(
 	return customer.Balance, nil
 }
 
-// HasSufficientBalance checks if a customer has sufficient balance for a payment
+// HasSufficientBalance checks if a customer can pay the amount from balance, including the unused overdraft
 func (s *BalancePaymentService) HasSufficientBalance(
 	ctx context.Context,
 	tenantID, customerID uuid.UUID,
 	amount decimal.Decimal,
 ) (bool, error) {
-	balance, err := s.GetCustomerBalance(ctx, tenantID, customerID)
+	customer, err := s.customerRepo.FindByIDForTenant(ctx, tenantID, customerID)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("failed to get customer: %w", err)
+	}
+	if customer == nil {
+		return false, shared.NewDomainError("CUSTOMER_NOT_FOUND", "Customer not found")
 	}
-	return balance.GreaterThanOrEqual(amount), nil
+	return customer.SpendableBalance().GreaterThanOrEqual(amount), nil
 }
 
 // ProcessReceiptVoucherBalancePayment processes a balance payment for a receipt voucher
@@ -41,6 +41,14 @@ func (m *MockCustomerRepositoryForBalance) FindByIDForTenant(ctx context.Context
 	return args.Get(0).(*partner.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepositoryForBalance) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Customer, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*partner.Customer), args.Error(1)
+}
+
 func (m *MockCustomerRepositoryForBalance) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Customer, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -110,6 +118,14 @@ func (m *MockCustomerRepositoryForBalance) FindWithPositiveBalance(ctx context.C
 	return args.Get(0).([]partner.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepositoryForBalance) FindLevelPeriodEnded(ctx context.Context, asOf time.Time) ([]partner.Customer, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]partner.Customer), args.Error(1)
+}
+
 func (m *MockCustomerRepositoryForBalance) Save(ctx context.Context, customer *partner.Customer) error {
 	args := m.Called(ctx, customer)
 	return args.Error(0)
@@ -135,6 +151,11 @@ func (m *MockCustomerRepositoryForBalance) DeleteForTenant(ctx context.Context,
 	return args.Error(0)
 }
 
+func (m *MockCustomerRepositoryForBalance) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 func (m *MockCustomerRepositoryForBalance) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
@@ -0,0 +1,136 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CashFlowForecastRequest represents a request for a projected cash position
+type CashFlowForecastRequest struct {
+	Weeks          int             `json:"weeks"`           // Number of weekly periods; 0 uses the default of 12
+	OpeningBalance decimal.Decimal `json:"opening_balance"` // Cash on hand at the start of the forecast
+	Currency       string          `json:"currency"`        // Currency of OpeningBalance; empty uses CNY
+}
+
+// CashFlowForecastWeekResponse represents the expected cash movement of one week
+type CashFlowForecastWeekResponse struct {
+	Week           int             `json:"week"`
+	StartDate      time.Time       `json:"start_date"`
+	EndDate        time.Time       `json:"end_date"` // Exclusive
+	Inflow         decimal.Decimal `json:"inflow"`
+	Outflow        decimal.Decimal `json:"outflow"`
+	InflowCount    int64           `json:"inflow_count"`
+	OutflowCount   int64           `json:"outflow_count"`
+	Net            decimal.Decimal `json:"net"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+}
+
+// CashFlowForecastTotalsResponse represents expected amounts outside the weekly schedule
+type CashFlowForecastTotalsResponse struct {
+	Inflow       decimal.Decimal `json:"inflow"`
+	Outflow      decimal.Decimal `json:"outflow"`
+	InflowCount  int64           `json:"inflow_count"`
+	OutflowCount int64           `json:"outflow_count"`
+}
+
+// CashFlowForecastResponse represents projected cash positions based on receivable and payable due dates
+type CashFlowForecastResponse struct {
+	StartDate  time.Time                          `json:"start_date"`
+	Currencies []CurrencyCashFlowForecastResponse `json:"currencies"` // One forecast per currency, in alphabetical order
+}
+
+// CurrencyCashFlowForecastResponse represents the projected cash position in one currency
+type CurrencyCashFlowForecastResponse struct {
+	Currency         string                         `json:"currency"`
+	OpeningBalance   decimal.Decimal                `json:"opening_balance"`
+	Weeks            []CashFlowForecastWeekResponse `json:"weeks"`
+	TotalInflow      decimal.Decimal                `json:"total_inflow"`
+	TotalOutflow     decimal.Decimal                `json:"total_outflow"`
+	ProjectedBalance decimal.Decimal                `json:"projected_balance"`
+	Overdue          CashFlowForecastTotalsResponse `json:"overdue"`        // Already past due; included in the first week
+	BeyondHorizon    CashFlowForecastTotalsResponse `json:"beyond_horizon"` // Due after the last week; not projected
+	Unscheduled      CashFlowForecastTotalsResponse `json:"unscheduled"`    // No due date; not projected
+}
+
+// GetCashFlowForecast projects the cash position in each currency over the coming weeks.
+// Open receivables are scheduled as inflows and open payables as outflows by their due dates.
+func (s *FinanceService) GetCashFlowForecast(ctx context.Context, tenantID uuid.UUID, req CashFlowForecastRequest) (*CashFlowForecastResponse, error) {
+	weeks := req.Weeks
+	if weeks == 0 {
+		weeks = finance.DefaultCashFlowForecastWeeks
+	}
+
+	inflows, err := s.receivableRepo.FindAgingEntries(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	outflows, err := s.payableRepo.FindAgingEntries(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := valueobject.Currency(req.Currency)
+	if currency == "" {
+		currency = valueobject.DefaultCurrency
+	}
+	openingBalances := map[valueobject.Currency]decimal.Decimal{currency: req.OpeningBalance}
+
+	// The opening balance currency is always forecast, so there is at least one forecast
+	forecasts, err := finance.ForecastCashFlowByCurrency(time.Now(), weeks, openingBalances, inflows, outflows)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CashFlowForecastResponse{
+		StartDate:  forecasts[0].StartDate,
+		Currencies: make([]CurrencyCashFlowForecastResponse, len(forecasts)),
+	}
+	for i, forecast := range forecasts {
+		response.Currencies[i] = toCurrencyCashFlowForecastResponse(forecast)
+	}
+	return response, nil
+}
+
+func toCurrencyCashFlowForecastResponse(f *finance.CashFlowForecast) CurrencyCashFlowForecastResponse {
+	weeks := make([]CashFlowForecastWeekResponse, len(f.Weeks))
+	for i, w := range f.Weeks {
+		weeks[i] = CashFlowForecastWeekResponse{
+			Week:           w.Week,
+			StartDate:      w.StartDate,
+			EndDate:        w.EndDate,
+			Inflow:         w.Inflow,
+			Outflow:        w.Outflow,
+			InflowCount:    w.InflowCount,
+			OutflowCount:   w.OutflowCount,
+			Net:            w.Net,
+			ClosingBalance: w.ClosingBalance,
+		}
+	}
+
+	return CurrencyCashFlowForecastResponse{
+		Currency:         string(f.Currency),
+		OpeningBalance:   f.OpeningBalance,
+		Weeks:            weeks,
+		TotalInflow:      f.TotalInflow,
+		TotalOutflow:     f.TotalOutflow,
+		ProjectedBalance: f.ProjectedBalance,
+		Overdue:          toCashFlowForecastTotalsResponse(f.Overdue),
+		BeyondHorizon:    toCashFlowForecastTotalsResponse(f.BeyondHorizon),
+		Unscheduled:      toCashFlowForecastTotalsResponse(f.Unscheduled),
+	}
+}
+
+func toCashFlowForecastTotalsResponse(t finance.CashFlowForecastTotals) CashFlowForecastTotalsResponse {
+	return CashFlowForecastTotalsResponse{
+		Inflow:       t.Inflow,
+		Outflow:      t.Outflow,
+		InflowCount:  t.InflowCount,
+		OutflowCount: t.OutflowCount,
+	}
+}
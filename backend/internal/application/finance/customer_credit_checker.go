@@ -0,0 +1,90 @@
+package finance
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CustomerReader provides read access to customers for finance operations
+type CustomerReader interface {
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Customer, error)
+}
+
+// CreditTenantReader provides the tenant configuration that controls credit checks
+type CreditTenantReader interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error)
+}
+
+// CustomerCreditChecker enforces customer credit limits against outstanding receivables
+// This service can be used by the trade context to block shipments
+// without directly depending on the partner and finance domains
+type CustomerCreditChecker struct {
+	customerReader CustomerReader
+	receivableRepo finance.AccountReceivableRepository
+	tenantReader   CreditTenantReader
+}
+
+// NewCustomerCreditChecker creates a new CustomerCreditChecker
+// tenantReader may be nil, in which case credit control is enabled for all tenants
+func NewCustomerCreditChecker(
+	customerReader CustomerReader,
+	receivableRepo finance.AccountReceivableRepository,
+	tenantReader CreditTenantReader,
+) *CustomerCreditChecker {
+	return &CustomerCreditChecker{
+		customerReader: customerReader,
+		receivableRepo: receivableRepo,
+		tenantReader:   tenantReader,
+	}
+}
+
+// CheckCredit returns a *trade.CreditLimitExceededError if the customer's outstanding receivables
+// plus orderAmount exceed their credit limit.
+// Customers without a credit limit and tenants with credit control disabled are not checked.
+func (c *CustomerCreditChecker) CheckCredit(ctx context.Context, tenantID, customerID uuid.UUID, orderAmount decimal.Decimal) error {
+	enabled, err := c.isCreditControlEnabled(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	customer, err := c.customerReader.FindByIDForTenant(ctx, tenantID, customerID)
+	if err != nil {
+		return err
+	}
+	if !customer.HasCreditLimit() {
+		return nil
+	}
+
+	outstanding, err := c.receivableRepo.SumOutstandingByCustomer(ctx, tenantID, customerID)
+	if err != nil {
+		return err
+	}
+
+	if outstanding.Add(orderAmount).GreaterThan(customer.CreditLimit) {
+		return trade.NewCreditLimitExceededError(customerID, customer.CreditLimit, outstanding, orderAmount)
+	}
+
+	return nil
+}
+
+// isCreditControlEnabled returns whether the tenant has credit control enabled
+func (c *CustomerCreditChecker) isCreditControlEnabled(ctx context.Context, tenantID uuid.UUID) (bool, error) {
+	if c.tenantReader == nil {
+		return true, nil
+	}
+
+	tenant, err := c.tenantReader.FindByID(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+	return tenant.Config.CreditControlEnabled, nil
+}
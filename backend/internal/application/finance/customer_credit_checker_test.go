@@ -0,0 +1,123 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCustomerReader is a mock implementation of CustomerReader
+type MockCustomerReader struct {
+	mock.Mock
+}
+
+func (m *MockCustomerReader) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Customer, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*partner.Customer), args.Error(1)
+}
+
+// MockCreditTenantReader is a mock implementation of CreditTenantReader
+type MockCreditTenantReader struct {
+	mock.Mock
+}
+
+func (m *MockCreditTenantReader) FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*identity.Tenant), args.Error(1)
+}
+
+func newCreditTestCustomer(t *testing.T, tenantID uuid.UUID, creditLimit int64) *partner.Customer {
+	customer, err := partner.NewCustomer(tenantID, "C001", "Credit Customer", partner.CustomerTypeOrganization)
+	require.NoError(t, err)
+	require.NoError(t, customer.SetCreditLimit(decimal.NewFromInt(creditLimit)))
+	return customer
+}
+
+func newCreditTestTenant(t *testing.T, creditControlEnabled bool) *identity.Tenant {
+	tenant, err := identity.NewTenant("T001", "Credit Tenant")
+	require.NoError(t, err)
+	tenant.Config.CreditControlEnabled = creditControlEnabled
+	return tenant
+}
+
+func TestCustomerCreditChecker_CheckCredit(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	t.Run("blocks when outstanding plus order exceeds limit", func(t *testing.T) {
+		customers := new(MockCustomerReader)
+		receivables := new(MockAccountReceivableRepository)
+		tenants := new(MockCreditTenantReader)
+		checker := NewCustomerCreditChecker(customers, receivables, tenants)
+
+		customer := newCreditTestCustomer(t, tenantID, 100000)
+		tenants.On("FindByID", ctx, tenantID).Return(newCreditTestTenant(t, true), nil)
+		customers.On("FindByIDForTenant", ctx, tenantID, customer.ID).Return(customer, nil)
+		receivables.On("SumOutstandingByCustomer", ctx, tenantID, customer.ID).Return(decimal.NewFromInt(140000), nil)
+
+		err := checker.CheckCredit(ctx, tenantID, customer.ID, decimal.NewFromInt(10000))
+
+		var creditErr *trade.CreditLimitExceededError
+		require.True(t, errors.As(err, &creditErr))
+		assert.True(t, creditErr.CurrentOutstanding.Equal(decimal.NewFromInt(140000)))
+		assert.True(t, creditErr.CreditLimit.Equal(decimal.NewFromInt(100000)))
+		assert.True(t, creditErr.Overage.Equal(decimal.NewFromInt(50000)))
+	})
+
+	t.Run("allows order that reaches the limit exactly", func(t *testing.T) {
+		customers := new(MockCustomerReader)
+		receivables := new(MockAccountReceivableRepository)
+		checker := NewCustomerCreditChecker(customers, receivables, nil)
+
+		customer := newCreditTestCustomer(t, tenantID, 1000)
+		customers.On("FindByIDForTenant", ctx, tenantID, customer.ID).Return(customer, nil)
+		receivables.On("SumOutstandingByCustomer", ctx, tenantID, customer.ID).Return(decimal.NewFromInt(600), nil)
+
+		err := checker.CheckCredit(ctx, tenantID, customer.ID, decimal.NewFromInt(400))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips customers without a credit limit", func(t *testing.T) {
+		customers := new(MockCustomerReader)
+		receivables := new(MockAccountReceivableRepository)
+		checker := NewCustomerCreditChecker(customers, receivables, nil)
+
+		customer := newCreditTestCustomer(t, tenantID, 0)
+		customers.On("FindByIDForTenant", ctx, tenantID, customer.ID).Return(customer, nil)
+
+		err := checker.CheckCredit(ctx, tenantID, customer.ID, decimal.NewFromInt(1000000))
+
+		assert.NoError(t, err)
+		receivables.AssertNotCalled(t, "SumOutstandingByCustomer", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("skips tenants with credit control disabled", func(t *testing.T) {
+		customers := new(MockCustomerReader)
+		receivables := new(MockAccountReceivableRepository)
+		tenants := new(MockCreditTenantReader)
+		checker := NewCustomerCreditChecker(customers, receivables, tenants)
+
+		tenants.On("FindByID", ctx, tenantID).Return(newCreditTestTenant(t, false), nil)
+
+		err := checker.CheckCredit(ctx, tenantID, uuid.New(), decimal.NewFromInt(1000000))
+
+		assert.NoError(t, err)
+		customers.AssertNotCalled(t, "FindByIDForTenant", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,139 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/printing"
+	"github.com/google/uuid"
+)
+
+// ExpenseAttachmentStorage stores the files attached to expenses.
+// printing.FileSystemStorage satisfies it.
+type ExpenseAttachmentStorage interface {
+	Store(ctx context.Context, req *printing.StoreRequest) (*printing.StoreResult, error)
+	Delete(ctx context.Context, path string) error
+}
+
+// expenseAttachmentExtensions maps allowed attachment content types to stored file extensions
+var expenseAttachmentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+}
+
+// SetAttachmentStorage sets the storage used for expense attachment files
+func (s *ExpenseIncomeService) SetAttachmentStorage(storage ExpenseAttachmentStorage) {
+	s.attachmentStorage = storage
+}
+
+// ExpenseAttachmentResponse represents a supporting document attached to an expense
+type ExpenseAttachmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	FileSize    int64     `json:"file_size"`
+	StorageKey  string    `json:"storage_key"`
+	UploadedBy  uuid.UUID `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// AddExpenseAttachmentRequest represents an uploaded supporting document
+type AddExpenseAttachmentRequest struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+	UploadedBy  uuid.UUID
+}
+
+// AddExpenseAttachment stores a supporting document and attaches it to a draft or pending expense
+func (s *ExpenseIncomeService) AddExpenseAttachment(ctx context.Context, tenantID, expenseID uuid.UUID, req AddExpenseAttachmentRequest) (*ExpenseAttachmentResponse, error) {
+	if s.attachmentStorage == nil {
+		return nil, shared.NewDomainError("STORAGE_UNAVAILABLE", "Attachment storage is not configured")
+	}
+
+	expense, err := s.expenseRepo.FindByIDForTenant(ctx, tenantID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if expense == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Expense record not found")
+	}
+
+	attachment, err := finance.NewExpenseAttachment(req.FileName, req.ContentType, int64(len(req.Data)), req.UploadedBy)
+	if err != nil {
+		return nil, err
+	}
+	// Check before storing the file so a rejected upload leaves nothing behind
+	if !expense.Status.CanModifyAttachments() {
+		return nil, shared.NewDomainError("INVALID_STATE", "Can only add attachments to expense in draft or pending status")
+	}
+	if len(expense.Attachments) >= finance.MaxExpenseAttachments {
+		return nil, shared.NewDomainError("TOO_MANY_ATTACHMENTS", "Expense already has the maximum number of attachments")
+	}
+
+	stored, err := s.attachmentStorage.Store(ctx, &printing.StoreRequest{
+		TenantID:  tenantID,
+		JobID:     attachment.ID,
+		PDFData:   req.Data,
+		Extension: expenseAttachmentExtensions[attachment.ContentType],
+	})
+	if err != nil {
+		return nil, err
+	}
+	attachment.StorageKey = stored.Path
+
+	if err := expense.AddAttachment(attachment); err != nil {
+		_ = s.attachmentStorage.Delete(ctx, stored.Path)
+		return nil, err
+	}
+	if err := s.expenseRepo.SaveWithLock(ctx, expense); err != nil {
+		_ = s.attachmentStorage.Delete(ctx, stored.Path)
+		return nil, err
+	}
+
+	resp := toExpenseAttachmentResponse(*attachment)
+	return &resp, nil
+}
+
+// RemoveExpenseAttachment detaches a supporting document from a draft or pending expense and deletes its file.
+// Attachments of approved expenses are kept as the audit trail of the approval.
+func (s *ExpenseIncomeService) RemoveExpenseAttachment(ctx context.Context, tenantID, expenseID, attachmentID uuid.UUID) error {
+	expense, err := s.expenseRepo.FindByIDForTenant(ctx, tenantID, expenseID)
+	if err != nil {
+		return err
+	}
+	if expense == nil {
+		return shared.NewDomainError("NOT_FOUND", "Expense record not found")
+	}
+
+	removed, err := expense.RemoveAttachment(attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.expenseRepo.SaveWithLock(ctx, expense); err != nil {
+		return err
+	}
+
+	// The expense no longer references the file, so a failed delete only leaves an orphaned file
+	if s.attachmentStorage != nil {
+		_ = s.attachmentStorage.Delete(ctx, removed.StorageKey)
+	}
+	return nil
+}
+
+func toExpenseAttachmentResponse(a finance.ExpenseAttachment) ExpenseAttachmentResponse {
+	return ExpenseAttachmentResponse{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		FileSize:    a.FileSize,
+		StorageKey:  a.StorageKey,
+		UploadedBy:  a.UploadedBy,
+		UploadedAt:  a.UploadedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/erp/backend/internal/infrastructure/printing"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockExpenseAttachmentStorage is a mock implementation of ExpenseAttachmentStorage
+type MockExpenseAttachmentStorage struct {
+	mock.Mock
+}
+
+func (m *MockExpenseAttachmentStorage) Store(ctx context.Context, req *printing.StoreRequest) (*printing.StoreResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*printing.StoreResult), args.Error(1)
+}
+
+func (m *MockExpenseAttachmentStorage) Delete(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
+	return args.Error(0)
+}
+
+func TestExpenseIncomeService_Attachments(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	newService := func(t *testing.T) (*ExpenseIncomeService, *finance.ExpenseRecord, *MockExpenseRecordRepository, *MockExpenseAttachmentStorage) {
+		expense, err := finance.NewExpenseRecord(tenantID, "EXP-001", finance.ExpenseCategoryTravel,
+			valueobject.NewMoneyCNY(decimal.NewFromInt(800)), "Train tickets", time.Now())
+		require.NoError(t, err)
+
+		expenseRepo := new(MockExpenseRecordRepository)
+		expenseRepo.On("FindByIDForTenant", ctx, tenantID, expense.ID).Return(expense, nil)
+		storage := new(MockExpenseAttachmentStorage)
+
+		svc := NewExpenseIncomeService(expenseRepo, nil, nil, nil)
+		svc.SetAttachmentStorage(storage)
+		return svc, expense, expenseRepo, storage
+	}
+
+	upload := AddExpenseAttachmentRequest{
+		FileName:    "ticket.png",
+		ContentType: "image/png",
+		Data:        []byte("png content"),
+		UploadedBy:  userID,
+	}
+
+	t.Run("stores the file and attaches it", func(t *testing.T) {
+		svc, expense, expenseRepo, storage := newService(t)
+		storage.On("Store", ctx, mock.MatchedBy(func(req *printing.StoreRequest) bool {
+			return req.TenantID == tenantID && req.Extension == ".png"
+		})).Return(&printing.StoreResult{Path: "tenant/ticket.png"}, nil)
+		expenseRepo.On("SaveWithLock", ctx, expense).Return(nil)
+
+		attachment, err := svc.AddExpenseAttachment(ctx, tenantID, expense.ID, upload)
+		require.NoError(t, err)
+
+		assert.Equal(t, "tenant/ticket.png", attachment.StorageKey)
+		assert.Equal(t, userID, attachment.UploadedBy)
+		require.Len(t, expense.Attachments, 1)
+		assert.Equal(t, attachment.ID, expense.Attachments[0].ID)
+	})
+
+	t.Run("deletes the stored file when saving fails", func(t *testing.T) {
+		svc, expense, expenseRepo, storage := newService(t)
+		storage.On("Store", ctx, mock.Anything).Return(&printing.StoreResult{Path: "tenant/ticket.png"}, nil)
+		storage.On("Delete", ctx, "tenant/ticket.png").Return(nil)
+		expenseRepo.On("SaveWithLock", ctx, expense).Return(shared.ErrConcurrencyConflict)
+
+		_, err := svc.AddExpenseAttachment(ctx, tenantID, expense.ID, upload)
+
+		assert.ErrorIs(t, err, shared.ErrConcurrencyConflict)
+		storage.AssertCalled(t, "Delete", ctx, "tenant/ticket.png")
+	})
+
+	t.Run("rejects upload to an approved expense without storing it", func(t *testing.T) {
+		svc, expense, _, storage := newService(t)
+		require.NoError(t, expense.Submit(userID, 1))
+		require.NoError(t, expense.Approve(uuid.New(), ""))
+
+		_, err := svc.AddExpenseAttachment(ctx, tenantID, expense.ID, upload)
+
+		require.Error(t, err)
+		storage.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+	})
+
+	t.Run("removes the attachment and its file", func(t *testing.T) {
+		svc, expense, expenseRepo, storage := newService(t)
+		attachment, err := finance.NewExpenseAttachment("ticket.png", "image/png", 11, userID)
+		require.NoError(t, err)
+		attachment.StorageKey = "tenant/ticket.png"
+		require.NoError(t, expense.AddAttachment(attachment))
+		expenseRepo.On("SaveWithLock", ctx, expense).Return(nil)
+		storage.On("Delete", ctx, "tenant/ticket.png").Return(errors.New("disk unavailable"))
+
+		err = svc.RemoveExpenseAttachment(ctx, tenantID, expense.ID, attachment.ID)
+
+		require.NoError(t, err)
+		assert.Empty(t, expense.Attachments)
+		storage.AssertCalled(t, "Delete", ctx, "tenant/ticket.png")
+	})
+
+	t.Run("rejects removing an attachment of an approved expense", func(t *testing.T) {
+		svc, expense, expenseRepo, storage := newService(t)
+		attachment, err := finance.NewExpenseAttachment("ticket.png", "image/png", 11, userID)
+		require.NoError(t, err)
+		attachment.StorageKey = "tenant/ticket.png"
+		require.NoError(t, expense.AddAttachment(attachment))
+		require.NoError(t, expense.Submit(userID, 1))
+		require.NoError(t, expense.Approve(uuid.New(), ""))
+
+		err = svc.RemoveExpenseAttachment(ctx, tenantID, expense.ID, attachment.ID)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_STATE", domainErr.Code)
+		assert.Len(t, expense.Attachments, 1)
+		expenseRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+		storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
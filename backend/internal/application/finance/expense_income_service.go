@@ -5,18 +5,26 @@ import (
 	"time"
 
 	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
+// ExpenseApprovalTenantReader provides the tenant configuration that controls expense approval chains
+type ExpenseApprovalTenantReader interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error)
+}
+
 // ExpenseIncomeService provides application-level expense and income operations
 type ExpenseIncomeService struct {
 	expenseRepo        finance.ExpenseRecordRepository
 	incomeRepo         finance.OtherIncomeRecordRepository
 	receiptVoucherRepo finance.ReceiptVoucherRepository
 	paymentVoucherRepo finance.PaymentVoucherRepository
+	tenantReader       ExpenseApprovalTenantReader // Optional: without it every expense needs a single approval
+	attachmentStorage  ExpenseAttachmentStorage    // Optional: without it attachments cannot be uploaded
 }
 
 // NewExpenseIncomeService creates a new ExpenseIncomeService
@@ -34,6 +42,11 @@ func NewExpenseIncomeService(
 	}
 }
 
+// SetTenantReader sets the tenant reader used to look up expense approval thresholds
+func (s *ExpenseIncomeService) SetTenantReader(reader ExpenseApprovalTenantReader) {
+	s.tenantReader = reader
+}
+
 // ===================== Expense Record Operations =====================
 
 // ExpenseRecordResponse represents an expense record in API responses
@@ -63,6 +76,22 @@ type ExpenseRecordResponse struct {
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
 	Version         int             `json:"version"`
+
+	// Approval chain; only meaningful once the expense has been submitted
+	RequiredApprovals    int                       `json:"required_approvals"`
+	CurrentApprovalLevel int                       `json:"current_approval_level"`
+	RemainingApprovals   int                       `json:"remaining_approvals"`
+	Approvals            []ExpenseApprovalResponse `json:"approvals,omitempty"`
+
+	Attachments []ExpenseAttachmentResponse `json:"attachments,omitempty"`
+}
+
+// ExpenseApprovalResponse represents one signed-off approval level of an expense
+type ExpenseApprovalResponse struct {
+	Level      int       `json:"level"`
+	ApprovedBy uuid.UUID `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+	Remark     string    `json:"remark,omitempty"`
 }
 
 // CreateExpenseRecordRequest represents a request to create an expense record
@@ -229,7 +258,12 @@ func (s *ExpenseIncomeService) SubmitExpenseRecord(ctx context.Context, tenantID
 		return nil, shared.NewDomainError("NOT_FOUND", "Expense record not found")
 	}
 
-	if err := expense.Submit(userID); err != nil {
+	requiredApprovals, err := s.requiredExpenseApprovals(ctx, tenantID, expense)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := expense.Submit(userID, requiredApprovals); err != nil {
 		return nil, err
 	}
 
@@ -240,7 +274,21 @@ func (s *ExpenseIncomeService) SubmitExpenseRecord(ctx context.Context, tenantID
 	return toExpenseRecordResponse(expense), nil
 }
 
-// ApproveExpenseRecord approves an expense record
+// requiredExpenseApprovals computes the approval levels an expense needs from the tenant's thresholds
+func (s *ExpenseIncomeService) requiredExpenseApprovals(ctx context.Context, tenantID uuid.UUID, expense *finance.ExpenseRecord) (int, error) {
+	if s.tenantReader == nil {
+		return 1, nil
+	}
+
+	tenant, err := s.tenantReader.FindByID(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return finance.RequiredExpenseApprovalLevels(expense.Amount, tenant.Config.ExpenseApprovalThresholds), nil
+}
+
+// ApproveExpenseRecord signs off the current approval level of an expense record.
+// The expense is only approved once all required levels have signed off.
 func (s *ExpenseIncomeService) ApproveExpenseRecord(ctx context.Context, tenantID, expenseID, userID uuid.UUID, remark string) (*ExpenseRecordResponse, error) {
 	expense, err := s.expenseRepo.FindByIDForTenant(ctx, tenantID, expenseID)
 	if err != nil {
@@ -909,6 +957,21 @@ func toExpenseRecordResponse(e *finance.ExpenseRecord) *ExpenseRecordResponse {
 		paymentMethod = &pm
 	}
 
+	approvals := make([]ExpenseApprovalResponse, len(e.Approvals))
+	for i, a := range e.Approvals {
+		approvals[i] = ExpenseApprovalResponse{
+			Level:      a.Level,
+			ApprovedBy: a.ApprovedBy,
+			ApprovedAt: a.ApprovedAt,
+			Remark:     a.Remark,
+		}
+	}
+
+	attachments := make([]ExpenseAttachmentResponse, len(e.Attachments))
+	for i, a := range e.Attachments {
+		attachments[i] = toExpenseAttachmentResponse(a)
+	}
+
 	return &ExpenseRecordResponse{
 		ID:              e.ID,
 		TenantID:        e.TenantID,
@@ -935,6 +998,13 @@ func toExpenseRecordResponse(e *finance.ExpenseRecord) *ExpenseRecordResponse {
 		CreatedAt:       e.CreatedAt,
 		UpdatedAt:       e.UpdatedAt,
 		Version:         e.Version,
+
+		RequiredApprovals:    e.GetRequiredApprovals(),
+		CurrentApprovalLevel: e.CurrentApprovalLevel(),
+		RemainingApprovals:   e.RemainingApprovals(),
+		Approvals:            approvals,
+
+		Attachments: attachments,
 	}
 }
 
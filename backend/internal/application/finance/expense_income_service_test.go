@@ -0,0 +1,221 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockExpenseRecordRepository is a mock implementation of ExpenseRecordRepository
+type MockExpenseRecordRepository struct {
+	mock.Mock
+}
+
+func (m *MockExpenseRecordRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.ExpenseRecord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*finance.ExpenseRecord), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*finance.ExpenseRecord, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*finance.ExpenseRecord), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) FindByExpenseNumber(ctx context.Context, tenantID uuid.UUID, expenseNumber string) (*finance.ExpenseRecord, error) {
+	args := m.Called(ctx, tenantID, expenseNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*finance.ExpenseRecord), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.ExpenseRecordFilter) ([]finance.ExpenseRecord, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.ExpenseRecord), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) FindByCategory(ctx context.Context, tenantID uuid.UUID, category finance.ExpenseCategory, filter finance.ExpenseRecordFilter) ([]finance.ExpenseRecord, error) {
+	args := m.Called(ctx, tenantID, category, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.ExpenseRecord), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.ExpenseStatus, filter finance.ExpenseRecordFilter) ([]finance.ExpenseRecord, error) {
+	args := m.Called(ctx, tenantID, status, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.ExpenseRecord), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) FindPendingApproval(ctx context.Context, tenantID uuid.UUID) ([]finance.ExpenseRecord, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.ExpenseRecord), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) Save(ctx context.Context, expense *finance.ExpenseRecord) error {
+	args := m.Called(ctx, expense)
+	return args.Error(0)
+}
+
+func (m *MockExpenseRecordRepository) SaveWithLock(ctx context.Context, expense *finance.ExpenseRecord) error {
+	args := m.Called(ctx, expense)
+	return args.Error(0)
+}
+
+func (m *MockExpenseRecordRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockExpenseRecordRepository) DeleteForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *MockExpenseRecordRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.ExpenseRecordFilter) (int64, error) {
+	args := m.Called(ctx, tenantID, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.ExpenseStatus) (int64, error) {
+	args := m.Called(ctx, tenantID, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) CountByCategory(ctx context.Context, tenantID uuid.UUID, category finance.ExpenseCategory) (int64, error) {
+	args := m.Called(ctx, tenantID, category)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) SumByCategory(ctx context.Context, tenantID uuid.UUID, category finance.ExpenseCategory, from, to time.Time) (decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID, category, from, to)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) SumForTenant(ctx context.Context, tenantID uuid.UUID, from, to time.Time) (decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID, from, to)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) SumApprovedForTenant(ctx context.Context, tenantID uuid.UUID, from, to time.Time) (decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID, from, to)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) ExistsByExpenseNumber(ctx context.Context, tenantID uuid.UUID, expenseNumber string) (bool, error) {
+	args := m.Called(ctx, tenantID, expenseNumber)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockExpenseRecordRepository) GenerateExpenseNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	args := m.Called(ctx, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+func TestExpenseIncomeService_ApprovalChain(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	submitter := uuid.New()
+	firstApprover := uuid.New()
+	secondApprover := uuid.New()
+	threshold := decimal.NewFromInt(5000)
+
+	tenant := &identity.Tenant{Config: identity.DefaultTenantConfig()}
+	tenant.Config.ExpenseApprovalThresholds = []decimal.Decimal{threshold}
+
+	newService := func(t *testing.T, amount decimal.Decimal) (*ExpenseIncomeService, *finance.ExpenseRecord) {
+		expense, err := finance.NewExpenseRecord(tenantID, "EXP-001", finance.ExpenseCategoryOffice,
+			valueobject.NewMoneyCNY(amount), "Office furniture", time.Now())
+		require.NoError(t, err)
+
+		expenseRepo := new(MockExpenseRecordRepository)
+		expenseRepo.On("FindByIDForTenant", ctx, tenantID, expense.ID).Return(expense, nil)
+		expenseRepo.On("SaveWithLock", ctx, expense).Return(nil)
+		tenants := new(MockCreditTenantReader)
+		tenants.On("FindByID", ctx, tenantID).Return(tenant, nil)
+
+		svc := NewExpenseIncomeService(expenseRepo, nil, nil, nil)
+		svc.SetTenantReader(tenants)
+		return svc, expense
+	}
+
+	t.Run("expense just below the threshold needs one approval", func(t *testing.T) {
+		svc, expense := newService(t, threshold.Sub(decimal.NewFromFloat(0.01)))
+
+		submitted, err := svc.SubmitExpenseRecord(ctx, tenantID, expense.ID, submitter)
+		require.NoError(t, err)
+		assert.Equal(t, 1, submitted.RequiredApprovals)
+		assert.Equal(t, 0, submitted.CurrentApprovalLevel)
+		assert.Equal(t, 1, submitted.RemainingApprovals)
+
+		approved, err := svc.ApproveExpenseRecord(ctx, tenantID, expense.ID, firstApprover, "")
+		require.NoError(t, err)
+		assert.Equal(t, string(finance.ExpenseStatusApproved), approved.Status)
+		assert.Equal(t, 1, approved.CurrentApprovalLevel)
+		assert.Equal(t, 0, approved.RemainingApprovals)
+	})
+
+	t.Run("expense just above the threshold needs two approvals", func(t *testing.T) {
+		svc, expense := newService(t, threshold.Add(decimal.NewFromFloat(0.01)))
+
+		submitted, err := svc.SubmitExpenseRecord(ctx, tenantID, expense.ID, submitter)
+		require.NoError(t, err)
+		assert.Equal(t, 2, submitted.RequiredApprovals)
+		assert.Equal(t, 2, submitted.RemainingApprovals)
+
+		afterFirst, err := svc.ApproveExpenseRecord(ctx, tenantID, expense.ID, firstApprover, "")
+		require.NoError(t, err)
+		assert.Equal(t, string(finance.ExpenseStatusPending), afterFirst.Status)
+		assert.Equal(t, 1, afterFirst.CurrentApprovalLevel)
+		assert.Equal(t, 1, afterFirst.RemainingApprovals)
+		assert.Nil(t, afterFirst.ApprovedBy)
+
+		afterSecond, err := svc.ApproveExpenseRecord(ctx, tenantID, expense.ID, secondApprover, "")
+		require.NoError(t, err)
+		assert.Equal(t, string(finance.ExpenseStatusApproved), afterSecond.Status)
+		assert.Equal(t, 2, afterSecond.CurrentApprovalLevel)
+		assert.Equal(t, 0, afterSecond.RemainingApprovals)
+		require.Len(t, afterSecond.Approvals, 2)
+		assert.Equal(t, firstApprover, afterSecond.Approvals[0].ApprovedBy)
+		assert.Equal(t, secondApprover, afterSecond.Approvals[1].ApprovedBy)
+	})
+
+	t.Run("without a tenant reader every expense needs one approval", func(t *testing.T) {
+		expense, err := finance.NewExpenseRecord(tenantID, "EXP-002", finance.ExpenseCategoryOffice,
+			valueobject.NewMoneyCNY(decimal.NewFromInt(100000)), "Server hardware", time.Now())
+		require.NoError(t, err)
+		expenseRepo := new(MockExpenseRecordRepository)
+		expenseRepo.On("FindByIDForTenant", ctx, tenantID, expense.ID).Return(expense, nil)
+		expenseRepo.On("SaveWithLock", ctx, expense).Return(nil)
+		svc := NewExpenseIncomeService(expenseRepo, nil, nil, nil)
+
+		submitted, err := svc.SubmitExpenseRecord(ctx, tenantID, expense.ID, submitter)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, submitted.RequiredApprovals)
+	})
+}
@@ -14,11 +14,18 @@ import (
 
 // FinanceService provides application-level finance operations
 type FinanceService struct {
-	receivableRepo     finance.AccountReceivableRepository
-	payableRepo        finance.AccountPayableRepository
-	receiptVoucherRepo finance.ReceiptVoucherRepository
-	paymentVoucherRepo finance.PaymentVoucherRepository
-	reconciliationSvc  *finance.ReconciliationService
+	receivableRepo      finance.AccountReceivableRepository
+	payableRepo         finance.AccountPayableRepository
+	receiptVoucherRepo  finance.ReceiptVoucherRepository
+	paymentVoucherRepo  finance.PaymentVoucherRepository
+	reconciliationSvc   *finance.ReconciliationService
+	customerReader      CustomerReader
+	txScope             TransactionScope
+	idempotencyKeyRepo  finance.VoucherIdempotencyKeyRepository
+	purchaseOrderReader PurchaseOrderReader
+	matchTolerance      *finance.MatchTolerance
+	eventPublisher      shared.EventPublisher
+	businessMetrics     *telemetry.BusinessMetrics
 }
 
 // FinanceServiceOption is a functional option for configuring FinanceService
@@ -53,6 +60,21 @@ func WithReconciliationService(svc *finance.ReconciliationService) FinanceServic
 	}
 }
 
+// WithCustomerReader sets the customer reader used to resolve customers for batch receipts
+func WithCustomerReader(reader CustomerReader) FinanceServiceOption {
+	return func(s *FinanceService) {
+		s.customerReader = reader
+	}
+}
+
+// WithTransactionScope sets the transaction scope for multi-step operations.
+// When set, batch receipt rows are created and reconciled in one database transaction each.
+func WithTransactionScope(scope TransactionScope) FinanceServiceOption {
+	return func(s *FinanceService) {
+		s.txScope = scope
+	}
+}
+
 // NewFinanceService creates a new FinanceService
 func NewFinanceService(
 	receivableRepo finance.AccountReceivableRepository,
@@ -74,11 +96,52 @@ func NewFinanceService(
 	return s
 }
 
+// SetEventPublisher sets the event publisher for publishing domain events
+func (s *FinanceService) SetEventPublisher(publisher shared.EventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// SetBusinessMetrics sets the business metrics collector
+func (s *FinanceService) SetBusinessMetrics(bm *telemetry.BusinessMetrics) {
+	s.businessMetrics = bm
+}
+
+// publishDomainEvents publishes and clears the domain events of the given aggregates
+func (s *FinanceService) publishDomainEvents(ctx context.Context, aggregates ...shared.AggregateRoot) {
+	if s.eventPublisher == nil {
+		return
+	}
+	for _, aggregate := range aggregates {
+		events := aggregate.GetDomainEvents()
+		if len(events) == 0 {
+			continue
+		}
+		// Publish events (errors are logged by the event bus, not propagated)
+		_ = s.eventPublisher.Publish(ctx, events...)
+		aggregate.ClearDomainEvents()
+	}
+}
+
 // GetReconciliationService returns the underlying reconciliation service for inspection
 func (s *FinanceService) GetReconciliationService() *finance.ReconciliationService {
 	return s.reconciliationSvc
 }
 
+// AvailableReconciliationStrategies returns the reconciliation strategy types that can be requested
+func (s *FinanceService) AvailableReconciliationStrategies() []string {
+	types := s.reconciliationSvc.AvailableStrategies()
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return names
+}
+
+// IsReconciliationStrategyAvailable returns true if the strategy type can be requested
+func (s *FinanceService) IsReconciliationStrategyAvailable(strategyType string) bool {
+	return s.reconciliationSvc.IsStrategyAvailable(finance.ReconciliationStrategyType(strategyType))
+}
+
 // ===================== Account Receivable Operations =====================
 
 // AccountReceivableResponse represents an account receivable in API responses
@@ -91,6 +154,7 @@ type AccountReceivableResponse struct {
 	SourceType        string                  `json:"source_type"`
 	SourceID          uuid.UUID               `json:"source_id"`
 	SourceNumber      string                  `json:"source_number"`
+	Currency          string                  `json:"currency"`
 	TotalAmount       decimal.Decimal         `json:"total_amount"`
 	PaidAmount        decimal.Decimal         `json:"paid_amount"`
 	OutstandingAmount decimal.Decimal         `json:"outstanding_amount"`
@@ -99,6 +163,10 @@ type AccountReceivableResponse struct {
 	PaymentRecords    []PaymentRecordResponse `json:"payment_records,omitempty"`
 	Remark            string                  `json:"remark,omitempty"`
 	PaidAt            *time.Time              `json:"paid_at,omitempty"`
+	WrittenOffAmount  decimal.Decimal         `json:"written_off_amount"`
+	WrittenOffAt      *time.Time              `json:"written_off_at,omitempty"`
+	WrittenOffBy      *uuid.UUID              `json:"written_off_by,omitempty"`
+	WriteOffReason    string                  `json:"write_off_reason,omitempty"`
 	CreatedAt         time.Time               `json:"created_at"`
 	UpdatedAt         time.Time               `json:"updated_at"`
 	Version           int                     `json:"version"`
@@ -106,11 +174,12 @@ type AccountReceivableResponse struct {
 
 // PaymentRecordResponse represents a payment record in API responses
 type PaymentRecordResponse struct {
-	ID               uuid.UUID       `json:"id"`
-	ReceiptVoucherID uuid.UUID       `json:"receipt_voucher_id"`
-	Amount           decimal.Decimal `json:"amount"`
-	AppliedAt        time.Time       `json:"applied_at"`
-	Remark           string          `json:"remark,omitempty"`
+	ID                   uuid.UUID       `json:"id"`
+	ReceiptVoucherID     uuid.UUID       `json:"receipt_voucher_id"`
+	ReceiptVoucherNumber string          `json:"receipt_voucher_number,omitempty"` // Only set by ListReceivablePayments
+	Amount               decimal.Decimal `json:"amount"`
+	AppliedAt            time.Time       `json:"applied_at"`
+	Remark               string          `json:"remark,omitempty"`
 }
 
 // AccountReceivableListFilter defines filtering options for receivable list queries
@@ -124,6 +193,14 @@ type AccountReceivableListFilter struct {
 	Overdue    *bool      `form:"overdue"`
 	Page       int        `form:"page"`
 	PageSize   int        `form:"page_size"`
+	// Pagination is "offset" (default) or "cursor"; Cursor is the next_cursor of the previous page
+	Pagination string `form:"pagination" binding:"omitempty,oneof=offset cursor"`
+	Cursor     string `form:"cursor"`
+}
+
+// UsesCursor reports whether the request asked for cursor pagination
+func (f AccountReceivableListFilter) UsesCursor() bool {
+	return f.Pagination == "cursor" || f.Cursor != ""
 }
 
 // GetReceivableByID gets a receivable by ID
@@ -135,11 +212,91 @@ func (s *FinanceService) GetReceivableByID(ctx context.Context, tenantID, id uui
 	if receivable == nil {
 		return nil, shared.NewDomainError("NOT_FOUND", "Account receivable not found")
 	}
-	return toReceivableResponse(receivable), nil
+	return ToReceivableResponse(receivable), nil
+}
+
+// ListReceivablePayments lists the payment records of a receivable, newest first
+func (s *FinanceService) ListReceivablePayments(ctx context.Context, tenantID, receivableID uuid.UUID, page, pageSize int) ([]PaymentRecordResponse, int64, error) {
+	records, total, err := s.receivableRepo.ListPaymentRecords(ctx, tenantID, receivableID, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]PaymentRecordResponse, len(records))
+	for i, record := range records {
+		responses[i] = PaymentRecordResponse{
+			ID:                   record.ID,
+			ReceiptVoucherID:     record.ReceiptVoucherID,
+			ReceiptVoucherNumber: record.ReceiptVoucherNumber,
+			Amount:               record.Amount,
+			AppliedAt:            record.AppliedAt,
+			Remark:               record.Remark,
+		}
+	}
+	return responses, total, nil
 }
 
 // ListReceivables lists receivables with filtering
 func (s *FinanceService) ListReceivables(ctx context.Context, tenantID uuid.UUID, filter AccountReceivableListFilter) ([]AccountReceivableResponse, int64, error) {
+	domainFilter, err := buildReceivableFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	receivables, err := s.receivableRepo.FindAllForTenant(ctx, tenantID, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.receivableRepo.CountForTenant(ctx, tenantID, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]AccountReceivableResponse, len(receivables))
+	for i, r := range receivables {
+		responses[i] = *ToReceivableResponse(&r)
+	}
+
+	return responses, total, nil
+}
+
+// ListReceivablesByCursor lists one keyset page of receivables, newest first.
+// It returns the cursor for the next page, or "" on the last page, and skips the total count.
+func (s *FinanceService) ListReceivablesByCursor(ctx context.Context, tenantID uuid.UUID, filter AccountReceivableListFilter) ([]AccountReceivableResponse, string, error) {
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+
+	domainFilter, err := buildReceivableFilter(filter)
+	if err != nil {
+		return nil, "", err
+	}
+	domainFilter.Keyset = true
+	if filter.Cursor != "" {
+		if domainFilter.After, err = shared.DecodeCursor(filter.Cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	receivables, err := s.receivableRepo.FindAllForTenant(ctx, tenantID, domainFilter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	receivables, nextCursor := shared.NextCursor(receivables, filter.PageSize, domainFilter.OrderDir, func(r finance.AccountReceivable) (time.Time, uuid.UUID) {
+		return r.CreatedAt, r.ID
+	})
+	responses := make([]AccountReceivableResponse, len(receivables))
+	for i, r := range receivables {
+		responses[i] = *ToReceivableResponse(&r)
+	}
+
+	return responses, nextCursor, nil
+}
+
+// buildReceivableFilter converts the receivable list request into a repository filter
+func buildReceivableFilter(filter AccountReceivableListFilter) (finance.AccountReceivableFilter, error) {
 	domainFilter := finance.AccountReceivableFilter{
 		FromDate: filter.FromDate,
 		ToDate:   filter.ToDate,
@@ -153,7 +310,7 @@ func (s *FinanceService) ListReceivables(ctx context.Context, tenantID uuid.UUID
 	if filter.CustomerID != "" {
 		customerID, err := uuid.Parse(filter.CustomerID)
 		if err != nil {
-			return nil, 0, shared.NewDomainError("INVALID_CUSTOMER_ID", "Invalid customer ID format")
+			return finance.AccountReceivableFilter{}, shared.NewDomainError("INVALID_CUSTOMER_ID", "Invalid customer ID format")
 		}
 		domainFilter.CustomerID = &customerID
 	}
@@ -167,34 +324,66 @@ func (s *FinanceService) ListReceivables(ctx context.Context, tenantID uuid.UUID
 		domainFilter.SourceType = &sourceType
 	}
 
-	receivables, err := s.receivableRepo.FindAllForTenant(ctx, tenantID, domainFilter)
+	return domainFilter, nil
+}
+
+// WriteOffReceivable writes off the outstanding balance of a receivable as bad debt
+func (s *FinanceService) WriteOffReceivable(ctx context.Context, tenantID, receivableID, userID uuid.UUID, reason string) (*AccountReceivableResponse, error) {
+	receivable, err := s.receivableRepo.FindByIDForTenant(ctx, tenantID, receivableID)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	if receivable == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Account receivable not found")
 	}
 
-	total, err := s.receivableRepo.CountForTenant(ctx, tenantID, domainFilter)
-	if err != nil {
-		return nil, 0, err
+	if err := receivable.WriteOff(reason, userID); err != nil {
+		return nil, err
 	}
 
-	responses := make([]AccountReceivableResponse, len(receivables))
-	for i, r := range receivables {
-		responses[i] = *toReceivableResponse(&r)
+	if err := s.receivableRepo.SaveWithLock(ctx, receivable); err != nil {
+		return nil, err
 	}
 
-	return responses, total, nil
+	s.publishDomainEvents(ctx, receivable)
+
+	return ToReceivableResponse(receivable), nil
 }
 
-// GetReceivableSummary gets a summary of receivables for a tenant
+// ReceivableSummary represents a summary of receivables
 type ReceivableSummary struct {
-	TotalOutstanding decimal.Decimal `json:"total_outstanding"`
-	TotalOverdue     decimal.Decimal `json:"total_overdue"`
-	PendingCount     int64           `json:"pending_count"`
-	PartialCount     int64           `json:"partial_count"`
-	OverdueCount     int64           `json:"overdue_count"`
+	TotalOutstanding decimal.Decimal        `json:"total_outstanding"`
+	TotalOverdue     decimal.Decimal        `json:"total_overdue"`
+	TotalWrittenOff  decimal.Decimal        `json:"total_written_off"`
+	PendingCount     int64                  `json:"pending_count"`
+	PartialCount     int64                  `json:"partial_count"`
+	OverdueCount     int64                  `json:"overdue_count"`
+	Aging            []CurrencyAgingSummary `json:"aging"` // Aging buckets per currency
+}
+
+// CurrencyAgingSummary represents the aging buckets of the documents in one currency
+type CurrencyAgingSummary struct {
+	Currency string               `json:"currency"`
+	Buckets  []AgingBucketSummary `json:"buckets"`
+}
+
+// AgingBucketSummary represents the outstanding amount and count in one aging range
+type AgingBucketSummary struct {
+	Key     string          `json:"key"`
+	MinDays int             `json:"min_days"`
+	MaxDays *int            `json:"max_days,omitempty"`
+	Amount  decimal.Decimal `json:"amount"`
+	Count   int64           `json:"count"`
 }
 
-func (s *FinanceService) GetReceivableSummary(ctx context.Context, tenantID uuid.UUID) (*ReceivableSummary, error) {
+// GetReceivableSummary gets receivable totals and aging buckets per currency for a tenant.
+// agingBoundaries sets the upper bound of each overdue bucket in days; empty uses 30/60/90.
+func (s *FinanceService) GetReceivableSummary(ctx context.Context, tenantID uuid.UUID, agingBoundaries []int) (*ReceivableSummary, error) {
+	agingConfig, err := finance.NewAgingBucketConfig(agingBoundaries)
+	if err != nil {
+		return nil, err
+	}
+
 	totalOutstanding, err := s.receivableRepo.SumOutstandingForTenant(ctx, tenantID)
 	if err != nil {
 		return nil, err
@@ -205,6 +394,11 @@ func (s *FinanceService) GetReceivableSummary(ctx context.Context, tenantID uuid
 		return nil, err
 	}
 
+	totalWrittenOff, err := s.receivableRepo.SumWrittenOffForTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
 	pendingCount, err := s.receivableRepo.CountByStatus(ctx, tenantID, finance.ReceivableStatusPending)
 	if err != nil {
 		return nil, err
@@ -220,15 +414,47 @@ func (s *FinanceService) GetReceivableSummary(ctx context.Context, tenantID uuid
 		return nil, err
 	}
 
+	agingEntries, err := s.receivableRepo.FindAgingEntries(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ReceivableSummary{
 		TotalOutstanding: totalOutstanding,
 		TotalOverdue:     totalOverdue,
+		TotalWrittenOff:  totalWrittenOff,
 		PendingCount:     pendingCount,
 		PartialCount:     partialCount,
 		OverdueCount:     overdueCount,
+		Aging:            toCurrencyAgingSummaries(agingConfig.ComputeByCurrency(time.Now(), agingEntries)),
 	}, nil
 }
 
+func toCurrencyAgingSummaries(agings []finance.CurrencyAging) []CurrencyAgingSummary {
+	summaries := make([]CurrencyAgingSummary, len(agings))
+	for i, a := range agings {
+		summaries[i] = CurrencyAgingSummary{
+			Currency: string(a.Currency),
+			Buckets:  toAgingBucketSummaries(a.Buckets),
+		}
+	}
+	return summaries
+}
+
+func toAgingBucketSummaries(buckets []finance.AgingBucket) []AgingBucketSummary {
+	summaries := make([]AgingBucketSummary, len(buckets))
+	for i, b := range buckets {
+		summaries[i] = AgingBucketSummary{
+			Key:     b.Key,
+			MinDays: b.MinDays,
+			MaxDays: b.MaxDays,
+			Amount:  b.Amount,
+			Count:   b.Count,
+		}
+	}
+	return summaries
+}
+
 // ===================== Account Payable Operations =====================
 
 // AccountPayableResponse represents an account payable in API responses
@@ -241,6 +467,7 @@ type AccountPayableResponse struct {
 	SourceType        string                         `json:"source_type"`
 	SourceID          uuid.UUID                      `json:"source_id"`
 	SourceNumber      string                         `json:"source_number"`
+	Currency          string                         `json:"currency"`
 	TotalAmount       decimal.Decimal                `json:"total_amount"`
 	PaidAmount        decimal.Decimal                `json:"paid_amount"`
 	OutstandingAmount decimal.Decimal                `json:"outstanding_amount"`
@@ -249,6 +476,10 @@ type AccountPayableResponse struct {
 	PaymentRecords    []PayablePaymentRecordResponse `json:"payment_records,omitempty"`
 	Remark            string                         `json:"remark,omitempty"`
 	PaidAt            *time.Time                     `json:"paid_at,omitempty"`
+	InvoiceNumber     string                         `json:"invoice_number,omitempty"`
+	InvoiceAmount     decimal.Decimal                `json:"invoice_amount"`
+	InvoiceDate       *time.Time                     `json:"invoice_date,omitempty"`
+	MatchStatus       string                         `json:"match_status,omitempty"` // Empty until the invoice is matched
 	CreatedAt         time.Time                      `json:"created_at"`
 	UpdatedAt         time.Time                      `json:"updated_at"`
 	Version           int                            `json:"version"`
@@ -337,14 +568,22 @@ func (s *FinanceService) ListPayables(ctx context.Context, tenantID uuid.UUID, f
 
 // PayableSummary represents a summary of payables
 type PayableSummary struct {
-	TotalOutstanding decimal.Decimal `json:"total_outstanding"`
-	TotalOverdue     decimal.Decimal `json:"total_overdue"`
-	PendingCount     int64           `json:"pending_count"`
-	PartialCount     int64           `json:"partial_count"`
-	OverdueCount     int64           `json:"overdue_count"`
+	TotalOutstanding decimal.Decimal        `json:"total_outstanding"`
+	TotalOverdue     decimal.Decimal        `json:"total_overdue"`
+	PendingCount     int64                  `json:"pending_count"`
+	PartialCount     int64                  `json:"partial_count"`
+	OverdueCount     int64                  `json:"overdue_count"`
+	Aging            []CurrencyAgingSummary `json:"aging"` // Aging buckets per currency
 }
 
-func (s *FinanceService) GetPayableSummary(ctx context.Context, tenantID uuid.UUID) (*PayableSummary, error) {
+// GetPayableSummary gets payable totals and aging buckets per currency for a tenant.
+// agingBoundaries sets the upper bound of each overdue bucket in days; empty uses 30/60/90.
+func (s *FinanceService) GetPayableSummary(ctx context.Context, tenantID uuid.UUID, agingBoundaries []int) (*PayableSummary, error) {
+	agingConfig, err := finance.NewAgingBucketConfig(agingBoundaries)
+	if err != nil {
+		return nil, err
+	}
+
 	totalOutstanding, err := s.payableRepo.SumOutstandingForTenant(ctx, tenantID)
 	if err != nil {
 		return nil, err
@@ -370,12 +609,18 @@ func (s *FinanceService) GetPayableSummary(ctx context.Context, tenantID uuid.UU
 		return nil, err
 	}
 
+	agingEntries, err := s.payableRepo.FindAgingEntries(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PayableSummary{
 		TotalOutstanding: totalOutstanding,
 		TotalOverdue:     totalOverdue,
 		PendingCount:     pendingCount,
 		PartialCount:     partialCount,
 		OverdueCount:     overdueCount,
+		Aging:            toCurrencyAgingSummaries(agingConfig.ComputeByCurrency(time.Now(), agingEntries)),
 	}, nil
 }
 
@@ -423,6 +668,7 @@ type CreateReceiptVoucherRequest struct {
 	ReceiptDate      time.Time       `json:"receipt_date"`
 	Remark           string          `json:"remark"`
 	CreatedBy        *uuid.UUID      `json:"-"` // Set from JWT context, not from request body
+	IdempotencyKey   string          `json:"-"` // Set from the Idempotency-Key header; a replay returns the original voucher
 }
 
 // ReceiptVoucherListFilter defines filtering options for receipt voucher list queries
@@ -439,6 +685,12 @@ type ReceiptVoucherListFilter struct {
 
 // CreateReceiptVoucher creates a new receipt voucher
 func (s *FinanceService) CreateReceiptVoucher(ctx context.Context, tenantID uuid.UUID, req CreateReceiptVoucherRequest) (*ReceiptVoucherResponse, error) {
+	if s.isIdempotent(req.IdempotencyKey) {
+		if replayed, err := s.replayReceiptVoucher(ctx, tenantID, req); replayed != nil || err != nil {
+			return replayed, err
+		}
+	}
+
 	voucherNumber, err := s.receiptVoucherRepo.GenerateVoucherNumber(ctx, tenantID)
 	if err != nil {
 		return nil, err
@@ -476,6 +728,10 @@ func (s *FinanceService) CreateReceiptVoucher(ctx context.Context, tenantID uuid
 		voucher.SetCreatedBy(*req.CreatedBy)
 	}
 
+	if s.isIdempotent(req.IdempotencyKey) {
+		return s.saveIdempotentReceiptVoucher(ctx, tenantID, req, voucher)
+	}
+
 	if err := s.receiptVoucherRepo.Save(ctx, voucher); err != nil {
 		return nil, err
 	}
@@ -627,6 +883,7 @@ type CreatePaymentVoucherRequest struct {
 	PaymentDate      time.Time       `json:"payment_date"`
 	Remark           string          `json:"remark"`
 	CreatedBy        *uuid.UUID      `json:"-"` // Set from JWT context, not from request body
+	IdempotencyKey   string          `json:"-"` // Set from the Idempotency-Key header; a replay returns the original voucher
 }
 
 // PaymentVoucherListFilter defines filtering options for payment voucher list queries
@@ -643,6 +900,12 @@ type PaymentVoucherListFilter struct {
 
 // CreatePaymentVoucher creates a new payment voucher
 func (s *FinanceService) CreatePaymentVoucher(ctx context.Context, tenantID uuid.UUID, req CreatePaymentVoucherRequest) (*PaymentVoucherResponse, error) {
+	if s.isIdempotent(req.IdempotencyKey) {
+		if replayed, err := s.replayPaymentVoucher(ctx, tenantID, req); replayed != nil || err != nil {
+			return replayed, err
+		}
+	}
+
 	voucherNumber, err := s.paymentVoucherRepo.GenerateVoucherNumber(ctx, tenantID)
 	if err != nil {
 		return nil, err
@@ -680,6 +943,10 @@ func (s *FinanceService) CreatePaymentVoucher(ctx context.Context, tenantID uuid
 		voucher.SetCreatedBy(*req.CreatedBy)
 	}
 
+	if s.isIdempotent(req.IdempotencyKey) {
+		return s.saveIdempotentPaymentVoucher(ctx, tenantID, req, voucher)
+	}
+
 	if err := s.paymentVoucherRepo.Save(ctx, voucher); err != nil {
 		return nil, err
 	}
@@ -792,7 +1059,7 @@ func (s *FinanceService) CancelPaymentVoucher(ctx context.Context, tenantID, vou
 // ReconcileReceiptRequest represents a request to reconcile a receipt voucher
 type ReconcileReceiptRequest struct {
 	VoucherID         uuid.UUID                 `json:"voucher_id"`
-	StrategyType      string                    `json:"strategy_type"` // FIFO or MANUAL
+	StrategyType      string                    `json:"strategy_type"` // FIFO, LIFO, LARGEST_FIRST or MANUAL; empty uses the tenant default
 	ManualAllocations []ManualAllocationRequest `json:"manual_allocations,omitempty"`
 }
 
@@ -834,9 +1101,10 @@ func (s *FinanceService) ReconcileReceipt(ctx context.Context, tenantID uuid.UUI
 			return
 		}
 
+		// The requested strategy is resolved from the strategy registry by the reconciliation service.
+		// Only when none is specified fall back to the effective strategy for the tenant.
 		strategyType := finance.ReconciliationStrategyType(req.StrategyType)
-		// If no strategy specified or invalid, use the effective strategy from the service
-		if !strategyType.IsValid() {
+		if strategyType == "" {
 			strategyType = s.reconciliationSvc.GetEffectiveStrategy(c, tenantID)
 		}
 
@@ -877,7 +1145,11 @@ func (s *FinanceService) ReconcileReceipt(ctx context.Context, tenantID uuid.UUI
 		// Convert to response
 		updatedReceivables := make([]AccountReceivableResponse, len(result.UpdatedReceivables))
 		for i, r := range result.UpdatedReceivables {
-			updatedReceivables[i] = *toReceivableResponse(&r)
+			updatedReceivables[i] = *ToReceivableResponse(&r)
+		}
+
+		if s.businessMetrics != nil {
+			s.businessMetrics.RecordPaymentReconciled(c, tenantID, telemetry.ReconcileDirectionReceipt, result.FullyReconciled)
 		}
 
 		response = &ReconcileReceiptResult{
@@ -895,7 +1167,7 @@ func (s *FinanceService) ReconcileReceipt(ctx context.Context, tenantID uuid.UUI
 // ReconcilePaymentRequest represents a request to reconcile a payment voucher
 type ReconcilePaymentRequest struct {
 	VoucherID         uuid.UUID                 `json:"voucher_id"`
-	StrategyType      string                    `json:"strategy_type"` // FIFO or MANUAL
+	StrategyType      string                    `json:"strategy_type"` // FIFO, LIFO, LARGEST_FIRST or MANUAL; empty uses the tenant default
 	ManualAllocations []ManualAllocationRequest `json:"manual_allocations,omitempty"`
 }
 
@@ -931,9 +1203,10 @@ func (s *FinanceService) ReconcilePayment(ctx context.Context, tenantID uuid.UUI
 			return
 		}
 
+		// The requested strategy is resolved from the strategy registry by the reconciliation service.
+		// Only when none is specified fall back to the effective strategy for the tenant.
 		strategyType := finance.ReconciliationStrategyType(req.StrategyType)
-		// If no strategy specified or invalid, use the effective strategy from the service
-		if !strategyType.IsValid() {
+		if strategyType == "" {
 			strategyType = s.reconciliationSvc.GetEffectiveStrategy(c, tenantID)
 		}
 
@@ -977,6 +1250,10 @@ func (s *FinanceService) ReconcilePayment(ctx context.Context, tenantID uuid.UUI
 			updatedPayables[i] = *toPayableResponse(&p)
 		}
 
+		if s.businessMetrics != nil {
+			s.businessMetrics.RecordPaymentReconciled(c, tenantID, telemetry.ReconcileDirectionPayment, result.FullyReconciled)
+		}
+
 		response = &ReconcilePaymentResult{
 			Voucher:              toPaymentVoucherResponse(result.PaymentVoucher),
 			UpdatedPayables:      updatedPayables,
@@ -991,7 +1268,8 @@ func (s *FinanceService) ReconcilePayment(ctx context.Context, tenantID uuid.UUI
 
 // ===================== Helper Functions =====================
 
-func toReceivableResponse(r *finance.AccountReceivable) *AccountReceivableResponse {
+// ToReceivableResponse converts a domain AccountReceivable to its response DTO
+func ToReceivableResponse(r *finance.AccountReceivable) *AccountReceivableResponse {
 	paymentRecords := make([]PaymentRecordResponse, len(r.PaymentRecords))
 	for i, pr := range r.PaymentRecords {
 		paymentRecords[i] = PaymentRecordResponse{
@@ -1012,6 +1290,7 @@ func toReceivableResponse(r *finance.AccountReceivable) *AccountReceivableRespon
 		SourceType:        string(r.SourceType),
 		SourceID:          r.SourceID,
 		SourceNumber:      r.SourceNumber,
+		Currency:          string(r.GetCurrency()),
 		TotalAmount:       r.TotalAmount,
 		PaidAmount:        r.PaidAmount,
 		OutstandingAmount: r.OutstandingAmount,
@@ -1020,6 +1299,10 @@ func toReceivableResponse(r *finance.AccountReceivable) *AccountReceivableRespon
 		PaymentRecords:    paymentRecords,
 		Remark:            r.Remark,
 		PaidAt:            r.PaidAt,
+		WrittenOffAmount:  r.WrittenOffAmount,
+		WrittenOffAt:      r.WrittenOffAt,
+		WrittenOffBy:      r.WrittenOffBy,
+		WriteOffReason:    r.WriteOffReason,
 		CreatedAt:         r.CreatedAt,
 		UpdatedAt:         r.UpdatedAt,
 		Version:           r.Version,
@@ -1038,6 +1321,11 @@ func toPayableResponse(p *finance.AccountPayable) *AccountPayableResponse {
 		}
 	}
 
+	var matchStatus string
+	if p.MatchResult != nil {
+		matchStatus = string(p.MatchResult.Status)
+	}
+
 	return &AccountPayableResponse{
 		ID:                p.ID,
 		TenantID:          p.TenantID,
@@ -1047,6 +1335,7 @@ func toPayableResponse(p *finance.AccountPayable) *AccountPayableResponse {
 		SourceType:        string(p.SourceType),
 		SourceID:          p.SourceID,
 		SourceNumber:      p.SourceNumber,
+		Currency:          string(p.GetCurrency()),
 		TotalAmount:       p.TotalAmount,
 		PaidAmount:        p.PaidAmount,
 		OutstandingAmount: p.OutstandingAmount,
@@ -1055,6 +1344,10 @@ func toPayableResponse(p *finance.AccountPayable) *AccountPayableResponse {
 		PaymentRecords:    paymentRecords,
 		Remark:            p.Remark,
 		PaidAt:            p.PaidAt,
+		InvoiceNumber:     p.InvoiceNumber,
+		InvoiceAmount:     p.InvoiceAmount,
+		InvoiceDate:       p.InvoiceDate,
+		MatchStatus:       matchStatus,
 		CreatedAt:         p.CreatedAt,
 		UpdatedAt:         p.UpdatedAt,
 		Version:           p.Version,
@@ -0,0 +1,39 @@
+package finance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinanceService_WriteOffReceivable_PublishesEvent(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	receivable := newBatchTestReceivable(t, tenantID, uuid.New(), 1000)
+	receivable.ClearDomainEvents()
+
+	receivableRepo := new(MockAccountReceivableRepository)
+	publisher := new(MockEventPublisher)
+	svc := NewFinanceService(receivableRepo, nil, nil, nil)
+	svc.SetEventPublisher(publisher)
+
+	receivableRepo.On("FindByIDForTenant", ctx, tenantID, receivable.ID).Return(&receivable, nil)
+	receivableRepo.On("SaveWithLock", ctx, &receivable).Return(nil)
+	publisher.On("Publish", ctx, mock.MatchedBy(func(events []shared.DomainEvent) bool {
+		event, ok := events[0].(*finance.ReceivableWrittenOffEvent)
+		return len(events) == 1 && ok && event.ReceivableID == receivable.ID
+	})).Return(nil).Once()
+
+	result, err := svc.WriteOffReceivable(ctx, tenantID, receivable.ID, uuid.New(), "Customer insolvent")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(finance.ReceivableStatusWrittenOff), result.Status)
+	publisher.AssertExpectations(t)
+	assert.Empty(t, receivable.GetDomainEvents())
+}
@@ -0,0 +1,249 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PurchaseOrderReader provides read access to purchase orders for the three-way match
+type PurchaseOrderReader interface {
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*trade.PurchaseOrder, error)
+}
+
+// WithPurchaseOrderReader enables the three-way match of supplier invoices against purchase orders
+func WithPurchaseOrderReader(reader PurchaseOrderReader) FinanceServiceOption {
+	return func(s *FinanceService) {
+		s.purchaseOrderReader = reader
+	}
+}
+
+// WithMatchTolerance sets how far the invoice may differ from the received goods before the match fails.
+// Without it finance.DefaultMatchTolerance is used.
+func WithMatchTolerance(tolerance finance.MatchTolerance) FinanceServiceOption {
+	return func(s *FinanceService) {
+		s.matchTolerance = &tolerance
+	}
+}
+
+// RecordPayableInvoiceRequest represents a supplier invoice recorded against a purchase order payable
+type RecordPayableInvoiceRequest struct {
+	InvoiceNumber string          `json:"invoice_number"`
+	InvoiceAmount decimal.Decimal `json:"invoice_amount"`
+	InvoiceDate   *time.Time      `json:"invoice_date"` // Defaults to now
+}
+
+// PayableMatchResponse represents the three-way match result of a payable in API responses
+type PayableMatchResponse struct {
+	PayableID        uuid.UUID                  `json:"payable_id"`
+	PayableNumber    string                     `json:"payable_number"`
+	SourceID         uuid.UUID                  `json:"source_id"`
+	SourceNumber     string                     `json:"source_number"`
+	PayableStatus    string                     `json:"payable_status"`
+	InvoiceNumber    string                     `json:"invoice_number"`
+	InvoiceAmount    decimal.Decimal            `json:"invoice_amount"`
+	InvoiceDate      *time.Time                 `json:"invoice_date,omitempty"`
+	MatchStatus      string                     `json:"match_status"`
+	OrderedAmount    decimal.Decimal            `json:"ordered_amount"`
+	ReceivedAmount   decimal.Decimal            `json:"received_amount"`
+	BookedAmount     decimal.Decimal            `json:"booked_amount"`
+	TolerancePercent decimal.Decimal            `json:"tolerance_percent"`
+	ToleranceAmount  decimal.Decimal            `json:"tolerance_amount"`
+	Lines            []PayableMatchLineResponse `json:"lines"`
+	Discrepancies    []MatchDiscrepancyResponse `json:"discrepancies"`
+	MatchedAt        time.Time                  `json:"matched_at"`
+}
+
+// PayableMatchLineResponse represents one purchase order line of a three-way match
+type PayableMatchLineResponse struct {
+	ProductID        uuid.UUID       `json:"product_id"`
+	ProductName      string          `json:"product_name"`
+	OrderedQuantity  decimal.Decimal `json:"ordered_quantity"`
+	ReceivedQuantity decimal.Decimal `json:"received_quantity"`
+	UnitCost         decimal.Decimal `json:"unit_cost"`
+}
+
+// MatchDiscrepancyResponse represents a three-way match discrepancy in API responses
+type MatchDiscrepancyResponse struct {
+	Type        string          `json:"type"`
+	ProductID   *uuid.UUID      `json:"product_id,omitempty"`
+	ProductName string          `json:"product_name,omitempty"`
+	Expected    decimal.Decimal `json:"expected"`
+	Actual      decimal.Decimal `json:"actual"`
+	Difference  decimal.Decimal `json:"difference"`
+	Allowed     decimal.Decimal `json:"allowed"`
+}
+
+// RecordPayableInvoice records the supplier invoice against a purchase order payable and
+// matches it with the ordered prices and received quantities. A mismatch beyond the tolerance
+// moves the payable to MATCH_FAILED, which blocks payment.
+func (s *FinanceService) RecordPayableInvoice(ctx context.Context, tenantID, payableID uuid.UUID, req RecordPayableInvoiceRequest) (*PayableMatchResponse, error) {
+	payable, err := s.findPayable(ctx, tenantID, payableID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceDate := time.Now()
+	if req.InvoiceDate != nil {
+		invoiceDate = *req.InvoiceDate
+	}
+	amount, err := valueobject.NewMoney(req.InvoiceAmount, payable.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	if err := payable.RecordInvoice(req.InvoiceNumber, amount, invoiceDate); err != nil {
+		return nil, err
+	}
+
+	return s.matchAndSavePayable(ctx, payable)
+}
+
+// MatchPayable runs the three-way match again for a payable with a recorded invoice,
+// e.g. after further goods were received against the purchase order
+func (s *FinanceService) MatchPayable(ctx context.Context, tenantID, payableID uuid.UUID) (*PayableMatchResponse, error) {
+	payable, err := s.findPayable(ctx, tenantID, payableID)
+	if err != nil {
+		return nil, err
+	}
+	return s.matchAndSavePayable(ctx, payable)
+}
+
+// GetPayableMatch returns the latest three-way match result of a payable
+func (s *FinanceService) GetPayableMatch(ctx context.Context, tenantID, payableID uuid.UUID) (*PayableMatchResponse, error) {
+	payable, err := s.findPayable(ctx, tenantID, payableID)
+	if err != nil {
+		return nil, err
+	}
+	if payable.MatchResult == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "No invoice has been matched for this payable")
+	}
+	return toPayableMatchResponse(payable), nil
+}
+
+// findPayable loads a payable of the tenant, returning NOT_FOUND if it does not exist
+func (s *FinanceService) findPayable(ctx context.Context, tenantID, payableID uuid.UUID) (*finance.AccountPayable, error) {
+	payable, err := s.payableRepo.FindByIDForTenant(ctx, tenantID, payableID)
+	if err != nil {
+		return nil, err
+	}
+	if payable == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Account payable not found")
+	}
+	return payable, nil
+}
+
+// matchAndSavePayable matches the payable's invoice against its purchase order and saves the result
+func (s *FinanceService) matchAndSavePayable(ctx context.Context, payable *finance.AccountPayable) (*PayableMatchResponse, error) {
+	if !payable.HasInvoice() {
+		return nil, shared.NewDomainError("NO_INVOICE", "An invoice must be recorded before matching")
+	}
+	if payable.SourceType != finance.PayableSourceTypePurchaseOrder {
+		return nil, shared.NewDomainError("INVALID_SOURCE_TYPE", "Only purchase order payables can be matched")
+	}
+	if s.purchaseOrderReader == nil {
+		return nil, shared.NewDomainError("MATCH_UNAVAILABLE", "Three-way match is not configured")
+	}
+
+	order, err := s.purchaseOrderReader.FindByIDForTenant(ctx, payable.TenantID, payable.SourceID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, shared.NewDomainError("NOT_FOUND", "Purchase order of the payable not found")
+		}
+		return nil, err
+	}
+	if order == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Purchase order of the payable not found")
+	}
+
+	lines := make([]finance.ThreeWayMatchLine, len(order.Items))
+	for i, item := range order.Items {
+		lines[i] = finance.ThreeWayMatchLine{
+			ProductID:        item.ProductID,
+			ProductName:      item.ProductName,
+			OrderedQuantity:  item.OrderedQuantity,
+			ReceivedQuantity: item.ReceivedQuantity,
+			UnitCost:         item.UnitCost,
+		}
+	}
+
+	result := finance.ThreeWayMatch(finance.ThreeWayMatchInput{
+		Lines:         lines,
+		OrderTotal:    order.TotalAmount,
+		OrderPayable:  order.PayableAmount,
+		BookedAmount:  payable.TotalAmount,
+		InvoiceAmount: payable.InvoiceAmount,
+		Tolerance:     s.getMatchTolerance(),
+	})
+	if err := payable.ApplyMatchResult(result); err != nil {
+		return nil, err
+	}
+
+	if err := s.payableRepo.SaveWithLock(ctx, payable); err != nil {
+		return nil, err
+	}
+	s.publishDomainEvents(ctx, payable)
+
+	return toPayableMatchResponse(payable), nil
+}
+
+// getMatchTolerance returns the configured match tolerance or the default one
+func (s *FinanceService) getMatchTolerance() finance.MatchTolerance {
+	if s.matchTolerance != nil {
+		return *s.matchTolerance
+	}
+	return finance.DefaultMatchTolerance()
+}
+
+func toPayableMatchResponse(p *finance.AccountPayable) *PayableMatchResponse {
+	result := p.MatchResult
+
+	lines := make([]PayableMatchLineResponse, len(result.Lines))
+	for i, l := range result.Lines {
+		lines[i] = PayableMatchLineResponse{
+			ProductID:        l.ProductID,
+			ProductName:      l.ProductName,
+			OrderedQuantity:  l.OrderedQuantity,
+			ReceivedQuantity: l.ReceivedQuantity,
+			UnitCost:         l.UnitCost,
+		}
+	}
+	discrepancies := make([]MatchDiscrepancyResponse, len(result.Discrepancies))
+	for i, d := range result.Discrepancies {
+		discrepancies[i] = MatchDiscrepancyResponse{
+			Type:        string(d.Type),
+			ProductID:   d.ProductID,
+			ProductName: d.ProductName,
+			Expected:    d.Expected,
+			Actual:      d.Actual,
+			Difference:  d.Difference,
+			Allowed:     d.Allowed,
+		}
+	}
+
+	return &PayableMatchResponse{
+		PayableID:        p.ID,
+		PayableNumber:    p.PayableNumber,
+		SourceID:         p.SourceID,
+		SourceNumber:     p.SourceNumber,
+		PayableStatus:    string(p.Status),
+		InvoiceNumber:    p.InvoiceNumber,
+		InvoiceAmount:    p.InvoiceAmount,
+		InvoiceDate:      p.InvoiceDate,
+		MatchStatus:      string(result.Status),
+		OrderedAmount:    result.OrderedAmount,
+		ReceivedAmount:   result.ReceivedAmount,
+		BookedAmount:     result.BookedAmount,
+		TolerancePercent: result.Tolerance.Percent,
+		ToleranceAmount:  result.Tolerance.Amount,
+		Lines:            lines,
+		Discrepancies:    discrepancies,
+		MatchedAt:        result.MatchedAt,
+	}
+}
@@ -0,0 +1,199 @@
+package finance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPurchaseOrderReader serves a fixed purchase order
+type stubPurchaseOrderReader struct {
+	order *trade.PurchaseOrder
+}
+
+func (r *stubPurchaseOrderReader) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*trade.PurchaseOrder, error) {
+	if r.order == nil || r.order.ID != id {
+		return nil, shared.ErrNotFound
+	}
+	return r.order, nil
+}
+
+func TestFinanceService_RecordPayableInvoice(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	// newReceivedOrder returns a purchase order of 20 x 50.00 with all goods received,
+	// and the 1000.00 payable booked from the receipt
+	newReceivedOrder := func(t *testing.T) (*trade.PurchaseOrder, *finance.AccountPayable) {
+		order, err := trade.NewPurchaseOrder(tenantID, "PO-2026-00001", uuid.New(), "Test Supplier")
+		require.NoError(t, err)
+		item, err := order.AddItem(uuid.New(), "Widget", "W-1", "pcs", "pcs",
+			decimal.NewFromInt(20), decimal.NewFromInt(1), valueobject.NewMoneyCNY(decimal.NewFromInt(50)))
+		require.NoError(t, err)
+		order.Items[0].ReceivedQuantity = item.OrderedQuantity
+
+		payable, err := finance.NewAccountPayable(tenantID, "AP-2026-00001", order.SupplierID, order.SupplierName,
+			finance.PayableSourceTypePurchaseOrder, order.ID, order.OrderNumber, valueobject.NewMoneyCNY(decimal.NewFromInt(1000)), nil)
+		require.NoError(t, err)
+		payable.ClearDomainEvents()
+		return order, payable
+	}
+
+	newService := func(payableRepo *MockAccountPayableRepository, order *trade.PurchaseOrder, opts ...FinanceServiceOption) *FinanceService {
+		opts = append(opts, WithPurchaseOrderReader(&stubPurchaseOrderReader{order: order}))
+		return NewFinanceService(nil, payableRepo, nil, nil, opts...)
+	}
+
+	t.Run("exact invoice matches", func(t *testing.T) {
+		order, payable := newReceivedOrder(t)
+		payableRepo := new(MockAccountPayableRepository)
+		payableRepo.On("FindByIDForTenant", ctx, tenantID, payable.ID).Return(payable, nil)
+		payableRepo.On("SaveWithLock", ctx, payable).Return(nil)
+		svc := newService(payableRepo, order)
+
+		match, err := svc.RecordPayableInvoice(ctx, tenantID, payable.ID, RecordPayableInvoiceRequest{
+			InvoiceNumber: "INV-001",
+			InvoiceAmount: decimal.NewFromInt(1000),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "MATCHED", match.MatchStatus)
+		assert.Equal(t, "PENDING", match.PayableStatus)
+		assert.Equal(t, "INV-001", match.InvoiceNumber)
+		assert.True(t, decimal.NewFromInt(1000).Equal(match.ReceivedAmount))
+		assert.Empty(t, match.Discrepancies)
+		require.Len(t, match.Lines, 1)
+		assert.True(t, decimal.NewFromInt(20).Equal(match.Lines[0].ReceivedQuantity))
+		assert.NotNil(t, match.InvoiceDate)
+		payableRepo.AssertExpectations(t)
+	})
+
+	t.Run("invoice within tolerance matches", func(t *testing.T) {
+		order, payable := newReceivedOrder(t)
+		payableRepo := new(MockAccountPayableRepository)
+		payableRepo.On("FindByIDForTenant", ctx, tenantID, payable.ID).Return(payable, nil)
+		payableRepo.On("SaveWithLock", ctx, payable).Return(nil)
+		svc := newService(payableRepo, order)
+
+		match, err := svc.RecordPayableInvoice(ctx, tenantID, payable.ID, RecordPayableInvoiceRequest{
+			InvoiceNumber: "INV-001",
+			InvoiceAmount: decimal.NewFromFloat(1008.5),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "MATCHED", match.MatchStatus)
+		assert.Equal(t, "PENDING", match.PayableStatus)
+		assert.True(t, decimal.NewFromInt(1).Equal(match.TolerancePercent))
+	})
+
+	t.Run("invoice over tolerance blocks payment", func(t *testing.T) {
+		order, payable := newReceivedOrder(t)
+		payableRepo := new(MockAccountPayableRepository)
+		publisher := new(MockEventPublisher)
+		payableRepo.On("FindByIDForTenant", ctx, tenantID, payable.ID).Return(payable, nil)
+		payableRepo.On("SaveWithLock", ctx, payable).Return(nil)
+		publisher.On("Publish", ctx, mock.Anything).Return(nil)
+		svc := newService(payableRepo, order)
+		svc.SetEventPublisher(publisher)
+
+		match, err := svc.RecordPayableInvoice(ctx, tenantID, payable.ID, RecordPayableInvoiceRequest{
+			InvoiceNumber: "INV-001",
+			InvoiceAmount: decimal.NewFromInt(1050),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "MISMATCHED", match.MatchStatus)
+		assert.Equal(t, "MATCH_FAILED", match.PayableStatus)
+		require.Len(t, match.Discrepancies, 1)
+		assert.Equal(t, "INVOICE", match.Discrepancies[0].Type)
+		assert.True(t, decimal.NewFromInt(50).Equal(match.Discrepancies[0].Difference))
+		assert.Error(t, payable.ApplyPayment(valueobject.NewMoneyCNY(decimal.NewFromInt(100)), uuid.New(), ""))
+		publisher.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("configured tolerance is used", func(t *testing.T) {
+		order, payable := newReceivedOrder(t)
+		payableRepo := new(MockAccountPayableRepository)
+		payableRepo.On("FindByIDForTenant", ctx, tenantID, payable.ID).Return(payable, nil)
+		payableRepo.On("SaveWithLock", ctx, payable).Return(nil)
+		svc := newService(payableRepo, order, WithMatchTolerance(finance.MatchTolerance{
+			Percent: decimal.NewFromInt(5),
+			Amount:  decimal.Zero,
+		}))
+
+		match, err := svc.RecordPayableInvoice(ctx, tenantID, payable.ID, RecordPayableInvoiceRequest{
+			InvoiceNumber: "INV-001",
+			InvoiceAmount: decimal.NewFromInt(1050),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "MATCHED", match.MatchStatus)
+	})
+
+	t.Run("without purchase order reader", func(t *testing.T) {
+		_, payable := newReceivedOrder(t)
+		payableRepo := new(MockAccountPayableRepository)
+		payableRepo.On("FindByIDForTenant", ctx, tenantID, payable.ID).Return(payable, nil)
+		svc := NewFinanceService(nil, payableRepo, nil, nil)
+
+		_, err := svc.RecordPayableInvoice(ctx, tenantID, payable.ID, RecordPayableInvoiceRequest{
+			InvoiceNumber: "INV-001",
+			InvoiceAmount: decimal.NewFromInt(1000),
+		})
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "MATCH_UNAVAILABLE", domainErr.Code)
+		payableRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFinanceService_GetPayableMatch(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	payable, err := finance.NewAccountPayable(tenantID, "AP-2026-00001", uuid.New(), "Test Supplier",
+		finance.PayableSourceTypePurchaseOrder, uuid.New(), "PO-2026-00001", valueobject.NewMoneyCNY(decimal.NewFromInt(1000)), nil)
+	require.NoError(t, err)
+	payableRepo := new(MockAccountPayableRepository)
+	payableRepo.On("FindByIDForTenant", ctx, tenantID, payable.ID).Return(payable, nil)
+	svc := NewFinanceService(nil, payableRepo, nil, nil)
+
+	t.Run("not matched yet", func(t *testing.T) {
+		_, err := svc.GetPayableMatch(ctx, tenantID, payable.ID)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Code)
+	})
+
+	t.Run("returns latest match", func(t *testing.T) {
+		require.NoError(t, payable.RecordInvoice("INV-001", valueobject.NewMoneyCNY(decimal.NewFromInt(1000)), payable.CreatedAt))
+		require.NoError(t, payable.ApplyMatchResult(finance.ThreeWayMatch(finance.ThreeWayMatchInput{
+			Lines: []finance.ThreeWayMatchLine{
+				{ProductID: uuid.New(), ProductName: "Widget", OrderedQuantity: decimal.NewFromInt(20), ReceivedQuantity: decimal.NewFromInt(20), UnitCost: decimal.NewFromInt(50)},
+			},
+			OrderTotal:    decimal.NewFromInt(1000),
+			OrderPayable:  decimal.NewFromInt(1000),
+			BookedAmount:  payable.TotalAmount,
+			InvoiceAmount: payable.InvoiceAmount,
+			Tolerance:     finance.DefaultMatchTolerance(),
+		})))
+
+		match, err := svc.GetPayableMatch(ctx, tenantID, payable.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, payable.ID, match.PayableID)
+		assert.Equal(t, "MATCHED", match.MatchStatus)
+		assert.Equal(t, "PO-2026-00001", match.SourceNumber)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +33,7 @@ var (
 // It implements the PaymentCallbackHandler interface defined in the domain layer
 type PaymentCallbackService struct {
 	gateways           map[finance.PaymentGatewayType]finance.PaymentGateway
+	registry           *GatewayRegistry
 	receiptVoucherRepo finance.ReceiptVoucherRepository
 	receivableRepo     finance.AccountReceivableRepository
 	refundRecordRepo   finance.RefundRecordRepository
@@ -44,6 +47,7 @@ type PaymentCallbackService struct {
 // PaymentCallbackServiceConfig holds configuration for the callback service
 type PaymentCallbackServiceConfig struct {
 	Gateways           []finance.PaymentGateway
+	Registry           *GatewayRegistry // Gateways served by name; Gateways are added to it
 	ReceiptVoucherRepo finance.ReceiptVoucherRepository
 	ReceivableRepo     finance.AccountReceivableRepository
 	RefundRecordRepo   finance.RefundRecordRepository
@@ -54,18 +58,19 @@ type PaymentCallbackServiceConfig struct {
 
 // NewPaymentCallbackService creates a new PaymentCallbackService
 func NewPaymentCallbackService(config PaymentCallbackServiceConfig) *PaymentCallbackService {
-	gateways := make(map[finance.PaymentGatewayType]finance.PaymentGateway)
-	for _, gw := range config.Gateways {
-		gateways[gw.GatewayType()] = gw
-	}
-
 	logger := config.Logger
 	if logger == nil {
 		logger = zap.NewNop()
 	}
 
-	return &PaymentCallbackService{
-		gateways:           gateways,
+	registry := config.Registry
+	if registry == nil {
+		registry = NewGatewayRegistry()
+	}
+
+	s := &PaymentCallbackService{
+		gateways:           make(map[finance.PaymentGatewayType]finance.PaymentGateway),
+		registry:           registry,
 		receiptVoucherRepo: config.ReceiptVoucherRepo,
 		receivableRepo:     config.ReceivableRepo,
 		refundRecordRepo:   config.RefundRecordRepo,
@@ -74,11 +79,27 @@ func NewPaymentCallbackService(config PaymentCallbackServiceConfig) *PaymentCall
 		businessMetrics:    config.BusinessMetrics,
 		logger:             logger,
 	}
+	for _, gw := range config.Gateways {
+		s.RegisterGateway(gw)
+	}
+	return s
 }
 
-// RegisterGateway registers a payment gateway for callback processing
+// RegisterGateway registers a payment gateway for callback processing.
+// The gateway is also served from the registry under its lower-cased type.
 func (s *PaymentCallbackService) RegisterGateway(gateway finance.PaymentGateway) {
 	s.gateways[gateway.GatewayType()] = gateway
+	name := strings.ToLower(string(gateway.GatewayType()))
+	if err := s.registry.Register(name, NewPaymentGatewayCallbacks(gateway)); err != nil {
+		s.logger.Warn("Payment gateway not added to registry",
+			zap.String("gateway", name),
+			zap.Error(err))
+	}
+}
+
+// Registry returns the registry of gateways served by name
+func (s *PaymentCallbackService) Registry() *GatewayRegistry {
+	return s.registry
 }
 
 // GetGateway returns the gateway for a given type
@@ -184,6 +205,99 @@ func (s *PaymentCallbackService) ProcessPaymentCallback(
 	}, nil
 }
 
+// ProcessGatewayCallback processes a raw payment callback for a gateway in the registry.
+// It returns ErrGatewayNotFound when no gateway is registered under name; otherwise the
+// result carries the acknowledgement the gateway expects, even when processing fails.
+func (s *PaymentCallbackService) ProcessGatewayCallback(
+	ctx context.Context,
+	name string,
+	rawBody []byte,
+	headers http.Header,
+) (*PaymentCallbackResult, error) {
+	ctx, span := telemetry.StartServiceSpan(ctx, "payment", "process_gateway_callback")
+	defer span.End()
+
+	telemetry.SetAttribute(span, telemetry.SpanAttrPaymentGateway, name)
+
+	gateway, err := s.registry.Get(name)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	respond := func(success bool, message string, err error) *PaymentCallbackResult {
+		contentType, body := gateway.CallbackResponse(success, message)
+		return &PaymentCallbackResult{
+			Success:            success,
+			Error:              err,
+			GatewayResponse:    body,
+			GatewayContentType: contentType,
+		}
+	}
+
+	if err := gateway.VerifyCallback(ctx, rawBody, headers); err != nil {
+		telemetry.RecordError(span, err)
+		s.logger.Warn("Callback verification failed",
+			zap.String("gateway", name),
+			zap.Error(err))
+		err = fmt.Errorf("%w: %v", ErrCallbackVerificationFailed, err)
+		return respond(false, err.Error(), err), err
+	}
+
+	callback, err := gateway.ParsePayment(ctx, rawBody, headers)
+	if err == nil && callback == nil {
+		err = ErrCallbackInvalidPayload
+	}
+	if err != nil {
+		telemetry.RecordError(span, err)
+		s.logger.Warn("Failed to parse payment callback",
+			zap.String("gateway", name),
+			zap.Error(err))
+		if !errors.Is(err, ErrCallbackInvalidPayload) {
+			err = fmt.Errorf("%w: %v", ErrCallbackInvalidPayload, err)
+		}
+		return respond(false, err.Error(), err), err
+	}
+
+	s.logger.Info("Payment callback received",
+		zap.String("gateway", name),
+		zap.String("gateway_order_id", callback.GatewayOrderID),
+		zap.String("order_number", callback.OrderNumber),
+		zap.String("status", string(callback.Status)),
+		zap.String("amount", callback.Amount.String()))
+
+	// Check for idempotency using gateway transaction ID
+	idempotencyKey := fmt.Sprintf("payment:%s:%s", strings.ToLower(name), callback.GatewayTransactionID)
+	if _, loaded := s.processedCallbacks.LoadOrStore(idempotencyKey, time.Now()); loaded {
+		s.logger.Info("Callback already processed (idempotency check)",
+			zap.String("idempotency_key", idempotencyKey))
+		telemetry.AddEvent(span, "callback_already_processed")
+		result := respond(true, "", nil)
+		result.AlreadyProcessed = true
+		return result, nil
+	}
+
+	if err := s.HandlePaymentCallback(ctx, callback); err != nil {
+		// Remove from processed on error to allow retry
+		s.processedCallbacks.Delete(idempotencyKey)
+
+		telemetry.RecordError(span, err)
+		s.logger.Error("Failed to handle payment callback",
+			zap.String("gateway", name),
+			zap.String("gateway_order_id", callback.GatewayOrderID),
+			zap.Error(err))
+		return respond(false, err.Error(), err), err
+	}
+
+	telemetry.AddEvent(span, "payment_callback_processed",
+		"gateway_transaction_id", callback.GatewayTransactionID,
+	)
+
+	result := respond(true, "", nil)
+	result.Callback = callback
+	return result, nil
+}
+
 // HandlePaymentCallback processes a verified payment callback
 // This implements the PaymentCallbackHandler interface
 func (s *PaymentCallbackService) HandlePaymentCallback(ctx context.Context, callback *finance.PaymentCallback) error {
@@ -513,6 +627,10 @@ func (s *PaymentCallbackService) autoReconcile(ctx context.Context, voucher *fin
 		}
 	}
 
+	if s.businessMetrics != nil {
+		s.businessMetrics.RecordPaymentReconciled(ctx, voucher.TenantID, telemetry.ReconcileDirectionReceipt, result.FullyReconciled)
+	}
+
 	s.logger.Info("Auto-reconciliation completed",
 		zap.String("voucher_id", voucher.ID.String()),
 		zap.String("total_reconciled", result.TotalReconciled.String()),
@@ -528,6 +646,8 @@ type PaymentCallbackResult struct {
 	Callback         *finance.PaymentCallback `json:"callback,omitempty"`
 	Error            error                    `json:"-"`
 	GatewayResponse  []byte                   `json:"-"`
+	// GatewayContentType is the content type of GatewayResponse (registry gateways only)
+	GatewayContentType string `json:"-"`
 }
 
 // RefundCallbackResult represents the result of processing a refund callback
@@ -0,0 +1,129 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/erp/backend/internal/domain/finance"
+)
+
+var (
+	// ErrGatewayNotFound is returned when no gateway is registered under a name
+	ErrGatewayNotFound = errors.New("payment gateway: not found")
+	// ErrGatewayAlreadyRegistered is returned when a name is already taken
+	ErrGatewayAlreadyRegistered = errors.New("payment gateway: already registered")
+)
+
+// CallbackGateway receives payment notifications from one payment gateway.
+// Gateways are registered by name in a GatewayRegistry and served from
+// POST /api/v1/payment/callback/:gateway, so adding a gateway needs no new route.
+type CallbackGateway interface {
+	// VerifyCallback checks the authenticity of a notification from its raw body and headers
+	VerifyCallback(ctx context.Context, rawBody []byte, headers http.Header) error
+	// ParsePayment extracts the payment from a verified notification
+	ParsePayment(ctx context.Context, rawBody []byte, headers http.Header) (*finance.PaymentCallback, error)
+	// CallbackResponse returns the content type and body acknowledging a notification
+	CallbackResponse(success bool, message string) (contentType string, body []byte)
+}
+
+// GatewayRegistry holds the callback gateways keyed by case-insensitive name
+type GatewayRegistry struct {
+	mu       sync.RWMutex
+	gateways map[string]CallbackGateway
+}
+
+// NewGatewayRegistry creates an empty GatewayRegistry
+func NewGatewayRegistry() *GatewayRegistry {
+	return &GatewayRegistry{gateways: make(map[string]CallbackGateway)}
+}
+
+// Register registers a gateway under name
+func (r *GatewayRegistry) Register(name string, gateway CallbackGateway) error {
+	key := normalizeGatewayName(name)
+	if key == "" {
+		return errors.New("payment gateway: name is required")
+	}
+	if gateway == nil {
+		return errors.New("payment gateway: gateway is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.gateways[key]; exists {
+		return fmt.Errorf("%w: %s", ErrGatewayAlreadyRegistered, key)
+	}
+	r.gateways[key] = gateway
+	return nil
+}
+
+// Get returns the gateway registered under name
+func (r *GatewayRegistry) Get(name string) (CallbackGateway, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	gateway, ok := r.gateways[normalizeGatewayName(name)]
+	if !ok {
+		return nil, ErrGatewayNotFound
+	}
+	return gateway, nil
+}
+
+// Names returns the registered gateway names in sorted order
+func (r *GatewayRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.gateways))
+	for name := range r.gateways {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func normalizeGatewayName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// paymentGatewayCallbacks adapts a domain PaymentGateway (WeChat Pay, Alipay)
+// to CallbackGateway so it can be served from the registry
+type paymentGatewayCallbacks struct {
+	gateway finance.PaymentGateway
+}
+
+// NewPaymentGatewayCallbacks wraps a domain PaymentGateway as a CallbackGateway
+func NewPaymentGatewayCallbacks(gateway finance.PaymentGateway) CallbackGateway {
+	return &paymentGatewayCallbacks{gateway: gateway}
+}
+
+// VerifyCallback verifies the notification with the wrapped gateway
+func (g *paymentGatewayCallbacks) VerifyCallback(ctx context.Context, rawBody []byte, headers http.Header) error {
+	_, err := g.gateway.VerifyCallback(ctx, rawBody, g.signature(headers))
+	return err
+}
+
+// ParsePayment parses the notification with the wrapped gateway
+func (g *paymentGatewayCallbacks) ParsePayment(ctx context.Context, rawBody []byte, headers http.Header) (*finance.PaymentCallback, error) {
+	return g.gateway.VerifyCallback(ctx, rawBody, g.signature(headers))
+}
+
+// CallbackResponse returns the acknowledgement format the wrapped gateway expects
+func (g *paymentGatewayCallbacks) CallbackResponse(success bool, message string) (string, []byte) {
+	contentType := "text/plain"
+	if g.gateway.GatewayType() == finance.PaymentGatewayTypeWechat {
+		contentType = "application/json"
+	}
+	return contentType, g.gateway.GenerateCallbackResponse(success, message)
+}
+
+// signature returns the header signature; Alipay signs in the form body, which
+// its adapter reads when no signature is passed
+func (g *paymentGatewayCallbacks) signature(headers http.Header) string {
+	if g.gateway.GatewayType() == finance.PaymentGatewayTypeWechat {
+		return headers.Get("Wechatpay-Signature")
+	}
+	return ""
+}
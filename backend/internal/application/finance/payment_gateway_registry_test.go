@@ -0,0 +1,54 @@
+package finance
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCallbackGateway struct{}
+
+func (stubCallbackGateway) VerifyCallback(ctx context.Context, rawBody []byte, headers http.Header) error {
+	return nil
+}
+
+func (stubCallbackGateway) ParsePayment(ctx context.Context, rawBody []byte, headers http.Header) (*finance.PaymentCallback, error) {
+	return &finance.PaymentCallback{}, nil
+}
+
+func (stubCallbackGateway) CallbackResponse(success bool, message string) (string, []byte) {
+	return "text/plain", nil
+}
+
+func TestGatewayRegistry(t *testing.T) {
+	registry := NewGatewayRegistry()
+
+	require.NoError(t, registry.Register("UnionPay", stubCallbackGateway{}))
+	require.NoError(t, registry.Register("bank", stubCallbackGateway{}))
+
+	_, err := registry.Get("unionpay")
+	assert.NoError(t, err)
+	_, err = registry.Get("paypal")
+	assert.ErrorIs(t, err, ErrGatewayNotFound)
+
+	assert.ErrorIs(t, registry.Register("UNIONPAY", stubCallbackGateway{}), ErrGatewayAlreadyRegistered)
+	assert.Error(t, registry.Register(" ", stubCallbackGateway{}))
+	assert.Error(t, registry.Register("paypal", nil))
+
+	assert.Equal(t, []string{"bank", "unionpay"}, registry.Names())
+}
+
+func TestPaymentCallbackService_RegisterGatewayAddsToRegistry(t *testing.T) {
+	mockGateway := &MockPaymentGateway{}
+	mockGateway.On("GatewayType").Return(finance.PaymentGatewayTypeAlipay)
+
+	svc := NewPaymentCallbackService(PaymentCallbackServiceConfig{
+		Gateways: []finance.PaymentGateway{mockGateway},
+	})
+
+	assert.Equal(t, []string{"alipay"}, svc.Registry().Names())
+}
@@ -14,7 +14,7 @@ import (
 )
 
 // PurchaseOrderReceivedHandler handles PurchaseOrderReceivedEvent
-// and creates AccountPayable when goods are received
+// and creates or increases the AccountPayable for each receipt of goods
 type PurchaseOrderReceivedHandler struct {
 	payableRepo finance.AccountPayableRepository
 	logger      *zap.Logger
@@ -37,6 +37,7 @@ func (h *PurchaseOrderReceivedHandler) EventTypes() []string {
 }
 
 // Handle processes a PurchaseOrderReceivedEvent by creating an AccountPayable
+// on the first receipt and accumulating subsequent partial receipts into it
 func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
 	// Type assert to PurchaseOrderReceivedEvent
 	receivedEvent, ok := event.(*trade.PurchaseOrderReceivedEvent)
@@ -58,15 +59,11 @@ func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.
 		zap.Bool("is_fully_received", receivedEvent.IsFullyReceived),
 	)
 
-	// Calculate total amount for received items
-	receivedAmount := decimal.Zero
-	for _, item := range receivedEvent.ReceivedItems {
-		itemAmount := item.Quantity.Mul(item.UnitCost)
-		receivedAmount = receivedAmount.Add(itemAmount)
-	}
+	// Payable amount for the goods received in this specific receipt
+	receiptAmount := receivedEvent.PayableAmount
 
-	// Skip if received amount is zero
-	if receivedAmount.IsZero() {
+	// Skip if receipt amount is zero
+	if receiptAmount.LessThanOrEqual(decimal.Zero) {
 		h.logger.Info("skipping payable creation - received amount is zero",
 			zap.String("order_id", receivedEvent.OrderID.String()),
 			zap.String("order_number", receivedEvent.OrderNumber),
@@ -74,9 +71,8 @@ func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.
 		return nil
 	}
 
-	// Check if payable already exists for this source
-	// Note: For partial receiving, we create one payable per receive event
-	// We use event ID as part of idempotency check for multiple receives
+	// Each purchase order has at most one payable. Partial receipts accumulate
+	// into the existing payable rather than creating a new one per receipt.
 	existingPayable, err := h.payableRepo.FindBySource(
 		ctx,
 		receivedEvent.TenantID(),
@@ -91,29 +87,30 @@ func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.
 		return fmt.Errorf("failed to check existing payable: %w", err)
 	}
 
-	// For partial receiving, we may have multiple receives for one PO
-	// The strategy here: only create ONE payable when fully received
-	// If already exists, skip (idempotent)
+	amount := valueobject.NewMoneyCNY(receiptAmount)
+
 	if existingPayable != nil {
-		h.logger.Warn("payable already exists for purchase order, skipping",
-			zap.String("order_id", receivedEvent.OrderID.String()),
-			zap.String("order_number", receivedEvent.OrderNumber),
-			zap.String("existing_payable_id", existingPayable.ID.String()),
-		)
-		return nil // Idempotent - already processed
+		// The event ID identifies the receipt; a redelivered event is already included
+		if existingPayable.HasReceipt(receivedEvent.EventID()) {
+			h.logger.Info("skipping receipt already included in payable",
+				zap.String("event_id", receivedEvent.EventID().String()),
+				zap.String("order_id", receivedEvent.OrderID.String()),
+				zap.String("payable_id", existingPayable.ID.String()),
+			)
+			return nil
+		}
+		return h.increasePayable(ctx, existingPayable, receivedEvent, amount)
 	}
 
-	// Only create payable when order is fully received
-	// This prevents multiple payables for partial receives
-	if !receivedEvent.IsFullyReceived {
-		h.logger.Info("skipping payable creation - order not fully received yet",
-			zap.String("order_id", receivedEvent.OrderID.String()),
-			zap.String("order_number", receivedEvent.OrderNumber),
-			zap.String("received_amount", receivedAmount.String()),
-		)
-		return nil
-	}
+	return h.createPayable(ctx, receivedEvent, amount)
+}
 
+// createPayable creates the payable for the first receipt of a purchase order
+func (h *PurchaseOrderReceivedHandler) createPayable(
+	ctx context.Context,
+	receivedEvent *trade.PurchaseOrderReceivedEvent,
+	amount valueobject.Money,
+) error {
 	// Generate payable number
 	payableNumber, err := h.payableRepo.GeneratePayableNumber(ctx, receivedEvent.TenantID())
 	if err != nil {
@@ -127,9 +124,6 @@ func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.
 	// Set default due date (30 days from now)
 	dueDate := time.Now().AddDate(0, 0, 30)
 
-	// Use PayableAmount from the order (the full amount to pay)
-	amount := valueobject.NewMoneyCNY(receivedEvent.PayableAmount)
-
 	// Create AccountPayable
 	payable, err := finance.NewAccountPayable(
 		receivedEvent.TenantID(),
@@ -150,6 +144,7 @@ func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.
 		)
 		return fmt.Errorf("failed to create account payable: %w", err)
 	}
+	payable.RecordReceipt(receivedEvent.EventID())
 
 	// Save the payable
 	if err := h.payableRepo.Save(ctx, payable); err != nil {
@@ -168,13 +163,52 @@ func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.
 		zap.String("order_number", receivedEvent.OrderNumber),
 		zap.String("supplier_id", receivedEvent.SupplierID.String()),
 		zap.String("supplier_name", receivedEvent.SupplierName),
-		zap.String("amount", receivedEvent.PayableAmount.String()),
+		zap.String("amount", amount.Amount().String()),
 		zap.Time("due_date", dueDate),
 	)
 
 	return nil
 }
 
+// increasePayable adds the amount of a subsequent partial receipt to the existing payable
+func (h *PurchaseOrderReceivedHandler) increasePayable(
+	ctx context.Context,
+	payable *finance.AccountPayable,
+	receivedEvent *trade.PurchaseOrderReceivedEvent,
+	amount valueobject.Money,
+) error {
+	if err := payable.IncreaseAmount(receivedEvent.EventID(), amount); err != nil {
+		h.logger.Warn("cannot accumulate receipt into existing payable, skipping",
+			zap.String("order_id", receivedEvent.OrderID.String()),
+			zap.String("order_number", receivedEvent.OrderNumber),
+			zap.String("existing_payable_id", payable.ID.String()),
+			zap.String("status", string(payable.Status)),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	if err := h.payableRepo.SaveWithLock(ctx, payable); err != nil {
+		h.logger.Error("failed to update account payable",
+			zap.String("order_id", receivedEvent.OrderID.String()),
+			zap.String("payable_number", payable.PayableNumber),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to update account payable: %w", err)
+	}
+
+	h.logger.Info("account payable increased for partial receipt",
+		zap.String("payable_id", payable.ID.String()),
+		zap.String("payable_number", payable.PayableNumber),
+		zap.String("order_id", receivedEvent.OrderID.String()),
+		zap.String("order_number", receivedEvent.OrderNumber),
+		zap.String("receipt_amount", amount.Amount().String()),
+		zap.String("total_amount", payable.TotalAmount.String()),
+	)
+
+	return nil
+}
+
 // isNotFoundError checks if the error is a "not found" error
 func isNotFoundError(err error) bool {
 	if err == nil {
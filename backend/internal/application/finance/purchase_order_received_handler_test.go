@@ -13,6 +13,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -123,6 +124,11 @@ func (m *MockAccountPayableRepository) CountOutstandingBySupplier(ctx context.Co
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockAccountPayableRepository) UpdateSupplierName(ctx context.Context, tenantID, supplierID uuid.UUID, supplierName string) (int64, error) {
+	args := m.Called(ctx, tenantID, supplierID, supplierName)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockAccountPayableRepository) SumOutstandingBySupplier(ctx context.Context, tenantID, supplierID uuid.UUID) (decimal.Decimal, error) {
 	args := m.Called(ctx, tenantID, supplierID)
 	return args.Get(0).(decimal.Decimal), args.Error(1)
@@ -138,6 +144,14 @@ func (m *MockAccountPayableRepository) SumOverdueForTenant(ctx context.Context,
 	return args.Get(0).(decimal.Decimal), args.Error(1)
 }
 
+func (m *MockAccountPayableRepository) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.AgingEntry), args.Error(1)
+}
+
 func (m *MockAccountPayableRepository) ExistsByPayableNumber(ctx context.Context, tenantID uuid.UUID, payableNumber string) (bool, error) {
 	args := m.Called(ctx, tenantID, payableNumber)
 	return args.Bool(0), args.Error(1)
@@ -241,7 +255,7 @@ func TestPurchaseOrderReceivedHandler_Handle_WrongEventType(t *testing.T) {
 	assert.Contains(t, err.Error(), "unexpected event type")
 }
 
-func TestPurchaseOrderReceivedHandler_Handle_SkipPartialReceive(t *testing.T) {
+func TestPurchaseOrderReceivedHandler_Handle_CreatesPayableOnPartialReceive(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockAccountPayableRepository)
 	handler := NewPurchaseOrderReceivedHandler(mockRepo, newTestLogger2())
@@ -253,19 +267,102 @@ func TestPurchaseOrderReceivedHandler_Handle_SkipPartialReceive(t *testing.T) {
 
 	// No existing payable
 	mockRepo.On("FindBySource", ctx, tenantID, finance.PayableSourceTypePurchaseOrder, orderID).Return(nil, shared.NewDomainError("NOT_FOUND", "not found"))
+	mockRepo.On("GeneratePayableNumber", ctx, tenantID).Return("AP-20260124-001", nil)
+
+	var savedPayable *finance.AccountPayable
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*finance.AccountPayable")).Run(func(args mock.Arguments) {
+		savedPayable = args.Get(1).(*finance.AccountPayable)
+	}).Return(nil)
 
 	// Execute
 	err := handler.Handle(ctx, event)
 
-	// Assert - no error, just skipped
+	// Assert - payable is created for the partial receipt amount
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
-	// GeneratePayableNumber and Save should NOT be called
-	mockRepo.AssertNotCalled(t, "GeneratePayableNumber")
-	mockRepo.AssertNotCalled(t, "Save")
+	assert.NotNil(t, savedPayable)
+	assert.True(t, savedPayable.TotalAmount.Equal(decimal.NewFromFloat(500.00)))
 }
 
-func TestPurchaseOrderReceivedHandler_Handle_IdempotentWhenExisting(t *testing.T) {
+func TestPurchaseOrderReceivedHandler_Handle_PartialReceiptsAccumulate(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockAccountPayableRepository)
+	handler := NewPurchaseOrderReceivedHandler(mockRepo, newTestLogger2())
+
+	tenantID := uuid.New()
+	orderID := uuid.New()
+	supplierID := uuid.New()
+	firstReceipt := newTestPurchaseOrderReceivedEvent(tenantID, orderID, supplierID, false)
+	secondReceipt := newTestPurchaseOrderReceivedEvent(tenantID, orderID, supplierID, true)
+
+	// First receipt: no payable exists yet, one is created
+	var savedPayable *finance.AccountPayable
+	mockRepo.On("FindBySource", ctx, tenantID, finance.PayableSourceTypePurchaseOrder, orderID).
+		Return(nil, shared.NewDomainError("NOT_FOUND", "not found")).Once()
+	mockRepo.On("GeneratePayableNumber", ctx, tenantID).Return("AP-20260124-001", nil).Once()
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*finance.AccountPayable")).Run(func(args mock.Arguments) {
+		savedPayable = args.Get(1).(*finance.AccountPayable)
+	}).Return(nil).Once()
+
+	err := handler.Handle(ctx, firstReceipt)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, savedPayable) {
+		return
+	}
+
+	// Second receipt: the existing payable is found and increased
+	mockRepo.On("FindBySource", ctx, tenantID, finance.PayableSourceTypePurchaseOrder, orderID).
+		Return(savedPayable, nil).Once()
+	mockRepo.On("SaveWithLock", ctx, savedPayable).Return(nil).Once()
+
+	err = handler.Handle(ctx, secondReceipt)
+
+	// Assert - a single payable with the accumulated total
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "Save", 1)
+	mockRepo.AssertNumberOfCalls(t, "GeneratePayableNumber", 1)
+	assert.True(t, savedPayable.TotalAmount.Equal(decimal.NewFromFloat(1000.00)))
+	assert.True(t, savedPayable.OutstandingAmount.Equal(decimal.NewFromFloat(1000.00)))
+	assert.Equal(t, finance.PayableStatusPending, savedPayable.Status)
+}
+
+func TestPurchaseOrderReceivedHandler_Handle_SkipsRedeliveredReceipts(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockAccountPayableRepository)
+	handler := NewPurchaseOrderReceivedHandler(mockRepo, newTestLogger2())
+
+	tenantID := uuid.New()
+	orderID := uuid.New()
+	supplierID := uuid.New()
+	firstReceipt := newTestPurchaseOrderReceivedEvent(tenantID, orderID, supplierID, false)
+	secondReceipt := newTestPurchaseOrderReceivedEvent(tenantID, orderID, supplierID, true)
+
+	var savedPayable *finance.AccountPayable
+	mockRepo.On("FindBySource", ctx, tenantID, finance.PayableSourceTypePurchaseOrder, orderID).
+		Return(nil, shared.NewDomainError("NOT_FOUND", "not found")).Once()
+	mockRepo.On("GeneratePayableNumber", ctx, tenantID).Return("AP-20260124-001", nil).Once()
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*finance.AccountPayable")).Run(func(args mock.Arguments) {
+		savedPayable = args.Get(1).(*finance.AccountPayable)
+	}).Return(nil).Once()
+	require.NoError(t, handler.Handle(ctx, firstReceipt))
+	require.NotNil(t, savedPayable)
+
+	mockRepo.On("FindBySource", ctx, tenantID, finance.PayableSourceTypePurchaseOrder, orderID).
+		Return(savedPayable, nil)
+	mockRepo.On("SaveWithLock", ctx, savedPayable).Return(nil).Once()
+	require.NoError(t, handler.Handle(ctx, secondReceipt))
+
+	// Both receipts are delivered again, e.g. by the outbox after a crash
+	require.NoError(t, handler.Handle(ctx, firstReceipt))
+	require.NoError(t, handler.Handle(ctx, secondReceipt))
+
+	mockRepo.AssertNumberOfCalls(t, "Save", 1)
+	mockRepo.AssertNumberOfCalls(t, "SaveWithLock", 1)
+	assert.True(t, savedPayable.TotalAmount.Equal(decimal.NewFromFloat(1000.00)))
+}
+
+func TestPurchaseOrderReceivedHandler_Handle_SkipsTerminalExistingPayable(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockAccountPayableRepository)
 	handler := NewPurchaseOrderReceivedHandler(mockRepo, newTestLogger2())
@@ -275,8 +372,8 @@ func TestPurchaseOrderReceivedHandler_Handle_IdempotentWhenExisting(t *testing.T
 	supplierID := uuid.New()
 	event := newTestPurchaseOrderReceivedEvent(tenantID, orderID, supplierID, true)
 
-	// Already exists - return existing payable
-	existingPayable := &finance.AccountPayable{}
+	// Existing payable has been cancelled - receipts can no longer accumulate
+	existingPayable := &finance.AccountPayable{Status: finance.PayableStatusCancelled}
 	existingPayable.ID = uuid.New()
 	mockRepo.On("FindBySource", ctx, tenantID, finance.PayableSourceTypePurchaseOrder, orderID).Return(existingPayable, nil)
 
@@ -286,8 +383,35 @@ func TestPurchaseOrderReceivedHandler_Handle_IdempotentWhenExisting(t *testing.T
 	// Assert - no error, just skipped
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
-	// Save should NOT be called
 	mockRepo.AssertNotCalled(t, "Save")
+	mockRepo.AssertNotCalled(t, "SaveWithLock")
+}
+
+func TestPurchaseOrderReceivedHandler_Handle_UpdateExistingError(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockAccountPayableRepository)
+	handler := NewPurchaseOrderReceivedHandler(mockRepo, newTestLogger2())
+
+	tenantID := uuid.New()
+	orderID := uuid.New()
+	supplierID := uuid.New()
+	event := newTestPurchaseOrderReceivedEvent(tenantID, orderID, supplierID, true)
+
+	existingPayable := &finance.AccountPayable{
+		Status:            finance.PayableStatusPending,
+		TotalAmount:       decimal.NewFromFloat(500.00),
+		OutstandingAmount: decimal.NewFromFloat(500.00),
+	}
+	existingPayable.ID = uuid.New()
+	mockRepo.On("FindBySource", ctx, tenantID, finance.PayableSourceTypePurchaseOrder, orderID).Return(existingPayable, nil)
+	mockRepo.On("SaveWithLock", ctx, existingPayable).Return(errors.New("version conflict"))
+
+	// Execute
+	err := handler.Handle(ctx, event)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to update account payable")
 }
 
 func TestPurchaseOrderReceivedHandler_Handle_SkipZeroAmount(t *testing.T) {
@@ -306,6 +430,7 @@ func TestPurchaseOrderReceivedHandler_Handle_SkipZeroAmount(t *testing.T) {
 			UnitCost: decimal.NewFromFloat(50.00),
 		},
 	}
+	event.PayableAmount = decimal.Zero
 
 	// Execute
 	err := handler.Handle(ctx, event)
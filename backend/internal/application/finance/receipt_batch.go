@@ -0,0 +1,255 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/erp/backend/internal/infrastructure/telemetry"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BatchReceiptRow represents one receipt in a batch, e.g. a line of a bank settlement file
+type BatchReceiptRow struct {
+	CustomerID       uuid.UUID       `json:"customer_id"`
+	CustomerName     string          `json:"customer_name,omitempty"` // Only used when no customer reader is configured
+	Amount           decimal.Decimal `json:"amount"`
+	PaymentMethod    string          `json:"payment_method"`
+	PaymentReference string          `json:"payment_reference"`
+	ReceiptDate      time.Time       `json:"receipt_date"`
+	Remark           string          `json:"remark"`
+}
+
+// CreateAndReconcileReceiptVouchersRequest represents a request to create, confirm and reconcile receipts in bulk
+type CreateAndReconcileReceiptVouchersRequest struct {
+	Rows         []BatchReceiptRow `json:"rows"`
+	StrategyType string            `json:"strategy_type"` // FIFO, LIFO or LARGEST_FIRST; empty uses the tenant default
+	UserID       uuid.UUID         `json:"-"`             // Set from JWT context; used as creator and confirmer
+}
+
+// BatchReceiptRowResult represents the outcome of a single batch row
+type BatchReceiptRowResult struct {
+	Index                int                     `json:"index"`
+	CustomerID           uuid.UUID               `json:"customer_id"`
+	Success              bool                    `json:"success"`
+	Voucher              *ReceiptVoucherResponse `json:"voucher,omitempty"`
+	TotalReconciled      decimal.Decimal         `json:"total_reconciled"`
+	RemainingUnallocated decimal.Decimal         `json:"remaining_unallocated"`
+	FullyReconciled      bool                    `json:"fully_reconciled"`
+	ErrorCode            string                  `json:"error_code,omitempty"`
+	Error                string                  `json:"error,omitempty"`
+}
+
+// CreateAndReconcileReceiptVouchersResult summarizes a batch of receipts
+type CreateAndReconcileReceiptVouchersResult struct {
+	Results                  []BatchReceiptRowResult `json:"results"`
+	TotalRows                int                     `json:"total_rows"`
+	SucceededCount           int                     `json:"succeeded_count"`
+	FailedCount              int                     `json:"failed_count"`
+	FullyReconciledCount     int                     `json:"fully_reconciled_count"`
+	PartiallyReconciledCount int                     `json:"partially_reconciled_count"`
+	UnreconciledCount        int                     `json:"unreconciled_count"` // Created but no open receivables to allocate to
+	TotalAmount              decimal.Decimal         `json:"total_amount"`
+	TotalReconciled          decimal.Decimal         `json:"total_reconciled"`
+}
+
+// CreateAndReconcileReceiptVouchers creates, confirms and reconciles a receipt voucher for each row.
+// Each row runs in its own transaction, so a failing row is reported in its result
+// and does not abort the rest of the batch.
+func (s *FinanceService) CreateAndReconcileReceiptVouchers(ctx context.Context, tenantID uuid.UUID, req CreateAndReconcileReceiptVouchersRequest) (*CreateAndReconcileReceiptVouchersResult, error) {
+	if len(req.Rows) == 0 {
+		return nil, shared.NewDomainError("INVALID_INPUT", "At least one receipt row is required")
+	}
+	if req.UserID == uuid.Nil {
+		return nil, shared.NewDomainError("INVALID_INPUT", "User ID is required to confirm receipt vouchers")
+	}
+
+	strategyType := finance.ReconciliationStrategyType(req.StrategyType)
+	if strategyType == "" {
+		strategyType = s.reconciliationSvc.GetEffectiveStrategy(ctx, tenantID)
+	}
+	if strategyType == finance.ReconciliationStrategyTypeManual {
+		return nil, shared.NewDomainError("INVALID_STRATEGY", "Manual reconciliation is not supported for batch receipts")
+	}
+	if !s.reconciliationSvc.IsStrategyAvailable(strategyType) {
+		return nil, shared.NewDomainError("INVALID_STRATEGY",
+			fmt.Sprintf("Invalid reconciliation strategy type %q, available strategies: %s",
+				strategyType, finance.FormatStrategyTypes(s.reconciliationSvc.AvailableStrategies())))
+	}
+
+	result := &CreateAndReconcileReceiptVouchersResult{
+		Results:         make([]BatchReceiptRowResult, len(req.Rows)),
+		TotalRows:       len(req.Rows),
+		TotalAmount:     decimal.Zero,
+		TotalReconciled: decimal.Zero,
+	}
+
+	for i, row := range req.Rows {
+		rowResult := BatchReceiptRowResult{
+			Index:                i,
+			CustomerID:           row.CustomerID,
+			TotalReconciled:      decimal.Zero,
+			RemainingUnallocated: decimal.Zero,
+		}
+
+		reconciled, err := s.createAndReconcileReceiptRow(ctx, tenantID, row, strategyType, req.UserID)
+		if err != nil {
+			rowResult.ErrorCode, rowResult.Error = batchRowError(err)
+			result.FailedCount++
+			result.Results[i] = rowResult
+			continue
+		}
+
+		rowResult.Success = true
+		rowResult.Voucher = toReceiptVoucherResponse(reconciled.ReceiptVoucher)
+		rowResult.TotalReconciled = reconciled.TotalReconciled
+		rowResult.RemainingUnallocated = reconciled.RemainingUnallocated
+		rowResult.FullyReconciled = reconciled.FullyReconciled
+		result.Results[i] = rowResult
+
+		result.SucceededCount++
+		result.TotalAmount = result.TotalAmount.Add(row.Amount)
+		result.TotalReconciled = result.TotalReconciled.Add(reconciled.TotalReconciled)
+		switch {
+		case reconciled.FullyReconciled:
+			result.FullyReconciledCount++
+		case reconciled.TotalReconciled.IsPositive():
+			result.PartiallyReconciledCount++
+		default:
+			result.UnreconciledCount++
+		}
+	}
+
+	return result, nil
+}
+
+// createAndReconcileReceiptRow creates, confirms and reconciles a single batch row atomically
+func (s *FinanceService) createAndReconcileReceiptRow(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	row BatchReceiptRow,
+	strategyType finance.ReconciliationStrategyType,
+	userID uuid.UUID,
+) (*finance.ReconcileReceiptResult, error) {
+	customerName, err := s.resolveBatchCustomerName(ctx, tenantID, row)
+	if err != nil {
+		return nil, err
+	}
+
+	var reconciled *finance.ReconcileReceiptResult
+	executeRow := func(receiptVoucherRepo finance.ReceiptVoucherRepository, receivableRepo finance.AccountReceivableRepository) error {
+		voucherNumber, err := receiptVoucherRepo.GenerateVoucherNumber(ctx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		voucher, err := finance.NewReceiptVoucher(
+			tenantID,
+			voucherNumber,
+			row.CustomerID,
+			customerName,
+			valueobject.NewMoneyCNY(row.Amount),
+			finance.PaymentMethod(row.PaymentMethod),
+			row.ReceiptDate,
+		)
+		if err != nil {
+			return err
+		}
+		if row.PaymentReference != "" {
+			if err := voucher.SetPaymentReference(row.PaymentReference); err != nil {
+				return err
+			}
+		}
+		if row.Remark != "" {
+			if err := voucher.SetRemark(row.Remark); err != nil {
+				return err
+			}
+		}
+		voucher.SetCreatedBy(userID)
+
+		if err := voucher.Confirm(userID); err != nil {
+			return err
+		}
+
+		receivables, err := receivableRepo.FindOutstanding(ctx, tenantID, row.CustomerID)
+		if err != nil {
+			return err
+		}
+
+		result, err := s.reconciliationSvc.ReconcileReceipt(ctx, finance.ReconcileReceiptRequest{
+			ReceiptVoucher: voucher,
+			Receivables:    receivables,
+			StrategyType:   strategyType,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The voucher is new, so it is saved once with its allocations
+		if err := receiptVoucherRepo.Save(ctx, result.ReceiptVoucher); err != nil {
+			return err
+		}
+		for i := range result.UpdatedReceivables {
+			if err := receivableRepo.SaveWithLock(ctx, &result.UpdatedReceivables[i]); err != nil {
+				return err
+			}
+		}
+
+		reconciled = result
+		return nil
+	}
+
+	// Execute with or without transaction scope
+	if s.txScope != nil {
+		err = s.txScope.Execute(ctx, func(repos TransactionalRepositories) error {
+			return executeRow(repos.ReceiptVoucherRepo(), repos.ReceivableRepo())
+		})
+	} else {
+		err = executeRow(s.receiptVoucherRepo, s.receivableRepo)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.businessMetrics != nil {
+		s.businessMetrics.RecordPaymentReconciled(ctx, tenantID, telemetry.ReconcileDirectionReceipt, reconciled.FullyReconciled)
+	}
+
+	return reconciled, nil
+}
+
+// resolveBatchCustomerName looks up the customer for a batch row.
+// Without a customer reader the name supplied in the row is used.
+func (s *FinanceService) resolveBatchCustomerName(ctx context.Context, tenantID uuid.UUID, row BatchReceiptRow) (string, error) {
+	if row.CustomerID == uuid.Nil {
+		return "", shared.NewDomainError("INVALID_CUSTOMER", "Customer ID cannot be empty")
+	}
+	if s.customerReader == nil {
+		return row.CustomerName, nil
+	}
+
+	customer, err := s.customerReader.FindByIDForTenant(ctx, tenantID, row.CustomerID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return "", shared.NewDomainError("CUSTOMER_NOT_FOUND", fmt.Sprintf("Customer %s not found", row.CustomerID))
+		}
+		return "", err
+	}
+	if customer == nil {
+		return "", shared.NewDomainError("CUSTOMER_NOT_FOUND", fmt.Sprintf("Customer %s not found", row.CustomerID))
+	}
+	return customer.Name, nil
+}
+
+// batchRowError extracts an error code and message for a failed batch row
+func batchRowError(err error) (string, string) {
+	var domainErr *shared.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code, domainErr.Message
+	}
+	return "INTERNAL_ERROR", err.Error()
+}
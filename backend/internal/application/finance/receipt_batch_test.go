@@ -0,0 +1,122 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchTestReceivable(t *testing.T, tenantID, customerID uuid.UUID, amount int64) finance.AccountReceivable {
+	dueDate := time.Now().AddDate(0, 0, 30)
+	receivable, err := finance.NewAccountReceivable(
+		tenantID, "AR-"+uuid.NewString()[:8], customerID, "Batch Customer",
+		finance.SourceTypeSalesOrder, uuid.New(), "SO-001",
+		valueobject.NewMoneyCNY(decimal.NewFromInt(amount)), &dueDate,
+	)
+	require.NoError(t, err)
+	return *receivable
+}
+
+func newBatchTestCustomer(t *testing.T, tenantID uuid.UUID, name string) *partner.Customer {
+	customer, err := partner.NewCustomer(tenantID, "C-"+uuid.NewString()[:8], name, partner.CustomerTypeOrganization)
+	require.NoError(t, err)
+	return customer
+}
+
+func TestFinanceService_CreateAndReconcileReceiptVouchers(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	userID := uuid.New()
+	receiptDate := time.Now()
+
+	t.Run("reports per-row results without aborting the batch", func(t *testing.T) {
+		receivableRepo := new(MockAccountReceivableRepository)
+		voucherRepo := new(MockReceiptVoucherRepository)
+		customers := new(MockCustomerReader)
+		svc := NewFinanceService(receivableRepo, nil, voucherRepo, nil,
+			WithCustomerReader(customers),
+			WithTransactionScope(NewNoOpTransactionScope(receivableRepo, voucherRepo, nil, nil)),
+		)
+
+		fullCustomer := newBatchTestCustomer(t, tenantID, "Full Customer")
+		partialCustomer := newBatchTestCustomer(t, tenantID, "Partial Customer")
+		unknownCustomerID := uuid.New()
+
+		customers.On("FindByIDForTenant", ctx, tenantID, fullCustomer.ID).Return(fullCustomer, nil)
+		customers.On("FindByIDForTenant", ctx, tenantID, partialCustomer.ID).Return(partialCustomer, nil)
+		customers.On("FindByIDForTenant", ctx, tenantID, unknownCustomerID).Return(nil, shared.ErrNotFound)
+
+		receivableRepo.On("FindOutstanding", ctx, tenantID, fullCustomer.ID).
+			Return([]finance.AccountReceivable{newBatchTestReceivable(t, tenantID, fullCustomer.ID, 1000)}, nil)
+		receivableRepo.On("FindOutstanding", ctx, tenantID, partialCustomer.ID).
+			Return([]finance.AccountReceivable{newBatchTestReceivable(t, tenantID, partialCustomer.ID, 1000)}, nil)
+		receivableRepo.On("SaveWithLock", ctx, mock.AnythingOfType("*finance.AccountReceivable")).Return(nil)
+		voucherRepo.On("GenerateVoucherNumber", ctx, tenantID).Return("RV-001", nil)
+		voucherRepo.On("Save", ctx, mock.AnythingOfType("*finance.ReceiptVoucher")).Return(nil)
+
+		result, err := svc.CreateAndReconcileReceiptVouchers(ctx, tenantID, CreateAndReconcileReceiptVouchersRequest{
+			Rows: []BatchReceiptRow{
+				{CustomerID: fullCustomer.ID, Amount: decimal.NewFromInt(1000), PaymentMethod: "BANK_TRANSFER", ReceiptDate: receiptDate},
+				{CustomerID: unknownCustomerID, Amount: decimal.NewFromInt(500), PaymentMethod: "BANK_TRANSFER", ReceiptDate: receiptDate},
+				{CustomerID: partialCustomer.ID, Amount: decimal.NewFromInt(1500), PaymentMethod: "BANK_TRANSFER", ReceiptDate: receiptDate},
+			},
+			StrategyType: "FIFO",
+			UserID:       userID,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, result.TotalRows)
+		assert.Equal(t, 2, result.SucceededCount)
+		assert.Equal(t, 1, result.FailedCount)
+		assert.Equal(t, 1, result.FullyReconciledCount)
+		assert.Equal(t, 1, result.PartiallyReconciledCount)
+		assert.True(t, result.TotalAmount.Equal(decimal.NewFromInt(2500)))
+		assert.True(t, result.TotalReconciled.Equal(decimal.NewFromInt(2000)))
+
+		assert.True(t, result.Results[0].Success)
+		assert.True(t, result.Results[0].FullyReconciled)
+		assert.Equal(t, string(finance.VoucherStatusAllocated), result.Results[0].Voucher.Status)
+		assert.Equal(t, "Full Customer", result.Results[0].Voucher.CustomerName)
+
+		assert.False(t, result.Results[1].Success)
+		assert.Equal(t, "CUSTOMER_NOT_FOUND", result.Results[1].ErrorCode)
+		assert.Nil(t, result.Results[1].Voucher)
+
+		assert.True(t, result.Results[2].Success)
+		assert.False(t, result.Results[2].FullyReconciled)
+		assert.True(t, result.Results[2].RemainingUnallocated.Equal(decimal.NewFromInt(500)))
+
+		voucherRepo.AssertNumberOfCalls(t, "Save", 2)
+	})
+
+	t.Run("rejects manual strategy", func(t *testing.T) {
+		svc := NewFinanceService(nil, nil, nil, nil)
+
+		_, err := svc.CreateAndReconcileReceiptVouchers(ctx, tenantID, CreateAndReconcileReceiptVouchersRequest{
+			Rows:         []BatchReceiptRow{{CustomerID: uuid.New(), Amount: decimal.NewFromInt(100)}},
+			StrategyType: "MANUAL",
+			UserID:       userID,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Manual reconciliation is not supported")
+	})
+
+	t.Run("rejects empty batch", func(t *testing.T) {
+		svc := NewFinanceService(nil, nil, nil, nil)
+
+		_, err := svc.CreateAndReconcileReceiptVouchers(ctx, tenantID, CreateAndReconcileReceiptVouchersRequest{UserID: userID})
+
+		require.Error(t, err)
+	})
+}
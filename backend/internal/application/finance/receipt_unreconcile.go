@@ -0,0 +1,79 @@
+package finance
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// UnreconcileReceiptVoucherRequest represents a request to undo one allocation of a receipt voucher
+type UnreconcileReceiptVoucherRequest struct {
+	VoucherID    uuid.UUID `json:"voucher_id"`
+	AllocationID uuid.UUID `json:"allocation_id"`
+	Reason       string    `json:"reason"`
+}
+
+// UnreconcileReceiptVoucherResult represents the result of undoing a receipt voucher allocation
+type UnreconcileReceiptVoucherResult struct {
+	Voucher           *ReceiptVoucherResponse    `json:"voucher"`
+	UpdatedReceivable *AccountReceivableResponse `json:"updated_receivable"`
+	ReversedAmount    decimal.Decimal            `json:"reversed_amount"`
+}
+
+// UnreconcileReceiptVoucher removes an allocation from a receipt voucher, e.g. when it was applied to the wrong invoice.
+// The allocated amount becomes outstanding on the receivable again and returns to the voucher's unallocated pool,
+// so it can be reconciled to another receivable.
+func (s *FinanceService) UnreconcileReceiptVoucher(ctx context.Context, tenantID uuid.UUID, req UnreconcileReceiptVoucherRequest) (*UnreconcileReceiptVoucherResult, error) {
+	voucher, err := s.receiptVoucherRepo.FindByIDForTenant(ctx, tenantID, req.VoucherID)
+	if err != nil {
+		return nil, err
+	}
+	if voucher == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Receipt voucher not found")
+	}
+
+	allocation, err := voucher.RemoveAllocation(req.AllocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	receivable, err := s.receivableRepo.FindByIDForTenant(ctx, tenantID, allocation.ReceivableID)
+	if err != nil {
+		return nil, err
+	}
+	if receivable == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Account receivable not found")
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "Allocation removed from receipt voucher " + voucher.VoucherNumber
+	}
+	if _, err := receivable.ReversePayment(allocation, reason); err != nil {
+		return nil, err
+	}
+
+	scope := s.txScope
+	if scope == nil {
+		scope = NewNoOpTransactionScope(s.receivableRepo, s.receiptVoucherRepo, s.paymentVoucherRepo, s.idempotencyKeyRepo)
+	}
+	err = scope.Execute(ctx, func(repos TransactionalRepositories) error {
+		if err := repos.ReceiptVoucherRepo().SaveWithLock(ctx, voucher); err != nil {
+			return err
+		}
+		return repos.ReceivableRepo().SaveWithLock(ctx, receivable)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishDomainEvents(ctx, voucher, receivable)
+
+	return &UnreconcileReceiptVoucherResult{
+		Voucher:           toReceiptVoucherResponse(voucher),
+		UpdatedReceivable: ToReceivableResponse(receivable),
+		ReversedAmount:    allocation.Amount,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package finance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinanceService_UnreconcileReceiptVoucher(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	customerID := uuid.New()
+
+	// newReconciled returns a confirmed 1000 voucher fully allocated to a 1000 receivable
+	newReconciled := func(t *testing.T) (*finance.ReceiptVoucher, *finance.AccountReceivable, *finance.ReceivableAllocation) {
+		voucher := newIdempotencyTestReceiptVoucher(t, tenantID, customerID, 1000)
+		require.NoError(t, voucher.Confirm(uuid.New()))
+		receivable := newBatchTestReceivable(t, tenantID, customerID, 1000)
+
+		allocation, err := voucher.AllocateToReceivable(receivable.ID, receivable.ReceivableNumber, valueobject.NewMoneyCNY(decimal.NewFromInt(1000)), "")
+		require.NoError(t, err)
+		require.NoError(t, receivable.ApplyAllocation(allocation, valueobject.NewMoneyCNY(decimal.NewFromInt(1000)), ""))
+		voucher.ClearDomainEvents()
+		receivable.ClearDomainEvents()
+		return voucher, &receivable, allocation
+	}
+
+	newService := func(receivableRepo *MockAccountReceivableRepository, voucherRepo *MockReceiptVoucherRepository) *FinanceService {
+		return NewFinanceService(receivableRepo, nil, voucherRepo, nil,
+			WithTransactionScope(NewNoOpTransactionScope(receivableRepo, voucherRepo, nil, nil)),
+		)
+	}
+
+	t.Run("restores outstanding amount and publishes events", func(t *testing.T) {
+		voucher, receivable, allocation := newReconciled(t)
+		receivableRepo := new(MockAccountReceivableRepository)
+		voucherRepo := new(MockReceiptVoucherRepository)
+		publisher := new(MockEventPublisher)
+		svc := newService(receivableRepo, voucherRepo)
+		svc.SetEventPublisher(publisher)
+
+		voucherRepo.On("FindByIDForTenant", ctx, tenantID, voucher.ID).Return(voucher, nil)
+		receivableRepo.On("FindByIDForTenant", ctx, tenantID, receivable.ID).Return(receivable, nil)
+		voucherRepo.On("SaveWithLock", ctx, voucher).Return(nil)
+		receivableRepo.On("SaveWithLock", ctx, receivable).Return(nil)
+		publisher.On("Publish", ctx, mock.Anything).Return(nil)
+
+		result, err := svc.UnreconcileReceiptVoucher(ctx, tenantID, UnreconcileReceiptVoucherRequest{
+			VoucherID:    voucher.ID,
+			AllocationID: allocation.ID,
+			Reason:       "Applied to wrong invoice",
+		})
+		require.NoError(t, err)
+
+		assert.True(t, result.ReversedAmount.Equal(decimal.NewFromInt(1000)))
+		assert.Equal(t, string(finance.VoucherStatusConfirmed), result.Voucher.Status)
+		assert.True(t, result.Voucher.UnallocatedAmount.Equal(decimal.NewFromInt(1000)))
+		assert.Equal(t, string(finance.ReceivableStatusPending), result.UpdatedReceivable.Status)
+		assert.True(t, result.UpdatedReceivable.OutstandingAmount.Equal(decimal.NewFromInt(1000)))
+
+		publisher.AssertNumberOfCalls(t, "Publish", 2)
+		assert.Empty(t, voucher.GetDomainEvents())
+		assert.Empty(t, receivable.GetDomainEvents())
+	})
+
+	t.Run("does not save when the allocation is unknown", func(t *testing.T) {
+		voucher, _, _ := newReconciled(t)
+		receivableRepo := new(MockAccountReceivableRepository)
+		voucherRepo := new(MockReceiptVoucherRepository)
+		svc := newService(receivableRepo, voucherRepo)
+
+		voucherRepo.On("FindByIDForTenant", ctx, tenantID, voucher.ID).Return(voucher, nil)
+
+		_, err := svc.UnreconcileReceiptVoucher(ctx, tenantID, UnreconcileReceiptVoucherRequest{
+			VoucherID:    voucher.ID,
+			AllocationID: uuid.New(),
+		})
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Code)
+		voucherRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+}
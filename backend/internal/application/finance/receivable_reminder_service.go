@@ -0,0 +1,108 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"go.uber.org/zap"
+)
+
+// ReceivableReminderService raises due-date reminders for outstanding receivables.
+// It is run periodically by a background job; each reminder stage is sent at most once per receivable.
+type ReceivableReminderService struct {
+	receivableRepo finance.AccountReceivableRepository
+	eventPublisher shared.EventPublisher
+	leadTime       time.Duration
+	logger         *zap.Logger
+}
+
+// NewReceivableReminderService creates a new ReceivableReminderService.
+// leadTime is how long before the due date a due-soon reminder is sent.
+func NewReceivableReminderService(
+	receivableRepo finance.AccountReceivableRepository,
+	leadTime time.Duration,
+	logger *zap.Logger,
+) *ReceivableReminderService {
+	return &ReceivableReminderService{
+		receivableRepo: receivableRepo,
+		leadTime:       leadTime,
+		logger:         logger,
+	}
+}
+
+// SetEventPublisher sets the event publisher for reminder events
+func (s *ReceivableReminderService) SetEventPublisher(publisher shared.EventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// ReceivableReminderStats contains statistics about a reminder run
+type ReceivableReminderStats struct {
+	TotalCandidates int       `json:"total_candidates"`
+	DueSoonSent     int       `json:"due_soon_sent"`
+	OverdueSent     int       `json:"overdue_sent"`
+	Failed          int       `json:"failed"`
+	ProcessedAt     time.Time `json:"processed_at"`
+}
+
+// SendDueReminders finds receivables that are due soon or overdue and publishes a reminder event for each.
+// The reminder is recorded on the receivable before its event is published, so a failed save never
+// results in a duplicate reminder on the next run.
+func (s *ReceivableReminderService) SendDueReminders(ctx context.Context) (*ReceivableReminderStats, error) {
+	now := time.Now()
+	stats := &ReceivableReminderStats{
+		ProcessedAt: now,
+	}
+
+	receivables, err := s.receivableRepo.FindDueForReminder(ctx, now.Add(s.leadTime))
+	if err != nil {
+		s.logger.Error("Failed to find receivables due for reminder", zap.Error(err))
+		return nil, err
+	}
+
+	stats.TotalCandidates = len(receivables)
+	if stats.TotalCandidates == 0 {
+		s.logger.Debug("No receivables due for reminder")
+		return stats, nil
+	}
+
+	for i := range receivables {
+		receivable := &receivables[i]
+		stage := receivable.RecordDueReminder(now, s.leadTime)
+		if stage == finance.ReceivableReminderStageNone {
+			continue
+		}
+
+		if err := s.receivableRepo.SaveWithLock(ctx, receivable); err != nil {
+			s.logger.Error("Failed to record receivable reminder",
+				zap.String("receivable_id", receivable.ID.String()),
+				zap.String("stage", string(stage)),
+				zap.Error(err),
+			)
+			stats.Failed++
+			continue
+		}
+
+		if s.eventPublisher != nil {
+			// Publish events (errors are logged by the event bus, not propagated)
+			_ = s.eventPublisher.Publish(ctx, receivable.GetDomainEvents()...)
+		}
+		receivable.ClearDomainEvents()
+
+		if stage == finance.ReceivableReminderStageOverdue {
+			stats.OverdueSent++
+		} else {
+			stats.DueSoonSent++
+		}
+	}
+
+	s.logger.Info("Completed receivable due-date reminders",
+		zap.Int("candidates", stats.TotalCandidates),
+		zap.Int("due_soon", stats.DueSoonSent),
+		zap.Int("overdue", stats.OverdueSent),
+		zap.Int("failed", stats.Failed),
+	)
+
+	return stats, nil
+}
@@ -0,0 +1,92 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReceivableReminderService_SendDueReminders(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	customerID := uuid.New()
+	leadTime := 3 * 24 * time.Hour
+
+	newReceivableDueIn := func(t *testing.T, d time.Duration) finance.AccountReceivable {
+		receivable := newBatchTestReceivable(t, tenantID, customerID, 1000)
+		dueDate := time.Now().Add(d)
+		receivable.DueDate = &dueDate
+		receivable.ClearDomainEvents()
+		return receivable
+	}
+
+	t.Run("sends due-soon and overdue reminders", func(t *testing.T) {
+		dueSoon := newReceivableDueIn(t, 24*time.Hour)
+		overdue := newReceivableDueIn(t, -48*time.Hour)
+		alreadyReminded := newReceivableDueIn(t, 24*time.Hour)
+		alreadyReminded.ReminderStage = finance.ReceivableReminderStageDueSoon
+
+		receivableRepo := new(MockAccountReceivableRepository)
+		publisher := new(MockEventPublisher)
+		receivableRepo.On("FindDueForReminder", ctx, mock.AnythingOfType("time.Time")).
+			Return([]finance.AccountReceivable{dueSoon, overdue, alreadyReminded}, nil)
+		receivableRepo.On("SaveWithLock", ctx, mock.Anything).Return(nil)
+		publisher.On("Publish", ctx, mock.Anything).Return(nil)
+
+		svc := NewReceivableReminderService(receivableRepo, leadTime, zap.NewNop())
+		svc.SetEventPublisher(publisher)
+
+		stats, err := svc.SendDueReminders(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, stats.TotalCandidates)
+		assert.Equal(t, 1, stats.DueSoonSent)
+		assert.Equal(t, 1, stats.OverdueSent)
+		assert.Equal(t, 0, stats.Failed)
+		receivableRepo.AssertNumberOfCalls(t, "SaveWithLock", 2)
+		receivableRepo.AssertCalled(t, "SaveWithLock", ctx, mock.MatchedBy(func(ar *finance.AccountReceivable) bool {
+			return ar.ID == overdue.ID && ar.ReminderStage == finance.ReceivableReminderStageOverdue && ar.LastReminderSentAt != nil
+		}))
+		publisher.AssertNumberOfCalls(t, "Publish", 2)
+	})
+
+	t.Run("does not publish when the reminder cannot be recorded", func(t *testing.T) {
+		overdue := newReceivableDueIn(t, -48*time.Hour)
+
+		receivableRepo := new(MockAccountReceivableRepository)
+		publisher := new(MockEventPublisher)
+		receivableRepo.On("FindDueForReminder", ctx, mock.AnythingOfType("time.Time")).
+			Return([]finance.AccountReceivable{overdue}, nil)
+		receivableRepo.On("SaveWithLock", ctx, mock.Anything).Return(shared.ErrConcurrencyConflict)
+
+		svc := NewReceivableReminderService(receivableRepo, leadTime, zap.NewNop())
+		svc.SetEventPublisher(publisher)
+
+		stats, err := svc.SendDueReminders(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, stats.Failed)
+		assert.Equal(t, 0, stats.OverdueSent)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		receivableRepo := new(MockAccountReceivableRepository)
+		receivableRepo.On("FindDueForReminder", ctx, mock.AnythingOfType("time.Time")).
+			Return(nil, errors.New("database unavailable"))
+
+		svc := NewReceivableReminderService(receivableRepo, leadTime, zap.NewNop())
+
+		_, err := svc.SendDueReminders(ctx)
+		require.Error(t, err)
+	})
+}
@@ -54,15 +54,12 @@ func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.Doma
 		zap.String("customer_id", shippedEvent.CustomerID.String()),
 		zap.String("customer_name", shippedEvent.CustomerName),
 		zap.String("payable_amount", shippedEvent.PayableAmount.String()),
+		zap.Int("shipment_number", shippedEvent.ShipmentNumber),
+		zap.Bool("is_fully_shipped", shippedEvent.IsFullyShipped),
 	)
 
-	// Idempotency check: verify receivable doesn't already exist for this source
-	exists, err := h.receivableRepo.ExistsBySource(
-		ctx,
-		shippedEvent.TenantID(),
-		finance.SourceTypeSalesOrder,
-		shippedEvent.OrderID,
-	)
+	// Idempotency check: verify receivable doesn't already exist for this shipment
+	exists, err := h.shipmentInvoiced(ctx, shippedEvent)
 	if err != nil {
 		h.logger.Error("failed to check existing receivable",
 			zap.String("order_id", shippedEvent.OrderID.String()),
@@ -71,9 +68,10 @@ func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.Doma
 		return fmt.Errorf("failed to check existing receivable: %w", err)
 	}
 	if exists {
-		h.logger.Warn("receivable already exists for sales order, skipping",
+		h.logger.Warn("receivable already exists for sales order shipment, skipping",
 			zap.String("order_id", shippedEvent.OrderID.String()),
 			zap.String("order_number", shippedEvent.OrderNumber),
+			zap.Int("shipment_number", shippedEvent.ShipmentNumber),
 		)
 		return nil // Idempotent - already processed
 	}
@@ -124,7 +122,9 @@ func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.Doma
 		return fmt.Errorf("failed to create account receivable: %w", err)
 	}
 
-	// Save the receivable
+	receivable.ShipmentNumber = receivableShipmentNumber(shippedEvent)
+
+	// Save the receivable; a unique index on the shipment rejects a concurrent duplicate
 	if err := h.receivableRepo.Save(ctx, receivable); err != nil {
 		h.logger.Error("failed to save account receivable",
 			zap.String("order_id", shippedEvent.OrderID.String()),
@@ -148,5 +148,28 @@ func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.Doma
 	return nil
 }
 
+// shipmentInvoiced checks whether a receivable was already created for the shipment.
+// Each shipment of an order creates its own receivable, keyed by its shipment number,
+// so shipments redelivered out of order are each recognised on their own.
+func (h *SalesOrderShippedHandler) shipmentInvoiced(ctx context.Context, shippedEvent *trade.SalesOrderShippedEvent) (bool, error) {
+	sourceType := finance.SourceTypeSalesOrder
+	shipmentNumber := receivableShipmentNumber(shippedEvent)
+	count, err := h.receivableRepo.CountForTenant(ctx, shippedEvent.TenantID(), finance.AccountReceivableFilter{
+		SourceType:     &sourceType,
+		SourceID:       &shippedEvent.OrderID,
+		ShipmentNumber: &shipmentNumber,
+	})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// receivableShipmentNumber returns the shipment number a shipment's receivable is keyed on.
+// Events raised before shipments were numbered belong to the order's first shipment.
+func receivableShipmentNumber(shippedEvent *trade.SalesOrderShippedEvent) int {
+	return max(shippedEvent.ShipmentNumber, 1)
+}
+
 // Ensure SalesOrderShippedHandler implements shared.EventHandler
 var _ shared.EventHandler = (*SalesOrderShippedHandler)(nil)
@@ -8,11 +8,13 @@ import (
 
 	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/erp/backend/internal/domain/trade"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -78,6 +80,14 @@ func (m *MockAccountReceivableRepository) FindOverdue(ctx context.Context, tenan
 	return args.Get(0).([]finance.AccountReceivable), args.Error(1)
 }
 
+func (m *MockAccountReceivableRepository) FindDueForReminder(ctx context.Context, dueBefore time.Time) ([]finance.AccountReceivable, error) {
+	args := m.Called(ctx, dueBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.AccountReceivable), args.Error(1)
+}
+
 func (m *MockAccountReceivableRepository) Save(ctx context.Context, receivable *finance.AccountReceivable) error {
 	args := m.Called(ctx, receivable)
 	return args.Error(0)
@@ -128,6 +138,14 @@ func (m *MockAccountReceivableRepository) SumOutstandingByCustomer(ctx context.C
 	return args.Get(0).(decimal.Decimal), args.Error(1)
 }
 
+func (m *MockAccountReceivableRepository) SumOutstandingByCustomerByCurrency(ctx context.Context, tenantID, customerID uuid.UUID) (map[valueobject.Currency]decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[valueobject.Currency]decimal.Decimal), args.Error(1)
+}
+
 func (m *MockAccountReceivableRepository) SumOutstandingForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
 	args := m.Called(ctx, tenantID)
 	return args.Get(0).(decimal.Decimal), args.Error(1)
@@ -138,6 +156,27 @@ func (m *MockAccountReceivableRepository) SumOverdueForTenant(ctx context.Contex
 	return args.Get(0).(decimal.Decimal), args.Error(1)
 }
 
+func (m *MockAccountReceivableRepository) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.AgingEntry), args.Error(1)
+}
+
+func (m *MockAccountReceivableRepository) ListPaymentRecords(ctx context.Context, tenantID, receivableID uuid.UUID, page, pageSize int) ([]finance.ReceivablePaymentRecord, int64, error) {
+	args := m.Called(ctx, tenantID, receivableID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]finance.ReceivablePaymentRecord), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAccountReceivableRepository) SumWrittenOffForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
 func (m *MockAccountReceivableRepository) ExistsByReceivableNumber(ctx context.Context, tenantID uuid.UUID, receivableNumber string) (bool, error) {
 	args := m.Called(ctx, tenantID, receivableNumber)
 	return args.Bool(0), args.Error(1)
@@ -187,6 +226,15 @@ func newTestSalesOrderShippedEvent(tenantID, orderID, customerID uuid.UUID) *tra
 	}
 }
 
+// onShipmentReceivableCount expects the count of the order's receivables for the shipment
+func onShipmentReceivableCount(mockRepo *MockAccountReceivableRepository, ctx context.Context, tenantID, orderID uuid.UUID, shipmentNumber int) *mock.Call {
+	return mockRepo.On("CountForTenant", ctx, tenantID, mock.MatchedBy(func(filter finance.AccountReceivableFilter) bool {
+		return filter.SourceType != nil && *filter.SourceType == finance.SourceTypeSalesOrder &&
+			filter.SourceID != nil && *filter.SourceID == orderID &&
+			filter.ShipmentNumber != nil && *filter.ShipmentNumber == shipmentNumber
+	}))
+}
+
 // Tests for SalesOrderShippedHandler
 func TestSalesOrderShippedHandler_EventTypes(t *testing.T) {
 	mockRepo := new(MockAccountReceivableRepository)
@@ -208,7 +256,7 @@ func TestSalesOrderShippedHandler_Handle_Success(t *testing.T) {
 	event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
 
 	// Setup expectations
-	mockRepo.On("ExistsBySource", ctx, tenantID, finance.SourceTypeSalesOrder, orderID).Return(false, nil)
+	onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(0), nil)
 	mockRepo.On("GenerateReceivableNumber", ctx, tenantID).Return("AR-20260124-001", nil)
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*finance.AccountReceivable")).Return(nil)
 
@@ -249,7 +297,7 @@ func TestSalesOrderShippedHandler_Handle_IdempotentWhenExisting(t *testing.T) {
 	event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
 
 	// Already exists
-	mockRepo.On("ExistsBySource", ctx, tenantID, finance.SourceTypeSalesOrder, orderID).Return(true, nil)
+	onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(1), nil)
 
 	// Execute
 	err := handler.Handle(ctx, event)
@@ -273,7 +321,7 @@ func TestSalesOrderShippedHandler_Handle_SkipZeroAmount(t *testing.T) {
 	event.PayableAmount = decimal.Zero // Fully prepaid
 
 	// Not existing
-	mockRepo.On("ExistsBySource", ctx, tenantID, finance.SourceTypeSalesOrder, orderID).Return(false, nil)
+	onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(0), nil)
 
 	// Execute
 	err := handler.Handle(ctx, event)
@@ -297,7 +345,7 @@ func TestSalesOrderShippedHandler_Handle_ExistsCheckError(t *testing.T) {
 	event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
 
 	// Database error
-	mockRepo.On("ExistsBySource", ctx, tenantID, finance.SourceTypeSalesOrder, orderID).Return(false, errors.New("db error"))
+	onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(0), errors.New("db error"))
 
 	// Execute
 	err := handler.Handle(ctx, event)
@@ -317,7 +365,7 @@ func TestSalesOrderShippedHandler_Handle_GenerateNumberError(t *testing.T) {
 	customerID := uuid.New()
 	event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
 
-	mockRepo.On("ExistsBySource", ctx, tenantID, finance.SourceTypeSalesOrder, orderID).Return(false, nil)
+	onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(0), nil)
 	mockRepo.On("GenerateReceivableNumber", ctx, tenantID).Return("", errors.New("generate error"))
 
 	// Execute
@@ -338,7 +386,7 @@ func TestSalesOrderShippedHandler_Handle_SaveError(t *testing.T) {
 	customerID := uuid.New()
 	event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
 
-	mockRepo.On("ExistsBySource", ctx, tenantID, finance.SourceTypeSalesOrder, orderID).Return(false, nil)
+	onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(0), nil)
 	mockRepo.On("GenerateReceivableNumber", ctx, tenantID).Return("AR-20260124-001", nil)
 	mockRepo.On("Save", ctx, mock.AnythingOfType("*finance.AccountReceivable")).Return(errors.New("save error"))
 
@@ -361,7 +409,7 @@ func TestSalesOrderShippedHandler_Handle_CreatesCorrectReceivable(t *testing.T)
 	event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
 	receivableNumber := "AR-20260124-001"
 
-	mockRepo.On("ExistsBySource", ctx, tenantID, finance.SourceTypeSalesOrder, orderID).Return(false, nil)
+	onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(0), nil)
 	mockRepo.On("GenerateReceivableNumber", ctx, tenantID).Return(receivableNumber, nil)
 
 	var savedReceivable *finance.AccountReceivable
@@ -387,3 +435,89 @@ func TestSalesOrderShippedHandler_Handle_CreatesCorrectReceivable(t *testing.T)
 	assert.NotNil(t, savedReceivable.DueDate)
 	assert.True(t, savedReceivable.DueDate.After(time.Now()))
 }
+
+func TestSalesOrderShippedHandler_Handle_PartialShipments(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	orderID := uuid.New()
+	customerID := uuid.New()
+
+	t.Run("creates a receivable keyed on a later shipment", func(t *testing.T) {
+		mockRepo := new(MockAccountReceivableRepository)
+		handler := NewSalesOrderShippedHandler(mockRepo, newTestLogger())
+		event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
+		event.ShipmentNumber = 2
+		event.PayableAmount = decimal.NewFromFloat(400.00)
+
+		onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 2).Return(int64(0), nil)
+		mockRepo.On("GenerateReceivableNumber", ctx, tenantID).Return("AR-20260124-002", nil)
+
+		var savedReceivable *finance.AccountReceivable
+		mockRepo.On("Save", ctx, mock.AnythingOfType("*finance.AccountReceivable")).Run(func(args mock.Arguments) {
+			savedReceivable = args.Get(1).(*finance.AccountReceivable)
+		}).Return(nil)
+
+		err := handler.Handle(ctx, event)
+
+		require.NoError(t, err)
+		require.NotNil(t, savedReceivable)
+		assert.True(t, savedReceivable.TotalAmount.Equal(decimal.NewFromFloat(400.00)))
+		assert.Equal(t, 2, savedReceivable.ShipmentNumber)
+	})
+
+	t.Run("skips a shipment that already has its receivable", func(t *testing.T) {
+		mockRepo := new(MockAccountReceivableRepository)
+		handler := NewSalesOrderShippedHandler(mockRepo, newTestLogger())
+		event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
+		event.ShipmentNumber = 2
+
+		onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 2).Return(int64(1), nil)
+
+		err := handler.Handle(ctx, event)
+
+		require.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates each receivable when shipment 2 is delivered before shipment 1", func(t *testing.T) {
+		mockRepo := new(MockAccountReceivableRepository)
+		handler := NewSalesOrderShippedHandler(mockRepo, newTestLogger())
+		first := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
+		first.ShipmentNumber = 1
+		first.PayableAmount = decimal.NewFromFloat(600.00)
+		second := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
+		second.ShipmentNumber = 2
+		second.PayableAmount = decimal.NewFromFloat(400.00)
+
+		// The order already has shipment 2's receivable when shipment 1 is redelivered
+		onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 2).Return(int64(0), nil).Once()
+		onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(0), nil).Once()
+		mockRepo.On("GenerateReceivableNumber", ctx, tenantID).Return("AR-20260124-002", nil)
+
+		var saved []*finance.AccountReceivable
+		mockRepo.On("Save", ctx, mock.AnythingOfType("*finance.AccountReceivable")).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*finance.AccountReceivable))
+		}).Return(nil)
+
+		require.NoError(t, handler.Handle(ctx, second))
+		require.NoError(t, handler.Handle(ctx, first))
+
+		require.Len(t, saved, 2)
+		assert.Equal(t, 2, saved[0].ShipmentNumber)
+		assert.Equal(t, 1, saved[1].ShipmentNumber)
+		assert.True(t, saved[1].TotalAmount.Equal(decimal.NewFromFloat(600.00)))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("keys events raised before shipments were numbered on the first shipment", func(t *testing.T) {
+		mockRepo := new(MockAccountReceivableRepository)
+		handler := NewSalesOrderShippedHandler(mockRepo, newTestLogger())
+		event := newTestSalesOrderShippedEvent(tenantID, orderID, customerID)
+
+		onShipmentReceivableCount(mockRepo, ctx, tenantID, orderID, 1).Return(int64(1), nil)
+
+		require.NoError(t, handler.Handle(ctx, event))
+
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
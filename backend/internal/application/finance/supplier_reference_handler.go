@@ -0,0 +1,160 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/finance/acl"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SupplierReferenceHandler keeps the Finance context's view of suppliers in sync with the Partner context.
+// It translates Partner supplier events into ACL event DTOs, maintains the supplier reference cache
+// and refreshes the denormalized supplier name on payables when a supplier is renamed.
+type SupplierReferenceHandler struct {
+	payableRepo finance.AccountPayableRepository
+	cache       acl.SupplierReferenceCache // Optional
+	logger      *zap.Logger
+}
+
+// NewSupplierReferenceHandler creates a new handler for supplier events.
+// cache may be nil, in which case only payables are updated.
+func NewSupplierReferenceHandler(
+	payableRepo finance.AccountPayableRepository,
+	cache acl.SupplierReferenceCache,
+	logger *zap.Logger,
+) *SupplierReferenceHandler {
+	return &SupplierReferenceHandler{
+		payableRepo: payableRepo,
+		cache:       cache,
+		logger:      logger,
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *SupplierReferenceHandler) EventTypes() []string {
+	return []string{
+		partner.EventTypeSupplierCreated,
+		partner.EventTypeSupplierUpdated,
+		partner.EventTypeSupplierDeleted,
+		partner.EventTypeSupplierStatusChanged,
+	}
+}
+
+// Handle translates a Partner supplier event and dispatches it to the matching ACL handler method
+func (h *SupplierReferenceHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	switch e := event.(type) {
+	case *partner.SupplierCreatedEvent:
+		return h.HandleSupplierCreated(ctx, acl.SupplierCreatedEventDTO{
+			TenantID:   e.TenantID(),
+			SupplierID: e.SupplierID,
+			Code:       e.Code,
+			Name:       e.Name,
+		})
+	case *partner.SupplierUpdatedEvent:
+		return h.HandleSupplierUpdated(ctx, acl.SupplierUpdatedEventDTO{
+			TenantID:    e.TenantID(),
+			SupplierID:  e.SupplierID,
+			Code:        e.Code,
+			Name:        e.Name,
+			ShortName:   e.ShortName,
+			ContactName: e.ContactName,
+			Phone:       e.Phone,
+			Email:       e.Email,
+		})
+	case *partner.SupplierDeletedEvent:
+		return h.HandleSupplierDeleted(ctx, acl.SupplierDeletedEventDTO{
+			TenantID:   e.TenantID(),
+			SupplierID: e.SupplierID,
+			Code:       e.Code,
+			Name:       e.Name,
+		})
+	case *partner.SupplierStatusChangedEvent:
+		return h.HandleSupplierStatusChanged(ctx, acl.SupplierStatusChangedEventDTO{
+			TenantID:   e.TenantID(),
+			SupplierID: e.SupplierID,
+			Code:       e.Code,
+			OldStatus:  string(e.OldStatus),
+			NewStatus:  string(e.NewStatus),
+		})
+	}
+
+	h.logger.Error("unexpected event type",
+		zap.Strings("expected", h.EventTypes()),
+		zap.String("actual", event.EventType()),
+	)
+	return fmt.Errorf("unexpected event type: %s", event.EventType())
+}
+
+// HandleSupplierCreated adds the new supplier to the reference cache
+func (h *SupplierReferenceHandler) HandleSupplierCreated(ctx context.Context, event acl.SupplierCreatedEventDTO) error {
+	return h.cacheReference(ctx, event.TenantID, event.SupplierID, event.Name, event.Code)
+}
+
+// HandleSupplierUpdated refreshes the cached reference and the supplier name on existing payables
+func (h *SupplierReferenceHandler) HandleSupplierUpdated(ctx context.Context, event acl.SupplierUpdatedEventDTO) error {
+	if err := h.cacheReference(ctx, event.TenantID, event.SupplierID, event.Name, event.Code); err != nil {
+		return err
+	}
+	if event.Name == "" {
+		return nil
+	}
+
+	updated, err := h.payableRepo.UpdateSupplierName(ctx, event.TenantID, event.SupplierID, event.Name)
+	if err != nil {
+		h.logger.Error("failed to refresh supplier name on payables",
+			zap.String("supplier_id", event.SupplierID.String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to refresh supplier name on payables: %w", err)
+	}
+	if updated > 0 {
+		h.logger.Info("refreshed supplier name on payables",
+			zap.String("supplier_id", event.SupplierID.String()),
+			zap.String("supplier_name", event.Name),
+			zap.Int64("payables_updated", updated),
+		)
+	}
+	return nil
+}
+
+// HandleSupplierDeleted invalidates the cached reference.
+// Payables keep the supplier name they were created with for historical purposes.
+func (h *SupplierReferenceHandler) HandleSupplierDeleted(ctx context.Context, event acl.SupplierDeletedEventDTO) error {
+	if h.cache == nil {
+		return nil
+	}
+	return h.cache.Invalidate(ctx, event.TenantID, event.SupplierID)
+}
+
+// HandleSupplierStatusChanged leaves the cached reference untouched:
+// payables of inactive or blocked suppliers still need to be settled and displayed
+func (h *SupplierReferenceHandler) HandleSupplierStatusChanged(ctx context.Context, event acl.SupplierStatusChangedEventDTO) error {
+	return nil
+}
+
+// cacheReference stores a supplier reference built from event data in the cache, if one is configured
+func (h *SupplierReferenceHandler) cacheReference(ctx context.Context, tenantID, supplierID uuid.UUID, name, code string) error {
+	if h.cache == nil {
+		return nil
+	}
+	ref, err := acl.NewSupplierReferenceFromUUID(supplierID, name, code)
+	if err != nil {
+		h.logger.Warn("skipping invalid supplier reference",
+			zap.String("supplier_id", supplierID.String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return h.cache.Set(ctx, tenantID, ref)
+}
+
+// Ensure SupplierReferenceHandler implements both the ACL and event bus handler interfaces
+var (
+	_ acl.SupplierEventHandler = (*SupplierReferenceHandler)(nil)
+	_ shared.EventHandler      = (*SupplierReferenceHandler)(nil)
+)
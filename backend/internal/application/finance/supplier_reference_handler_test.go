@@ -0,0 +1,83 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestSupplierForHandler(t *testing.T, tenantID uuid.UUID) *partner.Supplier {
+	supplier, err := partner.NewSupplier(tenantID, "SUP-001", "Renamed Supplier", partner.SupplierTypeManufacturer)
+	require.NoError(t, err)
+	return supplier
+}
+
+func TestSupplierReferenceHandler_EventTypes(t *testing.T) {
+	handler := NewSupplierReferenceHandler(nil, nil, zap.NewNop())
+
+	assert.ElementsMatch(t, []string{
+		partner.EventTypeSupplierCreated,
+		partner.EventTypeSupplierUpdated,
+		partner.EventTypeSupplierDeleted,
+		partner.EventTypeSupplierStatusChanged,
+	}, handler.EventTypes())
+}
+
+func TestSupplierReferenceHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	t.Run("refreshes supplier name on payables when supplier is updated", func(t *testing.T) {
+		payableRepo := new(MockAccountPayableRepository)
+		handler := NewSupplierReferenceHandler(payableRepo, nil, zap.NewNop())
+		supplier := newTestSupplierForHandler(t, tenantID)
+
+		payableRepo.On("UpdateSupplierName", ctx, tenantID, supplier.ID, "Renamed Supplier").Return(int64(3), nil)
+
+		err := handler.Handle(ctx, partner.NewSupplierUpdatedEvent(supplier))
+
+		require.NoError(t, err)
+		payableRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns error when refresh fails", func(t *testing.T) {
+		payableRepo := new(MockAccountPayableRepository)
+		handler := NewSupplierReferenceHandler(payableRepo, nil, zap.NewNop())
+		supplier := newTestSupplierForHandler(t, tenantID)
+
+		payableRepo.On("UpdateSupplierName", ctx, tenantID, supplier.ID, "Renamed Supplier").Return(int64(0), errors.New("db down"))
+
+		err := handler.Handle(ctx, partner.NewSupplierUpdatedEvent(supplier))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to refresh supplier name")
+	})
+
+	t.Run("does not touch payables when supplier is created or deleted", func(t *testing.T) {
+		payableRepo := new(MockAccountPayableRepository)
+		handler := NewSupplierReferenceHandler(payableRepo, nil, zap.NewNop())
+		supplier := newTestSupplierForHandler(t, tenantID)
+
+		require.NoError(t, handler.Handle(ctx, partner.NewSupplierCreatedEvent(supplier)))
+		require.NoError(t, handler.Handle(ctx, partner.NewSupplierDeletedEvent(supplier)))
+
+		payableRepo.AssertNotCalled(t, "UpdateSupplierName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects unrelated events", func(t *testing.T) {
+		handler := NewSupplierReferenceHandler(nil, nil, zap.NewNop())
+		event := shared.NewBaseDomainEvent("SomethingElse", "Other", uuid.New(), tenantID)
+
+		err := handler.Handle(ctx, &event)
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,83 @@
+package finance
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/finance"
+)
+
+// TransactionScope provides transactional access to finance repositories.
+// When a function is executed within a transaction scope, all repository operations
+// will be part of the same database transaction and will be committed or rolled back atomically.
+type TransactionScope interface {
+	// Execute runs the given function within a database transaction.
+	// If the function returns an error, the transaction is rolled back.
+	// If the function succeeds, the transaction is committed.
+	Execute(ctx context.Context, fn func(repos TransactionalRepositories) error) error
+}
+
+// TransactionalRepositories provides access to finance repositories within a transaction.
+// All repositories returned share the same underlying database transaction.
+type TransactionalRepositories interface {
+	// ReceivableRepo returns the account receivable repository scoped to the current transaction
+	ReceivableRepo() finance.AccountReceivableRepository
+	// ReceiptVoucherRepo returns the receipt voucher repository scoped to the current transaction
+	ReceiptVoucherRepo() finance.ReceiptVoucherRepository
+	// PaymentVoucherRepo returns the payment voucher repository scoped to the current transaction
+	PaymentVoucherRepo() finance.PaymentVoucherRepository
+	// IdempotencyKeyRepo returns the voucher idempotency key repository scoped to the current transaction
+	IdempotencyKeyRepo() finance.VoucherIdempotencyKeyRepository
+}
+
+// NoOpTransactionScope is a transaction scope that doesn't actually use transactions.
+// This is useful for testing or when transaction support is not required.
+type NoOpTransactionScope struct {
+	receivableRepo     finance.AccountReceivableRepository
+	receiptVoucherRepo finance.ReceiptVoucherRepository
+	paymentVoucherRepo finance.PaymentVoucherRepository
+	idempotencyKeyRepo finance.VoucherIdempotencyKeyRepository
+}
+
+// NewNoOpTransactionScope creates a NoOpTransactionScope with the given repositories.
+func NewNoOpTransactionScope(
+	receivableRepo finance.AccountReceivableRepository,
+	receiptVoucherRepo finance.ReceiptVoucherRepository,
+	paymentVoucherRepo finance.PaymentVoucherRepository,
+	idempotencyKeyRepo finance.VoucherIdempotencyKeyRepository,
+) *NoOpTransactionScope {
+	return &NoOpTransactionScope{
+		receivableRepo:     receivableRepo,
+		receiptVoucherRepo: receiptVoucherRepo,
+		paymentVoucherRepo: paymentVoucherRepo,
+		idempotencyKeyRepo: idempotencyKeyRepo,
+	}
+}
+
+// Execute runs the function without a real transaction (for testing/compatibility).
+func (s *NoOpTransactionScope) Execute(_ context.Context, fn func(repos TransactionalRepositories) error) error {
+	return fn(s)
+}
+
+// ReceivableRepo returns the account receivable repository.
+func (s *NoOpTransactionScope) ReceivableRepo() finance.AccountReceivableRepository {
+	return s.receivableRepo
+}
+
+// ReceiptVoucherRepo returns the receipt voucher repository.
+func (s *NoOpTransactionScope) ReceiptVoucherRepo() finance.ReceiptVoucherRepository {
+	return s.receiptVoucherRepo
+}
+
+// PaymentVoucherRepo returns the payment voucher repository.
+func (s *NoOpTransactionScope) PaymentVoucherRepo() finance.PaymentVoucherRepository {
+	return s.paymentVoucherRepo
+}
+
+// IdempotencyKeyRepo returns the voucher idempotency key repository.
+func (s *NoOpTransactionScope) IdempotencyKeyRepo() finance.VoucherIdempotencyKeyRepository {
+	return s.idempotencyKeyRepo
+}
+
+// Ensure NoOpTransactionScope implements both interfaces
+var _ TransactionScope = (*NoOpTransactionScope)(nil)
+var _ TransactionalRepositories = (*NoOpTransactionScope)(nil)
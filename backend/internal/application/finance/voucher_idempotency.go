@@ -0,0 +1,155 @@
+package finance
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// WithIdempotencyKeyRepository enables Idempotency-Key support for voucher creation.
+// A create request that repeats a key within its TTL returns the voucher the key first created.
+func WithIdempotencyKeyRepository(repo finance.VoucherIdempotencyKeyRepository) FinanceServiceOption {
+	return func(s *FinanceService) {
+		s.idempotencyKeyRepo = repo
+	}
+}
+
+// isIdempotent returns true if a create request with the given key should be deduplicated
+func (s *FinanceService) isIdempotent(key string) bool {
+	return key != "" && s.idempotencyKeyRepo != nil
+}
+
+// findIdempotentVoucherID returns the voucher created for an idempotency key, or uuid.Nil if the key is unused
+func (s *FinanceService) findIdempotentVoucherID(ctx context.Context, tenantID uuid.UUID, voucherType finance.IdempotentVoucherType, key string) (uuid.UUID, error) {
+	if err := finance.ValidateIdempotencyKey(key); err != nil {
+		return uuid.Nil, err
+	}
+
+	record, err := s.idempotencyKeyRepo.FindByIdempotencyKey(ctx, tenantID, voucherType, key)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, err
+	}
+	return record.VoucherID, nil
+}
+
+// saveWithIdempotencyKey records the key and saves the voucher in one transaction.
+// Without a transaction scope the key is recorded first, so a failed save leaves the key
+// pointing at a missing voucher until it expires rather than allowing a duplicate.
+func (s *FinanceService) saveWithIdempotencyKey(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	voucherType finance.IdempotentVoucherType,
+	key string,
+	voucherID uuid.UUID,
+	save func(repos TransactionalRepositories) error,
+) error {
+	record, err := finance.NewVoucherIdempotencyKey(tenantID, voucherType, key, voucherID, finance.DefaultVoucherIdempotencyTTL)
+	if err != nil {
+		return err
+	}
+
+	scope := s.txScope
+	if scope == nil {
+		scope = NewNoOpTransactionScope(s.receivableRepo, s.receiptVoucherRepo, s.paymentVoucherRepo, s.idempotencyKeyRepo)
+	}
+	return scope.Execute(ctx, func(repos TransactionalRepositories) error {
+		if err := repos.IdempotencyKeyRepo().Create(ctx, record); err != nil {
+			return err
+		}
+		return save(repos)
+	})
+}
+
+// replayReceiptVoucher returns the receipt voucher already created with the request's idempotency key,
+// or nil if the key has not been used
+func (s *FinanceService) replayReceiptVoucher(ctx context.Context, tenantID uuid.UUID, req CreateReceiptVoucherRequest) (*ReceiptVoucherResponse, error) {
+	voucherID, err := s.findIdempotentVoucherID(ctx, tenantID, finance.IdempotentVoucherTypeReceipt, req.IdempotencyKey)
+	if err != nil || voucherID == uuid.Nil {
+		return nil, err
+	}
+
+	voucher, err := s.receiptVoucherRepo.FindByIDForTenant(ctx, tenantID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+	if voucher == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Receipt voucher not found")
+	}
+	if voucher.CustomerID != req.CustomerID || !voucher.Amount.Equal(req.Amount) {
+		return nil, shared.NewDomainError("IDEMPOTENCY_KEY_REUSED", "Idempotency key was already used for a different receipt voucher")
+	}
+	return toReceiptVoucherResponse(voucher), nil
+}
+
+// saveIdempotentReceiptVoucher saves a new receipt voucher under the request's idempotency key.
+// If a concurrent request claimed the key first, its voucher is returned instead.
+func (s *FinanceService) saveIdempotentReceiptVoucher(ctx context.Context, tenantID uuid.UUID, req CreateReceiptVoucherRequest, voucher *finance.ReceiptVoucher) (*ReceiptVoucherResponse, error) {
+	err := s.saveWithIdempotencyKey(ctx, tenantID, finance.IdempotentVoucherTypeReceipt, req.IdempotencyKey, voucher.ID,
+		func(repos TransactionalRepositories) error {
+			return repos.ReceiptVoucherRepo().Save(ctx, voucher)
+		})
+	if errors.Is(err, shared.ErrAlreadyExists) {
+		replayed, err := s.replayReceiptVoucher(ctx, tenantID, req)
+		if err != nil {
+			return nil, err
+		}
+		if replayed == nil {
+			return nil, shared.ErrConcurrencyConflict
+		}
+		return replayed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toReceiptVoucherResponse(voucher), nil
+}
+
+// replayPaymentVoucher returns the payment voucher already created with the request's idempotency key,
+// or nil if the key has not been used
+func (s *FinanceService) replayPaymentVoucher(ctx context.Context, tenantID uuid.UUID, req CreatePaymentVoucherRequest) (*PaymentVoucherResponse, error) {
+	voucherID, err := s.findIdempotentVoucherID(ctx, tenantID, finance.IdempotentVoucherTypePayment, req.IdempotencyKey)
+	if err != nil || voucherID == uuid.Nil {
+		return nil, err
+	}
+
+	voucher, err := s.paymentVoucherRepo.FindByIDForTenant(ctx, tenantID, voucherID)
+	if err != nil {
+		return nil, err
+	}
+	if voucher == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "Payment voucher not found")
+	}
+	if voucher.SupplierID != req.SupplierID || !voucher.Amount.Equal(req.Amount) {
+		return nil, shared.NewDomainError("IDEMPOTENCY_KEY_REUSED", "Idempotency key was already used for a different payment voucher")
+	}
+	return toPaymentVoucherResponse(voucher), nil
+}
+
+// saveIdempotentPaymentVoucher saves a new payment voucher under the request's idempotency key.
+// If a concurrent request claimed the key first, its voucher is returned instead.
+func (s *FinanceService) saveIdempotentPaymentVoucher(ctx context.Context, tenantID uuid.UUID, req CreatePaymentVoucherRequest, voucher *finance.PaymentVoucher) (*PaymentVoucherResponse, error) {
+	err := s.saveWithIdempotencyKey(ctx, tenantID, finance.IdempotentVoucherTypePayment, req.IdempotencyKey, voucher.ID,
+		func(repos TransactionalRepositories) error {
+			return repos.PaymentVoucherRepo().Save(ctx, voucher)
+		})
+	if errors.Is(err, shared.ErrAlreadyExists) {
+		replayed, err := s.replayPaymentVoucher(ctx, tenantID, req)
+		if err != nil {
+			return nil, err
+		}
+		if replayed == nil {
+			return nil, shared.ErrConcurrencyConflict
+		}
+		return replayed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPaymentVoucherResponse(voucher), nil
+}
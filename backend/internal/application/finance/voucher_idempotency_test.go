@@ -0,0 +1,160 @@
+package finance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockVoucherIdempotencyKeyRepository is a mock implementation of finance.VoucherIdempotencyKeyRepository
+type MockVoucherIdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockVoucherIdempotencyKeyRepository) FindByIdempotencyKey(ctx context.Context, tenantID uuid.UUID, voucherType finance.IdempotentVoucherType, key string) (*finance.VoucherIdempotencyKey, error) {
+	args := m.Called(ctx, tenantID, voucherType, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*finance.VoucherIdempotencyKey), args.Error(1)
+}
+
+func (m *MockVoucherIdempotencyKeyRepository) Create(ctx context.Context, key *finance.VoucherIdempotencyKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func newIdempotencyTestReceiptVoucher(t *testing.T, tenantID, customerID uuid.UUID, amount int64) *finance.ReceiptVoucher {
+	voucher, err := finance.NewReceiptVoucher(
+		tenantID, "RV-001", customerID, "Idempotent Customer",
+		valueobject.NewMoneyCNY(decimal.NewFromInt(amount)), finance.PaymentMethodBankTransfer, time.Now(),
+	)
+	require.NoError(t, err)
+	return voucher
+}
+
+func newIdempotencyTestService(voucherRepo *MockReceiptVoucherRepository, keyRepo *MockVoucherIdempotencyKeyRepository) *FinanceService {
+	return NewFinanceService(nil, nil, voucherRepo, nil,
+		WithIdempotencyKeyRepository(keyRepo),
+		WithTransactionScope(NewNoOpTransactionScope(nil, voucherRepo, nil, keyRepo)),
+	)
+}
+
+func TestFinanceService_CreateReceiptVoucher_Idempotency(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	customerID := uuid.New()
+
+	newRequest := func(amount int64) CreateReceiptVoucherRequest {
+		return CreateReceiptVoucherRequest{
+			CustomerID:     customerID,
+			CustomerName:   "Idempotent Customer",
+			Amount:         decimal.NewFromInt(amount),
+			PaymentMethod:  string(finance.PaymentMethodBankTransfer),
+			ReceiptDate:    time.Now(),
+			IdempotencyKey: "retry-1",
+		}
+	}
+
+	t.Run("first request stores the key with the new voucher", func(t *testing.T) {
+		voucherRepo := new(MockReceiptVoucherRepository)
+		keyRepo := new(MockVoucherIdempotencyKeyRepository)
+		svc := newIdempotencyTestService(voucherRepo, keyRepo)
+
+		keyRepo.On("FindByIdempotencyKey", ctx, tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1").Return(nil, shared.ErrNotFound)
+		voucherRepo.On("GenerateVoucherNumber", ctx, tenantID).Return("RV-001", nil)
+		keyRepo.On("Create", ctx, mock.AnythingOfType("*finance.VoucherIdempotencyKey")).Return(nil)
+		voucherRepo.On("Save", ctx, mock.AnythingOfType("*finance.ReceiptVoucher")).Return(nil)
+
+		resp, err := svc.CreateReceiptVoucher(ctx, tenantID, newRequest(1000))
+		require.NoError(t, err)
+
+		stored := keyRepo.Calls[1].Arguments.Get(1).(*finance.VoucherIdempotencyKey)
+		assert.Equal(t, resp.ID, stored.VoucherID)
+		assert.Equal(t, "retry-1", stored.Key)
+		voucherRepo.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("replay returns the original voucher without creating another", func(t *testing.T) {
+		voucherRepo := new(MockReceiptVoucherRepository)
+		keyRepo := new(MockVoucherIdempotencyKeyRepository)
+		svc := newIdempotencyTestService(voucherRepo, keyRepo)
+
+		original := newIdempotencyTestReceiptVoucher(t, tenantID, customerID, 1000)
+		keyRepo.On("FindByIdempotencyKey", ctx, tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1").
+			Return(&finance.VoucherIdempotencyKey{VoucherID: original.ID}, nil)
+		voucherRepo.On("FindByIDForTenant", ctx, tenantID, original.ID).Return(original, nil)
+
+		resp, err := svc.CreateReceiptVoucher(ctx, tenantID, newRequest(1000))
+		require.NoError(t, err)
+
+		assert.Equal(t, original.ID, resp.ID)
+		voucherRepo.AssertNotCalled(t, "GenerateVoucherNumber", mock.Anything, mock.Anything)
+		voucherRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects key reused for a different voucher", func(t *testing.T) {
+		voucherRepo := new(MockReceiptVoucherRepository)
+		keyRepo := new(MockVoucherIdempotencyKeyRepository)
+		svc := newIdempotencyTestService(voucherRepo, keyRepo)
+
+		original := newIdempotencyTestReceiptVoucher(t, tenantID, customerID, 1000)
+		keyRepo.On("FindByIdempotencyKey", ctx, tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1").
+			Return(&finance.VoucherIdempotencyKey{VoucherID: original.ID}, nil)
+		voucherRepo.On("FindByIDForTenant", ctx, tenantID, original.ID).Return(original, nil)
+
+		_, err := svc.CreateReceiptVoucher(ctx, tenantID, newRequest(2000))
+		require.Error(t, err)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", domainErr.Code)
+	})
+
+	t.Run("concurrent request reuses the voucher that claimed the key first", func(t *testing.T) {
+		voucherRepo := new(MockReceiptVoucherRepository)
+		keyRepo := new(MockVoucherIdempotencyKeyRepository)
+		svc := newIdempotencyTestService(voucherRepo, keyRepo)
+
+		winner := newIdempotencyTestReceiptVoucher(t, tenantID, customerID, 1000)
+		keyRepo.On("FindByIdempotencyKey", ctx, tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1").
+			Return(nil, shared.ErrNotFound).Once()
+		voucherRepo.On("GenerateVoucherNumber", ctx, tenantID).Return("RV-002", nil)
+		keyRepo.On("Create", ctx, mock.AnythingOfType("*finance.VoucherIdempotencyKey")).Return(shared.ErrAlreadyExists)
+		keyRepo.On("FindByIdempotencyKey", ctx, tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1").
+			Return(&finance.VoucherIdempotencyKey{VoucherID: winner.ID}, nil).Once()
+		voucherRepo.On("FindByIDForTenant", ctx, tenantID, winner.ID).Return(winner, nil)
+
+		resp, err := svc.CreateReceiptVoucher(ctx, tenantID, newRequest(1000))
+		require.NoError(t, err)
+
+		assert.Equal(t, winner.ID, resp.ID)
+		voucherRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requests without a key are not deduplicated", func(t *testing.T) {
+		voucherRepo := new(MockReceiptVoucherRepository)
+		keyRepo := new(MockVoucherIdempotencyKeyRepository)
+		svc := newIdempotencyTestService(voucherRepo, keyRepo)
+
+		voucherRepo.On("GenerateVoucherNumber", ctx, tenantID).Return("RV-003", nil)
+		voucherRepo.On("Save", ctx, mock.AnythingOfType("*finance.ReceiptVoucher")).Return(nil)
+
+		req := newRequest(1000)
+		req.IdempotencyKey = ""
+		_, err := svc.CreateReceiptVoucher(ctx, tenantID, req)
+		require.NoError(t, err)
+
+		keyRepo.AssertNotCalled(t, "FindByIdempotencyKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		keyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -31,6 +31,8 @@ type AuthService struct {
 	roleRepo       identity.RoleRepository
 	jwtService     *auth.JWTService
 	tokenBlacklist auth.TokenBlacklist
+	sessionStore   auth.SessionStore
+	passwordPolicy *PasswordPolicyService
 	config         AuthServiceConfig
 	logger         *zap.Logger
 }
@@ -59,6 +61,18 @@ func (s *AuthService) SetTokenBlacklist(blacklist auth.TokenBlacklist) {
 	s.tokenBlacklist = blacklist
 }
 
+// SetSessionStore sets the session store for the service
+// This allows listing and revoking the sessions of a user
+func (s *AuthService) SetSessionStore(store auth.SessionStore) {
+	s.sessionStore = store
+}
+
+// SetPasswordPolicyService sets the password policy service for the service
+// This enforces tenant password policies on password changes and password expiry on login
+func (s *AuthService) SetPasswordPolicyService(passwordPolicy *PasswordPolicyService) {
+	s.passwordPolicy = passwordPolicy
+}
+
 // Login authenticates a user and returns tokens
 func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult, error) {
 	s.logger.Info("Login attempt", zap.String("username", input.Username))
@@ -136,6 +150,12 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to generate authentication tokens")
 	}
 
+	// An expired password still logs in, but has to be changed
+	if s.passwordPolicy != nil && !user.MustChangePassword && s.passwordPolicy.IsPasswordExpired(ctx, user) {
+		s.logger.Info("Password expired, password change required", zap.String("user_id", user.ID.String()))
+		user.ForcePasswordChange()
+	}
+
 	// Record successful login
 	user.RecordLoginSuccess(input.IP)
 	if err := s.userRepo.Update(ctx, user); err != nil {
@@ -143,6 +163,8 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 		// Don't fail the login - just log the error
 	}
 
+	s.startSession(ctx, user, tokenPair, input.IP, input.UserAgent)
+
 	s.logger.Info("User logged in successfully",
 		zap.String("username", input.Username),
 		zap.String("user_id", user.ID.String()))
@@ -164,6 +186,7 @@ func (s *AuthService) Login(ctx context.Context, input LoginInput) (*LoginResult
 			Permissions: permissions,
 			RoleIDs:     user.RoleIDs,
 		},
+		MustChangePassword: user.MustChangePassword,
 	}, nil
 }
 
@@ -203,6 +226,20 @@ func (s *AuthService) RefreshToken(ctx context.Context, input RefreshTokenInput)
 				zap.String("user_id", refreshClaims.UserID))
 			return nil, shared.NewDomainError("TOKEN_REVOKED", "Session has been invalidated. Please log in again")
 		}
+
+		// Check if the token family has been revoked after a refresh token replay
+		familyRevoked, err := s.tokenBlacklist.IsTokenFamilyRevoked(ctx, refreshClaims.GetFamilyID())
+		if err != nil {
+			s.logger.Error("Failed to check token family revocation during refresh",
+				zap.String("user_id", refreshClaims.UserID),
+				zap.Error(err))
+			// Don't fail on error - allow refresh for availability
+		} else if familyRevoked {
+			s.logger.Warn("Refresh token rejected - token family revoked",
+				zap.String("user_id", refreshClaims.UserID),
+				zap.String("family_id", refreshClaims.GetFamilyID()))
+			return nil, shared.NewDomainError("TOKEN_REVOKED", "Session has been invalidated. Please log in again")
+		}
 	}
 
 	// Parse user ID from refresh token claims
@@ -258,6 +295,14 @@ func (s *AuthService) RefreshToken(ctx context.Context, input RefreshTokenInput)
 		}
 	}
 
+	// Rotate the refresh token: the presented one can only be used once.
+	// It is only marked used once refreshing succeeded, so a failed refresh doesn't burn it.
+	if err := s.consumeRefreshToken(ctx, refreshClaims); err != nil {
+		return nil, err
+	}
+
+	s.renewSession(ctx, refreshClaims, tokenPair, input.IP, input.UserAgent)
+
 	s.logger.Info("Token refreshed successfully", zap.String("user_id", userID.String()))
 
 	return &RefreshTokenResult{
@@ -269,6 +314,44 @@ func (s *AuthService) RefreshToken(ctx context.Context, input RefreshTokenInput)
 	}, nil
 }
 
+// consumeRefreshToken marks a refresh token as used so it can't be refreshed again.
+// A refresh token presented a second time has been replayed, by an attacker or by
+// the client the attacker stole it from. As it can't be told which, the whole
+// token family is revoked and the user has to log in again.
+func (s *AuthService) consumeRefreshToken(ctx context.Context, claims *auth.Claims) error {
+	if s.tokenBlacklist == nil {
+		return nil
+	}
+
+	firstUse, err := s.tokenBlacklist.MarkRefreshTokenUsed(ctx, claims.ID, claims.GetRemainingTTL())
+	if err != nil {
+		s.logger.Error("Failed to mark refresh token as used",
+			zap.String("user_id", claims.UserID),
+			zap.Error(err))
+		// Don't fail on error - allow refresh for availability
+		return nil
+	}
+	if firstUse {
+		return nil
+	}
+
+	familyID := claims.GetFamilyID()
+	s.logger.Warn("Refresh token reuse detected - revoking token family",
+		zap.String("user_id", claims.UserID),
+		zap.String("tenant_id", claims.TenantID),
+		zap.String("family_id", familyID),
+		zap.String("token_jti", claims.ID))
+
+	if err := s.tokenBlacklist.RevokeTokenFamily(ctx, familyID, s.jwtService.GetRefreshTokenExpiration()); err != nil {
+		s.logger.Error("Failed to revoke token family",
+			zap.String("user_id", claims.UserID),
+			zap.String("family_id", familyID),
+			zap.Error(err))
+	}
+
+	return shared.NewDomainError("TOKEN_REUSED", "Refresh token has already been used. Please log in again")
+}
+
 // Logout handles user logout by invalidating all tokens for the user
 // This ensures both access and refresh tokens are immediately invalidated
 func (s *AuthService) Logout(ctx context.Context, input LogoutInput) error {
@@ -319,6 +402,8 @@ func (s *AuthService) GetCurrentUser(ctx context.Context, input GetCurrentUserIn
 		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to load user permissions")
 	}
 
+	s.touchSession(ctx, input.UserID.String(), input.FamilyID, input.IP)
+
 	return &CurrentUserResult{
 		User: UserInfo{
 			ID:          user.ID,
@@ -342,7 +427,13 @@ func (s *AuthService) ChangePassword(ctx context.Context, input ChangePasswordIn
 		return shared.NewDomainError("USER_NOT_FOUND", "User not found")
 	}
 
-	if err := user.ChangePassword(input.OldPassword, input.NewPassword); err != nil {
+	previousHash := user.PasswordHash
+	if s.passwordPolicy != nil {
+		err = s.passwordPolicy.ChangePassword(ctx, user, input.OldPassword, input.NewPassword)
+	} else {
+		err = user.ChangePassword(input.OldPassword, input.NewPassword)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -351,6 +442,10 @@ func (s *AuthService) ChangePassword(ctx context.Context, input ChangePasswordIn
 		return shared.NewDomainError("INTERNAL_ERROR", "Failed to update password")
 	}
 
+	if s.passwordPolicy != nil {
+		s.passwordPolicy.RecordPreviousPassword(ctx, user, previousHash)
+	}
+
 	s.logger.Info("User password changed", zap.String("user_id", input.UserID.String()))
 
 	// Invalidate all existing tokens for security
@@ -429,6 +429,246 @@ func TestAuthService_RefreshToken_Success(t *testing.T) {
 	assert.NotEqual(t, loginResult.AccessToken, refreshResult.AccessToken)
 }
 
+// loginForRefresh sets up the mocks for a login and token refreshes of a test user
+// and returns the auth service, with an in-memory token blacklist and session store,
+// and the login result
+func loginForRefresh(t *testing.T, ctx context.Context) (*AuthService, *LoginResult) {
+	tenantID := uuid.New()
+	userRepo := new(MockUserRepository)
+	roleRepo := new(MockRoleRepository)
+
+	user := createTestUser(tenantID)
+	role := createTestRole(tenantID)
+	user.RoleIDs = []uuid.UUID{role.ID}
+
+	userRepo.On("FindByUsername", ctx, "testuser").Return(user, nil)
+	userRepo.On("FindByID", ctx, user.ID).Return(user, nil)
+	userRepo.On("LoadUserRoles", ctx, user).Return(nil)
+	userRepo.On("Update", ctx, user).Return(nil)
+	roleRepo.On("FindByIDs", ctx, user.RoleIDs).Return([]*identity.Role{role}, nil)
+	roleRepo.On("LoadPermissions", ctx, role).Return(nil)
+
+	authService := createAuthService(userRepo, roleRepo)
+	authService.SetTokenBlacklist(auth.NewInMemoryTokenBlacklist())
+	authService.SetSessionStore(auth.NewInMemorySessionStore())
+
+	loginResult, err := authService.Login(ctx, LoginInput{
+		Username: "testuser",
+		Password: "Password123",
+		IP:       "127.0.0.1",
+	})
+	require.NoError(t, err)
+	return authService, loginResult
+}
+
+func TestAuthService_RefreshToken_RotatesRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	authService, loginResult := loginForRefresh(t, ctx)
+
+	first, err := authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: loginResult.RefreshToken})
+	require.NoError(t, err)
+	assert.NotEqual(t, loginResult.RefreshToken, first.RefreshToken)
+
+	// The rotated refresh token can be used in turn
+	second, err := authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: first.RefreshToken})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+}
+
+func TestAuthService_RefreshToken_ReuseRevokesTokenFamily(t *testing.T) {
+	ctx := context.Background()
+	authService, loginResult := loginForRefresh(t, ctx)
+
+	rotated, err := authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: loginResult.RefreshToken})
+	require.NoError(t, err)
+
+	// Replaying the already rotated refresh token is detected
+	result, err := authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: loginResult.RefreshToken})
+	require.Error(t, err)
+	assert.Nil(t, result)
+	var domainErr *shared.DomainError
+	require.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "TOKEN_REUSED", domainErr.Code)
+
+	// The whole family is revoked, including the token issued by the legitimate rotation
+	result, err = authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: rotated.RefreshToken})
+	require.Error(t, err)
+	assert.Nil(t, result)
+	require.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "TOKEN_REVOKED", domainErr.Code)
+
+	// Access tokens of the family are revoked as well
+	claims, err := authService.jwtService.ValidateAccessToken(rotated.AccessToken)
+	require.NoError(t, err)
+	revoked, err := authService.tokenBlacklist.IsTokenFamilyRevoked(ctx, claims.FamilyID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Logging in again starts a new, unaffected family
+	relogin, err := authService.Login(ctx, LoginInput{
+		Username: "testuser",
+		Password: "Password123",
+		IP:       "127.0.0.1",
+	})
+	require.NoError(t, err)
+	_, err = authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: relogin.RefreshToken})
+	require.NoError(t, err)
+}
+
+func TestAuthService_RefreshToken_FailedRefreshDoesNotConsumeToken(t *testing.T) {
+	ctx := context.Background()
+	authService, loginResult := loginForRefresh(t, ctx)
+
+	// Rotate until the refresh token has reached the maximum refresh count
+	refreshToken := loginResult.RefreshToken
+	for i := 0; i < 10; i++ {
+		result, err := authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: refreshToken})
+		require.NoError(t, err)
+		refreshToken = result.RefreshToken
+	}
+
+	// Failing to refresh leaves the token unused, so presenting it again is not a reuse
+	var domainErr *shared.DomainError
+	for i := 0; i < 2; i++ {
+		result, err := authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: refreshToken})
+		require.Error(t, err)
+		assert.Nil(t, result)
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, "TOKEN_MAX_REFRESH", domainErr.Code)
+	}
+}
+
+// accessTokenClaims returns the claims of an access token issued by the auth service
+func accessTokenClaims(t *testing.T, authService *AuthService, accessToken string) *auth.Claims {
+	claims, err := authService.jwtService.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+	return claims
+}
+
+func TestAuthService_ListUserSessions(t *testing.T) {
+	ctx := context.Background()
+	authService, first := loginForRefresh(t, ctx)
+	user := first.User
+
+	second, err := authService.Login(ctx, LoginInput{
+		Username:  "testuser",
+		Password:  "Password123",
+		IP:        "10.0.0.2",
+		UserAgent: "Firefox",
+	})
+	require.NoError(t, err)
+
+	sessions, err := authService.ListUserSessions(ctx, ListUserSessionsInput{TargetUserID: user.ID, TenantID: user.TenantID})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, accessTokenClaims(t, authService, second.AccessToken).ID, sessions[0].JTI, "most recently seen first")
+	assert.Equal(t, "Firefox", sessions[0].UserAgent)
+	assert.Equal(t, "10.0.0.2", sessions[0].IP)
+	assert.Equal(t, accessTokenClaims(t, authService, first.AccessToken).ID, sessions[1].JTI)
+
+	// Using the first session updates its last-seen time and IP
+	time.Sleep(5 * time.Millisecond)
+	firstClaims := accessTokenClaims(t, authService, first.AccessToken)
+	_, err = authService.GetCurrentUser(ctx, GetCurrentUserInput{
+		UserID:   user.ID,
+		TenantID: user.TenantID,
+		FamilyID: firstClaims.GetFamilyID(),
+		IP:       "10.0.0.9",
+	})
+	require.NoError(t, err)
+
+	sessions, err = authService.ListUserSessions(ctx, ListUserSessionsInput{TargetUserID: user.ID, TenantID: user.TenantID})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, firstClaims.ID, sessions[0].JTI)
+	assert.Equal(t, "10.0.0.9", sessions[0].IP)
+	assert.True(t, sessions[0].LastSeenAt.After(sessions[1].LastSeenAt))
+
+	// Refreshing moves the session to the new access token
+	refreshed, err := authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: second.RefreshToken})
+	require.NoError(t, err)
+	sessions, err = authService.ListUserSessions(ctx, ListUserSessionsInput{TargetUserID: user.ID, TenantID: user.TenantID})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, accessTokenClaims(t, authService, refreshed.AccessToken).ID, sessions[0].JTI)
+	assert.Equal(t, "Firefox", sessions[0].UserAgent)
+
+	// Sessions are gone after a logout
+	require.NoError(t, authService.Logout(ctx, LogoutInput{UserID: user.ID, TenantID: user.TenantID}))
+	sessions, err = authService.ListUserSessions(ctx, ListUserSessionsInput{TargetUserID: user.ID, TenantID: user.TenantID})
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestAuthService_RevokeUserSession(t *testing.T) {
+	ctx := context.Background()
+	authService, first := loginForRefresh(t, ctx)
+	user := first.User
+
+	second, err := authService.Login(ctx, LoginInput{
+		Username:  "testuser",
+		Password:  "Password123",
+		IP:        "10.0.0.2",
+		UserAgent: "Firefox",
+	})
+	require.NoError(t, err)
+
+	firstClaims := accessTokenClaims(t, authService, first.AccessToken)
+	secondClaims := accessTokenClaims(t, authService, second.AccessToken)
+
+	err = authService.RevokeUserSession(ctx, RevokeSessionInput{
+		AdminUserID:  uuid.New(),
+		TargetUserID: user.ID,
+		TenantID:     user.TenantID,
+		JTI:          firstClaims.ID,
+	})
+	require.NoError(t, err)
+
+	// The revoked session's access token is blacklisted and its refresh token rejected
+	blacklisted, err := authService.IsTokenBlacklisted(ctx, firstClaims.ID)
+	require.NoError(t, err)
+	assert.True(t, blacklisted)
+	_, err = authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: first.RefreshToken})
+	var domainErr *shared.DomainError
+	require.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "TOKEN_REVOKED", domainErr.Code)
+
+	// The other session stays valid
+	blacklisted, err = authService.IsTokenBlacklisted(ctx, secondClaims.ID)
+	require.NoError(t, err)
+	assert.False(t, blacklisted)
+	revoked, err := authService.tokenBlacklist.IsTokenFamilyRevoked(ctx, secondClaims.GetFamilyID())
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	_, err = authService.RefreshToken(ctx, RefreshTokenInput{RefreshToken: second.RefreshToken})
+	require.NoError(t, err)
+
+	sessions, err := authService.ListUserSessions(ctx, ListUserSessionsInput{TargetUserID: user.ID, TenantID: user.TenantID})
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "Firefox", sessions[0].UserAgent)
+
+	t.Run("unknown session", func(t *testing.T) {
+		err := authService.RevokeUserSession(ctx, RevokeSessionInput{
+			TargetUserID: user.ID,
+			TenantID:     user.TenantID,
+			JTI:          firstClaims.ID,
+		})
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, "SESSION_NOT_FOUND", domainErr.Code)
+	})
+
+	t.Run("user of another tenant", func(t *testing.T) {
+		err := authService.RevokeUserSession(ctx, RevokeSessionInput{
+			TargetUserID: user.ID,
+			TenantID:     uuid.New(),
+			JTI:          sessions[0].JTI,
+		})
+		require.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, "FORBIDDEN", domainErr.Code)
+	})
+}
+
 func TestAuthService_RefreshToken_InvalidToken(t *testing.T) {
 	ctx := context.Background()
 	userRepo := new(MockUserRepository)
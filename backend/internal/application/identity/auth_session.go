@@ -0,0 +1,254 @@
+package identity
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/auth"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// startSession records the session a login starts
+func (s *AuthService) startSession(ctx context.Context, user *identity.User, tokenPair *auth.TokenPair, ip, userAgent string) {
+	if s.sessionStore == nil {
+		return
+	}
+
+	now := time.Now()
+	session := &auth.Session{
+		FamilyID:   tokenPair.FamilyID,
+		JTI:        tokenPair.AccessTokenID,
+		UserID:     user.ID.String(),
+		TenantID:   user.TenantID.String(),
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now,
+		IssuedAt:   now,
+		LastSeenAt: now,
+		ExpiresAt:  tokenPair.RefreshTokenExpiresAt,
+	}
+	if err := s.sessionStore.SaveSession(ctx, session); err != nil {
+		s.logger.Error("Failed to save session on login",
+			zap.String("user_id", session.UserID),
+			zap.Error(err))
+		// Don't fail the login - the session is just not listed
+	}
+}
+
+// renewSession moves a session to the token pair issued by refreshing it
+func (s *AuthService) renewSession(ctx context.Context, claims *auth.Claims, tokenPair *auth.TokenPair, ip, userAgent string) {
+	if s.sessionStore == nil {
+		return
+	}
+
+	session, err := s.sessionStore.GetSession(ctx, claims.UserID, tokenPair.FamilyID)
+	if err != nil {
+		s.logger.Error("Failed to load session on token refresh",
+			zap.String("user_id", claims.UserID),
+			zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	if session == nil {
+		// Sessions started before the store was available, or lost by it
+		session = &auth.Session{
+			FamilyID:  tokenPair.FamilyID,
+			UserID:    claims.UserID,
+			TenantID:  claims.TenantID,
+			CreatedAt: claims.GetIssuedAtTime(),
+		}
+	}
+	session.JTI = tokenPair.AccessTokenID
+	session.IssuedAt = now
+	session.LastSeenAt = now
+	session.ExpiresAt = tokenPair.RefreshTokenExpiresAt
+	if ip != "" {
+		session.IP = ip
+	}
+	if userAgent != "" {
+		session.UserAgent = userAgent
+	}
+
+	if err := s.sessionStore.SaveSession(ctx, session); err != nil {
+		s.logger.Error("Failed to save session on token refresh",
+			zap.String("user_id", claims.UserID),
+			zap.Error(err))
+	}
+}
+
+// touchSession records that a session was just seen from ip
+func (s *AuthService) touchSession(ctx context.Context, userID, familyID, ip string) {
+	if s.sessionStore == nil || familyID == "" {
+		return
+	}
+
+	session, err := s.sessionStore.GetSession(ctx, userID, familyID)
+	if err != nil {
+		s.logger.Warn("Failed to load session to update last seen",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return
+	}
+	if session == nil {
+		return
+	}
+
+	session.LastSeenAt = time.Now()
+	if ip != "" {
+		session.IP = ip
+	}
+	if err := s.sessionStore.SaveSession(ctx, session); err != nil {
+		s.logger.Warn("Failed to update session last seen",
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+}
+
+// findTenantUser finds a user the caller may manage, i.e. one of the caller's tenant
+func (s *AuthService) findTenantUser(ctx context.Context, userID, tenantID uuid.UUID) (*identity.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, shared.NewDomainError("USER_NOT_FOUND", "Target user not found")
+	}
+	if user.TenantID != tenantID {
+		s.logger.Warn("Session access attempt across tenant boundary",
+			zap.String("target_user_id", userID.String()),
+			zap.String("tenant_id", tenantID.String()),
+			zap.String("target_tenant_id", user.TenantID.String()))
+		return nil, shared.NewDomainError("FORBIDDEN", "Cannot access sessions of user from different tenant")
+	}
+	return user, nil
+}
+
+// ListUserSessions returns the active sessions of a user, most recently seen first.
+// Sessions ended by a logout, password change, force logout or token reuse are
+// not active anymore and are removed from the store.
+func (s *AuthService) ListUserSessions(ctx context.Context, input ListUserSessionsInput) ([]SessionInfo, error) {
+	if _, err := s.findTenantUser(ctx, input.TargetUserID, input.TenantID); err != nil {
+		return nil, err
+	}
+	if s.sessionStore == nil {
+		return []SessionInfo{}, nil
+	}
+
+	userID := input.TargetUserID.String()
+	sessions, err := s.sessionStore.ListUserSessions(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list user sessions",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to list user sessions")
+	}
+
+	result := make([]SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		if !s.isSessionActive(ctx, session) {
+			if err := s.sessionStore.DeleteSession(ctx, userID, session.FamilyID); err != nil {
+				s.logger.Warn("Failed to remove ended session",
+					zap.String("user_id", userID),
+					zap.Error(err))
+			}
+			continue
+		}
+		result = append(result, SessionInfo{
+			JTI:        session.JTI,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			IssuedAt:   session.IssuedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastSeenAt.After(result[j].LastSeenAt)
+	})
+	return result, nil
+}
+
+// isSessionActive checks that the tokens of a session have not been invalidated.
+// On blacklist errors the session is assumed active.
+func (s *AuthService) isSessionActive(ctx context.Context, session *auth.Session) bool {
+	if s.tokenBlacklist == nil {
+		return true
+	}
+
+	invalidated, err := s.tokenBlacklist.IsUserTokenInvalidated(ctx, session.UserID, session.IssuedAt)
+	if err == nil && invalidated {
+		return false
+	}
+	revoked, err := s.tokenBlacklist.IsTokenFamilyRevoked(ctx, session.FamilyID)
+	if err == nil && revoked {
+		return false
+	}
+	return true
+}
+
+// RevokeUserSession ends a single session of a user, leaving the others valid.
+// The session's access token JTI is blacklisted and its token family revoked, so
+// neither the access token nor the refresh token of the session can be used.
+func (s *AuthService) RevokeUserSession(ctx context.Context, input RevokeSessionInput) error {
+	s.logger.Info("Session revocation initiated",
+		zap.String("admin_user_id", input.AdminUserID.String()),
+		zap.String("target_user_id", input.TargetUserID.String()),
+		zap.String("tenant_id", input.TenantID.String()),
+		zap.String("token_jti", input.JTI))
+
+	if _, err := s.findTenantUser(ctx, input.TargetUserID, input.TenantID); err != nil {
+		return err
+	}
+	if s.sessionStore == nil || s.tokenBlacklist == nil {
+		return shared.NewDomainError("SESSION_NOT_FOUND", "Session not found")
+	}
+
+	userID := input.TargetUserID.String()
+	sessions, err := s.sessionStore.ListUserSessions(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list user sessions",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return shared.NewDomainError("INTERNAL_ERROR", "Failed to revoke session")
+	}
+
+	var session *auth.Session
+	for _, candidate := range sessions {
+		if candidate.JTI == input.JTI {
+			session = candidate
+			break
+		}
+	}
+	if session == nil {
+		return shared.NewDomainError("SESSION_NOT_FOUND", "Session not found")
+	}
+
+	// The access token expiration bounds the remaining lifetime of the JTI
+	if err := s.tokenBlacklist.AddToBlacklist(ctx, session.JTI, s.jwtService.GetAccessTokenExpiration()); err != nil {
+		s.logger.Error("Failed to blacklist session token",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return shared.NewDomainError("INTERNAL_ERROR", "Failed to revoke session")
+	}
+	if err := s.tokenBlacklist.RevokeTokenFamily(ctx, session.FamilyID, s.jwtService.GetRefreshTokenExpiration()); err != nil {
+		s.logger.Error("Failed to revoke session token family",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return shared.NewDomainError("INTERNAL_ERROR", "Failed to revoke session")
+	}
+	if err := s.sessionStore.DeleteSession(ctx, userID, session.FamilyID); err != nil {
+		s.logger.Warn("Failed to remove revoked session",
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+
+	s.logger.Info("Session revoked",
+		zap.String("admin_user_id", input.AdminUserID.String()),
+		zap.String("target_user_id", userID),
+		zap.String("token_jti", session.JTI))
+	return nil
+}
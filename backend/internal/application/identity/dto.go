@@ -8,9 +8,10 @@ import (
 
 // LoginInput contains the input for user login
 type LoginInput struct {
-	Username string
-	Password string
-	IP       string // Client IP for login tracking
+	Username  string
+	Password  string
+	IP        string // Client IP for login tracking
+	UserAgent string // Client user agent, shown in the session list
 }
 
 // LoginResult contains the result of a successful login
@@ -21,6 +22,7 @@ type LoginResult struct {
 	RefreshTokenExpiresAt time.Time
 	TokenType             string
 	User                  UserInfo
+	MustChangePassword    bool // The password was reset by an admin or has expired
 }
 
 // UserInfo contains basic user information returned after login
@@ -41,6 +43,8 @@ type RefreshTokenInput struct {
 	RefreshToken string
 	UserID       uuid.UUID // For permission reload
 	TenantID     uuid.UUID
+	IP           string // Client IP, recorded as the session's last-seen IP
+	UserAgent    string // Client user agent
 }
 
 // RefreshTokenResult contains the result of a token refresh
@@ -70,6 +74,8 @@ type ChangePasswordInput struct {
 type GetCurrentUserInput struct {
 	UserID   uuid.UUID
 	TenantID uuid.UUID
+	FamilyID string // Token family (session) of the request, to update its last-seen time
+	IP       string // Client IP, recorded as the session's last-seen IP
 }
 
 // CurrentUserResult contains the current user's information
@@ -90,3 +96,28 @@ type ForceLogoutInput struct {
 type ForceLogoutResult struct {
 	Message string
 }
+
+// ListUserSessionsInput contains the input for listing a user's sessions
+type ListUserSessionsInput struct {
+	TargetUserID uuid.UUID // User whose sessions are listed
+	TenantID     uuid.UUID
+}
+
+// SessionInfo contains information about an active session of a user
+type SessionInfo struct {
+	JTI        string // JTI of the session's latest access token, used to revoke it
+	UserAgent  string
+	IP         string // IP the session was last seen from
+	CreatedAt  time.Time
+	IssuedAt   time.Time // Issue time of the session's latest access token
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+}
+
+// RevokeSessionInput contains the input for revoking a single session of a user
+type RevokeSessionInput struct {
+	AdminUserID  uuid.UUID // Admin performing the action
+	TargetUserID uuid.UUID // User whose session is revoked
+	TenantID     uuid.UUID
+	JTI          string // JTI of the session's access token
+}
@@ -0,0 +1,112 @@
+package identity
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PasswordPolicyService enforces tenant password policies on password changes and resets
+type PasswordPolicyService struct {
+	tenantRepo  identity.TenantRepository
+	historyRepo identity.PasswordHistoryRepository
+	logger      *zap.Logger
+}
+
+// NewPasswordPolicyService creates a new password policy service
+func NewPasswordPolicyService(
+	tenantRepo identity.TenantRepository,
+	historyRepo identity.PasswordHistoryRepository,
+	logger *zap.Logger,
+) *PasswordPolicyService {
+	return &PasswordPolicyService{
+		tenantRepo:  tenantRepo,
+		historyRepo: historyRepo,
+		logger:      logger,
+	}
+}
+
+// PolicyFor returns the password policy of a tenant
+func (s *PasswordPolicyService) PolicyFor(ctx context.Context, tenantID uuid.UUID) (identity.PasswordPolicy, error) {
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Error("Failed to load tenant password policy",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+		return identity.PasswordPolicy{}, shared.NewDomainError("INTERNAL_ERROR", "Failed to load password policy")
+	}
+	return tenant.Config.PasswordPolicy, nil
+}
+
+// ChangePassword changes a user's password after verifying the old one, enforcing the tenant's policy.
+// The user is not saved; call RecordPreviousPassword with the replaced hash once it is.
+func (s *PasswordPolicyService) ChangePassword(ctx context.Context, user *identity.User, oldPassword, newPassword string) error {
+	policy, previousHashes, err := s.loadPolicy(ctx, user)
+	if err != nil {
+		return err
+	}
+	return user.ChangePasswordWithPolicy(oldPassword, newPassword, policy, previousHashes)
+}
+
+// SetPassword sets a user's password without the old one (admin reset), enforcing the tenant's policy.
+// The user is not saved; call RecordPreviousPassword with the replaced hash once it is.
+func (s *PasswordPolicyService) SetPassword(ctx context.Context, user *identity.User, newPassword string) error {
+	policy, previousHashes, err := s.loadPolicy(ctx, user)
+	if err != nil {
+		return err
+	}
+	return user.SetPasswordWithPolicy(newPassword, policy, previousHashes)
+}
+
+// loadPolicy returns the policy of the user's tenant and the previous password hashes it forbids reusing
+func (s *PasswordPolicyService) loadPolicy(ctx context.Context, user *identity.User) (identity.PasswordPolicy, []string, error) {
+	policy, err := s.PolicyFor(ctx, user.TenantID)
+	if err != nil {
+		return identity.PasswordPolicy{}, nil, err
+	}
+
+	// The current password is checked separately, so only HistoryCount-1 previous ones are needed
+	if policy.HistoryCount <= 1 {
+		return policy, nil, nil
+	}
+	entries, err := s.historyRepo.FindRecentByUserID(ctx, user.ID, policy.HistoryCount-1)
+	if err != nil {
+		s.logger.Error("Failed to load password history",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err))
+		return identity.PasswordPolicy{}, nil, shared.NewDomainError("INTERNAL_ERROR", "Failed to load password history")
+	}
+
+	previousHashes := make([]string, len(entries))
+	for i, entry := range entries {
+		previousHashes[i] = entry.PasswordHash
+	}
+	return policy, previousHashes, nil
+}
+
+// RecordPreviousPassword adds the hash of a replaced password to the user's password history.
+// Failures are logged only: the password change itself already succeeded.
+func (s *PasswordPolicyService) RecordPreviousPassword(ctx context.Context, user *identity.User, previousHash string) {
+	if previousHash == "" {
+		return
+	}
+	if err := s.historyRepo.Save(ctx, identity.NewPasswordHistoryEntry(user, previousHash)); err != nil {
+		s.logger.Error("Failed to record password history",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// IsPasswordExpired checks whether the user's password is older than the tenant policy's max age.
+// If the policy cannot be loaded the password is assumed not expired.
+func (s *PasswordPolicyService) IsPasswordExpired(ctx context.Context, user *identity.User) bool {
+	policy, err := s.PolicyFor(ctx, user.TenantID)
+	if err != nil {
+		return false
+	}
+	return policy.IsExpired(user.PasswordChangedAt, time.Now())
+}
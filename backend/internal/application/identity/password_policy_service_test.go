@@ -0,0 +1,180 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubPasswordHistoryRepository keeps password history in memory, most recent last
+type stubPasswordHistoryRepository struct {
+	entries []identity.PasswordHistoryEntry
+}
+
+func (r *stubPasswordHistoryRepository) Save(_ context.Context, entry *identity.PasswordHistoryEntry) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *stubPasswordHistoryRepository) FindRecentByUserID(_ context.Context, userID uuid.UUID, limit int) ([]identity.PasswordHistoryEntry, error) {
+	var recent []identity.PasswordHistoryEntry
+	for i := len(r.entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		if r.entries[i].UserID == userID {
+			recent = append(recent, r.entries[i])
+		}
+	}
+	return recent, nil
+}
+
+func newTestPasswordPolicyService(tenantID uuid.UUID, policy identity.PasswordPolicy) (*PasswordPolicyService, *stubPasswordHistoryRepository) {
+	tenant := &identity.Tenant{Config: identity.DefaultTenantConfig()}
+	tenant.Config.PasswordPolicy = policy
+	tenantRepo := &stubTenantRepository{tenants: map[uuid.UUID]*identity.Tenant{tenantID: tenant}}
+	historyRepo := &stubPasswordHistoryRepository{}
+	return NewPasswordPolicyService(tenantRepo, historyRepo, zap.NewNop()), historyRepo
+}
+
+func policyViolationRules(t *testing.T, err error) []identity.PasswordRule {
+	t.Helper()
+	var policyErr *identity.PasswordPolicyError
+	require.True(t, errors.As(err, &policyErr))
+	rules := make([]identity.PasswordRule, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		rules[i] = v.Rule
+	}
+	return rules
+}
+
+func TestAuthService_ChangePassword_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	policy := identity.PasswordPolicy{MinLength: 10, RequireLetter: true, RequireDigit: true, HistoryCount: 3}
+
+	t.Run("fails min length", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		user := createTestUser(tenantID)
+		userRepo.On("FindByID", ctx, user.ID).Return(user, nil)
+
+		policyService, historyRepo := newTestPasswordPolicyService(tenantID, policy)
+		authService := createAuthService(userRepo, new(MockRoleRepository))
+		authService.SetPasswordPolicyService(policyService)
+
+		err := authService.ChangePassword(ctx, ChangePasswordInput{
+			UserID:      user.ID,
+			OldPassword: "Password123",
+			NewPassword: "Short12",
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, []identity.PasswordRule{identity.PasswordRuleMinLength}, policyViolationRules(t, err))
+		userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		assert.Empty(t, historyRepo.entries)
+	})
+
+	t.Run("fails reuse of a recent password", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		user := createTestUser(tenantID)
+		userRepo.On("FindByID", ctx, user.ID).Return(user, nil)
+		userRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		policyService, _ := newTestPasswordPolicyService(tenantID, policy)
+		authService := createAuthService(userRepo, new(MockRoleRepository))
+		authService.SetPasswordPolicyService(policyService)
+
+		require.NoError(t, authService.ChangePassword(ctx, ChangePasswordInput{
+			UserID:      user.ID,
+			OldPassword: "Password123",
+			NewPassword: "SecondPassword2",
+		}))
+
+		// Password123 is now in the history, within the last 3 passwords
+		err := authService.ChangePassword(ctx, ChangePasswordInput{
+			UserID:      user.ID,
+			OldPassword: "SecondPassword2",
+			NewPassword: "Password123",
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, []identity.PasswordRule{identity.PasswordRuleReuse}, policyViolationRules(t, err))
+		assert.True(t, user.VerifyPassword("SecondPassword2"))
+	})
+
+	t.Run("passes all rules", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		user := createTestUser(tenantID)
+		previousHash := user.PasswordHash
+		userRepo.On("FindByID", ctx, user.ID).Return(user, nil)
+		userRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		policyService, historyRepo := newTestPasswordPolicyService(tenantID, policy)
+		authService := createAuthService(userRepo, new(MockRoleRepository))
+		authService.SetPasswordPolicyService(policyService)
+
+		require.NoError(t, authService.ChangePassword(ctx, ChangePasswordInput{
+			UserID:      user.ID,
+			OldPassword: "Password123",
+			NewPassword: "BrandNewPassword9",
+		}))
+
+		assert.True(t, user.VerifyPassword("BrandNewPassword9"))
+		require.Len(t, historyRepo.entries, 1)
+		assert.Equal(t, previousHash, historyRepo.entries[0].PasswordHash)
+		userRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_ResetPassword_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	userRepo := new(MockUserRepository)
+	user := createTestUser(tenantID)
+	userRepo.On("FindByID", ctx, user.ID).Return(user, nil)
+
+	policyService, _ := newTestPasswordPolicyService(tenantID, identity.PasswordPolicy{MinLength: 10, RequireUppercase: true, RequireSpecial: true})
+	userService := NewUserService(userRepo, new(MockRoleRepository), zap.NewNop())
+	userService.SetPasswordPolicyService(policyService)
+
+	err := userService.ResetPassword(ctx, user.ID, "lowercase")
+
+	require.Error(t, err)
+	assert.Equal(t, []identity.PasswordRule{
+		identity.PasswordRuleMinLength,
+		identity.PasswordRuleUppercase,
+		identity.PasswordRuleSpecial,
+	}, policyViolationRules(t, err))
+	userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_ExpiredPasswordMustBeChanged(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	userRepo := new(MockUserRepository)
+	roleRepo := new(MockRoleRepository)
+
+	user := createTestUser(tenantID)
+	changedAt := time.Now().AddDate(0, 0, -31)
+	user.PasswordChangedAt = &changedAt
+
+	userRepo.On("FindByUsername", ctx, "testuser").Return(user, nil)
+	userRepo.On("LoadUserRoles", ctx, user).Return(nil)
+	userRepo.On("Update", ctx, mock.Anything).Return(nil)
+	roleRepo.On("FindByIDs", ctx, mock.Anything).Return([]*identity.Role{}, nil)
+
+	policyService, _ := newTestPasswordPolicyService(tenantID, identity.PasswordPolicy{MinLength: 8, MaxAgeDays: 30})
+	authService := createAuthService(userRepo, roleRepo)
+	authService.SetPasswordPolicyService(policyService)
+
+	result, err := authService.Login(ctx, LoginInput{Username: "testuser", Password: "Password123"})
+
+	require.NoError(t, err)
+	assert.True(t, result.MustChangePassword)
+	assert.True(t, user.MustChangePassword)
+}
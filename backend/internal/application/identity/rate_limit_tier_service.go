@@ -0,0 +1,156 @@
+package identity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RateLimitTierConfig maps roles and tenant plans to API rate limits
+type RateLimitTierConfig struct {
+	// RoleLimits maps role codes to the maximum requests per window
+	RoleLimits map[string]int
+	// PlanLimits maps tenant plans to the maximum requests per window
+	PlanLimits map[string]int
+	// CacheTTL is how long role codes and tenant plans are cached (default: 1 minute)
+	CacheTTL time.Duration
+}
+
+// RateLimitTier is the rate limit resolved for a principal
+type RateLimitTier struct {
+	// Bucket names the role or plan the limit comes from, e.g. "role:admin" or "plan:pro"
+	Bucket string
+	// Limit is the maximum requests per window
+	Limit int
+}
+
+// cachedValue is a role code or tenant plan cached until expiresAt
+type cachedValue struct {
+	value     string
+	expiresAt time.Time
+}
+
+// RateLimitTierService resolves the API rate limit of a principal from its roles
+// and its tenant's plan. The highest configured limit wins.
+//
+// It runs on every API request, so role codes and tenant plans are cached for
+// CacheTTL; a role or plan change takes effect within that time.
+type RateLimitTierService struct {
+	tenantRepo identity.TenantRepository
+	roleRepo   identity.RoleRepository
+	config     RateLimitTierConfig
+	logger     *zap.Logger
+
+	mu    sync.Mutex
+	plans map[uuid.UUID]cachedValue // tenant ID -> plan
+	roles map[uuid.UUID]cachedValue // role ID -> role code
+}
+
+// NewRateLimitTierService creates a new rate limit tier service
+func NewRateLimitTierService(
+	tenantRepo identity.TenantRepository,
+	roleRepo identity.RoleRepository,
+	config RateLimitTierConfig,
+	logger *zap.Logger,
+) *RateLimitTierService {
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = time.Minute
+	}
+	return &RateLimitTierService{
+		tenantRepo: tenantRepo,
+		roleRepo:   roleRepo,
+		config:     config,
+		logger:     logger,
+		plans:      make(map[uuid.UUID]cachedValue),
+		roles:      make(map[uuid.UUID]cachedValue),
+	}
+}
+
+// ResolveTier returns the highest rate limit configured for the roles or the tenant plan.
+// Returns false if none of them has a configured limit.
+func (s *RateLimitTierService) ResolveTier(ctx context.Context, tenantID uuid.UUID, roleIDs []uuid.UUID) (RateLimitTier, bool) {
+	var best RateLimitTier
+
+	if len(s.config.RoleLimits) > 0 {
+		for _, code := range s.roleCodes(ctx, roleIDs) {
+			if limit, ok := s.config.RoleLimits[code]; ok && limit > best.Limit {
+				best = RateLimitTier{Bucket: "role:" + code, Limit: limit}
+			}
+		}
+	}
+
+	if len(s.config.PlanLimits) > 0 {
+		if plan, ok := s.tenantPlan(ctx, tenantID); ok {
+			if limit, ok := s.config.PlanLimits[plan]; ok && limit > best.Limit {
+				best = RateLimitTier{Bucket: "plan:" + plan, Limit: limit}
+			}
+		}
+	}
+
+	return best, best.Limit > 0
+}
+
+// tenantPlan returns the plan of a tenant, from the cache if possible
+func (s *RateLimitTierService) tenantPlan(ctx context.Context, tenantID uuid.UUID) (string, bool) {
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.plans[tenantID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.value, true
+	}
+
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Warn("Failed to load tenant plan for rate limiting",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+		return "", false
+	}
+
+	plan := string(tenant.Plan)
+	s.mu.Lock()
+	s.plans[tenantID] = cachedValue{value: plan, expiresAt: now.Add(s.config.CacheTTL)}
+	s.mu.Unlock()
+	return plan, true
+}
+
+// roleCodes returns the codes of the roles, from the cache if possible
+func (s *RateLimitTierService) roleCodes(ctx context.Context, roleIDs []uuid.UUID) []string {
+	now := time.Now()
+	codes := make([]string, 0, len(roleIDs))
+	var missing []uuid.UUID
+
+	s.mu.Lock()
+	for _, id := range roleIDs {
+		if cached, ok := s.roles[id]; ok && now.Before(cached.expiresAt) {
+			codes = append(codes, cached.value)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(missing) == 0 {
+		return codes
+	}
+
+	roles, err := s.roleRepo.FindByIDs(ctx, missing)
+	if err != nil {
+		s.logger.Warn("Failed to load roles for rate limiting", zap.Error(err))
+		return codes
+	}
+
+	s.mu.Lock()
+	for _, role := range roles {
+		s.roles[role.ID] = cachedValue{value: role.Code, expiresAt: now.Add(s.config.CacheTTL)}
+		codes = append(codes, role.Code)
+	}
+	s.mu.Unlock()
+	return codes
+}
@@ -0,0 +1,92 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// stubTenantRepository returns tenants by ID and counts the lookups
+type stubTenantRepository struct {
+	identity.TenantRepository
+	tenants map[uuid.UUID]*identity.Tenant
+	finds   int
+}
+
+func (r *stubTenantRepository) FindByID(_ context.Context, id uuid.UUID) (*identity.Tenant, error) {
+	r.finds++
+	tenant, ok := r.tenants[id]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return tenant, nil
+}
+
+func TestRateLimitTierService_ResolveTier(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	admin := createTestRole(tenantID)
+	admin.Code = "admin"
+	clerk := createTestRole(tenantID)
+	clerk.Code = "clerk"
+
+	newService := func(plan identity.TenantPlan) (*RateLimitTierService, *stubTenantRepository, *MockRoleRepository) {
+		tenantRepo := &stubTenantRepository{tenants: map[uuid.UUID]*identity.Tenant{
+			tenantID: {Plan: plan},
+		}}
+		roleRepo := new(MockRoleRepository)
+		service := NewRateLimitTierService(tenantRepo, roleRepo, RateLimitTierConfig{
+			RoleLimits: map[string]int{"admin": 1000},
+			PlanLimits: map[string]int{"free": 100, "enterprise": 5000},
+		}, zap.NewNop())
+		return service, tenantRepo, roleRepo
+	}
+
+	t.Run("role limit above the plan limit wins", func(t *testing.T) {
+		service, _, roleRepo := newService(identity.TenantPlanFree)
+		roleRepo.On("FindByIDs", ctx, []uuid.UUID{clerk.ID, admin.ID}).Return([]*identity.Role{clerk, admin}, nil)
+
+		tier, ok := service.ResolveTier(ctx, tenantID, []uuid.UUID{clerk.ID, admin.ID})
+
+		assert.True(t, ok)
+		assert.Equal(t, RateLimitTier{Bucket: "role:admin", Limit: 1000}, tier)
+	})
+
+	t.Run("plan limit above the role limit wins", func(t *testing.T) {
+		service, _, roleRepo := newService(identity.TenantPlanEnterprise)
+		roleRepo.On("FindByIDs", ctx, []uuid.UUID{admin.ID}).Return([]*identity.Role{admin}, nil)
+
+		tier, ok := service.ResolveTier(ctx, tenantID, []uuid.UUID{admin.ID})
+
+		assert.True(t, ok)
+		assert.Equal(t, RateLimitTier{Bucket: "plan:enterprise", Limit: 5000}, tier)
+	})
+
+	t.Run("no configured role or plan", func(t *testing.T) {
+		service, _, roleRepo := newService(identity.TenantPlanPro)
+		roleRepo.On("FindByIDs", ctx, []uuid.UUID{clerk.ID}).Return([]*identity.Role{clerk}, nil)
+
+		_, ok := service.ResolveTier(ctx, tenantID, []uuid.UUID{clerk.ID})
+
+		assert.False(t, ok)
+	})
+
+	t.Run("caches role codes and tenant plans", func(t *testing.T) {
+		service, tenantRepo, roleRepo := newService(identity.TenantPlanFree)
+		roleRepo.On("FindByIDs", ctx, []uuid.UUID{admin.ID}).Return([]*identity.Role{admin}, nil)
+
+		for i := 0; i < 3; i++ {
+			tier, ok := service.ResolveTier(ctx, tenantID, []uuid.UUID{admin.ID})
+			assert.True(t, ok)
+			assert.Equal(t, 1000, tier.Limit)
+		}
+
+		roleRepo.AssertNumberOfCalls(t, "FindByIDs", 1)
+		assert.Equal(t, 1, tenantRepo.finds)
+	})
+}
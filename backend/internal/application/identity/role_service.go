@@ -106,6 +106,9 @@ func (s *RoleService) Create(ctx context.Context, input CreateRoleInput) (*RoleD
 
 	// Add permissions
 	for _, permCode := range input.Permissions {
+		if !identity.IsAssignablePermission(permCode) {
+			return nil, errPermissionNotAssignable(permCode)
+		}
 		if err := role.GrantPermissionByCode(permCode); err != nil {
 			// Skip duplicate errors silently
 			if domainErr, ok := err.(*shared.DomainError); ok && domainErr.Code == "PERMISSION_ALREADY_GRANTED" {
@@ -403,6 +406,9 @@ func (s *RoleService) SetPermissions(ctx context.Context, roleID uuid.UUID, perm
 	// Build new permission list
 	permissions := make([]identity.Permission, 0, len(permissionCodes))
 	for _, code := range permissionCodes {
+		if !identity.IsAssignablePermission(code) {
+			return nil, errPermissionNotAssignable(code)
+		}
 		perm, err := identity.NewPermissionFromCode(code)
 		if err != nil {
 			return nil, err
@@ -484,3 +490,8 @@ func toRoleDTO(role *identity.Role) *RoleDTO {
 		UpdatedAt:    role.UpdatedAt,
 	}
 }
+
+// errPermissionNotAssignable is returned when a role is given a permission reserved for platform operators
+func errPermissionNotAssignable(code string) error {
+	return shared.NewDomainError("PERMISSION_NOT_ASSIGNABLE", "Permission "+code+" cannot be assigned to roles")
+}
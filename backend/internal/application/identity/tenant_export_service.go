@@ -0,0 +1,293 @@
+package identity
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	financeapp "github.com/erp/backend/internal/application/finance"
+	partnerapp "github.com/erp/backend/internal/application/partner"
+	tradeapp "github.com/erp/backend/internal/application/trade"
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// exportPageSize is the number of rows read per query during a tenant export
+const exportPageSize = 100
+
+// Files of a tenant export archive
+const (
+	ExportFileTenant      = "tenant.json"
+	ExportFileUsers       = "users.json"
+	ExportFileCustomers   = "customers.json"
+	ExportFileSalesOrders = "sales_orders.json"
+	ExportFileInvoices    = "invoices.json"
+	ExportFileManifest    = "manifest.json"
+)
+
+// TenantExportManifest describes the contents of a tenant export archive.
+// It is written last, so an archive with a manifest is complete.
+type TenantExportManifest struct {
+	TenantID   uuid.UUID      `json:"tenant_id"`
+	TenantCode string         `json:"tenant_code"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Counts     map[string]int `json:"counts"` // file name -> number of records
+}
+
+// TenantExportService exports all data of a tenant (GDPR-style data portability).
+//
+// The export is a zip archive of JSON files streamed to a writer: each file is
+// read page by page with read-only queries, so large tenants are never held in
+// memory. Every query is scoped to the tenant and every row is checked against
+// it again, so a row of another tenant aborts the export instead of leaking.
+type TenantExportService struct {
+	tenantRepo     identity.TenantRepository
+	userRepo       identity.UserRepository
+	customerRepo   partner.CustomerRepository
+	salesOrderRepo trade.SalesOrderRepository
+	receivableRepo finance.AccountReceivableRepository
+	logger         *zap.Logger
+}
+
+// NewTenantExportService creates a new tenant export service
+func NewTenantExportService(
+	tenantRepo identity.TenantRepository,
+	userRepo identity.UserRepository,
+	customerRepo partner.CustomerRepository,
+	salesOrderRepo trade.SalesOrderRepository,
+	receivableRepo finance.AccountReceivableRepository,
+	logger *zap.Logger,
+) *TenantExportService {
+	return &TenantExportService{
+		tenantRepo:     tenantRepo,
+		userRepo:       userRepo,
+		customerRepo:   customerRepo,
+		salesOrderRepo: salesOrderRepo,
+		receivableRepo: receivableRepo,
+		logger:         logger,
+	}
+}
+
+// ExportTenantData writes a zip archive of the tenant's users, customers, sales
+// orders and invoices (accounts receivable) to w.
+// Nothing is written if the tenant does not exist.
+func (s *TenantExportService) ExportTenantData(ctx context.Context, tenantID uuid.UUID, w io.Writer) error {
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		if err == shared.ErrNotFound {
+			return shared.NewDomainError("TENANT_NOT_FOUND", "Tenant not found")
+		}
+		s.logger.Error("Failed to find tenant for export", zap.Error(err))
+		return shared.NewDomainError("INTERNAL_ERROR", "Failed to find tenant")
+	}
+
+	archive := zip.NewWriter(w)
+	manifest := TenantExportManifest{
+		TenantID:   tenant.ID,
+		TenantCode: tenant.Code,
+		ExportedAt: time.Now(),
+		Counts:     make(map[string]int),
+	}
+
+	if err := writeExportObject(archive, ExportFileTenant, toTenantDTO(tenant)); err != nil {
+		return s.exportFailed(tenantID, ExportFileTenant, err)
+	}
+	manifest.Counts[ExportFileTenant] = 1
+
+	files := []struct {
+		name  string
+		write func() (int, error)
+	}{
+		{ExportFileUsers, func() (int, error) { return s.exportUsers(ctx, archive, tenantID) }},
+		{ExportFileCustomers, func() (int, error) { return s.exportCustomers(ctx, archive, tenantID) }},
+		{ExportFileSalesOrders, func() (int, error) { return s.exportSalesOrders(ctx, archive, tenantID) }},
+		{ExportFileInvoices, func() (int, error) { return s.exportInvoices(ctx, archive, tenantID) }},
+	}
+	for _, file := range files {
+		count, err := file.write()
+		if err != nil {
+			return s.exportFailed(tenantID, file.name, err)
+		}
+		manifest.Counts[file.name] = count
+	}
+
+	if err := writeExportObject(archive, ExportFileManifest, manifest); err != nil {
+		return s.exportFailed(tenantID, ExportFileManifest, err)
+	}
+	if err := archive.Close(); err != nil {
+		return s.exportFailed(tenantID, ExportFileManifest, err)
+	}
+
+	s.logger.Info("Tenant data exported",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Any("counts", manifest.Counts))
+	return nil
+}
+
+// exportFailed logs a failed export and returns the error to report
+func (s *TenantExportService) exportFailed(tenantID uuid.UUID, file string, err error) error {
+	s.logger.Error("Failed to export tenant data",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("file", file),
+		zap.Error(err))
+	return shared.NewDomainError("EXPORT_FAILED", "Failed to export tenant data")
+}
+
+// exportUsers writes the tenant's users
+func (s *TenantExportService) exportUsers(ctx context.Context, archive *zip.Writer, tenantID uuid.UUID) (int, error) {
+	return writeExportList(archive, ExportFileUsers, func(page int) ([]any, error) {
+		filter := identity.NewUserFilter().
+			WithTenantID(tenantID).
+			WithPagination(page, exportPageSize).
+			WithSorting("id", "asc")
+		users, _, err := s.userRepo.FindAll(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]any, len(users))
+		for i, user := range users {
+			if err := checkExportTenant(tenantID, user.TenantID, "user", user.ID); err != nil {
+				return nil, err
+			}
+			rows[i] = toUserDTO(user)
+		}
+		return rows, nil
+	})
+}
+
+// exportCustomers writes the tenant's customers
+func (s *TenantExportService) exportCustomers(ctx context.Context, archive *zip.Writer, tenantID uuid.UUID) (int, error) {
+	return writeExportList(archive, ExportFileCustomers, func(page int) ([]any, error) {
+		customers, err := s.customerRepo.FindAllForTenant(ctx, tenantID, exportFilter(page))
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]any, len(customers))
+		for i := range customers {
+			if err := checkExportTenant(tenantID, customers[i].TenantID, "customer", customers[i].ID); err != nil {
+				return nil, err
+			}
+			rows[i] = partnerapp.ToCustomerResponse(&customers[i])
+		}
+		return rows, nil
+	})
+}
+
+// exportSalesOrders writes the tenant's sales orders with their items
+func (s *TenantExportService) exportSalesOrders(ctx context.Context, archive *zip.Writer, tenantID uuid.UUID) (int, error) {
+	return writeExportList(archive, ExportFileSalesOrders, func(page int) ([]any, error) {
+		orders, err := s.salesOrderRepo.FindAllForTenant(ctx, tenantID, exportFilter(page))
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]any, len(orders))
+		for i := range orders {
+			if err := checkExportTenant(tenantID, orders[i].TenantID, "sales order", orders[i].ID); err != nil {
+				return nil, err
+			}
+			rows[i] = tradeapp.ToSalesOrderResponse(&orders[i])
+		}
+		return rows, nil
+	})
+}
+
+// exportInvoices writes the tenant's invoices (accounts receivable) with their payment records
+func (s *TenantExportService) exportInvoices(ctx context.Context, archive *zip.Writer, tenantID uuid.UUID) (int, error) {
+	return writeExportList(archive, ExportFileInvoices, func(page int) ([]any, error) {
+		receivables, err := s.receivableRepo.FindAllForTenant(ctx, tenantID, finance.AccountReceivableFilter{
+			Filter: exportFilter(page),
+		})
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]any, len(receivables))
+		for i := range receivables {
+			if err := checkExportTenant(tenantID, receivables[i].TenantID, "receivable", receivables[i].ID); err != nil {
+				return nil, err
+			}
+			rows[i] = financeapp.ToReceivableResponse(&receivables[i])
+		}
+		return rows, nil
+	})
+}
+
+// exportFilter returns the filter reading one page of an export, in a stable order
+func exportFilter(page int) shared.Filter {
+	return shared.Filter{
+		Page:     page,
+		PageSize: exportPageSize,
+		OrderBy:  "id",
+		OrderDir: "asc",
+	}
+}
+
+// checkExportTenant returns an error if a row to export belongs to another tenant
+func checkExportTenant(tenantID, rowTenantID uuid.UUID, kind string, rowID uuid.UUID) error {
+	if rowTenantID != tenantID {
+		return fmt.Errorf("%s %s belongs to tenant %s, not to the exported tenant %s", kind, rowID, rowTenantID, tenantID)
+	}
+	return nil
+}
+
+// writeExportObject writes a single JSON object as a file of the archive
+func writeExportObject(archive *zip.Writer, name string, value any) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeExportList writes a JSON array as a file of the archive, reading and
+// encoding one page at a time until a page is not full.
+// Returns the number of records written.
+func writeExportList(archive *zip.Writer, name string, nextPage func(page int) ([]any, error)) (int, error) {
+	file, err := archive.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.WriteString(file, "["); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for page := 1; ; page++ {
+		rows, err := nextPage(page)
+		if err != nil {
+			return count, err
+		}
+		for _, row := range rows {
+			data, err := json.Marshal(row)
+			if err != nil {
+				return count, err
+			}
+			separator := "\n"
+			if count > 0 {
+				separator = ",\n"
+			}
+			if _, err := io.WriteString(file, separator); err != nil {
+				return count, err
+			}
+			if _, err := file.Write(data); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(rows) < exportPageSize {
+			break
+		}
+	}
+
+	_, err = io.WriteString(file, "\n]\n")
+	return count, err
+}
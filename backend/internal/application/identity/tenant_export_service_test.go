@@ -0,0 +1,254 @@
+package identity
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// page returns one page of rows as a repository would
+func page[T any](rows []T, pageNum, pageSize int) []T {
+	start := (pageNum - 1) * pageSize
+	if start >= len(rows) {
+		return nil
+	}
+	end := start + pageSize
+	if end > len(rows) {
+		end = len(rows)
+	}
+	return rows[start:end]
+}
+
+// stubExportUserRepository returns users filtered by UserFilter.TenantID
+type stubExportUserRepository struct {
+	identity.UserRepository
+	users []*identity.User
+}
+
+func (r *stubExportUserRepository) FindAll(_ context.Context, filter identity.UserFilter) ([]*identity.User, int64, error) {
+	var users []*identity.User
+	for _, user := range r.users {
+		if filter.TenantID == nil || user.TenantID == *filter.TenantID {
+			users = append(users, user)
+		}
+	}
+	return page(users, filter.Page, filter.PageSize), int64(len(users)), nil
+}
+
+// stubExportCustomerRepository returns customers of the tenant, or of every tenant if leaky
+type stubExportCustomerRepository struct {
+	partner.CustomerRepository
+	customers []partner.Customer
+	leaky     bool
+}
+
+func (r *stubExportCustomerRepository) FindAllForTenant(_ context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Customer, error) {
+	var customers []partner.Customer
+	for _, customer := range r.customers {
+		if r.leaky || customer.TenantID == tenantID {
+			customers = append(customers, customer)
+		}
+	}
+	return page(customers, filter.Page, filter.PageSize), nil
+}
+
+// stubExportSalesOrderRepository returns sales orders of the tenant
+type stubExportSalesOrderRepository struct {
+	trade.SalesOrderRepository
+	orders []trade.SalesOrder
+}
+
+func (r *stubExportSalesOrderRepository) FindAllForTenant(_ context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.SalesOrder, error) {
+	var orders []trade.SalesOrder
+	for _, order := range r.orders {
+		if order.TenantID == tenantID {
+			orders = append(orders, order)
+		}
+	}
+	return page(orders, filter.Page, filter.PageSize), nil
+}
+
+// stubExportReceivableRepository returns receivables of the tenant
+type stubExportReceivableRepository struct {
+	finance.AccountReceivableRepository
+	receivables []finance.AccountReceivable
+}
+
+func (r *stubExportReceivableRepository) FindAllForTenant(_ context.Context, tenantID uuid.UUID, filter finance.AccountReceivableFilter) ([]finance.AccountReceivable, error) {
+	var receivables []finance.AccountReceivable
+	for _, receivable := range r.receivables {
+		if receivable.TenantID == tenantID {
+			receivables = append(receivables, receivable)
+		}
+	}
+	return page(receivables, filter.Page, filter.PageSize), nil
+}
+
+// exportFixture holds the rows of two tenants
+type exportFixture struct {
+	tenants     *stubTenantRepository
+	users       *stubExportUserRepository
+	customers   *stubExportCustomerRepository
+	orders      *stubExportSalesOrderRepository
+	receivables *stubExportReceivableRepository
+}
+
+// newExportFixture creates customers rows per tenant and one user, sales order and receivable per tenant
+func newExportFixture(tenantIDs []uuid.UUID, customers int) *exportFixture {
+	f := &exportFixture{
+		tenants:     &stubTenantRepository{tenants: make(map[uuid.UUID]*identity.Tenant)},
+		users:       &stubExportUserRepository{},
+		customers:   &stubExportCustomerRepository{},
+		orders:      &stubExportSalesOrderRepository{},
+		receivables: &stubExportReceivableRepository{},
+	}
+	for _, tenantID := range tenantIDs {
+		tenant := &identity.Tenant{Code: "T-" + tenantID.String()[:8]}
+		tenant.ID = tenantID
+		f.tenants.tenants[tenantID] = tenant
+
+		f.users.users = append(f.users.users, &identity.User{
+			TenantAggregateRoot: shared.NewTenantAggregateRoot(tenantID),
+			Username:            "user-" + tenantID.String()[:8],
+		})
+		for i := 0; i < customers; i++ {
+			f.customers.customers = append(f.customers.customers, partner.Customer{
+				TenantAggregateRoot: shared.NewTenantAggregateRoot(tenantID),
+				Name:                "customer",
+			})
+		}
+		f.orders.orders = append(f.orders.orders, trade.SalesOrder{
+			TenantAggregateRoot: shared.NewTenantAggregateRoot(tenantID),
+			OrderNumber:         "SO-" + tenantID.String()[:8],
+		})
+		f.receivables.receivables = append(f.receivables.receivables, finance.AccountReceivable{
+			TenantAggregateRoot: shared.NewTenantAggregateRoot(tenantID),
+			ReceivableNumber:    "AR-" + tenantID.String()[:8],
+		})
+	}
+	return f
+}
+
+func (f *exportFixture) service() *TenantExportService {
+	return NewTenantExportService(f.tenants, f.users, f.customers, f.orders, f.receivables, zap.NewNop())
+}
+
+// readExportArchive returns the JSON files of an export archive by name
+func readExportArchive(t *testing.T, data []byte) map[string]json.RawMessage {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := make(map[string]json.RawMessage)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		var raw json.RawMessage
+		require.NoError(t, json.NewDecoder(rc).Decode(&raw), file.Name)
+		_ = rc.Close()
+		files[file.Name] = raw
+	}
+	return files
+}
+
+// exportedTenantIDs returns the tenant_id of each record of a JSON array file
+func exportedTenantIDs(t *testing.T, raw json.RawMessage) []uuid.UUID {
+	var rows []struct {
+		TenantID uuid.UUID `json:"tenant_id"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &rows))
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.TenantID
+	}
+	return ids
+}
+
+func TestTenantExportService_ExportTenantData(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	otherTenantID := uuid.New()
+
+	t.Run("includes only the requested tenant's rows", func(t *testing.T) {
+		// More customers than fit in one page
+		fixture := newExportFixture([]uuid.UUID{tenantID, otherTenantID}, exportPageSize+20)
+		var buf bytes.Buffer
+
+		err := fixture.service().ExportTenantData(ctx, tenantID, &buf)
+		require.NoError(t, err)
+
+		files := readExportArchive(t, buf.Bytes())
+		for _, name := range []string{ExportFileUsers, ExportFileCustomers, ExportFileSalesOrders, ExportFileInvoices} {
+			require.Contains(t, files, name)
+			ids := exportedTenantIDs(t, files[name])
+			assert.NotEmpty(t, ids, name)
+			for _, id := range ids {
+				assert.Equal(t, tenantID, id, name)
+			}
+		}
+		assert.Len(t, exportedTenantIDs(t, files[ExportFileCustomers]), exportPageSize+20)
+
+		var tenant TenantDTO
+		require.NoError(t, json.Unmarshal(files[ExportFileTenant], &tenant))
+		assert.Equal(t, tenantID, tenant.ID)
+
+		var manifest TenantExportManifest
+		require.NoError(t, json.Unmarshal(files[ExportFileManifest], &manifest))
+		assert.Equal(t, tenantID, manifest.TenantID)
+		assert.Equal(t, map[string]int{
+			ExportFileTenant:      1,
+			ExportFileUsers:       1,
+			ExportFileCustomers:   exportPageSize + 20,
+			ExportFileSalesOrders: 1,
+			ExportFileInvoices:    1,
+		}, manifest.Counts)
+	})
+
+	t.Run("empty tenant", func(t *testing.T) {
+		fixture := newExportFixture([]uuid.UUID{otherTenantID}, 1)
+		tenant := &identity.Tenant{}
+		tenant.ID = tenantID
+		fixture.tenants.tenants[tenantID] = tenant
+		var buf bytes.Buffer
+
+		err := fixture.service().ExportTenantData(ctx, tenantID, &buf)
+		require.NoError(t, err)
+
+		files := readExportArchive(t, buf.Bytes())
+		assert.Empty(t, exportedTenantIDs(t, files[ExportFileCustomers]))
+	})
+
+	t.Run("aborts on a row of another tenant", func(t *testing.T) {
+		fixture := newExportFixture([]uuid.UUID{tenantID, otherTenantID}, 1)
+		fixture.customers.leaky = true
+		var buf bytes.Buffer
+
+		err := fixture.service().ExportTenantData(ctx, tenantID, &buf)
+
+		require.Error(t, err)
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "EXPORT_FAILED", domainErr.Code)
+	})
+
+	t.Run("tenant not found writes nothing", func(t *testing.T) {
+		fixture := newExportFixture(nil, 0)
+		var buf bytes.Buffer
+
+		err := fixture.service().ExportTenantData(ctx, tenantID, &buf)
+
+		require.Error(t, err)
+		assert.Zero(t, buf.Len())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -66,6 +67,12 @@ type TenantConfigInput struct {
 	Currency      *string
 	Timezone      *string
 	Locale        *string
+
+	CreditControlEnabled      *bool
+	ExpenseApprovalThresholds *[]decimal.Decimal
+	IndustryPlugin            *string
+	ShipExpiryBufferDays      *int
+	PasswordPolicy            *identity.PasswordPolicy // Replaces the whole policy
 }
 
 // TenantDTO represents tenant data transfer object
@@ -99,6 +106,24 @@ type TenantConfigDTO struct {
 	Currency      string `json:"currency"`
 	Timezone      string `json:"timezone"`
 	Locale        string `json:"locale"`
+
+	CreditControlEnabled      bool              `json:"credit_control_enabled"`
+	ExpenseApprovalThresholds []decimal.Decimal `json:"expense_approval_thresholds"`
+	IndustryPlugin            string            `json:"industry_plugin"`
+	ShipExpiryBufferDays      int               `json:"ship_expiry_buffer_days"`
+	PasswordPolicy            PasswordPolicyDTO `json:"password_policy"`
+}
+
+// PasswordPolicyDTO represents a tenant's password policy
+type PasswordPolicyDTO struct {
+	MinLength        int  `json:"min_length"`
+	RequireLetter    bool `json:"require_letter"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSpecial   bool `json:"require_special"`
+	HistoryCount     int  `json:"history_count"`
+	MaxAgeDays       int  `json:"max_age_days"`
 }
 
 // TenantFilter represents filter for querying tenants
@@ -431,6 +456,21 @@ func (s *TenantService) UpdateConfig(ctx context.Context, id uuid.UUID, input Te
 	if input.Locale != nil {
 		config.Locale = *input.Locale
 	}
+	if input.CreditControlEnabled != nil {
+		config.CreditControlEnabled = *input.CreditControlEnabled
+	}
+	if input.ExpenseApprovalThresholds != nil {
+		config.ExpenseApprovalThresholds = *input.ExpenseApprovalThresholds
+	}
+	if input.IndustryPlugin != nil {
+		config.IndustryPlugin = *input.IndustryPlugin
+	}
+	if input.ShipExpiryBufferDays != nil {
+		config.ShipExpiryBufferDays = *input.ShipExpiryBufferDays
+	}
+	if input.PasswordPolicy != nil {
+		config.PasswordPolicy = *input.PasswordPolicy
+	}
 
 	if err := tenant.UpdateConfig(config); err != nil {
 		return nil, err
@@ -645,6 +685,21 @@ func toTenantDTO(tenant *identity.Tenant) *TenantDTO {
 			Currency:      tenant.Config.Currency,
 			Timezone:      tenant.Config.Timezone,
 			Locale:        tenant.Config.Locale,
+
+			CreditControlEnabled:      tenant.Config.CreditControlEnabled,
+			ExpenseApprovalThresholds: tenant.Config.ExpenseApprovalThresholds,
+			IndustryPlugin:            tenant.Config.IndustryPlugin,
+			ShipExpiryBufferDays:      tenant.Config.ShipExpiryBufferDays,
+			PasswordPolicy: PasswordPolicyDTO{
+				MinLength:        tenant.Config.PasswordPolicy.MinLength,
+				RequireLetter:    tenant.Config.PasswordPolicy.RequireLetter,
+				RequireUppercase: tenant.Config.PasswordPolicy.RequireUppercase,
+				RequireLowercase: tenant.Config.PasswordPolicy.RequireLowercase,
+				RequireDigit:     tenant.Config.PasswordPolicy.RequireDigit,
+				RequireSpecial:   tenant.Config.PasswordPolicy.RequireSpecial,
+				HistoryCount:     tenant.Config.PasswordPolicy.HistoryCount,
+				MaxAgeDays:       tenant.Config.PasswordPolicy.MaxAgeDays,
+			},
 		},
 		Notes:     tenant.Notes,
 		CreatedAt: tenant.CreatedAt,
@@ -0,0 +1,185 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+)
+
+// StrategyCatalog tells which registry strategies a tenant can choose and which apply by default
+type StrategyCatalog interface {
+	IsRegistered(strategyType strategy.StrategyType, name string) bool
+	GetDefault(strategyType strategy.StrategyType) string
+}
+
+// ReconciliationStrategyCatalog tells which finance reconciliation strategies a tenant can choose
+// and which applies by default
+type ReconciliationStrategyCatalog interface {
+	GetDefaultStrategy() finance.ReconciliationStrategyType
+	IsStrategyAvailable(strategyType finance.ReconciliationStrategyType) bool
+}
+
+// TenantStrategyService manages the strategies tenants choose in place of the global defaults
+type TenantStrategyService struct {
+	repo           strategy.TenantStrategyConfigRepository
+	catalog        StrategyCatalog
+	reconciliation ReconciliationStrategyCatalog
+}
+
+// NewTenantStrategyService creates a new tenant strategy service
+func NewTenantStrategyService(
+	repo strategy.TenantStrategyConfigRepository,
+	catalog StrategyCatalog,
+	reconciliation ReconciliationStrategyCatalog,
+) *TenantStrategyService {
+	return &TenantStrategyService{
+		repo:           repo,
+		catalog:        catalog,
+		reconciliation: reconciliation,
+	}
+}
+
+// TenantStrategyChoiceDTO is the strategy a tenant chose for one strategy type
+type TenantStrategyChoiceDTO struct {
+	// Configured is the strategy the tenant chose, empty for the default
+	Configured string `json:"configured"`
+	// Effective is the strategy that applies to the tenant
+	Effective string `json:"effective"`
+}
+
+// TenantStrategyConfigDTO is the strategy configuration of a tenant
+type TenantStrategyConfigDTO struct {
+	TenantID       uuid.UUID               `json:"tenant_id"`
+	Cost           TenantStrategyChoiceDTO `json:"cost"`
+	Pricing        TenantStrategyChoiceDTO `json:"pricing"`
+	Allocation     TenantStrategyChoiceDTO `json:"allocation"`
+	Reconciliation TenantStrategyChoiceDTO `json:"reconciliation"`
+	UpdatedAt      *time.Time              `json:"updated_at,omitempty"`
+}
+
+// UpdateTenantStrategyInput replaces the strategies of a tenant; an empty strategy restores the default
+type UpdateTenantStrategyInput struct {
+	CostStrategy           string
+	PricingStrategy        string
+	AllocationStrategy     string
+	ReconciliationStrategy string
+}
+
+// Get returns the strategy configuration of a tenant, with the defaults for the strategies it has not chosen
+func (s *TenantStrategyService) Get(ctx context.Context, tenantID uuid.UUID) (*TenantStrategyConfigDTO, error) {
+	config, err := s.repo.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		if !errors.Is(err, shared.ErrNotFound) {
+			return nil, err
+		}
+		config = nil
+	}
+	return s.toDTO(tenantID, config), nil
+}
+
+// Update replaces the strategy configuration of a tenant.
+// Returns an UNKNOWN_STRATEGY error if a strategy is not registered; manual reconciliation
+// cannot be a default as it needs the allocations of each voucher.
+func (s *TenantStrategyService) Update(ctx context.Context, tenantID uuid.UUID, input UpdateTenantStrategyInput) (*TenantStrategyConfigDTO, error) {
+	cost := strings.TrimSpace(input.CostStrategy)
+	pricing := strings.TrimSpace(input.PricingStrategy)
+	allocation := strings.TrimSpace(input.AllocationStrategy)
+	reconciliation := strings.ToUpper(strings.TrimSpace(input.ReconciliationStrategy))
+
+	if err := s.validateStrategy(strategy.StrategyTypeCost, cost); err != nil {
+		return nil, err
+	}
+	if err := s.validateStrategy(strategy.StrategyTypePricing, pricing); err != nil {
+		return nil, err
+	}
+	if err := s.validateStrategy(strategy.StrategyTypeAllocation, allocation); err != nil {
+		return nil, err
+	}
+	if err := s.validateReconciliationStrategy(reconciliation); err != nil {
+		return nil, err
+	}
+
+	config, err := s.repo.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		if !errors.Is(err, shared.ErrNotFound) {
+			return nil, err
+		}
+		config = strategy.NewTenantStrategyConfig(tenantID)
+	}
+	config.CostStrategy = cost
+	config.PricingStrategy = pricing
+	config.AllocationStrategy = allocation
+	config.ReconciliationStrategy = reconciliation
+	config.UpdatedAt = time.Now()
+
+	if err := s.repo.Save(ctx, config); err != nil {
+		return nil, err
+	}
+	return s.toDTO(tenantID, config), nil
+}
+
+func (s *TenantStrategyService) validateStrategy(strategyType strategy.StrategyType, name string) error {
+	if name == "" || s.catalog.IsRegistered(strategyType, name) {
+		return nil
+	}
+	return shared.NewDomainError("UNKNOWN_STRATEGY",
+		fmt.Sprintf("Unknown %s strategy %q", strategyType, name))
+}
+
+func (s *TenantStrategyService) validateReconciliationStrategy(name string) error {
+	if name == "" {
+		return nil
+	}
+	strategyType := finance.ReconciliationStrategyType(name)
+	if strategyType == finance.ReconciliationStrategyTypeManual {
+		return shared.NewDomainError("UNKNOWN_STRATEGY", "Manual reconciliation cannot be the default reconciliation strategy")
+	}
+	if !s.reconciliation.IsStrategyAvailable(strategyType) {
+		return shared.NewDomainError("UNKNOWN_STRATEGY",
+			fmt.Sprintf("Unknown reconciliation strategy %q", name))
+	}
+	return nil
+}
+
+// toDTO converts a tenant strategy configuration, nil if the tenant has none, to a DTO
+func (s *TenantStrategyService) toDTO(tenantID uuid.UUID, config *strategy.TenantStrategyConfig) *TenantStrategyConfigDTO {
+	result := &TenantStrategyConfigDTO{
+		TenantID:   tenantID,
+		Cost:       s.choice(strategy.StrategyTypeCost, config.StrategyName(strategy.StrategyTypeCost)),
+		Pricing:    s.choice(strategy.StrategyTypePricing, config.StrategyName(strategy.StrategyTypePricing)),
+		Allocation: s.choice(strategy.StrategyTypeAllocation, config.StrategyName(strategy.StrategyTypeAllocation)),
+		Reconciliation: TenantStrategyChoiceDTO{
+			Effective: s.reconciliation.GetDefaultStrategy().String(),
+		},
+	}
+	if config == nil {
+		return result
+	}
+
+	result.Reconciliation.Configured = config.ReconciliationStrategy
+	if strategyType := finance.ReconciliationStrategyType(config.ReconciliationStrategy); s.reconciliation.IsStrategyAvailable(strategyType) {
+		result.Reconciliation.Effective = strategyType.String()
+	}
+	updatedAt := config.UpdatedAt
+	result.UpdatedAt = &updatedAt
+	return result
+}
+
+// choice describes the strategy of a type a tenant chose, falling back to the default as the registry does
+func (s *TenantStrategyService) choice(strategyType strategy.StrategyType, configured string) TenantStrategyChoiceDTO {
+	choice := TenantStrategyChoiceDTO{
+		Configured: configured,
+		Effective:  s.catalog.GetDefault(strategyType),
+	}
+	if configured != "" && s.catalog.IsRegistered(strategyType, configured) {
+		choice.Effective = configured
+	}
+	return choice
+}
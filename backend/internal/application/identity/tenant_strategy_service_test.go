@@ -0,0 +1,148 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTenantStrategyConfigRepository keeps tenant strategy configurations in memory
+type stubTenantStrategyConfigRepository struct {
+	configs map[uuid.UUID]strategy.TenantStrategyConfig
+}
+
+func (r *stubTenantStrategyConfigRepository) FindByTenantID(_ context.Context, tenantID uuid.UUID) (*strategy.TenantStrategyConfig, error) {
+	config, ok := r.configs[tenantID]
+	if !ok {
+		return nil, shared.ErrNotFound
+	}
+	return &config, nil
+}
+
+func (r *stubTenantStrategyConfigRepository) Save(_ context.Context, config *strategy.TenantStrategyConfig) error {
+	r.configs[config.TenantID] = *config
+	return nil
+}
+
+// stubStrategyCatalog registers a fixed set of strategies per type, the first being the default
+type stubStrategyCatalog map[strategy.StrategyType][]string
+
+func (c stubStrategyCatalog) IsRegistered(strategyType strategy.StrategyType, name string) bool {
+	for _, registered := range c[strategyType] {
+		if registered == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c stubStrategyCatalog) GetDefault(strategyType strategy.StrategyType) string {
+	if len(c[strategyType]) == 0 {
+		return ""
+	}
+	return c[strategyType][0]
+}
+
+func newTestTenantStrategyService() (*TenantStrategyService, *stubTenantStrategyConfigRepository) {
+	repo := &stubTenantStrategyConfigRepository{configs: map[uuid.UUID]strategy.TenantStrategyConfig{}}
+	catalog := stubStrategyCatalog{
+		strategy.StrategyTypeCost:       {"moving_average", "fifo", "moving_weighted_average"},
+		strategy.StrategyTypePricing:    {"standard", "tiered"},
+		strategy.StrategyTypeAllocation: {"fifo"},
+	}
+	return NewTenantStrategyService(repo, catalog, finance.NewReconciliationService()), repo
+}
+
+func TestTenantStrategyService_Get(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestTenantStrategyService()
+
+	t.Run("tenant without configuration uses the defaults", func(t *testing.T) {
+		tenantID := uuid.New()
+		config, err := service.Get(ctx, tenantID)
+		require.NoError(t, err)
+
+		assert.Equal(t, tenantID, config.TenantID)
+		assert.Equal(t, TenantStrategyChoiceDTO{Effective: "moving_average"}, config.Cost)
+		assert.Equal(t, TenantStrategyChoiceDTO{Effective: "standard"}, config.Pricing)
+		assert.Equal(t, TenantStrategyChoiceDTO{Effective: "fifo"}, config.Allocation)
+		assert.Equal(t, TenantStrategyChoiceDTO{Effective: "FIFO"}, config.Reconciliation)
+		assert.Nil(t, config.UpdatedAt)
+	})
+
+	t.Run("strategy that is no longer registered falls back to the default", func(t *testing.T) {
+		tenantID := uuid.New()
+		repo.configs[tenantID] = strategy.TenantStrategyConfig{TenantID: tenantID, CostStrategy: "removed_strategy"}
+
+		config, err := service.Get(ctx, tenantID)
+		require.NoError(t, err)
+		assert.Equal(t, TenantStrategyChoiceDTO{Configured: "removed_strategy", Effective: "moving_average"}, config.Cost)
+	})
+}
+
+func TestTenantStrategyService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("tenants keep their own strategies", func(t *testing.T) {
+		service, _ := newTestTenantStrategyService()
+		tenantA, tenantB := uuid.New(), uuid.New()
+
+		_, err := service.Update(ctx, tenantA, UpdateTenantStrategyInput{CostStrategy: "fifo", ReconciliationStrategy: "lifo"})
+		require.NoError(t, err)
+		_, err = service.Update(ctx, tenantB, UpdateTenantStrategyInput{CostStrategy: "moving_weighted_average", PricingStrategy: "tiered"})
+		require.NoError(t, err)
+
+		configA, err := service.Get(ctx, tenantA)
+		require.NoError(t, err)
+		configB, err := service.Get(ctx, tenantB)
+		require.NoError(t, err)
+
+		assert.Equal(t, "fifo", configA.Cost.Effective)
+		assert.Equal(t, "LIFO", configA.Reconciliation.Effective)
+		assert.Equal(t, "standard", configA.Pricing.Effective)
+		assert.Equal(t, "moving_weighted_average", configB.Cost.Effective)
+		assert.Equal(t, "FIFO", configB.Reconciliation.Effective)
+		assert.Equal(t, "tiered", configB.Pricing.Effective)
+		assert.NotNil(t, configA.UpdatedAt)
+	})
+
+	t.Run("empty strategy restores the default", func(t *testing.T) {
+		service, repo := newTestTenantStrategyService()
+		tenantID := uuid.New()
+		_, err := service.Update(ctx, tenantID, UpdateTenantStrategyInput{CostStrategy: "fifo"})
+		require.NoError(t, err)
+
+		config, err := service.Update(ctx, tenantID, UpdateTenantStrategyInput{})
+		require.NoError(t, err)
+		assert.Equal(t, TenantStrategyChoiceDTO{Effective: "moving_average"}, config.Cost)
+		assert.Empty(t, repo.configs[tenantID].CostStrategy)
+	})
+
+	tests := []struct {
+		name  string
+		input UpdateTenantStrategyInput
+	}{
+		{name: "unknown cost strategy", input: UpdateTenantStrategyInput{CostStrategy: "average"}},
+		{name: "unknown pricing strategy", input: UpdateTenantStrategyInput{PricingStrategy: "fifo"}},
+		{name: "unknown allocation strategy", input: UpdateTenantStrategyInput{AllocationStrategy: "lifo"}},
+		{name: "unknown reconciliation strategy", input: UpdateTenantStrategyInput{ReconciliationStrategy: "OLDEST"}},
+		{name: "manual reconciliation", input: UpdateTenantStrategyInput{ReconciliationStrategy: "MANUAL"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestTenantStrategyService()
+			_, err := service.Update(ctx, uuid.New(), tt.input)
+
+			var domainErr *shared.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, "UNKNOWN_STRATEGY", domainErr.Code)
+			assert.Empty(t, repo.configs)
+		})
+	}
+}
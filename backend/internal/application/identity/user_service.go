@@ -12,9 +12,10 @@ import (
 
 // UserService handles user management operations
 type UserService struct {
-	userRepo identity.UserRepository
-	roleRepo identity.RoleRepository
-	logger   *zap.Logger
+	userRepo       identity.UserRepository
+	roleRepo       identity.RoleRepository
+	passwordPolicy *PasswordPolicyService
+	logger         *zap.Logger
 }
 
 // NewUserService creates a new user service
@@ -30,6 +31,12 @@ func NewUserService(
 	}
 }
 
+// SetPasswordPolicyService sets the password policy service for the service
+// This enforces tenant password policies on admin password resets
+func (s *UserService) SetPasswordPolicyService(passwordPolicy *PasswordPolicyService) {
+	s.passwordPolicy = passwordPolicy
+}
+
 // CreateUserInput contains input for creating a user
 type CreateUserInput struct {
 	TenantID    uuid.UUID
@@ -448,7 +455,13 @@ func (s *UserService) ResetPassword(ctx context.Context, userID uuid.UUID, newPa
 		return shared.NewDomainError("INTERNAL_ERROR", "Failed to find user")
 	}
 
-	if err := user.SetPassword(newPassword); err != nil {
+	previousHash := user.PasswordHash
+	if s.passwordPolicy != nil {
+		err = s.passwordPolicy.SetPassword(ctx, user, newPassword)
+	} else {
+		err = user.SetPassword(newPassword)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -460,6 +473,10 @@ func (s *UserService) ResetPassword(ctx context.Context, userID uuid.UUID, newPa
 		return shared.NewDomainError("INTERNAL_ERROR", "Failed to reset password")
 	}
 
+	if s.passwordPolicy != nil {
+		s.passwordPolicy.RecordPreviousPassword(ctx, user, previousHash)
+	}
+
 	s.logger.Info("User password reset", zap.String("user_id", userID.String()))
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/erp/backend/internal/domain/partner"
 	"github.com/erp/backend/internal/domain/shared"
@@ -36,6 +37,14 @@ func (m *MockCustomerRepository) FindByIDForTenant(ctx context.Context, tenantID
 	return args.Get(0).(*partner.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Customer, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*partner.Customer), args.Error(1)
+}
+
 func (m *MockCustomerRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Customer, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -105,6 +114,14 @@ func (m *MockCustomerRepository) FindWithPositiveBalance(ctx context.Context, te
 	return args.Get(0).([]partner.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepository) FindLevelPeriodEnded(ctx context.Context, asOf time.Time) ([]partner.Customer, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]partner.Customer), args.Error(1)
+}
+
 func (m *MockCustomerRepository) Save(ctx context.Context, customer *partner.Customer) error {
 	args := m.Called(ctx, customer)
 	return args.Error(0)
@@ -130,6 +147,11 @@ func (m *MockCustomerRepository) DeleteForTenant(ctx context.Context, tenantID,
 	return args.Error(0)
 }
 
+func (m *MockCustomerRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 func (m *MockCustomerRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
@@ -203,6 +203,22 @@ func (m *MockInventoryTransactionRepository) CountByInventoryItem(ctx context.Co
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockInventoryTransactionRepository) FindByWarehouseAsOf(ctx context.Context, tenantID, warehouseID uuid.UUID, asOf time.Time) ([]inventory.InventoryTransaction, error) {
+	args := m.Called(ctx, tenantID, warehouseID, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.InventoryTransaction), args.Error(1)
+}
+
+func (m *MockInventoryTransactionRepository) FindLedgerByInventoryItem(ctx context.Context, tenantID, inventoryItemID uuid.UUID) ([]inventory.InventoryTransaction, error) {
+	args := m.Called(ctx, tenantID, inventoryItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.InventoryTransaction), args.Error(1)
+}
+
 func (m *MockInventoryTransactionRepository) SumQuantityByTypeAndDateRange(ctx context.Context, tenantID uuid.UUID, txType inventory.TransactionType, start, end time.Time) (decimal.Decimal, error) {
 	args := m.Called(ctx, tenantID, txType, start, end)
 	return args.Get(0).(decimal.Decimal), args.Error(1)
@@ -361,6 +377,14 @@ func (m *MockInventoryProductRepository) FindByIDForTenant(ctx context.Context,
 	return args.Get(0).(*catalog.Product), args.Error(1)
 }
 
+func (m *MockInventoryProductRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*catalog.Product), args.Error(1)
+}
+
 func (m *MockInventoryProductRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Product, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -382,6 +406,11 @@ func (m *MockInventoryProductRepository) FindByIDs(ctx context.Context, tenantID
 	return args.Get(0).([]catalog.Product), args.Error(1)
 }
 
+func (m *MockInventoryProductRepository) FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]catalog.Product, error) {
+	args := m.Called(ctx, tenantID, barcodes)
+	return args.Get(0).([]catalog.Product), args.Error(1)
+}
+
 func (m *MockInventoryProductRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]catalog.Product, error) {
 	args := m.Called(ctx, tenantID, codes)
 	return args.Get(0).([]catalog.Product), args.Error(1)
@@ -452,6 +481,11 @@ func (m *MockInventoryProductRepository) Save(ctx context.Context, product *cata
 	return args.Error(0)
 }
 
+func (m *MockInventoryProductRepository) SaveWithLock(ctx context.Context, product *catalog.Product, expectedVersion int) error {
+	args := m.Called(ctx, product, expectedVersion)
+	return args.Error(0)
+}
+
 func (m *MockInventoryProductRepository) SaveBatch(ctx context.Context, products []*catalog.Product) error {
 	args := m.Called(ctx, products)
 	return args.Error(0)
@@ -467,6 +501,11 @@ func (m *MockInventoryProductRepository) DeleteForTenant(ctx context.Context, te
 	return args.Error(0)
 }
 
+func (m *MockInventoryProductRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 // Test helpers for inventory import
 func newInventoryValidatedSession(tenantID, userID uuid.UUID) *csvimport.ImportSession {
 	session := csvimport.NewImportSession(tenantID, userID, csvimport.EntityInventory, "inventory.csv", 1024)
@@ -38,6 +38,14 @@ func (m *MockProductRepository) FindByIDForTenant(ctx context.Context, tenantID,
 	return args.Get(0).(*catalog.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*catalog.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Product, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -59,6 +67,11 @@ func (m *MockProductRepository) FindByIDs(ctx context.Context, tenantID uuid.UUI
 	return args.Get(0).([]catalog.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]catalog.Product, error) {
+	args := m.Called(ctx, tenantID, barcodes)
+	return args.Get(0).([]catalog.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]catalog.Product, error) {
 	args := m.Called(ctx, tenantID, codes)
 	return args.Get(0).([]catalog.Product), args.Error(1)
@@ -129,6 +142,11 @@ func (m *MockProductRepository) Save(ctx context.Context, product *catalog.Produ
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) SaveWithLock(ctx context.Context, product *catalog.Product, expectedVersion int) error {
+	args := m.Called(ctx, product, expectedVersion)
+	return args.Error(0)
+}
+
 func (m *MockProductRepository) SaveBatch(ctx context.Context, products []*catalog.Product) error {
 	args := m.Called(ctx, products)
 	return args.Error(0)
@@ -144,6 +162,11 @@ func (m *MockProductRepository) DeleteForTenant(ctx context.Context, tenantID, i
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 // MockCategoryRepository is a mock implementation of catalog.CategoryRepository
 type MockCategoryRepository struct {
 	mock.Mock
@@ -246,8 +269,8 @@ func (m *MockCategoryRepository) ExistsByCode(ctx context.Context, tenantID uuid
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, newPath string, levelDelta int) error {
-	args := m.Called(ctx, tenantID, categoryID, newPath, levelDelta)
+func (m *MockCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, parentID *uuid.UUID, newPath string, levelDelta int) error {
+	args := m.Called(ctx, tenantID, categoryID, parentID, newPath, levelDelta)
 	return args.Error(0)
 }
 
@@ -36,6 +36,14 @@ func (m *MockSupplierRepository) FindByIDForTenant(ctx context.Context, tenantID
 	return args.Get(0).(*partner.Supplier), args.Error(1)
 }
 
+func (m *MockSupplierRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Supplier, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*partner.Supplier), args.Error(1)
+}
+
 func (m *MockSupplierRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Supplier, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -125,6 +133,11 @@ func (m *MockSupplierRepository) DeleteForTenant(ctx context.Context, tenantID,
 	return args.Error(0)
 }
 
+func (m *MockSupplierRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 func (m *MockSupplierRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
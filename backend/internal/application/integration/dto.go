@@ -211,3 +211,93 @@ func (f ProductMappingListFilter) ToDomainFilter() integration.ProductMappingFil
 
 	return filter
 }
+
+// ---------------------------------------------------------------------------
+// Order Import DTOs
+// ---------------------------------------------------------------------------
+
+// OrderImportResult summarizes a platform order import run
+type OrderImportResult struct {
+	PlatformCode  integration.PlatformCode `json:"platform_code"`
+	WindowStart   time.Time                `json:"window_start"`
+	WindowEnd     time.Time                `json:"window_end"`
+	PulledCount   int                      `json:"pulled_count"`
+	ImportedCount int                      `json:"imported_count"`
+	SkippedCount  int                      `json:"skipped_count"`
+	FailedCount   int                      `json:"failed_count"`
+	Imported      []ImportedOrder          `json:"imported"`
+	Failures      []OrderImportFailure     `json:"failures"`
+}
+
+// ImportedOrder is a platform order imported as a draft sales order
+type ImportedOrder struct {
+	PlatformOrderID  string    `json:"platform_order_id"`
+	SalesOrderID     uuid.UUID `json:"sales_order_id"`
+	SalesOrderNumber string    `json:"sales_order_number"`
+}
+
+// OrderImportFailure is a platform order that could not be imported
+type OrderImportFailure struct {
+	PlatformOrderID string   `json:"platform_order_id"`
+	Error           string   `json:"error"`
+	UnmappedSkus    []string `json:"unmapped_skus,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Mapping Suggestion DTOs
+// ---------------------------------------------------------------------------
+
+// MappingMatchReason explains why a local product was suggested for a platform product
+type MappingMatchReason string
+
+const (
+	// MappingMatchReasonSKU means the platform product or SKU code equals the local product code
+	MappingMatchReasonSKU MappingMatchReason = "SKU_MATCH"
+	// MappingMatchReasonBarcode means the platform product or SKU barcode equals the local barcode
+	MappingMatchReasonBarcode MappingMatchReason = "BARCODE_MATCH"
+	// MappingMatchReasonName means the product names are similar
+	MappingMatchReasonName MappingMatchReason = "NAME_SIMILARITY"
+)
+
+// ProductMappingSuggestion proposes a local product for an unmapped platform product
+type ProductMappingSuggestion struct {
+	PlatformProductID   string             `json:"platform_product_id"`
+	PlatformProductName string             `json:"platform_product_name"`
+	PlatformProductCode string             `json:"platform_product_code,omitempty"`
+	PlatformSkuIDs      []string           `json:"platform_sku_ids"`
+	LocalProductID      uuid.UUID          `json:"local_product_id"`
+	LocalProductCode    string             `json:"local_product_code"`
+	LocalProductName    string             `json:"local_product_name"`
+	Confidence          float64            `json:"confidence"`
+	MatchReason         MappingMatchReason `json:"match_reason"`
+	MatchedValue        string             `json:"matched_value"`
+}
+
+// MappingSuggestionResult lists the suggested mappings for a platform, highest confidence first
+type MappingSuggestionResult struct {
+	PlatformCode       integration.PlatformCode   `json:"platform_code"`
+	ScannedCount       int                        `json:"scanned_count"`
+	AlreadyMappedCount int                        `json:"already_mapped_count"`
+	UnmatchedCount     int                        `json:"unmatched_count"`
+	Suggestions        []ProductMappingSuggestion `json:"suggestions"`
+}
+
+// ConfirmProductMappingInput is an accepted suggestion to persist as a mapping
+type ConfirmProductMappingInput struct {
+	PlatformProductID   string    `json:"platform_product_id" binding:"required"`
+	LocalProductID      uuid.UUID `json:"local_product_id" binding:"required"`
+	PlatformProductName string    `json:"platform_product_name,omitempty"`
+	PlatformSkuIDs      []string  `json:"platform_sku_ids,omitempty"`
+}
+
+// ConfirmProductMappingsResult lists the mappings created from accepted suggestions
+type ConfirmProductMappingsResult struct {
+	Created  []ProductMappingResponse       `json:"created"`
+	Failures []ConfirmProductMappingFailure `json:"failures"`
+}
+
+// ConfirmProductMappingFailure is an accepted suggestion that could not be persisted
+type ConfirmProductMappingFailure struct {
+	PlatformProductID string `json:"platform_product_id"`
+	Error             string `json:"error"`
+}
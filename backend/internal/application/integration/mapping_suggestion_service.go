@@ -0,0 +1,340 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// Confidence scores per match reason. Name matches are scaled by similarity
+// and always rank below code and barcode matches.
+const (
+	skuMatchConfidence     = 1.0
+	barcodeMatchConfidence = 0.9
+	nameMatchMaxConfidence = 0.6
+	// minNameSimilarity is the lowest name similarity that is still suggested
+	minNameSimilarity = 0.5
+)
+
+// Page sizes used to scan platform and local products
+const (
+	platformProductPageSize = 100
+	localProductPageSize    = 500
+)
+
+// ActiveProductFinder lists the active local products that platform products can map to
+type ActiveProductFinder interface {
+	FindActive(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]catalog.Product, error)
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error)
+}
+
+// MappingSuggestionService proposes product mappings between platform products
+// and local products, and persists the ones a user accepts
+type MappingSuggestionService struct {
+	platforms      integration.EcommercePlatformRegistry
+	mappingRepo    integration.ProductMappingRepository
+	mappingService *ProductMappingServiceImpl
+	products       ActiveProductFinder
+}
+
+// NewMappingSuggestionService creates a new MappingSuggestionService
+func NewMappingSuggestionService(
+	platforms integration.EcommercePlatformRegistry,
+	mappingRepo integration.ProductMappingRepository,
+	products ActiveProductFinder,
+) *MappingSuggestionService {
+	return &MappingSuggestionService{
+		platforms:      platforms,
+		mappingRepo:    mappingRepo,
+		mappingService: NewProductMappingService(mappingRepo),
+		products:       products,
+	}
+}
+
+// SuggestProductMappings fetches the platform's products and proposes a local product
+// for each one that is not mapped yet: first by exact SKU (product code) match, then by
+// barcode, then by name similarity. Local products already mapped on the platform are
+// not proposed again. Nothing is persisted; see ConfirmProductMappings.
+func (s *MappingSuggestionService) SuggestProductMappings(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*MappingSuggestionResult, error) {
+	platform, err := s.platforms.GetPlatform(platformCode)
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := platform.IsEnabled(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, integration.ErrPlatformNotEnabled
+	}
+
+	// Inactive mappings still reserve both products, so they are loaded too
+	existing, err := s.mappingRepo.FindAll(ctx, tenantID, integration.ProductMappingFilter{PlatformCode: &platformCode})
+	if err != nil {
+		return nil, err
+	}
+	mappedPlatformProducts := make(map[string]bool, len(existing))
+	mappedLocalProducts := make(map[uuid.UUID]bool, len(existing))
+	for _, mapping := range existing {
+		mappedPlatformProducts[mapping.PlatformProductID] = true
+		mappedLocalProducts[mapping.LocalProductID] = true
+	}
+
+	index, err := s.loadLocalProducts(ctx, tenantID, mappedLocalProducts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MappingSuggestionResult{
+		PlatformCode: platformCode,
+		Suggestions:  []ProductMappingSuggestion{},
+	}
+
+	pageNo := 1
+	for {
+		resp, err := platform.ListProducts(ctx, &integration.ProductListRequest{
+			TenantID:     tenantID,
+			PlatformCode: platformCode,
+			PageNo:       pageNo,
+			PageSize:     platformProductPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list %s products: %w", platformCode, err)
+		}
+
+		for i := range resp.Products {
+			platformProduct := &resp.Products[i]
+			result.ScannedCount++
+			if mappedPlatformProducts[platformProduct.PlatformProductID] {
+				result.AlreadyMappedCount++
+				continue
+			}
+			suggestion, ok := index.match(platformProduct)
+			if !ok {
+				result.UnmatchedCount++
+				continue
+			}
+			result.Suggestions = append(result.Suggestions, suggestion)
+		}
+
+		if !resp.HasMore {
+			break
+		}
+		pageNo = resp.NextPageNo
+		if pageNo <= 0 {
+			pageNo++
+		}
+	}
+
+	sort.SliceStable(result.Suggestions, func(i, j int) bool {
+		return result.Suggestions[i].Confidence > result.Suggestions[j].Confidence
+	})
+	return result, nil
+}
+
+// ConfirmProductMappings creates the mappings a user accepted from the suggestions.
+// Each mapping is created independently; failures are reported per platform product.
+func (s *MappingSuggestionService) ConfirmProductMappings(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	platformCode integration.PlatformCode,
+	inputs []ConfirmProductMappingInput,
+) (*ConfirmProductMappingsResult, error) {
+	if !platformCode.IsValid() {
+		return nil, integration.ErrMappingInvalidPlatformCode
+	}
+
+	result := &ConfirmProductMappingsResult{
+		Created:  []ProductMappingResponse{},
+		Failures: []ConfirmProductMappingFailure{},
+	}
+	for _, input := range inputs {
+		mapping, err := s.confirmMapping(ctx, tenantID, platformCode, input)
+		if err != nil {
+			result.Failures = append(result.Failures, ConfirmProductMappingFailure{
+				PlatformProductID: input.PlatformProductID,
+				Error:             err.Error(),
+			})
+			continue
+		}
+		result.Created = append(result.Created, ToProductMappingResponse(mapping))
+	}
+	return result, nil
+}
+
+func (s *MappingSuggestionService) confirmMapping(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	platformCode integration.PlatformCode,
+	input ConfirmProductMappingInput,
+) (*integration.ProductMapping, error) {
+	if _, err := s.products.FindByIDForTenant(ctx, tenantID, input.LocalProductID); err != nil {
+		return nil, err
+	}
+
+	mapping, err := s.mappingService.CreateMapping(ctx, tenantID, input.LocalProductID, platformCode, input.PlatformProductID)
+	if err != nil {
+		return nil, err
+	}
+	if input.PlatformProductName == "" && len(input.PlatformSkuIDs) == 0 {
+		return mapping, nil
+	}
+
+	mapping.PlatformProductName = input.PlatformProductName
+	// Local products have no variants, so every platform SKU maps to the product itself
+	for _, skuID := range input.PlatformSkuIDs {
+		if err := mapping.AddSKUMapping(input.LocalProductID, skuID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.mappingService.UpdateMapping(ctx, mapping); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// loadLocalProducts indexes the tenant's active local products that are not mapped yet
+func (s *MappingSuggestionService) loadLocalProducts(ctx context.Context, tenantID uuid.UUID, exclude map[uuid.UUID]bool) (*localProductIndex, error) {
+	index := &localProductIndex{
+		byCode:    make(map[string]*catalog.Product),
+		byBarcode: make(map[string]*catalog.Product),
+	}
+	for page := 1; ; page++ {
+		products, err := s.products.FindActive(ctx, tenantID, shared.Filter{
+			Page:     page,
+			PageSize: localProductPageSize,
+			OrderBy:  "code",
+			OrderDir: "asc",
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range products {
+			if exclude[products[i].ID] {
+				continue
+			}
+			index.add(&products[i])
+		}
+		if len(products) < localProductPageSize {
+			return index, nil
+		}
+	}
+}
+
+// localProductIndex looks local products up by code, barcode and name
+type localProductIndex struct {
+	byCode    map[string]*catalog.Product
+	byBarcode map[string]*catalog.Product
+	all       []*catalog.Product
+}
+
+func (x *localProductIndex) add(product *catalog.Product) {
+	x.byCode[strings.ToUpper(product.Code)] = product
+	if product.Barcode != "" {
+		x.byBarcode[product.Barcode] = product
+	}
+	x.all = append(x.all, product)
+}
+
+// match finds the best local product for a platform product
+func (x *localProductIndex) match(p *integration.ProductSync) (ProductMappingSuggestion, bool) {
+	skuIDs := make([]string, 0, len(p.SKUs))
+	codes := []string{p.ProductCode}
+	barcodes := []string{p.Barcode}
+	for _, sku := range p.SKUs {
+		if sku.PlatformSkuID != "" {
+			skuIDs = append(skuIDs, sku.PlatformSkuID)
+		}
+		codes = append(codes, sku.SkuCode)
+		barcodes = append(barcodes, sku.Barcode)
+	}
+
+	suggestion := ProductMappingSuggestion{
+		PlatformProductID:   p.PlatformProductID,
+		PlatformProductName: p.ProductName,
+		PlatformProductCode: p.ProductCode,
+		PlatformSkuIDs:      skuIDs,
+	}
+
+	for _, code := range codes {
+		if product, ok := x.byCode[strings.ToUpper(strings.TrimSpace(code))]; ok && code != "" {
+			suggestion.setLocalProduct(product, MappingMatchReasonSKU, skuMatchConfidence, code)
+			return suggestion, true
+		}
+	}
+	for _, barcode := range barcodes {
+		if product, ok := x.byBarcode[strings.TrimSpace(barcode)]; ok && barcode != "" {
+			suggestion.setLocalProduct(product, MappingMatchReasonBarcode, barcodeMatchConfidence, barcode)
+			return suggestion, true
+		}
+	}
+
+	var best *catalog.Product
+	bestSimilarity := 0.0
+	for _, product := range x.all {
+		if similarity := nameSimilarity(p.ProductName, product.Name); similarity > bestSimilarity {
+			best, bestSimilarity = product, similarity
+		}
+	}
+	if best == nil || bestSimilarity < minNameSimilarity {
+		return suggestion, false
+	}
+	suggestion.setLocalProduct(best, MappingMatchReasonName, nameMatchMaxConfidence*bestSimilarity, p.ProductName)
+	return suggestion, true
+}
+
+func (s *ProductMappingSuggestion) setLocalProduct(product *catalog.Product, reason MappingMatchReason, confidence float64, matchedValue string) {
+	s.LocalProductID = product.ID
+	s.LocalProductCode = product.Code
+	s.LocalProductName = product.Name
+	s.MatchReason = reason
+	s.Confidence = confidence
+	s.MatchedValue = matchedValue
+}
+
+// nameSimilarity returns the Dice coefficient of the character bigrams of two
+// product names, ignoring case, spaces and punctuation. It works for both
+// Chinese and Latin names: 1 means identical, 0 means nothing in common.
+func nameSimilarity(a, b string) float64 {
+	ra, rb := normalizeName(a), normalizeName(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	if string(ra) == string(rb) {
+		return 1
+	}
+	if len(ra) < 2 || len(rb) < 2 {
+		return 0
+	}
+
+	bigrams := make(map[string]int, len(ra)-1)
+	for i := 0; i < len(ra)-1; i++ {
+		bigrams[string(ra[i:i+2])]++
+	}
+	common := 0
+	for i := 0; i < len(rb)-1; i++ {
+		bigram := string(rb[i : i+2])
+		if bigrams[bigram] > 0 {
+			bigrams[bigram]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(ra)-1+len(rb)-1)
+}
+
+func normalizeName(name string) []rune {
+	runes := make([]rune, 0, len(name))
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
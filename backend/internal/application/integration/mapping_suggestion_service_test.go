@@ -0,0 +1,217 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/ecommerce"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCatalogPlatform serves a fixed product catalog, one product per page
+type fakeCatalogPlatform struct {
+	integration.EcommercePlatform
+	products []integration.ProductSync
+	enabled  bool
+}
+
+func (p *fakeCatalogPlatform) PlatformCode() integration.PlatformCode {
+	return integration.PlatformCodeTaobao
+}
+
+func (p *fakeCatalogPlatform) IsEnabled(ctx context.Context, tenantID uuid.UUID) (bool, error) {
+	return p.enabled, nil
+}
+
+func (p *fakeCatalogPlatform) ListProducts(ctx context.Context, req *integration.ProductListRequest) (*integration.ProductListResponse, error) {
+	resp := &integration.ProductListResponse{
+		Products:   []integration.ProductSync{},
+		TotalCount: int64(len(p.products)),
+	}
+	if req.PageNo <= len(p.products) {
+		resp.Products = append(resp.Products, p.products[req.PageNo-1])
+	}
+	resp.HasMore = req.PageNo < len(p.products)
+	if resp.HasMore {
+		resp.NextPageNo = req.PageNo + 1
+	}
+	return resp, nil
+}
+
+// staticActiveProductFinder serves a fixed set of active local products
+type staticActiveProductFinder struct {
+	products []catalog.Product
+}
+
+func (f *staticActiveProductFinder) FindActive(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]catalog.Product, error) {
+	if filter.Page > 1 {
+		return []catalog.Product{}, nil
+	}
+	return f.products, nil
+}
+
+func (f *staticActiveProductFinder) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	for i := range f.products {
+		if f.products[i].ID == id {
+			return &f.products[i], nil
+		}
+	}
+	return nil, fmt.Errorf("product %s not found", id)
+}
+
+type mappingSuggestionFixture struct {
+	service  *MappingSuggestionService
+	platform *fakeCatalogPlatform
+	mappings *MockProductMappingRepository
+	tee      *catalog.Product
+	mug      *catalog.Product
+	hoodie   *catalog.Product
+	tenantID uuid.UUID
+}
+
+func newMappingSuggestionFixture(t *testing.T) *mappingSuggestionFixture {
+	t.Helper()
+
+	tenantID := uuid.New()
+	tee, err := catalog.NewProduct(tenantID, "TEE-RED", "Red T-Shirt", "pcs")
+	require.NoError(t, err)
+	mug, err := catalog.NewProduct(tenantID, "MUG-001", "Ceramic Mug", "pcs")
+	require.NoError(t, err)
+	require.NoError(t, mug.SetBarcode("6901234567892"))
+	hoodie, err := catalog.NewProduct(tenantID, "HD-GRY", "Grey Zip Hoodie", "pcs")
+	require.NoError(t, err)
+
+	f := &mappingSuggestionFixture{
+		platform: &fakeCatalogPlatform{enabled: true},
+		mappings: new(MockProductMappingRepository),
+		tee:      tee,
+		mug:      mug,
+		hoodie:   hoodie,
+		tenantID: tenantID,
+	}
+	f.service = NewMappingSuggestionService(
+		ecommerce.NewPlatformRegistry(f.platform),
+		f.mappings,
+		&staticActiveProductFinder{products: []catalog.Product{*tee, *mug, *hoodie}},
+	)
+	return f
+}
+
+func TestMappingSuggestionService_SuggestProductMappings(t *testing.T) {
+	t.Run("ranks exact SKU matches above name-only matches", func(t *testing.T) {
+		f := newMappingSuggestionFixture(t)
+		f.platform.products = []integration.ProductSync{
+			{PlatformProductID: "P-300", ProductName: "Grey Zip Hoodie Unisex"},
+			{
+				PlatformProductID: "P-100",
+				ProductName:       "Cotton tee",
+				SKUs:              []integration.ProductSkuSync{{PlatformSkuID: "S-1", SkuCode: "tee-red"}},
+			},
+		}
+		f.mappings.On("FindAll", mock.Anything, f.tenantID, mock.Anything).Return([]integration.ProductMapping{}, nil)
+
+		result, err := f.service.SuggestProductMappings(context.Background(), f.tenantID, integration.PlatformCodeTaobao)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.ScannedCount)
+		require.Len(t, result.Suggestions, 2)
+
+		sku := result.Suggestions[0]
+		assert.Equal(t, "P-100", sku.PlatformProductID)
+		assert.Equal(t, f.tee.ID, sku.LocalProductID)
+		assert.Equal(t, MappingMatchReasonSKU, sku.MatchReason)
+		assert.Equal(t, 1.0, sku.Confidence)
+		assert.Equal(t, []string{"S-1"}, sku.PlatformSkuIDs)
+
+		name := result.Suggestions[1]
+		assert.Equal(t, "P-300", name.PlatformProductID)
+		assert.Equal(t, f.hoodie.ID, name.LocalProductID)
+		assert.Equal(t, MappingMatchReasonName, name.MatchReason)
+		assert.Greater(t, name.Confidence, 0.0)
+		assert.Less(t, name.Confidence, barcodeMatchConfidence)
+		assert.LessOrEqual(t, name.Confidence, nameMatchMaxConfidence)
+
+		f.mappings.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("matches by barcode when no code matches", func(t *testing.T) {
+		f := newMappingSuggestionFixture(t)
+		f.platform.products = []integration.ProductSync{{
+			PlatformProductID: "P-200",
+			ProductName:       "Coffee cup 350ml",
+			SKUs:              []integration.ProductSkuSync{{PlatformSkuID: "S-2", SkuCode: "CUP-350", Barcode: "6901234567892"}},
+		}}
+		f.mappings.On("FindAll", mock.Anything, f.tenantID, mock.Anything).Return([]integration.ProductMapping{}, nil)
+
+		result, err := f.service.SuggestProductMappings(context.Background(), f.tenantID, integration.PlatformCodeTaobao)
+
+		require.NoError(t, err)
+		require.Len(t, result.Suggestions, 1)
+		assert.Equal(t, f.mug.ID, result.Suggestions[0].LocalProductID)
+		assert.Equal(t, MappingMatchReasonBarcode, result.Suggestions[0].MatchReason)
+		assert.Equal(t, barcodeMatchConfidence, result.Suggestions[0].Confidence)
+	})
+
+	t.Run("skips mapped products and reports unmatched ones", func(t *testing.T) {
+		f := newMappingSuggestionFixture(t)
+		f.platform.products = []integration.ProductSync{
+			{PlatformProductID: "P-100", ProductName: "Red T-Shirt", ProductCode: "TEE-RED"},
+			{PlatformProductID: "P-400", ProductName: "Mystery Product", ProductCode: "MYSTERY-42"},
+			{PlatformProductID: "P-500", ProductName: "Red T-Shirt XL", ProductCode: "TEE-RED"},
+		}
+		existing, err := integration.NewProductMapping(f.tenantID, f.tee.ID, integration.PlatformCodeTaobao, "P-100")
+		require.NoError(t, err)
+		f.mappings.On("FindAll", mock.Anything, f.tenantID, mock.Anything).Return([]integration.ProductMapping{*existing}, nil)
+
+		result, err := f.service.SuggestProductMappings(context.Background(), f.tenantID, integration.PlatformCodeTaobao)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.ScannedCount)
+		assert.Equal(t, 1, result.AlreadyMappedCount)
+		// P-500 would match TEE-RED, but that local product is already mapped
+		assert.Equal(t, 2, result.UnmatchedCount)
+		assert.Empty(t, result.Suggestions)
+	})
+
+	t.Run("rejects disabled platforms", func(t *testing.T) {
+		f := newMappingSuggestionFixture(t)
+		f.platform.enabled = false
+
+		_, err := f.service.SuggestProductMappings(context.Background(), f.tenantID, integration.PlatformCodeTaobao)
+
+		assert.ErrorIs(t, err, integration.ErrPlatformNotEnabled)
+	})
+}
+
+func TestMappingSuggestionService_ConfirmProductMappings(t *testing.T) {
+	f := newMappingSuggestionFixture(t)
+	f.mappings.On("ExistsByLocalProductAndPlatform", mock.Anything, f.tenantID, f.tee.ID, integration.PlatformCodeTaobao).Return(false, nil)
+	f.mappings.On("ExistsByPlatformProduct", mock.Anything, f.tenantID, integration.PlatformCodeTaobao, "P-100").Return(false, nil)
+	f.mappings.On("ExistsByLocalProductAndPlatform", mock.Anything, f.tenantID, f.mug.ID, integration.PlatformCodeTaobao).Return(true, nil)
+	f.mappings.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := f.service.ConfirmProductMappings(context.Background(), f.tenantID, integration.PlatformCodeTaobao, []ConfirmProductMappingInput{
+		{PlatformProductID: "P-100", PlatformProductName: "Cotton tee", PlatformSkuIDs: []string{"S-1", "S-2"}, LocalProductID: f.tee.ID},
+		{PlatformProductID: "P-200", LocalProductID: f.mug.ID},
+		{PlatformProductID: "P-300", LocalProductID: uuid.New()},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Created, 1)
+	created := result.Created[0]
+	assert.Equal(t, "P-100", created.PlatformProductID)
+	assert.Equal(t, f.tee.ID, created.LocalProductID)
+	require.Len(t, created.SKUMappings, 2)
+	assert.Equal(t, f.tee.ID, created.SKUMappings[0].LocalSKUID)
+
+	require.Len(t, result.Failures, 2)
+	assert.Equal(t, "P-200", result.Failures[0].PlatformProductID)
+	assert.Equal(t, "P-300", result.Failures[1].PlatformProductID)
+}
@@ -0,0 +1,363 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tradeapp "github.com/erp/backend/internal/application/trade"
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// DefaultOrderImportLookback is how far back the first import for a platform reaches
+const DefaultOrderImportLookback = 7 * 24 * time.Hour
+
+// orderImportPageSize is the number of orders pulled per platform request
+const orderImportPageSize = 50
+
+// ImportProductReader provides read access to the local products that platform SKUs map to
+type ImportProductReader interface {
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error)
+}
+
+// SalesOrderCreator creates the draft sales orders that platform orders are imported as
+type SalesOrderCreator interface {
+	Create(ctx context.Context, tenantID uuid.UUID, req tradeapp.CreateSalesOrderRequest) (*tradeapp.SalesOrderResponse, error)
+}
+
+// OrderImportServiceConfig holds the dependencies of an OrderImportService
+type OrderImportServiceConfig struct {
+	Platforms   integration.EcommercePlatformRegistry
+	Mappings    integration.ProductMappingReader
+	SyncRecords integration.OrderSyncRecordRepository
+	SyncConfigs integration.OrderSyncConfigRepository
+	Products    ImportProductReader
+	SalesOrders SalesOrderCreator
+	Logger      *zap.Logger
+	// Lookback is how far back the first import reaches (default: DefaultOrderImportLookback)
+	Lookback time.Duration
+}
+
+// OrderImportService imports paid platform orders as draft sales orders
+type OrderImportService struct {
+	platforms   integration.EcommercePlatformRegistry
+	mappings    integration.ProductMappingReader
+	syncRecords integration.OrderSyncRecordRepository
+	syncConfigs integration.OrderSyncConfigRepository
+	products    ImportProductReader
+	salesOrders SalesOrderCreator
+	logger      *zap.Logger
+	lookback    time.Duration
+	now         func() time.Time
+}
+
+// NewOrderImportService creates a new OrderImportService
+func NewOrderImportService(cfg OrderImportServiceConfig) *OrderImportService {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	lookback := cfg.Lookback
+	if lookback <= 0 {
+		lookback = DefaultOrderImportLookback
+	}
+	return &OrderImportService{
+		platforms:   cfg.Platforms,
+		mappings:    cfg.Mappings,
+		syncRecords: cfg.SyncRecords,
+		syncConfigs: cfg.SyncConfigs,
+		products:    cfg.Products,
+		salesOrders: cfg.SalesOrders,
+		logger:      logger,
+		lookback:    lookback,
+		now:         time.Now,
+	}
+}
+
+// ImportPlatformOrders pulls the orders created on a platform since the last import
+// and creates a draft sales order for each paid one.
+//
+// Orders already imported are skipped, so re-runs never create duplicates. An order
+// whose SKUs are not all mapped to local products is reported in the result's
+// failures without aborting the batch. The sync cursor advances to the end of the
+// pulled window, or only up to the earliest failed order so that it is pulled and
+// retried on the next run; the cursor is left unchanged if a pull fails.
+func (s *OrderImportService) ImportPlatformOrders(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*OrderImportResult, error) {
+	platform, err := s.platforms.GetPlatform(platformCode)
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := platform.IsEnabled(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, integration.ErrPlatformNotEnabled
+	}
+
+	config, err := s.syncConfigs.FindByTenantAndPlatform(ctx, tenantID, platformCode)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil || config.DefaultCustomerID == uuid.Nil {
+		return nil, integration.ErrOrderSyncNotConfigured
+	}
+
+	windowEnd := s.now()
+	windowStart := windowEnd.Add(-s.lookback)
+	if config.OrderCursor != nil {
+		windowStart = *config.OrderCursor
+	}
+
+	result := &OrderImportResult{
+		PlatformCode: platformCode,
+		WindowStart:  windowStart,
+		WindowEnd:    windowEnd,
+		Imported:     []ImportedOrder{},
+		Failures:     []OrderImportFailure{},
+	}
+
+	cursor := windowEnd
+	pageNo := 1
+	for {
+		resp, err := platform.PullOrders(ctx, &integration.OrderPullRequest{
+			TenantID:     tenantID,
+			PlatformCode: platformCode,
+			StartTime:    windowStart,
+			EndTime:      windowEnd,
+			PageNo:       pageNo,
+			PageSize:     orderImportPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pull %s orders: %w", platformCode, err)
+		}
+
+		for i := range resp.Orders {
+			order := &resp.Orders[i]
+			if s.importOrder(ctx, tenantID, config, order, result) {
+				continue
+			}
+			retryFrom := windowStart
+			if order.CreatedAt.After(windowStart) {
+				retryFrom = order.CreatedAt
+			}
+			if retryFrom.Before(cursor) {
+				cursor = retryFrom
+			}
+		}
+
+		if !resp.HasMore {
+			break
+		}
+		pageNo = resp.NextPageNo
+		if pageNo <= 0 {
+			pageNo++
+		}
+	}
+
+	config.OrderCursor = &cursor
+	if err := s.syncConfigs.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("save %s order cursor: %w", platformCode, err)
+	}
+
+	s.logger.Info("Imported platform orders",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("platform", platformCode.String()),
+		zap.Int("pulled", result.PulledCount),
+		zap.Int("imported", result.ImportedCount),
+		zap.Int("skipped", result.SkippedCount),
+		zap.Int("failed", result.FailedCount))
+
+	return result, nil
+}
+
+// importOrder imports a single platform order, recording the outcome in result.
+// It returns false if the order failed and must be retried.
+func (s *OrderImportService) importOrder(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	config *integration.OrderSyncConfig,
+	order *integration.PlatformOrder,
+	result *OrderImportResult,
+) bool {
+	result.PulledCount++
+
+	// Only paid orders awaiting shipment become sales orders
+	if !order.Status.RequiresShipment() {
+		result.SkippedCount++
+		return true
+	}
+
+	record, err := s.syncRecords.FindByPlatformOrder(ctx, tenantID, config.PlatformCode, order.PlatformOrderID)
+	if err != nil {
+		s.recordFailure(result, order, err, nil)
+		return false
+	}
+	if record != nil && record.Status == integration.SyncStatusSuccess {
+		result.SkippedCount++
+		return true
+	}
+	if record == nil {
+		record = s.newSyncRecord(tenantID, config.PlatformCode, order.PlatformOrderID)
+	}
+	record.PlatformStatus = order.Status
+	record.SyncedAt = s.now()
+	record.UpdatedAt = record.SyncedAt
+
+	items, unmappedSkus, err := s.buildOrderItems(ctx, tenantID, config.PlatformCode, order)
+	if err == nil && len(unmappedSkus) > 0 {
+		err = fmt.Errorf("%w: %s", integration.ErrMappingNotFound, strings.Join(unmappedSkus, ", "))
+	}
+	if err != nil {
+		s.failSyncRecord(ctx, record, err)
+		s.recordFailure(result, order, err, unmappedSkus)
+		return false
+	}
+
+	req := tradeapp.CreateSalesOrderRequest{
+		CustomerID:   config.DefaultCustomerID,
+		CustomerName: config.DefaultCustomerName,
+		Items:        items,
+		Remark:       fmt.Sprintf("%s order %s", config.PlatformCode.DisplayName(), order.PlatformOrderID),
+	}
+	if config.DefaultWarehouseID != uuid.Nil {
+		warehouseID := config.DefaultWarehouseID
+		req.WarehouseID = &warehouseID
+	}
+
+	salesOrder, err := s.salesOrders.Create(ctx, tenantID, req)
+	if err != nil {
+		s.failSyncRecord(ctx, record, err)
+		s.recordFailure(result, order, err, nil)
+		return false
+	}
+
+	record.LocalOrderID = &salesOrder.ID
+	record.LocalOrderNumber = salesOrder.OrderNumber
+	record.Status = integration.SyncStatusSuccess
+	record.ErrorMessage = ""
+	if err := s.syncRecords.Save(ctx, record); err != nil {
+		// The sales order exists; log loudly so the record can be repaired rather than re-imported
+		s.logger.Error("Failed to save platform order sync record",
+			zap.String("platform_order_id", order.PlatformOrderID),
+			zap.String("sales_order_id", salesOrder.ID.String()),
+			zap.Error(err))
+	}
+
+	result.ImportedCount++
+	result.Imported = append(result.Imported, ImportedOrder{
+		PlatformOrderID:  order.PlatformOrderID,
+		SalesOrderID:     salesOrder.ID,
+		SalesOrderNumber: salesOrder.OrderNumber,
+	})
+	return true
+}
+
+// buildOrderItems maps the platform order lines to sales order items. It returns the
+// SKUs that have no product mapping; items are only usable when none are returned.
+func (s *OrderImportService) buildOrderItems(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	platformCode integration.PlatformCode,
+	order *integration.PlatformOrder,
+) ([]tradeapp.CreateSalesOrderItemInput, []string, error) {
+	items := make([]tradeapp.CreateSalesOrderItemInput, 0, len(order.Items))
+	var unmappedSkus []string
+
+	for _, item := range order.Items {
+		mapping, err := s.findMapping(ctx, tenantID, platformCode, item)
+		if errors.Is(err, integration.ErrMappingNotFound) {
+			unmappedSkus = append(unmappedSkus, platformItemKey(item))
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		product, err := s.products.FindByIDForTenant(ctx, tenantID, mapping.LocalProductID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load product for SKU %s: %w", platformItemKey(item), err)
+		}
+
+		input := tradeapp.CreateSalesOrderItemInput{
+			ProductID:      product.ID,
+			ProductName:    product.Name,
+			ProductCode:    product.Code,
+			Unit:           product.Unit,
+			BaseUnit:       product.Unit,
+			Quantity:       item.Quantity,
+			ConversionRate: decimal.NewFromInt(1),
+			UnitPrice:      item.UnitPrice,
+			Remark:         item.SkuName,
+		}
+		if item.DiscountAmount.IsPositive() {
+			input.DiscountType = "amount"
+			input.DiscountValue = item.DiscountAmount
+		}
+		items = append(items, input)
+	}
+
+	return items, unmappedSkus, nil
+}
+
+// findMapping looks an order line up by SKU, falling back to the platform product
+// for single-SKU products that have no SKU ID
+func (s *OrderImportService) findMapping(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	platformCode integration.PlatformCode,
+	item integration.PlatformOrderItem,
+) (*integration.ProductMapping, error) {
+	if item.PlatformSkuID != "" {
+		return s.mappings.FindByPlatformSku(ctx, tenantID, platformCode, item.PlatformSkuID)
+	}
+	return s.mappings.FindByPlatformProduct(ctx, tenantID, platformCode, item.PlatformProductID)
+}
+
+func (s *OrderImportService) newSyncRecord(tenantID uuid.UUID, platformCode integration.PlatformCode, platformOrderID string) *integration.PlatformOrderSyncRecord {
+	now := s.now()
+	return &integration.PlatformOrderSyncRecord{
+		ID:              uuid.New(),
+		TenantID:        tenantID,
+		PlatformCode:    platformCode,
+		PlatformOrderID: platformOrderID,
+		Direction:       integration.OrderSyncDirectionInbound,
+		Status:          integration.SyncStatusPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// failSyncRecord marks the sync record failed so the order is retried on the next run
+func (s *OrderImportService) failSyncRecord(ctx context.Context, record *integration.PlatformOrderSyncRecord, cause error) {
+	record.Status = integration.SyncStatusFailed
+	record.ErrorMessage = cause.Error()
+	if err := s.syncRecords.Save(ctx, record); err != nil {
+		s.logger.Warn("Failed to save platform order sync record",
+			zap.String("platform_order_id", record.PlatformOrderID),
+			zap.Error(err))
+	}
+}
+
+func (s *OrderImportService) recordFailure(result *OrderImportResult, order *integration.PlatformOrder, err error, unmappedSkus []string) {
+	result.FailedCount++
+	result.Failures = append(result.Failures, OrderImportFailure{
+		PlatformOrderID: order.PlatformOrderID,
+		Error:           err.Error(),
+		UnmappedSkus:    unmappedSkus,
+	})
+}
+
+// platformItemKey identifies an order line for mapping errors
+func platformItemKey(item integration.PlatformOrderItem) string {
+	if item.PlatformSkuID != "" {
+		return item.PlatformSkuID
+	}
+	return item.PlatformProductID
+}
@@ -0,0 +1,342 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	tradeapp "github.com/erp/backend/internal/application/trade"
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/infrastructure/ecommerce"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrderPlatform serves a fixed set of orders created within the pulled window
+type fakeOrderPlatform struct {
+	integration.EcommercePlatform
+	orders   []integration.PlatformOrder
+	enabled  bool
+	requests []integration.OrderPullRequest
+}
+
+func (p *fakeOrderPlatform) PlatformCode() integration.PlatformCode {
+	return integration.PlatformCodeTaobao
+}
+
+func (p *fakeOrderPlatform) IsEnabled(ctx context.Context, tenantID uuid.UUID) (bool, error) {
+	return p.enabled, nil
+}
+
+func (p *fakeOrderPlatform) PullOrders(ctx context.Context, req *integration.OrderPullRequest) (*integration.OrderPullResponse, error) {
+	p.requests = append(p.requests, *req)
+	resp := &integration.OrderPullResponse{Orders: []integration.PlatformOrder{}}
+	for _, order := range p.orders {
+		if !order.CreatedAt.Before(req.StartTime) && order.CreatedAt.Before(req.EndTime) {
+			resp.Orders = append(resp.Orders, order)
+		}
+	}
+	resp.TotalCount = int64(len(resp.Orders))
+	return resp, nil
+}
+
+// memorySyncRecordRepository keeps order sync records in memory
+type memorySyncRecordRepository struct {
+	integration.OrderSyncRecordRepository
+	records map[string]*integration.PlatformOrderSyncRecord
+}
+
+func (r *memorySyncRecordRepository) Save(ctx context.Context, record *integration.PlatformOrderSyncRecord) error {
+	saved := *record
+	r.records[record.PlatformOrderID] = &saved
+	return nil
+}
+
+func (r *memorySyncRecordRepository) FindByPlatformOrder(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, platformOrderID string) (*integration.PlatformOrderSyncRecord, error) {
+	record, ok := r.records[platformOrderID]
+	if !ok {
+		return nil, nil
+	}
+	found := *record
+	return &found, nil
+}
+
+func (r *memorySyncRecordRepository) FindByLocalOrder(ctx context.Context, tenantID uuid.UUID, localOrderID uuid.UUID) (*integration.PlatformOrderSyncRecord, error) {
+	for _, record := range r.records {
+		if record.LocalOrderID != nil && *record.LocalOrderID == localOrderID {
+			found := *record
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+// memorySyncConfigRepository holds a single order sync config
+type memorySyncConfigRepository struct {
+	integration.OrderSyncConfigRepository
+	config *integration.OrderSyncConfig
+}
+
+func (r *memorySyncConfigRepository) Save(ctx context.Context, config *integration.OrderSyncConfig) error {
+	r.config = config
+	return nil
+}
+
+func (r *memorySyncConfigRepository) FindByTenantAndPlatform(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*integration.OrderSyncConfig, error) {
+	if r.config == nil {
+		return nil, nil
+	}
+	config := *r.config
+	return &config, nil
+}
+
+// staticProductReader serves a fixed set of local products
+type staticProductReader struct {
+	products map[uuid.UUID]*catalog.Product
+}
+
+func (r *staticProductReader) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	product, ok := r.products[id]
+	if !ok {
+		return nil, fmt.Errorf("product %s not found", id)
+	}
+	return product, nil
+}
+
+// recordingSalesOrderCreator records the sales orders it is asked to create
+type recordingSalesOrderCreator struct {
+	requests []tradeapp.CreateSalesOrderRequest
+}
+
+func (c *recordingSalesOrderCreator) Create(ctx context.Context, tenantID uuid.UUID, req tradeapp.CreateSalesOrderRequest) (*tradeapp.SalesOrderResponse, error) {
+	c.requests = append(c.requests, req)
+	return &tradeapp.SalesOrderResponse{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		OrderNumber: fmt.Sprintf("SO-TEST-%03d", len(c.requests)),
+		Status:      "draft",
+	}, nil
+}
+
+type orderImportFixture struct {
+	service     *OrderImportService
+	platform    *fakeOrderPlatform
+	mappings    *MockProductMappingRepository
+	syncRecords *memorySyncRecordRepository
+	syncConfigs *memorySyncConfigRepository
+	salesOrders *recordingSalesOrderCreator
+	product     *catalog.Product
+	tenantID    uuid.UUID
+	customerID  uuid.UUID
+	now         time.Time
+}
+
+func newOrderImportFixture(t *testing.T) *orderImportFixture {
+	t.Helper()
+
+	tenantID := uuid.New()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	product, err := catalog.NewProduct(tenantID, "TEE-RED", "Red T-Shirt", "pcs")
+	require.NoError(t, err)
+
+	f := &orderImportFixture{
+		platform: &fakeOrderPlatform{
+			enabled: true,
+			orders: []integration.PlatformOrder{
+				{
+					PlatformOrderID: "TB1001",
+					PlatformCode:    integration.PlatformCodeTaobao,
+					Status:          integration.PlatformOrderStatusPaid,
+					CreatedAt:       now.Add(-2 * time.Hour),
+					Items: []integration.PlatformOrderItem{{
+						PlatformProductID: "P-100",
+						PlatformSkuID:     "SKU-RED-M",
+						ProductName:       "T-Shirt",
+						SkuName:           "Red / M",
+						Quantity:          decimal.NewFromInt(2),
+						UnitPrice:         decimal.NewFromInt(59),
+						DiscountAmount:    decimal.NewFromInt(10),
+					}},
+				},
+				{
+					PlatformOrderID: "TB1002",
+					PlatformCode:    integration.PlatformCodeTaobao,
+					Status:          integration.PlatformOrderStatusPaid,
+					CreatedAt:       now.Add(-time.Hour),
+					Items: []integration.PlatformOrderItem{
+						{PlatformProductID: "P-100", PlatformSkuID: "SKU-RED-M", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(59)},
+						{PlatformProductID: "P-200", PlatformSkuID: "SKU-BLUE-XL", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(79)},
+					},
+				},
+			},
+		},
+		mappings:    new(MockProductMappingRepository),
+		syncRecords: &memorySyncRecordRepository{records: make(map[string]*integration.PlatformOrderSyncRecord)},
+		salesOrders: &recordingSalesOrderCreator{},
+		product:     product,
+		tenantID:    tenantID,
+		customerID:  uuid.New(),
+		now:         now,
+	}
+	f.syncConfigs = &memorySyncConfigRepository{config: &integration.OrderSyncConfig{
+		TenantID:            tenantID,
+		PlatformCode:        integration.PlatformCodeTaobao,
+		IsEnabled:           true,
+		DefaultCustomerID:   f.customerID,
+		DefaultCustomerName: "Taobao Buyers",
+	}}
+
+	mapping, err := integration.NewProductMapping(tenantID, product.ID, integration.PlatformCodeTaobao, "P-100")
+	require.NoError(t, err)
+	require.NoError(t, mapping.AddSKUMapping(product.ID, "SKU-RED-M"))
+	f.mappings.On("FindByPlatformSku", mock.Anything, tenantID, integration.PlatformCodeTaobao, "SKU-RED-M").Return(mapping, nil)
+	f.mappings.On("FindByPlatformSku", mock.Anything, tenantID, integration.PlatformCodeTaobao, "SKU-BLUE-XL").Return(nil, integration.ErrMappingNotFound)
+
+	f.service = NewOrderImportService(OrderImportServiceConfig{
+		Platforms:   ecommerce.NewPlatformRegistry(f.platform),
+		Mappings:    f.mappings,
+		SyncRecords: f.syncRecords,
+		SyncConfigs: f.syncConfigs,
+		Products:    &staticProductReader{products: map[uuid.UUID]*catalog.Product{product.ID: product}},
+		SalesOrders: f.salesOrders,
+	})
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+func TestOrderImportService_ImportPlatformOrders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("imports mapped orders and reports unmapped SKUs per order", func(t *testing.T) {
+		f := newOrderImportFixture(t)
+
+		result, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, result.PulledCount)
+		assert.Equal(t, 1, result.ImportedCount)
+		assert.Equal(t, 1, result.FailedCount)
+		require.Len(t, result.Imported, 1)
+		assert.Equal(t, "TB1001", result.Imported[0].PlatformOrderID)
+		assert.Equal(t, "SO-TEST-001", result.Imported[0].SalesOrderNumber)
+		require.Len(t, result.Failures, 1)
+		assert.Equal(t, "TB1002", result.Failures[0].PlatformOrderID)
+		assert.Equal(t, []string{"SKU-BLUE-XL"}, result.Failures[0].UnmappedSkus)
+
+		require.Len(t, f.salesOrders.requests, 1)
+		req := f.salesOrders.requests[0]
+		assert.Equal(t, f.customerID, req.CustomerID)
+		assert.Nil(t, req.WarehouseID)
+		assert.Contains(t, req.Remark, "TB1001")
+		require.Len(t, req.Items, 1)
+		assert.Equal(t, f.product.ID, req.Items[0].ProductID)
+		assert.Equal(t, "TEE-RED", req.Items[0].ProductCode)
+		assert.True(t, decimal.NewFromInt(2).Equal(req.Items[0].Quantity))
+		assert.True(t, decimal.NewFromInt(59).Equal(req.Items[0].UnitPrice))
+		assert.Equal(t, "amount", req.Items[0].DiscountType)
+		assert.True(t, decimal.NewFromInt(10).Equal(req.Items[0].DiscountValue))
+
+		imported := f.syncRecords.records["TB1001"]
+		require.NotNil(t, imported)
+		assert.Equal(t, integration.SyncStatusSuccess, imported.Status)
+		assert.Equal(t, "SO-TEST-001", imported.LocalOrderNumber)
+		failed := f.syncRecords.records["TB1002"]
+		require.NotNil(t, failed)
+		assert.Equal(t, integration.SyncStatusFailed, failed.Status)
+		assert.Contains(t, failed.ErrorMessage, "SKU-BLUE-XL")
+	})
+
+	t.Run("re-runs do not re-import orders", func(t *testing.T) {
+		f := newOrderImportFixture(t)
+
+		_, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+		f.now = f.now.Add(15 * time.Minute)
+		result, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+
+		assert.Len(t, f.salesOrders.requests, 1)
+		assert.Zero(t, result.ImportedCount)
+		// Only the unmapped order is pulled again, and retried
+		assert.Equal(t, 1, result.PulledCount)
+		assert.Equal(t, 1, result.FailedCount)
+
+		// Orders pulled again by an overlapping window are skipped
+		f.syncConfigs.config.OrderCursor = nil
+		result, err = f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+
+		assert.Len(t, f.salesOrders.requests, 1)
+		assert.Equal(t, 1, result.SkippedCount)
+	})
+
+	t.Run("advances the cursor to the earliest failed order", func(t *testing.T) {
+		f := newOrderImportFixture(t)
+
+		_, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+
+		require.NotNil(t, f.syncConfigs.config.OrderCursor)
+		assert.Equal(t, f.now.Add(-time.Hour), *f.syncConfigs.config.OrderCursor)
+		assert.Equal(t, f.now.Add(-DefaultOrderImportLookback), f.platform.requests[0].StartTime)
+	})
+
+	t.Run("imports a failed order once its SKU is mapped", func(t *testing.T) {
+		f := newOrderImportFixture(t)
+
+		_, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+
+		mapping, err := integration.NewProductMapping(f.tenantID, f.product.ID, integration.PlatformCodeTaobao, "P-200")
+		require.NoError(t, err)
+		f.mappings.ExpectedCalls = nil
+		f.mappings.On("FindByPlatformSku", mock.Anything, f.tenantID, integration.PlatformCodeTaobao, mock.Anything).Return(mapping, nil)
+
+		f.now = f.now.Add(15 * time.Minute)
+		result, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.ImportedCount)
+		assert.Zero(t, result.FailedCount)
+		assert.Len(t, f.salesOrders.requests, 2)
+		assert.Equal(t, integration.SyncStatusSuccess, f.syncRecords.records["TB1002"].Status)
+		assert.Equal(t, f.now, *f.syncConfigs.config.OrderCursor)
+	})
+
+	t.Run("skips orders that are not awaiting shipment", func(t *testing.T) {
+		f := newOrderImportFixture(t)
+		f.platform.orders[1].Status = integration.PlatformOrderStatusPending
+
+		result, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.ImportedCount)
+		assert.Equal(t, 1, result.SkippedCount)
+		assert.Zero(t, result.FailedCount)
+	})
+
+	t.Run("requires order sync to be configured", func(t *testing.T) {
+		f := newOrderImportFixture(t)
+		f.syncConfigs.config = nil
+
+		_, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		assert.ErrorIs(t, err, integration.ErrOrderSyncNotConfigured)
+		assert.Empty(t, f.platform.requests)
+	})
+
+	t.Run("rejects platforms without an adapter or not enabled", func(t *testing.T) {
+		f := newOrderImportFixture(t)
+
+		_, err := f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeJD)
+		assert.ErrorIs(t, err, integration.ErrPlatformNotConfigured)
+
+		f.platform.enabled = false
+		_, err = f.service.ImportPlatformOrders(ctx, f.tenantID, integration.PlatformCodeTaobao)
+		assert.ErrorIs(t, err, integration.ErrPlatformNotEnabled)
+	})
+}
@@ -0,0 +1,212 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Default backoff between retries of a failed order status push
+const (
+	DefaultOrderStatusPushBaseBackoff = time.Minute
+	DefaultOrderStatusPushMaxBackoff  = time.Hour
+)
+
+// orderStatusPushRetryBatchSize is the number of failed pushes retried per run
+const orderStatusPushRetryBatchSize = 100
+
+// OrderStatusPushServiceConfig holds the dependencies of an OrderStatusPushService
+type OrderStatusPushServiceConfig struct {
+	Platforms   integration.EcommercePlatformRegistry
+	SyncRecords integration.OrderSyncRecordRepository
+	Pushes      integration.OrderStatusPushRepository
+	Logger      *zap.Logger
+	// BaseBackoff is the delay before the first retry of a failed push; it doubles
+	// on each further failure up to MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// OrderStatusPushService pushes the status of sales orders imported from e-commerce
+// platforms back to the platform. Pushes the platform rejects or cannot be reached
+// for are queued and retried with backoff instead of being lost.
+type OrderStatusPushService struct {
+	platforms   integration.EcommercePlatformRegistry
+	syncRecords integration.OrderSyncRecordRepository
+	pushes      integration.OrderStatusPushRepository
+	logger      *zap.Logger
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+}
+
+// NewOrderStatusPushService creates a new OrderStatusPushService
+func NewOrderStatusPushService(cfg OrderStatusPushServiceConfig) *OrderStatusPushService {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	baseBackoff := cfg.BaseBackoff
+	if baseBackoff <= 0 {
+		baseBackoff = DefaultOrderStatusPushBaseBackoff
+	}
+	maxBackoff := cfg.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultOrderStatusPushMaxBackoff
+	}
+	return &OrderStatusPushService{
+		platforms:   cfg.Platforms,
+		syncRecords: cfg.SyncRecords,
+		pushes:      cfg.Pushes,
+		logger:      logger,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+		now:         time.Now,
+	}
+}
+
+// PushShipment tells the platform that a sales order imported from it has shipped,
+// with the shipment's carrier and tracking number.
+//
+// Orders not imported from a platform, and orders the platform already knows to be
+// shipped, are ignored. A shipment without a carrier or tracking number cannot be
+// confirmed on the platform and is skipped with a warning. If the platform call
+// fails, the push is queued for retry and no error is returned.
+func (s *OrderStatusPushService) PushShipment(ctx context.Context, tenantID, localOrderID uuid.UUID, shippingCompany, trackingNumber string) error {
+	record, err := s.syncRecords.FindByLocalOrder(ctx, tenantID, localOrderID)
+	if err != nil {
+		return fmt.Errorf("find platform order for sales order %s: %w", localOrderID, err)
+	}
+	if record == nil || !awaitingShipment(record.PlatformStatus) {
+		return nil
+	}
+
+	req := &integration.OrderStatusUpdateRequest{
+		TenantID:        tenantID,
+		PlatformCode:    record.PlatformCode,
+		PlatformOrderID: record.PlatformOrderID,
+		Status:          integration.PlatformOrderStatusShipped,
+		ShippingCompany: shippingCompany,
+		TrackingNumber:  trackingNumber,
+	}
+	logger := s.logger.With(
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("platform", record.PlatformCode.String()),
+		zap.String("platform_order_id", record.PlatformOrderID),
+		zap.String("order_id", localOrderID.String()),
+	)
+	if err := req.Validate(); err != nil {
+		logger.Warn("shipment not pushed to platform; confirm it on the platform manually", zap.Error(err))
+		return nil
+	}
+
+	if err := s.send(ctx, req); err != nil {
+		push := integration.NewFailedOrderStatusPush(req, localOrderID, err.Error(), s.baseBackoff, s.maxBackoff)
+		if saveErr := s.pushes.Save(ctx, push); saveErr != nil {
+			return fmt.Errorf("queue failed shipment push for platform order %s: %w", record.PlatformOrderID, saveErr)
+		}
+		logger.Warn("failed to push shipment to platform, queued for retry",
+			zap.String("push_id", push.ID.String()),
+			zap.Timep("next_retry_at", push.NextRetryAt),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	s.recordPlatformStatus(ctx, record, req.Status)
+	logger.Info("pushed shipment to platform", zap.String("tracking_number", trackingNumber))
+	return nil
+}
+
+// RetryFailedPushes retries the queued pushes that are due, returning how many were delivered.
+// A push that keeps failing moves to the dead letter state after its last retry.
+func (s *OrderStatusPushService) RetryFailedPushes(ctx context.Context) (int, error) {
+	pushes, err := s.pushes.FindRetryable(ctx, s.now(), orderStatusPushRetryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, push := range pushes {
+		if !push.CanRetry() {
+			continue
+		}
+		logger := s.logger.With(
+			zap.String("push_id", push.ID.String()),
+			zap.String("tenant_id", push.TenantID.String()),
+			zap.String("platform", push.PlatformCode.String()),
+			zap.String("platform_order_id", push.PlatformOrderID),
+		)
+
+		record, err := s.syncRecords.FindByLocalOrder(ctx, push.TenantID, push.LocalOrderID)
+		if err != nil {
+			logger.Error("failed to load platform order for push retry", zap.Error(err))
+			continue
+		}
+
+		// A later push may already have delivered the status
+		if record != nil && record.PlatformStatus == push.Status {
+			push.MarkSent()
+		} else if err := s.send(ctx, push.Request()); err != nil {
+			push.MarkFailedWithBackoff(err.Error(), s.baseBackoff, s.maxBackoff)
+			if push.IsDead() {
+				logger.Error("order status push failed permanently after all retries; update the platform manually",
+					zap.Int("retry_count", push.RetryCount),
+					zap.Error(err),
+				)
+			} else {
+				logger.Warn("order status push retry failed",
+					zap.Int("retry_count", push.RetryCount),
+					zap.Timep("next_retry_at", push.NextRetryAt),
+					zap.Error(err),
+				)
+			}
+		} else {
+			push.MarkSent()
+			if record != nil {
+				s.recordPlatformStatus(ctx, record, push.Status)
+			}
+			delivered++
+		}
+
+		if err := s.pushes.Save(ctx, push); err != nil {
+			logger.Error("failed to save order status push", zap.Error(err))
+		}
+	}
+	return delivered, nil
+}
+
+// send delivers a status update to its platform
+func (s *OrderStatusPushService) send(ctx context.Context, req *integration.OrderStatusUpdateRequest) error {
+	platform, err := s.platforms.GetPlatform(req.PlatformCode)
+	if err != nil {
+		return err
+	}
+	return platform.UpdateOrderStatus(ctx, req)
+}
+
+// recordPlatformStatus notes the status now known to the platform on the order's sync record
+func (s *OrderStatusPushService) recordPlatformStatus(ctx context.Context, record *integration.PlatformOrderSyncRecord, status integration.PlatformOrderStatus) {
+	record.PlatformStatus = status
+	record.UpdatedAt = s.now()
+	if err := s.syncRecords.Save(ctx, record); err != nil {
+		s.logger.Warn("failed to record platform order status",
+			zap.String("platform_order_id", record.PlatformOrderID),
+			zap.Error(err),
+		)
+	}
+}
+
+// awaitingShipment reports whether a platform order has not been shipped on the platform yet
+func awaitingShipment(status integration.PlatformOrderStatus) bool {
+	switch status {
+	case "", integration.PlatformOrderStatusPending, integration.PlatformOrderStatusPaid:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,271 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/erp/backend/internal/infrastructure/ecommerce"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeStatusPlatform records status updates and fails while err is set
+type fakeStatusPlatform struct {
+	integration.EcommercePlatform
+	err     error
+	updates []integration.OrderStatusUpdateRequest
+}
+
+func (p *fakeStatusPlatform) PlatformCode() integration.PlatformCode {
+	return integration.PlatformCodeTaobao
+}
+
+func (p *fakeStatusPlatform) UpdateOrderStatus(ctx context.Context, req *integration.OrderStatusUpdateRequest) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.updates = append(p.updates, *req)
+	return nil
+}
+
+// memoryOrderStatusPushRepository keeps order status pushes in memory
+type memoryOrderStatusPushRepository struct {
+	pushes map[uuid.UUID]*integration.OrderStatusPush
+}
+
+func (r *memoryOrderStatusPushRepository) Save(ctx context.Context, push *integration.OrderStatusPush) error {
+	saved := *push
+	r.pushes[push.ID] = &saved
+	return nil
+}
+
+func (r *memoryOrderStatusPushRepository) FindRetryable(ctx context.Context, before time.Time, limit int) ([]*integration.OrderStatusPush, error) {
+	var due []*integration.OrderStatusPush
+	for _, push := range r.pushes {
+		if push.CanRetry() && push.NextRetryAt != nil && !push.NextRetryAt.After(before) {
+			found := *push
+			due = append(due, &found)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryOrderStatusPushRepository) all() []*integration.OrderStatusPush {
+	pushes := make([]*integration.OrderStatusPush, 0, len(r.pushes))
+	for _, push := range r.pushes {
+		pushes = append(pushes, push)
+	}
+	return pushes
+}
+
+type orderStatusPushFixture struct {
+	service     *OrderStatusPushService
+	platform    *fakeStatusPlatform
+	syncRecords *memorySyncRecordRepository
+	pushes      *memoryOrderStatusPushRepository
+	tenantID    uuid.UUID
+}
+
+func newOrderStatusPushFixture(t *testing.T) *orderStatusPushFixture {
+	t.Helper()
+
+	f := &orderStatusPushFixture{
+		platform:    &fakeStatusPlatform{},
+		syncRecords: &memorySyncRecordRepository{records: make(map[string]*integration.PlatformOrderSyncRecord)},
+		pushes:      &memoryOrderStatusPushRepository{pushes: make(map[uuid.UUID]*integration.OrderStatusPush)},
+		tenantID:    uuid.New(),
+	}
+	f.service = NewOrderStatusPushService(OrderStatusPushServiceConfig{
+		Platforms:   ecommerce.NewPlatformRegistry(f.platform),
+		SyncRecords: f.syncRecords,
+		Pushes:      f.pushes,
+	})
+	return f
+}
+
+// importedOrder records a sales order as imported from a platform order with the given status
+func (f *orderStatusPushFixture) importedOrder(platformOrderID string, status integration.PlatformOrderStatus) uuid.UUID {
+	localOrderID := uuid.New()
+	f.syncRecords.records[platformOrderID] = &integration.PlatformOrderSyncRecord{
+		ID:              uuid.New(),
+		TenantID:        f.tenantID,
+		PlatformCode:    integration.PlatformCodeTaobao,
+		PlatformOrderID: platformOrderID,
+		LocalOrderID:    &localOrderID,
+		Direction:       integration.OrderSyncDirectionInbound,
+		Status:          integration.SyncStatusSuccess,
+		PlatformStatus:  status,
+	}
+	return localOrderID
+}
+
+// retryNow makes every queued push due
+func (f *orderStatusPushFixture) retryNow() {
+	f.service.now = func() time.Time { return time.Now().Add(24 * time.Hour) }
+}
+
+func TestOrderStatusPushService_PushShipment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pushes the shipment and tracking number to the platform", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB1001", integration.PlatformOrderStatusPaid)
+
+		err := f.service.PushShipment(ctx, f.tenantID, orderID, "SF", "SF1234567890")
+
+		require.NoError(t, err)
+		require.Len(t, f.platform.updates, 1)
+		update := f.platform.updates[0]
+		assert.Equal(t, "TB1001", update.PlatformOrderID)
+		assert.Equal(t, integration.PlatformOrderStatusShipped, update.Status)
+		assert.Equal(t, "SF", update.ShippingCompany)
+		assert.Equal(t, "SF1234567890", update.TrackingNumber)
+		assert.Equal(t, integration.PlatformOrderStatusShipped, f.syncRecords.records["TB1001"].PlatformStatus)
+		assert.Empty(t, f.pushes.all())
+	})
+
+	t.Run("queues a failed push for retry", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB1002", integration.PlatformOrderStatusPaid)
+		f.platform.err = errors.New("platform unavailable")
+
+		err := f.service.PushShipment(ctx, f.tenantID, orderID, "SF", "SF1234567890")
+
+		require.NoError(t, err)
+		pushes := f.pushes.all()
+		require.Len(t, pushes, 1)
+		push := pushes[0]
+		assert.Equal(t, integration.OrderStatusPushStateFailed, push.State)
+		assert.Equal(t, "platform unavailable", push.LastError)
+		assert.Equal(t, orderID, push.LocalOrderID)
+		assert.Equal(t, "SF1234567890", push.TrackingNumber)
+		assert.NotNil(t, push.NextRetryAt)
+		assert.Equal(t, integration.PlatformOrderStatusPaid, f.syncRecords.records["TB1002"].PlatformStatus)
+	})
+
+	t.Run("ignores orders not imported from a platform", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+
+		err := f.service.PushShipment(ctx, f.tenantID, uuid.New(), "SF", "SF1234567890")
+
+		require.NoError(t, err)
+		assert.Empty(t, f.platform.updates)
+		assert.Empty(t, f.pushes.all())
+	})
+
+	t.Run("skips orders already shipped on the platform", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB1003", integration.PlatformOrderStatusShipped)
+
+		err := f.service.PushShipment(ctx, f.tenantID, orderID, "SF", "SF1234567890")
+
+		require.NoError(t, err)
+		assert.Empty(t, f.platform.updates)
+	})
+
+	t.Run("skips shipments without a tracking number", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB1004", integration.PlatformOrderStatusPaid)
+
+		err := f.service.PushShipment(ctx, f.tenantID, orderID, "", "")
+
+		require.NoError(t, err)
+		assert.Empty(t, f.platform.updates)
+		assert.Empty(t, f.pushes.all())
+	})
+}
+
+func TestOrderStatusPushService_RetryFailedPushes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delivers a queued push once the platform recovers", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB2001", integration.PlatformOrderStatusPaid)
+		f.platform.err = errors.New("platform unavailable")
+		require.NoError(t, f.service.PushShipment(ctx, f.tenantID, orderID, "SF", "SF1234567890"))
+
+		f.platform.err = nil
+		f.retryNow()
+		delivered, err := f.service.RetryFailedPushes(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		require.Len(t, f.platform.updates, 1)
+		assert.Equal(t, "SF1234567890", f.platform.updates[0].TrackingNumber)
+		assert.Equal(t, integration.OrderStatusPushStateSent, f.pushes.all()[0].State)
+		assert.Equal(t, integration.PlatformOrderStatusShipped, f.syncRecords.records["TB2001"].PlatformStatus)
+	})
+
+	t.Run("does not retry pushes before they are due", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB2002", integration.PlatformOrderStatusPaid)
+		f.platform.err = errors.New("platform unavailable")
+		require.NoError(t, f.service.PushShipment(ctx, f.tenantID, orderID, "SF", "SF1234567890"))
+
+		f.platform.err = nil
+		delivered, err := f.service.RetryFailedPushes(ctx)
+
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+		assert.Empty(t, f.platform.updates)
+	})
+
+	t.Run("moves a push to dead letter after its last retry", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB2003", integration.PlatformOrderStatusPaid)
+		f.platform.err = errors.New("order closed on platform")
+		require.NoError(t, f.service.PushShipment(ctx, f.tenantID, orderID, "SF", "SF1234567890"))
+
+		f.retryNow()
+		for i := 0; i < 10; i++ {
+			_, err := f.service.RetryFailedPushes(ctx)
+			require.NoError(t, err)
+		}
+
+		push := f.pushes.all()[0]
+		assert.True(t, push.IsDead())
+		assert.Equal(t, push.MaxRetries, push.RetryCount)
+	})
+}
+
+func TestSalesOrderShippedHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	shippedEvent := func(f *orderStatusPushFixture, orderID uuid.UUID, shipmentNumber int) *trade.SalesOrderShippedEvent {
+		return &trade.SalesOrderShippedEvent{
+			BaseDomainEvent: shared.NewBaseDomainEvent(trade.EventTypeSalesOrderShipped, trade.AggregateTypeSalesOrder, orderID, f.tenantID),
+			OrderID:         orderID,
+			ShipmentNumber:  shipmentNumber,
+			ShippingCompany: "SF",
+			TrackingNumber:  "SF1234567890",
+		}
+	}
+
+	t.Run("pushes the first shipment of a platform order", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB3001", integration.PlatformOrderStatusPaid)
+		handler := NewSalesOrderShippedHandler(f.service, zap.NewNop())
+
+		require.NoError(t, handler.Handle(ctx, shippedEvent(f, orderID, 1)))
+
+		require.Len(t, f.platform.updates, 1)
+		assert.Equal(t, "SF1234567890", f.platform.updates[0].TrackingNumber)
+	})
+
+	t.Run("ignores later shipments", func(t *testing.T) {
+		f := newOrderStatusPushFixture(t)
+		orderID := f.importedOrder("TB3002", integration.PlatformOrderStatusPaid)
+		handler := NewSalesOrderShippedHandler(f.service, zap.NewNop())
+
+		require.NoError(t, handler.Handle(ctx, shippedEvent(f, orderID, 2)))
+
+		assert.Empty(t, f.platform.updates)
+	})
+}
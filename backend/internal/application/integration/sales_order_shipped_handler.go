@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ShipmentPusher pushes shipments of platform orders back to their platform
+type ShipmentPusher interface {
+	PushShipment(ctx context.Context, tenantID, localOrderID uuid.UUID, shippingCompany, trackingNumber string) error
+}
+
+// SalesOrderShippedHandler handles SalesOrderShippedEvent by confirming the shipment
+// on the e-commerce platform the order was imported from.
+//
+// Only the first shipment of an order is pushed: platforms take one shipment
+// confirmation per order, with the tracking number of the first parcel.
+type SalesOrderShippedHandler struct {
+	pusher ShipmentPusher
+	logger *zap.Logger
+}
+
+// NewSalesOrderShippedHandler creates a new handler for sales order shipped events
+func NewSalesOrderShippedHandler(pusher ShipmentPusher, logger *zap.Logger) *SalesOrderShippedHandler {
+	return &SalesOrderShippedHandler{
+		pusher: pusher,
+		logger: logger,
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *SalesOrderShippedHandler) EventTypes() []string {
+	return []string{trade.EventTypeSalesOrderShipped}
+}
+
+// Handle processes a SalesOrderShippedEvent
+func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	shippedEvent, ok := event.(*trade.SalesOrderShippedEvent)
+	if !ok {
+		h.logger.Error("unexpected event type",
+			zap.String("expected", trade.EventTypeSalesOrderShipped),
+			zap.String("actual", event.EventType()),
+		)
+		return fmt.Errorf("unexpected event type: expected %s, got %s",
+			trade.EventTypeSalesOrderShipped, event.EventType())
+	}
+
+	if shippedEvent.ShipmentNumber > 1 {
+		return nil
+	}
+
+	return h.pusher.PushShipment(ctx, event.TenantID(), shippedEvent.OrderID,
+		shippedEvent.ShippingCompany, shippedEvent.TrackingNumber)
+}
+
+// Ensure SalesOrderShippedHandler implements shared.EventHandler
+var _ shared.EventHandler = (*SalesOrderShippedHandler)(nil)
@@ -0,0 +1,279 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// DefaultStockPushDebounce is how long a product's stock has to stay unchanged
+// before its quantity is pushed to the platforms
+const DefaultStockPushDebounce = 5 * time.Second
+
+// stockPushTimeout bounds one product's push to all of its platforms
+const stockPushTimeout = 30 * time.Second
+
+// ProductStockReader reads a product's inventory across warehouses
+type ProductStockReader interface {
+	FindByProduct(ctx context.Context, tenantID, productID uuid.UUID, filter shared.Filter) ([]inventory.InventoryItem, error)
+}
+
+// StockPushHandlerConfig holds the dependencies of a StockPushHandler
+type StockPushHandlerConfig struct {
+	Platforms integration.EcommercePlatformRegistry
+	Mappings  integration.ProductMappingReader
+	Stock     ProductStockReader
+	// EnabledPlatforms are the platforms whose listings receive stock updates
+	EnabledPlatforms []integration.PlatformCode
+	// Debounce is how long to wait for further changes to a product before
+	// pushing its quantity (default: DefaultStockPushDebounce)
+	Debounce time.Duration
+	Logger   *zap.Logger
+}
+
+// StockPushHandler pushes a product's available quantity to the e-commerce
+// platforms it is mapped on whenever its stock changes, so platform listings
+// do not oversell.
+//
+// Pushes are debounced per product: a burst of stock changes results in one
+// push, made once the product's stock has been unchanged for the debounce
+// window, with the available quantity read at that time.
+type StockPushHandler struct {
+	platforms integration.EcommercePlatformRegistry
+	mappings  integration.ProductMappingReader
+	stock     ProductStockReader
+	enabled   map[integration.PlatformCode]bool
+	debounce  time.Duration
+	logger    *zap.Logger
+
+	mu       sync.Mutex
+	pending  map[stockPushKey]*pendingStockPush
+	inflight sync.WaitGroup
+	stopped  bool
+}
+
+// stockPushKey identifies a product whose stock push is pending
+type stockPushKey struct {
+	tenantID  uuid.UUID
+	productID uuid.UUID
+}
+
+// pendingStockPush is a stock push waiting for the product's debounce window to end
+type pendingStockPush struct {
+	timer *time.Timer
+	// ctx is the context of the event that scheduled the push without its
+	// cancellation, so the push runs as the event's tenant
+	ctx context.Context
+}
+
+// NewStockPushHandler creates a new handler for stock change events
+func NewStockPushHandler(cfg StockPushHandlerConfig) *StockPushHandler {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	debounce := cfg.Debounce
+	if debounce <= 0 {
+		debounce = DefaultStockPushDebounce
+	}
+	enabled := make(map[integration.PlatformCode]bool, len(cfg.EnabledPlatforms))
+	for _, code := range cfg.EnabledPlatforms {
+		enabled[code] = true
+	}
+	return &StockPushHandler{
+		platforms: cfg.Platforms,
+		mappings:  cfg.Mappings,
+		stock:     cfg.Stock,
+		enabled:   enabled,
+		debounce:  debounce,
+		logger:    logger,
+		pending:   make(map[stockPushKey]*pendingStockPush),
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *StockPushHandler) EventTypes() []string {
+	return []string{
+		inventory.EventTypeStockIncreased,
+		inventory.EventTypeStockDecreased,
+		inventory.EventTypeStockDeducted,
+		inventory.EventTypeStockAdjusted,
+	}
+}
+
+// Handle schedules a stock push for the product whose stock changed
+func (h *StockPushHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	var productID uuid.UUID
+	switch e := event.(type) {
+	case *inventory.StockIncreasedEvent:
+		productID = e.ProductID
+	case *inventory.StockDecreasedEvent:
+		productID = e.ProductID
+	case *inventory.StockDeductedEvent:
+		productID = e.ProductID
+	case *inventory.StockAdjustedEvent:
+		productID = e.ProductID
+	default:
+		h.logger.Error("unexpected event type",
+			zap.Strings("expected", h.EventTypes()),
+			zap.String("actual", event.EventType()),
+		)
+		return fmt.Errorf("unexpected event type: %s", event.EventType())
+	}
+
+	if len(h.enabled) == 0 {
+		return nil
+	}
+	h.schedule(ctx, stockPushKey{tenantID: event.TenantID(), productID: productID})
+	return nil
+}
+
+// schedule starts the product's debounce window, or restarts it if a push is already pending
+func (h *StockPushHandler) schedule(ctx context.Context, key stockPushKey) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopped {
+		return
+	}
+	if pending, ok := h.pending[key]; ok && pending.timer.Stop() {
+		pending.timer.Reset(h.debounce)
+		return
+	}
+
+	// Either nothing is pending or the previous push is already running;
+	// in the latter case this change gets a push of its own
+	h.inflight.Add(1)
+	pending := &pendingStockPush{ctx: context.WithoutCancel(ctx)}
+	pending.timer = time.AfterFunc(h.debounce, func() {
+		defer h.inflight.Done()
+		h.mu.Lock()
+		if h.pending[key] == pending {
+			delete(h.pending, key)
+		}
+		h.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(pending.ctx, stockPushTimeout)
+		defer cancel()
+		h.push(ctx, key)
+	})
+	h.pending[key] = pending
+}
+
+// Stop pushes the pending stock updates immediately and waits for running pushes to finish.
+// Stock changes handled after Stop are not pushed.
+func (h *StockPushHandler) Stop(ctx context.Context) error {
+	h.mu.Lock()
+	h.stopped = true
+	due := make(map[stockPushKey]*pendingStockPush, len(h.pending))
+	for key, pending := range h.pending {
+		if pending.timer.Stop() {
+			due[key] = pending
+			h.inflight.Done()
+		}
+		delete(h.pending, key)
+	}
+	h.mu.Unlock()
+
+	for key, pending := range due {
+		// Push as the event's tenant, within the time given to Stop
+		pushCtx, cancel := context.WithCancel(pending.ctx)
+		stopCancel := context.AfterFunc(ctx, cancel)
+		h.push(pushCtx, key)
+		stopCancel()
+		cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// push sends the product's current available quantity to every enabled platform it is mapped on
+func (h *StockPushHandler) push(ctx context.Context, key stockPushKey) {
+	logger := h.logger.With(
+		zap.String("tenant_id", key.tenantID.String()),
+		zap.String("product_id", key.productID.String()),
+	)
+
+	mappings, err := h.mappings.FindByLocalProduct(ctx, key.tenantID, key.productID)
+	if err != nil {
+		logger.Error("failed to load product mappings for stock push", zap.Error(err))
+		return
+	}
+	targets := make([]integration.ProductMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		if mapping.IsActive && mapping.SyncEnabled && h.enabled[mapping.PlatformCode] {
+			targets = append(targets, mapping)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	quantity, err := h.availableQuantity(ctx, key)
+	if err != nil {
+		logger.Error("failed to read available stock for stock push", zap.Error(err))
+		return
+	}
+
+	for _, mapping := range targets {
+		fields := []zap.Field{
+			zap.String("platform", mapping.PlatformCode.String()),
+			zap.String("platform_product_id", mapping.PlatformProductID),
+			zap.String("quantity", quantity.String()),
+		}
+
+		platform, err := h.platforms.GetPlatform(mapping.PlatformCode)
+		if err != nil {
+			logger.Warn("stock push skipped: platform not configured", append(fields, zap.Error(err))...)
+			continue
+		}
+		enabled, err := platform.IsEnabled(ctx, key.tenantID)
+		if err != nil || !enabled {
+			logger.Debug("stock push skipped: platform not enabled for tenant", fields...)
+			continue
+		}
+
+		if err := platform.UpdateStock(ctx, key.tenantID, mapping.PlatformProductID, quantity); err != nil {
+			logger.Error("failed to push stock to platform", append(fields, zap.Error(err))...)
+			continue
+		}
+		logger.Info("pushed stock to platform", fields...)
+	}
+}
+
+// availableQuantity sums the product's available (unlocked) stock across warehouses
+func (h *StockPushHandler) availableQuantity(ctx context.Context, key stockPushKey) (decimal.Decimal, error) {
+	items, err := h.stock.FindByProduct(ctx, key.tenantID, key.productID, shared.Filter{})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	total := decimal.Zero
+	for _, item := range items {
+		total = total.Add(item.AvailableQuantity.Amount())
+	}
+	if total.IsNegative() {
+		return decimal.Zero, nil
+	}
+	return total, nil
+}
+
+// Ensure StockPushHandler implements shared.EventHandler
+var _ shared.EventHandler = (*StockPushHandler)(nil)
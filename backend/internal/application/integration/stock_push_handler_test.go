@@ -0,0 +1,219 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/ecommerce"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stockPush is a quantity pushed to a platform product
+type stockPush struct {
+	platformProductID string
+	quantity          decimal.Decimal
+}
+
+// fakeStockPlatform captures the stock pushed to it
+type fakeStockPlatform struct {
+	integration.EcommercePlatform
+	code   integration.PlatformCode
+	mu     sync.Mutex
+	pushes []stockPush
+}
+
+func (p *fakeStockPlatform) PlatformCode() integration.PlatformCode {
+	return p.code
+}
+
+func (p *fakeStockPlatform) IsEnabled(ctx context.Context, tenantID uuid.UUID) (bool, error) {
+	return true, nil
+}
+
+func (p *fakeStockPlatform) UpdateStock(ctx context.Context, tenantID uuid.UUID, platformProductID string, quantity decimal.Decimal) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pushes = append(p.pushes, stockPush{platformProductID: platformProductID, quantity: quantity})
+	return nil
+}
+
+func (p *fakeStockPlatform) pushed() []stockPush {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]stockPush(nil), p.pushes...)
+}
+
+// mutableStockReader serves one warehouse's stock per product, which tests change over time
+type mutableStockReader struct {
+	mu        sync.Mutex
+	available map[uuid.UUID]decimal.Decimal
+}
+
+func (r *mutableStockReader) set(productID uuid.UUID, quantity int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.available[productID] = decimal.NewFromInt(quantity)
+}
+
+func (r *mutableStockReader) FindByProduct(ctx context.Context, tenantID, productID uuid.UUID, filter shared.Filter) ([]inventory.InventoryItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	item, err := inventory.NewInventoryItem(tenantID, uuid.New(), productID)
+	if err != nil {
+		return nil, err
+	}
+	item.AvailableQuantity = inventory.MustNewInventoryQuantity(r.available[productID])
+	return []inventory.InventoryItem{*item}, nil
+}
+
+type stockPushFixture struct {
+	handler  *StockPushHandler
+	taobao   *fakeStockPlatform
+	douyin   *fakeStockPlatform
+	mappings *MockProductMappingRepository
+	stock    *mutableStockReader
+	tenantID uuid.UUID
+}
+
+func newStockPushFixture(t *testing.T, enabled ...integration.PlatformCode) *stockPushFixture {
+	t.Helper()
+
+	f := &stockPushFixture{
+		taobao:   &fakeStockPlatform{code: integration.PlatformCodeTaobao},
+		douyin:   &fakeStockPlatform{code: integration.PlatformCodeDouyin},
+		mappings: new(MockProductMappingRepository),
+		stock:    &mutableStockReader{available: make(map[uuid.UUID]decimal.Decimal)},
+		tenantID: uuid.New(),
+	}
+	f.handler = NewStockPushHandler(StockPushHandlerConfig{
+		Platforms:        ecommerce.NewPlatformRegistry(f.taobao, f.douyin),
+		Mappings:         f.mappings,
+		Stock:            f.stock,
+		EnabledPlatforms: enabled,
+		Debounce:         20 * time.Millisecond,
+	})
+	t.Cleanup(func() { _ = f.handler.Stop(context.Background()) })
+	return f
+}
+
+// mapProduct maps a local product to a product on each given platform
+func (f *stockPushFixture) mapProduct(t *testing.T, productID uuid.UUID, platforms ...integration.PlatformCode) {
+	t.Helper()
+	mappings := make([]integration.ProductMapping, 0, len(platforms))
+	for _, code := range platforms {
+		mapping, err := integration.NewProductMapping(f.tenantID, productID, code, string(code)+"-"+productID.String()[:8])
+		require.NoError(t, err)
+		mappings = append(mappings, *mapping)
+	}
+	f.mappings.On("FindByLocalProduct", mock.Anything, f.tenantID, productID).Return(mappings, nil)
+}
+
+// stockChanged sets the product's available stock and publishes a stock adjusted event
+func (f *stockPushFixture) stockChanged(t *testing.T, productID uuid.UUID, available int64) {
+	t.Helper()
+	f.stock.set(productID, available)
+	item, err := inventory.NewInventoryItem(f.tenantID, uuid.New(), productID)
+	require.NoError(t, err)
+	event := inventory.NewStockAdjustedEvent(item, decimal.Zero, decimal.NewFromInt(available), decimal.NewFromInt(available), "test")
+	require.NoError(t, f.handler.Handle(context.Background(), event))
+}
+
+func TestStockPushHandler_EventTypes(t *testing.T) {
+	handler := NewStockPushHandler(StockPushHandlerConfig{})
+
+	assert.ElementsMatch(t, []string{
+		inventory.EventTypeStockIncreased,
+		inventory.EventTypeStockDecreased,
+		inventory.EventTypeStockDeducted,
+		inventory.EventTypeStockAdjusted,
+	}, handler.EventTypes())
+}
+
+func TestStockPushHandler_Handle(t *testing.T) {
+	t.Run("coalesces a burst of changes into one push with the final quantity", func(t *testing.T) {
+		f := newStockPushFixture(t, integration.PlatformCodeTaobao)
+		productID := uuid.New()
+		f.mapProduct(t, productID, integration.PlatformCodeTaobao)
+
+		f.stockChanged(t, productID, 10)
+		f.stockChanged(t, productID, 8)
+		f.stockChanged(t, productID, 5)
+
+		require.Eventually(t, func() bool { return len(f.taobao.pushed()) > 0 }, time.Second, 5*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		pushes := f.taobao.pushed()
+		require.Len(t, pushes, 1)
+		assert.True(t, decimal.NewFromInt(5).Equal(pushes[0].quantity), "pushed %s", pushes[0].quantity)
+	})
+
+	t.Run("pushes each product separately", func(t *testing.T) {
+		f := newStockPushFixture(t, integration.PlatformCodeTaobao)
+		tee, mug := uuid.New(), uuid.New()
+		f.mapProduct(t, tee, integration.PlatformCodeTaobao)
+		f.mapProduct(t, mug, integration.PlatformCodeTaobao)
+
+		f.stockChanged(t, tee, 3)
+		f.stockChanged(t, mug, 7)
+
+		require.Eventually(t, func() bool { return len(f.taobao.pushed()) == 2 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("only pushes to enabled platforms", func(t *testing.T) {
+		f := newStockPushFixture(t, integration.PlatformCodeDouyin)
+		productID := uuid.New()
+		f.mapProduct(t, productID, integration.PlatformCodeTaobao, integration.PlatformCodeDouyin)
+
+		f.stockChanged(t, productID, 12)
+
+		require.Eventually(t, func() bool { return len(f.douyin.pushed()) == 1 }, time.Second, 5*time.Millisecond)
+		assert.True(t, decimal.NewFromInt(12).Equal(f.douyin.pushed()[0].quantity))
+		assert.Empty(t, f.taobao.pushed())
+	})
+
+	t.Run("does nothing when no platform is enabled", func(t *testing.T) {
+		f := newStockPushFixture(t)
+		productID := uuid.New()
+
+		f.stockChanged(t, productID, 4)
+		require.NoError(t, f.handler.Stop(context.Background()))
+
+		f.mappings.AssertNotCalled(t, "FindByLocalProduct", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects unexpected event types", func(t *testing.T) {
+		f := newStockPushFixture(t, integration.PlatformCodeTaobao)
+		item, err := inventory.NewInventoryItem(f.tenantID, uuid.New(), uuid.New())
+		require.NoError(t, err)
+
+		err = f.handler.Handle(context.Background(), inventory.NewStockLockedEvent(item, decimal.NewFromInt(1), uuid.New(), "SALES_ORDER", "SO-1"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestStockPushHandler_Stop(t *testing.T) {
+	f := newStockPushFixture(t, integration.PlatformCodeTaobao)
+	f.handler.debounce = time.Hour
+	productID := uuid.New()
+	f.mapProduct(t, productID, integration.PlatformCodeTaobao)
+
+	f.stockChanged(t, productID, 9)
+	require.NoError(t, f.handler.Stop(context.Background()))
+
+	pushes := f.taobao.pushed()
+	require.Len(t, pushes, 1)
+	assert.True(t, decimal.NewFromInt(9).Equal(pushes[0].quantity))
+
+	// Changes after Stop are not pushed
+	f.stockChanged(t, productID, 1)
+	assert.Len(t, f.taobao.pushed(), 1)
+}
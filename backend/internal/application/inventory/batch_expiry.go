@@ -0,0 +1,59 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ExpiryTenantReader provides the tenant configuration that sets the ship expiry buffer
+type ExpiryTenantReader interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error)
+}
+
+// SetExpiryTenantReader sets the tenant reader used to look up the ship expiry buffer.
+// Without it, only batches already expired on the ship date are blocked.
+func (s *InventoryService) SetExpiryTenantReader(reader ExpiryTenantReader) {
+	s.expiryTenantReader = reader
+}
+
+// sellableUntil returns the date stock shipped on shipDate must stay good until:
+// the ship date plus the tenant's expiry buffer
+func (s *InventoryService) sellableUntil(ctx context.Context, tenantID uuid.UUID, shipDate time.Time) (time.Time, error) {
+	if s.expiryTenantReader == nil {
+		return shipDate, nil
+	}
+	tenant, err := s.expiryTenantReader.FindByID(ctx, tenantID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return shipDate.AddDate(0, 0, tenant.Config.ShipExpiryBufferDays), nil
+}
+
+// CheckBatchExpiry returns a BATCH_EXPIRED error naming the batch and its expiry when shipping
+// the quantity of a product on shipDate would take stock from a batch that expires before the
+// ship date plus the tenant's expiry buffer. Products without stock or batches in the warehouse
+// pass the check.
+func (s *InventoryService) CheckBatchExpiry(ctx context.Context, tenantID, warehouseID, productID uuid.UUID, quantity decimal.Decimal, shipDate time.Time) error {
+	item, err := s.inventoryRepo.FindByWarehouseAndProduct(ctx, tenantID, warehouseID, productID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if len(item.Batches) == 0 {
+		return nil
+	}
+
+	sellableUntil, err := s.sellableUntil(ctx, tenantID, shipDate)
+	if err != nil {
+		return err
+	}
+	return item.CheckSellableBatches(quantity, sellableUntil)
+}
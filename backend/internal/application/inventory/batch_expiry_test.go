@@ -0,0 +1,143 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubExpiryTenantReader serves a tenant with the given ship expiry buffer
+type stubExpiryTenantReader int
+
+func (r stubExpiryTenantReader) FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error) {
+	tenant := &identity.Tenant{Config: identity.DefaultTenantConfig()}
+	tenant.ID = id
+	tenant.Config.ShipExpiryBufferDays = int(r)
+	return tenant, nil
+}
+
+func TestInventoryService_BatchExpiry(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	warehouseID := uuid.New()
+	productID := uuid.New()
+	shipDate := time.Now()
+
+	// newItem holds 10 units of a single batch expiring on the given date
+	newItem := func(t *testing.T, batchNumber string, expiry time.Time) *inventory.InventoryItem {
+		item := createTestInventoryItem(tenantID, warehouseID, productID)
+		require.NoError(t, item.IncreaseStock(decimal.NewFromInt(10), valueobject.NewMoneyCNYFromFloat(10), inventory.NewBatchInfo(batchNumber, nil, &expiry)))
+		item.ClearDomainEvents()
+		return item
+	}
+
+	errorCode := func(t *testing.T, err error) string {
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		return domainErr.Code
+	}
+
+	checkService := func(item *inventory.InventoryItem, bufferDays int) *InventoryService {
+		invRepo := new(MockInventoryItemRepository)
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, warehouseID, productID).Return(item, nil)
+		service := NewInventoryServiceWithLockRepo(invRepo, new(MockStockLockRepository), new(MockTransactionRepository))
+		service.SetExpiryTenantReader(stubExpiryTenantReader(bufferDays))
+		return service
+	}
+
+	t.Run("check blocks an expired batch", func(t *testing.T) {
+		service := checkService(newItem(t, "LOT-EXPIRED", shipDate.AddDate(0, 0, -2)), 0)
+
+		err := service.CheckBatchExpiry(ctx, tenantID, warehouseID, productID, decimal.NewFromInt(5), shipDate)
+
+		assert.Equal(t, "BATCH_EXPIRED", errorCode(t, err))
+		assert.Contains(t, err.Error(), "LOT-EXPIRED")
+	})
+
+	t.Run("check allows a fresh batch", func(t *testing.T) {
+		service := checkService(newItem(t, "LOT-FRESH", shipDate.AddDate(0, 3, 0)), 7)
+
+		assert.NoError(t, service.CheckBatchExpiry(ctx, tenantID, warehouseID, productID, decimal.NewFromInt(5), shipDate))
+	})
+
+	t.Run("check applies the tenant expiry buffer", func(t *testing.T) {
+		expiry := shipDate.AddDate(0, 0, 5)
+
+		assert.NoError(t, checkService(newItem(t, "LOT-SOON", expiry), 0).CheckBatchExpiry(ctx, tenantID, warehouseID, productID, decimal.NewFromInt(5), shipDate))
+
+		err := checkService(newItem(t, "LOT-SOON", expiry), 7).CheckBatchExpiry(ctx, tenantID, warehouseID, productID, decimal.NewFromInt(5), shipDate)
+		assert.Equal(t, "BATCH_EXPIRED", errorCode(t, err))
+		assert.Contains(t, err.Error(), expiry.Format("2006-01-02"))
+	})
+
+	t.Run("check passes products without stock in the warehouse", func(t *testing.T) {
+		invRepo := new(MockInventoryItemRepository)
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, warehouseID, productID).Return(nil, shared.ErrNotFound)
+		service := NewInventoryServiceWithLockRepo(invRepo, new(MockStockLockRepository), new(MockTransactionRepository))
+
+		assert.NoError(t, service.CheckBatchExpiry(ctx, tenantID, warehouseID, productID, decimal.NewFromInt(5), shipDate))
+	})
+
+	// deduct locks 5 units of the item and deducts them
+	deduct := func(t *testing.T, item *inventory.InventoryItem, allowExpired bool) (*MockInventoryItemRepository, error) {
+		lock, err := item.LockStock(decimal.NewFromInt(5), "SALES_ORDER", "SO-001", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		item.Locks = nil
+
+		invRepo := new(MockInventoryItemRepository)
+		lockRepo := new(MockStockLockRepository)
+		txRepo := new(MockTransactionRepository)
+		invRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		invRepo.On("SaveWithLock", mock.Anything, mock.Anything).Return(nil)
+		lockRepo.On("FindByID", mock.Anything, lock.ID).Return(lock, nil)
+		lockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		txRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		service := NewInventoryServiceWithLockRepo(invRepo, lockRepo, txRepo)
+		service.SetExpiryTenantReader(stubExpiryTenantReader(0))
+		return invRepo, service.DeductStock(ctx, tenantID, DeductStockRequest{
+			LockID:       lock.ID,
+			SourceType:   "SALES_ORDER",
+			SourceID:     "SO-001",
+			ShipDate:     &shipDate,
+			AllowExpired: allowExpired,
+		})
+	}
+
+	t.Run("deduct refuses stock from an expired batch", func(t *testing.T) {
+		item := newItem(t, "LOT-EXPIRED", shipDate.AddDate(0, 0, -2))
+
+		invRepo, err := deduct(t, item, false)
+
+		assert.Equal(t, "BATCH_EXPIRED", errorCode(t, err))
+		invRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deduct takes stock from a fresh batch", func(t *testing.T) {
+		item := newItem(t, "LOT-FRESH", shipDate.AddDate(0, 3, 0))
+
+		_, err := deduct(t, item, false)
+
+		require.NoError(t, err)
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(5)))
+		assert.True(t, item.TotalQuantity().Amount().Equal(decimal.NewFromInt(5)))
+	})
+
+	t.Run("deduct ships expired stock when the order allows it", func(t *testing.T) {
+		item := newItem(t, "LOT-EXPIRED", shipDate.AddDate(0, 0, -2))
+
+		_, err := deduct(t, item, true)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,244 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CorrectReceiptCost records a correction of the unit cost of a receipt.
+// Transactions are immutable, so the correction is a cost adjustment referencing the receipt;
+// correcting the same receipt again adjusts it from its last corrected cost.
+// The issues that followed the receipt keep their cost until RecalculateCostLayers is run
+// from the receipt's date.
+func (s *InventoryService) CorrectReceiptCost(ctx context.Context, tenantID, transactionID uuid.UUID, req CorrectReceiptCostRequest) (*TransactionResponse, error) {
+	if req.UnitCost.IsNegative() {
+		return nil, shared.NewDomainError("INVALID_COST", "Unit cost cannot be negative")
+	}
+
+	receipt, err := s.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.TenantID != tenantID {
+		return nil, shared.ErrNotFound
+	}
+	if !receipt.TransactionType.IsReceipt() {
+		return nil, shared.NewDomainError("NOT_A_RECEIPT", "Only the cost of a receipt can be corrected")
+	}
+
+	item, err := s.inventoryRepo.FindByID(ctx, receipt.InventoryItemID)
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := s.transactionRepo.FindLedgerByInventoryItem(ctx, tenantID, receipt.InventoryItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	corrections := collectCostAdjustments(ledger, inventory.SourceTypeCostCorrection)
+	currentCost := receipt.TotalCost.Add(corrections[receipt.ID])
+	delta := receipt.Quantity.Mul(req.UnitCost).Sub(currentCost)
+	if delta.IsZero() {
+		return nil, shared.NewDomainError("RECEIPT_COST_UNCHANGED", "The receipt already has this unit cost")
+	}
+
+	correction, err := inventory.CreateCostAdjustmentTransaction(
+		receipt,
+		delta,
+		item.AvailableQuantity.Amount(),
+		inventory.SourceTypeCostCorrection,
+		receipt.ID.String(),
+		req.Reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	correction.WithCostMethod(s.getStrategyNameForTenant(ctx, tenantID))
+	if receipt.Reference != "" {
+		correction.WithReference(receipt.Reference)
+	}
+	if req.OperatorID != nil {
+		correction.WithOperatorID(*req.OperatorID)
+	}
+
+	if err := s.transactionRepo.Create(ctx, correction); err != nil {
+		return nil, err
+	}
+
+	response := ToTransactionResponse(correction)
+	return &response, nil
+}
+
+// RecalculateCostLayers replays the transaction ledger of a product in a warehouse under the
+// tenant's cost strategy and restates the cost of the issues dated from fromDate on.
+//
+// Recalculation rules:
+//   - The whole ledger is replayed so the cost layers at fromDate are known; issues before
+//     fromDate keep their recorded cost
+//   - Receipts are received at their corrected cost (see CorrectReceiptCost)
+//   - Sales outbounds and decrease adjustments are issues; transfers keep the cost they carried
+//     to the other warehouse
+//   - Each issue whose replayed cost differs from its recorded cost, including earlier
+//     restatements, gets a cost adjustment for the difference, so running it again adds nothing
+//   - The item's unit cost is restated to the replayed cost of the stock on hand
+//
+// This replays every transaction of the item; use CostRecalculationQueue to run it in the background.
+func (s *InventoryService) RecalculateCostLayers(ctx context.Context, tenantID, productID, warehouseID uuid.UUID, fromDate time.Time) (*CostRecalculationResult, error) {
+	return s.recalculateCostLayers(ctx, tenantID, RecalculateCostLayersRequest{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		FromDate:    fromDate,
+	}, uuid.New(), nil)
+}
+
+// validateRecalculateCostLayersRequest checks a cost layer recalculation request
+func validateRecalculateCostLayersRequest(req RecalculateCostLayersRequest) error {
+	if req.WarehouseID == uuid.Nil {
+		return shared.NewDomainError("INVALID_WAREHOUSE", "Warehouse ID cannot be empty")
+	}
+	if req.ProductID == uuid.Nil {
+		return shared.NewDomainError("INVALID_PRODUCT", "Product ID cannot be empty")
+	}
+	if req.FromDate.IsZero() {
+		return shared.NewDomainError("INVALID_FROM_DATE", "Recalculation start date cannot be empty")
+	}
+	return nil
+}
+
+// recalculateCostLayers implements RecalculateCostLayers. The adjustments reference the run by runID,
+// and progress, if set, is called with the number of transactions replayed so far.
+func (s *InventoryService) recalculateCostLayers(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	req RecalculateCostLayersRequest,
+	runID uuid.UUID,
+	progress func(processed, total int),
+) (*CostRecalculationResult, error) {
+	if err := validateRecalculateCostLayersRequest(req); err != nil {
+		return nil, err
+	}
+
+	costMethod := s.getStrategyNameForTenant(ctx, tenantID)
+	reason := fmt.Sprintf("Cost restated from %s under %s", req.FromDate.Format("2006-01-02"), costMethod)
+
+	var result *CostRecalculationResult
+	var domainEvents []shared.DomainEvent
+
+	executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository) error {
+		item, err := invRepo.FindByWarehouseAndProduct(ctx, tenantID, req.WarehouseID, req.ProductID)
+		if err != nil {
+			return err
+		}
+		ledger, err := txRepo.FindLedgerByInventoryItem(ctx, tenantID, item.ID)
+		if err != nil {
+			return err
+		}
+
+		corrections := collectCostAdjustments(ledger, inventory.SourceTypeCostCorrection)
+		restated := collectCostAdjustments(ledger, inventory.SourceTypeCostRecalculation)
+		balance := item.AvailableQuantity.Amount()
+
+		result = &CostRecalculationResult{
+			InventoryItemID: item.ID,
+			CostMethod:      costMethod,
+			COGSDifference:  decimal.Zero,
+			UnitCostBefore:  item.UnitCost,
+			Adjustments:     []TransactionResponse{},
+		}
+		valuation := &stockValuation{inventoryItemID: item.ID, method: costMethod}
+		var adjustments []*inventory.InventoryTransaction
+
+		for i := range ledger {
+			tx := &ledger[i]
+			if progress != nil {
+				progress(i+1, len(ledger))
+			}
+			switch tx.TransactionType {
+			case inventory.TransactionTypeLock, inventory.TransactionTypeUnlock:
+				continue
+			case inventory.TransactionTypeCostIncrease, inventory.TransactionTypeCostDecrease:
+				continue
+			}
+
+			cost := valuation.apply(tx, corrections)
+			result.ReplayedTransactions++
+			if !tx.TransactionType.IsIssue() || tx.TransactionDate.Before(req.FromDate) {
+				continue
+			}
+
+			delta := cost.Sub(tx.TotalCost.Add(restated[tx.ID])).Round(2)
+			if delta.IsZero() {
+				continue
+			}
+			adjustment, err := inventory.CreateCostAdjustmentTransaction(
+				tx,
+				delta,
+				balance,
+				inventory.SourceTypeCostRecalculation,
+				runID.String(),
+				reason,
+			)
+			if err != nil {
+				return err
+			}
+			adjustment.WithCostMethod(costMethod)
+			adjustments = append(adjustments, adjustment)
+			result.RestatedIssues++
+			result.COGSDifference = result.COGSDifference.Add(delta)
+		}
+
+		// A ledger that does not add up to the stock on hand (e.g. stock loaded without
+		// transactions) cannot tell the cost of what is left
+		if !valuation.quantity.Equal(item.TotalQuantity().Amount()) {
+			return shared.NewDomainError("COST_LEDGER_MISMATCH",
+				fmt.Sprintf("The transaction ledger adds up to %s but %s is on hand", valuation.quantity, item.TotalQuantity().Amount()))
+		}
+
+		if len(adjustments) > 0 {
+			if err := txRepo.CreateBatch(ctx, adjustments); err != nil {
+				return err
+			}
+		}
+
+		if err := item.RestateUnitCost(valuation.unitCost()); err != nil {
+			return err
+		}
+		result.UnitCostAfter = item.UnitCost
+		if !result.UnitCostAfter.Equal(result.UnitCostBefore) {
+			if err := invRepo.SaveWithLock(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		domainEvents = item.GetDomainEvents()
+		item.ClearDomainEvents()
+		for _, adjustment := range adjustments {
+			result.Adjustments = append(result.Adjustments, ToTransactionResponse(adjustment))
+		}
+		return nil
+	}
+
+	var err error
+	if s.txScope != nil {
+		err = s.txScope.Execute(ctx, func(repos TransactionalRepositories) error {
+			return executeOperation(repos.InventoryRepo(), repos.TransactionRepo())
+		})
+	} else {
+		err = executeOperation(s.inventoryRepo, s.transactionRepo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.eventPublisher != nil && len(domainEvents) > 0 {
+		_ = s.eventPublisher.Publish(ctx, domainEvents...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,276 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Default cost recalculation queue sizing
+const (
+	DefaultCostRecalculationWorkers   = 1
+	DefaultCostRecalculationQueueSize = 50
+	// DefaultCostRecalculationRetention is how long finished jobs can still be polled
+	DefaultCostRecalculationRetention = 24 * time.Hour
+)
+
+// ErrCostRecalculationQueueFull is returned when a recalculation cannot be queued because the queue is full
+var ErrCostRecalculationQueueFull = errors.New("cost recalculation queue is full")
+
+// CostRecalculationJobStatus represents the status of a cost recalculation job
+type CostRecalculationJobStatus string
+
+const (
+	CostRecalculationJobStatusPending   CostRecalculationJobStatus = "PENDING"
+	CostRecalculationJobStatusRunning   CostRecalculationJobStatus = "RUNNING"
+	CostRecalculationJobStatusCompleted CostRecalculationJobStatus = "COMPLETED"
+	CostRecalculationJobStatusFailed    CostRecalculationJobStatus = "FAILED"
+)
+
+// CostRecalculationQueueConfig holds the configuration of a CostRecalculationQueue
+type CostRecalculationQueueConfig struct {
+	// Workers is the number of recalculations run concurrently
+	Workers int
+	// Size is the number of recalculations that can wait for a worker
+	Size int
+	// Retention is how long finished jobs are kept for polling
+	Retention time.Duration
+	Logger    *zap.Logger
+}
+
+// costRecalculationJob is a recalculation queued or run by the queue
+type costRecalculationJob struct {
+	id       uuid.UUID
+	tenantID uuid.UUID
+	// ctx is the context of the enqueuing request without its cancellation,
+	// so the recalculation keeps the request's tenant and user
+	ctx         context.Context
+	req         RecalculateCostLayersRequest
+	status      CostRecalculationJobStatus
+	processed   int
+	total       int
+	result      *CostRecalculationResult
+	err         string
+	createdAt   time.Time
+	startedAt   *time.Time
+	completedAt *time.Time
+}
+
+func (j *costRecalculationJob) finished() bool {
+	return j.status == CostRecalculationJobStatusCompleted || j.status == CostRecalculationJobStatusFailed
+}
+
+func (j *costRecalculationJob) toResponse() *CostRecalculationJobResponse {
+	return &CostRecalculationJobResponse{
+		ID:          j.id,
+		Status:      string(j.status),
+		WarehouseID: j.req.WarehouseID,
+		ProductID:   j.req.ProductID,
+		FromDate:    j.req.FromDate,
+		Processed:   j.processed,
+		Total:       j.total,
+		Result:      j.result,
+		Error:       j.err,
+		CreatedAt:   j.createdAt,
+		StartedAt:   j.startedAt,
+		CompletedAt: j.completedAt,
+	}
+}
+
+// CostRecalculationQueue runs InventoryService.RecalculateCostLayers in the background, since
+// replaying the ledger of a busy product takes long. Clients enqueue a recalculation and poll
+// it with GetJob, which reports how many transactions were replayed, until it is COMPLETED or FAILED.
+//
+// Jobs are kept in memory only: jobs still queued when the server stops are dropped and have to
+// be started again, which is safe because a recalculation only adds what is still missing.
+// Only one recalculation of a product in a warehouse can be queued or running at a time.
+type CostRecalculationQueue struct {
+	service   *InventoryService
+	queue     chan uuid.UUID
+	workers   int
+	retention time.Duration
+	logger    *zap.Logger
+
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*costRecalculationJob
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewCostRecalculationQueue creates a new CostRecalculationQueue running recalculations with the given InventoryService
+func NewCostRecalculationQueue(service *InventoryService, cfg CostRecalculationQueueConfig) *CostRecalculationQueue {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = DefaultCostRecalculationWorkers
+	}
+	size := cfg.Size
+	if size <= 0 {
+		size = DefaultCostRecalculationQueueSize
+	}
+	retention := cfg.Retention
+	if retention <= 0 {
+		retention = DefaultCostRecalculationRetention
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &CostRecalculationQueue{
+		service:   service,
+		queue:     make(chan uuid.UUID, size),
+		workers:   workers,
+		retention: retention,
+		logger:    logger,
+		jobs:      make(map[uuid.UUID]*costRecalculationJob),
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start starts the workers
+func (q *CostRecalculationQueue) Start() {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	q.logger.Info("Cost recalculation queue started",
+		zap.Int("workers", q.workers),
+		zap.Int("size", cap(q.queue)),
+	)
+}
+
+// Stop stops the workers, waiting for the running recalculations to finish or ctx to be done.
+// A recalculation that is cut short rolls back with its transaction.
+func (q *CostRecalculationQueue) Stop(ctx context.Context) error {
+	q.once.Do(func() { close(q.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Enqueue queues a recalculation and returns its job without waiting for it to run.
+// Returns ErrCostRecalculationQueueFull if the queue has no room, and a
+// COST_RECALCULATION_IN_PROGRESS error if the product is already being recalculated.
+func (q *CostRecalculationQueue) Enqueue(ctx context.Context, tenantID uuid.UUID, req RecalculateCostLayersRequest) (*CostRecalculationJobResponse, error) {
+	if err := validateRecalculateCostLayersRequest(req); err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pruneLocked(time.Now())
+	for _, job := range q.jobs {
+		if !job.finished() && job.tenantID == tenantID &&
+			job.req.WarehouseID == req.WarehouseID && job.req.ProductID == req.ProductID {
+			return nil, shared.NewDomainError("COST_RECALCULATION_IN_PROGRESS", "The costs of this product are already being recalculated")
+		}
+	}
+
+	job := &costRecalculationJob{
+		id:        uuid.New(),
+		tenantID:  tenantID,
+		ctx:       context.WithoutCancel(ctx),
+		req:       req,
+		status:    CostRecalculationJobStatusPending,
+		createdAt: time.Now(),
+	}
+	select {
+	case q.queue <- job.id:
+	default:
+		q.logger.Warn("cost recalculation queue is full",
+			zap.String("productId", req.ProductID.String()),
+			zap.String("warehouseId", req.WarehouseID.String()),
+		)
+		return nil, ErrCostRecalculationQueueFull
+	}
+	q.jobs[job.id] = job
+
+	return job.toResponse(), nil
+}
+
+// GetJob returns a recalculation job of the tenant
+func (q *CostRecalculationQueue) GetJob(tenantID, jobID uuid.UUID) (*CostRecalculationJobResponse, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[jobID]
+	if !ok || job.tenantID != tenantID {
+		return nil, shared.ErrNotFound
+	}
+	return job.toResponse(), nil
+}
+
+// pruneLocked forgets jobs that finished longer than the retention ago. Callers hold q.mu.
+func (q *CostRecalculationQueue) pruneLocked(now time.Time) {
+	for id, job := range q.jobs {
+		if job.finished() && job.completedAt != nil && now.Sub(*job.completedAt) > q.retention {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// work runs queued recalculations until the queue is stopped
+func (q *CostRecalculationQueue) work() {
+	defer q.wg.Done()
+	for {
+		select {
+		case <-q.stop:
+			return
+		case jobID := <-q.queue:
+			q.process(jobID)
+		}
+	}
+}
+
+// process runs a queued recalculation. Failures are recorded on the job, which the client polls.
+func (q *CostRecalculationQueue) process(jobID uuid.UUID) {
+	q.mu.Lock()
+	job, ok := q.jobs[jobID]
+	if !ok || job.status != CostRecalculationJobStatusPending {
+		q.mu.Unlock()
+		return
+	}
+	startedAt := time.Now()
+	job.status = CostRecalculationJobStatusRunning
+	job.startedAt = &startedAt
+	ctx, tenantID, req := job.ctx, job.tenantID, job.req
+	q.mu.Unlock()
+
+	progress := func(processed, total int) {
+		q.mu.Lock()
+		job.processed = processed
+		job.total = total
+		q.mu.Unlock()
+	}
+	result, err := q.service.recalculateCostLayers(ctx, tenantID, req, jobID, progress)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	completedAt := time.Now()
+	job.completedAt = &completedAt
+	if err != nil {
+		q.logger.Warn("cost recalculation failed", zap.String("jobId", jobID.String()), zap.Error(err))
+		job.status = CostRecalculationJobStatusFailed
+		job.err = err.Error()
+		return
+	}
+	job.status = CostRecalculationJobStatusCompleted
+	job.result = result
+}
@@ -0,0 +1,290 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// weightedAverageLedger is a moving-average product whose first receipt was booked at 10 instead of 13:
+//
+//	t1 receive 10 @ 10  -> average 10
+//	t2 issue    5 @ 10  -> 50
+//	t3 receive 10 @ 16  -> average (5*10 + 10*16) / 15 = 14
+//	t4 issue    6 @ 14  -> 84, leaving 9 on hand at 14
+//
+// At the correct cost of 13 the issues cost 65 and 6 * (5*13 + 10*16) / 15 = 90.
+type weightedAverageLedger struct {
+	item               *inventory.InventoryItem
+	firstReceipt       inventory.InventoryTransaction
+	firstIssue         inventory.InventoryTransaction
+	secondIssue        inventory.InventoryTransaction
+	transactions       []inventory.InventoryTransaction
+	t1, t2, t3, t4     time.Time
+	correctedUnitCost  decimal.Decimal
+	correctedCostDelta decimal.Decimal
+}
+
+func newWeightedAverageLedger(t *testing.T) *weightedAverageLedger {
+	l := &weightedAverageLedger{
+		item:               createTestInventoryItemWithStock(uuid.New(), uuid.New(), uuid.New(), decimal.NewFromInt(9), decimal.Zero),
+		t1:                 time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		correctedUnitCost:  decimal.NewFromInt(13),
+		correctedCostDelta: decimal.NewFromInt(30),
+	}
+	l.item.UnitCost = decimal.NewFromInt(14)
+	l.t2 = l.t1.Add(24 * time.Hour)
+	l.t3 = l.t2.Add(24 * time.Hour)
+	l.t4 = l.t3.Add(24 * time.Hour)
+
+	l.firstReceipt = newSnapshotTestTransaction(t, l.item, inventory.TransactionTypeInbound, 10, 10, l.t1)
+	l.firstIssue = newSnapshotTestTransaction(t, l.item, inventory.TransactionTypeOutbound, 5, 10, l.t2)
+	l.secondIssue = newSnapshotTestTransaction(t, l.item, inventory.TransactionTypeOutbound, 6, 14, l.t4)
+	l.transactions = []inventory.InventoryTransaction{
+		l.firstReceipt,
+		l.firstIssue,
+		newSnapshotTestTransaction(t, l.item, inventory.TransactionTypeInbound, 10, 16, l.t3),
+		newSnapshotTestTransaction(t, l.item, inventory.TransactionTypeLock, 6, 14, l.t3),
+		l.secondIssue,
+	}
+	return l
+}
+
+// withCorrection returns the ledger with the first receipt corrected to 13
+func (l *weightedAverageLedger) withCorrection(t *testing.T) []inventory.InventoryTransaction {
+	correction, err := inventory.CreateCostAdjustmentTransaction(
+		&l.firstReceipt, l.correctedCostDelta, decimal.NewFromInt(9),
+		inventory.SourceTypeCostCorrection, l.firstReceipt.ID.String(), "Wrong invoice price",
+	)
+	require.NoError(t, err)
+	correction.TransactionDate = l.t4.Add(time.Hour)
+	return append(append([]inventory.InventoryTransaction{}, l.transactions...), *correction)
+}
+
+// newRecalculationService serves the ledger and captures the adjustments it creates
+func newRecalculationService(ctx context.Context, item *inventory.InventoryItem, ledger []inventory.InventoryTransaction, created *[]*inventory.InventoryTransaction) (*InventoryService, *MockInventoryItemRepository, *MockTransactionRepository) {
+	invRepo := new(MockInventoryItemRepository)
+	txRepo := new(MockTransactionRepository)
+	invRepo.On("FindByWarehouseAndProduct", ctx, item.TenantID, item.WarehouseID, item.ProductID).Return(item, nil)
+	invRepo.On("SaveWithLock", ctx, item).Return(nil)
+	txRepo.On("FindLedgerByInventoryItem", ctx, item.TenantID, item.ID).Return(ledger, nil)
+	txRepo.On("CreateBatch", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		*created = append(*created, args.Get(1).([]*inventory.InventoryTransaction)...)
+	}).Return(nil)
+
+	return NewInventoryService(invRepo, nil, new(MockStockLockRepository), txRepo), invRepo, txRepo
+}
+
+// adjustmentFor returns the signed cost adjustment created for a transaction
+func adjustmentFor(t *testing.T, adjustments []*inventory.InventoryTransaction, adjusted uuid.UUID) decimal.Decimal {
+	for _, adjustment := range adjustments {
+		if adjustment.SourceLineID == adjusted.String() {
+			return adjustment.GetSignedTotalCost()
+		}
+	}
+	t.Fatalf("no adjustment for transaction %s", adjusted)
+	return decimal.Zero
+}
+
+func TestInventoryService_CorrectReceiptCost(t *testing.T) {
+	ctx := context.Background()
+	l := newWeightedAverageLedger(t)
+
+	invRepo := new(MockInventoryItemRepository)
+	txRepo := new(MockTransactionRepository)
+	invRepo.On("FindByID", ctx, l.item.ID).Return(l.item, nil)
+	txRepo.On("FindByID", ctx, l.firstReceipt.ID).Return(&l.firstReceipt, nil)
+	txRepo.On("FindByID", ctx, l.firstIssue.ID).Return(&l.firstIssue, nil)
+	txRepo.On("FindLedgerByInventoryItem", ctx, l.item.TenantID, l.item.ID).Return(l.transactions, nil)
+	var correction *inventory.InventoryTransaction
+	txRepo.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		correction = args.Get(1).(*inventory.InventoryTransaction)
+	}).Return(nil)
+	service := NewInventoryService(invRepo, nil, new(MockStockLockRepository), txRepo)
+
+	t.Run("records the cost difference against the receipt", func(t *testing.T) {
+		response, err := service.CorrectReceiptCost(ctx, l.item.TenantID, l.firstReceipt.ID, CorrectReceiptCostRequest{
+			UnitCost: l.correctedUnitCost,
+			Reason:   "Wrong invoice price",
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, correction)
+		assert.Equal(t, inventory.TransactionTypeCostIncrease, correction.TransactionType)
+		assert.Equal(t, inventory.SourceTypeCostCorrection, correction.SourceType)
+		assert.Equal(t, l.firstReceipt.ID.String(), correction.SourceLineID)
+		assert.True(t, l.correctedCostDelta.Equal(correction.TotalCost), "got %s", correction.TotalCost)
+		assert.True(t, correction.GetSignedQuantity().IsZero(), "a cost correction moves no stock")
+		assert.Equal(t, correction.ID, response.ID)
+	})
+
+	t.Run("only receipts can be corrected", func(t *testing.T) {
+		_, err := service.CorrectReceiptCost(ctx, l.item.TenantID, l.firstIssue.ID, CorrectReceiptCostRequest{UnitCost: decimal.NewFromInt(13)})
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_A_RECEIPT", domainErr.Code)
+	})
+
+	t.Run("other tenants' receipts are not found", func(t *testing.T) {
+		_, err := service.CorrectReceiptCost(ctx, uuid.New(), l.firstReceipt.ID, CorrectReceiptCostRequest{UnitCost: decimal.NewFromInt(13)})
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
+
+func TestInventoryService_RecalculateCostLayers_WeightedAverage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("early receipt correction restates the later issues", func(t *testing.T) {
+		l := newWeightedAverageLedger(t)
+		var created []*inventory.InventoryTransaction
+		service, invRepo, _ := newRecalculationService(ctx, l.item, l.withCorrection(t), &created)
+
+		result, err := service.RecalculateCostLayers(ctx, l.item.TenantID, l.item.ProductID, l.item.WarehouseID, l.t1)
+		require.NoError(t, err)
+
+		require.Len(t, created, 2)
+		assert.True(t, decimal.NewFromInt(15).Equal(adjustmentFor(t, created, l.firstIssue.ID)), "5 * (13 - 10)")
+		assert.True(t, decimal.NewFromInt(6).Equal(adjustmentFor(t, created, l.secondIssue.ID)), "6 * (15 - 14)")
+		for _, adjustment := range created {
+			assert.Equal(t, inventory.TransactionTypeCostIncrease, adjustment.TransactionType)
+			assert.Equal(t, inventory.SourceTypeCostRecalculation, adjustment.SourceType)
+		}
+
+		assert.Equal(t, "moving_average", result.CostMethod)
+		assert.Equal(t, 2, result.RestatedIssues)
+		assert.Len(t, result.Adjustments, 2)
+		assert.True(t, decimal.NewFromInt(21).Equal(result.COGSDifference), "got %s", result.COGSDifference)
+		assert.True(t, decimal.NewFromInt(14).Equal(result.UnitCostBefore))
+		assert.True(t, decimal.NewFromInt(15).Equal(result.UnitCostAfter), "got %s", result.UnitCostAfter)
+		assert.True(t, decimal.NewFromInt(15).Equal(l.item.UnitCost))
+		invRepo.AssertCalled(t, "SaveWithLock", ctx, l.item)
+	})
+
+	t.Run("issues before the start date keep their cost", func(t *testing.T) {
+		l := newWeightedAverageLedger(t)
+		var created []*inventory.InventoryTransaction
+		service, _, _ := newRecalculationService(ctx, l.item, l.withCorrection(t), &created)
+
+		result, err := service.RecalculateCostLayers(ctx, l.item.TenantID, l.item.ProductID, l.item.WarehouseID, l.t3)
+		require.NoError(t, err)
+
+		require.Len(t, created, 1)
+		assert.True(t, decimal.NewFromInt(6).Equal(adjustmentFor(t, created, l.secondIssue.ID)))
+		assert.True(t, decimal.NewFromInt(6).Equal(result.COGSDifference))
+		assert.True(t, decimal.NewFromInt(15).Equal(result.UnitCostAfter), "the cost on hand still reflects the correction")
+	})
+
+	t.Run("running it again adds nothing", func(t *testing.T) {
+		l := newWeightedAverageLedger(t)
+		var created []*inventory.InventoryTransaction
+		service, _, _ := newRecalculationService(ctx, l.item, l.withCorrection(t), &created)
+		_, err := service.RecalculateCostLayers(ctx, l.item.TenantID, l.item.ProductID, l.item.WarehouseID, l.t1)
+		require.NoError(t, err)
+
+		ledger := l.withCorrection(t)
+		for _, adjustment := range created {
+			ledger = append(ledger, *adjustment)
+		}
+		var createdAgain []*inventory.InventoryTransaction
+		service, invRepo, txRepo := newRecalculationService(ctx, l.item, ledger, &createdAgain)
+
+		result, err := service.RecalculateCostLayers(ctx, l.item.TenantID, l.item.ProductID, l.item.WarehouseID, l.t1)
+		require.NoError(t, err)
+
+		assert.Empty(t, createdAgain)
+		assert.Equal(t, 0, result.RestatedIssues)
+		assert.True(t, result.COGSDifference.IsZero())
+		txRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+		invRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ledger that does not match the stock on hand is refused", func(t *testing.T) {
+		l := newWeightedAverageLedger(t)
+		l.item.AvailableQuantity = inventory.MustNewInventoryQuantity(decimal.NewFromInt(20))
+		var created []*inventory.InventoryTransaction
+		service, _, _ := newRecalculationService(ctx, l.item, l.withCorrection(t), &created)
+
+		_, err := service.RecalculateCostLayers(ctx, l.item.TenantID, l.item.ProductID, l.item.WarehouseID, l.t1)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "COST_LEDGER_MISMATCH", domainErr.Code)
+		assert.Empty(t, created)
+	})
+}
+
+// recalculationRequestKey marks the context of the request that enqueued a recalculation
+type recalculationRequestKey struct{}
+
+func TestCostRecalculationQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs in the background and reports progress", func(t *testing.T) {
+		l := newWeightedAverageLedger(t)
+		ledger := l.withCorrection(t)
+		var created []*inventory.InventoryTransaction
+		// The job runs with the enqueuing request's context, tenant included
+		reqCtx, cancel := context.WithCancel(context.WithValue(ctx, recalculationRequestKey{}, "request"))
+		service, _, _ := newRecalculationService(context.WithoutCancel(reqCtx), l.item, ledger, &created)
+		queue := NewCostRecalculationQueue(service, CostRecalculationQueueConfig{})
+		queue.Start()
+		defer func() { _ = queue.Stop(ctx) }()
+
+		job, err := queue.Enqueue(reqCtx, l.item.TenantID, RecalculateCostLayersRequest{
+			WarehouseID: l.item.WarehouseID,
+			ProductID:   l.item.ProductID,
+			FromDate:    l.t1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, string(CostRecalculationJobStatusPending), job.Status)
+		// The request ending does not cancel the recalculation
+		cancel()
+
+		var polled *CostRecalculationJobResponse
+		require.Eventually(t, func() bool {
+			polled, err = queue.GetJob(l.item.TenantID, job.ID)
+			require.NoError(t, err)
+			return polled.Status == string(CostRecalculationJobStatusCompleted)
+		}, 5*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, len(ledger), polled.Total)
+		assert.Equal(t, len(ledger), polled.Processed)
+		require.NotNil(t, polled.Result)
+		assert.True(t, decimal.NewFromInt(21).Equal(polled.Result.COGSDifference))
+		require.Len(t, created, 2)
+		assert.Equal(t, job.ID.String(), created[0].SourceID, "adjustments reference the job")
+
+		_, err = queue.GetJob(uuid.New(), job.ID)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+
+	t.Run("one recalculation per product at a time", func(t *testing.T) {
+		// Without workers the jobs stay queued
+		queue := NewCostRecalculationQueue(NewInventoryService(nil, nil, nil, nil), CostRecalculationQueueConfig{Size: 2})
+		tenantID := uuid.New()
+		req := RecalculateCostLayersRequest{WarehouseID: uuid.New(), ProductID: uuid.New(), FromDate: time.Now()}
+
+		_, err := queue.Enqueue(ctx, tenantID, req)
+		require.NoError(t, err)
+		_, err = queue.Enqueue(ctx, tenantID, req)
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "COST_RECALCULATION_IN_PROGRESS", domainErr.Code)
+
+		req.ProductID = uuid.New()
+		_, err = queue.Enqueue(ctx, tenantID, req)
+		require.NoError(t, err)
+		req.ProductID = uuid.New()
+		_, err = queue.Enqueue(ctx, tenantID, req)
+		assert.ErrorIs(t, err, ErrCostRecalculationQueueFull)
+	})
+}
@@ -74,6 +74,11 @@ type IncreaseStockRequest struct {
 	Reference   string          `json:"reference"`
 	Reason      string          `json:"reason"`
 	OperatorID  *uuid.UUID      `json:"operator_id"`
+	// SerialNumbers lists one serial number per unit received; required for serialized products
+	SerialNumbers []string `json:"serial_numbers"`
+	// SkipSerialNumbers receives serialized units without serial numbers. It is set by the
+	// order event handlers, whose documents do not carry serial numbers.
+	SkipSerialNumbers bool `json:"-"`
 }
 
 // LockStockRequest represents a request to lock stock
@@ -110,6 +115,19 @@ type DeductStockRequest struct {
 	SourceID   string     `json:"source_id" binding:"required"`
 	Reference  string     `json:"reference"`
 	OperatorID *uuid.UUID `json:"operator_id"`
+	// Quantity deducts only part of the lock, leaving the rest locked; the whole lock when nil
+	Quantity *decimal.Decimal `json:"quantity"`
+	// BatchStrategy selects the batches the stock is taken from: FIFO (default) or FEFO
+	BatchStrategy string `json:"batch_strategy"`
+	// SerialNumbers lists one serial number per unit shipped; required for serialized products
+	SerialNumbers []string `json:"serial_numbers"`
+	// SkipSerialNumbers ships serialized units without serial numbers. It is set by the
+	// order event handlers, whose documents do not carry serial numbers.
+	SkipSerialNumbers bool `json:"-"`
+	// ShipDate is the date batch expiry is checked against; now when nil
+	ShipDate *time.Time `json:"ship_date"`
+	// AllowExpired skips blocking batches that expire before the ship date plus the tenant's buffer
+	AllowExpired bool `json:"allow_expired"`
 }
 
 // DecreaseStockRequest represents a request to directly decrease available stock
@@ -123,6 +141,37 @@ type DecreaseStockRequest struct {
 	Reference   string          `json:"reference"`
 	Reason      string          `json:"reason"`
 	OperatorID  *uuid.UUID      `json:"operator_id"`
+	// SerialNumbers lists one serial number per unit removed; required for serialized products
+	SerialNumbers []string `json:"serial_numbers"`
+	// SkipSerialNumbers removes serialized units without serial numbers. It is set by the
+	// return event handlers, whose documents do not carry serial numbers.
+	SkipSerialNumbers bool `json:"-"`
+}
+
+// TransferStockRequest represents a request to transfer stock between warehouses
+type TransferStockRequest struct {
+	FromWarehouseID uuid.UUID       `json:"from_warehouse_id" binding:"required"`
+	ToWarehouseID   uuid.UUID       `json:"to_warehouse_id" binding:"required"`
+	ProductID       uuid.UUID       `json:"product_id" binding:"required"`
+	Quantity        decimal.Decimal `json:"quantity" binding:"required"`
+	BatchID         *uuid.UUID      `json:"batch_id"` // Transfers from this batch and carries its batch number across; required for batch-tracked stock
+	Reference       string          `json:"reference"`
+	Reason          string          `json:"reason"`
+	OperatorID      *uuid.UUID      `json:"operator_id"`
+	// SerialNumbers lists one serial number per unit transferred; required for serialized products
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+// TransferStockResponse represents the result of a stock transfer
+type TransferStockResponse struct {
+	TransferID          uuid.UUID             `json:"transfer_id"`
+	Quantity            decimal.Decimal       `json:"quantity"`
+	UnitCost            decimal.Decimal       `json:"unit_cost"`
+	BatchNumber         string                `json:"batch_number,omitempty"`
+	OutboundTransaction TransactionResponse   `json:"outbound_transaction"`
+	InboundTransaction  TransactionResponse   `json:"inbound_transaction"`
+	Source              InventoryItemResponse `json:"source"`
+	Destination         InventoryItemResponse `json:"destination"`
 }
 
 // AdjustStockRequest represents a request to adjust stock
@@ -133,6 +182,7 @@ type AdjustStockRequest struct {
 	Reason         string          `json:"reason" binding:"required,min=1,max=255"`
 	SourceType     string          `json:"source_type"` // defaults to MANUAL_ADJUSTMENT
 	SourceID       string          `json:"source_id"`   // auto-generated if empty
+	Reference      string          `json:"reference"`
 	OperatorID     *uuid.UUID      `json:"operator_id"`
 }
 
@@ -200,6 +250,14 @@ type TransactionListFilter struct {
 	PageSize        int        `form:"page_size" binding:"omitempty,min=1,max=100"`
 	OrderBy         string     `form:"order_by"`
 	OrderDir        string     `form:"order_dir" binding:"omitempty,oneof=asc desc"`
+	// Pagination is "offset" (default) or "cursor"; Cursor is the next_cursor of the previous page
+	Pagination string `form:"pagination" binding:"omitempty,oneof=offset cursor"`
+	Cursor     string `form:"cursor"`
+}
+
+// UsesCursor reports whether the request asked for cursor pagination
+func (f TransactionListFilter) UsesCursor() bool {
+	return f.Pagination == "cursor" || f.Cursor != ""
 }
 
 // InventorySummaryResponse represents inventory summary statistics
@@ -220,6 +278,153 @@ type WarehouseSummary struct {
 	BelowMinimum int64           `json:"below_minimum"`
 }
 
+// ReorderSuggestionsResponse represents draft purchase order suggestions for a warehouse
+type ReorderSuggestionsResponse struct {
+	WarehouseID uuid.UUID                   `json:"warehouse_id"`
+	Suppliers   []SupplierReorderSuggestion `json:"suppliers"`
+	LineCount   int                         `json:"line_count"`
+}
+
+// SupplierReorderSuggestion groups the suggested purchase order lines for one supplier.
+// SupplierID is nil for products that have never been purchased.
+type SupplierReorderSuggestion struct {
+	SupplierID   *uuid.UUID              `json:"supplier_id,omitempty"`
+	SupplierName string                  `json:"supplier_name,omitempty"`
+	Lines        []ReorderSuggestionLine `json:"lines"`
+	TotalAmount  decimal.Decimal         `json:"total_amount"`
+}
+
+// ReorderSuggestionLine represents a suggested purchase order line for an item below its minimum threshold
+type ReorderSuggestionLine struct {
+	ProductID         uuid.UUID       `json:"product_id"`
+	ProductCode       string          `json:"product_code"`
+	ProductName       string          `json:"product_name"`
+	CurrentQuantity   decimal.Decimal `json:"current_quantity"`   // Available + locked, in base units
+	MinQuantity       decimal.Decimal `json:"min_quantity"`       // Minimum threshold, in base units
+	MaxQuantity       decimal.Decimal `json:"max_quantity"`       // Maximum threshold, in base units
+	IncomingQuantity  decimal.Decimal `json:"incoming_quantity"`  // Still to be received on open purchase orders, in base units
+	ShortageQuantity  decimal.Decimal `json:"shortage_quantity"`  // Quantity needed to reach the target level, in base units
+	BaseUnit          string          `json:"base_unit"`          // Base unit code
+	Unit              string          `json:"unit"`               // Purchase unit code
+	ConversionRate    decimal.Decimal `json:"conversion_rate"`    // Base units per purchase unit
+	SuggestedQuantity decimal.Decimal `json:"suggested_quantity"` // Quantity to order, in purchase units
+	UnitCost          decimal.Decimal `json:"unit_cost"`          // Cost per purchase unit
+	Amount            decimal.Decimal `json:"amount"`             // SuggestedQuantity * UnitCost
+}
+
+// InventorySnapshotResponse represents the reconstructed inventory of a warehouse as of a point in time
+type InventorySnapshotResponse struct {
+	WarehouseID   uuid.UUID               `json:"warehouse_id"`
+	AsOf          time.Time               `json:"as_of"`
+	CostMethod    string                  `json:"cost_method"` // Cost strategy used for valuation
+	Items         []InventorySnapshotItem `json:"items"`
+	TotalQuantity decimal.Decimal         `json:"total_quantity"`
+	TotalValue    decimal.Decimal         `json:"total_value"`
+}
+
+// InventorySnapshotItem represents the on-hand quantity and value of one product as of the snapshot time
+type InventorySnapshotItem struct {
+	ProductID         uuid.UUID       `json:"product_id"`
+	InventoryItemID   uuid.UUID       `json:"inventory_item_id"`
+	Quantity          decimal.Decimal `json:"quantity"`  // On-hand (available + locked)
+	UnitCost          decimal.Decimal `json:"unit_cost"` // Average cost of the quantity on hand
+	TotalValue        decimal.Decimal `json:"total_value"`
+	LastTransactionAt *time.Time      `json:"last_transaction_at,omitempty"`
+}
+
+// CorrectReceiptCostRequest represents a request to correct the unit cost of a receipt
+type CorrectReceiptCostRequest struct {
+	UnitCost   decimal.Decimal `json:"unit_cost" binding:"required"` // Corrected cost per unit
+	Reason     string          `json:"reason" binding:"required,min=1,max=255"`
+	OperatorID *uuid.UUID      `json:"operator_id"`
+}
+
+// RecalculateCostLayersRequest represents a request to recalculate the costs of a product in a warehouse
+type RecalculateCostLayersRequest struct {
+	WarehouseID uuid.UUID `json:"warehouse_id" binding:"required"`
+	ProductID   uuid.UUID `json:"product_id" binding:"required"`
+	FromDate    time.Time `json:"from_date" binding:"required"` // Issues from this date on are restated
+}
+
+// CostRecalculationResult summarizes a cost layer recalculation
+type CostRecalculationResult struct {
+	InventoryItemID      uuid.UUID             `json:"inventory_item_id"`
+	CostMethod           string                `json:"cost_method"` // Cost strategy the ledger was replayed under
+	ReplayedTransactions int                   `json:"replayed_transactions"`
+	RestatedIssues       int                   `json:"restated_issues"` // Issues whose cost changed
+	COGSDifference       decimal.Decimal       `json:"cogs_difference"` // Positive when the restated issues cost more
+	UnitCostBefore       decimal.Decimal       `json:"unit_cost_before"`
+	UnitCostAfter        decimal.Decimal       `json:"unit_cost_after"`
+	Adjustments          []TransactionResponse `json:"adjustments"`
+}
+
+// CostRecalculationJobResponse represents a cost layer recalculation running in the background
+type CostRecalculationJobResponse struct {
+	ID          uuid.UUID                `json:"id"`
+	Status      string                   `json:"status"`
+	WarehouseID uuid.UUID                `json:"warehouse_id"`
+	ProductID   uuid.UUID                `json:"product_id"`
+	FromDate    time.Time                `json:"from_date"`
+	Processed   int                      `json:"processed"` // Transactions replayed so far
+	Total       int                      `json:"total"`     // Transactions to replay, known once running
+	Result      *CostRecalculationResult `json:"result,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	StartedAt   *time.Time               `json:"started_at,omitempty"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+}
+
+// SerialNumberResponse represents a serialized unit with its movement history
+type SerialNumberResponse struct {
+	ID              uuid.UUID                      `json:"id"`
+	ProductID       uuid.UUID                      `json:"product_id"`
+	InventoryItemID uuid.UUID                      `json:"inventory_item_id"`
+	WarehouseID     uuid.UUID                      `json:"warehouse_id"`
+	SerialNumber    string                         `json:"serial_number"`
+	Status          string                         `json:"status"`
+	Movements       []SerialNumberMovementResponse `json:"movements"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	UpdatedAt       time.Time                      `json:"updated_at"`
+}
+
+// SerialNumberMovementResponse represents a serialized unit entering or leaving stock
+type SerialNumberMovementResponse struct {
+	ID              uuid.UUID `json:"id"`
+	MovementType    string    `json:"movement_type"`
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	WarehouseID     uuid.UUID `json:"warehouse_id"`
+	SourceType      string    `json:"source_type"`
+	SourceID        string    `json:"source_id"`
+	OccurredAt      time.Time `json:"occurred_at"`
+}
+
+// ToSerialNumberResponse converts a domain SerialNumber and its movements to a response DTO
+func ToSerialNumberResponse(sn *inventory.SerialNumber, movements []inventory.SerialNumberMovement) SerialNumberResponse {
+	response := SerialNumberResponse{
+		ID:              sn.ID,
+		ProductID:       sn.ProductID,
+		InventoryItemID: sn.InventoryItemID,
+		WarehouseID:     sn.WarehouseID,
+		SerialNumber:    sn.SerialNumber,
+		Status:          string(sn.Status),
+		Movements:       make([]SerialNumberMovementResponse, len(movements)),
+		CreatedAt:       sn.CreatedAt,
+		UpdatedAt:       sn.UpdatedAt,
+	}
+	for i, mv := range movements {
+		response.Movements[i] = SerialNumberMovementResponse{
+			ID:              mv.ID,
+			MovementType:    string(mv.MovementType),
+			InventoryItemID: mv.InventoryItemID,
+			WarehouseID:     mv.WarehouseID,
+			SourceType:      string(mv.SourceType),
+			SourceID:        mv.SourceID,
+			OccurredAt:      mv.OccurredAt,
+		}
+	}
+	return response
+}
+
 // ToInventoryItemResponse converts domain InventoryItem to response DTO
 func ToInventoryItemResponse(item *inventory.InventoryItem) InventoryItemResponse {
 	return InventoryItemResponse{
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/partner"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/strategy"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
@@ -32,6 +34,12 @@ type CostStrategyProvider interface {
 	GetCostStrategyOrDefault(name string) strategy.CostCalculationStrategy
 }
 
+// TenantStrategyResolver resolves the strategies a tenant chose in its strategy configuration
+type TenantStrategyResolver interface {
+	// TenantStrategyName returns the strategy of a type the tenant chose, or false if it has not chosen one
+	TenantStrategyName(ctx context.Context, tenantID uuid.UUID, strategyType strategy.StrategyType) (string, bool)
+}
+
 // CostStrategyResolverAdapter adapts a CostStrategyProvider to the domain's CostStrategyResolver interface.
 // This allows existing CostStrategyProvider implementations to work with the new domain service API.
 type CostStrategyResolverAdapter struct {
@@ -75,9 +83,43 @@ type InventoryService struct {
 	transactionRepo  inventory.InventoryTransactionRepository
 	tenantRepo       identity.TenantRepository
 	strategyProvider CostStrategyProvider
+	tenantStrategies TenantStrategyResolver
 	eventPublisher   shared.EventPublisher
 	txScope          TransactionScope
 	domainService    *inventory.InventoryDomainService
+
+	// Optional readers for reorder suggestions
+	reorderOrderReader ReorderPurchaseOrderReader
+	reorderUnitReader  ReorderProductUnitReader
+
+	// Optional reader for the warehouse negative-stock policy
+	warehouseReader WarehouseReader
+
+	// Optional near-capacity alerts for received stock
+	loadReader        WarehouseLoadReader
+	capacityThreshold decimal.Decimal
+
+	// Optional serial number tracking for serialized products
+	productReader    SerializedProductReader
+	serialNumberRepo inventory.SerialNumberRepository
+
+	// Optional reader for the tenant's ship expiry buffer
+	expiryTenantReader ExpiryTenantReader
+
+	businessMetrics *telemetry.BusinessMetrics
+}
+
+// WarehouseReader provides the warehouses whose negative-stock policy is consulted
+// when a stock-out would drive on-hand below zero
+type WarehouseReader interface {
+	// FindByIDForTenant finds a warehouse by ID within a tenant
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Warehouse, error)
+}
+
+// WarehouseLoadReader measures the on-hand stock of a warehouse in a capacity unit
+type WarehouseLoadReader interface {
+	// SumLoadByWarehouse sums the on-hand stock in a warehouse measured in the given unit
+	SumLoadByWarehouse(ctx context.Context, tenantID, warehouseID uuid.UUID, unit partner.CapacityUnit) (decimal.Decimal, error)
 }
 
 // NewInventoryService creates a new InventoryService
@@ -145,6 +187,12 @@ func (s *InventoryService) SetTenantRepository(repo identity.TenantRepository) {
 	s.tenantRepo = repo
 }
 
+// SetTenantStrategyResolver sets the resolver of the tenants' strategy configurations (optional).
+// A cost strategy a tenant chose there takes precedence over the one in its tenant config.
+func (s *InventoryService) SetTenantStrategyResolver(resolver TenantStrategyResolver) {
+	s.tenantStrategies = resolver
+}
+
 // SetStrategyProvider sets the strategy provider (optional, for cost calculation).
 // This also initializes the domain service with a strategy resolver that wraps the provider.
 func (s *InventoryService) SetStrategyProvider(provider CostStrategyProvider) {
@@ -163,6 +211,89 @@ func (s *InventoryService) SetTransactionScope(scope TransactionScope) {
 	s.txScope = scope
 }
 
+// SetWarehouseReader sets the warehouse reader (optional, for the negative-stock policy).
+// Without it, no warehouse allows negative stock.
+func (s *InventoryService) SetWarehouseReader(reader WarehouseReader) {
+	s.warehouseReader = reader
+}
+
+// SetCapacityMonitor enables near-capacity alerts (optional, requires the warehouse reader).
+// When received stock pushes a warehouse's utilization to thresholdPercent or above,
+// a WarehouseNearCapacityEvent is published.
+func (s *InventoryService) SetCapacityMonitor(reader WarehouseLoadReader, thresholdPercent float64) {
+	s.loadReader = reader
+	s.capacityThreshold = decimal.NewFromFloat(thresholdPercent)
+}
+
+// SetBusinessMetrics sets the business metrics collector
+func (s *InventoryService) SetBusinessMetrics(bm *telemetry.BusinessMetrics) {
+	s.businessMetrics = bm
+}
+
+// recordAdjustment counts a committed adjustment that changed the stock by delta
+func (s *InventoryService) recordAdjustment(ctx context.Context, tenantID, warehouseID uuid.UUID, delta decimal.Decimal) {
+	if s.businessMetrics == nil || delta.IsZero() {
+		return
+	}
+	direction := telemetry.AdjustmentDirectionIncrease
+	if delta.IsNegative() {
+		direction = telemetry.AdjustmentDirectionDecrease
+	}
+	s.businessMetrics.RecordInventoryAdjustment(ctx, tenantID, warehouseID, direction)
+}
+
+// allowsNegativeStock reports whether stock in the warehouse may go below zero
+func (s *InventoryService) allowsNegativeStock(ctx context.Context, tenantID, warehouseID uuid.UUID) (bool, error) {
+	if s.warehouseReader == nil {
+		return false, nil
+	}
+	warehouse, err := s.warehouseReader.FindByIDForTenant(ctx, tenantID, warehouseID)
+	if err != nil {
+		return false, err
+	}
+	return warehouse.AllowNegativeStock, nil
+}
+
+// capacityCheck is the load of a warehouse before stock is received into it
+type capacityCheck struct {
+	warehouse  *partner.Warehouse
+	usedBefore decimal.Decimal
+}
+
+// beginCapacityCheck measures the warehouse's load before a receipt.
+// It returns nil when alerts are disabled or the warehouse has no capacity;
+// a failed lookup skips the alert rather than failing the receipt.
+func (s *InventoryService) beginCapacityCheck(ctx context.Context, tenantID, warehouseID uuid.UUID) *capacityCheck {
+	if s.loadReader == nil || s.warehouseReader == nil {
+		return nil
+	}
+	warehouse, err := s.warehouseReader.FindByIDForTenant(ctx, tenantID, warehouseID)
+	if err != nil || !warehouse.HasCapacity() {
+		return nil
+	}
+	used, err := s.loadReader.SumLoadByWarehouse(ctx, tenantID, warehouseID, warehouse.CapacityUnit)
+	if err != nil {
+		return nil
+	}
+	return &capacityCheck{warehouse: warehouse, usedBefore: used}
+}
+
+// finishCapacityCheck publishes a near-capacity event if the receipt pushed
+// the warehouse's utilization over the threshold
+func (s *InventoryService) finishCapacityCheck(ctx context.Context, check *capacityCheck) {
+	if check == nil || s.eventPublisher == nil {
+		return
+	}
+	w := check.warehouse
+	usedAfter, err := s.loadReader.SumLoadByWarehouse(ctx, w.TenantID, w.ID, w.CapacityUnit)
+	if err != nil {
+		return
+	}
+	if event := w.NearCapacityEvent(check.usedBefore, usedAfter, s.capacityThreshold); event != nil {
+		_ = s.eventPublisher.Publish(ctx, event)
+	}
+}
+
 // getDomainService returns the inventory domain service, creating one if not already set.
 // This ensures the domain service is always available for cost calculations.
 func (s *InventoryService) getDomainService() *inventory.InventoryDomainService {
@@ -183,6 +314,13 @@ func (s *InventoryService) getDomainService() *inventory.InventoryDomainService
 // This method only performs orchestration: looking up tenant config and mapping to strategy name.
 // The actual strategy resolution and fallback is handled by the domain service.
 func (s *InventoryService) getStrategyNameForTenant(ctx context.Context, tenantID uuid.UUID) string {
+	// Strategy chosen in the tenant's strategy configuration
+	if s.tenantStrategies != nil {
+		if name, ok := s.tenantStrategies.TenantStrategyName(ctx, tenantID, strategy.StrategyTypeCost); ok {
+			return name
+		}
+	}
+
 	// Default strategy name
 	strategyName := inventory.DefaultCostStrategyName
 
@@ -386,12 +524,22 @@ func (s *InventoryService) IncreaseStock(ctx context.Context, tenantID uuid.UUID
 		batchInfo = inventory.NewBatchInfo(req.BatchNumber, nil, req.ExpiryDate)
 	}
 
+	// Serialized products must list one serial number per unit received
+	serialNumbers, err := s.serialNumbersFor(ctx, tenantID, req.ProductID, req.Quantity, req.SerialNumbers, req.SkipSerialNumbers)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
 	// Get strategy name from tenant configuration (application layer orchestration)
 	strategyName := s.getStrategyNameForTenant(ctx, tenantID)
 
 	// Get domain service (handles strategy resolution and fallback internally)
 	domainService := s.getDomainService()
 
+	// Measure the warehouse before receiving, to detect it filling up
+	capacity := s.beginCapacityCheck(ctx, tenantID, req.WarehouseID)
+
 	var response *InventoryItemResponse
 	var domainEvents []shared.DomainEvent
 	var costMethod string
@@ -400,7 +548,7 @@ func (s *InventoryService) IncreaseStock(ctx context.Context, tenantID uuid.UUID
 	var operationErr error
 	telemetry.WithProfilingLabels(ctx, telemetry.InventoryOperationLabels(telemetry.OperationIncreaseStock, ""), func(c context.Context) {
 		// Core operation function that can be executed within a transaction
-		executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository) error {
+		executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository, serialRepo inventory.SerialNumberRepository) error {
 			// Get or create inventory item
 			item, err := invRepo.GetOrCreate(c, tenantID, req.WarehouseID, req.ProductID)
 			if err != nil {
@@ -424,6 +572,11 @@ func (s *InventoryService) IncreaseStock(ctx context.Context, tenantID uuid.UUID
 				return err
 			}
 
+			// Register the received serial numbers
+			if err := receiveSerialNumbers(c, serialRepo, item, serialNumbers, sourceType, req.SourceID); err != nil {
+				return err
+			}
+
 			// Capture domain events for publishing after transaction commits
 			domainEvents = item.GetDomainEvents()
 			item.ClearDomainEvents()
@@ -471,10 +624,10 @@ func (s *InventoryService) IncreaseStock(ctx context.Context, tenantID uuid.UUID
 		// Execute with or without transaction scope
 		if s.txScope != nil {
 			operationErr = s.txScope.Execute(c, func(repos TransactionalRepositories) error {
-				return executeOperation(repos.InventoryRepo(), repos.TransactionRepo())
+				return executeOperation(repos.InventoryRepo(), repos.TransactionRepo(), repos.SerialNumberRepo())
 			})
 		} else {
-			operationErr = executeOperation(s.inventoryRepo, s.transactionRepo)
+			operationErr = executeOperation(s.inventoryRepo, s.transactionRepo, s.serialNumberRepo)
 		}
 	})
 
@@ -487,6 +640,7 @@ func (s *InventoryService) IncreaseStock(ctx context.Context, tenantID uuid.UUID
 	if s.eventPublisher != nil && len(domainEvents) > 0 {
 		_ = s.eventPublisher.Publish(ctx, domainEvents...)
 	}
+	s.finishCapacityCheck(ctx, capacity)
 
 	// Add success event to span
 	telemetry.AddEvent(span, "stock_increased",
@@ -762,13 +916,31 @@ func (s *InventoryService) DeductStock(ctx context.Context, tenantID uuid.UUID,
 		return err
 	}
 
+	// Resolve the batch outbound strategy (FIFO unless specified)
+	outbound, err := batchOutboundStrategy(req.BatchStrategy)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	// Stock must stay good until the ship date plus the tenant's expiry buffer
+	shipDate := time.Now()
+	if req.ShipDate != nil {
+		shipDate = *req.ShipDate
+	}
+	sellableUntil, err := s.sellableUntil(ctx, tenantID, shipDate)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
 	var domainEvents []shared.DomainEvent
 
 	// Wrap in profiling labels for performance analysis
 	var operationErr error
 	telemetry.WithProfilingLabels(ctx, telemetry.InventoryOperationLabels(telemetry.OperationDeductStock, ""), func(c context.Context) {
 		// Core operation function that can be executed within a transaction
-		executeOperation := func(invRepo inventory.InventoryItemRepository, lockRepo inventory.StockLockRepository, txRepo inventory.InventoryTransactionRepository) error {
+		executeOperation := func(invRepo inventory.InventoryItemRepository, lockRepo inventory.StockLockRepository, txRepo inventory.InventoryTransactionRepository, serialRepo inventory.SerialNumberRepository) error {
 			// Find the lock
 			lock, err := lockRepo.FindByID(c, req.LockID)
 			if err != nil {
@@ -786,6 +958,45 @@ func (s *InventoryService) DeductStock(ctx context.Context, tenantID uuid.UUID,
 				return shared.NewDomainError("FORBIDDEN", "Lock does not belong to this tenant")
 			}
 
+			// Deduct the whole lock unless a partial quantity is given
+			quantity := lock.Quantity
+			if req.Quantity != nil {
+				quantity = *req.Quantity
+			}
+
+			// Deducting more than the lock takes the excess from available stock, which
+			// may only go below zero in warehouses that allow negative stock
+			deduct := item.DeductStockPartial
+			if quantity.Sub(lock.Quantity).GreaterThan(item.AvailableQuantity.Amount()) {
+				allowNegative, err := s.allowsNegativeStock(c, tenantID, item.WarehouseID)
+				if err != nil {
+					return err
+				}
+				if !allowNegative {
+					return shared.ErrInsufficientStock
+				}
+				deduct = item.DeductStockAllowNegative
+			}
+
+			// Serialized products must list one serial number per unit shipped
+			serialNumbers, err := s.serialNumbersFor(c, tenantID, item.ProductID, quantity, req.SerialNumbers, req.SkipSerialNumbers)
+			if err != nil {
+				return err
+			}
+
+			// Take the quantity out of the item's batches while the item still holds it,
+			// refusing batches that expire too soon unless expired stock is allowed
+			if len(item.Batches) > 0 {
+				if req.AllowExpired {
+					_, err = item.ConsumeBatches(quantity, outbound)
+				} else {
+					_, err = item.ConsumeSellableBatches(quantity, outbound, sellableUntil)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
 			// Add lock to item's Locks slice so domain method can find it
 			// (Repository doesn't preload associations)
 			item.Locks = append(item.Locks, *lock)
@@ -794,7 +1005,7 @@ func (s *InventoryService) DeductStock(ctx context.Context, tenantID uuid.UUID,
 			lockedBefore := item.LockedQuantity.Amount()
 
 			// Deduct stock
-			if err := item.DeductStock(req.LockID); err != nil {
+			if err := deduct(req.LockID, quantity); err != nil {
 				return err
 			}
 
@@ -808,7 +1019,8 @@ func (s *InventoryService) DeductStock(ctx context.Context, tenantID uuid.UUID,
 			item.ClearDomainEvents()
 
 			// Update the lock record (find by ID, not by position - Locks[0] assumption is incorrect)
-			// The domain method marks the lock as Consumed in item.Locks
+			// The domain method marks the lock as Consumed in item.Locks, or reduces its quantity
+			// when only part of it was deducted
 			var consumedLock *inventory.StockLock
 			for idx := range item.Locks {
 				if item.Locks[idx].ID == req.LockID {
@@ -823,13 +1035,18 @@ func (s *InventoryService) DeductStock(ctx context.Context, tenantID uuid.UUID,
 				return err
 			}
 
+			// Mark the shipped serial numbers
+			if err := shipSerialNumbers(c, serialRepo, item, serialNumbers, sourceType, req.SourceID); err != nil {
+				return err
+			}
+
 			// Create transaction record for the deduction (outbound)
 			tx, err := inventory.CreateOutboundTransaction(
 				tenantID,
 				item.ID,
 				item.WarehouseID,
 				item.ProductID,
-				lock.Quantity,
+				quantity,
 				item.UnitCost,
 				lockedBefore,
 				item.LockedQuantity.Amount(),
@@ -855,10 +1072,10 @@ func (s *InventoryService) DeductStock(ctx context.Context, tenantID uuid.UUID,
 		// Execute with or without transaction scope
 		if s.txScope != nil {
 			operationErr = s.txScope.Execute(c, func(repos TransactionalRepositories) error {
-				return executeOperation(repos.InventoryRepo(), repos.LockRepo(), repos.TransactionRepo())
+				return executeOperation(repos.InventoryRepo(), repos.LockRepo(), repos.TransactionRepo(), repos.SerialNumberRepo())
 			})
 		} else {
-			operationErr = executeOperation(s.inventoryRepo, s.lockRepo, s.transactionRepo)
+			operationErr = executeOperation(s.inventoryRepo, s.lockRepo, s.transactionRepo, s.serialNumberRepo)
 		}
 	})
 
@@ -880,6 +1097,19 @@ func (s *InventoryService) DeductStock(ctx context.Context, tenantID uuid.UUID,
 	return nil
 }
 
+// batchOutboundStrategy resolves the batch outbound strategy selected for a deduction.
+// Only the automatic FIFO and FEFO strategies can be selected; FIFO is used when none is given.
+func batchOutboundStrategy(name string) (inventory.BatchOutboundStrategy, error) {
+	switch inventory.BatchOutboundStrategyType(strings.ToUpper(name)) {
+	case "", inventory.BatchOutboundStrategyTypeFIFO:
+		return inventory.NewFIFOBatchOutboundStrategy(), nil
+	case inventory.BatchOutboundStrategyTypeFEFO:
+		return inventory.NewFEFOBatchOutboundStrategy(), nil
+	default:
+		return nil, shared.NewDomainError("INVALID_BATCH_STRATEGY", "Batch strategy must be FIFO or FEFO")
+	}
+}
+
 // This is used for operations like purchase returns where goods are shipped back to supplier
 func (s *InventoryService) DecreaseStock(ctx context.Context, tenantID uuid.UUID, req DecreaseStockRequest) error {
 	// Start tracing span for stock decrease flow
@@ -902,13 +1132,20 @@ func (s *InventoryService) DecreaseStock(ctx context.Context, tenantID uuid.UUID
 		return err
 	}
 
+	// Serialized products must list one serial number per unit removed
+	serialNumbers, err := s.serialNumbersFor(ctx, tenantID, req.ProductID, req.Quantity, req.SerialNumbers, req.SkipSerialNumbers)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
 	var domainEvents []shared.DomainEvent
 
 	// Wrap in profiling labels for performance analysis
 	var operationErr error
 	telemetry.WithProfilingLabels(ctx, telemetry.InventoryOperationLabels(telemetry.OperationDecreaseStock, ""), func(c context.Context) {
 		// Core operation function that can be executed within a transaction
-		executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository) error {
+		executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository, serialRepo inventory.SerialNumberRepository) error {
 			// Get inventory item
 			item, err := invRepo.FindByWarehouseAndProduct(c, tenantID, req.WarehouseID, req.ProductID)
 			if err != nil {
@@ -918,8 +1155,21 @@ func (s *InventoryService) DecreaseStock(ctx context.Context, tenantID uuid.UUID
 			// Record balance before
 			balanceBefore := item.AvailableQuantity.Amount()
 
+			// Going below zero is only allowed in warehouses that allow negative stock
+			decrease := item.DecreaseStock
+			if balanceBefore.LessThan(req.Quantity) {
+				allowNegative, err := s.allowsNegativeStock(c, tenantID, req.WarehouseID)
+				if err != nil {
+					return err
+				}
+				if !allowNegative {
+					return shared.ErrInsufficientStock
+				}
+				decrease = item.DecreaseStockAllowNegative
+			}
+
 			// Decrease stock
-			if err := item.DecreaseStock(req.Quantity, req.SourceType, req.SourceID, req.Reason); err != nil {
+			if err := decrease(req.Quantity, req.SourceType, req.SourceID, req.Reason); err != nil {
 				return err
 			}
 
@@ -928,6 +1178,11 @@ func (s *InventoryService) DecreaseStock(ctx context.Context, tenantID uuid.UUID
 				return err
 			}
 
+			// Mark the removed serial numbers
+			if err := shipSerialNumbers(c, serialRepo, item, serialNumbers, sourceType, req.SourceID); err != nil {
+				return err
+			}
+
 			// Capture domain events for publishing after transaction commits
 			domainEvents = item.GetDomainEvents()
 			item.ClearDomainEvents()
@@ -966,10 +1221,10 @@ func (s *InventoryService) DecreaseStock(ctx context.Context, tenantID uuid.UUID
 		// Execute with or without transaction scope
 		if s.txScope != nil {
 			operationErr = s.txScope.Execute(c, func(repos TransactionalRepositories) error {
-				return executeOperation(repos.InventoryRepo(), repos.TransactionRepo())
+				return executeOperation(repos.InventoryRepo(), repos.TransactionRepo(), repos.SerialNumberRepo())
 			})
 		} else {
-			operationErr = executeOperation(s.inventoryRepo, s.transactionRepo)
+			operationErr = executeOperation(s.inventoryRepo, s.transactionRepo, s.serialNumberRepo)
 		}
 	})
 
@@ -991,95 +1246,202 @@ func (s *InventoryService) DecreaseStock(ctx context.Context, tenantID uuid.UUID
 	return nil
 }
 
-// AdjustStock adjusts the stock to match actual quantity
-func (s *InventoryService) AdjustStock(ctx context.Context, tenantID uuid.UUID, req AdjustStockRequest) (*InventoryItemResponse, error) {
-	// Start tracing span for stock adjustment flow
-	ctx, span := telemetry.StartServiceSpan(ctx, "inventory", "adjust_stock")
+// TransferStock moves available stock of a product from one warehouse to another.
+//
+// The source item is decreased and the destination item increased in a single transaction,
+// recording a TRANSFER_OUT and a TRANSFER_IN transaction that share the transfer ID as source ID.
+// The transferred stock keeps the source's unit cost (or the batch's when a batch is given), and a
+// batch transfer creates a batch with the same batch number and dates in the destination.
+// Insufficient stock is detected before anything is written.
+func (s *InventoryService) TransferStock(ctx context.Context, tenantID uuid.UUID, req TransferStockRequest) (*TransferStockResponse, error) {
+	// Start tracing span for stock transfer flow
+	ctx, span := telemetry.StartServiceSpan(ctx, "inventory", "transfer_stock")
 	defer span.End()
 
 	telemetry.SetAttributes(span,
-		"warehouse_id", req.WarehouseID.String(),
+		"from_warehouse_id", req.FromWarehouseID.String(),
+		"to_warehouse_id", req.ToWarehouseID.String(),
 		"product_id", req.ProductID.String(),
-		"target_quantity", req.ActualQuantity.String(),
-		"reason", req.Reason,
+		telemetry.SpanAttrQuantity, req.Quantity.String(),
 	)
 
-	// Determine source type and ID upfront
-	sourceType := inventory.SourceTypeManualAdjustment
-	if req.SourceType != "" {
-		st := inventory.SourceType(req.SourceType)
-		if st.IsValid() {
-			sourceType = st
-		}
+	if req.FromWarehouseID == req.ToWarehouseID {
+		err := shared.NewDomainError("INVALID_TRANSFER", "Source and destination warehouses must be different")
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
-	sourceID := req.SourceID
-	if sourceID == "" {
-		sourceID = fmt.Sprintf("ADJ-%s", time.Now().Format("20060102150405"))
+	if !req.Quantity.IsPositive() {
+		err := shared.NewDomainError("INVALID_QUANTITY", "Quantity must be positive")
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
 
-	var response *InventoryItemResponse
+	// Serialized products must list one serial number per unit transferred
+	serialNumbers, err := s.serialNumbersFor(ctx, tenantID, req.ProductID, req.Quantity, req.SerialNumbers, false)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	transferID := uuid.New()
+	strategyName := s.getStrategyNameForTenant(ctx, tenantID)
+	domainService := s.getDomainService()
+
+	var response *TransferStockResponse
 	var domainEvents []shared.DomainEvent
 
 	// Wrap in profiling labels for performance analysis
 	var operationErr error
-	telemetry.WithProfilingLabels(ctx, telemetry.InventoryOperationLabels(telemetry.OperationAdjustStock, ""), func(c context.Context) {
+	telemetry.WithProfilingLabels(ctx, telemetry.InventoryOperationLabels(telemetry.OperationTransferStock, ""), func(c context.Context) {
 		// Core operation function that can be executed within a transaction
-		executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository) error {
-			// Get or create inventory item
-			item, err := invRepo.GetOrCreate(c, tenantID, req.WarehouseID, req.ProductID)
+		executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository, serialRepo inventory.SerialNumberRepository) error {
+			from, err := invRepo.FindByWarehouseAndProduct(c, tenantID, req.FromWarehouseID, req.ProductID)
 			if err != nil {
+				if errors.Is(err, shared.ErrNotFound) {
+					return shared.NewDomainError("INSUFFICIENT_STOCK", "No stock of this product in the source warehouse")
+				}
 				return err
 			}
 
-			// Record balance before
-			balanceBefore := item.AvailableQuantity.Amount()
+			// Take the stock out of the source first; this validates quantities before any write
+			fromBalanceBefore := from.AvailableQuantity.Amount()
+			batchInfo, unitCost, err := from.TransferOut(req.Quantity, req.BatchID, transferID.String())
+			if err != nil {
+				return err
+			}
 
-			// Adjust stock
-			if err := item.AdjustStock(req.ActualQuantity, req.Reason); err != nil {
+			to, err := invRepo.GetOrCreate(c, tenantID, req.ToWarehouseID, req.ProductID)
+			if err != nil {
+				return err
+			}
+			toBalanceBefore := to.AvailableQuantity.Amount()
+			if _, err := domainService.StockInWithStrategyName(c, to, req.Quantity, valueobject.NewMoneyCNY(unitCost), batchInfo, strategyName); err != nil {
 				return err
 			}
 
-			// Save with optimistic locking
-			if err := invRepo.SaveWithLock(c, item); err != nil {
+			if err := invRepo.SaveWithLock(c, from); err != nil {
+				return err
+			}
+			if err := invRepo.SaveWithLock(c, to); err != nil {
+				return err
+			}
+
+			// Move the transferred serial numbers to the destination warehouse
+			if err := transferSerialNumbers(c, serialRepo, from, to, serialNumbers, transferID.String()); err != nil {
 				return err
 			}
 
 			// Capture domain events for publishing after transaction commits
-			domainEvents = item.GetDomainEvents()
-			item.ClearDomainEvents()
+			domainEvents = append(from.GetDomainEvents(), to.GetDomainEvents()...)
+			from.ClearDomainEvents()
+			to.ClearDomainEvents()
 
-			// Calculate adjustment quantity (absolute value)
-			adjustmentQty := req.ActualQuantity.Sub(balanceBefore).Abs()
-			if !adjustmentQty.IsZero() {
-				// Create transaction record
-				tx, err := inventory.CreateAdjustmentTransaction(
-					tenantID,
-					item.ID,
-					req.WarehouseID,
-					req.ProductID,
-					adjustmentQty,
-					item.UnitCost,
-					balanceBefore,
-					item.AvailableQuantity.Amount(),
-					sourceType,
-					sourceID,
-					req.Reason,
-				)
-				if err == nil {
-					if req.OperatorID != nil {
-						tx.WithOperatorID(*req.OperatorID)
-					}
-					if err := txRepo.Create(c, tx); err != nil {
-						return err
-					}
+			outTx, inTx, err := inventory.CreateTransferTransactions(
+				tenantID,
+				req.ProductID,
+				from,
+				to,
+				req.Quantity,
+				unitCost,
+				fromBalanceBefore,
+				toBalanceBefore,
+				transferID.String(),
+			)
+			if err != nil {
+				return err
+			}
+			if req.BatchID != nil {
+				outTx.WithBatchID(*req.BatchID)
+				if len(to.Batches) > 0 {
+					inTx.WithBatchID(to.Batches[len(to.Batches)-1].ID)
+				}
+			}
+			for _, tx := range []*inventory.InventoryTransaction{outTx, inTx} {
+				if req.Reference != "" {
+					tx.WithReference(req.Reference)
+				}
+				if req.Reason != "" {
+					tx.WithReason(req.Reason)
+				}
+				if req.OperatorID != nil {
+					tx.WithOperatorID(*req.OperatorID)
 				}
 			}
+			if err := txRepo.CreateBatch(c, []*inventory.InventoryTransaction{outTx, inTx}); err != nil {
+				return err
+			}
 
-			resp := ToInventoryItemResponse(item)
-			response = &resp
+			response = &TransferStockResponse{
+				TransferID:          transferID,
+				Quantity:            req.Quantity,
+				UnitCost:            unitCost,
+				OutboundTransaction: ToTransactionResponse(outTx),
+				InboundTransaction:  ToTransactionResponse(inTx),
+				Source:              ToInventoryItemResponse(from),
+				Destination:         ToInventoryItemResponse(to),
+			}
+			if batchInfo != nil {
+				response.BatchNumber = batchInfo.BatchNumber
+			}
 			return nil
 		}
 
+		// Execute with or without transaction scope
+		if s.txScope != nil {
+			operationErr = s.txScope.Execute(c, func(repos TransactionalRepositories) error {
+				return executeOperation(repos.InventoryRepo(), repos.TransactionRepo(), repos.SerialNumberRepo())
+			})
+		} else {
+			operationErr = executeOperation(s.inventoryRepo, s.transactionRepo, s.serialNumberRepo)
+		}
+	})
+
+	if operationErr != nil {
+		telemetry.RecordError(span, operationErr)
+		return nil, operationErr
+	}
+
+	// Publish domain events after successful transaction commit
+	if s.eventPublisher != nil && len(domainEvents) > 0 {
+		_ = s.eventPublisher.Publish(ctx, domainEvents...)
+	}
+
+	// Add success event to span
+	telemetry.AddEvent(span, "stock_transferred",
+		"transfer_id", transferID.String(),
+		telemetry.SpanAttrQuantity, req.Quantity.String(),
+	)
+
+	return response, nil
+}
+
+// AdjustStock adjusts the stock to match actual quantity
+func (s *InventoryService) AdjustStock(ctx context.Context, tenantID uuid.UUID, req AdjustStockRequest) (*InventoryItemResponse, error) {
+	// Start tracing span for stock adjustment flow
+	ctx, span := telemetry.StartServiceSpan(ctx, "inventory", "adjust_stock")
+	defer span.End()
+
+	telemetry.SetAttributes(span,
+		"warehouse_id", req.WarehouseID.String(),
+		"product_id", req.ProductID.String(),
+		"target_quantity", req.ActualQuantity.String(),
+		"reason", req.Reason,
+	)
+
+	var response *InventoryItemResponse
+	var domainEvents []shared.DomainEvent
+	var delta decimal.Decimal
+
+	// Wrap in profiling labels for performance analysis
+	var operationErr error
+	telemetry.WithProfilingLabels(ctx, telemetry.InventoryOperationLabels(telemetry.OperationAdjustStock, ""), func(c context.Context) {
+		// Core operation function that can be executed within a transaction
+		executeOperation := func(invRepo inventory.InventoryItemRepository, txRepo inventory.InventoryTransactionRepository) error {
+			var err error
+			response, domainEvents, delta, err = s.adjustStock(c, invRepo, txRepo, tenantID, req)
+			return err
+		}
+
 		// Execute with or without transaction scope
 		if s.txScope != nil {
 			operationErr = s.txScope.Execute(c, func(repos TransactionalRepositories) error {
@@ -1099,6 +1461,7 @@ func (s *InventoryService) AdjustStock(ctx context.Context, tenantID uuid.UUID,
 	if s.eventPublisher != nil && len(domainEvents) > 0 {
 		_ = s.eventPublisher.Publish(ctx, domainEvents...)
 	}
+	s.recordAdjustment(ctx, tenantID, req.WarehouseID, delta)
 
 	// Add success event to span
 	telemetry.AddEvent(span, "stock_adjusted",
@@ -1108,6 +1471,122 @@ func (s *InventoryService) AdjustStock(ctx context.Context, tenantID uuid.UUID,
 	return response, nil
 }
 
+// AdjustStockInTransaction adjusts the stock to match actual quantity using the repositories
+// of a transaction opened by the caller, so the adjustment commits or rolls back with it.
+// The domain events are returned instead of published; the caller publishes them after commit.
+// The adjustment is not counted in the business metrics; the caller records it after commit.
+func (s *InventoryService) AdjustStockInTransaction(ctx context.Context, repos TransactionalRepositories, tenantID uuid.UUID, req AdjustStockRequest) (*InventoryItemResponse, []shared.DomainEvent, error) {
+	response, events, _, err := s.adjustStock(ctx, repos.InventoryRepo(), repos.TransactionRepo(), tenantID, req)
+	return response, events, err
+}
+
+// adjustStock adjusts an item to the actual quantity and records the adjustment transaction.
+// Returns the change in available quantity alongside the adjusted item.
+func (s *InventoryService) adjustStock(
+	ctx context.Context,
+	invRepo inventory.InventoryItemRepository,
+	txRepo inventory.InventoryTransactionRepository,
+	tenantID uuid.UUID,
+	req AdjustStockRequest,
+) (*InventoryItemResponse, []shared.DomainEvent, decimal.Decimal, error) {
+	// An adjustment does not say which units were found or lost, so the stock of serialized
+	// products is corrected by receiving or removing units by serial number instead
+	serialized, err := s.isSerialized(ctx, tenantID, req.ProductID)
+	if err != nil {
+		return nil, nil, decimal.Zero, err
+	}
+	if serialized {
+		return nil, nil, decimal.Zero, shared.NewDomainError("SERIALIZED_PRODUCT",
+			"Stock of a serialized product cannot be adjusted; receive or decrease it by serial number")
+	}
+
+	// Determine source type and ID upfront
+	sourceType := inventory.SourceTypeManualAdjustment
+	if req.SourceType != "" {
+		st := inventory.SourceType(req.SourceType)
+		if st.IsValid() {
+			sourceType = st
+		}
+	}
+	sourceID := req.SourceID
+	if sourceID == "" {
+		sourceID = fmt.Sprintf("ADJ-%s", time.Now().Format("20060102150405"))
+	}
+
+	// A negative actual quantity is only allowed in warehouses that allow negative stock
+	allowNegative := false
+	if req.ActualQuantity.IsNegative() {
+		var err error
+		allowNegative, err = s.allowsNegativeStock(ctx, tenantID, req.WarehouseID)
+		if err != nil {
+			return nil, nil, decimal.Zero, err
+		}
+		if !allowNegative {
+			return nil, nil, decimal.Zero, shared.ErrInsufficientStock
+		}
+	}
+
+	// Get or create inventory item
+	item, err := invRepo.GetOrCreate(ctx, tenantID, req.WarehouseID, req.ProductID)
+	if err != nil {
+		return nil, nil, decimal.Zero, err
+	}
+
+	// Record balance before
+	balanceBefore := item.AvailableQuantity.Amount()
+
+	// Adjust stock
+	adjust := item.AdjustStock
+	if allowNegative {
+		adjust = item.AdjustStockAllowNegative
+	}
+	if err := adjust(req.ActualQuantity, req.Reason); err != nil {
+		return nil, nil, decimal.Zero, err
+	}
+
+	// Save with optimistic locking
+	if err := invRepo.SaveWithLock(ctx, item); err != nil {
+		return nil, nil, decimal.Zero, err
+	}
+
+	// Capture domain events for publishing after transaction commits
+	domainEvents := item.GetDomainEvents()
+	item.ClearDomainEvents()
+
+	// Calculate adjustment quantity (absolute value)
+	adjustmentQty := req.ActualQuantity.Sub(balanceBefore).Abs()
+	if !adjustmentQty.IsZero() {
+		// Create transaction record
+		tx, err := inventory.CreateAdjustmentTransaction(
+			tenantID,
+			item.ID,
+			req.WarehouseID,
+			req.ProductID,
+			adjustmentQty,
+			item.UnitCost,
+			balanceBefore,
+			item.AvailableQuantity.Amount(),
+			sourceType,
+			sourceID,
+			req.Reason,
+		)
+		if err == nil {
+			if req.Reference != "" {
+				tx.WithReference(req.Reference)
+			}
+			if req.OperatorID != nil {
+				tx.WithOperatorID(*req.OperatorID)
+			}
+			if err := txRepo.Create(ctx, tx); err != nil {
+				return nil, nil, decimal.Zero, err
+			}
+		}
+	}
+
+	response := ToInventoryItemResponse(item)
+	return &response, domainEvents, item.AvailableQuantity.Amount().Sub(balanceBefore), nil
+}
+
 // SetThresholds sets min/max quantity thresholds for an inventory item
 func (s *InventoryService) SetThresholds(ctx context.Context, tenantID uuid.UUID, req SetThresholdsRequest) (*InventoryItemResponse, error) {
 	// Get or create inventory item
@@ -1247,7 +1726,60 @@ func (s *InventoryService) ListTransactions(ctx context.Context, tenantID uuid.U
 		filter.OrderDir = "desc"
 	}
 
-	// Build domain filter
+	domainFilter, err := buildTransactionFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get transactions
+	txs, err := s.transactionRepo.FindForTenant(ctx, tenantID, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get total count
+	total, err := s.transactionRepo.CountForTenant(ctx, tenantID, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ToTransactionResponses(txs), total, nil
+}
+
+// ListTransactionsByCursor retrieves one keyset page of inventory transactions, newest first by default.
+// It returns the cursor for the next page, or "" on the last page, and skips the total count.
+func (s *InventoryService) ListTransactionsByCursor(ctx context.Context, tenantID uuid.UUID, filter TransactionListFilter) ([]TransactionResponse, string, error) {
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	if filter.OrderDir == "" {
+		filter.OrderDir = "desc"
+	}
+
+	domainFilter, err := buildTransactionFilter(filter)
+	if err != nil {
+		return nil, "", err
+	}
+	domainFilter.Keyset = true
+	if filter.Cursor != "" {
+		if domainFilter.After, err = shared.DecodeCursor(filter.Cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	txs, err := s.transactionRepo.FindForTenant(ctx, tenantID, domainFilter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	txs, nextCursor := shared.NextCursor(txs, filter.PageSize, domainFilter.OrderDir, func(tx inventory.InventoryTransaction) (time.Time, uuid.UUID) {
+		return tx.TransactionDate, tx.ID
+	})
+	return ToTransactionResponses(txs), nextCursor, nil
+}
+
+// buildTransactionFilter converts the transaction list request into a repository filter
+func buildTransactionFilter(filter TransactionListFilter) (shared.Filter, error) {
 	domainFilter := shared.Filter{
 		Page:     filter.Page,
 		PageSize: filter.PageSize,
@@ -1260,14 +1792,14 @@ func (s *InventoryService) ListTransactions(ctx context.Context, tenantID uuid.U
 	if filter.WarehouseID != "" {
 		warehouseID, err := uuid.Parse(filter.WarehouseID)
 		if err != nil {
-			return nil, 0, errors.New("invalid warehouse_id format")
+			return shared.Filter{}, errors.New("invalid warehouse_id format")
 		}
 		domainFilter.Filters["warehouse_id"] = warehouseID
 	}
 	if filter.ProductID != "" {
 		productID, err := uuid.Parse(filter.ProductID)
 		if err != nil {
-			return nil, 0, errors.New("invalid product_id format")
+			return shared.Filter{}, errors.New("invalid product_id format")
 		}
 		domainFilter.Filters["product_id"] = productID
 	}
@@ -1287,19 +1819,7 @@ func (s *InventoryService) ListTransactions(ctx context.Context, tenantID uuid.U
 		domainFilter.Filters["end_date"] = *filter.EndDate
 	}
 
-	// Get transactions
-	txs, err := s.transactionRepo.FindForTenant(ctx, tenantID, domainFilter)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	// Get total count
-	total, err := s.transactionRepo.CountForTenant(ctx, tenantID, domainFilter)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return ToTransactionResponses(txs), total, nil
+	return domainFilter, nil
 }
 
 // ListTransactionsByInventoryItem retrieves transactions for a specific inventory item
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/partner"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -355,6 +357,22 @@ func (m *MockTransactionRepository) CountByInventoryItem(ctx context.Context, in
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockTransactionRepository) FindByWarehouseAsOf(ctx context.Context, tenantID, warehouseID uuid.UUID, asOf time.Time) ([]inventory.InventoryTransaction, error) {
+	args := m.Called(ctx, tenantID, warehouseID, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.InventoryTransaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) FindLedgerByInventoryItem(ctx context.Context, tenantID, inventoryItemID uuid.UUID) ([]inventory.InventoryTransaction, error) {
+	args := m.Called(ctx, tenantID, inventoryItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.InventoryTransaction), args.Error(1)
+}
+
 func (m *MockTransactionRepository) SumQuantityByTypeAndDateRange(ctx context.Context, tenantID uuid.UUID, txType inventory.TransactionType, start, end time.Time) (decimal.Decimal, error) {
 	args := m.Called(ctx, tenantID, txType, start, end)
 	return args.Get(0).(decimal.Decimal), args.Error(1)
@@ -817,6 +835,200 @@ func TestInventoryService_AdjustStock(t *testing.T) {
 	})
 }
 
+func TestInventoryService_DeductStock_BatchStrategy(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	// Two batches of 10: the earlier received expires later than the one received after it
+	setup := func(t *testing.T) (*InventoryService, *inventory.InventoryItem, *inventory.StockLock) {
+		item := createTestInventoryItem(tenantID, uuid.New(), uuid.New())
+		lateExpiry := time.Now().AddDate(0, 6, 0)
+		soonExpiry := time.Now().AddDate(0, 1, 0)
+		require.NoError(t, item.IncreaseStock(decimal.NewFromInt(10), valueobject.NewMoneyCNY(decimal.NewFromInt(10)), inventory.NewBatchInfo("EARLY-RECEIVED", nil, &lateExpiry)))
+		require.NoError(t, item.IncreaseStock(decimal.NewFromInt(10), valueobject.NewMoneyCNY(decimal.NewFromInt(10)), inventory.NewBatchInfo("SOON-EXPIRING", nil, &soonExpiry)))
+		item.Batches[0].CreatedAt = time.Now().AddDate(0, 0, -2)
+		item.Batches[1].CreatedAt = time.Now().AddDate(0, 0, -1)
+		lock, err := item.LockStock(decimal.NewFromInt(4), "SALES_ORDER", "SO-001", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		// The repository does not load locks with the item
+		item.Locks = nil
+
+		invRepo := new(MockInventoryItemRepository)
+		lockRepo := new(MockStockLockRepository)
+		txRepo := new(MockTransactionRepository)
+		lockRepo.On("FindByID", mock.Anything, lock.ID).Return(lock, nil)
+		lockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		invRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		invRepo.On("SaveWithLock", mock.Anything, item).Return(nil)
+		txRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		return NewInventoryServiceWithLockRepo(invRepo, lockRepo, txRepo), item, lock
+	}
+
+	deduct := func(service *InventoryService, lock *inventory.StockLock, batchStrategy string) error {
+		return service.DeductStock(ctx, tenantID, DeductStockRequest{
+			LockID:        lock.ID,
+			SourceType:    "SALES_ORDER",
+			SourceID:      "SO-001",
+			BatchStrategy: batchStrategy,
+		})
+	}
+
+	t.Run("FIFO consumes the earliest received batch by default", func(t *testing.T) {
+		service, item, lock := setup(t)
+
+		require.NoError(t, deduct(service, lock, ""))
+
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(6)))
+		assert.True(t, item.Batches[1].Quantity.Equal(decimal.NewFromInt(10)))
+	})
+
+	t.Run("FEFO consumes the soonest expiring batch", func(t *testing.T) {
+		service, item, lock := setup(t)
+
+		require.NoError(t, deduct(service, lock, "FEFO"))
+
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(10)))
+		assert.True(t, item.Batches[1].Quantity.Equal(decimal.NewFromInt(6)))
+	})
+
+	t.Run("rejects an unknown strategy", func(t *testing.T) {
+		service, _, lock := setup(t)
+
+		err := deduct(service, lock, "LIFO")
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_BATCH_STRATEGY", domainErr.Code)
+	})
+}
+
+func TestInventoryService_TransferStock(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	fromWarehouseID := uuid.New()
+	toWarehouseID := uuid.New()
+	productID := uuid.New()
+
+	t.Run("success - records a linked pair of transactions", func(t *testing.T) {
+		invRepo := new(MockInventoryItemRepository)
+		txRepo := new(MockTransactionRepository)
+		service := NewInventoryServiceWithLockRepo(invRepo, new(MockStockLockRepository), txRepo)
+		service.SetTransactionScope(NewNoOpTransactionScope(invRepo, new(MockStockLockRepository), txRepo, nil, nil))
+
+		from := createTestInventoryItemWithStock(tenantID, fromWarehouseID, productID, decimal.NewFromInt(100), decimal.Zero)
+		to := createTestInventoryItem(tenantID, toWarehouseID, productID)
+
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, fromWarehouseID, productID).Return(from, nil).Once()
+		invRepo.On("GetOrCreate", mock.Anything, tenantID, toWarehouseID, productID).Return(to, nil).Once()
+		invRepo.On("SaveWithLock", mock.Anything, from).Return(nil).Once()
+		invRepo.On("SaveWithLock", mock.Anything, to).Return(nil).Once()
+		var recorded []*inventory.InventoryTransaction
+		txRepo.On("CreateBatch", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			recorded = args.Get(1).([]*inventory.InventoryTransaction)
+		}).Return(nil).Once()
+
+		response, err := service.TransferStock(ctx, tenantID, TransferStockRequest{
+			FromWarehouseID: fromWarehouseID,
+			ToWarehouseID:   toWarehouseID,
+			ProductID:       productID,
+			Quantity:        decimal.NewFromInt(30),
+			Reference:       "TR-001",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(70), response.Source.AvailableQuantity)
+		assert.Equal(t, decimal.NewFromInt(30), response.Destination.AvailableQuantity)
+		assert.True(t, response.Destination.UnitCost.Equal(decimal.NewFromInt(10)))
+
+		require.Len(t, recorded, 2)
+		assert.Equal(t, inventory.TransactionTypeTransferOut, recorded[0].TransactionType)
+		assert.Equal(t, fromWarehouseID, recorded[0].WarehouseID)
+		assert.Equal(t, inventory.TransactionTypeTransferIn, recorded[1].TransactionType)
+		assert.Equal(t, toWarehouseID, recorded[1].WarehouseID)
+		for _, tx := range recorded {
+			assert.Equal(t, inventory.SourceTypeTransfer, tx.SourceType)
+			assert.Equal(t, response.TransferID.String(), tx.SourceID)
+			assert.Equal(t, "TR-001", tx.Reference)
+		}
+		invRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - carries the batch number across", func(t *testing.T) {
+		invRepo := new(MockInventoryItemRepository)
+		txRepo := new(MockTransactionRepository)
+		service := NewInventoryServiceWithLockRepo(invRepo, new(MockStockLockRepository), txRepo)
+
+		from := createTestInventoryItem(tenantID, fromWarehouseID, productID)
+		require.NoError(t, from.IncreaseStock(decimal.NewFromInt(50), valueobject.NewMoneyCNY(decimal.NewFromInt(8)), inventory.NewBatchInfo("BATCH-001", nil, nil)))
+		batchID := from.Batches[0].ID
+		to := createTestInventoryItem(tenantID, toWarehouseID, productID)
+
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, fromWarehouseID, productID).Return(from, nil).Once()
+		invRepo.On("GetOrCreate", mock.Anything, tenantID, toWarehouseID, productID).Return(to, nil).Once()
+		invRepo.On("SaveWithLock", mock.Anything, mock.AnythingOfType("*inventory.InventoryItem")).Return(nil).Twice()
+		var recorded []*inventory.InventoryTransaction
+		txRepo.On("CreateBatch", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			recorded = args.Get(1).([]*inventory.InventoryTransaction)
+		}).Return(nil).Once()
+
+		response, err := service.TransferStock(ctx, tenantID, TransferStockRequest{
+			FromWarehouseID: fromWarehouseID,
+			ToWarehouseID:   toWarehouseID,
+			ProductID:       productID,
+			Quantity:        decimal.NewFromInt(20),
+			BatchID:         &batchID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "BATCH-001", response.BatchNumber)
+		assert.True(t, from.Batches[0].Quantity.Equal(decimal.NewFromInt(30)))
+		require.Len(t, to.Batches, 1)
+		assert.Equal(t, "BATCH-001", to.Batches[0].BatchNumber)
+		assert.True(t, to.Batches[0].Quantity.Equal(decimal.NewFromInt(20)))
+		require.Len(t, recorded, 2)
+		assert.Equal(t, &batchID, recorded[0].BatchID)
+		assert.Equal(t, &to.Batches[0].ID, recorded[1].BatchID)
+	})
+
+	t.Run("fails before any write when stock is insufficient", func(t *testing.T) {
+		invRepo := new(MockInventoryItemRepository)
+		txRepo := new(MockTransactionRepository)
+		service := NewInventoryServiceWithLockRepo(invRepo, new(MockStockLockRepository), txRepo)
+
+		from := createTestInventoryItemWithStock(tenantID, fromWarehouseID, productID, decimal.NewFromInt(10), decimal.Zero)
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, fromWarehouseID, productID).Return(from, nil).Once()
+
+		response, err := service.TransferStock(ctx, tenantID, TransferStockRequest{
+			FromWarehouseID: fromWarehouseID,
+			ToWarehouseID:   toWarehouseID,
+			ProductID:       productID,
+			Quantity:        decimal.NewFromInt(11),
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		invRepo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		invRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+		txRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a transfer within the same warehouse", func(t *testing.T) {
+		service := NewInventoryServiceWithLockRepo(new(MockInventoryItemRepository), new(MockStockLockRepository), new(MockTransactionRepository))
+
+		_, err := service.TransferStock(ctx, tenantID, TransferStockRequest{
+			FromWarehouseID: fromWarehouseID,
+			ToWarehouseID:   fromWarehouseID,
+			ProductID:       productID,
+			Quantity:        decimal.NewFromInt(1),
+		})
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_TRANSFER", domainErr.Code)
+	})
+}
+
 func TestInventoryService_SetThresholds(t *testing.T) {
 	ctx := context.Background()
 	tenantID := uuid.New()
@@ -928,6 +1140,63 @@ func TestInventoryService_ListTransactions(t *testing.T) {
 	})
 }
 
+func TestInventoryService_ListTransactionsByCursor(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	base := time.Now().Truncate(time.Second)
+
+	txAt := func(date time.Time) inventory.InventoryTransaction {
+		return inventory.InventoryTransaction{BaseEntity: shared.NewBaseEntity(), TenantID: tenantID, TransactionDate: date}
+	}
+
+	t.Run("returns a cursor at the last row when more rows follow", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+		service := NewInventoryService(new(MockInventoryItemRepository), new(MockStockBatchRepository), new(MockStockLockRepository), txRepo)
+
+		// The repository returns one row past the page to signal another page
+		txs := []inventory.InventoryTransaction{txAt(base), txAt(base.Add(-time.Minute)), txAt(base.Add(-2 * time.Minute))}
+		txRepo.On("FindForTenant", ctx, tenantID, mock.MatchedBy(func(f shared.Filter) bool {
+			return f.Keyset && f.After == nil && f.PageSize == 2 && f.OrderDir == "desc"
+		})).Return(txs, nil).Once()
+
+		responses, nextCursor, err := service.ListTransactionsByCursor(ctx, tenantID, TransactionListFilter{PageSize: 2, Pagination: "cursor"})
+
+		require.NoError(t, err)
+		require.Len(t, responses, 2)
+		cursor, err := shared.DecodeCursor(nextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, txs[1].ID, cursor.ID)
+		assert.True(t, txs[1].TransactionDate.Equal(cursor.SortValue))
+		txRepo.AssertNotCalled(t, "CountForTenant", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("passes the decoded cursor and ends on a short page", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+		service := NewInventoryService(new(MockInventoryItemRepository), new(MockStockBatchRepository), new(MockStockLockRepository), txRepo)
+
+		after := shared.Cursor{SortValue: base, ID: uuid.New(), Direction: "DESC"}
+		txRepo.On("FindForTenant", ctx, tenantID, mock.MatchedBy(func(f shared.Filter) bool {
+			return f.Keyset && f.After != nil && f.After.ID == after.ID
+		})).Return([]inventory.InventoryTransaction{txAt(base.Add(-time.Hour))}, nil).Once()
+
+		responses, nextCursor, err := service.ListTransactionsByCursor(ctx, tenantID, TransactionListFilter{PageSize: 2, Cursor: after.Encode()})
+
+		require.NoError(t, err)
+		assert.Len(t, responses, 1)
+		assert.Empty(t, nextCursor)
+	})
+
+	t.Run("rejects a malformed cursor", func(t *testing.T) {
+		txRepo := new(MockTransactionRepository)
+		service := NewInventoryService(new(MockInventoryItemRepository), new(MockStockBatchRepository), new(MockStockLockRepository), txRepo)
+
+		_, _, err := service.ListTransactionsByCursor(ctx, tenantID, TransactionListFilter{Cursor: "not-a-cursor"})
+
+		assert.ErrorIs(t, err, shared.ErrInvalidCursor)
+		txRepo.AssertNotCalled(t, "FindForTenant", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 // Test DTOs conversion functions
 func TestToInventoryItemResponse(t *testing.T) {
 	tenantID := uuid.New()
@@ -1117,6 +1386,232 @@ func TestInventoryService_DecreaseStock_PublishesStockBelowThresholdEvent(t *tes
 	})
 }
 
+// stubWarehouseReader returns a fixed warehouse for any lookup
+type stubWarehouseReader struct {
+	warehouse *partner.Warehouse
+}
+
+func (r *stubWarehouseReader) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Warehouse, error) {
+	return r.warehouse, nil
+}
+
+func TestInventoryService_NegativeStockPolicy(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	setup := func(t *testing.T, allowNegative bool) (*InventoryService, *MockInventoryItemRepository, *MockTransactionRepository, *inventory.InventoryItem) {
+		warehouse, err := partner.NewConsignWarehouse(tenantID, "CONSIGN", "Consignment")
+		require.NoError(t, err)
+		warehouse.SetAllowNegativeStock(allowNegative)
+
+		invRepo := new(MockInventoryItemRepository)
+		txRepo := new(MockTransactionRepository)
+		service := NewInventoryService(invRepo, new(MockStockBatchRepository), new(MockStockLockRepository), txRepo)
+		service.SetWarehouseReader(&stubWarehouseReader{warehouse: warehouse})
+
+		item := createTestInventoryItemWithStock(tenantID, warehouse.ID, uuid.New(), decimal.NewFromInt(10), decimal.Zero)
+		return service, invRepo, txRepo, item
+	}
+
+	decrease := func(item *inventory.InventoryItem) DecreaseStockRequest {
+		return DecreaseStockRequest{
+			WarehouseID: item.WarehouseID,
+			ProductID:   item.ProductID,
+			Quantity:    decimal.NewFromInt(15),
+			SourceType:  "PURCHASE_RETURN",
+			SourceID:    "PR-001",
+		}
+	}
+
+	t.Run("hard stop rejects deducting more than available before writing", func(t *testing.T) {
+		service, invRepo, txRepo, item := setup(t, false)
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, item.WarehouseID, item.ProductID).Return(item, nil).Once()
+
+		err := service.DecreaseStock(ctx, tenantID, decrease(item))
+
+		assert.ErrorIs(t, err, shared.ErrInsufficientStock)
+		assert.Equal(t, decimal.NewFromInt(10), item.AvailableQuantity.Amount())
+		invRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+		txRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("negative stock allowed records the negative balance", func(t *testing.T) {
+		service, invRepo, txRepo, item := setup(t, true)
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, item.WarehouseID, item.ProductID).Return(item, nil).Once()
+		invRepo.On("SaveWithLock", mock.Anything, item).Return(nil).Once()
+		txRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *inventory.InventoryTransaction) bool {
+			return tx.BalanceBefore.Equal(decimal.NewFromInt(10)) && tx.BalanceAfter.Equal(decimal.NewFromInt(-5))
+		})).Return(nil).Once()
+
+		err := service.DecreaseStock(ctx, tenantID, decrease(item))
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(-5), item.AvailableQuantity.Amount())
+		invRepo.AssertExpectations(t)
+		txRepo.AssertExpectations(t)
+	})
+
+	// Locks 5 of the item's 10 and ships 15, 10 more than the 5 left available
+	deduct := func(t *testing.T, service *InventoryService, item *inventory.InventoryItem) error {
+		lock, err := item.LockStock(decimal.NewFromInt(5), "SALES_ORDER", "SO-001", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		// The repository does not load locks with the item
+		item.Locks = nil
+		lockRepo := service.lockRepo.(*MockStockLockRepository)
+		lockRepo.On("FindByID", mock.Anything, lock.ID).Return(lock, nil)
+		lockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		service.inventoryRepo.(*MockInventoryItemRepository).On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+		quantity := decimal.NewFromInt(15)
+		return service.DeductStock(ctx, tenantID, DeductStockRequest{
+			LockID:     lock.ID,
+			Quantity:   &quantity,
+			SourceType: "SALES_ORDER",
+			SourceID:   "SO-001",
+		})
+	}
+
+	t.Run("hard stop rejects deducting more than on hand before writing", func(t *testing.T) {
+		service, invRepo, txRepo, item := setup(t, false)
+
+		err := deduct(t, service, item)
+
+		assert.ErrorIs(t, err, shared.ErrInsufficientStock)
+		assert.Equal(t, decimal.NewFromInt(5), item.AvailableQuantity.Amount())
+		assert.Equal(t, decimal.NewFromInt(5), item.LockedQuantity.Amount())
+		invRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+		txRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("negative stock allowed deducts below zero", func(t *testing.T) {
+		service, invRepo, txRepo, item := setup(t, true)
+		invRepo.On("SaveWithLock", mock.Anything, item).Return(nil).Once()
+		txRepo.On("Create", mock.Anything, mock.AnythingOfType("*inventory.InventoryTransaction")).Return(nil).Once()
+
+		err := deduct(t, service, item)
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(-5), item.AvailableQuantity.Amount())
+		assert.True(t, item.LockedQuantity.IsZero())
+		invRepo.AssertExpectations(t)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("hard stop rejects adjusting to a negative quantity", func(t *testing.T) {
+		service, invRepo, _, item := setup(t, false)
+
+		_, err := service.AdjustStock(ctx, tenantID, AdjustStockRequest{
+			WarehouseID:    item.WarehouseID,
+			ProductID:      item.ProductID,
+			ActualQuantity: decimal.NewFromInt(-5),
+			Reason:         "Consignment count",
+		})
+
+		assert.ErrorIs(t, err, shared.ErrInsufficientStock)
+		invRepo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("negative stock allowed adjusts to a negative quantity", func(t *testing.T) {
+		service, invRepo, txRepo, item := setup(t, true)
+		invRepo.On("GetOrCreate", mock.Anything, tenantID, item.WarehouseID, item.ProductID).Return(item, nil).Once()
+		invRepo.On("SaveWithLock", mock.Anything, item).Return(nil).Once()
+		txRepo.On("Create", mock.Anything, mock.AnythingOfType("*inventory.InventoryTransaction")).Return(nil).Once()
+
+		response, err := service.AdjustStock(ctx, tenantID, AdjustStockRequest{
+			WarehouseID:    item.WarehouseID,
+			ProductID:      item.ProductID,
+			ActualQuantity: decimal.NewFromInt(-5),
+			Reason:         "Consignment count",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(-5), response.AvailableQuantity)
+	})
+}
+
+// stubLoadReader returns the given warehouse loads in turn, one per call
+type stubLoadReader struct {
+	loads []decimal.Decimal
+	units []partner.CapacityUnit
+}
+
+func (r *stubLoadReader) SumLoadByWarehouse(ctx context.Context, tenantID, warehouseID uuid.UUID, unit partner.CapacityUnit) (decimal.Decimal, error) {
+	r.units = append(r.units, unit)
+	load := r.loads[0]
+	r.loads = r.loads[1:]
+	return load, nil
+}
+
+func TestInventoryService_NearCapacityAlert(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	receive := func(t *testing.T, capacity int, loads ...int64) (*MockEventPublisher, *stubLoadReader) {
+		t.Helper()
+		warehouse, err := partner.NewPhysicalWarehouse(tenantID, "WH-CAP", "Capacity")
+		require.NoError(t, err)
+		require.NoError(t, warehouse.SetCapacity(capacity))
+		require.NoError(t, warehouse.SetCapacityUnit(partner.CapacityUnitCubicMetre))
+
+		loadReader := &stubLoadReader{}
+		for _, load := range loads {
+			loadReader.loads = append(loadReader.loads, decimal.NewFromInt(load))
+		}
+
+		invRepo := new(MockInventoryItemRepository)
+		txRepo := new(MockTransactionRepository)
+		publisher := NewMockEventPublisher()
+		service := NewInventoryService(invRepo, new(MockStockBatchRepository), new(MockStockLockRepository), txRepo)
+		service.SetEventPublisher(publisher)
+		service.SetWarehouseReader(&stubWarehouseReader{warehouse: warehouse})
+		service.SetCapacityMonitor(loadReader, 90)
+
+		item := createTestInventoryItem(tenantID, warehouse.ID, uuid.New())
+		invRepo.On("GetOrCreate", mock.Anything, tenantID, warehouse.ID, item.ProductID).Return(item, nil).Once()
+		invRepo.On("SaveWithLock", mock.Anything, item).Return(nil).Once()
+		txRepo.On("Create", mock.Anything, mock.AnythingOfType("*inventory.InventoryTransaction")).Return(nil).Once()
+
+		_, err = service.IncreaseStock(ctx, tenantID, IncreaseStockRequest{
+			WarehouseID: warehouse.ID,
+			ProductID:   item.ProductID,
+			Quantity:    decimal.NewFromInt(10),
+			UnitCost:    decimal.NewFromInt(5),
+			SourceType:  "PURCHASE_ORDER",
+			SourceID:    "PO-CAP",
+		})
+		require.NoError(t, err)
+		return publisher, loadReader
+	}
+
+	t.Run("receipt crossing the threshold publishes the event", func(t *testing.T) {
+		publisher, loadReader := receive(t, 100, 85, 95)
+
+		events := publisher.GetEventsByType(partner.EventTypeWarehouseNearCapacity)
+		require.Len(t, events, 1)
+		event := events[0].(*partner.WarehouseNearCapacityEvent)
+		assert.True(t, event.Percent.Equal(decimal.NewFromInt(95)))
+		assert.True(t, event.Threshold.Equal(decimal.NewFromInt(90)))
+		assert.Equal(t, partner.CapacityUnitCubicMetre, event.CapacityUnit)
+		assert.Equal(t, []partner.CapacityUnit{partner.CapacityUnitCubicMetre, partner.CapacityUnitCubicMetre}, loadReader.units)
+	})
+
+	t.Run("receipt staying below the threshold publishes nothing", func(t *testing.T) {
+		publisher, _ := receive(t, 100, 50, 60)
+		assert.Empty(t, publisher.GetEventsByType(partner.EventTypeWarehouseNearCapacity))
+	})
+
+	t.Run("warehouse already over the threshold is not reported again", func(t *testing.T) {
+		publisher, _ := receive(t, 100, 92, 97)
+		assert.Empty(t, publisher.GetEventsByType(partner.EventTypeWarehouseNearCapacity))
+	})
+
+	t.Run("warehouse without capacity is not measured", func(t *testing.T) {
+		publisher, loadReader := receive(t, 0)
+		assert.Empty(t, publisher.GetEventsByType(partner.EventTypeWarehouseNearCapacity))
+		assert.Empty(t, loadReader.units)
+	})
+}
+
 func TestInventoryService_SetEventPublisher(t *testing.T) {
 	invRepo := new(MockInventoryItemRepository)
 	batchRepo := new(MockStockBatchRepository)
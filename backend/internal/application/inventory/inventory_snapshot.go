@@ -0,0 +1,225 @@
+package inventory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// GetSnapshotAsOf reconstructs the on-hand quantity and valuation of every product in a warehouse
+// as of the given timestamp by replaying the transaction ledger.
+//
+// Replay rules:
+//   - Transactions dated exactly at asOf are included
+//   - Lock and unlock transactions only move stock between available and locked, so they do not
+//     change on-hand quantity and are skipped
+//   - Valuation follows the tenant's cost strategy: FIFO and LIFO consume cost layers from the
+//     oldest or newest receipt, any other strategy values stock at the moving average cost
+//   - Receipts are valued at their corrected cost when a cost correction was recorded up to asOf
+//   - Inventory items of the warehouse without transactions up to asOf are reported with zero quantity
+func (s *InventoryService) GetSnapshotAsOf(ctx context.Context, tenantID, warehouseID uuid.UUID, asOf time.Time) (*InventorySnapshotResponse, error) {
+	if warehouseID == uuid.Nil {
+		return nil, shared.NewDomainError("INVALID_WAREHOUSE", "Warehouse ID cannot be empty")
+	}
+	if asOf.IsZero() {
+		return nil, shared.NewDomainError("INVALID_AS_OF", "Snapshot timestamp cannot be empty")
+	}
+
+	txs, err := s.transactionRepo.FindByWarehouseAsOf(ctx, tenantID, warehouseID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.inventoryRepo.FindByWarehouse(ctx, tenantID, warehouseID, shared.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	costMethod := s.getStrategyNameForTenant(ctx, tenantID)
+	corrections := collectCostAdjustments(txs, inventory.SourceTypeCostCorrection)
+
+	valuations := make(map[uuid.UUID]*stockValuation)
+	for i := range items {
+		valuations[items[i].ProductID] = &stockValuation{inventoryItemID: items[i].ID, method: costMethod}
+	}
+	for i := range txs {
+		tx := &txs[i]
+		if tx.TransactionType == inventory.TransactionTypeLock || tx.TransactionType == inventory.TransactionTypeUnlock {
+			continue
+		}
+		v, ok := valuations[tx.ProductID]
+		if !ok {
+			v = &stockValuation{inventoryItemID: tx.InventoryItemID, method: costMethod}
+			valuations[tx.ProductID] = v
+		}
+		v.apply(tx, corrections)
+	}
+
+	response := &InventorySnapshotResponse{
+		WarehouseID:   warehouseID,
+		AsOf:          asOf,
+		CostMethod:    costMethod,
+		Items:         make([]InventorySnapshotItem, 0, len(valuations)),
+		TotalQuantity: decimal.Zero,
+		TotalValue:    decimal.Zero,
+	}
+	for productID, v := range valuations {
+		line := InventorySnapshotItem{
+			ProductID:         productID,
+			InventoryItemID:   v.inventoryItemID,
+			Quantity:          v.quantity,
+			UnitCost:          v.unitCost(),
+			TotalValue:        v.value(),
+			LastTransactionAt: v.lastTransactionAt,
+		}
+		response.Items = append(response.Items, line)
+		response.TotalQuantity = response.TotalQuantity.Add(line.Quantity)
+		response.TotalValue = response.TotalValue.Add(line.TotalValue)
+	}
+	sort.Slice(response.Items, func(i, j int) bool {
+		return response.Items[i].ProductID.String() < response.Items[j].ProductID.String()
+	})
+
+	return response, nil
+}
+
+// costLayer is a received quantity still on hand at its receipt cost
+type costLayer struct {
+	quantity decimal.Decimal
+	unitCost decimal.Decimal
+}
+
+// stockValuation accumulates the quantity and cost of one product while replaying transactions
+type stockValuation struct {
+	inventoryItemID   uuid.UUID
+	method            string
+	quantity          decimal.Decimal
+	averageCost       decimal.Decimal
+	layers            []costLayer
+	shortage          decimal.Decimal // Quantity issued beyond the available layers (negative stock)
+	lastUnitCost      decimal.Decimal
+	lastTransactionAt *time.Time
+}
+
+func (v *stockValuation) usesLayers() bool {
+	return v.method == "fifo" || v.method == "lifo"
+}
+
+// apply replays a stock movement and returns the cost of the stock it took out.
+// Receipts are received at their corrected cost; cost adjustments move no stock.
+func (v *stockValuation) apply(tx *inventory.InventoryTransaction, corrections costAdjustments) decimal.Decimal {
+	date := tx.TransactionDate
+	v.lastTransactionAt = &date
+
+	switch {
+	case tx.TransactionType.IsIncrease():
+		unitCost := tx.UnitCost
+		if tx.TransactionType.IsReceipt() {
+			if delta, ok := corrections[tx.ID]; ok {
+				unitCost = tx.TotalCost.Add(delta).Div(tx.Quantity)
+			}
+		}
+		v.receive(tx.Quantity, unitCost)
+	case tx.TransactionType.IsDecrease():
+		return v.issue(tx.Quantity)
+	}
+	return decimal.Zero
+}
+
+func (v *stockValuation) receive(quantity, unitCost decimal.Decimal) {
+	if v.quantity.IsPositive() {
+		total := v.quantity.Mul(v.averageCost).Add(quantity.Mul(unitCost))
+		newQuantity := v.quantity.Add(quantity)
+		v.averageCost = total.Div(newQuantity).Round(4)
+	} else {
+		v.averageCost = unitCost
+	}
+	v.quantity = v.quantity.Add(quantity)
+	v.lastUnitCost = unitCost
+
+	if !v.usesLayers() {
+		return
+	}
+	// Incoming stock first covers any quantity issued while the product was out of stock
+	if v.shortage.IsPositive() {
+		covered := decimal.Min(v.shortage, quantity)
+		v.shortage = v.shortage.Sub(covered)
+		quantity = quantity.Sub(covered)
+	}
+	if quantity.IsPositive() {
+		v.layers = append(v.layers, costLayer{quantity: quantity, unitCost: unitCost})
+	}
+}
+
+// issue takes quantity out of stock and returns its cost
+func (v *stockValuation) issue(quantity decimal.Decimal) decimal.Decimal {
+	v.quantity = v.quantity.Sub(quantity)
+
+	if !v.usesLayers() {
+		return quantity.Mul(v.averageCost)
+	}
+	cost := decimal.Zero
+	for quantity.IsPositive() && len(v.layers) > 0 {
+		idx := 0
+		if v.method == "lifo" {
+			idx = len(v.layers) - 1
+		}
+		layer := &v.layers[idx]
+		consumed := decimal.Min(layer.quantity, quantity)
+		cost = cost.Add(consumed.Mul(layer.unitCost))
+		layer.quantity = layer.quantity.Sub(consumed)
+		quantity = quantity.Sub(consumed)
+		v.lastUnitCost = layer.unitCost
+		if layer.quantity.IsZero() {
+			v.layers = append(v.layers[:idx], v.layers[idx+1:]...)
+		}
+	}
+	// Stock issued beyond the layers is costed at the last known cost
+	v.shortage = v.shortage.Add(quantity)
+	return cost.Add(quantity.Mul(v.lastUnitCost))
+}
+
+func (v *stockValuation) value() decimal.Decimal {
+	if !v.usesLayers() {
+		return v.quantity.Mul(v.averageCost).Round(2)
+	}
+	total := v.shortage.Neg().Mul(v.lastUnitCost)
+	for _, layer := range v.layers {
+		total = total.Add(layer.quantity.Mul(layer.unitCost))
+	}
+	return total.Round(2)
+}
+
+func (v *stockValuation) unitCost() decimal.Decimal {
+	if !v.usesLayers() {
+		return v.averageCost
+	}
+	if v.quantity.IsZero() {
+		return decimal.Zero
+	}
+	return v.value().Div(v.quantity).Round(4)
+}
+
+// costAdjustments sums the signed cost adjustments of a ledger by the transaction they adjust
+type costAdjustments map[uuid.UUID]decimal.Decimal
+
+// collectCostAdjustments collects the cost adjustments of the given source type from a ledger
+func collectCostAdjustments(txs []inventory.InventoryTransaction, sourceType inventory.SourceType) costAdjustments {
+	adjustments := make(costAdjustments)
+	for i := range txs {
+		tx := &txs[i]
+		if !tx.TransactionType.IsCostAdjustment() || tx.SourceType != sourceType {
+			continue
+		}
+		adjustedID, err := uuid.Parse(tx.SourceLineID)
+		if err != nil {
+			continue
+		}
+		adjustments[adjustedID] = adjustments[adjustedID].Add(tx.GetSignedTotalCost())
+	}
+	return adjustments
+}
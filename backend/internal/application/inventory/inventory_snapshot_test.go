@@ -0,0 +1,226 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSnapshotTenantRepo serves a single tenant whose cost strategy drives valuation
+type stubSnapshotTenantRepo struct {
+	identity.TenantRepository
+	tenant *identity.Tenant
+}
+
+func (r *stubSnapshotTenantRepo) FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error) {
+	return r.tenant, nil
+}
+
+func newSnapshotTestTransaction(t *testing.T, item *inventory.InventoryItem, txType inventory.TransactionType, qty, cost int64, at time.Time) inventory.InventoryTransaction {
+	tx, err := inventory.NewInventoryTransaction(
+		item.TenantID, item.ID, item.WarehouseID, item.ProductID, txType,
+		decimal.NewFromInt(qty), decimal.NewFromInt(cost), decimal.Zero, decimal.Zero,
+		inventory.SourceTypeManualAdjustment, "test",
+	)
+	require.NoError(t, err)
+	tx.TransactionDate = at
+	return *tx
+}
+
+// ledgerUpTo mimics the repository's inclusive as-of filter
+func ledgerUpTo(ledger []inventory.InventoryTransaction, asOf time.Time) []inventory.InventoryTransaction {
+	var result []inventory.InventoryTransaction
+	for _, tx := range ledger {
+		if !tx.TransactionDate.After(asOf) {
+			result = append(result, tx)
+		}
+	}
+	return result
+}
+
+func TestInventoryService_GetSnapshotAsOf(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	warehouseID := uuid.New()
+
+	item := createTestInventoryItem(tenantID, warehouseID, uuid.New())
+	untouched := createTestInventoryItem(tenantID, warehouseID, uuid.New())
+
+	t1 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	t2 := t1.Add(24 * time.Hour)
+	t3 := t2.Add(24 * time.Hour)
+	ledger := []inventory.InventoryTransaction{
+		newSnapshotTestTransaction(t, item, inventory.TransactionTypeInbound, 10, 10, t1),
+		newSnapshotTestTransaction(t, item, inventory.TransactionTypeInbound, 10, 20, t2),
+		newSnapshotTestTransaction(t, item, inventory.TransactionTypeLock, 8, 15, t2),
+		newSnapshotTestTransaction(t, item, inventory.TransactionTypeOutbound, 15, 15, t3),
+	}
+
+	newService := func(costStrategy string, asOf time.Time) *InventoryService {
+		invRepo := new(MockInventoryItemRepository)
+		txRepo := new(MockTransactionRepository)
+		invRepo.On("FindByWarehouse", ctx, tenantID, warehouseID, shared.Filter{}).
+			Return([]inventory.InventoryItem{*item, *untouched}, nil)
+		txRepo.On("FindByWarehouseAsOf", ctx, tenantID, warehouseID, asOf).
+			Return(ledgerUpTo(ledger, asOf), nil)
+
+		service := NewInventoryService(invRepo, nil, new(MockStockLockRepository), txRepo)
+		if costStrategy != "" {
+			tenant, err := identity.NewTenant("T1", "Tenant")
+			require.NoError(t, err)
+			tenant.Config.CostStrategy = costStrategy
+			service.SetTenantRepository(&stubSnapshotTenantRepo{tenant: tenant})
+		}
+		return service
+	}
+
+	lineFor := func(t *testing.T, snapshot *InventorySnapshotResponse, productID uuid.UUID) InventorySnapshotItem {
+		for _, line := range snapshot.Items {
+			if line.ProductID == productID {
+				return line
+			}
+		}
+		t.Fatalf("product %s missing from snapshot", productID)
+		return InventorySnapshotItem{}
+	}
+
+	t.Run("includes transactions exactly at the boundary and ignores locks", func(t *testing.T) {
+		snapshot, err := newService("", t2).GetSnapshotAsOf(ctx, tenantID, warehouseID, t2)
+		require.NoError(t, err)
+
+		assert.Equal(t, "moving_average", snapshot.CostMethod)
+		line := lineFor(t, snapshot, item.ProductID)
+		assert.True(t, decimal.NewFromInt(20).Equal(line.Quantity), "got %s", line.Quantity)
+		assert.True(t, decimal.NewFromInt(15).Equal(line.UnitCost), "got %s", line.UnitCost)
+		assert.True(t, decimal.NewFromInt(300).Equal(line.TotalValue), "got %s", line.TotalValue)
+		assert.Equal(t, t2, *line.LastTransactionAt)
+	})
+
+	t.Run("product without transactions before the date is zero", func(t *testing.T) {
+		snapshot, err := newService("", t1.Add(-time.Second)).GetSnapshotAsOf(ctx, tenantID, warehouseID, t1.Add(-time.Second))
+		require.NoError(t, err)
+
+		require.Len(t, snapshot.Items, 2)
+		for _, line := range snapshot.Items {
+			assert.True(t, line.Quantity.IsZero())
+			assert.True(t, line.TotalValue.IsZero())
+			assert.Nil(t, line.LastTransactionAt)
+		}
+		assert.True(t, snapshot.TotalValue.IsZero())
+	})
+
+	t.Run("moving average after outbound", func(t *testing.T) {
+		snapshot, err := newService("weighted_average", t3).GetSnapshotAsOf(ctx, tenantID, warehouseID, t3)
+		require.NoError(t, err)
+
+		line := lineFor(t, snapshot, item.ProductID)
+		assert.True(t, decimal.NewFromInt(5).Equal(line.Quantity), "got %s", line.Quantity)
+		assert.True(t, decimal.NewFromInt(75).Equal(line.TotalValue), "got %s", line.TotalValue)
+		assert.True(t, lineFor(t, snapshot, untouched.ProductID).Quantity.IsZero())
+		assert.True(t, decimal.NewFromInt(75).Equal(snapshot.TotalValue))
+	})
+
+	t.Run("fifo values remaining stock at the newest receipts", func(t *testing.T) {
+		snapshot, err := newService("fifo", t3).GetSnapshotAsOf(ctx, tenantID, warehouseID, t3)
+		require.NoError(t, err)
+
+		assert.Equal(t, "fifo", snapshot.CostMethod)
+		line := lineFor(t, snapshot, item.ProductID)
+		assert.True(t, decimal.NewFromInt(5).Equal(line.Quantity), "got %s", line.Quantity)
+		assert.True(t, decimal.NewFromInt(100).Equal(line.TotalValue), "got %s", line.TotalValue)
+		assert.True(t, decimal.NewFromInt(20).Equal(line.UnitCost), "got %s", line.UnitCost)
+	})
+
+	t.Run("lifo values remaining stock at the oldest receipts", func(t *testing.T) {
+		snapshot, err := newService("lifo", t3).GetSnapshotAsOf(ctx, tenantID, warehouseID, t3)
+		require.NoError(t, err)
+
+		line := lineFor(t, snapshot, item.ProductID)
+		assert.True(t, decimal.NewFromInt(50).Equal(line.TotalValue), "got %s", line.TotalValue)
+	})
+
+	t.Run("rejects missing warehouse", func(t *testing.T) {
+		service := NewInventoryService(new(MockInventoryItemRepository), nil, new(MockStockLockRepository), new(MockTransactionRepository))
+		_, err := service.GetSnapshotAsOf(ctx, tenantID, uuid.Nil, t3)
+		require.Error(t, err)
+	})
+}
+
+// stubTenantStrategyResolver serves the cost strategies tenants chose in their strategy configuration
+type stubTenantStrategyResolver struct {
+	costStrategies map[uuid.UUID]string
+}
+
+func (r *stubTenantStrategyResolver) TenantStrategyName(_ context.Context, tenantID uuid.UUID, strategyType strategy.StrategyType) (string, bool) {
+	if strategyType != strategy.StrategyTypeCost {
+		return "", false
+	}
+	name, ok := r.costStrategies[tenantID]
+	return name, ok
+}
+
+func TestInventoryService_GetSnapshotAsOf_TenantStrategies(t *testing.T) {
+	ctx := context.Background()
+	fifoTenantID := uuid.New()
+	defaultTenantID := uuid.New()
+	resolver := &stubTenantStrategyResolver{costStrategies: map[uuid.UUID]string{fifoTenantID: "fifo"}}
+
+	t1 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	t3 := t1.Add(48 * time.Hour)
+
+	// Both tenants receive 10 @ 10 and 10 @ 20, then issue 15
+	newService := func(tenantID uuid.UUID) (*InventoryService, uuid.UUID) {
+		warehouseID := uuid.New()
+		item := createTestInventoryItem(tenantID, warehouseID, uuid.New())
+		ledger := []inventory.InventoryTransaction{
+			newSnapshotTestTransaction(t, item, inventory.TransactionTypeInbound, 10, 10, t1),
+			newSnapshotTestTransaction(t, item, inventory.TransactionTypeInbound, 10, 20, t1.Add(24*time.Hour)),
+			newSnapshotTestTransaction(t, item, inventory.TransactionTypeOutbound, 15, 15, t3),
+		}
+
+		invRepo := new(MockInventoryItemRepository)
+		txRepo := new(MockTransactionRepository)
+		invRepo.On("FindByWarehouse", ctx, tenantID, warehouseID, shared.Filter{}).Return([]inventory.InventoryItem{*item}, nil)
+		txRepo.On("FindByWarehouseAsOf", ctx, tenantID, warehouseID, t3).Return(ledger, nil)
+
+		service := NewInventoryService(invRepo, nil, new(MockStockLockRepository), txRepo)
+		service.SetTenantStrategyResolver(resolver)
+		return service, warehouseID
+	}
+
+	t.Run("tenants with different strategies value the same stock differently", func(t *testing.T) {
+		fifoService, fifoWarehouseID := newService(fifoTenantID)
+		fifoSnapshot, err := fifoService.GetSnapshotAsOf(ctx, fifoTenantID, fifoWarehouseID, t3)
+		require.NoError(t, err)
+
+		defaultService, defaultWarehouseID := newService(defaultTenantID)
+		defaultSnapshot, err := defaultService.GetSnapshotAsOf(ctx, defaultTenantID, defaultWarehouseID, t3)
+		require.NoError(t, err)
+
+		assert.Equal(t, "fifo", fifoSnapshot.CostMethod)
+		assert.True(t, decimal.NewFromInt(100).Equal(fifoSnapshot.TotalValue), "got %s", fifoSnapshot.TotalValue)
+		assert.Equal(t, "moving_average", defaultSnapshot.CostMethod)
+		assert.True(t, decimal.NewFromInt(75).Equal(defaultSnapshot.TotalValue), "got %s", defaultSnapshot.TotalValue)
+	})
+
+	t.Run("strategy configuration takes precedence over the tenant config", func(t *testing.T) {
+		service, warehouseID := newService(fifoTenantID)
+		tenant, err := identity.NewTenant("T1", "Tenant")
+		require.NoError(t, err)
+		tenant.Config.CostStrategy = "lifo"
+		service.SetTenantRepository(&stubSnapshotTenantRepo{tenant: tenant})
+
+		snapshot, err := service.GetSnapshotAsOf(ctx, fifoTenantID, warehouseID, t3)
+		require.NoError(t, err)
+		assert.Equal(t, "fifo", snapshot.CostMethod)
+	})
+}
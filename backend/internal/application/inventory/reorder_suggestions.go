@@ -0,0 +1,236 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReorderPurchaseOrderReader provides the purchase order queries used to build reorder suggestions
+type ReorderPurchaseOrderReader interface {
+	// FindPendingReceipt finds purchase orders pending receipt (CONFIRMED or PARTIAL_RECEIVED)
+	FindPendingReceipt(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error)
+	// FindAllForTenant finds purchase orders for a tenant with filtering
+	FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error)
+}
+
+// ReorderProductUnitReader provides the product units used to pick the purchase unit of a suggestion
+type ReorderProductUnitReader interface {
+	// FindByProductID finds all units for a product
+	FindByProductID(ctx context.Context, tenantID, productID uuid.UUID) ([]catalog.ProductUnit, error)
+}
+
+// SetReorderSources sets the purchase order and product unit readers used by GenerateReorderSuggestions
+func (s *InventoryService) SetReorderSources(orderReader ReorderPurchaseOrderReader, unitReader ReorderProductUnitReader) {
+	s.reorderOrderReader = orderReader
+	s.reorderUnitReader = unitReader
+}
+
+// GenerateReorderSuggestions suggests draft purchase order lines for the items of a warehouse
+// that are below their minimum threshold, grouped by supplier.
+//
+// For each item:
+//   - Quantities still to be received on open purchase orders for the warehouse count as incoming;
+//     items whose current plus incoming quantity reaches the minimum threshold are skipped
+//   - The shortage is the quantity needed to reach the maximum threshold (or the minimum when no
+//     higher maximum is set), net of incoming quantities
+//   - The shortage is ordered in the product's default purchase unit, rounded up to whole units
+//   - The preferred supplier is the supplier of the product's most recent confirmed purchase order;
+//     products that have never been purchased are grouped without a supplier
+func (s *InventoryService) GenerateReorderSuggestions(ctx context.Context, tenantID, warehouseID uuid.UUID) (*ReorderSuggestionsResponse, error) {
+	if s.reorderOrderReader == nil || s.reorderUnitReader == nil {
+		return nil, shared.NewDomainError("REORDER_NOT_CONFIGURED", "Reorder suggestions are not available")
+	}
+
+	items, err := s.inventoryRepo.FindAllForTenant(ctx, tenantID, shared.Filter{
+		OrderBy:  "product_code",
+		OrderDir: "asc",
+		Filters: map[string]interface{}{
+			"warehouse_id":  warehouseID,
+			"below_minimum": true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	incoming, err := s.incomingQuantities(ctx, tenantID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[uuid.UUID]*SupplierReorderSuggestion)
+	lineCount := 0
+	for i := range items {
+		item := &items[i]
+		line, ok, err := s.buildReorderLine(ctx, tenantID, item, incoming[item.ProductID])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		supplierID, supplierName, err := s.preferredSupplier(ctx, tenantID, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		group, exists := groups[supplierID]
+		if !exists {
+			group = &SupplierReorderSuggestion{SupplierName: supplierName, TotalAmount: decimal.Zero}
+			if supplierID != uuid.Nil {
+				id := supplierID
+				group.SupplierID = &id
+			}
+			groups[supplierID] = group
+		}
+		group.Lines = append(group.Lines, line)
+		group.TotalAmount = group.TotalAmount.Add(line.Amount)
+		lineCount++
+	}
+
+	suppliers := make([]SupplierReorderSuggestion, 0, len(groups))
+	for _, group := range groups {
+		suppliers = append(suppliers, *group)
+	}
+	// Suppliers by name, products without a supplier last
+	sort.Slice(suppliers, func(i, j int) bool {
+		if (suppliers[i].SupplierID == nil) != (suppliers[j].SupplierID == nil) {
+			return suppliers[j].SupplierID == nil
+		}
+		return suppliers[i].SupplierName < suppliers[j].SupplierName
+	})
+
+	return &ReorderSuggestionsResponse{
+		WarehouseID: warehouseID,
+		Suppliers:   suppliers,
+		LineCount:   lineCount,
+	}, nil
+}
+
+// incomingQuantities sums the base quantities still to be received per product
+// on the open purchase orders of a warehouse
+func (s *InventoryService) incomingQuantities(ctx context.Context, tenantID, warehouseID uuid.UUID) (map[uuid.UUID]decimal.Decimal, error) {
+	orders, err := s.reorderOrderReader.FindPendingReceipt(ctx, tenantID, shared.Filter{
+		Filters: map[string]interface{}{"warehouse_id": warehouseID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	incoming := make(map[uuid.UUID]decimal.Decimal)
+	for _, order := range orders {
+		if order.WarehouseID == nil || *order.WarehouseID != warehouseID {
+			continue
+		}
+		for _, orderItem := range order.Items {
+			remaining := orderItem.RemainingQuantity().Mul(orderItem.ConversionRate)
+			incoming[orderItem.ProductID] = incoming[orderItem.ProductID].Add(remaining)
+		}
+	}
+	return incoming, nil
+}
+
+// buildReorderLine computes the suggested line for an item.
+// Returns false if open purchase orders already cover the item's minimum threshold.
+func (s *InventoryService) buildReorderLine(ctx context.Context, tenantID uuid.UUID, item *inventory.InventoryItem, incoming decimal.Decimal) (ReorderSuggestionLine, bool, error) {
+	current := item.TotalQuantity().Amount()
+	minQuantity := item.MinQuantity.Amount()
+	maxQuantity := item.MaxQuantity.Amount()
+
+	projected := current.Add(incoming)
+	if projected.GreaterThanOrEqual(minQuantity) {
+		return ReorderSuggestionLine{}, false, nil
+	}
+
+	target := minQuantity
+	if maxQuantity.GreaterThan(minQuantity) {
+		target = maxQuantity
+	}
+	shortage := target.Sub(projected)
+
+	unitCode, conversionRate, unitCost, err := s.purchaseUnit(ctx, tenantID, item)
+	if err != nil {
+		return ReorderSuggestionLine{}, false, err
+	}
+	quantity := shortage.Div(conversionRate).Ceil()
+
+	return ReorderSuggestionLine{
+		ProductID:         item.ProductID,
+		ProductCode:       item.ProductCode,
+		ProductName:       item.ProductName,
+		CurrentQuantity:   current,
+		MinQuantity:       minQuantity,
+		MaxQuantity:       maxQuantity,
+		IncomingQuantity:  incoming,
+		ShortageQuantity:  shortage,
+		BaseUnit:          item.Unit,
+		Unit:              unitCode,
+		ConversionRate:    conversionRate,
+		SuggestedQuantity: quantity,
+		UnitCost:          unitCost,
+		Amount:            quantity.Mul(unitCost).Round(2),
+	}, true, nil
+}
+
+// purchaseUnit returns the product's default purchase unit with its conversion rate to the base unit
+// and cost per unit. Falls back to the base unit when no default purchase unit converts to it.
+func (s *InventoryService) purchaseUnit(ctx context.Context, tenantID uuid.UUID, item *inventory.InventoryItem) (string, decimal.Decimal, decimal.Decimal, error) {
+	one := decimal.NewFromInt(1)
+
+	units, err := s.reorderUnitReader.FindByProductID(ctx, tenantID, item.ProductID)
+	if err != nil {
+		return "", decimal.Zero, decimal.Zero, err
+	}
+
+	graph := catalog.NewUnitConversionGraph(item.Unit, units)
+	for _, unit := range units {
+		if !unit.IsDefaultPurchaseUnit {
+			continue
+		}
+		rate, err := graph.Convert(one, unit.UnitCode, item.Unit)
+		if err != nil || !rate.IsPositive() {
+			break
+		}
+		unitCost := unit.DefaultPurchasePrice
+		if !unitCost.IsPositive() {
+			unitCost = item.UnitCost.Mul(rate).Round(4)
+		}
+		return unit.UnitCode, rate, unitCost, nil
+	}
+
+	return item.Unit, one, item.UnitCost, nil
+}
+
+// preferredSupplier returns the supplier of the product's most recent confirmed purchase order.
+// Returns uuid.Nil if the product has never been purchased.
+func (s *InventoryService) preferredSupplier(ctx context.Context, tenantID, productID uuid.UUID) (uuid.UUID, string, error) {
+	orders, err := s.reorderOrderReader.FindAllForTenant(ctx, tenantID, shared.Filter{
+		Page:     1,
+		PageSize: 1,
+		OrderBy:  "created_at",
+		OrderDir: "desc",
+		Filters: map[string]interface{}{
+			"product_id": productID,
+			"statuses": []string{
+				string(trade.PurchaseOrderStatusConfirmed),
+				string(trade.PurchaseOrderStatusPartialReceived),
+				string(trade.PurchaseOrderStatusCompleted),
+			},
+		},
+	})
+	if err != nil && !errors.Is(err, shared.ErrNotFound) {
+		return uuid.Nil, "", err
+	}
+	if len(orders) == 0 {
+		return uuid.Nil, "", nil
+	}
+	return orders[0].SupplierID, orders[0].SupplierName, nil
+}
@@ -0,0 +1,145 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubReorderOrderReader serves open purchase orders and the latest purchase order per product
+type stubReorderOrderReader struct {
+	pending []trade.PurchaseOrder
+	latest  map[uuid.UUID]trade.PurchaseOrder
+}
+
+func (r *stubReorderOrderReader) FindPendingReceipt(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error) {
+	return r.pending, nil
+}
+
+func (r *stubReorderOrderReader) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error) {
+	order, ok := r.latest[filter.Filters["product_id"].(uuid.UUID)]
+	if !ok {
+		return []trade.PurchaseOrder{}, nil
+	}
+	return []trade.PurchaseOrder{order}, nil
+}
+
+// stubReorderUnitReader serves product units by product
+type stubReorderUnitReader map[uuid.UUID][]catalog.ProductUnit
+
+func (r stubReorderUnitReader) FindByProductID(ctx context.Context, tenantID, productID uuid.UUID) ([]catalog.ProductUnit, error) {
+	return r[productID], nil
+}
+
+func newReorderTestItem(t *testing.T, tenantID, warehouseID uuid.UUID, code string, stock, minQty, maxQty int64) inventory.InventoryItem {
+	item := createTestInventoryItemWithStock(tenantID, warehouseID, uuid.New(), decimal.NewFromInt(stock), decimal.Zero)
+	item.ProductCode = code
+	item.ProductName = "Product " + code
+	item.Unit = "bag"
+	require.NoError(t, item.SetMinQuantity(decimal.NewFromInt(minQty)))
+	if maxQty > 0 {
+		require.NoError(t, item.SetMaxQuantity(decimal.NewFromInt(maxQty)))
+	}
+	return *item
+}
+
+func newReorderTestOrder(t *testing.T, tenantID, warehouseID, supplierID uuid.UUID, supplierName string, productID uuid.UUID, ordered, received int64) trade.PurchaseOrder {
+	order, err := trade.NewPurchaseOrder(tenantID, "PO-"+uuid.NewString()[:8], supplierID, supplierName)
+	require.NoError(t, err)
+	order.WarehouseID = &warehouseID
+	order.Items = []trade.PurchaseOrderItem{{
+		ProductID:        productID,
+		OrderedQuantity:  decimal.NewFromInt(ordered),
+		ReceivedQuantity: decimal.NewFromInt(received),
+		ConversionRate:   decimal.NewFromInt(1),
+	}}
+	return *order
+}
+
+func TestInventoryService_GenerateReorderSuggestions(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	warehouseID := uuid.New()
+	otherWarehouseID := uuid.New()
+	supplierID := uuid.New()
+
+	// A: 8 on hand + 12 incoming is below the minimum of 30, so top up to 100 in pallets of 40 bags
+	itemA := newReorderTestItem(t, tenantID, warehouseID, "A", 8, 30, 100)
+	// B: never purchased and no purchase unit, so top up to the minimum in bags at the item's cost
+	itemB := newReorderTestItem(t, tenantID, warehouseID, "B", 5, 10, 0)
+	// C: 2 on hand + 10 incoming already covers the minimum of 10
+	itemC := newReorderTestItem(t, tenantID, warehouseID, "C", 2, 10, 50)
+
+	orderA := newReorderTestOrder(t, tenantID, warehouseID, supplierID, "Green Fields Co.", itemA.ProductID, 20, 8)
+	orderC := newReorderTestOrder(t, tenantID, warehouseID, supplierID, "Green Fields Co.", itemC.ProductID, 10, 0)
+	otherWarehouseOrder := newReorderTestOrder(t, tenantID, otherWarehouseID, supplierID, "Green Fields Co.", itemA.ProductID, 500, 0)
+
+	pallet, err := catalog.NewProductUnit(tenantID, itemA.ProductID, "pallet", "Pallet", decimal.NewFromInt(40))
+	require.NoError(t, err)
+	pallet.IsDefaultPurchaseUnit = true
+	pallet.DefaultPurchasePrice = decimal.NewFromInt(1200)
+
+	invRepo := new(MockInventoryItemRepository)
+	invRepo.On("FindAllForTenant", ctx, tenantID, mock.MatchedBy(func(filter shared.Filter) bool {
+		return filter.Filters["warehouse_id"] == warehouseID && filter.Filters["below_minimum"] == true
+	})).Return([]inventory.InventoryItem{itemA, itemB, itemC}, nil)
+
+	service := NewInventoryServiceWithLockRepo(invRepo, new(MockStockLockRepository), new(MockTransactionRepository))
+	service.SetReorderSources(
+		&stubReorderOrderReader{
+			pending: []trade.PurchaseOrder{orderA, orderC, otherWarehouseOrder},
+			latest:  map[uuid.UUID]trade.PurchaseOrder{itemA.ProductID: orderA, itemC.ProductID: orderC},
+		},
+		stubReorderUnitReader{itemA.ProductID: {*pallet}},
+	)
+
+	result, err := service.GenerateReorderSuggestions(ctx, tenantID, warehouseID)
+
+	require.NoError(t, err)
+	assert.Equal(t, warehouseID, result.WarehouseID)
+	assert.Equal(t, 2, result.LineCount)
+	require.Len(t, result.Suppliers, 2)
+
+	withSupplier := result.Suppliers[0]
+	require.NotNil(t, withSupplier.SupplierID)
+	assert.Equal(t, supplierID, *withSupplier.SupplierID)
+	assert.Equal(t, "Green Fields Co.", withSupplier.SupplierName)
+	require.Len(t, withSupplier.Lines, 1)
+	lineA := withSupplier.Lines[0]
+	assert.Equal(t, itemA.ProductID, lineA.ProductID)
+	assert.True(t, lineA.IncomingQuantity.Equal(decimal.NewFromInt(12)))
+	assert.True(t, lineA.ShortageQuantity.Equal(decimal.NewFromInt(80)))
+	assert.Equal(t, "pallet", lineA.Unit)
+	assert.True(t, lineA.ConversionRate.Equal(decimal.NewFromInt(40)))
+	assert.True(t, lineA.SuggestedQuantity.Equal(decimal.NewFromInt(2)))
+	assert.True(t, lineA.Amount.Equal(decimal.NewFromInt(2400)))
+	assert.True(t, withSupplier.TotalAmount.Equal(decimal.NewFromInt(2400)))
+
+	unassigned := result.Suppliers[1]
+	assert.Nil(t, unassigned.SupplierID)
+	require.Len(t, unassigned.Lines, 1)
+	lineB := unassigned.Lines[0]
+	assert.Equal(t, itemB.ProductID, lineB.ProductID)
+	assert.Equal(t, "bag", lineB.Unit)
+	assert.True(t, lineB.SuggestedQuantity.Equal(decimal.NewFromInt(5)))
+	assert.True(t, lineB.Amount.Equal(decimal.NewFromInt(50)))
+}
+
+func TestInventoryService_GenerateReorderSuggestions_NotConfigured(t *testing.T) {
+	service := NewInventoryServiceWithLockRepo(new(MockInventoryItemRepository), new(MockStockLockRepository), new(MockTransactionRepository))
+
+	_, err := service.GenerateReorderSuggestions(context.Background(), uuid.New(), uuid.New())
+
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "REORDER_NOT_CONFIGURED", domainErr.Code)
+}
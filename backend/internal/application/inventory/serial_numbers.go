@@ -0,0 +1,190 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SerializedProductReader provides the products whose serial number tracking flag is
+// consulted when stock is received or shipped
+type SerializedProductReader interface {
+	// FindByIDForTenant finds a product by ID within a tenant
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error)
+}
+
+// SetSerialNumberTracking sets the product reader and serial number repository used to track
+// serialized products. Without them, serial numbers are neither required nor recorded.
+func (s *InventoryService) SetSerialNumberTracking(productReader SerializedProductReader, serialNumberRepo inventory.SerialNumberRepository) {
+	s.productReader = productReader
+	s.serialNumberRepo = serialNumberRepo
+}
+
+// serialNumbersFor validates the serial numbers given for a stock movement of a product.
+// Serialized products need exactly one serial number per unit; other products accept none.
+// When skip is set, a serialized product may be moved without serial numbers, leaving its units untracked.
+// Returns the normalized serial numbers, or nil for products that are not serialized.
+func (s *InventoryService) serialNumbersFor(ctx context.Context, tenantID, productID uuid.UUID, quantity decimal.Decimal, serialNumbers []string, skip bool) ([]string, error) {
+	serialized, err := s.isSerialized(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !serialized {
+		if len(serialNumbers) > 0 {
+			return nil, shared.NewDomainError("PRODUCT_NOT_SERIALIZED", "Serial numbers can only be given for serialized products")
+		}
+		return nil, nil
+	}
+	if skip && len(serialNumbers) == 0 {
+		return nil, nil
+	}
+	return inventory.NormalizeSerialNumbers(serialNumbers, quantity)
+}
+
+// isSerialized returns true if serial numbers are tracked for the product
+func (s *InventoryService) isSerialized(ctx context.Context, tenantID, productID uuid.UUID) (bool, error) {
+	if s.productReader == nil || s.serialNumberRepo == nil {
+		return false, nil
+	}
+	product, err := s.productReader.FindByIDForTenant(ctx, tenantID, productID)
+	if err != nil {
+		return false, err
+	}
+	return product.IsSerialized, nil
+}
+
+// receiveSerialNumbers registers received units in an inventory item. Unknown serial numbers
+// are created; shipped ones are taken back into stock. A serial number that is still in stock
+// cannot be received again.
+func receiveSerialNumbers(ctx context.Context, repo inventory.SerialNumberRepository, item *inventory.InventoryItem, serialNumbers []string, sourceType inventory.SourceType, sourceID string) error {
+	if len(serialNumbers) == 0 {
+		return nil
+	}
+
+	existing, err := repo.FindBySerialNumbers(ctx, item.TenantID, item.ProductID, serialNumbers)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]*inventory.SerialNumber, len(existing))
+	for i := range existing {
+		known[existing[i].SerialNumber] = &existing[i]
+	}
+
+	for _, serial := range serialNumbers {
+		sn, ok := known[serial]
+		if ok {
+			if err := sn.Receive(item, sourceType, sourceID); err != nil {
+				return err
+			}
+		} else {
+			sn, err = inventory.NewSerialNumber(item, serial, sourceType, sourceID)
+			if err != nil {
+				return err
+			}
+		}
+		if err := repo.Save(ctx, sn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shipSerialNumbers marks shipped units of an inventory item. Every serial number must be
+// in stock in that item.
+func shipSerialNumbers(ctx context.Context, repo inventory.SerialNumberRepository, item *inventory.InventoryItem, serialNumbers []string, sourceType inventory.SourceType, sourceID string) error {
+	if len(serialNumbers) == 0 {
+		return nil
+	}
+
+	existing, err := repo.FindBySerialNumbers(ctx, item.TenantID, item.ProductID, serialNumbers)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]*inventory.SerialNumber, len(existing))
+	for i := range existing {
+		known[existing[i].SerialNumber] = &existing[i]
+	}
+
+	for _, serial := range serialNumbers {
+		sn, ok := known[serial]
+		if !ok {
+			return shared.NewDomainError("SERIAL_NOT_FOUND", "Serial number "+serial+" has not been received")
+		}
+		if err := sn.Ship(item, sourceType, sourceID); err != nil {
+			return err
+		}
+		if err := repo.Save(ctx, sn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferSerialNumbers moves units from one inventory item to another. Every serial number
+// must be in stock in the from item.
+func transferSerialNumbers(ctx context.Context, repo inventory.SerialNumberRepository, from, to *inventory.InventoryItem, serialNumbers []string, sourceID string) error {
+	if len(serialNumbers) == 0 {
+		return nil
+	}
+
+	existing, err := repo.FindBySerialNumbers(ctx, from.TenantID, from.ProductID, serialNumbers)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]*inventory.SerialNumber, len(existing))
+	for i := range existing {
+		known[existing[i].SerialNumber] = &existing[i]
+	}
+
+	for _, serial := range serialNumbers {
+		sn, ok := known[serial]
+		if !ok {
+			return shared.NewDomainError("SERIAL_NOT_FOUND", "Serial number "+serial+" has not been received")
+		}
+		if err := sn.TransferTo(from, to, inventory.SourceTypeTransfer, sourceID); err != nil {
+			return err
+		}
+		if err := repo.Save(ctx, sn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSerialNumber retrieves a serial number with its movement history
+func (s *InventoryService) GetSerialNumber(ctx context.Context, tenantID, id uuid.UUID) (*SerialNumberResponse, error) {
+	if s.serialNumberRepo == nil {
+		return nil, shared.NewDomainError("SERIAL_TRACKING_NOT_CONFIGURED", "Serial number tracking is not available")
+	}
+	sn, err := s.serialNumberRepo.FindByIDForTenant(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.serialNumberWithHistory(ctx, sn)
+}
+
+// LookupSerialNumber retrieves a product's serial number with its movement history
+func (s *InventoryService) LookupSerialNumber(ctx context.Context, tenantID, productID uuid.UUID, serialNumber string) (*SerialNumberResponse, error) {
+	if s.serialNumberRepo == nil {
+		return nil, shared.NewDomainError("SERIAL_TRACKING_NOT_CONFIGURED", "Serial number tracking is not available")
+	}
+	sn, err := s.serialNumberRepo.FindBySerialNumber(ctx, tenantID, productID, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.serialNumberWithHistory(ctx, sn)
+}
+
+func (s *InventoryService) serialNumberWithHistory(ctx context.Context, sn *inventory.SerialNumber) (*SerialNumberResponse, error) {
+	movements, err := s.serialNumberRepo.FindMovements(ctx, sn.ID)
+	if err != nil {
+		return nil, err
+	}
+	response := ToSerialNumberResponse(sn, movements)
+	return &response, nil
+}
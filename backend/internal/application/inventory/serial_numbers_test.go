@@ -0,0 +1,322 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSerialProductReader serves products by ID
+type stubSerialProductReader map[uuid.UUID]*catalog.Product
+
+func (r stubSerialProductReader) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	product, ok := r[id]
+	if !ok {
+		return nil, shared.ErrNotFound
+	}
+	return product, nil
+}
+
+// memorySerialNumberRepo keeps serial numbers and their movements in memory
+type memorySerialNumberRepo struct {
+	serials   map[string]inventory.SerialNumber
+	movements map[uuid.UUID][]inventory.SerialNumberMovement
+}
+
+func newMemorySerialNumberRepo() *memorySerialNumberRepo {
+	return &memorySerialNumberRepo{
+		serials:   make(map[string]inventory.SerialNumber),
+		movements: make(map[uuid.UUID][]inventory.SerialNumberMovement),
+	}
+}
+
+func (r *memorySerialNumberRepo) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*inventory.SerialNumber, error) {
+	for _, sn := range r.serials {
+		if sn.ID == id && sn.TenantID == tenantID {
+			return &sn, nil
+		}
+	}
+	return nil, shared.ErrNotFound
+}
+
+func (r *memorySerialNumberRepo) FindBySerialNumber(ctx context.Context, tenantID, productID uuid.UUID, serialNumber string) (*inventory.SerialNumber, error) {
+	sn, ok := r.serials[productID.String()+"/"+serialNumber]
+	if !ok || sn.TenantID != tenantID {
+		return nil, shared.ErrNotFound
+	}
+	return &sn, nil
+}
+
+func (r *memorySerialNumberRepo) FindBySerialNumbers(ctx context.Context, tenantID, productID uuid.UUID, serialNumbers []string) ([]inventory.SerialNumber, error) {
+	var result []inventory.SerialNumber
+	for _, serial := range serialNumbers {
+		if sn, err := r.FindBySerialNumber(ctx, tenantID, productID, serial); err == nil {
+			result = append(result, *sn)
+		}
+	}
+	return result, nil
+}
+
+func (r *memorySerialNumberRepo) FindMovements(ctx context.Context, serialNumberID uuid.UUID) ([]inventory.SerialNumberMovement, error) {
+	return r.movements[serialNumberID], nil
+}
+
+func (r *memorySerialNumberRepo) Save(ctx context.Context, sn *inventory.SerialNumber) error {
+	r.movements[sn.ID] = append(r.movements[sn.ID], sn.Movements...)
+	saved := *sn
+	saved.Movements = nil
+	r.serials[sn.ProductID.String()+"/"+sn.SerialNumber] = saved
+	return nil
+}
+
+func TestInventoryService_SerialNumbers(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	warehouseID := uuid.New()
+
+	serialized, err := catalog.NewProduct(tenantID, "PHONE-01", "Phone", "pcs")
+	require.NoError(t, err)
+	serialized.SetSerialized(true)
+	plain, err := catalog.NewProduct(tenantID, "CABLE-01", "Cable", "pcs")
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (*InventoryService, *inventory.InventoryItem, *memorySerialNumberRepo) {
+		item := createTestInventoryItem(tenantID, warehouseID, serialized.ID)
+		invRepo := new(MockInventoryItemRepository)
+		lockRepo := new(MockStockLockRepository)
+		txRepo := new(MockTransactionRepository)
+		invRepo.On("GetOrCreate", mock.Anything, tenantID, warehouseID, mock.Anything).Return(item, nil)
+		invRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		invRepo.On("SaveWithLock", mock.Anything, mock.Anything).Return(nil)
+		lockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		txRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		serialRepo := newMemorySerialNumberRepo()
+
+		service := NewInventoryServiceWithLockRepo(invRepo, lockRepo, txRepo)
+		service.SetSerialNumberTracking(stubSerialProductReader{serialized.ID: serialized, plain.ID: plain}, serialRepo)
+		return service, item, serialRepo
+	}
+
+	receive := func(service *InventoryService, productID uuid.UUID, qty int64, serials ...string) error {
+		_, err := service.IncreaseStock(ctx, tenantID, IncreaseStockRequest{
+			WarehouseID:   warehouseID,
+			ProductID:     productID,
+			Quantity:      decimal.NewFromInt(qty),
+			UnitCost:      decimal.NewFromInt(100),
+			SourceType:    "PURCHASE_ORDER",
+			SourceID:      "PO-001",
+			SerialNumbers: serials,
+		})
+		return err
+	}
+
+	errorCode := func(t *testing.T, err error) string {
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		return domainErr.Code
+	}
+
+	t.Run("receive registers serial numbers", func(t *testing.T) {
+		service, item, serialRepo := setup(t)
+
+		require.NoError(t, receive(service, serialized.ID, 2, "SN-1", " SN-2 "))
+
+		sn, err := service.LookupSerialNumber(ctx, tenantID, serialized.ID, "SN-2")
+		require.NoError(t, err)
+		assert.Equal(t, "IN_STOCK", sn.Status)
+		assert.Equal(t, item.ID, sn.InventoryItemID)
+		require.Len(t, sn.Movements, 1)
+		assert.Equal(t, "RECEIVED", sn.Movements[0].MovementType)
+		assert.Len(t, serialRepo.serials, 2)
+	})
+
+	t.Run("serialized product requires one serial number per unit", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		assert.Equal(t, "SERIAL_COUNT_MISMATCH", errorCode(t, receive(service, serialized.ID, 2, "SN-1")))
+		assert.Equal(t, "SERIAL_COUNT_MISMATCH", errorCode(t, receive(service, serialized.ID, 1)))
+		assert.Equal(t, "DUPLICATE_SERIAL_NUMBER", errorCode(t, receive(service, serialized.ID, 2, "SN-1", "SN-1")))
+	})
+
+	t.Run("serial numbers are rejected for products that are not serialized", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		assert.Equal(t, "PRODUCT_NOT_SERIALIZED", errorCode(t, receive(service, plain.ID, 1, "SN-1")))
+		assert.NoError(t, receive(service, plain.ID, 1))
+	})
+
+	t.Run("a serial number in stock cannot be received twice", func(t *testing.T) {
+		service, _, _ := setup(t)
+		require.NoError(t, receive(service, serialized.ID, 1, "SN-1"))
+
+		assert.Equal(t, "SERIAL_ALREADY_IN_STOCK", errorCode(t, receive(service, serialized.ID, 1, "SN-1")))
+	})
+
+	// deductSetup receives three units and locks two of them for a sales order
+	deductSetup := func(t *testing.T) (*InventoryService, *memorySerialNumberRepo, func(serials ...string) error) {
+		service, item, serialRepo := setup(t)
+		require.NoError(t, receive(service, serialized.ID, 3, "SN-1", "SN-2", "SN-3"))
+
+		lock, err := item.LockStock(decimal.NewFromInt(2), "SALES_ORDER", "SO-001", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		item.Locks = nil
+		service.lockRepo.(*MockStockLockRepository).On("FindByID", mock.Anything, lock.ID).Return(lock, nil)
+
+		return service, serialRepo, func(serials ...string) error {
+			return service.DeductStock(ctx, tenantID, DeductStockRequest{
+				LockID:        lock.ID,
+				SourceType:    "SALES_ORDER",
+				SourceID:      "SO-001",
+				SerialNumbers: serials,
+			})
+		}
+	}
+
+	t.Run("deduct rejects serial numbers that do not match the shipment", func(t *testing.T) {
+		_, _, deduct := deductSetup(t)
+		assert.Equal(t, "SERIAL_COUNT_MISMATCH", errorCode(t, deduct("SN-1")))
+
+		_, _, deduct = deductSetup(t)
+		assert.Equal(t, "SERIAL_NOT_FOUND", errorCode(t, deduct("SN-1", "SN-9")))
+	})
+
+	t.Run("deduct ships the given serial numbers and a return receives them back", func(t *testing.T) {
+		service, serialRepo, deduct := deductSetup(t)
+		require.NoError(t, deduct("SN-1", "SN-3"))
+
+		shipped, err := service.LookupSerialNumber(ctx, tenantID, serialized.ID, "SN-3")
+		require.NoError(t, err)
+		assert.Equal(t, "SHIPPED", shipped.Status)
+		kept, err := service.LookupSerialNumber(ctx, tenantID, serialized.ID, "SN-2")
+		require.NoError(t, err)
+		assert.Equal(t, "IN_STOCK", kept.Status)
+
+		_, err = service.IncreaseStock(ctx, tenantID, IncreaseStockRequest{
+			WarehouseID:   warehouseID,
+			ProductID:     serialized.ID,
+			Quantity:      decimal.NewFromInt(1),
+			UnitCost:      decimal.NewFromInt(100),
+			SourceType:    "SALES_RETURN",
+			SourceID:      "SR-001",
+			SerialNumbers: []string{"SN-3"},
+		})
+		require.NoError(t, err)
+
+		history, err := service.GetSerialNumber(ctx, tenantID, shipped.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "RETURNED", history.Status)
+		require.Len(t, history.Movements, 3)
+		assert.Equal(t, "RECEIVED", history.Movements[0].MovementType)
+		assert.Equal(t, "SHIPPED", history.Movements[1].MovementType)
+		assert.Equal(t, "SO-001", history.Movements[1].SourceID)
+		assert.Equal(t, "RETURNED", history.Movements[2].MovementType)
+		assert.Len(t, serialRepo.serials, 3)
+	})
+	t.Run("order event flows move serialized units without serial numbers", func(t *testing.T) {
+		service, item, serialRepo := setup(t)
+
+		_, err := service.IncreaseStock(ctx, tenantID, IncreaseStockRequest{
+			WarehouseID:       warehouseID,
+			ProductID:         serialized.ID,
+			Quantity:          decimal.NewFromInt(2),
+			UnitCost:          decimal.NewFromInt(100),
+			SourceType:        "PURCHASE_ORDER",
+			SourceID:          "PO-002",
+			SkipSerialNumbers: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, item.AvailableQuantity.Amount().Equal(decimal.NewFromInt(2)))
+		assert.Empty(t, serialRepo.serials)
+
+		// Serial numbers that are given are still checked and recorded
+		_, err = service.IncreaseStock(ctx, tenantID, IncreaseStockRequest{
+			WarehouseID:       warehouseID,
+			ProductID:         serialized.ID,
+			Quantity:          decimal.NewFromInt(2),
+			UnitCost:          decimal.NewFromInt(100),
+			SourceType:        "PURCHASE_ORDER",
+			SourceID:          "PO-003",
+			SerialNumbers:     []string{"SN-1"},
+			SkipSerialNumbers: true,
+		})
+		assert.Equal(t, "SERIAL_COUNT_MISMATCH", errorCode(t, err))
+	})
+
+	t.Run("decrease removes the given serial numbers", func(t *testing.T) {
+		service, item, _ := setup(t)
+		require.NoError(t, receive(service, serialized.ID, 2, "SN-1", "SN-2"))
+		service.inventoryRepo.(*MockInventoryItemRepository).
+			On("FindByWarehouseAndProduct", mock.Anything, tenantID, warehouseID, serialized.ID).Return(item, nil)
+
+		decrease := func(serials ...string) error {
+			return service.DecreaseStock(ctx, tenantID, DecreaseStockRequest{
+				WarehouseID:   warehouseID,
+				ProductID:     serialized.ID,
+				Quantity:      decimal.NewFromInt(1),
+				SourceType:    "PURCHASE_RETURN",
+				SourceID:      "PR-001",
+				SerialNumbers: serials,
+			})
+		}
+		assert.Equal(t, "SERIAL_COUNT_MISMATCH", errorCode(t, decrease()))
+		require.NoError(t, decrease("SN-2"))
+
+		returned, err := service.LookupSerialNumber(ctx, tenantID, serialized.ID, "SN-2")
+		require.NoError(t, err)
+		assert.Equal(t, "SHIPPED", returned.Status)
+		assert.Equal(t, "PR-001", returned.Movements[1].SourceID)
+	})
+
+	t.Run("transfer moves the serial numbers to the destination warehouse", func(t *testing.T) {
+		service, from, _ := setup(t)
+		require.NoError(t, receive(service, serialized.ID, 2, "SN-1", "SN-2"))
+		toWarehouseID := uuid.New()
+		to := createTestInventoryItem(tenantID, toWarehouseID, serialized.ID)
+		invRepo := service.inventoryRepo.(*MockInventoryItemRepository)
+		invRepo.On("FindByWarehouseAndProduct", mock.Anything, tenantID, warehouseID, serialized.ID).Return(from, nil)
+		invRepo.On("GetOrCreate", mock.Anything, tenantID, toWarehouseID, serialized.ID).Return(to, nil)
+		service.transactionRepo.(*MockTransactionRepository).On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+		transfer := func(serials ...string) error {
+			_, err := service.TransferStock(ctx, tenantID, TransferStockRequest{
+				FromWarehouseID: warehouseID,
+				ToWarehouseID:   toWarehouseID,
+				ProductID:       serialized.ID,
+				Quantity:        decimal.NewFromInt(1),
+				SerialNumbers:   serials,
+			})
+			return err
+		}
+		assert.Equal(t, "SERIAL_COUNT_MISMATCH", errorCode(t, transfer()))
+		require.NoError(t, transfer("SN-1"))
+
+		moved, err := service.LookupSerialNumber(ctx, tenantID, serialized.ID, "SN-1")
+		require.NoError(t, err)
+		assert.Equal(t, "IN_STOCK", moved.Status)
+		assert.Equal(t, to.ID, moved.InventoryItemID)
+		assert.Equal(t, toWarehouseID, moved.WarehouseID)
+		require.Len(t, moved.Movements, 2)
+		assert.Equal(t, "TRANSFERRED", moved.Movements[1].MovementType)
+	})
+
+	t.Run("adjust is rejected for serialized products", func(t *testing.T) {
+		service, _, _ := setup(t)
+
+		_, err := service.AdjustStock(ctx, tenantID, AdjustStockRequest{
+			WarehouseID:    warehouseID,
+			ProductID:      serialized.ID,
+			ActualQuantity: decimal.NewFromInt(5),
+			Reason:         "Stock count",
+		})
+		assert.Equal(t, "SERIALIZED_PRODUCT", errorCode(t, err))
+	})
+}
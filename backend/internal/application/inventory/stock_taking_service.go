@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/erp/backend/internal/domain/inventory"
@@ -13,6 +14,10 @@ import (
 type StockTakingService struct {
 	stockTakingRepo inventory.StockTakingRepository
 	eventBus        shared.EventBus
+
+	// Optional, for adjusting inventory to the counted quantities on approval
+	inventoryService *InventoryService
+	txScope          TransactionScope
 }
 
 // NewStockTakingService creates a new StockTakingService
@@ -26,6 +31,14 @@ func NewStockTakingService(
 	}
 }
 
+// SetInventoryAdjustment sets the inventory service used to adjust stock to the counted
+// quantities when a stock taking is approved, and the transaction scope the approval and
+// its adjustments share. Without an inventory service, approval leaves inventory unchanged.
+func (s *StockTakingService) SetInventoryAdjustment(inventoryService *InventoryService, txScope TransactionScope) {
+	s.inventoryService = inventoryService
+	s.txScope = txScope
+}
+
 // ===================== Query Methods =====================
 
 // GetByID retrieves a stock taking by ID
@@ -392,7 +405,7 @@ func (s *StockTakingService) SubmitForApproval(ctx context.Context, tenantID, id
 	return &response, nil
 }
 
-// Approve approves the stock taking
+// Approve approves the stock taking and adjusts inventory to the counted quantities
 func (s *StockTakingService) Approve(ctx context.Context, tenantID, id uuid.UUID, req ApproveStockTakingRequest) (*StockTakingResponse, error) {
 	st, err := s.stockTakingRepo.FindByIDForTenant(ctx, tenantID, id)
 	if err != nil {
@@ -403,17 +416,71 @@ func (s *StockTakingService) Approve(ctx context.Context, tenantID, id uuid.UUID
 		return nil, err
 	}
 
-	if err := s.stockTakingRepo.Save(ctx, st); err != nil {
+	adjustmentEvents, err := s.saveApproval(ctx, tenantID, st, req.ApproverID)
+	if err != nil {
 		return nil, err
 	}
 
-	// Publish domain events (StockTakingApprovedEvent triggers inventory adjustments)
+	// Publish domain events
 	s.publishEvents(ctx, st)
+	if len(adjustmentEvents) > 0 && s.inventoryService.eventPublisher != nil {
+		_ = s.inventoryService.eventPublisher.Publish(ctx, adjustmentEvents...)
+	}
+	if s.inventoryService != nil {
+		for _, item := range st.GetItemsWithDifference() {
+			s.inventoryService.recordAdjustment(ctx, tenantID, st.WarehouseID, item.DifferenceQty)
+		}
+	}
 
 	response := ToStockTakingResponse(st)
 	return &response, nil
 }
 
+// saveApproval saves an approved stock taking and, in the same transaction, adjusts the
+// inventory of every item with a variance to its counted quantity. The adjustment
+// transactions are tagged with the stock taking number. Returns the inventory domain events
+// for publishing after commit.
+func (s *StockTakingService) saveApproval(ctx context.Context, tenantID uuid.UUID, st *inventory.StockTaking, approverID uuid.UUID) ([]shared.DomainEvent, error) {
+	if s.inventoryService == nil {
+		return nil, s.stockTakingRepo.Save(ctx, st)
+	}
+
+	scope := s.txScope
+	if scope == nil {
+		scope = NewNoOpTransactionScope(s.inventoryService.inventoryRepo, s.inventoryService.lockRepo, s.inventoryService.transactionRepo, s.stockTakingRepo, s.inventoryService.serialNumberRepo)
+	}
+
+	var events []shared.DomainEvent
+	err := scope.Execute(ctx, func(repos TransactionalRepositories) error {
+		events = nil
+		if err := repos.StockTakingRepo().Save(ctx, st); err != nil {
+			return err
+		}
+
+		for _, item := range st.GetItemsWithDifference() {
+			_, itemEvents, err := s.inventoryService.AdjustStockInTransaction(ctx, repos, tenantID, AdjustStockRequest{
+				WarehouseID:    st.WarehouseID,
+				ProductID:      item.ProductID,
+				ActualQuantity: item.ActualQuantity,
+				Reason:         fmt.Sprintf("Stock taking %s variance", st.TakingNumber),
+				SourceType:     string(inventory.SourceTypeStockTaking),
+				SourceID:       st.ID.String(),
+				Reference:      st.TakingNumber,
+				OperatorID:     &approverID,
+			})
+			if err != nil {
+				return err
+			}
+			events = append(events, itemEvents...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
 // Reject rejects the stock taking
 func (s *StockTakingService) Reject(ctx context.Context, tenantID, id uuid.UUID, req RejectStockTakingRequest) (*StockTakingResponse, error) {
 	st, err := s.stockTakingRepo.FindByIDForTenant(ctx, tenantID, id)
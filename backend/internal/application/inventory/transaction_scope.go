@@ -26,6 +26,9 @@ type TransactionScope interface {
 //     individual lock state changes. Locks are child entities of InventoryItem, but have separate
 //     storage for query performance.
 //   - TransactionRepo: Append-only repository for inventory transaction records.
+//   - StockTakingRepo: Repository for the StockTaking aggregate, so an approval and the
+//     inventory adjustments it produces are committed together.
+//   - SerialNumberRepo: Repository for the serial numbers received and shipped with stock.
 //
 // Note: StockBatch is a child entity within the InventoryItem aggregate and does NOT have
 // independent repository access. Batches are persisted automatically via GORM's association
@@ -37,14 +40,20 @@ type TransactionalRepositories interface {
 	LockRepo() inventory.StockLockRepository
 	// TransactionRepo returns the inventory transaction repository scoped to the current transaction
 	TransactionRepo() inventory.InventoryTransactionRepository
+	// StockTakingRepo returns the stock taking repository scoped to the current transaction
+	StockTakingRepo() inventory.StockTakingRepository
+	// SerialNumberRepo returns the serial number repository scoped to the current transaction
+	SerialNumberRepo() inventory.SerialNumberRepository
 }
 
 // NoOpTransactionScope is a transaction scope that doesn't actually use transactions.
 // This is useful for testing or when transaction support is not required.
 type NoOpTransactionScope struct {
-	inventoryRepo   inventory.InventoryItemRepository
-	lockRepo        inventory.StockLockRepository
-	transactionRepo inventory.InventoryTransactionRepository
+	inventoryRepo    inventory.InventoryItemRepository
+	lockRepo         inventory.StockLockRepository
+	transactionRepo  inventory.InventoryTransactionRepository
+	stockTakingRepo  inventory.StockTakingRepository
+	serialNumberRepo inventory.SerialNumberRepository
 }
 
 // NewNoOpTransactionScope creates a NoOpTransactionScope with the given repositories.
@@ -52,11 +61,15 @@ func NewNoOpTransactionScope(
 	inventoryRepo inventory.InventoryItemRepository,
 	lockRepo inventory.StockLockRepository,
 	transactionRepo inventory.InventoryTransactionRepository,
+	stockTakingRepo inventory.StockTakingRepository,
+	serialNumberRepo inventory.SerialNumberRepository,
 ) *NoOpTransactionScope {
 	return &NoOpTransactionScope{
-		inventoryRepo:   inventoryRepo,
-		lockRepo:        lockRepo,
-		transactionRepo: transactionRepo,
+		inventoryRepo:    inventoryRepo,
+		lockRepo:         lockRepo,
+		transactionRepo:  transactionRepo,
+		stockTakingRepo:  stockTakingRepo,
+		serialNumberRepo: serialNumberRepo,
 	}
 }
 
@@ -80,6 +93,16 @@ func (s *NoOpTransactionScope) TransactionRepo() inventory.InventoryTransactionR
 	return s.transactionRepo
 }
 
+// StockTakingRepo returns the stock taking repository.
+func (s *NoOpTransactionScope) StockTakingRepo() inventory.StockTakingRepository {
+	return s.stockTakingRepo
+}
+
+// SerialNumberRepo returns the serial number repository.
+func (s *NoOpTransactionScope) SerialNumberRepo() inventory.SerialNumberRepository {
+	return s.serialNumberRepo
+}
+
 // Ensure NoOpTransactionScope implements both interfaces
 var _ TransactionScope = (*NoOpTransactionScope)(nil)
 var _ TransactionalRepositories = (*NoOpTransactionScope)(nil)
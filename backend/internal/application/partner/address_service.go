@@ -0,0 +1,269 @@
+package partner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// AddressService manages the address books of customers and suppliers.
+// Every owner keeps exactly one default address per type while it has addresses of that type:
+// the first address of a type becomes its default, a new default replaces the previous one,
+// and deleting the default promotes the oldest remaining address of the type.
+type AddressService struct {
+	addressRepo  partner.AddressRepository
+	customerRepo partner.CustomerRepository
+	supplierRepo partner.SupplierRepository
+	txScope      TransactionScope
+}
+
+// NewAddressService creates a new AddressService
+func NewAddressService(
+	addressRepo partner.AddressRepository,
+	customerRepo partner.CustomerRepository,
+	supplierRepo partner.SupplierRepository,
+) *AddressService {
+	return &AddressService{
+		addressRepo:  addressRepo,
+		customerRepo: customerRepo,
+		supplierRepo: supplierRepo,
+	}
+}
+
+// SetTransactionScope sets the transaction scope used to move the default flag atomically
+func (s *AddressService) SetTransactionScope(txScope TransactionScope) {
+	s.txScope = txScope
+}
+
+// inTransaction runs fn with the address repository of the transaction scope, or the
+// plain repository when no scope is set. Clearing the previous default and saving the
+// new one in one transaction keeps the owner from ending up with none or two defaults.
+func (s *AddressService) inTransaction(ctx context.Context, fn func(repo partner.AddressRepository) error) error {
+	if s.txScope == nil {
+		return fn(s.addressRepo)
+	}
+	return s.txScope.Execute(ctx, func(repos TransactionalRepositories) error {
+		return fn(repos.AddressRepo())
+	})
+}
+
+// List lists the addresses of a customer or supplier
+func (s *AddressService) List(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID) ([]AddressResponse, error) {
+	if err := s.verifyOwner(ctx, tenantID, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.addressRepo.FindByOwner(ctx, tenantID, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return ToAddressResponses(addresses), nil
+}
+
+// GetByID gets an address of a customer or supplier
+func (s *AddressService) GetByID(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID, id uuid.UUID) (*AddressResponse, error) {
+	address, err := s.findOwned(ctx, tenantID, ownerType, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := ToAddressResponse(address)
+	return &response, nil
+}
+
+// Create adds an address to the address book of a customer or supplier
+func (s *AddressService) Create(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID, req CreateAddressRequest) (*AddressResponse, error) {
+	if err := s.verifyOwner(ctx, tenantID, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	address, err := partner.NewAddress(tenantID, ownerType, ownerID, partner.AddressType(req.Type), req.Detail)
+	if err != nil {
+		return nil, err
+	}
+	if err := address.Update(req.Province, req.City, req.Detail, req.PostalCode, req.Country); err != nil {
+		return nil, err
+	}
+	if err := address.SetContact(req.Label, req.ContactName, req.Phone); err != nil {
+		return nil, err
+	}
+
+	err = s.inTransaction(ctx, func(repo partner.AddressRepository) error {
+		makeDefault := req.IsDefault
+		if !makeDefault {
+			// The first address of a type becomes its default
+			_, err := repo.FindDefault(ctx, tenantID, ownerType, ownerID, address.Type)
+			if errors.Is(err, shared.ErrNotFound) {
+				makeDefault = true
+			} else if err != nil {
+				return err
+			}
+		}
+		if makeDefault {
+			if err := repo.ClearDefault(ctx, tenantID, ownerType, ownerID, address.Type); err != nil {
+				return err
+			}
+			address.SetAsDefault(true)
+		}
+		return repo.Save(ctx, address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := ToAddressResponse(address)
+	return &response, nil
+}
+
+// Update updates an address of a customer or supplier
+func (s *AddressService) Update(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID, id uuid.UUID, req UpdateAddressRequest) (*AddressResponse, error) {
+	address, err := s.findOwned(ctx, tenantID, ownerType, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update location if provided
+	if req.Province != nil || req.City != nil || req.Detail != nil || req.PostalCode != nil || req.Country != nil {
+		province := address.Province
+		if req.Province != nil {
+			province = *req.Province
+		}
+		city := address.City
+		if req.City != nil {
+			city = *req.City
+		}
+		detail := address.Detail
+		if req.Detail != nil {
+			detail = *req.Detail
+		}
+		postalCode := address.PostalCode
+		if req.PostalCode != nil {
+			postalCode = *req.PostalCode
+		}
+		country := address.Country
+		if req.Country != nil {
+			country = *req.Country
+		}
+		if err := address.Update(province, city, detail, postalCode, country); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update contact if provided
+	if req.Label != nil || req.ContactName != nil || req.Phone != nil {
+		label := address.Label
+		if req.Label != nil {
+			label = *req.Label
+		}
+		contactName := address.ContactName
+		if req.ContactName != nil {
+			contactName = *req.ContactName
+		}
+		phone := address.Phone
+		if req.Phone != nil {
+			phone = *req.Phone
+		}
+		if err := address.SetContact(label, contactName, phone); err != nil {
+			return nil, err
+		}
+	}
+
+	// The default flag only moves to another address, it cannot just be removed
+	makeDefault := false
+	if req.IsDefault != nil {
+		if !*req.IsDefault && address.IsDefault {
+			return nil, shared.NewDomainError("DEFAULT_ADDRESS_REQUIRED", "Set another address as default instead of clearing the default")
+		}
+		makeDefault = *req.IsDefault && !address.IsDefault
+	}
+
+	err = s.inTransaction(ctx, func(repo partner.AddressRepository) error {
+		if makeDefault {
+			if err := repo.ClearDefault(ctx, tenantID, ownerType, ownerID, address.Type); err != nil {
+				return err
+			}
+			address.SetAsDefault(true)
+		}
+		return repo.Save(ctx, address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := ToAddressResponse(address)
+	return &response, nil
+}
+
+// Delete removes an address from the address book of a customer or supplier.
+// Deleting the default address promotes the oldest remaining address of the same type.
+func (s *AddressService) Delete(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID, id uuid.UUID) error {
+	address, err := s.findOwned(ctx, tenantID, ownerType, ownerID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.inTransaction(ctx, func(repo partner.AddressRepository) error {
+		if err := repo.DeleteForTenant(ctx, tenantID, id); err != nil {
+			return err
+		}
+		if !address.IsDefault {
+			return nil
+		}
+
+		remaining, err := repo.FindByOwner(ctx, tenantID, ownerType, ownerID)
+		if err != nil {
+			return err
+		}
+		next := partner.NextDefaultAddress(remaining, address.Type, address.ID)
+		if next == nil {
+			return nil
+		}
+		next.SetAsDefault(true)
+		return repo.Save(ctx, next)
+	})
+}
+
+// CheckShippingAddress returns an error if the address is not a shipping address of the customer.
+// It lets sales orders reference a specific shipping address of their customer.
+func (s *AddressService) CheckShippingAddress(ctx context.Context, tenantID, customerID, addressID uuid.UUID) error {
+	address, err := s.addressRepo.FindByIDForTenant(ctx, tenantID, addressID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return shared.NewDomainError("INVALID_SHIPPING_ADDRESS", "Shipping address not found")
+		}
+		return err
+	}
+	if !address.BelongsTo(partner.AddressOwnerCustomer, customerID) || address.Type != partner.AddressTypeShipping {
+		return shared.NewDomainError("INVALID_SHIPPING_ADDRESS", "Address is not a shipping address of the customer")
+	}
+	return nil
+}
+
+// findOwned loads an address and checks that it belongs to the given owner
+func (s *AddressService) findOwned(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID, id uuid.UUID) (*partner.Address, error) {
+	address, err := s.addressRepo.FindByIDForTenant(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !address.BelongsTo(ownerType, ownerID) {
+		return nil, shared.ErrNotFound
+	}
+	return address, nil
+}
+
+// verifyOwner checks that the customer or supplier exists in the tenant
+func (s *AddressService) verifyOwner(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID) error {
+	switch ownerType {
+	case partner.AddressOwnerCustomer:
+		_, err := s.customerRepo.FindByIDForTenant(ctx, tenantID, ownerID)
+		return err
+	case partner.AddressOwnerSupplier:
+		_, err := s.supplierRepo.FindByIDForTenant(ctx, tenantID, ownerID)
+		return err
+	default:
+		return shared.NewDomainError("INVALID_ADDRESS_OWNER", "Address owner must be a customer or supplier")
+	}
+}
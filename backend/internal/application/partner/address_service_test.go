@@ -0,0 +1,237 @@
+package partner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAddressRepository keeps addresses in memory and stores copies, like a database would
+type memoryAddressRepository struct {
+	partner.AddressRepository
+	addresses map[uuid.UUID]partner.Address
+}
+
+func newMemoryAddressRepository() *memoryAddressRepository {
+	return &memoryAddressRepository{addresses: make(map[uuid.UUID]partner.Address)}
+}
+
+func (r *memoryAddressRepository) FindByIDForTenant(_ context.Context, tenantID, id uuid.UUID) (*partner.Address, error) {
+	address, ok := r.addresses[id]
+	if !ok || address.TenantID != tenantID {
+		return nil, shared.ErrNotFound
+	}
+	return &address, nil
+}
+
+func (r *memoryAddressRepository) FindByOwner(_ context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID) ([]partner.Address, error) {
+	var addresses []partner.Address
+	for _, address := range r.addresses {
+		if address.TenantID == tenantID && address.BelongsTo(ownerType, ownerID) {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+func (r *memoryAddressRepository) FindDefault(_ context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID, addressType partner.AddressType) (*partner.Address, error) {
+	for _, address := range r.addresses {
+		if address.TenantID == tenantID && address.BelongsTo(ownerType, ownerID) && address.Type == addressType && address.IsDefault {
+			return &address, nil
+		}
+	}
+	return nil, shared.ErrNotFound
+}
+
+func (r *memoryAddressRepository) Save(_ context.Context, address *partner.Address) error {
+	r.addresses[address.ID] = *address
+	return nil
+}
+
+func (r *memoryAddressRepository) DeleteForTenant(_ context.Context, tenantID, id uuid.UUID) error {
+	if address, ok := r.addresses[id]; !ok || address.TenantID != tenantID {
+		return shared.ErrNotFound
+	}
+	delete(r.addresses, id)
+	return nil
+}
+
+func (r *memoryAddressRepository) ClearDefault(_ context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID, addressType partner.AddressType) error {
+	for id, address := range r.addresses {
+		if address.TenantID == tenantID && address.BelongsTo(ownerType, ownerID) && address.Type == addressType {
+			address.IsDefault = false
+			r.addresses[id] = address
+		}
+	}
+	return nil
+}
+
+// defaults returns the IDs of the owner's default addresses of the given type
+func (r *memoryAddressRepository) defaults(ownerID uuid.UUID, addressType partner.AddressType) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, address := range r.addresses {
+		if address.OwnerID == ownerID && address.Type == addressType && address.IsDefault {
+			ids = append(ids, address.ID)
+		}
+	}
+	return ids
+}
+
+func setupAddressService(t *testing.T) (*AddressService, *memoryAddressRepository, *partner.Customer) {
+	t.Helper()
+	tenantID := newTestTenantID()
+	customer, err := partner.NewIndividualCustomer(tenantID, "CUST-001", "Test Customer")
+	require.NoError(t, err)
+
+	customerRepo := new(MockCustomerRepository)
+	customerRepo.On("FindByIDForTenant", context.Background(), tenantID, customer.ID).Return(customer, nil)
+	addressRepo := newMemoryAddressRepository()
+
+	service := NewAddressService(addressRepo, customerRepo, nil)
+	service.SetTransactionScope(NewNoOpTransactionScope(customerRepo, nil, nil, addressRepo))
+	return service, addressRepo, customer
+}
+
+func createShippingAddress(t *testing.T, service *AddressService, customer *partner.Customer, detail string, isDefault bool) *AddressResponse {
+	t.Helper()
+	address, err := service.Create(context.Background(), customer.TenantID, partner.AddressOwnerCustomer, customer.ID, CreateAddressRequest{
+		Type:      string(partner.AddressTypeShipping),
+		Detail:    detail,
+		IsDefault: isDefault,
+	})
+	require.NoError(t, err)
+	return address
+}
+
+func TestAddressService_Create_FirstAddressOfTypeBecomesDefault(t *testing.T) {
+	ctx := context.Background()
+	service, addressRepo, customer := setupAddressService(t)
+
+	shipping := createShippingAddress(t, service, customer, "1 Dock Rd", false)
+	second := createShippingAddress(t, service, customer, "2 Dock Rd", false)
+	billing, err := service.Create(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, CreateAddressRequest{
+		Type:   string(partner.AddressTypeBilling),
+		Detail: "9 Office Park",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, shipping.IsDefault)
+	assert.False(t, second.IsDefault)
+	assert.True(t, billing.IsDefault, "defaults are kept per type")
+	assert.Equal(t, []uuid.UUID{shipping.ID}, addressRepo.defaults(customer.ID, partner.AddressTypeShipping))
+}
+
+func TestAddressService_SetDefault_DemotesPreviousDefault(t *testing.T) {
+	ctx := context.Background()
+	service, addressRepo, customer := setupAddressService(t)
+	previous := createShippingAddress(t, service, customer, "1 Dock Rd", false)
+	other := createShippingAddress(t, service, customer, "2 Dock Rd", false)
+	billing, err := service.Create(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, CreateAddressRequest{
+		Type:   string(partner.AddressTypeBilling),
+		Detail: "9 Office Park",
+	})
+	require.NoError(t, err)
+
+	isDefault := true
+	updated, err := service.Update(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, other.ID, UpdateAddressRequest{IsDefault: &isDefault})
+	require.NoError(t, err)
+
+	assert.True(t, updated.IsDefault)
+	assert.Equal(t, []uuid.UUID{other.ID}, addressRepo.defaults(customer.ID, partner.AddressTypeShipping))
+	demoted, err := service.GetByID(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, previous.ID)
+	require.NoError(t, err)
+	assert.False(t, demoted.IsDefault)
+	assert.Equal(t, []uuid.UUID{billing.ID}, addressRepo.defaults(customer.ID, partner.AddressTypeBilling), "the billing default is untouched")
+
+	// A new address created as default demotes the current one too
+	created := createShippingAddress(t, service, customer, "3 Dock Rd", true)
+	assert.Equal(t, []uuid.UUID{created.ID}, addressRepo.defaults(customer.ID, partner.AddressTypeShipping))
+}
+
+func TestAddressService_Update_CannotClearDefault(t *testing.T) {
+	ctx := context.Background()
+	service, addressRepo, customer := setupAddressService(t)
+	address := createShippingAddress(t, service, customer, "1 Dock Rd", false)
+
+	isDefault := false
+	_, err := service.Update(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, address.ID, UpdateAddressRequest{IsDefault: &isDefault})
+
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "DEFAULT_ADDRESS_REQUIRED", domainErr.Code)
+	assert.Equal(t, []uuid.UUID{address.ID}, addressRepo.defaults(customer.ID, partner.AddressTypeShipping))
+}
+
+func TestAddressService_Delete_NonDefaultAddress(t *testing.T) {
+	ctx := context.Background()
+	service, addressRepo, customer := setupAddressService(t)
+	defaultAddress := createShippingAddress(t, service, customer, "1 Dock Rd", false)
+	nonDefault := createShippingAddress(t, service, customer, "2 Dock Rd", false)
+
+	err := service.Delete(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, nonDefault.ID)
+	require.NoError(t, err)
+
+	addresses, err := service.List(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID)
+	require.NoError(t, err)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, defaultAddress.ID, addresses[0].ID)
+	assert.True(t, addresses[0].IsDefault, "deleting another address keeps the default")
+	assert.Equal(t, []uuid.UUID{defaultAddress.ID}, addressRepo.defaults(customer.ID, partner.AddressTypeShipping))
+}
+
+func TestAddressService_Delete_DefaultPromotesOldestRemaining(t *testing.T) {
+	ctx := context.Background()
+	service, addressRepo, customer := setupAddressService(t)
+	defaultAddress := createShippingAddress(t, service, customer, "1 Dock Rd", false)
+	older := createShippingAddress(t, service, customer, "2 Dock Rd", false)
+	newer := createShippingAddress(t, service, customer, "3 Dock Rd", false)
+	// Make the creation order unambiguous
+	stored := addressRepo.addresses[newer.ID]
+	stored.CreatedAt = addressRepo.addresses[older.ID].CreatedAt.Add(time.Minute)
+	addressRepo.addresses[newer.ID] = stored
+
+	err := service.Delete(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, defaultAddress.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uuid.UUID{older.ID}, addressRepo.defaults(customer.ID, partner.AddressTypeShipping))
+}
+
+func TestAddressService_OtherOwnersAddressIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	service, _, customer := setupAddressService(t)
+	address := createShippingAddress(t, service, customer, "1 Dock Rd", false)
+
+	_, err := service.GetByID(ctx, customer.TenantID, partner.AddressOwnerCustomer, uuid.New(), address.ID)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+	err = service.Delete(ctx, customer.TenantID, partner.AddressOwnerSupplier, customer.ID, address.ID)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
+
+func TestAddressService_CheckShippingAddress(t *testing.T) {
+	ctx := context.Background()
+	service, _, customer := setupAddressService(t)
+	shipping := createShippingAddress(t, service, customer, "1 Dock Rd", false)
+	billing, err := service.Create(ctx, customer.TenantID, partner.AddressOwnerCustomer, customer.ID, CreateAddressRequest{
+		Type:   string(partner.AddressTypeBilling),
+		Detail: "9 Office Park",
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, service.CheckShippingAddress(ctx, customer.TenantID, customer.ID, shipping.ID))
+
+	for name, check := range map[string]error{
+		"billing address":          service.CheckShippingAddress(ctx, customer.TenantID, customer.ID, billing.ID),
+		"other customer's address": service.CheckShippingAddress(ctx, customer.TenantID, uuid.New(), shipping.ID),
+		"unknown address":          service.CheckShippingAddress(ctx, customer.TenantID, customer.ID, uuid.New()),
+	} {
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, check, &domainErr, name)
+		assert.Equal(t, "INVALID_SHIPPING_ADDRESS", domainErr.Code, name)
+	}
+}
@@ -15,6 +15,7 @@ type BalanceTransactionService struct {
 	balanceTxRepo partner.BalanceTransactionRepository
 	customerRepo  partner.CustomerRepository
 	eventBus      shared.EventBus
+	txScope       TransactionScope
 }
 
 // NewBalanceTransactionService creates a new BalanceTransactionService
@@ -33,6 +34,31 @@ func (s *BalanceTransactionService) SetEventBus(eventBus shared.EventBus) {
 	s.eventBus = eventBus
 }
 
+// SetTransactionScope sets the transaction scope used to save the customer and
+// its balance transaction atomically
+func (s *BalanceTransactionService) SetTransactionScope(txScope TransactionScope) {
+	s.txScope = txScope
+}
+
+// persist saves the customer and its balance transaction. The customer is saved
+// with a version check, so when two requests change the same balance concurrently
+// only the first one is stored and the other fails with a version conflict instead
+// of overwriting it. That keeps concurrent deductions from both passing the overdraft limit.
+func (s *BalanceTransactionService) persist(ctx context.Context, customer *partner.Customer, transaction *partner.BalanceTransaction) error {
+	if s.txScope == nil {
+		if err := s.customerRepo.SaveWithLock(ctx, customer); err != nil {
+			return err
+		}
+		return s.balanceTxRepo.Create(ctx, transaction)
+	}
+	return s.txScope.Execute(ctx, func(repos TransactionalRepositories) error {
+		if err := repos.CustomerRepo().SaveWithLock(ctx, customer); err != nil {
+			return err
+		}
+		return repos.BalanceTransactionRepo().Create(ctx, transaction)
+	})
+}
+
 // Recharge adds balance to a customer with transaction record (top-up)
 // This is the main API for customer balance top-up per spec.md section 17
 func (s *BalanceTransactionService) Recharge(
@@ -83,13 +109,8 @@ func (s *BalanceTransactionService) Recharge(
 		transaction.WithOperatorID(*operatorID)
 	}
 
-	// Save customer
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
-		return nil, err
-	}
-
-	// Save transaction
-	if err := s.balanceTxRepo.Create(ctx, transaction); err != nil {
+	// Save customer and transaction
+	if err := s.persist(ctx, customer, transaction); err != nil {
 		return nil, err
 	}
 
@@ -173,13 +194,8 @@ func (s *BalanceTransactionService) Consume(
 		transaction.WithOperatorID(*operatorID)
 	}
 
-	// Save customer
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
-		return nil, err
-	}
-
-	// Save transaction
-	if err := s.balanceTxRepo.Create(ctx, transaction); err != nil {
+	// Save customer and transaction
+	if err := s.persist(ctx, customer, transaction); err != nil {
 		return nil, err
 	}
 
@@ -251,13 +267,8 @@ func (s *BalanceTransactionService) Refund(
 		transaction.WithOperatorID(*operatorID)
 	}
 
-	// Save customer
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
-		return nil, err
-	}
-
-	// Save transaction
-	if err := s.balanceTxRepo.Create(ctx, transaction); err != nil {
+	// Save customer and transaction
+	if err := s.persist(ctx, customer, transaction); err != nil {
 		return nil, err
 	}
 
@@ -331,13 +342,8 @@ func (s *BalanceTransactionService) Adjust(
 		transaction.WithOperatorID(*operatorID)
 	}
 
-	// Save customer
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
-		return nil, err
-	}
-
-	// Save transaction
-	if err := s.balanceTxRepo.Create(ctx, transaction); err != nil {
+	// Save customer and transaction
+	if err := s.persist(ctx, customer, transaction); err != nil {
 		return nil, err
 	}
 
@@ -493,9 +499,9 @@ func (s *BalanceTransactionService) GetBalance(ctx context.Context, tenantID, cu
 	return customer.Balance, nil
 }
 
-// HasSufficientBalance checks if customer has sufficient balance
+// HasSufficientBalance checks if customer can spend the amount, including the unused overdraft
 func (s *BalanceTransactionService) HasSufficientBalance(ctx context.Context, tenantID, customerID uuid.UUID, amount decimal.Decimal) (bool, error) {
-	balance, err := s.GetBalance(ctx, tenantID, customerID)
+	customer, err := s.customerRepo.FindByIDForTenant(ctx, tenantID, customerID)
 	if err != nil {
 		if err == shared.ErrNotFound {
 			return false, nil
@@ -503,5 +509,5 @@ func (s *BalanceTransactionService) HasSufficientBalance(ctx context.Context, te
 		return false, err
 	}
 
-	return balance.GreaterThanOrEqual(amount), nil
+	return customer.SpendableBalance().GreaterThanOrEqual(amount), nil
 }
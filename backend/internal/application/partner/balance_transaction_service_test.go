@@ -0,0 +1,162 @@
+package partner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedCustomerRepository keeps one customer row and enforces the version check of SaveWithLock.
+// Every load waits until all expected readers have loaded, so concurrent requests see the same version.
+type versionedCustomerRepository struct {
+	partner.CustomerRepository
+	mu      sync.Mutex
+	stored  partner.Customer
+	readers sync.WaitGroup
+}
+
+func newVersionedCustomerRepository(customer *partner.Customer, readers int) *versionedCustomerRepository {
+	r := &versionedCustomerRepository{stored: *customer}
+	r.readers.Add(readers)
+	return r
+}
+
+func (r *versionedCustomerRepository) FindByIDForTenant(_ context.Context, _, _ uuid.UUID) (*partner.Customer, error) {
+	r.mu.Lock()
+	loaded := r.stored
+	r.mu.Unlock()
+
+	r.readers.Done()
+	r.readers.Wait()
+	return &loaded, nil
+}
+
+func (r *versionedCustomerRepository) SaveWithLock(_ context.Context, customer *partner.Customer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stored.Version != customer.Version {
+		return shared.NewVersionConflictError("customer", r.stored.Version)
+	}
+	customer.Version++
+	r.stored = *customer
+	return nil
+}
+
+// lockedBalanceTransactionRepository records created balance transactions from concurrent requests
+type lockedBalanceTransactionRepository struct {
+	partner.BalanceTransactionRepository
+	mu      sync.Mutex
+	created []*partner.BalanceTransaction
+}
+
+func (r *lockedBalanceTransactionRepository) Create(_ context.Context, tx *partner.BalanceTransaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.created = append(r.created, tx)
+	return nil
+}
+
+// deductConcurrently runs two deductions of the full spendable amount at the same time
+func deductConcurrently(t *testing.T, deduct func(service *BalanceTransactionService, customer *partner.Customer) error) {
+	t.Helper()
+
+	tenantID := newTestTenantID()
+	customer, err := partner.NewIndividualCustomer(tenantID, "CUST-001", "Test Customer")
+	require.NoError(t, err)
+	customer.Balance = decimal.NewFromInt(100)
+	require.NoError(t, customer.SetOverdraftLimit(decimal.NewFromInt(50)))
+
+	customerRepo := newVersionedCustomerRepository(customer, 2)
+	balanceRepo := &lockedBalanceTransactionRepository{}
+	service := NewBalanceTransactionService(balanceRepo, customerRepo)
+	service.SetTransactionScope(NewNoOpTransactionScope(customerRepo, balanceRepo, nil, nil))
+
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = deduct(service, customer)
+		}(i)
+	}
+	wg.Wait()
+
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var conflictErr *shared.VersionConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+	}
+	assert.Equal(t, 1, succeeded, "only one deduction of the full spendable amount may succeed")
+	assert.True(t, customerRepo.stored.Balance.Equal(decimal.NewFromInt(-50)))
+	assert.Len(t, balanceRepo.created, 1)
+}
+
+func TestBalanceTransactionService_Consume_ConcurrentFullDeductions(t *testing.T) {
+	deductConcurrently(t, func(service *BalanceTransactionService, customer *partner.Customer) error {
+		_, err := service.Consume(context.Background(), customer.TenantID, customer.ID,
+			decimal.NewFromInt(150), partner.BalanceSourceTypeSalesOrder, nil, "", "", nil)
+		return err
+	})
+}
+
+func TestBalanceTransactionService_Adjust_ConcurrentFullDeductions(t *testing.T) {
+	deductConcurrently(t, func(service *BalanceTransactionService, customer *partner.Customer) error {
+		_, err := service.Adjust(context.Background(), customer.TenantID, customer.ID,
+			decimal.NewFromInt(150), false, "", "", nil)
+		return err
+	})
+}
+
+func TestBalanceTransactionService_Adjust_ExceedsOverdraft(t *testing.T) {
+	tenantID := newTestTenantID()
+	customer, err := partner.NewIndividualCustomer(tenantID, "CUST-001", "Test Customer")
+	require.NoError(t, err)
+	customer.Balance = decimal.NewFromInt(100)
+	require.NoError(t, customer.SetOverdraftLimit(decimal.NewFromInt(50)))
+
+	customerRepo := newVersionedCustomerRepository(customer, 1)
+	balanceRepo := &lockedBalanceTransactionRepository{}
+	service := NewBalanceTransactionService(balanceRepo, customerRepo)
+
+	_, err = service.Adjust(context.Background(), tenantID, customer.ID, decimal.NewFromInt(151), false, "", "", nil)
+
+	var balanceErr *shared.InsufficientBalanceError
+	require.ErrorAs(t, err, &balanceErr)
+	assert.True(t, balanceErr.Available.Equal(decimal.NewFromInt(150)))
+	assert.True(t, errors.Is(err, shared.ErrInsufficientBalance))
+	assert.True(t, customerRepo.stored.Balance.Equal(decimal.NewFromInt(100)))
+	assert.Empty(t, balanceRepo.created)
+}
+
+func TestBalanceTransactionService_Consume_PrepaidCustomerCannotOverdraw(t *testing.T) {
+	tenantID := newTestTenantID()
+	customer, err := partner.NewIndividualCustomer(tenantID, "CUST-001", "Test Customer")
+	require.NoError(t, err)
+	customer.Balance = decimal.NewFromInt(100)
+
+	customerRepo := newVersionedCustomerRepository(customer, 1)
+	balanceRepo := &lockedBalanceTransactionRepository{}
+	service := NewBalanceTransactionService(balanceRepo, customerRepo)
+
+	_, err = service.Consume(context.Background(), tenantID, customer.ID,
+		decimal.NewFromInt(101), partner.BalanceSourceTypeSalesOrder, nil, "", "", nil)
+
+	var balanceErr *shared.InsufficientBalanceError
+	require.ErrorAs(t, err, &balanceErr)
+	assert.True(t, balanceErr.Available.Equal(decimal.NewFromInt(100)))
+	assert.Empty(t, balanceRepo.created)
+}
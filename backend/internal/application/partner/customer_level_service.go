@@ -23,78 +23,91 @@ type CreateCustomerLevelRequest struct {
 	IsDefault    bool    `json:"is_default"`
 	IsActive     bool    `json:"is_active"`
 	Description  string  `json:"description" binding:"max=500"`
+	// Qualifying spend that promotes customers to this level; zero keeps the level manual only
+	UpgradeThreshold float64 `json:"upgrade_threshold" binding:"gte=0"`
+	SpendWindowDays  *int    `json:"spend_window_days" binding:"omitempty,min=1,max=3650"`
 }
 
 // UpdateCustomerLevelRequest represents a request to update a customer level
 type UpdateCustomerLevelRequest struct {
-	Name         *string  `json:"name" binding:"omitempty,min=1,max=100"`
-	DiscountRate *float64 `json:"discount_rate" binding:"omitempty,gte=0,lte=1"`
-	SortOrder    *int     `json:"sort_order"`
-	IsDefault    *bool    `json:"is_default"`
-	IsActive     *bool    `json:"is_active"`
-	Description  *string  `json:"description" binding:"omitempty,max=500"`
+	Name             *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	DiscountRate     *float64 `json:"discount_rate" binding:"omitempty,gte=0,lte=1"`
+	SortOrder        *int     `json:"sort_order"`
+	IsDefault        *bool    `json:"is_default"`
+	IsActive         *bool    `json:"is_active"`
+	Description      *string  `json:"description" binding:"omitempty,max=500"`
+	UpgradeThreshold *float64 `json:"upgrade_threshold" binding:"omitempty,gte=0"`
+	SpendWindowDays  *int     `json:"spend_window_days" binding:"omitempty,min=1,max=3650"`
 }
 
 // CustomerLevelResponse represents a customer level in API responses
 type CustomerLevelResponse struct {
-	ID              uuid.UUID       `json:"id"`
-	TenantID        uuid.UUID       `json:"tenant_id"`
-	Code            string          `json:"code"`
-	Name            string          `json:"name"`
-	DiscountRate    decimal.Decimal `json:"discount_rate"`
-	DiscountPercent decimal.Decimal `json:"discount_percent"`
-	SortOrder       int             `json:"sort_order"`
-	IsDefault       bool            `json:"is_default"`
-	IsActive        bool            `json:"is_active"`
-	Description     string          `json:"description"`
-	CustomerCount   int64           `json:"customer_count,omitempty"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
+	ID               uuid.UUID       `json:"id"`
+	TenantID         uuid.UUID       `json:"tenant_id"`
+	Code             string          `json:"code"`
+	Name             string          `json:"name"`
+	DiscountRate     decimal.Decimal `json:"discount_rate"`
+	DiscountPercent  decimal.Decimal `json:"discount_percent"`
+	SortOrder        int             `json:"sort_order"`
+	IsDefault        bool            `json:"is_default"`
+	IsActive         bool            `json:"is_active"`
+	Description      string          `json:"description"`
+	UpgradeThreshold decimal.Decimal `json:"upgrade_threshold"`
+	SpendWindowDays  int             `json:"spend_window_days"`
+	CustomerCount    int64           `json:"customer_count,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
 }
 
 // CustomerLevelListResponse represents a list item for customer levels
 type CustomerLevelListResponse struct {
-	ID              uuid.UUID       `json:"id"`
-	Code            string          `json:"code"`
-	Name            string          `json:"name"`
-	DiscountRate    decimal.Decimal `json:"discount_rate"`
-	DiscountPercent decimal.Decimal `json:"discount_percent"`
-	SortOrder       int             `json:"sort_order"`
-	IsDefault       bool            `json:"is_default"`
-	IsActive        bool            `json:"is_active"`
-	CustomerCount   int64           `json:"customer_count"`
+	ID               uuid.UUID       `json:"id"`
+	Code             string          `json:"code"`
+	Name             string          `json:"name"`
+	DiscountRate     decimal.Decimal `json:"discount_rate"`
+	DiscountPercent  decimal.Decimal `json:"discount_percent"`
+	SortOrder        int             `json:"sort_order"`
+	IsDefault        bool            `json:"is_default"`
+	IsActive         bool            `json:"is_active"`
+	UpgradeThreshold decimal.Decimal `json:"upgrade_threshold"`
+	SpendWindowDays  int             `json:"spend_window_days"`
+	CustomerCount    int64           `json:"customer_count"`
 }
 
 // ToCustomerLevelResponse converts a domain CustomerLevelRecord to CustomerLevelResponse
 func ToCustomerLevelResponse(r *partner.CustomerLevelRecord) CustomerLevelResponse {
 	return CustomerLevelResponse{
-		ID:              r.ID,
-		TenantID:        r.TenantID,
-		Code:            r.Code,
-		Name:            r.Name,
-		DiscountRate:    r.DiscountRate,
-		DiscountPercent: r.DiscountRate.Mul(decimal.NewFromInt(100)),
-		SortOrder:       r.SortOrder,
-		IsDefault:       r.IsDefault,
-		IsActive:        r.IsActive,
-		Description:     r.Description,
-		CreatedAt:       r.CreatedAt,
-		UpdatedAt:       r.UpdatedAt,
+		ID:               r.ID,
+		TenantID:         r.TenantID,
+		Code:             r.Code,
+		Name:             r.Name,
+		DiscountRate:     r.DiscountRate,
+		DiscountPercent:  r.DiscountRate.Mul(decimal.NewFromInt(100)),
+		SortOrder:        r.SortOrder,
+		IsDefault:        r.IsDefault,
+		IsActive:         r.IsActive,
+		Description:      r.Description,
+		UpgradeThreshold: r.UpgradeThreshold,
+		SpendWindowDays:  r.SpendWindowDays,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
 	}
 }
 
 // ToCustomerLevelListResponse converts a domain CustomerLevelRecord to CustomerLevelListResponse
 func ToCustomerLevelListResponse(r *partner.CustomerLevelRecord, customerCount int64) CustomerLevelListResponse {
 	return CustomerLevelListResponse{
-		ID:              r.ID,
-		Code:            r.Code,
-		Name:            r.Name,
-		DiscountRate:    r.DiscountRate,
-		DiscountPercent: r.DiscountRate.Mul(decimal.NewFromInt(100)),
-		SortOrder:       r.SortOrder,
-		IsDefault:       r.IsDefault,
-		IsActive:        r.IsActive,
-		CustomerCount:   customerCount,
+		ID:               r.ID,
+		Code:             r.Code,
+		Name:             r.Name,
+		DiscountRate:     r.DiscountRate,
+		DiscountPercent:  r.DiscountRate.Mul(decimal.NewFromInt(100)),
+		SortOrder:        r.SortOrder,
+		IsDefault:        r.IsDefault,
+		IsActive:         r.IsActive,
+		UpgradeThreshold: r.UpgradeThreshold,
+		SpendWindowDays:  r.SpendWindowDays,
+		CustomerCount:    customerCount,
 	}
 }
 
@@ -143,6 +156,14 @@ func (s *CustomerLevelService) Create(ctx context.Context, tenantID uuid.UUID, r
 	record.IsActive = req.IsActive
 	record.Description = req.Description
 
+	windowDays := partner.DefaultSpendWindowDays
+	if req.SpendWindowDays != nil {
+		windowDays = *req.SpendWindowDays
+	}
+	if err := record.SetUpgradeRule(decimal.NewFromFloat(req.UpgradeThreshold), windowDays); err != nil {
+		return nil, err
+	}
+
 	// If this is set as default, we need to handle it (trigger in DB handles this)
 	if err := s.levelRepo.Save(ctx, record); err != nil {
 		return nil, err
@@ -265,6 +286,18 @@ func (s *CustomerLevelService) Update(ctx context.Context, tenantID, levelID uui
 	if req.Description != nil {
 		record.Description = *req.Description
 	}
+	if req.UpgradeThreshold != nil || req.SpendWindowDays != nil {
+		threshold, windowDays := record.UpgradeThreshold, record.SpendWindowDays
+		if req.UpgradeThreshold != nil {
+			threshold = decimal.NewFromFloat(*req.UpgradeThreshold)
+		}
+		if req.SpendWindowDays != nil {
+			windowDays = *req.SpendWindowDays
+		}
+		if err := record.SetUpgradeRule(threshold, windowDays); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate the updated values
 	_, err = partner.NewCustomerLevel(record.Code, record.Name, record.DiscountRate)
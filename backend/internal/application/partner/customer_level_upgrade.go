@@ -0,0 +1,193 @@
+package partner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// CustomerSpendReader sums the qualifying spend of a customer
+type CustomerSpendReader interface {
+	// SumCompletedByCustomer sums the payable amount of the customer's sales orders completed since the given time
+	SumCompletedByCustomer(ctx context.Context, tenantID, customerID uuid.UUID, since time.Time) (decimal.Decimal, error)
+}
+
+// CustomerLevelUpgradeService moves customers between levels by their qualifying spend
+// over the rolling windows configured on the level definitions
+type CustomerLevelUpgradeService struct {
+	customerRepo   partner.CustomerRepository
+	levelRepo      partner.CustomerLevelRepository
+	spendReader    CustomerSpendReader
+	eventPublisher shared.EventPublisher
+	now            func() time.Time
+}
+
+// NewCustomerLevelUpgradeService creates a new CustomerLevelUpgradeService
+func NewCustomerLevelUpgradeService(
+	customerRepo partner.CustomerRepository,
+	levelRepo partner.CustomerLevelRepository,
+	spendReader CustomerSpendReader,
+) *CustomerLevelUpgradeService {
+	return &CustomerLevelUpgradeService{
+		customerRepo: customerRepo,
+		levelRepo:    levelRepo,
+		spendReader:  spendReader,
+		now:          time.Now,
+	}
+}
+
+// SetEventPublisher sets the publisher for level changed events
+func (s *CustomerLevelUpgradeService) SetEventPublisher(publisher shared.EventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// Evaluate re-evaluates a customer's level against its qualifying spend.
+// The spend is recomputed from completed orders on every call rather than accumulated,
+// so evaluating the same customer again, or for a redelivered event, changes nothing.
+// It returns true if the customer's level changed.
+func (s *CustomerLevelUpgradeService) Evaluate(ctx context.Context, tenantID, customerID uuid.UUID) (bool, error) {
+	levels, err := s.levelRepo.FindActiveForTenant(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+	windows := partner.SpendWindows(levels)
+	if len(windows) == 0 {
+		// No level is reached by spend, levels are assigned manually only
+		return false, nil
+	}
+
+	customer, err := s.customerRepo.FindByIDForTenant(ctx, tenantID, customerID)
+	if err != nil {
+		return false, err
+	}
+
+	now := s.now()
+	spend := make(partner.CustomerSpend, len(windows))
+	for _, days := range windows {
+		total, err := s.spendReader.SumCompletedByCustomer(ctx, tenantID, customerID, now.AddDate(0, 0, -days))
+		if err != nil {
+			return false, err
+		}
+		spend[days] = total
+	}
+
+	oldLevel := customer.Level.Code()
+	if !customer.ApplySpendLevel(levels, spend, now) {
+		return false, nil
+	}
+	if err := s.customerRepo.SaveWithLock(ctx, customer); err != nil {
+		return false, err
+	}
+
+	if s.eventPublisher != nil {
+		if events := customer.GetDomainEvents(); len(events) > 0 {
+			// Publish errors are logged by the event bus, not propagated
+			_ = s.eventPublisher.Publish(ctx, events...)
+			customer.ClearDomainEvents()
+		}
+	}
+	return customer.Level.Code() != oldLevel, nil
+}
+
+// EvaluateEndedPeriods re-evaluates every customer whose level period has ended, across all tenants.
+// Demotions only apply at the end of a level period, and a customer whose spend lapsed places
+// no new orders to trigger an evaluation, so this runs as a recurring job.
+// Customers that fail to evaluate do not stop the others; their errors are returned joined.
+// It returns the number of customers whose level changed.
+func (s *CustomerLevelUpgradeService) EvaluateEndedPeriods(ctx context.Context) (int, error) {
+	customers, err := s.customerRepo.FindLevelPeriodEnded(ctx, s.now())
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	var errs []error
+	for i := range customers {
+		customer := &customers[i]
+		ok, err := s.Evaluate(ctx, customer.TenantID, customer.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("customer %s: %w", customer.ID, err))
+			continue
+		}
+		if ok {
+			changed++
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+// CustomerLevelUpgradeHandler re-evaluates a customer's level when its sales orders
+// complete or its receivables are paid
+type CustomerLevelUpgradeHandler struct {
+	upgrades *CustomerLevelUpgradeService
+	logger   *zap.Logger
+}
+
+// NewCustomerLevelUpgradeHandler creates a new handler promoting customers by their spend
+func NewCustomerLevelUpgradeHandler(upgrades *CustomerLevelUpgradeService, logger *zap.Logger) *CustomerLevelUpgradeHandler {
+	return &CustomerLevelUpgradeHandler{
+		upgrades: upgrades,
+		logger:   logger,
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *CustomerLevelUpgradeHandler) EventTypes() []string {
+	return []string{trade.EventTypeSalesOrderCompleted, finance.EventTypeAccountReceivablePaid}
+}
+
+// Handle re-evaluates the level of the event's customer
+func (h *CustomerLevelUpgradeHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	if shared.IsEventReplay(ctx) {
+		// Levels were evaluated on the original delivery
+		h.logger.Debug("skipping replayed event",
+			zap.String("event_id", event.EventID().String()),
+			zap.String("event_type", event.EventType()),
+		)
+		return nil
+	}
+
+	var customerID uuid.UUID
+	switch e := event.(type) {
+	case *trade.SalesOrderCompletedEvent:
+		customerID = e.CustomerID
+	case *finance.AccountReceivablePaidEvent:
+		customerID = e.CustomerID
+	default:
+		h.logger.Error("unexpected event type",
+			zap.Strings("expected", h.EventTypes()),
+			zap.String("actual", event.EventType()),
+		)
+		return fmt.Errorf("unexpected event type: %s", event.EventType())
+	}
+
+	changed, err := h.upgrades.Evaluate(ctx, event.TenantID(), customerID)
+	if err != nil {
+		h.logger.Error("failed to evaluate customer level",
+			zap.String("tenant_id", event.TenantID().String()),
+			zap.String("customer_id", customerID.String()),
+			zap.String("event_type", event.EventType()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to evaluate customer level: %w", err)
+	}
+	if changed {
+		h.logger.Info("customer level changed by spend",
+			zap.String("tenant_id", event.TenantID().String()),
+			zap.String("customer_id", customerID.String()),
+		)
+	}
+	return nil
+}
+
+// Ensure CustomerLevelUpgradeHandler implements shared.EventHandler
+var _ shared.EventHandler = (*CustomerLevelUpgradeHandler)(nil)
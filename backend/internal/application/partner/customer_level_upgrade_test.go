@@ -0,0 +1,170 @@
+package partner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubCustomerLevelRepository returns fixed level definitions
+type stubCustomerLevelRepository struct {
+	partner.CustomerLevelRepository
+	levels []*partner.CustomerLevelRecord
+}
+
+func (r *stubCustomerLevelRepository) FindActiveForTenant(_ context.Context, _ uuid.UUID) ([]*partner.CustomerLevelRecord, error) {
+	return r.levels, nil
+}
+
+// stubCustomerSpendReader returns the spend set by the test and records the windows asked for
+type stubCustomerSpendReader struct {
+	spend decimal.Decimal
+	since []time.Time
+}
+
+func (r *stubCustomerSpendReader) SumCompletedByCustomer(_ context.Context, _, _ uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	r.since = append(r.since, since)
+	return r.spend, nil
+}
+
+func newOrderCompletedEvent(tenantID, customerID uuid.UUID) *trade.SalesOrderCompletedEvent {
+	orderID := uuid.New()
+	return &trade.SalesOrderCompletedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(trade.EventTypeSalesOrderCompleted, trade.AggregateTypeSalesOrder, orderID, tenantID),
+		OrderID:         orderID,
+		CustomerID:      customerID,
+	}
+}
+
+func TestCustomerLevelUpgradeHandler_CrossingThresholdChangesLevelOnce(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	customer, err := partner.NewIndividualCustomer(tenantID, "CUST-001", "Test Customer")
+	require.NoError(t, err)
+	customer.ClearDomainEvents()
+
+	levels := partner.DefaultCustomerLevelRecords(tenantID)
+	for _, level := range levels {
+		if level.Code == partner.CustomerLevelCodeGold {
+			require.NoError(t, level.SetUpgradeRule(decimal.NewFromInt(5000), 90))
+		}
+	}
+
+	customerRepo := new(MockCustomerRepository)
+	customerRepo.On("FindByIDForTenant", ctx, tenantID, customer.ID).Return(customer, nil)
+	customerRepo.On("SaveWithLock", ctx, customer).Return(nil).Once()
+	spendReader := &stubCustomerSpendReader{}
+	publisher := &recordingPublisher{}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	upgrades := NewCustomerLevelUpgradeService(customerRepo, &stubCustomerLevelRepository{levels: levels}, spendReader)
+	upgrades.SetEventPublisher(publisher)
+	upgrades.now = func() time.Time { return now }
+	handler := NewCustomerLevelUpgradeHandler(upgrades, zap.NewNop())
+
+	// Below the threshold the customer stays on the default level
+	spendReader.spend = decimal.NewFromInt(4000)
+	require.NoError(t, handler.Handle(ctx, newOrderCompletedEvent(tenantID, customer.ID)))
+	assert.Equal(t, partner.CustomerLevelCodeNormal, customer.Level.Code())
+	assert.Equal(t, []time.Time{now.AddDate(0, 0, -90)}, spendReader.since)
+
+	// The next completed order crosses the threshold
+	spendReader.spend = decimal.NewFromInt(5500)
+	require.NoError(t, handler.Handle(ctx, newOrderCompletedEvent(tenantID, customer.ID)))
+	assert.Equal(t, partner.CustomerLevelCodeGold, customer.Level.Code())
+
+	// Paying the order's receivable, or redelivering the event, does not change the level again
+	paid := &finance.AccountReceivablePaidEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(finance.EventTypeAccountReceivablePaid, "AccountReceivable", uuid.New(), tenantID),
+		CustomerID:      customer.ID,
+	}
+	require.NoError(t, handler.Handle(ctx, paid))
+	require.NoError(t, handler.Handle(ctx, newOrderCompletedEvent(tenantID, customer.ID)))
+
+	assert.Equal(t, partner.CustomerLevelCodeGold, customer.Level.Code())
+	customerRepo.AssertNumberOfCalls(t, "SaveWithLock", 1)
+	require.Len(t, publisher.events, 1)
+	levelEvent, ok := publisher.events[0].(*partner.CustomerLevelChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, partner.CustomerLevelCodeNormal, levelEvent.OldLevel.Code())
+	assert.Equal(t, partner.CustomerLevelCodeGold, levelEvent.NewLevel.Code())
+}
+
+func TestCustomerLevelUpgradeService_EvaluateEndedPeriods_DemotesWithoutNewSpend(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	customer, err := partner.NewIndividualCustomer(tenantID, "CUST-001", "Test Customer")
+	require.NoError(t, err)
+	customer.ClearDomainEvents()
+
+	levels := partner.DefaultCustomerLevelRecords(tenantID)
+	for _, level := range levels {
+		if level.Code == partner.CustomerLevelCodeGold {
+			require.NoError(t, level.SetUpgradeRule(decimal.NewFromInt(5000), 90))
+			customer.Level = level.ToCustomerLevel()
+		}
+	}
+	now := time.Date(2026, 6, 1, 2, 0, 0, 0, time.UTC)
+	periodStart := now.AddDate(0, 0, -91)
+	customer.LevelPeriodStart = &periodStart
+
+	// The customer placed no orders since its promotion, so only the job evaluates it
+	customerRepo := new(MockCustomerRepository)
+	customerRepo.On("FindLevelPeriodEnded", ctx, now).Return([]partner.Customer{*customer}, nil)
+	customerRepo.On("FindByIDForTenant", ctx, tenantID, customer.ID).Return(customer, nil)
+	customerRepo.On("SaveWithLock", ctx, customer).Return(nil).Once()
+	publisher := &recordingPublisher{}
+
+	upgrades := NewCustomerLevelUpgradeService(customerRepo, &stubCustomerLevelRepository{levels: levels}, &stubCustomerSpendReader{spend: decimal.Zero})
+	upgrades.SetEventPublisher(publisher)
+	upgrades.now = func() time.Time { return now }
+
+	changed, err := upgrades.EvaluateEndedPeriods(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, partner.CustomerLevelCodeNormal, customer.Level.Code())
+	customerRepo.AssertExpectations(t)
+	require.Len(t, publisher.events, 1)
+	levelEvent, ok := publisher.events[0].(*partner.CustomerLevelChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, partner.CustomerLevelCodeGold, levelEvent.OldLevel.Code())
+	assert.Equal(t, partner.CustomerLevelCodeNormal, levelEvent.NewLevel.Code())
+}
+
+func TestCustomerLevelUpgradeService_Evaluate_WithoutSpendLevels(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	customerRepo := new(MockCustomerRepository)
+	spendReader := &stubCustomerSpendReader{spend: decimal.NewFromInt(100000)}
+	levelRepo := &stubCustomerLevelRepository{levels: partner.DefaultCustomerLevelRecords(tenantID)}
+	upgrades := NewCustomerLevelUpgradeService(customerRepo, levelRepo, spendReader)
+
+	changed, err := upgrades.Evaluate(ctx, tenantID, uuid.New())
+
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, spendReader.since)
+	customerRepo.AssertNotCalled(t, "FindByIDForTenant", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCustomerLevelUpgradeHandler_SkipsReplay(t *testing.T) {
+	customerRepo := new(MockCustomerRepository)
+	upgrades := NewCustomerLevelUpgradeService(customerRepo, &stubCustomerLevelRepository{}, &stubCustomerSpendReader{})
+	handler := NewCustomerLevelUpgradeHandler(upgrades, zap.NewNop())
+
+	ctx := shared.WithEventReplay(context.Background())
+	require.NoError(t, handler.Handle(ctx, newOrderCompletedEvent(newTestTenantID(), uuid.New())))
+	customerRepo.AssertExpectations(t)
+}
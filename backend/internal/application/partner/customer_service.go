@@ -17,6 +17,8 @@ type CustomerService struct {
 	customerRepo          partner.CustomerRepository
 	accountReceivableRepo finance.AccountReceivableRepository // Optional: for delete validation
 	salesOrderRepo        trade.SalesOrderRepository          // Optional: for delete validation
+	txScope               TransactionScope                    // Optional: required to merge customers
+	eventPublisher        shared.EventPublisher               // Optional: publishes merge events to other contexts
 }
 
 // NewCustomerService creates a new CustomerService
@@ -36,6 +38,16 @@ func (s *CustomerService) SetSalesOrderRepo(repo trade.SalesOrderRepository) {
 	s.salesOrderRepo = repo
 }
 
+// SetTransactionScope sets the transaction scope used to merge customers atomically
+func (s *CustomerService) SetTransactionScope(scope TransactionScope) {
+	s.txScope = scope
+}
+
+// SetEventPublisher sets the event publisher for publishing customer domain events
+func (s *CustomerService) SetEventPublisher(publisher shared.EventPublisher) {
+	s.eventPublisher = publisher
+}
+
 // Create creates a new customer
 func (s *CustomerService) Create(ctx context.Context, tenantID uuid.UUID, req CreateCustomerRequest) (*CustomerResponse, error) {
 	// Check if code already exists
@@ -111,6 +123,13 @@ func (s *CustomerService) Create(ctx context.Context, tenantID uuid.UUID, req Cr
 		}
 	}
 
+	// Set overdraft limit
+	if req.OverdraftLimit != nil && !req.OverdraftLimit.IsZero() {
+		if err := customer.SetOverdraftLimit(*req.OverdraftLimit); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set notes
 	if req.Notes != "" {
 		customer.SetNotes(req.Notes)
@@ -206,6 +225,9 @@ func (s *CustomerService) List(ctx context.Context, tenantID uuid.UUID, filter C
 	if filter.Province != "" {
 		domainFilter.Filters["province"] = filter.Province
 	}
+	if filter.IncludeDeleted {
+		domainFilter.Filters["include_deleted"] = true
+	}
 
 	// Get customers
 	customers, err := s.customerRepo.FindAllForTenant(ctx, tenantID, domainFilter)
@@ -229,6 +251,9 @@ func (s *CustomerService) Update(ctx context.Context, tenantID, customerID uuid.
 	if err != nil {
 		return nil, err
 	}
+	if err := shared.CheckVersion("customer", req.Version, customer.Version); err != nil {
+		return nil, err
+	}
 
 	// Update name and short name
 	if req.Name != nil {
@@ -329,6 +354,13 @@ func (s *CustomerService) Update(ctx context.Context, tenantID, customerID uuid.
 		}
 	}
 
+	// Update overdraft limit
+	if req.OverdraftLimit != nil {
+		if err := customer.SetOverdraftLimit(*req.OverdraftLimit); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update level
 	if req.Level != nil {
 		level, err := partner.NewCustomerLevelFromCode(*req.Level)
@@ -357,8 +389,8 @@ func (s *CustomerService) Update(ctx context.Context, tenantID, customerID uuid.
 		}
 	}
 
-	// Save the customer
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
+	// Save the customer, failing if it changed since it was loaded
+	if err := s.customerRepo.SaveWithLock(ctx, customer); err != nil {
 		return nil, err
 	}
 
@@ -443,6 +475,131 @@ func (s *CustomerService) Delete(ctx context.Context, tenantID, customerID uuid.
 	return s.customerRepo.DeleteForTenant(ctx, tenantID, customerID)
 }
 
+// Restore restores a soft-deleted customer.
+// It fails if another customer has taken the code since the customer was deleted.
+func (s *CustomerService) Restore(ctx context.Context, tenantID, customerID uuid.UUID) (*CustomerResponse, error) {
+	customer, err := s.customerRepo.FindDeletedByIDForTenant(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.customerRepo.ExistsByCode(ctx, tenantID, customer.Code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, shared.NewDomainError("ALREADY_EXISTS",
+			fmt.Sprintf("Cannot restore customer: code %s is now used by another customer", customer.Code))
+	}
+
+	if err := s.customerRepo.RestoreForTenant(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+	customer.DeletedAt = nil
+
+	response := ToCustomerResponse(customer)
+	return &response, nil
+}
+
+// Merge merges a duplicate customer into the surviving customer.
+// In one transaction the merged customer's orders, returns, receivables, vouchers,
+// refunds and balance transactions are moved to the survivor, its balance is added
+// to the survivor's balance, and it is marked as merged instead of being deleted.
+func (s *CustomerService) Merge(ctx context.Context, tenantID, survivingID, mergedID uuid.UUID) (*CustomerMergeResponse, error) {
+	if s.txScope == nil {
+		return nil, shared.NewDomainError("MERGE_UNAVAILABLE", "Customer merge is not configured")
+	}
+	if survivingID == mergedID {
+		return nil, shared.NewDomainError("CANNOT_MERGE_SELF", "Cannot merge a customer into itself")
+	}
+
+	var surviving, merged *partner.Customer
+	response := &CustomerMergeResponse{MergedCustomerID: mergedID}
+	err := s.txScope.Execute(ctx, func(repos TransactionalRepositories) error {
+		var err error
+		surviving, err = repos.CustomerRepo().FindByIDForTenant(ctx, tenantID, survivingID)
+		if err != nil {
+			return err
+		}
+		// Customers of other tenants are not found, so they cannot be merged
+		merged, err = repos.CustomerRepo().FindByIDForTenant(ctx, tenantID, mergedID)
+		if err != nil {
+			return err
+		}
+
+		balanceBefore := surviving.Balance
+		transferred, err := partner.MergeCustomers(surviving, merged)
+		if err != nil {
+			return err
+		}
+
+		reassigned, err := repos.CustomerReferenceRepo().ReassignCustomer(ctx, tenantID, merged.ID, surviving)
+		if err != nil {
+			return err
+		}
+
+		if !transferred.IsZero() {
+			balanceTx, err := partner.NewBalanceTransaction(
+				tenantID,
+				surviving.ID,
+				partner.BalanceTransactionTypeAdjustment,
+				transferred.Abs(),
+				balanceBefore,
+				surviving.Balance,
+				partner.BalanceSourceTypeSystem,
+			)
+			if err != nil {
+				return err
+			}
+			balanceTx.WithSourceID(merged.ID.String()).
+				WithReference(merged.Code).
+				WithRemark(fmt.Sprintf("Balance merged from customer %s", merged.Code))
+			if err := repos.BalanceTransactionRepo().Create(ctx, balanceTx); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.CustomerRepo().SaveWithLock(ctx, merged); err != nil {
+			return err
+		}
+		if err := repos.CustomerRepo().SaveWithLock(ctx, surviving); err != nil {
+			return err
+		}
+
+		response.BalanceTransferred = transferred
+		response.SalesOrders = reassigned.SalesOrders
+		response.SalesReturns = reassigned.SalesReturns
+		response.Receivables = reassigned.Receivables
+		response.ReceiptVouchers = reassigned.ReceiptVouchers
+		response.RefundRecords = reassigned.RefundRecords
+		response.BalanceTransactions = reassigned.BalanceTransactions
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishDomainEvents(ctx, merged)
+	s.publishDomainEvents(ctx, surviving)
+
+	response.Customer = ToCustomerResponse(surviving)
+	return response, nil
+}
+
+// publishDomainEvents publishes all domain events from the customer
+func (s *CustomerService) publishDomainEvents(ctx context.Context, customer *partner.Customer) {
+	if s.eventPublisher == nil {
+		return
+	}
+	events := customer.GetDomainEvents()
+	if len(events) == 0 {
+		return
+	}
+	// Publish events (errors are logged by the event bus, not propagated)
+	_ = s.eventPublisher.Publish(ctx, events...)
+	customer.ClearDomainEvents()
+}
+
 // Activate activates a customer
 func (s *CustomerService) Activate(ctx context.Context, tenantID, customerID uuid.UUID) (*CustomerResponse, error) {
 	customer, err := s.customerRepo.FindByIDForTenant(ctx, tenantID, customerID)
@@ -511,7 +668,7 @@ func (s *CustomerService) AddBalance(ctx context.Context, tenantID, customerID u
 		return nil, err
 	}
 
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
+	if err := s.customerRepo.SaveWithLock(ctx, customer); err != nil {
 		return nil, err
 	}
 
@@ -530,7 +687,8 @@ func (s *CustomerService) DeductBalance(ctx context.Context, tenantID, customerI
 		return nil, err
 	}
 
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
+	// The version check keeps concurrent balance changes from overwriting each other
+	if err := s.customerRepo.SaveWithLock(ctx, customer); err != nil {
 		return nil, err
 	}
 
@@ -549,7 +707,7 @@ func (s *CustomerService) RefundBalance(ctx context.Context, tenantID, customerI
 		return nil, err
 	}
 
-	if err := s.customerRepo.Save(ctx, customer); err != nil {
+	if err := s.customerRepo.SaveWithLock(ctx, customer); err != nil {
 		return nil, err
 	}
 
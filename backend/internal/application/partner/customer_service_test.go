@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/domain/partner"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/erp/backend/internal/domain/trade"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -40,6 +42,14 @@ func (m *MockCustomerRepository) FindByIDForTenant(ctx context.Context, tenantID
 	return args.Get(0).(*partner.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Customer, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*partner.Customer), args.Error(1)
+}
+
 func (m *MockCustomerRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Customer, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -109,6 +119,14 @@ func (m *MockCustomerRepository) FindWithPositiveBalance(ctx context.Context, te
 	return args.Get(0).([]partner.Customer), args.Error(1)
 }
 
+func (m *MockCustomerRepository) FindLevelPeriodEnded(ctx context.Context, asOf time.Time) ([]partner.Customer, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]partner.Customer), args.Error(1)
+}
+
 func (m *MockCustomerRepository) Save(ctx context.Context, customer *partner.Customer) error {
 	args := m.Called(ctx, customer)
 	return args.Error(0)
@@ -134,6 +152,11 @@ func (m *MockCustomerRepository) DeleteForTenant(ctx context.Context, tenantID,
 	return args.Error(0)
 }
 
+func (m *MockCustomerRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 func (m *MockCustomerRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
@@ -237,6 +260,14 @@ func (m *MockAccountReceivableRepository) FindOverdue(ctx context.Context, tenan
 	return args.Get(0).([]finance.AccountReceivable), args.Error(1)
 }
 
+func (m *MockAccountReceivableRepository) FindDueForReminder(ctx context.Context, dueBefore time.Time) ([]finance.AccountReceivable, error) {
+	args := m.Called(ctx, dueBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.AccountReceivable), args.Error(1)
+}
+
 func (m *MockAccountReceivableRepository) Save(ctx context.Context, receivable *finance.AccountReceivable) error {
 	args := m.Called(ctx, receivable)
 	return args.Error(0)
@@ -287,6 +318,14 @@ func (m *MockAccountReceivableRepository) SumOutstandingByCustomer(ctx context.C
 	return args.Get(0).(decimal.Decimal), args.Error(1)
 }
 
+func (m *MockAccountReceivableRepository) SumOutstandingByCustomerByCurrency(ctx context.Context, tenantID, customerID uuid.UUID) (map[valueobject.Currency]decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[valueobject.Currency]decimal.Decimal), args.Error(1)
+}
+
 func (m *MockAccountReceivableRepository) SumOutstandingForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
 	args := m.Called(ctx, tenantID)
 	return args.Get(0).(decimal.Decimal), args.Error(1)
@@ -297,6 +336,27 @@ func (m *MockAccountReceivableRepository) SumOverdueForTenant(ctx context.Contex
 	return args.Get(0).(decimal.Decimal), args.Error(1)
 }
 
+func (m *MockAccountReceivableRepository) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.AgingEntry), args.Error(1)
+}
+
+func (m *MockAccountReceivableRepository) ListPaymentRecords(ctx context.Context, tenantID, receivableID uuid.UUID, page, pageSize int) ([]finance.ReceivablePaymentRecord, int64, error) {
+	args := m.Called(ctx, tenantID, receivableID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]finance.ReceivablePaymentRecord), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAccountReceivableRepository) SumWrittenOffForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
 func (m *MockAccountReceivableRepository) ExistsByReceivableNumber(ctx context.Context, tenantID uuid.UUID, receivableNumber string) (bool, error) {
 	args := m.Called(ctx, tenantID, receivableNumber)
 	return args.Bool(0), args.Error(1)
@@ -437,6 +497,14 @@ func (m *MockSalesOrderRepository) ExistsByProduct(ctx context.Context, tenantID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockSalesOrderRepository) FindExpiredQuotes(ctx context.Context, expiresBefore time.Time) ([]trade.SalesOrder, error) {
+	args := m.Called(ctx, expiresBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]trade.SalesOrder), args.Error(1)
+}
+
 // Verify interface compliance
 var _ trade.SalesOrderRepository = (*MockSalesOrderRepository)(nil)
 
@@ -664,7 +732,7 @@ func TestCustomerService_Update_Success(t *testing.T) {
 	}
 
 	mockRepo.On("FindByIDForTenant", ctx, tenantID, customerID).Return(customer, nil)
-	mockRepo.On("Save", ctx, mock.AnythingOfType("*partner.Customer")).Return(nil)
+	mockRepo.On("SaveWithLock", ctx, mock.AnythingOfType("*partner.Customer")).Return(nil)
 
 	result, err := service.Update(ctx, tenantID, customerID, req)
 
@@ -674,6 +742,36 @@ func TestCustomerService_Update_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCustomerService_Update_StaleVersion(t *testing.T) {
+	mockRepo := new(MockCustomerRepository)
+	service := NewCustomerService(mockRepo)
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	customerID := newTestCustomerID()
+	customer := createTestCustomer(tenantID)
+	loadedVersion := customer.Version
+
+	mockRepo.On("FindByIDForTenant", ctx, tenantID, customerID).Return(customer, nil)
+	mockRepo.On("SaveWithLock", ctx, customer).Run(func(args mock.Arguments) {
+		args.Get(1).(*partner.Customer).Version++
+	}).Return(nil).Once()
+
+	firstNotes := "First edit"
+	_, err := service.Update(ctx, tenantID, customerID, UpdateCustomerRequest{Notes: &firstNotes, Version: &loadedVersion})
+	assert.NoError(t, err)
+
+	secondNotes := "Second edit"
+	result, err := service.Update(ctx, tenantID, customerID, UpdateCustomerRequest{Notes: &secondNotes, Version: &loadedVersion})
+
+	assert.Nil(t, result)
+	var conflictErr *shared.VersionConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, loadedVersion+1, conflictErr.CurrentVersion)
+	assert.Equal(t, "First edit", customer.Notes)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCustomerService_UpdateCode_Success(t *testing.T) {
 	mockRepo := new(MockCustomerRepository)
 	service := NewCustomerService(mockRepo)
@@ -868,6 +966,83 @@ func TestCustomerService_Delete_OrderCheckFailed(t *testing.T) {
 	mockOrderRepo.AssertExpectations(t)
 }
 
+func TestCustomerService_Restore_Success(t *testing.T) {
+	mockRepo := new(MockCustomerRepository)
+	service := NewCustomerService(mockRepo)
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	customerID := newTestCustomerID()
+	customer := createTestCustomer(tenantID)
+	deletedAt := time.Now()
+	customer.DeletedAt = &deletedAt
+
+	mockRepo.On("FindDeletedByIDForTenant", ctx, tenantID, customerID).Return(customer, nil)
+	mockRepo.On("ExistsByCode", ctx, tenantID, customer.Code).Return(false, nil)
+	mockRepo.On("RestoreForTenant", ctx, tenantID, customerID).Return(nil)
+
+	result, err := service.Restore(ctx, tenantID, customerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, customer.Code, result.Code)
+	assert.Nil(t, result.DeletedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCustomerService_Restore_CodeReused(t *testing.T) {
+	mockRepo := new(MockCustomerRepository)
+	service := NewCustomerService(mockRepo)
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	customerID := newTestCustomerID()
+	customer := createTestCustomer(tenantID)
+
+	mockRepo.On("FindDeletedByIDForTenant", ctx, tenantID, customerID).Return(customer, nil)
+	mockRepo.On("ExistsByCode", ctx, tenantID, customer.Code).Return(true, nil)
+
+	result, err := service.Restore(ctx, tenantID, customerID)
+
+	assert.Nil(t, result)
+	var domainErr *shared.DomainError
+	assert.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ALREADY_EXISTS", domainErr.Code)
+	mockRepo.AssertNotCalled(t, "RestoreForTenant", ctx, tenantID, customerID)
+}
+
+func TestCustomerService_Restore_NotDeleted(t *testing.T) {
+	mockRepo := new(MockCustomerRepository)
+	service := NewCustomerService(mockRepo)
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	customerID := newTestCustomerID()
+
+	mockRepo.On("FindDeletedByIDForTenant", ctx, tenantID, customerID).Return(nil, shared.ErrNotFound)
+
+	result, err := service.Restore(ctx, tenantID, customerID)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
+
+func TestCustomerService_List_IncludeDeleted(t *testing.T) {
+	mockRepo := new(MockCustomerRepository)
+	service := NewCustomerService(mockRepo)
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+
+	withDeleted := mock.MatchedBy(func(f shared.Filter) bool { return f.Filters["include_deleted"] == true })
+	mockRepo.On("FindAllForTenant", ctx, tenantID, withDeleted).Return([]partner.Customer{}, nil)
+	mockRepo.On("CountForTenant", ctx, tenantID, withDeleted).Return(int64(0), nil)
+
+	_, _, err := service.List(ctx, tenantID, CustomerListFilter{IncludeDeleted: true})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCustomerService_Activate_Success(t *testing.T) {
 	mockRepo := new(MockCustomerRepository)
 	service := NewCustomerService(mockRepo)
@@ -940,7 +1115,7 @@ func TestCustomerService_AddBalance_Success(t *testing.T) {
 	amount := decimal.NewFromFloat(100.00)
 
 	mockRepo.On("FindByIDForTenant", ctx, tenantID, customerID).Return(customer, nil)
-	mockRepo.On("Save", ctx, mock.AnythingOfType("*partner.Customer")).Return(nil)
+	mockRepo.On("SaveWithLock", ctx, mock.AnythingOfType("*partner.Customer")).Return(nil)
 
 	result, err := service.AddBalance(ctx, tenantID, customerID, amount)
 
@@ -950,6 +1125,132 @@ func TestCustomerService_AddBalance_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// MockCustomerReferenceRepository is a mock implementation of CustomerReferenceRepository
+type MockCustomerReferenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerReferenceRepository) ReassignCustomer(ctx context.Context, tenantID, fromID uuid.UUID, to *partner.Customer) (partner.CustomerReassignment, error) {
+	args := m.Called(ctx, tenantID, fromID, to)
+	return args.Get(0).(partner.CustomerReassignment), args.Error(1)
+}
+
+// stubBalanceTransactionRepository records the balance transactions created during a merge
+type stubBalanceTransactionRepository struct {
+	partner.BalanceTransactionRepository
+	created []*partner.BalanceTransaction
+}
+
+func (r *stubBalanceTransactionRepository) Create(_ context.Context, tx *partner.BalanceTransaction) error {
+	r.created = append(r.created, tx)
+	return nil
+}
+
+// recordingPublisher collects published events
+type recordingPublisher struct {
+	events []shared.DomainEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events ...shared.DomainEvent) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func TestCustomerService_Merge_Success(t *testing.T) {
+	mockRepo := new(MockCustomerRepository)
+	refRepo := new(MockCustomerReferenceRepository)
+	balanceRepo := &stubBalanceTransactionRepository{}
+	publisher := &recordingPublisher{}
+	service := NewCustomerService(mockRepo)
+	service.SetTransactionScope(NewNoOpTransactionScope(mockRepo, balanceRepo, refRepo, nil))
+	service.SetEventPublisher(publisher)
+
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+	surviving, _ := partner.NewOrganizationCustomer(tenantID, "ACME", "Acme Corp")
+	surviving.Balance = decimal.NewFromInt(100)
+	merged, _ := partner.NewOrganizationCustomer(tenantID, "ACME-2", "Acme Corporation")
+	merged.Balance = decimal.NewFromInt(50)
+	surviving.ClearDomainEvents()
+	merged.ClearDomainEvents()
+
+	mockRepo.On("FindByIDForTenant", ctx, tenantID, surviving.ID).Return(surviving, nil)
+	mockRepo.On("FindByIDForTenant", ctx, tenantID, merged.ID).Return(merged, nil)
+	refRepo.On("ReassignCustomer", ctx, tenantID, merged.ID, surviving).
+		Return(partner.CustomerReassignment{SalesOrders: 3, Receivables: 2, BalanceTransactions: 1}, nil)
+	mockRepo.On("SaveWithLock", ctx, merged).Return(nil)
+	mockRepo.On("SaveWithLock", ctx, surviving).Return(nil)
+
+	result, err := service.Merge(ctx, tenantID, surviving.ID, merged.ID)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Customer.Balance.Equal(decimal.NewFromInt(150)))
+	assert.True(t, result.BalanceTransferred.Equal(decimal.NewFromInt(50)))
+	assert.Equal(t, int64(3), result.SalesOrders)
+	assert.Equal(t, int64(2), result.Receivables)
+	assert.Equal(t, partner.CustomerStatusMerged, merged.Status)
+	assert.Equal(t, surviving.ID, *merged.MergedIntoID)
+
+	if assert.Len(t, balanceRepo.created, 1) {
+		tx := balanceRepo.created[0]
+		assert.Equal(t, surviving.ID, tx.CustomerID)
+		assert.Equal(t, partner.BalanceTransactionTypeAdjustment, tx.TransactionType)
+		assert.True(t, tx.BalanceBefore.Equal(decimal.NewFromInt(100)))
+		assert.True(t, tx.BalanceAfter.Equal(decimal.NewFromInt(150)))
+	}
+
+	eventTypes := make([]string, 0, len(publisher.events))
+	for _, e := range publisher.events {
+		eventTypes = append(eventTypes, e.EventType())
+	}
+	assert.Contains(t, eventTypes, partner.EventTypeCustomerMerged)
+	mockRepo.AssertExpectations(t)
+	refRepo.AssertExpectations(t)
+}
+
+func TestCustomerService_Merge_Rejected(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newTestTenantID()
+
+	t.Run("into itself", func(t *testing.T) {
+		mockRepo := new(MockCustomerRepository)
+		service := NewCustomerService(mockRepo)
+		service.SetTransactionScope(NewNoOpTransactionScope(mockRepo, nil, nil, nil))
+
+		customerID := newTestCustomerID()
+		_, err := service.Merge(ctx, tenantID, customerID, customerID)
+
+		var domainErr *shared.DomainError
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, "CANNOT_MERGE_SELF", domainErr.Code)
+		mockRepo.AssertNotCalled(t, "FindByIDForTenant", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("customer of another tenant", func(t *testing.T) {
+		mockRepo := new(MockCustomerRepository)
+		refRepo := new(MockCustomerReferenceRepository)
+		service := NewCustomerService(mockRepo)
+		service.SetTransactionScope(NewNoOpTransactionScope(mockRepo, nil, refRepo, nil))
+
+		surviving := createTestCustomer(tenantID)
+		otherID := uuid.New()
+		mockRepo.On("FindByIDForTenant", ctx, tenantID, surviving.ID).Return(surviving, nil)
+		mockRepo.On("FindByIDForTenant", ctx, tenantID, otherID).Return(nil, shared.ErrNotFound)
+
+		_, err := service.Merge(ctx, tenantID, surviving.ID, otherID)
+
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		refRepo.AssertNotCalled(t, "ReassignCustomer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("without transaction scope", func(t *testing.T) {
+		service := NewCustomerService(new(MockCustomerRepository))
+		_, err := service.Merge(ctx, tenantID, uuid.New(), uuid.New())
+		assert.Error(t, err)
+	})
+}
+
 func TestCustomerService_SetLevel_Success(t *testing.T) {
 	mockRepo := new(MockCustomerRepository)
 	service := NewCustomerService(mockRepo)
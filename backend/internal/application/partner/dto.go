@@ -14,44 +14,47 @@ import (
 
 // CreateCustomerRequest represents a request to create a new customer
 type CreateCustomerRequest struct {
-	Code        string           `json:"code" binding:"required,min=1,max=50"`
-	Name        string           `json:"name" binding:"required,min=1,max=200"`
-	ShortName   string           `json:"short_name" binding:"max=100"`
-	Type        string           `json:"type" binding:"required,oneof=individual organization"`
-	ContactName string           `json:"contact_name" binding:"max=100"`
-	Phone       string           `json:"phone" binding:"max=50"`
-	Email       string           `json:"email" binding:"omitempty,email,max=200"`
-	Address     string           `json:"address" binding:"max=500"`
-	City        string           `json:"city" binding:"max=100"`
-	Province    string           `json:"province" binding:"max=100"`
-	PostalCode  string           `json:"postal_code" binding:"max=20"`
-	Country     string           `json:"country" binding:"max=100"`
-	TaxID       string           `json:"tax_id" binding:"max=50"`
-	CreditLimit *decimal.Decimal `json:"credit_limit"`
-	Notes       string           `json:"notes"`
-	SortOrder   *int             `json:"sort_order"`
-	Attributes  string           `json:"attributes"`
-	CreatedBy   *uuid.UUID       `json:"-"` // Set from JWT context, not from request body
+	Code           string           `json:"code" binding:"required,min=1,max=50"`
+	Name           string           `json:"name" binding:"required,min=1,max=200"`
+	ShortName      string           `json:"short_name" binding:"max=100"`
+	Type           string           `json:"type" binding:"required,oneof=individual organization"`
+	ContactName    string           `json:"contact_name" binding:"max=100"`
+	Phone          string           `json:"phone" binding:"max=50"`
+	Email          string           `json:"email" binding:"omitempty,email,max=200"`
+	Address        string           `json:"address" binding:"max=500"`
+	City           string           `json:"city" binding:"max=100"`
+	Province       string           `json:"province" binding:"max=100"`
+	PostalCode     string           `json:"postal_code" binding:"max=20"`
+	Country        string           `json:"country" binding:"max=100"`
+	TaxID          string           `json:"tax_id" binding:"max=50"`
+	CreditLimit    *decimal.Decimal `json:"credit_limit"`
+	OverdraftLimit *decimal.Decimal `json:"overdraft_limit"` // How far the balance may go below zero, zero for prepaid customers
+	Notes          string           `json:"notes"`
+	SortOrder      *int             `json:"sort_order"`
+	Attributes     string           `json:"attributes"`
+	CreatedBy      *uuid.UUID       `json:"-"` // Set from JWT context, not from request body
 }
 
 // UpdateCustomerRequest represents a request to update a customer
 type UpdateCustomerRequest struct {
-	Name        *string          `json:"name" binding:"omitempty,min=1,max=200"`
-	ShortName   *string          `json:"short_name" binding:"omitempty,max=100"`
-	ContactName *string          `json:"contact_name" binding:"omitempty,max=100"`
-	Phone       *string          `json:"phone" binding:"omitempty,max=50"`
-	Email       *string          `json:"email" binding:"omitempty,email,max=200"`
-	Address     *string          `json:"address" binding:"omitempty,max=500"`
-	City        *string          `json:"city" binding:"omitempty,max=100"`
-	Province    *string          `json:"province" binding:"omitempty,max=100"`
-	PostalCode  *string          `json:"postal_code" binding:"omitempty,max=20"`
-	Country     *string          `json:"country" binding:"omitempty,max=100"`
-	TaxID       *string          `json:"tax_id" binding:"omitempty,max=50"`
-	CreditLimit *decimal.Decimal `json:"credit_limit"`
-	Level       *string          `json:"level" binding:"omitempty,oneof=normal silver gold platinum vip"`
-	Notes       *string          `json:"notes"`
-	SortOrder   *int             `json:"sort_order"`
-	Attributes  *string          `json:"attributes"`
+	Name           *string          `json:"name" binding:"omitempty,min=1,max=200"`
+	ShortName      *string          `json:"short_name" binding:"omitempty,max=100"`
+	ContactName    *string          `json:"contact_name" binding:"omitempty,max=100"`
+	Phone          *string          `json:"phone" binding:"omitempty,max=50"`
+	Email          *string          `json:"email" binding:"omitempty,email,max=200"`
+	Address        *string          `json:"address" binding:"omitempty,max=500"`
+	City           *string          `json:"city" binding:"omitempty,max=100"`
+	Province       *string          `json:"province" binding:"omitempty,max=100"`
+	PostalCode     *string          `json:"postal_code" binding:"omitempty,max=20"`
+	Country        *string          `json:"country" binding:"omitempty,max=100"`
+	TaxID          *string          `json:"tax_id" binding:"omitempty,max=50"`
+	CreditLimit    *decimal.Decimal `json:"credit_limit"`
+	OverdraftLimit *decimal.Decimal `json:"overdraft_limit"`
+	Level          *string          `json:"level" binding:"omitempty,oneof=normal silver gold platinum vip"`
+	Notes          *string          `json:"notes"`
+	SortOrder      *int             `json:"sort_order"`
+	Attributes     *string          `json:"attributes"`
+	Version        *int             `json:"version"` // Version the client loaded; the update fails with a conflict if the customer changed since
 }
 
 // UpdateCustomerCodeRequest represents a request to update a customer's code
@@ -61,32 +64,50 @@ type UpdateCustomerCodeRequest struct {
 
 // CustomerResponse represents a customer in API responses
 type CustomerResponse struct {
-	ID          uuid.UUID       `json:"id"`
-	TenantID    uuid.UUID       `json:"tenant_id"`
-	Code        string          `json:"code"`
-	Name        string          `json:"name"`
-	ShortName   string          `json:"short_name"`
-	Type        string          `json:"type"`
-	Level       string          `json:"level"`
-	Status      string          `json:"status"`
-	ContactName string          `json:"contact_name"`
-	Phone       string          `json:"phone"`
-	Email       string          `json:"email"`
-	Address     string          `json:"address"`
-	City        string          `json:"city"`
-	Province    string          `json:"province"`
-	PostalCode  string          `json:"postal_code"`
-	Country     string          `json:"country"`
-	FullAddress string          `json:"full_address"`
-	TaxID       string          `json:"tax_id"`
-	CreditLimit decimal.Decimal `json:"credit_limit"`
-	Balance     decimal.Decimal `json:"balance"`
-	Notes       string          `json:"notes"`
-	SortOrder   int             `json:"sort_order"`
-	Attributes  string          `json:"attributes"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	Version     int             `json:"version"`
+	ID               uuid.UUID       `json:"id"`
+	TenantID         uuid.UUID       `json:"tenant_id"`
+	Code             string          `json:"code"`
+	Name             string          `json:"name"`
+	ShortName        string          `json:"short_name"`
+	Type             string          `json:"type"`
+	Level            string          `json:"level"`
+	Status           string          `json:"status"`
+	ContactName      string          `json:"contact_name"`
+	Phone            string          `json:"phone"`
+	Email            string          `json:"email"`
+	Address          string          `json:"address"`
+	City             string          `json:"city"`
+	Province         string          `json:"province"`
+	PostalCode       string          `json:"postal_code"`
+	Country          string          `json:"country"`
+	FullAddress      string          `json:"full_address"`
+	TaxID            string          `json:"tax_id"`
+	CreditLimit      decimal.Decimal `json:"credit_limit"`
+	Balance          decimal.Decimal `json:"balance"`
+	OverdraftLimit   decimal.Decimal `json:"overdraft_limit"`
+	SpendableBalance decimal.Decimal `json:"spendable_balance"` // Balance plus the unused overdraft
+	Notes            string          `json:"notes"`
+	SortOrder        int             `json:"sort_order"`
+	Attributes       string          `json:"attributes"`
+	MergedIntoID     *uuid.UUID      `json:"merged_into_id,omitempty"`
+	MergedAt         *time.Time      `json:"merged_at,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	DeletedAt        *time.Time      `json:"deleted_at,omitempty"`
+	Version          int             `json:"version"`
+}
+
+// CustomerMergeResponse reports the outcome of merging a duplicate customer
+type CustomerMergeResponse struct {
+	Customer            CustomerResponse `json:"customer"`
+	MergedCustomerID    uuid.UUID        `json:"merged_customer_id"`
+	BalanceTransferred  decimal.Decimal  `json:"balance_transferred"`
+	SalesOrders         int64            `json:"sales_orders"`
+	SalesReturns        int64            `json:"sales_returns"`
+	Receivables         int64            `json:"receivables"`
+	ReceiptVouchers     int64            `json:"receipt_vouchers"`
+	RefundRecords       int64            `json:"refund_records"`
+	BalanceTransactions int64            `json:"balance_transactions"`
 }
 
 // CustomerListResponse represents a list item for customers
@@ -105,51 +126,58 @@ type CustomerListResponse struct {
 	CreditLimit decimal.Decimal `json:"credit_limit"`
 	Balance     decimal.Decimal `json:"balance"`
 	CreatedAt   time.Time       `json:"created_at"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
 }
 
 // CustomerListFilter represents filter options for customer list
 type CustomerListFilter struct {
-	Search   string `form:"search"`
-	Status   string `form:"status" binding:"omitempty,oneof=active inactive suspended"`
-	Type     string `form:"type" binding:"omitempty,oneof=individual organization"`
-	Level    string `form:"level" binding:"omitempty,oneof=normal silver gold platinum vip"`
-	City     string `form:"city"`
-	Province string `form:"province"`
-	Page     int    `form:"page" binding:"omitempty,min=1"`
-	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
-	OrderBy  string `form:"order_by"`
-	OrderDir string `form:"order_dir" binding:"omitempty,oneof=asc desc"`
+	Search         string `form:"search"`
+	Status         string `form:"status" binding:"omitempty,oneof=active inactive suspended"`
+	Type           string `form:"type" binding:"omitempty,oneof=individual organization"`
+	Level          string `form:"level" binding:"omitempty,oneof=normal silver gold platinum vip"`
+	City           string `form:"city"`
+	Province       string `form:"province"`
+	Page           int    `form:"page" binding:"omitempty,min=1"`
+	PageSize       int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	OrderBy        string `form:"order_by"`
+	OrderDir       string `form:"order_dir" binding:"omitempty,oneof=asc desc"`
+	IncludeDeleted bool   `form:"include_deleted"` // Also list soft-deleted customers
 }
 
 // ToCustomerResponse converts a domain Customer to CustomerResponse
 func ToCustomerResponse(c *partner.Customer) CustomerResponse {
 	return CustomerResponse{
-		ID:          c.ID,
-		TenantID:    c.TenantID,
-		Code:        c.Code,
-		Name:        c.Name,
-		ShortName:   c.ShortName,
-		Type:        string(c.Type),
-		Level:       c.Level.Code(), // CustomerLevel is now a Value Object, use Code()
-		Status:      string(c.Status),
-		ContactName: c.ContactName,
-		Phone:       c.Phone,
-		Email:       c.Email,
-		Address:     c.Address,
-		City:        c.City,
-		Province:    c.Province,
-		PostalCode:  c.PostalCode,
-		Country:     c.Country,
-		FullAddress: c.GetFullAddress(),
-		TaxID:       c.TaxID,
-		CreditLimit: c.CreditLimit,
-		Balance:     c.Balance,
-		Notes:       c.Notes,
-		SortOrder:   c.SortOrder,
-		Attributes:  c.Attributes,
-		CreatedAt:   c.CreatedAt,
-		UpdatedAt:   c.UpdatedAt,
-		Version:     c.Version,
+		ID:               c.ID,
+		TenantID:         c.TenantID,
+		Code:             c.Code,
+		Name:             c.Name,
+		ShortName:        c.ShortName,
+		Type:             string(c.Type),
+		Level:            c.Level.Code(), // CustomerLevel is now a Value Object, use Code()
+		Status:           string(c.Status),
+		ContactName:      c.ContactName,
+		Phone:            c.Phone,
+		Email:            c.Email,
+		Address:          c.Address,
+		City:             c.City,
+		Province:         c.Province,
+		PostalCode:       c.PostalCode,
+		Country:          c.Country,
+		FullAddress:      c.GetFullAddress(),
+		TaxID:            c.TaxID,
+		CreditLimit:      c.CreditLimit,
+		Balance:          c.Balance,
+		OverdraftLimit:   c.OverdraftLimit,
+		SpendableBalance: c.SpendableBalance(),
+		Notes:            c.Notes,
+		SortOrder:        c.SortOrder,
+		Attributes:       c.Attributes,
+		MergedIntoID:     c.MergedIntoID,
+		MergedAt:         c.MergedAt,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+		DeletedAt:        c.DeletedAt,
+		Version:          c.Version,
 	}
 }
 
@@ -170,6 +198,7 @@ func ToCustomerListResponse(c *partner.Customer) CustomerListResponse {
 		CreditLimit: c.CreditLimit,
 		Balance:     c.Balance,
 		CreatedAt:   c.CreatedAt,
+		DeletedAt:   c.DeletedAt,
 	}
 }
 
@@ -271,6 +300,7 @@ type SupplierResponse struct {
 	Attributes      string          `json:"attributes"`
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       *time.Time      `json:"deleted_at,omitempty"`
 	Version         int             `json:"version"`
 }
 
@@ -291,21 +321,23 @@ type SupplierListResponse struct {
 	Balance     decimal.Decimal `json:"balance"`
 	Rating      int             `json:"rating"`
 	CreatedAt   time.Time       `json:"created_at"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
 }
 
 // SupplierListFilter represents filter options for supplier list
 type SupplierListFilter struct {
-	Search    string `form:"search"`
-	Status    string `form:"status" binding:"omitempty,oneof=active inactive blocked"`
-	Type      string `form:"type" binding:"omitempty,oneof=manufacturer distributor retailer service"`
-	City      string `form:"city"`
-	Province  string `form:"province"`
-	MinRating *int   `form:"min_rating" binding:"omitempty,min=0,max=5"`
-	MaxRating *int   `form:"max_rating" binding:"omitempty,min=0,max=5"`
-	Page      int    `form:"page" binding:"omitempty,min=1"`
-	PageSize  int    `form:"page_size" binding:"omitempty,min=1,max=100"`
-	OrderBy   string `form:"order_by"`
-	OrderDir  string `form:"order_dir" binding:"omitempty,oneof=asc desc"`
+	Search         string `form:"search"`
+	Status         string `form:"status" binding:"omitempty,oneof=active inactive blocked"`
+	Type           string `form:"type" binding:"omitempty,oneof=manufacturer distributor retailer service"`
+	City           string `form:"city"`
+	Province       string `form:"province"`
+	MinRating      *int   `form:"min_rating" binding:"omitempty,min=0,max=5"`
+	MaxRating      *int   `form:"max_rating" binding:"omitempty,min=0,max=5"`
+	Page           int    `form:"page" binding:"omitempty,min=1"`
+	PageSize       int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	OrderBy        string `form:"order_by"`
+	OrderDir       string `form:"order_dir" binding:"omitempty,oneof=asc desc"`
+	IncludeDeleted bool   `form:"include_deleted"` // Also list soft-deleted suppliers
 }
 
 // ToSupplierResponse converts a domain Supplier to SupplierResponse
@@ -340,6 +372,7 @@ func ToSupplierResponse(s *partner.Supplier) SupplierResponse {
 		Attributes:      s.Attributes,
 		CreatedAt:       s.CreatedAt,
 		UpdatedAt:       s.UpdatedAt,
+		DeletedAt:       s.DeletedAt,
 		Version:         s.Version,
 	}
 }
@@ -362,6 +395,7 @@ func ToSupplierListResponse(s *partner.Supplier) SupplierListResponse {
 		Balance:     s.Balance,
 		Rating:      s.Rating,
 		CreatedAt:   s.CreatedAt,
+		DeletedAt:   s.DeletedAt,
 	}
 }
 
@@ -380,43 +414,46 @@ func ToSupplierListResponses(suppliers []partner.Supplier) []SupplierListRespons
 
 // CreateWarehouseRequest represents a request to create a new warehouse
 type CreateWarehouseRequest struct {
-	Code        string     `json:"code" binding:"required,min=1,max=50"`
-	Name        string     `json:"name" binding:"required,min=1,max=200"`
-	ShortName   string     `json:"short_name" binding:"max=100"`
-	Type        string     `json:"type" binding:"required,oneof=physical virtual consign transit"`
-	ContactName string     `json:"contact_name" binding:"max=100"`
-	Phone       string     `json:"phone" binding:"max=50"`
-	Email       string     `json:"email" binding:"omitempty,email,max=200"`
-	Address     string     `json:"address" binding:"max=500"`
-	City        string     `json:"city" binding:"max=100"`
-	Province    string     `json:"province" binding:"max=100"`
-	PostalCode  string     `json:"postal_code" binding:"max=20"`
-	Country     string     `json:"country" binding:"max=100"`
-	IsDefault   *bool      `json:"is_default"`
-	Capacity    *int       `json:"capacity"`
-	Notes       string     `json:"notes"`
-	SortOrder   *int       `json:"sort_order"`
-	Attributes  string     `json:"attributes"`
-	CreatedBy   *uuid.UUID `json:"-"` // Set from JWT context, not from request body
+	Code               string     `json:"code" binding:"required,min=1,max=50"`
+	Name               string     `json:"name" binding:"required,min=1,max=200"`
+	ShortName          string     `json:"short_name" binding:"max=100"`
+	Type               string     `json:"type" binding:"required,oneof=physical virtual consign transit"`
+	ContactName        string     `json:"contact_name" binding:"max=100"`
+	Phone              string     `json:"phone" binding:"max=50"`
+	Email              string     `json:"email" binding:"omitempty,email,max=200"`
+	Address            string     `json:"address" binding:"max=500"`
+	City               string     `json:"city" binding:"max=100"`
+	Province           string     `json:"province" binding:"max=100"`
+	PostalCode         string     `json:"postal_code" binding:"max=20"`
+	Country            string     `json:"country" binding:"max=100"`
+	IsDefault          *bool      `json:"is_default"`
+	Capacity           *int       `json:"capacity"`
+	CapacityUnit       string     `json:"capacity_unit" binding:"omitempty,oneof=unit kg m3"`
+	AllowNegativeStock *bool      `json:"allow_negative_stock"`
+	Notes              string     `json:"notes"`
+	SortOrder          *int       `json:"sort_order"`
+	Attributes         string     `json:"attributes"`
+	CreatedBy          *uuid.UUID `json:"-"` // Set from JWT context, not from request body
 }
 
 // UpdateWarehouseRequest represents a request to update a warehouse
 type UpdateWarehouseRequest struct {
-	Name        *string `json:"name" binding:"omitempty,min=1,max=200"`
-	ShortName   *string `json:"short_name" binding:"omitempty,max=100"`
-	ContactName *string `json:"contact_name" binding:"omitempty,max=100"`
-	Phone       *string `json:"phone" binding:"omitempty,max=50"`
-	Email       *string `json:"email" binding:"omitempty,email,max=200"`
-	Address     *string `json:"address" binding:"omitempty,max=500"`
-	City        *string `json:"city" binding:"omitempty,max=100"`
-	Province    *string `json:"province" binding:"omitempty,max=100"`
-	PostalCode  *string `json:"postal_code" binding:"omitempty,max=20"`
-	Country     *string `json:"country" binding:"omitempty,max=100"`
-	IsDefault   *bool   `json:"is_default"`
-	Capacity    *int    `json:"capacity"`
-	Notes       *string `json:"notes"`
-	SortOrder   *int    `json:"sort_order"`
-	Attributes  *string `json:"attributes"`
+	Name         *string `json:"name" binding:"omitempty,min=1,max=200"`
+	ShortName    *string `json:"short_name" binding:"omitempty,max=100"`
+	ContactName  *string `json:"contact_name" binding:"omitempty,max=100"`
+	Phone        *string `json:"phone" binding:"omitempty,max=50"`
+	Email        *string `json:"email" binding:"omitempty,email,max=200"`
+	Address      *string `json:"address" binding:"omitempty,max=500"`
+	City         *string `json:"city" binding:"omitempty,max=100"`
+	Province     *string `json:"province" binding:"omitempty,max=100"`
+	PostalCode   *string `json:"postal_code" binding:"omitempty,max=20"`
+	Country      *string `json:"country" binding:"omitempty,max=100"`
+	IsDefault    *bool   `json:"is_default"`
+	Capacity     *int    `json:"capacity"`
+	CapacityUnit *string `json:"capacity_unit" binding:"omitempty,oneof=unit kg m3"`
+	Notes        *string `json:"notes"`
+	SortOrder    *int    `json:"sort_order"`
+	Attributes   *string `json:"attributes"`
 }
 
 // UpdateWarehouseCodeRequest represents a request to update a warehouse's code
@@ -424,48 +461,66 @@ type UpdateWarehouseCodeRequest struct {
 	Code string `json:"code" binding:"required,min=1,max=50"`
 }
 
+// SetWarehouseNegativeStockRequest represents a request to set a warehouse's negative-stock policy
+type SetWarehouseNegativeStockRequest struct {
+	AllowNegativeStock *bool `json:"allow_negative_stock" binding:"required"`
+}
+
 // WarehouseResponse represents a warehouse in API responses
 type WarehouseResponse struct {
-	ID          uuid.UUID `json:"id"`
-	TenantID    uuid.UUID `json:"tenant_id"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	ShortName   string    `json:"short_name"`
-	Type        string    `json:"type"`
-	Status      string    `json:"status"`
-	ContactName string    `json:"contact_name"`
-	Phone       string    `json:"phone"`
-	Email       string    `json:"email"`
-	Address     string    `json:"address"`
-	City        string    `json:"city"`
-	Province    string    `json:"province"`
-	PostalCode  string    `json:"postal_code"`
-	Country     string    `json:"country"`
-	FullAddress string    `json:"full_address"`
-	IsDefault   bool      `json:"is_default"`
-	Capacity    int       `json:"capacity"`
-	Notes       string    `json:"notes"`
-	SortOrder   int       `json:"sort_order"`
-	Attributes  string    `json:"attributes"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Version     int       `json:"version"`
+	ID                 uuid.UUID `json:"id"`
+	TenantID           uuid.UUID `json:"tenant_id"`
+	Code               string    `json:"code"`
+	Name               string    `json:"name"`
+	ShortName          string    `json:"short_name"`
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	ContactName        string    `json:"contact_name"`
+	Phone              string    `json:"phone"`
+	Email              string    `json:"email"`
+	Address            string    `json:"address"`
+	City               string    `json:"city"`
+	Province           string    `json:"province"`
+	PostalCode         string    `json:"postal_code"`
+	Country            string    `json:"country"`
+	FullAddress        string    `json:"full_address"`
+	IsDefault          bool      `json:"is_default"`
+	Capacity           int       `json:"capacity"`
+	CapacityUnit       string    `json:"capacity_unit"`
+	AllowNegativeStock bool      `json:"allow_negative_stock"`
+	Notes              string    `json:"notes"`
+	SortOrder          int       `json:"sort_order"`
+	Attributes         string    `json:"attributes"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Version            int       `json:"version"`
 }
 
 // WarehouseListResponse represents a list item for warehouses
 type WarehouseListResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	ShortName   string    `json:"short_name"`
-	Type        string    `json:"type"`
-	Status      string    `json:"status"`
-	ContactName string    `json:"contact_name"`
-	Phone       string    `json:"phone"`
-	City        string    `json:"city"`
-	IsDefault   bool      `json:"is_default"`
-	Capacity    int       `json:"capacity"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID           uuid.UUID `json:"id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	ShortName    string    `json:"short_name"`
+	Type         string    `json:"type"`
+	Status       string    `json:"status"`
+	ContactName  string    `json:"contact_name"`
+	Phone        string    `json:"phone"`
+	City         string    `json:"city"`
+	IsDefault    bool      `json:"is_default"`
+	Capacity     int       `json:"capacity"`
+	CapacityUnit string    `json:"capacity_unit"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// WarehouseUtilizationResponse reports how much of a warehouse's capacity is in use
+type WarehouseUtilizationResponse struct {
+	WarehouseID  uuid.UUID       `json:"warehouse_id"`
+	Code         string          `json:"code"`
+	CapacityUnit string          `json:"capacity_unit"`
+	Used         decimal.Decimal `json:"used"`
+	Total        decimal.Decimal `json:"total"`
+	Percent      decimal.Decimal `json:"percent"`
 }
 
 // WarehouseListFilter represents filter options for warehouse list
@@ -509,48 +564,51 @@ func mapAPIStatusToDomain(status string) string {
 // ToWarehouseResponse converts a domain Warehouse to WarehouseResponse
 func ToWarehouseResponse(w *partner.Warehouse) WarehouseResponse {
 	return WarehouseResponse{
-		ID:          w.ID,
-		TenantID:    w.TenantID,
-		Code:        w.Code,
-		Name:        w.Name,
-		ShortName:   w.ShortName,
-		Type:        string(w.Type),
-		Status:      mapWarehouseStatus(w.Status),
-		ContactName: w.ContactName,
-		Phone:       w.Phone,
-		Email:       w.Email,
-		Address:     w.Address,
-		City:        w.City,
-		Province:    w.Province,
-		PostalCode:  w.PostalCode,
-		Country:     w.Country,
-		FullAddress: w.GetFullAddress(),
-		IsDefault:   w.IsDefault,
-		Capacity:    w.Capacity,
-		Notes:       w.Notes,
-		SortOrder:   w.SortOrder,
-		Attributes:  w.Attributes,
-		CreatedAt:   w.CreatedAt,
-		UpdatedAt:   w.UpdatedAt,
-		Version:     w.Version,
+		ID:                 w.ID,
+		TenantID:           w.TenantID,
+		Code:               w.Code,
+		Name:               w.Name,
+		ShortName:          w.ShortName,
+		Type:               string(w.Type),
+		Status:             mapWarehouseStatus(w.Status),
+		ContactName:        w.ContactName,
+		Phone:              w.Phone,
+		Email:              w.Email,
+		Address:            w.Address,
+		City:               w.City,
+		Province:           w.Province,
+		PostalCode:         w.PostalCode,
+		Country:            w.Country,
+		FullAddress:        w.GetFullAddress(),
+		IsDefault:          w.IsDefault,
+		Capacity:           w.Capacity,
+		CapacityUnit:       string(w.CapacityUnit),
+		AllowNegativeStock: w.AllowNegativeStock,
+		Notes:              w.Notes,
+		SortOrder:          w.SortOrder,
+		Attributes:         w.Attributes,
+		CreatedAt:          w.CreatedAt,
+		UpdatedAt:          w.UpdatedAt,
+		Version:            w.Version,
 	}
 }
 
 // ToWarehouseListResponse converts a domain Warehouse to WarehouseListResponse
 func ToWarehouseListResponse(w *partner.Warehouse) WarehouseListResponse {
 	return WarehouseListResponse{
-		ID:          w.ID,
-		Code:        w.Code,
-		Name:        w.Name,
-		ShortName:   w.ShortName,
-		Type:        string(w.Type),
-		Status:      mapWarehouseStatus(w.Status),
-		ContactName: w.ContactName,
-		Phone:       w.Phone,
-		City:        w.City,
-		IsDefault:   w.IsDefault,
-		Capacity:    w.Capacity,
-		CreatedAt:   w.CreatedAt,
+		ID:           w.ID,
+		Code:         w.Code,
+		Name:         w.Name,
+		ShortName:    w.ShortName,
+		Type:         string(w.Type),
+		Status:       mapWarehouseStatus(w.Status),
+		ContactName:  w.ContactName,
+		Phone:        w.Phone,
+		City:         w.City,
+		IsDefault:    w.IsDefault,
+		Capacity:     w.Capacity,
+		CapacityUnit: string(w.CapacityUnit),
+		CreatedAt:    w.CreatedAt,
 	}
 }
 
@@ -649,3 +707,83 @@ func ToBalanceTransactionResponses(transactions []*partner.BalanceTransaction) [
 	}
 	return responses
 }
+
+// =============================================================================
+// Address DTOs
+// =============================================================================
+
+// CreateAddressRequest represents a request to add an address to a customer's or supplier's address book
+type CreateAddressRequest struct {
+	Type        string `json:"type" binding:"required,oneof=billing shipping"`
+	Label       string `json:"label" binding:"max=100"`
+	ContactName string `json:"contact_name" binding:"max=100"`
+	Phone       string `json:"phone" binding:"max=50"`
+	Province    string `json:"province" binding:"max=100"`
+	City        string `json:"city" binding:"max=100"`
+	Detail      string `json:"detail" binding:"required,min=1,max=500"`
+	PostalCode  string `json:"postal_code" binding:"max=20"`
+	Country     string `json:"country" binding:"max=100"`
+	IsDefault   bool   `json:"is_default"` // The first address of a type always becomes its default
+}
+
+// UpdateAddressRequest represents a request to update an address
+type UpdateAddressRequest struct {
+	Label       *string `json:"label" binding:"omitempty,max=100"`
+	ContactName *string `json:"contact_name" binding:"omitempty,max=100"`
+	Phone       *string `json:"phone" binding:"omitempty,max=50"`
+	Province    *string `json:"province" binding:"omitempty,max=100"`
+	City        *string `json:"city" binding:"omitempty,max=100"`
+	Detail      *string `json:"detail" binding:"omitempty,min=1,max=500"`
+	PostalCode  *string `json:"postal_code" binding:"omitempty,max=20"`
+	Country     *string `json:"country" binding:"omitempty,max=100"`
+	IsDefault   *bool   `json:"is_default"` // Only true is accepted; set another address as default to move the flag
+}
+
+// AddressResponse represents an address in API responses
+type AddressResponse struct {
+	ID          uuid.UUID `json:"id"`
+	OwnerType   string    `json:"owner_type"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	Type        string    `json:"type"`
+	Label       string    `json:"label"`
+	ContactName string    `json:"contact_name"`
+	Phone       string    `json:"phone"`
+	Province    string    `json:"province"`
+	City        string    `json:"city"`
+	Detail      string    `json:"detail"`
+	PostalCode  string    `json:"postal_code"`
+	Country     string    `json:"country"`
+	IsDefault   bool      `json:"is_default"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToAddressResponse converts a domain Address to AddressResponse
+func ToAddressResponse(a *partner.Address) AddressResponse {
+	return AddressResponse{
+		ID:          a.ID,
+		OwnerType:   string(a.OwnerType),
+		OwnerID:     a.OwnerID,
+		Type:        string(a.Type),
+		Label:       a.Label,
+		ContactName: a.ContactName,
+		Phone:       a.Phone,
+		Province:    a.Province,
+		City:        a.City,
+		Detail:      a.Detail,
+		PostalCode:  a.PostalCode,
+		Country:     a.Country,
+		IsDefault:   a.IsDefault,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
+
+// ToAddressResponses converts a slice of domain Addresses to AddressResponses
+func ToAddressResponses(addresses []partner.Address) []AddressResponse {
+	responses := make([]AddressResponse, len(addresses))
+	for i := range addresses {
+		responses[i] = ToAddressResponse(&addresses[i])
+	}
+	return responses
+}
@@ -17,6 +17,7 @@ type SupplierService struct {
 	supplierRepo       partner.SupplierRepository
 	accountPayableRepo finance.AccountPayableRepository // Optional: for delete validation
 	purchaseOrderRepo  trade.PurchaseOrderRepository    // Optional: for delete validation
+	eventPublisher     shared.EventPublisher            // Optional: publishes supplier events to other contexts
 }
 
 // NewSupplierService creates a new SupplierService
@@ -36,6 +37,25 @@ func (s *SupplierService) SetPurchaseOrderRepo(repo trade.PurchaseOrderRepositor
 	s.purchaseOrderRepo = repo
 }
 
+// SetEventPublisher sets the event publisher for publishing supplier domain events
+func (s *SupplierService) SetEventPublisher(publisher shared.EventPublisher) {
+	s.eventPublisher = publisher
+}
+
+// publishDomainEvents publishes all domain events from the supplier
+func (s *SupplierService) publishDomainEvents(ctx context.Context, supplier *partner.Supplier) {
+	if s.eventPublisher == nil {
+		return
+	}
+	events := supplier.GetDomainEvents()
+	if len(events) == 0 {
+		return
+	}
+	// Publish events (errors are logged by the event bus, not propagated)
+	_ = s.eventPublisher.Publish(ctx, events...)
+	supplier.ClearDomainEvents()
+}
+
 // Create creates a new supplier
 func (s *SupplierService) Create(ctx context.Context, tenantID uuid.UUID, req CreateSupplierRequest) (*SupplierResponse, error) {
 	// Check if code already exists
@@ -159,6 +179,7 @@ func (s *SupplierService) Create(ctx context.Context, tenantID uuid.UUID, req Cr
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
@@ -231,6 +252,9 @@ func (s *SupplierService) List(ctx context.Context, tenantID uuid.UUID, filter S
 	if filter.MaxRating != nil {
 		domainFilter.Filters["max_rating"] = *filter.MaxRating
 	}
+	if filter.IncludeDeleted {
+		domainFilter.Filters["include_deleted"] = true
+	}
 
 	// Get suppliers
 	suppliers, err := s.supplierRepo.FindAllForTenant(ctx, tenantID, domainFilter)
@@ -405,6 +429,7 @@ func (s *SupplierService) Update(ctx context.Context, tenantID, supplierID uuid.
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
@@ -438,6 +463,7 @@ func (s *SupplierService) UpdateCode(ctx context.Context, tenantID, supplierID u
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
@@ -484,7 +510,39 @@ func (s *SupplierService) Delete(ctx context.Context, tenantID, supplierID uuid.
 		}
 	}
 
-	return s.supplierRepo.DeleteForTenant(ctx, tenantID, supplierID)
+	if err := s.supplierRepo.DeleteForTenant(ctx, tenantID, supplierID); err != nil {
+		return err
+	}
+
+	supplier.AddDomainEvent(partner.NewSupplierDeletedEvent(supplier))
+	s.publishDomainEvents(ctx, supplier)
+	return nil
+}
+
+// Restore restores a soft-deleted supplier.
+// It fails if another supplier has taken the code since the supplier was deleted.
+func (s *SupplierService) Restore(ctx context.Context, tenantID, supplierID uuid.UUID) (*SupplierResponse, error) {
+	supplier, err := s.supplierRepo.FindDeletedByIDForTenant(ctx, tenantID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.supplierRepo.ExistsByCode(ctx, tenantID, supplier.Code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, shared.NewDomainError("ALREADY_EXISTS",
+			fmt.Sprintf("Cannot restore supplier: code %s is now used by another supplier", supplier.Code))
+	}
+
+	if err := s.supplierRepo.RestoreForTenant(ctx, tenantID, supplierID); err != nil {
+		return nil, err
+	}
+	supplier.DeletedAt = nil
+
+	response := ToSupplierResponse(supplier)
+	return &response, nil
 }
 
 // Activate activates a supplier
@@ -501,6 +559,7 @@ func (s *SupplierService) Activate(ctx context.Context, tenantID, supplierID uui
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
@@ -520,6 +579,7 @@ func (s *SupplierService) Deactivate(ctx context.Context, tenantID, supplierID u
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
@@ -539,6 +599,7 @@ func (s *SupplierService) Block(ctx context.Context, tenantID, supplierID uuid.U
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
@@ -558,6 +619,7 @@ func (s *SupplierService) SetRating(ctx context.Context, tenantID, supplierID uu
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
@@ -577,6 +639,7 @@ func (s *SupplierService) SetPaymentTerms(ctx context.Context, tenantID, supplie
 	if err := s.supplierRepo.Save(ctx, supplier); err != nil {
 		return nil, err
 	}
+	s.publishDomainEvents(ctx, supplier)
 
 	response := ToSupplierResponse(supplier)
 	return &response, nil
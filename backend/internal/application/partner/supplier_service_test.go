@@ -40,6 +40,14 @@ func (m *MockSupplierRepository) FindByIDForTenant(ctx context.Context, tenantID
 	return args.Get(0).(*partner.Supplier), args.Error(1)
 }
 
+func (m *MockSupplierRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Supplier, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*partner.Supplier), args.Error(1)
+}
+
 func (m *MockSupplierRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Supplier, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -129,6 +137,11 @@ func (m *MockSupplierRepository) DeleteForTenant(ctx context.Context, tenantID,
 	return args.Error(0)
 }
 
+func (m *MockSupplierRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 func (m *MockSupplierRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
@@ -275,6 +288,11 @@ func (m *MockAccountPayableRepositoryForSupplier) CountOutstandingBySupplier(ctx
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockAccountPayableRepositoryForSupplier) UpdateSupplierName(ctx context.Context, tenantID, supplierID uuid.UUID, supplierName string) (int64, error) {
+	args := m.Called(ctx, tenantID, supplierID, supplierName)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockAccountPayableRepositoryForSupplier) SumOutstandingBySupplier(ctx context.Context, tenantID, supplierID uuid.UUID) (decimal.Decimal, error) {
 	args := m.Called(ctx, tenantID, supplierID)
 	return args.Get(0).(decimal.Decimal), args.Error(1)
@@ -290,6 +308,14 @@ func (m *MockAccountPayableRepositoryForSupplier) SumOverdueForTenant(ctx contex
 	return args.Get(0).(decimal.Decimal), args.Error(1)
 }
 
+func (m *MockAccountPayableRepositoryForSupplier) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]finance.AgingEntry), args.Error(1)
+}
+
 func (m *MockAccountPayableRepositoryForSupplier) ExistsByPayableNumber(ctx context.Context, tenantID uuid.UUID, payableNumber string) (bool, error) {
 	args := m.Called(ctx, tenantID, payableNumber)
 	return args.Bool(0), args.Error(1)
@@ -485,6 +511,50 @@ func TestSupplierService_Delete_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestSupplierService_Restore_Success(t *testing.T) {
+	mockRepo := new(MockSupplierRepository)
+	service := NewSupplierService(mockRepo)
+
+	ctx := context.Background()
+	tenantID := newSupplierTestTenantID()
+	supplierID := newSupplierTestSupplierID()
+	supplier := createTestSupplier(tenantID)
+	deletedAt := supplier.UpdatedAt
+	supplier.DeletedAt = &deletedAt
+
+	mockRepo.On("FindDeletedByIDForTenant", ctx, tenantID, supplierID).Return(supplier, nil)
+	mockRepo.On("ExistsByCode", ctx, tenantID, supplier.Code).Return(false, nil)
+	mockRepo.On("RestoreForTenant", ctx, tenantID, supplierID).Return(nil)
+
+	result, err := service.Restore(ctx, tenantID, supplierID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, supplier.Code, result.Code)
+	assert.Nil(t, result.DeletedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSupplierService_Restore_CodeReused(t *testing.T) {
+	mockRepo := new(MockSupplierRepository)
+	service := NewSupplierService(mockRepo)
+
+	ctx := context.Background()
+	tenantID := newSupplierTestTenantID()
+	supplierID := newSupplierTestSupplierID()
+	supplier := createTestSupplier(tenantID)
+
+	mockRepo.On("FindDeletedByIDForTenant", ctx, tenantID, supplierID).Return(supplier, nil)
+	mockRepo.On("ExistsByCode", ctx, tenantID, supplier.Code).Return(true, nil)
+
+	result, err := service.Restore(ctx, tenantID, supplierID)
+
+	assert.Nil(t, result)
+	var domainErr *shared.DomainError
+	assert.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ALREADY_EXISTS", domainErr.Code)
+	mockRepo.AssertNotCalled(t, "RestoreForTenant", ctx, tenantID, supplierID)
+}
+
 func TestSupplierService_Delete_HasBalance(t *testing.T) {
 	mockRepo := new(MockSupplierRepository)
 	service := NewSupplierService(mockRepo)
@@ -0,0 +1,83 @@
+package partner
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/partner"
+)
+
+// TransactionScope provides transactional access to partner repositories.
+// When a function is executed within a transaction scope, all repository operations
+// will be part of the same database transaction and will be committed or rolled back atomically.
+type TransactionScope interface {
+	// Execute runs the given function within a database transaction.
+	// If the function returns an error, the transaction is rolled back.
+	// If the function succeeds, the transaction is committed.
+	Execute(ctx context.Context, fn func(repos TransactionalRepositories) error) error
+}
+
+// TransactionalRepositories provides access to partner repositories within a transaction.
+// All repositories returned share the same underlying database transaction.
+type TransactionalRepositories interface {
+	// CustomerRepo returns the customer repository scoped to the current transaction
+	CustomerRepo() partner.CustomerRepository
+	// BalanceTransactionRepo returns the balance transaction repository scoped to the current transaction
+	BalanceTransactionRepo() partner.BalanceTransactionRepository
+	// CustomerReferenceRepo returns the customer reference repository scoped to the current transaction
+	CustomerReferenceRepo() partner.CustomerReferenceRepository
+	// AddressRepo returns the partner address repository scoped to the current transaction
+	AddressRepo() partner.AddressRepository
+}
+
+// NoOpTransactionScope is a transaction scope that doesn't actually use transactions.
+// This is useful for testing or when transaction support is not required.
+type NoOpTransactionScope struct {
+	customerRepo          partner.CustomerRepository
+	balanceTxRepo         partner.BalanceTransactionRepository
+	customerReferenceRepo partner.CustomerReferenceRepository
+	addressRepo           partner.AddressRepository
+}
+
+// NewNoOpTransactionScope creates a NoOpTransactionScope with the given repositories.
+func NewNoOpTransactionScope(
+	customerRepo partner.CustomerRepository,
+	balanceTxRepo partner.BalanceTransactionRepository,
+	customerReferenceRepo partner.CustomerReferenceRepository,
+	addressRepo partner.AddressRepository,
+) *NoOpTransactionScope {
+	return &NoOpTransactionScope{
+		customerRepo:          customerRepo,
+		balanceTxRepo:         balanceTxRepo,
+		customerReferenceRepo: customerReferenceRepo,
+		addressRepo:           addressRepo,
+	}
+}
+
+// Execute runs the function without a real transaction (for testing/compatibility).
+func (s *NoOpTransactionScope) Execute(_ context.Context, fn func(repos TransactionalRepositories) error) error {
+	return fn(s)
+}
+
+// CustomerRepo returns the customer repository.
+func (s *NoOpTransactionScope) CustomerRepo() partner.CustomerRepository {
+	return s.customerRepo
+}
+
+// BalanceTransactionRepo returns the balance transaction repository.
+func (s *NoOpTransactionScope) BalanceTransactionRepo() partner.BalanceTransactionRepository {
+	return s.balanceTxRepo
+}
+
+// CustomerReferenceRepo returns the customer reference repository.
+func (s *NoOpTransactionScope) CustomerReferenceRepo() partner.CustomerReferenceRepository {
+	return s.customerReferenceRepo
+}
+
+// AddressRepo returns the partner address repository.
+func (s *NoOpTransactionScope) AddressRepo() partner.AddressRepository {
+	return s.addressRepo
+}
+
+// Ensure NoOpTransactionScope implements both interfaces
+var _ TransactionScope = (*NoOpTransactionScope)(nil)
+var _ TransactionalRepositories = (*NoOpTransactionScope)(nil)
@@ -8,12 +8,20 @@ import (
 	"github.com/erp/backend/internal/domain/partner"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // WarehouseService handles warehouse-related business operations
 type WarehouseService struct {
 	warehouseRepo     partner.WarehouseRepository
 	inventoryItemRepo inventory.InventoryItemRepository
+	loadReader        WarehouseLoadReader
+}
+
+// WarehouseLoadReader measures the on-hand stock of a warehouse in a capacity unit
+type WarehouseLoadReader interface {
+	// SumLoadByWarehouse sums the on-hand stock in a warehouse measured in the given unit
+	SumLoadByWarehouse(ctx context.Context, tenantID, warehouseID uuid.UUID, unit partner.CapacityUnit) (decimal.Decimal, error)
 }
 
 // NewWarehouseService creates a new WarehouseService
@@ -75,6 +83,16 @@ func (s *WarehouseService) Create(ctx context.Context, tenantID uuid.UUID, req C
 			return nil, err
 		}
 	}
+	if req.CapacityUnit != "" {
+		if err := warehouse.SetCapacityUnit(partner.CapacityUnit(req.CapacityUnit)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Set negative-stock policy
+	if req.AllowNegativeStock != nil {
+		warehouse.SetAllowNegativeStock(*req.AllowNegativeStock)
+	}
 
 	// Set notes
 	if req.Notes != "" {
@@ -286,6 +304,11 @@ func (s *WarehouseService) Update(ctx context.Context, tenantID, warehouseID uui
 			return nil, err
 		}
 	}
+	if req.CapacityUnit != nil {
+		if err := warehouse.SetCapacityUnit(partner.CapacityUnit(*req.CapacityUnit)); err != nil {
+			return nil, err
+		}
+	}
 
 	// Handle default warehouse setting
 	if req.IsDefault != nil {
@@ -466,6 +489,54 @@ func (s *WarehouseService) SetDefault(ctx context.Context, tenantID, warehouseID
 	return &response, nil
 }
 
+// SetNegativeStockPolicy sets whether stock-outs may drive on-hand below zero in a warehouse
+func (s *WarehouseService) SetNegativeStockPolicy(ctx context.Context, tenantID, warehouseID uuid.UUID, allow bool) (*WarehouseResponse, error) {
+	warehouse, err := s.warehouseRepo.FindByIDForTenant(ctx, tenantID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouse.SetAllowNegativeStock(allow)
+
+	if err := s.warehouseRepo.Save(ctx, warehouse); err != nil {
+		return nil, err
+	}
+
+	response := ToWarehouseResponse(warehouse)
+	return &response, nil
+}
+
+// SetLoadReader sets the reader used to measure warehouse utilization
+func (s *WarehouseService) SetLoadReader(reader WarehouseLoadReader) {
+	s.loadReader = reader
+}
+
+// GetUtilization returns how much of a warehouse's capacity its on-hand stock takes up
+func (s *WarehouseService) GetUtilization(ctx context.Context, tenantID, warehouseID uuid.UUID) (*WarehouseUtilizationResponse, error) {
+	warehouse, err := s.warehouseRepo.FindByIDForTenant(ctx, tenantID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if s.loadReader == nil {
+		return nil, shared.NewDomainError("UTILIZATION_UNAVAILABLE", "Warehouse utilization is not available")
+	}
+
+	used, err := s.loadReader.SumLoadByWarehouse(ctx, tenantID, warehouseID, warehouse.CapacityUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	utilization := warehouse.Utilization(used)
+	return &WarehouseUtilizationResponse{
+		WarehouseID:  warehouse.ID,
+		Code:         warehouse.Code,
+		CapacityUnit: string(utilization.Unit),
+		Used:         utilization.Used,
+		Total:        utilization.Total,
+		Percent:      utilization.Percent,
+	}, nil
+}
+
 // CountByStatus returns warehouse counts by status for a tenant
 func (s *WarehouseService) CountByStatus(ctx context.Context, tenantID uuid.UUID) (map[string]int64, error) {
 	counts := make(map[string]int64)
@@ -548,3 +548,58 @@ func TestWarehouseService_GetByID_NotFound(t *testing.T) {
 	assert.ErrorIs(t, err, shared.ErrNotFound)
 	mockWarehouseRepo.AssertExpectations(t)
 }
+
+// =============================================================================
+// WarehouseService Utilization Tests
+// =============================================================================
+
+// MockWarehouseLoadReader is a mock implementation of WarehouseLoadReader
+type MockWarehouseLoadReader struct {
+	mock.Mock
+}
+
+func (m *MockWarehouseLoadReader) SumLoadByWarehouse(ctx context.Context, tenantID, warehouseID uuid.UUID, unit partner.CapacityUnit) (decimal.Decimal, error) {
+	args := m.Called(ctx, tenantID, warehouseID, unit)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func TestWarehouseService_GetUtilization(t *testing.T) {
+	ctx := context.Background()
+	tenantID := newWarehouseTestTenantID()
+	warehouseID := newTestWarehouseID()
+
+	t.Run("measures the load in the capacity unit", func(t *testing.T) {
+		mockWarehouseRepo := new(MockWarehouseRepository)
+		mockLoadReader := new(MockWarehouseLoadReader)
+		service := NewWarehouseService(mockWarehouseRepo, new(MockInventoryItemRepository))
+		service.SetLoadReader(mockLoadReader)
+
+		warehouse := createTestWarehouseEntity(tenantID)
+		_ = warehouse.SetCapacity(800)
+		_ = warehouse.SetCapacityUnit(partner.CapacityUnitKilogram)
+		mockWarehouseRepo.On("FindByIDForTenant", ctx, tenantID, warehouseID).Return(warehouse, nil)
+		mockLoadReader.On("SumLoadByWarehouse", ctx, tenantID, warehouseID, partner.CapacityUnitKilogram).
+			Return(decimal.NewFromFloat(612.5), nil)
+
+		result, err := service.GetUtilization(ctx, tenantID, warehouseID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "kg", result.CapacityUnit)
+		assert.True(t, result.Used.Equal(decimal.NewFromFloat(612.5)))
+		assert.True(t, result.Total.Equal(decimal.NewFromInt(800)))
+		assert.True(t, result.Percent.Equal(decimal.NewFromFloat(76.56)), "percent = %s", result.Percent)
+		mockLoadReader.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockWarehouseRepo := new(MockWarehouseRepository)
+		service := NewWarehouseService(mockWarehouseRepo, new(MockInventoryItemRepository))
+		service.SetLoadReader(new(MockWarehouseLoadReader))
+		mockWarehouseRepo.On("FindByIDForTenant", ctx, tenantID, warehouseID).Return(nil, shared.ErrNotFound)
+
+		result, err := service.GetUtilization(ctx, tenantID, warehouseID)
+
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		assert.Nil(t, result)
+	})
+}
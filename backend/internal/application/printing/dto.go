@@ -92,6 +92,7 @@ type PrintJobResponse struct {
 	Copies         int        `json:"copies"`
 	PdfURL         string     `json:"pdf_url,omitempty"`
 	ErrorMessage   string     `json:"error_message,omitempty"`
+	ErrorDetail    string     `json:"error_detail,omitempty"` // Renderer diagnostic output of a failed render
 	PrintedAt      *time.Time `json:"printed_at,omitempty"`
 	PrintedBy      string     `json:"printed_by,omitempty"`
 	CreatedAt      time.Time  `json:"created_at"`
@@ -0,0 +1,184 @@
+package printing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/erp/backend/internal/domain/printing"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Default print job queue sizing
+const (
+	DefaultPrintJobWorkers   = 2
+	DefaultPrintJobQueueSize = 100
+)
+
+// ErrPrintQueueFull is returned when a print job cannot be queued because the queue is full
+var ErrPrintQueueFull = errors.New("print job queue is full")
+
+// PrintJobQueueConfig holds the configuration of a PrintJobQueue
+type PrintJobQueueConfig struct {
+	// Workers is the number of jobs rendered concurrently
+	Workers int
+	// Size is the number of jobs that can wait for a worker
+	Size   int
+	Logger *zap.Logger
+}
+
+// queuedPrintJob is a print job waiting for a worker
+type queuedPrintJob struct {
+	// ctx is the context the job is rendered with: the enqueuing request's context
+	// without its cancellation, or the context given to Start for recovered jobs
+	ctx      context.Context
+	tenantID uuid.UUID
+	jobID    uuid.UUID
+	// data is the document data given with the request; nil loads it from the data providers
+	data any
+}
+
+// PrintJobQueue renders print jobs in the background, so that large documents
+// don't hold the request open until the PDF is ready. Clients enqueue a job and
+// poll it with PrintService.GetJob until it is COMPLETED or FAILED.
+//
+// Queued jobs are kept in memory. Jobs still PENDING when the server stops are
+// queued again by Start, with their document data reloaded from the data providers.
+type PrintJobQueue struct {
+	service *PrintService
+	jobs    chan queuedPrintJob
+	workers int
+	logger  *zap.Logger
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// NewPrintJobQueue creates a new PrintJobQueue rendering jobs with the given PrintService
+func NewPrintJobQueue(service *PrintService, cfg PrintJobQueueConfig) *PrintJobQueue {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = DefaultPrintJobWorkers
+	}
+	size := cfg.Size
+	if size <= 0 {
+		size = DefaultPrintJobQueueSize
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &PrintJobQueue{
+		service: service,
+		jobs:    make(chan queuedPrintJob, size),
+		workers: workers,
+		logger:  logger,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start starts the workers and queues the jobs left pending by a previous run.
+// An error means the pending jobs could not be recovered; the workers run regardless.
+func (q *PrintJobQueue) Start(ctx context.Context) error {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+
+	pending, err := q.service.jobRepo.FindPending(ctx, cap(q.jobs))
+	if err != nil {
+		return fmt.Errorf("failed to find pending print jobs: %w", err)
+	}
+	for _, job := range pending {
+		q.jobs <- queuedPrintJob{ctx: context.WithoutCancel(ctx), tenantID: job.TenantID, jobID: job.ID}
+	}
+
+	q.logger.Info("Print job queue started",
+		zap.Int("workers", q.workers),
+		zap.Int("size", cap(q.jobs)),
+		zap.Int("recovered_jobs", len(pending)),
+	)
+	return nil
+}
+
+// Stop stops the workers, waiting for the jobs being rendered to finish or ctx to be done.
+// Jobs still waiting in the queue stay PENDING and are picked up by the next Start.
+func (q *PrintJobQueue) Stop(ctx context.Context) error {
+	q.once.Do(func() { close(q.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Enqueue creates a pending print job and queues it for rendering, returning the job
+// without waiting for the PDF. Returns ErrPrintQueueFull if the queue has no room;
+// the job is then saved as failed.
+func (q *PrintJobQueue) Enqueue(ctx context.Context, tenantID, userID uuid.UUID, req GeneratePDFRequest) (*PrintJobResponse, error) {
+	job, _, err := q.service.createJob(ctx, tenantID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case q.jobs <- queuedPrintJob{ctx: context.WithoutCancel(ctx), tenantID: tenantID, jobID: job.ID, data: req.Data}:
+	default:
+		q.logger.Warn("print job queue is full", zap.String("jobId", job.ID.String()))
+		_ = job.Fail("Too many print jobs are waiting. Please try again later.")
+		_ = q.service.jobRepo.Save(ctx, job)
+		return nil, ErrPrintQueueFull
+	}
+
+	return toJobResponse(job), nil
+}
+
+// work renders queued jobs until the queue is stopped
+func (q *PrintJobQueue) work() {
+	defer q.wg.Done()
+	for {
+		select {
+		case <-q.stop:
+			return
+		case queued := <-q.jobs:
+			q.process(queued)
+		}
+	}
+}
+
+// process renders a queued job. Failures are recorded on the job, which the client polls.
+func (q *PrintJobQueue) process(queued queuedPrintJob) {
+	ctx := queued.ctx
+	logger := q.logger.With(zap.String("jobId", queued.jobID.String()))
+
+	job, err := q.service.jobRepo.FindByIDForTenant(ctx, queued.tenantID, queued.jobID)
+	if err != nil {
+		logger.Error("failed to load queued print job", zap.Error(err))
+		return
+	}
+	if job.Status != printing.JobStatusPending {
+		return
+	}
+
+	template := q.service.templateStore.GetByID(job.TemplateID.String())
+	if template == nil {
+		logger.Error("template of queued print job not found", zap.String("templateId", job.TemplateID.String()))
+		_ = job.Fail("Template not found")
+		_ = q.service.jobRepo.Save(ctx, job)
+		return
+	}
+
+	if err := q.service.renderJob(ctx, job, template, queued.data); err != nil {
+		logger.Warn("queued print job failed", zap.Error(err))
+	}
+}
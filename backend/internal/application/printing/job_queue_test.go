@@ -0,0 +1,207 @@
+package printing
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/printing"
+	"github.com/erp/backend/internal/domain/shared"
+	infra "github.com/erp/backend/internal/infrastructure/printing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPrintJobRepository keeps print jobs in memory
+type memoryPrintJobRepository struct {
+	printing.PrintJobRepository
+	mu   sync.Mutex
+	jobs map[uuid.UUID]printing.PrintJob
+}
+
+func (r *memoryPrintJobRepository) Save(ctx context.Context, job *printing.PrintJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *memoryPrintJobRepository) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*printing.PrintJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok || job.TenantID != tenantID {
+		return nil, shared.ErrNotFound
+	}
+	return &job, nil
+}
+
+func (r *memoryPrintJobRepository) FindPending(ctx context.Context, limit int) ([]printing.PrintJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var pending []printing.PrintJob
+	for _, job := range r.jobs {
+		if job.Status == printing.JobStatusPending && len(pending) < limit {
+			pending = append(pending, job)
+		}
+	}
+	return pending, nil
+}
+
+// fakePDFRenderer returns a fixed PDF, or fails with err, once release is closed
+type fakePDFRenderer struct {
+	err     error
+	release chan struct{}
+}
+
+func (r *fakePDFRenderer) Render(ctx context.Context, req *infra.RenderRequest) (*infra.RenderResult, error) {
+	if r.release != nil {
+		<-r.release
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &infra.RenderResult{PDFData: []byte("%PDF-1.4 test"), PageCount: 1}, nil
+}
+
+func (r *fakePDFRenderer) Close() error {
+	return nil
+}
+
+type printJobQueueFixture struct {
+	queue    *PrintJobQueue
+	service  *PrintService
+	jobs     *memoryPrintJobRepository
+	renderer *fakePDFRenderer
+	tenantID uuid.UUID
+	userID   uuid.UUID
+}
+
+func newPrintJobQueueFixture(t *testing.T, cfg PrintJobQueueConfig) *printJobQueueFixture {
+	t.Helper()
+
+	templateStore, err := infra.NewTemplateStore(nil)
+	require.NoError(t, err)
+	storage, err := infra.NewFileSystemStorage(&infra.FileSystemStorageConfig{BasePath: t.TempDir()})
+	require.NoError(t, err)
+
+	f := &printJobQueueFixture{
+		jobs:     &memoryPrintJobRepository{jobs: make(map[uuid.UUID]printing.PrintJob)},
+		renderer: &fakePDFRenderer{release: make(chan struct{})},
+		tenantID: uuid.New(),
+		userID:   uuid.New(),
+	}
+	f.service = NewPrintService(templateStore, f.jobs, infra.NewTemplateEngine(), f.renderer, storage, nil, nil)
+	f.queue = NewPrintJobQueue(f.service, cfg)
+	return f
+}
+
+func (f *printJobQueueFixture) start(t *testing.T) {
+	t.Helper()
+	require.NoError(t, f.queue.Start(context.Background()))
+	t.Cleanup(func() { _ = f.queue.Stop(context.Background()) })
+}
+
+func (f *printJobQueueFixture) enqueue(t *testing.T) *PrintJobResponse {
+	t.Helper()
+	job, err := f.queue.Enqueue(context.Background(), f.tenantID, f.userID, GeneratePDFRequest{
+		DocumentType:   string(printing.DocTypeSalesOrder),
+		DocumentID:     uuid.New(),
+		DocumentNumber: "SO-2024-001",
+		Data:           map[string]any{},
+	})
+	require.NoError(t, err)
+	return job
+}
+
+// waitForStatus polls the job until it reaches the given status
+func (f *printJobQueueFixture) waitForStatus(t *testing.T, jobID string, status printing.JobStatus) *PrintJobResponse {
+	t.Helper()
+	var job *PrintJobResponse
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = f.service.GetJob(context.Background(), f.tenantID, uuid.MustParse(jobID))
+		require.NoError(t, err)
+		return job.Status == string(status)
+	}, 2*time.Second, 5*time.Millisecond)
+	return job
+}
+
+func TestPrintJobQueue_Enqueue(t *testing.T) {
+	t.Run("renders a queued job in the background", func(t *testing.T) {
+		f := newPrintJobQueueFixture(t, PrintJobQueueConfig{})
+		f.start(t)
+
+		job := f.enqueue(t)
+
+		assert.Equal(t, string(printing.JobStatusPending), job.Status)
+		assert.Empty(t, job.PdfURL)
+		f.waitForStatus(t, job.ID, printing.JobStatusRendering)
+
+		close(f.renderer.release)
+		done := f.waitForStatus(t, job.ID, printing.JobStatusCompleted)
+
+		assert.NotEmpty(t, done.PdfURL)
+		assert.Contains(t, done.PdfURL, job.ID+".pdf")
+		assert.Empty(t, done.ErrorMessage)
+	})
+
+	t.Run("records the renderer output of a failed render", func(t *testing.T) {
+		f := newPrintJobQueueFixture(t, PrintJobQueueConfig{})
+		renderErr := infra.NewRenderError(infra.ErrCodeRenderTimeout, "PDF rendering timed out after 30s", context.DeadlineExceeded)
+		renderErr.Output = "network error: Failed to load resource (https://cdn.example.com/logo.png)"
+		f.renderer.err = renderErr
+		close(f.renderer.release)
+		f.start(t)
+
+		job := f.enqueue(t)
+		failed := f.waitForStatus(t, job.ID, printing.JobStatusFailed)
+
+		assert.Equal(t, "PDF generation failed. Please try again later.", failed.ErrorMessage)
+		assert.Contains(t, failed.ErrorDetail, "timed out after 30s")
+		assert.Contains(t, failed.ErrorDetail, "https://cdn.example.com/logo.png")
+		assert.Empty(t, failed.PdfURL)
+	})
+
+	t.Run("rejects jobs when the queue is full", func(t *testing.T) {
+		f := newPrintJobQueueFixture(t, PrintJobQueueConfig{Size: 1})
+		f.enqueue(t)
+
+		_, err := f.queue.Enqueue(context.Background(), f.tenantID, f.userID, GeneratePDFRequest{
+			DocumentType:   string(printing.DocTypeSalesOrder),
+			DocumentID:     uuid.New(),
+			DocumentNumber: "SO-2024-002",
+			Data:           map[string]any{},
+		})
+
+		assert.ErrorIs(t, err, ErrPrintQueueFull)
+	})
+
+	t.Run("rejects invalid requests without queueing", func(t *testing.T) {
+		f := newPrintJobQueueFixture(t, PrintJobQueueConfig{})
+
+		_, err := f.queue.Enqueue(context.Background(), f.tenantID, f.userID, GeneratePDFRequest{
+			DocumentType:   "UNKNOWN",
+			DocumentID:     uuid.New(),
+			DocumentNumber: "SO-2024-003",
+		})
+
+		assert.Error(t, err)
+		assert.Empty(t, f.jobs.jobs)
+	})
+}
+
+func TestPrintJobQueue_Start(t *testing.T) {
+	f := newPrintJobQueueFixture(t, PrintJobQueueConfig{})
+	close(f.renderer.release)
+	// A job queued before a restart is still pending
+	job := f.enqueue(t)
+	f.queue = NewPrintJobQueue(f.service, PrintJobQueueConfig{})
+
+	f.start(t)
+
+	done := f.waitForStatus(t, job.ID, printing.JobStatusCompleted)
+	assert.NotEmpty(t, done.PdfURL)
+}
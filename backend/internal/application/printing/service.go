@@ -150,10 +150,24 @@ func (s *PrintService) PreviewDocument(ctx context.Context, tenantID uuid.UUID,
 
 // GeneratePDF generates a PDF for a document and creates a print job
 func (s *PrintService) GeneratePDF(ctx context.Context, tenantID, userID uuid.UUID, req GeneratePDFRequest) (*PrintJobResponse, error) {
+	job, template, err := s.createJob(ctx, tenantID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.renderJob(ctx, job, template, req.Data); err != nil {
+		return nil, err
+	}
+
+	return toJobResponse(job), nil
+}
+
+// createJob validates a PDF generation request and saves a pending print job for it
+func (s *PrintService) createJob(ctx context.Context, tenantID, userID uuid.UUID, req GeneratePDFRequest) (*printing.PrintJob, *infra.StaticTemplate, error) {
 	// Validate document type
 	docType := printing.DocType(req.DocumentType)
 	if !docType.IsValid() {
-		return nil, shared.NewDomainError("INVALID_INPUT", "Invalid document type")
+		return nil, nil, shared.NewDomainError("INVALID_INPUT", "Invalid document type")
 	}
 
 	// Get template
@@ -161,12 +175,12 @@ func (s *PrintService) GeneratePDF(ctx context.Context, tenantID, userID uuid.UU
 	if req.TemplateID != nil {
 		template = s.templateStore.GetByID(req.TemplateID.String())
 		if template == nil {
-			return nil, shared.NewDomainError("NOT_FOUND", "Template not found")
+			return nil, nil, shared.NewDomainError("NOT_FOUND", "Template not found")
 		}
 	} else {
 		template = s.templateStore.GetDefault(docType)
 		if template == nil {
-			return nil, shared.NewDomainError("NOT_FOUND", "No default template found for this document type")
+			return nil, nil, shared.NewDomainError("NOT_FOUND", "No default template found for this document type")
 		}
 	}
 
@@ -181,40 +195,45 @@ func (s *PrintService) GeneratePDF(ctx context.Context, tenantID, userID uuid.UU
 		userID,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if req.Copies != nil && *req.Copies > 1 {
 		if err := job.SetCopies(*req.Copies); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	// Save job in pending state
 	if err := s.jobRepo.Save(ctx, job); err != nil {
-		return nil, fmt.Errorf("failed to save print job: %w", err)
+		return nil, nil, fmt.Errorf("failed to save print job: %w", err)
 	}
 
+	return job, template, nil
+}
+
+// renderJob renders a pending print job to PDF and stores it, completing the job.
+// If any step fails the job is saved as failed and the error is returned.
+func (s *PrintService) renderJob(ctx context.Context, job *printing.PrintJob, template *infra.StaticTemplate, data any) error {
 	// Start rendering
 	if err := job.StartRendering(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := s.jobRepo.Save(ctx, job); err != nil {
-		return nil, fmt.Errorf("failed to update job status: %w", err)
+		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
 	// Load data from provider if not provided
-	data := req.Data
-	if data == nil && s.dataProviders != nil && req.DocumentID != uuid.Nil {
-		docData, err := s.dataProviders.LoadData(ctx, tenantID, docType, req.DocumentID)
+	if data == nil && s.dataProviders != nil && job.DocumentID != uuid.Nil {
+		docData, err := s.dataProviders.LoadData(ctx, job.TenantID, job.DocumentType, job.DocumentID)
 		if err != nil {
 			s.logger.Warn("failed to load document data from provider",
 				zap.Error(err),
-				zap.String("docType", string(docType)),
-				zap.String("documentID", req.DocumentID.String()))
+				zap.String("docType", string(job.DocumentType)),
+				zap.String("documentID", job.DocumentID.String()))
 			_ = job.Fail("Failed to load document data")
 			_ = s.jobRepo.Save(ctx, job)
-			return nil, fmt.Errorf("failed to load document data: %w", err)
+			return fmt.Errorf("failed to load document data: %w", err)
 		}
 		data = docData
 	}
@@ -228,7 +247,7 @@ func (s *PrintService) GeneratePDF(ctx context.Context, tenantID, userID uuid.UU
 		s.logger.Error("template rendering failed", zap.Error(err), zap.String("jobId", job.ID.String()))
 		_ = job.Fail("Template rendering failed. Please check template syntax.")
 		_ = s.jobRepo.Save(ctx, job)
-		return nil, fmt.Errorf("failed to render template: %w", err)
+		return fmt.Errorf("failed to render template: %w", err)
 	}
 
 	// Render PDF
@@ -237,18 +256,18 @@ func (s *PrintService) GeneratePDF(ctx context.Context, tenantID, userID uuid.UU
 		PaperSize:   template.PaperSize,
 		Orientation: template.Orientation,
 		Margins:     template.Margins,
-		Title:       fmt.Sprintf("%s - %s", docType.DisplayName(), req.DocumentNumber),
+		Title:       fmt.Sprintf("%s - %s", job.DocumentType.DisplayName(), job.DocumentNumber),
 	})
 	if err != nil {
 		s.logger.Error("PDF rendering failed", zap.Error(err), zap.String("jobId", job.ID.String()))
-		_ = job.Fail("PDF generation failed. Please try again later.")
+		_ = job.FailWithDetail("PDF generation failed. Please try again later.", renderFailureDetail(err))
 		_ = s.jobRepo.Save(ctx, job)
-		return nil, fmt.Errorf("failed to render PDF: %w", err)
+		return fmt.Errorf("failed to render PDF: %w", err)
 	}
 
 	// Store PDF
 	storeResult, err := s.pdfStorage.Store(ctx, &infra.StoreRequest{
-		TenantID: tenantID,
+		TenantID: job.TenantID,
 		JobID:    job.ID,
 		PDFData:  pdfResult.PDFData,
 	})
@@ -256,23 +275,23 @@ func (s *PrintService) GeneratePDF(ctx context.Context, tenantID, userID uuid.UU
 		s.logger.Error("PDF storage failed", zap.Error(err), zap.String("jobId", job.ID.String()))
 		_ = job.Fail("Failed to save PDF file. Please try again later.")
 		_ = s.jobRepo.Save(ctx, job)
-		return nil, fmt.Errorf("failed to store PDF: %w", err)
+		return fmt.Errorf("failed to store PDF: %w", err)
 	}
 
 	// Complete the job
 	if err := job.Complete(storeResult.URL); err != nil {
-		return nil, err
+		return err
 	}
 	if err := s.jobRepo.Save(ctx, job); err != nil {
-		return nil, fmt.Errorf("failed to update job status: %w", err)
+		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
 	s.logger.Info("PDF generated",
 		zap.String("jobId", job.ID.String()),
-		zap.String("docType", string(docType)),
+		zap.String("docType", string(job.DocumentType)),
 		zap.String("url", storeResult.URL))
 
-	return toJobResponse(job), nil
+	return nil
 }
 
 // =============================================================================
@@ -380,6 +399,16 @@ func (s *PrintService) GetPaperSizes() []PaperSizeResponse {
 // Helper Functions
 // =============================================================================
 
+// renderFailureDetail describes a failed PDF render for troubleshooting: the renderer
+// error followed by the rendering engine's diagnostic output
+func renderFailureDetail(err error) string {
+	detail := err.Error()
+	if output := infra.RenderOutput(err); output != "" {
+		detail += "\n" + output
+	}
+	return detail
+}
+
 func staticTemplateToResponse(t *infra.StaticTemplate) TemplateResponse {
 	return TemplateResponse{
 		ID:           t.ID,
@@ -415,6 +444,7 @@ func toJobResponse(j *printing.PrintJob) *PrintJobResponse {
 		Copies:         j.Copies,
 		PdfURL:         j.PdfURL,
 		ErrorMessage:   j.ErrorMessage,
+		ErrorDetail:    j.ErrorDetail,
 		CreatedAt:      j.CreatedAt,
 		UpdatedAt:      j.UpdatedAt,
 	}
@@ -41,6 +41,7 @@ type ReportCacheRepository interface {
 	// Metadata
 	UpdateCacheMetadata(ctx context.Context, tenantID uuid.UUID, reportType string, periodStart, periodEnd time.Time) error
 	InvalidateCache(ctx context.Context, tenantID uuid.UUID, reportType string) error
+	IsCacheValid(ctx context.Context, tenantID uuid.UUID, reportType string, periodStart, periodEnd time.Time) (bool, error)
 }
 
 // Cache models for storing pre-computed data
@@ -177,6 +178,7 @@ type ReportAggregationService struct {
 	inventoryRepo report.InventoryReportRepository
 	financeRepo   report.FinanceReportRepository
 	cacheRepo     ReportCacheRepository
+	salesFacts    *DailySalesFactService
 	logger        *zap.Logger
 }
 
@@ -197,6 +199,12 @@ func NewReportAggregationService(
 	}
 }
 
+// SetDailySalesFactService sets the daily sales fact service for the service
+// The daily trend job then rebuilds the facts of its period before reading the trend from them
+func (s *ReportAggregationService) SetDailySalesFactService(salesFacts *DailySalesFactService) {
+	s.salesFacts = salesFacts
+}
+
 // Execute implements scheduler.JobExecutor
 func (s *ReportAggregationService) Execute(ctx context.Context, job *scheduler.Job) error {
 	if job.TenantID == nil {
@@ -257,6 +265,9 @@ func (s *ReportAggregationService) computeSalesSummary(ctx context.Context, tena
 	if err := s.cacheRepo.SaveSalesSummaryCache(ctx, cache); err != nil {
 		return err
 	}
+	if err := s.markCacheValid(ctx, tenantID, scheduler.ReportTypeSalesSummary, periodStart, periodEnd); err != nil {
+		return err
+	}
 
 	s.logger.Info("Sales summary computed and cached",
 		zap.String("tenant_id", tenantID.String()),
@@ -269,6 +280,12 @@ func (s *ReportAggregationService) computeSalesSummary(ctx context.Context, tena
 
 // computeSalesDailyTrend computes and caches daily sales trend
 func (s *ReportAggregationService) computeSalesDailyTrend(ctx context.Context, tenantID uuid.UUID, periodStart, periodEnd time.Time) error {
+	if s.salesFacts != nil {
+		if _, err := s.salesFacts.RefreshDays(ctx, tenantID, periodStart, periodEnd); err != nil {
+			return err
+		}
+	}
+
 	filter := report.SalesReportFilter{
 		TenantID:  tenantID,
 		StartDate: periodStart,
@@ -300,6 +317,9 @@ func (s *ReportAggregationService) computeSalesDailyTrend(ctx context.Context, t
 	if err := s.cacheRepo.SaveSalesDailyCache(ctx, caches); err != nil {
 		return err
 	}
+	if err := s.markCacheValid(ctx, tenantID, scheduler.ReportTypeSalesDailyTrend, periodStart, periodEnd); err != nil {
+		return err
+	}
 
 	s.logger.Info("Daily sales trend computed and cached",
 		zap.String("tenant_id", tenantID.String()),
@@ -342,6 +362,9 @@ func (s *ReportAggregationService) computeInventorySummary(ctx context.Context,
 	if err := s.cacheRepo.SaveInventorySummaryCache(ctx, cache); err != nil {
 		return err
 	}
+	if err := s.markCacheValid(ctx, tenantID, scheduler.ReportTypeInventorySummary, snapshotDate, snapshotDate); err != nil {
+		return err
+	}
 
 	s.logger.Info("Inventory summary computed and cached",
 		zap.String("tenant_id", tenantID.String()),
@@ -389,6 +412,9 @@ func (s *ReportAggregationService) computePnlMonthly(ctx context.Context, tenant
 	if err := s.cacheRepo.SavePnlMonthlyCache(ctx, cache); err != nil {
 		return err
 	}
+	if err := s.markCacheValid(ctx, tenantID, scheduler.ReportTypeProfitLossMonthly, periodStart, periodEnd); err != nil {
+		return err
+	}
 
 	s.logger.Info("Monthly P&L computed and cached",
 		zap.String("tenant_id", tenantID.String()),
@@ -439,6 +465,9 @@ func (s *ReportAggregationService) computeProductRanking(ctx context.Context, te
 	if err := s.cacheRepo.SaveProductRankingCache(ctx, caches); err != nil {
 		return err
 	}
+	if err := s.markCacheValid(ctx, tenantID, scheduler.ReportTypeProductRanking, periodStart, periodEnd); err != nil {
+		return err
+	}
 
 	s.logger.Info("Product ranking computed and cached",
 		zap.String("tenant_id", tenantID.String()),
@@ -486,6 +515,9 @@ func (s *ReportAggregationService) computeCustomerRanking(ctx context.Context, t
 	if err := s.cacheRepo.SaveCustomerRankingCache(ctx, caches); err != nil {
 		return err
 	}
+	if err := s.markCacheValid(ctx, tenantID, scheduler.ReportTypeCustomerRanking, periodStart, periodEnd); err != nil {
+		return err
+	}
 
 	s.logger.Info("Customer ranking computed and cached",
 		zap.String("tenant_id", tenantID.String()),
@@ -522,6 +554,38 @@ func (s *ReportAggregationService) RefreshAllReports(ctx context.Context, tenant
 	return nil
 }
 
+// markCacheValid records that a report segment was just computed
+func (s *ReportAggregationService) markCacheValid(ctx context.Context, tenantID uuid.UUID, reportType scheduler.ReportType, periodStart, periodEnd time.Time) error {
+	return s.cacheRepo.UpdateCacheMetadata(ctx, tenantID, string(reportType), periodStart, periodEnd)
+}
+
+// InvalidateReports marks a tenant's cached segments of the given report types as dirty,
+// so the next read recomputes them instead of waiting for the nightly refresh.
+// Other tenants and other report types keep their cached data.
+func (s *ReportAggregationService) InvalidateReports(ctx context.Context, tenantID uuid.UUID, reportTypes ...scheduler.ReportType) error {
+	for _, reportType := range reportTypes {
+		if err := s.cacheRepo.InvalidateCache(ctx, tenantID, string(reportType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSalesSummary returns the cached sales summary of a period,
+// recomputing it first when the segment is missing or marked dirty
+func (s *ReportAggregationService) GetSalesSummary(ctx context.Context, tenantID uuid.UUID, periodStart, periodEnd time.Time) (*SalesSummaryCacheModel, error) {
+	valid, err := s.cacheRepo.IsCacheValid(ctx, tenantID, string(scheduler.ReportTypeSalesSummary), periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		if err := s.computeSalesSummary(ctx, tenantID, periodStart, periodEnd); err != nil {
+			return nil, err
+		}
+	}
+	return s.cacheRepo.GetSalesSummaryCache(ctx, tenantID, periodStart, periodEnd)
+}
+
 // GormReportCacheRepository is the GORM implementation of ReportCacheRepository
 type GormReportCacheRepository struct {
 	db *gorm.DB
@@ -719,3 +783,14 @@ func (r *GormReportCacheRepository) InvalidateCache(ctx context.Context, tenantI
 		Where("tenant_id = ? AND report_type = ?", tenantID, reportType).
 		Update("is_valid", false).Error
 }
+
+// IsCacheValid reports whether a report segment has been computed and not invalidated since
+func (r *GormReportCacheRepository) IsCacheValid(ctx context.Context, tenantID uuid.UUID, reportType string, periodStart, periodEnd time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("report_cache_metadata").
+		Where("tenant_id = ? AND report_type = ? AND period_start = ? AND period_end = ? AND is_valid = ?",
+			tenantID, reportType, periodStart, periodEnd, true).
+		Count(&count).Error
+	return count > 0, err
+}
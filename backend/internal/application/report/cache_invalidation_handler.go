@@ -0,0 +1,100 @@
+package report
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/erp/backend/internal/infrastructure/scheduler"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Finance events raised when reconciliation allocates a voucher to a receivable or payable
+const (
+	eventTypeReceiptVoucherAllocated = "ReceiptVoucherAllocated"
+	eventTypePaymentVoucherAllocated = "PaymentVoucherAllocated"
+)
+
+// reportTypesByEvent lists the cached reports each source event makes stale
+var reportTypesByEvent = map[string][]scheduler.ReportType{
+	trade.EventTypeSalesOrderCompleted: {
+		scheduler.ReportTypeSalesSummary,
+		scheduler.ReportTypeSalesDailyTrend,
+		scheduler.ReportTypeProductRanking,
+		scheduler.ReportTypeCustomerRanking,
+		scheduler.ReportTypeProfitLossMonthly,
+	},
+	inventory.EventTypeStockAdjusted: {
+		scheduler.ReportTypeInventorySummary,
+	},
+	eventTypeReceiptVoucherAllocated: {
+		scheduler.ReportTypeProfitLossMonthly,
+	},
+	eventTypePaymentVoucherAllocated: {
+		scheduler.ReportTypeProfitLossMonthly,
+	},
+}
+
+// ReportCacheInvalidator marks cached report segments as dirty.
+// It is implemented by ReportAggregationService.
+type ReportCacheInvalidator interface {
+	InvalidateReports(ctx context.Context, tenantID uuid.UUID, reportTypes ...scheduler.ReportType) error
+}
+
+// CacheInvalidationHandler marks cached reports dirty when their source data changes,
+// so they are recomputed on the next read instead of staying stale until the nightly refresh.
+// Only the reports of the tenant the event belongs to are invalidated.
+type CacheInvalidationHandler struct {
+	cache  ReportCacheInvalidator
+	logger *zap.Logger
+}
+
+// NewCacheInvalidationHandler creates a new handler invalidating cached reports on source events
+func NewCacheInvalidationHandler(cache ReportCacheInvalidator, logger *zap.Logger) *CacheInvalidationHandler {
+	return &CacheInvalidationHandler{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *CacheInvalidationHandler) EventTypes() []string {
+	return []string{
+		trade.EventTypeSalesOrderCompleted,
+		inventory.EventTypeStockAdjusted,
+		eventTypeReceiptVoucherAllocated,
+		eventTypePaymentVoucherAllocated,
+	}
+}
+
+// Handle invalidates the tenant's cached reports affected by the event
+func (h *CacheInvalidationHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	reportTypes, ok := reportTypesByEvent[event.EventType()]
+	if !ok {
+		h.logger.Warn("unexpected event type for report cache invalidation",
+			zap.String("event_type", event.EventType()))
+		return nil
+	}
+
+	tenantID := event.TenantID()
+	if err := h.cache.InvalidateReports(ctx, tenantID, reportTypes...); err != nil {
+		h.logger.Error("Failed to invalidate cached reports",
+			zap.String("tenant_id", tenantID.String()),
+			zap.String("event_type", event.EventType()),
+			zap.Error(err))
+		return err
+	}
+	h.logger.Debug("Invalidated cached reports",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("event_type", event.EventType()),
+		zap.Int("report_types", len(reportTypes)))
+	return nil
+}
+
+// Ensure CacheInvalidationHandler implements shared.EventHandler
+var _ shared.EventHandler = (*CacheInvalidationHandler)(nil)
+
+// Ensure ReportAggregationService implements ReportCacheInvalidator
+var _ ReportCacheInvalidator = (*ReportAggregationService)(nil)
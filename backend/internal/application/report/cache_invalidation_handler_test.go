@@ -0,0 +1,133 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/erp/backend/internal/infrastructure/scheduler"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubReportCacheRepository keeps sales summaries and cache metadata in memory; other caches are not used
+type stubReportCacheRepository struct {
+	ReportCacheRepository
+	summaries map[string]*SalesSummaryCacheModel
+	valid     map[string]bool
+}
+
+func newStubReportCacheRepository() *stubReportCacheRepository {
+	return &stubReportCacheRepository{
+		summaries: map[string]*SalesSummaryCacheModel{},
+		valid:     map[string]bool{},
+	}
+}
+
+func cacheKey(tenantID uuid.UUID, reportType string, periodStart, periodEnd time.Time) string {
+	return fmt.Sprintf("%s/%s/%d/%d", tenantID, reportType, periodStart.Unix(), periodEnd.Unix())
+}
+
+func (r *stubReportCacheRepository) SaveSalesSummaryCache(_ context.Context, cache *SalesSummaryCacheModel) error {
+	r.summaries[cacheKey(cache.TenantID, "", cache.PeriodStart, cache.PeriodEnd)] = cache
+	return nil
+}
+
+func (r *stubReportCacheRepository) GetSalesSummaryCache(_ context.Context, tenantID uuid.UUID, periodStart, periodEnd time.Time) (*SalesSummaryCacheModel, error) {
+	return r.summaries[cacheKey(tenantID, "", periodStart, periodEnd)], nil
+}
+
+func (r *stubReportCacheRepository) UpdateCacheMetadata(_ context.Context, tenantID uuid.UUID, reportType string, periodStart, periodEnd time.Time) error {
+	r.valid[cacheKey(tenantID, reportType, periodStart, periodEnd)] = true
+	return nil
+}
+
+func (r *stubReportCacheRepository) InvalidateCache(_ context.Context, tenantID uuid.UUID, reportType string) error {
+	prefix := tenantID.String() + "/" + reportType + "/"
+	for key := range r.valid {
+		if strings.HasPrefix(key, prefix) {
+			r.valid[key] = false
+		}
+	}
+	return nil
+}
+
+func (r *stubReportCacheRepository) IsCacheValid(_ context.Context, tenantID uuid.UUID, reportType string, periodStart, periodEnd time.Time) (bool, error) {
+	return r.valid[cacheKey(tenantID, reportType, periodStart, periodEnd)], nil
+}
+
+// countingSalesReportRepository serves a sales summary per tenant and counts the queries
+type countingSalesReportRepository struct {
+	report.SalesReportRepository
+	orders  map[uuid.UUID]int64
+	queries int
+}
+
+func (r *countingSalesReportRepository) GetSalesSummary(filter report.SalesReportFilter) (*report.SalesSummary, error) {
+	r.queries++
+	return &report.SalesSummary{TotalOrders: r.orders[filter.TenantID], TotalSalesAmount: decimal.NewFromInt(r.orders[filter.TenantID] * 100)}, nil
+}
+
+func TestCacheInvalidationHandler_SalesOrderCompleted(t *testing.T) {
+	ctx := context.Background()
+	tenantA, tenantB := uuid.New(), uuid.New()
+	periodStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	salesSummary := string(scheduler.ReportTypeSalesSummary)
+
+	salesRepo := &countingSalesReportRepository{orders: map[uuid.UUID]int64{tenantA: 3, tenantB: 5}}
+	cacheRepo := newStubReportCacheRepository()
+	service := NewReportAggregationService(salesRepo, nil, nil, cacheRepo, zap.NewNop())
+	handler := NewCacheInvalidationHandler(service, zap.NewNop())
+
+	for _, tenantID := range []uuid.UUID{tenantA, tenantB} {
+		require.NoError(t, service.RefreshReport(ctx, tenantID, scheduler.ReportTypeSalesSummary, periodStart, periodEnd))
+	}
+	// An inventory summary of tenant A that a sales order does not touch
+	require.NoError(t, cacheRepo.UpdateCacheMetadata(ctx, tenantA, string(scheduler.ReportTypeInventorySummary), periodEnd, periodEnd))
+
+	order := &trade.SalesOrder{}
+	order.ID = uuid.New()
+	order.TenantID = tenantA
+	require.NoError(t, handler.Handle(ctx, trade.NewSalesOrderCompletedEvent(order)))
+
+	valid, err := cacheRepo.IsCacheValid(ctx, tenantA, salesSummary, periodStart, periodEnd)
+	require.NoError(t, err)
+	assert.False(t, valid, "the completed order's tenant sales summary should be dirty")
+
+	valid, err = cacheRepo.IsCacheValid(ctx, tenantB, salesSummary, periodStart, periodEnd)
+	require.NoError(t, err)
+	assert.True(t, valid, "another tenant's sales summary should stay cached")
+
+	valid, err = cacheRepo.IsCacheValid(ctx, tenantA, string(scheduler.ReportTypeInventorySummary), periodEnd, periodEnd)
+	require.NoError(t, err)
+	assert.True(t, valid, "unrelated report types should stay cached")
+
+	// The next read of tenant A recomputes; tenant B is served from the cache
+	salesRepo.orders[tenantA] = 4
+	queries := salesRepo.queries
+
+	summary, err := service.GetSalesSummary(ctx, tenantA, periodStart, periodEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), summary.TotalOrders)
+	assert.Equal(t, queries+1, salesRepo.queries)
+
+	summary, err = service.GetSalesSummary(ctx, tenantB, periodStart, periodEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), summary.TotalOrders)
+	assert.Equal(t, queries+1, salesRepo.queries)
+}
+
+func TestCacheInvalidationHandler_EventTypes(t *testing.T) {
+	handler := NewCacheInvalidationHandler(nil, zap.NewNop())
+	for _, eventType := range handler.EventTypes() {
+		assert.NotEmpty(t, reportTypesByEvent[eventType], eventType)
+	}
+}
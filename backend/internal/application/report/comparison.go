@@ -0,0 +1,195 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ComparePeriod selects the period a report is compared against
+type ComparePeriod string
+
+const (
+	// ComparePreviousPeriod compares with the period of the same length just before.
+	// A range of whole calendar months is compared with as many whole months before it,
+	// so a month is compared with the previous month whatever their lengths.
+	ComparePreviousPeriod ComparePeriod = "previous_period"
+	// ComparePreviousYear compares with the same range one year earlier
+	ComparePreviousYear ComparePeriod = "previous_year"
+)
+
+// ParseComparePeriod parses a compare_to value
+func ParseComparePeriod(value string) (ComparePeriod, error) {
+	switch ComparePeriod(value) {
+	case ComparePreviousPeriod, ComparePreviousYear:
+		return ComparePeriod(value), nil
+	}
+	return "", fmt.Errorf("compare_to: must be %s or %s", ComparePreviousPeriod, ComparePreviousYear)
+}
+
+// Range returns the comparison period of the range from start to end.
+// end is the last instant of the range, as report filters use the end of the last day.
+func (p ComparePeriod) Range(start, end time.Time) (time.Time, time.Time) {
+	months, wholeMonths := wholeMonthsBetween(start, end)
+	if p == ComparePreviousYear {
+		months = 12
+	}
+
+	if wholeMonths {
+		compStart := start.AddDate(0, -months, 0)
+		// Day 0 of a month is the last day of the month before
+		compEnd := time.Date(end.Year(), end.Month()-time.Month(months)+1, 0,
+			end.Hour(), end.Minute(), end.Second(), end.Nanosecond(), end.Location())
+		return compStart, compEnd
+	}
+
+	if p == ComparePreviousYear {
+		return start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0)
+	}
+	days := periodDays(start, end)
+	return start.AddDate(0, 0, -days), end.AddDate(0, 0, -days)
+}
+
+// wholeMonthsBetween reports whether a range starts on the first day of a month and ends on
+// the last day of a month, and how many months it covers
+func wholeMonthsBetween(start, end time.Time) (int, bool) {
+	startsMonth := start.Day() == 1 && start.Hour() == 0 && start.Minute() == 0 && start.Second() == 0
+	endsMonth := end.AddDate(0, 0, 1).Day() == 1
+	if !startsMonth || !endsMonth || end.Before(start) {
+		return 0, false
+	}
+	return (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1, true
+}
+
+// MetricDelta is the change of a metric from the comparison period to the current period
+type MetricDelta struct {
+	Absolute float64 `json:"absolute"`
+	// Percent is the change relative to the comparison value; null when the comparison value is zero
+	Percent *float64 `json:"percent"`
+}
+
+// newMetricDelta computes the change of a metric, with the percent rounded to two decimals.
+// The percent is relative to the magnitude of the comparison value, so a loss shrinking
+// from -100 to -50 is a +50% change.
+func newMetricDelta(current, comparison decimal.Decimal) MetricDelta {
+	diff := current.Sub(comparison)
+	delta := MetricDelta{Absolute: toFloat64(diff)}
+	if !comparison.IsZero() {
+		percent := toFloat64(diff.Div(comparison.Abs()).Mul(decimal.NewFromInt(100)).Round(2))
+		delta.Percent = &percent
+	}
+	return delta
+}
+
+// ===================== Sales Summary Comparison =====================
+
+// SalesSummaryDelta holds the change of each sales summary metric
+type SalesSummaryDelta struct {
+	TotalOrders      MetricDelta `json:"total_orders"`
+	TotalQuantity    MetricDelta `json:"total_quantity"`
+	TotalSalesAmount MetricDelta `json:"total_sales_amount"`
+	TotalCostAmount  MetricDelta `json:"total_cost_amount"`
+	TotalGrossProfit MetricDelta `json:"total_gross_profit"`
+	AvgOrderValue    MetricDelta `json:"avg_order_value"`
+	ProfitMargin     MetricDelta `json:"profit_margin"`
+}
+
+// SalesSummaryComparisonResponse represents a sales summary next to the summary of a comparison period
+type SalesSummaryComparisonResponse struct {
+	CompareTo  ComparePeriod        `json:"compare_to"`
+	Current    SalesSummaryResponse `json:"current"`
+	Comparison SalesSummaryResponse `json:"comparison"`
+	Delta      SalesSummaryDelta    `json:"delta"`
+}
+
+// CompareSalesSummary returns the sales summary of the period, of the comparison period and their difference
+func (s *ReportService) CompareSalesSummary(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter, compareTo ComparePeriod) (*SalesSummaryComparisonResponse, error) {
+	current, err := s.SalesSummary(ctx, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	compFilter := filter
+	compFilter.StartDate, compFilter.EndDate = compareTo.Range(filter.StartDate, filter.EndDate)
+	comparison, err := s.SalesSummary(ctx, tenantID, compFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SalesSummaryComparisonResponse{
+		CompareTo:  compareTo,
+		Current:    toSalesSummaryResponse(current),
+		Comparison: toSalesSummaryResponse(comparison),
+		Delta: SalesSummaryDelta{
+			TotalOrders:      newMetricDelta(decimal.NewFromInt(current.TotalOrders), decimal.NewFromInt(comparison.TotalOrders)),
+			TotalQuantity:    newMetricDelta(current.TotalQuantity, comparison.TotalQuantity),
+			TotalSalesAmount: newMetricDelta(current.TotalSalesAmount, comparison.TotalSalesAmount),
+			TotalCostAmount:  newMetricDelta(current.TotalCostAmount, comparison.TotalCostAmount),
+			TotalGrossProfit: newMetricDelta(current.TotalGrossProfit, comparison.TotalGrossProfit),
+			AvgOrderValue:    newMetricDelta(current.AvgOrderValue, comparison.AvgOrderValue),
+			ProfitMargin:     newMetricDelta(current.ProfitMargin, comparison.ProfitMargin),
+		},
+	}, nil
+}
+
+// ===================== Profit & Loss Comparison =====================
+
+// ProfitLossDelta holds the change of each P&L line
+type ProfitLossDelta struct {
+	SalesRevenue    MetricDelta `json:"sales_revenue"`
+	SalesReturns    MetricDelta `json:"sales_returns"`
+	NetSalesRevenue MetricDelta `json:"net_sales_revenue"`
+	COGS            MetricDelta `json:"cogs"`
+	GrossProfit     MetricDelta `json:"gross_profit"`
+	GrossMargin     MetricDelta `json:"gross_margin"`
+	OtherIncome     MetricDelta `json:"other_income"`
+	TotalIncome     MetricDelta `json:"total_income"`
+	Expenses        MetricDelta `json:"expenses"`
+	NetProfit       MetricDelta `json:"net_profit"`
+	NetMargin       MetricDelta `json:"net_margin"`
+}
+
+// ProfitLossComparisonResponse represents a P&L statement next to the statement of a comparison period
+type ProfitLossComparisonResponse struct {
+	CompareTo  ComparePeriod               `json:"compare_to"`
+	Current    ProfitLossStatementResponse `json:"current"`
+	Comparison ProfitLossStatementResponse `json:"comparison"`
+	Delta      ProfitLossDelta             `json:"delta"`
+}
+
+// CompareProfitLossStatement returns the P&L statement of the period, of the comparison period and their difference
+func (s *ReportService) CompareProfitLossStatement(ctx context.Context, tenantID uuid.UUID, filter FinanceReportFilter, compareTo ComparePeriod) (*ProfitLossComparisonResponse, error) {
+	current, err := s.ProfitLossStatement(ctx, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	compFilter := filter
+	compFilter.StartDate, compFilter.EndDate = compareTo.Range(filter.StartDate, filter.EndDate)
+	comparison, err := s.ProfitLossStatement(ctx, tenantID, compFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProfitLossComparisonResponse{
+		CompareTo:  compareTo,
+		Current:    toProfitLossStatementResponse(current),
+		Comparison: toProfitLossStatementResponse(comparison),
+		Delta: ProfitLossDelta{
+			SalesRevenue:    newMetricDelta(current.SalesRevenue, comparison.SalesRevenue),
+			SalesReturns:    newMetricDelta(current.SalesReturns, comparison.SalesReturns),
+			NetSalesRevenue: newMetricDelta(current.NetSalesRevenue, comparison.NetSalesRevenue),
+			COGS:            newMetricDelta(current.COGS, comparison.COGS),
+			GrossProfit:     newMetricDelta(current.GrossProfit, comparison.GrossProfit),
+			GrossMargin:     newMetricDelta(current.GrossMargin, comparison.GrossMargin),
+			OtherIncome:     newMetricDelta(current.OtherIncome, comparison.OtherIncome),
+			TotalIncome:     newMetricDelta(current.TotalIncome, comparison.TotalIncome),
+			Expenses:        newMetricDelta(current.Expenses, comparison.Expenses),
+			NetProfit:       newMetricDelta(current.NetProfit, comparison.NetProfit),
+			NetMargin:       newMetricDelta(current.NetMargin, comparison.NetMargin),
+		},
+	}, nil
+}
@@ -0,0 +1,158 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// periodSalesReportRepository serves the sales summary stored for each period start;
+// periods without one have no sales
+type periodSalesReportRepository struct {
+	report.SalesReportRepository
+	summaries map[time.Time]report.SalesSummary
+	filters   []report.SalesReportFilter
+}
+
+func (r *periodSalesReportRepository) GetSalesSummary(filter report.SalesReportFilter) (*report.SalesSummary, error) {
+	r.filters = append(r.filters, filter)
+	summary := r.summaries[filter.StartDate]
+	summary.PeriodStart, summary.PeriodEnd = filter.StartDate, filter.EndDate
+	return &summary, nil
+}
+
+// periodFinanceReportRepository serves the P&L statement stored for each period start
+type periodFinanceReportRepository struct {
+	report.FinanceReportRepository
+	statements map[time.Time]report.ProfitLossStatement
+}
+
+func (r *periodFinanceReportRepository) GetProfitLossStatement(filter report.FinanceReportFilter) (*report.ProfitLossStatement, error) {
+	statement := r.statements[filter.StartDate]
+	statement.PeriodStart, statement.PeriodEnd = filter.StartDate, filter.EndDate
+	return &statement, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// endOfDay returns the last second of a day, as report filters end
+func endOfDay(year int, month time.Month, day int) time.Time {
+	return date(year, month, day).Add(24*time.Hour - time.Second)
+}
+
+func TestComparePeriod_Range(t *testing.T) {
+	tests := []struct {
+		name       string
+		compareTo  ComparePeriod
+		start, end time.Time
+		wantStart  time.Time
+		wantEnd    time.Time
+	}{
+		{"month to previous month", ComparePreviousPeriod, date(2026, 10, 1), endOfDay(2026, 10, 31), date(2026, 9, 1), endOfDay(2026, 9, 30)},
+		{"short month to longer previous month", ComparePreviousPeriod, date(2026, 2, 1), endOfDay(2026, 2, 28), date(2026, 1, 1), endOfDay(2026, 1, 31)},
+		{"month across the year boundary", ComparePreviousPeriod, date(2026, 1, 1), endOfDay(2026, 1, 31), date(2025, 12, 1), endOfDay(2025, 12, 31)},
+		{"quarter to previous quarter", ComparePreviousPeriod, date(2026, 4, 1), endOfDay(2026, 6, 30), date(2026, 1, 1), endOfDay(2026, 3, 31)},
+		{"days to as many days before", ComparePreviousPeriod, date(2026, 10, 8), endOfDay(2026, 10, 14), date(2026, 10, 1), endOfDay(2026, 10, 7)},
+		{"month to same month last year", ComparePreviousYear, date(2026, 2, 1), endOfDay(2026, 2, 28), date(2025, 2, 1), endOfDay(2025, 2, 28)},
+		{"leap month to same month last year", ComparePreviousYear, date(2024, 2, 1), endOfDay(2024, 2, 29), date(2023, 2, 1), endOfDay(2023, 2, 28)},
+		{"days to same days last year", ComparePreviousYear, date(2026, 10, 8), endOfDay(2026, 10, 14), date(2025, 10, 8), endOfDay(2025, 10, 14)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.compareTo.Range(tt.start, tt.end)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestReportService_CompareSalesSummary_MonthOverMonthWithZeroPriorPeriod(t *testing.T) {
+	salesRepo := &periodSalesReportRepository{summaries: map[time.Time]report.SalesSummary{
+		date(2026, 10, 1): {
+			TotalOrders:      12,
+			TotalQuantity:    decimal.NewFromInt(40),
+			TotalSalesAmount: decimal.RequireFromString("2400.50"),
+			TotalCostAmount:  decimal.RequireFromString("1600.25"),
+			TotalGrossProfit: decimal.RequireFromString("800.25"),
+			AvgOrderValue:    decimal.RequireFromString("200.04"),
+			ProfitMargin:     decimal.RequireFromString("33.34"),
+		},
+		// September has no sales
+	}}
+	service := NewReportService(salesRepo, nil, nil, nil)
+
+	resp, err := service.CompareSalesSummary(context.Background(), uuid.New(), SalesReportFilter{
+		StartDate: date(2026, 10, 1),
+		EndDate:   endOfDay(2026, 10, 31),
+	}, ComparePreviousPeriod)
+
+	require.NoError(t, err)
+	require.Len(t, salesRepo.filters, 2)
+	assert.Equal(t, date(2026, 9, 1), salesRepo.filters[1].StartDate)
+	assert.Equal(t, endOfDay(2026, 9, 30), salesRepo.filters[1].EndDate)
+
+	assert.Equal(t, ComparePreviousPeriod, resp.CompareTo)
+	assert.Equal(t, int64(12), resp.Current.TotalOrders)
+	assert.Equal(t, int64(0), resp.Comparison.TotalOrders)
+	assert.Equal(t, date(2026, 9, 1), resp.Comparison.PeriodStart)
+
+	assert.Equal(t, float64(12), resp.Delta.TotalOrders.Absolute)
+	assert.Equal(t, 2400.5, resp.Delta.TotalSalesAmount.Absolute)
+	// Every prior value is zero, so no percent change can be given
+	for _, delta := range []MetricDelta{
+		resp.Delta.TotalOrders, resp.Delta.TotalQuantity, resp.Delta.TotalSalesAmount, resp.Delta.TotalCostAmount,
+		resp.Delta.TotalGrossProfit, resp.Delta.AvgOrderValue, resp.Delta.ProfitMargin,
+	} {
+		assert.Nil(t, delta.Percent)
+	}
+}
+
+func TestReportService_CompareSalesSummary_PercentChange(t *testing.T) {
+	salesRepo := &periodSalesReportRepository{summaries: map[time.Time]report.SalesSummary{
+		date(2026, 10, 1): {TotalOrders: 15, TotalSalesAmount: decimal.NewFromInt(900)},
+		date(2026, 9, 1):  {TotalOrders: 12, TotalSalesAmount: decimal.NewFromInt(1200)},
+	}}
+	service := NewReportService(salesRepo, nil, nil, nil)
+
+	resp, err := service.CompareSalesSummary(context.Background(), uuid.New(), SalesReportFilter{
+		StartDate: date(2026, 10, 1),
+		EndDate:   endOfDay(2026, 10, 31),
+	}, ComparePreviousPeriod)
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.Delta.TotalOrders.Percent)
+	assert.Equal(t, 25.0, *resp.Delta.TotalOrders.Percent)
+	assert.Equal(t, -300.0, resp.Delta.TotalSalesAmount.Absolute)
+	require.NotNil(t, resp.Delta.TotalSalesAmount.Percent)
+	assert.Equal(t, -25.0, *resp.Delta.TotalSalesAmount.Percent)
+}
+
+func TestReportService_CompareProfitLossStatement(t *testing.T) {
+	financeRepo := &periodFinanceReportRepository{statements: map[time.Time]report.ProfitLossStatement{
+		date(2026, 10, 1): {SalesRevenue: decimal.NewFromInt(5000), NetProfit: decimal.NewFromInt(-50)},
+		date(2025, 10, 1): {SalesRevenue: decimal.NewFromInt(4000), NetProfit: decimal.NewFromInt(-100)},
+	}}
+	service := NewReportService(nil, nil, financeRepo, nil)
+
+	resp, err := service.CompareProfitLossStatement(context.Background(), uuid.New(), FinanceReportFilter{
+		StartDate: date(2026, 10, 1),
+		EndDate:   endOfDay(2026, 10, 31),
+	}, ComparePreviousYear)
+
+	require.NoError(t, err)
+	assert.Equal(t, date(2025, 10, 1), resp.Comparison.PeriodStart)
+	require.NotNil(t, resp.Delta.SalesRevenue.Percent)
+	assert.Equal(t, 25.0, *resp.Delta.SalesRevenue.Percent)
+	// A loss halving is an improvement relative to the size of the prior loss
+	require.NotNil(t, resp.Delta.NetProfit.Percent)
+	assert.Equal(t, 50.0, *resp.Delta.NetProfit.Percent)
+	assert.Nil(t, resp.Delta.Expenses.Percent)
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DailySalesFactService maintains the daily sales fact table from completed orders
+type DailySalesFactService struct {
+	factRepo report.DailySalesFactRepository
+	logger   *zap.Logger
+}
+
+// NewDailySalesFactService creates a new daily sales fact service
+func NewDailySalesFactService(factRepo report.DailySalesFactRepository, logger *zap.Logger) *DailySalesFactService {
+	return &DailySalesFactService{
+		factRepo: factRepo,
+		logger:   logger,
+	}
+}
+
+// RefreshDays rebuilds the tenant's facts for every day from start to end out of the orders
+// completed on those days, and returns the number of facts stored.
+// Rebuilding whole days keeps it idempotent, so it can run for a day any number of times.
+func (s *DailySalesFactService) RefreshDays(ctx context.Context, tenantID uuid.UUID, start, end time.Time) (int, error) {
+	start, end = dayStart(start), dayEnd(end)
+
+	lines, err := s.factRepo.GetCompletedOrderLines(tenantID, start, end)
+	if err != nil {
+		return 0, err
+	}
+	facts := report.AggregateDailySalesFacts(tenantID, lines)
+	if err := s.factRepo.ReplaceDailySalesFacts(tenantID, start, end, facts); err != nil {
+		return 0, err
+	}
+
+	s.logger.Debug("Daily sales facts refreshed",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Time("start", start),
+		zap.Time("end", end),
+		zap.Int("facts", len(facts)),
+	)
+	return len(facts), nil
+}
+
+// Backfill rebuilds the facts from the orders completed between from and to, a month at a time.
+// A nil tenantID backfills every tenant with completed orders in the range.
+func (s *DailySalesFactService) Backfill(ctx context.Context, tenantID *uuid.UUID, from, to time.Time) (int, error) {
+	from, to = dayStart(from), dayEnd(to)
+
+	tenantIDs := []uuid.UUID{}
+	if tenantID != nil {
+		tenantIDs = append(tenantIDs, *tenantID)
+	} else {
+		var err error
+		if tenantIDs, err = s.factRepo.GetTenantIDsWithCompletedOrders(from, to); err != nil {
+			return 0, err
+		}
+	}
+
+	total := 0
+	for _, id := range tenantIDs {
+		for chunkStart := from; !chunkStart.After(to); chunkStart = chunkStart.AddDate(0, 1, 0) {
+			if err := ctx.Err(); err != nil {
+				return total, err
+			}
+			chunkEnd := chunkStart.AddDate(0, 1, -1)
+			if chunkEnd.After(to) {
+				chunkEnd = to
+			}
+			n, err := s.RefreshDays(ctx, id, chunkStart, chunkEnd)
+			if err != nil {
+				return total, err
+			}
+			total += n
+		}
+		s.logger.Info("Daily sales facts backfilled",
+			zap.String("tenant_id", id.String()),
+			zap.Time("from", from),
+			zap.Time("to", to),
+		)
+	}
+	return total, nil
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func dayEnd(t time.Time) time.Time {
+	return dayStart(t).AddDate(0, 0, 1).Add(-time.Second)
+}
+
+// DailySalesFactHandler refreshes the daily sales facts of the day a sales order completes,
+// so the fact table stays current between aggregation runs
+type DailySalesFactHandler struct {
+	facts  *DailySalesFactService
+	logger *zap.Logger
+}
+
+// NewDailySalesFactHandler creates a new handler refreshing daily sales facts on order completion
+func NewDailySalesFactHandler(facts *DailySalesFactService, logger *zap.Logger) *DailySalesFactHandler {
+	return &DailySalesFactHandler{
+		facts:  facts,
+		logger: logger,
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *DailySalesFactHandler) EventTypes() []string {
+	return []string{trade.EventTypeSalesOrderCompleted}
+}
+
+// Handle rebuilds the facts of the order's tenant for the day the order completed
+func (h *DailySalesFactHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	if event.EventType() != trade.EventTypeSalesOrderCompleted {
+		h.logger.Warn("unexpected event type for daily sales facts",
+			zap.String("event_type", event.EventType()))
+		return nil
+	}
+
+	day := event.OccurredAt()
+	if _, err := h.facts.RefreshDays(ctx, event.TenantID(), day, day); err != nil {
+		h.logger.Error("Failed to refresh daily sales facts",
+			zap.String("tenant_id", event.TenantID().String()),
+			zap.String("order_id", event.AggregateID().String()),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Ensure DailySalesFactHandler implements shared.EventHandler
+var _ shared.EventHandler = (*DailySalesFactHandler)(nil)
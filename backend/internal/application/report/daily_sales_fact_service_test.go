@@ -0,0 +1,94 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// factRange is a range of days a fact refresh covered
+type factRange struct {
+	tenantID   uuid.UUID
+	start, end time.Time
+}
+
+// stubDailySalesFactRepository serves fixed order lines and records the facts replaced
+type stubDailySalesFactRepository struct {
+	lines    []report.CompletedOrderLine
+	tenants  []uuid.UUID
+	replaced []factRange
+	facts    []report.DailySalesFact
+}
+
+func (r *stubDailySalesFactRepository) GetCompletedOrderLines(_ uuid.UUID, start, end time.Time) ([]report.CompletedOrderLine, error) {
+	var lines []report.CompletedOrderLine
+	for _, line := range r.lines {
+		if !line.Date.Before(start) && !line.Date.After(end) {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+func (r *stubDailySalesFactRepository) ReplaceDailySalesFacts(tenantID uuid.UUID, start, end time.Time, facts []report.DailySalesFact) error {
+	r.replaced = append(r.replaced, factRange{tenantID, start, end})
+	r.facts = append(r.facts, facts...)
+	return nil
+}
+
+func (r *stubDailySalesFactRepository) GetTenantIDsWithCompletedOrders(_, _ time.Time) ([]uuid.UUID, error) {
+	return r.tenants, nil
+}
+
+func TestDailySalesFactHandler_RefreshesCompletionDay(t *testing.T) {
+	tenantID := uuid.New()
+	productID, warehouseID := uuid.New(), uuid.New()
+	completedAt := time.Now()
+	repo := &stubDailySalesFactRepository{lines: []report.CompletedOrderLine{
+		{OrderID: uuid.New(), Date: completedAt, ProductID: productID, WarehouseID: warehouseID, Quantity: decimal.NewFromInt(2), Amount: decimal.NewFromInt(50)},
+		{OrderID: uuid.New(), Date: completedAt, ProductID: productID, WarehouseID: warehouseID, Quantity: decimal.NewFromInt(1), Amount: decimal.NewFromInt(25)},
+	}}
+	handler := NewDailySalesFactHandler(NewDailySalesFactService(repo, zap.NewNop()), zap.NewNop())
+
+	order := &trade.SalesOrder{}
+	order.ID = uuid.New()
+	order.TenantID = tenantID
+	event := trade.NewSalesOrderCompletedEvent(order)
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	require.Len(t, repo.replaced, 1)
+	occurred := event.OccurredAt()
+	assert.Equal(t, tenantID, repo.replaced[0].tenantID)
+	assert.Equal(t, dayStart(occurred), repo.replaced[0].start)
+	assert.Equal(t, dayEnd(occurred), repo.replaced[0].end)
+
+	require.Len(t, repo.facts, 1)
+	assert.Equal(t, int64(2), repo.facts[0].OrderCount)
+	assert.Equal(t, "75", repo.facts[0].Revenue.String())
+}
+
+func TestDailySalesFactService_BackfillByMonth(t *testing.T) {
+	tenantA, tenantB := uuid.New(), uuid.New()
+	repo := &stubDailySalesFactRepository{tenants: []uuid.UUID{tenantA, tenantB}}
+	service := NewDailySalesFactService(repo, zap.NewNop())
+
+	_, err := service.Backfill(context.Background(), nil, date(2026, 1, 15), date(2026, 3, 10))
+	require.NoError(t, err)
+
+	// Each tenant's range is rebuilt in contiguous chunks of at most a month
+	want := []factRange{
+		{tenantA, date(2026, 1, 15), endOfDay(2026, 2, 14)},
+		{tenantA, date(2026, 2, 15), endOfDay(2026, 3, 10)},
+		{tenantB, date(2026, 1, 15), endOfDay(2026, 2, 14)},
+		{tenantB, date(2026, 2, 15), endOfDay(2026, 3, 10)},
+	}
+	assert.Equal(t, want, repo.replaced)
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubInventoryReportRepository serves fixed product movements and records the filter it was queried with
+type stubInventoryReportRepository struct {
+	report.InventoryReportRepository
+	movements []report.ProductInventoryMovement
+	filter    report.InventoryTurnoverFilter
+}
+
+func (r *stubInventoryReportRepository) GetProductInventoryMovements(filter report.InventoryTurnoverFilter) ([]report.ProductInventoryMovement, error) {
+	r.filter = filter
+	return r.movements, nil
+}
+
+func TestReportService_GetProductTurnover(t *testing.T) {
+	dec := decimal.RequireFromString
+	movement := func(sku, opening, closing, cogs string) report.ProductInventoryMovement {
+		return report.ProductInventoryMovement{
+			ProductID:       uuid.New(),
+			ProductSKU:      sku,
+			OpeningQuantity: dec(opening),
+			ClosingQuantity: dec(closing),
+			COGS:            dec(cogs),
+			UnitCost:        dec("5"),
+		}
+	}
+	inventoryRepo := &stubInventoryReportRepository{movements: []report.ProductInventoryMovement{
+		movement("STEADY", "120", "80", "1500"), // Turnover 3
+		movement("EMPTY", "0", "0", "0"),        // No inventory: undefined turnover
+		movement("IDLE", "50", "50", "0"),       // No movement: turnover 0
+		movement("SLOW", "100", "100", "250"),   // Turnover 0.5
+	}}
+	service := NewReportService(nil, inventoryRepo, nil, nil)
+	warehouseID := uuid.New()
+	filter := ProductTurnoverFilter{
+		StartDate:   date(2026, 9, 1),
+		EndDate:     endOfDay(2026, 9, 30),
+		WarehouseID: &warehouseID,
+		Page:        1,
+		PageSize:    10,
+	}
+
+	t.Run("slowest movers first", func(t *testing.T) {
+		turnovers, total, err := service.GetProductTurnover(context.Background(), uuid.New(), filter)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Equal(t, &warehouseID, inventoryRepo.filter.WarehouseID)
+		require.Len(t, turnovers, 4)
+		assert.Equal(t, []string{"IDLE", "SLOW", "STEADY", "EMPTY"}, productSKUs(turnovers))
+
+		idle := turnovers[0]
+		require.NotNil(t, idle.TurnoverRatio)
+		assert.Equal(t, 0.0, *idle.TurnoverRatio)
+		assert.Nil(t, idle.DaysOnHand)
+
+		steady := turnovers[2]
+		require.NotNil(t, steady.TurnoverRatio)
+		assert.Equal(t, 3.0, *steady.TurnoverRatio)
+		require.NotNil(t, steady.DaysOnHand)
+		assert.Equal(t, 10.0, *steady.DaysOnHand)
+
+		assert.Nil(t, turnovers[3].TurnoverRatio)
+	})
+
+	t.Run("descending keeps undefined turnover last", func(t *testing.T) {
+		desc := filter
+		desc.Descending = true
+
+		turnovers, _, err := service.GetProductTurnover(context.Background(), uuid.New(), desc)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"STEADY", "SLOW", "IDLE", "EMPTY"}, productSKUs(turnovers))
+	})
+
+	t.Run("paginates after sorting", func(t *testing.T) {
+		paged := filter
+		paged.Page, paged.PageSize = 2, 3
+
+		turnovers, total, err := service.GetProductTurnover(context.Background(), uuid.New(), paged)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Equal(t, []string{"EMPTY"}, productSKUs(turnovers))
+
+		paged.Page = 3
+		turnovers, _, err = service.GetProductTurnover(context.Background(), uuid.New(), paged)
+		require.NoError(t, err)
+		assert.Empty(t, turnovers)
+	})
+}
+
+func productSKUs(turnovers []ProductTurnoverResponse) []string {
+	skus := make([]string, len(turnovers))
+	for i, t := range turnovers {
+		skus[i] = t.ProductSKU
+	}
+	return skus
+}
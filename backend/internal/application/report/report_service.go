@@ -2,6 +2,8 @@ package report
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/erp/backend/internal/domain/report"
@@ -14,6 +16,7 @@ type ReportService struct {
 	salesRepo     report.SalesReportRepository
 	inventoryRepo report.InventoryReportRepository
 	financeRepo   report.FinanceReportRepository
+	returnRepo    report.ReturnReportRepository
 }
 
 // NewReportService creates a new ReportService
@@ -21,11 +24,13 @@ func NewReportService(
 	salesRepo report.SalesReportRepository,
 	inventoryRepo report.InventoryReportRepository,
 	financeRepo report.FinanceReportRepository,
+	returnRepo report.ReturnReportRepository,
 ) *ReportService {
 	return &ReportService{
 		salesRepo:     salesRepo,
 		inventoryRepo: inventoryRepo,
 		financeRepo:   financeRepo,
+		returnRepo:    returnRepo,
 	}
 }
 
@@ -70,11 +75,13 @@ type ProductSalesRankingResponse struct {
 type CustomerSalesRankingResponse struct {
 	Rank          int     `json:"rank"`
 	CustomerID    string  `json:"customer_id"`
+	CustomerCode  string  `json:"customer_code,omitempty"`
 	CustomerName  string  `json:"customer_name"`
 	TotalOrders   int64   `json:"total_orders"`
 	TotalQuantity float64 `json:"total_quantity"`
 	TotalAmount   float64 `json:"total_amount"`
 	TotalProfit   float64 `json:"total_profit"`
+	AvgOrderValue float64 `json:"avg_order_value"`
 }
 
 // SalesReportFilter defines the request filter for sales reports
@@ -85,25 +92,36 @@ type SalesReportFilter struct {
 	CategoryID *uuid.UUID `form:"category_id"`
 	CustomerID *uuid.UUID `form:"customer_id"`
 	TopN       int        `form:"top_n"`
+	// Metric orders the customer ranking; empty means revenue
+	Metric string `form:"metric"`
 }
 
-// GetSalesSummary returns sales summary for the period
-func (s *ReportService) GetSalesSummary(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter) (*SalesSummaryResponse, error) {
-	domainFilter := report.SalesReportFilter{
+// SalesSummary returns the sales summary with exact decimal amounts, for exports
+func (s *ReportService) SalesSummary(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter) (*report.SalesSummary, error) {
+	return s.salesRepo.GetSalesSummary(report.SalesReportFilter{
 		TenantID:   tenantID,
 		StartDate:  filter.StartDate,
 		EndDate:    filter.EndDate,
 		ProductID:  filter.ProductID,
 		CategoryID: filter.CategoryID,
 		CustomerID: filter.CustomerID,
-	}
+	})
+}
 
-	summary, err := s.salesRepo.GetSalesSummary(domainFilter)
+// GetSalesSummary returns sales summary for the period
+func (s *ReportService) GetSalesSummary(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter) (*SalesSummaryResponse, error) {
+	summary, err := s.SalesSummary(ctx, tenantID, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return &SalesSummaryResponse{
+	resp := toSalesSummaryResponse(summary)
+	return &resp, nil
+}
+
+// toSalesSummaryResponse converts a sales summary to its response
+func toSalesSummaryResponse(summary *report.SalesSummary) SalesSummaryResponse {
+	return SalesSummaryResponse{
 		PeriodStart:      summary.PeriodStart,
 		PeriodEnd:        summary.PeriodEnd,
 		TotalOrders:      summary.TotalOrders,
@@ -113,7 +131,7 @@ func (s *ReportService) GetSalesSummary(ctx context.Context, tenantID uuid.UUID,
 		TotalGrossProfit: toFloat64(summary.TotalGrossProfit),
 		AvgOrderValue:    toFloat64(summary.AvgOrderValue),
 		ProfitMargin:     toFloat64(summary.ProfitMargin),
-	}, nil
+	}
 }
 
 // GetDailySalesTrend returns daily sales trend
@@ -143,22 +161,25 @@ func (s *ReportService) GetDailySalesTrend(ctx context.Context, tenantID uuid.UU
 	return responses, nil
 }
 
-// GetProductSalesRanking returns top products by sales
-func (s *ReportService) GetProductSalesRanking(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter) ([]ProductSalesRankingResponse, error) {
+// ProductSalesRanking returns the top products by sales with exact decimal amounts, for exports
+func (s *ReportService) ProductSalesRanking(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter) ([]report.ProductSalesRanking, error) {
 	topN := filter.TopN
 	if topN <= 0 {
 		topN = 10
 	}
 
-	domainFilter := report.SalesReportFilter{
+	return s.salesRepo.GetProductSalesRanking(report.SalesReportFilter{
 		TenantID:   tenantID,
 		StartDate:  filter.StartDate,
 		EndDate:    filter.EndDate,
 		CategoryID: filter.CategoryID,
 		TopN:       topN,
-	}
+	})
+}
 
-	rankings, err := s.salesRepo.GetProductSalesRanking(domainFilter)
+// GetProductSalesRanking returns top products by sales
+func (s *ReportService) GetProductSalesRanking(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter) ([]ProductSalesRankingResponse, error) {
+	rankings, err := s.ProductSalesRanking(ctx, tenantID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +202,7 @@ func (s *ReportService) GetProductSalesRanking(ctx context.Context, tenantID uui
 	return responses, nil
 }
 
-// GetCustomerSalesRanking returns top customers by sales
+// GetCustomerSalesRanking returns top customers by the filter's metric, revenue by default
 func (s *ReportService) GetCustomerSalesRanking(ctx context.Context, tenantID uuid.UUID, filter SalesReportFilter) ([]CustomerSalesRankingResponse, error) {
 	topN := filter.TopN
 	if topN <= 0 {
@@ -193,6 +214,7 @@ func (s *ReportService) GetCustomerSalesRanking(ctx context.Context, tenantID uu
 		StartDate: filter.StartDate,
 		EndDate:   filter.EndDate,
 		TopN:      topN,
+		RankBy:    report.CustomerRankingMetric(filter.Metric),
 	}
 
 	rankings, err := s.salesRepo.GetCustomerSalesRanking(domainFilter)
@@ -205,17 +227,133 @@ func (s *ReportService) GetCustomerSalesRanking(ctx context.Context, tenantID uu
 		responses[i] = CustomerSalesRankingResponse{
 			Rank:          r.Rank,
 			CustomerID:    r.CustomerID.String(),
+			CustomerCode:  r.CustomerCode,
 			CustomerName:  r.CustomerName,
 			TotalOrders:   r.TotalOrders,
 			TotalQuantity: toFloat64(r.TotalQuantity),
 			TotalAmount:   toFloat64(r.TotalAmount),
 			TotalProfit:   toFloat64(r.TotalProfit),
+			AvgOrderValue: toFloat64(r.AvgOrderValue),
 		}
 	}
 
 	return responses, nil
 }
 
+// DurationStatsResponse represents how long orders spent in one fulfillment stage
+type DurationStatsResponse struct {
+	Count      int64   `json:"count"`
+	InProgress int64   `json:"in_progress"`
+	AvgHours   float64 `json:"avg_hours"`
+	P95Hours   float64 `json:"p95_hours"`
+	MaxHours   float64 `json:"max_hours"`
+}
+
+// FulfillmentSLAResponse represents fulfillment durations for all orders or one warehouse
+type FulfillmentSLAResponse struct {
+	WarehouseID    *uuid.UUID            `json:"warehouse_id,omitempty"`
+	WarehouseName  string                `json:"warehouse_name,omitempty"`
+	OrderCount     int64                 `json:"order_count"`
+	CancelledCount int64                 `json:"cancelled_count"`
+	ConfirmToShip  DurationStatsResponse `json:"confirm_to_ship"`
+	ShipToComplete DurationStatsResponse `json:"ship_to_complete"`
+}
+
+// FulfillmentSLAReportResponse represents the fulfillment SLA report for a period
+type FulfillmentSLAReportResponse struct {
+	PeriodStart time.Time                `json:"period_start"`
+	PeriodEnd   time.Time                `json:"period_end"`
+	Overall     FulfillmentSLAResponse   `json:"overall"`
+	Warehouses  []FulfillmentSLAResponse `json:"warehouses,omitempty"`
+}
+
+// FulfillmentSLAFilter defines the request filter for the fulfillment SLA report
+type FulfillmentSLAFilter struct {
+	StartDate        time.Time `form:"start_date" binding:"required"`
+	EndDate          time.Time `form:"end_date" binding:"required"`
+	GroupByWarehouse bool      `form:"-"`
+}
+
+// GetFulfillmentSLA returns confirm-to-ship and ship-to-complete durations for orders confirmed
+// in the period, optionally broken down by warehouse
+func (s *ReportService) GetFulfillmentSLA(ctx context.Context, tenantID uuid.UUID, filter FulfillmentSLAFilter) (*FulfillmentSLAReportResponse, error) {
+	domainFilter := report.SalesReportFilter{
+		TenantID:  tenantID,
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+	}
+
+	orders, err := s.salesRepo.GetOrderFulfillmentTimes(domainFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &FulfillmentSLAReportResponse{
+		PeriodStart: filter.StartDate,
+		PeriodEnd:   filter.EndDate,
+		Overall:     toFulfillmentSLAResponse(report.ComputeFulfillmentSLA(orders)),
+	}
+	if !filter.GroupByWarehouse {
+		return resp, nil
+	}
+
+	// Orders without a warehouse are grouped under uuid.Nil
+	byWarehouse := make(map[uuid.UUID][]report.OrderFulfillmentTimes)
+	names := make(map[uuid.UUID]string)
+	for _, o := range orders {
+		key := uuid.Nil
+		if o.WarehouseID != nil {
+			key = *o.WarehouseID
+		}
+		byWarehouse[key] = append(byWarehouse[key], o)
+		names[key] = o.WarehouseName
+	}
+
+	resp.Warehouses = make([]FulfillmentSLAResponse, 0, len(byWarehouse))
+	for warehouseID, warehouseOrders := range byWarehouse {
+		sla := report.ComputeFulfillmentSLA(warehouseOrders)
+		if warehouseID != uuid.Nil {
+			id := warehouseID
+			sla.WarehouseID = &id
+			sla.WarehouseName = names[warehouseID]
+		}
+		resp.Warehouses = append(resp.Warehouses, toFulfillmentSLAResponse(sla))
+	}
+	sort.Slice(resp.Warehouses, func(i, j int) bool {
+		a, b := resp.Warehouses[i], resp.Warehouses[j]
+		if (a.WarehouseID == nil) != (b.WarehouseID == nil) {
+			return b.WarehouseID == nil
+		}
+		if a.WarehouseName != b.WarehouseName {
+			return a.WarehouseName < b.WarehouseName
+		}
+		return a.WarehouseID != nil && a.WarehouseID.String() < b.WarehouseID.String()
+	})
+
+	return resp, nil
+}
+
+func toFulfillmentSLAResponse(sla report.FulfillmentSLA) FulfillmentSLAResponse {
+	return FulfillmentSLAResponse{
+		WarehouseID:    sla.WarehouseID,
+		WarehouseName:  sla.WarehouseName,
+		OrderCount:     sla.OrderCount,
+		CancelledCount: sla.CancelledCount,
+		ConfirmToShip:  toDurationStatsResponse(sla.ConfirmToShip),
+		ShipToComplete: toDurationStatsResponse(sla.ShipToComplete),
+	}
+}
+
+func toDurationStatsResponse(stats report.DurationStats) DurationStatsResponse {
+	return DurationStatsResponse{
+		Count:      stats.Count,
+		InProgress: stats.InProgress,
+		AvgHours:   toFloat64(stats.AvgHours),
+		P95Hours:   toFloat64(stats.P95Hours),
+		MaxHours:   toFloat64(stats.MaxHours),
+	}
+}
+
 // ===================== Inventory Report Operations =====================
 
 // InventorySummaryResponse represents inventory summary
@@ -440,6 +578,102 @@ func (s *ReportService) GetSlowMovingProducts(ctx context.Context, tenantID uuid
 	return responses, nil
 }
 
+// ProductTurnoverResponse represents the turnover of a product over a period
+type ProductTurnoverResponse struct {
+	ProductID       string   `json:"product_id"`
+	ProductSKU      string   `json:"product_sku"`
+	ProductName     string   `json:"product_name"`
+	CategoryName    string   `json:"category_name,omitempty"`
+	OpeningQuantity float64  `json:"opening_quantity"`
+	ClosingQuantity float64  `json:"closing_quantity"`
+	AverageQuantity float64  `json:"average_quantity"`
+	AverageValue    float64  `json:"average_value"`
+	SoldQuantity    float64  `json:"sold_quantity"`
+	COGS            float64  `json:"cogs"`
+	TurnoverRatio   *float64 `json:"turnover_ratio"`
+	DaysOnHand      *float64 `json:"days_on_hand"`
+}
+
+// ProductTurnoverFilter defines the request filter for the per-product turnover report
+type ProductTurnoverFilter struct {
+	StartDate   time.Time
+	EndDate     time.Time
+	ProductID   *uuid.UUID
+	CategoryID  *uuid.UUID
+	WarehouseID *uuid.UUID
+	Descending  bool // Sort by turnover ratio descending instead of slowest movers first
+	Page        int
+	PageSize    int
+}
+
+// GetProductTurnover returns a page of per-product turnover ratios and days on hand, sorted by turnover ratio
+// with the slowest movers first unless Descending is set. Products whose turnover is undefined, because they
+// held no inventory, come last either way; ties put the products with the most inventory value first.
+func (s *ReportService) GetProductTurnover(ctx context.Context, tenantID uuid.UUID, filter ProductTurnoverFilter) ([]ProductTurnoverResponse, int64, error) {
+	movements, err := s.inventoryRepo.GetProductInventoryMovements(report.InventoryTurnoverFilter{
+		TenantID:    tenantID,
+		StartDate:   filter.StartDate,
+		EndDate:     filter.EndDate,
+		ProductID:   filter.ProductID,
+		CategoryID:  filter.CategoryID,
+		WarehouseID: filter.WarehouseID,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	days := periodDays(filter.StartDate, filter.EndDate)
+	turnovers := make([]report.ProductTurnover, len(movements))
+	for i, movement := range movements {
+		turnovers[i] = report.NewProductTurnover(movement, days)
+	}
+
+	sort.SliceStable(turnovers, func(i, j int) bool {
+		a, b := turnovers[i], turnovers[j]
+		if (a.TurnoverRatio == nil) != (b.TurnoverRatio == nil) {
+			return b.TurnoverRatio == nil
+		}
+		if a.TurnoverRatio != nil && !a.TurnoverRatio.Equal(*b.TurnoverRatio) {
+			return a.TurnoverRatio.LessThan(*b.TurnoverRatio) != filter.Descending
+		}
+		if !a.AverageValue.Equal(b.AverageValue) {
+			return a.AverageValue.GreaterThan(b.AverageValue)
+		}
+		return a.ProductSKU < b.ProductSKU
+	})
+
+	total := int64(len(turnovers))
+	page, pageSize := filter.Page, filter.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	start := min((page-1)*pageSize, len(turnovers))
+	end := min(start+pageSize, len(turnovers))
+
+	responses := make([]ProductTurnoverResponse, 0, end-start)
+	for _, t := range turnovers[start:end] {
+		responses = append(responses, ProductTurnoverResponse{
+			ProductID:       t.ProductID.String(),
+			ProductSKU:      t.ProductSKU,
+			ProductName:     t.ProductName,
+			CategoryName:    t.CategoryName,
+			OpeningQuantity: toFloat64(t.OpeningQuantity),
+			ClosingQuantity: toFloat64(t.ClosingQuantity),
+			AverageQuantity: toFloat64(t.AverageQuantity),
+			AverageValue:    toFloat64(t.AverageValue),
+			SoldQuantity:    toFloat64(t.SoldQuantity),
+			COGS:            toFloat64(t.COGS),
+			TurnoverRatio:   toFloat64Ptr(t.TurnoverRatio),
+			DaysOnHand:      toFloat64Ptr(t.DaysOnHand),
+		})
+	}
+
+	return responses, total, nil
+}
+
 // ===================== Finance Report Operations =====================
 
 // ProfitLossStatementResponse represents P&L statement
@@ -517,20 +751,29 @@ type FinanceReportFilter struct {
 	TopN       int        `form:"top_n"`
 }
 
-// GetProfitLossStatement returns P&L statement
-func (s *ReportService) GetProfitLossStatement(ctx context.Context, tenantID uuid.UUID, filter FinanceReportFilter) (*ProfitLossStatementResponse, error) {
-	domainFilter := report.FinanceReportFilter{
+// ProfitLossStatement returns the P&L statement with exact decimal amounts, for exports
+func (s *ReportService) ProfitLossStatement(ctx context.Context, tenantID uuid.UUID, filter FinanceReportFilter) (*report.ProfitLossStatement, error) {
+	return s.financeRepo.GetProfitLossStatement(report.FinanceReportFilter{
 		TenantID:  tenantID,
 		StartDate: filter.StartDate,
 		EndDate:   filter.EndDate,
-	}
+	})
+}
 
-	statement, err := s.financeRepo.GetProfitLossStatement(domainFilter)
+// GetProfitLossStatement returns P&L statement
+func (s *ReportService) GetProfitLossStatement(ctx context.Context, tenantID uuid.UUID, filter FinanceReportFilter) (*ProfitLossStatementResponse, error) {
+	statement, err := s.ProfitLossStatement(ctx, tenantID, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ProfitLossStatementResponse{
+	resp := toProfitLossStatementResponse(statement)
+	return &resp, nil
+}
+
+// toProfitLossStatementResponse converts a P&L statement to its response
+func toProfitLossStatementResponse(statement *report.ProfitLossStatement) ProfitLossStatementResponse {
+	return ProfitLossStatementResponse{
 		PeriodStart:     statement.PeriodStart,
 		PeriodEnd:       statement.PeriodEnd,
 		SalesRevenue:    toFloat64(statement.SalesRevenue),
@@ -544,7 +787,7 @@ func (s *ReportService) GetProfitLossStatement(ctx context.Context, tenantID uui
 		Expenses:        toFloat64(statement.Expenses),
 		NetProfit:       toFloat64(statement.NetProfit),
 		NetMargin:       toFloat64(statement.NetMargin),
-	}, nil
+	}
 }
 
 // GetMonthlyProfitTrend returns monthly profit trend
@@ -669,9 +912,127 @@ func (s *ReportService) GetCashFlowItems(ctx context.Context, tenantID uuid.UUID
 	return responses, nil
 }
 
+// ===================== Return Report Operations =====================
+
+// ReturnReasonResponse represents the returns filed under one reason code
+type ReturnReasonResponse struct {
+	ReasonCode           string  `json:"reason_code"`
+	ReturnCount          int64   `json:"return_count"`
+	TotalAmount          float64 `json:"total_amount"`
+	SalesReturnCount     int64   `json:"sales_return_count"`
+	SalesReturnAmount    float64 `json:"sales_return_amount"`
+	PurchaseReturnCount  int64   `json:"purchase_return_count"`
+	PurchaseReturnAmount float64 `json:"purchase_return_amount"`
+}
+
+// ReturnReasonReportResponse represents return counts and amounts by reason code for a period
+type ReturnReasonReportResponse struct {
+	PeriodStart          time.Time              `json:"period_start"`
+	PeriodEnd            time.Time              `json:"period_end"`
+	TotalSalesReturns    int64                  `json:"total_sales_returns"`
+	TotalSalesAmount     float64                `json:"total_sales_amount"`
+	TotalPurchaseReturns int64                  `json:"total_purchase_returns"`
+	TotalPurchaseAmount  float64                `json:"total_purchase_amount"`
+	Reasons              []ReturnReasonResponse `json:"reasons"`
+}
+
+// ReturnReportFilter defines the request filter for return reports
+type ReturnReportFilter struct {
+	StartDate time.Time `form:"start_date" binding:"required"`
+	EndDate   time.Time `form:"end_date" binding:"required"`
+}
+
+// GetReturnReasonReport returns sales and purchase return counts and refund amounts by reason code,
+// ordered by the number of returns
+func (s *ReportService) GetReturnReasonReport(ctx context.Context, tenantID uuid.UUID, filter ReturnReportFilter) (*ReturnReasonReportResponse, error) {
+	domainFilter := report.ReturnReportFilter{
+		TenantID:  tenantID,
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+	}
+
+	salesStats, err := s.returnRepo.GetSalesReturnsByReason(domainFilter)
+	if err != nil {
+		return nil, err
+	}
+	purchaseStats, err := s.returnRepo.GetPurchaseReturnsByReason(domainFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	type reasonTotals struct {
+		salesCount, purchaseCount   int64
+		salesAmount, purchaseAmount decimal.Decimal
+	}
+	byCode := make(map[string]*reasonTotals)
+	totalsFor := func(code string) *reasonTotals {
+		code = strings.ToLower(code)
+		if totals, ok := byCode[code]; ok {
+			return totals
+		}
+		totals := &reasonTotals{}
+		byCode[code] = totals
+		return totals
+	}
+
+	resp := &ReturnReasonReportResponse{
+		PeriodStart: filter.StartDate,
+		PeriodEnd:   filter.EndDate,
+	}
+	salesAmount, purchaseAmount := decimal.Zero, decimal.Zero
+	for _, stat := range salesStats {
+		totals := totalsFor(stat.ReasonCode)
+		totals.salesCount, totals.salesAmount = stat.ReturnCount, stat.TotalAmount
+		resp.TotalSalesReturns += stat.ReturnCount
+		salesAmount = salesAmount.Add(stat.TotalAmount)
+	}
+	for _, stat := range purchaseStats {
+		totals := totalsFor(stat.ReasonCode)
+		totals.purchaseCount, totals.purchaseAmount = stat.ReturnCount, stat.TotalAmount
+		resp.TotalPurchaseReturns += stat.ReturnCount
+		purchaseAmount = purchaseAmount.Add(stat.TotalAmount)
+	}
+	resp.TotalSalesAmount = toFloat64(salesAmount)
+	resp.TotalPurchaseAmount = toFloat64(purchaseAmount)
+
+	resp.Reasons = make([]ReturnReasonResponse, 0, len(byCode))
+	for code, totals := range byCode {
+		resp.Reasons = append(resp.Reasons, ReturnReasonResponse{
+			ReasonCode:           code,
+			ReturnCount:          totals.salesCount + totals.purchaseCount,
+			TotalAmount:          toFloat64(totals.salesAmount.Add(totals.purchaseAmount)),
+			SalesReturnCount:     totals.salesCount,
+			SalesReturnAmount:    toFloat64(totals.salesAmount),
+			PurchaseReturnCount:  totals.purchaseCount,
+			PurchaseReturnAmount: toFloat64(totals.purchaseAmount),
+		})
+	}
+	sort.Slice(resp.Reasons, func(i, j int) bool {
+		if resp.Reasons[i].ReturnCount != resp.Reasons[j].ReturnCount {
+			return resp.Reasons[i].ReturnCount > resp.Reasons[j].ReturnCount
+		}
+		return resp.Reasons[i].ReasonCode < resp.Reasons[j].ReasonCode
+	})
+
+	return resp, nil
+}
+
 // ===================== Helper Functions =====================
 
 func toFloat64(d decimal.Decimal) float64 {
 	f, _ := d.Float64()
 	return f
 }
+
+func toFloat64Ptr(d *decimal.Decimal) *float64 {
+	if d == nil {
+		return nil
+	}
+	f := toFloat64(*d)
+	return &f
+}
+
+// periodDays returns the number of days from start to end, counting the day end falls on
+func periodDays(start, end time.Time) int {
+	return int(end.Sub(start).Hours()/24) + 1
+}
@@ -0,0 +1,133 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubReturnReportRepository serves fixed return stats and records the filter it was queried with
+type stubReturnReportRepository struct {
+	sales    []report.ReturnReasonStat
+	purchase []report.ReturnReasonStat
+	filter   report.ReturnReportFilter
+}
+
+func (r *stubReturnReportRepository) GetSalesReturnsByReason(filter report.ReturnReportFilter) ([]report.ReturnReasonStat, error) {
+	r.filter = filter
+	return r.sales, nil
+}
+
+func (r *stubReturnReportRepository) GetPurchaseReturnsByReason(filter report.ReturnReportFilter) ([]report.ReturnReasonStat, error) {
+	return r.purchase, nil
+}
+
+func TestReportService_GetReturnReasonReport(t *testing.T) {
+	tenantID := uuid.New()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	repo := &stubReturnReportRepository{
+		sales: []report.ReturnReasonStat{
+			{ReasonCode: "DAMAGED", ReturnCount: 3, TotalAmount: decimal.NewFromFloat(450.5)},
+			{ReasonCode: "CUSTOMER_REMORSE", ReturnCount: 2, TotalAmount: decimal.NewFromInt(200)},
+		},
+		purchase: []report.ReturnReasonStat{
+			{ReasonCode: "QUALITY", ReturnCount: 4, TotalAmount: decimal.NewFromInt(1200)},
+			{ReasonCode: "DAMAGED", ReturnCount: 2, TotalAmount: decimal.NewFromInt(300)},
+		},
+	}
+	service := NewReportService(nil, nil, nil, repo)
+
+	resp, err := service.GetReturnReasonReport(context.Background(), tenantID, ReturnReportFilter{StartDate: start, EndDate: end})
+	require.NoError(t, err)
+
+	assert.Equal(t, tenantID, repo.filter.TenantID)
+	assert.Equal(t, start, resp.PeriodStart)
+	assert.Equal(t, end, resp.PeriodEnd)
+	assert.Equal(t, int64(5), resp.TotalSalesReturns)
+	assert.Equal(t, 650.5, resp.TotalSalesAmount)
+	assert.Equal(t, int64(6), resp.TotalPurchaseReturns)
+	assert.Equal(t, 1500.0, resp.TotalPurchaseAmount)
+
+	// Sales and purchase returns are merged per reason code, most frequent first
+	require.Len(t, resp.Reasons, 3)
+	assert.Equal(t, ReturnReasonResponse{
+		ReasonCode:           "damaged",
+		ReturnCount:          5,
+		TotalAmount:          750.5,
+		SalesReturnCount:     3,
+		SalesReturnAmount:    450.5,
+		PurchaseReturnCount:  2,
+		PurchaseReturnAmount: 300,
+	}, resp.Reasons[0])
+	assert.Equal(t, "quality", resp.Reasons[1].ReasonCode)
+	assert.Equal(t, int64(4), resp.Reasons[1].PurchaseReturnCount)
+	assert.Zero(t, resp.Reasons[1].SalesReturnCount)
+	assert.Equal(t, "customer_remorse", resp.Reasons[2].ReasonCode)
+}
+
+// stubSalesReportRepository serves fixed order fulfillment times; other queries are not used
+type stubSalesReportRepository struct {
+	report.SalesReportRepository
+	orders []report.OrderFulfillmentTimes
+}
+
+func (r *stubSalesReportRepository) GetOrderFulfillmentTimes(filter report.SalesReportFilter) ([]report.OrderFulfillmentTimes, error) {
+	return r.orders, nil
+}
+
+func TestReportService_GetFulfillmentSLA(t *testing.T) {
+	confirmed := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	after := func(hours int) *time.Time {
+		ts := confirmed.Add(time.Duration(hours) * time.Hour)
+		return &ts
+	}
+	north, south := uuid.New(), uuid.New()
+
+	repo := &stubSalesReportRepository{orders: []report.OrderFulfillmentTimes{
+		{OrderID: uuid.New(), WarehouseID: &south, WarehouseName: "South", ConfirmedAt: confirmed, FullyShippedAt: after(30)},
+		{OrderID: uuid.New(), WarehouseID: &north, WarehouseName: "North", ConfirmedAt: confirmed, FullyShippedAt: after(10), CompletedAt: after(20)},
+		{OrderID: uuid.New(), WarehouseID: &north, WarehouseName: "North", ConfirmedAt: confirmed, CancelledAt: after(1)},
+		{OrderID: uuid.New(), ConfirmedAt: confirmed},
+	}}
+	service := NewReportService(repo, nil, nil, nil)
+	filter := FulfillmentSLAFilter{StartDate: confirmed, EndDate: confirmed.AddDate(0, 1, 0)}
+
+	t.Run("overall only by default", func(t *testing.T) {
+		resp, err := service.GetFulfillmentSLA(context.Background(), uuid.New(), filter)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(4), resp.Overall.OrderCount)
+		assert.Equal(t, int64(1), resp.Overall.CancelledCount)
+		assert.Equal(t, int64(2), resp.Overall.ConfirmToShip.Count)
+		assert.Equal(t, int64(1), resp.Overall.ConfirmToShip.InProgress)
+		assert.Equal(t, 20.0, resp.Overall.ConfirmToShip.AvgHours)
+		assert.Equal(t, int64(1), resp.Overall.ShipToComplete.InProgress)
+		assert.Empty(t, resp.Warehouses)
+	})
+
+	t.Run("grouped by warehouse", func(t *testing.T) {
+		filter.GroupByWarehouse = true
+		resp, err := service.GetFulfillmentSLA(context.Background(), uuid.New(), filter)
+		require.NoError(t, err)
+
+		// Named warehouses first, then orders without a warehouse
+		require.Len(t, resp.Warehouses, 3)
+		assert.Equal(t, "North", resp.Warehouses[0].WarehouseName)
+		assert.Equal(t, &north, resp.Warehouses[0].WarehouseID)
+		assert.Equal(t, int64(2), resp.Warehouses[0].OrderCount)
+		assert.Equal(t, 10.0, resp.Warehouses[0].ConfirmToShip.AvgHours)
+		assert.Equal(t, 10.0, resp.Warehouses[0].ShipToComplete.AvgHours)
+		assert.Equal(t, "South", resp.Warehouses[1].WarehouseName)
+		assert.Equal(t, 30.0, resp.Warehouses[1].ConfirmToShip.P95Hours)
+		assert.Nil(t, resp.Warehouses[2].WarehouseID)
+		assert.Equal(t, int64(1), resp.Warehouses[2].ConfirmToShip.InProgress)
+	})
+}
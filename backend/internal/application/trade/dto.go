@@ -87,6 +87,24 @@ type CancelPurchaseOrderRequest struct {
 	Reason string `json:"reason" binding:"required,min=1,max=500"`
 }
 
+// PurchaseOrderAmendmentLineInput represents a proposed change to a purchase order line
+type PurchaseOrderAmendmentLineInput struct {
+	ItemID   uuid.UUID        `json:"item_id" binding:"required"`
+	Quantity *decimal.Decimal `json:"quantity"`  // New ordered quantity; omit to keep the current quantity
+	UnitCost *decimal.Decimal `json:"unit_cost"` // New unit cost; omit to keep the current cost
+}
+
+// RequestPurchaseOrderAmendmentRequest represents a request to amend a confirmed purchase order
+type RequestPurchaseOrderAmendmentRequest struct {
+	Lines  []PurchaseOrderAmendmentLineInput `json:"lines" binding:"required,min=1"`
+	Reason string                            `json:"reason" binding:"required,min=1,max=500"`
+}
+
+// RejectPurchaseOrderAmendmentRequest represents a request to reject a pending amendment
+type RejectPurchaseOrderAmendmentRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500"`
+}
+
 // PurchaseOrderListFilter represents filter options for purchase order list
 type PurchaseOrderListFilter struct {
 	Search      string                     `form:"search"`
@@ -106,29 +124,45 @@ type PurchaseOrderListFilter struct {
 
 // PurchaseOrderResponse represents a purchase order in API responses
 type PurchaseOrderResponse struct {
-	ID               uuid.UUID                   `json:"id"`
-	TenantID         uuid.UUID                   `json:"tenant_id"`
-	OrderNumber      string                      `json:"order_number"`
-	SupplierID       uuid.UUID                   `json:"supplier_id"`
-	SupplierName     string                      `json:"supplier_name"`
-	WarehouseID      *uuid.UUID                  `json:"warehouse_id,omitempty"`
-	Items            []PurchaseOrderItemResponse `json:"items"`
-	ItemCount        int                         `json:"item_count"`
-	TotalQuantity    decimal.Decimal             `json:"total_quantity"`
-	ReceivedQuantity decimal.Decimal             `json:"received_quantity"`
-	TotalAmount      decimal.Decimal             `json:"total_amount"`
-	DiscountAmount   decimal.Decimal             `json:"discount_amount"`
-	PayableAmount    decimal.Decimal             `json:"payable_amount"`
-	Status           string                      `json:"status"`
-	ReceiveProgress  decimal.Decimal             `json:"receive_progress"`
-	Remark           string                      `json:"remark"`
-	ConfirmedAt      *time.Time                  `json:"confirmed_at,omitempty"`
-	CompletedAt      *time.Time                  `json:"completed_at,omitempty"`
-	CancelledAt      *time.Time                  `json:"cancelled_at,omitempty"`
-	CancelReason     string                      `json:"cancel_reason,omitempty"`
-	CreatedAt        time.Time                   `json:"created_at"`
-	UpdatedAt        time.Time                   `json:"updated_at"`
-	Version          int                         `json:"version"`
+	ID               uuid.UUID                       `json:"id"`
+	TenantID         uuid.UUID                       `json:"tenant_id"`
+	OrderNumber      string                          `json:"order_number"`
+	SupplierID       uuid.UUID                       `json:"supplier_id"`
+	SupplierName     string                          `json:"supplier_name"`
+	WarehouseID      *uuid.UUID                      `json:"warehouse_id,omitempty"`
+	Items            []PurchaseOrderItemResponse     `json:"items"`
+	ItemCount        int                             `json:"item_count"`
+	TotalQuantity    decimal.Decimal                 `json:"total_quantity"`
+	ReceivedQuantity decimal.Decimal                 `json:"received_quantity"`
+	TotalAmount      decimal.Decimal                 `json:"total_amount"`
+	DiscountAmount   decimal.Decimal                 `json:"discount_amount"`
+	PayableAmount    decimal.Decimal                 `json:"payable_amount"`
+	Status           string                          `json:"status"`
+	ReceiveProgress  decimal.Decimal                 `json:"receive_progress"`
+	Remark           string                          `json:"remark"`
+	ConfirmedAt      *time.Time                      `json:"confirmed_at,omitempty"`
+	CompletedAt      *time.Time                      `json:"completed_at,omitempty"`
+	CancelledAt      *time.Time                      `json:"cancelled_at,omitempty"`
+	CancelReason     string                          `json:"cancel_reason,omitempty"`
+	PendingAmendment *PurchaseOrderAmendmentResponse `json:"pending_amendment,omitempty"`
+	CreatedAt        time.Time                       `json:"created_at"`
+	UpdatedAt        time.Time                       `json:"updated_at"`
+	Version          int                             `json:"version"`
+}
+
+// PurchaseOrderAmendmentResponse represents a pending purchase order amendment in API responses
+type PurchaseOrderAmendmentResponse struct {
+	Lines       []PurchaseOrderAmendmentLineResponse `json:"lines"`
+	Reason      string                               `json:"reason"`
+	RequestedBy uuid.UUID                            `json:"requested_by"`
+	RequestedAt time.Time                            `json:"requested_at"`
+}
+
+// PurchaseOrderAmendmentLineResponse represents a proposed line change in API responses
+type PurchaseOrderAmendmentLineResponse struct {
+	ItemID   uuid.UUID        `json:"item_id"`
+	Quantity *decimal.Decimal `json:"quantity,omitempty"`
+	UnitCost *decimal.Decimal `json:"unit_cost,omitempty"`
 }
 
 // PurchaseOrderListItemResponse represents a purchase order in list responses (less detail)
@@ -188,14 +222,15 @@ type ReceiveResultResponse struct {
 
 // PurchaseOrderStatusSummary represents a summary of purchase orders by status
 type PurchaseOrderStatusSummary struct {
-	Draft           int64           `json:"draft"`
-	Confirmed       int64           `json:"confirmed"`
-	PartialReceived int64           `json:"partial_received"`
-	Completed       int64           `json:"completed"`
-	Cancelled       int64           `json:"cancelled"`
-	Total           int64           `json:"total"`
-	PendingReceipt  int64           `json:"pending_receipt"` // CONFIRMED + PARTIAL_RECEIVED
-	TotalAmount     decimal.Decimal `json:"total_amount"`
+	Draft            int64           `json:"draft"`
+	Confirmed        int64           `json:"confirmed"`
+	PendingAmendment int64           `json:"pending_amendment"`
+	PartialReceived  int64           `json:"partial_received"`
+	Completed        int64           `json:"completed"`
+	Cancelled        int64           `json:"cancelled"`
+	Total            int64           `json:"total"`
+	PendingReceipt   int64           `json:"pending_receipt"` // CONFIRMED + PARTIAL_RECEIVED
+	TotalAmount      decimal.Decimal `json:"total_amount"`
 }
 
 // ToPurchaseOrderResponse converts domain PurchaseOrder to response DTO
@@ -226,12 +261,34 @@ func ToPurchaseOrderResponse(order *trade.PurchaseOrder) PurchaseOrderResponse {
 		CompletedAt:      order.CompletedAt,
 		CancelledAt:      order.CancelledAt,
 		CancelReason:     order.CancelReason,
+		PendingAmendment: toPurchaseOrderAmendmentResponse(order.PendingAmendment),
 		CreatedAt:        order.CreatedAt,
 		UpdatedAt:        order.UpdatedAt,
 		Version:          order.Version,
 	}
 }
 
+// toPurchaseOrderAmendmentResponse converts a pending amendment to response DTO, nil if none is pending
+func toPurchaseOrderAmendmentResponse(amendment *trade.PurchaseOrderAmendment) *PurchaseOrderAmendmentResponse {
+	if amendment == nil {
+		return nil
+	}
+	lines := make([]PurchaseOrderAmendmentLineResponse, len(amendment.Lines))
+	for i, line := range amendment.Lines {
+		lines[i] = PurchaseOrderAmendmentLineResponse{
+			ItemID:   line.ItemID,
+			Quantity: line.Quantity,
+			UnitCost: line.UnitCost,
+		}
+	}
+	return &PurchaseOrderAmendmentResponse{
+		Lines:       lines,
+		Reason:      amendment.Reason,
+		RequestedBy: amendment.RequestedBy,
+		RequestedAt: amendment.RequestedAt,
+	}
+}
+
 // ToPurchaseOrderListItemResponse converts domain PurchaseOrder to list response DTO
 func ToPurchaseOrderListItemResponse(order *trade.PurchaseOrder) PurchaseOrderListItemResponse {
 	return PurchaseOrderListItemResponse{
@@ -312,11 +369,15 @@ type CreateSalesOrderRequest struct {
 	CustomerName        string                      `json:"customer_name" binding:"required,min=1,max=200"`
 	CustomerLevel       string                      `json:"customer_level"` // Customer level for pricing (normal, silver, gold, platinum, vip)
 	WarehouseID         *uuid.UUID                  `json:"warehouse_id"`
+	ShippingAddressID   *uuid.UUID                  `json:"shipping_address_id"` // Optional shipping address of the customer; defaults to the customer's default
 	Items               []CreateSalesOrderItemInput `json:"items"`
 	Discount            *decimal.Decimal            `json:"discount"`
 	Remark              string                      `json:"remark"`
-	PricingStrategyName string                      `json:"pricing_strategy"` // Optional: pricing strategy to use (standard, tiered, customer_level)
-	CreatedBy           *uuid.UUID                  `json:"-"`                // Set from JWT context, not from request body
+	PricingStrategyName string                      `json:"pricing_strategy"`    // Optional: pricing strategy to use (standard, tiered, customer_level)
+	IsQuote             bool                        `json:"is_quote"`            // Create a quote instead of a draft order
+	QuoteExpiresAt      *time.Time                  `json:"quote_expires_at"`    // Optional quote expiry; defaults to the configured quote validity
+	AllowExpiredStock   bool                        `json:"allow_expired_stock"` // Allow shipping stock from expired batches
+	CreatedBy           *uuid.UUID                  `json:"-"`                   // Set from JWT context, not from request body
 }
 
 // CreateSalesOrderItemInput represents an item in the create order request
@@ -330,14 +391,19 @@ type CreateSalesOrderItemInput struct {
 	ConversionRate decimal.Decimal `json:"conversion_rate" binding:"required"` // Conversion rate to base unit (1 if using base unit)
 	UnitPrice      decimal.Decimal `json:"unit_price" binding:"required"`      // Final unit price (or override price)
 	BasePrice      decimal.Decimal `json:"base_price"`                         // Optional: base price before strategy calculation
+	DiscountType   string          `json:"discount_type"`                      // Optional line discount: percent or amount
+	DiscountValue  decimal.Decimal `json:"discount_value"`                     // Percentage or fixed amount off the line
 	Remark         string          `json:"remark"`
 }
 
 // UpdateSalesOrderRequest represents a request to update a sales order (only in DRAFT status)
 type UpdateSalesOrderRequest struct {
-	WarehouseID *uuid.UUID       `json:"warehouse_id"`
-	Discount    *decimal.Decimal `json:"discount"`
-	Remark      *string          `json:"remark"`
+	WarehouseID       *uuid.UUID       `json:"warehouse_id"`
+	ShippingAddressID *uuid.UUID       `json:"shipping_address_id"`
+	Discount          *decimal.Decimal `json:"discount"`
+	Remark            *string          `json:"remark"`
+	AllowExpiredStock *bool            `json:"allow_expired_stock"`
+	Version           *int             `json:"version"` // Version the client loaded; the update fails with a conflict if the order changed since
 }
 
 // AddOrderItemRequest represents a request to add an item to an order
@@ -350,14 +416,19 @@ type AddOrderItemRequest struct {
 	Quantity       decimal.Decimal `json:"quantity" binding:"required"`
 	ConversionRate decimal.Decimal `json:"conversion_rate" binding:"required"` // Conversion rate to base unit
 	UnitPrice      decimal.Decimal `json:"unit_price" binding:"required"`
+	DiscountType   string          `json:"discount_type"`  // Optional line discount: percent or amount
+	DiscountValue  decimal.Decimal `json:"discount_value"` // Percentage or fixed amount off the line
 	Remark         string          `json:"remark"`
 }
 
 // UpdateOrderItemRequest represents a request to update an order item
+// Setting either discount field replaces the line discount; an empty discount type removes it
 type UpdateOrderItemRequest struct {
-	Quantity  *decimal.Decimal `json:"quantity"`
-	UnitPrice *decimal.Decimal `json:"unit_price"`
-	Remark    *string          `json:"remark"`
+	Quantity      *decimal.Decimal `json:"quantity"`
+	UnitPrice     *decimal.Decimal `json:"unit_price"`
+	DiscountType  *string          `json:"discount_type"`
+	DiscountValue *decimal.Decimal `json:"discount_value"`
+	Remark        *string          `json:"remark"`
 }
 
 // ConfirmOrderRequest represents a request to confirm an order
@@ -365,9 +436,33 @@ type ConfirmOrderRequest struct {
 	WarehouseID *uuid.UUID `json:"warehouse_id"` // Optional warehouse override
 }
 
+// ConvertQuoteRequest represents a request to convert a quote into a draft order
+type ConvertQuoteRequest struct {
+	Reprice             bool   `json:"reprice"`          // Re-price every item with the current pricing strategy
+	PricingStrategyName string `json:"pricing_strategy"` // Pricing strategy used when re-pricing
+	CustomerLevel       string `json:"customer_level"`   // Customer level used when re-pricing
+}
+
 // ShipOrderRequest represents a request to ship an order
 type ShipOrderRequest struct {
 	WarehouseID *uuid.UUID `json:"warehouse_id"` // Optional warehouse override (must be set if not already)
+	// Items to ship for a partial shipment; everything not yet shipped when empty
+	Items []ShipOrderItemInput `json:"items"`
+	// Carrier and tracking number of the shipment, passed on to the sales channel
+	ShippingCompany string `json:"shipping_company"`
+	TrackingNumber  string `json:"tracking_number"`
+}
+
+// ShipOrderItemInput represents the quantity of an order item in a partial shipment
+type ShipOrderItemInput struct {
+	ItemID   uuid.UUID       `json:"item_id" binding:"required"`
+	Quantity decimal.Decimal `json:"quantity" binding:"required"`
+}
+
+// ApproveCreditOverrideRequest represents a request to let an order ship beyond the customer's credit limit
+type ApproveCreditOverrideRequest struct {
+	ApprovedBy uuid.UUID `json:"approved_by"`
+	Reason     string    `json:"reason" binding:"required,min=1,max=500"`
 }
 
 // CancelOrderRequest represents a request to cancel an order
@@ -394,71 +489,95 @@ type SalesOrderListFilter struct {
 
 // SalesOrderResponse represents a sales order in API responses
 type SalesOrderResponse struct {
-	ID             uuid.UUID                `json:"id"`
-	TenantID       uuid.UUID                `json:"tenant_id"`
-	OrderNumber    string                   `json:"order_number"`
-	CustomerID     uuid.UUID                `json:"customer_id"`
-	CustomerName   string                   `json:"customer_name"`
-	WarehouseID    *uuid.UUID               `json:"warehouse_id,omitempty"`
-	Items          []SalesOrderItemResponse `json:"items"`
-	ItemCount      int                      `json:"item_count"`
-	TotalQuantity  decimal.Decimal          `json:"total_quantity"`
-	TotalAmount    decimal.Decimal          `json:"total_amount"`
-	DiscountAmount decimal.Decimal          `json:"discount_amount"`
-	PayableAmount  decimal.Decimal          `json:"payable_amount"`
-	Status         string                   `json:"status"`
-	Remark         string                   `json:"remark"`
-	ConfirmedAt    *time.Time               `json:"confirmed_at,omitempty"`
-	ShippedAt      *time.Time               `json:"shipped_at,omitempty"`
-	CompletedAt    *time.Time               `json:"completed_at,omitempty"`
-	CancelledAt    *time.Time               `json:"cancelled_at,omitempty"`
-	CancelReason   string                   `json:"cancel_reason,omitempty"`
-	CreatedAt      time.Time                `json:"created_at"`
-	UpdatedAt      time.Time                `json:"updated_at"`
-	Version        int                      `json:"version"`
+	ID           uuid.UUID  `json:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	OrderNumber  string     `json:"order_number"`
+	CustomerID   uuid.UUID  `json:"customer_id"`
+	CustomerName string     `json:"customer_name"`
+	WarehouseID  *uuid.UUID `json:"warehouse_id,omitempty"`
+	// Customer shipping address the order is delivered to, omitted for the customer's default
+	ShippingAddressID *uuid.UUID               `json:"shipping_address_id,omitempty"`
+	Items             []SalesOrderItemResponse `json:"items"`
+	ItemCount         int                      `json:"item_count"`
+	TotalQuantity     decimal.Decimal          `json:"total_quantity"`
+	TotalAmount       decimal.Decimal          `json:"total_amount"`
+	DiscountAmount    decimal.Decimal          `json:"discount_amount"`
+	PayableAmount     decimal.Decimal          `json:"payable_amount"`
+	// Sum of line discounts, already taken off TotalAmount
+	LineDiscountAmount decimal.Decimal `json:"line_discount_amount"`
+	Status             string          `json:"status"`
+	Remark             string          `json:"remark"`
+	ConfirmedAt        *time.Time      `json:"confirmed_at,omitempty"`
+	FirstShippedAt     *time.Time      `json:"first_shipped_at,omitempty"`
+	ShippedAt          *time.Time      `json:"shipped_at,omitempty"`
+	FullyShippedAt     *time.Time      `json:"fully_shipped_at,omitempty"`
+	ShipmentCount      int             `json:"shipment_count"`
+	CompletedAt        *time.Time      `json:"completed_at,omitempty"`
+	CancelledAt        *time.Time      `json:"cancelled_at,omitempty"`
+	CancelReason       string          `json:"cancel_reason,omitempty"`
+	QuoteExpiresAt     *time.Time      `json:"quote_expires_at,omitempty"`
+	ConvertedAt        *time.Time      `json:"converted_at,omitempty"`
+	ExpiredAt          *time.Time      `json:"expired_at,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	Version            int             `json:"version"`
+
+	CreditOverrideApprovedBy *uuid.UUID `json:"credit_override_approved_by,omitempty"`
+	CreditOverrideApprovedAt *time.Time `json:"credit_override_approved_at,omitempty"`
+	CreditOverrideReason     string     `json:"credit_override_reason,omitempty"`
+
+	AllowExpiredStock bool `json:"allow_expired_stock"`
 }
 
 // SalesOrderListItemResponse represents a sales order in list responses (less detail)
 type SalesOrderListItemResponse struct {
-	ID            uuid.UUID       `json:"id"`
-	OrderNumber   string          `json:"order_number"`
-	CustomerID    uuid.UUID       `json:"customer_id"`
-	CustomerName  string          `json:"customer_name"`
-	WarehouseID   *uuid.UUID      `json:"warehouse_id,omitempty"`
-	ItemCount     int             `json:"item_count"`
-	TotalAmount   decimal.Decimal `json:"total_amount"`
-	PayableAmount decimal.Decimal `json:"payable_amount"`
-	Status        string          `json:"status"`
-	ConfirmedAt   *time.Time      `json:"confirmed_at,omitempty"`
-	ShippedAt     *time.Time      `json:"shipped_at,omitempty"`
-	CreatedAt     time.Time       `json:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at"`
+	ID             uuid.UUID       `json:"id"`
+	OrderNumber    string          `json:"order_number"`
+	CustomerID     uuid.UUID       `json:"customer_id"`
+	CustomerName   string          `json:"customer_name"`
+	WarehouseID    *uuid.UUID      `json:"warehouse_id,omitempty"`
+	ItemCount      int             `json:"item_count"`
+	TotalAmount    decimal.Decimal `json:"total_amount"`
+	PayableAmount  decimal.Decimal `json:"payable_amount"`
+	Status         string          `json:"status"`
+	ConfirmedAt    *time.Time      `json:"confirmed_at,omitempty"`
+	ShippedAt      *time.Time      `json:"shipped_at,omitempty"`
+	QuoteExpiresAt *time.Time      `json:"quote_expires_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
 }
 
 // SalesOrderItemResponse represents an order item in API responses
 type SalesOrderItemResponse struct {
-	ID          uuid.UUID       `json:"id"`
-	ProductID   uuid.UUID       `json:"product_id"`
-	ProductName string          `json:"product_name"`
-	ProductCode string          `json:"product_code"`
-	Quantity    decimal.Decimal `json:"quantity"`
-	UnitPrice   decimal.Decimal `json:"unit_price"`
-	Amount      decimal.Decimal `json:"amount"`
-	Unit        string          `json:"unit"`
-	Remark      string          `json:"remark,omitempty"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID                uuid.UUID       `json:"id"`
+	ProductID         uuid.UUID       `json:"product_id"`
+	ProductName       string          `json:"product_name"`
+	ProductCode       string          `json:"product_code"`
+	Quantity          decimal.Decimal `json:"quantity"`
+	ShippedQuantity   decimal.Decimal `json:"shipped_quantity"`
+	RemainingQuantity decimal.Decimal `json:"remaining_quantity"`
+	UnitPrice         decimal.Decimal `json:"unit_price"`
+	DiscountType      string          `json:"discount_type,omitempty"`
+	DiscountValue     decimal.Decimal `json:"discount_value"`
+	DiscountAmount    decimal.Decimal `json:"discount_amount"`
+	Amount            decimal.Decimal `json:"amount"`
+	Unit              string          `json:"unit"`
+	Remark            string          `json:"remark,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
 }
 
 // OrderStatusSummary represents a summary of orders by status
+// Quotes are not orders yet and are not counted
 type OrderStatusSummary struct {
-	Draft       int64           `json:"draft"`
-	Confirmed   int64           `json:"confirmed"`
-	Shipped     int64           `json:"shipped"`
-	Completed   int64           `json:"completed"`
-	Cancelled   int64           `json:"cancelled"`
-	Total       int64           `json:"total"`
-	TotalAmount decimal.Decimal `json:"total_amount"`
+	Draft            int64           `json:"draft"`
+	Confirmed        int64           `json:"confirmed"`
+	PartiallyShipped int64           `json:"partially_shipped"`
+	Shipped          int64           `json:"shipped"`
+	Completed        int64           `json:"completed"`
+	Cancelled        int64           `json:"cancelled"`
+	Total            int64           `json:"total"`
+	TotalAmount      decimal.Decimal `json:"total_amount"`
 }
 
 // ToSalesOrderResponse converts domain SalesOrder to response DTO
@@ -469,47 +588,61 @@ func ToSalesOrderResponse(order *trade.SalesOrder) SalesOrderResponse {
 	}
 
 	return SalesOrderResponse{
+		ID:                 order.ID,
+		TenantID:           order.TenantID,
+		OrderNumber:        order.OrderNumber,
+		CustomerID:         order.CustomerID,
+		CustomerName:       order.CustomerName,
+		WarehouseID:        order.WarehouseID,
+		ShippingAddressID:  order.ShippingAddressID,
+		Items:              items,
+		ItemCount:          order.ItemCount(),
+		TotalQuantity:      order.TotalQuantity(),
+		TotalAmount:        order.TotalAmount,
+		DiscountAmount:     order.DiscountAmount,
+		PayableAmount:      order.PayableAmount,
+		LineDiscountAmount: order.LineDiscountAmount(),
+		Status:             strings.ToLower(string(order.Status)),
+		Remark:             order.Remark,
+		ConfirmedAt:        order.ConfirmedAt,
+		FirstShippedAt:     order.FirstShippedAt,
+		ShippedAt:          order.ShippedAt,
+		FullyShippedAt:     order.FullyShippedAt,
+		ShipmentCount:      order.ShipmentCount,
+		CompletedAt:        order.CompletedAt,
+		CancelledAt:        order.CancelledAt,
+		CancelReason:       order.CancelReason,
+		QuoteExpiresAt:     order.QuoteExpiresAt,
+		ConvertedAt:        order.ConvertedAt,
+		ExpiredAt:          order.ExpiredAt,
+		CreatedAt:          order.CreatedAt,
+		UpdatedAt:          order.UpdatedAt,
+		Version:            order.Version,
+
+		CreditOverrideApprovedBy: order.CreditOverrideApprovedBy,
+		CreditOverrideApprovedAt: order.CreditOverrideApprovedAt,
+		CreditOverrideReason:     order.CreditOverrideReason,
+		AllowExpiredStock:        order.AllowExpiredStock,
+	}
+}
+
+// ToSalesOrderListItemResponse converts domain SalesOrder to list response DTO
+func ToSalesOrderListItemResponse(order *trade.SalesOrder) SalesOrderListItemResponse {
+	return SalesOrderListItemResponse{
 		ID:             order.ID,
-		TenantID:       order.TenantID,
 		OrderNumber:    order.OrderNumber,
 		CustomerID:     order.CustomerID,
 		CustomerName:   order.CustomerName,
 		WarehouseID:    order.WarehouseID,
-		Items:          items,
 		ItemCount:      order.ItemCount(),
-		TotalQuantity:  order.TotalQuantity(),
 		TotalAmount:    order.TotalAmount,
-		DiscountAmount: order.DiscountAmount,
 		PayableAmount:  order.PayableAmount,
 		Status:         strings.ToLower(string(order.Status)),
-		Remark:         order.Remark,
 		ConfirmedAt:    order.ConfirmedAt,
 		ShippedAt:      order.ShippedAt,
-		CompletedAt:    order.CompletedAt,
-		CancelledAt:    order.CancelledAt,
-		CancelReason:   order.CancelReason,
+		QuoteExpiresAt: order.QuoteExpiresAt,
 		CreatedAt:      order.CreatedAt,
 		UpdatedAt:      order.UpdatedAt,
-		Version:        order.Version,
-	}
-}
-
-// ToSalesOrderListItemResponse converts domain SalesOrder to list response DTO
-func ToSalesOrderListItemResponse(order *trade.SalesOrder) SalesOrderListItemResponse {
-	return SalesOrderListItemResponse{
-		ID:            order.ID,
-		OrderNumber:   order.OrderNumber,
-		CustomerID:    order.CustomerID,
-		CustomerName:  order.CustomerName,
-		WarehouseID:   order.WarehouseID,
-		ItemCount:     order.ItemCount(),
-		TotalAmount:   order.TotalAmount,
-		PayableAmount: order.PayableAmount,
-		Status:        strings.ToLower(string(order.Status)),
-		ConfirmedAt:   order.ConfirmedAt,
-		ShippedAt:     order.ShippedAt,
-		CreatedAt:     order.CreatedAt,
-		UpdatedAt:     order.UpdatedAt,
 	}
 }
 
@@ -525,17 +658,22 @@ func ToSalesOrderListItemResponses(orders []trade.SalesOrder) []SalesOrderListIt
 // ToSalesOrderItemResponse converts domain SalesOrderItem to response DTO
 func ToSalesOrderItemResponse(item *trade.SalesOrderItem) SalesOrderItemResponse {
 	return SalesOrderItemResponse{
-		ID:          item.ID,
-		ProductID:   item.ProductID,
-		ProductName: item.ProductName,
-		ProductCode: item.ProductCode,
-		Quantity:    item.Quantity,
-		UnitPrice:   item.UnitPrice,
-		Amount:      item.Amount,
-		Unit:        item.Unit,
-		Remark:      item.Remark,
-		CreatedAt:   item.CreatedAt,
-		UpdatedAt:   item.UpdatedAt,
+		ID:                item.ID,
+		ProductID:         item.ProductID,
+		ProductName:       item.ProductName,
+		ProductCode:       item.ProductCode,
+		Quantity:          item.Quantity,
+		ShippedQuantity:   item.ShippedQuantity,
+		RemainingQuantity: item.RemainingQuantity(),
+		UnitPrice:         item.UnitPrice,
+		DiscountType:      strings.ToLower(string(item.DiscountType)),
+		DiscountValue:     item.DiscountValue,
+		DiscountAmount:    item.DiscountAmount,
+		Amount:            item.Amount,
+		Unit:              item.Unit,
+		Remark:            item.Remark,
+		CreatedAt:         item.CreatedAt,
+		UpdatedAt:         item.UpdatedAt,
 	}
 }
 
@@ -546,7 +684,8 @@ type CreateSalesReturnRequest struct {
 	SalesOrderID uuid.UUID                    `json:"sales_order_id" binding:"required"`
 	WarehouseID  *uuid.UUID                   `json:"warehouse_id"`
 	Items        []CreateSalesReturnItemInput `json:"items" binding:"required,min=1"`
-	Reason       string                       `json:"reason"`
+	ReasonCode   string                       `json:"reason_code" binding:"required"` // damaged, defective, wrong_item, etc.
+	Reason       string                       `json:"reason"`                         // Optional note on the reason
 	Remark       string                       `json:"remark"`
 	CreatedBy    *uuid.UUID                   `json:"-"` // Set from JWT context, not from request body
 }
@@ -562,6 +701,7 @@ type CreateSalesReturnItemInput struct {
 // UpdateSalesReturnRequest represents a request to update a sales return (only in DRAFT status)
 type UpdateSalesReturnRequest struct {
 	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	ReasonCode  *string    `json:"reason_code"`
 	Reason      *string    `json:"reason"`
 	Remark      *string    `json:"remark"`
 }
@@ -639,6 +779,7 @@ type SalesReturnResponse struct {
 	TotalQuantity    decimal.Decimal           `json:"total_quantity"`
 	TotalRefund      decimal.Decimal           `json:"total_refund"`
 	Status           string                    `json:"status"`
+	ReasonCode       string                    `json:"reason_code"`
 	Reason           string                    `json:"reason,omitempty"`
 	Remark           string                    `json:"remark,omitempty"`
 	SubmittedAt      *time.Time                `json:"submitted_at,omitempty"`
@@ -669,6 +810,7 @@ type SalesReturnListItemResponse struct {
 	ItemCount        int             `json:"item_count"`
 	TotalRefund      decimal.Decimal `json:"total_refund"`
 	Status           string          `json:"status"`
+	ReasonCode       string          `json:"reason_code"`
 	SubmittedAt      *time.Time      `json:"submitted_at,omitempty"`
 	ApprovedAt       *time.Time      `json:"approved_at,omitempty"`
 	ReceivedAt       *time.Time      `json:"received_at,omitempty"`
@@ -733,6 +875,7 @@ func ToSalesReturnResponse(sr *trade.SalesReturn) SalesReturnResponse {
 		TotalQuantity:    sr.TotalReturnQuantity(),
 		TotalRefund:      sr.TotalRefund,
 		Status:           strings.ToLower(string(sr.Status)),
+		ReasonCode:       strings.ToLower(string(sr.ReasonCode)),
 		Reason:           sr.Reason,
 		Remark:           sr.Remark,
 		SubmittedAt:      sr.SubmittedAt,
@@ -765,6 +908,7 @@ func ToSalesReturnListItemResponse(sr *trade.SalesReturn) SalesReturnListItemRes
 		ItemCount:        sr.ItemCount(),
 		TotalRefund:      sr.TotalRefund,
 		Status:           strings.ToLower(string(sr.Status)),
+		ReasonCode:       strings.ToLower(string(sr.ReasonCode)),
 		SubmittedAt:      sr.SubmittedAt,
 		ApprovedAt:       sr.ApprovedAt,
 		ReceivedAt:       sr.ReceivedAt,
@@ -813,7 +957,8 @@ type CreatePurchaseReturnRequest struct {
 	PurchaseOrderID uuid.UUID                       `json:"purchase_order_id" binding:"required"`
 	WarehouseID     *uuid.UUID                      `json:"warehouse_id"`
 	Items           []CreatePurchaseReturnItemInput `json:"items" binding:"required,min=1"`
-	Reason          string                          `json:"reason"`
+	ReasonCode      string                          `json:"reason_code" binding:"required"` // defective, wrong_item, quality, etc.
+	Reason          string                          `json:"reason"`                         // Optional note on the reason
 	Remark          string                          `json:"remark"`
 	CreatedBy       *uuid.UUID                      `json:"-"` // Set from JWT context, not from request body
 }
@@ -830,6 +975,7 @@ type CreatePurchaseReturnItemInput struct {
 // UpdatePurchaseReturnRequest represents a request to update a purchase return (only in DRAFT status)
 type UpdatePurchaseReturnRequest struct {
 	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	ReasonCode  *string    `json:"reason_code"`
 	Reason      *string    `json:"reason"`
 	Remark      *string    `json:"remark"`
 }
@@ -910,6 +1056,7 @@ type PurchaseReturnResponse struct {
 	TotalQuantity       decimal.Decimal              `json:"total_quantity"`
 	TotalRefund         decimal.Decimal              `json:"total_refund"`
 	Status              string                       `json:"status"`
+	ReasonCode          string                       `json:"reason_code"`
 	Reason              string                       `json:"reason,omitempty"`
 	Remark              string                       `json:"remark,omitempty"`
 	SubmittedAt         *time.Time                   `json:"submitted_at,omitempty"`
@@ -943,6 +1090,7 @@ type PurchaseReturnListItemResponse struct {
 	ItemCount           int             `json:"item_count"`
 	TotalRefund         decimal.Decimal `json:"total_refund"`
 	Status              string          `json:"status"`
+	ReasonCode          string          `json:"reason_code"`
 	SubmittedAt         *time.Time      `json:"submitted_at,omitempty"`
 	ApprovedAt          *time.Time      `json:"approved_at,omitempty"`
 	ShippedAt           *time.Time      `json:"shipped_at,omitempty"`
@@ -1010,6 +1158,7 @@ func ToPurchaseReturnResponse(pr *trade.PurchaseReturn) PurchaseReturnResponse {
 		TotalQuantity:       pr.TotalReturnQuantity(),
 		TotalRefund:         pr.TotalRefund,
 		Status:              strings.ToLower(string(pr.Status)),
+		ReasonCode:          strings.ToLower(string(pr.ReasonCode)),
 		Reason:              pr.Reason,
 		Remark:              pr.Remark,
 		SubmittedAt:         pr.SubmittedAt,
@@ -1045,6 +1194,7 @@ func ToPurchaseReturnListItemResponse(pr *trade.PurchaseReturn) PurchaseReturnLi
 		ItemCount:           pr.ItemCount(),
 		TotalRefund:         pr.TotalRefund,
 		Status:              strings.ToLower(string(pr.Status)),
+		ReasonCode:          strings.ToLower(string(pr.ReasonCode)),
 		SubmittedAt:         pr.SubmittedAt,
 		ApprovedAt:          pr.ApprovedAt,
 		ShippedAt:           pr.ShippedAt,
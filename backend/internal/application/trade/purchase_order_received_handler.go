@@ -77,6 +77,8 @@ func (h *PurchaseOrderReceivedHandler) Handle(ctx context.Context, event shared.
 			ExpiryDate:  item.ExpiryDate,
 			Reference:   fmt.Sprintf("PO:%s", receivedEvent.OrderNumber),
 			Reason:      "Purchase order receiving",
+			// Purchase receipts do not carry serial numbers
+			SkipSerialNumbers: true,
 		}
 
 		if _, err := h.inventoryService.IncreaseStock(ctx, event.TenantID(), req); err != nil {
@@ -518,6 +518,80 @@ func (s *PurchaseOrderService) Cancel(ctx context.Context, tenantID, orderID uui
 	return &response, nil
 }
 
+// RequestAmendment proposes line changes for a confirmed purchase order.
+// The order stays in PENDING_AMENDMENT until the amendment is approved or rejected.
+func (s *PurchaseOrderService) RequestAmendment(ctx context.Context, tenantID, orderID, userID uuid.UUID, req RequestPurchaseOrderAmendmentRequest) (*PurchaseOrderResponse, error) {
+	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]trade.PurchaseOrderAmendmentLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = trade.PurchaseOrderAmendmentLine{
+			ItemID:   line.ItemID,
+			Quantity: line.Quantity,
+			UnitCost: line.UnitCost,
+		}
+	}
+
+	if err := order.RequestAmendment(lines, req.Reason, userID); err != nil {
+		return nil, err
+	}
+
+	// Save with optimistic locking
+	if err := s.orderRepo.SaveWithLock(ctx, order); err != nil {
+		return nil, err
+	}
+
+	response := ToPurchaseOrderResponse(order)
+	return &response, nil
+}
+
+// ApproveAmendment applies the pending amendment of a purchase order and recalculates its totals
+func (s *PurchaseOrderService) ApproveAmendment(ctx context.Context, tenantID, orderID, userID uuid.UUID) (*PurchaseOrderResponse, error) {
+	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.ApproveAmendment(userID); err != nil {
+		return nil, err
+	}
+
+	// Collect domain events before save
+	events := order.GetDomainEvents()
+	order.ClearDomainEvents()
+
+	// Save with optimistic locking and events atomically (transactional outbox pattern)
+	if err := s.orderRepo.SaveWithLockAndEvents(ctx, order, events); err != nil {
+		return nil, err
+	}
+
+	response := ToPurchaseOrderResponse(order)
+	return &response, nil
+}
+
+// RejectAmendment discards the pending amendment of a purchase order, leaving its lines unchanged
+func (s *PurchaseOrderService) RejectAmendment(ctx context.Context, tenantID, orderID uuid.UUID, req RejectPurchaseOrderAmendmentRequest) (*PurchaseOrderResponse, error) {
+	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.RejectAmendment(req.Reason); err != nil {
+		return nil, err
+	}
+
+	// Save with optimistic locking
+	if err := s.orderRepo.SaveWithLock(ctx, order); err != nil {
+		return nil, err
+	}
+
+	response := ToPurchaseOrderResponse(order)
+	return &response, nil
+}
+
 // Delete deletes a purchase order (only allowed in DRAFT status)
 func (s *PurchaseOrderService) Delete(ctx context.Context, tenantID, orderID uuid.UUID) error {
 	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
@@ -544,6 +618,11 @@ func (s *PurchaseOrderService) GetStatusSummary(ctx context.Context, tenantID uu
 		return nil, err
 	}
 
+	pendingAmendment, err := s.orderRepo.CountByStatus(ctx, tenantID, trade.PurchaseOrderStatusPendingAmendment)
+	if err != nil {
+		return nil, err
+	}
+
 	partialReceived, err := s.orderRepo.CountByStatus(ctx, tenantID, trade.PurchaseOrderStatusPartialReceived)
 	if err != nil {
 		return nil, err
@@ -565,13 +644,14 @@ func (s *PurchaseOrderService) GetStatusSummary(ctx context.Context, tenantID uu
 	}
 
 	return &PurchaseOrderStatusSummary{
-		Draft:           draft,
-		Confirmed:       confirmed,
-		PartialReceived: partialReceived,
-		Completed:       completed,
-		Cancelled:       cancelled,
-		Total:           draft + confirmed + partialReceived + completed + cancelled,
-		PendingReceipt:  pendingReceipt,
+		Draft:            draft,
+		Confirmed:        confirmed,
+		PendingAmendment: pendingAmendment,
+		PartialReceived:  partialReceived,
+		Completed:        completed,
+		Cancelled:        cancelled,
+		Total:            draft + confirmed + pendingAmendment + partialReceived + completed + cancelled,
+		PendingReceipt:   pendingReceipt,
 	}, nil
 }
 
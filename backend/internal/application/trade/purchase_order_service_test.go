@@ -13,6 +13,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockPurchaseOrderRepository is a mock implementation of PurchaseOrderRepository
@@ -777,6 +778,99 @@ func TestPurchaseOrderService_Cancel(t *testing.T) {
 	})
 }
 
+// Tests for the amendment workflow
+func TestPurchaseOrderService_Amendment(t *testing.T) {
+	requesterID := uuid.New()
+	approverID := uuid.New()
+
+	amendmentRequest := func(order *trade.PurchaseOrder) RequestPurchaseOrderAmendmentRequest {
+		quantity := decimal.NewFromInt(12)
+		unitCost := decimal.NewFromInt(90)
+		return RequestPurchaseOrderAmendmentRequest{
+			Lines: []PurchaseOrderAmendmentLineInput{
+				{ItemID: order.Items[0].ID, Quantity: &quantity, UnitCost: &unitCost},
+			},
+			Reason: "Supplier price change",
+		}
+	}
+
+	t.Run("amend before receipt is approved and applied", func(t *testing.T) {
+		repo := new(MockPurchaseOrderRepository)
+		service := NewPurchaseOrderService(repo)
+		ctx := context.Background()
+
+		order := createConfirmedPurchaseOrder()
+		order.ClearDomainEvents()
+		repo.On("FindByIDForTenant", mock.Anything, testPOTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		requested, err := service.RequestAmendment(ctx, testPOTenantID, order.ID, requesterID, amendmentRequest(order))
+		require.NoError(t, err)
+		assert.Equal(t, "pending_amendment", requested.Status)
+		require.NotNil(t, requested.PendingAmendment)
+		assert.Equal(t, requesterID, requested.PendingAmendment.RequestedBy)
+		assert.True(t, decimal.NewFromInt(1000).Equal(requested.TotalAmount))
+
+		var savedEvents []shared.DomainEvent
+		repo.On("SaveWithLockAndEvents", mock.Anything, order, mock.Anything).Run(func(args mock.Arguments) {
+			savedEvents = args.Get(2).([]shared.DomainEvent)
+		}).Return(nil)
+
+		approved, err := service.ApproveAmendment(ctx, testPOTenantID, order.ID, approverID)
+		require.NoError(t, err)
+		assert.Equal(t, "confirmed", approved.Status)
+		assert.Nil(t, approved.PendingAmendment)
+		assert.True(t, decimal.NewFromInt(1080).Equal(approved.TotalAmount))
+		assert.True(t, decimal.NewFromInt(1080).Equal(approved.PayableAmount))
+
+		require.Len(t, savedEvents, 1)
+		event, ok := savedEvents[0].(*trade.PurchaseOrderAmendedEvent)
+		require.True(t, ok)
+		assert.Equal(t, approverID, event.ApprovedBy)
+		assert.Empty(t, order.GetDomainEvents())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("amend after partial receipt is rejected", func(t *testing.T) {
+		repo := new(MockPurchaseOrderRepository)
+		service := NewPurchaseOrderService(repo)
+		ctx := context.Background()
+
+		order := createConfirmedPurchaseOrder()
+		_, err := order.Receive([]trade.ReceiveItem{{ProductID: testPOProductID, Quantity: decimal.NewFromInt(5)}})
+		require.NoError(t, err)
+		repo.On("FindByIDForTenant", mock.Anything, testPOTenantID, order.ID).Return(order, nil)
+
+		result, err := service.RequestAmendment(ctx, testPOTenantID, order.ID, requesterID, amendmentRequest(order))
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, trade.PurchaseOrderStatusPartialReceived, order.Status)
+		repo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejected amendment leaves the order unchanged", func(t *testing.T) {
+		repo := new(MockPurchaseOrderRepository)
+		service := NewPurchaseOrderService(repo)
+		ctx := context.Background()
+
+		order := createConfirmedPurchaseOrder()
+		require.NoError(t, order.RequestAmendment([]trade.PurchaseOrderAmendmentLine{
+			{ItemID: order.Items[0].ID, Quantity: amendmentRequest(order).Lines[0].Quantity},
+		}, "More units", requesterID))
+		repo.On("FindByIDForTenant", mock.Anything, testPOTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		result, err := service.RejectAmendment(ctx, testPOTenantID, order.ID, RejectPurchaseOrderAmendmentRequest{Reason: "Budget exceeded"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "confirmed", result.Status)
+		assert.Nil(t, result.PendingAmendment)
+		assert.True(t, decimal.NewFromInt(10).Equal(result.Items[0].OrderedQuantity))
+		repo.AssertExpectations(t)
+	})
+}
+
 // Tests for Delete
 func TestPurchaseOrderService_Delete(t *testing.T) {
 	t.Run("delete draft order successfully", func(t *testing.T) {
@@ -818,6 +912,7 @@ func TestPurchaseOrderService_GetStatusSummary(t *testing.T) {
 
 		repo.On("CountByStatus", ctx, testPOTenantID, trade.PurchaseOrderStatusDraft).Return(int64(5), nil)
 		repo.On("CountByStatus", ctx, testPOTenantID, trade.PurchaseOrderStatusConfirmed).Return(int64(10), nil)
+		repo.On("CountByStatus", ctx, testPOTenantID, trade.PurchaseOrderStatusPendingAmendment).Return(int64(1), nil)
 		repo.On("CountByStatus", ctx, testPOTenantID, trade.PurchaseOrderStatusPartialReceived).Return(int64(3), nil)
 		repo.On("CountByStatus", ctx, testPOTenantID, trade.PurchaseOrderStatusCompleted).Return(int64(100), nil)
 		repo.On("CountByStatus", ctx, testPOTenantID, trade.PurchaseOrderStatusCancelled).Return(int64(2), nil)
@@ -829,10 +924,11 @@ func TestPurchaseOrderService_GetStatusSummary(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, int64(5), result.Draft)
 		assert.Equal(t, int64(10), result.Confirmed)
+		assert.Equal(t, int64(1), result.PendingAmendment)
 		assert.Equal(t, int64(3), result.PartialReceived)
 		assert.Equal(t, int64(100), result.Completed)
 		assert.Equal(t, int64(2), result.Cancelled)
-		assert.Equal(t, int64(120), result.Total)
+		assert.Equal(t, int64(121), result.Total)
 		assert.Equal(t, int64(13), result.PendingReceipt)
 		repo.AssertExpectations(t)
 	})
@@ -83,6 +83,11 @@ func (s *PurchaseReturnService) Create(ctx context.Context, tenantID uuid.UUID,
 		}
 	}
 
+	// The reason code is required; the free-text reason is an optional note
+	if err := pr.SetReasonCode(toReturnReasonCode(req.ReasonCode)); err != nil {
+		return nil, err
+	}
+
 	// Set optional fields
 	if req.Reason != "" {
 		pr.SetReason(req.Reason)
@@ -242,6 +247,13 @@ func (s *PurchaseReturnService) Update(ctx context.Context, tenantID, returnID u
 		}
 	}
 
+	// Update reason code if provided
+	if req.ReasonCode != nil {
+		if err := pr.SetReasonCode(toReturnReasonCode(*req.ReasonCode)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update reason if provided
 	if req.Reason != nil {
 		pr.SetReason(*req.Reason)
@@ -79,6 +79,8 @@ func (h *PurchaseReturnShippedHandler) Handle(ctx context.Context, event shared.
 			SourceID:    shippedEvent.ReturnID.String(),
 			Reference:   reference,
 			Reason:      fmt.Sprintf("Purchase return shipped: %s", shippedEvent.ReturnNumber),
+			// Purchase returns do not carry serial numbers
+			SkipSerialNumbers: true,
 		}
 
 		err := h.inventoryService.DecreaseStock(ctx, event.TenantID(), req)
@@ -3,6 +3,8 @@ package trade
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/strategy"
@@ -29,19 +31,53 @@ type ProductSaleValidator interface {
 	CanBeSold(ctx context.Context, tenantID, productID uuid.UUID) (bool, error)
 }
 
+// CustomerCreditChecker checks whether a customer can take on more receivables
+// This interface allows the trade context to enforce credit limits without
+// directly depending on the partner and finance domains
+type CustomerCreditChecker interface {
+	// CheckCredit returns a *trade.CreditLimitExceededError if the customer's outstanding
+	// receivables plus orderAmount would exceed their credit limit.
+	// Implementations return nil when credit control is disabled for the tenant.
+	CheckCredit(ctx context.Context, tenantID, customerID uuid.UUID, orderAmount decimal.Decimal) error
+}
+
+// BatchExpiryChecker checks that shipped stock does not come from batches that are too close to expiry
+// This interface allows the trade context to block shipments without depending on the inventory service
+type BatchExpiryChecker interface {
+	// CheckBatchExpiry returns an error naming the batch and its expiry if shipping the quantity of
+	// a product from the warehouse on shipDate would take stock from a batch that expires too soon
+	CheckBatchExpiry(ctx context.Context, tenantID, warehouseID, productID uuid.UUID, quantity decimal.Decimal, shipDate time.Time) error
+}
+
+// ShippingAddressChecker checks that a shipping address can be used for a customer's order
+// This interface allows the trade context to reference addresses without
+// directly depending on the partner domain
+type ShippingAddressChecker interface {
+	// CheckShippingAddress returns an error if the address is not a shipping address of the customer
+	CheckShippingAddress(ctx context.Context, tenantID, customerID, addressID uuid.UUID) error
+}
+
 // SalesOrderService handles sales order business operations
 type SalesOrderService struct {
 	orderRepo        trade.SalesOrderRepository
 	eventPublisher   shared.EventPublisher
 	pricingProvider  PricingStrategyProvider
 	productValidator ProductSaleValidator
+	creditChecker    CustomerCreditChecker
+	expiryChecker    BatchExpiryChecker
+	addressChecker   ShippingAddressChecker
 	businessMetrics  *telemetry.BusinessMetrics
+	quoteValidity    time.Duration
 }
 
+// DefaultQuoteValidity is how long a quote stays valid when no expiry is given
+const DefaultQuoteValidity = 30 * 24 * time.Hour
+
 // NewSalesOrderService creates a new SalesOrderService
 func NewSalesOrderService(orderRepo trade.SalesOrderRepository) *SalesOrderService {
 	return &SalesOrderService{
-		orderRepo: orderRepo,
+		orderRepo:     orderRepo,
+		quoteValidity: DefaultQuoteValidity,
 	}
 }
 
@@ -60,11 +96,43 @@ func (s *SalesOrderService) SetProductValidator(validator ProductSaleValidator)
 	s.productValidator = validator
 }
 
+// SetCreditChecker sets the credit checker used to enforce customer credit limits on ship
+func (s *SalesOrderService) SetCreditChecker(checker CustomerCreditChecker) {
+	s.creditChecker = checker
+}
+
+// SetBatchExpiryChecker sets the checker used to block shipping stock from expired batches
+func (s *SalesOrderService) SetBatchExpiryChecker(checker BatchExpiryChecker) {
+	s.expiryChecker = checker
+}
+
+// SetShippingAddressChecker sets the checker used to validate the shipping address of orders
+func (s *SalesOrderService) SetShippingAddressChecker(checker ShippingAddressChecker) {
+	s.addressChecker = checker
+}
+
+// SetQuoteValidity sets how long new quotes stay valid when no expiry is given
+func (s *SalesOrderService) SetQuoteValidity(validity time.Duration) {
+	if validity > 0 {
+		s.quoteValidity = validity
+	}
+}
+
 // SetBusinessMetrics sets the business metrics collector
 func (s *SalesOrderService) SetBusinessMetrics(bm *telemetry.BusinessMetrics) {
 	s.businessMetrics = bm
 }
 
+// setShippingAddress sets the order's shipping address after checking it belongs to the order's customer
+func (s *SalesOrderService) setShippingAddress(ctx context.Context, order *trade.SalesOrder, addressID *uuid.UUID) error {
+	if s.addressChecker != nil {
+		if err := s.addressChecker.CheckShippingAddress(ctx, order.TenantID, order.CustomerID, *addressID); err != nil {
+			return err
+		}
+	}
+	return order.SetShippingAddress(addressID)
+}
+
 // validateProductForSale validates that a product can be sold
 // Returns an error if the product is disabled or not found
 func (s *SalesOrderService) validateProductForSale(ctx context.Context, tenantID, productID uuid.UUID, productCode string) error {
@@ -86,6 +154,41 @@ func (s *SalesOrderService) validateProductForSale(ctx context.Context, tenantID
 	return nil
 }
 
+// checkCreditLimit verifies that shipping the order keeps the customer within their credit limit
+// Orders with an approved credit override skip the check
+func (s *SalesOrderService) checkCreditLimit(ctx context.Context, order *trade.SalesOrder, shipItems []trade.ShipItem) error {
+	if s.creditChecker == nil || order.HasCreditOverride() {
+		return nil
+	}
+
+	// Only the amount of this shipment is added to the customer's outstanding balance
+	amount, err := order.ShipmentPayableAmount(shipItems)
+	if err != nil {
+		return err
+	}
+	return s.creditChecker.CheckCredit(ctx, order.TenantID, order.CustomerID, amount)
+}
+
+// checkBatchExpiry verifies that the shipment does not take stock from batches that expire
+// before the ship date plus the tenant's buffer. Orders allowing expired stock skip the check.
+func (s *SalesOrderService) checkBatchExpiry(ctx context.Context, order *trade.SalesOrder, shipItems []trade.ShipItem, shipDate time.Time) error {
+	if s.expiryChecker == nil || order.AllowExpiredStock || order.WarehouseID == nil {
+		return nil
+	}
+
+	for _, shipItem := range shipItems {
+		item := order.GetItem(shipItem.ItemID)
+		if item == nil {
+			// Unknown items are rejected when the order is shipped
+			continue
+		}
+		if err := s.expiryChecker.CheckBatchExpiry(ctx, order.TenantID, *order.WarehouseID, item.ProductID, shipItem.Quantity, shipDate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // calculateItemPrice calculates the unit price for an item using the pricing strategy
 // If no strategy is configured or if UseProvidedPrice is true, it uses the provided price
 func (s *SalesOrderService) calculateItemPrice(
@@ -137,6 +240,48 @@ func (s *SalesOrderService) calculateItemPrice(
 	return result.UnitPrice
 }
 
+// tieredPricingStrategyName is the pricing strategy that prices lines by the quantity breaks of their product
+const tieredPricingStrategyName = "tiered"
+
+// priceByQuantity prices a line with the tiered pricing strategy once its quantity is set.
+// basePrice applies below the smallest tier; zero uses the product's selling price.
+// Returns false, leaving the line price as it is, when there is no tiered strategy, it applied
+// no rule, e.g. because the product has no price tiers, or the tier price is not below basePrice,
+// so a tier never raises a price agreed with the customer.
+func (s *SalesOrderService) priceByQuantity(
+	ctx context.Context,
+	tenantID, productID uuid.UUID,
+	quantity, basePrice decimal.Decimal,
+) (decimal.Decimal, bool) {
+	if s.pricingProvider == nil {
+		return decimal.Zero, false
+	}
+	pricingStrategy, err := s.pricingProvider.GetPricingStrategy(tieredPricingStrategyName)
+	if err != nil || pricingStrategy == nil {
+		return decimal.Zero, false
+	}
+
+	result, err := pricingStrategy.CalculatePrice(ctx, strategy.PricingContext{
+		TenantID:  tenantID.String(),
+		ProductID: productID.String(),
+		Quantity:  quantity,
+		BasePrice: basePrice,
+		Currency:  "CNY",
+	})
+	if err != nil || len(result.AppliedRules) == 0 {
+		return decimal.Zero, false
+	}
+	if !basePrice.IsZero() && !result.UnitPrice.LessThan(basePrice) {
+		return decimal.Zero, false
+	}
+	return result.UnitPrice, true
+}
+
+// toLineDiscountType converts a request discount type (percent or amount, any case) to the domain type
+func toLineDiscountType(discountType string) trade.LineDiscountType {
+	return trade.LineDiscountType(strings.ToUpper(discountType))
+}
+
 // Create creates a new sales order
 func (s *SalesOrderService) Create(ctx context.Context, tenantID uuid.UUID, req CreateSalesOrderRequest) (*SalesOrderResponse, error) {
 	// Start tracing span for order creation flow
@@ -162,8 +307,17 @@ func (s *SalesOrderService) Create(ctx context.Context, tenantID uuid.UUID, req
 		}
 		telemetry.SetAttribute(span, telemetry.SpanAttrOrderNumber, orderNumber)
 
-		// Create order
-		order, err := trade.NewSalesOrder(tenantID, orderNumber, req.CustomerID, req.CustomerName)
+		// Create order, or a quote that does not affect inventory until it is converted
+		var order *trade.SalesOrder
+		if req.IsQuote {
+			expiresAt := time.Now().Add(s.quoteValidity)
+			if req.QuoteExpiresAt != nil {
+				expiresAt = *req.QuoteExpiresAt
+			}
+			order, err = trade.NewSalesQuote(tenantID, orderNumber, req.CustomerID, req.CustomerName, expiresAt)
+		} else {
+			order, err = trade.NewSalesOrder(tenantID, orderNumber, req.CustomerID, req.CustomerName)
+		}
 		if err != nil {
 			telemetry.RecordError(span, err)
 			createErr = err
@@ -179,6 +333,15 @@ func (s *SalesOrderService) Create(ctx context.Context, tenantID uuid.UUID, req
 			}
 		}
 
+		// Set shipping address if provided
+		if req.ShippingAddressID != nil {
+			if err := s.setShippingAddress(c, order, req.ShippingAddressID); err != nil {
+				telemetry.RecordError(span, err)
+				createErr = err
+				return
+			}
+		}
+
 		// Add items
 		for _, item := range req.Items {
 			// Validate product can be sold (not disabled/discontinued)
@@ -188,8 +351,19 @@ func (s *SalesOrderService) Create(ctx context.Context, tenantID uuid.UUID, req
 				return
 			}
 
-			// Calculate unit price using pricing strategy if configured
+			// Calculate unit price using pricing strategy if configured,
+			// otherwise by the quantity breaks of the product
 			calculatedUnitPrice := s.calculateItemPrice(c, tenantID, item, req.CustomerLevel, req.PricingStrategyName)
+			listPrice := calculatedUnitPrice
+			if req.PricingStrategyName == "" {
+				basePrice := item.UnitPrice
+				if item.BasePrice.GreaterThan(decimal.Zero) {
+					basePrice = item.BasePrice
+				}
+				if price, ok := s.priceByQuantity(c, tenantID, item.ProductID, item.Quantity, basePrice); ok {
+					calculatedUnitPrice = price
+				}
+			}
 			unitPrice := valueobject.NewMoneyCNY(calculatedUnitPrice)
 			orderItem, err := order.AddItem(
 				item.ProductID,
@@ -206,9 +380,17 @@ func (s *SalesOrderService) Create(ctx context.Context, tenantID uuid.UUID, req
 				createErr = err
 				return
 			}
+			if item.DiscountType != "" {
+				if err := order.SetItemDiscount(orderItem.ID, toLineDiscountType(item.DiscountType), item.DiscountValue); err != nil {
+					telemetry.RecordError(span, err)
+					createErr = err
+					return
+				}
+			}
 			if item.Remark != "" {
 				orderItem.SetRemark(item.Remark)
 			}
+			order.GetItem(orderItem.ID).SetListPrice(listPrice)
 		}
 
 		// Apply discount if provided
@@ -226,6 +408,15 @@ func (s *SalesOrderService) Create(ctx context.Context, tenantID uuid.UUID, req
 			order.SetRemark(req.Remark)
 		}
 
+		// Allow shipping stock from expired batches
+		if req.AllowExpiredStock {
+			if err := order.SetAllowExpiredStock(true); err != nil {
+				telemetry.RecordError(span, err)
+				createErr = err
+				return
+			}
+		}
+
 		// Set created_by if provided (from JWT context via handler)
 		if req.CreatedBy != nil {
 			order.SetCreatedBy(*req.CreatedBy)
@@ -238,8 +429,8 @@ func (s *SalesOrderService) Create(ctx context.Context, tenantID uuid.UUID, req
 			return
 		}
 
-		// Record business metrics
-		if s.businessMetrics != nil {
+		// Record business metrics (quotes are recorded once converted)
+		if s.businessMetrics != nil && !order.IsQuote() {
 			s.businessMetrics.RecordOrderWithAmount(c, tenantID, telemetry.OrderTypeSales, order.TotalAmount)
 		}
 
@@ -362,9 +553,12 @@ func (s *SalesOrderService) Update(ctx context.Context, tenantID, orderID uuid.U
 	if err != nil {
 		return nil, err
 	}
+	if err := shared.CheckVersion("sales order", req.Version, order.Version); err != nil {
+		return nil, err
+	}
 
 	if !order.CanModify() {
-		return nil, shared.NewDomainError("INVALID_STATE", "Order can only be modified in draft status")
+		return nil, shared.NewDomainError("INVALID_STATE", "Order can only be modified in quote or draft status")
 	}
 
 	// Update warehouse
@@ -374,6 +568,13 @@ func (s *SalesOrderService) Update(ctx context.Context, tenantID, orderID uuid.U
 		}
 	}
 
+	// Update shipping address
+	if req.ShippingAddressID != nil {
+		if err := s.setShippingAddress(ctx, order, req.ShippingAddressID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update discount
 	if req.Discount != nil {
 		discountMoney := valueobject.NewMoneyCNY(*req.Discount)
@@ -387,6 +588,13 @@ func (s *SalesOrderService) Update(ctx context.Context, tenantID, orderID uuid.U
 		order.SetRemark(*req.Remark)
 	}
 
+	// Update expired stock setting
+	if req.AllowExpiredStock != nil {
+		if err := order.SetAllowExpiredStock(*req.AllowExpiredStock); err != nil {
+			return nil, err
+		}
+	}
+
 	// Save with optimistic locking
 	if err := s.orderRepo.SaveWithLock(ctx, order); err != nil {
 		return nil, err
@@ -408,7 +616,13 @@ func (s *SalesOrderService) AddItem(ctx context.Context, tenantID, orderID uuid.
 		return nil, err
 	}
 
-	unitPrice := valueobject.NewMoneyCNY(req.UnitPrice)
+	// The quantity breaks of the product take precedence over the requested price once reached and lower
+	price := req.UnitPrice
+	if tierPrice, ok := s.priceByQuantity(ctx, tenantID, req.ProductID, req.Quantity, req.UnitPrice); ok {
+		price = tierPrice
+	}
+
+	unitPrice := valueobject.NewMoneyCNY(price)
 	item, err := order.AddItem(
 		req.ProductID,
 		req.ProductName,
@@ -423,6 +637,14 @@ func (s *SalesOrderService) AddItem(ctx context.Context, tenantID, orderID uuid.
 		return nil, err
 	}
 
+	order.GetItem(item.ID).SetListPrice(req.UnitPrice)
+
+	if req.DiscountType != "" {
+		if err := order.SetItemDiscount(item.ID, toLineDiscountType(req.DiscountType), req.DiscountValue); err != nil {
+			return nil, err
+		}
+	}
+
 	if req.Remark != "" {
 		item.SetRemark(req.Remark)
 	}
@@ -443,6 +665,28 @@ func (s *SalesOrderService) UpdateItem(ctx context.Context, tenantID, orderID, i
 		return nil, err
 	}
 
+	// Work out the new line discount before the quantity or price changes
+	changeDiscount := req.DiscountType != nil || req.DiscountValue != nil
+	var discountType trade.LineDiscountType
+	var discountValue decimal.Decimal
+	if changeDiscount {
+		item := order.GetItem(itemID)
+		if item == nil {
+			return nil, shared.NewDomainError("ITEM_NOT_FOUND", "Order item not found")
+		}
+		discountType, discountValue = item.DiscountType, item.DiscountValue
+		if req.DiscountType != nil {
+			discountType = toLineDiscountType(*req.DiscountType)
+		}
+		if req.DiscountValue != nil {
+			discountValue = *req.DiscountValue
+		}
+		// Drop the old discount so it cannot block a lower quantity or price
+		if err := order.SetItemDiscount(itemID, trade.LineDiscountTypeNone, decimal.Zero); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update quantity
 	if req.Quantity != nil {
 		if err := order.UpdateItemQuantity(itemID, *req.Quantity); err != nil {
@@ -450,14 +694,42 @@ func (s *SalesOrderService) UpdateItem(ctx context.Context, tenantID, orderID, i
 		}
 	}
 
-	// Update price
+	// A requested price becomes the line's list price
 	if req.UnitPrice != nil {
-		unitPrice := valueobject.NewMoneyCNY(*req.UnitPrice)
+		if item := order.GetItem(itemID); item != nil {
+			item.SetListPrice(*req.UnitPrice)
+		}
+	}
+
+	// Re-price the line from its list price by the quantity breaks of its product when its quantity changes,
+	// so a tier price no longer applies once the quantity drops below the tier.
+	// Lines without a list price are priced from the product's selling price.
+	newPrice := req.UnitPrice
+	if req.Quantity != nil {
+		if item := order.GetItem(itemID); item != nil {
+			if tierPrice, ok := s.priceByQuantity(ctx, tenantID, item.ProductID, *req.Quantity, item.ListPrice); ok {
+				newPrice = &tierPrice
+			} else if !item.ListPrice.IsZero() {
+				newPrice = &item.ListPrice
+			}
+		}
+	}
+
+	// Update price
+	if newPrice != nil {
+		unitPrice := valueobject.NewMoneyCNY(*newPrice)
 		if err := order.UpdateItemPrice(itemID, unitPrice); err != nil {
 			return nil, err
 		}
 	}
 
+	// Update line discount
+	if changeDiscount {
+		if err := order.SetItemDiscount(itemID, discountType, discountValue); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update remark
 	if req.Remark != nil {
 		item := order.GetItem(itemID)
@@ -496,6 +768,49 @@ func (s *SalesOrderService) RemoveItem(ctx context.Context, tenantID, orderID, i
 	return &response, nil
 }
 
+// ConvertQuote converts a quote into a draft order, optionally re-pricing every item with the
+// current pricing strategy first. The resulting draft is confirmed like any other order.
+func (s *SalesOrderService) ConvertQuote(ctx context.Context, tenantID, orderID uuid.UUID, req ConvertQuoteRequest) (*SalesOrderResponse, error) {
+	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.IsQuote() {
+		return nil, shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot convert order in %s status", order.Status))
+	}
+
+	if req.Reprice {
+		for _, item := range order.Items {
+			// The quoted price is the base the current strategy is applied to
+			price := s.calculateItemPrice(ctx, tenantID, CreateSalesOrderItemInput{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+				UnitPrice: item.UnitPrice,
+				BasePrice: item.UnitPrice,
+			}, req.CustomerLevel, req.PricingStrategyName)
+			if err := order.UpdateItemPrice(item.ID, valueobject.NewMoneyCNY(price)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := order.ConvertQuote(); err != nil {
+		return nil, err
+	}
+
+	// Save with optimistic locking
+	if err := s.orderRepo.SaveWithLock(ctx, order); err != nil {
+		return nil, err
+	}
+
+	if s.businessMetrics != nil {
+		s.businessMetrics.RecordOrderWithAmount(ctx, tenantID, telemetry.OrderTypeSales, order.TotalAmount)
+	}
+
+	response := ToSalesOrderResponse(order)
+	return &response, nil
+}
+
 // Confirm confirms a sales order
 // This triggers stock locking via domain events (P3-BE-006)
 func (s *SalesOrderService) Confirm(ctx context.Context, tenantID, orderID uuid.UUID, req ConfirmOrderRequest) (*SalesOrderResponse, error) {
@@ -562,7 +877,7 @@ func (s *SalesOrderService) Confirm(ctx context.Context, tenantID, orderID uuid.
 	return response, confirmErr
 }
 
-// Ship marks an order as shipped
+// Ship ships the given items of an order, or everything not yet shipped when no items are given
 // This triggers stock deduction via domain events (P3-BE-006)
 func (s *SalesOrderService) Ship(ctx context.Context, tenantID, orderID uuid.UUID, req ShipOrderRequest) (*SalesOrderResponse, error) {
 	// Start tracing span for order shipping flow
@@ -596,8 +911,33 @@ func (s *SalesOrderService) Ship(ctx context.Context, tenantID, orderID uuid.UUI
 			}
 		}
 
+		// Partial shipment of the requested items, or the full remainder of the order
+		shipItems := order.RemainingShipItems()
+		if len(req.Items) > 0 {
+			shipItems = make([]trade.ShipItem, len(req.Items))
+			for i, item := range req.Items {
+				shipItems[i] = trade.ShipItem{ItemID: item.ItemID, Quantity: item.Quantity}
+			}
+		}
+
+		// Block the shipment if it would take the customer over their credit limit
+		// or ship stock from batches that expire too soon
+		if order.Status.CanShip() {
+			if err := s.checkCreditLimit(c, order, shipItems); err != nil {
+				telemetry.RecordError(span, err)
+				shipErr = err
+				return
+			}
+			if err := s.checkBatchExpiry(c, order, shipItems, time.Now()); err != nil {
+				telemetry.RecordError(span, err)
+				shipErr = err
+				return
+			}
+		}
+
 		// Ship order
-		if err := order.Ship(); err != nil {
+		tracking := trade.ShipmentTracking{ShippingCompany: req.ShippingCompany, TrackingNumber: req.TrackingNumber}
+		if err := order.ShipItemsWithTracking(shipItems, tracking); err != nil {
 			telemetry.RecordError(span, err)
 			shipErr = err
 			return
@@ -627,6 +967,26 @@ func (s *SalesOrderService) Ship(ctx context.Context, tenantID, orderID uuid.UUI
 	return response, shipErr
 }
 
+// ApproveCreditOverride approves an order to ship even if it exceeds the customer's credit limit
+func (s *SalesOrderService) ApproveCreditOverride(ctx context.Context, tenantID, orderID uuid.UUID, req ApproveCreditOverrideRequest) (*SalesOrderResponse, error) {
+	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.ApproveCreditOverride(req.ApprovedBy, req.Reason); err != nil {
+		return nil, err
+	}
+
+	// Save with optimistic locking
+	if err := s.orderRepo.SaveWithLock(ctx, order); err != nil {
+		return nil, err
+	}
+
+	response := ToSalesOrderResponse(order)
+	return &response, nil
+}
+
 // Complete marks an order as completed
 func (s *SalesOrderService) Complete(ctx context.Context, tenantID, orderID uuid.UUID) (*SalesOrderResponse, error) {
 	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
@@ -703,15 +1063,15 @@ func (s *SalesOrderService) Cancel(ctx context.Context, tenantID, orderID uuid.U
 	return response, cancelErr
 }
 
-// Delete deletes a sales order (only allowed in DRAFT status)
+// Delete deletes a sales order (only allowed for drafts and quotes)
 func (s *SalesOrderService) Delete(ctx context.Context, tenantID, orderID uuid.UUID) error {
 	order, err := s.orderRepo.FindByIDForTenant(ctx, tenantID, orderID)
 	if err != nil {
 		return err
 	}
 
-	if !order.IsDraft() {
-		return shared.NewDomainError("INVALID_STATE", "Only draft orders can be deleted")
+	if !order.IsDraft() && !order.IsQuote() && !order.IsExpired() {
+		return shared.NewDomainError("INVALID_STATE", "Only draft orders and quotes can be deleted")
 	}
 
 	return s.orderRepo.DeleteForTenant(ctx, tenantID, orderID)
@@ -729,6 +1089,11 @@ func (s *SalesOrderService) GetStatusSummary(ctx context.Context, tenantID uuid.
 		return nil, err
 	}
 
+	partiallyShipped, err := s.orderRepo.CountByStatus(ctx, tenantID, trade.OrderStatusPartiallyShipped)
+	if err != nil {
+		return nil, err
+	}
+
 	shipped, err := s.orderRepo.CountByStatus(ctx, tenantID, trade.OrderStatusShipped)
 	if err != nil {
 		return nil, err
@@ -745,11 +1110,12 @@ func (s *SalesOrderService) GetStatusSummary(ctx context.Context, tenantID uuid.
 	}
 
 	return &OrderStatusSummary{
-		Draft:     draft,
-		Confirmed: confirmed,
-		Shipped:   shipped,
-		Completed: completed,
-		Cancelled: cancelled,
-		Total:     draft + confirmed + shipped + completed + cancelled,
+		Draft:            draft,
+		Confirmed:        confirmed,
+		PartiallyShipped: partiallyShipped,
+		Shipped:          shipped,
+		Completed:        completed,
+		Cancelled:        cancelled,
+		Total:            draft + confirmed + partiallyShipped + shipped + completed + cancelled,
 	}, nil
 }
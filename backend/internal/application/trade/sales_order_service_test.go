@@ -4,14 +4,20 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/erp/backend/internal/domain/trade"
+	"github.com/erp/backend/internal/infrastructure/telemetry"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 // MockSalesOrderRepository is a mock implementation of SalesOrderRepository
@@ -135,6 +141,14 @@ func (m *MockSalesOrderRepository) ExistsByProduct(ctx context.Context, tenantID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockSalesOrderRepository) FindExpiredQuotes(ctx context.Context, expiresBefore time.Time) ([]trade.SalesOrder, error) {
+	args := m.Called(ctx, expiresBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]trade.SalesOrder), args.Error(1)
+}
+
 // Test helpers
 var (
 	testTenantID     = uuid.New()
@@ -295,6 +309,57 @@ func TestSalesOrderService_Create(t *testing.T) {
 	})
 }
 
+func TestSalesOrderService_Create_RecordsBusinessMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+	bm, err := telemetry.NewBusinessMetrics(telemetry.BusinessMetricsConfig{Meter: provider.Meter("test")})
+	require.NoError(t, err)
+
+	repo := new(MockSalesOrderRepository)
+	service := NewSalesOrderService(repo)
+	service.SetBusinessMetrics(bm)
+	repo.On("GenerateOrderNumber", mock.Anything, testTenantID).Return(testOrderNumber, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*trade.SalesOrder")).Return(nil)
+
+	_, err = service.Create(context.Background(), testTenantID, CreateSalesOrderRequest{
+		CustomerID:   testCustomerID,
+		CustomerName: testCustomerName,
+		Items: []CreateSalesOrderItemInput{
+			{
+				ProductID:      testProductID,
+				ProductName:    testProductName,
+				ProductCode:    testProductCode,
+				Unit:           testUnit,
+				BaseUnit:       testUnit,
+				ConversionRate: decimal.NewFromInt(1),
+				Quantity:       decimal.NewFromInt(5),
+				UnitPrice:      decimal.NewFromInt(100),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var created *metricdata.Sum[int64]
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "erp_order_created_total" {
+				created = &sum
+			}
+		}
+	}
+	require.NotNil(t, created, "erp_order_created_total should be recorded")
+	require.Len(t, created.DataPoints, 1)
+	point := created.DataPoints[0]
+	assert.Equal(t, int64(1), point.Value)
+	tenant, _ := point.Attributes.Value(telemetry.AttrTenantID)
+	assert.Equal(t, testTenantID.String(), tenant.AsString())
+	orderType, _ := point.Attributes.Value(telemetry.AttrOrderType)
+	assert.Equal(t, string(telemetry.OrderTypeSales), orderType.AsString())
+}
+
 // Tests for GetByID
 func TestSalesOrderService_GetByID(t *testing.T) {
 	t.Run("get order successfully", func(t *testing.T) {
@@ -426,6 +491,206 @@ func TestSalesOrderService_AddItem(t *testing.T) {
 		assert.Nil(t, result)
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("add item with line discount", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		ctx := context.Background()
+
+		order := createTestOrder()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		req := AddOrderItemRequest{
+			ProductID:      testProductID,
+			ProductName:    testProductName,
+			ProductCode:    testProductCode,
+			Unit:           testUnit,
+			BaseUnit:       testUnit,
+			ConversionRate: decimal.NewFromInt(1),
+			Quantity:       decimal.NewFromInt(5),
+			UnitPrice:      decimal.NewFromInt(100),
+			DiscountType:   "percent",
+			DiscountValue:  decimal.NewFromInt(10),
+		}
+
+		result, err := service.AddItem(ctx, testTenantID, order.ID, req)
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(450).Equal(result.TotalAmount))
+		assert.True(t, decimal.NewFromInt(50).Equal(result.LineDiscountAmount))
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "percent", result.Items[0].DiscountType)
+		assert.True(t, decimal.NewFromInt(50).Equal(result.Items[0].DiscountAmount))
+	})
+}
+
+// Tests for UpdateItem
+func TestSalesOrderService_Update_Version(t *testing.T) {
+	t.Run("second update against the same loaded version gets a conflict", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		ctx := context.Background()
+
+		order := createTestOrder()
+		loadedVersion := order.Version
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Run(func(args mock.Arguments) {
+			args.Get(1).(*trade.SalesOrder).Version++
+		}).Return(nil).Once()
+
+		first := "first edit"
+		_, err := service.Update(ctx, testTenantID, order.ID, UpdateSalesOrderRequest{Remark: &first, Version: &loadedVersion})
+		require.NoError(t, err)
+
+		second := "second edit"
+		result, err := service.Update(ctx, testTenantID, order.ID, UpdateSalesOrderRequest{Remark: &second, Version: &loadedVersion})
+
+		assert.Nil(t, result)
+		var conflictErr *shared.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, loadedVersion+1, conflictErr.CurrentVersion)
+		assert.ErrorIs(t, err, shared.ErrConcurrencyConflict)
+		assert.Equal(t, "first edit", order.Remark)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("update that loses the race to save surfaces the conflict", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		ctx := context.Background()
+
+		order := createTestOrder()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(shared.NewVersionConflictError("sales order", 5))
+
+		remark := "edit"
+		result, err := service.Update(ctx, testTenantID, order.ID, UpdateSalesOrderRequest{Remark: &remark})
+
+		assert.Nil(t, result)
+		var conflictErr *shared.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, 5, conflictErr.CurrentVersion)
+	})
+}
+
+// MockShippingAddressChecker is a mock implementation of ShippingAddressChecker
+type MockShippingAddressChecker struct {
+	mock.Mock
+}
+
+func (m *MockShippingAddressChecker) CheckShippingAddress(ctx context.Context, tenantID, customerID, addressID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, customerID, addressID)
+	return args.Error(0)
+}
+
+func TestSalesOrderService_Update_ShippingAddress(t *testing.T) {
+	t.Run("sets a shipping address of the customer", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockShippingAddressChecker)
+		service := NewSalesOrderService(repo)
+		service.SetShippingAddressChecker(checker)
+		ctx := context.Background()
+
+		order := createTestOrder()
+		addressID := uuid.New()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+		checker.On("CheckShippingAddress", mock.Anything, testTenantID, order.CustomerID, addressID).Return(nil)
+
+		result, err := service.Update(ctx, testTenantID, order.ID, UpdateSalesOrderRequest{ShippingAddressID: &addressID})
+
+		require.NoError(t, err)
+		assert.Equal(t, addressID, *result.ShippingAddressID)
+		checker.AssertExpectations(t)
+	})
+
+	t.Run("rejects an address that is not the customer's", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockShippingAddressChecker)
+		service := NewSalesOrderService(repo)
+		service.SetShippingAddressChecker(checker)
+		ctx := context.Background()
+
+		order := createTestOrder()
+		addressID := uuid.New()
+		invalidErr := shared.NewDomainError("INVALID_SHIPPING_ADDRESS", "Address is not a shipping address of the customer")
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		checker.On("CheckShippingAddress", mock.Anything, testTenantID, order.CustomerID, addressID).Return(invalidErr)
+
+		result, err := service.Update(ctx, testTenantID, order.ID, UpdateSalesOrderRequest{ShippingAddressID: &addressID})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, invalidErr)
+		assert.Nil(t, order.ShippingAddressID)
+		repo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSalesOrderService_UpdateItem(t *testing.T) {
+	t.Run("lowers quantity below the old fixed discount when the discount changes too", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		ctx := context.Background()
+
+		order := createTestOrderWithItem()
+		itemID := order.Items[0].ID
+		require.NoError(t, order.SetItemDiscount(itemID, trade.LineDiscountTypeAmount, decimal.NewFromInt(500)))
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		quantity := decimal.NewFromInt(2)
+		discountValue := decimal.NewFromInt(50)
+		result, err := service.UpdateItem(ctx, testTenantID, order.ID, itemID, UpdateOrderItemRequest{
+			Quantity:      &quantity,
+			DiscountValue: &discountValue,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(150).Equal(result.TotalAmount), "got %s", result.TotalAmount)
+		assert.Equal(t, "amount", result.Items[0].DiscountType)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("empty discount type removes the discount", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		ctx := context.Background()
+
+		order := createTestOrderWithItem()
+		itemID := order.Items[0].ID
+		require.NoError(t, order.SetItemDiscount(itemID, trade.LineDiscountTypePercent, decimal.NewFromInt(20)))
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		none := ""
+		result, err := service.UpdateItem(ctx, testTenantID, order.ID, itemID, UpdateOrderItemRequest{DiscountType: &none})
+
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(1000).Equal(result.TotalAmount))
+		assert.Empty(t, result.Items[0].DiscountType)
+	})
+
+	t.Run("discount larger than the line is rejected", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		ctx := context.Background()
+
+		order := createTestOrderWithItem()
+		itemID := order.Items[0].ID
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+
+		discountType := "amount"
+		discountValue := decimal.NewFromInt(1001)
+		result, err := service.UpdateItem(ctx, testTenantID, order.ID, itemID, UpdateOrderItemRequest{
+			DiscountType:  &discountType,
+			DiscountValue: &discountValue,
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		repo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
 }
 
 // Tests for Confirm
@@ -523,6 +788,197 @@ func TestSalesOrderService_Ship(t *testing.T) {
 	})
 }
 
+// MockCustomerCreditChecker is a mock implementation of CustomerCreditChecker
+type MockCustomerCreditChecker struct {
+	mock.Mock
+}
+
+func (m *MockCustomerCreditChecker) CheckCredit(ctx context.Context, tenantID, customerID uuid.UUID, orderAmount decimal.Decimal) error {
+	args := m.Called(ctx, tenantID, customerID, orderAmount)
+	return args.Error(0)
+}
+
+func TestSalesOrderService_Ship_CreditLimit(t *testing.T) {
+	createShippableOrder := func() *trade.SalesOrder {
+		order := createTestOrderWithItem()
+		order.SetWarehouse(testWarehouseID)
+		order.Confirm()
+		return order
+	}
+
+	t.Run("blocks ship when credit limit exceeded", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockCustomerCreditChecker)
+		service := NewSalesOrderService(repo)
+		service.SetCreditChecker(checker)
+		ctx := context.Background()
+
+		order := createShippableOrder()
+		creditErr := trade.NewCreditLimitExceededError(testCustomerID, decimal.NewFromInt(500), decimal.NewFromInt(400), order.PayableAmount)
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		checker.On("CheckCredit", mock.Anything, testTenantID, testCustomerID, order.PayableAmount).Return(creditErr)
+
+		result, err := service.Ship(ctx, testTenantID, order.ID, ShipOrderRequest{})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, trade.ErrCreditLimitExceeded)
+		var typedErr *trade.CreditLimitExceededError
+		assert.True(t, errors.As(err, &typedErr))
+		assert.True(t, typedErr.Overage.Equal(decimal.NewFromInt(900)))
+		assert.Equal(t, trade.OrderStatusConfirmed, order.Status)
+		repo.AssertNotCalled(t, "SaveWithLockAndEvents", mock.Anything, mock.Anything, mock.Anything)
+		checker.AssertExpectations(t)
+	})
+
+	t.Run("ships within credit limit", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockCustomerCreditChecker)
+		service := NewSalesOrderService(repo)
+		service.SetCreditChecker(checker)
+		ctx := context.Background()
+
+		order := createShippableOrder()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLockAndEvents", mock.Anything, mock.AnythingOfType("*trade.SalesOrder"), mock.Anything).Return(nil)
+		checker.On("CheckCredit", mock.Anything, testTenantID, testCustomerID, order.PayableAmount).Return(nil)
+
+		result, err := service.Ship(ctx, testTenantID, order.ID, ShipOrderRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "shipped", result.Status)
+		checker.AssertExpectations(t)
+	})
+
+	t.Run("approved override skips credit check", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockCustomerCreditChecker)
+		service := NewSalesOrderService(repo)
+		service.SetCreditChecker(checker)
+		ctx := context.Background()
+
+		order := createShippableOrder()
+		order.ApproveCreditOverride(uuid.New(), "Approved by finance")
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLockAndEvents", mock.Anything, mock.AnythingOfType("*trade.SalesOrder"), mock.Anything).Return(nil)
+
+		result, err := service.Ship(ctx, testTenantID, order.ID, ShipOrderRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "shipped", result.Status)
+		checker.AssertNotCalled(t, "CheckCredit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// MockBatchExpiryChecker is a mock implementation of BatchExpiryChecker
+type MockBatchExpiryChecker struct {
+	mock.Mock
+}
+
+func (m *MockBatchExpiryChecker) CheckBatchExpiry(ctx context.Context, tenantID, warehouseID, productID uuid.UUID, quantity decimal.Decimal, shipDate time.Time) error {
+	args := m.Called(ctx, tenantID, warehouseID, productID, quantity, shipDate)
+	return args.Error(0)
+}
+
+func TestSalesOrderService_Ship_BatchExpiry(t *testing.T) {
+	createShippableOrder := func() *trade.SalesOrder {
+		order := createTestOrderWithItem()
+		order.SetWarehouse(testWarehouseID)
+		order.Confirm()
+		return order
+	}
+
+	t.Run("blocks ship from an expired batch", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockBatchExpiryChecker)
+		service := NewSalesOrderService(repo)
+		service.SetBatchExpiryChecker(checker)
+		ctx := context.Background()
+
+		order := createShippableOrder()
+		expiredErr := shared.NewDomainError("BATCH_EXPIRED", "Batch LOT-1 expires on 2026-01-01 and cannot be shipped")
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		checker.On("CheckBatchExpiry", mock.Anything, testTenantID, testWarehouseID, testProductID, decimal.NewFromInt(10), mock.AnythingOfType("time.Time")).Return(expiredErr)
+
+		result, err := service.Ship(ctx, testTenantID, order.ID, ShipOrderRequest{})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, expiredErr)
+		assert.Equal(t, trade.OrderStatusConfirmed, order.Status)
+		repo.AssertNotCalled(t, "SaveWithLockAndEvents", mock.Anything, mock.Anything, mock.Anything)
+		checker.AssertExpectations(t)
+	})
+
+	t.Run("ships from a fresh batch", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockBatchExpiryChecker)
+		service := NewSalesOrderService(repo)
+		service.SetBatchExpiryChecker(checker)
+		ctx := context.Background()
+
+		order := createShippableOrder()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLockAndEvents", mock.Anything, mock.AnythingOfType("*trade.SalesOrder"), mock.Anything).Return(nil)
+		checker.On("CheckBatchExpiry", mock.Anything, testTenantID, testWarehouseID, testProductID, decimal.NewFromInt(10), mock.AnythingOfType("time.Time")).Return(nil)
+
+		result, err := service.Ship(ctx, testTenantID, order.ID, ShipOrderRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "shipped", result.Status)
+		checker.AssertExpectations(t)
+	})
+
+	t.Run("order allowing expired stock skips the check", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		checker := new(MockBatchExpiryChecker)
+		service := NewSalesOrderService(repo)
+		service.SetBatchExpiryChecker(checker)
+		ctx := context.Background()
+
+		order := createShippableOrder()
+		require.NoError(t, order.SetAllowExpiredStock(true))
+		var events []shared.DomainEvent
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLockAndEvents", mock.Anything, mock.AnythingOfType("*trade.SalesOrder"), mock.Anything).
+			Run(func(args mock.Arguments) { events = args.Get(2).([]shared.DomainEvent) }).
+			Return(nil)
+
+		result, err := service.Ship(ctx, testTenantID, order.ID, ShipOrderRequest{})
+
+		assert.NoError(t, err)
+		assert.True(t, result.AllowExpiredStock)
+		checker.AssertNotCalled(t, "CheckBatchExpiry", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		require.NotEmpty(t, events)
+		shipped, ok := events[len(events)-1].(*trade.SalesOrderShippedEvent)
+		require.True(t, ok)
+		assert.True(t, shipped.AllowExpiredStock)
+	})
+}
+
+// Tests for ApproveCreditOverride
+func TestSalesOrderService_ApproveCreditOverride(t *testing.T) {
+	t.Run("approve credit override successfully", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		ctx := context.Background()
+
+		order := createTestOrderWithItem()
+		order.Confirm()
+		approverID := uuid.New()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		result, err := service.ApproveCreditOverride(ctx, testTenantID, order.ID, ApproveCreditOverrideRequest{
+			ApprovedBy: approverID,
+			Reason:     "Approved by finance",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &approverID, result.CreditOverrideApprovedBy)
+		assert.Equal(t, "Approved by finance", result.CreditOverrideReason)
+		repo.AssertExpectations(t)
+	})
+}
+
 // Tests for Complete
 func TestSalesOrderService_Complete(t *testing.T) {
 	t.Run("complete order successfully", func(t *testing.T) {
@@ -638,6 +1094,275 @@ func TestSalesOrderService_Delete(t *testing.T) {
 	})
 }
 
+// stubPricingProvider serves a single pricing strategy regardless of name
+type stubPricingProvider struct {
+	strategy strategy.PricingStrategy
+}
+
+func (p stubPricingProvider) GetPricingStrategy(name string) (strategy.PricingStrategy, error) {
+	return p.strategy, nil
+}
+
+func (p stubPricingProvider) GetPricingStrategyOrDefault(name string) strategy.PricingStrategy {
+	return p.strategy
+}
+
+// Tests for pricing lines by the quantity breaks of their product
+func TestSalesOrderService_TieredPricing(t *testing.T) {
+	ctx := context.Background()
+	tieredProvider := stubPricingProvider{strategy: strategy.NewTieredPricingStrategy([]strategy.PriceTier{
+		{MinQuantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(90)},
+		{MinQuantity: decimal.NewFromInt(50), UnitPrice: decimal.NewFromInt(80)},
+	})}
+
+	addItem := func(t *testing.T, quantity, unitPrice int64) *SalesOrderResponse {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		service.SetPricingProvider(tieredProvider)
+
+		order := createTestOrder()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		result, err := service.AddItem(ctx, testTenantID, order.ID, AddOrderItemRequest{
+			ProductID:      testProductID,
+			ProductName:    testProductName,
+			ProductCode:    testProductCode,
+			Unit:           testUnit,
+			BaseUnit:       testUnit,
+			ConversionRate: decimal.NewFromInt(1),
+			Quantity:       decimal.NewFromInt(quantity),
+			UnitPrice:      decimal.NewFromInt(unitPrice),
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	updateQuantity := func(t *testing.T, order *trade.SalesOrder, quantity int64) *SalesOrderResponse {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		service.SetPricingProvider(tieredProvider)
+
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		newQuantity := decimal.NewFromInt(quantity)
+		result, err := service.UpdateItem(ctx, testTenantID, order.ID, order.Items[0].ID, UpdateOrderItemRequest{
+			Quantity: &newQuantity,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("quantity exactly on a tier boundary gets the tier price", func(t *testing.T) {
+		result := addItem(t, 10, 100)
+
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(90)), "got %s", result.Items[0].UnitPrice)
+		assert.True(t, result.TotalAmount.Equal(decimal.NewFromInt(900)), "got %s", result.TotalAmount)
+	})
+
+	t.Run("quantity below the smallest tier keeps the base price", func(t *testing.T) {
+		result := addItem(t, 9, 100)
+
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(100)), "got %s", result.Items[0].UnitPrice)
+		assert.True(t, result.TotalAmount.Equal(decimal.NewFromInt(900)), "got %s", result.TotalAmount)
+	})
+
+	t.Run("requested price below the tier price is kept", func(t *testing.T) {
+		result := addItem(t, 10, 85)
+
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(85)), "got %s", result.Items[0].UnitPrice)
+	})
+
+	t.Run("updating the quantity re-prices the line", func(t *testing.T) {
+		result := updateQuantity(t, createTestOrderWithItem(), 50)
+
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(80)), "got %s", result.Items[0].UnitPrice)
+		assert.True(t, result.TotalAmount.Equal(decimal.NewFromInt(4000)), "got %s", result.TotalAmount)
+	})
+
+	t.Run("updating the quantity keeps a current price below the tier price", func(t *testing.T) {
+		order := createTestOrder()
+		_, err := order.AddItem(testProductID, testProductName, testProductCode, testUnit, testUnit, decimal.NewFromInt(5), decimal.NewFromInt(1), newMoneyCNY("75"))
+		require.NoError(t, err)
+
+		result := updateQuantity(t, order, 50)
+
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(75)), "got %s", result.Items[0].UnitPrice)
+		assert.True(t, result.TotalAmount.Equal(decimal.NewFromInt(3750)), "got %s", result.TotalAmount)
+	})
+
+	t.Run("updating the quantity below the smallest tier keeps the current price", func(t *testing.T) {
+		order := createTestOrder()
+		_, err := order.AddItem(testProductID, testProductName, testProductCode, testUnit, testUnit, decimal.NewFromInt(5), decimal.NewFromInt(1), newMoneyCNY("120"))
+		require.NoError(t, err)
+
+		result := updateQuantity(t, order, 8)
+
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(120)), "got %s", result.Items[0].UnitPrice)
+	})
+
+	t.Run("raising and then lowering the quantity re-prices from the list price", func(t *testing.T) {
+		order := createTestOrder()
+		_, err := order.AddItem(testProductID, testProductName, testProductCode, testUnit, testUnit, decimal.NewFromInt(5), decimal.NewFromInt(1), newMoneyCNY("100"))
+		require.NoError(t, err)
+
+		result := updateQuantity(t, order, 50)
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(80)), "got %s", result.Items[0].UnitPrice)
+
+		result = updateQuantity(t, order, 10)
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(90)), "got %s", result.Items[0].UnitPrice)
+
+		result = updateQuantity(t, order, 5)
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(100)), "got %s", result.Items[0].UnitPrice)
+		assert.True(t, result.TotalAmount.Equal(decimal.NewFromInt(500)), "got %s", result.TotalAmount)
+	})
+
+	t.Run("a tier price from adding the line does not stick", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		service.SetPricingProvider(tieredProvider)
+
+		order := createTestOrder()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+		_, err := service.AddItem(ctx, testTenantID, order.ID, AddOrderItemRequest{
+			ProductID:      testProductID,
+			ProductName:    testProductName,
+			ProductCode:    testProductCode,
+			Unit:           testUnit,
+			BaseUnit:       testUnit,
+			ConversionRate: decimal.NewFromInt(1),
+			Quantity:       decimal.NewFromInt(50),
+			UnitPrice:      decimal.NewFromInt(100),
+		})
+		require.NoError(t, err)
+		require.True(t, order.Items[0].UnitPrice.Equal(decimal.NewFromInt(80)))
+
+		result := updateQuantity(t, order, 5)
+
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(100)), "got %s", result.Items[0].UnitPrice)
+	})
+
+	t.Run("updating only the price keeps the requested price", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		service.SetPricingProvider(tieredProvider)
+
+		order := createTestOrderWithItem()
+		itemID := order.Items[0].ID
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+		repo.On("SaveWithLock", mock.Anything, order).Return(nil)
+
+		unitPrice := decimal.NewFromInt(95)
+		result, err := service.UpdateItem(ctx, testTenantID, order.ID, itemID, UpdateOrderItemRequest{
+			UnitPrice: &unitPrice,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(95)), "got %s", result.Items[0].UnitPrice)
+	})
+}
+
+// Tests for quotes
+func TestSalesOrderService_Quote(t *testing.T) {
+	ctx := context.Background()
+
+	createTestQuote := func(t *testing.T) *trade.SalesOrder {
+		quote, err := trade.NewSalesQuote(testTenantID, testOrderNumber, testCustomerID, testCustomerName, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		_, err = quote.AddItem(testProductID, testProductName, testProductCode, testUnit, testUnit, decimal.NewFromInt(10), decimal.NewFromInt(1), newMoneyCNY("100"))
+		require.NoError(t, err)
+		return quote
+	}
+
+	t.Run("create quote with default validity", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		service.SetQuoteValidity(7 * 24 * time.Hour)
+
+		repo.On("GenerateOrderNumber", mock.Anything, testTenantID).Return(testOrderNumber, nil)
+		repo.On("Save", mock.Anything, mock.AnythingOfType("*trade.SalesOrder")).Return(nil)
+
+		result, err := service.Create(ctx, testTenantID, CreateSalesOrderRequest{
+			CustomerID:   testCustomerID,
+			CustomerName: testCustomerName,
+			IsQuote:      true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "quote", result.Status)
+		require.NotNil(t, result.QuoteExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), *result.QuoteExpiresAt, time.Minute)
+	})
+
+	t.Run("convert quote into draft order", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+
+		quote := createTestQuote(t)
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, quote.ID).Return(quote, nil)
+		repo.On("SaveWithLock", mock.Anything, mock.AnythingOfType("*trade.SalesOrder")).Return(nil)
+
+		result, err := service.ConvertQuote(ctx, testTenantID, quote.ID, ConvertQuoteRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "draft", result.Status)
+		assert.NotNil(t, result.ConvertedAt)
+		assert.True(t, result.TotalAmount.Equal(decimal.NewFromInt(1000)))
+		// Converting does not confirm the order, so no stock is locked
+		repo.AssertNotCalled(t, "SaveWithLockAndEvents", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("convert quote re-pricing with the current strategy", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+		service.SetPricingProvider(stubPricingProvider{strategy: strategy.NewTieredPricingStrategy([]strategy.PriceTier{
+			{MinQuantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(80)},
+		})})
+
+		quote := createTestQuote(t)
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, quote.ID).Return(quote, nil)
+		repo.On("SaveWithLock", mock.Anything, mock.AnythingOfType("*trade.SalesOrder")).Return(nil)
+
+		result, err := service.ConvertQuote(ctx, testTenantID, quote.ID, ConvertQuoteRequest{
+			Reprice:             true,
+			PricingStrategyName: "tiered",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "draft", result.Status)
+		assert.True(t, result.Items[0].UnitPrice.Equal(decimal.NewFromInt(80)))
+		assert.True(t, result.PayableAmount.Equal(decimal.NewFromInt(800)))
+	})
+
+	t.Run("fail to convert an expired quote", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+
+		quote := createTestQuote(t)
+		require.NoError(t, quote.Expire(quote.QuoteExpiresAt.Add(time.Second)))
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, quote.ID).Return(quote, nil)
+
+		_, err := service.ConvertQuote(ctx, testTenantID, quote.ID, ConvertQuoteRequest{})
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fail to convert a draft order", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		service := NewSalesOrderService(repo)
+
+		order := createTestOrderWithItem()
+		repo.On("FindByIDForTenant", mock.Anything, testTenantID, order.ID).Return(order, nil)
+
+		_, err := service.ConvertQuote(ctx, testTenantID, order.ID, ConvertQuoteRequest{})
+
+		assert.Error(t, err)
+	})
+}
+
 // Tests for GetStatusSummary
 func TestSalesOrderService_GetStatusSummary(t *testing.T) {
 	t.Run("get status summary successfully", func(t *testing.T) {
@@ -647,6 +1372,7 @@ func TestSalesOrderService_GetStatusSummary(t *testing.T) {
 
 		repo.On("CountByStatus", ctx, testTenantID, trade.OrderStatusDraft).Return(int64(5), nil)
 		repo.On("CountByStatus", ctx, testTenantID, trade.OrderStatusConfirmed).Return(int64(10), nil)
+		repo.On("CountByStatus", ctx, testTenantID, trade.OrderStatusPartiallyShipped).Return(int64(4), nil)
 		repo.On("CountByStatus", ctx, testTenantID, trade.OrderStatusShipped).Return(int64(3), nil)
 		repo.On("CountByStatus", ctx, testTenantID, trade.OrderStatusCompleted).Return(int64(100), nil)
 		repo.On("CountByStatus", ctx, testTenantID, trade.OrderStatusCancelled).Return(int64(2), nil)
@@ -657,10 +1383,11 @@ func TestSalesOrderService_GetStatusSummary(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.Equal(t, int64(5), result.Draft)
 		assert.Equal(t, int64(10), result.Confirmed)
+		assert.Equal(t, int64(4), result.PartiallyShipped)
 		assert.Equal(t, int64(3), result.Shipped)
 		assert.Equal(t, int64(100), result.Completed)
 		assert.Equal(t, int64(2), result.Cancelled)
-		assert.Equal(t, int64(120), result.Total)
+		assert.Equal(t, int64(124), result.Total)
 		repo.AssertExpectations(t)
 	})
 }
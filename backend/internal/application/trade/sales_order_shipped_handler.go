@@ -74,12 +74,12 @@ func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.Doma
 		return fmt.Errorf("failed to get locks: %w", err)
 	}
 
-	// Create a map of productID -> lockID for quick lookup
-	lockByProduct := make(map[uuid.UUID]uuid.UUID)
+	// Create a map of productID -> lock for quick lookup
+	lockByProduct := make(map[uuid.UUID]inventoryapp.StockLockResponse)
 	for _, lock := range locks {
 		// Only consider active locks (not released, not consumed)
 		if !lock.Released && !lock.Consumed {
-			lockByProduct[lock.ProductID] = lock.ID
+			lockByProduct[lock.ProductID] = lock
 		}
 	}
 
@@ -87,9 +87,11 @@ func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.Doma
 	var lastErr error
 	successCount := 0
 	reference := fmt.Sprintf("SO:%s", shippedEvent.OrderNumber)
+	// Batch expiry is checked against the day the order shipped, not when the event is handled
+	shipDate := shippedEvent.OccurredAt()
 
 	for _, item := range shippedEvent.Items {
-		lockID, exists := lockByProduct[item.ProductID]
+		lock, exists := lockByProduct[item.ProductID]
 		if !exists {
 			h.logger.Warn("no active lock found for item, skipping deduction",
 				zap.String("order_id", shippedEvent.OrderID.String()),
@@ -101,12 +103,22 @@ func (h *SalesOrderShippedHandler) Handle(ctx context.Context, event shared.Doma
 			continue
 		}
 
+		lockID := lock.ID
 		req := inventoryapp.DeductStockRequest{
-			LockID:     lockID,
-			SourceType: sourceType,
-			SourceID:   sourceID,
-			Reference:  reference,
-			OperatorID: nil,
+			LockID:       lockID,
+			SourceType:   sourceType,
+			SourceID:     sourceID,
+			Reference:    reference,
+			OperatorID:   nil,
+			ShipDate:     &shipDate,
+			AllowExpired: shippedEvent.AllowExpiredStock,
+			// Sales order shipments do not carry serial numbers
+			SkipSerialNumbers: true,
+		}
+		// A partial shipment deducts only the shipped quantity and keeps the rest locked
+		if item.Quantity.LessThan(lock.Quantity) {
+			quantity := item.Quantity
+			req.Quantity = &quantity
 		}
 
 		if err := h.inventoryService.DeductStock(ctx, event.TenantID(), req); err != nil {
@@ -0,0 +1,85 @@
+package trade
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/trade"
+	"go.uber.org/zap"
+)
+
+// SalesQuoteExpirationService moves quotes past their expiry to EXPIRED.
+// It is run periodically by a background job.
+type SalesQuoteExpirationService struct {
+	orderRepo trade.SalesOrderRepository
+	logger    *zap.Logger
+}
+
+// NewSalesQuoteExpirationService creates a new SalesQuoteExpirationService
+func NewSalesQuoteExpirationService(orderRepo trade.SalesOrderRepository, logger *zap.Logger) *SalesQuoteExpirationService {
+	return &SalesQuoteExpirationService{
+		orderRepo: orderRepo,
+		logger:    logger,
+	}
+}
+
+// SalesQuoteExpirationStats contains statistics about an expiration run
+type SalesQuoteExpirationStats struct {
+	TotalExpired  int       `json:"total_expired"`
+	Expired       int       `json:"expired"`
+	FailedExpires int       `json:"failed_expires"`
+	ProcessedAt   time.Time `json:"processed_at"`
+}
+
+// ExpireQuotes finds quotes whose expiry has passed and marks each one as EXPIRED.
+// A quote that fails to save is left as a quote and retried on the next run.
+func (s *SalesQuoteExpirationService) ExpireQuotes(ctx context.Context) (*SalesQuoteExpirationStats, error) {
+	now := time.Now()
+	stats := &SalesQuoteExpirationStats{
+		ProcessedAt: now,
+	}
+
+	quotes, err := s.orderRepo.FindExpiredQuotes(ctx, now)
+	if err != nil {
+		s.logger.Error("Failed to find expired quotes", zap.Error(err))
+		return nil, err
+	}
+
+	stats.TotalExpired = len(quotes)
+	if stats.TotalExpired == 0 {
+		s.logger.Debug("No expired quotes found")
+		return stats, nil
+	}
+
+	for i := range quotes {
+		quote := &quotes[i]
+		if err := quote.Expire(now); err != nil {
+			s.logger.Error("Failed to expire quote",
+				zap.String("order_id", quote.ID.String()),
+				zap.String("order_number", quote.OrderNumber),
+				zap.Error(err),
+			)
+			stats.FailedExpires++
+			continue
+		}
+
+		if err := s.orderRepo.SaveWithLock(ctx, quote); err != nil {
+			s.logger.Error("Failed to save expired quote",
+				zap.String("order_id", quote.ID.String()),
+				zap.String("order_number", quote.OrderNumber),
+				zap.Error(err),
+			)
+			stats.FailedExpires++
+			continue
+		}
+		stats.Expired++
+	}
+
+	s.logger.Info("Completed quote expiration",
+		zap.Int("total", stats.TotalExpired),
+		zap.Int("expired", stats.Expired),
+		zap.Int("failed", stats.FailedExpires),
+	)
+
+	return stats, nil
+}
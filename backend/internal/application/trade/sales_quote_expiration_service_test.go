@@ -0,0 +1,84 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSalesQuoteExpirationService_ExpireQuotes(t *testing.T) {
+	ctx := context.Background()
+
+	newExpiredQuote := func(t *testing.T) trade.SalesOrder {
+		quote, err := trade.NewSalesQuote(testTenantID, testOrderNumber, testCustomerID, testCustomerName, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		past := time.Now().Add(-time.Minute)
+		quote.QuoteExpiresAt = &past
+		return *quote
+	}
+
+	t.Run("expires quotes past their expiry", func(t *testing.T) {
+		first := newExpiredQuote(t)
+		second := newExpiredQuote(t)
+
+		repo := new(MockSalesOrderRepository)
+		repo.On("FindExpiredQuotes", ctx, mock.AnythingOfType("time.Time")).
+			Return([]trade.SalesOrder{first, second}, nil)
+		repo.On("SaveWithLock", ctx, mock.Anything).Return(nil)
+
+		stats, err := NewSalesQuoteExpirationService(repo, zap.NewNop()).ExpireQuotes(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, stats.TotalExpired)
+		assert.Equal(t, 2, stats.Expired)
+		assert.Equal(t, 0, stats.FailedExpires)
+		repo.AssertCalled(t, "SaveWithLock", ctx, mock.MatchedBy(func(o *trade.SalesOrder) bool {
+			return o.ID == first.ID && o.Status == trade.OrderStatusExpired && o.ExpiredAt != nil
+		}))
+	})
+
+	t.Run("counts quotes that cannot be saved as failed", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		repo.On("FindExpiredQuotes", ctx, mock.AnythingOfType("time.Time")).
+			Return([]trade.SalesOrder{newExpiredQuote(t)}, nil)
+		repo.On("SaveWithLock", ctx, mock.Anything).Return(shared.ErrConcurrencyConflict)
+
+		stats, err := NewSalesQuoteExpirationService(repo, zap.NewNop()).ExpireQuotes(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, stats.Expired)
+		assert.Equal(t, 1, stats.FailedExpires)
+	})
+
+	t.Run("counts quotes that can no longer be expired as failed", func(t *testing.T) {
+		converted := newExpiredQuote(t)
+		converted.Status = trade.OrderStatusDraft
+
+		repo := new(MockSalesOrderRepository)
+		repo.On("FindExpiredQuotes", ctx, mock.AnythingOfType("time.Time")).
+			Return([]trade.SalesOrder{converted}, nil)
+
+		stats, err := NewSalesQuoteExpirationService(repo, zap.NewNop()).ExpireQuotes(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, stats.FailedExpires)
+		repo.AssertNotCalled(t, "SaveWithLock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		repo.On("FindExpiredQuotes", ctx, mock.AnythingOfType("time.Time")).
+			Return(nil, errors.New("database error"))
+
+		_, err := NewSalesQuoteExpirationService(repo, zap.NewNop()).ExpireQuotes(ctx)
+		assert.Error(t, err)
+	})
+}
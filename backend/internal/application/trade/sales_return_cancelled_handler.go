@@ -140,6 +140,8 @@ func (h *SalesReturnCancelledHandler) Handle(ctx context.Context, event shared.D
 			SourceID:    cancelledEvent.ReturnID.String(),
 			Reference:   reference,
 			Reason:      fmt.Sprintf("Sales return cancelled: %s - %s", cancelledEvent.ReturnNumber, cancelledEvent.CancelReason),
+			// Sales returns do not carry serial numbers
+			SkipSerialNumbers: true,
 		}
 
 		err := h.inventoryService.DecreaseStock(ctx, event.TenantID(), req)
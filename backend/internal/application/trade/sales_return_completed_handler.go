@@ -101,6 +101,8 @@ func (h *SalesReturnCompletedHandler) Handle(ctx context.Context, event shared.D
 			SourceID:    completedEvent.ReturnID.String(),
 			Reference:   reference,
 			Reason:      fmt.Sprintf("Sales return: %s", completedEvent.ReturnNumber),
+			// Sales returns do not carry serial numbers
+			SkipSerialNumbers: true,
 		}
 
 		_, err = h.inventoryService.IncreaseStock(ctx, event.TenantID(), req)
@@ -2,6 +2,7 @@ package trade
 
 import (
 	"context"
+	"strings"
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/trade"
@@ -31,6 +32,11 @@ func (s *SalesReturnService) SetEventPublisher(publisher shared.EventPublisher)
 	s.eventPublisher = publisher
 }
 
+// toReturnReasonCode converts a request reason code (e.g. wrong_item, any case) to the domain code
+func toReturnReasonCode(code string) trade.ReturnReasonCode {
+	return trade.ReturnReasonCode(strings.ToUpper(code))
+}
+
 // Create creates a new sales return from an existing sales order
 func (s *SalesReturnService) Create(ctx context.Context, tenantID uuid.UUID, req CreateSalesReturnRequest) (*SalesReturnResponse, error) {
 	// Get the sales order
@@ -114,6 +120,11 @@ func (s *SalesReturnService) Create(ctx context.Context, tenantID uuid.UUID, req
 		}
 	}
 
+	// The reason code is required; the free-text reason is an optional note
+	if err := sr.SetReasonCode(toReturnReasonCode(req.ReasonCode)); err != nil {
+		return nil, err
+	}
+
 	// Set optional fields
 	if req.Reason != "" {
 		sr.SetReason(req.Reason)
@@ -273,6 +284,13 @@ func (s *SalesReturnService) Update(ctx context.Context, tenantID, returnID uuid
 		}
 	}
 
+	// Update reason code if provided
+	if req.ReasonCode != nil {
+		if err := sr.SetReasonCode(toReturnReasonCode(*req.ReasonCode)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update reason if provided
 	if req.Reason != nil {
 		sr.SetReason(*req.Reason)
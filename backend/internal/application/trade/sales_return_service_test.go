@@ -2,6 +2,7 @@ package trade
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/erp/backend/internal/domain/shared"
@@ -10,6 +11,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockSalesReturnRepository is a mock implementation of SalesReturnRepository
@@ -212,6 +214,7 @@ func TestSalesReturnService_Create_ValidatesReturnQuantity(t *testing.T) {
 
 		req := CreateSalesReturnRequest{
 			SalesOrderID: orderID,
+			ReasonCode:   "damaged",
 			Items: []CreateSalesReturnItemInput{
 				{
 					SalesOrderItemID: orderItemID,
@@ -248,6 +251,7 @@ func TestSalesReturnService_Create_ValidatesReturnQuantity(t *testing.T) {
 
 		req := CreateSalesReturnRequest{
 			SalesOrderID: orderID,
+			ReasonCode:   "damaged",
 			Items: []CreateSalesReturnItemInput{
 				{
 					SalesOrderItemID: orderItemID,
@@ -282,6 +286,7 @@ func TestSalesReturnService_Create_ValidatesReturnQuantity(t *testing.T) {
 
 		req := CreateSalesReturnRequest{
 			SalesOrderID: orderID,
+			ReasonCode:   "damaged",
 			Items: []CreateSalesReturnItemInput{
 				{
 					SalesOrderItemID: orderItemID,
@@ -322,6 +327,7 @@ func TestSalesReturnService_Create_ValidatesReturnQuantity(t *testing.T) {
 
 		req := CreateSalesReturnRequest{
 			SalesOrderID: orderID,
+			ReasonCode:   "damaged",
 			Items: []CreateSalesReturnItemInput{
 				{
 					SalesOrderItemID: orderItemID,
@@ -345,6 +351,55 @@ func TestSalesReturnService_Create_ValidatesReturnQuantity(t *testing.T) {
 	})
 }
 
+func TestSalesReturnService_Create_ReasonCode(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	orderID := uuid.New()
+	orderItemID := uuid.New()
+
+	create := func(reasonCode string) (*MockSalesReturnRepository, *SalesReturnResponse, error) {
+		mockReturnRepo := new(MockSalesReturnRepository)
+		mockOrderRepo := new(MockSalesOrderRepository)
+		service := NewSalesReturnService(mockReturnRepo, mockOrderRepo)
+
+		order := createTestSalesOrderForReturn(tenantID, orderID, orderItemID, decimal.NewFromInt(100))
+		mockOrderRepo.On("FindByIDForTenant", ctx, tenantID, orderID).Return(order, nil)
+		mockReturnRepo.On("GetReturnedQuantityByOrderItems", ctx, tenantID, []uuid.UUID{orderItemID}).Return(map[uuid.UUID]decimal.Decimal{}, nil)
+		mockReturnRepo.On("GenerateReturnNumber", ctx, tenantID).Return("SR-2026-00001", nil)
+		mockReturnRepo.On("Save", ctx, mock.AnythingOfType("*trade.SalesReturn")).Return(nil)
+
+		result, err := service.Create(ctx, tenantID, CreateSalesReturnRequest{
+			SalesOrderID: orderID,
+			ReasonCode:   reasonCode,
+			Reason:       "Box crushed on arrival",
+			Items: []CreateSalesReturnItemInput{
+				{SalesOrderItemID: orderItemID, ReturnQuantity: decimal.NewFromInt(1)},
+			},
+		})
+		return mockReturnRepo, result, err
+	}
+
+	t.Run("stores the reason code with the optional note", func(t *testing.T) {
+		_, result, err := create("damaged")
+
+		require.NoError(t, err)
+		assert.Equal(t, "damaged", result.ReasonCode)
+		assert.Equal(t, "Box crushed on arrival", result.Reason)
+	})
+
+	for _, reasonCode := range []string{"", "broken"} {
+		t.Run("rejects reason code "+strconv.Quote(reasonCode), func(t *testing.T) {
+			mockReturnRepo, result, err := create(reasonCode)
+
+			assert.Nil(t, result)
+			var domainErr *shared.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, "INVALID_REASON_CODE", domainErr.Code)
+			mockReturnRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestSalesReturnService_AddItem_ValidatesReturnQuantity(t *testing.T) {
 	ctx := context.Background()
 	tenantID := uuid.New()
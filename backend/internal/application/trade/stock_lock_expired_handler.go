@@ -0,0 +1,115 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SalesOrderReader provides the sales order lookup used by the stock lock expired handler
+type SalesOrderReader interface {
+	// FindByIDForTenant finds a sales order by ID for a specific tenant
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*trade.SalesOrder, error)
+}
+
+// StockLockExpiredHandler handles StockLockExpiredEvent
+// and warns when the expired lock was reserving stock for a confirmed sales order.
+//
+// Once its lock expires, the order's stock is back in the available pool and is no longer
+// deducted when the order ships, so the order needs its stock re-reserved or reconciled manually.
+type StockLockExpiredHandler struct {
+	orderReader SalesOrderReader
+	logger      *zap.Logger
+}
+
+// NewStockLockExpiredHandler creates a new handler for stock lock expired events
+func NewStockLockExpiredHandler(orderReader SalesOrderReader, logger *zap.Logger) *StockLockExpiredHandler {
+	return &StockLockExpiredHandler{
+		orderReader: orderReader,
+		logger:      logger,
+	}
+}
+
+// EventTypes returns the event types this handler is interested in
+func (h *StockLockExpiredHandler) EventTypes() []string {
+	return []string{inventory.EventTypeStockLockExpired}
+}
+
+// Handle processes a StockLockExpiredEvent by logging an actionable warning
+// if the lock belonged to a sales order that is still waiting to ship
+func (h *StockLockExpiredHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	// Type assert to StockLockExpiredEvent
+	expiredEvent, ok := event.(*inventory.StockLockExpiredEvent)
+	if !ok {
+		h.logger.Error("unexpected event type",
+			zap.String("expected", inventory.EventTypeStockLockExpired),
+			zap.String("actual", event.EventType()),
+		)
+		return fmt.Errorf("unexpected event type: expected %s, got %s",
+			inventory.EventTypeStockLockExpired, event.EventType())
+	}
+
+	// Only locks reserved by sales orders concern trade
+	if !strings.EqualFold(expiredEvent.SourceType, "SALES_ORDER") {
+		return nil
+	}
+
+	orderID, err := uuid.Parse(expiredEvent.SourceID)
+	if err != nil {
+		h.logger.Warn("expired stock lock has an invalid sales order ID",
+			zap.String("lock_id", expiredEvent.LockID.String()),
+			zap.String("source_id", expiredEvent.SourceID),
+		)
+		return nil
+	}
+
+	order, err := h.orderReader.FindByIDForTenant(ctx, event.TenantID(), orderID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			h.logger.Warn("expired stock lock references a missing sales order",
+				zap.String("lock_id", expiredEvent.LockID.String()),
+				zap.String("order_id", orderID.String()),
+			)
+			return nil
+		}
+		return fmt.Errorf("failed to load sales order %s: %w", orderID, err)
+	}
+
+	// Shipped, completed or cancelled orders no longer rely on the lock
+	if !order.IsConfirmed() {
+		h.logger.Debug("stock lock expired for sales order that no longer holds a reservation",
+			zap.String("order_id", order.ID.String()),
+			zap.String("status", string(order.Status)),
+		)
+		return nil
+	}
+
+	fields := []zap.Field{
+		zap.String("order_id", order.ID.String()),
+		zap.String("order_number", order.OrderNumber),
+		zap.String("warehouse_id", expiredEvent.WarehouseID.String()),
+		zap.String("product_id", expiredEvent.ProductID.String()),
+		zap.String("quantity", expiredEvent.Quantity.String()),
+		zap.String("lock_id", expiredEvent.LockID.String()),
+	}
+	if item := order.GetItemByProduct(expiredEvent.ProductID); item != nil {
+		fields = append(fields,
+			zap.String("product_code", item.ProductCode),
+			zap.String("product_name", item.ProductName),
+		)
+	}
+	h.logger.Warn("stock reservation expired for confirmed sales order; "+
+		"re-reserve the stock or reconcile inventory manually after shipping", fields...)
+
+	return nil
+}
+
+// Ensure StockLockExpiredHandler implements shared.EventHandler
+var _ shared.EventHandler = (*StockLockExpiredHandler)(nil)
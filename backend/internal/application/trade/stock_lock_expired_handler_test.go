@@ -0,0 +1,116 @@
+package trade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newStockLockExpiredTestOrder(t *testing.T, productID uuid.UUID) *trade.SalesOrder {
+	order, err := trade.NewSalesOrder(testSalesHandlerTenantID, testSalesHandlerOrderNumber, testSalesHandlerCustomerID, testSalesHandlerCustomerName)
+	require.NoError(t, err)
+	_, err = order.AddItem(productID, "Test Product", "PROD-001", "pcs", "pcs", decimal.NewFromInt(5), decimal.NewFromInt(1), valueobject.NewMoneyCNY(decimal.NewFromInt(10)))
+	require.NoError(t, err)
+	require.NoError(t, order.SetWarehouse(testSalesHandlerWarehouseID))
+	return order
+}
+
+func newStockLockExpiredTestEvent(sourceType, sourceID string) *inventory.StockLockExpiredEvent {
+	return inventory.NewStockLockExpiredEvent(
+		testSalesHandlerTenantID,
+		uuid.New(),
+		testSalesHandlerWarehouseID,
+		testSalesHandlerProductID,
+		uuid.New(),
+		decimal.NewFromInt(5),
+		sourceType,
+		sourceID,
+	)
+}
+
+func TestStockLockExpiredHandler_EventTypes(t *testing.T) {
+	handler := NewStockLockExpiredHandler(nil, zap.NewNop())
+
+	assert.Equal(t, []string{inventory.EventTypeStockLockExpired}, handler.EventTypes())
+}
+
+func TestStockLockExpiredHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("warns when a confirmed order loses its reservation", func(t *testing.T) {
+		order := newStockLockExpiredTestOrder(t, testSalesHandlerProductID)
+		require.NoError(t, order.Confirm())
+		repo := new(MockSalesOrderRepository)
+		repo.On("FindByIDForTenant", ctx, testSalesHandlerTenantID, order.ID).Return(order, nil)
+		core, logs := observer.New(zapcore.WarnLevel)
+		handler := NewStockLockExpiredHandler(repo, zap.New(core))
+
+		err := handler.Handle(ctx, newStockLockExpiredTestEvent("SALES_ORDER", order.ID.String()))
+
+		require.NoError(t, err)
+		require.Equal(t, 1, logs.Len())
+		fields := logs.All()[0].ContextMap()
+		assert.Equal(t, testSalesHandlerOrderNumber, fields["order_number"])
+		assert.Equal(t, testSalesHandlerProductID.String(), fields["product_id"])
+		assert.Equal(t, testSalesHandlerWarehouseID.String(), fields["warehouse_id"])
+		assert.Equal(t, "5", fields["quantity"])
+		assert.Equal(t, "PROD-001", fields["product_code"])
+	})
+
+	t.Run("stays quiet when the order has already shipped", func(t *testing.T) {
+		order := newStockLockExpiredTestOrder(t, testSalesHandlerProductID)
+		require.NoError(t, order.Confirm())
+		require.NoError(t, order.Ship())
+		repo := new(MockSalesOrderRepository)
+		repo.On("FindByIDForTenant", ctx, testSalesHandlerTenantID, order.ID).Return(order, nil)
+		core, logs := observer.New(zapcore.WarnLevel)
+		handler := NewStockLockExpiredHandler(repo, zap.New(core))
+
+		err := handler.Handle(ctx, newStockLockExpiredTestEvent("SALES_ORDER", order.ID.String()))
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, logs.Len())
+	})
+
+	t.Run("ignores locks not held by sales orders", func(t *testing.T) {
+		repo := new(MockSalesOrderRepository)
+		handler := NewStockLockExpiredHandler(repo, zap.NewNop())
+
+		err := handler.Handle(ctx, newStockLockExpiredTestEvent("MANUAL", "ADJ-001"))
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "FindByIDForTenant", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("tolerates a missing order", func(t *testing.T) {
+		orderID := uuid.New()
+		repo := new(MockSalesOrderRepository)
+		repo.On("FindByIDForTenant", ctx, testSalesHandlerTenantID, orderID).Return(nil, shared.ErrNotFound)
+		handler := NewStockLockExpiredHandler(repo, zap.NewNop())
+
+		err := handler.Handle(ctx, newStockLockExpiredTestEvent("SALES_ORDER", orderID.String()))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects other events", func(t *testing.T) {
+		handler := NewStockLockExpiredHandler(new(MockSalesOrderRepository), zap.NewNop())
+		event := &trade.SalesOrderCancelledEvent{
+			BaseDomainEvent: shared.NewBaseDomainEvent(trade.EventTypeSalesOrderCancelled, trade.AggregateTypeSalesOrder, testSalesHandlerOrderID, testSalesHandlerTenantID),
+		}
+
+		assert.Error(t, handler.Handle(ctx, event))
+	})
+}
@@ -272,6 +272,7 @@ type QuotaCheckResult struct {
 	UsagePercent  float64
 	OveragePolicy OveragePolicy
 	IsUnlimited   bool
+	ResetAt       time.Time // Start of the next quota period, set by the caller that knows it (zero if the quota never resets)
 }
 
 // IsAllowed returns true if the usage is allowed based on the overage policy
@@ -0,0 +1,88 @@
+package billing
+
+import (
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// MaxUsageSeriesBuckets caps the number of buckets in a usage series
+const MaxUsageSeriesBuckets = 1000
+
+// IsSeriesGranularity returns true if the period can bucket a usage series
+func (a AggregationPeriod) IsSeriesGranularity() bool {
+	switch a {
+	case AggregationPeriodHour, AggregationPeriodDay, AggregationPeriodMonth:
+		return true
+	}
+	return false
+}
+
+// BucketStart returns the start of the period containing t, with periods
+// aligned to the calendar of loc (e.g. days start at local midnight)
+func (a AggregationPeriod) BucketStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch a {
+	case AggregationPeriodHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+	case AggregationPeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+// NextBucketStart returns the start of the period following the one starting at start
+func (a AggregationPeriod) NextBucketStart(start time.Time) time.Time {
+	switch a {
+	case AggregationPeriodHour:
+		return start.Add(time.Hour)
+	case AggregationPeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// NewUsageSeries builds a dense series of usage meters covering [from, to), one per
+// period of the given granularity in loc. Aggregations are matched to buckets by
+// period start; buckets without an aggregation are zero rather than missing.
+// Meter periods are half-open: PeriodEnd is the start of the next bucket.
+func NewUsageSeries(
+	tenantID uuid.UUID,
+	usageType UsageType,
+	from, to time.Time,
+	granularity AggregationPeriod,
+	loc *time.Location,
+	aggregations []UsageAggregation,
+) ([]*UsageMeter, error) {
+	if !usageType.IsValid() {
+		return nil, shared.NewDomainError("INVALID_USAGE_TYPE", "Invalid usage type")
+	}
+	if !granularity.IsSeriesGranularity() {
+		return nil, shared.NewDomainError("INVALID_GRANULARITY", "Granularity must be HOUR, DAY or MONTH")
+	}
+	if !from.Before(to) {
+		return nil, shared.NewDomainError("INVALID_TIME_RANGE", "From must be before to")
+	}
+
+	byStart := make(map[int64]UsageAggregation, len(aggregations))
+	for _, agg := range aggregations {
+		byStart[agg.PeriodStart.Unix()] = agg
+	}
+
+	var series []*UsageMeter
+	for start := granularity.BucketStart(from, loc); start.Before(to); start = granularity.NextBucketStart(start) {
+		if len(series) == MaxUsageSeriesBuckets {
+			return nil, shared.NewDomainError("USAGE_SERIES_TOO_LARGE",
+				"Time range produces too many buckets; use a coarser granularity or a shorter range")
+		}
+		meter := NewUsageMeter(tenantID, usageType, start, granularity.NextBucketStart(start))
+		if agg, ok := byStart[start.Unix()]; ok {
+			meter.WithTotalUsage(agg.TotalUsage).WithRecordCount(agg.RecordCount).WithPeakUsage(agg.MaxUsage)
+		}
+		series = append(series, meter)
+	}
+	return series, nil
+}
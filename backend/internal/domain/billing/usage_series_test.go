@@ -0,0 +1,98 @@
+package billing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUsageSeries(t *testing.T) {
+	tenantID := uuid.New()
+	shanghai := time.FixedZone("CST", 8*60*60)
+
+	t.Run("days follow the tenant's time zone across a day boundary", func(t *testing.T) {
+		// 20:00 UTC on March 1 is already March 2 in Shanghai
+		from := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+		aggregations := []UsageAggregation{
+			{PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, shanghai), TotalUsage: 40, RecordCount: 4, MaxUsage: 15},
+			{PeriodStart: time.Date(2026, 3, 2, 0, 0, 0, 0, shanghai), TotalUsage: 7, RecordCount: 1, MaxUsage: 7},
+		}
+
+		series, err := NewUsageSeries(tenantID, UsageTypeAPICalls, from, to, AggregationPeriodDay, shanghai, aggregations)
+		require.NoError(t, err)
+
+		require.Len(t, series, 3)
+		assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, shanghai), series[0].PeriodStart)
+		assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, shanghai), series[0].PeriodEnd)
+		assert.Equal(t, int64(40), series[0].TotalUsage)
+		assert.Equal(t, int64(4), series[0].RecordCount)
+		assert.Equal(t, int64(15), series[0].PeakUsage)
+		assert.Equal(t, int64(7), series[1].TotalUsage)
+		assert.Equal(t, time.Date(2026, 3, 3, 0, 0, 0, 0, shanghai), series[2].PeriodStart)
+		assert.Zero(t, series[2].TotalUsage)
+		for _, meter := range series {
+			assert.Equal(t, tenantID, meter.TenantID)
+			assert.Equal(t, UsageTypeAPICalls, meter.UsageType)
+		}
+	})
+
+	t.Run("sparse periods produce zero buckets", func(t *testing.T) {
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+		aggregations := []UsageAggregation{
+			{PeriodStart: time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC), TotalUsage: 3, RecordCount: 3},
+			{PeriodStart: time.Date(2026, 3, 1, 4, 0, 0, 0, time.UTC), TotalUsage: 9, RecordCount: 2},
+		}
+
+		series, err := NewUsageSeries(tenantID, UsageTypeAPICalls, from, to, AggregationPeriodHour, time.UTC, aggregations)
+		require.NoError(t, err)
+
+		totals := make([]int64, len(series))
+		for i, meter := range series {
+			totals[i] = meter.TotalUsage
+		}
+		assert.Equal(t, []int64{0, 3, 0, 0, 9, 0}, totals)
+	})
+
+	t.Run("months", func(t *testing.T) {
+		from := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+		series, err := NewUsageSeries(tenantID, UsageTypeOrdersCreated, from, to, AggregationPeriodMonth, time.UTC, nil)
+		require.NoError(t, err)
+
+		require.Len(t, series, 3)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), series[0].PeriodStart)
+		assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), series[1].PeriodStart)
+		assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), series[2].PeriodEnd)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		cases := []struct {
+			name        string
+			usageType   UsageType
+			to          time.Time
+			granularity AggregationPeriod
+			code        string
+		}{
+			{"unknown usage type", UsageType("UNKNOWN"), from.AddDate(0, 0, 1), AggregationPeriodDay, "INVALID_USAGE_TYPE"},
+			{"weekly granularity", UsageTypeAPICalls, from.AddDate(0, 0, 1), AggregationPeriodWeek, "INVALID_GRANULARITY"},
+			{"empty range", UsageTypeAPICalls, from, AggregationPeriodDay, "INVALID_TIME_RANGE"},
+			{"too many buckets", UsageTypeAPICalls, from.AddDate(1, 0, 0), AggregationPeriodHour, "USAGE_SERIES_TOO_LARGE"},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewUsageSeries(tenantID, tc.usageType, from, tc.to, tc.granularity, time.UTC, nil)
+				var domainErr *shared.DomainError
+				require.ErrorAs(t, err, &domainErr)
+				assert.Equal(t, tc.code, domainErr.Code)
+			})
+		}
+	})
+}
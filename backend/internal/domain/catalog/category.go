@@ -12,6 +12,9 @@ import (
 // MaxCategoryDepth is the maximum depth of category hierarchy
 const MaxCategoryDepth = 5
 
+// ErrCategoryCycle is returned when a category would become its own ancestor
+var ErrCategoryCycle = shared.NewDomainError("CIRCULAR_REFERENCE", "Cannot move category under itself or one of its descendants")
+
 // CategoryStatus represents the status of a category
 type CategoryStatus string
 
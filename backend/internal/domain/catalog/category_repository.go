@@ -61,7 +61,7 @@ type CategoryRepository interface {
 	// ExistsByCode checks if a category with the given code exists in the tenant
 	ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error)
 
-	// UpdatePath updates the path for a category and its descendants
-	// This is used when moving a category to a new parent
-	UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, newPath string, levelDelta int) error
+	// UpdatePath sets the parent of a category and updates the path for the category and its descendants
+	// This is used when moving a category to a new parent (nil parentID moves it to the root)
+	UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, parentID *uuid.UUID, newPath string, levelDelta int) error
 }
@@ -35,6 +35,11 @@ type Product struct {
 	Status        ProductStatus   // Product status
 	SortOrder     int             // Display order
 	Attributes    string          // JSON storage for custom attributes
+	IsSerialized  bool            // Each unit is tracked by serial number on receipt and shipment
+	Weight        decimal.Decimal // Weight of one base unit in kilograms
+	Volume        decimal.Decimal // Volume of one base unit in cubic metres
+	PriceTiers    []PriceTier     // Quantity breaks by ascending minimum quantity
+	DeletedAt     *time.Time      // Set when the product is soft deleted
 }
 
 // NewProduct creates a new product
@@ -57,6 +62,8 @@ func NewProduct(tenantID uuid.UUID, code, name, unit string) (*Product, error) {
 		PurchasePrice:       decimal.Zero,
 		SellingPrice:        decimal.Zero,
 		MinStock:            decimal.Zero,
+		Weight:              decimal.Zero,
+		Volume:              decimal.Zero,
 		Status:              ProductStatusActive,
 		Attributes:          "{}",
 	}
@@ -212,6 +219,31 @@ func (p *Product) SetSortOrder(order int) {
 	p.IncrementVersion()
 }
 
+// SetSerialized sets whether the product's units are tracked by serial number
+func (p *Product) SetSerialized(serialized bool) {
+	p.IsSerialized = serialized
+	p.UpdatedAt = time.Now()
+	p.IncrementVersion()
+}
+
+// SetDimensions sets the weight (kg) and volume (m³) of one base unit,
+// used to work out how much warehouse capacity the product's stock takes up
+func (p *Product) SetDimensions(weight, volume decimal.Decimal) error {
+	if weight.IsNegative() {
+		return shared.NewDomainError("INVALID_WEIGHT", "Weight cannot be negative")
+	}
+	if volume.IsNegative() {
+		return shared.NewDomainError("INVALID_VOLUME", "Volume cannot be negative")
+	}
+
+	p.Weight = weight
+	p.Volume = volume
+	p.UpdatedAt = time.Now()
+	p.IncrementVersion()
+
+	return nil
+}
+
 // SetAttributes sets custom attributes as JSON
 func (p *Product) SetAttributes(attributes string) error {
 	if attributes == "" {
@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/plugin"
+)
+
+// ErrMissingRequiredAttributes is returned when a product does not satisfy the attribute schema of its industry.
+// Use errors.Is to detect it and errors.As with *MissingRequiredAttributesError to get the offending attributes.
+var ErrMissingRequiredAttributes = shared.NewDomainError("MISSING_REQUIRED_ATTRIBUTES", "Product is missing required attributes")
+
+// AttributeViolationReason describes why an attribute failed validation
+type AttributeViolationReason string
+
+const (
+	AttributeViolationMissing       AttributeViolationReason = "REQUIRED"
+	AttributeViolationInvalidFormat AttributeViolationReason = "INVALID_FORMAT"
+)
+
+// AttributeViolation describes a single missing or invalid product attribute
+type AttributeViolation struct {
+	Key    string                   // Attribute key, e.g. "registration_number"
+	Label  string                   // Display name of the attribute
+	Reason AttributeViolationReason // Why the attribute was rejected
+}
+
+// MissingRequiredAttributesError lists the attributes that failed an industry plugin's attribute schema
+type MissingRequiredAttributesError struct {
+	Plugin     string
+	Violations []AttributeViolation
+}
+
+// Error implements the error interface
+func (e *MissingRequiredAttributesError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		switch v.Reason {
+		case AttributeViolationInvalidFormat:
+			parts[i] = fmt.Sprintf("%s (%s) has an invalid format", v.Key, v.Label)
+		default:
+			parts[i] = fmt.Sprintf("%s (%s) is required", v.Key, v.Label)
+		}
+	}
+	return "Product attributes do not satisfy the " + e.Plugin + " industry requirements: " + strings.Join(parts, "; ")
+}
+
+// Unwrap returns ErrMissingRequiredAttributes so the error maps to the MISSING_REQUIRED_ATTRIBUTES domain error code
+func (e *MissingRequiredAttributesError) Unwrap() error {
+	return ErrMissingRequiredAttributes
+}
+
+// ValidateIndustryAttributes checks the product's attributes against the attribute definitions of an industry plugin.
+// A definition applies when it lists no category codes or lists categoryCode.
+// Applicable required attributes must be present, and present attributes must match the definition's pattern.
+// Returns a *MissingRequiredAttributesError listing every violation, or nil.
+func ValidateIndustryAttributes(pluginName string, product *Product, categoryCode string, definitions []plugin.AttributeDefinition) error {
+	attributes := map[string]any{}
+	if product.Attributes != "" {
+		// Attributes that are not a valid JSON object are treated as empty
+		_ = json.Unmarshal([]byte(product.Attributes), &attributes)
+	}
+
+	var violations []AttributeViolation
+	for _, def := range definitions {
+		if len(def.CategoryCodes) > 0 && !slices.Contains(def.CategoryCodes, categoryCode) {
+			continue
+		}
+
+		value := attributeString(attributes[def.Key])
+		if value == "" {
+			if def.Required {
+				violations = append(violations, AttributeViolation{Key: def.Key, Label: def.Label, Reason: AttributeViolationMissing})
+			}
+			continue
+		}
+
+		if def.Regex != "" {
+			pattern, err := regexp.Compile(def.Regex)
+			if err == nil && !pattern.MatchString(value) {
+				violations = append(violations, AttributeViolation{Key: def.Key, Label: def.Label, Reason: AttributeViolationInvalidFormat})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &MissingRequiredAttributesError{Plugin: pluginName, Violations: violations}
+	}
+	return nil
+}
+
+// attributeString returns an attribute value as trimmed text
+func attributeString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
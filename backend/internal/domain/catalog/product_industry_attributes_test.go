@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/shared/plugin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIndustryAttributes(t *testing.T) {
+	definitions := []plugin.AttributeDefinition{
+		{Key: "manufacturer", Label: "Manufacturer", Required: true},
+		{Key: "registration_number", Label: "Registration Number", Required: true, Regex: `^PD\d{8}$`, CategoryCodes: []string{"PESTICIDE"}},
+		{Key: "license", Label: "License", Regex: `^L\d+$`},
+	}
+
+	newProduct := func(t *testing.T, attributes string) *Product {
+		product, err := NewProduct(uuid.New(), "SKU-001", "Product", "pcs")
+		require.NoError(t, err)
+		require.NoError(t, product.SetAttributes(attributes))
+		return product
+	}
+
+	t.Run("passes when required attributes are present", func(t *testing.T) {
+		product := newProduct(t, `{"manufacturer": "Acme", "registration_number": "PD20240001"}`)
+
+		assert.NoError(t, ValidateIndustryAttributes("agricultural", product, "PESTICIDE", definitions))
+	})
+
+	t.Run("ignores definitions for other categories", func(t *testing.T) {
+		product := newProduct(t, `{"manufacturer": "Acme"}`)
+
+		assert.NoError(t, ValidateIndustryAttributes("agricultural", product, "SEED", definitions))
+	})
+
+	t.Run("lists every missing and invalid attribute", func(t *testing.T) {
+		product := newProduct(t, `{"manufacturer": "  ", "license": "X-1"}`)
+
+		err := ValidateIndustryAttributes("agricultural", product, "PESTICIDE", definitions)
+
+		var attrErr *MissingRequiredAttributesError
+		require.ErrorAs(t, err, &attrErr)
+		assert.True(t, errors.Is(err, ErrMissingRequiredAttributes))
+		assert.Equal(t, []AttributeViolation{
+			{Key: "manufacturer", Label: "Manufacturer", Reason: AttributeViolationMissing},
+			{Key: "registration_number", Label: "Registration Number", Reason: AttributeViolationMissing},
+			{Key: "license", Label: "License", Reason: AttributeViolationInvalidFormat},
+		}, attrErr.Violations)
+	})
+
+	t.Run("rejects a value that does not match the pattern", func(t *testing.T) {
+		product := newProduct(t, `{"manufacturer": "Acme", "registration_number": "PD123"}`)
+
+		err := ValidateIndustryAttributes("agricultural", product, "PESTICIDE", definitions)
+
+		var attrErr *MissingRequiredAttributesError
+		require.ErrorAs(t, err, &attrErr)
+		require.Len(t, attrErr.Violations, 1)
+		assert.Equal(t, AttributeViolationInvalidFormat, attrErr.Violations[0].Reason)
+	})
+}
@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PriceType identifies which product price a history entry refers to
+type PriceType string
+
+const (
+	PriceTypePurchase PriceType = "PURCHASE"
+	PriceTypeSelling  PriceType = "SELLING"
+)
+
+// IsValid checks if the price type is valid
+func (t PriceType) IsValid() bool {
+	switch t {
+	case PriceTypePurchase, PriceTypeSelling:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProductPriceHistory records a single change of a product price.
+// Entries are append-only: together they allow the price of a product to be looked up at any point in time.
+type ProductPriceHistory struct {
+	shared.BaseEntity
+	TenantID    uuid.UUID
+	ProductID   uuid.UUID
+	PriceType   PriceType
+	OldPrice    decimal.Decimal
+	NewPrice    decimal.Decimal
+	ChangedBy   *uuid.UUID // User who changed the price (optional)
+	EffectiveAt time.Time  // When the new price took effect
+}
+
+// NewProductPriceHistory creates a new price history entry
+func NewProductPriceHistory(
+	tenantID uuid.UUID,
+	productID uuid.UUID,
+	priceType PriceType,
+	oldPrice decimal.Decimal,
+	newPrice decimal.Decimal,
+	changedBy *uuid.UUID,
+	effectiveAt time.Time,
+) (*ProductPriceHistory, error) {
+	if tenantID == uuid.Nil {
+		return nil, shared.NewDomainError("INVALID_TENANT_ID", "Tenant ID cannot be empty")
+	}
+	if productID == uuid.Nil {
+		return nil, shared.NewDomainError("INVALID_PRODUCT_ID", "Product ID cannot be empty")
+	}
+	if !priceType.IsValid() {
+		return nil, shared.NewDomainError("INVALID_PRICE_TYPE", "Invalid price type")
+	}
+	if oldPrice.IsNegative() || newPrice.IsNegative() {
+		return nil, shared.NewDomainError("INVALID_PRICE", "Price cannot be negative")
+	}
+	if oldPrice.Equal(newPrice) {
+		return nil, shared.NewDomainError("INVALID_PRICE", "Price history entry requires a price change")
+	}
+	if effectiveAt.IsZero() {
+		effectiveAt = time.Now()
+	}
+
+	return &ProductPriceHistory{
+		BaseEntity:  shared.NewBaseEntity(),
+		TenantID:    tenantID,
+		ProductID:   productID,
+		PriceType:   priceType,
+		OldPrice:    oldPrice,
+		NewPrice:    newPrice,
+		ChangedBy:   changedBy,
+		EffectiveAt: effectiveAt,
+	}, nil
+}
+
+// NewPriceHistoryEntries creates history entries for the prices of the product that differ from the given old prices.
+// Returns no entries if neither price changed.
+func NewPriceHistoryEntries(
+	product *Product,
+	oldPurchasePrice decimal.Decimal,
+	oldSellingPrice decimal.Decimal,
+	changedBy *uuid.UUID,
+	effectiveAt time.Time,
+) ([]*ProductPriceHistory, error) {
+	var entries []*ProductPriceHistory
+	changes := []struct {
+		priceType PriceType
+		oldPrice  decimal.Decimal
+		newPrice  decimal.Decimal
+	}{
+		{PriceTypePurchase, oldPurchasePrice, product.PurchasePrice},
+		{PriceTypeSelling, oldSellingPrice, product.SellingPrice},
+	}
+	for _, change := range changes {
+		if change.oldPrice.Equal(change.newPrice) {
+			continue
+		}
+		entry, err := NewProductPriceHistory(product.TenantID, product.ID, change.priceType,
+			change.oldPrice, change.newPrice, changedBy, effectiveAt)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,30 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// ProductPriceHistoryRepository defines the interface for product price history persistence.
+// Price history is append-only: entries are never updated or deleted.
+type ProductPriceHistoryRepository interface {
+	// FindByProduct finds price history entries for a product, most recent first
+	FindByProduct(ctx context.Context, tenantID, productID uuid.UUID, filter shared.Filter) ([]ProductPriceHistory, error)
+
+	// CountByProduct counts price history entries for a product
+	CountByProduct(ctx context.Context, tenantID, productID uuid.UUID) (int64, error)
+
+	// FindLatestAt finds the most recent change of the given price type that took effect at or before the given time.
+	// Returns shared.ErrNotFound if there is none.
+	FindLatestAt(ctx context.Context, tenantID, productID uuid.UUID, priceType PriceType, at time.Time) (*ProductPriceHistory, error)
+
+	// FindEarliestAfter finds the first change of the given price type that took effect after the given time.
+	// Returns shared.ErrNotFound if there is none.
+	FindEarliestAfter(ctx context.Context, tenantID, productID uuid.UUID, priceType PriceType, at time.Time) (*ProductPriceHistory, error)
+
+	// CreateBatch creates multiple price history entries
+	CreateBatch(ctx context.Context, entries []*ProductPriceHistory) error
+}
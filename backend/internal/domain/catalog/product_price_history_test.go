@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProductPriceHistory(t *testing.T) {
+	tenantID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	effectiveAt := time.Now()
+
+	t.Run("creates entry with valid inputs", func(t *testing.T) {
+		entry, err := NewProductPriceHistory(tenantID, productID, PriceTypeSelling,
+			decimal.NewFromInt(100), decimal.NewFromInt(120), &userID, effectiveAt)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, uuid.Nil, entry.ID)
+		assert.Equal(t, PriceTypeSelling, entry.PriceType)
+		assert.True(t, entry.NewPrice.Equal(decimal.NewFromInt(120)))
+		assert.Equal(t, &userID, entry.ChangedBy)
+		assert.Equal(t, effectiveAt, entry.EffectiveAt)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		_, err := NewProductPriceHistory(tenantID, productID, PriceType("RETAIL"),
+			decimal.NewFromInt(100), decimal.NewFromInt(120), nil, effectiveAt)
+		assert.Error(t, err)
+
+		_, err = NewProductPriceHistory(tenantID, uuid.Nil, PriceTypeSelling,
+			decimal.NewFromInt(100), decimal.NewFromInt(120), nil, effectiveAt)
+		assert.Error(t, err)
+
+		_, err = NewProductPriceHistory(tenantID, productID, PriceTypeSelling,
+			decimal.NewFromInt(100), decimal.NewFromInt(100), nil, effectiveAt)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewPriceHistoryEntries(t *testing.T) {
+	product, err := NewProduct(uuid.New(), "SKU-001", "Test Product", "pcs")
+	require.NoError(t, err)
+	oldPurchasePrice := product.PurchasePrice
+	oldSellingPrice := product.SellingPrice
+
+	require.NoError(t, product.SetPrices(
+		valueobject.NewMoneyCNY(oldPurchasePrice),
+		valueobject.NewMoneyCNY(decimal.NewFromInt(99)),
+	))
+
+	entries, err := NewPriceHistoryEntries(product, oldPurchasePrice, oldSellingPrice, nil, product.UpdatedAt)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, PriceTypeSelling, entries[0].PriceType)
+	assert.Equal(t, product.ID, entries[0].ProductID)
+	assert.Equal(t, product.TenantID, entries[0].TenantID)
+	assert.True(t, entries[0].OldPrice.Equal(oldSellingPrice))
+
+	entries, err = NewPriceHistoryEntries(product, product.PurchasePrice, product.SellingPrice, nil, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
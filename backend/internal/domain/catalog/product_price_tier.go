@@ -0,0 +1,54 @@
+package catalog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/shopspring/decimal"
+)
+
+// PriceTier is a quantity break of a product: ordering at least MinQuantity sells at UnitPrice
+type PriceTier struct {
+	MinQuantity decimal.Decimal `json:"min_quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+}
+
+// ValidatePriceTiers checks that price tiers are listed by ascending minimum quantity without overlapping.
+// Every minimum quantity must be positive and greater than the one before, and no unit price can be negative.
+func ValidatePriceTiers(tiers []PriceTier) error {
+	for i, tier := range tiers {
+		if !tier.MinQuantity.IsPositive() {
+			return shared.NewDomainError("INVALID_PRICE_TIERS",
+				fmt.Sprintf("Minimum quantity of price tier %d must be positive", i+1))
+		}
+		if tier.UnitPrice.IsNegative() {
+			return shared.NewDomainError("INVALID_PRICE_TIERS",
+				fmt.Sprintf("Unit price of price tier %d cannot be negative", i+1))
+		}
+		if i > 0 && !tier.MinQuantity.GreaterThan(tiers[i-1].MinQuantity) {
+			return shared.NewDomainError("INVALID_PRICE_TIERS",
+				fmt.Sprintf("Price tier %d overlaps the tier before it; minimum quantities must be ascending", i+1))
+		}
+	}
+	return nil
+}
+
+// SetPriceTiers replaces the quantity breaks of the product; an empty list removes them
+func (p *Product) SetPriceTiers(tiers []PriceTier) error {
+	if err := ValidatePriceTiers(tiers); err != nil {
+		return err
+	}
+
+	p.PriceTiers = make([]PriceTier, len(tiers))
+	copy(p.PriceTiers, tiers)
+	p.UpdatedAt = time.Now()
+	p.IncrementVersion()
+
+	return nil
+}
+
+// HasPriceTiers returns true if the product sells at lower prices for larger quantities
+func (p *Product) HasPriceTiers() bool {
+	return len(p.PriceTiers) > 0
+}
@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func priceTier(minQuantity, unitPrice int64) PriceTier {
+	return PriceTier{MinQuantity: decimal.NewFromInt(minQuantity), UnitPrice: decimal.NewFromInt(unitPrice)}
+}
+
+func TestProduct_SetPriceTiers(t *testing.T) {
+	newProduct := func(t *testing.T) *Product {
+		product, err := NewProduct(uuid.New(), "SKU-001", "Test Product", "pcs")
+		require.NoError(t, err)
+		return product
+	}
+
+	t.Run("sets ascending tiers", func(t *testing.T) {
+		product := newProduct(t)
+		version := product.GetVersion()
+
+		err := product.SetPriceTiers([]PriceTier{priceTier(10, 95), priceTier(50, 90)})
+
+		require.NoError(t, err)
+		assert.True(t, product.HasPriceTiers())
+		assert.Equal(t, []PriceTier{priceTier(10, 95), priceTier(50, 90)}, product.PriceTiers)
+		assert.Equal(t, version+1, product.GetVersion())
+	})
+
+	t.Run("empty list removes the tiers", func(t *testing.T) {
+		product := newProduct(t)
+		require.NoError(t, product.SetPriceTiers([]PriceTier{priceTier(10, 95)}))
+
+		require.NoError(t, product.SetPriceTiers(nil))
+		assert.False(t, product.HasPriceTiers())
+	})
+
+	t.Run("rejects invalid tiers", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			tiers []PriceTier
+		}{
+			{"descending", []PriceTier{priceTier(50, 90), priceTier(10, 95)}},
+			{"overlapping", []PriceTier{priceTier(10, 95), priceTier(10, 90)}},
+			{"zero minimum quantity", []PriceTier{priceTier(0, 95)}},
+			{"negative unit price", []PriceTier{priceTier(10, -1)}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				product := newProduct(t)
+
+				err := product.SetPriceTiers(tt.tiers)
+
+				var domainErr *shared.DomainError
+				require.ErrorAs(t, err, &domainErr)
+				assert.Equal(t, "INVALID_PRICE_TIERS", domainErr.Code)
+				assert.False(t, product.HasPriceTiers())
+			})
+		}
+	})
+}
@@ -16,6 +16,9 @@ type ProductReader interface {
 	// FindByIDForTenant finds a product by ID within a tenant
 	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*Product, error)
 
+	// FindDeletedByIDForTenant finds a soft-deleted product by ID within a tenant
+	FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*Product, error)
+
 	// FindByCode finds a product by its code within a tenant
 	FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*Product, error)
 
@@ -27,6 +30,9 @@ type ProductReader interface {
 
 	// FindByCodes finds multiple products by their codes
 	FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]Product, error)
+
+	// FindByBarcodes finds multiple products by their barcodes
+	FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]Product, error)
 }
 
 // ProductFinder defines the interface for searching and filtering products
@@ -75,6 +81,11 @@ type ProductWriter interface {
 	// Save creates or updates a product
 	Save(ctx context.Context, product *Product) error
 
+	// SaveWithLock updates a product only if its stored version is still expectedVersion.
+	// Product methods bump Version as they change the product, so the version it was loaded with is passed in.
+	// Returns a shared.VersionConflictError if another update got there first.
+	SaveWithLock(ctx context.Context, product *Product, expectedVersion int) error
+
 	// SaveBatch creates or updates multiple products
 	SaveBatch(ctx context.Context, products []*Product) error
 
@@ -83,6 +94,9 @@ type ProductWriter interface {
 
 	// DeleteForTenant deletes a product within a tenant
 	DeleteForTenant(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// RestoreForTenant restores a soft-deleted product within a tenant
+	RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error
 }
 
 // ProductRepository defines the full interface for product persistence
@@ -322,6 +322,32 @@ func TestProductMinStock(t *testing.T) {
 	})
 }
 
+func TestProductDimensions(t *testing.T) {
+	tenantID := uuid.New()
+	product, _ := NewProduct(tenantID, "SKU-001", "Test", "pcs")
+	assert.True(t, product.Weight.IsZero())
+	assert.True(t, product.Volume.IsZero())
+
+	t.Run("sets weight and volume", func(t *testing.T) {
+		err := product.SetDimensions(decimal.NewFromFloat(2.5), decimal.NewFromFloat(0.012))
+		require.NoError(t, err)
+		assert.True(t, product.Weight.Equal(decimal.NewFromFloat(2.5)))
+		assert.True(t, product.Volume.Equal(decimal.NewFromFloat(0.012)))
+	})
+
+	t.Run("fails with negative weight", func(t *testing.T) {
+		err := product.SetDimensions(decimal.NewFromInt(-1), decimal.Zero)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Weight cannot be negative")
+	})
+
+	t.Run("fails with negative volume", func(t *testing.T) {
+		err := product.SetDimensions(decimal.Zero, decimal.NewFromInt(-1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Volume cannot be negative")
+	})
+}
+
 func TestProductSortOrder(t *testing.T) {
 	tenantID := uuid.New()
 	product, _ := NewProduct(tenantID, "SKU-001", "Test", "pcs")
@@ -9,14 +9,16 @@ import (
 )
 
 // ProductUnit represents an alternate unit for a product with conversion rate
-// It defines how different units relate to the base unit (e.g., 1 box = 24 pcs)
+// It defines how different units relate to the base unit (e.g., 1 box = 24 pcs),
+// or to another unit of the product (e.g., 1 pallet = 40 case)
 type ProductUnit struct {
 	ID                    uuid.UUID       // Primary key
 	TenantID              uuid.UUID       // Tenant ID reference
 	ProductID             uuid.UUID       // Product ID reference
 	UnitCode              string          // Unit code (e.g., "box", "case")
 	UnitName              string          // Unit display name
-	ConversionRate        decimal.Decimal // Rate to convert to base unit
+	ConversionRate        decimal.Decimal // Rate to convert to the reference unit
+	ReferenceUnitCode     string          // Unit the conversion rate is relative to; empty for the base unit
 	DefaultPurchasePrice  decimal.Decimal // Default purchase price for this unit
 	DefaultSellingPrice   decimal.Decimal // Default selling price for this unit
 	IsDefaultPurchaseUnit bool            // Whether this is the default purchase unit
@@ -71,6 +73,29 @@ func (pu *ProductUnit) Update(unitName string, conversionRate decimal.Decimal) e
 	return nil
 }
 
+// SetReferenceUnit sets the unit that the conversion rate is relative to
+// An empty unit code makes the rate relative to the product's base unit
+func (pu *ProductUnit) SetReferenceUnit(unitCode string) error {
+	if unitCode != "" {
+		if err := validateUnitCode(unitCode); err != nil {
+			return err
+		}
+		if unitCode == pu.UnitCode {
+			return shared.NewDomainError("INVALID_REFERENCE_UNIT", "Unit cannot be converted relative to itself")
+		}
+	}
+
+	pu.ReferenceUnitCode = unitCode
+	pu.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// IsRelativeToBaseUnit returns true if the conversion rate converts directly to the base unit
+func (pu *ProductUnit) IsRelativeToBaseUnit() bool {
+	return pu.ReferenceUnitCode == ""
+}
+
 // SetPrices sets default prices for this unit
 func (pu *ProductUnit) SetPrices(purchasePrice, sellingPrice decimal.Decimal) error {
 	if purchasePrice.IsNegative() {
@@ -107,12 +132,14 @@ func (pu *ProductUnit) SetSortOrder(order int) {
 
 // ConvertToBaseUnit converts quantity from this unit to base unit
 // Formula: baseQuantity = quantity * conversionRate
+// Only valid for units relative to the base unit; use UnitConversionGraph otherwise
 func (pu *ProductUnit) ConvertToBaseUnit(quantity decimal.Decimal) decimal.Decimal {
 	return quantity.Mul(pu.ConversionRate).Round(4)
 }
 
 // ConvertFromBaseUnit converts quantity from base unit to this unit
 // Formula: unitQuantity = baseQuantity / conversionRate
+// Only valid for units relative to the base unit; use UnitConversionGraph otherwise
 func (pu *ProductUnit) ConvertFromBaseUnit(baseQuantity decimal.Decimal) decimal.Decimal {
 	if pu.ConversionRate.IsZero() {
 		return decimal.Zero
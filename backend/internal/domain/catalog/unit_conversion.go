@@ -0,0 +1,98 @@
+package catalog
+
+import (
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoConversionPath is returned when two units of a product are not connected by conversion rates
+var ErrNoConversionPath = shared.NewDomainError("NO_CONVERSION_PATH", "No conversion path exists between the units")
+
+// unitConversionEdge converts a quantity to an adjacent unit: quantity * numerator / denominator
+type unitConversionEdge struct {
+	to          string
+	numerator   decimal.Decimal
+	denominator decimal.Decimal
+}
+
+// UnitConversionGraph converts quantities between the units of a product.
+// Every unit is linked to its reference unit (the base unit when none is set) by its conversion rate,
+// so units can be converted through any number of intermediate units (e.g., box -> case -> pallet).
+type UnitConversionGraph struct {
+	units map[string]bool
+	edges map[string][]unitConversionEdge
+}
+
+// NewUnitConversionGraph builds the conversion graph for a product's base unit and alternate units
+func NewUnitConversionGraph(baseUnit string, units []ProductUnit) *UnitConversionGraph {
+	g := &UnitConversionGraph{
+		units: map[string]bool{baseUnit: true},
+		edges: make(map[string][]unitConversionEdge),
+	}
+
+	one := decimal.NewFromInt(1)
+	for _, u := range units {
+		if !u.ConversionRate.IsPositive() {
+			continue
+		}
+		g.units[u.UnitCode] = true
+
+		reference := u.ReferenceUnitCode
+		if reference == "" {
+			reference = baseUnit
+		}
+		// 1 unit = rate reference units
+		g.edges[u.UnitCode] = append(g.edges[u.UnitCode], unitConversionEdge{to: reference, numerator: u.ConversionRate, denominator: one})
+		g.edges[reference] = append(g.edges[reference], unitConversionEdge{to: u.UnitCode, numerator: one, denominator: u.ConversionRate})
+	}
+
+	return g
+}
+
+// HasUnit returns true if the unit code is the base unit or one of the product's units
+func (g *UnitConversionGraph) HasUnit(unitCode string) bool {
+	return g.units[unitCode]
+}
+
+// Convert converts a quantity between two units by multiplying the conversion rates
+// along the shortest path between them.
+// Returns ErrNoConversionPath if no path exists.
+func (g *UnitConversionGraph) Convert(quantity decimal.Decimal, fromUnit, toUnit string) (decimal.Decimal, error) {
+	if fromUnit == toUnit {
+		return quantity, nil
+	}
+
+	// Breadth-first search; visited units are never expanded twice, so cycles terminate.
+	// Numerator and denominator are accumulated separately to divide only once.
+	type step struct {
+		unit        string
+		numerator   decimal.Decimal
+		denominator decimal.Decimal
+	}
+	one := decimal.NewFromInt(1)
+	visited := map[string]bool{fromUnit: true}
+	queue := []step{{unit: fromUnit, numerator: one, denominator: one}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range g.edges[current.unit] {
+			if visited[edge.to] {
+				continue
+			}
+			next := step{
+				unit:        edge.to,
+				numerator:   current.numerator.Mul(edge.numerator),
+				denominator: current.denominator.Mul(edge.denominator),
+			}
+			if next.unit == toUnit {
+				return quantity.Mul(next.numerator).Div(next.denominator), nil
+			}
+			visited[next.unit] = true
+			queue = append(queue, next)
+		}
+	}
+
+	return decimal.Zero, ErrNoConversionPath
+}
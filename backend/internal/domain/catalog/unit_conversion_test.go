@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConversionUnit(t *testing.T, code string, rate int64, referenceUnit string) ProductUnit {
+	unit, err := NewProductUnit(uuid.New(), uuid.New(), code, code, decimal.NewFromInt(rate))
+	require.NoError(t, err)
+	require.NoError(t, unit.SetReferenceUnit(referenceUnit))
+	return *unit
+}
+
+func TestUnitConversionGraph_Convert(t *testing.T) {
+	// pcs <- box (12 pcs) <- case (6 box) <- pallet (40 case), plus a directly defined dozen
+	graph := NewUnitConversionGraph("pcs", []ProductUnit{
+		newTestConversionUnit(t, "box", 12, ""),
+		newTestConversionUnit(t, "case", 6, "box"),
+		newTestConversionUnit(t, "pallet", 40, "case"),
+		newTestConversionUnit(t, "dozen", 12, ""),
+	})
+
+	tests := []struct {
+		name     string
+		quantity int64
+		from     string
+		to       string
+		expected string
+	}{
+		{"three hops up to the base unit", 2, "pallet", "pcs", "5760"},
+		{"three hops down from the base unit", 5760, "pcs", "pallet", "2"},
+		{"between intermediate units", 1, "pallet", "box", "240"},
+		{"through the base unit", 1, "case", "dozen", "6"},
+		{"same unit", 3, "box", "box", "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := graph.Convert(decimal.NewFromInt(tt.quantity), tt.from, tt.to)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.String())
+		})
+	}
+
+	t.Run("divides once to keep precision", func(t *testing.T) {
+		result, err := graph.Convert(decimal.NewFromInt(1), "box", "pallet")
+
+		require.NoError(t, err)
+		assert.True(t, result.Mul(decimal.NewFromInt(240)).Round(10).Equal(decimal.NewFromInt(1)))
+	})
+}
+
+func TestUnitConversionGraph_Disconnected(t *testing.T) {
+	// crate and bin only reference each other, so they never reach the base unit
+	graph := NewUnitConversionGraph("pcs", []ProductUnit{
+		newTestConversionUnit(t, "box", 12, ""),
+		newTestConversionUnit(t, "crate", 2, "bin"),
+		newTestConversionUnit(t, "bin", 3, "crate"),
+		newTestConversionUnit(t, "drum", 5, "barrel"),
+	})
+
+	assert.True(t, graph.HasUnit("crate"))
+	assert.False(t, graph.HasUnit("barrel"), "a reference unit that was never defined is not a unit")
+
+	_, err := graph.Convert(decimal.NewFromInt(1), "crate", "box")
+	assert.ErrorIs(t, err, ErrNoConversionPath)
+
+	_, err = graph.Convert(decimal.NewFromInt(1), "drum", "pcs")
+	assert.ErrorIs(t, err, ErrNoConversionPath)
+
+	// Units inside the cycle still convert to each other
+	result, err := graph.Convert(decimal.NewFromInt(1), "crate", "bin")
+	require.NoError(t, err)
+	assert.Equal(t, "2", result.String())
+}
+
+func TestProductUnit_SetReferenceUnit(t *testing.T) {
+	unit, err := NewProductUnit(uuid.New(), uuid.New(), "box", "Box", decimal.NewFromInt(12))
+	require.NoError(t, err)
+
+	assert.Error(t, unit.SetReferenceUnit("box"))
+	require.NoError(t, unit.SetReferenceUnit("case"))
+	assert.False(t, unit.IsRelativeToBaseUnit())
+	require.NoError(t, unit.SetReferenceUnit(""))
+	assert.True(t, unit.IsRelativeToBaseUnit())
+}
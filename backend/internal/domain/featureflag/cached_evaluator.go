@@ -64,6 +64,15 @@ func NewCachedEvaluator(
 
 // Evaluate evaluates a single feature flag for the given context with caching
 func (e *CachedEvaluator) Evaluate(ctx context.Context, flagKey string, evalCtx *EvaluationContext) EvaluationResult {
+	return e.evaluate(ctx, flagKey, evalCtx, 0)
+}
+
+// evaluate evaluates a single feature flag with caching, depth levels down a prerequisite chain
+func (e *CachedEvaluator) evaluate(ctx context.Context, flagKey string, evalCtx *EvaluationContext, depth int) EvaluationResult {
+	if depth > MaxPrerequisiteDepth {
+		return NewErrorResult(flagKey, errPrerequisiteDepth)
+	}
+
 	// Get the feature flag (with caching)
 	flag, err := e.getFlag(ctx, flagKey)
 	if err != nil {
@@ -91,10 +100,17 @@ func (e *CachedEvaluator) Evaluate(ctx context.Context, flagKey string, evalCtx
 	}
 
 	// Use PureEvaluator for evaluation with pre-fetched data
-	pureEval := NewPureEvaluator()
+	pureEval := NewPureEvaluator().WithPrerequisites(e.prerequisiteEvaluator(ctx, evalCtx, depth+1))
 	return pureEval.Evaluate(flag, evalCtx, userOverride, tenantOverride)
 }
 
+// prerequisiteEvaluator returns a function evaluating prerequisite flags with caching at the given depth
+func (e *CachedEvaluator) prerequisiteEvaluator(ctx context.Context, evalCtx *EvaluationContext, depth int) func(flagKey string) EvaluationResult {
+	return func(flagKey string) EvaluationResult {
+		return e.evaluate(ctx, flagKey, evalCtx, depth)
+	}
+}
+
 // EvaluateBatch evaluates multiple feature flags at once with caching
 func (e *CachedEvaluator) EvaluateBatch(ctx context.Context, flagKeys []string, evalCtx *EvaluationContext) map[string]EvaluationResult {
 	results := make(map[string]EvaluationResult, len(flagKeys))
@@ -156,7 +172,7 @@ func (e *CachedEvaluator) EvaluateAll(ctx context.Context, evalCtx *EvaluationCo
 		}
 
 		// Evaluate
-		pureEval := NewPureEvaluator()
+		pureEval := NewPureEvaluator().WithPrerequisites(e.prerequisiteEvaluator(ctx, evalCtx, 1))
 		results[flagKey] = pureEval.Evaluate(flag, evalCtx, userOverride, tenantOverride)
 	}
 
@@ -25,6 +25,8 @@ const (
 	EvaluationReasonFlagNotFound EvaluationReason = "flag_not_found"
 	// EvaluationReasonPlanRestricted indicates the tenant's plan doesn't meet the required plan
 	EvaluationReasonPlanRestricted EvaluationReason = "plan_restricted"
+	// EvaluationReasonPrerequisiteNotMet indicates a prerequisite flag was not enabled or had another variant
+	EvaluationReasonPrerequisiteNotMet EvaluationReason = "prerequisite_not_met"
 	// EvaluationReasonError indicates an error occurred during evaluation
 	EvaluationReasonError EvaluationReason = "error"
 )
@@ -215,6 +217,18 @@ func (c *EvaluationContext) HasTenant() bool {
 	return c.TenantID != ""
 }
 
+// StableID returns the identifier a percentage rollout is keyed on: the tenant ID
+// for RolloutByTenant, the user ID otherwise. Returns "" for a nil context.
+func (c *EvaluationContext) StableID(by RolloutBy) string {
+	if c == nil {
+		return ""
+	}
+	if by == RolloutByTenant {
+		return c.TenantID
+	}
+	return c.UserID
+}
+
 // EvaluationResult represents the result of evaluating a feature flag
 type EvaluationResult struct {
 	// Key is the key of the evaluated flag
@@ -296,6 +310,19 @@ func NewPlanRestrictedResult(key string, requiredPlan string, flagVersion int) E
 	}
 }
 
+// NewPrerequisiteNotMetResult creates an evaluation result for a flag whose prerequisite flag isn't met
+func NewPrerequisiteNotMetResult(key string, prerequisite string, flagVersion int) EvaluationResult {
+	value := NewBooleanFlagValue(false).WithMetadata("prerequisite", prerequisite)
+	return EvaluationResult{
+		Key:         key,
+		Enabled:     false,
+		Value:       value,
+		Reason:      EvaluationReasonPrerequisiteNotMet,
+		FlagVersion: flagVersion,
+		EvaluatedAt: time.Now(),
+	}
+}
+
 // NewErrorResult creates an evaluation result for an error
 func NewErrorResult(key string, err error) EvaluationResult {
 	return EvaluationResult{
@@ -369,6 +396,11 @@ func (r EvaluationResult) IsPlanRestricted() bool {
 	return r.Reason == EvaluationReasonPlanRestricted
 }
 
+// IsPrerequisiteNotMet returns true if the result is due to an unmet prerequisite
+func (r EvaluationResult) IsPrerequisiteNotMet() bool {
+	return r.Reason == EvaluationReasonPrerequisiteNotMet
+}
+
 // copyMap creates a shallow copy of a map
 func copyMap(m map[string]any) map[string]any {
 	if m == nil {
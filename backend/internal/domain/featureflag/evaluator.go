@@ -13,9 +13,11 @@ import (
 // 1. Check user-level override (highest priority)
 // 2. Check tenant-level override
 // 3. Check if flag is enabled (disabled flags return default with reason "disabled")
-// 4. Evaluate targeting rules in priority order
-// 5. For percentage/variant types, apply consistent hashing
-// 6. Return default value (lowest priority)
+// 4. Check the plan restriction
+// 5. Check prerequisite flags (unmet prerequisites return reason "prerequisite_not_met")
+// 6. Evaluate targeting rules in priority order
+// 7. For percentage/variant types, apply consistent hashing
+// 8. Return default value (lowest priority)
 type Evaluator struct {
 	flagRepo     FeatureFlagRepository
 	overrideRepo FlagOverrideRepository
@@ -31,6 +33,15 @@ func NewEvaluator(flagRepo FeatureFlagRepository, overrideRepo FlagOverrideRepos
 
 // Evaluate evaluates a single feature flag for the given context
 func (e *Evaluator) Evaluate(ctx context.Context, flagKey string, evalCtx *EvaluationContext) EvaluationResult {
+	return e.evaluate(ctx, flagKey, evalCtx, 0)
+}
+
+// evaluate evaluates a single feature flag, depth levels down a prerequisite chain
+func (e *Evaluator) evaluate(ctx context.Context, flagKey string, evalCtx *EvaluationContext, depth int) EvaluationResult {
+	if depth > MaxPrerequisiteDepth {
+		return NewErrorResult(flagKey, errPrerequisiteDepth)
+	}
+
 	// Get the feature flag
 	flag, err := e.flagRepo.FindByKey(ctx, flagKey)
 	if err != nil {
@@ -42,12 +53,17 @@ func (e *Evaluator) Evaluate(ctx context.Context, flagKey string, evalCtx *Evalu
 		return NewErrorResult(flagKey, err)
 	}
 
-	return e.EvaluateFlag(ctx, flag, evalCtx)
+	return e.evaluateFlag(ctx, flag, evalCtx, depth)
 }
 
 // EvaluateFlag evaluates a pre-fetched feature flag for the given context
 // This is useful when you already have the flag loaded (e.g., from cache)
 func (e *Evaluator) EvaluateFlag(ctx context.Context, flag *FeatureFlag, evalCtx *EvaluationContext) EvaluationResult {
+	return e.evaluateFlag(ctx, flag, evalCtx, 0)
+}
+
+// evaluateFlag evaluates a pre-fetched feature flag, depth levels down a prerequisite chain
+func (e *Evaluator) evaluateFlag(ctx context.Context, flag *FeatureFlag, evalCtx *EvaluationContext, depth int) EvaluationResult {
 	if flag == nil {
 		return NewFlagNotFoundResult("")
 	}
@@ -96,7 +112,17 @@ func (e *Evaluator) EvaluateFlag(ctx context.Context, flag *FeatureFlag, evalCtx
 		}
 	}
 
-	// Step 5: Evaluate targeting rules in priority order
+	// Step 5: Check prerequisites, evaluated for the same context
+	if flag.HasPrerequisites() {
+		unmet, ok := firstUnmetPrerequisite(flag, func(prerequisiteKey string) EvaluationResult {
+			return e.evaluate(ctx, prerequisiteKey, evalCtx, depth+1)
+		})
+		if ok {
+			return NewPrerequisiteNotMetResult(flagKey, unmet.FlagKey, flagVersion)
+		}
+	}
+
+	// Step 6: Evaluate targeting rules in priority order
 	rules := flag.GetRules()
 	if len(rules) > 0 {
 		// Sort rules by priority (lower number = higher priority)
@@ -109,15 +135,9 @@ func (e *Evaluator) EvaluateFlag(ctx context.Context, flag *FeatureFlag, evalCtx
 		for _, rule := range sortedRules {
 			if e.matchRule(rule, evalCtx) {
 				// Rule matches, check percentage if applicable
-				if rule.Percentage < 100 {
-					userID := ""
-					if evalCtx != nil {
-						userID = evalCtx.UserID
-					}
-					if !IsInPercentage(flagKey+":"+rule.RuleID, userID, rule.Percentage) {
-						// User not in percentage rollout for this rule, continue to next rule
-						continue
-					}
+				if !rule.InRollout(flagKey, evalCtx) {
+					// Target not in percentage rollout for this rule, continue to next rule
+					continue
 				}
 
 				result := NewEvaluationResult(flagKey, rule.GetValue(), EvaluationReasonRuleMatch, flagVersion)
@@ -126,7 +146,7 @@ func (e *Evaluator) EvaluateFlag(ctx context.Context, flag *FeatureFlag, evalCtx
 		}
 	}
 
-	// Step 6: Handle flag type-specific evaluation (percentage rollout, variant selection)
+	// Step 7: Handle flag type-specific evaluation (percentage rollout, variant selection)
 	result := e.evaluateByType(flag, evalCtx)
 	return result
 }
@@ -285,13 +305,24 @@ func (e *Evaluator) GetVariant(ctx context.Context, flagKey string, evalCtx *Eva
 
 // PureEvaluator evaluates flags without repository access (for cached/pre-loaded flags)
 // This is useful for high-performance scenarios where flags are already loaded
-type PureEvaluator struct{}
+type PureEvaluator struct {
+	// prerequisites evaluates a prerequisite flag; without it, prerequisites are unmet
+	prerequisites func(flagKey string) EvaluationResult
+}
 
 // NewPureEvaluator creates a new pure evaluator
 func NewPureEvaluator() *PureEvaluator {
 	return &PureEvaluator{}
 }
 
+// WithPrerequisites sets how the evaluator evaluates the prerequisite flags of a flag.
+// The function is called with the key of each prerequisite and must evaluate it for
+// the same context as the dependent flag.
+func (e *PureEvaluator) WithPrerequisites(evaluate func(flagKey string) EvaluationResult) *PureEvaluator {
+	e.prerequisites = evaluate
+	return e
+}
+
 // Evaluate evaluates a feature flag with optional overrides
 func (e *PureEvaluator) Evaluate(flag *FeatureFlag, evalCtx *EvaluationContext, userOverride, tenantOverride *FlagOverride) EvaluationResult {
 	if flag == nil {
@@ -327,7 +358,20 @@ func (e *PureEvaluator) Evaluate(flag *FeatureFlag, evalCtx *EvaluationContext,
 		}
 	}
 
-	// Step 5: Evaluate targeting rules
+	// Step 5: Check prerequisites
+	if flag.HasPrerequisites() {
+		evaluate := e.prerequisites
+		if evaluate == nil {
+			evaluate = func(prerequisiteKey string) EvaluationResult {
+				return NewFlagNotFoundResult(prerequisiteKey)
+			}
+		}
+		if unmet, ok := firstUnmetPrerequisite(flag, evaluate); ok {
+			return NewPrerequisiteNotMetResult(flagKey, unmet.FlagKey, flagVersion)
+		}
+	}
+
+	// Step 6: Evaluate targeting rules
 	rules := flag.GetRules()
 	if len(rules) > 0 {
 		sortedRules := make([]TargetingRule, len(rules))
@@ -338,14 +382,8 @@ func (e *PureEvaluator) Evaluate(flag *FeatureFlag, evalCtx *EvaluationContext,
 
 		for _, rule := range sortedRules {
 			if e.matchRule(rule, evalCtx) {
-				if rule.Percentage < 100 {
-					userID := ""
-					if evalCtx != nil {
-						userID = evalCtx.UserID
-					}
-					if !IsInPercentage(flagKey+":"+rule.RuleID, userID, rule.Percentage) {
-						continue
-					}
+				if !rule.InRollout(flagKey, evalCtx) {
+					continue
 				}
 
 				result := NewEvaluationResult(flagKey, rule.GetValue(), EvaluationReasonRuleMatch, flagVersion)
@@ -354,7 +392,7 @@ func (e *PureEvaluator) Evaluate(flag *FeatureFlag, evalCtx *EvaluationContext,
 		}
 	}
 
-	// Step 6: Return default value
+	// Step 7: Return default value
 	return e.evaluateByType(flag, evalCtx)
 }
 
@@ -974,3 +974,198 @@ func TestPureEvaluator_Evaluate_PlanRestriction(t *testing.T) {
 		assert.True(t, result.Enabled)
 	})
 }
+
+// createRolloutFlag creates an enabled flag, off by default, served on to percentage of targets
+func createRolloutFlag(t *testing.T, key string, percentage int, by RolloutBy) *FeatureFlag {
+	t.Helper()
+	flag, err := NewFeatureFlag(key, "Rollout Flag", FlagTypeBoolean, NewBooleanFlagValue(false), nil)
+	require.NoError(t, err)
+	require.NoError(t, flag.Enable(nil))
+	rule, err := NewRolloutRule("rollout", 1, NewBooleanFlagValue(true), percentage, by)
+	require.NoError(t, err)
+	require.NoError(t, flag.AddRule(rule, nil))
+	return flag
+}
+
+func TestPureEvaluator_Evaluate_PercentageRollout(t *testing.T) {
+	evaluator := NewPureEvaluator()
+
+	t.Run("enables the flag for about the rollout percentage of users", func(t *testing.T) {
+		flag := createRolloutFlag(t, "new-checkout", 10, RolloutByUser)
+
+		const users = 10000
+		enabled := 0
+		for range users {
+			evalCtx := NewEvaluationContext().WithUser(uuid.New().String())
+			if evaluator.Evaluate(flag, evalCtx, nil, nil).Enabled {
+				enabled++
+			}
+		}
+
+		assert.InDelta(t, 0.10, float64(enabled)/users, 0.015)
+	})
+
+	t.Run("gives the same user the same answer on every evaluation", func(t *testing.T) {
+		flag := createRolloutFlag(t, "new-checkout", 10, RolloutByUser)
+
+		for range 100 {
+			evalCtx := NewEvaluationContext().WithUser(uuid.New().String())
+			first := evaluator.Evaluate(flag, evalCtx, nil, nil)
+			for range 10 {
+				assert.Equal(t, first.Enabled, evaluator.Evaluate(flag, evalCtx, nil, nil).Enabled)
+			}
+		}
+	})
+
+	t.Run("keeps enabled users enabled when the percentage is raised", func(t *testing.T) {
+		at10 := createRolloutFlag(t, "new-checkout", 10, RolloutByUser)
+		at50 := createRolloutFlag(t, "new-checkout", 50, RolloutByUser)
+
+		for range 1000 {
+			evalCtx := NewEvaluationContext().WithUser(uuid.New().String())
+			if evaluator.Evaluate(at10, evalCtx, nil, nil).Enabled {
+				assert.True(t, evaluator.Evaluate(at50, evalCtx, nil, nil).Enabled)
+			}
+		}
+	})
+
+	t.Run("gives all users of a tenant the same answer", func(t *testing.T) {
+		flag := createRolloutFlag(t, "new-checkout", 50, RolloutByTenant)
+
+		for range 20 {
+			tenantID := uuid.New().String()
+			first := evaluator.Evaluate(flag, NewEvaluationContext().WithTenant(tenantID).WithUser(uuid.New().String()), nil, nil)
+			for range 10 {
+				evalCtx := NewEvaluationContext().WithTenant(tenantID).WithUser(uuid.New().String())
+				assert.Equal(t, first.Enabled, evaluator.Evaluate(flag, evalCtx, nil, nil).Enabled)
+			}
+		}
+	})
+
+	t.Run("leaves targets without the stable ID out of a partial rollout", func(t *testing.T) {
+		flag := createRolloutFlag(t, "new-checkout", 99, RolloutByTenant)
+
+		result := evaluator.Evaluate(flag, NewEvaluationContext().WithUser(uuid.New().String()), nil, nil)
+
+		assert.False(t, result.Enabled)
+		assert.Equal(t, EvaluationReasonDefault, result.Reason)
+	})
+
+	t.Run("serves a full rollout without a stable ID", func(t *testing.T) {
+		flag := createRolloutFlag(t, "new-checkout", 100, RolloutByUser)
+
+		result := evaluator.Evaluate(flag, nil, nil, nil)
+
+		assert.True(t, result.Enabled)
+		assert.Equal(t, "rollout", result.RuleID)
+	})
+}
+
+// Helper to create an enabled flag depending on the given prerequisites
+func createDependentFlag(t *testing.T, key string, prerequisites ...Prerequisite) *FeatureFlag {
+	flag := createTestFlag(t, key, "Dependent Flag", FlagTypeBoolean, FlagStatusEnabled)
+	require.NoError(t, flag.SetPrerequisites(prerequisites, nil))
+	return flag
+}
+
+func TestEvaluator_Evaluate_Prerequisites(t *testing.T) {
+	ctx := context.Background()
+
+	newEvaluator := func(flags ...*FeatureFlag) *Evaluator {
+		flagRepo := &MockFeatureFlagRepository{}
+		for _, flag := range flags {
+			flagRepo.On("FindByKey", mock.Anything, flag.GetKey()).Return(flag, nil)
+		}
+		return NewEvaluator(flagRepo, nil)
+	}
+
+	t.Run("evaluates the flag when the prerequisite is enabled", func(t *testing.T) {
+		flag := createDependentFlag(t, "new-checkout", Prerequisite{FlagKey: "new-cart"})
+		prerequisite := createTestFlag(t, "new-cart", "New Cart", FlagTypeBoolean, FlagStatusEnabled)
+
+		result := newEvaluator(flag, prerequisite).Evaluate(ctx, "new-checkout", NewEvaluationContext())
+
+		assert.True(t, result.Enabled)
+		assert.Equal(t, EvaluationReasonDefault, result.Reason)
+	})
+
+	t.Run("returns disabled when the prerequisite is disabled", func(t *testing.T) {
+		flag := createDependentFlag(t, "new-checkout", Prerequisite{FlagKey: "new-cart"})
+		prerequisite := createTestFlag(t, "new-cart", "New Cart", FlagTypeBoolean, FlagStatusDisabled)
+
+		result := newEvaluator(flag, prerequisite).Evaluate(ctx, "new-checkout", NewEvaluationContext())
+
+		assert.False(t, result.Enabled)
+		assert.Equal(t, EvaluationReasonPrerequisiteNotMet, result.Reason)
+		prerequisiteKey, _ := result.Value.GetMetadataValue("prerequisite")
+		assert.Equal(t, "new-cart", prerequisiteKey)
+	})
+
+	t.Run("short-circuits before targeting rules", func(t *testing.T) {
+		flag := createDependentFlag(t, "new-checkout", Prerequisite{FlagKey: "new-cart"})
+		rule, err := NewTargetingRule("everyone", 1, nil, NewBooleanFlagValue(true))
+		require.NoError(t, err)
+		require.NoError(t, flag.AddRule(rule, nil))
+		prerequisite := createTestFlag(t, "new-cart", "New Cart", FlagTypeBoolean, FlagStatusDisabled)
+
+		result := newEvaluator(flag, prerequisite).Evaluate(ctx, "new-checkout", NewEvaluationContext())
+
+		assert.False(t, result.Enabled)
+		assert.Equal(t, EvaluationReasonPrerequisiteNotMet, result.Reason)
+		assert.Empty(t, result.RuleID)
+	})
+
+	t.Run("requires the prerequisite variant", func(t *testing.T) {
+		prerequisite, err := NewVariantFlag("checkout-layout", "Checkout Layout", "compact", nil)
+		require.NoError(t, err)
+		require.NoError(t, prerequisite.Enable(nil))
+
+		met := createDependentFlag(t, "express-pay", Prerequisite{FlagKey: "checkout-layout", Variant: "compact"})
+		unmet := createDependentFlag(t, "one-click-pay", Prerequisite{FlagKey: "checkout-layout", Variant: "classic"})
+		evaluator := newEvaluator(met, unmet, prerequisite)
+
+		assert.Equal(t, EvaluationReasonDefault, evaluator.Evaluate(ctx, "express-pay", NewEvaluationContext()).Reason)
+		assert.Equal(t, EvaluationReasonPrerequisiteNotMet, evaluator.Evaluate(ctx, "one-click-pay", NewEvaluationContext()).Reason)
+	})
+
+	t.Run("returns disabled when the prerequisite flag doesn't exist", func(t *testing.T) {
+		flag := createDependentFlag(t, "new-checkout", Prerequisite{FlagKey: "new-cart"})
+		flagRepo := &MockFeatureFlagRepository{}
+		flagRepo.On("FindByKey", mock.Anything, "new-checkout").Return(flag, nil)
+		flagRepo.On("FindByKey", mock.Anything, "new-cart").Return(nil, shared.NewDomainError("NOT_FOUND", "flag not found"))
+
+		result := NewEvaluator(flagRepo, nil).Evaluate(ctx, "new-checkout", NewEvaluationContext())
+
+		assert.Equal(t, EvaluationReasonPrerequisiteNotMet, result.Reason)
+	})
+
+	t.Run("stops following a stored prerequisite cycle", func(t *testing.T) {
+		a := createDependentFlag(t, "flag-a", Prerequisite{FlagKey: "flag-b"})
+		b := createDependentFlag(t, "flag-b", Prerequisite{FlagKey: "flag-a"})
+
+		result := newEvaluator(a, b).Evaluate(ctx, "flag-a", NewEvaluationContext())
+
+		assert.False(t, result.Enabled)
+		assert.Equal(t, EvaluationReasonPrerequisiteNotMet, result.Reason)
+	})
+}
+
+func TestPureEvaluator_Evaluate_Prerequisites(t *testing.T) {
+	flag := createDependentFlag(t, "new-checkout", Prerequisite{FlagKey: "new-cart"})
+
+	t.Run("evaluates prerequisites with the given function", func(t *testing.T) {
+		evaluator := NewPureEvaluator().WithPrerequisites(func(flagKey string) EvaluationResult {
+			return NewEvaluationResult(flagKey, NewBooleanFlagValue(true), EvaluationReasonDefault, 1)
+		})
+
+		result := evaluator.Evaluate(flag, NewEvaluationContext(), nil, nil)
+
+		assert.True(t, result.Enabled)
+	})
+
+	t.Run("treats prerequisites as unmet without a function", func(t *testing.T) {
+		result := NewPureEvaluator().Evaluate(flag, NewEvaluationContext(), nil, nil)
+
+		assert.Equal(t, EvaluationReasonPrerequisiteNotMet, result.Reason)
+	})
+}
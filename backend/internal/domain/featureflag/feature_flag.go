@@ -101,17 +101,18 @@ func (p RequiredPlan) MeetsPlanRequirement(tenantPlan string) bool {
 // FlagOverride entities, which allow per-tenant or per-user overrides.
 type FeatureFlag struct {
 	shared.BaseAggregateRoot
-	Key          string          `json:"key"`
-	Name         string          `json:"name"`
-	Description  string          `json:"description,omitempty"`
-	Type         FlagType        `json:"type"`
-	Status       FlagStatus      `json:"status"`
-	DefaultValue FlagValue       `json:"default_value"`
-	Rules        []TargetingRule `json:"rules,omitempty"`
-	Tags         []string        `json:"tags,omitempty"`
-	RequiredPlan RequiredPlan    `json:"required_plan,omitempty"` // Minimum plan required to access this feature
-	CreatedBy    *uuid.UUID      `json:"created_by,omitempty"`
-	UpdatedBy    *uuid.UUID      `json:"updated_by,omitempty"`
+	Key           string          `json:"key"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description,omitempty"`
+	Type          FlagType        `json:"type"`
+	Status        FlagStatus      `json:"status"`
+	DefaultValue  FlagValue       `json:"default_value"`
+	Rules         []TargetingRule `json:"rules,omitempty"`
+	Tags          []string        `json:"tags,omitempty"`
+	RequiredPlan  RequiredPlan    `json:"required_plan,omitempty"` // Minimum plan required to access this feature
+	Prerequisites []Prerequisite  `json:"prerequisites,omitempty"` // Flags that must be enabled before this flag is evaluated
+	CreatedBy     *uuid.UUID      `json:"created_by,omitempty"`
+	UpdatedBy     *uuid.UUID      `json:"updated_by,omitempty"`
 }
 
 // NewFeatureFlag creates a new feature flag
@@ -141,6 +142,7 @@ func NewFeatureFlag(
 		DefaultValue:      defaultValue,
 		Rules:             make([]TargetingRule, 0),
 		Tags:              make([]string, 0),
+		Prerequisites:     make([]Prerequisite, 0),
 		CreatedBy:         createdBy,
 		UpdatedBy:         createdBy,
 	}
@@ -258,6 +260,55 @@ func (f *FeatureFlag) MeetsPlanRequirement(tenantPlan string) bool {
 	return f.RequiredPlan.MeetsPlanRequirement(tenantPlan)
 }
 
+// GetPrerequisites returns a copy of the flag's prerequisites
+func (f *FeatureFlag) GetPrerequisites() []Prerequisite {
+	if f.Prerequisites == nil {
+		return nil
+	}
+	prerequisites := make([]Prerequisite, len(f.Prerequisites))
+	copy(prerequisites, f.Prerequisites)
+	return prerequisites
+}
+
+// HasPrerequisites returns true if the flag depends on other flags
+func (f *FeatureFlag) HasPrerequisites() bool {
+	return len(f.Prerequisites) > 0
+}
+
+// SetPrerequisites sets the flags that must be enabled before this flag is evaluated.
+// It rejects a flag depending on itself; longer cycles must be checked against the
+// other flags with CheckPrerequisiteCycle.
+func (f *FeatureFlag) SetPrerequisites(prerequisites []Prerequisite, updatedBy *uuid.UUID) error {
+	if f.Status == FlagStatusArchived {
+		return shared.NewDomainError("CANNOT_UPDATE", "Cannot update an archived flag")
+	}
+
+	keys := make(map[string]struct{}, len(prerequisites))
+	normalized := make([]Prerequisite, 0, len(prerequisites))
+	for _, p := range prerequisites {
+		p, err := NewPrerequisite(p.FlagKey, p.Variant)
+		if err != nil {
+			return err
+		}
+		if p.FlagKey == f.Key {
+			return shared.NewDomainError("PREREQUISITE_CYCLE", "Flag cannot be its own prerequisite")
+		}
+		if _, exists := keys[p.FlagKey]; exists {
+			return shared.NewDomainError("DUPLICATE_PREREQUISITE", "Duplicate prerequisite: "+p.FlagKey)
+		}
+		keys[p.FlagKey] = struct{}{}
+		normalized = append(normalized, p)
+	}
+
+	f.Prerequisites = normalized
+	f.UpdatedBy = updatedBy
+	f.UpdatedAt = time.Now()
+
+	f.AddDomainEvent(NewFlagUpdatedEvent(f))
+
+	return nil
+}
+
 // IsEnabled returns true if the flag is enabled
 func (f *FeatureFlag) IsEnabled() bool {
 	return f.Status == FlagStatusEnabled
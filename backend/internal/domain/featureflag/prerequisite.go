@@ -0,0 +1,111 @@
+package featureflag
+
+import (
+	"context"
+	"strings"
+
+	"github.com/erp/backend/internal/domain/shared"
+)
+
+// MaxPrerequisiteDepth limits how deep prerequisite chains are followed during evaluation.
+// Cycles are rejected when flags are saved; the limit guards evaluation against
+// stored data that bypassed that check.
+const MaxPrerequisiteDepth = 10
+
+// Prerequisite is a flag that must evaluate as enabled for the dependent flag
+// to be evaluated. If Variant is set, the prerequisite must also evaluate to that variant.
+type Prerequisite struct {
+	FlagKey string `json:"flag_key"`
+	Variant string `json:"variant,omitempty"`
+}
+
+// NewPrerequisite creates a new prerequisite on the flag with the given key
+func NewPrerequisite(flagKey, variant string) (Prerequisite, error) {
+	p := Prerequisite{
+		FlagKey: strings.ToLower(flagKey),
+		Variant: variant,
+	}
+	if err := p.Validate(); err != nil {
+		return Prerequisite{}, err
+	}
+	return p, nil
+}
+
+// Validate validates the prerequisite
+func (p Prerequisite) Validate() error {
+	if err := ValidateKey(p.FlagKey); err != nil {
+		return shared.NewDomainError("INVALID_PREREQUISITE", "Invalid prerequisite flag key: "+p.FlagKey)
+	}
+	return nil
+}
+
+// HasVariant returns true if the prerequisite requires a specific variant
+func (p Prerequisite) HasVariant() bool {
+	return p.Variant != ""
+}
+
+// IsMetBy returns true if the evaluation result of the prerequisite flag satisfies it
+func (p Prerequisite) IsMetBy(result EvaluationResult) bool {
+	if !result.Enabled {
+		return false
+	}
+	return !p.HasVariant() || result.Variant == p.Variant
+}
+
+// PrerequisiteLookup returns the prerequisites of the flag with the given key
+type PrerequisiteLookup func(ctx context.Context, flagKey string) ([]Prerequisite, error)
+
+// CheckPrerequisiteCycle checks that giving the flag with the given key these
+// prerequisites doesn't make it depend on itself, directly or through other flags.
+// The prerequisites of other flags are read with lookup.
+// Returns a PREREQUISITE_CYCLE error naming the cycle if one is found.
+func CheckPrerequisiteCycle(ctx context.Context, flagKey string, prerequisites []Prerequisite, lookup PrerequisiteLookup) error {
+	flagKey = strings.ToLower(flagKey)
+	// Keys already checked to not lead back to flagKey
+	checked := make(map[string]struct{})
+
+	var visit func(key string, path []string) error
+	visit = func(key string, path []string) error {
+		path = append(path, key)
+		if key == flagKey {
+			return shared.NewDomainError("PREREQUISITE_CYCLE",
+				"Flag prerequisites form a cycle: "+strings.Join(path, " -> "))
+		}
+		if _, ok := checked[key]; ok {
+			return nil
+		}
+		checked[key] = struct{}{}
+
+		next, err := lookup(ctx, key)
+		if err != nil {
+			return err
+		}
+		for _, p := range next {
+			if err := visit(p.FlagKey, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, p := range prerequisites {
+		if err := visit(p.FlagKey, []string{flagKey}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errPrerequisiteDepth is returned when a prerequisite chain is deeper than MaxPrerequisiteDepth
+var errPrerequisiteDepth = shared.NewDomainError("PREREQUISITE_DEPTH_EXCEEDED", "Flag prerequisites are nested too deeply")
+
+// firstUnmetPrerequisite evaluates the flag's prerequisites in order with evaluate
+// and returns the first one that isn't met
+func firstUnmetPrerequisite(flag *FeatureFlag, evaluate func(flagKey string) EvaluationResult) (Prerequisite, bool) {
+	for _, p := range flag.GetPrerequisites() {
+		if !p.IsMetBy(evaluate(p.FlagKey)) {
+			return p, true
+		}
+	}
+	return Prerequisite{}, false
+}
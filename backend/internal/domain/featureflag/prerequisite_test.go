@@ -0,0 +1,104 @@
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erp/backend/internal/domain/shared"
+)
+
+func TestNewPrerequisite(t *testing.T) {
+	p, err := NewPrerequisite("New-Cart", "compact")
+	require.NoError(t, err)
+	assert.Equal(t, "new-cart", p.FlagKey)
+	assert.Equal(t, "compact", p.Variant)
+
+	_, err = NewPrerequisite("", "")
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "INVALID_PREREQUISITE", domainErr.Code)
+}
+
+func TestPrerequisite_IsMetBy(t *testing.T) {
+	enabled := NewEvaluationResult("layout", NewVariantFlagValue("compact"), EvaluationReasonDefault, 1)
+	disabled := NewDisabledResult("layout", NewVariantFlagValue("compact"), 1)
+
+	assert.True(t, Prerequisite{FlagKey: "layout"}.IsMetBy(enabled))
+	assert.True(t, Prerequisite{FlagKey: "layout", Variant: "compact"}.IsMetBy(enabled))
+	assert.False(t, Prerequisite{FlagKey: "layout", Variant: "classic"}.IsMetBy(enabled))
+	assert.False(t, Prerequisite{FlagKey: "layout"}.IsMetBy(disabled))
+}
+
+func TestFeatureFlag_SetPrerequisites(t *testing.T) {
+	t.Run("sets normalized prerequisites", func(t *testing.T) {
+		flag := createTestFlag(t, "new-checkout", "New Checkout", FlagTypeBoolean, FlagStatusEnabled)
+
+		err := flag.SetPrerequisites([]Prerequisite{{FlagKey: "New-Cart"}}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Prerequisite{{FlagKey: "new-cart"}}, flag.GetPrerequisites())
+		assert.True(t, flag.HasPrerequisites())
+	})
+
+	tests := []struct {
+		name          string
+		prerequisites []Prerequisite
+		errorCode     string
+	}{
+		{"self dependency", []Prerequisite{{FlagKey: "new-checkout"}}, "PREREQUISITE_CYCLE"},
+		{"duplicate", []Prerequisite{{FlagKey: "new-cart"}, {FlagKey: "new-cart", Variant: "v2"}}, "DUPLICATE_PREREQUISITE"},
+		{"invalid key", []Prerequisite{{FlagKey: "1-cart"}}, "INVALID_PREREQUISITE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := createTestFlag(t, "new-checkout", "New Checkout", FlagTypeBoolean, FlagStatusEnabled)
+
+			err := flag.SetPrerequisites(tt.prerequisites, nil)
+
+			var domainErr *shared.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, tt.errorCode, domainErr.Code)
+		})
+	}
+}
+
+func TestCheckPrerequisiteCycle(t *testing.T) {
+	ctx := context.Background()
+	stored := map[string][]Prerequisite{
+		"flag-a": {{FlagKey: "flag-b"}},
+		"flag-b": {{FlagKey: "flag-c"}},
+		"flag-c": nil,
+		"flag-d": {{FlagKey: "flag-b"}, {FlagKey: "flag-c"}},
+	}
+	lookup := func(_ context.Context, key string) ([]Prerequisite, error) {
+		return stored[key], nil
+	}
+
+	t.Run("accepts a chain without cycles", func(t *testing.T) {
+		err := CheckPrerequisiteCycle(ctx, "flag-e", []Prerequisite{{FlagKey: "flag-a"}, {FlagKey: "flag-d"}}, lookup)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a prerequisite leading back to the flag", func(t *testing.T) {
+		err := CheckPrerequisiteCycle(ctx, "flag-c", []Prerequisite{{FlagKey: "flag-a"}}, lookup)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "PREREQUISITE_CYCLE", domainErr.Code)
+		assert.Contains(t, domainErr.Message, "flag-c -> flag-a -> flag-b -> flag-c")
+	})
+
+	t.Run("returns lookup errors", func(t *testing.T) {
+		lookupErr := errors.New("database unavailable")
+
+		err := CheckPrerequisiteCycle(ctx, "flag-e", []Prerequisite{{FlagKey: "flag-a"}},
+			func(context.Context, string) ([]Prerequisite, error) { return nil, lookupErr })
+
+		assert.ErrorIs(t, err, lookupErr)
+	})
+}
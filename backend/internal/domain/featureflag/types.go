@@ -177,6 +177,40 @@ func (t OverrideTargetType) Value() (driver.Value, error) {
 	return string(t), nil
 }
 
+// RolloutBy is the evaluation context identifier a percentage rollout is keyed on.
+// Hashing a stable identifier keeps every user (or tenant) on the same side of the rollout.
+type RolloutBy string
+
+const (
+	// RolloutByUser rolls out per user: each user is in or out on their own
+	RolloutByUser RolloutBy = "user"
+	// RolloutByTenant rolls out per tenant: all users of a tenant get the same answer
+	RolloutByTenant RolloutBy = "tenant"
+)
+
+// AllRolloutBys returns all valid rollout identifiers
+func AllRolloutBys() []RolloutBy {
+	return []RolloutBy{
+		RolloutByUser,
+		RolloutByTenant,
+	}
+}
+
+// IsValid checks if the rollout identifier is valid
+func (r RolloutBy) IsValid() bool {
+	switch r {
+	case RolloutByUser, RolloutByTenant:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the rollout identifier
+func (r RolloutBy) String() string {
+	return string(r)
+}
+
 // ConditionOperator represents operators for targeting conditions
 type ConditionOperator string
 
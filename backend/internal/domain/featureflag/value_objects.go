@@ -173,7 +173,8 @@ type TargetingRule struct {
 	Priority   int         `json:"priority"`
 	Conditions []Condition `json:"conditions"`
 	Value      FlagValue   `json:"value"`
-	Percentage int         `json:"percentage"` // 0-100, for percentage-based rollouts within matched users
+	Percentage int         `json:"percentage"`           // 0-100, for percentage-based rollouts within matched users
+	RolloutBy  RolloutBy   `json:"rollout_by,omitempty"` // Identifier the percentage is keyed on (default: user)
 }
 
 // NewTargetingRule creates a new targeting rule
@@ -208,6 +209,18 @@ func NewTargetingRuleWithPercentage(ruleID string, priority int, conditions []Co
 	return rule, nil
 }
 
+// NewRolloutRule creates a catch-all rule serving value to a stable percentage of targets.
+// A target is in the rollout if hash(flagKey, ruleID, stable ID) % 100 < percentage,
+// so the same user (or tenant) always gets the same answer, and raising the percentage
+// only adds targets to the rollout.
+func NewRolloutRule(ruleID string, priority int, value FlagValue, percentage int, by RolloutBy) (TargetingRule, error) {
+	rule, err := NewTargetingRuleWithPercentage(ruleID, priority, nil, value, percentage)
+	if err != nil {
+		return TargetingRule{}, err
+	}
+	return rule.WithRolloutBy(by)
+}
+
 // GetRuleID returns the rule ID
 func (r TargetingRule) GetRuleID() string {
 	return r.RuleID
@@ -238,6 +251,27 @@ func (r TargetingRule) GetPercentage() int {
 	return r.Percentage
 }
 
+// GetRolloutBy returns the identifier the percentage rollout is keyed on
+func (r TargetingRule) GetRolloutBy() RolloutBy {
+	if r.RolloutBy == "" {
+		return RolloutByUser
+	}
+	return r.RolloutBy
+}
+
+// InRollout checks if the evaluation context falls within the rule's percentage rollout.
+// Contexts without the stable identifier are only included in a 100% rollout.
+func (r TargetingRule) InRollout(flagKey string, evalCtx *EvaluationContext) bool {
+	if r.Percentage >= 100 {
+		return true
+	}
+	stableID := evalCtx.StableID(r.GetRolloutBy())
+	if stableID == "" {
+		return false
+	}
+	return IsInPercentage(flagKey+":"+r.RuleID, stableID, r.Percentage)
+}
+
 // HasConditions returns true if the rule has conditions
 func (r TargetingRule) HasConditions() bool {
 	return len(r.Conditions) > 0
@@ -254,6 +288,9 @@ func (r TargetingRule) Validate() error {
 	if r.Percentage < 0 || r.Percentage > 100 {
 		return shared.NewDomainError("INVALID_PERCENTAGE", "Percentage must be between 0 and 100")
 	}
+	if r.RolloutBy != "" && !r.RolloutBy.IsValid() {
+		return shared.NewDomainError("INVALID_ROLLOUT_BY", "Rollout must be by user or tenant")
+	}
 	for _, condition := range r.Conditions {
 		if err := condition.Validate(); err != nil {
 			return err
@@ -276,6 +313,7 @@ func (r TargetingRule) AddCondition(condition Condition) (TargetingRule, error)
 		Conditions: newConditions,
 		Value:      r.Value,
 		Percentage: r.Percentage,
+		RolloutBy:  r.RolloutBy,
 	}, nil
 }
 
@@ -290,6 +328,7 @@ func (r TargetingRule) WithPriority(priority int) (TargetingRule, error) {
 		Conditions: r.GetConditions(),
 		Value:      r.Value,
 		Percentage: r.Percentage,
+		RolloutBy:  r.RolloutBy,
 	}, nil
 }
 
@@ -304,5 +343,21 @@ func (r TargetingRule) WithPercentage(percentage int) (TargetingRule, error) {
 		Conditions: r.GetConditions(),
 		Value:      r.Value,
 		Percentage: percentage,
+		RolloutBy:  r.RolloutBy,
+	}, nil
+}
+
+// WithRolloutBy returns a new rule with its percentage rollout keyed on the given identifier
+func (r TargetingRule) WithRolloutBy(by RolloutBy) (TargetingRule, error) {
+	if !by.IsValid() {
+		return TargetingRule{}, shared.NewDomainError("INVALID_ROLLOUT_BY", "Rollout must be by user or tenant")
+	}
+	return TargetingRule{
+		RuleID:     r.RuleID,
+		Priority:   r.Priority,
+		Conditions: r.GetConditions(),
+		Value:      r.Value,
+		Percentage: r.Percentage,
+		RolloutBy:  by,
 	}, nil
 }
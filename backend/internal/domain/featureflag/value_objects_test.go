@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erp/backend/internal/domain/shared"
 )
 
 func TestNewBooleanFlagValue(t *testing.T) {
@@ -359,3 +362,47 @@ func TestTargetingRule_WithPercentage_Invalid(t *testing.T) {
 	_, err = rule.WithPercentage(101)
 	assert.Error(t, err)
 }
+
+func TestNewRolloutRule(t *testing.T) {
+	t.Run("creates a catch-all percentage rule", func(t *testing.T) {
+		rule, err := NewRolloutRule("rollout", 1, NewBooleanFlagValue(true), 10, RolloutByTenant)
+
+		require.NoError(t, err)
+		assert.False(t, rule.HasConditions())
+		assert.Equal(t, 10, rule.GetPercentage())
+		assert.Equal(t, RolloutByTenant, rule.GetRolloutBy())
+		assert.NoError(t, rule.Validate())
+	})
+
+	t.Run("rejects an invalid percentage", func(t *testing.T) {
+		_, err := NewRolloutRule("rollout", 1, NewBooleanFlagValue(true), 101, RolloutByUser)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an unknown rollout identifier", func(t *testing.T) {
+		_, err := NewRolloutRule("rollout", 1, NewBooleanFlagValue(true), 10, RolloutBy("session"))
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_ROLLOUT_BY", domainErr.Code)
+	})
+
+	t.Run("keeps the rollout identifier when the percentage changes", func(t *testing.T) {
+		rule, err := NewRolloutRule("rollout", 1, NewBooleanFlagValue(true), 10, RolloutByTenant)
+		require.NoError(t, err)
+
+		ramped, err := rule.WithPercentage(25)
+
+		require.NoError(t, err)
+		assert.Equal(t, 25, ramped.GetPercentage())
+		assert.Equal(t, RolloutByTenant, ramped.GetRolloutBy())
+	})
+
+	t.Run("rolls out by user by default", func(t *testing.T) {
+		rule, err := NewTargetingRuleWithPercentage("rule", 1, nil, NewBooleanFlagValue(true), 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, RolloutByUser, rule.GetRolloutBy())
+	})
+}
@@ -1,9 +1,13 @@
 package finance
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/erp/backend/internal/domain/finance/acl"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
@@ -19,13 +23,15 @@ const (
 	PayableStatusPaid      PayableStatus = "PAID"      // Fully paid, outstanding = 0
 	PayableStatusReversed  PayableStatus = "REVERSED"  // Reversed/voided (e.g., purchase return)
 	PayableStatusCancelled PayableStatus = "CANCELLED" // Cancelled before any payment
+	// PayableStatusMatchFailed blocks payment until the supplier invoice matches the purchase order and goods receipts
+	PayableStatusMatchFailed PayableStatus = "MATCH_FAILED"
 )
 
 // IsValid checks if the status is a valid PayableStatus
 func (s PayableStatus) IsValid() bool {
 	switch s {
 	case PayableStatusPending, PayableStatusPartial, PayableStatusPaid,
-		PayableStatusReversed, PayableStatusCancelled:
+		PayableStatusReversed, PayableStatusCancelled, PayableStatusMatchFailed:
 		return true
 	}
 	return false
@@ -101,6 +107,7 @@ type AccountPayable struct {
 	SourceType        PayableSourceType      `json:"source_type"`
 	SourceID          uuid.UUID              `json:"source_id"`          // ID of the source document (e.g., PurchaseOrder)
 	SourceNumber      string                 `json:"source_number"`      // Number of the source document
+	Currency          valueobject.Currency   `json:"currency"`           // Currency of all amounts on this payable
 	TotalAmount       decimal.Decimal        `json:"total_amount"`       // Original amount due
 	PaidAmount        decimal.Decimal        `json:"paid_amount"`        // Amount already paid
 	OutstandingAmount decimal.Decimal        `json:"outstanding_amount"` // Remaining amount due
@@ -113,6 +120,45 @@ type AccountPayable struct {
 	ReversalReason    string                 `json:"reversal_reason"` // Reason for reversal
 	CancelledAt       *time.Time             `json:"cancelled_at"`    // When cancelled
 	CancelReason      string                 `json:"cancel_reason"`   // Reason for cancellation
+	InvoiceNumber     string                 `json:"invoice_number"`  // Supplier invoice number, empty until an invoice is recorded
+	InvoiceAmount     decimal.Decimal        `json:"invoice_amount"`  // Amount on the supplier invoice
+	InvoiceDate       *time.Time             `json:"invoice_date"`    // Date on the supplier invoice
+	MatchResult       *ThreeWayMatchResult   `json:"match_result"`    // Latest three-way match result, nil until matched
+	ReceiptIDs        ReceiptIDs             `json:"receipt_ids"`     // Receipts whose amount is included, so a redelivered receipt is not counted twice
+}
+
+// ReceiptIDs identifies the receipts of goods accumulated into a payable
+type ReceiptIDs []uuid.UUID
+
+// Value implements driver.Valuer interface for GORM to store as JSONB
+func (r ReceiptIDs) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	return json.Marshal(r)
+}
+
+// Scan implements sql.Scanner interface for GORM to read from JSONB
+func (r *ReceiptIDs) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case nil:
+		*r = ReceiptIDs{}
+		return nil
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan ReceiptIDs: unsupported type")
+	}
+
+	if len(bytes) == 0 {
+		*r = ReceiptIDs{}
+		return nil
+	}
+
+	return json.Unmarshal(bytes, r)
 }
 
 // NewAccountPayable creates a new account payable
@@ -161,6 +207,7 @@ func NewAccountPayable(
 		SourceType:          sourceType,
 		SourceID:            sourceID,
 		SourceNumber:        sourceNumber,
+		Currency:            normalizeCurrency(totalAmount.Currency()),
 		TotalAmount:         totalAmount.Amount(),
 		PaidAmount:          decimal.Zero,
 		OutstandingAmount:   totalAmount.Amount(),
@@ -174,12 +221,43 @@ func NewAccountPayable(
 	return ap, nil
 }
 
+// NewAccountPayableWithRef creates a new account payable from the Finance context's
+// view of the supplier, so the payable keeps the same supplier name as the ACL cache
+func NewAccountPayableWithRef(
+	tenantID uuid.UUID,
+	payableNumber string,
+	supplierRef acl.SupplierReference,
+	sourceType PayableSourceType,
+	sourceID uuid.UUID,
+	sourceNumber string,
+	totalAmount valueobject.Money,
+	dueDate *time.Time,
+) (*AccountPayable, error) {
+	if supplierRef.IsEmpty() {
+		return nil, shared.NewDomainError("INVALID_SUPPLIER", "Supplier reference cannot be empty")
+	}
+	return NewAccountPayable(
+		tenantID,
+		payableNumber,
+		supplierRef.UUID(),
+		supplierRef.Name(),
+		sourceType,
+		sourceID,
+		sourceNumber,
+		totalAmount,
+		dueDate,
+	)
+}
+
 // ApplyPayment applies a payment to the payable
 // Returns error if payment exceeds outstanding amount or payable is in terminal state
 func (ap *AccountPayable) ApplyPayment(amount valueobject.Money, voucherID uuid.UUID, remark string) error {
 	if !ap.Status.CanApplyPayment() {
 		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot apply payment to payable in %s status", ap.Status))
 	}
+	if normalizeCurrency(amount.Currency()) != ap.GetCurrency() {
+		return NewCurrencyMismatchError(ap.GetCurrency(), amount.Currency())
+	}
 	if amount.Amount().LessThanOrEqual(decimal.Zero) {
 		return shared.NewDomainError("INVALID_AMOUNT", "Payment amount must be positive")
 	}
@@ -189,6 +267,9 @@ func (ap *AccountPayable) ApplyPayment(amount valueobject.Money, voucherID uuid.
 	if voucherID == uuid.Nil {
 		return shared.NewDomainError("INVALID_VOUCHER", "Payment voucher ID cannot be empty")
 	}
+	if ap.HasInvoice() && ap.MatchResult == nil {
+		return shared.NewDomainError("MATCH_REQUIRED", "The invoice must be matched again before payment")
+	}
 
 	// Create payment record
 	record := NewPayablePaymentRecord(ap.ID, voucherID, amount, remark)
@@ -214,6 +295,61 @@ func (ap *AccountPayable) ApplyPayment(amount valueobject.Money, voucherID uuid.
 	return nil
 }
 
+// HasReceipt reports whether the amount of a receipt is already included in the payable
+func (ap *AccountPayable) HasReceipt(receiptID uuid.UUID) bool {
+	for _, id := range ap.ReceiptIDs {
+		if id == receiptID {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordReceipt records that the payable was created for the amount of a receipt
+func (ap *AccountPayable) RecordReceipt(receiptID uuid.UUID) {
+	if !ap.HasReceipt(receiptID) {
+		ap.ReceiptIDs = append(ap.ReceiptIDs, receiptID)
+	}
+}
+
+// IncreaseAmount adds the amount of a receipt to the payable
+// Used when a purchase order is received in multiple partial deliveries,
+// so each receipt accumulates into the same payable. A receipt already
+// included is rejected, so a redelivered receipt is not counted twice.
+// The latest match result no longer reflects the booked amount, so it is
+// cleared and an invoiced payable must be matched again before payment.
+func (ap *AccountPayable) IncreaseAmount(receiptID uuid.UUID, amount valueobject.Money) error {
+	if ap.HasReceipt(receiptID) {
+		return shared.NewDomainError("RECEIPT_ALREADY_INCLUDED", "The receipt is already included in the payable")
+	}
+	if ap.Status == PayableStatusReversed || ap.Status == PayableStatusCancelled {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot increase amount of payable in %s status", ap.Status))
+	}
+	if normalizeCurrency(amount.Currency()) != ap.GetCurrency() {
+		return NewCurrencyMismatchError(ap.GetCurrency(), amount.Currency())
+	}
+	if amount.Amount().LessThanOrEqual(decimal.Zero) {
+		return shared.NewDomainError("INVALID_AMOUNT", "Increase amount must be positive")
+	}
+
+	ap.TotalAmount = ap.TotalAmount.Add(amount.Amount())
+	ap.OutstandingAmount = ap.TotalAmount.Sub(ap.PaidAmount)
+	ap.ReceiptIDs = append(ap.ReceiptIDs, receiptID)
+	ap.MatchResult = nil
+
+	// A fully paid payable becomes partially paid again once more goods arrive
+	if ap.Status == PayableStatusPaid {
+		ap.Status = PayableStatusPartial
+		ap.PaidAt = nil
+	}
+
+	ap.UpdatedAt = time.Now()
+
+	ap.AddDomainEvent(NewAccountPayableAmountIncreasedEvent(ap, amount))
+
+	return nil
+}
+
 // Reverse reverses the payable (e.g., due to purchase return)
 // This creates a debit/negative adjustment
 func (ap *AccountPayable) Reverse(reason string) error {
@@ -261,6 +397,67 @@ func (ap *AccountPayable) Cancel(reason string) error {
 	return nil
 }
 
+// RecordInvoice records the supplier invoice against a payable sourced from a purchase order.
+// Recording a new invoice replaces the previous one; the payable must be matched again afterwards.
+func (ap *AccountPayable) RecordInvoice(invoiceNumber string, amount valueobject.Money, invoiceDate time.Time) error {
+	if ap.SourceType != PayableSourceTypePurchaseOrder {
+		return shared.NewDomainError("INVALID_SOURCE_TYPE", "Invoices can only be recorded against purchase order payables")
+	}
+	if ap.Status.IsTerminal() {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot record invoice for payable in %s status", ap.Status))
+	}
+	if invoiceNumber == "" {
+		return shared.NewDomainError("INVALID_INVOICE_NUMBER", "Invoice number cannot be empty")
+	}
+	if len(invoiceNumber) > 50 {
+		return shared.NewDomainError("INVALID_INVOICE_NUMBER", "Invoice number cannot exceed 50 characters")
+	}
+	if normalizeCurrency(amount.Currency()) != ap.GetCurrency() {
+		return NewCurrencyMismatchError(ap.GetCurrency(), amount.Currency())
+	}
+	if amount.Amount().LessThanOrEqual(decimal.Zero) {
+		return shared.NewDomainError("INVALID_AMOUNT", "Invoice amount must be positive")
+	}
+
+	ap.InvoiceNumber = invoiceNumber
+	ap.InvoiceAmount = amount.Amount()
+	ap.InvoiceDate = &invoiceDate
+	ap.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ApplyMatchResult stores the result of a three-way match against the recorded invoice.
+// A mismatch moves the payable to MATCH_FAILED, which blocks payment; a later successful
+// match returns it to PENDING or PARTIAL depending on the payments already applied.
+func (ap *AccountPayable) ApplyMatchResult(result *ThreeWayMatchResult) error {
+	if !ap.HasInvoice() {
+		return shared.NewDomainError("NO_INVOICE", "An invoice must be recorded before matching")
+	}
+	if ap.Status.IsTerminal() {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot match payable in %s status", ap.Status))
+	}
+	if result == nil {
+		return shared.NewDomainError("INVALID_MATCH_RESULT", "Match result cannot be empty")
+	}
+
+	ap.MatchResult = result
+	if result.IsMatched() {
+		if ap.Status == PayableStatusMatchFailed {
+			ap.Status = PayableStatusPending
+			if ap.PaidAmount.GreaterThan(decimal.Zero) {
+				ap.Status = PayableStatusPartial
+			}
+		}
+	} else {
+		ap.Status = PayableStatusMatchFailed
+		ap.AddDomainEvent(NewAccountPayableMatchFailedEvent(ap))
+	}
+	ap.UpdatedAt = time.Now()
+
+	return nil
+}
+
 // SetDueDate updates the due date
 func (ap *AccountPayable) SetDueDate(dueDate *time.Time) error {
 	if ap.Status.IsTerminal() {
@@ -281,19 +478,24 @@ func (ap *AccountPayable) SetRemark(remark string) {
 
 // Helper methods
 
+// GetCurrency returns the payable currency, defaulting to CNY for legacy records
+func (ap *AccountPayable) GetCurrency() valueobject.Currency {
+	return normalizeCurrency(ap.Currency)
+}
+
 // GetTotalAmountMoney returns total amount as Money
 func (ap *AccountPayable) GetTotalAmountMoney() valueobject.Money {
-	return valueobject.NewMoneyCNY(ap.TotalAmount)
+	return newMoney(ap.TotalAmount, ap.Currency)
 }
 
 // GetPaidAmountMoney returns paid amount as Money
 func (ap *AccountPayable) GetPaidAmountMoney() valueobject.Money {
-	return valueobject.NewMoneyCNY(ap.PaidAmount)
+	return newMoney(ap.PaidAmount, ap.Currency)
 }
 
 // GetOutstandingAmountMoney returns outstanding amount as Money
 func (ap *AccountPayable) GetOutstandingAmountMoney() valueobject.Money {
-	return valueobject.NewMoneyCNY(ap.OutstandingAmount)
+	return newMoney(ap.OutstandingAmount, ap.Currency)
 }
 
 // IsPending returns true if payable is pending
@@ -321,6 +523,16 @@ func (ap *AccountPayable) IsCancelled() bool {
 	return ap.Status == PayableStatusCancelled
 }
 
+// IsMatchFailed returns true if payment is blocked by a failed three-way match
+func (ap *AccountPayable) IsMatchFailed() bool {
+	return ap.Status == PayableStatusMatchFailed
+}
+
+// HasInvoice returns true if a supplier invoice has been recorded
+func (ap *AccountPayable) HasInvoice() bool {
+	return ap.InvoiceNumber != ""
+}
+
 // IsOverdue returns true if the payable is past due date and not paid
 func (ap *AccountPayable) IsOverdue() bool {
 	if ap.Status.IsTerminal() {
@@ -12,15 +12,16 @@ import (
 // AccountPayableCreatedEvent is raised when a new account payable is created
 type AccountPayableCreatedEvent struct {
 	shared.BaseDomainEvent
-	PayableID     uuid.UUID         `json:"payable_id"`
-	PayableNumber string            `json:"payable_number"`
-	SupplierID    uuid.UUID         `json:"supplier_id"`
-	SupplierName  string            `json:"supplier_name"`
-	SourceType    PayableSourceType `json:"source_type"`
-	SourceID      uuid.UUID         `json:"source_id"`
-	SourceNumber  string            `json:"source_number"`
-	TotalAmount   decimal.Decimal   `json:"total_amount"`
-	DueDate       *time.Time        `json:"due_date,omitempty"`
+	PayableID     uuid.UUID            `json:"payable_id"`
+	PayableNumber string               `json:"payable_number"`
+	SupplierID    uuid.UUID            `json:"supplier_id"`
+	SupplierName  string               `json:"supplier_name"`
+	SourceType    PayableSourceType    `json:"source_type"`
+	SourceID      uuid.UUID            `json:"source_id"`
+	SourceNumber  string               `json:"source_number"`
+	Currency      valueobject.Currency `json:"currency"`
+	TotalAmount   decimal.Decimal      `json:"total_amount"`
+	DueDate       *time.Time           `json:"due_date,omitempty"`
 }
 
 // EventType returns the event type name
@@ -39,6 +40,7 @@ func NewAccountPayableCreatedEvent(ap *AccountPayable) *AccountPayableCreatedEve
 		SourceType:      ap.SourceType,
 		SourceID:        ap.SourceID,
 		SourceNumber:    ap.SourceNumber,
+		Currency:        ap.GetCurrency(),
 		TotalAmount:     ap.TotalAmount,
 		DueDate:         ap.DueDate,
 	}
@@ -112,6 +114,41 @@ func NewAccountPayablePartiallyPaidEvent(ap *AccountPayable, paymentAmount value
 	}
 }
 
+// AccountPayableAmountIncreasedEvent is raised when additional amount is added to a payable
+type AccountPayableAmountIncreasedEvent struct {
+	shared.BaseDomainEvent
+	PayableID         uuid.UUID         `json:"payable_id"`
+	PayableNumber     string            `json:"payable_number"`
+	SupplierID        uuid.UUID         `json:"supplier_id"`
+	SupplierName      string            `json:"supplier_name"`
+	SourceType        PayableSourceType `json:"source_type"`
+	SourceID          uuid.UUID         `json:"source_id"`
+	IncreaseAmount    decimal.Decimal   `json:"increase_amount"`
+	TotalAmount       decimal.Decimal   `json:"total_amount"`
+	OutstandingAmount decimal.Decimal   `json:"outstanding_amount"`
+}
+
+// EventType returns the event type name
+func (e *AccountPayableAmountIncreasedEvent) EventType() string {
+	return "AccountPayableAmountIncreased"
+}
+
+// NewAccountPayableAmountIncreasedEvent creates a new AccountPayableAmountIncreasedEvent
+func NewAccountPayableAmountIncreasedEvent(ap *AccountPayable, increaseAmount valueobject.Money) *AccountPayableAmountIncreasedEvent {
+	return &AccountPayableAmountIncreasedEvent{
+		BaseDomainEvent:   shared.NewBaseDomainEvent("AccountPayableAmountIncreased", "AccountPayable", ap.ID, ap.TenantID),
+		PayableID:         ap.ID,
+		PayableNumber:     ap.PayableNumber,
+		SupplierID:        ap.SupplierID,
+		SupplierName:      ap.SupplierName,
+		SourceType:        ap.SourceType,
+		SourceID:          ap.SourceID,
+		IncreaseAmount:    increaseAmount.Amount(),
+		TotalAmount:       ap.TotalAmount,
+		OutstandingAmount: ap.OutstandingAmount,
+	}
+}
+
 // AccountPayableReversedEvent is raised when a payable is reversed
 type AccountPayableReversedEvent struct {
 	shared.BaseDomainEvent
@@ -187,3 +224,41 @@ func NewAccountPayableCancelledEvent(ap *AccountPayable) *AccountPayableCancelle
 		CancelledAt:     cancelledAt,
 	}
 }
+
+// AccountPayableMatchFailedEvent is raised when the supplier invoice fails the three-way match
+type AccountPayableMatchFailedEvent struct {
+	shared.BaseDomainEvent
+	PayableID      uuid.UUID          `json:"payable_id"`
+	PayableNumber  string             `json:"payable_number"`
+	SupplierID     uuid.UUID          `json:"supplier_id"`
+	SupplierName   string             `json:"supplier_name"`
+	SourceNumber   string             `json:"source_number"`
+	InvoiceNumber  string             `json:"invoice_number"`
+	InvoiceAmount  decimal.Decimal    `json:"invoice_amount"`
+	ReceivedAmount decimal.Decimal    `json:"received_amount"`
+	Discrepancies  []MatchDiscrepancy `json:"discrepancies"`
+}
+
+// EventType returns the event type name
+func (e *AccountPayableMatchFailedEvent) EventType() string {
+	return "AccountPayableMatchFailed"
+}
+
+// NewAccountPayableMatchFailedEvent creates a new AccountPayableMatchFailedEvent
+func NewAccountPayableMatchFailedEvent(ap *AccountPayable) *AccountPayableMatchFailedEvent {
+	event := &AccountPayableMatchFailedEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent("AccountPayableMatchFailed", "AccountPayable", ap.ID, ap.TenantID),
+		PayableID:       ap.ID,
+		PayableNumber:   ap.PayableNumber,
+		SupplierID:      ap.SupplierID,
+		SupplierName:    ap.SupplierName,
+		SourceNumber:    ap.SourceNumber,
+		InvoiceNumber:   ap.InvoiceNumber,
+		InvoiceAmount:   ap.InvoiceAmount,
+	}
+	if ap.MatchResult != nil {
+		event.ReceivedAmount = ap.MatchResult.ReceivedAmount
+		event.Discrepancies = ap.MatchResult.Discrepancies
+	}
+	return event
+}
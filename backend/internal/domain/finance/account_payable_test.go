@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/erp/backend/internal/domain/finance/acl"
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -23,6 +25,7 @@ func TestPayableStatus_String(t *testing.T) {
 		{PayableStatusPaid, "PAID"},
 		{PayableStatusReversed, "REVERSED"},
 		{PayableStatusCancelled, "CANCELLED"},
+		{PayableStatusMatchFailed, "MATCH_FAILED"},
 	}
 
 	for _, tc := range tests {
@@ -42,6 +45,7 @@ func TestPayableStatus_IsValid(t *testing.T) {
 		{PayableStatusPaid, true},
 		{PayableStatusReversed, true},
 		{PayableStatusCancelled, true},
+		{PayableStatusMatchFailed, true},
 		{PayableStatus("INVALID"), false},
 		{PayableStatus(""), false},
 	}
@@ -63,6 +67,7 @@ func TestPayableStatus_IsTerminal(t *testing.T) {
 		{PayableStatusPaid, true},
 		{PayableStatusReversed, true},
 		{PayableStatusCancelled, true},
+		{PayableStatusMatchFailed, false},
 	}
 
 	for _, tc := range tests {
@@ -82,6 +87,7 @@ func TestPayableStatus_CanApplyPayment(t *testing.T) {
 		{PayableStatusPaid, false},
 		{PayableStatusReversed, false},
 		{PayableStatusCancelled, false},
+		{PayableStatusMatchFailed, false},
 	}
 
 	for _, tc := range tests {
@@ -182,6 +188,38 @@ func TestNewAccountPayable_ValidData(t *testing.T) {
 	assert.Equal(t, "AccountPayableCreated", events[0].EventType())
 }
 
+func TestNewAccountPayableWithRef(t *testing.T) {
+	supplierRef := acl.MustNewSupplierReference(uuid.New(), "Ref Supplier", "SUP-001")
+
+	ap, err := NewAccountPayableWithRef(
+		uuid.New(),
+		"AP-2024-00001",
+		supplierRef,
+		PayableSourceTypePurchaseOrder,
+		uuid.New(),
+		"PO-2024-00001",
+		valueobject.NewMoneyCNYFromFloat(1000.00),
+		nil,
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, supplierRef.UUID(), ap.SupplierID)
+	assert.Equal(t, "Ref Supplier", ap.SupplierName)
+
+	_, err = NewAccountPayableWithRef(
+		uuid.New(),
+		"AP-2024-00002",
+		acl.EmptySupplierReference(),
+		PayableSourceTypePurchaseOrder,
+		uuid.New(),
+		"PO-2024-00001",
+		valueobject.NewMoneyCNYFromFloat(1000.00),
+		nil,
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Supplier reference cannot be empty")
+}
+
 func TestNewAccountPayable_EmptyPayableNumber(t *testing.T) {
 	tenantID := uuid.New()
 	supplierID := uuid.New()
@@ -535,6 +573,77 @@ func TestAccountPayable_ApplyPayment_ToCancelledPayable(t *testing.T) {
 	assert.Contains(t, err.Error(), "Cannot apply payment")
 }
 
+// Test IncreaseAmount
+
+func TestAccountPayable_IncreaseAmount_FromPending(t *testing.T) {
+	ap := createTestPayable(t, 500.00)
+
+	err := ap.IncreaseAmount(uuid.New(), valueobject.NewMoneyCNYFromFloat(500.00))
+
+	require.NoError(t, err)
+	assert.True(t, ap.TotalAmount.Equal(decimal.NewFromFloat(1000.00)))
+	assert.True(t, ap.OutstandingAmount.Equal(decimal.NewFromFloat(1000.00)))
+	assert.Equal(t, PayableStatusPending, ap.Status)
+
+	// Verify event
+	events := ap.GetDomainEvents()
+	require.Len(t, events, 2) // Created + AmountIncreased
+	increasedEvent := events[1].(*AccountPayableAmountIncreasedEvent)
+	assert.Equal(t, "AccountPayableAmountIncreased", increasedEvent.EventType())
+	assert.True(t, increasedEvent.IncreaseAmount.Equal(decimal.NewFromFloat(500.00)))
+}
+
+func TestAccountPayable_IncreaseAmount_ReopensPaidPayable(t *testing.T) {
+	ap := createTestPayable(t, 500.00)
+	_ = ap.ApplyPayment(valueobject.NewMoneyCNYFromFloat(500.00), uuid.New(), "Full payment")
+	require.Equal(t, PayableStatusPaid, ap.Status)
+
+	err := ap.IncreaseAmount(uuid.New(), valueobject.NewMoneyCNYFromFloat(200.00))
+
+	require.NoError(t, err)
+	assert.Equal(t, PayableStatusPartial, ap.Status)
+	assert.Nil(t, ap.PaidAt)
+	assert.True(t, ap.OutstandingAmount.Equal(decimal.NewFromFloat(200.00)))
+}
+
+func TestAccountPayable_IncreaseAmount_ReceiptAlreadyIncluded(t *testing.T) {
+	ap := createTestPayable(t, 500.00)
+	firstReceiptID := uuid.New()
+	ap.RecordReceipt(firstReceiptID)
+	secondReceiptID := uuid.New()
+	require.NoError(t, ap.IncreaseAmount(secondReceiptID, valueobject.NewMoneyCNYFromFloat(200.00)))
+
+	// Redelivered receipts, including the one the payable was created for
+	for _, receiptID := range []uuid.UUID{firstReceiptID, secondReceiptID} {
+		err := ap.IncreaseAmount(receiptID, valueobject.NewMoneyCNYFromFloat(200.00))
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "RECEIPT_ALREADY_INCLUDED", domainErr.Code)
+	}
+	assert.True(t, ap.TotalAmount.Equal(decimal.NewFromFloat(700.00)))
+	assert.True(t, ap.HasReceipt(secondReceiptID))
+}
+
+func TestAccountPayable_IncreaseAmount_NonPositiveAmount(t *testing.T) {
+	ap := createTestPayable(t, 500.00)
+
+	err := ap.IncreaseAmount(uuid.New(), valueobject.NewMoneyCNYFromFloat(0))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Increase amount must be positive")
+}
+
+func TestAccountPayable_IncreaseAmount_ToCancelledPayable(t *testing.T) {
+	ap := createTestPayable(t, 500.00)
+	_ = ap.Cancel("Test cancellation")
+
+	err := ap.IncreaseAmount(uuid.New(), valueobject.NewMoneyCNYFromFloat(100.00))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Cannot increase amount")
+}
+
 // Test Reverse
 
 func TestAccountPayable_Reverse_FromPending(t *testing.T) {
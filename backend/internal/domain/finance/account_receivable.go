@@ -17,18 +17,19 @@ import (
 type ReceivableStatus string
 
 const (
-	ReceivableStatusPending   ReceivableStatus = "PENDING"   // Unpaid, outstanding balance > 0
-	ReceivableStatusPartial   ReceivableStatus = "PARTIAL"   // Partially paid, 0 < outstanding < total
-	ReceivableStatusPaid      ReceivableStatus = "PAID"      // Fully paid, outstanding = 0
-	ReceivableStatusReversed  ReceivableStatus = "REVERSED"  // Reversed/voided (e.g., return)
-	ReceivableStatusCancelled ReceivableStatus = "CANCELLED" // Cancelled before any payment
+	ReceivableStatusPending    ReceivableStatus = "PENDING"     // Unpaid, outstanding balance > 0
+	ReceivableStatusPartial    ReceivableStatus = "PARTIAL"     // Partially paid, 0 < outstanding < total
+	ReceivableStatusPaid       ReceivableStatus = "PAID"        // Fully paid, outstanding = 0
+	ReceivableStatusReversed   ReceivableStatus = "REVERSED"    // Reversed/voided (e.g., return)
+	ReceivableStatusCancelled  ReceivableStatus = "CANCELLED"   // Cancelled before any payment
+	ReceivableStatusWrittenOff ReceivableStatus = "WRITTEN_OFF" // Written off as bad debt
 )
 
 // IsValid checks if the status is a valid ReceivableStatus
 func (s ReceivableStatus) IsValid() bool {
 	switch s {
 	case ReceivableStatusPending, ReceivableStatusPartial, ReceivableStatusPaid,
-		ReceivableStatusReversed, ReceivableStatusCancelled:
+		ReceivableStatusReversed, ReceivableStatusCancelled, ReceivableStatusWrittenOff:
 		return true
 	}
 	return false
@@ -41,7 +42,8 @@ func (s ReceivableStatus) String() string {
 
 // IsTerminal returns true if the receivable is in a terminal state
 func (s ReceivableStatus) IsTerminal() bool {
-	return s == ReceivableStatusPaid || s == ReceivableStatusReversed || s == ReceivableStatusCancelled
+	return s == ReceivableStatusPaid || s == ReceivableStatusReversed || s == ReceivableStatusCancelled ||
+		s == ReceivableStatusWrittenOff
 }
 
 // CanApplyPayment returns true if payments can be applied in this status
@@ -85,6 +87,7 @@ func (s PaymentRecordStatus) IsValid() bool {
 type PaymentRecord struct {
 	ID               uuid.UUID           `json:"id"`
 	ReceiptVoucherID uuid.UUID           `json:"receipt_voucher_id"` // Reference to the receipt voucher
+	AllocationID     uuid.UUID           `json:"allocation_id"`      // Voucher allocation the payment came from, if any
 	Amount           decimal.Decimal     `json:"amount"`
 	AppliedAt        time.Time           `json:"applied_at"`
 	Remark           string              `json:"remark,omitempty"`
@@ -169,24 +172,33 @@ func (p *PaymentRecord) MarkReversed(reason string) {
 // It tracks money owed by a customer for goods/services provided
 type AccountReceivable struct {
 	shared.TenantAggregateRoot
-	ReceivableNumber  string           `json:"receivable_number"`
-	CustomerID        uuid.UUID        `json:"customer_id"`
-	CustomerName      string           `json:"customer_name"`
-	SourceType        SourceType       `json:"source_type"`
-	SourceID          uuid.UUID        `json:"source_id"`          // ID of the source document (e.g., SalesOrder)
-	SourceNumber      string           `json:"source_number"`      // Number of the source document
-	TotalAmount       decimal.Decimal  `json:"total_amount"`       // Original amount due
-	PaidAmount        decimal.Decimal  `json:"paid_amount"`        // Amount already paid
-	OutstandingAmount decimal.Decimal  `json:"outstanding_amount"` // Remaining amount due
-	Status            ReceivableStatus `json:"status"`
-	DueDate           *time.Time       `json:"due_date"` // When payment is expected
-	PaymentRecords    PaymentRecords   `json:"payment_records"`
-	Remark            string           `json:"remark"`
-	PaidAt            *time.Time       `json:"paid_at"`         // When fully paid
-	ReversedAt        *time.Time       `json:"reversed_at"`     // When reversed
-	ReversalReason    string           `json:"reversal_reason"` // Reason for reversal
-	CancelledAt       *time.Time       `json:"cancelled_at"`    // When cancelled
-	CancelReason      string           `json:"cancel_reason"`   // Reason for cancellation
+	ReceivableNumber  string               `json:"receivable_number"`
+	CustomerID        uuid.UUID            `json:"customer_id"`
+	CustomerName      string               `json:"customer_name"`
+	SourceType        SourceType           `json:"source_type"`
+	SourceID          uuid.UUID            `json:"source_id"`          // ID of the source document (e.g., SalesOrder)
+	SourceNumber      string               `json:"source_number"`      // Number of the source document
+	ShipmentNumber    int                  `json:"shipment_number"`    // Shipment of a sales order this receivable bills; 0 if not billed per shipment
+	Currency          valueobject.Currency `json:"currency"`           // Currency of all amounts on this receivable
+	TotalAmount       decimal.Decimal      `json:"total_amount"`       // Original amount due
+	PaidAmount        decimal.Decimal      `json:"paid_amount"`        // Amount already paid
+	OutstandingAmount decimal.Decimal      `json:"outstanding_amount"` // Remaining amount due
+	Status            ReceivableStatus     `json:"status"`
+	DueDate           *time.Time           `json:"due_date"` // When payment is expected
+	PaymentRecords    PaymentRecords       `json:"payment_records"`
+	Remark            string               `json:"remark"`
+	PaidAt            *time.Time           `json:"paid_at"`            // When fully paid
+	ReversedAt        *time.Time           `json:"reversed_at"`        // When reversed
+	ReversalReason    string               `json:"reversal_reason"`    // Reason for reversal
+	CancelledAt       *time.Time           `json:"cancelled_at"`       // When cancelled
+	CancelReason      string               `json:"cancel_reason"`      // Reason for cancellation
+	WrittenOffAmount  decimal.Decimal      `json:"written_off_amount"` // Outstanding amount written off as bad debt
+	WrittenOffAt      *time.Time           `json:"written_off_at"`     // When written off
+	WrittenOffBy      *uuid.UUID           `json:"written_off_by"`     // Who approved the write-off
+	WriteOffReason    string               `json:"write_off_reason"`   // Reason for write-off
+
+	ReminderStage      ReceivableReminderStage `json:"reminder_stage"`        // Last due-date reminder sent
+	LastReminderSentAt *time.Time              `json:"last_reminder_sent_at"` // When the last due-date reminder was sent
 }
 
 // NewAccountReceivable creates a new account receivable
@@ -235,6 +247,7 @@ func NewAccountReceivable(
 		SourceType:          sourceType,
 		SourceID:            sourceID,
 		SourceNumber:        sourceNumber,
+		Currency:            normalizeCurrency(totalAmount.Currency()),
 		TotalAmount:         totalAmount.Amount(),
 		PaidAmount:          decimal.Zero,
 		OutstandingAmount:   totalAmount.Amount(),
@@ -251,9 +264,22 @@ func NewAccountReceivable(
 // ApplyPayment applies a payment to the receivable
 // Returns error if payment exceeds outstanding amount or receivable is in terminal state
 func (ar *AccountReceivable) ApplyPayment(amount valueobject.Money, voucherID uuid.UUID, remark string) error {
+	return ar.applyPayment(amount, voucherID, uuid.Nil, remark)
+}
+
+// ApplyAllocation applies the payment made by a receipt voucher allocation to this receivable.
+// The payment record keeps the allocation ID so undoing the allocation reverses exactly this payment.
+func (ar *AccountReceivable) ApplyAllocation(allocation *ReceivableAllocation, amount valueobject.Money, remark string) error {
+	return ar.applyPayment(amount, allocation.ReceiptVoucherID, allocation.ID, remark)
+}
+
+func (ar *AccountReceivable) applyPayment(amount valueobject.Money, voucherID, allocationID uuid.UUID, remark string) error {
 	if !ar.Status.CanApplyPayment() {
 		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot apply payment to receivable in %s status", ar.Status))
 	}
+	if normalizeCurrency(amount.Currency()) != ar.GetCurrency() {
+		return NewCurrencyMismatchError(ar.GetCurrency(), amount.Currency())
+	}
 	if amount.Amount().LessThanOrEqual(decimal.Zero) {
 		return shared.NewDomainError("INVALID_AMOUNT", "Payment amount must be positive")
 	}
@@ -266,6 +292,7 @@ func (ar *AccountReceivable) ApplyPayment(amount valueobject.Money, voucherID uu
 
 	// Create payment record
 	record := NewPaymentRecord(voucherID, amount, remark)
+	record.AllocationID = allocationID
 	ar.PaymentRecords = append(ar.PaymentRecords, *record)
 
 	// Update amounts
@@ -288,6 +315,60 @@ func (ar *AccountReceivable) ApplyPayment(amount valueobject.Money, voucherID uu
 	return nil
 }
 
+// ReversePayment reverses the active payment applied by a receipt voucher allocation, e.g. when
+// the allocation is undone. The amount becomes outstanding again and a paid receivable goes back
+// to partial or pending.
+// Payments recorded before allocations were tracked are matched by voucher and amount.
+// Returns the reversed payment record
+func (ar *AccountReceivable) ReversePayment(allocation *ReceivableAllocation, reason string) (*PaymentRecord, error) {
+	if ar.Status != ReceivableStatusPartial && ar.Status != ReceivableStatusPaid {
+		return nil, shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot reverse payment on receivable in %s status", ar.Status))
+	}
+
+	record := ar.findAllocationPayment(allocation)
+	if record == nil {
+		return nil, shared.NewDomainError("NOT_FOUND", "No active payment from this receipt voucher allocation")
+	}
+
+	record.MarkReversed(reason)
+
+	// Update amounts
+	previousStatus := ar.Status
+	ar.PaidAmount = ar.PaidAmount.Sub(record.Amount)
+	ar.OutstandingAmount = ar.TotalAmount.Sub(ar.PaidAmount)
+
+	if ar.PaidAmount.IsZero() {
+		ar.Status = ReceivableStatusPending
+	} else {
+		ar.Status = ReceivableStatusPartial
+	}
+	ar.PaidAt = nil
+
+	ar.UpdatedAt = time.Now()
+
+	ar.AddDomainEvent(NewAccountReceivablePaymentReversedEvent(ar, previousStatus, record))
+
+	return record, nil
+}
+
+// findAllocationPayment returns the active payment record applied by the allocation, or nil
+func (ar *AccountReceivable) findAllocationPayment(allocation *ReceivableAllocation) *PaymentRecord {
+	var legacy *PaymentRecord
+	for i := range ar.PaymentRecords {
+		record := &ar.PaymentRecords[i]
+		if !record.IsActive() || record.ReceiptVoucherID != allocation.ReceiptVoucherID {
+			continue
+		}
+		if record.AllocationID == allocation.ID {
+			return record
+		}
+		if legacy == nil && record.AllocationID == uuid.Nil && record.Amount.Equal(allocation.Amount) {
+			legacy = record
+		}
+	}
+	return legacy
+}
+
 // ReversalResult contains the result of a receivable reversal operation
 // It indicates what actions need to be taken for the paid amount
 type ReversalResult struct {
@@ -385,12 +466,50 @@ func (ar *AccountReceivable) Cancel(reason string) error {
 	return nil
 }
 
+// WriteOff writes off the remaining outstanding amount as bad debt.
+// Unlike Cancel and Reverse, payments already applied are kept and only the
+// uncollectable balance is removed, with the approver recorded for audit.
+func (ar *AccountReceivable) WriteOff(reason string, approvedBy uuid.UUID) error {
+	if ar.Status.IsTerminal() {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot write off receivable in %s status", ar.Status))
+	}
+	if !ar.OutstandingAmount.GreaterThan(decimal.Zero) {
+		return shared.NewDomainError("INVALID_AMOUNT", "No outstanding amount to write off")
+	}
+	if reason == "" {
+		return shared.NewDomainError("INVALID_REASON", "Write-off reason is required")
+	}
+	if approvedBy == uuid.Nil {
+		return shared.NewDomainError("INVALID_APPROVER", "Write-off approver is required")
+	}
+
+	now := time.Now()
+	previousStatus := ar.Status
+	ar.Status = ReceivableStatusWrittenOff
+	ar.WrittenOffAmount = ar.OutstandingAmount
+	ar.WrittenOffAt = &now
+	ar.WrittenOffBy = &approvedBy
+	ar.WriteOffReason = reason
+	ar.OutstandingAmount = decimal.Zero // No longer outstanding
+	ar.UpdatedAt = now
+
+	ar.AddDomainEvent(NewReceivableWrittenOffEvent(ar, previousStatus))
+
+	return nil
+}
+
 // SetDueDate updates the due date
 func (ar *AccountReceivable) SetDueDate(dueDate *time.Time) error {
 	if ar.Status.IsTerminal() {
 		return shared.NewDomainError("INVALID_STATE", "Cannot modify due date for receivable in terminal state")
 	}
 
+	// A new due date starts a new reminder cycle
+	if !sameDueDate(ar.DueDate, dueDate) {
+		ar.ReminderStage = ReceivableReminderStageNone
+		ar.LastReminderSentAt = nil
+	}
+
 	ar.DueDate = dueDate
 	ar.UpdatedAt = time.Now()
 
@@ -405,19 +524,24 @@ func (ar *AccountReceivable) SetRemark(remark string) {
 
 // Helper methods
 
+// GetCurrency returns the receivable currency, defaulting to CNY for legacy records
+func (ar *AccountReceivable) GetCurrency() valueobject.Currency {
+	return normalizeCurrency(ar.Currency)
+}
+
 // GetTotalAmountMoney returns total amount as Money
 func (ar *AccountReceivable) GetTotalAmountMoney() valueobject.Money {
-	return valueobject.NewMoneyCNY(ar.TotalAmount)
+	return newMoney(ar.TotalAmount, ar.Currency)
 }
 
 // GetPaidAmountMoney returns paid amount as Money
 func (ar *AccountReceivable) GetPaidAmountMoney() valueobject.Money {
-	return valueobject.NewMoneyCNY(ar.PaidAmount)
+	return newMoney(ar.PaidAmount, ar.Currency)
 }
 
 // GetOutstandingAmountMoney returns outstanding amount as Money
 func (ar *AccountReceivable) GetOutstandingAmountMoney() valueobject.Money {
-	return valueobject.NewMoneyCNY(ar.OutstandingAmount)
+	return newMoney(ar.OutstandingAmount, ar.Currency)
 }
 
 // IsPending returns true if receivable is pending
@@ -445,6 +569,11 @@ func (ar *AccountReceivable) IsCancelled() bool {
 	return ar.Status == ReceivableStatusCancelled
 }
 
+// IsWrittenOff returns true if receivable is written off
+func (ar *AccountReceivable) IsWrittenOff() bool {
+	return ar.Status == ReceivableStatusWrittenOff
+}
+
 // IsOverdue returns true if the receivable is past due date and not paid
 func (ar *AccountReceivable) IsOverdue() bool {
 	if ar.Status.IsTerminal() {
@@ -12,15 +12,16 @@ import (
 // AccountReceivableCreatedEvent is raised when a new account receivable is created
 type AccountReceivableCreatedEvent struct {
 	shared.BaseDomainEvent
-	ReceivableID     uuid.UUID       `json:"receivable_id"`
-	ReceivableNumber string          `json:"receivable_number"`
-	CustomerID       uuid.UUID       `json:"customer_id"`
-	CustomerName     string          `json:"customer_name"`
-	SourceType       SourceType      `json:"source_type"`
-	SourceID         uuid.UUID       `json:"source_id"`
-	SourceNumber     string          `json:"source_number"`
-	TotalAmount      decimal.Decimal `json:"total_amount"`
-	DueDate          *time.Time      `json:"due_date,omitempty"`
+	ReceivableID     uuid.UUID            `json:"receivable_id"`
+	ReceivableNumber string               `json:"receivable_number"`
+	CustomerID       uuid.UUID            `json:"customer_id"`
+	CustomerName     string               `json:"customer_name"`
+	SourceType       SourceType           `json:"source_type"`
+	SourceID         uuid.UUID            `json:"source_id"`
+	SourceNumber     string               `json:"source_number"`
+	Currency         valueobject.Currency `json:"currency"`
+	TotalAmount      decimal.Decimal      `json:"total_amount"`
+	DueDate          *time.Time           `json:"due_date,omitempty"`
 }
 
 // EventType returns the event type name
@@ -39,11 +40,15 @@ func NewAccountReceivableCreatedEvent(ar *AccountReceivable) *AccountReceivableC
 		SourceType:       ar.SourceType,
 		SourceID:         ar.SourceID,
 		SourceNumber:     ar.SourceNumber,
+		Currency:         ar.GetCurrency(),
 		TotalAmount:      ar.TotalAmount,
 		DueDate:          ar.DueDate,
 	}
 }
 
+// EventTypeAccountReceivablePaid is the event type of AccountReceivablePaidEvent
+const EventTypeAccountReceivablePaid = "AccountReceivablePaid"
+
 // AccountReceivablePaidEvent is raised when a receivable is fully paid
 type AccountReceivablePaidEvent struct {
 	shared.BaseDomainEvent
@@ -58,7 +63,7 @@ type AccountReceivablePaidEvent struct {
 
 // EventType returns the event type name
 func (e *AccountReceivablePaidEvent) EventType() string {
-	return "AccountReceivablePaid"
+	return EventTypeAccountReceivablePaid
 }
 
 // NewAccountReceivablePaidEvent creates a new AccountReceivablePaidEvent
@@ -68,7 +73,7 @@ func NewAccountReceivablePaidEvent(ar *AccountReceivable) *AccountReceivablePaid
 		paidAt = *ar.PaidAt
 	}
 	return &AccountReceivablePaidEvent{
-		BaseDomainEvent:  shared.NewBaseDomainEvent("AccountReceivablePaid", "AccountReceivable", ar.ID, ar.TenantID),
+		BaseDomainEvent:  shared.NewBaseDomainEvent(EventTypeAccountReceivablePaid, "AccountReceivable", ar.ID, ar.TenantID),
 		ReceivableID:     ar.ID,
 		ReceivableNumber: ar.ReceivableNumber,
 		CustomerID:       ar.CustomerID,
@@ -112,6 +117,48 @@ func NewAccountReceivablePartiallyPaidEvent(ar *AccountReceivable, paymentAmount
 	}
 }
 
+// AccountReceivablePaymentReversedEvent is raised when a single payment is reversed,
+// returning its amount to the receivable's outstanding balance
+type AccountReceivablePaymentReversedEvent struct {
+	shared.BaseDomainEvent
+	ReceivableID      uuid.UUID        `json:"receivable_id"`
+	ReceivableNumber  string           `json:"receivable_number"`
+	CustomerID        uuid.UUID        `json:"customer_id"`
+	CustomerName      string           `json:"customer_name"`
+	PaymentRecordID   uuid.UUID        `json:"payment_record_id"`
+	ReceiptVoucherID  uuid.UUID        `json:"receipt_voucher_id"`
+	PaymentAmount     decimal.Decimal  `json:"payment_amount"`
+	TotalAmount       decimal.Decimal  `json:"total_amount"`
+	PaidAmount        decimal.Decimal  `json:"paid_amount"`
+	OutstandingAmount decimal.Decimal  `json:"outstanding_amount"`
+	PreviousStatus    ReceivableStatus `json:"previous_status"`
+	ReversalReason    string           `json:"reversal_reason"`
+}
+
+// EventType returns the event type name
+func (e *AccountReceivablePaymentReversedEvent) EventType() string {
+	return "AccountReceivablePaymentReversed"
+}
+
+// NewAccountReceivablePaymentReversedEvent creates a new AccountReceivablePaymentReversedEvent
+func NewAccountReceivablePaymentReversedEvent(ar *AccountReceivable, previousStatus ReceivableStatus, record *PaymentRecord) *AccountReceivablePaymentReversedEvent {
+	return &AccountReceivablePaymentReversedEvent{
+		BaseDomainEvent:   shared.NewBaseDomainEvent("AccountReceivablePaymentReversed", "AccountReceivable", ar.ID, ar.TenantID),
+		ReceivableID:      ar.ID,
+		ReceivableNumber:  ar.ReceivableNumber,
+		CustomerID:        ar.CustomerID,
+		CustomerName:      ar.CustomerName,
+		PaymentRecordID:   record.ID,
+		ReceiptVoucherID:  record.ReceiptVoucherID,
+		PaymentAmount:     record.Amount,
+		TotalAmount:       ar.TotalAmount,
+		PaidAmount:        ar.PaidAmount,
+		OutstandingAmount: ar.OutstandingAmount,
+		PreviousStatus:    previousStatus,
+		ReversalReason:    record.ReversalReason,
+	}
+}
+
 // ReversedPaymentInfo contains information about a payment record that was reversed
 // BUG-010: Provides complete audit trail for reversed payments
 type ReversedPaymentInfo struct {
@@ -227,3 +274,114 @@ func NewAccountReceivableCancelledEvent(ar *AccountReceivable) *AccountReceivabl
 		CancelledAt:      cancelledAt,
 	}
 }
+
+// ReceivableWrittenOffEvent is raised when a receivable is written off as bad debt
+type ReceivableWrittenOffEvent struct {
+	shared.BaseDomainEvent
+	ReceivableID     uuid.UUID            `json:"receivable_id"`
+	ReceivableNumber string               `json:"receivable_number"`
+	CustomerID       uuid.UUID            `json:"customer_id"`
+	CustomerName     string               `json:"customer_name"`
+	Currency         valueobject.Currency `json:"currency"`
+	TotalAmount      decimal.Decimal      `json:"total_amount"`
+	PaidAmount       decimal.Decimal      `json:"paid_amount"`
+	WrittenOffAmount decimal.Decimal      `json:"written_off_amount"`
+	PreviousStatus   ReceivableStatus     `json:"previous_status"`
+	WriteOffReason   string               `json:"write_off_reason"`
+	ApprovedBy       uuid.UUID            `json:"approved_by"`
+	WrittenOffAt     time.Time            `json:"written_off_at"`
+}
+
+// EventType returns the event type name
+func (e *ReceivableWrittenOffEvent) EventType() string {
+	return "ReceivableWrittenOff"
+}
+
+// NewReceivableWrittenOffEvent creates a new ReceivableWrittenOffEvent
+func NewReceivableWrittenOffEvent(ar *AccountReceivable, previousStatus ReceivableStatus) *ReceivableWrittenOffEvent {
+	writtenOffAt := time.Now()
+	if ar.WrittenOffAt != nil {
+		writtenOffAt = *ar.WrittenOffAt
+	}
+	var approvedBy uuid.UUID
+	if ar.WrittenOffBy != nil {
+		approvedBy = *ar.WrittenOffBy
+	}
+	return &ReceivableWrittenOffEvent{
+		BaseDomainEvent:  shared.NewBaseDomainEvent("ReceivableWrittenOff", "AccountReceivable", ar.ID, ar.TenantID),
+		ReceivableID:     ar.ID,
+		ReceivableNumber: ar.ReceivableNumber,
+		CustomerID:       ar.CustomerID,
+		CustomerName:     ar.CustomerName,
+		Currency:         ar.GetCurrency(),
+		TotalAmount:      ar.TotalAmount,
+		PaidAmount:       ar.PaidAmount,
+		WrittenOffAmount: ar.WrittenOffAmount,
+		PreviousStatus:   previousStatus,
+		WriteOffReason:   ar.WriteOffReason,
+		ApprovedBy:       approvedBy,
+		WrittenOffAt:     writtenOffAt,
+	}
+}
+
+// ReceivableDueSoonEvent is raised when an outstanding receivable's due date is approaching
+type ReceivableDueSoonEvent struct {
+	shared.BaseDomainEvent
+	ReceivableID      uuid.UUID       `json:"receivable_id"`
+	ReceivableNumber  string          `json:"receivable_number"`
+	CustomerID        uuid.UUID       `json:"customer_id"`
+	CustomerName      string          `json:"customer_name"`
+	OutstandingAmount decimal.Decimal `json:"outstanding_amount"`
+	DueDate           time.Time       `json:"due_date"`
+	DaysUntilDue      int             `json:"days_until_due"`
+}
+
+// EventType returns the event type name
+func (e *ReceivableDueSoonEvent) EventType() string {
+	return "ReceivableDueSoon"
+}
+
+// NewReceivableDueSoonEvent creates a new ReceivableDueSoonEvent
+func NewReceivableDueSoonEvent(ar *AccountReceivable, now time.Time) *ReceivableDueSoonEvent {
+	return &ReceivableDueSoonEvent{
+		BaseDomainEvent:   shared.NewBaseDomainEvent("ReceivableDueSoon", "AccountReceivable", ar.ID, ar.TenantID),
+		ReceivableID:      ar.ID,
+		ReceivableNumber:  ar.ReceivableNumber,
+		CustomerID:        ar.CustomerID,
+		CustomerName:      ar.CustomerName,
+		OutstandingAmount: ar.OutstandingAmount,
+		DueDate:           *ar.DueDate,
+		DaysUntilDue:      int(ar.DueDate.Sub(now).Hours() / 24),
+	}
+}
+
+// ReceivableOverdueEvent is raised when an outstanding receivable passes its due date
+type ReceivableOverdueEvent struct {
+	shared.BaseDomainEvent
+	ReceivableID      uuid.UUID       `json:"receivable_id"`
+	ReceivableNumber  string          `json:"receivable_number"`
+	CustomerID        uuid.UUID       `json:"customer_id"`
+	CustomerName      string          `json:"customer_name"`
+	OutstandingAmount decimal.Decimal `json:"outstanding_amount"`
+	DueDate           time.Time       `json:"due_date"`
+	DaysOverdue       int             `json:"days_overdue"`
+}
+
+// EventType returns the event type name
+func (e *ReceivableOverdueEvent) EventType() string {
+	return "ReceivableOverdue"
+}
+
+// NewReceivableOverdueEvent creates a new ReceivableOverdueEvent
+func NewReceivableOverdueEvent(ar *AccountReceivable, now time.Time) *ReceivableOverdueEvent {
+	return &ReceivableOverdueEvent{
+		BaseDomainEvent:   shared.NewBaseDomainEvent("ReceivableOverdue", "AccountReceivable", ar.ID, ar.TenantID),
+		ReceivableID:      ar.ID,
+		ReceivableNumber:  ar.ReceivableNumber,
+		CustomerID:        ar.CustomerID,
+		CustomerName:      ar.CustomerName,
+		OutstandingAmount: ar.OutstandingAmount,
+		DueDate:           *ar.DueDate,
+		DaysOverdue:       int(now.Sub(*ar.DueDate).Hours() / 24),
+	}
+}
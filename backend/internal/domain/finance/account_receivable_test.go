@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -54,6 +55,7 @@ func TestReceivableStatus_IsValid(t *testing.T) {
 		{ReceivableStatusPaid, true},
 		{ReceivableStatusReversed, true},
 		{ReceivableStatusCancelled, true},
+		{ReceivableStatusWrittenOff, true},
 		{ReceivableStatus("INVALID"), false},
 		{ReceivableStatus(""), false},
 	}
@@ -75,6 +77,7 @@ func TestReceivableStatus_IsTerminal(t *testing.T) {
 		{ReceivableStatusPaid, true},
 		{ReceivableStatusReversed, true},
 		{ReceivableStatusCancelled, true},
+		{ReceivableStatusWrittenOff, true},
 	}
 
 	for _, tt := range tests {
@@ -94,6 +97,7 @@ func TestReceivableStatus_CanApplyPayment(t *testing.T) {
 		{ReceivableStatusPaid, false},
 		{ReceivableStatusReversed, false},
 		{ReceivableStatusCancelled, false},
+		{ReceivableStatusWrittenOff, false},
 	}
 
 	for _, tt := range tests {
@@ -467,6 +471,110 @@ func TestAccountReceivable_ApplyPayment(t *testing.T) {
 	})
 }
 
+// ============================================
+// ReversePayment Tests
+// ============================================
+
+func TestAccountReceivable_ReversePayment(t *testing.T) {
+	// allocate applies a payment to ar through an allocation of the voucher
+	allocate := func(t *testing.T, ar *AccountReceivable, voucherID uuid.UUID, amount float64) *ReceivableAllocation {
+		money := valueobject.NewMoneyCNYFromFloat(amount)
+		allocation := NewReceivableAllocation(voucherID, ar.ID, ar.ReceivableNumber, money, "")
+		require.NoError(t, ar.ApplyAllocation(allocation, money, ""))
+		return allocation
+	}
+
+	t.Run("returns paid receivable to partial", func(t *testing.T) {
+		ar := createTestReceivable(t) // 1000.00
+		allocate(t, ar, uuid.New(), 400.00)
+		second := allocate(t, ar, uuid.New(), 600.00)
+		require.Equal(t, ReceivableStatusPaid, ar.Status)
+		ar.ClearDomainEvents()
+
+		record, err := ar.ReversePayment(second, "Applied to wrong invoice")
+		require.NoError(t, err)
+
+		assert.True(t, record.IsReversed())
+		assert.Equal(t, "Applied to wrong invoice", record.ReversalReason)
+		assert.Equal(t, ReceivableStatusPartial, ar.Status)
+		assert.Nil(t, ar.PaidAt)
+		assert.True(t, decimal.NewFromFloat(400.00).Equal(ar.PaidAmount))
+		assert.True(t, decimal.NewFromFloat(600.00).Equal(ar.OutstandingAmount))
+		assert.True(t, ar.PaymentRecords[0].IsActive())
+
+		events := ar.GetDomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(*AccountReceivablePaymentReversedEvent)
+		require.True(t, ok)
+		assert.Equal(t, ReceivableStatusPaid, event.PreviousStatus)
+		assert.True(t, decimal.NewFromFloat(600.00).Equal(event.PaymentAmount))
+	})
+
+	t.Run("reverses only the allocation's payment when a voucher paid twice", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		voucherID := uuid.New()
+		allocate(t, ar, voucherID, 300.00)
+		second := allocate(t, ar, voucherID, 200.00)
+
+		record, err := ar.ReversePayment(second, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, second.ID, record.AllocationID)
+		assert.True(t, decimal.NewFromFloat(200.00).Equal(record.Amount))
+		assert.True(t, ar.PaymentRecords[0].IsActive())
+		assert.True(t, decimal.NewFromFloat(300.00).Equal(ar.PaidAmount))
+	})
+
+	t.Run("matches payments recorded without an allocation by amount", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		voucherID := uuid.New()
+		require.NoError(t, ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(300.00), voucherID, ""))
+		require.NoError(t, ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(200.00), voucherID, ""))
+		allocation := NewReceivableAllocation(voucherID, ar.ID, ar.ReceivableNumber, valueobject.NewMoneyCNYFromFloat(200.00), "")
+
+		record, err := ar.ReversePayment(allocation, "")
+		require.NoError(t, err)
+
+		assert.Same(t, &ar.PaymentRecords[1], record)
+		assert.True(t, ar.PaymentRecords[0].IsActive())
+	})
+
+	t.Run("returns receivable to pending when no payment remains", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		allocation := allocate(t, ar, uuid.New(), 1000.00)
+
+		_, err := ar.ReversePayment(allocation, "Duplicate receipt")
+		require.NoError(t, err)
+
+		assert.Equal(t, ReceivableStatusPending, ar.Status)
+		assert.True(t, ar.PaidAmount.IsZero())
+		assert.True(t, decimal.NewFromFloat(1000.00).Equal(ar.OutstandingAmount))
+	})
+
+	t.Run("fails when the allocation has no active payment", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		allocation := allocate(t, ar, uuid.New(), 300.00)
+		_, err := ar.ReversePayment(allocation, "")
+		require.NoError(t, err)
+		allocate(t, ar, uuid.New(), 100.00)
+
+		_, err = ar.ReversePayment(allocation, "")
+		require.Error(t, err)
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NOT_FOUND", domainErr.Code)
+	})
+
+	t.Run("fails for pending receivable", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		allocation := NewReceivableAllocation(uuid.New(), ar.ID, ar.ReceivableNumber, valueobject.NewMoneyCNYFromFloat(100.00), "")
+
+		_, err := ar.ReversePayment(allocation, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot reverse payment")
+	})
+}
+
 // ============================================
 // Reverse Tests
 // ============================================
@@ -725,6 +833,106 @@ func TestAccountReceivable_Cancel(t *testing.T) {
 	})
 }
 
+// ============================================
+// WriteOff Tests
+// ============================================
+
+func TestAccountReceivable_WriteOff(t *testing.T) {
+	t.Run("writes off pending receivable", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		approverID := uuid.New()
+
+		err := ar.WriteOff("Customer bankrupt", approverID)
+		require.NoError(t, err)
+
+		assert.Equal(t, ReceivableStatusWrittenOff, ar.Status)
+		assert.True(t, ar.IsWrittenOff())
+		assert.True(t, ar.OutstandingAmount.IsZero())
+		assert.True(t, ar.WrittenOffAmount.Equal(decimal.NewFromFloat(1000.00)))
+		assert.NotNil(t, ar.WrittenOffAt)
+		require.NotNil(t, ar.WrittenOffBy)
+		assert.Equal(t, approverID, *ar.WrittenOffBy)
+		assert.Equal(t, "Customer bankrupt", ar.WriteOffReason)
+	})
+
+	t.Run("writes off remaining balance of partial receivable", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(950.00), uuid.New(), "")
+
+		err := ar.WriteOff("Small balance uncollectable", uuid.New())
+		require.NoError(t, err)
+
+		assert.Equal(t, ReceivableStatusWrittenOff, ar.Status)
+		assert.True(t, ar.PaidAmount.Equal(decimal.NewFromFloat(950.00)))
+		assert.True(t, ar.WrittenOffAmount.Equal(decimal.NewFromFloat(50.00)))
+		assert.True(t, ar.OutstandingAmount.IsZero())
+		assert.Len(t, ar.PaymentRecords, 1)
+	})
+
+	t.Run("publishes ReceivableWrittenOff event", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(300.00), uuid.New(), "")
+		ar.ClearDomainEvents()
+		approverID := uuid.New()
+
+		ar.WriteOff("Uncollectable", approverID)
+
+		events := ar.GetDomainEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, "ReceivableWrittenOff", events[0].EventType())
+
+		event, ok := events[0].(*ReceivableWrittenOffEvent)
+		require.True(t, ok)
+		assert.Equal(t, ar.ID, event.ReceivableID)
+		assert.Equal(t, ReceivableStatusPartial, event.PreviousStatus)
+		assert.True(t, event.WrittenOffAmount.Equal(decimal.NewFromFloat(700.00)))
+		assert.Equal(t, approverID, event.ApprovedBy)
+		assert.Equal(t, "Uncollectable", event.WriteOffReason)
+	})
+
+	t.Run("fails without reason", func(t *testing.T) {
+		ar := createTestReceivable(t)
+
+		err := ar.WriteOff("", uuid.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Write-off reason is required")
+	})
+
+	t.Run("fails without approver", func(t *testing.T) {
+		ar := createTestReceivable(t)
+
+		err := ar.WriteOff("Uncollectable", uuid.Nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Write-off approver is required")
+	})
+
+	t.Run("fails when already paid", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(1000.00), uuid.New(), "")
+
+		err := ar.WriteOff("Uncollectable", uuid.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PAID status")
+	})
+
+	t.Run("fails when cancelled", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		ar.Cancel("Order voided")
+
+		err := ar.WriteOff("Uncollectable", uuid.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CANCELLED status")
+	})
+
+	t.Run("rejects payments after write-off", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		ar.WriteOff("Uncollectable", uuid.New())
+
+		err := ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(100.00), uuid.New(), "")
+		require.Error(t, err)
+	})
+}
+
 // ============================================
 // SetDueDate Tests
 // ============================================
@@ -1358,3 +1566,78 @@ func TestAccountReceivableReversedEvent_AuditTrail(t *testing.T) {
 		assert.True(t, voucherIDs[voucherID2])
 	})
 }
+
+// ============================================
+// Currency Tests
+// ============================================
+
+func TestAccountReceivable_Currency(t *testing.T) {
+	t.Run("takes currency from total amount", func(t *testing.T) {
+		totalAmount, err := valueobject.NewMoney(decimal.NewFromInt(1000), valueobject.USD)
+		require.NoError(t, err)
+
+		ar, err := NewAccountReceivable(
+			uuid.New(),
+			"AR-2024-001",
+			uuid.New(),
+			"Test Customer",
+			SourceTypeSalesOrder,
+			uuid.New(),
+			"SO-2024-001",
+			totalAmount,
+			nil,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, valueobject.USD, ar.GetCurrency())
+		assert.Equal(t, valueobject.USD, ar.GetOutstandingAmountMoney().Currency())
+
+		events := ar.GetDomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(*AccountReceivableCreatedEvent)
+		require.True(t, ok)
+		assert.Equal(t, valueobject.USD, event.Currency)
+	})
+
+	t.Run("treats empty currency as default currency", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		ar.Currency = ""
+
+		assert.Equal(t, valueobject.CNY, ar.GetCurrency())
+		assert.Equal(t, valueobject.CNY, ar.GetTotalAmountMoney().Currency())
+
+		err := ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(100.00), uuid.New(), "")
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects payment in a different currency", func(t *testing.T) {
+		totalAmount, err := valueobject.NewMoney(decimal.NewFromInt(1000), valueobject.USD)
+		require.NoError(t, err)
+		ar, err := NewAccountReceivable(
+			uuid.New(),
+			"AR-2024-001",
+			uuid.New(),
+			"Test Customer",
+			SourceTypeSalesOrder,
+			uuid.New(),
+			"SO-2024-001",
+			totalAmount,
+			nil,
+		)
+		require.NoError(t, err)
+
+		err = ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(500.00), uuid.New(), "")
+
+		var mismatchErr *CurrencyMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, valueobject.USD, mismatchErr.Expected)
+		assert.Equal(t, valueobject.CNY, mismatchErr.Actual)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "CURRENCY_MISMATCH", domainErr.Code)
+
+		assert.True(t, ar.PaidAmount.IsZero())
+		assert.Empty(t, ar.PaymentRecords)
+	})
+}
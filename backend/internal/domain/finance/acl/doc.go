@@ -7,7 +7,7 @@
 // In Domain-Driven Design, an Anti-Corruption Layer protects a bounded context from
 // being polluted by models and concepts from other contexts. This package provides
 // the ACL components that isolate the Finance context from the Partner context
-// (which owns the Customer aggregate).
+// (which owns the Customer and Supplier aggregates).
 //
 // # Why ACL?
 //
@@ -16,8 +16,10 @@
 //   - Receipt Vouchers: Record payments from customers
 //   - Credit Memos: Handle customer returns/refunds
 //
+// Likewise, Account Payables and Payment Vouchers need supplier information.
+//
 // Without ACL, the Finance domain would directly depend on the Partner domain's
-// Customer aggregate, creating tight coupling and making changes in Partner
+// Customer and Supplier aggregates, creating tight coupling and making changes in Partner
 // propagate to Finance.
 //
 // # Components
@@ -38,6 +40,10 @@
 // CustomerEventHandler: Interface for handling customer events from Partner context.
 // Maintains cache and optionally updates denormalized data in Finance aggregates.
 //
+// SupplierID, SupplierReference, SupplierQueryService, SupplierReferenceCache and
+// SupplierEventHandler: The same components for suppliers, used by Account Payables.
+// On SupplierUpdated the denormalized supplier name on existing payables is refreshed.
+//
 // # Event-Driven Updates
 //
 // The ACL subscribes to customer events from the Partner context:
@@ -47,6 +53,9 @@
 //	CustomerDeleted    -> Invalidate cache entry
 //	CustomerStatusChanged -> Update/invalidate based on status
 //
+// and to the matching supplier events (SupplierCreated, SupplierUpdated,
+// SupplierDeleted, SupplierStatusChanged).
+//
 // This event-driven approach ensures:
 //  1. Loose coupling: Finance doesn't query Partner synchronously
 //  2. Eventual consistency: Customer info is kept up-to-date
@@ -73,7 +82,6 @@
 // # Future Considerations
 //
 // The ACL can be extended to include:
-//   - SupplierReference: For Account Payables (similar pattern)
 //   - CustomerCreditInfo: Credit limit and status for credit checks
 //   - CustomerPricingTier: For Finance-specific pricing calculations
 package acl
@@ -0,0 +1,181 @@
+package acl
+
+import (
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// SupplierID is a value object representing a supplier identifier within the Finance context.
+// This provides type safety and isolates the Finance domain from the Partner domain's
+// internal representation of supplier identifiers.
+//
+// Unlike using uuid.UUID directly, SupplierID:
+// - Provides explicit semantic meaning in the Finance domain
+// - Prevents accidental mixing with other UUID-based IDs
+// - Allows for potential future representation changes without affecting the domain
+type SupplierID struct {
+	value uuid.UUID
+}
+
+// NewSupplierID creates a new SupplierID from a UUID.
+// Returns an error if the UUID is nil/empty.
+func NewSupplierID(id uuid.UUID) (SupplierID, error) {
+	if id == uuid.Nil {
+		return SupplierID{}, shared.NewDomainError("INVALID_SUPPLIER_ID", "Supplier ID cannot be empty")
+	}
+	return SupplierID{value: id}, nil
+}
+
+// MustNewSupplierID creates a new SupplierID, panicking if the ID is invalid.
+// Use only when the ID is guaranteed to be valid (e.g., from database).
+func MustNewSupplierID(id uuid.UUID) SupplierID {
+	cid, err := NewSupplierID(id)
+	if err != nil {
+		panic(err)
+	}
+	return cid
+}
+
+// ParseSupplierID parses a string into a SupplierID.
+// Returns an error if the string is not a valid UUID or is empty.
+func ParseSupplierID(s string) (SupplierID, error) {
+	if s == "" {
+		return SupplierID{}, shared.NewDomainError("INVALID_SUPPLIER_ID", "Supplier ID cannot be empty")
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return SupplierID{}, shared.NewDomainError("INVALID_SUPPLIER_ID", "Supplier ID is not a valid UUID")
+	}
+	return NewSupplierID(id)
+}
+
+// UUID returns the underlying UUID value.
+// This is used for persistence and integration with external systems.
+func (c SupplierID) UUID() uuid.UUID {
+	return c.value
+}
+
+// String returns the string representation of the SupplierID.
+func (c SupplierID) String() string {
+	return c.value.String()
+}
+
+// IsEmpty returns true if the SupplierID is empty (nil UUID).
+func (c SupplierID) IsEmpty() bool {
+	return c.value == uuid.Nil
+}
+
+// Equals checks if two SupplierIDs are equal.
+func (c SupplierID) Equals(other SupplierID) bool {
+	return c.value == other.value
+}
+
+// SupplierReference is a value object that holds denormalized supplier information
+// needed by the Finance context. This is the Finance context's local view of a supplier,
+// maintained through event-driven synchronization from the Partner context.
+//
+// This pattern follows DDD's recommendation for cross-bounded-context references:
+// - Store minimal necessary information (ID and name for display)
+// - Update through domain events from the source context
+// - Never directly query the source context from the domain layer
+type SupplierReference struct {
+	id   SupplierID
+	name string
+	code string // Supplier code for display (e.g., "SUP-001")
+}
+
+// NewSupplierReference creates a new SupplierReference.
+// Returns an error if the ID is empty or the name is empty.
+func NewSupplierReference(id SupplierID, name, code string) (SupplierReference, error) {
+	if id.IsEmpty() {
+		return SupplierReference{}, shared.NewDomainError("INVALID_SUPPLIER_ID", "Supplier ID cannot be empty")
+	}
+	if name == "" {
+		return SupplierReference{}, shared.NewDomainError("INVALID_SUPPLIER_NAME", "Supplier name cannot be empty")
+	}
+	// Code is optional but useful for display
+
+	return SupplierReference{
+		id:   id,
+		name: name,
+		code: code,
+	}, nil
+}
+
+// NewSupplierReferenceFromUUID creates a SupplierReference from raw UUID and name.
+// This is a convenience method for creating references from database records.
+func NewSupplierReferenceFromUUID(id uuid.UUID, name, code string) (SupplierReference, error) {
+	supplierID, err := NewSupplierID(id)
+	if err != nil {
+		return SupplierReference{}, err
+	}
+	return NewSupplierReference(supplierID, name, code)
+}
+
+// MustNewSupplierReference creates a SupplierReference, panicking if invalid.
+// Use only when inputs are guaranteed to be valid (e.g., from database).
+func MustNewSupplierReference(id uuid.UUID, name, code string) SupplierReference {
+	ref, err := NewSupplierReferenceFromUUID(id, name, code)
+	if err != nil {
+		panic(err)
+	}
+	return ref
+}
+
+// ID returns the SupplierID.
+func (r SupplierReference) ID() SupplierID {
+	return r.id
+}
+
+// UUID returns the underlying UUID of the supplier ID.
+// Convenience method to avoid r.ID().UUID() calls.
+func (r SupplierReference) UUID() uuid.UUID {
+	return r.id.UUID()
+}
+
+// Name returns the supplier name.
+func (r SupplierReference) Name() string {
+	return r.name
+}
+
+// Code returns the supplier code.
+func (r SupplierReference) Code() string {
+	return r.code
+}
+
+// DisplayName returns a formatted display name (code + name if code exists).
+func (r SupplierReference) DisplayName() string {
+	if r.code != "" {
+		return r.code + " - " + r.name
+	}
+	return r.name
+}
+
+// IsEmpty returns true if the reference is empty.
+func (r SupplierReference) IsEmpty() bool {
+	return r.id.IsEmpty()
+}
+
+// Equals checks if two SupplierReferences are equal (by ID).
+func (r SupplierReference) Equals(other SupplierReference) bool {
+	return r.id.Equals(other.id)
+}
+
+// WithUpdatedInfo returns a new SupplierReference with updated name and code.
+// This is used when processing SupplierUpdatedEvent.
+func (r SupplierReference) WithUpdatedInfo(name, code string) (SupplierReference, error) {
+	if name == "" {
+		return SupplierReference{}, shared.NewDomainError("INVALID_SUPPLIER_NAME", "Supplier name cannot be empty")
+	}
+	return SupplierReference{
+		id:   r.id,
+		name: name,
+		code: code,
+	}, nil
+}
+
+// EmptySupplierReference returns an empty SupplierReference.
+// Used as a zero value or for optional supplier references.
+func EmptySupplierReference() SupplierReference {
+	return SupplierReference{}
+}
@@ -0,0 +1,76 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// =============================================================================
+// SupplierID Tests
+// =============================================================================
+
+func TestNewSupplierID(t *testing.T) {
+	id := uuid.New()
+	supplierID, err := NewSupplierID(id)
+
+	require.NoError(t, err)
+	assert.Equal(t, id, supplierID.UUID())
+	assert.False(t, supplierID.IsEmpty())
+
+	_, err = NewSupplierID(uuid.Nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be empty")
+}
+
+func TestParseSupplierID(t *testing.T) {
+	id := uuid.New()
+	supplierID, err := ParseSupplierID(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, id, supplierID.UUID())
+
+	_, err = ParseSupplierID("not-a-uuid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a valid UUID")
+}
+
+// =============================================================================
+// SupplierReference Tests
+// =============================================================================
+
+func TestNewSupplierReferenceFromUUID(t *testing.T) {
+	id := uuid.New()
+	ref, err := NewSupplierReferenceFromUUID(id, "Acme Supplies", "SUP-001")
+
+	require.NoError(t, err)
+	assert.Equal(t, id, ref.UUID())
+	assert.Equal(t, "Acme Supplies", ref.Name())
+	assert.Equal(t, "SUP-001 - Acme Supplies", ref.DisplayName())
+
+	_, err = NewSupplierReferenceFromUUID(id, "", "SUP-001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Supplier name cannot be empty")
+
+	_, err = NewSupplierReferenceFromUUID(uuid.Nil, "Acme Supplies", "")
+	require.Error(t, err)
+}
+
+func TestSupplierReference_WithUpdatedInfo(t *testing.T) {
+	original := MustNewSupplierReference(uuid.New(), "Acme Supplies", "SUP-001")
+
+	updated, err := original.WithUpdatedInfo("Acme Industrial", "SUP-001")
+	require.NoError(t, err)
+
+	assert.True(t, updated.Equals(original))
+	assert.Equal(t, "Acme Industrial", updated.Name())
+	assert.Equal(t, "Acme Supplies", original.Name())
+
+	_, err = original.WithUpdatedInfo("", "SUP-001")
+	require.Error(t, err)
+}
+
+func TestEmptySupplierReference(t *testing.T) {
+	assert.True(t, EmptySupplierReference().IsEmpty())
+}
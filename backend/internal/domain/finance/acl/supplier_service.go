@@ -0,0 +1,138 @@
+package acl
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SupplierQueryService defines the interface for querying supplier information
+// from the Partner bounded context. This is the ACL's port for external queries.
+//
+// Implementations of this interface should:
+// - First check the local cache (SupplierReferenceCache)
+// - Fall back to the Partner context if not in cache
+// - Update the local cache with fetched data
+//
+// This interface is defined in the Finance domain but implemented in the
+// infrastructure layer, following the Dependency Inversion Principle.
+type SupplierQueryService interface {
+	// GetSupplierReference retrieves supplier information for use in the Finance context.
+	// It returns a SupplierReference value object that contains the minimal information
+	// needed by Finance domain objects.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeouts
+	//   - tenantID: The tenant ID for multi-tenancy isolation
+	//   - supplierID: The supplier's UUID
+	//
+	// Returns:
+	//   - SupplierReference: The supplier reference value object
+	//   - error: Returns error if supplier not found or on infrastructure failure
+	GetSupplierReference(ctx context.Context, tenantID, supplierID uuid.UUID) (SupplierReference, error)
+
+	// GetSupplierReferences retrieves multiple supplier references in batch.
+	// This is more efficient than multiple single queries.
+	//
+	// Returns a map of supplierID -> SupplierReference.
+	// Missing suppliers will not be in the returned map (no error).
+	GetSupplierReferences(ctx context.Context, tenantID uuid.UUID, supplierIDs []uuid.UUID) (map[uuid.UUID]SupplierReference, error)
+
+	// SupplierExists checks if a supplier exists without fetching full details.
+	// Useful for validation before creating financial records.
+	SupplierExists(ctx context.Context, tenantID, supplierID uuid.UUID) (bool, error)
+}
+
+// SupplierReferenceCache defines the interface for caching supplier references
+// within the Finance context. This cache is updated by event handlers listening
+// to Partner context events.
+//
+// The cache serves two purposes:
+// 1. Performance: Avoid repeated queries to Partner context
+// 2. Eventual consistency: Store snapshot of supplier data for Finance operations
+type SupplierReferenceCache interface {
+	// Get retrieves a supplier reference from cache.
+	// Returns (SupplierReference, true) if found, (empty, false) if not in cache.
+	Get(ctx context.Context, tenantID, supplierID uuid.UUID) (SupplierReference, bool)
+
+	// Set stores a supplier reference in cache.
+	Set(ctx context.Context, tenantID uuid.UUID, ref SupplierReference) error
+
+	// SetBatch stores multiple supplier references efficiently.
+	SetBatch(ctx context.Context, tenantID uuid.UUID, refs []SupplierReference) error
+
+	// Invalidate removes a supplier reference from cache.
+	// Called when a supplier is deleted or deactivated.
+	Invalidate(ctx context.Context, tenantID, supplierID uuid.UUID) error
+
+	// InvalidateAll clears all cached references for a tenant.
+	// Useful for cache maintenance or tenant data refresh.
+	InvalidateAll(ctx context.Context, tenantID uuid.UUID) error
+}
+
+// SupplierEventHandler defines the interface for handling supplier-related events
+// from the Partner context. This is the reactive part of the ACL that maintains
+// data consistency through event-driven updates.
+//
+// Implementations should:
+// - Update the local SupplierReferenceCache
+// - Optionally update denormalized supplier data in Finance aggregates
+type SupplierEventHandler interface {
+	// HandleSupplierCreated processes SupplierCreatedEvent from Partner context.
+	// Creates a new entry in the local cache.
+	HandleSupplierCreated(ctx context.Context, event SupplierCreatedEventDTO) error
+
+	// HandleSupplierUpdated processes SupplierUpdatedEvent from Partner context.
+	// Updates existing cache entry and refreshes the denormalized supplier name on payables.
+	HandleSupplierUpdated(ctx context.Context, event SupplierUpdatedEventDTO) error
+
+	// HandleSupplierDeleted processes SupplierDeletedEvent from Partner context.
+	// Invalidates cache entry. Note: Finance records referencing this supplier
+	// should remain intact for historical purposes.
+	HandleSupplierDeleted(ctx context.Context, event SupplierDeletedEventDTO) error
+
+	// HandleSupplierStatusChanged processes SupplierStatusChangedEvent.
+	// May invalidate cache if supplier becomes inactive/suspended.
+	HandleSupplierStatusChanged(ctx context.Context, event SupplierStatusChangedEventDTO) error
+}
+
+// Event DTOs for cross-context communication
+// These are local representations of Partner context events,
+// isolating the Finance context from Partner's event structure.
+
+// SupplierCreatedEventDTO represents the data from a SupplierCreatedEvent.
+type SupplierCreatedEventDTO struct {
+	TenantID   uuid.UUID
+	SupplierID uuid.UUID
+	Code       string
+	Name       string
+}
+
+// SupplierUpdatedEventDTO represents the data from a SupplierUpdatedEvent.
+type SupplierUpdatedEventDTO struct {
+	TenantID    uuid.UUID
+	SupplierID  uuid.UUID
+	Code        string
+	Name        string
+	ShortName   string
+	ContactName string
+	Phone       string
+	Email       string
+}
+
+// SupplierDeletedEventDTO represents the data from a SupplierDeletedEvent.
+type SupplierDeletedEventDTO struct {
+	TenantID   uuid.UUID
+	SupplierID uuid.UUID
+	Code       string
+	Name       string
+}
+
+// SupplierStatusChangedEventDTO represents the data from a SupplierStatusChangedEvent.
+type SupplierStatusChangedEventDTO struct {
+	TenantID   uuid.UUID
+	SupplierID uuid.UUID
+	Code       string
+	OldStatus  string
+	NewStatus  string
+}
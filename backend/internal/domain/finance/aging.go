@@ -0,0 +1,167 @@
+package finance
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/shopspring/decimal"
+)
+
+// Aging bucket keys that do not depend on the configured boundaries
+const (
+	AgingBucketCurrent = "current" // Not yet past due
+	AgingBucketUndated = "undated" // No due date set
+)
+
+// DefaultAgingBoundaries are the standard 1-30, 31-60, 61-90 and 90+ day buckets
+var DefaultAgingBoundaries = []int{30, 60, 90}
+
+// AgingBucketConfig defines the upper bounds (in days past due) of the overdue buckets.
+// Boundaries [30, 60, 90] produce the buckets 1-30, 31-60, 61-90 and 90+.
+type AgingBucketConfig struct {
+	Boundaries []int
+}
+
+// NewAgingBucketConfig creates an aging bucket configuration.
+// Boundaries must be positive and strictly ascending; empty uses the defaults.
+func NewAgingBucketConfig(boundaries []int) (AgingBucketConfig, error) {
+	if len(boundaries) == 0 {
+		return DefaultAgingBucketConfig(), nil
+	}
+	for i, b := range boundaries {
+		if b <= 0 {
+			return AgingBucketConfig{}, shared.NewDomainError("INVALID_INPUT", "Aging boundaries must be positive")
+		}
+		if i > 0 && b <= boundaries[i-1] {
+			return AgingBucketConfig{}, shared.NewDomainError("INVALID_INPUT", "Aging boundaries must be strictly ascending")
+		}
+	}
+	copied := make([]int, len(boundaries))
+	copy(copied, boundaries)
+	return AgingBucketConfig{Boundaries: copied}, nil
+}
+
+// DefaultAgingBucketConfig returns the standard aging bucket configuration
+func DefaultAgingBucketConfig() AgingBucketConfig {
+	config, _ := NewAgingBucketConfig(DefaultAgingBoundaries)
+	return config
+}
+
+// AgingEntry is the minimal data needed to age an outstanding document
+type AgingEntry struct {
+	DueDate           *time.Time
+	OutstandingAmount decimal.Decimal
+	Currency          valueobject.Currency // Currency of OutstandingAmount; empty for legacy records in CNY
+}
+
+// GroupAgingEntriesByCurrency splits entries by currency, since amounts in different
+// currencies cannot be added. Currencies are returned in alphabetical order.
+func GroupAgingEntriesByCurrency(entries []AgingEntry) ([]valueobject.Currency, map[valueobject.Currency][]AgingEntry) {
+	groups := make(map[valueobject.Currency][]AgingEntry)
+	for _, entry := range entries {
+		currency := normalizeCurrency(entry.Currency)
+		groups[currency] = append(groups[currency], entry)
+	}
+	return sortedCurrencies(groups), groups
+}
+
+// sortedCurrencies returns the currencies of a grouping in alphabetical order
+func sortedCurrencies[T any](groups map[valueobject.Currency]T) []valueobject.Currency {
+	currencies := make([]valueobject.Currency, 0, len(groups))
+	for currency := range groups {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+	return currencies
+}
+
+// CurrencyAging holds the aging buckets of the documents in one currency
+type CurrencyAging struct {
+	Currency valueobject.Currency
+	Buckets  []AgingBucket
+}
+
+// AgingBucket holds the totals for one aging range.
+// MaxDays is nil for the open-ended last bucket and for current/undated.
+type AgingBucket struct {
+	Key     string
+	MinDays int
+	MaxDays *int
+	Amount  decimal.Decimal
+	Count   int64
+}
+
+// Compute distributes the entries into aging buckets relative to now.
+// Buckets are returned in order: current, each overdue range, the open-ended range, undated.
+func (c AgingBucketConfig) Compute(now time.Time, entries []AgingEntry) []AgingBucket {
+	boundaries := c.Boundaries
+	if len(boundaries) == 0 {
+		boundaries = DefaultAgingBoundaries
+	}
+
+	buckets := make([]AgingBucket, 0, len(boundaries)+3)
+	buckets = append(buckets, AgingBucket{Key: AgingBucketCurrent, Amount: decimal.Zero})
+	lower := 1
+	for _, upper := range boundaries {
+		maxDays := upper
+		buckets = append(buckets, AgingBucket{
+			Key:     fmt.Sprintf("%d-%d", lower, upper),
+			MinDays: lower,
+			MaxDays: &maxDays,
+			Amount:  decimal.Zero,
+		})
+		lower = upper + 1
+	}
+	last := boundaries[len(boundaries)-1]
+	buckets = append(buckets, AgingBucket{
+		Key:     fmt.Sprintf("%d+", last),
+		MinDays: last + 1,
+		Amount:  decimal.Zero,
+	})
+	buckets = append(buckets, AgingBucket{Key: AgingBucketUndated, Amount: decimal.Zero})
+
+	undatedIdx := len(buckets) - 1
+	for _, entry := range entries {
+		idx := undatedIdx
+		if entry.DueDate != nil {
+			idx = bucketIndex(boundaries, DaysPastDue(now, *entry.DueDate))
+		}
+		buckets[idx].Amount = buckets[idx].Amount.Add(entry.OutstandingAmount)
+		buckets[idx].Count++
+	}
+
+	return buckets
+}
+
+// ComputeByCurrency distributes the entries into aging buckets per currency.
+// Currencies are returned in alphabetical order; no entries yields no currencies.
+func (c AgingBucketConfig) ComputeByCurrency(now time.Time, entries []AgingEntry) []CurrencyAging {
+	currencies, groups := GroupAgingEntriesByCurrency(entries)
+	agings := make([]CurrencyAging, len(currencies))
+	for i, currency := range currencies {
+		agings[i] = CurrencyAging{Currency: currency, Buckets: c.Compute(now, groups[currency])}
+	}
+	return agings
+}
+
+// bucketIndex returns the index of the bucket for the given days past due,
+// where index 0 is current and len(boundaries)+1 is the open-ended bucket
+func bucketIndex(boundaries []int, daysPastDue int) int {
+	if daysPastDue <= 0 {
+		return 0
+	}
+	for i, upper := range boundaries {
+		if daysPastDue <= upper {
+			return i + 1
+		}
+	}
+	return len(boundaries) + 1
+}
+
+// DaysPastDue returns the number of whole days between dueDate and now (0 or negative if not yet due)
+func DaysPastDue(now, dueDate time.Time) int {
+	return int(now.Sub(dueDate).Hours() / 24)
+}
@@ -0,0 +1,162 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func agingEntryDaysPastDue(now time.Time, days int, amount int64) AgingEntry {
+	dueDate := now.Add(-time.Duration(days) * 24 * time.Hour)
+	return AgingEntry{DueDate: &dueDate, OutstandingAmount: decimal.NewFromInt(amount)}
+}
+
+func findAgingBucket(t *testing.T, buckets []AgingBucket, key string) AgingBucket {
+	for _, b := range buckets {
+		if b.Key == key {
+			return b
+		}
+	}
+	require.Failf(t, "bucket not found", "no aging bucket with key %s", key)
+	return AgingBucket{}
+}
+
+func TestNewAgingBucketConfig(t *testing.T) {
+	t.Run("empty uses default boundaries", func(t *testing.T) {
+		config, err := NewAgingBucketConfig(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int{30, 60, 90}, config.Boundaries)
+	})
+
+	t.Run("accepts ascending boundaries", func(t *testing.T) {
+		config, err := NewAgingBucketConfig([]int{15, 45})
+		require.NoError(t, err)
+		assert.Equal(t, []int{15, 45}, config.Boundaries)
+	})
+
+	t.Run("rejects non-positive boundary", func(t *testing.T) {
+		_, err := NewAgingBucketConfig([]int{0, 30})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be positive")
+	})
+
+	t.Run("rejects non-ascending boundaries", func(t *testing.T) {
+		_, err := NewAgingBucketConfig([]int{30, 30, 60})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "strictly ascending")
+	})
+}
+
+func TestAgingBucketConfig_Compute(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	config := DefaultAgingBucketConfig()
+
+	t.Run("returns buckets in order", func(t *testing.T) {
+		buckets := config.Compute(now, nil)
+
+		keys := make([]string, len(buckets))
+		for i, b := range buckets {
+			keys[i] = b.Key
+			assert.True(t, b.Amount.IsZero())
+			assert.Zero(t, b.Count)
+		}
+		assert.Equal(t, []string{"current", "1-30", "31-60", "61-90", "90+", "undated"}, keys)
+	})
+
+	t.Run("exactly 30 days past due lands in 1-30", func(t *testing.T) {
+		buckets := config.Compute(now, []AgingEntry{agingEntryDaysPastDue(now, 30, 100)})
+
+		bucket := findAgingBucket(t, buckets, "1-30")
+		assert.Equal(t, int64(1), bucket.Count)
+		assert.True(t, bucket.Amount.Equal(decimal.NewFromInt(100)))
+		assert.Zero(t, findAgingBucket(t, buckets, "31-60").Count)
+	})
+
+	t.Run("exactly 31 days past due lands in 31-60", func(t *testing.T) {
+		buckets := config.Compute(now, []AgingEntry{agingEntryDaysPastDue(now, 31, 100)})
+
+		bucket := findAgingBucket(t, buckets, "31-60")
+		assert.Equal(t, int64(1), bucket.Count)
+		assert.True(t, bucket.Amount.Equal(decimal.NewFromInt(100)))
+		assert.Zero(t, findAgingBucket(t, buckets, "1-30").Count)
+	})
+
+	t.Run("not yet due lands in current", func(t *testing.T) {
+		dueToday := now
+		entries := []AgingEntry{
+			agingEntryDaysPastDue(now, -10, 100),
+			{DueDate: &dueToday, OutstandingAmount: decimal.NewFromInt(50)},
+		}
+
+		buckets := config.Compute(now, entries)
+
+		bucket := findAgingBucket(t, buckets, AgingBucketCurrent)
+		assert.Equal(t, int64(2), bucket.Count)
+		assert.True(t, bucket.Amount.Equal(decimal.NewFromInt(150)))
+	})
+
+	t.Run("no due date lands in undated rather than current", func(t *testing.T) {
+		buckets := config.Compute(now, []AgingEntry{{OutstandingAmount: decimal.NewFromInt(100)}})
+
+		assert.Equal(t, int64(1), findAgingBucket(t, buckets, AgingBucketUndated).Count)
+		assert.Zero(t, findAgingBucket(t, buckets, AgingBucketCurrent).Count)
+	})
+
+	t.Run("more than 90 days past due lands in open-ended bucket", func(t *testing.T) {
+		buckets := config.Compute(now, []AgingEntry{
+			agingEntryDaysPastDue(now, 90, 100),
+			agingEntryDaysPastDue(now, 91, 200),
+			agingEntryDaysPastDue(now, 365, 300),
+		})
+
+		assert.Equal(t, int64(1), findAgingBucket(t, buckets, "61-90").Count)
+		bucket := findAgingBucket(t, buckets, "90+")
+		assert.Equal(t, int64(2), bucket.Count)
+		assert.True(t, bucket.Amount.Equal(decimal.NewFromInt(500)))
+		assert.Equal(t, 91, bucket.MinDays)
+		assert.Nil(t, bucket.MaxDays)
+	})
+
+	t.Run("custom boundaries", func(t *testing.T) {
+		custom, err := NewAgingBucketConfig([]int{15, 45})
+		require.NoError(t, err)
+
+		buckets := custom.Compute(now, []AgingEntry{
+			agingEntryDaysPastDue(now, 15, 100),
+			agingEntryDaysPastDue(now, 16, 200),
+			agingEntryDaysPastDue(now, 46, 300),
+		})
+
+		require.Len(t, buckets, 5)
+		assert.Equal(t, int64(1), findAgingBucket(t, buckets, "1-15").Count)
+		assert.Equal(t, int64(1), findAgingBucket(t, buckets, "16-45").Count)
+		assert.Equal(t, int64(1), findAgingBucket(t, buckets, "45+").Count)
+	})
+}
+
+func TestAgingBucketConfig_ComputeByCurrency(t *testing.T) {
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+	config := DefaultAgingBucketConfig()
+
+	usd := agingEntryDaysPastDue(now, 10, 50)
+	usd.Currency = valueobject.USD
+	legacy := agingEntryDaysPastDue(now, 10, 300) // No currency: stored before currencies were recorded
+	cny := agingEntryDaysPastDue(now, 40, 100)
+	cny.Currency = valueobject.CNY
+
+	agings := config.ComputeByCurrency(now, []AgingEntry{usd, legacy, cny})
+
+	require.Len(t, agings, 2)
+	assert.Equal(t, valueobject.CNY, agings[0].Currency)
+	assert.True(t, findAgingBucket(t, agings[0].Buckets, "1-30").Amount.Equal(decimal.NewFromInt(300)))
+	assert.True(t, findAgingBucket(t, agings[0].Buckets, "31-60").Amount.Equal(decimal.NewFromInt(100)))
+	assert.Equal(t, valueobject.USD, agings[1].Currency)
+	assert.True(t, findAgingBucket(t, agings[1].Buckets, "1-30").Amount.Equal(decimal.NewFromInt(50)))
+	assert.Zero(t, findAgingBucket(t, agings[1].Buckets, "31-60").Count)
+
+	assert.Empty(t, config.ComputeByCurrency(now, nil))
+}
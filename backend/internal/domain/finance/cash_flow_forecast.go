@@ -0,0 +1,207 @@
+package finance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/shopspring/decimal"
+)
+
+// Cash flow forecast horizon limits, in weeks
+const (
+	DefaultCashFlowForecastWeeks = 12
+	MaxCashFlowForecastWeeks     = 52
+)
+
+// CashFlowForecastTotals holds expected inflows and outflows outside the weekly schedule
+type CashFlowForecastTotals struct {
+	Inflow       decimal.Decimal
+	Outflow      decimal.Decimal
+	InflowCount  int64
+	OutflowCount int64
+}
+
+// CashFlowForecastWeek holds the expected cash movement of one weekly period.
+// The period covers [StartDate, EndDate).
+type CashFlowForecastWeek struct {
+	Week           int // 1-based
+	StartDate      time.Time
+	EndDate        time.Time
+	Inflow         decimal.Decimal
+	Outflow        decimal.Decimal
+	InflowCount    int64
+	OutflowCount   int64
+	Net            decimal.Decimal
+	ClosingBalance decimal.Decimal // Projected balance at the end of the week
+}
+
+// CashFlowForecast is a projection of cash position from open receivables (inflows)
+// and open payables (outflows) scheduled by their due dates
+type CashFlowForecast struct {
+	Currency         valueobject.Currency // Currency of all amounts; empty when not forecast by currency
+	StartDate        time.Time
+	OpeningBalance   decimal.Decimal
+	Weeks            []CashFlowForecastWeek
+	TotalInflow      decimal.Decimal // Scheduled inflows across all weeks
+	TotalOutflow     decimal.Decimal // Scheduled outflows across all weeks
+	ProjectedBalance decimal.Decimal // Closing balance of the last week
+	// Overdue amounts are already past due at the start and are scheduled into the first week
+	Overdue CashFlowForecastTotals
+	// BeyondHorizon amounts are due after the last week and are not part of the projection
+	BeyondHorizon CashFlowForecastTotals
+	// Unscheduled amounts have no due date and are not part of the projection
+	Unscheduled CashFlowForecastTotals
+}
+
+// ForecastCashFlow schedules outstanding inflows and outflows into weekly periods starting
+// at the beginning of the start day, and computes a running balance from openingBalance.
+func ForecastCashFlow(start time.Time, weeks int, openingBalance decimal.Decimal, inflows, outflows []AgingEntry) (*CashFlowForecast, error) {
+	if err := validateForecastWeeks(weeks); err != nil {
+		return nil, err
+	}
+
+	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	forecast := &CashFlowForecast{
+		StartDate:      startDate,
+		OpeningBalance: openingBalance,
+		Weeks:          make([]CashFlowForecastWeek, weeks),
+		TotalInflow:    decimal.Zero,
+		TotalOutflow:   decimal.Zero,
+		Overdue:        newCashFlowForecastTotals(),
+		BeyondHorizon:  newCashFlowForecastTotals(),
+		Unscheduled:    newCashFlowForecastTotals(),
+	}
+	for i := range forecast.Weeks {
+		forecast.Weeks[i] = CashFlowForecastWeek{
+			Week:      i + 1,
+			StartDate: startDate.AddDate(0, 0, 7*i),
+			EndDate:   startDate.AddDate(0, 0, 7*(i+1)),
+			Inflow:    decimal.Zero,
+			Outflow:   decimal.Zero,
+		}
+	}
+
+	for _, entry := range inflows {
+		forecast.schedule(entry, true)
+	}
+	for _, entry := range outflows {
+		forecast.schedule(entry, false)
+	}
+
+	balance := openingBalance
+	for i := range forecast.Weeks {
+		week := &forecast.Weeks[i]
+		week.Net = week.Inflow.Sub(week.Outflow)
+		balance = balance.Add(week.Net)
+		week.ClosingBalance = balance
+		forecast.TotalInflow = forecast.TotalInflow.Add(week.Inflow)
+		forecast.TotalOutflow = forecast.TotalOutflow.Add(week.Outflow)
+	}
+	forecast.ProjectedBalance = balance
+
+	return forecast, nil
+}
+
+// ForecastCashFlowByCurrency forecasts the cash position separately for each currency,
+// since amounts in different currencies cannot be added. Every currency with an opening
+// balance or an entry is forecast, in alphabetical order.
+func ForecastCashFlowByCurrency(
+	start time.Time,
+	weeks int,
+	openingBalances map[valueobject.Currency]decimal.Decimal,
+	inflows, outflows []AgingEntry,
+) ([]*CashFlowForecast, error) {
+	if err := validateForecastWeeks(weeks); err != nil {
+		return nil, err
+	}
+
+	_, inflowGroups := GroupAgingEntriesByCurrency(inflows)
+	_, outflowGroups := GroupAgingEntriesByCurrency(outflows)
+
+	balances := make(map[valueobject.Currency]decimal.Decimal, len(openingBalances))
+	for currency, balance := range openingBalances {
+		balances[normalizeCurrency(currency)] = balance
+	}
+	for currency := range inflowGroups {
+		if _, ok := balances[currency]; !ok {
+			balances[currency] = decimal.Zero
+		}
+	}
+	for currency := range outflowGroups {
+		if _, ok := balances[currency]; !ok {
+			balances[currency] = decimal.Zero
+		}
+	}
+
+	currencies := sortedCurrencies(balances)
+	forecasts := make([]*CashFlowForecast, len(currencies))
+	for i, currency := range currencies {
+		forecast, err := ForecastCashFlow(start, weeks, balances[currency], inflowGroups[currency], outflowGroups[currency])
+		if err != nil {
+			return nil, err
+		}
+		forecast.Currency = currency
+		forecasts[i] = forecast
+	}
+	return forecasts, nil
+}
+
+// validateForecastWeeks checks the forecast horizon is within the supported range
+func validateForecastWeeks(weeks int) error {
+	if weeks < 1 || weeks > MaxCashFlowForecastWeeks {
+		return shared.NewDomainError("INVALID_INPUT",
+			fmt.Sprintf("Forecast weeks must be between 1 and %d", MaxCashFlowForecastWeeks))
+	}
+	return nil
+}
+
+// schedule adds an entry to the week containing its due date, or to the matching off-schedule totals
+func (f *CashFlowForecast) schedule(entry AgingEntry, inflow bool) {
+	if entry.DueDate == nil {
+		f.Unscheduled.add(entry.OutstandingAmount, inflow)
+		return
+	}
+
+	dueDate := *entry.DueDate
+	horizonEnd := f.Weeks[len(f.Weeks)-1].EndDate
+	var week *CashFlowForecastWeek
+	switch {
+	case dueDate.Before(f.StartDate):
+		f.Overdue.add(entry.OutstandingAmount, inflow)
+		week = &f.Weeks[0]
+	case !dueDate.Before(horizonEnd):
+		f.BeyondHorizon.add(entry.OutstandingAmount, inflow)
+		return
+	default:
+		for i := range f.Weeks {
+			if dueDate.Before(f.Weeks[i].EndDate) {
+				week = &f.Weeks[i]
+				break
+			}
+		}
+	}
+
+	if inflow {
+		week.Inflow = week.Inflow.Add(entry.OutstandingAmount)
+		week.InflowCount++
+	} else {
+		week.Outflow = week.Outflow.Add(entry.OutstandingAmount)
+		week.OutflowCount++
+	}
+}
+
+func newCashFlowForecastTotals() CashFlowForecastTotals {
+	return CashFlowForecastTotals{Inflow: decimal.Zero, Outflow: decimal.Zero}
+}
+
+func (t *CashFlowForecastTotals) add(amount decimal.Decimal, inflow bool) {
+	if inflow {
+		t.Inflow = t.Inflow.Add(amount)
+		t.InflowCount++
+	} else {
+		t.Outflow = t.Outflow.Add(amount)
+		t.OutflowCount++
+	}
+}
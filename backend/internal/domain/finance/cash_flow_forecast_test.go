@@ -0,0 +1,143 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func forecastEntry(dueDate time.Time, amount int64) AgingEntry {
+	return AgingEntry{DueDate: &dueDate, OutstandingAmount: decimal.NewFromInt(amount)}
+}
+
+func TestForecastCashFlow(t *testing.T) {
+	now := time.Date(2026, 3, 16, 15, 30, 0, 0, time.UTC)
+	startDate := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("builds weekly periods from the start of the day", func(t *testing.T) {
+		forecast, err := ForecastCashFlow(now, 3, decimal.Zero, nil, nil)
+
+		require.NoError(t, err)
+		require.Len(t, forecast.Weeks, 3)
+		assert.Equal(t, startDate, forecast.StartDate)
+		assert.Equal(t, startDate, forecast.Weeks[0].StartDate)
+		assert.Equal(t, startDate.AddDate(0, 0, 7), forecast.Weeks[0].EndDate)
+		assert.Equal(t, 3, forecast.Weeks[2].Week)
+		assert.Equal(t, startDate.AddDate(0, 0, 21), forecast.Weeks[2].EndDate)
+	})
+
+	t.Run("nets inflows and outflows with a running balance", func(t *testing.T) {
+		inflows := []AgingEntry{
+			forecastEntry(startDate.AddDate(0, 0, 2), 1000),
+			forecastEntry(startDate.AddDate(0, 0, 9), 500),
+		}
+		outflows := []AgingEntry{
+			forecastEntry(startDate.AddDate(0, 0, 6), 300),
+			forecastEntry(startDate.AddDate(0, 0, 7), 2000),
+		}
+
+		forecast, err := ForecastCashFlow(now, 2, decimal.NewFromInt(1000), inflows, outflows)
+
+		require.NoError(t, err)
+		week1, week2 := forecast.Weeks[0], forecast.Weeks[1]
+		assert.True(t, week1.Inflow.Equal(decimal.NewFromInt(1000)))
+		assert.True(t, week1.Outflow.Equal(decimal.NewFromInt(300)))
+		assert.True(t, week1.Net.Equal(decimal.NewFromInt(700)))
+		assert.True(t, week1.ClosingBalance.Equal(decimal.NewFromInt(1700)))
+		assert.True(t, week2.Inflow.Equal(decimal.NewFromInt(500)))
+		assert.True(t, week2.Outflow.Equal(decimal.NewFromInt(2000)))
+		assert.True(t, week2.ClosingBalance.Equal(decimal.NewFromInt(200)))
+		assert.True(t, forecast.ProjectedBalance.Equal(decimal.NewFromInt(200)))
+		assert.True(t, forecast.TotalInflow.Equal(decimal.NewFromInt(1500)))
+		assert.True(t, forecast.TotalOutflow.Equal(decimal.NewFromInt(2300)))
+	})
+
+	t.Run("reports undated entries as unscheduled", func(t *testing.T) {
+		inflows := []AgingEntry{{OutstandingAmount: decimal.NewFromInt(400)}}
+		outflows := []AgingEntry{{OutstandingAmount: decimal.NewFromInt(250)}}
+
+		forecast, err := ForecastCashFlow(now, 2, decimal.Zero, inflows, outflows)
+
+		require.NoError(t, err)
+		assert.True(t, forecast.Unscheduled.Inflow.Equal(decimal.NewFromInt(400)))
+		assert.True(t, forecast.Unscheduled.Outflow.Equal(decimal.NewFromInt(250)))
+		assert.Equal(t, int64(1), forecast.Unscheduled.InflowCount)
+		assert.True(t, forecast.Weeks[0].Inflow.IsZero())
+		assert.True(t, forecast.ProjectedBalance.IsZero())
+	})
+
+	t.Run("schedules overdue entries into the first week", func(t *testing.T) {
+		inflows := []AgingEntry{forecastEntry(startDate.AddDate(0, 0, -10), 800)}
+
+		forecast, err := ForecastCashFlow(now, 2, decimal.Zero, inflows, nil)
+
+		require.NoError(t, err)
+		assert.True(t, forecast.Weeks[0].Inflow.Equal(decimal.NewFromInt(800)))
+		assert.True(t, forecast.Overdue.Inflow.Equal(decimal.NewFromInt(800)))
+	})
+
+	t.Run("excludes entries due after the horizon", func(t *testing.T) {
+		outflows := []AgingEntry{forecastEntry(startDate.AddDate(0, 0, 14), 900)}
+
+		forecast, err := ForecastCashFlow(now, 2, decimal.Zero, nil, outflows)
+
+		require.NoError(t, err)
+		assert.True(t, forecast.TotalOutflow.IsZero())
+		assert.True(t, forecast.BeyondHorizon.Outflow.Equal(decimal.NewFromInt(900)))
+		assert.Equal(t, int64(1), forecast.BeyondHorizon.OutflowCount)
+	})
+
+	t.Run("rejects out of range weeks", func(t *testing.T) {
+		_, err := ForecastCashFlow(now, 0, decimal.Zero, nil, nil)
+		require.Error(t, err)
+
+		_, err = ForecastCashFlow(now, MaxCashFlowForecastWeeks+1, decimal.Zero, nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestForecastCashFlowByCurrency(t *testing.T) {
+	now := time.Date(2026, 3, 16, 15, 30, 0, 0, time.UTC)
+	startDate := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("forecasts each currency separately", func(t *testing.T) {
+		usdInflow := forecastEntry(startDate.AddDate(0, 0, 1), 100)
+		usdInflow.Currency = valueobject.USD
+		cnyInflow := forecastEntry(startDate.AddDate(0, 0, 2), 700) // Legacy record without a currency
+		cnyOutflow := forecastEntry(startDate.AddDate(0, 0, 3), 200)
+		cnyOutflow.Currency = valueobject.CNY
+
+		forecasts, err := ForecastCashFlowByCurrency(now, 2,
+			map[valueobject.Currency]decimal.Decimal{valueobject.CNY: decimal.NewFromInt(1000)},
+			[]AgingEntry{usdInflow, cnyInflow}, []AgingEntry{cnyOutflow})
+
+		require.NoError(t, err)
+		require.Len(t, forecasts, 2)
+		assert.Equal(t, valueobject.CNY, forecasts[0].Currency)
+		assert.True(t, forecasts[0].TotalInflow.Equal(decimal.NewFromInt(700)))
+		assert.True(t, forecasts[0].TotalOutflow.Equal(decimal.NewFromInt(200)))
+		assert.True(t, forecasts[0].ProjectedBalance.Equal(decimal.NewFromInt(1500)))
+		assert.Equal(t, valueobject.USD, forecasts[1].Currency)
+		assert.True(t, forecasts[1].OpeningBalance.IsZero())
+		assert.True(t, forecasts[1].ProjectedBalance.Equal(decimal.NewFromInt(100)))
+	})
+
+	t.Run("forecasts the opening balance currency without entries", func(t *testing.T) {
+		forecasts, err := ForecastCashFlowByCurrency(now, 2,
+			map[valueobject.Currency]decimal.Decimal{valueobject.USD: decimal.NewFromInt(300)}, nil, nil)
+
+		require.NoError(t, err)
+		require.Len(t, forecasts, 1)
+		assert.Equal(t, valueobject.USD, forecasts[0].Currency)
+		assert.True(t, forecasts[0].ProjectedBalance.Equal(decimal.NewFromInt(300)))
+	})
+
+	t.Run("rejects out of range weeks", func(t *testing.T) {
+		_, err := ForecastCashFlowByCurrency(now, 0, nil, nil, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,52 @@
+package finance
+
+import (
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/shopspring/decimal"
+)
+
+// CurrencyMismatchError is returned when an operation combines amounts
+// in different currencies, e.g. applying a USD payment to a CNY receivable.
+// It unwraps to a DomainError with code CURRENCY_MISMATCH so that callers
+// handling DomainError continue to work.
+type CurrencyMismatchError struct {
+	Expected valueobject.Currency
+	Actual   valueobject.Currency
+}
+
+// NewCurrencyMismatchError creates a new CurrencyMismatchError
+func NewCurrencyMismatchError(expected, actual valueobject.Currency) *CurrencyMismatchError {
+	return &CurrencyMismatchError{
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+// Error implements the error interface
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("Currency mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// Unwrap returns the equivalent DomainError
+func (e *CurrencyMismatchError) Unwrap() error {
+	return shared.NewDomainError("CURRENCY_MISMATCH", e.Error())
+}
+
+// normalizeCurrency returns the default currency for empty values.
+// Records created before multi-currency support have no currency set.
+func normalizeCurrency(currency valueobject.Currency) valueobject.Currency {
+	if currency == "" {
+		return valueobject.DefaultCurrency
+	}
+	return currency
+}
+
+// newMoney creates Money in the given currency, falling back to the default currency.
+// NewMoney only fails for an empty currency, which normalizeCurrency rules out.
+func newMoney(amount decimal.Decimal, currency valueobject.Currency) valueobject.Money {
+	m, _ := valueobject.NewMoney(amount, normalizeCurrency(currency))
+	return m
+}
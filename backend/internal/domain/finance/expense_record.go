@@ -1,6 +1,9 @@
 package finance
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -120,6 +123,11 @@ func (s ExpenseStatus) CanCancel() bool {
 	return s == ExpenseStatusDraft || s == ExpenseStatusPending
 }
 
+// CanModifyAttachments returns true if attachments can be added to or removed from the expense
+func (s ExpenseStatus) CanModifyAttachments() bool {
+	return s == ExpenseStatusDraft || s == ExpenseStatusPending
+}
+
 // PaymentStatus represents whether the expense has been paid
 type PaymentStatus string
 
@@ -128,6 +136,162 @@ const (
 	PaymentStatusPaid   PaymentStatus = "PAID"
 )
 
+// ExpenseApproval records one level of sign-off in an expense approval chain
+type ExpenseApproval struct {
+	Level      int       `json:"level"` // 1-based approval level
+	ApprovedBy uuid.UUID `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+	Remark     string    `json:"remark,omitempty"`
+}
+
+// ExpenseApprovals is a slice of ExpenseApproval that implements GORM Scanner/Valuer for JSONB storage
+type ExpenseApprovals []ExpenseApproval
+
+// Value implements driver.Valuer interface for GORM to write to JSONB
+func (a ExpenseApprovals) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner interface for GORM to read from JSONB
+func (a *ExpenseApprovals) Scan(value interface{}) error {
+	if value == nil {
+		*a = ExpenseApprovals{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan ExpenseApprovals: unsupported type")
+	}
+
+	if len(bytes) == 0 {
+		*a = ExpenseApprovals{}
+		return nil
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+// Expense attachment limits
+const (
+	MaxExpenseAttachments          = 20
+	MaxExpenseAttachmentSize       = 10 * 1024 * 1024 // 10MB
+	MaxExpenseAttachmentNameLength = 255
+)
+
+// allowedExpenseAttachmentContentTypes lists the supporting document formats accepted for expenses
+var allowedExpenseAttachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+}
+
+// IsAllowedExpenseAttachmentContentType returns true if the content type is accepted for expense attachments
+func IsAllowedExpenseAttachmentContentType(contentType string) bool {
+	return allowedExpenseAttachmentContentTypes[contentType]
+}
+
+// ExpenseAttachment is a supporting document (receipt, invoice) attached to an expense
+type ExpenseAttachment struct {
+	ID          uuid.UUID `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	FileSize    int64     `json:"file_size"`
+	StorageKey  string    `json:"storage_key"` // Path of the file in attachment storage
+	UploadedBy  uuid.UUID `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// NewExpenseAttachment creates an attachment for a file that is about to be stored.
+// The storage key is set once the file has been stored, see ExpenseRecord.AddAttachment.
+func NewExpenseAttachment(fileName, contentType string, fileSize int64, uploadedBy uuid.UUID) (*ExpenseAttachment, error) {
+	if fileName == "" {
+		return nil, shared.NewDomainError("INVALID_FILE_NAME", "File name cannot be empty")
+	}
+	if len(fileName) > MaxExpenseAttachmentNameLength {
+		return nil, shared.NewDomainError("INVALID_FILE_NAME", "File name cannot exceed 255 characters")
+	}
+	if !IsAllowedExpenseAttachmentContentType(contentType) {
+		return nil, shared.NewDomainError("INVALID_CONTENT_TYPE", fmt.Sprintf("Content type %s is not allowed for expense attachments", contentType))
+	}
+	if fileSize <= 0 {
+		return nil, shared.NewDomainError("INVALID_FILE_SIZE", "File cannot be empty")
+	}
+	if fileSize > MaxExpenseAttachmentSize {
+		return nil, shared.NewDomainError("INVALID_FILE_SIZE", "File cannot exceed 10MB")
+	}
+	if uploadedBy == uuid.Nil {
+		return nil, shared.NewDomainError("INVALID_USER", "Uploader user ID cannot be empty")
+	}
+
+	return &ExpenseAttachment{
+		ID:          uuid.New(),
+		FileName:    fileName,
+		ContentType: contentType,
+		FileSize:    fileSize,
+		UploadedBy:  uploadedBy,
+		UploadedAt:  time.Now(),
+	}, nil
+}
+
+// ExpenseAttachments is a slice of ExpenseAttachment that implements GORM Scanner/Valuer for JSONB storage
+type ExpenseAttachments []ExpenseAttachment
+
+// Value implements driver.Valuer interface for GORM to write to JSONB
+func (a ExpenseAttachments) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner interface for GORM to read from JSONB
+func (a *ExpenseAttachments) Scan(value interface{}) error {
+	if value == nil {
+		*a = ExpenseAttachments{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan ExpenseAttachments: unsupported type")
+	}
+
+	if len(bytes) == 0 {
+		*a = ExpenseAttachments{}
+		return nil
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+// RequiredExpenseApprovalLevels returns how many approvals an expense of the given amount needs.
+// Every expense needs one approval, plus one more for each threshold the amount exceeds.
+func RequiredExpenseApprovalLevels(amount decimal.Decimal, thresholds []decimal.Decimal) int {
+	levels := 1
+	for _, threshold := range thresholds {
+		if amount.GreaterThan(threshold) {
+			levels++
+		}
+	}
+	return levels
+}
+
 // ExpenseRecord represents an expense record aggregate root
 // It tracks non-trade expenses like rent, utilities, salary, etc.
 type ExpenseRecord struct {
@@ -154,6 +318,13 @@ type ExpenseRecord struct {
 	CancelledAt     *time.Time      `json:"cancelled_at"` // When cancelled
 	CancelledBy     *uuid.UUID      `json:"cancelled_by"` // User who cancelled
 	CancelReason    string          `json:"cancel_reason"`
+
+	// Approval chain: RequiredApprovals is computed on submission from the tenant's thresholds
+	RequiredApprovals int              `json:"required_approvals"`
+	Approvals         ExpenseApprovals `json:"approvals"` // Sign-offs collected so far, in level order
+
+	// Supporting documents; files live in attachment storage, only their metadata is kept here
+	Attachments ExpenseAttachments `json:"attachments"`
 }
 
 // NewExpenseRecord creates a new expense record
@@ -201,19 +372,26 @@ func NewExpenseRecord(
 	return expense, nil
 }
 
-// Submit submits the expense for approval
-func (e *ExpenseRecord) Submit(submittedBy uuid.UUID) error {
+// Submit submits the expense for approval.
+// requiredApprovals is the number of approval levels the expense must pass,
+// see RequiredExpenseApprovalLevels.
+func (e *ExpenseRecord) Submit(submittedBy uuid.UUID, requiredApprovals int) error {
 	if !e.Status.CanSubmit() {
 		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot submit expense in %s status", e.Status))
 	}
 	if submittedBy == uuid.Nil {
 		return shared.NewDomainError("INVALID_USER", "Submitter user ID cannot be empty")
 	}
+	if requiredApprovals < 1 {
+		return shared.NewDomainError("INVALID_APPROVAL_LEVELS", "At least one approval level is required")
+	}
 
 	now := time.Now()
 	e.Status = ExpenseStatusPending
 	e.SubmittedAt = &now
 	e.SubmittedBy = &submittedBy
+	e.RequiredApprovals = requiredApprovals
+	e.Approvals = ExpenseApprovals{}
 	e.UpdatedAt = now
 
 	e.AddDomainEvent(NewExpenseRecordSubmittedEvent(e))
@@ -221,7 +399,9 @@ func (e *ExpenseRecord) Submit(submittedBy uuid.UUID) error {
 	return nil
 }
 
-// Approve approves the expense
+// Approve signs off the current approval level.
+// The expense only becomes approved once all required levels have signed off,
+// and each level must be signed off by a different user.
 func (e *ExpenseRecord) Approve(approvedBy uuid.UUID, remark string) error {
 	if !e.Status.CanApprove() {
 		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot approve expense in %s status", e.Status))
@@ -229,8 +409,26 @@ func (e *ExpenseRecord) Approve(approvedBy uuid.UUID, remark string) error {
 	if approvedBy == uuid.Nil {
 		return shared.NewDomainError("INVALID_USER", "Approver user ID cannot be empty")
 	}
+	for _, approval := range e.Approvals {
+		if approval.ApprovedBy == approvedBy {
+			return shared.NewDomainError("DUPLICATE_APPROVER", "User has already approved this expense")
+		}
+	}
 
 	now := time.Now()
+	e.Approvals = append(e.Approvals, ExpenseApproval{
+		Level:      len(e.Approvals) + 1,
+		ApprovedBy: approvedBy,
+		ApprovedAt: now,
+		Remark:     remark,
+	})
+	e.UpdatedAt = now
+
+	if e.RemainingApprovals() > 0 {
+		e.AddDomainEvent(NewExpenseRecordApprovalLevelCompletedEvent(e))
+		return nil
+	}
+
 	e.Status = ExpenseStatusApproved
 	e.ApprovedAt = &now
 	e.ApprovedBy = &approvedBy
@@ -357,6 +555,47 @@ func (e *ExpenseRecord) SetAttachmentURLs(urls string) {
 	e.UpdatedAt = time.Now()
 }
 
+// AddAttachment attaches a stored supporting document to the expense.
+// Attachments can only be changed while the expense is draft or pending approval.
+func (e *ExpenseRecord) AddAttachment(attachment *ExpenseAttachment) error {
+	if !e.Status.CanModifyAttachments() {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot add attachments to expense in %s status", e.Status))
+	}
+	if attachment == nil {
+		return shared.NewDomainError("INVALID_ATTACHMENT", "Attachment cannot be empty")
+	}
+	if attachment.StorageKey == "" {
+		return shared.NewDomainError("INVALID_ATTACHMENT", "Attachment storage key cannot be empty")
+	}
+	if len(e.Attachments) >= MaxExpenseAttachments {
+		return shared.NewDomainError("TOO_MANY_ATTACHMENTS", fmt.Sprintf("Expense cannot have more than %d attachments", MaxExpenseAttachments))
+	}
+
+	e.Attachments = append(e.Attachments, *attachment)
+	e.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// RemoveAttachment removes an attachment from the expense and returns it,
+// so the caller can delete the stored file.
+// Attachments can only be changed while the expense is draft or pending approval.
+func (e *ExpenseRecord) RemoveAttachment(attachmentID uuid.UUID) (*ExpenseAttachment, error) {
+	if !e.Status.CanModifyAttachments() {
+		return nil, shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot remove attachments from expense in %s status", e.Status))
+	}
+
+	for i, attachment := range e.Attachments {
+		if attachment.ID == attachmentID {
+			e.Attachments = append(e.Attachments[:i:i], e.Attachments[i+1:]...)
+			e.UpdatedAt = time.Now()
+			return &attachment, nil
+		}
+	}
+
+	return nil, shared.NewDomainError("NOT_FOUND", "Attachment not found")
+}
+
 // Helper methods
 
 // GetAmountMoney returns amount as Money
@@ -364,6 +603,28 @@ func (e *ExpenseRecord) GetAmountMoney() valueobject.Money {
 	return valueobject.NewMoneyCNY(e.Amount)
 }
 
+// GetRequiredApprovals returns the number of approval levels the expense needs.
+// Expenses submitted before approval chains existed need a single approval.
+func (e *ExpenseRecord) GetRequiredApprovals() int {
+	if e.RequiredApprovals < 1 {
+		return 1
+	}
+	return e.RequiredApprovals
+}
+
+// CurrentApprovalLevel returns the number of approval levels signed off so far
+func (e *ExpenseRecord) CurrentApprovalLevel() int {
+	return len(e.Approvals)
+}
+
+// RemainingApprovals returns the number of approval levels still to be signed off
+func (e *ExpenseRecord) RemainingApprovals() int {
+	if e.Status == ExpenseStatusApproved {
+		return 0
+	}
+	return max(e.GetRequiredApprovals()-len(e.Approvals), 0)
+}
+
 // IsDraft returns true if expense is in draft status
 func (e *ExpenseRecord) IsDraft() bool {
 	return e.Status == ExpenseStatusDraft
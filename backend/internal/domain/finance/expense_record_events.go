@@ -113,6 +113,45 @@ func NewExpenseRecordApprovedEvent(expense *ExpenseRecord) *ExpenseRecordApprove
 	}
 }
 
+// ExpenseRecordApprovalLevelCompletedEvent is raised when an approval level is signed off
+// but further levels are still required before the expense is approved
+type ExpenseRecordApprovalLevelCompletedEvent struct {
+	shared.BaseDomainEvent
+	ExpenseID          uuid.UUID       `json:"expense_id"`
+	ExpenseNumber      string          `json:"expense_number"`
+	Amount             decimal.Decimal `json:"amount"`
+	Level              int             `json:"level"`
+	RequiredApprovals  int             `json:"required_approvals"`
+	RemainingApprovals int             `json:"remaining_approvals"`
+	ApprovedBy         uuid.UUID       `json:"approved_by"`
+	ApprovedAt         time.Time       `json:"approved_at"`
+}
+
+// EventType returns the event type name
+func (e *ExpenseRecordApprovalLevelCompletedEvent) EventType() string {
+	return "ExpenseRecordApprovalLevelCompleted"
+}
+
+// NewExpenseRecordApprovalLevelCompletedEvent creates a new ExpenseRecordApprovalLevelCompletedEvent
+// for the most recent approval of the expense
+func NewExpenseRecordApprovalLevelCompletedEvent(expense *ExpenseRecord) *ExpenseRecordApprovalLevelCompletedEvent {
+	event := &ExpenseRecordApprovalLevelCompletedEvent{
+		BaseDomainEvent:    shared.NewBaseDomainEvent("ExpenseRecordApprovalLevelCompleted", "ExpenseRecord", expense.ID, expense.TenantID),
+		ExpenseID:          expense.ID,
+		ExpenseNumber:      expense.ExpenseNumber,
+		Amount:             expense.Amount,
+		RequiredApprovals:  expense.GetRequiredApprovals(),
+		RemainingApprovals: expense.RemainingApprovals(),
+	}
+	if n := len(expense.Approvals); n > 0 {
+		last := expense.Approvals[n-1]
+		event.Level = last.Level
+		event.ApprovedBy = last.ApprovedBy
+		event.ApprovedAt = last.ApprovedAt
+	}
+	return event
+}
+
 // ExpenseRecordRejectedEvent is raised when an expense is rejected
 type ExpenseRecordRejectedEvent struct {
 	shared.BaseDomainEvent
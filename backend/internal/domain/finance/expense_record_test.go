@@ -0,0 +1,234 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExpenseRecord(t *testing.T, amount int64) *ExpenseRecord {
+	expense, err := NewExpenseRecord(
+		uuid.New(),
+		"EXP-001",
+		ExpenseCategoryOffice,
+		valueobject.NewMoneyCNY(decimal.NewFromInt(amount)),
+		"Office supplies",
+		time.Now(),
+	)
+	require.NoError(t, err)
+	return expense
+}
+
+func TestRequiredExpenseApprovalLevels(t *testing.T) {
+	thresholds := []decimal.Decimal{decimal.NewFromInt(5000), decimal.NewFromInt(50000)}
+
+	tests := []struct {
+		name   string
+		amount decimal.Decimal
+		want   int
+	}{
+		{"below first threshold", decimal.NewFromInt(4999), 1},
+		{"at first threshold", decimal.NewFromInt(5000), 1},
+		{"above first threshold", decimal.NewFromFloat(5000.01), 2},
+		{"above all thresholds", decimal.NewFromInt(60000), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RequiredExpenseApprovalLevels(tt.amount, thresholds))
+		})
+	}
+
+	t.Run("no thresholds", func(t *testing.T) {
+		assert.Equal(t, 1, RequiredExpenseApprovalLevels(decimal.NewFromInt(1000000), nil))
+	})
+}
+
+func TestExpenseRecord_ApprovalChain(t *testing.T) {
+	submitter := uuid.New()
+	firstApprover := uuid.New()
+	secondApprover := uuid.New()
+
+	t.Run("single level is approved by one approval", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 1000)
+		require.NoError(t, expense.Submit(submitter, 1))
+		assert.Equal(t, 1, expense.RemainingApprovals())
+
+		require.NoError(t, expense.Approve(firstApprover, "ok"))
+
+		assert.True(t, expense.IsApproved())
+		assert.Equal(t, 1, expense.CurrentApprovalLevel())
+		assert.Equal(t, 0, expense.RemainingApprovals())
+		assert.Equal(t, &firstApprover, expense.ApprovedBy)
+	})
+
+	t.Run("two levels stay pending until the second approval", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 10000)
+		require.NoError(t, expense.Submit(submitter, 2))
+		expense.ClearDomainEvents()
+
+		require.NoError(t, expense.Approve(firstApprover, "level one"))
+
+		assert.True(t, expense.IsPending())
+		assert.Nil(t, expense.ApprovedBy)
+		assert.Equal(t, 1, expense.CurrentApprovalLevel())
+		assert.Equal(t, 1, expense.RemainingApprovals())
+		events := expense.GetDomainEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, "ExpenseRecordApprovalLevelCompleted", events[0].EventType())
+
+		require.NoError(t, expense.Approve(secondApprover, "level two"))
+
+		assert.True(t, expense.IsApproved())
+		assert.Equal(t, &secondApprover, expense.ApprovedBy)
+		assert.Equal(t, 2, expense.CurrentApprovalLevel())
+		assert.Equal(t, 0, expense.RemainingApprovals())
+		require.Len(t, expense.Approvals, 2)
+		assert.Equal(t, 1, expense.Approvals[0].Level)
+		assert.Equal(t, firstApprover, expense.Approvals[0].ApprovedBy)
+		assert.Equal(t, 2, expense.Approvals[1].Level)
+	})
+
+	t.Run("same user cannot approve two levels", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 10000)
+		require.NoError(t, expense.Submit(submitter, 2))
+		require.NoError(t, expense.Approve(firstApprover, ""))
+
+		err := expense.Approve(firstApprover, "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already approved")
+		assert.True(t, expense.IsPending())
+	})
+
+	t.Run("submit requires at least one level", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 1000)
+
+		err := expense.Submit(submitter, 0)
+
+		require.Error(t, err)
+		assert.True(t, expense.IsDraft())
+	})
+}
+
+func newTestExpenseAttachment(t *testing.T) *ExpenseAttachment {
+	attachment, err := NewExpenseAttachment("invoice.pdf", "application/pdf", 1024, uuid.New())
+	require.NoError(t, err)
+	attachment.StorageKey = "tenant/2026/01/" + attachment.ID.String() + ".pdf"
+	return attachment
+}
+
+func TestNewExpenseAttachment(t *testing.T) {
+	uploader := uuid.New()
+
+	tests := []struct {
+		name        string
+		fileName    string
+		contentType string
+		size        int64
+		uploadedBy  uuid.UUID
+		wantErr     bool
+	}{
+		{"valid pdf", "invoice.pdf", "application/pdf", 1024, uploader, false},
+		{"valid image", "receipt.jpg", "image/jpeg", MaxExpenseAttachmentSize, uploader, false},
+		{"empty file name", "", "application/pdf", 1024, uploader, true},
+		{"disallowed content type", "macro.xlsm", "application/vnd.ms-excel", 1024, uploader, true},
+		{"empty file", "invoice.pdf", "application/pdf", 0, uploader, true},
+		{"file too large", "invoice.pdf", "application/pdf", MaxExpenseAttachmentSize + 1, uploader, true},
+		{"missing uploader", "invoice.pdf", "application/pdf", 1024, uuid.Nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment, err := NewExpenseAttachment(tt.fileName, tt.contentType, tt.size, tt.uploadedBy)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, attachment.ID)
+			assert.Equal(t, tt.uploadedBy, attachment.UploadedBy)
+		})
+	}
+}
+
+func TestExpenseRecord_Attachments(t *testing.T) {
+	t.Run("add and remove while draft", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 100)
+		attachment := newTestExpenseAttachment(t)
+
+		require.NoError(t, expense.AddAttachment(attachment))
+		require.Len(t, expense.Attachments, 1)
+
+		removed, err := expense.RemoveAttachment(attachment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, attachment.StorageKey, removed.StorageKey)
+		assert.Empty(t, expense.Attachments)
+	})
+
+	t.Run("add while pending approval", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 100)
+		require.NoError(t, expense.Submit(uuid.New(), 1))
+
+		assert.NoError(t, expense.AddAttachment(newTestExpenseAttachment(t)))
+	})
+
+	t.Run("rejects attachment without storage key", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 100)
+		attachment := newTestExpenseAttachment(t)
+		attachment.StorageKey = ""
+
+		assert.Error(t, expense.AddAttachment(attachment))
+	})
+
+	t.Run("rejects more than the maximum attachments", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 100)
+		for range MaxExpenseAttachments {
+			require.NoError(t, expense.AddAttachment(newTestExpenseAttachment(t)))
+		}
+
+		assert.Error(t, expense.AddAttachment(newTestExpenseAttachment(t)))
+	})
+
+	t.Run("rejects changes once approved", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 100)
+		attachment := newTestExpenseAttachment(t)
+		require.NoError(t, expense.AddAttachment(attachment))
+		require.NoError(t, expense.Submit(uuid.New(), 1))
+		require.NoError(t, expense.Approve(uuid.New(), ""))
+
+		_, err := expense.RemoveAttachment(attachment.ID)
+		assert.Error(t, err)
+		assert.Len(t, expense.Attachments, 1)
+
+		assert.Error(t, expense.AddAttachment(newTestExpenseAttachment(t)))
+	})
+
+	t.Run("remove unknown attachment", func(t *testing.T) {
+		expense := newTestExpenseRecord(t, 100)
+
+		_, err := expense.RemoveAttachment(uuid.New())
+		assert.Error(t, err)
+	})
+}
+
+func TestExpenseAttachments_ValueAndScan(t *testing.T) {
+	attachments := ExpenseAttachments{*newTestExpenseAttachment(t)}
+
+	value, err := attachments.Value()
+	require.NoError(t, err)
+
+	var scanned ExpenseAttachments
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 1)
+	assert.Equal(t, attachments[0].ID, scanned[0].ID)
+	assert.Equal(t, attachments[0].StorageKey, scanned[0].StorageKey)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Empty(t, scanned)
+}
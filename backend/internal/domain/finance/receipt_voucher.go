@@ -257,6 +257,40 @@ func (rv *ReceiptVoucher) AllocateToReceivable(
 	return allocation, nil
 }
 
+// RemoveAllocation removes an allocation and returns its amount to the unallocated pool.
+// A fully allocated voucher goes back to confirmed so the amount can be allocated again.
+// Returns the removed allocation record
+func (rv *ReceiptVoucher) RemoveAllocation(allocationID uuid.UUID) (*ReceivableAllocation, error) {
+	if rv.Status != VoucherStatusConfirmed && rv.Status != VoucherStatusAllocated {
+		return nil, shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot remove allocation from voucher in %s status", rv.Status))
+	}
+
+	index := -1
+	for i := range rv.Allocations {
+		if rv.Allocations[i].ID == allocationID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, shared.NewDomainError("NOT_FOUND", "Allocation not found")
+	}
+
+	removed := rv.Allocations[index]
+	rv.Allocations = append(rv.Allocations[:index], rv.Allocations[index+1:]...)
+
+	// Update amounts
+	rv.AllocatedAmount = rv.AllocatedAmount.Sub(removed.Amount)
+	rv.UnallocatedAmount = rv.Amount.Sub(rv.AllocatedAmount)
+	rv.Status = VoucherStatusConfirmed
+
+	rv.UpdatedAt = time.Now()
+
+	rv.AddDomainEvent(NewReceiptVoucherUnallocatedEvent(rv, &removed))
+
+	return &removed, nil
+}
+
 // Cancel cancels the receipt voucher
 // Only drafts and confirmed vouchers without allocations can be cancelled
 func (rv *ReceiptVoucher) Cancel(cancelledBy uuid.UUID, reason string) error {
@@ -111,6 +111,41 @@ func NewReceiptVoucherAllocatedEvent(rv *ReceiptVoucher, allocation *ReceivableA
 	}
 }
 
+// ReceiptVoucherUnallocatedEvent is raised when an allocation is removed from a receipt voucher
+type ReceiptVoucherUnallocatedEvent struct {
+	shared.BaseDomainEvent
+	VoucherID        uuid.UUID       `json:"voucher_id"`
+	VoucherNumber    string          `json:"voucher_number"`
+	CustomerID       uuid.UUID       `json:"customer_id"`
+	AllocationID     uuid.UUID       `json:"allocation_id"`
+	ReceivableID     uuid.UUID       `json:"receivable_id"`
+	ReceivableNumber string          `json:"receivable_number"`
+	AllocationAmount decimal.Decimal `json:"allocation_amount"`
+	TotalAllocated   decimal.Decimal `json:"total_allocated"`
+	RemainingAmount  decimal.Decimal `json:"remaining_amount"`
+}
+
+// EventType returns the event type name
+func (e *ReceiptVoucherUnallocatedEvent) EventType() string {
+	return "ReceiptVoucherUnallocated"
+}
+
+// NewReceiptVoucherUnallocatedEvent creates a new ReceiptVoucherUnallocatedEvent
+func NewReceiptVoucherUnallocatedEvent(rv *ReceiptVoucher, allocation *ReceivableAllocation) *ReceiptVoucherUnallocatedEvent {
+	return &ReceiptVoucherUnallocatedEvent{
+		BaseDomainEvent:  shared.NewBaseDomainEvent("ReceiptVoucherUnallocated", "ReceiptVoucher", rv.ID, rv.TenantID),
+		VoucherID:        rv.ID,
+		VoucherNumber:    rv.VoucherNumber,
+		CustomerID:       rv.CustomerID,
+		AllocationID:     allocation.ID,
+		ReceivableID:     allocation.ReceivableID,
+		ReceivableNumber: allocation.ReceivableNumber,
+		AllocationAmount: allocation.Amount,
+		TotalAllocated:   rv.AllocatedAmount,
+		RemainingAmount:  rv.UnallocatedAmount,
+	}
+}
+
 // ReceiptVoucherCancelledEvent is raised when a receipt voucher is cancelled
 type ReceiptVoucherCancelledEvent struct {
 	shared.BaseDomainEvent
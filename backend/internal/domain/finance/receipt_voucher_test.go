@@ -427,6 +427,68 @@ func TestReceiptVoucher_AllocateToReceivable(t *testing.T) {
 	})
 }
 
+// ============================================
+// RemoveAllocation Tests
+// ============================================
+
+func TestReceiptVoucher_RemoveAllocation(t *testing.T) {
+	t.Run("returns amount to the unallocated pool", func(t *testing.T) {
+		rv := createConfirmedReceiptVoucher(t) // 1000.00
+		first, err := rv.AllocateToReceivable(uuid.New(), "AR-001", valueobject.NewMoneyCNYFromFloat(400.00), "")
+		require.NoError(t, err)
+		_, err = rv.AllocateToReceivable(uuid.New(), "AR-002", valueobject.NewMoneyCNYFromFloat(600.00), "")
+		require.NoError(t, err)
+		require.Equal(t, VoucherStatusAllocated, rv.Status)
+		rv.ClearDomainEvents()
+
+		removed, err := rv.RemoveAllocation(first.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.ID, removed.ID)
+		assert.Equal(t, 1, rv.AllocationCount())
+		assert.Equal(t, "AR-002", rv.Allocations[0].ReceivableNumber)
+		assert.True(t, decimal.NewFromFloat(600.00).Equal(rv.AllocatedAmount))
+		assert.True(t, decimal.NewFromFloat(400.00).Equal(rv.UnallocatedAmount))
+		assert.Equal(t, VoucherStatusConfirmed, rv.Status)
+
+		events := rv.GetDomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(*ReceiptVoucherUnallocatedEvent)
+		require.True(t, ok)
+		assert.Equal(t, first.ReceivableID, event.ReceivableID)
+		assert.True(t, decimal.NewFromFloat(400.00).Equal(event.AllocationAmount))
+	})
+
+	t.Run("allows reallocating to the same receivable", func(t *testing.T) {
+		rv := createConfirmedReceiptVoucher(t)
+		receivableID := uuid.New()
+		allocation, err := rv.AllocateToReceivable(receivableID, "AR-001", valueobject.NewMoneyCNYFromFloat(300.00), "")
+		require.NoError(t, err)
+
+		_, err = rv.RemoveAllocation(allocation.ID)
+		require.NoError(t, err)
+
+		_, err = rv.AllocateToReceivable(receivableID, "AR-001", valueobject.NewMoneyCNYFromFloat(300.00), "")
+		require.NoError(t, err)
+	})
+
+	t.Run("fails for unknown allocation", func(t *testing.T) {
+		rv := createConfirmedReceiptVoucher(t)
+
+		_, err := rv.RemoveAllocation(uuid.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Allocation not found")
+	})
+
+	t.Run("fails for draft voucher", func(t *testing.T) {
+		rv := createTestReceiptVoucher(t)
+
+		_, err := rv.RemoveAllocation(uuid.New())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot remove allocation")
+	})
+}
+
 // ============================================
 // Cancel Tests
 // ============================================
@@ -0,0 +1,65 @@
+package finance
+
+import "time"
+
+// ReceivableReminderStage records the last due-date reminder sent for a receivable.
+// Stages only move forward, so each reminder is sent at most once per due date.
+type ReceivableReminderStage string
+
+const (
+	ReceivableReminderStageNone    ReceivableReminderStage = ""         // No reminder sent yet
+	ReceivableReminderStageDueSoon ReceivableReminderStage = "DUE_SOON" // Due date is approaching
+	ReceivableReminderStageOverdue ReceivableReminderStage = "OVERDUE"  // Due date has passed
+)
+
+// NextReminderStage returns the reminder that should be sent for the receivable at the given time,
+// or ReceivableReminderStageNone if no reminder is due.
+// A receivable whose due date falls within leadTime gets a due-soon reminder,
+// one whose due date has passed gets an overdue reminder.
+func (ar *AccountReceivable) NextReminderStage(now time.Time, leadTime time.Duration) ReceivableReminderStage {
+	if ar.Status != ReceivableStatusPending && ar.Status != ReceivableStatusPartial {
+		return ReceivableReminderStageNone
+	}
+	if ar.DueDate == nil {
+		return ReceivableReminderStageNone
+	}
+
+	if now.After(*ar.DueDate) {
+		if ar.ReminderStage != ReceivableReminderStageOverdue {
+			return ReceivableReminderStageOverdue
+		}
+		return ReceivableReminderStageNone
+	}
+	if ar.ReminderStage == ReceivableReminderStageNone && !ar.DueDate.After(now.Add(leadTime)) {
+		return ReceivableReminderStageDueSoon
+	}
+	return ReceivableReminderStageNone
+}
+
+// RecordDueReminder raises the reminder event due at the given time and records it as sent.
+// Returns the stage of the reminder, or ReceivableReminderStageNone if no reminder was due.
+func (ar *AccountReceivable) RecordDueReminder(now time.Time, leadTime time.Duration) ReceivableReminderStage {
+	stage := ar.NextReminderStage(now, leadTime)
+	switch stage {
+	case ReceivableReminderStageDueSoon:
+		ar.AddDomainEvent(NewReceivableDueSoonEvent(ar, now))
+	case ReceivableReminderStageOverdue:
+		ar.AddDomainEvent(NewReceivableOverdueEvent(ar, now))
+	default:
+		return ReceivableReminderStageNone
+	}
+
+	ar.ReminderStage = stage
+	ar.LastReminderSentAt = &now
+	ar.UpdatedAt = now
+
+	return stage
+}
+
+// sameDueDate returns true if both due dates are unset or equal
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
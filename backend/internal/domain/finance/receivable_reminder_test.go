@@ -0,0 +1,110 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReminderLeadTime = 3 * 24 * time.Hour
+
+func TestAccountReceivable_NextReminderStage(t *testing.T) {
+	now := time.Now()
+
+	t.Run("no reminder when due date is beyond lead time", func(t *testing.T) {
+		ar := createTestReceivableWithDueDate(t, 10)
+		assert.Equal(t, ReceivableReminderStageNone, ar.NextReminderStage(now, testReminderLeadTime))
+	})
+
+	t.Run("due soon within lead time", func(t *testing.T) {
+		ar := createTestReceivableWithDueDate(t, 2)
+		assert.Equal(t, ReceivableReminderStageDueSoon, ar.NextReminderStage(now, testReminderLeadTime))
+	})
+
+	t.Run("overdue after due date", func(t *testing.T) {
+		ar := createTestReceivableWithDueDate(t, -1)
+		assert.Equal(t, ReceivableReminderStageOverdue, ar.NextReminderStage(now, testReminderLeadTime))
+	})
+
+	t.Run("overdue even if due-soon reminder was sent", func(t *testing.T) {
+		ar := createTestReceivableWithDueDate(t, -1)
+		ar.ReminderStage = ReceivableReminderStageDueSoon
+		assert.Equal(t, ReceivableReminderStageOverdue, ar.NextReminderStage(now, testReminderLeadTime))
+	})
+
+	t.Run("no reminder without due date", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		ar.DueDate = nil
+		assert.Equal(t, ReceivableReminderStageNone, ar.NextReminderStage(now, testReminderLeadTime))
+	})
+
+	t.Run("no reminder for paid receivable", func(t *testing.T) {
+		ar := createTestReceivableWithDueDate(t, -1)
+		require.NoError(t, ar.ApplyPayment(valueobject.NewMoneyCNYFromFloat(1000.00), uuid.New(), ""))
+		assert.Equal(t, ReceivableReminderStageNone, ar.NextReminderStage(now, testReminderLeadTime))
+	})
+}
+
+func TestAccountReceivable_RecordDueReminder(t *testing.T) {
+	now := time.Now()
+
+	t.Run("records due-soon reminder once", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		dueDate := now.Add(36 * time.Hour)
+		ar.DueDate = &dueDate
+		ar.ClearDomainEvents()
+
+		stage := ar.RecordDueReminder(now, testReminderLeadTime)
+
+		assert.Equal(t, ReceivableReminderStageDueSoon, stage)
+		assert.Equal(t, ReceivableReminderStageDueSoon, ar.ReminderStage)
+		require.NotNil(t, ar.LastReminderSentAt)
+		assert.Equal(t, now, *ar.LastReminderSentAt)
+
+		events := ar.GetDomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(*ReceivableDueSoonEvent)
+		require.True(t, ok)
+		assert.Equal(t, ar.ID, event.ReceivableID)
+		assert.Equal(t, 1, event.DaysUntilDue)
+
+		assert.Equal(t, ReceivableReminderStageNone, ar.RecordDueReminder(now, testReminderLeadTime))
+		assert.Len(t, ar.GetDomainEvents(), 1)
+	})
+
+	t.Run("records overdue reminder once", func(t *testing.T) {
+		ar := createTestReceivable(t)
+		dueDate := now.Add(-5*24*time.Hour - time.Hour)
+		ar.DueDate = &dueDate
+		ar.ClearDomainEvents()
+
+		stage := ar.RecordDueReminder(now, testReminderLeadTime)
+
+		assert.Equal(t, ReceivableReminderStageOverdue, stage)
+		events := ar.GetDomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(*ReceivableOverdueEvent)
+		require.True(t, ok)
+		assert.Equal(t, "ReceivableOverdue", event.EventType())
+		assert.Equal(t, 5, event.DaysOverdue)
+
+		assert.Equal(t, ReceivableReminderStageNone, ar.RecordDueReminder(now.Add(24*time.Hour), testReminderLeadTime))
+	})
+
+	t.Run("changing the due date restarts reminders", func(t *testing.T) {
+		ar := createTestReceivableWithDueDate(t, -1)
+		ar.RecordDueReminder(now, testReminderLeadTime)
+		require.Equal(t, ReceivableReminderStageOverdue, ar.ReminderStage)
+
+		newDueDate := now.AddDate(0, 0, 2)
+		require.NoError(t, ar.SetDueDate(&newDueDate))
+
+		assert.Equal(t, ReceivableReminderStageNone, ar.ReminderStage)
+		assert.Nil(t, ar.LastReminderSentAt)
+		assert.Equal(t, ReceivableReminderStageDueSoon, ar.NextReminderStage(now, testReminderLeadTime))
+	})
+}
@@ -76,6 +76,22 @@ func (s *ReconciliationService) GetEffectiveStrategy(ctx context.Context, tenant
 	return s.defaultStrategyType
 }
 
+// AvailableStrategies returns the reconciliation strategy types that can be requested
+func (s *ReconciliationService) AvailableStrategies() []ReconciliationStrategyType {
+	return s.strategyFactory.AvailableStrategies()
+}
+
+// IsStrategyAvailable returns true if the strategy type can be requested
+func (s *ReconciliationService) IsStrategyAvailable(strategyType ReconciliationStrategyType) bool {
+	return s.strategyFactory.IsRegistered(strategyType)
+}
+
+func (s *ReconciliationService) invalidStrategyError(strategyType ReconciliationStrategyType) error {
+	return shared.NewDomainError("INVALID_STRATEGY",
+		fmt.Sprintf("Invalid reconciliation strategy type %q, available strategies: %s",
+			strategyType, FormatStrategyTypes(s.AvailableStrategies())))
+}
+
 // ReconcileReceiptRequest represents a request to reconcile a receipt voucher
 type ReconcileReceiptRequest struct {
 	ReceiptVoucher *ReceiptVoucher
@@ -112,9 +128,9 @@ func (s *ReconciliationService) ReconcileReceipt(
 		return nil, shared.NewDomainError("NO_UNALLOCATED", "Receipt voucher has no unallocated amount")
 	}
 
-	// Validate strategy type
-	if !req.StrategyType.IsValid() {
-		return nil, shared.NewDomainError("INVALID_STRATEGY", "Invalid reconciliation strategy type")
+	// Validate strategy type against the registered strategies
+	if !s.strategyFactory.IsRegistered(req.StrategyType) {
+		return nil, s.invalidStrategyError(req.StrategyType)
 	}
 
 	// Create the appropriate strategy
@@ -123,10 +139,20 @@ func (s *ReconciliationService) ReconcileReceipt(
 		return nil, err
 	}
 
-	// Filter receivables for the same customer
+	// Amounts in different currencies are never summed together.
+	// Manually targeting a receivable in another currency is rejected outright.
+	voucherCurrency := req.ReceiptVoucher.GetAmountMoney().Currency()
+	if req.StrategyType == ReconciliationStrategyTypeManual {
+		if err := validateReceivableAllocationCurrency(voucherCurrency, req.Receivables, req.ManualAllocations); err != nil {
+			return nil, err
+		}
+	}
+
+	// Filter receivables for the same customer and currency
 	customerReceivables := make([]AccountReceivable, 0)
 	for _, r := range req.Receivables {
 		if r.CustomerID == req.ReceiptVoucher.CustomerID &&
+			r.GetCurrency() == voucherCurrency &&
 			r.Status.CanApplyPayment() &&
 			r.OutstandingAmount.GreaterThan(decimal.Zero) {
 			customerReceivables = append(customerReceivables, r)
@@ -173,7 +199,7 @@ func (s *ReconciliationService) ReconcileReceipt(
 			continue
 		}
 
-		allocAmount := valueobject.NewMoneyCNY(alloc.Amount)
+		allocAmount := newMoney(alloc.Amount, voucherCurrency)
 
 		// Allocate on voucher
 		allocation, err := req.ReceiptVoucher.AllocateToReceivable(
@@ -188,7 +214,7 @@ func (s *ReconciliationService) ReconcileReceipt(
 		allocations = append(allocations, *allocation)
 
 		// Apply payment to receivable
-		err = receivable.ApplyPayment(allocAmount, req.ReceiptVoucher.ID,
+		err = receivable.ApplyAllocation(allocation, allocAmount,
 			fmt.Sprintf("Payment from receipt voucher %s", req.ReceiptVoucher.VoucherNumber))
 		if err != nil {
 			return nil, fmt.Errorf("failed to apply payment to receivable %s: %w", receivable.ReceivableNumber, err)
@@ -242,9 +268,9 @@ func (s *ReconciliationService) ReconcilePayment(
 		return nil, shared.NewDomainError("NO_UNALLOCATED", "Payment voucher has no unallocated amount")
 	}
 
-	// Validate strategy type
-	if !req.StrategyType.IsValid() {
-		return nil, shared.NewDomainError("INVALID_STRATEGY", "Invalid reconciliation strategy type")
+	// Validate strategy type against the registered strategies
+	if !s.strategyFactory.IsRegistered(req.StrategyType) {
+		return nil, s.invalidStrategyError(req.StrategyType)
 	}
 
 	// Create the appropriate strategy
@@ -253,10 +279,20 @@ func (s *ReconciliationService) ReconcilePayment(
 		return nil, err
 	}
 
-	// Filter payables for the same supplier
+	// Amounts in different currencies are never summed together.
+	// Manually targeting a payable in another currency is rejected outright.
+	voucherCurrency := req.PaymentVoucher.GetAmountMoney().Currency()
+	if req.StrategyType == ReconciliationStrategyTypeManual {
+		if err := validatePayableAllocationCurrency(voucherCurrency, req.Payables, req.ManualAllocations); err != nil {
+			return nil, err
+		}
+	}
+
+	// Filter payables for the same supplier and currency
 	supplierPayables := make([]AccountPayable, 0)
 	for _, p := range req.Payables {
 		if p.SupplierID == req.PaymentVoucher.SupplierID &&
+			p.GetCurrency() == voucherCurrency &&
 			p.Status.CanApplyPayment() &&
 			p.OutstandingAmount.GreaterThan(decimal.Zero) {
 			supplierPayables = append(supplierPayables, p)
@@ -303,7 +339,7 @@ func (s *ReconciliationService) ReconcilePayment(
 			continue
 		}
 
-		allocAmount := valueobject.NewMoneyCNY(alloc.Amount)
+		allocAmount := newMoney(alloc.Amount, voucherCurrency)
 
 		// Allocate on voucher
 		allocation, err := req.PaymentVoucher.AllocateToPayable(
@@ -412,10 +448,20 @@ func (s *ReconciliationService) PreviewReconcileReceipt(
 		return nil, err
 	}
 
-	// Filter receivables for the same customer
+	// Amounts in different currencies are never summed together.
+	// Manually targeting a receivable in another currency is rejected outright.
+	voucherCurrency := req.ReceiptVoucher.GetAmountMoney().Currency()
+	if req.StrategyType == ReconciliationStrategyTypeManual {
+		if err := validateReceivableAllocationCurrency(voucherCurrency, req.Receivables, req.ManualAllocations); err != nil {
+			return nil, err
+		}
+	}
+
+	// Filter receivables for the same customer and currency
 	customerReceivables := make([]AccountReceivable, 0)
 	for _, r := range req.Receivables {
 		if r.CustomerID == req.ReceiptVoucher.CustomerID &&
+			r.GetCurrency() == voucherCurrency &&
 			r.Status.CanApplyPayment() &&
 			r.OutstandingAmount.GreaterThan(decimal.Zero) {
 			customerReceivables = append(customerReceivables, r)
@@ -449,10 +495,20 @@ func (s *ReconciliationService) PreviewReconcilePayment(
 		return nil, err
 	}
 
-	// Filter payables for the same supplier
+	// Amounts in different currencies are never summed together.
+	// Manually targeting a payable in another currency is rejected outright.
+	voucherCurrency := req.PaymentVoucher.GetAmountMoney().Currency()
+	if req.StrategyType == ReconciliationStrategyTypeManual {
+		if err := validatePayableAllocationCurrency(voucherCurrency, req.Payables, req.ManualAllocations); err != nil {
+			return nil, err
+		}
+	}
+
+	// Filter payables for the same supplier and currency
 	supplierPayables := make([]AccountPayable, 0)
 	for _, p := range req.Payables {
 		if p.SupplierID == req.PaymentVoucher.SupplierID &&
+			p.GetCurrency() == voucherCurrency &&
 			p.Status.CanApplyPayment() &&
 			p.OutstandingAmount.GreaterThan(decimal.Zero) {
 			supplierPayables = append(supplierPayables, p)
@@ -466,3 +522,29 @@ func (s *ReconciliationService) PreviewReconcilePayment(
 
 	return payableStrategy.AllocatePayment(req.PaymentVoucher, supplierPayables)
 }
+
+// validateReceivableAllocationCurrency ensures manual allocations only target receivables
+// in the voucher currency
+func validateReceivableAllocationCurrency(currency valueobject.Currency, receivables []AccountReceivable, allocations []ManualAllocationRequest) error {
+	for _, alloc := range allocations {
+		for i := range receivables {
+			if receivables[i].ID == alloc.TargetID && receivables[i].GetCurrency() != currency {
+				return NewCurrencyMismatchError(currency, receivables[i].GetCurrency())
+			}
+		}
+	}
+	return nil
+}
+
+// validatePayableAllocationCurrency ensures manual allocations only target payables
+// in the voucher currency
+func validatePayableAllocationCurrency(currency valueobject.Currency, payables []AccountPayable, allocations []ManualAllocationRequest) error {
+	for _, alloc := range allocations {
+		for i := range payables {
+			if payables[i].ID == alloc.TargetID && payables[i].GetCurrency() != currency {
+				return NewCurrencyMismatchError(currency, payables[i].GetCurrency())
+			}
+		}
+	}
+	return nil
+}
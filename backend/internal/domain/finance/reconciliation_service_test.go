@@ -187,6 +187,33 @@ func TestReconciliationService_ReconcileReceipt_InvalidStrategy(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Invalid reconciliation strategy type")
+	assert.Contains(t, err.Error(), "available strategies: FIFO, LARGEST_FIRST, LIFO, MANUAL")
+}
+
+func TestReconciliationService_ReconcileReceipt_LIFO(t *testing.T) {
+	service := NewReconciliationService()
+	tenantID := uuid.New()
+	customerID := uuid.New()
+
+	voucher := createReceiptVoucherForReconciliation(t, tenantID, customerID, decimal.NewFromInt(1000), true)
+
+	dueDate1 := time.Now().Add(7 * 24 * time.Hour)
+	dueDate2 := time.Now().Add(14 * 24 * time.Hour)
+	receivable1 := createReceivableForReconciliation(t, tenantID, customerID, "AR-001", decimal.NewFromInt(800), &dueDate1)
+	receivable2 := createReceivableForReconciliation(t, tenantID, customerID, "AR-002", decimal.NewFromInt(800), &dueDate2)
+
+	result, err := service.ReconcileReceipt(context.Background(), ReconcileReceiptRequest{
+		ReceiptVoucher: voucher,
+		Receivables:    []AccountReceivable{receivable1, receivable2},
+		StrategyType:   ReconciliationStrategyTypeLIFO,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Allocations, 2)
+	assert.Equal(t, "AR-002", result.Allocations[0].ReceivableNumber)
+	assert.True(t, result.Allocations[0].Amount.Equal(decimal.NewFromInt(800)))
+	assert.Equal(t, "AR-001", result.Allocations[1].ReceivableNumber)
+	assert.True(t, result.Allocations[1].Amount.Equal(decimal.NewFromInt(200)))
 }
 
 func TestReconciliationService_ReconcileReceipt_NoReceivables(t *testing.T) {
@@ -771,3 +798,65 @@ func TestReconciliationService_AutoReconcilePayment_VoucherExceedsPayables(t *te
 	// Voucher should be CONFIRMED (not fully allocated)
 	assert.Equal(t, VoucherStatusConfirmed, result.PaymentVoucher.Status)
 }
+
+func TestReconciliationService_AutoReconcileReceipt_FiltersDifferentCurrency(t *testing.T) {
+	service := NewReconciliationService()
+	tenantID := uuid.New()
+	customerID := uuid.New()
+
+	voucher := createReceiptVoucherForReconciliation(t, tenantID, customerID, decimal.NewFromInt(1000), true)
+
+	dueDate := time.Now().Add(7 * 24 * time.Hour)
+	receivable := createReceivableForReconciliation(t, tenantID, customerID, "AR-001", decimal.NewFromInt(1000), &dueDate)
+	// Receivable in USD - should be filtered out for a CNY voucher
+	receivable.Currency = valueobject.USD
+
+	result, err := service.AutoReconcileReceipt(context.Background(), voucher, []AccountReceivable{receivable})
+
+	require.NoError(t, err)
+	assert.Len(t, result.UpdatedReceivables, 0)
+	assert.Len(t, result.Allocations, 0)
+	assert.False(t, result.FullyReconciled)
+}
+
+func TestReconciliationService_ManualReconcileReceipt_RejectsDifferentCurrency(t *testing.T) {
+	service := NewReconciliationService()
+	tenantID := uuid.New()
+	customerID := uuid.New()
+
+	voucher := createReceiptVoucherForReconciliation(t, tenantID, customerID, decimal.NewFromInt(1000), true)
+
+	dueDate := time.Now().Add(7 * 24 * time.Hour)
+	receivable := createReceivableForReconciliation(t, tenantID, customerID, "AR-001", decimal.NewFromInt(1000), &dueDate)
+	receivable.Currency = valueobject.USD
+
+	allocations := []ManualAllocationRequest{
+		{TargetID: receivable.ID, Amount: decimal.NewFromInt(500)},
+	}
+
+	_, err := service.ManualReconcileReceipt(context.Background(), voucher, []AccountReceivable{receivable}, allocations)
+
+	var mismatchErr *CurrencyMismatchError
+	require.ErrorAs(t, err, &mismatchErr)
+	assert.Equal(t, valueobject.CNY, mismatchErr.Expected)
+	assert.Equal(t, valueobject.USD, mismatchErr.Actual)
+}
+
+func TestReconciliationService_AutoReconcilePayment_FiltersDifferentCurrency(t *testing.T) {
+	service := NewReconciliationService()
+	tenantID := uuid.New()
+	supplierID := uuid.New()
+
+	voucher := createPaymentVoucherForReconciliation(t, tenantID, supplierID, decimal.NewFromInt(1000), true)
+
+	dueDate := time.Now().Add(7 * 24 * time.Hour)
+	payable := createPayableForReconciliation(t, tenantID, supplierID, "AP-001", decimal.NewFromInt(1000), &dueDate)
+	payable.Currency = valueobject.EUR
+
+	result, err := service.AutoReconcilePayment(context.Background(), voucher, []AccountPayable{payable})
+
+	require.NoError(t, err)
+	assert.Len(t, result.UpdatedPayables, 0)
+	assert.Len(t, result.Allocations, 0)
+	assert.False(t, result.FullyReconciled)
+}
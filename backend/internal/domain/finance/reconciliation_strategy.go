@@ -1,7 +1,9 @@
 package finance
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
@@ -15,14 +17,17 @@ import (
 type ReconciliationStrategyType string
 
 const (
-	ReconciliationStrategyTypeFIFO   ReconciliationStrategyType = "FIFO"   // First In First Out by date
-	ReconciliationStrategyTypeManual ReconciliationStrategyType = "MANUAL" // Manual allocation to specific items
+	ReconciliationStrategyTypeFIFO         ReconciliationStrategyType = "FIFO"          // First In First Out by date
+	ReconciliationStrategyTypeLIFO         ReconciliationStrategyType = "LIFO"          // Last In First Out by date
+	ReconciliationStrategyTypeLargestFirst ReconciliationStrategyType = "LARGEST_FIRST" // Largest outstanding amount first
+	ReconciliationStrategyTypeManual       ReconciliationStrategyType = "MANUAL"        // Manual allocation to specific items
 )
 
 // IsValid checks if the strategy type is valid
 func (t ReconciliationStrategyType) IsValid() bool {
 	switch t {
-	case ReconciliationStrategyTypeFIFO, ReconciliationStrategyTypeManual:
+	case ReconciliationStrategyTypeFIFO, ReconciliationStrategyTypeLIFO,
+		ReconciliationStrategyTypeLargestFirst, ReconciliationStrategyTypeManual:
 		return true
 	}
 	return false
@@ -37,6 +42,8 @@ func (t ReconciliationStrategyType) String() string {
 func AllReconciliationStrategyTypes() []ReconciliationStrategyType {
 	return []ReconciliationStrategyType{
 		ReconciliationStrategyTypeFIFO,
+		ReconciliationStrategyTypeLIFO,
+		ReconciliationStrategyTypeLargestFirst,
 		ReconciliationStrategyTypeManual,
 	}
 }
@@ -114,6 +121,27 @@ func (s *FIFOReconciliationStrategy) StrategyType() ReconciliationStrategyType {
 
 // Allocate allocates the amount to targets using FIFO order (oldest first)
 func (s *FIFOReconciliationStrategy) Allocate(amount valueobject.Money, targets []AllocationTarget) (*ReconciliationResult, error) {
+	return allocateInOrder(amount, targets, fifoLess)
+}
+
+// fifoLess orders targets by due date first (nil due dates go to the end), then creation date
+func fifoLess(a, b AllocationTarget) bool {
+	if a.DueDate != nil && b.DueDate != nil {
+		if !a.DueDate.Equal(*b.DueDate) {
+			return a.DueDate.Before(*b.DueDate)
+		}
+	} else if a.DueDate != nil {
+		return true // a has due date, b doesn't - a comes first
+	} else if b.DueDate != nil {
+		return false // b has due date, a doesn't - b comes first
+	}
+	// Fall back to creation date
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// allocateInOrder sorts targets with less and allocates the amount to each in turn
+// until the amount is used up. It is shared by the ordering-based strategies.
+func allocateInOrder(amount valueobject.Money, targets []AllocationTarget, less func(a, b AllocationTarget) bool) (*ReconciliationResult, error) {
 	if amount.Amount().LessThanOrEqual(decimal.Zero) {
 		return nil, shared.NewDomainError("INVALID_AMOUNT", "Allocation amount must be positive")
 	}
@@ -128,22 +156,10 @@ func (s *FIFOReconciliationStrategy) Allocate(amount valueobject.Money, targets
 		}, nil
 	}
 
-	// Sort targets by FIFO order: due date first, then creation date
 	sortedTargets := make([]AllocationTarget, len(targets))
 	copy(sortedTargets, targets)
-	sort.Slice(sortedTargets, func(i, j int) bool {
-		// Sort by due date first (nil due dates go to the end)
-		if sortedTargets[i].DueDate != nil && sortedTargets[j].DueDate != nil {
-			if !sortedTargets[i].DueDate.Equal(*sortedTargets[j].DueDate) {
-				return sortedTargets[i].DueDate.Before(*sortedTargets[j].DueDate)
-			}
-		} else if sortedTargets[i].DueDate != nil {
-			return true // i has due date, j doesn't - i comes first
-		} else if sortedTargets[j].DueDate != nil {
-			return false // j has due date, i doesn't - j comes first
-		}
-		// Fall back to creation date
-		return sortedTargets[i].CreatedAt.Before(sortedTargets[j].CreatedAt)
+	sort.SliceStable(sortedTargets, func(i, j int) bool {
+		return less(sortedTargets[i], sortedTargets[j])
 	})
 
 	allocations := make([]AllocationResult, 0)
@@ -192,17 +208,149 @@ func (s *FIFOReconciliationStrategy) Allocate(amount valueobject.Money, targets
 
 // AllocateReceipt allocates a receipt voucher to receivables using FIFO
 func (s *FIFOReconciliationStrategy) AllocateReceipt(voucher *ReceiptVoucher, receivables []AccountReceivable) (*ReconciliationResult, error) {
+	if err := validateReceiptVoucherForAllocation(voucher); err != nil {
+		return nil, err
+	}
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), receivableAllocationTargets(receivables))
+}
+
+// AllocatePayment allocates a payment voucher to payables using FIFO
+func (s *FIFOReconciliationStrategy) AllocatePayment(voucher *PaymentVoucher, payables []AccountPayable) (*ReconciliationResult, error) {
+	if err := validatePaymentVoucherForAllocation(voucher); err != nil {
+		return nil, err
+	}
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), payableAllocationTargets(payables))
+}
+
+// LIFOReconciliationStrategy implements LIFO (Last In First Out) reconciliation
+// It allocates payments/receipts to the most recent outstanding items first
+type LIFOReconciliationStrategy struct {
+	strategy.BaseStrategy
+}
+
+// NewLIFOReconciliationStrategy creates a new LIFO reconciliation strategy
+func NewLIFOReconciliationStrategy() *LIFOReconciliationStrategy {
+	return &LIFOReconciliationStrategy{
+		BaseStrategy: strategy.NewBaseStrategy(
+			"lifo_reconciliation",
+			strategy.StrategyTypeAllocation,
+			"LIFO reconciliation strategy - allocates to newest outstanding items first by due date, then creation date",
+		),
+	}
+}
+
+// StrategyType returns the reconciliation strategy type
+func (s *LIFOReconciliationStrategy) StrategyType() ReconciliationStrategyType {
+	return ReconciliationStrategyTypeLIFO
+}
+
+// Allocate allocates the amount to targets using LIFO order (newest first).
+// Targets without a due date still go last, as they cannot be placed in time.
+func (s *LIFOReconciliationStrategy) Allocate(amount valueobject.Money, targets []AllocationTarget) (*ReconciliationResult, error) {
+	return allocateInOrder(amount, targets, func(a, b AllocationTarget) bool {
+		if a.DueDate != nil && b.DueDate != nil {
+			if !a.DueDate.Equal(*b.DueDate) {
+				return a.DueDate.After(*b.DueDate)
+			}
+		} else if a.DueDate != nil {
+			return true
+		} else if b.DueDate != nil {
+			return false
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+}
+
+// AllocateReceipt allocates a receipt voucher to receivables using LIFO
+func (s *LIFOReconciliationStrategy) AllocateReceipt(voucher *ReceiptVoucher, receivables []AccountReceivable) (*ReconciliationResult, error) {
+	if err := validateReceiptVoucherForAllocation(voucher); err != nil {
+		return nil, err
+	}
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), receivableAllocationTargets(receivables))
+}
+
+// AllocatePayment allocates a payment voucher to payables using LIFO
+func (s *LIFOReconciliationStrategy) AllocatePayment(voucher *PaymentVoucher, payables []AccountPayable) (*ReconciliationResult, error) {
+	if err := validatePaymentVoucherForAllocation(voucher); err != nil {
+		return nil, err
+	}
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), payableAllocationTargets(payables))
+}
+
+// LargestFirstReconciliationStrategy allocates payments/receipts to the items
+// with the largest outstanding amount first, clearing big balances before small ones
+type LargestFirstReconciliationStrategy struct {
+	strategy.BaseStrategy
+}
+
+// NewLargestFirstReconciliationStrategy creates a new largest-first reconciliation strategy
+func NewLargestFirstReconciliationStrategy() *LargestFirstReconciliationStrategy {
+	return &LargestFirstReconciliationStrategy{
+		BaseStrategy: strategy.NewBaseStrategy(
+			"largest_first_reconciliation",
+			strategy.StrategyTypeAllocation,
+			"Largest-first reconciliation strategy - allocates to items with the largest outstanding amount first",
+		),
+	}
+}
+
+// StrategyType returns the reconciliation strategy type
+func (s *LargestFirstReconciliationStrategy) StrategyType() ReconciliationStrategyType {
+	return ReconciliationStrategyTypeLargestFirst
+}
+
+// Allocate allocates the amount to targets by outstanding amount (largest first), ties in FIFO order
+func (s *LargestFirstReconciliationStrategy) Allocate(amount valueobject.Money, targets []AllocationTarget) (*ReconciliationResult, error) {
+	return allocateInOrder(amount, targets, func(a, b AllocationTarget) bool {
+		if !a.OutstandingAmount.Equal(b.OutstandingAmount) {
+			return a.OutstandingAmount.GreaterThan(b.OutstandingAmount)
+		}
+		return fifoLess(a, b)
+	})
+}
+
+// AllocateReceipt allocates a receipt voucher to receivables, largest first
+func (s *LargestFirstReconciliationStrategy) AllocateReceipt(voucher *ReceiptVoucher, receivables []AccountReceivable) (*ReconciliationResult, error) {
+	if err := validateReceiptVoucherForAllocation(voucher); err != nil {
+		return nil, err
+	}
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), receivableAllocationTargets(receivables))
+}
+
+// AllocatePayment allocates a payment voucher to payables, largest first
+func (s *LargestFirstReconciliationStrategy) AllocatePayment(voucher *PaymentVoucher, payables []AccountPayable) (*ReconciliationResult, error) {
+	if err := validatePaymentVoucherForAllocation(voucher); err != nil {
+		return nil, err
+	}
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), payableAllocationTargets(payables))
+}
+
+// validateReceiptVoucherForAllocation checks that a receipt voucher has an amount left to allocate
+func validateReceiptVoucherForAllocation(voucher *ReceiptVoucher) error {
 	if voucher == nil {
-		return nil, shared.NewDomainError("INVALID_VOUCHER", "Receipt voucher cannot be nil")
+		return shared.NewDomainError("INVALID_VOUCHER", "Receipt voucher cannot be nil")
 	}
 	if voucher.UnallocatedAmount.LessThanOrEqual(decimal.Zero) {
-		return nil, shared.NewDomainError("NO_UNALLOCATED", "Receipt voucher has no unallocated amount")
+		return shared.NewDomainError("NO_UNALLOCATED", "Receipt voucher has no unallocated amount")
 	}
+	return nil
+}
+
+// validatePaymentVoucherForAllocation checks that a payment voucher has an amount left to allocate
+func validatePaymentVoucherForAllocation(voucher *PaymentVoucher) error {
+	if voucher == nil {
+		return shared.NewDomainError("INVALID_VOUCHER", "Payment voucher cannot be nil")
+	}
+	if voucher.UnallocatedAmount.LessThanOrEqual(decimal.Zero) {
+		return shared.NewDomainError("NO_UNALLOCATED", "Payment voucher has no unallocated amount")
+	}
+	return nil
+}
 
-	// Convert receivables to allocation targets
+// receivableAllocationTargets converts receivables that can receive payments to allocation targets
+func receivableAllocationTargets(receivables []AccountReceivable) []AllocationTarget {
 	targets := make([]AllocationTarget, 0, len(receivables))
 	for _, r := range receivables {
-		// Only include receivables that can receive payments
 		if r.Status.CanApplyPayment() && r.OutstandingAmount.GreaterThan(decimal.Zero) {
 			targets = append(targets, AllocationTarget{
 				ID:                r.ID,
@@ -213,23 +361,13 @@ func (s *FIFOReconciliationStrategy) AllocateReceipt(voucher *ReceiptVoucher, re
 			})
 		}
 	}
-
-	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), targets)
+	return targets
 }
 
-// AllocatePayment allocates a payment voucher to payables using FIFO
-func (s *FIFOReconciliationStrategy) AllocatePayment(voucher *PaymentVoucher, payables []AccountPayable) (*ReconciliationResult, error) {
-	if voucher == nil {
-		return nil, shared.NewDomainError("INVALID_VOUCHER", "Payment voucher cannot be nil")
-	}
-	if voucher.UnallocatedAmount.LessThanOrEqual(decimal.Zero) {
-		return nil, shared.NewDomainError("NO_UNALLOCATED", "Payment voucher has no unallocated amount")
-	}
-
-	// Convert payables to allocation targets
+// payableAllocationTargets converts payables that can receive payments to allocation targets
+func payableAllocationTargets(payables []AccountPayable) []AllocationTarget {
 	targets := make([]AllocationTarget, 0, len(payables))
 	for _, p := range payables {
-		// Only include payables that can receive payments
 		if p.Status.CanApplyPayment() && p.OutstandingAmount.GreaterThan(decimal.Zero) {
 			targets = append(targets, AllocationTarget{
 				ID:                p.ID,
@@ -240,8 +378,7 @@ func (s *FIFOReconciliationStrategy) AllocatePayment(voucher *PaymentVoucher, pa
 			})
 		}
 	}
-
-	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), targets)
+	return targets
 }
 
 // ManualAllocationRequest represents a request to manually allocate to a specific target
@@ -368,62 +505,72 @@ func (s *ManualReconciliationStrategy) Allocate(amount valueobject.Money, target
 
 // AllocateReceipt allocates a receipt voucher to receivables using manual allocations
 func (s *ManualReconciliationStrategy) AllocateReceipt(voucher *ReceiptVoucher, receivables []AccountReceivable) (*ReconciliationResult, error) {
-	if voucher == nil {
-		return nil, shared.NewDomainError("INVALID_VOUCHER", "Receipt voucher cannot be nil")
+	if err := validateReceiptVoucherForAllocation(voucher); err != nil {
+		return nil, err
 	}
-	if voucher.UnallocatedAmount.LessThanOrEqual(decimal.Zero) {
-		return nil, shared.NewDomainError("NO_UNALLOCATED", "Receipt voucher has no unallocated amount")
-	}
-
-	// Convert receivables to allocation targets
-	targets := make([]AllocationTarget, 0, len(receivables))
-	for _, r := range receivables {
-		if r.Status.CanApplyPayment() && r.OutstandingAmount.GreaterThan(decimal.Zero) {
-			targets = append(targets, AllocationTarget{
-				ID:                r.ID,
-				Number:            r.ReceivableNumber,
-				OutstandingAmount: r.OutstandingAmount,
-				DueDate:           r.DueDate,
-				CreatedAt:         r.CreatedAt,
-			})
-		}
-	}
-
-	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), targets)
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), receivableAllocationTargets(receivables))
 }
 
 // AllocatePayment allocates a payment voucher to payables using manual allocations
 func (s *ManualReconciliationStrategy) AllocatePayment(voucher *PaymentVoucher, payables []AccountPayable) (*ReconciliationResult, error) {
-	if voucher == nil {
-		return nil, shared.NewDomainError("INVALID_VOUCHER", "Payment voucher cannot be nil")
-	}
-	if voucher.UnallocatedAmount.LessThanOrEqual(decimal.Zero) {
-		return nil, shared.NewDomainError("NO_UNALLOCATED", "Payment voucher has no unallocated amount")
+	if err := validatePaymentVoucherForAllocation(voucher); err != nil {
+		return nil, err
 	}
+	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), payableAllocationTargets(payables))
+}
 
-	// Convert payables to allocation targets
-	targets := make([]AllocationTarget, 0, len(payables))
-	for _, p := range payables {
-		if p.Status.CanApplyPayment() && p.OutstandingAmount.GreaterThan(decimal.Zero) {
-			targets = append(targets, AllocationTarget{
-				ID:                p.ID,
-				Number:            p.PayableNumber,
-				OutstandingAmount: p.OutstandingAmount,
-				DueDate:           p.DueDate,
-				CreatedAt:         p.CreatedAt,
-			})
-		}
+// ReconciliationStrategyConstructor builds a strategy for a single reconciliation request.
+// Manual allocations are passed through for strategies that need them.
+type ReconciliationStrategyConstructor func(allocations []ManualAllocationRequest) (ReconciliationStrategy, error)
+
+// ReconciliationStrategyFactory creates reconciliation strategies.
+// It keeps a registry of strategy constructors so the strategy can be resolved per request.
+type ReconciliationStrategyFactory struct {
+	strategyRegistry map[ReconciliationStrategyType]ReconciliationStrategyConstructor
+}
+
+// NewReconciliationStrategyFactory creates a new factory with the built-in strategies registered
+func NewReconciliationStrategyFactory() *ReconciliationStrategyFactory {
+	f := &ReconciliationStrategyFactory{
+		strategyRegistry: make(map[ReconciliationStrategyType]ReconciliationStrategyConstructor),
 	}
+	f.Register(ReconciliationStrategyTypeFIFO, func([]ManualAllocationRequest) (ReconciliationStrategy, error) {
+		return f.CreateFIFOStrategy(), nil
+	})
+	f.Register(ReconciliationStrategyTypeLIFO, func([]ManualAllocationRequest) (ReconciliationStrategy, error) {
+		return f.CreateLIFOStrategy(), nil
+	})
+	f.Register(ReconciliationStrategyTypeLargestFirst, func([]ManualAllocationRequest) (ReconciliationStrategy, error) {
+		return f.CreateLargestFirstStrategy(), nil
+	})
+	f.Register(ReconciliationStrategyTypeManual, func(allocations []ManualAllocationRequest) (ReconciliationStrategy, error) {
+		if len(allocations) == 0 {
+			return nil, shared.NewDomainError("INVALID_ALLOCATIONS", "Manual strategy requires allocation requests")
+		}
+		return f.CreateManualStrategy(allocations), nil
+	})
+	return f
+}
 
-	return s.Allocate(valueobject.NewMoneyCNY(voucher.UnallocatedAmount), targets)
+// Register adds or replaces the constructor for a strategy type
+func (f *ReconciliationStrategyFactory) Register(strategyType ReconciliationStrategyType, constructor ReconciliationStrategyConstructor) {
+	f.strategyRegistry[strategyType] = constructor
 }
 
-// ReconciliationStrategyFactory creates reconciliation strategies
-type ReconciliationStrategyFactory struct{}
+// IsRegistered returns true if a strategy is registered for the type
+func (f *ReconciliationStrategyFactory) IsRegistered(strategyType ReconciliationStrategyType) bool {
+	_, ok := f.strategyRegistry[strategyType]
+	return ok
+}
 
-// NewReconciliationStrategyFactory creates a new factory
-func NewReconciliationStrategyFactory() *ReconciliationStrategyFactory {
-	return &ReconciliationStrategyFactory{}
+// AvailableStrategies returns the registered strategy types in sorted order
+func (f *ReconciliationStrategyFactory) AvailableStrategies() []ReconciliationStrategyType {
+	types := make([]ReconciliationStrategyType, 0, len(f.strategyRegistry))
+	for t := range f.strategyRegistry {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
 }
 
 // CreateFIFOStrategy creates a FIFO reconciliation strategy
@@ -431,22 +578,37 @@ func (f *ReconciliationStrategyFactory) CreateFIFOStrategy() *FIFOReconciliation
 	return NewFIFOReconciliationStrategy()
 }
 
+// CreateLIFOStrategy creates a LIFO reconciliation strategy
+func (f *ReconciliationStrategyFactory) CreateLIFOStrategy() *LIFOReconciliationStrategy {
+	return NewLIFOReconciliationStrategy()
+}
+
+// CreateLargestFirstStrategy creates a largest-first reconciliation strategy
+func (f *ReconciliationStrategyFactory) CreateLargestFirstStrategy() *LargestFirstReconciliationStrategy {
+	return NewLargestFirstReconciliationStrategy()
+}
+
 // CreateManualStrategy creates a manual reconciliation strategy with specified allocations
 func (f *ReconciliationStrategyFactory) CreateManualStrategy(allocations []ManualAllocationRequest) *ManualReconciliationStrategy {
 	return NewManualReconciliationStrategy(allocations)
 }
 
-// GetStrategy returns a strategy by type
+// GetStrategy resolves a strategy by type from the registry
 func (f *ReconciliationStrategyFactory) GetStrategy(strategyType ReconciliationStrategyType, allocations []ManualAllocationRequest) (ReconciliationStrategy, error) {
-	switch strategyType {
-	case ReconciliationStrategyTypeFIFO:
-		return f.CreateFIFOStrategy(), nil
-	case ReconciliationStrategyTypeManual:
-		if len(allocations) == 0 {
-			return nil, shared.NewDomainError("INVALID_ALLOCATIONS", "Manual strategy requires allocation requests")
-		}
-		return f.CreateManualStrategy(allocations), nil
-	default:
-		return nil, shared.NewDomainError("INVALID_STRATEGY", "Unknown reconciliation strategy type")
+	constructor, ok := f.strategyRegistry[strategyType]
+	if !ok {
+		return nil, shared.NewDomainError("INVALID_STRATEGY",
+			fmt.Sprintf("Unknown reconciliation strategy type %q, available strategies: %s",
+				strategyType, FormatStrategyTypes(f.AvailableStrategies())))
+	}
+	return constructor(allocations)
+}
+
+// FormatStrategyTypes joins strategy types into a comma-separated list
+func FormatStrategyTypes(types []ReconciliationStrategyType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
 	}
+	return strings.Join(names, ", ")
 }
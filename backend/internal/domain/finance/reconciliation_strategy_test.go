@@ -14,6 +14,8 @@ import (
 func TestReconciliationStrategyType(t *testing.T) {
 	t.Run("IsValid returns true for valid types", func(t *testing.T) {
 		assert.True(t, ReconciliationStrategyTypeFIFO.IsValid())
+		assert.True(t, ReconciliationStrategyTypeLIFO.IsValid())
+		assert.True(t, ReconciliationStrategyTypeLargestFirst.IsValid())
 		assert.True(t, ReconciliationStrategyTypeManual.IsValid())
 	})
 
@@ -29,8 +31,10 @@ func TestReconciliationStrategyType(t *testing.T) {
 
 	t.Run("AllReconciliationStrategyTypes returns all types", func(t *testing.T) {
 		types := AllReconciliationStrategyTypes()
-		assert.Len(t, types, 2)
+		assert.Len(t, types, 4)
 		assert.Contains(t, types, ReconciliationStrategyTypeFIFO)
+		assert.Contains(t, types, ReconciliationStrategyTypeLIFO)
+		assert.Contains(t, types, ReconciliationStrategyTypeLargestFirst)
 		assert.Contains(t, types, ReconciliationStrategyTypeManual)
 	})
 }
@@ -607,7 +611,148 @@ func TestReconciliationStrategyFactory(t *testing.T) {
 		_, err := factory.GetStrategy(ReconciliationStrategyType("INVALID"), nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Unknown")
+		assert.Contains(t, err.Error(), "FIFO, LARGEST_FIRST, LIFO, MANUAL")
 	})
+
+	t.Run("GetStrategy returns LIFO and largest-first strategies", func(t *testing.T) {
+		lifo, err := factory.GetStrategy(ReconciliationStrategyTypeLIFO, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ReconciliationStrategyTypeLIFO, lifo.StrategyType())
+
+		largest, err := factory.GetStrategy(ReconciliationStrategyTypeLargestFirst, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ReconciliationStrategyTypeLargestFirst, largest.StrategyType())
+	})
+
+	t.Run("AvailableStrategies lists registered strategies", func(t *testing.T) {
+		assert.Equal(t, []ReconciliationStrategyType{
+			ReconciliationStrategyTypeFIFO,
+			ReconciliationStrategyTypeLargestFirst,
+			ReconciliationStrategyTypeLIFO,
+			ReconciliationStrategyTypeManual,
+		}, factory.AvailableStrategies())
+	})
+
+	t.Run("Register adds a custom strategy", func(t *testing.T) {
+		custom := NewReconciliationStrategyFactory()
+		custom.Register("OLDEST_CREATED", func([]ManualAllocationRequest) (ReconciliationStrategy, error) {
+			return NewFIFOReconciliationStrategy(), nil
+		})
+
+		assert.True(t, custom.IsRegistered("OLDEST_CREATED"))
+		_, err := custom.GetStrategy("OLDEST_CREATED", nil)
+		require.NoError(t, err)
+		assert.False(t, factory.IsRegistered("OLDEST_CREATED"))
+	})
+}
+
+func TestLIFOReconciliationStrategy(t *testing.T) {
+	t.Run("NewLIFOReconciliationStrategy creates valid strategy", func(t *testing.T) {
+		strategy := NewLIFOReconciliationStrategy()
+		assert.Equal(t, "lifo_reconciliation", strategy.Name())
+		assert.Equal(t, ReconciliationStrategyTypeLIFO, strategy.StrategyType())
+	})
+
+	t.Run("Allocate sorts by due date newest first", func(t *testing.T) {
+		strategy := NewLIFOReconciliationStrategy()
+		now := time.Now()
+		earlier := now.Add(-24 * time.Hour)
+		later := now.Add(24 * time.Hour)
+
+		id1 := uuid.New()
+		id2 := uuid.New()
+		id3 := uuid.New()
+		id4 := uuid.New()
+
+		targets := []AllocationTarget{
+			{ID: id1, Number: "AR-001", OutstandingAmount: decimal.NewFromInt(100), DueDate: &earlier, CreatedAt: now},
+			{ID: id4, Number: "AR-004", OutstandingAmount: decimal.NewFromInt(100), DueDate: nil, CreatedAt: now},
+			{ID: id2, Number: "AR-002", OutstandingAmount: decimal.NewFromInt(100), DueDate: &later, CreatedAt: now},
+			{ID: id3, Number: "AR-003", OutstandingAmount: decimal.NewFromInt(100), DueDate: &now, CreatedAt: now},
+		}
+
+		result, err := strategy.Allocate(valueobject.NewMoneyCNY(decimal.NewFromInt(400)), targets)
+		require.NoError(t, err)
+
+		require.Len(t, result.Allocations, 4)
+		assert.Equal(t, id2, result.Allocations[0].TargetID)
+		assert.Equal(t, id3, result.Allocations[1].TargetID)
+		assert.Equal(t, id1, result.Allocations[2].TargetID)
+		// Targets without due date go last
+		assert.Equal(t, id4, result.Allocations[3].TargetID)
+	})
+}
+
+func TestLargestFirstReconciliationStrategy(t *testing.T) {
+	t.Run("NewLargestFirstReconciliationStrategy creates valid strategy", func(t *testing.T) {
+		strategy := NewLargestFirstReconciliationStrategy()
+		assert.Equal(t, "largest_first_reconciliation", strategy.Name())
+		assert.Equal(t, ReconciliationStrategyTypeLargestFirst, strategy.StrategyType())
+	})
+
+	t.Run("Allocate sorts by outstanding amount largest first", func(t *testing.T) {
+		strategy := NewLargestFirstReconciliationStrategy()
+		now := time.Now()
+		earlier := now.Add(-24 * time.Hour)
+
+		small := uuid.New()
+		large := uuid.New()
+		mediumOld := uuid.New()
+		mediumNew := uuid.New()
+
+		targets := []AllocationTarget{
+			{ID: small, Number: "AR-001", OutstandingAmount: decimal.NewFromInt(50), DueDate: &earlier, CreatedAt: now},
+			{ID: mediumNew, Number: "AR-002", OutstandingAmount: decimal.NewFromInt(200), DueDate: &now, CreatedAt: now},
+			{ID: large, Number: "AR-003", OutstandingAmount: decimal.NewFromInt(500), DueDate: &now, CreatedAt: now},
+			{ID: mediumOld, Number: "AR-004", OutstandingAmount: decimal.NewFromInt(200), DueDate: &earlier, CreatedAt: now},
+		}
+
+		result, err := strategy.Allocate(valueobject.NewMoneyCNY(decimal.NewFromInt(800)), targets)
+		require.NoError(t, err)
+
+		require.Len(t, result.Allocations, 3)
+		assert.Equal(t, large, result.Allocations[0].TargetID)
+		// Equal amounts fall back to FIFO order
+		assert.Equal(t, mediumOld, result.Allocations[1].TargetID)
+		assert.Equal(t, mediumNew, result.Allocations[2].TargetID)
+		assert.True(t, result.Allocations[2].Amount.Equal(decimal.NewFromInt(100)))
+		assert.Contains(t, result.TargetsPartiallyPaid, mediumNew)
+	})
+}
+
+func TestReconciliationStrategies_FIFOAndLIFOAllocateInDifferentOrder(t *testing.T) {
+	tenantID := uuid.New()
+	customerID := uuid.New()
+	now := time.Now()
+	oldDue := now.Add(-30 * 24 * time.Hour)
+	newDue := now.Add(30 * 24 * time.Hour)
+
+	oldReceivable := createReceivableForReconciliation(t, tenantID, customerID, "AR-OLD", decimal.NewFromInt(600), &oldDue)
+	newReceivable := createReceivableForReconciliation(t, tenantID, customerID, "AR-NEW", decimal.NewFromInt(600), &newDue)
+	receivables := []AccountReceivable{oldReceivable, newReceivable}
+
+	fifoVoucher := createReceiptVoucherForReconciliation(t, tenantID, customerID, decimal.NewFromInt(800), true)
+	fifoResult, err := NewFIFOReconciliationStrategy().AllocateReceipt(fifoVoucher, receivables)
+	require.NoError(t, err)
+
+	lifoVoucher := createReceiptVoucherForReconciliation(t, tenantID, customerID, decimal.NewFromInt(800), true)
+	lifoResult, err := NewLIFOReconciliationStrategy().AllocateReceipt(lifoVoucher, receivables)
+	require.NoError(t, err)
+
+	require.Len(t, fifoResult.Allocations, 2)
+	require.Len(t, lifoResult.Allocations, 2)
+
+	// FIFO clears the oldest receivable first
+	assert.Equal(t, "AR-OLD", fifoResult.Allocations[0].TargetNumber)
+	assert.True(t, fifoResult.Allocations[0].Amount.Equal(decimal.NewFromInt(600)))
+	assert.Equal(t, "AR-NEW", fifoResult.Allocations[1].TargetNumber)
+	assert.True(t, fifoResult.Allocations[1].Amount.Equal(decimal.NewFromInt(200)))
+
+	// LIFO clears the newest receivable first
+	assert.Equal(t, "AR-NEW", lifoResult.Allocations[0].TargetNumber)
+	assert.True(t, lifoResult.Allocations[0].Amount.Equal(decimal.NewFromInt(600)))
+	assert.Equal(t, "AR-OLD", lifoResult.Allocations[1].TargetNumber)
+	assert.True(t, lifoResult.Allocations[1].Amount.Equal(decimal.NewFromInt(200)))
 }
 
 func TestAllocationResult(t *testing.T) {
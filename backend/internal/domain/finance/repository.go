@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -12,17 +13,25 @@ import (
 // AccountReceivableFilter defines filtering options for receivable queries
 type AccountReceivableFilter struct {
 	shared.Filter
-	CustomerID *uuid.UUID        // Filter by customer
-	Status     *ReceivableStatus // Filter by status
-	SourceType *SourceType       // Filter by source type
-	SourceID   *uuid.UUID        // Filter by source document
-	FromDate   *time.Time        // Filter by creation date range start
-	ToDate     *time.Time        // Filter by creation date range end
-	DueFrom    *time.Time        // Filter by due date range start
-	DueTo      *time.Time        // Filter by due date range end
-	Overdue    *bool             // Filter only overdue receivables
-	MinAmount  *decimal.Decimal  // Filter by minimum outstanding amount
-	MaxAmount  *decimal.Decimal  // Filter by maximum outstanding amount
+	CustomerID     *uuid.UUID        // Filter by customer
+	Status         *ReceivableStatus // Filter by status
+	SourceType     *SourceType       // Filter by source type
+	SourceID       *uuid.UUID        // Filter by source document
+	ShipmentNumber *int              // Filter by shipment number of the source sales order
+	FromDate       *time.Time        // Filter by creation date range start
+	ToDate         *time.Time        // Filter by creation date range end
+	DueFrom        *time.Time        // Filter by due date range start
+	DueTo          *time.Time        // Filter by due date range end
+	Overdue        *bool             // Filter only overdue receivables
+	MinAmount      *decimal.Decimal  // Filter by minimum outstanding amount
+	MaxAmount      *decimal.Decimal  // Filter by maximum outstanding amount
+}
+
+// ReceivablePaymentRecord is a payment record of a receivable together with
+// the number of the receipt voucher it originated from
+type ReceivablePaymentRecord struct {
+	PaymentRecord
+	ReceiptVoucherNumber string // Empty if the receipt voucher no longer exists
 }
 
 // AccountReceivableRepository defines the interface for account receivable persistence
@@ -54,6 +63,10 @@ type AccountReceivableRepository interface {
 	// FindOverdue finds all overdue receivables for a tenant
 	FindOverdue(ctx context.Context, tenantID uuid.UUID, filter AccountReceivableFilter) ([]AccountReceivable, error)
 
+	// FindDueForReminder finds outstanding receivables across all tenants that are due on or before
+	// dueBefore and have not yet received an overdue reminder
+	FindDueForReminder(ctx context.Context, dueBefore time.Time) ([]AccountReceivable, error)
+
 	// Save creates or updates an account receivable
 	Save(ctx context.Context, receivable *AccountReceivable) error
 
@@ -85,12 +98,26 @@ type AccountReceivableRepository interface {
 	// SumOutstandingByCustomer calculates total outstanding amount for a customer
 	SumOutstandingByCustomer(ctx context.Context, tenantID, customerID uuid.UUID) (decimal.Decimal, error)
 
+	// SumOutstandingByCustomerByCurrency calculates outstanding amounts for a customer grouped by currency
+	SumOutstandingByCustomerByCurrency(ctx context.Context, tenantID, customerID uuid.UUID) (map[valueobject.Currency]decimal.Decimal, error)
+
 	// SumOutstandingForTenant calculates total outstanding amount for a tenant
 	SumOutstandingForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error)
 
 	// SumOverdueForTenant calculates total overdue amount for a tenant
 	SumOverdueForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error)
 
+	// FindAgingEntries returns due date, outstanding amount and currency of all outstanding receivables for aging analysis
+	FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]AgingEntry, error)
+
+	// ListPaymentRecords returns a page of payment records of a receivable, newest first,
+	// and the total number of records. Returns shared.ErrNotFound if the receivable
+	// does not belong to the tenant.
+	ListPaymentRecords(ctx context.Context, tenantID, receivableID uuid.UUID, page, pageSize int) ([]ReceivablePaymentRecord, int64, error)
+
+	// SumWrittenOffForTenant calculates total amount written off as bad debt for a tenant
+	SumWrittenOffForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error)
+
 	// ExistsByReceivableNumber checks if a receivable number exists for a tenant
 	ExistsByReceivableNumber(ctx context.Context, tenantID uuid.UUID, receivableNumber string) (bool, error)
 
@@ -174,6 +201,10 @@ type AccountPayableRepository interface {
 	// Used for validation before supplier deletion
 	CountOutstandingBySupplier(ctx context.Context, tenantID, supplierID uuid.UUID) (int64, error)
 
+	// UpdateSupplierName refreshes the denormalized supplier name on all payables of a supplier
+	// Returns the number of payables updated
+	UpdateSupplierName(ctx context.Context, tenantID, supplierID uuid.UUID, supplierName string) (int64, error)
+
 	// SumOutstandingBySupplier calculates total outstanding amount for a supplier
 	SumOutstandingBySupplier(ctx context.Context, tenantID, supplierID uuid.UUID) (decimal.Decimal, error)
 
@@ -183,6 +214,9 @@ type AccountPayableRepository interface {
 	// SumOverdueForTenant calculates total overdue amount for a tenant
 	SumOverdueForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error)
 
+	// FindAgingEntries returns due date, outstanding amount and currency of all outstanding payables for aging analysis
+	FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]AgingEntry, error)
+
 	// ExistsByPayableNumber checks if a payable number exists for a tenant
 	ExistsByPayableNumber(ctx context.Context, tenantID uuid.UUID, payableNumber string) (bool, error)
 
@@ -723,3 +757,15 @@ type RefundRecordRepository interface {
 	// GenerateRefundNumber generates a unique refund number for a tenant
 	GenerateRefundNumber(ctx context.Context, tenantID uuid.UUID) (string, error)
 }
+
+// VoucherIdempotencyKeyRepository defines the interface for voucher idempotency key persistence
+type VoucherIdempotencyKeyRepository interface {
+	// FindByIdempotencyKey finds the unexpired key of the given voucher type for a tenant.
+	// Returns shared.ErrNotFound if there is none.
+	FindByIdempotencyKey(ctx context.Context, tenantID uuid.UUID, voucherType IdempotentVoucherType, key string) (*VoucherIdempotencyKey, error)
+
+	// Create stores a new key, replacing an expired one with the same value.
+	// Returns shared.ErrAlreadyExists if an unexpired key exists. If another transaction
+	// is creating the same key, Create waits for it to finish before deciding.
+	Create(ctx context.Context, key *VoucherIdempotencyKey) error
+}
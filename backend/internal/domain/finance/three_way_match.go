@@ -0,0 +1,205 @@
+package finance
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MatchStatus is the outcome of a three-way match
+type MatchStatus string
+
+const (
+	MatchStatusMatched    MatchStatus = "MATCHED"    // All legs agree within tolerance
+	MatchStatusMismatched MatchStatus = "MISMATCHED" // At least one discrepancy exceeds the tolerance
+)
+
+// MatchDiscrepancyType identifies which leg of the three-way match disagrees
+type MatchDiscrepancyType string
+
+const (
+	// MatchDiscrepancyQuantity means more was received on a line than was ordered
+	MatchDiscrepancyQuantity MatchDiscrepancyType = "QUANTITY"
+	// MatchDiscrepancyPrice means the goods were booked at a different value than the ordered price
+	MatchDiscrepancyPrice MatchDiscrepancyType = "PRICE"
+	// MatchDiscrepancyInvoice means the invoice differs from the value of the goods received at the ordered price
+	MatchDiscrepancyInvoice MatchDiscrepancyType = "INVOICE"
+)
+
+// MatchTolerance is how far the legs of a three-way match may differ before they are flagged.
+// A difference is within tolerance if it does not exceed the larger of Percent of the
+// expected value and Amount.
+type MatchTolerance struct {
+	Percent decimal.Decimal `json:"percent"` // Allowed difference as a percentage of the expected value
+	Amount  decimal.Decimal `json:"amount"`  // Allowed absolute difference (amounts only)
+}
+
+// DefaultMatchTolerance returns the tolerance used when none is configured: 1% or 1.00, whichever is larger
+func DefaultMatchTolerance() MatchTolerance {
+	return MatchTolerance{
+		Percent: decimal.NewFromInt(1),
+		Amount:  decimal.NewFromInt(1),
+	}
+}
+
+// allowedAmount returns the largest amount difference accepted for the expected amount
+func (t MatchTolerance) allowedAmount(expected decimal.Decimal) decimal.Decimal {
+	return decimal.Max(t.allowedQuantity(expected), t.Amount)
+}
+
+// allowedQuantity returns the largest quantity difference accepted for the expected quantity
+func (t MatchTolerance) allowedQuantity(expected decimal.Decimal) decimal.Decimal {
+	return expected.Abs().Mul(t.Percent).Div(decimal.NewFromInt(100)).Round(4)
+}
+
+// ThreeWayMatchLine is one purchase order line as seen by the three-way match
+type ThreeWayMatchLine struct {
+	ProductID        uuid.UUID       `json:"product_id"`
+	ProductName      string          `json:"product_name"`
+	OrderedQuantity  decimal.Decimal `json:"ordered_quantity"`
+	ReceivedQuantity decimal.Decimal `json:"received_quantity"`
+	UnitCost         decimal.Decimal `json:"unit_cost"` // Ordered price
+}
+
+// ThreeWayMatchInput holds the purchase order, goods receipt and invoice figures to match
+type ThreeWayMatchInput struct {
+	Lines         []ThreeWayMatchLine
+	OrderTotal    decimal.Decimal // Order value before the order-level discount
+	OrderPayable  decimal.Decimal // Order value after the order-level discount
+	BookedAmount  decimal.Decimal // Amount booked on the payable from goods receipts
+	InvoiceAmount decimal.Decimal // Amount on the supplier invoice
+	Tolerance     MatchTolerance
+}
+
+// MatchDiscrepancy describes one difference found by the three-way match
+type MatchDiscrepancy struct {
+	Type        MatchDiscrepancyType `json:"type"`
+	ProductID   *uuid.UUID           `json:"product_id,omitempty"` // Set for line-level discrepancies
+	ProductName string               `json:"product_name,omitempty"`
+	Expected    decimal.Decimal      `json:"expected"`
+	Actual      decimal.Decimal      `json:"actual"`
+	Difference  decimal.Decimal      `json:"difference"` // Actual - Expected
+	Allowed     decimal.Decimal      `json:"allowed"`    // Largest difference within tolerance
+}
+
+// ThreeWayMatchResult is the outcome of matching a purchase order, its goods receipts and the supplier invoice.
+// It implements GORM Scanner/Valuer for JSONB storage.
+type ThreeWayMatchResult struct {
+	Status         MatchStatus         `json:"status"`
+	OrderedAmount  decimal.Decimal     `json:"ordered_amount"`  // Full order at ordered prices, after discount
+	ReceivedAmount decimal.Decimal     `json:"received_amount"` // Received quantities at ordered prices, after discount
+	BookedAmount   decimal.Decimal     `json:"booked_amount"`
+	InvoiceAmount  decimal.Decimal     `json:"invoice_amount"`
+	Tolerance      MatchTolerance      `json:"tolerance"`
+	Lines          []ThreeWayMatchLine `json:"lines"`
+	Discrepancies  []MatchDiscrepancy  `json:"discrepancies"`
+	MatchedAt      time.Time           `json:"matched_at"`
+}
+
+// IsMatched returns true if no discrepancy exceeded the tolerance
+func (r *ThreeWayMatchResult) IsMatched() bool {
+	return r.Status == MatchStatusMatched
+}
+
+// Value implements driver.Valuer interface for GORM to write to JSONB
+func (r ThreeWayMatchResult) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements sql.Scanner interface for GORM to read from JSONB
+func (r *ThreeWayMatchResult) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan ThreeWayMatchResult: unsupported type")
+	}
+
+	if len(bytes) == 0 {
+		return nil
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// ThreeWayMatch compares the ordered price, the received quantities and the invoiced amount.
+// The expected amount is the received quantity of each line at its ordered price, with the
+// order-level discount applied proportionally as it is for goods receipts. Lines received
+// beyond their ordered quantity, a booked amount that differs from the expected amount
+// (goods received at a different price), and an invoice that differs from the expected
+// amount are flagged when the difference exceeds the tolerance.
+func ThreeWayMatch(input ThreeWayMatchInput) *ThreeWayMatchResult {
+	result := &ThreeWayMatchResult{
+		Status:        MatchStatusMatched,
+		BookedAmount:  input.BookedAmount,
+		InvoiceAmount: input.InvoiceAmount,
+		Tolerance:     input.Tolerance,
+		Lines:         input.Lines,
+		Discrepancies: make([]MatchDiscrepancy, 0),
+		MatchedAt:     time.Now(),
+	}
+
+	orderedValue, receivedValue := decimal.Zero, decimal.Zero
+	for _, line := range input.Lines {
+		orderedValue = orderedValue.Add(line.OrderedQuantity.Mul(line.UnitCost))
+		receivedValue = receivedValue.Add(line.ReceivedQuantity.Mul(line.UnitCost))
+
+		overReceived := line.ReceivedQuantity.Sub(line.OrderedQuantity)
+		if allowed := input.Tolerance.allowedQuantity(line.OrderedQuantity); overReceived.GreaterThan(allowed) {
+			productID := line.ProductID
+			result.Discrepancies = append(result.Discrepancies, MatchDiscrepancy{
+				Type:        MatchDiscrepancyQuantity,
+				ProductID:   &productID,
+				ProductName: line.ProductName,
+				Expected:    line.OrderedQuantity,
+				Actual:      line.ReceivedQuantity,
+				Difference:  overReceived,
+				Allowed:     allowed,
+			})
+		}
+	}
+	result.OrderedAmount = applyOrderDiscount(orderedValue, input.OrderTotal, input.OrderPayable)
+	result.ReceivedAmount = applyOrderDiscount(receivedValue, input.OrderTotal, input.OrderPayable)
+
+	result.addAmountDiscrepancy(MatchDiscrepancyPrice, input.BookedAmount)
+	result.addAmountDiscrepancy(MatchDiscrepancyInvoice, input.InvoiceAmount)
+
+	if len(result.Discrepancies) > 0 {
+		result.Status = MatchStatusMismatched
+	}
+	return result
+}
+
+// addAmountDiscrepancy flags actual if it differs from the received amount by more than the tolerance
+func (r *ThreeWayMatchResult) addAmountDiscrepancy(discrepancyType MatchDiscrepancyType, actual decimal.Decimal) {
+	difference := actual.Sub(r.ReceivedAmount)
+	allowed := r.Tolerance.allowedAmount(r.ReceivedAmount)
+	if difference.Abs().LessThanOrEqual(allowed) {
+		return
+	}
+	r.Discrepancies = append(r.Discrepancies, MatchDiscrepancy{
+		Type:       discrepancyType,
+		Expected:   r.ReceivedAmount,
+		Actual:     actual,
+		Difference: difference,
+		Allowed:    allowed,
+	})
+}
+
+// applyOrderDiscount scales value by the order's payable ratio, rounded to two decimal places
+func applyOrderDiscount(value, orderTotal, orderPayable decimal.Decimal) decimal.Decimal {
+	if orderTotal.IsZero() || orderPayable.Equal(orderTotal) {
+		return value.Round(2)
+	}
+	return value.Mul(orderPayable).Div(orderTotal).Round(2)
+}
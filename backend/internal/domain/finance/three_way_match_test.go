@@ -0,0 +1,284 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMatchInput returns a fully received order of 10 x 50.00 and 4 x 125.00 (1000.00),
+// booked on the payable at the ordered prices, with the given invoice amount
+func newMatchInput(invoiceAmount float64) ThreeWayMatchInput {
+	return ThreeWayMatchInput{
+		Lines: []ThreeWayMatchLine{
+			{ProductID: uuid.New(), ProductName: "Widget", OrderedQuantity: decimal.NewFromInt(10), ReceivedQuantity: decimal.NewFromInt(10), UnitCost: decimal.NewFromInt(50)},
+			{ProductID: uuid.New(), ProductName: "Gadget", OrderedQuantity: decimal.NewFromInt(4), ReceivedQuantity: decimal.NewFromInt(4), UnitCost: decimal.NewFromInt(125)},
+		},
+		OrderTotal:    decimal.NewFromInt(1000),
+		OrderPayable:  decimal.NewFromInt(1000),
+		BookedAmount:  decimal.NewFromInt(1000),
+		InvoiceAmount: decimal.NewFromFloat(invoiceAmount),
+		Tolerance:     DefaultMatchTolerance(),
+	}
+}
+
+func TestThreeWayMatch(t *testing.T) {
+	t.Run("exact match", func(t *testing.T) {
+		result := ThreeWayMatch(newMatchInput(1000))
+
+		assert.True(t, result.IsMatched())
+		assert.Equal(t, MatchStatusMatched, result.Status)
+		assert.True(t, decimal.NewFromInt(1000).Equal(result.OrderedAmount))
+		assert.True(t, decimal.NewFromInt(1000).Equal(result.ReceivedAmount))
+		assert.Empty(t, result.Discrepancies)
+		assert.Len(t, result.Lines, 2)
+	})
+
+	t.Run("invoice within tolerance matches", func(t *testing.T) {
+		// 1% of 1000.00 allows up to 10.00 either way
+		for _, amount := range []float64{1010, 990, 1004.5} {
+			result := ThreeWayMatch(newMatchInput(amount))
+			assert.True(t, result.IsMatched(), "invoice %.2f should match", amount)
+			assert.Empty(t, result.Discrepancies)
+		}
+	})
+
+	t.Run("invoice over tolerance is flagged", func(t *testing.T) {
+		result := ThreeWayMatch(newMatchInput(1010.01))
+
+		assert.False(t, result.IsMatched())
+		assert.Equal(t, MatchStatusMismatched, result.Status)
+		require.Len(t, result.Discrepancies, 1)
+		d := result.Discrepancies[0]
+		assert.Equal(t, MatchDiscrepancyInvoice, d.Type)
+		assert.Nil(t, d.ProductID)
+		assert.True(t, decimal.NewFromInt(1000).Equal(d.Expected))
+		assert.True(t, decimal.NewFromFloat(1010.01).Equal(d.Actual))
+		assert.True(t, decimal.NewFromFloat(10.01).Equal(d.Difference))
+		assert.True(t, decimal.NewFromInt(10).Equal(d.Allowed))
+	})
+
+	t.Run("absolute tolerance applies to small amounts", func(t *testing.T) {
+		input := newMatchInput(0)
+		input.Lines = input.Lines[:1]
+		input.Lines[0].OrderedQuantity = decimal.NewFromInt(1)
+		input.Lines[0].ReceivedQuantity = decimal.NewFromInt(1)
+		input.OrderTotal, input.OrderPayable, input.BookedAmount = decimal.NewFromInt(50), decimal.NewFromInt(50), decimal.NewFromInt(50)
+
+		// 1% of 50.00 is 0.50, but differences up to 1.00 are accepted
+		input.InvoiceAmount = decimal.NewFromInt(51)
+		assert.True(t, ThreeWayMatch(input).IsMatched())
+
+		input.InvoiceAmount = decimal.NewFromFloat(51.01)
+		assert.False(t, ThreeWayMatch(input).IsMatched())
+	})
+
+	t.Run("invoice for goods not yet received is flagged", func(t *testing.T) {
+		input := newMatchInput(1000)
+		input.Lines[1].ReceivedQuantity = decimal.NewFromInt(2)
+		input.BookedAmount = decimal.NewFromInt(750)
+
+		result := ThreeWayMatch(input)
+
+		assert.False(t, result.IsMatched())
+		assert.True(t, decimal.NewFromInt(1000).Equal(result.OrderedAmount))
+		assert.True(t, decimal.NewFromInt(750).Equal(result.ReceivedAmount))
+		require.Len(t, result.Discrepancies, 1)
+		assert.Equal(t, MatchDiscrepancyInvoice, result.Discrepancies[0].Type)
+		assert.True(t, decimal.NewFromInt(250).Equal(result.Discrepancies[0].Difference))
+	})
+
+	t.Run("goods booked above the ordered price are flagged", func(t *testing.T) {
+		input := newMatchInput(1000)
+		input.BookedAmount = decimal.NewFromInt(1100)
+
+		result := ThreeWayMatch(input)
+
+		assert.False(t, result.IsMatched())
+		require.Len(t, result.Discrepancies, 1)
+		assert.Equal(t, MatchDiscrepancyPrice, result.Discrepancies[0].Type)
+		assert.True(t, decimal.NewFromInt(1100).Equal(result.Discrepancies[0].Actual))
+	})
+
+	t.Run("over-received line is flagged", func(t *testing.T) {
+		input := newMatchInput(1500)
+		input.Lines[0].ReceivedQuantity = decimal.NewFromInt(20)
+		input.BookedAmount = decimal.NewFromInt(1500)
+
+		result := ThreeWayMatch(input)
+
+		assert.False(t, result.IsMatched())
+		require.Len(t, result.Discrepancies, 1)
+		d := result.Discrepancies[0]
+		assert.Equal(t, MatchDiscrepancyQuantity, d.Type)
+		require.NotNil(t, d.ProductID)
+		assert.Equal(t, input.Lines[0].ProductID, *d.ProductID)
+		assert.Equal(t, "Widget", d.ProductName)
+		assert.True(t, decimal.NewFromInt(10).Equal(d.Difference))
+	})
+
+	t.Run("order discount is applied to the expected amount", func(t *testing.T) {
+		input := newMatchInput(900)
+		input.OrderPayable = decimal.NewFromInt(900)
+		input.BookedAmount = decimal.NewFromInt(900)
+
+		result := ThreeWayMatch(input)
+
+		assert.True(t, result.IsMatched())
+		assert.True(t, decimal.NewFromInt(900).Equal(result.ReceivedAmount))
+	})
+}
+
+func TestThreeWayMatchResult_ValueScan(t *testing.T) {
+	result := ThreeWayMatch(newMatchInput(1100))
+
+	value, err := result.Value()
+	require.NoError(t, err)
+
+	var scanned ThreeWayMatchResult
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, MatchStatusMismatched, scanned.Status)
+	assert.Len(t, scanned.Lines, 2)
+	require.Len(t, scanned.Discrepancies, 1)
+	assert.True(t, result.Discrepancies[0].Difference.Equal(scanned.Discrepancies[0].Difference))
+}
+
+func TestAccountPayable_RecordInvoice(t *testing.T) {
+	invoiceDate := time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC)
+
+	t.Run("records invoice on purchase order payable", func(t *testing.T) {
+		ap := createTestPayable(t, 1000)
+
+		err := ap.RecordInvoice("INV-001", valueobject.NewMoneyCNYFromFloat(1000), invoiceDate)
+
+		require.NoError(t, err)
+		assert.True(t, ap.HasInvoice())
+		assert.Equal(t, "INV-001", ap.InvoiceNumber)
+		assert.True(t, decimal.NewFromInt(1000).Equal(ap.InvoiceAmount))
+		assert.Equal(t, &invoiceDate, ap.InvoiceDate)
+		assert.Equal(t, PayableStatusPending, ap.Status)
+	})
+
+	t.Run("rejects payables not sourced from a purchase order", func(t *testing.T) {
+		ap, err := NewAccountPayable(uuid.New(), "AP-TEST-00002", uuid.New(), "Test Supplier",
+			PayableSourceTypeManual, uuid.New(), "MAN-001", valueobject.NewMoneyCNYFromFloat(100), nil)
+		require.NoError(t, err)
+
+		err = ap.RecordInvoice("INV-001", valueobject.NewMoneyCNYFromFloat(100), invoiceDate)
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "INVALID_SOURCE_TYPE", domainErr.Code)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		ap := createTestPayable(t, 1000)
+
+		assert.Error(t, ap.RecordInvoice("", valueobject.NewMoneyCNYFromFloat(1000), invoiceDate))
+		assert.Error(t, ap.RecordInvoice("INV-001", valueobject.NewMoneyCNYFromFloat(0), invoiceDate))
+		assert.False(t, ap.HasInvoice())
+	})
+
+	t.Run("rejects paid payable", func(t *testing.T) {
+		ap := createTestPayable(t, 1000)
+		require.NoError(t, ap.ApplyPayment(valueobject.NewMoneyCNYFromFloat(1000), uuid.New(), ""))
+
+		err := ap.RecordInvoice("INV-001", valueobject.NewMoneyCNYFromFloat(1000), invoiceDate)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAccountPayable_ApplyMatchResult(t *testing.T) {
+	newInvoicedPayable := func(t *testing.T) *AccountPayable {
+		ap := createTestPayable(t, 1000)
+		require.NoError(t, ap.RecordInvoice("INV-001", valueobject.NewMoneyCNYFromFloat(1200), time.Now()))
+		ap.ClearDomainEvents()
+		return ap
+	}
+
+	t.Run("requires a recorded invoice", func(t *testing.T) {
+		ap := createTestPayable(t, 1000)
+
+		err := ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1000)))
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "NO_INVOICE", domainErr.Code)
+	})
+
+	t.Run("mismatch blocks payment", func(t *testing.T) {
+		ap := newInvoicedPayable(t)
+
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1200))))
+
+		assert.Equal(t, PayableStatusMatchFailed, ap.Status)
+		assert.True(t, ap.IsMatchFailed())
+		require.NotNil(t, ap.MatchResult)
+		assert.False(t, ap.MatchResult.IsMatched())
+		events := ap.GetDomainEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, "AccountPayableMatchFailed", events[0].EventType())
+
+		err := ap.ApplyPayment(valueobject.NewMoneyCNYFromFloat(100), uuid.New(), "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot apply payment")
+		assert.True(t, ap.PaidAmount.IsZero())
+	})
+
+	t.Run("successful rematch releases the payable", func(t *testing.T) {
+		ap := newInvoicedPayable(t)
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1200))))
+
+		require.NoError(t, ap.RecordInvoice("INV-001-R", valueobject.NewMoneyCNYFromFloat(1000), time.Now()))
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1000))))
+
+		assert.Equal(t, PayableStatusPending, ap.Status)
+		assert.True(t, ap.MatchResult.IsMatched())
+		assert.NoError(t, ap.ApplyPayment(valueobject.NewMoneyCNYFromFloat(100), uuid.New(), ""))
+	})
+
+	t.Run("released payable with earlier payments is partial", func(t *testing.T) {
+		ap := createTestPayable(t, 1000)
+		require.NoError(t, ap.ApplyPayment(valueobject.NewMoneyCNYFromFloat(300), uuid.New(), ""))
+		require.NoError(t, ap.RecordInvoice("INV-001", valueobject.NewMoneyCNYFromFloat(1200), time.Now()))
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1200))))
+		require.Equal(t, PayableStatusMatchFailed, ap.Status)
+
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1000))))
+
+		assert.Equal(t, PayableStatusPartial, ap.Status)
+	})
+
+	t.Run("increased amount requires a rematch before payment", func(t *testing.T) {
+		ap := newInvoicedPayable(t)
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1000))))
+		require.Equal(t, PayableStatusPending, ap.Status)
+
+		require.NoError(t, ap.IncreaseAmount(uuid.New(), valueobject.NewMoneyCNYFromFloat(200)))
+
+		assert.Nil(t, ap.MatchResult)
+		err := ap.ApplyPayment(valueobject.NewMoneyCNYFromFloat(100), uuid.New(), "")
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "MATCH_REQUIRED", domainErr.Code)
+		assert.True(t, ap.PaidAmount.IsZero())
+
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1000))))
+		assert.NoError(t, ap.ApplyPayment(valueobject.NewMoneyCNYFromFloat(100), uuid.New(), ""))
+	})
+
+	t.Run("match failed payable can still be cancelled", func(t *testing.T) {
+		ap := newInvoicedPayable(t)
+		require.NoError(t, ap.ApplyMatchResult(ThreeWayMatch(newMatchInput(1200))))
+
+		assert.NoError(t, ap.Cancel("Supplier invoice disputed"))
+		assert.Equal(t, PayableStatusCancelled, ap.Status)
+	})
+}
@@ -0,0 +1,74 @@
+package finance
+
+import (
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// DefaultVoucherIdempotencyTTL is how long an idempotency key replays the voucher it created
+const DefaultVoucherIdempotencyTTL = 24 * time.Hour
+
+// MaxIdempotencyKeyLength is the maximum length of a client-supplied idempotency key
+const MaxIdempotencyKeyLength = 255
+
+// IdempotentVoucherType identifies which kind of voucher an idempotency key created
+type IdempotentVoucherType string
+
+const (
+	IdempotentVoucherTypeReceipt IdempotentVoucherType = "RECEIPT"
+	IdempotentVoucherTypePayment IdempotentVoucherType = "PAYMENT"
+)
+
+// VoucherIdempotencyKey records the voucher created for a client-supplied idempotency key,
+// so that a retried create request returns the original voucher instead of a duplicate
+type VoucherIdempotencyKey struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	VoucherType IdempotentVoucherType
+	Key         string
+	VoucherID   uuid.UUID
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// NewVoucherIdempotencyKey creates an idempotency key record valid for ttl
+func NewVoucherIdempotencyKey(tenantID uuid.UUID, voucherType IdempotentVoucherType, key string, voucherID uuid.UUID, ttl time.Duration) (*VoucherIdempotencyKey, error) {
+	if err := ValidateIdempotencyKey(key); err != nil {
+		return nil, err
+	}
+	if voucherID == uuid.Nil {
+		return nil, shared.NewDomainError("INVALID_VOUCHER", "Voucher ID cannot be empty")
+	}
+	if ttl <= 0 {
+		ttl = DefaultVoucherIdempotencyTTL
+	}
+
+	now := time.Now()
+	return &VoucherIdempotencyKey{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		VoucherType: voucherType,
+		Key:         key,
+		VoucherID:   voucherID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// ValidateIdempotencyKey checks that a client-supplied idempotency key is usable
+func ValidateIdempotencyKey(key string) error {
+	if key == "" {
+		return shared.NewDomainError("INVALID_INPUT", "Idempotency key cannot be empty")
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return shared.NewDomainError("INVALID_INPUT", "Idempotency key cannot exceed 255 characters")
+	}
+	return nil
+}
+
+// IsExpired returns true if the key no longer replays its voucher
+func (k *VoucherIdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
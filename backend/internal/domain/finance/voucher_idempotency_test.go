@@ -0,0 +1,58 @@
+package finance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVoucherIdempotencyKey(t *testing.T) {
+	tenantID := uuid.New()
+	voucherID := uuid.New()
+
+	t.Run("creates key with ttl", func(t *testing.T) {
+		key, err := NewVoucherIdempotencyKey(tenantID, IdempotentVoucherTypeReceipt, "retry-1", voucherID, time.Hour)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, uuid.Nil, key.ID)
+		assert.Equal(t, tenantID, key.TenantID)
+		assert.Equal(t, IdempotentVoucherTypeReceipt, key.VoucherType)
+		assert.Equal(t, "retry-1", key.Key)
+		assert.Equal(t, voucherID, key.VoucherID)
+		assert.Equal(t, time.Hour, key.ExpiresAt.Sub(key.CreatedAt))
+	})
+
+	t.Run("defaults non-positive ttl", func(t *testing.T) {
+		key, err := NewVoucherIdempotencyKey(tenantID, IdempotentVoucherTypePayment, "retry-1", voucherID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultVoucherIdempotencyTTL, key.ExpiresAt.Sub(key.CreatedAt))
+	})
+
+	t.Run("rejects empty key", func(t *testing.T) {
+		_, err := NewVoucherIdempotencyKey(tenantID, IdempotentVoucherTypeReceipt, "", voucherID, time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects overlong key", func(t *testing.T) {
+		_, err := NewVoucherIdempotencyKey(tenantID, IdempotentVoucherTypeReceipt, strings.Repeat("k", MaxIdempotencyKeyLength+1), voucherID, time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects empty voucher ID", func(t *testing.T) {
+		_, err := NewVoucherIdempotencyKey(tenantID, IdempotentVoucherTypeReceipt, "retry-1", uuid.Nil, time.Hour)
+		assert.Error(t, err)
+	})
+}
+
+func TestVoucherIdempotencyKey_IsExpired(t *testing.T) {
+	key, err := NewVoucherIdempotencyKey(uuid.New(), IdempotentVoucherTypeReceipt, "retry-1", uuid.New(), time.Hour)
+	require.NoError(t, err)
+
+	assert.False(t, key.IsExpired(key.CreatedAt))
+	assert.False(t, key.IsExpired(key.ExpiresAt.Add(-time.Second)))
+	assert.True(t, key.IsExpired(key.ExpiresAt))
+}
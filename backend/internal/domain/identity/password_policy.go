@@ -0,0 +1,196 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// Password policy limits
+const (
+	// MinPasswordLength is the lowest minimum length a policy may set
+	MinPasswordLength = 6
+	// MaxPasswordLength is the maximum length of any password
+	MaxPasswordLength = 128
+	// MaxPasswordHistoryCount is the largest number of previous passwords a policy may forbid reusing.
+	// Every remembered password costs a bcrypt comparison on each password change.
+	MaxPasswordHistoryCount = 12
+)
+
+// ErrPasswordPolicyViolation is returned when a new password does not satisfy the tenant's password policy.
+// Use errors.Is to detect it and errors.As with *PasswordPolicyError to get the failed rules.
+var ErrPasswordPolicyViolation = shared.NewDomainError("PASSWORD_POLICY_VIOLATION", "Password does not satisfy the password policy")
+
+// PasswordRule identifies a rule of a password policy
+type PasswordRule string
+
+const (
+	PasswordRuleMinLength PasswordRule = "MIN_LENGTH"
+	PasswordRuleMaxLength PasswordRule = "MAX_LENGTH"
+	PasswordRuleLetter    PasswordRule = "LETTER"
+	PasswordRuleUppercase PasswordRule = "UPPERCASE"
+	PasswordRuleLowercase PasswordRule = "LOWERCASE"
+	PasswordRuleDigit     PasswordRule = "DIGIT"
+	PasswordRuleSpecial   PasswordRule = "SPECIAL"
+	PasswordRuleReuse     PasswordRule = "REUSE"
+)
+
+// PasswordPolicy is a tenant's rules for user passwords
+type PasswordPolicy struct {
+	MinLength        int  `json:"min_length"`
+	RequireLetter    bool `json:"require_letter"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSpecial   bool `json:"require_special"`
+	// HistoryCount forbids reusing the current and the last HistoryCount-1 previous passwords (0 allows reuse)
+	HistoryCount int `json:"history_count"`
+	// MaxAgeDays is the number of days after which a password must be changed (0 never expires)
+	MaxAgeDays int `json:"max_age_days"`
+}
+
+// DefaultPasswordPolicy returns the password policy of tenants that have not configured one:
+// at least 8 characters with a letter and a digit
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:     8,
+		RequireLetter: true,
+		RequireDigit:  true,
+	}
+}
+
+// Validate checks that the policy itself is valid
+func (p PasswordPolicy) Validate() error {
+	if p.MinLength < MinPasswordLength || p.MinLength > MaxPasswordLength {
+		return shared.NewDomainError("INVALID_PASSWORD_POLICY",
+			fmt.Sprintf("Password minimum length must be between %d and %d", MinPasswordLength, MaxPasswordLength))
+	}
+	if p.HistoryCount < 0 || p.HistoryCount > MaxPasswordHistoryCount {
+		return shared.NewDomainError("INVALID_PASSWORD_POLICY",
+			fmt.Sprintf("Password history count must be between 0 and %d", MaxPasswordHistoryCount))
+	}
+	if p.MaxAgeDays < 0 {
+		return shared.NewDomainError("INVALID_PASSWORD_POLICY", "Password max age cannot be negative")
+	}
+	return nil
+}
+
+// PasswordRuleViolation describes a password policy rule a password fails
+type PasswordRuleViolation struct {
+	Rule    PasswordRule
+	Message string
+}
+
+// PasswordPolicyError lists the password policy rules a new password fails
+type PasswordPolicyError struct {
+	Violations []PasswordRuleViolation
+}
+
+// Error implements the error interface
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return "Password does not satisfy the password policy: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns ErrPasswordPolicyViolation so the error maps to the PASSWORD_POLICY_VIOLATION domain error code
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicyViolation
+}
+
+// Check returns the length and character class rules the password fails
+func (p PasswordPolicy) Check(password string) []PasswordRuleViolation {
+	var violations []PasswordRuleViolation
+
+	length := len([]rune(password))
+	if length < p.MinLength {
+		violations = append(violations, PasswordRuleViolation{
+			Rule:    PasswordRuleMinLength,
+			Message: fmt.Sprintf("Password must be at least %d characters", p.MinLength),
+		})
+	}
+	if length > MaxPasswordLength {
+		violations = append(violations, PasswordRuleViolation{
+			Rule:    PasswordRuleMaxLength,
+			Message: fmt.Sprintf("Password cannot exceed %d characters", MaxPasswordLength),
+		})
+	}
+
+	var hasLetter, hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+			hasUpper = hasUpper || unicode.IsUpper(r)
+			hasLower = hasLower || unicode.IsLower(r)
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	classes := []struct {
+		required bool
+		present  bool
+		rule     PasswordRule
+		message  string
+	}{
+		{p.RequireLetter, hasLetter, PasswordRuleLetter, "Password must contain a letter"},
+		{p.RequireUppercase, hasUpper, PasswordRuleUppercase, "Password must contain an uppercase letter"},
+		{p.RequireLowercase, hasLower, PasswordRuleLowercase, "Password must contain a lowercase letter"},
+		{p.RequireDigit, hasDigit, PasswordRuleDigit, "Password must contain a digit"},
+		{p.RequireSpecial, hasSpecial, PasswordRuleSpecial, "Password must contain a special character"},
+	}
+	for _, class := range classes {
+		if class.required && !class.present {
+			violations = append(violations, PasswordRuleViolation{Rule: class.rule, Message: class.message})
+		}
+	}
+
+	return violations
+}
+
+// IsExpired reports whether a password changed at changedAt has outlived the policy's max age
+func (p PasswordPolicy) IsExpired(changedAt *time.Time, now time.Time) bool {
+	if p.MaxAgeDays <= 0 || changedAt == nil {
+		return false
+	}
+	return now.After(changedAt.AddDate(0, 0, p.MaxAgeDays))
+}
+
+// PasswordHistoryEntry is a password a user had before changing it
+type PasswordHistoryEntry struct {
+	ID           uuid.UUID
+	TenantID     uuid.UUID
+	UserID       uuid.UUID
+	PasswordHash string
+	CreatedAt    time.Time // When the password was replaced
+}
+
+// NewPasswordHistoryEntry records the password hash a user is replacing
+func NewPasswordHistoryEntry(user *User, passwordHash string) *PasswordHistoryEntry {
+	return &PasswordHistoryEntry{
+		ID:           uuid.New(),
+		TenantID:     user.TenantID,
+		UserID:       user.ID,
+		PasswordHash: passwordHash,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// PasswordHistoryRepository defines the interface for password history persistence
+type PasswordHistoryRepository interface {
+	// Save records a previous password of a user
+	Save(ctx context.Context, entry *PasswordHistoryEntry) error
+
+	// FindRecentByUserID finds the most recent previous passwords of a user, most recent first
+	FindRecentByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]PasswordHistoryEntry, error)
+}
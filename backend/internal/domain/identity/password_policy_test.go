@@ -0,0 +1,126 @@
+package identity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passwordRules(t *testing.T, err error) []PasswordRule {
+	t.Helper()
+	var policyErr *PasswordPolicyError
+	require.True(t, errors.As(err, &policyErr))
+	rules := make([]PasswordRule, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		rules[i] = v.Rule
+	}
+	return rules
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+	}
+
+	t.Run("passes all rules", func(t *testing.T) {
+		assert.Empty(t, policy.Check("Str0ng!Password"))
+	})
+
+	t.Run("lists every failed rule", func(t *testing.T) {
+		violations := policy.Check("short")
+		rules := make([]PasswordRule, len(violations))
+		for i, v := range violations {
+			rules[i] = v.Rule
+		}
+		assert.Equal(t, []PasswordRule{
+			PasswordRuleMinLength,
+			PasswordRuleUppercase,
+			PasswordRuleDigit,
+			PasswordRuleSpecial,
+		}, rules)
+	})
+
+	t.Run("counts characters, not bytes", func(t *testing.T) {
+		violations := PasswordPolicy{MinLength: 8}.Check("密码密码密码1")
+		assert.Len(t, violations, 1)
+	})
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	assert.NoError(t, DefaultPasswordPolicy().Validate())
+	assert.Error(t, PasswordPolicy{MinLength: 4}.Validate())
+	assert.Error(t, PasswordPolicy{MinLength: 8, HistoryCount: MaxPasswordHistoryCount + 1}.Validate())
+	assert.Error(t, PasswordPolicy{MinLength: 8, MaxAgeDays: -1}.Validate())
+}
+
+func TestPasswordPolicy_IsExpired(t *testing.T) {
+	now := time.Now()
+	changedAt := now.AddDate(0, 0, -91)
+
+	assert.True(t, PasswordPolicy{MaxAgeDays: 90}.IsExpired(&changedAt, now))
+	assert.False(t, PasswordPolicy{MaxAgeDays: 120}.IsExpired(&changedAt, now))
+	assert.False(t, PasswordPolicy{}.IsExpired(&changedAt, now))
+	assert.False(t, PasswordPolicy{MaxAgeDays: 90}.IsExpired(nil, now))
+}
+
+func TestUser_SetPasswordWithPolicy(t *testing.T) {
+	tenantID := uuid.New()
+	policy := PasswordPolicy{MinLength: 12, RequireLetter: true, RequireDigit: true, HistoryCount: 3}
+
+	t.Run("fails min length", func(t *testing.T) {
+		user, _ := NewActiveUser(tenantID, "testuser", "Password123")
+		oldHash := user.PasswordHash
+
+		err := user.SetPasswordWithPolicy("Short1", policy, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrPasswordPolicyViolation))
+		assert.Equal(t, []PasswordRule{PasswordRuleMinLength}, passwordRules(t, err))
+		assert.Equal(t, oldHash, user.PasswordHash)
+	})
+
+	t.Run("fails reuse of a recent password", func(t *testing.T) {
+		user, _ := NewActiveUser(tenantID, "testuser", "FirstPassword1")
+		previousHash := user.PasswordHash
+		require.NoError(t, user.SetPassword("SecondPassword2"))
+
+		err := user.SetPasswordWithPolicy("FirstPassword1", policy, []string{previousHash})
+		require.Error(t, err)
+		assert.Equal(t, []PasswordRule{PasswordRuleReuse}, passwordRules(t, err))
+
+		err = user.SetPasswordWithPolicy("SecondPassword2", policy, []string{previousHash})
+		require.Error(t, err)
+		assert.Equal(t, []PasswordRule{PasswordRuleReuse}, passwordRules(t, err))
+	})
+
+	t.Run("passes all rules", func(t *testing.T) {
+		user, _ := NewActiveUser(tenantID, "testuser", "Password123")
+		user.ForcePasswordChange()
+
+		require.NoError(t, user.SetPasswordWithPolicy("BrandNewPassword9", policy, nil))
+		assert.True(t, user.VerifyPassword("BrandNewPassword9"))
+		assert.False(t, user.MustChangePassword)
+	})
+
+	t.Run("allows reuse when history is disabled", func(t *testing.T) {
+		user, _ := NewActiveUser(tenantID, "testuser", "Password1234")
+
+		require.NoError(t, user.SetPasswordWithPolicy("Password1234", PasswordPolicy{MinLength: 8}, nil))
+	})
+}
+
+func TestUser_ChangePasswordWithPolicy_WrongOldPassword(t *testing.T) {
+	user, _ := NewActiveUser(uuid.New(), "testuser", "Password123")
+
+	err := user.ChangePasswordWithPolicy("WrongPassword1", "NewPassword456", DefaultPasswordPolicy(), nil)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrPasswordPolicyViolation))
+	assert.Contains(t, err.Error(), "Current password is incorrect")
+}
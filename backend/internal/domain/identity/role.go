@@ -20,6 +20,15 @@ const (
 	DataScopeWarehouse  DataScopeType = "warehouse"  // Data within assigned warehouses (warehouse_id based)
 )
 
+// PermissionPlatformAdmin lets platform operators act on tenants other than their own.
+// It is granted by migration only; tenant administrators cannot assign it to roles.
+const PermissionPlatformAdmin = "platform:admin"
+
+// IsAssignablePermission returns false for permission codes that cannot be assigned to roles through the API
+func IsAssignablePermission(code string) bool {
+	return strings.ToLower(strings.TrimSpace(code)) != PermissionPlatformAdmin
+}
+
 // Permission represents a functional permission (resource:action pattern)
 // It is a value object
 type Permission struct {
@@ -158,6 +158,13 @@ func TestPermission_IsEmpty(t *testing.T) {
 	assert.True(t, perm2.IsEmpty())
 }
 
+func TestIsAssignablePermission(t *testing.T) {
+	assert.True(t, IsAssignablePermission("product:create"))
+	assert.True(t, IsAssignablePermission("tenant:export"))
+	assert.False(t, IsAssignablePermission(PermissionPlatformAdmin))
+	assert.False(t, IsAssignablePermission(" Platform:Admin "))
+}
+
 // DataScope Value Object Tests
 
 func TestNewDataScope(t *testing.T) {
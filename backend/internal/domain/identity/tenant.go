@@ -6,6 +6,7 @@ import (
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // TenantStatus represents the status of a tenant
@@ -35,10 +36,20 @@ type TenantConfig struct {
 	MaxProducts   int    `json:"max_products"`   // Maximum number of products
 	Features      string `json:"features"`       // JSON object of enabled features
 	Settings      string `json:"settings"`       // JSON object of tenant settings
-	CostStrategy  string `json:"cost_strategy"`  // Default cost calculation strategy (fifo, weighted_average)
+	CostStrategy  string `json:"cost_strategy"`  // Default cost calculation strategy (fifo, weighted_average, moving_weighted_average)
 	Currency      string `json:"currency"`       // Default currency code
 	Timezone      string `json:"timezone"`       // Tenant timezone
 	Locale        string `json:"locale"`         // Tenant locale (e.g., zh-CN, en-US)
+	// CreditControlEnabled enforces customer credit limits when shipping sales orders
+	CreditControlEnabled bool `json:"credit_control_enabled"`
+	// ExpenseApprovalThresholds are ascending expense amounts; each one exceeded requires an additional approver
+	ExpenseApprovalThresholds []decimal.Decimal `json:"expense_approval_thresholds"`
+	// IndustryPlugin names the industry plugin whose product attribute requirements apply to the tenant (empty for none)
+	IndustryPlugin string `json:"industry_plugin"`
+	// ShipExpiryBufferDays blocks shipping stock from batches that expire within this many days of the ship date
+	ShipExpiryBufferDays int `json:"ship_expiry_buffer_days"`
+	// PasswordPolicy is enforced when users change their passwords and when admins reset them
+	PasswordPolicy PasswordPolicy `json:"password_policy"`
 }
 
 // DefaultTenantConfig returns the default configuration for a new tenant
@@ -53,6 +64,9 @@ func DefaultTenantConfig() TenantConfig {
 		Currency:      "CNY",
 		Timezone:      "Asia/Shanghai",
 		Locale:        "zh-CN",
+
+		CreditControlEnabled: true,
+		PasswordPolicy:       DefaultPasswordPolicy(),
 	}
 }
 
@@ -274,6 +288,24 @@ func (t *Tenant) UpdateConfig(config TenantConfig) error {
 	if config.MaxProducts < 0 {
 		return shared.NewDomainError("INVALID_MAX_PRODUCTS", "Max products cannot be negative")
 	}
+	if config.ShipExpiryBufferDays < 0 {
+		return shared.NewDomainError("INVALID_SHIP_EXPIRY_BUFFER", "Ship expiry buffer days cannot be negative")
+	}
+	if config.PasswordPolicy == (PasswordPolicy{}) {
+		// An unset password policy falls back to the default one
+		config.PasswordPolicy = DefaultPasswordPolicy()
+	}
+	if err := config.PasswordPolicy.Validate(); err != nil {
+		return err
+	}
+	for i, threshold := range config.ExpenseApprovalThresholds {
+		if !threshold.IsPositive() {
+			return shared.NewDomainError("INVALID_EXPENSE_APPROVAL_THRESHOLDS", "Expense approval thresholds must be positive")
+		}
+		if i > 0 && !threshold.GreaterThan(config.ExpenseApprovalThresholds[i-1]) {
+			return shared.NewDomainError("INVALID_EXPENSE_APPROVAL_THRESHOLDS", "Expense approval thresholds must be in ascending order")
+		}
+	}
 
 	t.Config = config
 	t.UpdatedAt = time.Now()
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -454,6 +455,30 @@ func TestTenant_UpdateConfig(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Max products cannot be negative")
 	})
+
+	t.Run("fails with non-ascending expense approval thresholds", func(t *testing.T) {
+		tenant, _ := NewTenant("TENANT001", "Test Company")
+		config := TenantConfig{
+			ExpenseApprovalThresholds: []decimal.Decimal{decimal.NewFromInt(5000), decimal.NewFromInt(5000)},
+		}
+
+		err := tenant.UpdateConfig(config)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ascending order")
+	})
+
+	t.Run("fails with non-positive expense approval threshold", func(t *testing.T) {
+		tenant, _ := NewTenant("TENANT001", "Test Company")
+		config := TenantConfig{
+			ExpenseApprovalThresholds: []decimal.Decimal{decimal.Zero},
+		}
+
+		err := tenant.UpdateConfig(config)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be positive")
+	})
 }
 
 func TestTenant_SetAddress(t *testing.T) {
@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -178,6 +179,11 @@ func (u *User) SetPassword(newPassword string) error {
 		return err
 	}
 
+	return u.applyPassword(newPassword)
+}
+
+// applyPassword hashes and stores a validated new password
+func (u *User) applyPassword(newPassword string) error {
 	passwordHash, err := hashPassword(newPassword)
 	if err != nil {
 		return shared.NewDomainError("PASSWORD_HASH_ERROR", "Failed to hash password")
@@ -195,6 +201,54 @@ func (u *User) SetPassword(newPassword string) error {
 	return nil
 }
 
+// ChangePasswordWithPolicy changes the user's password, enforcing a tenant password policy.
+// See SetPasswordWithPolicy for previousHashes.
+func (u *User) ChangePasswordWithPolicy(oldPassword, newPassword string, policy PasswordPolicy, previousHashes []string) error {
+	if !u.VerifyPassword(oldPassword) {
+		return shared.NewDomainError("INVALID_PASSWORD", "Current password is incorrect")
+	}
+
+	return u.SetPasswordWithPolicy(newPassword, policy, previousHashes)
+}
+
+// SetPasswordWithPolicy sets a new password that satisfies a tenant password policy.
+// previousHashes are the hashes of the user's previous passwords, most recent first;
+// the new password may not match the current one or the first HistoryCount-1 of them.
+// A password failing any rule returns a *PasswordPolicyError listing every failed rule.
+func (u *User) SetPasswordWithPolicy(newPassword string, policy PasswordPolicy, previousHashes []string) error {
+	violations := policy.Check(newPassword)
+	if u.isRecentPassword(newPassword, policy.HistoryCount, previousHashes) {
+		violations = append(violations, PasswordRuleViolation{
+			Rule:    PasswordRuleReuse,
+			Message: fmt.Sprintf("Password cannot be one of the last %d passwords", policy.HistoryCount),
+		})
+	}
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	return u.applyPassword(newPassword)
+}
+
+// isRecentPassword checks the password against the current and the recent previous password hashes
+func (u *User) isRecentPassword(password string, historyCount int, previousHashes []string) bool {
+	if historyCount <= 0 {
+		return false
+	}
+	if u.PasswordHash != "" && u.VerifyPassword(password) {
+		return true
+	}
+	for i, hash := range previousHashes {
+		if i >= historyCount-1 {
+			break
+		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // ForcePasswordChange marks that user must change password on next login
 func (u *User) ForcePasswordChange() {
 	u.MustChangePassword = true
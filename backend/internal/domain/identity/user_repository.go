@@ -62,6 +62,9 @@ type UserFilter struct {
 	// Filter by role ID
 	RoleID *uuid.UUID
 
+	// Filter by tenant ID
+	TenantID *uuid.UUID
+
 	// Pagination
 	Page     int
 	PageSize int
@@ -99,6 +102,12 @@ func (f UserFilter) WithRoleID(roleID uuid.UUID) UserFilter {
 	return f
 }
 
+// WithTenantID sets the tenant ID filter
+func (f UserFilter) WithTenantID(tenantID uuid.UUID) UserFilter {
+	f.TenantID = &tenantID
+	return f
+}
+
 // WithPagination sets pagination parameters
 func (f UserFilter) WithPagination(page, pageSize int) UserFilter {
 	f.Page = page
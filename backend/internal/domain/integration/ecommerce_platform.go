@@ -36,6 +36,7 @@ var (
 	ErrOrderSyncDuplicateOrder     = errors.New("integration: order already synced")
 	ErrOrderSyncInvalidStatus      = errors.New("integration: invalid order status transition")
 	ErrOrderSyncStatusUpdateFailed = errors.New("integration: order status update failed")
+	ErrOrderSyncNotConfigured      = errors.New("integration: order sync not configured")
 
 	// Inventory sync errors
 	ErrInventorySyncFailed = errors.New("integration: inventory sync failed")
@@ -301,6 +302,8 @@ type ProductSync struct {
 	ProductCode string
 	// ProductName is the product name
 	ProductName string
+	// Barcode is the product barcode (e.g., EAN-13), if the platform has one
+	Barcode string
 	// Description is the product description
 	Description string
 	// CategoryID is the platform category ID
@@ -331,6 +334,8 @@ type ProductSkuSync struct {
 	SkuCode string
 	// SkuName is the SKU specification name
 	SkuName string
+	// Barcode is the SKU barcode, if the platform has one
+	Barcode string
 	// Attributes contains SKU attributes (e.g., color, size)
 	Attributes map[string]string
 	// Price is the SKU price
@@ -408,6 +413,47 @@ type OrderPullResponse struct {
 	NextPageNo int
 }
 
+// ProductListRequest represents a request to list the products on a platform
+type ProductListRequest struct {
+	// TenantID is the tenant making the request
+	TenantID uuid.UUID
+	// PlatformCode specifies which platform to list from
+	PlatformCode PlatformCode
+	// PageNo is the page number (1-indexed)
+	PageNo int
+	// PageSize is the number of products per page
+	PageSize int
+}
+
+// Validate validates the product list request
+func (r *ProductListRequest) Validate() error {
+	if r.TenantID == uuid.Nil {
+		return ErrMappingInvalidTenantID
+	}
+	if !r.PlatformCode.IsValid() {
+		return ErrMappingInvalidPlatformCode
+	}
+	if r.PageNo < 1 {
+		r.PageNo = 1
+	}
+	if r.PageSize < 1 || r.PageSize > 100 {
+		r.PageSize = 50
+	}
+	return nil
+}
+
+// ProductListResponse represents the response from listing platform products
+type ProductListResponse struct {
+	// Products contains the listed products
+	Products []ProductSync
+	// TotalCount is the total number of products on the platform
+	TotalCount int64
+	// HasMore indicates if there are more pages
+	HasMore bool
+	// NextPageNo is the next page number (if HasMore is true)
+	NextPageNo int
+}
+
 // SyncResult represents the result of a sync operation
 type SyncResult struct {
 	// Status is the overall sync status
@@ -498,6 +544,9 @@ type EcommercePlatform interface {
 	// GetProduct retrieves a product from the platform
 	GetProduct(ctx context.Context, tenantID uuid.UUID, platformProductID string) (*ProductSync, error)
 
+	// ListProducts lists the products on the platform, one page at a time
+	ListProducts(ctx context.Context, req *ProductListRequest) (*ProductListResponse, error)
+
 	// ---------------------------------------------------------------------------
 	// Order Operations
 	// ---------------------------------------------------------------------------
@@ -518,6 +567,9 @@ type EcommercePlatform interface {
 
 	// SyncInventory synchronizes inventory levels to the platform
 	SyncInventory(ctx context.Context, tenantID uuid.UUID, items []InventorySync) (*SyncResult, error)
+
+	// UpdateStock sets the sellable quantity of a single platform product
+	UpdateStock(ctx context.Context, tenantID uuid.UUID, platformProductID string, quantity decimal.Decimal) error
 }
 
 // EcommercePlatformRegistry provides access to configured e-commerce platforms
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// OrderStatusPushState is the delivery state of an order status push
+type OrderStatusPushState string
+
+const (
+	OrderStatusPushStateFailed OrderStatusPushState = "FAILED"
+	OrderStatusPushStateSent   OrderStatusPushState = "SENT"
+	OrderStatusPushStateDead   OrderStatusPushState = "DEAD"
+)
+
+// OrderStatusPush is an order status update that a platform rejected or could not be
+// reached for, queued for retry. Like outbox entries, failed pushes are retried with
+// exponential backoff and move to the dead letter state after MaxRetries attempts.
+type OrderStatusPush struct {
+	ID           uuid.UUID
+	TenantID     uuid.UUID
+	PlatformCode PlatformCode
+	// PlatformOrderID is the order ID on the platform
+	PlatformOrderID string
+	// LocalOrderID is the sales order whose status is pushed
+	LocalOrderID uuid.UUID
+	// Status is the platform status to set
+	Status          PlatformOrderStatus
+	ShippingCompany string
+	TrackingNumber  string
+	State           OrderStatusPushState
+	RetryCount      int
+	MaxRetries      int
+	LastError       string
+	NextRetryAt     *time.Time
+	SentAt          *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// NewFailedOrderStatusPush queues a status update whose first attempt failed
+func NewFailedOrderStatusPush(req *OrderStatusUpdateRequest, localOrderID uuid.UUID, errMsg string, base, max time.Duration) *OrderStatusPush {
+	now := time.Now()
+	push := &OrderStatusPush{
+		ID:              uuid.New(),
+		TenantID:        req.TenantID,
+		PlatformCode:    req.PlatformCode,
+		PlatformOrderID: req.PlatformOrderID,
+		LocalOrderID:    localOrderID,
+		Status:          req.Status,
+		ShippingCompany: req.ShippingCompany,
+		TrackingNumber:  req.TrackingNumber,
+		MaxRetries:      shared.DefaultMaxRetries,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	push.MarkFailedWithBackoff(errMsg, base, max)
+	return push
+}
+
+// Request returns the platform request that delivers this push
+func (p *OrderStatusPush) Request() *OrderStatusUpdateRequest {
+	return &OrderStatusUpdateRequest{
+		TenantID:        p.TenantID,
+		PlatformCode:    p.PlatformCode,
+		PlatformOrderID: p.PlatformOrderID,
+		Status:          p.Status,
+		ShippingCompany: p.ShippingCompany,
+		TrackingNumber:  p.TrackingNumber,
+	}
+}
+
+// CanRetry returns true if the push can be retried
+func (p *OrderStatusPush) CanRetry() bool {
+	return p.State == OrderStatusPushStateFailed && p.RetryCount < p.MaxRetries
+}
+
+// MarkSent marks the push as delivered
+func (p *OrderStatusPush) MarkSent() {
+	now := time.Now()
+	p.State = OrderStatusPushStateSent
+	p.SentAt = &now
+	p.NextRetryAt = nil
+	p.UpdatedAt = now
+}
+
+// MarkFailedWithBackoff records a failed attempt. Once the retry count reaches
+// MaxRetries the push moves to the dead letter state, otherwise the next retry
+// is scheduled with exponential backoff from base, capped at max.
+func (p *OrderStatusPush) MarkFailedWithBackoff(errMsg string, base, max time.Duration) {
+	p.RetryCount++
+	p.LastError = errMsg
+	p.UpdatedAt = time.Now()
+
+	if p.RetryCount >= p.MaxRetries {
+		p.State = OrderStatusPushStateDead
+		p.NextRetryAt = nil
+		return
+	}
+	p.State = OrderStatusPushStateFailed
+	nextRetry := time.Now().Add(shared.RetryBackoff(p.RetryCount, base, max))
+	p.NextRetryAt = &nextRetry
+}
+
+// IsDead returns true if the push is in the dead letter state
+func (p *OrderStatusPush) IsDead() bool {
+	return p.State == OrderStatusPushStateDead
+}
+
+// OrderStatusPushRepository persists order status pushes awaiting retry
+type OrderStatusPushRepository interface {
+	// Save creates or updates a push
+	Save(ctx context.Context, push *OrderStatusPush) error
+
+	// FindRetryable finds failed pushes whose next retry is due before the given time, oldest first
+	FindRetryable(ctx context.Context, before time.Time, limit int) ([]*OrderStatusPush, error)
+}
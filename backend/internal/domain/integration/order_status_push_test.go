@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFailedOrderStatusPush(t *testing.T) {
+	req := &OrderStatusUpdateRequest{
+		TenantID:        uuid.New(),
+		PlatformCode:    PlatformCodeTaobao,
+		PlatformOrderID: "TB1001",
+		Status:          PlatformOrderStatusShipped,
+		ShippingCompany: "SF",
+		TrackingNumber:  "SF1234567890",
+	}
+	localOrderID := uuid.New()
+
+	push := NewFailedOrderStatusPush(req, localOrderID, "timeout", time.Minute, time.Hour)
+
+	assert.Equal(t, OrderStatusPushStateFailed, push.State)
+	assert.Equal(t, 1, push.RetryCount)
+	assert.Equal(t, "timeout", push.LastError)
+	assert.True(t, push.CanRetry())
+	require.NotNil(t, push.NextRetryAt)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), *push.NextRetryAt, 5*time.Second)
+	assert.Equal(t, localOrderID, push.LocalOrderID)
+	assert.Equal(t, req, push.Request())
+}
+
+func TestOrderStatusPush_MarkFailedWithBackoff(t *testing.T) {
+	t.Run("doubles the backoff on each failure", func(t *testing.T) {
+		push := &OrderStatusPush{RetryCount: 2, MaxRetries: 5, State: OrderStatusPushStateFailed}
+
+		push.MarkFailedWithBackoff("rejected", time.Minute, time.Hour)
+
+		assert.Equal(t, 3, push.RetryCount)
+		require.NotNil(t, push.NextRetryAt)
+		assert.WithinDuration(t, time.Now().Add(4*time.Minute), *push.NextRetryAt, 5*time.Second)
+	})
+
+	t.Run("moves to dead letter after max retries", func(t *testing.T) {
+		push := &OrderStatusPush{RetryCount: 4, MaxRetries: 5, State: OrderStatusPushStateFailed}
+
+		push.MarkFailedWithBackoff("rejected", time.Minute, time.Hour)
+
+		assert.True(t, push.IsDead())
+		assert.False(t, push.CanRetry())
+		assert.Nil(t, push.NextRetryAt)
+	})
+}
+
+func TestOrderStatusPush_MarkSent(t *testing.T) {
+	next := time.Now()
+	push := &OrderStatusPush{RetryCount: 1, MaxRetries: 5, State: OrderStatusPushStateFailed, NextRetryAt: &next}
+
+	push.MarkSent()
+
+	assert.Equal(t, OrderStatusPushStateSent, push.State)
+	assert.NotNil(t, push.SentAt)
+	assert.Nil(t, push.NextRetryAt)
+	assert.False(t, push.CanRetry())
+}
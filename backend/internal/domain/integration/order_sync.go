@@ -97,6 +97,10 @@ type OrderSyncConfig struct {
 	DefaultWarehouseID uuid.UUID
 	// DefaultSalespersonID is the default salesperson for synced orders
 	DefaultSalespersonID *uuid.UUID
+	// DefaultCustomerID is the customer imported orders are booked to
+	DefaultCustomerID uuid.UUID
+	// DefaultCustomerName is the name of the default customer
+	DefaultCustomerName string
 	// AutoLockStock indicates if stock should be auto-locked on sync
 	AutoLockStock bool
 	// OrderPrefixFormat is the prefix format for converted order numbers
@@ -104,6 +108,9 @@ type OrderSyncConfig struct {
 	OrderPrefixFormat string
 	// StatusMappings maps platform status to internal workflow actions
 	StatusMappings map[PlatformOrderStatus]string
+	// OrderCursor is the sync cursor: orders created on the platform before it
+	// have already been pulled. Nil until the first import.
+	OrderCursor *time.Time
 }
 
 // ---------------------------------------------------------------------------
@@ -29,6 +29,8 @@ func MapTenantConfigToStrategyName(configValue string) string {
 	switch configValue {
 	case "weighted_average", "moving_average":
 		return "moving_average"
+	case "moving_weighted_average":
+		return "moving_weighted_average"
 	case "fifo":
 		return "fifo"
 	case "lifo":
@@ -214,7 +216,8 @@ func (s *InventoryDomainService) calculateMovingAverageCost(
 	currentStock := item.TotalQuantity().Amount() // Get decimal amount from Quantity
 	currentCost := item.UnitCost
 
-	if currentStock.IsZero() {
+	// With no stock on hand (or a negative balance), the incoming cost is used as is
+	if !currentStock.IsPositive() {
 		return incomingCost.Round(4)
 	}
 
@@ -356,6 +356,11 @@ func TestMapTenantConfigToStrategyName(t *testing.T) {
 			input:    "moving_average",
 			expected: "moving_average",
 		},
+		{
+			name:     "moving_weighted_average maps to moving_weighted_average",
+			input:    "moving_weighted_average",
+			expected: "moving_weighted_average",
+		},
 		{
 			name:     "fifo maps to fifo",
 			input:    "fifo",
@@ -1,6 +1,7 @@
 package inventory
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
@@ -28,6 +29,17 @@ func MustNewInventoryQuantity(value decimal.Decimal) valueobject.Quantity {
 	return valueobject.MustNewQuantity(value, InventoryUnit)
 }
 
+// NewInventoryBalance creates the inventory Quantity for an available balance.
+// Unlike NewInventoryQuantity it accepts negative values, which occur in warehouses
+// that allow negative stock.
+func NewInventoryBalance(value decimal.Decimal) valueobject.Quantity {
+	if !value.IsNegative() {
+		return MustNewInventoryQuantity(value)
+	}
+	balance, _ := ZeroInventoryQuantity().SubtractAllowNegative(MustNewInventoryQuantity(value.Neg()))
+	return balance
+}
+
 // ZeroInventoryQuantity returns a zero quantity for inventory.
 func ZeroInventoryQuantity() valueobject.Quantity {
 	return valueobject.ZeroQuantity(InventoryUnit)
@@ -56,7 +68,7 @@ func ZeroInventoryQuantity() valueobject.Quantity {
 //
 // Type Safety:
 // AvailableQuantity and LockedQuantity use the Quantity value object to ensure:
-// - Non-negative invariant is enforced
+// - Non-negative invariant is enforced (except via the AllowNegative methods)
 // - Type-safe quantity operations (Add, Subtract)
 // - Immutability of quantity values
 type InventoryItem struct {
@@ -77,6 +89,9 @@ type InventoryItem struct {
 	// Associations - loaded lazily
 	Batches []StockBatch
 	Locks   []StockLock
+
+	// changedBatchIDs holds the batches added or changed since the item was last saved
+	changedBatchIDs map[uuid.UUID]struct{}
 }
 
 // NewInventoryItem creates a new inventory item for a warehouse-product combination
@@ -131,7 +146,8 @@ func (i *InventoryItem) IncreaseStock(quantity decimal.Decimal, unitCost valueob
 
 	// Calculate new weighted average cost
 	// New Cost = (Old Quantity * Old Cost + New Quantity * New Cost) / (Old Quantity + New Quantity)
-	if oldQuantity.IsZero() {
+	// With no stock on hand (or a negative balance), the incoming cost is used as is
+	if !oldQuantity.IsPositive() {
 		i.UnitCost = unitCost.Amount()
 	} else {
 		totalValue := oldQuantity.Mul(oldCost).Add(quantity.Mul(unitCost.Amount()))
@@ -156,6 +172,7 @@ func (i *InventoryItem) IncreaseStock(quantity decimal.Decimal, unitCost valueob
 	if batch != nil {
 		stockBatch := NewStockBatch(i.ID, batch.BatchNumber, batch.ProductionDate, batch.ExpiryDate, quantity, unitCost.Amount())
 		i.Batches = append(i.Batches, *stockBatch)
+		i.markBatchChanged(stockBatch.ID)
 	}
 
 	// Emit events
@@ -203,6 +220,7 @@ func (i *InventoryItem) IncreaseStockWithCost(quantity decimal.Decimal, newUnitC
 	if batch != nil {
 		stockBatch := NewStockBatch(i.ID, batch.BatchNumber, batch.ProductionDate, batch.ExpiryDate, quantity, newUnitCost)
 		i.Batches = append(i.Batches, *stockBatch)
+		i.markBatchChanged(stockBatch.ID)
 	}
 
 	// Emit stock increased event only (cost change event handled by caller)
@@ -215,6 +233,17 @@ func (i *InventoryItem) IncreaseStockWithCost(quantity decimal.Decimal, newUnitC
 // This is used for operations like purchase returns where goods are shipped back to supplier
 // Different from DeductStock which works with locked stock
 func (i *InventoryItem) DecreaseStock(quantity decimal.Decimal, sourceType, sourceID, reason string) error {
+	return i.decreaseStock(quantity, sourceType, sourceID, reason, false)
+}
+
+// DecreaseStockAllowNegative directly decreases available stock, letting the available
+// quantity go below zero when there is not enough stock.
+// This is only used for warehouses that allow negative stock.
+func (i *InventoryItem) DecreaseStockAllowNegative(quantity decimal.Decimal, sourceType, sourceID, reason string) error {
+	return i.decreaseStock(quantity, sourceType, sourceID, reason, true)
+}
+
+func (i *InventoryItem) decreaseStock(quantity decimal.Decimal, sourceType, sourceID, reason string, allowNegative bool) error {
 	if quantity.LessThanOrEqual(decimal.Zero) {
 		return shared.NewDomainError("INVALID_QUANTITY", "Quantity must be positive")
 	}
@@ -227,7 +256,7 @@ func (i *InventoryItem) DecreaseStock(quantity decimal.Decimal, sourceType, sour
 
 	// Check if we have enough available stock
 	hasEnough, _ := i.AvailableQuantity.GreaterThanOrEqual(qtyToDecrease)
-	if !hasEnough {
+	if !hasEnough && !allowNegative {
 		return shared.NewDomainError("INSUFFICIENT_STOCK", "Insufficient available stock to decrease")
 	}
 	if sourceType == "" || sourceID == "" {
@@ -235,7 +264,7 @@ func (i *InventoryItem) DecreaseStock(quantity decimal.Decimal, sourceType, sour
 	}
 
 	// Decrease available quantity using type-safe operation
-	newAvailable, err := i.AvailableQuantity.Subtract(qtyToDecrease)
+	newAvailable, err := i.AvailableQuantity.SubtractAllowNegative(qtyToDecrease)
 	if err != nil {
 		return shared.NewDomainError("QUANTITY_OPERATION_ERROR", err.Error())
 	}
@@ -256,6 +285,52 @@ func (i *InventoryItem) DecreaseStock(quantity decimal.Decimal, sourceType, sour
 	return nil
 }
 
+// TransferOut removes available stock that is being transferred to another warehouse.
+// When batchID is set, the quantity is taken from that batch and its batch info is returned
+// so the receiving warehouse can carry the batch number across; otherwise the batch info is nil.
+// A batch must be given while the item still holds stock in batches, so batch quantities
+// never drift from the item's quantity.
+// Returns the unit cost of the transferred stock. The item is not modified if any check fails.
+func (i *InventoryItem) TransferOut(quantity decimal.Decimal, batchID *uuid.UUID, transferID string) (*BatchInfo, decimal.Decimal, error) {
+	if quantity.LessThanOrEqual(decimal.Zero) {
+		return nil, decimal.Zero, shared.NewDomainError("INVALID_QUANTITY", "Quantity must be positive")
+	}
+
+	var batch *StockBatch
+	if batchID == nil {
+		for idx := range i.Batches {
+			if i.Batches[idx].HasStock() {
+				return nil, decimal.Zero, shared.NewDomainError("BATCH_REQUIRED", "A batch must be selected to transfer stock of a batch-tracked item")
+			}
+		}
+	} else {
+		for idx := range i.Batches {
+			if i.Batches[idx].ID == *batchID {
+				batch = &i.Batches[idx]
+				break
+			}
+		}
+		if batch == nil {
+			return nil, decimal.Zero, shared.NewDomainError("BATCH_NOT_FOUND", "Batch not found for this inventory item")
+		}
+		if !batch.HasStock() || batch.Quantity.LessThan(quantity) {
+			return nil, decimal.Zero, shared.NewDomainError("INSUFFICIENT_BATCH_STOCK", "Insufficient stock in the batch to transfer")
+		}
+	}
+
+	unitCost := i.UnitCost
+	if err := i.DecreaseStock(quantity, string(SourceTypeTransfer), transferID, "Transfer to another warehouse"); err != nil {
+		return nil, decimal.Zero, err
+	}
+
+	if batch == nil {
+		return nil, unitCost, nil
+	}
+	batch.Deduct(quantity)
+	i.markBatchChanged(batch.ID)
+	return NewBatchInfo(batch.BatchNumber, batch.ProductionDate, batch.ExpiryDate), batch.UnitCost, nil
+}
+
 // LockStock locks a quantity of stock for a pending order
 // Returns the lock ID that must be used to unlock or deduct
 func (i *InventoryItem) LockStock(quantity decimal.Decimal, sourceType, sourceID string, expireAt time.Time) (*StockLock, error) {
@@ -345,15 +420,31 @@ func (i *InventoryItem) UnlockStock(lockID uuid.UUID) error {
 }
 
 // DeductStock deducts locked stock (actual shipment/consumption)
-// The lock must exist and match the quantity
+// The whole locked quantity is deducted and the lock is consumed
 func (i *InventoryItem) DeductStock(lockID uuid.UUID) error {
+	return i.deductLockedStock(lockID, nil, false)
+}
+
+// DeductStockPartial deducts part of the locked stock (partial shipment)
+// The rest of the lock stays locked; deducting the full locked quantity consumes the lock.
+// A quantity above the locked quantity consumes the lock and takes the excess from available stock.
+func (i *InventoryItem) DeductStockPartial(lockID uuid.UUID, quantity decimal.Decimal) error {
+	return i.deductLockedStock(lockID, &quantity, false)
+}
+
+// DeductStockAllowNegative deducts locked stock like DeductStockPartial, letting the available
+// quantity go below zero when the excess over the lock is more than the available stock.
+// This is only used for warehouses that allow negative stock.
+func (i *InventoryItem) DeductStockAllowNegative(lockID uuid.UUID, quantity decimal.Decimal) error {
+	return i.deductLockedStock(lockID, &quantity, true)
+}
+
+func (i *InventoryItem) deductLockedStock(lockID uuid.UUID, quantity *decimal.Decimal, allowNegative bool) error {
 	// Find the lock
 	var lock *StockLock
-	var lockIndex int
 	for idx := range i.Locks {
 		if i.Locks[idx].ID == lockID && !i.Locks[idx].Released && !i.Locks[idx].Consumed {
 			lock = &i.Locks[idx]
-			lockIndex = idx
 			break
 		}
 	}
@@ -362,8 +453,27 @@ func (i *InventoryItem) DeductStock(lockID uuid.UUID) error {
 		return shared.NewDomainError("LOCK_NOT_FOUND", "Stock lock not found or already released/consumed")
 	}
 
-	// Create quantity value object from the lock's quantity
-	qtyToDeduct, err := NewInventoryQuantity(lock.Quantity)
+	deducted := lock.Quantity
+	if quantity != nil {
+		if quantity.LessThanOrEqual(decimal.Zero) {
+			return shared.NewDomainError("INVALID_QUANTITY", "Deduct quantity must be positive")
+		}
+		deducted = *quantity
+	}
+
+	// The lock covers at most its own quantity; the excess comes out of available stock
+	fromLock := decimal.Min(deducted, lock.Quantity)
+	excess, err := NewInventoryQuantity(deducted.Sub(fromLock))
+	if err != nil {
+		return shared.NewDomainError("INVALID_QUANTITY", err.Error())
+	}
+	hasEnough, _ := i.AvailableQuantity.GreaterThanOrEqual(excess)
+	if !hasEnough && !allowNegative {
+		return shared.NewDomainError("INSUFFICIENT_STOCK", "Insufficient available stock to deduct")
+	}
+
+	// Create quantity value object from the locked part of the deduction
+	qtyToDeduct, err := NewInventoryQuantity(fromLock)
 	if err != nil {
 		return shared.NewDomainError("INVALID_QUANTITY", err.Error())
 	}
@@ -373,13 +483,23 @@ func (i *InventoryItem) DeductStock(lockID uuid.UUID) error {
 	if err != nil {
 		return shared.NewDomainError("QUANTITY_OPERATION_ERROR", err.Error())
 	}
+	newAvailable, err := i.AvailableQuantity.SubtractAllowNegative(excess)
+	if err != nil {
+		return shared.NewDomainError("QUANTITY_OPERATION_ERROR", err.Error())
+	}
 	i.LockedQuantity = newLocked
+	i.AvailableQuantity = newAvailable
 	i.UpdatedAt = time.Now()
 
-	// Mark lock as consumed
-	i.Locks[lockIndex].Consume()
+	// Consume the lock once nothing remains locked, otherwise keep the rest reserved
+	if fromLock.Equal(lock.Quantity) {
+		lock.Consume()
+	} else {
+		lock.Quantity = lock.Quantity.Sub(fromLock)
+		lock.UpdatedAt = i.UpdatedAt
+	}
 
-	i.AddDomainEvent(NewStockDeductedEvent(i, lock.Quantity, lockID, lock.SourceType, lock.SourceID))
+	i.AddDomainEvent(NewStockDeductedEvent(i, deducted, lockID, lock.SourceType, lock.SourceID))
 
 	// Check if below minimum threshold
 	if !i.MinQuantity.IsZero() {
@@ -392,10 +512,141 @@ func (i *InventoryItem) DeductStock(lockID uuid.UUID) error {
 	return nil
 }
 
+// ConsumeBatches takes a quantity that has left the item's stock out of its batches,
+// selecting the batches with the given outbound strategy (FIFO when nil).
+// Stock not tracked in batches covers any quantity the batches cannot fulfill.
+func (i *InventoryItem) ConsumeBatches(quantity decimal.Decimal, outbound BatchOutboundStrategy) (*BatchOutboundResult, error) {
+	if outbound == nil {
+		outbound = NewFIFOBatchOutboundStrategy()
+	}
+
+	result, err := outbound.SelectBatches(quantity, i.Batches)
+	if err != nil {
+		return nil, err
+	}
+
+	i.applyBatchDeductions(result)
+	return result, nil
+}
+
+// ConsumeSellableBatches works like ConsumeBatches but never takes stock from a batch that
+// expires before sellableUntil. It must be called before the quantity leaves the item's stock,
+// as stock not tracked in batches is worked out from the item's current total.
+func (i *InventoryItem) ConsumeSellableBatches(quantity decimal.Decimal, outbound BatchOutboundStrategy, sellableUntil time.Time) (*BatchOutboundResult, error) {
+	if outbound == nil {
+		outbound = NewFIFOBatchOutboundStrategy()
+	}
+
+	result, err := i.selectSellableBatches(quantity, outbound, sellableUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	i.applyBatchDeductions(result)
+	return result, nil
+}
+
+// CheckSellableBatches returns a BATCH_EXPIRED error naming the batch when the quantity can
+// only be covered by a batch that expires before sellableUntil. Batches still good on that
+// date and stock not tracked in batches are used first. The item is not changed.
+func (i *InventoryItem) CheckSellableBatches(quantity decimal.Decimal, sellableUntil time.Time) error {
+	_, err := i.selectSellableBatches(quantity, NewFIFOBatchOutboundStrategy(), sellableUntil)
+	return err
+}
+
+// selectSellableBatches selects batches for the quantity from the batches still good on
+// sellableUntil, failing if the rest would have to come from a batch expiring before then
+func (i *InventoryItem) selectSellableBatches(quantity decimal.Decimal, outbound BatchOutboundStrategy, sellableUntil time.Time) (*BatchOutboundResult, error) {
+	sellable := make([]StockBatch, 0, len(i.Batches))
+	batchStock := decimal.Zero
+	var blocked *StockBatch
+	for idx := range i.Batches {
+		batch := &i.Batches[idx]
+		if !batch.HasStock() {
+			continue
+		}
+		batchStock = batchStock.Add(batch.Quantity)
+		if batch.ExpiresBefore(sellableUntil) {
+			if blocked == nil || batch.ExpiryDate.Before(*blocked.ExpiryDate) {
+				blocked = batch
+			}
+			continue
+		}
+		sellable = append(sellable, *batch)
+	}
+
+	result, err := outbound.SelectBatches(quantity, sellable)
+	if err != nil {
+		return nil, err
+	}
+
+	if blocked != nil && !result.FullyFulfilled {
+		untracked := i.TotalQuantity().Amount().Sub(batchStock)
+		if result.RemainingQuantity.GreaterThan(untracked) {
+			return nil, shared.NewDomainError("BATCH_EXPIRED", fmt.Sprintf(
+				"Batch %s expires on %s and cannot be shipped; stock must be good until %s",
+				blocked.BatchNumber, blocked.ExpiryDate.Format("2006-01-02"), sellableUntil.Format("2006-01-02"),
+			))
+		}
+	}
+
+	return result, nil
+}
+
+// applyBatchDeductions takes the selected deductions out of the item's batches
+func (i *InventoryItem) applyBatchDeductions(result *BatchOutboundResult) {
+	for _, deduction := range result.Deductions {
+		for idx := range i.Batches {
+			if i.Batches[idx].ID == deduction.BatchID {
+				i.Batches[idx].Deduct(deduction.DeductedAmount)
+				i.markBatchChanged(deduction.BatchID)
+				break
+			}
+		}
+	}
+	if len(result.Deductions) > 0 {
+		i.UpdatedAt = time.Now()
+	}
+}
+
+// markBatchChanged records that a batch must be written on the next save
+func (i *InventoryItem) markBatchChanged(batchID uuid.UUID) {
+	if i.changedBatchIDs == nil {
+		i.changedBatchIDs = make(map[uuid.UUID]struct{})
+	}
+	i.changedBatchIDs[batchID] = struct{}{}
+}
+
+// ChangedBatches returns the batches added or changed since the item was last saved
+func (i *InventoryItem) ChangedBatches() []*StockBatch {
+	changed := make([]*StockBatch, 0, len(i.changedBatchIDs))
+	for idx := range i.Batches {
+		if _, ok := i.changedBatchIDs[i.Batches[idx].ID]; ok {
+			changed = append(changed, &i.Batches[idx])
+		}
+	}
+	return changed
+}
+
+// ClearChangedBatches marks all batches as saved
+func (i *InventoryItem) ClearChangedBatches() {
+	i.changedBatchIDs = nil
+}
+
 // AdjustStock adjusts the stock to match actual quantity (used during stock taking/counting)
 // The reason is recorded for audit purposes
 func (i *InventoryItem) AdjustStock(actualQuantity decimal.Decimal, reason string) error {
-	if actualQuantity.IsNegative() {
+	return i.adjustStock(actualQuantity, reason, false)
+}
+
+// AdjustStockAllowNegative adjusts the stock to match actual quantity, accepting a negative
+// actual quantity. This is only used for warehouses that allow negative stock.
+func (i *InventoryItem) AdjustStockAllowNegative(actualQuantity decimal.Decimal, reason string) error {
+	return i.adjustStock(actualQuantity, reason, true)
+}
+
+func (i *InventoryItem) adjustStock(actualQuantity decimal.Decimal, reason string, allowNegative bool) error {
+	if actualQuantity.IsNegative() && !allowNegative {
 		return shared.NewDomainError("INVALID_QUANTITY", "Actual quantity cannot be negative")
 	}
 	if reason == "" {
@@ -407,11 +658,7 @@ func (i *InventoryItem) AdjustStock(actualQuantity decimal.Decimal, reason strin
 		return shared.NewDomainError("HAS_LOCKED_STOCK", "Cannot adjust stock while there are outstanding locks")
 	}
 
-	// Create new quantity value object
-	newQty, err := NewInventoryQuantity(actualQuantity)
-	if err != nil {
-		return shared.NewDomainError("INVALID_QUANTITY", err.Error())
-	}
+	newQty := NewInventoryBalance(actualQuantity)
 
 	oldQuantity := i.AvailableQuantity.Amount()
 	difference := actualQuantity.Sub(oldQuantity)
@@ -466,6 +713,23 @@ func (i *InventoryItem) SetMaxQuantity(quantity decimal.Decimal) error {
 	return nil
 }
 
+// RestateUnitCost replaces the unit cost with one recalculated from the transaction ledger,
+// e.g. after the cost of an earlier receipt was corrected
+func (i *InventoryItem) RestateUnitCost(unitCost decimal.Decimal) error {
+	if unitCost.IsNegative() {
+		return shared.NewDomainError("INVALID_COST", "Unit cost cannot be negative")
+	}
+	if unitCost.Equal(i.UnitCost) {
+		return nil
+	}
+
+	oldCost := i.UnitCost
+	i.UnitCost = unitCost
+	i.UpdatedAt = time.Now()
+	i.AddDomainEvent(NewInventoryCostChangedEvent(i, valueobject.NewMoneyCNY(oldCost), valueobject.NewMoneyCNY(unitCost)))
+	return nil
+}
+
 // GetUnitCostMoney returns unit cost as Money value object
 func (i *InventoryItem) GetUnitCostMoney() valueobject.Money {
 	return valueobject.NewMoneyCNY(i.UnitCost)
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -383,6 +384,64 @@ func TestInventoryItem_DeductStock(t *testing.T) {
 	})
 }
 
+func TestInventoryItem_DeductStockPartial(t *testing.T) {
+	t.Run("keeps the remainder locked", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 100)
+		lock, _ := item.LockStock(decimal.NewFromInt(30), "sales_order", "SO-001", time.Now().Add(time.Hour))
+		item.ClearDomainEvents()
+
+		err := item.DeductStockPartial(lock.ID, decimal.NewFromInt(10))
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(70), item.AvailableQuantity.Amount())
+		assert.Equal(t, decimal.NewFromInt(20), item.LockedQuantity.Amount())
+		require.Len(t, item.Locks, 1)
+		assert.False(t, item.Locks[0].Consumed)
+		assert.Equal(t, decimal.NewFromInt(20), item.Locks[0].Quantity)
+
+		events := item.GetDomainEvents()
+		require.Len(t, events, 1)
+		deducted, ok := events[0].(*StockDeductedEvent)
+		require.True(t, ok)
+		assert.Equal(t, decimal.NewFromInt(10), deducted.Quantity)
+	})
+
+	t.Run("deducting the rest consumes the lock", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 100)
+		lock, _ := item.LockStock(decimal.NewFromInt(30), "sales_order", "SO-001", time.Now().Add(time.Hour))
+		require.NoError(t, item.DeductStockPartial(lock.ID, decimal.NewFromInt(10)))
+
+		err := item.DeductStockPartial(lock.ID, decimal.NewFromInt(20))
+
+		require.NoError(t, err)
+		assert.True(t, item.LockedQuantity.IsZero())
+		assert.True(t, item.Locks[0].Consumed)
+		assert.Equal(t, decimal.NewFromInt(70), item.TotalQuantity().Amount())
+	})
+
+	t.Run("takes the excess over the lock from available stock", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 100)
+		lock, _ := item.LockStock(decimal.NewFromInt(30), "sales_order", "SO-001", time.Now().Add(time.Hour))
+
+		err := item.DeductStockPartial(lock.ID, decimal.NewFromInt(35))
+
+		require.NoError(t, err)
+		assert.True(t, item.LockedQuantity.IsZero())
+		assert.Equal(t, decimal.NewFromInt(65), item.AvailableQuantity.Amount())
+		assert.True(t, item.Locks[0].Consumed)
+	})
+
+	t.Run("fails when the excess is more than available", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 40)
+		lock, _ := item.LockStock(decimal.NewFromInt(30), "sales_order", "SO-001", time.Now().Add(time.Hour))
+
+		assert.Error(t, item.DeductStockPartial(lock.ID, decimal.NewFromInt(41)))
+		assert.Error(t, item.DeductStockPartial(lock.ID, decimal.Zero))
+		assert.Equal(t, decimal.NewFromInt(30), item.LockedQuantity.Amount())
+		assert.Equal(t, decimal.NewFromInt(10), item.AvailableQuantity.Amount())
+	})
+}
+
 func TestInventoryItem_DecreaseStock(t *testing.T) {
 	t.Run("decreases available stock successfully", func(t *testing.T) {
 		item := createTestInventoryItemWithStock(t, 100)
@@ -477,6 +536,238 @@ func TestInventoryItem_DecreaseStock(t *testing.T) {
 	})
 }
 
+func TestInventoryItem_TransferOut(t *testing.T) {
+	t.Run("transfers available stock at the item's unit cost", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 100)
+		item.ClearDomainEvents()
+
+		batchInfo, unitCost, err := item.TransferOut(decimal.NewFromInt(40), nil, "TR-001")
+
+		require.NoError(t, err)
+		assert.Nil(t, batchInfo)
+		assert.True(t, unitCost.Equal(decimal.NewFromInt(10)))
+		assert.Equal(t, decimal.NewFromInt(60), item.AvailableQuantity.Amount())
+		events := item.GetDomainEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, EventTypeStockDecreased, events[0].EventType())
+	})
+
+	t.Run("takes the quantity from the batch and returns its batch info", func(t *testing.T) {
+		item := createTestInventoryItem(t)
+		expiry := time.Now().AddDate(0, 6, 0)
+		require.NoError(t, item.IncreaseStock(decimal.NewFromInt(50), valueobject.NewMoneyCNYFromFloat(12.00), NewBatchInfo("BATCH-001", nil, &expiry)))
+		batchID := item.Batches[0].ID
+
+		batchInfo, unitCost, err := item.TransferOut(decimal.NewFromInt(20), &batchID, "TR-002")
+
+		require.NoError(t, err)
+		require.NotNil(t, batchInfo)
+		assert.Equal(t, "BATCH-001", batchInfo.BatchNumber)
+		assert.Equal(t, &expiry, batchInfo.ExpiryDate)
+		assert.True(t, unitCost.Equal(decimal.NewFromInt(12)))
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(30)))
+		assert.Equal(t, decimal.NewFromInt(30), item.AvailableQuantity.Amount())
+	})
+
+	t.Run("fails without changes when stock is insufficient", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 10)
+
+		_, _, err := item.TransferOut(decimal.NewFromInt(11), nil, "TR-003")
+
+		assert.Error(t, err)
+		assert.Equal(t, decimal.NewFromInt(10), item.AvailableQuantity.Amount())
+	})
+
+	t.Run("fails without changes when the batch is short", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 100)
+		require.NoError(t, item.IncreaseStock(decimal.NewFromInt(5), valueobject.NewMoneyCNYFromFloat(10.00), NewBatchInfo("BATCH-002", nil, nil)))
+		batchID := item.Batches[0].ID
+
+		_, _, err := item.TransferOut(decimal.NewFromInt(6), &batchID, "TR-004")
+
+		assert.Error(t, err)
+		assert.Equal(t, decimal.NewFromInt(105), item.AvailableQuantity.Amount())
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(5)))
+	})
+
+	t.Run("requires a batch while the item holds batch stock", func(t *testing.T) {
+		item := createTestInventoryItem(t)
+		require.NoError(t, item.IncreaseStock(decimal.NewFromInt(50), valueobject.NewMoneyCNYFromFloat(12.00), NewBatchInfo("BATCH-003", nil, nil)))
+
+		_, _, err := item.TransferOut(decimal.NewFromInt(10), nil, "TR-006")
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "BATCH_REQUIRED", domainErr.Code)
+		assert.Equal(t, decimal.NewFromInt(50), item.AvailableQuantity.Amount())
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(50)))
+	})
+
+	t.Run("fails for an unknown batch", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 100)
+		batchID := uuid.New()
+
+		_, _, err := item.TransferOut(decimal.NewFromInt(1), &batchID, "TR-005")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestInventoryItem_ChangedBatches(t *testing.T) {
+	item := createTestInventoryItem(t)
+	require.NoError(t, item.IncreaseStock(decimal.NewFromInt(50), valueobject.NewMoneyCNYFromFloat(12.00), NewBatchInfo("BATCH-001", nil, nil)))
+	require.NoError(t, item.IncreaseStock(decimal.NewFromInt(30), valueobject.NewMoneyCNYFromFloat(12.00), NewBatchInfo("BATCH-002", nil, nil)))
+	assert.Len(t, item.ChangedBatches(), 2, "new batches must be saved")
+
+	item.ClearChangedBatches()
+	assert.Empty(t, item.ChangedBatches())
+
+	// Only the batch the transfer takes stock from is written again
+	batchID := item.Batches[1].ID
+	_, _, err := item.TransferOut(decimal.NewFromInt(10), &batchID, "TR-007")
+	require.NoError(t, err)
+
+	changed := item.ChangedBatches()
+	require.Len(t, changed, 1)
+	assert.Equal(t, batchID, changed[0].ID)
+	assert.True(t, changed[0].Quantity.Equal(decimal.NewFromInt(20)))
+}
+
+func TestInventoryItem_ConsumeBatches(t *testing.T) {
+	// Received in order: A (expires in 6 months), B (expires in 1 month), C (no expiry)
+	newItem := func(t *testing.T) *InventoryItem {
+		item := createTestInventoryItem(t)
+		received := time.Now().AddDate(0, 0, -30)
+		for _, batch := range []struct {
+			number string
+			expiry *time.Time
+		}{
+			{"BATCH-A", timePtr(time.Now().AddDate(0, 6, 0))},
+			{"BATCH-B", timePtr(time.Now().AddDate(0, 1, 0))},
+			{"BATCH-C", nil},
+		} {
+			require.NoError(t, item.IncreaseStock(decimal.NewFromInt(10), valueobject.NewMoneyCNYFromFloat(10.00), NewBatchInfo(batch.number, nil, batch.expiry)))
+			item.Batches[len(item.Batches)-1].CreatedAt = received
+			received = received.AddDate(0, 0, 1)
+		}
+		return item
+	}
+
+	t.Run("FIFO consumes the earliest received batch first", func(t *testing.T) {
+		item := newItem(t)
+
+		result, err := item.ConsumeBatches(decimal.NewFromInt(15), NewFIFOBatchOutboundStrategy())
+
+		require.NoError(t, err)
+		require.Len(t, result.Deductions, 2)
+		assert.Equal(t, "BATCH-A", result.Deductions[0].BatchNumber)
+		assert.Equal(t, "BATCH-B", result.Deductions[1].BatchNumber)
+		assert.True(t, item.Batches[0].Consumed)
+		assert.True(t, item.Batches[1].Quantity.Equal(decimal.NewFromInt(5)))
+		assert.True(t, item.Batches[2].Quantity.Equal(decimal.NewFromInt(10)))
+	})
+
+	t.Run("FEFO consumes the soonest expiring batch first and batches without expiry last", func(t *testing.T) {
+		item := newItem(t)
+
+		result, err := item.ConsumeBatches(decimal.NewFromInt(25), NewFEFOBatchOutboundStrategy())
+
+		require.NoError(t, err)
+		require.Len(t, result.Deductions, 3)
+		assert.Equal(t, "BATCH-B", result.Deductions[0].BatchNumber)
+		assert.Equal(t, "BATCH-A", result.Deductions[1].BatchNumber)
+		assert.Equal(t, "BATCH-C", result.Deductions[2].BatchNumber)
+		assert.True(t, item.Batches[1].Consumed)
+		assert.True(t, item.Batches[0].Consumed)
+		assert.True(t, item.Batches[2].Quantity.Equal(decimal.NewFromInt(5)))
+	})
+
+	t.Run("defaults to FIFO", func(t *testing.T) {
+		item := newItem(t)
+
+		result, err := item.ConsumeBatches(decimal.NewFromInt(5), nil)
+
+		require.NoError(t, err)
+		require.Len(t, result.Deductions, 1)
+		assert.Equal(t, "BATCH-A", result.Deductions[0].BatchNumber)
+	})
+
+	t.Run("leaves the quantity batches cannot cover unfulfilled", func(t *testing.T) {
+		item := newItem(t)
+
+		result, err := item.ConsumeBatches(decimal.NewFromInt(40), NewFEFOBatchOutboundStrategy())
+
+		require.NoError(t, err)
+		assert.False(t, result.FullyFulfilled)
+		assert.True(t, result.RemainingQuantity.Equal(decimal.NewFromInt(10)))
+	})
+}
+
+func TestInventoryItem_ConsumeSellableBatches(t *testing.T) {
+	shipDate := time.Now()
+
+	// newItem receives 10 units each of the given batches, in order
+	newItem := func(t *testing.T, batches ...*BatchInfo) *InventoryItem {
+		item := createTestInventoryItem(t)
+		for _, batch := range batches {
+			require.NoError(t, item.IncreaseStock(decimal.NewFromInt(10), valueobject.NewMoneyCNYFromFloat(10.00), batch))
+		}
+		return item
+	}
+
+	t.Run("blocks a shipment that needs an expired batch and names it", func(t *testing.T) {
+		expiry := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+		item := newItem(t, NewBatchInfo("BATCH-OLD", nil, &expiry))
+
+		_, err := item.ConsumeSellableBatches(decimal.NewFromInt(5), nil, shipDate)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "BATCH-OLD")
+		assert.Contains(t, err.Error(), "2020-03-01")
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(10)))
+	})
+
+	t.Run("ships from a fresh batch", func(t *testing.T) {
+		item := newItem(t, NewBatchInfo("BATCH-NEW", nil, timePtr(shipDate.AddDate(0, 6, 0))))
+
+		result, err := item.ConsumeSellableBatches(decimal.NewFromInt(5), nil, shipDate)
+
+		require.NoError(t, err)
+		require.Len(t, result.Deductions, 1)
+		assert.Equal(t, "BATCH-NEW", result.Deductions[0].BatchNumber)
+		assert.True(t, item.Batches[0].Quantity.Equal(decimal.NewFromInt(5)))
+	})
+
+	t.Run("takes fresh batches ahead of a batch within the buffer", func(t *testing.T) {
+		item := newItem(t,
+			NewBatchInfo("BATCH-SOON", nil, timePtr(shipDate.AddDate(0, 0, 3))),
+			NewBatchInfo("BATCH-NEW", nil, timePtr(shipDate.AddDate(0, 6, 0))),
+		)
+		sellableUntil := shipDate.AddDate(0, 0, 7)
+
+		err := item.CheckSellableBatches(decimal.NewFromInt(11), sellableUntil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "BATCH-SOON")
+
+		// Without a buffer the batch can still be shipped
+		assert.NoError(t, item.CheckSellableBatches(decimal.NewFromInt(11), shipDate))
+
+		result, err := item.ConsumeSellableBatches(decimal.NewFromInt(10), NewFEFOBatchOutboundStrategy(), sellableUntil)
+		require.NoError(t, err)
+		require.Len(t, result.Deductions, 1)
+		assert.Equal(t, "BATCH-NEW", result.Deductions[0].BatchNumber)
+	})
+
+	t.Run("stock not tracked in batches covers the quantity before an expired batch", func(t *testing.T) {
+		expiry := shipDate.AddDate(0, 0, -1)
+		item := newItem(t, NewBatchInfo("BATCH-OLD", nil, &expiry))
+		require.NoError(t, item.IncreaseStock(decimal.NewFromInt(5), valueobject.NewMoneyCNYFromFloat(10.00), nil))
+
+		assert.NoError(t, item.CheckSellableBatches(decimal.NewFromInt(5), shipDate))
+		assert.Error(t, item.CheckSellableBatches(decimal.NewFromInt(6), shipDate))
+	})
+}
+
 func TestInventoryItem_AdjustStock(t *testing.T) {
 	t.Run("adjusts stock successfully (increase)", func(t *testing.T) {
 		item := createTestInventoryItemWithStock(t, 100)
@@ -539,6 +830,78 @@ func TestInventoryItem_AdjustStock(t *testing.T) {
 	})
 }
 
+func TestInventoryItem_AllowNegative(t *testing.T) {
+	t.Run("DecreaseStockAllowNegative drives available below zero", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 10)
+		item.ClearDomainEvents()
+
+		err := item.DecreaseStockAllowNegative(decimal.NewFromInt(15), "PURCHASE_RETURN", "PR-001", "Consignment shipped")
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(-5), item.AvailableQuantity.Amount())
+		events := item.GetDomainEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, EventTypeStockDecreased, events[0].EventType())
+	})
+
+	t.Run("DecreaseStock still rejects going below zero", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 10)
+
+		err := item.DecreaseStock(decimal.NewFromInt(15), "PURCHASE_RETURN", "PR-001", "Purchase return")
+
+		require.Error(t, err)
+		assert.Equal(t, decimal.NewFromInt(10), item.AvailableQuantity.Amount())
+	})
+
+	t.Run("DeductStockAllowNegative drives available below zero", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 40)
+		lock, _ := item.LockStock(decimal.NewFromInt(30), "sales_order", "SO-001", time.Now().Add(time.Hour))
+
+		err := item.DeductStockAllowNegative(lock.ID, decimal.NewFromInt(45))
+
+		require.NoError(t, err)
+		assert.True(t, item.LockedQuantity.IsZero())
+		assert.Equal(t, decimal.NewFromInt(-5), item.AvailableQuantity.Amount())
+		assert.True(t, item.Locks[0].Consumed)
+	})
+
+	t.Run("stock received into a negative balance offsets it", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 10)
+		require.NoError(t, item.DecreaseStockAllowNegative(decimal.NewFromInt(15), "PURCHASE_RETURN", "PR-001", "Consignment shipped"))
+
+		err := item.IncreaseStock(decimal.NewFromInt(20), valueobject.NewMoneyCNY(decimal.NewFromInt(10)), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(15), item.AvailableQuantity.Amount())
+		assert.True(t, decimal.NewFromInt(10).Equal(item.UnitCost))
+	})
+
+	t.Run("receiving exactly the shortfall brings the balance back to zero", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 10)
+		require.NoError(t, item.DecreaseStockAllowNegative(decimal.NewFromInt(15), "PURCHASE_RETURN", "PR-001", "Consignment shipped"))
+
+		err := item.IncreaseStock(decimal.NewFromInt(5), valueobject.NewMoneyCNY(decimal.NewFromInt(12)), nil)
+
+		require.NoError(t, err)
+		assert.True(t, item.AvailableQuantity.IsZero())
+		assert.True(t, decimal.NewFromInt(12).Equal(item.UnitCost))
+	})
+
+	t.Run("AdjustStockAllowNegative accepts a negative actual quantity", func(t *testing.T) {
+		item := createTestInventoryItemWithStock(t, 10)
+
+		err := item.AdjustStockAllowNegative(decimal.NewFromInt(-3), "Consignment count")
+
+		require.NoError(t, err)
+		assert.Equal(t, decimal.NewFromInt(-3), item.AvailableQuantity.Amount())
+	})
+
+	t.Run("NewInventoryBalance keeps the sign", func(t *testing.T) {
+		assert.Equal(t, decimal.NewFromInt(-7), NewInventoryBalance(decimal.NewFromInt(-7)).Amount())
+		assert.Equal(t, decimal.NewFromInt(7), NewInventoryBalance(decimal.NewFromInt(7)).Amount())
+	})
+}
+
 func TestInventoryItem_ThresholdChecks(t *testing.T) {
 	t.Run("IsBelowMinimum returns true when below threshold", func(t *testing.T) {
 		item := createTestInventoryItemWithStock(t, 50)
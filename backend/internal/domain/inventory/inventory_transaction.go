@@ -30,6 +30,10 @@ const (
 	TransactionTypeLock TransactionType = "LOCK"
 	// TransactionTypeUnlock represents stock unlocked (order cancelled)
 	TransactionTypeUnlock TransactionType = "UNLOCK"
+	// TransactionTypeCostIncrease raises the cost of earlier stock movements without moving stock
+	TransactionTypeCostIncrease TransactionType = "COST_INCREASE"
+	// TransactionTypeCostDecrease lowers the cost of earlier stock movements without moving stock
+	TransactionTypeCostDecrease TransactionType = "COST_DECREASE"
 )
 
 // String returns the string representation of TransactionType
@@ -48,12 +52,37 @@ func (t TransactionType) IsValid() bool {
 		TransactionTypeTransferOut,
 		TransactionTypeReturn,
 		TransactionTypeLock,
-		TransactionTypeUnlock:
+		TransactionTypeUnlock,
+		TransactionTypeCostIncrease,
+		TransactionTypeCostDecrease:
 		return true
 	}
 	return false
 }
 
+// IsCostAdjustment returns true if this transaction type only changes cost, not quantity
+func (t TransactionType) IsCostAdjustment() bool {
+	return t == TransactionTypeCostIncrease || t == TransactionTypeCostDecrease
+}
+
+// IsReceipt returns true if this transaction type receives stock at its own cost.
+// Transfers in carry the cost of the source warehouse and unlocks only release locked stock.
+func (t TransactionType) IsReceipt() bool {
+	switch t {
+	case TransactionTypeInbound,
+		TransactionTypeAdjustmentIncrease,
+		TransactionTypeReturn:
+		return true
+	}
+	return false
+}
+
+// IsIssue returns true if this transaction type consumes stock at cost (cost of goods sold
+// or written off). Transfers out move stock to another warehouse and locks only reserve it.
+func (t TransactionType) IsIssue() bool {
+	return t == TransactionTypeOutbound || t == TransactionTypeAdjustmentDecrease
+}
+
 // IsIncrease returns true if this transaction type increases available quantity
 func (t TransactionType) IsIncrease() bool {
 	switch t {
@@ -99,6 +128,10 @@ const (
 	SourceTypeTransfer SourceType = "TRANSFER"
 	// SourceTypeInitialStock is initial stock setup
 	SourceTypeInitialStock SourceType = "INITIAL_STOCK"
+	// SourceTypeCostCorrection is a correction of a receipt's cost
+	SourceTypeCostCorrection SourceType = "COST_CORRECTION"
+	// SourceTypeCostRecalculation is a restatement of issue costs by a cost layer recalculation
+	SourceTypeCostRecalculation SourceType = "COST_RECALCULATION"
 )
 
 // String returns the string representation of SourceType
@@ -116,7 +149,9 @@ func (s SourceType) IsValid() bool {
 		SourceTypeStockTaking,
 		SourceTypeManualAdjustment,
 		SourceTypeTransfer,
-		SourceTypeInitialStock:
+		SourceTypeInitialStock,
+		SourceTypeCostCorrection,
+		SourceTypeCostRecalculation:
 		return true
 	}
 	return false
@@ -261,8 +296,11 @@ func (t *InventoryTransaction) WithCostMethod(method string) *InventoryTransacti
 }
 
 // GetSignedQuantity returns the quantity with sign based on transaction type
-// Positive for increases, negative for decreases
+// Positive for increases, negative for decreases, zero for cost adjustments
 func (t *InventoryTransaction) GetSignedQuantity() decimal.Decimal {
+	if t.TransactionType.IsCostAdjustment() {
+		return decimal.Zero
+	}
 	if t.TransactionType.IsDecrease() {
 		return t.Quantity.Neg()
 	}
@@ -271,7 +309,7 @@ func (t *InventoryTransaction) GetSignedQuantity() decimal.Decimal {
 
 // GetSignedTotalCost returns the total cost with sign based on transaction type
 func (t *InventoryTransaction) GetSignedTotalCost() decimal.Decimal {
-	if t.TransactionType.IsDecrease() {
+	if t.TransactionType.IsDecrease() || t.TransactionType == TransactionTypeCostDecrease {
 		return t.TotalCost.Neg()
 	}
 	return t.TotalCost
@@ -452,6 +490,52 @@ func CreateOutboundTransaction(
 	)
 }
 
+// CreateTransferTransactions is a helper to create the linked pair of transactions for a stock transfer.
+// Both transactions reference the transfer through the TRANSFER source type and the transfer ID.
+func CreateTransferTransactions(
+	tenantID, productID uuid.UUID,
+	from, to *InventoryItem,
+	quantity, unitCost decimal.Decimal,
+	fromBalanceBefore, toBalanceBefore decimal.Decimal,
+	transferID string,
+) (out *InventoryTransaction, in *InventoryTransaction, err error) {
+	out, err = NewInventoryTransaction(
+		tenantID,
+		from.ID,
+		from.WarehouseID,
+		productID,
+		TransactionTypeTransferOut,
+		quantity,
+		unitCost,
+		fromBalanceBefore,
+		from.AvailableQuantity.Amount(),
+		SourceTypeTransfer,
+		transferID,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in, err = NewInventoryTransaction(
+		tenantID,
+		to.ID,
+		to.WarehouseID,
+		productID,
+		TransactionTypeTransferIn,
+		quantity,
+		unitCost,
+		toBalanceBefore,
+		to.AvailableQuantity.Amount(),
+		SourceTypeTransfer,
+		transferID,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return out, in, nil
+}
+
 // CreateAdjustmentTransaction is a helper to create an adjustment transaction
 func CreateAdjustmentTransaction(
 	tenantID, inventoryItemID, warehouseID, productID uuid.UUID,
@@ -485,3 +569,47 @@ func CreateAdjustmentTransaction(
 	tx.WithReason(reason)
 	return tx, nil
 }
+
+// CreateCostAdjustmentTransaction is a helper to create a transaction that changes the cost of
+// an earlier stock movement without moving stock. The adjusted transaction is referenced by the
+// source line ID, quantity is the quantity it moved, and costDelta is the signed change of its
+// total cost. The transaction is a cost increase or decrease depending on the sign of costDelta.
+func CreateCostAdjustmentTransaction(
+	adjusted *InventoryTransaction,
+	costDelta decimal.Decimal,
+	balance decimal.Decimal,
+	sourceType SourceType,
+	sourceID string,
+	reason string,
+) (*InventoryTransaction, error) {
+	if costDelta.IsZero() {
+		return nil, shared.NewDomainError("INVALID_COST", "Cost adjustment cannot be zero")
+	}
+	txType := TransactionTypeCostIncrease
+	if costDelta.IsNegative() {
+		txType = TransactionTypeCostDecrease
+	}
+
+	tx, err := NewInventoryTransaction(
+		adjusted.TenantID,
+		adjusted.InventoryItemID,
+		adjusted.WarehouseID,
+		adjusted.ProductID,
+		txType,
+		adjusted.Quantity,
+		costDelta.Abs().Div(adjusted.Quantity).Round(4),
+		balance,
+		balance,
+		sourceType,
+		sourceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the exact delta; the unit cost is only informational
+	tx.TotalCost = costDelta.Abs()
+	tx.WithSourceLineID(adjusted.ID.String())
+	tx.WithReason(reason)
+	return tx, nil
+}
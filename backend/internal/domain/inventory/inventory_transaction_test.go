@@ -670,3 +670,42 @@ func TestInventoryTransaction_ZeroUnitCost(t *testing.T) {
 	assert.True(t, tx.UnitCost.IsZero())
 	assert.True(t, tx.TotalCost.IsZero())
 }
+
+func TestCreateCostAdjustmentTransaction(t *testing.T) {
+	issue, err := CreateOutboundTransaction(
+		uuid.New(), uuid.New(), uuid.New(), uuid.New(),
+		decimal.NewFromInt(3), decimal.NewFromInt(10),
+		decimal.NewFromInt(10), decimal.NewFromInt(7),
+		SourceTypeSalesOrder, "SO-001",
+	)
+	require.NoError(t, err)
+
+	t.Run("decrease keeps the exact delta and moves no stock", func(t *testing.T) {
+		tx, err := CreateCostAdjustmentTransaction(issue, decimal.NewFromInt(-10), decimal.NewFromInt(7), SourceTypeCostRecalculation, "RUN-1", "Restated")
+
+		require.NoError(t, err)
+		assert.Equal(t, TransactionTypeCostDecrease, tx.TransactionType)
+		assert.Equal(t, issue.ID.String(), tx.SourceLineID)
+		assert.True(t, tx.Quantity.Equal(issue.Quantity))
+		assert.True(t, decimal.NewFromInt(10).Equal(tx.TotalCost))
+		assert.True(t, decimal.NewFromInt(-10).Equal(tx.GetSignedTotalCost()))
+		assert.True(t, tx.GetSignedQuantity().IsZero())
+		assert.True(t, tx.QuantityChange().IsZero())
+		assert.False(t, tx.IsInbound())
+		assert.False(t, tx.IsOutbound())
+	})
+
+	t.Run("increase", func(t *testing.T) {
+		tx, err := CreateCostAdjustmentTransaction(issue, decimal.NewFromInt(6), decimal.NewFromInt(7), SourceTypeCostRecalculation, "RUN-1", "Restated")
+
+		require.NoError(t, err)
+		assert.Equal(t, TransactionTypeCostIncrease, tx.TransactionType)
+		assert.True(t, decimal.NewFromInt(2).Equal(tx.UnitCost))
+		assert.True(t, decimal.NewFromInt(6).Equal(tx.GetSignedTotalCost()))
+	})
+
+	t.Run("zero delta is rejected", func(t *testing.T) {
+		_, err := CreateCostAdjustmentTransaction(issue, decimal.Zero, decimal.NewFromInt(7), SourceTypeCostRecalculation, "RUN-1", "Restated")
+		assert.Error(t, err)
+	})
+}
@@ -189,6 +189,13 @@ type InventoryTransactionRepository interface {
 	// FindByDateRange finds transactions within a date range
 	FindByDateRange(ctx context.Context, tenantID uuid.UUID, start, end time.Time, filter shared.Filter) ([]InventoryTransaction, error)
 
+	// FindByWarehouseAsOf finds all transactions for a warehouse dated at or before asOf,
+	// in chronological order
+	FindByWarehouseAsOf(ctx context.Context, tenantID, warehouseID uuid.UUID, asOf time.Time) ([]InventoryTransaction, error)
+
+	// FindLedgerByInventoryItem finds all transactions for an inventory item in chronological order
+	FindLedgerByInventoryItem(ctx context.Context, tenantID, inventoryItemID uuid.UUID) ([]InventoryTransaction, error)
+
 	// FindByType finds transactions by type
 	FindByType(ctx context.Context, tenantID uuid.UUID, txType TransactionType, filter shared.Filter) ([]InventoryTransaction, error)
 
@@ -297,3 +304,21 @@ type StockTakingFilter struct {
 	EndDate     *time.Time
 	CreatedByID *uuid.UUID
 }
+
+// SerialNumberRepository defines the interface for serial number persistence
+type SerialNumberRepository interface {
+	// FindByIDForTenant finds a serial number by ID within a tenant
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*SerialNumber, error)
+
+	// FindBySerialNumber finds a product's serial number
+	FindBySerialNumber(ctx context.Context, tenantID, productID uuid.UUID, serialNumber string) (*SerialNumber, error)
+
+	// FindBySerialNumbers finds the existing serial numbers of a product among the given ones
+	FindBySerialNumbers(ctx context.Context, tenantID, productID uuid.UUID, serialNumbers []string) ([]SerialNumber, error)
+
+	// FindMovements finds the movement history of a serial number, oldest first
+	FindMovements(ctx context.Context, serialNumberID uuid.UUID) ([]SerialNumberMovement, error)
+
+	// Save creates or updates a serial number and appends its new movements
+	Save(ctx context.Context, sn *SerialNumber) error
+}
@@ -0,0 +1,189 @@
+package inventory
+
+import (
+	"strings"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SerialNumberStatus represents where a serialized unit currently is
+type SerialNumberStatus string
+
+const (
+	// SerialNumberStatusInStock is a unit on hand since it was received
+	SerialNumberStatusInStock SerialNumberStatus = "IN_STOCK"
+	// SerialNumberStatusShipped is a unit that has left the warehouse
+	SerialNumberStatusShipped SerialNumberStatus = "SHIPPED"
+	// SerialNumberStatusReturned is a shipped unit that came back and is on hand again
+	SerialNumberStatusReturned SerialNumberStatus = "RETURNED"
+)
+
+// IsValid returns true if the status is valid
+func (s SerialNumberStatus) IsValid() bool {
+	switch s {
+	case SerialNumberStatusInStock, SerialNumberStatusShipped, SerialNumberStatusReturned:
+		return true
+	}
+	return false
+}
+
+// SerialMovementType represents a change of location of a serialized unit
+type SerialMovementType string
+
+const (
+	// SerialMovementTypeReceived is the first receipt of a unit
+	SerialMovementTypeReceived SerialMovementType = "RECEIVED"
+	// SerialMovementTypeShipped is a unit leaving the warehouse
+	SerialMovementTypeShipped SerialMovementType = "SHIPPED"
+	// SerialMovementTypeReturned is a shipped unit received back
+	SerialMovementTypeReturned SerialMovementType = "RETURNED"
+	// SerialMovementTypeTransferred is a unit moved to another warehouse
+	SerialMovementTypeTransferred SerialMovementType = "TRANSFERRED"
+)
+
+// SerialNumber tracks an individual unit of a serialized product.
+// A serial number is unique per product within a tenant.
+type SerialNumber struct {
+	shared.BaseEntity
+	TenantID        uuid.UUID
+	InventoryItemID uuid.UUID // Inventory item the unit was last received into
+	WarehouseID     uuid.UUID
+	ProductID       uuid.UUID
+	SerialNumber    string
+	Status          SerialNumberStatus
+	// Movements holds the movements recorded since the serial number was loaded;
+	// the full history is read through the repository
+	Movements []SerialNumberMovement
+}
+
+// SerialNumberMovement is an immutable record of a serialized unit entering or leaving stock
+type SerialNumberMovement struct {
+	shared.BaseEntity
+	SerialNumberID  uuid.UUID
+	InventoryItemID uuid.UUID
+	WarehouseID     uuid.UUID
+	MovementType    SerialMovementType
+	SourceType      SourceType
+	SourceID        string
+	OccurredAt      time.Time
+}
+
+// NewSerialNumber registers a newly received unit of a product in an inventory item
+func NewSerialNumber(item *InventoryItem, serialNumber string, sourceType SourceType, sourceID string) (*SerialNumber, error) {
+	serialNumber = strings.TrimSpace(serialNumber)
+	if err := validateSerialNumber(serialNumber); err != nil {
+		return nil, err
+	}
+
+	sn := &SerialNumber{
+		BaseEntity:      shared.NewBaseEntity(),
+		TenantID:        item.TenantID,
+		InventoryItemID: item.ID,
+		WarehouseID:     item.WarehouseID,
+		ProductID:       item.ProductID,
+		SerialNumber:    serialNumber,
+		Status:          SerialNumberStatusInStock,
+	}
+	sn.recordMovement(SerialMovementTypeReceived, sourceType, sourceID)
+	return sn, nil
+}
+
+// IsInStock returns true if the unit is on hand
+func (s *SerialNumber) IsInStock() bool {
+	return s.Status == SerialNumberStatusInStock || s.Status == SerialNumberStatusReturned
+}
+
+// Receive takes a previously shipped unit back into stock in the given inventory item.
+// A unit that is still in stock cannot be received again.
+func (s *SerialNumber) Receive(item *InventoryItem, sourceType SourceType, sourceID string) error {
+	if s.IsInStock() {
+		return shared.NewDomainError("SERIAL_ALREADY_IN_STOCK", "Serial number "+s.SerialNumber+" is already in stock")
+	}
+	if item.ProductID != s.ProductID {
+		return shared.NewDomainError("SERIAL_PRODUCT_MISMATCH", "Serial number "+s.SerialNumber+" belongs to another product")
+	}
+
+	s.InventoryItemID = item.ID
+	s.WarehouseID = item.WarehouseID
+	s.Status = SerialNumberStatusReturned
+	s.recordMovement(SerialMovementTypeReturned, sourceType, sourceID)
+	return nil
+}
+
+// Ship takes an in-stock unit out of the given inventory item
+func (s *SerialNumber) Ship(item *InventoryItem, sourceType SourceType, sourceID string) error {
+	if !s.IsInStock() || s.InventoryItemID != item.ID {
+		return shared.NewDomainError("SERIAL_NOT_IN_STOCK", "Serial number "+s.SerialNumber+" is not in stock in this warehouse")
+	}
+
+	s.Status = SerialNumberStatusShipped
+	s.recordMovement(SerialMovementTypeShipped, sourceType, sourceID)
+	return nil
+}
+
+// TransferTo moves an in-stock unit of the from inventory item to the to inventory item,
+// which holds the same product in another warehouse
+func (s *SerialNumber) TransferTo(from, to *InventoryItem, sourceType SourceType, sourceID string) error {
+	if !s.IsInStock() || s.InventoryItemID != from.ID {
+		return shared.NewDomainError("SERIAL_NOT_IN_STOCK", "Serial number "+s.SerialNumber+" is not in stock in this warehouse")
+	}
+	if to.ProductID != s.ProductID {
+		return shared.NewDomainError("SERIAL_PRODUCT_MISMATCH", "Serial number "+s.SerialNumber+" belongs to another product")
+	}
+
+	s.InventoryItemID = to.ID
+	s.WarehouseID = to.WarehouseID
+	s.recordMovement(SerialMovementTypeTransferred, sourceType, sourceID)
+	return nil
+}
+
+func (s *SerialNumber) recordMovement(movementType SerialMovementType, sourceType SourceType, sourceID string) {
+	now := time.Now()
+	s.UpdatedAt = now
+	s.Movements = append(s.Movements, SerialNumberMovement{
+		BaseEntity:      shared.NewBaseEntity(),
+		SerialNumberID:  s.ID,
+		InventoryItemID: s.InventoryItemID,
+		WarehouseID:     s.WarehouseID,
+		MovementType:    movementType,
+		SourceType:      sourceType,
+		SourceID:        sourceID,
+		OccurredAt:      now,
+	})
+}
+
+// NormalizeSerialNumbers trims the serial numbers of a stock movement and checks that they
+// are non-empty, unique, and match the moved quantity one to one
+func NormalizeSerialNumbers(serialNumbers []string, quantity decimal.Decimal) ([]string, error) {
+	if !quantity.Equal(decimal.NewFromInt(int64(len(serialNumbers)))) {
+		return nil, shared.NewDomainError("SERIAL_COUNT_MISMATCH", "The number of serial numbers must equal the quantity")
+	}
+
+	seen := make(map[string]struct{}, len(serialNumbers))
+	normalized := make([]string, len(serialNumbers))
+	for i, sn := range serialNumbers {
+		sn = strings.TrimSpace(sn)
+		if err := validateSerialNumber(sn); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[sn]; ok {
+			return nil, shared.NewDomainError("DUPLICATE_SERIAL_NUMBER", "Serial number "+sn+" is listed more than once")
+		}
+		seen[sn] = struct{}{}
+		normalized[i] = sn
+	}
+	return normalized, nil
+}
+
+func validateSerialNumber(serialNumber string) error {
+	if serialNumber == "" {
+		return shared.NewDomainError("INVALID_SERIAL_NUMBER", "Serial number cannot be empty")
+	}
+	if len(serialNumber) > 100 {
+		return shared.NewDomainError("INVALID_SERIAL_NUMBER", "Serial number cannot exceed 100 characters")
+	}
+	return nil
+}
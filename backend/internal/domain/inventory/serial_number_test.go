@@ -0,0 +1,160 @@
+package inventory
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSerialNumber(t *testing.T) {
+	item := createTestInventoryItem(t)
+
+	t.Run("registers unit in stock with a received movement", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, " SN-001 ", SourceTypePurchaseOrder, "PO-001")
+
+		require.NoError(t, err)
+		assert.Equal(t, "SN-001", sn.SerialNumber)
+		assert.Equal(t, SerialNumberStatusInStock, sn.Status)
+		assert.Equal(t, item.ID, sn.InventoryItemID)
+		assert.Equal(t, item.ProductID, sn.ProductID)
+		require.Len(t, sn.Movements, 1)
+		assert.Equal(t, SerialMovementTypeReceived, sn.Movements[0].MovementType)
+		assert.Equal(t, sn.ID, sn.Movements[0].SerialNumberID)
+		assert.Equal(t, "PO-001", sn.Movements[0].SourceID)
+	})
+
+	t.Run("rejects empty serial number", func(t *testing.T) {
+		_, err := NewSerialNumber(item, "  ", SourceTypePurchaseOrder, "PO-001")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects serial number over 100 characters", func(t *testing.T) {
+		_, err := NewSerialNumber(item, strings.Repeat("X", 101), SourceTypePurchaseOrder, "PO-001")
+		assert.Error(t, err)
+	})
+}
+
+func TestSerialNumber_ShipAndReceive(t *testing.T) {
+	item := createTestInventoryItem(t)
+
+	t.Run("unit in stock cannot be received again", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+
+		err = sn.Receive(item, SourceTypePurchaseOrder, "PO-002")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already in stock")
+	})
+
+	t.Run("shipped unit is returned into stock", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+
+		require.NoError(t, sn.Ship(item, SourceTypeSalesOrder, "SO-001"))
+		assert.Equal(t, SerialNumberStatusShipped, sn.Status)
+		assert.False(t, sn.IsInStock())
+
+		require.NoError(t, sn.Receive(item, SourceTypeSalesReturn, "SR-001"))
+		assert.Equal(t, SerialNumberStatusReturned, sn.Status)
+		assert.True(t, sn.IsInStock())
+		require.Len(t, sn.Movements, 3)
+		assert.Equal(t, SerialMovementTypeShipped, sn.Movements[1].MovementType)
+		assert.Equal(t, SerialMovementTypeReturned, sn.Movements[2].MovementType)
+	})
+
+	t.Run("shipped unit cannot be shipped again", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+		require.NoError(t, sn.Ship(item, SourceTypeSalesOrder, "SO-001"))
+
+		assert.Error(t, sn.Ship(item, SourceTypeSalesOrder, "SO-002"))
+	})
+
+	t.Run("unit cannot be shipped from another warehouse", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+
+		other, err := NewInventoryItem(item.TenantID, uuid.New(), item.ProductID)
+		require.NoError(t, err)
+		assert.Error(t, sn.Ship(other, SourceTypeSalesOrder, "SO-001"))
+	})
+
+	t.Run("unit cannot be returned into another product", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+		require.NoError(t, sn.Ship(item, SourceTypeSalesOrder, "SO-001"))
+
+		other, err := NewInventoryItem(item.TenantID, item.WarehouseID, uuid.New())
+		require.NoError(t, err)
+		assert.Error(t, sn.Receive(other, SourceTypeSalesReturn, "SR-001"))
+	})
+}
+
+func TestSerialNumber_TransferTo(t *testing.T) {
+	item := createTestInventoryItem(t)
+	other, err := NewInventoryItem(item.TenantID, uuid.New(), item.ProductID)
+	require.NoError(t, err)
+
+	t.Run("unit in stock moves to the other warehouse", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+
+		require.NoError(t, sn.TransferTo(item, other, SourceTypeTransfer, "TR-001"))
+		assert.Equal(t, SerialNumberStatusInStock, sn.Status)
+		assert.Equal(t, other.ID, sn.InventoryItemID)
+		assert.Equal(t, other.WarehouseID, sn.WarehouseID)
+		require.Len(t, sn.Movements, 2)
+		assert.Equal(t, SerialMovementTypeTransferred, sn.Movements[1].MovementType)
+		assert.Equal(t, other.WarehouseID, sn.Movements[1].WarehouseID)
+
+		// It is no longer in stock in the source warehouse
+		assert.Error(t, sn.TransferTo(item, other, SourceTypeTransfer, "TR-002"))
+	})
+
+	t.Run("shipped unit cannot be transferred", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+		require.NoError(t, sn.Ship(item, SourceTypeSalesOrder, "SO-001"))
+
+		assert.Error(t, sn.TransferTo(item, other, SourceTypeTransfer, "TR-001"))
+	})
+
+	t.Run("unit cannot be transferred into another product", func(t *testing.T) {
+		sn, err := NewSerialNumber(item, "SN-001", SourceTypePurchaseOrder, "PO-001")
+		require.NoError(t, err)
+
+		otherProduct, err := NewInventoryItem(item.TenantID, uuid.New(), uuid.New())
+		require.NoError(t, err)
+		assert.Error(t, sn.TransferTo(item, otherProduct, SourceTypeTransfer, "TR-001"))
+	})
+}
+
+func TestNormalizeSerialNumbers(t *testing.T) {
+	t.Run("trims serial numbers", func(t *testing.T) {
+		serials, err := NormalizeSerialNumbers([]string{" A ", "B"}, decimal.NewFromInt(2))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A", "B"}, serials)
+	})
+
+	t.Run("rejects count not matching quantity", func(t *testing.T) {
+		_, err := NormalizeSerialNumbers([]string{"A"}, decimal.NewFromInt(2))
+		assert.Error(t, err)
+
+		_, err = NormalizeSerialNumbers([]string{"A"}, decimal.NewFromFloat(1.5))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects duplicates after trimming", func(t *testing.T) {
+		_, err := NormalizeSerialNumbers([]string{"A", " A"}, decimal.NewFromInt(2))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects empty serial number", func(t *testing.T) {
+		_, err := NormalizeSerialNumbers([]string{"A", ""}, decimal.NewFromInt(2))
+		assert.Error(t, err)
+	})
+}
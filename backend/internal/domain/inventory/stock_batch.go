@@ -57,6 +57,14 @@ func (b *StockBatch) WillExpireWithin(duration time.Duration) bool {
 	return b.ExpiryDate.Before(time.Now().Add(duration))
 }
 
+// ExpiresBefore returns true if the batch expires before the given time
+func (b *StockBatch) ExpiresBefore(t time.Time) bool {
+	if b.ExpiryDate == nil {
+		return false
+	}
+	return b.ExpiryDate.Before(t)
+}
+
 // DaysUntilExpiry returns the number of days until expiry, -1 if no expiry date
 func (b *StockBatch) DaysUntilExpiry() int {
 	if b.ExpiryDate == nil {
@@ -0,0 +1,179 @@
+package partner
+
+import (
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// AddressType represents what an address is used for
+type AddressType string
+
+const (
+	AddressTypeBilling  AddressType = "billing"  // Invoices and statements are sent here
+	AddressTypeShipping AddressType = "shipping" // Goods are delivered here
+)
+
+// IsValid checks if the address type is valid
+func (t AddressType) IsValid() bool {
+	switch t {
+	case AddressTypeBilling, AddressTypeShipping:
+		return true
+	default:
+		return false
+	}
+}
+
+// AddressOwnerType represents the kind of partner an address belongs to
+type AddressOwnerType string
+
+const (
+	AddressOwnerCustomer AddressOwnerType = "customer"
+	AddressOwnerSupplier AddressOwnerType = "supplier"
+)
+
+// IsValid checks if the address owner type is valid
+func (t AddressOwnerType) IsValid() bool {
+	switch t {
+	case AddressOwnerCustomer, AddressOwnerSupplier:
+		return true
+	default:
+		return false
+	}
+}
+
+// Address is an entry in the address book of a customer or supplier.
+// A partner can keep several billing and shipping addresses, of which exactly one per type is the default.
+// The type of an address is fixed once created; add a new address to use it for the other purpose.
+type Address struct {
+	shared.BaseEntity
+	TenantID    uuid.UUID
+	OwnerType   AddressOwnerType // customer or supplier
+	OwnerID     uuid.UUID        // Customer or supplier ID
+	Type        AddressType      // billing or shipping
+	Label       string           // Short name shown when picking an address, e.g. "Head office"
+	ContactName string           // Person receiving goods or invoices at this address
+	Phone       string
+	Province    string
+	City        string
+	Detail      string // Street address
+	PostalCode  string
+	Country     string
+	IsDefault   bool // Default address of its type for the owner
+}
+
+// NewAddress creates a new address for a customer or supplier
+func NewAddress(tenantID uuid.UUID, ownerType AddressOwnerType, ownerID uuid.UUID, addressType AddressType, detail string) (*Address, error) {
+	if !ownerType.IsValid() {
+		return nil, shared.NewDomainError("INVALID_ADDRESS_OWNER", "Address owner must be a customer or supplier")
+	}
+	if ownerID == uuid.Nil {
+		return nil, shared.NewDomainError("INVALID_ADDRESS_OWNER", "Address owner ID cannot be empty")
+	}
+	if !addressType.IsValid() {
+		return nil, shared.NewDomainError("INVALID_ADDRESS_TYPE", "Address type must be billing or shipping")
+	}
+	if err := validateAddressDetail(detail); err != nil {
+		return nil, err
+	}
+
+	return &Address{
+		BaseEntity: shared.NewBaseEntity(),
+		TenantID:   tenantID,
+		OwnerType:  ownerType,
+		OwnerID:    ownerID,
+		Type:       addressType,
+		Detail:     detail,
+		Country:    "中国",
+	}, nil
+}
+
+// Update updates the location of the address
+func (a *Address) Update(province, city, detail, postalCode, country string) error {
+	if err := validateAddressDetail(detail); err != nil {
+		return err
+	}
+	if len(city) > 100 {
+		return shared.NewDomainError("INVALID_CITY", "City cannot exceed 100 characters")
+	}
+	if len(province) > 100 {
+		return shared.NewDomainError("INVALID_PROVINCE", "Province cannot exceed 100 characters")
+	}
+	if len(postalCode) > 20 {
+		return shared.NewDomainError("INVALID_POSTAL_CODE", "Postal code cannot exceed 20 characters")
+	}
+	if len(country) > 100 {
+		return shared.NewDomainError("INVALID_COUNTRY", "Country cannot exceed 100 characters")
+	}
+
+	a.Province = province
+	a.City = city
+	a.Detail = detail
+	a.PostalCode = postalCode
+	if country != "" {
+		a.Country = country
+	}
+	a.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetContact sets the label and the contact person of the address
+func (a *Address) SetContact(label, contactName, phone string) error {
+	if len(label) > 100 {
+		return shared.NewDomainError("INVALID_ADDRESS_LABEL", "Address label cannot exceed 100 characters")
+	}
+	if len(contactName) > 100 {
+		return shared.NewDomainError("INVALID_CONTACT_NAME", "Contact name cannot exceed 100 characters")
+	}
+	if len(phone) > 50 {
+		return shared.NewDomainError("INVALID_PHONE", "Phone cannot exceed 50 characters")
+	}
+
+	a.Label = label
+	a.ContactName = contactName
+	a.Phone = phone
+	a.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetAsDefault marks this address as the default of its type.
+// The previous default of the same owner and type must be cleared in the same transaction.
+func (a *Address) SetAsDefault(isDefault bool) {
+	a.IsDefault = isDefault
+	a.UpdatedAt = time.Now()
+}
+
+// BelongsTo returns true if the address is in the address book of the given partner
+func (a *Address) BelongsTo(ownerType AddressOwnerType, ownerID uuid.UUID) bool {
+	return a.OwnerType == ownerType && a.OwnerID == ownerID
+}
+
+// NextDefaultAddress picks the address of the given type that becomes the default
+// when the current default is removed: the oldest remaining one.
+// It returns nil if no other address of the type is left.
+func NextDefaultAddress(addresses []Address, addressType AddressType, removedID uuid.UUID) *Address {
+	var next *Address
+	for i := range addresses {
+		candidate := &addresses[i]
+		if candidate.ID == removedID || candidate.Type != addressType {
+			continue
+		}
+		if next == nil || candidate.CreatedAt.Before(next.CreatedAt) {
+			next = candidate
+		}
+	}
+	return next
+}
+
+func validateAddressDetail(detail string) error {
+	if detail == "" {
+		return shared.NewDomainError("INVALID_ADDRESS", "Address cannot be empty")
+	}
+	if len(detail) > 500 {
+		return shared.NewDomainError("INVALID_ADDRESS", "Address cannot exceed 500 characters")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package partner
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AddressRepository defines the interface for partner address persistence
+type AddressRepository interface {
+	// FindByIDForTenant finds an address by ID within a tenant
+	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*Address, error)
+
+	// FindByOwner finds all addresses of a customer or supplier (defaults first, then oldest first)
+	FindByOwner(ctx context.Context, tenantID uuid.UUID, ownerType AddressOwnerType, ownerID uuid.UUID) ([]Address, error)
+
+	// FindDefault finds the default address of the given type for a customer or supplier
+	FindDefault(ctx context.Context, tenantID uuid.UUID, ownerType AddressOwnerType, ownerID uuid.UUID, addressType AddressType) (*Address, error)
+
+	// Save creates or updates an address
+	Save(ctx context.Context, address *Address) error
+
+	// DeleteForTenant deletes an address within a tenant
+	DeleteForTenant(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// ClearDefault clears the default flag on all addresses of the given type for a customer or supplier
+	ClearDefault(ctx context.Context, tenantID uuid.UUID, ownerType AddressOwnerType, ownerID uuid.UUID, addressType AddressType) error
+}
@@ -0,0 +1,52 @@
+package partner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAddress(t *testing.T) {
+	tenantID := uuid.New()
+	ownerID := uuid.New()
+
+	address, err := NewAddress(tenantID, AddressOwnerCustomer, ownerID, AddressTypeShipping, "1 Dock Rd")
+	require.NoError(t, err)
+	assert.Equal(t, AddressTypeShipping, address.Type)
+	assert.False(t, address.IsDefault)
+	assert.True(t, address.BelongsTo(AddressOwnerCustomer, ownerID))
+	assert.False(t, address.BelongsTo(AddressOwnerSupplier, ownerID))
+
+	_, err = NewAddress(tenantID, AddressOwnerType("partner"), ownerID, AddressTypeShipping, "1 Dock Rd")
+	assertDomainErrorCode(t, err, "INVALID_ADDRESS_OWNER")
+	_, err = NewAddress(tenantID, AddressOwnerCustomer, uuid.Nil, AddressTypeShipping, "1 Dock Rd")
+	assertDomainErrorCode(t, err, "INVALID_ADDRESS_OWNER")
+	_, err = NewAddress(tenantID, AddressOwnerCustomer, ownerID, AddressType("delivery"), "1 Dock Rd")
+	assertDomainErrorCode(t, err, "INVALID_ADDRESS_TYPE")
+	_, err = NewAddress(tenantID, AddressOwnerCustomer, ownerID, AddressTypeBilling, "")
+	assertDomainErrorCode(t, err, "INVALID_ADDRESS")
+}
+
+func TestNextDefaultAddress(t *testing.T) {
+	ownerID := uuid.New()
+	now := time.Now()
+	newAddress := func(addressType AddressType, createdAt time.Time) Address {
+		address, err := NewAddress(uuid.New(), AddressOwnerCustomer, ownerID, addressType, "1 Dock Rd")
+		require.NoError(t, err)
+		address.CreatedAt = createdAt
+		return *address
+	}
+	removed := newAddress(AddressTypeShipping, now.Add(-3*time.Hour))
+	billing := newAddress(AddressTypeBilling, now.Add(-2*time.Hour))
+	older := newAddress(AddressTypeShipping, now.Add(-time.Hour))
+	newer := newAddress(AddressTypeShipping, now)
+
+	next := NextDefaultAddress([]Address{newer, removed, billing, older}, AddressTypeShipping, removed.ID)
+	require.NotNil(t, next)
+	assert.Equal(t, older.ID, next.ID)
+
+	assert.Nil(t, NextDefaultAddress([]Address{removed, billing}, AddressTypeShipping, removed.ID))
+}
@@ -102,8 +102,8 @@ type BalanceTransaction struct {
 	CustomerID      uuid.UUID
 	TransactionType BalanceTransactionType
 	Amount          decimal.Decimal // Always positive, direction determined by type
-	BalanceBefore   decimal.Decimal // Balance before transaction
-	BalanceAfter    decimal.Decimal // Balance after transaction
+	BalanceBefore   decimal.Decimal // Balance before transaction, negative while overdrawn
+	BalanceAfter    decimal.Decimal // Balance after transaction, negative while overdrawn
 	SourceType      BalanceTransactionSourceType
 	SourceID        *string    // ID of source document (optional)
 	Reference       string     // Reference number/code
@@ -112,7 +112,9 @@ type BalanceTransaction struct {
 	TransactionDate time.Time
 }
 
-// NewBalanceTransaction creates a new balance transaction
+// NewBalanceTransaction creates a new balance transaction.
+// Balances may be negative for customers with an overdraft; the customer
+// aggregate decides whether a deduction is allowed.
 func NewBalanceTransaction(
 	tenantID uuid.UUID,
 	customerID uuid.UUID,
@@ -134,12 +136,6 @@ func NewBalanceTransaction(
 	if amount.IsNegative() || amount.IsZero() {
 		return nil, shared.NewDomainError("INVALID_AMOUNT", "Amount must be positive")
 	}
-	if balanceBefore.IsNegative() {
-		return nil, shared.NewDomainError("INVALID_BALANCE", "Balance before cannot be negative")
-	}
-	if balanceAfter.IsNegative() {
-		return nil, shared.NewDomainError("INVALID_BALANCE", "Balance after cannot be negative")
-	}
 	if !sourceType.IsValid() {
 		return nil, shared.NewDomainError("INVALID_SOURCE_TYPE", "Invalid source type")
 	}
@@ -241,9 +237,6 @@ func CreateConsumeTransaction(
 	amount, balanceBefore decimal.Decimal,
 	sourceType BalanceTransactionSourceType,
 ) (*BalanceTransaction, error) {
-	if balanceBefore.LessThan(amount) {
-		return nil, shared.NewDomainError("INSUFFICIENT_BALANCE", "Insufficient balance for consumption")
-	}
 	balanceAfter := balanceBefore.Sub(amount)
 	return NewBalanceTransaction(
 		tenantID,
@@ -285,9 +278,6 @@ func CreateAdjustmentTransaction(
 	if isIncrease {
 		balanceAfter = balanceBefore.Add(amount)
 	} else {
-		if balanceBefore.LessThan(amount) {
-			return nil, shared.NewDomainError("INSUFFICIENT_BALANCE", "Insufficient balance for adjustment")
-		}
 		balanceAfter = balanceBefore.Sub(amount)
 	}
 	return NewBalanceTransaction(
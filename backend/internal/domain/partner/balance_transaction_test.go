@@ -212,7 +212,7 @@ func TestNewBalanceTransaction(t *testing.T) {
 		assert.Contains(t, err.Error(), "positive")
 	})
 
-	t.Run("fails with negative balance before", func(t *testing.T) {
+	t.Run("records recharge of an overdrawn balance", func(t *testing.T) {
 		tx, err := NewBalanceTransaction(
 			tenantID,
 			customerID,
@@ -223,12 +223,11 @@ func TestNewBalanceTransaction(t *testing.T) {
 			BalanceSourceTypeManual,
 		)
 
-		assert.Error(t, err)
-		assert.Nil(t, tx)
-		assert.Contains(t, err.Error(), "Balance before")
+		require.NoError(t, err)
+		assert.True(t, tx.BalanceBefore.Equal(decimal.NewFromFloat(-10.00)))
 	})
 
-	t.Run("fails with negative balance after", func(t *testing.T) {
+	t.Run("records consumption into the overdraft", func(t *testing.T) {
 		tx, err := NewBalanceTransaction(
 			tenantID,
 			customerID,
@@ -239,9 +238,8 @@ func TestNewBalanceTransaction(t *testing.T) {
 			BalanceSourceTypeSalesOrder,
 		)
 
-		assert.Error(t, err)
-		assert.Nil(t, tx)
-		assert.Contains(t, err.Error(), "Balance after")
+		require.NoError(t, err)
+		assert.True(t, tx.BalanceAfter.Equal(decimal.NewFromFloat(-50.00)))
 	})
 
 	t.Run("fails with invalid source type", func(t *testing.T) {
@@ -302,7 +300,8 @@ func TestCreateConsumeTransaction(t *testing.T) {
 		assert.True(t, tx.BalanceAfter.Equal(decimal.NewFromFloat(50.00)))
 	})
 
-	t.Run("fails with insufficient balance", func(t *testing.T) {
+	t.Run("records overdraft use", func(t *testing.T) {
+		// Whether the overdraft may be used is decided by Customer.DeductBalance
 		tx, err := CreateConsumeTransaction(
 			tenantID,
 			customerID,
@@ -311,9 +310,8 @@ func TestCreateConsumeTransaction(t *testing.T) {
 			BalanceSourceTypeSalesOrder,
 		)
 
-		assert.Error(t, err)
-		assert.Nil(t, tx)
-		assert.Contains(t, err.Error(), "Insufficient balance")
+		require.NoError(t, err)
+		assert.True(t, tx.BalanceAfter.Equal(decimal.NewFromFloat(-50.00)))
 	})
 }
 
@@ -374,7 +372,7 @@ func TestCreateAdjustmentTransaction(t *testing.T) {
 		assert.True(t, tx.BalanceAfter.Equal(decimal.NewFromFloat(75.00)))
 	})
 
-	t.Run("fails with insufficient balance for decrease", func(t *testing.T) {
+	t.Run("records decrease into the overdraft", func(t *testing.T) {
 		tx, err := CreateAdjustmentTransaction(
 			tenantID,
 			customerID,
@@ -383,9 +381,8 @@ func TestCreateAdjustmentTransaction(t *testing.T) {
 			decimal.NewFromFloat(100.00),
 		)
 
-		assert.Error(t, err)
-		assert.Nil(t, tx)
-		assert.Contains(t, err.Error(), "Insufficient balance")
+		require.NoError(t, err)
+		assert.True(t, tx.BalanceAfter.Equal(decimal.NewFromFloat(-50.00)))
 	})
 }
 
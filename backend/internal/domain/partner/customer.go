@@ -1,6 +1,7 @@
 package partner
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ const (
 	CustomerStatusActive    CustomerStatus = "active"
 	CustomerStatusInactive  CustomerStatus = "inactive"
 	CustomerStatusSuspended CustomerStatus = "suspended" // Suspended due to credit issues
+	CustomerStatusMerged    CustomerStatus = "merged"    // Duplicate merged into another customer
 )
 
 // CustomerType represents the type of customer
@@ -32,26 +34,31 @@ const (
 // It is the aggregate root for customer-related operations
 type Customer struct {
 	shared.TenantAggregateRoot
-	Code        string
-	Name        string
-	ShortName   string        // Abbreviated name
-	Type        CustomerType  // individual or organization
-	Level       CustomerLevel // Customer tier (stored as code)
-	Status      CustomerStatus
-	ContactName string // Primary contact person
-	Phone       string
-	Email       string
-	Address     string // Full address
-	City        string
-	Province    string
-	PostalCode  string
-	Country     string
-	TaxID       string // Tax identification number
-	CreditLimit decimal.Decimal
-	Balance     decimal.Decimal // Prepaid balance
-	Notes       string
-	SortOrder   int
-	Attributes  string // Custom attributes
+	Code             string
+	Name             string
+	ShortName        string        // Abbreviated name
+	Type             CustomerType  // individual or organization
+	Level            CustomerLevel // Customer tier (stored as code)
+	LevelPeriodStart *time.Time    // Start of the period the customer keeps its spend-based level, nil after a manual change
+	Status           CustomerStatus
+	ContactName      string // Primary contact person
+	Phone            string
+	Email            string
+	Address          string // Full address
+	City             string
+	Province         string
+	PostalCode       string
+	Country          string
+	TaxID            string // Tax identification number
+	CreditLimit      decimal.Decimal
+	Balance          decimal.Decimal // Prepaid balance, negative while an overdraft is used
+	OverdraftLimit   decimal.Decimal // How far the balance may go below zero, zero for prepaid customers
+	Notes            string
+	SortOrder        int
+	Attributes       string     // Custom attributes
+	MergedIntoID     *uuid.UUID // Surviving customer, set once this customer is merged
+	MergedAt         *time.Time // Set when the customer is merged into another customer
+	DeletedAt        *time.Time // Set when the customer is soft deleted
 }
 
 // NewCustomer creates a new customer with required fields
@@ -75,6 +82,7 @@ func NewCustomer(tenantID uuid.UUID, code, name string, customerType CustomerTyp
 		Status:              CustomerStatusActive,
 		CreditLimit:         decimal.Zero,
 		Balance:             decimal.Zero,
+		OverdraftLimit:      decimal.Zero,
 		Country:             "中国",
 		Attributes:          "{}",
 	}
@@ -192,7 +200,8 @@ func (c *Customer) SetTaxID(taxID string) error {
 	return nil
 }
 
-// SetLevel sets the customer's tier level
+// SetLevel sets the customer's tier level.
+// A manually set level starts a new level period at the next spend evaluation.
 func (c *Customer) SetLevel(level CustomerLevel) error {
 	if err := validateCustomerLevel(level); err != nil {
 		return err
@@ -200,6 +209,7 @@ func (c *Customer) SetLevel(level CustomerLevel) error {
 
 	oldLevel := c.Level
 	c.Level = level
+	c.LevelPeriodStart = nil
 	c.UpdatedAt = time.Now()
 
 	c.AddDomainEvent(NewCustomerLevelChangedEvent(c, oldLevel, level))
@@ -219,8 +229,28 @@ func (c *Customer) SetCreditLimit(limit decimal.Decimal) error {
 	return nil
 }
 
+// SetOverdraftLimit sets how far the balance may go below zero.
+// The limit cannot be lowered below an overdraft that is already used.
+func (c *Customer) SetOverdraftLimit(limit decimal.Decimal) error {
+	if limit.IsNegative() {
+		return shared.NewDomainError("INVALID_OVERDRAFT_LIMIT", "Overdraft limit cannot be negative")
+	}
+	if c.Balance.Add(limit).IsNegative() {
+		return shared.NewDomainError("OVERDRAFT_IN_USE",
+			fmt.Sprintf("Overdraft limit cannot be lower than the overdrawn amount of %s", c.Balance.Neg().StringFixed(2)))
+	}
+
+	c.OverdraftLimit = limit
+	c.UpdatedAt = time.Now()
+
+	return nil
+}
+
 // AddBalance adds to the customer's prepaid balance (deposit/recharge)
 func (c *Customer) AddBalance(amount decimal.Decimal) error {
+	if c.IsMerged() {
+		return errCustomerMerged()
+	}
 	if amount.IsNegative() {
 		return shared.NewDomainError("INVALID_AMOUNT", "Amount cannot be negative")
 	}
@@ -245,8 +275,8 @@ func (c *Customer) DeductBalance(amount decimal.Decimal) error {
 	if amount.IsZero() {
 		return shared.NewDomainError("INVALID_AMOUNT", "Amount cannot be zero")
 	}
-	if c.Balance.LessThan(amount) {
-		return shared.NewDomainError("INSUFFICIENT_BALANCE", "Insufficient balance")
+	if spendable := c.SpendableBalance(); spendable.LessThan(amount) {
+		return shared.NewInsufficientBalanceError(spendable, amount)
 	}
 
 	oldBalance := c.Balance
@@ -260,6 +290,9 @@ func (c *Customer) DeductBalance(amount decimal.Decimal) error {
 
 // RefundBalance refunds to the customer's prepaid balance
 func (c *Customer) RefundBalance(amount decimal.Decimal) error {
+	if c.IsMerged() {
+		return errCustomerMerged()
+	}
 	if amount.IsNegative() {
 		return shared.NewDomainError("INVALID_AMOUNT", "Amount cannot be negative")
 	}
@@ -306,6 +339,9 @@ func (c *Customer) SetAttributes(attributes string) error {
 
 // Activate activates the customer
 func (c *Customer) Activate() error {
+	if c.IsMerged() {
+		return errCustomerMerged()
+	}
 	if c.Status == CustomerStatusActive {
 		return shared.NewDomainError("ALREADY_ACTIVE", "Customer is already active")
 	}
@@ -321,6 +357,9 @@ func (c *Customer) Activate() error {
 
 // Deactivate deactivates the customer
 func (c *Customer) Deactivate() error {
+	if c.IsMerged() {
+		return errCustomerMerged()
+	}
 	if c.Status == CustomerStatusInactive {
 		return shared.NewDomainError("ALREADY_INACTIVE", "Customer is already inactive")
 	}
@@ -336,6 +375,9 @@ func (c *Customer) Deactivate() error {
 
 // Suspend suspends the customer (e.g., due to credit issues)
 func (c *Customer) Suspend() error {
+	if c.IsMerged() {
+		return errCustomerMerged()
+	}
 	if c.Status == CustomerStatusSuspended {
 		return shared.NewDomainError("ALREADY_SUSPENDED", "Customer is already suspended")
 	}
@@ -364,6 +406,11 @@ func (c *Customer) IsSuspended() bool {
 	return c.Status == CustomerStatusSuspended
 }
 
+// IsMerged returns true if the customer was merged into another customer
+func (c *Customer) IsMerged() bool {
+	return c.Status == CustomerStatusMerged
+}
+
 // IsIndividual returns true if customer is an individual
 func (c *Customer) IsIndividual() bool {
 	return c.Type == CustomerTypeIndividual
@@ -379,9 +426,14 @@ func (c *Customer) HasCreditLimit() bool {
 	return c.CreditLimit.GreaterThan(decimal.Zero)
 }
 
-// HasBalance returns true if customer has prepaid balance
+// HasBalance returns true if customer has a prepaid or overdrawn balance
 func (c *Customer) HasBalance() bool {
-	return c.Balance.GreaterThan(decimal.Zero)
+	return !c.Balance.IsZero()
+}
+
+// SpendableBalance returns the amount that can still be deducted, including the unused overdraft
+func (c *Customer) SpendableBalance() decimal.Decimal {
+	return c.Balance.Add(c.OverdraftLimit)
 }
 
 // GetAvailableCredit returns the available credit for the customer
@@ -17,6 +17,7 @@ const (
 	EventTypeCustomerLevelChanged   = "CustomerLevelChanged"
 	EventTypeCustomerBalanceChanged = "CustomerBalanceChanged"
 	EventTypeCustomerDeleted        = "CustomerDeleted"
+	EventTypeCustomerMerged         = "CustomerMerged"
 )
 
 // CustomerCreatedEvent is published when a new customer is created
@@ -112,7 +113,7 @@ type CustomerBalanceChangedEvent struct {
 	Code       string          `json:"code"`
 	OldBalance decimal.Decimal `json:"old_balance"`
 	NewBalance decimal.Decimal `json:"new_balance"`
-	Reason     string          `json:"reason"` // "recharge", "deduction", "refund"
+	Reason     string          `json:"reason"` // "recharge", "deduction", "refund", "merge"
 }
 
 // NewCustomerBalanceChangedEvent creates a new CustomerBalanceChangedEvent
@@ -145,6 +146,29 @@ func NewCustomerDeletedEvent(customer *Customer) *CustomerDeletedEvent {
 	}
 }
 
+// CustomerMergedEvent is published when a duplicate customer is merged into a surviving customer.
+// Subscribers drop cached data and access rules of the merged customer.
+type CustomerMergedEvent struct {
+	shared.BaseDomainEvent
+	SurvivingCustomerID uuid.UUID       `json:"surviving_customer_id"`
+	SurvivingCode       string          `json:"surviving_code"`
+	MergedCustomerID    uuid.UUID       `json:"merged_customer_id"`
+	MergedCode          string          `json:"merged_code"`
+	BalanceTransferred  decimal.Decimal `json:"balance_transferred"`
+}
+
+// NewCustomerMergedEvent creates a new CustomerMergedEvent
+func NewCustomerMergedEvent(surviving, merged *Customer, balanceTransferred decimal.Decimal) *CustomerMergedEvent {
+	return &CustomerMergedEvent{
+		BaseDomainEvent:     shared.NewBaseDomainEvent(EventTypeCustomerMerged, AggregateTypeCustomer, surviving.ID, surviving.TenantID),
+		SurvivingCustomerID: surviving.ID,
+		SurvivingCode:       surviving.Code,
+		MergedCustomerID:    merged.ID,
+		MergedCode:          merged.Code,
+		BalanceTransferred:  balanceTransferred,
+	}
+}
+
 // =============================================================================
 // Balance Transaction Events (per spec.md section 17.5)
 // =============================================================================
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -323,20 +324,56 @@ func (cl CustomerLevel) WithDetails(name string, discountRate decimal.Decimal) C
 	}
 }
 
+// DefaultSpendWindowDays is the rolling window over which qualifying spend is summed
+// when a level definition does not set its own
+const DefaultSpendWindowDays = 365
+
 // CustomerLevelRecord represents a row in the customer_levels database table
 // This is used for GORM persistence of customer level definitions
 type CustomerLevelRecord struct {
-	ID           uuid.UUID
-	TenantID     uuid.UUID
-	Code         string
-	Name         string
-	DiscountRate decimal.Decimal
-	SortOrder    int
-	IsDefault    bool
-	IsActive     bool
-	Description  string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID               uuid.UUID
+	TenantID         uuid.UUID
+	Code             string
+	Name             string
+	DiscountRate     decimal.Decimal
+	SortOrder        int
+	IsDefault        bool
+	IsActive         bool
+	Description      string
+	UpgradeThreshold decimal.Decimal // Qualifying spend that promotes a customer to this level, zero if assigned manually only
+	SpendWindowDays  int             // Rolling window for the qualifying spend, also the period a customer keeps the level
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsAutomatic returns true if customers are promoted to this level by their spend
+func (r *CustomerLevelRecord) IsAutomatic() bool {
+	return r.UpgradeThreshold.IsPositive()
+}
+
+// SpendWindow returns the rolling window over which qualifying spend is summed
+func (r *CustomerLevelRecord) SpendWindow() time.Duration {
+	return time.Duration(r.spendWindowDays()) * 24 * time.Hour
+}
+
+func (r *CustomerLevelRecord) spendWindowDays() int {
+	if r.SpendWindowDays <= 0 {
+		return DefaultSpendWindowDays
+	}
+	return r.SpendWindowDays
+}
+
+// SetUpgradeRule sets the qualifying spend threshold and the rolling window in days
+func (r *CustomerLevelRecord) SetUpgradeRule(threshold decimal.Decimal, windowDays int) error {
+	if threshold.IsNegative() {
+		return shared.NewDomainError("INVALID_UPGRADE_THRESHOLD", "Upgrade threshold cannot be negative")
+	}
+	if windowDays <= 0 {
+		return shared.NewDomainError("INVALID_SPEND_WINDOW", "Spend window must be at least one day")
+	}
+	r.UpgradeThreshold = threshold
+	r.SpendWindowDays = windowDays
+	return nil
 }
 
 // ToCustomerLevel converts a database record to a CustomerLevel value object
@@ -351,16 +388,17 @@ func (r *CustomerLevelRecord) ToCustomerLevel() CustomerLevel {
 // NewCustomerLevelRecord creates a new CustomerLevelRecord for database insertion
 func NewCustomerLevelRecord(tenantID uuid.UUID, level CustomerLevel, sortOrder int, isDefault bool) *CustomerLevelRecord {
 	return &CustomerLevelRecord{
-		ID:           uuid.New(),
-		TenantID:     tenantID,
-		Code:         level.Code(),
-		Name:         level.Name(),
-		DiscountRate: level.DiscountRate(),
-		SortOrder:    sortOrder,
-		IsDefault:    isDefault,
-		IsActive:     true,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:              uuid.New(),
+		TenantID:        tenantID,
+		Code:            level.Code(),
+		Name:            level.Name(),
+		DiscountRate:    level.DiscountRate(),
+		SortOrder:       sortOrder,
+		IsDefault:       isDefault,
+		IsActive:        true,
+		SpendWindowDays: DefaultSpendWindowDays,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}
 }
 
@@ -0,0 +1,117 @@
+package partner
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CustomerSpend is a customer's qualifying spend, keyed by the spend window in days it was summed over
+type CustomerSpend map[int]decimal.Decimal
+
+// SpendWindows returns the distinct spend windows, in days, of the active spend-based levels.
+// The qualifying spend of a customer must be summed over each of them.
+func SpendWindows(levels []*CustomerLevelRecord) []int {
+	var windows []int
+	seen := make(map[int]bool)
+	for _, level := range levels {
+		if !level.IsActive || level.IsDefault || !level.IsAutomatic() {
+			continue
+		}
+		days := level.spendWindowDays()
+		if !seen[days] {
+			seen[days] = true
+			windows = append(windows, days)
+		}
+	}
+	return windows
+}
+
+// ApplySpendLevel moves the customer to the highest active level its qualifying spend reaches.
+// Promotions apply at once and start a new level period. Demotions only apply once the
+// current level period has ended, so a promoted customer keeps the level for at least its
+// spend window. Customers on a level that is assigned manually only are left alone.
+// It returns true if the customer changed and must be saved.
+func (c *Customer) ApplySpendLevel(levels []*CustomerLevelRecord, spend CustomerSpend, now time.Time) bool {
+	if c.IsMerged() {
+		return false
+	}
+
+	var current, base *CustomerLevelRecord
+	for _, level := range levels {
+		if !level.IsActive {
+			continue
+		}
+		if level.Code == c.Level.Code() {
+			current = level
+		}
+		if level.IsDefault {
+			base = level
+		}
+	}
+	if current == nil || base == nil || (!current.IsDefault && !current.IsAutomatic()) {
+		return false
+	}
+
+	target := base
+	for _, level := range levels {
+		if !level.IsActive || level.IsDefault || !level.IsAutomatic() {
+			continue
+		}
+		if spend[level.spendWindowDays()].LessThan(level.UpgradeThreshold) {
+			continue
+		}
+		if ranksAbove(level, target) {
+			target = level
+		}
+	}
+	if target == current && current.IsDefault {
+		// Customers on the default level have no level period to keep
+		return false
+	}
+
+	switch {
+	case ranksAbove(target, current):
+		c.changeSpendLevel(target, now)
+	case c.LevelPeriodStart == nil:
+		// First evaluation since the level was set manually
+		c.LevelPeriodStart = &now
+		c.UpdatedAt = now
+	case now.Before(c.LevelPeriodStart.Add(current.SpendWindow())):
+		// Mid-period, the customer keeps its level even if its spend dropped
+		return false
+	case target != current:
+		c.changeSpendLevel(target, now)
+	default:
+		// The period ended and the customer still qualifies, so it keeps the level for another period
+		c.LevelPeriodStart = &now
+		c.UpdatedAt = now
+	}
+	return true
+}
+
+// changeSpendLevel moves the customer to a level reached by its spend and starts a new level period
+func (c *Customer) changeSpendLevel(level *CustomerLevelRecord, now time.Time) {
+	oldLevel := c.Level
+	c.Level = level.ToCustomerLevel()
+	c.LevelPeriodStart = &now
+	c.UpdatedAt = now
+
+	c.AddDomainEvent(NewCustomerLevelChangedEvent(c, oldLevel, c.Level))
+}
+
+// ranksAbove returns true if level a is a higher spend-based level than level b.
+// The default level ranks lowest, higher thresholds rank higher, and ties go to the higher sort order.
+func ranksAbove(a, b *CustomerLevelRecord) bool {
+	thresholdA, thresholdB := a.UpgradeThreshold, b.UpgradeThreshold
+	if a.IsDefault {
+		thresholdA = decimal.Zero
+	}
+	if b.IsDefault {
+		thresholdB = decimal.Zero
+	}
+	if !thresholdA.Equal(thresholdB) {
+		return thresholdA.GreaterThan(thresholdB)
+	}
+	return a.SortOrder > b.SortOrder
+}
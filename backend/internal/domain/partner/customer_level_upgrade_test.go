@@ -0,0 +1,172 @@
+package partner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spendLevels returns the default levels with silver reached at 1000 and gold at 5000 over 30 days
+func spendLevels(t *testing.T, tenantID uuid.UUID) []*CustomerLevelRecord {
+	t.Helper()
+	records := DefaultCustomerLevelRecords(tenantID)
+	for _, record := range records {
+		switch record.Code {
+		case CustomerLevelCodeSilver:
+			require.NoError(t, record.SetUpgradeRule(decimal.NewFromInt(1000), 30))
+		case CustomerLevelCodeGold:
+			require.NoError(t, record.SetUpgradeRule(decimal.NewFromInt(5000), 30))
+		}
+	}
+	return records
+}
+
+func TestSpendWindows(t *testing.T) {
+	tenantID := uuid.New()
+	levels := spendLevels(t, tenantID)
+
+	assert.Equal(t, []int{30}, SpendWindows(levels))
+	assert.Empty(t, SpendWindows(DefaultCustomerLevelRecords(tenantID)))
+}
+
+func TestCustomerApplySpendLevel(t *testing.T) {
+	tenantID := uuid.New()
+	levels := spendLevels(t, tenantID)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	spendOf := func(amount int64) CustomerSpend {
+		return CustomerSpend{30: decimal.NewFromInt(amount)}
+	}
+	newCustomer := func(t *testing.T) *Customer {
+		customer, err := NewIndividualCustomer(tenantID, "CUST001", "Test Customer")
+		require.NoError(t, err)
+		customer.ClearDomainEvents()
+		return customer
+	}
+
+	t.Run("promotes to the highest level reached", func(t *testing.T) {
+		customer := newCustomer(t)
+
+		changed := customer.ApplySpendLevel(levels, spendOf(6000), now)
+
+		require.True(t, changed)
+		assert.Equal(t, CustomerLevelCodeGold, customer.Level.Code())
+		assert.True(t, customer.Level.DiscountRate().Equal(GoldLevel().DiscountRate()))
+		require.NotNil(t, customer.LevelPeriodStart)
+		assert.Equal(t, now, *customer.LevelPeriodStart)
+
+		events := customer.GetDomainEvents()
+		require.Len(t, events, 1)
+		levelEvent, ok := events[0].(*CustomerLevelChangedEvent)
+		require.True(t, ok)
+		assert.Equal(t, CustomerLevelCodeNormal, levelEvent.OldLevel.Code())
+		assert.Equal(t, CustomerLevelCodeGold, levelEvent.NewLevel.Code())
+	})
+
+	t.Run("leaves customers below every threshold on the default level", func(t *testing.T) {
+		customer := newCustomer(t)
+
+		assert.False(t, customer.ApplySpendLevel(levels, spendOf(999), now))
+		assert.Equal(t, CustomerLevelCodeNormal, customer.Level.Code())
+		assert.Empty(t, customer.GetDomainEvents())
+	})
+
+	t.Run("does not demote mid-period", func(t *testing.T) {
+		customer := newCustomer(t)
+		require.True(t, customer.ApplySpendLevel(levels, spendOf(6000), now))
+		customer.ClearDomainEvents()
+
+		changed := customer.ApplySpendLevel(levels, spendOf(1500), now.AddDate(0, 0, 29))
+
+		assert.False(t, changed)
+		assert.Equal(t, CustomerLevelCodeGold, customer.Level.Code())
+		assert.Empty(t, customer.GetDomainEvents())
+	})
+
+	t.Run("demotes at the period boundary", func(t *testing.T) {
+		customer := newCustomer(t)
+		require.True(t, customer.ApplySpendLevel(levels, spendOf(6000), now))
+		customer.ClearDomainEvents()
+		boundary := now.AddDate(0, 0, 30)
+
+		changed := customer.ApplySpendLevel(levels, spendOf(1500), boundary)
+
+		require.True(t, changed)
+		assert.Equal(t, CustomerLevelCodeSilver, customer.Level.Code())
+		assert.Equal(t, boundary, *customer.LevelPeriodStart)
+		assert.Len(t, customer.GetDomainEvents(), 1)
+	})
+
+	t.Run("starts a new period when the level is kept at the boundary", func(t *testing.T) {
+		customer := newCustomer(t)
+		require.True(t, customer.ApplySpendLevel(levels, spendOf(6000), now))
+		customer.ClearDomainEvents()
+		boundary := now.AddDate(0, 0, 31)
+
+		changed := customer.ApplySpendLevel(levels, spendOf(5000), boundary)
+
+		require.True(t, changed)
+		assert.Equal(t, CustomerLevelCodeGold, customer.Level.Code())
+		assert.Equal(t, boundary, *customer.LevelPeriodStart)
+		assert.Empty(t, customer.GetDomainEvents())
+	})
+
+	t.Run("promotes mid-period", func(t *testing.T) {
+		customer := newCustomer(t)
+		require.True(t, customer.ApplySpendLevel(levels, spendOf(1000), now))
+		later := now.AddDate(0, 0, 5)
+
+		require.True(t, customer.ApplySpendLevel(levels, spendOf(5000), later))
+		assert.Equal(t, CustomerLevelCodeGold, customer.Level.Code())
+		assert.Equal(t, later, *customer.LevelPeriodStart)
+	})
+
+	t.Run("does not demote a manually set level until a period has passed", func(t *testing.T) {
+		customer := newCustomer(t)
+		require.NoError(t, customer.SetLevel(GoldLevel()))
+		customer.ClearDomainEvents()
+
+		require.True(t, customer.ApplySpendLevel(levels, spendOf(0), now))
+		assert.Equal(t, CustomerLevelCodeGold, customer.Level.Code())
+		assert.Equal(t, now, *customer.LevelPeriodStart)
+		assert.Empty(t, customer.GetDomainEvents())
+	})
+
+	t.Run("leaves manually assigned levels alone", func(t *testing.T) {
+		customer := newCustomer(t)
+		require.NoError(t, customer.SetLevel(VIPLevel()))
+		customer.ClearDomainEvents()
+
+		assert.False(t, customer.ApplySpendLevel(levels, spendOf(0), now.AddDate(1, 0, 0)))
+		assert.Equal(t, CustomerLevelCodeVIP, customer.Level.Code())
+	})
+
+	t.Run("ignores inactive levels", func(t *testing.T) {
+		customer := newCustomer(t)
+		inactive := spendLevels(t, tenantID)
+		for _, level := range inactive {
+			if level.Code == CustomerLevelCodeGold {
+				level.IsActive = false
+			}
+		}
+
+		require.True(t, customer.ApplySpendLevel(inactive, spendOf(6000), now))
+		assert.Equal(t, CustomerLevelCodeSilver, customer.Level.Code())
+	})
+}
+
+func TestCustomerLevelRecordSetUpgradeRule(t *testing.T) {
+	record := NewCustomerLevelRecord(uuid.New(), GoldLevel(), 2, false)
+	assert.Equal(t, DefaultSpendWindowDays, record.SpendWindowDays)
+	assert.False(t, record.IsAutomatic())
+
+	require.NoError(t, record.SetUpgradeRule(decimal.NewFromInt(5000), 90))
+	assert.True(t, record.IsAutomatic())
+	assert.Equal(t, 90*24*time.Hour, record.SpendWindow())
+
+	assertDomainErrorCode(t, record.SetUpgradeRule(decimal.NewFromInt(-1), 90), "INVALID_UPGRADE_THRESHOLD")
+	assertDomainErrorCode(t, record.SetUpgradeRule(decimal.NewFromInt(5000), 0), "INVALID_SPEND_WINDOW")
+}
@@ -0,0 +1,77 @@
+package partner
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CustomerReassignment counts the records moved from a merged customer to the surviving customer
+type CustomerReassignment struct {
+	SalesOrders         int64
+	SalesReturns        int64
+	Receivables         int64
+	ReceiptVouchers     int64
+	RefundRecords       int64
+	BalanceTransactions int64
+}
+
+// CustomerReferenceRepository moves records owned by other contexts between customers.
+// It is used when duplicate customers are merged.
+type CustomerReferenceRepository interface {
+	// ReassignCustomer points every record of the from customer to the to customer,
+	// copying the to customer's name where records keep a denormalized copy
+	ReassignCustomer(ctx context.Context, tenantID, fromID uuid.UUID, to *Customer) (CustomerReassignment, error)
+}
+
+// MergeCustomers merges a duplicate customer into the surviving customer.
+// The merged customer's balance moves to the survivor and the merged customer
+// is marked as merged instead of being deleted, so its history stays readable.
+// It returns the balance that was moved, which is negative for an overdrawn customer.
+func MergeCustomers(surviving, merged *Customer) (decimal.Decimal, error) {
+	if surviving.ID == merged.ID {
+		return decimal.Zero, shared.NewDomainError("CANNOT_MERGE_SELF", "Cannot merge a customer into itself")
+	}
+	if surviving.TenantID != merged.TenantID {
+		return decimal.Zero, shared.NewDomainError("CROSS_TENANT_MERGE", "Cannot merge customers of different tenants")
+	}
+	if surviving.IsMerged() || merged.IsMerged() {
+		return decimal.Zero, errCustomerMerged()
+	}
+
+	now := time.Now()
+	transferred := merged.Balance
+	if !transferred.IsZero() {
+		// An overdrawn duplicate moves its debt, which must fit the survivor's overdraft
+		if spendable := surviving.SpendableBalance(); transferred.IsNegative() && spendable.LessThan(transferred.Neg()) {
+			return decimal.Zero, shared.NewInsufficientBalanceError(spendable, transferred.Neg())
+		}
+		oldBalance := surviving.Balance
+		surviving.Balance = surviving.Balance.Add(transferred)
+		surviving.AddDomainEvent(NewCustomerBalanceChangedEvent(surviving, oldBalance, surviving.Balance, "merge"))
+
+		merged.Balance = decimal.Zero
+		merged.AddDomainEvent(NewCustomerBalanceChangedEvent(merged, transferred, merged.Balance, "merge"))
+	}
+	surviving.UpdatedAt = now
+
+	oldStatus := merged.Status
+	merged.Status = CustomerStatusMerged
+	mergedInto := surviving.ID
+	merged.MergedIntoID = &mergedInto
+	merged.MergedAt = &now
+	merged.UpdatedAt = now
+	merged.AddDomainEvent(NewCustomerStatusChangedEvent(merged, oldStatus, CustomerStatusMerged))
+
+	surviving.AddDomainEvent(NewCustomerMergedEvent(surviving, merged, transferred))
+
+	return transferred, nil
+}
+
+// errCustomerMerged is returned when a merged customer is changed
+func errCustomerMerged() error {
+	return shared.NewDomainError("CUSTOMER_MERGED", "Customer has been merged into another customer")
+}
@@ -0,0 +1,140 @@
+package partner
+
+import (
+	"testing"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertDomainErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, code, domainErr.Code)
+}
+
+func TestMergeCustomers(t *testing.T) {
+	tenantID := uuid.New()
+	newPair := func(t *testing.T) (*Customer, *Customer) {
+		surviving, err := NewOrganizationCustomer(tenantID, "ACME", "Acme Corp")
+		require.NoError(t, err)
+		merged, err := NewOrganizationCustomer(tenantID, "ACME-2", "Acme Corporation")
+		require.NoError(t, err)
+		surviving.ClearDomainEvents()
+		merged.ClearDomainEvents()
+		return surviving, merged
+	}
+
+	t.Run("moves the balance and marks the duplicate as merged", func(t *testing.T) {
+		surviving, merged := newPair(t)
+		surviving.Balance = decimal.NewFromInt(100)
+		merged.Balance = decimal.NewFromInt(40)
+
+		transferred, err := MergeCustomers(surviving, merged)
+
+		require.NoError(t, err)
+		assert.True(t, transferred.Equal(decimal.NewFromInt(40)))
+		assert.True(t, surviving.Balance.Equal(decimal.NewFromInt(140)))
+		assert.True(t, merged.Balance.IsZero())
+		assert.True(t, merged.IsMerged())
+		assert.Equal(t, surviving.ID, *merged.MergedIntoID)
+		assert.NotNil(t, merged.MergedAt)
+		assert.True(t, surviving.IsActive())
+
+		events := surviving.GetDomainEvents()
+		require.NotEmpty(t, events)
+		mergedEvent, ok := events[len(events)-1].(*CustomerMergedEvent)
+		require.True(t, ok)
+		assert.Equal(t, EventTypeCustomerMerged, mergedEvent.EventType())
+		assert.Equal(t, merged.ID, mergedEvent.MergedCustomerID)
+		assert.Equal(t, "ACME-2", mergedEvent.MergedCode)
+		assert.True(t, mergedEvent.BalanceTransferred.Equal(decimal.NewFromInt(40)))
+	})
+
+	t.Run("without balance", func(t *testing.T) {
+		surviving, merged := newPair(t)
+
+		transferred, err := MergeCustomers(surviving, merged)
+
+		require.NoError(t, err)
+		assert.True(t, transferred.IsZero())
+		assert.True(t, surviving.Balance.IsZero())
+		assert.True(t, merged.IsMerged())
+	})
+
+	t.Run("moves the debt of an overdrawn duplicate", func(t *testing.T) {
+		surviving, merged := newPair(t)
+		surviving.Balance = decimal.NewFromInt(100)
+		merged.Balance = decimal.NewFromInt(-30)
+		merged.OverdraftLimit = decimal.NewFromInt(50)
+
+		transferred, err := MergeCustomers(surviving, merged)
+
+		require.NoError(t, err)
+		assert.True(t, transferred.Equal(decimal.NewFromInt(-30)))
+		assert.True(t, surviving.Balance.Equal(decimal.NewFromInt(70)))
+		assert.True(t, merged.Balance.IsZero())
+	})
+
+	t.Run("rejects debt beyond the survivor's overdraft", func(t *testing.T) {
+		surviving, merged := newPair(t)
+		surviving.Balance = decimal.NewFromInt(10)
+		merged.Balance = decimal.NewFromInt(-30)
+		merged.OverdraftLimit = decimal.NewFromInt(50)
+
+		_, err := MergeCustomers(surviving, merged)
+
+		var balanceErr *shared.InsufficientBalanceError
+		require.ErrorAs(t, err, &balanceErr)
+		assert.True(t, balanceErr.Available.Equal(decimal.NewFromInt(10)))
+		assert.False(t, merged.IsMerged())
+		assert.True(t, surviving.Balance.Equal(decimal.NewFromInt(10)))
+	})
+
+	t.Run("rejects merging a customer into itself", func(t *testing.T) {
+		surviving, _ := newPair(t)
+		_, err := MergeCustomers(surviving, surviving)
+		assertDomainErrorCode(t, err, "CANNOT_MERGE_SELF")
+		assert.False(t, surviving.IsMerged())
+	})
+
+	t.Run("rejects merging across tenants", func(t *testing.T) {
+		surviving, _ := newPair(t)
+		other, err := NewOrganizationCustomer(uuid.New(), "ACME-2", "Acme Corporation")
+		require.NoError(t, err)
+		other.Balance = decimal.NewFromInt(10)
+
+		_, err = MergeCustomers(surviving, other)
+
+		assertDomainErrorCode(t, err, "CROSS_TENANT_MERGE")
+		assert.True(t, other.Balance.Equal(decimal.NewFromInt(10)))
+		assert.False(t, other.IsMerged())
+	})
+
+	t.Run("rejects customers that are already merged", func(t *testing.T) {
+		surviving, merged := newPair(t)
+		_, err := MergeCustomers(surviving, merged)
+		require.NoError(t, err)
+
+		third, err := NewOrganizationCustomer(tenantID, "ACME-3", "ACME")
+		require.NoError(t, err)
+		_, err = MergeCustomers(third, merged)
+		assertDomainErrorCode(t, err, "CUSTOMER_MERGED")
+		_, err = MergeCustomers(merged, third)
+		assertDomainErrorCode(t, err, "CUSTOMER_MERGED")
+	})
+
+	t.Run("merged customer cannot be reactivated or recharged", func(t *testing.T) {
+		surviving, merged := newPair(t)
+		_, err := MergeCustomers(surviving, merged)
+		require.NoError(t, err)
+
+		assertDomainErrorCode(t, merged.Activate(), "CUSTOMER_MERGED")
+		assertDomainErrorCode(t, merged.Suspend(), "CUSTOMER_MERGED")
+		assertDomainErrorCode(t, merged.AddBalance(decimal.NewFromInt(5)), "CUSTOMER_MERGED")
+	})
+}
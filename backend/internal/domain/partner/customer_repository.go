@@ -2,6 +2,7 @@ package partner
 
 import (
 	"context"
+	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
@@ -15,6 +16,9 @@ type CustomerRepository interface {
 	// FindByIDForTenant finds a customer by ID within a tenant
 	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*Customer, error)
 
+	// FindDeletedByIDForTenant finds a soft-deleted customer by ID within a tenant
+	FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*Customer, error)
+
 	// FindByCode finds a customer by its code within a tenant
 	FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*Customer, error)
 
@@ -51,6 +55,10 @@ type CustomerRepository interface {
 	// FindWithPositiveBalance finds customers with prepaid balance > 0
 	FindWithPositiveBalance(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]Customer, error)
 
+	// FindLevelPeriodEnded finds customers across all tenants whose spend-based level period,
+	// the spend window of their current non-default level, ended on or before asOf
+	FindLevelPeriodEnded(ctx context.Context, asOf time.Time) ([]Customer, error)
+
 	// Save creates or updates a customer
 	Save(ctx context.Context, customer *Customer) error
 
@@ -67,6 +75,9 @@ type CustomerRepository interface {
 	// DeleteForTenant deletes a customer within a tenant
 	DeleteForTenant(ctx context.Context, tenantID, id uuid.UUID) error
 
+	// RestoreForTenant restores a soft-deleted customer within a tenant
+	RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error
+
 	// Count counts customers matching the filter
 	Count(ctx context.Context, filter shared.Filter) (int64, error)
 
@@ -3,6 +3,7 @@ package partner
 import (
 	"testing"
 
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -271,6 +272,70 @@ func TestCustomerCreditLimit(t *testing.T) {
 	})
 }
 
+func TestCustomerOverdraftLimit(t *testing.T) {
+	tenantID := uuid.New()
+	newCustomer := func(t *testing.T, balance int64) *Customer {
+		customer, err := NewCustomer(tenantID, "CUST001", "Test Customer", CustomerTypeIndividual)
+		require.NoError(t, err)
+		customer.Balance = decimal.NewFromInt(balance)
+		customer.ClearDomainEvents()
+		return customer
+	}
+
+	t.Run("prepaid customers have no overdraft", func(t *testing.T) {
+		customer := newCustomer(t, 100)
+
+		assert.True(t, customer.OverdraftLimit.IsZero())
+		assert.True(t, customer.SpendableBalance().Equal(decimal.NewFromInt(100)))
+	})
+
+	t.Run("deducts into the overdraft", func(t *testing.T) {
+		customer := newCustomer(t, 100)
+		require.NoError(t, customer.SetOverdraftLimit(decimal.NewFromInt(50)))
+
+		err := customer.DeductBalance(decimal.NewFromInt(150))
+
+		require.NoError(t, err)
+		assert.True(t, customer.Balance.Equal(decimal.NewFromInt(-50)))
+		assert.True(t, customer.SpendableBalance().IsZero())
+		assert.True(t, customer.HasBalance())
+	})
+
+	t.Run("rejects a deduction past the overdraft with the spendable amount", func(t *testing.T) {
+		customer := newCustomer(t, 100)
+		require.NoError(t, customer.SetOverdraftLimit(decimal.NewFromInt(50)))
+
+		err := customer.DeductBalance(decimal.NewFromInt(151))
+
+		var balanceErr *shared.InsufficientBalanceError
+		require.ErrorAs(t, err, &balanceErr)
+		assert.True(t, balanceErr.Available.Equal(decimal.NewFromInt(150)))
+		assert.True(t, balanceErr.Requested.Equal(decimal.NewFromInt(151)))
+		assert.ErrorIs(t, err, shared.ErrInsufficientBalance)
+		assert.True(t, customer.Balance.Equal(decimal.NewFromInt(100)))
+		assert.Empty(t, customer.GetDomainEvents())
+	})
+
+	t.Run("fails with negative overdraft limit", func(t *testing.T) {
+		customer := newCustomer(t, 0)
+
+		err := customer.SetOverdraftLimit(decimal.NewFromInt(-1))
+
+		assertDomainErrorCode(t, err, "INVALID_OVERDRAFT_LIMIT")
+	})
+
+	t.Run("cannot be lowered below the used overdraft", func(t *testing.T) {
+		customer := newCustomer(t, -30)
+		customer.OverdraftLimit = decimal.NewFromInt(50)
+
+		err := customer.SetOverdraftLimit(decimal.NewFromInt(20))
+
+		assertDomainErrorCode(t, err, "OVERDRAFT_IN_USE")
+		assert.True(t, customer.OverdraftLimit.Equal(decimal.NewFromInt(50)))
+		require.NoError(t, customer.SetOverdraftLimit(decimal.NewFromInt(30)))
+	})
+}
+
 func TestCustomerStatus(t *testing.T) {
 	tenantID := uuid.New()
 
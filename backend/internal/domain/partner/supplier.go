@@ -55,7 +55,8 @@ type Supplier struct {
 	Rating      int             // Supplier rating (0-5)
 	Notes       string
 	SortOrder   int
-	Attributes  string // Custom attributes
+	Attributes  string     // Custom attributes
+	DeletedAt   *time.Time // Set when the supplier is soft deleted
 }
 
 // NewSupplier creates a new supplier with required fields
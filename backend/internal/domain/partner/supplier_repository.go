@@ -15,6 +15,9 @@ type SupplierRepository interface {
 	// FindByIDForTenant finds a supplier by ID within a tenant
 	FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*Supplier, error)
 
+	// FindDeletedByIDForTenant finds a soft-deleted supplier by ID within a tenant
+	FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*Supplier, error)
+
 	// FindByCode finds a supplier by its code within a tenant
 	FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*Supplier, error)
 
@@ -63,6 +66,9 @@ type SupplierRepository interface {
 	// DeleteForTenant deletes a supplier within a tenant
 	DeleteForTenant(ctx context.Context, tenantID, id uuid.UUID) error
 
+	// RestoreForTenant restores a soft-deleted supplier within a tenant
+	RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error
+
 	// Count counts suppliers matching the filter
 	Count(ctx context.Context, filter shared.Filter) (int64, error)
 
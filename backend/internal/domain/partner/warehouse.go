@@ -27,28 +27,49 @@ const (
 	WarehouseTypeTransit  WarehouseType = "transit"  // Transit/in-transit warehouse
 )
 
+// CapacityUnit is the unit a warehouse's capacity is measured in
+type CapacityUnit string
+
+const (
+	CapacityUnitQuantity   CapacityUnit = "unit" // On-hand quantity in base units
+	CapacityUnitKilogram   CapacityUnit = "kg"   // On-hand weight
+	CapacityUnitCubicMetre CapacityUnit = "m3"   // On-hand volume
+)
+
+// IsValid returns true if the capacity unit is supported
+func (u CapacityUnit) IsValid() bool {
+	switch u {
+	case CapacityUnitQuantity, CapacityUnitKilogram, CapacityUnitCubicMetre:
+		return true
+	default:
+		return false
+	}
+}
+
 // Warehouse represents a warehouse in the partner context
 // It is the aggregate root for warehouse-related operations
 type Warehouse struct {
 	shared.TenantAggregateRoot
-	Code        string
-	Name        string
-	ShortName   string // Abbreviated name
-	Type        WarehouseType
-	Status      WarehouseStatus
-	ContactName string // Warehouse manager/contact
-	Phone       string
-	Email       string
-	Address     string // Full address
-	City        string
-	Province    string
-	PostalCode  string
-	Country     string
-	IsDefault   bool // Default warehouse for operations
-	Capacity    int  // Storage capacity (in units)
-	Notes       string
-	SortOrder   int
-	Attributes  string // Custom attributes
+	Code               string
+	Name               string
+	ShortName          string // Abbreviated name
+	Type               WarehouseType
+	Status             WarehouseStatus
+	ContactName        string // Warehouse manager/contact
+	Phone              string
+	Email              string
+	Address            string // Full address
+	City               string
+	Province           string
+	PostalCode         string
+	Country            string
+	IsDefault          bool // Default warehouse for operations
+	Capacity           int  // Storage capacity, measured in CapacityUnit (0 = unlimited)
+	CapacityUnit       CapacityUnit
+	AllowNegativeStock bool // Stock-outs may drive on-hand below zero (e.g. consignment)
+	Notes              string
+	SortOrder          int
+	Attributes         string // Custom attributes
 }
 
 // NewWarehouse creates a new warehouse with required fields
@@ -71,6 +92,7 @@ func NewWarehouse(tenantID uuid.UUID, code, name string, warehouseType Warehouse
 		Status:              WarehouseStatusActive,
 		IsDefault:           false,
 		Capacity:            0,
+		CapacityUnit:        CapacityUnitQuantity,
 		Country:             "中国",
 		Attributes:          "{}",
 	}
@@ -214,6 +236,26 @@ func (w *Warehouse) SetCapacity(capacity int) error {
 	return nil
 }
 
+// SetCapacityUnit sets the unit the warehouse's capacity is measured in
+func (w *Warehouse) SetCapacityUnit(unit CapacityUnit) error {
+	if !unit.IsValid() {
+		return shared.NewDomainError("INVALID_CAPACITY_UNIT", "Capacity unit must be one of: unit, kg, m3")
+	}
+
+	w.CapacityUnit = unit
+	w.UpdatedAt = time.Now()
+	w.IncrementVersion()
+
+	return nil
+}
+
+// SetAllowNegativeStock sets whether stock in this warehouse may go below zero
+func (w *Warehouse) SetAllowNegativeStock(allow bool) {
+	w.AllowNegativeStock = allow
+	w.UpdatedAt = time.Now()
+	w.IncrementVersion()
+}
+
 // SetNotes sets the warehouse's notes
 func (w *Warehouse) SetNotes(notes string) {
 	w.Notes = notes
@@ -3,6 +3,7 @@ package partner
 import (
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // Aggregate type constant for Warehouse
@@ -15,6 +16,7 @@ const (
 	EventTypeWarehouseStatusChanged = "WarehouseStatusChanged"
 	EventTypeWarehouseSetAsDefault  = "WarehouseSetAsDefault"
 	EventTypeWarehouseDeleted       = "WarehouseDeleted"
+	EventTypeWarehouseNearCapacity  = "WarehouseNearCapacity"
 )
 
 // WarehouseCreatedEvent is published when a new warehouse is created
@@ -118,3 +120,32 @@ func NewWarehouseDeletedEvent(warehouse *Warehouse) *WarehouseDeletedEvent {
 		Name:            warehouse.Name,
 	}
 }
+
+// WarehouseNearCapacityEvent is published when received stock pushes a
+// warehouse's utilization to or above the near-capacity threshold
+type WarehouseNearCapacityEvent struct {
+	shared.BaseDomainEvent
+	WarehouseID  uuid.UUID       `json:"warehouse_id"`
+	Code         string          `json:"code"`
+	Name         string          `json:"name"`
+	CapacityUnit CapacityUnit    `json:"capacity_unit"`
+	Used         decimal.Decimal `json:"used"`
+	Total        decimal.Decimal `json:"total"`
+	Percent      decimal.Decimal `json:"percent"`
+	Threshold    decimal.Decimal `json:"threshold"`
+}
+
+// NewWarehouseNearCapacityEvent creates a new WarehouseNearCapacityEvent
+func NewWarehouseNearCapacityEvent(warehouse *Warehouse, utilization WarehouseUtilization, threshold decimal.Decimal) *WarehouseNearCapacityEvent {
+	return &WarehouseNearCapacityEvent{
+		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeWarehouseNearCapacity, AggregateTypeWarehouse, warehouse.ID, warehouse.TenantID),
+		WarehouseID:     warehouse.ID,
+		Code:            warehouse.Code,
+		Name:            warehouse.Name,
+		CapacityUnit:    utilization.Unit,
+		Used:            utilization.Used,
+		Total:           utilization.Total,
+		Percent:         utilization.Percent,
+		Threshold:       threshold,
+	}
+}
@@ -324,6 +324,17 @@ func TestWarehouse_SetCapacity(t *testing.T) {
 	})
 }
 
+func TestWarehouse_SetAllowNegativeStock(t *testing.T) {
+	warehouse := createTestWarehouse(t)
+	assert.False(t, warehouse.AllowNegativeStock)
+
+	warehouse.SetAllowNegativeStock(true)
+	assert.True(t, warehouse.AllowNegativeStock)
+
+	warehouse.SetAllowNegativeStock(false)
+	assert.False(t, warehouse.AllowNegativeStock)
+}
+
 func TestWarehouse_SetNotes(t *testing.T) {
 	warehouse := createTestWarehouse(t)
 	warehouse.SetNotes("Some notes about the warehouse")
@@ -0,0 +1,53 @@
+package partner
+
+import "github.com/shopspring/decimal"
+
+var hundred = decimal.NewFromInt(100)
+
+// WarehouseUtilization is how much of a warehouse's capacity its on-hand stock takes up
+type WarehouseUtilization struct {
+	Unit    CapacityUnit
+	Used    decimal.Decimal // On-hand stock measured in Unit
+	Total   decimal.Decimal // Capacity in Unit; zero means unlimited
+	Percent decimal.Decimal // Used as a percentage of Total, rounded to 2 decimal places
+}
+
+// Utilization works out the warehouse's utilization from the on-hand stock,
+// already measured in the warehouse's capacity unit.
+// A warehouse without a capacity reports 0%.
+func (w *Warehouse) Utilization(used decimal.Decimal) WarehouseUtilization {
+	utilization := WarehouseUtilization{
+		Unit:    w.CapacityUnit,
+		Used:    used,
+		Total:   decimal.NewFromInt(int64(w.Capacity)),
+		Percent: decimal.Zero,
+	}
+	if w.HasCapacity() {
+		utilization.Percent = used.Div(utilization.Total).Mul(hundred).Round(2)
+	}
+	return utilization
+}
+
+// IsLimited returns true if the warehouse has a capacity to fill
+func (u WarehouseUtilization) IsLimited() bool {
+	return u.Total.IsPositive()
+}
+
+// Reaches returns true if utilization is at or above the threshold percentage
+func (u WarehouseUtilization) Reaches(threshold decimal.Decimal) bool {
+	return u.IsLimited() && u.Percent.GreaterThanOrEqual(threshold)
+}
+
+// NearCapacityEvent returns the event for stock moving the warehouse from below
+// the threshold percentage to at or above it, or nil if it did not cross it.
+// Stock already above the threshold does not raise the event again.
+func (w *Warehouse) NearCapacityEvent(usedBefore, usedAfter, threshold decimal.Decimal) *WarehouseNearCapacityEvent {
+	if !threshold.IsPositive() {
+		return nil
+	}
+	after := w.Utilization(usedAfter)
+	if !after.Reaches(threshold) || w.Utilization(usedBefore).Reaches(threshold) {
+		return nil
+	}
+	return NewWarehouseNearCapacityEvent(w, after, threshold)
+}
@@ -0,0 +1,105 @@
+package partner
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapacityUnit_IsValid(t *testing.T) {
+	assert.True(t, CapacityUnitQuantity.IsValid())
+	assert.True(t, CapacityUnitKilogram.IsValid())
+	assert.True(t, CapacityUnitCubicMetre.IsValid())
+	assert.False(t, CapacityUnit("ton").IsValid())
+	assert.False(t, CapacityUnit("").IsValid())
+}
+
+func TestWarehouse_SetCapacityUnit(t *testing.T) {
+	warehouse := createTestWarehouse(t)
+	assert.Equal(t, CapacityUnitQuantity, warehouse.CapacityUnit)
+
+	require.NoError(t, warehouse.SetCapacityUnit(CapacityUnitCubicMetre))
+	assert.Equal(t, CapacityUnitCubicMetre, warehouse.CapacityUnit)
+
+	err := warehouse.SetCapacityUnit("ton")
+	assert.Error(t, err)
+	assert.Equal(t, CapacityUnitCubicMetre, warehouse.CapacityUnit)
+}
+
+func TestWarehouse_Utilization(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		used     string
+		percent  string
+	}{
+		{"empty warehouse", 1000, "0", "0"},
+		{"partly filled", 1000, "250", "25"},
+		{"rounds to two decimal places", 3, "1", "33.33"},
+		{"fractional load", 40, "12.5", "31.25"},
+		{"full", 1000, "1000", "100"},
+		{"over capacity", 1000, "1200", "120"},
+		{"no capacity reports zero", 0, "500", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warehouse := createTestWarehouse(t)
+			require.NoError(t, warehouse.SetCapacity(tt.capacity))
+			require.NoError(t, warehouse.SetCapacityUnit(CapacityUnitKilogram))
+
+			u := warehouse.Utilization(decimal.RequireFromString(tt.used))
+
+			assert.Equal(t, CapacityUnitKilogram, u.Unit)
+			assert.True(t, u.Used.Equal(decimal.RequireFromString(tt.used)))
+			assert.True(t, u.Total.Equal(decimal.NewFromInt(int64(tt.capacity))))
+			assert.True(t, u.Percent.Equal(decimal.RequireFromString(tt.percent)), "percent = %s", u.Percent)
+			assert.Equal(t, tt.capacity > 0, u.IsLimited())
+		})
+	}
+}
+
+func TestWarehouse_NearCapacityEvent(t *testing.T) {
+	threshold := decimal.NewFromInt(90)
+	warehouse := createTestWarehouse(t)
+	require.NoError(t, warehouse.SetCapacity(1000))
+
+	tests := []struct {
+		name      string
+		before    int64
+		after     int64
+		threshold decimal.Decimal
+		raised    bool
+	}{
+		{"stays below threshold", 500, 899, threshold, false},
+		{"reaches threshold exactly", 800, 900, threshold, true},
+		{"crosses threshold", 850, 950, threshold, true},
+		{"goes over capacity", 100, 1200, threshold, true},
+		{"already above threshold", 920, 980, threshold, false},
+		{"threshold disabled", 850, 950, decimal.Zero, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := warehouse.NearCapacityEvent(decimal.NewFromInt(tt.before), decimal.NewFromInt(tt.after), tt.threshold)
+			if !tt.raised {
+				assert.Nil(t, event)
+				return
+			}
+			require.NotNil(t, event)
+			assert.Equal(t, EventTypeWarehouseNearCapacity, event.EventType())
+			assert.Equal(t, warehouse.ID, event.WarehouseID)
+			assert.Equal(t, warehouse.TenantID, event.TenantID())
+			assert.True(t, event.Used.Equal(decimal.NewFromInt(tt.after)))
+			assert.True(t, event.Total.Equal(decimal.NewFromInt(1000)))
+			assert.True(t, event.Threshold.Equal(threshold))
+		})
+	}
+
+	t.Run("unlimited warehouse never raises", func(t *testing.T) {
+		unlimited := createTestWarehouse(t)
+		assert.Nil(t, unlimited.NearCapacityEvent(decimal.Zero, decimal.NewFromInt(1_000_000), threshold))
+	})
+}
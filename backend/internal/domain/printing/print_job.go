@@ -20,6 +20,7 @@ type PrintJob struct {
 	PrinterName    string     // Target printer name (optional, for local printing)
 	PdfURL         string     // URL to the generated PDF file
 	ErrorMessage   string     // Error message if job failed
+	ErrorDetail    string     // Renderer diagnostic output if job failed, for troubleshooting
 	PrintedAt      *time.Time // When the job was printed
 	PrintedBy      *uuid.UUID // User who initiated the print
 }
@@ -125,6 +126,12 @@ func (j *PrintJob) Complete(pdfURL string) error {
 
 // Fail marks the job as failed with an error message
 func (j *PrintJob) Fail(errorMessage string) error {
+	return j.FailWithDetail(errorMessage, "")
+}
+
+// FailWithDetail marks the job as failed with an error message for the user and
+// the renderer's diagnostic output for troubleshooting
+func (j *PrintJob) FailWithDetail(errorMessage, detail string) error {
 	if j.Status.IsTerminal() {
 		return shared.NewDomainError("INVALID_STATE",
 			"Cannot fail a job that is already in terminal status: "+j.Status.String())
@@ -133,6 +140,7 @@ func (j *PrintJob) Fail(errorMessage string) error {
 	oldStatus := j.Status
 	j.Status = JobStatusFailed
 	j.ErrorMessage = errorMessage
+	j.ErrorDetail = detail
 	j.UpdatedAt = time.Now()
 	j.IncrementVersion()
 
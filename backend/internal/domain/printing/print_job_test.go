@@ -273,6 +273,18 @@ func TestPrintJob_Fail(t *testing.T) {
 	assert.Contains(t, eventTypes, EventTypePrintJobFailed)
 }
 
+func TestPrintJob_FailWithDetail(t *testing.T) {
+	job := createTestPrintJob(t)
+	_ = job.StartRendering()
+
+	err := job.FailWithDetail("PDF generation failed", "net::ERR_NAME_NOT_RESOLVED loading https://cdn.example.com/logo.png")
+	require.NoError(t, err)
+
+	assert.Equal(t, JobStatusFailed, job.Status)
+	assert.Equal(t, "PDF generation failed", job.ErrorMessage)
+	assert.Contains(t, job.ErrorDetail, "ERR_NAME_NOT_RESOLVED")
+}
+
 func TestPrintJob_Fail_FromPending(t *testing.T) {
 	job := createTestPrintJob(t)
 	job.ClearDomainEvents()
@@ -0,0 +1,105 @@
+package printing
+
+import (
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/shared"
+)
+
+// Printer resolutions in dots per inch
+const (
+	DefaultDPI        = 300 // Office laser and inkjet printers
+	ThermalPrinterDPI = 203 // Thermal receipt printers
+	MinDPI            = 72
+	MaxDPI            = 1200
+)
+
+// minPrintableMM is the smallest width or height, in millimeters, the margins must leave to print on
+const minPrintableMM = 20
+
+// PrintProfile holds the page settings a document type is printed with,
+// so that render calls don't repeat them
+type PrintProfile struct {
+	DocType     DocType     `json:"doc_type"`
+	PaperSize   PaperSize   `json:"paper_size"`
+	Orientation Orientation `json:"orientation"`
+	Margins     Margins     `json:"margins"`
+	DPI         int         `json:"dpi"` // Resolution of the target printer
+}
+
+// NewPrintProfile creates a new print profile, validating its settings
+func NewPrintProfile(docType DocType, paperSize PaperSize, orientation Orientation, margins Margins, dpi int) (PrintProfile, error) {
+	profile := PrintProfile{
+		DocType:     docType,
+		PaperSize:   paperSize,
+		Orientation: orientation,
+		Margins:     margins,
+		DPI:         dpi,
+	}
+	if err := profile.Validate(); err != nil {
+		return PrintProfile{}, err
+	}
+	return profile, nil
+}
+
+// DefaultPrintProfile returns the standard print profile of a document type:
+// 80mm thermal roll for sales receipts, A4 portrait for everything else
+func DefaultPrintProfile(docType DocType) PrintProfile {
+	if docType == DocTypeSalesReceipt {
+		return PrintProfile{
+			DocType:     docType,
+			PaperSize:   PaperSizeReceipt80MM,
+			Orientation: OrientationPortrait,
+			Margins:     ReceiptMargins(),
+			DPI:         ThermalPrinterDPI,
+		}
+	}
+	return PrintProfile{
+		DocType:     docType,
+		PaperSize:   PaperSizeA4,
+		Orientation: OrientationPortrait,
+		Margins:     DefaultMargins(),
+		DPI:         DefaultDPI,
+	}
+}
+
+// Validate checks the profile settings, including that the margins fit the paper
+func (p PrintProfile) Validate() error {
+	if !p.DocType.IsValid() {
+		return shared.NewDomainError("INVALID_DOC_TYPE", "Invalid document type")
+	}
+	if !p.PaperSize.IsValid() {
+		return shared.NewDomainError("INVALID_PAPER_SIZE", "Invalid paper size")
+	}
+	if !p.Orientation.IsValid() {
+		return shared.NewDomainError("INVALID_ORIENTATION", "Invalid orientation")
+	}
+	if p.DPI < MinDPI || p.DPI > MaxDPI {
+		return shared.NewDomainError("INVALID_DPI", fmt.Sprintf("DPI must be between %d and %d", MinDPI, MaxDPI))
+	}
+	if _, err := NewMargins(p.Margins.Top, p.Margins.Right, p.Margins.Bottom, p.Margins.Left); err != nil {
+		return err
+	}
+
+	width, height := p.PageDimensions()
+	if width-p.Margins.Left-p.Margins.Right < minPrintableMM {
+		return shared.NewDomainError("INVALID_MARGINS",
+			fmt.Sprintf("Left and right margins leave less than %dmm of the %dmm paper width", minPrintableMM, width))
+	}
+	// Roll paper has no fixed height
+	if height > 0 && height-p.Margins.Top-p.Margins.Bottom < minPrintableMM {
+		return shared.NewDomainError("INVALID_MARGINS",
+			fmt.Sprintf("Top and bottom margins leave less than %dmm of the %dmm paper height", minPrintableMM, height))
+	}
+	return nil
+}
+
+// PageDimensions returns the page dimensions in millimeters (width, height) in the
+// profile's orientation. Height is 0 for roll paper, which is always printed portrait.
+func (p PrintProfile) PageDimensions() (width, height int) {
+	width, height = p.PaperSize.Dimensions()
+	if height > 0 && p.Orientation == OrientationLandscape {
+		return height, width
+	}
+	return width, height
+}
@@ -0,0 +1,85 @@
+package printing
+
+import (
+	"testing"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPrintProfile(t *testing.T) {
+	t.Run("sales receipts print on 80mm thermal rolls", func(t *testing.T) {
+		profile := DefaultPrintProfile(DocTypeSalesReceipt)
+
+		assert.Equal(t, PaperSizeReceipt80MM, profile.PaperSize)
+		assert.Equal(t, OrientationPortrait, profile.Orientation)
+		assert.Equal(t, ReceiptMargins(), profile.Margins)
+		assert.Equal(t, ThermalPrinterDPI, profile.DPI)
+		assert.NoError(t, profile.Validate())
+	})
+
+	t.Run("other documents print on A4 portrait", func(t *testing.T) {
+		for _, docType := range AllDocTypes() {
+			if docType == DocTypeSalesReceipt {
+				continue
+			}
+			profile := DefaultPrintProfile(docType)
+
+			assert.Equal(t, docType, profile.DocType)
+			assert.Equal(t, PaperSizeA4, profile.PaperSize)
+			assert.Equal(t, OrientationPortrait, profile.Orientation)
+			assert.Equal(t, DefaultDPI, profile.DPI)
+			assert.NoError(t, profile.Validate())
+		}
+	})
+}
+
+func TestNewPrintProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		paperSize   PaperSize
+		orientation Orientation
+		margins     Margins
+		dpi         int
+		errorCode   string
+	}{
+		{"80mm receipt", PaperSizeReceipt80MM, OrientationPortrait, ReceiptMargins(), ThermalPrinterDPI, ""},
+		{"80mm receipt with wide side margins", PaperSizeReceipt80MM, OrientationPortrait, Margins{Top: 2, Right: 31, Bottom: 2, Left: 31}, ThermalPrinterDPI, "INVALID_MARGINS"},
+		{"80mm receipt ignores top and bottom against the roll", PaperSizeReceipt80MM, OrientationPortrait, Margins{Top: 100, Right: 5, Bottom: 100, Left: 5}, ThermalPrinterDPI, ""},
+		{"A4 portrait", PaperSizeA4, OrientationPortrait, DefaultMargins(), DefaultDPI, ""},
+		{"A5 landscape checks the rotated height", PaperSizeA5, OrientationLandscape, Margins{Top: 70, Right: 10, Bottom: 70, Left: 10}, DefaultDPI, "INVALID_MARGINS"},
+		{"A5 portrait fits the same margins", PaperSizeA5, OrientationPortrait, Margins{Top: 70, Right: 10, Bottom: 70, Left: 10}, DefaultDPI, ""},
+		{"negative margin", PaperSizeA4, OrientationPortrait, Margins{Top: -1}, DefaultDPI, "INVALID_MARGINS"},
+		{"invalid paper size", PaperSize("LETTER"), OrientationPortrait, DefaultMargins(), DefaultDPI, "INVALID_PAPER_SIZE"},
+		{"invalid orientation", PaperSizeA4, Orientation("DIAGONAL"), DefaultMargins(), DefaultDPI, "INVALID_ORIENTATION"},
+		{"DPI too low", PaperSizeA4, OrientationPortrait, DefaultMargins(), 10, "INVALID_DPI"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := NewPrintProfile(DocTypeSalesReceipt, tt.paperSize, tt.orientation, tt.margins, tt.dpi)
+
+			if tt.errorCode == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.paperSize, profile.PaperSize)
+				assert.Equal(t, tt.margins, profile.Margins)
+				return
+			}
+			var domainErr *shared.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, tt.errorCode, domainErr.Code)
+		})
+	}
+}
+
+func TestPrintProfile_PageDimensions(t *testing.T) {
+	width, height := DefaultPrintProfile(DocTypeSalesReceipt).PageDimensions()
+	assert.Equal(t, 80, width)
+	assert.Zero(t, height)
+
+	landscape := PrintProfile{PaperSize: PaperSizeA4, Orientation: OrientationLandscape}
+	width, height = landscape.PageDimensions()
+	assert.Equal(t, 297, width)
+	assert.Equal(t, 210, height)
+}
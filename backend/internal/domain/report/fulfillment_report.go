@@ -0,0 +1,119 @@
+package report
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// OrderFulfillmentTimes holds the lifecycle timestamps of a confirmed sales order
+type OrderFulfillmentTimes struct {
+	OrderID        uuid.UUID  `json:"order_id"`
+	WarehouseID    *uuid.UUID `json:"warehouse_id,omitempty"`
+	WarehouseName  string     `json:"warehouse_name,omitempty"`
+	ConfirmedAt    time.Time  `json:"confirmed_at"`
+	FullyShippedAt *time.Time `json:"fully_shipped_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CancelledAt    *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// DurationStats summarizes how long orders spent in one fulfillment stage
+type DurationStats struct {
+	Count      int64           `json:"count"`       // Orders that finished the stage
+	InProgress int64           `json:"in_progress"` // Orders that entered the stage but have not finished it
+	AvgHours   decimal.Decimal `json:"avg_hours"`
+	P95Hours   decimal.Decimal `json:"p95_hours"`
+	MaxHours   decimal.Decimal `json:"max_hours"`
+}
+
+// FulfillmentSLA is a read model for sales order fulfillment durations.
+// Confirm-to-ship runs from confirmation until the order is fully shipped;
+// ship-to-complete runs from the full shipment until the order is completed.
+type FulfillmentSLA struct {
+	WarehouseID    *uuid.UUID    `json:"warehouse_id,omitempty"`
+	WarehouseName  string        `json:"warehouse_name,omitempty"`
+	OrderCount     int64         `json:"order_count"`     // Orders confirmed in the period
+	CancelledCount int64         `json:"cancelled_count"` // Of those, orders cancelled before shipping
+	ConfirmToShip  DurationStats `json:"confirm_to_ship"`
+	ShipToComplete DurationStats `json:"ship_to_complete"`
+}
+
+// ComputeFulfillmentSLA computes fulfillment durations for the given orders.
+// Orders that never reach a stage do not count towards it: cancelled orders are left out of
+// confirm-to-ship, and orders that never fully shipped are left out of ship-to-complete.
+// Open orders are counted as in progress, and stages with inconsistent timestamps
+// (ending before they started, or a completed order with no full shipment) are skipped.
+func ComputeFulfillmentSLA(orders []OrderFulfillmentTimes) FulfillmentSLA {
+	var sla FulfillmentSLA
+	var confirmToShip, shipToComplete []time.Duration
+
+	for _, o := range orders {
+		sla.OrderCount++
+
+		switch {
+		case o.FullyShippedAt != nil:
+			if d := o.FullyShippedAt.Sub(o.ConfirmedAt); d >= 0 {
+				confirmToShip = append(confirmToShip, d)
+			}
+		case o.CancelledAt != nil:
+			sla.CancelledCount++
+			continue
+		case o.CompletedAt != nil:
+			// Completed without a recorded full shipment; neither stage can be measured
+			continue
+		default:
+			sla.ConfirmToShip.InProgress++
+			continue
+		}
+
+		if o.CompletedAt == nil {
+			sla.ShipToComplete.InProgress++
+			continue
+		}
+		if d := o.CompletedAt.Sub(*o.FullyShippedAt); d >= 0 {
+			shipToComplete = append(shipToComplete, d)
+		}
+	}
+
+	sla.ConfirmToShip = summarizeDurations(confirmToShip, sla.ConfirmToShip.InProgress)
+	sla.ShipToComplete = summarizeDurations(shipToComplete, sla.ShipToComplete.InProgress)
+	return sla
+}
+
+// summarizeDurations returns the count, average, 95th percentile and maximum of the durations in hours.
+// The percentile uses the nearest-rank method, so it is always one of the observed durations.
+func summarizeDurations(durations []time.Duration, inProgress int64) DurationStats {
+	stats := DurationStats{
+		Count:      int64(len(durations)),
+		InProgress: inProgress,
+		AvgHours:   decimal.Zero,
+		P95Hours:   decimal.Zero,
+		MaxHours:   decimal.Zero,
+	}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	rank := int(math.Ceil(0.95 * float64(len(sorted))))
+
+	stats.AvgHours = toHours(total / time.Duration(len(sorted)))
+	stats.P95Hours = toHours(sorted[rank-1])
+	stats.MaxHours = toHours(sorted[len(sorted)-1])
+	return stats
+}
+
+// toHours converts a duration to hours rounded to two decimal places
+func toHours(d time.Duration) decimal.Decimal {
+	return decimal.NewFromInt(int64(d)).Div(decimal.NewFromInt(int64(time.Hour))).Round(2)
+}
@@ -0,0 +1,112 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeFulfillmentSLA(t *testing.T) {
+	confirmed := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	at := func(hours float64) *time.Time {
+		ts := confirmed.Add(time.Duration(hours * float64(time.Hour)))
+		return &ts
+	}
+	order := func(shippedAfter, completedAfter, cancelledAfter *time.Time) OrderFulfillmentTimes {
+		return OrderFulfillmentTimes{
+			OrderID:        uuid.New(),
+			ConfirmedAt:    confirmed,
+			FullyShippedAt: shippedAfter,
+			CompletedAt:    completedAfter,
+			CancelledAt:    cancelledAfter,
+		}
+	}
+	hours := func(h float64) decimal.Decimal { return decimal.NewFromFloat(h) }
+
+	t.Run("measures both stages of completed orders", func(t *testing.T) {
+		sla := ComputeFulfillmentSLA([]OrderFulfillmentTimes{
+			order(at(10), at(34), nil), // 10h to ship, 24h to complete
+			order(at(20), at(68), nil), // 20h to ship, 48h to complete
+		})
+
+		assert.Equal(t, int64(2), sla.OrderCount)
+		assert.Equal(t, int64(2), sla.ConfirmToShip.Count)
+		assert.True(t, hours(15).Equal(sla.ConfirmToShip.AvgHours), "got %s", sla.ConfirmToShip.AvgHours)
+		assert.True(t, hours(20).Equal(sla.ConfirmToShip.P95Hours))
+		assert.True(t, hours(20).Equal(sla.ConfirmToShip.MaxHours))
+		assert.Equal(t, int64(2), sla.ShipToComplete.Count)
+		assert.True(t, hours(36).Equal(sla.ShipToComplete.AvgHours))
+		assert.True(t, hours(48).Equal(sla.ShipToComplete.P95Hours))
+	})
+
+	t.Run("cancelled orders are left out of the durations", func(t *testing.T) {
+		sla := ComputeFulfillmentSLA([]OrderFulfillmentTimes{
+			order(at(4), at(12), nil),
+			order(nil, nil, at(2)),
+		})
+
+		assert.Equal(t, int64(2), sla.OrderCount)
+		assert.Equal(t, int64(1), sla.CancelledCount)
+		assert.Equal(t, int64(1), sla.ConfirmToShip.Count)
+		assert.Zero(t, sla.ConfirmToShip.InProgress)
+		assert.True(t, hours(4).Equal(sla.ConfirmToShip.AvgHours))
+		assert.Equal(t, int64(1), sla.ShipToComplete.Count)
+		assert.Zero(t, sla.ShipToComplete.InProgress)
+	})
+
+	t.Run("open orders are reported as in progress", func(t *testing.T) {
+		sla := ComputeFulfillmentSLA([]OrderFulfillmentTimes{
+			order(nil, nil, nil),     // not yet fully shipped
+			order(at(6), nil, nil),   // shipped, awaiting completion
+			order(at(8), at(9), nil), // done
+		})
+
+		assert.Equal(t, int64(1), sla.ConfirmToShip.InProgress)
+		assert.Equal(t, int64(2), sla.ConfirmToShip.Count)
+		assert.True(t, hours(7).Equal(sla.ConfirmToShip.AvgHours))
+		assert.Equal(t, int64(1), sla.ShipToComplete.InProgress)
+		assert.Equal(t, int64(1), sla.ShipToComplete.Count)
+		assert.True(t, hours(1).Equal(sla.ShipToComplete.AvgHours))
+	})
+
+	t.Run("stages with missing or inconsistent timestamps are skipped", func(t *testing.T) {
+		sla := ComputeFulfillmentSLA([]OrderFulfillmentTimes{
+			order(nil, at(30), nil),   // completed without a recorded full shipment
+			order(at(-1), at(5), nil), // shipped before confirmation
+			order(at(10), at(9), nil), // completed before shipping
+		})
+
+		assert.Equal(t, int64(3), sla.OrderCount)
+		assert.Equal(t, int64(1), sla.ConfirmToShip.Count)
+		assert.True(t, hours(10).Equal(sla.ConfirmToShip.AvgHours))
+		assert.Zero(t, sla.ConfirmToShip.InProgress)
+		assert.Equal(t, int64(1), sla.ShipToComplete.Count)
+		assert.True(t, hours(6).Equal(sla.ShipToComplete.AvgHours))
+		assert.Zero(t, sla.ShipToComplete.InProgress)
+	})
+
+	t.Run("p95 uses the nearest rank", func(t *testing.T) {
+		orders := make([]OrderFulfillmentTimes, 0, 20)
+		for i := 1; i <= 20; i++ {
+			orders = append(orders, order(at(float64(i)), nil, nil))
+		}
+
+		sla := ComputeFulfillmentSLA(orders)
+
+		assert.True(t, hours(10.5).Equal(sla.ConfirmToShip.AvgHours))
+		assert.True(t, hours(19).Equal(sla.ConfirmToShip.P95Hours), "got %s", sla.ConfirmToShip.P95Hours)
+		assert.True(t, hours(20).Equal(sla.ConfirmToShip.MaxHours))
+		assert.Equal(t, int64(20), sla.ShipToComplete.InProgress)
+	})
+
+	t.Run("no orders gives zero durations", func(t *testing.T) {
+		sla := ComputeFulfillmentSLA(nil)
+
+		assert.Zero(t, sla.OrderCount)
+		assert.True(t, sla.ConfirmToShip.AvgHours.IsZero())
+		assert.True(t, sla.ShipToComplete.P95Hours.IsZero())
+	})
+}
@@ -25,6 +25,53 @@ type InventoryTurnover struct {
 	StockValue      decimal.Decimal `json:"stock_value"`       // Current value
 }
 
+// ProductInventoryMovement holds the ledger figures of a product over a period,
+// summed over the warehouses the product is stocked in
+type ProductInventoryMovement struct {
+	ProductID       uuid.UUID       `json:"product_id"`
+	ProductSKU      string          `json:"product_sku"`
+	ProductName     string          `json:"product_name"`
+	CategoryName    string          `json:"category_name,omitempty"`
+	OpeningQuantity decimal.Decimal `json:"opening_quantity"` // Ledger balance at the start of the period
+	ClosingQuantity decimal.Decimal `json:"closing_quantity"` // Ledger balance at the end of the period
+	SoldQuantity    decimal.Decimal `json:"sold_quantity"`
+	COGS            decimal.Decimal `json:"cogs"`      // Cost of the quantity shipped to sales orders
+	UnitCost        decimal.Decimal `json:"unit_cost"` // Cost the average inventory is valued at
+}
+
+// ProductTurnover is a read model for the turnover of a product over a period
+type ProductTurnover struct {
+	ProductInventoryMovement
+	AverageQuantity decimal.Decimal `json:"average_quantity"` // (OpeningQuantity + ClosingQuantity) / 2
+	AverageValue    decimal.Decimal `json:"average_value"`    // AverageQuantity * UnitCost
+	// TurnoverRatio is COGS / AverageValue; nil when the product had no inventory to turn over
+	TurnoverRatio *decimal.Decimal `json:"turnover_ratio"`
+	// DaysOnHand is the number of days the average inventory lasts at the period's rate of sale;
+	// nil when nothing was sold
+	DaysOnHand *decimal.Decimal `json:"days_on_hand"`
+}
+
+// NewProductTurnover computes the turnover of a product from its movement over a period of periodDays days
+func NewProductTurnover(movement ProductInventoryMovement, periodDays int) ProductTurnover {
+	averageQuantity := movement.OpeningQuantity.Add(movement.ClosingQuantity).Div(decimal.NewFromInt(2))
+	t := ProductTurnover{
+		ProductInventoryMovement: movement,
+		AverageQuantity:          averageQuantity,
+		AverageValue:             averageQuantity.Mul(movement.UnitCost),
+	}
+	if t.AverageValue.IsZero() {
+		return t
+	}
+
+	ratio := movement.COGS.Div(t.AverageValue).Round(4)
+	t.TurnoverRatio = &ratio
+	if movement.COGS.IsPositive() {
+		days := t.AverageValue.Div(movement.COGS).Mul(decimal.NewFromInt(int64(periodDays))).Round(1)
+		t.DaysOnHand = &days
+	}
+	return t
+}
+
 // InventorySummary provides aggregated inventory statistics
 type InventorySummary struct {
 	TotalProducts   int64           `json:"total_products"`
@@ -96,4 +143,7 @@ type InventoryReportRepository interface {
 
 	// GetSlowMovingProducts returns products with low turnover
 	GetSlowMovingProducts(filter InventoryTurnoverFilter) ([]InventoryTurnover, error)
+
+	// GetProductInventoryMovements returns the ledger figures of each product over the filter's period
+	GetProductInventoryMovements(filter InventoryTurnoverFilter) ([]ProductInventoryMovement, error)
 }
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProductTurnover(t *testing.T) {
+	dec := decimal.RequireFromString
+
+	t.Run("zero movement has zero turnover and no days on hand", func(t *testing.T) {
+		turnover := NewProductTurnover(ProductInventoryMovement{
+			ProductID:       uuid.New(),
+			OpeningQuantity: dec("50"),
+			ClosingQuantity: dec("50"),
+			SoldQuantity:    decimal.Zero,
+			COGS:            decimal.Zero,
+			UnitCost:        dec("8"),
+		}, 30)
+
+		assert.True(t, turnover.AverageQuantity.Equal(dec("50")))
+		assert.True(t, turnover.AverageValue.Equal(dec("400")))
+		require.NotNil(t, turnover.TurnoverRatio)
+		assert.True(t, turnover.TurnoverRatio.IsZero())
+		assert.Nil(t, turnover.DaysOnHand)
+	})
+
+	t.Run("steady sales use the average of opening and closing inventory", func(t *testing.T) {
+		// 10 units a day for 30 days at a cost of 5, from 120 units down to 80 with restocking in between
+		turnover := NewProductTurnover(ProductInventoryMovement{
+			ProductID:       uuid.New(),
+			OpeningQuantity: dec("120"),
+			ClosingQuantity: dec("80"),
+			SoldQuantity:    dec("300"),
+			COGS:            dec("1500"),
+			UnitCost:        dec("5"),
+		}, 30)
+
+		assert.True(t, turnover.AverageQuantity.Equal(dec("100")))
+		assert.True(t, turnover.AverageValue.Equal(dec("500")))
+		require.NotNil(t, turnover.TurnoverRatio)
+		assert.Equal(t, "3", turnover.TurnoverRatio.String())
+		require.NotNil(t, turnover.DaysOnHand)
+		assert.Equal(t, "10", turnover.DaysOnHand.String())
+	})
+
+	t.Run("no inventory leaves turnover undefined", func(t *testing.T) {
+		turnover := NewProductTurnover(ProductInventoryMovement{
+			ProductID: uuid.New(),
+			COGS:      dec("200"),
+			UnitCost:  dec("5"),
+		}, 30)
+
+		assert.Nil(t, turnover.TurnoverRatio)
+		assert.Nil(t, turnover.DaysOnHand)
+	})
+}
@@ -0,0 +1,32 @@
+package report
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReturnReasonStat is the number and refund amount of returns filed under one reason code
+type ReturnReasonStat struct {
+	ReasonCode  string          `json:"reason_code"`
+	ReturnCount int64           `json:"return_count"`
+	TotalAmount decimal.Decimal `json:"total_amount"`
+}
+
+// ReturnReportFilter defines filter criteria for return reports.
+// Only returns submitted in the period count; drafts, rejected and cancelled returns are excluded.
+type ReturnReportFilter struct {
+	TenantID  uuid.UUID `json:"-"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// ReturnReportRepository defines the interface for return report queries
+type ReturnReportRepository interface {
+	// GetSalesReturnsByReason returns sales return counts and refund amounts grouped by reason code
+	GetSalesReturnsByReason(filter ReturnReportFilter) ([]ReturnReasonStat, error)
+
+	// GetPurchaseReturnsByReason returns purchase return counts and refund amounts grouped by reason code
+	GetPurchaseReturnsByReason(filter ReturnReportFilter) ([]ReturnReasonStat, error)
+}
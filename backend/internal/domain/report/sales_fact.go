@@ -0,0 +1,94 @@
+package report
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CompletedOrderLine is one line of a completed sales order, dated by the day the order completed
+type CompletedOrderLine struct {
+	OrderID     uuid.UUID       `json:"order_id"`
+	Date        time.Time       `json:"date"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	WarehouseID uuid.UUID       `json:"warehouse_id"`
+	Quantity    decimal.Decimal `json:"quantity"`
+	Amount      decimal.Decimal `json:"amount"` // Line amount before order-level discount
+	Cost        decimal.Decimal `json:"cost"`   // Cost of goods shipped for the line
+}
+
+// DailySalesFact holds the completed sales of a product from a warehouse on one day
+type DailySalesFact struct {
+	TenantID    uuid.UUID       `json:"tenant_id"`
+	Date        time.Time       `json:"date"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	WarehouseID uuid.UUID       `json:"warehouse_id"`
+	OrderCount  int64           `json:"order_count"` // Orders with a line for the product
+	Quantity    decimal.Decimal `json:"quantity"`
+	Revenue     decimal.Decimal `json:"revenue"`
+	Cost        decimal.Decimal `json:"cost"`
+}
+
+// dailySalesFactKey is the grain of the daily sales fact table
+type dailySalesFactKey struct {
+	date        time.Time
+	productID   uuid.UUID
+	warehouseID uuid.UUID
+}
+
+// AggregateDailySalesFacts sums completed order lines into one fact per day, product and warehouse,
+// ordered by date, product and warehouse. Line dates are truncated to the day.
+func AggregateDailySalesFacts(tenantID uuid.UUID, lines []CompletedOrderLine) []DailySalesFact {
+	index := make(map[dailySalesFactKey]int)
+	orders := make(map[dailySalesFactKey]map[uuid.UUID]struct{})
+	var facts []DailySalesFact
+
+	for _, line := range lines {
+		key := dailySalesFactKey{
+			date:        time.Date(line.Date.Year(), line.Date.Month(), line.Date.Day(), 0, 0, 0, 0, line.Date.Location()),
+			productID:   line.ProductID,
+			warehouseID: line.WarehouseID,
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(facts)
+			index[key] = i
+			orders[key] = make(map[uuid.UUID]struct{})
+			facts = append(facts, DailySalesFact{
+				TenantID:    tenantID,
+				Date:        key.date,
+				ProductID:   key.productID,
+				WarehouseID: key.warehouseID,
+			})
+		}
+		orders[key][line.OrderID] = struct{}{}
+		facts[i].OrderCount = int64(len(orders[key]))
+		facts[i].Quantity = facts[i].Quantity.Add(line.Quantity)
+		facts[i].Revenue = facts[i].Revenue.Add(line.Amount)
+		facts[i].Cost = facts[i].Cost.Add(line.Cost)
+	}
+
+	slices.SortFunc(facts, func(a, b DailySalesFact) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.ProductID.String(), b.ProductID.String()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.WarehouseID.String(), b.WarehouseID.String())
+	})
+	return facts
+}
+
+// DailySalesFactRepository reads completed order lines and stores the daily sales facts built from them
+type DailySalesFactRepository interface {
+	// GetCompletedOrderLines returns the lines of the tenant's orders completed between start and end
+	GetCompletedOrderLines(tenantID uuid.UUID, start, end time.Time) ([]CompletedOrderLine, error)
+	// ReplaceDailySalesFacts replaces the tenant's facts for the days from start to end with facts
+	ReplaceDailySalesFacts(tenantID uuid.UUID, start, end time.Time, facts []DailySalesFact) error
+	// GetTenantIDsWithCompletedOrders returns the tenants with orders completed between start and end
+	GetTenantIDsWithCompletedOrders(start, end time.Time) ([]uuid.UUID, error)
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateDailySalesFacts(t *testing.T) {
+	dec := decimal.RequireFromString
+	tenantID := uuid.New()
+	productA, productB := uuid.New(), uuid.New()
+	warehouse1, warehouse2 := uuid.New(), uuid.New()
+	order1, order2, order3, order4 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	day := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	nextDay := day.AddDate(0, 0, 1)
+
+	lines := []CompletedOrderLine{
+		{OrderID: order1, Date: day.Add(9 * time.Hour), ProductID: productA, WarehouseID: warehouse1, Quantity: dec("2"), Amount: dec("200"), Cost: dec("120")},
+		{OrderID: order1, Date: day.Add(9 * time.Hour), ProductID: productB, WarehouseID: warehouse1, Quantity: dec("1"), Amount: dec("55.50"), Cost: dec("30")},
+		// The same product twice on one order counts the order once
+		{OrderID: order2, Date: day.Add(15 * time.Hour), ProductID: productA, WarehouseID: warehouse1, Quantity: dec("3"), Amount: dec("300"), Cost: dec("180")},
+		{OrderID: order2, Date: day.Add(15 * time.Hour), ProductID: productA, WarehouseID: warehouse1, Quantity: dec("1"), Amount: dec("90"), Cost: dec("60")},
+		{OrderID: order3, Date: day.Add(23 * time.Hour), ProductID: productA, WarehouseID: warehouse2, Quantity: dec("5"), Amount: dec("480"), Cost: dec("300")},
+		{OrderID: order4, Date: nextDay.Add(time.Hour), ProductID: productA, WarehouseID: warehouse1, Quantity: dec("7"), Amount: dec("700"), Cost: dec("420")},
+	}
+
+	facts := AggregateDailySalesFacts(tenantID, lines)
+
+	// The facts of a day add up to that day's completed order lines
+	for _, d := range []time.Time{day, nextDay} {
+		var lineQuantity, lineRevenue, lineCost decimal.Decimal
+		for _, line := range lines {
+			if line.Date.Truncate(24 * time.Hour).Equal(d) {
+				lineQuantity = lineQuantity.Add(line.Quantity)
+				lineRevenue = lineRevenue.Add(line.Amount)
+				lineCost = lineCost.Add(line.Cost)
+			}
+		}
+		var factQuantity, factRevenue, factCost decimal.Decimal
+		for _, fact := range facts {
+			if fact.Date.Equal(d) {
+				factQuantity = factQuantity.Add(fact.Quantity)
+				factRevenue = factRevenue.Add(fact.Revenue)
+				factCost = factCost.Add(fact.Cost)
+			}
+		}
+		assert.True(t, lineQuantity.Equal(factQuantity), "quantity on %s: %s != %s", d, lineQuantity, factQuantity)
+		assert.True(t, lineRevenue.Equal(factRevenue), "revenue on %s: %s != %s", d, lineRevenue, factRevenue)
+		assert.True(t, lineCost.Equal(factCost), "cost on %s: %s != %s", d, lineCost, factCost)
+	}
+
+	// One fact per day, product and warehouse
+	require.Len(t, facts, 4)
+	for _, fact := range facts {
+		assert.Equal(t, tenantID, fact.TenantID)
+		if fact.Date.Equal(day) && fact.ProductID == productA && fact.WarehouseID == warehouse1 {
+			assert.Equal(t, int64(2), fact.OrderCount)
+			assert.Equal(t, "6", fact.Quantity.String())
+			assert.Equal(t, "590", fact.Revenue.String())
+		}
+	}
+	for i := 1; i < len(facts); i++ {
+		assert.False(t, facts[i].Date.Before(facts[i-1].Date), "facts should be ordered by date")
+	}
+}
+
+func TestAggregateDailySalesFacts_NoLines(t *testing.T) {
+	assert.Empty(t, AggregateDailySalesFacts(uuid.New(), nil))
+}
@@ -1,6 +1,8 @@
 package report
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -62,11 +64,60 @@ type ProductSalesRanking struct {
 type CustomerSalesRanking struct {
 	Rank          int             `json:"rank"`
 	CustomerID    uuid.UUID       `json:"customer_id"`
+	CustomerCode  string          `json:"customer_code,omitempty"`
 	CustomerName  string          `json:"customer_name"`
 	TotalOrders   int64           `json:"total_orders"`
 	TotalQuantity decimal.Decimal `json:"total_quantity"`
 	TotalAmount   decimal.Decimal `json:"total_amount"`
-	TotalProfit   decimal.Decimal `json:"total_profit"`
+	TotalProfit   decimal.Decimal `json:"total_profit"` // Gross margin: TotalAmount less the cost of goods sold
+	AvgOrderValue decimal.Decimal `json:"avg_order_value"`
+}
+
+// CustomerRankingMetric is the metric customers are ranked by
+type CustomerRankingMetric string
+
+const (
+	CustomerRankingByRevenue       CustomerRankingMetric = "revenue"
+	CustomerRankingByGrossMargin   CustomerRankingMetric = "gross_margin"
+	CustomerRankingByOrderCount    CustomerRankingMetric = "order_count"
+	CustomerRankingByAvgOrderValue CustomerRankingMetric = "avg_order_value"
+)
+
+// value returns the value of the metric for a customer
+func (m CustomerRankingMetric) value(r CustomerSalesRanking) decimal.Decimal {
+	switch m {
+	case CustomerRankingByGrossMargin:
+		return r.TotalProfit
+	case CustomerRankingByOrderCount:
+		return decimal.NewFromInt(r.TotalOrders)
+	case CustomerRankingByAvgOrderValue:
+		return r.AvgOrderValue
+	default:
+		return r.TotalAmount
+	}
+}
+
+// RankCustomerSales sorts customers by the metric, highest first, and numbers them from 1.
+// Ties are broken by customer code, then by customer ID, so the order is the same on every call
+// and pages of the ranking don't overlap. An empty metric ranks by revenue; topN > 0 keeps the first topN.
+func RankCustomerSales(customers []CustomerSalesRanking, metric CustomerRankingMetric, topN int) []CustomerSalesRanking {
+	ranked := slices.Clone(customers)
+	slices.SortStableFunc(ranked, func(a, b CustomerSalesRanking) int {
+		if c := metric.value(b).Cmp(metric.value(a)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.CustomerCode, b.CustomerCode); c != 0 {
+			return c
+		}
+		return strings.Compare(a.CustomerID.String(), b.CustomerID.String())
+	})
+	if topN > 0 && len(ranked) > topN {
+		ranked = ranked[:topN]
+	}
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+	return ranked
 }
 
 // SalesReportFilter defines filtering options for sales reports
@@ -78,6 +129,8 @@ type SalesReportFilter struct {
 	CategoryID *uuid.UUID `json:"category_id,omitempty"`
 	CustomerID *uuid.UUID `json:"customer_id,omitempty"`
 	TopN       int        `json:"top_n,omitempty"` // For rankings
+	// RankBy is the metric customer rankings are ordered by; empty means revenue
+	RankBy CustomerRankingMetric `json:"rank_by,omitempty"`
 }
 
 // SalesReportRepository defines the interface for sales report queries
@@ -85,7 +138,7 @@ type SalesReportRepository interface {
 	// GetSalesSummary returns aggregated sales summary for the period
 	GetSalesSummary(filter SalesReportFilter) (*SalesSummary, error)
 
-	// GetDailySalesTrend returns daily sales trend data
+	// GetDailySalesTrend returns the daily trend of completed sales, read from the daily sales facts
 	GetDailySalesTrend(filter SalesReportFilter) ([]DailySalesTrend, error)
 
 	// GetProductSalesReport returns sales report grouped by product
@@ -96,4 +149,7 @@ type SalesReportRepository interface {
 
 	// GetCustomerSalesRanking returns top N customers by sales
 	GetCustomerSalesRanking(filter SalesReportFilter) ([]CustomerSalesRanking, error)
+
+	// GetOrderFulfillmentTimes returns the lifecycle timestamps of orders confirmed in the period
+	GetOrderFulfillmentTimes(filter SalesReportFilter) ([]OrderFulfillmentTimes, error)
 }
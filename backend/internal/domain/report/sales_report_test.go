@@ -0,0 +1,64 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// rankingCustomers is one period of customer sales: C003 buys the most in total,
+// C001 orders most often in small amounts, C002 buys the most profitable goods
+func rankingCustomers() []CustomerSalesRanking {
+	dec := decimal.RequireFromString
+	return []CustomerSalesRanking{
+		{CustomerID: uuid.New(), CustomerCode: "C001", TotalOrders: 12, TotalAmount: dec("2400"), TotalProfit: dec("300"), AvgOrderValue: dec("200")},
+		{CustomerID: uuid.New(), CustomerCode: "C002", TotalOrders: 3, TotalAmount: dec("2700"), TotalProfit: dec("1200"), AvgOrderValue: dec("900")},
+		{CustomerID: uuid.New(), CustomerCode: "C003", TotalOrders: 6, TotalAmount: dec("3000"), TotalProfit: dec("600"), AvgOrderValue: dec("500")},
+	}
+}
+
+func customerCodes(ranked []CustomerSalesRanking) []string {
+	codes := make([]string, len(ranked))
+	for i, r := range ranked {
+		codes[i] = r.CustomerCode
+	}
+	return codes
+}
+
+func TestRankCustomerSales(t *testing.T) {
+	tests := []struct {
+		metric CustomerRankingMetric
+		want   []string
+	}{
+		{"", []string{"C003", "C002", "C001"}},
+		{CustomerRankingByRevenue, []string{"C003", "C002", "C001"}},
+		{CustomerRankingByGrossMargin, []string{"C002", "C003", "C001"}},
+		{CustomerRankingByOrderCount, []string{"C001", "C003", "C002"}},
+		{CustomerRankingByAvgOrderValue, []string{"C002", "C003", "C001"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			ranked := RankCustomerSales(rankingCustomers(), tt.metric, 0)
+			assert.Equal(t, tt.want, customerCodes(ranked))
+			for i, r := range ranked {
+				assert.Equal(t, i+1, r.Rank)
+			}
+		})
+	}
+}
+
+func TestRankCustomerSales_TiesBreakByCustomerCode(t *testing.T) {
+	amount := decimal.NewFromInt(1000)
+	customers := []CustomerSalesRanking{
+		{CustomerID: uuid.New(), CustomerCode: "C020", TotalOrders: 2, TotalAmount: amount},
+		{CustomerID: uuid.New(), CustomerCode: "C003", TotalOrders: 2, TotalAmount: amount},
+		{CustomerID: uuid.New(), CustomerCode: "C011", TotalOrders: 2, TotalAmount: amount},
+	}
+
+	ranked := RankCustomerSales(customers, CustomerRankingByRevenue, 2)
+
+	assert.Equal(t, []string{"C003", "C011"}, customerCodes(ranked))
+	assert.Equal(t, "C020", customers[0].CustomerCode, "the input should not be reordered")
+}
@@ -0,0 +1,67 @@
+package shared
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = NewDomainError("INVALID_CURSOR", "Invalid pagination cursor")
+
+// Cursor is the sort key of the last row of a keyset page.
+// The next page starts strictly after it, so rows inserted between fetches
+// neither shift nor repeat results the way OFFSET pagination does.
+// Direction records the sort order the cursor was issued for ("ASC" or "DESC"),
+// since seeking past it in the other order would skip or repeat rows.
+type Cursor struct {
+	SortValue time.Time `json:"v"`
+	ID        uuid.UUID `json:"id"`
+	Direction string    `json:"dir"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Direction != "ASC" && cursor.Direction != "DESC" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// CursorDirection returns the direction a cursor records for an order direction:
+// "ASC" for ascending, "DESC" for anything else
+func CursorDirection(orderDir string) string {
+	if strings.EqualFold(strings.TrimSpace(orderDir), "ASC") {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// NextCursor trims the extra row a keyset query fetches beyond pageSize and
+// returns the token for the next page, or "" when this is the last page.
+// orderDir is the order direction the rows were fetched in.
+func NextCursor[T any](rows []T, pageSize int, orderDir string, key func(T) (time.Time, uuid.UUID)) ([]T, string) {
+	if pageSize <= 0 || len(rows) <= pageSize {
+		return rows, ""
+	}
+	rows = rows[:pageSize]
+	sortValue, id := key(rows[pageSize-1])
+	return rows, Cursor{SortValue: sortValue, ID: id, Direction: CursorDirection(orderDir)}.Encode()
+}
@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	t.Run("round trips sort value and id", func(t *testing.T) {
+		cursor := Cursor{SortValue: time.Date(2026, 3, 1, 8, 30, 0, 123456000, time.UTC), ID: uuid.New(), Direction: "ASC"}
+
+		decoded, err := DecodeCursor(cursor.Encode())
+
+		require.NoError(t, err)
+		assert.True(t, cursor.SortValue.Equal(decoded.SortValue))
+		assert.Equal(t, cursor.ID, decoded.ID)
+		assert.Equal(t, "ASC", decoded.Direction)
+	})
+
+	t.Run("rejects a cursor without a sort direction", func(t *testing.T) {
+		_, err := DecodeCursor(Cursor{SortValue: time.Now(), ID: uuid.New()}.Encode())
+
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("rejects malformed tokens", func(t *testing.T) {
+		for _, token := range []string{"", "not base64!", "bm90IGpzb24", "e30"} {
+			_, err := DecodeCursor(token)
+			assert.ErrorIs(t, err, ErrInvalidCursor, "token %q", token)
+		}
+	})
+}
+
+func TestNextCursor(t *testing.T) {
+	type row struct {
+		at time.Time
+		id uuid.UUID
+	}
+	key := func(r row) (time.Time, uuid.UUID) { return r.at, r.id }
+	now := time.Now()
+	rows := []row{{now, uuid.New()}, {now.Add(-time.Minute), uuid.New()}, {now.Add(-2 * time.Minute), uuid.New()}}
+
+	t.Run("trims the extra row and points at the last kept row", func(t *testing.T) {
+		page, token := NextCursor(rows, 2, "desc", key)
+
+		require.Len(t, page, 2)
+		cursor, err := DecodeCursor(token)
+		require.NoError(t, err)
+		assert.Equal(t, rows[1].id, cursor.ID)
+		assert.Equal(t, "DESC", cursor.Direction)
+	})
+
+	t.Run("last page has no cursor", func(t *testing.T) {
+		page, token := NextCursor(rows, 3, "desc", key)
+
+		assert.Len(t, page, 3)
+		assert.Empty(t, token)
+	})
+}
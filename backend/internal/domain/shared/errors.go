@@ -1,5 +1,11 @@
 package shared
 
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
 // DomainError represents a domain-level error
 type DomainError struct {
 	Code    string `json:"code"`
@@ -31,3 +37,56 @@ var (
 	ErrInsufficientStock   = NewDomainError("INSUFFICIENT_STOCK", "Insufficient stock available")
 	ErrInsufficientBalance = NewDomainError("INSUFFICIENT_BALANCE", "Insufficient balance available")
 )
+
+// VersionConflictError reports that an aggregate was changed by someone else after it was loaded.
+// It carries the version now stored, so the client can refetch and retry.
+type VersionConflictError struct {
+	Resource       string
+	CurrentVersion int
+}
+
+// NewVersionConflictError creates a version conflict error for the named resource
+func NewVersionConflictError(resource string, currentVersion int) *VersionConflictError {
+	return &VersionConflictError{Resource: resource, CurrentVersion: currentVersion}
+}
+
+// Error implements the error interface
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("The %s has been modified by another user (current version %d)", e.Resource, e.CurrentVersion)
+}
+
+// Unwrap returns ErrConcurrencyConflict so the error maps to the CONCURRENCY_CONFLICT domain error code
+func (e *VersionConflictError) Unwrap() error {
+	return ErrConcurrencyConflict
+}
+
+// InsufficientBalanceError reports a deduction that exceeds what can be spent.
+// It carries the spendable amount, so the client can offer a smaller deduction.
+type InsufficientBalanceError struct {
+	Available decimal.Decimal
+	Requested decimal.Decimal
+}
+
+// NewInsufficientBalanceError creates an insufficient balance error
+func NewInsufficientBalanceError(available, requested decimal.Decimal) *InsufficientBalanceError {
+	return &InsufficientBalanceError{Available: available, Requested: requested}
+}
+
+// Error implements the error interface
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("Insufficient balance: available %s, required %s", e.Available.StringFixed(2), e.Requested.StringFixed(2))
+}
+
+// Unwrap returns ErrInsufficientBalance so the error maps to the INSUFFICIENT_BALANCE domain error code
+func (e *InsufficientBalanceError) Unwrap() error {
+	return ErrInsufficientBalance
+}
+
+// CheckVersion returns a VersionConflictError when the client sent the version it loaded
+// and the aggregate has moved on since. A nil expected version skips the check.
+func CheckVersion(resource string, expected *int, current int) error {
+	if expected != nil && *expected != current {
+		return NewVersionConflictError(resource, current)
+	}
+	return nil
+}
@@ -43,3 +43,19 @@ type OutboxEventSaver interface {
 	// The txProvider should be a *gorm.DB transaction
 	SaveEvents(ctx context.Context, txProvider interface{}, events ...DomainEvent) error
 }
+
+// replayContextKey marks a context whose events are being replayed
+type replayContextKey struct{}
+
+// WithEventReplay marks ctx as replaying events that were already delivered once.
+// Only read-model handlers are registered with the replay service; a handler
+// shared with live delivery can check IsEventReplay to skip other side effects.
+func WithEventReplay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayContextKey{}, true)
+}
+
+// IsEventReplay returns true if the events handled with ctx are being replayed
+func IsEventReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayContextKey{}).(bool)
+	return replay
+}
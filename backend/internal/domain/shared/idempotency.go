@@ -14,6 +14,9 @@ type IdempotencyStore interface {
 	// IsProcessed checks if an event has already been processed
 	IsProcessed(ctx context.Context, eventID string) (bool, error)
 
+	// Release removes the mark of an event, so it can be processed again
+	Release(ctx context.Context, eventID string) error
+
 	// Close closes the store and releases resources
 	Close() error
 }
@@ -21,13 +24,23 @@ type IdempotencyStore interface {
 // IdempotencyConfig holds configuration for idempotency handling
 type IdempotencyConfig struct {
 	// TTL is the time-to-live for processed event IDs
-	// After this duration, the same event ID can be processed again
+	// After this duration, the same event ID can be processed again; zero keeps them forever
 	// Default: 24 hours
 	TTL time.Duration
 
 	// Enabled determines whether idempotency checking is enabled
 	// Default: true
 	Enabled bool
+
+	// PerHandler marks events per handler, so every handler subscribed to an event
+	// processes it once instead of only the first handler to see it
+	// Default: false
+	PerHandler bool
+
+	// RetryOnFailure releases the mark of an event whose handler failed, so a
+	// redelivery processes it again instead of waiting for the TTL to expire
+	// Default: false
+	RetryOnFailure bool
 }
 
 // DefaultIdempotencyConfig returns the default idempotency configuration
@@ -37,3 +50,12 @@ func DefaultIdempotencyConfig() IdempotencyConfig {
 		Enabled: true,
 	}
 }
+
+// NamedEventHandler is an EventHandler with a stable name for per-handler idempotency.
+// Handlers without a name are tracked by their Go type name, so renaming the type
+// makes already-processed events look new to it.
+type NamedEventHandler interface {
+	EventHandler
+	// HandlerName returns the name the handler's processed events are recorded under
+	HandlerName() string
+}
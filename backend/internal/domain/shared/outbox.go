@@ -23,6 +23,7 @@ const (
 const (
 	DefaultMaxRetries  = 5
 	DefaultBaseBackoff = time.Second
+	DefaultMaxBackoff  = 5 * time.Minute
 )
 
 // OutboxEntry represents an event stored in the outbox for reliable delivery
@@ -86,7 +87,15 @@ func (e *OutboxEntry) MarkSent() {
 }
 
 // MarkFailed marks the entry as failed with error and calculates next retry time
+// using the default backoff
 func (e *OutboxEntry) MarkFailed(errMsg string) {
+	e.MarkFailedWithBackoff(errMsg, DefaultBaseBackoff, DefaultMaxBackoff)
+}
+
+// MarkFailedWithBackoff marks the entry as failed with error. Once the retry count
+// reaches MaxRetries the entry moves to the dead letter state, otherwise the next
+// retry is scheduled with exponential backoff from base, capped at max.
+func (e *OutboxEntry) MarkFailedWithBackoff(errMsg string, base, max time.Duration) {
 	e.RetryCount++
 	e.LastError = errMsg
 	e.UpdatedAt = time.Now()
@@ -95,13 +104,27 @@ func (e *OutboxEntry) MarkFailed(errMsg string) {
 		e.Status = OutboxStatusDead
 	} else {
 		e.Status = OutboxStatusFailed
-		// Exponential backoff: 1s, 2s, 4s, 8s, 16s, ...
-		backoff := DefaultBaseBackoff * time.Duration(1<<uint(e.RetryCount-1))
-		nextRetry := time.Now().Add(backoff)
+		nextRetry := time.Now().Add(RetryBackoff(e.RetryCount, base, max))
 		e.NextRetryAt = &nextRetry
 	}
 }
 
+// RetryBackoff returns the delay before retry attempt n (1-based):
+// base, 2*base, 4*base, ... capped at max
+func RetryBackoff(attempt int, base, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	backoff := base
+	for i := 1; i < attempt; i++ {
+		if backoff >= max/2 {
+			return max
+		}
+		backoff *= 2
+	}
+	return min(backoff, max)
+}
+
 // ResetForRetry resets a dead letter entry for retry
 func (e *OutboxEntry) ResetForRetry() error {
 	if e.Status != OutboxStatusDead {
@@ -125,6 +148,16 @@ func (OutboxEntry) TableName() string {
 	return "outbox_events"
 }
 
+// OutboxReplayFilter selects delivered outbox entries to replay
+type OutboxReplayFilter struct {
+	TenantID      *uuid.UUID // Optional; all tenants if nil
+	EventTypes    []string   // Optional; all event types if empty
+	AggregateType string     // Optional
+	From          time.Time  // Inclusive lower bound on the entry creation time
+	To            time.Time  // Exclusive upper bound on the entry creation time
+	Limit         int
+}
+
 // OutboxRepository defines the interface for outbox persistence
 type OutboxRepository interface {
 	// Save persists one or more outbox entries
@@ -135,8 +168,12 @@ type OutboxRepository interface {
 	FindRetryable(ctx context.Context, before time.Time, limit int) ([]*OutboxEntry, error)
 	// FindDead retrieves dead letter entries with pagination
 	FindDead(ctx context.Context, page, pageSize int) ([]*OutboxEntry, int64, error)
+	// FindOldestDead retrieves up to limit dead letter entries, oldest first
+	FindOldestDead(ctx context.Context, limit int) ([]*OutboxEntry, error)
 	// FindByID retrieves a single outbox entry by ID
 	FindByID(ctx context.Context, id uuid.UUID) (*OutboxEntry, error)
+	// FindForReplay retrieves sent entries matching the filter, oldest first
+	FindForReplay(ctx context.Context, filter OutboxReplayFilter) ([]*OutboxEntry, error)
 	// MarkProcessing atomically marks entries as processing and returns them
 	MarkProcessing(ctx context.Context, ids []uuid.UUID) ([]*OutboxEntry, error)
 	// Update updates an existing outbox entry
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOutboxEntry_ResetForRetry(t *testing.T) {
@@ -121,3 +122,31 @@ func TestOutboxEntry_MarkFailed_ExponentialBackoff(t *testing.T) {
 	thirdBackoff := entry.NextRetryAt.Sub(time.Now())
 	assert.True(t, thirdBackoff > 3*time.Second && thirdBackoff <= 5*time.Second)
 }
+
+func TestRetryBackoff(t *testing.T) {
+	base, max := time.Second, 10*time.Second
+
+	assert.Equal(t, time.Second, RetryBackoff(1, base, max))
+	assert.Equal(t, 2*time.Second, RetryBackoff(2, base, max))
+	assert.Equal(t, 8*time.Second, RetryBackoff(4, base, max))
+	// Capped at max, including attempts that would overflow
+	assert.Equal(t, max, RetryBackoff(5, base, max))
+	assert.Equal(t, max, RetryBackoff(100, base, max))
+}
+
+func TestOutboxEntry_MarkFailedWithBackoff(t *testing.T) {
+	entry := &OutboxEntry{
+		ID:         uuid.New(),
+		Status:     OutboxStatusProcessing,
+		RetryCount: 6,
+		MaxRetries: 10,
+	}
+
+	before := time.Now()
+	entry.MarkFailedWithBackoff("error", 100*time.Millisecond, time.Second)
+
+	assert.Equal(t, OutboxStatusFailed, entry.Status)
+	require.NotNil(t, entry.NextRetryAt)
+	// 100ms * 2^6 exceeds the cap, so the retry is one second out
+	assert.WithinDuration(t, before.Add(time.Second), *entry.NextRetryAt, 100*time.Millisecond)
+}
@@ -0,0 +1,101 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erp/backend/internal/domain/shared"
+)
+
+// ConflictKindAttribute marks a conflict over a product attribute key rather than a strategy name
+const ConflictKindAttribute = "attribute"
+
+// PluginConflict is a strategy name or attribute key that a registered plugin already claims
+type PluginConflict struct {
+	Kind   string // Strategy kind (cost, pricing, allocation, batch, validation) or "attribute"
+	Name   string // Strategy name or attribute key
+	Plugin string // Registered plugin that claims it
+}
+
+// PluginConflictError is returned when a plugin claims strategy names or attribute keys of registered plugins.
+// It unwraps to shared.ErrAlreadyExists.
+type PluginConflictError struct {
+	Plugin    string
+	Conflicts []PluginConflict
+}
+
+// Error implements the error interface
+func (e *PluginConflictError) Error() string {
+	parts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		if c.Kind == ConflictKindAttribute {
+			parts[i] = fmt.Sprintf("attribute '%s' is defined by plugin '%s'", c.Name, c.Plugin)
+		} else {
+			parts[i] = fmt.Sprintf("%s strategy '%s' is registered by plugin '%s'", c.Kind, c.Name, c.Plugin)
+		}
+	}
+	return fmt.Sprintf("plugin '%s' conflicts with registered plugins: %s", e.Plugin, strings.Join(parts, "; "))
+}
+
+// Unwrap returns shared.ErrAlreadyExists so callers can detect conflicts with errors.Is
+func (e *PluginConflictError) Unwrap() error {
+	return shared.ErrAlreadyExists
+}
+
+// strategyClaim is a strategy a plugin registers
+type strategyClaim struct {
+	kind     StrategyKind
+	name     string
+	strategy any
+}
+
+// recordingRegistrar records the strategies a plugin registers without registering them,
+// so they can be checked for conflicts first
+type recordingRegistrar struct {
+	claims []strategyClaim
+}
+
+func (r *recordingRegistrar) record(kind StrategyKind, s any) error {
+	name := ""
+	if named, ok := s.(interface{ Name() string }); ok {
+		name = named.Name()
+	}
+	r.claims = append(r.claims, strategyClaim{kind: kind, name: name, strategy: s})
+	return nil
+}
+
+func (r *recordingRegistrar) RegisterCostStrategy(s any) error {
+	return r.record(StrategyKindCost, s)
+}
+
+func (r *recordingRegistrar) RegisterPricingStrategy(s any) error {
+	return r.record(StrategyKindPricing, s)
+}
+
+func (r *recordingRegistrar) RegisterAllocationStrategy(s any) error {
+	return r.record(StrategyKindAllocation, s)
+}
+
+func (r *recordingRegistrar) RegisterBatchStrategy(s any) error {
+	return r.record(StrategyKindBatch, s)
+}
+
+func (r *recordingRegistrar) RegisterValidationStrategy(s any) error {
+	return r.record(StrategyKindValidation, s)
+}
+
+// register registers a recorded strategy with a registry
+func (c strategyClaim) register(registry StrategyRegistrar) error {
+	switch c.kind {
+	case StrategyKindCost:
+		return registry.RegisterCostStrategy(c.strategy)
+	case StrategyKindPricing:
+		return registry.RegisterPricingStrategy(c.strategy)
+	case StrategyKindAllocation:
+		return registry.RegisterAllocationStrategy(c.strategy)
+	case StrategyKindBatch:
+		return registry.RegisterBatchStrategy(c.strategy)
+	default:
+		return registry.RegisterValidationStrategy(c.strategy)
+	}
+}
@@ -1,7 +1,9 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"sync"
 
@@ -12,6 +14,7 @@ import (
 type PluginManager struct {
 	mu       sync.RWMutex
 	plugins  map[string]IndustryPlugin
+	claims   map[string][]strategyClaim // Strategies registered by each plugin
 	registry StrategyRegistrar
 }
 
@@ -19,12 +22,18 @@ type PluginManager struct {
 func NewPluginManager(registry StrategyRegistrar) *PluginManager {
 	return &PluginManager{
 		plugins:  make(map[string]IndustryPlugin),
+		claims:   make(map[string][]strategyClaim),
 		registry: registry,
 	}
 }
 
 // Register registers an industry plugin
-// This also triggers the plugin's strategy registration
+// This also triggers the plugin's strategy registration.
+//
+// Re-registering the same plugin (same name and implementation) is a no-op, while a different
+// plugin with a registered name is rejected with shared.ErrAlreadyExists. A plugin whose strategy
+// names or attribute keys are already claimed by a registered plugin is rejected with a
+// *PluginConflictError listing every conflict, and none of its strategies are registered.
 func (m *PluginManager) Register(plugin IndustryPlugin) error {
 	if plugin == nil {
 		return fmt.Errorf("%w: plugin cannot be nil", shared.ErrInvalidInput)
@@ -38,17 +47,80 @@ func (m *PluginManager) Register(plugin IndustryPlugin) error {
 		return fmt.Errorf("%w: plugin name cannot be empty", shared.ErrInvalidInput)
 	}
 
-	if _, exists := m.plugins[name]; exists {
+	if existing, exists := m.plugins[name]; exists {
+		if reflect.TypeOf(existing) == reflect.TypeOf(plugin) {
+			return nil
+		}
 		return fmt.Errorf("%w: plugin '%s' already registered", shared.ErrAlreadyExists, name)
 	}
 
+	// Collect the plugin's strategies and check them against the registered plugins first
+	recorder := &recordingRegistrar{}
+	plugin.RegisterStrategies(recorder)
+	if conflicts := m.findConflicts(plugin, recorder.claims); len(conflicts) > 0 {
+		return &PluginConflictError{Plugin: name, Conflicts: conflicts}
+	}
+
 	// Register plugin's strategies with the registry
-	plugin.RegisterStrategies(m.registry)
+	for i, claim := range recorder.claims {
+		if err := claim.register(m.registry); err != nil {
+			_ = m.unregisterStrategies(recorder.claims[:i])
+			return fmt.Errorf("plugin '%s': registering %s strategy '%s': %w", name, claim.kind, claim.name, err)
+		}
+	}
 
 	m.plugins[name] = plugin
+	m.claims[name] = recorder.claims
 	return nil
 }
 
+// findConflicts lists the strategy names and attribute keys of a plugin that registered plugins already claim
+func (m *PluginManager) findConflicts(plugin IndustryPlugin, claims []strategyClaim) []PluginConflict {
+	names := make([]string, 0, len(m.plugins))
+	for name := range m.plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var conflicts []PluginConflict
+	for _, other := range names {
+		for _, claim := range claims {
+			for _, registered := range m.claims[other] {
+				if claim.name != "" && claim.kind == registered.kind && claim.name == registered.name {
+					conflicts = append(conflicts, PluginConflict{Kind: string(claim.kind), Name: claim.name, Plugin: other})
+				}
+			}
+		}
+
+		otherKeys := make(map[string]bool)
+		for _, attr := range m.plugins[other].GetRequiredProductAttributes() {
+			otherKeys[attr.Key] = true
+		}
+		for _, attr := range plugin.GetRequiredProductAttributes() {
+			if otherKeys[attr.Key] {
+				conflicts = append(conflicts, PluginConflict{Kind: ConflictKindAttribute, Name: attr.Key, Plugin: other})
+			}
+		}
+	}
+	return conflicts
+}
+
+// unregisterStrategies removes strategies from the registry, if it can remove strategies
+func (m *PluginManager) unregisterStrategies(claims []strategyClaim) error {
+	unregistrar, ok := m.registry.(StrategyUnregistrar)
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for _, claim := range claims {
+		if err := unregistrar.UnregisterStrategy(claim.kind, claim.name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // GetPlugin returns a plugin by name
 func (m *PluginManager) GetPlugin(name string) (IndustryPlugin, bool) {
 	m.mu.RLock()
@@ -108,7 +180,9 @@ func (m *PluginManager) GetAttributesForPlugin(pluginName string) ([]AttributeDe
 	return plugin.GetRequiredProductAttributes(), nil
 }
 
-// Unregister removes a plugin (useful for testing)
+// Unregister removes a plugin, releasing its strategy names and attribute keys for other plugins.
+// If the registry can remove strategies (see StrategyUnregistrar), the plugin's strategies are removed too;
+// the plugin is unregistered even if removing them fails, and the failures are returned.
 func (m *PluginManager) Unregister(name string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -117,8 +191,10 @@ func (m *PluginManager) Unregister(name string) error {
 		return fmt.Errorf("%w: plugin '%s' not found", shared.ErrNotFound, name)
 	}
 
+	claims := m.claims[name]
 	delete(m.plugins, name)
-	return nil
+	delete(m.claims, name)
+	return m.unregisterStrategies(claims)
 }
 
 // Count returns the number of registered plugins
@@ -13,6 +13,7 @@ type mockPlugin struct {
 	name        string
 	displayName string
 	attributes  []AttributeDefinition
+	validators  []string // Names of the validation strategies the plugin registers
 }
 
 func (p *mockPlugin) Name() string {
@@ -24,7 +25,23 @@ func (p *mockPlugin) DisplayName() string {
 }
 
 func (p *mockPlugin) RegisterStrategies(registry StrategyRegistrar) {
-	// No-op for testing
+	for _, name := range p.validators {
+		_ = registry.RegisterValidationStrategy(mockStrategy{name: name})
+	}
+}
+
+// otherPlugin is a second IndustryPlugin implementation
+type otherPlugin struct {
+	mockPlugin
+}
+
+// mockStrategy is a test strategy identified by its name
+type mockStrategy struct {
+	name string
+}
+
+func (s mockStrategy) Name() string {
+	return s.name
 }
 
 func (p *mockPlugin) GetRequiredProductAttributes() []AttributeDefinition {
@@ -40,6 +57,26 @@ func (m *mockRegistrar) RegisterAllocationStrategy(s any) error { return nil }
 func (m *mockRegistrar) RegisterBatchStrategy(s any) error      { return nil }
 func (m *mockRegistrar) RegisterValidationStrategy(s any) error { return nil }
 
+// recordingMockRegistrar keeps the validation strategies registered with it and can remove them
+type recordingMockRegistrar struct {
+	mockRegistrar
+	validators map[string]bool
+}
+
+func newRecordingMockRegistrar() *recordingMockRegistrar {
+	return &recordingMockRegistrar{validators: make(map[string]bool)}
+}
+
+func (m *recordingMockRegistrar) RegisterValidationStrategy(s any) error {
+	m.validators[s.(mockStrategy).Name()] = true
+	return nil
+}
+
+func (m *recordingMockRegistrar) UnregisterStrategy(kind StrategyKind, name string) error {
+	delete(m.validators, name)
+	return nil
+}
+
 func TestNewPluginManager(t *testing.T) {
 	registry := &mockRegistrar{}
 	manager := NewPluginManager(registry)
@@ -85,17 +122,71 @@ func TestPluginManager_Register_EmptyName(t *testing.T) {
 	assert.ErrorIs(t, err, shared.ErrInvalidInput)
 }
 
+func TestPluginManager_Register_Idempotent(t *testing.T) {
+	registry := newRecordingMockRegistrar()
+	manager := NewPluginManager(registry)
+
+	plugin := &mockPlugin{name: "test", validators: []string{"test_validator"}}
+
+	require.NoError(t, manager.Register(plugin))
+	require.NoError(t, manager.Register(plugin))
+	// A new instance of the same plugin is the same plugin
+	require.NoError(t, manager.Register(&mockPlugin{name: "test", validators: []string{"test_validator"}}))
+
+	assert.Equal(t, 1, manager.Count())
+	assert.Len(t, registry.validators, 1)
+}
+
 func TestPluginManager_Register_Duplicate(t *testing.T) {
 	registry := &mockRegistrar{}
 	manager := NewPluginManager(registry)
 
-	plugin := &mockPlugin{name: "test"}
-
-	err := manager.Register(plugin)
+	err := manager.Register(&mockPlugin{name: "test"})
 	require.NoError(t, err)
 
-	err = manager.Register(plugin)
+	// A different plugin cannot take a registered name
+	err = manager.Register(&otherPlugin{mockPlugin{name: "test"}})
+	assert.ErrorIs(t, err, shared.ErrAlreadyExists)
+}
+
+func TestPluginManager_Register_Conflicts(t *testing.T) {
+	registry := newRecordingMockRegistrar()
+	manager := NewPluginManager(registry)
+
+	first := &mockPlugin{
+		name:       "agricultural",
+		attributes: []AttributeDefinition{{Key: "manufacturer"}, {Key: "registration_number"}},
+		validators: []string{"agricultural"},
+	}
+	require.NoError(t, manager.Register(first))
+
+	second := &otherPlugin{mockPlugin{
+		name:       "pharmacy",
+		attributes: []AttributeDefinition{{Key: "manufacturer"}, {Key: "approval_number"}},
+		validators: []string{"agricultural", "pharmacy"},
+	}}
+	err := manager.Register(second)
+
+	var conflictErr *PluginConflictError
+	require.ErrorAs(t, err, &conflictErr)
 	assert.ErrorIs(t, err, shared.ErrAlreadyExists)
+	assert.Equal(t, "pharmacy", conflictErr.Plugin)
+	assert.Equal(t, []PluginConflict{
+		{Kind: "validation", Name: "agricultural", Plugin: "agricultural"},
+		{Kind: ConflictKindAttribute, Name: "manufacturer", Plugin: "agricultural"},
+	}, conflictErr.Conflicts)
+	assert.Contains(t, err.Error(), "validation strategy 'agricultural' is registered by plugin 'agricultural'")
+	assert.Contains(t, err.Error(), "attribute 'manufacturer' is defined by plugin 'agricultural'")
+
+	// The rejected plugin registers none of its strategies
+	assert.Equal(t, []string{"agricultural"}, manager.ListPlugins())
+	assert.Equal(t, map[string]bool{"agricultural": true}, registry.validators)
+
+	// Once the first plugin is unregistered its names are free again
+	require.NoError(t, manager.Unregister("agricultural"))
+	assert.Empty(t, registry.validators)
+	require.NoError(t, manager.Register(second))
+	assert.Equal(t, map[string]bool{"agricultural": true, "pharmacy": true}, registry.validators)
 }
 
 func TestPluginManager_GetPlugin_Found(t *testing.T) {
@@ -42,3 +42,21 @@ type StrategyRegistrar interface {
 	// RegisterValidationStrategy registers a product validation strategy
 	RegisterValidationStrategy(s any) error
 }
+
+// StrategyKind identifies the kind of strategy a plugin registers
+type StrategyKind string
+
+const (
+	StrategyKindCost       StrategyKind = "cost"
+	StrategyKindPricing    StrategyKind = "pricing"
+	StrategyKindAllocation StrategyKind = "allocation"
+	StrategyKindBatch      StrategyKind = "batch"
+	StrategyKindValidation StrategyKind = "validation"
+)
+
+// StrategyUnregistrar is implemented by registries that can remove strategies.
+// When the registry implements it, unregistering a plugin also removes the plugin's strategies.
+type StrategyUnregistrar interface {
+	// UnregisterStrategy removes the strategy of a kind with the given name
+	UnregisterStrategy(kind StrategyKind, name string) error
+}
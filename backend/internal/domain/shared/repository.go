@@ -30,6 +30,10 @@ type Filter struct {
 	OrderDir string
 	Search   string
 	Filters  map[string]interface{}
+	// Keyset selects cursor pagination instead of Page: rows are ordered by the
+	// repository's keyset column and id, and After is the last row already seen.
+	Keyset bool
+	After  *Cursor
 }
 
 // DefaultFilter returns a filter with default values
@@ -11,10 +11,11 @@ import (
 type CostMethod string
 
 const (
-	CostMethodMovingAverage CostMethod = "moving_average"
-	CostMethodFIFO          CostMethod = "fifo"
-	CostMethodLIFO          CostMethod = "lifo"
-	CostMethodSpecific      CostMethod = "specific"
+	CostMethodMovingAverage         CostMethod = "moving_average"
+	CostMethodMovingWeightedAverage CostMethod = "moving_weighted_average"
+	CostMethodFIFO                  CostMethod = "fifo"
+	CostMethodLIFO                  CostMethod = "lifo"
+	CostMethodSpecific              CostMethod = "specific"
 )
 
 // String returns the string representation of the cost method
@@ -0,0 +1,57 @@
+package strategy
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenantStrategyConfig holds the strategies a tenant chose in place of the global defaults.
+// An empty name means the tenant has not chosen one and the global default applies.
+type TenantStrategyConfig struct {
+	TenantID           uuid.UUID
+	CostStrategy       string
+	PricingStrategy    string
+	AllocationStrategy string
+	// ReconciliationStrategy is the finance reconciliation strategy type (e.g. FIFO, LIFO),
+	// which is not part of the strategy registry
+	ReconciliationStrategy string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// NewTenantStrategyConfig creates an empty strategy configuration for a tenant
+func NewTenantStrategyConfig(tenantID uuid.UUID) *TenantStrategyConfig {
+	now := time.Now()
+	return &TenantStrategyConfig{
+		TenantID:  tenantID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// StrategyName returns the strategy the tenant chose for a registry strategy type, or empty if none
+func (c *TenantStrategyConfig) StrategyName(strategyType StrategyType) string {
+	if c == nil {
+		return ""
+	}
+	switch strategyType {
+	case StrategyTypeCost:
+		return c.CostStrategy
+	case StrategyTypePricing:
+		return c.PricingStrategy
+	case StrategyTypeAllocation:
+		return c.AllocationStrategy
+	default:
+		return ""
+	}
+}
+
+// TenantStrategyConfigRepository persists the strategy configurations of tenants
+type TenantStrategyConfigRepository interface {
+	// FindByTenantID returns the strategy configuration of a tenant, or shared.ErrNotFound if it has none
+	FindByTenantID(ctx context.Context, tenantID uuid.UUID) (*TenantStrategyConfig, error)
+	// Save creates or replaces the strategy configuration of a tenant
+	Save(ctx context.Context, config *TenantStrategyConfig) error
+}
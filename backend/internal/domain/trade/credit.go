@@ -0,0 +1,45 @@
+package trade
+
+import (
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrCreditLimitExceeded is returned when shipping an order would take a customer over their credit limit.
+// Use errors.Is to detect it and errors.As with *CreditLimitExceededError to get the amounts.
+var ErrCreditLimitExceeded = shared.NewDomainError("CREDIT_LIMIT_EXCEEDED", "Customer credit limit exceeded")
+
+// CreditLimitExceededError carries the figures behind a blocked shipment
+// so that callers can show why the order was rejected
+type CreditLimitExceededError struct {
+	CustomerID         uuid.UUID
+	CreditLimit        decimal.Decimal // The customer's credit limit
+	CurrentOutstanding decimal.Decimal // Outstanding receivables before this order
+	OrderAmount        decimal.Decimal // Amount of the order being shipped
+	Overage            decimal.Decimal // CurrentOutstanding + OrderAmount - CreditLimit
+}
+
+// NewCreditLimitExceededError creates a CreditLimitExceededError and calculates the overage
+func NewCreditLimitExceededError(customerID uuid.UUID, creditLimit, currentOutstanding, orderAmount decimal.Decimal) *CreditLimitExceededError {
+	return &CreditLimitExceededError{
+		CustomerID:         customerID,
+		CreditLimit:        creditLimit,
+		CurrentOutstanding: currentOutstanding,
+		OrderAmount:        orderAmount,
+		Overage:            currentOutstanding.Add(orderAmount).Sub(creditLimit),
+	}
+}
+
+// Error implements the error interface
+func (e *CreditLimitExceededError) Error() string {
+	return fmt.Sprintf("Customer credit limit exceeded: outstanding %s plus order amount %s exceeds credit limit %s by %s",
+		e.CurrentOutstanding.StringFixed(2), e.OrderAmount.StringFixed(2), e.CreditLimit.StringFixed(2), e.Overage.StringFixed(2))
+}
+
+// Unwrap returns ErrCreditLimitExceeded so the error maps to the CREDIT_LIMIT_EXCEEDED domain error code
+func (e *CreditLimitExceededError) Unwrap() error {
+	return ErrCreditLimitExceeded
+}
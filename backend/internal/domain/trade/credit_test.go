@@ -0,0 +1,32 @@
+package trade
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreditLimitExceededError(t *testing.T) {
+	customerID := uuid.New()
+
+	err := NewCreditLimitExceededError(customerID, decimal.NewFromInt(100000), decimal.NewFromInt(95000), decimal.NewFromInt(55000))
+
+	assert.Equal(t, customerID, err.CustomerID)
+	assert.True(t, err.Overage.Equal(decimal.NewFromInt(50000)))
+	assert.Contains(t, err.Error(), "exceeds credit limit 100000.00 by 50000.00")
+}
+
+func TestCreditLimitExceededError_Unwrap(t *testing.T) {
+	var err error = NewCreditLimitExceededError(uuid.New(), decimal.NewFromInt(100), decimal.NewFromInt(80), decimal.NewFromInt(50))
+
+	assert.True(t, errors.Is(err, ErrCreditLimitExceeded))
+
+	var domainErr *shared.DomainError
+	require.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "CREDIT_LIMIT_EXCEEDED", domainErr.Code)
+}
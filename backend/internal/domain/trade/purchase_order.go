@@ -14,18 +14,19 @@ import (
 type PurchaseOrderStatus string
 
 const (
-	PurchaseOrderStatusDraft           PurchaseOrderStatus = "DRAFT"
-	PurchaseOrderStatusConfirmed       PurchaseOrderStatus = "CONFIRMED"
-	PurchaseOrderStatusPartialReceived PurchaseOrderStatus = "PARTIAL_RECEIVED"
-	PurchaseOrderStatusCompleted       PurchaseOrderStatus = "COMPLETED"
-	PurchaseOrderStatusCancelled       PurchaseOrderStatus = "CANCELLED"
+	PurchaseOrderStatusDraft            PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderStatusConfirmed        PurchaseOrderStatus = "CONFIRMED"
+	PurchaseOrderStatusPendingAmendment PurchaseOrderStatus = "PENDING_AMENDMENT"
+	PurchaseOrderStatusPartialReceived  PurchaseOrderStatus = "PARTIAL_RECEIVED"
+	PurchaseOrderStatusCompleted        PurchaseOrderStatus = "COMPLETED"
+	PurchaseOrderStatusCancelled        PurchaseOrderStatus = "CANCELLED"
 )
 
 // IsValid checks if the status is a valid PurchaseOrderStatus
 func (s PurchaseOrderStatus) IsValid() bool {
 	switch s {
-	case PurchaseOrderStatusDraft, PurchaseOrderStatusConfirmed, PurchaseOrderStatusPartialReceived,
-		PurchaseOrderStatusCompleted, PurchaseOrderStatusCancelled:
+	case PurchaseOrderStatusDraft, PurchaseOrderStatusConfirmed, PurchaseOrderStatusPendingAmendment,
+		PurchaseOrderStatusPartialReceived, PurchaseOrderStatusCompleted, PurchaseOrderStatusCancelled:
 		return true
 	}
 	return false
@@ -42,7 +43,10 @@ func (s PurchaseOrderStatus) CanTransitionTo(target PurchaseOrderStatus) bool {
 	case PurchaseOrderStatusDraft:
 		return target == PurchaseOrderStatusConfirmed || target == PurchaseOrderStatusCancelled
 	case PurchaseOrderStatusConfirmed:
-		return target == PurchaseOrderStatusPartialReceived || target == PurchaseOrderStatusCompleted || target == PurchaseOrderStatusCancelled
+		return target == PurchaseOrderStatusPartialReceived || target == PurchaseOrderStatusCompleted || target == PurchaseOrderStatusCancelled ||
+			target == PurchaseOrderStatusPendingAmendment
+	case PurchaseOrderStatusPendingAmendment:
+		return target == PurchaseOrderStatusConfirmed // Amendment approved or rejected
 	case PurchaseOrderStatusPartialReceived:
 		return target == PurchaseOrderStatusPartialReceived || target == PurchaseOrderStatusCompleted
 	case PurchaseOrderStatusCompleted, PurchaseOrderStatusCancelled:
@@ -242,6 +246,9 @@ type PurchaseOrder struct {
 	CompletedAt    *time.Time
 	CancelledAt    *time.Time
 	CancelReason   string
+
+	// Proposed line changes awaiting approval; set only in PENDING_AMENDMENT status
+	PendingAmendment *PurchaseOrderAmendment
 }
 
 // NewPurchaseOrder creates a new purchase order
@@ -636,6 +643,11 @@ func (o *PurchaseOrder) IsPartialReceived() bool {
 	return o.Status == PurchaseOrderStatusPartialReceived
 }
 
+// IsPendingAmendment returns true if an amendment of the order awaits approval
+func (o *PurchaseOrder) IsPendingAmendment() bool {
+	return o.Status == PurchaseOrderStatusPendingAmendment
+}
+
 // IsCompleted returns true if order is completed
 func (o *PurchaseOrder) IsCompleted() bool {
 	return o.Status == PurchaseOrderStatusCompleted
@@ -0,0 +1,219 @@
+package trade
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PurchaseOrderAmendmentLine is a proposed change to one line of a confirmed purchase order
+type PurchaseOrderAmendmentLine struct {
+	ItemID   uuid.UUID        `json:"item_id"`
+	Quantity *decimal.Decimal `json:"quantity,omitempty"`  // New ordered quantity; nil keeps the current quantity
+	UnitCost *decimal.Decimal `json:"unit_cost,omitempty"` // New unit cost; nil keeps the current cost
+}
+
+// PurchaseOrderAmendment holds line changes proposed for a confirmed purchase order.
+// The changes only take effect once the amendment is approved.
+type PurchaseOrderAmendment struct {
+	Lines       []PurchaseOrderAmendmentLine `json:"lines"`
+	Reason      string                       `json:"reason"`
+	RequestedBy uuid.UUID                    `json:"requested_by"`
+	RequestedAt time.Time                    `json:"requested_at"`
+}
+
+// Value implements driver.Valuer interface for GORM to write to JSONB
+func (a PurchaseOrderAmendment) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner interface for GORM to read from JSONB
+func (a *PurchaseOrderAmendment) Scan(value interface{}) error {
+	if value == nil {
+		*a = PurchaseOrderAmendment{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan PurchaseOrderAmendment: unsupported type")
+	}
+
+	if len(bytes) == 0 {
+		*a = PurchaseOrderAmendment{}
+		return nil
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+// RequestAmendment proposes line changes for a confirmed order, moving it to PENDING_AMENDMENT
+// until the amendment is approved or rejected. Amendments are not allowed once any goods
+// have been received.
+func (o *PurchaseOrder) RequestAmendment(lines []PurchaseOrderAmendmentLine, reason string, requestedBy uuid.UUID) error {
+	if o.hasReceivedAnyGoods() {
+		return shared.NewDomainError("ALREADY_RECEIVED", "Cannot amend order after goods have been received")
+	}
+	if !o.Status.CanTransitionTo(PurchaseOrderStatusPendingAmendment) {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot amend order in %s status", o.Status))
+	}
+	if reason == "" {
+		return shared.NewDomainError("INVALID_REASON", "Amendment reason is required")
+	}
+	if requestedBy == uuid.Nil {
+		return shared.NewDomainError("INVALID_USER", "Requester user ID cannot be empty")
+	}
+
+	total, err := o.amendedTotal(lines)
+	if err != nil {
+		return err
+	}
+	if total.Sub(o.DiscountAmount).LessThanOrEqual(decimal.Zero) {
+		return shared.NewDomainError("INVALID_AMOUNT", "Amended order payable amount must be positive")
+	}
+
+	now := time.Now()
+	o.PendingAmendment = &PurchaseOrderAmendment{
+		Lines:       lines,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+		RequestedAt: now,
+	}
+	o.Status = PurchaseOrderStatusPendingAmendment
+	o.UpdatedAt = now
+
+	return nil
+}
+
+// ApproveAmendment applies the pending amendment to the order lines, recalculates the totals
+// and returns the order to CONFIRMED
+func (o *PurchaseOrder) ApproveAmendment(approvedBy uuid.UUID) error {
+	if !o.IsPendingAmendment() || o.PendingAmendment == nil {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot approve amendment for order in %s status", o.Status))
+	}
+	if approvedBy == uuid.Nil {
+		return shared.NewDomainError("INVALID_USER", "Approver user ID cannot be empty")
+	}
+
+	// Validate against the current lines again before changing anything
+	if _, err := o.amendedTotal(o.PendingAmendment.Lines); err != nil {
+		return err
+	}
+
+	amendment := *o.PendingAmendment
+	previousTotal := o.TotalAmount
+	previousPayable := o.PayableAmount
+
+	changes := make([]PurchaseOrderAmendmentChange, 0, len(amendment.Lines))
+	for _, line := range amendment.Lines {
+		item := o.GetItem(line.ItemID)
+		change := PurchaseOrderAmendmentChange{
+			ItemID:           item.ID,
+			ProductID:        item.ProductID,
+			ProductName:      item.ProductName,
+			ProductCode:      item.ProductCode,
+			Unit:             item.Unit,
+			PreviousQuantity: item.OrderedQuantity,
+			PreviousUnitCost: item.UnitCost,
+		}
+		if line.Quantity != nil {
+			if err := item.UpdateQuantity(*line.Quantity); err != nil {
+				return err
+			}
+		}
+		if line.UnitCost != nil {
+			if err := item.UpdateUnitCost(valueobject.NewMoneyCNY(*line.UnitCost)); err != nil {
+				return err
+			}
+		}
+		change.Quantity = item.OrderedQuantity
+		change.UnitCost = item.UnitCost
+		changes = append(changes, change)
+	}
+	o.recalculateTotals()
+
+	o.Status = PurchaseOrderStatusConfirmed
+	o.PendingAmendment = nil
+	o.UpdatedAt = time.Now()
+
+	o.AddDomainEvent(NewPurchaseOrderAmendedEvent(o, amendment, changes, previousTotal, previousPayable, approvedBy))
+
+	return nil
+}
+
+// RejectAmendment discards the pending amendment and returns the order to CONFIRMED unchanged
+func (o *PurchaseOrder) RejectAmendment(reason string) error {
+	if !o.IsPendingAmendment() {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot reject amendment for order in %s status", o.Status))
+	}
+	if reason == "" {
+		return shared.NewDomainError("INVALID_REASON", "Rejection reason is required")
+	}
+
+	o.Status = PurchaseOrderStatusConfirmed
+	o.PendingAmendment = nil
+	o.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// amendedTotal validates amendment lines against the order and returns the order total
+// the amendment would result in
+func (o *PurchaseOrder) amendedTotal(lines []PurchaseOrderAmendmentLine) (decimal.Decimal, error) {
+	if len(lines) == 0 {
+		return decimal.Zero, shared.NewDomainError("NO_ITEMS", "Amendment must change at least one line")
+	}
+
+	amounts := make(map[uuid.UUID]decimal.Decimal, len(lines))
+	for _, line := range lines {
+		item := o.GetItem(line.ItemID)
+		if item == nil {
+			return decimal.Zero, shared.NewDomainError("ITEM_NOT_FOUND", fmt.Sprintf("Item %s not found in order", line.ItemID))
+		}
+		if _, ok := amounts[line.ItemID]; ok {
+			return decimal.Zero, shared.NewDomainError("DUPLICATE_ITEM", fmt.Sprintf("Item %s is amended more than once", line.ItemID))
+		}
+
+		quantity := item.OrderedQuantity
+		if line.Quantity != nil {
+			if line.Quantity.LessThanOrEqual(decimal.Zero) {
+				return decimal.Zero, shared.NewDomainError("INVALID_QUANTITY", fmt.Sprintf("Quantity for %s must be positive", item.ProductName))
+			}
+			quantity = *line.Quantity
+		}
+		unitCost := item.UnitCost
+		if line.UnitCost != nil {
+			if line.UnitCost.IsNegative() {
+				return decimal.Zero, shared.NewDomainError("INVALID_COST", fmt.Sprintf("Unit cost for %s cannot be negative", item.ProductName))
+			}
+			unitCost = *line.UnitCost
+		}
+		if quantity.Equal(item.OrderedQuantity) && unitCost.Equal(item.UnitCost) {
+			return decimal.Zero, shared.NewDomainError("NO_CHANGES", fmt.Sprintf("Amendment does not change %s", item.ProductName))
+		}
+
+		amounts[line.ItemID] = quantity.Mul(unitCost)
+	}
+
+	total := decimal.Zero
+	for _, item := range o.Items {
+		if amount, ok := amounts[item.ID]; ok {
+			total = total.Add(amount)
+		} else {
+			total = total.Add(item.Amount)
+		}
+	}
+	return total, nil
+}
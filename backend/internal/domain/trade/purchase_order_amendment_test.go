@@ -0,0 +1,248 @@
+package trade
+
+import (
+	"testing"
+
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createConfirmedPurchaseOrder creates a confirmed order of 10 x 100 and 5 x 20 with a 100 discount
+func createConfirmedPurchaseOrder(t *testing.T) (*PurchaseOrder, *PurchaseOrderItem, *PurchaseOrderItem) {
+	order := createTestPurchaseOrder(t)
+	first := addTestPurchaseOrderItem(t, order, "Product 1", 10, 100.00)
+	second := addTestPurchaseOrderItem(t, order, "Product 2", 5, 20.00)
+	require.NoError(t, order.ApplyDiscount(valueobject.NewMoneyCNYFromFloat(100)))
+	require.NoError(t, order.SetWarehouse(uuid.New()))
+	require.NoError(t, order.Confirm())
+	order.ClearDomainEvents()
+	return order, first, second
+}
+
+func decimalPtr(v float64) *decimal.Decimal {
+	d := decimal.NewFromFloat(v)
+	return &d
+}
+
+func TestPurchaseOrder_RequestAmendment(t *testing.T) {
+	requestedBy := uuid.New()
+
+	t.Run("moves confirmed order to pending amendment without changing lines", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+
+		err := order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, Quantity: decimalPtr(12), UnitCost: decimalPtr(95)},
+		}, "Supplier price change", requestedBy)
+
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderStatusPendingAmendment, order.Status)
+		assert.True(t, order.IsPendingAmendment())
+		require.NotNil(t, order.PendingAmendment)
+		assert.Equal(t, "Supplier price change", order.PendingAmendment.Reason)
+		assert.Equal(t, requestedBy, order.PendingAmendment.RequestedBy)
+		assert.True(t, decimal.NewFromInt(10).Equal(order.GetItem(first.ID).OrderedQuantity))
+		assert.True(t, decimal.NewFromInt(1100).Equal(order.TotalAmount))
+		assert.Empty(t, order.GetDomainEvents())
+	})
+
+	t.Run("blocks receiving and a second amendment while pending", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+		require.NoError(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, Quantity: decimalPtr(12)},
+		}, "More units", requestedBy))
+
+		_, err := order.Receive([]ReceiveItem{{ProductID: first.ProductID, Quantity: decimal.NewFromInt(1)}})
+		assert.Error(t, err)
+
+		err = order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, Quantity: decimalPtr(15)},
+		}, "Even more units", requestedBy)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejected after partial receipt", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+		_, err := order.Receive([]ReceiveItem{{ProductID: first.ProductID, Quantity: decimal.NewFromInt(4)}})
+		require.NoError(t, err)
+
+		err = order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, UnitCost: decimalPtr(90)},
+		}, "Supplier price change", requestedBy)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after goods have been received")
+		assert.Equal(t, PurchaseOrderStatusPartialReceived, order.Status)
+		assert.Nil(t, order.PendingAmendment)
+	})
+
+	t.Run("rejected for draft order", func(t *testing.T) {
+		order := createTestPurchaseOrder(t)
+		item := addTestPurchaseOrderItem(t, order, "Product 1", 10, 100.00)
+
+		err := order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: item.ID, Quantity: decimalPtr(12)},
+		}, "More units", requestedBy)
+		assert.Error(t, err)
+	})
+
+	t.Run("validates lines", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+
+		tests := []struct {
+			name  string
+			lines []PurchaseOrderAmendmentLine
+		}{
+			{"no lines", nil},
+			{"unknown item", []PurchaseOrderAmendmentLine{{ItemID: uuid.New(), Quantity: decimalPtr(1)}}},
+			{"duplicate item", []PurchaseOrderAmendmentLine{{ItemID: first.ID, Quantity: decimalPtr(11)}, {ItemID: first.ID, UnitCost: decimalPtr(90)}}},
+			{"zero quantity", []PurchaseOrderAmendmentLine{{ItemID: first.ID, Quantity: decimalPtr(0)}}},
+			{"negative cost", []PurchaseOrderAmendmentLine{{ItemID: first.ID, UnitCost: decimalPtr(-1)}}},
+			{"no change", []PurchaseOrderAmendmentLine{{ItemID: first.ID, Quantity: decimalPtr(10), UnitCost: decimalPtr(100)}}},
+			{"total below discount", []PurchaseOrderAmendmentLine{{ItemID: first.ID, UnitCost: decimalPtr(0)}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := order.RequestAmendment(tt.lines, "Change", requestedBy)
+				assert.Error(t, err)
+				assert.Equal(t, PurchaseOrderStatusConfirmed, order.Status)
+			})
+		}
+
+		assert.Error(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{{ItemID: first.ID, Quantity: decimalPtr(12)}}, "", requestedBy))
+		assert.Error(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{{ItemID: first.ID, Quantity: decimalPtr(12)}}, "Change", uuid.Nil))
+	})
+}
+
+func TestPurchaseOrder_ApproveAmendment(t *testing.T) {
+	requestedBy := uuid.New()
+	approvedBy := uuid.New()
+
+	t.Run("applies changes and recalculates totals", func(t *testing.T) {
+		order, first, second := createConfirmedPurchaseOrder(t)
+		require.NoError(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, Quantity: decimalPtr(12), UnitCost: decimalPtr(95)},
+			{ItemID: second.ID, UnitCost: decimalPtr(22)},
+		}, "Supplier price change", requestedBy))
+
+		err := order.ApproveAmendment(approvedBy)
+
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderStatusConfirmed, order.Status)
+		assert.Nil(t, order.PendingAmendment)
+
+		amendedFirst := order.GetItem(first.ID)
+		assert.True(t, decimal.NewFromInt(12).Equal(amendedFirst.OrderedQuantity))
+		assert.True(t, decimal.NewFromInt(95).Equal(amendedFirst.UnitCost))
+		assert.True(t, decimal.NewFromInt(1140).Equal(amendedFirst.Amount))
+		assert.True(t, decimal.NewFromInt(12).Equal(amendedFirst.BaseQuantity))
+		amendedSecond := order.GetItem(second.ID)
+		assert.True(t, decimal.NewFromInt(5).Equal(amendedSecond.OrderedQuantity))
+		assert.True(t, decimal.NewFromInt(110).Equal(amendedSecond.Amount))
+
+		assert.True(t, decimal.NewFromInt(1250).Equal(order.TotalAmount))
+		assert.True(t, decimal.NewFromInt(100).Equal(order.DiscountAmount))
+		assert.True(t, decimal.NewFromInt(1150).Equal(order.PayableAmount))
+
+		events := order.GetDomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(*PurchaseOrderAmendedEvent)
+		require.True(t, ok)
+		assert.Equal(t, EventTypePurchaseOrderAmended, event.EventType())
+		assert.Equal(t, order.ID, event.OrderID)
+		assert.True(t, decimal.NewFromInt(1100).Equal(event.PreviousTotalAmount))
+		assert.True(t, decimal.NewFromInt(1000).Equal(event.PreviousPayableAmount))
+		assert.True(t, decimal.NewFromInt(1250).Equal(event.TotalAmount))
+		assert.True(t, decimal.NewFromInt(1150).Equal(event.PayableAmount))
+		assert.Equal(t, "Supplier price change", event.Reason)
+		assert.Equal(t, requestedBy, event.RequestedBy)
+		assert.Equal(t, approvedBy, event.ApprovedBy)
+		require.Len(t, event.Changes, 2)
+		assert.True(t, decimal.NewFromInt(10).Equal(event.Changes[0].PreviousQuantity))
+		assert.True(t, decimal.NewFromInt(12).Equal(event.Changes[0].Quantity))
+		assert.True(t, decimal.NewFromInt(20).Equal(event.Changes[1].PreviousUnitCost))
+		assert.True(t, decimal.NewFromInt(22).Equal(event.Changes[1].UnitCost))
+	})
+
+	t.Run("amended order can be received at the new cost", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+		require.NoError(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, UnitCost: decimalPtr(90)},
+		}, "Supplier price change", requestedBy))
+		require.NoError(t, order.ApproveAmendment(approvedBy))
+
+		received, err := order.Receive([]ReceiveItem{{ProductID: first.ProductID, Quantity: decimal.NewFromInt(10)}})
+
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		assert.True(t, decimal.NewFromInt(90).Equal(received[0].UnitCost))
+	})
+
+	t.Run("fails without pending amendment", func(t *testing.T) {
+		order, _, _ := createConfirmedPurchaseOrder(t)
+
+		err := order.ApproveAmendment(approvedBy)
+		assert.Error(t, err)
+	})
+
+	t.Run("fails without approver", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+		require.NoError(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, Quantity: decimalPtr(12)},
+		}, "More units", requestedBy))
+
+		err := order.ApproveAmendment(uuid.Nil)
+		assert.Error(t, err)
+		assert.True(t, order.IsPendingAmendment())
+	})
+}
+
+func TestPurchaseOrder_RejectAmendment(t *testing.T) {
+	t.Run("discards changes and returns to confirmed", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+		require.NoError(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, Quantity: decimalPtr(12)},
+		}, "More units", uuid.New()))
+
+		err := order.RejectAmendment("Budget exceeded")
+
+		require.NoError(t, err)
+		assert.Equal(t, PurchaseOrderStatusConfirmed, order.Status)
+		assert.Nil(t, order.PendingAmendment)
+		assert.True(t, decimal.NewFromInt(10).Equal(order.GetItem(first.ID).OrderedQuantity))
+		assert.True(t, decimal.NewFromInt(1000).Equal(order.PayableAmount))
+		assert.Empty(t, order.GetDomainEvents())
+	})
+
+	t.Run("fails without reason or pending amendment", func(t *testing.T) {
+		order, first, _ := createConfirmedPurchaseOrder(t)
+		assert.Error(t, order.RejectAmendment("Not pending"))
+
+		require.NoError(t, order.RequestAmendment([]PurchaseOrderAmendmentLine{
+			{ItemID: first.ID, Quantity: decimalPtr(12)},
+		}, "More units", uuid.New()))
+		assert.Error(t, order.RejectAmendment(""))
+		assert.True(t, order.IsPendingAmendment())
+	})
+}
+
+func TestPurchaseOrderAmendment_ScanValue(t *testing.T) {
+	amendment := PurchaseOrderAmendment{
+		Lines:       []PurchaseOrderAmendmentLine{{ItemID: uuid.New(), Quantity: decimalPtr(12)}},
+		Reason:      "More units",
+		RequestedBy: uuid.New(),
+	}
+
+	value, err := amendment.Value()
+	require.NoError(t, err)
+
+	var scanned PurchaseOrderAmendment
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, amendment.Reason, scanned.Reason)
+	assert.Equal(t, amendment.RequestedBy, scanned.RequestedBy)
+	require.Len(t, scanned.Lines, 1)
+	assert.Nil(t, scanned.Lines[0].UnitCost)
+	assert.True(t, decimal.NewFromInt(12).Equal(*scanned.Lines[0].Quantity))
+}
@@ -18,6 +18,7 @@ const (
 	EventTypePurchaseOrderReceived  = "PurchaseOrderReceived"
 	EventTypePurchaseOrderCompleted = "PurchaseOrderCompleted"
 	EventTypePurchaseOrderCancelled = "PurchaseOrderCancelled"
+	EventTypePurchaseOrderAmended   = "PurchaseOrderAmended"
 )
 
 // PurchaseOrderCreatedEvent is raised when a new purchase order is created
@@ -129,8 +130,8 @@ type PurchaseOrderReceivedEvent struct {
 	SupplierName    string             `json:"supplier_name"`
 	WarehouseID     uuid.UUID          `json:"warehouse_id"`
 	ReceivedItems   []ReceivedItemInfo `json:"received_items"`
-	TotalAmount     decimal.Decimal    `json:"total_amount"`
-	PayableAmount   decimal.Decimal    `json:"payable_amount"`
+	TotalAmount     decimal.Decimal    `json:"total_amount"`      // Order total amount
+	PayableAmount   decimal.Decimal    `json:"payable_amount"`    // Amount payable for the goods received in this operation
 	IsFullyReceived bool               `json:"is_fully_received"` // True if this completes the order
 }
 
@@ -150,11 +151,25 @@ func NewPurchaseOrderReceivedEvent(order *PurchaseOrder, receivedItems []Receive
 		WarehouseID:     warehouseID,
 		ReceivedItems:   receivedItems,
 		TotalAmount:     order.TotalAmount,
-		PayableAmount:   order.PayableAmount,
+		PayableAmount:   receiptPayableAmount(order, receivedItems),
 		IsFullyReceived: order.IsCompleted(),
 	}
 }
 
+// receiptPayableAmount calculates the payable amount for a single receipt.
+// The received value is scaled by the order's payable ratio so that
+// order-level discounts are applied proportionally across partial receipts.
+func receiptPayableAmount(order *PurchaseOrder, receivedItems []ReceivedItemInfo) decimal.Decimal {
+	receivedValue := decimal.Zero
+	for _, item := range receivedItems {
+		receivedValue = receivedValue.Add(item.Quantity.Mul(item.UnitCost))
+	}
+	if order.TotalAmount.IsZero() || order.PayableAmount.Equal(order.TotalAmount) {
+		return receivedValue.Round(2)
+	}
+	return receivedValue.Mul(order.PayableAmount).Div(order.TotalAmount).Round(2)
+}
+
 // EventType returns the event type name
 func (e *PurchaseOrderReceivedEvent) EventType() string {
 	return EventTypePurchaseOrderReceived
@@ -233,3 +248,57 @@ func NewPurchaseOrderCancelledEvent(order *PurchaseOrder, wasConfirmed bool) *Pu
 func (e *PurchaseOrderCancelledEvent) EventType() string {
 	return EventTypePurchaseOrderCancelled
 }
+
+// PurchaseOrderAmendmentChange describes how an approved amendment changed an order line
+type PurchaseOrderAmendmentChange struct {
+	ItemID           uuid.UUID       `json:"item_id"`
+	ProductID        uuid.UUID       `json:"product_id"`
+	ProductName      string          `json:"product_name"`
+	ProductCode      string          `json:"product_code"`
+	Unit             string          `json:"unit"`
+	PreviousQuantity decimal.Decimal `json:"previous_quantity"`
+	Quantity         decimal.Decimal `json:"quantity"`
+	PreviousUnitCost decimal.Decimal `json:"previous_unit_cost"`
+	UnitCost         decimal.Decimal `json:"unit_cost"`
+}
+
+// PurchaseOrderAmendedEvent is raised when an amendment of a confirmed purchase order is approved
+type PurchaseOrderAmendedEvent struct {
+	shared.BaseDomainEvent
+	OrderID               uuid.UUID                      `json:"order_id"`
+	OrderNumber           string                         `json:"order_number"`
+	SupplierID            uuid.UUID                      `json:"supplier_id"`
+	SupplierName          string                         `json:"supplier_name"`
+	Changes               []PurchaseOrderAmendmentChange `json:"changes"`
+	PreviousTotalAmount   decimal.Decimal                `json:"previous_total_amount"`
+	PreviousPayableAmount decimal.Decimal                `json:"previous_payable_amount"`
+	TotalAmount           decimal.Decimal                `json:"total_amount"`
+	PayableAmount         decimal.Decimal                `json:"payable_amount"`
+	Reason                string                         `json:"reason"`
+	RequestedBy           uuid.UUID                      `json:"requested_by"`
+	ApprovedBy            uuid.UUID                      `json:"approved_by"`
+}
+
+// NewPurchaseOrderAmendedEvent creates a new PurchaseOrderAmendedEvent
+func NewPurchaseOrderAmendedEvent(order *PurchaseOrder, amendment PurchaseOrderAmendment, changes []PurchaseOrderAmendmentChange, previousTotal, previousPayable decimal.Decimal, approvedBy uuid.UUID) *PurchaseOrderAmendedEvent {
+	return &PurchaseOrderAmendedEvent{
+		BaseDomainEvent:       shared.NewBaseDomainEvent(EventTypePurchaseOrderAmended, AggregateTypePurchaseOrder, order.ID, order.TenantID),
+		OrderID:               order.ID,
+		OrderNumber:           order.OrderNumber,
+		SupplierID:            order.SupplierID,
+		SupplierName:          order.SupplierName,
+		Changes:               changes,
+		PreviousTotalAmount:   previousTotal,
+		PreviousPayableAmount: previousPayable,
+		TotalAmount:           order.TotalAmount,
+		PayableAmount:         order.PayableAmount,
+		Reason:                amendment.Reason,
+		RequestedBy:           amendment.RequestedBy,
+		ApprovedBy:            approvedBy,
+	}
+}
+
+// EventType returns the event type name
+func (e *PurchaseOrderAmendedEvent) EventType() string {
+	return EventTypePurchaseOrderAmended
+}
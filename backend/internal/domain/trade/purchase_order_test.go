@@ -39,6 +39,7 @@ func TestPurchaseOrderStatus_IsValid(t *testing.T) {
 	}{
 		{PurchaseOrderStatusDraft, true},
 		{PurchaseOrderStatusConfirmed, true},
+		{PurchaseOrderStatusPendingAmendment, true},
 		{PurchaseOrderStatusPartialReceived, true},
 		{PurchaseOrderStatusCompleted, true},
 		{PurchaseOrderStatusCancelled, true},
@@ -68,13 +69,19 @@ func TestPurchaseOrderStatus_CanTransitionTo(t *testing.T) {
 		{PurchaseOrderStatusConfirmed, PurchaseOrderStatusPartialReceived, true},
 		{PurchaseOrderStatusConfirmed, PurchaseOrderStatusCompleted, true},
 		{PurchaseOrderStatusConfirmed, PurchaseOrderStatusCancelled, true},
+		{PurchaseOrderStatusConfirmed, PurchaseOrderStatusPendingAmendment, true},
 		{PurchaseOrderStatusConfirmed, PurchaseOrderStatusDraft, false},
+		// From PENDING_AMENDMENT
+		{PurchaseOrderStatusPendingAmendment, PurchaseOrderStatusConfirmed, true},
+		{PurchaseOrderStatusPendingAmendment, PurchaseOrderStatusPartialReceived, false},
+		{PurchaseOrderStatusPendingAmendment, PurchaseOrderStatusCancelled, false},
 		// From PARTIAL_RECEIVED
 		{PurchaseOrderStatusPartialReceived, PurchaseOrderStatusPartialReceived, true},
 		{PurchaseOrderStatusPartialReceived, PurchaseOrderStatusCompleted, true},
 		{PurchaseOrderStatusPartialReceived, PurchaseOrderStatusCancelled, false}, // Cannot cancel after receiving
 		{PurchaseOrderStatusPartialReceived, PurchaseOrderStatusDraft, false},
 		{PurchaseOrderStatusPartialReceived, PurchaseOrderStatusConfirmed, false},
+		{PurchaseOrderStatusPartialReceived, PurchaseOrderStatusPendingAmendment, false},
 		// From COMPLETED (terminal)
 		{PurchaseOrderStatusCompleted, PurchaseOrderStatusDraft, false},
 		{PurchaseOrderStatusCompleted, PurchaseOrderStatusConfirmed, false},
@@ -101,6 +108,7 @@ func TestPurchaseOrderStatus_CanReceive(t *testing.T) {
 	}{
 		{PurchaseOrderStatusDraft, false},
 		{PurchaseOrderStatusConfirmed, true},
+		{PurchaseOrderStatusPendingAmendment, false},
 		{PurchaseOrderStatusPartialReceived, true},
 		{PurchaseOrderStatusCompleted, false},
 		{PurchaseOrderStatusCancelled, false},
@@ -765,6 +773,8 @@ func TestPurchaseOrder_Receive(t *testing.T) {
 		assert.Len(t, event.ReceivedItems, 1)
 		assert.Equal(t, "BATCH-001", event.ReceivedItems[0].BatchNumber)
 		assert.False(t, event.IsFullyReceived)
+		// Payable amount covers only the goods received in this operation
+		assert.True(t, event.PayableAmount.Equal(decimal.NewFromFloat(500)))
 	})
 
 	t.Run("fails when not in receivable status", func(t *testing.T) {
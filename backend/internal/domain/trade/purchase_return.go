@@ -231,7 +231,8 @@ type PurchaseReturn struct {
 	Items               []PurchaseReturnItem
 	TotalRefund         decimal.Decimal // Sum of all item refunds
 	Status              PurchaseReturnStatus
-	Reason              string // Overall return reason
+	ReasonCode          ReturnReasonCode // Classified return reason, used for analytics
+	Reason              string           // Optional free-text note on the reason
 	Remark              string
 	SubmittedAt         *time.Time // When submitted for approval
 	ApprovedAt          *time.Time
@@ -374,12 +375,25 @@ func (r *PurchaseReturn) RemoveItem(itemID uuid.UUID) error {
 	return shared.NewDomainError("ITEM_NOT_FOUND", "Return item not found")
 }
 
-// SetReason sets the overall return reason
+// SetReason sets the free-text note on the return reason
 func (r *PurchaseReturn) SetReason(reason string) {
 	r.Reason = reason
 	r.UpdatedAt = time.Now()
 }
 
+// SetReasonCode sets the classified return reason
+func (r *PurchaseReturn) SetReasonCode(code ReturnReasonCode) error {
+	if code == "" {
+		return shared.NewDomainError("INVALID_REASON_CODE", "Return reason code is required")
+	}
+	if !code.IsValid() {
+		return shared.NewDomainError("INVALID_REASON_CODE", fmt.Sprintf("Unknown return reason code: %s", code))
+	}
+	r.ReasonCode = code
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
 // SetRemark sets the return remark
 func (r *PurchaseReturn) SetRemark(remark string) {
 	r.Remark = remark
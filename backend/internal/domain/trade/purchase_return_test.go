@@ -576,6 +576,18 @@ func TestPurchaseReturn_Events(t *testing.T) {
 	})
 }
 
+func TestPurchaseReturn_SetReasonCode(t *testing.T) {
+	order := createTestPurchaseOrderForReturn(t)
+	pr, _ := NewPurchaseReturn(order.TenantID, "PR-001", order)
+
+	require.NoError(t, pr.SetReasonCode(ReturnReasonQuality))
+	assert.Equal(t, ReturnReasonQuality, pr.ReasonCode)
+
+	assert.Error(t, pr.SetReasonCode(""))
+	assert.Error(t, pr.SetReasonCode("SPOILED"))
+	assert.Equal(t, ReturnReasonQuality, pr.ReasonCode)
+}
+
 func TestPurchaseReturn_HelperMethods(t *testing.T) {
 	order := createTestPurchaseOrderForReturn(t)
 	pr, _ := NewPurchaseReturn(order.TenantID, "PR-001", order)
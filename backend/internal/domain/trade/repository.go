@@ -2,6 +2,7 @@ package trade
 
 import (
 	"context"
+	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
@@ -57,7 +58,7 @@ type SalesOrderRepository interface {
 	// CountByCustomer counts sales orders for a customer
 	CountByCustomer(ctx context.Context, tenantID, customerID uuid.UUID) (int64, error)
 
-	// CountIncompleteByCustomer counts incomplete (not COMPLETED or CANCELLED) orders for a customer
+	// CountIncompleteByCustomer counts incomplete (not COMPLETED, CANCELLED or EXPIRED) orders for a customer
 	// Used for validation before customer deletion
 	CountIncompleteByCustomer(ctx context.Context, tenantID, customerID uuid.UUID) (int64, error)
 
@@ -70,6 +71,9 @@ type SalesOrderRepository interface {
 	// ExistsByProduct checks if any sales order items exist for a product
 	// Used for validation before product deletion
 	ExistsByProduct(ctx context.Context, tenantID, productID uuid.UUID) (bool, error)
+
+	// FindExpiredQuotes finds quotes across all tenants whose expiry is on or before the given time
+	FindExpiredQuotes(ctx context.Context, expiresBefore time.Time) ([]SalesOrder, error)
 }
 
 // PurchaseOrderRepository defines the interface for purchase order persistence
@@ -0,0 +1,45 @@
+package trade
+
+// ReturnReasonCode classifies why goods are returned, for both sales and purchase returns.
+// The free-text Reason on a return stays available as an optional note.
+type ReturnReasonCode string
+
+const (
+	ReturnReasonDamaged         ReturnReasonCode = "DAMAGED"          // Damaged in transit or on arrival
+	ReturnReasonDefective       ReturnReasonCode = "DEFECTIVE"        // Does not work as intended
+	ReturnReasonWrongItem       ReturnReasonCode = "WRONG_ITEM"       // Different product, model or quantity than ordered
+	ReturnReasonQuality         ReturnReasonCode = "QUALITY"          // Below the agreed quality standard
+	ReturnReasonNotAsDescribed  ReturnReasonCode = "NOT_AS_DESCRIBED" // Differs from the description or sample
+	ReturnReasonExpired         ReturnReasonCode = "EXPIRED"          // Expired or too close to expiry
+	ReturnReasonCustomerRemorse ReturnReasonCode = "CUSTOMER_REMORSE" // No longer wanted
+	ReturnReasonOther           ReturnReasonCode = "OTHER"
+)
+
+// AllReturnReasonCodes returns every valid return reason code
+func AllReturnReasonCodes() []ReturnReasonCode {
+	return []ReturnReasonCode{
+		ReturnReasonDamaged,
+		ReturnReasonDefective,
+		ReturnReasonWrongItem,
+		ReturnReasonQuality,
+		ReturnReasonNotAsDescribed,
+		ReturnReasonExpired,
+		ReturnReasonCustomerRemorse,
+		ReturnReasonOther,
+	}
+}
+
+// IsValid checks if the code is a valid ReturnReasonCode
+func (c ReturnReasonCode) IsValid() bool {
+	for _, code := range AllReturnReasonCodes() {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the string representation of ReturnReasonCode
+func (c ReturnReasonCode) String() string {
+	return string(c)
+}
@@ -14,17 +14,21 @@ import (
 type OrderStatus string
 
 const (
-	OrderStatusDraft     OrderStatus = "DRAFT"
-	OrderStatusConfirmed OrderStatus = "CONFIRMED"
-	OrderStatusShipped   OrderStatus = "SHIPPED"
-	OrderStatusCompleted OrderStatus = "COMPLETED"
-	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusQuote            OrderStatus = "QUOTE"
+	OrderStatusExpired          OrderStatus = "EXPIRED"
+	OrderStatusDraft            OrderStatus = "DRAFT"
+	OrderStatusConfirmed        OrderStatus = "CONFIRMED"
+	OrderStatusPartiallyShipped OrderStatus = "PARTIALLY_SHIPPED"
+	OrderStatusShipped          OrderStatus = "SHIPPED"
+	OrderStatusCompleted        OrderStatus = "COMPLETED"
+	OrderStatusCancelled        OrderStatus = "CANCELLED"
 )
 
 // IsValid checks if the status is a valid OrderStatus
 func (s OrderStatus) IsValid() bool {
 	switch s {
-	case OrderStatusDraft, OrderStatusConfirmed, OrderStatusShipped, OrderStatusCompleted, OrderStatusCancelled:
+	case OrderStatusQuote, OrderStatusExpired, OrderStatusDraft, OrderStatusConfirmed, OrderStatusPartiallyShipped,
+		OrderStatusShipped, OrderStatusCompleted, OrderStatusCancelled:
 		return true
 	}
 	return false
@@ -38,35 +42,72 @@ func (s OrderStatus) String() string {
 // CanTransitionTo checks if the status can transition to the target status
 func (s OrderStatus) CanTransitionTo(target OrderStatus) bool {
 	switch s {
+	case OrderStatusQuote:
+		return target == OrderStatusDraft || target == OrderStatusExpired || target == OrderStatusCancelled
 	case OrderStatusDraft:
 		return target == OrderStatusConfirmed || target == OrderStatusCancelled
 	case OrderStatusConfirmed:
-		return target == OrderStatusShipped || target == OrderStatusCancelled
+		return target == OrderStatusPartiallyShipped || target == OrderStatusShipped || target == OrderStatusCancelled
+	case OrderStatusPartiallyShipped:
+		return target == OrderStatusPartiallyShipped || target == OrderStatusShipped
 	case OrderStatusShipped:
 		return target == OrderStatusCompleted
-	case OrderStatusCompleted, OrderStatusCancelled:
+	case OrderStatusCompleted, OrderStatusCancelled, OrderStatusExpired:
 		return false // Terminal states
 	}
 	return false
 }
 
+// IsEditable returns true if items and pricing can still be changed in this status
+func (s OrderStatus) IsEditable() bool {
+	return s == OrderStatusQuote || s == OrderStatusDraft
+}
+
+// CanShip returns true if shipping goods is allowed in this status
+func (s OrderStatus) CanShip() bool {
+	return s == OrderStatusConfirmed || s == OrderStatusPartiallyShipped
+}
+
+// LineDiscountType is how a discount on a sales order line is expressed
+type LineDiscountType string
+
+const (
+	LineDiscountTypeNone    LineDiscountType = ""        // No line discount
+	LineDiscountTypePercent LineDiscountType = "PERCENT" // Percentage of the line amount
+	LineDiscountTypeAmount  LineDiscountType = "AMOUNT"  // Fixed amount off the line
+)
+
+// IsValid checks if the type is a valid LineDiscountType
+func (t LineDiscountType) IsValid() bool {
+	switch t {
+	case LineDiscountTypeNone, LineDiscountTypePercent, LineDiscountTypeAmount:
+		return true
+	}
+	return false
+}
+
 // SalesOrderItem represents a line item in a sales order
 type SalesOrderItem struct {
-	ID             uuid.UUID
-	OrderID        uuid.UUID
-	ProductID      uuid.UUID
-	ProductName    string
-	ProductCode    string
-	Quantity       decimal.Decimal // Quantity in the order unit
-	UnitPrice      decimal.Decimal // Price per unit
-	Amount         decimal.Decimal // Quantity * UnitPrice
-	Unit           string          // Unit of measure (may be auxiliary unit)
-	ConversionRate decimal.Decimal // Conversion rate to base unit
-	BaseQuantity   decimal.Decimal // Quantity in base units (for inventory)
-	BaseUnit       string          // Base unit code
-	Remark         string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID              uuid.UUID
+	OrderID         uuid.UUID
+	ProductID       uuid.UUID
+	ProductName     string
+	ProductCode     string
+	Quantity        decimal.Decimal // Quantity in the order unit
+	ShippedQuantity decimal.Decimal // Quantity already shipped (in order unit)
+	UnitPrice       decimal.Decimal // Price per unit
+	ListPrice       decimal.Decimal // Price per unit before quantity breaks; zero if not recorded
+	DiscountType    LineDiscountType
+	DiscountValue   decimal.Decimal // Percentage or fixed amount, depending on DiscountType
+	DiscountAmount  decimal.Decimal // Discount taken off the line, rounded to two decimals
+	Amount          decimal.Decimal // Quantity * UnitPrice - DiscountAmount
+	Unit            string          // Unit of measure (may be auxiliary unit)
+	ConversionRate  decimal.Decimal // Conversion rate to base unit
+	BaseQuantity    decimal.Decimal // Quantity in base units (for inventory)
+	BaseUnit        string          // Base unit code
+	Remark          string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // NewSalesOrderItem creates a new sales order item
@@ -108,20 +149,25 @@ func NewSalesOrderItem(orderID, productID uuid.UUID, productName, productCode, u
 	baseQuantity := quantity.Mul(conversionRate).Round(4)
 
 	return &SalesOrderItem{
-		ID:             uuid.New(),
-		OrderID:        orderID,
-		ProductID:      productID,
-		ProductName:    productName,
-		ProductCode:    productCode,
-		Quantity:       quantity,
-		UnitPrice:      unitPrice.Amount(),
-		Amount:         amount,
-		Unit:           unit,
-		ConversionRate: conversionRate,
-		BaseQuantity:   baseQuantity,
-		BaseUnit:       baseUnit,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+		ID:              uuid.New(),
+		OrderID:         orderID,
+		ProductID:       productID,
+		ProductName:     productName,
+		ProductCode:     productCode,
+		Quantity:        quantity,
+		ShippedQuantity: decimal.Zero,
+		UnitPrice:       unitPrice.Amount(),
+		ListPrice:       unitPrice.Amount(),
+		DiscountType:    LineDiscountTypeNone,
+		DiscountValue:   decimal.Zero,
+		DiscountAmount:  decimal.Zero,
+		Amount:          amount,
+		Unit:            unit,
+		ConversionRate:  conversionRate,
+		BaseQuantity:    baseQuantity,
+		BaseUnit:        baseUnit,
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}, nil
 }
 
@@ -130,9 +176,14 @@ func (i *SalesOrderItem) UpdateQuantity(quantity decimal.Decimal) error {
 	if quantity.LessThanOrEqual(decimal.Zero) {
 		return shared.NewDomainError("INVALID_QUANTITY", "Quantity must be positive")
 	}
+	discount, err := lineDiscountAmount(quantity.Mul(i.UnitPrice), i.DiscountType, i.DiscountValue)
+	if err != nil {
+		return err
+	}
 
 	i.Quantity = quantity
-	i.Amount = quantity.Mul(i.UnitPrice)
+	i.DiscountAmount = discount
+	i.Amount = quantity.Mul(i.UnitPrice).Sub(discount)
 	i.BaseQuantity = quantity.Mul(i.ConversionRate).Round(4)
 	i.UpdatedAt = time.Now()
 
@@ -144,20 +195,123 @@ func (i *SalesOrderItem) UpdateUnitPrice(unitPrice valueobject.Money) error {
 	if unitPrice.Amount().IsNegative() {
 		return shared.NewDomainError("INVALID_PRICE", "Unit price cannot be negative")
 	}
+	discount, err := lineDiscountAmount(i.Quantity.Mul(unitPrice.Amount()), i.DiscountType, i.DiscountValue)
+	if err != nil {
+		return err
+	}
 
 	i.UnitPrice = unitPrice.Amount()
-	i.Amount = i.Quantity.Mul(i.UnitPrice)
+	i.DiscountAmount = discount
+	i.Amount = i.Quantity.Mul(i.UnitPrice).Sub(discount)
+	i.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetDiscount sets the line discount and recalculates the amount
+// A percentage must be between 0 and 100, and a discount can never exceed the line amount
+func (i *SalesOrderItem) SetDiscount(discountType LineDiscountType, value decimal.Decimal) error {
+	if !discountType.IsValid() {
+		return shared.NewDomainError("INVALID_DISCOUNT_TYPE", fmt.Sprintf("Invalid line discount type: %s", discountType))
+	}
+	if discountType == LineDiscountTypeNone {
+		value = decimal.Zero
+	}
+	if value.IsNegative() {
+		return shared.NewDomainError("INVALID_DISCOUNT", "Line discount cannot be negative")
+	}
+	if discountType == LineDiscountTypePercent && value.GreaterThan(decimal.NewFromInt(100)) {
+		return shared.NewDomainError("INVALID_DISCOUNT", "Line discount percentage cannot exceed 100")
+	}
+	discount, err := lineDiscountAmount(i.GrossAmount(), discountType, value)
+	if err != nil {
+		return err
+	}
+
+	i.DiscountType = discountType
+	i.DiscountValue = value
+	i.DiscountAmount = discount
+	i.Amount = i.GrossAmount().Sub(discount)
 	i.UpdatedAt = time.Now()
 
 	return nil
 }
 
+// lineDiscountAmount returns the discount taken off a line with the given gross amount
+func lineDiscountAmount(gross decimal.Decimal, discountType LineDiscountType, value decimal.Decimal) (decimal.Decimal, error) {
+	var discount decimal.Decimal
+	switch discountType {
+	case LineDiscountTypePercent:
+		discount = gross.Mul(value).Div(decimal.NewFromInt(100)).Round(2)
+	case LineDiscountTypeAmount:
+		discount = value.Round(2)
+	default:
+		return decimal.Zero, nil
+	}
+	if discount.GreaterThan(gross) {
+		return decimal.Zero, shared.NewDomainError("INVALID_DISCOUNT", "Line discount cannot exceed the line amount")
+	}
+	return discount, nil
+}
+
+// HasDiscount returns true if a discount is taken off the line
+func (i *SalesOrderItem) HasDiscount() bool {
+	return i.DiscountType != LineDiscountTypeNone
+}
+
+// GrossAmount returns the line amount before the line discount
+func (i *SalesOrderItem) GrossAmount() decimal.Decimal {
+	return i.Quantity.Mul(i.UnitPrice)
+}
+
+// NetUnitPrice returns the unit price after the line discount
+func (i *SalesOrderItem) NetUnitPrice() decimal.Decimal {
+	if !i.HasDiscount() || i.Quantity.IsZero() {
+		return i.UnitPrice
+	}
+	return i.Amount.Div(i.Quantity).Round(4)
+}
+
+// NetAmountFor returns the discounted amount for part of the line quantity
+func (i *SalesOrderItem) NetAmountFor(quantity decimal.Decimal) decimal.Decimal {
+	if !i.HasDiscount() {
+		return quantity.Mul(i.UnitPrice)
+	}
+	if quantity.Equal(i.Quantity) {
+		return i.Amount
+	}
+	if i.Quantity.IsZero() {
+		return decimal.Zero
+	}
+	return quantity.Mul(i.Amount).Div(i.Quantity).Round(2)
+}
+
 // SetRemark sets the remark for the item
 func (i *SalesOrderItem) SetRemark(remark string) {
 	i.Remark = remark
 	i.UpdatedAt = time.Now()
 }
 
+// SetListPrice sets the price the line is priced from before quantity breaks
+func (i *SalesOrderItem) SetListPrice(price decimal.Decimal) {
+	i.ListPrice = price
+	i.UpdatedAt = time.Now()
+}
+
+// RemainingQuantity returns the quantity still to be shipped
+func (i *SalesOrderItem) RemainingQuantity() decimal.Decimal {
+	remaining := i.Quantity.Sub(i.ShippedQuantity)
+	if remaining.IsNegative() {
+		return decimal.Zero
+	}
+	return remaining
+}
+
+// IsFullyShipped returns true if all ordered quantity has been shipped
+func (i *SalesOrderItem) IsFullyShipped() bool {
+	return i.ShippedQuantity.GreaterThanOrEqual(i.Quantity)
+}
+
 // GetAmountMoney returns the amount as Money value object
 func (i *SalesOrderItem) GetAmountMoney() valueobject.Money {
 	return valueobject.NewMoneyCNY(i.Amount)
@@ -168,25 +322,57 @@ func (i *SalesOrderItem) GetUnitPriceMoney() valueobject.Money {
 	return valueobject.NewMoneyCNY(i.UnitPrice)
 }
 
+// GetNetUnitPriceMoney returns the unit price after the line discount as Money value object
+func (i *SalesOrderItem) GetNetUnitPriceMoney() valueobject.Money {
+	return valueobject.NewMoneyCNY(i.NetUnitPrice())
+}
+
+// ShipItem represents a quantity of an order item shipped in a shipping operation
+type ShipItem struct {
+	ItemID   uuid.UUID       `json:"item_id"`
+	Quantity decimal.Decimal `json:"quantity"` // Quantity shipped in the order unit
+}
+
+// ShipmentTracking identifies the carrier and parcel of a shipment
+type ShipmentTracking struct {
+	ShippingCompany string
+	TrackingNumber  string
+}
+
 // SalesOrder represents a sales order aggregate root
 // It manages the lifecycle of a customer order from creation to completion
 type SalesOrder struct {
 	shared.TenantAggregateRoot
-	OrderNumber    string
-	CustomerID     uuid.UUID
-	CustomerName   string
-	WarehouseID    *uuid.UUID // Warehouse for shipment (set on confirm/ship)
-	Items          []SalesOrderItem
-	TotalAmount    decimal.Decimal // Sum of all items
-	DiscountAmount decimal.Decimal // Order-level discount
-	PayableAmount  decimal.Decimal // TotalAmount - DiscountAmount
-	Status         OrderStatus
-	Remark         string
-	ConfirmedAt    *time.Time
-	ShippedAt      *time.Time
-	CompletedAt    *time.Time
-	CancelledAt    *time.Time
-	CancelReason   string
+	OrderNumber  string
+	CustomerID   uuid.UUID
+	CustomerName string
+	WarehouseID  *uuid.UUID // Warehouse for shipment (set on confirm/ship)
+	// ShippingAddressID is the customer's shipping address the goods are delivered to, nil for the customer's default
+	ShippingAddressID *uuid.UUID
+	Items             []SalesOrderItem
+	TotalAmount       decimal.Decimal // Sum of all items
+	DiscountAmount    decimal.Decimal // Order-level discount
+	PayableAmount     decimal.Decimal // TotalAmount - DiscountAmount
+	Status            OrderStatus
+	Remark            string
+	ConfirmedAt       *time.Time
+	FirstShippedAt    *time.Time // When the first shipment left the warehouse
+	ShippedAt         *time.Time // When the most recent shipment left the warehouse
+	FullyShippedAt    *time.Time // When the last outstanding quantity shipped and the order became SHIPPED
+	ShipmentCount     int        // Number of shipments made for the order
+	CompletedAt       *time.Time
+	CancelledAt       *time.Time
+	CancelReason      string
+	// Credit override allows the order to ship even if it exceeds the customer's credit limit
+	CreditOverrideApprovedBy *uuid.UUID
+	CreditOverrideApprovedAt *time.Time
+	CreditOverrideReason     string
+	// AllowExpiredStock lets the order ship stock from batches that are expired or close to expiry
+	AllowExpiredStock bool
+	// Quotes are converted into a draft order before they expire, and never lock stock
+	QuoteExpiresAt *time.Time
+	ConvertedAt    *time.Time // When the quote was converted into an order
+	ExpiredAt      *time.Time
 }
 
 // NewSalesOrder creates a new sales order
@@ -221,8 +407,25 @@ func NewSalesOrder(tenantID uuid.UUID, orderNumber string, customerID uuid.UUID,
 	return order, nil
 }
 
+// NewSalesQuote creates a new sales quote valid until expiresAt
+// A quote can be edited freely and does not affect inventory until it is converted into an order
+func NewSalesQuote(tenantID uuid.UUID, orderNumber string, customerID uuid.UUID, customerName string, expiresAt time.Time) (*SalesOrder, error) {
+	if !expiresAt.After(time.Now()) {
+		return nil, shared.NewDomainError("INVALID_EXPIRY", "Quote expiry must be in the future")
+	}
+
+	order, err := NewSalesOrder(tenantID, orderNumber, customerID, customerName)
+	if err != nil {
+		return nil, err
+	}
+	order.Status = OrderStatusQuote
+	order.QuoteExpiresAt = &expiresAt
+
+	return order, nil
+}
+
 // AddItem adds a new item to the order
-// Only allowed in DRAFT status
+// Only allowed in QUOTE or DRAFT status
 // Parameters:
 //   - productID: the product ID
 //   - productName, productCode: product display info
@@ -232,7 +435,7 @@ func NewSalesOrder(tenantID uuid.UUID, orderNumber string, customerID uuid.UUID,
 //   - conversionRate: conversion rate from order unit to base unit (1 if using base unit)
 //   - unitPrice: price per order unit
 func (o *SalesOrder) AddItem(productID uuid.UUID, productName, productCode, unit, baseUnit string, quantity, conversionRate decimal.Decimal, unitPrice valueobject.Money) (*SalesOrderItem, error) {
-	if o.Status != OrderStatusDraft {
+	if !o.Status.IsEditable() {
 		return nil, shared.NewDomainError("INVALID_STATE", "Cannot add items to a non-draft order")
 	}
 
@@ -256,9 +459,9 @@ func (o *SalesOrder) AddItem(productID uuid.UUID, productName, productCode, unit
 }
 
 // UpdateItemQuantity updates the quantity of an existing item
-// Only allowed in DRAFT status
+// Only allowed in QUOTE or DRAFT status
 func (o *SalesOrder) UpdateItemQuantity(itemID uuid.UUID, quantity decimal.Decimal) error {
-	if o.Status != OrderStatusDraft {
+	if !o.Status.IsEditable() {
 		return shared.NewDomainError("INVALID_STATE", "Cannot update items in a non-draft order")
 	}
 
@@ -277,9 +480,9 @@ func (o *SalesOrder) UpdateItemQuantity(itemID uuid.UUID, quantity decimal.Decim
 }
 
 // UpdateItemPrice updates the unit price of an existing item
-// Only allowed in DRAFT status
+// Only allowed in QUOTE or DRAFT status
 func (o *SalesOrder) UpdateItemPrice(itemID uuid.UUID, unitPrice valueobject.Money) error {
-	if o.Status != OrderStatusDraft {
+	if !o.Status.IsEditable() {
 		return shared.NewDomainError("INVALID_STATE", "Cannot update items in a non-draft order")
 	}
 
@@ -297,10 +500,30 @@ func (o *SalesOrder) UpdateItemPrice(itemID uuid.UUID, unitPrice valueobject.Mon
 	return shared.NewDomainError("ITEM_NOT_FOUND", "Order item not found")
 }
 
+// SetItemDiscount sets the discount on an existing item
+// Only allowed in QUOTE or DRAFT status. Line discounts are applied before the order discount.
+func (o *SalesOrder) SetItemDiscount(itemID uuid.UUID, discountType LineDiscountType, value decimal.Decimal) error {
+	if !o.Status.IsEditable() {
+		return shared.NewDomainError("INVALID_STATE", "Cannot update items in a non-draft order")
+	}
+
+	item := o.GetItem(itemID)
+	if item == nil {
+		return shared.NewDomainError("ITEM_NOT_FOUND", "Order item not found")
+	}
+	if err := item.SetDiscount(discountType, value); err != nil {
+		return err
+	}
+	o.recalculateTotals()
+	o.UpdatedAt = time.Now()
+
+	return nil
+}
+
 // RemoveItem removes an item from the order
-// Only allowed in DRAFT status
+// Only allowed in QUOTE or DRAFT status
 func (o *SalesOrder) RemoveItem(itemID uuid.UUID) error {
-	if o.Status != OrderStatusDraft {
+	if !o.Status.IsEditable() {
 		return shared.NewDomainError("INVALID_STATE", "Cannot remove items from a non-draft order")
 	}
 
@@ -317,9 +540,9 @@ func (o *SalesOrder) RemoveItem(itemID uuid.UUID) error {
 }
 
 // ApplyDiscount applies a discount to the order
-// Only allowed in DRAFT status
+// Only allowed in QUOTE or DRAFT status
 func (o *SalesOrder) ApplyDiscount(discount valueobject.Money) error {
-	if o.Status != OrderStatusDraft {
+	if !o.Status.IsEditable() {
 		return shared.NewDomainError("INVALID_STATE", "Cannot apply discount to a non-draft order")
 	}
 	if discount.Amount().IsNegative() {
@@ -343,9 +566,9 @@ func (o *SalesOrder) SetRemark(remark string) {
 }
 
 // SetWarehouse sets the warehouse for the order
-// Only allowed in DRAFT or CONFIRMED status
+// Only allowed in QUOTE, DRAFT or CONFIRMED status
 func (o *SalesOrder) SetWarehouse(warehouseID uuid.UUID) error {
-	if o.Status != OrderStatusDraft && o.Status != OrderStatusConfirmed {
+	if !o.Status.IsEditable() && o.Status != OrderStatusConfirmed {
 		return shared.NewDomainError("INVALID_STATE", "Cannot set warehouse for order in current status")
 	}
 	if warehouseID == uuid.Nil {
@@ -358,6 +581,105 @@ func (o *SalesOrder) SetWarehouse(warehouseID uuid.UUID) error {
 	return nil
 }
 
+// SetShippingAddress sets the customer's shipping address the order is delivered to
+// The address must belong to the order's customer, which the caller verifies.
+// Only allowed in QUOTE, DRAFT or CONFIRMED status; nil falls back to the customer's default shipping address
+func (o *SalesOrder) SetShippingAddress(addressID *uuid.UUID) error {
+	if !o.Status.IsEditable() && o.Status != OrderStatusConfirmed {
+		return shared.NewDomainError("INVALID_STATE", "Cannot set shipping address for order in current status")
+	}
+	if addressID != nil && *addressID == uuid.Nil {
+		return shared.NewDomainError("INVALID_SHIPPING_ADDRESS", "Shipping address ID cannot be empty")
+	}
+
+	o.ShippingAddressID = addressID
+	o.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ApproveCreditOverride flags the order as approved to ship beyond the customer's credit limit
+// Only allowed in DRAFT or CONFIRMED status
+func (o *SalesOrder) ApproveCreditOverride(approvedBy uuid.UUID, reason string) error {
+	if o.Status != OrderStatusDraft && o.Status != OrderStatusConfirmed {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot approve credit override for order in %s status", o.Status))
+	}
+	if approvedBy == uuid.Nil {
+		return shared.NewDomainError("INVALID_APPROVER", "Approver ID cannot be empty")
+	}
+	if reason == "" {
+		return shared.NewDomainError("INVALID_REASON", "Credit override reason is required")
+	}
+
+	now := time.Now()
+	o.CreditOverrideApprovedBy = &approvedBy
+	o.CreditOverrideApprovedAt = &now
+	o.CreditOverrideReason = reason
+	o.UpdatedAt = now
+
+	return nil
+}
+
+// HasCreditOverride returns true if the order is approved to ship beyond the customer's credit limit
+func (o *SalesOrder) HasCreditOverride() bool {
+	return o.CreditOverrideApprovedBy != nil
+}
+
+// SetAllowExpiredStock sets whether the order may ship stock from expired batches
+// Only allowed before the order is fully shipped
+func (o *SalesOrder) SetAllowExpiredStock(allow bool) error {
+	if !o.Status.IsEditable() && !o.Status.CanShip() {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot change expired stock setting for order in %s status", o.Status))
+	}
+
+	o.AllowExpiredStock = allow
+	o.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ConvertQuote converts an accepted quote into a draft order, which can then be confirmed
+// Quotes past their expiry cannot be converted
+func (o *SalesOrder) ConvertQuote() error {
+	if !o.Status.CanTransitionTo(OrderStatusDraft) {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot convert order in %s status", o.Status))
+	}
+	now := time.Now()
+	if o.IsQuoteExpired(now) {
+		return shared.NewDomainError("QUOTE_EXPIRED", "Cannot convert an expired quote")
+	}
+	if len(o.Items) == 0 {
+		return shared.NewDomainError("NO_ITEMS", "Cannot convert quote without items")
+	}
+
+	o.Status = OrderStatusDraft
+	o.ConvertedAt = &now
+	o.UpdatedAt = now
+
+	return nil
+}
+
+// Expire marks a quote whose validity has run out as EXPIRED
+func (o *SalesOrder) Expire(now time.Time) error {
+	if !o.Status.CanTransitionTo(OrderStatusExpired) {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot expire order in %s status", o.Status))
+	}
+	if !o.IsQuoteExpired(now) {
+		return shared.NewDomainError("QUOTE_NOT_EXPIRED", "Quote has not reached its expiry")
+	}
+
+	o.Status = OrderStatusExpired
+	o.ExpiredAt = &now
+	o.UpdatedAt = now
+
+	return nil
+}
+
+// IsQuoteExpired returns true if the order has a quote expiry that is not after now
+func (o *SalesOrder) IsQuoteExpired(now time.Time) bool {
+	return o.QuoteExpiresAt != nil && !o.QuoteExpiresAt.After(now)
+}
+
 // Confirm confirms the order, transitioning from DRAFT to CONFIRMED
 // Requires at least one item in the order
 func (o *SalesOrder) Confirm() error {
@@ -381,26 +703,163 @@ func (o *SalesOrder) Confirm() error {
 	return nil
 }
 
-// Ship marks the order as shipped
+// Ship ships everything not yet shipped, marking the order as shipped
 // Requires warehouse to be set and stock to be locked (handled by application service)
 func (o *SalesOrder) Ship() error {
-	if !o.Status.CanTransitionTo(OrderStatusShipped) {
+	if !o.Status.CanShip() {
+		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot ship order in %s status", o.Status))
+	}
+	return o.ShipItems(o.RemainingShipItems())
+}
+
+// ShipItems ships part or all of the remaining order quantities
+// Only allowed in CONFIRMED or PARTIALLY_SHIPPED status. The order becomes SHIPPED once
+// every item is fully shipped, and PARTIALLY_SHIPPED otherwise.
+// The shipped event carries only the quantities of this shipment.
+func (o *SalesOrder) ShipItems(shipItems []ShipItem) error {
+	return o.ShipItemsWithTracking(shipItems, ShipmentTracking{})
+}
+
+// ShipItemsWithTracking ships items like ShipItems and records the shipment's
+// carrier and tracking number on the shipped event
+func (o *SalesOrder) ShipItemsWithTracking(shipItems []ShipItem, tracking ShipmentTracking) error {
+	if !o.Status.CanShip() {
 		return shared.NewDomainError("INVALID_STATE", fmt.Sprintf("Cannot ship order in %s status", o.Status))
 	}
 	if o.WarehouseID == nil {
 		return shared.NewDomainError("NO_WAREHOUSE", "Warehouse must be set before shipping")
 	}
 
+	quantities, err := o.shipmentQuantities(shipItems)
+	if err != nil {
+		return err
+	}
+	payableAmount := o.shippedPayableAmount(quantities).Sub(o.shippedPayableAmount(nil))
+
 	now := time.Now()
-	o.Status = OrderStatusShipped
+	shippedInfos := make([]SalesOrderItemInfo, 0, len(quantities))
+	for idx := range o.Items {
+		item := &o.Items[idx]
+		quantity, ok := quantities[item.ID]
+		if !ok {
+			continue
+		}
+		item.ShippedQuantity = item.ShippedQuantity.Add(quantity)
+		item.UpdatedAt = now
+
+		shippedInfos = append(shippedInfos, SalesOrderItemInfo{
+			ItemID:      item.ID,
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			ProductCode: item.ProductCode,
+			Quantity:    quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.NetAmountFor(quantity),
+			Unit:        item.Unit,
+		})
+	}
+
+	if o.isAllItemsShipped() {
+		o.Status = OrderStatusShipped
+		o.FullyShippedAt = &now
+	} else {
+		o.Status = OrderStatusPartiallyShipped
+	}
+	if o.FirstShippedAt == nil {
+		o.FirstShippedAt = &now
+	}
+	o.ShipmentCount++
 	o.ShippedAt = &now
 	o.UpdatedAt = now
 
-	o.AddDomainEvent(NewSalesOrderShippedEvent(o))
+	event := NewSalesOrderShipmentEvent(o, shippedInfos, payableAmount)
+	event.ShippingCompany = tracking.ShippingCompany
+	event.TrackingNumber = tracking.TrackingNumber
+	o.AddDomainEvent(event)
 
 	return nil
 }
 
+// ShipmentPayableAmount returns the amount the customer owes for a shipment of the given items
+func (o *SalesOrder) ShipmentPayableAmount(shipItems []ShipItem) (decimal.Decimal, error) {
+	quantities, err := o.shipmentQuantities(shipItems)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return o.shippedPayableAmount(quantities).Sub(o.shippedPayableAmount(nil)), nil
+}
+
+// RemainingShipItems returns the quantities still to be shipped for every item
+func (o *SalesOrder) RemainingShipItems() []ShipItem {
+	shipItems := make([]ShipItem, 0, len(o.Items))
+	for _, item := range o.Items {
+		if remaining := item.RemainingQuantity(); remaining.IsPositive() {
+			shipItems = append(shipItems, ShipItem{ItemID: item.ID, Quantity: remaining})
+		}
+	}
+	return shipItems
+}
+
+// shipmentQuantities validates a shipment and returns the quantity shipped per item ID
+func (o *SalesOrder) shipmentQuantities(shipItems []ShipItem) (map[uuid.UUID]decimal.Decimal, error) {
+	if len(shipItems) == 0 {
+		return nil, shared.NewDomainError("NO_ITEMS", "Ship items cannot be empty")
+	}
+
+	quantities := make(map[uuid.UUID]decimal.Decimal, len(shipItems))
+	for _, si := range shipItems {
+		if si.Quantity.LessThanOrEqual(decimal.Zero) {
+			return nil, shared.NewDomainError("INVALID_QUANTITY", fmt.Sprintf("Ship quantity for item %s must be positive", si.ItemID))
+		}
+		item := o.GetItem(si.ItemID)
+		if item == nil {
+			return nil, shared.NewDomainError("ITEM_NOT_FOUND", fmt.Sprintf("Item %s not found in order", si.ItemID))
+		}
+		if _, ok := quantities[si.ItemID]; ok {
+			return nil, shared.NewDomainError("DUPLICATE_ITEM", fmt.Sprintf("Item %s is listed more than once", si.ItemID))
+		}
+		if si.Quantity.GreaterThan(item.RemainingQuantity()) {
+			return nil, shared.NewDomainError("QUANTITY_EXCEEDED", fmt.Sprintf("Cannot ship %s of %s, only %s remaining", si.Quantity.String(), item.ProductName, item.RemainingQuantity().String()))
+		}
+		quantities[si.ItemID] = si.Quantity
+	}
+	return quantities, nil
+}
+
+// shippedPayableAmount returns the payable amount for everything shipped so far plus the
+// given pending quantities. The order-level discount is spread over shipments in proportion
+// to their value after line discounts, and a fully shipped order reaches the exact payable amount, so the payable
+// amounts of all shipments add up to the order's payable amount.
+func (o *SalesOrder) shippedPayableAmount(pending map[uuid.UUID]decimal.Decimal) decimal.Decimal {
+	shippedValue := decimal.Zero
+	fullyShipped := true
+	for _, item := range o.Items {
+		shipped := item.ShippedQuantity.Add(pending[item.ID])
+		if shipped.LessThan(item.Quantity) {
+			fullyShipped = false
+		}
+		shippedValue = shippedValue.Add(item.NetAmountFor(shipped))
+	}
+
+	if fullyShipped {
+		return o.PayableAmount
+	}
+	if shippedValue.IsZero() || o.TotalAmount.IsZero() {
+		return decimal.Zero
+	}
+	return shippedValue.Mul(o.PayableAmount).Div(o.TotalAmount).Round(2)
+}
+
+// isAllItemsShipped returns true if every item is fully shipped
+func (o *SalesOrder) isAllItemsShipped() bool {
+	for _, item := range o.Items {
+		if !item.IsFullyShipped() {
+			return false
+		}
+	}
+	return true
+}
+
 // Complete marks the order as completed (delivered/received)
 func (o *SalesOrder) Complete() error {
 	if !o.Status.CanTransitionTo(OrderStatusCompleted) {
@@ -418,7 +877,7 @@ func (o *SalesOrder) Complete() error {
 }
 
 // Cancel cancels the order
-// Allowed only in DRAFT or CONFIRMED status
+// Allowed only in QUOTE, DRAFT or CONFIRMED status
 // If CONFIRMED, stock locks should be released (handled by application service)
 func (o *SalesOrder) Cancel(reason string) error {
 	if !o.Status.CanTransitionTo(OrderStatusCancelled) {
@@ -441,6 +900,7 @@ func (o *SalesOrder) Cancel(reason string) error {
 }
 
 // recalculateTotals recalculates the order totals
+// Line amounts already have line discounts taken off; the order discount is applied to their sum
 func (o *SalesOrder) recalculateTotals() {
 	total := decimal.Zero
 	for _, item := range o.Items {
@@ -471,6 +931,15 @@ func (o *SalesOrder) GetPayableAmountMoney() valueobject.Money {
 	return valueobject.NewMoneyCNY(o.PayableAmount)
 }
 
+// LineDiscountAmount returns the sum of all line discounts
+func (o *SalesOrder) LineDiscountAmount() decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range o.Items {
+		total = total.Add(item.DiscountAmount)
+	}
+	return total
+}
+
 // ItemCount returns the number of items in the order
 func (o *SalesOrder) ItemCount() int {
 	return len(o.Items)
@@ -485,6 +954,16 @@ func (o *SalesOrder) TotalQuantity() decimal.Decimal {
 	return total
 }
 
+// IsQuote returns true if order is a quote not yet converted into an order
+func (o *SalesOrder) IsQuote() bool {
+	return o.Status == OrderStatusQuote
+}
+
+// IsExpired returns true if the quote expired without being converted
+func (o *SalesOrder) IsExpired() bool {
+	return o.Status == OrderStatusExpired
+}
+
 // IsDraft returns true if order is in draft status
 func (o *SalesOrder) IsDraft() bool {
 	return o.Status == OrderStatusDraft
@@ -495,6 +974,11 @@ func (o *SalesOrder) IsConfirmed() bool {
 	return o.Status == OrderStatusConfirmed
 }
 
+// IsPartiallyShipped returns true if part of the order has been shipped
+func (o *SalesOrder) IsPartiallyShipped() bool {
+	return o.Status == OrderStatusPartiallyShipped
+}
+
 // IsShipped returns true if order is shipped
 func (o *SalesOrder) IsShipped() bool {
 	return o.Status == OrderStatusShipped
@@ -510,14 +994,14 @@ func (o *SalesOrder) IsCancelled() bool {
 	return o.Status == OrderStatusCancelled
 }
 
-// IsTerminal returns true if order is in a terminal state (completed or cancelled)
+// IsTerminal returns true if order is in a terminal state (completed, cancelled or expired)
 func (o *SalesOrder) IsTerminal() bool {
-	return o.IsCompleted() || o.IsCancelled()
+	return o.IsCompleted() || o.IsCancelled() || o.IsExpired()
 }
 
 // CanModify returns true if the order can be modified (items, discount, etc.)
 func (o *SalesOrder) CanModify() bool {
-	return o.IsDraft()
+	return o.Status.IsEditable()
 }
 
 // GetItem returns an item by its ID
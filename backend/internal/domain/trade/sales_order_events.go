@@ -103,21 +103,29 @@ func (e *SalesOrderConfirmedEvent) EventType() string {
 	return EventTypeSalesOrderConfirmed
 }
 
-// SalesOrderShippedEvent is raised when a sales order is shipped
-// This event triggers stock deduction and accounts receivable creation
+// SalesOrderShippedEvent is raised when goods of a sales order are shipped
+// This event triggers stock deduction and accounts receivable creation.
+// For a partial shipment, Items and the amounts cover only the quantities shipped.
 type SalesOrderShippedEvent struct {
 	shared.BaseDomainEvent
-	OrderID       uuid.UUID            `json:"order_id"`
-	OrderNumber   string               `json:"order_number"`
-	CustomerID    uuid.UUID            `json:"customer_id"`
-	CustomerName  string               `json:"customer_name"`
-	WarehouseID   uuid.UUID            `json:"warehouse_id"`
-	Items         []SalesOrderItemInfo `json:"items"`
-	TotalAmount   decimal.Decimal      `json:"total_amount"`
-	PayableAmount decimal.Decimal      `json:"payable_amount"`
+	OrderID        uuid.UUID            `json:"order_id"`
+	OrderNumber    string               `json:"order_number"`
+	CustomerID     uuid.UUID            `json:"customer_id"`
+	CustomerName   string               `json:"customer_name"`
+	WarehouseID    uuid.UUID            `json:"warehouse_id"`
+	Items          []SalesOrderItemInfo `json:"items"`
+	TotalAmount    decimal.Decimal      `json:"total_amount"`     // Value of the goods shipped
+	PayableAmount  decimal.Decimal      `json:"payable_amount"`   // Amount receivable for the goods shipped
+	ShipmentNumber int                  `json:"shipment_number"`  // 1 for the first shipment of the order
+	IsFullyShipped bool                 `json:"is_fully_shipped"` // True if this completes shipping the order
+	// ShippingCompany and TrackingNumber identify the shipment's parcel, when known
+	ShippingCompany string `json:"shipping_company,omitempty"`
+	TrackingNumber  string `json:"tracking_number,omitempty"`
+	// AllowExpiredStock lets the stock deduction take stock from expired batches
+	AllowExpiredStock bool `json:"allow_expired_stock"`
 }
 
-// NewSalesOrderShippedEvent creates a new SalesOrderShippedEvent
+// NewSalesOrderShippedEvent creates a SalesOrderShippedEvent covering the entire order
 func NewSalesOrderShippedEvent(order *SalesOrder) *SalesOrderShippedEvent {
 	items := make([]SalesOrderItemInfo, len(order.Items))
 	for i, item := range order.Items {
@@ -133,11 +141,25 @@ func NewSalesOrderShippedEvent(order *SalesOrder) *SalesOrderShippedEvent {
 		}
 	}
 
+	event := NewSalesOrderShipmentEvent(order, items, order.PayableAmount)
+	event.TotalAmount = order.TotalAmount
+	event.IsFullyShipped = true
+	return event
+}
+
+// NewSalesOrderShipmentEvent creates a SalesOrderShippedEvent for a single shipment
+// of the given items and the amount receivable for them
+func NewSalesOrderShipmentEvent(order *SalesOrder, shippedItems []SalesOrderItemInfo, payableAmount decimal.Decimal) *SalesOrderShippedEvent {
 	warehouseID := uuid.Nil
 	if order.WarehouseID != nil {
 		warehouseID = *order.WarehouseID
 	}
 
+	totalAmount := decimal.Zero
+	for _, item := range shippedItems {
+		totalAmount = totalAmount.Add(item.Amount)
+	}
+
 	return &SalesOrderShippedEvent{
 		BaseDomainEvent: shared.NewBaseDomainEvent(EventTypeSalesOrderShipped, AggregateTypeSalesOrder, order.ID, order.TenantID),
 		OrderID:         order.ID,
@@ -145,9 +167,13 @@ func NewSalesOrderShippedEvent(order *SalesOrder) *SalesOrderShippedEvent {
 		CustomerID:      order.CustomerID,
 		CustomerName:    order.CustomerName,
 		WarehouseID:     warehouseID,
-		Items:           items,
-		TotalAmount:     order.TotalAmount,
-		PayableAmount:   order.PayableAmount,
+		Items:           shippedItems,
+		TotalAmount:     totalAmount,
+		PayableAmount:   payableAmount,
+		ShipmentNumber:  order.ShipmentCount,
+		IsFullyShipped:  order.IsShipped(),
+
+		AllowExpiredStock: order.AllowExpiredStock,
 	}
 }
 
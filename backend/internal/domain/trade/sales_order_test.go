@@ -2,6 +2,7 @@ package trade
 
 import (
 	"testing"
+	"time"
 
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
@@ -36,6 +37,8 @@ func TestOrderStatus_IsValid(t *testing.T) {
 		status  OrderStatus
 		isValid bool
 	}{
+		{OrderStatusQuote, true},
+		{OrderStatusExpired, true},
 		{OrderStatusDraft, true},
 		{OrderStatusConfirmed, true},
 		{OrderStatusShipped, true},
@@ -58,8 +61,15 @@ func TestOrderStatus_CanTransitionTo(t *testing.T) {
 		to       OrderStatus
 		canTrans bool
 	}{
+		// From QUOTE
+		{OrderStatusQuote, OrderStatusDraft, true},
+		{OrderStatusQuote, OrderStatusExpired, true},
+		{OrderStatusQuote, OrderStatusCancelled, true},
+		{OrderStatusQuote, OrderStatusConfirmed, false},
+		{OrderStatusQuote, OrderStatusShipped, false},
 		// From DRAFT
 		{OrderStatusDraft, OrderStatusConfirmed, true},
+		{OrderStatusDraft, OrderStatusQuote, false},
 		{OrderStatusDraft, OrderStatusCancelled, true},
 		{OrderStatusDraft, OrderStatusShipped, false},
 		{OrderStatusDraft, OrderStatusCompleted, false},
@@ -83,6 +93,10 @@ func TestOrderStatus_CanTransitionTo(t *testing.T) {
 		{OrderStatusCancelled, OrderStatusConfirmed, false},
 		{OrderStatusCancelled, OrderStatusShipped, false},
 		{OrderStatusCancelled, OrderStatusCompleted, false},
+		// From EXPIRED (terminal)
+		{OrderStatusExpired, OrderStatusQuote, false},
+		{OrderStatusExpired, OrderStatusDraft, false},
+		{OrderStatusExpired, OrderStatusCancelled, false},
 	}
 
 	for _, tt := range tests {
@@ -492,6 +506,130 @@ func TestSalesOrder_ApplyDiscount(t *testing.T) {
 	})
 }
 
+// ============================================
+// Line Discount Tests
+// ============================================
+
+func TestSalesOrder_SetItemDiscount(t *testing.T) {
+	t.Run("percent discount rounds to two decimals", func(t *testing.T) {
+		order := createTestOrder(t)
+		item := addTestItem(t, order, "Product 1", 3, 33.33)
+
+		err := order.SetItemDiscount(item.ID, LineDiscountTypePercent, decimal.NewFromInt(10))
+		require.NoError(t, err)
+
+		item = order.GetItem(item.ID)
+		assert.True(t, decimal.NewFromFloat(99.99).Equal(item.GrossAmount()))
+		assert.True(t, decimal.NewFromInt(10).Equal(item.DiscountAmount), "got %s", item.DiscountAmount)
+		assert.True(t, decimal.NewFromFloat(89.99).Equal(item.Amount), "got %s", item.Amount)
+		assert.True(t, decimal.NewFromFloat(89.99).Equal(order.TotalAmount))
+	})
+
+	t.Run("amount discount follows quantity changes", func(t *testing.T) {
+		order := createTestOrder(t)
+		item := addTestItem(t, order, "Product 1", 2, 50.00)
+
+		require.NoError(t, order.SetItemDiscount(item.ID, LineDiscountTypeAmount, decimal.NewFromInt(15)))
+		assert.True(t, decimal.NewFromInt(85).Equal(order.TotalAmount))
+
+		require.NoError(t, order.UpdateItemQuantity(item.ID, decimal.NewFromInt(4)))
+		assert.True(t, decimal.NewFromInt(185).Equal(order.TotalAmount), "got %s", order.TotalAmount)
+	})
+
+	t.Run("line cannot go negative", func(t *testing.T) {
+		order := createTestOrder(t)
+		item := addTestItem(t, order, "Product 1", 2, 10.00)
+
+		assert.Error(t, order.SetItemDiscount(item.ID, LineDiscountTypeAmount, decimal.NewFromInt(21)))
+		assert.Error(t, order.SetItemDiscount(item.ID, LineDiscountTypePercent, decimal.NewFromInt(101)))
+		assert.Error(t, order.SetItemDiscount(item.ID, LineDiscountTypePercent, decimal.NewFromInt(-1)))
+
+		require.NoError(t, order.SetItemDiscount(item.ID, LineDiscountTypeAmount, decimal.NewFromInt(15)))
+		err := order.UpdateItemQuantity(item.ID, decimal.NewFromInt(1))
+		assert.Error(t, err)
+		assert.True(t, decimal.NewFromInt(2).Equal(order.GetItem(item.ID).Quantity))
+		assert.True(t, decimal.NewFromInt(5).Equal(order.TotalAmount))
+	})
+
+	t.Run("rejects unknown discount type", func(t *testing.T) {
+		order := createTestOrder(t)
+		item := addTestItem(t, order, "Product 1", 1, 10.00)
+
+		assert.Error(t, order.SetItemDiscount(item.ID, LineDiscountType("BOGUS"), decimal.NewFromInt(1)))
+	})
+
+	t.Run("removing the discount restores the gross amount", func(t *testing.T) {
+		order := createTestOrder(t)
+		item := addTestItem(t, order, "Product 1", 2, 10.00)
+		require.NoError(t, order.SetItemDiscount(item.ID, LineDiscountTypePercent, decimal.NewFromInt(50)))
+
+		require.NoError(t, order.SetItemDiscount(item.ID, LineDiscountTypeNone, decimal.Zero))
+		assert.False(t, order.GetItem(item.ID).HasDiscount())
+		assert.True(t, decimal.NewFromInt(20).Equal(order.TotalAmount))
+	})
+
+	t.Run("fails in confirmed order", func(t *testing.T) {
+		order := createTestOrder(t)
+		item := addTestItem(t, order, "Product 1", 1, 10.00)
+		require.NoError(t, order.SetWarehouse(uuid.New()))
+		require.NoError(t, order.Confirm())
+
+		assert.Error(t, order.SetItemDiscount(item.ID, LineDiscountTypePercent, decimal.NewFromInt(10)))
+	})
+}
+
+func TestSalesOrder_LineAndOrderDiscount(t *testing.T) {
+	// discountedOrder has 3 x 33.33 at 10% off (89.99) and 2 x 50 with 15 off (85),
+	// then a 20 order discount: total 174.99, payable 154.99
+	discountedOrder := func(t *testing.T) (*SalesOrder, *SalesOrderItem, *SalesOrderItem) {
+		order := createTestOrder(t)
+		first := addTestItem(t, order, "Product 1", 3, 33.33)
+		second := addTestItem(t, order, "Product 2", 2, 50.00)
+		require.NoError(t, order.SetItemDiscount(first.ID, LineDiscountTypePercent, decimal.NewFromInt(10)))
+		require.NoError(t, order.SetItemDiscount(second.ID, LineDiscountTypeAmount, decimal.NewFromInt(15)))
+		require.NoError(t, order.ApplyDiscount(valueobject.NewMoneyCNYFromFloat(20)))
+		return order, first, second
+	}
+
+	t.Run("order discount applies after line discounts", func(t *testing.T) {
+		order, _, _ := discountedOrder(t)
+
+		assert.True(t, decimal.NewFromFloat(174.99).Equal(order.TotalAmount), "got %s", order.TotalAmount)
+		assert.True(t, decimal.NewFromInt(25).Equal(order.LineDiscountAmount()))
+		assert.True(t, decimal.NewFromFloat(154.99).Equal(order.PayableAmount), "got %s", order.PayableAmount)
+	})
+
+	t.Run("order discount cannot exceed the discounted total", func(t *testing.T) {
+		order, _, _ := discountedOrder(t)
+
+		assert.Error(t, order.ApplyDiscount(valueobject.NewMoneyCNYFromFloat(175)))
+	})
+
+	t.Run("shipped events carry discounted amounts", func(t *testing.T) {
+		order, first, second := discountedOrder(t)
+		require.NoError(t, order.SetWarehouse(uuid.New()))
+		require.NoError(t, order.Confirm())
+		order.ClearDomainEvents()
+
+		require.NoError(t, order.ShipItems([]ShipItem{{ItemID: second.ID, Quantity: decimal.NewFromInt(2)}}))
+		events := order.GetDomainEvents()
+		require.Len(t, events, 1)
+		firstShipment := events[0].(*SalesOrderShippedEvent)
+		require.Len(t, firstShipment.Items, 1)
+		assert.True(t, decimal.NewFromInt(85).Equal(firstShipment.Items[0].Amount), "got %s", firstShipment.Items[0].Amount)
+		assert.True(t, decimal.NewFromFloat(75.29).Equal(firstShipment.PayableAmount), "got %s", firstShipment.PayableAmount)
+		order.ClearDomainEvents()
+
+		require.NoError(t, order.ShipItems([]ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(3)}}))
+		events = order.GetDomainEvents()
+		require.Len(t, events, 1)
+		secondShipment := events[0].(*SalesOrderShippedEvent)
+		assert.True(t, secondShipment.IsFullyShipped)
+		assert.True(t, decimal.NewFromFloat(79.70).Equal(secondShipment.PayableAmount), "got %s", secondShipment.PayableAmount)
+		assert.True(t, order.PayableAmount.Equal(firstShipment.PayableAmount.Add(secondShipment.PayableAmount)))
+	})
+}
+
 // ============================================
 // Warehouse Tests
 // ============================================
@@ -544,6 +682,41 @@ func TestSalesOrder_SetWarehouse(t *testing.T) {
 	})
 }
 
+func TestSalesOrder_SetShippingAddress(t *testing.T) {
+	t.Run("sets and clears the shipping address", func(t *testing.T) {
+		order := createTestOrder(t)
+		addressID := uuid.New()
+
+		require.NoError(t, order.SetShippingAddress(&addressID))
+		assert.Equal(t, addressID, *order.ShippingAddressID)
+
+		require.NoError(t, order.SetShippingAddress(nil))
+		assert.Nil(t, order.ShippingAddressID)
+	})
+
+	t.Run("fails with nil address ID", func(t *testing.T) {
+		order := createTestOrder(t)
+		empty := uuid.Nil
+
+		err := order.SetShippingAddress(&empty)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Shipping address ID cannot be empty")
+	})
+
+	t.Run("fails when order is shipped", func(t *testing.T) {
+		order := createTestOrder(t)
+		addTestItem(t, order, "Product 1", 10, 100.00)
+		require.NoError(t, order.SetWarehouse(uuid.New()))
+		require.NoError(t, order.Confirm())
+		require.NoError(t, order.Ship())
+
+		addressID := uuid.New()
+		err := order.SetShippingAddress(&addressID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "current status")
+	})
+}
+
 // ============================================
 // Confirm Tests
 // ============================================
@@ -668,10 +841,192 @@ func TestSalesOrder_Ship(t *testing.T) {
 	})
 }
 
+func TestSalesOrder_ShipItems(t *testing.T) {
+	// confirmedOrder has 10 x 100 and 4 x 50 with a 120 discount: total 1200, payable 1080
+	confirmedOrder := func(t *testing.T) (*SalesOrder, *SalesOrderItem, *SalesOrderItem) {
+		order := createTestOrder(t)
+		first := addTestItem(t, order, "Product 1", 10, 100.00)
+		second := addTestItem(t, order, "Product 2", 4, 50.00)
+		require.NoError(t, order.ApplyDiscount(valueobject.NewMoneyCNYFromFloat(120)))
+		require.NoError(t, order.SetWarehouse(uuid.New()))
+		require.NoError(t, order.Confirm())
+		order.ClearDomainEvents()
+		return order, first, second
+	}
+
+	shippedEvent := func(t *testing.T, order *SalesOrder) *SalesOrderShippedEvent {
+		events := order.GetDomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(*SalesOrderShippedEvent)
+		require.True(t, ok)
+		order.ClearDomainEvents()
+		return event
+	}
+
+	t.Run("ships half then the rest", func(t *testing.T) {
+		order, first, second := confirmedOrder(t)
+
+		err := order.ShipItems([]ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(5)}, {ItemID: second.ID, Quantity: decimal.NewFromInt(2)}})
+		require.NoError(t, err)
+
+		assert.Equal(t, OrderStatusPartiallyShipped, order.Status)
+		assert.True(t, order.IsPartiallyShipped())
+		assert.Equal(t, 1, order.ShipmentCount)
+		require.NotNil(t, order.FirstShippedAt)
+		assert.Nil(t, order.FullyShippedAt)
+		firstShippedAt := *order.FirstShippedAt
+		assert.True(t, decimal.NewFromInt(5).Equal(order.GetItem(first.ID).ShippedQuantity))
+		assert.True(t, decimal.NewFromInt(5).Equal(order.GetItem(first.ID).RemainingQuantity()))
+
+		firstShipment := shippedEvent(t, order)
+		require.Len(t, firstShipment.Items, 2)
+		assert.True(t, decimal.NewFromInt(5).Equal(firstShipment.Items[0].Quantity))
+		assert.True(t, decimal.NewFromInt(500).Equal(firstShipment.Items[0].Amount))
+		assert.True(t, decimal.NewFromInt(600).Equal(firstShipment.TotalAmount))
+		assert.True(t, decimal.NewFromInt(540).Equal(firstShipment.PayableAmount), "got %s", firstShipment.PayableAmount)
+		assert.Equal(t, 1, firstShipment.ShipmentNumber)
+		assert.False(t, firstShipment.IsFullyShipped)
+
+		require.NoError(t, order.Ship())
+
+		assert.Equal(t, OrderStatusShipped, order.Status)
+		assert.Equal(t, 2, order.ShipmentCount)
+		assert.True(t, order.GetItem(second.ID).IsFullyShipped())
+		assert.Equal(t, firstShippedAt, *order.FirstShippedAt, "the first shipment time is kept")
+		require.NotNil(t, order.FullyShippedAt)
+		assert.Equal(t, *order.ShippedAt, *order.FullyShippedAt)
+
+		secondShipment := shippedEvent(t, order)
+		require.Len(t, secondShipment.Items, 2)
+		assert.True(t, decimal.NewFromInt(5).Equal(secondShipment.Items[0].Quantity))
+		assert.True(t, decimal.NewFromInt(2).Equal(secondShipment.Items[1].Quantity))
+		assert.Equal(t, 2, secondShipment.ShipmentNumber)
+		assert.True(t, secondShipment.IsFullyShipped)
+		assert.True(t, order.PayableAmount.Equal(firstShipment.PayableAmount.Add(secondShipment.PayableAmount)))
+	})
+
+	t.Run("shipment payable amounts add up to the order payable despite rounding", func(t *testing.T) {
+		order := createTestOrder(t)
+		item := addTestItem(t, order, "Product 1", 3, 10.00)
+		require.NoError(t, order.ApplyDiscount(valueobject.NewMoneyCNYFromFloat(10)))
+		require.NoError(t, order.SetWarehouse(uuid.New()))
+		require.NoError(t, order.Confirm())
+		order.ClearDomainEvents()
+
+		total := decimal.Zero
+		for i := 0; i < 3; i++ {
+			require.NoError(t, order.ShipItems([]ShipItem{{ItemID: item.ID, Quantity: decimal.NewFromInt(1)}}))
+			total = total.Add(shippedEvent(t, order).PayableAmount)
+		}
+
+		assert.True(t, decimal.NewFromInt(20).Equal(total), "got %s", total)
+		assert.Equal(t, OrderStatusShipped, order.Status)
+	})
+
+	t.Run("ShipmentPayableAmount matches the shipped event", func(t *testing.T) {
+		order, first, _ := confirmedOrder(t)
+		shipItems := []ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(10)}}
+
+		amount, err := order.ShipmentPayableAmount(shipItems)
+		require.NoError(t, err)
+		require.NoError(t, order.ShipItems(shipItems))
+
+		assert.True(t, amount.Equal(shippedEvent(t, order).PayableAmount))
+		assert.True(t, decimal.NewFromInt(900).Equal(amount), "got %s", amount)
+	})
+
+	t.Run("fails when shipping more than remaining", func(t *testing.T) {
+		order, first, _ := confirmedOrder(t)
+		require.NoError(t, order.ShipItems([]ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(8)}}))
+
+		err := order.ShipItems([]ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(3)}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only 2 remaining")
+	})
+
+	t.Run("fails for unknown item, duplicate item and non-positive quantity", func(t *testing.T) {
+		order, first, _ := confirmedOrder(t)
+
+		assert.Error(t, order.ShipItems([]ShipItem{{ItemID: uuid.New(), Quantity: decimal.NewFromInt(1)}}))
+		assert.Error(t, order.ShipItems([]ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(1)}, {ItemID: first.ID, Quantity: decimal.NewFromInt(1)}}))
+		assert.Error(t, order.ShipItems([]ShipItem{{ItemID: first.ID, Quantity: decimal.Zero}}))
+		assert.Error(t, order.ShipItems(nil))
+		assert.Equal(t, OrderStatusConfirmed, order.Status)
+		assert.Empty(t, order.GetDomainEvents())
+	})
+
+	t.Run("partially shipped order cannot be cancelled or completed", func(t *testing.T) {
+		order, first, _ := confirmedOrder(t)
+		require.NoError(t, order.ShipItems([]ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(1)}}))
+
+		assert.Error(t, order.Cancel("customer changed mind"))
+		assert.Error(t, order.Complete())
+	})
+
+	t.Run("records tracking on the shipped event", func(t *testing.T) {
+		order, first, _ := confirmedOrder(t)
+
+		err := order.ShipItemsWithTracking(
+			[]ShipItem{{ItemID: first.ID, Quantity: decimal.NewFromInt(1)}},
+			ShipmentTracking{ShippingCompany: "SF Express", TrackingNumber: "SF1234567890"},
+		)
+		require.NoError(t, err)
+
+		event := shippedEvent(t, order)
+		assert.Equal(t, "SF Express", event.ShippingCompany)
+		assert.Equal(t, "SF1234567890", event.TrackingNumber)
+	})
+}
+
 // ============================================
 // Complete Tests
 // ============================================
 
+func TestSalesOrder_ApproveCreditOverride(t *testing.T) {
+	t.Run("approves override for confirmed order", func(t *testing.T) {
+		order := createTestOrder(t)
+		addTestItem(t, order, "Product 1", 10, 100.00)
+		order.Confirm()
+		approverID := uuid.New()
+
+		err := order.ApproveCreditOverride(approverID, "Long-term customer")
+		require.NoError(t, err)
+
+		assert.True(t, order.HasCreditOverride())
+		assert.Equal(t, approverID, *order.CreditOverrideApprovedBy)
+		assert.NotNil(t, order.CreditOverrideApprovedAt)
+		assert.Equal(t, "Long-term customer", order.CreditOverrideReason)
+	})
+
+	t.Run("fails without approver", func(t *testing.T) {
+		order := createTestOrder(t)
+
+		err := order.ApproveCreditOverride(uuid.Nil, "Long-term customer")
+		assert.Error(t, err)
+		assert.False(t, order.HasCreditOverride())
+	})
+
+	t.Run("fails without reason", func(t *testing.T) {
+		order := createTestOrder(t)
+
+		err := order.ApproveCreditOverride(uuid.New(), "")
+		assert.Error(t, err)
+		assert.False(t, order.HasCreditOverride())
+	})
+
+	t.Run("fails for shipped order", func(t *testing.T) {
+		order := createTestOrder(t)
+		addTestItem(t, order, "Product 1", 10, 100.00)
+		order.SetWarehouse(uuid.New())
+		order.Confirm()
+		order.Ship()
+
+		err := order.ApproveCreditOverride(uuid.New(), "Long-term customer")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot approve credit override")
+	})
+}
+
 func TestSalesOrder_Complete(t *testing.T) {
 	t.Run("completes shipped order", func(t *testing.T) {
 		order := createTestOrder(t)
@@ -895,6 +1250,129 @@ func TestSalesOrder_StatusHelpers(t *testing.T) {
 	assert.True(t, order.IsTerminal())
 }
 
+// ============================================
+// Quote Tests
+// ============================================
+
+func createTestQuote(t *testing.T) *SalesOrder {
+	quote, err := NewSalesQuote(uuid.New(), "SO-2024-002", uuid.New(), "Test Customer", time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	return quote
+}
+
+func TestNewSalesQuote(t *testing.T) {
+	t.Run("creates quote with expiry", func(t *testing.T) {
+		quote := createTestQuote(t)
+
+		assert.Equal(t, OrderStatusQuote, quote.Status)
+		assert.True(t, quote.IsQuote())
+		assert.NotNil(t, quote.QuoteExpiresAt)
+		assert.True(t, quote.CanModify())
+		assert.False(t, quote.IsTerminal())
+	})
+
+	t.Run("rejects expiry in the past", func(t *testing.T) {
+		_, err := NewSalesQuote(uuid.New(), "SO-2024-002", uuid.New(), "Test Customer", time.Now().Add(-time.Minute))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "future")
+	})
+
+	t.Run("items and pricing can be edited", func(t *testing.T) {
+		quote := createTestQuote(t)
+		item := addTestItem(t, quote, "Product 1", 10, 100.00)
+
+		require.NoError(t, quote.UpdateItemQuantity(item.ID, decimal.NewFromInt(20)))
+		require.NoError(t, quote.UpdateItemPrice(item.ID, valueobject.NewMoneyCNYFromFloat(90.00)))
+		require.NoError(t, quote.ApplyDiscount(valueobject.NewMoneyCNYFromFloat(100.00)))
+		assert.True(t, quote.PayableAmount.Equal(decimal.NewFromInt(1700)))
+	})
+
+	t.Run("quote cannot be confirmed", func(t *testing.T) {
+		quote := createTestQuote(t)
+		addTestItem(t, quote, "Product 1", 10, 100.00)
+
+		assert.Error(t, quote.Confirm())
+	})
+}
+
+func TestSalesOrder_ConvertQuote(t *testing.T) {
+	t.Run("converts quote into draft order", func(t *testing.T) {
+		quote := createTestQuote(t)
+		addTestItem(t, quote, "Product 1", 10, 100.00)
+		quote.ClearDomainEvents()
+
+		require.NoError(t, quote.ConvertQuote())
+		assert.Equal(t, OrderStatusDraft, quote.Status)
+		assert.NotNil(t, quote.ConvertedAt)
+		assert.Empty(t, quote.GetDomainEvents())
+
+		// The converted order follows the normal order lifecycle
+		require.NoError(t, quote.Confirm())
+		assert.Equal(t, OrderStatusConfirmed, quote.Status)
+	})
+
+	t.Run("fails without items", func(t *testing.T) {
+		quote := createTestQuote(t)
+
+		err := quote.ConvertQuote()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "without items")
+	})
+
+	t.Run("fails once the quote has expired", func(t *testing.T) {
+		quote := createTestQuote(t)
+		addTestItem(t, quote, "Product 1", 10, 100.00)
+		past := time.Now().Add(-time.Minute)
+		quote.QuoteExpiresAt = &past
+
+		err := quote.ConvertQuote()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "expired")
+		assert.Equal(t, OrderStatusQuote, quote.Status)
+	})
+
+	t.Run("fails for a draft order", func(t *testing.T) {
+		order := createTestOrder(t)
+		addTestItem(t, order, "Product 1", 10, 100.00)
+
+		assert.Error(t, order.ConvertQuote())
+	})
+}
+
+func TestSalesOrder_Expire(t *testing.T) {
+	t.Run("expires quote past its expiry", func(t *testing.T) {
+		quote := createTestQuote(t)
+		addTestItem(t, quote, "Product 1", 10, 100.00)
+
+		now := quote.QuoteExpiresAt.Add(time.Second)
+		require.NoError(t, quote.Expire(now))
+		assert.Equal(t, OrderStatusExpired, quote.Status)
+		assert.Equal(t, &now, quote.ExpiredAt)
+		assert.True(t, quote.IsTerminal())
+		assert.False(t, quote.CanModify())
+
+		_, err := quote.AddItem(uuid.New(), "Product 2", "SKU-002", "pcs", "pcs", decimal.NewFromInt(1), decimal.NewFromInt(1), valueobject.NewMoneyCNYFromFloat(10.00))
+		assert.Error(t, err)
+		assert.Error(t, quote.ConvertQuote())
+	})
+
+	t.Run("fails before expiry", func(t *testing.T) {
+		quote := createTestQuote(t)
+
+		err := quote.Expire(time.Now())
+		assert.Error(t, err)
+		assert.Equal(t, OrderStatusQuote, quote.Status)
+	})
+
+	t.Run("fails for a converted quote", func(t *testing.T) {
+		quote := createTestQuote(t)
+		addTestItem(t, quote, "Product 1", 10, 100.00)
+		require.NoError(t, quote.ConvertQuote())
+
+		assert.Error(t, quote.Expire(quote.QuoteExpiresAt.Add(time.Second)))
+	})
+}
+
 // ============================================
 // Event Tests
 // ============================================
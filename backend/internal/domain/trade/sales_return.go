@@ -199,7 +199,8 @@ type SalesReturn struct {
 	Items            []SalesReturnItem
 	TotalRefund      decimal.Decimal // Sum of all item refunds
 	Status           ReturnStatus
-	Reason           string // Overall return reason
+	ReasonCode       ReturnReasonCode // Classified return reason, used for analytics
+	Reason           string           // Optional free-text note on the reason
 	Remark           string
 	SubmittedAt      *time.Time // When submitted for approval
 	ApprovedAt       *time.Time
@@ -282,7 +283,7 @@ func (r *SalesReturn) AddItem(
 		salesOrderItem.Quantity,
 		returnQuantity,
 		salesOrderItem.ConversionRate,
-		salesOrderItem.GetUnitPriceMoney(),
+		salesOrderItem.GetNetUnitPriceMoney(), // Refund what the customer paid after the line discount
 	)
 	if err != nil {
 		return nil, err
@@ -335,12 +336,25 @@ func (r *SalesReturn) RemoveItem(itemID uuid.UUID) error {
 	return shared.NewDomainError("ITEM_NOT_FOUND", "Return item not found")
 }
 
-// SetReason sets the overall return reason
+// SetReason sets the free-text note on the return reason
 func (r *SalesReturn) SetReason(reason string) {
 	r.Reason = reason
 	r.UpdatedAt = time.Now()
 }
 
+// SetReasonCode sets the classified return reason
+func (r *SalesReturn) SetReasonCode(code ReturnReasonCode) error {
+	if code == "" {
+		return shared.NewDomainError("INVALID_REASON_CODE", "Return reason code is required")
+	}
+	if !code.IsValid() {
+		return shared.NewDomainError("INVALID_REASON_CODE", fmt.Sprintf("Unknown return reason code: %s", code))
+	}
+	r.ReasonCode = code
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
 // SetRemark sets the return remark
 func (r *SalesReturn) SetRemark(remark string) {
 	r.Remark = remark
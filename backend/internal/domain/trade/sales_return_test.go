@@ -3,6 +3,7 @@ package trade
 import (
 	"testing"
 
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -473,6 +474,21 @@ func TestSalesReturn_HelperMethods(t *testing.T) {
 	})
 }
 
+func TestSalesReturn_SetReasonCode(t *testing.T) {
+	order := createTestSalesOrderForReturn(t)
+	sr, _ := NewSalesReturn(order.TenantID, "SR-001", order)
+
+	require.NoError(t, sr.SetReasonCode(ReturnReasonWrongItem))
+	assert.Equal(t, ReturnReasonWrongItem, sr.ReasonCode)
+
+	for _, code := range []ReturnReasonCode{"", "BROKEN", "wrong_item"} {
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, sr.SetReasonCode(code), &domainErr, code)
+		assert.Equal(t, "INVALID_REASON_CODE", domainErr.Code)
+	}
+	assert.Equal(t, ReturnReasonWrongItem, sr.ReasonCode, "invalid codes leave the reason code unchanged")
+}
+
 func TestSalesReturnItem_SetReason(t *testing.T) {
 	order := createTestSalesOrderForReturn(t)
 	sr, _ := NewSalesReturn(order.TenantID, "SR-001", order)
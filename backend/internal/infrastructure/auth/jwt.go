@@ -40,6 +40,9 @@ type Claims struct {
 	Permissions  []string  `json:"permissions,omitempty"`
 	TokenType    TokenType `json:"token_type"`
 	RefreshCount int       `json:"refresh_count,omitempty"`
+	// FamilyID identifies the login session the token belongs to. It is set
+	// on login and carried over to every token issued by refreshing.
+	FamilyID string `json:"family_id,omitempty"`
 }
 
 // TokenPair represents an access and refresh token pair
@@ -49,6 +52,8 @@ type TokenPair struct {
 	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
 	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 	TokenType             string    `json:"token_type"` // Bearer
+	AccessTokenID         string    `json:"-"`          // JTI of the access token
+	FamilyID              string    `json:"-"`          // Token family (login session) of the pair
 }
 
 // JWTService handles JWT token operations
@@ -91,6 +96,7 @@ type GenerateTokenInput struct {
 func (s *JWTService) GenerateTokenPair(input GenerateTokenInput) (*TokenPair, error) {
 	now := time.Now()
 	jti := uuid.New().String()
+	familyID := uuid.New().String()
 
 	// Convert UUIDs to strings
 	roleIDStrings := make([]string, len(input.RoleIDs))
@@ -115,6 +121,7 @@ func (s *JWTService) GenerateTokenPair(input GenerateTokenInput) (*TokenPair, er
 		RoleIDs:     roleIDStrings,
 		Permissions: input.Permissions,
 		TokenType:   TokenTypeAccess,
+		FamilyID:    familyID,
 	}
 
 	accessToken, err := s.generateToken(accessClaims, s.accessSecret)
@@ -137,6 +144,7 @@ func (s *JWTService) GenerateTokenPair(input GenerateTokenInput) (*TokenPair, er
 		UserID:       input.UserID.String(),
 		TokenType:    TokenTypeRefresh,
 		RefreshCount: 0,
+		FamilyID:     familyID,
 	}
 
 	refreshToken, err := s.generateToken(refreshClaims, s.refreshSecret)
@@ -150,6 +158,8 @@ func (s *JWTService) GenerateTokenPair(input GenerateTokenInput) (*TokenPair, er
 		AccessTokenExpiresAt:  now.Add(s.accessExpiration),
 		RefreshTokenExpiresAt: now.Add(s.refreshExpiration),
 		TokenType:             "Bearer",
+		AccessTokenID:         jti,
+		FamilyID:              familyID,
 	}, nil
 }
 
@@ -231,9 +241,10 @@ func (s *JWTService) RefreshTokenPair(refreshToken string, permissions []string)
 		return nil, ErrInvalidClaims
 	}
 
-	// Generate new token pair
+	// Generate new token pair in the same family
 	now := time.Now()
 	jti := uuid.New().String()
+	familyID := claims.GetFamilyID()
 
 	// Generate new access token
 	accessClaims := &Claims{
@@ -252,6 +263,7 @@ func (s *JWTService) RefreshTokenPair(refreshToken string, permissions []string)
 		RoleIDs:     claims.RoleIDs,
 		Permissions: permissions,
 		TokenType:   TokenTypeAccess,
+		FamilyID:    familyID,
 	}
 
 	accessToken, err := s.generateToken(accessClaims, s.accessSecret)
@@ -274,6 +286,7 @@ func (s *JWTService) RefreshTokenPair(refreshToken string, permissions []string)
 		UserID:       userID.String(),
 		TokenType:    TokenTypeRefresh,
 		RefreshCount: claims.RefreshCount + 1,
+		FamilyID:     familyID,
 	}
 
 	newRefreshToken, err := s.generateToken(refreshClaims, s.refreshSecret)
@@ -287,6 +300,8 @@ func (s *JWTService) RefreshTokenPair(refreshToken string, permissions []string)
 		AccessTokenExpiresAt:  now.Add(s.accessExpiration),
 		RefreshTokenExpiresAt: now.Add(s.refreshExpiration),
 		TokenType:             "Bearer",
+		AccessTokenID:         jti,
+		FamilyID:              familyID,
 	}, nil
 }
 
@@ -359,6 +374,15 @@ func (c *Claims) GetExpiresAtTime() time.Time {
 	return time.Time{}
 }
 
+// GetFamilyID returns the token family ID. Tokens issued before families were
+// introduced have none; their own JTI then starts the family.
+func (c *Claims) GetFamilyID() string {
+	if c.FamilyID != "" {
+		return c.FamilyID
+	}
+	return c.ID
+}
+
 // GetRemainingTTL returns the remaining time until the token expires
 func (c *Claims) GetRemainingTTL() time.Duration {
 	if c.ExpiresAt == nil {
@@ -203,6 +203,35 @@ func TestRefreshTokenPair_Success(t *testing.T) {
 	assert.Equal(t, newPermissions, claims.Permissions)
 }
 
+func TestRefreshTokenPair_KeepsTokenFamily(t *testing.T) {
+	svc := newTestJWTService()
+
+	pair, err := svc.GenerateTokenPair(newTestInput())
+	require.NoError(t, err)
+	claims, err := svc.ValidateRefreshToken(pair.RefreshToken)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.FamilyID)
+
+	newPair, err := svc.RefreshTokenPair(pair.RefreshToken, nil)
+	require.NoError(t, err)
+
+	newClaims, err := svc.ValidateRefreshToken(newPair.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, claims.FamilyID, newClaims.FamilyID)
+	assert.NotEqual(t, claims.ID, newClaims.ID)
+
+	accessClaims, err := svc.ValidateAccessToken(newPair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, claims.FamilyID, accessClaims.FamilyID)
+
+	// Another login starts a new family
+	otherPair, err := svc.GenerateTokenPair(newTestInput())
+	require.NoError(t, err)
+	otherClaims, err := svc.ValidateRefreshToken(otherPair.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, claims.FamilyID, otherClaims.FamilyID)
+}
+
 func TestRefreshTokenPair_IncrementsRefreshCount(t *testing.T) {
 	svc := newTestJWTService()
 	input := newTestInput()
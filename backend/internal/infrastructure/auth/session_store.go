@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Session is an active login session of a user.
+// A session is one token family: it starts with a login and is carried over by
+// every token refresh, so JTI always names the latest access token issued for it.
+type Session struct {
+	FamilyID   string    `json:"family_id"`
+	JTI        string    `json:"jti"` // JTI of the latest access token of the session
+	UserID     string    `json:"user_id"`
+	TenantID   string    `json:"tenant_id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"` // IP the session was last seen from
+	CreatedAt  time.Time `json:"created_at"`
+	IssuedAt   time.Time `json:"issued_at"` // Issue time of the latest access token
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"` // Expiration of the latest refresh token
+}
+
+// SessionStore defines the interface for tracking the login sessions of users
+type SessionStore interface {
+	// SaveSession creates or replaces the session of a token family
+	SaveSession(ctx context.Context, session *Session) error
+
+	// GetSession returns the session of a token family, or nil if there is none
+	GetSession(ctx context.Context, userID, familyID string) (*Session, error)
+
+	// ListUserSessions returns the unexpired sessions of a user
+	ListUserSessions(ctx context.Context, userID string) ([]*Session, error)
+
+	// DeleteSession removes the session of a token family
+	DeleteSession(ctx context.Context, userID, familyID string) error
+}
+
+// RedisSessionStore implements SessionStore using Redis.
+// The sessions of a user are stored in one hash keyed by token family, which
+// expires with the user's longest-lived session.
+type RedisSessionStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedisSessionStoreWithClient creates a session store with an existing Redis client
+func NewRedisSessionStoreWithClient(client *redis.Client) *RedisSessionStore {
+	return &RedisSessionStore{
+		client:    client,
+		keyPrefix: "session:",
+	}
+}
+
+// userKey returns the Redis key for the sessions of a user
+func (s *RedisSessionStore) userKey(userID string) string {
+	return s.keyPrefix + "user:" + userID
+}
+
+// SaveSession stores a session in the user's hash and extends the hash's TTL to cover it
+func (s *RedisSessionStore) SaveSession(ctx context.Context, session *Session) error {
+	key := s.userKey(session.UserID)
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	if err := s.client.HSet(ctx, key, session.FamilyID, data).Err(); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	ttl, err := s.client.TTL(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read session TTL: %w", err)
+	}
+	if remaining := time.Until(session.ExpiresAt); ttl < remaining {
+		if err := s.client.Expire(ctx, key, remaining).Err(); err != nil {
+			return fmt.Errorf("failed to set session TTL: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetSession returns the session of a token family, or nil if there is none
+func (s *RedisSessionStore) GetSession(ctx context.Context, userID, familyID string) (*Session, error) {
+	data, err := s.client.HGet(ctx, s.userKey(userID), familyID).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// ListUserSessions returns the unexpired sessions of a user and removes the expired ones
+func (s *RedisSessionStore) ListUserSessions(ctx context.Context, userID string) ([]*Session, error) {
+	key := s.userKey(userID)
+
+	entries, err := s.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	sessions := make([]*Session, 0, len(entries))
+	var expired []string
+	for familyID, data := range entries {
+		var session Session
+		if err := json.Unmarshal([]byte(data), &session); err != nil || now.After(session.ExpiresAt) {
+			expired = append(expired, familyID)
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if len(expired) > 0 {
+		if err := s.client.HDel(ctx, key, expired...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to remove expired sessions: %w", err)
+		}
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession removes the session of a token family
+func (s *RedisSessionStore) DeleteSession(ctx context.Context, userID, familyID string) error {
+	if err := s.client.HDel(ctx, s.userKey(userID), familyID).Err(); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// Ensure RedisSessionStore implements SessionStore
+var _ SessionStore = (*RedisSessionStore)(nil)
+
+// InMemorySessionStore provides an in-memory implementation for testing
+// WARNING: This should not be used in production with multiple instances
+type InMemorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]map[string]Session // userID -> familyID -> session
+}
+
+// NewInMemorySessionStore creates a new in-memory session store
+func NewInMemorySessionStore() *InMemorySessionStore {
+	return &InMemorySessionStore{
+		sessions: make(map[string]map[string]Session),
+	}
+}
+
+// SaveSession creates or replaces the session of a token family
+func (s *InMemorySessionStore) SaveSession(_ context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userSessions, ok := s.sessions[session.UserID]
+	if !ok {
+		userSessions = make(map[string]Session)
+		s.sessions[session.UserID] = userSessions
+	}
+	userSessions[session.FamilyID] = *session
+	return nil
+}
+
+// GetSession returns the session of a token family, or nil if there is none
+func (s *InMemorySessionStore) GetSession(_ context.Context, userID, familyID string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[userID][familyID]
+	if !ok || time.Now().After(session.ExpiresAt) {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// ListUserSessions returns the unexpired sessions of a user and removes the expired ones
+func (s *InMemorySessionStore) ListUserSessions(_ context.Context, userID string) ([]*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	userSessions := s.sessions[userID]
+	sessions := make([]*Session, 0, len(userSessions))
+	for familyID, session := range userSessions {
+		if now.After(session.ExpiresAt) {
+			delete(userSessions, familyID)
+			continue
+		}
+		session := session
+		sessions = append(sessions, &session)
+	}
+	return sessions, nil
+}
+
+// DeleteSession removes the session of a token family
+func (s *InMemorySessionStore) DeleteSession(_ context.Context, userID, familyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions[userID], familyID)
+	return nil
+}
+
+// Ensure InMemorySessionStore implements SessionStore
+var _ SessionStore = (*InMemorySessionStore)(nil)
@@ -0,0 +1,63 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/infrastructure/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemorySessionStore_SaveAndList(t *testing.T) {
+	store := auth.NewInMemorySessionStore()
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, store.SaveSession(ctx, &auth.Session{FamilyID: "family-1", JTI: "jti-1", UserID: "user-1", ExpiresAt: expiresAt}))
+	require.NoError(t, store.SaveSession(ctx, &auth.Session{FamilyID: "family-2", JTI: "jti-2", UserID: "user-1", ExpiresAt: expiresAt}))
+	require.NoError(t, store.SaveSession(ctx, &auth.Session{FamilyID: "family-3", JTI: "jti-3", UserID: "user-2", ExpiresAt: expiresAt}))
+
+	sessions, err := store.ListUserSessions(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	// Saving a family again replaces its session
+	require.NoError(t, store.SaveSession(ctx, &auth.Session{FamilyID: "family-1", JTI: "jti-1b", UserID: "user-1", ExpiresAt: expiresAt}))
+	session, err := store.GetSession(ctx, "user-1", "family-1")
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, "jti-1b", session.JTI)
+
+	require.NoError(t, store.DeleteSession(ctx, "user-1", "family-1"))
+	sessions, err = store.ListUserSessions(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "jti-2", sessions[0].JTI)
+
+	session, err = store.GetSession(ctx, "user-1", "family-1")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestInMemorySessionStore_ExpiredSessions(t *testing.T) {
+	store := auth.NewInMemorySessionStore()
+	ctx := context.Background()
+
+	require.NoError(t, store.SaveSession(ctx, &auth.Session{FamilyID: "family-1", UserID: "user-1", ExpiresAt: time.Now().Add(time.Millisecond)}))
+	time.Sleep(10 * time.Millisecond)
+
+	session, err := store.GetSession(ctx, "user-1", "family-1")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	sessions, err := store.ListUserSessions(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestSessionStore_Interface(t *testing.T) {
+	var _ auth.SessionStore = (*auth.InMemorySessionStore)(nil)
+	var _ auth.SessionStore = (*auth.RedisSessionStore)(nil)
+}
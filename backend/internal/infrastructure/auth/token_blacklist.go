@@ -26,6 +26,18 @@ type TokenBlacklist interface {
 	// IsUserTokenInvalidated checks if a user's tokens have been invalidated
 	// Returns true if tokens issued before the invalidation timestamp should be rejected
 	IsUserTokenInvalidated(ctx context.Context, userID string, tokenIssuedAt time.Time) (bool, error)
+
+	// MarkRefreshTokenUsed records that a refresh token's JTI has been rotated
+	// Returns false if it was already marked, i.e. the refresh token is being replayed
+	// ttl should be set to the remaining time until token expiration
+	MarkRefreshTokenUsed(ctx context.Context, jti string, ttl time.Duration) (bool, error)
+
+	// RevokeTokenFamily invalidates all tokens of a token family (one login session)
+	// ttl should be set to the refresh token lifetime, which no token of the family outlives
+	RevokeTokenFamily(ctx context.Context, familyID string, ttl time.Duration) error
+
+	// IsTokenFamilyRevoked checks if a token family has been revoked
+	IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error)
 }
 
 // RedisTokenBlacklist implements TokenBlacklist using Redis
@@ -88,6 +100,16 @@ func (b *RedisTokenBlacklist) userKey(userID string) string {
 	return b.keyPrefix + "user:" + userID
 }
 
+// usedRefreshKey returns the Redis key marking a refresh token JTI as used
+func (b *RedisTokenBlacklist) usedRefreshKey(jti string) string {
+	return b.keyPrefix + "refresh:" + jti
+}
+
+// familyKey returns the Redis key for token family revocation
+func (b *RedisTokenBlacklist) familyKey(familyID string) string {
+	return b.keyPrefix + "family:" + familyID
+}
+
 // AddToBlacklist adds a token's JTI to the blacklist
 func (b *RedisTokenBlacklist) AddToBlacklist(ctx context.Context, jti string, ttl time.Duration) error {
 	key := b.jtiKey(jti)
@@ -151,6 +173,43 @@ func (b *RedisTokenBlacklist) IsUserTokenInvalidated(ctx context.Context, userID
 	return tokenIssuedAt.Unix() <= invalidationTime, nil
 }
 
+// MarkRefreshTokenUsed marks a refresh token JTI as used
+// SETNX makes this atomic, so of two concurrent refreshes with the same token only one succeeds
+func (b *RedisTokenBlacklist) MarkRefreshTokenUsed(ctx context.Context, jti string, ttl time.Duration) (bool, error) {
+	key := b.usedRefreshKey(jti)
+
+	marked, err := b.client.SetNX(ctx, key, "1", ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+
+	return marked, nil
+}
+
+// RevokeTokenFamily revokes all tokens of a token family
+func (b *RedisTokenBlacklist) RevokeTokenFamily(ctx context.Context, familyID string, ttl time.Duration) error {
+	key := b.familyKey(familyID)
+
+	err := b.client.Set(ctx, key, "1", ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenFamilyRevoked checks if a token family has been revoked
+func (b *RedisTokenBlacklist) IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	key := b.familyKey(familyID)
+
+	exists, err := b.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token family revocation: %w", err)
+	}
+
+	return exists > 0, nil
+}
+
 // Close closes the Redis client
 func (b *RedisTokenBlacklist) Close() error {
 	return b.client.Close()
@@ -170,6 +229,8 @@ type InMemoryTokenBlacklist struct {
 	mu                    sync.RWMutex
 	jtiBlacklist          map[string]time.Time // JTI -> expiration time
 	userInvalidationTimes map[string]time.Time // userID -> invalidation time
+	usedRefreshTokens     map[string]time.Time // refresh token JTI -> expiration time
+	revokedFamilies       map[string]time.Time // familyID -> expiration time
 }
 
 // NewInMemoryTokenBlacklist creates a new in-memory token blacklist
@@ -177,6 +238,8 @@ func NewInMemoryTokenBlacklist() *InMemoryTokenBlacklist {
 	return &InMemoryTokenBlacklist{
 		jtiBlacklist:          make(map[string]time.Time),
 		userInvalidationTimes: make(map[string]time.Time),
+		usedRefreshTokens:     make(map[string]time.Time),
+		revokedFamilies:       make(map[string]time.Time),
 	}
 }
 
@@ -230,5 +293,43 @@ func (b *InMemoryTokenBlacklist) IsUserTokenInvalidated(_ context.Context, userI
 	return tokenIssuedAt.UnixNano() <= invalidationTime.UnixNano(), nil
 }
 
+// MarkRefreshTokenUsed marks a refresh token JTI as used
+func (b *InMemoryTokenBlacklist) MarkRefreshTokenUsed(_ context.Context, jti string, ttl time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if expiration, exists := b.usedRefreshTokens[jti]; exists && time.Now().Before(expiration) {
+		return false, nil
+	}
+	b.usedRefreshTokens[jti] = time.Now().Add(ttl)
+	return true, nil
+}
+
+// RevokeTokenFamily revokes all tokens of a token family
+func (b *InMemoryTokenBlacklist) RevokeTokenFamily(_ context.Context, familyID string, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.revokedFamilies[familyID] = time.Now().Add(ttl)
+	return nil
+}
+
+// IsTokenFamilyRevoked checks if a token family has been revoked (and not expired)
+func (b *InMemoryTokenBlacklist) IsTokenFamilyRevoked(_ context.Context, familyID string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiration, exists := b.revokedFamilies[familyID]
+	if !exists {
+		return false, nil
+	}
+
+	if time.Now().After(expiration) {
+		delete(b.revokedFamilies, familyID)
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // Ensure InMemoryTokenBlacklist implements TokenBlacklist
 var _ TokenBlacklist = (*InMemoryTokenBlacklist)(nil)
@@ -105,6 +105,55 @@ func TestInMemoryTokenBlacklist_MultipleTokens(t *testing.T) {
 	assert.False(t, isBlacklisted)
 }
 
+func TestInMemoryTokenBlacklist_MarkRefreshTokenUsed(t *testing.T) {
+	blacklist := auth.NewInMemoryTokenBlacklist()
+	ctx := context.Background()
+
+	// First use marks the token
+	firstUse, err := blacklist.MarkRefreshTokenUsed(ctx, "refresh-jti-1", 1*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, firstUse)
+
+	// Second use is a replay
+	firstUse, err = blacklist.MarkRefreshTokenUsed(ctx, "refresh-jti-1", 1*time.Hour)
+	require.NoError(t, err)
+	assert.False(t, firstUse)
+
+	// Other tokens are unaffected
+	firstUse, err = blacklist.MarkRefreshTokenUsed(ctx, "refresh-jti-2", 1*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, firstUse)
+}
+
+func TestInMemoryTokenBlacklist_TokenFamilyRevocation(t *testing.T) {
+	blacklist := auth.NewInMemoryTokenBlacklist()
+	ctx := context.Background()
+
+	revoked, err := blacklist.IsTokenFamilyRevoked(ctx, "family-1")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	err = blacklist.RevokeTokenFamily(ctx, "family-1", 1*time.Hour)
+	require.NoError(t, err)
+
+	revoked, err = blacklist.IsTokenFamilyRevoked(ctx, "family-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = blacklist.IsTokenFamilyRevoked(ctx, "family-2")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Revocation expires with its TTL
+	err = blacklist.RevokeTokenFamily(ctx, "family-3", 1*time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+
+	revoked, err = blacklist.IsTokenFamilyRevoked(ctx, "family-3")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
 func TestInMemoryTokenBlacklist_Interface(t *testing.T) {
 	// Ensure InMemoryTokenBlacklist implements TokenBlacklist interface
 	var _ auth.TokenBlacklist = (*auth.InMemoryTokenBlacklist)(nil)
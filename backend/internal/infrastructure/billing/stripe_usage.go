@@ -70,6 +70,7 @@ type UsageReportLog struct {
 	UsageType          billing.UsageType
 	Quantity           int64
 	Timestamp          time.Time
+	IdempotencyKey     string // Marks the usage already reported for a tenant, item, type and period
 	StripeRecordID     string
 	Status             UsageReportStatus
 	ErrorMessage       string
@@ -278,6 +279,10 @@ type UsageReportLogRepository interface {
 	// FindByID retrieves a usage report log by ID
 	FindByID(ctx context.Context, id uuid.UUID) (*UsageReportLog, error)
 
+	// FindByIdempotencyKey retrieves the usage report log with the given idempotency key,
+	// or nil if there is none
+	FindByIdempotencyKey(ctx context.Context, key string) (*UsageReportLog, error)
+
 	// FindPending retrieves all pending usage reports for retry
 	FindPending(ctx context.Context, maxRetries int) ([]*UsageReportLog, error)
 
@@ -10,7 +10,12 @@ import (
 
 // entry represents a stored event ID with expiration
 type entry struct {
-	expiresAt time.Time
+	expiresAt time.Time // zero if the entry never expires
+}
+
+// expired reports whether the entry has expired at the given time
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
 }
 
 // InMemoryIdempotencyStore implements IdempotencyStore using an in-memory map
@@ -46,16 +51,18 @@ func (s *InMemoryIdempotencyStore) MarkProcessed(ctx context.Context, eventID st
 
 	// Check if already exists and not expired
 	if e, exists := s.entries[eventID]; exists {
-		if time.Now().Before(e.expiresAt) {
+		if !e.expired(time.Now()) {
 			return false, nil // Already processed
 		}
 		// Entry exists but expired, will be overwritten
 	}
 
 	// Mark as processed
-	s.entries[eventID] = entry{
-		expiresAt: time.Now().Add(ttl),
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
 	}
+	s.entries[eventID] = entry{expiresAt: expiresAt}
 
 	return true, nil
 }
@@ -71,13 +78,21 @@ func (s *InMemoryIdempotencyStore) IsProcessed(ctx context.Context, eventID stri
 	}
 
 	// Check if entry has expired
-	if time.Now().After(e.expiresAt) {
+	if e.expired(time.Now()) {
 		return false, nil // Expired, treat as not processed
 	}
 
 	return true, nil
 }
 
+// Release removes the mark of an event, so it can be processed again
+func (s *InMemoryIdempotencyStore) Release(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, eventID)
+	return nil
+}
+
 // Close stops the cleanup goroutine and releases resources
 // Safe to call multiple times
 func (s *InMemoryIdempotencyStore) Close() error {
@@ -112,7 +127,7 @@ func (s *InMemoryIdempotencyStore) cleanup() {
 
 	now := time.Now()
 	for eventID, e := range s.entries {
-		if now.After(e.expiresAt) {
+		if e.expired(now) {
 			delete(s.entries, eventID)
 		}
 	}
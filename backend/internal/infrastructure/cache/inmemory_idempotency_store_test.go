@@ -94,6 +94,28 @@ func TestInMemoryIdempotencyStore_IsProcessed(t *testing.T) {
 	})
 }
 
+func TestInMemoryIdempotencyStore_Release(t *testing.T) {
+	store := NewInMemoryIdempotencyStore()
+	defer store.Close()
+
+	ctx := context.Background()
+
+	// A zero TTL keeps the mark until it is released
+	isNew, err := store.MarkProcessed(ctx, "event-release", 0)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	store.cleanup()
+
+	processed, err := store.IsProcessed(ctx, "event-release")
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	require.NoError(t, store.Release(ctx, "event-release"))
+	isNew, err = store.MarkProcessed(ctx, "event-release", 0)
+	require.NoError(t, err)
+	assert.True(t, isNew, "released event should be marked again")
+}
+
 func TestInMemoryIdempotencyStore_Size(t *testing.T) {
 	store := NewInMemoryIdempotencyStore()
 	defer store.Close()
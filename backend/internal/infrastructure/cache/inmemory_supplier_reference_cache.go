@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/erp/backend/internal/domain/finance/acl"
+	"github.com/google/uuid"
+)
+
+// InMemorySupplierReferenceCache implements acl.SupplierReferenceCache using an in-memory map per tenant
+// This is suitable for single-instance deployments and testing
+type InMemorySupplierReferenceCache struct {
+	mu      sync.RWMutex
+	tenants map[uuid.UUID]map[uuid.UUID]acl.SupplierReference
+}
+
+// NewInMemorySupplierReferenceCache creates a new in-memory supplier reference cache
+func NewInMemorySupplierReferenceCache() *InMemorySupplierReferenceCache {
+	return &InMemorySupplierReferenceCache{
+		tenants: make(map[uuid.UUID]map[uuid.UUID]acl.SupplierReference),
+	}
+}
+
+// Get retrieves a supplier reference from the cache
+func (c *InMemorySupplierReferenceCache) Get(ctx context.Context, tenantID, supplierID uuid.UUID) (acl.SupplierReference, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ref, ok := c.tenants[tenantID][supplierID]
+	return ref, ok
+}
+
+// Set stores a supplier reference in the cache, replacing any previous one
+func (c *InMemorySupplierReferenceCache) Set(ctx context.Context, tenantID uuid.UUID, ref acl.SupplierReference) error {
+	return c.SetBatch(ctx, tenantID, []acl.SupplierReference{ref})
+}
+
+// SetBatch stores multiple supplier references in the cache
+func (c *InMemorySupplierReferenceCache) SetBatch(ctx context.Context, tenantID uuid.UUID, refs []acl.SupplierReference) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	refsByID, ok := c.tenants[tenantID]
+	if !ok {
+		refsByID = make(map[uuid.UUID]acl.SupplierReference)
+		c.tenants[tenantID] = refsByID
+	}
+	for _, ref := range refs {
+		refsByID[ref.UUID()] = ref
+	}
+	return nil
+}
+
+// Invalidate removes a supplier reference from the cache
+func (c *InMemorySupplierReferenceCache) Invalidate(ctx context.Context, tenantID, supplierID uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.tenants[tenantID], supplierID)
+	return nil
+}
+
+// InvalidateAll removes all cached supplier references of a tenant
+func (c *InMemorySupplierReferenceCache) InvalidateAll(ctx context.Context, tenantID uuid.UUID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.tenants, tenantID)
+	return nil
+}
+
+// Ensure InMemorySupplierReferenceCache implements acl.SupplierReferenceCache
+var _ acl.SupplierReferenceCache = (*InMemorySupplierReferenceCache)(nil)
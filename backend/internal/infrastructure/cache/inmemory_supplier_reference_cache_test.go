@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/finance/acl"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemorySupplierReferenceCache(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	otherTenantID := uuid.New()
+
+	t.Run("returns a stored reference for its tenant only", func(t *testing.T) {
+		cache := NewInMemorySupplierReferenceCache()
+		ref := acl.MustNewSupplierReference(uuid.New(), "Acme Supplies", "SUP-001")
+
+		require.NoError(t, cache.Set(ctx, tenantID, ref))
+
+		got, ok := cache.Get(ctx, tenantID, ref.UUID())
+		require.True(t, ok)
+		assert.True(t, got.Equals(ref))
+		assert.Equal(t, "Acme Supplies", got.Name())
+		_, ok = cache.Get(ctx, otherTenantID, ref.UUID())
+		assert.False(t, ok)
+	})
+
+	t.Run("set replaces the previous reference", func(t *testing.T) {
+		cache := NewInMemorySupplierReferenceCache()
+		supplierID := uuid.New()
+		require.NoError(t, cache.Set(ctx, tenantID, acl.MustNewSupplierReference(supplierID, "Old Name", "SUP-001")))
+
+		require.NoError(t, cache.Set(ctx, tenantID, acl.MustNewSupplierReference(supplierID, "New Name", "SUP-001")))
+
+		got, ok := cache.Get(ctx, tenantID, supplierID)
+		require.True(t, ok)
+		assert.Equal(t, "New Name", got.Name())
+	})
+
+	t.Run("invalidate removes one reference", func(t *testing.T) {
+		cache := NewInMemorySupplierReferenceCache()
+		first := acl.MustNewSupplierReference(uuid.New(), "First", "SUP-001")
+		second := acl.MustNewSupplierReference(uuid.New(), "Second", "SUP-002")
+		require.NoError(t, cache.SetBatch(ctx, tenantID, []acl.SupplierReference{first, second}))
+
+		require.NoError(t, cache.Invalidate(ctx, tenantID, first.UUID()))
+
+		_, ok := cache.Get(ctx, tenantID, first.UUID())
+		assert.False(t, ok)
+		_, ok = cache.Get(ctx, tenantID, second.UUID())
+		assert.True(t, ok)
+	})
+
+	t.Run("invalidate all clears only the tenant", func(t *testing.T) {
+		cache := NewInMemorySupplierReferenceCache()
+		ref := acl.MustNewSupplierReference(uuid.New(), "Acme Supplies", "SUP-001")
+		require.NoError(t, cache.Set(ctx, tenantID, ref))
+		require.NoError(t, cache.Set(ctx, otherTenantID, ref))
+
+		require.NoError(t, cache.InvalidateAll(ctx, tenantID))
+
+		_, ok := cache.Get(ctx, tenantID, ref.UUID())
+		assert.False(t, ok)
+		_, ok = cache.Get(ctx, otherTenantID, ref.UUID())
+		assert.True(t, ok)
+	})
+}
@@ -87,6 +87,14 @@ func (s *RedisIdempotencyStore) IsProcessed(ctx context.Context, eventID string)
 	return exists > 0, nil
 }
 
+// Release removes the mark of an event, so it can be processed again
+func (s *RedisIdempotencyStore) Release(ctx context.Context, eventID string) error {
+	if err := s.client.Del(ctx, s.keyPrefix+eventID).Err(); err != nil {
+		return fmt.Errorf("failed to release processed event: %w", err)
+	}
+	return nil
+}
+
 // Close closes the Redis client
 func (s *RedisIdempotencyStore) Close() error {
 	return s.client.Close()
@@ -11,21 +11,26 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	App          AppConfig
-	Database     DatabaseConfig
-	Redis        RedisConfig
-	JWT          JWTConfig
-	Cookie       CookieConfig
-	Log          LogConfig
-	Event        EventConfig
-	HTTP         HTTPConfig
-	Scheduler    SchedulerConfig
-	StockLock    StockLockConfig
-	Swagger      SwaggerConfig
-	Telemetry    TelemetryConfig
-	FeatureFlags FeatureFlagsConfig
-	Storage      StorageConfig
-	Stripe       StripeConfig
+	App                AppConfig
+	Database           DatabaseConfig
+	Redis              RedisConfig
+	JWT                JWTConfig
+	Cookie             CookieConfig
+	Log                LogConfig
+	Event              EventConfig
+	HTTP               HTTPConfig
+	Scheduler          SchedulerConfig
+	StockLock          StockLockConfig
+	ReceivableReminder ReceivableReminderConfig
+	SalesQuote         SalesQuoteConfig
+	WarehouseCapacity  WarehouseCapacityConfig
+	StockPush          StockPushConfig
+	OrderStatusPush    OrderStatusPushConfig
+	Swagger            SwaggerConfig
+	Telemetry          TelemetryConfig
+	FeatureFlags       FeatureFlagsConfig
+	Storage            StorageConfig
+	Stripe             StripeConfig
 }
 
 // StripeConfig holds Stripe billing configuration
@@ -78,6 +83,10 @@ type DatabaseConfig struct {
 	MaxIdleConns    int
 	ConnMaxLifetime int // in minutes
 	ConnMaxIdleTime int // in minutes
+	// TenantGuard controls the GORM tenant callbacks on tenant-owned tables (default: scope):
+	// "off" disables them, "scope" adds the request's tenant to statements that forget it,
+	// and "strict" also fails statements that run without a tenant in context.
+	TenantGuard string
 }
 
 // RedisConfig holds Redis connection settings
@@ -115,6 +124,19 @@ type EventConfig struct {
 	MaxRetries       int
 	CleanupEnabled   bool
 	CleanupRetention time.Duration
+
+	RetryBaseBackoff time.Duration // Delay before the first retry of a failed event; doubles on each further failure
+	RetryMaxBackoff  time.Duration // Upper bound on the delay between retries of a failed event
+	BusType          string        // Event bus: "memory" (this instance only) or "redis" (shared across instances)
+	BusPartitions    int           // Delivery partitions for ordered async delivery per aggregate (0 = synchronous delivery, memory bus only)
+	BusStream        string        // Redis stream events are published to (redis bus only)
+	BusConsumerGroup string        // Redis consumer group shared by all instances (redis bus only)
+
+	DeadLetterMonitorEnabled bool          // Whether to watch the dead letter queue in the background
+	DeadLetterCheckInterval  time.Duration // How often to check the dead letter queue
+	DeadLetterThreshold      int64         // Dead letter count above which the queue is red and an alert is sent
+	DeadLetterMaxAge         time.Duration // Age of the oldest dead letter above which the queue is red
+	DeadLetterWebhookURL     string        // Webhook receiving dead letter alerts (empty = log and metrics only)
 }
 
 // HTTPConfig holds HTTP server configuration
@@ -127,13 +149,21 @@ type HTTPConfig struct {
 	RateLimitEnabled      bool
 	RateLimitRequests     int
 	RateLimitWindow       time.Duration
-	AuthRateLimitEnabled  bool          // Enable stricter rate limiting for auth endpoints
-	AuthRateLimitRequests int           // Max auth attempts (default: 5)
-	AuthRateLimitWindow   time.Duration // Auth rate limit window (default: 1 minute)
+	RateLimitRoleRequests map[string]int // Requests per window by role code, for authenticated users
+	RateLimitPlanRequests map[string]int // Requests per window by tenant plan, for authenticated users
+	AuthRateLimitEnabled  bool           // Enable stricter rate limiting for auth endpoints
+	AuthRateLimitRequests int            // Max auth attempts (default: 5)
+	AuthRateLimitWindow   time.Duration  // Auth rate limit window (default: 1 minute)
 	CORSAllowOrigins      []string
 	CORSAllowMethods      []string
 	CORSAllowHeaders      []string
 	TrustedProxies        []string
+	// TenantHeavyOpsLimit caps the concurrent heavy operations (reports, exports) of one tenant (default: 2).
+	// Reports weigh one unit and exports two; requests over a tenant's limit get 429.
+	TenantHeavyOpsLimit int
+	// ReadOnly starts the service in read-only mode: API writes are rejected with 503 while reads keep working.
+	// Operators can switch it at runtime through PUT /system/read-only.
+	ReadOnly bool
 }
 
 // SchedulerConfig holds report scheduler configuration
@@ -153,6 +183,39 @@ type StockLockConfig struct {
 	AutoReleaseEnabled bool          // Whether to auto-release expired locks
 }
 
+// ReceivableReminderConfig holds receivable due-date reminder configuration
+type ReceivableReminderConfig struct {
+	Enabled       bool          // Whether to send due-date reminders
+	CheckInterval time.Duration // How often to scan for receivables due for a reminder
+	LeadTime      time.Duration // How long before the due date a due-soon reminder is sent
+}
+
+// SalesQuoteConfig holds sales quote validity and expiration configuration
+type SalesQuoteConfig struct {
+	ExpirationEnabled bool          // Whether to move quotes past their expiry to EXPIRED
+	CheckInterval     time.Duration // How often to scan for expired quotes
+	DefaultValidity   time.Duration // How long a quote stays valid when no expiry is given
+}
+
+// WarehouseCapacityConfig holds warehouse capacity alert configuration
+type WarehouseCapacityConfig struct {
+	AlertEnabled          bool    // Whether receiving stock checks the warehouse against its capacity
+	NearCapacityThreshold float64 // Utilization percentage that raises a near-capacity event
+}
+
+// StockPushConfig holds the configuration for pushing stock levels to e-commerce platforms
+type StockPushConfig struct {
+	Platforms []string      // Platform codes whose listings receive stock updates (empty = none)
+	Debounce  time.Duration // How long a product's stock must stay unchanged before it is pushed
+}
+
+// OrderStatusPushConfig holds the retry configuration for pushing order status back to e-commerce platforms
+type OrderStatusPushConfig struct {
+	RetryInterval time.Duration // How often to retry pushes the platform rejected
+	BaseBackoff   time.Duration // Delay before the first retry; doubles on each failure
+	MaxBackoff    time.Duration // Upper bound on the delay between retries
+}
+
 // SwaggerConfig holds Swagger documentation endpoint configuration
 type SwaggerConfig struct {
 	Enabled     bool     // Whether to enable Swagger endpoint
@@ -168,6 +231,9 @@ type TelemetryConfig struct {
 	ServiceName           string        // Service name for traces
 	Insecure              bool          // Use insecure (non-TLS) connection (development only)
 	MetricsExportInterval time.Duration // Metrics export interval (default: 60s)
+	// BusinessMetricsEnabled records business metrics (orders, payments, inventory adjustments).
+	// They go through the metrics pipeline, so nothing is exported while telemetry is disabled.
+	BusinessMetricsEnabled bool
 	// Logs bridge options (Zap -> OTEL)
 	LogsEnabled bool // Enable OTEL logs bridge (exports Zap logs to OTEL Collector)
 	// Database tracing options
@@ -183,9 +249,10 @@ type FeatureFlagsConfig struct {
 	// PreloadKeys are the feature flag keys to pre-evaluate for each request
 	// These flags will be available in the request context without additional evaluation
 	PreloadKeys []string
-	// CacheEnabled enables Redis caching for feature flags
+	// CacheEnabled enables the in-process cache flag evaluations read flags and overrides through
 	CacheEnabled bool
-	// CacheTTL is the time-to-live for cached flag values (default: 5m)
+	// CacheTTL is the time-to-live for cached flags and overrides (default: 5m).
+	// Changes are evicted through flag events; the TTL bounds staleness on instances that don't receive them.
 	CacheTTL time.Duration
 }
 
@@ -296,6 +363,7 @@ func Load() (*Config, error) {
 			MaxIdleConns:    v.GetInt("database.max_idle_conns"),
 			ConnMaxLifetime: v.GetInt("database.conn_max_lifetime"),
 			ConnMaxIdleTime: v.GetInt("database.conn_max_idle_time"),
+			TenantGuard:     v.GetString("database.tenant_guard"),
 		},
 		Redis: RedisConfig{
 			Host:     v.GetString("redis.host"),
@@ -330,6 +398,18 @@ func Load() (*Config, error) {
 			MaxRetries:       v.GetInt("event.max_retries"),
 			CleanupEnabled:   v.GetBool("event.cleanup_enabled"),
 			CleanupRetention: v.GetDuration("event.cleanup_retention"),
+			RetryBaseBackoff: v.GetDuration("event.retry_base_backoff"),
+			RetryMaxBackoff:  v.GetDuration("event.retry_max_backoff"),
+			BusType:          v.GetString("event.bus_type"),
+			BusPartitions:    v.GetInt("event.bus_partitions"),
+			BusStream:        v.GetString("event.bus_stream"),
+			BusConsumerGroup: v.GetString("event.bus_consumer_group"),
+
+			DeadLetterMonitorEnabled: v.GetBool("event.dead_letter_monitor_enabled"),
+			DeadLetterCheckInterval:  v.GetDuration("event.dead_letter_check_interval"),
+			DeadLetterThreshold:      v.GetInt64("event.dead_letter_threshold"),
+			DeadLetterMaxAge:         v.GetDuration("event.dead_letter_max_age"),
+			DeadLetterWebhookURL:     v.GetString("event.dead_letter_webhook_url"),
 		},
 		HTTP: HTTPConfig{
 			ReadTimeout:           v.GetDuration("http.read_timeout"),
@@ -337,9 +417,13 @@ func Load() (*Config, error) {
 			IdleTimeout:           v.GetDuration("http.idle_timeout"),
 			MaxHeaderBytes:        v.GetInt("http.max_header_bytes"),
 			MaxBodySize:           v.GetInt64("http.max_body_size"),
+			ReadOnly:              v.GetBool("http.read_only"),
+			TenantHeavyOpsLimit:   v.GetInt("http.tenant_heavy_ops_limit"),
 			RateLimitEnabled:      v.GetBool("http.rate_limit_enabled"),
 			RateLimitRequests:     v.GetInt("http.rate_limit_requests"),
 			RateLimitWindow:       v.GetDuration("http.rate_limit_window"),
+			RateLimitRoleRequests: getIntMap(v, "http.rate_limit_role_requests"),
+			RateLimitPlanRequests: getIntMap(v, "http.rate_limit_plan_requests"),
 			AuthRateLimitEnabled:  v.GetBool("http.auth_rate_limit_enabled"),
 			AuthRateLimitRequests: v.GetInt("http.auth_rate_limit_requests"),
 			AuthRateLimitWindow:   v.GetDuration("http.auth_rate_limit_window"),
@@ -361,22 +445,46 @@ func Load() (*Config, error) {
 			DefaultExpiration:  v.GetDuration("stock_lock.default_expiration"),
 			AutoReleaseEnabled: v.GetBool("stock_lock.auto_release_enabled"),
 		},
+		ReceivableReminder: ReceivableReminderConfig{
+			Enabled:       v.GetBool("receivable_reminder.enabled"),
+			CheckInterval: v.GetDuration("receivable_reminder.check_interval"),
+			LeadTime:      v.GetDuration("receivable_reminder.lead_time"),
+		},
+		SalesQuote: SalesQuoteConfig{
+			ExpirationEnabled: v.GetBool("sales_quote.expiration_enabled"),
+			CheckInterval:     v.GetDuration("sales_quote.check_interval"),
+			DefaultValidity:   v.GetDuration("sales_quote.default_validity"),
+		},
+		WarehouseCapacity: WarehouseCapacityConfig{
+			AlertEnabled:          v.GetBool("warehouse_capacity.alert_enabled"),
+			NearCapacityThreshold: v.GetFloat64("warehouse_capacity.near_capacity_threshold"),
+		},
+		StockPush: StockPushConfig{
+			Platforms: v.GetStringSlice("stock_push.platforms"),
+			Debounce:  v.GetDuration("stock_push.debounce"),
+		},
+		OrderStatusPush: OrderStatusPushConfig{
+			RetryInterval: v.GetDuration("order_status_push.retry_interval"),
+			BaseBackoff:   v.GetDuration("order_status_push.base_backoff"),
+			MaxBackoff:    v.GetDuration("order_status_push.max_backoff"),
+		},
 		Swagger: SwaggerConfig{
 			Enabled:     v.GetBool("swagger.enabled"),
 			RequireAuth: v.GetBool("swagger.require_auth"),
 			AllowedIPs:  v.GetStringSlice("swagger.allowed_ips"),
 		},
 		Telemetry: TelemetryConfig{
-			Enabled:               v.GetBool("telemetry.enabled"),
-			CollectorEndpoint:     v.GetString("telemetry.collector_endpoint"),
-			SamplingRatio:         v.GetFloat64("telemetry.sampling_ratio"),
-			ServiceName:           v.GetString("telemetry.service_name"),
-			Insecure:              v.GetBool("telemetry.insecure"),
-			MetricsExportInterval: v.GetDuration("telemetry.metrics_export_interval"),
-			LogsEnabled:           v.GetBool("telemetry.logs_enabled"),
-			DBTraceEnabled:        v.GetBool("telemetry.db_trace_enabled"),
-			DBLogFullSQL:          v.GetBool("telemetry.db_log_full_sql"),
-			DBSlowQueryThresh:     v.GetDuration("telemetry.db_slow_query_threshold"),
+			Enabled:                v.GetBool("telemetry.enabled"),
+			CollectorEndpoint:      v.GetString("telemetry.collector_endpoint"),
+			SamplingRatio:          v.GetFloat64("telemetry.sampling_ratio"),
+			ServiceName:            v.GetString("telemetry.service_name"),
+			Insecure:               v.GetBool("telemetry.insecure"),
+			MetricsExportInterval:  v.GetDuration("telemetry.metrics_export_interval"),
+			BusinessMetricsEnabled: v.GetBool("telemetry.business_metrics_enabled"),
+			LogsEnabled:            v.GetBool("telemetry.logs_enabled"),
+			DBTraceEnabled:         v.GetBool("telemetry.db_trace_enabled"),
+			DBLogFullSQL:           v.GetBool("telemetry.db_log_full_sql"),
+			DBSlowQueryThresh:      v.GetDuration("telemetry.db_slow_query_threshold"),
 			Profiling: ProfilingConfig{
 				Enabled:              v.GetBool("telemetry.profiling.enabled"),
 				ServerAddress:        v.GetString("telemetry.profiling.server_address"),
@@ -441,6 +549,15 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// getIntMap reads a table of integers, e.g. [http.rate_limit_role_requests]
+func getIntMap(v *viper.Viper, key string) map[string]int {
+	result := make(map[string]int)
+	for name := range v.GetStringMap(key) {
+		result[name] = v.GetInt(key + "." + name)
+	}
+	return result
+}
+
 // applyDefaults sets default values for any empty config fields
 func applyDefaults(cfg *Config) {
 	if cfg.App.Name == "" {
@@ -479,6 +596,9 @@ func applyDefaults(cfg *Config) {
 	if cfg.Database.ConnMaxIdleTime == 0 {
 		cfg.Database.ConnMaxIdleTime = 30
 	}
+	if cfg.Database.TenantGuard == "" {
+		cfg.Database.TenantGuard = "scope"
+	}
 	if cfg.Redis.Host == "" {
 		cfg.Redis.Host = "localhost"
 	}
@@ -525,6 +645,30 @@ func applyDefaults(cfg *Config) {
 	if cfg.Event.CleanupRetention == 0 {
 		cfg.Event.CleanupRetention = 168 * time.Hour
 	}
+	if cfg.Event.RetryBaseBackoff == 0 {
+		cfg.Event.RetryBaseBackoff = time.Second
+	}
+	if cfg.Event.RetryMaxBackoff == 0 {
+		cfg.Event.RetryMaxBackoff = 5 * time.Minute
+	}
+	if cfg.Event.BusType == "" {
+		cfg.Event.BusType = "memory"
+	}
+	if cfg.Event.BusStream == "" {
+		cfg.Event.BusStream = "erp:events"
+	}
+	if cfg.Event.BusConsumerGroup == "" {
+		cfg.Event.BusConsumerGroup = "erp-backend"
+	}
+	if cfg.Event.DeadLetterCheckInterval == 0 {
+		cfg.Event.DeadLetterCheckInterval = 5 * time.Minute
+	}
+	if cfg.Event.DeadLetterThreshold == 0 {
+		cfg.Event.DeadLetterThreshold = 10
+	}
+	if cfg.Event.DeadLetterMaxAge == 0 {
+		cfg.Event.DeadLetterMaxAge = 24 * time.Hour
+	}
 	if cfg.HTTP.ReadTimeout == 0 {
 		cfg.HTTP.ReadTimeout = 15 * time.Second
 	}
@@ -553,6 +697,9 @@ func applyDefaults(cfg *Config) {
 	if cfg.HTTP.AuthRateLimitWindow == 0 {
 		cfg.HTTP.AuthRateLimitWindow = time.Minute // 1 minute window
 	}
+	if cfg.HTTP.TenantHeavyOpsLimit == 0 {
+		cfg.HTTP.TenantHeavyOpsLimit = 2
+	}
 	// NOTE: CORS origins are intentionally not given a default fallback to "*".
 	// An empty list means no cross-origin requests are allowed until explicitly configured.
 	// This is a secure default - applications MUST configure allowed origins explicitly.
@@ -585,6 +732,38 @@ func applyDefaults(cfg *Config) {
 	if cfg.StockLock.DefaultExpiration == 0 {
 		cfg.StockLock.DefaultExpiration = 24 * time.Hour // 24h as per spec
 	}
+	// ReceivableReminder defaults
+	if cfg.ReceivableReminder.CheckInterval == 0 {
+		cfg.ReceivableReminder.CheckInterval = 24 * time.Hour
+	}
+	if cfg.ReceivableReminder.LeadTime == 0 {
+		cfg.ReceivableReminder.LeadTime = 72 * time.Hour
+	}
+	// SalesQuote defaults
+	if cfg.SalesQuote.CheckInterval == 0 {
+		cfg.SalesQuote.CheckInterval = time.Hour
+	}
+	if cfg.SalesQuote.DefaultValidity == 0 {
+		cfg.SalesQuote.DefaultValidity = 30 * 24 * time.Hour
+	}
+	// WarehouseCapacity defaults
+	if cfg.WarehouseCapacity.NearCapacityThreshold == 0 {
+		cfg.WarehouseCapacity.NearCapacityThreshold = 90
+	}
+	// StockPush defaults
+	if cfg.StockPush.Debounce == 0 {
+		cfg.StockPush.Debounce = 5 * time.Second
+	}
+	// OrderStatusPush defaults
+	if cfg.OrderStatusPush.RetryInterval == 0 {
+		cfg.OrderStatusPush.RetryInterval = time.Minute
+	}
+	if cfg.OrderStatusPush.BaseBackoff == 0 {
+		cfg.OrderStatusPush.BaseBackoff = time.Minute
+	}
+	if cfg.OrderStatusPush.MaxBackoff == 0 {
+		cfg.OrderStatusPush.MaxBackoff = time.Hour
+	}
 	// Swagger defaults: enabled by default (will be overridden by validation in production)
 	// Note: We set enabled=true here, but production validation enforces proper configuration
 
@@ -700,6 +879,14 @@ func (c *Config) validate() error {
 		return fmt.Errorf("database.max_idle_conns (%d) cannot exceed database.max_open_conns (%d)",
 			c.Database.MaxIdleConns, c.Database.MaxOpenConns)
 	}
+	switch c.Database.TenantGuard {
+	case "off", "scope", "strict":
+	default:
+		return fmt.Errorf("database.tenant_guard must be one of off, scope or strict, got %q", c.Database.TenantGuard)
+	}
+	if c.HTTP.TenantHeavyOpsLimit < 0 {
+		return fmt.Errorf("http.tenant_heavy_ops_limit cannot be negative")
+	}
 
 	// Production-specific validations
 	if c.App.Env == "production" {
@@ -753,6 +940,16 @@ func (c *Config) validate() error {
 		return fmt.Errorf("telemetry.metrics_export_interval cannot be negative")
 	}
 
+	// Validate warehouse capacity alerts
+	if c.WarehouseCapacity.NearCapacityThreshold <= 0 || c.WarehouseCapacity.NearCapacityThreshold > 100 {
+		return fmt.Errorf("warehouse_capacity.near_capacity_threshold must be between 0 and 100, got %f", c.WarehouseCapacity.NearCapacityThreshold)
+	}
+
+	// Validate event bus selection
+	if c.Event.BusType != "memory" && c.Event.BusType != "redis" {
+		return fmt.Errorf("event.bus_type must be 'memory' or 'redis', got %q", c.Event.BusType)
+	}
+
 	return nil
 }
 
@@ -185,46 +185,52 @@ func (a *DouyinAdapter) GetProduct(ctx context.Context, tenantID uuid.UUID, plat
 		return nil, integration.ErrProductSyncMappingNotFound
 	}
 
-	item := resp.Data.Product
-	product := &integration.ProductSync{
-		PlatformProductID: strconv.FormatInt(item.ProductID, 10),
-		ProductCode:       item.OutProductID,
-		ProductName:       item.Name,
-		Description:       item.Description,
-		// Price in cents, convert to yuan
-		Price:         decimal.NewFromInt(item.DiscountPrice).Div(decimal.NewFromInt(centsPerYuan)),
-		OriginalPrice: decimal.NewFromInt(item.MarketPrice).Div(decimal.NewFromInt(centsPerYuan)),
-		IsOnSale:      item.Status == 0, // 0 = online, 1 = offline
-		SKUs:          make([]integration.ProductSkuSync, 0),
+	return convertDouyinProductToProductSync(resp.Data.Product), nil
+}
+
+// ListProducts lists the shop's products on Douyin
+func (a *DouyinAdapter) ListProducts(ctx context.Context, req *integration.ProductListRequest) (*integration.ProductListResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
-	// Main image
-	if item.Img != "" {
-		product.ImageURLs = append(product.ImageURLs, item.Img)
+	config, err := a.getTenantConfig(req.TenantID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse SKUs
-	var totalQuantity int64
-	for _, sku := range item.SkuList {
-		totalQuantity += sku.StockNum
+	params := map[string]any{
+		"page": req.PageNo,
+		"size": req.PageSize,
+	}
 
-		// Build SKU name from spec details
-		var specNames []string
-		for _, spec := range sku.SpecDetail {
-			specNames = append(specNames, fmt.Sprintf("%s:%s", spec.SpecName, spec.ValueName))
-		}
+	respBody, err := a.doRequest(ctx, config, "/product/listV2", params)
+	if err != nil {
+		return nil, err
+	}
 
-		product.SKUs = append(product.SKUs, integration.ProductSkuSync{
-			PlatformSkuID: strconv.FormatInt(sku.SkuID, 10),
-			SkuCode:       sku.OutSkuID,
-			SkuName:       strings.Join(specNames, ";"),
-			Price:         decimal.NewFromInt(sku.Price).Div(decimal.NewFromInt(centsPerYuan)),
-			Quantity:      decimal.NewFromInt(sku.StockNum),
-		})
+	var resp DouyinProductListResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("douyin: failed to parse response: %w", err)
 	}
-	product.Quantity = decimal.NewFromInt(totalQuantity)
 
-	return product, nil
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("douyin: %d - %s", resp.ErrNo, resp.Message)
+	}
+
+	response := &integration.ProductListResponse{
+		Products:   make([]integration.ProductSync, 0),
+		NextPageNo: req.PageNo + 1,
+	}
+	if resp.Data != nil {
+		response.TotalCount = resp.Data.Total
+		for i := range resp.Data.Data {
+			response.Products = append(response.Products, *convertDouyinProductToProductSync(&resp.Data.Data[i]))
+		}
+	}
+	response.HasMore = int64(req.PageNo*req.PageSize) < response.TotalCount
+
+	return response, nil
 }
 
 // ---------------------------------------------------------------------------
@@ -423,6 +429,19 @@ func (a *DouyinAdapter) SyncInventory(ctx context.Context, tenantID uuid.UUID, i
 	return result, nil
 }
 
+// UpdateStock sets the stock of a Douyin product. Douyin keeps stock per SKU,
+// so the product ID is used as the SKU ID, as it is for single-SKU products.
+func (a *DouyinAdapter) UpdateStock(ctx context.Context, tenantID uuid.UUID, platformProductID string, quantity decimal.Decimal) error {
+	config, err := a.getTenantConfig(tenantID)
+	if err != nil {
+		return err
+	}
+	return a.updateSkuStock(ctx, config, &integration.InventorySync{
+		PlatformProductID: platformProductID,
+		AvailableQuantity: quantity,
+	})
+}
+
 // updateSkuStock updates SKU stock on Douyin
 func (a *DouyinAdapter) updateSkuStock(ctx context.Context, config *DouyinConfig, item *integration.InventorySync) error {
 	skuID, err := strconv.ParseInt(item.PlatformSkuID, 10, 64)
@@ -606,6 +625,49 @@ func (a *DouyinAdapter) convertDouyinOrderToPlatformOrder(order *DouyinOrder) in
 	return platformOrder
 }
 
+// convertDouyinProductToProductSync converts a Douyin product to the domain ProductSync
+func convertDouyinProductToProductSync(item *DouyinProduct) *integration.ProductSync {
+	product := &integration.ProductSync{
+		PlatformProductID: strconv.FormatInt(item.ProductID, 10),
+		ProductCode:       item.OutProductID,
+		ProductName:       item.Name,
+		Description:       item.Description,
+		// Price in cents, convert to yuan
+		Price:         decimal.NewFromInt(item.DiscountPrice).Div(decimal.NewFromInt(centsPerYuan)),
+		OriginalPrice: decimal.NewFromInt(item.MarketPrice).Div(decimal.NewFromInt(centsPerYuan)),
+		IsOnSale:      item.Status == 0, // 0 = online, 1 = offline
+		SKUs:          make([]integration.ProductSkuSync, 0),
+	}
+
+	// Main image
+	if item.Img != "" {
+		product.ImageURLs = append(product.ImageURLs, item.Img)
+	}
+
+	// Parse SKUs
+	var totalQuantity int64
+	for _, sku := range item.SkuList {
+		totalQuantity += sku.StockNum
+
+		// Build SKU name from spec details
+		var specNames []string
+		for _, spec := range sku.SpecDetail {
+			specNames = append(specNames, fmt.Sprintf("%s:%s", spec.SpecName, spec.ValueName))
+		}
+
+		product.SKUs = append(product.SKUs, integration.ProductSkuSync{
+			PlatformSkuID: strconv.FormatInt(sku.SkuID, 10),
+			SkuCode:       sku.OutSkuID,
+			SkuName:       strings.Join(specNames, ";"),
+			Price:         decimal.NewFromInt(sku.Price).Div(decimal.NewFromInt(centsPerYuan)),
+			Quantity:      decimal.NewFromInt(sku.StockNum),
+		})
+	}
+	product.Quantity = decimal.NewFromInt(totalQuantity)
+
+	return product
+}
+
 // ---------------------------------------------------------------------------
 // Status Mapping
 // ---------------------------------------------------------------------------
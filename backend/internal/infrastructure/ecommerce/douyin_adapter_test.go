@@ -545,6 +545,34 @@ func TestDouyinAdapter_SyncInventory(t *testing.T) {
 // Product Operations Tests
 // ---------------------------------------------------------------------------
 
+func TestDouyinAdapter_UpdateStock(t *testing.T) {
+	tenantID := uuid.New()
+
+	var path string
+	var params map[string]any
+	server := createMockDouyinServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		paramJSON, _ := body["param_json"].(string)
+		require.NoError(t, json.Unmarshal([]byte(paramJSON), &params))
+		resp := DouyinStockUpdateResponse{
+			DouyinResponse: DouyinResponse{ErrNo: 0, Message: "success"},
+			Data:           &DouyinStockUpdateData{Success: true},
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	adapter := createTestDouyinAdapterWithServer(t, server.URL, tenantID)
+
+	err := adapter.UpdateStock(context.Background(), tenantID, "123456", decimal.NewFromInt(42))
+	require.NoError(t, err)
+	assert.Equal(t, "/sku/stockNum", path)
+	assert.EqualValues(t, 123456, params["sku_id"])
+	assert.EqualValues(t, 42, params["stock_num"])
+}
+
 func TestDouyinAdapter_GetProduct(t *testing.T) {
 	tenantID := uuid.New()
 
@@ -640,6 +668,50 @@ func TestDouyinAdapter_GetProduct(t *testing.T) {
 	})
 }
 
+func TestDouyinAdapter_ListProducts(t *testing.T) {
+	tenantID := uuid.New()
+
+	server := createMockDouyinServer(t, func(w http.ResponseWriter, r *http.Request) {
+		resp := DouyinProductListResponse{
+			DouyinResponse: DouyinResponse{
+				ErrNo:   0,
+				Message: "success",
+			},
+			Data: &DouyinProductListData{
+				Total: 1,
+				Data: []DouyinProduct{
+					{
+						ProductID:     123456,
+						OutProductID:  "SKU001",
+						Name:          "测试商品",
+						DiscountPrice: 9900,
+						SkuList: []DouyinSku{
+							{SkuID: 789, OutSkuID: "SKU001-RED", Price: 9900, StockNum: 50},
+						},
+					},
+				},
+			},
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	adapter := createTestDouyinAdapterWithServer(t, server.URL, tenantID)
+
+	resp, err := adapter.ListProducts(context.Background(), &integration.ProductListRequest{
+		TenantID:     tenantID,
+		PlatformCode: integration.PlatformCodeDouyin,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 1)
+	assert.Equal(t, "123456", resp.Products[0].PlatformProductID)
+	assert.Equal(t, "SKU001", resp.Products[0].ProductCode)
+	require.Len(t, resp.Products[0].SKUs, 1)
+	assert.Equal(t, "SKU001-RED", resp.Products[0].SKUs[0].SkuCode)
+	assert.Equal(t, int64(1), resp.TotalCount)
+	assert.False(t, resp.HasMore)
+}
+
 func TestDouyinAdapter_SyncProducts(t *testing.T) {
 	tenantID := uuid.New()
 
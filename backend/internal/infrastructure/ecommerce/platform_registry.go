@@ -0,0 +1,87 @@
+package ecommerce
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/google/uuid"
+)
+
+// PlatformRegistry is an in-memory EcommercePlatformRegistry holding the
+// platform adapters configured at startup
+type PlatformRegistry struct {
+	mu        sync.RWMutex
+	platforms map[integration.PlatformCode]integration.EcommercePlatform
+}
+
+// NewPlatformRegistry creates a registry with the given platform adapters
+func NewPlatformRegistry(platforms ...integration.EcommercePlatform) *PlatformRegistry {
+	r := &PlatformRegistry{
+		platforms: make(map[integration.PlatformCode]integration.EcommercePlatform),
+	}
+	for _, platform := range platforms {
+		r.Register(platform)
+	}
+	return r
+}
+
+// Register adds a platform adapter, replacing any adapter for the same platform
+func (r *PlatformRegistry) Register(platform integration.EcommercePlatform) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.platforms[platform.PlatformCode()] = platform
+}
+
+// GetPlatform returns the platform adapter for the specified code
+func (r *PlatformRegistry) GetPlatform(platformCode integration.PlatformCode) (integration.EcommercePlatform, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	platform, ok := r.platforms[platformCode]
+	if !ok {
+		return nil, integration.ErrPlatformNotConfigured
+	}
+	return platform, nil
+}
+
+// ListPlatforms returns all registered platform adapters ordered by platform code
+func (r *PlatformRegistry) ListPlatforms() []integration.EcommercePlatform {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	platforms := make([]integration.EcommercePlatform, 0, len(r.platforms))
+	for _, platform := range r.platforms {
+		platforms = append(platforms, platform)
+	}
+	sort.Slice(platforms, func(i, j int) bool {
+		return platforms[i].PlatformCode() < platforms[j].PlatformCode()
+	})
+	return platforms
+}
+
+// ListEnabledPlatforms returns all enabled platforms for a tenant
+func (r *PlatformRegistry) ListEnabledPlatforms(ctx context.Context, tenantID uuid.UUID) ([]integration.EcommercePlatform, error) {
+	var enabled []integration.EcommercePlatform
+	for _, platform := range r.ListPlatforms() {
+		ok, err := platform.IsEnabled(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			enabled = append(enabled, platform)
+		}
+	}
+	return enabled, nil
+}
+
+// IsEnabled returns true if the platform is registered and enabled for the tenant
+func (r *PlatformRegistry) IsEnabled(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (bool, error) {
+	platform, err := r.GetPlatform(platformCode)
+	if err != nil {
+		return false, nil
+	}
+	return platform.IsEnabled(ctx, tenantID)
+}
+
+// Ensure PlatformRegistry implements EcommercePlatformRegistry
+var _ integration.EcommercePlatformRegistry = (*PlatformRegistry)(nil)
@@ -187,7 +187,7 @@ func (a *TaobaoAdapter) GetProduct(ctx context.Context, tenantID uuid.UUID, plat
 	params := map[string]string{
 		"method":  "taobao.item.get",
 		"num_iid": platformProductID,
-		"fields":  "num_iid,title,nick,type,cid,num,price,desc,item_imgs,skus,outer_id",
+		"fields":  "num_iid,title,nick,type,cid,num,price,desc,item_imgs,skus,outer_id,barcode",
 	}
 
 	respBody, err := a.doRequest(ctx, config, params)
@@ -208,39 +208,59 @@ func (a *TaobaoAdapter) GetProduct(ctx context.Context, tenantID uuid.UUID, plat
 		return nil, integration.ErrProductSyncMappingNotFound
 	}
 
-	item := resp.ItemGetResponse.Item
-	product := &integration.ProductSync{
-		PlatformProductID: strconv.FormatInt(item.NumIid, 10),
-		ProductCode:       item.OuterId,
-		ProductName:       item.Title,
-		Description:       item.Desc,
-		Price:             ParseDecimal(item.Price),
-		Quantity:          decimal.NewFromInt(item.Num),
-		IsOnSale:          item.ApproveStatus == "onsale",
-		SKUs:              make([]integration.ProductSkuSync, 0),
+	return convertTaobaoItemToProductSync(resp.ItemGetResponse.Item), nil
+}
+
+// ListProducts lists the seller's on-sale items on Taobao.
+// The list API does not return SKUs; use GetProduct for SKU details.
+func (a *TaobaoAdapter) ListProducts(ctx context.Context, req *integration.ProductListRequest) (*integration.ProductListResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
-	// Parse images
-	if item.ItemImg != nil {
-		for _, img := range item.ItemImg.ItemImg {
-			product.ImageURLs = append(product.ImageURLs, img.URL)
-		}
+	config, err := a.getTenantConfig(req.TenantID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse SKUs
-	if item.Skus != nil {
-		for _, sku := range item.Skus.Sku {
-			product.SKUs = append(product.SKUs, integration.ProductSkuSync{
-				PlatformSkuID: strconv.FormatInt(sku.SkuID, 10),
-				SkuCode:       sku.OuterId,
-				SkuName:       sku.PropertiesName,
-				Price:         ParseDecimal(sku.Price),
-				Quantity:      decimal.NewFromInt(sku.Quantity),
-			})
+	params := map[string]string{
+		"method":    "taobao.items.onsale.get",
+		"fields":    "num_iid,title,num,price,outer_id,barcode,approve_status",
+		"page_no":   strconv.Itoa(req.PageNo),
+		"page_size": strconv.Itoa(req.PageSize),
+	}
+
+	respBody, err := a.doRequest(ctx, config, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp TaobaoItemsOnsaleGetResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse response: %v", integration.ErrPlatformInvalidResponse, err)
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("%w: %s - %s", integration.ErrPlatformRequestFailed, resp.ErrorResponse.Code, resp.ErrorResponse.Msg)
+	}
+
+	if resp.ItemsOnsaleGetResponse == nil {
+		return nil, integration.ErrPlatformInvalidResponse
+	}
+
+	response := &integration.ProductListResponse{
+		Products:   make([]integration.ProductSync, 0),
+		TotalCount: resp.ItemsOnsaleGetResponse.TotalResults,
+		NextPageNo: req.PageNo + 1,
+	}
+	if resp.ItemsOnsaleGetResponse.Items != nil {
+		for i := range resp.ItemsOnsaleGetResponse.Items.Item {
+			response.Products = append(response.Products, *convertTaobaoItemToProductSync(&resp.ItemsOnsaleGetResponse.Items.Item[i]))
 		}
 	}
+	response.HasMore = int64(req.PageNo*req.PageSize) < response.TotalCount
 
-	return product, nil
+	return response, nil
 }
 
 // ---------------------------------------------------------------------------
@@ -459,6 +479,15 @@ func (a *TaobaoAdapter) SyncInventory(ctx context.Context, tenantID uuid.UUID, i
 	return result, nil
 }
 
+// UpdateStock sets the item-level quantity of a Taobao item
+func (a *TaobaoAdapter) UpdateStock(ctx context.Context, tenantID uuid.UUID, platformProductID string, quantity decimal.Decimal) error {
+	config, err := a.getTenantConfig(tenantID)
+	if err != nil {
+		return err
+	}
+	return a.updateItemQuantity(ctx, config, platformProductID, quantity)
+}
+
 // updateItemQuantity updates item-level inventory on Taobao
 func (a *TaobaoAdapter) updateItemQuantity(ctx context.Context, config *TaobaoConfig, numIid string, quantity decimal.Decimal) error {
 	params := map[string]string{
@@ -644,6 +673,44 @@ func (a *TaobaoAdapter) convertTaobaoTradeToPlatformOrder(trade *TaobaoTrade) in
 	return order
 }
 
+// convertTaobaoItemToProductSync converts a Taobao item to the domain ProductSync
+func convertTaobaoItemToProductSync(item *TaobaoFullItem) *integration.ProductSync {
+	product := &integration.ProductSync{
+		PlatformProductID: strconv.FormatInt(item.NumIid, 10),
+		ProductCode:       item.OuterId,
+		ProductName:       item.Title,
+		Barcode:           item.Barcode,
+		Description:       item.Desc,
+		Price:             ParseDecimal(item.Price),
+		Quantity:          decimal.NewFromInt(item.Num),
+		IsOnSale:          item.ApproveStatus == "onsale",
+		SKUs:              make([]integration.ProductSkuSync, 0),
+	}
+
+	// Parse images
+	if item.ItemImg != nil {
+		for _, img := range item.ItemImg.ItemImg {
+			product.ImageURLs = append(product.ImageURLs, img.URL)
+		}
+	}
+
+	// Parse SKUs
+	if item.Skus != nil {
+		for _, sku := range item.Skus.Sku {
+			product.SKUs = append(product.SKUs, integration.ProductSkuSync{
+				PlatformSkuID: strconv.FormatInt(sku.SkuID, 10),
+				SkuCode:       sku.OuterId,
+				SkuName:       sku.PropertiesName,
+				Barcode:       sku.Barcode,
+				Price:         ParseDecimal(sku.Price),
+				Quantity:      decimal.NewFromInt(sku.Quantity),
+			})
+		}
+	}
+
+	return product
+}
+
 // ---------------------------------------------------------------------------
 // Status Mapping
 // ---------------------------------------------------------------------------
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -533,6 +534,31 @@ func TestTaobaoAdapter_SyncInventory(t *testing.T) {
 // Product Operations Tests
 // ---------------------------------------------------------------------------
 
+func TestTaobaoAdapter_UpdateStock(t *testing.T) {
+	tenantID := uuid.New()
+
+	var params url.Values
+	server := createMockTaobaoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		params = r.PostForm
+		resp := TaobaoItemQuantityUpdateResponse{
+			ItemQuantityUpdateResponse: &ItemQuantityUpdateResponse{
+				Item: &TaobaoItem{NumIid: 123456, Num: 42},
+			},
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	adapter := createTestAdapterWithServer(t, server.URL, tenantID)
+
+	err := adapter.UpdateStock(context.Background(), tenantID, "123456", decimal.NewFromInt(42))
+	require.NoError(t, err)
+	assert.Equal(t, "taobao.item.quantity.update", params.Get("method"))
+	assert.Equal(t, "123456", params.Get("num_iid"))
+	assert.Equal(t, "42", params.Get("quantity"))
+}
+
 func TestTaobaoAdapter_GetProduct(t *testing.T) {
 	tenantID := uuid.New()
 
@@ -616,6 +642,43 @@ func TestTaobaoAdapter_GetProduct(t *testing.T) {
 	})
 }
 
+func TestTaobaoAdapter_ListProducts(t *testing.T) {
+	tenantID := uuid.New()
+
+	server := createMockTaobaoServer(t, func(w http.ResponseWriter, r *http.Request) {
+		resp := TaobaoItemsOnsaleGetResponse{
+			ItemsOnsaleGetResponse: &ItemsOnsaleGetResponse{
+				TotalResults: 3,
+				Items: &TaobaoFullItems{
+					Item: []TaobaoFullItem{
+						{NumIid: 123456, Title: "测试商品", Price: "99.00", OuterId: "SKU001", Barcode: "6901234567892", ApproveStatus: "onsale"},
+						{NumIid: 123457, Title: "测试商品2", Price: "59.00", OuterId: "SKU002", ApproveStatus: "onsale"},
+					},
+				},
+			},
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	adapter := createTestAdapterWithServer(t, server.URL, tenantID)
+
+	resp, err := adapter.ListProducts(context.Background(), &integration.ProductListRequest{
+		TenantID:     tenantID,
+		PlatformCode: integration.PlatformCodeTaobao,
+		PageNo:       1,
+		PageSize:     2,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 2)
+	assert.Equal(t, "123456", resp.Products[0].PlatformProductID)
+	assert.Equal(t, "SKU001", resp.Products[0].ProductCode)
+	assert.Equal(t, "6901234567892", resp.Products[0].Barcode)
+	assert.Equal(t, int64(3), resp.TotalCount)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, 2, resp.NextPageNo)
+}
+
 func TestTaobaoAdapter_SyncProducts(t *testing.T) {
 	tenantID := uuid.New()
 
@@ -277,6 +277,24 @@ type ItemGetResponse struct {
 	RequestID string          `json:"request_id"`
 }
 
+// TaobaoItemsOnsaleGetResponse is the response for taobao.items.onsale.get API
+type TaobaoItemsOnsaleGetResponse struct {
+	TaobaoResponse
+	ItemsOnsaleGetResponse *ItemsOnsaleGetResponse `json:"items_onsale_get_response,omitempty"`
+}
+
+// ItemsOnsaleGetResponse contains the seller's on-sale items
+type ItemsOnsaleGetResponse struct {
+	TotalResults int64            `json:"total_results"`
+	Items        *TaobaoFullItems `json:"items,omitempty"`
+	RequestID    string           `json:"request_id"`
+}
+
+// TaobaoFullItems is a wrapper for item list
+type TaobaoFullItems struct {
+	Item []TaobaoFullItem `json:"item"`
+}
+
 // TaobaoFullItem represents full product details
 type TaobaoFullItem struct {
 	NumIid        int64       `json:"num_iid"`
@@ -305,6 +323,7 @@ type TaobaoFullItem struct {
 	PropImgs      *PropImgs   `json:"prop_imgs,omitempty"`
 	Skus          *TaobaoSkus `json:"skus,omitempty"`
 	OuterId       string      `json:"outer_id,omitempty"`
+	Barcode       string      `json:"barcode,omitempty"`
 	IsVirtual     bool        `json:"is_virtual,omitempty"`
 }
 
@@ -346,6 +365,7 @@ type TaobaoFullSku struct {
 	Quantity       int64  `json:"quantity,omitempty"`
 	Price          string `json:"price,omitempty"`
 	OuterId        string `json:"outer_id,omitempty"`
+	Barcode        string `json:"barcode,omitempty"`
 	Status         string `json:"status,omitempty"`
 	Created        string `json:"created,omitempty"`
 	Modified       string `json:"modified,omitempty"`
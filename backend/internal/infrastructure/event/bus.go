@@ -2,48 +2,145 @@ package event
 
 import (
 	"context"
+	"hash/fnv"
 	"sync"
 	"sync/atomic"
 
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/logger"
+	"github.com/erp/backend/internal/infrastructure/persistence/tenant"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// InMemoryEventBus implements EventBus with in-memory pub/sub
+// defaultPartitionQueueSize is the number of events buffered per delivery partition
+// before Publish blocks
+const defaultPartitionQueueSize = 1024
+
+// InMemoryEventBus implements EventBus with in-memory pub/sub.
+//
+// By default events are delivered synchronously within Publish. With
+// WithOrderedDelivery, events are instead queued to hash-partitioned workers
+// keyed on aggregate ID: events for one aggregate are handled in publish order,
+// while events for different aggregates are handled in parallel.
 type InMemoryEventBus struct {
 	registry *HandlerRegistry
 	logger   *zap.Logger
 	running  atomic.Bool
 	wg       sync.WaitGroup
+
+	partitionCount int
+	partitionsMu   sync.RWMutex
+	partitions     []chan queuedEvent
+	// stopping is closed by Stop to release publishers waiting on a full partition
+	stopping chan struct{}
+	// sending tracks publishers that may still send to the partitions
+	sending *sync.WaitGroup
+}
+
+// queuedEvent is an event waiting in a delivery partition
+type queuedEvent struct {
+	ctx   context.Context
+	event shared.DomainEvent
+}
+
+// InMemoryEventBusOption configures an InMemoryEventBus
+type InMemoryEventBusOption func(*InMemoryEventBus)
+
+// WithOrderedDelivery delivers events asynchronously on the given number of
+// partitions, serializing delivery per aggregate ID. Events published before
+// Start or after Stop are delivered synchronously.
+func WithOrderedDelivery(partitions int) InMemoryEventBusOption {
+	return func(b *InMemoryEventBus) {
+		if partitions > 0 {
+			b.partitionCount = partitions
+		}
+	}
 }
 
 // NewInMemoryEventBus creates a new in-memory event bus
-func NewInMemoryEventBus(logger *zap.Logger) *InMemoryEventBus {
-	return &InMemoryEventBus{
+func NewInMemoryEventBus(logger *zap.Logger, opts ...InMemoryEventBusOption) *InMemoryEventBus {
+	b := &InMemoryEventBus{
 		registry: NewHandlerRegistry(),
 		logger:   logger,
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
-// Publish publishes events to all registered handlers synchronously
+// Publish publishes events to all registered handlers, synchronously unless
+// ordered delivery is enabled and the bus is running
 func (b *InMemoryEventBus) Publish(ctx context.Context, events ...shared.DomainEvent) error {
+	// The lock is only held to take the partitions: a publisher waiting on a full
+	// partition must not hold up Stop, or handlers that publish while it waits
+	b.partitionsMu.RLock()
+	partitions, stopping, sending := b.partitions, b.stopping, b.sending
+	if partitions != nil {
+		sending.Add(1)
+	}
+	b.partitionsMu.RUnlock()
+
+	if partitions == nil {
+		for _, event := range events {
+			b.deliver(ctx, event)
+		}
+		return nil
+	}
+	defer sending.Done()
+
+	// Handlers run after the publisher returns, so they must not be cancelled with it
+	queuedCtx := context.WithoutCancel(ctx)
 	for _, event := range events {
-		handlers := b.registry.GetHandlers(event.EventType())
-
-		for _, handler := range handlers {
-			if err := b.dispatchToHandler(ctx, handler, event); err != nil {
-				// Log error but continue with other handlers
-				b.logger.Error("handler failed to process event",
-					zap.String("event_type", event.EventType()),
-					zap.String("event_id", event.EventID().String()),
-					zap.Error(err),
-				)
-			}
+		select {
+		case partitions[b.partitionFor(event.AggregateID())] <- queuedEvent{ctx: queuedCtx, event: event}:
+		case <-stopping:
+			// The bus stopped while the partition was full: deliver like a stopped bus
+			b.deliver(ctx, event)
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
 	return nil
 }
 
+// deliver dispatches an event to each of its handlers.
+// Events published from background work or read from the stream carry no
+// request tenant; handlers then query as the event's tenant.
+func (b *InMemoryEventBus) deliver(ctx context.Context, event shared.DomainEvent) {
+	if logger.GetTenantID(ctx) == "" && event.TenantID() != uuid.Nil {
+		ctx = tenant.WithTenant(ctx, event.TenantID())
+	}
+	handlers := b.registry.GetHandlers(event.EventType())
+
+	for _, handler := range handlers {
+		if err := b.dispatchToHandler(ctx, handler, event); err != nil {
+			// Log error but continue with other handlers
+			b.logger.Error("handler failed to process event",
+				zap.String("event_type", event.EventType()),
+				zap.String("event_id", event.EventID().String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// partitionFor returns the delivery partition of an aggregate
+func (b *InMemoryEventBus) partitionFor(aggregateID uuid.UUID) int {
+	h := fnv.New32a()
+	_, _ = h.Write(aggregateID[:])
+	return int(h.Sum32() % uint32(b.partitionCount))
+}
+
+// runPartition delivers the events of one partition in order until it is closed
+func (b *InMemoryEventBus) runPartition(queue <-chan queuedEvent) {
+	defer b.wg.Done()
+	for queued := range queue {
+		b.deliver(queued.ctx, queued.event)
+	}
+}
+
 // Subscribe registers a handler for specific event types
 func (b *InMemoryEventBus) Subscribe(handler shared.EventHandler, eventTypes ...string) {
 	// If handler specifies its own event types, use those
@@ -64,17 +161,58 @@ func (b *InMemoryEventBus) Unsubscribe(handler shared.EventHandler) {
 
 // Start starts the event bus
 func (b *InMemoryEventBus) Start(ctx context.Context) error {
+	if b.partitionCount > 0 {
+		b.partitionsMu.Lock()
+		if b.partitions == nil {
+			b.stopping = make(chan struct{})
+			b.sending = &sync.WaitGroup{}
+			b.partitions = make([]chan queuedEvent, b.partitionCount)
+			for i := range b.partitions {
+				b.partitions[i] = make(chan queuedEvent, defaultPartitionQueueSize)
+				b.wg.Add(1)
+				go b.runPartition(b.partitions[i])
+			}
+		}
+		b.partitionsMu.Unlock()
+	}
+
 	b.running.Store(true)
-	b.logger.Info("event bus started")
+	b.logger.Info("event bus started", zap.Int("partitions", b.partitionCount))
 	return nil
 }
 
-// Stop stops the event bus gracefully
+// Stop stops the event bus gracefully, delivering any queued events first
 func (b *InMemoryEventBus) Stop(ctx context.Context) error {
 	b.running.Store(false)
-	b.wg.Wait()
-	b.logger.Info("event bus stopped")
-	return nil
+
+	b.partitionsMu.Lock()
+	partitions, sending := b.partitions, b.sending
+	b.partitions = nil
+	if partitions != nil {
+		close(b.stopping)
+	}
+	b.partitionsMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		// Queues are closed once no publisher can still send to them
+		if sending != nil {
+			sending.Wait()
+		}
+		for _, queue := range partitions {
+			close(queue)
+		}
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		b.logger.Info("event bus stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // dispatchToHandler safely dispatches an event to a handler
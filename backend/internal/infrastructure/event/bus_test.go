@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/logger"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,6 +82,37 @@ func TestInMemoryEventBus_Publish(t *testing.T) {
 	assert.Equal(t, event, handler.getHandled()[0])
 }
 
+// tenantRecordingHandler records the tenant of the context each event is handled with
+type tenantRecordingHandler struct {
+	testHandler
+	tenantIDs []string
+}
+
+func (h *tenantRecordingHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	h.mu.Lock()
+	h.tenantIDs = append(h.tenantIDs, logger.GetTenantID(ctx))
+	h.mu.Unlock()
+	return h.testHandler.Handle(ctx, event)
+}
+
+func TestInMemoryEventBus_Publish_HandlerContextCarriesTenant(t *testing.T) {
+	bus := NewInMemoryEventBus(zap.NewNop())
+
+	handler := &tenantRecordingHandler{testHandler: *newTestHandler("TestEvent")}
+	bus.Subscribe(handler, "TestEvent")
+
+	eventTenantID := uuid.New()
+	requestTenantID := uuid.New()
+
+	// Published from background work: handled as the event's tenant
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent("TestEvent", eventTenantID)))
+	// Published within a request: the request tenant is kept
+	ctx := context.WithValue(context.Background(), logger.TenantIDKey, requestTenantID.String())
+	require.NoError(t, bus.Publish(ctx, newTestEvent("TestEvent", eventTenantID)))
+
+	assert.Equal(t, []string{eventTenantID.String(), requestTenantID.String()}, handler.tenantIDs)
+}
+
 func TestInMemoryEventBus_Publish_MultipleEvents(t *testing.T) {
 	logger := zap.NewNop()
 	bus := NewInMemoryEventBus(logger)
@@ -198,3 +231,179 @@ func TestInMemoryEventBus_StartStop(t *testing.T) {
 	err = bus.Stop(ctx)
 	require.NoError(t, err)
 }
+
+// slowFirstHandler takes longer on the first event type it sees so that, without
+// ordering, later events for the same aggregate would overtake it
+type slowFirstHandler struct {
+	*testHandler
+	slowType string
+	delay    time.Duration
+}
+
+func (h *slowFirstHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	if event.EventType() == h.slowType {
+		time.Sleep(h.delay)
+	}
+	return h.testHandler.Handle(ctx, event)
+}
+
+func TestInMemoryEventBus_OrderedDelivery(t *testing.T) {
+	bus := NewInMemoryEventBus(zap.NewNop(), WithOrderedDelivery(4))
+	handler := &slowFirstHandler{
+		testHandler: newTestHandler("SalesOrderConfirmed", "SalesOrderShipped", "SalesOrderCompleted"),
+		slowType:    "SalesOrderConfirmed",
+		delay:       50 * time.Millisecond,
+	}
+	bus.Subscribe(handler)
+	require.NoError(t, bus.Start(context.Background()))
+
+	tenantID, orderID := uuid.New(), uuid.New()
+	var emitted []shared.DomainEvent
+	for _, eventType := range []string{"SalesOrderConfirmed", "SalesOrderShipped", "SalesOrderCompleted"} {
+		emitted = append(emitted, &testEvent{
+			BaseDomainEvent: shared.NewBaseDomainEvent(eventType, "SalesOrder", orderID, tenantID),
+		})
+	}
+	// Published separately, as services do, so each call returns before delivery
+	for _, event := range emitted {
+		require.NoError(t, bus.Publish(context.Background(), event))
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Stop(stopCtx))
+
+	// The slow first event is still observed before the events emitted after it
+	assert.Equal(t, emitted, handler.getHandled())
+}
+
+func TestInMemoryEventBus_OrderedDelivery_AggregatesRunInParallel(t *testing.T) {
+	bus := NewInMemoryEventBus(zap.NewNop(), WithOrderedDelivery(4))
+
+	// Pick two aggregates on different partitions
+	blockedID, otherID := uuid.New(), uuid.New()
+	for bus.partitionFor(otherID) == bus.partitionFor(blockedID) {
+		otherID = uuid.New()
+	}
+
+	release := make(chan struct{})
+	handled := make(chan uuid.UUID, 2)
+	handler := &blockingHandler{blockedID: blockedID, release: release, handled: handled}
+	bus.Subscribe(handler, "TestEvent")
+	require.NoError(t, bus.Start(context.Background()))
+
+	tenantID := uuid.New()
+	require.NoError(t, bus.Publish(context.Background(),
+		&testEvent{BaseDomainEvent: shared.NewBaseDomainEvent("TestEvent", "TestAggregate", blockedID, tenantID)},
+		&testEvent{BaseDomainEvent: shared.NewBaseDomainEvent("TestEvent", "TestAggregate", otherID, tenantID)},
+	))
+
+	// The other aggregate is handled while the first is still blocked
+	select {
+	case id := <-handled:
+		assert.Equal(t, otherID, id)
+	case <-time.After(time.Second):
+		t.Fatal("other aggregate was held back by a blocked aggregate")
+	}
+
+	close(release)
+	stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Stop(stopCtx))
+	assert.Equal(t, blockedID, <-handled)
+}
+
+// blockingHandler blocks events of one aggregate until released
+type blockingHandler struct {
+	blockedID uuid.UUID
+	release   chan struct{}
+	handled   chan uuid.UUID
+}
+
+func (h *blockingHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	if event.AggregateID() == h.blockedID {
+		<-h.release
+	}
+	h.handled <- event.AggregateID()
+	return nil
+}
+
+func (h *blockingHandler) EventTypes() []string {
+	return []string{"TestEvent"}
+}
+
+func TestInMemoryEventBus_OrderedDelivery_SynchronousWhenStopped(t *testing.T) {
+	bus := NewInMemoryEventBus(zap.NewNop(), WithOrderedDelivery(4))
+	handler := newTestHandler("TestEvent")
+	bus.Subscribe(handler, "TestEvent")
+
+	// Not started: delivered within Publish
+	require.NoError(t, bus.Publish(context.Background(), newTestEvent("TestEvent", uuid.New())))
+	assert.Len(t, handler.getHandled(), 1)
+}
+
+// republishingHandler publishes a follow-up event once its first event is released
+type republishingHandler struct {
+	bus     *InMemoryEventBus
+	release chan struct{}
+	handled chan string
+	first   atomic.Bool
+}
+
+func (h *republishingHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
+	if h.first.CompareAndSwap(false, true) {
+		<-h.release
+		if err := h.bus.Publish(ctx, newTestEvent("FollowUpEvent", event.TenantID())); err != nil {
+			return err
+		}
+	}
+	h.handled <- event.EventType()
+	return nil
+}
+
+func (h *republishingHandler) EventTypes() []string {
+	return []string{"TestEvent", "FollowUpEvent"}
+}
+
+func TestInMemoryEventBus_OrderedDelivery_StopWithFullPartition(t *testing.T) {
+	bus := NewInMemoryEventBus(zap.NewNop(), WithOrderedDelivery(1))
+	handler := &republishingHandler{
+		bus:     bus,
+		release: make(chan struct{}),
+		handled: make(chan string, defaultPartitionQueueSize+3),
+	}
+	bus.Subscribe(handler)
+	require.NoError(t, bus.Start(context.Background()))
+
+	// The first event holds the only partition while the queue fills up
+	tenantID := uuid.New()
+	for range defaultPartitionQueueSize + 1 {
+		require.NoError(t, bus.Publish(context.Background(), newTestEvent("TestEvent", tenantID)))
+	}
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(context.Background(), newTestEvent("TestEvent", tenantID))
+	}()
+
+	// Let the publisher reach the full partition before stopping
+	time.Sleep(50 * time.Millisecond)
+	stopped := make(chan error, 1)
+	go func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stopped <- bus.Stop(stopCtx)
+	}()
+
+	// Released while Stop is pending, the handler publishes again
+	time.Sleep(50 * time.Millisecond)
+	close(handler.release)
+
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop deadlocked with a publisher waiting on a full partition")
+	}
+	require.NoError(t, <-published)
+	assert.Len(t, handler.handled, defaultPartitionQueueSize+3)
+}
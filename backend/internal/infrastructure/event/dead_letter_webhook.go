@@ -0,0 +1,57 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	eventapp "github.com/erp/backend/internal/application/event"
+)
+
+// defaultWebhookTimeout bounds a dead letter webhook request
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookDeadLetterNotifier POSTs dead letter alerts as JSON to a webhook URL
+type WebhookDeadLetterNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookDeadLetterNotifier creates a notifier posting to the given URL
+func NewWebhookDeadLetterNotifier(url string) *WebhookDeadLetterNotifier {
+	return &WebhookDeadLetterNotifier{
+		url:    url,
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+	}
+}
+
+// NotifyDeadLetters posts the alert to the webhook, failing on any non-2xx response
+func (n *WebhookDeadLetterNotifier) NotifyDeadLetters(ctx context.Context, alert eventapp.DeadLetterAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create dead letter webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send dead letter webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("dead letter webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Ensure WebhookDeadLetterNotifier implements eventapp.DeadLetterNotifier
+var _ eventapp.DeadLetterNotifier = (*WebhookDeadLetterNotifier)(nil)
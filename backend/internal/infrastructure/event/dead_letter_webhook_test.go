@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	eventapp "github.com/erp/backend/internal/application/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeadLetterNotifier_NotifyDeadLetters(t *testing.T) {
+	alert := eventapp.DeadLetterAlert{
+		Status:           "red",
+		DeadCount:        12,
+		Threshold:        10,
+		OldestAgeSeconds: 7200,
+		MaxAgeSeconds:    86400,
+		SampleEventTypes: []string{"SalesOrderShipped"},
+		CheckedAt:        time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("posts the alert as JSON", func(t *testing.T) {
+		var received eventapp.DeadLetterAlert
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := NewWebhookDeadLetterNotifier(server.URL).NotifyDeadLetters(context.Background(), alert)
+		require.NoError(t, err)
+		assert.Equal(t, alert, received)
+	})
+
+	t.Run("fails on a non-2xx response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := NewWebhookDeadLetterNotifier(server.URL).NotifyDeadLetters(context.Background(), alert)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "502")
+	})
+}
@@ -4,12 +4,20 @@ import (
 	"github.com/erp/backend/internal/domain/featureflag"
 	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/trade"
 )
 
+// EventRegistrar registers event types for deserialization.
+// Both EventSerializer and VersionedSerializer implement it.
+type EventRegistrar interface {
+	Register(eventType string, eventInstance shared.DomainEvent)
+}
+
 // RegisterAllEvents registers all domain event types with the serializer
 // This is required for the OutboxProcessor to deserialize events from the outbox table
-func RegisterAllEvents(serializer *EventSerializer) {
+func RegisterAllEvents(serializer EventRegistrar) {
 	// Trade domain - Sales Order events
 	serializer.Register("SalesOrderCreated", &trade.SalesOrderCreatedEvent{})
 	serializer.Register("SalesOrderConfirmed", &trade.SalesOrderConfirmedEvent{})
@@ -23,6 +31,7 @@ func RegisterAllEvents(serializer *EventSerializer) {
 	serializer.Register("PurchaseOrderReceived", &trade.PurchaseOrderReceivedEvent{})
 	serializer.Register("PurchaseOrderCompleted", &trade.PurchaseOrderCompletedEvent{})
 	serializer.Register("PurchaseOrderCancelled", &trade.PurchaseOrderCancelledEvent{})
+	serializer.Register("PurchaseOrderAmended", &trade.PurchaseOrderAmendedEvent{})
 
 	// Trade domain - Sales Return events
 	serializer.Register("SalesReturnCreated", &trade.SalesReturnCreatedEvent{})
@@ -64,20 +73,27 @@ func RegisterAllEvents(serializer *EventSerializer) {
 	serializer.Register("AccountReceivableCreated", &finance.AccountReceivableCreatedEvent{})
 	serializer.Register("AccountReceivablePaid", &finance.AccountReceivablePaidEvent{})
 	serializer.Register("AccountReceivablePartiallyPaid", &finance.AccountReceivablePartiallyPaidEvent{})
+	serializer.Register("AccountReceivablePaymentReversed", &finance.AccountReceivablePaymentReversedEvent{})
 	serializer.Register("AccountReceivableReversed", &finance.AccountReceivableReversedEvent{})
 	serializer.Register("AccountReceivableCancelled", &finance.AccountReceivableCancelledEvent{})
+	serializer.Register("ReceivableDueSoon", &finance.ReceivableDueSoonEvent{})
+	serializer.Register("ReceivableOverdue", &finance.ReceivableOverdueEvent{})
+	serializer.Register("ReceivableWrittenOff", &finance.ReceivableWrittenOffEvent{})
 
 	// Finance domain - Account Payable events
 	serializer.Register("AccountPayableCreated", &finance.AccountPayableCreatedEvent{})
 	serializer.Register("AccountPayablePaid", &finance.AccountPayablePaidEvent{})
 	serializer.Register("AccountPayablePartiallyPaid", &finance.AccountPayablePartiallyPaidEvent{})
+	serializer.Register("AccountPayableAmountIncreased", &finance.AccountPayableAmountIncreasedEvent{})
 	serializer.Register("AccountPayableReversed", &finance.AccountPayableReversedEvent{})
 	serializer.Register("AccountPayableCancelled", &finance.AccountPayableCancelledEvent{})
+	serializer.Register("AccountPayableMatchFailed", &finance.AccountPayableMatchFailedEvent{})
 
 	// Finance domain - Receipt Voucher events
 	serializer.Register("ReceiptVoucherCreated", &finance.ReceiptVoucherCreatedEvent{})
 	serializer.Register("ReceiptVoucherConfirmed", &finance.ReceiptVoucherConfirmedEvent{})
 	serializer.Register("ReceiptVoucherAllocated", &finance.ReceiptVoucherAllocatedEvent{})
+	serializer.Register("ReceiptVoucherUnallocated", &finance.ReceiptVoucherUnallocatedEvent{})
 	serializer.Register("ReceiptVoucherCancelled", &finance.ReceiptVoucherCancelledEvent{})
 
 	// Finance domain - Payment Voucher events
@@ -104,6 +120,7 @@ func RegisterAllEvents(serializer *EventSerializer) {
 	serializer.Register("ExpenseRecordCreated", &finance.ExpenseRecordCreatedEvent{})
 	serializer.Register("ExpenseRecordSubmitted", &finance.ExpenseRecordSubmittedEvent{})
 	serializer.Register("ExpenseRecordApproved", &finance.ExpenseRecordApprovedEvent{})
+	serializer.Register("ExpenseRecordApprovalLevelCompleted", &finance.ExpenseRecordApprovalLevelCompletedEvent{})
 	serializer.Register("ExpenseRecordRejected", &finance.ExpenseRecordRejectedEvent{})
 	serializer.Register("ExpenseRecordCancelled", &finance.ExpenseRecordCancelledEvent{})
 	serializer.Register("ExpenseRecordPaid", &finance.ExpenseRecordPaidEvent{})
@@ -118,6 +135,14 @@ func RegisterAllEvents(serializer *EventSerializer) {
 	serializer.Register("GatewayPaymentCompleted", &finance.GatewayPaymentCompletedEvent{})
 	serializer.Register("GatewayRefundCompleted", &finance.GatewayRefundCompletedEvent{})
 
+	// Partner domain - Customer events published by customer merges
+	serializer.Register(partner.EventTypeCustomerStatusChanged, &partner.CustomerStatusChangedEvent{})
+	serializer.Register(partner.EventTypeCustomerBalanceChanged, &partner.CustomerBalanceChangedEvent{})
+	serializer.Register(partner.EventTypeCustomerMerged, &partner.CustomerMergedEvent{})
+
+	// Partner domain - Warehouse events
+	serializer.Register(partner.EventTypeWarehouseNearCapacity, &partner.WarehouseNearCapacityEvent{})
+
 	// Feature Flag domain events
 	serializer.Register(featureflag.EventTypeFlagCreated, &featureflag.FlagCreatedEvent{})
 	serializer.Register(featureflag.EventTypeFlagUpdated, &featureflag.FlagUpdatedEvent{})
@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"reflect"
 	"sync/atomic"
 
 	"github.com/erp/backend/internal/domain/shared"
@@ -37,9 +38,12 @@ type IdempotencyStats struct {
 }
 
 // IdempotentHandler wraps an EventHandler with idempotency checking
-// It ensures each event is only processed once, even if delivered multiple times
+// It ensures each event is only processed once, even if delivered multiple times.
+// The event is claimed in the store before the wrapped handler runs, so concurrent
+// deliveries of one event cannot both process it.
 type IdempotentHandler struct {
 	handler shared.EventHandler
+	name    string
 	store   shared.IdempotencyStore
 	config  shared.IdempotencyConfig
 	logger  *zap.Logger
@@ -72,6 +76,7 @@ func NewIdempotentHandler(
 ) *IdempotentHandler {
 	h := &IdempotentHandler{
 		handler: handler,
+		name:    HandlerName(handler),
 		store:   store,
 		config:  shared.DefaultIdempotencyConfig(),
 		logger:  logger,
@@ -85,22 +90,52 @@ func NewIdempotentHandler(
 	return h
 }
 
+// HandlerName returns the name a handler's processed events are recorded under:
+// its HandlerName if it implements shared.NamedEventHandler, otherwise its
+// fully qualified Go type name
+func HandlerName(handler shared.EventHandler) string {
+	if named, ok := handler.(shared.NamedEventHandler); ok {
+		return named.HandlerName()
+	}
+	t := reflect.TypeOf(handler)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
 // EventTypes returns the event types this handler is interested in
 func (h *IdempotentHandler) EventTypes() []string {
 	return h.handler.EventTypes()
 }
 
+// HandlerName returns the name of the wrapped handler
+func (h *IdempotentHandler) HandlerName() string {
+	return h.name
+}
+
+// idempotencyKey returns the key the event is marked under in the store
+func (h *IdempotentHandler) idempotencyKey(event shared.DomainEvent) string {
+	if h.config.PerHandler {
+		return h.name + ":" + event.EventID().String()
+	}
+	return event.EventID().String()
+}
+
 // Handle processes the event with idempotency checking
 func (h *IdempotentHandler) Handle(ctx context.Context, event shared.DomainEvent) error {
-	// If idempotency is disabled, process directly
-	if !h.config.Enabled {
+	// If idempotency is disabled, process directly. Replays deliberately re-deliver
+	// processed events, so they bypass the store; the wrapped handler checks
+	// shared.IsEventReplay to decide which effects to repeat.
+	if !h.config.Enabled || shared.IsEventReplay(ctx) {
 		return h.handler.Handle(ctx, event)
 	}
 
 	eventID := event.EventID().String()
+	key := h.idempotencyKey(event)
 
 	// Try to mark as processed (atomic check-and-set)
-	isNew, err := h.store.MarkProcessed(ctx, eventID, h.config.TTL)
+	isNew, err := h.store.MarkProcessed(ctx, key, h.config.TTL)
 	if err != nil {
 		// Log warning but continue processing
 		// Better to risk duplicate processing than to drop events
@@ -128,9 +163,18 @@ func (h *IdempotentHandler) Handle(ctx context.Context, event shared.DomainEvent
 			zap.String("event_type", event.EventType()),
 			zap.Error(err),
 		)
-		// Note: We don't remove the idempotency key on failure
+		// Unless RetryOnFailure is set, the idempotency key is kept on failure
 		// This prevents rapid retries. The key will expire after TTL
 		// allowing retry after a cooldown period
+		if h.config.RetryOnFailure && isNew {
+			if releaseErr := h.store.Release(ctx, key); releaseErr != nil {
+				h.logger.Warn("failed to release idempotency key of failed event",
+					zap.String("event_id", eventID),
+					zap.String("event_type", event.EventType()),
+					zap.Error(releaseErr),
+				)
+			}
+		}
 		return err
 	}
 
@@ -153,8 +197,8 @@ func (h *IdempotentHandler) GetWrappedHandler() shared.EventHandler {
 	return h.handler
 }
 
-// Ensure IdempotentHandler implements EventHandler
-var _ shared.EventHandler = (*IdempotentHandler)(nil)
+// Ensure IdempotentHandler implements NamedEventHandler
+var _ shared.NamedEventHandler = (*IdempotentHandler)(nil)
 
 // WrapHandlersWithIdempotency wraps multiple handlers with idempotency checking
 // This is a convenience function for wrapping all handlers at once
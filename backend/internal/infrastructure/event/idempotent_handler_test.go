@@ -45,6 +45,11 @@ func (m *MockIdempotencyStore) IsProcessed(ctx context.Context, eventID string)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockIdempotencyStore) Release(ctx context.Context, eventID string) error {
+	args := m.Called(ctx, eventID)
+	return args.Error(0)
+}
+
 func (m *MockIdempotencyStore) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -192,6 +197,30 @@ func TestIdempotentHandler_Handle_Disabled(t *testing.T) {
 	assert.Equal(t, int64(0), handler.metrics.EventsDuplicate.Load())
 }
 
+func TestIdempotentHandler_Handle_Replay(t *testing.T) {
+	logger := zap.NewNop()
+	store := cache.NewInMemoryIdempotencyStore()
+	defer store.Close()
+
+	mockHandler := new(MockEventHandler)
+	event := newIdempotencyTestEvent()
+
+	// The original delivery and the replay both reach the handler, and only
+	// the replay carries the replay flag
+	mockHandler.On("Handle", mock.MatchedBy(func(ctx context.Context) bool {
+		return !shared.IsEventReplay(ctx)
+	}), event).Return(nil).Once()
+	mockHandler.On("Handle", mock.MatchedBy(shared.IsEventReplay), event).Return(nil).Once()
+
+	handler := NewIdempotentHandler(mockHandler, store, logger)
+
+	require.NoError(t, handler.Handle(context.Background(), event))
+	require.NoError(t, handler.Handle(shared.WithEventReplay(context.Background()), event))
+
+	mockHandler.AssertExpectations(t)
+	assert.Equal(t, int64(0), handler.metrics.EventsDuplicate.Load())
+}
+
 func TestIdempotentHandler_EventTypes(t *testing.T) {
 	logger := zap.NewNop()
 	store := cache.NewInMemoryIdempotencyStore()
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"go.uber.org/zap"
+)
+
+// IdempotentSubscriber decorates an EventSubscriber so that every handler subscribed
+// through it is wrapped in an IdempotentHandler
+type IdempotentSubscriber struct {
+	subscriber shared.EventSubscriber
+	store      shared.IdempotencyStore
+	logger     *zap.Logger
+	opts       []IdempotentHandlerOption
+
+	mu      sync.Mutex
+	wrapped map[shared.EventHandler]*IdempotentHandler
+}
+
+// NewIdempotentSubscriber creates a new idempotent subscriber decorator.
+// The options are applied to every handler it wraps.
+func NewIdempotentSubscriber(
+	subscriber shared.EventSubscriber,
+	store shared.IdempotencyStore,
+	logger *zap.Logger,
+	opts ...IdempotentHandlerOption,
+) *IdempotentSubscriber {
+	return &IdempotentSubscriber{
+		subscriber: subscriber,
+		store:      store,
+		logger:     logger,
+		opts:       opts,
+		wrapped:    make(map[shared.EventHandler]*IdempotentHandler),
+	}
+}
+
+// Subscribe registers the handler, wrapped for idempotency, with the underlying subscriber
+func (s *IdempotentSubscriber) Subscribe(handler shared.EventHandler, eventTypes ...string) {
+	wrapped := NewIdempotentHandler(handler, s.store, s.logger, s.opts...)
+
+	s.mu.Lock()
+	s.wrapped[handler] = wrapped
+	s.mu.Unlock()
+
+	s.subscriber.Subscribe(wrapped, eventTypes...)
+}
+
+// Unsubscribe removes a handler subscribed through this decorator
+func (s *IdempotentSubscriber) Unsubscribe(handler shared.EventHandler) {
+	s.mu.Lock()
+	wrapped, ok := s.wrapped[handler]
+	delete(s.wrapped, handler)
+	s.mu.Unlock()
+
+	if ok {
+		s.subscriber.Unsubscribe(wrapped)
+	}
+}
+
+// Ensure IdempotentSubscriber implements EventSubscriber
+var _ shared.EventSubscriber = (*IdempotentSubscriber)(nil)
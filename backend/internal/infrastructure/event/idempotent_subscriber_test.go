@@ -0,0 +1,187 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// perHandlerConfig is the idempotency configuration the server subscribes handlers with
+var perHandlerConfig = shared.IdempotencyConfig{Enabled: true, PerHandler: true, RetryOnFailure: true}
+
+// namedTestHandler is a MockEventHandler with a stable handler name
+type namedTestHandler struct {
+	MockEventHandler
+	name string
+}
+
+func (h *namedTestHandler) HandlerName() string {
+	return h.name
+}
+
+func TestIdempotentSubscriber_SameEventTwice(t *testing.T) {
+	store := cache.NewInMemoryIdempotencyStore()
+	defer store.Close()
+	bus := NewInMemoryEventBus(zap.NewNop())
+	subscriber := NewIdempotentSubscriber(bus, store, zap.NewNop(), WithIdempotencyConfig(perHandlerConfig))
+
+	first, second := new(MockEventHandler), &namedTestHandler{name: "read-model"}
+	first.On("EventTypes").Return([]string{"test.event"})
+	second.On("EventTypes").Return([]string{"test.event"})
+	event := newIdempotencyTestEvent()
+	first.On("Handle", mock.Anything, event).Return(nil).Once()
+	second.On("Handle", mock.Anything, event).Return(nil).Once()
+
+	subscriber.Subscribe(first)
+	subscriber.Subscribe(second)
+
+	// Delivered twice, e.g. directly and again by the outbox processor
+	require.NoError(t, bus.Publish(context.Background(), event))
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	// Each handler runs once
+	first.AssertNumberOfCalls(t, "Handle", 1)
+	second.AssertNumberOfCalls(t, "Handle", 1)
+}
+
+func TestIdempotentHandler_PerHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("failed event is processed again", func(t *testing.T) {
+		store := cache.NewInMemoryIdempotencyStore()
+		defer store.Close()
+		inner := new(MockEventHandler)
+		event := newIdempotencyTestEvent()
+		inner.On("Handle", mock.Anything, event).Return(errors.New("temporary failure")).Once()
+		inner.On("Handle", mock.Anything, event).Return(nil).Once()
+		handler := NewIdempotentHandler(inner, store, zap.NewNop(), WithIdempotencyConfig(perHandlerConfig))
+
+		require.Error(t, handler.Handle(ctx, event))
+		require.NoError(t, handler.Handle(ctx, event))
+		require.NoError(t, handler.Handle(ctx, event))
+
+		inner.AssertNumberOfCalls(t, "Handle", 2)
+	})
+
+	t.Run("event is claimed before it is handled", func(t *testing.T) {
+		store := cache.NewInMemoryIdempotencyStore()
+		defer store.Close()
+		inner := new(MockEventHandler)
+		event := newIdempotencyTestEvent()
+		handler := NewIdempotentHandler(inner, store, zap.NewNop(), WithIdempotencyConfig(perHandlerConfig))
+
+		// A concurrent delivery arriving while the first is being handled is skipped
+		inner.On("Handle", mock.Anything, event).Run(func(mock.Arguments) {
+			require.NoError(t, handler.Handle(ctx, event))
+		}).Return(nil).Once()
+
+		require.NoError(t, handler.Handle(ctx, event))
+		inner.AssertNumberOfCalls(t, "Handle", 1)
+	})
+
+	t.Run("marks are kept per handler", func(t *testing.T) {
+		store := new(MockIdempotencyStore)
+		inner := &namedTestHandler{name: "read-model"}
+		event := newIdempotencyTestEvent()
+		store.On("MarkProcessed", ctx, "read-model:"+event.EventID().String(), time.Duration(0)).Return(true, nil)
+		inner.On("Handle", mock.Anything, event).Return(nil)
+		handler := NewIdempotentHandler(inner, store, zap.NewNop(), WithIdempotencyConfig(perHandlerConfig))
+
+		require.NoError(t, handler.Handle(ctx, event))
+		store.AssertExpectations(t)
+	})
+
+	t.Run("handlers are tracked by name", func(t *testing.T) {
+		assert.Equal(t, "github.com/erp/backend/internal/infrastructure/event.MockEventHandler", HandlerName(new(MockEventHandler)))
+		assert.Equal(t, "read-model", HandlerName(&namedTestHandler{name: "read-model"}))
+	})
+}
+
+func TestIdempotentSubscriber_Unsubscribe(t *testing.T) {
+	store := cache.NewInMemoryIdempotencyStore()
+	defer store.Close()
+	bus := NewInMemoryEventBus(zap.NewNop())
+	subscriber := NewIdempotentSubscriber(bus, store, zap.NewNop(), WithIdempotencyConfig(perHandlerConfig))
+
+	handler := new(MockEventHandler)
+	handler.On("EventTypes").Return([]string{"test.event"})
+	subscriber.Subscribe(handler)
+	subscriber.Unsubscribe(handler)
+
+	require.NoError(t, bus.Publish(context.Background(), newIdempotencyTestEvent()))
+	handler.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything)
+}
+
+func TestGormProcessedEventStore(t *testing.T) {
+	ctx := context.Background()
+	key := "read-model:" + newIdempotencyTestEvent().EventID().String()
+
+	t.Run("mark processed", func(t *testing.T) {
+		db, sqlMock := setupMockDB(t)
+		store := NewGormProcessedEventStore(db)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "processed_events"`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT ("event_key") DO UPDATE SET "processed_at"="excluded"."processed_at","expires_at"="excluded"."expires_at" WHERE processed_events.expires_at <`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectCommit()
+
+		isNew, err := store.MarkProcessed(ctx, key, 0)
+		require.NoError(t, err)
+		assert.True(t, isNew)
+
+		isNew, err = store.MarkProcessed(ctx, key, time.Hour)
+		require.NoError(t, err)
+		assert.False(t, isNew)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("is processed", func(t *testing.T) {
+		db, sqlMock := setupMockDB(t)
+		store := NewGormProcessedEventStore(db)
+
+		query := regexp.QuoteMeta(`SELECT count(*) FROM "processed_events" WHERE event_key = $1 AND (expires_at IS NULL OR expires_at >= $2)`)
+		sqlMock.ExpectQuery(query).
+			WithArgs(key, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		sqlMock.ExpectQuery(query).
+			WithArgs("other", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		processed, err := store.IsProcessed(ctx, key)
+		require.NoError(t, err)
+		assert.True(t, processed)
+
+		processed, err = store.IsProcessed(ctx, "other")
+		require.NoError(t, err)
+		assert.False(t, processed)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("release", func(t *testing.T) {
+		db, sqlMock := setupMockDB(t)
+		store := NewGormProcessedEventStore(db)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "processed_events" WHERE event_key = $1`)).
+			WithArgs(key).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+
+		require.NoError(t, store.Release(ctx, key))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -17,6 +18,10 @@ type OutboxProcessorConfig struct {
 	CleanupEnabled   bool
 	CleanupRetention time.Duration
 	CleanupInterval  time.Duration
+
+	MaxRetries  int           // Failed attempts after which an entry moves to the dead letter queue
+	BaseBackoff time.Duration // Delay before the first retry; doubles on each further failure
+	MaxBackoff  time.Duration // Upper bound on the delay between retries
 }
 
 // DefaultOutboxProcessorConfig returns default configuration
@@ -27,6 +32,9 @@ func DefaultOutboxProcessorConfig() OutboxProcessorConfig {
 		CleanupEnabled:   true,
 		CleanupRetention: 7 * 24 * time.Hour, // 7 days
 		CleanupInterval:  1 * time.Hour,
+		MaxRetries:       shared.DefaultMaxRetries,
+		BaseBackoff:      shared.DefaultBaseBackoff,
+		MaxBackoff:       shared.DefaultMaxBackoff,
 	}
 }
 
@@ -50,6 +58,15 @@ func NewOutboxProcessor(
 	config OutboxProcessorConfig,
 	logger *zap.Logger,
 ) *OutboxProcessor {
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = shared.DefaultMaxRetries
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = shared.DefaultBaseBackoff
+	}
+	if config.MaxBackoff < config.BaseBackoff {
+		config.MaxBackoff = max(shared.DefaultMaxBackoff, config.BaseBackoff)
+	}
 	return &OutboxProcessor{
 		repo:       repo,
 		eventBus:   eventBus,
@@ -77,6 +94,9 @@ func (p *OutboxProcessor) Start(ctx context.Context) error {
 	p.logger.Info("outbox processor started",
 		zap.Int("batch_size", p.config.BatchSize),
 		zap.Duration("poll_interval", p.config.PollInterval),
+		zap.Int("max_retries", p.config.MaxRetries),
+		zap.Duration("base_backoff", p.config.BaseBackoff),
+		zap.Duration("max_backoff", p.config.MaxBackoff),
 	)
 
 	return nil
@@ -145,7 +165,8 @@ func (p *OutboxProcessor) processBatch(ctx context.Context) {
 	}
 }
 
-// processEntries processes a slice of outbox entries
+// processEntries processes a slice of outbox entries. Each entry is processed
+// independently, so a failing entry does not hold back the rest of the batch.
 func (p *OutboxProcessor) processEntries(ctx context.Context, entries []*shared.OutboxEntry) {
 	ids := make([]uuid.UUID, len(entries))
 	for i, e := range entries {
@@ -166,6 +187,18 @@ func (p *OutboxProcessor) processEntries(ctx context.Context, entries []*shared.
 
 // processEntry processes a single outbox entry
 func (p *OutboxProcessor) processEntry(ctx context.Context, entry *shared.OutboxEntry) {
+	// A panicking (poison) event must not take down the processor
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("panic while processing outbox entry",
+				zap.String("event_id", entry.EventID.String()),
+				zap.String("event_type", entry.EventType),
+				zap.Any("panic", r),
+			)
+			p.markFailed(ctx, entry, fmt.Sprintf("panic: %v", r))
+		}
+	}()
+
 	// Deserialize the event
 	event, err := p.serializer.Deserialize(entry.EventType, entry.Payload)
 	if err != nil {
@@ -174,20 +207,7 @@ func (p *OutboxProcessor) processEntry(ctx context.Context, entry *shared.Outbox
 			zap.String("event_type", entry.EventType),
 			zap.Error(err),
 		)
-		entry.MarkFailed(err.Error())
-		if entry.IsDead() {
-			p.logger.Warn("event moved to dead letter queue",
-				zap.String("event_id", entry.EventID.String()),
-				zap.String("event_type", entry.EventType),
-				zap.String("aggregate_type", entry.AggregateType),
-				zap.String("aggregate_id", entry.AggregateID.String()),
-				zap.Int("retry_count", entry.RetryCount),
-				zap.String("last_error", entry.LastError),
-			)
-		}
-		if updateErr := p.repo.Update(ctx, entry); updateErr != nil {
-			p.logger.Error("failed to update entry", zap.Error(updateErr))
-		}
+		p.markFailed(ctx, entry, err.Error())
 		return
 	}
 
@@ -198,20 +218,7 @@ func (p *OutboxProcessor) processEntry(ctx context.Context, entry *shared.Outbox
 			zap.String("event_type", entry.EventType),
 			zap.Error(err),
 		)
-		entry.MarkFailed(err.Error())
-		if entry.IsDead() {
-			p.logger.Warn("event moved to dead letter queue",
-				zap.String("event_id", entry.EventID.String()),
-				zap.String("event_type", entry.EventType),
-				zap.String("aggregate_type", entry.AggregateType),
-				zap.String("aggregate_id", entry.AggregateID.String()),
-				zap.Int("retry_count", entry.RetryCount),
-				zap.String("last_error", entry.LastError),
-			)
-		}
-		if updateErr := p.repo.Update(ctx, entry); updateErr != nil {
-			p.logger.Error("failed to update entry", zap.Error(updateErr))
-		}
+		p.markFailed(ctx, entry, err.Error())
 		return
 	}
 
@@ -230,6 +237,26 @@ func (p *OutboxProcessor) processEntry(ctx context.Context, entry *shared.Outbox
 	}
 }
 
+// markFailed records a failed attempt, scheduling a backed-off retry or moving
+// the entry to the dead letter queue once it has used up its retries
+func (p *OutboxProcessor) markFailed(ctx context.Context, entry *shared.OutboxEntry, errMsg string) {
+	entry.MaxRetries = p.config.MaxRetries
+	entry.MarkFailedWithBackoff(errMsg, p.config.BaseBackoff, p.config.MaxBackoff)
+	if entry.IsDead() {
+		p.logger.Warn("event moved to dead letter queue",
+			zap.String("event_id", entry.EventID.String()),
+			zap.String("event_type", entry.EventType),
+			zap.String("aggregate_type", entry.AggregateType),
+			zap.String("aggregate_id", entry.AggregateID.String()),
+			zap.Int("retry_count", entry.RetryCount),
+			zap.String("last_error", entry.LastError),
+		)
+	}
+	if updateErr := p.repo.Update(ctx, entry); updateErr != nil {
+		p.logger.Error("failed to update entry", zap.Error(updateErr))
+	}
+}
+
 // cleanupLoop periodically cleans up old processed entries
 func (p *OutboxProcessor) cleanupLoop(ctx context.Context) {
 	defer p.wg.Done()
@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -118,6 +119,14 @@ func (r *mockOutboxRepository) FindByID(ctx context.Context, id uuid.UUID) (*sha
 	return nil, nil
 }
 
+func (r *mockOutboxRepository) FindOldestDead(ctx context.Context, limit int) ([]*shared.OutboxEntry, error) {
+	return nil, nil
+}
+
+func (r *mockOutboxRepository) FindForReplay(ctx context.Context, filter shared.OutboxReplayFilter) ([]*shared.OutboxEntry, error) {
+	return nil, nil
+}
+
 func (r *mockOutboxRepository) CountByStatus(ctx context.Context) (map[shared.OutboxStatus]int64, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -242,4 +251,105 @@ func TestDefaultOutboxProcessorConfig(t *testing.T) {
 	assert.True(t, config.CleanupEnabled)
 	assert.Equal(t, 7*24*time.Hour, config.CleanupRetention)
 	assert.Equal(t, 1*time.Hour, config.CleanupInterval)
+	assert.Equal(t, 5, config.MaxRetries)
+	assert.Equal(t, time.Second, config.BaseBackoff)
+	assert.Equal(t, 5*time.Minute, config.MaxBackoff)
+}
+
+// poisonEventBus fails to publish the poison events and delegates the rest
+type poisonEventBus struct {
+	*InMemoryEventBus
+	poison map[uuid.UUID]bool
+	panics bool
+}
+
+func (b *poisonEventBus) Publish(ctx context.Context, events ...shared.DomainEvent) error {
+	for _, event := range events {
+		if b.poison[event.EventID()] {
+			if b.panics {
+				panic("poison event")
+			}
+			return errors.New("poison event")
+		}
+	}
+	return b.InMemoryEventBus.Publish(ctx, events...)
+}
+
+func TestOutboxProcessor_IsolatesPoisonEvent(t *testing.T) {
+	for _, panics := range []bool{false, true} {
+		name := "publish error"
+		if panics {
+			name = "publish panic"
+		}
+		t.Run(name, func(t *testing.T) {
+			logger := zap.NewNop()
+			serializer := NewEventSerializer()
+			serializer.Register("TestEvent", &testEvent{})
+
+			bus := &poisonEventBus{
+				InMemoryEventBus: NewInMemoryEventBus(logger),
+				poison:           make(map[uuid.UUID]bool),
+				panics:           panics,
+			}
+			handler := newTestHandler("TestEvent")
+			bus.Subscribe(handler, "TestEvent")
+
+			repo := newMockOutboxRepository()
+			repo.findRetryableFn = func(ctx context.Context, before time.Time, limit int) ([]*shared.OutboxEntry, error) {
+				repo.mu.Lock()
+				defer repo.mu.Unlock()
+				var result []*shared.OutboxEntry
+				for _, e := range repo.entries {
+					if e.Status == shared.OutboxStatusFailed && !e.NextRetryAt.After(before) {
+						result = append(result, e)
+					}
+				}
+				return result, nil
+			}
+
+			// One always-failing event among several good ones
+			tenantID := uuid.New()
+			var poison *shared.OutboxEntry
+			for i := 0; i < 5; i++ {
+				event := newTestEvent("TestEvent", tenantID)
+				payload, err := serializer.Serialize(event)
+				require.NoError(t, err)
+				entry := shared.NewOutboxEntry(tenantID, event, payload)
+				if i == 2 {
+					bus.poison[event.EventID()] = true
+					poison = entry
+				}
+				require.NoError(t, repo.Save(context.Background(), entry))
+			}
+
+			config := OutboxProcessorConfig{
+				BatchSize:   100,
+				MaxRetries:  3,
+				BaseBackoff: time.Millisecond,
+				MaxBackoff:  2 * time.Millisecond,
+			}
+			processor := NewOutboxProcessor(repo, bus, serializer, config, logger)
+
+			// The good events still publish alongside the poison one
+			processor.processBatch(context.Background())
+			assert.Len(t, handler.getHandled(), 4)
+			assert.Equal(t, shared.OutboxStatusFailed, poison.Status)
+			assert.Equal(t, 1, poison.RetryCount)
+			for id, entry := range repo.entries {
+				if id != poison.ID {
+					assert.Equal(t, shared.OutboxStatusSent, entry.Status)
+				}
+			}
+
+			// Retried with backoff until the configured retry count, then dead
+			for i := 0; i < 5; i++ {
+				time.Sleep(5 * time.Millisecond)
+				processor.processBatch(context.Background())
+			}
+			assert.Equal(t, shared.OutboxStatusDead, poison.Status)
+			assert.Equal(t, 3, poison.RetryCount)
+			assert.Equal(t, 3, poison.MaxRetries)
+			assert.Len(t, handler.getHandled(), 4)
+		})
+	}
 }
@@ -152,6 +152,19 @@ func (r *GormOutboxRepository) FindDead(ctx context.Context, page, pageSize int)
 	return entries, total, nil
 }
 
+// FindOldestDead retrieves up to limit dead letter entries, oldest first
+func (r *GormOutboxRepository) FindOldestDead(ctx context.Context, limit int) ([]*shared.OutboxEntry, error) {
+	var entries []*shared.OutboxEntry
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", shared.OutboxStatusDead).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 // FindByID retrieves a single outbox entry by ID
 func (r *GormOutboxRepository) FindByID(ctx context.Context, id uuid.UUID) (*shared.OutboxEntry, error) {
 	var entry shared.OutboxEntry
@@ -162,6 +175,30 @@ func (r *GormOutboxRepository) FindByID(ctx context.Context, id uuid.UUID) (*sha
 	return &entry, nil
 }
 
+// FindForReplay retrieves sent entries matching the filter, oldest first
+func (r *GormOutboxRepository) FindForReplay(ctx context.Context, filter shared.OutboxReplayFilter) ([]*shared.OutboxEntry, error) {
+	query := r.db.WithContext(ctx).
+		Where("status = ? AND created_at >= ? AND created_at < ?", shared.OutboxStatusSent, filter.From, filter.To)
+	if filter.TenantID != nil {
+		query = query.Where("tenant_id = ?", *filter.TenantID)
+	}
+	if len(filter.EventTypes) > 0 {
+		query = query.Where("event_type IN ?", filter.EventTypes)
+	}
+	if filter.AggregateType != "" {
+		query = query.Where("aggregate_type = ?", filter.AggregateType)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var entries []*shared.OutboxEntry
+	if err := query.Order("created_at ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 // CountByStatus returns count of entries for each status
 func (r *GormOutboxRepository) CountByStatus(ctx context.Context) (map[shared.OutboxStatus]int64, error) {
 	type statusCount struct {
@@ -0,0 +1,87 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// processedEventModel is a row of the processed_events table
+type processedEventModel struct {
+	EventKey    string     `gorm:"type:varchar(300);primaryKey"`
+	ProcessedAt time.Time  `gorm:"not null"`
+	ExpiresAt   *time.Time // nil if the mark never expires
+}
+
+// TableName specifies the table name for GORM
+func (processedEventModel) TableName() string {
+	return "processed_events"
+}
+
+// GormProcessedEventStore implements IdempotencyStore using GORM, for marks that must
+// outlive process restarts. The event_key primary key makes marking an event atomic.
+type GormProcessedEventStore struct {
+	db *gorm.DB
+}
+
+// NewGormProcessedEventStore creates a new GORM processed event store
+func NewGormProcessedEventStore(db *gorm.DB) *GormProcessedEventStore {
+	return &GormProcessedEventStore{db: db}
+}
+
+// MarkProcessed marks an event as processed with a TTL (zero never expires)
+// Returns true if the event was newly marked, false if it was already processed
+func (s *GormProcessedEventStore) MarkProcessed(ctx context.Context, eventID string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	model := &processedEventModel{EventKey: eventID, ProcessedAt: now}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		model.ExpiresAt = &expiresAt
+	}
+
+	// An expired mark is taken over; a live one leaves the row untouched
+	result := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"processed_at", "expires_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "processed_events.expires_at < ?", Vars: []any{now}},
+			}},
+		}).
+		Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// IsProcessed checks if an event has already been processed
+func (s *GormProcessedEventStore) IsProcessed(ctx context.Context, eventID string) (bool, error) {
+	var count int64
+	err := s.db.WithContext(ctx).
+		Model(&processedEventModel{}).
+		Where("event_key = ? AND (expires_at IS NULL OR expires_at >= ?)", eventID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Release removes the mark of an event, so it can be processed again
+func (s *GormProcessedEventStore) Release(ctx context.Context, eventID string) error {
+	return s.db.WithContext(ctx).
+		Where("event_key = ?", eventID).
+		Delete(&processedEventModel{}).Error
+}
+
+// Close is a no-op; the database connection is owned by the caller
+func (s *GormProcessedEventStore) Close() error {
+	return nil
+}
+
+// Ensure GormProcessedEventStore implements IdempotencyStore
+var _ shared.IdempotencyStore = (*GormProcessedEventStore)(nil)
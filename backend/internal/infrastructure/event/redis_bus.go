@@ -0,0 +1,305 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// Defaults for the Redis event bus
+const (
+	DefaultRedisEventStream        = "erp:events"
+	DefaultRedisEventConsumerGroup = "erp-backend"
+
+	defaultRedisReadCount    = 100
+	defaultRedisReadBlock    = 2 * time.Second
+	defaultRedisClaimIdle    = time.Minute
+	defaultRedisStreamMaxLen = 100000
+)
+
+// Redis stream message fields
+const (
+	redisFieldEventType = "event_type"
+	redisFieldEventID   = "event_id"
+	redisFieldPayload   = "payload"
+)
+
+// RedisEventBus implements EventBus on a Redis stream shared by all server instances.
+//
+// Publish appends events to the stream. Every instance reads the stream as a member
+// of one consumer group, so each event is handled once, by whichever instance the
+// group delivers it to, using that instance's locally subscribed handlers, so every
+// instance must subscribe the same handlers. Messages left unacknowledged by an
+// instance that died are claimed by the others after they have been idle for a while.
+type RedisEventBus struct {
+	client     *redis.Client
+	serializer *EventSerializer
+	local      *InMemoryEventBus
+	logger     *zap.Logger
+
+	stream    string
+	group     string
+	consumer  string
+	readCount int64
+	readBlock time.Duration
+	claimIdle time.Duration
+	maxLen    int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// RedisEventBusOption is a functional option for configuring the Redis event bus
+type RedisEventBusOption func(*RedisEventBus)
+
+// WithRedisEventStream sets the stream events are published to
+func WithRedisEventStream(stream string) RedisEventBusOption {
+	return func(b *RedisEventBus) {
+		if stream != "" {
+			b.stream = stream
+		}
+	}
+}
+
+// WithRedisEventConsumerGroup sets the consumer group shared by all instances
+func WithRedisEventConsumerGroup(group string) RedisEventBusOption {
+	return func(b *RedisEventBus) {
+		if group != "" {
+			b.group = group
+		}
+	}
+}
+
+// WithRedisEventConsumer sets this instance's consumer name (default: hostname plus a random suffix)
+func WithRedisEventConsumer(consumer string) RedisEventBusOption {
+	return func(b *RedisEventBus) {
+		if consumer != "" {
+			b.consumer = consumer
+		}
+	}
+}
+
+// WithRedisEventReadBlock sets how long a read waits for new events
+func WithRedisEventReadBlock(block time.Duration) RedisEventBusOption {
+	return func(b *RedisEventBus) {
+		if block > 0 {
+			b.readBlock = block
+		}
+	}
+}
+
+// NewRedisEventBus creates a Redis stream event bus.
+// The serializer must have every published event type registered.
+// Note: The caller retains ownership of the client and is responsible for closing it
+func NewRedisEventBus(
+	client *redis.Client,
+	serializer *EventSerializer,
+	logger *zap.Logger,
+	opts ...RedisEventBusOption,
+) *RedisEventBus {
+	b := &RedisEventBus{
+		client:     client,
+		serializer: serializer,
+		local:      NewInMemoryEventBus(logger),
+		logger:     logger,
+		stream:     DefaultRedisEventStream,
+		group:      DefaultRedisEventConsumerGroup,
+		consumer:   defaultRedisConsumerName(),
+		readCount:  defaultRedisReadCount,
+		readBlock:  defaultRedisReadBlock,
+		claimIdle:  defaultRedisClaimIdle,
+		maxLen:     defaultRedisStreamMaxLen,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// defaultRedisConsumerName returns a consumer name unique to this process
+func defaultRedisConsumerName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "erp"
+	}
+	return host + "-" + uuid.NewString()[:8]
+}
+
+// Publish appends events to the stream
+func (b *RedisEventBus) Publish(ctx context.Context, events ...shared.DomainEvent) error {
+	for _, event := range events {
+		payload, err := b.serializer.Serialize(event)
+		if err != nil {
+			return fmt.Errorf("failed to serialize event %s: %w", event.EventType(), err)
+		}
+
+		err = b.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: b.stream,
+			MaxLen: b.maxLen,
+			Approx: true,
+			Values: map[string]any{
+				redisFieldEventType: event.EventType(),
+				redisFieldEventID:   event.EventID().String(),
+				redisFieldPayload:   string(payload),
+			},
+		}).Err()
+		if err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.EventType(), err)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a handler on this instance
+func (b *RedisEventBus) Subscribe(handler shared.EventHandler, eventTypes ...string) {
+	b.local.Subscribe(handler, eventTypes...)
+}
+
+// Unsubscribe removes a handler from this instance
+func (b *RedisEventBus) Unsubscribe(handler shared.EventHandler) {
+	b.local.Unsubscribe(handler)
+}
+
+// Start creates the consumer group if needed and starts consuming the stream
+func (b *RedisEventBus) Start(ctx context.Context) error {
+	err := b.client.XGroupCreateMkStream(ctx, b.stream, b.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", b.group, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	b.cancel = cancel
+
+	b.wg.Add(1)
+	go b.consumeLoop(ctx)
+
+	b.logger.Info("redis event bus started",
+		zap.String("stream", b.stream),
+		zap.String("group", b.group),
+		zap.String("consumer", b.consumer),
+	)
+	return nil
+}
+
+// Stop stops consuming the stream, letting the event being handled finish
+func (b *RedisEventBus) Stop(ctx context.Context) error {
+	if b.cancel != nil {
+		b.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		b.logger.Info("redis event bus stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// consumeLoop reads and handles stream messages until the context is cancelled
+func (b *RedisEventBus) consumeLoop(ctx context.Context) {
+	defer b.wg.Done()
+
+	for ctx.Err() == nil {
+		b.claimStale(ctx)
+
+		streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    b.group,
+			Consumer: b.consumer,
+			Streams:  []string{b.stream, ">"},
+			Count:    b.readCount,
+			Block:    b.readBlock,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			b.logger.Error("failed to read event stream", zap.String("stream", b.stream), zap.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(b.readBlock):
+			}
+			continue
+		}
+
+		for _, stream := range streams {
+			b.handleMessages(ctx, stream.Messages)
+		}
+	}
+}
+
+// claimStale takes over messages another consumer left unacknowledged for too long
+func (b *RedisEventBus) claimStale(ctx context.Context) {
+	messages, _, err := b.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   b.stream,
+		Group:    b.group,
+		Consumer: b.consumer,
+		MinIdle:  b.claimIdle,
+		Start:    "0-0",
+		Count:    b.readCount,
+	}).Result()
+	if err != nil {
+		if ctx.Err() == nil && !errors.Is(err, redis.Nil) {
+			b.logger.Warn("failed to claim stale events", zap.String("stream", b.stream), zap.Error(err))
+		}
+		return
+	}
+	b.handleMessages(ctx, messages)
+}
+
+// handleMessages delivers messages to the local handlers and acknowledges them.
+// On shutdown the message being handled is finished; the rest stay pending and
+// are claimed by another instance.
+func (b *RedisEventBus) handleMessages(ctx context.Context, messages []redis.XMessage) {
+	handleCtx := context.WithoutCancel(ctx)
+	for _, msg := range messages {
+		if ctx.Err() != nil {
+			return
+		}
+		b.handleMessage(handleCtx, msg)
+
+		if err := b.client.XAck(handleCtx, b.stream, b.group, msg.ID).Err(); err != nil {
+			b.logger.Error("failed to acknowledge event",
+				zap.String("message_id", msg.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// handleMessage deserializes a message and delivers it to the local handlers.
+// Undecodable messages are logged and dropped; they would fail on every instance.
+func (b *RedisEventBus) handleMessage(ctx context.Context, msg redis.XMessage) {
+	eventType, _ := msg.Values[redisFieldEventType].(string)
+	payload, _ := msg.Values[redisFieldPayload].(string)
+
+	event, err := b.serializer.Deserialize(eventType, []byte(payload))
+	if err != nil {
+		b.logger.Error("failed to deserialize event from stream",
+			zap.String("message_id", msg.ID),
+			zap.String("event_type", eventType),
+			zap.Error(err),
+		)
+		return
+	}
+
+	b.local.deliver(ctx, event)
+}
+
+// Ensure RedisEventBus implements EventBus
+var _ shared.EventBus = (*RedisEventBus)(nil)
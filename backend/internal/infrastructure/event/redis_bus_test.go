@@ -0,0 +1,119 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestRedisClient(t *testing.T) *redis.Client {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func newTestRedisEventBus(client *redis.Client, consumer string) *RedisEventBus {
+	serializer := NewEventSerializer()
+	serializer.Register("TestEvent", &testEvent{})
+	return NewRedisEventBus(client, serializer, zap.NewNop(),
+		WithRedisEventConsumer(consumer),
+		WithRedisEventReadBlock(10*time.Millisecond),
+	)
+}
+
+func stopRedisEventBus(t *testing.T, bus *RedisEventBus) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Stop(ctx))
+}
+
+func TestRedisEventBus_PublishSubscribe(t *testing.T) {
+	client := newTestRedisClient(t)
+	bus := newTestRedisEventBus(client, "node-1")
+
+	handler := newTestHandler("TestEvent")
+	bus.Subscribe(handler)
+	require.NoError(t, bus.Start(context.Background()))
+	defer stopRedisEventBus(t, bus)
+
+	event := newTestEvent("TestEvent", uuid.New())
+	event.Data = "round trip"
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	require.Eventually(t, func() bool {
+		return len(handler.getHandled()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	received, ok := handler.getHandled()[0].(*testEvent)
+	require.True(t, ok)
+	assert.Equal(t, event.EventID(), received.EventID())
+	assert.Equal(t, event.AggregateID(), received.AggregateID())
+	assert.Equal(t, event.TenantID(), received.TenantID())
+	assert.Equal(t, "round trip", received.Data)
+
+	// Acknowledged once handled
+	pending, err := client.XPending(context.Background(), DefaultRedisEventStream, DefaultRedisEventConsumerGroup).Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
+func TestRedisEventBus_EventHandledOnceAcrossInstances(t *testing.T) {
+	client := newTestRedisClient(t)
+
+	// Two server instances with the same subscriptions, sharing one consumer group
+	node1 := newTestRedisEventBus(client, "node-1")
+	node2 := newTestRedisEventBus(client, "node-2")
+	handler1, handler2 := newTestHandler("TestEvent"), newTestHandler("TestEvent")
+	node1.Subscribe(handler1)
+	node2.Subscribe(handler2)
+
+	require.NoError(t, node1.Start(context.Background()))
+	defer stopRedisEventBus(t, node1)
+	require.NoError(t, node2.Start(context.Background()))
+	defer stopRedisEventBus(t, node2)
+
+	// Events produced on one node
+	var published []shared.DomainEvent
+	for i := 0; i < 20; i++ {
+		published = append(published, newTestEvent("TestEvent", uuid.New()))
+	}
+	require.NoError(t, node1.Publish(context.Background(), published...))
+
+	require.Eventually(t, func() bool {
+		return len(handler1.getHandled())+len(handler2.getHandled()) >= len(published)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Each event is handled exactly once across the instances
+	seen := make(map[uuid.UUID]int)
+	for _, event := range append(handler1.getHandled(), handler2.getHandled()...) {
+		seen[event.EventID()]++
+	}
+	require.Len(t, seen, len(published))
+	for _, event := range published {
+		assert.Equal(t, 1, seen[event.EventID()])
+	}
+}
+
+func TestRedisEventBus_StartIsIdempotentAcrossInstances(t *testing.T) {
+	client := newTestRedisClient(t)
+
+	// The second instance finds the consumer group already created
+	first := newTestRedisEventBus(client, "node-1")
+	require.NoError(t, first.Start(context.Background()))
+	defer stopRedisEventBus(t, first)
+
+	second := newTestRedisEventBus(client, "node-2")
+	require.NoError(t, second.Start(context.Background()))
+	defer stopRedisEventBus(t, second)
+}
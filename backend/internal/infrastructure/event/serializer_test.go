@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -129,3 +130,25 @@ func TestEventSerializer_RoundTrip_PreservesAllFields(t *testing.T) {
 	assert.Equal(t, original.Data, event.Data)
 	assert.Equal(t, original.Counter, event.Counter)
 }
+
+func TestRegisterAllEvents_ExpenseApprovalLevelCompleted(t *testing.T) {
+	serializer := NewEventSerializer()
+	RegisterAllEvents(serializer)
+
+	event := &finance.ExpenseRecordApprovalLevelCompletedEvent{
+		BaseDomainEvent:    shared.NewBaseDomainEvent("ExpenseRecordApprovalLevelCompleted", "ExpenseRecord", uuid.New(), uuid.New()),
+		ExpenseID:          uuid.New(),
+		ExpenseNumber:      "EXP-001",
+		Level:              1,
+		RequiredApprovals:  2,
+		RemainingApprovals: 1,
+	}
+	data, err := serializer.Serialize(event)
+	require.NoError(t, err)
+
+	// Events read back from the outbox or the stream must deserialize
+	restored, err := serializer.Deserialize(event.EventType(), data)
+	require.NoError(t, err)
+	assert.Equal(t, event.ExpenseNumber, restored.(*finance.ExpenseRecordApprovalLevelCompletedEvent).ExpenseNumber)
+	assert.Equal(t, 1, restored.(*finance.ExpenseRecordApprovalLevelCompletedEvent).RemainingApprovals)
+}
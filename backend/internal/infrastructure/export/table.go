@@ -0,0 +1,189 @@
+// Package export converts report data into tables and writes them as CSV or XLSX files.
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Format is a tabular export file format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat parses a format name, case-insensitively
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("unsupported export format %q: must be csv or xlsx", name)
+}
+
+// ContentType returns the MIME type of files in the format
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Extension returns the file name extension of the format, without the dot
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Cell is a formatted table value
+type Cell struct {
+	Value   string
+	Numeric bool // Written as a number in spreadsheets
+}
+
+// Table is the tabular form of report data
+type Table struct {
+	Headers []string
+	Rows    [][]Cell
+}
+
+var (
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+	timeType    = reflect.TypeOf(time.Time{})
+	stringer    = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// TableFrom converts a struct, a pointer to a struct or a slice of structs into a table.
+// Each exported field is a column headed by its JSON name; fields tagged json:"-" are skipped.
+// A single struct becomes a one-row table.
+//
+// Decimals are written with their exact digits, so amounts keep their precision
+// instead of going through float64.
+func TableFrom(data any) (*Table, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, fmt.Errorf("cannot export nil %s", v.Type())
+		}
+		v = v.Elem()
+	}
+
+	var rows []reflect.Value
+	rowType := v.Type()
+	switch v.Kind() {
+	case reflect.Struct:
+		rows = []reflect.Value{v}
+	case reflect.Slice, reflect.Array:
+		rowType = rowType.Elem()
+		for i := 0; i < v.Len(); i++ {
+			rows = append(rows, v.Index(i))
+		}
+	default:
+		return nil, fmt.Errorf("cannot export %s: must be a struct or a slice of structs", v.Type())
+	}
+
+	isPointer := rowType.Kind() == reflect.Pointer
+	if isPointer {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot export rows of %s: must be structs", rowType)
+	}
+
+	fields, headers := columns(rowType)
+	table := &Table{Headers: headers, Rows: make([][]Cell, 0, len(rows))}
+	for _, row := range rows {
+		if isPointer {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+		cells := make([]Cell, len(fields))
+		for i, index := range fields {
+			cells[i] = formatCell(row.Field(index))
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+	return table, nil
+}
+
+// columns returns the indexes and headers of the exported fields of a struct type
+func columns(t reflect.Type) ([]int, []string) {
+	var fields []int
+	var headers []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		fields = append(fields, i)
+		headers = append(headers, name)
+	}
+	return fields, headers
+}
+
+// formatCell formats a field value; nil pointers are empty cells
+func formatCell(v reflect.Value) Cell {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return Cell{}
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case decimalType:
+		return Cell{Value: v.Interface().(decimal.Decimal).String(), Numeric: true}
+	case timeType:
+		return Cell{Value: formatTime(v.Interface().(time.Time))}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return Cell{Value: v.String()}
+	case reflect.Bool:
+		return Cell{Value: strconv.FormatBool(v.Bool())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Cell{Value: strconv.FormatInt(v.Int(), 10), Numeric: true}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Cell{Value: strconv.FormatUint(v.Uint(), 10), Numeric: true}
+	case reflect.Float32, reflect.Float64:
+		// Shortest representation that reads back as the same float, never in exponent form
+		return Cell{Value: strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), Numeric: true}
+	}
+
+	if v.Type().Implements(stringer) {
+		return Cell{Value: v.Interface().(fmt.Stringer).String()}
+	}
+	return Cell{Value: fmt.Sprint(v.Interface())}
+}
+
+// formatTime formats dates without a time of day as YYYY-MM-DD, other times as RFC 3339
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRankingRow struct {
+	Rank        int             `json:"rank"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	ProductName string          `json:"product_name"`
+	Category    string          `json:"category_name,omitempty"`
+	TotalAmount decimal.Decimal `json:"total_amount"`
+	Margin      float64         `json:"margin"`
+	SoldOn      time.Time       `json:"sold_on"`
+	Internal    string          `json:"-"`
+	LastOrderAt *time.Time      `json:"last_order_at"`
+}
+
+func testRankingRows() []testRankingRow {
+	return []testRankingRow{
+		{
+			Rank:        1,
+			ProductID:   uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+			ProductName: "Widget, large",
+			TotalAmount: decimal.RequireFromString("12345678901234.5678"),
+			Margin:      0.3,
+			SoldOn:      time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+			Internal:    "secret",
+		},
+		{
+			Rank:        2,
+			ProductID:   uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"),
+			ProductName: "Gadget",
+			Category:    "Tools",
+			TotalAmount: decimal.RequireFromString("0.0001"),
+			Margin:      12.5,
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	table, err := TableFrom(testRankingRows())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, table))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, []string{"rank", "product_id", "product_name", "category_name", "total_amount", "margin", "sold_on", "last_order_at"}, records[0])
+	assert.Equal(t, []string{
+		"1", "550e8400-e29b-41d4-a716-446655440000", "Widget, large", "",
+		"12345678901234.5678", "0.3", "2026-01-31", "",
+	}, records[1])
+	// Small decimals are written in full rather than in exponent form
+	assert.Equal(t, "0.0001", records[2][4])
+	assert.Equal(t, "12.5", records[2][5])
+	assert.Equal(t, "", records[2][6])
+}
+
+func TestTableFrom_SingleStruct(t *testing.T) {
+	row := testRankingRows()[0]
+
+	table, err := TableFrom(&row)
+	require.NoError(t, err)
+	require.Len(t, table.Rows, 1)
+	assert.Equal(t, Cell{Value: "12345678901234.5678", Numeric: true}, table.Rows[0][4])
+	assert.Equal(t, Cell{Value: "Widget, large"}, table.Rows[0][2])
+}
+
+func TestTableFrom_Invalid(t *testing.T) {
+	_, err := TableFrom([]int{1, 2})
+	assert.Error(t, err)
+
+	var row *testRankingRow
+	_, err = TableFrom(row)
+	assert.Error(t, err)
+}
+
+func TestWriteXLSX(t *testing.T) {
+	table, err := TableFrom(testRankingRows())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteXLSX(&buf, table))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	var sheet string
+	for _, f := range archive.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			sheet = string(data)
+		}
+	}
+	require.NotEmpty(t, sheet)
+
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">rank</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="E2"><v>12345678901234.5678</v></c>`)
+	assert.Contains(t, sheet, `<c r="C2" t="inlineStr"><is><t xml:space="preserve">Widget, large</t></is></c>`)
+	assert.Equal(t, 3, strings.Count(sheet, "<row "))
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("CSV")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	_, err = ParseFormat("pdf")
+	assert.Error(t, err)
+}
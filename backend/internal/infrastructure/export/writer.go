@@ -0,0 +1,157 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Write writes a table to w in the given format
+func Write(w io.Writer, format Format, table *Table) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, table)
+	case FormatXLSX:
+		return WriteXLSX(w, table)
+	}
+	return fmt.Errorf("unsupported export format %q", format)
+}
+
+// WriteCSV writes a table as CSV with a header row
+func WriteCSV(w io.Writer, table *Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Headers); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	record := make([]string, len(table.Headers))
+	for _, row := range table.Rows {
+		for i, cell := range row {
+			record[i] = cell.Value
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Static parts of an XLSX workbook with a single worksheet
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+)
+
+// WriteXLSX writes a table as an XLSX workbook with a single sheet whose first row holds the headers.
+// Numeric cells are written with their exact digits, so spreadsheets read them as numbers
+// without a float conversion on the way.
+func WriteXLSX(w io.Writer, table *Table) error {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return fmt.Errorf("failed to create worksheet: %w", err)
+	}
+	if err := writeSheet(sheet, table); err != nil {
+		return fmt.Errorf("failed to write worksheet: %w", err)
+	}
+
+	return archive.Close()
+}
+
+// writeSheet writes the worksheet XML of a table
+func writeSheet(w io.Writer, table *Table) error {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	headers := make([]Cell, len(table.Headers))
+	for i, header := range table.Headers {
+		headers[i] = Cell{Value: header}
+	}
+	writeRow(&b, 1, headers)
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+
+	for i, row := range table.Rows {
+		b.Reset()
+		writeRow(&b, i+2, row)
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, `</sheetData></worksheet>`)
+	return err
+}
+
+// writeRow writes a worksheet row; rowNum is 1-based
+func writeRow(b *strings.Builder, rowNum int, cells []Cell) {
+	fmt.Fprintf(b, `<row r="%d">`, rowNum)
+	for col, cell := range cells {
+		ref := columnName(col) + fmt.Sprint(rowNum)
+		switch {
+		case cell.Value == "":
+			// Empty cells are omitted
+		case cell.Numeric:
+			fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, cell.Value)
+		default:
+			fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			_ = xml.EscapeText(b, []byte(cell.Value))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+}
+
+// columnName returns the spreadsheet name of a 0-based column index (A, B, ..., Z, AA, ...)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
@@ -0,0 +1,290 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// ErrChecksumMismatch is returned when an applied migration was edited or removed after it ran
+var ErrChecksumMismatch = errors.New("applied migration checksum mismatch")
+
+// ChecksumStatus is the result of comparing an applied migration with its file
+type ChecksumStatus string
+
+const (
+	// ChecksumOK means the file matches the checksum recorded when it was applied
+	ChecksumOK ChecksumStatus = "ok"
+	// ChecksumMismatch means the file changed after it was applied
+	ChecksumMismatch ChecksumStatus = "mismatch"
+	// ChecksumMissingFile means an applied migration's file no longer exists
+	ChecksumMissingFile ChecksumStatus = "missing_file"
+	// ChecksumUnrecorded means the migration was applied before checksums were tracked
+	ChecksumUnrecorded ChecksumStatus = "unrecorded"
+)
+
+// ChecksumResult is the verification result for a single applied migration
+type ChecksumResult struct {
+	Version    uint
+	Identifier string
+	Status     ChecksumStatus
+	Recorded   string
+	Actual     string
+}
+
+// ChecksumReport lists the verification results of all applied migrations
+type ChecksumReport struct {
+	Results []ChecksumResult
+}
+
+// Problems returns the migrations that changed or disappeared after they were applied
+func (r *ChecksumReport) Problems() []ChecksumResult {
+	problems := make([]ChecksumResult, 0)
+	for _, result := range r.Results {
+		if result.Status == ChecksumMismatch || result.Status == ChecksumMissingFile {
+			problems = append(problems, result)
+		}
+	}
+	return problems
+}
+
+// Err returns ErrChecksumMismatch listing the offending versions, or nil
+func (r *ChecksumReport) Err() error {
+	problems := r.Problems()
+	if len(problems) == 0 {
+		return nil
+	}
+	versions := make([]uint, 0, len(problems))
+	for _, p := range problems {
+		versions = append(versions, p.Version)
+	}
+	return fmt.Errorf("%w: versions %v", ErrChecksumMismatch, versions)
+}
+
+// Write prints one line per applied migration
+func (r *ChecksumReport) Write(w io.Writer) error {
+	for _, result := range r.Results {
+		detail := ""
+		switch result.Status {
+		case ChecksumMismatch:
+			detail = fmt.Sprintf(" (recorded %s, file %s)", shortChecksum(result.Recorded), shortChecksum(result.Actual))
+		case ChecksumMissingFile:
+			detail = fmt.Sprintf(" (recorded %s)", shortChecksum(result.Recorded))
+		}
+		if _, err := fmt.Fprintf(w, "  %-12s %d %s%s\n", result.Status, result.Version, result.Identifier, detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Checksum returns the hex-encoded SHA-256 of a migration's up SQL
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordedChecksum is a row of the schema_migration_checksums table
+type recordedChecksum struct {
+	identifier string
+	checksum   string
+}
+
+// VerifyChecksums compares the checksums recorded for applied migrations with
+// the files on disk. It only reads from the database.
+func VerifyChecksums(db *sql.DB, migrationsPath string) (*ChecksumReport, error) {
+	limit, err := appliedLimit(db)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded, err := loadRecordedChecksums(db)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, checksums, err := loadSourceChecksums(migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ChecksumReport{}
+	for _, m := range migrations {
+		if int64(m.version) > limit {
+			continue
+		}
+		result := ChecksumResult{
+			Version:    m.version,
+			Identifier: m.identifier,
+			Actual:     checksums[m.version],
+		}
+		rec, ok := recorded[m.version]
+		delete(recorded, m.version)
+		switch {
+		case !ok:
+			result.Status = ChecksumUnrecorded
+		case rec.checksum != result.Actual:
+			result.Status = ChecksumMismatch
+			result.Recorded = rec.checksum
+		default:
+			result.Status = ChecksumOK
+			result.Recorded = rec.checksum
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	for version, rec := range recorded {
+		if int64(version) > limit {
+			continue
+		}
+		report.Results = append(report.Results, ChecksumResult{
+			Version:    version,
+			Identifier: rec.identifier,
+			Status:     ChecksumMissingFile,
+			Recorded:   rec.checksum,
+		})
+	}
+
+	sort.Slice(report.Results, func(i, j int) bool { return report.Results[i].Version < report.Results[j].Version })
+	return report, nil
+}
+
+// RecordChecksums brings the checksum table in line with schema_migrations:
+// it records applied migrations that have no checksum yet and forgets rolled
+// back ones. Existing checksums are kept unless overwrite is set.
+func RecordChecksums(db *sql.DB, migrationsPath string, overwrite bool) error {
+	limit, err := appliedLimit(db)
+	if err != nil {
+		return err
+	}
+
+	migrations, checksums, err := loadSourceChecksums(migrationsPath)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migration_checksums (
+		version BIGINT PRIMARY KEY,
+		identifier TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`); err != nil {
+		return fmt.Errorf("failed to create checksum table: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM schema_migration_checksums WHERE version > $1`, limit); err != nil {
+		return fmt.Errorf("failed to remove rolled back checksums: %w", err)
+	}
+
+	insert := `INSERT INTO schema_migration_checksums (version, identifier, checksum)
+		VALUES ($1, $2, $3) ON CONFLICT (version) DO NOTHING`
+	if overwrite {
+		insert = `INSERT INTO schema_migration_checksums (version, identifier, checksum)
+		VALUES ($1, $2, $3) ON CONFLICT (version) DO UPDATE
+		SET identifier = EXCLUDED.identifier, checksum = EXCLUDED.checksum, applied_at = NOW()
+		WHERE schema_migration_checksums.checksum <> EXCLUDED.checksum`
+	}
+	for _, m := range migrations {
+		if int64(m.version) > limit {
+			break
+		}
+		if _, err := tx.Exec(insert, int64(m.version), m.identifier, checksums[m.version]); err != nil {
+			return fmt.Errorf("failed to record checksum for version %d: %w", m.version, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit checksums: %w", err)
+	}
+	return nil
+}
+
+// appliedLimit returns the highest version that is fully applied.
+// A dirty version failed part way through and does not count.
+func appliedLimit(db *sql.DB) (int64, error) {
+	current, dirty, err := readVersion(db)
+	if err != nil {
+		return 0, err
+	}
+	limit := int64(current)
+	if dirty {
+		limit--
+	}
+	return limit, nil
+}
+
+// loadRecordedChecksums reads the checksum table. A missing table means
+// nothing has been recorded yet.
+func loadRecordedChecksums(db *sql.DB) (map[uint]recordedChecksum, error) {
+	recorded := make(map[uint]recordedChecksum)
+
+	rows, err := db.Query(`SELECT version, identifier, checksum FROM schema_migration_checksums`)
+	if err != nil {
+		if isUndefinedTable(err) {
+			return recorded, nil
+		}
+		return nil, fmt.Errorf("failed to read migration checksums: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			version int64
+			rec     recordedChecksum
+		)
+		if err := rows.Scan(&version, &rec.identifier, &rec.checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan migration checksum: %w", err)
+		}
+		recorded[uint(version)] = rec
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read migration checksums: %w", err)
+	}
+	return recorded, nil
+}
+
+// loadSourceChecksums lists the migrations on disk with the checksums of their up files
+func loadSourceChecksums(migrationsPath string) ([]sourceMigration, map[uint]string, error) {
+	src, err := source.Open("file://" + migrationsPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+	defer src.Close()
+
+	migrations, err := listSourceMigrations(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checksums := make(map[uint]string, len(migrations))
+	for _, m := range migrations {
+		pm, err := readPlannedMigration(src, m, DirectionUp)
+		if err != nil {
+			return nil, nil, err
+		}
+		checksums[m.version] = Checksum([]byte(pm.SQL))
+	}
+	return migrations, checksums, nil
+}
+
+// shortChecksum abbreviates a checksum for display
+func shortChecksum(checksum string) string {
+	if len(checksum) > 12 {
+		return checksum[:12]
+	}
+	return checksum
+}
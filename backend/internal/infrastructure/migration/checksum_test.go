@@ -0,0 +1,192 @@
+package migration
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const checksumQuery = `SELECT version, identifier, checksum FROM schema_migration_checksums`
+
+func newChecksumMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+	return db, mock
+}
+
+func expectVersion(mock sqlmock.Sqlmock, version int64, dirty bool) {
+	mock.ExpectQuery(regexp.QuoteMeta(versionQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(version, dirty))
+}
+
+// expectRecorded makes the checksum query return the given test migrations' checksums
+func expectRecorded(mock sqlmock.Sqlmock, checksums map[uint]string, versions ...uint) {
+	identifiers := map[uint]string{1: "create_users", 2: "create_orders", 3: "add_order_status", 5: "create_invoices"}
+	rows := sqlmock.NewRows([]string{"version", "identifier", "checksum"})
+	for _, v := range versions {
+		rows.AddRow(int64(v), identifiers[v], checksums[v])
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(checksumQuery)).WillReturnRows(rows)
+}
+
+// originalChecksums returns the checksums of the untouched test migrations
+func originalChecksums(t *testing.T, dir string) map[uint]string {
+	t.Helper()
+	_, checksums, err := loadSourceChecksums(dir)
+	require.NoError(t, err)
+	return checksums
+}
+
+func statuses(report *ChecksumReport) map[uint]ChecksumStatus {
+	result := make(map[uint]ChecksumStatus, len(report.Results))
+	for _, r := range report.Results {
+		result[r.Version] = r.Status
+	}
+	return result
+}
+
+func TestChecksum(t *testing.T) {
+	assert.Equal(t, Checksum([]byte("SELECT 1;")), Checksum([]byte("SELECT 1;")))
+	assert.NotEqual(t, Checksum([]byte("SELECT 1;")), Checksum([]byte("SELECT 2;")))
+	assert.Len(t, Checksum(nil), 64)
+}
+
+func TestVerifyChecksums_Clean(t *testing.T) {
+	dir := writeTestMigrations(t)
+	checksums := originalChecksums(t, dir)
+	db, mock := newChecksumMock(t)
+
+	expectVersion(mock, 3, false)
+	expectRecorded(mock, checksums, 1, 2, 3)
+
+	report, err := VerifyChecksums(db, dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]ChecksumStatus{1: ChecksumOK, 2: ChecksumOK, 3: ChecksumOK}, statuses(report))
+	assert.NoError(t, report.Err())
+}
+
+func TestVerifyChecksums_TamperedFile(t *testing.T) {
+	dir := writeTestMigrations(t)
+	checksums := originalChecksums(t, dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "000002_create_orders.up.sql"),
+		[]byte("-- up 2\nALTER TABLE orders ADD COLUMN note TEXT;"), 0644))
+
+	db, mock := newChecksumMock(t)
+	expectVersion(mock, 3, false)
+	expectRecorded(mock, checksums, 1, 2, 3)
+
+	report, err := VerifyChecksums(db, dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]ChecksumStatus{1: ChecksumOK, 2: ChecksumMismatch, 3: ChecksumOK}, statuses(report))
+
+	problems := report.Problems()
+	require.Len(t, problems, 1)
+	assert.Equal(t, checksums[2], problems[0].Recorded)
+	assert.NotEqual(t, problems[0].Recorded, problems[0].Actual)
+	assert.ErrorIs(t, report.Err(), ErrChecksumMismatch)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf))
+	assert.Contains(t, buf.String(), "mismatch     2 create_orders (recorded "+checksums[2][:12])
+}
+
+func TestVerifyChecksums_MissingFile(t *testing.T) {
+	dir := writeTestMigrations(t)
+	checksums := originalChecksums(t, dir)
+	require.NoError(t, os.Remove(filepath.Join(dir, "000003_add_order_status.up.sql")))
+	require.NoError(t, os.Remove(filepath.Join(dir, "000003_add_order_status.down.sql")))
+
+	db, mock := newChecksumMock(t)
+	expectVersion(mock, 5, false)
+	expectRecorded(mock, checksums, 1, 2, 3, 5)
+
+	report, err := VerifyChecksums(db, dir)
+	require.NoError(t, err)
+	assert.Equal(t, ChecksumMissingFile, statuses(report)[3])
+	assert.ErrorIs(t, report.Err(), ErrChecksumMismatch)
+}
+
+func TestVerifyChecksums_Unrecorded(t *testing.T) {
+	dir := writeTestMigrations(t)
+	db, mock := newChecksumMock(t)
+
+	expectVersion(mock, 2, false)
+	mock.ExpectQuery(regexp.QuoteMeta(checksumQuery)).WillReturnError(&pq.Error{Code: "42P01"})
+
+	report, err := VerifyChecksums(db, dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]ChecksumStatus{1: ChecksumUnrecorded, 2: ChecksumUnrecorded}, statuses(report))
+	assert.NoError(t, report.Err(), "migrations applied before tracking are not errors")
+}
+
+func TestVerifyChecksums_IgnoresDirtyAndRolledBack(t *testing.T) {
+	dir := writeTestMigrations(t)
+	checksums := originalChecksums(t, dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "000003_add_order_status.up.sql"), []byte("-- fixed"), 0644))
+
+	db, mock := newChecksumMock(t)
+	expectVersion(mock, 3, true)
+	expectRecorded(mock, checksums, 1, 2, 3, 5)
+
+	report, err := VerifyChecksums(db, dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]ChecksumStatus{1: ChecksumOK, 2: ChecksumOK}, statuses(report))
+}
+
+func TestRecordChecksums(t *testing.T) {
+	dir := writeTestMigrations(t)
+	checksums := originalChecksums(t, dir)
+	db, mock := newChecksumMock(t)
+
+	expectVersion(mock, 2, false)
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migration_checksums").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migration_checksums WHERE version > \\$1").
+		WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO schema_migration_checksums .* DO NOTHING").
+		WithArgs(int64(1), "create_users", checksums[1]).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO schema_migration_checksums .* DO NOTHING").
+		WithArgs(int64(2), "create_orders", checksums[2]).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, RecordChecksums(db, dir, false))
+}
+
+func TestMigrator_VerifyChecksums(t *testing.T) {
+	dir := writeTestMigrations(t)
+	checksums := originalChecksums(t, dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "000001_create_users.up.sql"), []byte("-- edited"), 0644))
+
+	t.Run("refuses to migrate", func(t *testing.T) {
+		db, mock := newChecksumMock(t)
+		expectVersion(mock, 2, false)
+		expectRecorded(mock, checksums, 1, 2)
+
+		m := &Migrator{db: db, migrationsPath: dir, logger: zap.NewNop()}
+		assert.ErrorIs(t, m.verifyChecksums(), ErrChecksumMismatch)
+	})
+
+	t.Run("force proceeds", func(t *testing.T) {
+		db, mock := newChecksumMock(t)
+		expectVersion(mock, 2, false)
+		expectRecorded(mock, checksums, 1, 2)
+
+		m := &Migrator{db: db, migrationsPath: dir, logger: zap.NewNop()}
+		m.SetForceChecksum(true)
+		assert.NoError(t, m.verifyChecksums())
+	})
+}
@@ -10,10 +10,16 @@ import (
 	"go.uber.org/zap"
 )
 
-// Migrator handles database migrations using golang-migrate
+// Migrator handles database migrations using golang-migrate.
+// It also tracks a checksum of every applied migration and refuses to
+// migrate up while an applied migration's file has changed.
 type Migrator struct {
-	migrate *migrate.Migrate
-	logger  *zap.Logger
+	migrate        *migrate.Migrate
+	db             *sql.DB
+	ownsDB         bool
+	migrationsPath string
+	forceChecksum  bool
+	logger         *zap.Logger
 }
 
 // Config holds migration configuration
@@ -39,36 +45,52 @@ func New(db *sql.DB, migrationsPath string, logger *zap.Logger) (*Migrator, erro
 	}
 
 	return &Migrator{
-		migrate: m,
-		logger:  logger,
+		migrate:        m,
+		db:             db,
+		migrationsPath: migrationsPath,
+		logger:         logger,
 	}, nil
 }
 
 // NewFromURL creates a Migrator from database URL
 func NewFromURL(databaseURL, migrationsPath string, logger *zap.Logger) (*Migrator, error) {
-	m, err := migrate.New(
-		fmt.Sprintf("file://%s", migrationsPath),
-		databaseURL,
-	)
+	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	return &Migrator{
-		migrate: m,
-		logger:  logger,
-	}, nil
+	m, err := New(db, migrationsPath, logger)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	m.ownsDB = true
+	return m, nil
+}
+
+// SetForceChecksum lets migrations run even when an applied migration's
+// checksum no longer matches its file. The new checksums are recorded.
+func (m *Migrator) SetForceChecksum(force bool) {
+	m.forceChecksum = force
 }
 
 // Up runs all pending migrations
 func (m *Migrator) Up() error {
 	m.logger.Info("Running migrations up")
 
+	if err := m.verifyChecksums(); err != nil {
+		return err
+	}
+
 	err := m.migrate.Up()
 	if err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("migration up failed: %w", err)
 	}
 
+	if err := m.recordChecksums(); err != nil {
+		return err
+	}
+
 	if err == migrate.ErrNoChange {
 		m.logger.Info("No migrations to apply")
 		return nil
@@ -96,6 +118,10 @@ func (m *Migrator) Down() error {
 		return fmt.Errorf("migration down failed: %w", err)
 	}
 
+	if err := m.recordChecksums(); err != nil {
+		return err
+	}
+
 	if err == migrate.ErrNoChange {
 		m.logger.Info("No migrations to roll back")
 		return nil
@@ -109,11 +135,21 @@ func (m *Migrator) Down() error {
 func (m *Migrator) Steps(n int) error {
 	m.logger.Info("Running migration steps", zap.Int("steps", n))
 
+	if n > 0 {
+		if err := m.verifyChecksums(); err != nil {
+			return err
+		}
+	}
+
 	err := m.migrate.Steps(n)
 	if err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("migration steps failed: %w", err)
 	}
 
+	if err := m.recordChecksums(); err != nil {
+		return err
+	}
+
 	if err == migrate.ErrNoChange {
 		m.logger.Info("No migrations to apply")
 		return nil
@@ -136,11 +172,25 @@ func (m *Migrator) Steps(n int) error {
 func (m *Migrator) GoTo(version uint) error {
 	m.logger.Info("Migrating to version", zap.Uint("target_version", version))
 
-	err := m.migrate.Migrate(version)
+	current, _, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version > current {
+		if err := m.verifyChecksums(); err != nil {
+			return err
+		}
+	}
+
+	err = m.migrate.Migrate(version)
 	if err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("migration to version %d failed: %w", version, err)
 	}
 
+	if err := m.recordChecksums(); err != nil {
+		return err
+	}
+
 	if err == migrate.ErrNoChange {
 		m.logger.Info("Already at target version")
 		return nil
@@ -172,6 +222,10 @@ func (m *Migrator) Force(version int) error {
 		return fmt.Errorf("failed to force version %d: %w", version, err)
 	}
 
+	if err := m.recordChecksums(); err != nil {
+		return err
+	}
+
 	m.logger.Info("Migration version forced", zap.Int("version", version))
 	return nil
 }
@@ -199,5 +253,36 @@ func (m *Migrator) Close() error {
 	if dbErr != nil {
 		return fmt.Errorf("failed to close database: %w", dbErr)
 	}
+	if m.ownsDB {
+		if err := m.db.Close(); err != nil {
+			return fmt.Errorf("failed to close database: %w", err)
+		}
+	}
+	return nil
+}
+
+// verifyChecksums fails with ErrChecksumMismatch if an applied migration
+// was edited or removed, unless checksum verification is forced
+func (m *Migrator) verifyChecksums() error {
+	report, err := VerifyChecksums(m.db, m.migrationsPath)
+	if err != nil {
+		return fmt.Errorf("failed to verify migration checksums: %w", err)
+	}
+
+	if err := report.Err(); err != nil {
+		if !m.forceChecksum {
+			return fmt.Errorf("%w (run 'migrate verify' for details, or pass -force-checksum to proceed)", err)
+		}
+		m.logger.Warn("Proceeding despite changed applied migrations", zap.Error(err))
+	}
+	return nil
+}
+
+// recordChecksums records the checksums of newly applied migrations and
+// drops those of rolled back ones
+func (m *Migrator) recordChecksums() error {
+	if err := RecordChecksums(m.db, m.migrationsPath, m.forceChecksum); err != nil {
+		return fmt.Errorf("failed to record migration checksums: %w", err)
+	}
 	return nil
 }
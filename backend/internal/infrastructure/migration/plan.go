@@ -0,0 +1,282 @@
+package migration
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/lib/pq"
+)
+
+// ErrUnknownVersion is returned when a version is not present in the migrations directory
+var ErrUnknownVersion = errors.New("migration version not found")
+
+// Direction is the direction a planned migration runs in
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// PlannedMigration is a single migration that a plan would execute
+type PlannedMigration struct {
+	Version    uint
+	Identifier string
+	Direction  Direction
+	SQL        string
+}
+
+// Plan describes the migrations that would run to move the database from
+// one version to another, without applying them
+type Plan struct {
+	From       uint
+	To         uint
+	Dirty      bool
+	Migrations []PlannedMigration
+}
+
+// Write prints the plan as an annotated SQL script
+func (p *Plan) Write(w io.Writer) error {
+	state := ""
+	if p.Dirty {
+		state = " (dirty)"
+	}
+	if _, err := fmt.Fprintf(w, "-- Migration plan: version %d%s -> %d\n", p.From, state, p.To); err != nil {
+		return err
+	}
+	if p.Dirty {
+		if _, err := fmt.Fprintf(w, "-- WARNING: database is dirty at version %d; it is treated as not applied.\n"+
+			"-- Resolve it with 'migrate force <version>' before applying this plan.\n", p.From); err != nil {
+			return err
+		}
+	}
+	if len(p.Migrations) == 0 {
+		_, err := fmt.Fprintln(w, "-- No migrations to run")
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "-- %d migration(s) to run\n", len(p.Migrations)); err != nil {
+		return err
+	}
+
+	for i, pm := range p.Migrations {
+		if _, err := fmt.Fprintf(w, "\n-- [%d/%d] %s %d %s\n%s\n",
+			i+1, len(p.Migrations), pm.Direction, pm.Version, pm.Identifier, pm.SQL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sourceMigration is a migration version found in the migrations directory
+type sourceMigration struct {
+	version    uint
+	identifier string
+}
+
+// Planner resolves migration plans without changing the database.
+// It only reads the schema_migrations table, so it is safe to run against
+// production and against databases left dirty by a failed migration.
+type Planner struct {
+	db             *sql.DB
+	migrationsPath string
+}
+
+// NewPlanner creates a new Planner
+func NewPlanner(db *sql.DB, migrationsPath string) *Planner {
+	return &Planner{
+		db:             db,
+		migrationsPath: migrationsPath,
+	}
+}
+
+// Version returns the version recorded in schema_migrations.
+// A missing or empty table reports version 0.
+func (p *Planner) Version() (uint, bool, error) {
+	return readVersion(p.db)
+}
+
+// Up plans applying up to limit pending migrations (limit <= 0 means all)
+func (p *Planner) Up(limit int) (*Plan, error) {
+	return p.plan(func(migrations []sourceMigration, applied int) (int, error) {
+		target := len(migrations)
+		if limit > 0 && applied+limit < target {
+			target = applied + limit
+		}
+		return target, nil
+	})
+}
+
+// Down plans rolling back up to limit applied migrations (limit <= 0 means all)
+func (p *Planner) Down(limit int) (*Plan, error) {
+	return p.plan(func(migrations []sourceMigration, applied int) (int, error) {
+		if limit > 0 && applied-limit > 0 {
+			return applied - limit, nil
+		}
+		return 0, nil
+	})
+}
+
+// GoTo plans migrating up or down to the given version
+func (p *Planner) GoTo(version uint) (*Plan, error) {
+	return p.plan(func(migrations []sourceMigration, applied int) (int, error) {
+		if version == 0 {
+			return 0, nil
+		}
+		idx := indexOfVersion(migrations, version)
+		if idx < 0 {
+			return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+		}
+		return idx + 1, nil
+	})
+}
+
+// plan builds a plan from the current database version to the target chosen
+// by resolve. Versions are addressed by their position in the sorted list of
+// migrations; resolve receives how many are applied and returns how many
+// should be applied afterwards.
+func (p *Planner) plan(resolve func(migrations []sourceMigration, applied int) (int, error)) (*Plan, error) {
+	current, dirty, err := p.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := source.Open("file://" + p.migrationsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+	defer src.Close()
+
+	migrations, err := listSourceMigrations(src)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := 0
+	if current > 0 {
+		idx := indexOfVersion(migrations, current)
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: database is at version %d", ErrUnknownVersion, current)
+		}
+		applied = idx + 1
+		// A dirty version failed part way through, so it still needs to run
+		if dirty {
+			applied = idx
+		}
+	}
+
+	target, err := resolve(migrations, applied)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		From:  current,
+		Dirty: dirty,
+	}
+	if target > 0 {
+		plan.To = migrations[target-1].version
+	}
+
+	for i := applied; i < target; i++ {
+		pm, err := readPlannedMigration(src, migrations[i], DirectionUp)
+		if err != nil {
+			return nil, err
+		}
+		plan.Migrations = append(plan.Migrations, pm)
+	}
+	for i := applied - 1; i >= target; i-- {
+		pm, err := readPlannedMigration(src, migrations[i], DirectionDown)
+		if err != nil {
+			return nil, err
+		}
+		plan.Migrations = append(plan.Migrations, pm)
+	}
+
+	return plan, nil
+}
+
+// listSourceMigrations returns all versions in the source in ascending order
+func listSourceMigrations(src source.Driver) ([]sourceMigration, error) {
+	var migrations []sourceMigration
+
+	version, err := src.First()
+	for err == nil {
+		r, identifier, readErr := src.ReadUp(version)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read up migration %d: %w", version, readErr)
+		}
+		_ = r.Close()
+		migrations = append(migrations, sourceMigration{version: version, identifier: identifier})
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	return migrations, nil
+}
+
+// readPlannedMigration loads the SQL for a migration in the given direction
+func readPlannedMigration(src source.Driver, m sourceMigration, direction Direction) (PlannedMigration, error) {
+	read := src.ReadUp
+	if direction == DirectionDown {
+		read = src.ReadDown
+	}
+
+	r, _, err := read(m.version)
+	if err != nil {
+		return PlannedMigration{}, fmt.Errorf("failed to read %s migration %d: %w", direction, m.version, err)
+	}
+	defer r.Close()
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return PlannedMigration{}, fmt.Errorf("failed to read %s migration %d: %w", direction, m.version, err)
+	}
+
+	return PlannedMigration{
+		Version:    m.version,
+		Identifier: m.identifier,
+		Direction:  direction,
+		SQL:        string(body),
+	}, nil
+}
+
+// readVersion reads the version recorded in schema_migrations without
+// creating the table. A missing or empty table reports version 0.
+func readVersion(db *sql.DB) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := db.QueryRow(`SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isUndefinedTable(err) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if version < 0 {
+		return 0, dirty, nil
+	}
+	return uint(version), dirty, nil
+}
+
+// isUndefinedTable reports whether err is PostgreSQL's undefined_table error
+func isUndefinedTable(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "42P01"
+}
+
+// indexOfVersion returns the position of version in migrations, or -1
+func indexOfVersion(migrations []sourceMigration, version uint) int {
+	for i, m := range migrations {
+		if m.version == version {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,175 @@
+package migration
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const versionQuery = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+// writeTestMigrations creates migrations 1, 2, 3 and 5 in a temporary directory
+func writeTestMigrations(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, m := range []struct {
+		version uint
+		name    string
+	}{
+		{1, "create_users"},
+		{2, "create_orders"},
+		{3, "add_order_status"},
+		{5, "create_invoices"},
+	} {
+		base := filepath.Join(dir, fmt.Sprintf("%06d_%s", m.version, m.name))
+		require.NoError(t, os.WriteFile(base+".up.sql", []byte(fmt.Sprintf("-- up %d", m.version)), 0644))
+		require.NoError(t, os.WriteFile(base+".down.sql", []byte(fmt.Sprintf("-- down %d", m.version)), 0644))
+	}
+	return dir
+}
+
+// newTestPlanner returns a planner whose database reports the given version.
+// Any statement other than the version query fails the test.
+func newTestPlanner(t *testing.T, version int64, dirty bool) *Planner {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	mock.ExpectQuery(versionQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(version, dirty))
+
+	return NewPlanner(db, writeTestMigrations(t))
+}
+
+// planSteps summarizes a plan as "direction version" pairs
+func planSteps(plan *Plan) []string {
+	steps := make([]string, 0, len(plan.Migrations))
+	for _, pm := range plan.Migrations {
+		steps = append(steps, fmt.Sprintf("%s %d", pm.Direction, pm.Version))
+	}
+	return steps
+}
+
+func TestPlanner_Up(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int64
+		dirty    bool
+		limit    int
+		expected []string
+		to       uint
+	}{
+		{"from scratch", 0, false, 0, []string{"up 1", "up 2", "up 3", "up 5"}, 5},
+		{"partially applied", 2, false, 0, []string{"up 3", "up 5"}, 5},
+		{"limited", 1, false, 2, []string{"up 2", "up 3"}, 3},
+		{"up to date", 5, false, 0, []string{}, 5},
+		{"dirty version is re-run", 3, true, 0, []string{"up 3", "up 5"}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := newTestPlanner(t, tt.version, tt.dirty).Up(tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, planSteps(plan))
+			assert.Equal(t, uint(tt.version), plan.From)
+			assert.Equal(t, tt.to, plan.To)
+			assert.Equal(t, tt.dirty, plan.Dirty)
+		})
+	}
+}
+
+func TestPlanner_Down(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int64
+		limit    int
+		expected []string
+		to       uint
+	}{
+		{"all", 5, 0, []string{"down 5", "down 3", "down 2", "down 1"}, 0},
+		{"last one", 5, 1, []string{"down 5"}, 3},
+		{"more than applied", 2, 10, []string{"down 2", "down 1"}, 0},
+		{"nothing applied", 0, 1, []string{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := newTestPlanner(t, tt.version, false).Down(tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, planSteps(plan))
+			assert.Equal(t, tt.to, plan.To)
+		})
+	}
+}
+
+func TestPlanner_GoTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  int64
+		target   uint
+		expected []string
+	}{
+		{"forward", 1, 3, []string{"up 2", "up 3"}},
+		{"backward", 5, 2, []string{"down 5", "down 3"}},
+		{"same version", 3, 3, []string{}},
+		{"to zero", 2, 0, []string{"down 2", "down 1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := newTestPlanner(t, tt.version, false).GoTo(tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, planSteps(plan))
+			assert.Equal(t, tt.target, plan.To)
+		})
+	}
+
+	t.Run("unknown target", func(t *testing.T) {
+		_, err := newTestPlanner(t, 1, false).GoTo(4)
+		assert.ErrorIs(t, err, ErrUnknownVersion)
+	})
+}
+
+func TestPlanner_UnknownCurrentVersion(t *testing.T) {
+	_, err := newTestPlanner(t, 4, false).Up(0)
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestPlanner_MissingVersionTable(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(versionQuery).WillReturnError(&pq.Error{Code: "42P01"})
+
+	plan, err := NewPlanner(db, writeTestMigrations(t)).Up(0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up 1", "up 2", "up 3", "up 5"}, planSteps(plan))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPlan_Write(t *testing.T) {
+	plan, err := newTestPlanner(t, 3, true).Up(0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, plan.Write(&buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "-- Migration plan: version 3 (dirty) -> 5")
+	assert.Contains(t, out, "WARNING: database is dirty at version 3")
+	assert.Contains(t, out, "-- 2 migration(s) to run")
+	assert.Contains(t, out, "-- [1/2] up 3 add_order_status\n-- up 3")
+	assert.Contains(t, out, "-- [2/2] up 5 create_invoices\n-- up 5")
+}
@@ -0,0 +1,174 @@
+package migration
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SeedFile is an idempotent SQL script in the seeds directory
+type SeedFile struct {
+	Name     string
+	Path     string
+	Checksum string
+	SQL      string
+}
+
+// SeedResult reports a seed that was applied
+type SeedResult struct {
+	Name     string
+	Checksum string
+	RunCount int
+}
+
+// Seeder applies seed scripts and records which ones ran.
+// Unlike migrations, seeds are expected to be idempotent (upserts) and are
+// applied again on every run, so data for newly created tenants is filled in.
+type Seeder struct {
+	db        *sql.DB
+	seedsPath string
+	logger    *zap.Logger
+}
+
+// NewSeeder creates a new Seeder
+func NewSeeder(db *sql.DB, seedsPath string, logger *zap.Logger) *Seeder {
+	return &Seeder{
+		db:        db,
+		seedsPath: seedsPath,
+		logger:    logger,
+	}
+}
+
+// ListSeeds returns the .sql files in a seeds directory, ordered by name
+func ListSeeds(seedsDir string) ([]SeedFile, error) {
+	entries, err := os.ReadDir(seedsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []SeedFile{}, nil
+		}
+		return nil, fmt.Errorf("failed to read seeds directory: %w", err)
+	}
+
+	seeds := make([]SeedFile, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		path := filepath.Join(seedsDir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seed %s: %w", entry.Name(), err)
+		}
+
+		seeds = append(seeds, SeedFile{
+			Name:     strings.TrimSuffix(entry.Name(), ".sql"),
+			Path:     path,
+			Checksum: Checksum(content),
+			SQL:      string(content),
+		})
+	}
+
+	sort.Slice(seeds, func(i, j int) bool { return seeds[i].Name < seeds[j].Name })
+	return seeds, nil
+}
+
+// Run applies the named seeds, or all seeds if no names are given. Each seed
+// runs in its own transaction together with its schema_seeds record.
+func (s *Seeder) Run(names ...string) ([]SeedResult, error) {
+	seeds, err := ListSeeds(s.seedsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) > 0 {
+		seeds, err = selectSeeds(seeds, names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_seeds (
+		name TEXT PRIMARY KEY,
+		checksum TEXT NOT NULL,
+		run_count INTEGER NOT NULL DEFAULT 1,
+		first_applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		last_applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`); err != nil {
+		return nil, fmt.Errorf("failed to create seed tracking table: %w", err)
+	}
+
+	results := make([]SeedResult, 0, len(seeds))
+	for _, seed := range seeds {
+		result, err := s.apply(seed)
+		if err != nil {
+			return results, err
+		}
+		s.logger.Info("Seed applied",
+			zap.String("seed", result.Name),
+			zap.Int("run_count", result.RunCount),
+		)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// apply runs a single seed and records it
+func (s *Seeder) apply(seed SeedFile) (SeedResult, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return SeedResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(seed.SQL); err != nil {
+		return SeedResult{}, fmt.Errorf("seed %s failed: %w", seed.Name, err)
+	}
+
+	result := SeedResult{Name: seed.Name, Checksum: seed.Checksum}
+	err = tx.QueryRow(`INSERT INTO schema_seeds (name, checksum) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE
+		SET checksum = EXCLUDED.checksum, run_count = schema_seeds.run_count + 1, last_applied_at = NOW()
+		RETURNING run_count`, seed.Name, seed.Checksum).Scan(&result.RunCount)
+	if err != nil {
+		return SeedResult{}, fmt.Errorf("failed to record seed %s: %w", seed.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return SeedResult{}, fmt.Errorf("failed to commit seed %s: %w", seed.Name, err)
+	}
+	return result, nil
+}
+
+// selectSeeds picks the named seeds, keeping directory order
+func selectSeeds(seeds []SeedFile, names []string) ([]SeedFile, error) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[strings.TrimSuffix(name, ".sql")] = true
+	}
+
+	selected := make([]SeedFile, 0, len(names))
+	for _, seed := range seeds {
+		if wanted[seed.Name] {
+			selected = append(selected, seed)
+			delete(wanted, seed.Name)
+		}
+	}
+
+	if len(wanted) > 0 {
+		missing := make([]string, 0, len(wanted))
+		for name := range wanted {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("seeds not found: %s", strings.Join(missing, ", "))
+	}
+	return selected, nil
+}
@@ -0,0 +1,116 @@
+package migration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeTestSeeds creates seed files in a temporary directory
+func writeTestSeeds(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestListSeeds(t *testing.T) {
+	dir := writeTestSeeds(t, map[string]string{
+		"002_levels.sql": "SELECT 2;",
+		"001_roles.sql":  "SELECT 1;",
+		"README.md":      "not a seed",
+	})
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "003_dir.sql"), 0755))
+
+	seeds, err := ListSeeds(dir)
+	require.NoError(t, err)
+	require.Len(t, seeds, 2)
+	assert.Equal(t, "001_roles", seeds[0].Name)
+	assert.Equal(t, "SELECT 1;", seeds[0].SQL)
+	assert.Equal(t, Checksum([]byte("SELECT 1;")), seeds[0].Checksum)
+	assert.Equal(t, "002_levels", seeds[1].Name)
+}
+
+func TestListSeeds_NonexistentDirectory(t *testing.T) {
+	seeds, err := ListSeeds("/nonexistent/path/that/does/not/exist")
+	require.NoError(t, err)
+	assert.Empty(t, seeds)
+}
+
+// expectSeed expects a seed to be executed and recorded in its own transaction
+func expectSeed(mock sqlmock.Sqlmock, name, content string, runCount int) {
+	mock.ExpectBegin()
+	mock.ExpectExec(content).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO schema_seeds").
+		WithArgs(name, Checksum([]byte(content))).
+		WillReturnRows(sqlmock.NewRows([]string{"run_count"}).AddRow(runCount))
+	mock.ExpectCommit()
+}
+
+func TestSeeder_Run(t *testing.T) {
+	dir := writeTestSeeds(t, map[string]string{
+		"001_roles.sql":  "SELECT 1;",
+		"002_levels.sql": "SELECT 2;",
+	})
+	db, mock := newChecksumMock(t)
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_seeds").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectSeed(mock, "001_roles", "SELECT 1;", 2)
+	expectSeed(mock, "002_levels", "SELECT 2;", 1)
+
+	results, err := NewSeeder(db, dir, zap.NewNop()).Run()
+	require.NoError(t, err)
+	assert.Equal(t, []SeedResult{
+		{Name: "001_roles", Checksum: Checksum([]byte("SELECT 1;")), RunCount: 2},
+		{Name: "002_levels", Checksum: Checksum([]byte("SELECT 2;")), RunCount: 1},
+	}, results)
+}
+
+func TestSeeder_RunSelected(t *testing.T) {
+	dir := writeTestSeeds(t, map[string]string{
+		"001_roles.sql":  "SELECT 1;",
+		"002_levels.sql": "SELECT 2;",
+	})
+
+	t.Run("named seeds only", func(t *testing.T) {
+		db, mock := newChecksumMock(t)
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_seeds").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectSeed(mock, "002_levels", "SELECT 2;", 1)
+
+		results, err := NewSeeder(db, dir, zap.NewNop()).Run("002_levels.sql")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "002_levels", results[0].Name)
+	})
+
+	t.Run("unknown seed", func(t *testing.T) {
+		db, _ := newChecksumMock(t)
+		_, err := NewSeeder(db, dir, zap.NewNop()).Run("001_roles", "009_missing")
+		assert.EqualError(t, err, "seeds not found: 009_missing")
+	})
+}
+
+func TestSeeder_RunStopsOnFailure(t *testing.T) {
+	dir := writeTestSeeds(t, map[string]string{
+		"001_roles.sql":  "SELECT 1;",
+		"002_levels.sql": "SELECT 2;",
+	})
+	db, mock := newChecksumMock(t)
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_seeds").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT 1;").WillReturnError(errors.New("relation does not exist"))
+	mock.ExpectRollback()
+
+	results, err := NewSeeder(db, dir, zap.NewNop()).Run()
+	assert.ErrorContains(t, err, "seed 001_roles failed")
+	assert.Empty(t, results)
+}
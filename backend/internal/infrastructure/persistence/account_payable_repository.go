@@ -9,12 +9,21 @@ import (
 
 	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/erp/backend/internal/infrastructure/persistence/models"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
+// outstandingPayableStatuses are the statuses of payables that still have an amount owed.
+// MATCH_FAILED payables cannot be paid yet but are still owed to the supplier.
+var outstandingPayableStatuses = []finance.PayableStatus{
+	finance.PayableStatusPending,
+	finance.PayableStatusPartial,
+	finance.PayableStatusMatchFailed,
+}
+
 // GormAccountPayableRepository implements AccountPayableRepository using GORM
 type GormAccountPayableRepository struct {
 	db *gorm.DB
@@ -144,7 +153,7 @@ func (r *GormAccountPayableRepository) FindOutstanding(ctx context.Context, tena
 	if err := r.db.WithContext(ctx).
 		Preload("PaymentRecords").
 		Where("tenant_id = ? AND supplier_id = ? AND status IN ?", tenantID, supplierID,
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial}).
+			outstandingPayableStatuses).
 		Order("created_at ASC").
 		Find(&payableModels).Error; err != nil {
 		return nil, err
@@ -162,7 +171,7 @@ func (r *GormAccountPayableRepository) FindOverdue(ctx context.Context, tenantID
 	query := r.db.WithContext(ctx).Model(&models.AccountPayableModel{}).
 		Preload("PaymentRecords").
 		Where("tenant_id = ? AND due_date < ? AND status IN ?", tenantID, time.Now(),
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial})
+			outstandingPayableStatuses)
 	query = r.applyPayableFilter(query, filter)
 
 	if err := query.Find(&payableModels).Error; err != nil {
@@ -283,27 +292,42 @@ func (r *GormAccountPayableRepository) CountOverdue(ctx context.Context, tenantI
 	if err := r.db.WithContext(ctx).
 		Model(&models.AccountPayableModel{}).
 		Where("tenant_id = ? AND due_date < ? AND status IN ?", tenantID, time.Now(),
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial}).
+			outstandingPayableStatuses).
 		Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-// CountOutstandingBySupplier counts unsettled (PENDING or PARTIAL) payables for a supplier
+// CountOutstandingBySupplier counts unsettled (PENDING, PARTIAL or MATCH_FAILED) payables for a supplier
 // Used for validation before supplier deletion
 func (r *GormAccountPayableRepository) CountOutstandingBySupplier(ctx context.Context, tenantID, supplierID uuid.UUID) (int64, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).
 		Model(&models.AccountPayableModel{}).
 		Where("tenant_id = ? AND supplier_id = ? AND status IN ?", tenantID, supplierID,
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial}).
+			outstandingPayableStatuses).
 		Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
+// UpdateSupplierName refreshes the denormalized supplier name on all payables of a supplier
+func (r *GormAccountPayableRepository) UpdateSupplierName(ctx context.Context, tenantID, supplierID uuid.UUID, supplierName string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.AccountPayableModel{}).
+		Where("tenant_id = ? AND supplier_id = ? AND supplier_name <> ?", tenantID, supplierID, supplierName).
+		Updates(map[string]interface{}{
+			"supplier_name": supplierName,
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
 // SumOutstandingBySupplier calculates total outstanding for a supplier
 func (r *GormAccountPayableRepository) SumOutstandingBySupplier(ctx context.Context, tenantID, supplierID uuid.UUID) (decimal.Decimal, error) {
 	var result struct {
@@ -313,7 +337,7 @@ func (r *GormAccountPayableRepository) SumOutstandingBySupplier(ctx context.Cont
 		Model(&models.AccountPayableModel{}).
 		Select("COALESCE(SUM(outstanding_amount), 0) as total").
 		Where("tenant_id = ? AND supplier_id = ? AND status IN ?", tenantID, supplierID,
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial}).
+			outstandingPayableStatuses).
 		Scan(&result).Error; err != nil {
 		return decimal.Zero, err
 	}
@@ -329,7 +353,7 @@ func (r *GormAccountPayableRepository) SumOutstandingForTenant(ctx context.Conte
 		Model(&models.AccountPayableModel{}).
 		Select("COALESCE(SUM(outstanding_amount), 0) as total").
 		Where("tenant_id = ? AND status IN ?", tenantID,
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial}).
+			outstandingPayableStatuses).
 		Scan(&result).Error; err != nil {
 		return decimal.Zero, err
 	}
@@ -345,13 +369,40 @@ func (r *GormAccountPayableRepository) SumOverdueForTenant(ctx context.Context,
 		Model(&models.AccountPayableModel{}).
 		Select("COALESCE(SUM(outstanding_amount), 0) as total").
 		Where("tenant_id = ? AND due_date < ? AND status IN ?", tenantID, time.Now(),
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial}).
+			outstandingPayableStatuses).
 		Scan(&result).Error; err != nil {
 		return decimal.Zero, err
 	}
 	return result.Total, nil
 }
 
+// FindAgingEntries returns due date, outstanding amount and currency of all outstanding payables for aging analysis
+func (r *GormAccountPayableRepository) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	var rows []struct {
+		DueDate           *time.Time
+		OutstandingAmount decimal.Decimal
+		Currency          string
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.AccountPayableModel{}).
+		Select("due_date, outstanding_amount, currency").
+		Where("tenant_id = ? AND status IN ?", tenantID,
+			outstandingPayableStatuses).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]finance.AgingEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = finance.AgingEntry{
+			DueDate:           row.DueDate,
+			OutstandingAmount: row.OutstandingAmount,
+			Currency:          valueobject.Currency(row.Currency),
+		}
+	}
+	return entries, nil
+}
+
 // ExistsByPayableNumber checks if a payable number exists
 func (r *GormAccountPayableRepository) ExistsByPayableNumber(ctx context.Context, tenantID uuid.UUID, payableNumber string) (bool, error) {
 	var count int64
@@ -469,7 +520,7 @@ func (r *GormAccountPayableRepository) applyPayableFilterWithoutPagination(query
 	}
 	if filter.Overdue != nil && *filter.Overdue {
 		query = query.Where("due_date < ? AND status IN ?", time.Now(),
-			[]finance.PayableStatus{finance.PayableStatusPending, finance.PayableStatusPartial})
+			outstandingPayableStatuses)
 	}
 	if filter.MinAmount != nil {
 		query = query.Where("outstanding_amount >= ?", *filter.MinAmount)
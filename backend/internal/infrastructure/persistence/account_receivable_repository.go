@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/erp/backend/internal/infrastructure/persistence/tenant"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
@@ -166,6 +169,25 @@ func (r *GormAccountReceivableRepository) FindOverdue(ctx context.Context, tenan
 	return receivables, nil
 }
 
+// FindDueForReminder finds outstanding receivables across all tenants that are due on or before
+// dueBefore and have not yet received an overdue reminder
+func (r *GormAccountReceivableRepository) FindDueForReminder(ctx context.Context, dueBefore time.Time) ([]finance.AccountReceivable, error) {
+	var receivableModels []models.AccountReceivableModel
+	if err := r.db.WithContext(tenant.WithCrossTenant(ctx)).
+		Where("status IN ? AND due_date IS NOT NULL AND due_date <= ? AND reminder_stage <> ?",
+			[]finance.ReceivableStatus{finance.ReceivableStatusPending, finance.ReceivableStatusPartial},
+			dueBefore, finance.ReceivableReminderStageOverdue).
+		Order("due_date ASC").
+		Find(&receivableModels).Error; err != nil {
+		return nil, err
+	}
+	receivables := make([]finance.AccountReceivable, len(receivableModels))
+	for i, model := range receivableModels {
+		receivables[i] = *model.ToDomain()
+	}
+	return receivables, nil
+}
+
 // Save creates or updates an account receivable
 func (r *GormAccountReceivableRepository) Save(ctx context.Context, receivable *finance.AccountReceivable) error {
 	model := models.AccountReceivableModelFromDomain(receivable)
@@ -192,10 +214,12 @@ func (r *GormAccountReceivableRepository) SaveWithLock(ctx context.Context, rece
 	// Increment version
 	receivable.Version++
 
+	// Select all columns so fields reset to their zero value (e.g. PaidAt after a payment reversal) are written
 	model := models.AccountReceivableModelFromDomain(receivable)
 	result := r.db.WithContext(ctx).
 		Model(model).
 		Where("id = ? AND version = ?", receivable.ID, currentVersion).
+		Select("*").
 		Updates(model)
 
 	if result.Error != nil {
@@ -310,6 +334,29 @@ func (r *GormAccountReceivableRepository) SumOutstandingByCustomer(ctx context.C
 	return result.Total, nil
 }
 
+// SumOutstandingByCustomerByCurrency calculates outstanding amounts for a customer grouped by currency
+func (r *GormAccountReceivableRepository) SumOutstandingByCustomerByCurrency(ctx context.Context, tenantID, customerID uuid.UUID) (map[valueobject.Currency]decimal.Decimal, error) {
+	var rows []struct {
+		Currency string
+		Total    decimal.Decimal
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.AccountReceivableModel{}).
+		Select("currency, COALESCE(SUM(outstanding_amount), 0) as total").
+		Where("tenant_id = ? AND customer_id = ? AND status IN ?", tenantID, customerID,
+			[]finance.ReceivableStatus{finance.ReceivableStatusPending, finance.ReceivableStatusPartial}).
+		Group("currency").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	totals := make(map[valueobject.Currency]decimal.Decimal, len(rows))
+	for _, row := range rows {
+		totals[valueobject.Currency(row.Currency)] = row.Total
+	}
+	return totals, nil
+}
+
 // SumOutstandingForTenant calculates total outstanding for a tenant
 func (r *GormAccountReceivableRepository) SumOutstandingForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
 	var result struct {
@@ -342,6 +389,118 @@ func (r *GormAccountReceivableRepository) SumOverdueForTenant(ctx context.Contex
 	return result.Total, nil
 }
 
+// SumWrittenOffForTenant calculates total amount written off as bad debt for a tenant
+func (r *GormAccountReceivableRepository) SumWrittenOffForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	var result struct {
+		Total decimal.Decimal
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.AccountReceivableModel{}).
+		Select("COALESCE(SUM(written_off_amount), 0) as total").
+		Where("tenant_id = ? AND status = ?", tenantID, finance.ReceivableStatusWrittenOff).
+		Scan(&result).Error; err != nil {
+		return decimal.Zero, err
+	}
+	return result.Total, nil
+}
+
+// FindAgingEntries returns due date, outstanding amount and currency of all outstanding receivables for aging analysis
+func (r *GormAccountReceivableRepository) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	var rows []struct {
+		DueDate           *time.Time
+		OutstandingAmount decimal.Decimal
+		Currency          string
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.AccountReceivableModel{}).
+		Select("due_date, outstanding_amount, currency").
+		Where("tenant_id = ? AND status IN ?", tenantID,
+			[]finance.ReceivableStatus{finance.ReceivableStatusPending, finance.ReceivableStatusPartial}).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]finance.AgingEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = finance.AgingEntry{
+			DueDate:           row.DueDate,
+			OutstandingAmount: row.OutstandingAmount,
+			Currency:          valueobject.Currency(row.Currency),
+		}
+	}
+	return entries, nil
+}
+
+// ListPaymentRecords returns a page of payment records of a receivable, newest first.
+// Payment records are stored as JSONB on the receivable, so they are paginated in memory
+// and joined with receipt_vouchers on receipt_voucher_id for the voucher numbers.
+func (r *GormAccountReceivableRepository) ListPaymentRecords(ctx context.Context, tenantID, receivableID uuid.UUID, page, pageSize int) ([]finance.ReceivablePaymentRecord, int64, error) {
+	var model models.AccountReceivableModel
+	if err := r.db.WithContext(ctx).
+		Select("id, payment_records").
+		Where("tenant_id = ? AND id = ?", tenantID, receivableID).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, shared.ErrNotFound
+		}
+		return nil, 0, err
+	}
+
+	records := make([]finance.PaymentRecord, len(model.PaymentRecords))
+	copy(records, model.PaymentRecords)
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].AppliedAt.After(records[j].AppliedAt)
+	})
+
+	total := int64(len(records))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	start := (page - 1) * pageSize
+	if start >= len(records) {
+		return []finance.ReceivablePaymentRecord{}, total, nil
+	}
+	end := min(start+pageSize, len(records))
+	records = records[start:end]
+
+	voucherIDs := make([]uuid.UUID, 0, len(records))
+	for _, record := range records {
+		if record.ReceiptVoucherID != uuid.Nil {
+			voucherIDs = append(voucherIDs, record.ReceiptVoucherID)
+		}
+	}
+
+	voucherNumbers := make(map[uuid.UUID]string, len(voucherIDs))
+	if len(voucherIDs) > 0 {
+		var vouchers []struct {
+			ID            uuid.UUID
+			VoucherNumber string
+		}
+		if err := r.db.WithContext(ctx).
+			Model(&models.ReceiptVoucherModel{}).
+			Select("id, voucher_number").
+			Where("tenant_id = ? AND id IN ?", tenantID, voucherIDs).
+			Scan(&vouchers).Error; err != nil {
+			return nil, 0, err
+		}
+		for _, v := range vouchers {
+			voucherNumbers[v.ID] = v.VoucherNumber
+		}
+	}
+
+	result := make([]finance.ReceivablePaymentRecord, len(records))
+	for i, record := range records {
+		result[i] = finance.ReceivablePaymentRecord{
+			PaymentRecord:        record,
+			ReceiptVoucherNumber: voucherNumbers[record.ReceiptVoucherID],
+		}
+	}
+	return result, total, nil
+}
+
 // ExistsByReceivableNumber checks if a receivable number exists
 func (r *GormAccountReceivableRepository) ExistsByReceivableNumber(ctx context.Context, tenantID uuid.UUID, receivableNumber string) (bool, error) {
 	var count int64
@@ -401,6 +560,11 @@ func (r *GormAccountReceivableRepository) GenerateReceivableNumber(ctx context.C
 func (r *GormAccountReceivableRepository) applyReceivableFilter(query *gorm.DB, filter finance.AccountReceivableFilter) *gorm.DB {
 	query = r.applyReceivableFilterWithoutPagination(query, filter)
 
+	// Cursor pagination seeks past the last row seen instead of using OFFSET
+	if filter.Keyset {
+		return applyKeyset(query, "created_at", filter.Filter)
+	}
+
 	// Apply pagination
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := (filter.Page - 1) * filter.PageSize
@@ -445,6 +609,9 @@ func (r *GormAccountReceivableRepository) applyReceivableFilterWithoutPagination
 	if filter.SourceID != nil {
 		query = query.Where("source_id = ?", *filter.SourceID)
 	}
+	if filter.ShipmentNumber != nil {
+		query = query.Where("shipment_number = ?", *filter.ShipmentNumber)
+	}
 	if filter.FromDate != nil {
 		query = query.Where("created_at >= ?", *filter.FromDate)
 	}
@@ -0,0 +1,167 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newMockAccountReceivableRepository creates a GormAccountReceivableRepository with a mocked SQL connection
+func newMockAccountReceivableRepository(t *testing.T) (*GormAccountReceivableRepository, sqlmock.Sqlmock, *sql.DB) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	})
+
+	gormDB, err := gorm.Open(dialector, &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	return NewGormAccountReceivableRepository(gormDB), mock, mockDB
+}
+
+func TestGormAccountReceivableRepository_ListPaymentRecords(t *testing.T) {
+	tenantID := uuid.New()
+	receivableID := uuid.New()
+	firstVoucherID := uuid.New()
+	secondVoucherID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	records := finance.PaymentRecords{
+		{ID: uuid.New(), ReceiptVoucherID: firstVoucherID, Amount: decimal.NewFromInt(100), AppliedAt: now.Add(-2 * time.Hour)},
+		{ID: uuid.New(), ReceiptVoucherID: secondVoucherID, Amount: decimal.NewFromInt(200), AppliedAt: now},
+		{ID: uuid.New(), ReceiptVoucherID: firstVoucherID, Amount: decimal.NewFromInt(300), AppliedAt: now.Add(-1 * time.Hour)},
+	}
+	recordsJSON, err := json.Marshal(records)
+	require.NoError(t, err)
+
+	t.Run("returns newest records first with voucher numbers", func(t *testing.T) {
+		repo, mock, mockDB := newMockAccountReceivableRepository(t)
+		defer mockDB.Close()
+
+		mock.ExpectQuery(`SELECT id, payment_records FROM "account_receivables" WHERE tenant_id = \$1 AND id = \$2 .*LIMIT .*`).
+			WithArgs(tenantID, receivableID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "payment_records"}).AddRow(receivableID, recordsJSON))
+		mock.ExpectQuery(`SELECT id, voucher_number FROM "receipt_vouchers" WHERE tenant_id = \$1 AND id IN \(\$2,\$3\)`).
+			WithArgs(tenantID, secondVoucherID, firstVoucherID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "voucher_number"}).
+				AddRow(firstVoucherID, "RV-001").
+				AddRow(secondVoucherID, "RV-002"))
+
+		result, total, err := repo.ListPaymentRecords(context.Background(), tenantID, receivableID, 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, result, 2)
+		assert.True(t, result[0].Amount.Equal(decimal.NewFromInt(200)))
+		assert.Equal(t, "RV-002", result[0].ReceiptVoucherNumber)
+		assert.True(t, result[1].Amount.Equal(decimal.NewFromInt(300)))
+		assert.Equal(t, "RV-001", result[1].ReceiptVoucherNumber)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns empty page past the last record", func(t *testing.T) {
+		repo, mock, mockDB := newMockAccountReceivableRepository(t)
+		defer mockDB.Close()
+
+		mock.ExpectQuery(`SELECT id, payment_records FROM "account_receivables"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "payment_records"}).AddRow(receivableID, recordsJSON))
+
+		result, total, err := repo.ListPaymentRecords(context.Background(), tenantID, receivableID, 3, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Empty(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns not found for receivable of another tenant", func(t *testing.T) {
+		repo, mock, mockDB := newMockAccountReceivableRepository(t)
+		defer mockDB.Close()
+
+		mock.ExpectQuery(`SELECT id, payment_records FROM "account_receivables"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, _, err := repo.ListPaymentRecords(context.Background(), tenantID, receivableID, 1, 20)
+
+		assert.Nil(t, result)
+		assert.Equal(t, shared.ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGormAccountReceivableRepository_FindAllForTenant_Keyset(t *testing.T) {
+	tenantID := uuid.New()
+	columns := []string{"id", "tenant_id", "receivable_number", "created_at"}
+
+	t.Run("first page orders by created_at and id without OFFSET", func(t *testing.T) {
+		repo, mock, mockDB := newMockAccountReceivableRepository(t)
+		defer mockDB.Close()
+
+		mock.ExpectQuery(`SELECT \* FROM "account_receivables" WHERE tenant_id = \$1 ORDER BY created_at DESC,id DESC LIMIT \$2$`).
+			WithArgs(tenantID, 3).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		filter := finance.AccountReceivableFilter{Filter: shared.Filter{PageSize: 2, Keyset: true}}
+		_, err := repo.FindAllForTenant(context.Background(), tenantID, filter)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("next page seeks past the cursor", func(t *testing.T) {
+		repo, mock, mockDB := newMockAccountReceivableRepository(t)
+		defer mockDB.Close()
+
+		after := &shared.Cursor{SortValue: time.Now().UTC(), ID: uuid.New(), Direction: "DESC"}
+		mock.ExpectQuery(`SELECT \* FROM "account_receivables" WHERE tenant_id = \$1 AND \(created_at, id\) < \(\$2, \$3\) ORDER BY created_at DESC,id DESC LIMIT \$4$`).
+			WithArgs(tenantID, after.SortValue, after.ID, 3).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		filter := finance.AccountReceivableFilter{Filter: shared.Filter{PageSize: 2, Keyset: true, After: after}}
+		_, err := repo.FindAllForTenant(context.Background(), tenantID, filter)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ascending order seeks forward", func(t *testing.T) {
+		repo, mock, mockDB := newMockAccountReceivableRepository(t)
+		defer mockDB.Close()
+
+		after := &shared.Cursor{SortValue: time.Now().UTC(), ID: uuid.New(), Direction: "ASC"}
+		mock.ExpectQuery(`\(created_at, id\) > \(\$2, \$3\) .*ORDER BY created_at ASC,id ASC LIMIT \$4$`).
+			WithArgs(tenantID, after.SortValue, after.ID, 21).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		filter := finance.AccountReceivableFilter{Filter: shared.Filter{OrderDir: "asc", Keyset: true, After: after}}
+		_, err := repo.FindAllForTenant(context.Background(), tenantID, filter)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects a cursor issued for the other sort order", func(t *testing.T) {
+		repo, mock, mockDB := newMockAccountReceivableRepository(t)
+		defer mockDB.Close()
+
+		after := &shared.Cursor{SortValue: time.Now().UTC(), ID: uuid.New(), Direction: "DESC"}
+		filter := finance.AccountReceivableFilter{Filter: shared.Filter{OrderDir: "asc", Keyset: true, After: after}}
+		_, err := repo.FindAllForTenant(context.Background(), tenantID, filter)
+		assert.ErrorIs(t, err, shared.ErrInvalidCursor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
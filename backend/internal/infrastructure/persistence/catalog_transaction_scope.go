@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"context"
+
+	appcatalog "github.com/erp/backend/internal/application/catalog"
+	"github.com/erp/backend/internal/domain/catalog"
+	"gorm.io/gorm"
+)
+
+// GormCatalogTransactionScope implements the catalog TransactionScope using GORM transactions.
+// It provides atomic execution of multiple catalog repository operations.
+type GormCatalogTransactionScope struct {
+	db *gorm.DB
+}
+
+// NewGormCatalogTransactionScope creates a new GormCatalogTransactionScope.
+func NewGormCatalogTransactionScope(db *gorm.DB) *GormCatalogTransactionScope {
+	return &GormCatalogTransactionScope{db: db}
+}
+
+// Execute runs the given function within a database transaction.
+// If the function returns an error, the transaction is rolled back.
+// If the function succeeds, the transaction is committed.
+func (s *GormCatalogTransactionScope) Execute(ctx context.Context, fn func(repos appcatalog.TransactionalRepositories) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repos := &gormCatalogTransactionalRepositories{tx: tx}
+		return fn(repos)
+	})
+}
+
+// gormCatalogTransactionalRepositories provides access to catalog repositories within a transaction.
+type gormCatalogTransactionalRepositories struct {
+	tx *gorm.DB
+}
+
+// ProductRepo returns the product repository scoped to the current transaction.
+func (r *gormCatalogTransactionalRepositories) ProductRepo() catalog.ProductRepository {
+	return NewGormProductRepository(r.tx)
+}
+
+// PriceHistoryRepo returns the product price history repository scoped to the current transaction.
+func (r *gormCatalogTransactionalRepositories) PriceHistoryRepo() catalog.ProductPriceHistoryRepository {
+	return NewGormProductPriceHistoryRepository(r.tx)
+}
+
+// Ensure GormCatalogTransactionScope implements TransactionScope
+var _ appcatalog.TransactionScope = (*GormCatalogTransactionScope)(nil)
+
+// Ensure gormCatalogTransactionalRepositories implements TransactionalRepositories
+var _ appcatalog.TransactionalRepositories = (*gormCatalogTransactionalRepositories)(nil)
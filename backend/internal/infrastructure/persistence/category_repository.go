@@ -258,7 +258,7 @@ func (r *GormCategoryRepository) ExistsByCode(ctx context.Context, tenantID uuid
 }
 
 // UpdatePath updates the path for a category and its descendants
-func (r *GormCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, newPath string, levelDelta int) error {
+func (r *GormCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, parentID *uuid.UUID, newPath string, levelDelta int) error {
 	// Get the current category
 	current, err := r.FindByIDForTenant(ctx, tenantID, categoryID)
 	if err != nil {
@@ -272,8 +272,9 @@ func (r *GormCategoryRepository) UpdatePath(ctx context.Context, tenantID, categ
 		Model(&models.CategoryModel{}).
 		Where("id = ?", categoryID).
 		Updates(map[string]interface{}{
-			"path":  newPath,
-			"level": gorm.Expr("level + ?", levelDelta),
+			"parent_id": parentID,
+			"path":      newPath,
+			"level":     gorm.Expr("level + ?", levelDelta),
 		}).Error; err != nil {
 		return err
 	}
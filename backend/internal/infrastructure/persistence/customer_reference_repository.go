@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GormCustomerReferenceRepository implements CustomerReferenceRepository using GORM.
+// It updates the tables of the trade and finance contexts directly, so a merge
+// runs in the same transaction as the customer changes.
+type GormCustomerReferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCustomerReferenceRepository creates a new GormCustomerReferenceRepository
+func NewGormCustomerReferenceRepository(db *gorm.DB) *GormCustomerReferenceRepository {
+	return &GormCustomerReferenceRepository{db: db}
+}
+
+// ReassignCustomer points every record of the from customer to the to customer.
+// Documents keep a copy of the customer name, which is replaced as well.
+func (r *GormCustomerReferenceRepository) ReassignCustomer(ctx context.Context, tenantID, fromID uuid.UUID, to *partner.Customer) (partner.CustomerReassignment, error) {
+	var result partner.CustomerReassignment
+	db := r.db.WithContext(ctx)
+
+	documents := []struct {
+		table string
+		count *int64
+	}{
+		{"sales_orders", &result.SalesOrders},
+		{"sales_returns", &result.SalesReturns},
+		{"account_receivables", &result.Receivables},
+		{"receipt_vouchers", &result.ReceiptVouchers},
+		{"refund_records", &result.RefundRecords},
+	}
+	for _, doc := range documents {
+		res := db.Exec("UPDATE "+doc.table+" SET customer_id = ?, customer_name = ? WHERE tenant_id = ? AND customer_id = ?",
+			to.ID, to.Name, tenantID, fromID)
+		if res.Error != nil {
+			return result, fmt.Errorf("failed to reassign %s: %w", doc.table, res.Error)
+		}
+		*doc.count = res.RowsAffected
+	}
+
+	res := db.Exec("UPDATE balance_transactions SET customer_id = ? WHERE tenant_id = ? AND customer_id = ?",
+		to.ID, tenantID, fromID)
+	if res.Error != nil {
+		return result, fmt.Errorf("failed to reassign balance_transactions: %w", res.Error)
+	}
+	result.BalanceTransactions = res.RowsAffected
+
+	if err := db.Exec("UPDATE order_sync_configs SET default_customer_id = ?, default_customer_name = ? WHERE tenant_id = ? AND default_customer_id = ?",
+		to.ID, to.Name, tenantID, fromID).Error; err != nil {
+		return result, fmt.Errorf("failed to reassign order_sync_configs: %w", err)
+	}
+
+	return result, nil
+}
+
+// Ensure GormCustomerReferenceRepository implements CustomerReferenceRepository
+var _ partner.CustomerReferenceRepository = (*GormCustomerReferenceRepository)(nil)
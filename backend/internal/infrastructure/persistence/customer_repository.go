@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/erp/backend/internal/domain/partner"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/erp/backend/internal/infrastructure/persistence/tenant"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
@@ -49,6 +51,20 @@ func (r *GormCustomerRepository) FindByIDForTenant(ctx context.Context, tenantID
 	return model.ToDomain(), nil
 }
 
+// FindDeletedByIDForTenant finds a soft-deleted customer by ID within a tenant
+func (r *GormCustomerRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Customer, error) {
+	var model models.CustomerModel
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("tenant_id = ? AND id = ? AND deleted_at IS NOT NULL", tenantID, id).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
 // FindByCode finds a customer by its code within a tenant
 func (r *GormCustomerRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Customer, error) {
 	var model models.CustomerModel
@@ -259,6 +275,24 @@ func (r *GormCustomerRepository) FindWithPositiveBalance(ctx context.Context, te
 	return customers, nil
 }
 
+// FindLevelPeriodEnded finds customers across all tenants whose spend-based level period,
+// the spend window of their current non-default level, ended on or before asOf
+func (r *GormCustomerRepository) FindLevelPeriodEnded(ctx context.Context, asOf time.Time) ([]partner.Customer, error) {
+	var customerModels []models.CustomerModel
+	if err := r.db.WithContext(tenant.WithCrossTenant(ctx)).
+		Joins("JOIN customer_levels ON customer_levels.tenant_id = customers.tenant_id AND customer_levels.code = customers.level AND customer_levels.is_active AND NOT customer_levels.is_default").
+		Where("customers.level_period_start IS NOT NULL AND customers.level_period_start + customer_levels.spend_window_days * INTERVAL '1 day' <= ?", asOf).
+		Order("customers.level_period_start ASC").
+		Find(&customerModels).Error; err != nil {
+		return nil, err
+	}
+	customers := make([]partner.Customer, len(customerModels))
+	for i, model := range customerModels {
+		customers[i] = *model.ToDomain()
+	}
+	return customers, nil
+}
+
 // Save creates or updates a customer
 func (r *GormCustomerRepository) Save(ctx context.Context, customer *partner.Customer) error {
 	model := models.CustomerModelFromDomain(customer)
@@ -266,37 +300,43 @@ func (r *GormCustomerRepository) Save(ctx context.Context, customer *partner.Cus
 }
 
 // SaveWithLock saves a customer with optimistic locking (version check)
-// Returns error if the version has changed (concurrent modification)
+// Returns a VersionConflictError if the version has changed (concurrent modification)
 func (r *GormCustomerRepository) SaveWithLock(ctx context.Context, customer *partner.Customer) error {
 	// Get current version from database
 	var currentVersion int
-	if err := r.db.WithContext(ctx).
+	scan := r.db.WithContext(ctx).
 		Model(&models.CustomerModel{}).
 		Where("id = ?", customer.ID).
 		Select("version").
-		Scan(&currentVersion).Error; err != nil {
-		return err
+		Scan(&currentVersion)
+	if scan.Error != nil {
+		return scan.Error
+	}
+	if scan.RowsAffected == 0 {
+		return shared.ErrNotFound
 	}
 
 	// Check version matches
 	if currentVersion != customer.Version {
-		return shared.NewDomainError("CONCURRENT_MODIFICATION", "The customer has been modified by another user")
+		return shared.NewVersionConflictError("customer", currentVersion)
 	}
 
 	// Increment version
 	customer.Version++
 
+	// Select all columns so fields cleared to their zero value are written too
 	model := models.CustomerModelFromDomain(customer)
 	result := r.db.WithContext(ctx).
 		Model(model).
-		Where("id = ? AND version = ?", customer.ID, currentVersion).
+		Select("*").
+		Where("version = ?", currentVersion).
 		Updates(model)
 
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return shared.NewDomainError("CONCURRENT_MODIFICATION", "The customer has been modified by another user")
+		return versionConflict(r.db.WithContext(ctx), &models.CustomerModel{}, customer.ID, "customer")
 	}
 	return nil
 }
@@ -337,6 +377,21 @@ func (r *GormCustomerRepository) DeleteForTenant(ctx context.Context, tenantID,
 	return nil
 }
 
+// RestoreForTenant clears the deletion of a soft-deleted customer within a tenant
+func (r *GormCustomerRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&models.CustomerModel{}).
+		Where("tenant_id = ? AND id = ? AND deleted_at IS NOT NULL", tenantID, id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
 // Count counts customers matching the filter
 func (r *GormCustomerRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	var count int64
@@ -501,6 +556,10 @@ func (r *GormCustomerRepository) applyFilterWithoutPagination(query *gorm.DB, fi
 			} else {
 				query = query.Where("credit_limit = 0")
 			}
+		case "include_deleted":
+			if value == true {
+				query = query.Unscoped()
+			}
 		}
 	}
 
@@ -55,7 +55,7 @@ func TestGormCustomerRepository_FindByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "type", "level", "status", "balance", "credit_limit"}).
 			AddRow(customerID, tenantID, "CUST001", "Test Customer", "individual", "normal", "active", decimal.Zero, decimal.Zero)
 
-		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE id = \$1 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE id = \$1 AND "customers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(customerID, 1).
 			WillReturnRows(rows)
 
@@ -74,7 +74,7 @@ func TestGormCustomerRepository_FindByID(t *testing.T) {
 
 		customerID := uuid.New()
 
-		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE id = \$1 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE id = \$1 AND "customers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(customerID, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
@@ -98,7 +98,7 @@ func TestGormCustomerRepository_FindByIDForTenant(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "type", "level", "status", "balance", "credit_limit"}).
 			AddRow(customerID, tenantID, "CUST001", "Test Customer", "individual", "normal", "active", decimal.Zero, decimal.Zero)
 
-		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE tenant_id = \$1 AND id = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE \(tenant_id = \$1 AND id = \$2\) AND "customers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, customerID, 1).
 			WillReturnRows(rows)
 
@@ -122,7 +122,7 @@ func TestGormCustomerRepository_FindByCode(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "type", "level", "status", "balance", "credit_limit"}).
 			AddRow(customerID, tenantID, "CUST001", "Test Customer", "individual", "normal", "active", decimal.Zero, decimal.Zero)
 
-		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE tenant_id = \$1 AND code = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE \(tenant_id = \$1 AND code = \$2\) AND "customers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, "CUST001", 1).
 			WillReturnRows(rows)
 
@@ -146,7 +146,7 @@ func TestGormCustomerRepository_FindByPhone(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "phone", "type", "level", "status", "balance", "credit_limit"}).
 			AddRow(customerID, tenantID, "CUST001", "Test Customer", "13800138000", "individual", "normal", "active", decimal.Zero, decimal.Zero)
 
-		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE tenant_id = \$1 AND phone = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE \(tenant_id = \$1 AND phone = \$2\) AND "customers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, "13800138000", 1).
 			WillReturnRows(rows)
 
@@ -179,7 +179,7 @@ func TestGormCustomerRepository_FindByEmail(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "email", "type", "level", "status", "balance", "credit_limit"}).
 			AddRow(customerID, tenantID, "CUST001", "Test Customer", "test@example.com", "individual", "normal", "active", decimal.Zero, decimal.Zero)
 
-		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE tenant_id = \$1 AND email = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE \(tenant_id = \$1 AND email = \$2\) AND "customers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, "test@example.com", 1).
 			WillReturnRows(rows)
 
@@ -213,7 +213,7 @@ func TestGormCustomerRepository_FindByIDs(t *testing.T) {
 			AddRow(id1, tenantID, "CUST001", "Customer 1", "individual", "normal", "active", decimal.Zero, decimal.Zero).
 			AddRow(id2, tenantID, "CUST002", "Customer 2", "organization", "gold", "active", decimal.Zero, decimal.Zero)
 
-		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE tenant_id = \$1 AND id IN \(\$2,\$3\)`).
+		mock.ExpectQuery(`SELECT \* FROM "customers" WHERE \(tenant_id = \$1 AND id IN \(\$2,\$3\)\)`).
 			WithArgs(tenantID, id1, id2).
 			WillReturnRows(rows)
 
@@ -265,6 +265,69 @@ func TestGormCustomerRepository_Save(t *testing.T) {
 	})
 }
 
+func TestGormCustomerRepository_SaveWithLock(t *testing.T) {
+	t.Run("writes all columns and bumps the version", func(t *testing.T) {
+		repo, mock, mockDB := newMockCustomerRepository(t)
+		defer mockDB.Close()
+
+		customer, _ := partner.NewIndividualCustomer(uuid.New(), "CUST001", "Test Customer")
+		customer.SetNotes("")
+
+		mock.ExpectQuery(`SELECT "version" FROM "customers" WHERE id = \$1`).
+			WithArgs(customer.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+		mock.ExpectExec(`UPDATE "customers" SET .*"version"=.*"notes"=.* WHERE version = \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SaveWithLock(context.Background(), customer)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, customer.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stale version returns the current version", func(t *testing.T) {
+		repo, mock, mockDB := newMockCustomerRepository(t)
+		defer mockDB.Close()
+
+		customer, _ := partner.NewIndividualCustomer(uuid.New(), "CUST001", "Test Customer")
+
+		mock.ExpectQuery(`SELECT "version" FROM "customers" WHERE id = \$1`).
+			WithArgs(customer.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+
+		err := repo.SaveWithLock(context.Background(), customer)
+
+		var conflictErr *shared.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, 3, conflictErr.CurrentVersion)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update losing the race re-reads the current version", func(t *testing.T) {
+		repo, mock, mockDB := newMockCustomerRepository(t)
+		defer mockDB.Close()
+
+		customer, _ := partner.NewIndividualCustomer(uuid.New(), "CUST001", "Test Customer")
+
+		mock.ExpectQuery(`SELECT "version" FROM "customers" WHERE id = \$1`).
+			WithArgs(customer.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+		mock.ExpectExec(`UPDATE "customers" SET .* WHERE version = \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT "version" FROM "customers" WHERE id = \$1`).
+			WithArgs(customer.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+
+		err := repo.SaveWithLock(context.Background(), customer)
+
+		var conflictErr *shared.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, 2, conflictErr.CurrentVersion)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestGormCustomerRepository_SaveBatch(t *testing.T) {
 	t.Run("returns nil for empty batch", func(t *testing.T) {
 		repo, _, mockDB := newMockCustomerRepository(t)
@@ -283,8 +346,8 @@ func TestGormCustomerRepository_Delete(t *testing.T) {
 
 		customerID := uuid.New()
 
-		mock.ExpectExec(`DELETE FROM "customers" WHERE id = \$1`).
-			WithArgs(customerID).
+		mock.ExpectExec(`UPDATE "customers" SET "deleted_at"=\$1 WHERE id = \$2 AND "customers"\."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), customerID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Delete(context.Background(), customerID)
@@ -299,8 +362,8 @@ func TestGormCustomerRepository_Delete(t *testing.T) {
 
 		customerID := uuid.New()
 
-		mock.ExpectExec(`DELETE FROM "customers" WHERE id = \$1`).
-			WithArgs(customerID).
+		mock.ExpectExec(`UPDATE "customers" SET "deleted_at"=\$1 WHERE id = \$2 AND "customers"\."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), customerID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
 		err := repo.Delete(context.Background(), customerID)
@@ -319,8 +382,8 @@ func TestGormCustomerRepository_DeleteForTenant(t *testing.T) {
 		tenantID := uuid.New()
 		customerID := uuid.New()
 
-		mock.ExpectExec(`DELETE FROM "customers" WHERE tenant_id = \$1 AND id = \$2`).
-			WithArgs(tenantID, customerID).
+		mock.ExpectExec(`UPDATE "customers" SET "deleted_at"=\$1 WHERE \(tenant_id = \$2 AND id = \$3\) AND "customers"\."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), tenantID, customerID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.DeleteForTenant(context.Background(), tenantID, customerID)
@@ -330,6 +393,42 @@ func TestGormCustomerRepository_DeleteForTenant(t *testing.T) {
 	})
 }
 
+func TestGormCustomerRepository_RestoreForTenant(t *testing.T) {
+	t.Run("clears deleted_at of a deleted customer", func(t *testing.T) {
+		repo, mock, mockDB := newMockCustomerRepository(t)
+		defer mockDB.Close()
+
+		tenantID := uuid.New()
+		customerID := uuid.New()
+
+		mock.ExpectExec(`UPDATE "customers" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE tenant_id = \$3 AND id = \$4 AND deleted_at IS NOT NULL`).
+			WithArgs(nil, sqlmock.AnyArg(), tenantID, customerID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.RestoreForTenant(context.Background(), tenantID, customerID)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns not found when customer is not deleted", func(t *testing.T) {
+		repo, mock, mockDB := newMockCustomerRepository(t)
+		defer mockDB.Close()
+
+		tenantID := uuid.New()
+		customerID := uuid.New()
+
+		mock.ExpectExec(`UPDATE "customers" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE tenant_id = \$3 AND id = \$4 AND deleted_at IS NOT NULL`).
+			WithArgs(nil, sqlmock.AnyArg(), tenantID, customerID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.RestoreForTenant(context.Background(), tenantID, customerID)
+
+		assert.Equal(t, shared.ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestGormCustomerRepository_Count(t *testing.T) {
 	t.Run("counts customers", func(t *testing.T) {
 		repo, mock, mockDB := newMockCustomerRepository(t)
@@ -372,7 +471,7 @@ func TestGormCustomerRepository_CountByType(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE tenant_id = \$1 AND type = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE \(tenant_id = \$1 AND type = \$2\)`).
 			WithArgs(tenantID, partner.CustomerTypeIndividual).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 
@@ -392,7 +491,7 @@ func TestGormCustomerRepository_CountByLevel(t *testing.T) {
 		tenantID := uuid.New()
 		goldLevel := partner.GoldLevel()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE tenant_id = \$1 AND level = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE \(tenant_id = \$1 AND level = \$2\)`).
 			WithArgs(tenantID, goldLevel.Code()).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
@@ -411,7 +510,7 @@ func TestGormCustomerRepository_CountByStatus(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE tenant_id = \$1 AND status = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE \(tenant_id = \$1 AND status = \$2\)`).
 			WithArgs(tenantID, partner.CustomerStatusActive).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
 
@@ -430,7 +529,7 @@ func TestGormCustomerRepository_ExistsByCode(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE tenant_id = \$1 AND code = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE \(tenant_id = \$1 AND code = \$2\)`).
 			WithArgs(tenantID, "CUST001").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
@@ -447,7 +546,7 @@ func TestGormCustomerRepository_ExistsByCode(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE tenant_id = \$1 AND code = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE \(tenant_id = \$1 AND code = \$2\)`).
 			WithArgs(tenantID, "NONEXISTENT").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
@@ -476,7 +575,7 @@ func TestGormCustomerRepository_ExistsByPhone(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE tenant_id = \$1 AND phone = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "customers" WHERE \(tenant_id = \$1 AND phone = \$2\)`).
 			WithArgs(tenantID, "13800138000").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// dailySalesFactModel is the GORM model of the daily_sales_fact table
+type dailySalesFactModel struct {
+	ID          uuid.UUID       `gorm:"column:id;type:uuid;primaryKey"`
+	TenantID    uuid.UUID       `gorm:"column:tenant_id;type:uuid;not null"`
+	Date        time.Time       `gorm:"column:date;type:date;not null"`
+	ProductID   uuid.UUID       `gorm:"column:product_id;type:uuid;not null"`
+	WarehouseID uuid.UUID       `gorm:"column:warehouse_id;type:uuid;not null"`
+	OrderCount  int64           `gorm:"column:order_count;default:0"`
+	Quantity    decimal.Decimal `gorm:"column:quantity;type:decimal(20,4);default:0"`
+	Revenue     decimal.Decimal `gorm:"column:revenue;type:decimal(20,4);default:0"`
+	Cost        decimal.Decimal `gorm:"column:cost;type:decimal(20,4);default:0"`
+	ComputedAt  time.Time       `gorm:"column:computed_at"`
+}
+
+func (dailySalesFactModel) TableName() string {
+	return "daily_sales_fact"
+}
+
+// GormDailySalesFactRepository implements DailySalesFactRepository using GORM
+type GormDailySalesFactRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDailySalesFactRepository creates a new GormDailySalesFactRepository
+func NewGormDailySalesFactRepository(db *gorm.DB) *GormDailySalesFactRepository {
+	return &GormDailySalesFactRepository{db: db}
+}
+
+// GetCompletedOrderLines returns the lines of the tenant's orders completed between start and end.
+// The cost of a line is the cost of the stock shipped for it, or its quantity at the current
+// unit cost when the ledger has no shipment for it.
+func (r *GormDailySalesFactRepository) GetCompletedOrderLines(tenantID uuid.UUID, start, end time.Time) ([]report.CompletedOrderLine, error) {
+	// Cost of the stock shipped for each order and product, from the inventory ledger
+	shippedCost := r.db.Table("inventory_transactions t").
+		Select("t.source_id, t.product_id, SUM(t.total_cost) as cost, SUM(t.quantity) as quantity").
+		Where("t.tenant_id = ?", tenantID).
+		Where("t.transaction_type = ? AND t.source_type = ?", "OUTBOUND", "SALES_ORDER").
+		Group("t.source_id, t.product_id")
+
+	var lines []report.CompletedOrderLine
+	err := r.db.Table("sales_order_items soi").
+		Select(`
+			so.id as order_id,
+			DATE(so.completed_at) as date,
+			soi.product_id,
+			so.warehouse_id,
+			soi.quantity,
+			soi.amount,
+			COALESCE(
+				shipped.cost * soi.base_quantity / NULLIF(shipped.quantity, 0),
+				soi.base_quantity * ii.unit_cost,
+				0
+			) as cost
+		`).
+		Joins("JOIN sales_orders so ON so.id = soi.order_id").
+		Joins("LEFT JOIN (?) shipped ON shipped.source_id = so.id::text AND shipped.product_id = soi.product_id", shippedCost).
+		Joins("LEFT JOIN inventory_items ii ON ii.product_id = soi.product_id AND ii.warehouse_id = so.warehouse_id AND ii.tenant_id = so.tenant_id").
+		Where("so.tenant_id = ?", tenantID).
+		Where("so.status = ?", "COMPLETED").
+		Where("so.warehouse_id IS NOT NULL").
+		Where("so.completed_at BETWEEN ? AND ?", start, end).
+		Scan(&lines).Error
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// ReplaceDailySalesFacts replaces the tenant's facts for the days from start to end in one transaction
+func (r *GormDailySalesFactRepository) ReplaceDailySalesFacts(tenantID uuid.UUID, start, end time.Time, facts []report.DailySalesFact) error {
+	now := time.Now()
+	models := make([]dailySalesFactModel, len(facts))
+	for i, fact := range facts {
+		models[i] = dailySalesFactModel{
+			ID:          uuid.New(),
+			TenantID:    tenantID,
+			Date:        fact.Date,
+			ProductID:   fact.ProductID,
+			WarehouseID: fact.WarehouseID,
+			OrderCount:  fact.OrderCount,
+			Quantity:    fact.Quantity,
+			Revenue:     fact.Revenue,
+			Cost:        fact.Cost,
+			ComputedAt:  now,
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ? AND date BETWEEN DATE(?) AND DATE(?)", tenantID, start, end).
+			Delete(&dailySalesFactModel{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(models, 500).Error
+	})
+}
+
+// GetTenantIDsWithCompletedOrders returns the tenants with orders completed between start and end
+func (r *GormDailySalesFactRepository) GetTenantIDsWithCompletedOrders(start, end time.Time) ([]uuid.UUID, error) {
+	var tenantIDs []uuid.UUID
+	err := r.db.Table("sales_orders").
+		Distinct("tenant_id").
+		Where("status = ?", "COMPLETED").
+		Where("completed_at BETWEEN ? AND ?", start, end).
+		Pluck("tenant_id", &tenantIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return tenantIDs, nil
+}
+
+// Ensure GormDailySalesFactRepository implements DailySalesFactRepository
+var _ report.DailySalesFactRepository = (*GormDailySalesFactRepository)(nil)
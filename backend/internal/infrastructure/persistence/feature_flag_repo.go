@@ -781,6 +781,11 @@ func (r *GormFlagAuditLogRepository) CountByFlagKey(ctx context.Context, flagKey
 func (r *GormFlagAuditLogRepository) applyAuditLogFilter(query *gorm.DB, filter shared.Filter) *gorm.DB {
 	query = r.applyAuditLogFilterWithoutPagination(query, filter)
 
+	// Cursor pagination seeks past the last row seen instead of using OFFSET
+	if filter.Keyset {
+		return applyKeyset(query, "created_at", filter)
+	}
+
 	// Apply pagination
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := (filter.Page - 1) * filter.PageSize
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+
+	appfinance "github.com/erp/backend/internal/application/finance"
+	"github.com/erp/backend/internal/domain/finance"
+	"gorm.io/gorm"
+)
+
+// GormFinanceTransactionScope implements the finance TransactionScope using GORM transactions.
+// It provides atomic execution of multiple finance repository operations.
+type GormFinanceTransactionScope struct {
+	db *gorm.DB
+}
+
+// NewGormFinanceTransactionScope creates a new GormFinanceTransactionScope.
+func NewGormFinanceTransactionScope(db *gorm.DB) *GormFinanceTransactionScope {
+	return &GormFinanceTransactionScope{db: db}
+}
+
+// Execute runs the given function within a database transaction.
+// If the function returns an error, the transaction is rolled back.
+// If the function succeeds, the transaction is committed.
+func (s *GormFinanceTransactionScope) Execute(ctx context.Context, fn func(repos appfinance.TransactionalRepositories) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repos := &gormFinanceTransactionalRepositories{tx: tx}
+		return fn(repos)
+	})
+}
+
+// gormFinanceTransactionalRepositories provides access to finance repositories within a transaction.
+type gormFinanceTransactionalRepositories struct {
+	tx *gorm.DB
+}
+
+// ReceivableRepo returns the account receivable repository scoped to the current transaction.
+func (r *gormFinanceTransactionalRepositories) ReceivableRepo() finance.AccountReceivableRepository {
+	return NewGormAccountReceivableRepository(r.tx)
+}
+
+// ReceiptVoucherRepo returns the receipt voucher repository scoped to the current transaction.
+func (r *gormFinanceTransactionalRepositories) ReceiptVoucherRepo() finance.ReceiptVoucherRepository {
+	return NewGormReceiptVoucherRepository(r.tx)
+}
+
+// PaymentVoucherRepo returns the payment voucher repository scoped to the current transaction.
+func (r *gormFinanceTransactionalRepositories) PaymentVoucherRepo() finance.PaymentVoucherRepository {
+	return NewGormPaymentVoucherRepository(r.tx)
+}
+
+// IdempotencyKeyRepo returns the voucher idempotency key repository scoped to the current transaction.
+func (r *gormFinanceTransactionalRepositories) IdempotencyKeyRepo() finance.VoucherIdempotencyKeyRepository {
+	return NewGormVoucherIdempotencyKeyRepository(r.tx)
+}
+
+// Ensure GormFinanceTransactionScope implements TransactionScope
+var _ appfinance.TransactionScope = (*GormFinanceTransactionScope)(nil)
+
+// Ensure gormFinanceTransactionalRepositories implements TransactionalRepositories
+var _ appfinance.TransactionalRepositories = (*gormFinanceTransactionalRepositories)(nil)
@@ -0,0 +1,359 @@
+// Command tracegen generates tracing decorators for the repositories of the persistence package.
+//
+// For every exported *Repository struct it writes a Traced<Type> wrapper with the same exported
+// methods. Methods taking a context start a repo.<Type>.<Method> span around the call, tagged
+// with the tenant ID and the number of rows returned; other methods are passed straight through.
+//
+// Run it from the persistence package with go generate.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const telemetryImport = "github.com/erp/backend/internal/infrastructure/telemetry"
+
+func main() {
+	dir := flag.String("dir", ".", "Package directory to scan")
+	out := flag.String("out", "traced_repositories_gen.go", "Output file name, relative to -dir")
+	flag.Parse()
+
+	src, err := generate(*dir, *out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *out), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// repository is a repository type and the exported methods to wrap
+type repository struct {
+	name    string
+	methods []*method
+}
+
+type method struct {
+	name string
+	decl *ast.FuncDecl
+	file *ast.File
+}
+
+// generator collects the imports the wrapped signatures need
+type generator struct {
+	fset    *token.FileSet
+	imports map[string]string // package name -> import path
+}
+
+func generate(dir, out string) ([]byte, error) {
+	fset := token.NewFileSet()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgName string
+	repos := map[string]*repository{}
+	var files []*ast.File
+	for _, name := range matches {
+		base := filepath.Base(name)
+		if strings.HasSuffix(base, "_test.go") || base == out {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		pkgName = file.Name.Name
+		files = append(files, file)
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if _, isStruct := ts.Type.(*ast.StructType); isStruct && ts.Name.IsExported() &&
+					strings.HasSuffix(ts.Name.Name, "Repository") && ts.TypeParams == nil {
+					repos[ts.Name.Name] = &repository{name: ts.Name.Name}
+				}
+			}
+		}
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			ident, ok := star.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if repo, ok := repos[ident.Name]; ok {
+				repo.methods = append(repo.methods, &method{name: fn.Name.Name, decl: fn, file: file})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(repos))
+	for name, repo := range repos {
+		if len(repo.methods) > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	g := &generator{fset: fset, imports: map[string]string{"telemetry": telemetryImport}}
+	var body bytes.Buffer
+	for _, name := range names {
+		repo := repos[name]
+		slices.SortFunc(repo.methods, func(a, b *method) int { return strings.Compare(a.name, b.name) })
+		if err := g.writeRepository(&body, repo); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by tracegen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkgName)
+	pkgs := make([]string, 0, len(g.imports))
+	for pkg := range g.imports {
+		pkgs = append(pkgs, pkg)
+	}
+	// Standard library imports first, as goimports groups them
+	isStd := func(pkg string) bool { return !strings.Contains(strings.Split(g.imports[pkg], "/")[0], ".") }
+	slices.SortFunc(pkgs, func(a, b string) int {
+		if isStd(a) != isStd(b) {
+			if isStd(a) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(g.imports[a], g.imports[b])
+	})
+	for i, pkg := range pkgs {
+		importPath := g.imports[pkg]
+		if i > 0 && isStd(pkgs[i-1]) && !isStd(pkg) {
+			buf.WriteString("\n")
+		}
+		if path.Base(importPath) == pkg {
+			fmt.Fprintf(&buf, "\t%q\n", importPath)
+		} else {
+			fmt.Fprintf(&buf, "\t%s %q\n", pkg, importPath)
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+func (g *generator) writeRepository(w *bytes.Buffer, repo *repository) error {
+	traced := "Traced" + repo.name
+	spanType := strings.TrimPrefix(repo.name, "Gorm")
+
+	fmt.Fprintf(w, "\n// %s records a span for each %s call that takes a context\n", traced, repo.name)
+	fmt.Fprintf(w, "type %s struct {\n\tnext *%s\n}\n\n", traced, repo.name)
+	fmt.Fprintf(w, "// New%s wraps next with repository tracing\n", traced)
+	fmt.Fprintf(w, "func New%s(next *%s) *%s {\n\treturn &%s{next: next}\n}\n", traced, repo.name, traced, traced)
+
+	for _, m := range repo.methods {
+		if err := g.writeMethod(w, traced, spanType, m); err != nil {
+			return fmt.Errorf("%s.%s: %w", repo.name, m.name, err)
+		}
+	}
+	return nil
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+func (g *generator) writeMethod(w *bytes.Buffer, traced, spanType string, m *method) error {
+	fn := m.decl.Type
+	if fn.TypeParams != nil {
+		return fmt.Errorf("type parameters are not supported")
+	}
+
+	var params []param
+	for _, field := range fn.Params.List {
+		typ, err := g.typeString(m.file, field.Type)
+		if err != nil {
+			return err
+		}
+		_, variadic := field.Type.(*ast.Ellipsis)
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		for _, n := range names {
+			name := n.Name
+			if name == "_" {
+				name = "p" + strconv.Itoa(len(params))
+			}
+			params = append(params, param{name: name, typ: typ, variadic: variadic})
+		}
+	}
+
+	var results []string
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			typ, err := g.typeString(m.file, field.Type)
+			if err != nil {
+				return err
+			}
+			for range max(1, len(field.Names)) {
+				results = append(results, typ)
+			}
+		}
+	}
+
+	paramDecls := make([]string, len(params))
+	args := make([]string, len(params))
+	for i, p := range params {
+		paramDecls[i] = p.name + " " + p.typ
+		args[i] = p.name
+		if p.variadic {
+			args[i] += "..."
+		}
+	}
+	resultDecl := strings.Join(results, ", ")
+	if len(results) > 1 {
+		resultDecl = "(" + resultDecl + ")"
+	}
+	call := fmt.Sprintf("r.next.%s(%s)", m.name, strings.Join(args, ", "))
+
+	fmt.Fprintf(w, "\nfunc (r *%s) %s(%s) %s {\n", traced, m.name, strings.Join(paramDecls, ", "), resultDecl)
+
+	if len(params) == 0 || params[0].typ != "context.Context" {
+		if len(results) == 0 {
+			fmt.Fprintf(w, "\t%s\n}\n", call)
+		} else {
+			fmt.Fprintf(w, "\treturn %s\n}\n", call)
+		}
+		return nil
+	}
+
+	ctx := params[0].name
+	if len(results) == 0 {
+		fmt.Fprintf(w, "\tif !telemetry.RepositoryTracingEnabled() {\n\t\t%s\n\t\treturn\n\t}\n", call)
+	} else {
+		fmt.Fprintf(w, "\tif !telemetry.RepositoryTracingEnabled() {\n\t\treturn %s\n\t}\n", call)
+	}
+
+	tenant := `""`
+	for _, p := range params {
+		if p.name == "tenantID" && p.typ == "uuid.UUID" {
+			tenant = "tenantID.String()"
+		}
+	}
+	fmt.Fprintf(w, "\t%s, span := telemetry.StartRepositorySpan(%s, %q, %q, %s)\n", ctx, ctx, spanType, m.name, tenant)
+
+	vars := make([]string, len(results))
+	for i := range results {
+		vars[i] = "r" + strconv.Itoa(i)
+	}
+	if len(results) > 0 {
+		fmt.Fprintf(w, "\t%s := %s\n", strings.Join(vars, ", "), call)
+	} else {
+		fmt.Fprintf(w, "\t%s\n", call)
+	}
+
+	for i, typ := range results {
+		if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") {
+			fmt.Fprintf(w, "\tspan.SetRowCount(len(%s))\n", vars[i])
+			break
+		}
+		if strings.HasPrefix(typ, "*") {
+			fmt.Fprintf(w, "\tspan.SetFound(%s != nil)\n", vars[i])
+			break
+		}
+	}
+
+	errVar := "nil"
+	if n := len(results); n > 0 && results[n-1] == "error" {
+		errVar = vars[n-1]
+	}
+	fmt.Fprintf(w, "\tspan.End(%s)\n", errVar)
+	if len(results) > 0 {
+		fmt.Fprintf(w, "\treturn %s\n", strings.Join(vars, ", "))
+	}
+	w.WriteString("}\n")
+	return nil
+}
+
+// typeString prints a type expression and records the imports it refers to
+func (g *generator) typeString(file *ast.File, expr ast.Expr) (string, error) {
+	var err error
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		importPath, found := importPathOf(file, pkg.Name)
+		if !found {
+			err = fmt.Errorf("no import for package %s", pkg.Name)
+			return false
+		}
+		if existing, ok := g.imports[pkg.Name]; ok && existing != importPath {
+			err = fmt.Errorf("package name %s refers to both %s and %s", pkg.Name, existing, importPath)
+			return false
+		}
+		g.imports[pkg.Name] = importPath
+		return false
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, g.fset, expr); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// importPathOf returns the path a file imports under the package name
+func importPathOf(file *ast.File, name string) (string, bool) {
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		pkg := path.Base(importPath)
+		if spec.Name != nil {
+			pkg = spec.Name.Name
+		}
+		if pkg == name {
+			return importPath, true
+		}
+	}
+	return "", false
+}
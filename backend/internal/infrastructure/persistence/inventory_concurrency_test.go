@@ -422,6 +422,8 @@ func TestGetOrCreate_RaceCondition(t *testing.T) {
 		)
 		mock.ExpectQuery(`SELECT \* FROM "inventory_items" WHERE tenant_id`).
 			WillReturnRows(existingRows)
+		mock.ExpectQuery(`SELECT \* FROM "stock_batches"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inventory_item_id", "batch_number", "quantity", "unit_cost", "consumed"}))
 
 		item, err := repo.GetOrCreate(context.Background(), tenantID, warehouseID, productID)
 
@@ -455,6 +457,8 @@ func TestGetOrCreate_RaceCondition(t *testing.T) {
 		)
 		mock.ExpectQuery(`SELECT \* FROM "inventory_items" WHERE tenant_id`).
 			WillReturnRows(existingRows)
+		mock.ExpectQuery(`SELECT \* FROM "stock_batches"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inventory_item_id", "batch_number", "quantity", "unit_cost", "consumed"}))
 
 		item, err := repo.GetOrCreate(context.Background(), tenantID, warehouseID, productID)
 
@@ -380,3 +380,84 @@ func (r *GormInventoryReportRepository) GetSlowMovingProducts(filter report.Inve
 
 	return turnovers, nil
 }
+
+// GetProductInventoryMovements returns the ledger figures of each product over the filter's period.
+// Opening and closing quantities are the ledger balances around the period boundaries; an item
+// without transactions on one side of a boundary takes the balance from the other side, and an
+// item without any transactions keeps its current quantity throughout.
+func (r *GormInventoryReportRepository) GetProductInventoryMovements(filter report.InventoryTurnoverFilter) ([]report.ProductInventoryMovement, error) {
+	// Per inventory item: balances at the period boundaries and the cost shipped to sales orders
+	items := r.db.Table("inventory_items ii").
+		Select(`
+			ii.product_id,
+			ii.available_quantity,
+			ii.unit_cost,
+			COALESCE(
+				(SELECT t.balance_after FROM inventory_transactions t
+					WHERE t.inventory_item_id = ii.id AND t.transaction_date < @start
+					ORDER BY t.transaction_date DESC, t.created_at DESC LIMIT 1),
+				(SELECT t.balance_before FROM inventory_transactions t
+					WHERE t.inventory_item_id = ii.id AND t.transaction_date >= @start
+					ORDER BY t.transaction_date ASC, t.created_at ASC LIMIT 1),
+				ii.available_quantity
+			) as opening_quantity,
+			COALESCE(
+				(SELECT t.balance_after FROM inventory_transactions t
+					WHERE t.inventory_item_id = ii.id AND t.transaction_date <= @end
+					ORDER BY t.transaction_date DESC, t.created_at DESC LIMIT 1),
+				(SELECT t.balance_before FROM inventory_transactions t
+					WHERE t.inventory_item_id = ii.id AND t.transaction_date > @end
+					ORDER BY t.transaction_date ASC, t.created_at ASC LIMIT 1),
+				ii.available_quantity
+			) as closing_quantity,
+			COALESCE((
+				SELECT SUM(t.quantity) FROM inventory_transactions t
+				WHERE t.inventory_item_id = ii.id
+				AND t.transaction_type = 'OUTBOUND' AND t.source_type = 'SALES_ORDER'
+				AND t.transaction_date BETWEEN @start AND @end
+			), 0) as sold_quantity,
+			COALESCE((
+				SELECT SUM(t.total_cost) FROM inventory_transactions t
+				WHERE t.inventory_item_id = ii.id
+				AND t.transaction_type = 'OUTBOUND' AND t.source_type = 'SALES_ORDER'
+				AND t.transaction_date BETWEEN @start AND @end
+			), 0) as cogs
+		`, map[string]any{"start": filter.StartDate, "end": filter.EndDate}).
+		Where("ii.tenant_id = ?", filter.TenantID)
+
+	if filter.WarehouseID != nil {
+		items = items.Where("ii.warehouse_id = ?", *filter.WarehouseID)
+	}
+	if filter.ProductID != nil {
+		items = items.Where("ii.product_id = ?", *filter.ProductID)
+	}
+
+	query := r.db.Table("(?) as items", items).
+		Select(`
+			items.product_id,
+			p.code as product_sku,
+			p.name as product_name,
+			COALESCE(c.name, '') as category_name,
+			SUM(items.opening_quantity) as opening_quantity,
+			SUM(items.closing_quantity) as closing_quantity,
+			SUM(items.sold_quantity) as sold_quantity,
+			SUM(items.cogs) as cogs,
+			COALESCE(
+				SUM(items.unit_cost * items.available_quantity) / NULLIF(SUM(items.available_quantity), 0),
+				AVG(items.unit_cost)
+			) as unit_cost
+		`).
+		Joins("JOIN products p ON p.id = items.product_id").
+		Joins("LEFT JOIN categories c ON c.id = p.category_id").
+		Group("items.product_id, p.code, p.name, c.name")
+
+	if filter.CategoryID != nil {
+		query = query.Where("p.category_id = ?", *filter.CategoryID)
+	}
+
+	var movements []report.ProductInventoryMovement
+	if err := query.Scan(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/partner"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/infrastructure/persistence/datascope"
 	"github.com/erp/backend/internal/infrastructure/persistence/models"
@@ -33,7 +34,9 @@ func (r *GormInventoryItemRepository) WithTx(tx *gorm.DB) *GormInventoryItemRepo
 // FindByID finds an inventory item by its ID
 func (r *GormInventoryItemRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.InventoryItem, error) {
 	var model models.InventoryItemModel
-	if err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Preload("Batches", "consumed = ?", false).
+		First(&model, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, shared.ErrNotFound
 		}
@@ -60,6 +63,7 @@ func (r *GormInventoryItemRepository) FindByIDForTenant(ctx context.Context, ten
 func (r *GormInventoryItemRepository) FindByWarehouseAndProduct(ctx context.Context, tenantID, warehouseID, productID uuid.UUID) (*inventory.InventoryItem, error) {
 	var model models.InventoryItemModel
 	if err := r.db.WithContext(ctx).
+		Preload("Batches", "consumed = ?", false).
 		Where("tenant_id = ? AND warehouse_id = ? AND product_id = ?", tenantID, warehouseID, productID).
 		First(&model).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -282,6 +286,18 @@ func (r *GormInventoryItemRepository) SaveWithLock(ctx context.Context, item *in
 	if updateResult.RowsAffected == 0 {
 		return shared.NewDomainError("OPTIMISTIC_LOCK_FAILED", "Inventory item was modified by another transaction")
 	}
+
+	// Persist only the batches added or changed since the item was loaded
+	if changed := item.ChangedBatches(); len(changed) > 0 {
+		batchModels := make([]models.StockBatchModel, len(changed))
+		for idx, batch := range changed {
+			batchModels[idx] = *models.StockBatchModelFromDomain(batch)
+		}
+		if err := r.db.WithContext(ctx).Save(&batchModels).Error; err != nil {
+			return err
+		}
+	}
+	item.ClearChangedBatches()
 	return nil
 }
 
@@ -388,6 +404,34 @@ func (r *GormInventoryItemRepository) SumValueByWarehouse(ctx context.Context, t
 	return result.Total, nil
 }
 
+// SumLoadByWarehouse sums the on-hand stock in a warehouse measured in a capacity unit:
+// the quantity itself, or the quantity weighted by each product's weight or volume
+func (r *GormInventoryItemRepository) SumLoadByWarehouse(ctx context.Context, tenantID, warehouseID uuid.UUID, unit partner.CapacityUnit) (decimal.Decimal, error) {
+	onHand := "(inventory_items.available_quantity + inventory_items.locked_quantity)"
+	query := r.db.WithContext(ctx).
+		Model(&models.InventoryItemModel{}).
+		Where("inventory_items.tenant_id = ? AND inventory_items.warehouse_id = ?", tenantID, warehouseID)
+
+	switch unit {
+	case partner.CapacityUnitKilogram:
+		query = query.Joins("JOIN products ON products.id = inventory_items.product_id").
+			Select("COALESCE(SUM(" + onHand + " * products.weight), 0) as total")
+	case partner.CapacityUnitCubicMetre:
+		query = query.Joins("JOIN products ON products.id = inventory_items.product_id").
+			Select("COALESCE(SUM(" + onHand + " * products.volume), 0) as total")
+	default:
+		query = query.Select("COALESCE(SUM(" + onHand + "), 0) as total")
+	}
+
+	var result struct {
+		Total decimal.Decimal
+	}
+	if err := query.Scan(&result).Error; err != nil {
+		return decimal.Zero, err
+	}
+	return result.Total, nil
+}
+
 // ExistsByWarehouseAndProduct checks if inventory exists for warehouse-product
 func (r *GormInventoryItemRepository) ExistsByWarehouseAndProduct(ctx context.Context, tenantID, warehouseID, productID uuid.UUID) (bool, error) {
 	var count int64
@@ -68,6 +68,9 @@ func TestGormInventoryItemRepository_FindByID(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "inventory_items" WHERE id = \$1`).
 			WithArgs(itemID, 1).
 			WillReturnRows(rows)
+		mock.ExpectQuery(`SELECT \* FROM "stock_batches" WHERE "stock_batches"."inventory_item_id" = \$1 AND consumed = \$2`).
+			WithArgs(itemID, false).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inventory_item_id", "batch_number", "quantity", "unit_cost", "consumed"}))
 
 		item, err := repo.FindByID(context.Background(), itemID)
 
@@ -154,6 +157,9 @@ func TestGormInventoryItemRepository_FindByWarehouseAndProduct(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "inventory_items" WHERE tenant_id = \$1 AND warehouse_id = \$2 AND product_id = \$3`).
 			WithArgs(tenantID, warehouseID, productID, 1).
 			WillReturnRows(rows)
+		mock.ExpectQuery(`SELECT \* FROM "stock_batches" WHERE "stock_batches"."inventory_item_id" = \$1 AND consumed = \$2`).
+			WithArgs(itemID, false).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inventory_item_id", "batch_number", "quantity", "unit_cost", "consumed"}))
 
 		item, err := repo.FindByWarehouseAndProduct(context.Background(), tenantID, warehouseID, productID)
 
@@ -132,6 +132,39 @@ func (r *GormInventoryTransactionRepository) FindByDateRange(ctx context.Context
 	return txs, nil
 }
 
+// FindByWarehouseAsOf finds all transactions for a warehouse dated at or before asOf,
+// in chronological order
+func (r *GormInventoryTransactionRepository) FindByWarehouseAsOf(ctx context.Context, tenantID, warehouseID uuid.UUID, asOf time.Time) ([]inventory.InventoryTransaction, error) {
+	var txModels []models.InventoryTransactionModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND warehouse_id = ? AND transaction_date <= ?", tenantID, warehouseID, asOf).
+		Order("transaction_date ASC, created_at ASC").
+		Find(&txModels).Error; err != nil {
+		return nil, err
+	}
+	txs := make([]inventory.InventoryTransaction, len(txModels))
+	for i, model := range txModels {
+		txs[i] = *model.ToDomain()
+	}
+	return txs, nil
+}
+
+// FindLedgerByInventoryItem finds all transactions for an inventory item in chronological order
+func (r *GormInventoryTransactionRepository) FindLedgerByInventoryItem(ctx context.Context, tenantID, inventoryItemID uuid.UUID) ([]inventory.InventoryTransaction, error) {
+	var txModels []models.InventoryTransactionModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND inventory_item_id = ?", tenantID, inventoryItemID).
+		Order("transaction_date ASC, created_at ASC").
+		Find(&txModels).Error; err != nil {
+		return nil, err
+	}
+	txs := make([]inventory.InventoryTransaction, len(txModels))
+	for i, model := range txModels {
+		txs[i] = *model.ToDomain()
+	}
+	return txs, nil
+}
+
 // FindByType finds transactions by type
 func (r *GormInventoryTransactionRepository) FindByType(ctx context.Context, tenantID uuid.UUID, txType inventory.TransactionType, filter shared.Filter) ([]inventory.InventoryTransaction, error) {
 	var txModels []models.InventoryTransactionModel
@@ -232,6 +265,11 @@ func (r *GormInventoryTransactionRepository) SumQuantityByTypeAndDateRange(ctx c
 func (r *GormInventoryTransactionRepository) applyFilter(query *gorm.DB, filter shared.Filter) *gorm.DB {
 	query = r.applyFilterWithoutPagination(query, filter)
 
+	// Cursor pagination seeks past the last row seen instead of using OFFSET
+	if filter.Keyset {
+		return applyKeyset(query, "transaction_date", filter)
+	}
+
 	// Apply pagination
 	if filter.Page > 0 && filter.PageSize > 0 {
 		offset := (filter.Page - 1) * filter.PageSize
@@ -35,6 +35,8 @@ func (s *GormTransactionScope) Execute(ctx context.Context, fn func(repos appinv
 // - InventoryRepo: Repository for the InventoryItem aggregate root
 // - LockRepo: Used for cross-aggregate lock queries and persistence
 // - TransactionRepo: Append-only repository for inventory transactions
+// - StockTakingRepo: Repository for the StockTaking aggregate
+// - SerialNumberRepo: Repository for serial numbers of serialized products
 //
 // Note: StockBatch is a child entity within InventoryItem and does not have
 // independent repository access in this transactional context.
@@ -57,6 +59,16 @@ func (r *gormTransactionalRepositories) TransactionRepo() inventory.InventoryTra
 	return NewGormInventoryTransactionRepository(r.tx)
 }
 
+// StockTakingRepo returns the stock taking repository scoped to the current transaction.
+func (r *gormTransactionalRepositories) StockTakingRepo() inventory.StockTakingRepository {
+	return NewGormStockTakingRepository(r.tx)
+}
+
+// SerialNumberRepo returns the serial number repository scoped to the current transaction.
+func (r *gormTransactionalRepositories) SerialNumberRepo() inventory.SerialNumberRepository {
+	return NewGormSerialNumberRepository(r.tx)
+}
+
 // Ensure GormTransactionScope implements TransactionScope
 var _ appinv.TransactionScope = (*GormTransactionScope)(nil)
 
@@ -0,0 +1,31 @@
+package persistence
+
+import (
+	"github.com/erp/backend/internal/domain/shared"
+	"gorm.io/gorm"
+)
+
+// applyKeyset orders the query by column and id and seeks past filter.After
+// instead of using OFFSET. It fetches one row more than the page size so the
+// caller can tell whether another page follows (see shared.NextCursor).
+// A cursor issued for the other sort order fails the query with shared.ErrInvalidCursor.
+func applyKeyset(query *gorm.DB, column string, filter shared.Filter) *gorm.DB {
+	sortOrder := ValidateSortOrder(filter.OrderDir)
+	if filter.After != nil {
+		if filter.After.Direction != sortOrder {
+			_ = query.AddError(shared.ErrInvalidCursor)
+			return query
+		}
+		op := "<"
+		if sortOrder == "ASC" {
+			op = ">"
+		}
+		query = query.Where("("+column+", id) "+op+" (?, ?)", filter.After.SortValue, filter.After.ID)
+	}
+
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	return query.Order(column + " " + sortOrder).Order("id " + sortOrder).Limit(pageSize + 1)
+}
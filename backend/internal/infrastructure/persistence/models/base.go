@@ -5,6 +5,7 @@ import (
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // BaseModel provides common persistence fields for all models.
@@ -52,6 +53,9 @@ type TenantAggregateModel struct {
 	CreatedBy *uuid.UUID `gorm:"type:uuid;index"`
 }
 
+// TenantScoped marks the model's rows as belonging to one tenant, so the tenant callbacks filter and guard its queries
+func (TenantAggregateModel) TenantScoped() {}
+
 // FromDomainTenantAggregateRoot populates TenantAggregateModel from domain TenantAggregateRoot
 func (m *TenantAggregateModel) FromDomainTenantAggregateRoot(t shared.TenantAggregateRoot) {
 	m.FromDomainAggregateRoot(t.BaseAggregateRoot)
@@ -68,3 +72,20 @@ func (m *TenantAggregateModel) PopulateTenantAggregateRoot(t *shared.TenantAggre
 	t.TenantID = m.TenantID
 	t.CreatedBy = m.CreatedBy
 }
+
+// deletedAtToDomain converts a soft-delete column to the domain's optional deletion time
+func deletedAtToDomain(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
+
+// deletedAtFromDomain converts the domain's optional deletion time to a soft-delete column
+func deletedAtFromDomain(t *time.Time) gorm.DeletedAt {
+	if t == nil {
+		return gorm.DeletedAt{}
+	}
+	return gorm.DeletedAt{Time: *t, Valid: true}
+}
@@ -1,18 +1,20 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/erp/backend/internal/domain/catalog"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 )
 
 // ProductModel is the persistence model for the Product domain entity.
 type ProductModel struct {
 	TenantAggregateModel
-	Code          string                `gorm:"type:varchar(50);not null;uniqueIndex:idx_product_tenant_code,priority:2"`
+	Code          string                `gorm:"type:varchar(50);not null;uniqueIndex:idx_product_tenant_code,priority:2,where:deleted_at IS NULL"`
 	Name          string                `gorm:"type:varchar(200);not null"`
 	Description   string                `gorm:"type:text"`
 	Barcode       string                `gorm:"type:varchar(50);index"`
@@ -24,6 +26,11 @@ type ProductModel struct {
 	Status        catalog.ProductStatus `gorm:"type:varchar(20);not null;default:'active'"`
 	SortOrder     int                   `gorm:"not null;default:0"`
 	Attributes    string                `gorm:"type:jsonb"`
+	IsSerialized  bool                  `gorm:"not null;default:false"`
+	Weight        decimal.Decimal       `gorm:"type:decimal(18,4);not null;default:0"`
+	Volume        decimal.Decimal       `gorm:"type:decimal(18,6);not null;default:0"`
+	PriceTiers    string                `gorm:"type:jsonb;not null;default:'[]'"`
+	DeletedAt     gorm.DeletedAt        `gorm:"index"`
 }
 
 // TableName returns the table name for GORM
@@ -58,6 +65,11 @@ func (m *ProductModel) ToDomain() *catalog.Product {
 		Status:        m.Status,
 		SortOrder:     m.SortOrder,
 		Attributes:    m.Attributes,
+		IsSerialized:  m.IsSerialized,
+		Weight:        m.Weight,
+		Volume:        m.Volume,
+		PriceTiers:    priceTiersToDomain(m.PriceTiers),
+		DeletedAt:     deletedAtToDomain(m.DeletedAt),
 	}
 }
 
@@ -76,6 +88,35 @@ func (m *ProductModel) FromDomain(p *catalog.Product) {
 	m.Status = p.Status
 	m.SortOrder = p.SortOrder
 	m.Attributes = p.Attributes
+	m.IsSerialized = p.IsSerialized
+	m.Weight = p.Weight
+	m.Volume = p.Volume
+	m.PriceTiers = priceTiersFromDomain(p.PriceTiers)
+	m.DeletedAt = deletedAtFromDomain(p.DeletedAt)
+}
+
+// priceTiersToDomain parses the price tiers column; tiers that cannot be parsed are treated as none
+func priceTiersToDomain(raw string) []catalog.PriceTier {
+	if raw == "" || raw == "[]" {
+		return nil
+	}
+	var tiers []catalog.PriceTier
+	if err := json.Unmarshal([]byte(raw), &tiers); err != nil {
+		return nil
+	}
+	return tiers
+}
+
+// priceTiersFromDomain serializes price tiers for the price tiers column
+func priceTiersFromDomain(tiers []catalog.PriceTier) string {
+	if len(tiers) == 0 {
+		return "[]"
+	}
+	raw, err := json.Marshal(tiers)
+	if err != nil {
+		return "[]"
+	}
+	return string(raw)
 }
 
 // ProductModelFromDomain creates a new persistence model from a domain Product entity.
@@ -157,6 +198,7 @@ type ProductUnitModel struct {
 	UnitCode              string          `gorm:"type:varchar(20);not null;uniqueIndex:idx_product_unit_code,priority:3"`
 	UnitName              string          `gorm:"type:varchar(50);not null"`
 	ConversionRate        decimal.Decimal `gorm:"type:decimal(18,6);not null"`
+	ReferenceUnitCode     string          `gorm:"type:varchar(20);not null;default:''"`
 	DefaultPurchasePrice  decimal.Decimal `gorm:"type:decimal(18,4);not null;default:0"`
 	DefaultSellingPrice   decimal.Decimal `gorm:"type:decimal(18,4);not null;default:0"`
 	IsDefaultPurchaseUnit bool            `gorm:"not null;default:false"`
@@ -180,6 +222,7 @@ func (m *ProductUnitModel) ToDomain() *catalog.ProductUnit {
 		UnitCode:              m.UnitCode,
 		UnitName:              m.UnitName,
 		ConversionRate:        m.ConversionRate,
+		ReferenceUnitCode:     m.ReferenceUnitCode,
 		DefaultPurchasePrice:  m.DefaultPurchasePrice,
 		DefaultSellingPrice:   m.DefaultSellingPrice,
 		IsDefaultPurchaseUnit: m.IsDefaultPurchaseUnit,
@@ -198,6 +241,7 @@ func (m *ProductUnitModel) FromDomain(pu *catalog.ProductUnit) {
 	m.UnitCode = pu.UnitCode
 	m.UnitName = pu.UnitName
 	m.ConversionRate = pu.ConversionRate
+	m.ReferenceUnitCode = pu.ReferenceUnitCode
 	m.DefaultPurchasePrice = pu.DefaultPurchasePrice
 	m.DefaultSellingPrice = pu.DefaultSellingPrice
 	m.IsDefaultPurchaseUnit = pu.IsDefaultPurchaseUnit
@@ -213,3 +257,53 @@ func ProductUnitModelFromDomain(pu *catalog.ProductUnit) *ProductUnitModel {
 	m.FromDomain(pu)
 	return m
 }
+
+// ProductPriceHistoryModel is the persistence model for the ProductPriceHistory entity.
+type ProductPriceHistoryModel struct {
+	BaseModel
+	TenantID    uuid.UUID         `gorm:"type:uuid;not null;index:idx_price_history_product_time,priority:1"`
+	ProductID   uuid.UUID         `gorm:"type:uuid;not null;index:idx_price_history_product_time,priority:2"`
+	PriceType   catalog.PriceType `gorm:"type:varchar(20);not null;index:idx_price_history_product_time,priority:3"`
+	OldPrice    decimal.Decimal   `gorm:"type:decimal(18,4);not null"`
+	NewPrice    decimal.Decimal   `gorm:"type:decimal(18,4);not null"`
+	ChangedBy   *uuid.UUID        `gorm:"type:uuid"`
+	EffectiveAt time.Time         `gorm:"type:timestamptz;not null;index:idx_price_history_product_time,priority:4"`
+}
+
+// TableName returns the table name for GORM
+func (ProductPriceHistoryModel) TableName() string {
+	return "product_price_histories"
+}
+
+// ToDomain converts the persistence model to a domain ProductPriceHistory entity.
+func (m *ProductPriceHistoryModel) ToDomain() *catalog.ProductPriceHistory {
+	return &catalog.ProductPriceHistory{
+		BaseEntity:  m.BaseModel.ToDomain(),
+		TenantID:    m.TenantID,
+		ProductID:   m.ProductID,
+		PriceType:   m.PriceType,
+		OldPrice:    m.OldPrice,
+		NewPrice:    m.NewPrice,
+		ChangedBy:   m.ChangedBy,
+		EffectiveAt: m.EffectiveAt,
+	}
+}
+
+// FromDomain populates the persistence model from a domain ProductPriceHistory entity.
+func (m *ProductPriceHistoryModel) FromDomain(h *catalog.ProductPriceHistory) {
+	m.FromDomainBaseEntity(h.BaseEntity)
+	m.TenantID = h.TenantID
+	m.ProductID = h.ProductID
+	m.PriceType = h.PriceType
+	m.OldPrice = h.OldPrice
+	m.NewPrice = h.NewPrice
+	m.ChangedBy = h.ChangedBy
+	m.EffectiveAt = h.EffectiveAt
+}
+
+// ProductPriceHistoryModelFromDomain creates a new persistence model from a domain ProductPriceHistory entity.
+func ProductPriceHistoryModelFromDomain(h *catalog.ProductPriceHistory) *ProductPriceHistoryModel {
+	m := &ProductPriceHistoryModel{}
+	m.FromDomain(h)
+	return m
+}
@@ -18,16 +18,17 @@ var modelLogger = zap.L().Named("featureflag.models")
 // across the entire system.
 type FeatureFlagModel struct {
 	AggregateModel
-	Key              string                 `gorm:"type:varchar(100);not null;uniqueIndex"`
-	Name             string                 `gorm:"type:varchar(200);not null"`
-	Description      string                 `gorm:"type:text"`
-	Type             featureflag.FlagType   `gorm:"type:varchar(20);not null"`
-	Status           featureflag.FlagStatus `gorm:"type:varchar(20);not null;index"`
-	DefaultValueJSON string                 `gorm:"column:default_value;type:jsonb;not null"`
-	RulesJSON        string                 `gorm:"column:rules;type:jsonb;default:'[]'"`
-	TagsJSON         string                 `gorm:"column:tags;type:jsonb;default:'[]'"`
-	CreatedBy        *uuid.UUID             `gorm:"type:uuid;index"`
-	UpdatedBy        *uuid.UUID             `gorm:"type:uuid"`
+	Key               string                 `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Name              string                 `gorm:"type:varchar(200);not null"`
+	Description       string                 `gorm:"type:text"`
+	Type              featureflag.FlagType   `gorm:"type:varchar(20);not null"`
+	Status            featureflag.FlagStatus `gorm:"type:varchar(20);not null;index"`
+	DefaultValueJSON  string                 `gorm:"column:default_value;type:jsonb;not null"`
+	RulesJSON         string                 `gorm:"column:rules;type:jsonb;default:'[]'"`
+	TagsJSON          string                 `gorm:"column:tags;type:jsonb;default:'[]'"`
+	PrerequisitesJSON string                 `gorm:"column:prerequisites;type:jsonb;default:'[]'"`
+	CreatedBy         *uuid.UUID             `gorm:"type:uuid;index"`
+	UpdatedBy         *uuid.UUID             `gorm:"type:uuid"`
 }
 
 // TableName returns the table name for GORM
@@ -46,15 +47,16 @@ func (m *FeatureFlagModel) ToDomain() *featureflag.FeatureFlag {
 			},
 			Version: m.Version,
 		},
-		Key:         m.Key,
-		Name:        m.Name,
-		Description: m.Description,
-		Type:        m.Type,
-		Status:      m.Status,
-		Rules:       make([]featureflag.TargetingRule, 0),
-		Tags:        make([]string, 0),
-		CreatedBy:   m.CreatedBy,
-		UpdatedBy:   m.UpdatedBy,
+		Key:           m.Key,
+		Name:          m.Name,
+		Description:   m.Description,
+		Type:          m.Type,
+		Status:        m.Status,
+		Rules:         make([]featureflag.TargetingRule, 0),
+		Tags:          make([]string, 0),
+		Prerequisites: make([]featureflag.Prerequisite, 0),
+		CreatedBy:     m.CreatedBy,
+		UpdatedBy:     m.UpdatedBy,
 	}
 
 	// Parse default value from JSON
@@ -96,6 +98,19 @@ func (m *FeatureFlagModel) ToDomain() *featureflag.FeatureFlag {
 		}
 	}
 
+	// Parse prerequisites from JSON
+	if m.PrerequisitesJSON != "" && m.PrerequisitesJSON != "[]" {
+		var prerequisites []featureflag.Prerequisite
+		if err := json.Unmarshal([]byte(m.PrerequisitesJSON), &prerequisites); err != nil {
+			modelLogger.Warn("failed to parse prerequisites JSON",
+				zap.String("flag_key", m.Key),
+				zap.String("raw_json", m.PrerequisitesJSON),
+				zap.Error(err))
+		} else {
+			flag.Prerequisites = prerequisites
+		}
+	}
+
 	return flag
 }
 
@@ -138,6 +153,17 @@ func (m *FeatureFlagModel) FromDomain(f *featureflag.FeatureFlag) {
 	} else {
 		m.TagsJSON = "[]"
 	}
+
+	// Serialize prerequisites to JSON
+	if len(f.Prerequisites) > 0 {
+		if jsonBytes, err := json.Marshal(f.Prerequisites); err == nil {
+			m.PrerequisitesJSON = string(jsonBytes)
+		} else {
+			m.PrerequisitesJSON = "[]"
+		}
+	} else {
+		m.PrerequisitesJSON = "[]"
+	}
 }
 
 // FeatureFlagModelFromDomain creates a new persistence model from a domain FeatureFlag entity.
@@ -118,6 +118,18 @@ func TestFeatureFlagModel_RulesJSONRoundTrip(t *testing.T) {
 	assert.Equal(t, "country", domainFlag.Rules[0].Conditions[0].Attribute)
 }
 
+func TestFeatureFlagModel_PrerequisitesJSONRoundTrip(t *testing.T) {
+	flag, err := featureflag.NewBooleanFlag("new_checkout", "New Checkout", true, nil)
+	require.NoError(t, err)
+	prerequisites := []featureflag.Prerequisite{{FlagKey: "new_cart"}, {FlagKey: "checkout_layout", Variant: "compact"}}
+	require.NoError(t, flag.SetPrerequisites(prerequisites, nil))
+
+	model := FeatureFlagModelFromDomain(flag)
+	assert.JSONEq(t, `[{"flag_key":"new_cart"},{"flag_key":"checkout_layout","variant":"compact"}]`, model.PrerequisitesJSON)
+
+	assert.Equal(t, prerequisites, model.ToDomain().Prerequisites)
+}
+
 func TestFlagOverrideModel_TableName(t *testing.T) {
 	model := FlagOverrideModel{}
 	assert.Equal(t, "flag_overrides", model.TableName())
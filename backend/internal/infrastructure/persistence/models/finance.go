@@ -5,6 +5,7 @@ import (
 
 	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -12,24 +13,32 @@ import (
 // AccountReceivableModel is the persistence model for the AccountReceivable aggregate root.
 type AccountReceivableModel struct {
 	TenantAggregateModel
-	ReceivableNumber  string                   `gorm:"type:varchar(50);not null;uniqueIndex:idx_receivable_tenant_number,priority:2"`
-	CustomerID        uuid.UUID                `gorm:"type:uuid;not null;index"`
-	CustomerName      string                   `gorm:"type:varchar(200);not null"`
-	SourceType        finance.SourceType       `gorm:"type:varchar(30);not null;index"`
-	SourceID          uuid.UUID                `gorm:"type:uuid;not null;index"`
-	SourceNumber      string                   `gorm:"type:varchar(50);not null"`
-	TotalAmount       decimal.Decimal          `gorm:"type:decimal(18,4);not null"`
-	PaidAmount        decimal.Decimal          `gorm:"type:decimal(18,4);not null"`
-	OutstandingAmount decimal.Decimal          `gorm:"type:decimal(18,4);not null;index"`
-	Status            finance.ReceivableStatus `gorm:"type:varchar(20);not null;default:'PENDING';index"`
-	DueDate           *time.Time               `gorm:"index"`
-	PaymentRecords    finance.PaymentRecords   `gorm:"type:jsonb;default:'[]'"`
-	Remark            string                   `gorm:"type:text"`
-	PaidAt            *time.Time
-	ReversedAt        *time.Time
-	ReversalReason    string `gorm:"type:varchar(500)"`
-	CancelledAt       *time.Time
-	CancelReason      string `gorm:"type:varchar(500)"`
+	ReceivableNumber   string                   `gorm:"type:varchar(50);not null;uniqueIndex:idx_receivable_tenant_number,priority:2"`
+	CustomerID         uuid.UUID                `gorm:"type:uuid;not null;index"`
+	CustomerName       string                   `gorm:"type:varchar(200);not null"`
+	SourceType         finance.SourceType       `gorm:"type:varchar(30);not null;index"`
+	SourceID           uuid.UUID                `gorm:"type:uuid;not null;index"`
+	SourceNumber       string                   `gorm:"type:varchar(50);not null"`
+	ShipmentNumber     int                      `gorm:"not null;default:0"`
+	Currency           string                   `gorm:"type:varchar(3);not null;default:'CNY';index"`
+	TotalAmount        decimal.Decimal          `gorm:"type:decimal(18,4);not null"`
+	PaidAmount         decimal.Decimal          `gorm:"type:decimal(18,4);not null"`
+	OutstandingAmount  decimal.Decimal          `gorm:"type:decimal(18,4);not null;index"`
+	Status             finance.ReceivableStatus `gorm:"type:varchar(20);not null;default:'PENDING';index"`
+	DueDate            *time.Time               `gorm:"index"`
+	PaymentRecords     finance.PaymentRecords   `gorm:"type:jsonb;default:'[]'"`
+	Remark             string                   `gorm:"type:text"`
+	PaidAt             *time.Time
+	ReversedAt         *time.Time
+	ReversalReason     string `gorm:"type:varchar(500)"`
+	CancelledAt        *time.Time
+	CancelReason       string          `gorm:"type:varchar(500)"`
+	WrittenOffAmount   decimal.Decimal `gorm:"type:decimal(18,4);not null;default:0"`
+	WrittenOffAt       *time.Time
+	WrittenOffBy       *uuid.UUID                      `gorm:"type:uuid"`
+	WriteOffReason     string                          `gorm:"type:varchar(500)"`
+	ReminderStage      finance.ReceivableReminderStage `gorm:"type:varchar(20);not null;default:''"`
+	LastReminderSentAt *time.Time
 }
 
 // TableName returns the table name for GORM
@@ -52,24 +61,32 @@ func (m *AccountReceivableModel) ToDomain() *finance.AccountReceivable {
 			TenantID:  m.TenantID,
 			CreatedBy: m.CreatedBy,
 		},
-		ReceivableNumber:  m.ReceivableNumber,
-		CustomerID:        m.CustomerID,
-		CustomerName:      m.CustomerName,
-		SourceType:        m.SourceType,
-		SourceID:          m.SourceID,
-		SourceNumber:      m.SourceNumber,
-		TotalAmount:       m.TotalAmount,
-		PaidAmount:        m.PaidAmount,
-		OutstandingAmount: m.OutstandingAmount,
-		Status:            m.Status,
-		DueDate:           m.DueDate,
-		PaymentRecords:    m.PaymentRecords,
-		Remark:            m.Remark,
-		PaidAt:            m.PaidAt,
-		ReversedAt:        m.ReversedAt,
-		ReversalReason:    m.ReversalReason,
-		CancelledAt:       m.CancelledAt,
-		CancelReason:      m.CancelReason,
+		ReceivableNumber:   m.ReceivableNumber,
+		CustomerID:         m.CustomerID,
+		CustomerName:       m.CustomerName,
+		SourceType:         m.SourceType,
+		SourceID:           m.SourceID,
+		SourceNumber:       m.SourceNumber,
+		ShipmentNumber:     m.ShipmentNumber,
+		Currency:           valueobject.Currency(m.Currency),
+		TotalAmount:        m.TotalAmount,
+		PaidAmount:         m.PaidAmount,
+		OutstandingAmount:  m.OutstandingAmount,
+		Status:             m.Status,
+		DueDate:            m.DueDate,
+		PaymentRecords:     m.PaymentRecords,
+		Remark:             m.Remark,
+		PaidAt:             m.PaidAt,
+		ReversedAt:         m.ReversedAt,
+		ReversalReason:     m.ReversalReason,
+		CancelledAt:        m.CancelledAt,
+		CancelReason:       m.CancelReason,
+		WrittenOffAmount:   m.WrittenOffAmount,
+		WrittenOffAt:       m.WrittenOffAt,
+		WrittenOffBy:       m.WrittenOffBy,
+		WriteOffReason:     m.WriteOffReason,
+		ReminderStage:      m.ReminderStage,
+		LastReminderSentAt: m.LastReminderSentAt,
 	}
 }
 
@@ -82,6 +99,8 @@ func (m *AccountReceivableModel) FromDomain(ar *finance.AccountReceivable) {
 	m.SourceType = ar.SourceType
 	m.SourceID = ar.SourceID
 	m.SourceNumber = ar.SourceNumber
+	m.ShipmentNumber = ar.ShipmentNumber
+	m.Currency = string(ar.GetCurrency())
 	m.TotalAmount = ar.TotalAmount
 	m.PaidAmount = ar.PaidAmount
 	m.OutstandingAmount = ar.OutstandingAmount
@@ -94,6 +113,12 @@ func (m *AccountReceivableModel) FromDomain(ar *finance.AccountReceivable) {
 	m.ReversalReason = ar.ReversalReason
 	m.CancelledAt = ar.CancelledAt
 	m.CancelReason = ar.CancelReason
+	m.WrittenOffAmount = ar.WrittenOffAmount
+	m.WrittenOffAt = ar.WrittenOffAt
+	m.WrittenOffBy = ar.WrittenOffBy
+	m.WriteOffReason = ar.WriteOffReason
+	m.ReminderStage = ar.ReminderStage
+	m.LastReminderSentAt = ar.LastReminderSentAt
 }
 
 // AccountReceivableModelFromDomain creates a new persistence model from a domain AccountReceivable.
@@ -112,6 +137,7 @@ type AccountPayableModel struct {
 	SourceType        finance.PayableSourceType   `gorm:"type:varchar(30);not null;index"`
 	SourceID          uuid.UUID                   `gorm:"type:uuid;not null;index"`
 	SourceNumber      string                      `gorm:"type:varchar(50);not null"`
+	Currency          string                      `gorm:"type:varchar(3);not null;default:'CNY';index"`
 	TotalAmount       decimal.Decimal             `gorm:"type:decimal(18,4);not null"`
 	PaidAmount        decimal.Decimal             `gorm:"type:decimal(18,4);not null"`
 	OutstandingAmount decimal.Decimal             `gorm:"type:decimal(18,4);not null;index"`
@@ -123,7 +149,12 @@ type AccountPayableModel struct {
 	ReversedAt        *time.Time
 	ReversalReason    string `gorm:"type:varchar(500)"`
 	CancelledAt       *time.Time
-	CancelReason      string `gorm:"type:varchar(500)"`
+	CancelReason      string                       `gorm:"type:varchar(500)"`
+	InvoiceNumber     string                       `gorm:"type:varchar(50)"`
+	InvoiceAmount     decimal.Decimal              `gorm:"type:decimal(18,4);not null;default:0"`
+	MatchResult       *finance.ThreeWayMatchResult `gorm:"type:jsonb"`
+	InvoiceDate       *time.Time
+	ReceiptIDs        finance.ReceiptIDs `gorm:"type:jsonb;not null;default:'[]'"`
 }
 
 // TableName returns the table name for GORM
@@ -152,6 +183,7 @@ func (m *AccountPayableModel) ToDomain() *finance.AccountPayable {
 		SourceType:        m.SourceType,
 		SourceID:          m.SourceID,
 		SourceNumber:      m.SourceNumber,
+		Currency:          valueobject.Currency(m.Currency),
 		TotalAmount:       m.TotalAmount,
 		PaidAmount:        m.PaidAmount,
 		OutstandingAmount: m.OutstandingAmount,
@@ -163,6 +195,11 @@ func (m *AccountPayableModel) ToDomain() *finance.AccountPayable {
 		ReversalReason:    m.ReversalReason,
 		CancelledAt:       m.CancelledAt,
 		CancelReason:      m.CancelReason,
+		InvoiceNumber:     m.InvoiceNumber,
+		InvoiceAmount:     m.InvoiceAmount,
+		InvoiceDate:       m.InvoiceDate,
+		MatchResult:       m.MatchResult,
+		ReceiptIDs:        m.ReceiptIDs,
 		PaymentRecords:    make([]finance.PayablePaymentRecord, len(m.PaymentRecords)),
 	}
 	for i, pr := range m.PaymentRecords {
@@ -180,6 +217,7 @@ func (m *AccountPayableModel) FromDomain(ap *finance.AccountPayable) {
 	m.SourceType = ap.SourceType
 	m.SourceID = ap.SourceID
 	m.SourceNumber = ap.SourceNumber
+	m.Currency = string(ap.GetCurrency())
 	m.TotalAmount = ap.TotalAmount
 	m.PaidAmount = ap.PaidAmount
 	m.OutstandingAmount = ap.OutstandingAmount
@@ -191,6 +229,11 @@ func (m *AccountPayableModel) FromDomain(ap *finance.AccountPayable) {
 	m.ReversalReason = ap.ReversalReason
 	m.CancelledAt = ap.CancelledAt
 	m.CancelReason = ap.CancelReason
+	m.InvoiceNumber = ap.InvoiceNumber
+	m.InvoiceAmount = ap.InvoiceAmount
+	m.InvoiceDate = ap.InvoiceDate
+	m.MatchResult = ap.MatchResult
+	m.ReceiptIDs = ap.ReceiptIDs
 	m.PaymentRecords = make([]PayablePaymentRecordModel, len(ap.PaymentRecords))
 	for i, pr := range ap.PaymentRecords {
 		m.PaymentRecords[i] = *PayablePaymentRecordModelFromDomain(&pr)
@@ -563,6 +606,11 @@ type ExpenseRecordModel struct {
 	CancelledAt     *time.Time
 	CancelledBy     *uuid.UUID `gorm:"type:uuid"`
 	CancelReason    string     `gorm:"type:varchar(500)"`
+
+	RequiredApprovals int                      `gorm:"not null;default:1"`
+	Approvals         finance.ExpenseApprovals `gorm:"type:jsonb;default:'[]'"`
+
+	Attachments finance.ExpenseAttachments `gorm:"type:jsonb;default:'[]'"`
 }
 
 // TableName returns the table name for GORM
@@ -607,6 +655,11 @@ func (m *ExpenseRecordModel) ToDomain() *finance.ExpenseRecord {
 		CancelledAt:     m.CancelledAt,
 		CancelledBy:     m.CancelledBy,
 		CancelReason:    m.CancelReason,
+
+		RequiredApprovals: m.RequiredApprovals,
+		Approvals:         m.Approvals,
+
+		Attachments: m.Attachments,
 	}
 }
 
@@ -635,6 +688,9 @@ func (m *ExpenseRecordModel) FromDomain(er *finance.ExpenseRecord) {
 	m.CancelledAt = er.CancelledAt
 	m.CancelledBy = er.CancelledBy
 	m.CancelReason = er.CancelReason
+	m.RequiredApprovals = er.GetRequiredApprovals()
+	m.Approvals = er.Approvals
+	m.Attachments = er.Attachments
 }
 
 // ExpenseRecordModelFromDomain creates a new persistence model from domain.
@@ -920,3 +976,50 @@ func RefundRecordModelFromDomain(rr *finance.RefundRecord) *RefundRecordModel {
 	m.FromDomain(rr)
 	return m
 }
+
+// VoucherIdempotencyKeyModel is the persistence model for VoucherIdempotencyKey.
+type VoucherIdempotencyKeyModel struct {
+	ID          uuid.UUID                     `gorm:"type:uuid;primary_key"`
+	TenantID    uuid.UUID                     `gorm:"type:uuid;not null;uniqueIndex:uq_voucher_idempotency_keys_key,priority:1"`
+	VoucherType finance.IdempotentVoucherType `gorm:"type:varchar(20);not null;uniqueIndex:uq_voucher_idempotency_keys_key,priority:2"`
+	Key         string                        `gorm:"column:idempotency_key;type:varchar(255);not null;uniqueIndex:uq_voucher_idempotency_keys_key,priority:3"`
+	VoucherID   uuid.UUID                     `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time                     `gorm:"not null"`
+	ExpiresAt   time.Time                     `gorm:"not null;index"`
+}
+
+// TableName returns the table name for GORM
+func (VoucherIdempotencyKeyModel) TableName() string {
+	return "voucher_idempotency_keys"
+}
+
+// ToDomain converts the persistence model to a domain VoucherIdempotencyKey.
+func (m *VoucherIdempotencyKeyModel) ToDomain() *finance.VoucherIdempotencyKey {
+	return &finance.VoucherIdempotencyKey{
+		ID:          m.ID,
+		TenantID:    m.TenantID,
+		VoucherType: m.VoucherType,
+		Key:         m.Key,
+		VoucherID:   m.VoucherID,
+		CreatedAt:   m.CreatedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}
+}
+
+// FromDomain populates the persistence model from a domain VoucherIdempotencyKey.
+func (m *VoucherIdempotencyKeyModel) FromDomain(k *finance.VoucherIdempotencyKey) {
+	m.ID = k.ID
+	m.TenantID = k.TenantID
+	m.VoucherType = k.VoucherType
+	m.Key = k.Key
+	m.VoucherID = k.VoucherID
+	m.CreatedAt = k.CreatedAt
+	m.ExpiresAt = k.ExpiresAt
+}
+
+// VoucherIdempotencyKeyModelFromDomain creates a new persistence model from domain.
+func VoucherIdempotencyKeyModelFromDomain(k *finance.VoucherIdempotencyKey) *VoucherIdempotencyKeyModel {
+	m := &VoucherIdempotencyKeyModel{}
+	m.FromDomain(k)
+	return m
+}
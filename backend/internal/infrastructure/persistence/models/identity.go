@@ -1,11 +1,13 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // UserModel is the persistence model for the User domain entity.
@@ -96,6 +98,47 @@ func UserModelFromDomain(u *identity.User) *UserModel {
 	return m
 }
 
+// PasswordHistoryModel is the persistence model for the PasswordHistoryEntry domain entity.
+type PasswordHistoryModel struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key"`
+	TenantID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	PasswordHash string    `gorm:"type:varchar(255);not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for GORM
+func (PasswordHistoryModel) TableName() string {
+	return "user_password_history"
+}
+
+// ToDomain converts the persistence model to a domain PasswordHistoryEntry entity.
+func (m *PasswordHistoryModel) ToDomain() *identity.PasswordHistoryEntry {
+	return &identity.PasswordHistoryEntry{
+		ID:           m.ID,
+		TenantID:     m.TenantID,
+		UserID:       m.UserID,
+		PasswordHash: m.PasswordHash,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// FromDomain populates the persistence model from a domain PasswordHistoryEntry entity.
+func (m *PasswordHistoryModel) FromDomain(e *identity.PasswordHistoryEntry) {
+	m.ID = e.ID
+	m.TenantID = e.TenantID
+	m.UserID = e.UserID
+	m.PasswordHash = e.PasswordHash
+	m.CreatedAt = e.CreatedAt
+}
+
+// PasswordHistoryModelFromDomain creates a new persistence model from a domain PasswordHistoryEntry entity.
+func PasswordHistoryModelFromDomain(e *identity.PasswordHistoryEntry) *PasswordHistoryModel {
+	m := &PasswordHistoryModel{}
+	m.FromDomain(e)
+	return m
+}
+
 // UserRoleModel is the persistence model for the UserRole relationship.
 type UserRoleModel struct {
 	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
@@ -153,7 +196,15 @@ type TenantModel struct {
 	ConfigCurrency      string `gorm:"column:config_currency;type:varchar(10);default:'CNY'"`
 	ConfigTimezone      string `gorm:"column:config_timezone;type:varchar(50);default:'Asia/Shanghai'"`
 	ConfigLocale        string `gorm:"column:config_locale;type:varchar(20);default:'zh-CN'"`
-	Notes               string `gorm:"type:text"`
+	// ConfigCreditControlEnabled is a pointer so that an explicit false is not replaced by the column default
+	ConfigCreditControlEnabled *bool `gorm:"column:config_credit_control_enabled;not null;default:true"`
+	// ConfigExpenseApprovalThresholds is a JSON array of decimal amounts
+	ConfigExpenseApprovalThresholds string `gorm:"column:config_expense_approval_thresholds;type:jsonb;not null;default:'[]'"`
+	ConfigIndustryPlugin            string `gorm:"column:config_industry_plugin;type:varchar(50);not null;default:''"`
+	ConfigShipExpiryBufferDays      int    `gorm:"column:config_ship_expiry_buffer_days;not null;default:0"`
+	// ConfigPasswordPolicy is a JSON object of the password policy; '{}' means the default policy
+	ConfigPasswordPolicy string `gorm:"column:config_password_policy;type:jsonb;not null;default:'{}'"`
+	Notes                string `gorm:"type:text"`
 	// Stripe billing fields
 	StripeCustomerID     string `gorm:"column:stripe_customer_id;type:varchar(255);index"`
 	StripeSubscriptionID string `gorm:"column:stripe_subscription_id;type:varchar(255);index"`
@@ -198,6 +249,12 @@ func (m *TenantModel) ToDomain() *identity.Tenant {
 			Currency:      m.ConfigCurrency,
 			Timezone:      m.ConfigTimezone,
 			Locale:        m.ConfigLocale,
+
+			CreditControlEnabled:      m.ConfigCreditControlEnabled == nil || *m.ConfigCreditControlEnabled,
+			ExpenseApprovalThresholds: parseExpenseApprovalThresholds(m.ConfigExpenseApprovalThresholds),
+			IndustryPlugin:            m.ConfigIndustryPlugin,
+			ShipExpiryBufferDays:      m.ConfigShipExpiryBufferDays,
+			PasswordPolicy:            parsePasswordPolicy(m.ConfigPasswordPolicy),
 		},
 		Notes:                m.Notes,
 		StripeCustomerID:     m.StripeCustomerID,
@@ -230,11 +287,64 @@ func (m *TenantModel) FromDomain(t *identity.Tenant) {
 	m.ConfigCurrency = t.Config.Currency
 	m.ConfigTimezone = t.Config.Timezone
 	m.ConfigLocale = t.Config.Locale
+	creditControlEnabled := t.Config.CreditControlEnabled
+	m.ConfigCreditControlEnabled = &creditControlEnabled
+	m.ConfigExpenseApprovalThresholds = formatExpenseApprovalThresholds(t.Config.ExpenseApprovalThresholds)
+	m.ConfigIndustryPlugin = t.Config.IndustryPlugin
+	m.ConfigShipExpiryBufferDays = t.Config.ShipExpiryBufferDays
+	m.ConfigPasswordPolicy = formatPasswordPolicy(t.Config.PasswordPolicy)
 	m.Notes = t.Notes
 	m.StripeCustomerID = t.StripeCustomerID
 	m.StripeSubscriptionID = t.StripeSubscriptionID
 }
 
+// parseExpenseApprovalThresholds decodes the stored JSON array of thresholds.
+// Invalid or empty data yields no thresholds, so every expense needs a single approval.
+func parseExpenseApprovalThresholds(data string) []decimal.Decimal {
+	if data == "" {
+		return nil
+	}
+	var thresholds []decimal.Decimal
+	if err := json.Unmarshal([]byte(data), &thresholds); err != nil {
+		return nil
+	}
+	return thresholds
+}
+
+// formatExpenseApprovalThresholds encodes thresholds as a JSON array for storage
+func formatExpenseApprovalThresholds(thresholds []decimal.Decimal) string {
+	if len(thresholds) == 0 {
+		return "[]"
+	}
+	data, err := json.Marshal(thresholds)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// parsePasswordPolicy decodes the stored password policy.
+// Invalid or empty data yields the default policy.
+func parsePasswordPolicy(data string) identity.PasswordPolicy {
+	var policy identity.PasswordPolicy
+	if data == "" || json.Unmarshal([]byte(data), &policy) != nil || policy == (identity.PasswordPolicy{}) {
+		return identity.DefaultPasswordPolicy()
+	}
+	return policy
+}
+
+// formatPasswordPolicy encodes a password policy as a JSON object for storage
+func formatPasswordPolicy(policy identity.PasswordPolicy) string {
+	if policy == (identity.PasswordPolicy{}) {
+		return "{}"
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
 // TenantModelFromDomain creates a new persistence model from a domain Tenant entity.
 func TenantModelFromDomain(t *identity.Tenant) *TenantModel {
 	m := &TenantModel{}
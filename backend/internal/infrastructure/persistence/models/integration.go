@@ -96,3 +96,212 @@ func ProductMappingModelFromDomain(pm *integration.ProductMapping) *ProductMappi
 	m.FromDomain(pm)
 	return m
 }
+
+// PlatformOrderSyncRecordModel is the persistence model for the PlatformOrderSyncRecord domain type.
+type PlatformOrderSyncRecordModel struct {
+	ID               uuid.UUID                       `gorm:"type:uuid;primary_key"`
+	TenantID         uuid.UUID                       `gorm:"type:uuid;not null;uniqueIndex:idx_order_sync_record_platform_order,priority:1"`
+	PlatformCode     integration.PlatformCode        `gorm:"type:varchar(20);not null;uniqueIndex:idx_order_sync_record_platform_order,priority:2"`
+	PlatformOrderID  string                          `gorm:"type:varchar(100);not null;uniqueIndex:idx_order_sync_record_platform_order,priority:3"`
+	LocalOrderID     *uuid.UUID                      `gorm:"type:uuid;index"`
+	LocalOrderNumber string                          `gorm:"type:varchar(50)"`
+	Direction        integration.OrderSyncDirection  `gorm:"type:varchar(20);not null"`
+	Status           integration.SyncStatus          `gorm:"type:varchar(20);not null"`
+	PlatformStatus   integration.PlatformOrderStatus `gorm:"type:varchar(20)"`
+	ErrorMessage     string                          `gorm:"type:text"`
+	SyncedAt         time.Time                       `gorm:"not null"`
+	CreatedAt        time.Time                       `gorm:"not null"`
+	UpdatedAt        time.Time                       `gorm:"not null"`
+}
+
+// TableName returns the table name for GORM
+func (PlatformOrderSyncRecordModel) TableName() string {
+	return "platform_order_sync_records"
+}
+
+// ToDomain converts the persistence model to a domain PlatformOrderSyncRecord.
+func (m *PlatformOrderSyncRecordModel) ToDomain() *integration.PlatformOrderSyncRecord {
+	return &integration.PlatformOrderSyncRecord{
+		ID:               m.ID,
+		TenantID:         m.TenantID,
+		PlatformCode:     m.PlatformCode,
+		PlatformOrderID:  m.PlatformOrderID,
+		LocalOrderID:     m.LocalOrderID,
+		LocalOrderNumber: m.LocalOrderNumber,
+		Direction:        m.Direction,
+		Status:           m.Status,
+		PlatformStatus:   m.PlatformStatus,
+		ErrorMessage:     m.ErrorMessage,
+		SyncedAt:         m.SyncedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// PlatformOrderSyncRecordModelFromDomain creates a new persistence model from a domain PlatformOrderSyncRecord.
+func PlatformOrderSyncRecordModelFromDomain(r *integration.PlatformOrderSyncRecord) *PlatformOrderSyncRecordModel {
+	return &PlatformOrderSyncRecordModel{
+		ID:               r.ID,
+		TenantID:         r.TenantID,
+		PlatformCode:     r.PlatformCode,
+		PlatformOrderID:  r.PlatformOrderID,
+		LocalOrderID:     r.LocalOrderID,
+		LocalOrderNumber: r.LocalOrderNumber,
+		Direction:        r.Direction,
+		Status:           r.Status,
+		PlatformStatus:   r.PlatformStatus,
+		ErrorMessage:     r.ErrorMessage,
+		SyncedAt:         r.SyncedAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+// OrderSyncConfigModel is the persistence model for the OrderSyncConfig domain type.
+type OrderSyncConfigModel struct {
+	TenantID             uuid.UUID                `gorm:"type:uuid;primary_key"`
+	PlatformCode         integration.PlatformCode `gorm:"type:varchar(20);primary_key"`
+	IsEnabled            bool                     `gorm:"not null;default:false"`
+	SyncIntervalMinutes  int                      `gorm:"not null;default:15"`
+	AutoCreateCustomer   bool                     `gorm:"not null;default:false"`
+	DefaultWarehouseID   *uuid.UUID               `gorm:"type:uuid"`
+	DefaultSalespersonID *uuid.UUID               `gorm:"type:uuid"`
+	DefaultCustomerID    *uuid.UUID               `gorm:"type:uuid"`
+	DefaultCustomerName  string                   `gorm:"type:varchar(200)"`
+	AutoLockStock        bool                     `gorm:"not null;default:false"`
+	OrderPrefixFormat    string                   `gorm:"type:varchar(50)"`
+	StatusMappingsJSON   string                   `gorm:"type:jsonb;column:status_mappings"`
+	OrderCursor          *time.Time
+	CreatedAt            time.Time `gorm:"not null"`
+	UpdatedAt            time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for GORM
+func (OrderSyncConfigModel) TableName() string {
+	return "order_sync_configs"
+}
+
+// ToDomain converts the persistence model to a domain OrderSyncConfig.
+func (m *OrderSyncConfigModel) ToDomain() *integration.OrderSyncConfig {
+	config := &integration.OrderSyncConfig{
+		TenantID:             m.TenantID,
+		PlatformCode:         m.PlatformCode,
+		IsEnabled:            m.IsEnabled,
+		SyncIntervalMinutes:  m.SyncIntervalMinutes,
+		AutoCreateCustomer:   m.AutoCreateCustomer,
+		DefaultSalespersonID: m.DefaultSalespersonID,
+		DefaultCustomerName:  m.DefaultCustomerName,
+		AutoLockStock:        m.AutoLockStock,
+		OrderPrefixFormat:    m.OrderPrefixFormat,
+		StatusMappings:       make(map[integration.PlatformOrderStatus]string),
+		OrderCursor:          m.OrderCursor,
+	}
+	if m.DefaultWarehouseID != nil {
+		config.DefaultWarehouseID = *m.DefaultWarehouseID
+	}
+	if m.DefaultCustomerID != nil {
+		config.DefaultCustomerID = *m.DefaultCustomerID
+	}
+	if m.StatusMappingsJSON != "" {
+		_ = json.Unmarshal([]byte(m.StatusMappingsJSON), &config.StatusMappings)
+	}
+	return config
+}
+
+// OrderSyncConfigModelFromDomain creates a new persistence model from a domain OrderSyncConfig.
+func OrderSyncConfigModelFromDomain(c *integration.OrderSyncConfig) *OrderSyncConfigModel {
+	m := &OrderSyncConfigModel{
+		TenantID:             c.TenantID,
+		PlatformCode:         c.PlatformCode,
+		IsEnabled:            c.IsEnabled,
+		SyncIntervalMinutes:  c.SyncIntervalMinutes,
+		AutoCreateCustomer:   c.AutoCreateCustomer,
+		DefaultSalespersonID: c.DefaultSalespersonID,
+		DefaultCustomerName:  c.DefaultCustomerName,
+		AutoLockStock:        c.AutoLockStock,
+		OrderPrefixFormat:    c.OrderPrefixFormat,
+		StatusMappingsJSON:   "{}",
+		OrderCursor:          c.OrderCursor,
+	}
+	if c.DefaultWarehouseID != uuid.Nil {
+		m.DefaultWarehouseID = &c.DefaultWarehouseID
+	}
+	if c.DefaultCustomerID != uuid.Nil {
+		m.DefaultCustomerID = &c.DefaultCustomerID
+	}
+	if len(c.StatusMappings) > 0 {
+		if jsonBytes, err := json.Marshal(c.StatusMappings); err == nil {
+			m.StatusMappingsJSON = string(jsonBytes)
+		}
+	}
+	return m
+}
+
+// OrderStatusPushModel is the persistence model for the OrderStatusPush domain type.
+type OrderStatusPushModel struct {
+	ID              uuid.UUID                        `gorm:"type:uuid;primary_key"`
+	TenantID        uuid.UUID                        `gorm:"type:uuid;not null;index"`
+	PlatformCode    integration.PlatformCode         `gorm:"type:varchar(20);not null"`
+	PlatformOrderID string                           `gorm:"type:varchar(100);not null"`
+	LocalOrderID    uuid.UUID                        `gorm:"type:uuid;not null"`
+	Status          integration.PlatformOrderStatus  `gorm:"type:varchar(20);not null"`
+	ShippingCompany string                           `gorm:"type:varchar(100)"`
+	TrackingNumber  string                           `gorm:"type:varchar(100)"`
+	State           integration.OrderStatusPushState `gorm:"type:varchar(20);not null;index:idx_order_status_pushes_retry,priority:1"`
+	RetryCount      int                              `gorm:"not null;default:0"`
+	MaxRetries      int                              `gorm:"not null;default:5"`
+	LastError       string                           `gorm:"type:text"`
+	NextRetryAt     *time.Time                       `gorm:"index:idx_order_status_pushes_retry,priority:2"`
+	SentAt          *time.Time
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for GORM
+func (OrderStatusPushModel) TableName() string {
+	return "order_status_pushes"
+}
+
+// ToDomain converts the persistence model to a domain OrderStatusPush.
+func (m *OrderStatusPushModel) ToDomain() *integration.OrderStatusPush {
+	return &integration.OrderStatusPush{
+		ID:              m.ID,
+		TenantID:        m.TenantID,
+		PlatformCode:    m.PlatformCode,
+		PlatformOrderID: m.PlatformOrderID,
+		LocalOrderID:    m.LocalOrderID,
+		Status:          m.Status,
+		ShippingCompany: m.ShippingCompany,
+		TrackingNumber:  m.TrackingNumber,
+		State:           m.State,
+		RetryCount:      m.RetryCount,
+		MaxRetries:      m.MaxRetries,
+		LastError:       m.LastError,
+		NextRetryAt:     m.NextRetryAt,
+		SentAt:          m.SentAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// OrderStatusPushModelFromDomain creates a new persistence model from a domain OrderStatusPush.
+func OrderStatusPushModelFromDomain(p *integration.OrderStatusPush) *OrderStatusPushModel {
+	return &OrderStatusPushModel{
+		ID:              p.ID,
+		TenantID:        p.TenantID,
+		PlatformCode:    p.PlatformCode,
+		PlatformOrderID: p.PlatformOrderID,
+		LocalOrderID:    p.LocalOrderID,
+		Status:          p.Status,
+		ShippingCompany: p.ShippingCompany,
+		TrackingNumber:  p.TrackingNumber,
+		State:           p.State,
+		RetryCount:      p.RetryCount,
+		MaxRetries:      p.MaxRetries,
+		LastError:       p.LastError,
+		NextRetryAt:     p.NextRetryAt,
+		SentAt:          p.SentAt,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
@@ -46,7 +46,7 @@ func (m *InventoryItemModel) ToDomain() *inventory.InventoryItem {
 		},
 		WarehouseID:       m.WarehouseID,
 		ProductID:         m.ProductID,
-		AvailableQuantity: inventory.MustNewInventoryQuantity(m.AvailableQuantity),
+		AvailableQuantity: inventory.NewInventoryBalance(m.AvailableQuantity),
 		LockedQuantity:    inventory.MustNewInventoryQuantity(m.LockedQuantity),
 		UnitCost:          m.UnitCost,
 		MinQuantity:       inventory.MustNewInventoryQuantity(m.MinQuantity),
@@ -470,3 +470,92 @@ func StockTakingItemModelFromDomain(i *inventory.StockTakingItem) *StockTakingIt
 	m.FromDomain(i)
 	return m
 }
+
+// SerialNumberModel is the persistence model for the SerialNumber entity.
+type SerialNumberModel struct {
+	BaseModel
+	TenantID        uuid.UUID                    `gorm:"type:uuid;not null;uniqueIndex:idx_serial_number_product,priority:1"`
+	InventoryItemID uuid.UUID                    `gorm:"type:uuid;not null;index"`
+	WarehouseID     uuid.UUID                    `gorm:"type:uuid;not null"`
+	ProductID       uuid.UUID                    `gorm:"type:uuid;not null;uniqueIndex:idx_serial_number_product,priority:2"`
+	SerialNumber    string                       `gorm:"type:varchar(100);not null;uniqueIndex:idx_serial_number_product,priority:3"`
+	Status          inventory.SerialNumberStatus `gorm:"type:varchar(20);not null"`
+}
+
+// TableName returns the table name for GORM
+func (SerialNumberModel) TableName() string {
+	return "serial_numbers"
+}
+
+// ToDomain converts the persistence model to a domain SerialNumber entity.
+func (m *SerialNumberModel) ToDomain() *inventory.SerialNumber {
+	return &inventory.SerialNumber{
+		BaseEntity:      m.BaseModel.ToDomain(),
+		TenantID:        m.TenantID,
+		InventoryItemID: m.InventoryItemID,
+		WarehouseID:     m.WarehouseID,
+		ProductID:       m.ProductID,
+		SerialNumber:    m.SerialNumber,
+		Status:          m.Status,
+	}
+}
+
+// SerialNumberModelFromDomain creates a new persistence model from a domain SerialNumber entity.
+func SerialNumberModelFromDomain(s *inventory.SerialNumber) *SerialNumberModel {
+	m := &SerialNumberModel{
+		TenantID:        s.TenantID,
+		InventoryItemID: s.InventoryItemID,
+		WarehouseID:     s.WarehouseID,
+		ProductID:       s.ProductID,
+		SerialNumber:    s.SerialNumber,
+		Status:          s.Status,
+	}
+	m.FromDomainBaseEntity(s.BaseEntity)
+	return m
+}
+
+// SerialNumberMovementModel is the persistence model for the SerialNumberMovement entity.
+type SerialNumberMovementModel struct {
+	BaseModel
+	SerialNumberID  uuid.UUID                    `gorm:"type:uuid;not null;index"`
+	InventoryItemID uuid.UUID                    `gorm:"type:uuid;not null"`
+	WarehouseID     uuid.UUID                    `gorm:"type:uuid;not null"`
+	MovementType    inventory.SerialMovementType `gorm:"type:varchar(20);not null"`
+	SourceType      inventory.SourceType         `gorm:"type:varchar(30);not null"`
+	SourceID        string                       `gorm:"type:varchar(50);not null"`
+	OccurredAt      time.Time                    `gorm:"type:timestamptz;not null"`
+}
+
+// TableName returns the table name for GORM
+func (SerialNumberMovementModel) TableName() string {
+	return "serial_number_movements"
+}
+
+// ToDomain converts the persistence model to a domain SerialNumberMovement entity.
+func (m *SerialNumberMovementModel) ToDomain() *inventory.SerialNumberMovement {
+	return &inventory.SerialNumberMovement{
+		BaseEntity:      m.BaseModel.ToDomain(),
+		SerialNumberID:  m.SerialNumberID,
+		InventoryItemID: m.InventoryItemID,
+		WarehouseID:     m.WarehouseID,
+		MovementType:    m.MovementType,
+		SourceType:      m.SourceType,
+		SourceID:        m.SourceID,
+		OccurredAt:      m.OccurredAt,
+	}
+}
+
+// SerialNumberMovementModelFromDomain creates a new persistence model from a domain SerialNumberMovement entity.
+func SerialNumberMovementModelFromDomain(mv *inventory.SerialNumberMovement) *SerialNumberMovementModel {
+	m := &SerialNumberMovementModel{
+		SerialNumberID:  mv.SerialNumberID,
+		InventoryItemID: mv.InventoryItemID,
+		WarehouseID:     mv.WarehouseID,
+		MovementType:    mv.MovementType,
+		SourceType:      mv.SourceType,
+		SourceID:        mv.SourceID,
+		OccurredAt:      mv.OccurredAt,
+	}
+	m.FromDomainBaseEntity(mv.BaseEntity)
+	return m
+}
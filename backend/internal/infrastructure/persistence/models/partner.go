@@ -7,31 +7,37 @@ import (
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 )
 
 // CustomerModel is the persistence model for the Customer domain entity.
 type CustomerModel struct {
 	TenantAggregateModel
-	Code        string                 `gorm:"type:varchar(50);not null;uniqueIndex:idx_customer_tenant_code,priority:2"`
-	Name        string                 `gorm:"type:varchar(200);not null"`
-	ShortName   string                 `gorm:"type:varchar(100)"`
-	Type        partner.CustomerType   `gorm:"type:varchar(20);not null;default:'individual'"`
-	Level       partner.CustomerLevel  `gorm:"type:varchar(20);not null;default:'normal'"`
-	Status      partner.CustomerStatus `gorm:"type:varchar(20);not null;default:'active'"`
-	ContactName string                 `gorm:"type:varchar(100)"`
-	Phone       string                 `gorm:"type:varchar(50);index"`
-	Email       string                 `gorm:"type:varchar(200);index"`
-	Address     string                 `gorm:"type:text"`
-	City        string                 `gorm:"type:varchar(100)"`
-	Province    string                 `gorm:"type:varchar(100)"`
-	PostalCode  string                 `gorm:"type:varchar(20)"`
-	Country     string                 `gorm:"type:varchar(100);default:'中国'"`
-	TaxID       string                 `gorm:"type:varchar(50)"`
-	CreditLimit decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
-	Balance     decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
-	Notes       string                 `gorm:"type:text"`
-	SortOrder   int                    `gorm:"not null;default:0"`
-	Attributes  string                 `gorm:"type:jsonb"`
+	Code             string                `gorm:"type:varchar(50);not null;uniqueIndex:idx_customer_tenant_code,priority:2,where:deleted_at IS NULL"`
+	Name             string                `gorm:"type:varchar(200);not null"`
+	ShortName        string                `gorm:"type:varchar(100)"`
+	Type             partner.CustomerType  `gorm:"type:varchar(20);not null;default:'individual'"`
+	Level            partner.CustomerLevel `gorm:"type:varchar(20);not null;default:'normal'"`
+	LevelPeriodStart *time.Time
+	Status           partner.CustomerStatus `gorm:"type:varchar(20);not null;default:'active'"`
+	ContactName      string                 `gorm:"type:varchar(100)"`
+	Phone            string                 `gorm:"type:varchar(50);index"`
+	Email            string                 `gorm:"type:varchar(200);index"`
+	Address          string                 `gorm:"type:text"`
+	City             string                 `gorm:"type:varchar(100)"`
+	Province         string                 `gorm:"type:varchar(100)"`
+	PostalCode       string                 `gorm:"type:varchar(20)"`
+	Country          string                 `gorm:"type:varchar(100);default:'中国'"`
+	TaxID            string                 `gorm:"type:varchar(50)"`
+	CreditLimit      decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
+	Balance          decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
+	OverdraftLimit   decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
+	Notes            string                 `gorm:"type:text"`
+	SortOrder        int                    `gorm:"not null;default:0"`
+	Attributes       string                 `gorm:"type:jsonb"`
+	MergedIntoID     *uuid.UUID             `gorm:"type:uuid"`
+	MergedAt         *time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName returns the table name for GORM
@@ -54,26 +60,31 @@ func (m *CustomerModel) ToDomain() *partner.Customer {
 			TenantID:  m.TenantID,
 			CreatedBy: m.CreatedBy,
 		},
-		Code:        m.Code,
-		Name:        m.Name,
-		ShortName:   m.ShortName,
-		Type:        m.Type,
-		Level:       m.Level,
-		Status:      m.Status,
-		ContactName: m.ContactName,
-		Phone:       m.Phone,
-		Email:       m.Email,
-		Address:     m.Address,
-		City:        m.City,
-		Province:    m.Province,
-		PostalCode:  m.PostalCode,
-		Country:     m.Country,
-		TaxID:       m.TaxID,
-		CreditLimit: m.CreditLimit,
-		Balance:     m.Balance,
-		Notes:       m.Notes,
-		SortOrder:   m.SortOrder,
-		Attributes:  m.Attributes,
+		Code:             m.Code,
+		Name:             m.Name,
+		ShortName:        m.ShortName,
+		Type:             m.Type,
+		Level:            m.Level,
+		LevelPeriodStart: m.LevelPeriodStart,
+		Status:           m.Status,
+		ContactName:      m.ContactName,
+		Phone:            m.Phone,
+		Email:            m.Email,
+		Address:          m.Address,
+		City:             m.City,
+		Province:         m.Province,
+		PostalCode:       m.PostalCode,
+		Country:          m.Country,
+		TaxID:            m.TaxID,
+		CreditLimit:      m.CreditLimit,
+		Balance:          m.Balance,
+		OverdraftLimit:   m.OverdraftLimit,
+		Notes:            m.Notes,
+		SortOrder:        m.SortOrder,
+		Attributes:       m.Attributes,
+		MergedIntoID:     m.MergedIntoID,
+		MergedAt:         m.MergedAt,
+		DeletedAt:        deletedAtToDomain(m.DeletedAt),
 	}
 }
 
@@ -85,6 +96,7 @@ func (m *CustomerModel) FromDomain(c *partner.Customer) {
 	m.ShortName = c.ShortName
 	m.Type = c.Type
 	m.Level = c.Level
+	m.LevelPeriodStart = c.LevelPeriodStart
 	m.Status = c.Status
 	m.ContactName = c.ContactName
 	m.Phone = c.Phone
@@ -97,9 +109,13 @@ func (m *CustomerModel) FromDomain(c *partner.Customer) {
 	m.TaxID = c.TaxID
 	m.CreditLimit = c.CreditLimit
 	m.Balance = c.Balance
+	m.OverdraftLimit = c.OverdraftLimit
 	m.Notes = c.Notes
 	m.SortOrder = c.SortOrder
 	m.Attributes = c.Attributes
+	m.MergedIntoID = c.MergedIntoID
+	m.MergedAt = c.MergedAt
+	m.DeletedAt = deletedAtFromDomain(c.DeletedAt)
 }
 
 // CustomerModelFromDomain creates a new persistence model from a domain Customer entity.
@@ -112,7 +128,7 @@ func CustomerModelFromDomain(c *partner.Customer) *CustomerModel {
 // SupplierModel is the persistence model for the Supplier domain entity.
 type SupplierModel struct {
 	TenantAggregateModel
-	Code        string                 `gorm:"type:varchar(50);not null;uniqueIndex:idx_supplier_tenant_code,priority:2"`
+	Code        string                 `gorm:"type:varchar(50);not null;uniqueIndex:idx_supplier_tenant_code,priority:2,where:deleted_at IS NULL"`
 	Name        string                 `gorm:"type:varchar(200);not null"`
 	ShortName   string                 `gorm:"type:varchar(100)"`
 	Type        partner.SupplierType   `gorm:"type:varchar(20);not null;default:'distributor'"`
@@ -135,6 +151,7 @@ type SupplierModel struct {
 	Notes       string                 `gorm:"type:text"`
 	SortOrder   int                    `gorm:"not null;default:0"`
 	Attributes  string                 `gorm:"type:jsonb"`
+	DeletedAt   gorm.DeletedAt         `gorm:"index"`
 }
 
 // TableName returns the table name for GORM
@@ -180,6 +197,7 @@ func (m *SupplierModel) ToDomain() *partner.Supplier {
 		Notes:       m.Notes,
 		SortOrder:   m.SortOrder,
 		Attributes:  m.Attributes,
+		DeletedAt:   deletedAtToDomain(m.DeletedAt),
 	}
 }
 
@@ -209,6 +227,7 @@ func (m *SupplierModel) FromDomain(s *partner.Supplier) {
 	m.Notes = s.Notes
 	m.SortOrder = s.SortOrder
 	m.Attributes = s.Attributes
+	m.DeletedAt = deletedAtFromDomain(s.DeletedAt)
 }
 
 // SupplierModelFromDomain creates a new persistence model from a domain Supplier entity.
@@ -221,24 +240,26 @@ func SupplierModelFromDomain(s *partner.Supplier) *SupplierModel {
 // WarehouseModel is the persistence model for the Warehouse domain entity.
 type WarehouseModel struct {
 	TenantAggregateModel
-	Code        string                  `gorm:"type:varchar(50);not null;uniqueIndex:idx_warehouse_tenant_code,priority:2"`
-	Name        string                  `gorm:"type:varchar(200);not null"`
-	ShortName   string                  `gorm:"type:varchar(100)"`
-	Type        partner.WarehouseType   `gorm:"type:varchar(20);not null;default:'physical'"`
-	Status      partner.WarehouseStatus `gorm:"type:varchar(20);not null;default:'active'"`
-	ContactName string                  `gorm:"type:varchar(100)"`
-	Phone       string                  `gorm:"type:varchar(50);index"`
-	Email       string                  `gorm:"type:varchar(200)"`
-	Address     string                  `gorm:"type:text"`
-	City        string                  `gorm:"type:varchar(100)"`
-	Province    string                  `gorm:"type:varchar(100)"`
-	PostalCode  string                  `gorm:"type:varchar(20)"`
-	Country     string                  `gorm:"type:varchar(100);default:'中国'"`
-	IsDefault   bool                    `gorm:"not null;default:false"`
-	Capacity    int                     `gorm:"not null;default:0"`
-	Notes       string                  `gorm:"type:text"`
-	SortOrder   int                     `gorm:"not null;default:0"`
-	Attributes  string                  `gorm:"type:jsonb"`
+	Code               string                  `gorm:"type:varchar(50);not null;uniqueIndex:idx_warehouse_tenant_code,priority:2"`
+	Name               string                  `gorm:"type:varchar(200);not null"`
+	ShortName          string                  `gorm:"type:varchar(100)"`
+	Type               partner.WarehouseType   `gorm:"type:varchar(20);not null;default:'physical'"`
+	Status             partner.WarehouseStatus `gorm:"type:varchar(20);not null;default:'active'"`
+	ContactName        string                  `gorm:"type:varchar(100)"`
+	Phone              string                  `gorm:"type:varchar(50);index"`
+	Email              string                  `gorm:"type:varchar(200)"`
+	Address            string                  `gorm:"type:text"`
+	City               string                  `gorm:"type:varchar(100)"`
+	Province           string                  `gorm:"type:varchar(100)"`
+	PostalCode         string                  `gorm:"type:varchar(20)"`
+	Country            string                  `gorm:"type:varchar(100);default:'中国'"`
+	IsDefault          bool                    `gorm:"not null;default:false"`
+	Capacity           int                     `gorm:"not null;default:0"`
+	CapacityUnit       partner.CapacityUnit    `gorm:"type:varchar(10);not null;default:'unit'"`
+	AllowNegativeStock bool                    `gorm:"not null;default:false"`
+	Notes              string                  `gorm:"type:text"`
+	SortOrder          int                     `gorm:"not null;default:0"`
+	Attributes         string                  `gorm:"type:jsonb"`
 }
 
 // TableName returns the table name for GORM
@@ -261,24 +282,26 @@ func (m *WarehouseModel) ToDomain() *partner.Warehouse {
 			TenantID:  m.TenantID,
 			CreatedBy: m.CreatedBy,
 		},
-		Code:        m.Code,
-		Name:        m.Name,
-		ShortName:   m.ShortName,
-		Type:        m.Type,
-		Status:      m.Status,
-		ContactName: m.ContactName,
-		Phone:       m.Phone,
-		Email:       m.Email,
-		Address:     m.Address,
-		City:        m.City,
-		Province:    m.Province,
-		PostalCode:  m.PostalCode,
-		Country:     m.Country,
-		IsDefault:   m.IsDefault,
-		Capacity:    m.Capacity,
-		Notes:       m.Notes,
-		SortOrder:   m.SortOrder,
-		Attributes:  m.Attributes,
+		Code:               m.Code,
+		Name:               m.Name,
+		ShortName:          m.ShortName,
+		Type:               m.Type,
+		Status:             m.Status,
+		ContactName:        m.ContactName,
+		Phone:              m.Phone,
+		Email:              m.Email,
+		Address:            m.Address,
+		City:               m.City,
+		Province:           m.Province,
+		PostalCode:         m.PostalCode,
+		Country:            m.Country,
+		IsDefault:          m.IsDefault,
+		Capacity:           m.Capacity,
+		CapacityUnit:       m.CapacityUnit,
+		AllowNegativeStock: m.AllowNegativeStock,
+		Notes:              m.Notes,
+		SortOrder:          m.SortOrder,
+		Attributes:         m.Attributes,
 	}
 }
 
@@ -300,6 +323,8 @@ func (m *WarehouseModel) FromDomain(w *partner.Warehouse) {
 	m.Country = w.Country
 	m.IsDefault = w.IsDefault
 	m.Capacity = w.Capacity
+	m.CapacityUnit = w.CapacityUnit
+	m.AllowNegativeStock = w.AllowNegativeStock
 	m.Notes = w.Notes
 	m.SortOrder = w.SortOrder
 	m.Attributes = w.Attributes
@@ -315,17 +340,19 @@ func WarehouseModelFromDomain(w *partner.Warehouse) *WarehouseModel {
 // CustomerLevelRecordModel is the persistence model for the CustomerLevelRecord.
 // This is for storing customer level definitions in the database.
 type CustomerLevelRecordModel struct {
-	ID           uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	TenantID     uuid.UUID       `gorm:"type:uuid;not null;index"`
-	Code         string          `gorm:"type:varchar(50);not null"`
-	Name         string          `gorm:"type:varchar(100);not null"`
-	DiscountRate decimal.Decimal `gorm:"type:decimal(5,4);not null;default:0"`
-	SortOrder    int             `gorm:"not null;default:0"`
-	IsDefault    bool            `gorm:"not null;default:false"`
-	IsActive     bool            `gorm:"not null;default:true"`
-	Description  string          `gorm:"type:text"`
-	CreatedAt    time.Time       `gorm:"not null;default:CURRENT_TIMESTAMP"`
-	UpdatedAt    time.Time       `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	ID               uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantID         uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Code             string          `gorm:"type:varchar(50);not null"`
+	Name             string          `gorm:"type:varchar(100);not null"`
+	DiscountRate     decimal.Decimal `gorm:"type:decimal(5,4);not null;default:0"`
+	SortOrder        int             `gorm:"not null;default:0"`
+	IsDefault        bool            `gorm:"not null;default:false"`
+	IsActive         bool            `gorm:"not null;default:true"`
+	Description      string          `gorm:"type:text"`
+	UpgradeThreshold decimal.Decimal `gorm:"type:decimal(18,4);not null;default:0"`
+	SpendWindowDays  int             `gorm:"not null;default:365"`
+	CreatedAt        time.Time       `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt        time.Time       `gorm:"not null;default:CURRENT_TIMESTAMP"`
 }
 
 // TableName returns the table name for GORM
@@ -336,17 +363,19 @@ func (CustomerLevelRecordModel) TableName() string {
 // ToDomain converts the persistence model to a domain CustomerLevelRecord.
 func (m *CustomerLevelRecordModel) ToDomain() *partner.CustomerLevelRecord {
 	return &partner.CustomerLevelRecord{
-		ID:           m.ID,
-		TenantID:     m.TenantID,
-		Code:         m.Code,
-		Name:         m.Name,
-		DiscountRate: m.DiscountRate,
-		SortOrder:    m.SortOrder,
-		IsDefault:    m.IsDefault,
-		IsActive:     m.IsActive,
-		Description:  m.Description,
-		CreatedAt:    m.CreatedAt,
-		UpdatedAt:    m.UpdatedAt,
+		ID:               m.ID,
+		TenantID:         m.TenantID,
+		Code:             m.Code,
+		Name:             m.Name,
+		DiscountRate:     m.DiscountRate,
+		SortOrder:        m.SortOrder,
+		IsDefault:        m.IsDefault,
+		IsActive:         m.IsActive,
+		Description:      m.Description,
+		UpgradeThreshold: m.UpgradeThreshold,
+		SpendWindowDays:  m.SpendWindowDays,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
 	}
 }
 
@@ -361,6 +390,8 @@ func (m *CustomerLevelRecordModel) FromDomain(r *partner.CustomerLevelRecord) {
 	m.IsDefault = r.IsDefault
 	m.IsActive = r.IsActive
 	m.Description = r.Description
+	m.UpgradeThreshold = r.UpgradeThreshold
+	m.SpendWindowDays = r.SpendWindowDays
 	m.CreatedAt = r.CreatedAt
 	m.UpdatedAt = r.UpdatedAt
 }
@@ -372,6 +403,74 @@ func CustomerLevelRecordModelFromDomain(r *partner.CustomerLevelRecord) *Custome
 	return m
 }
 
+// PartnerAddressModel is the persistence model for the Address entity of a customer or supplier.
+type PartnerAddressModel struct {
+	BaseModel
+	TenantID    uuid.UUID                `gorm:"type:uuid;not null;index"`
+	OwnerType   partner.AddressOwnerType `gorm:"type:varchar(20);not null;index:idx_partner_address_owner,priority:1"`
+	OwnerID     uuid.UUID                `gorm:"type:uuid;not null;index:idx_partner_address_owner,priority:2"`
+	Type        partner.AddressType      `gorm:"type:varchar(20);not null"`
+	Label       string                   `gorm:"type:varchar(100)"`
+	ContactName string                   `gorm:"type:varchar(100)"`
+	Phone       string                   `gorm:"type:varchar(50)"`
+	Province    string                   `gorm:"type:varchar(100)"`
+	City        string                   `gorm:"type:varchar(100)"`
+	Detail      string                   `gorm:"type:varchar(500);not null"`
+	PostalCode  string                   `gorm:"type:varchar(20)"`
+	Country     string                   `gorm:"type:varchar(100);default:'中国'"`
+	IsDefault   bool                     `gorm:"not null;default:false"`
+}
+
+// TableName returns the table name for GORM
+func (PartnerAddressModel) TableName() string {
+	return "partner_addresses"
+}
+
+// ToDomain converts the persistence model to a domain Address entity.
+func (m *PartnerAddressModel) ToDomain() *partner.Address {
+	return &partner.Address{
+		BaseEntity:  m.BaseModel.ToDomain(),
+		TenantID:    m.TenantID,
+		OwnerType:   m.OwnerType,
+		OwnerID:     m.OwnerID,
+		Type:        m.Type,
+		Label:       m.Label,
+		ContactName: m.ContactName,
+		Phone:       m.Phone,
+		Province:    m.Province,
+		City:        m.City,
+		Detail:      m.Detail,
+		PostalCode:  m.PostalCode,
+		Country:     m.Country,
+		IsDefault:   m.IsDefault,
+	}
+}
+
+// FromDomain populates the persistence model from a domain Address entity.
+func (m *PartnerAddressModel) FromDomain(a *partner.Address) {
+	m.FromDomainBaseEntity(a.BaseEntity)
+	m.TenantID = a.TenantID
+	m.OwnerType = a.OwnerType
+	m.OwnerID = a.OwnerID
+	m.Type = a.Type
+	m.Label = a.Label
+	m.ContactName = a.ContactName
+	m.Phone = a.Phone
+	m.Province = a.Province
+	m.City = a.City
+	m.Detail = a.Detail
+	m.PostalCode = a.PostalCode
+	m.Country = a.Country
+	m.IsDefault = a.IsDefault
+}
+
+// PartnerAddressModelFromDomain creates a new persistence model from a domain Address entity.
+func PartnerAddressModelFromDomain(a *partner.Address) *PartnerAddressModel {
+	m := &PartnerAddressModel{}
+	m.FromDomain(a)
+	return m
+}
+
 // BalanceTransactionModel is the persistence model for the BalanceTransaction entity.
 type BalanceTransactionModel struct {
 	BaseModel
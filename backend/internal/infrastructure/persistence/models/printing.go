@@ -20,6 +20,7 @@ type PrintJobModel struct {
 	Copies         int        `gorm:"not null;default:1"`
 	PdfURL         string     `gorm:"column:pdf_url;type:text"`
 	ErrorMessage   string     `gorm:"column:error_message;type:text"`
+	ErrorDetail    string     `gorm:"column:error_detail;type:text"`
 	PrintedAt      *time.Time `gorm:"column:printed_at"`
 	PrintedBy      *uuid.UUID `gorm:"column:printed_by;type:uuid"`
 	CreatedAt      time.Time  `gorm:"not null"`
@@ -54,6 +55,7 @@ func (m *PrintJobModel) ToDomain() *printing.PrintJob {
 		Copies:         m.Copies,
 		PdfURL:         m.PdfURL,
 		ErrorMessage:   m.ErrorMessage,
+		ErrorDetail:    m.ErrorDetail,
 		PrintedAt:      m.PrintedAt,
 		PrintedBy:      m.PrintedBy,
 	}
@@ -72,6 +74,7 @@ func PrintJobModelFromDomain(j *printing.PrintJob) *PrintJobModel {
 		Copies:         j.Copies,
 		PdfURL:         j.PdfURL,
 		ErrorMessage:   j.ErrorMessage,
+		ErrorDetail:    j.ErrorDetail,
 		PrintedAt:      j.PrintedAt,
 		PrintedBy:      j.PrintedBy,
 		CreatedAt:      j.CreatedAt,
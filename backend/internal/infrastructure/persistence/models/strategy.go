@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+)
+
+// TenantStrategyConfigModel is the persistence model for the TenantStrategyConfig of a tenant.
+type TenantStrategyConfigModel struct {
+	TenantID               uuid.UUID `gorm:"type:uuid;primary_key"`
+	CostStrategy           string    `gorm:"type:varchar(50)"`
+	PricingStrategy        string    `gorm:"type:varchar(50)"`
+	AllocationStrategy     string    `gorm:"type:varchar(50)"`
+	ReconciliationStrategy string    `gorm:"type:varchar(50)"`
+	CreatedAt              time.Time `gorm:"not null"`
+	UpdatedAt              time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for GORM
+func (TenantStrategyConfigModel) TableName() string {
+	return "tenant_strategy_configs"
+}
+
+// ToDomain converts the persistence model to a domain TenantStrategyConfig.
+func (m *TenantStrategyConfigModel) ToDomain() *strategy.TenantStrategyConfig {
+	return &strategy.TenantStrategyConfig{
+		TenantID:               m.TenantID,
+		CostStrategy:           m.CostStrategy,
+		PricingStrategy:        m.PricingStrategy,
+		AllocationStrategy:     m.AllocationStrategy,
+		ReconciliationStrategy: m.ReconciliationStrategy,
+		CreatedAt:              m.CreatedAt,
+		UpdatedAt:              m.UpdatedAt,
+	}
+}
+
+// FromDomain populates the persistence model from a domain TenantStrategyConfig.
+func (m *TenantStrategyConfigModel) FromDomain(c *strategy.TenantStrategyConfig) {
+	m.TenantID = c.TenantID
+	m.CostStrategy = c.CostStrategy
+	m.PricingStrategy = c.PricingStrategy
+	m.AllocationStrategy = c.AllocationStrategy
+	m.ReconciliationStrategy = c.ReconciliationStrategy
+	m.CreatedAt = c.CreatedAt
+	m.UpdatedAt = c.UpdatedAt
+}
+
+// TenantStrategyConfigModelFromDomain creates a new persistence model from a domain TenantStrategyConfig.
+func TenantStrategyConfigModelFromDomain(c *strategy.TenantStrategyConfig) *TenantStrategyConfigModel {
+	m := &TenantStrategyConfigModel{}
+	m.FromDomain(c)
+	return m
+}
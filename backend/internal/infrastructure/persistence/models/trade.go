@@ -12,21 +12,35 @@ import (
 // SalesOrderModel is the persistence model for the SalesOrder aggregate root.
 type SalesOrderModel struct {
 	TenantAggregateModel
-	OrderNumber    string                `gorm:"type:varchar(50);not null;uniqueIndex:idx_sales_order_tenant_number,priority:2"`
-	CustomerID     uuid.UUID             `gorm:"type:uuid;not null;index"`
-	CustomerName   string                `gorm:"type:varchar(200);not null"`
-	WarehouseID    *uuid.UUID            `gorm:"type:uuid;index"`
-	Items          []SalesOrderItemModel `gorm:"foreignKey:OrderID;references:ID"`
-	TotalAmount    decimal.Decimal       `gorm:"type:decimal(18,4);not null;default:0"`
-	DiscountAmount decimal.Decimal       `gorm:"type:decimal(18,4);not null;default:0"`
-	PayableAmount  decimal.Decimal       `gorm:"type:decimal(18,4);not null;default:0"`
-	Status         trade.OrderStatus     `gorm:"type:varchar(20);not null;default:'DRAFT'"`
-	Remark         string                `gorm:"type:text"`
-	ConfirmedAt    *time.Time            `gorm:"index"`
-	ShippedAt      *time.Time            `gorm:"index"`
-	CompletedAt    *time.Time
-	CancelledAt    *time.Time
-	CancelReason   string `gorm:"type:varchar(500)"`
+	OrderNumber  string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_sales_order_tenant_number,priority:2"`
+	CustomerID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CustomerName string     `gorm:"type:varchar(200);not null"`
+	WarehouseID  *uuid.UUID `gorm:"type:uuid;index"`
+	// Shipping address from the customer's address book
+	ShippingAddressID *uuid.UUID            `gorm:"type:uuid"`
+	Items             []SalesOrderItemModel `gorm:"foreignKey:OrderID;references:ID"`
+	TotalAmount       decimal.Decimal       `gorm:"type:decimal(18,4);not null;default:0"`
+	DiscountAmount    decimal.Decimal       `gorm:"type:decimal(18,4);not null;default:0"`
+	PayableAmount     decimal.Decimal       `gorm:"type:decimal(18,4);not null;default:0"`
+	Status            trade.OrderStatus     `gorm:"type:varchar(20);not null;default:'DRAFT'"`
+	Remark            string                `gorm:"type:text"`
+	ConfirmedAt       *time.Time            `gorm:"index"`
+	ShippedAt         *time.Time            `gorm:"index"`
+	FullyShippedAt    *time.Time            `gorm:"index"`
+	ShipmentCount     int                   `gorm:"not null;default:0"`
+	FirstShippedAt    *time.Time
+	CompletedAt       *time.Time
+	CancelledAt       *time.Time
+	CancelReason      string `gorm:"type:varchar(500)"`
+	// Credit override approval
+	CreditOverrideApprovedBy *uuid.UUID `gorm:"type:uuid"`
+	CreditOverrideApprovedAt *time.Time
+	CreditOverrideReason     string `gorm:"type:varchar(500)"`
+	AllowExpiredStock        bool   `gorm:"not null;default:false"`
+	// Quote lifecycle
+	QuoteExpiresAt *time.Time `gorm:"index"`
+	ConvertedAt    *time.Time
+	ExpiredAt      *time.Time
 }
 
 // TableName returns the table name for GORM
@@ -59,11 +73,24 @@ func (m *SalesOrderModel) ToDomain() *trade.SalesOrder {
 		Status:         m.Status,
 		Remark:         m.Remark,
 		ConfirmedAt:    m.ConfirmedAt,
+		FirstShippedAt: m.FirstShippedAt,
 		ShippedAt:      m.ShippedAt,
+		FullyShippedAt: m.FullyShippedAt,
+		ShipmentCount:  m.ShipmentCount,
 		CompletedAt:    m.CompletedAt,
 		CancelledAt:    m.CancelledAt,
 		CancelReason:   m.CancelReason,
 		Items:          make([]trade.SalesOrderItem, len(m.Items)),
+
+		CreditOverrideApprovedBy: m.CreditOverrideApprovedBy,
+		CreditOverrideApprovedAt: m.CreditOverrideApprovedAt,
+		CreditOverrideReason:     m.CreditOverrideReason,
+		AllowExpiredStock:        m.AllowExpiredStock,
+		ShippingAddressID:        m.ShippingAddressID,
+
+		QuoteExpiresAt: m.QuoteExpiresAt,
+		ConvertedAt:    m.ConvertedAt,
+		ExpiredAt:      m.ExpiredAt,
 	}
 	for i, item := range m.Items {
 		order.Items[i] = *item.ToDomain()
@@ -78,16 +105,27 @@ func (m *SalesOrderModel) FromDomain(o *trade.SalesOrder) {
 	m.CustomerID = o.CustomerID
 	m.CustomerName = o.CustomerName
 	m.WarehouseID = o.WarehouseID
+	m.ShippingAddressID = o.ShippingAddressID
 	m.TotalAmount = o.TotalAmount
 	m.DiscountAmount = o.DiscountAmount
 	m.PayableAmount = o.PayableAmount
 	m.Status = o.Status
 	m.Remark = o.Remark
 	m.ConfirmedAt = o.ConfirmedAt
+	m.FirstShippedAt = o.FirstShippedAt
 	m.ShippedAt = o.ShippedAt
+	m.FullyShippedAt = o.FullyShippedAt
+	m.ShipmentCount = o.ShipmentCount
 	m.CompletedAt = o.CompletedAt
 	m.CancelledAt = o.CancelledAt
 	m.CancelReason = o.CancelReason
+	m.CreditOverrideApprovedBy = o.CreditOverrideApprovedBy
+	m.CreditOverrideApprovedAt = o.CreditOverrideApprovedAt
+	m.CreditOverrideReason = o.CreditOverrideReason
+	m.AllowExpiredStock = o.AllowExpiredStock
+	m.QuoteExpiresAt = o.QuoteExpiresAt
+	m.ConvertedAt = o.ConvertedAt
+	m.ExpiredAt = o.ExpiredAt
 	m.Items = make([]SalesOrderItemModel, len(o.Items))
 	for i, item := range o.Items {
 		m.Items[i] = *SalesOrderItemModelFromDomain(&item)
@@ -103,21 +141,26 @@ func SalesOrderModelFromDomain(o *trade.SalesOrder) *SalesOrderModel {
 
 // SalesOrderItemModel is the persistence model for the SalesOrderItem entity.
 type SalesOrderItemModel struct {
-	ID             uuid.UUID       `gorm:"type:uuid;primary_key"`
-	OrderID        uuid.UUID       `gorm:"type:uuid;not null;index"`
-	ProductID      uuid.UUID       `gorm:"type:uuid;not null"`
-	ProductName    string          `gorm:"type:varchar(200);not null"`
-	ProductCode    string          `gorm:"type:varchar(50);not null"`
-	Quantity       decimal.Decimal `gorm:"type:decimal(18,4);not null"`
-	UnitPrice      decimal.Decimal `gorm:"type:decimal(18,4);not null"`
-	Amount         decimal.Decimal `gorm:"type:decimal(18,4);not null"`
-	Unit           string          `gorm:"type:varchar(20);not null"`
-	ConversionRate decimal.Decimal `gorm:"type:decimal(18,6);not null;default:1"`
-	BaseQuantity   decimal.Decimal `gorm:"type:decimal(18,4);not null"`
-	BaseUnit       string          `gorm:"type:varchar(20);not null"`
-	Remark         string          `gorm:"type:varchar(500)"`
-	CreatedAt      time.Time       `gorm:"not null"`
-	UpdatedAt      time.Time       `gorm:"not null"`
+	ID              uuid.UUID              `gorm:"type:uuid;primary_key"`
+	OrderID         uuid.UUID              `gorm:"type:uuid;not null;index"`
+	ProductID       uuid.UUID              `gorm:"type:uuid;not null"`
+	ProductName     string                 `gorm:"type:varchar(200);not null"`
+	ProductCode     string                 `gorm:"type:varchar(50);not null"`
+	Quantity        decimal.Decimal        `gorm:"type:decimal(18,4);not null"`
+	ShippedQuantity decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
+	UnitPrice       decimal.Decimal        `gorm:"type:decimal(18,4);not null"`
+	ListPrice       decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
+	DiscountType    trade.LineDiscountType `gorm:"type:varchar(10);not null;default:''"`
+	DiscountValue   decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
+	DiscountAmount  decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
+	Amount          decimal.Decimal        `gorm:"type:decimal(18,4);not null"`
+	Unit            string                 `gorm:"type:varchar(20);not null"`
+	ConversionRate  decimal.Decimal        `gorm:"type:decimal(18,6);not null;default:1"`
+	BaseQuantity    decimal.Decimal        `gorm:"type:decimal(18,4);not null"`
+	BaseUnit        string                 `gorm:"type:varchar(20);not null"`
+	Remark          string                 `gorm:"type:varchar(500)"`
+	CreatedAt       time.Time              `gorm:"not null"`
+	UpdatedAt       time.Time              `gorm:"not null"`
 }
 
 // TableName returns the table name for GORM
@@ -128,21 +171,26 @@ func (SalesOrderItemModel) TableName() string {
 // ToDomain converts the persistence model to a domain SalesOrderItem entity.
 func (m *SalesOrderItemModel) ToDomain() *trade.SalesOrderItem {
 	return &trade.SalesOrderItem{
-		ID:             m.ID,
-		OrderID:        m.OrderID,
-		ProductID:      m.ProductID,
-		ProductName:    m.ProductName,
-		ProductCode:    m.ProductCode,
-		Quantity:       m.Quantity,
-		UnitPrice:      m.UnitPrice,
-		Amount:         m.Amount,
-		Unit:           m.Unit,
-		ConversionRate: m.ConversionRate,
-		BaseQuantity:   m.BaseQuantity,
-		BaseUnit:       m.BaseUnit,
-		Remark:         m.Remark,
-		CreatedAt:      m.CreatedAt,
-		UpdatedAt:      m.UpdatedAt,
+		ID:              m.ID,
+		OrderID:         m.OrderID,
+		ProductID:       m.ProductID,
+		ProductName:     m.ProductName,
+		ProductCode:     m.ProductCode,
+		Quantity:        m.Quantity,
+		ShippedQuantity: m.ShippedQuantity,
+		UnitPrice:       m.UnitPrice,
+		ListPrice:       m.ListPrice,
+		DiscountType:    m.DiscountType,
+		DiscountValue:   m.DiscountValue,
+		DiscountAmount:  m.DiscountAmount,
+		Amount:          m.Amount,
+		Unit:            m.Unit,
+		ConversionRate:  m.ConversionRate,
+		BaseQuantity:    m.BaseQuantity,
+		BaseUnit:        m.BaseUnit,
+		Remark:          m.Remark,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
 	}
 }
 
@@ -154,7 +202,12 @@ func (m *SalesOrderItemModel) FromDomain(i *trade.SalesOrderItem) {
 	m.ProductName = i.ProductName
 	m.ProductCode = i.ProductCode
 	m.Quantity = i.Quantity
+	m.ShippedQuantity = i.ShippedQuantity
 	m.UnitPrice = i.UnitPrice
+	m.ListPrice = i.ListPrice
+	m.DiscountType = i.DiscountType
+	m.DiscountValue = i.DiscountValue
+	m.DiscountAmount = i.DiscountAmount
 	m.Amount = i.Amount
 	m.Unit = i.Unit
 	m.ConversionRate = i.ConversionRate
@@ -175,20 +228,21 @@ func SalesOrderItemModelFromDomain(i *trade.SalesOrderItem) *SalesOrderItemModel
 // PurchaseOrderModel is the persistence model for the PurchaseOrder aggregate root.
 type PurchaseOrderModel struct {
 	TenantAggregateModel
-	OrderNumber    string                    `gorm:"type:varchar(50);not null;uniqueIndex:idx_purchase_order_tenant_number,priority:2"`
-	SupplierID     uuid.UUID                 `gorm:"type:uuid;not null;index"`
-	SupplierName   string                    `gorm:"type:varchar(200);not null"`
-	WarehouseID    *uuid.UUID                `gorm:"type:uuid;index"`
-	Items          []PurchaseOrderItemModel  `gorm:"foreignKey:OrderID;references:ID"`
-	TotalAmount    decimal.Decimal           `gorm:"type:decimal(18,4);not null;default:0"`
-	DiscountAmount decimal.Decimal           `gorm:"type:decimal(18,4);not null;default:0"`
-	PayableAmount  decimal.Decimal           `gorm:"type:decimal(18,4);not null;default:0"`
-	Status         trade.PurchaseOrderStatus `gorm:"type:varchar(20);not null;default:'DRAFT'"`
-	Remark         string                    `gorm:"type:text"`
-	ConfirmedAt    *time.Time                `gorm:"index"`
-	CompletedAt    *time.Time
-	CancelledAt    *time.Time
-	CancelReason   string `gorm:"type:varchar(500)"`
+	OrderNumber      string                    `gorm:"type:varchar(50);not null;uniqueIndex:idx_purchase_order_tenant_number,priority:2"`
+	SupplierID       uuid.UUID                 `gorm:"type:uuid;not null;index"`
+	SupplierName     string                    `gorm:"type:varchar(200);not null"`
+	WarehouseID      *uuid.UUID                `gorm:"type:uuid;index"`
+	Items            []PurchaseOrderItemModel  `gorm:"foreignKey:OrderID;references:ID"`
+	TotalAmount      decimal.Decimal           `gorm:"type:decimal(18,4);not null;default:0"`
+	DiscountAmount   decimal.Decimal           `gorm:"type:decimal(18,4);not null;default:0"`
+	PayableAmount    decimal.Decimal           `gorm:"type:decimal(18,4);not null;default:0"`
+	Status           trade.PurchaseOrderStatus `gorm:"type:varchar(20);not null;default:'DRAFT'"`
+	Remark           string                    `gorm:"type:text"`
+	ConfirmedAt      *time.Time                `gorm:"index"`
+	CompletedAt      *time.Time
+	CancelledAt      *time.Time
+	CancelReason     string                        `gorm:"type:varchar(500)"`
+	PendingAmendment *trade.PurchaseOrderAmendment `gorm:"type:jsonb"`
 }
 
 // TableName returns the table name for GORM
@@ -211,20 +265,21 @@ func (m *PurchaseOrderModel) ToDomain() *trade.PurchaseOrder {
 			TenantID:  m.TenantID,
 			CreatedBy: m.CreatedBy,
 		},
-		OrderNumber:    m.OrderNumber,
-		SupplierID:     m.SupplierID,
-		SupplierName:   m.SupplierName,
-		WarehouseID:    m.WarehouseID,
-		TotalAmount:    m.TotalAmount,
-		DiscountAmount: m.DiscountAmount,
-		PayableAmount:  m.PayableAmount,
-		Status:         m.Status,
-		Remark:         m.Remark,
-		ConfirmedAt:    m.ConfirmedAt,
-		CompletedAt:    m.CompletedAt,
-		CancelledAt:    m.CancelledAt,
-		CancelReason:   m.CancelReason,
-		Items:          make([]trade.PurchaseOrderItem, len(m.Items)),
+		OrderNumber:      m.OrderNumber,
+		SupplierID:       m.SupplierID,
+		SupplierName:     m.SupplierName,
+		WarehouseID:      m.WarehouseID,
+		TotalAmount:      m.TotalAmount,
+		DiscountAmount:   m.DiscountAmount,
+		PayableAmount:    m.PayableAmount,
+		Status:           m.Status,
+		Remark:           m.Remark,
+		ConfirmedAt:      m.ConfirmedAt,
+		CompletedAt:      m.CompletedAt,
+		CancelledAt:      m.CancelledAt,
+		CancelReason:     m.CancelReason,
+		PendingAmendment: m.PendingAmendment,
+		Items:            make([]trade.PurchaseOrderItem, len(m.Items)),
 	}
 	for i, item := range m.Items {
 		order.Items[i] = *item.ToDomain()
@@ -248,6 +303,7 @@ func (m *PurchaseOrderModel) FromDomain(o *trade.PurchaseOrder) {
 	m.CompletedAt = o.CompletedAt
 	m.CancelledAt = o.CancelledAt
 	m.CancelReason = o.CancelReason
+	m.PendingAmendment = o.PendingAmendment
 	m.Items = make([]PurchaseOrderItemModel, len(o.Items))
 	for i, item := range o.Items {
 		m.Items[i] = *PurchaseOrderItemModelFromDomain(&item)
@@ -347,6 +403,7 @@ type SalesReturnModel struct {
 	Items            []SalesReturnItemModel `gorm:"foreignKey:ReturnID;references:ID"`
 	TotalRefund      decimal.Decimal        `gorm:"type:decimal(18,4);not null;default:0"`
 	Status           trade.ReturnStatus     `gorm:"type:varchar(20);not null;default:'DRAFT'"`
+	ReasonCode       trade.ReturnReasonCode `gorm:"type:varchar(30);not null;default:'OTHER'"`
 	Reason           string                 `gorm:"type:text"`
 	Remark           string                 `gorm:"type:text"`
 	SubmittedAt      *time.Time             `gorm:"index"`
@@ -390,6 +447,7 @@ func (m *SalesReturnModel) ToDomain() *trade.SalesReturn {
 		WarehouseID:      m.WarehouseID,
 		TotalRefund:      m.TotalRefund,
 		Status:           m.Status,
+		ReasonCode:       m.ReasonCode,
 		Reason:           m.Reason,
 		Remark:           m.Remark,
 		SubmittedAt:      m.SubmittedAt,
@@ -422,6 +480,7 @@ func (m *SalesReturnModel) FromDomain(sr *trade.SalesReturn) {
 	m.WarehouseID = sr.WarehouseID
 	m.TotalRefund = sr.TotalRefund
 	m.Status = sr.Status
+	m.ReasonCode = sr.ReasonCode
 	m.Reason = sr.Reason
 	m.Remark = sr.Remark
 	m.SubmittedAt = sr.SubmittedAt
@@ -540,6 +599,7 @@ type PurchaseReturnModel struct {
 	Items               []PurchaseReturnItemModel  `gorm:"foreignKey:ReturnID;references:ID"`
 	TotalRefund         decimal.Decimal            `gorm:"type:decimal(18,4);not null;default:0"`
 	Status              trade.PurchaseReturnStatus `gorm:"type:varchar(20);not null;default:'DRAFT'"`
+	ReasonCode          trade.ReturnReasonCode     `gorm:"type:varchar(30);not null;default:'OTHER'"`
 	Reason              string                     `gorm:"type:text"`
 	Remark              string                     `gorm:"type:text"`
 	SubmittedAt         *time.Time                 `gorm:"index"`
@@ -586,6 +646,7 @@ func (m *PurchaseReturnModel) ToDomain() *trade.PurchaseReturn {
 		WarehouseID:         m.WarehouseID,
 		TotalRefund:         m.TotalRefund,
 		Status:              m.Status,
+		ReasonCode:          m.ReasonCode,
 		Reason:              m.Reason,
 		Remark:              m.Remark,
 		SubmittedAt:         m.SubmittedAt,
@@ -621,6 +682,7 @@ func (m *PurchaseReturnModel) FromDomain(pr *trade.PurchaseReturn) {
 	m.WarehouseID = pr.WarehouseID
 	m.TotalRefund = pr.TotalRefund
 	m.Status = pr.Status
+	m.ReasonCode = pr.ReasonCode
 	m.Reason = pr.Reason
 	m.Remark = pr.Remark
 	m.SubmittedAt = pr.SubmittedAt
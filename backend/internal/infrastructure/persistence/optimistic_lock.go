@@ -0,0 +1,25 @@
+package persistence
+
+import (
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// versionConflict builds the error for a version-checked update that matched no row.
+// It reads the version now stored so the client can refetch and retry, and reports
+// ErrNotFound when the row is gone altogether.
+func versionConflict(db *gorm.DB, model any, id uuid.UUID, resource string) error {
+	var currentVersion int
+	result := db.Model(model).
+		Where("id = ?", id).
+		Select("version").
+		Scan(&currentVersion)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrNotFound
+	}
+	return shared.NewVersionConflictError(resource, currentVersion)
+}
@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"gorm.io/gorm"
+)
+
+// GormOrderStatusPushRepository implements OrderStatusPushRepository using GORM
+type GormOrderStatusPushRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOrderStatusPushRepository creates a new GormOrderStatusPushRepository
+func NewGormOrderStatusPushRepository(db *gorm.DB) *GormOrderStatusPushRepository {
+	return &GormOrderStatusPushRepository{db: db}
+}
+
+// Save creates or updates a push
+func (r *GormOrderStatusPushRepository) Save(ctx context.Context, push *integration.OrderStatusPush) error {
+	model := models.OrderStatusPushModelFromDomain(push)
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+// FindRetryable finds failed pushes whose next retry is due before the given time, oldest first
+func (r *GormOrderStatusPushRepository) FindRetryable(ctx context.Context, before time.Time, limit int) ([]*integration.OrderStatusPush, error) {
+	var pushModels []models.OrderStatusPushModel
+	if err := r.db.WithContext(ctx).
+		Where("state = ? AND retry_count < max_retries AND next_retry_at <= ?", integration.OrderStatusPushStateFailed, before).
+		Order("next_retry_at ASC").
+		Limit(limit).
+		Find(&pushModels).Error; err != nil {
+		return nil, err
+	}
+
+	pushes := make([]*integration.OrderStatusPush, len(pushModels))
+	for i := range pushModels {
+		pushes[i] = pushModels[i].ToDomain()
+	}
+	return pushes, nil
+}
+
+// Ensure GormOrderStatusPushRepository implements the domain interface
+var _ integration.OrderStatusPushRepository = (*GormOrderStatusPushRepository)(nil)
@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GormOrderSyncRecordRepository implements OrderSyncRecordRepository using GORM
+type GormOrderSyncRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOrderSyncRecordRepository creates a new GormOrderSyncRecordRepository
+func NewGormOrderSyncRecordRepository(db *gorm.DB) *GormOrderSyncRecordRepository {
+	return &GormOrderSyncRecordRepository{db: db}
+}
+
+// Save creates or updates a sync record
+func (r *GormOrderSyncRecordRepository) Save(ctx context.Context, record *integration.PlatformOrderSyncRecord) error {
+	model := models.PlatformOrderSyncRecordModelFromDomain(record)
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+// FindByPlatformOrder finds a record by platform order ID, returning nil if none exists
+func (r *GormOrderSyncRecordRepository) FindByPlatformOrder(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, platformOrderID string) (*integration.PlatformOrderSyncRecord, error) {
+	var model models.PlatformOrderSyncRecordModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND platform_code = ? AND platform_order_id = ?", tenantID, platformCode, platformOrderID).
+		Order("synced_at DESC").
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByLocalOrder finds a record by local order ID, returning nil if none exists
+func (r *GormOrderSyncRecordRepository) FindByLocalOrder(ctx context.Context, tenantID uuid.UUID, localOrderID uuid.UUID) (*integration.PlatformOrderSyncRecord, error) {
+	var model models.PlatformOrderSyncRecordModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND local_order_id = ?", tenantID, localOrderID).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindAll finds all records matching the filter
+func (r *GormOrderSyncRecordRepository) FindAll(ctx context.Context, tenantID uuid.UUID, filter integration.OrderSyncRecordFilter) ([]integration.PlatformOrderSyncRecord, error) {
+	var recordModels []models.PlatformOrderSyncRecordModel
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&models.PlatformOrderSyncRecordModel{}).Where("tenant_id = ?", tenantID), filter)
+	query = query.Order("synced_at DESC")
+
+	if filter.PageSize > 0 {
+		page := filter.Page
+		if page < 1 {
+			page = 1
+		}
+		query = query.Offset((page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+
+	if err := query.Find(&recordModels).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]integration.PlatformOrderSyncRecord, len(recordModels))
+	for i, model := range recordModels {
+		records[i] = *model.ToDomain()
+	}
+	return records, nil
+}
+
+// Count counts records matching the filter
+func (r *GormOrderSyncRecordRepository) Count(ctx context.Context, tenantID uuid.UUID, filter integration.OrderSyncRecordFilter) (int64, error) {
+	var count int64
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&models.PlatformOrderSyncRecordModel{}).Where("tenant_id = ?", tenantID), filter)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Delete deletes a sync record
+func (r *GormOrderSyncRecordRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.PlatformOrderSyncRecordModel{}, "id = ?", id).Error
+}
+
+// applyFilter applies the filter criteria without pagination
+func (r *GormOrderSyncRecordRepository) applyFilter(query *gorm.DB, filter integration.OrderSyncRecordFilter) *gorm.DB {
+	if filter.PlatformCode != nil {
+		query = query.Where("platform_code = ?", *filter.PlatformCode)
+	}
+	if filter.Direction != nil {
+		query = query.Where("direction = ?", *filter.Direction)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("synced_at >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("synced_at <= ?", *filter.EndTime)
+	}
+	return query
+}
+
+// GormOrderSyncConfigRepository implements OrderSyncConfigRepository using GORM
+type GormOrderSyncConfigRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOrderSyncConfigRepository creates a new GormOrderSyncConfigRepository
+func NewGormOrderSyncConfigRepository(db *gorm.DB) *GormOrderSyncConfigRepository {
+	return &GormOrderSyncConfigRepository{db: db}
+}
+
+// Save creates or updates a sync config
+func (r *GormOrderSyncConfigRepository) Save(ctx context.Context, config *integration.OrderSyncConfig) error {
+	model := models.OrderSyncConfigModelFromDomain(config)
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+// FindByTenantAndPlatform finds a config by tenant and platform, returning nil if none exists
+func (r *GormOrderSyncConfigRepository) FindByTenantAndPlatform(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*integration.OrderSyncConfig, error) {
+	var model models.OrderSyncConfigModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND platform_code = ?", tenantID, platformCode).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindAllForTenant finds all configs for a tenant
+func (r *GormOrderSyncConfigRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID) ([]integration.OrderSyncConfig, error) {
+	var configModels []models.OrderSyncConfigModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("platform_code ASC").
+		Find(&configModels).Error; err != nil {
+		return nil, err
+	}
+
+	configs := make([]integration.OrderSyncConfig, len(configModels))
+	for i, model := range configModels {
+		configs[i] = *model.ToDomain()
+	}
+	return configs, nil
+}
+
+// Delete deletes a config
+func (r *GormOrderSyncConfigRepository) Delete(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) error {
+	return r.db.WithContext(ctx).
+		Delete(&models.OrderSyncConfigModel{}, "tenant_id = ? AND platform_code = ?", tenantID, platformCode).Error
+}
+
+// Ensure the repositories implement the domain interfaces
+var (
+	_ integration.OrderSyncRecordRepository = (*GormOrderSyncRecordRepository)(nil)
+	_ integration.OrderSyncConfigRepository = (*GormOrderSyncConfigRepository)(nil)
+)
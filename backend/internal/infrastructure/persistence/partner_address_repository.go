@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GormPartnerAddressRepository implements AddressRepository using GORM
+type GormPartnerAddressRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPartnerAddressRepository creates a new GormPartnerAddressRepository
+func NewGormPartnerAddressRepository(db *gorm.DB) *GormPartnerAddressRepository {
+	return &GormPartnerAddressRepository{db: db}
+}
+
+// FindByIDForTenant finds an address by ID within a tenant
+func (r *GormPartnerAddressRepository) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Address, error) {
+	var model models.PartnerAddressModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByOwner finds all addresses of a customer or supplier (defaults first, then oldest first)
+func (r *GormPartnerAddressRepository) FindByOwner(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID) ([]partner.Address, error) {
+	var addressModels []models.PartnerAddressModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND owner_type = ? AND owner_id = ?", tenantID, ownerType, ownerID).
+		Order("type ASC, is_default DESC, created_at ASC").
+		Find(&addressModels).Error; err != nil {
+		return nil, err
+	}
+
+	addresses := make([]partner.Address, len(addressModels))
+	for i, model := range addressModels {
+		addresses[i] = *model.ToDomain()
+	}
+	return addresses, nil
+}
+
+// FindDefault finds the default address of the given type for a customer or supplier
+func (r *GormPartnerAddressRepository) FindDefault(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID, addressType partner.AddressType) (*partner.Address, error) {
+	var model models.PartnerAddressModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND owner_type = ? AND owner_id = ? AND type = ? AND is_default = ?", tenantID, ownerType, ownerID, addressType, true).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// Save creates or updates an address
+func (r *GormPartnerAddressRepository) Save(ctx context.Context, address *partner.Address) error {
+	model := models.PartnerAddressModelFromDomain(address)
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+// DeleteForTenant deletes an address within a tenant
+func (r *GormPartnerAddressRepository) DeleteForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.PartnerAddressModel{}, "tenant_id = ? AND id = ?", tenantID, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
+// ClearDefault clears the default flag on all addresses of the given type for a customer or supplier
+func (r *GormPartnerAddressRepository) ClearDefault(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID, addressType partner.AddressType) error {
+	return r.db.WithContext(ctx).
+		Model(&models.PartnerAddressModel{}).
+		Where("tenant_id = ? AND owner_type = ? AND owner_id = ? AND type = ? AND is_default = ?", tenantID, ownerType, ownerID, addressType, true).
+		Update("is_default", false).Error
+}
+
+// Ensure GormPartnerAddressRepository implements AddressRepository
+var _ partner.AddressRepository = (*GormPartnerAddressRepository)(nil)
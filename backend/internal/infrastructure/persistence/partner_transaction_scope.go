@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+
+	apppartner "github.com/erp/backend/internal/application/partner"
+	"github.com/erp/backend/internal/domain/partner"
+	"gorm.io/gorm"
+)
+
+// GormPartnerTransactionScope implements the partner TransactionScope using GORM transactions.
+// It provides atomic execution of multiple partner repository operations.
+type GormPartnerTransactionScope struct {
+	db *gorm.DB
+}
+
+// NewGormPartnerTransactionScope creates a new GormPartnerTransactionScope.
+func NewGormPartnerTransactionScope(db *gorm.DB) *GormPartnerTransactionScope {
+	return &GormPartnerTransactionScope{db: db}
+}
+
+// Execute runs the given function within a database transaction.
+// If the function returns an error, the transaction is rolled back.
+// If the function succeeds, the transaction is committed.
+func (s *GormPartnerTransactionScope) Execute(ctx context.Context, fn func(repos apppartner.TransactionalRepositories) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repos := &gormPartnerTransactionalRepositories{tx: tx}
+		return fn(repos)
+	})
+}
+
+// gormPartnerTransactionalRepositories provides access to partner repositories within a transaction.
+type gormPartnerTransactionalRepositories struct {
+	tx *gorm.DB
+}
+
+// CustomerRepo returns the customer repository scoped to the current transaction.
+func (r *gormPartnerTransactionalRepositories) CustomerRepo() partner.CustomerRepository {
+	return NewGormCustomerRepository(r.tx)
+}
+
+// BalanceTransactionRepo returns the balance transaction repository scoped to the current transaction.
+func (r *gormPartnerTransactionalRepositories) BalanceTransactionRepo() partner.BalanceTransactionRepository {
+	return NewGormBalanceTransactionRepository(r.tx)
+}
+
+// CustomerReferenceRepo returns the customer reference repository scoped to the current transaction.
+func (r *gormPartnerTransactionalRepositories) CustomerReferenceRepo() partner.CustomerReferenceRepository {
+	return NewGormCustomerReferenceRepository(r.tx)
+}
+
+// AddressRepo returns the partner address repository scoped to the current transaction.
+func (r *gormPartnerTransactionalRepositories) AddressRepo() partner.AddressRepository {
+	return NewGormPartnerAddressRepository(r.tx)
+}
+
+// Ensure GormPartnerTransactionScope implements TransactionScope
+var _ apppartner.TransactionScope = (*GormPartnerTransactionScope)(nil)
+
+// Ensure gormPartnerTransactionalRepositories implements TransactionalRepositories
+var _ apppartner.TransactionalRepositories = (*gormPartnerTransactionalRepositories)(nil)
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GormPasswordHistoryRepository implements PasswordHistoryRepository using GORM
+type GormPasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPasswordHistoryRepository creates a new GormPasswordHistoryRepository
+func NewGormPasswordHistoryRepository(db *gorm.DB) *GormPasswordHistoryRepository {
+	return &GormPasswordHistoryRepository{db: db}
+}
+
+// Save records a previous password of a user
+func (r *GormPasswordHistoryRepository) Save(ctx context.Context, entry *identity.PasswordHistoryEntry) error {
+	return r.db.WithContext(ctx).Create(models.PasswordHistoryModelFromDomain(entry)).Error
+}
+
+// FindRecentByUserID finds the most recent previous passwords of a user, most recent first
+func (r *GormPasswordHistoryRepository) FindRecentByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]identity.PasswordHistoryEntry, error) {
+	if limit <= 0 {
+		return []identity.PasswordHistoryEntry{}, nil
+	}
+
+	var historyModels []models.PasswordHistoryModel
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&historyModels).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]identity.PasswordHistoryEntry, len(historyModels))
+	for i, model := range historyModels {
+		entries[i] = *model.ToDomain()
+	}
+	return entries, nil
+}
+
+// Ensure GormPasswordHistoryRepository implements PasswordHistoryRepository
+var _ identity.PasswordHistoryRepository = (*GormPasswordHistoryRepository)(nil)
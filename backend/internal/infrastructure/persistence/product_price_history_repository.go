@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GormProductPriceHistoryRepository implements ProductPriceHistoryRepository using GORM
+type GormProductPriceHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormProductPriceHistoryRepository creates a new GormProductPriceHistoryRepository
+func NewGormProductPriceHistoryRepository(db *gorm.DB) *GormProductPriceHistoryRepository {
+	return &GormProductPriceHistoryRepository{db: db}
+}
+
+// FindByProduct finds price history entries for a product, most recent first
+func (r *GormProductPriceHistoryRepository) FindByProduct(ctx context.Context, tenantID, productID uuid.UUID, filter shared.Filter) ([]catalog.ProductPriceHistory, error) {
+	var historyModels []models.ProductPriceHistoryModel
+	query := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND product_id = ?", tenantID, productID).
+		Order("effective_at DESC, created_at DESC")
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		query = query.Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize)
+	}
+
+	if err := query.Find(&historyModels).Error; err != nil {
+		return nil, err
+	}
+	entries := make([]catalog.ProductPriceHistory, len(historyModels))
+	for i, model := range historyModels {
+		entries[i] = *model.ToDomain()
+	}
+	return entries, nil
+}
+
+// CountByProduct counts price history entries for a product
+func (r *GormProductPriceHistoryRepository) CountByProduct(ctx context.Context, tenantID, productID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.ProductPriceHistoryModel{}).
+		Where("tenant_id = ? AND product_id = ?", tenantID, productID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindLatestAt finds the most recent change of the given price type that took effect at or before the given time
+func (r *GormProductPriceHistoryRepository) FindLatestAt(ctx context.Context, tenantID, productID uuid.UUID, priceType catalog.PriceType, at time.Time) (*catalog.ProductPriceHistory, error) {
+	var model models.ProductPriceHistoryModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND product_id = ? AND price_type = ? AND effective_at <= ?", tenantID, productID, priceType, at).
+		Order("effective_at DESC, created_at DESC").
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindEarliestAfter finds the first change of the given price type that took effect after the given time
+func (r *GormProductPriceHistoryRepository) FindEarliestAfter(ctx context.Context, tenantID, productID uuid.UUID, priceType catalog.PriceType, at time.Time) (*catalog.ProductPriceHistory, error) {
+	var model models.ProductPriceHistoryModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND product_id = ? AND price_type = ? AND effective_at > ?", tenantID, productID, priceType, at).
+		Order("effective_at ASC, created_at ASC").
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// CreateBatch creates multiple price history entries
+func (r *GormProductPriceHistoryRepository) CreateBatch(ctx context.Context, entries []*catalog.ProductPriceHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	historyModels := make([]*models.ProductPriceHistoryModel, len(entries))
+	for i, entry := range entries {
+		historyModels[i] = models.ProductPriceHistoryModelFromDomain(entry)
+	}
+	return r.db.WithContext(ctx).Create(&historyModels).Error
+}
+
+// Ensure GormProductPriceHistoryRepository implements the interface
+var _ catalog.ProductPriceHistoryRepository = (*GormProductPriceHistoryRepository)(nil)
@@ -48,6 +48,20 @@ func (r *GormProductRepository) FindByIDForTenant(ctx context.Context, tenantID,
 	return model.ToDomain(), nil
 }
 
+// FindDeletedByIDForTenant finds a soft-deleted product by ID within a tenant
+func (r *GormProductRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	var model models.ProductModel
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("tenant_id = ? AND id = ? AND deleted_at IS NOT NULL", tenantID, id).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
 // FindByCode finds a product by its code within a tenant
 func (r *GormProductRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Product, error) {
 	var model models.ProductModel
@@ -222,6 +236,27 @@ func (r *GormProductRepository) FindByIDs(ctx context.Context, tenantID uuid.UUI
 	return products, nil
 }
 
+// FindByBarcodes finds multiple products by their barcodes
+func (r *GormProductRepository) FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]catalog.Product, error) {
+	if len(barcodes) == 0 {
+		return []catalog.Product{}, nil
+	}
+
+	var productModels []models.ProductModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND barcode IN ?", tenantID, barcodes).
+		Find(&productModels).Error; err != nil {
+		return nil, err
+	}
+
+	// Convert to domain entities
+	products := make([]catalog.Product, len(productModels))
+	for i, model := range productModels {
+		products[i] = *model.ToDomain()
+	}
+	return products, nil
+}
+
 // FindByCodes finds multiple products by their codes
 func (r *GormProductRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]catalog.Product, error) {
 	if len(codes) == 0 {
@@ -255,6 +290,25 @@ func (r *GormProductRepository) Save(ctx context.Context, product *catalog.Produ
 	return r.db.WithContext(ctx).Save(model).Error
 }
 
+// SaveWithLock updates a product only if its stored version is still expectedVersion
+func (r *GormProductRepository) SaveWithLock(ctx context.Context, product *catalog.Product, expectedVersion int) error {
+	// Select all columns so fields cleared to their zero value are written too
+	model := models.ProductModelFromDomain(product)
+	result := r.db.WithContext(ctx).
+		Model(model).
+		Select("*").
+		Where("tenant_id = ? AND version = ?", product.TenantID, expectedVersion).
+		Updates(model)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return versionConflict(r.db.WithContext(ctx), &models.ProductModel{}, product.ID, "product")
+	}
+	return nil
+}
+
 // SaveBatch creates or updates multiple products
 func (r *GormProductRepository) SaveBatch(ctx context.Context, products []*catalog.Product) error {
 	if len(products) == 0 {
@@ -291,6 +345,21 @@ func (r *GormProductRepository) DeleteForTenant(ctx context.Context, tenantID, i
 	return nil
 }
 
+// RestoreForTenant clears the deletion of a soft-deleted product within a tenant
+func (r *GormProductRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&models.ProductModel{}).
+		Where("tenant_id = ? AND id = ? AND deleted_at IS NOT NULL", tenantID, id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
 // Count counts products matching the filter
 func (r *GormProductRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	var count int64
@@ -425,6 +494,10 @@ func (r *GormProductRepository) applyFilterWithoutPagination(query *gorm.DB, fil
 			} else {
 				query = query.Where("barcode IS NULL OR barcode = ''")
 			}
+		case "include_deleted":
+			if value == true {
+				query = query.Unscoped()
+			}
 		}
 	}
 
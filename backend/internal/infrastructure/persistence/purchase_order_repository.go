@@ -186,7 +186,8 @@ func (r *GormPurchaseOrderRepository) FindPendingReceipt(ctx context.Context, te
 
 	query = r.applyFilter(query, filter)
 
-	if err := query.Find(&orderModels).Error; err != nil {
+	// Preload Items so callers can read the quantities still to be received
+	if err := query.Preload("Items").Find(&orderModels).Error; err != nil {
 		return nil, err
 	}
 	orders := make([]trade.PurchaseOrder, len(orderModels))
@@ -271,20 +272,21 @@ func (r *GormPurchaseOrderRepository) SaveWithLock(ctx context.Context, order *t
 		result := tx.Model(&models.PurchaseOrderModel{}).
 			Where("id = ? AND version = ?", order.ID, currentVersion).
 			Updates(map[string]interface{}{
-				"supplier_id":     order.SupplierID,
-				"supplier_name":   order.SupplierName,
-				"warehouse_id":    order.WarehouseID,
-				"total_amount":    order.TotalAmount,
-				"discount_amount": order.DiscountAmount,
-				"payable_amount":  order.PayableAmount,
-				"status":          order.Status,
-				"remark":          order.Remark,
-				"confirmed_at":    order.ConfirmedAt,
-				"completed_at":    order.CompletedAt,
-				"cancelled_at":    order.CancelledAt,
-				"cancel_reason":   order.CancelReason,
-				"version":         order.Version,
-				"updated_at":      order.UpdatedAt,
+				"supplier_id":       order.SupplierID,
+				"supplier_name":     order.SupplierName,
+				"warehouse_id":      order.WarehouseID,
+				"total_amount":      order.TotalAmount,
+				"discount_amount":   order.DiscountAmount,
+				"payable_amount":    order.PayableAmount,
+				"status":            order.Status,
+				"remark":            order.Remark,
+				"confirmed_at":      order.ConfirmedAt,
+				"completed_at":      order.CompletedAt,
+				"cancelled_at":      order.CancelledAt,
+				"cancel_reason":     order.CancelReason,
+				"pending_amendment": order.PendingAmendment,
+				"version":           order.Version,
+				"updated_at":        order.UpdatedAt,
 			})
 
 		if result.Error != nil {
@@ -357,20 +359,21 @@ func (r *GormPurchaseOrderRepository) SaveWithLockAndEvents(ctx context.Context,
 		result := tx.Model(&models.PurchaseOrderModel{}).
 			Where("id = ? AND version = ?", order.ID, currentVersion).
 			Updates(map[string]interface{}{
-				"supplier_id":     order.SupplierID,
-				"supplier_name":   order.SupplierName,
-				"warehouse_id":    order.WarehouseID,
-				"total_amount":    order.TotalAmount,
-				"discount_amount": order.DiscountAmount,
-				"payable_amount":  order.PayableAmount,
-				"status":          order.Status,
-				"remark":          order.Remark,
-				"confirmed_at":    order.ConfirmedAt,
-				"completed_at":    order.CompletedAt,
-				"cancelled_at":    order.CancelledAt,
-				"cancel_reason":   order.CancelReason,
-				"version":         order.Version,
-				"updated_at":      order.UpdatedAt,
+				"supplier_id":       order.SupplierID,
+				"supplier_name":     order.SupplierName,
+				"warehouse_id":      order.WarehouseID,
+				"total_amount":      order.TotalAmount,
+				"discount_amount":   order.DiscountAmount,
+				"payable_amount":    order.PayableAmount,
+				"status":            order.Status,
+				"remark":            order.Remark,
+				"confirmed_at":      order.ConfirmedAt,
+				"completed_at":      order.CompletedAt,
+				"cancelled_at":      order.CancelledAt,
+				"cancel_reason":     order.CancelReason,
+				"pending_amendment": order.PendingAmendment,
+				"version":           order.Version,
+				"updated_at":        order.UpdatedAt,
 			})
 
 		if result.Error != nil {
@@ -679,6 +682,8 @@ func (r *GormPurchaseOrderRepository) applyFilterWithoutPagination(query *gorm.D
 			query = query.Where("supplier_id = ?", value)
 		case "warehouse_id":
 			query = query.Where("warehouse_id = ?", value)
+		case "product_id":
+			query = query.Where("id IN (?)", r.db.Model(&models.PurchaseOrderItemModel{}).Select("order_id").Where("product_id = ?", value))
 		case "status":
 			query = query.Where("status = ?", value)
 		case "statuses":
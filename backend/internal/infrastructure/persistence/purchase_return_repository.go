@@ -230,6 +230,7 @@ func (r *GormPurchaseReturnRepository) SaveWithLock(ctx context.Context, pr *tra
 				"warehouse_id":          pr.WarehouseID,
 				"total_refund":          pr.TotalRefund,
 				"status":                pr.Status,
+				"reason_code":           pr.ReasonCode,
 				"reason":                pr.Reason,
 				"remark":                pr.Remark,
 				"submitted_at":          pr.SubmittedAt,
@@ -170,19 +170,31 @@ func (r *GormReceiptVoucherRepository) SaveWithLock(ctx context.Context, voucher
 	// Increment version
 	voucher.Version++
 
-	model := models.ReceiptVoucherModelFromDomain(voucher)
-	result := r.db.WithContext(ctx).
-		Model(model).
-		Where("id = ? AND version = ?", voucher.ID, currentVersion).
-		Updates(model)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		model := models.ReceiptVoucherModelFromDomain(voucher)
+		result := tx.
+			Model(model).
+			Where("id = ? AND version = ?", voucher.ID, currentVersion).
+			Updates(model)
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return shared.NewDomainError("CONCURRENT_MODIFICATION", "The voucher has been modified by another user")
+		}
 
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return shared.NewDomainError("CONCURRENT_MODIFICATION", "The voucher has been modified by another user")
-	}
-	return nil
+		// Delete allocations removed from the voucher
+		currentAllocationIDs := make([]uuid.UUID, len(voucher.Allocations))
+		for i, alloc := range voucher.Allocations {
+			currentAllocationIDs[i] = alloc.ID
+		}
+		query := tx.Where("receipt_voucher_id = ?", voucher.ID)
+		if len(currentAllocationIDs) > 0 {
+			query = query.Where("id NOT IN ?", currentAllocationIDs)
+		}
+		return query.Delete(&models.ReceivableAllocationModel{}).Error
+	})
 }
 
 // Delete soft deletes a receipt voucher
@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// GormReturnReportRepository implements ReturnReportRepository using GORM
+type GormReturnReportRepository struct {
+	db *gorm.DB
+}
+
+// NewGormReturnReportRepository creates a new GormReturnReportRepository
+func NewGormReturnReportRepository(db *gorm.DB) *GormReturnReportRepository {
+	return &GormReturnReportRepository{db: db}
+}
+
+// GetSalesReturnsByReason returns sales return counts and refund amounts grouped by reason code
+func (r *GormReturnReportRepository) GetSalesReturnsByReason(filter report.ReturnReportFilter) ([]report.ReturnReasonStat, error) {
+	return r.getReturnsByReason("sales_returns", []string{"PENDING", "APPROVED", "RECEIVING", "COMPLETED"}, filter)
+}
+
+// GetPurchaseReturnsByReason returns purchase return counts and refund amounts grouped by reason code
+func (r *GormReturnReportRepository) GetPurchaseReturnsByReason(filter report.ReturnReportFilter) ([]report.ReturnReasonStat, error) {
+	return r.getReturnsByReason("purchase_returns", []string{"PENDING", "APPROVED", "SHIPPED", "COMPLETED"}, filter)
+}
+
+// getReturnsByReason aggregates the given return table by reason code for returns in the
+// given statuses submitted within the filter period
+func (r *GormReturnReportRepository) getReturnsByReason(table string, statuses []string, filter report.ReturnReportFilter) ([]report.ReturnReasonStat, error) {
+	type reasonResult struct {
+		ReasonCode  string
+		ReturnCount int64
+		TotalAmount decimal.Decimal
+	}
+
+	var results []reasonResult
+
+	err := r.db.Table(table).
+		Select(`
+			reason_code,
+			COUNT(*) as return_count,
+			COALESCE(SUM(total_refund), 0) as total_amount
+		`).
+		Where("tenant_id = ?", filter.TenantID).
+		Where("submitted_at BETWEEN ? AND ?", filter.StartDate, filter.EndDate).
+		Where("status IN ?", statuses).
+		Group("reason_code").
+		Order("return_count DESC, reason_code ASC").
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]report.ReturnReasonStat, len(results))
+	for i, res := range results {
+		stats[i] = report.ReturnReasonStat{
+			ReasonCode:  res.ReasonCode,
+			ReturnCount: res.ReturnCount,
+			TotalAmount: res.TotalAmount,
+		}
+	}
+
+	return stats, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/erp/backend/internal/domain/trade"
 	"github.com/erp/backend/internal/infrastructure/persistence/datascope"
 	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/erp/backend/internal/infrastructure/persistence/tenant"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
@@ -234,7 +235,7 @@ func (r *GormSalesOrderRepository) SaveWithLock(ctx context.Context, order *trad
 
 		// Check version matches
 		if currentVersion != order.Version {
-			return shared.NewDomainError("CONCURRENT_MODIFICATION", "The order has been modified by another user")
+			return shared.NewVersionConflictError("sales order", currentVersion)
 		}
 
 		// Increment version
@@ -245,21 +246,32 @@ func (r *GormSalesOrderRepository) SaveWithLock(ctx context.Context, order *trad
 		result := tx.Model(&models.SalesOrderModel{}).
 			Where("id = ? AND version = ?", order.ID, currentVersion).
 			Updates(map[string]interface{}{
-				"customer_id":     order.CustomerID,
-				"customer_name":   order.CustomerName,
-				"warehouse_id":    order.WarehouseID,
-				"total_amount":    order.TotalAmount,
-				"discount_amount": order.DiscountAmount,
-				"payable_amount":  order.PayableAmount,
-				"status":          order.Status,
-				"remark":          order.Remark,
-				"confirmed_at":    order.ConfirmedAt,
-				"shipped_at":      order.ShippedAt,
-				"completed_at":    order.CompletedAt,
-				"cancelled_at":    order.CancelledAt,
-				"cancel_reason":   order.CancelReason,
-				"version":         order.Version,
-				"updated_at":      order.UpdatedAt,
+				"customer_id":                 order.CustomerID,
+				"customer_name":               order.CustomerName,
+				"warehouse_id":                order.WarehouseID,
+				"shipping_address_id":         order.ShippingAddressID,
+				"total_amount":                order.TotalAmount,
+				"discount_amount":             order.DiscountAmount,
+				"payable_amount":              order.PayableAmount,
+				"status":                      order.Status,
+				"remark":                      order.Remark,
+				"confirmed_at":                order.ConfirmedAt,
+				"first_shipped_at":            order.FirstShippedAt,
+				"shipped_at":                  order.ShippedAt,
+				"fully_shipped_at":            order.FullyShippedAt,
+				"shipment_count":              order.ShipmentCount,
+				"completed_at":                order.CompletedAt,
+				"cancelled_at":                order.CancelledAt,
+				"cancel_reason":               order.CancelReason,
+				"credit_override_approved_by": order.CreditOverrideApprovedBy,
+				"credit_override_approved_at": order.CreditOverrideApprovedAt,
+				"credit_override_reason":      order.CreditOverrideReason,
+				"allow_expired_stock":         order.AllowExpiredStock,
+				"quote_expires_at":            order.QuoteExpiresAt,
+				"converted_at":                order.ConvertedAt,
+				"expired_at":                  order.ExpiredAt,
+				"version":                     order.Version,
+				"updated_at":                  order.UpdatedAt,
 			})
 
 		if result.Error != nil {
@@ -267,7 +279,7 @@ func (r *GormSalesOrderRepository) SaveWithLock(ctx context.Context, order *trad
 		}
 
 		if result.RowsAffected == 0 {
-			return shared.NewDomainError("CONCURRENT_MODIFICATION", "The order has been modified by another user")
+			return versionConflict(tx, &models.SalesOrderModel{}, order.ID, "sales order")
 		}
 
 		// Handle items
@@ -321,7 +333,7 @@ func (r *GormSalesOrderRepository) SaveWithLockAndEvents(ctx context.Context, or
 
 		// Check version matches
 		if currentVersion != order.Version {
-			return shared.NewDomainError("CONCURRENT_MODIFICATION", "The order has been modified by another user")
+			return shared.NewVersionConflictError("sales order", currentVersion)
 		}
 
 		// Increment version
@@ -332,21 +344,32 @@ func (r *GormSalesOrderRepository) SaveWithLockAndEvents(ctx context.Context, or
 		result := tx.Model(&models.SalesOrderModel{}).
 			Where("id = ? AND version = ?", order.ID, currentVersion).
 			Updates(map[string]interface{}{
-				"customer_id":     order.CustomerID,
-				"customer_name":   order.CustomerName,
-				"warehouse_id":    order.WarehouseID,
-				"total_amount":    order.TotalAmount,
-				"discount_amount": order.DiscountAmount,
-				"payable_amount":  order.PayableAmount,
-				"status":          order.Status,
-				"remark":          order.Remark,
-				"confirmed_at":    order.ConfirmedAt,
-				"shipped_at":      order.ShippedAt,
-				"completed_at":    order.CompletedAt,
-				"cancelled_at":    order.CancelledAt,
-				"cancel_reason":   order.CancelReason,
-				"version":         order.Version,
-				"updated_at":      order.UpdatedAt,
+				"customer_id":                 order.CustomerID,
+				"customer_name":               order.CustomerName,
+				"warehouse_id":                order.WarehouseID,
+				"shipping_address_id":         order.ShippingAddressID,
+				"total_amount":                order.TotalAmount,
+				"discount_amount":             order.DiscountAmount,
+				"payable_amount":              order.PayableAmount,
+				"status":                      order.Status,
+				"remark":                      order.Remark,
+				"confirmed_at":                order.ConfirmedAt,
+				"first_shipped_at":            order.FirstShippedAt,
+				"shipped_at":                  order.ShippedAt,
+				"fully_shipped_at":            order.FullyShippedAt,
+				"shipment_count":              order.ShipmentCount,
+				"completed_at":                order.CompletedAt,
+				"cancelled_at":                order.CancelledAt,
+				"cancel_reason":               order.CancelReason,
+				"credit_override_approved_by": order.CreditOverrideApprovedBy,
+				"credit_override_approved_at": order.CreditOverrideApprovedAt,
+				"credit_override_reason":      order.CreditOverrideReason,
+				"allow_expired_stock":         order.AllowExpiredStock,
+				"quote_expires_at":            order.QuoteExpiresAt,
+				"converted_at":                order.ConvertedAt,
+				"expired_at":                  order.ExpiredAt,
+				"version":                     order.Version,
+				"updated_at":                  order.UpdatedAt,
 			})
 
 		if result.Error != nil {
@@ -354,7 +377,7 @@ func (r *GormSalesOrderRepository) SaveWithLockAndEvents(ctx context.Context, or
 		}
 
 		if result.RowsAffected == 0 {
-			return shared.NewDomainError("CONCURRENT_MODIFICATION", "The order has been modified by another user")
+			return versionConflict(tx, &models.SalesOrderModel{}, order.ID, "sales order")
 		}
 
 		// Handle items
@@ -494,19 +517,35 @@ func (r *GormSalesOrderRepository) CountByCustomer(ctx context.Context, tenantID
 	return count, nil
 }
 
-// CountIncompleteByCustomer counts incomplete orders (not COMPLETED or CANCELLED) for a customer
+// CountIncompleteByCustomer counts incomplete orders (not COMPLETED, CANCELLED or EXPIRED) for a customer
 func (r *GormSalesOrderRepository) CountIncompleteByCustomer(ctx context.Context, tenantID, customerID uuid.UUID) (int64, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).
 		Model(&models.SalesOrderModel{}).
 		Where("tenant_id = ? AND customer_id = ? AND status NOT IN ?", tenantID, customerID,
-			[]trade.OrderStatus{trade.OrderStatusCompleted, trade.OrderStatusCancelled}).
+			[]trade.OrderStatus{trade.OrderStatusCompleted, trade.OrderStatusCancelled, trade.OrderStatusExpired}).
 		Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
+// SumCompletedByCustomer sums the payable amount of a customer's sales orders completed since the given time
+func (r *GormSalesOrderRepository) SumCompletedByCustomer(ctx context.Context, tenantID, customerID uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	var result struct {
+		Total decimal.Decimal
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.SalesOrderModel{}).
+		Select("COALESCE(SUM(payable_amount), 0) as total").
+		Where("tenant_id = ? AND customer_id = ? AND status = ? AND completed_at >= ?",
+			tenantID, customerID, trade.OrderStatusCompleted, since).
+		Scan(&result).Error; err != nil {
+		return decimal.Zero, err
+	}
+	return result.Total, nil
+}
+
 // ExistsByOrderNumber checks if an order number exists for a tenant
 func (r *GormSalesOrderRepository) ExistsByOrderNumber(ctx context.Context, tenantID uuid.UUID, orderNumber string) (bool, error) {
 	var count int64
@@ -675,3 +714,20 @@ func (r *GormSalesOrderRepository) applyFilterWithoutPagination(query *gorm.DB,
 
 // Ensure GormSalesOrderRepository implements SalesOrderRepository
 var _ trade.SalesOrderRepository = (*GormSalesOrderRepository)(nil)
+
+// FindExpiredQuotes finds quotes across all tenants whose expiry is on or before expiresBefore
+func (r *GormSalesOrderRepository) FindExpiredQuotes(ctx context.Context, expiresBefore time.Time) ([]trade.SalesOrder, error) {
+	var orderModels []models.SalesOrderModel
+	if err := r.db.WithContext(tenant.WithCrossTenant(ctx)).
+		Preload("Items").
+		Where("status = ? AND quote_expires_at IS NOT NULL AND quote_expires_at <= ?", trade.OrderStatusQuote, expiresBefore).
+		Order("quote_expires_at ASC").
+		Find(&orderModels).Error; err != nil {
+		return nil, err
+	}
+	orders := make([]trade.SalesOrder, len(orderModels))
+	for i, model := range orderModels {
+		orders[i] = *model.ToDomain()
+	}
+	return orders, nil
+}
@@ -79,7 +79,8 @@ func (r *GormSalesReportRepository) GetSalesSummary(filter report.SalesReportFil
 	}, nil
 }
 
-// GetDailySalesTrend returns daily sales trend data
+// GetDailySalesTrend returns the daily trend of completed sales, read from the daily sales facts.
+// Orders are counted from sales_orders, as fact order counts are per product and can't be summed.
 func (r *GormSalesReportRepository) GetDailySalesTrend(filter report.SalesReportFilter) ([]report.DailySalesTrend, error) {
 	type dailyResult struct {
 		Date        time.Time
@@ -91,20 +92,26 @@ func (r *GormSalesReportRepository) GetDailySalesTrend(filter report.SalesReport
 
 	var results []dailyResult
 
-	err := r.db.Table("sales_orders so").
+	dailyOrders := r.db.Table("sales_orders").
+		Select("DATE(completed_at) as date, COUNT(*) as order_count").
+		Where("tenant_id = ?", filter.TenantID).
+		Where("status = ?", "COMPLETED").
+		Where("completed_at BETWEEN ? AND ?", filter.StartDate, filter.EndDate).
+		Group("DATE(completed_at)")
+
+	err := r.db.Table("daily_sales_fact f").
 		Select(`
-			DATE(so.created_at) as date,
-			COUNT(DISTINCT so.id) as order_count,
-			COALESCE(SUM(so.total_amount), 0) as total_amount,
-			COALESCE(SUM(soi.amount), 0) as total_cost,
-			COALESCE(SUM(soi.quantity), 0) as items_sold
+			f.date,
+			COALESCE(o.order_count, 0) as order_count,
+			COALESCE(SUM(f.revenue), 0) as total_amount,
+			COALESCE(SUM(f.cost), 0) as total_cost,
+			COALESCE(SUM(f.quantity), 0) as items_sold
 		`).
-		Joins("LEFT JOIN sales_order_items soi ON soi.order_id = so.id").
-		Where("so.tenant_id = ?", filter.TenantID).
-		Where("so.created_at BETWEEN ? AND ?", filter.StartDate, filter.EndDate).
-		Where("so.status IN ?", []string{"CONFIRMED", "SHIPPED", "COMPLETED"}).
-		Group("DATE(so.created_at)").
-		Order("date ASC").
+		Joins("LEFT JOIN (?) o ON o.date = f.date", dailyOrders).
+		Where("f.tenant_id = ?", filter.TenantID).
+		Where("f.date BETWEEN DATE(?) AND DATE(?)", filter.StartDate, filter.EndDate).
+		Group("f.date, o.order_count").
+		Order("f.date ASC").
 		Scan(&results).Error
 
 	if err != nil {
@@ -264,10 +271,13 @@ func (r *GormSalesReportRepository) GetProductSalesRanking(filter report.SalesRe
 	return rankings, nil
 }
 
-// GetCustomerSalesRanking returns top N customers by sales
+// GetCustomerSalesRanking returns the top N customers by the filter's ranking metric.
+// Gross margin uses the cost the stock left the warehouse at, as set by the tenant's cost strategy;
+// orders not yet shipped are costed at the current unit cost of their items' inventory.
 func (r *GormSalesReportRepository) GetCustomerSalesRanking(filter report.SalesReportFilter) ([]report.CustomerSalesRanking, error) {
 	type rankingResult struct {
 		CustomerID    uuid.UUID
+		CustomerCode  string
 		CustomerName  string
 		TotalOrders   int64
 		TotalQuantity decimal.Decimal
@@ -282,40 +292,116 @@ func (r *GormSalesReportRepository) GetCustomerSalesRanking(filter report.SalesR
 		topN = 10
 	}
 
+	// Item quantities and their cost at the current unit cost, per order
+	orderItems := r.db.Table("sales_order_items soi").
+		Select(`
+			soi.order_id,
+			SUM(soi.quantity) as quantity,
+			SUM(soi.quantity * COALESCE(ii.unit_cost, 0)) as estimated_cost
+		`).
+		Joins("JOIN sales_orders o ON o.id = soi.order_id").
+		Joins("LEFT JOIN inventory_items ii ON ii.product_id = soi.product_id AND ii.warehouse_id = o.warehouse_id AND ii.tenant_id = o.tenant_id").
+		Where("o.tenant_id = ?", filter.TenantID).
+		Group("soi.order_id")
+
+	// Cost of the stock shipped for each order, from the inventory ledger
+	shippedCost := r.db.Table("inventory_transactions t").
+		Select("t.source_id, SUM(t.total_cost) as cost").
+		Where("t.tenant_id = ?", filter.TenantID).
+		Where("t.transaction_type = ? AND t.source_type = ?", "OUTBOUND", "SALES_ORDER").
+		Group("t.source_id")
+
 	err := r.db.Table("sales_orders so").
 		Select(`
 			so.customer_id,
-			so.customer_name,
-			COUNT(DISTINCT so.id) as total_orders,
-			COALESCE(SUM(soi.quantity), 0) as total_quantity,
+			COALESCE(c.code, '') as customer_code,
+			MAX(so.customer_name) as customer_name,
+			COUNT(so.id) as total_orders,
+			COALESCE(SUM(items.quantity), 0) as total_quantity,
 			COALESCE(SUM(so.total_amount), 0) as total_amount,
-			COALESCE(SUM(soi.amount), 0) as total_cost
+			COALESCE(SUM(COALESCE(shipped.cost, items.estimated_cost)), 0) as total_cost
 		`).
-		Joins("LEFT JOIN sales_order_items soi ON soi.order_id = so.id").
+		Joins("LEFT JOIN customers c ON c.id = so.customer_id").
+		Joins("LEFT JOIN (?) items ON items.order_id = so.id", orderItems).
+		Joins("LEFT JOIN (?) shipped ON shipped.source_id = so.id::text", shippedCost).
 		Where("so.tenant_id = ?", filter.TenantID).
 		Where("so.created_at BETWEEN ? AND ?", filter.StartDate, filter.EndDate).
 		Where("so.status IN ?", []string{"CONFIRMED", "SHIPPED", "COMPLETED"}).
-		Group("so.customer_id, so.customer_name").
-		Order("total_amount DESC").
-		Limit(topN).
+		Group("so.customer_id, c.code").
 		Scan(&results).Error
 
 	if err != nil {
 		return nil, err
 	}
 
-	rankings := make([]report.CustomerSalesRanking, len(results))
+	customers := make([]report.CustomerSalesRanking, len(results))
 	for i, r := range results {
-		rankings[i] = report.CustomerSalesRanking{
-			Rank:          i + 1,
+		avgOrderValue := decimal.Zero
+		if r.TotalOrders > 0 {
+			avgOrderValue = r.TotalAmount.Div(decimal.NewFromInt(r.TotalOrders)).Round(4)
+		}
+		customers[i] = report.CustomerSalesRanking{
 			CustomerID:    r.CustomerID,
+			CustomerCode:  r.CustomerCode,
 			CustomerName:  r.CustomerName,
 			TotalOrders:   r.TotalOrders,
 			TotalQuantity: r.TotalQuantity,
 			TotalAmount:   r.TotalAmount,
 			TotalProfit:   r.TotalAmount.Sub(r.TotalCost),
+			AvgOrderValue: avgOrderValue,
 		}
 	}
 
-	return rankings, nil
+	return report.RankCustomerSales(customers, filter.RankBy, topN), nil
+}
+
+// GetOrderFulfillmentTimes returns the lifecycle timestamps of orders confirmed in the period
+func (r *GormSalesReportRepository) GetOrderFulfillmentTimes(filter report.SalesReportFilter) ([]report.OrderFulfillmentTimes, error) {
+	type fulfillmentResult struct {
+		ID             uuid.UUID
+		WarehouseID    *uuid.UUID
+		WarehouseName  *string
+		ConfirmedAt    time.Time
+		FullyShippedAt *time.Time
+		CompletedAt    *time.Time
+		CancelledAt    *time.Time
+	}
+
+	var results []fulfillmentResult
+
+	err := r.db.Table("sales_orders so").
+		Select(`
+			so.id,
+			so.warehouse_id,
+			w.name as warehouse_name,
+			so.confirmed_at,
+			so.fully_shipped_at,
+			so.completed_at,
+			so.cancelled_at
+		`).
+		Joins("LEFT JOIN warehouses w ON w.id = so.warehouse_id").
+		Where("so.tenant_id = ?", filter.TenantID).
+		Where("so.confirmed_at BETWEEN ? AND ?", filter.StartDate, filter.EndDate).
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]report.OrderFulfillmentTimes, len(results))
+	for i, res := range results {
+		orders[i] = report.OrderFulfillmentTimes{
+			OrderID:        res.ID,
+			WarehouseID:    res.WarehouseID,
+			ConfirmedAt:    res.ConfirmedAt,
+			FullyShippedAt: res.FullyShippedAt,
+			CompletedAt:    res.CompletedAt,
+			CancelledAt:    res.CancelledAt,
+		}
+		if res.WarehouseName != nil {
+			orders[i].WarehouseName = *res.WarehouseName
+		}
+	}
+
+	return orders, nil
 }
@@ -230,6 +230,7 @@ func (r *GormSalesReturnRepository) SaveWithLock(ctx context.Context, sr *trade.
 				"warehouse_id":       sr.WarehouseID,
 				"total_refund":       sr.TotalRefund,
 				"status":             sr.Status,
+				"reason_code":        sr.ReasonCode,
 				"reason":             sr.Reason,
 				"remark":             sr.Remark,
 				"submitted_at":       sr.SubmittedAt,
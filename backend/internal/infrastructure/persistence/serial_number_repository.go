@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormSerialNumberRepository implements SerialNumberRepository using GORM
+type GormSerialNumberRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSerialNumberRepository creates a new GormSerialNumberRepository
+func NewGormSerialNumberRepository(db *gorm.DB) *GormSerialNumberRepository {
+	return &GormSerialNumberRepository{db: db}
+}
+
+// WithTx returns a new repository instance with the given transaction
+func (r *GormSerialNumberRepository) WithTx(tx *gorm.DB) *GormSerialNumberRepository {
+	return &GormSerialNumberRepository{db: tx}
+}
+
+// FindByIDForTenant finds a serial number by ID within a tenant
+func (r *GormSerialNumberRepository) FindByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*inventory.SerialNumber, error) {
+	var model models.SerialNumberModel
+	if err := r.db.WithContext(ctx).First(&model, "tenant_id = ? AND id = ?", tenantID, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindBySerialNumber finds a product's serial number
+func (r *GormSerialNumberRepository) FindBySerialNumber(ctx context.Context, tenantID, productID uuid.UUID, serialNumber string) (*inventory.SerialNumber, error) {
+	var model models.SerialNumberModel
+	if err := r.db.WithContext(ctx).
+		First(&model, "tenant_id = ? AND product_id = ? AND serial_number = ?", tenantID, productID, serialNumber).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindBySerialNumbers finds the existing serial numbers of a product among the given ones
+func (r *GormSerialNumberRepository) FindBySerialNumbers(ctx context.Context, tenantID, productID uuid.UUID, serialNumbers []string) ([]inventory.SerialNumber, error) {
+	if len(serialNumbers) == 0 {
+		return []inventory.SerialNumber{}, nil
+	}
+	var snModels []models.SerialNumberModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND product_id = ? AND serial_number IN ?", tenantID, productID, serialNumbers).
+		Find(&snModels).Error; err != nil {
+		return nil, err
+	}
+	sns := make([]inventory.SerialNumber, len(snModels))
+	for i, model := range snModels {
+		sns[i] = *model.ToDomain()
+	}
+	return sns, nil
+}
+
+// FindMovements finds the movement history of a serial number, oldest first
+func (r *GormSerialNumberRepository) FindMovements(ctx context.Context, serialNumberID uuid.UUID) ([]inventory.SerialNumberMovement, error) {
+	var mvModels []models.SerialNumberMovementModel
+	if err := r.db.WithContext(ctx).
+		Where("serial_number_id = ?", serialNumberID).
+		Order("occurred_at ASC, created_at ASC").
+		Find(&mvModels).Error; err != nil {
+		return nil, err
+	}
+	movements := make([]inventory.SerialNumberMovement, len(mvModels))
+	for i, model := range mvModels {
+		movements[i] = *model.ToDomain()
+	}
+	return movements, nil
+}
+
+// Save creates or updates a serial number and appends its new movements
+func (r *GormSerialNumberRepository) Save(ctx context.Context, sn *inventory.SerialNumber) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Save(models.SerialNumberModelFromDomain(sn)).Error; err != nil {
+		return err
+	}
+	if len(sn.Movements) == 0 {
+		return nil
+	}
+	mvModels := make([]*models.SerialNumberMovementModel, len(sn.Movements))
+	for i := range sn.Movements {
+		mvModels[i] = models.SerialNumberMovementModelFromDomain(&sn.Movements[i])
+	}
+	// Movements are append-only; ones already persisted by an earlier save are skipped
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(mvModels).Error
+}
@@ -49,6 +49,20 @@ func (r *GormSupplierRepository) FindByIDForTenant(ctx context.Context, tenantID
 	return model.ToDomain(), nil
 }
 
+// FindDeletedByIDForTenant finds a soft-deleted supplier by ID within a tenant
+func (r *GormSupplierRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*partner.Supplier, error) {
+	var model models.SupplierModel
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("tenant_id = ? AND id = ? AND deleted_at IS NOT NULL", tenantID, id).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
 // FindByCode finds a supplier by its code within a tenant
 func (r *GormSupplierRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Supplier, error) {
 	var model models.SupplierModel
@@ -301,6 +315,21 @@ func (r *GormSupplierRepository) DeleteForTenant(ctx context.Context, tenantID,
 	return nil
 }
 
+// RestoreForTenant clears the deletion of a soft-deleted supplier within a tenant
+func (r *GormSupplierRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&models.SupplierModel{}).
+		Where("tenant_id = ? AND id = ? AND deleted_at IS NOT NULL", tenantID, id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
 // Count counts suppliers matching the filter
 func (r *GormSupplierRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	var count int64
@@ -459,6 +488,10 @@ func (r *GormSupplierRepository) applyFilterWithoutPagination(query *gorm.DB, fi
 			query = query.Where("rating >= ?", value)
 		case "max_rating":
 			query = query.Where("rating <= ?", value)
+		case "include_deleted":
+			if value == true {
+				query = query.Unscoped()
+			}
 		}
 	}
 
@@ -55,7 +55,7 @@ func TestGormSupplierRepository_FindByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "type", "status", "balance", "credit_limit", "credit_days", "rating"}).
 			AddRow(supplierID, tenantID, "SUP001", "Test Supplier", "distributor", "active", decimal.Zero, decimal.Zero, 30, 4)
 
-		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE id = \$1 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE id = \$1 AND "suppliers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(supplierID, 1).
 			WillReturnRows(rows)
 
@@ -74,7 +74,7 @@ func TestGormSupplierRepository_FindByID(t *testing.T) {
 
 		supplierID := uuid.New()
 
-		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE id = \$1 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE id = \$1 AND "suppliers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(supplierID, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
@@ -98,7 +98,7 @@ func TestGormSupplierRepository_FindByIDForTenant(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "type", "status", "balance", "credit_limit", "credit_days", "rating"}).
 			AddRow(supplierID, tenantID, "SUP001", "Test Supplier", "distributor", "active", decimal.Zero, decimal.Zero, 30, 4)
 
-		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE tenant_id = \$1 AND id = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE \(tenant_id = \$1 AND id = \$2\) AND "suppliers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, supplierID, 1).
 			WillReturnRows(rows)
 
@@ -122,7 +122,7 @@ func TestGormSupplierRepository_FindByCode(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "type", "status", "balance", "credit_limit", "credit_days", "rating"}).
 			AddRow(supplierID, tenantID, "SUP001", "Test Supplier", "distributor", "active", decimal.Zero, decimal.Zero, 30, 4)
 
-		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE tenant_id = \$1 AND code = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE \(tenant_id = \$1 AND code = \$2\) AND "suppliers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, "SUP001", 1).
 			WillReturnRows(rows)
 
@@ -146,7 +146,7 @@ func TestGormSupplierRepository_FindByPhone(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "phone", "type", "status", "balance", "credit_limit", "credit_days", "rating"}).
 			AddRow(supplierID, tenantID, "SUP001", "Test Supplier", "13900139000", "distributor", "active", decimal.Zero, decimal.Zero, 30, 4)
 
-		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE tenant_id = \$1 AND phone = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE \(tenant_id = \$1 AND phone = \$2\) AND "suppliers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, "13900139000", 1).
 			WillReturnRows(rows)
 
@@ -179,7 +179,7 @@ func TestGormSupplierRepository_FindByEmail(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "email", "type", "status", "balance", "credit_limit", "credit_days", "rating"}).
 			AddRow(supplierID, tenantID, "SUP001", "Test Supplier", "supplier@example.com", "distributor", "active", decimal.Zero, decimal.Zero, 30, 4)
 
-		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE tenant_id = \$1 AND email = \$2 ORDER BY .* LIMIT .*`).
+		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE \(tenant_id = \$1 AND email = \$2\) AND "suppliers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
 			WithArgs(tenantID, "supplier@example.com", 1).
 			WillReturnRows(rows)
 
@@ -213,7 +213,7 @@ func TestGormSupplierRepository_FindByIDs(t *testing.T) {
 			AddRow(id1, tenantID, "SUP001", "Supplier 1", "manufacturer", "active", decimal.Zero, decimal.Zero, 30, 5).
 			AddRow(id2, tenantID, "SUP002", "Supplier 2", "distributor", "active", decimal.Zero, decimal.Zero, 45, 4)
 
-		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE tenant_id = \$1 AND id IN \(\$2,\$3\)`).
+		mock.ExpectQuery(`SELECT \* FROM "suppliers" WHERE \(tenant_id = \$1 AND id IN \(\$2,\$3\)\)`).
 			WithArgs(tenantID, id1, id2).
 			WillReturnRows(rows)
 
@@ -283,8 +283,8 @@ func TestGormSupplierRepository_Delete(t *testing.T) {
 
 		supplierID := uuid.New()
 
-		mock.ExpectExec(`DELETE FROM "suppliers" WHERE id = \$1`).
-			WithArgs(supplierID).
+		mock.ExpectExec(`UPDATE "suppliers" SET "deleted_at"=\$1 WHERE id = \$2 AND "suppliers"\."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), supplierID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.Delete(context.Background(), supplierID)
@@ -299,8 +299,8 @@ func TestGormSupplierRepository_Delete(t *testing.T) {
 
 		supplierID := uuid.New()
 
-		mock.ExpectExec(`DELETE FROM "suppliers" WHERE id = \$1`).
-			WithArgs(supplierID).
+		mock.ExpectExec(`UPDATE "suppliers" SET "deleted_at"=\$1 WHERE id = \$2 AND "suppliers"\."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), supplierID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
 		err := repo.Delete(context.Background(), supplierID)
@@ -319,8 +319,8 @@ func TestGormSupplierRepository_DeleteForTenant(t *testing.T) {
 		tenantID := uuid.New()
 		supplierID := uuid.New()
 
-		mock.ExpectExec(`DELETE FROM "suppliers" WHERE tenant_id = \$1 AND id = \$2`).
-			WithArgs(tenantID, supplierID).
+		mock.ExpectExec(`UPDATE "suppliers" SET "deleted_at"=\$1 WHERE \(tenant_id = \$2 AND id = \$3\) AND "suppliers"\."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), tenantID, supplierID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err := repo.DeleteForTenant(context.Background(), tenantID, supplierID)
@@ -372,7 +372,7 @@ func TestGormSupplierRepository_CountByType(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE tenant_id = \$1 AND type = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE \(tenant_id = \$1 AND type = \$2\)`).
 			WithArgs(tenantID, partner.SupplierTypeManufacturer).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
 
@@ -391,7 +391,7 @@ func TestGormSupplierRepository_CountByStatus(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE tenant_id = \$1 AND status = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE \(tenant_id = \$1 AND status = \$2\)`).
 			WithArgs(tenantID, partner.SupplierStatusActive).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
 
@@ -410,7 +410,7 @@ func TestGormSupplierRepository_ExistsByCode(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE tenant_id = \$1 AND code = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE \(tenant_id = \$1 AND code = \$2\)`).
 			WithArgs(tenantID, "SUP001").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
@@ -427,7 +427,7 @@ func TestGormSupplierRepository_ExistsByCode(t *testing.T) {
 
 		tenantID := uuid.New()
 
-		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE tenant_id = \$1 AND code = \$2`).
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "suppliers" WHERE \(tenant_id = \$1 AND code = \$2\)`).
 			WithArgs(tenantID, "NONEXISTENT").
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
@@ -1,6 +1,10 @@
 package tenant
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/erp/backend/internal/infrastructure/logger"
@@ -9,7 +13,42 @@ import (
 	"gorm.io/gorm/clause"
 )
 
-// TenantCallback provides GORM callback hooks for automatic tenant filtering
+// Scoped is implemented by persistence models whose rows each belong to one tenant.
+// The tenant callbacks only filter and guard statements on these models; statements
+// without a model (Table or Raw) are left to the caller.
+type Scoped interface {
+	TenantScoped()
+}
+
+// crossTenantKey is the context key marking a context as allowed to query across tenants
+type crossTenantKey struct{}
+
+// WithCrossTenant returns a context whose queries skip the tenant callbacks.
+// It is the explicit escape hatch for platform administration and background jobs
+// that must read or write rows of every tenant; keep its use easy to grep for.
+func WithCrossTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crossTenantKey{}, true)
+}
+
+// WithTenant returns a context whose queries are scoped to the tenant.
+// Background work done on behalf of one tenant (queued jobs, event handlers)
+// uses it where no request context carries the tenant. It lifts a
+// WithCrossTenant mark, so a worker over all tenants scopes each tenant's work.
+func WithTenant(ctx context.Context, tenantID uuid.UUID) context.Context {
+	ctx = context.WithValue(ctx, crossTenantKey{}, false)
+	return context.WithValue(ctx, logger.TenantIDKey, tenantID.String())
+}
+
+// IsCrossTenant reports whether ctx was marked with WithCrossTenant
+func IsCrossTenant(ctx context.Context) bool {
+	crossTenant, _ := ctx.Value(crossTenantKey{}).(bool)
+	return crossTenant
+}
+
+// TenantCallback provides GORM callback hooks for automatic tenant filtering.
+// Statements on Scoped models that do not filter on the tenant column get
+// tenant_id = <tenant from context> added. With required set, such a statement
+// run without a tenant in context fails instead of touching every tenant's rows.
 type TenantCallback struct {
 	tenantColumn string
 	required     bool
@@ -65,8 +104,8 @@ func (tc *TenantCallback) addTenantFilter(db *gorm.DB) {
 		return
 	}
 
-	// Skip if unscoped
-	if db.Statement.Unscoped {
+	// Only tenant-owned tables are scoped; cross-tenant work must opt out explicitly
+	if !isScopedModel(db.Statement) || IsCrossTenant(db.Statement.Context) {
 		return
 	}
 
@@ -79,7 +118,7 @@ func (tc *TenantCallback) addTenantFilter(db *gorm.DB) {
 	tenantID := logger.GetTenantID(db.Statement.Context)
 	if tenantID == "" {
 		if tc.required {
-			_ = db.AddError(ErrTenantIDRequired)
+			_ = db.AddError(fmt.Errorf("%w: unscoped statement on table %s", ErrTenantIDRequired, db.Statement.Table))
 		}
 		return
 	}
@@ -101,13 +140,17 @@ func (tc *TenantCallback) addTenantFilter(db *gorm.DB) {
 	})
 }
 
-// hasTenantCondition checks if tenant_id condition is already present
-func (tc *TenantCallback) hasTenantCondition(db *gorm.DB) bool {
-	// Check if there's a manual scope applied via Unscoped
-	if db.Statement.Unscoped {
-		return true
+// isScopedModel reports whether the statement runs against a Scoped model
+func isScopedModel(stmt *gorm.Statement) bool {
+	if stmt.Schema == nil {
+		return false
 	}
+	_, ok := reflect.New(stmt.Schema.ModelType).Interface().(Scoped)
+	return ok
+}
 
+// hasTenantCondition checks if tenant_id condition is already present
+func (tc *TenantCallback) hasTenantCondition(db *gorm.DB) bool {
 	// Check existing where clauses for tenant_id
 	if whereClause, ok := db.Statement.Clauses["WHERE"]; ok {
 		if where, ok := whereClause.Expression.(clause.Where); ok {
@@ -121,23 +164,32 @@ func (tc *TenantCallback) hasTenantCondition(db *gorm.DB) bool {
 
 	// Also check the built SQL if available
 	sql := db.Statement.SQL.String()
-	if sql != "" && strings.Contains(sql, tc.tenantColumn) {
+	if sql != "" && tc.conditionScopesTenant(sql) {
 		return true
 	}
 
 	return false
 }
 
-// exprContainsTenant checks if an expression contains tenant_id column
+// exprContainsTenant checks if an expression restricts the rows to given tenants.
+// A condition only counts if every row it matches has one of those tenants, so
+// "tenant_id = ? OR tenant_id IS NULL" or a tenant_id inside a subquery does not.
 func (tc *TenantCallback) exprContainsTenant(expr clause.Expression) bool {
 	switch e := expr.(type) {
 	case clause.Eq:
-		if col, ok := e.Column.(clause.Column); ok {
-			return col.Name == tc.tenantColumn
-		}
+		return e.Value != nil && tc.isTenantColumn(e.Column)
 	case clause.IN:
-		if col, ok := e.Column.(clause.Column); ok {
-			return col.Name == tc.tenantColumn
+		return tc.isTenantColumn(e.Column)
+	case clause.Expr:
+		// Where("tenant_id = ? AND id = ?", ...) as written by the repositories
+		return tc.conditionScopesTenant(e.SQL)
+	case clause.NamedExpr:
+		return tc.conditionScopesTenant(e.SQL)
+	case clause.Where:
+		for _, cond := range e.Exprs {
+			if tc.exprContainsTenant(cond) {
+				return true
+			}
 		}
 	case clause.AndConditions:
 		for _, cond := range e.Exprs {
@@ -146,11 +198,139 @@ func (tc *TenantCallback) exprContainsTenant(expr clause.Expression) bool {
 			}
 		}
 	case clause.OrConditions:
+		// Every alternative must be restricted to the tenant
 		for _, cond := range e.Exprs {
-			if tc.exprContainsTenant(cond) {
-				return true
+			if !tc.exprContainsTenant(cond) {
+				return false
+			}
+		}
+		return len(e.Exprs) > 0
+	}
+	return false
+}
+
+// conditionScopesTenant reports whether a raw SQL condition restricts the rows to given
+// tenants: one of its top-level AND terms compares the tenant column with = or IN, and
+// each alternative of a top-level OR does so
+func (tc *TenantCallback) conditionScopesTenant(sql string) bool {
+	sql = stripEnclosingParens(sql)
+
+	if alternatives := splitTopLevel(sql, "OR"); len(alternatives) > 1 {
+		for _, alternative := range alternatives {
+			if !tc.conditionScopesTenant(alternative) {
+				return false
 			}
 		}
+		return true
+	}
+
+	terms := splitTopLevel(sql, "AND")
+	for _, term := range terms {
+		if len(terms) > 1 && tc.conditionScopesTenant(term) {
+			return true
+		}
+		if tc.tenantComparison().MatchString(topLevelText(term)) {
+			return true
+		}
+	}
+	return false
+}
+
+// tenantComparison matches the tenant column, optionally table-qualified or quoted,
+// compared with = or IN
+func (tc *TenantCallback) tenantComparison() *regexp.Regexp {
+	column := regexp.QuoteMeta(tc.tenantColumn)
+	return regexp.MustCompile(`(?i)(^|[^\w"])(["\w]+\.)?"?` + column + `"?\s*(=|IN\b)`)
+}
+
+// splitTopLevel splits sql on the keyword where it is outside parentheses and quotes
+func splitTopLevel(sql, keyword string) []string {
+	var parts []string
+	depth, start := 0, 0
+	inQuote := false
+	upper := strings.ToUpper(sql)
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(upper[i:], keyword) &&
+			(i == 0 || !isWordChar(sql[i-1])) &&
+			(i+len(keyword) == len(sql) || !isWordChar(sql[i+len(keyword)])):
+			parts = append(parts, sql[start:i])
+			start = i + len(keyword)
+			i += len(keyword) - 1
+		}
+	}
+	return append(parts, sql[start:])
+}
+
+// topLevelText blanks out everything inside parentheses and quotes, so that only
+// the conditions of the term itself can match
+func topLevelText(sql string) string {
+	text := []byte(sql)
+	depth := 0
+	inQuote := false
+	for i, c := range text {
+		switch {
+		case c == '\'':
+			inQuote = !inQuote
+			text[i] = ' '
+		case inQuote:
+			text[i] = ' '
+		case c == '(':
+			depth++
+			text[i] = ' '
+		case c == ')':
+			depth--
+			text[i] = ' '
+		case depth > 0:
+			text[i] = ' '
+		}
+	}
+	return string(text)
+}
+
+// stripEnclosingParens removes parentheses that enclose the whole condition
+func stripEnclosingParens(sql string) string {
+	for {
+		sql = strings.TrimSpace(sql)
+		if len(sql) < 2 || sql[0] != '(' || sql[len(sql)-1] != ')' {
+			return sql
+		}
+		depth := 0
+		for i := 0; i < len(sql)-1; i++ {
+			switch sql[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				// The opening parenthesis closes before the end: "(a) AND (b)"
+				return sql
+			}
+		}
+		sql = sql[1 : len(sql)-1]
+	}
+}
+
+// isWordChar reports whether c can be part of an SQL identifier
+func isWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isTenantColumn reports whether a condition column is the tenant column
+func (tc *TenantCallback) isTenantColumn(column any) bool {
+	switch col := column.(type) {
+	case clause.Column:
+		return col.Name == tc.tenantColumn
+	case string:
+		return col == tc.tenantColumn || strings.HasSuffix(col, "."+tc.tenantColumn)
 	}
 	return false
 }
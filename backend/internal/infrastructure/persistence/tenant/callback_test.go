@@ -7,6 +7,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/erp/backend/internal/infrastructure/logger"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/postgres"
@@ -123,6 +124,175 @@ func TestTenantCallback_NotRequired(t *testing.T) {
 	})
 }
 
+// sharedModel has a tenant column but is not Scoped, like tables holding rows shared by tenants
+type sharedModel struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID uuid.UUID `gorm:"type:uuid"`
+}
+
+func (sharedModel) TableName() string {
+	return "shared_models"
+}
+
+func TestTenantCallback_ScopesForgottenFilter(t *testing.T) {
+	tenantID := uuid.New()
+
+	t.Run("query without tenant filter is scoped to the context tenant", func(t *testing.T) {
+		db, mock, mockDB := setupCallbackMockDB(t)
+		defer mockDB.Close()
+		EnableAutoTenantFilter(db, true)
+
+		mock.ExpectQuery(`SELECT \* FROM "test_models" WHERE name = \$1 AND "test_models"\."tenant_id" = \$2`).
+			WithArgs("Widget", tenantID.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+		var results []TestModel
+		err := db.WithContext(createCallbackTestContext(tenantID.String())).Where("name = ?", "Widget").Find(&results).Error
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update by primary key is scoped to the context tenant", func(t *testing.T) {
+		db, mock, mockDB := setupCallbackMockDB(t)
+		defer mockDB.Close()
+		EnableAutoTenantFilter(db, true)
+
+		id := uuid.New()
+		mock.ExpectExec(`UPDATE "test_models" SET "name"=\$1 WHERE "test_models"\."tenant_id" = \$2 AND "id" = \$3`).
+			WithArgs("Renamed", tenantID.String(), id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := db.WithContext(createCallbackTestContext(tenantID.String())).Model(&TestModel{ID: id}).Update("name", "Renamed").Error
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("explicit tenant filter is left as written", func(t *testing.T) {
+		db, mock, mockDB := setupCallbackMockDB(t)
+		defer mockDB.Close()
+		EnableAutoTenantFilter(db, true)
+
+		otherTenantID := uuid.New()
+		mock.ExpectQuery(`SELECT \* FROM "test_models" WHERE tenant_id = \$1 AND name = \$2$`).
+			WithArgs(otherTenantID, "Widget").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+		var results []TestModel
+		err := db.WithContext(createCallbackTestContext(tenantID.String())).
+			Where("tenant_id = ? AND name = ?", otherTenantID, "Widget").Find(&results).Error
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("tenant filter that also admits other rows is still scoped", func(t *testing.T) {
+		db, mock, mockDB := setupCallbackMockDB(t)
+		defer mockDB.Close()
+		EnableAutoTenantFilter(db, true)
+
+		mock.ExpectQuery(`SELECT \* FROM "test_models" WHERE \(tenant_id = \$1 OR tenant_id IS NULL\) AND "test_models"\."tenant_id" = \$2`).
+			WithArgs(tenantID, tenantID.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+		var results []TestModel
+		err := db.WithContext(createCallbackTestContext(tenantID.String())).
+			Where("tenant_id = ? OR tenant_id IS NULL", tenantID).Find(&results).Error
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("tenant filter inside a subquery is still scoped", func(t *testing.T) {
+		db, mock, mockDB := setupCallbackMockDB(t)
+		defer mockDB.Close()
+		EnableAutoTenantFilter(db, true)
+
+		mock.ExpectQuery(`SELECT \* FROM "test_models" WHERE id IN \(SELECT model_id FROM links WHERE tenant_id = \$1\) AND "test_models"\."tenant_id" = \$2`).
+			WithArgs(tenantID, tenantID.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+		var results []TestModel
+		err := db.WithContext(createCallbackTestContext(tenantID.String())).
+			Where("id IN (SELECT model_id FROM links WHERE tenant_id = ?)", tenantID).Find(&results).Error
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unscoped for soft deletes does not bypass the tenant filter", func(t *testing.T) {
+		db, mock, mockDB := setupCallbackMockDB(t)
+		defer mockDB.Close()
+		EnableAutoTenantFilter(db, true)
+
+		mock.ExpectQuery(`SELECT \* FROM "test_models" WHERE "test_models"\."tenant_id" = \$1`).
+			WithArgs(tenantID.String()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+		var results []TestModel
+		err := db.WithContext(createCallbackTestContext(tenantID.String())).Unscoped().Find(&results).Error
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("models that are not Scoped are untouched", func(t *testing.T) {
+		db, mock, mockDB := setupCallbackMockDB(t)
+		defer mockDB.Close()
+		EnableAutoTenantFilter(db, true)
+
+		mock.ExpectQuery(`SELECT \* FROM "shared_models"$`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id"}))
+
+		var results []sharedModel
+		err := db.WithContext(context.Background()).Find(&results).Error
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTenantCallback_MissingTenantFailsBeforeQuerying(t *testing.T) {
+	db, mock, mockDB := setupCallbackMockDB(t)
+	defer mockDB.Close()
+	EnableAutoTenantFilter(db, true)
+
+	var results []TestModel
+	err := db.WithContext(context.Background()).Where("name = ?", "Widget").Find(&results).Error
+
+	assert.ErrorIs(t, err, ErrTenantIDRequired)
+	assert.Contains(t, err.Error(), "test_models")
+	// No statement reached the database
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallback_CrossTenantEscapeHatch(t *testing.T) {
+	db, mock, mockDB := setupCallbackMockDB(t)
+	defer mockDB.Close()
+	EnableAutoTenantFilter(db, true)
+
+	mock.ExpectQuery(`SELECT \* FROM "test_models"$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+	mock.ExpectQuery(`SELECT \* FROM "test_models"$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+	var results []TestModel
+
+	// Without a request tenant, e.g. a background job over all tenants
+	err := db.WithContext(WithCrossTenant(context.Background())).Find(&results).Error
+	require.NoError(t, err)
+
+	// With a request tenant, e.g. a platform admin query
+	ctx := WithCrossTenant(createCallbackTestContext(uuid.New().String()))
+	err = db.WithContext(ctx).Find(&results).Error
+	require.NoError(t, err)
+
+	assert.True(t, IsCrossTenant(ctx))
+	assert.False(t, IsCrossTenant(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func createCallbackTestContext(tenantID string) context.Context {
 	ctx := context.Background()
 	if tenantID != "" {
@@ -131,3 +301,50 @@ func createCallbackTestContext(tenantID string) context.Context {
 	}
 	return ctx
 }
+
+func TestWithTenant(t *testing.T) {
+	db, mock, mockDB := setupCallbackMockDB(t)
+	defer mockDB.Close()
+	EnableAutoTenantFilter(db, true)
+
+	tenantID := uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "test_models" WHERE "test_models"\."tenant_id" = \$1`).
+		WithArgs(tenantID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+	var results []TestModel
+	// Work for one tenant started by a worker over all tenants
+	err := db.WithContext(WithTenant(WithCrossTenant(context.Background()), tenantID)).Find(&results).Error
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantCallback_ConditionScopesTenant(t *testing.T) {
+	tc := NewTenantCallback("", true)
+
+	tests := []struct {
+		condition string
+		scoped    bool
+	}{
+		{"tenant_id = ?", true},
+		{"tenant_id = ? AND id = ?", true},
+		{`"orders"."tenant_id" = ? AND status = ?`, true},
+		{"o.tenant_id IN ?", true},
+		{"(tenant_id = ? AND id = ?)", true},
+		{"(tenant_id = ? OR tenant_id = ?) AND id = ?", true},
+		{"tenant_id = ? OR tenant_id IS NULL", false},
+		{"id = ? OR tenant_id = ?", false},
+		{"tenant_id IS NULL", false},
+		{"tenant_id <> ?", false},
+		{"parent_tenant_id = ?", false},
+		{"id IN (SELECT model_id FROM links WHERE tenant_id = ?)", false},
+		{"name = 'tenant_id = 1'", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			assert.Equal(t, tt.scoped, tc.conditionScopesTenant(tt.condition))
+		})
+	}
+}
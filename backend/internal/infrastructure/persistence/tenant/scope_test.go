@@ -25,6 +25,8 @@ func (TestModel) TableName() string {
 	return "test_models"
 }
 
+func (TestModel) TenantScoped() {}
+
 func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, *sql.DB) {
 	mockDB, mock, err := sqlmock.New()
 	require.NoError(t, err)
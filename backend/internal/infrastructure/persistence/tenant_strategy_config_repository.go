@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormTenantStrategyConfigRepository implements TenantStrategyConfigRepository using GORM
+type GormTenantStrategyConfigRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTenantStrategyConfigRepository creates a new GormTenantStrategyConfigRepository
+func NewGormTenantStrategyConfigRepository(db *gorm.DB) *GormTenantStrategyConfigRepository {
+	return &GormTenantStrategyConfigRepository{db: db}
+}
+
+// FindByTenantID finds the strategy configuration of a tenant
+func (r *GormTenantStrategyConfigRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) (*strategy.TenantStrategyConfig, error) {
+	var model models.TenantStrategyConfigModel
+	if err := r.db.WithContext(ctx).First(&model, "tenant_id = ?", tenantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// Save creates or replaces the strategy configuration of a tenant
+func (r *GormTenantStrategyConfigRepository) Save(ctx context.Context, config *strategy.TenantStrategyConfig) error {
+	model := models.TenantStrategyConfigModelFromDomain(config)
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"cost_strategy",
+			"pricing_strategy",
+			"allocation_strategy",
+			"reconciliation_strategy",
+			"updated_at",
+		}),
+	}).Create(model).Error
+}
@@ -0,0 +1,6 @@
+package persistence
+
+// Tracing decorators for the repositories in this package are generated into
+// traced_repositories_gen.go. Re-run go generate after adding or changing a repository method.
+//
+//go:generate go run ./internal/tracegen
@@ -0,0 +1,8276 @@
+// Code generated by tracegen; DO NOT EDIT.
+
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp/backend/internal/domain/billing"
+	"github.com/erp/backend/internal/domain/bulk"
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/erp/backend/internal/domain/featureflag"
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/printing"
+	"github.com/erp/backend/internal/domain/report"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/erp/backend/internal/domain/trade"
+	infraBilling "github.com/erp/backend/internal/infrastructure/billing"
+	"github.com/erp/backend/internal/infrastructure/telemetry"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// TracedGormAccountPayableRepository records a span for each GormAccountPayableRepository call that takes a context
+type TracedGormAccountPayableRepository struct {
+	next *GormAccountPayableRepository
+}
+
+// NewTracedGormAccountPayableRepository wraps next with repository tracing
+func NewTracedGormAccountPayableRepository(next *GormAccountPayableRepository) *TracedGormAccountPayableRepository {
+	return &TracedGormAccountPayableRepository{next: next}
+}
+
+func (r *TracedGormAccountPayableRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.PayableStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) CountBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "CountBySupplier", tenantID.String())
+	r0, r1 := r.next.CountBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.AccountPayableFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) CountOutstandingBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountOutstandingBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "CountOutstandingBySupplier", tenantID.String())
+	r0, r1 := r.next.CountOutstandingBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) CountOverdue(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountOverdue(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "CountOverdue", tenantID.String())
+	r0, r1 := r.next.CountOverdue(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountPayableRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountPayableRepository) ExistsByPayableNumber(ctx context.Context, tenantID uuid.UUID, payableNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByPayableNumber(ctx, tenantID, payableNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "ExistsByPayableNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByPayableNumber(ctx, tenantID, payableNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) ExistsBySource(ctx context.Context, tenantID uuid.UUID, sourceType finance.PayableSourceType, sourceID uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsBySource(ctx, tenantID, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "ExistsBySource", tenantID.String())
+	r0, r1 := r.next.ExistsBySource(ctx, tenantID, sourceType, sourceID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAgingEntries(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindAgingEntries", tenantID.String())
+	r0, r1 := r.next.FindAgingEntries(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.AccountPayableFilter) ([]finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindByPayableNumber(ctx context.Context, tenantID uuid.UUID, payableNumber string) (*finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPayableNumber(ctx, tenantID, payableNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindByPayableNumber", tenantID.String())
+	r0, r1 := r.next.FindByPayableNumber(ctx, tenantID, payableNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindBySource(ctx context.Context, tenantID uuid.UUID, sourceType finance.PayableSourceType, sourceID uuid.UUID) (*finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySource(ctx, tenantID, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindBySource", tenantID.String())
+	r0, r1 := r.next.FindBySource(ctx, tenantID, sourceType, sourceID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.PayableStatus, filter finance.AccountPayableFilter) ([]finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID, filter finance.AccountPayableFilter) ([]finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindBySupplier", tenantID.String())
+	r0, r1 := r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindOutstanding(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) ([]finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindOutstanding(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindOutstanding", tenantID.String())
+	r0, r1 := r.next.FindOutstanding(ctx, tenantID, supplierID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) FindOverdue(ctx context.Context, tenantID uuid.UUID, filter finance.AccountPayableFilter) ([]finance.AccountPayable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindOverdue(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "FindOverdue", tenantID.String())
+	r0, r1 := r.next.FindOverdue(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) GeneratePayableNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GeneratePayableNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "GeneratePayableNumber", tenantID.String())
+	r0, r1 := r.next.GeneratePayableNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) Save(ctx context.Context, payable *finance.AccountPayable) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, payable)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "Save", "")
+	r0 := r.next.Save(ctx, payable)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountPayableRepository) SaveWithLock(ctx context.Context, payable *finance.AccountPayable) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, payable)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, payable)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountPayableRepository) SumOutstandingBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumOutstandingBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "SumOutstandingBySupplier", tenantID.String())
+	r0, r1 := r.next.SumOutstandingBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) SumOutstandingForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumOutstandingForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "SumOutstandingForTenant", tenantID.String())
+	r0, r1 := r.next.SumOutstandingForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) SumOverdueForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumOverdueForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "SumOverdueForTenant", tenantID.String())
+	r0, r1 := r.next.SumOverdueForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountPayableRepository) UpdateSupplierName(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID, supplierName string) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.UpdateSupplierName(ctx, tenantID, supplierID, supplierName)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountPayableRepository", "UpdateSupplierName", tenantID.String())
+	r0, r1 := r.next.UpdateSupplierName(ctx, tenantID, supplierID, supplierName)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormAccountReceivableRepository records a span for each GormAccountReceivableRepository call that takes a context
+type TracedGormAccountReceivableRepository struct {
+	next *GormAccountReceivableRepository
+}
+
+// NewTracedGormAccountReceivableRepository wraps next with repository tracing
+func NewTracedGormAccountReceivableRepository(next *GormAccountReceivableRepository) *TracedGormAccountReceivableRepository {
+	return &TracedGormAccountReceivableRepository{next: next}
+}
+
+func (r *TracedGormAccountReceivableRepository) CountByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "CountByCustomer", tenantID.String())
+	r0, r1 := r.next.CountByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.ReceivableStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.AccountReceivableFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) CountOutstandingByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountOutstandingByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "CountOutstandingByCustomer", tenantID.String())
+	r0, r1 := r.next.CountOutstandingByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) CountOverdue(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountOverdue(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "CountOverdue", tenantID.String())
+	r0, r1 := r.next.CountOverdue(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountReceivableRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountReceivableRepository) ExistsByReceivableNumber(ctx context.Context, tenantID uuid.UUID, receivableNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByReceivableNumber(ctx, tenantID, receivableNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "ExistsByReceivableNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByReceivableNumber(ctx, tenantID, receivableNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) ExistsBySource(ctx context.Context, tenantID uuid.UUID, sourceType finance.SourceType, sourceID uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsBySource(ctx, tenantID, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "ExistsBySource", tenantID.String())
+	r0, r1 := r.next.ExistsBySource(ctx, tenantID, sourceType, sourceID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindAgingEntries(ctx context.Context, tenantID uuid.UUID) ([]finance.AgingEntry, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAgingEntries(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindAgingEntries", tenantID.String())
+	r0, r1 := r.next.FindAgingEntries(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.AccountReceivableFilter) ([]finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, filter finance.AccountReceivableFilter) ([]finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindByCustomer", tenantID.String())
+	r0, r1 := r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindByReceivableNumber(ctx context.Context, tenantID uuid.UUID, receivableNumber string) (*finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByReceivableNumber(ctx, tenantID, receivableNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindByReceivableNumber", tenantID.String())
+	r0, r1 := r.next.FindByReceivableNumber(ctx, tenantID, receivableNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindBySource(ctx context.Context, tenantID uuid.UUID, sourceType finance.SourceType, sourceID uuid.UUID) (*finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySource(ctx, tenantID, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindBySource", tenantID.String())
+	r0, r1 := r.next.FindBySource(ctx, tenantID, sourceType, sourceID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.ReceivableStatus, filter finance.AccountReceivableFilter) ([]finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindDueForReminder(ctx context.Context, dueBefore time.Time) ([]finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDueForReminder(ctx, dueBefore)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindDueForReminder", "")
+	r0, r1 := r.next.FindDueForReminder(ctx, dueBefore)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindOutstanding(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) ([]finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindOutstanding(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindOutstanding", tenantID.String())
+	r0, r1 := r.next.FindOutstanding(ctx, tenantID, customerID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) FindOverdue(ctx context.Context, tenantID uuid.UUID, filter finance.AccountReceivableFilter) ([]finance.AccountReceivable, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindOverdue(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "FindOverdue", tenantID.String())
+	r0, r1 := r.next.FindOverdue(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) GenerateReceivableNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateReceivableNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "GenerateReceivableNumber", tenantID.String())
+	r0, r1 := r.next.GenerateReceivableNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) ListPaymentRecords(ctx context.Context, tenantID uuid.UUID, receivableID uuid.UUID, page int, pageSize int) ([]finance.ReceivablePaymentRecord, int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ListPaymentRecords(ctx, tenantID, receivableID, page, pageSize)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "ListPaymentRecords", tenantID.String())
+	r0, r1, r2 := r.next.ListPaymentRecords(ctx, tenantID, receivableID, page, pageSize)
+	span.SetRowCount(len(r0))
+	span.End(r2)
+	return r0, r1, r2
+}
+
+func (r *TracedGormAccountReceivableRepository) Save(ctx context.Context, receivable *finance.AccountReceivable) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, receivable)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "Save", "")
+	r0 := r.next.Save(ctx, receivable)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountReceivableRepository) SaveWithLock(ctx context.Context, receivable *finance.AccountReceivable) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, receivable)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, receivable)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormAccountReceivableRepository) SumOutstandingByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumOutstandingByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "SumOutstandingByCustomer", tenantID.String())
+	r0, r1 := r.next.SumOutstandingByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) SumOutstandingByCustomerByCurrency(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (map[valueobject.Currency]decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumOutstandingByCustomerByCurrency(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "SumOutstandingByCustomerByCurrency", tenantID.String())
+	r0, r1 := r.next.SumOutstandingByCustomerByCurrency(ctx, tenantID, customerID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) SumOutstandingForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumOutstandingForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "SumOutstandingForTenant", tenantID.String())
+	r0, r1 := r.next.SumOutstandingForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) SumOverdueForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumOverdueForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "SumOverdueForTenant", tenantID.String())
+	r0, r1 := r.next.SumOverdueForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormAccountReceivableRepository) SumWrittenOffForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumWrittenOffForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "AccountReceivableRepository", "SumWrittenOffForTenant", tenantID.String())
+	r0, r1 := r.next.SumWrittenOffForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormBalanceTransactionRepository records a span for each GormBalanceTransactionRepository call that takes a context
+type TracedGormBalanceTransactionRepository struct {
+	next *GormBalanceTransactionRepository
+}
+
+// NewTracedGormBalanceTransactionRepository wraps next with repository tracing
+func NewTracedGormBalanceTransactionRepository(next *GormBalanceTransactionRepository) *TracedGormBalanceTransactionRepository {
+	return &TracedGormBalanceTransactionRepository{next: next}
+}
+
+func (r *TracedGormBalanceTransactionRepository) Create(ctx context.Context, transaction *partner.BalanceTransaction) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, transaction)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "BalanceTransactionRepository", "Create", "")
+	r0 := r.next.Create(ctx, transaction)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormBalanceTransactionRepository) FindByCustomerID(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, filter partner.BalanceTransactionFilter) ([]*partner.BalanceTransaction, int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCustomerID(ctx, tenantID, customerID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "BalanceTransactionRepository", "FindByCustomerID", tenantID.String())
+	r0, r1, r2 := r.next.FindByCustomerID(ctx, tenantID, customerID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r2)
+	return r0, r1, r2
+}
+
+func (r *TracedGormBalanceTransactionRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.BalanceTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "BalanceTransactionRepository", "FindByID", tenantID.String())
+	r0, r1 := r.next.FindByID(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormBalanceTransactionRepository) FindBySourceID(ctx context.Context, tenantID uuid.UUID, sourceType partner.BalanceTransactionSourceType, sourceID string) ([]*partner.BalanceTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySourceID(ctx, tenantID, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "BalanceTransactionRepository", "FindBySourceID", tenantID.String())
+	r0, r1 := r.next.FindBySourceID(ctx, tenantID, sourceType, sourceID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormBalanceTransactionRepository) GetLatestByCustomerID(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (*partner.BalanceTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetLatestByCustomerID(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "BalanceTransactionRepository", "GetLatestByCustomerID", tenantID.String())
+	r0, r1 := r.next.GetLatestByCustomerID(ctx, tenantID, customerID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormBalanceTransactionRepository) List(ctx context.Context, tenantID uuid.UUID, filter partner.BalanceTransactionFilter) ([]*partner.BalanceTransaction, int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.List(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "BalanceTransactionRepository", "List", tenantID.String())
+	r0, r1, r2 := r.next.List(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r2)
+	return r0, r1, r2
+}
+
+func (r *TracedGormBalanceTransactionRepository) SumByCustomerIDAndType(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, txType partner.BalanceTransactionType, from time.Time, to time.Time) (float64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumByCustomerIDAndType(ctx, tenantID, customerID, txType, from, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "BalanceTransactionRepository", "SumByCustomerIDAndType", tenantID.String())
+	r0, r1 := r.next.SumByCustomerIDAndType(ctx, tenantID, customerID, txType, from, to)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormCategoryRepository records a span for each GormCategoryRepository call that takes a context
+type TracedGormCategoryRepository struct {
+	next *GormCategoryRepository
+}
+
+// NewTracedGormCategoryRepository wraps next with repository tracing
+func NewTracedGormCategoryRepository(next *GormCategoryRepository) *TracedGormCategoryRepository {
+	return &TracedGormCategoryRepository{next: next}
+}
+
+func (r *TracedGormCategoryRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCategoryRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCategoryRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindAll(ctx context.Context, filter shared.Filter) ([]catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindByPath(ctx context.Context, tenantID uuid.UUID, path string) (*catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPath(ctx, tenantID, path)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindByPath", tenantID.String())
+	r0, r1 := r.next.FindByPath(ctx, tenantID, path)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindChildren(ctx context.Context, tenantID uuid.UUID, parentID uuid.UUID) ([]catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindChildren(ctx, tenantID, parentID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindChildren", tenantID.String())
+	r0, r1 := r.next.FindChildren(ctx, tenantID, parentID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindDescendants(ctx context.Context, tenantID uuid.UUID, categoryID uuid.UUID) ([]catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDescendants(ctx, tenantID, categoryID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindDescendants", tenantID.String())
+	r0, r1 := r.next.FindDescendants(ctx, tenantID, categoryID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) FindRootCategories(ctx context.Context, tenantID uuid.UUID) ([]catalog.Category, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindRootCategories(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "FindRootCategories", tenantID.String())
+	r0, r1 := r.next.FindRootCategories(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) HasChildren(ctx context.Context, tenantID uuid.UUID, categoryID uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.HasChildren(ctx, tenantID, categoryID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "HasChildren", tenantID.String())
+	r0, r1 := r.next.HasChildren(ctx, tenantID, categoryID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) HasProducts(ctx context.Context, tenantID uuid.UUID, categoryID uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.HasProducts(ctx, tenantID, categoryID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "HasProducts", tenantID.String())
+	r0, r1 := r.next.HasProducts(ctx, tenantID, categoryID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCategoryRepository) Save(ctx context.Context, category *catalog.Category) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, category)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "Save", "")
+	r0 := r.next.Save(ctx, category)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCategoryRepository) UpdatePath(ctx context.Context, tenantID uuid.UUID, categoryID uuid.UUID, parentID *uuid.UUID, newPath string, levelDelta int) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.UpdatePath(ctx, tenantID, categoryID, parentID, newPath, levelDelta)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CategoryRepository", "UpdatePath", tenantID.String())
+	r0 := r.next.UpdatePath(ctx, tenantID, categoryID, parentID, newPath, levelDelta)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormCustomerLevelRepository records a span for each GormCustomerLevelRepository call that takes a context
+type TracedGormCustomerLevelRepository struct {
+	next *GormCustomerLevelRepository
+}
+
+// NewTracedGormCustomerLevelRepository wraps next with repository tracing
+func NewTracedGormCustomerLevelRepository(next *GormCustomerLevelRepository) *TracedGormCustomerLevelRepository {
+	return &TracedGormCustomerLevelRepository{next: next}
+}
+
+func (r *TracedGormCustomerLevelRepository) CountCustomersByLevelCodes(ctx context.Context, tenantID uuid.UUID, codes []string) (map[string]int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountCustomersByLevelCodes(ctx, tenantID, codes)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "CountCustomersByLevelCodes", tenantID.String())
+	r0, r1 := r.next.CountCustomersByLevelCodes(ctx, tenantID, codes)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) CountCustomersWithLevel(ctx context.Context, tenantID uuid.UUID, levelCode string) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountCustomersWithLevel(ctx, tenantID, levelCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "CountCustomersWithLevel", tenantID.String())
+	r0, r1 := r.next.CountCustomersWithLevel(ctx, tenantID, levelCode)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerLevelRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerLevelRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) FindActiveForTenant(ctx context.Context, tenantID uuid.UUID) ([]*partner.CustomerLevelRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActiveForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "FindActiveForTenant", tenantID.String())
+	r0, r1 := r.next.FindActiveForTenant(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID) ([]*partner.CustomerLevelRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.CustomerLevelRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) FindByID(ctx context.Context, id uuid.UUID) (*partner.CustomerLevelRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.CustomerLevelRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) FindDefaultForTenant(ctx context.Context, tenantID uuid.UUID) (*partner.CustomerLevelRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDefaultForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "FindDefaultForTenant", tenantID.String())
+	r0, r1 := r.next.FindDefaultForTenant(ctx, tenantID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerLevelRepository) InitializeDefaultLevels(ctx context.Context, tenantID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.InitializeDefaultLevels(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "InitializeDefaultLevels", tenantID.String())
+	r0 := r.next.InitializeDefaultLevels(ctx, tenantID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerLevelRepository) Save(ctx context.Context, record *partner.CustomerLevelRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, record)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerLevelRepository", "Save", "")
+	r0 := r.next.Save(ctx, record)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormCustomerReferenceRepository records a span for each GormCustomerReferenceRepository call that takes a context
+type TracedGormCustomerReferenceRepository struct {
+	next *GormCustomerReferenceRepository
+}
+
+// NewTracedGormCustomerReferenceRepository wraps next with repository tracing
+func NewTracedGormCustomerReferenceRepository(next *GormCustomerReferenceRepository) *TracedGormCustomerReferenceRepository {
+	return &TracedGormCustomerReferenceRepository{next: next}
+}
+
+func (r *TracedGormCustomerReferenceRepository) ReassignCustomer(ctx context.Context, tenantID uuid.UUID, fromID uuid.UUID, to *partner.Customer) (partner.CustomerReassignment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ReassignCustomer(ctx, tenantID, fromID, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerReferenceRepository", "ReassignCustomer", tenantID.String())
+	r0, r1 := r.next.ReassignCustomer(ctx, tenantID, fromID, to)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormCustomerRepository records a span for each GormCustomerRepository call that takes a context
+type TracedGormCustomerRepository struct {
+	next *GormCustomerRepository
+}
+
+// NewTracedGormCustomerRepository wraps next with repository tracing
+func NewTracedGormCustomerRepository(next *GormCustomerRepository) *TracedGormCustomerRepository {
+	return &TracedGormCustomerRepository{next: next}
+}
+
+func (r *TracedGormCustomerRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) CountByLevel(ctx context.Context, tenantID uuid.UUID, level partner.CustomerLevel) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByLevel(ctx, tenantID, level)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "CountByLevel", tenantID.String())
+	r0, r1 := r.next.CountByLevel(ctx, tenantID, level)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status partner.CustomerStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) CountByType(ctx context.Context, tenantID uuid.UUID, customerType partner.CustomerType) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByType(ctx, tenantID, customerType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "CountByType", tenantID.String())
+	r0, r1 := r.next.CountByType(ctx, tenantID, customerType)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) ExistsByEmail(ctx context.Context, tenantID uuid.UUID, email string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByEmail(ctx, tenantID, email)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "ExistsByEmail", tenantID.String())
+	r0, r1 := r.next.ExistsByEmail(ctx, tenantID, email)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) ExistsByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByPhone(ctx, tenantID, phone)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "ExistsByPhone", tenantID.String())
+	r0, r1 := r.next.ExistsByPhone(ctx, tenantID, phone)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindActive(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActive(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindActive", tenantID.String())
+	r0, r1 := r.next.FindActive(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindAll(ctx context.Context, filter shared.Filter) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCodes(ctx, tenantID, codes)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByCodes", tenantID.String())
+	r0, r1 := r.next.FindByCodes(ctx, tenantID, codes)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByEmail(ctx, tenantID, email)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByEmail", tenantID.String())
+	r0, r1 := r.next.FindByEmail(ctx, tenantID, email)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByID(ctx context.Context, id uuid.UUID) (*partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, tenantID, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByIDs", tenantID.String())
+	r0, r1 := r.next.FindByIDs(ctx, tenantID, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByLevel(ctx context.Context, tenantID uuid.UUID, level partner.CustomerLevel, filter shared.Filter) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByLevel(ctx, tenantID, level, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByLevel", tenantID.String())
+	r0, r1 := r.next.FindByLevel(ctx, tenantID, level, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPhone(ctx, tenantID, phone)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByPhone", tenantID.String())
+	r0, r1 := r.next.FindByPhone(ctx, tenantID, phone)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status partner.CustomerStatus, filter shared.Filter) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindByType(ctx context.Context, tenantID uuid.UUID, customerType partner.CustomerType, filter shared.Filter) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByType(ctx, tenantID, customerType, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindByType", tenantID.String())
+	r0, r1 := r.next.FindByType(ctx, tenantID, customerType, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDeletedByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindDeletedByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindDeletedByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindWithPositiveBalance(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindWithPositiveBalance(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindWithPositiveBalance", tenantID.String())
+	r0, r1 := r.next.FindWithPositiveBalance(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) FindLevelPeriodEnded(ctx context.Context, asOf time.Time) ([]partner.Customer, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindLevelPeriodEnded(ctx, asOf)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "FindLevelPeriodEnded", "")
+	r0, r1 := r.next.FindLevelPeriodEnded(ctx, asOf)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormCustomerRepository) RestoreForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.RestoreForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "RestoreForTenant", tenantID.String())
+	r0 := r.next.RestoreForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerRepository) Save(ctx context.Context, customer *partner.Customer) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, customer)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "Save", "")
+	r0 := r.next.Save(ctx, customer)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerRepository) SaveBatch(ctx context.Context, customers []*partner.Customer) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, customers)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, customers)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormCustomerRepository) SaveWithLock(ctx context.Context, customer *partner.Customer) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, customer)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "CustomerRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, customer)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormDailySalesFactRepository records a span for each GormDailySalesFactRepository call that takes a context
+type TracedGormDailySalesFactRepository struct {
+	next *GormDailySalesFactRepository
+}
+
+// NewTracedGormDailySalesFactRepository wraps next with repository tracing
+func NewTracedGormDailySalesFactRepository(next *GormDailySalesFactRepository) *TracedGormDailySalesFactRepository {
+	return &TracedGormDailySalesFactRepository{next: next}
+}
+
+func (r *TracedGormDailySalesFactRepository) GetCompletedOrderLines(tenantID uuid.UUID, start time.Time, end time.Time) ([]report.CompletedOrderLine, error) {
+	return r.next.GetCompletedOrderLines(tenantID, start, end)
+}
+
+func (r *TracedGormDailySalesFactRepository) GetTenantIDsWithCompletedOrders(start time.Time, end time.Time) ([]uuid.UUID, error) {
+	return r.next.GetTenantIDsWithCompletedOrders(start, end)
+}
+
+func (r *TracedGormDailySalesFactRepository) ReplaceDailySalesFacts(tenantID uuid.UUID, start time.Time, end time.Time, facts []report.DailySalesFact) error {
+	return r.next.ReplaceDailySalesFacts(tenantID, start, end, facts)
+}
+
+// TracedGormDepartmentRepository records a span for each GormDepartmentRepository call that takes a context
+type TracedGormDepartmentRepository struct {
+	next *GormDepartmentRepository
+}
+
+// NewTracedGormDepartmentRepository wraps next with repository tracing
+func NewTracedGormDepartmentRepository(next *GormDepartmentRepository) *TracedGormDepartmentRepository {
+	return &TracedGormDepartmentRepository{next: next}
+}
+
+func (r *TracedGormDepartmentRepository) CountByTenantID(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByTenantID(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "CountByTenantID", tenantID.String())
+	r0, r1 := r.next.CountByTenantID(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) Create(ctx context.Context, dept *identity.Department) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, dept)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "Create", "")
+	r0 := r.next.Create(ctx, dept)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormDepartmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormDepartmentRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindByIDs", "")
+	r0, r1 := r.next.FindByIDs(ctx, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindByManagerID(ctx context.Context, managerID uuid.UUID) ([]*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByManagerID(ctx, managerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindByManagerID", "")
+	r0, r1 := r.next.FindByManagerID(ctx, managerID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenantID(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindByTenantID", tenantID.String())
+	r0, r1 := r.next.FindByTenantID(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindChildren(ctx context.Context, parentID uuid.UUID) ([]*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindChildren(ctx, parentID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindChildren", "")
+	r0, r1 := r.next.FindChildren(ctx, parentID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindDescendants(ctx context.Context, dept *identity.Department) ([]*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDescendants(ctx, dept)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindDescendants", "")
+	r0, r1 := r.next.FindDescendants(ctx, dept)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) FindRootDepartments(ctx context.Context, tenantID uuid.UUID) ([]*identity.Department, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindRootDepartments(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "FindRootDepartments", tenantID.String())
+	r0, r1 := r.next.FindRootDepartments(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) GetAllDepartmentIDsInSubtree(ctx context.Context, departmentID uuid.UUID) ([]uuid.UUID, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetAllDepartmentIDsInSubtree(ctx, departmentID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "GetAllDepartmentIDsInSubtree", "")
+	r0, r1 := r.next.GetAllDepartmentIDsInSubtree(ctx, departmentID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormDepartmentRepository) Update(ctx context.Context, dept *identity.Department) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Update(ctx, dept)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "DepartmentRepository", "Update", "")
+	r0 := r.next.Update(ctx, dept)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormExpenseRecordRepository records a span for each GormExpenseRecordRepository call that takes a context
+type TracedGormExpenseRecordRepository struct {
+	next *GormExpenseRecordRepository
+}
+
+// NewTracedGormExpenseRecordRepository wraps next with repository tracing
+func NewTracedGormExpenseRecordRepository(next *GormExpenseRecordRepository) *TracedGormExpenseRecordRepository {
+	return &TracedGormExpenseRecordRepository{next: next}
+}
+
+func (r *TracedGormExpenseRecordRepository) CountByCategory(ctx context.Context, tenantID uuid.UUID, category finance.ExpenseCategory) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCategory(ctx, tenantID, category)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "CountByCategory", tenantID.String())
+	r0, r1 := r.next.CountByCategory(ctx, tenantID, category)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.ExpenseStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.ExpenseRecordFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormExpenseRecordRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormExpenseRecordRepository) ExistsByExpenseNumber(ctx context.Context, tenantID uuid.UUID, expenseNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByExpenseNumber(ctx, tenantID, expenseNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "ExistsByExpenseNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByExpenseNumber(ctx, tenantID, expenseNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.ExpenseRecordFilter) ([]finance.ExpenseRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) FindByCategory(ctx context.Context, tenantID uuid.UUID, category finance.ExpenseCategory, filter finance.ExpenseRecordFilter) ([]finance.ExpenseRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCategory(ctx, tenantID, category, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "FindByCategory", tenantID.String())
+	r0, r1 := r.next.FindByCategory(ctx, tenantID, category, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) FindByExpenseNumber(ctx context.Context, tenantID uuid.UUID, expenseNumber string) (*finance.ExpenseRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByExpenseNumber(ctx, tenantID, expenseNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "FindByExpenseNumber", tenantID.String())
+	r0, r1 := r.next.FindByExpenseNumber(ctx, tenantID, expenseNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.ExpenseRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*finance.ExpenseRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.ExpenseStatus, filter finance.ExpenseRecordFilter) ([]finance.ExpenseRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) FindPendingApproval(ctx context.Context, tenantID uuid.UUID) ([]finance.ExpenseRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPendingApproval(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "FindPendingApproval", tenantID.String())
+	r0, r1 := r.next.FindPendingApproval(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) GenerateExpenseNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateExpenseNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "GenerateExpenseNumber", tenantID.String())
+	r0, r1 := r.next.GenerateExpenseNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) Save(ctx context.Context, expense *finance.ExpenseRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, expense)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "Save", "")
+	r0 := r.next.Save(ctx, expense)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormExpenseRecordRepository) SaveWithLock(ctx context.Context, expense *finance.ExpenseRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, expense)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, expense)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormExpenseRecordRepository) SumApprovedForTenant(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumApprovedForTenant(ctx, tenantID, from, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "SumApprovedForTenant", tenantID.String())
+	r0, r1 := r.next.SumApprovedForTenant(ctx, tenantID, from, to)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) SumByCategory(ctx context.Context, tenantID uuid.UUID, category finance.ExpenseCategory, from time.Time, to time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumByCategory(ctx, tenantID, category, from, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "SumByCategory", tenantID.String())
+	r0, r1 := r.next.SumByCategory(ctx, tenantID, category, from, to)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormExpenseRecordRepository) SumForTenant(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumForTenant(ctx, tenantID, from, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ExpenseRecordRepository", "SumForTenant", tenantID.String())
+	r0, r1 := r.next.SumForTenant(ctx, tenantID, from, to)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormFeatureFlagRepository records a span for each GormFeatureFlagRepository call that takes a context
+type TracedGormFeatureFlagRepository struct {
+	next *GormFeatureFlagRepository
+}
+
+// NewTracedGormFeatureFlagRepository wraps next with repository tracing
+func NewTracedGormFeatureFlagRepository(next *GormFeatureFlagRepository) *TracedGormFeatureFlagRepository {
+	return &TracedGormFeatureFlagRepository{next: next}
+}
+
+func (r *TracedGormFeatureFlagRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) CountByStatus(ctx context.Context, status featureflag.FlagStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "CountByStatus", "")
+	r0, r1 := r.next.CountByStatus(ctx, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) Create(ctx context.Context, flag *featureflag.FeatureFlag) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, flag)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "Create", "")
+	r0 := r.next.Create(ctx, flag)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFeatureFlagRepository) Delete(ctx context.Context, key string) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, key)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, key)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFeatureFlagRepository) ExistsByKey(ctx context.Context, key string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByKey(ctx, key)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "ExistsByKey", "")
+	r0, r1 := r.next.ExistsByKey(ctx, key)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) FindAll(ctx context.Context, filter shared.Filter) ([]featureflag.FeatureFlag, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) FindByID(ctx context.Context, id uuid.UUID) (*featureflag.FeatureFlag, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) FindByKey(ctx context.Context, key string) (*featureflag.FeatureFlag, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByKey(ctx, key)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "FindByKey", "")
+	r0, r1 := r.next.FindByKey(ctx, key)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) FindByStatus(ctx context.Context, status featureflag.FlagStatus, filter shared.Filter) ([]featureflag.FeatureFlag, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "FindByStatus", "")
+	r0, r1 := r.next.FindByStatus(ctx, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) FindByTags(ctx context.Context, tags []string, filter shared.Filter) ([]featureflag.FeatureFlag, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTags(ctx, tags, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "FindByTags", "")
+	r0, r1 := r.next.FindByTags(ctx, tags, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) FindByType(ctx context.Context, flagType featureflag.FlagType, filter shared.Filter) ([]featureflag.FeatureFlag, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByType(ctx, flagType, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "FindByType", "")
+	r0, r1 := r.next.FindByType(ctx, flagType, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) FindEnabled(ctx context.Context, filter shared.Filter) ([]featureflag.FeatureFlag, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindEnabled(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "FindEnabled", "")
+	r0, r1 := r.next.FindEnabled(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFeatureFlagRepository) Update(ctx context.Context, flag *featureflag.FeatureFlag) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Update(ctx, flag)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FeatureFlagRepository", "Update", "")
+	r0 := r.next.Update(ctx, flag)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFeatureFlagRepository) WithTx(tx *gorm.DB) *GormFeatureFlagRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormFinanceReportRepository records a span for each GormFinanceReportRepository call that takes a context
+type TracedGormFinanceReportRepository struct {
+	next *GormFinanceReportRepository
+}
+
+// NewTracedGormFinanceReportRepository wraps next with repository tracing
+func NewTracedGormFinanceReportRepository(next *GormFinanceReportRepository) *TracedGormFinanceReportRepository {
+	return &TracedGormFinanceReportRepository{next: next}
+}
+
+func (r *TracedGormFinanceReportRepository) GetCashFlowItems(filter report.FinanceReportFilter) ([]report.CashFlowItem, error) {
+	return r.next.GetCashFlowItems(filter)
+}
+
+func (r *TracedGormFinanceReportRepository) GetCashFlowStatement(filter report.FinanceReportFilter) (*report.CashFlowStatement, error) {
+	return r.next.GetCashFlowStatement(filter)
+}
+
+func (r *TracedGormFinanceReportRepository) GetMonthlyProfitTrend(filter report.FinanceReportFilter) ([]report.MonthlyProfitTrend, error) {
+	return r.next.GetMonthlyProfitTrend(filter)
+}
+
+func (r *TracedGormFinanceReportRepository) GetProfitByCustomer(filter report.FinanceReportFilter) ([]report.ProfitByCustomer, error) {
+	return r.next.GetProfitByCustomer(filter)
+}
+
+func (r *TracedGormFinanceReportRepository) GetProfitByProduct(filter report.FinanceReportFilter) ([]report.ProfitByProduct, error) {
+	return r.next.GetProfitByProduct(filter)
+}
+
+func (r *TracedGormFinanceReportRepository) GetProfitLossDetail(filter report.FinanceReportFilter) (*report.ProfitLossDetail, error) {
+	return r.next.GetProfitLossDetail(filter)
+}
+
+func (r *TracedGormFinanceReportRepository) GetProfitLossStatement(filter report.FinanceReportFilter) (*report.ProfitLossStatement, error) {
+	return r.next.GetProfitLossStatement(filter)
+}
+
+// TracedGormFlagAuditLogRepository records a span for each GormFlagAuditLogRepository call that takes a context
+type TracedGormFlagAuditLogRepository struct {
+	next *GormFlagAuditLogRepository
+}
+
+// NewTracedGormFlagAuditLogRepository wraps next with repository tracing
+func NewTracedGormFlagAuditLogRepository(next *GormFlagAuditLogRepository) *TracedGormFlagAuditLogRepository {
+	return &TracedGormFlagAuditLogRepository{next: next}
+}
+
+func (r *TracedGormFlagAuditLogRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagAuditLogRepository) CountByFlagKey(ctx context.Context, flagKey string) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByFlagKey(ctx, flagKey)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "CountByFlagKey", "")
+	r0, r1 := r.next.CountByFlagKey(ctx, flagKey)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagAuditLogRepository) Create(ctx context.Context, log *featureflag.FlagAuditLog) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, log)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "Create", "")
+	r0 := r.next.Create(ctx, log)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFlagAuditLogRepository) CreateBatch(ctx context.Context, logs []*featureflag.FlagAuditLog) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CreateBatch(ctx, logs)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "CreateBatch", "")
+	r0 := r.next.CreateBatch(ctx, logs)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFlagAuditLogRepository) FindAll(ctx context.Context, filter shared.Filter) ([]featureflag.FlagAuditLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagAuditLogRepository) FindByAction(ctx context.Context, action featureflag.AuditAction, filter shared.Filter) ([]featureflag.FlagAuditLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByAction(ctx, action, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "FindByAction", "")
+	r0, r1 := r.next.FindByAction(ctx, action, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagAuditLogRepository) FindByFlagKey(ctx context.Context, flagKey string, filter shared.Filter) ([]featureflag.FlagAuditLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByFlagKey(ctx, flagKey, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "FindByFlagKey", "")
+	r0, r1 := r.next.FindByFlagKey(ctx, flagKey, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagAuditLogRepository) FindByUserID(ctx context.Context, userID uuid.UUID, filter shared.Filter) ([]featureflag.FlagAuditLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByUserID(ctx, userID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagAuditLogRepository", "FindByUserID", "")
+	r0, r1 := r.next.FindByUserID(ctx, userID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagAuditLogRepository) WithTx(tx *gorm.DB) *GormFlagAuditLogRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormFlagOverrideRepository records a span for each GormFlagOverrideRepository call that takes a context
+type TracedGormFlagOverrideRepository struct {
+	next *GormFlagOverrideRepository
+}
+
+// NewTracedGormFlagOverrideRepository wraps next with repository tracing
+func NewTracedGormFlagOverrideRepository(next *GormFlagOverrideRepository) *TracedGormFlagOverrideRepository {
+	return &TracedGormFlagOverrideRepository{next: next}
+}
+
+func (r *TracedGormFlagOverrideRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) CountByFlagKey(ctx context.Context, flagKey string) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByFlagKey(ctx, flagKey)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "CountByFlagKey", "")
+	r0, r1 := r.next.CountByFlagKey(ctx, flagKey)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) Create(ctx context.Context, override *featureflag.FlagOverride) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, override)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "Create", "")
+	r0 := r.next.Create(ctx, override)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFlagOverrideRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFlagOverrideRepository) DeleteByFlagKey(ctx context.Context, flagKey string) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByFlagKey(ctx, flagKey)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "DeleteByFlagKey", "")
+	r0, r1 := r.next.DeleteByFlagKey(ctx, flagKey)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteExpired(ctx)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "DeleteExpired", "")
+	r0, r1 := r.next.DeleteExpired(ctx)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) FindActive(ctx context.Context, filter shared.Filter) ([]featureflag.FlagOverride, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActive(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "FindActive", "")
+	r0, r1 := r.next.FindActive(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) FindByFlagKey(ctx context.Context, flagKey string, filter shared.Filter) ([]featureflag.FlagOverride, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByFlagKey(ctx, flagKey, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "FindByFlagKey", "")
+	r0, r1 := r.next.FindByFlagKey(ctx, flagKey, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) FindByFlagKeyAndTarget(ctx context.Context, flagKey string, targetType featureflag.OverrideTargetType, targetID uuid.UUID) (*featureflag.FlagOverride, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByFlagKeyAndTarget(ctx, flagKey, targetType, targetID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "FindByFlagKeyAndTarget", "")
+	r0, r1 := r.next.FindByFlagKeyAndTarget(ctx, flagKey, targetType, targetID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) FindByID(ctx context.Context, id uuid.UUID) (*featureflag.FlagOverride, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) FindByTarget(ctx context.Context, targetType featureflag.OverrideTargetType, targetID uuid.UUID, filter shared.Filter) ([]featureflag.FlagOverride, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTarget(ctx, targetType, targetID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "FindByTarget", "")
+	r0, r1 := r.next.FindByTarget(ctx, targetType, targetID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) FindExpired(ctx context.Context, filter shared.Filter) ([]featureflag.FlagOverride, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindExpired(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "FindExpired", "")
+	r0, r1 := r.next.FindExpired(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) FindForEvaluation(ctx context.Context, flagKey string, tenantID *uuid.UUID, userID *uuid.UUID) (*featureflag.FlagOverride, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindForEvaluation(ctx, flagKey, tenantID, userID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "FindForEvaluation", "")
+	r0, r1 := r.next.FindForEvaluation(ctx, flagKey, tenantID, userID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormFlagOverrideRepository) Update(ctx context.Context, override *featureflag.FlagOverride) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Update(ctx, override)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "FlagOverrideRepository", "Update", "")
+	r0 := r.next.Update(ctx, override)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormFlagOverrideRepository) WithTx(tx *gorm.DB) *GormFlagOverrideRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormImportHistoryRepository records a span for each GormImportHistoryRepository call that takes a context
+type TracedGormImportHistoryRepository struct {
+	next *GormImportHistoryRepository
+}
+
+// NewTracedGormImportHistoryRepository wraps next with repository tracing
+func NewTracedGormImportHistoryRepository(next *GormImportHistoryRepository) *TracedGormImportHistoryRepository {
+	return &TracedGormImportHistoryRepository{next: next}
+}
+
+func (r *TracedGormImportHistoryRepository) Delete(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ImportHistoryRepository", "Delete", tenantID.String())
+	r0 := r.next.Delete(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormImportHistoryRepository) FindAll(ctx context.Context, tenantID uuid.UUID, filter bulk.ImportHistoryFilter, page int, pageSize int) (*bulk.ImportHistoryListResult, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, tenantID, filter, page, pageSize)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ImportHistoryRepository", "FindAll", tenantID.String())
+	r0, r1 := r.next.FindAll(ctx, tenantID, filter, page, pageSize)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormImportHistoryRepository) FindByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*bulk.ImportHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ImportHistoryRepository", "FindByID", tenantID.String())
+	r0, r1 := r.next.FindByID(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormImportHistoryRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status bulk.ImportStatus) ([]*bulk.ImportHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ImportHistoryRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormImportHistoryRepository) FindPending(ctx context.Context, tenantID uuid.UUID) ([]*bulk.ImportHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPending(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ImportHistoryRepository", "FindPending", tenantID.String())
+	r0, r1 := r.next.FindPending(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormImportHistoryRepository) Save(ctx context.Context, history *bulk.ImportHistory) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, history)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ImportHistoryRepository", "Save", "")
+	r0 := r.next.Save(ctx, history)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormInventoryItemRepository records a span for each GormInventoryItemRepository call that takes a context
+type TracedGormInventoryItemRepository struct {
+	next *GormInventoryItemRepository
+}
+
+// NewTracedGormInventoryItemRepository wraps next with repository tracing
+func NewTracedGormInventoryItemRepository(next *GormInventoryItemRepository) *TracedGormInventoryItemRepository {
+	return &TracedGormInventoryItemRepository{next: next}
+}
+
+func (r *TracedGormInventoryItemRepository) CountByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "CountByProduct", tenantID.String())
+	r0, r1 := r.next.CountByProduct(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) CountByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByWarehouse(ctx, tenantID, warehouseID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "CountByWarehouse", tenantID.String())
+	r0, r1 := r.next.CountByWarehouse(ctx, tenantID, warehouseID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormInventoryItemRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormInventoryItemRepository) ExistsByWarehouseAndProduct(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, productID uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByWarehouseAndProduct(ctx, tenantID, warehouseID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "ExistsByWarehouseAndProduct", tenantID.String())
+	r0, r1 := r.next.ExistsByWarehouseAndProduct(ctx, tenantID, warehouseID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindBelowMinimum(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBelowMinimum(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindBelowMinimum", tenantID.String())
+	r0, r1 := r.next.FindBelowMinimum(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindByIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, tenantID, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindByIDs", tenantID.String())
+	r0, r1 := r.next.FindByIDs(ctx, tenantID, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, filter shared.Filter) ([]inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByProduct(ctx, tenantID, productID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindByProduct", tenantID.String())
+	r0, r1 := r.next.FindByProduct(ctx, tenantID, productID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, filter shared.Filter) ([]inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindByWarehouse", tenantID.String())
+	r0, r1 := r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindByWarehouseAndProduct(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, productID uuid.UUID) (*inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByWarehouseAndProduct(ctx, tenantID, warehouseID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindByWarehouseAndProduct", tenantID.String())
+	r0, r1 := r.next.FindByWarehouseAndProduct(ctx, tenantID, warehouseID, productID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) FindWithAvailableStock(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindWithAvailableStock(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "FindWithAvailableStock", tenantID.String())
+	r0, r1 := r.next.FindWithAvailableStock(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) GetOrCreate(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, productID uuid.UUID) (*inventory.InventoryItem, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetOrCreate(ctx, tenantID, warehouseID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "GetOrCreate", tenantID.String())
+	r0, r1 := r.next.GetOrCreate(ctx, tenantID, warehouseID, productID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) Save(ctx context.Context, item *inventory.InventoryItem) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, item)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "Save", "")
+	r0 := r.next.Save(ctx, item)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormInventoryItemRepository) SaveWithLock(ctx context.Context, item *inventory.InventoryItem) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, item)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, item)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormInventoryItemRepository) SumLoadByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, unit partner.CapacityUnit) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumLoadByWarehouse(ctx, tenantID, warehouseID, unit)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "SumLoadByWarehouse", tenantID.String())
+	r0, r1 := r.next.SumLoadByWarehouse(ctx, tenantID, warehouseID, unit)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) SumQuantityByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumQuantityByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "SumQuantityByProduct", tenantID.String())
+	r0, r1 := r.next.SumQuantityByProduct(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) SumValueByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumValueByWarehouse(ctx, tenantID, warehouseID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryItemRepository", "SumValueByWarehouse", tenantID.String())
+	r0, r1 := r.next.SumValueByWarehouse(ctx, tenantID, warehouseID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryItemRepository) WithTx(tx *gorm.DB) *GormInventoryItemRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormInventoryReportRepository records a span for each GormInventoryReportRepository call that takes a context
+type TracedGormInventoryReportRepository struct {
+	next *GormInventoryReportRepository
+}
+
+// NewTracedGormInventoryReportRepository wraps next with repository tracing
+func NewTracedGormInventoryReportRepository(next *GormInventoryReportRepository) *TracedGormInventoryReportRepository {
+	return &TracedGormInventoryReportRepository{next: next}
+}
+
+func (r *TracedGormInventoryReportRepository) GetInventorySummary(filter report.InventoryTurnoverFilter) (*report.InventorySummary, error) {
+	return r.next.GetInventorySummary(filter)
+}
+
+func (r *TracedGormInventoryReportRepository) GetInventoryTurnover(filter report.InventoryTurnoverFilter) ([]report.InventoryTurnover, error) {
+	return r.next.GetInventoryTurnover(filter)
+}
+
+func (r *TracedGormInventoryReportRepository) GetInventoryValueByCategory(filter report.InventoryTurnoverFilter) ([]report.InventoryValueByCategory, error) {
+	return r.next.GetInventoryValueByCategory(filter)
+}
+
+func (r *TracedGormInventoryReportRepository) GetInventoryValueByWarehouse(filter report.InventoryTurnoverFilter) ([]report.InventoryValueByWarehouse, error) {
+	return r.next.GetInventoryValueByWarehouse(filter)
+}
+
+func (r *TracedGormInventoryReportRepository) GetProductInventoryMovements(filter report.InventoryTurnoverFilter) ([]report.ProductInventoryMovement, error) {
+	return r.next.GetProductInventoryMovements(filter)
+}
+
+func (r *TracedGormInventoryReportRepository) GetSlowMovingProducts(filter report.InventoryTurnoverFilter) ([]report.InventoryTurnover, error) {
+	return r.next.GetSlowMovingProducts(filter)
+}
+
+// TracedGormInventoryTransactionRepository records a span for each GormInventoryTransactionRepository call that takes a context
+type TracedGormInventoryTransactionRepository struct {
+	next *GormInventoryTransactionRepository
+}
+
+// NewTracedGormInventoryTransactionRepository wraps next with repository tracing
+func NewTracedGormInventoryTransactionRepository(next *GormInventoryTransactionRepository) *TracedGormInventoryTransactionRepository {
+	return &TracedGormInventoryTransactionRepository{next: next}
+}
+
+func (r *TracedGormInventoryTransactionRepository) CountByInventoryItem(ctx context.Context, inventoryItemID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByInventoryItem(ctx, inventoryItemID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "CountByInventoryItem", "")
+	r0, r1 := r.next.CountByInventoryItem(ctx, inventoryItemID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) Create(ctx context.Context, tx *inventory.InventoryTransaction) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, tx)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "Create", "")
+	r0 := r.next.Create(ctx, tx)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormInventoryTransactionRepository) CreateBatch(ctx context.Context, txs []*inventory.InventoryTransaction) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CreateBatch(ctx, txs)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "CreateBatch", "")
+	r0 := r.next.CreateBatch(ctx, txs)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindByDateRange(ctx context.Context, tenantID uuid.UUID, start time.Time, end time.Time, filter shared.Filter) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByDateRange(ctx, tenantID, start, end, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindByDateRange", tenantID.String())
+	r0, r1 := r.next.FindByDateRange(ctx, tenantID, start, end, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindByInventoryItem(ctx context.Context, inventoryItemID uuid.UUID, filter shared.Filter) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByInventoryItem(ctx, inventoryItemID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindByInventoryItem", "")
+	r0, r1 := r.next.FindByInventoryItem(ctx, inventoryItemID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, filter shared.Filter) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByProduct(ctx, tenantID, productID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindByProduct", tenantID.String())
+	r0, r1 := r.next.FindByProduct(ctx, tenantID, productID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindBySource(ctx context.Context, sourceType inventory.SourceType, sourceID string) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySource(ctx, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindBySource", "")
+	r0, r1 := r.next.FindBySource(ctx, sourceType, sourceID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindByType(ctx context.Context, tenantID uuid.UUID, txType inventory.TransactionType, filter shared.Filter) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByType(ctx, tenantID, txType, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindByType", tenantID.String())
+	r0, r1 := r.next.FindByType(ctx, tenantID, txType, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, filter shared.Filter) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindByWarehouse", tenantID.String())
+	r0, r1 := r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindByWarehouseAsOf(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, asOf time.Time) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByWarehouseAsOf(ctx, tenantID, warehouseID, asOf)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindByWarehouseAsOf", tenantID.String())
+	r0, r1 := r.next.FindByWarehouseAsOf(ctx, tenantID, warehouseID, asOf)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindForTenant", tenantID.String())
+	r0, r1 := r.next.FindForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) FindLedgerByInventoryItem(ctx context.Context, tenantID uuid.UUID, inventoryItemID uuid.UUID) ([]inventory.InventoryTransaction, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindLedgerByInventoryItem(ctx, tenantID, inventoryItemID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "FindLedgerByInventoryItem", tenantID.String())
+	r0, r1 := r.next.FindLedgerByInventoryItem(ctx, tenantID, inventoryItemID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) SumQuantityByTypeAndDateRange(ctx context.Context, tenantID uuid.UUID, txType inventory.TransactionType, start time.Time, end time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumQuantityByTypeAndDateRange(ctx, tenantID, txType, start, end)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "InventoryTransactionRepository", "SumQuantityByTypeAndDateRange", tenantID.String())
+	r0, r1 := r.next.SumQuantityByTypeAndDateRange(ctx, tenantID, txType, start, end)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormInventoryTransactionRepository) WithTx(tx *gorm.DB) *GormInventoryTransactionRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormOrderStatusPushRepository records a span for each GormOrderStatusPushRepository call that takes a context
+type TracedGormOrderStatusPushRepository struct {
+	next *GormOrderStatusPushRepository
+}
+
+// NewTracedGormOrderStatusPushRepository wraps next with repository tracing
+func NewTracedGormOrderStatusPushRepository(next *GormOrderStatusPushRepository) *TracedGormOrderStatusPushRepository {
+	return &TracedGormOrderStatusPushRepository{next: next}
+}
+
+func (r *TracedGormOrderStatusPushRepository) FindRetryable(ctx context.Context, before time.Time, limit int) ([]*integration.OrderStatusPush, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindRetryable(ctx, before, limit)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderStatusPushRepository", "FindRetryable", "")
+	r0, r1 := r.next.FindRetryable(ctx, before, limit)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOrderStatusPushRepository) Save(ctx context.Context, push *integration.OrderStatusPush) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, push)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderStatusPushRepository", "Save", "")
+	r0 := r.next.Save(ctx, push)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormOrderSyncConfigRepository records a span for each GormOrderSyncConfigRepository call that takes a context
+type TracedGormOrderSyncConfigRepository struct {
+	next *GormOrderSyncConfigRepository
+}
+
+// NewTracedGormOrderSyncConfigRepository wraps next with repository tracing
+func NewTracedGormOrderSyncConfigRepository(next *GormOrderSyncConfigRepository) *TracedGormOrderSyncConfigRepository {
+	return &TracedGormOrderSyncConfigRepository{next: next}
+}
+
+func (r *TracedGormOrderSyncConfigRepository) Delete(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, tenantID, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncConfigRepository", "Delete", tenantID.String())
+	r0 := r.next.Delete(ctx, tenantID, platformCode)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormOrderSyncConfigRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID) ([]integration.OrderSyncConfig, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncConfigRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOrderSyncConfigRepository) FindByTenantAndPlatform(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*integration.OrderSyncConfig, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenantAndPlatform(ctx, tenantID, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncConfigRepository", "FindByTenantAndPlatform", tenantID.String())
+	r0, r1 := r.next.FindByTenantAndPlatform(ctx, tenantID, platformCode)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOrderSyncConfigRepository) Save(ctx context.Context, config *integration.OrderSyncConfig) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, config)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncConfigRepository", "Save", "")
+	r0 := r.next.Save(ctx, config)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormOrderSyncRecordRepository records a span for each GormOrderSyncRecordRepository call that takes a context
+type TracedGormOrderSyncRecordRepository struct {
+	next *GormOrderSyncRecordRepository
+}
+
+// NewTracedGormOrderSyncRecordRepository wraps next with repository tracing
+func NewTracedGormOrderSyncRecordRepository(next *GormOrderSyncRecordRepository) *TracedGormOrderSyncRecordRepository {
+	return &TracedGormOrderSyncRecordRepository{next: next}
+}
+
+func (r *TracedGormOrderSyncRecordRepository) Count(ctx context.Context, tenantID uuid.UUID, filter integration.OrderSyncRecordFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncRecordRepository", "Count", tenantID.String())
+	r0, r1 := r.next.Count(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOrderSyncRecordRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncRecordRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormOrderSyncRecordRepository) FindAll(ctx context.Context, tenantID uuid.UUID, filter integration.OrderSyncRecordFilter) ([]integration.PlatformOrderSyncRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncRecordRepository", "FindAll", tenantID.String())
+	r0, r1 := r.next.FindAll(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOrderSyncRecordRepository) FindByLocalOrder(ctx context.Context, tenantID uuid.UUID, localOrderID uuid.UUID) (*integration.PlatformOrderSyncRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByLocalOrder(ctx, tenantID, localOrderID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncRecordRepository", "FindByLocalOrder", tenantID.String())
+	r0, r1 := r.next.FindByLocalOrder(ctx, tenantID, localOrderID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOrderSyncRecordRepository) FindByPlatformOrder(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, platformOrderID string) (*integration.PlatformOrderSyncRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlatformOrder(ctx, tenantID, platformCode, platformOrderID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncRecordRepository", "FindByPlatformOrder", tenantID.String())
+	r0, r1 := r.next.FindByPlatformOrder(ctx, tenantID, platformCode, platformOrderID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOrderSyncRecordRepository) Save(ctx context.Context, record *integration.PlatformOrderSyncRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, record)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OrderSyncRecordRepository", "Save", "")
+	r0 := r.next.Save(ctx, record)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormOtherIncomeRecordRepository records a span for each GormOtherIncomeRecordRepository call that takes a context
+type TracedGormOtherIncomeRecordRepository struct {
+	next *GormOtherIncomeRecordRepository
+}
+
+// NewTracedGormOtherIncomeRecordRepository wraps next with repository tracing
+func NewTracedGormOtherIncomeRecordRepository(next *GormOtherIncomeRecordRepository) *TracedGormOtherIncomeRecordRepository {
+	return &TracedGormOtherIncomeRecordRepository{next: next}
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) CountByCategory(ctx context.Context, tenantID uuid.UUID, category finance.IncomeCategory) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCategory(ctx, tenantID, category)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "CountByCategory", tenantID.String())
+	r0, r1 := r.next.CountByCategory(ctx, tenantID, category)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.IncomeStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.OtherIncomeRecordFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) ExistsByIncomeNumber(ctx context.Context, tenantID uuid.UUID, incomeNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByIncomeNumber(ctx, tenantID, incomeNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "ExistsByIncomeNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByIncomeNumber(ctx, tenantID, incomeNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.OtherIncomeRecordFilter) ([]finance.OtherIncomeRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) FindByCategory(ctx context.Context, tenantID uuid.UUID, category finance.IncomeCategory, filter finance.OtherIncomeRecordFilter) ([]finance.OtherIncomeRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCategory(ctx, tenantID, category, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "FindByCategory", tenantID.String())
+	r0, r1 := r.next.FindByCategory(ctx, tenantID, category, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.OtherIncomeRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*finance.OtherIncomeRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) FindByIncomeNumber(ctx context.Context, tenantID uuid.UUID, incomeNumber string) (*finance.OtherIncomeRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIncomeNumber(ctx, tenantID, incomeNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "FindByIncomeNumber", tenantID.String())
+	r0, r1 := r.next.FindByIncomeNumber(ctx, tenantID, incomeNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.IncomeStatus, filter finance.OtherIncomeRecordFilter) ([]finance.OtherIncomeRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) FindDraft(ctx context.Context, tenantID uuid.UUID) ([]finance.OtherIncomeRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDraft(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "FindDraft", tenantID.String())
+	r0, r1 := r.next.FindDraft(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) GenerateIncomeNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateIncomeNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "GenerateIncomeNumber", tenantID.String())
+	r0, r1 := r.next.GenerateIncomeNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) Save(ctx context.Context, income *finance.OtherIncomeRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, income)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "Save", "")
+	r0 := r.next.Save(ctx, income)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) SaveWithLock(ctx context.Context, income *finance.OtherIncomeRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, income)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, income)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) SumByCategory(ctx context.Context, tenantID uuid.UUID, category finance.IncomeCategory, from time.Time, to time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumByCategory(ctx, tenantID, category, from, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "SumByCategory", tenantID.String())
+	r0, r1 := r.next.SumByCategory(ctx, tenantID, category, from, to)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) SumConfirmedForTenant(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumConfirmedForTenant(ctx, tenantID, from, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "SumConfirmedForTenant", tenantID.String())
+	r0, r1 := r.next.SumConfirmedForTenant(ctx, tenantID, from, to)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormOtherIncomeRecordRepository) SumForTenant(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumForTenant(ctx, tenantID, from, to)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "OtherIncomeRecordRepository", "SumForTenant", tenantID.String())
+	r0, r1 := r.next.SumForTenant(ctx, tenantID, from, to)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormPartnerAddressRepository records a span for each GormPartnerAddressRepository call that takes a context
+type TracedGormPartnerAddressRepository struct {
+	next *GormPartnerAddressRepository
+}
+
+// NewTracedGormPartnerAddressRepository wraps next with repository tracing
+func NewTracedGormPartnerAddressRepository(next *GormPartnerAddressRepository) *TracedGormPartnerAddressRepository {
+	return &TracedGormPartnerAddressRepository{next: next}
+}
+
+func (r *TracedGormPartnerAddressRepository) ClearDefault(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID, addressType partner.AddressType) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ClearDefault(ctx, tenantID, ownerType, ownerID, addressType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PartnerAddressRepository", "ClearDefault", tenantID.String())
+	r0 := r.next.ClearDefault(ctx, tenantID, ownerType, ownerID, addressType)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPartnerAddressRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PartnerAddressRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPartnerAddressRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.Address, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PartnerAddressRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPartnerAddressRepository) FindByOwner(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID) ([]partner.Address, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByOwner(ctx, tenantID, ownerType, ownerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PartnerAddressRepository", "FindByOwner", tenantID.String())
+	r0, r1 := r.next.FindByOwner(ctx, tenantID, ownerType, ownerID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPartnerAddressRepository) FindDefault(ctx context.Context, tenantID uuid.UUID, ownerType partner.AddressOwnerType, ownerID uuid.UUID, addressType partner.AddressType) (*partner.Address, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDefault(ctx, tenantID, ownerType, ownerID, addressType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PartnerAddressRepository", "FindDefault", tenantID.String())
+	r0, r1 := r.next.FindDefault(ctx, tenantID, ownerType, ownerID, addressType)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPartnerAddressRepository) Save(ctx context.Context, address *partner.Address) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, address)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PartnerAddressRepository", "Save", "")
+	r0 := r.next.Save(ctx, address)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormPasswordHistoryRepository records a span for each GormPasswordHistoryRepository call that takes a context
+type TracedGormPasswordHistoryRepository struct {
+	next *GormPasswordHistoryRepository
+}
+
+// NewTracedGormPasswordHistoryRepository wraps next with repository tracing
+func NewTracedGormPasswordHistoryRepository(next *GormPasswordHistoryRepository) *TracedGormPasswordHistoryRepository {
+	return &TracedGormPasswordHistoryRepository{next: next}
+}
+
+func (r *TracedGormPasswordHistoryRepository) FindRecentByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]identity.PasswordHistoryEntry, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindRecentByUserID(ctx, userID, limit)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PasswordHistoryRepository", "FindRecentByUserID", "")
+	r0, r1 := r.next.FindRecentByUserID(ctx, userID, limit)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPasswordHistoryRepository) Save(ctx context.Context, entry *identity.PasswordHistoryEntry) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, entry)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PasswordHistoryRepository", "Save", "")
+	r0 := r.next.Save(ctx, entry)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormPaymentVoucherRepository records a span for each GormPaymentVoucherRepository call that takes a context
+type TracedGormPaymentVoucherRepository struct {
+	next *GormPaymentVoucherRepository
+}
+
+// NewTracedGormPaymentVoucherRepository wraps next with repository tracing
+func NewTracedGormPaymentVoucherRepository(next *GormPaymentVoucherRepository) *TracedGormPaymentVoucherRepository {
+	return &TracedGormPaymentVoucherRepository{next: next}
+}
+
+func (r *TracedGormPaymentVoucherRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.VoucherStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) CountBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "CountBySupplier", tenantID.String())
+	r0, r1 := r.next.CountBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.PaymentVoucherFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPaymentVoucherRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPaymentVoucherRepository) ExistsByVoucherNumber(ctx context.Context, tenantID uuid.UUID, voucherNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByVoucherNumber(ctx, tenantID, voucherNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "ExistsByVoucherNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByVoucherNumber(ctx, tenantID, voucherNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.PaymentVoucherFilter) ([]finance.PaymentVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.PaymentVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*finance.PaymentVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.VoucherStatus, filter finance.PaymentVoucherFilter) ([]finance.PaymentVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) FindBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID, filter finance.PaymentVoucherFilter) ([]finance.PaymentVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "FindBySupplier", tenantID.String())
+	r0, r1 := r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) FindByVoucherNumber(ctx context.Context, tenantID uuid.UUID, voucherNumber string) (*finance.PaymentVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByVoucherNumber(ctx, tenantID, voucherNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "FindByVoucherNumber", tenantID.String())
+	r0, r1 := r.next.FindByVoucherNumber(ctx, tenantID, voucherNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) FindWithUnallocatedAmount(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) ([]finance.PaymentVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindWithUnallocatedAmount(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "FindWithUnallocatedAmount", tenantID.String())
+	r0, r1 := r.next.FindWithUnallocatedAmount(ctx, tenantID, supplierID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) GenerateVoucherNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateVoucherNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "GenerateVoucherNumber", tenantID.String())
+	r0, r1 := r.next.GenerateVoucherNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) Save(ctx context.Context, voucher *finance.PaymentVoucher) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, voucher)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "Save", "")
+	r0 := r.next.Save(ctx, voucher)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPaymentVoucherRepository) SaveWithLock(ctx context.Context, voucher *finance.PaymentVoucher) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, voucher)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, voucher)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPaymentVoucherRepository) SumBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "SumBySupplier", tenantID.String())
+	r0, r1 := r.next.SumBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) SumForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "SumForTenant", tenantID.String())
+	r0, r1 := r.next.SumForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPaymentVoucherRepository) SumUnallocatedBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumUnallocatedBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PaymentVoucherRepository", "SumUnallocatedBySupplier", tenantID.String())
+	r0, r1 := r.next.SumUnallocatedBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormPlanFeatureRepository records a span for each GormPlanFeatureRepository call that takes a context
+type TracedGormPlanFeatureRepository struct {
+	next *GormPlanFeatureRepository
+}
+
+// NewTracedGormPlanFeatureRepository wraps next with repository tracing
+func NewTracedGormPlanFeatureRepository(next *GormPlanFeatureRepository) *TracedGormPlanFeatureRepository {
+	return &TracedGormPlanFeatureRepository{next: next}
+}
+
+func (r *TracedGormPlanFeatureRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPlanFeatureRepository) DeleteByPlan(ctx context.Context, planID identity.TenantPlan) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByPlan(ctx, planID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "DeleteByPlan", "")
+	r0 := r.next.DeleteByPlan(ctx, planID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPlanFeatureRepository) FindByID(ctx context.Context, id uuid.UUID) (*identity.PlanFeature, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPlanFeatureRepository) FindByPlan(ctx context.Context, planID identity.TenantPlan) ([]identity.PlanFeature, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlan(ctx, planID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "FindByPlan", "")
+	r0, r1 := r.next.FindByPlan(ctx, planID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPlanFeatureRepository) FindByPlanAndFeature(ctx context.Context, planID identity.TenantPlan, featureKey identity.FeatureKey) (*identity.PlanFeature, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlanAndFeature(ctx, planID, featureKey)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "FindByPlanAndFeature", "")
+	r0, r1 := r.next.FindByPlanAndFeature(ctx, planID, featureKey)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPlanFeatureRepository) FindEnabledByPlan(ctx context.Context, planID identity.TenantPlan) ([]identity.PlanFeature, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindEnabledByPlan(ctx, planID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "FindEnabledByPlan", "")
+	r0, r1 := r.next.FindEnabledByPlan(ctx, planID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPlanFeatureRepository) GetFeatureLimit(ctx context.Context, planID identity.TenantPlan, featureKey identity.FeatureKey) (*int, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetFeatureLimit(ctx, planID, featureKey)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "GetFeatureLimit", "")
+	r0, r1 := r.next.GetFeatureLimit(ctx, planID, featureKey)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPlanFeatureRepository) HasFeature(ctx context.Context, planID identity.TenantPlan, featureKey identity.FeatureKey) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.HasFeature(ctx, planID, featureKey)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "HasFeature", "")
+	r0, r1 := r.next.HasFeature(ctx, planID, featureKey)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPlanFeatureRepository) Save(ctx context.Context, feature *identity.PlanFeature) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, feature)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "Save", "")
+	r0 := r.next.Save(ctx, feature)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPlanFeatureRepository) SaveBatch(ctx context.Context, features []identity.PlanFeature) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, features)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, features)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPlanFeatureRepository) SaveBatchWithAuditLog(ctx context.Context, features []identity.PlanFeature, changedBy *uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatchWithAuditLog(ctx, features, changedBy)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "SaveBatchWithAuditLog", "")
+	r0 := r.next.SaveBatchWithAuditLog(ctx, features, changedBy)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPlanFeatureRepository) SaveWithAuditLog(ctx context.Context, feature *identity.PlanFeature, changedBy *uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithAuditLog(ctx, feature, changedBy)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PlanFeatureRepository", "SaveWithAuditLog", "")
+	r0 := r.next.SaveWithAuditLog(ctx, feature, changedBy)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormPrintJobRepository records a span for each GormPrintJobRepository call that takes a context
+type TracedGormPrintJobRepository struct {
+	next *GormPrintJobRepository
+}
+
+// NewTracedGormPrintJobRepository wraps next with repository tracing
+func NewTracedGormPrintJobRepository(next *GormPrintJobRepository) *TracedGormPrintJobRepository {
+	return &TracedGormPrintJobRepository{next: next}
+}
+
+func (r *TracedGormPrintJobRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status printing.JobStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPrintJobRepository) DeleteOlderThan(ctx context.Context, days int) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteOlderThan(ctx, days)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "DeleteOlderThan", "")
+	r0, r1 := r.next.DeleteOlderThan(ctx, days)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) FindAll(ctx context.Context, filter shared.Filter) ([]printing.PrintJob, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]printing.PrintJob, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) FindByDocument(ctx context.Context, tenantID uuid.UUID, docType printing.DocType, documentID uuid.UUID) ([]printing.PrintJob, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByDocument(ctx, tenantID, docType, documentID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "FindByDocument", tenantID.String())
+	r0, r1 := r.next.FindByDocument(ctx, tenantID, docType, documentID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*printing.PrintJob, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*printing.PrintJob, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) FindPending(ctx context.Context, limit int) ([]printing.PrintJob, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPending(ctx, limit)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "FindPending", "")
+	r0, r1 := r.next.FindPending(ctx, limit)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) FindRecent(ctx context.Context, tenantID uuid.UUID, days int, limit int) ([]printing.PrintJob, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindRecent(ctx, tenantID, days, limit)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "FindRecent", tenantID.String())
+	r0, r1 := r.next.FindRecent(ctx, tenantID, days, limit)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPrintJobRepository) Save(ctx context.Context, job *printing.PrintJob) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, job)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PrintJobRepository", "Save", "")
+	r0 := r.next.Save(ctx, job)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormProductAttachmentRepository records a span for each GormProductAttachmentRepository call that takes a context
+type TracedGormProductAttachmentRepository struct {
+	next *GormProductAttachmentRepository
+}
+
+// NewTracedGormProductAttachmentRepository wraps next with repository tracing
+func NewTracedGormProductAttachmentRepository(next *GormProductAttachmentRepository) *TracedGormProductAttachmentRepository {
+	return &TracedGormProductAttachmentRepository{next: next}
+}
+
+func (r *TracedGormProductAttachmentRepository) CountActiveByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountActiveByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "CountActiveByProduct", tenantID.String())
+	r0, r1 := r.next.CountActiveByProduct(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) CountByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "CountByProduct", tenantID.String())
+	r0, r1 := r.next.CountByProduct(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductAttachmentRepository) DeleteByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "DeleteByProduct", tenantID.String())
+	r0 := r.next.DeleteByProduct(ctx, tenantID, productID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductAttachmentRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductAttachmentRepository) ExistsByStorageKey(ctx context.Context, tenantID uuid.UUID, storageKey string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByStorageKey(ctx, tenantID, storageKey)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "ExistsByStorageKey", tenantID.String())
+	r0, r1 := r.next.ExistsByStorageKey(ctx, tenantID, storageKey)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindActiveByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) ([]catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActiveByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindActiveByProduct", tenantID.String())
+	r0, r1 := r.next.FindActiveByProduct(ctx, tenantID, productID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindByIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, tenantID, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindByIDs", tenantID.String())
+	r0, r1 := r.next.FindByIDs(ctx, tenantID, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, filter shared.Filter) ([]catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByProduct(ctx, tenantID, productID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindByProduct", tenantID.String())
+	r0, r1 := r.next.FindByProduct(ctx, tenantID, productID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindByProductAndStatus(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, status catalog.AttachmentStatus, filter shared.Filter) ([]catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByProductAndStatus(ctx, tenantID, productID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindByProductAndStatus", tenantID.String())
+	r0, r1 := r.next.FindByProductAndStatus(ctx, tenantID, productID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindByType(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, attachmentType catalog.AttachmentType) ([]catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByType(ctx, tenantID, productID, attachmentType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindByType", tenantID.String())
+	r0, r1 := r.next.FindByType(ctx, tenantID, productID, attachmentType)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindMainImage(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (*catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindMainImage(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindMainImage", tenantID.String())
+	r0, r1 := r.next.FindMainImage(ctx, tenantID, productID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) FindPendingOlderThan(ctx context.Context, tenantID uuid.UUID, olderThan int64) ([]catalog.ProductAttachment, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPendingOlderThan(ctx, tenantID, olderThan)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "FindPendingOlderThan", tenantID.String())
+	r0, r1 := r.next.FindPendingOlderThan(ctx, tenantID, olderThan)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) GetMaxSortOrder(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (int, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetMaxSortOrder(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "GetMaxSortOrder", tenantID.String())
+	r0, r1 := r.next.GetMaxSortOrder(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductAttachmentRepository) Save(ctx context.Context, attachment *catalog.ProductAttachment) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, attachment)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "Save", "")
+	r0 := r.next.Save(ctx, attachment)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductAttachmentRepository) SaveBatch(ctx context.Context, attachments []*catalog.ProductAttachment) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, attachments)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductAttachmentRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, attachments)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormProductMappingRepository records a span for each GormProductMappingRepository call that takes a context
+type TracedGormProductMappingRepository struct {
+	next *GormProductMappingRepository
+}
+
+// NewTracedGormProductMappingRepository wraps next with repository tracing
+func NewTracedGormProductMappingRepository(next *GormProductMappingRepository) *TracedGormProductMappingRepository {
+	return &TracedGormProductMappingRepository{next: next}
+}
+
+func (r *TracedGormProductMappingRepository) Count(ctx context.Context, tenantID uuid.UUID, filter integration.ProductMappingFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "Count", tenantID.String())
+	r0, r1 := r.next.Count(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductMappingRepository) DeleteByLocalProduct(ctx context.Context, tenantID uuid.UUID, localProductID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByLocalProduct(ctx, tenantID, localProductID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "DeleteByLocalProduct", tenantID.String())
+	r0 := r.next.DeleteByLocalProduct(ctx, tenantID, localProductID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductMappingRepository) DeleteByLocalProductAndPlatform(ctx context.Context, tenantID uuid.UUID, localProductID uuid.UUID, platformCode integration.PlatformCode) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByLocalProductAndPlatform(ctx, tenantID, localProductID, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "DeleteByLocalProductAndPlatform", tenantID.String())
+	r0 := r.next.DeleteByLocalProductAndPlatform(ctx, tenantID, localProductID, platformCode)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductMappingRepository) ExistsByLocalProductAndPlatform(ctx context.Context, tenantID uuid.UUID, localProductID uuid.UUID, platformCode integration.PlatformCode) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByLocalProductAndPlatform(ctx, tenantID, localProductID, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "ExistsByLocalProductAndPlatform", tenantID.String())
+	r0, r1 := r.next.ExistsByLocalProductAndPlatform(ctx, tenantID, localProductID, platformCode)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) ExistsByPlatformProduct(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, platformProductID string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByPlatformProduct(ctx, tenantID, platformCode, platformProductID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "ExistsByPlatformProduct", tenantID.String())
+	r0, r1 := r.next.ExistsByPlatformProduct(ctx, tenantID, platformCode, platformProductID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindActiveForPlatform(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) ([]integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActiveForPlatform(ctx, tenantID, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindActiveForPlatform", tenantID.String())
+	r0, r1 := r.next.FindActiveForPlatform(ctx, tenantID, platformCode)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindAll(ctx context.Context, tenantID uuid.UUID, filter integration.ProductMappingFilter) ([]integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindAll", tenantID.String())
+	r0, r1 := r.next.FindAll(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindByID(ctx context.Context, id uuid.UUID) (*integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindByLocalProduct(ctx context.Context, tenantID uuid.UUID, localProductID uuid.UUID) ([]integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByLocalProduct(ctx, tenantID, localProductID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindByLocalProduct", tenantID.String())
+	r0, r1 := r.next.FindByLocalProduct(ctx, tenantID, localProductID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindByLocalProductAndPlatform(ctx context.Context, tenantID uuid.UUID, localProductID uuid.UUID, platformCode integration.PlatformCode) (*integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByLocalProductAndPlatform(ctx, tenantID, localProductID, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindByLocalProductAndPlatform", tenantID.String())
+	r0, r1 := r.next.FindByLocalProductAndPlatform(ctx, tenantID, localProductID, platformCode)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindByLocalProductIDs(ctx context.Context, tenantID uuid.UUID, localProductIDs []uuid.UUID, platformCode integration.PlatformCode) ([]integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByLocalProductIDs(ctx, tenantID, localProductIDs, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindByLocalProductIDs", tenantID.String())
+	r0, r1 := r.next.FindByLocalProductIDs(ctx, tenantID, localProductIDs, platformCode)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindByPlatformProduct(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, platformProductID string) (*integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlatformProduct(ctx, tenantID, platformCode, platformProductID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindByPlatformProduct", tenantID.String())
+	r0, r1 := r.next.FindByPlatformProduct(ctx, tenantID, platformCode, platformProductID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindByPlatformSku(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, platformSkuID string) (*integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlatformSku(ctx, tenantID, platformCode, platformSkuID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindByPlatformSku", tenantID.String())
+	r0, r1 := r.next.FindByPlatformSku(ctx, tenantID, platformCode, platformSkuID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) FindSyncEnabled(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) ([]integration.ProductMapping, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindSyncEnabled(ctx, tenantID, platformCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "FindSyncEnabled", tenantID.String())
+	r0, r1 := r.next.FindSyncEnabled(ctx, tenantID, platformCode)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) GetMappingStats(ctx context.Context, tenantID uuid.UUID) (map[string]int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetMappingStats(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "GetMappingStats", tenantID.String())
+	r0, r1 := r.next.GetMappingStats(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductMappingRepository) Save(ctx context.Context, mapping *integration.ProductMapping) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, mapping)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "Save", "")
+	r0 := r.next.Save(ctx, mapping)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductMappingRepository) SaveBatch(ctx context.Context, mappings []*integration.ProductMapping) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, mappings)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, mappings)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductMappingRepository) UpdateSyncStatus(ctx context.Context, ids []uuid.UUID, status integration.SyncStatus, errorMsg string) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.UpdateSyncStatus(ctx, ids, status, errorMsg)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductMappingRepository", "UpdateSyncStatus", "")
+	r0 := r.next.UpdateSyncStatus(ctx, ids, status, errorMsg)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormProductPriceHistoryRepository records a span for each GormProductPriceHistoryRepository call that takes a context
+type TracedGormProductPriceHistoryRepository struct {
+	next *GormProductPriceHistoryRepository
+}
+
+// NewTracedGormProductPriceHistoryRepository wraps next with repository tracing
+func NewTracedGormProductPriceHistoryRepository(next *GormProductPriceHistoryRepository) *TracedGormProductPriceHistoryRepository {
+	return &TracedGormProductPriceHistoryRepository{next: next}
+}
+
+func (r *TracedGormProductPriceHistoryRepository) CountByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductPriceHistoryRepository", "CountByProduct", tenantID.String())
+	r0, r1 := r.next.CountByProduct(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductPriceHistoryRepository) CreateBatch(ctx context.Context, entries []*catalog.ProductPriceHistory) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CreateBatch(ctx, entries)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductPriceHistoryRepository", "CreateBatch", "")
+	r0 := r.next.CreateBatch(ctx, entries)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductPriceHistoryRepository) FindByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, filter shared.Filter) ([]catalog.ProductPriceHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByProduct(ctx, tenantID, productID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductPriceHistoryRepository", "FindByProduct", tenantID.String())
+	r0, r1 := r.next.FindByProduct(ctx, tenantID, productID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductPriceHistoryRepository) FindEarliestAfter(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, priceType catalog.PriceType, at time.Time) (*catalog.ProductPriceHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindEarliestAfter(ctx, tenantID, productID, priceType, at)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductPriceHistoryRepository", "FindEarliestAfter", tenantID.String())
+	r0, r1 := r.next.FindEarliestAfter(ctx, tenantID, productID, priceType, at)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductPriceHistoryRepository) FindLatestAt(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, priceType catalog.PriceType, at time.Time) (*catalog.ProductPriceHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindLatestAt(ctx, tenantID, productID, priceType, at)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductPriceHistoryRepository", "FindLatestAt", tenantID.String())
+	r0, r1 := r.next.FindLatestAt(ctx, tenantID, productID, priceType, at)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormProductRepository records a span for each GormProductRepository call that takes a context
+type TracedGormProductRepository struct {
+	next *GormProductRepository
+}
+
+// NewTracedGormProductRepository wraps next with repository tracing
+func NewTracedGormProductRepository(next *GormProductRepository) *TracedGormProductRepository {
+	return &TracedGormProductRepository{next: next}
+}
+
+func (r *TracedGormProductRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) CountByCategory(ctx context.Context, tenantID uuid.UUID, categoryID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCategory(ctx, tenantID, categoryID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "CountByCategory", tenantID.String())
+	r0, r1 := r.next.CountByCategory(ctx, tenantID, categoryID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status catalog.ProductStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductRepository) ExistsByBarcode(ctx context.Context, tenantID uuid.UUID, barcode string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByBarcode(ctx, tenantID, barcode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "ExistsByBarcode", tenantID.String())
+	r0, r1 := r.next.ExistsByBarcode(ctx, tenantID, barcode)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindActive(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActive(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindActive", tenantID.String())
+	r0, r1 := r.next.FindActive(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindAll(ctx context.Context, filter shared.Filter) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByBarcode(ctx context.Context, tenantID uuid.UUID, barcode string) (*catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByBarcode(ctx, tenantID, barcode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByBarcode", tenantID.String())
+	r0, r1 := r.next.FindByBarcode(ctx, tenantID, barcode)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByCategories(ctx context.Context, tenantID uuid.UUID, categoryIDs []uuid.UUID, filter shared.Filter) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCategories(ctx, tenantID, categoryIDs, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByCategories", tenantID.String())
+	r0, r1 := r.next.FindByCategories(ctx, tenantID, categoryIDs, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByCategory(ctx context.Context, tenantID uuid.UUID, categoryID uuid.UUID, filter shared.Filter) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCategory(ctx, tenantID, categoryID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByCategory", tenantID.String())
+	r0, r1 := r.next.FindByCategory(ctx, tenantID, categoryID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByBarcodes(ctx, tenantID, barcodes)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByBarcodes", tenantID.String())
+	r0, r1 := r.next.FindByBarcodes(ctx, tenantID, barcodes)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCodes(ctx, tenantID, codes)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByCodes", tenantID.String())
+	r0, r1 := r.next.FindByCodes(ctx, tenantID, codes)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByID(ctx context.Context, id uuid.UUID) (*catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, tenantID, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByIDs", tenantID.String())
+	r0, r1 := r.next.FindByIDs(ctx, tenantID, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status catalog.ProductStatus, filter shared.Filter) ([]catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*catalog.Product, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDeletedByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "FindDeletedByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindDeletedByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductRepository) RestoreForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.RestoreForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "RestoreForTenant", tenantID.String())
+	r0 := r.next.RestoreForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductRepository) Save(ctx context.Context, product *catalog.Product) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, product)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "Save", "")
+	r0 := r.next.Save(ctx, product)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductRepository) SaveBatch(ctx context.Context, products []*catalog.Product) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, products)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, products)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductRepository) SaveWithLock(ctx context.Context, product *catalog.Product, expectedVersion int) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, product, expectedVersion)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, product, expectedVersion)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormProductUnitRepository records a span for each GormProductUnitRepository call that takes a context
+type TracedGormProductUnitRepository struct {
+	next *GormProductUnitRepository
+}
+
+// NewTracedGormProductUnitRepository wraps next with repository tracing
+func NewTracedGormProductUnitRepository(next *GormProductUnitRepository) *TracedGormProductUnitRepository {
+	return &TracedGormProductUnitRepository{next: next}
+}
+
+func (r *TracedGormProductUnitRepository) ClearDefaultPurchaseUnit(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ClearDefaultPurchaseUnit(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "ClearDefaultPurchaseUnit", tenantID.String())
+	r0 := r.next.ClearDefaultPurchaseUnit(ctx, tenantID, productID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductUnitRepository) ClearDefaultSalesUnit(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ClearDefaultSalesUnit(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "ClearDefaultSalesUnit", tenantID.String())
+	r0 := r.next.ClearDefaultSalesUnit(ctx, tenantID, productID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductUnitRepository) CountByProductID(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByProductID(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "CountByProductID", tenantID.String())
+	r0, r1 := r.next.CountByProductID(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductUnitRepository) DeleteByProductID(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByProductID(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "DeleteByProductID", tenantID.String())
+	r0 := r.next.DeleteByProductID(ctx, tenantID, productID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductUnitRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductUnitRepository) ExistsByProductIDAndCode(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, unitCode string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByProductIDAndCode(ctx, tenantID, productID, unitCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "ExistsByProductIDAndCode", tenantID.String())
+	r0, r1 := r.next.ExistsByProductIDAndCode(ctx, tenantID, productID, unitCode)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) FindByID(ctx context.Context, id uuid.UUID) (*catalog.ProductUnit, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*catalog.ProductUnit, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) FindByProductID(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) ([]catalog.ProductUnit, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByProductID(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "FindByProductID", tenantID.String())
+	r0, r1 := r.next.FindByProductID(ctx, tenantID, productID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) FindByProductIDAndCode(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, unitCode string) (*catalog.ProductUnit, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByProductIDAndCode(ctx, tenantID, productID, unitCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "FindByProductIDAndCode", tenantID.String())
+	r0, r1 := r.next.FindByProductIDAndCode(ctx, tenantID, productID, unitCode)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) FindDefaultPurchaseUnit(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (*catalog.ProductUnit, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDefaultPurchaseUnit(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "FindDefaultPurchaseUnit", tenantID.String())
+	r0, r1 := r.next.FindDefaultPurchaseUnit(ctx, tenantID, productID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) FindDefaultSalesUnit(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (*catalog.ProductUnit, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDefaultSalesUnit(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "FindDefaultSalesUnit", tenantID.String())
+	r0, r1 := r.next.FindDefaultSalesUnit(ctx, tenantID, productID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormProductUnitRepository) Save(ctx context.Context, unit *catalog.ProductUnit) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, unit)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "Save", "")
+	r0 := r.next.Save(ctx, unit)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormProductUnitRepository) SaveBatch(ctx context.Context, units []*catalog.ProductUnit) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, units)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ProductUnitRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, units)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormPurchaseOrderRepository records a span for each GormPurchaseOrderRepository call that takes a context
+type TracedGormPurchaseOrderRepository struct {
+	next *GormPurchaseOrderRepository
+}
+
+// NewTracedGormPurchaseOrderRepository wraps next with repository tracing
+func NewTracedGormPurchaseOrderRepository(next *GormPurchaseOrderRepository) *TracedGormPurchaseOrderRepository {
+	return &TracedGormPurchaseOrderRepository{next: next}
+}
+
+func (r *TracedGormPurchaseOrderRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status trade.PurchaseOrderStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) CountBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "CountBySupplier", tenantID.String())
+	r0, r1 := r.next.CountBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) CountIncompleteBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountIncompleteBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "CountIncompleteBySupplier", tenantID.String())
+	r0, r1 := r.next.CountIncompleteBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) CountPendingReceipt(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountPendingReceipt(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "CountPendingReceipt", tenantID.String())
+	r0, r1 := r.next.CountPendingReceipt(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseOrderRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseOrderRepository) ExistsByOrderNumber(ctx context.Context, tenantID uuid.UUID, orderNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByOrderNumber(ctx, tenantID, orderNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "ExistsByOrderNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByOrderNumber(ctx, tenantID, orderNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) ExistsByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "ExistsByProduct", tenantID.String())
+	r0, r1 := r.next.ExistsByProduct(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindByOrderNumber(ctx context.Context, tenantID uuid.UUID, orderNumber string) (*trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByOrderNumber(ctx, tenantID, orderNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindByOrderNumber", tenantID.String())
+	r0, r1 := r.next.FindByOrderNumber(ctx, tenantID, orderNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status trade.PurchaseOrderStatus, filter shared.Filter) ([]trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindBySupplier", tenantID.String())
+	r0, r1 := r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindByWarehouse", tenantID.String())
+	r0, r1 := r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) FindPendingReceipt(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPendingReceipt(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "FindPendingReceipt", tenantID.String())
+	r0, r1 := r.next.FindPendingReceipt(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) GenerateOrderNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateOrderNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "GenerateOrderNumber", tenantID.String())
+	r0, r1 := r.next.GenerateOrderNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseOrderRepository) Save(ctx context.Context, order *trade.PurchaseOrder) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, order)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "Save", "")
+	r0 := r.next.Save(ctx, order)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseOrderRepository) SaveWithLock(ctx context.Context, order *trade.PurchaseOrder) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, order)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, order)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseOrderRepository) SaveWithLockAndEvents(ctx context.Context, order *trade.PurchaseOrder, events []shared.DomainEvent) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLockAndEvents(ctx, order, events)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseOrderRepository", "SaveWithLockAndEvents", "")
+	r0 := r.next.SaveWithLockAndEvents(ctx, order, events)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseOrderRepository) SetOutboxEventSaver(saver shared.OutboxEventSaver) {
+	r.next.SetOutboxEventSaver(saver)
+}
+
+// TracedGormPurchaseReturnRepository records a span for each GormPurchaseReturnRepository call that takes a context
+type TracedGormPurchaseReturnRepository struct {
+	next *GormPurchaseReturnRepository
+}
+
+// NewTracedGormPurchaseReturnRepository wraps next with repository tracing
+func NewTracedGormPurchaseReturnRepository(next *GormPurchaseReturnRepository) *TracedGormPurchaseReturnRepository {
+	return &TracedGormPurchaseReturnRepository{next: next}
+}
+
+func (r *TracedGormPurchaseReturnRepository) CountByPurchaseOrder(ctx context.Context, tenantID uuid.UUID, purchaseOrderID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByPurchaseOrder(ctx, tenantID, purchaseOrderID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "CountByPurchaseOrder", tenantID.String())
+	r0, r1 := r.next.CountByPurchaseOrder(ctx, tenantID, purchaseOrderID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status trade.PurchaseReturnStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) CountBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountBySupplier(ctx, tenantID, supplierID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "CountBySupplier", tenantID.String())
+	r0, r1 := r.next.CountBySupplier(ctx, tenantID, supplierID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) CountPendingApproval(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountPendingApproval(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "CountPendingApproval", tenantID.String())
+	r0, r1 := r.next.CountPendingApproval(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseReturnRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseReturnRepository) ExistsByReturnNumber(ctx context.Context, tenantID uuid.UUID, returnNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByReturnNumber(ctx, tenantID, returnNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "ExistsByReturnNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByReturnNumber(ctx, tenantID, returnNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindByID(ctx context.Context, id uuid.UUID) (*trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindByPurchaseOrder(ctx context.Context, tenantID uuid.UUID, purchaseOrderID uuid.UUID) ([]trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPurchaseOrder(ctx, tenantID, purchaseOrderID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindByPurchaseOrder", tenantID.String())
+	r0, r1 := r.next.FindByPurchaseOrder(ctx, tenantID, purchaseOrderID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindByReturnNumber(ctx context.Context, tenantID uuid.UUID, returnNumber string) (*trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByReturnNumber(ctx, tenantID, returnNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindByReturnNumber", tenantID.String())
+	r0, r1 := r.next.FindByReturnNumber(ctx, tenantID, returnNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status trade.PurchaseReturnStatus, filter shared.Filter) ([]trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindBySupplier(ctx context.Context, tenantID uuid.UUID, supplierID uuid.UUID, filter shared.Filter) ([]trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindBySupplier", tenantID.String())
+	r0, r1 := r.next.FindBySupplier(ctx, tenantID, supplierID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) FindPendingApproval(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.PurchaseReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPendingApproval(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "FindPendingApproval", tenantID.String())
+	r0, r1 := r.next.FindPendingApproval(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) GenerateReturnNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateReturnNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "GenerateReturnNumber", tenantID.String())
+	r0, r1 := r.next.GenerateReturnNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormPurchaseReturnRepository) Save(ctx context.Context, pr *trade.PurchaseReturn) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, pr)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "Save", "")
+	r0 := r.next.Save(ctx, pr)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormPurchaseReturnRepository) SaveWithLock(ctx context.Context, pr *trade.PurchaseReturn) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, pr)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "PurchaseReturnRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, pr)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormReceiptVoucherRepository records a span for each GormReceiptVoucherRepository call that takes a context
+type TracedGormReceiptVoucherRepository struct {
+	next *GormReceiptVoucherRepository
+}
+
+// NewTracedGormReceiptVoucherRepository wraps next with repository tracing
+func NewTracedGormReceiptVoucherRepository(next *GormReceiptVoucherRepository) *TracedGormReceiptVoucherRepository {
+	return &TracedGormReceiptVoucherRepository{next: next}
+}
+
+func (r *TracedGormReceiptVoucherRepository) CountByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "CountByCustomer", tenantID.String())
+	r0, r1 := r.next.CountByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.VoucherStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.ReceiptVoucherFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormReceiptVoucherRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormReceiptVoucherRepository) ExistsByVoucherNumber(ctx context.Context, tenantID uuid.UUID, voucherNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByVoucherNumber(ctx, tenantID, voucherNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "ExistsByVoucherNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByVoucherNumber(ctx, tenantID, voucherNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.ReceiptVoucherFilter) ([]finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, filter finance.ReceiptVoucherFilter) ([]finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindByCustomer", tenantID.String())
+	r0, r1 := r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindByPaymentReference(ctx context.Context, paymentReference string) (*finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPaymentReference(ctx, paymentReference)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindByPaymentReference", "")
+	r0, r1 := r.next.FindByPaymentReference(ctx, paymentReference)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.VoucherStatus, filter finance.ReceiptVoucherFilter) ([]finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindByVoucherNumber(ctx context.Context, tenantID uuid.UUID, voucherNumber string) (*finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByVoucherNumber(ctx, tenantID, voucherNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindByVoucherNumber", tenantID.String())
+	r0, r1 := r.next.FindByVoucherNumber(ctx, tenantID, voucherNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) FindWithUnallocatedAmount(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) ([]finance.ReceiptVoucher, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindWithUnallocatedAmount(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "FindWithUnallocatedAmount", tenantID.String())
+	r0, r1 := r.next.FindWithUnallocatedAmount(ctx, tenantID, customerID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) GenerateVoucherNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateVoucherNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "GenerateVoucherNumber", tenantID.String())
+	r0, r1 := r.next.GenerateVoucherNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) Save(ctx context.Context, voucher *finance.ReceiptVoucher) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, voucher)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "Save", "")
+	r0 := r.next.Save(ctx, voucher)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormReceiptVoucherRepository) SaveWithLock(ctx context.Context, voucher *finance.ReceiptVoucher) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, voucher)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, voucher)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormReceiptVoucherRepository) SumByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "SumByCustomer", tenantID.String())
+	r0, r1 := r.next.SumByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) SumForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "SumForTenant", tenantID.String())
+	r0, r1 := r.next.SumForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormReceiptVoucherRepository) SumUnallocatedByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumUnallocatedByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "ReceiptVoucherRepository", "SumUnallocatedByCustomer", tenantID.String())
+	r0, r1 := r.next.SumUnallocatedByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormRefundRecordRepository records a span for each GormRefundRecordRepository call that takes a context
+type TracedGormRefundRecordRepository struct {
+	next *GormRefundRecordRepository
+}
+
+// NewTracedGormRefundRecordRepository wraps next with repository tracing
+func NewTracedGormRefundRecordRepository(next *GormRefundRecordRepository) *TracedGormRefundRecordRepository {
+	return &TracedGormRefundRecordRepository{next: next}
+}
+
+func (r *TracedGormRefundRecordRepository) CountByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "CountByCustomer", tenantID.String())
+	r0, r1 := r.next.CountByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status finance.RefundRecordStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.RefundRecordFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRefundRecordRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRefundRecordRepository) ExistsByGatewayRefundID(ctx context.Context, gatewayType finance.PaymentGatewayType, gatewayRefundID string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByGatewayRefundID(ctx, gatewayType, gatewayRefundID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "ExistsByGatewayRefundID", "")
+	r0, r1 := r.next.ExistsByGatewayRefundID(ctx, gatewayType, gatewayRefundID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) ExistsByRefundNumber(ctx context.Context, tenantID uuid.UUID, refundNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByRefundNumber(ctx, tenantID, refundNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "ExistsByRefundNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByRefundNumber(ctx, tenantID, refundNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter finance.RefundRecordFilter) ([]finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, filter finance.RefundRecordFilter) ([]finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindByCustomer", tenantID.String())
+	r0, r1 := r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindByGatewayRefundID(ctx context.Context, gatewayType finance.PaymentGatewayType, gatewayRefundID string) (*finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByGatewayRefundID(ctx, gatewayType, gatewayRefundID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindByGatewayRefundID", "")
+	r0, r1 := r.next.FindByGatewayRefundID(ctx, gatewayType, gatewayRefundID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindByID(ctx context.Context, id uuid.UUID) (*finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindByRefundNumber(ctx context.Context, tenantID uuid.UUID, refundNumber string) (*finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByRefundNumber(ctx, tenantID, refundNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindByRefundNumber", tenantID.String())
+	r0, r1 := r.next.FindByRefundNumber(ctx, tenantID, refundNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindBySource(ctx context.Context, tenantID uuid.UUID, sourceType finance.RefundSourceType, sourceID uuid.UUID) ([]finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySource(ctx, tenantID, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindBySource", tenantID.String())
+	r0, r1 := r.next.FindBySource(ctx, tenantID, sourceType, sourceID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status finance.RefundRecordStatus, filter finance.RefundRecordFilter) ([]finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) FindPending(ctx context.Context, tenantID uuid.UUID) ([]finance.RefundRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPending(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "FindPending", tenantID.String())
+	r0, r1 := r.next.FindPending(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) GenerateRefundNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateRefundNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "GenerateRefundNumber", tenantID.String())
+	r0, r1 := r.next.GenerateRefundNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) Save(ctx context.Context, record *finance.RefundRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, record)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "Save", "")
+	r0 := r.next.Save(ctx, record)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRefundRecordRepository) SaveWithLock(ctx context.Context, record *finance.RefundRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, record)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, record)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRefundRecordRepository) SumByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "SumByCustomer", tenantID.String())
+	r0, r1 := r.next.SumByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) SumForTenant(ctx context.Context, tenantID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumForTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "SumForTenant", tenantID.String())
+	r0, r1 := r.next.SumForTenant(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRefundRecordRepository) SumSuccessfulByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumSuccessfulByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RefundRecordRepository", "SumSuccessfulByCustomer", tenantID.String())
+	r0, r1 := r.next.SumSuccessfulByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormReturnReportRepository records a span for each GormReturnReportRepository call that takes a context
+type TracedGormReturnReportRepository struct {
+	next *GormReturnReportRepository
+}
+
+// NewTracedGormReturnReportRepository wraps next with repository tracing
+func NewTracedGormReturnReportRepository(next *GormReturnReportRepository) *TracedGormReturnReportRepository {
+	return &TracedGormReturnReportRepository{next: next}
+}
+
+func (r *TracedGormReturnReportRepository) GetPurchaseReturnsByReason(filter report.ReturnReportFilter) ([]report.ReturnReasonStat, error) {
+	return r.next.GetPurchaseReturnsByReason(filter)
+}
+
+func (r *TracedGormReturnReportRepository) GetSalesReturnsByReason(filter report.ReturnReportFilter) ([]report.ReturnReasonStat, error) {
+	return r.next.GetSalesReturnsByReason(filter)
+}
+
+// TracedGormRoleRepository records a span for each GormRoleRepository call that takes a context
+type TracedGormRoleRepository struct {
+	next *GormRoleRepository
+}
+
+// NewTracedGormRoleRepository wraps next with repository tracing
+func NewTracedGormRoleRepository(next *GormRoleRepository) *TracedGormRoleRepository {
+	return &TracedGormRoleRepository{next: next}
+}
+
+func (r *TracedGormRoleRepository) Count(ctx context.Context, tenantID uuid.UUID, filter *identity.RoleFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "Count", tenantID.String())
+	r0, r1 := r.next.Count(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) CountUsersWithRole(ctx context.Context, roleID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountUsersWithRole(ctx, roleID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "CountUsersWithRole", "")
+	r0, r1 := r.next.CountUsersWithRole(ctx, roleID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) Create(ctx context.Context, role *identity.Role) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, role)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "Create", "")
+	r0 := r.next.Create(ctx, role)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRoleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRoleRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "ExistsByID", "")
+	r0, r1 := r.next.ExistsByID(ctx, id)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) FindAll(ctx context.Context, tenantID uuid.UUID, filter *identity.RoleFilter) ([]*identity.Role, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "FindAll", tenantID.String())
+	r0, r1 := r.next.FindAll(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*identity.Role, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) FindByID(ctx context.Context, id uuid.UUID) (*identity.Role, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*identity.Role, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "FindByIDs", "")
+	r0, r1 := r.next.FindByIDs(ctx, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) FindRolesWithPermission(ctx context.Context, tenantID uuid.UUID, permissionCode string) ([]*identity.Role, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindRolesWithPermission(ctx, tenantID, permissionCode)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "FindRolesWithPermission", tenantID.String())
+	r0, r1 := r.next.FindRolesWithPermission(ctx, tenantID, permissionCode)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) FindSystemRoles(ctx context.Context, tenantID uuid.UUID) ([]*identity.Role, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindSystemRoles(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "FindSystemRoles", tenantID.String())
+	r0, r1 := r.next.FindSystemRoles(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) FindUsersWithRole(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindUsersWithRole(ctx, roleID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "FindUsersWithRole", "")
+	r0, r1 := r.next.FindUsersWithRole(ctx, roleID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) GetAllPermissionCodes(ctx context.Context, tenantID uuid.UUID) ([]string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetAllPermissionCodes(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "GetAllPermissionCodes", tenantID.String())
+	r0, r1 := r.next.GetAllPermissionCodes(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormRoleRepository) LoadDataScopes(ctx context.Context, role *identity.Role) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.LoadDataScopes(ctx, role)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "LoadDataScopes", "")
+	r0 := r.next.LoadDataScopes(ctx, role)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRoleRepository) LoadPermissions(ctx context.Context, role *identity.Role) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.LoadPermissions(ctx, role)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "LoadPermissions", "")
+	r0 := r.next.LoadPermissions(ctx, role)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRoleRepository) LoadPermissionsAndDataScopes(ctx context.Context, role *identity.Role) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.LoadPermissionsAndDataScopes(ctx, role)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "LoadPermissionsAndDataScopes", "")
+	r0 := r.next.LoadPermissionsAndDataScopes(ctx, role)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRoleRepository) SaveDataScopes(ctx context.Context, role *identity.Role) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveDataScopes(ctx, role)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "SaveDataScopes", "")
+	r0 := r.next.SaveDataScopes(ctx, role)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRoleRepository) SavePermissions(ctx context.Context, role *identity.Role) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SavePermissions(ctx, role)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "SavePermissions", "")
+	r0 := r.next.SavePermissions(ctx, role)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormRoleRepository) Update(ctx context.Context, role *identity.Role) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Update(ctx, role)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "RoleRepository", "Update", "")
+	r0 := r.next.Update(ctx, role)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormSalesOrderRepository records a span for each GormSalesOrderRepository call that takes a context
+type TracedGormSalesOrderRepository struct {
+	next *GormSalesOrderRepository
+}
+
+// NewTracedGormSalesOrderRepository wraps next with repository tracing
+func NewTracedGormSalesOrderRepository(next *GormSalesOrderRepository) *TracedGormSalesOrderRepository {
+	return &TracedGormSalesOrderRepository{next: next}
+}
+
+func (r *TracedGormSalesOrderRepository) CountByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "CountByCustomer", tenantID.String())
+	r0, r1 := r.next.CountByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status trade.OrderStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) CountIncompleteByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountIncompleteByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "CountIncompleteByCustomer", tenantID.String())
+	r0, r1 := r.next.CountIncompleteByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesOrderRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesOrderRepository) ExistsByOrderNumber(ctx context.Context, tenantID uuid.UUID, orderNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByOrderNumber(ctx, tenantID, orderNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "ExistsByOrderNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByOrderNumber(ctx, tenantID, orderNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) ExistsByProduct(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByProduct(ctx, tenantID, productID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "ExistsByProduct", tenantID.String())
+	r0, r1 := r.next.ExistsByProduct(ctx, tenantID, productID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, filter shared.Filter) ([]trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindByCustomer", tenantID.String())
+	r0, r1 := r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindByID(ctx context.Context, id uuid.UUID) (*trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindByOrderNumber(ctx context.Context, tenantID uuid.UUID, orderNumber string) (*trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByOrderNumber(ctx, tenantID, orderNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindByOrderNumber", tenantID.String())
+	r0, r1 := r.next.FindByOrderNumber(ctx, tenantID, orderNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status trade.OrderStatus, filter shared.Filter) ([]trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, filter shared.Filter) ([]trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindByWarehouse", tenantID.String())
+	r0, r1 := r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) FindExpiredQuotes(ctx context.Context, expiresBefore time.Time) ([]trade.SalesOrder, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindExpiredQuotes(ctx, expiresBefore)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindExpiredQuotes", "")
+	r0, r1 := r.next.FindExpiredQuotes(ctx, expiresBefore)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) GenerateOrderNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateOrderNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "GenerateOrderNumber", tenantID.String())
+	r0, r1 := r.next.GenerateOrderNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesOrderRepository) Save(ctx context.Context, order *trade.SalesOrder) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, order)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "Save", "")
+	r0 := r.next.Save(ctx, order)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesOrderRepository) SaveWithLock(ctx context.Context, order *trade.SalesOrder) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, order)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, order)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesOrderRepository) SaveWithLockAndEvents(ctx context.Context, order *trade.SalesOrder, events []shared.DomainEvent) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLockAndEvents(ctx, order, events)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "SaveWithLockAndEvents", "")
+	r0 := r.next.SaveWithLockAndEvents(ctx, order, events)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesOrderRepository) SetOutboxEventSaver(saver shared.OutboxEventSaver) {
+	r.next.SetOutboxEventSaver(saver)
+}
+
+func (r *TracedGormSalesOrderRepository) SumCompletedByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumCompletedByCustomer(ctx, tenantID, customerID, since)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "SumCompletedByCustomer", tenantID.String())
+	r0, r1 := r.next.SumCompletedByCustomer(ctx, tenantID, customerID, since)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormSalesReportRepository records a span for each GormSalesReportRepository call that takes a context
+type TracedGormSalesReportRepository struct {
+	next *GormSalesReportRepository
+}
+
+// NewTracedGormSalesReportRepository wraps next with repository tracing
+func NewTracedGormSalesReportRepository(next *GormSalesReportRepository) *TracedGormSalesReportRepository {
+	return &TracedGormSalesReportRepository{next: next}
+}
+
+func (r *TracedGormSalesReportRepository) GetCustomerSalesRanking(filter report.SalesReportFilter) ([]report.CustomerSalesRanking, error) {
+	return r.next.GetCustomerSalesRanking(filter)
+}
+
+func (r *TracedGormSalesReportRepository) GetDailySalesTrend(filter report.SalesReportFilter) ([]report.DailySalesTrend, error) {
+	return r.next.GetDailySalesTrend(filter)
+}
+
+func (r *TracedGormSalesReportRepository) GetOrderFulfillmentTimes(filter report.SalesReportFilter) ([]report.OrderFulfillmentTimes, error) {
+	return r.next.GetOrderFulfillmentTimes(filter)
+}
+
+func (r *TracedGormSalesReportRepository) GetProductSalesRanking(filter report.SalesReportFilter) ([]report.ProductSalesRanking, error) {
+	return r.next.GetProductSalesRanking(filter)
+}
+
+func (r *TracedGormSalesReportRepository) GetProductSalesReport(filter report.SalesReportFilter) ([]report.SalesReport, error) {
+	return r.next.GetProductSalesReport(filter)
+}
+
+func (r *TracedGormSalesReportRepository) GetSalesSummary(filter report.SalesReportFilter) (*report.SalesSummary, error) {
+	return r.next.GetSalesSummary(filter)
+}
+
+// TracedGormSalesReturnRepository records a span for each GormSalesReturnRepository call that takes a context
+type TracedGormSalesReturnRepository struct {
+	next *GormSalesReturnRepository
+}
+
+// NewTracedGormSalesReturnRepository wraps next with repository tracing
+func NewTracedGormSalesReturnRepository(next *GormSalesReturnRepository) *TracedGormSalesReturnRepository {
+	return &TracedGormSalesReturnRepository{next: next}
+}
+
+func (r *TracedGormSalesReturnRepository) CountByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByCustomer(ctx, tenantID, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "CountByCustomer", tenantID.String())
+	r0, r1 := r.next.CountByCustomer(ctx, tenantID, customerID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) CountBySalesOrder(ctx context.Context, tenantID uuid.UUID, salesOrderID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountBySalesOrder(ctx, tenantID, salesOrderID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "CountBySalesOrder", tenantID.String())
+	r0, r1 := r.next.CountBySalesOrder(ctx, tenantID, salesOrderID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status trade.ReturnStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) CountPendingApproval(ctx context.Context, tenantID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountPendingApproval(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "CountPendingApproval", tenantID.String())
+	r0, r1 := r.next.CountPendingApproval(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesReturnRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesReturnRepository) ExistsByReturnNumber(ctx context.Context, tenantID uuid.UUID, returnNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByReturnNumber(ctx, tenantID, returnNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "ExistsByReturnNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByReturnNumber(ctx, tenantID, returnNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindByCustomer(ctx context.Context, tenantID uuid.UUID, customerID uuid.UUID, filter shared.Filter) ([]trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindByCustomer", tenantID.String())
+	r0, r1 := r.next.FindByCustomer(ctx, tenantID, customerID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindByID(ctx context.Context, id uuid.UUID) (*trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindByReturnNumber(ctx context.Context, tenantID uuid.UUID, returnNumber string) (*trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByReturnNumber(ctx, tenantID, returnNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindByReturnNumber", tenantID.String())
+	r0, r1 := r.next.FindByReturnNumber(ctx, tenantID, returnNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindBySalesOrder(ctx context.Context, tenantID uuid.UUID, salesOrderID uuid.UUID) ([]trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySalesOrder(ctx, tenantID, salesOrderID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindBySalesOrder", tenantID.String())
+	r0, r1 := r.next.FindBySalesOrder(ctx, tenantID, salesOrderID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status trade.ReturnStatus, filter shared.Filter) ([]trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) FindPendingApproval(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]trade.SalesReturn, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPendingApproval(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "FindPendingApproval", tenantID.String())
+	r0, r1 := r.next.FindPendingApproval(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) GenerateReturnNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateReturnNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "GenerateReturnNumber", tenantID.String())
+	r0, r1 := r.next.GenerateReturnNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) GetReturnedQuantityByOrderItem(ctx context.Context, tenantID uuid.UUID, salesOrderItemID uuid.UUID) (map[uuid.UUID]decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetReturnedQuantityByOrderItem(ctx, tenantID, salesOrderItemID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "GetReturnedQuantityByOrderItem", tenantID.String())
+	r0, r1 := r.next.GetReturnedQuantityByOrderItem(ctx, tenantID, salesOrderItemID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) GetReturnedQuantityByOrderItems(ctx context.Context, tenantID uuid.UUID, salesOrderItemIDs []uuid.UUID) (map[uuid.UUID]decimal.Decimal, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetReturnedQuantityByOrderItems(ctx, tenantID, salesOrderItemIDs)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "GetReturnedQuantityByOrderItems", tenantID.String())
+	r0, r1 := r.next.GetReturnedQuantityByOrderItems(ctx, tenantID, salesOrderItemIDs)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSalesReturnRepository) Save(ctx context.Context, sr *trade.SalesReturn) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, sr)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "Save", "")
+	r0 := r.next.Save(ctx, sr)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSalesReturnRepository) SaveWithLock(ctx context.Context, sr *trade.SalesReturn) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithLock(ctx, sr)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesReturnRepository", "SaveWithLock", "")
+	r0 := r.next.SaveWithLock(ctx, sr)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormSerialNumberRepository records a span for each GormSerialNumberRepository call that takes a context
+type TracedGormSerialNumberRepository struct {
+	next *GormSerialNumberRepository
+}
+
+// NewTracedGormSerialNumberRepository wraps next with repository tracing
+func NewTracedGormSerialNumberRepository(next *GormSerialNumberRepository) *TracedGormSerialNumberRepository {
+	return &TracedGormSerialNumberRepository{next: next}
+}
+
+func (r *TracedGormSerialNumberRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*inventory.SerialNumber, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SerialNumberRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSerialNumberRepository) FindBySerialNumber(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, serialNumber string) (*inventory.SerialNumber, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySerialNumber(ctx, tenantID, productID, serialNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SerialNumberRepository", "FindBySerialNumber", tenantID.String())
+	r0, r1 := r.next.FindBySerialNumber(ctx, tenantID, productID, serialNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSerialNumberRepository) FindBySerialNumbers(ctx context.Context, tenantID uuid.UUID, productID uuid.UUID, serialNumbers []string) ([]inventory.SerialNumber, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySerialNumbers(ctx, tenantID, productID, serialNumbers)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SerialNumberRepository", "FindBySerialNumbers", tenantID.String())
+	r0, r1 := r.next.FindBySerialNumbers(ctx, tenantID, productID, serialNumbers)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSerialNumberRepository) FindMovements(ctx context.Context, serialNumberID uuid.UUID) ([]inventory.SerialNumberMovement, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindMovements(ctx, serialNumberID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SerialNumberRepository", "FindMovements", "")
+	r0, r1 := r.next.FindMovements(ctx, serialNumberID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSerialNumberRepository) Save(ctx context.Context, sn *inventory.SerialNumber) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, sn)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SerialNumberRepository", "Save", "")
+	r0 := r.next.Save(ctx, sn)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSerialNumberRepository) WithTx(tx *gorm.DB) *GormSerialNumberRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormStockBatchRepository records a span for each GormStockBatchRepository call that takes a context
+type TracedGormStockBatchRepository struct {
+	next *GormStockBatchRepository
+}
+
+// NewTracedGormStockBatchRepository wraps next with repository tracing
+func NewTracedGormStockBatchRepository(next *GormStockBatchRepository) *TracedGormStockBatchRepository {
+	return &TracedGormStockBatchRepository{next: next}
+}
+
+func (r *TracedGormStockBatchRepository) CountByInventoryItem(ctx context.Context, inventoryItemID uuid.UUID) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByInventoryItem(ctx, inventoryItemID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "CountByInventoryItem", "")
+	r0, r1 := r.next.CountByInventoryItem(ctx, inventoryItemID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockBatchRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockBatchRepository) FindAvailable(ctx context.Context, inventoryItemID uuid.UUID) ([]inventory.StockBatch, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAvailable(ctx, inventoryItemID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "FindAvailable", "")
+	r0, r1 := r.next.FindAvailable(ctx, inventoryItemID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockBatchRepository) FindByBatchNumber(ctx context.Context, inventoryItemID uuid.UUID, batchNumber string) (*inventory.StockBatch, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByBatchNumber(ctx, inventoryItemID, batchNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "FindByBatchNumber", "")
+	r0, r1 := r.next.FindByBatchNumber(ctx, inventoryItemID, batchNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockBatchRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.StockBatch, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockBatchRepository) FindByInventoryItem(ctx context.Context, inventoryItemID uuid.UUID, filter shared.Filter) ([]inventory.StockBatch, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByInventoryItem(ctx, inventoryItemID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "FindByInventoryItem", "")
+	r0, r1 := r.next.FindByInventoryItem(ctx, inventoryItemID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockBatchRepository) FindExpired(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]inventory.StockBatch, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindExpired(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "FindExpired", tenantID.String())
+	r0, r1 := r.next.FindExpired(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockBatchRepository) FindExpiringSoon(ctx context.Context, tenantID uuid.UUID, withinDays int, filter shared.Filter) ([]inventory.StockBatch, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindExpiringSoon(ctx, tenantID, withinDays, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "FindExpiringSoon", tenantID.String())
+	r0, r1 := r.next.FindExpiringSoon(ctx, tenantID, withinDays, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockBatchRepository) Save(ctx context.Context, batch *inventory.StockBatch) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, batch)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "Save", "")
+	r0 := r.next.Save(ctx, batch)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockBatchRepository) SaveBatch(ctx context.Context, batches []inventory.StockBatch) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, batches)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockBatchRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, batches)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockBatchRepository) WithTx(tx *gorm.DB) *GormStockBatchRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormStockLockRepository records a span for each GormStockLockRepository call that takes a context
+type TracedGormStockLockRepository struct {
+	next *GormStockLockRepository
+}
+
+// NewTracedGormStockLockRepository wraps next with repository tracing
+func NewTracedGormStockLockRepository(next *GormStockLockRepository) *TracedGormStockLockRepository {
+	return &TracedGormStockLockRepository{next: next}
+}
+
+func (r *TracedGormStockLockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockLockRepository) FindActive(ctx context.Context, inventoryItemID uuid.UUID) ([]inventory.StockLock, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActive(ctx, inventoryItemID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "FindActive", "")
+	r0, r1 := r.next.FindActive(ctx, inventoryItemID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockLockRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.StockLock, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockLockRepository) FindByInventoryItem(ctx context.Context, inventoryItemID uuid.UUID) ([]inventory.StockLock, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByInventoryItem(ctx, inventoryItemID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "FindByInventoryItem", "")
+	r0, r1 := r.next.FindByInventoryItem(ctx, inventoryItemID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockLockRepository) FindBySource(ctx context.Context, sourceType string, sourceID string) ([]inventory.StockLock, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindBySource(ctx, sourceType, sourceID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "FindBySource", "")
+	r0, r1 := r.next.FindBySource(ctx, sourceType, sourceID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockLockRepository) FindExpired(ctx context.Context) ([]inventory.StockLock, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindExpired(ctx)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "FindExpired", "")
+	r0, r1 := r.next.FindExpired(ctx)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockLockRepository) ReleaseExpired(ctx context.Context) (int, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ReleaseExpired(ctx)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "ReleaseExpired", "")
+	r0, r1 := r.next.ReleaseExpired(ctx)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockLockRepository) Save(ctx context.Context, lock *inventory.StockLock) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, lock)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockLockRepository", "Save", "")
+	r0 := r.next.Save(ctx, lock)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockLockRepository) WithTx(tx *gorm.DB) *GormStockLockRepository {
+	return r.next.WithTx(tx)
+}
+
+// TracedGormStockTakingRepository records a span for each GormStockTakingRepository call that takes a context
+type TracedGormStockTakingRepository struct {
+	next *GormStockTakingRepository
+}
+
+// NewTracedGormStockTakingRepository wraps next with repository tracing
+func NewTracedGormStockTakingRepository(next *GormStockTakingRepository) *TracedGormStockTakingRepository {
+	return &TracedGormStockTakingRepository{next: next}
+}
+
+func (r *TracedGormStockTakingRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status inventory.StockTakingStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockTakingRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockTakingRepository) ExistsByTakingNumber(ctx context.Context, tenantID uuid.UUID, takingNumber string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByTakingNumber(ctx, tenantID, takingNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "ExistsByTakingNumber", tenantID.String())
+	r0, r1 := r.next.ExistsByTakingNumber(ctx, tenantID, takingNumber)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindByDateRange(ctx context.Context, tenantID uuid.UUID, start time.Time, end time.Time, filter shared.Filter) ([]inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByDateRange(ctx, tenantID, start, end, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindByDateRange", tenantID.String())
+	r0, r1 := r.next.FindByDateRange(ctx, tenantID, start, end, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindByID(ctx context.Context, id uuid.UUID) (*inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status inventory.StockTakingStatus, filter shared.Filter) ([]inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindByTakingNumber(ctx context.Context, tenantID uuid.UUID, takingNumber string) (*inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTakingNumber(ctx, tenantID, takingNumber)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindByTakingNumber", tenantID.String())
+	r0, r1 := r.next.FindByTakingNumber(ctx, tenantID, takingNumber)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindByWarehouse(ctx context.Context, tenantID uuid.UUID, warehouseID uuid.UUID, filter shared.Filter) ([]inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindByWarehouse", tenantID.String())
+	r0, r1 := r.next.FindByWarehouse(ctx, tenantID, warehouseID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) FindPendingApproval(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]inventory.StockTaking, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPendingApproval(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "FindPendingApproval", tenantID.String())
+	r0, r1 := r.next.FindPendingApproval(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) GenerateTakingNumber(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GenerateTakingNumber(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "GenerateTakingNumber", tenantID.String())
+	r0, r1 := r.next.GenerateTakingNumber(ctx, tenantID)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormStockTakingRepository) Save(ctx context.Context, st *inventory.StockTaking) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, st)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "Save", "")
+	r0 := r.next.Save(ctx, st)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormStockTakingRepository) SaveWithItems(ctx context.Context, st *inventory.StockTaking) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveWithItems(ctx, st)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "StockTakingRepository", "SaveWithItems", "")
+	r0 := r.next.SaveWithItems(ctx, st)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormSupplierRepository records a span for each GormSupplierRepository call that takes a context
+type TracedGormSupplierRepository struct {
+	next *GormSupplierRepository
+}
+
+// NewTracedGormSupplierRepository wraps next with repository tracing
+func NewTracedGormSupplierRepository(next *GormSupplierRepository) *TracedGormSupplierRepository {
+	return &TracedGormSupplierRepository{next: next}
+}
+
+func (r *TracedGormSupplierRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status partner.SupplierStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) CountByType(ctx context.Context, tenantID uuid.UUID, supplierType partner.SupplierType) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByType(ctx, tenantID, supplierType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "CountByType", tenantID.String())
+	r0, r1 := r.next.CountByType(ctx, tenantID, supplierType)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSupplierRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSupplierRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) ExistsByEmail(ctx context.Context, tenantID uuid.UUID, email string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByEmail(ctx, tenantID, email)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "ExistsByEmail", tenantID.String())
+	r0, r1 := r.next.ExistsByEmail(ctx, tenantID, email)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) ExistsByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByPhone(ctx, tenantID, phone)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "ExistsByPhone", tenantID.String())
+	r0, r1 := r.next.ExistsByPhone(ctx, tenantID, phone)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindActive(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActive(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindActive", tenantID.String())
+	r0, r1 := r.next.FindActive(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindAll(ctx context.Context, filter shared.Filter) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCodes(ctx, tenantID, codes)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByCodes", tenantID.String())
+	r0, r1 := r.next.FindByCodes(ctx, tenantID, codes)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByEmail(ctx, tenantID, email)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByEmail", tenantID.String())
+	r0, r1 := r.next.FindByEmail(ctx, tenantID, email)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByID(ctx context.Context, id uuid.UUID) (*partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, tenantID, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByIDs", tenantID.String())
+	r0, r1 := r.next.FindByIDs(ctx, tenantID, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPhone(ctx, tenantID, phone)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByPhone", tenantID.String())
+	r0, r1 := r.next.FindByPhone(ctx, tenantID, phone)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status partner.SupplierStatus, filter shared.Filter) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindByType(ctx context.Context, tenantID uuid.UUID, supplierType partner.SupplierType, filter shared.Filter) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByType(ctx, tenantID, supplierType, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindByType", tenantID.String())
+	r0, r1 := r.next.FindByType(ctx, tenantID, supplierType, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDeletedByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindDeletedByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindDeletedByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindOverCreditLimit(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindOverCreditLimit(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindOverCreditLimit", tenantID.String())
+	r0, r1 := r.next.FindOverCreditLimit(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) FindWithOutstandingBalance(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Supplier, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindWithOutstandingBalance(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "FindWithOutstandingBalance", tenantID.String())
+	r0, r1 := r.next.FindWithOutstandingBalance(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormSupplierRepository) RestoreForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.RestoreForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "RestoreForTenant", tenantID.String())
+	r0 := r.next.RestoreForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSupplierRepository) Save(ctx context.Context, supplier *partner.Supplier) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, supplier)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "Save", "")
+	r0 := r.next.Save(ctx, supplier)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormSupplierRepository) SaveBatch(ctx context.Context, suppliers []*partner.Supplier) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, suppliers)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "SupplierRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, suppliers)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormTenantRepository records a span for each GormTenantRepository call that takes a context
+type TracedGormTenantRepository struct {
+	next *GormTenantRepository
+}
+
+// NewTracedGormTenantRepository wraps next with repository tracing
+func NewTracedGormTenantRepository(next *GormTenantRepository) *TracedGormTenantRepository {
+	return &TracedGormTenantRepository{next: next}
+}
+
+func (r *TracedGormTenantRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) CountByPlan(ctx context.Context, plan identity.TenantPlan) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByPlan(ctx, plan)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "CountByPlan", "")
+	r0, r1 := r.next.CountByPlan(ctx, plan)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) CountByStatus(ctx context.Context, status identity.TenantStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "CountByStatus", "")
+	r0, r1 := r.next.CountByStatus(ctx, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormTenantRepository) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "ExistsByCode", "")
+	r0, r1 := r.next.ExistsByCode(ctx, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) ExistsByDomain(ctx context.Context, domain string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByDomain(ctx, domain)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "ExistsByDomain", "")
+	r0, r1 := r.next.ExistsByDomain(ctx, domain)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindActive(ctx context.Context, filter shared.Filter) ([]identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActive(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindActive", "")
+	r0, r1 := r.next.FindActive(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindAll(ctx context.Context, filter shared.Filter) ([]identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByCode(ctx context.Context, code string) (*identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByCode", "")
+	r0, r1 := r.next.FindByCode(ctx, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByDomain(ctx context.Context, domain string) (*identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByDomain(ctx, domain)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByDomain", "")
+	r0, r1 := r.next.FindByDomain(ctx, domain)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByIDs", "")
+	r0, r1 := r.next.FindByIDs(ctx, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByPlan(ctx context.Context, plan identity.TenantPlan, filter shared.Filter) ([]identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlan(ctx, plan, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByPlan", "")
+	r0, r1 := r.next.FindByPlan(ctx, plan, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByStatus(ctx context.Context, status identity.TenantStatus, filter shared.Filter) ([]identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByStatus", "")
+	r0, r1 := r.next.FindByStatus(ctx, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByStripeCustomerID(ctx context.Context, customerID string) (*identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStripeCustomerID(ctx, customerID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByStripeCustomerID", "")
+	r0, r1 := r.next.FindByStripeCustomerID(ctx, customerID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindByStripeSubscriptionID(ctx context.Context, subscriptionID string) (*identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStripeSubscriptionID(ctx, subscriptionID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindByStripeSubscriptionID", "")
+	r0, r1 := r.next.FindByStripeSubscriptionID(ctx, subscriptionID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindSubscriptionExpiring(ctx context.Context, withinDays int) ([]identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindSubscriptionExpiring(ctx, withinDays)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindSubscriptionExpiring", "")
+	r0, r1 := r.next.FindSubscriptionExpiring(ctx, withinDays)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) FindTrialExpiring(ctx context.Context, withinDays int) ([]identity.Tenant, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindTrialExpiring(ctx, withinDays)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "FindTrialExpiring", "")
+	r0, r1 := r.next.FindTrialExpiring(ctx, withinDays)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantRepository) Save(ctx context.Context, tenant *identity.Tenant) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, tenant)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantRepository", "Save", "")
+	r0 := r.next.Save(ctx, tenant)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormTenantStrategyConfigRepository records a span for each GormTenantStrategyConfigRepository call that takes a context
+type TracedGormTenantStrategyConfigRepository struct {
+	next *GormTenantStrategyConfigRepository
+}
+
+// NewTracedGormTenantStrategyConfigRepository wraps next with repository tracing
+func NewTracedGormTenantStrategyConfigRepository(next *GormTenantStrategyConfigRepository) *TracedGormTenantStrategyConfigRepository {
+	return &TracedGormTenantStrategyConfigRepository{next: next}
+}
+
+func (r *TracedGormTenantStrategyConfigRepository) FindByTenantID(ctx context.Context, tenantID uuid.UUID) (*strategy.TenantStrategyConfig, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenantID(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantStrategyConfigRepository", "FindByTenantID", tenantID.String())
+	r0, r1 := r.next.FindByTenantID(ctx, tenantID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormTenantStrategyConfigRepository) Save(ctx context.Context, config *strategy.TenantStrategyConfig) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, config)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "TenantStrategyConfigRepository", "Save", "")
+	r0 := r.next.Save(ctx, config)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormUserRepository records a span for each GormUserRepository call that takes a context
+type TracedGormUserRepository struct {
+	next *GormUserRepository
+}
+
+// NewTracedGormUserRepository wraps next with repository tracing
+func NewTracedGormUserRepository(next *GormUserRepository) *TracedGormUserRepository {
+	return &TracedGormUserRepository{next: next}
+}
+
+func (r *TracedGormUserRepository) Count(ctx context.Context) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) Create(ctx context.Context, user *identity.User) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, user)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "Create", "")
+	r0 := r.next.Create(ctx, user)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByEmail(ctx, email)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "ExistsByEmail", "")
+	r0, r1 := r.next.ExistsByEmail(ctx, email)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByUsername(ctx, username)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "ExistsByUsername", "")
+	r0, r1 := r.next.ExistsByUsername(ctx, username)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) FindAll(ctx context.Context, filter identity.UserFilter) ([]*identity.User, int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "FindAll", "")
+	r0, r1, r2 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r2)
+	return r0, r1, r2
+}
+
+func (r *TracedGormUserRepository) FindByEmail(ctx context.Context, email string) (*identity.User, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByEmail(ctx, email)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "FindByEmail", "")
+	r0, r1 := r.next.FindByEmail(ctx, email)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*identity.User, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) FindByPhone(ctx context.Context, phone string) (*identity.User, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPhone(ctx, phone)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "FindByPhone", "")
+	r0, r1 := r.next.FindByPhone(ctx, phone)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) FindByRoleID(ctx context.Context, roleID uuid.UUID) ([]*identity.User, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByRoleID(ctx, roleID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "FindByRoleID", "")
+	r0, r1 := r.next.FindByRoleID(ctx, roleID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) FindByUsername(ctx context.Context, username string) (*identity.User, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByUsername(ctx, username)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "FindByUsername", "")
+	r0, r1 := r.next.FindByUsername(ctx, username)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormUserRepository) LoadUserRoles(ctx context.Context, user *identity.User) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.LoadUserRoles(ctx, user)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "LoadUserRoles", "")
+	r0 := r.next.LoadUserRoles(ctx, user)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormUserRepository) SaveUserRoles(ctx context.Context, user *identity.User) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveUserRoles(ctx, user)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "SaveUserRoles", "")
+	r0 := r.next.SaveUserRoles(ctx, user)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormUserRepository) Update(ctx context.Context, user *identity.User) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Update(ctx, user)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UserRepository", "Update", "")
+	r0 := r.next.Update(ctx, user)
+	span.End(r0)
+	return r0
+}
+
+// TracedGormVoucherIdempotencyKeyRepository records a span for each GormVoucherIdempotencyKeyRepository call that takes a context
+type TracedGormVoucherIdempotencyKeyRepository struct {
+	next *GormVoucherIdempotencyKeyRepository
+}
+
+// NewTracedGormVoucherIdempotencyKeyRepository wraps next with repository tracing
+func NewTracedGormVoucherIdempotencyKeyRepository(next *GormVoucherIdempotencyKeyRepository) *TracedGormVoucherIdempotencyKeyRepository {
+	return &TracedGormVoucherIdempotencyKeyRepository{next: next}
+}
+
+func (r *TracedGormVoucherIdempotencyKeyRepository) Create(ctx context.Context, key *finance.VoucherIdempotencyKey) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Create(ctx, key)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "VoucherIdempotencyKeyRepository", "Create", "")
+	r0 := r.next.Create(ctx, key)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormVoucherIdempotencyKeyRepository) FindByIdempotencyKey(ctx context.Context, tenantID uuid.UUID, voucherType finance.IdempotentVoucherType, key string) (*finance.VoucherIdempotencyKey, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIdempotencyKey(ctx, tenantID, voucherType, key)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "VoucherIdempotencyKeyRepository", "FindByIdempotencyKey", tenantID.String())
+	r0, r1 := r.next.FindByIdempotencyKey(ctx, tenantID, voucherType, key)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedGormWarehouseRepository records a span for each GormWarehouseRepository call that takes a context
+type TracedGormWarehouseRepository struct {
+	next *GormWarehouseRepository
+}
+
+// NewTracedGormWarehouseRepository wraps next with repository tracing
+func NewTracedGormWarehouseRepository(next *GormWarehouseRepository) *TracedGormWarehouseRepository {
+	return &TracedGormWarehouseRepository{next: next}
+}
+
+func (r *TracedGormWarehouseRepository) ClearDefault(ctx context.Context, tenantID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ClearDefault(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "ClearDefault", tenantID.String())
+	r0 := r.next.ClearDefault(ctx, tenantID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormWarehouseRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Count(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "Count", "")
+	r0, r1 := r.next.Count(ctx, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID, status partner.WarehouseStatus) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByStatus(ctx, tenantID, status)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "CountByStatus", tenantID.String())
+	r0, r1 := r.next.CountByStatus(ctx, tenantID, status)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) CountByType(ctx context.Context, tenantID uuid.UUID, warehouseType partner.WarehouseType) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByType(ctx, tenantID, warehouseType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "CountByType", tenantID.String())
+	r0, r1 := r.next.CountByType(ctx, tenantID, warehouseType)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) CountForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "CountForTenant", tenantID.String())
+	r0, r1 := r.next.CountForTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormWarehouseRepository) DeleteForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "DeleteForTenant", tenantID.String())
+	r0 := r.next.DeleteForTenant(ctx, tenantID, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormWarehouseRepository) ExistsByCode(ctx context.Context, tenantID uuid.UUID, code string) (bool, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.ExistsByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "ExistsByCode", tenantID.String())
+	r0, r1 := r.next.ExistsByCode(ctx, tenantID, code)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindActive(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindActive(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindActive", tenantID.String())
+	r0, r1 := r.next.FindActive(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindAll(ctx context.Context, filter shared.Filter) ([]partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAll(ctx, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindAll", "")
+	r0, r1 := r.next.FindAll(ctx, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindAllForTenant(ctx context.Context, tenantID uuid.UUID, filter shared.Filter) ([]partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllForTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindAllForTenant", tenantID.String())
+	r0, r1 := r.next.FindAllForTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCode(ctx, tenantID, code)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindByCode", tenantID.String())
+	r0, r1 := r.next.FindByCode(ctx, tenantID, code)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByCodes(ctx, tenantID, codes)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindByCodes", tenantID.String())
+	r0, r1 := r.next.FindByCodes(ctx, tenantID, codes)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindByID(ctx context.Context, id uuid.UUID) (*partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindByIDForTenant(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDForTenant(ctx, tenantID, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindByIDForTenant", tenantID.String())
+	r0, r1 := r.next.FindByIDForTenant(ctx, tenantID, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindByIDs(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) ([]partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIDs(ctx, tenantID, ids)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindByIDs", tenantID.String())
+	r0, r1 := r.next.FindByIDs(ctx, tenantID, ids)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindByStatus(ctx context.Context, tenantID uuid.UUID, status partner.WarehouseStatus, filter shared.Filter) ([]partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, tenantID, status, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindByStatus", tenantID.String())
+	r0, r1 := r.next.FindByStatus(ctx, tenantID, status, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindByType(ctx context.Context, tenantID uuid.UUID, warehouseType partner.WarehouseType, filter shared.Filter) ([]partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByType(ctx, tenantID, warehouseType, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindByType", tenantID.String())
+	r0, r1 := r.next.FindByType(ctx, tenantID, warehouseType, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) FindDefault(ctx context.Context, tenantID uuid.UUID) (*partner.Warehouse, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindDefault(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "FindDefault", tenantID.String())
+	r0, r1 := r.next.FindDefault(ctx, tenantID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedGormWarehouseRepository) Save(ctx context.Context, warehouse *partner.Warehouse) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, warehouse)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "Save", "")
+	r0 := r.next.Save(ctx, warehouse)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedGormWarehouseRepository) SaveBatch(ctx context.Context, warehouses []*partner.Warehouse) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, warehouses)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "WarehouseRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, warehouses)
+	span.End(r0)
+	return r0
+}
+
+// TracedUsageHistoryRepository records a span for each UsageHistoryRepository call that takes a context
+type TracedUsageHistoryRepository struct {
+	next *UsageHistoryRepository
+}
+
+// NewTracedUsageHistoryRepository wraps next with repository tracing
+func NewTracedUsageHistoryRepository(next *UsageHistoryRepository) *TracedUsageHistoryRepository {
+	return &TracedUsageHistoryRepository{next: next}
+}
+
+func (r *TracedUsageHistoryRepository) CountByTenant(ctx context.Context, tenantID uuid.UUID, filter billing.UsageHistoryFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "CountByTenant", tenantID.String())
+	r0, r1 := r.next.CountByTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageHistoryRepository) DeleteByTenant(ctx context.Context, tenantID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "DeleteByTenant", tenantID.String())
+	r0 := r.next.DeleteByTenant(ctx, tenantID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageHistoryRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteOlderThan(ctx, before)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "DeleteOlderThan", "")
+	r0, r1 := r.next.DeleteOlderThan(ctx, before)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageHistoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*billing.UsageHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageHistoryRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, filter billing.UsageHistoryFilter) ([]*billing.UsageHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "FindByTenant", tenantID.String())
+	r0, r1 := r.next.FindByTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageHistoryRepository) FindByTenantAndDate(ctx context.Context, tenantID uuid.UUID, date time.Time) (*billing.UsageHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenantAndDate(ctx, tenantID, date)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "FindByTenantAndDate", tenantID.String())
+	r0, r1 := r.next.FindByTenantAndDate(ctx, tenantID, date)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageHistoryRepository) FindLatestByTenant(ctx context.Context, tenantID uuid.UUID) (*billing.UsageHistory, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindLatestByTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "FindLatestByTenant", tenantID.String())
+	r0, r1 := r.next.FindLatestByTenant(ctx, tenantID)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageHistoryRepository) GetAllTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetAllTenantIDs(ctx)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "GetAllTenantIDs", "")
+	r0, r1 := r.next.GetAllTenantIDs(ctx)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageHistoryRepository) Save(ctx context.Context, history *billing.UsageHistory) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, history)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "Save", "")
+	r0 := r.next.Save(ctx, history)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageHistoryRepository) SaveBatch(ctx context.Context, histories []*billing.UsageHistory) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, histories)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, histories)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageHistoryRepository) Upsert(ctx context.Context, history *billing.UsageHistory) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Upsert(ctx, history)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageHistoryRepository", "Upsert", "")
+	r0 := r.next.Upsert(ctx, history)
+	span.End(r0)
+	return r0
+}
+
+// TracedUsageMeterRepository records a span for each UsageMeterRepository call that takes a context
+type TracedUsageMeterRepository struct {
+	next *UsageMeterRepository
+}
+
+// NewTracedUsageMeterRepository wraps next with repository tracing
+func NewTracedUsageMeterRepository(next *UsageMeterRepository) *TracedUsageMeterRepository {
+	return &TracedUsageMeterRepository{next: next}
+}
+
+func (r *TracedUsageMeterRepository) CalculateMeter(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, periodStart time.Time, periodEnd time.Time) (*billing.UsageMeter, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CalculateMeter(ctx, tenantID, usageType, periodStart, periodEnd)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "CalculateMeter", tenantID.String())
+	r0, r1 := r.next.CalculateMeter(ctx, tenantID, usageType, periodStart, periodEnd)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageMeterRepository) CalculateSummary(ctx context.Context, tenantID uuid.UUID, periodStart time.Time, periodEnd time.Time) (*billing.UsageSummary, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CalculateSummary(ctx, tenantID, periodStart, periodEnd)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "CalculateSummary", tenantID.String())
+	r0, r1 := r.next.CalculateSummary(ctx, tenantID, periodStart, periodEnd)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageMeterRepository) GetMeter(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, periodStart time.Time, periodEnd time.Time) (*billing.UsageMeter, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetMeter(ctx, tenantID, usageType, periodStart, periodEnd)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "GetMeter", tenantID.String())
+	r0, r1 := r.next.GetMeter(ctx, tenantID, usageType, periodStart, periodEnd)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageMeterRepository) GetSummary(ctx context.Context, tenantID uuid.UUID, periodStart time.Time, periodEnd time.Time) (*billing.UsageSummary, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetSummary(ctx, tenantID, periodStart, periodEnd)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "GetSummary", tenantID.String())
+	r0, r1 := r.next.GetSummary(ctx, tenantID, periodStart, periodEnd)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageMeterRepository) InvalidateAllMeters(ctx context.Context, tenantID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.InvalidateAllMeters(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "InvalidateAllMeters", tenantID.String())
+	r0 := r.next.InvalidateAllMeters(ctx, tenantID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageMeterRepository) InvalidateMeter(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.InvalidateMeter(ctx, tenantID, usageType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "InvalidateMeter", tenantID.String())
+	r0 := r.next.InvalidateMeter(ctx, tenantID, usageType)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageMeterRepository) SetMeter(ctx context.Context, meter *billing.UsageMeter, ttl time.Duration) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SetMeter(ctx, meter, ttl)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "SetMeter", "")
+	r0 := r.next.SetMeter(ctx, meter, ttl)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageMeterRepository) SetSummary(ctx context.Context, summary *billing.UsageSummary, ttl time.Duration) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SetSummary(ctx, summary, ttl)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageMeterRepository", "SetSummary", "")
+	r0 := r.next.SetSummary(ctx, summary, ttl)
+	span.End(r0)
+	return r0
+}
+
+// TracedUsageQuotaRepository records a span for each UsageQuotaRepository call that takes a context
+type TracedUsageQuotaRepository struct {
+	next *UsageQuotaRepository
+}
+
+// NewTracedUsageQuotaRepository wraps next with repository tracing
+func NewTracedUsageQuotaRepository(next *UsageQuotaRepository) *TracedUsageQuotaRepository {
+	return &TracedUsageQuotaRepository{next: next}
+}
+
+func (r *TracedUsageQuotaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Delete(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "Delete", "")
+	r0 := r.next.Delete(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageQuotaRepository) DeleteByTenant(ctx context.Context, tenantID uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteByTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "DeleteByTenant", tenantID.String())
+	r0 := r.next.DeleteByTenant(ctx, tenantID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageQuotaRepository) FindAllEffectiveQuotas(ctx context.Context, tenantID uuid.UUID, planID string) ([]*billing.UsageQuota, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindAllEffectiveQuotas(ctx, tenantID, planID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "FindAllEffectiveQuotas", tenantID.String())
+	r0, r1 := r.next.FindAllEffectiveQuotas(ctx, tenantID, planID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageQuotaRepository) FindByID(ctx context.Context, id uuid.UUID) (*billing.UsageQuota, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageQuotaRepository) FindByPlan(ctx context.Context, planID string) ([]*billing.UsageQuota, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlan(ctx, planID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "FindByPlan", "")
+	r0, r1 := r.next.FindByPlan(ctx, planID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageQuotaRepository) FindByPlanAndType(ctx context.Context, planID string, usageType billing.UsageType) (*billing.UsageQuota, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByPlanAndType(ctx, planID, usageType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "FindByPlanAndType", "")
+	r0, r1 := r.next.FindByPlanAndType(ctx, planID, usageType)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageQuotaRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID) ([]*billing.UsageQuota, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenant(ctx, tenantID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "FindByTenant", tenantID.String())
+	r0, r1 := r.next.FindByTenant(ctx, tenantID)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageQuotaRepository) FindByTenantAndType(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType) (*billing.UsageQuota, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenantAndType(ctx, tenantID, usageType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "FindByTenantAndType", tenantID.String())
+	r0, r1 := r.next.FindByTenantAndType(ctx, tenantID, usageType)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageQuotaRepository) FindEffectiveQuota(ctx context.Context, tenantID uuid.UUID, planID string, usageType billing.UsageType) (*billing.UsageQuota, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindEffectiveQuota(ctx, tenantID, planID, usageType)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "FindEffectiveQuota", tenantID.String())
+	r0, r1 := r.next.FindEffectiveQuota(ctx, tenantID, planID, usageType)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageQuotaRepository) Save(ctx context.Context, quota *billing.UsageQuota) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, quota)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "Save", "")
+	r0 := r.next.Save(ctx, quota)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageQuotaRepository) Update(ctx context.Context, quota *billing.UsageQuota) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Update(ctx, quota)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageQuotaRepository", "Update", "")
+	r0 := r.next.Update(ctx, quota)
+	span.End(r0)
+	return r0
+}
+
+// TracedUsageRecordRepository records a span for each UsageRecordRepository call that takes a context
+type TracedUsageRecordRepository struct {
+	next *UsageRecordRepository
+}
+
+// NewTracedUsageRecordRepository wraps next with repository tracing
+func NewTracedUsageRecordRepository(next *UsageRecordRepository) *TracedUsageRecordRepository {
+	return &TracedUsageRecordRepository{next: next}
+}
+
+func (r *TracedUsageRecordRepository) CountByTenant(ctx context.Context, tenantID uuid.UUID, filter billing.UsageRecordFilter) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.CountByTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "CountByTenant", tenantID.String())
+	r0, r1 := r.next.CountByTenant(ctx, tenantID, filter)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageRecordRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteOlderThan(ctx, before)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "DeleteOlderThan", "")
+	r0, r1 := r.next.DeleteOlderThan(ctx, before)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageRecordRepository) FindByID(ctx context.Context, id uuid.UUID) (*billing.UsageRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageRecordRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, filter billing.UsageRecordFilter) ([]*billing.UsageRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenant(ctx, tenantID, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "FindByTenant", tenantID.String())
+	r0, r1 := r.next.FindByTenant(ctx, tenantID, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageRecordRepository) FindByTenantAndType(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, filter billing.UsageRecordFilter) ([]*billing.UsageRecord, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenantAndType(ctx, tenantID, usageType, filter)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "FindByTenantAndType", tenantID.String())
+	r0, r1 := r.next.FindByTenantAndType(ctx, tenantID, usageType, filter)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageRecordRepository) GetAggregatedUsage(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, start time.Time, end time.Time, groupBy billing.AggregationPeriod) ([]billing.UsageAggregation, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetAggregatedUsage(ctx, tenantID, usageType, start, end, groupBy)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "GetAggregatedUsage", tenantID.String())
+	r0, r1 := r.next.GetAggregatedUsage(ctx, tenantID, usageType, start, end, groupBy)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageRecordRepository) Save(ctx context.Context, record *billing.UsageRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, record)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "Save", "")
+	r0 := r.next.Save(ctx, record)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageRecordRepository) SaveBatch(ctx context.Context, records []*billing.UsageRecord) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SaveBatch(ctx, records)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "SaveBatch", "")
+	r0 := r.next.SaveBatch(ctx, records)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageRecordRepository) SumByTenantAndType(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, start time.Time, end time.Time) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.SumByTenantAndType(ctx, tenantID, usageType, start, end)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageRecordRepository", "SumByTenantAndType", tenantID.String())
+	r0, r1 := r.next.SumByTenantAndType(ctx, tenantID, usageType, start, end)
+	span.End(r1)
+	return r0, r1
+}
+
+// TracedUsageReportLogRepository records a span for each UsageReportLogRepository call that takes a context
+type TracedUsageReportLogRepository struct {
+	next *UsageReportLogRepository
+}
+
+// NewTracedUsageReportLogRepository wraps next with repository tracing
+func NewTracedUsageReportLogRepository(next *UsageReportLogRepository) *TracedUsageReportLogRepository {
+	return &TracedUsageReportLogRepository{next: next}
+}
+
+func (r *TracedUsageReportLogRepository) DeleteOldLogs(ctx context.Context, before time.Time) (int64, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.DeleteOldLogs(ctx, before)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "DeleteOldLogs", "")
+	r0, r1 := r.next.DeleteOldLogs(ctx, before)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageReportLogRepository) FindByID(ctx context.Context, id uuid.UUID) (*infraBilling.UsageReportLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByID(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "FindByID", "")
+	r0, r1 := r.next.FindByID(ctx, id)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageReportLogRepository) FindByIdempotencyKey(ctx context.Context, key string) (*infraBilling.UsageReportLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByIdempotencyKey(ctx, key)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "FindByIdempotencyKey", "")
+	r0, r1 := r.next.FindByIdempotencyKey(ctx, key)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageReportLogRepository) FindByStatus(ctx context.Context, status infraBilling.UsageReportStatus, limit int) ([]*infraBilling.UsageReportLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByStatus(ctx, status, limit)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "FindByStatus", "")
+	r0, r1 := r.next.FindByStatus(ctx, status, limit)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageReportLogRepository) FindByTenant(ctx context.Context, tenantID uuid.UUID, start time.Time, end time.Time) ([]*infraBilling.UsageReportLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindByTenant(ctx, tenantID, start, end)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "FindByTenant", tenantID.String())
+	r0, r1 := r.next.FindByTenant(ctx, tenantID, start, end)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageReportLogRepository) FindPending(ctx context.Context, maxRetries int) ([]*infraBilling.UsageReportLog, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.FindPending(ctx, maxRetries)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "FindPending", "")
+	r0, r1 := r.next.FindPending(ctx, maxRetries)
+	span.SetRowCount(len(r0))
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageReportLogRepository) GetReportingSummary(ctx context.Context, tenantID uuid.UUID, start time.Time, end time.Time) (*UsageReportingSummary, error) {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.GetReportingSummary(ctx, tenantID, start, end)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "GetReportingSummary", tenantID.String())
+	r0, r1 := r.next.GetReportingSummary(ctx, tenantID, start, end)
+	span.SetFound(r0 != nil)
+	span.End(r1)
+	return r0, r1
+}
+
+func (r *TracedUsageReportLogRepository) IncrementRetryCount(ctx context.Context, id uuid.UUID) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.IncrementRetryCount(ctx, id)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "IncrementRetryCount", "")
+	r0 := r.next.IncrementRetryCount(ctx, id)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageReportLogRepository) MarkAsFailed(ctx context.Context, id uuid.UUID, errorMessage string) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.MarkAsFailed(ctx, id, errorMessage)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "MarkAsFailed", "")
+	r0 := r.next.MarkAsFailed(ctx, id, errorMessage)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageReportLogRepository) MarkAsSuccess(ctx context.Context, id uuid.UUID, stripeRecordID string) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.MarkAsSuccess(ctx, id, stripeRecordID)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "MarkAsSuccess", "")
+	r0 := r.next.MarkAsSuccess(ctx, id, stripeRecordID)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageReportLogRepository) Save(ctx context.Context, log *infraBilling.UsageReportLog) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Save(ctx, log)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "Save", "")
+	r0 := r.next.Save(ctx, log)
+	span.End(r0)
+	return r0
+}
+
+func (r *TracedUsageReportLogRepository) Update(ctx context.Context, log *infraBilling.UsageReportLog) error {
+	if !telemetry.RepositoryTracingEnabled() {
+		return r.next.Update(ctx, log)
+	}
+	ctx, span := telemetry.StartRepositorySpan(ctx, "UsageReportLogRepository", "Update", "")
+	r0 := r.next.Update(ctx, log)
+	span.End(r0)
+	return r0
+}
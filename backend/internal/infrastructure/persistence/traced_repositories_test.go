@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/erp/backend/internal/infrastructure/telemetry"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+)
+
+// installTestTracer records spans in memory and sets repository tracing on or off for the test
+func installTestTracer(t *testing.T, enabled bool) *tracetest.SpanRecorder {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	telemetry.SetRepositoryTracingEnabled(enabled)
+
+	t.Cleanup(func() {
+		telemetry.SetRepositoryTracingEnabled(false)
+		otel.SetTracerProvider(original)
+		_ = tp.Shutdown(context.Background())
+	})
+	return sr
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracedRepository_RecordsChildSpan(t *testing.T) {
+	sr := installTestTracer(t, true)
+	repo, mock, mockDB := newMockCustomerRepository(t)
+	defer mockDB.Close()
+	traced := NewTracedGormCustomerRepository(repo)
+
+	customerID, tenantID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "customers" WHERE \(tenant_id = \$1 AND id = \$2\) AND "customers"\."deleted_at" IS NULL ORDER BY .* LIMIT .*`).
+		WithArgs(tenantID, customerID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "code", "name", "type", "level", "status", "balance", "credit_limit"}).
+			AddRow(customerID, tenantID, "CUST001", "Test Customer", "individual", "normal", "active", decimal.Zero, decimal.Zero))
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GET /customers/:id")
+	customer, err := traced.FindByIDForTenant(ctx, tenantID, customerID)
+	parent.End()
+
+	require.NoError(t, err)
+	require.NotNil(t, customer)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "repo.CustomerRepository.FindByIDForTenant", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	attrs := spanAttributes(span)
+	assert.Equal(t, tenantID.String(), attrs[telemetry.SpanAttrTenantID].AsString())
+	assert.Equal(t, int64(1), attrs[telemetry.SpanAttrRowCount].AsInt64())
+	assert.NotEqual(t, codes.Error, span.Status().Code)
+}
+
+func TestTracedRepository_RecordsErrorAndRowCount(t *testing.T) {
+	sr := installTestTracer(t, true)
+	repo, mock, mockDB := newMockCustomerRepository(t)
+	defer mockDB.Close()
+	traced := NewTracedGormCustomerRepository(repo)
+
+	mock.ExpectQuery(`SELECT \* FROM "customers"`).WillReturnError(gorm.ErrInvalidDB)
+
+	customers, err := traced.FindByIDs(context.Background(), uuid.New(), []uuid.UUID{uuid.New()})
+
+	require.Error(t, err)
+	assert.Empty(t, customers)
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "repo.CustomerRepository.FindByIDs", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, int64(0), spanAttributes(spans[0])[telemetry.SpanAttrRowCount].AsInt64())
+}
+
+func TestTracedRepository_DisabledRecordsNothing(t *testing.T) {
+	sr := installTestTracer(t, false)
+	repo, _, mockDB := newMockCustomerRepository(t)
+	defer mockDB.Close()
+	traced := NewTracedGormCustomerRepository(repo)
+
+	// An empty ID list returns without querying
+	customers, err := traced.FindByIDs(context.Background(), uuid.New(), nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, customers)
+	assert.Empty(t, sr.Ended())
+
+	// The decorator adds no allocations over calling the repository directly
+	ctx, tenantID := context.Background(), uuid.New()
+	direct := testing.AllocsPerRun(100, func() { _, _ = repo.FindByIDs(ctx, tenantID, nil) })
+	wrapped := testing.AllocsPerRun(100, func() { _, _ = traced.FindByIDs(ctx, tenantID, nil) })
+	assert.Equal(t, direct, wrapped)
+}
@@ -235,17 +235,23 @@ func (r *UsageRecordRepository) GetAggregatedUsage(ctx context.Context, tenantID
 		AvgUsage    float64
 	}
 
+	// Bucket by the calendar of start's time zone, so that e.g. days start at
+	// local midnight and period keys parse back in that zone
+	periodExpr, periodArgs := "TO_CHAR(recorded_at, ?)", []any{dateFormat}
+	if tz := start.Location().String(); tz != "Local" {
+		periodExpr, periodArgs = "TO_CHAR(recorded_at AT TIME ZONE ?, ?)", []any{tz, dateFormat}
+	}
+
 	var results []aggregationResult
 	err := r.db.WithContext(ctx).
 		Model(&UsageRecordModel{}).
-		Select(`
-			TO_CHAR(recorded_at, ?) as period_key,
+		Select(periodExpr+` as period_key,
 			SUM(quantity) as total_usage,
 			COUNT(*) as record_count,
 			MIN(quantity) as min_usage,
 			MAX(quantity) as max_usage,
 			AVG(quantity) as avg_usage
-		`, dateFormat).
+		`, periodArgs...).
 		Where("tenant_id = ?", tenantID).
 		Where("usage_type = ?", string(usageType)).
 		Where("recorded_at >= ?", start).
@@ -18,6 +18,7 @@ type UsageReportLogModel struct {
 	UsageType          string    `gorm:"type:varchar(50);not null"`
 	Quantity           int64     `gorm:"not null"`
 	Timestamp          time.Time `gorm:"not null"`
+	IdempotencyKey     *string   `gorm:"type:varchar(255);uniqueIndex"`
 	StripeRecordID     string    `gorm:"type:varchar(255)"`
 	Status             string    `gorm:"type:varchar(20);not null;index"`
 	ErrorMessage       string    `gorm:"type:text"`
@@ -34,6 +35,10 @@ func (UsageReportLogModel) TableName() string {
 // ToEntity converts the model to a domain entity
 func (m *UsageReportLogModel) ToEntity() *infraBilling.UsageReportLog {
 	usageType, _ := billing.ParseUsageType(m.UsageType)
+	var idempotencyKey string
+	if m.IdempotencyKey != nil {
+		idempotencyKey = *m.IdempotencyKey
+	}
 	return &infraBilling.UsageReportLog{
 		ID:                 m.ID,
 		TenantID:           m.TenantID,
@@ -41,6 +46,7 @@ func (m *UsageReportLogModel) ToEntity() *infraBilling.UsageReportLog {
 		UsageType:          usageType,
 		Quantity:           m.Quantity,
 		Timestamp:          m.Timestamp,
+		IdempotencyKey:     idempotencyKey,
 		StripeRecordID:     m.StripeRecordID,
 		Status:             infraBilling.UsageReportStatus(m.Status),
 		ErrorMessage:       m.ErrorMessage,
@@ -52,6 +58,11 @@ func (m *UsageReportLogModel) ToEntity() *infraBilling.UsageReportLog {
 
 // FromEntity creates a model from a domain entity
 func UsageReportLogModelFromEntity(e *infraBilling.UsageReportLog) *UsageReportLogModel {
+	// Logs without a key are stored as NULL so they don't collide on the unique index
+	var idempotencyKey *string
+	if e.IdempotencyKey != "" {
+		idempotencyKey = &e.IdempotencyKey
+	}
 	return &UsageReportLogModel{
 		ID:                 e.ID,
 		TenantID:           e.TenantID,
@@ -59,6 +70,7 @@ func UsageReportLogModelFromEntity(e *infraBilling.UsageReportLog) *UsageReportL
 		UsageType:          e.UsageType.String(),
 		Quantity:           e.Quantity,
 		Timestamp:          e.Timestamp,
+		IdempotencyKey:     idempotencyKey,
 		StripeRecordID:     e.StripeRecordID,
 		Status:             e.Status.String(),
 		ErrorMessage:       e.ErrorMessage,
@@ -102,6 +114,18 @@ func (r *UsageReportLogRepository) FindByID(ctx context.Context, id uuid.UUID) (
 	return model.ToEntity(), nil
 }
 
+// FindByIdempotencyKey retrieves a usage report log by idempotency key
+func (r *UsageReportLogRepository) FindByIdempotencyKey(ctx context.Context, key string) (*infraBilling.UsageReportLog, error) {
+	var model UsageReportLogModel
+	if err := r.db.WithContext(ctx).First(&model, "idempotency_key = ?", key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
 // FindPending retrieves all pending usage reports for retry
 func (r *UsageReportLogRepository) FindPending(ctx context.Context, maxRetries int) ([]*infraBilling.UsageReportLog, error) {
 	var models []UsageReportLogModel
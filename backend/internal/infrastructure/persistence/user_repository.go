@@ -276,6 +276,11 @@ func (r *GormUserRepository) applyFilter(query *gorm.DB, filter identity.UserFil
 		query = query.Where("status = ?", *filter.Status)
 	}
 
+	// Apply tenant filter
+	if filter.TenantID != nil {
+		query = query.Where("users.tenant_id = ?", *filter.TenantID)
+	}
+
 	// Apply role filter
 	if filter.RoleID != nil {
 		query = query.Joins("JOIN user_roles ON users.id = user_roles.user_id").
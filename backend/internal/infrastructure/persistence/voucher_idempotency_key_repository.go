@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/persistence/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormVoucherIdempotencyKeyRepository implements VoucherIdempotencyKeyRepository using GORM
+type GormVoucherIdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormVoucherIdempotencyKeyRepository creates a new GormVoucherIdempotencyKeyRepository
+func NewGormVoucherIdempotencyKeyRepository(db *gorm.DB) *GormVoucherIdempotencyKeyRepository {
+	return &GormVoucherIdempotencyKeyRepository{db: db}
+}
+
+// FindByIdempotencyKey finds the unexpired key of the given voucher type for a tenant
+func (r *GormVoucherIdempotencyKeyRepository) FindByIdempotencyKey(ctx context.Context, tenantID uuid.UUID, voucherType finance.IdempotentVoucherType, key string) (*finance.VoucherIdempotencyKey, error) {
+	var model models.VoucherIdempotencyKeyModel
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND voucher_type = ? AND idempotency_key = ? AND expires_at > ?",
+			tenantID, voucherType, key, time.Now()).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// Create stores a new key, replacing an expired one with the same value.
+// The insert relies on the unique index: PostgreSQL blocks a conflicting insert until the
+// transaction holding the other row finishes, then either skips it or lets it through.
+func (r *GormVoucherIdempotencyKeyRepository) Create(ctx context.Context, key *finance.VoucherIdempotencyKey) error {
+	db := r.db.WithContext(ctx)
+
+	if err := db.
+		Where("tenant_id = ? AND voucher_type = ? AND idempotency_key = ? AND expires_at <= ?",
+			key.TenantID, key.VoucherType, key.Key, time.Now()).
+		Delete(&models.VoucherIdempotencyKeyModel{}).Error; err != nil {
+		return err
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(models.VoucherIdempotencyKeyModelFromDomain(key))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrAlreadyExists
+	}
+	return nil
+}
+
+// Ensure GormVoucherIdempotencyKeyRepository implements the interface
+var _ finance.VoucherIdempotencyKeyRepository = (*GormVoucherIdempotencyKeyRepository)(nil)
@@ -0,0 +1,102 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newMockVoucherIdempotencyKeyRepository creates a GormVoucherIdempotencyKeyRepository with a mocked SQL connection
+func newMockVoucherIdempotencyKeyRepository(t *testing.T) (*GormVoucherIdempotencyKeyRepository, sqlmock.Sqlmock, *sql.DB) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	dialector := postgres.New(postgres.Config{
+		Conn:       mockDB,
+		DriverName: "postgres",
+	})
+
+	gormDB, err := gorm.Open(dialector, &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	return NewGormVoucherIdempotencyKeyRepository(gormDB), mock, mockDB
+}
+
+func TestGormVoucherIdempotencyKeyRepository_FindByIdempotencyKey(t *testing.T) {
+	tenantID := uuid.New()
+	voucherID := uuid.New()
+
+	t.Run("returns unexpired key", func(t *testing.T) {
+		repo, mock, mockDB := newMockVoucherIdempotencyKeyRepository(t)
+		defer mockDB.Close()
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT \* FROM "voucher_idempotency_keys" WHERE tenant_id = \$1 AND voucher_type = \$2 AND idempotency_key = \$3 AND expires_at > \$4`).
+			WithArgs(tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1", sqlmock.AnyArg(), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "voucher_type", "idempotency_key", "voucher_id", "created_at", "expires_at"}).
+				AddRow(uuid.New(), tenantID, "RECEIPT", "retry-1", voucherID, now, now.Add(time.Hour)))
+
+		key, err := repo.FindByIdempotencyKey(context.Background(), tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1")
+		require.NoError(t, err)
+		assert.Equal(t, voucherID, key.VoucherID)
+		assert.Equal(t, "retry-1", key.Key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns not found when missing or expired", func(t *testing.T) {
+		repo, mock, mockDB := newMockVoucherIdempotencyKeyRepository(t)
+		defer mockDB.Close()
+
+		mock.ExpectQuery(`SELECT \* FROM "voucher_idempotency_keys"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.FindByIdempotencyKey(context.Background(), tenantID, finance.IdempotentVoucherTypeReceipt, "retry-1")
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGormVoucherIdempotencyKeyRepository_Create(t *testing.T) {
+	key, err := finance.NewVoucherIdempotencyKey(uuid.New(), finance.IdempotentVoucherTypePayment, "retry-1", uuid.New(), time.Hour)
+	require.NoError(t, err)
+
+	t.Run("replaces expired key and inserts", func(t *testing.T) {
+		repo, mock, mockDB := newMockVoucherIdempotencyKeyRepository(t)
+		defer mockDB.Close()
+
+		mock.ExpectExec(`DELETE FROM "voucher_idempotency_keys" WHERE tenant_id = \$1 AND voucher_type = \$2 AND idempotency_key = \$3 AND expires_at <= \$4`).
+			WithArgs(key.TenantID, key.VoucherType, key.Key, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "voucher_idempotency_keys" .* ON CONFLICT DO NOTHING`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.Create(context.Background(), key))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns already exists when an unexpired key conflicts", func(t *testing.T) {
+		repo, mock, mockDB := newMockVoucherIdempotencyKeyRepository(t)
+		defer mockDB.Close()
+
+		mock.ExpectExec(`DELETE FROM "voucher_idempotency_keys"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO "voucher_idempotency_keys" .* ON CONFLICT DO NOTHING`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Create(context.Background(), key)
+		assert.ErrorIs(t, err, shared.ErrAlreadyExists)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
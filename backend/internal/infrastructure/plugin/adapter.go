@@ -1,6 +1,9 @@
 package plugin
 
 import (
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/plugin"
 	infraStrategy "github.com/erp/backend/internal/infrastructure/strategy"
 )
@@ -40,5 +43,26 @@ func (a *StrategyRegistryAdapter) RegisterValidationStrategy(s any) error {
 	return a.registry.RegisterValidationStrategyAny(s)
 }
 
-// Ensure StrategyRegistryAdapter implements StrategyRegistrar interface
-var _ plugin.StrategyRegistrar = (*StrategyRegistryAdapter)(nil)
+// UnregisterStrategy implements StrategyUnregistrar.UnregisterStrategy
+func (a *StrategyRegistryAdapter) UnregisterStrategy(kind plugin.StrategyKind, name string) error {
+	switch kind {
+	case plugin.StrategyKindCost:
+		return a.registry.UnregisterCostStrategy(name)
+	case plugin.StrategyKindPricing:
+		return a.registry.UnregisterPricingStrategy(name)
+	case plugin.StrategyKindAllocation:
+		return a.registry.UnregisterAllocationStrategy(name)
+	case plugin.StrategyKindBatch:
+		return a.registry.UnregisterBatchStrategy(name)
+	case plugin.StrategyKindValidation:
+		return a.registry.UnregisterValidationStrategy(name)
+	default:
+		return fmt.Errorf("%w: unknown strategy kind '%s'", shared.ErrInvalidInput, kind)
+	}
+}
+
+// Ensure StrategyRegistryAdapter implements StrategyRegistrar and StrategyUnregistrar interfaces
+var (
+	_ plugin.StrategyRegistrar   = (*StrategyRegistryAdapter)(nil)
+	_ plugin.StrategyUnregistrar = (*StrategyRegistryAdapter)(nil)
+)
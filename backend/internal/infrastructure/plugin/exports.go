@@ -14,6 +14,8 @@ type (
 	PluginManager = domainPlugin.PluginManager
 	// StrategyRegistrar is a re-export of the domain StrategyRegistrar interface
 	StrategyRegistrar = domainPlugin.StrategyRegistrar
+	// PluginConflictError is a re-export of the domain PluginConflictError type
+	PluginConflictError = domainPlugin.PluginConflictError
 )
 
 // NewPluginManager creates a new plugin manager (re-export from domain)
@@ -84,10 +84,11 @@ func TestPluginManager_Register_Duplicate(t *testing.T) {
 	err := manager.Register(plugin)
 	require.NoError(t, err)
 
-	// Try to register same plugin again
-	err = manager.Register(plugin)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "already registered")
+	// Registering the same plugin again is a no-op
+	err = manager.Register(NewAgriculturalPlugin())
+	require.NoError(t, err)
+	assert.Equal(t, 1, manager.Count())
+	assert.Len(t, registry.validationStrategies, 1)
 }
 
 func TestPluginManager_GetPlugin(t *testing.T) {
@@ -0,0 +1,270 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/erp/backend/internal/domain/shared/plugin"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+)
+
+// Storage temperature range (°C) accepted for retail and food-service products,
+// from deep-frozen goods to hot-held food
+const (
+	minStorageTemperature = -40.0
+	maxStorageTemperature = 60.0
+)
+
+// allergenNone declares that a product contains none of the listed allergens
+const allergenNone = "none"
+
+// Allergens lists the allergens a retail/F&B product can declare in its "allergens" attribute
+var Allergens = []string{
+	"milk",
+	"eggs",
+	"fish",
+	"shellfish",
+	"tree_nuts",
+	"peanuts",
+	"wheat",
+	"soybeans",
+	"sesame",
+}
+
+// RetailFnBPlugin is the industry plugin for retail and food-service (F&B) tenants.
+// It requires shelf life, storage temperature and allergen attributes on products
+// and contributes a markdown pricing strategy for stock approaching its expiry date.
+type RetailFnBPlugin struct {
+	expiryProvider ExpiryDateProvider
+}
+
+// NewRetailFnBPlugin creates a new retail/F&B industry plugin
+func NewRetailFnBPlugin() *RetailFnBPlugin {
+	return &RetailFnBPlugin{}
+}
+
+// SetExpiryProvider sets the provider of stock expiry dates used by the markdown pricing strategy.
+// It must be called before the plugin is registered; without it the strategy returns the base price.
+func (p *RetailFnBPlugin) SetExpiryProvider(provider ExpiryDateProvider) {
+	p.expiryProvider = provider
+}
+
+// Name returns the unique identifier for the plugin
+func (p *RetailFnBPlugin) Name() string {
+	return "retail_fnb"
+}
+
+// DisplayName returns the human-readable name for the plugin
+func (p *RetailFnBPlugin) DisplayName() string {
+	return "零售餐饮行业"
+}
+
+// RegisterStrategies registers retail/F&B-specific strategies with the registry
+func (p *RetailFnBPlugin) RegisterStrategies(registry plugin.StrategyRegistrar) {
+	// Register retail/F&B product validation strategy
+	_ = registry.RegisterValidationStrategy(NewRetailFnBProductValidator())
+
+	// Register markdown pricing for stock approaching expiry
+	markdown := NewExpiryMarkdownPricingStrategy(DefaultMarkdownRules())
+	markdown.SetProvider(p.expiryProvider)
+	_ = registry.RegisterPricingStrategy(markdown)
+}
+
+// GetRequiredProductAttributes returns the attribute definitions for retail/F&B products
+func (p *RetailFnBPlugin) GetRequiredProductAttributes() []plugin.AttributeDefinition {
+	return []plugin.AttributeDefinition{
+		{
+			Key:           "shelf_life_days",
+			Label:         "保质期(天)",
+			Required:      true,
+			Regex:         `^[1-9]\d*$`,
+			CategoryCodes: []string{},
+		},
+		{
+			Key:           "storage_temperature",
+			Label:         "储存温度(°C)",
+			Required:      true,
+			Regex:         `^-?\d+(\.\d+)?$`,
+			CategoryCodes: []string{},
+		},
+		{
+			Key:           "allergens",
+			Label:         "过敏原",
+			Required:      true, // "none" declares a product free of allergens
+			Regex:         allergenListRegex(),
+			CategoryCodes: []string{},
+		},
+	}
+}
+
+// allergenListRegex matches "none" or a comma-separated list of known allergens, ignoring case
+func allergenListRegex() string {
+	allergen := "(" + strings.Join(Allergens, "|") + ")"
+	return fmt.Sprintf(`(?i)^(%s|%s(\s*,\s*%s)*)$`, allergenNone, allergen, allergen)
+}
+
+// RetailFnBProductValidator validates products for retail and food-service tenants
+type RetailFnBProductValidator struct {
+	strategy.BaseStrategy
+}
+
+// NewRetailFnBProductValidator creates a new retail/F&B product validator
+func NewRetailFnBProductValidator() *RetailFnBProductValidator {
+	return &RetailFnBProductValidator{
+		BaseStrategy: strategy.NewBaseStrategy(
+			"retail_fnb",
+			strategy.StrategyTypeValidation,
+			"Retail and food-service product validation with shelf life, storage temperature and allergen requirements",
+		),
+	}
+}
+
+// Validate validates retail/F&B product data
+func (v *RetailFnBProductValidator) Validate(
+	ctx context.Context,
+	valCtx strategy.ValidationContext,
+	data strategy.ProductData,
+) (strategy.ValidationResult, error) {
+	result := strategy.ValidationResult{
+		IsValid:  true,
+		Errors:   make([]strategy.ValidationError, 0),
+		Warnings: make([]strategy.ValidationWarning, 0),
+	}
+
+	// Validate basic required fields (same as standard validator)
+	if strings.TrimSpace(data.SKU) == "" {
+		result.AddError("sku", "REQUIRED", "SKU is required")
+	}
+
+	if strings.TrimSpace(data.Name) == "" {
+		result.AddError("name", "REQUIRED", "Product name is required")
+	}
+
+	// Validate price and cost
+	if data.Price.IsNegative() {
+		result.AddError("price", "INVALID", "Price cannot be negative")
+	}
+
+	if data.Cost.IsNegative() {
+		result.AddError("cost", "INVALID", "Cost cannot be negative")
+	}
+
+	for _, field := range []string{"shelf_life_days", "storage_temperature", "allergens"} {
+		errs, _ := v.ValidateField(ctx, "attributes."+field, attributeValue(data.Attributes, field))
+		for _, err := range errs {
+			result.AddError(err.Field, err.Code, err.Message)
+		}
+	}
+
+	return result, nil
+}
+
+// ValidateField validates a single field
+func (v *RetailFnBProductValidator) ValidateField(
+	ctx context.Context,
+	field string,
+	value any,
+) ([]strategy.ValidationError, error) {
+	errors := make([]strategy.ValidationError, 0)
+	addError := func(code, message string) {
+		errors = append(errors, strategy.ValidationError{
+			Field:    field,
+			Code:     code,
+			Message:  message,
+			Severity: strategy.ValidationSeverityError,
+		})
+	}
+
+	switch field {
+	case "sku", "name":
+		if str, ok := value.(string); ok && strings.TrimSpace(str) == "" {
+			addError("REQUIRED", field+" is required")
+		}
+	case "attributes.shelf_life_days":
+		text := strings.TrimSpace(attributeText(value))
+		if text == "" {
+			addError("REQUIRED", "保质期是必填项")
+		} else if days, err := strconv.Atoi(text); err != nil || days <= 0 {
+			addError("INVALID_FORMAT", "保质期应为正整数天数")
+		}
+	case "attributes.storage_temperature":
+		text := strings.TrimSpace(attributeText(value))
+		if text == "" {
+			addError("REQUIRED", "储存温度是必填项")
+		} else if temperature, err := strconv.ParseFloat(text, 64); err != nil {
+			addError("INVALID_FORMAT", "储存温度应为数字(°C)")
+		} else if temperature < minStorageTemperature || temperature > maxStorageTemperature {
+			addError("OUT_OF_RANGE",
+				fmt.Sprintf("储存温度应在%g°C到%g°C之间", minStorageTemperature, maxStorageTemperature))
+		}
+	case "attributes.allergens":
+		allergens := allergenList(value)
+		if len(allergens) == 0 {
+			addError("REQUIRED", "过敏原是必填项，无过敏原请填写none")
+			break
+		}
+		for _, allergen := range allergens {
+			if allergen == allergenNone && len(allergens) == 1 {
+				continue
+			}
+			if !slices.Contains(Allergens, allergen) {
+				addError("INVALID_VALUE",
+					fmt.Sprintf("未知过敏原%q，可选值为: %s", allergen, strings.Join(Allergens, ", ")))
+			}
+		}
+	}
+
+	return errors, nil
+}
+
+// attributeValue safely gets an attribute from the attributes map
+func attributeValue(attrs map[string]any, key string) any {
+	if attrs == nil {
+		return nil
+	}
+	return attrs[key]
+}
+
+// attributeText returns a string or numeric attribute value as text
+func attributeText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// allergenList returns the allergens of a comma-separated string or a list attribute, lower-cased
+func allergenList(value any) []string {
+	var items []string
+	switch v := value.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []string:
+		items = v
+	case []any:
+		for _, item := range v {
+			items = append(items, attributeText(item))
+		}
+	}
+
+	allergens := make([]string, 0, len(items))
+	for _, item := range items {
+		if allergen := strings.ToLower(strings.TrimSpace(item)); allergen != "" {
+			allergens = append(allergens, allergen)
+		}
+	}
+	return allergens
+}
+
+// Ensure RetailFnBPlugin implements IndustryPlugin interface
+var _ plugin.IndustryPlugin = (*RetailFnBPlugin)(nil)
+
+// Ensure RetailFnBProductValidator implements ProductValidationStrategy interface
+var _ strategy.ProductValidationStrategy = (*RetailFnBProductValidator)(nil)
@@ -0,0 +1,209 @@
+package plugin
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MarkdownRule discounts stock that expires within a number of days
+type MarkdownRule struct {
+	// WithinDays is the largest number of days to expiry the rule applies to
+	WithinDays int
+	// DiscountPercent is the markdown off the base price, e.g. 30 for 30% off
+	DiscountPercent decimal.Decimal
+}
+
+// DefaultMarkdownRules returns the markdowns for perishable goods:
+// 10% off within a week of expiry, 30% off within 3 days and 50% off on the last day
+func DefaultMarkdownRules() []MarkdownRule {
+	return []MarkdownRule{
+		{WithinDays: 7, DiscountPercent: decimal.NewFromInt(10)},
+		{WithinDays: 3, DiscountPercent: decimal.NewFromInt(30)},
+		{WithinDays: 1, DiscountPercent: decimal.NewFromInt(50)},
+	}
+}
+
+// ExpiryDateProvider provides the expiry date of the stock a product is sold from.
+// This interface allows the markdown pricing strategy to price by expiry
+// without depending on the inventory repositories.
+type ExpiryDateProvider interface {
+	// GetEarliestExpiryDate returns the earliest expiry date of the available stock of a product.
+	// Returns false if no available stock of the product has an expiry date.
+	GetEarliestExpiryDate(ctx context.Context, tenantID, productID uuid.UUID) (time.Time, bool, error)
+}
+
+// ExpiryMarkdownPricingStrategy marks down products whose stock is approaching its expiry date.
+// The stock sold first (first-expired, first-out) determines the markdown: the rule with the
+// fewest days that still covers the days to expiry applies. Without a provider, or when the
+// product has no dated stock, the base price applies.
+type ExpiryMarkdownPricingStrategy struct {
+	strategy.BaseStrategy
+	rules    []MarkdownRule
+	provider ExpiryDateProvider
+}
+
+// NewExpiryMarkdownPricingStrategy creates a new markdown pricing strategy with the given rules.
+// Rules can be provided in any order - they are sorted by days to expiry ascending.
+func NewExpiryMarkdownPricingStrategy(rules []MarkdownRule) *ExpiryMarkdownPricingStrategy {
+	sortedRules := make([]MarkdownRule, len(rules))
+	copy(sortedRules, rules)
+	sort.Slice(sortedRules, func(i, j int) bool {
+		return sortedRules[i].WithinDays < sortedRules[j].WithinDays
+	})
+
+	return &ExpiryMarkdownPricingStrategy{
+		BaseStrategy: strategy.NewBaseStrategy(
+			"expiry_markdown",
+			strategy.StrategyTypePricing,
+			"Markdown pricing for stock approaching its expiry date",
+		),
+		rules: sortedRules,
+	}
+}
+
+// SetProvider sets the provider of stock expiry dates.
+//
+// WARNING: This method is NOT thread-safe. It should only be called during
+// initialization/setup, before the strategy is used concurrently by HTTP handlers.
+func (s *ExpiryMarkdownPricingStrategy) SetProvider(provider ExpiryDateProvider) {
+	s.provider = provider
+}
+
+// HasProvider returns true if a provider of stock expiry dates is configured.
+func (s *ExpiryMarkdownPricingStrategy) HasProvider() bool {
+	return s.provider != nil
+}
+
+// CalculatePrice calculates the price marked down by the days to expiry of the product's stock.
+// Days to expiry are counted from the order date, or from now if the context has none.
+// When a markdown applies, the result lists the "expiry_markdown" rule.
+func (s *ExpiryMarkdownPricingStrategy) CalculatePrice(
+	ctx context.Context,
+	pricingCtx strategy.PricingContext,
+) (strategy.PricingResult, error) {
+	basePrice := pricingCtx.BasePrice
+	unitPrice := basePrice
+	discountPercent := decimal.Zero
+	appliedRules := []string{}
+
+	if rule, ok := s.markdownRule(ctx, pricingCtx); ok {
+		discountPercent = rule.DiscountPercent
+		unitPrice = basePrice.Mul(decimal.NewFromInt(100).Sub(discountPercent)).Div(decimal.NewFromInt(100)).Round(4)
+		appliedRules = append(appliedRules, "expiry_markdown")
+	}
+
+	totalPrice := unitPrice.Mul(pricingCtx.Quantity)
+
+	return strategy.PricingResult{
+		UnitPrice:       unitPrice,
+		TotalPrice:      totalPrice,
+		DiscountAmount:  basePrice.Mul(pricingCtx.Quantity).Sub(totalPrice),
+		DiscountPercent: discountPercent,
+		Currency:        pricingCtx.Currency,
+		AppliedRules:    appliedRules,
+	}, nil
+}
+
+// markdownRule finds the markdown for the product being priced.
+// Returns false if there is no provider, the IDs are invalid, the lookup fails,
+// the product has no dated stock or it is not close enough to expiry.
+func (s *ExpiryMarkdownPricingStrategy) markdownRule(ctx context.Context, pricingCtx strategy.PricingContext) (MarkdownRule, bool) {
+	if s.provider == nil {
+		return MarkdownRule{}, false
+	}
+	tenantID, err := uuid.Parse(pricingCtx.TenantID)
+	if err != nil {
+		return MarkdownRule{}, false
+	}
+	productID, err := uuid.Parse(pricingCtx.ProductID)
+	if err != nil {
+		return MarkdownRule{}, false
+	}
+
+	expiryDate, ok, err := s.provider.GetEarliestExpiryDate(ctx, tenantID, productID)
+	if err != nil || !ok {
+		return MarkdownRule{}, false
+	}
+
+	pricedAt := pricingCtx.OrderDate
+	if pricedAt.IsZero() {
+		pricedAt = time.Now()
+	}
+	daysToExpiry := int(expiryDate.Sub(pricedAt).Hours() / 24)
+
+	for _, rule := range s.rules {
+		if daysToExpiry <= rule.WithinDays {
+			return rule, true
+		}
+	}
+	return MarkdownRule{}, false
+}
+
+// SupportsPromotion returns false as markdown pricing doesn't support promotions
+func (s *ExpiryMarkdownPricingStrategy) SupportsPromotion() bool {
+	return false
+}
+
+// SupportsTieredPricing returns false as markdown pricing doesn't depend on quantity
+func (s *ExpiryMarkdownPricingStrategy) SupportsTieredPricing() bool {
+	return false
+}
+
+// InventoryExpiryDateProvider implements ExpiryDateProvider using the inventory repositories.
+// The earliest expiry date of the unexpired batches with stock, across warehouses, is the
+// stock a first-expired, first-out sale takes.
+type InventoryExpiryDateProvider struct {
+	items   inventory.InventoryItemRepository
+	batches inventory.StockBatchRepository
+}
+
+// NewInventoryExpiryDateProvider creates a new InventoryExpiryDateProvider.
+func NewInventoryExpiryDateProvider(
+	items inventory.InventoryItemRepository,
+	batches inventory.StockBatchRepository,
+) *InventoryExpiryDateProvider {
+	return &InventoryExpiryDateProvider{items: items, batches: batches}
+}
+
+// GetEarliestExpiryDate returns the earliest expiry date of the available batches of a product.
+func (p *InventoryExpiryDateProvider) GetEarliestExpiryDate(
+	ctx context.Context,
+	tenantID, productID uuid.UUID,
+) (time.Time, bool, error) {
+	items, err := p.items.FindByProduct(ctx, tenantID, productID, shared.Filter{})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	var earliest time.Time
+	found := false
+	for _, item := range items {
+		batches, err := p.batches.FindAvailable(ctx, item.ID)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		for _, batch := range batches {
+			if !batch.IsAvailable() || batch.ExpiryDate == nil {
+				continue
+			}
+			if !found || batch.ExpiryDate.Before(earliest) {
+				earliest = *batch.ExpiryDate
+				found = true
+			}
+		}
+	}
+	return earliest, found, nil
+}
+
+// Ensure ExpiryMarkdownPricingStrategy implements PricingStrategy interface
+var _ strategy.PricingStrategy = (*ExpiryMarkdownPricingStrategy)(nil)
+
+// Ensure InventoryExpiryDateProvider implements ExpiryDateProvider interface
+var _ ExpiryDateProvider = (*InventoryExpiryDateProvider)(nil)
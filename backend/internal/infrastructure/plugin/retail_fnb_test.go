@@ -0,0 +1,237 @@
+package plugin
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validRetailFnBProduct() strategy.ProductData {
+	return strategy.ProductData{
+		SKU:   "FNB001",
+		Name:  "Fresh Milk 1L",
+		Price: decimal.NewFromFloat(12.5),
+		Cost:  decimal.NewFromFloat(8),
+		Attributes: map[string]any{
+			"shelf_life_days":     "7",
+			"storage_temperature": "4",
+			"allergens":           "milk",
+		},
+	}
+}
+
+func TestRetailFnBProductValidator_Validate(t *testing.T) {
+	validator := NewRetailFnBProductValidator()
+	ctx := context.Background()
+	valCtx := strategy.ValidationContext{TenantID: "tenant1", IsNew: true}
+
+	tests := []struct {
+		name        string
+		attributes  map[string]any
+		expectValid bool
+		errorField  string
+		errorCode   string
+	}{
+		{
+			name:        "valid product",
+			expectValid: true,
+		},
+		{
+			name:        "several allergens from a JSON list",
+			attributes:  map[string]any{"allergens": []any{"Wheat", "eggs", "sesame"}},
+			expectValid: true,
+		},
+		{
+			name:        "no allergens",
+			attributes:  map[string]any{"allergens": "none"},
+			expectValid: true,
+		},
+		{
+			name:        "numeric attributes",
+			attributes:  map[string]any{"shelf_life_days": float64(180), "storage_temperature": float64(-18)},
+			expectValid: true,
+		},
+		{
+			name:        "allergen outside the enum",
+			attributes:  map[string]any{"allergens": "milk, celery"},
+			expectValid: false,
+			errorField:  "attributes.allergens",
+			errorCode:   "INVALID_VALUE",
+		},
+		{
+			name:        "none together with allergens",
+			attributes:  map[string]any{"allergens": "none,milk"},
+			expectValid: false,
+			errorField:  "attributes.allergens",
+			errorCode:   "INVALID_VALUE",
+		},
+		{
+			name:        "missing allergens",
+			attributes:  map[string]any{"allergens": ""},
+			expectValid: false,
+			errorField:  "attributes.allergens",
+			errorCode:   "REQUIRED",
+		},
+		{
+			name:        "storage temperature out of range",
+			attributes:  map[string]any{"storage_temperature": "85"},
+			expectValid: false,
+			errorField:  "attributes.storage_temperature",
+			errorCode:   "OUT_OF_RANGE",
+		},
+		{
+			name:        "storage temperature not a number",
+			attributes:  map[string]any{"storage_temperature": "cold"},
+			expectValid: false,
+			errorField:  "attributes.storage_temperature",
+			errorCode:   "INVALID_FORMAT",
+		},
+		{
+			name:        "shelf life not a positive number of days",
+			attributes:  map[string]any{"shelf_life_days": "0"},
+			expectValid: false,
+			errorField:  "attributes.shelf_life_days",
+			errorCode:   "INVALID_FORMAT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := validRetailFnBProduct()
+			for key, value := range tt.attributes {
+				data.Attributes[key] = value
+			}
+
+			result, err := validator.Validate(ctx, valCtx, data)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectValid, result.IsValid)
+			if tt.expectValid {
+				assert.Empty(t, result.Errors)
+				return
+			}
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, tt.errorField, result.Errors[0].Field)
+			assert.Equal(t, tt.errorCode, result.Errors[0].Code)
+		})
+	}
+}
+
+func TestRetailFnBPlugin_AllergenAttributeRegex(t *testing.T) {
+	var allergens string
+	for _, def := range NewRetailFnBPlugin().GetRequiredProductAttributes() {
+		if def.Key == "allergens" {
+			allergens = def.Regex
+		}
+	}
+	pattern := regexp.MustCompile(allergens)
+
+	assert.True(t, pattern.MatchString("none"))
+	assert.True(t, pattern.MatchString("milk"))
+	assert.True(t, pattern.MatchString("Peanuts, tree_nuts,soybeans"))
+	assert.False(t, pattern.MatchString("celery"))
+	assert.False(t, pattern.MatchString("milk, celery"))
+	assert.False(t, pattern.MatchString("none, milk"))
+}
+
+func TestRetailFnBPlugin_RegisterStrategies(t *testing.T) {
+	registry := NewMockStrategyRegistrar()
+	manager := NewPluginManager(registry)
+
+	require.NoError(t, manager.Register(NewAgriculturalPlugin()))
+	require.NoError(t, manager.Register(NewRetailFnBPlugin()))
+
+	assert.Equal(t, []string{"agricultural", "retail_fnb"}, manager.ListPlugins())
+	require.Len(t, registry.validationStrategies, 2)
+	assert.IsType(t, &RetailFnBProductValidator{}, registry.validationStrategies[1])
+	require.Len(t, registry.pricingStrategies, 1)
+	assert.IsType(t, &ExpiryMarkdownPricingStrategy{}, registry.pricingStrategies[0])
+}
+
+// mockExpiryDateProvider returns a fixed expiry date for every product
+type mockExpiryDateProvider struct {
+	expiryDate time.Time
+	found      bool
+}
+
+func (m *mockExpiryDateProvider) GetEarliestExpiryDate(ctx context.Context, tenantID, productID uuid.UUID) (time.Time, bool, error) {
+	return m.expiryDate, m.found, nil
+}
+
+func TestExpiryMarkdownPricingStrategy_CalculatePrice(t *testing.T) {
+	orderDate := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	pricingCtx := strategy.PricingContext{
+		TenantID:  uuid.New().String(),
+		ProductID: uuid.New().String(),
+		Quantity:  decimal.NewFromInt(4),
+		BasePrice: decimal.NewFromInt(20),
+		OrderDate: orderDate,
+	}
+
+	tests := []struct {
+		name            string
+		provider        ExpiryDateProvider
+		expectUnitPrice decimal.Decimal
+		expectPercent   decimal.Decimal
+		expectRules     []string
+	}{
+		{
+			name:            "without a provider",
+			expectUnitPrice: decimal.NewFromInt(20),
+			expectPercent:   decimal.Zero,
+			expectRules:     []string{},
+		},
+		{
+			name:            "no dated stock",
+			provider:        &mockExpiryDateProvider{},
+			expectUnitPrice: decimal.NewFromInt(20),
+			expectPercent:   decimal.Zero,
+			expectRules:     []string{},
+		},
+		{
+			name:            "far from expiry",
+			provider:        &mockExpiryDateProvider{expiryDate: orderDate.AddDate(0, 0, 30), found: true},
+			expectUnitPrice: decimal.NewFromInt(20),
+			expectPercent:   decimal.Zero,
+			expectRules:     []string{},
+		},
+		{
+			name:            "within a week of expiry",
+			provider:        &mockExpiryDateProvider{expiryDate: orderDate.AddDate(0, 0, 5), found: true},
+			expectUnitPrice: decimal.NewFromInt(18),
+			expectPercent:   decimal.NewFromInt(10),
+			expectRules:     []string{"expiry_markdown"},
+		},
+		{
+			name:            "last day before expiry",
+			provider:        &mockExpiryDateProvider{expiryDate: orderDate.Add(20 * time.Hour), found: true},
+			expectUnitPrice: decimal.NewFromInt(10),
+			expectPercent:   decimal.NewFromInt(50),
+			expectRules:     []string{"expiry_markdown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown := NewExpiryMarkdownPricingStrategy(DefaultMarkdownRules())
+			if tt.provider != nil {
+				markdown.SetProvider(tt.provider)
+			}
+
+			result, err := markdown.CalculatePrice(context.Background(), pricingCtx)
+			require.NoError(t, err)
+
+			assert.True(t, tt.expectUnitPrice.Equal(result.UnitPrice), "unit price %s", result.UnitPrice)
+			assert.True(t, tt.expectUnitPrice.Mul(decimal.NewFromInt(4)).Equal(result.TotalPrice))
+			assert.True(t, tt.expectPercent.Equal(result.DiscountPercent))
+			assert.Equal(t, tt.expectRules, result.AppliedRules)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package printing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"image/png"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Default sizes of generated codes in pixels
+const (
+	DefaultBarcodeWidth  = 300
+	DefaultBarcodeHeight = 80
+	DefaultQRCodeSize    = 150
+)
+
+// ErrEmptyCodeValue is returned when generating a barcode or QR code for an empty value
+var ErrEmptyCodeValue = errors.New("barcode value is empty")
+
+// GenerateBarcode generates a Code128 barcode of value as a PNG image.
+// The image is widened beyond width if the barcode needs more room to stay scannable.
+func GenerateBarcode(value string, width, height int) ([]byte, error) {
+	if value == "" {
+		return nil, ErrEmptyCodeValue
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid barcode size %dx%d", width, height)
+	}
+
+	code, err := code128.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode barcode %q: %w", value, err)
+	}
+	width = max(width, code.Bounds().Dx())
+	return scaleCode(code, width, height)
+}
+
+// GenerateQRCode generates a QR code of value as a square PNG image of the given size
+func GenerateQRCode(value string, size int) ([]byte, error) {
+	if value == "" {
+		return nil, ErrEmptyCodeValue
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid QR code size %d", size)
+	}
+
+	code, err := qr.Encode(value, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	size = max(size, code.Bounds().Dx())
+	return scaleCode(code, size, size)
+}
+
+// BarcodeDataURI generates a Code128 barcode of value as a PNG data URI for <img src>
+func BarcodeDataURI(value string, width, height int) (template.URL, error) {
+	image, err := GenerateBarcode(value, width, height)
+	if err != nil {
+		return "", err
+	}
+	return pngDataURI(image), nil
+}
+
+// QRCodeDataURI generates a QR code of value as a PNG data URI for <img src>
+func QRCodeDataURI(value string, size int) (template.URL, error) {
+	image, err := GenerateQRCode(value, size)
+	if err != nil {
+		return "", err
+	}
+	return pngDataURI(image), nil
+}
+
+// scaleCode scales a code to the given size and encodes it as PNG
+func scaleCode(code barcode.Barcode, width, height int) ([]byte, error) {
+	scaled, err := barcode.Scale(code, width, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale code: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaled); err != nil {
+		return nil, fmt.Errorf("failed to encode code image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// pngDataURI returns a data URI embedding a PNG image
+func pngDataURI(image []byte) template.URL {
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(image))
+}
@@ -0,0 +1,180 @@
+package printing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/erp/backend/internal/domain/printing"
+	"github.com/google/uuid"
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/oned"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodePNG decodes a generated code image, placed on a white page as when printed
+func decodePNG(t *testing.T, data []byte) *gozxing.BinaryBitmap {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	const margin = 20
+	bounds := img.Bounds()
+	page := image.NewGray(image.Rect(0, 0, bounds.Dx()+2*margin, bounds.Dy()+2*margin))
+	draw.Draw(page, page.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(page, bounds.Add(image.Pt(margin, margin)), img, bounds.Min, draw.Src)
+
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(page)
+	require.NoError(t, err)
+	return bitmap
+}
+
+func TestGenerateBarcode(t *testing.T) {
+	t.Run("round-trips the value through a decoder", func(t *testing.T) {
+		data, err := GenerateBarcode("SO-2024-0315", DefaultBarcodeWidth, DefaultBarcodeHeight)
+		require.NoError(t, err)
+
+		result, err := oned.NewCode128Reader().Decode(decodePNG(t, data), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "SO-2024-0315", result.GetText())
+	})
+
+	t.Run("uses the requested size", func(t *testing.T) {
+		data, err := GenerateBarcode("SO-1", 400, 60)
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, 400, img.Bounds().Dx())
+		assert.Equal(t, 60, img.Bounds().Dy())
+	})
+
+	t.Run("widens a barcode too long for the requested width", func(t *testing.T) {
+		value := strings.Repeat("SO-2024-", 8)
+		data, err := GenerateBarcode(value, 50, 60)
+		require.NoError(t, err)
+
+		result, err := oned.NewCode128Reader().Decode(decodePNG(t, data), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, value, result.GetText())
+	})
+
+	t.Run("rejects an empty value", func(t *testing.T) {
+		_, err := GenerateBarcode("", DefaultBarcodeWidth, DefaultBarcodeHeight)
+
+		assert.ErrorIs(t, err, ErrEmptyCodeValue)
+	})
+
+	t.Run("rejects an invalid size", func(t *testing.T) {
+		_, err := GenerateBarcode("SO-1", 0, DefaultBarcodeHeight)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerateQRCode(t *testing.T) {
+	t.Run("round-trips the value through a decoder", func(t *testing.T) {
+		url := "https://erp.example.com/trade/sales-orders/6f1c2a9e-3b7d-4c51-9a0e-2d8f4b6c1e73"
+		data, err := GenerateQRCode(url, DefaultQRCodeSize)
+		require.NoError(t, err)
+
+		result, err := qrcode.NewQRCodeReader().Decode(decodePNG(t, data), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, url, result.GetText())
+	})
+
+	t.Run("rejects an empty value", func(t *testing.T) {
+		_, err := GenerateQRCode("", DefaultQRCodeSize)
+
+		assert.ErrorIs(t, err, ErrEmptyCodeValue)
+	})
+}
+
+func TestTemplateEngine_BarcodeFunctions(t *testing.T) {
+	ctx := context.Background()
+	dataURI := regexp.MustCompile(`src="data:image/png;base64,([A-Za-z0-9+/=]+)"`)
+
+	render := func(t *testing.T, engine *TemplateEngine, content string, data any) (string, error) {
+		t.Helper()
+		template := &printing.PrintTemplate{Content: content}
+		template.ID = uuid.New()
+		result, err := engine.Render(ctx, &RenderTemplateRequest{Template: template, Data: data})
+		if err != nil {
+			return "", err
+		}
+		// html/template escapes "+" in attributes; browsers unescape it
+		return html.UnescapeString(result.HTML), nil
+	}
+
+	embeddedImage := func(t *testing.T, page string) []byte {
+		t.Helper()
+		match := dataURI.FindStringSubmatch(page)
+		require.NotNil(t, match, "no image data URI in %s", page)
+		data, err := base64.StdEncoding.DecodeString(match[1])
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("embeds a scannable barcode", func(t *testing.T) {
+		page, err := render(t, NewTemplateEngine(), `<img src="{{ barcode .OrderNumber }}">`,
+			map[string]any{"OrderNumber": "SO-2024-0315"})
+		require.NoError(t, err)
+
+		result, err := oned.NewCode128Reader().Decode(decodePNG(t, embeddedImage(t, page)), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "SO-2024-0315", result.GetText())
+	})
+
+	t.Run("embeds a scannable QR code", func(t *testing.T) {
+		page, err := render(t, NewTemplateEngine(), `<img src="{{ qr .TrackingURL }}">`,
+			map[string]any{"TrackingURL": "https://track.example.com/SF1234567890"})
+		require.NoError(t, err)
+
+		result, err := qrcode.NewQRCodeReader().Decode(decodePNG(t, embeddedImage(t, page)), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://track.example.com/SF1234567890", result.GetText())
+	})
+
+	t.Run("uses the engine and template sizes", func(t *testing.T) {
+		engine := NewTemplateEngine(WithBarcodeSize(500, 100), WithQRCodeSize(120))
+
+		page, err := render(t, engine, `<img src="{{ barcode .No }}"><img src="{{ qr .No 200 }}">`,
+			map[string]any{"No": "SO-1"})
+		require.NoError(t, err)
+
+		matches := dataURI.FindAllStringSubmatch(page, -1)
+		require.Len(t, matches, 2)
+		sizes := make([]image.Point, 0, len(matches))
+		for _, match := range matches {
+			data, err := base64.StdEncoding.DecodeString(match[1])
+			require.NoError(t, err)
+			img, err := png.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+			sizes = append(sizes, img.Bounds().Size())
+		}
+		assert.Equal(t, image.Pt(500, 100), sizes[0])
+		assert.Equal(t, image.Pt(200, 200), sizes[1])
+	})
+
+	t.Run("fails the render for an empty value", func(t *testing.T) {
+		_, err := render(t, NewTemplateEngine(), `<img src="{{ barcode .OrderNumber }}">`,
+			map[string]any{"OrderNumber": ""})
+
+		assert.ErrorIs(t, err, ErrEmptyCodeValue)
+	})
+}
@@ -6,9 +6,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	cdplog "github.com/chromedp/cdproto/log"
 	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
 	"github.com/chromedp/chromedp"
 	"github.com/erp/backend/internal/domain/printing"
 	"go.uber.org/zap"
@@ -17,6 +20,8 @@ import (
 const (
 	defaultChromeTimeout = 30 * time.Second
 	defaultScale         = 1.0
+	// maxRenderOutputLines bounds the browser error output kept for a failed render
+	maxRenderOutputLines = 50
 )
 
 // ChromedpConfig contains configuration for the chromedp renderer
@@ -140,13 +145,16 @@ func (r *ChromedpRenderer) Render(ctx context.Context, req *RenderRequest) (*Ren
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Create browser context
+	// Create browser context, collecting the browser's error output for diagnosis
+	output := &renderOutput{}
 	browserCtx, browserCancel := chromedp.NewContext(r.allocCtx,
 		chromedp.WithLogf(func(format string, args ...interface{}) {
 			r.logger.Debug(fmt.Sprintf(format, args...))
 		}),
+		chromedp.WithErrorf(output.addf),
 	)
 	defer browserCancel()
+	chromedp.ListenTarget(browserCtx, output.listen)
 
 	// Build the complete HTML with header/footer if needed
 	html := r.buildCompleteHTML(req)
@@ -190,20 +198,25 @@ func (r *ChromedpRenderer) Render(ctx context.Context, req *RenderRequest) (*Ren
 	)
 
 	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return nil, NewRenderError(ErrCodeRenderTimeout,
+		var renderErr *RenderError
+		switch ctx.Err() {
+		case context.DeadlineExceeded:
+			renderErr = NewRenderError(ErrCodeRenderTimeout,
 				fmt.Sprintf("PDF rendering timed out after %v", timeout), err)
+		case context.Canceled:
+			renderErr = NewRenderError(ErrCodeRenderTimeout, "PDF rendering was cancelled", err)
+		default:
+			r.logger.Error("chromedp rendering failed", zap.Error(err))
+			renderErr = NewRenderError(ErrCodeRenderFailed, "chromedp execution failed: "+err.Error(), err)
 		}
-		if ctx.Err() == context.Canceled {
-			return nil, NewRenderError(ErrCodeRenderTimeout, "PDF rendering was cancelled", err)
-		}
-
-		r.logger.Error("chromedp rendering failed", zap.Error(err))
-		return nil, NewRenderError(ErrCodeRenderFailed, "chromedp execution failed: "+err.Error(), err)
+		renderErr.Output = output.String()
+		return nil, renderErr
 	}
 
 	if len(pdfData) == 0 {
-		return nil, NewRenderError(ErrCodeRenderFailed, "generated PDF is empty", nil)
+		renderErr := NewRenderError(ErrCodeRenderFailed, "generated PDF is empty", nil)
+		renderErr.Output = output.String()
+		return nil, renderErr
 	}
 
 	// Count pages
@@ -338,6 +351,61 @@ func dataURLToBytes(dataURL string) ([]byte, error) {
 	return base64.StdEncoding.DecodeString(dataURL[idx+1:])
 }
 
+// renderOutput collects the browser's error output during a render: protocol errors,
+// uncaught exceptions, console errors and browser log errors (e.g. failed resource loads)
+type renderOutput struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+// addf records a line of output; only the first maxRenderOutputLines lines are kept
+func (o *renderOutput) addf(format string, args ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.lines) < maxRenderOutputLines {
+		o.lines = append(o.lines, fmt.Sprintf(format, args...))
+	}
+}
+
+// listen records the error events of a browser target
+func (o *renderOutput) listen(ev interface{}) {
+	switch e := ev.(type) {
+	case *runtime.EventExceptionThrown:
+		if e.ExceptionDetails != nil {
+			o.addf("uncaught %s", e.ExceptionDetails.Error())
+		}
+	case *runtime.EventConsoleAPICalled:
+		if e.Type != runtime.APITypeError {
+			return
+		}
+		args := make([]string, 0, len(e.Args))
+		for _, arg := range e.Args {
+			if arg.Description != "" {
+				args = append(args, arg.Description)
+			} else {
+				args = append(args, string(arg.Value))
+			}
+		}
+		o.addf("console error: %s", strings.Join(args, " "))
+	case *cdplog.EventEntryAdded:
+		if e.Entry == nil || e.Entry.Level != cdplog.LevelError {
+			return
+		}
+		if e.Entry.URL != "" {
+			o.addf("%s error: %s (%s)", e.Entry.Source, e.Entry.Text, e.Entry.URL)
+		} else {
+			o.addf("%s error: %s", e.Entry.Source, e.Entry.Text)
+		}
+	}
+}
+
+// String returns the collected output, one entry per line
+func (o *renderOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return strings.Join(o.lines, "\n")
+}
+
 // Ensure ChromedpRenderer implements PDFRenderer
 var _ PDFRenderer = (*ChromedpRenderer)(nil)
 
@@ -1,9 +1,14 @@
 package printing
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	cdplog "github.com/chromedp/cdproto/log"
+	"github.com/chromedp/cdproto/runtime"
 	"github.com/erp/backend/internal/domain/printing"
 	"github.com/stretchr/testify/assert"
 )
@@ -298,3 +303,56 @@ func TestChromedpRenderer_Close(t *testing.T) {
 	err := r.Close()
 	assert.NoError(t, err)
 }
+
+func TestRenderOutput(t *testing.T) {
+	t.Run("collects browser errors", func(t *testing.T) {
+		output := &renderOutput{}
+
+		output.addf("could not unmarshal event: %s", "bad json")
+		output.listen(&runtime.EventExceptionThrown{ExceptionDetails: &runtime.ExceptionDetails{Text: "Uncaught ReferenceError"}})
+		output.listen(&runtime.EventConsoleAPICalled{
+			Type: runtime.APITypeError,
+			Args: []*runtime.RemoteObject{{Value: []byte(`"chart failed"`)}},
+		})
+		output.listen(&cdplog.EventEntryAdded{Entry: &cdplog.Entry{
+			Source: cdplog.SourceNetwork,
+			Level:  cdplog.LevelError,
+			Text:   "Failed to load resource",
+			URL:    "https://cdn.example.com/logo.png",
+		}})
+
+		lines := strings.Split(output.String(), "\n")
+		assert.Len(t, lines, 4)
+		assert.Contains(t, lines[0], "bad json")
+		assert.Contains(t, lines[1], "Uncaught ReferenceError")
+		assert.Contains(t, lines[2], "chart failed")
+		assert.Contains(t, lines[3], "https://cdn.example.com/logo.png")
+	})
+
+	t.Run("ignores non-error events", func(t *testing.T) {
+		output := &renderOutput{}
+
+		output.listen(&runtime.EventConsoleAPICalled{Type: runtime.APITypeLog})
+		output.listen(&cdplog.EventEntryAdded{Entry: &cdplog.Entry{Level: cdplog.LevelWarning, Text: "deprecated"}})
+
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("bounds the number of lines kept", func(t *testing.T) {
+		output := &renderOutput{}
+
+		for i := 0; i < maxRenderOutputLines+10; i++ {
+			output.addf("error %d", i)
+		}
+
+		assert.Len(t, strings.Split(output.String(), "\n"), maxRenderOutputLines)
+	})
+
+	t.Run("is carried by render errors", func(t *testing.T) {
+		renderErr := NewRenderError(ErrCodeRenderFailed, "chromedp execution failed", nil)
+		renderErr.Output = "console error: chart failed"
+
+		assert.Equal(t, "console error: chart failed", RenderOutput(fmt.Errorf("render: %w", renderErr)))
+		assert.Empty(t, RenderOutput(errors.New("other")))
+	})
+}
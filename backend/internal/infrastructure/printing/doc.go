@@ -1,22 +1,24 @@
 // Package printing provides infrastructure implementations for PDF generation
-// and print job rendering using wkhtmltopdf.
+// and print job rendering using headless Chrome.
 //
 // This package contains:
 // - PDFRenderer interface for rendering HTML to PDF
-// - WkhtmltopdfRenderer implementation using the wkhtmltopdf command-line tool
+// - ChromedpRenderer implementation using the Chrome DevTools Protocol
 // - PDFStorage interface for storing and managing generated PDF files
 // - FileSystemStorage implementation for local file system storage
+// - TemplateRenderer binding document models to print templates and rendering them to PDF
+// - PrintProfileStore holding the paper size, orientation, margins and DPI of each document type
+// - Code128 barcode and QR code generation, exposed to templates as the barcode and qr functions
 //
 // Example usage:
 //
-//	config := &WkhtmltopdfConfig{
-//	    BinaryPath:     "/usr/bin/wkhtmltopdf",
+//	renderer, err := NewChromedpRenderer(&ChromedpConfig{
 //	    DefaultTimeout: 30 * time.Second,
-//	}
-//	renderer, err := NewWkhtmltopdfRenderer(config)
+//	})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
+//	defer renderer.Close()
 //
 //	result, err := renderer.Render(ctx, &RenderRequest{
 //	    HTML:        "<html>...</html>",
@@ -24,6 +26,7 @@
 //	    Orientation: printing.OrientationPortrait,
 //	})
 //	if err != nil {
+//	    // RenderOutput(err) holds the browser's error output for diagnosis
 //	    log.Fatal(err)
 //	}
 //
@@ -0,0 +1,44 @@
+package printing
+
+import (
+	"sync"
+
+	"github.com/erp/backend/internal/domain/printing"
+)
+
+// PrintProfileStore holds the print profile of each document type.
+// Document types without a stored profile use printing.DefaultPrintProfile.
+type PrintProfileStore struct {
+	profiles map[printing.DocType]printing.PrintProfile
+	mu       sync.RWMutex
+}
+
+// NewPrintProfileStore creates a new print profile store with the default profiles
+func NewPrintProfileStore() *PrintProfileStore {
+	return &PrintProfileStore{
+		profiles: make(map[printing.DocType]printing.PrintProfile),
+	}
+}
+
+// Get returns the print profile of a document type
+func (s *PrintProfileStore) Get(docType printing.DocType) printing.PrintProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if profile, ok := s.profiles[docType]; ok {
+		return profile
+	}
+	return printing.DefaultPrintProfile(docType)
+}
+
+// Set stores the print profile of its document type, replacing the previous one
+func (s *PrintProfileStore) Set(profile printing.PrintProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles[profile.DocType] = profile
+	return nil
+}
@@ -0,0 +1,44 @@
+package printing
+
+import (
+	"testing"
+
+	"github.com/erp/backend/internal/domain/printing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintProfileStore(t *testing.T) {
+	t.Run("returns the 80mm thermal profile for sales receipts by default", func(t *testing.T) {
+		store := NewPrintProfileStore()
+
+		profile := store.Get(printing.DocTypeSalesReceipt)
+
+		assert.Equal(t, printing.DocTypeSalesReceipt, profile.DocType)
+		assert.Equal(t, printing.PaperSizeReceipt80MM, profile.PaperSize)
+		assert.Equal(t, printing.ThermalPrinterDPI, profile.DPI)
+	})
+
+	t.Run("returns a stored profile", func(t *testing.T) {
+		store := NewPrintProfileStore()
+		profile, err := printing.NewPrintProfile(printing.DocTypeSalesReceipt, printing.PaperSizeReceipt58MM,
+			printing.OrientationPortrait, printing.Margins{Top: 1, Right: 1, Bottom: 1, Left: 1}, printing.ThermalPrinterDPI)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Set(profile))
+
+		assert.Equal(t, profile, store.Get(printing.DocTypeSalesReceipt))
+		assert.Equal(t, printing.PaperSizeA4, store.Get(printing.DocTypeSalesOrder).PaperSize)
+	})
+
+	t.Run("rejects margins outside the 80mm roll", func(t *testing.T) {
+		store := NewPrintProfileStore()
+		profile := printing.DefaultPrintProfile(printing.DocTypeSalesReceipt)
+		profile.Margins = printing.Margins{Top: 2, Right: 35, Bottom: 2, Left: 35}
+
+		err := store.Set(profile)
+
+		assert.Error(t, err)
+		assert.Equal(t, printing.ReceiptMargins(), store.Get(printing.DocTypeSalesReceipt).Margins)
+	})
+}
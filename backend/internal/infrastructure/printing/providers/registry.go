@@ -74,3 +74,6 @@ func (r *DataProviderRegistry) RegisteredTypes() []printing.DocType {
 	}
 	return types
 }
+
+// Ensure DataProviderRegistry implements infra.DocumentLoader
+var _ infra.DocumentLoader = (*DataProviderRegistry)(nil)
@@ -2,6 +2,7 @@ package printing
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/erp/backend/internal/domain/printing"
@@ -17,6 +18,9 @@ type RenderRequest struct {
 	Orientation printing.Orientation
 	// Margins in millimeters
 	Margins printing.Margins
+	// DPI is the resolution of the target printer (optional). The PDF output is
+	// resolution independent; DPI is passed on for printers that need it.
+	DPI int
 	// Title for the PDF document metadata
 	Title string
 	// Header HTML content (optional)
@@ -52,6 +56,9 @@ type RenderError struct {
 	Code    string
 	Message string
 	Cause   error
+	// Output is the diagnostic output the rendering engine produced before failing
+	// (e.g. browser errors), kept for troubleshooting
+	Output string
 }
 
 func (e *RenderError) Error() string {
@@ -73,8 +80,19 @@ const (
 	ErrCodeBinaryNotFound   = "BINARY_NOT_FOUND"
 	ErrCodeInvalidPaperSize = "INVALID_PAPER_SIZE"
 	ErrCodeStorageFailed    = "STORAGE_FAILED"
+	ErrCodeMissingField     = "MISSING_TEMPLATE_FIELD"
+	ErrCodeTemplateNotFound = "TEMPLATE_NOT_FOUND"
 )
 
+// RenderOutput returns the rendering engine's diagnostic output carried by err, if any
+func RenderOutput(err error) string {
+	var renderErr *RenderError
+	if errors.As(err, &renderErr) {
+		return renderErr.Output
+	}
+	return ""
+}
+
 // NewRenderError creates a new RenderError
 func NewRenderError(code, message string, cause error) *RenderError {
 	return &RenderError{
@@ -36,6 +36,9 @@ type StoreRequest struct {
 	JobID uuid.UUID
 	// PDFData is the raw PDF content
 	PDFData []byte
+	// Extension is the file extension including the dot
+	// Default: .pdf (other files, e.g. expense attachments, can reuse the same storage)
+	Extension string
 }
 
 // StoreResult contains the result of storing a PDF
@@ -100,7 +103,7 @@ func NewFileSystemStorage(config *FileSystemStorageConfig) (*FileSystemStorage,
 }
 
 // Store saves a PDF file to the file system
-// Path structure: {base}/{tenant_id}/{year}/{month}/{job_id}.pdf
+// Path structure: {base}/{tenant_id}/{year}/{month}/{job_id}{extension}
 func (s *FileSystemStorage) Store(ctx context.Context, req *StoreRequest) (*StoreResult, error) {
 	// Check context cancellation
 	select {
@@ -121,6 +124,9 @@ func (s *FileSystemStorage) Store(ctx context.Context, req *StoreRequest) (*Stor
 	if len(req.PDFData) == 0 {
 		return nil, NewRenderError(ErrCodeStorageFailed, "PDF data is empty", nil)
 	}
+	if req.Extension != "" && (!strings.HasPrefix(req.Extension, ".") || strings.ContainsAny(req.Extension, `/\`)) {
+		return nil, NewRenderError(ErrCodeStorageFailed, "invalid file extension", nil)
+	}
 
 	// Build directory path: {base}/{tenant_id}/{year}/{month}/
 	now := time.Now()
@@ -137,7 +143,11 @@ func (s *FileSystemStorage) Store(ctx context.Context, req *StoreRequest) (*Stor
 	}
 
 	// Build file path
-	fileName := req.JobID.String() + ".pdf"
+	extension := req.Extension
+	if extension == "" {
+		extension = ".pdf"
+	}
+	fileName := req.JobID.String() + extension
 	filePath := filepath.Join(dirPath, fileName)
 
 	// Write file
@@ -84,6 +84,34 @@ func TestFileSystemStorage_Store(t *testing.T) {
 		assert.Equal(t, pdfData, content)
 	})
 
+	t.Run("store with extension", func(t *testing.T) {
+		jobID := uuid.New()
+
+		result, err := storage.Store(context.Background(), &StoreRequest{
+			TenantID:  uuid.New(),
+			JobID:     jobID,
+			PDFData:   []byte("png content"),
+			Extension: ".png",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, jobID.String()+".png", filepath.Base(result.Path))
+		_, err = os.Stat(filepath.Join(tempDir, result.Path))
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid extension", func(t *testing.T) {
+		result, err := storage.Store(context.Background(), &StoreRequest{
+			TenantID:  uuid.New(),
+			JobID:     uuid.New(),
+			PDFData:   []byte("test"),
+			Extension: "/../../evil",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "extension")
+	})
+
 	t.Run("nil request", func(t *testing.T) {
 		result, err := storage.Store(context.Background(), nil)
 		assert.Error(t, err)
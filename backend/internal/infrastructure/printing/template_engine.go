@@ -8,6 +8,7 @@ import (
 	"maps"
 	"math"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
@@ -22,14 +23,38 @@ import (
 // It uses Go's html/template package with custom functions for formatting.
 type TemplateEngine struct {
 	funcMap template.FuncMap
+
+	// Default sizes of the images generated by the barcode and qr functions
+	barcodeWidth  int
+	barcodeHeight int
+	qrCodeSize    int
 }
 
 // TemplateEngineOption configures the template engine
 type TemplateEngineOption func(*TemplateEngine)
 
+// WithBarcodeSize sets the default size of barcodes generated by the barcode function
+func WithBarcodeSize(width, height int) TemplateEngineOption {
+	return func(e *TemplateEngine) {
+		e.barcodeWidth = width
+		e.barcodeHeight = height
+	}
+}
+
+// WithQRCodeSize sets the default size of QR codes generated by the qr function
+func WithQRCodeSize(size int) TemplateEngineOption {
+	return func(e *TemplateEngine) {
+		e.qrCodeSize = size
+	}
+}
+
 // NewTemplateEngine creates a new template engine with default configuration
 func NewTemplateEngine(opts ...TemplateEngineOption) *TemplateEngine {
-	e := &TemplateEngine{}
+	e := &TemplateEngine{
+		barcodeWidth:  DefaultBarcodeWidth,
+		barcodeHeight: DefaultBarcodeHeight,
+		qrCodeSize:    DefaultQRCodeSize,
+	}
 
 	// Initialize template functions
 	e.funcMap = template.FuncMap{
@@ -113,6 +138,10 @@ func NewTemplateEngine(opts ...TemplateEngineOption) *TemplateEngine {
 		// UUID utilities
 		"shortUUID": shortUUID,
 
+		// Barcodes, as image data URIs
+		"barcode": e.barcode,
+		"qr":      e.qr,
+
 		// Misc
 		"now":        time.Now,
 		"dict":       dict,
@@ -135,6 +164,9 @@ type RenderTemplateRequest struct {
 	Data interface{}
 	// AdditionalFuncs are extra template functions (optional)
 	AdditionalFuncs template.FuncMap
+	// Strict fails the render when the template references a key missing from
+	// map data, instead of printing "<no value>"
+	Strict bool
 }
 
 // RenderResult contains the rendered HTML output
@@ -171,11 +203,14 @@ func (e *TemplateEngine) Render(ctx context.Context, req *RenderTemplateRequest)
 	if err != nil {
 		return nil, NewRenderError(ErrCodeInvalidHTML, "failed to parse template", err)
 	}
+	if req.Strict {
+		tmpl.Option("missingkey=error")
+	}
 
 	// Execute template
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, req.Data); err != nil {
-		return nil, NewRenderError(ErrCodeRenderFailed, "failed to execute template", err)
+		return nil, executeError(err)
 	}
 
 	return &RenderTemplateResult{
@@ -199,12 +234,40 @@ func (e *TemplateEngine) RenderString(ctx context.Context, name, content string,
 	// Execute template
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", NewRenderError(ErrCodeRenderFailed, "failed to execute template", err)
+		return "", executeError(err)
 	}
 
 	return buf.String(), nil
 }
 
+// missingFieldPattern extracts the field reference (e.g. ".Document.Customer.Name")
+// from a template execution error
+var missingFieldPattern = regexp.MustCompile(`at <(\.[^>]*)>: `)
+
+// missingFieldErrors are the execution error messages meaning the data has no value
+// for a field the template references
+var missingFieldErrors = []string{
+	"map has no entry for key",
+	"can't evaluate field",
+	"nil pointer evaluating",
+	"nil data; no entry for key",
+}
+
+// executeError converts a template execution error to a RenderError, naming the
+// field when the template references one the data doesn't have
+func executeError(err error) *RenderError {
+	msg := err.Error()
+	if match := missingFieldPattern.FindStringSubmatch(msg); match != nil {
+		for _, missing := range missingFieldErrors {
+			if strings.Contains(msg, missing) {
+				return NewRenderError(ErrCodeMissingField,
+					fmt.Sprintf("template field %s is missing from the document data", match[1]), err)
+			}
+		}
+	}
+	return NewRenderError(ErrCodeRenderFailed, "failed to execute template", err)
+}
+
 // GetFuncMap returns a copy of the template function map
 func (e *TemplateEngine) GetFuncMap() template.FuncMap {
 	funcMap := make(template.FuncMap, len(e.funcMap))
@@ -759,6 +822,41 @@ func safeURL(s string) template.URL {
 	return template.URL(s)
 }
 
+// =============================================================================
+// Template Functions - Barcodes
+// =============================================================================
+
+// barcode generates a Code128 barcode image of value as a data URI, for use as <img src>.
+// An optional width and height override the engine's default size.
+// Example: {{ barcode .Document.OrderNumber }}, {{ barcode .Document.OrderNumber 400 60 }}
+func (e *TemplateEngine) barcode(value string, size ...int) (template.URL, error) {
+	width, height := e.barcodeWidth, e.barcodeHeight
+	if len(size) > 2 {
+		return "", fmt.Errorf("barcode takes at most a width and a height, got %d sizes", len(size))
+	}
+	if len(size) > 0 {
+		width = size[0]
+	}
+	if len(size) > 1 {
+		height = size[1]
+	}
+	return BarcodeDataURI(value, width, height)
+}
+
+// qr generates a QR code image of value as a data URI, for use as <img src>.
+// An optional size overrides the engine's default size.
+// Example: {{ qr .Document.TrackingURL }}, {{ qr .Document.TrackingURL 200 }}
+func (e *TemplateEngine) qr(value string, size ...int) (template.URL, error) {
+	qrSize := e.qrCodeSize
+	if len(size) > 1 {
+		return "", fmt.Errorf("qr takes at most a size, got %d sizes", len(size))
+	}
+	if len(size) > 0 {
+		qrSize = size[0]
+	}
+	return QRCodeDataURI(value, qrSize)
+}
+
 // =============================================================================
 // Template Functions - UUID
 // =============================================================================
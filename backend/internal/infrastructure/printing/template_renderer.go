@@ -0,0 +1,153 @@
+package printing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erp/backend/internal/domain/printing"
+	"github.com/google/uuid"
+)
+
+// DocumentLoader loads the template model of a business document.
+// It is implemented by providers.DataProviderRegistry.
+type DocumentLoader interface {
+	LoadData(ctx context.Context, tenantID uuid.UUID, docType printing.DocType, documentID uuid.UUID) (*DocumentData, error)
+}
+
+// TemplateRenderer renders business documents to PDF with a stored print template.
+// It binds the typed document model (SalesOrderData, SalesDeliveryData,
+// ReceiptVoucherData, ...) to the template and feeds the resulting HTML to the
+// PDF renderer.
+//
+// Pages are laid out with the print profile of the document type, unless the
+// render call overrides it with WithPrintProfile.
+//
+// Templates are rendered strictly: a field the template references but the
+// document model doesn't have fails the render with ErrCodeMissingField, naming
+// the field, instead of printing a blank value.
+type TemplateRenderer struct {
+	templates *TemplateStore
+	profiles  *PrintProfileStore
+	engine    *TemplateEngine
+	pdf       PDFRenderer
+	documents DocumentLoader
+}
+
+// NewTemplateRenderer creates a new TemplateRenderer
+func NewTemplateRenderer(templates *TemplateStore, profiles *PrintProfileStore, engine *TemplateEngine, pdf PDFRenderer, documents DocumentLoader) *TemplateRenderer {
+	return &TemplateRenderer{
+		templates: templates,
+		profiles:  profiles,
+		engine:    engine,
+		pdf:       pdf,
+		documents: documents,
+	}
+}
+
+// RenderOption configures a single render call
+type RenderOption func(*renderOptions)
+
+// renderOptions holds the settings of a render call
+type renderOptions struct {
+	profile *printing.PrintProfile
+}
+
+// WithPrintProfile lays out the pages with the given profile instead of the
+// profile of the document type, e.g. for a template made for other paper
+func WithPrintProfile(profile printing.PrintProfile) RenderOption {
+	return func(o *renderOptions) {
+		o.profile = &profile
+	}
+}
+
+// RenderHTML binds the document data to the template with the given ID and returns the HTML
+func (r *TemplateRenderer) RenderHTML(ctx context.Context, templateID uuid.UUID, data *DocumentData) (string, error) {
+	template, err := r.template(templateID, data)
+	if err != nil {
+		return "", err
+	}
+	return r.renderHTML(ctx, template, data)
+}
+
+// RenderDocument loads a document, binds it to the template with the given ID and returns the PDF
+func (r *TemplateRenderer) RenderDocument(ctx context.Context, tenantID, templateID uuid.UUID, docType printing.DocType, documentID uuid.UUID, opts ...RenderOption) (*RenderResult, error) {
+	profile, err := r.profile(docType, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.documents.LoadData(ctx, tenantID, docType, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s %s: %w", docType, documentID, err)
+	}
+
+	template, err := r.template(templateID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := r.renderHTML(ctx, template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.pdf.Render(ctx, &RenderRequest{
+		HTML:        html,
+		PaperSize:   profile.PaperSize,
+		Orientation: profile.Orientation,
+		Margins:     profile.Margins,
+		DPI:         profile.DPI,
+		Title:       fmt.Sprintf("%s - %s", data.Meta.DocTypeName, data.Meta.DocNo),
+	})
+}
+
+// RenderSalesOrder renders a sales order to PDF with the template with the given ID
+func (r *TemplateRenderer) RenderSalesOrder(ctx context.Context, tenantID, templateID, orderID uuid.UUID, opts ...RenderOption) (*RenderResult, error) {
+	return r.RenderDocument(ctx, tenantID, templateID, printing.DocTypeSalesOrder, orderID, opts...)
+}
+
+// profile resolves the print profile of a render call: the override if given,
+// otherwise the profile of the document type
+func (r *TemplateRenderer) profile(docType printing.DocType, opts []RenderOption) (printing.PrintProfile, error) {
+	var o renderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.profile == nil {
+		return r.profiles.Get(docType), nil
+	}
+	if err := o.profile.Validate(); err != nil {
+		return printing.PrintProfile{}, err
+	}
+	return *o.profile, nil
+}
+
+// template returns the template with the given ID, checking it prints the document type of data
+func (r *TemplateRenderer) template(templateID uuid.UUID, data *DocumentData) (*StaticTemplate, error) {
+	if data == nil {
+		return nil, NewRenderError(ErrCodeRenderFailed, "document data is nil", nil)
+	}
+
+	template := r.templates.GetByID(templateID.String())
+	if template == nil {
+		return nil, NewRenderError(ErrCodeTemplateNotFound, fmt.Sprintf("print template %s not found", templateID), nil)
+	}
+	if template.DocType != data.Meta.DocType {
+		return nil, NewRenderError(ErrCodeRenderFailed,
+			fmt.Sprintf("print template %s is for %s, not %s", templateID, template.DocType, data.Meta.DocType), nil)
+	}
+	return template, nil
+}
+
+// renderHTML binds the document data to the template
+func (r *TemplateRenderer) renderHTML(ctx context.Context, template *StaticTemplate, data *DocumentData) (string, error) {
+	result, err := r.engine.Render(ctx, &RenderTemplateRequest{
+		Template: template.ToPrintTemplate(),
+		Data:     data,
+		Strict:   true,
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.HTML, nil
+}
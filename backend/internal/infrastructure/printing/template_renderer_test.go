@@ -0,0 +1,258 @@
+package printing
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/printing"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGolden rewrites the golden files with the current output:
+// go test ./internal/infrastructure/printing -run TestTemplateRenderer -update
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// fakeDocumentLoader returns fixed document data
+type fakeDocumentLoader struct {
+	data *DocumentData
+}
+
+func (l *fakeDocumentLoader) LoadData(ctx context.Context, tenantID uuid.UUID, docType printing.DocType, documentID uuid.UUID) (*DocumentData, error) {
+	if l.data == nil || l.data.Meta.DocType != docType {
+		return nil, errors.New("document not found")
+	}
+	return l.data, nil
+}
+
+// recordingPDFRenderer records the render request and returns a fixed PDF
+type recordingPDFRenderer struct {
+	requests []*RenderRequest
+}
+
+func (r *recordingPDFRenderer) Render(ctx context.Context, req *RenderRequest) (*RenderResult, error) {
+	r.requests = append(r.requests, req)
+	return &RenderResult{PDFData: []byte("%PDF-1.4 test"), PageCount: 1}, nil
+}
+
+func (r *recordingPDFRenderer) Close() error {
+	return nil
+}
+
+// sampleSalesOrderData returns a sales order model with fixed values, as built by the sales order provider
+func sampleSalesOrderData() *DocumentData {
+	createdAt := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+	data := NewDocumentData(printing.DocTypeSalesOrder, "SO-2024-0315")
+	data.Meta.Status = "CONFIRMED"
+	data.Meta.StatusText = "已确认"
+	data.Meta.CreatedAt = createdAt
+	data.Meta.CreatedAtFormatted = createdAt.Format("2006-01-02")
+	data.Company = CompanyInfo{Name: "示例贸易有限公司", Phone: "021-12345678"}
+	data.PrintDate = "2024-03-16"
+	data.PrintDateTime = "2024-03-16 10:00:00"
+	data.PrintTime = "10:00:00"
+
+	items := []SalesOrderItemData{
+		{Index: 1, ProductCode: "P-001", ProductName: "不锈钢保温杯 500ml", Unit: "个",
+			Quantity: decimal.NewFromInt(10), UnitPrice: decimal.RequireFromString("89.90"), Amount: decimal.RequireFromString("899.00"),
+			Remark: "礼盒装"},
+		{Index: 2, ProductCode: "P-002", ProductName: "竹纤维毛巾 <套装>", Unit: "条",
+			Quantity: decimal.NewFromInt(20), UnitPrice: decimal.RequireFromString("25.50"), Amount: decimal.RequireFromString("510.00")},
+	}
+	for i := range items {
+		items[i].QuantityFormatted = items[i].Quantity.String()
+		items[i].UnitPriceFormatted = FormatMoneyValue(items[i].UnitPrice)
+		items[i].AmountFormatted = FormatMoneyValue(items[i].Amount)
+	}
+
+	total := decimal.RequireFromString("1409.00")
+	discount := decimal.RequireFromString("9.00")
+	payable := total.Sub(discount)
+	data.Document = &SalesOrderData{
+		ID:                      uuid.MustParse("6f1c2a9e-3b7d-4c51-9a0e-2d8f4b6c1e73"),
+		OrderNumber:             "SO-2024-0315",
+		Customer:                CustomerInfo{Name: "华东商贸有限公司", Contact: "张三", Phone: "13800138000"},
+		Warehouse:               &WarehouseInfo{Name: "上海主仓"},
+		Items:                   items,
+		TotalAmount:             total,
+		DiscountAmount:          discount,
+		PayableAmount:           payable,
+		TotalQuantity:           decimal.NewFromInt(30),
+		ItemCount:               len(items),
+		Status:                  "CONFIRMED",
+		Remark:                  "请于周五前发货",
+		TotalAmountFormatted:    FormatMoneyValue(total),
+		DiscountAmountFormatted: FormatMoneyValue(discount),
+		PayableAmountFormatted:  FormatMoneyValue(payable),
+		PayableAmountChinese:    MoneyToChinese(payable),
+	}
+	return data
+}
+
+func newTestTemplateRenderer(t *testing.T, data *DocumentData) (*TemplateRenderer, *TemplateStore, *recordingPDFRenderer) {
+	t.Helper()
+	store, err := NewTemplateStore(nil)
+	require.NoError(t, err)
+	pdf := &recordingPDFRenderer{}
+	return NewTemplateRenderer(store, NewPrintProfileStore(), NewTemplateEngine(), pdf, &fakeDocumentLoader{data: data}), store, pdf
+}
+
+func TestTemplateRenderer_RenderHTML(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("matches the golden file for a sample sales order", func(t *testing.T) {
+		renderer, store, _ := newTestTemplateRenderer(t, nil)
+		template := store.GetDefault(printing.DocTypeSalesOrder)
+		require.NotNil(t, template)
+
+		html, err := renderer.RenderHTML(ctx, uuid.MustParse(template.ID), sampleSalesOrderData())
+		require.NoError(t, err)
+
+		golden := filepath.Join("testdata", "sales_order_a4.golden.html")
+		if *updateGolden {
+			require.NoError(t, os.WriteFile(golden, []byte(html), 0o644))
+		}
+		want, err := os.ReadFile(golden)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), html)
+	})
+
+	t.Run("names a field missing from the document model", func(t *testing.T) {
+		renderer, store, _ := newTestTemplateRenderer(t, nil)
+		template := store.GetDefault(printing.DocTypeSalesOrder)
+		require.NotNil(t, template)
+		data := sampleSalesOrderData()
+		// A sales order template bound to a model without the sales order fields
+		data.Document = &ReceiptVoucherData{VoucherNo: "RV-001"}
+
+		_, err := renderer.RenderHTML(ctx, uuid.MustParse(template.ID), data)
+
+		var renderErr *RenderError
+		require.ErrorAs(t, err, &renderErr)
+		assert.Equal(t, ErrCodeMissingField, renderErr.Code)
+		assert.Contains(t, renderErr.Message, ".Document.OrderNumber")
+	})
+
+	t.Run("rejects a template for another document type", func(t *testing.T) {
+		renderer, store, _ := newTestTemplateRenderer(t, nil)
+		template := store.GetDefault(printing.DocTypeSalesDelivery)
+		require.NotNil(t, template)
+
+		_, err := renderer.RenderHTML(ctx, uuid.MustParse(template.ID), sampleSalesOrderData())
+
+		var renderErr *RenderError
+		require.ErrorAs(t, err, &renderErr)
+		assert.Equal(t, ErrCodeRenderFailed, renderErr.Code)
+	})
+
+	t.Run("returns an error for an unknown template", func(t *testing.T) {
+		renderer, _, _ := newTestTemplateRenderer(t, nil)
+
+		_, err := renderer.RenderHTML(ctx, uuid.New(), sampleSalesOrderData())
+
+		var renderErr *RenderError
+		require.ErrorAs(t, err, &renderErr)
+		assert.Equal(t, ErrCodeTemplateNotFound, renderErr.Code)
+	})
+}
+
+func TestTemplateRenderer_RenderSalesOrder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("renders the order with the sales order profile", func(t *testing.T) {
+		renderer, store, pdf := newTestTemplateRenderer(t, sampleSalesOrderData())
+		template := store.GetDefault(printing.DocTypeSalesOrder)
+		require.NotNil(t, template)
+
+		result, err := renderer.RenderSalesOrder(ctx, uuid.New(), uuid.MustParse(template.ID), uuid.New())
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("%PDF-1.4 test"), result.PDFData)
+		require.Len(t, pdf.requests, 1)
+		req := pdf.requests[0]
+		assert.Contains(t, req.HTML, "华东商贸有限公司")
+		assert.Contains(t, req.HTML, "竹纤维毛巾 &lt;套装&gt;")
+		assert.Equal(t, printing.PaperSizeA4, req.PaperSize)
+		assert.Equal(t, printing.OrientationPortrait, req.Orientation)
+		assert.Equal(t, printing.DefaultMargins(), req.Margins)
+		assert.Equal(t, printing.DefaultDPI, req.DPI)
+		assert.Equal(t, "销售订单 - SO-2024-0315", req.Title)
+	})
+
+	t.Run("uses the stored profile of the document type", func(t *testing.T) {
+		renderer, store, pdf := newTestTemplateRenderer(t, sampleSalesOrderData())
+		template := store.GetDefault(printing.DocTypeSalesOrder)
+		require.NotNil(t, template)
+		profile, err := printing.NewPrintProfile(printing.DocTypeSalesOrder, printing.PaperSizeA5,
+			printing.OrientationLandscape, printing.Margins{Top: 5, Right: 8, Bottom: 5, Left: 8}, 600)
+		require.NoError(t, err)
+		require.NoError(t, renderer.profiles.Set(profile))
+
+		_, err = renderer.RenderSalesOrder(ctx, uuid.New(), uuid.MustParse(template.ID), uuid.New())
+
+		require.NoError(t, err)
+		req := pdf.requests[0]
+		assert.Equal(t, printing.PaperSizeA5, req.PaperSize)
+		assert.Equal(t, printing.OrientationLandscape, req.Orientation)
+		assert.Equal(t, profile.Margins, req.Margins)
+		assert.Equal(t, 600, req.DPI)
+	})
+
+	t.Run("prints on an 80mm thermal roll when overridden", func(t *testing.T) {
+		renderer, store, pdf := newTestTemplateRenderer(t, sampleSalesOrderData())
+		template := store.GetDefault(printing.DocTypeSalesOrder)
+		require.NotNil(t, template)
+		receipt := printing.DefaultPrintProfile(printing.DocTypeSalesReceipt)
+
+		_, err := renderer.RenderSalesOrder(ctx, uuid.New(), uuid.MustParse(template.ID), uuid.New(), WithPrintProfile(receipt))
+
+		require.NoError(t, err)
+		req := pdf.requests[0]
+		assert.Equal(t, printing.PaperSizeReceipt80MM, req.PaperSize)
+		assert.Equal(t, printing.ReceiptMargins(), req.Margins)
+		assert.Equal(t, printing.ThermalPrinterDPI, req.DPI)
+	})
+
+	t.Run("rejects an override with margins wider than the paper", func(t *testing.T) {
+		renderer, store, pdf := newTestTemplateRenderer(t, sampleSalesOrderData())
+		template := store.GetDefault(printing.DocTypeSalesOrder)
+		require.NotNil(t, template)
+		receipt := printing.DefaultPrintProfile(printing.DocTypeSalesReceipt)
+		receipt.Margins = printing.Margins{Left: 40, Right: 40}
+
+		_, err := renderer.RenderSalesOrder(ctx, uuid.New(), uuid.MustParse(template.ID), uuid.New(), WithPrintProfile(receipt))
+
+		assert.Error(t, err)
+		assert.Empty(t, pdf.requests)
+	})
+}
+
+func TestTemplateEngine_Render_Strict(t *testing.T) {
+	engine := NewTemplateEngine()
+	template := &printing.PrintTemplate{Content: "<p>{{ .Customer.Name }}</p>"}
+	template.ID = uuid.New()
+	data := map[string]any{"Customer": map[string]any{}}
+
+	t.Run("prints no value for a missing key by default", func(t *testing.T) {
+		result, err := engine.Render(context.Background(), &RenderTemplateRequest{Template: template, Data: data})
+
+		require.NoError(t, err)
+		assert.Equal(t, "<p></p>", result.HTML)
+	})
+
+	t.Run("fails on a missing key when strict", func(t *testing.T) {
+		_, err := engine.Render(context.Background(), &RenderTemplateRequest{Template: template, Data: data, Strict: true})
+
+		var renderErr *RenderError
+		require.ErrorAs(t, err, &renderErr)
+		assert.Equal(t, ErrCodeMissingField, renderErr.Code)
+		assert.Equal(t, "template field .Customer.Name is missing from the document data", renderErr.Message)
+	})
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// aggregationRunRetention is how long finished aggregation runs can be polled
+const aggregationRunRetention = 24 * time.Hour
+
+// AggregationRequest describes a report aggregation run
+type AggregationRequest struct {
+	// TenantID limits the run to one tenant; nil aggregates all active tenants
+	TenantID *uuid.UUID
+	// PeriodStart and PeriodEnd bound the aggregated period; zero values aggregate yesterday
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	// Force starts the run even if another aggregation run is in progress
+	Force bool
+}
+
+// AggregationRun tracks one run of the report aggregation: a report job per tenant and report type
+type AggregationRun struct {
+	ID            uuid.UUID
+	TenantID      *uuid.UUID // nil means all active tenants
+	PeriodStart   time.Time
+	PeriodEnd     time.Time
+	Status        JobStatus
+	TotalJobs     int
+	CompletedJobs int
+	FailedJobs    int
+	Error         string
+	StartedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+// newAggregationRun creates a running aggregation run
+func newAggregationRun(tenantID *uuid.UUID, periodStart, periodEnd time.Time) *AggregationRun {
+	return &AggregationRun{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Status:      JobStatusRunning,
+		StartedAt:   time.Now(),
+	}
+}
+
+// Progress returns the percentage (0-100) of the run's report jobs that have finished
+func (r *AggregationRun) Progress() int {
+	if r.TotalJobs == 0 {
+		if r.Status == JobStatusRunning {
+			return 0
+		}
+		return 100
+	}
+	return (r.CompletedJobs + r.FailedJobs) * 100 / r.TotalJobs
+}
+
+// IsFinished returns true if the run has succeeded or failed
+func (r *AggregationRun) IsFinished() bool {
+	return r.Status == JobStatusSuccess || r.Status == JobStatusFailed
+}
+
+// jobFinished counts a finished report job and completes the run once all its jobs have finished
+func (r *AggregationRun) jobFinished(succeeded bool, errMsg string) {
+	if succeeded {
+		r.CompletedJobs++
+	} else {
+		r.FailedJobs++
+		if r.Error == "" {
+			r.Error = errMsg
+		}
+	}
+	r.completeIfDone()
+}
+
+// completeIfDone marks the run succeeded, or failed if any report job failed, once all its jobs have finished
+func (r *AggregationRun) completeIfDone() {
+	if r.IsFinished() || r.CompletedJobs+r.FailedJobs < r.TotalJobs {
+		return
+	}
+	now := time.Now()
+	r.CompletedAt = &now
+	r.Status = JobStatusSuccess
+	if r.FailedJobs > 0 {
+		r.Status = JobStatusFailed
+		r.Error = fmt.Sprintf("%d of %d report jobs failed: %s", r.FailedJobs, r.TotalJobs, r.Error)
+	}
+}
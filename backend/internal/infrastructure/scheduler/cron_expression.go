@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit bounds the search for the next run of a cron expression that never matches (e.g. "0 0 31 2 *")
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// cronField is the set of values one field of a cron expression matches, one bit per value
+type cronField uint64
+
+func (f cronField) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// CronExpression is a parsed standard five-field cron expression: "minute hour day-of-month month day-of-week".
+// Each field accepts "*", single values, ranges ("1-5"), lists ("1,15") and steps ("*/15", "0-30/10").
+// Day of week is 0-6 with 0 (or 7) for Sunday. As in cron, when both day of month and day of week
+// are restricted a day matching either one matches.
+type CronExpression struct {
+	expr       string
+	minutes    cronField
+	hours      cronField
+	daysOfMon  cronField
+	months     cronField
+	daysOfWeek cronField
+	// domAny and dowAny tell whether the day fields are "*"
+	domAny bool
+	dowAny bool
+}
+
+// ParseCronExpression parses a five-field cron expression.
+// Returns ErrInvalidCronExpression if the expression is malformed or a value is out of range.
+func ParseCronExpression(expr string) (*CronExpression, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("%w: %q must have 5 fields, got %d", ErrInvalidCronExpression, expr, len(parts))
+	}
+
+	cron := &CronExpression{
+		expr:   strings.Join(parts, " "),
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}
+	fields := []struct {
+		target   *cronField
+		min, max int
+	}{
+		{&cron.minutes, 0, 59},
+		{&cron.hours, 0, 23},
+		{&cron.daysOfMon, 1, 31},
+		{&cron.months, 1, 12},
+		{&cron.daysOfWeek, 0, 7},
+	}
+	for i, field := range fields {
+		parsed, err := parseCronField(parts[i], field.min, field.max)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidCronExpression, expr, err)
+		}
+		*field.target = parsed
+	}
+
+	// 7 is Sunday as well as 0
+	if cron.daysOfWeek.has(7) {
+		cron.daysOfWeek |= 1
+	}
+	return cron, nil
+}
+
+// parseCronField parses one comma-separated field of a cron expression
+func parseCronField(field string, min, max int) (cronField, error) {
+	var result cronField
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		low, high := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var errLow, errHigh error
+			low, errLow = strconv.Atoi(bounds[0])
+			high, errHigh = strconv.Atoi(bounds[1])
+			if errLow != nil || errHigh != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			low = value
+			high = value
+			if strings.Contains(part, "/") {
+				// "5/15" means from 5 to the end in steps of 15
+				high = max
+			}
+		}
+
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for value := low; value <= high; value += step {
+			result |= 1 << uint(value)
+		}
+	}
+	return result, nil
+}
+
+// String returns the cron expression
+func (c *CronExpression) String() string {
+	return c.expr
+}
+
+// Matches returns true if the expression matches the minute of t
+func (c *CronExpression) Matches(t time.Time) bool {
+	return c.minutes.has(t.Minute()) &&
+		c.hours.has(t.Hour()) &&
+		c.months.has(int(t.Month())) &&
+		c.matchesDay(t)
+}
+
+// matchesDay applies the cron rule for the day of month and day of week fields
+func (c *CronExpression) matchesDay(t time.Time) bool {
+	dom := c.daysOfMon.has(t.Day())
+	dow := c.daysOfWeek.has(int(t.Weekday()))
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first minute after t the expression matches, or the zero time if it never matches
+func (c *CronExpression) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.Add(cronSearchLimit)
+
+	for next.Before(limit) {
+		if !c.months.has(int(next.Month())) {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.hours.has(next.Hour()) {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.minutes.has(next.Minute()) {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
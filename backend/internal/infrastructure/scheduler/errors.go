@@ -21,6 +21,18 @@ var (
 	// ErrInvalidConfig is returned when configuration is invalid
 	ErrInvalidConfig = errors.New("invalid scheduler configuration")
 
+	// ErrInvalidCronExpression is returned for malformed cron expressions
+	ErrInvalidCronExpression = errors.New("invalid cron expression")
+
+	// ErrJobAlreadyRegistered is returned when a job name is registered twice
+	ErrJobAlreadyRegistered = errors.New("job already registered")
+
+	// ErrJobAlreadyRunning is returned when a job is triggered while a run of it is in progress
+	ErrJobAlreadyRunning = errors.New("job already running")
+
+	// ErrInvalidPeriod is returned when a report period ends before it starts
+	ErrInvalidPeriod = errors.New("invalid report period")
+
 	// ---------------------------------------------------------------------------
 	// Order Sync Errors
 	// ---------------------------------------------------------------------------
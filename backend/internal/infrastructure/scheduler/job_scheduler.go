@@ -0,0 +1,432 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxJobNameLength is the longest job name the run history can store (report_scheduler_jobs.report_type)
+const maxJobNameLength = 50
+
+// JobHandler runs one execution of a recurring job
+type JobHandler func(ctx context.Context) error
+
+// RecurringJob is a named job run on a cron schedule
+type RecurringJob struct {
+	// Name uniquely identifies the job and is recorded in the run history
+	Name string
+	// CronExpression is the five-field cron schedule of the job, e.g. "0 2 * * *"
+	CronExpression string
+	// Handler runs the job
+	Handler JobHandler
+	// MaxRetries is the number of times a failed run is retried; zero disables retries
+	MaxRetries int
+	// RetryDelay is the delay before each retry
+	RetryDelay time.Duration
+	// Timeout is the maximum time a run can take; zero uses the scheduler's JobTimeout
+	Timeout time.Duration
+}
+
+// JobRunRecorder persists the run history of recurring jobs.
+// SchedulerJobRepository implements it.
+type JobRunRecorder interface {
+	// RecordJobStart records the start of a run and returns the ID of the record
+	RecordJobStart(ctx context.Context, tenantID *uuid.UUID, jobName string) (uuid.UUID, error)
+	// RecordJobComplete records the outcome of a run
+	RecordJobComplete(ctx context.Context, runID uuid.UUID, success bool, errMsg string) error
+}
+
+// JobSchedulerConfig holds configuration for the recurring job scheduler
+type JobSchedulerConfig struct {
+	// MaxConcurrentJobs is the maximum number of job runs in progress at once
+	MaxConcurrentJobs int
+	// JobTimeout is the default maximum time a single run can take
+	JobTimeout time.Duration
+	// CheckInterval is how often the schedules are checked; must not exceed one minute
+	CheckInterval time.Duration
+}
+
+// DefaultJobSchedulerConfig returns default job scheduler configuration
+func DefaultJobSchedulerConfig() JobSchedulerConfig {
+	return JobSchedulerConfig{
+		MaxConcurrentJobs: 3,
+		JobTimeout:        30 * time.Minute,
+		CheckInterval:     cronTickerInterval,
+	}
+}
+
+// scheduledJob is a registered job and the state of its runs
+type scheduledJob struct {
+	RecurringJob
+	schedule *CronExpression
+
+	running    bool
+	lastFired  time.Time // Minute of the last scheduled run, so each minute fires once
+	lastRunAt  *time.Time
+	lastStatus JobStatus
+	lastError  string
+	nextRunAt  time.Time
+}
+
+// JobScheduler runs named recurring jobs on cron schedules.
+// Runs across all jobs are capped by MaxConcurrentJobs; runs over the cap wait for a slot.
+// A job whose previous run is still in progress is skipped rather than run twice.
+// Failed runs are retried up to the job's MaxRetries, and every attempt is recorded in the run history.
+type JobScheduler struct {
+	config   JobSchedulerConfig
+	recorder JobRunRecorder
+	logger   *zap.Logger
+
+	jobs  map[string]*scheduledJob
+	slots chan struct{}
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	loopWG    sync.WaitGroup
+	runWG     sync.WaitGroup
+	mu        sync.Mutex
+	isRunning bool
+}
+
+// NewJobScheduler creates a new recurring job scheduler.
+// The recorder is optional; without it the run history is not persisted.
+func NewJobScheduler(config JobSchedulerConfig, recorder JobRunRecorder, logger *zap.Logger) *JobScheduler {
+	defaults := DefaultJobSchedulerConfig()
+	if config.MaxConcurrentJobs <= 0 {
+		config.MaxConcurrentJobs = defaults.MaxConcurrentJobs
+	}
+	if config.JobTimeout <= 0 {
+		config.JobTimeout = defaults.JobTimeout
+	}
+	if config.CheckInterval <= 0 || config.CheckInterval > time.Minute {
+		config.CheckInterval = defaults.CheckInterval
+	}
+
+	return &JobScheduler{
+		config:   config,
+		recorder: recorder,
+		logger:   logger,
+		jobs:     make(map[string]*scheduledJob),
+		slots:    make(chan struct{}, config.MaxConcurrentJobs),
+	}
+}
+
+// Register adds a recurring job.
+// Returns ErrInvalidConfig for an unnamed job or a job without a handler, ErrInvalidCronExpression
+// for a malformed schedule and ErrJobAlreadyRegistered if a job with the name exists.
+func (s *JobScheduler) Register(job RecurringJob) error {
+	name := strings.TrimSpace(job.Name)
+	if name == "" || len(name) > maxJobNameLength {
+		return fmt.Errorf("%w: job name must be 1-%d characters", ErrInvalidConfig, maxJobNameLength)
+	}
+	if job.Handler == nil {
+		return fmt.Errorf("%w: job %q has no handler", ErrInvalidConfig, name)
+	}
+	if job.MaxRetries < 0 {
+		return fmt.Errorf("%w: job %q cannot have negative retries", ErrInvalidConfig, name)
+	}
+	schedule, err := ParseCronExpression(job.CronExpression)
+	if err != nil {
+		return err
+	}
+	job.Name = name
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("%w: %q", ErrJobAlreadyRegistered, name)
+	}
+	s.jobs[name] = &scheduledJob{
+		RecurringJob: job,
+		schedule:     schedule,
+		nextRunAt:    schedule.Next(time.Now()),
+	}
+
+	s.logger.Info("Recurring job registered",
+		zap.String("job", name),
+		zap.String("cron_expression", schedule.String()),
+		zap.Int("max_retries", job.MaxRetries),
+	)
+	return nil
+}
+
+// Start starts the scheduler
+func (s *JobScheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isRunning {
+		return nil
+	}
+	s.isRunning = true
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	s.loopWG.Add(1)
+	go s.runLoop(s.ctx)
+
+	s.logger.Info("Job scheduler started",
+		zap.Int("jobs", len(s.jobs)),
+		zap.Int("max_concurrent_jobs", s.config.MaxConcurrentJobs),
+	)
+	return nil
+}
+
+// Stop stops the scheduler, cancelling the runs in progress and waiting for them to return
+func (s *JobScheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isRunning = false
+	s.mu.Unlock()
+
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.loopWG.Wait()
+		s.runWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Job scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("Job scheduler stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop checks the job schedules at every check interval
+func (s *JobScheduler) runLoop(ctx context.Context) {
+	defer s.loopWG.Done()
+
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.tick(now)
+		}
+	}
+}
+
+// tick starts the jobs scheduled for the minute of now that have not fired for it yet
+func (s *JobScheduler) tick(now time.Time) {
+	minute := now.Truncate(time.Minute)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.isRunning {
+		return
+	}
+
+	for _, name := range s.sortedJobNames() {
+		job := s.jobs[name]
+		if !job.schedule.Matches(minute) || job.lastFired.Equal(minute) {
+			continue
+		}
+		job.lastFired = minute
+		job.nextRunAt = job.schedule.Next(minute)
+
+		if job.running {
+			s.logger.Warn("Skipping scheduled run of job still in progress", zap.String("job", name))
+			continue
+		}
+		s.startLocked(job)
+	}
+}
+
+// RunNow starts a run of a job immediately, outside its schedule.
+// Returns ErrSchedulerNotRunning if the scheduler is stopped, ErrJobNotFound for an unknown job
+// and ErrJobAlreadyRunning if a run of the job is in progress.
+func (s *JobScheduler) RunNow(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.isRunning {
+		return ErrSchedulerNotRunning
+	}
+	job, ok := s.jobs[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrJobNotFound, name)
+	}
+	if job.running {
+		return fmt.Errorf("%w: %q", ErrJobAlreadyRunning, name)
+	}
+	s.startLocked(job)
+	return nil
+}
+
+// startLocked starts a run of the job in the background; s.mu must be held
+func (s *JobScheduler) startLocked(job *scheduledJob) {
+	job.running = true
+	s.runWG.Add(1)
+	go s.run(s.ctx, job)
+}
+
+// run executes a job, retrying failed attempts, and marks it idle when done
+func (s *JobScheduler) run(ctx context.Context, job *scheduledJob) {
+	defer s.runWG.Done()
+
+	var err error
+	for attempt := 0; attempt <= job.MaxRetries; attempt++ {
+		if attempt > 0 {
+			s.logger.Info("Retrying job",
+				zap.String("job", job.Name),
+				zap.Int("retry_count", attempt),
+				zap.Int("max_retries", job.MaxRetries),
+			)
+			if !sleepContext(ctx, job.RetryDelay) {
+				break
+			}
+		}
+
+		err = s.attempt(ctx, job)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	job.running = false
+	job.lastRunAt = &now
+	job.lastStatus = JobStatusSuccess
+	job.lastError = ""
+	if err != nil {
+		job.lastStatus = JobStatusFailed
+		job.lastError = err.Error()
+	}
+	s.mu.Unlock()
+}
+
+// attempt runs a job once within a concurrency slot and records the run
+func (s *JobScheduler) attempt(ctx context.Context, job *scheduledJob) error {
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.slots }()
+
+	var runID uuid.UUID
+	if s.recorder != nil {
+		var recordErr error
+		runID, recordErr = s.recorder.RecordJobStart(ctx, nil, job.Name)
+		if recordErr != nil {
+			s.logger.Warn("Failed to record job start", zap.String("job", job.Name), zap.Error(recordErr))
+		}
+	}
+
+	timeout := job.Timeout
+	if timeout <= 0 {
+		timeout = s.config.JobTimeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s.logger.Info("Running job", zap.String("job", job.Name))
+	err := job.Handler(runCtx)
+	if err != nil {
+		s.logger.Error("Job failed", zap.String("job", job.Name), zap.Error(err))
+	} else {
+		s.logger.Info("Job completed successfully", zap.String("job", job.Name))
+	}
+
+	if s.recorder != nil && runID != uuid.Nil {
+		errMsg := ""
+		if err != nil {
+			errMsg = err.Error()
+		}
+		// Record the outcome even if the run was cancelled
+		if recordErr := s.recorder.RecordJobComplete(context.WithoutCancel(ctx), runID, err == nil, errMsg); recordErr != nil {
+			s.logger.Warn("Failed to record job completion", zap.String("job", job.Name), zap.Error(recordErr))
+		}
+	}
+	return err
+}
+
+// sleepContext waits for the delay; returns false if the context is done first
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// NextRunAt returns when a job is next scheduled to run
+func (s *JobScheduler) NextRunAt(name string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[name]
+	if !ok || job.nextRunAt.IsZero() {
+		return time.Time{}, false
+	}
+	return job.nextRunAt, true
+}
+
+// GetStatus returns the current status of the scheduler and each of its jobs
+func (s *JobScheduler) GetStatus() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]map[string]any, 0, len(s.jobs))
+	for _, name := range s.sortedJobNames() {
+		job := s.jobs[name]
+		status := map[string]any{
+			"name":            name,
+			"cron_expression": job.schedule.String(),
+			"max_retries":     job.MaxRetries,
+			"is_running":      job.running,
+			"last_run_at":     job.lastRunAt,
+			"last_status":     job.lastStatus,
+			"last_error":      job.lastError,
+		}
+		if !job.nextRunAt.IsZero() {
+			status["next_run_at"] = job.nextRunAt
+		}
+		jobs = append(jobs, status)
+	}
+
+	return map[string]any{
+		"is_running":          s.isRunning,
+		"max_concurrent_jobs": s.config.MaxConcurrentJobs,
+		"jobs":                jobs,
+	}
+}
+
+// sortedJobNames returns the names of the registered jobs in order; s.mu must be held
+func (s *JobScheduler) sortedJobNames() []string {
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Ensure SchedulerJobRepository implements JobRunRecorder interface
+var _ JobRunRecorder = (*SchedulerJobRepository)(nil)
@@ -0,0 +1,337 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// mockJobRunRecorder keeps the run history in memory
+type mockJobRunRecorder struct {
+	mu   sync.Mutex
+	runs []mockJobRun
+}
+
+type mockJobRun struct {
+	id      uuid.UUID
+	jobName string
+	status  JobStatus
+	errMsg  string
+}
+
+func (m *mockJobRunRecorder) RecordJobStart(ctx context.Context, tenantID *uuid.UUID, jobName string) (uuid.UUID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	run := mockJobRun{id: uuid.New(), jobName: jobName, status: JobStatusRunning}
+	m.runs = append(m.runs, run)
+	return run.id, nil
+}
+
+func (m *mockJobRunRecorder) RecordJobComplete(ctx context.Context, runID uuid.UUID, success bool, errMsg string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.runs {
+		if m.runs[i].id == runID {
+			m.runs[i].status = JobStatusSuccess
+			if !success {
+				m.runs[i].status = JobStatusFailed
+			}
+			m.runs[i].errMsg = errMsg
+		}
+	}
+	return nil
+}
+
+func (m *mockJobRunRecorder) history() []mockJobRun {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]mockJobRun(nil), m.runs...)
+}
+
+func newTestJobScheduler(t *testing.T, maxConcurrentJobs int, recorder JobRunRecorder) *JobScheduler {
+	t.Helper()
+	s := NewJobScheduler(JobSchedulerConfig{MaxConcurrentJobs: maxConcurrentJobs, JobTimeout: time.Minute}, recorder, zap.NewNop())
+	require.NoError(t, s.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.Stop(ctx)
+	})
+	return s
+}
+
+func TestJobScheduler_Register(t *testing.T) {
+	s := NewJobScheduler(DefaultJobSchedulerConfig(), nil, zap.NewNop())
+	handler := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, s.Register(RecurringJob{Name: "supplier_scorecard_refresh", CronExpression: "0 3 * * 1", Handler: handler}))
+
+	err := s.Register(RecurringJob{Name: "supplier_scorecard_refresh", CronExpression: "0 4 * * 1", Handler: handler})
+	assert.ErrorIs(t, err, ErrJobAlreadyRegistered)
+
+	err = s.Register(RecurringJob{Name: "aging_snapshot", CronExpression: "0 1 1 *", Handler: handler})
+	assert.ErrorIs(t, err, ErrInvalidCronExpression)
+
+	err = s.Register(RecurringJob{Name: "aging_snapshot", CronExpression: "0 1 1 * *"})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	err = s.Register(RecurringJob{Name: "", CronExpression: "0 1 1 * *", Handler: handler})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	// Weekly job runs next Monday at 3:00
+	next, ok := s.NextRunAt("supplier_scorecard_refresh")
+	require.True(t, ok)
+	assert.Equal(t, time.Monday, next.Weekday())
+	assert.Equal(t, 3, next.Hour())
+}
+
+func TestJobScheduler_OverlappingSchedulesRespectConcurrencyCap(t *testing.T) {
+	const maxConcurrent = 2
+	recorder := &mockJobRunRecorder{}
+	s := NewJobScheduler(JobSchedulerConfig{MaxConcurrentJobs: maxConcurrent, JobTimeout: time.Minute}, recorder, zap.NewNop())
+
+	var running, peak, completed atomic.Int32
+	release := make(chan struct{})
+	handler := func(ctx context.Context) error {
+		current := running.Add(1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+		completed.Add(1)
+		return nil
+	}
+
+	// Four jobs whose schedules all fall on the same minute
+	for _, job := range []RecurringJob{
+		{Name: "daily_report_aggregation", CronExpression: "0 2 * * *"},
+		{Name: "supplier_scorecard_refresh", CronExpression: "0 2 * * 1"},
+		{Name: "aging_snapshot", CronExpression: "0 2 1 * *"},
+		{Name: "cache_cleanup", CronExpression: "*/15 * * * *"},
+	} {
+		job.Handler = handler
+		require.NoError(t, s.Register(job))
+	}
+	require.NoError(t, s.Start(context.Background()))
+	defer func() { _ = s.Stop(context.Background()) }()
+
+	// Monday 1 June 2026, 2:00
+	s.tick(time.Date(2026, 6, 1, 2, 0, 0, 0, time.Local))
+
+	require.Eventually(t, func() bool { return running.Load() == maxConcurrent }, time.Second, time.Millisecond)
+	// The other runs wait for a slot instead of exceeding the cap
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(maxConcurrent), running.Load())
+
+	// A tick in the same minute does not fire the jobs again, and jobs still in progress are not run twice
+	s.tick(time.Date(2026, 6, 1, 2, 0, 30, 0, time.Local))
+	assert.ErrorIs(t, s.RunNow("cache_cleanup"), ErrJobAlreadyRunning)
+
+	close(release)
+	require.Eventually(t, func() bool { return completed.Load() == 4 }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(maxConcurrent), peak.Load())
+
+	// Every run is recorded in the run history
+	require.Eventually(t, func() bool {
+		history := recorder.history()
+		for _, run := range history {
+			if run.status != JobStatusSuccess {
+				return false
+			}
+		}
+		return len(history) == 4
+	}, time.Second, time.Millisecond)
+}
+
+func TestJobScheduler_FailingJobRetries(t *testing.T) {
+	recorder := &mockJobRunRecorder{}
+	s := NewJobScheduler(JobSchedulerConfig{MaxConcurrentJobs: 1, JobTimeout: time.Minute}, recorder, zap.NewNop())
+
+	var attempts atomic.Int32
+	require.NoError(t, s.Register(RecurringJob{
+		Name:           "aging_snapshot",
+		CronExpression: "0 1 1 * *",
+		MaxRetries:     3,
+		RetryDelay:     time.Millisecond,
+		Handler: func(ctx context.Context) error {
+			// Fails twice, then succeeds
+			if attempts.Add(1) <= 2 {
+				return errors.New("database unavailable")
+			}
+			return nil
+		},
+	}))
+	require.NoError(t, s.Start(context.Background()))
+	defer func() { _ = s.Stop(context.Background()) }()
+
+	require.NoError(t, s.RunNow("aging_snapshot"))
+
+	require.Eventually(t, func() bool {
+		history := recorder.history()
+		return len(history) == 3 && history[2].status == JobStatusSuccess
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	history := recorder.history()
+	for _, run := range history[:2] {
+		assert.Equal(t, "aging_snapshot", run.jobName)
+		assert.Equal(t, JobStatusFailed, run.status)
+		assert.Equal(t, "database unavailable", run.errMsg)
+	}
+
+	require.Eventually(t, func() bool {
+		jobs := s.GetStatus()["jobs"].([]map[string]any)
+		return jobs[0]["is_running"] == false
+	}, time.Second, time.Millisecond)
+	jobs := s.GetStatus()["jobs"].([]map[string]any)
+	assert.Equal(t, JobStatusSuccess, jobs[0]["last_status"])
+}
+
+func TestJobScheduler_FailingJobStopsAfterMaxRetries(t *testing.T) {
+	recorder := &mockJobRunRecorder{}
+	s := newTestJobScheduler(t, 1, recorder)
+
+	var attempts atomic.Int32
+	require.NoError(t, s.Register(RecurringJob{
+		Name:           "supplier_scorecard_refresh",
+		CronExpression: "0 3 * * 1",
+		MaxRetries:     2,
+		Handler: func(ctx context.Context) error {
+			attempts.Add(1)
+			return errors.New("scorecard refresh failed")
+		},
+	}))
+
+	require.NoError(t, s.RunNow("supplier_scorecard_refresh"))
+
+	require.Eventually(t, func() bool {
+		jobs := s.GetStatus()["jobs"].([]map[string]any)
+		return jobs[0]["last_status"] == JobStatusFailed
+	}, time.Second, time.Millisecond)
+
+	// The first attempt and two retries
+	assert.Equal(t, int32(3), attempts.Load())
+	history := recorder.history()
+	require.Len(t, history, 3)
+	for _, run := range history {
+		assert.Equal(t, JobStatusFailed, run.status)
+	}
+	jobs := s.GetStatus()["jobs"].([]map[string]any)
+	assert.Equal(t, "scorecard refresh failed", jobs[0]["last_error"])
+}
+
+func TestJobScheduler_RunNow(t *testing.T) {
+	s := NewJobScheduler(DefaultJobSchedulerConfig(), nil, zap.NewNop())
+	require.NoError(t, s.Register(RecurringJob{
+		Name:           "aging_snapshot",
+		CronExpression: "0 1 1 * *",
+		Handler:        func(ctx context.Context) error { return nil },
+	}))
+
+	assert.ErrorIs(t, s.RunNow("aging_snapshot"), ErrSchedulerNotRunning)
+
+	require.NoError(t, s.Start(context.Background()))
+	defer func() { _ = s.Stop(context.Background()) }()
+
+	assert.ErrorIs(t, s.RunNow("unknown"), ErrJobNotFound)
+	assert.NoError(t, s.RunNow("aging_snapshot"))
+}
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		matches []time.Time
+		misses  []time.Time
+	}{
+		{
+			name:    "daily at 2am",
+			expr:    "0 2 * * *",
+			matches: []time.Time{time.Date(2026, 1, 15, 2, 0, 0, 0, time.UTC)},
+			misses:  []time.Time{time.Date(2026, 1, 15, 2, 1, 0, 0, time.UTC)},
+		},
+		{
+			name:    "every 15 minutes",
+			expr:    "*/15 * * * *",
+			matches: []time.Time{time.Date(2026, 1, 15, 9, 45, 0, 0, time.UTC)},
+			misses:  []time.Time{time.Date(2026, 1, 15, 9, 50, 0, 0, time.UTC)},
+		},
+		{
+			name: "weekdays at 9 and 17",
+			expr: "0 9,17 * * 1-5",
+			matches: []time.Time{
+				time.Date(2026, 1, 16, 17, 0, 0, 0, time.UTC), // Friday
+			},
+			misses: []time.Time{
+				time.Date(2026, 1, 17, 9, 0, 0, 0, time.UTC), // Saturday
+			},
+		},
+		{
+			name:    "Sunday as 7",
+			expr:    "30 6 * * 7",
+			matches: []time.Time{time.Date(2026, 1, 18, 6, 30, 0, 0, time.UTC)},
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 1 * 1",
+			matches: []time.Time{
+				time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),  // 1st, a Thursday
+				time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), // Monday
+			},
+			misses: []time.Time{time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCronExpression(tt.expr)
+			require.NoError(t, err)
+			for _, at := range tt.matches {
+				assert.True(t, cron.Matches(at), "expected match at %s", at)
+			}
+			for _, at := range tt.misses {
+				assert.False(t, cron.Matches(at), "expected no match at %s", at)
+			}
+		})
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := ParseCronExpression(expr)
+		assert.ErrorIs(t, err, ErrInvalidCronExpression, "expression %q", expr)
+	}
+}
+
+func TestCronExpression_Next(t *testing.T) {
+	from := time.Date(2026, 1, 31, 23, 59, 30, 0, time.UTC) // Saturday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"0 2 * * *", time.Date(2026, 2, 1, 2, 0, 0, 0, time.UTC)},
+		{"0 3 * * 1", time.Date(2026, 2, 2, 3, 0, 0, 0, time.UTC)},
+		{"0 1 1 * *", time.Date(2026, 2, 1, 1, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCronExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cron.Next(from))
+		})
+	}
+}
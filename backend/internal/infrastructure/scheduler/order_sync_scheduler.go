@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/infrastructure/persistence/tenant"
 )
 
 // ---------------------------------------------------------------------------
@@ -357,8 +358,8 @@ func (s *OrderSyncScheduler) processJob(ctx context.Context, job *OrderSyncJob,
 		zap.Time("end_time", job.EndTime),
 	)
 
-	// Create context with timeout
-	jobCtx, cancel := context.WithTimeout(ctx, s.config.JobTimeout)
+	// Create context with timeout, scoped to the job's tenant
+	jobCtx, cancel := context.WithTimeout(tenant.WithTenant(ctx, job.TenantID), s.config.JobTimeout)
 	defer cancel()
 
 	// Execute the job
@@ -14,7 +14,7 @@ import (
 	"gorm.io/gorm"
 )
 
-// cronTickerInterval is the interval at which the cron scheduler checks for execution
+// cronTickerInterval is the interval at which the job scheduler checks the job schedules
 const cronTickerInterval = 1 * time.Minute
 
 // ReportCronSchedulerConfig holds configuration for the cron-based report scheduler
@@ -190,7 +190,14 @@ func (r *SchedulerJobRepository) GetLastJobStatus(ctx context.Context, tenantID
 	return &record, nil
 }
 
-// ReportCronScheduler implements cron-based scheduling for daily report aggregation
+// ReportAggregationJobName is the name of the daily report aggregation job in the job scheduler
+const ReportAggregationJobName = "daily_report_aggregation"
+
+// ReportCronScheduler implements cron-based scheduling for daily report aggregation.
+// The aggregation runs as a recurring job of a JobScheduler (see RegisterJob) and submits
+// one report job per tenant and report type to its report worker pool.
+// Each aggregation, scheduled or triggered, is tracked as an AggregationRun; only one runs at a time
+// unless a trigger forces another.
 type ReportCronScheduler struct {
 	config     ReportCronSchedulerConfig
 	executor   JobExecutor
@@ -198,15 +205,15 @@ type ReportCronScheduler struct {
 	jobRepo    *SchedulerJobRepository
 	logger     *zap.Logger
 	scheduler  *Scheduler
+	jobs       *JobScheduler
 
-	cancel    context.CancelFunc
-	wg        sync.WaitGroup
 	mu        sync.Mutex
 	isRunning bool
 
 	// Last execution tracking
 	lastRunAt *time.Time
-	nextRunAt *time.Time
+	// Aggregation runs by ID, kept in memory for polling
+	runs map[uuid.UUID]*AggregationRun
 }
 
 // NewReportCronScheduler creates a new cron-based report scheduler
@@ -226,17 +233,48 @@ func NewReportCronScheduler(
 	}
 	scheduler := NewScheduler(schedulerConfig, executor, logger)
 
-	return &ReportCronScheduler{
+	s := &ReportCronScheduler{
 		config:     config,
 		executor:   executor,
 		tenantRepo: tenantRepo,
 		jobRepo:    jobRepo,
 		logger:     logger,
 		scheduler:  scheduler,
+		runs:       make(map[uuid.UUID]*AggregationRun),
 	}
+	scheduler.SetJobDoneHandler(s.reportJobDone)
+	return s
 }
 
-// Start starts the cron scheduler
+// RegisterJob registers the daily report aggregation as a recurring job of the job scheduler,
+// run on DailyCronSchedule (or CronHour:CronMinute daily if no expression is configured)
+func (s *ReportCronScheduler) RegisterJob(jobs *JobScheduler) error {
+	if err := jobs.Register(RecurringJob{
+		Name:           ReportAggregationJobName,
+		CronExpression: s.cronExpression(),
+		Handler:        s.runDailyAggregation,
+		MaxRetries:     s.config.RetryAttempts,
+		RetryDelay:     s.config.RetryDelay,
+		Timeout:        s.config.JobTimeout,
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.jobs = jobs
+	s.mu.Unlock()
+	return nil
+}
+
+// cronExpression returns the cron expression of the daily aggregation
+func (s *ReportCronScheduler) cronExpression() string {
+	if strings.TrimSpace(s.config.DailyCronSchedule) != "" {
+		return s.config.DailyCronSchedule
+	}
+	return fmt.Sprintf("%d %d * * *", s.config.CronMinute, s.config.CronHour)
+}
+
+// Start starts the report worker pool
 func (s *ReportCronScheduler) Start(ctx context.Context) error {
 	s.mu.Lock()
 	if s.isRunning {
@@ -251,26 +289,15 @@ func (s *ReportCronScheduler) Start(ctx context.Context) error {
 		return err
 	}
 
-	ctx, cancel := context.WithCancel(ctx)
-	s.cancel = cancel
-
-	// Calculate next run time
-	s.calculateNextRunTime()
-
-	// Start the cron ticker
-	s.wg.Add(1)
-	go s.cronLoop(ctx)
-
 	s.logger.Info("Report cron scheduler started",
-		zap.Int("cron_hour", s.config.CronHour),
-		zap.Int("cron_minute", s.config.CronMinute),
-		zap.Timep("next_run_at", s.nextRunAt),
+		zap.String("cron_expression", s.cronExpression()),
+		zap.Timep("next_run_at", s.GetNextRunAt()),
 	)
 
 	return nil
 }
 
-// Stop stops the cron scheduler
+// Stop stops the report worker pool
 func (s *ReportCronScheduler) Stop(ctx context.Context) error {
 	s.mu.Lock()
 	if !s.isRunning {
@@ -280,99 +307,81 @@ func (s *ReportCronScheduler) Stop(ctx context.Context) error {
 	s.isRunning = false
 	s.mu.Unlock()
 
-	// Cancel the cron loop
-	if s.cancel != nil {
-		s.cancel()
-	}
-
-	// Wait for cron loop to finish
-	done := make(chan struct{})
-	go func() {
-		s.wg.Wait()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		// Now stop the underlying scheduler
-		if err := s.scheduler.Stop(ctx); err != nil {
-			s.logger.Warn("Error stopping underlying scheduler", zap.Error(err))
-		}
-		s.logger.Info("Report cron scheduler stopped")
-		return nil
-	case <-ctx.Done():
+	if err := s.scheduler.Stop(ctx); err != nil {
 		s.logger.Warn("Report cron scheduler stop timed out")
-		return ctx.Err()
-	}
-}
-
-// cronLoop runs the main cron loop
-func (s *ReportCronScheduler) cronLoop(ctx context.Context) {
-	defer s.wg.Done()
-
-	// Use a ticker that checks every minute for cron execution
-	ticker := time.NewTicker(cronTickerInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case now := <-ticker.C:
-			if s.shouldRun(now) {
-				s.runDailyAggregation(ctx)
-				s.calculateNextRunTime()
-			}
-		}
+		return err
 	}
+	s.logger.Info("Report cron scheduler stopped")
+	return nil
 }
 
-// shouldRun checks if the cron should run at the given time
-func (s *ReportCronScheduler) shouldRun(now time.Time) bool {
-	return now.Hour() == s.config.CronHour && now.Minute() == s.config.CronMinute
-}
+// runDailyAggregation runs the daily aggregation of yesterday for all active tenants.
+// Returns an error if the run cannot start, e.g. another run is in progress or the tenants
+// cannot be fetched, so that the job scheduler retries it.
+func (s *ReportCronScheduler) runDailyAggregation(ctx context.Context) error {
+	s.logger.Info("Starting daily report aggregation")
 
-// calculateNextRunTime calculates the next run time
-func (s *ReportCronScheduler) calculateNextRunTime() {
 	now := time.Now()
-	next := time.Date(now.Year(), now.Month(), now.Day(), s.config.CronHour, s.config.CronMinute, 0, 0, now.Location())
-
-	// If we've already passed today's run time, schedule for tomorrow
-	if now.After(next) {
-		next = next.AddDate(0, 0, 1)
-	}
-
 	s.mu.Lock()
-	s.nextRunAt = &next
+	s.lastRunAt = &now
 	s.mu.Unlock()
-}
 
-// runDailyAggregation runs the daily aggregation for all active tenants
-func (s *ReportCronScheduler) runDailyAggregation(ctx context.Context) {
-	s.logger.Info("Starting daily report aggregation")
+	_, err := s.TriggerAggregation(ctx, AggregationRequest{})
+	return err
+}
 
-	now := time.Now()
+// TriggerAggregation starts an aggregation run submitting a report job per tenant and report type,
+// and returns the run so its progress can be polled with GetRun.
+// Returns ErrSchedulerNotRunning if the scheduler is stopped, ErrInvalidPeriod if the period ends
+// before it starts and ErrJobAlreadyRunning if another run is in progress and the request is not forced.
+func (s *ReportCronScheduler) TriggerAggregation(ctx context.Context, req AggregationRequest) (*AggregationRun, error) {
 	s.mu.Lock()
-	s.lastRunAt = &now
+	running := s.isRunning
 	s.mu.Unlock()
+	if !running {
+		return nil, ErrSchedulerNotRunning
+	}
 
-	// Get all active tenants
-	tenants, err := s.tenantRepo.FindActive(ctx, shared.Filter{})
+	periodStart, periodEnd := req.PeriodStart, req.PeriodEnd
+	if periodStart.IsZero() && periodEnd.IsZero() {
+		// Calculate period (yesterday)
+		yesterday := time.Now().AddDate(0, 0, -1)
+		periodStart = time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, time.Local)
+		periodEnd = time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 23, 59, 59, 999999999, time.Local)
+	}
+	if periodStart.IsZero() || periodEnd.IsZero() || periodEnd.Before(periodStart) {
+		return nil, ErrInvalidPeriod
+	}
+
+	// Reject a run in progress before fetching the tenants, and again before starting
+	if err := s.checkNoActiveRun(req.TenantID, req.Force); err != nil {
+		return nil, err
+	}
+	tenantIDs, err := s.aggregationTenants(ctx, req.TenantID)
 	if err != nil {
-		s.logger.Error("Failed to fetch active tenants for report aggregation", zap.Error(err))
-		return
+		return nil, err
 	}
 
-	s.logger.Info("Scheduling report aggregation for tenants", zap.Int("tenant_count", len(tenants)))
+	s.mu.Lock()
+	if err := s.checkNoActiveRunLocked(req.TenantID, req.Force); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.pruneRunsLocked()
+	run := newAggregationRun(req.TenantID, periodStart, periodEnd)
+	run.TotalJobs = len(tenantIDs) * len(AllReportTypes())
+	s.runs[run.ID] = run
+	s.mu.Unlock()
 
-	// Calculate period (yesterday)
-	yesterday := now.AddDate(0, 0, -1)
-	periodStart := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, time.Local)
-	periodEnd := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 23, 59, 59, 999999999, time.Local)
+	s.logger.Info("Scheduling report aggregation for tenants",
+		zap.String("run_id", run.ID.String()),
+		zap.Int("tenant_count", len(tenantIDs)),
+		zap.Time("period_start", periodStart),
+		zap.Time("period_end", periodEnd),
+	)
 
 	// Schedule jobs for each tenant
-	for _, tenant := range tenants {
-		tenantID := tenant.ID
+	for _, tenantID := range tenantIDs {
 		for _, reportType := range AllReportTypes() {
 			// Record job start
 			var jobID uuid.UUID
@@ -390,6 +399,7 @@ func (s *ReportCronScheduler) runDailyAggregation(ctx context.Context) {
 
 			// Create and submit job
 			job := NewJob(&tenantID, reportType, periodStart, periodEnd, s.config.RetryAttempts)
+			job.RunID = run.ID
 			if err := s.scheduler.SubmitJob(job); err != nil {
 				s.logger.Error("Failed to submit report job",
 					zap.String("tenant_id", tenantID.String()),
@@ -400,6 +410,8 @@ func (s *ReportCronScheduler) runDailyAggregation(ctx context.Context) {
 				if s.jobRepo != nil && jobID != uuid.Nil {
 					_ = s.jobRepo.RecordJobComplete(ctx, jobID, false, err.Error())
 				}
+				job.Fail(err.Error())
+				s.reportJobDone(job)
 				continue
 			}
 
@@ -410,67 +422,134 @@ func (s *ReportCronScheduler) runDailyAggregation(ctx context.Context) {
 		}
 	}
 
-	s.logger.Info("Daily report aggregation jobs scheduled",
-		zap.Int("tenant_count", len(tenants)),
+	s.mu.Lock()
+	// A run without jobs (no active tenants) is done at once
+	run.completeIfDone()
+	snapshot := *run
+	s.mu.Unlock()
+
+	s.logger.Info("Report aggregation jobs scheduled",
+		zap.String("run_id", run.ID.String()),
+		zap.Int("tenant_count", len(tenantIDs)),
 		zap.Int("report_types", len(AllReportTypes())),
 	)
+	return &snapshot, nil
 }
 
-// TriggerManualRun triggers a manual run of the daily aggregation
-// Note: Uses background context to avoid premature cancellation when HTTP request completes
-func (s *ReportCronScheduler) TriggerManualRun(ctx context.Context) error {
-	s.mu.Lock()
-	if !s.isRunning {
-		s.mu.Unlock()
-		return ErrSchedulerNotRunning
+// aggregationTenants returns the tenant to aggregate, or all active tenants if none is given
+func (s *ReportCronScheduler) aggregationTenants(ctx context.Context, tenantID *uuid.UUID) ([]uuid.UUID, error) {
+	if tenantID != nil {
+		return []uuid.UUID{*tenantID}, nil
 	}
-	s.mu.Unlock()
 
-	// Use background context to prevent premature cancellation when HTTP request completes
-	go s.runDailyAggregation(context.Background())
-	return nil
+	// Get all active tenants
+	tenants, err := s.tenantRepo.FindActive(ctx, shared.Filter{})
+	if err != nil {
+		s.logger.Error("Failed to fetch active tenants for report aggregation", zap.Error(err))
+		return nil, fmt.Errorf("fetch active tenants: %w", err)
+	}
+	tenantIDs := make([]uuid.UUID, len(tenants))
+	for i, tenant := range tenants {
+		tenantIDs[i] = tenant.ID
+	}
+	return tenantIDs, nil
 }
 
-// TriggerTenantAggregation triggers aggregation for a specific tenant
-func (s *ReportCronScheduler) TriggerTenantAggregation(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) error {
+// reportJobDone counts a finished report job towards the progress of its aggregation run
+func (s *ReportCronScheduler) reportJobDone(job *Job) {
+	if job.RunID == uuid.Nil {
+		return
+	}
+
 	s.mu.Lock()
-	if !s.isRunning {
-		s.mu.Unlock()
-		return ErrSchedulerNotRunning
+	defer s.mu.Unlock()
+	if run, ok := s.runs[job.RunID]; ok {
+		run.jobFinished(job.Status == JobStatusSuccess, job.Error)
 	}
-	s.mu.Unlock()
+}
 
-	for _, reportType := range AllReportTypes() {
-		job := NewJob(&tenantID, reportType, startDate, endDate, s.config.RetryAttempts)
-		if err := s.scheduler.SubmitJob(job); err != nil {
-			return err
+// checkNoActiveRun returns ErrJobAlreadyRunning if an aggregation run in progress covers
+// any of the tenants of a new run, unless forced. A nil tenant ID covers all tenants.
+func (s *ReportCronScheduler) checkNoActiveRun(tenantID *uuid.UUID, force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkNoActiveRunLocked(tenantID, force)
+}
+
+// checkNoActiveRunLocked is checkNoActiveRun with s.mu held
+func (s *ReportCronScheduler) checkNoActiveRunLocked(tenantID *uuid.UUID, force bool) error {
+	if force {
+		return nil
+	}
+	for _, run := range s.runs {
+		if !run.IsFinished() && tenantScopesOverlap(run.TenantID, tenantID) {
+			return ErrJobAlreadyRunning
 		}
 	}
 	return nil
 }
 
+// tenantScopesOverlap returns true if two aggregation runs would aggregate a common tenant.
+// A nil tenant ID stands for all active tenants.
+func tenantScopesOverlap(a, b *uuid.UUID) bool {
+	return a == nil || b == nil || *a == *b
+}
+
+// pruneRunsLocked forgets runs finished longer than the retention ago; s.mu must be held
+func (s *ReportCronScheduler) pruneRunsLocked() {
+	cutoff := time.Now().Add(-aggregationRunRetention)
+	for id, run := range s.runs {
+		if run.CompletedAt != nil && run.CompletedAt.Before(cutoff) {
+			delete(s.runs, id)
+		}
+	}
+}
+
+// GetRun returns an aggregation run by ID.
+// Finished runs can be polled for a day; returns false for unknown or expired runs.
+func (s *ReportCronScheduler) GetRun(id uuid.UUID) (AggregationRun, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, ok := s.runs[id]
+	if !ok {
+		return AggregationRun{}, false
+	}
+	return *run, true
+}
+
 // GetStatus returns the current status of the cron scheduler
 func (s *ReportCronScheduler) GetStatus() map[string]any {
+	nextRunAt := s.GetNextRunAt()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	return map[string]any{
-		"enabled":       s.config.Enabled,
-		"is_running":    s.isRunning,
-		"cron_hour":     s.config.CronHour,
-		"cron_minute":   s.config.CronMinute,
-		"cron_schedule": "Daily",
-		"last_run_at":   s.lastRunAt,
-		"next_run_at":   s.nextRunAt,
-		"report_types":  AllReportTypes(),
+		"enabled":         s.config.Enabled,
+		"is_running":      s.isRunning,
+		"cron_hour":       s.config.CronHour,
+		"cron_minute":     s.config.CronMinute,
+		"cron_schedule":   "Daily",
+		"cron_expression": s.cronExpression(),
+		"last_run_at":     s.lastRunAt,
+		"next_run_at":     nextRunAt,
+		"report_types":    AllReportTypes(),
 	}
 }
 
-// GetNextRunAt returns when the next scheduled run will occur
+// GetNextRunAt returns when the next scheduled run will occur, nil if the aggregation is not scheduled
 func (s *ReportCronScheduler) GetNextRunAt() *time.Time {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	return s.nextRunAt
+	jobs := s.jobs
+	s.mu.Unlock()
+
+	if jobs == nil {
+		return nil
+	}
+	if next, ok := jobs.NextRunAt(ReportAggregationJobName); ok {
+		return &next
+	}
+	return nil
 }
 
 // GetLastRunAt returns when the last run occurred
@@ -2,11 +2,15 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestParseCronSchedule(t *testing.T) {
@@ -76,15 +80,18 @@ func TestDefaultReportCronSchedulerConfig(t *testing.T) {
 	assert.Equal(t, 5*time.Minute, cfg.RetryDelay)
 }
 
-func TestShouldRun(t *testing.T) {
+func TestReportCronScheduler_CronExpression(t *testing.T) {
 	cfg := DefaultReportCronSchedulerConfig()
+	cfg.DailyCronSchedule = ""
 	cfg.CronHour = 2
 	cfg.CronMinute = 30
 
-	// Create a minimal scheduler for testing shouldRun
+	// Create a minimal scheduler for testing the schedule of the aggregation
 	s := &ReportCronScheduler{
 		config: cfg,
 	}
+	cron, err := ParseCronExpression(s.cronExpression())
+	require.NoError(t, err)
 
 	tests := []struct {
 		name     string
@@ -115,30 +122,32 @@ func TestShouldRun(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := s.shouldRun(tt.time)
+			result := cron.Matches(tt.time)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
-func TestCalculateNextRunTime(t *testing.T) {
+func TestReportCronScheduler_RegisterJob(t *testing.T) {
 	cfg := DefaultReportCronSchedulerConfig()
-	cfg.CronHour = 2
-	cfg.CronMinute = 0
+	cfg.DailyCronSchedule = "0 2 * * *"
 
 	s := &ReportCronScheduler{
 		config: cfg,
 	}
+	jobs := NewJobScheduler(DefaultJobSchedulerConfig(), nil, zap.NewNop())
 
-	// Test before today's run time
-	t.Run("Before run time", func(t *testing.T) {
-		// Assume current time is 1:00 AM
-		s.calculateNextRunTime()
-		assert.NotNil(t, s.nextRunAt)
-		// Should be scheduled for today at 2:00 AM
-		assert.Equal(t, cfg.CronHour, s.nextRunAt.Hour())
-		assert.Equal(t, cfg.CronMinute, s.nextRunAt.Minute())
-	})
+	require.NoError(t, s.RegisterJob(jobs))
+
+	// The aggregation is scheduled daily at 2:00 AM
+	nextRunAt := s.GetNextRunAt()
+	require.NotNil(t, nextRunAt)
+	assert.Equal(t, 2, nextRunAt.Hour())
+	assert.Equal(t, 0, nextRunAt.Minute())
+	assert.True(t, nextRunAt.After(time.Now()))
+
+	// The aggregation is registered once
+	assert.ErrorIs(t, s.RegisterJob(jobs), ErrJobAlreadyRegistered)
 }
 
 func TestSchedulerJobRecord(t *testing.T) {
@@ -163,26 +172,214 @@ func TestReportCronScheduler_GetStatus(t *testing.T) {
 	assert.Contains(t, status, "report_types")
 }
 
-func TestReportCronScheduler_TriggerManualRun_NotRunning(t *testing.T) {
+func TestReportCronScheduler_TriggerAggregation_NotRunning(t *testing.T) {
 	cfg := DefaultReportCronSchedulerConfig()
 	s := &ReportCronScheduler{
 		config:    cfg,
 		isRunning: false,
 	}
 
-	err := s.TriggerManualRun(context.Background())
+	_, err := s.TriggerAggregation(context.Background(), AggregationRequest{})
+	assert.ErrorIs(t, err, ErrSchedulerNotRunning)
+
+	tenantID := uuid.New()
+	_, err = s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantID, PeriodStart: time.Now(), PeriodEnd: time.Now()})
 	assert.ErrorIs(t, err, ErrSchedulerNotRunning)
 }
 
-func TestReportCronScheduler_TriggerTenantAggregation_NotRunning(t *testing.T) {
+// gatedJobExecutor runs report jobs once released, failing the jobs of one report type
+type gatedJobExecutor struct {
+	release  chan struct{}
+	failType ReportType
+
+	mu   sync.Mutex
+	jobs []Job
+}
+
+func newGatedJobExecutor() *gatedJobExecutor {
+	return &gatedJobExecutor{release: make(chan struct{})}
+}
+
+func (e *gatedJobExecutor) Execute(ctx context.Context, job *Job) error {
+	select {
+	case <-e.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	e.mu.Lock()
+	e.jobs = append(e.jobs, *job)
+	e.mu.Unlock()
+
+	if job.ReportType == e.failType {
+		return errors.New("aggregation query failed")
+	}
+	return nil
+}
+
+func (e *gatedJobExecutor) executed() []Job {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Job(nil), e.jobs...)
+}
+
+func startReportCronScheduler(t *testing.T, executor JobExecutor) *ReportCronScheduler {
+	t.Helper()
 	cfg := DefaultReportCronSchedulerConfig()
-	s := &ReportCronScheduler{
-		config:    cfg,
-		isRunning: false,
+	cfg.RetryAttempts = 0
+	s := NewReportCronScheduler(cfg, executor, nil, nil, zap.NewNop())
+	require.NoError(t, s.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.Stop(ctx)
+	})
+	return s
+}
+
+// pollRun polls an aggregation run until it has finished
+func pollRun(t *testing.T, s *ReportCronScheduler, id uuid.UUID) AggregationRun {
+	t.Helper()
+	var run AggregationRun
+	require.Eventually(t, func() bool {
+		var ok bool
+		run, ok = s.GetRun(id)
+		require.True(t, ok)
+		return run.IsFinished()
+	}, 5*time.Second, time.Millisecond)
+	return run
+}
+
+func TestReportCronScheduler_TriggerAggregation_CustomRange(t *testing.T) {
+	executor := newGatedJobExecutor()
+	s := startReportCronScheduler(t, executor)
+
+	tenantID := uuid.New()
+	periodStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	periodEnd := time.Date(2026, 3, 31, 23, 59, 59, 0, time.Local)
+
+	run, err := s.TriggerAggregation(context.Background(), AggregationRequest{
+		TenantID:    &tenantID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, run.ID)
+	assert.Equal(t, JobStatusRunning, run.Status)
+	assert.Equal(t, len(AllReportTypes()), run.TotalJobs)
+	assert.Equal(t, 0, run.Progress())
+
+	// The run is polled while in progress
+	polled, ok := s.GetRun(run.ID)
+	require.True(t, ok)
+	assert.Equal(t, JobStatusRunning, polled.Status)
+
+	close(executor.release)
+	finished := pollRun(t, s, run.ID)
+
+	assert.Equal(t, JobStatusSuccess, finished.Status)
+	assert.Equal(t, 100, finished.Progress())
+	assert.Equal(t, len(AllReportTypes()), finished.CompletedJobs)
+	assert.Empty(t, finished.Error)
+	assert.NotNil(t, finished.CompletedAt)
+
+	// Every report job aggregated the requested tenant and period
+	jobs := executor.executed()
+	require.Len(t, jobs, len(AllReportTypes()))
+	for _, job := range jobs {
+		assert.Equal(t, tenantID, *job.TenantID)
+		assert.Equal(t, periodStart, job.PeriodStart)
+		assert.Equal(t, periodEnd, job.PeriodEnd)
+		assert.Equal(t, run.ID, job.RunID)
 	}
 
-	err := s.TriggerTenantAggregation(context.Background(), [16]byte{}, time.Now(), time.Now())
-	assert.ErrorIs(t, err, ErrSchedulerNotRunning)
+	_, ok = s.GetRun(uuid.New())
+	assert.False(t, ok)
+}
+
+func TestReportCronScheduler_TriggerAggregation_FailedJob(t *testing.T) {
+	executor := newGatedJobExecutor()
+	executor.failType = ReportTypeInventorySummary
+	close(executor.release)
+	s := startReportCronScheduler(t, executor)
+
+	tenantID := uuid.New()
+	run, err := s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantID})
+	require.NoError(t, err)
+
+	finished := pollRun(t, s, run.ID)
+
+	assert.Equal(t, JobStatusFailed, finished.Status)
+	assert.Equal(t, 100, finished.Progress())
+	assert.Equal(t, 1, finished.FailedJobs)
+	assert.Equal(t, len(AllReportTypes())-1, finished.CompletedJobs)
+	assert.Contains(t, finished.Error, "aggregation query failed")
+
+	// Without a range the run aggregates yesterday
+	yesterday := time.Now().AddDate(0, 0, -1)
+	assert.Equal(t, yesterday.Day(), finished.PeriodStart.Day())
+	assert.Equal(t, yesterday.Day(), finished.PeriodEnd.Day())
+}
+
+func TestReportCronScheduler_TriggerAggregation_RejectsConcurrentRun(t *testing.T) {
+	executor := newGatedJobExecutor()
+	s := startReportCronScheduler(t, executor)
+
+	tenantID := uuid.New()
+	first, err := s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantID})
+	require.NoError(t, err)
+
+	// Another run is rejected while the first is in progress
+	_, err = s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantID})
+	assert.ErrorIs(t, err, ErrJobAlreadyRunning)
+
+	// Unless it is forced
+	forced, err := s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantID, Force: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, forced.ID)
+
+	close(executor.release)
+	assert.Equal(t, JobStatusSuccess, pollRun(t, s, first.ID).Status)
+	assert.Equal(t, JobStatusSuccess, pollRun(t, s, forced.ID).Status)
+
+	// Once the runs have finished a new run can start
+	_, err = s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantID})
+	assert.NoError(t, err)
+}
+
+func TestReportCronScheduler_TriggerAggregation_ConflictsOnlyOnOverlappingTenants(t *testing.T) {
+	executor := newGatedJobExecutor()
+	s := startReportCronScheduler(t, executor)
+
+	tenantA, tenantB := uuid.New(), uuid.New()
+	first, err := s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantA})
+	require.NoError(t, err)
+
+	// A run for another tenant does not overlap the first
+	other, err := s.TriggerAggregation(context.Background(), AggregationRequest{TenantID: &tenantB})
+	require.NoError(t, err)
+
+	// A run for all tenants overlaps both, and the conflict does not reveal the other run
+	_, err = s.TriggerAggregation(context.Background(), AggregationRequest{})
+	assert.ErrorIs(t, err, ErrJobAlreadyRunning)
+	assert.NotContains(t, err.Error(), first.ID.String())
+	assert.NotContains(t, err.Error(), other.ID.String())
+
+	close(executor.release)
+	assert.Equal(t, JobStatusSuccess, pollRun(t, s, first.ID).Status)
+	assert.Equal(t, JobStatusSuccess, pollRun(t, s, other.ID).Status)
+}
+
+func TestReportCronScheduler_TriggerAggregation_InvalidPeriod(t *testing.T) {
+	s := startReportCronScheduler(t, newGatedJobExecutor())
+	tenantID := uuid.New()
+
+	_, err := s.TriggerAggregation(context.Background(), AggregationRequest{
+		TenantID:    &tenantID,
+		PeriodStart: time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local),
+		PeriodEnd:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local),
+	})
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
 }
 
 func TestAllReportTypes(t *testing.T) {
@@ -7,6 +7,8 @@ import (
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+
+	"github.com/erp/backend/internal/infrastructure/persistence/tenant"
 )
 
 // JobStatus represents the status of a scheduled job
@@ -57,6 +59,8 @@ type Job struct {
 	RetryCount  int
 	MaxRetries  int
 	NextRetryAt *time.Time
+	// RunID is the aggregation run the job belongs to, uuid.Nil if none
+	RunID uuid.UUID
 }
 
 // NewJob creates a new job instance
@@ -114,6 +118,9 @@ type JobExecutor interface {
 	Execute(ctx context.Context, job *Job) error
 }
 
+// JobDoneHandler is called when a job has finished: it succeeded, or failed without further retries
+type JobDoneHandler func(job *Job)
+
 // SchedulerConfig holds scheduler configuration
 type SchedulerConfig struct {
 	Enabled           bool
@@ -139,6 +146,7 @@ type Scheduler struct {
 	config   SchedulerConfig
 	executor JobExecutor
 	logger   *zap.Logger
+	onDone   JobDoneHandler
 
 	jobs      chan *Job
 	cancel    context.CancelFunc
@@ -157,6 +165,13 @@ func NewScheduler(config SchedulerConfig, executor JobExecutor, logger *zap.Logg
 	}
 }
 
+// SetJobDoneHandler sets the handler called when each job has finished.
+//
+// WARNING: This method is NOT thread-safe. It should only be called before the scheduler is started.
+func (s *Scheduler) SetJobDoneHandler(handler JobDoneHandler) {
+	s.onDone = handler
+}
+
 // Start starts the scheduler
 func (s *Scheduler) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -271,6 +286,8 @@ func (s *Scheduler) processJob(ctx context.Context, job *Job, workerID int) {
 			s.logger.Warn("Failed to re-queue job for retry",
 				zap.String("job_id", job.ID.String()),
 			)
+			job.Fail(ErrJobQueueFull.Error())
+			s.jobDone(job)
 		}
 		return
 	}
@@ -282,8 +299,8 @@ func (s *Scheduler) processJob(ctx context.Context, job *Job, workerID int) {
 		zap.String("report_type", string(job.ReportType)),
 	)
 
-	// Create context with timeout
-	jobCtx, cancel := context.WithTimeout(ctx, s.config.JobTimeout)
+	// Create context with timeout, scoped to the job's tenant
+	jobCtx, cancel := context.WithTimeout(jobTenantContext(ctx, job), s.config.JobTimeout)
 	defer cancel()
 
 	// Execute the job
@@ -297,8 +314,8 @@ func (s *Scheduler) processJob(ctx context.Context, job *Job, workerID int) {
 			zap.Error(err),
 		)
 
-		// Check if should retry
-		if job.ShouldRetry() {
+		// Check if should retry; jobs are not retried once the scheduler is stopping
+		if job.ShouldRetry() && ctx.Err() == nil {
 			job.ScheduleRetry(s.config.RetryDelay)
 			s.logger.Info("Job scheduled for retry",
 				zap.String("job_id", job.ID.String()),
@@ -312,8 +329,12 @@ func (s *Scheduler) processJob(ctx context.Context, job *Job, workerID int) {
 				s.logger.Warn("Failed to re-queue job for retry",
 					zap.String("job_id", job.ID.String()),
 				)
+				job.Fail(ErrJobQueueFull.Error())
+				s.jobDone(job)
 			}
+			return
 		}
+		s.jobDone(job)
 		return
 	}
 
@@ -323,6 +344,14 @@ func (s *Scheduler) processJob(ctx context.Context, job *Job, workerID int) {
 		zap.String("job_id", job.ID.String()),
 		zap.String("report_type", string(job.ReportType)),
 	)
+	s.jobDone(job)
+}
+
+// jobDone notifies the job done handler, if any, that a job has finished
+func (s *Scheduler) jobDone(job *Job) {
+	if s.onDone != nil {
+		s.onDone(job)
+	}
 }
 
 // ScheduleDailyReports schedules all report types for a tenant
@@ -349,3 +378,12 @@ func (s *Scheduler) ScheduleReport(tenantID *uuid.UUID, reportType ReportType, p
 	job := NewJob(tenantID, reportType, periodStart, periodEnd, s.config.RetryAttempts)
 	return s.SubmitJob(job)
 }
+
+// jobTenantContext scopes the queries of a job to its tenant; a job over all
+// tenants queries across them
+func jobTenantContext(ctx context.Context, job *Job) context.Context {
+	if job.TenantID == nil {
+		return tenant.WithCrossTenant(ctx)
+	}
+	return tenant.WithTenant(ctx, *job.TenantID)
+}
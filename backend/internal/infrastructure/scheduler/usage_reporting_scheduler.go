@@ -34,6 +34,12 @@ type UsageReportingSchedulerConfig struct {
 	// DailyReportingHour is the hour (0-23) when daily reporting runs
 	DailyReportingHour int
 
+	// PeriodEndReportingEnabled enables reporting usage for billing periods that have ended
+	PeriodEndReportingEnabled bool
+
+	// PeriodEndCheckInterval is how often to look for billing periods that have ended
+	PeriodEndCheckInterval time.Duration
+
 	// RetryInterval is how often to retry failed reports
 	RetryInterval time.Duration
 
@@ -50,6 +56,10 @@ func DefaultUsageReportingSchedulerConfig() UsageReportingSchedulerConfig {
 		DailyReportingHour:     2, // 2 AM
 		RetryInterval:          15 * time.Minute,
 		ReportingTimeout:       30 * time.Minute,
+
+		// Stripe accepts usage for a closed period only until its invoice is finalized
+		PeriodEndReportingEnabled: true,
+		PeriodEndCheckInterval:    10 * time.Minute,
 	}
 }
 
@@ -96,6 +106,12 @@ func (s *UsageReportingScheduler) Start(ctx context.Context) error {
 		go s.runDailyReporting(ctx)
 	}
 
+	// Start period-end reporting goroutine
+	if s.config.PeriodEndReportingEnabled {
+		s.wg.Add(1)
+		go s.runPeriodEndReporting(ctx)
+	}
+
 	// Start retry goroutine
 	s.wg.Add(1)
 	go s.runRetryLoop(ctx)
@@ -104,6 +120,7 @@ func (s *UsageReportingScheduler) Start(ctx context.Context) error {
 		zap.Bool("hourly_enabled", s.config.HourlyReportingEnabled),
 		zap.Bool("daily_enabled", s.config.DailyReportingEnabled),
 		zap.Int("daily_hour", s.config.DailyReportingHour),
+		zap.Bool("period_end_enabled", s.config.PeriodEndReportingEnabled),
 	)
 
 	return nil
@@ -208,6 +225,24 @@ func (s *UsageReportingScheduler) runDailyReporting(ctx context.Context) {
 	}
 }
 
+// runPeriodEndReporting periodically reports usage for billing periods that have ended
+func (s *UsageReportingScheduler) runPeriodEndReporting(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.PeriodEndCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Debug("Period-end reporting loop stopping")
+			return
+		case <-ticker.C:
+			s.executePeriodEndReporting(ctx)
+		}
+	}
+}
+
 // runRetryLoop periodically retries failed usage reports
 func (s *UsageReportingScheduler) runRetryLoop(ctx context.Context) {
 	defer s.wg.Done()
@@ -256,6 +291,28 @@ func (s *UsageReportingScheduler) executeReporting(ctx context.Context, reportTy
 	)
 }
 
+// executePeriodEndReporting reports usage for billing periods that have ended
+func (s *UsageReportingScheduler) executePeriodEndReporting(ctx context.Context) {
+	reportCtx, cancel := context.WithTimeout(ctx, s.config.ReportingTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	err := s.service.ReportPeriodEndUsage(reportCtx, startTime)
+	duration := time.Since(startTime)
+
+	if err != nil {
+		s.logger.Error("Period-end usage reporting failed",
+			zap.Duration("duration", duration),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Debug("Period-end usage reporting completed",
+		zap.Duration("duration", duration),
+	)
+}
+
 // executeRetry retries failed usage reports
 func (s *UsageReportingScheduler) executeRetry(ctx context.Context) {
 	s.logger.Debug("Starting retry of failed usage reports")
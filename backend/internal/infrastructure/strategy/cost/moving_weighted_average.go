@@ -0,0 +1,128 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/shopspring/decimal"
+)
+
+// MovingWeightedAverageCostStrategy implements the moving weighted average cost method.
+// Entries are replayed in date order: a positive quantity is a receipt and recomputes the unit cost as
+//
+//	newAvg = (oldQty*oldAvg + receiptQty*receiptCost) / (oldQty + receiptQty)
+//
+// while a negative quantity is an issue, costed at the average in effect, which it leaves unchanged.
+// With nothing on hand, or a negative balance after issuing more than was received, a receipt
+// restarts the average at its own cost. The average is rounded to 4 decimal places on every receipt,
+// like the unit cost of an inventory item.
+type MovingWeightedAverageCostStrategy struct {
+	strategy.BaseStrategy
+}
+
+// NewMovingWeightedAverageCostStrategy creates a new moving weighted average cost strategy
+func NewMovingWeightedAverageCostStrategy() *MovingWeightedAverageCostStrategy {
+	return &MovingWeightedAverageCostStrategy{
+		BaseStrategy: strategy.NewBaseStrategy(
+			"moving_weighted_average",
+			strategy.StrategyTypeCost,
+			"Moving weighted average cost, recomputed on every receipt",
+		),
+	}
+}
+
+// Method returns the costing method
+func (s *MovingWeightedAverageCostStrategy) Method() strategy.CostMethod {
+	return strategy.CostMethodMovingWeightedAverage
+}
+
+// CalculateCost costs an issue of costCtx.Quantity at the running average after the entries.
+// Quantity beyond the stock on hand is still costed at the average and reported as RemainingQty.
+func (s *MovingWeightedAverageCostStrategy) CalculateCost(
+	ctx context.Context,
+	costCtx strategy.CostContext,
+	entries []strategy.StockEntry,
+) (strategy.CostResult, error) {
+	if !costCtx.Quantity.IsPositive() {
+		return strategy.CostResult{}, errors.New("quantity must be positive")
+	}
+
+	onHand, avgCost, err := s.replay(entries)
+	if err != nil {
+		return strategy.CostResult{}, err
+	}
+
+	remainingQty := decimal.Zero
+	if costCtx.Quantity.GreaterThan(onHand) {
+		remainingQty = costCtx.Quantity.Sub(decimal.Max(onHand, decimal.Zero))
+	}
+
+	return strategy.CostResult{
+		UnitCost:     avgCost,
+		TotalCost:    avgCost.Mul(costCtx.Quantity),
+		Method:       strategy.CostMethodMovingWeightedAverage,
+		EntriesUsed:  entries,
+		RemainingQty: remainingQty,
+	}, nil
+}
+
+// CalculateAverageCost returns the running average after replaying the entries
+func (s *MovingWeightedAverageCostStrategy) CalculateAverageCost(
+	ctx context.Context,
+	entries []strategy.StockEntry,
+) (decimal.Decimal, error) {
+	_, avgCost, err := s.replay(entries)
+	return avgCost, err
+}
+
+// replay applies the entries in date order and returns the quantity on hand and the running average
+func (s *MovingWeightedAverageCostStrategy) replay(entries []strategy.StockEntry) (decimal.Decimal, decimal.Decimal, error) {
+	if len(entries) == 0 {
+		return decimal.Zero, decimal.Zero, errors.New("no stock entries provided")
+	}
+
+	sortedEntries := make([]strategy.StockEntry, len(entries))
+	copy(sortedEntries, entries)
+	sort.SliceStable(sortedEntries, func(i, j int) bool {
+		return sortedEntries[i].EntryDate.Before(sortedEntries[j].EntryDate)
+	})
+
+	quantity := decimal.Zero
+	avgCost := decimal.Zero
+	received := false
+
+	for _, entry := range sortedEntries {
+		if entry.Quantity.IsNegative() {
+			quantity = quantity.Add(entry.Quantity)
+			continue
+		}
+		if entry.Quantity.IsZero() {
+			continue
+		}
+
+		receiptCost := entryUnitCost(entry)
+		if quantity.IsPositive() {
+			totalValue := quantity.Mul(avgCost).Add(entry.Quantity.Mul(receiptCost))
+			avgCost = totalValue.Div(quantity.Add(entry.Quantity)).Round(4)
+		} else {
+			avgCost = receiptCost.Round(4)
+		}
+		quantity = quantity.Add(entry.Quantity)
+		received = true
+	}
+
+	if !received {
+		return decimal.Zero, decimal.Zero, errors.New("no receipts to average")
+	}
+	return quantity, avgCost, nil
+}
+
+// entryUnitCost returns the unit cost of a receipt, deriving it from the total cost when not set
+func entryUnitCost(entry strategy.StockEntry) decimal.Decimal {
+	if entry.UnitCost.IsZero() && !entry.TotalCost.IsZero() {
+		return entry.TotalCost.Div(entry.Quantity)
+	}
+	return entry.UnitCost
+}
@@ -0,0 +1,209 @@
+package cost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mwaEntry(id string, qty int64, unitCost float64, date time.Time) strategy.StockEntry {
+	quantity := decimal.NewFromInt(qty)
+	cost := decimal.NewFromFloat(unitCost)
+	return strategy.StockEntry{
+		ID:        id,
+		Quantity:  quantity,
+		UnitCost:  cost,
+		TotalCost: quantity.Abs().Mul(cost),
+		EntryDate: date,
+	}
+}
+
+func TestNewMovingWeightedAverageCostStrategy(t *testing.T) {
+	s := NewMovingWeightedAverageCostStrategy()
+
+	assert.NotNil(t, s)
+	assert.Equal(t, "moving_weighted_average", s.Name())
+	assert.Equal(t, strategy.StrategyTypeCost, s.Type())
+	assert.Equal(t, strategy.CostMethodMovingWeightedAverage, s.Method())
+	assert.NotEmpty(t, s.Description())
+}
+
+func TestMovingWeightedAverageCostStrategy_CalculateAverageCost(t *testing.T) {
+	s := NewMovingWeightedAverageCostStrategy()
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		entries     []strategy.StockEntry
+		expected    decimal.Decimal
+		expectError bool
+	}{
+		{
+			name:        "empty entries",
+			entries:     []strategy.StockEntry{},
+			expectError: true,
+		},
+		{
+			name: "only issues",
+			entries: []strategy.StockEntry{
+				mwaEntry("1", -10, 5, base),
+			},
+			expectError: true,
+		},
+		{
+			name: "first receipt sets the average",
+			entries: []strategy.StockEntry{
+				mwaEntry("1", 100, 10, base),
+			},
+			expected: decimal.NewFromInt(10),
+		},
+		{
+			name: "sequential receipts at different costs",
+			entries: []strategy.StockEntry{
+				mwaEntry("1", 100, 10, base),
+				mwaEntry("2", 50, 13, base.Add(24*time.Hour)),
+				mwaEntry("3", 50, 20, base.Add(48*time.Hour)),
+			},
+			// (100*10 + 50*13) / 150 = 11, then (150*11 + 50*20) / 200 = 13.25
+			expected: decimal.NewFromFloat(13.25),
+		},
+		{
+			name: "entries out of order are replayed by date",
+			entries: []strategy.StockEntry{
+				mwaEntry("3", 50, 20, base.Add(48*time.Hour)),
+				mwaEntry("1", 100, 10, base),
+				mwaEntry("2", 50, 13, base.Add(24*time.Hour)),
+			},
+			expected: decimal.NewFromFloat(13.25),
+		},
+		{
+			name: "issue leaves the average unchanged",
+			entries: []strategy.StockEntry{
+				mwaEntry("1", 100, 10, base),
+				mwaEntry("2", 50, 13, base.Add(24*time.Hour)),
+				mwaEntry("3", -90, 0, base.Add(48*time.Hour)),
+				mwaEntry("4", 60, 14, base.Add(72*time.Hour)),
+			},
+			// 60 left at 11, then (60*11 + 60*14) / 120 = 12.5
+			expected: decimal.NewFromFloat(12.5),
+		},
+		{
+			name: "zero prior quantity restarts the average",
+			entries: []strategy.StockEntry{
+				mwaEntry("1", 10, 5, base),
+				mwaEntry("2", -10, 0, base.Add(24*time.Hour)),
+				mwaEntry("3", 10, 9, base.Add(48*time.Hour)),
+			},
+			expected: decimal.NewFromInt(9),
+		},
+		{
+			name: "negative prior quantity restarts the average",
+			entries: []strategy.StockEntry{
+				mwaEntry("1", 10, 5, base),
+				mwaEntry("2", -15, 0, base.Add(24*time.Hour)),
+				mwaEntry("3", 20, 8, base.Add(48*time.Hour)),
+			},
+			expected: decimal.NewFromInt(8),
+		},
+		{
+			name: "unit cost derived from total cost",
+			entries: []strategy.StockEntry{
+				{ID: "1", Quantity: decimal.NewFromInt(4), TotalCost: decimal.NewFromInt(10), EntryDate: base},
+			},
+			expected: decimal.NewFromFloat(2.5),
+		},
+		{
+			name: "average rounded to 4 decimal places",
+			entries: []strategy.StockEntry{
+				mwaEntry("1", 1, 1, base),
+				mwaEntry("2", 2, 2, base.Add(time.Hour)),
+			},
+			// 5 / 3
+			expected: decimal.NewFromFloat(1.6667),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.CalculateAverageCost(ctx, tt.entries)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result), "expected %s, got %s", tt.expected, result)
+		})
+	}
+}
+
+func TestMovingWeightedAverageCostStrategy_CalculateCost(t *testing.T) {
+	s := NewMovingWeightedAverageCostStrategy()
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	receipts := []strategy.StockEntry{
+		mwaEntry("1", 100, 10, base),
+		mwaEntry("2", 50, 13, base.Add(24*time.Hour)),
+		mwaEntry("3", 50, 20, base.Add(48*time.Hour)),
+	}
+
+	t.Run("deduction uses the running average", func(t *testing.T) {
+		result, err := s.CalculateCost(ctx, strategy.CostContext{Quantity: decimal.NewFromInt(80)}, receipts)
+		require.NoError(t, err)
+
+		assert.True(t, decimal.NewFromFloat(13.25).Equal(result.UnitCost))
+		assert.True(t, decimal.NewFromInt(1060).Equal(result.TotalCost))
+		assert.Equal(t, strategy.CostMethodMovingWeightedAverage, result.Method)
+		assert.True(t, result.RemainingQty.IsZero())
+	})
+
+	t.Run("receipt after a deduction averages with what is left", func(t *testing.T) {
+		entries := append(append([]strategy.StockEntry{}, receipts...),
+			mwaEntry("4", -80, 13.25, base.Add(72*time.Hour)),
+			mwaEntry("5", 30, 16, base.Add(96*time.Hour)),
+		)
+		result, err := s.CalculateCost(ctx, strategy.CostContext{Quantity: decimal.NewFromInt(10)}, entries)
+		require.NoError(t, err)
+
+		// (120*13.25 + 30*16) / 150 = 13.8
+		assert.True(t, decimal.NewFromFloat(13.8).Equal(result.UnitCost))
+		assert.True(t, decimal.NewFromInt(138).Equal(result.TotalCost))
+	})
+
+	t.Run("deduction beyond stock on hand", func(t *testing.T) {
+		entries := []strategy.StockEntry{mwaEntry("1", 10, 5, base)}
+		result, err := s.CalculateCost(ctx, strategy.CostContext{Quantity: decimal.NewFromInt(15)}, entries)
+		require.NoError(t, err)
+
+		assert.True(t, decimal.NewFromInt(75).Equal(result.TotalCost))
+		assert.True(t, decimal.NewFromInt(5).Equal(result.RemainingQty))
+	})
+
+	t.Run("deduction from negative stock", func(t *testing.T) {
+		entries := []strategy.StockEntry{
+			mwaEntry("1", 10, 5, base),
+			mwaEntry("2", -12, 5, base.Add(time.Hour)),
+		}
+		result, err := s.CalculateCost(ctx, strategy.CostContext{Quantity: decimal.NewFromInt(3)}, entries)
+		require.NoError(t, err)
+
+		assert.True(t, decimal.NewFromInt(15).Equal(result.TotalCost))
+		assert.True(t, decimal.NewFromInt(3).Equal(result.RemainingQty))
+	})
+
+	t.Run("non-positive quantity", func(t *testing.T) {
+		_, err := s.CalculateCost(ctx, strategy.CostContext{Quantity: decimal.Zero}, receipts)
+		assert.Error(t, err)
+	})
+
+	t.Run("no entries", func(t *testing.T) {
+		_, err := s.CalculateCost(ctx, strategy.CostContext{Quantity: decimal.NewFromInt(1)}, nil)
+		assert.Error(t, err)
+	})
+}
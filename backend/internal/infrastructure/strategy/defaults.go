@@ -1,6 +1,8 @@
 package strategy
 
 import (
+	"fmt"
+
 	"github.com/erp/backend/internal/domain/shared/strategy"
 	"github.com/erp/backend/internal/infrastructure/strategy/allocation"
 	"github.com/erp/backend/internal/infrastructure/strategy/batch"
@@ -28,6 +30,11 @@ func NewRegistryWithProvider(customerLevelProvider pricing.CustomerLevelProvider
 		return nil, err
 	}
 
+	movingWeightedAvg := cost.NewMovingWeightedAverageCostStrategy()
+	if err := r.RegisterCostStrategy(movingWeightedAvg); err != nil {
+		return nil, err
+	}
+
 	fifoCost := cost.NewFIFOCostStrategy()
 	if err := r.RegisterCostStrategy(fifoCost); err != nil {
 		return nil, err
@@ -97,3 +104,18 @@ func NewRegistryWithProvider(customerLevelProvider pricing.CustomerLevelProvider
 
 	return r, nil
 }
+
+// SetPriceTierProvider lets the registered "tiered" pricing strategy price by the quantity breaks
+// defined on each product. It should only be called during setup, before the registry is used concurrently.
+func (r *StrategyRegistry) SetPriceTierProvider(provider pricing.PriceTierProvider) error {
+	s, err := r.GetPricingStrategy("tiered")
+	if err != nil {
+		return err
+	}
+	tiered, ok := s.(*pricing.TieredPricingStrategy)
+	if !ok {
+		return fmt.Errorf("pricing strategy '%s' is not a tiered pricing strategy", s.Name())
+	}
+	tiered.SetProvider(provider)
+	return nil
+}
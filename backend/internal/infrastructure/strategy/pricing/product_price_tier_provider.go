@@ -0,0 +1,41 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/catalog"
+	"github.com/google/uuid"
+)
+
+// ProductPriceTierProvider implements PriceTierProvider using the catalog ProductReader.
+// This provider allows the TieredPricingStrategy to price by the quantity breaks
+// defined on each product.
+type ProductPriceTierProvider struct {
+	products catalog.ProductReader
+}
+
+// NewProductPriceTierProvider creates a new ProductPriceTierProvider.
+func NewProductPriceTierProvider(products catalog.ProductReader) *ProductPriceTierProvider {
+	return &ProductPriceTierProvider{products: products}
+}
+
+// GetProductPriceTiers returns the price tiers and selling price of a product for a tenant.
+// Returns an error if the product is not found.
+func (p *ProductPriceTierProvider) GetProductPriceTiers(
+	ctx context.Context,
+	tenantID, productID uuid.UUID,
+) (ProductPriceTiers, error) {
+	product, err := p.products.FindByIDForTenant(ctx, tenantID, productID)
+	if err != nil {
+		return ProductPriceTiers{}, err
+	}
+
+	tiers := make([]PriceTier, 0, len(product.PriceTiers))
+	for _, tier := range product.PriceTiers {
+		tiers = append(tiers, PriceTier{MinQuantity: tier.MinQuantity, UnitPrice: tier.UnitPrice})
+	}
+	return ProductPriceTiers{SellingPrice: product.SellingPrice, Tiers: tiers}, nil
+}
+
+// Ensure ProductPriceTierProvider implements PriceTierProvider
+var _ PriceTierProvider = (*ProductPriceTierProvider)(nil)
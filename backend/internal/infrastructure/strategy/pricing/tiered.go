@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
@@ -14,11 +15,32 @@ type PriceTier struct {
 	UnitPrice   decimal.Decimal `json:"unit_price"`
 }
 
+// ProductPriceTiers holds the quantity breaks of a product and its selling price
+type ProductPriceTiers struct {
+	SellingPrice decimal.Decimal
+	Tiers        []PriceTier // Sorted by min quantity ascending
+}
+
+// PriceTierProvider provides the quantity breaks of products for the tiered pricing strategy.
+// This interface allows the strategy to price by the tiers defined on each product
+// without depending on the catalog repositories.
+type PriceTierProvider interface {
+	// GetProductPriceTiers returns the price tiers and selling price of a product for a tenant.
+	// Returns no tiers if the product has none.
+	GetProductPriceTiers(ctx context.Context, tenantID, productID uuid.UUID) (ProductPriceTiers, error)
+}
+
 // TieredPricingStrategy implements quantity-based tiered pricing
 // Prices decrease as quantity increases (volume discounts)
+//
+// With a provider, products that define their own price tiers are priced by them: the unit price is
+// the price of the highest tier the quantity reaches, or the base price below the smallest tier.
+// The base price defaults to the product's selling price when the pricing context has none.
+// Products without tiers, or any lookup failure, fall back to the strategy's own tiers.
 type TieredPricingStrategy struct {
 	strategy.BaseStrategy
-	tiers []PriceTier
+	tiers    []PriceTier
+	provider PriceTierProvider
 }
 
 // NewTieredPricingStrategy creates a new tiered pricing strategy with the given tiers
@@ -41,6 +63,19 @@ func NewTieredPricingStrategy(tiers []PriceTier) *TieredPricingStrategy {
 	}
 }
 
+// SetProvider sets the provider of product price tiers.
+//
+// WARNING: This method is NOT thread-safe. It should only be called during
+// initialization/setup, before the strategy is used concurrently by HTTP handlers.
+func (s *TieredPricingStrategy) SetProvider(provider PriceTierProvider) {
+	s.provider = provider
+}
+
+// HasProvider returns true if a provider of product price tiers is configured.
+func (s *TieredPricingStrategy) HasProvider() bool {
+	return s.provider != nil
+}
+
 // GetTiers returns a copy of the pricing tiers
 func (s *TieredPricingStrategy) GetTiers() []PriceTier {
 	result := make([]PriceTier, len(s.tiers))
@@ -49,20 +84,32 @@ func (s *TieredPricingStrategy) GetTiers() []PriceTier {
 }
 
 // CalculatePrice calculates the price using quantity-based tiers
-// It finds the highest tier whose MinQuantity is <= the order quantity
+// It finds the highest tier whose MinQuantity is <= the order quantity.
+// When the product's own tiers are used, the result lists the "product_price_tiers" rule.
 func (s *TieredPricingStrategy) CalculatePrice(
 	ctx context.Context,
 	pricingCtx strategy.PricingContext,
 ) (strategy.PricingResult, error) {
-	// Default to base price if no tiers match
-	unitPrice := pricingCtx.BasePrice
+	tiers := s.tiers
+	basePrice := pricingCtx.BasePrice
 	appliedRules := []string{}
 
+	if product, ok := s.productPriceTiers(ctx, pricingCtx); ok {
+		tiers = product.Tiers
+		if !basePrice.IsPositive() {
+			basePrice = product.SellingPrice
+		}
+		appliedRules = append(appliedRules, "product_price_tiers")
+	}
+
+	// Default to base price if no tiers match
+	unitPrice := basePrice
+
 	// Find the applicable tier (highest tier where quantity >= min_quantity)
 	// Since tiers are sorted ascending, we iterate from the end
-	for i := len(s.tiers) - 1; i >= 0; i-- {
-		if pricingCtx.Quantity.GreaterThanOrEqual(s.tiers[i].MinQuantity) {
-			unitPrice = s.tiers[i].UnitPrice
+	for i := len(tiers) - 1; i >= 0; i-- {
+		if pricingCtx.Quantity.GreaterThanOrEqual(tiers[i].MinQuantity) {
+			unitPrice = tiers[i].UnitPrice
 			appliedRules = append(appliedRules, "tiered_pricing")
 			break
 		}
@@ -71,7 +118,7 @@ func (s *TieredPricingStrategy) CalculatePrice(
 	totalPrice := unitPrice.Mul(pricingCtx.Quantity)
 
 	// Calculate discount from base price
-	baseTotalPrice := pricingCtx.BasePrice.Mul(pricingCtx.Quantity)
+	baseTotalPrice := basePrice.Mul(pricingCtx.Quantity)
 	discountAmount := baseTotalPrice.Sub(totalPrice)
 	discountPercent := decimal.Zero
 	if baseTotalPrice.GreaterThan(decimal.Zero) {
@@ -88,6 +135,28 @@ func (s *TieredPricingStrategy) CalculatePrice(
 	}, nil
 }
 
+// productPriceTiers looks up the price tiers of the product being priced.
+// Returns false if there is no provider, the IDs are invalid, the lookup fails or the product has no tiers.
+func (s *TieredPricingStrategy) productPriceTiers(ctx context.Context, pricingCtx strategy.PricingContext) (ProductPriceTiers, bool) {
+	if s.provider == nil {
+		return ProductPriceTiers{}, false
+	}
+	tenantID, err := uuid.Parse(pricingCtx.TenantID)
+	if err != nil {
+		return ProductPriceTiers{}, false
+	}
+	productID, err := uuid.Parse(pricingCtx.ProductID)
+	if err != nil {
+		return ProductPriceTiers{}, false
+	}
+
+	product, err := s.provider.GetProductPriceTiers(ctx, tenantID, productID)
+	if err != nil || len(product.Tiers) == 0 {
+		return ProductPriceTiers{}, false
+	}
+	return product, true
+}
+
 // SupportsPromotion returns false as tiered pricing doesn't support promotions
 func (s *TieredPricingStrategy) SupportsPromotion() bool {
 	return false
@@ -100,9 +169,9 @@ func (s *TieredPricingStrategy) SupportsTieredPricing() bool {
 
 // DefaultTieredPricingStrategy creates a tiered strategy with NO default tiers (pass-through).
 //
-// IMPORTANT: This is a pass-through strategy that returns the base price unchanged.
-// It is registered as an available strategy type, but actual tier configuration
-// must be provided per-tenant or per-product based on business requirements.
+// IMPORTANT: Without a provider this is a pass-through strategy that returns the base price unchanged.
+// It is registered as an available strategy type; set a PriceTierProvider to price by the
+// tiers defined on each product.
 //
 // To use tiered pricing with actual discounts, use NewTieredPricingStrategy() directly:
 //
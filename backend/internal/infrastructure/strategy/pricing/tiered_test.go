@@ -2,9 +2,11 @@ package pricing
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -247,3 +249,96 @@ func TestDefaultTieredPricingStrategy_PassThrough(t *testing.T) {
 		})
 	}
 }
+
+// stubPriceTierProvider serves the price tiers of products for testing
+type stubPriceTierProvider struct {
+	products map[uuid.UUID]ProductPriceTiers
+}
+
+func (p *stubPriceTierProvider) GetProductPriceTiers(
+	ctx context.Context,
+	tenantID, productID uuid.UUID,
+) (ProductPriceTiers, error) {
+	product, ok := p.products[productID]
+	if !ok {
+		return ProductPriceTiers{}, errors.New("product not found")
+	}
+	return product, nil
+}
+
+func TestTieredPricingStrategy_ProductPriceTiers(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	tieredProductID := uuid.New()
+	plainProductID := uuid.New()
+
+	// Qty 1-9: base price, qty 10-49: 90, qty 50+: 80
+	provider := &stubPriceTierProvider{products: map[uuid.UUID]ProductPriceTiers{
+		tieredProductID: {
+			SellingPrice: decimal.NewFromInt(100),
+			Tiers: []PriceTier{
+				{MinQuantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(90)},
+				{MinQuantity: decimal.NewFromInt(50), UnitPrice: decimal.NewFromInt(80)},
+			},
+		},
+		plainProductID: {SellingPrice: decimal.NewFromInt(100)},
+	}}
+
+	s := DefaultTieredPricingStrategy()
+	assert.False(t, s.HasProvider())
+	s.SetProvider(provider)
+	assert.True(t, s.HasProvider())
+
+	price := func(productID uuid.UUID, quantity, basePrice int64) strategy.PricingResult {
+		result, err := s.CalculatePrice(ctx, strategy.PricingContext{
+			TenantID:  tenantID.String(),
+			ProductID: productID.String(),
+			Quantity:  decimal.NewFromInt(quantity),
+			BasePrice: decimal.NewFromInt(basePrice),
+			Currency:  "CNY",
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("quantity exactly on a tier boundary uses that tier", func(t *testing.T) {
+		result := price(tieredProductID, 10, 100)
+
+		assert.True(t, result.UnitPrice.Equal(decimal.NewFromInt(90)), "got %s", result.UnitPrice)
+		assert.True(t, result.TotalPrice.Equal(decimal.NewFromInt(900)))
+		assert.True(t, result.DiscountAmount.Equal(decimal.NewFromInt(100)))
+		assert.Equal(t, []string{"product_price_tiers", "tiered_pricing"}, result.AppliedRules)
+
+		result = price(tieredProductID, 50, 100)
+		assert.True(t, result.UnitPrice.Equal(decimal.NewFromInt(80)), "got %s", result.UnitPrice)
+	})
+
+	t.Run("quantity below the smallest tier uses the base price", func(t *testing.T) {
+		result := price(tieredProductID, 9, 95)
+
+		assert.True(t, result.UnitPrice.Equal(decimal.NewFromInt(95)), "got %s", result.UnitPrice)
+		assert.True(t, result.DiscountAmount.IsZero())
+		assert.Equal(t, []string{"product_price_tiers"}, result.AppliedRules)
+	})
+
+	t.Run("without a base price the selling price applies below the smallest tier", func(t *testing.T) {
+		result := price(tieredProductID, 9, 0)
+
+		assert.True(t, result.UnitPrice.Equal(decimal.NewFromInt(100)), "got %s", result.UnitPrice)
+		assert.True(t, result.TotalPrice.Equal(decimal.NewFromInt(900)))
+	})
+
+	t.Run("product without tiers passes through the base price", func(t *testing.T) {
+		result := price(plainProductID, 100, 95)
+
+		assert.True(t, result.UnitPrice.Equal(decimal.NewFromInt(95)), "got %s", result.UnitPrice)
+		assert.Empty(t, result.AppliedRules)
+	})
+
+	t.Run("unknown product passes through the base price", func(t *testing.T) {
+		result := price(uuid.New(), 100, 95)
+
+		assert.True(t, result.UnitPrice.Equal(decimal.NewFromInt(95)), "got %s", result.UnitPrice)
+		assert.Empty(t, result.AppliedRules)
+	})
+}
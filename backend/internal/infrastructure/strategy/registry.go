@@ -18,6 +18,7 @@ type StrategyRegistry struct {
 	batchStrategies      map[string]strategy.BatchManagementStrategy
 	validationStrategies map[string]strategy.ProductValidationStrategy
 	defaults             map[strategy.StrategyType]string
+	tenantConfigs        strategy.TenantStrategyConfigRepository
 }
 
 // NewStrategyRegistry creates a new strategy registry
@@ -7,6 +7,7 @@ import (
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/erp/backend/internal/infrastructure/strategy/pricing"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -538,6 +539,7 @@ func TestNewRegistryWithDefaults(t *testing.T) {
 	costList := r.ListCostStrategies()
 	assert.Contains(t, costList, "moving_average")
 	assert.Contains(t, costList, "fifo")
+	assert.Contains(t, costList, "moving_weighted_average")
 	assert.True(t, r.HasDefault(strategy.StrategyTypeCost))
 	assert.Equal(t, "moving_average", r.GetDefault(strategy.StrategyTypeCost))
 
@@ -571,6 +573,22 @@ func TestNewRegistryWithDefaults(t *testing.T) {
 	assert.Equal(t, "standard", pricingStrategy.Name())
 }
 
+func TestSetPriceTierProvider(t *testing.T) {
+	r, err := NewRegistryWithDefaults()
+	require.NoError(t, err)
+
+	require.NoError(t, r.SetPriceTierProvider(pricing.NewProductPriceTierProvider(nil)))
+
+	tiered, err := r.GetPricingStrategy("tiered")
+	require.NoError(t, err)
+	assert.True(t, tiered.(*pricing.TieredPricingStrategy).HasProvider())
+
+	t.Run("fails without a tiered strategy", func(t *testing.T) {
+		require.NoError(t, r.UnregisterPricingStrategy("tiered"))
+		assert.Error(t, r.SetPriceTierProvider(pricing.NewProductPriceTierProvider(nil)))
+	})
+}
+
 func TestGetPricingStrategyOrDefault(t *testing.T) {
 	r := NewStrategyRegistry()
 	defaultS := newMockPricingStrategy("default_pricing")
@@ -0,0 +1,75 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+)
+
+// SetTenantConfigRepository sets the repository of tenant strategy configurations (optional).
+// Without it every tenant resolves to the global defaults.
+func (r *StrategyRegistry) SetTenantConfigRepository(repo strategy.TenantStrategyConfigRepository) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tenantConfigs = repo
+}
+
+// tenantConfig returns the strategy configuration of a tenant, or nil if it has none or it cannot be read
+func (r *StrategyRegistry) tenantConfig(ctx context.Context, tenantID uuid.UUID) *strategy.TenantStrategyConfig {
+	r.mu.RLock()
+	repo := r.tenantConfigs
+	r.mu.RUnlock()
+
+	if repo == nil {
+		return nil
+	}
+	config, err := repo.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil
+	}
+	return config
+}
+
+// TenantStrategyName returns the strategy of a type the tenant chose.
+// Returns false if the tenant has not chosen one, or chose one that is no longer registered.
+func (r *StrategyRegistry) TenantStrategyName(ctx context.Context, tenantID uuid.UUID, strategyType strategy.StrategyType) (string, bool) {
+	name := r.tenantConfig(ctx, tenantID).StrategyName(strategyType)
+	if name == "" || !r.IsRegistered(strategyType, name) {
+		return "", false
+	}
+	return name, true
+}
+
+// ResolveStrategyName returns the strategy of a type the tenant chose, or the global default
+func (r *StrategyRegistry) ResolveStrategyName(ctx context.Context, tenantID uuid.UUID, strategyType strategy.StrategyType) string {
+	if name, ok := r.TenantStrategyName(ctx, tenantID, strategyType); ok {
+		return name
+	}
+	return r.GetDefault(strategyType)
+}
+
+// TenantReconciliationStrategy returns the finance reconciliation strategy type the tenant chose,
+// or empty if it has not chosen one
+func (r *StrategyRegistry) TenantReconciliationStrategy(ctx context.Context, tenantID uuid.UUID) string {
+	config := r.tenantConfig(ctx, tenantID)
+	if config == nil {
+		return ""
+	}
+	return config.ReconciliationStrategy
+}
+
+// GetCostStrategyForTenant returns the cost strategy the tenant chose, or the default
+func (r *StrategyRegistry) GetCostStrategyForTenant(ctx context.Context, tenantID uuid.UUID) (strategy.CostCalculationStrategy, error) {
+	return r.GetCostStrategy(r.ResolveStrategyName(ctx, tenantID, strategy.StrategyTypeCost))
+}
+
+// GetPricingStrategyForTenant returns the pricing strategy the tenant chose, or the default
+func (r *StrategyRegistry) GetPricingStrategyForTenant(ctx context.Context, tenantID uuid.UUID) (strategy.PricingStrategy, error) {
+	return r.GetPricingStrategy(r.ResolveStrategyName(ctx, tenantID, strategy.StrategyTypePricing))
+}
+
+// GetAllocationStrategyForTenant returns the allocation strategy the tenant chose, or the default
+func (r *StrategyRegistry) GetAllocationStrategyForTenant(ctx context.Context, tenantID uuid.UUID) (strategy.PaymentAllocationStrategy, error) {
+	return r.GetAllocationStrategy(r.ResolveStrategyName(ctx, tenantID, strategy.StrategyTypeAllocation))
+}
@@ -0,0 +1,130 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/strategy"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTenantConfigRepository keeps tenant strategy configurations in memory
+type stubTenantConfigRepository struct {
+	configs map[uuid.UUID]*strategy.TenantStrategyConfig
+	err     error
+}
+
+func (r *stubTenantConfigRepository) FindByTenantID(_ context.Context, tenantID uuid.UUID) (*strategy.TenantStrategyConfig, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	config, ok := r.configs[tenantID]
+	if !ok {
+		return nil, shared.ErrNotFound
+	}
+	return config, nil
+}
+
+func (r *stubTenantConfigRepository) Save(_ context.Context, config *strategy.TenantStrategyConfig) error {
+	r.configs[config.TenantID] = config
+	return nil
+}
+
+func TestStrategyRegistry_TenantStrategies(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewRegistryWithDefaults()
+	require.NoError(t, err)
+
+	fifoTenantID := uuid.New()
+	defaultTenantID := uuid.New()
+	staleTenantID := uuid.New()
+	repo := &stubTenantConfigRepository{configs: map[uuid.UUID]*strategy.TenantStrategyConfig{
+		fifoTenantID: {
+			TenantID:               fifoTenantID,
+			CostStrategy:           "fifo",
+			ReconciliationStrategy: "LIFO",
+		},
+		staleTenantID: {
+			TenantID:     staleTenantID,
+			CostStrategy: "removed_strategy",
+		},
+	}}
+	r.SetTenantConfigRepository(repo)
+
+	t.Run("tenants with different strategies cost the same issue differently", func(t *testing.T) {
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		entries := []strategy.StockEntry{
+			{ID: "1", Quantity: decimal.NewFromInt(10), UnitCost: decimal.NewFromInt(10), TotalCost: decimal.NewFromInt(100), EntryDate: base},
+			{ID: "2", Quantity: decimal.NewFromInt(10), UnitCost: decimal.NewFromInt(20), TotalCost: decimal.NewFromInt(200), EntryDate: base.Add(time.Hour)},
+		}
+		costCtx := strategy.CostContext{Quantity: decimal.NewFromInt(10)}
+
+		fifoStrategy, err := r.GetCostStrategyForTenant(ctx, fifoTenantID)
+		require.NoError(t, err)
+		defaultStrategy, err := r.GetCostStrategyForTenant(ctx, defaultTenantID)
+		require.NoError(t, err)
+		assert.Equal(t, "fifo", fifoStrategy.Name())
+		assert.Equal(t, "moving_average", defaultStrategy.Name())
+
+		fifoResult, err := fifoStrategy.CalculateCost(ctx, costCtx, entries)
+		require.NoError(t, err)
+		defaultResult, err := defaultStrategy.CalculateCost(ctx, costCtx, entries)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(100).Equal(fifoResult.TotalCost), "got %s", fifoResult.TotalCost)
+		assert.True(t, decimal.NewFromInt(150).Equal(defaultResult.TotalCost), "got %s", defaultResult.TotalCost)
+	})
+
+	t.Run("tenant strategy name", func(t *testing.T) {
+		name, ok := r.TenantStrategyName(ctx, fifoTenantID, strategy.StrategyTypeCost)
+		assert.True(t, ok)
+		assert.Equal(t, "fifo", name)
+
+		_, ok = r.TenantStrategyName(ctx, fifoTenantID, strategy.StrategyTypePricing)
+		assert.False(t, ok, "the tenant has not chosen a pricing strategy")
+		_, ok = r.TenantStrategyName(ctx, defaultTenantID, strategy.StrategyTypeCost)
+		assert.False(t, ok, "the tenant has no strategy configuration")
+	})
+
+	t.Run("unregistered choice falls back to the default", func(t *testing.T) {
+		_, ok := r.TenantStrategyName(ctx, staleTenantID, strategy.StrategyTypeCost)
+		assert.False(t, ok)
+		assert.Equal(t, "moving_average", r.ResolveStrategyName(ctx, staleTenantID, strategy.StrategyTypeCost))
+	})
+
+	t.Run("pricing and allocation fall back to the defaults", func(t *testing.T) {
+		pricing, err := r.GetPricingStrategyForTenant(ctx, fifoTenantID)
+		require.NoError(t, err)
+		assert.Equal(t, r.GetDefault(strategy.StrategyTypePricing), pricing.Name())
+
+		allocation, err := r.GetAllocationStrategyForTenant(ctx, fifoTenantID)
+		require.NoError(t, err)
+		assert.Equal(t, r.GetDefault(strategy.StrategyTypeAllocation), allocation.Name())
+	})
+
+	t.Run("reconciliation strategy", func(t *testing.T) {
+		assert.Equal(t, "LIFO", r.TenantReconciliationStrategy(ctx, fifoTenantID))
+		assert.Empty(t, r.TenantReconciliationStrategy(ctx, defaultTenantID))
+	})
+
+	t.Run("repository errors fall back to the defaults", func(t *testing.T) {
+		failing, err := NewRegistryWithDefaults()
+		require.NoError(t, err)
+		failing.SetTenantConfigRepository(&stubTenantConfigRepository{err: errors.New("connection refused")})
+
+		assert.Equal(t, "moving_average", failing.ResolveStrategyName(ctx, fifoTenantID, strategy.StrategyTypeCost))
+		assert.Empty(t, failing.TenantReconciliationStrategy(ctx, fifoTenantID))
+	})
+
+	t.Run("without a repository every tenant uses the defaults", func(t *testing.T) {
+		plain, err := NewRegistryWithDefaults()
+		require.NoError(t, err)
+
+		assert.Equal(t, "moving_average", plain.ResolveStrategyName(ctx, fifoTenantID, strategy.StrategyTypeCost))
+	})
+}
@@ -14,7 +14,7 @@ import (
 )
 
 // BusinessMetrics provides business metrics for the ERP system.
-// It tracks order creation, payment activity, and inventory health.
+// It tracks order creation, payment activity, reconciliation, and inventory health.
 type BusinessMetrics struct {
 	meter  metric.Meter
 	logger *zap.Logger
@@ -24,6 +24,13 @@ type BusinessMetrics struct {
 	orderAmountTotal  *Counter
 	paymentTotal      *Counter
 
+	// Reconciliation and inventory activity counters
+	paymentReconciledTotal   *Counter
+	inventoryAdjustmentTotal *Counter
+
+	// Histogram metrics (distributions)
+	orderValue *Histogram
+
 	// Gauge metrics (point-in-time values)
 	inventoryLockedQuantity *Gauge
 	inventoryLowStockCount  *Gauge
@@ -109,7 +116,38 @@ func NewBusinessMetrics(cfg BusinessMetricsConfig) (*BusinessMetrics, error) {
 		return nil, err
 	}
 
-	// Inventory gauge metrics
+	bm.paymentReconciledTotal, err = NewCounter(
+		cfg.Meter,
+		"erp_payment_reconciled_total",
+		"Total number of vouchers reconciled against receivables or payables",
+		"{vouchers}",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order value distribution, in currency units
+	bm.orderValue, err = NewHistogram(cfg.Meter, HistogramOpts{
+		Name:        "erp_order_value",
+		Description: "Distribution of order total amounts",
+		Unit:        "{yuan}",
+		Boundaries:  []float64{100, 500, 1000, 5000, 10000, 50000, 100000, 500000, 1000000},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Inventory metrics
+	bm.inventoryAdjustmentTotal, err = NewCounter(
+		cfg.Meter,
+		"erp_inventory_adjustment_total",
+		"Total number of inventory adjustments that changed stock",
+		"{adjustments}",
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	bm.inventoryLockedQuantity, err = NewGauge(
 		cfg.Meter,
 		"erp_inventory_locked_quantity",
@@ -163,13 +201,22 @@ func (bm *BusinessMetrics) RecordOrderAmount(ctx context.Context, tenantID uuid.
 	)
 }
 
-// RecordOrderWithAmount is a convenience method that records both order count and amount.
+// RecordOrderValue records an order total in the order value histogram.
+func (bm *BusinessMetrics) RecordOrderValue(ctx context.Context, tenantID uuid.UUID, orderType OrderType, amount decimal.Decimal) {
+	bm.orderValue.Record(ctx, amount.InexactFloat64(),
+		AttrTenantID.String(tenantID.String()),
+		AttrOrderType.String(string(orderType)),
+	)
+}
+
+// RecordOrderWithAmount is a convenience method that records the order count, amount and value.
 func (bm *BusinessMetrics) RecordOrderWithAmount(ctx context.Context, tenantID uuid.UUID, orderType OrderType, amount decimal.Decimal) {
 	bm.RecordOrderCreated(ctx, tenantID, orderType)
 
 	// Convert to fen (multiply by 100)
 	amountFen := amount.Mul(decimal.NewFromInt(100)).IntPart()
 	bm.RecordOrderAmount(ctx, tenantID, orderType, amountFen)
+	bm.RecordOrderValue(ctx, tenantID, orderType, amount)
 }
 
 // =============================================================================
@@ -194,10 +241,46 @@ func (bm *BusinessMetrics) RecordPayment(ctx context.Context, tenantID uuid.UUID
 	)
 }
 
+// ReconcileDirection tells receipt reconciliation (receivables) from payment reconciliation (payables).
+type ReconcileDirection string
+
+const (
+	ReconcileDirectionReceipt ReconcileDirection = "receipt"
+	ReconcileDirectionPayment ReconcileDirection = "payment"
+)
+
+// RecordPaymentReconciled records a voucher reconciled against outstanding receivables or payables.
+// This should be called once the reconciliation has been saved.
+func (bm *BusinessMetrics) RecordPaymentReconciled(ctx context.Context, tenantID uuid.UUID, direction ReconcileDirection, fullyReconciled bool) {
+	bm.paymentReconciledTotal.Inc(ctx,
+		AttrTenantID.String(tenantID.String()),
+		AttrReconcileDirection.String(string(direction)),
+		AttrFullyReconciled.Bool(fullyReconciled),
+	)
+}
+
 // =============================================================================
 // Inventory Metrics
 // =============================================================================
 
+// AdjustmentDirection tells stock gains from stock losses in inventory adjustments.
+type AdjustmentDirection string
+
+const (
+	AdjustmentDirectionIncrease AdjustmentDirection = "increase"
+	AdjustmentDirectionDecrease AdjustmentDirection = "decrease"
+)
+
+// RecordInventoryAdjustment records an inventory adjustment that changed the stock of a warehouse.
+// This should be called after the adjustment has been committed.
+func (bm *BusinessMetrics) RecordInventoryAdjustment(ctx context.Context, tenantID, warehouseID uuid.UUID, direction AdjustmentDirection) {
+	bm.inventoryAdjustmentTotal.Inc(ctx,
+		AttrTenantID.String(tenantID.String()),
+		AttrWarehouseID.String(warehouseID.String()),
+		AttrAdjustmentDirection.String(string(direction)),
+	)
+}
+
 // RecordLockedQuantity records the current locked inventory quantity for a warehouse.
 // This is a gauge metric that should be updated periodically.
 func (bm *BusinessMetrics) RecordLockedQuantity(ctx context.Context, tenantID, warehouseID uuid.UUID, quantity int64) {
@@ -336,4 +419,8 @@ func (e *MetricsError) Error() string {
 var (
 	// Additional business attributes can be added here
 	AttrOrderSource = attribute.Key("order_source")
+
+	AttrReconcileDirection  = attribute.Key("reconcile_direction")
+	AttrFullyReconciled     = attribute.Key("fully_reconciled")
+	AttrAdjustmentDirection = attribute.Key("adjustment_direction")
 )
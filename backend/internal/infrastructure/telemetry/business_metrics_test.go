@@ -11,6 +11,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
 )
 
@@ -97,6 +99,86 @@ func TestBusinessMetrics_RecordPayment(t *testing.T) {
 	bm.RecordPayment(ctx, tenantID, "alipay", telemetry.PaymentStatusFailed)
 }
 
+// newRecordedBusinessMetrics creates business metrics whose measurements are read back with the returned reader
+func newRecordedBusinessMetrics(t *testing.T) (*telemetry.BusinessMetrics, *sdkmetric.ManualReader) {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	bm, err := telemetry.NewBusinessMetrics(telemetry.BusinessMetricsConfig{
+		Meter: provider.Meter("test"),
+	})
+	require.NoError(t, err)
+	return bm, reader
+}
+
+// collectMetric returns the named metric from the reader, failing the test when it was not recorded
+func collectMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+	t.Fatalf("metric %s not recorded", name)
+	return metricdata.Metrics{}
+}
+
+func TestBusinessMetrics_RecordOrderWithAmount_RecordsValue(t *testing.T) {
+	bm, reader := newRecordedBusinessMetrics(t)
+	tenantID := uuid.New()
+
+	bm.RecordOrderWithAmount(context.Background(), tenantID, telemetry.OrderTypeSales, decimal.NewFromFloat(199.99))
+	bm.RecordOrderWithAmount(context.Background(), tenantID, telemetry.OrderTypeSales, decimal.NewFromInt(800))
+
+	hist, ok := collectMetric(t, reader, "erp_order_value").Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, hist.DataPoints, 1)
+	point := hist.DataPoints[0]
+	assert.Equal(t, uint64(2), point.Count)
+	assert.InDelta(t, 999.99, point.Sum, 0.001)
+	tenant, _ := point.Attributes.Value(telemetry.AttrTenantID)
+	assert.Equal(t, tenantID.String(), tenant.AsString())
+}
+
+func TestBusinessMetrics_RecordPaymentReconciled(t *testing.T) {
+	bm, reader := newRecordedBusinessMetrics(t)
+	tenantID := uuid.New()
+
+	bm.RecordPaymentReconciled(context.Background(), tenantID, telemetry.ReconcileDirectionReceipt, true)
+	bm.RecordPaymentReconciled(context.Background(), tenantID, telemetry.ReconcileDirectionReceipt, true)
+	bm.RecordPaymentReconciled(context.Background(), tenantID, telemetry.ReconcileDirectionPayment, false)
+
+	sum, ok := collectMetric(t, reader, "erp_payment_reconciled_total").Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	counts := map[string]int64{}
+	for _, point := range sum.DataPoints {
+		direction, _ := point.Attributes.Value(telemetry.AttrReconcileDirection)
+		counts[direction.AsString()] += point.Value
+	}
+	assert.Equal(t, map[string]int64{"receipt": 2, "payment": 1}, counts)
+}
+
+func TestBusinessMetrics_RecordInventoryAdjustment(t *testing.T) {
+	bm, reader := newRecordedBusinessMetrics(t)
+	tenantID, warehouseID := uuid.New(), uuid.New()
+
+	bm.RecordInventoryAdjustment(context.Background(), tenantID, warehouseID, telemetry.AdjustmentDirectionDecrease)
+
+	sum, ok := collectMetric(t, reader, "erp_inventory_adjustment_total").Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+	assert.True(t, sum.DataPoints[0].Attributes.HasValue(telemetry.AttrWarehouseID))
+	direction, _ := sum.DataPoints[0].Attributes.Value(telemetry.AttrAdjustmentDirection)
+	assert.Equal(t, "decrease", direction.AsString())
+}
+
 func TestBusinessMetrics_RecordLockedQuantity(t *testing.T) {
 	meter := noop.NewMeterProvider().Meter("test")
 	bm, err := telemetry.NewBusinessMetrics(telemetry.BusinessMetricsConfig{
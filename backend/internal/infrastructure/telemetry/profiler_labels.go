@@ -66,6 +66,8 @@ const (
 	OperationDecreaseStock = "decrease_stock"
 	// OperationAdjustStock represents the adjust_stock operation.
 	OperationAdjustStock = "adjust_stock"
+	// OperationTransferStock represents the transfer_stock operation.
+	OperationTransferStock = "transfer_stock"
 )
 
 // Finance operations
@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/erp/backend/internal/infrastructure/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attribute keys for repository spans
+const (
+	SpanAttrTenantID = "tenant_id"
+	SpanAttrRowCount = "db.row_count"
+)
+
+// repositoryTracingEnabled switches repository spans on; off by default
+var repositoryTracingEnabled atomic.Bool
+
+// SetRepositoryTracingEnabled turns repository spans on or off.
+// It follows the telemetry enabled flag and is set once at startup.
+func SetRepositoryTracingEnabled(enabled bool) {
+	repositoryTracingEnabled.Store(enabled)
+}
+
+// RepositoryTracingEnabled reports whether repository spans are recorded.
+// Traced repositories check it before doing any tracing work, so a disabled
+// tracer costs a single atomic load per call.
+func RepositoryTracingEnabled() bool {
+	return repositoryTracingEnabled.Load()
+}
+
+// RepositorySpan is a span around one repository method call
+type RepositorySpan struct {
+	span trace.Span
+}
+
+// StartRepositorySpan starts a child span named repo.<repoType>.<method>.
+// tenantID is the tenant the call is for; when empty, the request tenant from the context is used.
+//
+// Example:
+//
+//	ctx, span := telemetry.StartRepositorySpan(ctx, "SalesOrderRepository", "FindByID", tenantID.String())
+//	order, err := r.next.FindByID(ctx, tenantID, id)
+//	span.SetFound(order != nil)
+//	span.End(err)
+func StartRepositorySpan(ctx context.Context, repoType, method, tenantID string) (context.Context, *RepositorySpan) {
+	if tenantID == "" {
+		tenantID = logger.GetTenantID(ctx)
+	}
+
+	var opts []SpanOption
+	if tenantID != "" {
+		opts = append(opts, WithAttribute(SpanAttrTenantID, tenantID))
+	}
+	ctx, span := StartSpan(ctx, "repo."+repoType+"."+method, opts...)
+	return ctx, &RepositorySpan{span: span}
+}
+
+// SetRowCount records the number of rows the call returned
+func (s *RepositorySpan) SetRowCount(rows int) {
+	SetAttribute(s.span, SpanAttrRowCount, rows)
+}
+
+// SetFound records a single-row lookup as one row when found and none otherwise
+func (s *RepositorySpan) SetFound(found bool) {
+	rows := 0
+	if found {
+		rows = 1
+	}
+	s.SetRowCount(rows)
+}
+
+// End records err, if any, and ends the span
+func (s *RepositorySpan) End(err error) {
+	RecordError(s.span, err)
+	s.span.End()
+}
@@ -0,0 +1,262 @@
+package telemetry
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// Query Fingerprinting
+// =============================================================================
+
+var (
+	// inListPattern matches an IN list of placeholders, e.g. IN (?, ?, ?)
+	inListPattern = regexp.MustCompile(`(?i)\bIN\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	// valuesRowsPattern matches repeated placeholder rows of a multi-row insert, e.g. (?,?),(?,?)
+	valuesRowsPattern = regexp.MustCompile(`(\(\?(?:\s*,\s*\?)*\))(?:\s*,\s*\(\?(?:\s*,\s*\?)*\))+`)
+)
+
+// FingerprintSQL normalizes a SQL statement to its query shape so the same query
+// can be grouped across executions. String and numeric literals and bind placeholders
+// ($1, ?) become ?, IN lists and multi-row VALUES collapse to a single entry, and
+// whitespace is collapsed. Quoted identifiers are kept as they are.
+//
+// Example:
+//
+//	FingerprintSQL("SELECT * FROM orders WHERE id IN (1, 2, 3) AND status = 'paid'")
+//	// SELECT * FROM orders WHERE id IN (?) AND status = ?
+func FingerprintSQL(sql string) string {
+	var b strings.Builder
+	b.Grow(len(sql))
+
+	// lastIdent reports whether the previous output byte continues an identifier,
+	// so digits in names like table2 or t1.col are not taken for literals
+	lastIdent := func() bool {
+		s := b.String()
+		if s == "" {
+			return false
+		}
+		c := s[len(s)-1]
+		return c == '_' || c == '"' || isDigit(c) || isLetter(c)
+	}
+	pendingSpace := false
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			pendingSpace = b.Len() > 0
+			i++
+			continue
+		}
+		if pendingSpace {
+			b.WriteByte(' ')
+			pendingSpace = false
+		}
+
+		switch {
+		case c == '\'':
+			// String literal; a doubled quote is an escaped quote
+			i++
+			for i < len(sql) {
+				if sql[i] == '\'' {
+					if i+1 < len(sql) && sql[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i++
+			b.WriteByte('?')
+
+		case c == '"':
+			// Quoted identifier, copied as is
+			end := strings.IndexByte(sql[i+1:], '"')
+			if end < 0 {
+				b.WriteString(sql[i:])
+				i = len(sql)
+				continue
+			}
+			b.WriteString(sql[i : i+end+2])
+			i += end + 2
+
+		case c == '$' && i+1 < len(sql) && isDigit(sql[i+1]):
+			// Positional bind placeholder
+			i++
+			for i < len(sql) && isDigit(sql[i]) {
+				i++
+			}
+			b.WriteByte('?')
+
+		case isDigit(c) && !lastIdent():
+			// Numeric literal, including decimals and exponents
+			for i < len(sql) && (isDigit(sql[i]) || sql[i] == '.') {
+				i++
+			}
+			if i < len(sql) && (sql[i] == 'e' || sql[i] == 'E') {
+				j := i + 1
+				if j < len(sql) && (sql[j] == '+' || sql[j] == '-') {
+					j++
+				}
+				if j < len(sql) && isDigit(sql[j]) {
+					i = j
+					for i < len(sql) && isDigit(sql[i]) {
+						i++
+					}
+				}
+			}
+			b.WriteByte('?')
+
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	fingerprint := inListPattern.ReplaceAllString(b.String(), "IN (?)")
+	return valuesRowsPattern.ReplaceAllString(fingerprint, "$1")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// =============================================================================
+// GORM Plugin for Slow Query Logging
+// =============================================================================
+
+// SlowQueryLogPlugin is a GORM plugin that logs queries slower than a threshold.
+// Queries are logged by fingerprint only; bind parameters are never logged, so
+// customer data in query arguments stays out of the logs.
+type SlowQueryLogPlugin struct {
+	threshold time.Duration
+	logger    *zap.Logger
+}
+
+// NewSlowQueryLogPlugin creates a new GORM plugin for slow query logging.
+// A threshold of zero uses the default of 200ms.
+func NewSlowQueryLogPlugin(threshold time.Duration, logger *zap.Logger) *SlowQueryLogPlugin {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if threshold <= 0 {
+		threshold = 200 * time.Millisecond
+	}
+	return &SlowQueryLogPlugin{
+		threshold: threshold,
+		logger:    logger.Named("slow_query"),
+	}
+}
+
+// Name returns the plugin name.
+func (p *SlowQueryLogPlugin) Name() string {
+	return "slow_query_log"
+}
+
+// Initialize registers the GORM callbacks for slow query logging.
+func (p *SlowQueryLogPlugin) Initialize(db *gorm.DB) error {
+	before := func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		db.Statement.Context = context.WithValue(ctx, slowQueryStartTimeKey, time.Now())
+	}
+
+	// Register before callbacks to set the query start time
+	if err := db.Callback().Create().Before("gorm:create").Register("slow_query_log:before_create", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("slow_query_log:before_query", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("slow_query_log:before_update", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("slow_query_log:before_delete", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("slow_query_log:before_row", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Before("gorm:raw").Register("slow_query_log:before_raw", before); err != nil {
+		return err
+	}
+
+	// Register after callbacks to log slow queries
+	if err := db.Callback().Create().After("gorm:create").Register("slow_query_log:after_create", p.logIfSlow); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("slow_query_log:after_query", p.logIfSlow); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("slow_query_log:after_update", p.logIfSlow); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("slow_query_log:after_delete", p.logIfSlow); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("slow_query_log:after_row", p.logIfSlow); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:raw").Register("slow_query_log:after_raw", p.logIfSlow); err != nil {
+		return err
+	}
+
+	p.logger.Info("Slow query logging enabled", zap.Duration("threshold", p.threshold))
+	return nil
+}
+
+// logIfSlow logs the completed statement when it took longer than the threshold.
+func (p *SlowQueryLogPlugin) logIfSlow(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		return
+	}
+	start, ok := ctx.Value(slowQueryStartTimeKey).(time.Time)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed <= p.threshold {
+		return
+	}
+
+	// Statement.SQL holds placeholders; the arguments in Statement.Vars are deliberately not logged
+	sql := db.Statement.SQL.String()
+	fields := []zap.Field{
+		zap.String("fingerprint", FingerprintSQL(sql)),
+		zap.String("operation", detectOperationType(sql)),
+		zap.String("table", db.Statement.Table),
+		zap.Duration("duration", elapsed),
+		zap.Int64("rows_affected", db.Statement.RowsAffected),
+		zap.Duration("threshold", p.threshold),
+	}
+	if traceID := GetTraceID(ctx); traceID != "" {
+		fields = append(fields, zap.String("trace_id", traceID))
+	}
+	// Driver errors can echo key values, so only whether the statement failed is logged
+	if db.Error != nil {
+		fields = append(fields, zap.Bool("failed", true))
+	}
+	p.logger.Warn("Slow query", fields...)
+}
+
+// slowQueryStartTimeKey is the context key for storing the query start time.
+const slowQueryStartTimeKey dbMetricsContextKey = "slow_query_start_time"
+
+// RegisterSlowQueryLog registers slow query logging on a GORM DB instance.
+func RegisterSlowQueryLog(db *gorm.DB, threshold time.Duration, logger *zap.Logger) error {
+	return db.Use(NewSlowQueryLogPlugin(threshold, logger))
+}
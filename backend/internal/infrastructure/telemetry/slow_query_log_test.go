@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestFingerprintSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		sql      string
+		expected string
+	}{
+		{
+			name:     "numeric literals",
+			sql:      "SELECT * FROM orders WHERE total > 100 AND discount = 0.15 AND rate < 1e-3 LIMIT 20",
+			expected: "SELECT * FROM orders WHERE total > ? AND discount = ? AND rate < ? LIMIT ?",
+		},
+		{
+			name:     "string literals",
+			sql:      "SELECT * FROM customers WHERE email = 'jane@example.com' AND name = 'O''Brien'",
+			expected: "SELECT * FROM customers WHERE email = ? AND name = ?",
+		},
+		{
+			name:     "IN list of literals",
+			sql:      "SELECT * FROM products WHERE id IN (1, 2, 3) AND code in ('A','B')",
+			expected: "SELECT * FROM products WHERE id IN (?) AND code IN (?)",
+		},
+		{
+			name:     "IN lists of different lengths share a fingerprint",
+			sql:      "SELECT * FROM products WHERE id IN ($1,$2,$3,$4,$5)",
+			expected: "SELECT * FROM products WHERE id IN (?)",
+		},
+		{
+			name:     "positional placeholders",
+			sql:      `SELECT * FROM "sales_orders" WHERE tenant_id = $1 AND id = $2 ORDER BY "sales_orders"."id" LIMIT $3`,
+			expected: `SELECT * FROM "sales_orders" WHERE tenant_id = ? AND id = ? ORDER BY "sales_orders"."id" LIMIT ?`,
+		},
+		{
+			name:     "digits in identifiers are kept",
+			sql:      `SELECT t1.col2, "address_line1" FROM table3 t1 WHERE t1.v = 7`,
+			expected: `SELECT t1.col2, "address_line1" FROM table3 t1 WHERE t1.v = ?`,
+		},
+		{
+			name:     "multi-row insert collapses to one row",
+			sql:      `INSERT INTO "items" ("a","b") VALUES ($1,$2),($3,$4),($5,$6)`,
+			expected: `INSERT INTO "items" ("a","b") VALUES (?,?)`,
+		},
+		{
+			name:     "whitespace is collapsed",
+			sql:      "SELECT id\n\tFROM   users\n WHERE  active = true  ",
+			expected: "SELECT id FROM users WHERE active = true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FingerprintSQL(tt.sql))
+		})
+	}
+}
+
+func TestSlowQueryLogPlugin_LogsFingerprintWithoutParameters(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, gormDB.Use(NewSlowQueryLogPlugin(time.Nanosecond, zap.New(core))))
+
+	tp := sdktrace.NewTracerProvider()
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	const email = "jane@example.com"
+	mock.ExpectQuery(`SELECT "id" FROM "customers" WHERE email = \$1`).
+		WithArgs(email).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	var ids []int
+	require.NoError(t, gormDB.WithContext(ctx).Table("customers").Where("email = ?", email).Pluck("id", &ids).Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, `SELECT "id" FROM "customers" WHERE email = ?`, fields["fingerprint"])
+	assert.Equal(t, int64(2), fields["rows_affected"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), fields["trace_id"])
+	assert.Contains(t, fields, "duration")
+	for key, value := range fields {
+		assert.NotContains(t, fmt.Sprint(value), email, "field %s leaks a query parameter", key)
+	}
+}
+
+func TestSlowQueryLogPlugin_SkipsFastQueries(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, gormDB.Use(NewSlowQueryLogPlugin(time.Hour, zap.New(core))))
+
+	mock.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	var ids []int
+	require.NoError(t, gormDB.Table("customers").Pluck("id", &ids).Error)
+
+	assert.Zero(t, logs.Len())
+}
@@ -131,11 +131,61 @@ var ErrorCodeHTTPStatus = map[string]int{
 	"TOKEN_INVALID":        http.StatusUnauthorized,
 	"TOKEN_ERROR":          http.StatusUnauthorized,
 	"TOKEN_MAX_REFRESH":    http.StatusUnauthorized,
+	"TOKEN_REVOKED":        http.StatusUnauthorized,
+	"TOKEN_REUSED":         http.StatusUnauthorized,
 	"INVALID_TOKEN":        http.StatusUnauthorized,
 	"INVALID_REFRESH":      http.StatusUnauthorized,
 	"MAX_REFRESH_EXCEEDED": http.StatusUnauthorized,
 	"PASSWORD_INCORRECT":   http.StatusUnprocessableEntity,
 	"INVALID_PASSWORD":     http.StatusUnprocessableEntity,
+	// Password rejected by the tenant password policy; details list each failed rule
+	"PASSWORD_POLICY_VIOLATION": http.StatusUnprocessableEntity,
+	"INVALID_PASSWORD_POLICY":   http.StatusUnprocessableEntity,
+
+	// Finance domain-specific error codes
+	"CURRENCY_MISMATCH":  http.StatusUnprocessableEntity,
+	"DUPLICATE_APPROVER": http.StatusUnprocessableEntity,
+	// Idempotency key replayed with a different request body
+	"IDEMPOTENCY_KEY_REUSED": http.StatusUnprocessableEntity,
+
+	// Trade domain-specific error codes
+	"CREDIT_LIMIT_EXCEEDED": http.StatusUnprocessableEntity,
+
+	// Partner domain-specific error codes
+	"CANNOT_MERGE_SELF":         http.StatusUnprocessableEntity,
+	"CROSS_TENANT_MERGE":        http.StatusUnprocessableEntity,
+	"CUSTOMER_MERGED":           http.StatusUnprocessableEntity,
+	"INVALID_OVERDRAFT_LIMIT":   http.StatusUnprocessableEntity,
+	"OVERDRAFT_IN_USE":          http.StatusUnprocessableEntity,
+	"INVALID_UPGRADE_THRESHOLD": http.StatusUnprocessableEntity,
+	"INVALID_SPEND_WINDOW":      http.StatusUnprocessableEntity,
+	"INVALID_ADDRESS":           http.StatusUnprocessableEntity,
+	"INVALID_ADDRESS_TYPE":      http.StatusUnprocessableEntity,
+	"INVALID_ADDRESS_OWNER":     http.StatusUnprocessableEntity,
+	"INVALID_ADDRESS_LABEL":     http.StatusUnprocessableEntity,
+	"DEFAULT_ADDRESS_REQUIRED":  http.StatusUnprocessableEntity,
+	"INVALID_SHIPPING_ADDRESS":  http.StatusUnprocessableEntity,
+
+	// Inventory domain-specific error codes
+	"NOT_A_RECEIPT":                  http.StatusUnprocessableEntity,
+	"RECEIPT_COST_UNCHANGED":         http.StatusUnprocessableEntity,
+	"INVALID_FROM_DATE":              http.StatusUnprocessableEntity,
+	"COST_LEDGER_MISMATCH":           http.StatusUnprocessableEntity,
+	"COST_RECALCULATION_IN_PROGRESS": http.StatusConflict,
+
+	// Strategy configuration error codes
+	"UNKNOWN_STRATEGY": http.StatusUnprocessableEntity,
+
+	// Catalog domain-specific error codes
+	"MISSING_REQUIRED_ATTRIBUTES": http.StatusUnprocessableEntity,
+	"NO_CONVERSION_PATH":          http.StatusUnprocessableEntity,
+	"INVALID_PRICE_TIERS":         http.StatusUnprocessableEntity,
+
+	// Feature flag domain-specific error codes
+	"INVALID_PREREQUISITE":   http.StatusUnprocessableEntity,
+	"DUPLICATE_PREREQUISITE": http.StatusUnprocessableEntity,
+	"PREREQUISITE_NOT_FOUND": http.StatusUnprocessableEntity,
+	"PREREQUISITE_CYCLE":     http.StatusUnprocessableEntity,
 }
 
 // GetHTTPStatus returns the HTTP status code for an error code
@@ -150,30 +200,35 @@ func GetHTTPStatus(code string) int {
 // LegacyErrorCodeMapping maps old error codes to new standardized codes
 // This is for backward compatibility with existing domain errors
 var LegacyErrorCodeMapping = map[string]string{
-	"NOT_FOUND":              ErrCodeNotFound,
-	"FLAG_NOT_FOUND":         ErrCodeNotFound,
-	"OVERRIDE_NOT_FOUND":     ErrCodeNotFound,
-	"ALREADY_EXISTS":         ErrCodeAlreadyExists,
-	"FLAG_KEY_EXISTS":        ErrCodeAlreadyExists,
-	"FLAG_EXISTS":            ErrCodeAlreadyExists,
-	"OVERRIDE_EXISTS":        ErrCodeAlreadyExists,
-	"INVALID_INPUT":          ErrCodeInvalidInput,
-	"INVALID_STATE":          ErrCodeInvalidState,
-	"FLAG_ARCHIVED":          ErrCodeInvalidState,
-	"CANNOT_ENABLE":          ErrCodeInvalidState,
-	"CANNOT_DISABLE":         ErrCodeInvalidState,
-	"ALREADY_ENABLED":        ErrCodeInvalidState,
-	"ALREADY_DISABLED":       ErrCodeInvalidState,
-	"ALREADY_ARCHIVED":       ErrCodeInvalidState,
-	"UNAUTHORIZED":           ErrCodeUnauthorized,
-	"FORBIDDEN":              ErrCodeForbidden,
-	"CONCURRENCY_CONFLICT":   ErrCodeConcurrencyConflict,
-	"OPTIMISTIC_LOCK_FAILED": ErrCodeConcurrencyConflict,
-	"INSUFFICIENT_STOCK":     ErrCodeInsufficientStock,
-	"INSUFFICIENT_BALANCE":   ErrCodeInsufficientBalance,
-	"VALIDATION_ERROR":       ErrCodeValidation,
-	"BAD_REQUEST":            ErrCodeBadRequest,
-	"INTERNAL_ERROR":         ErrCodeInternal,
+	"NOT_FOUND":               ErrCodeNotFound,
+	"FLAG_NOT_FOUND":          ErrCodeNotFound,
+	"OVERRIDE_NOT_FOUND":      ErrCodeNotFound,
+	"TENANT_NOT_FOUND":        ErrCodeNotFound,
+	"SESSION_NOT_FOUND":       ErrCodeNotFound,
+	"ALREADY_EXISTS":          ErrCodeAlreadyExists,
+	"FLAG_KEY_EXISTS":         ErrCodeAlreadyExists,
+	"FLAG_EXISTS":             ErrCodeAlreadyExists,
+	"OVERRIDE_EXISTS":         ErrCodeAlreadyExists,
+	"INVALID_INPUT":           ErrCodeInvalidInput,
+	"INVALID_CURSOR":          ErrCodeInvalidInput,
+	"INVALID_STATE":           ErrCodeInvalidState,
+	"FLAG_ARCHIVED":           ErrCodeInvalidState,
+	"CANNOT_ENABLE":           ErrCodeInvalidState,
+	"CANNOT_DISABLE":          ErrCodeInvalidState,
+	"ALREADY_ENABLED":         ErrCodeInvalidState,
+	"ALREADY_DISABLED":        ErrCodeInvalidState,
+	"ALREADY_ARCHIVED":        ErrCodeInvalidState,
+	"UNAUTHORIZED":            ErrCodeUnauthorized,
+	"FORBIDDEN":               ErrCodeForbidden,
+	"CONCURRENCY_CONFLICT":    ErrCodeConcurrencyConflict,
+	"OPTIMISTIC_LOCK_FAILED":  ErrCodeConcurrencyConflict,
+	"CONCURRENT_MODIFICATION": ErrCodeConcurrencyConflict,
+	"VERSION_CONFLICT":        ErrCodeConcurrencyConflict,
+	"INSUFFICIENT_STOCK":      ErrCodeInsufficientStock,
+	"INSUFFICIENT_BALANCE":    ErrCodeInsufficientBalance,
+	"VALIDATION_ERROR":        ErrCodeValidation,
+	"BAD_REQUEST":             ErrCodeBadRequest,
+	"INTERNAL_ERROR":          ErrCodeInternal,
 }
 
 // NormalizeErrorCode converts a legacy error code to the standardized format
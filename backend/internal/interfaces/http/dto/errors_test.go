@@ -236,6 +236,20 @@ func TestNewSuccessResponseWithMeta(t *testing.T) {
 	assert.Equal(t, 10, resp.Meta.TotalPages) // 100 / 10 = 10
 }
 
+func TestNewSuccessResponseWithCursor(t *testing.T) {
+	resp := NewSuccessResponseWithCursor([]string{"item1", "item2"}, 2, "next-token")
+
+	assert.True(t, resp.Success)
+	assert.NotNil(t, resp.Meta)
+	assert.Equal(t, 2, resp.Meta.PageSize)
+	assert.Equal(t, "next-token", resp.Meta.NextCursor)
+	assert.True(t, resp.Meta.HasMore)
+
+	last := NewSuccessResponseWithCursor([]string{"item3"}, 2, "")
+	assert.Empty(t, last.Meta.NextCursor)
+	assert.False(t, last.Meta.HasMore)
+}
+
 func TestNewSuccessResponseWithMetaPagination(t *testing.T) {
 	tests := []struct {
 		total         int64
@@ -43,6 +43,10 @@ type Meta struct {
 	Page       int   `json:"page"`
 	PageSize   int   `json:"page_size"`
 	TotalPages int   `json:"total_pages"`
+	// NextCursor is set on cursor-paginated lists when another page follows.
+	// Total, Page and TotalPages are not computed in cursor mode.
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more,omitempty"`
 }
 
 // NewSuccessResponse creates a success response with typed data
@@ -74,6 +78,19 @@ func NewSuccessResponseWithMeta[T any](data T, total int64, page, pageSize int)
 	}
 }
 
+// NewSuccessResponseWithCursor creates a success response for one page of a cursor-paginated list
+func NewSuccessResponseWithCursor[T any](data T, pageSize int, nextCursor string) APIResponse[T] {
+	return APIResponse[T]{
+		Success: true,
+		Data:    data,
+		Meta: &Meta{
+			PageSize:   pageSize,
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+	}
+}
+
 // NewErrorResponse creates an error response with code and message
 func NewErrorResponse(code, message string) Response {
 	return Response{
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/erp/backend/internal/application/identity"
+	domainIdentity "github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/infrastructure/config"
 	"github.com/erp/backend/internal/interfaces/http/dto"
 	"github.com/erp/backend/internal/interfaces/http/middleware"
@@ -98,9 +100,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	clientIP := c.ClientIP()
 
 	result, err := h.authService.Login(c.Request.Context(), identity.LoginInput{
-		Username: req.Username,
-		Password: req.Password,
-		IP:       clientIP,
+		Username:  req.Username,
+		Password:  req.Password,
+		IP:        clientIP,
+		UserAgent: c.Request.UserAgent(),
 	})
 	if err != nil {
 		h.HandleError(c, err)
@@ -139,6 +142,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			Permissions: result.User.Permissions,
 			RoleIDs:     roleIDStrings,
 		},
+		MustChangePassword: result.MustChangePassword,
 	}
 
 	h.Success(c, response)
@@ -182,6 +186,8 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	// The auth service extracts user info from the refresh token itself
 	result, err := h.authService.RefreshToken(c.Request.Context(), identity.RefreshTokenInput{
 		RefreshToken: refreshToken,
+		IP:           c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
 	})
 	if err != nil {
 		// Clear invalid cookie on refresh failure
@@ -292,6 +298,8 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	result, err := h.authService.GetCurrentUser(c.Request.Context(), identity.GetCurrentUserInput{
 		UserID:   userID,
 		TenantID: tenantID,
+		FamilyID: claims.GetFamilyID(),
+		IP:       c.ClientIP(),
 	})
 	if err != nil {
 		h.HandleError(c, err)
@@ -327,6 +335,7 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 //	@ID				changePasswordAuth
 //	@Summary		Change password
 //	@Description	Change the current user's password
+//	@Description	The new password must satisfy the tenant's password policy; a 422 lists each failed rule in details
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -363,6 +372,11 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		NewPassword: req.NewPassword,
 	})
 	if err != nil {
+		var policyErr *domainIdentity.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			passwordPolicyViolation(c, policyErr)
+			return
+		}
 		h.HandleError(c, err)
 		return
 	}
@@ -372,6 +386,16 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	}))
 }
 
+// passwordPolicyViolation responds with each password policy rule the new password fails
+func passwordPolicyViolation(c *gin.Context, policyErr *domainIdentity.PasswordPolicyError) {
+	details := make([]dto.ValidationDetail, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		details[i] = dto.ValidationDetail{Field: "new_password." + string(v.Rule), Message: v.Message}
+	}
+	c.JSON(http.StatusUnprocessableEntity, dto.NewErrorResponseWithDetails(
+		domainIdentity.ErrPasswordPolicyViolation.Code, policyErr.Error(), getRequestID(c), details))
+}
+
 // ForceLogout godoc
 //
 //	@ID				forceLogoutAuth
@@ -435,3 +459,121 @@ func (h *AuthHandler) ForceLogout(c *gin.Context) {
 		Message: result.Message,
 	})
 }
+
+// ListUserSessions godoc
+//
+//	@ID				listUserSessions
+//	@Summary		List active sessions of a user (Admin)
+//	@Description	List the active login sessions of a user, most recently seen first. Requires user:read permission.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"	format(uuid)
+//	@Success		200	{object}	APIResponse[[]SessionResponse]
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/identity/users/{id}/sessions [get]
+func (h *AuthHandler) ListUserSessions(c *gin.Context) {
+	claims := middleware.GetJWTClaims(c)
+	if claims == nil {
+		h.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	tenantID, err := uuid.Parse(claims.TenantID)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID in token")
+		return
+	}
+
+	sessions, err := h.authService.ListUserSessions(c.Request.Context(), identity.ListUserSessionsInput{
+		TargetUserID: targetUserID,
+		TenantID:     tenantID,
+	})
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	response := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		response[i] = SessionResponse{
+			JTI:        session.JTI,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			IssuedAt:   session.IssuedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+		}
+	}
+
+	h.Success(c, response)
+}
+
+// RevokeUserSession godoc
+//
+//	@ID				revokeUserSession
+//	@Summary		Revoke a session of a user (Admin)
+//	@Description	Invalidate a single session of a user; the user's other sessions stay valid. Requires user:force_logout permission.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"	format(uuid)
+//	@Param			jti	path		string	true	"JTI of the session's access token"
+//	@Success		200	{object}	APIResponse[RevokeSessionResponse]
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/identity/users/{id}/sessions/{jti} [delete]
+func (h *AuthHandler) RevokeUserSession(c *gin.Context) {
+	claims := middleware.GetJWTClaims(c)
+	if claims == nil {
+		h.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	adminUserID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		h.BadRequest(c, "Invalid admin user ID in token")
+		return
+	}
+
+	tenantID, err := uuid.Parse(claims.TenantID)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID in token")
+		return
+	}
+
+	err = h.authService.RevokeUserSession(c.Request.Context(), identity.RevokeSessionInput{
+		AdminUserID:  adminUserID,
+		TargetUserID: targetUserID,
+		TenantID:     tenantID,
+		JTI:          c.Param("jti"),
+	})
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	h.Success(c, RevokeSessionResponse{
+		Message: "Session has been revoked",
+	})
+}
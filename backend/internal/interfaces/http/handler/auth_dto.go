@@ -24,7 +24,7 @@ type RefreshTokenRequest struct {
 // ChangePasswordRequest represents the request body for password change
 type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required"`
-	NewPassword string `json:"new_password" binding:"required,min=8,max=128"`
+	NewPassword string `json:"new_password" binding:"required,max=128"` // Length is checked by the tenant password policy
 }
 
 // ForceLogoutRequest represents the request body for admin force logout
@@ -63,6 +63,8 @@ type AuthUserResponse struct {
 type LoginResponse struct {
 	Token TokenResponse    `json:"token"`
 	User  AuthUserResponse `json:"user"`
+	// The password was reset by an admin or has expired and must be changed
+	MustChangePassword bool `json:"must_change_password"`
 }
 
 // RefreshTokenResponse represents the response body for successful token refresh
@@ -85,3 +87,19 @@ type LogoutResponse struct {
 type ForceLogoutResponse struct {
 	Message string `json:"message"`
 }
+
+// SessionResponse represents an active session of a user
+type SessionResponse struct {
+	JTI        string    `json:"jti"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// RevokeSessionResponse represents the response body for revoking a session
+type RevokeSessionResponse struct {
+	Message string `json:"message"`
+}
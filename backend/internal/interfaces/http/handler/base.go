@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/interfaces/http/dto"
@@ -48,6 +49,16 @@ func getTenantID(c *gin.Context) (uuid.UUID, error) {
 	return uuid.Parse(tenantIDStr)
 }
 
+// canAccessTenant returns true if the caller may act on the given tenant:
+// it must be the caller's own tenant, unless the caller is a platform administrator
+func canAccessTenant(c *gin.Context, tenantID uuid.UUID) bool {
+	if middleware.IsPlatformAdmin(c) {
+		return true
+	}
+	callerTenantID, err := getTenantID(c)
+	return err == nil && callerTenantID == tenantID
+}
+
 // Success sends a success response
 func (h *BaseHandler) Success(c *gin.Context, data any) {
 	c.JSON(http.StatusOK, dto.NewSuccessResponse(data))
@@ -58,6 +69,11 @@ func (h *BaseHandler) SuccessWithMeta(c *gin.Context, data any, total int64, pag
 	c.JSON(http.StatusOK, dto.NewSuccessResponseWithMeta(data, total, page, pageSize))
 }
 
+// SuccessWithCursor sends a success response for one page of a cursor-paginated list
+func (h *BaseHandler) SuccessWithCursor(c *gin.Context, data any, pageSize int, nextCursor string) {
+	c.JSON(http.StatusOK, dto.NewSuccessResponseWithCursor(data, pageSize, nextCursor))
+}
+
 // Created sends a 201 created response
 func (h *BaseHandler) Created(c *gin.Context, data any) {
 	c.JSON(http.StatusCreated, dto.NewSuccessResponse(data))
@@ -135,6 +151,18 @@ func (h *BaseHandler) ValidationError(c *gin.Context, details []dto.ValidationDe
 func (h *BaseHandler) HandleDomainError(c *gin.Context, err error) {
 	requestID := getRequestID(c)
 
+	var conflictErr *shared.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		h.versionConflict(c, conflictErr)
+		return
+	}
+
+	var balanceErr *shared.InsufficientBalanceError
+	if errors.As(err, &balanceErr) {
+		h.insufficientBalance(c, balanceErr)
+		return
+	}
+
 	var domainErr *shared.DomainError
 	if errors.As(err, &domainErr) {
 		code := dto.NormalizeErrorCode(domainErr.Code)
@@ -155,6 +183,18 @@ func (h *BaseHandler) HandleError(c *gin.Context, err error) {
 
 	requestID := getRequestID(c)
 
+	var conflictErr *shared.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		h.versionConflict(c, conflictErr)
+		return
+	}
+
+	var balanceErr *shared.InsufficientBalanceError
+	if errors.As(err, &balanceErr) {
+		h.insufficientBalance(c, balanceErr)
+		return
+	}
+
 	// Check for domain error using errors.As for wrapped error support
 	var domainErr *shared.DomainError
 	if errors.As(err, &domainErr) {
@@ -171,3 +211,21 @@ func (h *BaseHandler) HandleError(c *gin.Context, err error) {
 		requestID,
 	))
 }
+
+// versionConflict responds with 409 and the version now stored, so the client can refetch and retry
+func (h *BaseHandler) versionConflict(c *gin.Context, conflictErr *shared.VersionConflictError) {
+	details := []dto.ValidationDetail{
+		{Field: "current_version", Message: strconv.Itoa(conflictErr.CurrentVersion)},
+	}
+	c.JSON(http.StatusConflict, dto.NewErrorResponseWithDetails(
+		dto.ErrCodeConcurrencyConflict, conflictErr.Error(), getRequestID(c), details))
+}
+
+// insufficientBalance responds with 422 and the amount that can still be spent
+func (h *BaseHandler) insufficientBalance(c *gin.Context, balanceErr *shared.InsufficientBalanceError) {
+	details := []dto.ValidationDetail{
+		{Field: "available", Message: balanceErr.Available.StringFixed(2)},
+	}
+	c.JSON(http.StatusUnprocessableEntity, dto.NewErrorResponseWithDetails(
+		dto.ErrCodeInsufficientBalance, balanceErr.Error(), getRequestID(c), details))
+}
@@ -7,10 +7,14 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/auth"
 	"github.com/erp/backend/internal/interfaces/http/dto"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -377,6 +381,42 @@ func TestBaseHandlerHandleDomainErrorWithRequestID(t *testing.T) {
 	assert.Equal(t, "domain-err-req", resp.Error.RequestID)
 }
 
+func TestBaseHandlerHandleVersionConflict(t *testing.T) {
+	h := &BaseHandler{}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/", nil)
+
+	h.HandleDomainError(c, fmt.Errorf("save product: %w", shared.NewVersionConflictError("product", 4)))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var resp dto.Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, dto.ErrCodeConcurrencyConflict, resp.Error.Code)
+	assert.Equal(t, []dto.ValidationDetail{{Field: "current_version", Message: "4"}}, resp.Error.Details)
+}
+
+func TestBaseHandlerHandleInsufficientBalance(t *testing.T) {
+	h := &BaseHandler{}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/", nil)
+
+	h.HandleDomainError(c, shared.NewInsufficientBalanceError(decimal.NewFromInt(30), decimal.NewFromInt(100)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var resp dto.Response
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, dto.ErrCodeInsufficientBalance, resp.Error.Code)
+	assert.Equal(t, []dto.ValidationDetail{{Field: "available", Message: "30.00"}}, resp.Error.Details)
+}
+
 func TestBaseHandlerHandleNonDomainError(t *testing.T) {
 	h := &BaseHandler{}
 	w := httptest.NewRecorder()
@@ -463,3 +503,23 @@ func TestBaseHandlerUnprocessableEntity(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, dto.ErrCodeBusinessRule, resp.Error.Code)
 }
+
+func TestCanAccessTenant(t *testing.T) {
+	callerTenant := uuid.New()
+	otherTenant := uuid.New()
+
+	newContext := func(permissions ...string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		setJWTContext(c, callerTenant, uuid.New())
+		c.Set(middleware.JWTClaimsKey, &auth.Claims{TenantID: callerTenant.String(), Permissions: permissions})
+		return c
+	}
+
+	assert.True(t, canAccessTenant(newContext(), callerTenant))
+	assert.False(t, canAccessTenant(newContext("tenant:export"), otherTenant))
+	assert.True(t, canAccessTenant(newContext(identity.PermissionPlatformAdmin), otherTenant))
+
+	// Without authentication no tenant is accessible
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	assert.False(t, canAccessTenant(c, callerTenant))
+}
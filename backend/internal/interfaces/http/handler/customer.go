@@ -2,6 +2,7 @@ package handler
 
 import (
 	partnerapp "github.com/erp/backend/internal/application/partner"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -13,6 +14,27 @@ type CustomerHandler struct {
 	customerService *partnerapp.CustomerService
 }
 
+// customerFinancialFields are the customer response fields only principals
+// with customer:view_financials may see
+var customerFinancialFields = FieldMask{
+	{Field: "balance", Permission: "customer:view_financials"},
+	{Field: "credit_limit", Permission: "customer:view_financials"},
+	{Field: "overdraft_limit", Permission: "customer:view_financials"},
+	{Field: "spendable_balance", Permission: "customer:view_financials"},
+}
+
+// customerMergeFinancialFields are the merge result fields only principals
+// with customer:view_financials may see
+var customerMergeFinancialFields = FieldMask{
+	{Field: "balance_transferred", Permission: "customer:view_financials"},
+}
+
+// customerMergeResult is a merge result whose customer has already been masked
+type customerMergeResult struct {
+	*partnerapp.CustomerMergeResponse
+	Customer any `json:"customer"`
+}
+
 // NewCustomerHandler creates a new CustomerHandler
 func NewCustomerHandler(customerService *partnerapp.CustomerService) *CustomerHandler {
 	return &CustomerHandler{
@@ -38,9 +60,11 @@ type CreateCustomerRequest struct {
 	Country     string   `json:"country" binding:"max=100" example:"China"`
 	TaxID       string   `json:"tax_id" binding:"max=50" example:"91310000MA1FL8L972"`
 	CreditLimit *float64 `json:"credit_limit" example:"10000.00"`
-	Notes       string   `json:"notes" example:"VIP customer"`
-	SortOrder   *int     `json:"sort_order" example:"0"`
-	Attributes  string   `json:"attributes" example:"{}"`
+	// How far the balance may go below zero; omit or 0 for prepaid customers
+	OverdraftLimit *float64 `json:"overdraft_limit" example:"0"`
+	Notes          string   `json:"notes" example:"VIP customer"`
+	SortOrder      *int     `json:"sort_order" example:"0"`
+	Attributes     string   `json:"attributes" example:"{}"`
 }
 
 // UpdateCustomerRequest represents a request to update a customer
@@ -59,10 +83,14 @@ type UpdateCustomerRequest struct {
 	Country     *string  `json:"country" binding:"omitempty,max=100" example:"China"`
 	TaxID       *string  `json:"tax_id" binding:"omitempty,max=50" example:"91310000MA1FL8L972"`
 	CreditLimit *float64 `json:"credit_limit" example:"20000.00"`
-	Level       *string  `json:"level" binding:"omitempty,oneof=normal silver gold platinum vip" example:"gold"`
-	Notes       *string  `json:"notes" example:"Updated notes"`
-	SortOrder   *int     `json:"sort_order" example:"1"`
-	Attributes  *string  `json:"attributes" example:"{}"`
+	// Cannot be lowered below the overdraft the customer already uses
+	OverdraftLimit *float64 `json:"overdraft_limit" example:"500.00"`
+	Level          *string  `json:"level" binding:"omitempty,oneof=normal silver gold platinum vip" example:"gold"`
+	Notes          *string  `json:"notes" example:"Updated notes"`
+	SortOrder      *int     `json:"sort_order" example:"1"`
+	Attributes     *string  `json:"attributes" example:"{}"`
+	// Version the client loaded; the update is rejected with 409 and the current version if the customer changed since
+	Version *int `json:"version" example:"3"`
 }
 
 // UpdateCustomerCodeRequest represents a request to update a customer's code
@@ -79,6 +107,13 @@ type BalanceOperationRequest struct {
 	Amount float64 `json:"amount" binding:"required,gt=0" example:"1000.00"`
 }
 
+// MergeCustomerRequest represents a request to merge a duplicate customer
+//
+//	@Description	Request body for merging a duplicate customer into the customer in the path
+type MergeCustomerRequest struct {
+	MergedCustomerID string `json:"merged_customer_id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440002"`
+}
+
 // SetLevelRequest represents a request to set customer level
 //
 //	@Description	Request body for setting customer level
@@ -147,6 +182,10 @@ func (h *CustomerHandler) Create(c *gin.Context) {
 		d := decimal.NewFromFloat(*req.CreditLimit)
 		appReq.CreditLimit = &d
 	}
+	if req.OverdraftLimit != nil {
+		d := decimal.NewFromFloat(*req.OverdraftLimit)
+		appReq.OverdraftLimit = &d
+	}
 	if req.SortOrder != nil {
 		appReq.SortOrder = req.SortOrder
 	}
@@ -157,7 +196,7 @@ func (h *CustomerHandler) Create(c *gin.Context) {
 		return
 	}
 
-	h.Created(c, customer)
+	h.CreatedMasked(c, customer, customerFinancialFields)
 }
 
 // GetByID godoc
@@ -195,7 +234,7 @@ func (h *CustomerHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // GetByCode godoc
@@ -233,7 +272,7 @@ func (h *CustomerHandler) GetByCode(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // List godoc
@@ -254,9 +293,11 @@ func (h *CustomerHandler) GetByCode(c *gin.Context) {
 //	@Param			page_size	query		int		false	"Page size"			default(20)	maximum(100)
 //	@Param			order_by	query		string	false	"Order by field"	default(sort_order)
 //	@Param			order_dir	query		string	false	"Order direction"	Enums(asc, desc)	default(asc)
+//	@Param			include_deleted	query		boolean	false	"Include soft-deleted customers (requires customer:restore)"
 //	@Success		200			{object}	APIResponse[[]CustomerListResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/partner/customers [get]
@@ -273,6 +314,12 @@ func (h *CustomerHandler) List(c *gin.Context) {
 		return
 	}
 
+	// Soft-deleted customers are only visible to admins
+	if filter.IncludeDeleted && !middleware.HasPermission(c, "customer:restore") {
+		h.Forbidden(c, "Listing deleted customers requires admin permission (customer:restore)")
+		return
+	}
+
 	// Set defaults
 	if filter.Page <= 0 {
 		filter.Page = 1
@@ -287,14 +334,15 @@ func (h *CustomerHandler) List(c *gin.Context) {
 		return
 	}
 
-	h.SuccessWithMeta(c, customers, total, filter.Page, filter.PageSize)
+	h.SuccessWithMetaMasked(c, customers, total, filter.Page, filter.PageSize, customerFinancialFields)
 }
 
 // Update godoc
 //
 //	@ID				updateCustomer
 //	@Summary		Update a customer
-//	@Description	Update an existing customer's details
+//	@Description	Update an existing customer's details.
+//	@Description	Send the version the customer was loaded with; if it has changed since, 409 is returned with the current version.
 //	@Tags			customers
 //	@Accept			json
 //	@Produce		json
@@ -345,12 +393,17 @@ func (h *CustomerHandler) Update(c *gin.Context) {
 		Notes:       req.Notes,
 		SortOrder:   req.SortOrder,
 		Attributes:  req.Attributes,
+		Version:     req.Version,
 	}
 
 	if req.CreditLimit != nil {
 		d := decimal.NewFromFloat(*req.CreditLimit)
 		appReq.CreditLimit = &d
 	}
+	if req.OverdraftLimit != nil {
+		d := decimal.NewFromFloat(*req.OverdraftLimit)
+		appReq.OverdraftLimit = &d
+	}
 
 	customer, err := h.customerService.Update(c.Request.Context(), tenantID, customerID, appReq)
 	if err != nil {
@@ -358,7 +411,7 @@ func (h *CustomerHandler) Update(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // UpdateCode godoc
@@ -405,14 +458,14 @@ func (h *CustomerHandler) UpdateCode(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // Delete godoc
 //
 //	@ID				deleteCustomer
 //	@Summary		Delete a customer
-//	@Description	Delete a customer by ID
+//	@Description	Soft delete a customer by ID. Admins can restore it with POST /partner/customers/{id}/restore
 //	@Tags			customers
 //	@Produce		json
 //	@Param			X-Tenant-ID	header	string	false	"Tenant ID (optional for dev)"
@@ -447,6 +500,109 @@ func (h *CustomerHandler) Delete(c *gin.Context) {
 	h.NoContent(c)
 }
 
+// Restore godoc
+//
+//	@ID				restoreCustomer
+//	@Summary		Restore a customer
+//	@Description	Restore a soft-deleted customer. Fails with 409 if another customer has taken its code since it was deleted.
+//	@Description	Requires customer:restore permission.
+//	@Tags			customers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Customer ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[CustomerResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/customers/{id}/restore [post]
+func (h *CustomerHandler) Restore(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid customer ID format")
+		return
+	}
+
+	customer, err := h.customerService.Restore(c.Request.Context(), tenantID, customerID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.SuccessMasked(c, customer, customerFinancialFields)
+}
+
+// Merge godoc
+//
+//	@ID				mergeCustomer
+//	@Summary		Merge a duplicate customer
+//	@Description	Merge a duplicate customer into the customer in the path. The duplicate's orders, returns,
+//	@Description	receivables, receipts, refunds and balance transactions move to the surviving customer and
+//	@Description	its balance is added to the survivor's. The duplicate is kept with status merged.
+//	@Description	Requires customer:merge permission.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string					false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string					true	"Surviving customer ID"	format(uuid)
+//	@Param			request		body		MergeCustomerRequest	true	"Customer to merge"
+//	@Success		200			{object}	APIResponse[CustomerMergeResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/customers/{id}/merge [post]
+func (h *CustomerHandler) Merge(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	survivingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid customer ID format")
+		return
+	}
+
+	var req MergeCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+	mergedID, err := uuid.Parse(req.MergedCustomerID)
+	if err != nil {
+		h.BadRequest(c, "Invalid merged customer ID format")
+		return
+	}
+
+	result, err := h.customerService.Merge(c.Request.Context(), tenantID, survivingID, mergedID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	customer, err := customerFinancialFields.Apply(c, result.Customer)
+	if err != nil {
+		h.InternalError(c, "Failed to serialize response")
+		return
+	}
+	h.SuccessMasked(c, customerMergeResult{CustomerMergeResponse: result, Customer: customer}, customerMergeFinancialFields)
+}
+
 // Activate godoc
 //
 //	@ID				activateCustomer
@@ -484,7 +640,7 @@ func (h *CustomerHandler) Activate(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // Deactivate godoc
@@ -524,7 +680,7 @@ func (h *CustomerHandler) Deactivate(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // Suspend godoc
@@ -564,7 +720,7 @@ func (h *CustomerHandler) Suspend(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // AddBalance godoc
@@ -612,7 +768,7 @@ func (h *CustomerHandler) AddBalance(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // DeductBalance godoc
@@ -660,7 +816,7 @@ func (h *CustomerHandler) DeductBalance(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // SetLevel godoc
@@ -707,7 +863,7 @@ func (h *CustomerHandler) SetLevel(c *gin.Context) {
 		return
 	}
 
-	h.Success(c, customer)
+	h.SuccessMasked(c, customer, customerFinancialFields)
 }
 
 // CustomerCountByStatusResponse represents the customer count by status response
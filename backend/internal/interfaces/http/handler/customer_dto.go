@@ -3,32 +3,50 @@ package handler
 // CustomerResponse represents a customer in API responses
 // @Description Customer details returned by the API
 type CustomerResponse struct {
-	ID          string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	TenantID    string  `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Code        string  `json:"code" example:"CUST-001"`
-	Name        string  `json:"name" example:"Acme Corp"`
-	ShortName   string  `json:"short_name" example:"Acme"`
-	Type        string  `json:"type" example:"organization" enums:"individual,organization"`
-	Level       string  `json:"level" example:"normal" enums:"normal,silver,gold,platinum,vip"`
-	Status      string  `json:"status" example:"active" enums:"active,inactive,suspended"`
-	ContactName string  `json:"contact_name" example:"John Doe"`
-	Phone       string  `json:"phone" example:"13800138000"`
-	Email       string  `json:"email" example:"contact@acme.com"`
-	Address     string  `json:"address" example:"123 Main St"`
-	City        string  `json:"city" example:"Shanghai"`
-	Province    string  `json:"province" example:"Shanghai"`
-	PostalCode  string  `json:"postal_code" example:"200000"`
-	Country     string  `json:"country" example:"China"`
-	FullAddress string  `json:"full_address" example:"123 Main St, Shanghai, Shanghai 200000, China"`
-	TaxID       string  `json:"tax_id" example:"91310000MA1FL8L972"`
-	CreditLimit float64 `json:"credit_limit" example:"10000.00"`
-	Balance     float64 `json:"balance" example:"5000.00"`
-	Notes       string  `json:"notes" example:"VIP customer"`
-	SortOrder   int     `json:"sort_order" example:"0"`
-	Attributes  string  `json:"attributes" example:"{}"`
-	CreatedAt   string  `json:"created_at" example:"2026-01-24T12:00:00Z"`
-	UpdatedAt   string  `json:"updated_at" example:"2026-01-24T12:00:00Z"`
-	Version     int     `json:"version" example:"1"`
+	ID               string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TenantID         string  `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Code             string  `json:"code" example:"CUST-001"`
+	Name             string  `json:"name" example:"Acme Corp"`
+	ShortName        string  `json:"short_name" example:"Acme"`
+	Type             string  `json:"type" example:"organization" enums:"individual,organization"`
+	Level            string  `json:"level" example:"normal" enums:"normal,silver,gold,platinum,vip"`
+	Status           string  `json:"status" example:"active" enums:"active,inactive,suspended,merged"`
+	ContactName      string  `json:"contact_name" example:"John Doe"`
+	Phone            string  `json:"phone" example:"13800138000"`
+	Email            string  `json:"email" example:"contact@acme.com"`
+	Address          string  `json:"address" example:"123 Main St"`
+	City             string  `json:"city" example:"Shanghai"`
+	Province         string  `json:"province" example:"Shanghai"`
+	PostalCode       string  `json:"postal_code" example:"200000"`
+	Country          string  `json:"country" example:"China"`
+	FullAddress      string  `json:"full_address" example:"123 Main St, Shanghai, Shanghai 200000, China"`
+	TaxID            string  `json:"tax_id" example:"91310000MA1FL8L972"`
+	CreditLimit      float64 `json:"credit_limit" example:"10000.00"`
+	Balance          float64 `json:"balance" example:"5000.00"`
+	OverdraftLimit   float64 `json:"overdraft_limit" example:"0"`
+	SpendableBalance float64 `json:"spendable_balance" example:"5000.00"`
+	Notes            string  `json:"notes" example:"VIP customer"`
+	SortOrder        int     `json:"sort_order" example:"0"`
+	Attributes       string  `json:"attributes" example:"{}"`
+	MergedIntoID     string  `json:"merged_into_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	MergedAt         string  `json:"merged_at,omitempty" example:"2026-01-24T12:00:00Z"`
+	CreatedAt        string  `json:"created_at" example:"2026-01-24T12:00:00Z"`
+	UpdatedAt        string  `json:"updated_at" example:"2026-01-24T12:00:00Z"`
+	Version          int     `json:"version" example:"1"`
+}
+
+// CustomerMergeResponse represents the result of merging a duplicate customer
+// @Description Surviving customer and the records moved from the merged customer
+type CustomerMergeResponse struct {
+	Customer            CustomerResponse `json:"customer"`
+	MergedCustomerID    string           `json:"merged_customer_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	BalanceTransferred  float64          `json:"balance_transferred" example:"500.00"`
+	SalesOrders         int64            `json:"sales_orders" example:"12"`
+	SalesReturns        int64            `json:"sales_returns" example:"1"`
+	Receivables         int64            `json:"receivables" example:"3"`
+	ReceiptVouchers     int64            `json:"receipt_vouchers" example:"2"`
+	RefundRecords       int64            `json:"refund_records" example:"0"`
+	BalanceTransactions int64            `json:"balance_transactions" example:"4"`
 }
 
 // CustomerListResponse represents a customer list item
@@ -40,7 +58,7 @@ type CustomerListResponse struct {
 	ShortName string `json:"short_name" example:"Acme"`
 	Type      string `json:"type" example:"organization" enums:"individual,organization"`
 	Level     string `json:"level" example:"normal" enums:"normal,silver,gold,platinum,vip"`
-	Status    string `json:"status" example:"active" enums:"active,inactive,suspended"`
+	Status    string `json:"status" example:"active" enums:"active,inactive,suspended,merged"`
 	Phone     string `json:"phone" example:"13800138000"`
 	Email     string `json:"email" example:"contact@acme.com"`
 	City      string `json:"city" example:"Shanghai"`
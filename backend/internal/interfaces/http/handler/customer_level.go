@@ -37,34 +37,38 @@ func NewCustomerLevelHandler(levelService *partnerapp.CustomerLevelService) *Cus
 //
 //	@Description	Customer level details
 type CustomerLevelResponse struct {
-	ID              string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	TenantID        string  `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Code            string  `json:"code" example:"gold"`
-	Name            string  `json:"name" example:"金卡会员"`
-	DiscountRate    float64 `json:"discount_rate" example:"0.05"`
-	DiscountPercent float64 `json:"discount_percent" example:"5"`
-	SortOrder       int     `json:"sort_order" example:"2"`
-	IsDefault       bool    `json:"is_default" example:"false"`
-	IsActive        bool    `json:"is_active" example:"true"`
-	Description     string  `json:"description" example:"5% discount on all purchases"`
-	CustomerCount   int64   `json:"customer_count,omitempty" example:"150"`
-	CreatedAt       string  `json:"created_at" example:"2024-01-15T10:30:00Z"`
-	UpdatedAt       string  `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+	ID               string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TenantID         string  `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Code             string  `json:"code" example:"gold"`
+	Name             string  `json:"name" example:"金卡会员"`
+	DiscountRate     float64 `json:"discount_rate" example:"0.05"`
+	DiscountPercent  float64 `json:"discount_percent" example:"5"`
+	SortOrder        int     `json:"sort_order" example:"2"`
+	IsDefault        bool    `json:"is_default" example:"false"`
+	IsActive         bool    `json:"is_active" example:"true"`
+	Description      string  `json:"description" example:"5% discount on all purchases"`
+	UpgradeThreshold float64 `json:"upgrade_threshold" example:"50000.00"`
+	SpendWindowDays  int     `json:"spend_window_days" example:"365"`
+	CustomerCount    int64   `json:"customer_count,omitempty" example:"150"`
+	CreatedAt        string  `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt        string  `json:"updated_at" example:"2024-01-15T10:30:00Z"`
 }
 
 // CustomerLevelListResponse represents a customer level list item
 //
 //	@Description	Customer level list item with customer count
 type CustomerLevelListResponse struct {
-	ID              string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Code            string  `json:"code" example:"gold"`
-	Name            string  `json:"name" example:"金卡会员"`
-	DiscountRate    float64 `json:"discount_rate" example:"0.05"`
-	DiscountPercent float64 `json:"discount_percent" example:"5"`
-	SortOrder       int     `json:"sort_order" example:"2"`
-	IsDefault       bool    `json:"is_default" example:"false"`
-	IsActive        bool    `json:"is_active" example:"true"`
-	CustomerCount   int64   `json:"customer_count" example:"150"`
+	ID               string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Code             string  `json:"code" example:"gold"`
+	Name             string  `json:"name" example:"金卡会员"`
+	DiscountRate     float64 `json:"discount_rate" example:"0.05"`
+	DiscountPercent  float64 `json:"discount_percent" example:"5"`
+	SortOrder        int     `json:"sort_order" example:"2"`
+	IsDefault        bool    `json:"is_default" example:"false"`
+	IsActive         bool    `json:"is_active" example:"true"`
+	UpgradeThreshold float64 `json:"upgrade_threshold" example:"50000.00"`
+	SpendWindowDays  int     `json:"spend_window_days" example:"365"`
+	CustomerCount    int64   `json:"customer_count" example:"150"`
 }
 
 // =============================================================================
@@ -82,18 +86,24 @@ type CreateCustomerLevelRequest struct {
 	IsDefault    bool    `json:"is_default" example:"false"`
 	IsActive     bool    `json:"is_active" example:"true"`
 	Description  string  `json:"description" binding:"max=500" example:"5% discount on all purchases"`
+	// Spend over the window that promotes customers to this level; 0 keeps the level manual only
+	UpgradeThreshold float64 `json:"upgrade_threshold" binding:"gte=0" example:"50000.00"`
+	// Rolling window in days, also how long a promoted customer keeps the level (default 365)
+	SpendWindowDays *int `json:"spend_window_days" binding:"omitempty,min=1,max=3650" example:"365"`
 }
 
 // UpdateCustomerLevelRequest represents a request to update a customer level
 //
 //	@Description	Request body for updating a customer level
 type UpdateCustomerLevelRequest struct {
-	Name         *string  `json:"name" binding:"omitempty,min=1,max=100" example:"金卡会员"`
-	DiscountRate *float64 `json:"discount_rate" binding:"omitempty,gte=0,lte=1" example:"0.08"`
-	SortOrder    *int     `json:"sort_order" example:"3"`
-	IsDefault    *bool    `json:"is_default" example:"false"`
-	IsActive     *bool    `json:"is_active" example:"true"`
-	Description  *string  `json:"description" binding:"omitempty,max=500" example:"Updated description"`
+	Name             *string  `json:"name" binding:"omitempty,min=1,max=100" example:"金卡会员"`
+	DiscountRate     *float64 `json:"discount_rate" binding:"omitempty,gte=0,lte=1" example:"0.08"`
+	SortOrder        *int     `json:"sort_order" example:"3"`
+	IsDefault        *bool    `json:"is_default" example:"false"`
+	IsActive         *bool    `json:"is_active" example:"true"`
+	Description      *string  `json:"description" binding:"omitempty,max=500" example:"Updated description"`
+	UpgradeThreshold *float64 `json:"upgrade_threshold" binding:"omitempty,gte=0" example:"80000.00"`
+	SpendWindowDays  *int     `json:"spend_window_days" binding:"omitempty,min=1,max=3650" example:"180"`
 }
 
 // Create godoc
@@ -127,13 +137,15 @@ func (h *CustomerLevelHandler) Create(c *gin.Context) {
 	}
 
 	appReq := partnerapp.CreateCustomerLevelRequest{
-		Code:         req.Code,
-		Name:         req.Name,
-		DiscountRate: req.DiscountRate,
-		SortOrder:    req.SortOrder,
-		IsDefault:    req.IsDefault,
-		IsActive:     req.IsActive,
-		Description:  req.Description,
+		Code:             req.Code,
+		Name:             req.Name,
+		DiscountRate:     req.DiscountRate,
+		SortOrder:        req.SortOrder,
+		IsDefault:        req.IsDefault,
+		IsActive:         req.IsActive,
+		Description:      req.Description,
+		UpgradeThreshold: req.UpgradeThreshold,
+		SpendWindowDays:  req.SpendWindowDays,
 	}
 
 	level, err := h.levelService.Create(c.Request.Context(), tenantID, appReq)
@@ -327,12 +339,14 @@ func (h *CustomerLevelHandler) Update(c *gin.Context) {
 	}
 
 	appReq := partnerapp.UpdateCustomerLevelRequest{
-		Name:         req.Name,
-		DiscountRate: req.DiscountRate,
-		SortOrder:    req.SortOrder,
-		IsDefault:    req.IsDefault,
-		IsActive:     req.IsActive,
-		Description:  req.Description,
+		Name:             req.Name,
+		DiscountRate:     req.DiscountRate,
+		SortOrder:        req.SortOrder,
+		IsDefault:        req.IsDefault,
+		IsActive:         req.IsActive,
+		Description:      req.Description,
+		UpgradeThreshold: req.UpgradeThreshold,
+		SpendWindowDays:  req.SpendWindowDays,
 	}
 
 	level, err := h.levelService.Update(c.Request.Context(), tenantID, levelID, appReq)
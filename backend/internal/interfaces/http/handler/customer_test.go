@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	partnerapp "github.com/erp/backend/internal/application/partner"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/infrastructure/auth"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCustomerRepository serves a single customer
+type stubCustomerRepository struct {
+	partner.CustomerRepository
+	customer *partner.Customer
+}
+
+func (r *stubCustomerRepository) FindByIDForTenant(_ context.Context, _, _ uuid.UUID) (*partner.Customer, error) {
+	return r.customer, nil
+}
+
+func (r *stubCustomerRepository) FindAllForTenant(_ context.Context, _ uuid.UUID, _ shared.Filter) ([]partner.Customer, error) {
+	return []partner.Customer{*r.customer}, nil
+}
+
+func (r *stubCustomerRepository) CountForTenant(_ context.Context, _ uuid.UUID, _ shared.Filter) (int64, error) {
+	return 1, nil
+}
+
+// setupCustomerTestRouter creates a router serving customers to a principal with the given permissions
+func setupCustomerTestRouter(t *testing.T, permissions ...string) (*gin.Engine, *partner.Customer) {
+	t.Helper()
+	tenantID := uuid.New()
+
+	customer, err := partner.NewCustomer(tenantID, "CUST-001", "Acme Corp", partner.CustomerTypeOrganization)
+	require.NoError(t, err)
+	require.NoError(t, customer.SetCreditLimit(decimal.NewFromInt(10000)))
+	require.NoError(t, customer.AddBalance(decimal.NewFromInt(500)))
+
+	h := NewCustomerHandler(partnerapp.NewCustomerService(&stubCustomerRepository{customer: customer}))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		setJWTContext(c, tenantID, uuid.New())
+		c.Set(middleware.JWTClaimsKey, &auth.Claims{
+			TenantID:    tenantID.String(),
+			Permissions: permissions,
+		})
+		c.Next()
+	})
+	router.GET("/customers", h.List)
+	router.GET("/customers/:id", h.GetByID)
+	return router, customer
+}
+
+// getCustomerJSON performs a GET request and returns the data of the response
+func getCustomerJSON(t *testing.T, router *gin.Engine, path string) any {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Data
+}
+
+func TestCustomerHandler_FinancialFieldMasking(t *testing.T) {
+	t.Run("omits financial fields without customer:view_financials", func(t *testing.T) {
+		router, customer := setupCustomerTestRouter(t, "customer:read")
+
+		data := getCustomerJSON(t, router, "/customers/"+customer.ID.String()).(map[string]any)
+		assert.Equal(t, "CUST-001", data["code"])
+		assert.NotContains(t, data, "balance")
+		assert.NotContains(t, data, "credit_limit")
+		assert.NotContains(t, data, "overdraft_limit")
+		assert.NotContains(t, data, "spendable_balance")
+
+		list := getCustomerJSON(t, router, "/customers").([]any)
+		require.Len(t, list, 1)
+		item := list[0].(map[string]any)
+		assert.Equal(t, "Acme Corp", item["name"])
+		assert.NotContains(t, item, "balance")
+		assert.NotContains(t, item, "credit_limit")
+	})
+
+	t.Run("includes financial fields with customer:view_financials", func(t *testing.T) {
+		router, customer := setupCustomerTestRouter(t, "customer:read", "customer:view_financials")
+
+		data := getCustomerJSON(t, router, "/customers/"+customer.ID.String()).(map[string]any)
+		assert.Equal(t, "500", data["balance"])
+		assert.Equal(t, "10000", data["credit_limit"])
+		assert.Equal(t, "0", data["overdraft_limit"])
+		assert.Equal(t, "500", data["spendable_balance"])
+
+		list := getCustomerJSON(t, router, "/customers").([]any)
+		require.Len(t, list, 1)
+		assert.Equal(t, "500", list[0].(map[string]any)["balance"])
+	})
+}
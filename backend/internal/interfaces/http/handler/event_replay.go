@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"github.com/erp/backend/internal/application/event"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EventReplayHandler handles event replay HTTP requests
+type EventReplayHandler struct {
+	BaseHandler
+	replayService *event.ReplayService
+}
+
+// NewEventReplayHandler creates a new event replay handler
+func NewEventReplayHandler(replayService *event.ReplayService) *EventReplayHandler {
+	return &EventReplayHandler{
+		replayService: replayService,
+	}
+}
+
+// ReplayEventsRequest represents the request body for replaying events
+type ReplayEventsRequest struct {
+	TenantID      string   `json:"tenant_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	EventTypes    []string `json:"event_types,omitempty" example:"SupplierUpdated"`
+	AggregateType string   `json:"aggregate_type,omitempty" example:"Supplier"`
+	From          string   `json:"from" binding:"required" example:"2026-01-01T00:00:00Z"`
+	To            string   `json:"to" binding:"required" example:"2026-02-01T00:00:00Z"`
+	DryRun        bool     `json:"dry_run"`
+}
+
+// ReplayErrorResponse represents an event that failed to replay
+type ReplayErrorResponse struct {
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+	Error     string `json:"error"`
+}
+
+// ReplayEventsResponse represents the outcome of an event replay
+type ReplayEventsResponse struct {
+	Matched  int                   `json:"matched"`
+	Replayed int                   `json:"replayed"`
+	Skipped  int                   `json:"skipped"`
+	Failed   int                   `json:"failed"`
+	Errors   []ReplayErrorResponse `json:"errors"`
+	DryRun   bool                  `json:"dry_run"`
+}
+
+// ReplayEvents godoc
+//
+//	@ID				replaySystemEvents
+//	@Summary		Replay delivered events
+//	@Description	Re-dispatch delivered outbox events in a time window to the read model handlers, oldest first.
+//	@Description	Old payloads are upgraded to the current schema version. Handlers see the events as replayed
+//	@Description	and skip side effects such as creating receivables or moving stock. Requires event:replay permission.
+//	@Tags			outbox
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ReplayEventsRequest	true	"Replay filter"
+//	@Success		200		{object}	APIResponse[ReplayEventsResponse]
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/system/events/replay [post]
+func (h *EventReplayHandler) ReplayEvents(c *gin.Context) {
+	var req ReplayEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	from, err := parseDateTime(req.From)
+	if err != nil {
+		h.BadRequest(c, "Invalid from format")
+		return
+	}
+	to, err := parseDateTime(req.To)
+	if err != nil {
+		h.BadRequest(c, "Invalid to format")
+		return
+	}
+
+	filter := event.ReplayFilter{
+		EventTypes:    req.EventTypes,
+		AggregateType: req.AggregateType,
+		From:          from,
+		To:            to,
+		DryRun:        req.DryRun,
+	}
+	if req.TenantID != "" {
+		tenantID, err := uuid.Parse(req.TenantID)
+		if err != nil {
+			h.BadRequest(c, "Invalid tenant ID")
+			return
+		}
+		filter.TenantID = &tenantID
+	}
+
+	result, err := h.replayService.ReplayEvents(c.Request.Context(), filter)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	h.Success(c, toReplayEventsResponse(result))
+}
+
+func toReplayEventsResponse(result *event.ReplayResult) ReplayEventsResponse {
+	errors := make([]ReplayErrorResponse, len(result.Errors))
+	for i, e := range result.Errors {
+		errors[i] = ReplayErrorResponse{
+			EventID:   e.EventID.String(),
+			EventType: e.EventType,
+			Error:     e.Error,
+		}
+	}
+	return ReplayEventsResponse{
+		Matched:  result.Matched,
+		Replayed: result.Replayed,
+		Skipped:  result.Skipped,
+		Failed:   result.Failed,
+		Errors:   errors,
+		DryRun:   result.DryRun,
+	}
+}
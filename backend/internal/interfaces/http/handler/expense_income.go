@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"io"
+	"mime"
 	"net/http"
 	"time"
 
 	financeapp "github.com/erp/backend/internal/application/finance"
+	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/interfaces/http/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -55,6 +58,37 @@ type ExpenseRecordResponse struct {
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	Version         int        `json:"version" example:"1"`
+
+	// Approval chain; only meaningful once the expense has been submitted
+	RequiredApprovals    int                       `json:"required_approvals" example:"2"`
+	CurrentApprovalLevel int                       `json:"current_approval_level" example:"1"`
+	RemainingApprovals   int                       `json:"remaining_approvals" example:"1"`
+	Approvals            []ExpenseApprovalResponse `json:"approvals,omitempty"`
+
+	Attachments []ExpenseAttachmentResponse `json:"attachments,omitempty"`
+}
+
+// ExpenseApprovalResponse represents one signed-off approval level of an expense
+//
+//	@Description	Expense approval level response
+type ExpenseApprovalResponse struct {
+	Level      int       `json:"level" example:"1"`
+	ApprovedBy string    `json:"approved_by" example:"550e8400-e29b-41d4-a716-446655440002"`
+	ApprovedAt time.Time `json:"approved_at"`
+	Remark     string    `json:"remark,omitempty" example:"同意"`
+}
+
+// ExpenseAttachmentResponse represents a supporting document attached to an expense
+//
+//	@Description	Expense attachment response
+type ExpenseAttachmentResponse struct {
+	ID          string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	FileName    string    `json:"file_name" example:"invoice.pdf"`
+	ContentType string    `json:"content_type" example:"application/pdf"`
+	FileSize    int64     `json:"file_size" example:"102400"`
+	StorageKey  string    `json:"storage_key" example:"550e8400-e29b-41d4-a716-446655440001/2026/01/550e8400-e29b-41d4-a716-446655440003.pdf"`
+	UploadedBy  string    `json:"uploaded_by" example:"550e8400-e29b-41d4-a716-446655440002"`
+	UploadedAt  time.Time `json:"uploaded_at"`
 }
 
 // CreateExpenseRecordRequest represents a request to create an expense record
@@ -560,7 +594,7 @@ func (h *ExpenseIncomeHandler) SubmitExpense(c *gin.Context) {
 //	@ID				approveExpenseExpens
 //
 //	@Summary		Approve expense
-//	@Description	Approve an expense record
+//	@Description	Sign off the current approval level of an expense record. Expenses above the tenant's approval thresholds need one approval per level from different users before they are approved.
 //	@Tags			expenses
 //	@Accept			json
 //	@Produce		json
@@ -570,6 +604,7 @@ func (h *ExpenseIncomeHandler) SubmitExpense(c *gin.Context) {
 //	@Failure		400		{object}	dto.ErrorResponse
 //	@Failure		401		{object}	dto.ErrorResponse
 //	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ErrorResponse
 //	@Failure		500		{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/finance/expenses/{id}/approve [post]
@@ -754,6 +789,137 @@ func (h *ExpenseIncomeHandler) MarkExpensePaid(c *gin.Context) {
 	h.Success(c, h.toExpenseRecordResponse(*expense))
 }
 
+// AddExpenseAttachment godoc
+//
+//	@ID				addExpenseExpensAttachment
+//
+//	@Summary		Attach a supporting document to an expense
+//	@Description	Upload a receipt or invoice (PDF, JPEG, PNG, GIF or WebP, up to 10MB) to a draft or pending expense
+//	@Tags			expenses
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Expense ID"
+//	@Param			file	formData	file	true	"Supporting document"
+//	@Success		201		{object}	APIResponse[ExpenseAttachmentResponse]
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		413		{object}	dto.ErrorResponse
+//	@Failure		415		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/expenses/{id}/attachments [post]
+func (h *ExpenseIncomeHandler) AddExpenseAttachment(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil || tenantID == uuid.Nil {
+		h.Error(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid tenant")
+		return
+	}
+
+	userID, err := getUserID(c)
+	if err != nil || userID == uuid.Nil {
+		h.Error(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid user")
+		return
+	}
+
+	expenseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, http.StatusBadRequest, "INVALID_ID", "Invalid expense ID")
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		h.BadRequest(c, "file is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > finance.MaxExpenseAttachmentSize {
+		h.Error(c, http.StatusRequestEntityTooLarge, dto.ErrCodeValidation, "file exceeds maximum size of 10MB")
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, finance.MaxExpenseAttachmentSize+1))
+	if err != nil {
+		h.BadRequest(c, "failed to read file")
+		return
+	}
+	if len(data) > finance.MaxExpenseAttachmentSize {
+		h.Error(c, http.StatusRequestEntityTooLarge, dto.ErrCodeValidation, "file exceeds maximum size of 10MB")
+		return
+	}
+
+	// Trust the declared content type only when it is specific; otherwise sniff it
+	contentType, _, _ := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+	if !finance.IsAllowedExpenseAttachmentContentType(contentType) {
+		h.Error(c, http.StatusUnsupportedMediaType, dto.ErrCodeValidation, "file must be a PDF, JPEG, PNG, GIF or WebP file")
+		return
+	}
+
+	attachment, err := h.service.AddExpenseAttachment(c.Request.Context(), tenantID, expenseID, financeapp.AddExpenseAttachmentRequest{
+		FileName:    header.Filename,
+		ContentType: contentType,
+		Data:        data,
+		UploadedBy:  userID,
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Created(c, toExpenseAttachmentResponse(*attachment))
+}
+
+// RemoveExpenseAttachment godoc
+//
+//	@ID				removeExpenseExpensAttachment
+//
+//	@Summary		Remove an attachment from an expense
+//	@Description	Remove a supporting document from a draft or pending expense. Attachments of approved expenses cannot be removed.
+//	@Tags			expenses
+//	@Produce		json
+//	@Param			id				path		string	true	"Expense ID"
+//	@Param			attachmentId	path		string	true	"Attachment ID"
+//	@Success		200				{object}	SuccessResponse
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		404				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/expenses/{id}/attachments/{attachmentId} [delete]
+func (h *ExpenseIncomeHandler) RemoveExpenseAttachment(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil || tenantID == uuid.Nil {
+		h.Error(c, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid tenant")
+		return
+	}
+
+	expenseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.Error(c, http.StatusBadRequest, "INVALID_ID", "Invalid expense ID")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		h.Error(c, http.StatusBadRequest, "INVALID_ID", "Invalid attachment ID")
+		return
+	}
+
+	if err := h.service.RemoveExpenseAttachment(c.Request.Context(), tenantID, expenseID, attachmentID); err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, nil)
+}
+
 // GetExpensesSummary godoc
 //
 //	@ID				getExpensExpensesSummary
@@ -1406,6 +1572,21 @@ func (h *ExpenseIncomeHandler) toExpenseRecordResponse(exp financeapp.ExpenseRec
 		rejectedBy = &s
 	}
 
+	approvals := make([]ExpenseApprovalResponse, len(exp.Approvals))
+	for i, a := range exp.Approvals {
+		approvals[i] = ExpenseApprovalResponse{
+			Level:      a.Level,
+			ApprovedBy: a.ApprovedBy.String(),
+			ApprovedAt: a.ApprovedAt,
+			Remark:     a.Remark,
+		}
+	}
+
+	attachments := make([]ExpenseAttachmentResponse, len(exp.Attachments))
+	for i, a := range exp.Attachments {
+		attachments[i] = toExpenseAttachmentResponse(a)
+	}
+
 	return ExpenseRecordResponse{
 		ID:              exp.ID.String(),
 		TenantID:        exp.TenantID.String(),
@@ -1432,6 +1613,25 @@ func (h *ExpenseIncomeHandler) toExpenseRecordResponse(exp financeapp.ExpenseRec
 		CreatedAt:       exp.CreatedAt,
 		UpdatedAt:       exp.UpdatedAt,
 		Version:         exp.Version,
+
+		RequiredApprovals:    exp.RequiredApprovals,
+		CurrentApprovalLevel: exp.CurrentApprovalLevel,
+		RemainingApprovals:   exp.RemainingApprovals,
+		Approvals:            approvals,
+
+		Attachments: attachments,
+	}
+}
+
+func toExpenseAttachmentResponse(a financeapp.ExpenseAttachmentResponse) ExpenseAttachmentResponse {
+	return ExpenseAttachmentResponse{
+		ID:          a.ID.String(),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		FileSize:    a.FileSize,
+		StorageKey:  a.StorageKey,
+		UploadedBy:  a.UploadedBy.String(),
+		UploadedAt:  a.UploadedAt,
 	}
 }
 
@@ -1481,6 +1681,8 @@ func (h *ExpenseIncomeHandler) RegisterRoutes(rg *gin.RouterGroup) {
 		expenses.POST("/:id/reject", h.RejectExpense)
 		expenses.POST("/:id/cancel", h.CancelExpense)
 		expenses.POST("/:id/pay", h.MarkExpensePaid)
+		expenses.POST("/:id/attachments", h.AddExpenseAttachment)
+		expenses.DELETE("/:id/attachments/:attachmentId", h.RemoveExpenseAttachment)
 	}
 
 	// Income routes
@@ -40,25 +40,27 @@ func NewFeatureFlagHandler(
 //
 //	@Description	Request body for creating a new feature flag
 type CreateFlagHTTPRequest struct {
-	Key          string                 `json:"key" binding:"required,min=1,max=100" example:"new_checkout_flow"`
-	Name         string                 `json:"name" binding:"required,min=1,max=200" example:"New Checkout Flow"`
-	Description  string                 `json:"description,omitempty" example:"Enables the new checkout flow for users"`
-	Type         string                 `json:"type" binding:"required,oneof=boolean percentage variant user_segment" example:"boolean"`
-	DefaultValue dto.FlagValueDTO       `json:"default_value"`
-	Rules        []dto.TargetingRuleDTO `json:"rules,omitempty"`
-	Tags         []string               `json:"tags,omitempty" example:"checkout,experiment"`
+	Key           string                 `json:"key" binding:"required,min=1,max=100" example:"new_checkout_flow"`
+	Name          string                 `json:"name" binding:"required,min=1,max=200" example:"New Checkout Flow"`
+	Description   string                 `json:"description,omitempty" example:"Enables the new checkout flow for users"`
+	Type          string                 `json:"type" binding:"required,oneof=boolean percentage variant user_segment" example:"boolean"`
+	DefaultValue  dto.FlagValueDTO       `json:"default_value"`
+	Rules         []dto.TargetingRuleDTO `json:"rules,omitempty"`
+	Tags          []string               `json:"tags,omitempty" example:"checkout,experiment"`
+	Prerequisites []dto.PrerequisiteDTO  `json:"prerequisites,omitempty" binding:"omitempty,dive"`
 }
 
 // UpdateFlagHTTPRequest represents the HTTP request body for updating a flag
 //
 //	@Description	Request body for updating a feature flag
 type UpdateFlagHTTPRequest struct {
-	Name         *string                 `json:"name,omitempty" example:"Updated Name"`
-	Description  *string                 `json:"description,omitempty" example:"Updated description"`
-	DefaultValue *dto.FlagValueDTO       `json:"default_value,omitempty"`
-	Rules        *[]dto.TargetingRuleDTO `json:"rules,omitempty"`
-	Tags         *[]string               `json:"tags,omitempty"`
-	Version      *int                    `json:"version,omitempty"` // For optimistic locking
+	Name          *string                 `json:"name,omitempty" example:"Updated Name"`
+	Description   *string                 `json:"description,omitempty" example:"Updated description"`
+	DefaultValue  *dto.FlagValueDTO       `json:"default_value,omitempty"`
+	Rules         *[]dto.TargetingRuleDTO `json:"rules,omitempty"`
+	Tags          *[]string               `json:"tags,omitempty"`
+	Prerequisites *[]dto.PrerequisiteDTO  `json:"prerequisites,omitempty" binding:"omitempty,dive"`
+	Version       *int                    `json:"version,omitempty"` // For optimistic locking
 }
 
 // EvaluateFlagHTTPRequest represents the HTTP request body for evaluating a flag
@@ -195,13 +197,14 @@ func (h *FeatureFlagHandler) CreateFlag(c *gin.Context) {
 
 	// Convert to application DTO
 	appReq := dto.CreateFlagRequest{
-		Key:          req.Key,
-		Name:         req.Name,
-		Description:  req.Description,
-		Type:         req.Type,
-		DefaultValue: req.DefaultValue,
-		Rules:        req.Rules,
-		Tags:         req.Tags,
+		Key:           req.Key,
+		Name:          req.Name,
+		Description:   req.Description,
+		Type:          req.Type,
+		DefaultValue:  req.DefaultValue,
+		Rules:         req.Rules,
+		Tags:          req.Tags,
+		Prerequisites: req.Prerequisites,
 	}
 
 	result, err := h.flagService.CreateFlag(c.Request.Context(), appReq, auditCtx)
@@ -282,12 +285,13 @@ func (h *FeatureFlagHandler) UpdateFlag(c *gin.Context) {
 
 	// Convert to application DTO
 	appReq := dto.UpdateFlagRequest{
-		Name:         req.Name,
-		Description:  req.Description,
-		DefaultValue: req.DefaultValue,
-		Rules:        req.Rules,
-		Tags:         req.Tags,
-		Version:      req.Version,
+		Name:          req.Name,
+		Description:   req.Description,
+		DefaultValue:  req.DefaultValue,
+		Rules:         req.Rules,
+		Tags:          req.Tags,
+		Prerequisites: req.Prerequisites,
+		Version:       req.Version,
 	}
 
 	result, err := h.flagService.UpdateFlag(c.Request.Context(), key, appReq, auditCtx)
@@ -737,6 +741,8 @@ func (h *FeatureFlagHandler) DeleteOverride(c *gin.Context) {
 //	@Param			page		query		int		false	"Page number"	default(1)
 //	@Param			page_size	query		int		false	"Page size"		default(20)	maximum(100)
 //	@Param			action		query		string	false	"Filter by action"
+//	@Param			pagination	query		string	false	"Pagination mode; cursor returns next_cursor instead of totals"	Enums(offset, cursor)	default(offset)
+//	@Param			cursor		query		string	false	"next_cursor from the previous page (implies pagination=cursor)"
 //	@Success		200			{object}	APIResponse[dto.AuditLogListResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
@@ -291,6 +291,22 @@ func (m *MockOutboxRepository) DeleteOlderThan(ctx context.Context, before time.
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockOutboxRepository) FindOldestDead(ctx context.Context, limit int) ([]*shared.OutboxEntry, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shared.OutboxEntry), args.Error(1)
+}
+
+func (m *MockOutboxRepository) FindForReplay(ctx context.Context, filter shared.OutboxReplayFilter) ([]*shared.OutboxEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*shared.OutboxEntry), args.Error(1)
+}
+
 func (m *MockOutboxRepository) CountByStatus(ctx context.Context) (map[shared.OutboxStatus]int64, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(map[shared.OutboxStatus]int64), args.Error(1)
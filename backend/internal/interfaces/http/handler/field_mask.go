@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SensitiveField is a response field only principals with Permission may see
+type SensitiveField struct {
+	// Field is the JSON name of the field
+	Field string
+	// Permission is the permission code required to see the field
+	Permission string
+}
+
+// FieldMask declares the sensitive fields of a response.
+// RequirePermission gates whole endpoints; a FieldMask hides single fields of
+// the response from principals that may call the endpoint but lack the
+// permission of the field.
+type FieldMask []SensitiveField
+
+// hiddenFields returns the JSON names of the fields the principal may not see
+func (m FieldMask) hiddenFields(c *gin.Context) map[string]struct{} {
+	hidden := make(map[string]struct{})
+	for _, f := range m {
+		if !middleware.HasPermission(c, f.Permission) {
+			hidden[f.Field] = struct{}{}
+		}
+	}
+	return hidden
+}
+
+// Apply returns data with the fields the principal may not see omitted.
+// Fields are omitted after serialization, from the top-level object of data or
+// from each object if data is a list. If the principal may see every field,
+// data is returned unchanged.
+func (m FieldMask) Apply(c *gin.Context, data any) (any, error) {
+	hidden := m.hiddenFields(c)
+	if len(hidden) == 0 {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// UseNumber keeps numbers exactly as serialized
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		omitFields(v, hidden)
+	case []any:
+		for _, item := range v {
+			if obj, ok := item.(map[string]any); ok {
+				omitFields(obj, hidden)
+			}
+		}
+	}
+	return value, nil
+}
+
+// omitFields deletes the hidden fields from a serialized object
+func omitFields(obj map[string]any, hidden map[string]struct{}) {
+	for field := range hidden {
+		delete(obj, field)
+	}
+}
+
+// SuccessMasked sends a success response with the fields the principal may not see omitted
+func (h *BaseHandler) SuccessMasked(c *gin.Context, data any, mask FieldMask) {
+	masked, err := mask.Apply(c, data)
+	if err != nil {
+		h.InternalError(c, "Failed to serialize response")
+		return
+	}
+	h.Success(c, masked)
+}
+
+// SuccessWithMetaMasked sends a success response with pagination meta and the
+// fields the principal may not see omitted
+func (h *BaseHandler) SuccessWithMetaMasked(c *gin.Context, data any, total int64, page, pageSize int, mask FieldMask) {
+	masked, err := mask.Apply(c, data)
+	if err != nil {
+		h.InternalError(c, "Failed to serialize response")
+		return
+	}
+	h.SuccessWithMeta(c, masked, total, page, pageSize)
+}
+
+// CreatedMasked sends a 201 created response with the fields the principal may not see omitted
+func (h *BaseHandler) CreatedMasked(c *gin.Context, data any, mask FieldMask) {
+	masked, err := mask.Apply(c, data)
+	if err != nil {
+		h.InternalError(c, "Failed to serialize response")
+		return
+	}
+	h.Created(c, masked)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erp/backend/internal/infrastructure/auth"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMaskTestContext creates a gin context for a principal with the given permissions
+func newMaskTestContext(permissions ...string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(middleware.JWTClaimsKey, &auth.Claims{Permissions: permissions})
+	return c
+}
+
+type maskTestItem struct {
+	Name    string          `json:"name"`
+	Balance decimal.Decimal `json:"balance"`
+	Cost    int64           `json:"cost"`
+}
+
+func TestFieldMask_Apply(t *testing.T) {
+	mask := FieldMask{
+		{Field: "balance", Permission: "customer:view_financials"},
+		{Field: "cost", Permission: "product:view_cost"},
+	}
+	item := maskTestItem{Name: "Acme", Balance: decimal.NewFromInt(500), Cost: 9007199254740993}
+
+	t.Run("returns data unchanged with all permissions", func(t *testing.T) {
+		c := newMaskTestContext("customer:view_financials", "product:view_cost")
+
+		masked, err := mask.Apply(c, item)
+
+		require.NoError(t, err)
+		assert.Equal(t, item, masked)
+	})
+
+	t.Run("omits fields of an object without their permission", func(t *testing.T) {
+		c := newMaskTestContext("product:view_cost")
+
+		masked, err := mask.Apply(c, &item)
+		require.NoError(t, err)
+
+		raw, err := json.Marshal(masked)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"Acme","cost":9007199254740993}`, string(raw))
+	})
+
+	t.Run("omits fields of each object in a list", func(t *testing.T) {
+		c := newMaskTestContext()
+
+		masked, err := mask.Apply(c, []maskTestItem{item, item})
+		require.NoError(t, err)
+
+		raw, err := json.Marshal(masked)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"name":"Acme"},{"name":"Acme"}]`, string(raw))
+	})
+
+	t.Run("principal without claims sees no sensitive fields", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+		masked, err := mask.Apply(c, item)
+		require.NoError(t, err)
+
+		raw, err := json.Marshal(masked)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"Acme"}`, string(raw))
+	})
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	financeapp "github.com/erp/backend/internal/application/finance"
+	"github.com/erp/backend/internal/domain/finance"
 	"github.com/erp/backend/internal/interfaces/http/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -37,6 +41,7 @@ type AccountReceivableResponse struct {
 	SourceType        string                  `json:"source_type" example:"SALES_ORDER"`
 	SourceID          string                  `json:"source_id" example:"550e8400-e29b-41d4-a716-446655440003"`
 	SourceNumber      string                  `json:"source_number" example:"SO-2026-00001"`
+	Currency          string                  `json:"currency" example:"CNY"`
 	TotalAmount       float64                 `json:"total_amount" example:"1000.00"`
 	PaidAmount        float64                 `json:"paid_amount" example:"500.00"`
 	OutstandingAmount float64                 `json:"outstanding_amount" example:"500.00"`
@@ -45,6 +50,10 @@ type AccountReceivableResponse struct {
 	PaymentRecords    []PaymentRecordResponse `json:"payment_records,omitempty"`
 	Remark            string                  `json:"remark,omitempty" example:"备注"`
 	PaidAt            *time.Time              `json:"paid_at,omitempty"`
+	WrittenOffAmount  float64                 `json:"written_off_amount" example:"0"`
+	WrittenOffAt      *time.Time              `json:"written_off_at,omitempty"`
+	WrittenOffBy      *string                 `json:"written_off_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440004"`
+	WriteOffReason    string                  `json:"write_off_reason,omitempty" example:"客户破产，无法收回"`
 	CreatedAt         time.Time               `json:"created_at"`
 	UpdatedAt         time.Time               `json:"updated_at"`
 	Version           int                     `json:"version" example:"1"`
@@ -54,11 +63,12 @@ type AccountReceivableResponse struct {
 //
 //	@Description	Payment record response
 type PaymentRecordResponse struct {
-	ID               string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	ReceiptVoucherID string    `json:"receipt_voucher_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Amount           float64   `json:"amount" example:"500.00"`
-	AppliedAt        time.Time `json:"applied_at"`
-	Remark           string    `json:"remark,omitempty" example:"收款记录"`
+	ID                   string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ReceiptVoucherID     string    `json:"receipt_voucher_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ReceiptVoucherNumber string    `json:"receipt_voucher_number,omitempty" example:"RV-2024-0001"`
+	Amount               float64   `json:"amount" example:"500.00"`
+	AppliedAt            time.Time `json:"applied_at"`
+	Remark               string    `json:"remark,omitempty" example:"收款记录"`
 }
 
 // AccountPayableResponse represents an account payable in API responses
@@ -73,6 +83,7 @@ type AccountPayableResponse struct {
 	SourceType        string                         `json:"source_type" example:"PURCHASE_ORDER"`
 	SourceID          string                         `json:"source_id" example:"550e8400-e29b-41d4-a716-446655440003"`
 	SourceNumber      string                         `json:"source_number" example:"PO-2026-00001"`
+	Currency          string                         `json:"currency" example:"CNY"`
 	TotalAmount       float64                        `json:"total_amount" example:"2000.00"`
 	PaidAmount        float64                        `json:"paid_amount" example:"1000.00"`
 	OutstandingAmount float64                        `json:"outstanding_amount" example:"1000.00"`
@@ -81,6 +92,10 @@ type AccountPayableResponse struct {
 	PaymentRecords    []PayablePaymentRecordResponse `json:"payment_records,omitempty"`
 	Remark            string                         `json:"remark,omitempty" example:"备注"`
 	PaidAt            *time.Time                     `json:"paid_at,omitempty"`
+	InvoiceNumber     string                         `json:"invoice_number,omitempty" example:"INV-2026-0042"`
+	InvoiceAmount     float64                        `json:"invoice_amount" example:"2000.00"`
+	InvoiceDate       *time.Time                     `json:"invoice_date,omitempty"`
+	MatchStatus       string                         `json:"match_status,omitempty" example:"MATCHED" enums:"MATCHED,MISMATCHED"`
 	CreatedAt         time.Time                      `json:"created_at"`
 	UpdatedAt         time.Time                      `json:"updated_at"`
 	Version           int                            `json:"version" example:"1"`
@@ -182,6 +197,26 @@ type CreateReceiptVoucherRequest struct {
 	Remark           string  `json:"remark" example:"收款备注"`
 }
 
+// BatchReceiptRowRequest represents one receipt in a batch request
+//
+//	@Description	A single receipt row, e.g. one line of a bank settlement file
+type BatchReceiptRowRequest struct {
+	CustomerID       string  `json:"customer_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Amount           float64 `json:"amount" binding:"required,gt=0" example:"1000.00"`
+	PaymentMethod    string  `json:"payment_method" binding:"required" example:"BANK_TRANSFER"`
+	PaymentReference string  `json:"payment_reference" example:"BANK-20260124-0001"`
+	ReceiptDate      string  `json:"receipt_date" binding:"required" example:"2026-01-24"`
+	Remark           string  `json:"remark" example:"银行结算导入"`
+}
+
+// BatchReceiptVoucherRequest represents a request to create and reconcile receipt vouchers in bulk
+//
+//	@Description	Request body for creating, confirming and reconciling receipt vouchers in bulk
+type BatchReceiptVoucherRequest struct {
+	StrategyType string                   `json:"strategy_type" example:"FIFO" enums:"FIFO,LIFO,LARGEST_FIRST"`
+	Rows         []BatchReceiptRowRequest `json:"rows" binding:"required,min=1,max=500,dive"`
+}
+
 // CreatePaymentVoucherRequest represents a request to create a payment voucher
 //
 //	@Description	Request body for creating a payment voucher
@@ -202,14 +237,38 @@ type CancelVoucherRequest struct {
 	Reason string `json:"reason" binding:"required,min=1,max=500" example:"客户取消"`
 }
 
+// WriteOffReceivableRequest represents a request to write off a receivable
+//
+//	@Description	Request body for writing off a receivable as bad debt
+type WriteOffReceivableRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500" example:"客户破产，无法收回"`
+}
+
+// RecordPayableInvoiceRequest represents a supplier invoice recorded against a payable
+//
+//	@Description	Request body for recording a supplier invoice for the three-way match
+type RecordPayableInvoiceRequest struct {
+	InvoiceNumber string  `json:"invoice_number" binding:"required,min=1,max=50" example:"INV-2026-0042"`
+	InvoiceAmount float64 `json:"invoice_amount" binding:"required,gt=0" example:"2000.00"`
+	InvoiceDate   string  `json:"invoice_date" example:"2026-01-24"` // Defaults to today
+}
+
 // ReconcileRequest represents a request to reconcile a voucher
 //
 //	@Description	Request body for reconciling a voucher
 type ReconcileRequest struct {
-	StrategyType      string                         `json:"strategy_type" binding:"required" example:"FIFO"`
+	StrategyType      string                         `json:"strategy_type" binding:"required" example:"FIFO" enums:"FIFO,LIFO,LARGEST_FIRST,MANUAL"`
 	ManualAllocations []ManualAllocationInputRequest `json:"manual_allocations,omitempty"`
 }
 
+// UnreconcileRequest represents a request to undo a receipt voucher allocation
+//
+//	@Description	Request body for removing an allocation from a receipt voucher
+type UnreconcileRequest struct {
+	AllocationID string `json:"allocation_id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Reason       string `json:"reason" binding:"max=500" example:"核销到错误的应收单"`
+}
+
 // ManualAllocationInputRequest represents a manual allocation input
 //
 //	@Description	Manual allocation input for reconciliation
@@ -222,22 +281,91 @@ type ManualAllocationInputRequest struct {
 //
 //	@Description	Receivable summary response
 type ReceivableSummaryResponse struct {
-	TotalOutstanding float64 `json:"total_outstanding" example:"50000.00"`
-	TotalOverdue     float64 `json:"total_overdue" example:"10000.00"`
-	PendingCount     int64   `json:"pending_count" example:"10"`
-	PartialCount     int64   `json:"partial_count" example:"5"`
-	OverdueCount     int64   `json:"overdue_count" example:"3"`
+	TotalOutstanding float64                 `json:"total_outstanding" example:"50000.00"`
+	TotalOverdue     float64                 `json:"total_overdue" example:"10000.00"`
+	TotalWrittenOff  float64                 `json:"total_written_off" example:"2000.00"`
+	PendingCount     int64                   `json:"pending_count" example:"10"`
+	PartialCount     int64                   `json:"partial_count" example:"5"`
+	OverdueCount     int64                   `json:"overdue_count" example:"3"`
+	Aging            []CurrencyAgingResponse `json:"aging"`
+}
+
+// CurrencyAgingResponse represents the aging buckets of the documents in one currency
+//
+//	@Description	Aging buckets in one currency
+type CurrencyAgingResponse struct {
+	Currency string                `json:"currency" example:"CNY"`
+	Buckets  []AgingBucketResponse `json:"buckets"`
+}
+
+// AgingBucketResponse represents the outstanding amount in one aging range
+//
+//	@Description	Aging bucket (current, N-M days past due, N+ days past due, or undated)
+type AgingBucketResponse struct {
+	Key     string  `json:"key" example:"31-60"`
+	MinDays int     `json:"min_days" example:"31"`
+	MaxDays *int    `json:"max_days,omitempty" example:"60"`
+	Amount  float64 `json:"amount" example:"5000.00"`
+	Count   int64   `json:"count" example:"4"`
 }
 
 // PayableSummaryResponse represents a summary of payables
 //
 //	@Description	Payable summary response
 type PayableSummaryResponse struct {
-	TotalOutstanding float64 `json:"total_outstanding" example:"80000.00"`
-	TotalOverdue     float64 `json:"total_overdue" example:"20000.00"`
-	PendingCount     int64   `json:"pending_count" example:"15"`
-	PartialCount     int64   `json:"partial_count" example:"8"`
-	OverdueCount     int64   `json:"overdue_count" example:"5"`
+	TotalOutstanding float64                 `json:"total_outstanding" example:"80000.00"`
+	TotalOverdue     float64                 `json:"total_overdue" example:"20000.00"`
+	PendingCount     int64                   `json:"pending_count" example:"15"`
+	PartialCount     int64                   `json:"partial_count" example:"8"`
+	OverdueCount     int64                   `json:"overdue_count" example:"5"`
+	Aging            []CurrencyAgingResponse `json:"aging"`
+}
+
+// PayableMatchResponse represents the three-way match of a payable's invoice against its purchase order
+//
+//	@Description	Three-way match result (ordered price vs received quantity vs invoiced amount)
+type PayableMatchResponse struct {
+	PayableID        string                     `json:"payable_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PayableNumber    string                     `json:"payable_number" example:"AP-2026-00001"`
+	SourceID         string                     `json:"source_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	SourceNumber     string                     `json:"source_number" example:"PO-2026-00001"`
+	PayableStatus    string                     `json:"payable_status" example:"MATCH_FAILED"`
+	InvoiceNumber    string                     `json:"invoice_number" example:"INV-2026-0042"`
+	InvoiceAmount    float64                    `json:"invoice_amount" example:"2100.00"`
+	InvoiceDate      *time.Time                 `json:"invoice_date,omitempty"`
+	MatchStatus      string                     `json:"match_status" example:"MISMATCHED" enums:"MATCHED,MISMATCHED"`
+	OrderedAmount    float64                    `json:"ordered_amount" example:"2000.00"`
+	ReceivedAmount   float64                    `json:"received_amount" example:"2000.00"`
+	BookedAmount     float64                    `json:"booked_amount" example:"2000.00"`
+	TolerancePercent float64                    `json:"tolerance_percent" example:"1"`
+	ToleranceAmount  float64                    `json:"tolerance_amount" example:"1.00"`
+	Lines            []PayableMatchLineResponse `json:"lines"`
+	Discrepancies    []MatchDiscrepancyResponse `json:"discrepancies"`
+	MatchedAt        time.Time                  `json:"matched_at"`
+}
+
+// PayableMatchLineResponse represents one purchase order line of a three-way match
+//
+//	@Description	Purchase order line as seen by the three-way match
+type PayableMatchLineResponse struct {
+	ProductID        string  `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440004"`
+	ProductName      string  `json:"product_name" example:"商品A"`
+	OrderedQuantity  float64 `json:"ordered_quantity" example:"100"`
+	ReceivedQuantity float64 `json:"received_quantity" example:"100"`
+	UnitCost         float64 `json:"unit_cost" example:"20.00"`
+}
+
+// MatchDiscrepancyResponse represents a difference found by the three-way match
+//
+//	@Description	Three-way match discrepancy beyond the tolerance
+type MatchDiscrepancyResponse struct {
+	Type        string  `json:"type" example:"INVOICE" enums:"QUANTITY,PRICE,INVOICE"`
+	ProductID   *string `json:"product_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440004"`
+	ProductName string  `json:"product_name,omitempty" example:"商品A"`
+	Expected    float64 `json:"expected" example:"2000.00"`
+	Actual      float64 `json:"actual" example:"2100.00"`
+	Difference  float64 `json:"difference" example:"100.00"`
+	Allowed     float64 `json:"allowed" example:"20.00"`
 }
 
 // ReconcileReceiptResultResponse represents the result of reconciling a receipt voucher
@@ -251,6 +379,93 @@ type ReconcileReceiptResultResponse struct {
 	FullyReconciled      bool                        `json:"fully_reconciled" example:"false"`
 }
 
+// UnreconcileReceiptVoucherResultResponse represents the result of undoing a receipt voucher allocation
+//
+//	@Description	Unreconcile receipt result response
+type UnreconcileReceiptVoucherResultResponse struct {
+	Voucher           ReceiptVoucherResponse    `json:"voucher"`
+	UpdatedReceivable AccountReceivableResponse `json:"updated_receivable"`
+	ReversedAmount    float64                   `json:"reversed_amount" example:"500.00"`
+}
+
+// BatchReceiptRowResultResponse represents the outcome of a single batch row
+//
+//	@Description	Batch receipt row result
+type BatchReceiptRowResultResponse struct {
+	Index                int                     `json:"index" example:"0"`
+	CustomerID           string                  `json:"customer_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Success              bool                    `json:"success" example:"true"`
+	Voucher              *ReceiptVoucherResponse `json:"voucher,omitempty"`
+	TotalReconciled      float64                 `json:"total_reconciled" example:"1000.00"`
+	RemainingUnallocated float64                 `json:"remaining_unallocated" example:"0.00"`
+	FullyReconciled      bool                    `json:"fully_reconciled" example:"true"`
+	ErrorCode            string                  `json:"error_code,omitempty" example:"CUSTOMER_NOT_FOUND"`
+	Error                string                  `json:"error,omitempty" example:"Customer not found"`
+}
+
+// BatchReceiptVoucherResultResponse represents the result of a batch of receipts
+//
+//	@Description	Batch receipt result response
+type BatchReceiptVoucherResultResponse struct {
+	Results                  []BatchReceiptRowResultResponse `json:"results"`
+	TotalRows                int                             `json:"total_rows" example:"10"`
+	SucceededCount           int                             `json:"succeeded_count" example:"9"`
+	FailedCount              int                             `json:"failed_count" example:"1"`
+	FullyReconciledCount     int                             `json:"fully_reconciled_count" example:"7"`
+	PartiallyReconciledCount int                             `json:"partially_reconciled_count" example:"2"`
+	UnreconciledCount        int                             `json:"unreconciled_count" example:"0"`
+	TotalAmount              float64                         `json:"total_amount" example:"25000.00"`
+	TotalReconciled          float64                         `json:"total_reconciled" example:"23000.00"`
+}
+
+// CashFlowForecastWeekResponse represents the expected cash movement of one week
+//
+//	@Description	Cash flow forecast week
+type CashFlowForecastWeekResponse struct {
+	Week           int       `json:"week" example:"1"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	Inflow         float64   `json:"inflow" example:"12000.00"`
+	Outflow        float64   `json:"outflow" example:"8000.00"`
+	InflowCount    int64     `json:"inflow_count" example:"4"`
+	OutflowCount   int64     `json:"outflow_count" example:"3"`
+	Net            float64   `json:"net" example:"4000.00"`
+	ClosingBalance float64   `json:"closing_balance" example:"54000.00"`
+}
+
+// CashFlowForecastTotalsResponse represents expected amounts outside the weekly schedule
+//
+//	@Description	Cash flow forecast totals outside the weekly schedule
+type CashFlowForecastTotalsResponse struct {
+	Inflow       float64 `json:"inflow" example:"3000.00"`
+	Outflow      float64 `json:"outflow" example:"1500.00"`
+	InflowCount  int64   `json:"inflow_count" example:"2"`
+	OutflowCount int64   `json:"outflow_count" example:"1"`
+}
+
+// CashFlowForecastResponse represents projected cash positions, one per currency
+//
+//	@Description	Cash flow forecast from receivable and payable due dates
+type CashFlowForecastResponse struct {
+	StartDate  time.Time                          `json:"start_date"`
+	Currencies []CurrencyCashFlowForecastResponse `json:"currencies"`
+}
+
+// CurrencyCashFlowForecastResponse represents the projected cash position in one currency
+//
+//	@Description	Cash flow forecast in one currency
+type CurrencyCashFlowForecastResponse struct {
+	Currency         string                         `json:"currency" example:"CNY"`
+	OpeningBalance   float64                        `json:"opening_balance" example:"50000.00"`
+	Weeks            []CashFlowForecastWeekResponse `json:"weeks"`
+	TotalInflow      float64                        `json:"total_inflow" example:"120000.00"`
+	TotalOutflow     float64                        `json:"total_outflow" example:"90000.00"`
+	ProjectedBalance float64                        `json:"projected_balance" example:"80000.00"`
+	Overdue          CashFlowForecastTotalsResponse `json:"overdue"`
+	BeyondHorizon    CashFlowForecastTotalsResponse `json:"beyond_horizon"`
+	Unscheduled      CashFlowForecastTotalsResponse `json:"unscheduled"`
+}
+
 // ReconcilePaymentResultResponse represents the result of reconciling a payment voucher
 //
 //	@Description	Reconcile payment result response
@@ -283,6 +498,8 @@ type ReconcilePaymentResultResponse struct {
 //	@Param			overdue		query		boolean	false	"Filter overdue only"
 //	@Param			page		query		int		false	"Page number"	default(1)
 //	@Param			page_size	query		int		false	"Page size"		default(20)	maximum(100)
+//	@Param			pagination	query		string	false	"Pagination mode; cursor orders by created_at and returns meta.next_cursor"	Enums(offset, cursor)	default(offset)
+//	@Param			cursor		query		string	false	"next_cursor from the previous page (implies pagination=cursor)"
 //	@Success		200			{object}	APIResponse[[]AccountReceivableResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
@@ -308,6 +525,16 @@ func (h *FinanceHandler) ListReceivables(c *gin.Context) {
 		filter.PageSize = 20
 	}
 
+	if filter.UsesCursor() {
+		receivables, nextCursor, err := h.financeService.ListReceivablesByCursor(c.Request.Context(), tenantID, filter)
+		if err != nil {
+			h.HandleDomainError(c, err)
+			return
+		}
+		h.SuccessWithCursor(c, toAccountReceivableResponses(receivables), filter.PageSize, nextCursor)
+		return
+	}
+
 	receivables, total, err := h.financeService.ListReceivables(c.Request.Context(), tenantID, filter)
 	if err != nil {
 		h.HandleDomainError(c, err)
@@ -354,6 +581,60 @@ func (h *FinanceHandler) GetReceivableByID(c *gin.Context) {
 	h.Success(c, toAccountReceivableResponse(receivable))
 }
 
+// ListReceivablePayments godoc
+//
+//	@ID				listFinanceReceivableReceivablePayments
+//	@Summary		List receivable payment history
+//	@Description	Retrieve a paginated list of payments applied to an account receivable, newest first
+//	@Tags			finance-receivables
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Receivable ID"	format(uuid)
+//	@Param			page		query		int		false	"Page number"	default(1)
+//	@Param			page_size	query		int		false	"Page size"		default(20)	maximum(100)
+//	@Success		200			{object}	APIResponse[[]PaymentRecordResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/receivables/{id}/payments [get]
+func (h *FinanceHandler) ListReceivablePayments(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	receivableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid receivable ID format")
+		return
+	}
+
+	var query struct {
+		Page     int `form:"page"`
+		PageSize int `form:"page_size" binding:"omitempty,max=100"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 20
+	}
+
+	records, total, err := h.financeService.ListReceivablePayments(c.Request.Context(), tenantID, receivableID, query.Page, query.PageSize)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.SuccessWithMeta(c, toPaymentRecordResponses(records), total, query.Page, query.PageSize)
+}
+
 // GetReceivableSummary godoc
 //
 //	@ID				getFinanceReceivableReceivableSummary
@@ -361,8 +642,9 @@ func (h *FinanceHandler) GetReceivableByID(c *gin.Context) {
 //	@Description	Get summary statistics for account receivables
 //	@Tags			finance-receivables
 //	@Produce		json
-//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
-//	@Success		200			{object}	APIResponse[ReceivableSummaryResponse]
+//	@Param			X-Tenant-ID			header		string	false	"Tenant ID (optional for dev)"
+//	@Param			aging_boundaries	query		string	false	"Comma-separated upper bounds (days past due) of the overdue aging buckets"	default(30,60,90)
+//	@Success		200					{object}	APIResponse[ReceivableSummaryResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
@@ -374,7 +656,13 @@ func (h *FinanceHandler) GetReceivableSummary(c *gin.Context) {
 		return
 	}
 
-	summary, err := h.financeService.GetReceivableSummary(c.Request.Context(), tenantID)
+	agingBoundaries, err := parseAgingBoundaries(c.Query("aging_boundaries"))
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	summary, err := h.financeService.GetReceivableSummary(c.Request.Context(), tenantID, agingBoundaries)
 	if err != nil {
 		h.HandleDomainError(c, err)
 		return
@@ -383,12 +671,68 @@ func (h *FinanceHandler) GetReceivableSummary(c *gin.Context) {
 	h.Success(c, ReceivableSummaryResponse{
 		TotalOutstanding: summary.TotalOutstanding.InexactFloat64(),
 		TotalOverdue:     summary.TotalOverdue.InexactFloat64(),
+		TotalWrittenOff:  summary.TotalWrittenOff.InexactFloat64(),
 		PendingCount:     summary.PendingCount,
 		PartialCount:     summary.PartialCount,
 		OverdueCount:     summary.OverdueCount,
+		Aging:            toCurrencyAgingResponses(summary.Aging),
 	})
 }
 
+// WriteOffReceivable godoc
+//
+//	@ID				writeOffReceivableFinanceReceivable
+//	@Summary		Write off an account receivable
+//	@Description	Write off the outstanding balance of a receivable as bad debt
+//	@Tags			finance-receivables
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string						false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string						true	"Receivable ID"	format(uuid)
+//	@Param			request		body		WriteOffReceivableRequest	true	"Write-off request"
+//	@Success		200			{object}	APIResponse[AccountReceivableResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/receivables/{id}/write-off [post]
+func (h *FinanceHandler) WriteOffReceivable(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	receivableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid receivable ID format")
+		return
+	}
+
+	var req WriteOffReceivableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	// The authenticated user is recorded as the write-off approver
+	userID, err := getUserID(c)
+	if err != nil {
+		h.Unauthorized(c, "Authentication required for this operation")
+		return
+	}
+
+	receivable, err := h.financeService.WriteOffReceivable(c.Request.Context(), tenantID, receivableID, userID, req.Reason)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toAccountReceivableResponse(receivable))
+}
+
 // ===================== Account Payable Handlers =====================
 
 // ===================== Account Payable Handlers =====================
@@ -403,7 +747,7 @@ func (h *FinanceHandler) GetReceivableSummary(c *gin.Context) {
 //	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
 //	@Param			search		query		string	false	"Search term (payable number, supplier name)"
 //	@Param			supplier_id	query		string	false	"Supplier ID"			format(uuid)
-//	@Param			status		query		string	false	"Status"				Enums(PENDING, PARTIAL, PAID, REVERSED, CANCELLED)
+//	@Param			status		query		string	false	"Status"				Enums(PENDING, PARTIAL, PAID, REVERSED, CANCELLED, MATCH_FAILED)
 //	@Param			source_type	query		string	false	"Source type"			Enums(PURCHASE_ORDER, PURCHASE_RETURN, MANUAL)
 //	@Param			from_date	query		string	false	"From date (ISO 8601)"	format(date)
 //	@Param			to_date		query		string	false	"To date (ISO 8601)"	format(date)
@@ -481,6 +825,142 @@ func (h *FinanceHandler) GetPayableByID(c *gin.Context) {
 	h.Success(c, toAccountPayableResponse(payable))
 }
 
+// RecordPayableInvoice godoc
+//
+//	@ID				recordFinancePayableInvoice
+//	@Summary		Record supplier invoice for a payable
+//	@Description	Record the supplier invoice against a purchase order payable and run the three-way match
+//	@Description	(ordered price vs received quantity vs invoiced amount). A mismatch beyond the tolerance
+//	@Description	moves the payable to MATCH_FAILED, which blocks payment until the invoice matches.
+//	@Tags			finance-payables
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string						false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string						true	"Payable ID"	format(uuid)
+//	@Param			request		body		RecordPayableInvoiceRequest	true	"Supplier invoice"
+//	@Success		200			{object}	APIResponse[PayableMatchResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/payables/{id}/invoice [post]
+func (h *FinanceHandler) RecordPayableInvoice(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	payableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid payable ID format")
+		return
+	}
+
+	var req RecordPayableInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	appReq := financeapp.RecordPayableInvoiceRequest{
+		InvoiceNumber: req.InvoiceNumber,
+		InvoiceAmount: decimal.NewFromFloat(req.InvoiceAmount),
+	}
+	if req.InvoiceDate != "" {
+		invoiceDate, err := parseDateTime(req.InvoiceDate)
+		if err != nil {
+			h.BadRequest(c, "Invalid invoice date format")
+			return
+		}
+		appReq.InvoiceDate = &invoiceDate
+	}
+
+	match, err := h.financeService.RecordPayableInvoice(c.Request.Context(), tenantID, payableID, appReq)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toPayableMatchResponse(match))
+}
+
+// GetPayableMatch godoc
+//
+//	@ID				getFinancePayableMatch
+//	@Summary		Get three-way match result of a payable
+//	@Description	Retrieve the latest three-way match of the payable's supplier invoice against its purchase order
+//	@Tags			finance-payables
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Payable ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[PayableMatchResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/payables/{id}/match [get]
+func (h *FinanceHandler) GetPayableMatch(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	payableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid payable ID format")
+		return
+	}
+
+	match, err := h.financeService.GetPayableMatch(c.Request.Context(), tenantID, payableID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toPayableMatchResponse(match))
+}
+
+// MatchPayable godoc
+//
+//	@ID				matchFinancePayable
+//	@Summary		Re-run the three-way match of a payable
+//	@Description	Match the recorded supplier invoice again, e.g. after further goods were received against the purchase order
+//	@Tags			finance-payables
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Payable ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[PayableMatchResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/payables/{id}/match [post]
+func (h *FinanceHandler) MatchPayable(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	payableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid payable ID format")
+		return
+	}
+
+	match, err := h.financeService.MatchPayable(c.Request.Context(), tenantID, payableID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toPayableMatchResponse(match))
+}
+
 // GetPayableSummary godoc
 //
 //	@ID				getFinancePayablePayableSummary
@@ -488,8 +968,9 @@ func (h *FinanceHandler) GetPayableByID(c *gin.Context) {
 //	@Description	Get summary statistics for account payables
 //	@Tags			finance-payables
 //	@Produce		json
-//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
-//	@Success		200			{object}	APIResponse[PayableSummaryResponse]
+//	@Param			X-Tenant-ID			header		string	false	"Tenant ID (optional for dev)"
+//	@Param			aging_boundaries	query		string	false	"Comma-separated upper bounds (days past due) of the overdue aging buckets"	default(30,60,90)
+//	@Success		200					{object}	APIResponse[PayableSummaryResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
@@ -501,7 +982,13 @@ func (h *FinanceHandler) GetPayableSummary(c *gin.Context) {
 		return
 	}
 
-	summary, err := h.financeService.GetPayableSummary(c.Request.Context(), tenantID)
+	agingBoundaries, err := parseAgingBoundaries(c.Query("aging_boundaries"))
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	summary, err := h.financeService.GetPayableSummary(c.Request.Context(), tenantID, agingBoundaries)
 	if err != nil {
 		h.HandleDomainError(c, err)
 		return
@@ -513,9 +1000,59 @@ func (h *FinanceHandler) GetPayableSummary(c *gin.Context) {
 		PendingCount:     summary.PendingCount,
 		PartialCount:     summary.PartialCount,
 		OverdueCount:     summary.OverdueCount,
+		Aging:            toCurrencyAgingResponses(summary.Aging),
 	})
 }
 
+// ===================== Cash Flow Forecast Handlers =====================
+
+// GetCashFlowForecast godoc
+//
+//	@ID				getFinanceCashFlowForecast
+//	@Summary		Get cash flow forecast
+//	@Description	Project the cash position in each currency over the coming weeks. Open receivables are scheduled as inflows and open payables as outflows by due date.
+//	@Description	Overdue amounts fall into the first week; amounts without a due date are reported as unscheduled.
+//	@Tags			finance-cash-flow
+//	@Produce		json
+//	@Param			X-Tenant-ID		header		string	false	"Tenant ID (optional for dev)"
+//	@Param			weeks			query		int		false	"Number of weekly periods"	default(12)	minimum(1)	maximum(52)
+//	@Param			opening_balance	query		number	false	"Cash on hand at the start of the forecast"	default(0)
+//	@Param			currency		query		string	false	"Currency of the opening balance"	default(CNY)
+//	@Success		200				{object}	APIResponse[CashFlowForecastResponse]
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/cash-flow/forecast [get]
+func (h *FinanceHandler) GetCashFlowForecast(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	var query struct {
+		Weeks          int     `form:"weeks" binding:"omitempty,min=1,max=52"`
+		OpeningBalance float64 `form:"opening_balance"`
+		Currency       string  `form:"currency" binding:"omitempty,len=3"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	forecast, err := h.financeService.GetCashFlowForecast(c.Request.Context(), tenantID, financeapp.CashFlowForecastRequest{
+		Weeks:          query.Weeks,
+		OpeningBalance: decimal.NewFromFloat(query.OpeningBalance),
+		Currency:       strings.ToUpper(query.Currency),
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toCashFlowForecastResponse(forecast))
+}
+
 // ===================== Receipt Voucher Handlers =====================
 
 // ===================== Receipt Voucher Handlers =====================
@@ -524,12 +1061,13 @@ func (h *FinanceHandler) GetPayableSummary(c *gin.Context) {
 //
 //	@ID				createFinanceReceiptReceiptVoucher
 //	@Summary		Create a receipt voucher
-//	@Description	Create a new receipt voucher for customer payment
+//	@Description	Create a new receipt voucher for customer payment. Retrying with the same Idempotency-Key within 24 hours returns the original voucher.
 //	@Tags			finance-receipts
 //	@Accept			json
 //	@Produce		json
-//	@Param			X-Tenant-ID	header		string						false	"Tenant ID (optional for dev)"
-//	@Param			request		body		CreateReceiptVoucherRequest	true	"Receipt voucher creation request"
+//	@Param			X-Tenant-ID		header		string						false	"Tenant ID (optional for dev)"
+//	@Param			Idempotency-Key	header		string						false	"Client-generated key that makes retries safe"
+//	@Param			request			body		CreateReceiptVoucherRequest	true	"Receipt voucher creation request"
 //	@Success		201			{object}	APIResponse[ReceiptVoucherResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
@@ -548,6 +1086,12 @@ func (h *FinanceHandler) CreateReceiptVoucher(c *gin.Context) {
 		return
 	}
 
+	idempotencyKey := c.GetHeader("Idempotency-Key")
+	if len(idempotencyKey) > finance.MaxIdempotencyKeyLength {
+		h.BadRequest(c, "Idempotency-Key header cannot exceed 255 characters")
+		return
+	}
+
 	customerID, err := uuid.Parse(req.CustomerID)
 	if err != nil {
 		h.BadRequest(c, "Invalid customer ID format")
@@ -571,6 +1115,7 @@ func (h *FinanceHandler) CreateReceiptVoucher(c *gin.Context) {
 		PaymentReference: req.PaymentReference,
 		ReceiptDate:      receiptDate,
 		Remark:           req.Remark,
+		IdempotencyKey:   idempotencyKey,
 	}
 
 	// Set CreatedBy for data scope filtering
@@ -809,6 +1354,11 @@ func (h *FinanceHandler) ReconcileReceiptVoucher(c *gin.Context) {
 		h.BadRequest(c, err.Error())
 		return
 	}
+	if !h.financeService.IsReconciliationStrategyAvailable(req.StrategyType) {
+		h.BadRequest(c, fmt.Sprintf("Unknown strategy_type %q, available strategies: %s",
+			req.StrategyType, strings.Join(h.financeService.AvailableReconciliationStrategies(), ", ")))
+		return
+	}
 
 	var manualAllocs []financeapp.ManualAllocationRequest
 	for _, ma := range req.ManualAllocations {
@@ -838,6 +1388,143 @@ func (h *FinanceHandler) ReconcileReceiptVoucher(c *gin.Context) {
 	h.Success(c, toReconcileReceiptResultResponse(result))
 }
 
+// UnreconcileReceiptVoucher godoc
+//
+//	@ID				unreconcileReceiptVoucherFinanceReceipt
+//	@Summary		Undo a receipt voucher allocation
+//	@Description	Remove an allocation from a receipt voucher. The amount becomes outstanding on the receivable again and returns to the voucher's unallocated amount.
+//	@Tags			finance-receipts
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string				false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string				true	"Receipt Voucher ID"	format(uuid)
+//	@Param			request		body		UnreconcileRequest	true	"Unreconcile request"
+//	@Success		200			{object}	APIResponse[UnreconcileReceiptVoucherResultResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/receipts/{id}/unreconcile [post]
+func (h *FinanceHandler) UnreconcileReceiptVoucher(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	voucherID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid voucher ID format")
+		return
+	}
+
+	var req UnreconcileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	allocationID, err := uuid.Parse(req.AllocationID)
+	if err != nil {
+		h.BadRequest(c, "Invalid allocation ID format")
+		return
+	}
+
+	result, err := h.financeService.UnreconcileReceiptVoucher(c.Request.Context(), tenantID, financeapp.UnreconcileReceiptVoucherRequest{
+		VoucherID:    voucherID,
+		AllocationID: allocationID,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toUnreconcileReceiptVoucherResultResponse(result))
+}
+
+// BatchCreateReceiptVouchers godoc
+//
+//	@ID				batchCreateFinanceReceiptVouchers
+//	@Summary		Create and reconcile receipt vouchers in bulk
+//	@Description	Create, confirm and reconcile a receipt voucher for each row (e.g. a day's bank settlements). Each row is processed in its own transaction; failed rows are reported without aborting the batch.
+//	@Tags			finance-receipts
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string						false	"Tenant ID (optional for dev)"
+//	@Param			request		body		BatchReceiptVoucherRequest	true	"Batch receipt request"
+//	@Success		200			{object}	APIResponse[BatchReceiptVoucherResultResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/finance/receipts/batch [post]
+func (h *FinanceHandler) BatchCreateReceiptVouchers(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := getUserID(c)
+	if err != nil {
+		h.Unauthorized(c, "Authentication required for this operation")
+		return
+	}
+
+	var req BatchReceiptVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+	if req.StrategyType != "" && !h.financeService.IsReconciliationStrategyAvailable(req.StrategyType) {
+		h.BadRequest(c, fmt.Sprintf("Unknown strategy_type %q, available strategies: %s",
+			req.StrategyType, strings.Join(h.financeService.AvailableReconciliationStrategies(), ", ")))
+		return
+	}
+	if req.StrategyType == "MANUAL" {
+		h.BadRequest(c, "Manual reconciliation is not supported for batch receipts")
+		return
+	}
+
+	rows := make([]financeapp.BatchReceiptRow, len(req.Rows))
+	for i, row := range req.Rows {
+		customerID, err := uuid.Parse(row.CustomerID)
+		if err != nil {
+			h.BadRequest(c, fmt.Sprintf("Invalid customer ID format in row %d", i))
+			return
+		}
+		receiptDate, err := parseDateTime(row.ReceiptDate)
+		if err != nil {
+			h.BadRequest(c, fmt.Sprintf("Invalid receipt date format in row %d", i))
+			return
+		}
+		rows[i] = financeapp.BatchReceiptRow{
+			CustomerID:       customerID,
+			Amount:           decimal.NewFromFloat(row.Amount),
+			PaymentMethod:    row.PaymentMethod,
+			PaymentReference: row.PaymentReference,
+			ReceiptDate:      receiptDate,
+			Remark:           row.Remark,
+		}
+	}
+
+	appReq := financeapp.CreateAndReconcileReceiptVouchersRequest{
+		Rows:         rows,
+		StrategyType: req.StrategyType,
+		UserID:       userID,
+	}
+
+	result, err := h.financeService.CreateAndReconcileReceiptVouchers(c.Request.Context(), tenantID, appReq)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toBatchReceiptVoucherResultResponse(result))
+}
+
 // ===================== Payment Voucher Handlers =====================
 
 // ===================== Payment Voucher Handlers =====================
@@ -846,12 +1533,13 @@ func (h *FinanceHandler) ReconcileReceiptVoucher(c *gin.Context) {
 //
 //	@ID				createFinancePaymentPaymentVoucher
 //	@Summary		Create a payment voucher
-//	@Description	Create a new payment voucher for supplier payment
+//	@Description	Create a new payment voucher for supplier payment. Retrying with the same Idempotency-Key within 24 hours returns the original voucher.
 //	@Tags			finance-payments
 //	@Accept			json
 //	@Produce		json
-//	@Param			X-Tenant-ID	header		string						false	"Tenant ID (optional for dev)"
-//	@Param			request		body		CreatePaymentVoucherRequest	true	"Payment voucher creation request"
+//	@Param			X-Tenant-ID		header		string						false	"Tenant ID (optional for dev)"
+//	@Param			Idempotency-Key	header		string						false	"Client-generated key that makes retries safe"
+//	@Param			request			body		CreatePaymentVoucherRequest	true	"Payment voucher creation request"
 //	@Success		201			{object}	APIResponse[PaymentVoucherResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
@@ -870,6 +1558,12 @@ func (h *FinanceHandler) CreatePaymentVoucher(c *gin.Context) {
 		return
 	}
 
+	idempotencyKey := c.GetHeader("Idempotency-Key")
+	if len(idempotencyKey) > finance.MaxIdempotencyKeyLength {
+		h.BadRequest(c, "Idempotency-Key header cannot exceed 255 characters")
+		return
+	}
+
 	supplierID, err := uuid.Parse(req.SupplierID)
 	if err != nil {
 		h.BadRequest(c, "Invalid supplier ID format")
@@ -893,6 +1587,7 @@ func (h *FinanceHandler) CreatePaymentVoucher(c *gin.Context) {
 		PaymentReference: req.PaymentReference,
 		PaymentDate:      paymentDate,
 		Remark:           req.Remark,
+		IdempotencyKey:   idempotencyKey,
 	}
 
 	// Set CreatedBy for data scope filtering
@@ -1131,6 +1826,11 @@ func (h *FinanceHandler) ReconcilePaymentVoucher(c *gin.Context) {
 		h.BadRequest(c, err.Error())
 		return
 	}
+	if !h.financeService.IsReconciliationStrategyAvailable(req.StrategyType) {
+		h.BadRequest(c, fmt.Sprintf("Unknown strategy_type %q, available strategies: %s",
+			req.StrategyType, strings.Join(h.financeService.AvailableReconciliationStrategies(), ", ")))
+		return
+	}
 
 	var manualAllocs []financeapp.ManualAllocationRequest
 	for _, ma := range req.ManualAllocations {
@@ -1162,18 +1862,79 @@ func (h *FinanceHandler) ReconcilePaymentVoucher(c *gin.Context) {
 
 // ===================== Response Conversion Functions =====================
 
-func toAccountReceivableResponse(r *financeapp.AccountReceivableResponse) AccountReceivableResponse {
-	paymentRecords := make([]PaymentRecordResponse, len(r.PaymentRecords))
-	for i, pr := range r.PaymentRecords {
-		paymentRecords[i] = PaymentRecordResponse{
-			ID:               pr.ID.String(),
-			ReceiptVoucherID: pr.ReceiptVoucherID.String(),
-			Amount:           pr.Amount.InexactFloat64(),
-			AppliedAt:        pr.AppliedAt,
-			Remark:           pr.Remark,
+func toCashFlowForecastResponse(f *financeapp.CashFlowForecastResponse) CashFlowForecastResponse {
+	currencies := make([]CurrencyCashFlowForecastResponse, len(f.Currencies))
+	for i := range f.Currencies {
+		currencies[i] = toCurrencyCashFlowForecastResponse(&f.Currencies[i])
+	}
+	return CashFlowForecastResponse{
+		StartDate:  f.StartDate,
+		Currencies: currencies,
+	}
+}
+
+func toCurrencyCashFlowForecastResponse(f *financeapp.CurrencyCashFlowForecastResponse) CurrencyCashFlowForecastResponse {
+	weeks := make([]CashFlowForecastWeekResponse, len(f.Weeks))
+	for i, w := range f.Weeks {
+		weeks[i] = CashFlowForecastWeekResponse{
+			Week:           w.Week,
+			StartDate:      w.StartDate,
+			EndDate:        w.EndDate,
+			Inflow:         w.Inflow.InexactFloat64(),
+			Outflow:        w.Outflow.InexactFloat64(),
+			InflowCount:    w.InflowCount,
+			OutflowCount:   w.OutflowCount,
+			Net:            w.Net.InexactFloat64(),
+			ClosingBalance: w.ClosingBalance.InexactFloat64(),
 		}
 	}
 
+	return CurrencyCashFlowForecastResponse{
+		Currency:         f.Currency,
+		OpeningBalance:   f.OpeningBalance.InexactFloat64(),
+		Weeks:            weeks,
+		TotalInflow:      f.TotalInflow.InexactFloat64(),
+		TotalOutflow:     f.TotalOutflow.InexactFloat64(),
+		ProjectedBalance: f.ProjectedBalance.InexactFloat64(),
+		Overdue:          toCashFlowForecastTotalsResponse(f.Overdue),
+		BeyondHorizon:    toCashFlowForecastTotalsResponse(f.BeyondHorizon),
+		Unscheduled:      toCashFlowForecastTotalsResponse(f.Unscheduled),
+	}
+}
+
+func toCashFlowForecastTotalsResponse(t financeapp.CashFlowForecastTotalsResponse) CashFlowForecastTotalsResponse {
+	return CashFlowForecastTotalsResponse{
+		Inflow:       t.Inflow.InexactFloat64(),
+		Outflow:      t.Outflow.InexactFloat64(),
+		InflowCount:  t.InflowCount,
+		OutflowCount: t.OutflowCount,
+	}
+}
+
+func toPaymentRecordResponses(records []financeapp.PaymentRecordResponse) []PaymentRecordResponse {
+	responses := make([]PaymentRecordResponse, len(records))
+	for i, pr := range records {
+		responses[i] = PaymentRecordResponse{
+			ID:                   pr.ID.String(),
+			ReceiptVoucherID:     pr.ReceiptVoucherID.String(),
+			ReceiptVoucherNumber: pr.ReceiptVoucherNumber,
+			Amount:               pr.Amount.InexactFloat64(),
+			AppliedAt:            pr.AppliedAt,
+			Remark:               pr.Remark,
+		}
+	}
+	return responses
+}
+
+func toAccountReceivableResponse(r *financeapp.AccountReceivableResponse) AccountReceivableResponse {
+	paymentRecords := toPaymentRecordResponses(r.PaymentRecords)
+
+	var writtenOffBy *string
+	if r.WrittenOffBy != nil {
+		id := r.WrittenOffBy.String()
+		writtenOffBy = &id
+	}
+
 	return AccountReceivableResponse{
 		ID:                r.ID.String(),
 		TenantID:          r.TenantID.String(),
@@ -1183,6 +1944,7 @@ func toAccountReceivableResponse(r *financeapp.AccountReceivableResponse) Accoun
 		SourceType:        r.SourceType,
 		SourceID:          r.SourceID.String(),
 		SourceNumber:      r.SourceNumber,
+		Currency:          r.Currency,
 		TotalAmount:       r.TotalAmount.InexactFloat64(),
 		PaidAmount:        r.PaidAmount.InexactFloat64(),
 		OutstandingAmount: r.OutstandingAmount.InexactFloat64(),
@@ -1191,12 +1953,59 @@ func toAccountReceivableResponse(r *financeapp.AccountReceivableResponse) Accoun
 		PaymentRecords:    paymentRecords,
 		Remark:            r.Remark,
 		PaidAt:            r.PaidAt,
+		WrittenOffAmount:  r.WrittenOffAmount.InexactFloat64(),
+		WrittenOffAt:      r.WrittenOffAt,
+		WrittenOffBy:      writtenOffBy,
+		WriteOffReason:    r.WriteOffReason,
 		CreatedAt:         r.CreatedAt,
 		UpdatedAt:         r.UpdatedAt,
 		Version:           r.Version,
 	}
 }
 
+// parseAgingBoundaries parses a comma-separated list of aging bucket boundaries, e.g. "30,60,90".
+// An empty string returns nil so that the default boundaries are used.
+func parseAgingBoundaries(raw string) ([]int, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	boundaries := make([]int, 0, len(parts))
+	for _, part := range parts {
+		days, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid aging_boundaries value %q: must be comma-separated integers", part)
+		}
+		boundaries = append(boundaries, days)
+	}
+	return boundaries, nil
+}
+
+func toCurrencyAgingResponses(agings []financeapp.CurrencyAgingSummary) []CurrencyAgingResponse {
+	responses := make([]CurrencyAgingResponse, len(agings))
+	for i, a := range agings {
+		responses[i] = CurrencyAgingResponse{
+			Currency: a.Currency,
+			Buckets:  toAgingBucketResponses(a.Buckets),
+		}
+	}
+	return responses
+}
+
+func toAgingBucketResponses(buckets []financeapp.AgingBucketSummary) []AgingBucketResponse {
+	responses := make([]AgingBucketResponse, len(buckets))
+	for i, b := range buckets {
+		responses[i] = AgingBucketResponse{
+			Key:     b.Key,
+			MinDays: b.MinDays,
+			MaxDays: b.MaxDays,
+			Amount:  b.Amount.InexactFloat64(),
+			Count:   b.Count,
+		}
+	}
+	return responses
+}
+
 func toAccountReceivableResponses(receivables []financeapp.AccountReceivableResponse) []AccountReceivableResponse {
 	responses := make([]AccountReceivableResponse, len(receivables))
 	for i, r := range receivables {
@@ -1226,6 +2035,7 @@ func toAccountPayableResponse(p *financeapp.AccountPayableResponse) AccountPayab
 		SourceType:        p.SourceType,
 		SourceID:          p.SourceID.String(),
 		SourceNumber:      p.SourceNumber,
+		Currency:          p.Currency,
 		TotalAmount:       p.TotalAmount.InexactFloat64(),
 		PaidAmount:        p.PaidAmount.InexactFloat64(),
 		OutstandingAmount: p.OutstandingAmount.InexactFloat64(),
@@ -1234,6 +2044,10 @@ func toAccountPayableResponse(p *financeapp.AccountPayableResponse) AccountPayab
 		PaymentRecords:    paymentRecords,
 		Remark:            p.Remark,
 		PaidAt:            p.PaidAt,
+		InvoiceNumber:     p.InvoiceNumber,
+		InvoiceAmount:     p.InvoiceAmount.InexactFloat64(),
+		InvoiceDate:       p.InvoiceDate,
+		MatchStatus:       p.MatchStatus,
 		CreatedAt:         p.CreatedAt,
 		UpdatedAt:         p.UpdatedAt,
 		Version:           p.Version,
@@ -1248,6 +2062,56 @@ func toAccountPayableResponses(payables []financeapp.AccountPayableResponse) []A
 	return responses
 }
 
+func toPayableMatchResponse(m *financeapp.PayableMatchResponse) PayableMatchResponse {
+	lines := make([]PayableMatchLineResponse, len(m.Lines))
+	for i, l := range m.Lines {
+		lines[i] = PayableMatchLineResponse{
+			ProductID:        l.ProductID.String(),
+			ProductName:      l.ProductName,
+			OrderedQuantity:  l.OrderedQuantity.InexactFloat64(),
+			ReceivedQuantity: l.ReceivedQuantity.InexactFloat64(),
+			UnitCost:         l.UnitCost.InexactFloat64(),
+		}
+	}
+	discrepancies := make([]MatchDiscrepancyResponse, len(m.Discrepancies))
+	for i, d := range m.Discrepancies {
+		var productID *string
+		if d.ProductID != nil {
+			id := d.ProductID.String()
+			productID = &id
+		}
+		discrepancies[i] = MatchDiscrepancyResponse{
+			Type:        d.Type,
+			ProductID:   productID,
+			ProductName: d.ProductName,
+			Expected:    d.Expected.InexactFloat64(),
+			Actual:      d.Actual.InexactFloat64(),
+			Difference:  d.Difference.InexactFloat64(),
+			Allowed:     d.Allowed.InexactFloat64(),
+		}
+	}
+
+	return PayableMatchResponse{
+		PayableID:        m.PayableID.String(),
+		PayableNumber:    m.PayableNumber,
+		SourceID:         m.SourceID.String(),
+		SourceNumber:     m.SourceNumber,
+		PayableStatus:    m.PayableStatus,
+		InvoiceNumber:    m.InvoiceNumber,
+		InvoiceAmount:    m.InvoiceAmount.InexactFloat64(),
+		InvoiceDate:      m.InvoiceDate,
+		MatchStatus:      m.MatchStatus,
+		OrderedAmount:    m.OrderedAmount.InexactFloat64(),
+		ReceivedAmount:   m.ReceivedAmount.InexactFloat64(),
+		BookedAmount:     m.BookedAmount.InexactFloat64(),
+		TolerancePercent: m.TolerancePercent.InexactFloat64(),
+		ToleranceAmount:  m.ToleranceAmount.InexactFloat64(),
+		Lines:            lines,
+		Discrepancies:    discrepancies,
+		MatchedAt:        m.MatchedAt,
+	}
+}
+
 func toReceiptVoucherResponse(v *financeapp.ReceiptVoucherResponse) ReceiptVoucherResponse {
 	allocations := make([]ReceivableAllocationResponse, len(v.Allocations))
 	for i, a := range v.Allocations {
@@ -1349,6 +2213,46 @@ func toReconcileReceiptResultResponse(r *financeapp.ReconcileReceiptResult) Reco
 	}
 }
 
+func toUnreconcileReceiptVoucherResultResponse(r *financeapp.UnreconcileReceiptVoucherResult) UnreconcileReceiptVoucherResultResponse {
+	return UnreconcileReceiptVoucherResultResponse{
+		Voucher:           toReceiptVoucherResponse(r.Voucher),
+		UpdatedReceivable: toAccountReceivableResponse(r.UpdatedReceivable),
+		ReversedAmount:    r.ReversedAmount.InexactFloat64(),
+	}
+}
+
+func toBatchReceiptVoucherResultResponse(r *financeapp.CreateAndReconcileReceiptVouchersResult) BatchReceiptVoucherResultResponse {
+	results := make([]BatchReceiptRowResultResponse, len(r.Results))
+	for i, row := range r.Results {
+		results[i] = BatchReceiptRowResultResponse{
+			Index:                row.Index,
+			CustomerID:           row.CustomerID.String(),
+			Success:              row.Success,
+			TotalReconciled:      row.TotalReconciled.InexactFloat64(),
+			RemainingUnallocated: row.RemainingUnallocated.InexactFloat64(),
+			FullyReconciled:      row.FullyReconciled,
+			ErrorCode:            row.ErrorCode,
+			Error:                row.Error,
+		}
+		if row.Voucher != nil {
+			voucher := toReceiptVoucherResponse(row.Voucher)
+			results[i].Voucher = &voucher
+		}
+	}
+
+	return BatchReceiptVoucherResultResponse{
+		Results:                  results,
+		TotalRows:                r.TotalRows,
+		SucceededCount:           r.SucceededCount,
+		FailedCount:              r.FailedCount,
+		FullyReconciledCount:     r.FullyReconciledCount,
+		PartiallyReconciledCount: r.PartiallyReconciledCount,
+		UnreconciledCount:        r.UnreconciledCount,
+		TotalAmount:              r.TotalAmount.InexactFloat64(),
+		TotalReconciled:          r.TotalReconciled.InexactFloat64(),
+	}
+}
+
 func toReconcilePaymentResultResponse(r *financeapp.ReconcilePaymentResult) ReconcilePaymentResultResponse {
 	updatedPayables := make([]AccountPayableResponse, len(r.UpdatedPayables))
 	for i, pay := range r.UpdatedPayables {
@@ -82,6 +82,24 @@ type IncreaseStockRequest struct {
 	Reference   string  `json:"reference" example:"Received from supplier ABC"`
 	Reason      string  `json:"reason" example:"Regular purchase"`
 	OperatorID  string  `json:"operator_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	// One serial number per unit received; required for serialized products
+	SerialNumbers []string `json:"serial_numbers" example:"SN-0001,SN-0002"`
+}
+
+// TransferStockRequest represents a request to transfer stock between warehouses
+//
+//	@Description	Request body for transferring stock from one warehouse to another
+type TransferStockRequest struct {
+	FromWarehouseID string  `json:"from_warehouse_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ToWarehouseID   string  `json:"to_warehouse_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440003"`
+	ProductID       string  `json:"product_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Quantity        float64 `json:"quantity" binding:"required,gt=0" example:"20.0"`
+	BatchID         string  `json:"batch_id" example:"550e8400-e29b-41d4-a716-446655440004"`
+	Reference       string  `json:"reference" example:"TR-2024-001"`
+	Reason          string  `json:"reason" example:"Rebalance stock between stores"`
+	OperatorID      string  `json:"operator_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	// One serial number per unit transferred; required for serialized products
+	SerialNumbers []string `json:"serial_numbers" example:"SN-0001"`
 }
 
 // LockStockRequest represents a request to lock stock
@@ -126,6 +144,12 @@ type DeductStockRequest struct {
 	SourceID   string `json:"source_id" binding:"required" example:"SO-2024-001"`
 	Reference  string `json:"reference" example:"Shipped via SF Express"`
 	OperatorID string `json:"operator_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	// Batches to take the stock from: FIFO (earliest received, default) or FEFO (soonest expiring)
+	BatchStrategy string `json:"batch_strategy" binding:"omitempty,oneof=FIFO FEFO" example:"FEFO"`
+	// One serial number per unit shipped; required for serialized products
+	SerialNumbers []string `json:"serial_numbers" example:"SN-0001"`
+	// Allow taking stock from batches that are expired or within the tenant's expiry buffer
+	AllowExpired bool `json:"allow_expired" example:"false"`
 }
 
 // AdjustStockRequest represents a request to adjust stock
@@ -210,6 +234,111 @@ type TransactionResponse struct {
 	CreatedAt       string  `json:"created_at" example:"2024-01-15T10:30:00Z"`
 }
 
+// TransferStockResponse represents the result of a stock transfer
+//
+//	@Description	Result of a stock transfer with the linked transfer-out and transfer-in transactions
+type TransferStockResponse struct {
+	TransferID          string                `json:"transfer_id" example:"550e8400-e29b-41d4-a716-446655440005"`
+	Quantity            float64               `json:"quantity" example:"20.0"`
+	UnitCost            float64               `json:"unit_cost" example:"15.50"`
+	BatchNumber         string                `json:"batch_number,omitempty" example:"BATCH-001"`
+	OutboundTransaction TransactionResponse   `json:"outbound_transaction"`
+	InboundTransaction  TransactionResponse   `json:"inbound_transaction"`
+	Source              InventoryItemResponse `json:"source"`
+	Destination         InventoryItemResponse `json:"destination"`
+}
+
+// ReorderSuggestionLineResponse represents a suggested purchase order line
+//
+//	@Description	Suggested purchase order line for an item below its minimum threshold
+type ReorderSuggestionLineResponse struct {
+	ProductID         string  `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductCode       string  `json:"product_code" example:"SKU-001"`
+	ProductName       string  `json:"product_name" example:"Compound Fertilizer"`
+	CurrentQuantity   float64 `json:"current_quantity" example:"8.0"`
+	MinQuantity       float64 `json:"min_quantity" example:"20.0"`
+	MaxQuantity       float64 `json:"max_quantity" example:"100.0"`
+	IncomingQuantity  float64 `json:"incoming_quantity" example:"12.0"`
+	ShortageQuantity  float64 `json:"shortage_quantity" example:"80.0"`
+	BaseUnit          string  `json:"base_unit" example:"bag"`
+	Unit              string  `json:"unit" example:"pallet"`
+	ConversionRate    float64 `json:"conversion_rate" example:"40.0"`
+	SuggestedQuantity float64 `json:"suggested_quantity" example:"2.0"`
+	UnitCost          float64 `json:"unit_cost" example:"1200.0"`
+	Amount            float64 `json:"amount" example:"2400.0"`
+}
+
+// SupplierReorderSuggestionResponse groups suggested purchase order lines by supplier
+//
+//	@Description	Suggested purchase order lines for one supplier; supplier_id is omitted for products never purchased
+type SupplierReorderSuggestionResponse struct {
+	SupplierID   string                          `json:"supplier_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440001"`
+	SupplierName string                          `json:"supplier_name,omitempty" example:"Green Fields Co."`
+	Lines        []ReorderSuggestionLineResponse `json:"lines"`
+	TotalAmount  float64                         `json:"total_amount" example:"2400.0"`
+}
+
+// ReorderSuggestionsResponse represents draft purchase order suggestions for a warehouse
+//
+//	@Description	Draft purchase order suggestions for items below their minimum threshold, grouped by supplier
+type ReorderSuggestionsResponse struct {
+	WarehouseID string                              `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Suppliers   []SupplierReorderSuggestionResponse `json:"suppliers"`
+	LineCount   int                                 `json:"line_count" example:"1"`
+}
+
+// InventorySnapshotItemResponse represents the on-hand quantity and value of one product as of the snapshot time
+//
+//	@Description	On-hand quantity and value of one product as of the snapshot time
+type InventorySnapshotItemResponse struct {
+	ProductID         string     `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	InventoryItemID   string     `json:"inventory_item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Quantity          float64    `json:"quantity" example:"95.0"`
+	UnitCost          float64    `json:"unit_cost" example:"10.5"`
+	TotalValue        float64    `json:"total_value" example:"997.5"`
+	LastTransactionAt *time.Time `json:"last_transaction_at,omitempty"`
+}
+
+// InventorySnapshotResponse represents the inventory of a warehouse as of a point in time
+//
+//	@Description	Inventory quantities and valuation of a warehouse reconstructed from the transaction ledger
+type InventorySnapshotResponse struct {
+	WarehouseID   string                          `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	AsOf          time.Time                       `json:"as_of"`
+	CostMethod    string                          `json:"cost_method" example:"moving_average"`
+	Items         []InventorySnapshotItemResponse `json:"items"`
+	TotalQuantity float64                         `json:"total_quantity" example:"95.0"`
+	TotalValue    float64                         `json:"total_value" example:"997.5"`
+}
+
+// SerialNumberMovementResponse represents a serialized unit entering or leaving stock
+//
+//	@Description	Movement of a serialized unit
+type SerialNumberMovementResponse struct {
+	ID              string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	MovementType    string    `json:"movement_type" example:"RECEIVED" enums:"RECEIVED,SHIPPED,RETURNED"`
+	InventoryItemID string    `json:"inventory_item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	WarehouseID     string    `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	SourceType      string    `json:"source_type" example:"PURCHASE_ORDER"`
+	SourceID        string    `json:"source_id" example:"PO-2024-001"`
+	OccurredAt      time.Time `json:"occurred_at"`
+}
+
+// SerialNumberResponse represents a serialized unit with its movement history
+//
+//	@Description	Serialized unit with its current status and movement history
+type SerialNumberResponse struct {
+	ID              string                         `json:"id" example:"550e8400-e29b-41d4-a716-446655440011"`
+	ProductID       string                         `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	InventoryItemID string                         `json:"inventory_item_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	WarehouseID     string                         `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	SerialNumber    string                         `json:"serial_number" example:"SN-0001"`
+	Status          string                         `json:"status" example:"IN_STOCK" enums:"IN_STOCK,SHIPPED,RETURNED"`
+	Movements       []SerialNumberMovementResponse `json:"movements"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	UpdatedAt       time.Time                      `json:"updated_at"`
+}
+
 // ===================== Query Handlers =====================
 
 // ===================== Query Handlers =====================
@@ -525,6 +654,177 @@ func (h *InventoryHandler) ListBelowMinimum(c *gin.Context) {
 	h.SuccessWithMeta(c, items, total, filter.Page, filter.PageSize)
 }
 
+// GetReorderSuggestions godoc
+//
+//	@ID				getInventoryReorderSuggestions
+//	@Summary		Get reorder suggestions
+//	@Description	Suggest draft purchase order lines, grouped by supplier, for items of a warehouse below their minimum threshold.
+//	@Description	Suggested quantities top items up to their maximum threshold, net of quantities still to be received on open purchase orders.
+//	@Tags			inventory
+//	@Produce		json
+//	@Param			X-Tenant-ID		header		string	false	"Tenant ID (optional for dev)"
+//	@Param			warehouse_id	query		string	true	"Warehouse ID"	format(uuid)
+//	@Success		200				{object}	APIResponse[ReorderSuggestionsResponse]
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/reorder-suggestions [get]
+func (h *InventoryHandler) GetReorderSuggestions(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	warehouseID, err := uuid.Parse(c.Query("warehouse_id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid or missing warehouse_id")
+		return
+	}
+
+	suggestions, err := h.inventoryService.GenerateReorderSuggestions(c.Request.Context(), tenantID, warehouseID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, suggestions)
+}
+
+// GetSnapshot godoc
+//
+//	@ID				getInventorySnapshot
+//	@Summary		Get inventory snapshot as of a date
+//	@Description	Reconstruct the on-hand quantity and valuation of every product in a warehouse as of a past timestamp from the transaction ledger.
+//	@Description	Transactions dated exactly at as_of are included. A date without a time covers the whole day.
+//	@Tags			inventory
+//	@Produce		json
+//	@Param			X-Tenant-ID		header		string	false	"Tenant ID (optional for dev)"
+//	@Param			warehouse_id	query		string	true	"Warehouse ID"	format(uuid)
+//	@Param			as_of			query		string	true	"Snapshot time (RFC3339 or YYYY-MM-DD)"
+//	@Success		200				{object}	APIResponse[InventorySnapshotResponse]
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/snapshot [get]
+func (h *InventoryHandler) GetSnapshot(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	warehouseID, err := uuid.Parse(c.Query("warehouse_id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid or missing warehouse_id")
+		return
+	}
+
+	asOfStr := c.Query("as_of")
+	asOf, err := parseDateTime(asOfStr)
+	if err != nil {
+		h.BadRequest(c, "Invalid or missing as_of")
+		return
+	}
+	if len(asOfStr) == len("2006-01-02") {
+		// A bare date includes every transaction of that day
+		asOf = asOf.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	snapshot, err := h.inventoryService.GetSnapshotAsOf(c.Request.Context(), tenantID, warehouseID, asOf)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, snapshot)
+}
+
+// GetSerialNumber godoc
+//
+//	@ID				getInventorySerialNumber
+//	@Summary		Get serial number
+//	@Description	Retrieve a serialized unit by ID with its movement history
+//	@Tags			inventory
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Serial number ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[SerialNumberResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/serials/{id} [get]
+func (h *InventoryHandler) GetSerialNumber(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid serial number ID format")
+		return
+	}
+
+	serial, err := h.inventoryService.GetSerialNumber(c.Request.Context(), tenantID, id)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, serial)
+}
+
+// LookupSerialNumber godoc
+//
+//	@ID				lookupInventorySerialNumber
+//	@Summary		Look up serial number
+//	@Description	Retrieve a product's serialized unit by serial number with its movement history
+//	@Tags			inventory
+//	@Produce		json
+//	@Param			X-Tenant-ID		header		string	false	"Tenant ID (optional for dev)"
+//	@Param			product_id		query		string	true	"Product ID"	format(uuid)
+//	@Param			serial_number	query		string	true	"Serial number"
+//	@Success		200				{object}	APIResponse[SerialNumberResponse]
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		404				{object}	dto.ErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/serials/lookup [get]
+func (h *InventoryHandler) LookupSerialNumber(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	productID, err := uuid.Parse(c.Query("product_id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid or missing product_id")
+		return
+	}
+
+	serialNumber := c.Query("serial_number")
+	if serialNumber == "" {
+		h.BadRequest(c, "serial_number is required")
+		return
+	}
+
+	serial, err := h.inventoryService.LookupSerialNumber(c.Request.Context(), tenantID, productID, serialNumber)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, serial)
+}
+
 // CheckAvailability godoc
 //
 //	@ID				checkAvailabilityInventory
@@ -628,15 +928,16 @@ func (h *InventoryHandler) IncreaseStock(c *gin.Context) {
 
 	// Convert to application DTO
 	appReq := inventoryapp.IncreaseStockRequest{
-		WarehouseID: warehouseID,
-		ProductID:   productID,
-		Quantity:    decimal.NewFromFloat(req.Quantity),
-		UnitCost:    decimal.NewFromFloat(req.UnitCost),
-		SourceType:  req.SourceType,
-		SourceID:    req.SourceID,
-		BatchNumber: req.BatchNumber,
-		Reference:   req.Reference,
-		Reason:      req.Reason,
+		WarehouseID:   warehouseID,
+		ProductID:     productID,
+		Quantity:      decimal.NewFromFloat(req.Quantity),
+		UnitCost:      decimal.NewFromFloat(req.UnitCost),
+		SourceType:    req.SourceType,
+		SourceID:      req.SourceID,
+		BatchNumber:   req.BatchNumber,
+		Reference:     req.Reference,
+		Reason:        req.Reason,
+		SerialNumbers: req.SerialNumbers,
 	}
 
 	// Parse optional expiry date
@@ -668,6 +969,97 @@ func (h *InventoryHandler) IncreaseStock(c *gin.Context) {
 	h.Success(c, item)
 }
 
+// TransferStock godoc
+//
+//	@ID				transferStockInventory
+//	@Summary		Transfer stock
+//	@Description	Transfer available stock of a product between warehouses in one transaction.
+//	@Description	Records a TRANSFER_OUT and a TRANSFER_IN transaction sharing the transfer ID; a batch transfer keeps the batch number.
+//	@Description	batch_id is required while the source holds stock in batches.
+//	@Tags			inventory
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string					false	"Tenant ID (optional for dev)"
+//	@Param			request		body		TransferStockRequest	true	"Stock transfer request"
+//	@Success		200			{object}	APIResponse[TransferStockResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/stock/transfer [post]
+func (h *InventoryHandler) TransferStock(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	var req TransferStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	fromWarehouseID, err := uuid.Parse(req.FromWarehouseID)
+	if err != nil {
+		h.BadRequest(c, "Invalid source warehouse ID format")
+		return
+	}
+
+	toWarehouseID, err := uuid.Parse(req.ToWarehouseID)
+	if err != nil {
+		h.BadRequest(c, "Invalid destination warehouse ID format")
+		return
+	}
+
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		h.BadRequest(c, "Invalid product ID format")
+		return
+	}
+
+	// Convert to application DTO
+	appReq := inventoryapp.TransferStockRequest{
+		FromWarehouseID: fromWarehouseID,
+		ToWarehouseID:   toWarehouseID,
+		ProductID:       productID,
+		Quantity:        decimal.NewFromFloat(req.Quantity),
+		Reference:       req.Reference,
+		Reason:          req.Reason,
+		SerialNumbers:   req.SerialNumbers,
+	}
+
+	// Parse optional batch ID
+	if req.BatchID != "" {
+		batchID, err := uuid.Parse(req.BatchID)
+		if err != nil {
+			h.BadRequest(c, "Invalid batch ID format")
+			return
+		}
+		appReq.BatchID = &batchID
+	}
+
+	// Parse optional operator ID
+	if req.OperatorID != "" {
+		opID, err := uuid.Parse(req.OperatorID)
+		if err != nil {
+			h.BadRequest(c, "Invalid operator ID format")
+			return
+		}
+		appReq.OperatorID = &opID
+	}
+
+	result, err := h.inventoryService.TransferStock(c.Request.Context(), tenantID, appReq)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, result)
+}
+
 // LockStock godoc
 //
 //	@ID				lockStockInventory
@@ -827,10 +1219,13 @@ func (h *InventoryHandler) DeductStock(c *gin.Context) {
 	}
 
 	appReq := inventoryapp.DeductStockRequest{
-		LockID:     lockID,
-		SourceType: req.SourceType,
-		SourceID:   req.SourceID,
-		Reference:  req.Reference,
+		LockID:        lockID,
+		SourceType:    req.SourceType,
+		SourceID:      req.SourceID,
+		Reference:     req.Reference,
+		BatchStrategy: req.BatchStrategy,
+		SerialNumbers: req.SerialNumbers,
+		AllowExpired:  req.AllowExpired,
 	}
 
 	// Parse optional operator ID
@@ -1106,6 +1501,8 @@ func (h *InventoryHandler) GetLockByID(c *gin.Context) {
 //	@Param			page_size			query		int		false	"Page size"				default(20)	maximum(100)
 //	@Param			order_by			query		string	false	"Order by field"		default(transaction_date)
 //	@Param			order_dir			query		string	false	"Order direction"		Enums(asc, desc)	default(desc)
+//	@Param			pagination			query		string	false	"Pagination mode; cursor orders by transaction_date and returns meta.next_cursor"	Enums(offset, cursor)	default(offset)
+//	@Param			cursor				query		string	false	"next_cursor from the previous page (implies pagination=cursor)"
 //	@Success		200					{object}	APIResponse[[]TransactionResponse]
 //	@Failure		400					{object}	dto.ErrorResponse
 //	@Failure		401					{object}	dto.ErrorResponse
@@ -1133,6 +1530,16 @@ func (h *InventoryHandler) ListTransactions(c *gin.Context) {
 		filter.PageSize = 20
 	}
 
+	if filter.UsesCursor() {
+		txs, nextCursor, err := h.inventoryService.ListTransactionsByCursor(c.Request.Context(), tenantID, filter)
+		if err != nil {
+			h.HandleDomainError(c, err)
+			return
+		}
+		h.SuccessWithCursor(c, txs, filter.PageSize, nextCursor)
+		return
+	}
+
 	txs, total, err := h.inventoryService.ListTransactions(c.Request.Context(), tenantID, filter)
 	if err != nil {
 		h.HandleDomainError(c, err)
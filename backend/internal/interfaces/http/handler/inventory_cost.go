@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	inventoryapp "github.com/erp/backend/internal/application/inventory"
+	"github.com/erp/backend/internal/interfaces/http/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// InventoryCostHandler handles the correction of receipt costs and the recalculation
+// of the issue costs that followed them
+type InventoryCostHandler struct {
+	BaseHandler
+	inventoryService *inventoryapp.InventoryService
+	recalculations   *inventoryapp.CostRecalculationQueue
+}
+
+// NewInventoryCostHandler creates a new InventoryCostHandler
+func NewInventoryCostHandler(inventoryService *inventoryapp.InventoryService, recalculations *inventoryapp.CostRecalculationQueue) *InventoryCostHandler {
+	return &InventoryCostHandler{
+		inventoryService: inventoryService,
+		recalculations:   recalculations,
+	}
+}
+
+// CorrectReceiptCostRequest represents a request to correct the cost of a receipt
+//
+//	@Description	Request body for correcting the unit cost of a receipt
+type CorrectReceiptCostRequest struct {
+	UnitCost   float64 `json:"unit_cost" binding:"gte=0" example:"12.50"`
+	Reason     string  `json:"reason" binding:"required,min=1,max=255" example:"Supplier invoice price was entered wrongly"`
+	OperatorID string  `json:"operator_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+}
+
+// RecalculateCostLayersRequest represents a request to recalculate the costs of a product
+//
+//	@Description	Request body for starting a cost layer recalculation
+type RecalculateCostLayersRequest struct {
+	WarehouseID string `json:"warehouse_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductID   string `json:"product_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440001"`
+	FromDate    string `json:"from_date" binding:"required" example:"2024-01-15"`
+}
+
+// CostRecalculationResultResponse summarizes a finished cost layer recalculation
+//
+//	@Description	Issues restated by a cost layer recalculation
+type CostRecalculationResultResponse struct {
+	InventoryItemID      string                `json:"inventory_item_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	CostMethod           string                `json:"cost_method" example:"moving_average"`
+	ReplayedTransactions int                   `json:"replayed_transactions" example:"120"`
+	RestatedIssues       int                   `json:"restated_issues" example:"4"`
+	COGSDifference       float64               `json:"cogs_difference" example:"-25.00"`
+	UnitCostBefore       float64               `json:"unit_cost_before" example:"15.50"`
+	UnitCostAfter        float64               `json:"unit_cost_after" example:"15.00"`
+	Adjustments          []TransactionResponse `json:"adjustments"`
+}
+
+// CostRecalculationJobResponse represents a cost layer recalculation running in the background
+//
+//	@Description	Cost layer recalculation job with its progress
+type CostRecalculationJobResponse struct {
+	ID          string                           `json:"id" example:"550e8400-e29b-41d4-a716-446655440005"`
+	Status      string                           `json:"status" example:"RUNNING" enums:"PENDING,RUNNING,COMPLETED,FAILED"`
+	WarehouseID string                           `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductID   string                           `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	FromDate    string                           `json:"from_date" example:"2024-01-15T00:00:00Z"`
+	Processed   int                              `json:"processed" example:"60"`
+	Total       int                              `json:"total" example:"120"`
+	Result      *CostRecalculationResultResponse `json:"result,omitempty"`
+	Error       string                           `json:"error,omitempty"`
+	CreatedAt   string                           `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	StartedAt   string                           `json:"started_at,omitempty" example:"2024-01-15T10:30:01Z"`
+	CompletedAt string                           `json:"completed_at,omitempty" example:"2024-01-15T10:30:09Z"`
+}
+
+// CorrectReceiptCost godoc
+//
+//	@ID				correctInventoryReceiptCost
+//	@Summary		Correct the cost of a receipt
+//	@Description	Record a correction of the unit cost of a receipt as a cost adjustment transaction.
+//	@Description	Issues after the receipt keep their cost until the costs are recalculated from the receipt's date.
+//	@Tags			inventory
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string						false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string						true	"Receipt transaction ID"	format(uuid)
+//	@Param			request		body		CorrectReceiptCostRequest	true	"Cost correction request"
+//	@Success		201			{object}	APIResponse[TransactionResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/transactions/{id}/cost-correction [post]
+func (h *InventoryCostHandler) CorrectReceiptCost(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	txID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid transaction ID format")
+		return
+	}
+
+	var req CorrectReceiptCostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	appReq := inventoryapp.CorrectReceiptCostRequest{
+		UnitCost: decimal.NewFromFloat(req.UnitCost),
+		Reason:   req.Reason,
+	}
+	if req.OperatorID != "" {
+		opID, err := uuid.Parse(req.OperatorID)
+		if err != nil {
+			h.BadRequest(c, "Invalid operator ID format")
+			return
+		}
+		appReq.OperatorID = &opID
+	}
+
+	correction, err := h.inventoryService.CorrectReceiptCost(c.Request.Context(), tenantID, txID, appReq)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Created(c, correction)
+}
+
+// StartCostRecalculation godoc
+//
+//	@ID				startInventoryCostRecalculation
+//	@Summary		Recalculate costs in the background
+//	@Description	Queue a replay of the transactions of a product in a warehouse under the tenant's cost strategy.
+//	@Description	Issues from from_date on whose cost changed get cost adjustment transactions for the difference.
+//	@Description	Poll GET /inventory/cost-recalculations/{id} until the status is COMPLETED or FAILED.
+//	@Tags			inventory
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string							false	"Tenant ID (optional for dev)"
+//	@Param			request		body		RecalculateCostLayersRequest	true	"Recalculation request"
+//	@Success		202			{object}	APIResponse[CostRecalculationJobResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		429			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/cost-recalculations [post]
+func (h *InventoryCostHandler) StartCostRecalculation(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	var req RecalculateCostLayersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	warehouseID, err := uuid.Parse(req.WarehouseID)
+	if err != nil {
+		h.BadRequest(c, "Invalid warehouse ID format")
+		return
+	}
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		h.BadRequest(c, "Invalid product ID format")
+		return
+	}
+	fromDate, err := parseDateTime(req.FromDate)
+	if err != nil {
+		h.BadRequest(c, "Invalid from_date format")
+		return
+	}
+
+	job, err := h.recalculations.Enqueue(c.Request.Context(), tenantID, inventoryapp.RecalculateCostLayersRequest{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		FromDate:    fromDate,
+	})
+	if err != nil {
+		if errors.Is(err, inventoryapp.ErrCostRecalculationQueueFull) {
+			h.TooManyRequests(c, "Too many cost recalculations are waiting. Please try again later.")
+			return
+		}
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.NewSuccessResponse(job))
+}
+
+// GetCostRecalculation godoc
+//
+//	@ID				getInventoryCostRecalculation
+//	@Summary		Get cost recalculation job
+//	@Description	Retrieve a cost recalculation job with its progress and, once completed, the restated issues.
+//	@Description	Finished jobs can be polled for a day.
+//	@Tags			inventory
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Job ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[CostRecalculationJobResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/inventory/cost-recalculations/{id} [get]
+func (h *InventoryCostHandler) GetCostRecalculation(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid job ID format")
+		return
+	}
+
+	job, err := h.recalculations.GetJob(tenantID, jobID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, job)
+}
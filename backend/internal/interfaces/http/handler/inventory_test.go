@@ -520,6 +520,14 @@ func (m *mockInventoryTransactionRepository) CountByInventoryItem(ctx context.Co
 	return count, nil
 }
 
+func (m *mockInventoryTransactionRepository) FindByWarehouseAsOf(ctx context.Context, tenantID, warehouseID uuid.UUID, asOf time.Time) ([]inventory.InventoryTransaction, error) {
+	return nil, nil
+}
+
+func (m *mockInventoryTransactionRepository) FindLedgerByInventoryItem(ctx context.Context, tenantID, inventoryItemID uuid.UUID) ([]inventory.InventoryTransaction, error) {
+	return nil, nil
+}
+
 func (m *mockInventoryTransactionRepository) SumQuantityByTypeAndDateRange(ctx context.Context, tenantID uuid.UUID, txType inventory.TransactionType, start, end time.Time) (decimal.Decimal, error) {
 	return decimal.Zero, nil
 }
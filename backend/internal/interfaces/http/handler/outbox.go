@@ -164,6 +164,29 @@ func (h *OutboxHandler) GetStats(c *gin.Context) {
 	h.Success(c, toOutboxStatsResponse(stats))
 }
 
+// GetHealth godoc
+//
+//	@ID				getOutboxHealth
+//	@Summary		Get dead letter queue health
+//	@Description	Get the dead letter queue health for dashboards: green without dead letters, red when the
+//	@Description	dead letter count or the age of the oldest dead letter exceeds its configured threshold, amber otherwise
+//	@Tags			outbox
+//	@Produce		json
+//	@Success		200	{object}	APIResponse[OutboxHealthResponse]
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/system/outbox/health [get]
+func (h *OutboxHandler) GetHealth(c *gin.Context) {
+	health, err := h.outboxService.GetHealth(c.Request.Context())
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	h.Success(c, toOutboxHealthResponse(health))
+}
+
 // Request/Response types for swagger
 
 // OutboxEntryResponse represents an outbox entry in API response
@@ -203,6 +226,18 @@ type OutboxStatsResponse struct {
 	Total      int64 `json:"total"`
 }
 
+// OutboxHealthResponse represents the dead letter queue health response
+type OutboxHealthResponse struct {
+	Status           string   `json:"status" enums:"green,amber,red"`
+	DeadCount        int64    `json:"dead_count"`
+	OldestDeadAt     *string  `json:"oldest_dead_at,omitempty"`
+	OldestAgeSeconds int64    `json:"oldest_age_seconds"`
+	SampleEventTypes []string `json:"sample_event_types"`
+	Threshold        int64    `json:"threshold"`
+	MaxAgeSeconds    int64    `json:"max_age_seconds"`
+	CheckedAt        string   `json:"checked_at"`
+}
+
 // RetryAllResponse represents the response for retry all operation
 type RetryAllResponse struct {
 	Count int64 `json:"count"`
@@ -260,3 +295,20 @@ func toOutboxStatsResponse(dto *event.OutboxStatsDTO) OutboxStatsResponse {
 		Total:      dto.Total,
 	}
 }
+
+func toOutboxHealthResponse(dto *event.OutboxHealthDTO) OutboxHealthResponse {
+	resp := OutboxHealthResponse{
+		Status:           dto.Status,
+		DeadCount:        dto.DeadCount,
+		OldestAgeSeconds: dto.OldestAgeSeconds,
+		SampleEventTypes: dto.SampleEventTypes,
+		Threshold:        dto.Threshold,
+		MaxAgeSeconds:    dto.MaxAgeSeconds,
+		CheckedAt:        dto.CheckedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if dto.OldestDeadAt != nil {
+		t := dto.OldestDeadAt.Format("2006-01-02T15:04:05Z07:00")
+		resp.OldestDeadAt = &t
+	}
+	return resp
+}
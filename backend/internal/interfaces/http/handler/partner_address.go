@@ -0,0 +1,430 @@
+package handler
+
+import (
+	partnerapp "github.com/erp/backend/internal/application/partner"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PartnerAddressHandler handles the address book endpoints of customers and suppliers
+type PartnerAddressHandler struct {
+	BaseHandler
+	addressService *partnerapp.AddressService
+}
+
+// NewPartnerAddressHandler creates a new PartnerAddressHandler
+func NewPartnerAddressHandler(addressService *partnerapp.AddressService) *PartnerAddressHandler {
+	return &PartnerAddressHandler{
+		addressService: addressService,
+	}
+}
+
+// CreateAddressRequest represents a request to add an address to an address book
+//
+//	@Description	Request body for adding a billing or shipping address
+type CreateAddressRequest struct {
+	Type        string `json:"type" binding:"required,oneof=billing shipping" example:"shipping" enums:"billing,shipping"`
+	Label       string `json:"label" binding:"max=100" example:"Warehouse"`
+	ContactName string `json:"contact_name" binding:"max=100" example:"John Doe"`
+	Phone       string `json:"phone" binding:"max=50" example:"13800138000"`
+	Province    string `json:"province" binding:"max=100" example:"Shanghai"`
+	City        string `json:"city" binding:"max=100" example:"Shanghai"`
+	Detail      string `json:"detail" binding:"required,min=1,max=500" example:"88 Dock Rd"`
+	PostalCode  string `json:"postal_code" binding:"max=20" example:"200000"`
+	Country     string `json:"country" binding:"max=100" example:"China"`
+	IsDefault   bool   `json:"is_default" example:"false"`
+}
+
+// UpdateAddressRequest represents a request to update an address
+//
+//	@Description	Request body for updating an address. is_default can only be set to true; the previous default of the same type is demoted.
+type UpdateAddressRequest struct {
+	Label       *string `json:"label" binding:"omitempty,max=100" example:"Warehouse"`
+	ContactName *string `json:"contact_name" binding:"omitempty,max=100" example:"John Doe"`
+	Phone       *string `json:"phone" binding:"omitempty,max=50" example:"13800138000"`
+	Province    *string `json:"province" binding:"omitempty,max=100" example:"Shanghai"`
+	City        *string `json:"city" binding:"omitempty,max=100" example:"Shanghai"`
+	Detail      *string `json:"detail" binding:"omitempty,min=1,max=500" example:"88 Dock Rd"`
+	PostalCode  *string `json:"postal_code" binding:"omitempty,max=20" example:"200000"`
+	Country     *string `json:"country" binding:"omitempty,max=100" example:"China"`
+	IsDefault   *bool   `json:"is_default" example:"true"`
+}
+
+// AddressResponse represents an address in API responses
+//
+//	@Description	Billing or shipping address of a customer or supplier
+type AddressResponse struct {
+	ID          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OwnerType   string `json:"owner_type" example:"customer" enums:"customer,supplier"`
+	OwnerID     string `json:"owner_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Type        string `json:"type" example:"shipping" enums:"billing,shipping"`
+	Label       string `json:"label" example:"Warehouse"`
+	ContactName string `json:"contact_name" example:"John Doe"`
+	Phone       string `json:"phone" example:"13800138000"`
+	Province    string `json:"province" example:"Shanghai"`
+	City        string `json:"city" example:"Shanghai"`
+	Detail      string `json:"detail" example:"88 Dock Rd"`
+	PostalCode  string `json:"postal_code" example:"200000"`
+	Country     string `json:"country" example:"China"`
+	IsDefault   bool   `json:"is_default" example:"true"`
+	CreatedAt   string `json:"created_at" example:"2026-01-24T12:00:00Z"`
+	UpdatedAt   string `json:"updated_at" example:"2026-01-24T12:00:00Z"`
+}
+
+// ListCustomerAddresses godoc
+//
+//	@ID				listCustomerAddresses
+//	@Summary		List customer addresses
+//	@Description	List the billing and shipping addresses of a customer
+//	@Tags			customers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Customer ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[[]AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/customers/{id}/addresses [get]
+func (h *PartnerAddressHandler) ListCustomerAddresses(c *gin.Context) {
+	h.list(c, partner.AddressOwnerCustomer)
+}
+
+// CreateCustomerAddress godoc
+//
+//	@ID				createCustomerAddress
+//	@Summary		Add a customer address
+//	@Description	Add a billing or shipping address to a customer. The first address of a type becomes its default.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string					false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string					true	"Customer ID"	format(uuid)
+//	@Param			request		body		CreateAddressRequest	true	"Address creation request"
+//	@Success		201			{object}	APIResponse[AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/customers/{id}/addresses [post]
+func (h *PartnerAddressHandler) CreateCustomerAddress(c *gin.Context) {
+	h.create(c, partner.AddressOwnerCustomer)
+}
+
+// GetCustomerAddress godoc
+//
+//	@ID				getCustomerAddress
+//	@Summary		Get a customer address
+//	@Description	Retrieve an address of a customer
+//	@Tags			customers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Customer ID"	format(uuid)
+//	@Param			address_id	path		string	true	"Address ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/customers/{id}/addresses/{address_id} [get]
+func (h *PartnerAddressHandler) GetCustomerAddress(c *gin.Context) {
+	h.get(c, partner.AddressOwnerCustomer)
+}
+
+// UpdateCustomerAddress godoc
+//
+//	@ID				updateCustomerAddress
+//	@Summary		Update a customer address
+//	@Description	Update an address of a customer. Setting is_default demotes the previous default of the same type.
+//	@Tags			customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string					false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string					true	"Customer ID"	format(uuid)
+//	@Param			address_id	path		string					true	"Address ID"	format(uuid)
+//	@Param			request		body		UpdateAddressRequest	true	"Address update request"
+//	@Success		200			{object}	APIResponse[AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/customers/{id}/addresses/{address_id} [put]
+func (h *PartnerAddressHandler) UpdateCustomerAddress(c *gin.Context) {
+	h.update(c, partner.AddressOwnerCustomer)
+}
+
+// DeleteCustomerAddress godoc
+//
+//	@ID				deleteCustomerAddress
+//	@Summary		Delete a customer address
+//	@Description	Delete an address of a customer. Deleting the default promotes the oldest remaining address of the same type.
+//	@Tags			customers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header	string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path	string	true	"Customer ID"	format(uuid)
+//	@Param			address_id	path	string	true	"Address ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/customers/{id}/addresses/{address_id} [delete]
+func (h *PartnerAddressHandler) DeleteCustomerAddress(c *gin.Context) {
+	h.delete(c, partner.AddressOwnerCustomer)
+}
+
+// ListSupplierAddresses godoc
+//
+//	@ID				listSupplierAddresses
+//	@Summary		List supplier addresses
+//	@Description	List the billing and shipping addresses of a supplier
+//	@Tags			suppliers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Supplier ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[[]AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/suppliers/{id}/addresses [get]
+func (h *PartnerAddressHandler) ListSupplierAddresses(c *gin.Context) {
+	h.list(c, partner.AddressOwnerSupplier)
+}
+
+// CreateSupplierAddress godoc
+//
+//	@ID				createSupplierAddress
+//	@Summary		Add a supplier address
+//	@Description	Add a billing or shipping address to a supplier. The first address of a type becomes its default.
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string					false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string					true	"Supplier ID"	format(uuid)
+//	@Param			request		body		CreateAddressRequest	true	"Address creation request"
+//	@Success		201			{object}	APIResponse[AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/suppliers/{id}/addresses [post]
+func (h *PartnerAddressHandler) CreateSupplierAddress(c *gin.Context) {
+	h.create(c, partner.AddressOwnerSupplier)
+}
+
+// GetSupplierAddress godoc
+//
+//	@ID				getSupplierAddress
+//	@Summary		Get a supplier address
+//	@Description	Retrieve an address of a supplier
+//	@Tags			suppliers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Supplier ID"	format(uuid)
+//	@Param			address_id	path		string	true	"Address ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/suppliers/{id}/addresses/{address_id} [get]
+func (h *PartnerAddressHandler) GetSupplierAddress(c *gin.Context) {
+	h.get(c, partner.AddressOwnerSupplier)
+}
+
+// UpdateSupplierAddress godoc
+//
+//	@ID				updateSupplierAddress
+//	@Summary		Update a supplier address
+//	@Description	Update an address of a supplier. Setting is_default demotes the previous default of the same type.
+//	@Tags			suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string					false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string					true	"Supplier ID"	format(uuid)
+//	@Param			address_id	path		string					true	"Address ID"	format(uuid)
+//	@Param			request		body		UpdateAddressRequest	true	"Address update request"
+//	@Success		200			{object}	APIResponse[AddressResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/suppliers/{id}/addresses/{address_id} [put]
+func (h *PartnerAddressHandler) UpdateSupplierAddress(c *gin.Context) {
+	h.update(c, partner.AddressOwnerSupplier)
+}
+
+// DeleteSupplierAddress godoc
+//
+//	@ID				deleteSupplierAddress
+//	@Summary		Delete a supplier address
+//	@Description	Delete an address of a supplier. Deleting the default promotes the oldest remaining address of the same type.
+//	@Tags			suppliers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header	string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path	string	true	"Supplier ID"	format(uuid)
+//	@Param			address_id	path	string	true	"Address ID"	format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/suppliers/{id}/addresses/{address_id} [delete]
+func (h *PartnerAddressHandler) DeleteSupplierAddress(c *gin.Context) {
+	h.delete(c, partner.AddressOwnerSupplier)
+}
+
+func (h *PartnerAddressHandler) list(c *gin.Context, ownerType partner.AddressOwnerType) {
+	tenantID, ownerID, ok := h.parseOwner(c, ownerType)
+	if !ok {
+		return
+	}
+
+	addresses, err := h.addressService.List(c.Request.Context(), tenantID, ownerType, ownerID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, addresses)
+}
+
+func (h *PartnerAddressHandler) create(c *gin.Context, ownerType partner.AddressOwnerType) {
+	tenantID, ownerID, ok := h.parseOwner(c, ownerType)
+	if !ok {
+		return
+	}
+
+	var req CreateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	address, err := h.addressService.Create(c.Request.Context(), tenantID, ownerType, ownerID, partnerapp.CreateAddressRequest{
+		Type:        req.Type,
+		Label:       req.Label,
+		ContactName: req.ContactName,
+		Phone:       req.Phone,
+		Province:    req.Province,
+		City:        req.City,
+		Detail:      req.Detail,
+		PostalCode:  req.PostalCode,
+		Country:     req.Country,
+		IsDefault:   req.IsDefault,
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Created(c, address)
+}
+
+func (h *PartnerAddressHandler) get(c *gin.Context, ownerType partner.AddressOwnerType) {
+	tenantID, ownerID, ok := h.parseOwner(c, ownerType)
+	if !ok {
+		return
+	}
+	addressID, ok := h.parseAddressID(c)
+	if !ok {
+		return
+	}
+
+	address, err := h.addressService.GetByID(c.Request.Context(), tenantID, ownerType, ownerID, addressID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, address)
+}
+
+func (h *PartnerAddressHandler) update(c *gin.Context, ownerType partner.AddressOwnerType) {
+	tenantID, ownerID, ok := h.parseOwner(c, ownerType)
+	if !ok {
+		return
+	}
+	addressID, ok := h.parseAddressID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	address, err := h.addressService.Update(c.Request.Context(), tenantID, ownerType, ownerID, addressID, partnerapp.UpdateAddressRequest{
+		Label:       req.Label,
+		ContactName: req.ContactName,
+		Phone:       req.Phone,
+		Province:    req.Province,
+		City:        req.City,
+		Detail:      req.Detail,
+		PostalCode:  req.PostalCode,
+		Country:     req.Country,
+		IsDefault:   req.IsDefault,
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, address)
+}
+
+func (h *PartnerAddressHandler) delete(c *gin.Context, ownerType partner.AddressOwnerType) {
+	tenantID, ownerID, ok := h.parseOwner(c, ownerType)
+	if !ok {
+		return
+	}
+	addressID, ok := h.parseAddressID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.addressService.Delete(c.Request.Context(), tenantID, ownerType, ownerID, addressID); err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.NoContent(c)
+}
+
+// parseOwner reads the tenant and the customer or supplier ID of the request, responding with 400 if either is invalid
+func (h *PartnerAddressHandler) parseOwner(c *gin.Context, ownerType partner.AddressOwnerType) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	ownerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		if ownerType == partner.AddressOwnerSupplier {
+			h.BadRequest(c, "Invalid supplier ID format")
+		} else {
+			h.BadRequest(c, "Invalid customer ID format")
+		}
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, ownerID, true
+}
+
+// parseAddressID reads the address ID of the request, responding with 400 if it is invalid
+func (h *PartnerAddressHandler) parseAddressID(c *gin.Context) (uuid.UUID, bool) {
+	addressID, err := uuid.Parse(c.Param("address_id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid address ID format")
+		return uuid.Nil, false
+	}
+	return addressID, true
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"io"
 	"net/http"
 
@@ -102,6 +103,46 @@ func (h *PaymentCallbackHandler) HandleAlipayRefundCallback(c *gin.Context) {
 	h.handleRefundCallback(c, finance.PaymentGatewayTypeAlipay)
 }
 
+// HandleGatewayCallback godoc
+//
+//	@ID				handleGatewayCallbackPaymentCallback
+//	@Summary		Handle payment callback for a registered gateway
+//	@Description	Receive a payment notification and dispatch it to the gateway registered under the given name. The gateway verifies the raw body and headers and its acknowledgement is returned as-is.
+//	@Tags			payment-callbacks
+//	@Accept			json
+//	@Produce		json
+//	@Param			gateway	path		string	true	"Registered gateway name"	example(unionpay)
+//	@Success		200		{string}	string	"Gateway-specific acknowledgement"
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Router			/payment/callback/{gateway} [post]
+func (h *PaymentCallbackHandler) HandleGatewayCallback(c *gin.Context) {
+	name := c.Param("gateway")
+	if _, err := h.callbackService.Registry().Get(name); err != nil {
+		h.NotFound(c, "Payment gateway not found")
+		return
+	}
+
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		h.BadRequest(c, "Failed to read request body")
+		return
+	}
+
+	result, err := h.callbackService.ProcessGatewayCallback(c.Request.Context(), name, payload, c.Request.Header)
+	if result == nil {
+		if errors.Is(err, financeapp.ErrGatewayNotFound) {
+			h.NotFound(c, "Payment gateway not found")
+			return
+		}
+		h.InternalError(c, "Failed to process payment callback")
+		return
+	}
+
+	// Gateways expect their own acknowledgement format, including for failures
+	c.Data(http.StatusOK, result.GatewayContentType, result.GatewayResponse)
+}
+
 // handlePaymentCallback is the internal handler for payment callbacks
 func (h *PaymentCallbackHandler) handlePaymentCallback(c *gin.Context, gatewayType finance.PaymentGatewayType) {
 	// Read the raw request body
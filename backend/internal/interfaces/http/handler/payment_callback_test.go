@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	financeapp "github.com/erp/backend/internal/application/finance"
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCallbackGateway accepts JSON notifications signed with a fixed header value
+type fakeCallbackGateway struct {
+	parsed int
+}
+
+func (g *fakeCallbackGateway) VerifyCallback(ctx context.Context, rawBody []byte, headers http.Header) error {
+	if headers.Get("X-Fake-Signature") != "valid" {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func (g *fakeCallbackGateway) ParsePayment(ctx context.Context, rawBody []byte, headers http.Header) (*finance.PaymentCallback, error) {
+	g.parsed++
+	var notification struct {
+		OrderNumber   string `json:"order_number"`
+		TransactionID string `json:"transaction_id"`
+		Amount        string `json:"amount"`
+	}
+	if err := json.Unmarshal(rawBody, &notification); err != nil {
+		return nil, err
+	}
+	amount, err := decimal.NewFromString(notification.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return &finance.PaymentCallback{
+		GatewayType:          finance.PaymentGatewayType("FAKEPAY"),
+		GatewayOrderID:       notification.OrderNumber,
+		GatewayTransactionID: notification.TransactionID,
+		OrderNumber:          notification.OrderNumber,
+		Status:               finance.GatewayPaymentStatusPaid,
+		Amount:               amount,
+		PaidAmount:           amount,
+		RawPayload:           string(rawBody),
+	}, nil
+}
+
+func (g *fakeCallbackGateway) CallbackResponse(success bool, message string) (string, []byte) {
+	if success {
+		return "text/plain", []byte("OK")
+	}
+	return "text/plain", []byte("FAIL")
+}
+
+// callbackVoucherRepository serves a single receipt voucher by payment reference
+type callbackVoucherRepository struct {
+	finance.ReceiptVoucherRepository
+	voucher *finance.ReceiptVoucher
+	lookups int
+}
+
+func (r *callbackVoucherRepository) FindByPaymentReference(ctx context.Context, paymentReference string) (*finance.ReceiptVoucher, error) {
+	r.lookups++
+	if r.voucher.PaymentReference != paymentReference {
+		return nil, nil
+	}
+	return r.voucher, nil
+}
+
+func setupGatewayCallbackTest(t *testing.T) (*gin.Engine, *fakeCallbackGateway, *callbackVoucherRepository) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	voucher, err := finance.NewReceiptVoucher(uuid.New(), "RV-FAKE-001", uuid.New(), "Test Customer",
+		valueobject.NewMoneyCNYFromFloat(88.50), finance.PaymentMethodOther, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, voucher.SetPaymentReference("ORD-1001"))
+	// Already confirmed, so a successful callback is acknowledged without re-confirming
+	require.NoError(t, voucher.Confirm(uuid.New()))
+	repo := &callbackVoucherRepository{voucher: voucher}
+
+	gateway := &fakeCallbackGateway{}
+	registry := financeapp.NewGatewayRegistry()
+	require.NoError(t, registry.Register("fakepay", gateway))
+
+	service := financeapp.NewPaymentCallbackService(financeapp.PaymentCallbackServiceConfig{
+		Registry:           registry,
+		ReceiptVoucherRepo: repo,
+	})
+	h := NewPaymentCallbackHandler(service)
+
+	router := gin.New()
+	router.POST("/api/v1/payment/callback/:gateway", h.HandleGatewayCallback)
+	return router, gateway, repo
+}
+
+func postGatewayCallback(router *gin.Engine, gateway, signature string) *httptest.ResponseRecorder {
+	body := []byte(`{"order_number":"ORD-1001","transaction_id":"TXN-9001","amount":"88.50"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/payment/callback/"+gateway, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Fake-Signature", signature)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPaymentCallbackHandler_HandleGatewayCallback(t *testing.T) {
+	t.Run("dispatches to the registered gateway", func(t *testing.T) {
+		router, gateway, repo := setupGatewayCallbackTest(t)
+
+		w := postGatewayCallback(router, "fakepay", "valid")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "OK", w.Body.String())
+		assert.Equal(t, 1, gateway.parsed)
+		assert.Equal(t, 1, repo.lookups)
+	})
+
+	t.Run("gateway name is case-insensitive", func(t *testing.T) {
+		router, gateway, _ := setupGatewayCallbackTest(t)
+
+		w := postGatewayCallback(router, "FakePay", "valid")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, gateway.parsed)
+	})
+
+	t.Run("acknowledges failure when verification fails", func(t *testing.T) {
+		router, gateway, repo := setupGatewayCallbackTest(t)
+
+		w := postGatewayCallback(router, "fakepay", "forged")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "FAIL", w.Body.String())
+		assert.Zero(t, gateway.parsed)
+		assert.Zero(t, repo.lookups)
+	})
+
+	t.Run("returns 404 for unknown gateway", func(t *testing.T) {
+		router, gateway, repo := setupGatewayCallbackTest(t)
+
+		w := postGatewayCallback(router, "unknownpay", "valid")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Zero(t, gateway.parsed)
+		assert.Zero(t, repo.lookups)
+	})
+}
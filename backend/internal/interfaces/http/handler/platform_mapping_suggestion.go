@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"context"
+
+	integrationapp "github.com/erp/backend/internal/application/integration"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ProductMappingSuggester proposes and confirms product mappings for a platform
+type ProductMappingSuggester interface {
+	SuggestProductMappings(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*integrationapp.MappingSuggestionResult, error)
+	ConfirmProductMappings(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, inputs []integrationapp.ConfirmProductMappingInput) (*integrationapp.ConfirmProductMappingsResult, error)
+}
+
+// Ensure the application service satisfies ProductMappingSuggester
+var _ ProductMappingSuggester = (*integrationapp.MappingSuggestionService)(nil)
+
+// PlatformMappingSuggestionHandler handles product mapping suggestion HTTP requests
+type PlatformMappingSuggestionHandler struct {
+	BaseHandler
+	suggester ProductMappingSuggester
+}
+
+// NewPlatformMappingSuggestionHandler creates a new product mapping suggestion handler
+func NewPlatformMappingSuggestionHandler(suggester ProductMappingSuggester) *PlatformMappingSuggestionHandler {
+	return &PlatformMappingSuggestionHandler{suggester: suggester}
+}
+
+// ProductMappingSuggestionResponse represents a suggested product mapping
+//
+//	@Description	A local product proposed for an unmapped platform product
+type ProductMappingSuggestionResponse struct {
+	PlatformProductID   string   `json:"platform_product_id" example:"652731044381"`
+	PlatformProductName string   `json:"platform_product_name" example:"纯棉短袖T恤 红色"`
+	PlatformProductCode string   `json:"platform_product_code,omitempty" example:"TEE-RED"`
+	PlatformSkuIDs      []string `json:"platform_sku_ids" example:"4821760053211"`
+	LocalProductID      string   `json:"local_product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	LocalProductCode    string   `json:"local_product_code" example:"TEE-RED"`
+	LocalProductName    string   `json:"local_product_name" example:"红色T恤"`
+	Confidence          float64  `json:"confidence" example:"1"`
+	MatchReason         string   `json:"match_reason" example:"SKU_MATCH" enums:"SKU_MATCH,BARCODE_MATCH,NAME_SIMILARITY"`
+	MatchedValue        string   `json:"matched_value" example:"TEE-RED"`
+}
+
+// ProductMappingSuggestionsResponse represents the suggested mappings for a platform
+//
+//	@Description	Suggested mappings for unmapped platform products, highest confidence first
+type ProductMappingSuggestionsResponse struct {
+	PlatformCode       string                             `json:"platform_code" example:"TAOBAO"`
+	ScannedCount       int                                `json:"scanned_count" example:"120"`
+	AlreadyMappedCount int                                `json:"already_mapped_count" example:"80"`
+	UnmatchedCount     int                                `json:"unmatched_count" example:"12"`
+	Suggestions        []ProductMappingSuggestionResponse `json:"suggestions"`
+}
+
+// ConfirmProductMappingsRequest represents the suggestions a user accepted
+type ConfirmProductMappingsRequest struct {
+	Mappings []integrationapp.ConfirmProductMappingInput `json:"mappings" binding:"required,min=1,max=100,dive"`
+}
+
+// ConfirmedProductMappingResponse represents a mapping created from an accepted suggestion
+//
+//	@Description	A product mapping created from an accepted suggestion
+type ConfirmedProductMappingResponse struct {
+	ID                string   `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	PlatformProductID string   `json:"platform_product_id" example:"652731044381"`
+	LocalProductID    string   `json:"local_product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PlatformSkuIDs    []string `json:"platform_sku_ids"`
+}
+
+// ConfirmProductMappingFailureResponse represents an accepted suggestion that was not persisted
+//
+//	@Description	An accepted suggestion that could not be persisted
+type ConfirmProductMappingFailureResponse struct {
+	PlatformProductID string `json:"platform_product_id" example:"652731044382"`
+	Error             string `json:"error" example:"integration: product mapping already exists"`
+}
+
+// ConfirmProductMappingsResponse represents the result of confirming suggestions
+//
+//	@Description	Mappings created from accepted suggestions, with per-product failures
+type ConfirmProductMappingsResponse struct {
+	Created  []ConfirmedProductMappingResponse      `json:"created"`
+	Failures []ConfirmProductMappingFailureResponse `json:"failures"`
+}
+
+// GetMappingSuggestions godoc
+//
+//	@ID				getPlatformMappingSuggestions
+//	@Summary		Suggest product mappings
+//	@Description	Fetch the platform's products and propose a local product for each unmapped one: by exact SKU (product code) match, then barcode match, then name similarity. Each suggestion has a confidence score and the reason it matched. No mappings are created; confirm accepted suggestions with POST /integration/platforms/{platform}/mapping-suggestions/confirm.
+//	@Tags			integration
+//	@Produce		json
+//	@Param			platform	path		string	true	"Platform code"	Enums(TAOBAO, JD, PDD, DOUYIN, WECHAT, KUAISHOU)
+//	@Success		200			{object}	APIResponse[ProductMappingSuggestionsResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/integration/platforms/{platform}/mapping-suggestions [get]
+func (h *PlatformMappingSuggestionHandler) GetMappingSuggestions(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.Unauthorized(c, "Tenant ID not found in token")
+		return
+	}
+
+	platformCode, ok := parsePlatformCode(c)
+	if !ok {
+		h.BadRequest(c, "Invalid platform code")
+		return
+	}
+
+	result, err := h.suggester.SuggestProductMappings(c.Request.Context(), tenantID, platformCode)
+	if err != nil {
+		handlePlatformError(&h.BaseHandler, c, err)
+		return
+	}
+
+	resp := ProductMappingSuggestionsResponse{
+		PlatformCode:       result.PlatformCode.String(),
+		ScannedCount:       result.ScannedCount,
+		AlreadyMappedCount: result.AlreadyMappedCount,
+		UnmatchedCount:     result.UnmatchedCount,
+		Suggestions:        make([]ProductMappingSuggestionResponse, len(result.Suggestions)),
+	}
+	for i, s := range result.Suggestions {
+		resp.Suggestions[i] = ProductMappingSuggestionResponse{
+			PlatformProductID:   s.PlatformProductID,
+			PlatformProductName: s.PlatformProductName,
+			PlatformProductCode: s.PlatformProductCode,
+			PlatformSkuIDs:      s.PlatformSkuIDs,
+			LocalProductID:      s.LocalProductID.String(),
+			LocalProductCode:    s.LocalProductCode,
+			LocalProductName:    s.LocalProductName,
+			Confidence:          s.Confidence,
+			MatchReason:         string(s.MatchReason),
+			MatchedValue:        s.MatchedValue,
+		}
+	}
+	h.Success(c, resp)
+}
+
+// ConfirmMappingSuggestions godoc
+//
+//	@ID				confirmPlatformMappingSuggestions
+//	@Summary		Confirm suggested product mappings
+//	@Description	Create product mappings for the suggestions a user accepted. Each mapping is created independently; a platform or local product that is already mapped is reported in failures.
+//	@Tags			integration
+//	@Accept			json
+//	@Produce		json
+//	@Param			platform	path		string							true	"Platform code"	Enums(TAOBAO, JD, PDD, DOUYIN, WECHAT, KUAISHOU)
+//	@Param			request		body		ConfirmProductMappingsRequest	true	"Accepted suggestions"
+//	@Success		200			{object}	APIResponse[ConfirmProductMappingsResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/integration/platforms/{platform}/mapping-suggestions/confirm [post]
+func (h *PlatformMappingSuggestionHandler) ConfirmMappingSuggestions(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.Unauthorized(c, "Tenant ID not found in token")
+		return
+	}
+
+	platformCode, ok := parsePlatformCode(c)
+	if !ok {
+		h.BadRequest(c, "Invalid platform code")
+		return
+	}
+
+	var req ConfirmProductMappingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.suggester.ConfirmProductMappings(c.Request.Context(), tenantID, platformCode, req.Mappings)
+	if err != nil {
+		handlePlatformError(&h.BaseHandler, c, err)
+		return
+	}
+
+	resp := ConfirmProductMappingsResponse{
+		Created:  make([]ConfirmedProductMappingResponse, len(result.Created)),
+		Failures: make([]ConfirmProductMappingFailureResponse, len(result.Failures)),
+	}
+	for i, mapping := range result.Created {
+		skuIDs := make([]string, len(mapping.SKUMappings))
+		for j, sku := range mapping.SKUMappings {
+			skuIDs[j] = sku.PlatformSkuID
+		}
+		resp.Created[i] = ConfirmedProductMappingResponse{
+			ID:                mapping.ID.String(),
+			PlatformProductID: mapping.PlatformProductID,
+			LocalProductID:    mapping.LocalProductID.String(),
+			PlatformSkuIDs:    skuIDs,
+		}
+	}
+	for i, failure := range result.Failures {
+		resp.Failures[i] = ConfirmProductMappingFailureResponse{
+			PlatformProductID: failure.PlatformProductID,
+			Error:             failure.Error,
+		}
+	}
+	h.Success(c, resp)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	integrationapp "github.com/erp/backend/internal/application/integration"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubProductMappingSuggester returns fixed results and records confirmed inputs
+type stubProductMappingSuggester struct {
+	suggestions *integrationapp.MappingSuggestionResult
+	err         error
+	confirmed   []integrationapp.ConfirmProductMappingInput
+}
+
+func (s *stubProductMappingSuggester) SuggestProductMappings(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*integrationapp.MappingSuggestionResult, error) {
+	return s.suggestions, s.err
+}
+
+func (s *stubProductMappingSuggester) ConfirmProductMappings(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode, inputs []integrationapp.ConfirmProductMappingInput) (*integrationapp.ConfirmProductMappingsResult, error) {
+	s.confirmed = inputs
+	return &integrationapp.ConfirmProductMappingsResult{}, nil
+}
+
+func serveMappingSuggestions(suggester ProductMappingSuggester, method, path, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	h := NewPlatformMappingSuggestionHandler(suggester)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.JWTTenantIDKey, uuid.New().String())
+	})
+	router.GET("/api/v1/integration/platforms/:platform/mapping-suggestions", h.GetMappingSuggestions)
+	router.POST("/api/v1/integration/platforms/:platform/mapping-suggestions/confirm", h.ConfirmMappingSuggestions)
+
+	req := httptest.NewRequest(method, "/api/v1/integration/platforms/"+path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPlatformMappingSuggestionHandler_GetMappingSuggestions(t *testing.T) {
+	t.Run("returns suggestions", func(t *testing.T) {
+		localID := uuid.New()
+		suggester := &stubProductMappingSuggester{suggestions: &integrationapp.MappingSuggestionResult{
+			PlatformCode: integration.PlatformCodeTaobao,
+			ScannedCount: 1,
+			Suggestions: []integrationapp.ProductMappingSuggestion{{
+				PlatformProductID: "P-100",
+				LocalProductID:    localID,
+				Confidence:        1,
+				MatchReason:       integrationapp.MappingMatchReasonSKU,
+				MatchedValue:      "TEE-RED",
+			}},
+		}}
+
+		w := serveMappingSuggestions(suggester, http.MethodGet, "taobao/mapping-suggestions", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data ProductMappingSuggestionsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data.Suggestions, 1)
+		assert.Equal(t, localID.String(), resp.Data.Suggestions[0].LocalProductID)
+		assert.Equal(t, "SKU_MATCH", resp.Data.Suggestions[0].MatchReason)
+	})
+
+	t.Run("maps platform errors to status codes", func(t *testing.T) {
+		w := serveMappingSuggestions(&stubProductMappingSuggester{err: integration.ErrPlatformNotEnabled}, http.MethodGet, "JD/mapping-suggestions", "")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestPlatformMappingSuggestionHandler_ConfirmMappingSuggestions(t *testing.T) {
+	t.Run("passes accepted suggestions through", func(t *testing.T) {
+		suggester := &stubProductMappingSuggester{}
+		localID := uuid.New()
+
+		w := serveMappingSuggestions(suggester, http.MethodPost, "taobao/mapping-suggestions/confirm",
+			`{"mappings":[{"platform_product_id":"P-100","local_product_id":"`+localID.String()+`"}]}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, suggester.confirmed, 1)
+		assert.Equal(t, localID, suggester.confirmed[0].LocalProductID)
+	})
+
+	t.Run("rejects an empty request", func(t *testing.T) {
+		suggester := &stubProductMappingSuggester{}
+
+		w := serveMappingSuggestions(suggester, http.MethodPost, "taobao/mapping-suggestions/confirm", `{"mappings":[]}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Nil(t, suggester.confirmed)
+	})
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	integrationapp "github.com/erp/backend/internal/application/integration"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PlatformOrderImporter imports e-commerce platform orders as sales orders
+type PlatformOrderImporter interface {
+	ImportPlatformOrders(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*integrationapp.OrderImportResult, error)
+}
+
+// Ensure the application service satisfies PlatformOrderImporter
+var _ PlatformOrderImporter = (*integrationapp.OrderImportService)(nil)
+
+// PlatformOrderSyncHandler handles e-commerce platform order sync HTTP requests
+type PlatformOrderSyncHandler struct {
+	BaseHandler
+	importer PlatformOrderImporter
+}
+
+// NewPlatformOrderSyncHandler creates a new platform order sync handler
+func NewPlatformOrderSyncHandler(importer PlatformOrderImporter) *PlatformOrderSyncHandler {
+	return &PlatformOrderSyncHandler{importer: importer}
+}
+
+// ImportedPlatformOrderResponse represents a platform order imported as a sales order
+//
+//	@Description	A platform order imported as a draft sales order
+type ImportedPlatformOrderResponse struct {
+	PlatformOrderID  string `json:"platform_order_id" example:"TB20260301000123"`
+	SalesOrderID     string `json:"sales_order_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SalesOrderNumber string `json:"sales_order_number" example:"SO-2026-00042"`
+}
+
+// PlatformOrderImportFailureResponse represents a platform order that could not be imported
+//
+//	@Description	A platform order that could not be imported; it is retried on the next sync
+type PlatformOrderImportFailureResponse struct {
+	PlatformOrderID string   `json:"platform_order_id" example:"TB20260301000124"`
+	Error           string   `json:"error" example:"integration: product mapping not found: SKU-RED-XL"`
+	UnmappedSkus    []string `json:"unmapped_skus,omitempty" example:"SKU-RED-XL"`
+}
+
+// PlatformOrderSyncResponse represents the result of a platform order sync
+//
+//	@Description	Summary of one platform order import run
+type PlatformOrderSyncResponse struct {
+	PlatformCode  string                               `json:"platform_code" example:"TAOBAO"`
+	WindowStart   time.Time                            `json:"window_start"`
+	WindowEnd     time.Time                            `json:"window_end"`
+	PulledCount   int                                  `json:"pulled_count" example:"2"`
+	ImportedCount int                                  `json:"imported_count" example:"1"`
+	SkippedCount  int                                  `json:"skipped_count" example:"0"`
+	FailedCount   int                                  `json:"failed_count" example:"1"`
+	Imported      []ImportedPlatformOrderResponse      `json:"imported"`
+	Failures      []PlatformOrderImportFailureResponse `json:"failures"`
+}
+
+// SyncPlatformOrders godoc
+//
+//	@ID				syncPlatformOrders
+//	@Summary		Import platform orders
+//	@Description	Pull the orders created on an e-commerce platform since the last sync and create a draft sales order for each paid one. Platform SKUs are mapped to local products through the product mappings; an order with an unmapped SKU is reported in failures without aborting the batch and is retried on the next sync. Orders already imported are skipped.
+//	@Tags			integration
+//	@Produce		json
+//	@Param			platform	path		string	true	"Platform code"	Enums(TAOBAO, JD, PDD, DOUYIN, WECHAT, KUAISHOU)
+//	@Success		200			{object}	APIResponse[PlatformOrderSyncResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/integration/platforms/{platform}/sync-orders [post]
+func (h *PlatformOrderSyncHandler) SyncPlatformOrders(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.Unauthorized(c, "Tenant ID not found in token")
+		return
+	}
+
+	platformCode, ok := parsePlatformCode(c)
+	if !ok {
+		h.BadRequest(c, "Invalid platform code")
+		return
+	}
+
+	result, err := h.importer.ImportPlatformOrders(c.Request.Context(), tenantID, platformCode)
+	if err != nil {
+		handlePlatformError(&h.BaseHandler, c, err)
+		return
+	}
+
+	h.Success(c, toPlatformOrderSyncResponse(result))
+}
+
+// parsePlatformCode reads the :platform path parameter, case-insensitively
+func parsePlatformCode(c *gin.Context) (integration.PlatformCode, bool) {
+	platformCode := integration.PlatformCode(strings.ToUpper(c.Param("platform")))
+	return platformCode, platformCode.IsValid()
+}
+
+// handlePlatformError maps e-commerce platform errors to HTTP responses
+func handlePlatformError(h *BaseHandler, c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, integration.ErrPlatformNotConfigured):
+		h.NotFound(c, "Platform is not configured")
+	case errors.Is(err, integration.ErrPlatformNotEnabled):
+		h.UnprocessableEntity(c, "PLATFORM_NOT_ENABLED", "Platform is not enabled for this tenant")
+	case errors.Is(err, integration.ErrOrderSyncNotConfigured):
+		h.UnprocessableEntity(c, "ORDER_SYNC_NOT_CONFIGURED", "Order sync is not configured for this platform")
+	default:
+		h.HandleError(c, err)
+	}
+}
+
+func toPlatformOrderSyncResponse(result *integrationapp.OrderImportResult) PlatformOrderSyncResponse {
+	resp := PlatformOrderSyncResponse{
+		PlatformCode:  result.PlatformCode.String(),
+		WindowStart:   result.WindowStart,
+		WindowEnd:     result.WindowEnd,
+		PulledCount:   result.PulledCount,
+		ImportedCount: result.ImportedCount,
+		SkippedCount:  result.SkippedCount,
+		FailedCount:   result.FailedCount,
+		Imported:      make([]ImportedPlatformOrderResponse, len(result.Imported)),
+		Failures:      make([]PlatformOrderImportFailureResponse, len(result.Failures)),
+	}
+	for i, imported := range result.Imported {
+		resp.Imported[i] = ImportedPlatformOrderResponse{
+			PlatformOrderID:  imported.PlatformOrderID,
+			SalesOrderID:     imported.SalesOrderID.String(),
+			SalesOrderNumber: imported.SalesOrderNumber,
+		}
+	}
+	for i, failure := range result.Failures {
+		resp.Failures[i] = PlatformOrderImportFailureResponse{
+			PlatformOrderID: failure.PlatformOrderID,
+			Error:           failure.Error,
+			UnmappedSkus:    failure.UnmappedSkus,
+		}
+	}
+	return resp
+}
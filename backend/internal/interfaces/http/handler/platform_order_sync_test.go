@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	integrationapp "github.com/erp/backend/internal/application/integration"
+	"github.com/erp/backend/internal/domain/integration"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPlatformOrderImporter returns a fixed result or error
+type stubPlatformOrderImporter struct {
+	result   *integrationapp.OrderImportResult
+	err      error
+	platform integration.PlatformCode
+}
+
+func (s *stubPlatformOrderImporter) ImportPlatformOrders(ctx context.Context, tenantID uuid.UUID, platformCode integration.PlatformCode) (*integrationapp.OrderImportResult, error) {
+	s.platform = platformCode
+	return s.result, s.err
+}
+
+func postSyncOrders(importer PlatformOrderImporter, platform string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	h := NewPlatformOrderSyncHandler(importer)
+	router := gin.New()
+	router.POST("/api/v1/integration/platforms/:platform/sync-orders", func(c *gin.Context) {
+		c.Set(middleware.JWTTenantIDKey, uuid.New().String())
+		h.SyncPlatformOrders(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/integration/platforms/"+platform+"/sync-orders", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPlatformOrderSyncHandler_SyncPlatformOrders(t *testing.T) {
+	t.Run("returns the import summary", func(t *testing.T) {
+		importer := &stubPlatformOrderImporter{result: &integrationapp.OrderImportResult{
+			PlatformCode:  integration.PlatformCodeTaobao,
+			PulledCount:   2,
+			ImportedCount: 1,
+			FailedCount:   1,
+			Imported:      []integrationapp.ImportedOrder{{PlatformOrderID: "TB1001", SalesOrderID: uuid.New(), SalesOrderNumber: "SO-001"}},
+			Failures:      []integrationapp.OrderImportFailure{{PlatformOrderID: "TB1002", Error: "unmapped", UnmappedSkus: []string{"SKU-BLUE-XL"}}},
+		}}
+
+		w := postSyncOrders(importer, "taobao")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, integration.PlatformCodeTaobao, importer.platform)
+		var resp struct {
+			Data PlatformOrderSyncResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Data.ImportedCount)
+		require.Len(t, resp.Data.Failures, 1)
+		assert.Equal(t, []string{"SKU-BLUE-XL"}, resp.Data.Failures[0].UnmappedSkus)
+	})
+
+	t.Run("rejects unknown platform codes", func(t *testing.T) {
+		importer := &stubPlatformOrderImporter{}
+
+		w := postSyncOrders(importer, "ebay")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, importer.platform)
+	})
+
+	t.Run("maps platform errors to status codes", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound,
+			postSyncOrders(&stubPlatformOrderImporter{err: integration.ErrPlatformNotConfigured}, "JD").Code)
+		assert.Equal(t, http.StatusUnprocessableEntity,
+			postSyncOrders(&stubPlatformOrderImporter{err: integration.ErrPlatformNotEnabled}, "JD").Code)
+		assert.Equal(t, http.StatusUnprocessableEntity,
+			postSyncOrders(&stubPlatformOrderImporter{err: integration.ErrOrderSyncNotConfigured}, "JD").Code)
+	})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"regexp"
@@ -18,17 +19,26 @@ type PDFStorage interface {
 	Get(ctx context.Context, path string) (io.ReadCloser, error)
 }
 
+// PrintJobEnqueuer queues print jobs for background rendering
+type PrintJobEnqueuer interface {
+	Enqueue(ctx context.Context, tenantID, userID uuid.UUID, req printingapp.GeneratePDFRequest) (*printingapp.PrintJobResponse, error)
+}
+
+var _ PrintJobEnqueuer = (*printingapp.PrintJobQueue)(nil)
+
 // PrintHandler handles print-related API endpoints
 type PrintHandler struct {
 	BaseHandler
 	printService *printingapp.PrintService
+	jobQueue     PrintJobEnqueuer
 	pdfStorage   PDFStorage
 }
 
 // NewPrintHandler creates a new PrintHandler
-func NewPrintHandler(printService *printingapp.PrintService, pdfStorage PDFStorage) *PrintHandler {
+func NewPrintHandler(printService *printingapp.PrintService, jobQueue PrintJobEnqueuer, pdfStorage PDFStorage) *PrintHandler {
 	return &PrintHandler{
 		printService: printService,
+		jobQueue:     jobQueue,
 		pdfStorage:   pdfStorage,
 	}
 }
@@ -104,6 +114,7 @@ type PrintJobResponse struct {
 	Copies         int     `json:"copies" example:"1"`
 	PdfURL         string  `json:"pdf_url,omitempty" example:"/api/v1/print/jobs/xxx/download"`
 	ErrorMessage   string  `json:"error_message,omitempty"`
+	ErrorDetail    string  `json:"error_detail,omitempty" example:"PDF rendering timed out after 30s"`
 	PrintedAt      *string `json:"printed_at,omitempty" example:"2024-01-15T10:30:00Z"`
 	PrintedBy      string  `json:"printed_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440004"`
 	CreatedAt      string  `json:"created_at" example:"2024-01-15T10:30:00Z"`
@@ -269,16 +280,33 @@ func (h *PrintHandler) GeneratePDF(c *gin.Context) {
 		return
 	}
 
+	appReq, ok := h.bindGeneratePDFRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.printService.GeneratePDF(c.Request.Context(), tenantID, userID, appReq)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Created(c, result)
+}
+
+// bindGeneratePDFRequest binds a PDF generation request body, responding with
+// 400 Bad Request if it is invalid
+func (h *PrintHandler) bindGeneratePDFRequest(c *gin.Context) (printingapp.GeneratePDFRequest, bool) {
 	var req GeneratePDFHTTPRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.BadRequest(c, err.Error())
-		return
+		return printingapp.GeneratePDFRequest{}, false
 	}
 
 	documentID, err := uuid.Parse(req.DocumentID)
 	if err != nil {
 		h.BadRequest(c, "Invalid document ID format")
-		return
+		return printingapp.GeneratePDFRequest{}, false
 	}
 
 	appReq := printingapp.GeneratePDFRequest{
@@ -293,30 +321,75 @@ func (h *PrintHandler) GeneratePDF(c *gin.Context) {
 		templateID, err := uuid.Parse(*req.TemplateID)
 		if err != nil {
 			h.BadRequest(c, "Invalid template ID format")
-			return
+			return printingapp.GeneratePDFRequest{}, false
 		}
 		appReq.TemplateID = &templateID
 	}
 
-	result, err := h.printService.GeneratePDF(c.Request.Context(), tenantID, userID, appReq)
+	return appReq, true
+}
+
+// =============================================================================
+// Print Job Endpoints
+// =============================================================================
+
+// EnqueueJob godoc
+//
+//	@ID				enqueuePrintJob
+//
+//	@Summary		Queue PDF generation
+//	@Description	Queue a PDF for a document to be generated in the background and return the print job immediately.
+//	@Description	Poll GET /print/jobs/{id} until the status is COMPLETED (pdf_url is set) or FAILED (error_message and
+//	@Description	error_detail describe the failure). Use this instead of /print/generate for large documents.
+//	@Tags			print-jobs
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		GeneratePDFHTTPRequest	true	"PDF generation request"
+//	@Success		202		{object}	APIResponse[PrintJobResponse]
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		429		{object}	dto.ErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/print/jobs [post]
+func (h *PrintHandler) EnqueueJob(c *gin.Context) {
+	tenantID, err := getTenantID(c)
 	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := getUserID(c)
+	if err != nil {
+		h.Unauthorized(c, "User ID not found")
+		return
+	}
+
+	appReq, ok := h.bindGeneratePDFRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.jobQueue.Enqueue(c.Request.Context(), tenantID, userID, appReq)
+	if err != nil {
+		if errors.Is(err, printingapp.ErrPrintQueueFull) {
+			h.TooManyRequests(c, "Too many print jobs are waiting. Please try again later.")
+			return
+		}
 		h.HandleDomainError(c, err)
 		return
 	}
 
-	h.Created(c, result)
+	c.JSON(http.StatusAccepted, dto.NewSuccessResponse(result))
 }
 
-// =============================================================================
-// Print Job Endpoints
-// =============================================================================
-
 // GetJob godoc
 //
 //	@ID				getPrintJobJob
 //
 //	@Summary		Get print job by ID
-//	@Description	Retrieve a print job by its ID
+//	@Description	Retrieve a print job by its ID, e.g. to poll a queued job until it is COMPLETED or FAILED
 //	@Tags			print-jobs
 //	@Produce		json
 //	@Param			id	path		string	true	"Job ID"	format(uuid)
@@ -19,6 +19,7 @@ func PrintRoutes(handler *PrintHandler, authMiddleware gin.HandlerFunc) *router.
 
 	// Print jobs
 	group.GET("/jobs", handler.ListJobs)
+	group.POST("/jobs", handler.EnqueueJob)
 	group.GET("/jobs/:id", handler.GetJob)
 	group.GET("/jobs/:id/download", handler.DownloadPDF)
 	group.GET("/jobs/by-document/:doc_type/:document_id", handler.GetJobsByDocument)
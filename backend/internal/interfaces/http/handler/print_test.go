@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	printingapp "github.com/erp/backend/internal/application/printing"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPrintJobEnqueuer records queued requests and returns a pending job
+type stubPrintJobEnqueuer struct {
+	err    error
+	queued []printingapp.GeneratePDFRequest
+}
+
+func (s *stubPrintJobEnqueuer) Enqueue(ctx context.Context, tenantID, userID uuid.UUID, req printingapp.GeneratePDFRequest) (*printingapp.PrintJobResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.queued = append(s.queued, req)
+	return &printingapp.PrintJobResponse{
+		ID:             uuid.New().String(),
+		DocumentType:   req.DocumentType,
+		DocumentID:     req.DocumentID.String(),
+		DocumentNumber: req.DocumentNumber,
+		Status:         "PENDING",
+	}, nil
+}
+
+func serveEnqueuePrintJob(queue PrintJobEnqueuer, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	h := NewPrintHandler(nil, queue, nil)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.JWTTenantIDKey, uuid.New().String())
+		c.Set(middleware.JWTUserIDKey, uuid.New().String())
+	})
+	router.POST("/api/v1/print/jobs", h.EnqueueJob)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/print/jobs", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPrintHandler_EnqueueJob(t *testing.T) {
+	documentID := uuid.New()
+	body := `{"document_type":"SALES_ORDER","document_id":"` + documentID.String() + `","document_number":"SO-2024-001"}`
+
+	t.Run("queues the job and returns it immediately", func(t *testing.T) {
+		queue := &stubPrintJobEnqueuer{}
+
+		w := serveEnqueuePrintJob(queue, body)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		require.Len(t, queue.queued, 1)
+		assert.Equal(t, documentID, queue.queued[0].DocumentID)
+		var resp struct {
+			Data PrintJobResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "PENDING", resp.Data.Status)
+		assert.NotEmpty(t, resp.Data.ID)
+	})
+
+	t.Run("returns 429 when the queue is full", func(t *testing.T) {
+		w := serveEnqueuePrintJob(&stubPrintJobEnqueuer{err: printingapp.ErrPrintQueueFull}, body)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("rejects an invalid document ID", func(t *testing.T) {
+		queue := &stubPrintJobEnqueuer{}
+
+		w := serveEnqueuePrintJob(queue, `{"document_type":"SALES_ORDER","document_id":"nope","document_number":"SO-1"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, queue.queued)
+	})
+}
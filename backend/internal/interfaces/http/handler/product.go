@@ -1,9 +1,21 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	catalogapp "github.com/erp/backend/internal/application/catalog"
+	"github.com/erp/backend/internal/domain/catalog"
+	csvimport "github.com/erp/backend/internal/infrastructure/import"
 	"github.com/erp/backend/internal/interfaces/http/dto"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 )
 
@@ -35,6 +47,74 @@ type CreateProductRequest struct {
 	MinStock      *float64 `json:"min_stock" example:"10"`
 	SortOrder     *int     `json:"sort_order" example:"0"`
 	Attributes    string   `json:"attributes" example:"{}"`
+	IsSerialized  bool     `json:"is_serialized" example:"false"`
+	Weight        *float64 `json:"weight" example:"0.5"`
+	Volume        *float64 `json:"volume" example:"0.002"`
+	// Quantity breaks by ascending minimum quantity; large orders sell at the tier's unit price
+	PriceTiers []ProductPriceTier `json:"price_tiers"`
+}
+
+// toCreateProductAppRequest converts a create request to the application DTO.
+// userID is recorded as the creator for data scope filtering unless it is nil.
+func toCreateProductAppRequest(req CreateProductRequest, userID uuid.UUID) (catalogapp.CreateProductRequest, error) {
+	appReq := catalogapp.CreateProductRequest{
+		Code:         req.Code,
+		Name:         req.Name,
+		Description:  req.Description,
+		Barcode:      req.Barcode,
+		Unit:         req.Unit,
+		Attributes:   req.Attributes,
+		SortOrder:    req.SortOrder,
+		IsSerialized: req.IsSerialized,
+	}
+
+	// Set CreatedBy for data scope filtering
+	if userID != uuid.Nil {
+		appReq.CreatedBy = &userID
+	}
+
+	// Convert category ID
+	if req.CategoryID != nil && *req.CategoryID != "" {
+		catID, err := uuid.Parse(*req.CategoryID)
+		if err != nil {
+			return appReq, errors.New("Invalid category ID format")
+		}
+		appReq.CategoryID = &catID
+	}
+
+	// Convert prices
+	if req.PurchasePrice != nil {
+		appReq.PurchasePrice = toDecimalPtr(*req.PurchasePrice)
+	}
+	if req.SellingPrice != nil {
+		appReq.SellingPrice = toDecimalPtr(*req.SellingPrice)
+	}
+	if req.MinStock != nil {
+		appReq.MinStock = toDecimalPtr(*req.MinStock)
+	}
+	if req.Weight != nil {
+		appReq.Weight = toDecimalPtr(*req.Weight)
+	}
+	if req.Volume != nil {
+		appReq.Volume = toDecimalPtr(*req.Volume)
+	}
+	if len(req.PriceTiers) > 0 {
+		appReq.PriceTiers = toAppPriceTiers(req.PriceTiers)
+	}
+
+	return appReq, nil
+}
+
+// toAppPriceTiers converts price tiers of a request to the application DTO
+func toAppPriceTiers(tiers []ProductPriceTier) []catalogapp.ProductPriceTier {
+	result := make([]catalogapp.ProductPriceTier, len(tiers))
+	for i, tier := range tiers {
+		result[i] = catalogapp.ProductPriceTier{
+			MinQuantity: toDecimal(tier.MinQuantity),
+			UnitPrice:   toDecimal(tier.UnitPrice),
+		}
+	}
+	return result
 }
 
 // UpdateProductRequest represents a request to update a product
@@ -50,6 +130,13 @@ type UpdateProductRequest struct {
 	MinStock      *float64 `json:"min_stock" example:"15"`
 	SortOrder     *int     `json:"sort_order" example:"1"`
 	Attributes    *string  `json:"attributes" example:"{}"`
+	IsSerialized  *bool    `json:"is_serialized" example:"false"`
+	Weight        *float64 `json:"weight" example:"0.5"`
+	Volume        *float64 `json:"volume" example:"0.002"`
+	// Replaces the quantity breaks; an empty list removes them
+	PriceTiers *[]ProductPriceTier `json:"price_tiers"`
+	// Version the client loaded; the update is rejected with 409 and the current version if the product changed since
+	Version *int `json:"version" example:"3"`
 }
 
 // UpdateProductCodeRequest represents a request to update a product's code
@@ -74,6 +161,7 @@ type UpdateProductCodeRequest struct {
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
 //	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/catalog/products [post]
@@ -94,51 +182,228 @@ func (h *ProductHandler) Create(c *gin.Context) {
 	userID, _ := getUserID(c)
 
 	// Convert to application DTO
-	appReq := catalogapp.CreateProductRequest{
-		Code:        req.Code,
-		Name:        req.Name,
-		Description: req.Description,
-		Barcode:     req.Barcode,
-		Unit:        req.Unit,
-		Attributes:  req.Attributes,
+	appReq, err := toCreateProductAppRequest(req, userID)
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return
 	}
 
-	// Set CreatedBy for data scope filtering
-	if userID != uuid.Nil {
-		appReq.CreatedBy = &userID
+	product, err := h.productService.Create(c.Request.Context(), tenantID, appReq)
+	if err != nil {
+		var attrErr *catalog.MissingRequiredAttributesError
+		if errors.As(err, &attrErr) {
+			h.missingRequiredAttributes(c, attrErr)
+			return
+		}
+		h.HandleDomainError(c, err)
+		return
 	}
 
-	// Convert category ID
-	if req.CategoryID != nil && *req.CategoryID != "" {
-		catID, err := uuid.Parse(*req.CategoryID)
+	h.Created(c, product)
+}
+
+// missingRequiredAttributes responds with each product attribute rejected by the tenant's industry plugin
+func (h *ProductHandler) missingRequiredAttributes(c *gin.Context, attrErr *catalog.MissingRequiredAttributesError) {
+	details := make([]dto.ValidationDetail, len(attrErr.Violations))
+	for i, v := range attrErr.Violations {
+		details[i] = dto.ValidationDetail{Field: "attributes." + v.Key, Message: string(v.Reason)}
+	}
+	c.JSON(http.StatusUnprocessableEntity, dto.NewErrorResponseWithDetails(
+		catalog.ErrMissingRequiredAttributes.Code, attrErr.Error(), getRequestID(c), details))
+}
+
+// BulkImport godoc
+//
+//	@Summary		Bulk import products
+//	@Description	Create many products at once from a JSON array of product definitions or a multipart CSV file.
+//	@Description	Each product is validated with the same rules as single create; invalid rows are skipped and reported.
+//	@Description	Codes or barcodes repeated within the import are reported on every row that uses them.
+//	@Description	CSV columns: code, name, unit (required), description, barcode, category_id, purchase_price, selling_price, min_stock, weight, volume, sort_order, attributes.
+//	@Tags			products
+//
+//	@ID				bulkImportProducts
+//	@Accept			json
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string					false	"Tenant ID (optional for dev)"
+//	@Param			request		body		[]CreateProductRequest	false	"Product definitions (JSON)"
+//	@Param			file		formData	file					false	"CSV file (multipart)"
+//	@Success		200			{object}	APIResponse[BulkImportProductsResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		413			{object}	dto.ErrorResponse
+//	@Failure		415			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/catalog/products/import [post]
+func (h *ProductHandler) BulkImport(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	// Get user ID from JWT context (optional, for data scope)
+	userID, _ := getUserID(c)
+
+	var rows []catalogapp.BulkImportProductRow
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, header, err := c.Request.FormFile("file")
 		if err != nil {
-			h.BadRequest(c, "Invalid category ID format")
+			h.BadRequest(c, "file is required")
 			return
 		}
-		appReq.CategoryID = &catID
+		defer file.Close()
+
+		if header.Size > maxImportFileSize {
+			h.Error(c, http.StatusRequestEntityTooLarge, dto.ErrCodeValidation, "file exceeds maximum size of 10MB")
+			return
+		}
+		contentType := header.Header.Get("Content-Type")
+		if contentType != "" && contentType != "text/csv" && contentType != "application/octet-stream" &&
+			contentType != "text/plain" && contentType != "application/vnd.ms-excel" {
+			h.Error(c, http.StatusUnsupportedMediaType, dto.ErrCodeValidation, "file must be a CSV file")
+			return
+		}
+
+		rows, err = parseProductImportCSV(file, userID)
+		if err != nil {
+			h.BadRequest(c, err.Error())
+			return
+		}
+	} else {
+		// Items are decoded and validated one by one so that a single bad row does not reject the whole import
+		var items []json.RawMessage
+		if err := json.NewDecoder(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)).Decode(&items); err != nil {
+			h.BadRequest(c, "Request body must be a JSON array of products: "+err.Error())
+			return
+		}
+		rows = make([]catalogapp.BulkImportProductRow, len(items))
+		for i, item := range items {
+			rows[i] = toBulkImportProductRow(i+1, item, userID)
+		}
 	}
 
-	// Convert prices
-	if req.PurchasePrice != nil {
-		appReq.PurchasePrice = toDecimalPtr(*req.PurchasePrice)
+	result, err := h.productService.BulkImport(c.Request.Context(), tenantID, rows)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
 	}
-	if req.SellingPrice != nil {
-		appReq.SellingPrice = toDecimalPtr(*req.SellingPrice)
+
+	h.Success(c, result)
+}
+
+// toBulkImportProductRow decodes and validates a single product of a JSON bulk import
+func toBulkImportProductRow(rowNumber int, item json.RawMessage, userID uuid.UUID) catalogapp.BulkImportProductRow {
+	row := catalogapp.BulkImportProductRow{Row: rowNumber}
+
+	var req CreateProductRequest
+	if err := json.Unmarshal(item, &req); err != nil {
+		row.ParseError = "Invalid product: " + err.Error()
+		return row
 	}
-	if req.MinStock != nil {
-		appReq.MinStock = toDecimalPtr(*req.MinStock)
+	row.Product.Code = req.Code
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		row.ParseError = err.Error()
+		return row
 	}
-	if req.SortOrder != nil {
-		appReq.SortOrder = req.SortOrder
+	appReq, err := toCreateProductAppRequest(req, userID)
+	if err != nil {
+		row.ParseError = err.Error()
+		return row
 	}
+	row.Product = appReq
+	return row
+}
 
-	product, err := h.productService.Create(c.Request.Context(), tenantID, appReq)
+// parseProductImportCSV reads product definitions from a CSV file.
+// Row numbers are the line numbers in the file, with the header on line 1.
+func parseProductImportCSV(r io.Reader, userID uuid.UUID) ([]catalogapp.BulkImportProductRow, error) {
+	parser, err := csvimport.NewCSVParser(r)
 	if err != nil {
-		h.HandleDomainError(c, err)
-		return
+		return nil, err
+	}
+	if err := parser.ParseHeader(); err != nil {
+		return nil, err
+	}
+	if missing := parser.ValidateHeaders([]string{"code", "name", "unit"}); len(missing) > 0 {
+		return nil, errors.New("CSV file is missing required columns: " + strings.Join(missing, ", "))
+	}
+	csvRows, err := parser.ReadAllRows()
+	if err != nil {
+		return nil, err
 	}
 
-	h.Created(c, product)
+	rows := make([]catalogapp.BulkImportProductRow, 0, len(csvRows))
+	for _, csvRow := range csvRows {
+		req := CreateProductRequest{
+			Code:        csvRow.Get("code"),
+			Name:        csvRow.Get("name"),
+			Description: csvRow.Get("description"),
+			Barcode:     csvRow.Get("barcode"),
+			Unit:        csvRow.Get("unit"),
+			Attributes:  csvRow.Get("attributes"),
+		}
+		if categoryID := csvRow.Get("category_id"); categoryID != "" {
+			req.CategoryID = &categoryID
+		}
+
+		row := catalogapp.BulkImportProductRow{Row: csvRow.LineNumber}
+		row.Product.Code = req.Code
+		if err := parseCSVProductNumbers(csvRow, &req); err != nil {
+			row.ParseError = err.Error()
+			rows = append(rows, row)
+			continue
+		}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			row.ParseError = err.Error()
+			rows = append(rows, row)
+			continue
+		}
+		appReq, err := toCreateProductAppRequest(req, userID)
+		if err != nil {
+			row.ParseError = err.Error()
+			rows = append(rows, row)
+			continue
+		}
+		row.Product = appReq
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseCSVProductNumbers parses the optional numeric columns of a product CSV row
+func parseCSVProductNumbers(csvRow *csvimport.Row, req *CreateProductRequest) error {
+	decimals := []struct {
+		column string
+		target **float64
+	}{
+		{"purchase_price", &req.PurchasePrice},
+		{"selling_price", &req.SellingPrice},
+		{"min_stock", &req.MinStock},
+		{"weight", &req.Weight},
+		{"volume", &req.Volume},
+	}
+	for _, d := range decimals {
+		value := csvRow.Get(d.column)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("Invalid number in column " + d.column + ": " + value)
+		}
+		*d.target = &parsed
+	}
+
+	if value := csvRow.Get("sort_order"); value != "" {
+		sortOrder, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("Invalid number in column sort_order: " + value)
+		}
+		req.SortOrder = &sortOrder
+	}
+	return nil
 }
 
 // GetByID godoc
@@ -239,9 +504,11 @@ func (h *ProductHandler) GetByCode(c *gin.Context) {
 //	@Param			page_size	query		int		false	"Page size"			default(20)	maximum(100)
 //	@Param			order_by	query		string	false	"Order by field"	default(sort_order)
 //	@Param			order_dir	query		string	false	"Order direction"	Enums(asc, desc)	default(asc)
+//	@Param			include_deleted	query		boolean	false	"Include soft-deleted products (requires product:restore)"
 //	@Success		200			{object}	APIResponse[[]ProductListResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/catalog/products [get]
@@ -258,6 +525,12 @@ func (h *ProductHandler) List(c *gin.Context) {
 		return
 	}
 
+	// Soft-deleted products are only visible to admins
+	if filter.IncludeDeleted && !middleware.HasPermission(c, "product:restore") {
+		h.Forbidden(c, "Listing deleted products requires admin permission (product:restore)")
+		return
+	}
+
 	// Set defaults
 	if filter.Page <= 0 {
 		filter.Page = 1
@@ -280,7 +553,8 @@ func (h *ProductHandler) List(c *gin.Context) {
 //	@ID				updateProduct
 //
 //	@Summary		Update a product
-//	@Description	Update an existing product's details
+//	@Description	Update an existing product's details.
+//	@Description	Send the version the product was loaded with; if it has changed since, 409 is returned with the current version.
 //	@Tags			products
 //	@Accept			json
 //	@Produce		json
@@ -314,13 +588,21 @@ func (h *ProductHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// Get user ID from JWT context (optional, for price history)
+	userID, _ := getUserID(c)
+
 	// Convert to application DTO
 	appReq := catalogapp.UpdateProductRequest{
-		Name:        req.Name,
-		Description: req.Description,
-		Barcode:     req.Barcode,
-		SortOrder:   req.SortOrder,
-		Attributes:  req.Attributes,
+		Name:         req.Name,
+		Description:  req.Description,
+		Barcode:      req.Barcode,
+		SortOrder:    req.SortOrder,
+		Attributes:   req.Attributes,
+		IsSerialized: req.IsSerialized,
+		Version:      req.Version,
+	}
+	if userID != uuid.Nil {
+		appReq.UpdatedBy = &userID
 	}
 
 	// Convert category ID
@@ -349,6 +631,16 @@ func (h *ProductHandler) Update(c *gin.Context) {
 	if req.MinStock != nil {
 		appReq.MinStock = toDecimalPtr(*req.MinStock)
 	}
+	if req.Weight != nil {
+		appReq.Weight = toDecimalPtr(*req.Weight)
+	}
+	if req.Volume != nil {
+		appReq.Volume = toDecimalPtr(*req.Volume)
+	}
+	if req.PriceTiers != nil {
+		priceTiers := toAppPriceTiers(*req.PriceTiers)
+		appReq.PriceTiers = &priceTiers
+	}
 
 	product, err := h.productService.Update(c.Request.Context(), tenantID, productID, appReq)
 	if err != nil {
@@ -359,6 +651,76 @@ func (h *ProductHandler) Update(c *gin.Context) {
 	h.Success(c, product)
 }
 
+// GetPriceHistory godoc
+//
+//	@ID				getProductPriceHistory
+//
+//	@Summary		Get product price history
+//	@Description	Retrieve the price changes of a product, most recent first.
+//	@Description	When as_of is given, returns an object with the purchase_price and selling_price in effect at that time instead.
+//	@Tags			products
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Product ID"	format(uuid)
+//	@Param			as_of		query		string	false	"Return the prices in effect at this time (RFC3339)"	format(date-time)
+//	@Param			page		query		int		false	"Page number"	default(1)
+//	@Param			page_size	query		int		false	"Page size"		default(20)	maximum(100)
+//	@Success		200			{object}	APIResponse[[]ProductPriceHistoryResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/catalog/products/{id}/price-history [get]
+func (h *ProductHandler) GetPriceHistory(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid product ID format")
+		return
+	}
+
+	if asOf := c.Query("as_of"); asOf != "" {
+		at, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			h.BadRequest(c, "Invalid as_of format, expected RFC3339 (e.g. 2026-03-01T00:00:00Z)")
+			return
+		}
+		prices, err := h.productService.GetPriceAt(c.Request.Context(), tenantID, productID, at)
+		if err != nil {
+			h.HandleDomainError(c, err)
+			return
+		}
+		h.Success(c, prices)
+		return
+	}
+
+	var filter catalogapp.ProductPriceHistoryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+
+	history, total, err := h.productService.GetPriceHistory(c.Request.Context(), tenantID, productID, filter)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.SuccessWithMeta(c, history, total, filter.Page, filter.PageSize)
+}
+
 // UpdateCode godoc
 //
 //	@ID				updateProductCode
@@ -412,7 +774,7 @@ func (h *ProductHandler) UpdateCode(c *gin.Context) {
 //	@ID				deleteProduct
 //
 //	@Summary		Delete a product
-//	@Description	Delete a product by ID
+//	@Description	Soft delete a product by ID. Admins can restore it with POST /catalog/products/{id}/restore
 //	@Tags			products
 //	@Produce		json
 //	@Param			X-Tenant-ID	header	string	false	"Tenant ID (optional for dev)"
@@ -446,6 +808,48 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 	h.NoContent(c)
 }
 
+// Restore godoc
+//
+//	@ID				restoreProduct
+//
+//	@Summary		Restore a product
+//	@Description	Restore a soft-deleted product. Fails with 409 if another product has taken its code since it was deleted.
+//	@Description	Requires product:restore permission.
+//	@Tags			products
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Product ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[ProductResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/catalog/products/{id}/restore [post]
+func (h *ProductHandler) Restore(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid product ID format")
+		return
+	}
+
+	product, err := h.productService.Restore(c.Request.Context(), tenantID, productID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, product)
+}
+
 // Activate godoc
 //
 //	@ID				activateProduct
@@ -5,24 +5,35 @@ import "time"
 // ProductResponse represents a product in API responses
 // @Description Product details returned by the API
 type ProductResponse struct {
-	ID            string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	TenantID      string  `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Code          string  `json:"code" example:"SKU-001"`
-	Name          string  `json:"name" example:"Sample Product"`
-	Description   string  `json:"description" example:"This is a sample product description"`
-	Barcode       string  `json:"barcode" example:"6901234567890"`
-	CategoryID    *string `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440002"`
-	Unit          string  `json:"unit" example:"pcs"`
-	PurchasePrice float64 `json:"purchase_price" example:"50.00"`
-	SellingPrice  float64 `json:"selling_price" example:"100.00"`
-	MinStock      float64 `json:"min_stock" example:"10"`
-	Status        string  `json:"status" example:"active" enums:"active,inactive,discontinued"`
-	SortOrder     int     `json:"sort_order" example:"0"`
-	Attributes    string  `json:"attributes" example:"{}"`
-	ProfitMargin  float64 `json:"profit_margin" example:"100.00"`
-	CreatedAt     string  `json:"created_at" example:"2026-01-24T12:00:00Z"`
-	UpdatedAt     string  `json:"updated_at" example:"2026-01-24T12:00:00Z"`
-	Version       int     `json:"version" example:"1"`
+	ID            string             `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TenantID      string             `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Code          string             `json:"code" example:"SKU-001"`
+	Name          string             `json:"name" example:"Sample Product"`
+	Description   string             `json:"description" example:"This is a sample product description"`
+	Barcode       string             `json:"barcode" example:"6901234567890"`
+	CategoryID    *string            `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Unit          string             `json:"unit" example:"pcs"`
+	PurchasePrice float64            `json:"purchase_price" example:"50.00"`
+	SellingPrice  float64            `json:"selling_price" example:"100.00"`
+	MinStock      float64            `json:"min_stock" example:"10"`
+	Status        string             `json:"status" example:"active" enums:"active,inactive,discontinued"`
+	SortOrder     int                `json:"sort_order" example:"0"`
+	Attributes    string             `json:"attributes" example:"{}"`
+	IsSerialized  bool               `json:"is_serialized" example:"false"`
+	Weight        float64            `json:"weight" example:"0.5"`
+	Volume        float64            `json:"volume" example:"0.002"`
+	PriceTiers    []ProductPriceTier `json:"price_tiers"`
+	ProfitMargin  float64            `json:"profit_margin" example:"100.00"`
+	CreatedAt     string             `json:"created_at" example:"2026-01-24T12:00:00Z"`
+	UpdatedAt     string             `json:"updated_at" example:"2026-01-24T12:00:00Z"`
+	Version       int                `json:"version" example:"1"`
+}
+
+// ProductPriceTier represents a quantity break of a product
+// @Description Ordering at least min_quantity sells at unit_price
+type ProductPriceTier struct {
+	MinQuantity float64 `json:"min_quantity" example:"100"`
+	UnitPrice   float64 `json:"unit_price" example:"90.00"`
 }
 
 // ProductListResponse represents a product list item
@@ -41,5 +52,34 @@ type ProductListResponse struct {
 	CreatedAt     string  `json:"created_at" example:"2026-01-24T12:00:00Z"`
 }
 
+// ProductPriceHistoryResponse represents a product price change
+// @Description Product price change record
+type ProductPriceHistoryResponse struct {
+	ID          string  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductID   string  `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	PriceType   string  `json:"price_type" example:"SELLING" enums:"PURCHASE,SELLING"`
+	OldPrice    float64 `json:"old_price" example:"100.00"`
+	NewPrice    float64 `json:"new_price" example:"120.00"`
+	ChangedBy   *string `json:"changed_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440004"`
+	EffectiveAt string  `json:"effective_at" example:"2026-03-01T00:00:00Z"`
+}
+
+// BulkImportRowError describes a product that was skipped by a bulk import
+// @Description Bulk import row failure
+type BulkImportRowError struct {
+	Row   int    `json:"row" example:"3"`
+	Code  string `json:"code" example:"SKU-003"`
+	Error string `json:"error" example:"Product with this code already exists"`
+}
+
+// BulkImportProductsResponse reports the outcome of a bulk product import
+// @Description Bulk product import result
+type BulkImportProductsResponse struct {
+	Total    int                  `json:"total" example:"100"`
+	Inserted int                  `json:"inserted" example:"98"`
+	Skipped  int                  `json:"skipped" example:"2"`
+	Errors   []BulkImportRowError `json:"errors"`
+}
+
 // Helper to suppress unused import warning
 var _ = time.Now
@@ -55,6 +55,14 @@ func (m *MockProductRepository) FindByIDForTenant(ctx context.Context, tenantID,
 	return args.Get(0).(*catalog.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) FindDeletedByIDForTenant(ctx context.Context, tenantID, id uuid.UUID) (*catalog.Product, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*catalog.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) FindByCode(ctx context.Context, tenantID uuid.UUID, code string) (*catalog.Product, error) {
 	args := m.Called(ctx, tenantID, code)
 	if args.Get(0) == nil {
@@ -106,6 +114,11 @@ func (m *MockProductRepository) FindByIDs(ctx context.Context, tenantID uuid.UUI
 	return args.Get(0).([]catalog.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) FindByBarcodes(ctx context.Context, tenantID uuid.UUID, barcodes []string) ([]catalog.Product, error) {
+	args := m.Called(ctx, tenantID, barcodes)
+	return args.Get(0).([]catalog.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) FindByCodes(ctx context.Context, tenantID uuid.UUID, codes []string) ([]catalog.Product, error) {
 	args := m.Called(ctx, tenantID, codes)
 	return args.Get(0).([]catalog.Product), args.Error(1)
@@ -116,6 +129,11 @@ func (m *MockProductRepository) Save(ctx context.Context, product *catalog.Produ
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) SaveWithLock(ctx context.Context, product *catalog.Product, expectedVersion int) error {
+	args := m.Called(ctx, product, expectedVersion)
+	return args.Error(0)
+}
+
 func (m *MockProductRepository) SaveBatch(ctx context.Context, products []*catalog.Product) error {
 	args := m.Called(ctx, products)
 	return args.Error(0)
@@ -131,6 +149,11 @@ func (m *MockProductRepository) DeleteForTenant(ctx context.Context, tenantID, i
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) RestoreForTenant(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
 func (m *MockProductRepository) Count(ctx context.Context, filter shared.Filter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
@@ -240,8 +263,8 @@ func (m *MockCategoryRepository) HasProducts(ctx context.Context, tenantID, cate
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, newPath string, newLevel int) error {
-	args := m.Called(ctx, tenantID, categoryID, newPath, newLevel)
+func (m *MockCategoryRepository) UpdatePath(ctx context.Context, tenantID, categoryID uuid.UUID, parentID *uuid.UUID, newPath string, newLevel int) error {
+	args := m.Called(ctx, tenantID, categoryID, parentID, newPath, newLevel)
 	return args.Error(0)
 }
 
@@ -479,7 +502,7 @@ func TestProductHandler_Update_Success(t *testing.T) {
 	product.ID = productID
 
 	productRepo.On("FindByIDForTenant", mock.Anything, tenantID, productID).Return(product, nil)
-	productRepo.On("Save", mock.Anything, mock.AnythingOfType("*catalog.Product")).Return(nil)
+	productRepo.On("SaveWithLock", mock.Anything, mock.AnythingOfType("*catalog.Product"), 1).Return(nil)
 
 	router := setupTestRouter()
 	router.PUT("/products/:id", handler.Update)
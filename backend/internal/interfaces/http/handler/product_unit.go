@@ -28,6 +28,7 @@ type CreateProductUnitRequest struct {
 	UnitCode              string   `json:"unit_code" binding:"required,min=1,max=20" example:"BOX"`
 	UnitName              string   `json:"unit_name" binding:"required,min=1,max=50" example:"箱"`
 	ConversionRate        float64  `json:"conversion_rate" binding:"required,gt=0" example:"24"`
+	ReferenceUnitCode     string   `json:"reference_unit_code" binding:"omitempty,max=20" example:"pcs"`
 	DefaultPurchasePrice  *float64 `json:"default_purchase_price" example:"1200.00"`
 	DefaultSellingPrice   *float64 `json:"default_selling_price" example:"2400.00"`
 	IsDefaultPurchaseUnit bool     `json:"is_default_purchase_unit" example:"false"`
@@ -41,6 +42,7 @@ type CreateProductUnitRequest struct {
 type UpdateProductUnitRequest struct {
 	UnitName              *string  `json:"unit_name" binding:"omitempty,min=1,max=50" example:"箱"`
 	ConversionRate        *float64 `json:"conversion_rate" binding:"omitempty,gt=0" example:"24"`
+	ReferenceUnitCode     *string  `json:"reference_unit_code" binding:"omitempty,max=20" example:"pcs"`
 	DefaultPurchasePrice  *float64 `json:"default_purchase_price" example:"1200.00"`
 	DefaultSellingPrice   *float64 `json:"default_selling_price" example:"2400.00"`
 	IsDefaultPurchaseUnit *bool    `json:"is_default_purchase_unit" example:"false"`
@@ -66,6 +68,7 @@ type ProductUnitResponse struct {
 	UnitCode              string  `json:"unit_code" example:"BOX"`
 	UnitName              string  `json:"unit_name" example:"箱"`
 	ConversionRate        float64 `json:"conversion_rate" example:"24"`
+	ReferenceUnitCode     string  `json:"reference_unit_code,omitempty" example:"pcs"`
 	DefaultPurchasePrice  float64 `json:"default_purchase_price" example:"1200.00"`
 	DefaultSellingPrice   float64 `json:"default_selling_price" example:"2400.00"`
 	IsDefaultPurchaseUnit bool    `json:"is_default_purchase_unit" example:"false"`
@@ -127,6 +130,7 @@ func (h *ProductUnitHandler) Create(c *gin.Context) {
 		UnitCode:              req.UnitCode,
 		UnitName:              req.UnitName,
 		ConversionRate:        decimal.NewFromFloat(req.ConversionRate),
+		ReferenceUnitCode:     req.ReferenceUnitCode,
 		IsDefaultPurchaseUnit: req.IsDefaultPurchaseUnit,
 		IsDefaultSalesUnit:    req.IsDefaultSalesUnit,
 		SortOrder:             req.SortOrder,
@@ -265,6 +269,7 @@ func (h *ProductUnitHandler) Update(c *gin.Context) {
 	// Convert to application DTO
 	appReq := catalogapp.UpdateProductUnitRequest{
 		UnitName:              req.UnitName,
+		ReferenceUnitCode:     req.ReferenceUnitCode,
 		IsDefaultPurchaseUnit: req.IsDefaultPurchaseUnit,
 		IsDefaultSalesUnit:    req.IsDefaultSalesUnit,
 		SortOrder:             req.SortOrder,
@@ -333,7 +338,7 @@ func (h *ProductUnitHandler) Delete(c *gin.Context) {
 //
 //	@ID				convertProductUnit
 //	@Summary		Convert quantity between units
-//	@Description	Convert quantity from one unit to another for a product
+//	@Description	Convert quantity from one unit to another for a product, through intermediate units if needed
 //	@Tags			product-units
 //	@Accept			json
 //	@Produce		json
@@ -343,6 +348,7 @@ func (h *ProductUnitHandler) Delete(c *gin.Context) {
 //	@Success		200			{object}	APIResponse[ConvertUnitResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/catalog/products/{id}/units/convert [post]
@@ -117,33 +117,77 @@ type CancelPurchaseOrderRequest struct {
 	Reason string `json:"reason" binding:"required,min=1,max=500" example:"供应商无法供货"`
 }
 
+// PurchaseOrderAmendmentLineInput represents a proposed change to an order line
+//
+//	@Description	Proposed change to a purchase order line; omitted fields keep their current value
+type PurchaseOrderAmendmentLineInput struct {
+	ItemID   string   `json:"item_id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440020"`
+	Quantity *float64 `json:"quantity" binding:"omitempty,gt=0" example:"12"`
+	UnitCost *float64 `json:"unit_cost" binding:"omitempty,gte=0" example:"48.00"`
+}
+
+// RequestPurchaseOrderAmendmentRequest represents a request to amend a confirmed order
+//
+//	@Description	Request body for amending a confirmed purchase order
+type RequestPurchaseOrderAmendmentRequest struct {
+	Lines  []PurchaseOrderAmendmentLineInput `json:"lines" binding:"required,min=1,dive"`
+	Reason string                            `json:"reason" binding:"required,min=1,max=500" example:"供应商调整报价"`
+}
+
+// RejectPurchaseOrderAmendmentRequest represents a request to reject a pending amendment
+//
+//	@Description	Request body for rejecting a purchase order amendment
+type RejectPurchaseOrderAmendmentRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500" example:"价格上涨未获批准"`
+}
+
+// PurchaseOrderAmendmentResponse represents a pending amendment in API responses
+//
+//	@Description	Purchase order amendment awaiting approval
+type PurchaseOrderAmendmentResponse struct {
+	Lines       []PurchaseOrderAmendmentLineResponse `json:"lines"`
+	Reason      string                               `json:"reason" example:"供应商调整报价"`
+	RequestedBy string                               `json:"requested_by" example:"550e8400-e29b-41d4-a716-446655440003"`
+	RequestedAt time.Time                            `json:"requested_at"`
+}
+
+// PurchaseOrderAmendmentLineResponse represents a proposed line change in API responses
+//
+//	@Description	Proposed purchase order line change
+type PurchaseOrderAmendmentLineResponse struct {
+	ItemID   string   `json:"item_id" example:"550e8400-e29b-41d4-a716-446655440020"`
+	Quantity *float64 `json:"quantity,omitempty" example:"12"`
+	UnitCost *float64 `json:"unit_cost,omitempty" example:"48.00"`
+}
+
 // PurchaseOrderResponse represents a purchase order in API responses
 //
 //	@Description	Purchase order response
 type PurchaseOrderResponse struct {
-	ID               string                      `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
-	TenantID         string                      `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	OrderNumber      string                      `json:"order_number" example:"PO-2026-00001"`
-	SupplierID       string                      `json:"supplier_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	SupplierName     string                      `json:"supplier_name" example:"供应商A"`
-	WarehouseID      *string                     `json:"warehouse_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
-	Items            []PurchaseOrderItemResponse `json:"items"`
-	ItemCount        int                         `json:"item_count" example:"3"`
-	TotalQuantity    float64                     `json:"total_quantity" example:"30"`
-	ReceivedQuantity float64                     `json:"received_quantity" example:"10"`
-	TotalAmount      float64                     `json:"total_amount" example:"1500.00"`
-	DiscountAmount   float64                     `json:"discount_amount" example:"100.00"`
-	PayableAmount    float64                     `json:"payable_amount" example:"1400.00"`
-	Status           string                      `json:"status" example:"draft"`
-	ReceiveProgress  float64                     `json:"receive_progress" example:"33.33"`
-	Remark           string                      `json:"remark" example:"备注信息"`
-	ConfirmedAt      *time.Time                  `json:"confirmed_at,omitempty"`
-	CompletedAt      *time.Time                  `json:"completed_at,omitempty"`
-	CancelledAt      *time.Time                  `json:"cancelled_at,omitempty"`
-	CancelReason     string                      `json:"cancel_reason,omitempty" example:""`
-	CreatedAt        time.Time                   `json:"created_at"`
-	UpdatedAt        time.Time                   `json:"updated_at"`
-	Version          int                         `json:"version" example:"1"`
+	ID               string                          `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	TenantID         string                          `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	OrderNumber      string                          `json:"order_number" example:"PO-2026-00001"`
+	SupplierID       string                          `json:"supplier_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	SupplierName     string                          `json:"supplier_name" example:"供应商A"`
+	WarehouseID      *string                         `json:"warehouse_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Items            []PurchaseOrderItemResponse     `json:"items"`
+	ItemCount        int                             `json:"item_count" example:"3"`
+	TotalQuantity    float64                         `json:"total_quantity" example:"30"`
+	ReceivedQuantity float64                         `json:"received_quantity" example:"10"`
+	TotalAmount      float64                         `json:"total_amount" example:"1500.00"`
+	DiscountAmount   float64                         `json:"discount_amount" example:"100.00"`
+	PayableAmount    float64                         `json:"payable_amount" example:"1400.00"`
+	Status           string                          `json:"status" example:"draft"`
+	ReceiveProgress  float64                         `json:"receive_progress" example:"33.33"`
+	Remark           string                          `json:"remark" example:"备注信息"`
+	ConfirmedAt      *time.Time                      `json:"confirmed_at,omitempty"`
+	CompletedAt      *time.Time                      `json:"completed_at,omitempty"`
+	CancelledAt      *time.Time                      `json:"cancelled_at,omitempty"`
+	CancelReason     string                          `json:"cancel_reason,omitempty" example:""`
+	PendingAmendment *PurchaseOrderAmendmentResponse `json:"pending_amendment,omitempty"`
+	CreatedAt        time.Time                       `json:"created_at"`
+	UpdatedAt        time.Time                       `json:"updated_at"`
+	Version          int                             `json:"version" example:"1"`
 }
 
 // PurchaseOrderListResponse represents a purchase order in list responses
@@ -213,13 +257,14 @@ type ReceiveResultResponse struct {
 //
 //	@Description	Purchase order status summary response
 type PurchaseOrderStatusSummaryResponse struct {
-	Draft           int64 `json:"draft" example:"5"`
-	Confirmed       int64 `json:"confirmed" example:"10"`
-	PartialReceived int64 `json:"partial_received" example:"3"`
-	Completed       int64 `json:"completed" example:"100"`
-	Cancelled       int64 `json:"cancelled" example:"3"`
-	Total           int64 `json:"total" example:"121"`
-	PendingReceipt  int64 `json:"pending_receipt" example:"13"`
+	Draft            int64 `json:"draft" example:"5"`
+	Confirmed        int64 `json:"confirmed" example:"10"`
+	PendingAmendment int64 `json:"pending_amendment" example:"1"`
+	PartialReceived  int64 `json:"partial_received" example:"3"`
+	Completed        int64 `json:"completed" example:"100"`
+	Cancelled        int64 `json:"cancelled" example:"3"`
+	Total            int64 `json:"total" example:"121"`
+	PendingReceipt   int64 `json:"pending_receipt" example:"13"`
 }
 
 // Create godoc
@@ -998,6 +1043,174 @@ func (h *PurchaseOrderHandler) Cancel(c *gin.Context) {
 	h.Success(c, toPurchaseOrderResponse(order))
 }
 
+// RequestAmendment godoc
+//
+//	@ID				requestPurchaseOrderAmendment
+//	@Summary		Request an amendment of a purchase order
+//	@Description	Propose quantity or unit cost changes for a confirmed purchase order. The order stays in PENDING_AMENDMENT until the amendment is approved or rejected. Not allowed once any goods have been received.
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string									false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string									true	"Purchase Order ID"	format(uuid)
+//	@Param			request		body		RequestPurchaseOrderAmendmentRequest	true	"Amendment request"
+//	@Success		200			{object}	APIResponse[PurchaseOrderResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/trade/purchase-orders/{id}/amendment [post]
+func (h *PurchaseOrderHandler) RequestAmendment(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid order ID format")
+		return
+	}
+
+	userID, err := getUserID(c)
+	if err != nil || userID == uuid.Nil {
+		h.Unauthorized(c, "Invalid user")
+		return
+	}
+
+	var req RequestPurchaseOrderAmendmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	appReq := tradeapp.RequestPurchaseOrderAmendmentRequest{
+		Lines:  make([]tradeapp.PurchaseOrderAmendmentLineInput, len(req.Lines)),
+		Reason: req.Reason,
+	}
+	for i, line := range req.Lines {
+		itemID, err := uuid.Parse(line.ItemID)
+		if err != nil {
+			h.BadRequest(c, "Invalid item ID format")
+			return
+		}
+		appReq.Lines[i].ItemID = itemID
+		if line.Quantity != nil {
+			d := decimal.NewFromFloat(*line.Quantity)
+			appReq.Lines[i].Quantity = &d
+		}
+		if line.UnitCost != nil {
+			d := decimal.NewFromFloat(*line.UnitCost)
+			appReq.Lines[i].UnitCost = &d
+		}
+	}
+
+	order, err := h.orderService.RequestAmendment(c.Request.Context(), tenantID, orderID, userID, appReq)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toPurchaseOrderResponse(order))
+}
+
+// ApproveAmendment godoc
+//
+//	@ID				approvePurchaseOrderAmendment
+//	@Summary		Approve a purchase order amendment
+//	@Description	Apply the pending amendment to the order lines, recalculate the totals and return the order to CONFIRMED
+//	@Tags			purchase-orders
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Purchase Order ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[PurchaseOrderResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/trade/purchase-orders/{id}/amendment/approve [post]
+func (h *PurchaseOrderHandler) ApproveAmendment(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid order ID format")
+		return
+	}
+
+	userID, err := getUserID(c)
+	if err != nil || userID == uuid.Nil {
+		h.Unauthorized(c, "Invalid user")
+		return
+	}
+
+	order, err := h.orderService.ApproveAmendment(c.Request.Context(), tenantID, orderID, userID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toPurchaseOrderResponse(order))
+}
+
+// RejectAmendment godoc
+//
+//	@ID				rejectPurchaseOrderAmendment
+//	@Summary		Reject a purchase order amendment
+//	@Description	Discard the pending amendment and return the order to CONFIRMED unchanged
+//	@Tags			purchase-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string								false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string								true	"Purchase Order ID"	format(uuid)
+//	@Param			request		body		RejectPurchaseOrderAmendmentRequest	true	"Reject amendment request"
+//	@Success		200			{object}	APIResponse[PurchaseOrderResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/trade/purchase-orders/{id}/amendment/reject [post]
+func (h *PurchaseOrderHandler) RejectAmendment(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid order ID format")
+		return
+	}
+
+	var req RejectPurchaseOrderAmendmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	order, err := h.orderService.RejectAmendment(c.Request.Context(), tenantID, orderID, tradeapp.RejectPurchaseOrderAmendmentRequest{
+		Reason: req.Reason,
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toPurchaseOrderResponse(order))
+}
+
 // GetStatusSummary godoc
 //
 //	@ID				getPurchaseOrderStatusSummary
@@ -1026,13 +1239,14 @@ func (h *PurchaseOrderHandler) GetStatusSummary(c *gin.Context) {
 	}
 
 	h.Success(c, PurchaseOrderStatusSummaryResponse{
-		Draft:           summary.Draft,
-		Confirmed:       summary.Confirmed,
-		PartialReceived: summary.PartialReceived,
-		Completed:       summary.Completed,
-		Cancelled:       summary.Cancelled,
-		Total:           summary.Total,
-		PendingReceipt:  summary.PendingReceipt,
+		Draft:            summary.Draft,
+		Confirmed:        summary.Confirmed,
+		PendingAmendment: summary.PendingAmendment,
+		PartialReceived:  summary.PartialReceived,
+		Completed:        summary.Completed,
+		Cancelled:        summary.Cancelled,
+		Total:            summary.Total,
+		PendingReceipt:   summary.PendingReceipt,
 	})
 }
 
@@ -1126,6 +1340,27 @@ func toPurchaseOrderResponse(order *tradeapp.PurchaseOrderResponse) PurchaseOrde
 		resp.WarehouseID = &warehouseID
 	}
 
+	if order.PendingAmendment != nil {
+		lines := make([]PurchaseOrderAmendmentLineResponse, len(order.PendingAmendment.Lines))
+		for i, line := range order.PendingAmendment.Lines {
+			lines[i] = PurchaseOrderAmendmentLineResponse{ItemID: line.ItemID.String()}
+			if line.Quantity != nil {
+				quantity := line.Quantity.InexactFloat64()
+				lines[i].Quantity = &quantity
+			}
+			if line.UnitCost != nil {
+				unitCost := line.UnitCost.InexactFloat64()
+				lines[i].UnitCost = &unitCost
+			}
+		}
+		resp.PendingAmendment = &PurchaseOrderAmendmentResponse{
+			Lines:       lines,
+			Reason:      order.PendingAmendment.Reason,
+			RequestedBy: order.PendingAmendment.RequestedBy.String(),
+			RequestedAt: order.PendingAmendment.RequestedAt,
+		}
+	}
+
 	return resp
 }
 
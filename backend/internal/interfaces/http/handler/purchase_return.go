@@ -32,6 +32,7 @@ type CreatePurchaseReturnRequest struct {
 	PurchaseOrderID string                          `json:"purchase_order_id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 	WarehouseID     *string                         `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
 	Items           []CreatePurchaseReturnItemInput `json:"items" binding:"required,min=1"`
+	ReasonCode      string                          `json:"reason_code" binding:"required,oneof=damaged defective wrong_item quality not_as_described expired customer_remorse other" example:"defective"`
 	Reason          string                          `json:"reason" example:"商品质量问题"`
 	Remark          string                          `json:"remark" example:"备注信息"`
 }
@@ -52,6 +53,7 @@ type CreatePurchaseReturnItemInput struct {
 //	@Description	Request body for updating a purchase return (draft only)
 type UpdatePurchaseReturnRequest struct {
 	WarehouseID *string `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ReasonCode  *string `json:"reason_code" example:"quality"`
 	Reason      *string `json:"reason" example:"更新退货原因"`
 	Remark      *string `json:"remark" example:"更新备注"`
 }
@@ -123,6 +125,7 @@ type PurchaseReturnResponse struct {
 	TotalQuantity       float64                      `json:"total_quantity" example:"8"`
 	TotalRefund         float64                      `json:"total_refund" example:"799.92"`
 	Status              string                       `json:"status" example:"draft"`
+	ReasonCode          string                       `json:"reason_code" example:"defective"`
 	Reason              string                       `json:"reason,omitempty" example:"商品质量问题"`
 	Remark              string                       `json:"remark,omitempty" example:"备注信息"`
 	SubmittedAt         *time.Time                   `json:"submitted_at,omitempty"`
@@ -158,6 +161,7 @@ type PurchaseReturnListResponse struct {
 	ItemCount           int        `json:"item_count" example:"2"`
 	TotalRefund         float64    `json:"total_refund" example:"799.92"`
 	Status              string     `json:"status" example:"pending"`
+	ReasonCode          string     `json:"reason_code" example:"defective"`
 	SubmittedAt         *time.Time `json:"submitted_at,omitempty"`
 	ApprovedAt          *time.Time `json:"approved_at,omitempty"`
 	ShippedAt           *time.Time `json:"shipped_at,omitempty"`
@@ -250,6 +254,7 @@ func (h *PurchaseReturnHandler) Create(c *gin.Context) {
 
 	appReq := tradeapp.CreatePurchaseReturnRequest{
 		PurchaseOrderID: purchaseOrderID,
+		ReasonCode:      req.ReasonCode,
 		Reason:          req.Reason,
 		Remark:          req.Remark,
 	}
@@ -468,8 +473,9 @@ func (h *PurchaseReturnHandler) Update(c *gin.Context) {
 
 	// Convert to application DTO
 	appReq := tradeapp.UpdatePurchaseReturnRequest{
-		Reason: req.Reason,
-		Remark: req.Remark,
+		ReasonCode: req.ReasonCode,
+		Reason:     req.Reason,
+		Remark:     req.Remark,
 	}
 
 	// Convert warehouse ID
@@ -1092,6 +1098,7 @@ func toPurchaseReturnResponse(pr *tradeapp.PurchaseReturnResponse) PurchaseRetur
 		TotalQuantity:       pr.TotalQuantity.InexactFloat64(),
 		TotalRefund:         pr.TotalRefund.InexactFloat64(),
 		Status:              pr.Status,
+		ReasonCode:          pr.ReasonCode,
 		Reason:              pr.Reason,
 		Remark:              pr.Remark,
 		SubmittedAt:         pr.SubmittedAt,
@@ -1147,6 +1154,7 @@ func toPurchaseReturnListResponses(returns []tradeapp.PurchaseReturnListItemResp
 			ItemCount:           pr.ItemCount,
 			TotalRefund:         pr.TotalRefund.InexactFloat64(),
 			Status:              pr.Status,
+			ReasonCode:          pr.ReasonCode,
 			SubmittedAt:         pr.SubmittedAt,
 			ApprovedAt:          pr.ApprovedAt,
 			ShippedAt:           pr.ShippedAt,
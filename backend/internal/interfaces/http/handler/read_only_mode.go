@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReadOnlyModeHandler lets operators switch the service's read-only mode
+type ReadOnlyModeHandler struct {
+	BaseHandler
+	mode   *middleware.ReadOnlyMode
+	logger *zap.Logger
+}
+
+// NewReadOnlyModeHandler creates a new read-only mode handler
+func NewReadOnlyModeHandler(mode *middleware.ReadOnlyMode, logger *zap.Logger) *ReadOnlyModeHandler {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &ReadOnlyModeHandler{
+		mode:   mode,
+		logger: logger,
+	}
+}
+
+// ReadOnlyModeResponse represents the read-only mode state
+type ReadOnlyModeResponse struct {
+	Enabled bool `json:"enabled" example:"false"`
+}
+
+// SetReadOnlyModeRequest represents a request to switch read-only mode
+type SetReadOnlyModeRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required" example:"true"`
+	Reason  string `json:"reason,omitempty" binding:"max=500" example:"Database maintenance"`
+}
+
+// GetReadOnlyMode godoc
+//
+//	@ID				getSystemReadOnlyMode
+//	@Summary		Get read-only mode
+//	@Description	Returns whether the service is in read-only mode, rejecting writes with service_read_only
+//	@Tags			system
+//	@Produce		json
+//	@Success		200	{object}	APIResponse[ReadOnlyModeResponse]
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/system/read-only [get]
+func (h *ReadOnlyModeHandler) GetReadOnlyMode(c *gin.Context) {
+	h.Success(c, ReadOnlyModeResponse{Enabled: h.mode.Enabled()})
+}
+
+// SetReadOnlyMode godoc
+//
+//	@ID				setSystemReadOnlyMode
+//	@Summary		Switch read-only mode
+//	@Description	Turns read-only mode on or off. While on, POST, PUT, PATCH and DELETE requests under the API
+//	@Description	are rejected with 503 service_read_only; reads, login, payment callbacks and this endpoint keep working.
+//	@Description	The switch applies to the instance that handles the request. Requires system:maintenance permission.
+//	@Tags			system
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		SetReadOnlyModeRequest	true	"Read-only mode state"
+//	@Success		200		{object}	APIResponse[ReadOnlyModeResponse]
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/system/read-only [put]
+func (h *ReadOnlyModeHandler) SetReadOnlyMode(c *gin.Context) {
+	var req SetReadOnlyModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	h.mode.SetEnabled(*req.Enabled)
+	h.logger.Warn("Read-only mode switched",
+		zap.Bool("enabled", *req.Enabled),
+		zap.String("reason", req.Reason),
+		zap.String("user_id", middleware.GetJWTUserID(c)),
+		zap.String("tenant_id", middleware.GetJWTTenantID(c)),
+	)
+
+	h.Success(c, ReadOnlyModeResponse{Enabled: h.mode.Enabled()})
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erp/backend/internal/interfaces/http/dto"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyModeHandler_SetReadOnlyMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mode := middleware.NewReadOnlyMode(false)
+	h := NewReadOnlyModeHandler(mode, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/system/read-only", strings.NewReader(`{"enabled":true,"reason":"maintenance"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.SetReadOnlyMode(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, mode.Enabled())
+	var resp dto.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, true, resp.Data.(map[string]any)["enabled"])
+}
+
+func TestReadOnlyModeHandler_SetReadOnlyMode_RequiresEnabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mode := middleware.NewReadOnlyMode(true)
+	h := NewReadOnlyModeHandler(mode, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/system/read-only", strings.NewReader(`{"reason":"oops"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	h.SetReadOnlyMode(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.True(t, mode.Enabled(), "a missing enabled field must not switch the mode off")
+}
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	reportapp "github.com/erp/backend/internal/application/report"
+	"github.com/erp/backend/internal/infrastructure/export"
 	"github.com/erp/backend/internal/infrastructure/scheduler"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -51,6 +53,15 @@ type SalesReportFilterRequest struct {
 	TopN       int    `form:"top_n" example:"10"`
 }
 
+// FulfillmentSLAFilterRequest defines the filter for the fulfillment SLA report
+//
+//	@Description	Filter for fulfillment SLA report queries
+type FulfillmentSLAFilterRequest struct {
+	StartDate string `form:"start_date" binding:"required" example:"2026-01-01"`
+	EndDate   string `form:"end_date" binding:"required" example:"2026-01-31"`
+	GroupBy   string `form:"group_by" binding:"omitempty,oneof=warehouse" example:"warehouse"`
+}
+
 // InventoryReportFilterRequest defines the filter for inventory reports
 //
 //	@Description	Filter for inventory report queries
@@ -63,6 +74,25 @@ type InventoryReportFilterRequest struct {
 	TopN        int    `form:"top_n" example:"10"`
 }
 
+// CustomerRankingRequest defines the filter for the customer ranking
+//
+//	@Description	Filter for customer sales ranking queries
+type CustomerRankingRequest struct {
+	SalesReportFilterRequest
+	Metric string `form:"metric" binding:"omitempty,oneof=revenue gross_margin order_count avg_order_value" example:"gross_margin"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+}
+
+// ProductTurnoverFilterRequest defines the filter and paging for the per-product turnover report
+//
+//	@Description	Filter for per-product inventory turnover queries
+type ProductTurnoverFilterRequest struct {
+	InventoryReportFilterRequest
+	SortOrder string `form:"sort_order" binding:"omitempty,oneof=asc desc" example:"asc"`
+	Page      int    `form:"page" example:"1"`
+	PageSize  int    `form:"page_size" binding:"omitempty,max=100" example:"20"`
+}
+
 // FinanceReportFilterRequest defines the filter for finance reports
 //
 //	@Description	Filter for finance report queries
@@ -75,6 +105,14 @@ type FinanceReportFilterRequest struct {
 	TopN       int    `form:"top_n" example:"10"`
 }
 
+// ReturnReportFilterRequest defines the filter for return reports
+//
+//	@Description	Filter for return report queries
+type ReturnReportFilterRequest struct {
+	StartDate string `form:"start_date" binding:"required" example:"2026-01-01"`
+	EndDate   string `form:"end_date" binding:"required" example:"2026-01-31"`
+}
+
 // ===================== Response DTOs (for Swagger) =====================
 
 // SalesSummaryResponse represents the sales summary response
@@ -124,11 +162,46 @@ type ProductSalesRankingResponse struct {
 type CustomerSalesRankingResponse struct {
 	Rank          int     `json:"rank" example:"1"`
 	CustomerID    string  `json:"customer_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CustomerCode  string  `json:"customer_code,omitempty" example:"C0001"`
 	CustomerName  string  `json:"customer_name" example:"ABC Company"`
 	TotalOrders   int64   `json:"total_orders" example:"30"`
 	TotalQuantity float64 `json:"total_quantity" example:"200"`
 	TotalAmount   float64 `json:"total_amount" example:"15000.00"`
 	TotalProfit   float64 `json:"total_profit" example:"4500.00"`
+	AvgOrderValue float64 `json:"avg_order_value" example:"500.00"`
+}
+
+// DurationStatsResponse represents how long orders spent in one fulfillment stage
+//
+//	@Description	Duration statistics for a fulfillment stage, in hours
+type DurationStatsResponse struct {
+	Count      int64   `json:"count" example:"120"`
+	InProgress int64   `json:"in_progress" example:"8"`
+	AvgHours   float64 `json:"avg_hours" example:"18.5"`
+	P95Hours   float64 `json:"p95_hours" example:"52.25"`
+	MaxHours   float64 `json:"max_hours" example:"96"`
+}
+
+// FulfillmentSLAResponse represents fulfillment durations for all orders or one warehouse
+//
+//	@Description	Fulfillment durations for a group of orders
+type FulfillmentSLAResponse struct {
+	WarehouseID    string                `json:"warehouse_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	WarehouseName  string                `json:"warehouse_name,omitempty" example:"Main Warehouse"`
+	OrderCount     int64                 `json:"order_count" example:"135"`
+	CancelledCount int64                 `json:"cancelled_count" example:"7"`
+	ConfirmToShip  DurationStatsResponse `json:"confirm_to_ship"`
+	ShipToComplete DurationStatsResponse `json:"ship_to_complete"`
+}
+
+// FulfillmentSLAReportResponse represents the fulfillment SLA report
+//
+//	@Description	Sales order fulfillment SLA report for a period
+type FulfillmentSLAReportResponse struct {
+	PeriodStart string                   `json:"period_start" example:"2026-01-01T00:00:00Z"`
+	PeriodEnd   string                   `json:"period_end" example:"2026-01-31T23:59:59Z"`
+	Overall     FulfillmentSLAResponse   `json:"overall"`
+	Warehouses  []FulfillmentSLAResponse `json:"warehouses,omitempty"`
 }
 
 // InventorySummaryResponse represents inventory summary
@@ -174,6 +247,24 @@ type InventoryTurnoverResponse struct {
 	DaysOfStockOnHand float64 `json:"days_of_stock_on_hand" example:"81.1"`
 }
 
+// ProductTurnoverResponse represents the turnover of a product over a period
+//
+//	@Description	Per-product inventory turnover for a period
+type ProductTurnoverResponse struct {
+	ProductID       string   `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ProductSKU      string   `json:"product_sku" example:"SKU-001"`
+	ProductName     string   `json:"product_name" example:"Sample Product"`
+	CategoryName    string   `json:"category_name,omitempty" example:"Electronics"`
+	OpeningQuantity float64  `json:"opening_quantity" example:"120"`
+	ClosingQuantity float64  `json:"closing_quantity" example:"80"`
+	AverageQuantity float64  `json:"average_quantity" example:"100"`
+	AverageValue    float64  `json:"average_value" example:"5000.00"`
+	SoldQuantity    float64  `json:"sold_quantity" example:"300"`
+	COGS            float64  `json:"cogs" example:"15000.00"`
+	TurnoverRatio   *float64 `json:"turnover_ratio" example:"3.0"`
+	DaysOnHand      *float64 `json:"days_on_hand" example:"10.3"`
+}
+
 // InventoryValueByCategoryResponse represents inventory value by category
 //
 //	@Description	Inventory value grouped by category
@@ -340,6 +431,32 @@ type CashFlowItemResponse struct {
 	RunningBalance float64 `json:"running_balance,omitempty" example:"55000.00"`
 }
 
+// ReturnReasonResponse represents the returns filed under one reason code
+//
+//	@Description	Return counts and refund amounts for one reason code
+type ReturnReasonResponse struct {
+	ReasonCode           string  `json:"reason_code" example:"damaged"`
+	ReturnCount          int64   `json:"return_count" example:"12"`
+	TotalAmount          float64 `json:"total_amount" example:"6400.00"`
+	SalesReturnCount     int64   `json:"sales_return_count" example:"9"`
+	SalesReturnAmount    float64 `json:"sales_return_amount" example:"4500.00"`
+	PurchaseReturnCount  int64   `json:"purchase_return_count" example:"3"`
+	PurchaseReturnAmount float64 `json:"purchase_return_amount" example:"1900.00"`
+}
+
+// ReturnReasonReportResponse represents return analytics by reason code
+//
+//	@Description	Sales and purchase returns by reason code for a period
+type ReturnReasonReportResponse struct {
+	PeriodStart          string                 `json:"period_start" example:"2026-01-01T00:00:00Z"`
+	PeriodEnd            string                 `json:"period_end" example:"2026-01-31T23:59:59Z"`
+	TotalSalesReturns    int64                  `json:"total_sales_returns" example:"20"`
+	TotalSalesAmount     float64                `json:"total_sales_amount" example:"9800.00"`
+	TotalPurchaseReturns int64                  `json:"total_purchase_returns" example:"5"`
+	TotalPurchaseAmount  float64                `json:"total_purchase_amount" example:"3100.00"`
+	Reasons              []ReturnReasonResponse `json:"reasons"`
+}
+
 // ===================== Sales Report Endpoints =====================
 
 // ===================== Sales Report Endpoints =====================
@@ -348,14 +465,20 @@ type CashFlowItemResponse struct {
 //
 //	@ID				getReportSalesSummary
 //	@Summary		Get sales summary
-//	@Description	Get aggregated sales summary for the specified period
+//	@Description	Get aggregated sales summary for the specified period.
+//	@Description	With compare_to, responds with current and comparison summaries and a delta object holding the
+//	@Description	absolute and percent change of each metric; the percent is null when the comparison value is zero.
 //	@Tags			reports
 //	@Produce		json
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 //	@Param			start_date	query		string	true	"Start date (YYYY-MM-DD)"
 //	@Param			end_date	query		string	true	"End date (YYYY-MM-DD)"
 //	@Param			product_id	query		string	false	"Filter by product ID"
 //	@Param			category_id	query		string	false	"Filter by category ID"
 //	@Param			customer_id	query		string	false	"Filter by customer ID"
+//	@Param			compare_to	query		string	false	"Compare with another period"	Enums(previous_period, previous_year)
+//	@Param			format		query		string	false	"Download as a file instead of JSON"	Enums(csv, xlsx)
 //	@Success		200			{object}	APIResponse[SalesSummaryResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
@@ -379,6 +502,34 @@ func (h *ReportHandler) GetSalesSummary(c *gin.Context) {
 		return
 	}
 
+	format, ok := h.exportFormat(c)
+	if !ok {
+		return
+	}
+	compareTo, ok := h.comparePeriod(c, format)
+	if !ok {
+		return
+	}
+	if format != "" {
+		summary, err := h.reportService.SalesSummary(c.Request.Context(), tenantID, filter)
+		if err != nil {
+			h.HandleError(c, err)
+			return
+		}
+		h.exportReport(c, "sales-summary", format, summary)
+		return
+	}
+
+	if compareTo != "" {
+		comparison, err := h.reportService.CompareSalesSummary(c.Request.Context(), tenantID, filter, compareTo)
+		if err != nil {
+			h.HandleError(c, err)
+			return
+		}
+		h.Success(c, comparison)
+		return
+	}
+
 	summary, err := h.reportService.GetSalesSummary(c.Request.Context(), tenantID, filter)
 	if err != nil {
 		h.HandleError(c, err)
@@ -392,7 +543,8 @@ func (h *ReportHandler) GetSalesSummary(c *gin.Context) {
 //
 //	@ID				getReportDailySalesTrend
 //	@Summary		Get daily sales trend
-//	@Description	Get daily sales trend data for the specified period
+//	@Description	Get the daily trend of orders completed in the specified period, by completion date.
+//	@Description	Read from the daily sales fact table, which is rebuilt by the aggregation job and on order completion.
 //	@Tags			reports
 //	@Produce		json
 //	@Param			start_date	query		string	true	"Start date (YYYY-MM-DD)"
@@ -436,10 +588,13 @@ func (h *ReportHandler) GetDailySalesTrend(c *gin.Context) {
 //	@Description	Get top products by sales for the specified period
 //	@Tags			reports
 //	@Produce		json
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 //	@Param			start_date	query		string	true	"Start date (YYYY-MM-DD)"
 //	@Param			end_date	query		string	true	"End date (YYYY-MM-DD)"
 //	@Param			category_id	query		string	false	"Filter by category ID"
 //	@Param			top_n		query		int		false	"Number of top products (default 10)"
+//	@Param			format		query		string	false	"Download as a file instead of JSON"	Enums(csv, xlsx)
 //	@Success		200			{object}	APIResponse[[]ProductSalesRankingResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
@@ -463,6 +618,20 @@ func (h *ReportHandler) GetProductSalesRanking(c *gin.Context) {
 		return
 	}
 
+	format, ok := h.exportFormat(c)
+	if !ok {
+		return
+	}
+	if format != "" {
+		rankings, err := h.reportService.ProductSalesRanking(c.Request.Context(), tenantID, filter)
+		if err != nil {
+			h.HandleError(c, err)
+			return
+		}
+		h.exportReport(c, "product-sales-ranking", format, rankings)
+		return
+	}
+
 	rankings, err := h.reportService.GetProductSalesRanking(c.Request.Context(), tenantID, filter)
 	if err != nil {
 		h.HandleError(c, err)
@@ -476,12 +645,16 @@ func (h *ReportHandler) GetProductSalesRanking(c *gin.Context) {
 //
 //	@ID				getReportCustomerSalesRanking
 //	@Summary		Get customer sales ranking
-//	@Description	Get top customers by sales for the specified period
+//	@Description	Get top customers for the specified period by revenue, gross margin, order count or average order value.
+//	@Description	Gross margin is revenue less the cost of goods sold under the tenant's cost strategy.
+//	@Description	Ties are ordered by customer code.
 //	@Tags			reports
 //	@Produce		json
 //	@Param			start_date	query		string	true	"Start date (YYYY-MM-DD)"
 //	@Param			end_date	query		string	true	"End date (YYYY-MM-DD)"
-//	@Param			top_n		query		int		false	"Number of top customers (default 10)"
+//	@Param			metric		query		string	false	"Metric to rank by"	Enums(revenue, gross_margin, order_count, avg_order_value)	default(revenue)
+//	@Param			limit		query		int		false	"Number of top customers (default 10)"	maximum(100)
+//	@Param			top_n		query		int		false	"Deprecated alias of limit"
 //	@Success		200			{object}	APIResponse[[]CustomerSalesRankingResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
@@ -493,17 +666,21 @@ func (h *ReportHandler) GetCustomerSalesRanking(c *gin.Context) {
 		return
 	}
 
-	var req SalesReportFilterRequest
+	var req CustomerRankingRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		h.BadRequest(c, err.Error())
 		return
 	}
 
-	filter, err := h.parseSalesFilter(req)
+	filter, err := h.parseSalesFilter(req.SalesReportFilterRequest)
 	if err != nil {
 		h.BadRequest(c, err.Error())
 		return
 	}
+	filter.Metric = req.Metric
+	if req.Limit > 0 {
+		filter.TopN = req.Limit
+	}
 
 	rankings, err := h.reportService.GetCustomerSalesRanking(c.Request.Context(), tenantID, filter)
 	if err != nil {
@@ -514,6 +691,48 @@ func (h *ReportHandler) GetCustomerSalesRanking(c *gin.Context) {
 	h.Success(c, rankings)
 }
 
+// GetFulfillmentSLA godoc
+//
+//	@ID				getReportFulfillmentSla
+//	@Summary		Get sales order fulfillment SLA
+//	@Description	Get average, p95 and maximum confirm-to-ship and ship-to-complete durations (in hours) for orders confirmed in the specified period. Cancelled orders are left out of the durations, and open orders are counted as in progress.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			start_date	query		string	true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string	true	"End date (YYYY-MM-DD)"
+//	@Param			group_by	query		string	false	"Also break the durations down by warehouse"	Enums(warehouse)
+//	@Success		200			{object}	APIResponse[FulfillmentSLAReportResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Router			/reports/sales/fulfillment-sla [get]
+func (h *ReportHandler) GetFulfillmentSLA(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	var req FulfillmentSLAFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	filter, err := h.parseFulfillmentSLAFilter(req)
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	sla, err := h.reportService.GetFulfillmentSLA(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	h.Success(c, sla)
+}
+
 // ===================== Inventory Report Endpoints =====================
 
 // ===================== Inventory Report Endpoints =====================
@@ -605,6 +824,70 @@ func (h *ReportHandler) GetInventoryTurnover(c *gin.Context) {
 	h.Success(c, turnovers)
 }
 
+// GetProductTurnover godoc
+//
+//	@ID				getReportProductTurnover
+//	@Summary		Get per-product inventory turnover
+//	@Description	Get the turnover ratio (COGS / average inventory value) and days on hand of each product over a period.
+//	@Description	Average inventory is the mean of the opening and closing ledger balances. Sorted by turnover ratio,
+//	@Description	slowest movers first by default; products that held no inventory have a null ratio and come last.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			start_date		query		string	true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date		query		string	true	"End date (YYYY-MM-DD)"
+//	@Param			warehouse_id	query		string	false	"Filter by warehouse ID"
+//	@Param			category_id		query		string	false	"Filter by category ID"
+//	@Param			product_id		query		string	false	"Filter by product ID"
+//	@Param			sort_order		query		string	false	"Sort by turnover ratio"	Enums(asc, desc)	default(asc)
+//	@Param			page			query		int		false	"Page number"			default(1)
+//	@Param			page_size		query		int		false	"Page size"				default(20)	maximum(100)
+//	@Success		200				{object}	APIResponse[[]ProductTurnoverResponse]
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Router			/reports/inventory/turnover/products [get]
+func (h *ReportHandler) GetProductTurnover(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	var req ProductTurnoverFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	inventoryFilter, err := h.parseInventoryFilter(req.InventoryReportFilterRequest)
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 20
+	}
+
+	turnovers, total, err := h.reportService.GetProductTurnover(c.Request.Context(), tenantID, reportapp.ProductTurnoverFilter{
+		StartDate:   inventoryFilter.StartDate,
+		EndDate:     inventoryFilter.EndDate,
+		ProductID:   inventoryFilter.ProductID,
+		CategoryID:  inventoryFilter.CategoryID,
+		WarehouseID: inventoryFilter.WarehouseID,
+		Descending:  req.SortOrder == "desc",
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+	})
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	h.SuccessWithMeta(c, turnovers, total, req.Page, req.PageSize)
+}
+
 // GetInventoryValueByCategory godoc
 //
 //	@ID				getReportInventoryValueByCategory
@@ -738,11 +1021,17 @@ func (h *ReportHandler) GetSlowMovingProducts(c *gin.Context) {
 //
 //	@ID				getReportProfitLossStatement
 //	@Summary		Get profit and loss statement
-//	@Description	Get P&L statement for the specified period
+//	@Description	Get P&L statement for the specified period.
+//	@Description	With compare_to, responds with current and comparison statements and a delta object holding the
+//	@Description	absolute and percent change of each line; the percent is null when the comparison value is zero.
 //	@Tags			reports
 //	@Produce		json
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 //	@Param			start_date	query		string	true	"Start date (YYYY-MM-DD)"
 //	@Param			end_date	query		string	true	"End date (YYYY-MM-DD)"
+//	@Param			compare_to	query		string	false	"Compare with another period"	Enums(previous_period, previous_year)
+//	@Param			format		query		string	false	"Download as a file instead of JSON"	Enums(csv, xlsx)
 //	@Success		200			{object}	APIResponse[ProfitLossStatementResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
@@ -766,6 +1055,34 @@ func (h *ReportHandler) GetProfitLossStatement(c *gin.Context) {
 		return
 	}
 
+	format, ok := h.exportFormat(c)
+	if !ok {
+		return
+	}
+	compareTo, ok := h.comparePeriod(c, format)
+	if !ok {
+		return
+	}
+	if format != "" {
+		statement, err := h.reportService.ProfitLossStatement(c.Request.Context(), tenantID, filter)
+		if err != nil {
+			h.HandleError(c, err)
+			return
+		}
+		h.exportReport(c, "profit-loss", format, statement)
+		return
+	}
+
+	if compareTo != "" {
+		comparison, err := h.reportService.CompareProfitLossStatement(c.Request.Context(), tenantID, filter, compareTo)
+		if err != nil {
+			h.HandleError(c, err)
+			return
+		}
+		h.Success(c, comparison)
+		return
+	}
+
 	statement, err := h.reportService.GetProfitLossStatement(c.Request.Context(), tenantID, filter)
 	if err != nil {
 		h.HandleError(c, err)
@@ -941,8 +1258,70 @@ func (h *ReportHandler) GetCashFlowItems(c *gin.Context) {
 	h.Success(c, items)
 }
 
+// ===================== Return Report Endpoints =====================
+
+// GetReturnReasons godoc
+//
+//	@ID				getReportReturnReasons
+//	@Summary		Get returns by reason
+//	@Description	Get sales and purchase return counts and refund amounts grouped by reason code for returns submitted in the specified period, most frequent reason first. Draft, rejected and cancelled returns are excluded.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			start_date	query		string	true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string	true	"End date (YYYY-MM-DD)"
+//	@Success		200			{object}	APIResponse[ReturnReasonReportResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Router			/reports/returns/reasons [get]
+func (h *ReportHandler) GetReturnReasons(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	var req ReturnReportFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	filter, err := h.parseReturnFilter(req)
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	report, err := h.reportService.GetReturnReasonReport(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	h.Success(c, report)
+}
+
 // ===================== Helper Functions =====================
 
+// comparePeriod returns the period requested with ?compare_to=, or "" for no comparison.
+// An invalid value, or a comparison with a file export, responds with 400 and returns false.
+func (h *ReportHandler) comparePeriod(c *gin.Context, format export.Format) (reportapp.ComparePeriod, bool) {
+	value := c.Query("compare_to")
+	if value == "" {
+		return "", true
+	}
+	compareTo, err := reportapp.ParseComparePeriod(value)
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return "", false
+	}
+	if format != "" {
+		h.BadRequest(c, "compare_to: not supported with format")
+		return "", false
+	}
+	return compareTo, true
+}
+
 func (h *ReportHandler) parseSalesFilter(req SalesReportFilterRequest) (reportapp.SalesReportFilter, error) {
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
@@ -990,6 +1369,27 @@ func (h *ReportHandler) parseSalesFilter(req SalesReportFilterRequest) (reportap
 	return filter, nil
 }
 
+func (h *ReportHandler) parseFulfillmentSLAFilter(req FulfillmentSLAFilterRequest) (reportapp.FulfillmentSLAFilter, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return reportapp.FulfillmentSLAFilter{}, errors.New("start_date: Invalid date format, expected YYYY-MM-DD")
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return reportapp.FulfillmentSLAFilter{}, errors.New("end_date: Invalid date format, expected YYYY-MM-DD")
+	}
+
+	// Set end date to end of day
+	endDate = endDate.Add(24*time.Hour - time.Second)
+
+	return reportapp.FulfillmentSLAFilter{
+		StartDate:        startDate,
+		EndDate:          endDate,
+		GroupByWarehouse: req.GroupBy == "warehouse",
+	}, nil
+}
+
 func (h *ReportHandler) parseInventoryFilter(req InventoryReportFilterRequest) (reportapp.InventoryReportFilter, error) {
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
@@ -1084,6 +1484,26 @@ func (h *ReportHandler) parseFinanceFilter(req FinanceReportFilterRequest) (repo
 	return filter, nil
 }
 
+func (h *ReportHandler) parseReturnFilter(req ReturnReportFilterRequest) (reportapp.ReturnReportFilter, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return reportapp.ReturnReportFilter{}, errors.New("start_date: Invalid date format, expected YYYY-MM-DD")
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return reportapp.ReturnReportFilter{}, errors.New("end_date: Invalid date format, expected YYYY-MM-DD")
+	}
+
+	// Set end date to end of day
+	endDate = endDate.Add(24*time.Hour - time.Second)
+
+	return reportapp.ReturnReportFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil
+}
+
 // ===================== Manual Refresh Endpoints =====================
 
 // RefreshReportRequest defines the request for manual report refresh
@@ -1267,19 +1687,48 @@ func (h *ReportHandler) GetSchedulerStatus(c *gin.Context) {
 type TriggerDailyAggregationRequest struct {
 	StartDate string `json:"start_date" example:"2026-01-01"`
 	EndDate   string `json:"end_date" example:"2026-01-31"`
+	// TenantID limits the run to one tenant. Only platform administrators may name another
+	// tenant or, by leaving it empty without a date range, run all active tenants; everyone
+	// else defaults to the current tenant.
+	TenantID string `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Force starts the run even if another aggregation run is in progress
+	Force bool `json:"force" example:"false"`
+}
+
+// AggregationRunResponse represents a report aggregation run
+//
+//	@Description	Status and progress of a report aggregation run
+type AggregationRunResponse struct {
+	ID            string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status        string     `json:"status" example:"RUNNING" enums:"RUNNING,SUCCESS,FAILED"`
+	TenantID      *string    `json:"tenant_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PeriodStart   time.Time  `json:"period_start"`
+	PeriodEnd     time.Time  `json:"period_end"`
+	Progress      int        `json:"progress" example:"50"`
+	TotalJobs     int        `json:"total_jobs" example:"6"`
+	CompletedJobs int        `json:"completed_jobs" example:"3"`
+	FailedJobs    int        `json:"failed_jobs" example:"0"`
+	Error         string     `json:"error,omitempty"`
+	StartedAt     time.Time  `json:"started_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
 }
 
 // TriggerDailyAggregation godoc
 //
 //	@ID				triggerDailyAggregationReport
 //	@Summary		Trigger daily report aggregation
-//	@Description	Manually triggers the daily report aggregation for a specific date range
+//	@Description	Manually triggers the report aggregation for an optional date range (defaults to yesterday)
+//	@Description	and tenant, and returns the run to poll with GET /reports/scheduler/runs/{id}.
+//	@Description	A run is rejected with 409 while another run covering any of its tenants is in progress, unless force is set.
+//	@Description	Requires report:aggregate permission.
 //	@Tags			reports
 //	@Accept			json
 //	@Produce		json
-//	@Param			request	body		TriggerDailyAggregationRequest	false	"Date range (optional, defaults to yesterday)"
-//	@Success		200		{object}	APIResponse[RefreshReportResponse]
+//	@Param			request	body		TriggerDailyAggregationRequest	false	"Date range and tenant (optional)"
+//	@Success		200		{object}	APIResponse[AggregationRunResponse]
 //	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
 //	@Failure		500		{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/reports/scheduler/trigger [post]
@@ -1295,14 +1744,13 @@ func (h *ReportHandler) TriggerDailyAggregation(c *gin.Context) {
 		return
 	}
 
-	// If dates provided, trigger for specific tenant with date range
-	if req.StartDate != "" && req.EndDate != "" {
-		tenantID, err := getTenantID(c)
-		if err != nil {
-			h.BadRequest(c, "Invalid tenant ID")
-			return
-		}
+	aggregation := scheduler.AggregationRequest{Force: req.Force}
 
+	if (req.StartDate == "") != (req.EndDate == "") {
+		h.BadRequest(c, "start_date and end_date must be given together")
+		return
+	}
+	if req.StartDate != "" {
 		startDate, err := time.Parse("2006-01-02", req.StartDate)
 		if err != nil {
 			h.BadRequest(c, "start_date: Invalid date format, expected YYYY-MM-DD")
@@ -1314,26 +1762,107 @@ func (h *ReportHandler) TriggerDailyAggregation(c *gin.Context) {
 			h.BadRequest(c, "end_date: Invalid date format, expected YYYY-MM-DD")
 			return
 		}
-		endDate = endDate.Add(24*time.Hour - time.Second)
+		aggregation.PeriodStart = startDate
+		aggregation.PeriodEnd = endDate.Add(24*time.Hour - time.Second)
+	}
 
-		if err := h.cronScheduler.TriggerTenantAggregation(c.Request.Context(), tenantID, startDate, endDate); err != nil {
-			h.HandleError(c, err)
+	switch {
+	case req.TenantID != "":
+		tenantID, err := uuid.Parse(req.TenantID)
+		if err != nil {
+			h.BadRequest(c, "tenant_id: Invalid tenant ID format")
+			return
+		}
+		if !canAccessTenant(c, tenantID) {
+			h.Forbidden(c, "Cannot trigger report aggregation for another tenant")
+			return
+		}
+		aggregation.TenantID = &tenantID
+	case req.StartDate != "" || !middleware.IsPlatformAdmin(c):
+		// A date range without a tenant aggregates the current tenant, and only
+		// platform administrators may aggregate all tenants at once
+		tenantID, err := getTenantID(c)
+		if err != nil {
+			h.BadRequest(c, "Invalid tenant ID")
 			return
 		}
+		aggregation.TenantID = &tenantID
+	}
 
-		h.Success(c, RefreshReportResponse{
-			Message: "Report aggregation triggered for specified date range",
-		})
+	run, err := h.cronScheduler.TriggerAggregation(c.Request.Context(), aggregation)
+	if err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrJobAlreadyRunning):
+			h.Conflict(c, "A report aggregation run covering these tenants is already in progress; set force to start another")
+		case errors.Is(err, scheduler.ErrInvalidPeriod):
+			h.BadRequest(c, "end_date cannot be before start_date")
+		default:
+			h.HandleError(c, err)
+		}
 		return
 	}
 
-	// Otherwise trigger full daily aggregation for all tenants
-	if err := h.cronScheduler.TriggerManualRun(c.Request.Context()); err != nil {
-		h.HandleError(c, err)
+	h.Success(c, toAggregationRunResponse(run))
+}
+
+// GetSchedulerRun godoc
+//
+//	@ID				getReportSchedulerRun
+//	@Summary		Get a report aggregation run
+//	@Description	Returns the status, progress and any error of a report aggregation run.
+//	@Description	Finished runs can be polled for a day.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			id	path		string	true	"Run ID"	format(uuid)
+//	@Success		200	{object}	APIResponse[AggregationRunResponse]
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/reports/scheduler/runs/{id} [get]
+func (h *ReportHandler) GetSchedulerRun(c *gin.Context) {
+	if h.cronScheduler == nil {
+		h.InternalError(c, "Report cron scheduler not configured")
 		return
 	}
 
-	h.Success(c, RefreshReportResponse{
-		Message: "Daily report aggregation triggered for all tenants",
-	})
+	runID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid run ID format")
+		return
+	}
+
+	// Runs of other tenants are reported as not found so their IDs are not disclosed
+	run, ok := h.cronScheduler.GetRun(runID)
+	if ok && !middleware.IsPlatformAdmin(c) && (run.TenantID == nil || !canAccessTenant(c, *run.TenantID)) {
+		ok = false
+	}
+	if !ok {
+		h.NotFound(c, "Report aggregation run not found")
+		return
+	}
+
+	h.Success(c, toAggregationRunResponse(&run))
+}
+
+// toAggregationRunResponse converts an aggregation run to its response
+func toAggregationRunResponse(run *scheduler.AggregationRun) AggregationRunResponse {
+	resp := AggregationRunResponse{
+		ID:            run.ID.String(),
+		Status:        string(run.Status),
+		PeriodStart:   run.PeriodStart,
+		PeriodEnd:     run.PeriodEnd,
+		Progress:      run.Progress(),
+		TotalJobs:     run.TotalJobs,
+		CompletedJobs: run.CompletedJobs,
+		FailedJobs:    run.FailedJobs,
+		Error:         run.Error,
+		StartedAt:     run.StartedAt,
+		CompletedAt:   run.CompletedAt,
+	}
+	if run.TenantID != nil {
+		tenantID := run.TenantID.String()
+		resp.TenantID = &tenantID
+	}
+	return resp
 }
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/erp/backend/internal/infrastructure/export"
+	"github.com/gin-gonic/gin"
+)
+
+// exportFormat returns the file format requested with ?format=, or "" for a JSON response.
+// An unsupported format responds with 400 and returns false.
+func (h *ReportHandler) exportFormat(c *gin.Context) (export.Format, bool) {
+	name := c.Query("format")
+	if name == "" || name == "json" {
+		return "", true
+	}
+	format, err := export.ParseFormat(name)
+	if err != nil {
+		h.BadRequest(c, err.Error())
+		return "", false
+	}
+	return format, true
+}
+
+// exportReport streams the tabular form of report data as a file download.
+// The file is named after the report and the requested period.
+func (h *ReportHandler) exportReport(c *gin.Context, name string, format export.Format, data any) {
+	table, err := export.TableFrom(data)
+	if err != nil {
+		h.InternalError(c, "Failed to export report")
+		return
+	}
+
+	filename := name
+	if start, end := c.Query("start_date"), c.Query("end_date"); start != "" && end != "" {
+		filename += "_" + start + "_" + end
+	}
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"."+format.Extension()+"\"")
+	c.Status(http.StatusOK)
+
+	if err := export.Write(c.Writer, format, table); err != nil {
+		// The file is partially sent; the status can no longer change
+		c.Abort()
+	}
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/infrastructure/auth"
+	"github.com/erp/backend/internal/infrastructure/scheduler"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// gatedReportExecutor runs report jobs once released
+type gatedReportExecutor struct {
+	release chan struct{}
+}
+
+func (e *gatedReportExecutor) Execute(ctx context.Context, job *scheduler.Job) error {
+	select {
+	case <-e.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func setupReportSchedulerRouter(t *testing.T, tenantID uuid.UUID, permissions ...string) (*gin.Engine, *gatedReportExecutor) {
+	t.Helper()
+	h, executor := setupReportSchedulerHandler(t)
+	return newReportSchedulerRouter(h, tenantID, permissions...), executor
+}
+
+func setupReportSchedulerHandler(t *testing.T) (*ReportHandler, *gatedReportExecutor) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	executor := &gatedReportExecutor{release: make(chan struct{})}
+	cronScheduler := scheduler.NewReportCronScheduler(scheduler.DefaultReportCronSchedulerConfig(), executor, &mockTenantRepository{}, nil, zap.NewNop())
+	require.NoError(t, cronScheduler.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = cronScheduler.Stop(ctx)
+	})
+
+	h := NewReportHandler(nil)
+	h.SetCronScheduler(cronScheduler)
+	return h, executor
+}
+
+// newReportSchedulerRouter serves the scheduler endpoints as a caller of the given tenant
+func newReportSchedulerRouter(h *ReportHandler, tenantID uuid.UUID, permissions ...string) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.JWTTenantIDKey, tenantID.String())
+		c.Set(middleware.JWTClaimsKey, &auth.Claims{TenantID: tenantID.String(), Permissions: permissions})
+		c.Next()
+	})
+	router.POST("/api/v1/reports/scheduler/trigger", h.TriggerDailyAggregation)
+	router.GET("/api/v1/reports/scheduler/runs/:id", h.GetSchedulerRun)
+	return router
+}
+
+func triggerAggregation(router *gin.Engine, body map[string]any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/reports/scheduler/trigger", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func getAggregationRun(t *testing.T, router *gin.Engine, id string) (int, AggregationRunResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/scheduler/runs/"+id, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp struct {
+		Data AggregationRunResponse `json:"data"`
+	}
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp.Data
+}
+
+func TestReportHandler_TriggerDailyAggregation_CustomRange(t *testing.T) {
+	tenantID := uuid.New()
+	router, executor := setupReportSchedulerRouter(t, tenantID)
+
+	w := triggerAggregation(router, map[string]any{"start_date": "2026-03-01", "end_date": "2026-03-31"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Success bool                   `json:"success"`
+		Data    AggregationRunResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	run := resp.Data
+	assert.True(t, resp.Success)
+	assert.NotEmpty(t, run.ID)
+	assert.Equal(t, "RUNNING", run.Status)
+	assert.Equal(t, 0, run.Progress)
+	require.NotNil(t, run.TenantID)
+	assert.Equal(t, tenantID.String(), *run.TenantID)
+	assert.Equal(t, "2026-03-01", run.PeriodStart.Format("2006-01-02"))
+	assert.Equal(t, "2026-03-31", run.PeriodEnd.Format("2006-01-02"))
+
+	// A second run is rejected while the first is in progress, unless forced
+	w = triggerAggregation(router, map[string]any{})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Poll through to completion
+	code, polled := getAggregationRun(t, router, run.ID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "RUNNING", polled.Status)
+
+	close(executor.release)
+	require.Eventually(t, func() bool {
+		_, polled = getAggregationRun(t, router, run.ID)
+		return polled.Status != "RUNNING"
+	}, 5*time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, "SUCCESS", polled.Status)
+	assert.Equal(t, 100, polled.Progress)
+	assert.Equal(t, polled.TotalJobs, polled.CompletedJobs)
+	assert.Empty(t, polled.Error)
+	assert.NotNil(t, polled.CompletedAt)
+}
+
+func TestReportHandler_TriggerDailyAggregation_Force(t *testing.T) {
+	tenantID := uuid.New()
+	router, executor := setupReportSchedulerRouter(t, tenantID, identity.PermissionPlatformAdmin)
+	defer close(executor.release)
+
+	otherTenant := uuid.New()
+	w := triggerAggregation(router, map[string]any{"tenant_id": otherTenant.String()})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = triggerAggregation(router, map[string]any{"tenant_id": otherTenant.String(), "force": true})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Data AggregationRunResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Data.TenantID)
+	assert.Equal(t, otherTenant.String(), *resp.Data.TenantID)
+}
+
+func TestReportHandler_TriggerDailyAggregation_TenantScope(t *testing.T) {
+	tenantID := uuid.New()
+	router, executor := setupReportSchedulerRouter(t, tenantID, "report:aggregate")
+	defer close(executor.release)
+
+	// Another tenant cannot be named without platform administration
+	w := triggerAggregation(router, map[string]any{"tenant_id": uuid.New().String()})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Without a tenant the run is confined to the caller's tenant instead of all tenants
+	w = triggerAggregation(router, map[string]any{})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Data AggregationRunResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Data.TenantID)
+	assert.Equal(t, tenantID.String(), *resp.Data.TenantID)
+}
+
+func TestReportHandler_GetSchedulerRun_OtherTenant(t *testing.T) {
+	h, executor := setupReportSchedulerHandler(t)
+	defer close(executor.release)
+
+	tenantA, tenantB := uuid.New(), uuid.New()
+	routerA := newReportSchedulerRouter(h, tenantA, "report:aggregate")
+	routerB := newReportSchedulerRouter(h, tenantB, "report:aggregate")
+	adminRouter := newReportSchedulerRouter(h, uuid.New(), identity.PermissionPlatformAdmin)
+
+	triggerRun := func(router *gin.Engine, body map[string]any) string {
+		w := triggerAggregation(router, body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Data AggregationRunResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data.ID
+	}
+	tenantRun := triggerRun(routerA, map[string]any{})
+	allTenantsRun := triggerRun(adminRouter, map[string]any{"force": true})
+
+	code, _ := getAggregationRun(t, routerA, tenantRun)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = getAggregationRun(t, routerB, tenantRun)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// A run over all tenants is only visible to platform administrators
+	code, _ = getAggregationRun(t, routerA, allTenantsRun)
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = getAggregationRun(t, adminRouter, allTenantsRun)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = getAggregationRun(t, adminRouter, tenantRun)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestReportHandler_TriggerDailyAggregation_InvalidRequest(t *testing.T) {
+	router, executor := setupReportSchedulerRouter(t, uuid.New())
+	defer close(executor.release)
+
+	tests := []struct {
+		name string
+		body map[string]any
+	}{
+		{"start date without end date", map[string]any{"start_date": "2026-03-01"}},
+		{"invalid date", map[string]any{"start_date": "2026-03-01", "end_date": "31/03/2026"}},
+		{"end before start", map[string]any{"start_date": "2026-03-31", "end_date": "2026-03-01"}},
+		{"invalid tenant", map[string]any{"tenant_id": "not-a-uuid"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := triggerAggregation(router, tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestReportHandler_GetSchedulerRun_NotFound(t *testing.T) {
+	router, executor := setupReportSchedulerRouter(t, uuid.New())
+	defer close(executor.release)
+
+	code, _ := getAggregationRun(t, router, uuid.New().String())
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = getAggregationRun(t, router, "not-a-uuid")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -572,17 +572,18 @@ func getPredefinedPermissions() []string {
 	// Add special permissions
 	specialPerms := []string{
 		"product:enable", "product:disable",
-		"customer:enable", "customer:disable",
+		"customer:enable", "customer:disable", "customer:view_financials",
 		"supplier:enable", "supplier:disable",
 		"warehouse:enable", "warehouse:disable",
-		"sales_order:confirm", "sales_order:cancel", "sales_order:ship",
+		"sales_order:confirm", "sales_order:cancel", "sales_order:ship", "sales_order:approve_credit_override",
 		"purchase_order:confirm", "purchase_order:cancel", "purchase_order:receive",
 		"sales_return:approve", "sales_return:reject",
 		"inventory:adjust", "inventory:lock", "inventory:unlock",
 		"account_receivable:reconcile", "account_payable:reconcile",
 		"user:lock", "user:unlock", "user:assign_role",
 		"role:enable", "role:disable",
-		"report:export", "report:view_all",
+		"report:export", "report:view_all", "report:aggregate",
+		"tenant:export",
 	}
 	permissions = append(permissions, specialPerms...)
 
@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"io"
+	"net/http"
 	"time"
 
 	tradeapp "github.com/erp/backend/internal/application/trade"
@@ -34,6 +37,11 @@ type CreateSalesOrderRequest struct {
 	Items        []CreateSalesOrderItemInput `json:"items"`
 	Discount     *float64                    `json:"discount" example:"100.00"`
 	Remark       string                      `json:"remark" example:"备注信息"`
+	// Create a quote that does not affect inventory until it is converted into an order
+	IsQuote        bool       `json:"is_quote" example:"false"`
+	QuoteExpiresAt *time.Time `json:"quote_expires_at" example:"2026-12-31T23:59:59Z"`
+	// Allow shipping stock from batches that are expired or within the tenant's expiry buffer
+	AllowExpiredStock bool `json:"allow_expired_stock" example:"false"`
 }
 
 // CreateSalesOrderItemInput represents an item in the create order request
@@ -46,7 +54,10 @@ type CreateSalesOrderItemInput struct {
 	Unit        string  `json:"unit" binding:"required,min=1,max=20" example:"pcs"`
 	Quantity    float64 `json:"quantity" binding:"required,gt=0" example:"10"`
 	UnitPrice   float64 `json:"unit_price" binding:"required,gt=0" example:"99.99"`
-	Remark      string  `json:"remark" example:"商品备注"`
+	// Optional line discount, taken off the line before the order discount
+	DiscountType  string  `json:"discount_type" binding:"omitempty,oneof=percent amount" example:"percent"`
+	DiscountValue float64 `json:"discount_value" binding:"gte=0" example:"10"`
+	Remark        string  `json:"remark" example:"商品备注"`
 }
 
 // UpdateSalesOrderRequest represents a request to update a sales order
@@ -56,6 +67,10 @@ type UpdateSalesOrderRequest struct {
 	WarehouseID *string  `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
 	Discount    *float64 `json:"discount" example:"50.00"`
 	Remark      *string  `json:"remark" example:"更新备注"`
+	// Allow shipping stock from batches that are expired or within the tenant's expiry buffer
+	AllowExpiredStock *bool `json:"allow_expired_stock" example:"true"`
+	// Version the client loaded; the update is rejected with 409 and the current version if the order changed since
+	Version *int `json:"version" example:"3"`
 }
 
 // AddOrderItemRequest represents a request to add an item to an order
@@ -68,7 +83,10 @@ type AddOrderItemRequest struct {
 	Unit        string  `json:"unit" binding:"required,min=1,max=20" example:"pcs"`
 	Quantity    float64 `json:"quantity" binding:"required,gt=0" example:"5"`
 	UnitPrice   float64 `json:"unit_price" binding:"required,gt=0" example:"199.99"`
-	Remark      string  `json:"remark" example:"商品备注"`
+	// Optional line discount, taken off the line before the order discount
+	DiscountType  string  `json:"discount_type" binding:"omitempty,oneof=percent amount" example:"amount"`
+	DiscountValue float64 `json:"discount_value" binding:"gte=0" example:"20.00"`
+	Remark        string  `json:"remark" example:"商品备注"`
 }
 
 // UpdateOrderItemRequest represents a request to update an order item
@@ -77,7 +95,10 @@ type AddOrderItemRequest struct {
 type UpdateOrderItemRequest struct {
 	Quantity  *float64 `json:"quantity" example:"8"`
 	UnitPrice *float64 `json:"unit_price" example:"89.99"`
-	Remark    *string  `json:"remark" example:"更新商品备注"`
+	// Line discount; an empty discount type removes the discount
+	DiscountType  *string  `json:"discount_type" example:"percent"`
+	DiscountValue *float64 `json:"discount_value" binding:"omitempty,gte=0" example:"5"`
+	Remark        *string  `json:"remark" example:"更新商品备注"`
 }
 
 // ConfirmOrderRequest represents a request to confirm an order
@@ -87,11 +108,38 @@ type ConfirmOrderRequest struct {
 	WarehouseID *string `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
 }
 
+// ConvertQuoteRequest represents a request to convert a quote into an order
+//
+//	@Description	Request body for converting a quote into a draft order
+type ConvertQuoteRequest struct {
+	Reprice         bool   `json:"reprice" example:"true"`
+	PricingStrategy string `json:"pricing_strategy" example:"customer_level"`
+	CustomerLevel   string `json:"customer_level" example:"gold"`
+}
+
 // ShipOrderRequest represents a request to ship an order
 //
-//	@Description	Request body for shipping an order
+//	@Description	Request body for shipping an order. Omit items to ship everything not yet shipped.
 type ShipOrderRequest struct {
-	WarehouseID *string `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	WarehouseID     *string              `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Items           []ShipOrderItemInput `json:"items"`
+	ShippingCompany string               `json:"shipping_company" binding:"max=100" example:"SF Express"`
+	TrackingNumber  string               `json:"tracking_number" binding:"max=100" example:"SF1234567890"`
+}
+
+// ShipOrderItemInput represents the quantity of an order item in a partial shipment
+//
+//	@Description	Order item quantity to ship
+type ShipOrderItemInput struct {
+	ItemID   string  `json:"item_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440020"`
+	Quantity float64 `json:"quantity" binding:"required,gt=0" example:"5"`
+}
+
+// ApproveCreditOverrideRequest represents a request to approve shipping beyond the customer's credit limit
+//
+//	@Description	Request body for approving a credit limit override
+type ApproveCreditOverrideRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500" example:"长期合作客户，已获财务批准"`
 }
 
 // CancelOrderRequest represents a request to cancel an order
@@ -117,64 +165,85 @@ type SalesOrderResponse struct {
 	TotalAmount    float64                  `json:"total_amount" example:"2999.70"`
 	DiscountAmount float64                  `json:"discount_amount" example:"100.00"`
 	PayableAmount  float64                  `json:"payable_amount" example:"2899.70"`
-	Status         string                   `json:"status" example:"draft"`
-	Remark         string                   `json:"remark" example:"备注信息"`
-	ConfirmedAt    *time.Time               `json:"confirmed_at,omitempty"`
-	ShippedAt      *time.Time               `json:"shipped_at,omitempty"`
-	CompletedAt    *time.Time               `json:"completed_at,omitempty"`
-	CancelledAt    *time.Time               `json:"cancelled_at,omitempty"`
-	CancelReason   string                   `json:"cancel_reason,omitempty" example:""`
-	CreatedAt      time.Time                `json:"created_at"`
-	UpdatedAt      time.Time                `json:"updated_at"`
-	Version        int                      `json:"version" example:"1"`
+	// Sum of line discounts, already taken off total_amount
+	LineDiscountAmount float64    `json:"line_discount_amount" example:"50.00"`
+	Status             string     `json:"status" example:"draft"`
+	Remark             string     `json:"remark" example:"备注信息"`
+	ConfirmedAt        *time.Time `json:"confirmed_at,omitempty"`
+	FirstShippedAt     *time.Time `json:"first_shipped_at,omitempty"`
+	ShippedAt          *time.Time `json:"shipped_at,omitempty"`
+	FullyShippedAt     *time.Time `json:"fully_shipped_at,omitempty"`
+	ShipmentCount      int        `json:"shipment_count" example:"1"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CancelReason       string     `json:"cancel_reason,omitempty" example:""`
+	QuoteExpiresAt     *time.Time `json:"quote_expires_at,omitempty"`
+	ConvertedAt        *time.Time `json:"converted_at,omitempty"`
+	ExpiredAt          *time.Time `json:"expired_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	Version            int        `json:"version" example:"1"`
+
+	CreditOverrideApprovedBy *string    `json:"credit_override_approved_by,omitempty" example:"550e8400-e29b-41d4-a716-446655440003"`
+	CreditOverrideApprovedAt *time.Time `json:"credit_override_approved_at,omitempty"`
+	CreditOverrideReason     string     `json:"credit_override_reason,omitempty" example:"长期合作客户，已获财务批准"`
+
+	AllowExpiredStock bool `json:"allow_expired_stock" example:"false"`
 }
 
 // SalesOrderListResponse represents a sales order in list responses
 //
 //	@Description	Sales order list item response
 type SalesOrderListResponse struct {
-	ID            string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
-	OrderNumber   string     `json:"order_number" example:"SO-2026-00001"`
-	CustomerID    string     `json:"customer_id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	CustomerName  string     `json:"customer_name" example:"张三"`
-	WarehouseID   *string    `json:"warehouse_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
-	ItemCount     int        `json:"item_count" example:"3"`
-	TotalAmount   float64    `json:"total_amount" example:"2999.70"`
-	PayableAmount float64    `json:"payable_amount" example:"2899.70"`
-	Status        string     `json:"status" example:"draft"`
-	ConfirmedAt   *time.Time `json:"confirmed_at,omitempty"`
-	ShippedAt     *time.Time `json:"shipped_at,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID             string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	OrderNumber    string     `json:"order_number" example:"SO-2026-00001"`
+	CustomerID     string     `json:"customer_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	CustomerName   string     `json:"customer_name" example:"张三"`
+	WarehouseID    *string    `json:"warehouse_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	ItemCount      int        `json:"item_count" example:"3"`
+	TotalAmount    float64    `json:"total_amount" example:"2999.70"`
+	PayableAmount  float64    `json:"payable_amount" example:"2899.70"`
+	Status         string     `json:"status" example:"draft"`
+	ConfirmedAt    *time.Time `json:"confirmed_at,omitempty"`
+	ShippedAt      *time.Time `json:"shipped_at,omitempty"`
+	QuoteExpiresAt *time.Time `json:"quote_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
 // SalesOrderItemResponse represents an order item in API responses
 //
 //	@Description	Sales order item response
 type SalesOrderItemResponse struct {
-	ID          string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440020"`
-	ProductID   string    `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440002"`
-	ProductName string    `json:"product_name" example:"测试商品"`
-	ProductCode string    `json:"product_code" example:"SKU-001"`
-	Quantity    float64   `json:"quantity" example:"10"`
-	UnitPrice   float64   `json:"unit_price" example:"99.99"`
-	Amount      float64   `json:"amount" example:"999.90"`
-	Unit        string    `json:"unit" example:"pcs"`
-	Remark      string    `json:"remark,omitempty" example:"商品备注"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440020"`
+	ProductID         string    `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	ProductName       string    `json:"product_name" example:"测试商品"`
+	ProductCode       string    `json:"product_code" example:"SKU-001"`
+	Quantity          float64   `json:"quantity" example:"10"`
+	ShippedQuantity   float64   `json:"shipped_quantity" example:"5"`
+	RemainingQuantity float64   `json:"remaining_quantity" example:"5"`
+	UnitPrice         float64   `json:"unit_price" example:"99.99"`
+	DiscountType      string    `json:"discount_type,omitempty" example:"percent"`
+	DiscountValue     float64   `json:"discount_value" example:"10"`
+	DiscountAmount    float64   `json:"discount_amount" example:"99.99"`
+	Amount            float64   `json:"amount" example:"899.91"`
+	Unit              string    `json:"unit" example:"pcs"`
+	Remark            string    `json:"remark,omitempty" example:"商品备注"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // OrderStatusSummaryResponse represents order count summary by status
 //
 //	@Description	Order status summary response
 type OrderStatusSummaryResponse struct {
-	Draft     int64 `json:"draft" example:"5"`
-	Confirmed int64 `json:"confirmed" example:"10"`
-	Shipped   int64 `json:"shipped" example:"8"`
-	Completed int64 `json:"completed" example:"100"`
-	Cancelled int64 `json:"cancelled" example:"3"`
-	Total     int64 `json:"total" example:"126"`
+	Draft            int64 `json:"draft" example:"5"`
+	Confirmed        int64 `json:"confirmed" example:"10"`
+	PartiallyShipped int64 `json:"partially_shipped" example:"2"`
+	Shipped          int64 `json:"shipped" example:"8"`
+	Completed        int64 `json:"completed" example:"100"`
+	Cancelled        int64 `json:"cancelled" example:"3"`
+	Total            int64 `json:"total" example:"128"`
 }
 
 // Create godoc
@@ -217,9 +286,13 @@ func (h *SalesOrderHandler) Create(c *gin.Context) {
 	userID, _ := getUserID(c)
 
 	appReq := tradeapp.CreateSalesOrderRequest{
-		CustomerID:   customerID,
-		CustomerName: req.CustomerName,
-		Remark:       req.Remark,
+		CustomerID:     customerID,
+		CustomerName:   req.CustomerName,
+		Remark:         req.Remark,
+		IsQuote:        req.IsQuote,
+		QuoteExpiresAt: req.QuoteExpiresAt,
+
+		AllowExpiredStock: req.AllowExpiredStock,
 	}
 
 	// Set CreatedBy for data scope filtering
@@ -257,6 +330,8 @@ func (h *SalesOrderHandler) Create(c *gin.Context) {
 			Quantity:       decimal.NewFromFloat(item.Quantity),
 			ConversionRate: conversionRate,
 			UnitPrice:      decimal.NewFromFloat(item.UnitPrice),
+			DiscountType:   item.DiscountType,
+			DiscountValue:  decimal.NewFromFloat(item.DiscountValue),
 			Remark:         item.Remark,
 		})
 	}
@@ -413,7 +488,8 @@ func (h *SalesOrderHandler) List(c *gin.Context) {
 //
 //	@ID				updateSalesOrder
 //	@Summary		Update a sales order
-//	@Description	Update a sales order (only allowed in DRAFT status)
+//	@Description	Update a sales order (only allowed in DRAFT status).
+//	@Description	Send the version the order was loaded with; if it has changed since, 409 is returned with the current version.
 //	@Tags			sales-orders
 //	@Accept			json
 //	@Produce		json
@@ -424,6 +500,7 @@ func (h *SalesOrderHandler) List(c *gin.Context) {
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
 //	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
 //	@Failure		422			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
@@ -473,6 +550,8 @@ func (h *SalesOrderHandler) Update(c *gin.Context) {
 	}
 
 	appReq.Remark = req.Remark
+	appReq.AllowExpiredStock = req.AllowExpiredStock
+	appReq.Version = req.Version
 
 	order, err := h.orderService.Update(c.Request.Context(), tenantID, orderID, appReq)
 	if err != nil {
@@ -575,6 +654,8 @@ func (h *SalesOrderHandler) AddItem(c *gin.Context) {
 		Quantity:       decimal.NewFromFloat(req.Quantity),
 		ConversionRate: decimal.NewFromInt(1), // Default conversion_rate to 1
 		UnitPrice:      decimal.NewFromFloat(req.UnitPrice),
+		DiscountType:   req.DiscountType,
+		DiscountValue:  decimal.NewFromFloat(req.DiscountValue),
 		Remark:         req.Remark,
 	}
 
@@ -646,6 +727,12 @@ func (h *SalesOrderHandler) UpdateItem(c *gin.Context) {
 		appReq.UnitPrice = &d
 	}
 
+	appReq.DiscountType = req.DiscountType
+	if req.DiscountValue != nil {
+		d := decimal.NewFromFloat(*req.DiscountValue)
+		appReq.DiscountValue = &d
+	}
+
 	order, err := h.orderService.UpdateItem(c.Request.Context(), tenantID, orderID, itemID, appReq)
 	if err != nil {
 		h.HandleDomainError(c, err)
@@ -701,6 +788,55 @@ func (h *SalesOrderHandler) RemoveItem(c *gin.Context) {
 	h.Success(c, toSalesOrderResponse(order))
 }
 
+// ConvertQuote godoc
+//
+//	@ID				convertSalesQuote
+//	@Summary		Convert a quote into an order
+//	@Description	Convert a quote into a draft order (transitions from QUOTE to DRAFT), optionally re-pricing every item with the current pricing strategy. Expired quotes cannot be converted
+//	@Tags			sales-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string				false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string				true	"Sales Order ID"	format(uuid)
+//	@Param			request		body		ConvertQuoteRequest	false	"Convert quote request"
+//	@Success		200			{object}	APIResponse[SalesOrderResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/trade/sales-orders/{id}/convert-quote [post]
+func (h *SalesOrderHandler) ConvertQuote(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid order ID format")
+		return
+	}
+
+	var req ConvertQuoteRequest
+	// Allow empty body
+	_ = c.ShouldBindJSON(&req)
+
+	order, err := h.orderService.ConvertQuote(c.Request.Context(), tenantID, orderID, tradeapp.ConvertQuoteRequest{
+		Reprice:             req.Reprice,
+		PricingStrategyName: req.PricingStrategy,
+		CustomerLevel:       req.CustomerLevel,
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toSalesOrderResponse(order))
+}
+
 // Confirm godoc
 //
 //	@ID				confirmSalesOrder
@@ -761,7 +897,7 @@ func (h *SalesOrderHandler) Confirm(c *gin.Context) {
 //
 //	@ID				shipSalesOrder
 //	@Summary		Ship a sales order
-//	@Description	Mark a sales order as shipped (transitions from CONFIRMED to SHIPPED)
+//	@Description	Ship all or part of a sales order. Shipping less than ordered moves the order to PARTIALLY_SHIPPED; shipping the remainder moves it to SHIPPED. Shipping stock from batches that expire before the ship date plus the tenant expiry buffer is rejected unless the order allows expired stock. The shipping company and tracking number are passed on to the e-commerce platform for orders imported from one
 //	@Tags			sales-orders
 //	@Accept			json
 //	@Produce		json
@@ -791,9 +927,26 @@ func (h *SalesOrderHandler) Ship(c *gin.Context) {
 
 	var req ShipOrderRequest
 	// Allow empty body
-	_ = c.ShouldBindJSON(&req)
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.BadRequest(c, err.Error())
+		return
+	}
 
-	appReq := tradeapp.ShipOrderRequest{}
+	appReq := tradeapp.ShipOrderRequest{
+		ShippingCompany: req.ShippingCompany,
+		TrackingNumber:  req.TrackingNumber,
+	}
+	for _, item := range req.Items {
+		itemID, err := uuid.Parse(item.ItemID)
+		if err != nil {
+			h.BadRequest(c, "Invalid item ID format")
+			return
+		}
+		appReq.Items = append(appReq.Items, tradeapp.ShipOrderItemInput{
+			ItemID:   itemID,
+			Quantity: decimal.NewFromFloat(item.Quantity),
+		})
+	}
 
 	if req.WarehouseID != nil && *req.WarehouseID != "" {
 		warehouseID, err := uuid.Parse(*req.WarehouseID)
@@ -805,6 +958,83 @@ func (h *SalesOrderHandler) Ship(c *gin.Context) {
 	}
 
 	order, err := h.orderService.Ship(c.Request.Context(), tenantID, orderID, appReq)
+	if err != nil {
+		var creditErr *trade.CreditLimitExceededError
+		if errors.As(err, &creditErr) {
+			h.creditLimitExceeded(c, creditErr)
+			return
+		}
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, toSalesOrderResponse(order))
+}
+
+// creditLimitExceeded responds with the amounts that caused a shipment to be blocked
+func (h *SalesOrderHandler) creditLimitExceeded(c *gin.Context, creditErr *trade.CreditLimitExceededError) {
+	details := []dto.ValidationDetail{
+		{Field: "current_outstanding", Message: creditErr.CurrentOutstanding.StringFixed(2)},
+		{Field: "credit_limit", Message: creditErr.CreditLimit.StringFixed(2)},
+		{Field: "order_amount", Message: creditErr.OrderAmount.StringFixed(2)},
+		{Field: "overage", Message: creditErr.Overage.StringFixed(2)},
+	}
+	c.JSON(http.StatusUnprocessableEntity, dto.NewErrorResponseWithDetails(
+		trade.ErrCreditLimitExceeded.Code, creditErr.Error(), getRequestID(c), details))
+}
+
+// ApproveCreditOverride godoc
+//
+//	@ID				approveSalesOrderCreditOverride
+//	@Summary		Approve a credit limit override
+//	@Description	Allow a sales order to ship even if it takes the customer over their credit limit.
+//	@Description	Requires sales_order:approve_credit_override permission.
+//	@Tags			sales-orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string							false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string							true	"Sales Order ID"	format(uuid)
+//	@Param			request		body		ApproveCreditOverrideRequest	true	"Credit override request"
+//	@Success		200			{object}	APIResponse[SalesOrderResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/trade/sales-orders/{id}/credit-override [post]
+func (h *SalesOrderHandler) ApproveCreditOverride(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	userID, err := getUserID(c)
+	if err != nil {
+		h.Unauthorized(c, "Authentication required for this operation")
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid order ID format")
+		return
+	}
+
+	var req ApproveCreditOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	appReq := tradeapp.ApproveCreditOverrideRequest{
+		ApprovedBy: userID,
+		Reason:     req.Reason,
+	}
+
+	order, err := h.orderService.ApproveCreditOverride(c.Request.Context(), tenantID, orderID, appReq)
 	if err != nil {
 		h.HandleDomainError(c, err)
 		return
@@ -931,12 +1161,13 @@ func (h *SalesOrderHandler) GetStatusSummary(c *gin.Context) {
 	}
 
 	h.Success(c, OrderStatusSummaryResponse{
-		Draft:     summary.Draft,
-		Confirmed: summary.Confirmed,
-		Shipped:   summary.Shipped,
-		Completed: summary.Completed,
-		Cancelled: summary.Cancelled,
-		Total:     summary.Total,
+		Draft:            summary.Draft,
+		Confirmed:        summary.Confirmed,
+		PartiallyShipped: summary.PartiallyShipped,
+		Shipped:          summary.Shipped,
+		Completed:        summary.Completed,
+		Cancelled:        summary.Cancelled,
+		Total:            summary.Total,
 	})
 }
 
@@ -945,48 +1176,67 @@ func toSalesOrderResponse(order *tradeapp.SalesOrderResponse) SalesOrderResponse
 	items := make([]SalesOrderItemResponse, len(order.Items))
 	for i, item := range order.Items {
 		items[i] = SalesOrderItemResponse{
-			ID:          item.ID.String(),
-			ProductID:   item.ProductID.String(),
-			ProductName: item.ProductName,
-			ProductCode: item.ProductCode,
-			Quantity:    item.Quantity.InexactFloat64(),
-			UnitPrice:   item.UnitPrice.InexactFloat64(),
-			Amount:      item.Amount.InexactFloat64(),
-			Unit:        item.Unit,
-			Remark:      item.Remark,
-			CreatedAt:   item.CreatedAt,
-			UpdatedAt:   item.UpdatedAt,
+			ID:                item.ID.String(),
+			ProductID:         item.ProductID.String(),
+			ProductName:       item.ProductName,
+			ProductCode:       item.ProductCode,
+			Quantity:          item.Quantity.InexactFloat64(),
+			ShippedQuantity:   item.ShippedQuantity.InexactFloat64(),
+			RemainingQuantity: item.RemainingQuantity.InexactFloat64(),
+			UnitPrice:         item.UnitPrice.InexactFloat64(),
+			DiscountType:      item.DiscountType,
+			DiscountValue:     item.DiscountValue.InexactFloat64(),
+			DiscountAmount:    item.DiscountAmount.InexactFloat64(),
+			Amount:            item.Amount.InexactFloat64(),
+			Unit:              item.Unit,
+			Remark:            item.Remark,
+			CreatedAt:         item.CreatedAt,
+			UpdatedAt:         item.UpdatedAt,
 		}
 	}
 
 	resp := SalesOrderResponse{
-		ID:             order.ID.String(),
-		TenantID:       order.TenantID.String(),
-		OrderNumber:    order.OrderNumber,
-		CustomerID:     order.CustomerID.String(),
-		CustomerName:   order.CustomerName,
-		Items:          items,
-		ItemCount:      order.ItemCount,
-		TotalQuantity:  order.TotalQuantity.InexactFloat64(),
-		TotalAmount:    order.TotalAmount.InexactFloat64(),
-		DiscountAmount: order.DiscountAmount.InexactFloat64(),
-		PayableAmount:  order.PayableAmount.InexactFloat64(),
-		Status:         order.Status,
-		Remark:         order.Remark,
-		ConfirmedAt:    order.ConfirmedAt,
-		ShippedAt:      order.ShippedAt,
-		CompletedAt:    order.CompletedAt,
-		CancelledAt:    order.CancelledAt,
-		CancelReason:   order.CancelReason,
-		CreatedAt:      order.CreatedAt,
-		UpdatedAt:      order.UpdatedAt,
-		Version:        order.Version,
+		ID:                 order.ID.String(),
+		TenantID:           order.TenantID.String(),
+		OrderNumber:        order.OrderNumber,
+		CustomerID:         order.CustomerID.String(),
+		CustomerName:       order.CustomerName,
+		Items:              items,
+		ItemCount:          order.ItemCount,
+		TotalQuantity:      order.TotalQuantity.InexactFloat64(),
+		TotalAmount:        order.TotalAmount.InexactFloat64(),
+		DiscountAmount:     order.DiscountAmount.InexactFloat64(),
+		PayableAmount:      order.PayableAmount.InexactFloat64(),
+		LineDiscountAmount: order.LineDiscountAmount.InexactFloat64(),
+		Status:             order.Status,
+		Remark:             order.Remark,
+		ConfirmedAt:        order.ConfirmedAt,
+		FirstShippedAt:     order.FirstShippedAt,
+		ShippedAt:          order.ShippedAt,
+		FullyShippedAt:     order.FullyShippedAt,
+		ShipmentCount:      order.ShipmentCount,
+		CompletedAt:        order.CompletedAt,
+		CancelledAt:        order.CancelledAt,
+		CancelReason:       order.CancelReason,
+		QuoteExpiresAt:     order.QuoteExpiresAt,
+		ConvertedAt:        order.ConvertedAt,
+		ExpiredAt:          order.ExpiredAt,
+		CreatedAt:          order.CreatedAt,
+		UpdatedAt:          order.UpdatedAt,
+		Version:            order.Version,
 	}
 
 	if order.WarehouseID != nil {
 		warehouseID := order.WarehouseID.String()
 		resp.WarehouseID = &warehouseID
 	}
+	if order.CreditOverrideApprovedBy != nil {
+		approvedBy := order.CreditOverrideApprovedBy.String()
+		resp.CreditOverrideApprovedBy = &approvedBy
+		resp.CreditOverrideApprovedAt = order.CreditOverrideApprovedAt
+		resp.CreditOverrideReason = order.CreditOverrideReason
+	}
+	resp.AllowExpiredStock = order.AllowExpiredStock
 
 	return resp
 }
@@ -996,18 +1246,19 @@ func toSalesOrderListResponses(orders []tradeapp.SalesOrderListItemResponse) []S
 	responses := make([]SalesOrderListResponse, len(orders))
 	for i, order := range orders {
 		resp := SalesOrderListResponse{
-			ID:            order.ID.String(),
-			OrderNumber:   order.OrderNumber,
-			CustomerID:    order.CustomerID.String(),
-			CustomerName:  order.CustomerName,
-			ItemCount:     order.ItemCount,
-			TotalAmount:   order.TotalAmount.InexactFloat64(),
-			PayableAmount: order.PayableAmount.InexactFloat64(),
-			Status:        order.Status,
-			ConfirmedAt:   order.ConfirmedAt,
-			ShippedAt:     order.ShippedAt,
-			CreatedAt:     order.CreatedAt,
-			UpdatedAt:     order.UpdatedAt,
+			ID:             order.ID.String(),
+			OrderNumber:    order.OrderNumber,
+			CustomerID:     order.CustomerID.String(),
+			CustomerName:   order.CustomerName,
+			ItemCount:      order.ItemCount,
+			TotalAmount:    order.TotalAmount.InexactFloat64(),
+			PayableAmount:  order.PayableAmount.InexactFloat64(),
+			Status:         order.Status,
+			ConfirmedAt:    order.ConfirmedAt,
+			ShippedAt:      order.ShippedAt,
+			QuoteExpiresAt: order.QuoteExpiresAt,
+			CreatedAt:      order.CreatedAt,
+			UpdatedAt:      order.UpdatedAt,
 		}
 
 		if order.WarehouseID != nil {
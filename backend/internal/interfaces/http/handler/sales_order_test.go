@@ -141,6 +141,14 @@ func (m *MockSalesOrderRepository) ExistsByProduct(ctx context.Context, tenantID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockSalesOrderRepository) FindExpiredQuotes(ctx context.Context, expiresBefore time.Time) ([]trade.SalesOrder, error) {
+	args := m.Called(ctx, expiresBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]trade.SalesOrder), args.Error(1)
+}
+
 // Ensure mock implements the interface
 var _ trade.SalesOrderRepository = (*MockSalesOrderRepository)(nil)
 
@@ -561,6 +569,7 @@ func TestSalesOrderHandler_GetStatusSummary(t *testing.T) {
 
 		mockRepo.On("CountByStatus", mock.Anything, tenantID, trade.OrderStatusDraft).Return(int64(5), nil)
 		mockRepo.On("CountByStatus", mock.Anything, tenantID, trade.OrderStatusConfirmed).Return(int64(10), nil)
+		mockRepo.On("CountByStatus", mock.Anything, tenantID, trade.OrderStatusPartiallyShipped).Return(int64(2), nil)
 		mockRepo.On("CountByStatus", mock.Anything, tenantID, trade.OrderStatusShipped).Return(int64(8), nil)
 		mockRepo.On("CountByStatus", mock.Anything, tenantID, trade.OrderStatusCompleted).Return(int64(100), nil)
 		mockRepo.On("CountByStatus", mock.Anything, tenantID, trade.OrderStatusCancelled).Return(int64(3), nil)
@@ -581,10 +590,11 @@ func TestSalesOrderHandler_GetStatusSummary(t *testing.T) {
 		data := response["data"].(map[string]interface{})
 		assert.Equal(t, float64(5), data["draft"])
 		assert.Equal(t, float64(10), data["confirmed"])
+		assert.Equal(t, float64(2), data["partially_shipped"])
 		assert.Equal(t, float64(8), data["shipped"])
 		assert.Equal(t, float64(100), data["completed"])
 		assert.Equal(t, float64(3), data["cancelled"])
-		assert.Equal(t, float64(126), data["total"])
+		assert.Equal(t, float64(128), data["total"])
 
 		mockRepo.AssertExpectations(t)
 	})
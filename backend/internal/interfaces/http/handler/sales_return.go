@@ -31,6 +31,7 @@ type CreateSalesReturnRequest struct {
 	SalesOrderID string                       `json:"sales_order_id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 	WarehouseID  *string                      `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
 	Items        []CreateSalesReturnItemInput `json:"items" binding:"required,min=1"`
+	ReasonCode   string                       `json:"reason_code" binding:"required,oneof=damaged defective wrong_item quality not_as_described expired customer_remorse other" example:"wrong_item"`
 	Reason       string                       `json:"reason" example:"商品质量问题"`
 	Remark       string                       `json:"remark" example:"备注信息"`
 }
@@ -50,6 +51,7 @@ type CreateSalesReturnItemInput struct {
 //	@Description	Request body for updating a sales return (draft only)
 type UpdateSalesReturnRequest struct {
 	WarehouseID *string `json:"warehouse_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	ReasonCode  *string `json:"reason_code" example:"quality"`
 	Reason      *string `json:"reason" example:"更新退货原因"`
 	Remark      *string `json:"remark" example:"更新备注"`
 }
@@ -125,6 +127,7 @@ type SalesReturnResponse struct {
 	TotalQuantity    float64                   `json:"total_quantity" example:"8"`
 	TotalRefund      float64                   `json:"total_refund" example:"799.92"`
 	Status           string                    `json:"status" example:"draft"`
+	ReasonCode       string                    `json:"reason_code" example:"wrong_item"`
 	Reason           string                    `json:"reason,omitempty" example:"商品质量问题"`
 	Remark           string                    `json:"remark,omitempty" example:"备注信息"`
 	SubmittedAt      *time.Time                `json:"submitted_at,omitempty"`
@@ -157,6 +160,7 @@ type SalesReturnListResponse struct {
 	ItemCount        int        `json:"item_count" example:"2"`
 	TotalRefund      float64    `json:"total_refund" example:"799.92"`
 	Status           string     `json:"status" example:"pending"`
+	ReasonCode       string     `json:"reason_code" example:"wrong_item"`
 	SubmittedAt      *time.Time `json:"submitted_at,omitempty"`
 	ApprovedAt       *time.Time `json:"approved_at,omitempty"`
 	ReceivedAt       *time.Time `json:"received_at,omitempty"`
@@ -243,6 +247,7 @@ func (h *SalesReturnHandler) Create(c *gin.Context) {
 
 	appReq := tradeapp.CreateSalesReturnRequest{
 		SalesOrderID: salesOrderID,
+		ReasonCode:   req.ReasonCode,
 		Reason:       req.Reason,
 		Remark:       req.Remark,
 	}
@@ -460,8 +465,9 @@ func (h *SalesReturnHandler) Update(c *gin.Context) {
 
 	// Convert to application DTO
 	appReq := tradeapp.UpdateSalesReturnRequest{
-		Reason: req.Reason,
-		Remark: req.Remark,
+		ReasonCode: req.ReasonCode,
+		Reason:     req.Reason,
+		Remark:     req.Remark,
 	}
 
 	// Convert warehouse ID
@@ -1088,6 +1094,7 @@ func toSalesReturnResponse(sr *tradeapp.SalesReturnResponse) SalesReturnResponse
 		TotalQuantity:    sr.TotalQuantity.InexactFloat64(),
 		TotalRefund:      sr.TotalRefund.InexactFloat64(),
 		Status:           sr.Status,
+		ReasonCode:       sr.ReasonCode,
 		Reason:           sr.Reason,
 		Remark:           sr.Remark,
 		SubmittedAt:      sr.SubmittedAt,
@@ -1136,6 +1143,7 @@ func toSalesReturnListResponses(returns []tradeapp.SalesReturnListItemResponse)
 			ItemCount:        sr.ItemCount,
 			TotalRefund:      sr.TotalRefund.InexactFloat64(),
 			Status:           sr.Status,
+			ReasonCode:       sr.ReasonCode,
 			SubmittedAt:      sr.SubmittedAt,
 			ApprovedAt:       sr.ApprovedAt,
 			ReceivedAt:       sr.ReceivedAt,
@@ -262,7 +262,8 @@ func TestSalesReturnHandler_Create(t *testing.T) {
 					ConditionOnReturn: "damaged",
 				},
 			},
-			Reason: "商品质量问题",
+			ReasonCode: "quality",
+			Reason:     "商品质量问题",
 		}
 		body, _ := json.Marshal(reqBody)
 
@@ -39,17 +39,16 @@ type StripeWebhookResponse struct {
 //
 //	@ID				handleStripeWebhook
 //	@Summary		Handle Stripe webhook
-//	@Description	Receive and process webhook events from Stripe for subscription management
+//	@Description	Receive and process webhook events from Stripe for subscription management. The payload is verified against the Stripe-Signature header; requests with a missing or invalid signature are rejected without side effects. Also served at /webhooks/stripe for existing Stripe endpoint configurations.
 //	@Tags			webhooks
 //	@Accept			json
 //	@Produce		json
 //	@Param			Stripe-Signature	header		string					true	"Stripe webhook signature"
 //	@Success		200					{object}	StripeWebhookResponse	"Webhook processed successfully"
-//	@Failure		400					{object}	StripeWebhookResponse	"Invalid request"
-//	@Failure		401					{object}	StripeWebhookResponse	"Invalid signature"
+//	@Failure		400					{object}	StripeWebhookResponse	"Invalid request or signature"
 //	@Failure		413					{object}	StripeWebhookResponse	"Payload too large"
 //	@Failure		500					{object}	StripeWebhookResponse	"Internal server error"
-//	@Router			/webhooks/stripe [post]
+//	@Router			/billing/stripe/webhook [post]
 func (h *StripeWebhookHandler) HandleStripeWebhook(c *gin.Context) {
 	// Read the raw request body with size limit to prevent DoS attacks
 	// Stripe requires the raw body for signature verification
@@ -74,7 +73,7 @@ func (h *StripeWebhookHandler) HandleStripeWebhook(c *gin.Context) {
 	// Get signature from header
 	signature := c.GetHeader("Stripe-Signature")
 	if signature == "" {
-		c.JSON(http.StatusUnauthorized, StripeWebhookResponse{
+		c.JSON(http.StatusBadRequest, StripeWebhookResponse{
 			Received: false,
 			Message:  "Missing Stripe-Signature header",
 		})
@@ -86,7 +85,7 @@ func (h *StripeWebhookHandler) HandleStripeWebhook(c *gin.Context) {
 	if err != nil {
 		// Check if it's a signature verification error
 		if result == nil {
-			c.JSON(http.StatusUnauthorized, StripeWebhookResponse{
+			c.JSON(http.StatusBadRequest, StripeWebhookResponse{
 				Received: false,
 				Message:  "Webhook signature verification failed",
 			})
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	billingapp "github.com/erp/backend/internal/application/billing"
+	identityapp "github.com/erp/backend/internal/application/identity"
+	"github.com/erp/backend/internal/domain/identity"
+	"github.com/erp/backend/internal/infrastructure/billing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/webhook"
+	"go.uber.org/zap"
+)
+
+const testStripeWebhookSecret = "whsec_test_secret"
+
+// stripeWebhookTenantRepository serves a single subscribed tenant and counts writes
+type stripeWebhookTenantRepository struct {
+	mockTenantRepository
+	lookups int
+	saves   int
+}
+
+func (r *stripeWebhookTenantRepository) FindByID(ctx context.Context, id uuid.UUID) (*identity.Tenant, error) {
+	r.lookups++
+	return r.tenant, nil
+}
+
+func (r *stripeWebhookTenantRepository) FindByStripeSubscriptionID(ctx context.Context, subscriptionID string) (*identity.Tenant, error) {
+	r.lookups++
+	return r.tenant, nil
+}
+
+func (r *stripeWebhookTenantRepository) FindByStripeCustomerID(ctx context.Context, customerID string) (*identity.Tenant, error) {
+	r.lookups++
+	return r.tenant, nil
+}
+
+func (r *stripeWebhookTenantRepository) Save(ctx context.Context, tenant *identity.Tenant) error {
+	r.saves++
+	return nil
+}
+
+func setupStripeWebhookTest(t *testing.T) (*gin.Engine, *stripeWebhookTenantRepository) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	tenant, err := identity.NewTenant("STRIPE01", "Stripe Tenant")
+	require.NoError(t, err)
+	require.NoError(t, tenant.SetPlan(identity.TenantPlanPro))
+	tenant.SetStripeCustomerID("cus_test123")
+	tenant.SetStripeSubscriptionID("sub_test123")
+
+	repo := &stripeWebhookTenantRepository{}
+	repo.tenant = tenant
+
+	logger := zap.NewNop()
+	service := billingapp.NewStripeWebhookService(billingapp.StripeWebhookServiceConfig{
+		Config:        &billing.StripeConfig{WebhookSecret: testStripeWebhookSecret},
+		TenantRepo:    repo,
+		TenantService: identityapp.NewTenantService(repo, logger),
+		Logger:        logger,
+	})
+	h := NewStripeWebhookHandler(service)
+
+	router := gin.New()
+	router.POST("/api/v1/billing/stripe/webhook", h.HandleStripeWebhook)
+	return router, repo
+}
+
+func newStripeSubscriptionDeletedPayload(t *testing.T) []byte {
+	t.Helper()
+	payload, err := json.Marshal(map[string]any{
+		"id":          "evt_test123",
+		"object":      "event",
+		"type":        "customer.subscription.deleted",
+		"api_version": stripe.APIVersion,
+		"data": map[string]any{
+			"object": map[string]any{
+				"id":       "sub_test123",
+				"object":   "subscription",
+				"customer": "cus_test123",
+				"status":   "canceled",
+			},
+		},
+	})
+	require.NoError(t, err)
+	return payload
+}
+
+func postStripeWebhook(router *gin.Engine, payload []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/billing/stripe/webhook", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set("Stripe-Signature", signature)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestStripeWebhookHandler_ValidSignature(t *testing.T) {
+	router, repo := setupStripeWebhookTest(t)
+
+	payload := newStripeSubscriptionDeletedPayload(t)
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload: payload,
+		Secret:  testStripeWebhookSecret,
+	})
+
+	w := postStripeWebhook(router, signed.Payload, signed.Header)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp StripeWebhookResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Received)
+	assert.Equal(t, "evt_test123", resp.EventID)
+	assert.Equal(t, "customer.subscription.deleted", resp.EventType)
+
+	// Subscription canceled on Stripe's side: tenant is downgraded to the free plan
+	assert.Equal(t, identity.TenantPlanFree, repo.tenant.Plan)
+	assert.Empty(t, repo.tenant.StripeSubscriptionID)
+	assert.Positive(t, repo.saves)
+}
+
+func TestStripeWebhookHandler_TamperedPayload(t *testing.T) {
+	router, repo := setupStripeWebhookTest(t)
+
+	payload := newStripeSubscriptionDeletedPayload(t)
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload: payload,
+		Secret:  testStripeWebhookSecret,
+	})
+	tampered := bytes.Replace(signed.Payload, []byte("sub_test123"), []byte("sub_other99"), 1)
+
+	w := postStripeWebhook(router, tampered, signed.Header)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp StripeWebhookResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Received)
+
+	// No side effects
+	assert.Zero(t, repo.lookups)
+	assert.Zero(t, repo.saves)
+	assert.Equal(t, identity.TenantPlanPro, repo.tenant.Plan)
+	assert.Equal(t, "sub_test123", repo.tenant.StripeSubscriptionID)
+}
+
+func TestStripeWebhookHandler_MissingSignature(t *testing.T) {
+	router, repo := setupStripeWebhookTest(t)
+
+	w := postStripeWebhook(router, newStripeSubscriptionDeletedPayload(t), "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Zero(t, repo.lookups)
+	assert.Zero(t, repo.saves)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	partnerapp "github.com/erp/backend/internal/application/partner"
+	"github.com/erp/backend/internal/interfaces/http/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -269,9 +270,11 @@ func (h *SupplierHandler) GetByCode(c *gin.Context) {
 //	@Param			page_size	query		int		false	"Page size"			default(20)	maximum(100)
 //	@Param			order_by	query		string	false	"Order by field"	default(sort_order)
 //	@Param			order_dir	query		string	false	"Order direction"	Enums(asc, desc)	default(asc)
+//	@Param			include_deleted	query		boolean	false	"Include soft-deleted suppliers (requires supplier:restore)"
 //	@Success		200			{object}	APIResponse[[]SupplierListResponse]
 //	@Failure		400			{object}	dto.ErrorResponse
 //	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
 //	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/partner/suppliers [get]
@@ -288,6 +291,12 @@ func (h *SupplierHandler) List(c *gin.Context) {
 		return
 	}
 
+	// Soft-deleted suppliers are only visible to admins
+	if filter.IncludeDeleted && !middleware.HasPermission(c, "supplier:restore") {
+		h.Forbidden(c, "Listing deleted suppliers requires admin permission (supplier:restore)")
+		return
+	}
+
 	// Set defaults
 	if filter.Page <= 0 {
 		filter.Page = 1
@@ -430,7 +439,7 @@ func (h *SupplierHandler) UpdateCode(c *gin.Context) {
 //
 //	@ID				deleteSupplier
 //	@Summary		Delete a supplier
-//	@Description	Delete a supplier by ID
+//	@Description	Soft delete a supplier by ID. Admins can restore it with POST /partner/suppliers/{id}/restore
 //	@Tags			suppliers
 //	@Produce		json
 //	@Param			X-Tenant-ID	header	string	false	"Tenant ID (optional for dev)"
@@ -465,6 +474,47 @@ func (h *SupplierHandler) Delete(c *gin.Context) {
 	h.NoContent(c)
 }
 
+// Restore godoc
+//
+//	@ID				restoreSupplier
+//	@Summary		Restore a supplier
+//	@Description	Restore a soft-deleted supplier. Fails with 409 if another supplier has taken its code since it was deleted.
+//	@Description	Requires supplier:restore permission.
+//	@Tags			suppliers
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Supplier ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[SupplierResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/suppliers/{id}/restore [post]
+func (h *SupplierHandler) Restore(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid supplier ID format")
+		return
+	}
+
+	supplier, err := h.supplierService.Restore(c.Request.Context(), tenantID, supplierID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, supplier)
+}
+
 // Activate godoc
 //
 //	@ID				activateSupplier
@@ -2,9 +2,11 @@ package handler
 
 import (
 	"github.com/erp/backend/internal/application/identity"
+	domainIdentity "github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/interfaces/http/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // TenantHandler handles tenant management HTTP requests
@@ -268,6 +270,33 @@ func (h *TenantHandler) UpdateConfig(c *gin.Context) {
 		Currency:      req.Currency,
 		Timezone:      req.Timezone,
 		Locale:        req.Locale,
+
+		CreditControlEnabled: req.CreditControlEnabled,
+		IndustryPlugin:       req.IndustryPlugin,
+		ShipExpiryBufferDays: req.ShipExpiryBufferDays,
+	}
+	if req.ExpenseApprovalThresholds != nil {
+		thresholds := make([]decimal.Decimal, len(*req.ExpenseApprovalThresholds))
+		for i, threshold := range *req.ExpenseApprovalThresholds {
+			thresholds[i] = decimal.NewFromFloat(threshold)
+			if i > 0 && !thresholds[i].GreaterThan(thresholds[i-1]) {
+				h.BadRequest(c, "Expense approval thresholds must be in ascending order")
+				return
+			}
+		}
+		input.ExpenseApprovalThresholds = &thresholds
+	}
+	if req.PasswordPolicy != nil {
+		input.PasswordPolicy = &domainIdentity.PasswordPolicy{
+			MinLength:        req.PasswordPolicy.MinLength,
+			RequireLetter:    req.PasswordPolicy.RequireLetter,
+			RequireUppercase: req.PasswordPolicy.RequireUppercase,
+			RequireLowercase: req.PasswordPolicy.RequireLowercase,
+			RequireDigit:     req.PasswordPolicy.RequireDigit,
+			RequireSpecial:   req.PasswordPolicy.RequireSpecial,
+			HistoryCount:     req.PasswordPolicy.HistoryCount,
+			MaxAgeDays:       req.PasswordPolicy.MaxAgeDays,
+		}
 	}
 
 	tenant, err := h.tenantService.UpdateConfig(c.Request.Context(), id, input)
@@ -525,6 +554,12 @@ func toTenantResponse(tenant *identity.TenantDTO) *TenantResponse {
 			Currency:      tenant.Config.Currency,
 			Timezone:      tenant.Config.Timezone,
 			Locale:        tenant.Config.Locale,
+
+			CreditControlEnabled:      tenant.Config.CreditControlEnabled,
+			ExpenseApprovalThresholds: toExpenseApprovalThresholds(tenant.Config.ExpenseApprovalThresholds),
+			IndustryPlugin:            tenant.Config.IndustryPlugin,
+			ShipExpiryBufferDays:      tenant.Config.ShipExpiryBufferDays,
+			PasswordPolicy:            PasswordPolicyResponse(tenant.Config.PasswordPolicy),
 		},
 		Notes:     tenant.Notes,
 		CreatedAt: tenant.CreatedAt,
@@ -546,3 +581,11 @@ func toTenantListResponse(result *identity.TenantListResult) *TenantListResponse
 		TotalPages: result.TotalPages,
 	}
 }
+
+func toExpenseApprovalThresholds(thresholds []decimal.Decimal) []float64 {
+	result := make([]float64, len(thresholds))
+	for i, threshold := range thresholds {
+		result[i] = threshold.InexactFloat64()
+	}
+	return result
+}
@@ -45,10 +45,34 @@ type UpdateTenantConfigRequest struct {
 	MaxUsers      *int    `json:"max_users" binding:"omitempty,min=0"`
 	MaxWarehouses *int    `json:"max_warehouses" binding:"omitempty,min=0"`
 	MaxProducts   *int    `json:"max_products" binding:"omitempty,min=0"`
-	CostStrategy  *string `json:"cost_strategy" binding:"omitempty,oneof=fifo weighted_average"`
+	CostStrategy  *string `json:"cost_strategy" binding:"omitempty,oneof=fifo weighted_average moving_weighted_average"`
 	Currency      *string `json:"currency" binding:"omitempty,len=3"`
 	Timezone      *string `json:"timezone" binding:"omitempty,max=50"`
 	Locale        *string `json:"locale" binding:"omitempty,max=10"`
+
+	CreditControlEnabled *bool `json:"credit_control_enabled"`
+	// Ascending expense amounts; each one exceeded requires an additional approver. An empty list removes all thresholds.
+	ExpenseApprovalThresholds *[]float64 `json:"expense_approval_thresholds" binding:"omitempty,max=10,dive,gt=0" example:"5000,50000"`
+	// Industry plugin whose product attribute requirements apply to the tenant. An empty string disables them.
+	IndustryPlugin *string `json:"industry_plugin" binding:"omitempty,max=50" example:"agricultural"`
+	// Batches expiring within this many days of the ship date cannot be shipped. 0 blocks only expired batches.
+	ShipExpiryBufferDays *int `json:"ship_expiry_buffer_days" binding:"omitempty,min=0,max=3650" example:"7"`
+	// Password policy enforced on password changes and resets. Replaces the whole current policy.
+	PasswordPolicy *PasswordPolicyRequest `json:"password_policy"`
+}
+
+// PasswordPolicyRequest represents a tenant password policy in requests
+type PasswordPolicyRequest struct {
+	MinLength        int  `json:"min_length" binding:"required,min=6,max=128" example:"10"`
+	RequireLetter    bool `json:"require_letter" example:"true"`
+	RequireUppercase bool `json:"require_uppercase" example:"true"`
+	RequireLowercase bool `json:"require_lowercase" example:"true"`
+	RequireDigit     bool `json:"require_digit" example:"true"`
+	RequireSpecial   bool `json:"require_special" example:"false"`
+	// The new password cannot be the current one or one of the previous HistoryCount-1. 0 allows reuse.
+	HistoryCount int `json:"history_count" binding:"min=0,max=12" example:"5"`
+	// Days after which a password expires and must be changed at login. 0 never expires.
+	MaxAgeDays int `json:"max_age_days" binding:"min=0,max=3650" example:"90"`
 }
 
 // SetTenantPlanRequest represents the request body for setting tenant plan
@@ -102,6 +126,24 @@ type TenantConfigResponse struct {
 	Currency      string `json:"currency"`
 	Timezone      string `json:"timezone"`
 	Locale        string `json:"locale"`
+
+	CreditControlEnabled      bool                   `json:"credit_control_enabled"`
+	ExpenseApprovalThresholds []float64              `json:"expense_approval_thresholds"`
+	IndustryPlugin            string                 `json:"industry_plugin"`
+	ShipExpiryBufferDays      int                    `json:"ship_expiry_buffer_days"`
+	PasswordPolicy            PasswordPolicyResponse `json:"password_policy"`
+}
+
+// PasswordPolicyResponse represents a tenant password policy in API responses
+type PasswordPolicyResponse struct {
+	MinLength        int  `json:"min_length"`
+	RequireLetter    bool `json:"require_letter"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSpecial   bool `json:"require_special"`
+	HistoryCount     int  `json:"history_count"`
+	MaxAgeDays       int  `json:"max_age_days"`
 }
 
 // TenantListResponse represents a paginated list of tenants
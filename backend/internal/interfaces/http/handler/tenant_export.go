@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"github.com/erp/backend/internal/application/identity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TenantExportHandler handles tenant data export HTTP requests
+type TenantExportHandler struct {
+	BaseHandler
+	exportService *identity.TenantExportService
+}
+
+// NewTenantExportHandler creates a new tenant export handler
+func NewTenantExportHandler(exportService *identity.TenantExportService) *TenantExportHandler {
+	return &TenantExportHandler{
+		exportService: exportService,
+	}
+}
+
+// Export godoc
+//
+//	@ID				exportTenantData
+//	@Summary		Export all data of a tenant
+//	@Description	Stream a zip archive of the tenant's users, customers, sales orders and invoices as JSON files (GDPR-style data export).
+//	@Description	manifest.json is written last; an archive without it is incomplete.
+//	@Description	Only the caller's own tenant can be exported, unless the caller is a platform administrator.
+//	@Tags			tenants
+//	@Produce		application/zip
+//	@Param			id	path		string	true	"Tenant ID"	format(uuid)
+//	@Success		200	{file}		binary	"Zip archive"
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/identity/tenants/{id}/export [post]
+func (h *TenantExportHandler) Export(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+	if !canAccessTenant(c, id) {
+		h.Forbidden(c, "Cannot export the data of another tenant")
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", "attachment; filename=\"tenant-"+id.String()+"-export.zip\"")
+
+	if err := h.exportService.ExportTenantData(c.Request.Context(), id, c.Writer); err != nil {
+		if c.Writer.Written() {
+			// The archive is partially sent; the missing manifest marks it as incomplete
+			c.Abort()
+			return
+		}
+		c.Header("Content-Disposition", "")
+		h.HandleError(c, err)
+	}
+}
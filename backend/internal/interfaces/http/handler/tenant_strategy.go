@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/erp/backend/internal/application/identity"
+	"github.com/gin-gonic/gin"
+)
+
+// TenantStrategyHandler handles the strategies the current tenant chooses in place of the global defaults
+type TenantStrategyHandler struct {
+	BaseHandler
+	service *identity.TenantStrategyService
+}
+
+// NewTenantStrategyHandler creates a new TenantStrategyHandler
+func NewTenantStrategyHandler(service *identity.TenantStrategyService) *TenantStrategyHandler {
+	return &TenantStrategyHandler{
+		service: service,
+	}
+}
+
+// TenantStrategyChoiceResponse represents the strategy a tenant chose for one strategy type
+//
+//	@Description	Strategy chosen by the tenant and the strategy that applies
+type TenantStrategyChoiceResponse struct {
+	Configured string `json:"configured" example:"fifo"`
+	Effective  string `json:"effective" example:"fifo"`
+}
+
+// TenantStrategyConfigResponse represents the strategy configuration of a tenant
+//
+//	@Description	Cost, pricing, allocation and reconciliation strategies of a tenant
+type TenantStrategyConfigResponse struct {
+	TenantID       string                       `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Cost           TenantStrategyChoiceResponse `json:"cost"`
+	Pricing        TenantStrategyChoiceResponse `json:"pricing"`
+	Allocation     TenantStrategyChoiceResponse `json:"allocation"`
+	Reconciliation TenantStrategyChoiceResponse `json:"reconciliation"`
+	UpdatedAt      *time.Time                   `json:"updated_at,omitempty"`
+}
+
+// UpdateTenantStrategyConfigRequest represents a request to set the strategies of the current tenant
+//
+//	@Description	Strategies of the tenant; an empty strategy restores the global default
+type UpdateTenantStrategyConfigRequest struct {
+	CostStrategy           string `json:"cost_strategy" binding:"max=50" example:"moving_weighted_average"`
+	PricingStrategy        string `json:"pricing_strategy" binding:"max=50" example:""`
+	AllocationStrategy     string `json:"allocation_strategy" binding:"max=50" example:""`
+	ReconciliationStrategy string `json:"reconciliation_strategy" binding:"max=50" example:"LIFO"`
+}
+
+// GetTenantStrategies godoc
+//
+//	@ID				getSystemTenantStrategies
+//	@Summary		Get the strategies of the current tenant
+//	@Description	Returns the strategies the current tenant chose and the strategies that apply to it.
+//	@Description	Strategy types the tenant has not chosen use the global defaults.
+//	@Tags			system
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Success		200			{object}	APIResponse[TenantStrategyConfigResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/system/strategies/tenant [get]
+func (h *TenantStrategyHandler) GetTenantStrategies(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	config, err := h.service.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, config)
+}
+
+// SetTenantStrategies godoc
+//
+//	@ID				setSystemTenantStrategies
+//	@Summary		Set the strategies of the current tenant
+//	@Description	Replaces the strategies the current tenant chose. Cost, pricing and allocation strategies must be
+//	@Description	registered (see GET /system/strategies); the reconciliation strategy is FIFO, LIFO or LARGEST_FIRST.
+//	@Description	An empty strategy restores the global default. Requires tenant:update permission.
+//	@Tags			system
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string								false	"Tenant ID (optional for dev)"
+//	@Param			request		body		UpdateTenantStrategyConfigRequest	true	"Tenant strategies"
+//	@Success		200			{object}	APIResponse[TenantStrategyConfigResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/system/strategies/tenant [put]
+func (h *TenantStrategyHandler) SetTenantStrategies(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	var req UpdateTenantStrategyConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	config, err := h.service.Update(c.Request.Context(), tenantID, identity.UpdateTenantStrategyInput{
+		CostStrategy:           req.CostStrategy,
+		PricingStrategy:        req.PricingStrategy,
+		AllocationStrategy:     req.AllocationStrategy,
+		ReconciliationStrategy: req.ReconciliationStrategy,
+	})
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, config)
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	billingapp "github.com/erp/backend/internal/application/billing"
+	"github.com/erp/backend/internal/domain/billing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UsageAggregator builds time-bucketed usage series
+type UsageAggregator interface {
+	TenantLocation(ctx context.Context, tenantID uuid.UUID) (*time.Location, error)
+	AggregateUsage(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, from, to time.Time, granularity billing.AggregationPeriod) ([]*billing.UsageMeter, error)
+}
+
+// UsageSeriesHandler handles metered usage series HTTP requests
+type UsageSeriesHandler struct {
+	BaseHandler
+	aggregator UsageAggregator
+}
+
+// NewUsageSeriesHandler creates a new usage series handler
+func NewUsageSeriesHandler(aggregator UsageAggregator) *UsageSeriesHandler {
+	return &UsageSeriesHandler{aggregator: aggregator}
+}
+
+// Ensure the application service satisfies UsageAggregator
+var _ UsageAggregator = (*billingapp.UsageAggregationService)(nil)
+
+// UsageSeriesRequest represents the query for a usage series
+type UsageSeriesRequest struct {
+	Type        string `form:"type" binding:"required"`
+	Granularity string `form:"granularity"`
+	From        string `form:"from"`
+	To          string `form:"to"`
+}
+
+// UsageBucketResponse represents metered usage in one time bucket
+//
+//	@Description	Metered usage summed over one time bucket
+type UsageBucketResponse struct {
+	PeriodStart time.Time `json:"period_start" example:"2026-03-01T00:00:00+08:00"`
+	PeriodEnd   time.Time `json:"period_end" example:"2026-03-02T00:00:00+08:00"`
+	TotalUsage  int64     `json:"total_usage" example:"1250"`
+	RecordCount int64     `json:"record_count" example:"1250"`
+}
+
+// UsageSeriesResponse represents a time-bucketed usage series
+//
+//	@Description	Metered usage of one type bucketed by hour, day or month in the tenant's time zone
+type UsageSeriesResponse struct {
+	TenantID    string                `json:"tenant_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UsageType   string                `json:"usage_type" example:"API_CALLS"`
+	Unit        string                `json:"unit" example:"requests"`
+	Granularity string                `json:"granularity" example:"DAY"`
+	Timezone    string                `json:"timezone" example:"Asia/Shanghai"`
+	TotalUsage  int64                 `json:"total_usage" example:"8750"`
+	Buckets     []UsageBucketResponse `json:"buckets"`
+}
+
+// defaultUsageSeriesSpans is the range covered when no from date is given
+var defaultUsageSeriesSpans = map[billing.AggregationPeriod]func(time.Time) time.Time{
+	billing.AggregationPeriodHour:  func(t time.Time) time.Time { return t.Add(-24 * time.Hour) },
+	billing.AggregationPeriodDay:   func(t time.Time) time.Time { return t.AddDate(0, 0, -30) },
+	billing.AggregationPeriodMonth: func(t time.Time) time.Time { return t.AddDate(-1, 0, 0) },
+}
+
+// GetUsageSeries godoc
+//
+//	@ID				getBillingUsageSeries
+//	@Summary		Get metered usage over time
+//	@Description	Sum the current tenant's metered usage of one type per hour, day or month. Buckets follow the tenant's time zone, and buckets without usage are returned with zero usage. Dates (YYYY-MM-DD) are read in the tenant's time zone; RFC 3339 timestamps are also accepted. The range is [from, to) and defaults to the last 24 hours, 30 days or 12 months.
+//	@Tags			billing
+//	@Produce		json
+//	@Param			type		query		string	true	"Usage type"	example(API_CALLS)
+//	@Param			granularity	query		string	false	"Bucket size"	default(day)	Enums(hour, day, month)
+//	@Param			from		query		string	false	"Range start (YYYY-MM-DD or RFC 3339)"
+//	@Param			to			query		string	false	"Range end, exclusive (YYYY-MM-DD or RFC 3339)"
+//	@Success		200			{object}	APIResponse[UsageSeriesResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/billing/usage [get]
+func (h *UsageSeriesHandler) GetUsageSeries(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.Unauthorized(c, "Tenant ID not found in token")
+		return
+	}
+
+	var req UsageSeriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	usageType, err := billing.ParseUsageType(strings.ToUpper(req.Type))
+	if err != nil {
+		h.BadRequest(c, "Invalid usage type")
+		return
+	}
+
+	granularity := billing.AggregationPeriodDay
+	if req.Granularity != "" {
+		granularity = billing.AggregationPeriod(strings.ToUpper(req.Granularity))
+	}
+	if !granularity.IsSeriesGranularity() {
+		h.BadRequest(c, "Invalid granularity. Must be one of: hour, day, month")
+		return
+	}
+
+	loc, err := h.aggregator.TenantLocation(c.Request.Context(), tenantID)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	to := time.Now()
+	if req.To != "" {
+		if to, err = parseUsageSeriesTime(req.To, loc); err != nil {
+			h.BadRequest(c, "Invalid to format. Use YYYY-MM-DD or RFC 3339")
+			return
+		}
+	}
+	from := defaultUsageSeriesSpans[granularity](to)
+	if req.From != "" {
+		if from, err = parseUsageSeriesTime(req.From, loc); err != nil {
+			h.BadRequest(c, "Invalid from format. Use YYYY-MM-DD or RFC 3339")
+			return
+		}
+	}
+
+	series, err := h.aggregator.AggregateUsage(c.Request.Context(), tenantID, usageType, from, to, granularity)
+	if err != nil {
+		h.HandleError(c, err)
+		return
+	}
+
+	resp := UsageSeriesResponse{
+		TenantID:    tenantID.String(),
+		UsageType:   usageType.String(),
+		Unit:        usageType.Unit().String(),
+		Granularity: granularity.String(),
+		Timezone:    loc.String(),
+		Buckets:     make([]UsageBucketResponse, len(series)),
+	}
+	for i, meter := range series {
+		resp.TotalUsage += meter.TotalUsage
+		resp.Buckets[i] = UsageBucketResponse{
+			PeriodStart: meter.PeriodStart,
+			PeriodEnd:   meter.PeriodEnd,
+			TotalUsage:  meter.TotalUsage,
+			RecordCount: meter.RecordCount,
+		}
+	}
+	h.Success(c, resp)
+}
+
+// parseUsageSeriesTime parses a date in loc or an RFC 3339 timestamp
+func parseUsageSeriesTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
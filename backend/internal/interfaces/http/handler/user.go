@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"time"
 
 	"github.com/erp/backend/internal/application/identity"
@@ -420,6 +421,7 @@ func (h *UserHandler) Unlock(c *gin.Context) {
 //	@ID				resetPasswordUser
 //	@Summary		Reset user password
 //	@Description	Reset a user's password (admin action)
+//	@Description	The new password must satisfy the tenant's password policy; a 422 lists each failed rule in details
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
@@ -447,6 +449,11 @@ func (h *UserHandler) ResetPassword(c *gin.Context) {
 	}
 
 	if err := h.userService.ResetPassword(c.Request.Context(), id, req.NewPassword); err != nil {
+		var policyErr *domainIdentity.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			passwordPolicyViolation(c, policyErr)
+			return
+		}
 		h.HandleError(c, err)
 		return
 	}
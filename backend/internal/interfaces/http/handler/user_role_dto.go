@@ -34,7 +34,7 @@ type UpdateUserRequest struct {
 // ResetPasswordRequest represents the request body for resetting a user's password
 // @Name HandlerResetPasswordRequest
 type ResetPasswordRequest struct {
-	NewPassword string `json:"new_password" binding:"required,min=8,max=128"`
+	NewPassword string `json:"new_password" binding:"required,max=128"` // Length is checked by the tenant password policy
 }
 
 // AssignRolesRequest represents the request body for assigning roles to a user
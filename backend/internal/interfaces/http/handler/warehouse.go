@@ -25,44 +25,47 @@ func NewWarehouseHandler(warehouseService *partnerapp.WarehouseService) *Warehou
 //	@Description	Request body for creating a new warehouse
 //	@Name			HandlerCreateWarehouseRequest
 type CreateWarehouseRequest struct {
-	Code        string `json:"code" binding:"required,min=1,max=50" example:"WH-001"`
-	Name        string `json:"name" binding:"required,min=1,max=200" example:"Main Warehouse"`
-	ShortName   string `json:"short_name" binding:"max=100" example:"Main"`
-	Type        string `json:"type" binding:"required,oneof=physical virtual consign transit" example:"physical"`
-	ContactName string `json:"contact_name" binding:"max=100" example:"Wang Wei"`
-	Phone       string `json:"phone" binding:"max=50" example:"13500135000"`
-	Email       string `json:"email" binding:"omitempty,email,max=200" example:"warehouse@company.com"`
-	Address     string `json:"address" binding:"max=500" example:"Building 5, Industrial Zone"`
-	City        string `json:"city" binding:"max=100" example:"Shanghai"`
-	Province    string `json:"province" binding:"max=100" example:"Shanghai"`
-	PostalCode  string `json:"postal_code" binding:"max=20" example:"201100"`
-	Country     string `json:"country" binding:"max=100" example:"China"`
-	IsDefault   *bool  `json:"is_default" example:"true"`
-	Capacity    *int   `json:"capacity" example:"10000"`
-	Notes       string `json:"notes" example:"Primary storage facility"`
-	SortOrder   *int   `json:"sort_order" example:"0"`
-	Attributes  string `json:"attributes" example:"{}"`
+	Code               string `json:"code" binding:"required,min=1,max=50" example:"WH-001"`
+	Name               string `json:"name" binding:"required,min=1,max=200" example:"Main Warehouse"`
+	ShortName          string `json:"short_name" binding:"max=100" example:"Main"`
+	Type               string `json:"type" binding:"required,oneof=physical virtual consign transit" example:"physical"`
+	ContactName        string `json:"contact_name" binding:"max=100" example:"Wang Wei"`
+	Phone              string `json:"phone" binding:"max=50" example:"13500135000"`
+	Email              string `json:"email" binding:"omitempty,email,max=200" example:"warehouse@company.com"`
+	Address            string `json:"address" binding:"max=500" example:"Building 5, Industrial Zone"`
+	City               string `json:"city" binding:"max=100" example:"Shanghai"`
+	Province           string `json:"province" binding:"max=100" example:"Shanghai"`
+	PostalCode         string `json:"postal_code" binding:"max=20" example:"201100"`
+	Country            string `json:"country" binding:"max=100" example:"China"`
+	IsDefault          *bool  `json:"is_default" example:"true"`
+	Capacity           *int   `json:"capacity" example:"10000"`
+	CapacityUnit       string `json:"capacity_unit" binding:"omitempty,oneof=unit kg m3" example:"unit" enums:"unit,kg,m3"`
+	AllowNegativeStock *bool  `json:"allow_negative_stock" example:"false"`
+	Notes              string `json:"notes" example:"Primary storage facility"`
+	SortOrder          *int   `json:"sort_order" example:"0"`
+	Attributes         string `json:"attributes" example:"{}"`
 }
 
 // UpdateWarehouseRequest represents a request to update a warehouse
 //
 //	@Description	Request body for updating a warehouse
 type UpdateWarehouseRequest struct {
-	Name        *string `json:"name" binding:"omitempty,min=1,max=200" example:"Main Distribution Center"`
-	ShortName   *string `json:"short_name" binding:"omitempty,max=100" example:"MDC"`
-	ContactName *string `json:"contact_name" binding:"omitempty,max=100" example:"Li Ming"`
-	Phone       *string `json:"phone" binding:"omitempty,max=50" example:"13600136000"`
-	Email       *string `json:"email" binding:"omitempty,email,max=200" example:"mdc@company.com"`
-	Address     *string `json:"address" binding:"omitempty,max=500" example:"Block A, Logistics Park"`
-	City        *string `json:"city" binding:"omitempty,max=100" example:"Hangzhou"`
-	Province    *string `json:"province" binding:"omitempty,max=100" example:"Zhejiang"`
-	PostalCode  *string `json:"postal_code" binding:"omitempty,max=20" example:"310000"`
-	Country     *string `json:"country" binding:"omitempty,max=100" example:"China"`
-	IsDefault   *bool   `json:"is_default" example:"false"`
-	Capacity    *int    `json:"capacity" example:"15000"`
-	Notes       *string `json:"notes" example:"Upgraded capacity"`
-	SortOrder   *int    `json:"sort_order" example:"1"`
-	Attributes  *string `json:"attributes" example:"{}"`
+	Name         *string `json:"name" binding:"omitempty,min=1,max=200" example:"Main Distribution Center"`
+	ShortName    *string `json:"short_name" binding:"omitempty,max=100" example:"MDC"`
+	ContactName  *string `json:"contact_name" binding:"omitempty,max=100" example:"Li Ming"`
+	Phone        *string `json:"phone" binding:"omitempty,max=50" example:"13600136000"`
+	Email        *string `json:"email" binding:"omitempty,email,max=200" example:"mdc@company.com"`
+	Address      *string `json:"address" binding:"omitempty,max=500" example:"Block A, Logistics Park"`
+	City         *string `json:"city" binding:"omitempty,max=100" example:"Hangzhou"`
+	Province     *string `json:"province" binding:"omitempty,max=100" example:"Zhejiang"`
+	PostalCode   *string `json:"postal_code" binding:"omitempty,max=20" example:"310000"`
+	Country      *string `json:"country" binding:"omitempty,max=100" example:"China"`
+	IsDefault    *bool   `json:"is_default" example:"false"`
+	Capacity     *int    `json:"capacity" example:"15000"`
+	CapacityUnit *string `json:"capacity_unit" binding:"omitempty,oneof=unit kg m3" example:"kg" enums:"unit,kg,m3"`
+	Notes        *string `json:"notes" example:"Upgraded capacity"`
+	SortOrder    *int    `json:"sort_order" example:"1"`
+	Attributes   *string `json:"attributes" example:"{}"`
 }
 
 // UpdateWarehouseCodeRequest represents a request to update a warehouse's code
@@ -72,6 +75,13 @@ type UpdateWarehouseCodeRequest struct {
 	Code string `json:"code" binding:"required,min=1,max=50" example:"WH-002"`
 }
 
+// SetWarehouseNegativeStockRequest represents a request to set a warehouse's negative-stock policy
+//
+//	@Description	Request body for allowing or forbidding negative stock in a warehouse
+type SetWarehouseNegativeStockRequest struct {
+	AllowNegativeStock *bool `json:"allow_negative_stock" binding:"required" example:"true"`
+}
+
 // Create godoc
 //
 //	@ID				createWarehouse
@@ -108,23 +118,25 @@ func (h *WarehouseHandler) Create(c *gin.Context) {
 
 	// Convert to application DTO
 	appReq := partnerapp.CreateWarehouseRequest{
-		Code:        req.Code,
-		Name:        req.Name,
-		ShortName:   req.ShortName,
-		Type:        req.Type,
-		ContactName: req.ContactName,
-		Phone:       req.Phone,
-		Email:       req.Email,
-		Address:     req.Address,
-		City:        req.City,
-		Province:    req.Province,
-		PostalCode:  req.PostalCode,
-		Country:     req.Country,
-		IsDefault:   req.IsDefault,
-		Capacity:    req.Capacity,
-		Notes:       req.Notes,
-		SortOrder:   req.SortOrder,
-		Attributes:  req.Attributes,
+		Code:               req.Code,
+		Name:               req.Name,
+		ShortName:          req.ShortName,
+		Type:               req.Type,
+		ContactName:        req.ContactName,
+		Phone:              req.Phone,
+		Email:              req.Email,
+		Address:            req.Address,
+		City:               req.City,
+		Province:           req.Province,
+		PostalCode:         req.PostalCode,
+		Country:            req.Country,
+		IsDefault:          req.IsDefault,
+		Capacity:           req.Capacity,
+		CapacityUnit:       req.CapacityUnit,
+		AllowNegativeStock: req.AllowNegativeStock,
+		Notes:              req.Notes,
+		SortOrder:          req.SortOrder,
+		Attributes:         req.Attributes,
 	}
 
 	// Set CreatedBy for data scope filtering
@@ -347,21 +359,22 @@ func (h *WarehouseHandler) Update(c *gin.Context) {
 
 	// Convert to application DTO
 	appReq := partnerapp.UpdateWarehouseRequest{
-		Name:        req.Name,
-		ShortName:   req.ShortName,
-		ContactName: req.ContactName,
-		Phone:       req.Phone,
-		Email:       req.Email,
-		Address:     req.Address,
-		City:        req.City,
-		Province:    req.Province,
-		PostalCode:  req.PostalCode,
-		Country:     req.Country,
-		IsDefault:   req.IsDefault,
-		Capacity:    req.Capacity,
-		Notes:       req.Notes,
-		SortOrder:   req.SortOrder,
-		Attributes:  req.Attributes,
+		Name:         req.Name,
+		ShortName:    req.ShortName,
+		ContactName:  req.ContactName,
+		Phone:        req.Phone,
+		Email:        req.Email,
+		Address:      req.Address,
+		City:         req.City,
+		Province:     req.Province,
+		PostalCode:   req.PostalCode,
+		Country:      req.Country,
+		IsDefault:    req.IsDefault,
+		Capacity:     req.Capacity,
+		CapacityUnit: req.CapacityUnit,
+		Notes:        req.Notes,
+		SortOrder:    req.SortOrder,
+		Attributes:   req.Attributes,
 	}
 
 	warehouse, err := h.warehouseService.Update(c.Request.Context(), tenantID, warehouseID, appReq)
@@ -604,6 +617,92 @@ func (h *WarehouseHandler) SetDefault(c *gin.Context) {
 	h.Success(c, warehouse)
 }
 
+// SetNegativeStockPolicy godoc
+//
+//	@ID				setWarehouseNegativeStockPolicy
+//
+//	@Summary		Set warehouse negative-stock policy
+//	@Description	Allow or forbid stock-outs that drive on-hand quantities below zero in a warehouse
+//	@Tags			warehouses
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string								false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string								true	"Warehouse ID"	format(uuid)
+//	@Param			request		body		SetWarehouseNegativeStockRequest	true	"Negative-stock policy"
+//	@Success		200			{object}	APIResponse[WarehouseResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/warehouses/{id}/negative-stock [put]
+func (h *WarehouseHandler) SetNegativeStockPolicy(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	warehouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid warehouse ID format")
+		return
+	}
+
+	var req SetWarehouseNegativeStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.BadRequest(c, err.Error())
+		return
+	}
+
+	warehouse, err := h.warehouseService.SetNegativeStockPolicy(c.Request.Context(), tenantID, warehouseID, *req.AllowNegativeStock)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, warehouse)
+}
+
+// GetUtilization godoc
+//
+//	@ID				getWarehouseUtilization
+//
+//	@Summary		Get warehouse utilization
+//	@Description	Report how much of a warehouse's capacity its on-hand stock takes up, measured in the warehouse's capacity unit
+//	@Tags			warehouses
+//	@Produce		json
+//	@Param			X-Tenant-ID	header		string	false	"Tenant ID (optional for dev)"
+//	@Param			id			path		string	true	"Warehouse ID"	format(uuid)
+//	@Success		200			{object}	APIResponse[WarehouseUtilizationResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/partner/warehouses/{id}/utilization [get]
+func (h *WarehouseHandler) GetUtilization(c *gin.Context) {
+	tenantID, err := getTenantID(c)
+	if err != nil {
+		h.BadRequest(c, "Invalid tenant ID")
+		return
+	}
+
+	warehouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.BadRequest(c, "Invalid warehouse ID format")
+		return
+	}
+
+	utilization, err := h.warehouseService.GetUtilization(c.Request.Context(), tenantID, warehouseID)
+	if err != nil {
+		h.HandleDomainError(c, err)
+		return
+	}
+
+	h.Success(c, utilization)
+}
+
 // WarehouseCountByStatusResponse represents the warehouse count by status response
 type WarehouseCountByStatusResponse struct {
 	Active   int64 `json:"active" example:"8"`
@@ -152,6 +152,22 @@ func JWTAuthMiddlewareWithConfig(cfg JWTMiddlewareConfig) gin.HandlerFunc {
 					return
 				}
 			}
+
+			// Check if the token family has been revoked (refresh token replay)
+			if claims.FamilyID != "" {
+				revoked, err := cfg.TokenBlacklist.IsTokenFamilyRevoked(ctx, claims.FamilyID)
+				if err != nil {
+					// Log error but don't fail the request - fail open for availability
+					if cfg.Logger != nil {
+						cfg.Logger.Error("Failed to check token family revocation",
+							zap.String("family_id", claims.FamilyID),
+							zap.Error(err))
+					}
+				} else if revoked {
+					handleAuthError(c, cfg, auth.ErrTokenBlacklisted, "User session has been invalidated")
+					return
+				}
+			}
 		}
 
 		// Store claims in context for downstream use
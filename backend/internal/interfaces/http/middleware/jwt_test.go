@@ -533,6 +533,39 @@ func TestJWTAuthMiddleware_UserTokenInvalidated(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "TOKEN_REVOKED")
 }
 
+func TestJWTAuthMiddleware_TokenFamilyRevoked(t *testing.T) {
+	jwtService := newTestJWTService()
+	pair, _ := newTestTokenPair(jwtService)
+
+	claims, err := jwtService.ValidateAccessToken(pair.AccessToken)
+	require.NoError(t, err)
+
+	// Revoke the token family, as done on refresh token reuse
+	blacklist := auth.NewInMemoryTokenBlacklist()
+	err = blacklist.RevokeTokenFamily(t.Context(), claims.FamilyID, 1*time.Hour)
+	require.NoError(t, err)
+
+	cfg := JWTMiddlewareConfig{
+		JWTService:     jwtService,
+		TokenBlacklist: blacklist,
+	}
+
+	router := gin.New()
+	router.Use(JWTAuthMiddlewareWithConfig(cfg))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "TOKEN_REVOKED")
+}
+
 func TestJWTAuthMiddleware_NonBlacklistedToken(t *testing.T) {
 	jwtService := newTestJWTService()
 	pair, input := newTestTokenPair(jwtService)
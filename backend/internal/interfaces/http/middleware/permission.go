@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/erp/backend/internal/domain/identity"
 	"github.com/erp/backend/internal/infrastructure/auth"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -17,6 +18,13 @@ type PermissionConfig struct {
 	OnDenied func(c *gin.Context, requiredPerms []string)
 }
 
+// IsPlatformAdmin returns true if the authenticated user is a platform administrator,
+// who may act on tenants other than their own
+func IsPlatformAdmin(c *gin.Context) bool {
+	claims := GetJWTClaims(c)
+	return claims != nil && claims.HasPermission(identity.PermissionPlatformAdmin)
+}
+
 // RequirePermission creates middleware that requires a specific permission
 // This is a convenience function for single permission requirement
 func RequirePermission(permission string) gin.HandlerFunc {
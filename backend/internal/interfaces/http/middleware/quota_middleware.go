@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/erp/backend/internal/domain/billing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// QuotaExceededCode is the error code returned when a metered request is over quota
+const QuotaExceededCode = "quota_exceeded"
+
+// QuotaChecker checks a tenant's current-period usage against its quota
+type QuotaChecker interface {
+	// CheckUsageQuota checks if the tenant can consume amount more of usageType.
+	// The result carries the current usage, the limit and when the period resets.
+	CheckUsageQuota(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, amount int64) (*billing.QuotaCheckResult, error)
+}
+
+// UsageRecorder records usage events (UsageTracker implements it)
+type UsageRecorder interface {
+	// Track queues a usage record, returning false if it was dropped
+	Track(record *billing.UsageRecord) bool
+}
+
+// MeteredEndpointKey builds the MeteredEndpoints key for a method and Gin route pattern
+func MeteredEndpointKey(method, route string) string {
+	return method + " " + route
+}
+
+// DefaultMeteredEndpoints returns the endpoints metered against usage quotas
+func DefaultMeteredEndpoints() map[string]billing.UsageType {
+	return map[string]billing.UsageType{
+		MeteredEndpointKey(http.MethodPost, "/api/v1/trade/sales-orders"):    billing.UsageTypeOrdersCreated,
+		MeteredEndpointKey(http.MethodPost, "/api/v1/trade/purchase-orders"): billing.UsageTypeOrdersCreated,
+	}
+}
+
+// QuotaMiddlewareConfig holds configuration for quota enforcement middleware
+type QuotaMiddlewareConfig struct {
+	// Checker is required for checking quotas
+	Checker QuotaChecker
+	// Recorder records a usage event for each successful metered request (optional)
+	Recorder UsageRecorder
+	// MeteredEndpoints maps "METHOD /route/pattern" (see MeteredEndpointKey) to the
+	// usage type a request consumes. Routes not listed are not metered.
+	MeteredEndpoints map[string]billing.UsageType
+	// Logger for middleware logging
+	Logger *zap.Logger
+}
+
+// EnforceQuota returns a Gin middleware that enforces usage quotas on metered endpoints.
+// Before a metered request is handled, the tenant's current-period usage is checked
+// against its quota; when over, the request is rejected with 429 and the time the quota
+// resets. Successful (2xx) requests are recorded as one unit of usage.
+// This middleware should be placed after authentication middleware.
+func EnforceQuota(cfg QuotaMiddlewareConfig) gin.HandlerFunc {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return func(c *gin.Context) {
+		usageType, metered := cfg.MeteredEndpoints[MeteredEndpointKey(c.Request.Method, c.FullPath())]
+		if !metered || cfg.Checker == nil {
+			c.Next()
+			return
+		}
+
+		tenantID, err := uuid.Parse(GetJWTTenantID(c))
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		result, err := cfg.Checker.CheckUsageQuota(c.Request.Context(), tenantID, usageType, 1)
+		if err != nil {
+			// Fail open: a quota lookup failure should not take metered endpoints down
+			logger.Error("Failed to check usage quota, allowing request",
+				zap.String("tenant_id", tenantID.String()),
+				zap.String("usage_type", string(usageType)),
+				zap.Error(err),
+			)
+		} else if !result.IsAllowed() {
+			handleQuotaExceeded(c, result)
+			return
+		}
+
+		c.Next()
+
+		// Only record successful requests (2xx status codes)
+		if cfg.Recorder == nil || c.Writer.Status() < 200 || c.Writer.Status() >= 300 {
+			return
+		}
+
+		record, err := billing.NewUsageRecordSimple(tenantID, usageType, 1)
+		if err != nil {
+			logger.Debug("Failed to create metered usage record", zap.Error(err))
+			return
+		}
+		record.WithSource("api_request", c.FullPath())
+		record.WithMetadata("method", c.Request.Method)
+		if userID, err := uuid.Parse(GetJWTUserID(c)); err == nil {
+			record.WithUser(userID)
+		}
+		cfg.Recorder.Track(record)
+	}
+}
+
+// handleQuotaExceeded rejects a request that is over quota
+func handleQuotaExceeded(c *gin.Context, result *billing.QuotaCheckResult) {
+	details := gin.H{
+		"usage_type":    string(result.UsageType),
+		"current_usage": result.CurrentUsage,
+		"limit":         result.Limit,
+	}
+	if !result.ResetAt.IsZero() {
+		details["reset_at"] = result.ResetAt.UTC().Format(time.RFC3339)
+		retryAfter := int64(math.Ceil(time.Until(result.ResetAt).Seconds()))
+		c.Header("Retry-After", fmt.Sprintf("%d", max(retryAfter, 1)))
+	}
+
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code": QuotaExceededCode,
+			"message": fmt.Sprintf("%s quota exceeded: usage %d of limit %d",
+				result.UsageType.DisplayName(), result.CurrentUsage, result.Limit),
+			"details": details,
+		},
+	})
+}
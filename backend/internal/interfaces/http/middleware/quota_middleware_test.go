@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erp/backend/internal/domain/billing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuotaChecker checks usage against a single quota, like QuotaService
+type fakeQuotaChecker struct {
+	quota   *billing.UsageQuota
+	used    int64
+	resetAt time.Time
+	err     error
+	calls   int
+}
+
+func (f *fakeQuotaChecker) CheckUsageQuota(ctx context.Context, tenantID uuid.UUID, usageType billing.UsageType, amount int64) (*billing.QuotaCheckResult, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	result := f.quota.CheckUsage(f.used + amount)
+	result.CurrentUsage = f.used
+	result.ResetAt = f.resetAt
+	return &result, nil
+}
+
+// fakeUsageRecorder collects tracked usage records
+type fakeUsageRecorder struct {
+	mu      sync.Mutex
+	records []*billing.UsageRecord
+}
+
+func (f *fakeUsageRecorder) Track(record *billing.UsageRecord) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records = append(f.records, record)
+	return true
+}
+
+func newQuotaTestChecker(t *testing.T, used int64) *fakeQuotaChecker {
+	t.Helper()
+	quota, err := billing.NewUsageQuota("basic", billing.UsageTypeOrdersCreated, 100, billing.ResetPeriodMonthly)
+	require.NoError(t, err)
+	quota.WithOveragePolicy(billing.OveragePolicyBlock)
+	return &fakeQuotaChecker{
+		quota:   quota,
+		used:    used,
+		resetAt: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+}
+
+func newQuotaTestRouter(tenantID uuid.UUID, cfg QuotaMiddlewareConfig, status int, handled *int) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(JWTTenantIDKey, tenantID.String())
+		c.Set(JWTUserIDKey, uuid.New().String())
+		c.Next()
+	})
+	router.Use(EnforceQuota(cfg))
+	handler := func(c *gin.Context) {
+		*handled++
+		c.JSON(status, gin.H{"success": status < 300})
+	}
+	router.POST("/api/v1/trade/sales-orders", handler)
+	router.GET("/api/v1/trade/sales-orders", handler)
+	return router
+}
+
+func TestEnforceQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tenantID := uuid.New()
+
+	t.Run("allows and records request for tenant at 99% of quota", func(t *testing.T) {
+		checker := newQuotaTestChecker(t, 99)
+		recorder := &fakeUsageRecorder{}
+		handled := 0
+		router := newQuotaTestRouter(tenantID, QuotaMiddlewareConfig{
+			Checker:          checker,
+			Recorder:         recorder,
+			MeteredEndpoints: DefaultMeteredEndpoints(),
+		}, http.StatusCreated, &handled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/trade/sales-orders", nil))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, handled)
+		require.Len(t, recorder.records, 1)
+		assert.Equal(t, tenantID, recorder.records[0].TenantID)
+		assert.Equal(t, billing.UsageTypeOrdersCreated, recorder.records[0].UsageType)
+		assert.Equal(t, int64(1), recorder.records[0].Quantity)
+	})
+
+	t.Run("rejects request for tenant at 100% of quota", func(t *testing.T) {
+		checker := newQuotaTestChecker(t, 100)
+		recorder := &fakeUsageRecorder{}
+		handled := 0
+		router := newQuotaTestRouter(tenantID, QuotaMiddlewareConfig{
+			Checker:          checker,
+			Recorder:         recorder,
+			MeteredEndpoints: DefaultMeteredEndpoints(),
+		}, http.StatusCreated, &handled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/trade/sales-orders", nil))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Zero(t, handled)
+		assert.Empty(t, recorder.records)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		var body struct {
+			Success bool `json:"success"`
+			Error   struct {
+				Code    string `json:"code"`
+				Details struct {
+					UsageType    string `json:"usage_type"`
+					CurrentUsage int64  `json:"current_usage"`
+					Limit        int64  `json:"limit"`
+					ResetAt      string `json:"reset_at"`
+				} `json:"details"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.False(t, body.Success)
+		assert.Equal(t, QuotaExceededCode, body.Error.Code)
+		assert.Equal(t, "ORDERS_CREATED", body.Error.Details.UsageType)
+		assert.Equal(t, int64(100), body.Error.Details.CurrentUsage)
+		assert.Equal(t, int64(100), body.Error.Details.Limit)
+		resetAt, err := time.Parse(time.RFC3339, body.Error.Details.ResetAt)
+		require.NoError(t, err)
+		assert.True(t, checker.resetAt.Equal(resetAt))
+	})
+
+	t.Run("rejects request for tenant over quota", func(t *testing.T) {
+		checker := newQuotaTestChecker(t, 120)
+		handled := 0
+		router := newQuotaTestRouter(tenantID, QuotaMiddlewareConfig{
+			Checker:          checker,
+			MeteredEndpoints: DefaultMeteredEndpoints(),
+		}, http.StatusCreated, &handled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/trade/sales-orders", nil))
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Zero(t, handled)
+		assert.Contains(t, w.Body.String(), QuotaExceededCode)
+	})
+
+	t.Run("allows over quota request when overage policy is not BLOCK", func(t *testing.T) {
+		checker := newQuotaTestChecker(t, 120)
+		checker.quota.WithOveragePolicy(billing.OveragePolicyWarn)
+		handled := 0
+		router := newQuotaTestRouter(tenantID, QuotaMiddlewareConfig{
+			Checker:          checker,
+			MeteredEndpoints: DefaultMeteredEndpoints(),
+		}, http.StatusCreated, &handled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/trade/sales-orders", nil))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("does not meter routes not declared", func(t *testing.T) {
+		checker := newQuotaTestChecker(t, 120)
+		recorder := &fakeUsageRecorder{}
+		handled := 0
+		router := newQuotaTestRouter(tenantID, QuotaMiddlewareConfig{
+			Checker:          checker,
+			Recorder:         recorder,
+			MeteredEndpoints: DefaultMeteredEndpoints(),
+		}, http.StatusOK, &handled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/trade/sales-orders", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Zero(t, checker.calls)
+		assert.Empty(t, recorder.records)
+	})
+
+	t.Run("does not record failed requests", func(t *testing.T) {
+		checker := newQuotaTestChecker(t, 10)
+		recorder := &fakeUsageRecorder{}
+		handled := 0
+		router := newQuotaTestRouter(tenantID, QuotaMiddlewareConfig{
+			Checker:          checker,
+			Recorder:         recorder,
+			MeteredEndpoints: DefaultMeteredEndpoints(),
+		}, http.StatusBadRequest, &handled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/trade/sales-orders", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, 1, handled)
+		assert.Empty(t, recorder.records)
+	})
+
+	t.Run("allows request when quota check fails", func(t *testing.T) {
+		checker := newQuotaTestChecker(t, 120)
+		checker.err = errors.New("database unavailable")
+		handled := 0
+		router := newQuotaTestRouter(tenantID, QuotaMiddlewareConfig{
+			Checker:          checker,
+			MeteredEndpoints: DefaultMeteredEndpoints(),
+		}, http.StatusCreated, &handled)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/trade/sales-orders", nil))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, handled)
+	})
+}
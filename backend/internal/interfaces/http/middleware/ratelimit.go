@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -16,21 +17,56 @@ type RateLimiter struct {
 	limit       int           // Maximum requests per window
 	window      time.Duration // Time window
 	cleanupTick time.Duration // Cleanup interval
+	resolver    RateLimitResolver
 }
 
 type client struct {
 	tokens    int
+	limit     int
 	lastReset time.Time
 }
 
+// RateLimitPrincipal is the authenticated principal a rate limit is resolved for
+type RateLimitPrincipal struct {
+	TenantID string
+	UserID   string
+	RoleIDs  []string
+}
+
+// RateLimitTier is the rate limit of a group of principals, e.g. a role or a tenant plan
+type RateLimitTier struct {
+	// Bucket names the tier. Principals of the same tenant in the same bucket share the limit.
+	Bucket string
+	// Limit is the maximum number of requests per window
+	Limit int
+}
+
+// RateLimitResolver resolves the rate limit tier of an authenticated principal.
+// It returns false if the principal has no tier, in which case the default limit applies.
+type RateLimitResolver func(ctx context.Context, principal RateLimitPrincipal) (RateLimitTier, bool)
+
+// RateLimiterOption is a functional option for RateLimiter configuration
+type RateLimiterOption func(*RateLimiter)
+
+// WithLimitResolver resolves the limit of authenticated requests with the given resolver
+// instead of applying the default limit to every request
+func WithLimitResolver(resolver RateLimitResolver) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		rl.resolver = resolver
+	}
+}
+
 // NewRateLimiter creates a new rate limiter
-func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+func NewRateLimiter(limit int, window time.Duration, opts ...RateLimiterOption) *RateLimiter {
 	rl := &RateLimiter{
 		clients:     make(map[string]*client),
 		limit:       limit,
 		window:      window,
 		cleanupTick: window * 2, // Cleanup every 2 windows
 	}
+	for _, opt := range opts {
+		opt(rl)
+	}
 	go rl.cleanup()
 	return rl
 }
@@ -54,6 +90,11 @@ func (rl *RateLimiter) cleanup() {
 
 // Allow checks if a request from the given key should be allowed
 func (rl *RateLimiter) Allow(key string) bool {
+	return rl.AllowN(key, rl.limit)
+}
+
+// AllowN checks if a request from the given key should be allowed under the given limit
+func (rl *RateLimiter) AllowN(key string, limit int) bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
@@ -62,7 +103,8 @@ func (rl *RateLimiter) Allow(key string) bool {
 
 	if !exists {
 		rl.clients[key] = &client{
-			tokens:    rl.limit - 1,
+			tokens:    limit - 1,
+			limit:     limit,
 			lastReset: now,
 		}
 		return true
@@ -70,11 +112,18 @@ func (rl *RateLimiter) Allow(key string) bool {
 
 	// Reset tokens if window has passed
 	if now.Sub(c.lastReset) >= rl.window {
-		c.tokens = rl.limit - 1
+		c.tokens = limit - 1
+		c.limit = limit
 		c.lastReset = now
 		return true
 	}
 
+	// Apply a changed limit to the current window
+	if limit != c.limit {
+		c.tokens += limit - c.limit
+		c.limit = limit
+	}
+
 	// Check if tokens are available
 	if c.tokens > 0 {
 		c.tokens--
@@ -95,24 +144,49 @@ func (rl *RateLimiter) Remaining(key string) int {
 	}
 
 	if time.Since(c.lastReset) >= rl.window {
-		return rl.limit
+		return c.limit
 	}
 
-	return c.tokens
+	return max(c.tokens, 0)
 }
 
-// RateLimit returns a rate limiting middleware
+// resolve returns the rate limit key and limit of a request.
+// Authenticated requests with a resolved tier share the bucket of their tenant and tier;
+// other requests are limited per client IP (and tenant header) with the default limit.
+func (rl *RateLimiter) resolve(c *gin.Context) (string, int) {
+	if rl.resolver != nil {
+		if claims := GetJWTClaims(c); claims != nil && claims.TenantID != "" {
+			tier, ok := rl.resolver(c.Request.Context(), RateLimitPrincipal{
+				TenantID: claims.TenantID,
+				UserID:   claims.UserID,
+				RoleIDs:  claims.RoleIDs,
+			})
+			if ok && tier.Limit > 0 {
+				return "tenant:" + claims.TenantID + ":" + tier.Bucket, tier.Limit
+			}
+		}
+	}
+
+	// Use client IP as rate limit key
+	key := c.ClientIP()
+
+	// Add tenant ID to key if available for per-tenant limits
+	if tenantID := c.GetHeader("X-Tenant-ID"); tenantID != "" {
+		key = tenantID + ":" + key
+	}
+	return key, rl.limit
+}
+
+// RateLimit returns a rate limiting middleware.
+// If the limiter has a limit resolver, the middleware must run after JWTAuthMiddleware
+// so the principal of authenticated requests is known.
 func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Use client IP as rate limit key
-		key := c.ClientIP()
-
-		// Add tenant ID to key if available for per-tenant limits
-		if tenantID := c.GetHeader("X-Tenant-ID"); tenantID != "" {
-			key = tenantID + ":" + key
-		}
+		key, limit := limiter.resolve(c)
 
-		if !limiter.Allow(key) {
+		if !limiter.AllowN(key, limit) {
+			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", limit))
+			c.Header("X-RateLimit-Remaining", "0")
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
 				"success": false,
 				"error": gin.H{
@@ -124,7 +198,7 @@ func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
 		}
 
 		// Set rate limit headers
-		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", limiter.limit))
+		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", limit))
 		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", limiter.Remaining(key)))
 
 		c.Next()
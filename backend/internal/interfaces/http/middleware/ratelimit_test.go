@@ -1,14 +1,18 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/erp/backend/internal/infrastructure/auth"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRateLimiter(t *testing.T) {
@@ -167,6 +171,112 @@ func TestRateLimitMiddleware(t *testing.T) {
 	})
 }
 
+func TestRateLimitMiddleware_ResolvedLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtService := newTestJWTService()
+	tenantID := uuid.New()
+	adminRoleID := uuid.New()
+	trialRoleID := uuid.New()
+
+	// Admins get 3 requests per window, trial users 1; others the default 2
+	resolver := func(_ context.Context, principal RateLimitPrincipal) (RateLimitTier, bool) {
+		for _, roleID := range principal.RoleIDs {
+			switch roleID {
+			case adminRoleID.String():
+				return RateLimitTier{Bucket: "admin", Limit: 3}, true
+			case trialRoleID.String():
+				return RateLimitTier{Bucket: "trial", Limit: 1}, true
+			}
+		}
+		return RateLimitTier{}, false
+	}
+
+	newRouter := func() *gin.Engine {
+		limiter := NewRateLimiter(2, time.Minute, WithLimitResolver(resolver))
+		router := gin.New()
+		router.Use(OptionalJWTAuthMiddleware(jwtService))
+		router.Use(RateLimit(limiter))
+		router.GET("/test", func(c *gin.Context) {
+			c.String(http.StatusOK, "ok")
+		})
+		return router
+	}
+
+	newToken := func(roleID uuid.UUID) string {
+		pair, err := jwtService.GenerateTokenPair(auth.GenerateTokenInput{
+			TenantID: tenantID,
+			UserID:   uuid.New(),
+			Username: "user",
+			RoleIDs:  []uuid.UUID{roleID},
+		})
+		require.NoError(t, err)
+		return pair.AccessToken
+	}
+
+	get := func(router *gin.Engine, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("principals on different tiers get their own limits", func(t *testing.T) {
+		router := newRouter()
+		adminToken := newToken(adminRoleID)
+		trialToken := newToken(trialRoleID)
+
+		w := get(router, trialToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+
+		w = get(router, trialToken)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+
+		for i := 0; i < 3; i++ {
+			w = get(router, adminToken)
+			assert.Equal(t, http.StatusOK, w.Code, "admin request %d should be allowed", i+1)
+			assert.Equal(t, "3", w.Header().Get("X-RateLimit-Limit"))
+		}
+		w = get(router, adminToken)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("principals of the same tenant and tier share a bucket", func(t *testing.T) {
+		router := newRouter()
+
+		assert.Equal(t, http.StatusOK, get(router, newToken(trialRoleID)).Code)
+		assert.Equal(t, http.StatusTooManyRequests, get(router, newToken(trialRoleID)).Code)
+	})
+
+	t.Run("unauthenticated requests get the default limit", func(t *testing.T) {
+		router := newRouter()
+
+		for i := 0; i < 2; i++ {
+			w := get(router, "")
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+		}
+		assert.Equal(t, http.StatusTooManyRequests, get(router, "").Code)
+
+		// The default bucket is separate from the tiers
+		assert.Equal(t, http.StatusOK, get(router, newToken(adminRoleID)).Code)
+	})
+
+	t.Run("principals without a tier get the default limit", func(t *testing.T) {
+		router := newRouter()
+
+		w := get(router, newToken(uuid.New()))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	})
+}
+
 func TestRateLimitByKey(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceReadOnlyCode is the error code returned for writes while the service is read-only
+const ServiceReadOnlyCode = "service_read_only"
+
+// ReadOnlyMode is the global read-only switch. It is safe for concurrent use.
+// The switch is held in process, so each instance is toggled separately.
+type ReadOnlyMode struct {
+	enabled atomic.Bool
+}
+
+// NewReadOnlyMode creates a read-only switch in the given state
+func NewReadOnlyMode(enabled bool) *ReadOnlyMode {
+	m := &ReadOnlyMode{}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether writes are currently rejected
+func (m *ReadOnlyMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled turns read-only mode on or off
+func (m *ReadOnlyMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// ReadOnlyConfig holds configuration for read-only mode middleware
+type ReadOnlyConfig struct {
+	// Mode is the switch the middleware follows (required)
+	Mode *ReadOnlyMode
+	// PathPrefix limits enforcement to paths under it (default: /api/)
+	PathPrefix string
+	// ExemptPaths are exact paths that accept writes while read-only
+	ExemptPaths []string
+	// ExemptPathPrefixes are path prefixes that accept writes while read-only
+	ExemptPathPrefixes []string
+}
+
+// ReadOnly returns a Gin middleware that rejects writes while read-only mode is on.
+// POST, PUT, PATCH and DELETE requests under the path prefix are answered with 503 and
+// the service_read_only error code; reads and exempt paths pass through.
+func ReadOnly(cfg ReadOnlyConfig) gin.HandlerFunc {
+	prefix := cfg.PathPrefix
+	if prefix == "" {
+		prefix = "/api/"
+	}
+	exempt := make(map[string]bool, len(cfg.ExemptPaths))
+	for _, path := range cfg.ExemptPaths {
+		exempt[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if cfg.Mode == nil || !cfg.Mode.Enabled() || !isWriteMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		path := c.Request.URL.Path
+		if !strings.HasPrefix(path, prefix) || exempt[path] {
+			c.Next()
+			return
+		}
+		for _, exemptPrefix := range cfg.ExemptPathPrefixes {
+			if strings.HasPrefix(path, exemptPrefix) {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    ServiceReadOnlyCode,
+				"message": "The service is in read-only mode for maintenance; changes are not accepted right now",
+			},
+		})
+	}
+}
+
+// isWriteMethod reports whether the HTTP method changes data
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
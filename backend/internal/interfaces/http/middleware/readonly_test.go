@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReadOnlyTestRouter(mode *ReadOnlyMode) *gin.Engine {
+	router := gin.New()
+	router.Use(ReadOnly(ReadOnlyConfig{
+		Mode:               mode,
+		ExemptPaths:        []string{"/api/v1/auth/login"},
+		ExemptPathPrefixes: []string{"/api/v1/payment/callback"},
+	}))
+	ok := func(c *gin.Context) { c.String(http.StatusOK, "ok") }
+	router.GET("/health", ok)
+	router.GET("/api/v1/products", ok)
+	router.POST("/api/v1/products", ok)
+	router.DELETE("/api/v1/products/:id", ok)
+	router.POST("/api/v1/auth/login", ok)
+	router.POST("/api/v1/payment/callback/:gateway", ok)
+	return router
+}
+
+func serveReadOnly(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestReadOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("on: GET passes and POST is rejected", func(t *testing.T) {
+		router := newReadOnlyTestRouter(NewReadOnlyMode(true))
+
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodGet, "/api/v1/products").Code)
+
+		w := serveReadOnly(router, http.MethodPost, "/api/v1/products")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var body struct {
+			Success bool `json:"success"`
+			Error   struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.False(t, body.Success)
+		assert.Equal(t, ServiceReadOnlyCode, body.Error.Code)
+
+		assert.Equal(t, http.StatusServiceUnavailable, serveReadOnly(router, http.MethodDelete, "/api/v1/products/1").Code)
+	})
+
+	t.Run("off: GET and POST pass", func(t *testing.T) {
+		router := newReadOnlyTestRouter(NewReadOnlyMode(false))
+
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodGet, "/api/v1/products").Code)
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodPost, "/api/v1/products").Code)
+	})
+
+	t.Run("on: exempt paths and health checks pass", func(t *testing.T) {
+		router := newReadOnlyTestRouter(NewReadOnlyMode(true))
+
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodGet, "/health").Code)
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodPost, "/api/v1/auth/login").Code)
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodPost, "/api/v1/payment/callback/wechat").Code)
+	})
+
+	t.Run("toggling takes effect on the next request", func(t *testing.T) {
+		mode := NewReadOnlyMode(false)
+		router := newReadOnlyTestRouter(mode)
+
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodPost, "/api/v1/products").Code)
+		mode.SetEnabled(true)
+		assert.Equal(t, http.StatusServiceUnavailable, serveReadOnly(router, http.MethodPost, "/api/v1/products").Code)
+		mode.SetEnabled(false)
+		assert.Equal(t, http.StatusOK, serveReadOnly(router, http.MethodPost, "/api/v1/products").Code)
+	})
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/semaphore"
+)
+
+// TenantConcurrencyExceededCode is the error code returned when a tenant runs too many heavy operations at once
+const TenantConcurrencyExceededCode = "tenant_concurrency_exceeded"
+
+// TenantLimiter caps the concurrent heavy operations (reports, exports) of each tenant,
+// so one tenant's heavy queries cannot take the whole database pool from the others.
+// Each tenant gets a weighted semaphore of the same capacity; an operation takes its
+// weight from its tenant's semaphore and gives it back when done.
+type TenantLimiter struct {
+	mu       sync.Mutex
+	capacity int64
+	tenants  map[string]*tenantSemaphore
+}
+
+// tenantSemaphore is a tenant's semaphore and the number of operations holding it
+type tenantSemaphore struct {
+	sem  *semaphore.Weighted
+	refs int
+}
+
+// NewTenantLimiter creates a limiter allowing each tenant capacity units of concurrent heavy work.
+// A capacity below 1 is treated as 1.
+func NewTenantLimiter(capacity int64) *TenantLimiter {
+	return &TenantLimiter{
+		capacity: max(capacity, 1),
+		tenants:  make(map[string]*tenantSemaphore),
+	}
+}
+
+// Capacity returns the units of concurrent heavy work each tenant may use
+func (l *TenantLimiter) Capacity() int64 {
+	return l.capacity
+}
+
+// TryAcquire takes weight units from the tenant's slice without waiting.
+// A weight above the capacity is capped at it, so such an operation runs alone.
+// When ok, release must be called exactly once to give the units back.
+func (l *TenantLimiter) TryAcquire(tenantID string, weight int64) (release func(), ok bool) {
+	weight = min(max(weight, 1), l.capacity)
+
+	l.mu.Lock()
+	ts, found := l.tenants[tenantID]
+	if !found {
+		ts = &tenantSemaphore{sem: semaphore.NewWeighted(l.capacity)}
+		l.tenants[tenantID] = ts
+	}
+	if !ts.sem.TryAcquire(weight) {
+		if ts.refs == 0 {
+			delete(l.tenants, tenantID)
+		}
+		l.mu.Unlock()
+		return nil, false
+	}
+	ts.refs++
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			ts.sem.Release(weight)
+			// Drop idle tenants so the map only holds tenants with work in flight
+			if ts.refs--; ts.refs == 0 {
+				delete(l.tenants, tenantID)
+			}
+		})
+	}, true
+}
+
+// TenantConcurrencyLimit returns a Gin middleware that runs the request as a heavy operation
+// of the given weight in its tenant's slice of the limiter. When the tenant's slice is used up,
+// the request is rejected with 429 instead of queuing on the shared database pool.
+// The slice is given back when the request finishes, whether it succeeded, failed or panicked.
+// Requests without a tenant pass through. This middleware should be placed after authentication middleware.
+func TenantConcurrencyLimit(limiter *TenantLimiter, weight int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := GetJWTTenantID(c)
+		if limiter == nil || tenantID == "" {
+			c.Next()
+			return
+		}
+
+		release, ok := limiter.TryAcquire(tenantID, weight)
+		if !ok {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    TenantConcurrencyExceededCode,
+					"message": "Too many reports or exports are running for this tenant. Please retry when one has finished",
+					"details": gin.H{
+						"max_concurrent": limiter.Capacity(),
+					},
+				},
+			})
+			return
+		}
+		defer release()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHeavyOpTestRouter serves /report as a heavy operation of the tenant in the X-Tenant header.
+// With ?block=1 the handler signals started and holds its slot until release is closed.
+func newHeavyOpTestRouter(limiter *TenantLimiter, started chan<- struct{}, release <-chan struct{}) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.Use(func(c *gin.Context) {
+		if tenantID := c.GetHeader("X-Tenant"); tenantID != "" {
+			c.Set(JWTTenantIDKey, tenantID)
+		}
+		c.Next()
+	})
+	router.Use(TenantConcurrencyLimit(limiter, 1))
+	router.GET("/report", func(c *gin.Context) {
+		if c.Query("block") == "1" {
+			started <- struct{}{}
+			<-release
+		}
+		c.String(http.StatusOK, "ok")
+	})
+	router.GET("/failing-report", func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+	router.GET("/panicking-report", func(c *gin.Context) {
+		panic("query failed")
+	})
+	return router
+}
+
+func serveHeavyOp(router *gin.Engine, path, tenantID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("X-Tenant", tenantID)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTenantConcurrencyLimit_ThrottlesSameTenantOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := newHeavyOpTestRouter(NewTenantLimiter(1), started, release)
+
+	var wg sync.WaitGroup
+	var first *httptest.ResponseRecorder
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		first = serveHeavyOp(router, "/report?block=1", "tenant-a")
+	}()
+	<-started
+
+	// A second heavy request from the same tenant is throttled
+	second := serveHeavyOp(router, "/report", "tenant-a")
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Contains(t, second.Body.String(), TenantConcurrencyExceededCode)
+	assert.Equal(t, "1", second.Header().Get("Retry-After"))
+
+	// Another tenant has its own slice and proceeds
+	assert.Equal(t, http.StatusOK, serveHeavyOp(router, "/report", "tenant-b").Code)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, http.StatusOK, first.Code)
+
+	// The slot is released once the first request finishes
+	assert.Equal(t, http.StatusOK, serveHeavyOp(router, "/report", "tenant-a").Code)
+}
+
+func TestTenantConcurrencyLimit_ReleasesOnError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := NewTenantLimiter(1)
+	router := newHeavyOpTestRouter(limiter, nil, nil)
+
+	assert.Equal(t, http.StatusInternalServerError, serveHeavyOp(router, "/failing-report", "tenant-a").Code)
+	assert.Equal(t, http.StatusOK, serveHeavyOp(router, "/report", "tenant-a").Code)
+
+	assert.Equal(t, http.StatusInternalServerError, serveHeavyOp(router, "/panicking-report", "tenant-a").Code)
+	assert.Equal(t, http.StatusOK, serveHeavyOp(router, "/report", "tenant-a").Code)
+
+	assert.Empty(t, limiter.tenants, "idle tenants should not be kept")
+}
+
+func TestTenantLimiter_Weights(t *testing.T) {
+	limiter := NewTenantLimiter(3)
+
+	releaseReport, ok := limiter.TryAcquire("tenant-a", 1)
+	require.True(t, ok)
+
+	// An export weighing more than the capacity is capped and needs the whole slice
+	_, ok = limiter.TryAcquire("tenant-a", 5)
+	assert.False(t, ok)
+
+	releaseExport, ok := limiter.TryAcquire("tenant-a", 2)
+	require.True(t, ok)
+	_, ok = limiter.TryAcquire("tenant-a", 1)
+	assert.False(t, ok)
+
+	releaseReport()
+	releaseReport() // Releasing twice gives the units back once
+	_, ok = limiter.TryAcquire("tenant-a", 2)
+	assert.False(t, ok)
+
+	releaseExport()
+	releaseAll, ok := limiter.TryAcquire("tenant-a", 5)
+	require.True(t, ok)
+	releaseAll()
+	assert.Empty(t, limiter.tenants)
+}
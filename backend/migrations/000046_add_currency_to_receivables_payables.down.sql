@@ -0,0 +1,9 @@
+-- Rollback: Remove currency column from account_receivables and account_payables
+
+-- Drop the indexes first
+DROP INDEX IF EXISTS idx_account_payables_currency;
+DROP INDEX IF EXISTS idx_account_receivables_currency;
+
+-- Remove the columns
+ALTER TABLE account_payables DROP COLUMN IF EXISTS currency;
+ALTER TABLE account_receivables DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,18 @@
+-- Migration: Add currency column to account_receivables and account_payables
+-- Description: Enables multi-currency receivables and payables; existing rows default to CNY
+
+-- Add currency column to account_receivables table
+ALTER TABLE account_receivables
+ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'CNY';
+
+-- Add currency column to account_payables table
+ALTER TABLE account_payables
+ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'CNY';
+
+-- Create indexes for per-currency aggregation
+CREATE INDEX IF NOT EXISTS idx_account_receivables_currency ON account_receivables(tenant_id, customer_id, currency);
+CREATE INDEX IF NOT EXISTS idx_account_payables_currency ON account_payables(tenant_id, supplier_id, currency);
+
+-- Add comments for the new columns
+COMMENT ON COLUMN account_receivables.currency IS 'ISO 4217 currency code of the receivable amounts';
+COMMENT ON COLUMN account_payables.currency IS 'ISO 4217 currency code of the payable amounts';
@@ -0,0 +1,23 @@
+-- Rollback: Remove bad-debt write-off support from account_receivables
+-- Note: written-off receivables must be resolved before rolling back, as WRITTEN_OFF is no longer a valid status
+
+-- Drop the index first
+DROP INDEX IF EXISTS idx_receivable_written_off;
+
+-- Restore original constraints
+ALTER TABLE account_receivables DROP CONSTRAINT IF EXISTS chk_receivable_amounts;
+ALTER TABLE account_receivables ADD CONSTRAINT chk_receivable_amounts CHECK (
+    (status IN ('CANCELLED', 'REVERSED') AND outstanding_amount = 0) OR
+    (status NOT IN ('CANCELLED', 'REVERSED') AND paid_amount <= total_amount AND outstanding_amount = total_amount - paid_amount)
+);
+
+ALTER TABLE account_receivables DROP CONSTRAINT IF EXISTS chk_receivable_status;
+ALTER TABLE account_receivables ADD CONSTRAINT chk_receivable_status
+    CHECK (status IN ('PENDING', 'PARTIAL', 'PAID', 'REVERSED', 'CANCELLED'));
+
+-- Remove the columns
+ALTER TABLE account_receivables
+DROP COLUMN IF EXISTS write_off_reason,
+DROP COLUMN IF EXISTS written_off_by,
+DROP COLUMN IF EXISTS written_off_at,
+DROP COLUMN IF EXISTS written_off_amount;
@@ -0,0 +1,30 @@
+-- Migration: Add bad-debt write-off support to account_receivables
+-- Description: Adds WRITTEN_OFF status and write-off audit columns
+
+-- Add write-off tracking columns
+ALTER TABLE account_receivables
+ADD COLUMN IF NOT EXISTS written_off_amount DECIMAL(18, 4) NOT NULL DEFAULT 0 CHECK (written_off_amount >= 0),
+ADD COLUMN IF NOT EXISTS written_off_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS written_off_by UUID,
+ADD COLUMN IF NOT EXISTS write_off_reason VARCHAR(500);
+
+-- Allow WRITTEN_OFF status
+ALTER TABLE account_receivables DROP CONSTRAINT IF EXISTS chk_receivable_status;
+ALTER TABLE account_receivables ADD CONSTRAINT chk_receivable_status
+    CHECK (status IN ('PENDING', 'PARTIAL', 'PAID', 'REVERSED', 'CANCELLED', 'WRITTEN_OFF'));
+
+-- Written-off records have no outstanding amount, like cancelled/reversed ones
+ALTER TABLE account_receivables DROP CONSTRAINT IF EXISTS chk_receivable_amounts;
+ALTER TABLE account_receivables ADD CONSTRAINT chk_receivable_amounts CHECK (
+    (status IN ('CANCELLED', 'REVERSED', 'WRITTEN_OFF') AND outstanding_amount = 0) OR
+    (status NOT IN ('CANCELLED', 'REVERSED', 'WRITTEN_OFF') AND paid_amount <= total_amount AND outstanding_amount = total_amount - paid_amount)
+);
+
+-- Create index for written-off summary queries
+CREATE INDEX IF NOT EXISTS idx_receivable_written_off ON account_receivables(tenant_id, written_off_amount) WHERE status = 'WRITTEN_OFF';
+
+-- Add comments for the new columns
+COMMENT ON COLUMN account_receivables.written_off_amount IS 'Outstanding amount written off as bad debt';
+COMMENT ON COLUMN account_receivables.written_off_at IS 'When the receivable was written off';
+COMMENT ON COLUMN account_receivables.written_off_by IS 'User who approved the write-off';
+COMMENT ON COLUMN account_receivables.write_off_reason IS 'Reason for the write-off';
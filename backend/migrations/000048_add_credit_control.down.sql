@@ -0,0 +1,6 @@
+-- Rollback: Remove credit control settings
+
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS credit_override_reason;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS credit_override_approved_at;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS credit_override_approved_by;
+ALTER TABLE tenants DROP COLUMN IF EXISTS config_credit_control_enabled;
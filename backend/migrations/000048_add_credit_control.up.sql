@@ -0,0 +1,19 @@
+-- Migration: Add credit control settings
+-- Description: Lets tenants opt out of credit limit enforcement and lets sales orders
+-- carry an approved override to ship beyond the customer's credit limit
+
+-- Add credit control toggle to tenants (enabled by default)
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS config_credit_control_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- Add credit override approval columns to sales_orders
+ALTER TABLE sales_orders
+ADD COLUMN IF NOT EXISTS credit_override_approved_by UUID,
+ADD COLUMN IF NOT EXISTS credit_override_approved_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS credit_override_reason VARCHAR(500);
+
+-- Add comments for the new columns
+COMMENT ON COLUMN tenants.config_credit_control_enabled IS 'Whether customer credit limits are enforced when shipping sales orders';
+COMMENT ON COLUMN sales_orders.credit_override_approved_by IS 'User who approved shipping beyond the customer credit limit';
+COMMENT ON COLUMN sales_orders.credit_override_approved_at IS 'When the credit override was approved';
+COMMENT ON COLUMN sales_orders.credit_override_reason IS 'Reason given for the credit override';
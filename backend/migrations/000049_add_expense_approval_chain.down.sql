@@ -0,0 +1,5 @@
+-- Rollback: Remove expense approval chain
+
+ALTER TABLE expense_records DROP COLUMN IF EXISTS approvals;
+ALTER TABLE expense_records DROP COLUMN IF EXISTS required_approvals;
+ALTER TABLE tenants DROP COLUMN IF EXISTS config_expense_approval_thresholds;
@@ -0,0 +1,17 @@
+-- Migration: Add expense approval chain
+-- Description: Lets tenants configure amount thresholds above which expenses need
+-- additional approvers, and records each approval level signed off on an expense
+
+-- Add expense approval thresholds to tenants (no thresholds: a single approval)
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS config_expense_approval_thresholds JSONB NOT NULL DEFAULT '[]';
+
+-- Add approval chain columns to expense_records
+ALTER TABLE expense_records
+ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 1,
+ADD COLUMN IF NOT EXISTS approvals JSONB DEFAULT '[]';
+
+-- Add comments for the new columns
+COMMENT ON COLUMN tenants.config_expense_approval_thresholds IS 'Ascending expense amounts; each one exceeded requires an additional approver';
+COMMENT ON COLUMN expense_records.required_approvals IS 'Number of approval levels computed when the expense was submitted';
+COMMENT ON COLUMN expense_records.approvals IS 'Approval levels signed off so far (JSON array)';
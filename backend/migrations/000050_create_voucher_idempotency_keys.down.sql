@@ -0,0 +1,4 @@
+-- Migration: Drop voucher_idempotency_keys table
+-- Description: Removes the voucher_idempotency_keys table and its indexes
+
+DROP TABLE IF EXISTS voucher_idempotency_keys;
@@ -0,0 +1,27 @@
+-- Migration: Create voucher_idempotency_keys table
+-- Description: Records the voucher created for each client-supplied Idempotency-Key,
+-- so retried receipt and payment voucher requests return the original voucher
+
+CREATE TABLE voucher_idempotency_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    voucher_type VARCHAR(20) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    voucher_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    -- One key per tenant and voucher type; concurrent inserts of the same key wait on each other
+    CONSTRAINT uq_voucher_idempotency_keys_key UNIQUE (tenant_id, voucher_type, idempotency_key),
+    CONSTRAINT chk_voucher_idempotency_keys_type CHECK (voucher_type IN ('RECEIPT', 'PAYMENT'))
+);
+
+-- Create index for purging expired keys
+CREATE INDEX idx_voucher_idempotency_keys_expires_at ON voucher_idempotency_keys(expires_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE voucher_idempotency_keys IS 'Idempotency keys for receipt and payment voucher creation';
+COMMENT ON COLUMN voucher_idempotency_keys.voucher_type IS 'RECEIPT or PAYMENT';
+COMMENT ON COLUMN voucher_idempotency_keys.idempotency_key IS 'Client-supplied Idempotency-Key header value';
+COMMENT ON COLUMN voucher_idempotency_keys.voucher_id IS 'The voucher created by the first request with this key';
+COMMENT ON COLUMN voucher_idempotency_keys.expires_at IS 'After this time the key no longer replays its voucher and may be reused';
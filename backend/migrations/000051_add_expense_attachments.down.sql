@@ -0,0 +1,3 @@
+-- Rollback: Remove expense attachments
+
+ALTER TABLE expense_records DROP COLUMN IF EXISTS attachments;
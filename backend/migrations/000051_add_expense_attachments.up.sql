@@ -0,0 +1,8 @@
+-- Migration: Add expense attachments
+-- Description: Stores metadata of supporting documents (receipts, invoices) attached to expenses.
+-- The files themselves are kept in attachment storage.
+
+ALTER TABLE expense_records
+ADD COLUMN IF NOT EXISTS attachments JSONB DEFAULT '[]';
+
+COMMENT ON COLUMN expense_records.attachments IS 'Attached supporting documents: file name, content type, size, storage key, uploader (JSON array)';
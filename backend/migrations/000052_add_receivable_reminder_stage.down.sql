@@ -0,0 +1,7 @@
+-- Rollback: Remove receivable due-date reminder tracking
+
+DROP INDEX IF EXISTS idx_account_receivables_reminder_scan;
+
+ALTER TABLE account_receivables
+DROP COLUMN IF EXISTS last_reminder_sent_at,
+DROP COLUMN IF EXISTS reminder_stage;
@@ -0,0 +1,15 @@
+-- Migration: Add receivable due-date reminder tracking
+-- Description: Records which due-date reminder (due soon, overdue) was last sent for a receivable,
+-- so the daily reminder scan emits each reminder only once.
+
+ALTER TABLE account_receivables
+ADD COLUMN IF NOT EXISTS reminder_stage VARCHAR(20) NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS last_reminder_sent_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN account_receivables.reminder_stage IS 'Last due-date reminder sent: empty, DUE_SOON or OVERDUE';
+COMMENT ON COLUMN account_receivables.last_reminder_sent_at IS 'When the last due-date reminder was sent';
+
+-- Supports the reminder scan over outstanding receivables
+CREATE INDEX IF NOT EXISTS idx_account_receivables_reminder_scan
+ON account_receivables (due_date, reminder_stage)
+WHERE status IN ('PENDING', 'PARTIAL') AND due_date IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Migration: Drop product_price_histories table
+-- Description: Removes the product_price_histories table and its indexes
+
+DROP TABLE IF EXISTS product_price_histories;
@@ -0,0 +1,30 @@
+-- Migration: Create product_price_histories table
+-- Description: Append-only log of product price changes, so the price of a product
+-- can be looked up at any point in time
+
+CREATE TABLE product_price_histories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price_type VARCHAR(20) NOT NULL,
+    old_price DECIMAL(18,4) NOT NULL,
+    new_price DECIMAL(18,4) NOT NULL,
+    changed_by UUID,
+    effective_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_product_price_histories_type CHECK (price_type IN ('PURCHASE', 'SELLING')),
+    CONSTRAINT chk_product_price_histories_prices CHECK (old_price >= 0 AND new_price >= 0)
+);
+
+-- Create index for history listing and point-in-time price lookups
+CREATE INDEX idx_price_history_product_time ON product_price_histories(tenant_id, product_id, price_type, effective_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE product_price_histories IS 'Append-only log of product price changes';
+COMMENT ON COLUMN product_price_histories.price_type IS 'PURCHASE or SELLING';
+COMMENT ON COLUMN product_price_histories.old_price IS 'Price before the change';
+COMMENT ON COLUMN product_price_histories.new_price IS 'Price after the change';
+COMMENT ON COLUMN product_price_histories.changed_by IS 'User who changed the price';
+COMMENT ON COLUMN product_price_histories.effective_at IS 'When the new price took effect';
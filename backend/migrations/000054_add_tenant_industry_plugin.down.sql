@@ -0,0 +1,3 @@
+-- Rollback: Remove industry plugin setting from tenants
+
+ALTER TABLE tenants DROP COLUMN IF EXISTS config_industry_plugin;
@@ -0,0 +1,7 @@
+-- Migration: Add industry plugin setting to tenants
+-- Description: Lets a tenant opt in to the product attribute requirements of an industry plugin
+
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS config_industry_plugin VARCHAR(50) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN tenants.config_industry_plugin IS 'Industry plugin whose required product attributes are enforced (empty for none)';
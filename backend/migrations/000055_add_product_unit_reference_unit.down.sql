@@ -0,0 +1,3 @@
+-- Rollback: Remove reference unit from product units
+
+ALTER TABLE product_units DROP COLUMN IF EXISTS reference_unit_code;
@@ -0,0 +1,8 @@
+-- Migration: Add reference unit to product units
+-- Description: Lets a unit's conversion rate be relative to another unit of the product
+-- (e.g., 1 pallet = 40 case) instead of always to the base unit
+
+ALTER TABLE product_units
+ADD COLUMN IF NOT EXISTS reference_unit_code VARCHAR(20) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN product_units.reference_unit_code IS 'Unit the conversion rate is relative to (empty for the product base unit)';
@@ -0,0 +1,12 @@
+-- Rollback: Remove negative-stock policy from warehouses
+-- The checks are restored NOT VALID so rows recorded while negative stock was allowed are kept as-is;
+-- only new and updated rows are checked.
+
+ALTER TABLE inventory_items
+ADD CONSTRAINT inventory_items_available_quantity_check CHECK (available_quantity >= 0) NOT VALID;
+ALTER TABLE inventory_transactions
+ADD CONSTRAINT inventory_transactions_balance_before_check CHECK (balance_before >= 0) NOT VALID;
+ALTER TABLE inventory_transactions
+ADD CONSTRAINT inventory_transactions_balance_after_check CHECK (balance_after >= 0) NOT VALID;
+
+ALTER TABLE warehouses DROP COLUMN IF EXISTS allow_negative_stock;
@@ -0,0 +1,12 @@
+-- Migration: Add negative-stock policy to warehouses
+-- Description: Lets a warehouse (e.g., consignment) allow stock-outs that drive on-hand below zero.
+-- Warehouses default to a hard stop; the non-negative checks move from the tables to the application.
+
+ALTER TABLE warehouses
+ADD COLUMN IF NOT EXISTS allow_negative_stock BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN warehouses.allow_negative_stock IS 'Whether stock-outs may drive on-hand quantities below zero';
+
+ALTER TABLE inventory_items DROP CONSTRAINT IF EXISTS inventory_items_available_quantity_check;
+ALTER TABLE inventory_transactions DROP CONSTRAINT IF EXISTS inventory_transactions_balance_before_check;
+ALTER TABLE inventory_transactions DROP CONSTRAINT IF EXISTS inventory_transactions_balance_after_check;
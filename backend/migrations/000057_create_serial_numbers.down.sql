@@ -0,0 +1,6 @@
+-- Rollback: Remove serial number tracking
+
+DROP TABLE IF EXISTS serial_number_movements;
+DROP TABLE IF EXISTS serial_numbers;
+
+ALTER TABLE products DROP COLUMN IF EXISTS is_serialized;
@@ -0,0 +1,54 @@
+-- Migration: Add serial number tracking
+-- Description: Flags products whose units are tracked individually and records each unit's
+-- serial number, current status and movement history.
+
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS is_serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN products.is_serialized IS 'Whether each unit must be identified by serial number on receipt and shipment';
+
+-- Create serial_numbers table
+CREATE TABLE serial_numbers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    inventory_item_id UUID NOT NULL,
+    warehouse_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    serial_number VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('IN_STOCK', 'SHIPPED', 'RETURNED')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- A serial number identifies one unit of a product
+    CONSTRAINT idx_serial_number_product UNIQUE (tenant_id, product_id, serial_number),
+
+    -- Foreign keys
+    CONSTRAINT fk_serial_number_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_serial_number_inventory_item FOREIGN KEY (inventory_item_id) REFERENCES inventory_items(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_serial_number_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_serial_number_inventory_item ON serial_numbers(inventory_item_id);
+
+CREATE TRIGGER trg_serial_numbers_updated_at
+    BEFORE UPDATE ON serial_numbers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Create serial_number_movements table (append-only history)
+CREATE TABLE serial_number_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    serial_number_id UUID NOT NULL,
+    inventory_item_id UUID NOT NULL,
+    warehouse_id UUID NOT NULL,
+    movement_type VARCHAR(20) NOT NULL CHECK (movement_type IN ('RECEIVED', 'SHIPPED', 'RETURNED')),
+    source_type VARCHAR(30) NOT NULL,
+    source_id VARCHAR(50) NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_serial_movement_serial_number FOREIGN KEY (serial_number_id) REFERENCES serial_numbers(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_serial_movement_serial_number ON serial_number_movements(serial_number_id, occurred_at);
@@ -0,0 +1,7 @@
+-- Rollback: Remove partial shipment tracking from sales orders
+
+-- Partially shipped orders fall back to confirmed
+UPDATE sales_orders SET status = 'CONFIRMED' WHERE status = 'PARTIALLY_SHIPPED';
+
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS shipment_count;
+ALTER TABLE sales_order_items DROP COLUMN IF EXISTS shipped_quantity;
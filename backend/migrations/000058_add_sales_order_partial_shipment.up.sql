@@ -0,0 +1,26 @@
+-- Migration: Add partial shipment tracking to sales orders
+-- Description: Tracks the quantity shipped per sales order line and the number of
+-- shipments per order, so an order can ship in several parts (PARTIALLY_SHIPPED status)
+
+-- Add shipped quantity to sales order items
+ALTER TABLE sales_order_items
+ADD COLUMN IF NOT EXISTS shipped_quantity DECIMAL(18,4) NOT NULL DEFAULT 0;
+
+-- Add shipment counter to sales orders
+ALTER TABLE sales_orders
+ADD COLUMN IF NOT EXISTS shipment_count INTEGER NOT NULL DEFAULT 0;
+
+-- Orders shipped before partial shipments existed were shipped in full
+UPDATE sales_order_items soi
+SET shipped_quantity = soi.quantity
+FROM sales_orders so
+WHERE so.id = soi.order_id AND so.status IN ('SHIPPED', 'COMPLETED');
+
+UPDATE sales_orders
+SET shipment_count = 1
+WHERE status IN ('SHIPPED', 'COMPLETED');
+
+-- Add comments for the new columns
+COMMENT ON COLUMN sales_order_items.shipped_quantity IS 'Quantity already shipped, in the order unit';
+COMMENT ON COLUMN sales_orders.shipment_count IS 'Number of shipments made for the order';
+COMMENT ON COLUMN sales_orders.shipped_at IS 'When the most recent shipment of the order left the warehouse';
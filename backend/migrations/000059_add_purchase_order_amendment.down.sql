@@ -0,0 +1,6 @@
+-- Rollback: Remove amendment workflow from purchase orders
+
+-- Orders awaiting amendment approval keep their confirmed lines
+UPDATE purchase_orders SET status = 'CONFIRMED' WHERE status = 'PENDING_AMENDMENT';
+
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS pending_amendment;
@@ -0,0 +1,8 @@
+-- Migration: Add amendment workflow to purchase orders
+-- Description: Stores line changes proposed for a confirmed purchase order while the
+-- amendment awaits approval (PENDING_AMENDMENT status)
+
+ALTER TABLE purchase_orders
+ADD COLUMN IF NOT EXISTS pending_amendment JSONB;
+
+COMMENT ON COLUMN purchase_orders.pending_amendment IS 'Proposed line changes awaiting approval; NULL when no amendment is pending';
@@ -0,0 +1,12 @@
+-- Rollback: Remove sales quotes
+
+-- Quotes cannot be represented without the quote columns
+DELETE FROM sales_order_items
+WHERE order_id IN (SELECT id FROM sales_orders WHERE status IN ('QUOTE', 'EXPIRED'));
+DELETE FROM sales_orders WHERE status IN ('QUOTE', 'EXPIRED');
+
+DROP INDEX IF EXISTS idx_sales_orders_quote_expires_at;
+
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS expired_at;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS converted_at;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS quote_expires_at;
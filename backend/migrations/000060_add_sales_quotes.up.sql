@@ -0,0 +1,18 @@
+-- Migration: Add sales quotes
+-- Description: Sales orders can start as a QUOTE that is edited freely without affecting
+-- inventory, is converted into a DRAFT order when accepted, and moves to EXPIRED once its
+-- validity runs out
+
+ALTER TABLE sales_orders
+ADD COLUMN IF NOT EXISTS quote_expires_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS converted_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS expired_at TIMESTAMPTZ;
+
+-- Index for the quote expiration job
+CREATE INDEX IF NOT EXISTS idx_sales_orders_quote_expires_at
+ON sales_orders(quote_expires_at) WHERE status = 'QUOTE';
+
+-- Add comments for the new columns
+COMMENT ON COLUMN sales_orders.quote_expires_at IS 'When the quote expires if it has not been converted into an order';
+COMMENT ON COLUMN sales_orders.converted_at IS 'When the quote was converted into an order';
+COMMENT ON COLUMN sales_orders.expired_at IS 'When the quote was marked as expired';
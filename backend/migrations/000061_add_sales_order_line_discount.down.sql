@@ -0,0 +1,5 @@
+-- Rollback: Remove line discounts from sales order items
+
+ALTER TABLE sales_order_items DROP COLUMN IF EXISTS discount_amount;
+ALTER TABLE sales_order_items DROP COLUMN IF EXISTS discount_value;
+ALTER TABLE sales_order_items DROP COLUMN IF EXISTS discount_type;
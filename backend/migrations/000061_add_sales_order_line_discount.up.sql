@@ -0,0 +1,14 @@
+-- Migration: Add line discounts to sales order items
+-- Description: Each sales order line can carry a percentage or fixed amount discount.
+-- Line discounts are taken off the line amount before the order-level discount is applied.
+
+ALTER TABLE sales_order_items
+ADD COLUMN IF NOT EXISTS discount_type VARCHAR(10) NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS discount_value DECIMAL(18,4) NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS discount_amount DECIMAL(18,4) NOT NULL DEFAULT 0;
+
+-- Add comments for the new columns
+COMMENT ON COLUMN sales_order_items.discount_type IS 'Line discount type: PERCENT, AMOUNT, or empty for no discount';
+COMMENT ON COLUMN sales_order_items.discount_value IS 'Line discount percentage or fixed amount, depending on discount_type';
+COMMENT ON COLUMN sales_order_items.discount_amount IS 'Discount taken off the line amount';
+COMMENT ON COLUMN sales_order_items.amount IS 'Line amount after the line discount';
@@ -0,0 +1,4 @@
+-- Rollback: Remove batch expiry blocking on ship
+
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS allow_expired_stock;
+ALTER TABLE tenants DROP COLUMN IF EXISTS config_ship_expiry_buffer_days;
@@ -0,0 +1,15 @@
+-- Migration: Add batch expiry blocking on ship
+-- Description: Lets tenants block shipping stock from batches that expire within a number of days
+-- of the ship date, and lets sales orders explicitly allow shipping expired stock
+
+-- Add the expiry buffer to tenants (0 blocks only batches already expired on the ship date)
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS config_ship_expiry_buffer_days INTEGER NOT NULL DEFAULT 0;
+
+-- Add the expired stock override to sales_orders
+ALTER TABLE sales_orders
+ADD COLUMN IF NOT EXISTS allow_expired_stock BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Add comments for the new columns
+COMMENT ON COLUMN tenants.config_ship_expiry_buffer_days IS 'Days before expiry from which a batch can no longer be shipped';
+COMMENT ON COLUMN sales_orders.allow_expired_stock IS 'Whether the order may ship stock from expired batches';
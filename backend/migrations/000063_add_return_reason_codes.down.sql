@@ -0,0 +1,8 @@
+-- Rollback: Remove return reason codes
+
+DROP INDEX IF EXISTS idx_purchase_returns_tenant_reason_code;
+DROP INDEX IF EXISTS idx_sales_returns_tenant_reason_code;
+ALTER TABLE purchase_returns DROP CONSTRAINT IF EXISTS chk_purchase_returns_reason_code;
+ALTER TABLE sales_returns DROP CONSTRAINT IF EXISTS chk_sales_returns_reason_code;
+ALTER TABLE purchase_returns DROP COLUMN IF EXISTS reason_code;
+ALTER TABLE sales_returns DROP COLUMN IF EXISTS reason_code;
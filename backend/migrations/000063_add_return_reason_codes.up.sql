@@ -0,0 +1,29 @@
+-- Migration: Add return reason codes
+-- Description: Classifies sales and purchase returns by a fixed reason code for return analytics.
+-- The existing free-text reason column stays as an optional note.
+
+-- Existing returns are classified as OTHER
+ALTER TABLE sales_returns
+ADD COLUMN IF NOT EXISTS reason_code VARCHAR(30) NOT NULL DEFAULT 'OTHER';
+
+ALTER TABLE purchase_returns
+ADD COLUMN IF NOT EXISTS reason_code VARCHAR(30) NOT NULL DEFAULT 'OTHER';
+
+ALTER TABLE sales_returns
+ADD CONSTRAINT chk_sales_returns_reason_code CHECK (reason_code IN (
+    'DAMAGED', 'DEFECTIVE', 'WRONG_ITEM', 'QUALITY', 'NOT_AS_DESCRIBED', 'EXPIRED', 'CUSTOMER_REMORSE', 'OTHER'
+));
+
+ALTER TABLE purchase_returns
+ADD CONSTRAINT chk_purchase_returns_reason_code CHECK (reason_code IN (
+    'DAMAGED', 'DEFECTIVE', 'WRONG_ITEM', 'QUALITY', 'NOT_AS_DESCRIBED', 'EXPIRED', 'CUSTOMER_REMORSE', 'OTHER'
+));
+
+-- Indexes for the return reason report
+CREATE INDEX IF NOT EXISTS idx_sales_returns_tenant_reason_code ON sales_returns(tenant_id, reason_code);
+CREATE INDEX IF NOT EXISTS idx_purchase_returns_tenant_reason_code ON purchase_returns(tenant_id, reason_code);
+
+COMMENT ON COLUMN sales_returns.reason_code IS 'Classified return reason: DAMAGED, DEFECTIVE, WRONG_ITEM, QUALITY, NOT_AS_DESCRIBED, EXPIRED, CUSTOMER_REMORSE, OTHER';
+COMMENT ON COLUMN purchase_returns.reason_code IS 'Classified return reason: DAMAGED, DEFECTIVE, WRONG_ITEM, QUALITY, NOT_AS_DESCRIBED, EXPIRED, CUSTOMER_REMORSE, OTHER';
+COMMENT ON COLUMN sales_returns.reason IS 'Optional free-text note on the return reason';
+COMMENT ON COLUMN purchase_returns.reason IS 'Optional free-text note on the return reason';
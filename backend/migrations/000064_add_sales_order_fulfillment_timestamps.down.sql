@@ -0,0 +1,5 @@
+-- Rollback: Remove sales order fulfillment timestamps
+
+DROP INDEX IF EXISTS idx_sales_orders_tenant_confirmed_at;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS fully_shipped_at;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS first_shipped_at;
@@ -0,0 +1,24 @@
+-- Migration: Add sales order fulfillment timestamps
+-- Description: Records when the first shipment left and when the order became fully shipped,
+-- so fulfillment SLA reports can measure confirm-to-ship and ship-to-complete durations
+
+ALTER TABLE sales_orders
+ADD COLUMN IF NOT EXISTS first_shipped_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN IF NOT EXISTS fully_shipped_at TIMESTAMP WITH TIME ZONE;
+
+-- Backfill existing orders. shipped_at holds the most recent shipment, which is the full
+-- shipment for shipped and completed orders, and also the first one for single-shipment orders.
+UPDATE sales_orders
+SET fully_shipped_at = shipped_at
+WHERE shipped_at IS NOT NULL
+  AND status IN ('SHIPPED', 'COMPLETED');
+
+UPDATE sales_orders
+SET first_shipped_at = shipped_at
+WHERE shipped_at IS NOT NULL
+  AND shipment_count <= 1;
+
+CREATE INDEX IF NOT EXISTS idx_sales_orders_tenant_confirmed_at ON sales_orders(tenant_id, confirmed_at);
+
+COMMENT ON COLUMN sales_orders.first_shipped_at IS 'When the first shipment of the order left the warehouse';
+COMMENT ON COLUMN sales_orders.fully_shipped_at IS 'When the last outstanding quantity shipped and the order became SHIPPED';
@@ -0,0 +1,21 @@
+-- Rollback: Remove three-way match from account payables
+
+-- Payables blocked by a failed match become payable again
+UPDATE account_payables
+SET status = CASE WHEN paid_amount > 0 THEN 'PARTIAL' ELSE 'PENDING' END
+WHERE status = 'MATCH_FAILED';
+
+DROP INDEX IF EXISTS idx_payable_invoice_number;
+
+DROP INDEX IF EXISTS idx_payable_outstanding;
+CREATE INDEX idx_payable_outstanding ON account_payables(tenant_id, outstanding_amount) WHERE status IN ('PENDING', 'PARTIAL');
+
+ALTER TABLE account_payables DROP CONSTRAINT IF EXISTS chk_payable_status;
+ALTER TABLE account_payables
+ADD CONSTRAINT chk_payable_status CHECK (status IN ('PENDING', 'PARTIAL', 'PAID', 'REVERSED', 'CANCELLED'));
+
+ALTER TABLE account_payables
+DROP COLUMN IF EXISTS match_result,
+DROP COLUMN IF EXISTS invoice_date,
+DROP COLUMN IF EXISTS invoice_amount,
+DROP COLUMN IF EXISTS invoice_number;
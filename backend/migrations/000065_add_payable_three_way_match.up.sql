@@ -0,0 +1,25 @@
+-- Migration: Add three-way match to account payables
+-- Description: Records the supplier invoice against purchase order payables and the result of
+-- matching it with the ordered prices and received quantities. A failed match moves the payable
+-- to MATCH_FAILED, which blocks payment until the invoice matches.
+
+ALTER TABLE account_payables
+ADD COLUMN IF NOT EXISTS invoice_number VARCHAR(50),
+ADD COLUMN IF NOT EXISTS invoice_amount DECIMAL(18, 4) NOT NULL DEFAULT 0 CHECK (invoice_amount >= 0),
+ADD COLUMN IF NOT EXISTS invoice_date TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS match_result JSONB;
+
+ALTER TABLE account_payables DROP CONSTRAINT IF EXISTS chk_payable_status;
+ALTER TABLE account_payables
+ADD CONSTRAINT chk_payable_status CHECK (status IN ('PENDING', 'PARTIAL', 'PAID', 'REVERSED', 'CANCELLED', 'MATCH_FAILED'));
+
+-- Payables blocked by a failed match are still outstanding
+DROP INDEX IF EXISTS idx_payable_outstanding;
+CREATE INDEX idx_payable_outstanding ON account_payables(tenant_id, outstanding_amount) WHERE status IN ('PENDING', 'PARTIAL', 'MATCH_FAILED');
+
+CREATE INDEX IF NOT EXISTS idx_payable_invoice_number ON account_payables(tenant_id, invoice_number) WHERE invoice_number IS NOT NULL;
+
+COMMENT ON COLUMN account_payables.invoice_number IS 'Supplier invoice number recorded for the three-way match';
+COMMENT ON COLUMN account_payables.invoice_amount IS 'Amount on the supplier invoice';
+COMMENT ON COLUMN account_payables.invoice_date IS 'Date on the supplier invoice';
+COMMENT ON COLUMN account_payables.match_result IS 'Latest three-way match result (ordered price vs received quantity vs invoiced amount)';
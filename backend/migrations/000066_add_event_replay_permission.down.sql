@@ -0,0 +1,3 @@
+-- Migration: Remove event replay permission (rollback)
+
+DELETE FROM role_permissions WHERE code = 'event:replay';
@@ -0,0 +1,16 @@
+-- Migration: Add event replay permission
+-- Description: Grants event:replay, which protects POST /system/events/replay, to the ADMIN role
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'event:replay',
+    'event',
+    'replay',
+    'Admin permission for event:replay'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'event:replay'
+);
@@ -0,0 +1,3 @@
+-- Migration: Drop processed_events table (rollback)
+
+DROP TABLE IF EXISTS processed_events;
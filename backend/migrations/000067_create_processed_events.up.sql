@@ -0,0 +1,13 @@
+-- Migration: Create processed_events table
+-- Description: Records which event handler has processed which event, so that
+-- redelivered events are not processed twice by the same handler
+
+CREATE TABLE IF NOT EXISTS processed_events (
+    handler_name VARCHAR(200) NOT NULL,
+    event_id UUID NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT pk_processed_events PRIMARY KEY (handler_name, event_id)
+);
+
+COMMENT ON TABLE processed_events IS 'Processed (handler, event) pairs for exactly-once event handling';
+COMMENT ON COLUMN processed_events.handler_name IS 'Stable name of the event handler';
@@ -0,0 +1,3 @@
+-- Migration: Drop usage_report_logs table (rollback)
+
+DROP TABLE IF EXISTS usage_report_logs;
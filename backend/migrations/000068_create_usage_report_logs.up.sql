@@ -0,0 +1,32 @@
+-- Migration: Create usage_report_logs table
+-- Description: Tracks usage reported to Stripe for metered billing. The idempotency key
+-- marks usage already reported for a tenant, subscription item, usage type and period,
+-- so a billing period is never reported twice
+
+CREATE TABLE IF NOT EXISTS usage_report_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    subscription_item_id VARCHAR(255) NOT NULL,
+    usage_type VARCHAR(50) NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity >= 0),
+    timestamp TIMESTAMPTZ NOT NULL,
+    idempotency_key VARCHAR(255),
+    stripe_record_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error_message TEXT,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_usage_report_logs_status CHECK (status IN (
+        'pending', 'success', 'failed', 'retrying', 'abandoned'
+    ))
+);
+
+CREATE INDEX idx_usage_report_logs_tenant_id ON usage_report_logs(tenant_id);
+CREATE INDEX idx_usage_report_logs_status ON usage_report_logs(status);
+CREATE UNIQUE INDEX idx_usage_report_logs_idempotency_key ON usage_report_logs(idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+COMMENT ON TABLE usage_report_logs IS 'Usage reported to Stripe for metered billing';
+COMMENT ON COLUMN usage_report_logs.idempotency_key IS 'tenant:subscription_item:usage_type:period_start marker preventing duplicate reports';
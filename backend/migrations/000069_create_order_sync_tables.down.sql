@@ -0,0 +1,4 @@
+-- Migration: Drop platform order sync tables (rollback)
+
+DROP TABLE IF EXISTS platform_order_sync_records;
+DROP TABLE IF EXISTS order_sync_configs;
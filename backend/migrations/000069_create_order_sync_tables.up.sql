@@ -0,0 +1,52 @@
+-- Migration: Create platform order sync tables
+-- Description: Records orders imported from e-commerce platforms and the per-platform
+-- sync configuration, including the cursor that stops re-runs from re-importing orders
+
+CREATE TABLE IF NOT EXISTS order_sync_configs (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    platform_code VARCHAR(20) NOT NULL,
+    is_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    sync_interval_minutes INTEGER NOT NULL DEFAULT 15,
+    auto_create_customer BOOLEAN NOT NULL DEFAULT FALSE,
+    default_warehouse_id UUID,
+    default_salesperson_id UUID,
+    default_customer_id UUID,
+    default_customer_name VARCHAR(200),
+    auto_lock_stock BOOLEAN NOT NULL DEFAULT FALSE,
+    order_prefix_format VARCHAR(50),
+    status_mappings JSONB NOT NULL DEFAULT '{}',
+    order_cursor TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, platform_code)
+);
+
+CREATE TABLE IF NOT EXISTS platform_order_sync_records (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    platform_code VARCHAR(20) NOT NULL,
+    platform_order_id VARCHAR(100) NOT NULL,
+    local_order_id UUID,
+    local_order_number VARCHAR(50),
+    direction VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    platform_status VARCHAR(20),
+    error_message TEXT,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_platform_order_sync_records_status CHECK (status IN (
+        'PENDING', 'IN_PROGRESS', 'SUCCESS', 'PARTIAL', 'FAILED'
+    ))
+);
+
+CREATE UNIQUE INDEX idx_order_sync_record_platform_order
+    ON platform_order_sync_records(tenant_id, platform_code, platform_order_id);
+CREATE INDEX idx_platform_order_sync_records_local_order_id ON platform_order_sync_records(local_order_id);
+CREATE INDEX idx_platform_order_sync_records_status ON platform_order_sync_records(tenant_id, status);
+
+COMMENT ON TABLE order_sync_configs IS 'Per-tenant order sync configuration for each e-commerce platform';
+COMMENT ON COLUMN order_sync_configs.order_cursor IS 'Orders created on the platform before this time have already been pulled';
+COMMENT ON TABLE platform_order_sync_records IS 'Platform orders imported as sales orders, one row per platform order';
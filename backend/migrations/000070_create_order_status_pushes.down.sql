@@ -0,0 +1,3 @@
+-- Migration: Drop order status push retry queue (rollback)
+
+DROP TABLE IF EXISTS order_status_pushes;
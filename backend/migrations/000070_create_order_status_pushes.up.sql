@@ -0,0 +1,30 @@
+-- Migration: Create order status push retry queue
+-- Description: Order status updates (e.g. shipment confirmations) that an e-commerce
+-- platform rejected or could not be reached for, retried with exponential backoff
+
+CREATE TABLE IF NOT EXISTS order_status_pushes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    platform_code VARCHAR(20) NOT NULL,
+    platform_order_id VARCHAR(100) NOT NULL,
+    local_order_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    shipping_company VARCHAR(100),
+    tracking_number VARCHAR(100),
+    state VARCHAR(20) NOT NULL,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    max_retries INTEGER NOT NULL DEFAULT 5,
+    last_error TEXT,
+    next_retry_at TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_order_status_pushes_state CHECK (state IN ('FAILED', 'SENT', 'DEAD'))
+);
+
+CREATE INDEX idx_order_status_pushes_tenant_id ON order_status_pushes(tenant_id);
+CREATE INDEX idx_order_status_pushes_retry ON order_status_pushes(state, next_retry_at);
+CREATE INDEX idx_order_status_pushes_dead ON order_status_pushes(tenant_id, created_at) WHERE state = 'DEAD';
+
+COMMENT ON TABLE order_status_pushes IS 'Platform order status updates queued for retry after a failed push; DEAD rows exhausted their retries';
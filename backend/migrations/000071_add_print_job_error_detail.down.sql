@@ -0,0 +1,4 @@
+-- Rollback: Remove renderer diagnostics from print jobs
+
+ALTER TABLE print_jobs
+DROP COLUMN IF EXISTS error_detail;
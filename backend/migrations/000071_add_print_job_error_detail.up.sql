@@ -0,0 +1,7 @@
+-- Migration: Add renderer diagnostics to print jobs
+-- Description: Print jobs queued via POST /print/jobs render in the background. When a render
+-- fails, error_detail keeps the renderer's diagnostic output so the failure can be investigated
+-- after the request that queued it has returned.
+
+ALTER TABLE print_jobs
+ADD COLUMN IF NOT EXISTS error_detail TEXT;
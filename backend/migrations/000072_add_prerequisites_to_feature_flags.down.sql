@@ -0,0 +1,3 @@
+-- Rollback: Remove prerequisites column from feature_flags table
+
+ALTER TABLE feature_flags DROP COLUMN IF EXISTS prerequisites;
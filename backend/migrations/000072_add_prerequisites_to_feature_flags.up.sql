@@ -0,0 +1,9 @@
+-- Migration: Add prerequisites column to feature_flags table
+-- Description: A flag can depend on other flags being enabled, optionally with a specific
+-- variant. Each entry is {"flag_key": "...", "variant": "..."}; cycles are rejected when
+-- flags are saved.
+
+ALTER TABLE feature_flags
+ADD COLUMN IF NOT EXISTS prerequisites JSONB DEFAULT '[]';
+
+COMMENT ON COLUMN feature_flags.prerequisites IS 'Flags that must be enabled before this flag is evaluated';
@@ -0,0 +1,3 @@
+-- Migration: Remove customer financials permission (rollback)
+
+DELETE FROM role_permissions WHERE code = 'customer:view_financials';
@@ -0,0 +1,17 @@
+-- Migration: Add customer financials permission
+-- Description: Grants customer:view_financials, which is required to see customer
+-- balance and credit limit in customer API responses, to the ADMIN role
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'customer:view_financials',
+    'customer',
+    'view_financials',
+    'Admin permission for customer:view_financials'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'customer:view_financials'
+);
@@ -0,0 +1,3 @@
+-- Migration: Remove tenant export permission (rollback)
+
+DELETE FROM role_permissions WHERE code = 'tenant:export';
@@ -0,0 +1,17 @@
+-- Migration: Add tenant export permission
+-- Description: Grants tenant:export, which is required to export all data of a
+-- tenant (POST /identity/tenants/:id/export), to the ADMIN role
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'tenant:export',
+    'tenant',
+    'export',
+    'Admin permission for tenant:export'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'tenant:export'
+);
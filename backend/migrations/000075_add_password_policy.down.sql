@@ -0,0 +1,4 @@
+-- Rollback: Remove per-tenant password policy
+
+DROP TABLE IF EXISTS user_password_history;
+ALTER TABLE tenants DROP COLUMN IF EXISTS config_password_policy;
@@ -0,0 +1,23 @@
+-- Migration: Add per-tenant password policy
+-- Description: Lets tenants configure password length, character class, reuse and max age rules,
+-- and keeps the hashes of users' previous passwords to prevent reusing them
+
+-- Add the password policy to tenants ('{}' applies the default policy)
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS config_password_policy JSONB NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN tenants.config_password_policy IS 'Password policy: min_length, require_* character classes, history_count, max_age_days';
+
+-- Previous password hashes of users
+CREATE TABLE IF NOT EXISTS user_password_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_password_history_tenant_id ON user_password_history(tenant_id);
+CREATE INDEX idx_user_password_history_user_created ON user_password_history(user_id, created_at DESC);
+
+COMMENT ON TABLE user_password_history IS 'Bcrypt hashes of passwords users replaced, checked against the password policy history_count';
@@ -0,0 +1,4 @@
+-- Rollback: Drop daily sales fact table
+
+DROP INDEX IF EXISTS idx_sales_orders_tenant_completed_at;
+DROP TABLE IF EXISTS daily_sales_fact;
@@ -0,0 +1,29 @@
+-- Migration: Create daily sales fact table
+-- Description: Completed sales per day, product and warehouse, so sales reports can be
+-- aggregated at any of those grains without scanning orders. Populated by the report
+-- aggregation job, on sales order completion and by the backfill command.
+
+CREATE TABLE IF NOT EXISTS daily_sales_fact (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    product_id UUID NOT NULL,
+    warehouse_id UUID NOT NULL,
+    order_count BIGINT NOT NULL DEFAULT 0,
+    quantity DECIMAL(20,4) NOT NULL DEFAULT 0,
+    revenue DECIMAL(20,4) NOT NULL DEFAULT 0,
+    cost DECIMAL(20,4) NOT NULL DEFAULT 0,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uk_daily_sales_fact UNIQUE (tenant_id, date, product_id, warehouse_id)
+);
+
+CREATE INDEX idx_daily_sales_fact_tenant_product ON daily_sales_fact(tenant_id, product_id, date);
+CREATE INDEX idx_daily_sales_fact_tenant_warehouse ON daily_sales_fact(tenant_id, warehouse_id, date);
+
+-- Completed orders are looked up by completion day when facts are rebuilt
+CREATE INDEX IF NOT EXISTS idx_sales_orders_tenant_completed_at ON sales_orders(tenant_id, completed_at) WHERE status = 'COMPLETED';
+
+COMMENT ON TABLE daily_sales_fact IS 'Completed sales per day, product and warehouse, dated by order completion';
+COMMENT ON COLUMN daily_sales_fact.order_count IS 'Completed orders with a line for the product; not additive across products';
+COMMENT ON COLUMN daily_sales_fact.revenue IS 'Sum of line amounts, before order-level discounts';
+COMMENT ON COLUMN daily_sales_fact.cost IS 'Cost of goods shipped from the inventory ledger, or quantity at the current unit cost';
@@ -0,0 +1,3 @@
+-- Migration: Remove system maintenance permission (rollback)
+
+DELETE FROM role_permissions WHERE code = 'system:maintenance';
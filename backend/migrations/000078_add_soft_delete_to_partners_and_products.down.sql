@@ -0,0 +1,28 @@
+-- Rollback: Remove soft delete from customers, suppliers and products
+-- Soft-deleted rows would become live again and may share a code with a live row, so the rollback
+-- refuses to run until they have been restored or purged; nothing is deleted here.
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM customers WHERE deleted_at IS NOT NULL)
+        OR EXISTS (SELECT 1 FROM suppliers WHERE deleted_at IS NOT NULL)
+        OR EXISTS (SELECT 1 FROM products WHERE deleted_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'Cannot roll back soft delete: restore or purge soft-deleted customers, suppliers and products first';
+    END IF;
+END;
+$$;
+
+DELETE FROM role_permissions WHERE code IN ('customer:restore', 'supplier:restore', 'product:restore');
+
+DROP INDEX IF EXISTS uq_customer_tenant_code;
+ALTER TABLE customers ADD CONSTRAINT uq_customer_tenant_code UNIQUE (tenant_id, code);
+
+DROP INDEX IF EXISTS idx_supplier_tenant_code;
+CREATE UNIQUE INDEX idx_supplier_tenant_code ON suppliers(tenant_id, code);
+
+DROP INDEX IF EXISTS uq_product_tenant_code;
+ALTER TABLE products ADD CONSTRAINT uq_product_tenant_code UNIQUE (tenant_id, code);
+
+ALTER TABLE customers DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE suppliers DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_at;
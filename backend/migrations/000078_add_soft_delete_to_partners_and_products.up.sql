@@ -0,0 +1,41 @@
+-- Migration: Add soft delete to customers, suppliers and products
+-- Description: Deleting a customer, supplier or product now sets deleted_at so it can be restored.
+-- Codes only need to be unique among rows that are not deleted, so a deleted row's code can be reused.
+
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN customers.deleted_at IS 'Set when the customer is soft deleted; NULL for live customers';
+COMMENT ON COLUMN suppliers.deleted_at IS 'Set when the supplier is soft deleted; NULL for live suppliers';
+COMMENT ON COLUMN products.deleted_at IS 'Set when the product is soft deleted; NULL for live products';
+
+-- Replace the tenant/code unique constraints with unique indexes over live rows
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS uq_customer_tenant_code;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_customer_tenant_code ON customers(tenant_id, code) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_supplier_tenant_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_supplier_tenant_code ON suppliers(tenant_id, code) WHERE deleted_at IS NULL;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS uq_product_tenant_code;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_product_tenant_code ON products(tenant_id, code) WHERE deleted_at IS NULL;
+
+-- Soft-deleted rows are listed and restored by admins only
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    p.code,
+    p.resource,
+    'restore',
+    'Admin permission for ' || p.code
+FROM (VALUES
+    ('customer:restore', 'customer'),
+    ('supplier:restore', 'supplier'),
+    ('product:restore', 'product')
+) AS p(code, resource)
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = p.code
+);
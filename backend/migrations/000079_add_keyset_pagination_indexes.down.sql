@@ -0,0 +1,5 @@
+-- Migration: Remove keyset pagination indexes (rollback)
+
+DROP INDEX IF EXISTS idx_flag_audit_logs_flag_created_id;
+DROP INDEX IF EXISTS idx_receivable_tenant_created_id;
+DROP INDEX IF EXISTS idx_inv_tx_tenant_time_id;
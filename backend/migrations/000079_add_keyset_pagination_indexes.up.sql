@@ -0,0 +1,7 @@
+-- Migration: Add keyset pagination indexes
+-- Description: Cursor pagination on inventory transactions, receivables and flag audit logs
+-- seeks on (sort column, id) instead of using OFFSET. These indexes let it start at the cursor.
+
+CREATE INDEX IF NOT EXISTS idx_inv_tx_tenant_time_id ON inventory_transactions(tenant_id, transaction_date DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_receivable_tenant_created_id ON account_receivables(tenant_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_flag_audit_logs_flag_created_id ON flag_audit_logs(flag_key, created_at DESC, id DESC);
@@ -0,0 +1,9 @@
+-- Rollback: Remove warehouse capacity units and product dimensions
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_volume_check;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_weight_check;
+ALTER TABLE products DROP COLUMN IF EXISTS volume;
+ALTER TABLE products DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE warehouses DROP CONSTRAINT IF EXISTS warehouses_capacity_unit_check;
+ALTER TABLE warehouses DROP COLUMN IF EXISTS capacity_unit;
@@ -0,0 +1,22 @@
+-- Migration: Add warehouse capacity units and product dimensions
+-- Description: A warehouse's capacity can be measured in stock units, kilograms or cubic metres.
+-- Utilization sums the on-hand stock of the warehouse, weighted by each product's weight or volume.
+
+ALTER TABLE warehouses
+ADD COLUMN IF NOT EXISTS capacity_unit VARCHAR(10) NOT NULL DEFAULT 'unit';
+
+ALTER TABLE warehouses
+ADD CONSTRAINT warehouses_capacity_unit_check CHECK (capacity_unit IN ('unit', 'kg', 'm3'));
+
+COMMENT ON COLUMN warehouses.capacity_unit IS 'Unit of the capacity: unit (stock quantity), kg (weight) or m3 (volume)';
+
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS weight DECIMAL(18,4) NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS volume DECIMAL(18,6) NOT NULL DEFAULT 0;
+
+ALTER TABLE products
+ADD CONSTRAINT products_weight_check CHECK (weight >= 0),
+ADD CONSTRAINT products_volume_check CHECK (volume >= 0);
+
+COMMENT ON COLUMN products.weight IS 'Weight of one base unit in kilograms';
+COMMENT ON COLUMN products.volume IS 'Volume of one base unit in cubic metres';
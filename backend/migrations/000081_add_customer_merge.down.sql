@@ -0,0 +1,16 @@
+-- Rollback: Remove customer merge tracking
+-- Merged customers are set back to inactive so the original status constraint holds
+
+DELETE FROM role_permissions WHERE code = 'customer:merge';
+
+DROP INDEX IF EXISTS idx_customers_merged_into;
+
+ALTER TABLE customers
+DROP COLUMN IF EXISTS merged_at,
+DROP COLUMN IF EXISTS merged_into_id;
+
+UPDATE customers SET status = 'inactive' WHERE status = 'merged';
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS chk_customer_status;
+ALTER TABLE customers
+ADD CONSTRAINT chk_customer_status CHECK (status IN ('active', 'inactive', 'suspended'));
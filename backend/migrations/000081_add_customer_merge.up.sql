@@ -0,0 +1,31 @@
+-- Migration: Add customer merge tracking
+-- Description: Duplicate customers are merged into a surviving customer instead of being deleted.
+-- The merged record keeps its history and points to the customer that replaced it.
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS chk_customer_status;
+ALTER TABLE customers
+ADD CONSTRAINT chk_customer_status CHECK (status IN ('active', 'inactive', 'suspended', 'merged'));
+
+ALTER TABLE customers
+ADD COLUMN IF NOT EXISTS merged_into_id UUID REFERENCES customers(id),
+ADD COLUMN IF NOT EXISTS merged_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_customers_merged_into ON customers(merged_into_id) WHERE merged_into_id IS NOT NULL;
+
+COMMENT ON COLUMN customers.merged_into_id IS 'Surviving customer this duplicate was merged into';
+COMMENT ON COLUMN customers.merged_at IS 'When the customer was merged into another customer';
+
+-- Merging customers moves documents between customers and cannot be undone, so it is admin only
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'customer:merge',
+    'customer',
+    'merge',
+    'Admin permission for customer:merge'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'customer:merge'
+);
@@ -0,0 +1,13 @@
+-- Rollback: Remove customer overdraft limit
+-- Overdrawn customers must be settled before rolling back, otherwise the restored constraints fail
+
+ALTER TABLE balance_transactions
+ADD CONSTRAINT chk_balance_tx_balance_before CHECK (balance_before >= 0),
+ADD CONSTRAINT chk_balance_tx_balance_after CHECK (balance_after >= 0);
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS chk_customer_balance;
+ALTER TABLE customers
+ADD CONSTRAINT chk_customer_balance CHECK (balance >= 0);
+
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS chk_customer_overdraft_limit;
+ALTER TABLE customers DROP COLUMN IF EXISTS overdraft_limit;
@@ -0,0 +1,20 @@
+-- Migration: Add customer overdraft limit
+-- Description: Customers may be allowed to spend past a zero balance up to a per-customer limit.
+-- Prepaid customers keep the default limit of zero, so their balance still cannot go negative.
+
+ALTER TABLE customers
+ADD COLUMN IF NOT EXISTS overdraft_limit DECIMAL(18,4) NOT NULL DEFAULT 0;
+
+ALTER TABLE customers
+ADD CONSTRAINT chk_customer_overdraft_limit CHECK (overdraft_limit >= 0);
+
+-- The balance may now be negative, but never further than the customer's overdraft limit
+ALTER TABLE customers DROP CONSTRAINT IF EXISTS chk_customer_balance;
+ALTER TABLE customers
+ADD CONSTRAINT chk_customer_balance CHECK (balance >= -overdraft_limit);
+
+-- Ledger entries record overdrawn balances as they happened
+ALTER TABLE balance_transactions DROP CONSTRAINT IF EXISTS chk_balance_tx_balance_before;
+ALTER TABLE balance_transactions DROP CONSTRAINT IF EXISTS chk_balance_tx_balance_after;
+
+COMMENT ON COLUMN customers.overdraft_limit IS 'How far the balance may go below zero (0 for prepaid customers)';
@@ -0,0 +1,10 @@
+-- Rollback: Remove spend-based customer level upgrades
+
+ALTER TABLE customers DROP COLUMN IF EXISTS level_period_start;
+
+ALTER TABLE customer_levels DROP CONSTRAINT IF EXISTS chk_customer_level_spend_window;
+ALTER TABLE customer_levels DROP CONSTRAINT IF EXISTS chk_customer_level_upgrade_threshold;
+
+ALTER TABLE customer_levels
+DROP COLUMN IF EXISTS spend_window_days,
+DROP COLUMN IF EXISTS upgrade_threshold;
@@ -0,0 +1,19 @@
+-- Migration: Add spend-based customer level upgrades
+-- Description: Level definitions can promote customers automatically once their spend over a
+-- rolling window reaches a threshold. A zero threshold keeps the level assigned manually only.
+
+ALTER TABLE customer_levels
+ADD COLUMN IF NOT EXISTS upgrade_threshold DECIMAL(18,4) NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS spend_window_days INTEGER NOT NULL DEFAULT 365;
+
+ALTER TABLE customer_levels
+ADD CONSTRAINT chk_customer_level_upgrade_threshold CHECK (upgrade_threshold >= 0),
+ADD CONSTRAINT chk_customer_level_spend_window CHECK (spend_window_days > 0);
+
+-- Customers keep a spend-based level until the period that started with it ends
+ALTER TABLE customers
+ADD COLUMN IF NOT EXISTS level_period_start TIMESTAMPTZ;
+
+COMMENT ON COLUMN customer_levels.upgrade_threshold IS 'Qualifying spend that promotes a customer to this level (0 = manual only)';
+COMMENT ON COLUMN customer_levels.spend_window_days IS 'Rolling window in days for the qualifying spend and the period a customer keeps the level';
+COMMENT ON COLUMN customers.level_period_start IS 'Start of the period the customer keeps its spend-based level';
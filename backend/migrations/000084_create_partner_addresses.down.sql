@@ -0,0 +1,6 @@
+-- Rollback: Drop partner address book
+
+ALTER TABLE sales_orders
+DROP COLUMN IF EXISTS shipping_address_id;
+
+DROP TABLE IF EXISTS partner_addresses;
@@ -0,0 +1,37 @@
+-- Migration: Create partner address book
+-- Description: Customers and suppliers keep several billing and shipping addresses, one default per
+-- type. The single address on customers and suppliers stays as their registered address.
+-- Sales orders can reference the customer's shipping address the goods are delivered to.
+
+CREATE TABLE IF NOT EXISTS partner_addresses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    owner_type VARCHAR(20) NOT NULL,
+    owner_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    label VARCHAR(100),
+    contact_name VARCHAR(100),
+    phone VARCHAR(50),
+    province VARCHAR(100),
+    city VARCHAR(100),
+    detail VARCHAR(500) NOT NULL,
+    postal_code VARCHAR(20),
+    country VARCHAR(100) DEFAULT '中国',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_partner_address_owner_type CHECK (owner_type IN ('customer', 'supplier')),
+    CONSTRAINT chk_partner_address_type CHECK (type IN ('billing', 'shipping'))
+);
+
+CREATE INDEX idx_partner_address_owner ON partner_addresses(tenant_id, owner_type, owner_id);
+
+-- At most one default address per owner and type; a new default clears the previous one first
+CREATE UNIQUE INDEX uk_partner_address_default ON partner_addresses(tenant_id, owner_type, owner_id, type) WHERE is_default;
+
+ALTER TABLE sales_orders
+ADD COLUMN IF NOT EXISTS shipping_address_id UUID REFERENCES partner_addresses(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE partner_addresses IS 'Billing and shipping addresses of customers and suppliers';
+COMMENT ON COLUMN partner_addresses.is_default IS 'Default address of its type for the owner';
+COMMENT ON COLUMN sales_orders.shipping_address_id IS 'Customer shipping address the order is delivered to, NULL for the default';
@@ -0,0 +1,6 @@
+-- Rollback: Remove inventory cost adjustment transactions
+-- PostgreSQL cannot drop enum values, so the transaction and source types stay defined;
+-- the cost adjustments themselves are removed.
+
+DELETE FROM inventory_transactions
+WHERE transaction_type IN ('COST_INCREASE', 'COST_DECREASE');
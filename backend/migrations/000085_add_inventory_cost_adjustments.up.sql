@@ -0,0 +1,11 @@
+-- Migration: Add inventory cost adjustment transactions
+-- Description: Cost adjustments change the cost of an earlier stock movement without moving stock.
+-- A receipt cost correction adjusts a receipt (COST_CORRECTION), and a cost layer recalculation
+-- restates the cost of the issues that followed it (COST_RECALCULATION). The adjusted transaction
+-- is referenced by source_line_id.
+
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'COST_INCREASE';
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'COST_DECREASE';
+
+ALTER TYPE source_type ADD VALUE IF NOT EXISTS 'COST_CORRECTION';
+ALTER TYPE source_type ADD VALUE IF NOT EXISTS 'COST_RECALCULATION';
@@ -0,0 +1,5 @@
+-- Rollback: Drop tenant strategy configurations
+
+DELETE FROM role_permissions WHERE code = 'tenant:update';
+
+DROP TABLE IF EXISTS tenant_strategy_configs;
@@ -0,0 +1,32 @@
+-- Migration: Create tenant strategy configurations
+-- Description: Tenants can choose their own cost, pricing, payment allocation and finance
+-- reconciliation strategies instead of the global defaults of the strategy registry.
+-- An empty strategy keeps the global default. Grants tenant:update, which is required to
+-- change them (PUT /system/strategies/tenant), to the ADMIN role.
+
+CREATE TABLE IF NOT EXISTS tenant_strategy_configs (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    cost_strategy VARCHAR(50) NOT NULL DEFAULT '',
+    pricing_strategy VARCHAR(50) NOT NULL DEFAULT '',
+    allocation_strategy VARCHAR(50) NOT NULL DEFAULT '',
+    reconciliation_strategy VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE tenant_strategy_configs IS 'Strategies chosen by a tenant in place of the global defaults';
+COMMENT ON COLUMN tenant_strategy_configs.reconciliation_strategy IS 'Finance reconciliation strategy type (FIFO, LIFO, LARGEST_FIRST), empty for the default';
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'tenant:update',
+    'tenant',
+    'update',
+    'Admin permission for tenant:update'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'tenant:update'
+);
@@ -0,0 +1,3 @@
+-- Rollback: Remove product price tiers
+
+ALTER TABLE products DROP COLUMN IF EXISTS price_tiers;
@@ -0,0 +1,9 @@
+-- Migration: Add product price tiers
+-- Description: Products can sell at lower unit prices for larger quantities. Each tier is
+-- {"min_quantity": N, "unit_price": P}: ordering at least N sells at P. Tiers are stored by
+-- ascending minimum quantity and used by the tiered pricing strategy.
+
+ALTER TABLE products
+ADD COLUMN IF NOT EXISTS price_tiers JSONB NOT NULL DEFAULT '[]';
+
+COMMENT ON COLUMN products.price_tiers IS 'Quantity breaks by ascending minimum quantity: [{"min_quantity": N, "unit_price": P}]';
@@ -0,0 +1,3 @@
+-- Migration: Remove platform admin permission (rollback)
+
+DELETE FROM role_permissions WHERE code = 'platform:admin';
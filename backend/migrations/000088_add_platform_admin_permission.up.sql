@@ -0,0 +1,18 @@
+-- Migration: Add platform admin permission
+-- Description: Grants platform:admin to the ADMIN role of the default (platform) tenant.
+-- Platform administrators may act on tenants other than their own, e.g. export the data
+-- of any tenant. The permission cannot be assigned to roles through the role API.
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'platform:admin',
+    'platform',
+    'admin',
+    'Platform administrator permission'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'platform:admin'
+);
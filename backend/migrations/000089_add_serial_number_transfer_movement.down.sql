@@ -0,0 +1,9 @@
+-- Migration: Remove transfer movements from serial number history (rollback)
+-- Transfer movements are history and are kept; the rollback fails while any exist.
+
+ALTER TABLE serial_number_movements
+    DROP CONSTRAINT IF EXISTS serial_number_movements_movement_type_check;
+
+ALTER TABLE serial_number_movements
+    ADD CONSTRAINT serial_number_movements_movement_type_check
+    CHECK (movement_type IN ('RECEIVED', 'SHIPPED', 'RETURNED'));
@@ -0,0 +1,10 @@
+-- Migration: Add transfer movements to serial number history
+-- Description: Serialized units moved between warehouses by a stock transfer are recorded
+-- as TRANSFERRED movements.
+
+ALTER TABLE serial_number_movements
+    DROP CONSTRAINT IF EXISTS serial_number_movements_movement_type_check;
+
+ALTER TABLE serial_number_movements
+    ADD CONSTRAINT serial_number_movements_movement_type_check
+    CHECK (movement_type IN ('RECEIVED', 'SHIPPED', 'RETURNED', 'TRANSFERRED'));
@@ -0,0 +1,3 @@
+-- Rollback: Remove report aggregate permission
+
+DELETE FROM role_permissions WHERE code = 'report:aggregate';
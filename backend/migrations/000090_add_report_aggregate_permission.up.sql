@@ -0,0 +1,17 @@
+-- Migration: Add report aggregate permission
+-- Description: Manually triggering report aggregation runs requires report:aggregate,
+-- granted to the ADMIN role of the default tenant.
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'report:aggregate',
+    'report',
+    'aggregate',
+    'Admin permission for report:aggregate'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'report:aggregate'
+);
@@ -0,0 +1,3 @@
+-- Migration: Remove sales order credit override permission (rollback)
+
+DELETE FROM role_permissions WHERE code = 'sales_order:approve_credit_override';
@@ -0,0 +1,17 @@
+-- Migration: Add sales order credit override permission
+-- Description: Grants sales_order:approve_credit_override, which is required to let a
+-- sales order ship beyond the customer's credit limit, to the ADMIN role
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT
+    '00000000-0000-0000-0000-000000000010'::uuid,  -- ADMIN role ID
+    '00000000-0000-0000-0000-000000000001'::uuid,  -- Default tenant ID
+    'sales_order:approve_credit_override',
+    'sales_order',
+    'approve_credit_override',
+    'Admin permission for sales_order:approve_credit_override'
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_permissions rp
+    WHERE rp.role_id = '00000000-0000-0000-0000-000000000010'::uuid
+    AND rp.code = 'sales_order:approve_credit_override'
+);
@@ -0,0 +1,24 @@
+-- Rollback: Key processed_events by (handler_name, event_id) again
+-- Only per-handler marks can be split back into a handler and an event ID
+
+DELETE FROM processed_events
+WHERE event_key !~ ':[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$';
+
+ALTER TABLE processed_events
+ADD COLUMN handler_name VARCHAR(200),
+ADD COLUMN event_id UUID;
+UPDATE processed_events
+SET handler_name = left(event_key, length(event_key) - 37),
+    event_id = right(event_key, 36)::uuid;
+
+ALTER TABLE processed_events DROP CONSTRAINT pk_processed_events;
+ALTER TABLE processed_events
+DROP COLUMN event_key,
+DROP COLUMN expires_at;
+
+ALTER TABLE processed_events ALTER COLUMN handler_name SET NOT NULL;
+ALTER TABLE processed_events ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE processed_events ADD CONSTRAINT pk_processed_events PRIMARY KEY (handler_name, event_id);
+
+COMMENT ON TABLE processed_events IS 'Processed (handler, event) pairs for exactly-once event handling';
+COMMENT ON COLUMN processed_events.handler_name IS 'Stable name of the event handler';
@@ -0,0 +1,19 @@
+-- Migration: Key processed_events by idempotency key
+-- Description: processed_events backs the idempotency store of event handlers. Marks are
+-- keyed by "<handler>:<event ID>" and may expire, like the other idempotency stores.
+
+ALTER TABLE processed_events ADD COLUMN event_key VARCHAR(300);
+UPDATE processed_events SET event_key = handler_name || ':' || event_id::text;
+
+ALTER TABLE processed_events DROP CONSTRAINT pk_processed_events;
+ALTER TABLE processed_events
+DROP COLUMN handler_name,
+DROP COLUMN event_id,
+ADD COLUMN expires_at TIMESTAMPTZ;
+
+ALTER TABLE processed_events ALTER COLUMN event_key SET NOT NULL;
+ALTER TABLE processed_events ADD CONSTRAINT pk_processed_events PRIMARY KEY (event_key);
+
+COMMENT ON TABLE processed_events IS 'Idempotency marks of processed events for exactly-once event handling';
+COMMENT ON COLUMN processed_events.event_key IS 'Idempotency key, "<handler>:<event ID>" for per-handler marks';
+COMMENT ON COLUMN processed_events.expires_at IS 'When the mark expires; NULL keeps it forever';
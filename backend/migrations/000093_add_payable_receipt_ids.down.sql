@@ -0,0 +1,4 @@
+-- Rollback: Stop tracking the receipts accumulated into a payable
+
+ALTER TABLE account_payables
+DROP COLUMN IF EXISTS receipt_ids;
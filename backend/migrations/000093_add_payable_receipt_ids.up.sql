@@ -0,0 +1,9 @@
+-- Migration: Track the receipts accumulated into a payable
+-- Description: A purchase order's payable accumulates the amount of each receipt of goods.
+-- Recording the receipt (event) IDs lets a redelivered receipt event be recognised and
+-- skipped instead of increasing the payable twice.
+
+ALTER TABLE account_payables
+ADD COLUMN IF NOT EXISTS receipt_ids JSONB NOT NULL DEFAULT '[]';
+
+COMMENT ON COLUMN account_payables.receipt_ids IS 'IDs of the receipt events whose amount is included in the payable';
@@ -0,0 +1,5 @@
+-- Rollback: Stop keying sales order receivables on their shipment
+
+DROP INDEX IF EXISTS uq_receivable_sales_order_shipment;
+
+ALTER TABLE account_receivables DROP COLUMN IF EXISTS shipment_number;
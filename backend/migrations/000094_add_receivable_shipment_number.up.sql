@@ -0,0 +1,22 @@
+-- Migration: Key sales order receivables on their shipment
+-- Description: Each shipment of a sales order creates its own receivable. Recording the shipment
+-- number lets a redelivered shipment event find its own receivable, whatever order shipments arrive in.
+
+ALTER TABLE account_receivables
+ADD COLUMN IF NOT EXISTS shipment_number INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN account_receivables.shipment_number IS 'Shipment of the source sales order billed by the receivable; 0 if not billed per shipment';
+
+-- Existing sales order receivables were created one per shipment, in shipment order
+UPDATE account_receivables ar
+SET shipment_number = numbered.shipment_number
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY tenant_id, source_id ORDER BY created_at, id) AS shipment_number
+    FROM account_receivables
+    WHERE source_type = 'SALES_ORDER'
+) numbered
+WHERE ar.id = numbered.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_receivable_sales_order_shipment
+ON account_receivables(tenant_id, source_id, shipment_number)
+WHERE source_type = 'SALES_ORDER' AND shipment_number > 0;
@@ -0,0 +1,3 @@
+-- Rollback: Stop keeping the list price of sales order lines
+
+ALTER TABLE sales_order_items DROP COLUMN IF EXISTS list_price;
@@ -0,0 +1,10 @@
+-- Migration: Keep the list price of sales order lines
+-- Description: A line's unit price can be a quantity-break (tier) price. Keeping the price the line
+-- is priced from lets it be re-priced when its quantity changes, so a tier price no longer applies
+-- once the quantity drops below the tier.
+-- Existing lines keep 0 (unknown): they are re-priced from the product's selling price.
+
+ALTER TABLE sales_order_items
+ADD COLUMN IF NOT EXISTS list_price DECIMAL(18,4) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN sales_order_items.list_price IS 'Unit price of the line before quantity breaks; 0 if not recorded';
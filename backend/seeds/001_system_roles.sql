@@ -0,0 +1,25 @@
+-- Seed: System roles
+-- Description: Ensures every tenant has the built-in system roles, and grants each
+-- tenant's ADMIN role the permissions held by the default tenant's ADMIN role
+
+INSERT INTO roles (tenant_id, code, name, description, is_system_role, sort_order)
+SELECT t.id, r.code, r.name, r.description, TRUE, r.sort_order
+FROM tenants t
+CROSS JOIN (VALUES
+    ('ADMIN', 'System Administrator', 'Full system access', 1),
+    ('MANAGER', 'Manager', 'Management access', 2),
+    ('SALES', 'Sales', 'Sales operations access', 3),
+    ('PURCHASER', 'Purchaser', 'Purchase operations access', 4),
+    ('WAREHOUSE', 'Warehouse Staff', 'Warehouse operations access', 5),
+    ('CASHIER', 'Cashier', 'Payment operations access', 6),
+    ('ACCOUNTANT', 'Accountant', 'Finance operations access', 7)
+) AS r(code, name, description, sort_order)
+ON CONFLICT (tenant_id, code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, tenant_id, code, resource, action, description)
+SELECT r.id, r.tenant_id, p.code, p.resource, p.action, p.description
+FROM roles r
+JOIN role_permissions p ON p.role_id = '00000000-0000-0000-0000-000000000010'
+WHERE r.code = 'ADMIN'
+  AND r.is_system_role = TRUE
+ON CONFLICT (role_id, code) DO NOTHING;
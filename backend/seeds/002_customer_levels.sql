@@ -0,0 +1,16 @@
+-- Seed: Default customer levels
+-- Description: Gives every tenant without customer levels the standard five tiers.
+-- Tenants that already defined levels are left alone so their discounts are kept.
+
+INSERT INTO customer_levels (tenant_id, code, name, discount_rate, sort_order, is_default, is_active, description)
+SELECT t.id, l.code, l.name, l.discount_rate, l.sort_order, l.is_default, TRUE, l.description
+FROM tenants t
+CROSS JOIN (VALUES
+    ('normal', '普通会员', 0.0000, 0, TRUE, '普通会员，无折扣'),
+    ('silver', '银卡会员', 0.0300, 1, FALSE, '银卡会员，享受3%折扣'),
+    ('gold', '金卡会员', 0.0500, 2, FALSE, '金卡会员，享受5%折扣'),
+    ('platinum', '白金会员', 0.0800, 3, FALSE, '白金会员，享受8%折扣'),
+    ('vip', 'VIP会员', 0.1000, 4, FALSE, 'VIP会员，享受10%折扣')
+) AS l(code, name, discount_rate, sort_order, is_default, description)
+WHERE NOT EXISTS (SELECT 1 FROM customer_levels cl WHERE cl.tenant_id = t.id)
+ON CONFLICT (tenant_id, code) DO NOTHING;
@@ -0,0 +1,8 @@
+-- Seed: Default warehouse
+-- Description: Creates a default warehouse for every tenant that has none
+
+INSERT INTO warehouses (tenant_id, code, name, type, status, is_default, sort_order)
+SELECT t.id, 'DEFAULT', '默认仓库', 'physical', 'active', TRUE, 0
+FROM tenants t
+WHERE NOT EXISTS (SELECT 1 FROM warehouses w WHERE w.tenant_id = t.id AND w.is_default = TRUE)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,140 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	partnerapp "github.com/erp/backend/internal/application/partner"
+	"github.com/erp/backend/internal/domain/finance"
+	"github.com/erp/backend/internal/domain/partner"
+	"github.com/erp/backend/internal/domain/shared"
+	"github.com/erp/backend/internal/domain/shared/valueobject"
+	"github.com/erp/backend/internal/domain/trade"
+	"github.com/erp/backend/internal/infrastructure/persistence"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCustomerMerge_Integration merges a duplicate customer against a real database and
+// checks that its orders, receivables and balance move to the surviving customer
+func TestCustomerMerge_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	testDB := NewTestDB(t)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	testDB.CreateTestTenantWithUUID(tenantID)
+
+	customerRepo := persistence.NewGormCustomerRepository(testDB.DB)
+	balanceTxRepo := persistence.NewGormBalanceTransactionRepository(testDB.DB)
+	salesOrderRepo := persistence.NewGormSalesOrderRepository(testDB.DB)
+	receivableRepo := persistence.NewGormAccountReceivableRepository(testDB.DB)
+
+	service := partnerapp.NewCustomerService(customerRepo)
+	service.SetTransactionScope(persistence.NewGormPartnerTransactionScope(testDB.DB))
+
+	newCustomer := func(code, name string, balance int64) *partner.Customer {
+		t.Helper()
+		customer, err := partner.NewOrganizationCustomer(tenantID, code, name)
+		require.NoError(t, err)
+		require.NoError(t, customerRepo.Save(ctx, customer))
+		if balance > 0 {
+			before := customer.Balance
+			require.NoError(t, customer.AddBalance(decimal.NewFromInt(balance)))
+			require.NoError(t, customerRepo.Save(ctx, customer))
+			tx, err := partner.NewBalanceTransaction(tenantID, customer.ID, partner.BalanceTransactionTypeRecharge,
+				decimal.NewFromInt(balance), before, customer.Balance, partner.BalanceSourceTypeManual)
+			require.NoError(t, err)
+			require.NoError(t, balanceTxRepo.Create(ctx, tx))
+		}
+		return customer
+	}
+
+	surviving := newCustomer("ACME", "Acme Corp", 300)
+	duplicate := newCustomer("ACME-DUP", "ACME Corporation", 200)
+
+	for _, number := range []string{"SO-MERGE-001", "SO-MERGE-002"} {
+		order, err := trade.NewSalesOrder(tenantID, number, duplicate.ID, duplicate.Name)
+		require.NoError(t, err)
+		require.NoError(t, salesOrderRepo.Save(ctx, order))
+	}
+	receivable, err := finance.NewAccountReceivable(tenantID, "AR-MERGE-001", duplicate.ID, duplicate.Name,
+		finance.SourceTypeManual, uuid.New(), "MANUAL-001", valueobject.NewMoneyCNY(decimal.NewFromInt(500)), nil)
+	require.NoError(t, err)
+	require.NoError(t, receivableRepo.Save(ctx, receivable))
+
+	result, err := service.Merge(ctx, tenantID, surviving.ID, duplicate.ID)
+	require.NoError(t, err)
+
+	t.Run("orders and receivables move to the surviving customer", func(t *testing.T) {
+		assert.Equal(t, int64(2), result.SalesOrders)
+		assert.Equal(t, int64(1), result.Receivables)
+
+		orders, err := salesOrderRepo.FindByCustomer(ctx, tenantID, surviving.ID, shared.Filter{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Len(t, orders, 2)
+		for _, order := range orders {
+			assert.Equal(t, "Acme Corp", order.CustomerName)
+		}
+		left, err := salesOrderRepo.CountByCustomer(ctx, tenantID, duplicate.ID)
+		require.NoError(t, err)
+		assert.Zero(t, left)
+
+		movedReceivable, err := receivableRepo.FindByID(ctx, receivable.ID)
+		require.NoError(t, err)
+		assert.Equal(t, surviving.ID, movedReceivable.CustomerID)
+	})
+
+	t.Run("balances combine", func(t *testing.T) {
+		assert.True(t, result.BalanceTransferred.Equal(decimal.NewFromInt(200)))
+
+		found, err := customerRepo.FindByIDForTenant(ctx, tenantID, surviving.ID)
+		require.NoError(t, err)
+		assert.True(t, found.Balance.Equal(decimal.NewFromInt(500)), "got %s", found.Balance)
+
+		// Both recharges plus the merge adjustment now belong to the survivor
+		txs, total, err := balanceTxRepo.FindByCustomerID(ctx, tenantID, surviving.ID, partner.BalanceTransactionFilter{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		adjustments := 0
+		for _, tx := range txs {
+			if tx.TransactionType == partner.BalanceTransactionTypeAdjustment {
+				adjustments++
+				assert.True(t, tx.BalanceBefore.Equal(decimal.NewFromInt(300)))
+				assert.True(t, tx.BalanceAfter.Equal(decimal.NewFromInt(500)))
+			}
+		}
+		assert.Equal(t, 1, adjustments)
+	})
+
+	t.Run("duplicate is kept as merged", func(t *testing.T) {
+		found, err := customerRepo.FindByIDForTenant(ctx, tenantID, duplicate.ID)
+		require.NoError(t, err)
+		assert.Equal(t, partner.CustomerStatusMerged, found.Status)
+		require.NotNil(t, found.MergedIntoID)
+		assert.Equal(t, surviving.ID, *found.MergedIntoID)
+		assert.True(t, found.Balance.IsZero())
+	})
+
+	t.Run("rejects merging into itself and across tenants", func(t *testing.T) {
+		_, err := service.Merge(ctx, tenantID, surviving.ID, surviving.ID)
+		assert.Error(t, err)
+
+		otherTenant := uuid.New()
+		testDB.CreateTestTenantWithUUID(otherTenant)
+		foreign, err := partner.NewOrganizationCustomer(otherTenant, "ACME", "Acme Corp")
+		require.NoError(t, err)
+		require.NoError(t, customerRepo.Save(ctx, foreign))
+
+		_, err = service.Merge(ctx, tenantID, surviving.ID, foreign.ID)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+
+		unchanged, err := customerRepo.FindByIDForTenant(ctx, otherTenant, foreign.ID)
+		require.NoError(t, err)
+		assert.Equal(t, partner.CustomerStatusActive, unchanged.Status)
+	})
+}
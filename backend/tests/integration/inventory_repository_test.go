@@ -402,3 +402,96 @@ func TestInventoryRepository_ConcurrentUpdates(t *testing.T) {
 	require.NoError(t, err)
 	assert.Greater(t, updated.Version, item2.Version)
 }
+
+// TestInventoryTransactionRepository_KeysetPagination checks that cursor pages stay stable
+// when new transactions are recorded between page fetches, where OFFSET pages would repeat rows
+func TestInventoryTransactionRepository_KeysetPagination(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	testDB := NewTestDB(t)
+	itemRepo := persistence.NewGormInventoryItemRepository(testDB.DB)
+	txRepo := persistence.NewGormInventoryTransactionRepository(testDB.DB)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	warehouseID := uuid.New()
+	productID := uuid.New()
+
+	testDB.CreateTestTenantWithUUID(tenantID)
+	testDB.CreateTestWarehouse(tenantID, warehouseID)
+	testDB.CreateTestProduct(tenantID, productID)
+
+	item, err := inventory.NewInventoryItem(tenantID, warehouseID, productID)
+	require.NoError(t, err)
+	require.NoError(t, itemRepo.Save(ctx, item))
+
+	record := func(date time.Time) uuid.UUID {
+		tx, err := inventory.NewInventoryTransaction(
+			tenantID, item.ID, warehouseID, productID,
+			inventory.TransactionTypeInbound,
+			decimal.NewFromInt(1), decimal.NewFromInt(10),
+			decimal.Zero, decimal.NewFromInt(1),
+			inventory.SourceTypeManualAdjustment, "keyset-test",
+		)
+		require.NoError(t, err)
+		tx.WithTransactionDate(date)
+		require.NoError(t, txRepo.Create(ctx, tx))
+		return tx.ID
+	}
+
+	// Six transactions, two of them sharing a timestamp so the id tie-break is exercised
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	original := make(map[uuid.UUID]bool)
+	for i := 0; i < 5; i++ {
+		original[record(base.Add(time.Duration(i)*time.Minute))] = true
+	}
+	original[record(base.Add(2*time.Minute))] = true
+
+	const pageSize = 3
+	filter := shared.Filter{PageSize: pageSize, OrderDir: "desc", Keyset: true}
+
+	page1, err := txRepo.FindForTenant(ctx, tenantID, filter)
+	require.NoError(t, err)
+	page1, nextCursor := shared.NextCursor(page1, pageSize, filter.OrderDir, func(tx inventory.InventoryTransaction) (time.Time, uuid.UUID) {
+		return tx.TransactionDate, tx.ID
+	})
+	require.Len(t, page1, pageSize)
+	require.NotEmpty(t, nextCursor)
+
+	// New transactions arrive while the client is reading page 1
+	record(base.Add(10 * time.Minute))
+	record(base.Add(11 * time.Minute))
+
+	after, err := shared.DecodeCursor(nextCursor)
+	require.NoError(t, err)
+	filter.After = after
+	page2, err := txRepo.FindForTenant(ctx, tenantID, filter)
+	require.NoError(t, err)
+	page2, nextCursor = shared.NextCursor(page2, pageSize, filter.OrderDir, func(tx inventory.InventoryTransaction) (time.Time, uuid.UUID) {
+		return tx.TransactionDate, tx.ID
+	})
+	require.Len(t, page2, pageSize)
+	assert.Empty(t, nextCursor, "the original six rows fit in two pages")
+
+	seen := make(map[uuid.UUID]bool)
+	for _, tx := range append(page1, page2...) {
+		assert.False(t, seen[tx.ID], "transaction %s returned twice", tx.ID)
+		assert.True(t, original[tx.ID], "transaction %s was inserted after paging began", tx.ID)
+		seen[tx.ID] = true
+	}
+	assert.Len(t, seen, len(original))
+
+	// Offset pagination over the same data repeats rows pushed down by the inserts
+	offsetPage2, err := txRepo.FindForTenant(ctx, tenantID, shared.Filter{Page: 2, PageSize: pageSize, OrderBy: "transaction_date", OrderDir: "desc"})
+	require.NoError(t, err)
+	repeated := 0
+	for _, tx := range offsetPage2 {
+		for _, prev := range page1 {
+			if tx.ID == prev.ID {
+				repeated++
+			}
+		}
+	}
+	assert.Positive(t, repeated)
+}
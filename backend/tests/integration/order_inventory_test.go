@@ -14,12 +14,15 @@ import (
 	"github.com/erp/backend/internal/domain/shared"
 	"github.com/erp/backend/internal/domain/shared/valueobject"
 	domaintrade "github.com/erp/backend/internal/domain/trade"
+	"github.com/erp/backend/internal/infrastructure/event"
 	"github.com/erp/backend/internal/infrastructure/persistence"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // OrderInventoryTestSetup provides test infrastructure for order-inventory integration tests
@@ -1071,3 +1074,79 @@ func TestOrderInventory_LockExpiry(t *testing.T) {
 			"Lock expiry should be approximately 30 minutes from now, got diff: %v", timeDiff)
 	})
 }
+
+func TestOrderInventory_LockExpiryNotifiesSalesOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	setup := NewOrderInventoryTestSetup(t)
+	ctx := context.Background()
+	salesOrderRepo := persistence.NewGormSalesOrderRepository(setup.DB.DB)
+
+	t.Run("expired lock releases stock and warns about the confirmed order", func(t *testing.T) {
+		item := setup.CreateInventoryWithStock(t, 100)
+
+		// Confirmed sales order holding a 20 unit reservation
+		customerID := uuid.New()
+		setup.DB.CreateTestCustomer(setup.TenantID, customerID)
+		order, err := domaintrade.NewSalesOrder(setup.TenantID, "SO-2024-EXPIRY-002", customerID, "Test Customer")
+		require.NoError(t, err)
+		_, err = order.AddItem(setup.ProductID, "Test Product", "PROD-001", "pcs", "pcs", decimal.NewFromInt(20), decimal.NewFromInt(1), valueobject.NewMoneyCNY(decimal.NewFromInt(10)))
+		require.NoError(t, err)
+		require.NoError(t, order.SetWarehouse(setup.WarehouseID))
+		require.NoError(t, order.Confirm())
+		require.NoError(t, salesOrderRepo.Save(ctx, order))
+
+		confirmEvent := &domaintrade.SalesOrderConfirmedEvent{
+			BaseDomainEvent: shared.NewBaseDomainEvent(
+				domaintrade.EventTypeSalesOrderConfirmed,
+				domaintrade.AggregateTypeSalesOrder,
+				order.ID,
+				setup.TenantID,
+			),
+			OrderID:      order.ID,
+			OrderNumber:  order.OrderNumber,
+			CustomerID:   customerID,
+			CustomerName: "Test Customer",
+			WarehouseID:  &setup.WarehouseID,
+			Items: []domaintrade.SalesOrderItemInfo{
+				{
+					ItemID:    order.Items[0].ID,
+					ProductID: setup.ProductID,
+					Quantity:  decimal.NewFromInt(20),
+				},
+			},
+		}
+		confirmHandler := trade.NewSalesOrderConfirmedHandler(setup.InventoryService, setup.Logger)
+		require.NoError(t, confirmHandler.Handle(ctx, confirmEvent))
+
+		// Let the reservation lapse
+		err = setup.DB.DB.Exec(`UPDATE stock_locks SET expire_at = ? WHERE source_id = ?`,
+			time.Now().Add(-time.Minute), order.ID.String()).Error
+		require.NoError(t, err)
+
+		core, logs := observer.New(zapcore.WarnLevel)
+		eventBus := event.NewInMemoryEventBus(zap.NewNop())
+		eventBus.Subscribe(trade.NewStockLockExpiredHandler(salesOrderRepo, zap.New(core)))
+		expirationService := inventoryapp.NewStockLockExpirationService(setup.LockRepo, setup.InventoryRepo, eventBus, zap.NewNop())
+
+		_, err = expirationService.ReleaseExpiredLocks(ctx)
+		require.NoError(t, err)
+
+		// Stock is available again
+		updated, err := setup.InventoryRepo.FindByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.True(t, updated.AvailableQuantity.Amount().Equal(decimal.NewFromInt(100)))
+		assert.True(t, updated.LockedQuantity.Amount().IsZero())
+
+		// Trade was told which order lost its reservation
+		warnings := logs.FilterField(zap.String("order_id", order.ID.String())).All()
+		require.Len(t, warnings, 1)
+		fields := warnings[0].ContextMap()
+		assert.Equal(t, "SO-2024-EXPIRY-002", fields["order_number"])
+		assert.Equal(t, setup.ProductID.String(), fields["product_id"])
+		assert.Equal(t, setup.WarehouseID.String(), fields["warehouse_id"])
+		assert.Equal(t, "20", fields["quantity"])
+	})
+}
@@ -55,6 +55,7 @@ func NewPartnerTestServer(t *testing.T) *PartnerTestServer {
 	// Setup engine with test authentication middleware
 	engine := gin.New()
 	engine.Use(testutil.TestAuthMiddleware())
+	engine.Use(testutil.TestPermissionsMiddleware("customer:view_financials", "customer:restore", "supplier:restore"))
 
 	// Setup routes
 	r := router.NewRouter(engine, router.WithAPIVersion("v1"))
@@ -69,6 +70,7 @@ func NewPartnerTestServer(t *testing.T) *PartnerTestServer {
 	customerRoutes.PUT("/customers/:id", customerHandler.Update)
 	customerRoutes.PUT("/customers/:id/code", customerHandler.UpdateCode)
 	customerRoutes.DELETE("/customers/:id", customerHandler.Delete)
+	customerRoutes.POST("/customers/:id/restore", customerHandler.Restore)
 	customerRoutes.POST("/customers/:id/activate", customerHandler.Activate)
 	customerRoutes.POST("/customers/:id/deactivate", customerHandler.Deactivate)
 	customerRoutes.POST("/customers/:id/suspend", customerHandler.Suspend)
@@ -85,6 +87,7 @@ func NewPartnerTestServer(t *testing.T) *PartnerTestServer {
 	customerRoutes.PUT("/suppliers/:id", supplierHandler.Update)
 	customerRoutes.PUT("/suppliers/:id/code", supplierHandler.UpdateCode)
 	customerRoutes.DELETE("/suppliers/:id", supplierHandler.Delete)
+	customerRoutes.POST("/suppliers/:id/restore", supplierHandler.Restore)
 	customerRoutes.POST("/suppliers/:id/activate", supplierHandler.Activate)
 	customerRoutes.POST("/suppliers/:id/deactivate", supplierHandler.Deactivate)
 	customerRoutes.POST("/suppliers/:id/block", supplierHandler.Block)
@@ -644,6 +647,133 @@ func TestCustomerAPI_DuplicateCode(t *testing.T) {
 	})
 }
 
+// TestCustomerAPI_StaleVersionUpdate tests that an update sent with an outdated version
+// is rejected with 409 and the current version instead of overwriting a newer edit
+func TestCustomerAPI_StaleVersionUpdate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ts := NewPartnerTestServer(t)
+	tenantID := uuid.New()
+	ts.DB.CreateTestTenantWithUUID(tenantID)
+
+	w := ts.Request(http.MethodPost, "/api/v1/partner/customers", map[string]interface{}{
+		"code": "VERSIONED-CUST",
+		"name": "Versioned Customer",
+		"type": "individual",
+	}, tenantID)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var resp APIResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	data := resp.Data.(map[string]interface{})
+	customerID := data["id"].(string)
+	loadedVersion := int(data["version"].(float64))
+
+	w = ts.Request(http.MethodPut, "/api/v1/partner/customers/"+customerID, map[string]interface{}{
+		"notes":   "First edit",
+		"version": loadedVersion,
+	}, tenantID)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = ts.Request(http.MethodPut, "/api/v1/partner/customers/"+customerID, map[string]interface{}{
+		"notes":   "Second edit",
+		"version": loadedVersion,
+	}, tenantID)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var conflictResp APIResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &conflictResp))
+	require.NotNil(t, conflictResp.Error)
+	assert.Equal(t, "ERR_CONCURRENCY_CONFLICT", conflictResp.Error.Code)
+	require.Len(t, conflictResp.Error.Details, 1)
+	assert.Equal(t, "current_version", conflictResp.Error.Details[0].Field)
+	assert.Equal(t, fmt.Sprint(loadedVersion+1), conflictResp.Error.Details[0].Message)
+
+	w = ts.Request(http.MethodGet, "/api/v1/partner/customers/"+customerID, nil, tenantID)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "First edit", resp.Data.(map[string]interface{})["notes"])
+}
+
+// TestCustomerAPI_SoftDeleteAndRestore tests that deleted customers can be listed by admins,
+// restored, and that their code can be reused while they are deleted
+func TestCustomerAPI_SoftDeleteAndRestore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ts := NewPartnerTestServer(t)
+	tenantID := uuid.New()
+	ts.DB.CreateTestTenantWithUUID(tenantID)
+
+	createCustomer := func(name string) string {
+		reqBody := map[string]interface{}{
+			"code": "SOFT-CUST-001",
+			"name": name,
+			"type": "individual",
+		}
+		w := ts.Request(http.MethodPost, "/api/v1/partner/customers", reqBody, tenantID)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data.(map[string]interface{})["id"].(string)
+	}
+	listTotal := func(query string) int64 {
+		w := ts.Request(http.MethodGet, "/api/v1/partner/customers"+query, nil, tenantID)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Meta.Total
+	}
+
+	originalID := createCustomer("Original Customer")
+
+	t.Run("Delete hides the customer", func(t *testing.T) {
+		w := ts.Request(http.MethodDelete, "/api/v1/partner/customers/"+originalID, nil, tenantID)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		w = ts.Request(http.MethodGet, "/api/v1/partner/customers/"+originalID, nil, tenantID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, int64(0), listTotal(""))
+		assert.Equal(t, int64(1), listTotal("?include_deleted=true"))
+	})
+
+	var reuseID string
+	t.Run("Code can be reused after delete", func(t *testing.T) {
+		reuseID = createCustomer("Reusing Customer")
+		assert.Equal(t, int64(1), listTotal(""))
+		assert.Equal(t, int64(2), listTotal("?include_deleted=true"))
+	})
+
+	t.Run("Restore fails while the code is taken", func(t *testing.T) {
+		w := ts.Request(http.MethodPost, "/api/v1/partner/customers/"+originalID+"/restore", nil, tenantID)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Restore after the code is freed", func(t *testing.T) {
+		w := ts.Request(http.MethodDelete, "/api/v1/partner/customers/"+reuseID, nil, tenantID)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		w = ts.Request(http.MethodPost, "/api/v1/partner/customers/"+originalID+"/restore", nil, tenantID)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = ts.Request(http.MethodGet, "/api/v1/partner/customers/"+originalID, nil, tenantID)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp.Data.(map[string]interface{})
+		assert.Equal(t, "Original Customer", data["name"])
+		assert.NotContains(t, data, "deleted_at")
+	})
+
+	t.Run("Restoring a live customer is not found", func(t *testing.T) {
+		w := ts.Request(http.MethodPost, "/api/v1/partner/customers/"+originalID+"/restore", nil, tenantID)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
 // TestCustomerAPI_TenantIsolation tests that customers are isolated by tenant
 func TestCustomerAPI_TenantIsolation(t *testing.T) {
 	if testing.Short() {
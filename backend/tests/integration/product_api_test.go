@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -112,6 +113,10 @@ type APIResponse struct {
 	Error   *struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
+		Details []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"details,omitempty"`
 	} `json:"error,omitempty"`
 	Meta *struct {
 		Total    int64 `json:"total"`
@@ -815,4 +820,49 @@ func TestProductAPI_Concurrency(t *testing.T) {
 		data := resp.Data.(map[string]interface{})
 		assert.Equal(t, float64(10), data["total"])
 	})
+
+	t.Run("Second update against the same loaded version gets 409", func(t *testing.T) {
+		reqBody := map[string]interface{}{
+			"code": "VERSIONED-PROD",
+			"name": "Versioned Product",
+			"unit": "pcs",
+		}
+		w := ts.Request(http.MethodPost, "/api/v1/catalog/products", reqBody, tenantID)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var resp APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp.Data.(map[string]interface{})
+		productID := data["id"].(string)
+		loadedVersion := int(data["version"].(float64))
+
+		w = ts.Request(http.MethodPut, "/api/v1/catalog/products/"+productID, map[string]interface{}{
+			"name":    "First Edit",
+			"version": loadedVersion,
+		}, tenantID)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		currentVersion := int(resp.Data.(map[string]interface{})["version"].(float64))
+		assert.Greater(t, currentVersion, loadedVersion)
+
+		w = ts.Request(http.MethodPut, "/api/v1/catalog/products/"+productID, map[string]interface{}{
+			"name":    "Second Edit",
+			"version": loadedVersion,
+		}, tenantID)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		var conflictResp APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &conflictResp))
+		require.NotNil(t, conflictResp.Error)
+		assert.Equal(t, "ERR_CONCURRENCY_CONFLICT", conflictResp.Error.Code)
+		require.Len(t, conflictResp.Error.Details, 1)
+		assert.Equal(t, "current_version", conflictResp.Error.Details[0].Field)
+		assert.Equal(t, strconv.Itoa(currentVersion), conflictResp.Error.Details[0].Message)
+
+		// The first edit is kept
+		w = ts.Request(http.MethodGet, "/api/v1/catalog/products/"+productID, nil, tenantID)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "First Edit", resp.Data.(map[string]interface{})["name"])
+	})
 }
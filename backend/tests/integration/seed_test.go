@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/erp/backend/internal/infrastructure/migration"
+)
+
+// TestSeed_Idempotent runs the seeds twice and checks that the second run
+// adds no rows, while tenants created in between are still seeded
+func TestSeed_Idempotent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	tdb := NewTestDB(t)
+	seedsPath := filepath.Join(filepath.Dir(findMigrationsPath()), "seeds")
+	seeder := migration.NewSeeder(tdb.SqlDB, seedsPath, zap.NewNop())
+
+	tenantA := uuid.New()
+	tdb.CreateTestTenantWithUUID(tenantA)
+
+	count := func(query string, args ...any) int {
+		t.Helper()
+		var n int
+		require.NoError(t, tdb.SqlDB.QueryRow(query, args...).Scan(&n))
+		return n
+	}
+	snapshot := func() map[string]int {
+		return map[string]int{
+			"roles":            count(`SELECT COUNT(*) FROM roles`),
+			"role_permissions": count(`SELECT COUNT(*) FROM role_permissions`),
+			"customer_levels":  count(`SELECT COUNT(*) FROM customer_levels`),
+			"warehouses":       count(`SELECT COUNT(*) FROM warehouses`),
+		}
+	}
+
+	first, err := seeder.Run()
+	require.NoError(t, err)
+	require.NotEmpty(t, first)
+	afterFirst := snapshot()
+
+	assert.Equal(t, 7, count(`SELECT COUNT(*) FROM roles WHERE tenant_id = $1 AND is_system_role`, tenantA))
+	assert.Equal(t, 5, count(`SELECT COUNT(*) FROM customer_levels WHERE tenant_id = $1`, tenantA))
+	assert.Equal(t, 1, count(`SELECT COUNT(*) FROM customer_levels WHERE tenant_id = $1 AND is_default`, tenantA))
+	assert.Equal(t, 1, count(`SELECT COUNT(*) FROM warehouses WHERE tenant_id = $1 AND is_default`, tenantA))
+	assert.Equal(t,
+		count(`SELECT COUNT(*) FROM role_permissions WHERE role_id = '00000000-0000-0000-0000-000000000010'`),
+		count(`SELECT COUNT(*) FROM role_permissions p JOIN roles r ON r.id = p.role_id
+			WHERE r.tenant_id = $1 AND r.code = 'ADMIN'`, tenantA))
+
+	t.Run("second run adds no rows", func(t *testing.T) {
+		second, err := seeder.Run()
+		require.NoError(t, err)
+		assert.Equal(t, afterFirst, snapshot())
+
+		for _, result := range second {
+			assert.Equal(t, 2, result.RunCount, result.Name)
+		}
+		assert.Equal(t, len(second), count(`SELECT COUNT(*) FROM schema_seeds WHERE run_count = 2`))
+	})
+
+	t.Run("new tenant is seeded on the next run", func(t *testing.T) {
+		tenantB := uuid.New()
+		tdb.CreateTestTenantWithUUID(tenantB)
+
+		_, err := seeder.Run()
+		require.NoError(t, err)
+		assert.Equal(t, 7, count(`SELECT COUNT(*) FROM roles WHERE tenant_id = $1`, tenantB))
+		assert.Equal(t, 5, count(`SELECT COUNT(*) FROM customer_levels WHERE tenant_id = $1`, tenantB))
+		assert.Equal(t, 1, count(`SELECT COUNT(*) FROM warehouses WHERE tenant_id = $1`, tenantB))
+		assert.Equal(t, 7, count(`SELECT COUNT(*) FROM roles WHERE tenant_id = $1`, tenantA))
+	})
+}
@@ -0,0 +1,135 @@
+// Package integration provides integration tests for stock taking and inventory interactions.
+// This file tests that approving a stock taking adjusts on-hand inventory to the counted
+// quantities within the approval transaction.
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	inventoryapp "github.com/erp/backend/internal/application/inventory"
+	"github.com/erp/backend/internal/domain/inventory"
+	"github.com/erp/backend/internal/infrastructure/persistence"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestUser inserts a user row so stock taking creator/approver foreign keys are satisfied
+func createTestUser(t *testing.T, setup *OrderInventoryTestSetup) uuid.UUID {
+	t.Helper()
+
+	userID := uuid.New()
+	err := setup.DB.DB.Exec(`
+		INSERT INTO users (id, tenant_id, username, password_hash, status)
+		VALUES (?, ?, ?, 'x', 'active')
+	`, userID.String(), setup.TenantID.String(), fmt.Sprintf("user_%s", userID.String()[:8])).Error
+	require.NoError(t, err)
+	return userID
+}
+
+func TestStockTakingInventory_ApproveAdjustsStock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	setup := NewOrderInventoryTestSetup(t)
+	ctx := context.Background()
+	userID := createTestUser(t, setup)
+
+	stockTakingService := inventoryapp.NewStockTakingService(persistence.NewGormStockTakingRepository(setup.DB.DB), nil)
+	stockTakingService.SetInventoryAdjustment(setup.InventoryService, persistence.NewGormTransactionScope(setup.DB.DB))
+
+	// runTake creates a stock taking over the given system quantities, records the counts and approves it
+	runTake := func(t *testing.T, items map[uuid.UUID][2]float64) *inventoryapp.StockTakingResponse {
+		t.Helper()
+
+		st, err := stockTakingService.Create(ctx, setup.TenantID, inventoryapp.CreateStockTakingRequest{
+			WarehouseID:   setup.WarehouseID,
+			WarehouseName: "Test Warehouse",
+			CreatedByID:   userID,
+			CreatedByName: "Tester",
+		})
+		require.NoError(t, err)
+
+		var addReqs []inventoryapp.AddStockTakingItemRequest
+		var counts []inventoryapp.RecordCountRequest
+		for productID, qty := range items {
+			addReqs = append(addReqs, inventoryapp.AddStockTakingItemRequest{
+				ProductID:      productID,
+				ProductName:    "Product " + productID.String()[:8],
+				ProductCode:    "P" + productID.String()[:8],
+				Unit:           "pcs",
+				SystemQuantity: decimal.NewFromFloat(qty[0]),
+				UnitCost:       decimal.NewFromFloat(10.00),
+			})
+			counts = append(counts, inventoryapp.RecordCountRequest{
+				ProductID:      productID,
+				ActualQuantity: decimal.NewFromFloat(qty[1]),
+			})
+		}
+
+		_, err = stockTakingService.AddItems(ctx, setup.TenantID, st.ID, inventoryapp.AddStockTakingItemsRequest{Items: addReqs})
+		require.NoError(t, err)
+		_, err = stockTakingService.StartCounting(ctx, setup.TenantID, st.ID)
+		require.NoError(t, err)
+		_, err = stockTakingService.RecordCounts(ctx, setup.TenantID, st.ID, inventoryapp.RecordCountsRequest{Counts: counts})
+		require.NoError(t, err)
+		_, err = stockTakingService.SubmitForApproval(ctx, setup.TenantID, st.ID)
+		require.NoError(t, err)
+
+		approved, err := stockTakingService.Approve(ctx, setup.TenantID, st.ID, inventoryapp.ApproveStockTakingRequest{
+			ApproverID:   userID,
+			ApproverName: "Approver",
+		})
+		require.NoError(t, err)
+		return approved
+	}
+
+	t.Run("shortage and overage adjust on-hand to counted quantities", func(t *testing.T) {
+		shortProduct := setup.CreateAdditionalProduct(t)
+		overProduct := setup.CreateAdditionalProduct(t)
+		setup.CreateInventoryForProduct(t, shortProduct, 100)
+		setup.CreateInventoryForProduct(t, overProduct, 50)
+
+		approved := runTake(t, map[uuid.UUID][2]float64{
+			shortProduct: {100, 95},
+			overProduct:  {50, 60},
+		})
+		assert.Equal(t, "APPROVED", approved.Status)
+
+		shortItem, err := setup.InventoryRepo.FindByWarehouseAndProduct(ctx, setup.TenantID, setup.WarehouseID, shortProduct)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(95).Equal(shortItem.TotalQuantity().Amount()), "got %s", shortItem.TotalQuantity().Amount())
+
+		overItem, err := setup.InventoryRepo.FindByWarehouseAndProduct(ctx, setup.TenantID, setup.WarehouseID, overProduct)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(60).Equal(overItem.TotalQuantity().Amount()), "got %s", overItem.TotalQuantity().Amount())
+
+		txs, err := setup.TransactionRepo.FindBySource(ctx, inventory.SourceTypeStockTaking, approved.ID.String())
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		for _, tx := range txs {
+			assert.Equal(t, approved.TakingNumber, tx.Reference)
+		}
+	})
+
+	t.Run("zero variance makes no adjustments", func(t *testing.T) {
+		productID := setup.CreateAdditionalProduct(t)
+		setup.CreateInventoryForProduct(t, productID, 30)
+
+		approved := runTake(t, map[uuid.UUID][2]float64{
+			productID: {30, 30},
+		})
+
+		item, err := setup.InventoryRepo.FindByWarehouseAndProduct(ctx, setup.TenantID, setup.WarehouseID, productID)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(30).Equal(item.TotalQuantity().Amount()))
+
+		txs, err := setup.TransactionRepo.FindBySource(ctx, inventory.SourceTypeStockTaking, approved.ID.String())
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/erp/backend/internal/infrastructure/auth"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -235,3 +236,17 @@ func TestAuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// TestPermissionsMiddleware creates a Gin middleware that grants the given permissions
+// to the request by setting JWT claims, for handlers that check permissions themselves.
+// Use it after TestAuthMiddleware.
+func TestPermissionsMiddleware(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("jwt_claims", &auth.Claims{
+			TenantID:    c.GetString("jwt_tenant_id"),
+			UserID:      c.GetString("jwt_user_id"),
+			Permissions: permissions,
+		})
+		c.Next()
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/example/erp/tools/loadgen/internal/circuit"
 	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/configgen"
+	"github.com/example/erp/tools/loadgen/internal/generator"
 	"github.com/example/erp/tools/loadgen/internal/parser"
 	"github.com/example/erp/tools/loadgen/internal/runner"
 	"github.com/example/erp/tools/loadgen/internal/scenario"
@@ -38,6 +40,7 @@ var (
 	showVersion    bool
 	openapiPath    string
 	inferDryRun    bool
+	generateConfig bool
 	minConfidence  float64
 	outputFormat   string
 	outputFile     string
@@ -80,6 +83,7 @@ func init() {
 	// Inference flags
 	flag.BoolVar(&inferDryRun, "infer", false, "Run semantic type inference on OpenAPI spec (dry-run mode)")
 	flag.Float64Var(&minConfidence, "min-confidence", 0.7, "Minimum confidence threshold for inference (0.0-1.0)")
+	flag.BoolVar(&generateConfig, "generate-config", false, "Write a starter config for the OpenAPI spec to stdout")
 
 	// Output flags
 	flag.StringVar(&outputFormat, "output", "", "Output format: console, json, or console,json (enables JSON report)")
@@ -97,6 +101,7 @@ USAGE:
     loadgen -config <path> [options]
     loadgen -config <path> -scenario <name>  (Run specific scenario)
     loadgen -openapi <path> -list            (Parse and list OpenAPI endpoints)
+    loadgen -openapi <path> -generate-config (Write a starter config to stdout)
 
 DESCRIPTION:
     A load testing tool that generates realistic traffic patterns for the ERP system.
@@ -125,6 +130,7 @@ UTILITY OPTIONS:
     -dry-run              Show execution plan without running
     -verbose, -v          Enable verbose output
     -version              Show version information
+    -generate-config      With -openapi, write a starter config to stdout
     -help, -h             Show this help message
 
 OUTPUT OPTIONS:
@@ -166,6 +172,10 @@ EXAMPLES:
     # Parse OpenAPI with verbose output (show parameters and response fields)
     loadgen -openapi backend/docs/swagger.yaml -list -v
 
+    # Generate a starter config from an OpenAPI spec, then check it
+    loadgen -openapi backend/docs/swagger.yaml -generate-config > configs/generated.yaml
+    loadgen -config configs/generated.yaml -validate
+
     # Validate configuration
     loadgen -config configs/erp.yaml -validate
 
@@ -277,7 +287,7 @@ func main() {
 				Description: s.Description,
 				Endpoints:   s.Endpoints,
 				Weight:      s.Weight,
-				Sequential:  s.Sequential,
+				Sequential:  s.Sequential || s.Chain,
 			}
 		}
 		if err := registry.LoadFromConfig(inlineScenarios); err != nil {
@@ -709,6 +719,14 @@ func handleOpenAPIMode() {
 		os.Exit(1)
 	}
 
+	if generateConfig {
+		if err := writeGeneratedConfig(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating config: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle inference dry-run mode
 	if inferDryRun {
 		printInferenceResults(spec)
@@ -725,6 +743,27 @@ func handleOpenAPIMode() {
 	os.Exit(0)
 }
 
+// writeGeneratedConfig writes a starter config for spec to stdout.
+// The summary goes to stderr so the YAML can be redirected to a file.
+func writeGeneratedConfig(spec *parser.OpenAPISpec) error {
+	cfg, err := configgen.Generate(spec, configgen.Options{MinConfidence: minConfidence})
+	if err != nil {
+		return err
+	}
+	data, err := configgen.Marshal(cfg, spec)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stdout.Write(data); err != nil {
+		return err
+	}
+
+	disabled := len(cfg.Endpoints) - len(cfg.GetEnabledEndpoints())
+	fmt.Fprintf(os.Stderr, "Generated config '%s': %d endpoints (%d disabled), auth: %s\n",
+		cfg.Name, len(cfg.Endpoints), disabled, getAuthType(cfg))
+	return nil
+}
+
 // printOpenAPIEndpointList prints endpoints from an OpenAPI spec
 func printOpenAPIEndpointList(spec *parser.OpenAPISpec) {
 	fmt.Printf("OpenAPI Endpoints from '%s' (v%s)\n", spec.Title, spec.Version)
@@ -995,7 +1034,11 @@ func printInferenceResults(spec *parser.OpenAPISpec) {
 			typeCount[pin.SemanticType]++
 		}
 		for semType, count := range typeCount {
-			fmt.Printf("  %-30s %d\n", semType, count)
+			source := "type fallback"
+			if generator.HasSemanticGenerator(semType) {
+				source = "built-in"
+			}
+			fmt.Printf("  %-30s %d  (%s)\n", semType, count, source)
 		}
 	}
 }
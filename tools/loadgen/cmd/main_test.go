@@ -3,6 +3,8 @@ package main
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -322,19 +324,25 @@ endpoints:
 	assert.Equal(t, 1, exitCode)
 }
 
-func TestCLI_RunPlaceholder(t *testing.T) {
+func TestCLI_Run(t *testing.T) {
 	binPath := buildLoadgen(t)
 
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
 	// Create a minimal config
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "test-config.yaml")
 	configContent := `
 name: "Run Test"
 target:
-  baseURL: "http://localhost:8080"
+  baseURL: "` + server.URL + `"
 trafficShaper:
-  type: "sine"
-  baseQPS: 100
+  type: "constant"
+  baseQPS: 20
 endpoints:
   - name: "test.endpoint"
     path: "/test"
@@ -343,13 +351,14 @@ endpoints:
 	err := os.WriteFile(configPath, []byte(configContent), 0644)
 	require.NoError(t, err)
 
-	// Test run (should show placeholder message)
-	stdout, _, exitCode := runLoadgen(t, binPath, "-config", configPath)
+	// Test run executes the load phase and prints the final report
+	stdout, stderr, exitCode := runLoadgen(t, binPath, "-config", configPath, "-duration", "1s")
 
-	assert.Contains(t, stdout, "Load Test")
-	assert.Contains(t, stdout, "Configuration: Run Test")
-	assert.Contains(t, stdout, "not yet implemented")
-	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, 0, exitCode, "stderr: %s", stderr)
+	assert.Contains(t, stdout, "Load Generator: Run Test")
+	assert.Contains(t, stdout, "Running load test")
+	assert.Contains(t, stdout, "LOAD TEST FINAL REPORT")
+	assert.Contains(t, stdout, "test.endpoint")
 }
 
 // TestApplyOverrides tests the applyOverrides function behavior
@@ -752,6 +761,78 @@ paths:
 	assert.Equal(t, 0, exitCode)
 }
 
+// TestCLI_OpenAPIGenerateConfig tests that -generate-config writes a config that passes -validate
+func TestCLI_OpenAPIGenerateConfig(t *testing.T) {
+	binPath := buildLoadgen(t)
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "openapi.yaml")
+	specContent := `
+swagger: "2.0"
+info:
+  title: Generate Test API
+  version: "1.0"
+securityDefinitions:
+  BearerAuth:
+    type: apiKey
+    in: header
+    name: Authorization
+security:
+  - BearerAuth: []
+paths:
+  /auth/login:
+    post:
+      security: []
+      responses:
+        200:
+          description: OK
+  /products:
+    get:
+      responses:
+        200:
+          description: OK
+    post:
+      parameters:
+        - name: body
+          in: body
+          required: true
+          schema:
+            type: object
+            required: [name]
+            properties:
+              name:
+                type: string
+      responses:
+        201:
+          description: Created
+  /products/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+      responses:
+        200:
+          description: OK
+`
+	require.NoError(t, os.WriteFile(specPath, []byte(specContent), 0644))
+
+	stdout, stderr, exitCode := runLoadgen(t, binPath, "-openapi", specPath, "-generate-config")
+	require.Equal(t, 0, exitCode, stderr)
+	assert.Contains(t, stderr, "4 endpoints (1 disabled), auth: login")
+	assert.Contains(t, stdout, "name: Generate Test API Load Test")
+	assert.Contains(t, stdout, "path: /products/{id}")
+
+	configPath := filepath.Join(tmpDir, "generated.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(stdout), 0644))
+
+	stdout, stderr, exitCode = runLoadgen(t, binPath, "-config", configPath, "-validate")
+	assert.Equal(t, 0, exitCode, stderr)
+	assert.Contains(t, stdout, "Configuration 'Generate Test API Load Test' is valid.")
+	assert.Contains(t, stdout, "Endpoints:   4")
+}
+
 // TestParsePrometheusPort tests the parsePrometheusPort function
 func TestParsePrometheusPort(t *testing.T) {
 	tests := []struct {
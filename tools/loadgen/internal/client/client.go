@@ -109,8 +109,8 @@ func NewClient(cfg config.TargetConfig, authCfg *config.AuthConfig, retryCfg *Re
 		client.headers[k] = v
 	}
 
-	// Initialize auth manager
-	if authCfg != nil && authCfg.Type != "none" {
+	// Initialize auth manager (an empty type means no authentication)
+	if authCfg != nil && authCfg.Type != "" && authCfg.Type != "none" {
 		authManager, err := NewAuthManager(client, authCfg)
 		if err != nil {
 			return nil, fmt.Errorf("creating auth manager: %w", err)
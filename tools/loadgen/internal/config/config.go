@@ -235,6 +235,15 @@ type EndpointConfig struct {
 	// Default: 200 for GET, 201 for POST, 204 for DELETE
 	ExpectedStatus int `yaml:"expectedStatus,omitempty" json:"expectedStatus,omitempty"`
 
+	// ExpectedStatusCodes lists further accepted HTTP status codes.
+	// When neither this nor ExpectedStatus is set, any 2xx or 3xx is accepted;
+	// otherwise any other status counts as an assertion failure.
+	ExpectedStatusCodes []int `yaml:"expectedStatusCodes,omitempty" json:"expectedStatusCodes,omitempty"`
+
+	// ResponseAssertions check the response body of accepted responses.
+	// A response that fails any of them counts as an assertion failure.
+	ResponseAssertions []ResponseAssertion `yaml:"responseAssertions,omitempty" json:"responseAssertions,omitempty"`
+
 	// Produces defines values this endpoint produces (for warmup).
 	Produces []ProducesConfig `yaml:"produces,omitempty" json:"produces,omitempty"`
 
@@ -306,6 +315,13 @@ type ParameterConfig struct {
 
 	// Generator specifies a data generator.
 	Generator *GeneratorConfig `yaml:"generator,omitempty" json:"generator,omitempty"`
+
+	// Type is the OpenAPI data type ("string", "integer", "number", "boolean").
+	// It picks the fallback generator when no value source covers the semantic type.
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Format is the OpenAPI format (e.g. "uuid", "email", "date", "date-time").
+	Format string `yaml:"format,omitempty" json:"format,omitempty"`
 }
 
 // GeneratorConfig configures a data generator.
@@ -399,6 +415,24 @@ type ProducesConfig struct {
 	Multiple bool `yaml:"multiple,omitempty" json:"multiple,omitempty"`
 }
 
+// ResponseAssertion checks one value in a JSON response body.
+type ResponseAssertion struct {
+	// JSONPath selects the value to check (e.g., "$.data.status").
+	JSONPath string `yaml:"jsonPath" json:"jsonPath"`
+
+	// Exists requires the value to be present (true) or absent (false).
+	// A JSON null counts as absent.
+	// Default: true
+	Exists *bool `yaml:"exists,omitempty" json:"exists,omitempty"`
+
+	// Equals requires the value to equal this one when both are printed as text,
+	// so 42, "42" and 42.0 all match a JSON 42.
+	Equals any `yaml:"equals,omitempty" json:"equals,omitempty"`
+
+	// NotEmpty requires a non-empty string, array or object.
+	NotEmpty bool `yaml:"notEmpty,omitempty" json:"notEmpty,omitempty"`
+}
+
 // ScenarioConfig defines a named scenario grouping endpoints.
 type ScenarioConfig struct {
 	// Name is the scenario identifier.
@@ -416,6 +450,12 @@ type ScenarioConfig struct {
 
 	// Sequential indicates endpoints should run in order.
 	Sequential bool `yaml:"sequential,omitempty" json:"sequential,omitempty"`
+
+	// Chain runs the endpoints in order as one virtual user, feeding the values
+	// each step produces into later steps that consume the same semantic type.
+	// When any scenario is a chain, the runner executes chains instead of
+	// individual endpoints. Implies Sequential.
+	Chain bool `yaml:"chain,omitempty" json:"chain,omitempty"`
 }
 
 // OutputConfig configures output and reporting.
@@ -642,6 +682,33 @@ func (c *Config) Validate() error {
 				return fmt.Errorf("%w: endpoint[%d].schedule[%d]: %v", ErrInvalidConfig, i, j, err)
 			}
 		}
+
+		// Validate response expectations
+		for _, code := range ep.ExpectedStatusCodes {
+			if code < 100 || code > 599 {
+				return fmt.Errorf("%w: endpoint[%d].expectedStatusCodes: invalid status code %d", ErrInvalidConfig, i, code)
+			}
+		}
+		for j, a := range ep.ResponseAssertions {
+			if a.JSONPath == "" {
+				return fmt.Errorf("%w: endpoint[%d].responseAssertions[%d].jsonPath is required", ErrInvalidConfig, i, j)
+			}
+		}
+	}
+
+	// Validate chain scenarios
+	for i, s := range c.Scenarios {
+		if !s.Chain {
+			continue
+		}
+		if len(s.Endpoints) == 0 {
+			return fmt.Errorf("%w: scenario[%d] %s: chain requires at least one endpoint", ErrInvalidConfig, i, s.Name)
+		}
+		for _, name := range s.Endpoints {
+			if !names[name] {
+				return fmt.Errorf("%w: scenario[%d] %s: unknown endpoint: %s", ErrInvalidConfig, i, s.Name, name)
+			}
+		}
 	}
 
 	// Validate warmup config
@@ -252,6 +252,111 @@ endpoints:
 	assert.Contains(t, err.Error(), "method is required")
 }
 
+func TestValidate_ChainUnknownEndpoint(t *testing.T) {
+	yaml := `
+name: "Test"
+target:
+  baseURL: "http://localhost:8080"
+trafficShaper:
+  type: "sine"
+  baseQPS: 100
+endpoints:
+  - name: "orders.create"
+    path: "/orders"
+    method: "POST"
+scenarios:
+  - name: "order_flow"
+    chain: true
+    endpoints:
+      - "orders.create"
+      - "orders.get"
+`
+	_, err := LoadFromBytes([]byte(yaml))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown endpoint: orders.get")
+}
+
+func TestLoadFromBytes_ResponseAssertions(t *testing.T) {
+	yaml := `
+name: "Test"
+target:
+  baseURL: "http://localhost:8080"
+trafficShaper:
+  type: "sine"
+  baseQPS: 100
+endpoints:
+  - name: "orders.get"
+    path: "/orders/{id}"
+    method: "GET"
+    expectedStatus: 200
+    expectedStatusCodes: [404]
+    responseAssertions:
+      - jsonPath: "$.data.status"
+        equals: "confirmed"
+      - jsonPath: "$.data.total"
+        equals: 42
+      - jsonPath: "$.data.items"
+        notEmpty: true
+      - jsonPath: "$.error"
+        exists: false
+`
+	cfg, err := LoadFromBytes([]byte(yaml))
+	require.NoError(t, err)
+
+	ep := cfg.Endpoints[0]
+	assert.Equal(t, 200, ep.ExpectedStatus)
+	assert.Equal(t, []int{404}, ep.ExpectedStatusCodes)
+	require.Len(t, ep.ResponseAssertions, 4)
+	assert.Equal(t, "$.data.status", ep.ResponseAssertions[0].JSONPath)
+	assert.Equal(t, "confirmed", ep.ResponseAssertions[0].Equals)
+	assert.Equal(t, 42, ep.ResponseAssertions[1].Equals)
+	assert.True(t, ep.ResponseAssertions[2].NotEmpty)
+	require.NotNil(t, ep.ResponseAssertions[3].Exists)
+	assert.False(t, *ep.ResponseAssertions[3].Exists)
+}
+
+func TestValidate_ResponseAssertions(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		wantErr  string
+	}{
+		{
+			name: "missing jsonPath",
+			endpoint: `
+    responseAssertions:
+      - equals: "confirmed"`,
+			wantErr: "endpoint[0].responseAssertions[0].jsonPath is required",
+		},
+		{
+			name: "invalid status code",
+			endpoint: `
+    expectedStatusCodes: [200, 1000]`,
+			wantErr: "invalid status code 1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := `
+name: "Test"
+target:
+  baseURL: "http://localhost:8080"
+trafficShaper:
+  type: "sine"
+  baseQPS: 100
+endpoints:
+  - name: "orders.get"
+    path: "/orders/1"
+    method: "GET"` + tt.endpoint + "\n"
+			_, err := LoadFromBytes([]byte(yaml))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestGetEndpointByName(t *testing.T) {
 	cfg := &Config{
 		Endpoints: []EndpointConfig{
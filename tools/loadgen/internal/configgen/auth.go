@@ -0,0 +1,125 @@
+package configgen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/parser"
+)
+
+// tokenFieldNames are response fields that hold a login's access token, in preference order.
+var tokenFieldNames = []string{"access_token", "accessToken", "token"}
+
+// authConfig derives auth settings from the security scheme most endpoints
+// use. It also returns the paths of the login, refresh and logout endpoints.
+func authConfig(spec *parser.OpenAPISpec) (config.AuthConfig, map[string]bool) {
+	login := findEndpoint(spec, "/login", "/signin")
+	refresh := findEndpoint(spec, "/refresh")
+	logout := findEndpoint(spec, "/logout")
+
+	authPaths := make(map[string]bool)
+	for _, ep := range []*parser.EndpointUnit{login, refresh, logout} {
+		if ep != nil {
+			authPaths[ep.Path] = true
+		}
+	}
+
+	scheme, ok := primaryScheme(spec)
+	if !ok {
+		return config.AuthConfig{Type: "none"}, authPaths
+	}
+
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		return config.AuthConfig{
+			Type:  "basic",
+			Login: &config.LoginConfig{Username: placeholderSecret, Password: placeholderSecret},
+		}, authPaths
+
+	case scheme.Type == "apiKey" && !strings.EqualFold(scheme.Name, "Authorization"):
+		return config.AuthConfig{
+			Type:   "api_key",
+			APIKey: &config.APIKeyConfig{Key: placeholderSecret, Header: scheme.Name},
+		}, authPaths
+	}
+
+	// Bearer tokens: log in when the spec has a login endpoint, else use a static token
+	if login == nil {
+		return config.AuthConfig{
+			Type:   "bearer",
+			Bearer: &config.BearerConfig{Token: placeholderSecret},
+		}, authPaths
+	}
+
+	loginCfg := &config.LoginConfig{
+		Endpoint:  login.Path,
+		Method:    login.Method,
+		Username:  placeholderSecret,
+		Password:  placeholderSecret,
+		TokenPath: tokenPath(login),
+	}
+	if refresh != nil {
+		loginCfg.RefreshEndpoint = refresh.Path
+	}
+	return config.AuthConfig{Type: "login", Login: loginCfg}, authPaths
+}
+
+// primaryScheme returns the security scheme required by the most endpoints.
+func primaryScheme(spec *parser.OpenAPISpec) (parser.SecurityScheme, bool) {
+	counts := make(map[string]int)
+	for _, ep := range spec.Endpoints {
+		if !ep.RequiresAuth {
+			continue
+		}
+		for _, name := range ep.SecuritySchemes {
+			if _, ok := spec.SecurityDefinitions[name]; ok {
+				counts[name]++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return parser.SecurityScheme{}, false
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return spec.SecurityDefinitions[names[0]], true
+}
+
+// findEndpoint returns the first POST endpoint whose path ends with one of suffixes.
+func findEndpoint(spec *parser.OpenAPISpec, suffixes ...string) *parser.EndpointUnit {
+	for i := range spec.Endpoints {
+		ep := &spec.Endpoints[i]
+		if ep.Method != "POST" || ep.Deprecated {
+			continue
+		}
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(ep.Path, suffix) {
+				return ep
+			}
+		}
+	}
+	return nil
+}
+
+// tokenPath returns the JSONPath of the access token in a login response,
+// or "" to keep the client default.
+func tokenPath(login *parser.EndpointUnit) string {
+	for _, name := range tokenFieldNames {
+		for _, pin := range login.OutputPins {
+			if pin.Name == name && pin.Type == parser.ParameterTypeString {
+				return pin.JSONPath
+			}
+		}
+	}
+	return ""
+}
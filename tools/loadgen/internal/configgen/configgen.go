@@ -0,0 +1,429 @@
+// Package configgen builds starter load generator configurations from
+// OpenAPI specifications.
+//
+// The generated configuration lists every non-deprecated endpoint with a
+// default weight by method, derives authentication from the spec's security
+// schemes, and fills path, query and body parameters from the semantic types
+// inferred for each endpoint's input pins. It is meant as a starting point to
+// edit, not a finished test plan.
+package configgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/example/erp/tools/loadgen/internal/circuit"
+	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/generator"
+	"github.com/example/erp/tools/loadgen/internal/loadctrl"
+	"github.com/example/erp/tools/loadgen/internal/parser"
+	"github.com/example/erp/tools/loadgen/internal/warmup"
+)
+
+// ErrNoEndpoints is returned when a spec has no non-deprecated endpoints.
+var ErrNoEndpoints = errors.New("configgen: spec has no non-deprecated endpoints")
+
+const (
+	// DefaultBaseURL is used when the spec does not declare a server.
+	DefaultBaseURL = "http://localhost:8080"
+
+	// placeholderSecret stands in for credentials the spec cannot provide.
+	placeholderSecret = "CHANGE_ME"
+)
+
+// methodWeights are the default endpoint weights; reads run more often than writes.
+var methodWeights = map[string]int{
+	"GET":    10,
+	"POST":   3,
+	"PUT":    2,
+	"PATCH":  2,
+	"DELETE": 1,
+}
+
+// Options configures config generation.
+type Options struct {
+	// Name is the configuration name. Default: "<spec title> Load Test".
+	Name string
+
+	// BaseURL is the target base URL. Default: the spec's first server,
+	// or DefaultBaseURL.
+	BaseURL string
+
+	// MinConfidence is the minimum semantic inference confidence.
+	// Default: the inference engine's default.
+	MinConfidence float64
+}
+
+// Generate builds a configuration for spec. The result passes config validation.
+// Semantic types are inferred in place on spec's pins.
+func Generate(spec *parser.OpenAPISpec, opts Options) (*config.Config, error) {
+	engine := parser.NewSemanticInferenceEngine()
+	if opts.MinConfidence > 0 {
+		engine.SetMinConfidence(opts.MinConfidence)
+	}
+	engine.InferSpec(spec)
+
+	auth, authPaths := authConfig(spec)
+	cfg := &config.Config{
+		Name:          opts.Name,
+		Description:   fmt.Sprintf("Generated from %s", specLabel(spec)),
+		Version:       "1.0",
+		Target:        config.TargetConfig{BaseURL: opts.BaseURL, Timeout: 30 * time.Second},
+		Auth:          auth,
+		Duration:      5 * time.Minute,
+		TrafficShaper: loadctrl.ShaperConfig{Type: "constant", BaseQPS: 10},
+	}
+	if cfg.Name == "" {
+		cfg.Name = strings.TrimSpace(spec.Title + " Load Test")
+	}
+	if cfg.Target.BaseURL == "" {
+		cfg.Target.BaseURL = baseURL(spec)
+	}
+
+	var units []*parser.EndpointUnit
+	names := make(map[string]bool)
+	for i := range spec.Endpoints {
+		unit := &spec.Endpoints[i]
+		if unit.Deprecated {
+			continue
+		}
+		ep := endpointConfig(engine, unit)
+		ep.Name = uniqueName(ep.Name, names)
+		// Driving the auth endpoints would log the load test out or burn logins
+		ep.Disabled = authPaths[unit.Path]
+		cfg.Endpoints = append(cfg.Endpoints, ep)
+		units = append(units, unit)
+	}
+	if len(cfg.Endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	linkProducers(cfg, units)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("generated config is invalid: %w", err)
+	}
+	return cfg, nil
+}
+
+// Marshal renders cfg as YAML with a header naming the source spec.
+func Marshal(cfg *config.Config, spec *parser.OpenAPISpec) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Starter load generator configuration generated from %s.\n", specLabel(spec))
+	buf.WriteString("# Review auth credentials, weights and request bodies before running.\n\n")
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("encoding config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// endpointConfig converts one OpenAPI operation.
+func endpointConfig(engine *parser.SemanticInferenceEngine, unit *parser.EndpointUnit) config.EndpointConfig {
+	weight, ok := methodWeights[unit.Method]
+	if !ok {
+		weight = 1
+	}
+	requiresAuth := unit.RequiresAuth
+
+	ep := config.EndpointConfig{
+		Name:         unit.Name,
+		Description:  unit.Summary,
+		Path:         unit.Path,
+		Method:       unit.Method,
+		Weight:       weight,
+		Tags:         unit.Tags,
+		RequiresAuth: &requiresAuth,
+	}
+
+	for _, pin := range unit.InputPins {
+		switch pin.Location {
+		case parser.ParameterLocationPath:
+			if ep.PathParams == nil {
+				ep.PathParams = make(map[string]config.ParameterConfig)
+			}
+			ep.PathParams[pin.Name] = parameterConfig(pin)
+		case parser.ParameterLocationQuery:
+			// Optional filters would narrow every list call; leave them for the user to add
+			if !pin.Required {
+				continue
+			}
+			if ep.QueryParams == nil {
+				ep.QueryParams = make(map[string]config.ParameterConfig)
+			}
+			param := parameterConfig(pin)
+			if v := literalValue(pin); v != nil {
+				param.Value = fmt.Sprint(v)
+			}
+			ep.QueryParams[pin.Name] = param
+		case parser.ParameterLocationBody:
+			ep.Body = bodyTemplate(engine, unit, pin.Schema)
+		}
+	}
+	return ep
+}
+
+// parameterConfig converts a path or query pin.
+func parameterConfig(pin parser.InputPin) config.ParameterConfig {
+	param := config.ParameterConfig{
+		Type:   string(pin.Type),
+		Format: pin.Format,
+	}
+	if known(pin.SemanticType) {
+		param.SemanticType = pin.SemanticType
+	}
+	return param
+}
+
+// bodyTemplate renders a JSON body with one entry per top-level property:
+// the schema's literal value if it has one, otherwise a placeholder for the
+// property's inferred semantic type. Only required properties are included
+// when the schema lists any.
+func bodyTemplate(engine *parser.SemanticInferenceEngine, unit *parser.EndpointUnit, schema *parser.SchemaInfo) string {
+	if schema == nil || len(schema.Properties) == 0 {
+		return ""
+	}
+
+	props := schema.Required
+	if len(props) == 0 {
+		props = make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			props = append(props, name)
+		}
+	}
+	sort.Strings(props)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	written := 0
+	for _, name := range props {
+		prop, ok := schema.Properties[name]
+		if !ok || prop == nil {
+			continue
+		}
+		if written > 0 {
+			b.WriteString(",\n")
+		}
+		key, _ := json.Marshal(name)
+		fmt.Fprintf(&b, "  %s: %s", key, bodyValue(engine, unit, name, prop))
+		written++
+	}
+	b.WriteString("\n}")
+	return b.String()
+}
+
+// bodyValue returns the JSON text for one body property. Enum values stay
+// literal; otherwise a generated placeholder is preferred over the spec's
+// example, which would repeat unique fields such as codes on every create.
+func bodyValue(engine *parser.SemanticInferenceEngine, unit *parser.EndpointUnit, name string, prop *parser.SchemaInfo) string {
+	if len(prop.Enum) > 0 {
+		return jsonLiteral(prop.Enum[0])
+	}
+
+	switch prop.Type {
+	case parser.ParameterTypeObject:
+		return "{}"
+	case parser.ParameterTypeArray:
+		return "[]"
+	}
+
+	result := engine.Infer(name, string(prop.Type), prop.Format, &parser.InferenceContext{
+		EndpointPath:   unit.Path,
+		EndpointMethod: unit.Method,
+		OperationID:    unit.OperationID,
+		Tags:           unit.Tags,
+		IsInput:        true,
+		FieldPath:      name,
+	})
+	semanticType := result.SemanticType
+
+	switch {
+	case known(semanticType) && generator.HasSemanticGenerator(semanticType):
+	case prop.Default != nil:
+		return jsonLiteral(prop.Default)
+	case prop.Example != nil:
+		return jsonLiteral(prop.Example)
+	case !known(semanticType):
+		semanticType = formatSemanticType(prop.Format)
+		if !known(semanticType) {
+			return typeLiteral(prop.Type)
+		}
+	}
+
+	placeholder := "{{." + string(semanticType) + "}}"
+	if prop.Type == parser.ParameterTypeString || !yieldsScalar(semanticType) {
+		return `"` + placeholder + `"`
+	}
+	return placeholder
+}
+
+// jsonLiteral renders a schema value as JSON.
+func jsonLiteral(v any) string {
+	text, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(text)
+}
+
+// yieldsScalar reports whether the built-in generator for semanticType
+// produces a number or bool, so its placeholder can stand unquoted.
+func yieldsScalar(semanticType circuit.SemanticType) bool {
+	if !generator.HasSemanticGenerator(semanticType) {
+		return false
+	}
+	v, err := generator.NewRegistry().ForSemanticType(semanticType, "", "").Generate()
+	if err != nil {
+		return false
+	}
+	switch v.(type) {
+	case int, int64, float64, bool:
+		return true
+	}
+	return false
+}
+
+// formatSemanticType maps string formats with an obvious semantic type.
+func formatSemanticType(format string) circuit.SemanticType {
+	switch format {
+	case "uuid":
+		return circuit.CommonUUID
+	case "email":
+		return circuit.CommonEmail
+	case "date":
+		return circuit.CommonDate
+	case "date-time":
+		return circuit.CommonDateTime
+	}
+	return ""
+}
+
+// typeLiteral is the JSON value for a property nothing better is known about.
+func typeLiteral(t parser.ParameterType) string {
+	switch t {
+	case parser.ParameterTypeInteger, parser.ParameterTypeNumber:
+		return "1"
+	case parser.ParameterTypeBoolean:
+		return "false"
+	}
+	return `"sample"`
+}
+
+// literalValue returns a pin's default, example or first enum value.
+func literalValue(pin parser.InputPin) any {
+	switch {
+	case pin.Default != nil:
+		return pin.Default
+	case pin.Example != nil:
+		return pin.Example
+	case len(pin.Enum) > 0:
+		return pin.Enum[0]
+	}
+	return nil
+}
+
+// linkProducers adds produces entries for output pins whose semantic type an
+// enabled endpoint's path parameter consumes, and warms those types up.
+func linkProducers(cfg *config.Config, units []*parser.EndpointUnit) {
+	consumed := make(map[circuit.SemanticType]bool)
+	for _, ep := range cfg.Endpoints {
+		if ep.Disabled {
+			continue
+		}
+		for _, param := range ep.PathParams {
+			if param.SemanticType != "" {
+				consumed[param.SemanticType] = true
+			}
+		}
+	}
+
+	produced := make(map[circuit.SemanticType]bool)
+	for i := range cfg.Endpoints {
+		ep := &cfg.Endpoints[i]
+		if ep.Disabled {
+			continue
+		}
+		pins := slices.Clone(units[i].OutputPins)
+		// Prefer top-level fields such as $.data.id over nested ones
+		sort.SliceStable(pins, func(a, b int) bool { return len(pins[a].JSONPath) < len(pins[b].JSONPath) })
+
+		seen := make(map[circuit.SemanticType]bool)
+		for _, pin := range pins {
+			if !consumed[pin.SemanticType] || seen[pin.SemanticType] || envelopeField(pin.JSONPath) {
+				continue
+			}
+			seen[pin.SemanticType] = true
+			produced[pin.SemanticType] = true
+			ep.Produces = append(ep.Produces, config.ProducesConfig{
+				SemanticType: pin.SemanticType,
+				JSONPath:     pin.JSONPath,
+				Multiple:     strings.Contains(pin.JSONPath, "[*]"),
+			})
+		}
+	}
+
+	if len(produced) == 0 {
+		return
+	}
+	cfg.Warmup = warmup.DefaultConfig()
+	for semanticType := range produced {
+		cfg.Warmup.Fill = append(cfg.Warmup.Fill, semanticType)
+	}
+	slices.Sort(cfg.Warmup.Fill)
+}
+
+// envelopeField reports whether jsonPath points into a response's error or
+// meta envelope rather than its payload.
+func envelopeField(jsonPath string) bool {
+	return strings.HasPrefix(jsonPath, "$.error.") || strings.HasPrefix(jsonPath, "$.meta.")
+}
+
+// known reports whether an inference result names a real semantic type.
+func known(semanticType circuit.SemanticType) bool {
+	return semanticType != "" && semanticType != circuit.UnknownSemanticType
+}
+
+// uniqueName returns name, suffixed with a counter if it is already taken.
+func uniqueName(name string, taken map[string]bool) string {
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s.%d", name, i)
+	}
+	taken[candidate] = true
+	return candidate
+}
+
+// baseURL derives the target base URL from the spec's first server.
+func baseURL(spec *parser.OpenAPISpec) string {
+	host := strings.TrimSuffix(spec.Host, "/")
+	switch {
+	case strings.HasPrefix(host, "http://"), strings.HasPrefix(host, "https://"):
+		return host
+	case strings.HasPrefix(host, "//"):
+		return "http:" + host
+	case strings.HasPrefix(host, "/"):
+		return DefaultBaseURL + host
+	}
+	return DefaultBaseURL
+}
+
+// specLabel names a spec in generated text.
+func specLabel(spec *parser.OpenAPISpec) string {
+	if spec.Title == "" {
+		return "an OpenAPI spec"
+	}
+	return fmt.Sprintf("%q (OpenAPI %s)", spec.Title, spec.Version)
+}
@@ -0,0 +1,370 @@
+package configgen
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/erp/tools/loadgen/internal/circuit"
+	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/parser"
+)
+
+const ordersSpec = `
+openapi: "3.0.0"
+info:
+  title: Orders API
+  version: "1.0"
+servers:
+  - url: https://orders.example.com/api
+components:
+  securitySchemes:
+    BearerAuth:
+      type: http
+      scheme: bearer
+security:
+  - BearerAuth: []
+paths:
+  /auth/login:
+    post:
+      operationId: login
+      security: []
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: object
+                    properties:
+                      access_token:
+                        type: string
+  /auth/logout:
+    post:
+      operationId: logout
+      responses:
+        "204":
+          description: No Content
+  /orders:
+    get:
+      operationId: listOrders
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: string
+        - name: warehouse
+          in: query
+          required: true
+          schema:
+            type: string
+            default: main
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createOrder
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [customer_id, quantity, channel, lines]
+              properties:
+                customer_id:
+                  type: string
+                  format: uuid
+                quantity:
+                  type: integer
+                channel:
+                  type: string
+                  enum: [web, store]
+                lines:
+                  type: array
+                  items:
+                    type: object
+                note:
+                  type: string
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: object
+                    properties:
+                      id:
+                        type: string
+                      order_id:
+                        type: string
+                  error:
+                    type: object
+                    properties:
+                      order_id:
+                        type: string
+  /orders/{order_id}:
+    get:
+      operationId: getOrder
+      parameters:
+        - name: order_id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+      responses:
+        "200":
+          description: OK
+    delete:
+      operationId: deleteOrder
+      parameters:
+        - name: order_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: No Content
+  /orders/legacy:
+    get:
+      operationId: legacyOrders
+      deprecated: true
+      responses:
+        "200":
+          description: OK
+`
+
+func parseSpec(t *testing.T, spec string) *parser.OpenAPISpec {
+	t.Helper()
+	result, err := parser.NewParser().ParseBytes([]byte(spec))
+	require.NoError(t, err)
+	return result
+}
+
+func TestGenerate(t *testing.T) {
+	cfg, err := Generate(parseSpec(t, ordersSpec), Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Orders API Load Test", cfg.Name)
+	assert.Equal(t, "https://orders.example.com/api", cfg.Target.BaseURL)
+
+	t.Run("auth logs in through the login endpoint", func(t *testing.T) {
+		assert.Equal(t, "login", cfg.Auth.Type)
+		require.NotNil(t, cfg.Auth.Login)
+		assert.Equal(t, "/auth/login", cfg.Auth.Login.Endpoint)
+		assert.Equal(t, "POST", cfg.Auth.Login.Method)
+		assert.Equal(t, "$.data.access_token", cfg.Auth.Login.TokenPath)
+
+		assert.True(t, cfg.GetEndpointByName("login").Disabled)
+		assert.True(t, cfg.GetEndpointByName("logout").Disabled)
+		assert.False(t, *cfg.GetEndpointByName("login").RequiresAuth)
+	})
+
+	t.Run("deprecated endpoints are skipped", func(t *testing.T) {
+		assert.Nil(t, cfg.GetEndpointByName("legacyOrders"))
+		assert.Len(t, cfg.Endpoints, 6)
+	})
+
+	t.Run("reads outweigh writes", func(t *testing.T) {
+		get := cfg.GetEndpointByName("getOrder").Weight
+		assert.Greater(t, get, cfg.GetEndpointByName("createOrder").Weight)
+		assert.Greater(t, cfg.GetEndpointByName("createOrder").Weight, cfg.GetEndpointByName("deleteOrder").Weight)
+		assert.Equal(t, get, cfg.GetEndpointByName("listOrders").Weight)
+	})
+
+	t.Run("path and query parameters", func(t *testing.T) {
+		param := cfg.GetEndpointByName("getOrder").PathParams["order_id"]
+		assert.Equal(t, circuit.SemanticType("order.sales.id"), param.SemanticType)
+		assert.Equal(t, "string", param.Type)
+		assert.Equal(t, "uuid", param.Format)
+
+		query := cfg.GetEndpointByName("listOrders").QueryParams
+		assert.NotContains(t, query, "status", "optional filters are left out")
+		assert.Equal(t, "main", query["warehouse"].Value)
+	})
+
+	t.Run("body template", func(t *testing.T) {
+		body := cfg.GetEndpointByName("createOrder").Body
+		assert.Contains(t, body, `"customer_id": "{{.entity.customer.id}}"`)
+		assert.Contains(t, body, `"quantity": {{.common.quantity}}`)
+		assert.Contains(t, body, `"channel": "web"`)
+		assert.Contains(t, body, `"lines": []`)
+		assert.NotContains(t, body, "note", "optional properties are left out")
+
+		expanded := regexp.MustCompile(`\{\{\.[^}]+\}\}`).ReplaceAllString(body, "1")
+		assert.True(t, json.Valid([]byte(expanded)), expanded)
+	})
+
+	t.Run("producers feed path parameters", func(t *testing.T) {
+		produces := cfg.GetEndpointByName("createOrder").Produces
+		require.Len(t, produces, 1)
+		assert.Equal(t, config.ProducesConfig{SemanticType: "order.sales.id", JSONPath: "$.data.order_id"}, produces[0])
+		assert.Equal(t, []circuit.SemanticType{"order.sales.id"}, cfg.Warmup.Fill)
+	})
+}
+
+func TestGenerate_Auth(t *testing.T) {
+	tests := []struct {
+		name    string
+		schemes string
+		check   func(t *testing.T, auth config.AuthConfig)
+	}{
+		{
+			name: "api key header",
+			schemes: `
+    Key:
+      type: apiKey
+      in: header
+      name: X-Tenant-Key`,
+			check: func(t *testing.T, auth config.AuthConfig) {
+				assert.Equal(t, "api_key", auth.Type)
+				require.NotNil(t, auth.APIKey)
+				assert.Equal(t, "X-Tenant-Key", auth.APIKey.Header)
+			},
+		},
+		{
+			name: "bearer without login endpoint",
+			schemes: `
+    Key:
+      type: http
+      scheme: bearer`,
+			check: func(t *testing.T, auth config.AuthConfig) {
+				assert.Equal(t, "bearer", auth.Type)
+				require.NotNil(t, auth.Bearer)
+				assert.NotEmpty(t, auth.Bearer.Token)
+			},
+		},
+		{
+			name: "basic",
+			schemes: `
+    Key:
+      type: http
+      scheme: basic`,
+			check: func(t *testing.T, auth config.AuthConfig) {
+				assert.Equal(t, "basic", auth.Type)
+				require.NotNil(t, auth.Login)
+				assert.NotEmpty(t, auth.Login.Username)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := `
+openapi: "3.0.0"
+info:
+  title: Items
+  version: "1.0"
+components:
+  securitySchemes:` + tt.schemes + `
+security:
+  - Key: []
+paths:
+  /items:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+			cfg, err := Generate(parseSpec(t, spec), Options{Name: "items", BaseURL: "http://items:9000"})
+			require.NoError(t, err)
+			assert.Equal(t, "items", cfg.Name)
+			assert.Equal(t, "http://items:9000", cfg.Target.BaseURL)
+			tt.check(t, cfg.Auth)
+		})
+	}
+
+	t.Run("no security", func(t *testing.T) {
+		spec := `
+openapi: "3.0.0"
+info:
+  title: Items
+  version: "1.0"
+paths:
+  /items:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+		cfg, err := Generate(parseSpec(t, spec), Options{})
+		require.NoError(t, err)
+		assert.Equal(t, "none", cfg.Auth.Type)
+		assert.Equal(t, DefaultBaseURL, cfg.Target.BaseURL)
+	})
+}
+
+func TestGenerate_NoEndpoints(t *testing.T) {
+	spec := `
+openapi: "3.0.0"
+info:
+  title: Empty
+  version: "1.0"
+paths:
+  /old:
+    get:
+      deprecated: true
+      responses:
+        "200":
+          description: OK
+`
+	_, err := Generate(parseSpec(t, spec), Options{})
+	assert.ErrorIs(t, err, ErrNoEndpoints)
+}
+
+func TestGenerate_UniqueNames(t *testing.T) {
+	taken := make(map[string]bool)
+	assert.Equal(t, "get.orders", uniqueName("get.orders", taken))
+	assert.Equal(t, "get.orders.2", uniqueName("get.orders", taken))
+	assert.Equal(t, "get.orders.3", uniqueName("get.orders", taken))
+}
+
+// TestGenerate_ERPSwagger generates a config from the ERP backend spec and
+// checks that the written YAML loads and validates.
+func TestGenerate_ERPSwagger(t *testing.T) {
+	swaggerPath := "../../../../backend/docs/swagger.yaml"
+	if _, err := os.Stat(swaggerPath); os.IsNotExist(err) {
+		t.Skip("ERP swagger.yaml not found, skipping integration test")
+	}
+
+	spec, err := parser.NewParser().ParseFile(swaggerPath)
+	require.NoError(t, err)
+
+	active := 0
+	for _, ep := range spec.Endpoints {
+		if !ep.Deprecated {
+			active++
+		}
+	}
+
+	cfg, err := Generate(spec, Options{})
+	require.NoError(t, err)
+	data, err := Marshal(cfg, spec)
+	require.NoError(t, err)
+
+	loaded, err := config.LoadFromBytes(data)
+	require.NoError(t, err)
+	assert.Len(t, loaded.Endpoints, active)
+	assert.Equal(t, "login", loaded.Auth.Type)
+	assert.Equal(t, "/auth/login", loaded.Auth.Login.Endpoint)
+
+	for _, ep := range loaded.Endpoints {
+		if ep.Body == "" {
+			continue
+		}
+		expanded := regexp.MustCompile(`\{\{\.[^}]+\}\}`).ReplaceAllString(ep.Body, "1")
+		assert.True(t, json.Valid([]byte(expanded)), "%s body: %s", ep.Name, ep.Body)
+	}
+}
@@ -0,0 +1,190 @@
+// Package generator provides data generation capabilities for the load generator.
+package generator
+
+import (
+	"strings"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+
+	"github.com/example/erp/tools/loadgen/internal/circuit"
+)
+
+// TypeSemantic identifies a built-in generator chosen by semantic type.
+const TypeSemantic GeneratorType = "semantic"
+
+// semanticFaker backs the built-in semantic generators. It is created in
+// lock mode, so it is safe to share across goroutines.
+var semanticFaker = gofakeit.New(0)
+
+// SemanticGenerator produces values for a semantic or data type from a
+// built-in rule rather than from configuration.
+type SemanticGenerator struct {
+	genFn func(*gofakeit.Faker) any
+}
+
+// Generate produces a new value.
+func (g *SemanticGenerator) Generate() (any, error) {
+	return g.genFn(semanticFaker), nil
+}
+
+// Type returns the generator type.
+func (g *SemanticGenerator) Type() GeneratorType {
+	return TypeSemantic
+}
+
+// semanticTypeFunctions holds rules for semantic types whose field name alone
+// would pick the wrong value, e.g. a currency code is not a business code.
+var semanticTypeFunctions = map[circuit.SemanticType]func(*gofakeit.Faker) any{
+	circuit.FinanceCurrencyCode: func(f *gofakeit.Faker) any { return f.CurrencyShort() },
+	circuit.FinanceCurrencyRate: func(f *gofakeit.Faker) any { return f.Float64Range(0.1, 10) },
+	circuit.EntityProductName:   func(f *gofakeit.Faker) any { return f.ProductName() },
+	circuit.EntityCustomerName:  func(f *gofakeit.Faker) any { return f.Company() },
+	circuit.EntitySupplierName:  func(f *gofakeit.Faker) any { return f.Company() },
+	circuit.EntityTenantName:    func(f *gofakeit.Faker) any { return f.Company() },
+	circuit.EntityBrandName:     func(f *gofakeit.Faker) any { return f.Company() },
+}
+
+// semanticFieldFunctions maps the field part of a semantic type ("id" in
+// "entity.customer.id") to a generator function.
+var semanticFieldFunctions = map[string]func(*gofakeit.Faker) any{
+	// Identifiers
+	"id":        uuidValue,
+	"uuid":      uuidValue,
+	"parent_id": uuidValue,
+	"ref_id":    uuidValue,
+
+	// Codes and document numbers
+	"code": func(f *gofakeit.Faker) any { return "GEN-" + strings.ToUpper(randomAlphanumeric(8)) },
+	"sku":  func(f *gofakeit.Faker) any { return "SKU-" + strings.ToUpper(randomAlphanumeric(8)) },
+	"number": func(f *gofakeit.Faker) any {
+		return "NO-" + time.Now().Format("20060102") + "-" + randomString(6, numericChars)
+	},
+	"batch": func(f *gofakeit.Faker) any { return "B" + randomString(8, numericChars) },
+
+	// Contact
+	"email":    func(f *gofakeit.Faker) any { return strings.ToLower(f.Email()) },
+	"phone":    func(f *gofakeit.Faker) any { return f.Phone() },
+	"address":  func(f *gofakeit.Faker) any { return f.Street() + ", " + f.City() },
+	"username": func(f *gofakeit.Faker) any { return "user_" + strings.ToLower(randomAlphanumeric(10)) },
+
+	// Text
+	"name":        func(f *gofakeit.Faker) any { return f.Name() },
+	"title":       func(f *gofakeit.Faker) any { return f.Sentence(3) },
+	"description": sentenceValue,
+	"note":        sentenceValue,
+	"remark":      sentenceValue,
+	"comment":     sentenceValue,
+	"reason":      sentenceValue,
+	"keyword":     func(f *gofakeit.Faker) any { return f.Word() },
+	"query":       func(f *gofakeit.Faker) any { return f.Word() },
+
+	// Money and quantities
+	"amount":   amountValue,
+	"price":    amountValue,
+	"balance":  amountValue,
+	"quantity": func(f *gofakeit.Faker) any { return f.IntRange(1, 100) },
+	"count":    func(f *gofakeit.Faker) any { return f.IntRange(1, 100) },
+	"discount": percentValue,
+	"tax":      percentValue,
+	"rate":     percentValue,
+	"percent":  percentValue,
+	"version":  func(f *gofakeit.Faker) any { return f.IntRange(1, 10) },
+
+	// Dates and times
+	"date":       func(f *gofakeit.Faker) any { return recentTime(f).Format(time.DateOnly) },
+	"time":       func(f *gofakeit.Faker) any { return recentTime(f).Format(time.TimeOnly) },
+	"datetime":   dateTimeValue,
+	"timestamp":  dateTimeValue,
+	"created_at": dateTimeValue,
+	"updated_at": dateTimeValue,
+
+	// Pagination and sorting
+	"page":       func(f *gofakeit.Faker) any { return f.IntRange(1, 5) },
+	"page_size":  pageSizeValue,
+	"limit":      pageSizeValue,
+	"offset":     func(f *gofakeit.Faker) any { return f.IntRange(0, 100) },
+	"sort_order": func(f *gofakeit.Faker) any { return f.RandomString([]string{"asc", "desc"}) },
+
+	// Flags
+	"enabled":  boolValue,
+	"active":   boolValue,
+	"deleted":  boolValue,
+	"required": boolValue,
+}
+
+// HasSemanticGenerator reports whether a built-in rule covers semanticType,
+// as opposed to the data type fallback of ForSemanticType.
+func HasSemanticGenerator(semanticType circuit.SemanticType) bool {
+	if _, ok := semanticTypeFunctions[semanticType]; ok {
+		return true
+	}
+	_, ok := semanticFieldFunctions[semanticType.Field()]
+	return ok
+}
+
+// ForSemanticType returns a generator for semanticType. A generator
+// registered under the semantic type name (e.g. from dataGenerators config)
+// wins, then the built-in rule for the semantic type, then a generator for
+// the parameter's OpenAPI dataType and format.
+func (r *Registry) ForSemanticType(semanticType circuit.SemanticType, dataType, format string) Generator {
+	if gen, err := r.Get(string(semanticType)); err == nil {
+		return gen
+	}
+	if genFn, ok := semanticTypeFunctions[semanticType]; ok {
+		return &SemanticGenerator{genFn: genFn}
+	}
+	if genFn, ok := semanticFieldFunctions[semanticType.Field()]; ok {
+		return &SemanticGenerator{genFn: genFn}
+	}
+	return ForDataType(dataType, format)
+}
+
+// ForDataType returns a generator for an OpenAPI data type and format.
+// Known string formats are honoured; anything else yields a random string.
+func ForDataType(dataType, format string) Generator {
+	switch format {
+	case "uuid":
+		return &SemanticGenerator{genFn: uuidValue}
+	case "email":
+		return &SemanticGenerator{genFn: semanticFieldFunctions["email"]}
+	case "date":
+		return &SemanticGenerator{genFn: semanticFieldFunctions["date"]}
+	case "date-time":
+		return &SemanticGenerator{genFn: dateTimeValue}
+	}
+
+	switch dataType {
+	case "integer":
+		return &SemanticGenerator{genFn: func(f *gofakeit.Faker) any { return f.IntRange(1, 1000) }}
+	case "number":
+		return &SemanticGenerator{genFn: amountValue}
+	case "boolean":
+		return &SemanticGenerator{genFn: boolValue}
+	default:
+		return &SemanticGenerator{genFn: func(f *gofakeit.Faker) any { return "gen-" + randomAlphanumeric(8) }}
+	}
+}
+
+func uuidValue(*gofakeit.Faker) any { return uuid.New().String() }
+
+func sentenceValue(f *gofakeit.Faker) any { return f.Sentence(8) }
+
+// amountValue returns a positive amount with two decimal places.
+func amountValue(f *gofakeit.Faker) any { return float64(f.IntRange(100, 1000000)) / 100 }
+
+func percentValue(f *gofakeit.Faker) any { return float64(f.IntRange(0, 10000)) / 100 }
+
+func pageSizeValue(f *gofakeit.Faker) any { return f.RandomInt([]int{10, 20, 50}) }
+
+func boolValue(f *gofakeit.Faker) any { return f.Bool() }
+
+func dateTimeValue(f *gofakeit.Faker) any { return recentTime(f).Format(time.RFC3339) }
+
+// recentTime returns a time within the last 30 days, so generated dates
+// stay inside the ranges typical list filters and validations accept.
+func recentTime(f *gofakeit.Faker) time.Time {
+	now := time.Now().UTC()
+	return f.DateRange(now.AddDate(0, 0, -30), now).Truncate(time.Second)
+}
@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/erp/tools/loadgen/internal/circuit"
+)
+
+// semanticChecks holds the validation a value for each semantic field must pass.
+var semanticChecks = map[string]func(t *testing.T, v any){
+	"id":        isUUID,
+	"uuid":      isUUID,
+	"parent_id": isUUID,
+	"ref_id":    isUUID,
+
+	"code":   matches(`^[A-Z]+-[A-Z0-9]{8}$`),
+	"sku":    matches(`^SKU-[A-Z0-9]{8}$`),
+	"number": matches(`^NO-\d{8}-\d{6}$`),
+	"batch":  matches(`^B\d{8}$`),
+
+	"email": func(t *testing.T, v any) {
+		addr, err := mail.ParseAddress(asString(t, v))
+		require.NoError(t, err)
+		assert.Equal(t, v, addr.Address, "bare address")
+	},
+	"phone":    matches(`^\d{10}$`),
+	"address":  nonEmptyString,
+	"username": matches(`^user_[a-z0-9]{10}$`),
+
+	"name":        nonEmptyString,
+	"title":       nonEmptyString,
+	"description": nonEmptyString,
+	"note":        nonEmptyString,
+	"remark":      nonEmptyString,
+	"comment":     nonEmptyString,
+	"reason":      nonEmptyString,
+	"keyword":     nonEmptyString,
+	"query":       nonEmptyString,
+
+	"amount":   isAmount,
+	"price":    isAmount,
+	"balance":  isAmount,
+	"quantity": intBetween(1, 100),
+	"count":    intBetween(1, 100),
+	"discount": floatBetween(0, 100),
+	"tax":      floatBetween(0, 100),
+	"rate":     floatBetween(0, 100),
+	"percent":  floatBetween(0, 100),
+	"version":  intBetween(1, 10),
+
+	"date":       parsesAs(time.DateOnly),
+	"time":       parsesAs(time.TimeOnly),
+	"datetime":   parsesAs(time.RFC3339),
+	"timestamp":  parsesAs(time.RFC3339),
+	"created_at": parsesAs(time.RFC3339),
+	"updated_at": parsesAs(time.RFC3339),
+
+	"page":       intBetween(1, 5),
+	"page_size":  oneOf(10, 20, 50),
+	"limit":      oneOf(10, 20, 50),
+	"offset":     intBetween(0, 100),
+	"sort_order": oneOf("asc", "desc"),
+
+	"enabled":  isBool,
+	"active":   isBool,
+	"deleted":  isBool,
+	"required": isBool,
+}
+
+// semanticTypeChecks overrides semanticChecks for types with their own rule.
+var semanticTypeChecks = map[circuit.SemanticType]func(t *testing.T, v any){
+	circuit.FinanceCurrencyCode: matches(`^[A-Z]{3}$`),
+	circuit.FinanceCurrencyRate: floatBetween(0.1, 10),
+}
+
+func TestForSemanticType_BuiltInValuesAreValid(t *testing.T) {
+	registry := NewRegistry()
+	covered := 0
+
+	for _, st := range circuit.AllSemanticTypes() {
+		if !HasSemanticGenerator(st) {
+			continue
+		}
+		covered++
+
+		t.Run(string(st), func(t *testing.T) {
+			check, ok := semanticTypeChecks[st]
+			if !ok {
+				check, ok = semanticChecks[st.Field()]
+			}
+			require.True(t, ok, "no validation for %s", st)
+
+			gen := registry.ForSemanticType(st, "", "")
+			assert.Equal(t, TypeSemantic, gen.Type())
+			for range 20 {
+				v, err := gen.Generate()
+				require.NoError(t, err)
+				check(t, v)
+			}
+		})
+	}
+
+	assert.Greater(t, covered, 100, "most semantic types have a built-in generator")
+}
+
+func TestForSemanticType_ConfiguredGeneratorWins(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.LoadFromConfig(map[string]Config{
+		string(circuit.EntityCustomerCode): {
+			Type:     TypeSequence,
+			Sequence: &SequenceConfig{Prefix: "CUST-", Padding: 3},
+		},
+	}))
+
+	gen := registry.ForSemanticType(circuit.EntityCustomerCode, "string", "")
+	assert.Equal(t, TypeSequence, gen.Type())
+	v, err := gen.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, "CUST-001", v)
+
+	// Other codes still use the built-in rule
+	assert.Equal(t, TypeSemantic, registry.ForSemanticType(circuit.EntityProductCode, "", "").Type())
+}
+
+func TestForSemanticType_FallsBackToDataType(t *testing.T) {
+	registry := NewRegistry()
+	unknown := circuit.SemanticType("custom.widget.color")
+	require.False(t, HasSemanticGenerator(unknown))
+
+	tests := []struct {
+		dataType string
+		format   string
+		check    func(t *testing.T, v any)
+	}{
+		{dataType: "string", check: matches(`^gen-[A-Za-z0-9]{8}$`)},
+		{check: matches(`^gen-[A-Za-z0-9]{8}$`)},
+		{dataType: "integer", check: intBetween(1, 1000)},
+		{dataType: "number", check: isAmount},
+		{dataType: "boolean", check: isBool},
+		{dataType: "string", format: "uuid", check: isUUID},
+		{dataType: "string", format: "email", check: semanticChecks["email"]},
+		{dataType: "string", format: "date", check: parsesAs(time.DateOnly)},
+		{dataType: "string", format: "date-time", check: parsesAs(time.RFC3339)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dataType+"/"+tt.format, func(t *testing.T) {
+			v, err := registry.ForSemanticType(unknown, tt.dataType, tt.format).Generate()
+			require.NoError(t, err)
+			tt.check(t, v)
+		})
+	}
+}
+
+func TestSemanticGenerator_ConcurrentUse(t *testing.T) {
+	gen := NewRegistry().ForSemanticType(circuit.CommonEmail, "", "")
+
+	done := make(chan struct{})
+	for range 10 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for range 100 {
+				_, _ = gen.Generate()
+			}
+		}()
+	}
+	for range 10 {
+		<-done
+	}
+}
+
+func asString(t *testing.T, v any) string {
+	t.Helper()
+	s, ok := v.(string)
+	require.True(t, ok, "want string, got %T", v)
+	return s
+}
+
+func nonEmptyString(t *testing.T, v any) {
+	assert.NotEmpty(t, strings.TrimSpace(asString(t, v)))
+}
+
+func matches(pattern string) func(t *testing.T, v any) {
+	re := regexp.MustCompile(pattern)
+	return func(t *testing.T, v any) {
+		assert.Regexp(t, re, asString(t, v))
+	}
+}
+
+func isUUID(t *testing.T, v any) {
+	_, err := uuid.Parse(asString(t, v))
+	assert.NoError(t, err)
+}
+
+func parsesAs(layout string) func(t *testing.T, v any) {
+	return func(t *testing.T, v any) {
+		_, err := time.Parse(layout, asString(t, v))
+		assert.NoError(t, err)
+	}
+}
+
+// isAmount checks for a positive amount with at most two decimal places.
+func isAmount(t *testing.T, v any) {
+	f, ok := v.(float64)
+	require.True(t, ok, "want float64, got %T", v)
+	assert.Greater(t, f, 0.0)
+	cents := f * 100
+	assert.InDelta(t, float64(int64(cents+0.5)), cents, 1e-6, "%v has more than two decimals", f)
+}
+
+func intBetween(lo, hi int) func(t *testing.T, v any) {
+	return func(t *testing.T, v any) {
+		n, ok := v.(int)
+		require.True(t, ok, "want int, got %T", v)
+		assert.GreaterOrEqual(t, n, lo)
+		assert.LessOrEqual(t, n, hi)
+	}
+}
+
+func floatBetween(lo, hi float64) func(t *testing.T, v any) {
+	return func(t *testing.T, v any) {
+		f, ok := v.(float64)
+		require.True(t, ok, "want float64, got %T", v)
+		assert.GreaterOrEqual(t, f, lo)
+		assert.LessOrEqual(t, f, hi)
+	}
+}
+
+func oneOf[T comparable](allowed ...T) func(t *testing.T, v any) {
+	return func(t *testing.T, v any) {
+		assert.Contains(t, allowed, v)
+	}
+}
+
+func isBool(t *testing.T, v any) {
+	assert.IsType(t, true, v)
+}
@@ -21,10 +21,11 @@ type Collector struct {
 	mu sync.RWMutex
 
 	// Global counters
-	totalRequests   atomic.Int64
-	successRequests atomic.Int64
-	failedRequests  atomic.Int64
-	totalBytes      atomic.Int64
+	totalRequests     atomic.Int64
+	successRequests   atomic.Int64
+	failedRequests    atomic.Int64
+	assertionFailures atomic.Int64
+	totalBytes        atomic.Int64
 
 	// Latency tracking (stored as nanoseconds for precision)
 	latencies    []int64
@@ -76,16 +77,17 @@ func DefaultCollectorConfig() CollectorConfig {
 type EndpointStats struct {
 	mu sync.RWMutex
 
-	Name             string
-	TotalRequests    int64
-	SuccessRequests  int64
-	FailedRequests   int64
-	TotalLatencyNs   int64
-	MinLatency       time.Duration
-	MaxLatency       time.Duration
-	TotalBytes       int64
-	latencies        []int64
-	maxLatencySample int
+	Name              string
+	TotalRequests     int64
+	SuccessRequests   int64
+	FailedRequests    int64
+	AssertionFailures int64
+	TotalLatencyNs    int64
+	MinLatency        time.Duration
+	MaxLatency        time.Duration
+	TotalBytes        int64
+	latencies         []int64
+	maxLatencySample  int
 }
 
 // Result represents the result of a single request.
@@ -99,6 +101,11 @@ type Result struct {
 	ResponseSize int64
 	Timestamp    time.Time
 	Error        error
+
+	// AssertionFailed marks a response that arrived but broke the endpoint's
+	// expected status codes or body assertions, as opposed to a transport error.
+	// Such results are also counted as failed.
+	AssertionFailed bool
 }
 
 // Snapshot represents a point-in-time snapshot of all metrics.
@@ -114,6 +121,10 @@ type Snapshot struct {
 	FailedRequests  int64
 	TotalBytes      int64
 
+	// AssertionFailures counts the failed requests that got a response
+	// which broke the endpoint's expectations.
+	AssertionFailures int64
+
 	// Latency distribution
 	MinLatency time.Duration
 	AvgLatency time.Duration
@@ -135,19 +146,20 @@ type Snapshot struct {
 
 // EndpointSnapshot represents a snapshot of endpoint statistics.
 type EndpointSnapshot struct {
-	Name            string
-	TotalRequests   int64
-	SuccessRequests int64
-	FailedRequests  int64
-	TotalBytes      int64
-	MinLatency      time.Duration
-	AvgLatency      time.Duration
-	P50Latency      time.Duration
-	P95Latency      time.Duration
-	P99Latency      time.Duration
-	MaxLatency      time.Duration
-	SuccessRate     float64
-	QPS             float64
+	Name              string
+	TotalRequests     int64
+	SuccessRequests   int64
+	FailedRequests    int64
+	AssertionFailures int64
+	TotalBytes        int64
+	MinLatency        time.Duration
+	AvgLatency        time.Duration
+	P50Latency        time.Duration
+	P95Latency        time.Duration
+	P99Latency        time.Duration
+	MaxLatency        time.Duration
+	SuccessRate       float64
+	QPS               float64
 }
 
 // NewCollector creates a new metrics collector.
@@ -188,6 +200,9 @@ func (c *Collector) Record(result Result) {
 	} else {
 		c.failedRequests.Add(1)
 	}
+	if result.AssertionFailed {
+		c.assertionFailures.Add(1)
+	}
 	c.totalBytes.Add(result.ResponseSize)
 
 	// Record latency
@@ -254,6 +269,9 @@ func (c *Collector) recordEndpointResult(result Result) {
 	} else {
 		stats.FailedRequests++
 	}
+	if result.AssertionFailed {
+		stats.AssertionFailures++
+	}
 
 	latencyNs := result.Latency.Nanoseconds()
 	stats.TotalLatencyNs += latencyNs
@@ -296,6 +314,7 @@ func (c *Collector) Snapshot() Snapshot {
 	totalRequests := c.totalRequests.Load()
 	successRequests := c.successRequests.Load()
 	failedRequests := c.failedRequests.Load()
+	assertionFailures := c.assertionFailures.Load()
 	totalBytes := c.totalBytes.Load()
 
 	// Calculate latency percentiles
@@ -320,23 +339,24 @@ func (c *Collector) Snapshot() Snapshot {
 	endpointStats := c.copyEndpointStats(duration)
 
 	return Snapshot{
-		StartTime:       startTime,
-		EndTime:         endTime,
-		Duration:        duration,
-		TotalRequests:   totalRequests,
-		SuccessRequests: successRequests,
-		FailedRequests:  failedRequests,
-		TotalBytes:      totalBytes,
-		MinLatency:      minLat,
-		AvgLatency:      avgLat,
-		P50Latency:      p50Lat,
-		P95Latency:      p95Lat,
-		P99Latency:      p99Lat,
-		MaxLatency:      maxLat,
-		SuccessRate:     successRate,
-		QPS:             qps,
-		StatusCodes:     statusCodes,
-		EndpointStats:   endpointStats,
+		StartTime:         startTime,
+		EndTime:           endTime,
+		Duration:          duration,
+		TotalRequests:     totalRequests,
+		SuccessRequests:   successRequests,
+		FailedRequests:    failedRequests,
+		AssertionFailures: assertionFailures,
+		TotalBytes:        totalBytes,
+		MinLatency:        minLat,
+		AvgLatency:        avgLat,
+		P50Latency:        p50Lat,
+		P95Latency:        p95Lat,
+		P99Latency:        p99Lat,
+		MaxLatency:        maxLat,
+		SuccessRate:       successRate,
+		QPS:               qps,
+		StatusCodes:       statusCodes,
+		EndpointStats:     endpointStats,
 	}
 }
 
@@ -412,13 +432,14 @@ func (s *EndpointStats) snapshot(totalDuration time.Duration) *EndpointSnapshot
 	defer s.mu.RUnlock()
 
 	snapshot := &EndpointSnapshot{
-		Name:            s.Name,
-		TotalRequests:   s.TotalRequests,
-		SuccessRequests: s.SuccessRequests,
-		FailedRequests:  s.FailedRequests,
-		TotalBytes:      s.TotalBytes,
-		MinLatency:      s.MinLatency,
-		MaxLatency:      s.MaxLatency,
+		Name:              s.Name,
+		TotalRequests:     s.TotalRequests,
+		SuccessRequests:   s.SuccessRequests,
+		FailedRequests:    s.FailedRequests,
+		AssertionFailures: s.AssertionFailures,
+		TotalBytes:        s.TotalBytes,
+		MinLatency:        s.MinLatency,
+		MaxLatency:        s.MaxLatency,
 	}
 
 	// Calculate average latency
@@ -457,6 +478,7 @@ func (c *Collector) Reset() {
 	c.totalRequests.Store(0)
 	c.successRequests.Store(0)
 	c.failedRequests.Store(0)
+	c.assertionFailures.Store(0)
 	c.totalBytes.Store(0)
 
 	c.latencyMu.Lock()
@@ -270,6 +270,24 @@ func TestCollector_Reset(t *testing.T) {
 	assert.Empty(t, snapshot.EndpointStats)
 }
 
+func TestCollector_AssertionFailures(t *testing.T) {
+	c := NewCollector(DefaultCollectorConfig())
+	c.Start()
+
+	c.Record(Result{EndpointName: "orders.get", StatusCode: 200, Success: true})
+	c.Record(Result{EndpointName: "orders.get", StatusCode: 200, AssertionFailed: true})
+	c.Record(Result{EndpointName: "orders.list", StatusCode: 0})
+
+	snapshot := c.Snapshot()
+	assert.Equal(t, int64(2), snapshot.FailedRequests)
+	assert.Equal(t, int64(1), snapshot.AssertionFailures)
+	assert.Equal(t, int64(1), snapshot.EndpointStats["orders.get"].AssertionFailures)
+	assert.Equal(t, int64(0), snapshot.EndpointStats["orders.list"].AssertionFailures)
+
+	c.Reset()
+	assert.Equal(t, int64(0), c.Snapshot().AssertionFailures)
+}
+
 func TestCollector_LatencyPercentiles(t *testing.T) {
 	c := NewCollector(DefaultCollectorConfig())
 	c.Start()
@@ -401,6 +401,10 @@ func (c *Console) PrintFinalReport(snapshot Snapshot) {
 		c.color(colorGreen), snapshot.SuccessRequests, c.color(colorReset))
 	fmt.Fprintf(w, "  Failed:            %s%d%s\n",
 		c.color(colorRed), snapshot.FailedRequests, c.color(colorReset))
+	if snapshot.AssertionFailures > 0 {
+		fmt.Fprintf(w, "    Assertions:      %s%d%s\n",
+			c.color(colorRed), snapshot.AssertionFailures, c.color(colorReset))
+	}
 	fmt.Fprintf(w, "  Success Rate:      %s%.2f%%%s\n",
 		c.successRateColor(snapshot.SuccessRate), snapshot.SuccessRate, c.color(colorReset))
 	fmt.Fprintf(w, "  Throughput:        %s%.2f req/s%s\n",
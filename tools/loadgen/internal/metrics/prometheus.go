@@ -28,6 +28,7 @@ const (
 	MetricRequestBytesTotal       = "loadgen_request_bytes_total"
 	MetricEndpointRequestsTotal   = "loadgen_endpoint_requests_total"
 	MetricEndpointDurationSeconds = "loadgen_endpoint_duration_seconds"
+	MetricAssertionFailuresTotal  = "loadgen_assertion_failures_total"
 )
 
 // PrometheusExporter exports metrics to Prometheus via an HTTP endpoint.
@@ -53,6 +54,7 @@ type PrometheusExporter struct {
 	successRate            prometheus.Gauge
 	activeWorkers          prometheus.Gauge
 	requestBytesTotal      prometheus.Counter
+	assertionFailuresTotal *prometheus.CounterVec
 
 	// HTTP server
 	server *http.Server
@@ -218,6 +220,17 @@ func (e *PrometheusExporter) initMetrics() {
 		},
 	)
 
+	// Counter for responses that broke the endpoint's expectations
+	e.assertionFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: e.config.Namespace,
+			Subsystem: e.config.Subsystem,
+			Name:      "assertion_failures_total",
+			Help:      "Total responses that failed their endpoint's status or body assertions.",
+		},
+		[]string{"endpoint"},
+	)
+
 	// Register all metrics with the registry
 	e.registry.MustRegister(
 		e.requestsTotal,
@@ -229,6 +242,7 @@ func (e *PrometheusExporter) initMetrics() {
 		e.successRate,
 		e.activeWorkers,
 		e.requestBytesTotal,
+		e.assertionFailuresTotal,
 	)
 }
 
@@ -312,6 +326,10 @@ func (e *PrometheusExporter) RecordRequest(result Result) {
 
 	// Record bytes
 	e.requestBytesTotal.Add(float64(result.ResponseSize))
+
+	if result.AssertionFailed {
+		e.assertionFailuresTotal.WithLabelValues(result.EndpointName).Inc()
+	}
 }
 
 // UpdateCurrentQPS updates the current QPS gauge.
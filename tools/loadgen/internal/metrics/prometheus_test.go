@@ -149,6 +149,23 @@ func TestPrometheusExporter_RecordRequest(t *testing.T) {
 	assert.Equal(t, float64(1024+256), bytesTotal.Metric[0].GetCounter().GetValue())
 }
 
+func TestPrometheusExporter_RecordAssertionFailure(t *testing.T) {
+	exporter := NewPrometheusExporter(PrometheusExporterConfig{})
+
+	exporter.RecordRequest(Result{EndpointName: "orders.get", StatusCode: 200, Success: true})
+	exporter.RecordRequest(Result{EndpointName: "orders.get", StatusCode: 200, AssertionFailed: true})
+	exporter.RecordRequest(Result{EndpointName: "orders.get", StatusCode: 0})
+
+	metricFamilies, err := exporter.Gather()
+	require.NoError(t, err)
+
+	failures := findMetricFamily(metricFamilies, "assertion_failures_total")
+	require.NotNil(t, failures, "assertion_failures_total metric should exist")
+	metric := findMetricByLabels(failures, map[string]string{"endpoint": "orders.get"})
+	require.NotNil(t, metric)
+	assert.Equal(t, 1.0, metric.GetCounter().GetValue(), "transport errors are not assertion failures")
+}
+
 func TestPrometheusExporter_UpdateGauges(t *testing.T) {
 	exporter := NewPrometheusExporter(PrometheusExporterConfig{})
 
@@ -126,10 +126,11 @@ type ReportSummary struct {
 	Duration  Duration  `json:"duration"`
 
 	// Request counts
-	TotalRequests   int64 `json:"totalRequests"`
-	SuccessRequests int64 `json:"successRequests"`
-	FailedRequests  int64 `json:"failedRequests"`
-	TotalBytes      int64 `json:"totalBytes"`
+	TotalRequests     int64 `json:"totalRequests"`
+	SuccessRequests   int64 `json:"successRequests"`
+	FailedRequests    int64 `json:"failedRequests"`
+	AssertionFailures int64 `json:"assertionFailures"`
+	TotalBytes        int64 `json:"totalBytes"`
 
 	// Derived metrics
 	SuccessRate float64 `json:"successRate"`
@@ -166,14 +167,15 @@ type LatencyBucket struct {
 
 // EndpointReport contains statistics for a single endpoint.
 type EndpointReport struct {
-	Name            string       `json:"name"`
-	TotalRequests   int64        `json:"totalRequests"`
-	SuccessRequests int64        `json:"successRequests"`
-	FailedRequests  int64        `json:"failedRequests"`
-	TotalBytes      int64        `json:"totalBytes"`
-	SuccessRate     float64      `json:"successRate"`
-	QPS             float64      `json:"qps"`
-	Latency         LatencyStats `json:"latency"`
+	Name              string       `json:"name"`
+	TotalRequests     int64        `json:"totalRequests"`
+	SuccessRequests   int64        `json:"successRequests"`
+	FailedRequests    int64        `json:"failedRequests"`
+	AssertionFailures int64        `json:"assertionFailures"`
+	TotalBytes        int64        `json:"totalBytes"`
+	SuccessRate       float64      `json:"successRate"`
+	QPS               float64      `json:"qps"`
+	Latency           LatencyStats `json:"latency"`
 }
 
 // ErrorEntry represents an error encountered during testing.
@@ -338,17 +340,18 @@ func (r *Reporter) buildSummary(snapshot Snapshot) ReportSummary {
 	}
 
 	return ReportSummary{
-		StartTime:       snapshot.StartTime,
-		EndTime:         snapshot.EndTime,
-		Duration:        Duration{snapshot.Duration},
-		TotalRequests:   snapshot.TotalRequests,
-		SuccessRequests: snapshot.SuccessRequests,
-		FailedRequests:  snapshot.FailedRequests,
-		TotalBytes:      snapshot.TotalBytes,
-		SuccessRate:     snapshot.SuccessRate,
-		QPS:             snapshot.QPS,
-		BytesPerSec:     bytesPerSec,
-		Latency:         r.convertLatencyStats(snapshot),
+		StartTime:         snapshot.StartTime,
+		EndTime:           snapshot.EndTime,
+		Duration:          Duration{snapshot.Duration},
+		TotalRequests:     snapshot.TotalRequests,
+		SuccessRequests:   snapshot.SuccessRequests,
+		FailedRequests:    snapshot.FailedRequests,
+		AssertionFailures: snapshot.AssertionFailures,
+		TotalBytes:        snapshot.TotalBytes,
+		SuccessRate:       snapshot.SuccessRate,
+		QPS:               snapshot.QPS,
+		BytesPerSec:       bytesPerSec,
+		Latency:           r.convertLatencyStats(snapshot),
 	}
 }
 
@@ -443,13 +446,14 @@ func (r *Reporter) buildEndpointReports(snapshot Snapshot) []EndpointReport {
 	for _, name := range names {
 		stats := snapshot.EndpointStats[name]
 		report := EndpointReport{
-			Name:            name,
-			TotalRequests:   stats.TotalRequests,
-			SuccessRequests: stats.SuccessRequests,
-			FailedRequests:  stats.FailedRequests,
-			TotalBytes:      stats.TotalBytes,
-			SuccessRate:     stats.SuccessRate,
-			QPS:             stats.QPS,
+			Name:              name,
+			TotalRequests:     stats.TotalRequests,
+			SuccessRequests:   stats.SuccessRequests,
+			FailedRequests:    stats.FailedRequests,
+			AssertionFailures: stats.AssertionFailures,
+			TotalBytes:        stats.TotalBytes,
+			SuccessRate:       stats.SuccessRate,
+			QPS:               stats.QPS,
 			Latency: LatencyStats{
 				MinMs: float64(stats.MinLatency.Nanoseconds()) / 1e6,
 				AvgMs: float64(stats.AvgLatency.Nanoseconds()) / 1e6,
@@ -1,5 +1,6 @@
 // Package parser provides OpenAPI specification parsing for the load generator.
-// It extracts endpoint definitions, parameters, and response schemas from OpenAPI 3.x specs.
+// It extracts endpoint definitions, parameters, and response schemas from OpenAPI 3.x specs;
+// Swagger 2.0 specs are converted to OpenAPI 3 first.
 package parser
 
 import (
@@ -290,6 +291,14 @@ func (p *Parser) ParseFile(path string) (*OpenAPISpec, error) {
 		return nil, fmt.Errorf("%w: %s", ErrSpecNotFound, path)
 	}
 
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	if isSwagger2(data) {
+		return p.parseSwagger2(data)
+	}
+
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
 
@@ -303,6 +312,10 @@ func (p *Parser) ParseFile(path string) (*OpenAPISpec, error) {
 
 // ParseBytes parses an OpenAPI spec from bytes.
 func (p *Parser) ParseBytes(data []byte) (*OpenAPISpec, error) {
+	if isSwagger2(data) {
+		return p.parseSwagger2(data)
+	}
+
 	loader := openapi3.NewLoader()
 
 	doc, err := loader.LoadFromData(data)
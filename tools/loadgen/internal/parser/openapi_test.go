@@ -3,6 +3,7 @@ package parser
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -83,6 +84,84 @@ paths:
 	assert.Contains(t, ep.Tags, "users")
 }
 
+func TestParser_ParseBytes_Swagger2(t *testing.T) {
+	spec := `
+swagger: "2.0"
+info:
+  title: Legacy API
+  version: "1.0"
+host: api.example.com
+basePath: /v1
+schemes: [https]
+securityDefinitions:
+  BearerAuth:
+    type: apiKey
+    in: header
+    name: Authorization
+paths:
+  /users/{id}:
+    put:
+      operationId: updateUser
+      security:
+        - BearerAuth: []
+      consumes: [application/json]
+      produces: [application/json]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+          format: uuid
+        - name: body
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/User'
+      responses:
+        200:
+          description: OK
+          schema:
+            $ref: '#/definitions/User'
+definitions:
+  User:
+    type: object
+    required: [email]
+    properties:
+      id:
+        type: string
+      email:
+        type: string
+        format: email
+`
+	p := NewParser()
+	result, err := p.ParseBytes([]byte(spec))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Legacy API", result.Title)
+	assert.Equal(t, "https://api.example.com/v1", result.Host)
+	require.Contains(t, result.SecurityDefinitions, "BearerAuth")
+	assert.Equal(t, "Authorization", result.SecurityDefinitions["BearerAuth"].Name)
+
+	require.Len(t, result.Endpoints, 1)
+	ep := result.Endpoints[0]
+	assert.Equal(t, "PUT", ep.Method)
+	assert.True(t, ep.RequiresAuth)
+	assert.Equal(t, []int{200}, ep.SuccessStatusCodes)
+
+	id := findInputPinByName(ep.InputPins, "id")
+	require.NotNil(t, id)
+	assert.Equal(t, ParameterLocationPath, id.Location)
+	assert.Equal(t, "uuid", id.Format)
+
+	body := findInputPinByLocation(ep.InputPins, ParameterLocationBody)
+	require.NotNil(t, body)
+	require.NotNil(t, body.Schema)
+	assert.Equal(t, []string{"email"}, body.Schema.Required)
+	assert.Contains(t, body.Schema.Properties, "email")
+
+	assert.NotEmpty(t, ep.OutputPins, "response $ref is resolved")
+}
+
 func TestParser_ParseBytes_OpenAPI3_WithParameters(t *testing.T) {
 	spec := `
 openapi: "3.0.0"
@@ -802,7 +881,7 @@ func TestParser_ParseFile_ERPSwagger(t *testing.T) {
 	require.NoError(t, err)
 
 	// Basic assertions about ERP API
-	assert.True(t, result.Version == "3.1.0" || result.Version == "3.0.0", "Expected OpenAPI 3.x version")
+	assert.True(t, strings.HasPrefix(result.Version, "3."), "Expected OpenAPI 3.x version (Swagger 2.0 is converted)")
 	assert.Equal(t, "ERP Backend API", result.Title)
 
 	// Should have many endpoints
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// isSwagger2 reports whether data is a Swagger 2.0 document (YAML or JSON).
+func isSwagger2(data []byte) bool {
+	var header struct {
+		Swagger string `yaml:"swagger"`
+	}
+	return yaml.Unmarshal(data, &header) == nil && header.Swagger == "2.0"
+}
+
+// parseSwagger2 converts a Swagger 2.0 document to OpenAPI 3 and parses that.
+func (p *Parser) parseSwagger2(data []byte) (*OpenAPISpec, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	jsonData, err := json.Marshal(jsonCompatible(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+
+	var doc2 openapi2.T
+	if err := json.Unmarshal(jsonData, &doc2); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	doc, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("%w: converting Swagger 2.0: %v", ErrInvalidSpec, err)
+	}
+	if err := openapi3.NewLoader().ResolveRefsIn(doc, nil); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+
+	return p.convertDoc(doc)
+}
+
+// jsonCompatible converts YAML maps with non-string keys (e.g. unquoted
+// response codes) into maps encoding/json can marshal.
+func jsonCompatible(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = jsonCompatible(item)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[fmt.Sprint(k)] = jsonCompatible(item)
+		}
+		return m
+	case []any:
+		for i, item := range v {
+			v[i] = jsonCompatible(item)
+		}
+		return v
+	}
+	return v
+}
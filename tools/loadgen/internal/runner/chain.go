@@ -0,0 +1,243 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/example/erp/tools/loadgen/internal/circuit"
+	"github.com/example/erp/tools/loadgen/internal/config"
+)
+
+// vuContext holds the values a virtual user captured from its own responses,
+// keyed by semantic type. Later steps of a chain read the IDs their earlier steps
+// created instead of an arbitrary value from the shared pool.
+//
+// Thread Safety: Not safe for concurrent use; a chain runs its steps sequentially.
+// A nil *vuContext is empty and ignores writes.
+type vuContext struct {
+	values map[circuit.SemanticType]any
+}
+
+// newVUContext creates an empty virtual user context.
+func newVUContext() *vuContext {
+	return &vuContext{values: make(map[circuit.SemanticType]any)}
+}
+
+// get returns the captured value for a semantic type.
+func (vu *vuContext) get(semanticType circuit.SemanticType) (any, bool) {
+	if vu == nil {
+		return nil, false
+	}
+	v, ok := vu.values[semanticType]
+	return v, ok
+}
+
+// set captures a value for a semantic type, replacing any earlier one.
+func (vu *vuContext) set(semanticType circuit.SemanticType, value any) {
+	if vu == nil {
+		return
+	}
+	vu.values[semanticType] = value
+}
+
+// chainStep is one endpoint call in a chain.
+type chainStep struct {
+	endpoint *config.EndpointConfig
+
+	// linked maps each input fed by an earlier step to that step's endpoint name.
+	// Inputs not listed fall back to the shared pool and generated values.
+	linked map[circuit.SemanticType]string
+}
+
+// chain is a scenario run as a sequence of dependent requests by one virtual user.
+type chain struct {
+	name   string
+	weight int
+	steps  []chainStep
+}
+
+// buildChains builds a chain for each scenario marked as one. Step inputs are
+// linked to the latest earlier step that produces them, following the
+// producer-consumer connections of the endpoint dependency graph.
+// Chains that include a disabled endpoint are skipped.
+func buildChains(cfg *config.Config) ([]chain, error) {
+	graph := circuit.NewDependencyGraph()
+	for i := range cfg.Endpoints {
+		graph.AddEndpoint(endpointUnit(&cfg.Endpoints[i]))
+	}
+
+	var chains []chain
+scenarios:
+	for _, s := range cfg.Scenarios {
+		if !s.Chain {
+			continue
+		}
+
+		c := chain{name: s.Name, weight: s.Weight}
+		position := make(map[string]int)
+		for i, name := range s.Endpoints {
+			ep := cfg.GetEndpointByName(name)
+			if ep == nil {
+				return nil, fmt.Errorf("scenario %s: unknown endpoint %s", s.Name, name)
+			}
+			if ep.Disabled {
+				continue scenarios
+			}
+
+			step := chainStep{endpoint: ep, linked: make(map[circuit.SemanticType]string)}
+			for _, input := range inputPins(ep) {
+				latest := -1
+				for _, producer := range graph.GetProducers(input) {
+					if idx, ok := position[producer.Name]; ok && idx > latest {
+						latest = idx
+						step.linked[input] = producer.Name
+					}
+				}
+			}
+			c.steps = append(c.steps, step)
+			position[name] = i
+		}
+
+		if err := c.checkOrder(); err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", s.Name, err)
+		}
+		chains = append(chains, c)
+	}
+
+	return chains, nil
+}
+
+// checkOrder rejects a chain where a step consumes a value that only a later step produces.
+func (c *chain) checkOrder() error {
+	for i, step := range c.steps {
+		for _, input := range inputPins(step.endpoint) {
+			if _, ok := step.linked[input]; ok {
+				continue
+			}
+			for _, later := range c.steps[i+1:] {
+				if slices.Contains(outputPins(later.endpoint), input) {
+					return fmt.Errorf("step %s consumes %s, which is only produced by later step %s",
+						step.endpoint.Name, input, later.endpoint.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// runChain runs one iteration of a chain as a fresh virtual user.
+// The first step uses the rate limiter slot the load loop already acquired and
+// each later step acquires its own. The chain stops at the first failed step,
+// since the steps after it would be fed values that were never created.
+func (r *Runner) runChain(ctx context.Context, c *chain) {
+	vu := newVUContext()
+	for i := range c.steps {
+		if i > 0 {
+			if err := r.controller.Acquire(ctx); err != nil {
+				return
+			}
+		}
+		if !r.executeEndpoint(ctx, c.steps[i].endpoint, vu) {
+			return
+		}
+	}
+}
+
+// printChains prints each chain with the connections between its steps.
+func (r *Runner) printChains() {
+	fmt.Printf("  Chains: %d\n", len(r.chains))
+	for _, c := range r.chains {
+		names := make([]string, len(c.steps))
+		for i, step := range c.steps {
+			names[i] = step.endpoint.Name
+		}
+		fmt.Printf("    - %s: %s\n", c.name, strings.Join(names, " → "))
+
+		if r.cfg.Output.Verbose {
+			for _, step := range c.steps {
+				for _, input := range inputPins(step.endpoint) {
+					if from, ok := step.linked[input]; ok {
+						fmt.Printf("        %s ← %s (%s)\n", step.endpoint.Name, from, input)
+					}
+				}
+			}
+		}
+	}
+}
+
+// endpointUnit describes an endpoint's pins for the dependency graph.
+func endpointUnit(ep *config.EndpointConfig) *circuit.EndpointUnit {
+	return &circuit.EndpointUnit{
+		Name:       ep.Name,
+		Path:       ep.Path,
+		Method:     ep.Method,
+		InputPins:  inputPins(ep),
+		OutputPins: outputPins(ep),
+		DependsOn:  ep.DependsOn,
+		Weight:     ep.Weight,
+		Disabled:   ep.Disabled,
+	}
+}
+
+// inputPins returns the semantic types an endpoint consumes: declared consumes,
+// path and query parameters, and body template placeholders.
+func inputPins(ep *config.EndpointConfig) []circuit.SemanticType {
+	var pins []circuit.SemanticType
+	add := func(semanticType circuit.SemanticType) {
+		if semanticType != "" && !slices.Contains(pins, semanticType) {
+			pins = append(pins, semanticType)
+		}
+	}
+
+	for _, semanticType := range ep.Consumes {
+		add(semanticType)
+	}
+	for _, name := range sortedKeys(ep.PathParams) {
+		add(ep.PathParams[name].SemanticType)
+	}
+	for _, name := range sortedKeys(ep.QueryParams) {
+		add(ep.QueryParams[name].SemanticType)
+	}
+	for _, placeholder := range templatePlaceholders(ep.Body) {
+		add(circuit.SemanticType(placeholder))
+	}
+	return pins
+}
+
+// outputPins returns the semantic types an endpoint produces.
+func outputPins(ep *config.EndpointConfig) []circuit.SemanticType {
+	pins := make([]circuit.SemanticType, 0, len(ep.Produces))
+	for _, prod := range ep.Produces {
+		pins = append(pins, prod.SemanticType)
+	}
+	return pins
+}
+
+// templatePlaceholders returns the names of the {{.name}} placeholders in a template.
+func templatePlaceholders(template string) []string {
+	var names []string
+	for {
+		start := strings.Index(template, "{{.")
+		if start == -1 {
+			return names
+		}
+		end := strings.Index(template[start:], "}}")
+		if end == -1 {
+			return names
+		}
+		names = append(names, template[start+3:start+end])
+		template = template[start+end+2:]
+	}
+}
+
+// sortedKeys returns the keys of a parameter map in sorted order.
+func sortedKeys(params map[string]config.ParameterConfig) []string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/example/erp/tools/loadgen/internal/circuit"
+	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/loadctrl"
+	"github.com/example/erp/tools/loadgen/internal/pool"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderID circuit.SemanticType = "entity.order.id"
+
+// orderServer creates orders on POST /orders and records the paths of GET requests.
+type orderServer struct {
+	mu         sync.Mutex
+	created    int
+	gets       []string
+	createCode int
+}
+
+func (s *orderServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodPost {
+		if s.createCode != 0 {
+			w.WriteHeader(s.createCode)
+			return
+		}
+		s.created++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"success":true,"data":{"id":"ord-%d"}}`, s.created)
+		return
+	}
+	s.gets = append(s.gets, r.URL.Path)
+	_, _ = w.Write([]byte(`{"success":true}`))
+}
+
+func (s *orderServer) getPaths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.gets...)
+}
+
+func orderChainConfig(baseURL string) *config.Config {
+	cfg := &config.Config{
+		Name:     "chain",
+		Target:   config.TargetConfig{BaseURL: baseURL, Timeout: 5 * time.Second},
+		Duration: time.Second,
+		Endpoints: []config.EndpointConfig{
+			{
+				Name:   "orders.create",
+				Path:   "/orders",
+				Method: "POST",
+				Body:   `{"customer_id":"{{.entity.customer.id}}"}`,
+				Produces: []config.ProducesConfig{
+					{SemanticType: orderID, JSONPath: "$.data.id"},
+				},
+			},
+			{
+				Name:   "orders.get",
+				Path:   "/orders/{id}",
+				Method: "GET",
+				PathParams: map[string]config.ParameterConfig{
+					"id": {SemanticType: orderID},
+				},
+			},
+		},
+		Scenarios: []config.ScenarioConfig{
+			{Name: "order_flow", Endpoints: []string{"orders.create", "orders.get"}, Chain: true},
+		},
+		TrafficShaper: loadctrl.ShaperConfig{Type: "constant", BaseQPS: 1000},
+	}
+	cfg.ApplyDefaults()
+	return cfg
+}
+
+func TestBuildChains_LinksConsumerToEarlierProducer(t *testing.T) {
+	chains, err := buildChains(orderChainConfig("http://localhost:8080"))
+	require.NoError(t, err)
+	require.Len(t, chains, 1)
+
+	c := chains[0]
+	assert.Equal(t, "order_flow", c.name)
+	require.Len(t, c.steps, 2)
+	assert.Empty(t, c.steps[0].linked, "the customer ID has no producer in the chain")
+	assert.Equal(t, map[circuit.SemanticType]string{orderID: "orders.create"}, c.steps[1].linked)
+}
+
+func TestBuildChains_RejectsConsumerBeforeProducer(t *testing.T) {
+	cfg := orderChainConfig("http://localhost:8080")
+	cfg.Scenarios[0].Endpoints = []string{"orders.get", "orders.create"}
+
+	_, err := buildChains(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "step orders.get consumes entity.order.id, which is only produced by later step orders.create")
+}
+
+func TestBuildChains_SkipsDisabledEndpoints(t *testing.T) {
+	cfg := orderChainConfig("http://localhost:8080")
+	cfg.Endpoints[1].Disabled = true
+
+	chains, err := buildChains(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, chains)
+}
+
+func TestRunChain_FeedsProducedValueToNextStep(t *testing.T) {
+	srv := &orderServer{}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	r, err := New(orderChainConfig(server.URL))
+	require.NoError(t, err)
+	require.Len(t, r.chains, 1)
+
+	// A value in the shared pool must not win over the one this virtual user created
+	r.pool.Add(orderID, "ord-stale", pool.ValueSource{Endpoint: "seed"})
+
+	for range 3 {
+		r.runChain(context.Background(), &r.chains[0])
+	}
+
+	assert.Equal(t, []string{"/orders/ord-1", "/orders/ord-2", "/orders/ord-3"}, srv.getPaths())
+	assert.Equal(t, int64(6), r.stats.SuccessRequests)
+}
+
+func TestRunChain_StopsAfterFailedStep(t *testing.T) {
+	srv := &orderServer{createCode: http.StatusInternalServerError}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	r, err := New(orderChainConfig(server.URL))
+	require.NoError(t, err)
+
+	r.runChain(context.Background(), &r.chains[0])
+
+	assert.Empty(t, srv.getPaths())
+	assert.Equal(t, int64(1), r.stats.TotalRequests)
+	assert.Equal(t, int64(1), r.stats.FailedRequests)
+}
+
+func TestBuildRequest_FallsBackWithoutProducer(t *testing.T) {
+	r, err := New(orderChainConfig("http://localhost:8080"))
+	require.NoError(t, err)
+	ep := r.cfg.GetEndpointByName("orders.get")
+
+	// Nothing produced yet: a generated ID stands in
+	req, err := r.buildRequest(context.Background(), ep, newVUContext())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(req.URL.Path, "/orders/"), req.URL.Path)
+	_, err = uuid.Parse(strings.TrimPrefix(req.URL.Path, "/orders/"))
+	assert.NoError(t, err, req.URL.Path)
+
+	// The shared pool is used before the generator
+	r.pool.Add(orderID, "ord-pooled", pool.ValueSource{Endpoint: "seed"})
+	req, err = r.buildRequest(context.Background(), ep, newVUContext())
+	require.NoError(t, err)
+	assert.Equal(t, "/orders/ord-pooled", req.URL.Path)
+
+	// The virtual user's own value is used before the pool
+	vu := newVUContext()
+	vu.set(orderID, "ord-own")
+	req, err = r.buildRequest(context.Background(), ep, vu)
+	require.NoError(t, err)
+	assert.Equal(t, "/orders/ord-own", req.URL.Path)
+}
@@ -0,0 +1,48 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/example/erp/tools/loadgen/internal/circuit"
+	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/generator"
+)
+
+// newGeneratorRegistry builds the registry of configured dataGenerators,
+// keyed by semantic type.
+func newGeneratorRegistry(configs map[string]config.GeneratorConfig) (*generator.Registry, error) {
+	converted := make(map[string]generator.Config, len(configs))
+	for name, c := range configs {
+		gc := generator.Config{Type: generator.GeneratorType(c.Type)}
+		if c.Faker != nil {
+			gc.Faker = (*generator.FakerConfig)(c.Faker)
+		}
+		if c.Random != nil {
+			gc.Random = (*generator.RandomConfig)(c.Random)
+		}
+		if c.Sequence != nil {
+			gc.Sequence = (*generator.SequenceConfig)(c.Sequence)
+		}
+		if c.Pattern != nil {
+			gc.Pattern = (*generator.PatternConfig)(c.Pattern)
+		}
+		converted[name] = gc
+	}
+
+	registry := generator.NewRegistry()
+	if err := registry.LoadFromConfig(converted); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// generate produces a value for an input no producer has supplied: a
+// configured generator for the semantic type, the built-in rule for it, or a
+// value matching dataType and format.
+func (r *Runner) generate(semanticType circuit.SemanticType, dataType, format string) string {
+	value, err := r.generators.ForSemanticType(semanticType, dataType, format).Generate()
+	if err != nil {
+		value, _ = generator.ForDataType(dataType, format).Generate()
+	}
+	return fmt.Sprintf("%v", value)
+}
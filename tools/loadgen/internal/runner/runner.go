@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"os/signal"
 	"strings"
 	"sync"
@@ -18,7 +17,9 @@ import (
 	"github.com/example/erp/tools/loadgen/internal/circuit"
 	"github.com/example/erp/tools/loadgen/internal/client"
 	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/generator"
 	"github.com/example/erp/tools/loadgen/internal/loadctrl"
+	"github.com/example/erp/tools/loadgen/internal/metrics"
 	"github.com/example/erp/tools/loadgen/internal/pool"
 )
 
@@ -31,6 +32,16 @@ type Runner struct {
 	metrics    loadctrl.MetricsCollector
 	controller *loadctrl.LoadController
 	workerPool *loadctrl.WorkerPool
+	picker     *weightedPicker[config.EndpointConfig]
+	generators *generator.Registry
+
+	// Chain mode: when any scenario is a chain, each load slot starts a chain
+	chains      []chain
+	chainPicker *weightedPicker[chain]
+
+	// Reporting
+	collector  *metrics.Collector
+	prometheus *metrics.PrometheusExporter
 
 	// State
 	running   atomic.Bool
@@ -44,10 +55,11 @@ type Runner struct {
 
 // Stats holds overall test statistics.
 type Stats struct {
-	TotalRequests   int64
-	SuccessRequests int64
-	FailedRequests  int64
-	TotalLatency    int64 // nanoseconds
+	TotalRequests     int64
+	SuccessRequests   int64
+	FailedRequests    int64
+	AssertionFailures int64 // failed requests whose response broke the endpoint's expectations
+	TotalLatency      int64 // nanoseconds
 }
 
 // New creates a new load test runner.
@@ -88,12 +100,15 @@ func New(cfg *config.Config) (*Runner, error) {
 
 	// Create rate limiter
 	rateLimiterCfg := loadctrl.RateLimiterConfig{
-		Type:      "token_bucket",
+		Type:      loadctrl.RateLimiterTokenBucket,
 		QPS:       cfg.TrafficShaper.BaseQPS,
 		BurstSize: 10,
 	}
+	if cfg.RateLimiter.Type != "" {
+		rateLimiterCfg.Type = cfg.RateLimiter.Type
+	}
 	if cfg.RateLimiter.QPS > 0 {
-		rateLimiterCfg.QPS = cfg.RateLimiter.QPS
+		rateLimiterCfg.QPS = min(rateLimiterCfg.QPS, cfg.RateLimiter.QPS)
 	}
 	if cfg.RateLimiter.BurstSize > 0 {
 		rateLimiterCfg.BurstSize = cfg.RateLimiter.BurstSize
@@ -124,25 +139,48 @@ func New(cfg *config.Config) (*Runner, error) {
 	}
 	workerPool := loadctrl.NewWorkerPool(workerPoolCfg)
 
-	// Create load controller
-	controllerCfg := loadctrl.LoadControllerConfig{
-		AdjustInterval: 100 * time.Millisecond,
+	// Create load controller (unset intervals and factors fall back to controller defaults)
+	controller := loadctrl.NewLoadController(rateLimiter, shaper, workerPool, metricsCollector, cfg.Controller)
+
+	// Create report collector and, when enabled, the Prometheus exporter
+	var exporter *metrics.PrometheusExporter
+	if cfg.Output.Prometheus.Enabled {
+		exporter = metrics.NewPrometheusExporter(metrics.PrometheusExporterConfig{
+			Port: cfg.Output.Prometheus.Port,
+			Path: cfg.Output.Prometheus.Path,
+		})
+	}
+
+	chains, err := buildChains(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("building chains: %w", err)
+	}
+
+	generators, err := newGeneratorRegistry(cfg.DataGenerators)
+	if err != nil {
+		return nil, fmt.Errorf("creating data generators: %w", err)
 	}
-	controller := loadctrl.NewLoadController(rateLimiter, shaper, workerPool, metricsCollector, controllerCfg)
 
 	return &Runner{
-		cfg:        cfg,
-		httpClient: httpClient,
-		rawClient:  rawClient,
-		pool:       paramPool,
-		metrics:    metricsCollector,
-		controller: controller,
-		workerPool: workerPool,
-		stopCh:     make(chan struct{}),
+		cfg:         cfg,
+		httpClient:  httpClient,
+		rawClient:   rawClient,
+		pool:        paramPool,
+		metrics:     metricsCollector,
+		controller:  controller,
+		workerPool:  workerPool,
+		picker:      newEndpointPicker(cfg.GetEnabledEndpoints(), nil),
+		generators:  generators,
+		chains:      chains,
+		chainPicker: newWeightedPicker(chains, func(c chain) int { return c.weight }, nil),
+		collector:   metrics.NewCollector(metrics.DefaultCollectorConfig()),
+		prometheus:  exporter,
+		stopCh:      make(chan struct{}),
 	}, nil
 }
 
 // Run executes the load test.
+// Authentication and warmup run first; the configured duration applies to the load phase only.
 func (r *Runner) Run(ctx context.Context) error {
 	if r.running.Swap(true) {
 		return fmt.Errorf("runner is already running")
@@ -152,13 +190,8 @@ func (r *Runner) Run(ctx context.Context) error {
 	r.startTime = time.Now()
 
 	// Handle interrupt signals
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	defer signal.Stop(sigCh)
-
-	// Create cancellable context
-	ctx, cancel := context.WithTimeout(ctx, r.cfg.Duration)
-	defer cancel()
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// Print banner
 	r.printBanner()
@@ -172,48 +205,71 @@ func (r *Runner) Run(ctx context.Context) error {
 
 	// Phase 2: Warmup
 	fmt.Println("\n[Phase 2] Warmup...")
-	if err := r.warmup(ctx); err != nil {
+	warmupCtx, cancelWarmup := ctx, context.CancelFunc(func() {})
+	if r.cfg.Warmup.Timeout > 0 {
+		warmupCtx, cancelWarmup = context.WithTimeout(ctx, r.cfg.Warmup.Timeout)
+	}
+	err := r.warmup(warmupCtx)
+	cancelWarmup()
+	if err != nil {
 		fmt.Printf("  ⚠ Warmup completed with errors: %v\n", err)
 	} else {
 		fmt.Println("  ✓ Warmup complete")
 	}
 	r.printPoolStatus()
 
+	if ctx.Err() != nil {
+		fmt.Println("\n  Interrupted before the load phase")
+		return nil
+	}
+
 	// Phase 3: Load test
+	if r.prometheus != nil {
+		if err := r.prometheus.Start(); err != nil {
+			return err
+		}
+		fmt.Printf("\n  Prometheus metrics at %s\n", r.prometheus.GetAddress())
+	}
+
 	fmt.Println("\n[Phase 3] Running load test...")
 	fmt.Printf("  Duration: %v\n", r.cfg.Duration)
 	fmt.Printf("  Target QPS: %.1f\n", r.cfg.TrafficShaper.BaseQPS)
+	if len(r.chains) > 0 {
+		r.printChains()
+	}
 	fmt.Println()
 
+	loadCtx, cancel := context.WithTimeout(ctx, r.cfg.Duration)
+	defer cancel()
+
 	// Start components
-	r.controller.Start(ctx)
+	r.startTime = time.Now()
+	r.collector.Start()
+	r.controller.Start(loadCtx)
 
 	// Run the main load generation loop
 	r.wg.Add(1)
-	go r.runLoadLoop(ctx)
+	go r.runLoadLoop(loadCtx)
 
 	// Progress reporting
 	r.wg.Add(1)
-	go r.runProgressReporter(ctx)
+	go r.runProgressReporter(loadCtx)
 
 	// Wait for completion or interrupt
-	select {
-	case <-ctx.Done():
+	<-loadCtx.Done()
+	if ctx.Err() != nil {
+		fmt.Println("\n  Interrupted, stopping...")
+	} else {
 		fmt.Println("\n  Test duration reached")
-	case sig := <-sigCh:
-		fmt.Printf("\n  Received signal: %v, stopping...\n", sig)
-		cancel()
 	}
 
-	// Stop and cleanup
+	// Stop and cleanup: the controller waits for in-flight requests before the collector is closed
 	close(r.stopCh)
-	r.controller.Stop()
 	r.wg.Wait()
+	r.controller.Stop()
+	r.collector.Stop()
 
-	// Print final report
-	r.printFinalReport()
-
-	return nil
+	return r.report()
 }
 
 // authenticate performs the login flow.
@@ -280,7 +336,7 @@ func (r *Runner) findProducers() map[circuit.SemanticType]*config.EndpointConfig
 
 // executeProducer executes a producer endpoint and extracts values.
 func (r *Runner) executeProducer(ctx context.Context, ep *config.EndpointConfig) ([]any, error) {
-	req, err := r.buildRequest(ctx, ep)
+	req, err := r.buildRequest(ctx, ep, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -326,23 +382,22 @@ func (r *Runner) executeProducer(ctx context.Context, ep *config.EndpointConfig)
 }
 
 // buildRequest builds an HTTP request for an endpoint.
-func (r *Runner) buildRequest(ctx context.Context, ep *config.EndpointConfig) (*http.Request, error) {
+// Semantic values come from vu when it has them, then from the shared pool; vu may be nil.
+// Path parameters and body placeholders nothing supplies are generated.
+func (r *Runner) buildRequest(ctx context.Context, ep *config.EndpointConfig, vu *vuContext) (*http.Request, error) {
 	path := ep.Path
 
 	// Replace path parameters
 	for paramName, paramCfg := range ep.PathParams {
 		var value string
 		if paramCfg.SemanticType != "" {
-			poolValue, err := r.pool.Get(circuit.SemanticType(paramCfg.SemanticType))
-			if err == nil {
-				value = fmt.Sprintf("%v", poolValue.Data)
-			}
+			value, _ = r.lookup(vu, paramCfg.SemanticType)
 		}
 		if value == "" && paramCfg.Value != "" {
 			value = paramCfg.Value
 		}
 		if value == "" {
-			value = fmt.Sprintf("gen-%d", time.Now().UnixNano()%100000)
+			value = r.generate(paramCfg.SemanticType, paramCfg.Type, paramCfg.Format)
 		}
 		path = strings.ReplaceAll(path, "{"+paramName+"}", value)
 	}
@@ -352,10 +407,7 @@ func (r *Runner) buildRequest(ctx context.Context, ep *config.EndpointConfig) (*
 	for paramName, paramCfg := range ep.QueryParams {
 		var value string
 		if paramCfg.SemanticType != "" {
-			poolValue, err := r.pool.Get(circuit.SemanticType(paramCfg.SemanticType))
-			if err == nil {
-				value = fmt.Sprintf("%v", poolValue.Data)
-			}
+			value, _ = r.lookup(vu, paramCfg.SemanticType)
 		}
 		if value == "" && paramCfg.Value != "" {
 			value = paramCfg.Value
@@ -372,7 +424,7 @@ func (r *Runner) buildRequest(ctx context.Context, ep *config.EndpointConfig) (*
 
 	var bodyReader io.Reader
 	if ep.Body != "" {
-		body := r.expandTemplate(ep.Body)
+		body := r.expandTemplate(ep.Body, vu)
 		bodyReader = strings.NewReader(body)
 	}
 
@@ -394,8 +446,21 @@ func (r *Runner) buildRequest(ctx context.Context, ep *config.EndpointConfig) (*
 	return req, nil
 }
 
+// lookup returns the value for a semantic type, preferring what vu captured
+// from its own earlier requests over the shared pool.
+func (r *Runner) lookup(vu *vuContext, semanticType circuit.SemanticType) (string, bool) {
+	if v, ok := vu.get(semanticType); ok {
+		return fmt.Sprintf("%v", v), true
+	}
+	poolValue, err := r.pool.Get(semanticType)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%v", poolValue.Data), true
+}
+
 // expandTemplate expands template placeholders in a string.
-func (r *Runner) expandTemplate(template string) string {
+func (r *Runner) expandTemplate(template string, vu *vuContext) string {
 	result := template
 
 	for {
@@ -410,13 +475,9 @@ func (r *Runner) expandTemplate(template string) string {
 		end += start
 
 		placeholder := result[start+3 : end]
-		var value string
-
-		poolValue, err := r.pool.Get(circuit.SemanticType(placeholder))
-		if err == nil {
-			value = fmt.Sprintf("%v", poolValue.Data)
-		} else {
-			value = fmt.Sprintf("gen-%d", time.Now().UnixNano()%100000)
+		value, ok := r.lookup(vu, circuit.SemanticType(placeholder))
+		if !ok {
+			value = r.generate(circuit.SemanticType(placeholder), "", "")
 		}
 
 		result = result[:start] + value + result[end+2:]
@@ -426,27 +487,15 @@ func (r *Runner) expandTemplate(template string) string {
 }
 
 // runLoadLoop is the main load generation loop.
+// Each rate limiter slot runs one weighted random endpoint on the worker pool,
+// or starts a weighted random chain in chain mode.
 func (r *Runner) runLoadLoop(ctx context.Context) {
 	defer r.wg.Done()
 
-	endpoints := r.cfg.GetEnabledEndpoints()
-	if len(endpoints) == 0 {
+	if r.picker.total == 0 {
 		return
 	}
 
-	// Build weighted list
-	var weightedEndpoints []config.EndpointConfig
-	for _, ep := range endpoints {
-		weight := ep.Weight
-		if weight <= 0 {
-			weight = 1
-		}
-		for i := 0; i < weight; i++ {
-			weightedEndpoints = append(weightedEndpoints, ep)
-		}
-	}
-
-	epIdx := 0
 	for {
 		select {
 		case <-ctx.Done():
@@ -462,98 +511,133 @@ func (r *Runner) runLoadLoop(ctx context.Context) {
 				continue
 			}
 
-			// Select an endpoint
-			ep := weightedEndpoints[epIdx%len(weightedEndpoints)]
-			epIdx++
+			// Select a chain or an endpoint
+			var run func(context.Context)
+			if c := r.chainPicker.pick(); c != nil {
+				run = func(runCtx context.Context) { r.runChain(runCtx, c) }
+			} else {
+				ep := r.picker.pick()
+				run = func(runCtx context.Context) { r.executeEndpoint(runCtx, ep, nil) }
+			}
 
 			// Submit task to worker pool
-			epCopy := ep
 			task := func(taskCtx context.Context) error {
-				r.executeEndpoint(taskCtx, &epCopy)
+				run(taskCtx)
 				return nil
 			}
 
 			if !r.workerPool.Submit(task) {
 				// Queue full, execute synchronously
-				r.executeEndpoint(ctx, &epCopy)
+				run(ctx)
 			}
 		}
 	}
 }
 
-// executeEndpoint executes a single endpoint request.
-func (r *Runner) executeEndpoint(ctx context.Context, ep *config.EndpointConfig) {
+// executeEndpoint executes a single endpoint request and reports whether it succeeded.
+// Produced values go to the shared pool and, when vu is not nil, to vu.
+func (r *Runner) executeEndpoint(ctx context.Context, ep *config.EndpointConfig, vu *vuContext) bool {
 	startTime := time.Now()
+	result := metrics.Result{
+		EndpointName: ep.Name,
+		Method:       ep.Method,
+		Path:         ep.Path,
+		Timestamp:    startTime,
+	}
 
-	req, err := r.buildRequest(ctx, ep)
+	req, err := r.buildRequest(ctx, ep, vu)
 	if err != nil {
-		atomic.AddInt64(&r.stats.TotalRequests, 1)
-		atomic.AddInt64(&r.stats.FailedRequests, 1)
-		r.metrics.RecordLatency(time.Since(startTime))
-		r.metrics.RecordError()
-		return
+		result.Latency = time.Since(startTime)
+		result.Error = err
+		r.record(result)
+		return false
 	}
 
 	if authMgr := r.httpClient.GetAuthManager(); authMgr != nil && isAuthRequired(ep) {
 		if err := authMgr.Authenticate(req); err != nil {
-			atomic.AddInt64(&r.stats.TotalRequests, 1)
-			atomic.AddInt64(&r.stats.FailedRequests, 1)
-			r.metrics.RecordLatency(time.Since(startTime))
-			r.metrics.RecordError()
-			return
+			result.Latency = time.Since(startTime)
+			result.Error = err
+			r.record(result)
+			return false
 		}
 	}
 
 	resp, err := r.rawClient.Do(req)
-	latency := time.Since(startTime)
-
-	atomic.AddInt64(&r.stats.TotalRequests, 1)
-	atomic.AddInt64(&r.stats.TotalLatency, int64(latency))
-	r.metrics.RecordLatency(latency)
-
 	if err != nil {
-		atomic.AddInt64(&r.stats.FailedRequests, 1)
-		r.metrics.RecordError()
-		return
+		result.Latency = time.Since(startTime)
+		result.Error = err
+		r.record(result)
+		return false
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
+	result.Latency = time.Since(startTime)
+	result.StatusCode = resp.StatusCode
+	result.ResponseSize = int64(len(body))
+	result.Success, result.Error = checkResponse(ep, resp.StatusCode, body)
+	result.AssertionFailed = result.Error != nil
+	r.record(result)
+
+	if result.Success && len(ep.Produces) > 0 {
+		for _, prod := range ep.Produces {
+			extracted := extractJSONPath(body, prod.JSONPath, prod.Multiple)
+			if len(extracted) > 0 {
+				vu.set(prod.SemanticType, extracted[0])
+			}
+			for _, v := range extracted {
+				r.pool.Add(
+					circuit.SemanticType(prod.SemanticType),
+					v,
+					pool.ValueSource{
+						Endpoint:      ep.Name,
+						ResponseField: prod.JSONPath,
+					},
+				)
+			}
+		}
+	}
+	return result.Success
+}
 
-	success := resp.StatusCode >= 200 && resp.StatusCode < 400
-
-	if success {
+// record feeds a request result to the run statistics, the load controller,
+// the report collector and, when enabled, the Prometheus exporter.
+func (r *Runner) record(result metrics.Result) {
+	atomic.AddInt64(&r.stats.TotalRequests, 1)
+	atomic.AddInt64(&r.stats.TotalLatency, int64(result.Latency))
+	r.metrics.RecordLatency(result.Latency)
+	if result.Success {
 		atomic.AddInt64(&r.stats.SuccessRequests, 1)
 		r.metrics.RecordSuccess()
-
-		if len(ep.Produces) > 0 {
-			for _, prod := range ep.Produces {
-				extracted := extractJSONPath(body, prod.JSONPath, prod.Multiple)
-				for _, v := range extracted {
-					r.pool.Add(
-						circuit.SemanticType(prod.SemanticType),
-						v,
-						pool.ValueSource{
-							Endpoint:      ep.Name,
-							ResponseField: prod.JSONPath,
-						},
-					)
-				}
-			}
-		}
 	} else {
 		atomic.AddInt64(&r.stats.FailedRequests, 1)
 		r.metrics.RecordError()
 	}
+	if result.AssertionFailed {
+		atomic.AddInt64(&r.stats.AssertionFailures, 1)
+	}
+
+	r.collector.Record(result)
+	if r.prometheus != nil {
+		r.prometheus.RecordRequest(result)
+	}
 }
 
 // runProgressReporter reports progress periodically.
 func (r *Runner) runProgressReporter(ctx context.Context) {
 	defer r.wg.Done()
 
-	ticker := time.NewTicker(5 * time.Second)
+	interval := r.cfg.Output.ReportInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	// Prometheus gauges are refreshed every second regardless of the console interval
+	gaugeTicker := time.NewTicker(time.Second)
+	defer gaugeTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -562,10 +646,24 @@ func (r *Runner) runProgressReporter(ctx context.Context) {
 			return
 		case <-ticker.C:
 			r.printProgress()
+		case <-gaugeTicker.C:
+			r.updateGauges()
 		}
 	}
 }
 
+// updateGauges refreshes the Prometheus gauges from the collector and controller.
+func (r *Runner) updateGauges() {
+	if r.prometheus == nil {
+		return
+	}
+	r.prometheus.UpdateCurrentQPS(r.collector.GetCurrentQPS())
+	r.prometheus.UpdateSuccessRate(r.collector.GetSuccessRate())
+	r.prometheus.UpdateTargetQPS(r.controller.TargetQPS())
+	r.prometheus.UpdateActiveWorkers(r.workerPool.CurrentSize())
+	r.prometheus.UpdatePoolSize(int(r.pool.Stats().TotalValues))
+}
+
 // printBanner prints the test banner.
 func (r *Runner) printBanner() {
 	fmt.Println("╔════════════════════════════════════════════════════════════╗")
@@ -609,44 +707,75 @@ func (r *Runner) printProgress() {
 		elapsed.Round(time.Second), total, qps, successRate, stats.P95Latency)
 }
 
-// printFinalReport prints the final test report.
-func (r *Runner) printFinalReport() {
-	elapsed := time.Since(r.startTime)
-	total := atomic.LoadInt64(&r.stats.TotalRequests)
-	success := atomic.LoadInt64(&r.stats.SuccessRequests)
-	failed := atomic.LoadInt64(&r.stats.FailedRequests)
-	totalLatency := atomic.LoadInt64(&r.stats.TotalLatency)
+// report prints the final console report, writes the JSON report when enabled
+// and shuts down the Prometheus exporter.
+func (r *Runner) report() error {
+	snapshot := r.collector.Snapshot()
 
-	var avgLatency time.Duration
-	if total > 0 {
-		avgLatency = time.Duration(totalLatency / total)
+	consoleCfg := metrics.DefaultConsoleConfig()
+	consoleCfg.TotalDuration = r.cfg.Duration
+	if r.cfg.Output.Console.Enabled == nil || *r.cfg.Output.Console.Enabled {
+		metrics.NewConsole(consoleCfg).PrintFinalReport(snapshot)
 	}
 
-	successRate := float64(0)
-	if total > 0 {
-		successRate = float64(success) / float64(total) * 100
+	var reportErr error
+	if r.cfg.Output.JSON.Enabled {
+		reportErr = r.writeJSONReport(snapshot)
 	}
 
-	qps := float64(total) / elapsed.Seconds()
+	if r.prometheus != nil {
+		r.updateGauges()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := r.prometheus.Stop(shutdownCtx); err != nil {
+			fmt.Printf("  ⚠ Stopping Prometheus exporter: %v\n", err)
+		}
+	}
 
-	stats := r.metrics.GetStats()
+	return reportErr
+}
 
-	fmt.Println()
-	fmt.Println("╔════════════════════════════════════════════════════════════╗")
-	fmt.Println("║                    LOAD TEST RESULTS                       ║")
-	fmt.Println("╠════════════════════════════════════════════════════════════╣")
-	fmt.Printf("║  Duration:       %-42s ║\n", elapsed.Round(time.Second))
-	fmt.Printf("║  Total Requests: %-42d ║\n", total)
-	fmt.Printf("║  Successful:     %-42d ║\n", success)
-	fmt.Printf("║  Failed:         %-42d ║\n", failed)
-	fmt.Printf("║  QPS:            %-42.2f ║\n", qps)
-	fmt.Printf("║  Success Rate:   %-41.2f%% ║\n", successRate)
-	fmt.Println("╠════════════════════════════════════════════════════════════╣")
-	fmt.Printf("║  Avg Latency:    %-42s ║\n", avgLatency)
-	fmt.Printf("║  P50 Latency:    %-42s ║\n", stats.P50Latency)
-	fmt.Printf("║  P95 Latency:    %-42s ║\n", stats.P95Latency)
-	fmt.Printf("║  P99 Latency:    %-42s ║\n", stats.P99Latency)
-	fmt.Println("╚════════════════════════════════════════════════════════════╝")
+// writeJSONReport writes the JSON report to the configured file.
+func (r *Runner) writeJSONReport(snapshot metrics.Snapshot) error {
+	path := r.cfg.Output.JSON.File
+	if path == "" {
+		path = "./results/loadgen-{{.Timestamp}}.json"
+	}
+
+	rateLimiterType := r.cfg.RateLimiter.Type
+	if rateLimiterType == "" {
+		rateLimiterType = loadctrl.RateLimiterTokenBucket
+	}
+	fillTypes := make([]string, len(r.cfg.Warmup.Fill))
+	for i, st := range r.cfg.Warmup.Fill {
+		fillTypes[i] = string(st)
+	}
+
+	reporter := metrics.NewReporter()
+	report := reporter.GenerateReport(snapshot, metrics.ReportOptions{
+		ConfigName:            r.cfg.Name,
+		ConfigDescription:     r.cfg.Description,
+		TargetBaseURL:         r.cfg.Target.BaseURL,
+		TestDuration:          r.cfg.Duration,
+		TrafficShaperType:     r.cfg.TrafficShaper.Type,
+		TrafficShaperBaseQPS:  r.cfg.TrafficShaper.BaseQPS,
+		RateLimiterType:       string(rateLimiterType),
+		RateLimiterQPS:        r.cfg.RateLimiter.QPS,
+		RateLimiterBurst:      r.cfg.RateLimiter.BurstSize,
+		WorkerPoolMinSize:     r.workerPool.MinSize(),
+		WorkerPoolMaxSize:     r.workerPool.MaxSize(),
+		WorkerPoolInitialSize: r.cfg.WorkerPool.InitialSize,
+		EndpointCount:         len(r.cfg.GetEnabledEndpoints()),
+		TotalWeight:           r.picker.total,
+		WarmupEnabled:         r.cfg.Warmup.Iterations > 0,
+		WarmupIterations:      r.cfg.Warmup.Iterations,
+		WarmupFillTypes:       fillTypes,
+	})
+	if err := reporter.WriteToFile(report, path); err != nil {
+		return fmt.Errorf("writing JSON report: %w", err)
+	}
+	fmt.Printf("  ✓ JSON report written to %s\n", path)
+	return nil
 }
 
 // createTrafficShaper creates a traffic shaper from config.
@@ -654,6 +783,13 @@ func createTrafficShaper(cfg *config.Config) (loadctrl.TrafficShaper, error) {
 	shaperCfg := loadctrl.ShaperConfig{
 		Type:    cfg.TrafficShaper.Type,
 		BaseQPS: cfg.TrafficShaper.BaseQPS,
+		MinQPS:  cfg.TrafficShaper.MinQPS,
+		MaxQPS:  cfg.TrafficShaper.MaxQPS,
+	}
+
+	// The rate limiter QPS is a ceiling for the shaped rate
+	if cfg.RateLimiter.QPS > 0 && (shaperCfg.MaxQPS == 0 || cfg.RateLimiter.QPS < shaperCfg.MaxQPS) {
+		shaperCfg.MaxQPS = cfg.RateLimiter.QPS
 	}
 
 	if shaperCfg.Type == "" {
@@ -692,11 +828,16 @@ func createTrafficShaper(cfg *config.Config) (loadctrl.TrafficShaper, error) {
 			shaperCfg.Period = time.Minute
 		}
 	case "spike":
+		if cfg.TrafficShaper.Spike == nil {
+			return nil, fmt.Errorf("spike traffic shaper requires a spike section")
+		}
 		shaperCfg.Spike = &loadctrl.SpikeConfig{
 			SpikeQPS:      cfg.TrafficShaper.Spike.SpikeQPS,
 			SpikeDuration: cfg.TrafficShaper.Spike.SpikeDuration,
 			SpikeInterval: cfg.TrafficShaper.Spike.SpikeInterval,
 		}
+	case "custom":
+		shaperCfg.CustomPoints = cfg.TrafficShaper.CustomPoints
 	}
 
 	return loadctrl.NewTrafficShaper(shaperCfg)
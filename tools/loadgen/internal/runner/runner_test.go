@@ -1,11 +1,21 @@
 package runner
 
 import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/example/erp/tools/loadgen/internal/config"
 	"github.com/example/erp/tools/loadgen/internal/loadctrl"
+	"github.com/example/erp/tools/loadgen/internal/metrics"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -93,3 +103,138 @@ func TestTruncate(t *testing.T) {
 	require.Equal(t, "hello", truncate("hello", 10))
 	require.Equal(t, "hel...", truncate("hello world", 6))
 }
+
+func TestCreateTrafficShaper_QPSProfile(t *testing.T) {
+	type point struct {
+		at  time.Duration
+		qps float64
+	}
+
+	tests := []struct {
+		name   string
+		shaper loadctrl.ShaperConfig
+		limit  float64
+		points []point
+	}{
+		{
+			name:   "constant holds base QPS for the whole run",
+			shaper: loadctrl.ShaperConfig{Type: "constant", BaseQPS: 40},
+			points: []point{{0, 40}, {30 * time.Second, 40}, {59 * time.Second, 40}},
+		},
+		{
+			name: "step ramps linearly then holds each level",
+			shaper: loadctrl.ShaperConfig{Type: "step", BaseQPS: 10, Step: &loadctrl.StepConfig{Steps: []loadctrl.StepLevel{
+				{QPS: 10, Duration: 20 * time.Second},
+				{QPS: 50, Duration: 40 * time.Second, RampDuration: 20 * time.Second},
+			}}},
+			points: []point{{0, 10}, {19 * time.Second, 10}, {30 * time.Second, 30}, {45 * time.Second, 50}},
+		},
+		{
+			name:   "sine oscillates around base QPS by the relative amplitude",
+			shaper: loadctrl.ShaperConfig{Type: "sine", BaseQPS: 100, Amplitude: 0.5, Period: time.Minute},
+			points: []point{{0, 100}, {15 * time.Second, 150}, {30 * time.Second, 100}, {45 * time.Second, 50}},
+		},
+		{
+			name: "spike bursts at the start of every interval",
+			shaper: loadctrl.ShaperConfig{Type: "spike", BaseQPS: 20, Spike: &loadctrl.SpikeConfig{
+				SpikeQPS: 200, SpikeDuration: 10 * time.Second, SpikeInterval: 30 * time.Second,
+			}},
+			points: []point{{0, 200}, {9 * time.Second, 200}, {10 * time.Second, 20}, {29 * time.Second, 20}, {31 * time.Second, 200}},
+		},
+		{
+			name: "rate limiter QPS caps the shaped peak",
+			shaper: loadctrl.ShaperConfig{Type: "spike", BaseQPS: 20, Spike: &loadctrl.SpikeConfig{
+				SpikeQPS: 200, SpikeDuration: 10 * time.Second, SpikeInterval: 30 * time.Second,
+			}},
+			limit:  80,
+			points: []point{{0, 80}, {15 * time.Second, 20}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Duration:      time.Minute,
+				TrafficShaper: tt.shaper,
+				RateLimiter:   loadctrl.RateLimiterConfig{QPS: tt.limit},
+			}
+
+			shaper, err := createTrafficShaper(cfg)
+			require.NoError(t, err)
+
+			for _, p := range tt.points {
+				assert.InDelta(t, p.qps, shaper.GetTargetQPS(p.at), 0.01, "QPS at %v", p.at)
+			}
+		})
+	}
+
+	t.Run("spike without a spike section is rejected", func(t *testing.T) {
+		_, err := createTrafficShaper(&config.Config{TrafficShaper: loadctrl.ShaperConfig{Type: "spike", BaseQPS: 10}})
+		require.Error(t, err)
+	})
+}
+
+func TestRunner_Run(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	consoleEnabled := false
+	cfg := &config.Config{
+		Name:    "run-test",
+		Version: "1.0",
+		Target: config.TargetConfig{
+			BaseURL: server.URL,
+			Timeout: 5 * time.Second,
+		},
+		Duration: time.Second,
+		Endpoints: []config.EndpointConfig{
+			{Name: "ok", Path: "/ok", Method: "GET", Weight: 3},
+			{Name: "fail", Path: "/fail", Method: "GET", Weight: 1},
+		},
+		TrafficShaper: loadctrl.ShaperConfig{Type: "constant", BaseQPS: 50},
+		Output: config.OutputConfig{
+			Console:    config.ConsoleOutputConfig{Enabled: &consoleEnabled},
+			JSON:       config.JSONOutputConfig{Enabled: true, File: reportPath},
+			Prometheus: config.PrometheusOutputConfig{Enabled: true, Port: freePort(t)},
+		},
+	}
+	cfg.ApplyDefaults()
+
+	r, err := New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, r.Run(context.Background()))
+
+	snapshot := r.collector.Snapshot()
+	require.Positive(t, snapshot.TotalRequests)
+	assert.Positive(t, hits.Load())
+	assert.Positive(t, snapshot.StatusCodes[http.StatusOK])
+	assert.Positive(t, snapshot.StatusCodes[http.StatusInternalServerError])
+	assert.Contains(t, snapshot.EndpointStats, "ok")
+	assert.Contains(t, snapshot.EndpointStats, "fail")
+	assert.False(t, r.prometheus.IsRunning())
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report metrics.JSONReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "run-test", report.Configuration.Name)
+	assert.Equal(t, snapshot.TotalRequests, report.Summary.TotalRequests)
+}
+
+// freePort returns a TCP port that was free a moment ago.
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,58 @@
+package runner
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"github.com/example/erp/tools/loadgen/internal/config"
+)
+
+// weightedPicker selects items at random in proportion to their weight.
+// Items without a positive weight count as weight 1.
+//
+// Thread Safety: Safe for concurrent use when intn is (math/rand/v2.IntN is).
+type weightedPicker[T any] struct {
+	items      []T
+	cumulative []int
+	total      int
+	intn       func(n int) int
+}
+
+// newWeightedPicker creates a picker over items, reading each item's weight with weight.
+// intn returns a random int in [0, n); nil uses math/rand/v2.IntN.
+func newWeightedPicker[T any](items []T, weight func(T) int, intn func(n int) int) *weightedPicker[T] {
+	if intn == nil {
+		intn = rand.IntN
+	}
+
+	p := &weightedPicker[T]{
+		items:      items,
+		cumulative: make([]int, len(items)),
+		intn:       intn,
+	}
+	for i, item := range items {
+		w := weight(item)
+		if w <= 0 {
+			w = 1
+		}
+		p.total += w
+		p.cumulative[i] = p.total
+	}
+	return p
+}
+
+// newEndpointPicker creates a picker over endpoints weighted by their configured weight.
+func newEndpointPicker(endpoints []config.EndpointConfig, intn func(n int) int) *weightedPicker[config.EndpointConfig] {
+	return newWeightedPicker(endpoints, func(ep config.EndpointConfig) int { return ep.Weight }, intn)
+}
+
+// pick returns a weighted random item, or nil if there are none.
+func (p *weightedPicker[T]) pick() *T {
+	if p.total == 0 {
+		return nil
+	}
+	n := p.intn(p.total)
+	// First item whose cumulative weight exceeds n
+	idx := sort.SearchInts(p.cumulative, n+1)
+	return &p.items[idx]
+}
@@ -0,0 +1,48 @@
+package runner
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointPicker_WeightedDistribution(t *testing.T) {
+	endpoints := []config.EndpointConfig{
+		{Name: "list", Weight: 60},
+		{Name: "get", Weight: 30},
+		{Name: "create", Weight: 9},
+		{Name: "unweighted", Weight: 0}, // counts as 1
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	picker := newEndpointPicker(endpoints, rng.IntN)
+	require.Equal(t, 100, picker.total)
+
+	const draws = 200000
+	counts := make(map[string]int)
+	for range draws {
+		counts[picker.pick().Name]++
+	}
+
+	expected := map[string]float64{"list": 0.60, "get": 0.30, "create": 0.09, "unweighted": 0.01}
+	for name, share := range expected {
+		assert.InDelta(t, share, float64(counts[name])/draws, 0.005, "share of %s", name)
+	}
+}
+
+func TestEndpointPicker_Boundaries(t *testing.T) {
+	endpoints := []config.EndpointConfig{
+		{Name: "a", Weight: 2},
+		{Name: "b", Weight: 3},
+	}
+
+	// Draws 0-1 fall in a's range, 2-4 in b's
+	for n, want := range []string{"a", "a", "b", "b", "b"} {
+		picker := newEndpointPicker(endpoints, func(int) int { return n })
+		assert.Equal(t, want, picker.pick().Name, "draw %d", n)
+	}
+
+	assert.Nil(t, newEndpointPicker(nil, nil).pick())
+}
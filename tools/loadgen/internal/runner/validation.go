@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/example/erp/tools/loadgen/internal/config"
+)
+
+// errAssertionFailed marks a response that broke its endpoint's expectations.
+var errAssertionFailed = errors.New("assertion failed")
+
+// checkResponse reports whether a response counts as a success for ep.
+// A non-nil error means the response broke a declared expectation (an
+// assertion failure); a plain false with a nil error is an HTTP error status
+// on an endpoint without declared status codes.
+func checkResponse(ep *config.EndpointConfig, statusCode int, body []byte) (bool, error) {
+	if codes := expectedStatusCodes(ep); len(codes) > 0 {
+		if !slices.Contains(codes, statusCode) {
+			return false, fmt.Errorf("%w: status %d, want one of %v", errAssertionFailed, statusCode, codes)
+		}
+	} else if statusCode < 200 || statusCode >= 400 {
+		return false, nil
+	}
+
+	for _, a := range ep.ResponseAssertions {
+		if err := checkAssertion(a, body); err != nil {
+			return false, fmt.Errorf("%w: %s %w", errAssertionFailed, a.JSONPath, err)
+		}
+	}
+	return true, nil
+}
+
+// expectedStatusCodes returns the status codes ep accepts, or nil for the 2xx/3xx default.
+func expectedStatusCodes(ep *config.EndpointConfig) []int {
+	if ep.ExpectedStatus == 0 {
+		return ep.ExpectedStatusCodes
+	}
+	return append([]int{ep.ExpectedStatus}, ep.ExpectedStatusCodes...)
+}
+
+// checkAssertion checks one body assertion.
+func checkAssertion(a config.ResponseAssertion, body []byte) error {
+	values := extractJSONPath(body, a.JSONPath, false)
+	exists := len(values) > 0 && values[0] != nil
+
+	if a.Exists != nil && !*a.Exists {
+		if exists {
+			return errors.New("is present, want absent")
+		}
+		return nil
+	}
+	if !exists {
+		return errors.New("is missing")
+	}
+
+	value := values[0]
+	if a.Equals != nil && fmt.Sprint(value) != fmt.Sprint(a.Equals) {
+		return fmt.Errorf("= %v, want %v", value, a.Equals)
+	}
+	if a.NotEmpty && isEmpty(value) {
+		return errors.New("is empty")
+	}
+	return nil
+}
+
+// isEmpty reports whether a decoded JSON value is an empty string, array or object.
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/example/erp/tools/loadgen/internal/config"
+	"github.com/example/erp/tools/loadgen/internal/loadctrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cannedOrder = `{"success":true,"data":{"id":"ord-1","status":"confirmed","total":42,"items":[{"sku":"A"}],"tags":[]}}`
+
+func TestCheckResponse(t *testing.T) {
+	absent := false
+
+	tests := []struct {
+		name          string
+		ep            config.EndpointConfig
+		status        int
+		body          string
+		wantSuccess   bool
+		wantAssertion string
+	}{
+		{
+			name:        "no expectations accepts 2xx",
+			status:      http.StatusOK,
+			body:        cannedOrder,
+			wantSuccess: true,
+		},
+		{
+			name:   "no expectations rejects 5xx without an assertion failure",
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:          "status not in expected codes",
+			ep:            config.EndpointConfig{ExpectedStatus: 201, ExpectedStatusCodes: []int{409}},
+			status:        http.StatusOK,
+			body:          cannedOrder,
+			wantAssertion: "status 200, want one of [201 409]",
+		},
+		{
+			name:        "status in expected codes",
+			ep:          config.EndpointConfig{ExpectedStatusCodes: []int{200, 404}},
+			status:      http.StatusNotFound,
+			wantSuccess: true,
+		},
+		{
+			name: "passing body assertions",
+			ep: config.EndpointConfig{ResponseAssertions: []config.ResponseAssertion{
+				{JSONPath: "$.data.status", Equals: "confirmed"},
+				{JSONPath: "$.data.total", Equals: 42},
+				{JSONPath: "$.data.items", NotEmpty: true},
+				{JSONPath: "$.data.error", Exists: &absent},
+			}},
+			status:      http.StatusOK,
+			body:        cannedOrder,
+			wantSuccess: true,
+		},
+		{
+			name: "200 with unexpected value",
+			ep: config.EndpointConfig{ResponseAssertions: []config.ResponseAssertion{
+				{JSONPath: "$.data.status", Equals: "draft"},
+			}},
+			status:        http.StatusOK,
+			body:          cannedOrder,
+			wantAssertion: "$.data.status = confirmed, want draft",
+		},
+		{
+			name: "missing field",
+			ep: config.EndpointConfig{ResponseAssertions: []config.ResponseAssertion{
+				{JSONPath: "$.data.customer_id"},
+			}},
+			status:        http.StatusOK,
+			body:          cannedOrder,
+			wantAssertion: "$.data.customer_id is missing",
+		},
+		{
+			name: "empty array",
+			ep: config.EndpointConfig{ResponseAssertions: []config.ResponseAssertion{
+				{JSONPath: "$.data.tags", NotEmpty: true},
+			}},
+			status:        http.StatusOK,
+			body:          cannedOrder,
+			wantAssertion: "$.data.tags is empty",
+		},
+		{
+			name: "field that must be absent",
+			ep: config.EndpointConfig{ResponseAssertions: []config.ResponseAssertion{
+				{JSONPath: "$.data.id", Exists: &absent},
+			}},
+			status:        http.StatusOK,
+			body:          cannedOrder,
+			wantAssertion: "$.data.id is present, want absent",
+		},
+		{
+			name: "body that is not JSON",
+			ep: config.EndpointConfig{ResponseAssertions: []config.ResponseAssertion{
+				{JSONPath: "$.data.id"},
+			}},
+			status:        http.StatusOK,
+			body:          "<html>",
+			wantAssertion: "$.data.id is missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			success, err := checkResponse(&tt.ep, tt.status, []byte(tt.body))
+			assert.Equal(t, tt.wantSuccess, success)
+			if tt.wantAssertion == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, errAssertionFailed)
+			assert.Contains(t, err.Error(), tt.wantAssertion)
+		})
+	}
+}
+
+func TestExecuteEndpoint_RecordsAssertionFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(cannedOrder))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Name:   "assertions",
+		Target: config.TargetConfig{BaseURL: server.URL, Timeout: 5 * time.Second},
+		Endpoints: []config.EndpointConfig{
+			{
+				Name:   "orders.confirmed",
+				Path:   "/orders/1",
+				Method: "GET",
+				ResponseAssertions: []config.ResponseAssertion{
+					{JSONPath: "$.data.status", Equals: "confirmed"},
+				},
+			},
+			{
+				Name:   "orders.draft",
+				Path:   "/orders/1",
+				Method: "GET",
+				ResponseAssertions: []config.ResponseAssertion{
+					{JSONPath: "$.data.status", Equals: "draft"},
+				},
+			},
+		},
+		TrafficShaper: loadctrl.ShaperConfig{Type: "constant", BaseQPS: 10},
+	}
+	cfg.ApplyDefaults()
+
+	r, err := New(cfg)
+	require.NoError(t, err)
+
+	assert.True(t, r.executeEndpoint(context.Background(), &cfg.Endpoints[0], nil))
+	assert.False(t, r.executeEndpoint(context.Background(), &cfg.Endpoints[1], nil))
+
+	assert.Equal(t, int64(1), r.stats.SuccessRequests)
+	assert.Equal(t, int64(1), r.stats.FailedRequests)
+	assert.Equal(t, int64(1), r.stats.AssertionFailures)
+
+	snapshot := r.collector.Snapshot()
+	assert.Equal(t, int64(1), snapshot.AssertionFailures)
+	assert.Equal(t, int64(2), snapshot.StatusCodes[http.StatusOK], "both responses were 200")
+	assert.Equal(t, int64(0), snapshot.EndpointStats["orders.confirmed"].AssertionFailures)
+	assert.Equal(t, int64(1), snapshot.EndpointStats["orders.draft"].AssertionFailures)
+}